@@ -29,6 +29,7 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
@@ -40,6 +41,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"syscall"
 	"time"
 
@@ -48,13 +50,18 @@ import (
 	"github.com/seatunnel/seatunnelX/agent/internal/config"
 	agentdiagnostics "github.com/seatunnel/seatunnelX/agent/internal/diagnostics"
 	"github.com/seatunnel/seatunnelX/agent/internal/discovery"
+	"github.com/seatunnel/seatunnelX/agent/internal/doctor"
 	"github.com/seatunnel/seatunnelX/agent/internal/executor"
 	agentgrpc "github.com/seatunnel/seatunnelX/agent/internal/grpc"
 	"github.com/seatunnel/seatunnelX/agent/internal/installer"
 	"github.com/seatunnel/seatunnelX/agent/internal/logger"
+	"github.com/seatunnel/seatunnelX/agent/internal/logretention"
 	"github.com/seatunnel/seatunnelX/agent/internal/monitor"
 	"github.com/seatunnel/seatunnelX/agent/internal/process"
 	"github.com/seatunnel/seatunnelX/agent/internal/restart"
+	"github.com/seatunnel/seatunnelX/agent/internal/state"
+	"github.com/seatunnel/seatunnelX/agent/internal/tracing"
+	"github.com/seatunnel/seatunnelX/internal/pkg/installhook"
 	"github.com/seatunnel/seatunnelX/internal/seatunnel"
 	"github.com/spf13/cobra"
 )
@@ -122,10 +129,26 @@ type Agent struct {
 	// errorCollector 处理 Seatunnel ERROR 日志增量采集。
 	errorCollector *agentdiagnostics.Collector
 
+	// resultStore remembers results of executed commands for deduplication
+	// resultStore 记录已执行命令的结果用于去重
+	resultStore *executor.CommandResultStore
+
+	// stateStore persists managed processes and install progress across Agent restarts
+	// stateStore 在 Agent 重启之间持久化托管进程和安装进度
+	stateStore *state.Store
+
+	// configCh delivers AgentConfig pushed by Control Plane to the heartbeat loop
+	// configCh 将 Control Plane 下发的 AgentConfig 传递给心跳循环
+	configCh chan *pb.AgentConfig
+
 	// wg tracks running goroutines for graceful shutdown
 	// wg 跟踪运行中的 goroutine 以实现优雅关闭
 	wg sync.WaitGroup
 
+	// reconnecting is set while the connection monitor runs a reconnect, so ticks do not pile up
+	// reconnecting 在连接监控执行重连期间置位，避免定时触发的重连相互叠加
+	reconnecting atomic.Bool
+
 	// running indicates if the agent is running
 	// running 表示 Agent 是否正在运行
 	running bool
@@ -148,12 +171,26 @@ func NewAgent(cfg *config.Config) *Agent {
 
 	// Create command executor / 创建命令执行器
 	exec := executor.NewCommandExecutor()
+	exec.SetMaxConcurrentCommands(cfg.Agent.MaxConcurrentCommands)
+	resultStore, err := executor.NewCommandResultStore(executor.DefaultCommandResultStorePath)
+	if err != nil {
+		logger.WarnF(ctx, "Failed to load command result store, starting empty: %v / 加载命令结果存储失败，将使用空存储：%v", err, err)
+	}
+	exec.SetResultStore(resultStore)
+
+	// Load persisted Agent state / 加载持久化的 Agent 状态
+	stateStore, err := state.NewStore(state.DefaultStatePath)
+	if err != nil {
+		logger.WarnF(ctx, "Failed to load agent state, starting empty: %v / 加载 Agent 状态失败，将使用空状态：%v", err, err)
+	}
+	pm.SetStateStore(stateStore)
 
 	// Create gRPC client / 创建 gRPC 客户端
 	grpcClient := agentgrpc.NewClient(cfg)
 
 	// Create installer manager / 创建安装管理器
 	im := installer.NewInstallerManager()
+	im.SetStateStore(stateStore)
 
 	// Create process monitor / 创建进程监控器
 	pmon := monitor.NewProcessMonitor()
@@ -180,6 +217,9 @@ func NewAgent(cfg *config.Config) *Agent {
 		autoRestarter:    ar,
 		eventReporter:    er,
 		errorCollector:   ec,
+		resultStore:      resultStore,
+		stateStore:       stateStore,
+		configCh:         make(chan *pb.AgentConfig, 1),
 	}
 }
 
@@ -220,6 +260,10 @@ func (a *Agent) Run() error {
 	// Set up process event handler / 设置进程事件处理器
 	a.processManager.SetEventHandler(a.handleProcessEvent)
 
+	// Re-adopt processes and report installs interrupted by the last Agent stop
+	// 重新接管进程，并上报上次 Agent 停止时中断的安装
+	a.recoverState()
+
 	// Step 2: Start process monitor / 启动进程监控器
 	logger.InfoF(ctx, "[2/8] Starting process monitor... / 启动进程监控器...")
 	a.setupProcessMonitor()
@@ -230,6 +274,11 @@ func (a *Agent) Run() error {
 	// Step 3: Initialize process discovery (simplified, no auto-scan)
 	// 步骤 3：初始化进程发现（简化版，无自动扫描）
 	logger.InfoF(ctx, "[3/8] Initializing process discovery... / 初始化进程发现...")
+	// Report running SeaTunnel installs in heartbeats / 在心跳中上报运行中的 SeaTunnel 安装
+	inventory := discovery.NewInventory(discovery.DefaultInventoryTTL)
+	a.grpcClient.SetSeaTunnelInventoryProvider(func() *pb.SeaTunnelInventory {
+		return seaTunnelInventoryToProto(inventory.Snapshot())
+	})
 
 	// Step 4: Start event reporter / 启动事件上报器
 	logger.InfoF(ctx, "[4/8] Starting event reporter... / 启动事件上报器...")
@@ -251,7 +300,15 @@ func (a *Agent) Run() error {
 	// 步骤 7：向 Control Plane 注册
 	logger.InfoF(ctx, "[7/8] Registering with Control Plane... / 向 Control Plane 注册...")
 	if err := a.registerWithControlPlane(); err != nil {
-		return fmt.Errorf("failed to register with Control Plane: %w / 向 Control Plane 注册失败：%w", err, err)
+		delay, admission := agentgrpc.RegistrationRetryDelay(err)
+		if !admission {
+			return fmt.Errorf("failed to register with Control Plane: %w / 向 Control Plane 注册失败：%w", err, err)
+		}
+		// Pending approval, deny-list or rate limit: keep running and retry in the background
+		// 待审批、拒绝列表或限流：继续运行并在后台重试注册
+		logger.WarnF(ctx, "Registration not admitted yet: %v / 注册尚未获准入：%v", err, err)
+		a.wg.Add(1)
+		go a.retryRegistration(delay)
 	}
 
 	// Step 8: Start background services
@@ -271,6 +328,40 @@ func (a *Agent) Run() error {
 	return nil
 }
 
+// recoverState restores state persisted by the previous Agent run.
+// Running SeaTunnel processes are re-adopted and tracked again. Installations that were in progress
+// are recorded as failed command results, so an INSTALL command re-sent by the Control Plane reports
+// the interruption instead of running twice; a new INSTALL of the same version resumes from the
+// extracted package.
+// recoverState 恢复上一次 Agent 运行持久化的状态。
+// 运行中的 SeaTunnel 进程会被重新接管和跟踪。进行中的安装会记录为失败的命令结果，
+// 使 Control Plane 重发的 INSTALL 命令上报中断而不是重复执行；同版本的新 INSTALL 会从已解压的安装包继续。
+func (a *Agent) recoverState() {
+	ctx := a.ctx
+	for _, info := range a.processManager.RecoverProcesses(ctx) {
+		a.processMonitor.TrackProcessSilent(info.Name, info.PID, info.InstallDir, info.Role, &process.StartParams{
+			InstallDir: info.InstallDir,
+			Role:       info.Role,
+		})
+	}
+
+	for _, rec := range a.installerManager.InterruptedInstalls() {
+		logger.WarnF(ctx, "Installation of %s (version %s) was interrupted at step %s by an Agent restart / %s（版本 %s）的安装在步骤 %s 被 Agent 重启中断",
+			rec.InstallDir, rec.Version, rec.CurrentStep, rec.InstallDir, rec.Version, rec.CurrentStep)
+		if rec.CommandID == "" || a.resultStore == nil {
+			continue
+		}
+		if _, done := a.resultStore.Get(rec.CommandID); done {
+			continue
+		}
+		message := fmt.Sprintf("Installation interrupted by Agent restart at step %s / 安装在步骤 %s 被 Agent 重启中断", rec.CurrentStep, rec.CurrentStep)
+		resp := executor.CreateErrorResponse(rec.CommandID, message)
+		if err := a.resultStore.Put(pb.CommandType_INSTALL, resp); err != nil {
+			logger.WarnF(ctx, "Failed to record interrupted install %s: %v / 记录中断的安装 %s 失败：%v", rec.CommandID, err, rec.CommandID, err)
+		}
+	}
+}
+
 // setupProcessMonitor sets up the process monitor with callbacks
 // setupProcessMonitor 设置进程监控器的回调
 func (a *Agent) setupProcessMonitor() {
@@ -463,14 +554,21 @@ func (a *Agent) registerWithControlPlane() error {
 	hostname := a.metricsCollector.GetHostname()
 	ipAddress := a.metricsCollector.GetIPAddress()
 
+	agentSecret, err := config.LoadAgentSecret(a.config.Agent.SecretFile)
+	if err != nil {
+		logger.WarnF(ctx, "Failed to load agent secret: %v / 加载 Agent 密钥失败：%v", err, err)
+	}
+
 	req := &pb.RegisterRequest{
-		AgentId:      a.config.Agent.ID,
-		Hostname:     hostname,
-		IpAddress:    ipAddress,
-		OsType:       runtime.GOOS,
-		Arch:         runtime.GOARCH,
-		AgentVersion: Version,
-		SystemInfo:   sysInfo,
+		AgentId:         a.config.Agent.ID,
+		Hostname:        hostname,
+		IpAddress:       ipAddress,
+		OsType:          runtime.GOOS,
+		Arch:            runtime.GOARCH,
+		AgentVersion:    Version,
+		SystemInfo:      sysInfo,
+		EnrollmentToken: a.config.Agent.EnrollmentToken,
+		AgentSecret:     agentSecret,
 	}
 
 	resp, err := a.grpcClient.Register(a.ctx, req)
@@ -485,6 +583,14 @@ func (a *Agent) registerWithControlPlane() error {
 	// Save the assigned agent ID / 保存分配的 Agent ID
 	a.grpcClient.SetAgentID(resp.AssignedId)
 
+	// Keep the secret issued on enrollment; later registrations must present it
+	// 保存注册时签发的密钥，后续注册必须出示该密钥
+	if resp.AgentSecret != "" {
+		if err := config.SaveAgentSecret(a.config.Agent.SecretFile, resp.AgentSecret); err != nil {
+			logger.ErrorF(ctx, "Failed to save agent secret, re-enrollment will be required after restart: %v / 保存 Agent 密钥失败，重启后需要重新注册：%v", err, err)
+		}
+	}
+
 	logger.InfoF(ctx, "Registered successfully with ID: %s / 注册成功，ID：%s", resp.AssignedId, resp.AssignedId)
 
 	// Align diagnostics collector cursor with server-side persisted cursor.
@@ -523,6 +629,31 @@ func (a *Agent) registerWithControlPlane() error {
 	return nil
 }
 
+// retryRegistration retries registration until the Control Plane admits the Agent.
+// retryRegistration 重试注册，直到 Control Plane 准入该 Agent。
+func (a *Agent) retryRegistration(delay time.Duration) {
+	defer a.wg.Done()
+	ctx := a.ctx
+	for {
+		select {
+		case <-a.ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+
+		err := a.registerWithControlPlane()
+		if err == nil {
+			logger.InfoF(ctx, "Registration admitted by Control Plane / 注册已获 Control Plane 准入")
+			return
+		}
+		next, admission := agentgrpc.RegistrationRetryDelay(err)
+		if admission {
+			delay = next
+		}
+		logger.WarnF(ctx, "Registration retry failed: %v, next retry in %v / 注册重试失败：%v，%v 后重试", err, delay, err, delay)
+	}
+}
+
 // setupEventReporter sets up the event reporter with gRPC report function.
 // setupEventReporter 设置事件上报器的 gRPC 上报函数。
 func (a *Agent) setupEventReporter() {
@@ -576,26 +707,67 @@ func (a *Agent) setupEventReporter() {
 	logger.InfoF(ctx, "[Agent] Event reporter configured / 事件上报器已配置")
 }
 
-// applyRemoteConfig applies configuration received from Control Plane
-// applyRemoteConfig 应用从 Control Plane 接收的配置
+// applyRemoteConfig queues configuration received from Control Plane for the heartbeat loop.
+// Only the latest pending config is kept; an older one not yet consumed is replaced.
+// applyRemoteConfig 将来自 Control Plane 的配置投递给心跳循环。
+// 仅保留最新的待处理配置，尚未被消费的旧配置会被替换。
 func (a *Agent) applyRemoteConfig(cfg *pb.AgentConfig) {
+	if cfg == nil {
+		return
+	}
+	for {
+		select {
+		case a.configCh <- cfg:
+			return
+		default:
+		}
+		select {
+		case <-a.configCh:
+		default:
+		}
+	}
+}
+
+// reloadRemoteConfig applies a remote AgentConfig to the running agent without restart.
+// It returns the new heartbeat interval and whether it changed.
+// reloadRemoteConfig 在不重启的情况下将远程 AgentConfig 应用到运行中的 Agent。
+// 返回新的心跳间隔以及是否发生变化。
+func (a *Agent) reloadRemoteConfig(cfg *pb.AgentConfig) (time.Duration, bool) {
 	ctx := a.ctx
 	logger.InfoF(ctx, "Received remote config from Control Plane: HeartbeatInterval=%d seconds / 收到来自 Control Plane 的远程配置：HeartbeatInterval=%d 秒", cfg.HeartbeatInterval, cfg.HeartbeatInterval)
 	logger.InfoF(ctx, "Current local heartbeat interval: %v / 当前本地心跳间隔：%v", a.config.Heartbeat.Interval, a.config.Heartbeat.Interval)
 
 	configChanged := false
+	intervalChanged := false
 
 	if cfg.HeartbeatInterval > 0 {
 		newInterval := time.Duration(cfg.HeartbeatInterval) * time.Second
 		if a.config.Heartbeat.Interval != newInterval {
 			a.config.Heartbeat.Interval = newInterval
 			configChanged = true
+			intervalChanged = true
 			logger.InfoF(ctx, "Applied heartbeat interval from Control Plane: %v / 已应用来自 Control Plane 的心跳间隔：%v", newInterval, newInterval)
 		}
 	} else {
 		logger.WarnF(ctx, "Remote HeartbeatInterval is 0 or negative, keeping local config / 远程 HeartbeatInterval 为 0 或负数，保持本地配置")
 	}
 
+	if level := remoteLogLevel(cfg.LogLevel); level != "" && level != a.config.Log.Level {
+		a.config.Log.Level = level
+		logger.SetLevel(level)
+		configChanged = true
+		logger.InfoF(ctx, "Applied log level from Control Plane: %s / 已应用来自 Control Plane 的日志级别：%s", level, level)
+	}
+
+	if raw := strings.TrimSpace(cfg.Extra[remoteConfigKeyMetricsInterval]); raw != "" {
+		if interval, err := parseRemoteInterval(raw); err != nil || interval < time.Second {
+			logger.WarnF(ctx, "Ignoring invalid remote %s=%q / 忽略无效的远程配置 %s=%q", remoteConfigKeyMetricsInterval, raw, remoteConfigKeyMetricsInterval, raw)
+		} else {
+			a.processMonitor.SetMonitorInterval(interval)
+			logger.InfoF(ctx, "Applied metrics collection interval from Control Plane: %v / 已应用来自 Control Plane 的指标采集间隔：%v", interval, interval)
+		}
+	}
+
 	// Persist config changes to local file / 将配置变更持久化到本地文件
 	if configChanged {
 		if err := a.persistConfigToFile(); err != nil {
@@ -604,6 +776,38 @@ func (a *Agent) applyRemoteConfig(cfg *pb.AgentConfig) {
 			logger.InfoF(ctx, "Config persisted to local file / 配置已持久化到本地文件")
 		}
 	}
+
+	return a.config.Heartbeat.Interval, intervalChanged
+}
+
+// remoteConfigKeyMetricsInterval is the AgentConfig.Extra key for the process metrics collection interval.
+// remoteConfigKeyMetricsInterval 是 AgentConfig.Extra 中进程指标采集间隔的键。
+const remoteConfigKeyMetricsInterval = "metrics_interval"
+
+// remoteLogLevel converts the protobuf log level to the agent log level string.
+// remoteLogLevel 将 protobuf 日志级别转换为 Agent 日志级别字符串。
+func remoteLogLevel(level int32) string {
+	switch pb.LogLevel(level) {
+	case pb.LogLevel_DEBUG:
+		return "debug"
+	case pb.LogLevel_INFO:
+		return "info"
+	case pb.LogLevel_WARN:
+		return "warn"
+	case pb.LogLevel_ERROR:
+		return "error"
+	default:
+		return ""
+	}
+}
+
+// parseRemoteInterval parses an interval given either as a Go duration ("30s") or as plain seconds ("30").
+// parseRemoteInterval 解析间隔，支持 Go duration 格式（"30s"）或纯秒数（"30"）。
+func parseRemoteInterval(raw string) (time.Duration, error) {
+	if seconds, err := strconv.Atoi(raw); err == nil {
+		return time.Duration(seconds) * time.Second, nil
+	}
+	return time.ParseDuration(raw)
 }
 
 // persistConfigToFile saves the current config to the local config file
@@ -618,28 +822,14 @@ func (a *Agent) persistConfigToFile() error {
 		return fmt.Errorf("failed to read config file: %w", err)
 	}
 
-	// Update heartbeat interval in the config content
+	// Update hot-reloadable values in the config content
 	// Format: "interval: 10s" -> "interval: 60s"
 	lines := strings.Split(string(content), "\n")
-	inHeartbeatSection := false
-	for i, line := range lines {
-		trimmed := strings.TrimSpace(line)
-		// Detect heartbeat section
-		if strings.Contains(strings.ToLower(trimmed), "heartbeat") {
-			inHeartbeatSection = true
-			continue
-		}
-		// Detect next section (non-indented line that's not empty or comment)
-		if inHeartbeatSection && len(trimmed) > 0 && !strings.HasPrefix(trimmed, "#") && !strings.HasPrefix(line, " ") && !strings.HasPrefix(line, "\t") {
-			inHeartbeatSection = false
-		}
-		// Update interval in heartbeat section
-		if inHeartbeatSection && strings.HasPrefix(trimmed, "interval:") {
-			indent := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
-			lines[i] = fmt.Sprintf("%sinterval: %s", indent, a.config.Heartbeat.Interval.String())
-			logger.InfoF(ctx, "Updated config line: %s", lines[i])
-			break
-		}
+	if setYAMLSectionValue(lines, "heartbeat", "interval", a.config.Heartbeat.Interval.String()) {
+		logger.InfoF(ctx, "Updated config heartbeat.interval: %s", a.config.Heartbeat.Interval.String())
+	}
+	if a.config.Log.Level != "" && setYAMLSectionValue(lines, "log", "level", a.config.Log.Level) {
+		logger.InfoF(ctx, "Updated config log.level: %s", a.config.Log.Level)
 	}
 
 	// Write back to file
@@ -651,6 +841,30 @@ func (a *Agent) persistConfigToFile() error {
 	return nil
 }
 
+// setYAMLSectionValue replaces "key: value" inside a top-level YAML section in place.
+// It returns false when the section or key is not present.
+// setYAMLSectionValue 原地替换顶层 YAML 段内的 "key: value"，段或键不存在时返回 false。
+func setYAMLSectionValue(lines []string, section, key, value string) bool {
+	inSection := false
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		indented := strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")
+		if !indented {
+			inSection = strings.HasPrefix(trimmed, section+":")
+			continue
+		}
+		if inSection && strings.HasPrefix(trimmed, key+":") {
+			indent := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+			lines[i] = fmt.Sprintf("%s%s: %s", indent, key, value)
+			return true
+		}
+	}
+	return false
+}
+
 // startBackgroundServices starts all background goroutines
 // startBackgroundServices 启动所有后台 goroutine
 func (a *Agent) startBackgroundServices() {
@@ -675,10 +889,27 @@ func (a *Agent) startBackgroundServices() {
 		a.runConnectionMonitor()
 	}()
 
+	// Start Control Plane failback probe / 启动 Control Plane 回切探测
+	if len(a.config.ControlPlane.Addresses) > 1 {
+		a.wg.Add(1)
+		go func() {
+			defer a.wg.Done()
+			a.grpcClient.RunFailback(a.ctx, func(addr string) {
+				// Re-register on the preferred endpoint / 在首选端点上重新注册
+				if err := a.registerWithControlPlane(); err != nil {
+					logger.ErrorF(a.ctx, "Re-registration after failback failed: %v / 回切后重新注册失败：%v", err, err)
+				}
+			})
+		}()
+	}
+
 	// Start diagnostics error collector / 启动诊断错误采集器
 	if a.errorCollector != nil {
 		a.errorCollector.Start(a.ctx)
 	}
+
+	// Start log disk quota enforcement / 启动日志磁盘配额清理
+	logretention.NewEnforcer(a.stateStore).Start(a.ctx)
 }
 
 // runHeartbeatLoop runs the heartbeat sending loop
@@ -702,6 +933,13 @@ func (a *Agent) runHeartbeatLoop() {
 		case <-a.ctx.Done():
 			logger.InfoF(ctx, "Heartbeat loop stopped / 心跳循环已停止")
 			return
+		case cfg := <-a.configCh:
+			// Hot-reload config pushed by Control Plane / 热加载 Control Plane 下发的配置
+			if newInterval, changed := a.reloadRemoteConfig(cfg); changed {
+				interval = newInterval
+				ticker.Reset(interval)
+				logger.InfoF(ctx, "Heartbeat interval reloaded: %v / 心跳间隔已热加载：%v", interval, interval)
+			}
 		case <-ticker.C:
 			a.sendHeartbeat()
 		}
@@ -759,6 +997,29 @@ func (a *Agent) sendHeartbeat() {
 	}
 }
 
+// seaTunnelInventoryToProto converts an inventory snapshot for the heartbeat; nil until the first scan succeeds.
+// seaTunnelInventoryToProto 将清单快照转换为心跳字段；首次扫描成功前返回 nil。
+func seaTunnelInventoryToProto(processes []*discovery.DiscoveredProcess, scannedAt time.Time, ok bool) *pb.SeaTunnelInventory {
+	if !ok {
+		return nil
+	}
+	inventory := &pb.SeaTunnelInventory{
+		ScannedAt: scannedAt.UnixMilli(),
+		Installs:  make([]*pb.SeaTunnelInstall, 0, len(processes)),
+	}
+	for _, proc := range processes {
+		inventory.Installs = append(inventory.Installs, &pb.SeaTunnelInstall{
+			InstallDir:    proc.InstallDir,
+			Version:       proc.Version,
+			Role:          proc.Role,
+			Pid:           int32(proc.PID),
+			HazelcastPort: int32(proc.HazelcastPort),
+			HttpPort:      int32(proc.APIPort),
+		})
+	}
+	return inventory
+}
+
 // runCommandStreamLoop runs the command stream listener loop
 // runCommandStreamLoop 运行命令流监听循环
 // Requirements 1.2: Establish bidirectional gRPC stream for commands
@@ -784,6 +1045,11 @@ func (a *Agent) runCommandStreamLoop() {
 
 		// Start command stream / 启动命令流
 		err := a.grpcClient.StartCommandStream(a.ctx, a.handleCommand)
+		if errors.Is(err, agentgrpc.ErrControlPlaneDraining) {
+			// The client waits for the hinted delay before reconnecting / 客户端会按提示的延迟后再重连
+			logger.InfoF(ctx, "Command stream closed by draining Control Plane: %v / 命令流被排空中的 Control Plane 关闭：%v", err, err)
+			continue
+		}
 		if err != nil {
 			logger.ErrorF(ctx, "Command stream error: %v, will retry... / 命令流错误：%v，将重试...", err, err)
 
@@ -815,10 +1081,27 @@ func (a *Agent) handleCommand(ctx context.Context, cmd *pb.CommandRequest) (*pb.
 			resp := executor.CreateProgressResponse(commandID, progress, output)
 			return a.grpcClient.ReportCommandResult(ctx, resp)
 		},
+		OutputCallback: func(commandID string, chunk string) error {
+			return a.grpcClient.ReportCommandResult(ctx, executor.CreateOutputChunkResponse(commandID, chunk))
+		},
 	}
 
 	// Execute the command / 执行命令
 	resp, err := a.executor.Execute(ctx, cmd, reporter)
+	if resp != nil && resp.Status == pb.CommandStatus_CANCELLED {
+		// Report CANCELLED as-is instead of letting the stream turn it into FAILED
+		// 直接上报 CANCELLED，避免命令流将其转换为 FAILED
+		logger.InfoF(ctx, "Command %s (type: %s) cancelled / 命令 %s（类型：%s）已取消",
+			cmd.CommandId, cmd.Type.String(), cmd.CommandId, cmd.Type.String())
+		return resp, nil
+	}
+	if resp != nil && resp.Status == pb.CommandStatus_TIMEOUT {
+		// Report TIMEOUT as-is so the Control Plane can tell deadlines from failures
+		// 直接上报 TIMEOUT，使 Control Plane 能区分超时与失败
+		logger.WarnF(ctx, "Command %s (type: %s) timed out / 命令 %s（类型：%s）执行超时",
+			cmd.CommandId, cmd.Type.String(), cmd.CommandId, cmd.Type.String())
+		return resp, nil
+	}
 	if err != nil {
 		logger.ErrorF(ctx, "Command %s failed: %v / 命令 %s 失败：%v", cmd.CommandId, err, cmd.CommandId, err)
 	} else if resp.Status == pb.CommandStatus_FAILED {
@@ -853,9 +1136,10 @@ func (a *Agent) runConnectionMonitor() {
 			logger.InfoF(ctx, "Connection monitor stopped / 连接监控已停止")
 			return
 		case <-ticker.C:
-			if !a.grpcClient.IsConnected() {
+			if !a.grpcClient.IsConnected() && a.reconnecting.CompareAndSwap(false, true) {
 				logger.WarnF(ctx, "Connection lost, attempting reconnection... / 连接丢失，尝试重连...")
 				go func() {
+					defer a.reconnecting.Store(false)
 					ctx := context.Background()
 					if err := a.grpcClient.Reconnect(a.ctx); err != nil {
 						logger.ErrorF(ctx, "Reconnection failed: %v / 重连失败：%v", err, err)
@@ -878,8 +1162,55 @@ func (a *Agent) handleProcessEvent(name string, event process.ProcessEvent, info
 	logger.InfoF(ctx, "Process event: %s - %s (PID: %d, Status: %s) / 进程事件：%s - %s（PID：%d，状态：%s）",
 		name, event, info.PID, info.Status, name, event, info.PID, info.Status)
 
-	// TODO: Report process events to Control Plane
-	// TODO: 向 Control Plane 上报进程事件
+	// Lifecycle events are reported by the process monitor; only restart policy events are reported here
+	// 生命周期事件由进程监控上报；此处仅上报重启策略产生的事件
+	var eventType monitor.ProcessEventType
+	switch event {
+	case process.EventRestarted:
+		eventType = monitor.EventRestarted
+		// Keep the process monitor on the new PID / 让进程监控跟踪新的 PID
+		a.processMonitor.TrackProcessSilent(name, info.PID, info.InstallDir, info.Role, &process.StartParams{
+			InstallDir: info.InstallDir,
+			Role:       info.Role,
+		})
+	case process.EventRestartFailed:
+		eventType = monitor.EventRestartFailed
+	default:
+		return
+	}
+
+	go a.reportProcessEvent(&monitor.ProcessEvent{
+		Type:      eventType,
+		PID:       info.PID,
+		Name:      name,
+		Timestamp: time.Now(),
+		Details: map[string]interface{}{
+			"install_dir":   info.InstallDir,
+			"role":          info.Role,
+			"restart_count": info.RestartCount,
+			"error":         info.LastError,
+			"trigger":       "restart_policy",
+		},
+	})
+}
+
+// applyRestartPolicyParams reads restart_policy, max_restarts and restart_backoff command parameters.
+// applyRestartPolicyParams 读取 restart_policy、max_restarts 与 restart_backoff 命令参数。
+func applyRestartPolicyParams(params *process.StartParams, cmdParams map[string]string) error {
+	policy, err := process.ParseRestartPolicy(getParamString(cmdParams, "restart_policy", ""))
+	if err != nil {
+		return err
+	}
+	params.RestartPolicy = policy
+	params.MaxRestarts = getParamInt(cmdParams, "max_restarts", 0)
+	if raw := getParamString(cmdParams, "restart_backoff", ""); raw != "" {
+		backoff, err := parseRemoteInterval(raw)
+		if err != nil {
+			return fmt.Errorf("invalid restart_backoff %q: %w", raw, err)
+		}
+		params.RestartBackoff = backoff
+	}
+	return nil
 }
 
 // registerCommandHandlers registers all command handlers with the executor
@@ -904,21 +1235,53 @@ func (a *Agent) registerCommandHandlers() {
 	a.executor.RegisterHandler(pb.CommandType_COLLECT_LOGS, a.handleCollectLogsCommand)
 	a.executor.RegisterHandler(pb.CommandType_THREAD_DUMP, a.handleThreadDumpCommand)
 	a.executor.RegisterHandler(pb.CommandType_JVM_DUMP, a.handleJVMDumpCommand)
+	a.executor.RegisterHandler(pb.CommandType_DIAGNOSE, a.handleDiagnoseCommand)
 
 	// Register cluster discovery and monitoring handlers / 注册集群发现和监控处理器
 	a.executor.RegisterHandler(pb.CommandType_DISCOVER_CLUSTERS, a.handleDiscoverClustersCommand)
 	a.executor.RegisterHandler(pb.CommandType_UPDATE_MONITOR_CONFIG, a.handleUpdateMonitorConfigCommand)
 	a.executor.RegisterHandler(pb.CommandType_REMOVE_INSTALL_DIR, a.handleRemoveInstallDirCommand)
 
+	// Let transfer commands pull files through FetchFile / 允许传输命令通过 FetchFile 拉取文件
+	executor.SetFileFetcher(a.grpcClient.FetchFile)
+
 	// Initialize plugin manager and register plugin handlers / 初始化插件管理器并注册插件处理器
 	executor.InitPluginManager(a.config.SeaTunnel.InstallDir)
+	pluginCache, err := executor.InitPluginCache(
+		a.config.SeaTunnel.PluginCacheDir,
+		a.config.SeaTunnel.PluginCacheMaxSizeMB*1024*1024,
+		time.Duration(a.config.SeaTunnel.PluginCacheMaxAgeDays)*24*time.Hour,
+	)
+	if err != nil {
+		logger.WarnF(context.Background(), "Failed to initialize plugin cache, plugins will always be transferred: %v / 初始化插件缓存失败，插件将始终重新传输：%v", err, err)
+	} else {
+		// Report plugin cache usage in heartbeats / 在心跳中上报插件缓存使用情况
+		a.grpcClient.SetPluginCacheStatsProvider(func() *pb.PluginCacheStats {
+			stats := pluginCache.Stats()
+			return &pb.PluginCacheStats{
+				Entries:   stats.Entries,
+				SizeBytes: stats.SizeBytes,
+				MaxBytes:  stats.MaxBytes,
+				Hits:      stats.Hits,
+				Misses:    stats.Misses,
+				Evictions: stats.Evictions,
+			}
+		})
+	}
 	executor.RegisterPluginHandlers(a.executor)
 
 	// Register package transfer handlers / 注册安装包传输处理器
+	if err := executor.GetPackageTransferManager().SetCacheDir(a.config.SeaTunnel.PackageCacheDir); err != nil {
+		logger.WarnF(context.Background(), "Failed to create package cache dir, using default: %v / 创建安装包缓存目录失败，使用默认目录：%v", err, err)
+	}
 	executor.RegisterPackageHandlers(a.executor)
 
+	// Register maintenance script handler / 注册运维脚本处理器
+	executor.RegisterScriptHandlers(a.executor)
+
 	// Register config handlers / 注册配置处理器
 	configHandlers := executor.NewConfigHandlers()
+	configHandlers.SetAgentConfigApplier(a.applyRemoteConfig)
 	configHandlers.RegisterHandlers(a.executor)
 
 	logger.InfoF(ctx, "Registered %d command handlers / 已注册 %d 个命令处理器",
@@ -939,6 +1302,11 @@ func (a *Agent) handlePrecheckCommand(ctx context.Context, cmd *pb.CommandReques
 
 	reporter.Report(10, "Starting precheck... / 开始预检查...")
 
+	requiredPorts, err := getParamIntSlice(cmd.Parameters, "required_ports", []int{5801, 8080})
+	if err != nil {
+		return executor.CreateErrorResponse(cmd.CommandId, err.Error()), err
+	}
+
 	// Create precheck params from command parameters
 	// 从命令参数创建预检查参数
 	params := &installer.PrecheckParams{
@@ -946,7 +1314,8 @@ func (a *Agent) handlePrecheckCommand(ctx context.Context, cmd *pb.CommandReques
 		MinMemoryMB:    int64(getParamInt(cmd.Parameters, "min_memory_mb", 4096)),
 		MinCPUCores:    getParamInt(cmd.Parameters, "min_cpu_cores", 2),
 		MinDiskSpaceMB: int64(getParamInt(cmd.Parameters, "min_disk_mb", 10240)),
-		Ports:          getParamIntSlice(cmd.Parameters, "required_ports", []int{5801, 8080}),
+		Ports:          requiredPorts,
+		ClusterPort:    getParamInt(cmd.Parameters, "cluster_port", 5801),
 	}
 
 	prechecker := installer.NewPrechecker(params)
@@ -955,10 +1324,16 @@ func (a *Agent) handlePrecheckCommand(ctx context.Context, cmd *pb.CommandReques
 		return executor.CreateErrorResponse(cmd.CommandId, err.Error()), err
 	}
 
+	reporter.Report(60, "Checking system limits... / 检查系统限制...")
+	systemResult, err := prechecker.RunSystemChecks(ctx)
+	if err != nil {
+		return executor.CreateErrorResponse(cmd.CommandId, err.Error()), err
+	}
+
 	reporter.Report(100, "Precheck completed / 预检查完成")
 
 	// Format result as output / 将结果格式化为输出
-	output := formatPrecheckResult(result)
+	output := formatPrecheckResult(result) + "\n\n" + formatPrecheckResult(systemResult)
 	return executor.CreateSuccessResponse(cmd.CommandId, output), nil
 }
 
@@ -978,6 +1353,8 @@ func (a *Agent) handleInstallCommand(ctx context.Context, cmd *pb.CommandRequest
 		WorkerPort:     getParamInt(cmd.Parameters, "worker_port", 5802),
 		HTTPPort:       getParamInt(cmd.Parameters, "http_port", 8080),
 		ClusterID:      getParamString(cmd.Parameters, "cluster_id", ""),
+		CommandID:      cmd.CommandId,
+		HostID:         getParamString(cmd.Parameters, "host_id", ""),
 	}
 	if enableHTTPValue := strings.TrimSpace(getParamString(cmd.Parameters, "enable_http", "")); enableHTTPValue != "" {
 		enableHTTP := strings.EqualFold(enableHTTPValue, "true")
@@ -1011,23 +1388,74 @@ func (a *Agent) handleInstallCommand(ctx context.Context, cmd *pb.CommandRequest
 	if jobLogMode := strings.TrimSpace(getParamString(cmd.Parameters, "job_log_mode", "")); jobLogMode != "" {
 		params.JobLogMode = installer.JobLogMode(strings.ToLower(jobLogMode))
 	}
+	params.EnableSystemd = strings.EqualFold(strings.TrimSpace(getParamString(cmd.Parameters, "enable_systemd", "")), "true")
+	params.AutoInstallJava = strings.EqualFold(strings.TrimSpace(getParamString(cmd.Parameters, "auto_install_java", "")), "true")
+	if params.AutoInstallJava {
+		params.JavaMirror = installer.JavaMirror(strings.ToLower(strings.TrimSpace(getParamString(cmd.Parameters, "java_mirror", ""))))
+		params.JavaPackagePath = strings.TrimSpace(getParamString(cmd.Parameters, "java_package_path", ""))
+		// Offline nodes can pre-place the OpenJDK tarball next to cached packages
+		// 离线节点可以将 OpenJDK 安装包预先放在安装包缓存目录旁
+		params.JavaBundleDir = filepath.Join(a.config.SeaTunnel.PackageCacheDir, "java")
+	}
 
 	// Parse JVM config / 解析 JVM 配置
 	jvmHybridHeap := getParamInt(cmd.Parameters, "jvm_hybrid_heap", 0)
 	jvmMasterHeap := getParamInt(cmd.Parameters, "jvm_master_heap", 0)
 	jvmWorkerHeap := getParamInt(cmd.Parameters, "jvm_worker_heap", 0)
-	logger.InfoF(ctx, "[Install] JVM params received: hybrid=%d, master=%d, worker=%d", jvmHybridHeap, jvmMasterHeap, jvmWorkerHeap)
-	if jvmHybridHeap > 0 || jvmMasterHeap > 0 || jvmWorkerHeap > 0 {
+	jvmGC := strings.TrimSpace(getParamString(cmd.Parameters, "jvm_gc", ""))
+	jvmMaxDirectMemory := getParamInt(cmd.Parameters, "jvm_max_direct_memory_mb", 0)
+	var jvmExtraOptions []string
+	for _, option := range strings.Split(getParamString(cmd.Parameters, "jvm_extra_options", ""), "\n") {
+		if option = strings.TrimSpace(option); option != "" {
+			jvmExtraOptions = append(jvmExtraOptions, option)
+		}
+	}
+	logger.InfoF(ctx, "[Install] JVM params received: hybrid=%d, master=%d, worker=%d, gc=%s, direct=%dMB, extra=%d",
+		jvmHybridHeap, jvmMasterHeap, jvmWorkerHeap, jvmGC, jvmMaxDirectMemory, len(jvmExtraOptions))
+	if jvmHybridHeap > 0 || jvmMasterHeap > 0 || jvmWorkerHeap > 0 || jvmGC != "" || jvmMaxDirectMemory > 0 || len(jvmExtraOptions) > 0 {
 		params.JVM = &installer.JVMConfig{
-			HybridHeapSize: jvmHybridHeap,
-			MasterHeapSize: jvmMasterHeap,
-			WorkerHeapSize: jvmWorkerHeap,
+			HybridHeapSize:    jvmHybridHeap,
+			MasterHeapSize:    jvmMasterHeap,
+			WorkerHeapSize:    jvmWorkerHeap,
+			GC:                jvmGC,
+			MaxDirectMemoryMB: jvmMaxDirectMemory,
+			ExtraOptions:      jvmExtraOptions,
 		}
 		logger.InfoF(ctx, "[Install] JVM config created: %+v", params.JVM)
 	} else {
 		logger.InfoF(ctx, "[Install] JVM config not created (all values are 0)")
 	}
 
+	// Parse log rotation config / 解析日志滚动配置
+	logMaxFileSize := getParamInt(cmd.Parameters, "log_max_file_size_mb", 0)
+	logMaxBackups := getParamInt(cmd.Parameters, "log_max_backups", 0)
+	logCompress := strings.EqualFold(strings.TrimSpace(getParamString(cmd.Parameters, "log_compress", "")), "true")
+	logGC := strings.EqualFold(strings.TrimSpace(getParamString(cmd.Parameters, "log_gc", "")), "true")
+	logDiskQuota := getParamInt(cmd.Parameters, "log_disk_quota_mb", 0)
+	if logMaxFileSize > 0 || logMaxBackups > 0 || logCompress || logGC || logDiskQuota > 0 {
+		params.Log = &installer.LogConfig{
+			MaxFileSizeMB: logMaxFileSize,
+			MaxBackups:    logMaxBackups,
+			Compress:      logCompress,
+			GCLog:         logGC,
+			DiskQuotaMB:   logDiskQuota,
+		}
+	}
+
+	// Parse advanced Hazelcast network config / 解析 Hazelcast 高级网络配置
+	hazelcastPublicAddress := strings.TrimSpace(getParamString(cmd.Parameters, "hazelcast_public_address", ""))
+	hazelcastInterfaces := getParamStringSlice(cmd.Parameters, "hazelcast_interfaces")
+	hazelcastAdvertised := getParamStringSlice(cmd.Parameters, "hazelcast_advertised_addresses")
+	hazelcastRESTGroups := getParamStringSlice(cmd.Parameters, "hazelcast_rest_endpoint_groups")
+	if hazelcastPublicAddress != "" || len(hazelcastInterfaces) > 0 || len(hazelcastAdvertised) > 0 || len(hazelcastRESTGroups) > 0 {
+		params.Hazelcast = &installer.HazelcastNetworkConfig{
+			PublicAddress:       hazelcastPublicAddress,
+			Interfaces:          hazelcastInterfaces,
+			AdvertisedAddresses: hazelcastAdvertised,
+			RESTEndpointGroups:  hazelcastRESTGroups,
+		}
+	}
+
 	// Parse checkpoint config / 解析检查点配置
 	checkpointStorageType := getParamString(cmd.Parameters, "checkpoint_storage_type", "")
 	checkpointNamespace := getParamString(cmd.Parameters, "checkpoint_namespace", "")
@@ -1112,16 +1540,10 @@ func (a *Agent) handleInstallCommand(ctx context.Context, cmd *pb.CommandRequest
 	}
 
 	// Parse master addresses / 解析 master 地址列表
-	masterAddressesStr := getParamString(cmd.Parameters, "master_addresses", "")
-	if masterAddressesStr != "" {
-		params.MasterAddresses = strings.Split(masterAddressesStr, ",")
-	}
+	params.MasterAddresses = getParamStringSlice(cmd.Parameters, "master_addresses")
 
 	// Parse worker addresses (for separated mode) / 解析 worker 地址列表（分离模式）
-	workerAddressesStr := getParamString(cmd.Parameters, "worker_addresses", "")
-	if workerAddressesStr != "" {
-		params.WorkerAddresses = strings.Split(workerAddressesStr, ",")
-	}
+	params.WorkerAddresses = getParamStringSlice(cmd.Parameters, "worker_addresses")
 
 	// Parse install mode / 解析安装模式
 	installMode := getParamString(cmd.Parameters, "install_mode", "online")
@@ -1138,12 +1560,39 @@ func (a *Agent) handleInstallCommand(ctx context.Context, cmd *pb.CommandRequest
 		params.Mode = installer.InstallModeOffline // Use offline mode when package path is provided
 	}
 
+	// Parse presigned package download (shared object store) / 解析预签名安装包下载地址（共享对象存储）
+	if packageURL := getParamString(cmd.Parameters, "package_url", ""); packageURL != "" && params.PackagePath == "" {
+		params.PackageTransfer = &installer.PackageTransferInfo{
+			Source:      installer.PackageTransferFromURL,
+			Version:     params.Version,
+			FileName:    getParamString(cmd.Parameters, "package_file_name", ""),
+			FileSize:    int64(getParamInt(cmd.Parameters, "package_size", 0)),
+			Checksum:    getParamString(cmd.Parameters, "package_checksum", ""),
+			DownloadURL: packageURL,
+		}
+		params.Mode = installer.InstallModeOffline
+	}
+
 	// Parse mirror source / 解析镜像源
 	mirror := getParamString(cmd.Parameters, "mirror", "")
 	if mirror != "" {
 		params.Mirror = installer.MirrorSource(mirror)
 	}
 
+	// Parse skipped steps and step hooks / 解析跳过的步骤与步骤钩子
+	for _, step := range getParamStringSlice(cmd.Parameters, installhook.ParamSkipSteps) {
+		params.SkipSteps = append(params.SkipSteps, installer.InstallStep(step))
+	}
+	hooks, err := installhook.DecodeHooks(getParamString(cmd.Parameters, installhook.ParamHooks, ""))
+	if err != nil {
+		return executor.CreateErrorResponse(cmd.CommandId, err.Error()), err
+	}
+	params.Hooks = hooks
+
+	if getParamBool(cmd.Parameters, "dry_run", false) {
+		return a.handleInstallDryRun(ctx, cmd, params, reporter)
+	}
+
 	// Create progress adapter / 创建进度适配器
 	installReporter := &installerProgressAdapter{
 		reporter:  reporter,
@@ -1157,11 +1606,35 @@ func (a *Agent) handleInstallCommand(ctx context.Context, cmd *pb.CommandRequest
 		return executor.CreateErrorResponse(cmd.CommandId, err.Error()), err
 	}
 
+	// Hook results ride on the last output line so the Control Plane can record them per step
+	// 钩子结果附在输出最后一行，便于 Control Plane 按步骤记录
+	trailer := installhook.FormatTrailer(result.HookResults)
 	if !result.Success {
-		return executor.CreateErrorResponse(cmd.CommandId, result.Message), fmt.Errorf("%s", result.Message)
+		return executor.CreateErrorResponse(cmd.CommandId, result.Message+trailer), fmt.Errorf("%s", result.Message)
+	}
+
+	return executor.CreateSuccessResponse(cmd.CommandId, "Installation completed / 安装完成"+trailer), nil
+}
+
+// handleInstallDryRun validates an install request and returns the rendered configs as JSON without installing
+// handleInstallDryRun 校验安装请求并以 JSON 返回渲染后的配置，不执行安装
+func (a *Agent) handleInstallDryRun(ctx context.Context, cmd *pb.CommandRequest, params *installer.InstallParams, reporter executor.ProgressReporter) (*pb.CommandResponse, error) {
+	reporter.Report(10, "Running installation dry run... / 执行安装试运行...")
+
+	templates := make(map[string]string)
+	for key, value := range cmd.Parameters {
+		if name := strings.TrimPrefix(key, installer.DryRunTemplateParamPrefix); name != key && name != "" {
+			templates[name] = value
+		}
 	}
 
-	return executor.CreateSuccessResponse(cmd.CommandId, "Installation completed / 安装完成"), nil
+	result := a.installerManager.DryRun(ctx, params, templates)
+	payload, err := json.Marshal(result)
+	if err != nil {
+		return executor.CreateErrorResponse(cmd.CommandId, err.Error()), err
+	}
+	reporter.Report(100, "Installation dry run completed / 安装试运行完成")
+	return executor.CreateSuccessResponse(cmd.CommandId, string(payload)), nil
 }
 
 func (a *Agent) handleUninstallCommand(ctx context.Context, cmd *pb.CommandRequest, reporter executor.ProgressReporter) (*pb.CommandResponse, error) {
@@ -1173,6 +1646,9 @@ func (a *Agent) handleUninstallCommand(ctx context.Context, cmd *pb.CommandReque
 	if err != nil {
 		return executor.CreateErrorResponse(cmd.CommandId, err.Error()), err
 	}
+	if err := a.stateStore.DeleteLogPolicy(installDir); err != nil {
+		logger.WarnF(ctx, "[Agent] Failed to drop log quota: %v / 删除日志配额失败：%v", err, err)
+	}
 
 	return executor.CreateSuccessResponse(cmd.CommandId, "Uninstallation completed / 卸载完成"), nil
 }
@@ -1215,6 +1691,9 @@ func (a *Agent) handleStartCommand(ctx context.Context, cmd *pb.CommandRequest,
 		ConfigDir:  getParamString(cmd.Parameters, "config_dir", ""),
 		LogDir:     getParamString(cmd.Parameters, "log_dir", ""),
 	}
+	if err := applyRestartPolicyParams(params, cmd.Parameters); err != nil {
+		return executor.CreateErrorResponse(cmd.CommandId, err.Error()), err
+	}
 
 	// Check if auto-restart is enabled to avoid conflict
 	// 检查是否启用了自动重启以避免冲突
@@ -1225,6 +1704,9 @@ func (a *Agent) handleStartCommand(ctx context.Context, cmd *pb.CommandRequest,
 		a.autoRestarter.ResetRestartCount(processName)
 		logger.InfoF(ctx, "[Agent] Process registered for auto-start: %s (auto-restart will handle startup) / 进程已注册等待自动启动：%s（自动重启将处理启动）",
 			processName, processName)
+		if resp, err := a.verifyStartedMembership(ctx, cmd, installDir, reporter); resp != nil {
+			return resp, err
+		}
 		reporter.Report(100, "Process registered for auto-start / 进程已注册等待自动启动")
 		return executor.CreateSuccessResponse(cmd.CommandId, fmt.Sprintf("Process registered for auto-start (role: %s) / 进程已注册等待自动启动（角色：%s）", role, role)), nil
 	}
@@ -1244,10 +1726,44 @@ func (a *Agent) handleStartCommand(ctx context.Context, cmd *pb.CommandRequest,
 			processName, info.PID, processName, info.PID)
 	}
 
+	if resp, err := a.verifyStartedMembership(ctx, cmd, installDir, reporter); resp != nil {
+		return resp, err
+	}
 	reporter.Report(100, "Process started / 进程已启动")
 	return executor.CreateSuccessResponse(cmd.CommandId, fmt.Sprintf("Process started successfully (role: %s) / 进程启动成功（角色：%s）", role, role)), nil
 }
 
+// verifyStartedMembership waits for the started node to join the Hazelcast cluster when the Control
+// Plane asks for it, returning a failure response with diagnostics if it never shows up.
+// verifyStartedMembership 在 Control Plane 要求时等待已启动节点加入 Hazelcast 集群，
+// 若节点始终未出现则返回带诊断信息的失败响应。
+func (a *Agent) verifyStartedMembership(ctx context.Context, cmd *pb.CommandRequest, installDir string, reporter executor.ProgressReporter) (*pb.CommandResponse, error) {
+	if !getParamBool(cmd.Parameters, "verify_membership", false) {
+		return nil, nil
+	}
+	params := installer.ClusterMembershipParams{
+		Address:    getParamString(cmd.Parameters, "member_address", ""),
+		Port:       getParamInt(cmd.Parameters, "hazelcast_port", 0),
+		Seeds:      getParamStringSlice(cmd.Parameters, "membership_seeds"),
+		InstallDir: installDir,
+		Timeout:    time.Duration(getParamInt(cmd.Parameters, "membership_timeout", 0)) * time.Second,
+	}
+	reporter.Report(60, "Waiting for node to join the cluster... / 等待节点加入集群...")
+	result, err := installer.VerifyClusterMembership(ctx, params)
+	if err != nil {
+		message := fmt.Sprintf("Cluster membership verification failed: %v / 集群成员校验失败: %v", err, err)
+		if result != nil {
+			message += "\n" + result.Diagnostics()
+		}
+		logger.WarnF(ctx, "[Agent] %s", message)
+		return executor.CreateErrorResponse(cmd.CommandId, message), err
+	}
+	logger.InfoF(ctx, "[Agent] Node joined cluster via %s after %s, members: %v / 节点已加入集群",
+		result.Endpoint, result.Elapsed, result.Members)
+	reporter.Report(90, fmt.Sprintf("Node joined cluster (%d members) / 节点已加入集群（%d 个成员）", len(result.Members), len(result.Members)))
+	return nil, nil
+}
+
 func (a *Agent) handleStopCommand(ctx context.Context, cmd *pb.CommandRequest, reporter executor.ProgressReporter) (*pb.CommandResponse, error) {
 	if isSeatunnelXJavaProxyServiceCommand(cmd.Parameters) {
 		reporter.Report(10, "Stopping managed seatunnelx-java-proxy service... / 停止托管 seatunnelx-java-proxy 服务...")
@@ -1288,6 +1804,12 @@ func (a *Agent) handleStopCommand(ctx context.Context, cmd *pb.CommandRequest, r
 		return executor.CreateErrorResponse(cmd.CommandId, err.Error()), err
 	}
 
+	// Cluster deletion: forget the process for good and remove generated runtime artifacts
+	// 集群删除：彻底忘记该进程并移除生成的运行时产物
+	if getParamBool(cmd.Parameters, "teardown", false) {
+		return a.teardownStoppedProcess(ctx, cmd.CommandId, processName, installDir, reporter)
+	}
+
 	// Check if auto-restart is enabled to decide whether to untrack or set PID=0
 	// 检查是否启用了自动重启，以决定是取消跟踪还是设置 PID=0
 	if a.autoRestarter.IsEnabled() {
@@ -1308,6 +1830,34 @@ func (a *Agent) handleStopCommand(ctx context.Context, cmd *pb.CommandRequest, r
 	return executor.CreateSuccessResponse(cmd.CommandId, fmt.Sprintf("Process stopped successfully (role: %s) / 进程停止成功（角色：%s）", role, role)), nil
 }
 
+// teardownStoppedProcess untracks a stopped process regardless of auto-restart, drops its persisted
+// state and removes runtime artifacts of the installation, reporting what was removed.
+// teardownStoppedProcess 无论是否启用自动重启都取消跟踪已停止的进程，删除其持久化状态，
+// 并移除安装目录的运行时产物，上报已移除的内容。
+func (a *Agent) teardownStoppedProcess(ctx context.Context, commandID, processName, installDir string, reporter executor.ProgressReporter) (*pb.CommandResponse, error) {
+	reporter.Report(60, "Removing runtime artifacts... / 正在移除运行时产物...")
+
+	a.processMonitor.UntrackProcessSilent(processName)
+	a.processManager.RemoveProcess(processName)
+	if err := a.stateStore.DeleteInstall(installDir); err != nil {
+		logger.WarnF(ctx, "[Agent] Failed to drop install record: %v / 删除安装记录失败：%v", err, err)
+	}
+	if err := a.stateStore.DeleteLogPolicy(installDir); err != nil {
+		logger.WarnF(ctx, "[Agent] Failed to drop log quota: %v / 删除日志配额失败：%v", err, err)
+	}
+
+	result, err := installer.TeardownRuntimeArtifacts(ctx, installDir)
+	if err != nil {
+		return executor.CreateErrorResponse(commandID, err.Error()), err
+	}
+	logger.InfoF(ctx, "[Agent] Process %s torn down, removed files=%v units=%v / 进程 %s 已拆除，移除文件=%v unit=%v",
+		processName, result.RemovedFiles, result.RemovedUnits, processName, result.RemovedFiles, result.RemovedUnits)
+
+	reporter.Report(100, "Process stopped and torn down / 进程已停止并拆除")
+	output, _ := json.Marshal(result)
+	return executor.CreateSuccessResponse(commandID, string(output)), nil
+}
+
 func (a *Agent) handleRestartCommand(ctx context.Context, cmd *pb.CommandRequest, reporter executor.ProgressReporter) (*pb.CommandResponse, error) {
 	if isSeatunnelXJavaProxyServiceCommand(cmd.Parameters) {
 		reporter.Report(10, "Restarting managed seatunnelx-java-proxy service... / 重启托管 seatunnelx-java-proxy 服务...")
@@ -1344,6 +1894,9 @@ func (a *Agent) handleRestartCommand(ctx context.Context, cmd *pb.CommandRequest
 		InstallDir: installDir,
 		Role:       role,
 	}
+	if err := applyRestartPolicyParams(startParams, cmd.Parameters); err != nil {
+		return executor.CreateErrorResponse(cmd.CommandId, err.Error()), err
+	}
 	stopParams := &process.StopParams{
 		Graceful:   true,
 		Timeout:    30 * time.Second,
@@ -1367,6 +1920,9 @@ func (a *Agent) handleRestartCommand(ctx context.Context, cmd *pb.CommandRequest
 		a.autoRestarter.ResetRestartCount(processName)
 		logger.InfoF(ctx, "[Agent] Process stopped, registered for auto-restart: %s / 进程已停止，已注册等待自动重启：%s",
 			processName, processName)
+		if resp, err := a.verifyStartedMembership(ctx, cmd, installDir, reporter); resp != nil {
+			return resp, err
+		}
 		reporter.Report(100, "Process stopped, auto-restart will start it / 进程已停止，自动重启将启动它")
 		return executor.CreateSuccessResponse(cmd.CommandId, fmt.Sprintf("Process stopped, auto-restart will start it (role: %s) / 进程已停止，自动重启将启动它（角色：%s）", role, role)), nil
 	}
@@ -1385,6 +1941,10 @@ func (a *Agent) handleRestartCommand(ctx context.Context, cmd *pb.CommandRequest
 			processName, info.PID, processName, info.PID)
 	}
 
+	if resp, err := a.verifyStartedMembership(ctx, cmd, installDir, reporter); resp != nil {
+		return resp, err
+	}
+
 	reporter.Report(100, "Process restarted / 进程已重启")
 	return executor.CreateSuccessResponse(cmd.CommandId, fmt.Sprintf("Process restarted successfully (role: %s) / 进程重启成功（角色：%s）", role, role)), nil
 }
@@ -1737,6 +2297,51 @@ func (a *Agent) handleJVMDumpCommand(ctx context.Context, cmd *pb.CommandRequest
 	return executor.CreateSuccessResponse(cmd.CommandId, payload), nil
 }
 
+func (a *Agent) handleDiagnoseCommand(ctx context.Context, cmd *pb.CommandRequest, reporter executor.ProgressReporter) (*pb.CommandResponse, error) {
+	installDir := getParamString(cmd.Parameters, "install_dir", a.config.SeaTunnel.InstallDir)
+	role := getParamString(cmd.Parameters, "role", "")
+	outputDir := getParamString(cmd.Parameters, "output_dir", filepath.Join(installDir, "logs", "diagnostics"))
+
+	actions, err := agentdiagnostics.ParseDiagnoseActions(getParamString(cmd.Parameters, "actions", ""))
+	if err != nil {
+		return executor.CreateErrorResponse(cmd.CommandId, fmt.Sprintf("Invalid diagnose actions: %v / 诊断采集项无效：%v", err, err)), nil
+	}
+	reporter.Report(5, "Locating SeaTunnel JVM... / 正在定位 SeaTunnel JVM...")
+
+	// Report each section as soon as it is collected so long-running
+	// histograms do not leave the caller without feedback.
+	// 每个片段采集完成后立即上报，避免耗时较长的直方图采集期间调用方无反馈。
+	done := 0
+	result, err := agentdiagnostics.CollectJVMDiagnosis(ctx, installDir, role, outputDir, actions, func(section agentdiagnostics.DiagnoseSection) {
+		done++
+		progress := int32(5 + done*90/len(actions))
+		if section.Error != "" {
+			reporter.Report(progress, fmt.Sprintf("%s failed: %s / %s 采集失败", section.Action, section.Error, section.Action))
+			return
+		}
+		reporter.Report(progress, fmt.Sprintf("%s collected via %s (%d bytes) / %s 采集完成", section.Action, section.Tool, section.SizeBytes, section.Action))
+	})
+	if err != nil {
+		return executor.CreateErrorResponse(cmd.CommandId, fmt.Sprintf("Failed to diagnose JVM: %v / JVM 诊断失败：%v", err, err)), nil
+	}
+	if result.Failed() {
+		failures := make([]string, 0, len(result.Sections))
+		for _, section := range result.Sections {
+			failures = append(failures, section.Error)
+		}
+		reason := strings.Join(failures, "; ")
+		return executor.CreateErrorResponse(cmd.CommandId, fmt.Sprintf("Failed to diagnose JVM: %s / JVM 诊断失败：%s", reason, reason)), nil
+	}
+
+	payload, err := agentdiagnostics.MarshalDiagnoseResult(result)
+	if err != nil {
+		return executor.CreateErrorResponse(cmd.CommandId, fmt.Sprintf("Failed to encode diagnose result: %v / 编码诊断结果失败：%v", err, err)), nil
+	}
+
+	reporter.Report(100, "JVM diagnosis collected / JVM 诊断采集完成")
+	return executor.CreateSuccessResponse(cmd.CommandId, payload), nil
+}
+
 // readLastNLines reads the last N lines from a file
 // readLastNLines 从文件中读取最后 N 行
 func readLastNLines(filename string, n int) ([]byte, error) {
@@ -1883,14 +2488,23 @@ func getParamBool(params map[string]string, key string, defaultValue bool) bool
 	return defaultValue
 }
 
-// getParamIntSlice gets an integer slice parameter with default value
-// getParamIntSlice 获取整数切片参数，带默认值
-func getParamIntSlice(params map[string]string, key string, defaultValue []int) []int {
-	// For simplicity, return default value
-	// 为简单起见，返回默认值
-	// TODO: Implement parsing of comma-separated integers
-	// TODO: 实现逗号分隔整数的解析
-	return defaultValue
+// getParamIntSlice gets a comma-separated integer parameter, accepting ranges such as "9000-9005"; an absent parameter yields the default value
+// getParamIntSlice 获取逗号分隔的整数参数，支持 "9000-9005" 这样的范围；参数缺失时返回默认值
+func getParamIntSlice(params map[string]string, key string, defaultValue []int) ([]int, error) {
+	values, err := executor.ParamIntList(params, key)
+	if err != nil {
+		return nil, err
+	}
+	if len(values) == 0 {
+		return defaultValue, nil
+	}
+	return values, nil
+}
+
+// getParamStringSlice gets a comma-separated string parameter with empty entries dropped
+// getParamStringSlice 获取逗号分隔的字符串参数，空项会被丢弃
+func getParamStringSlice(params map[string]string, key string) []string {
+	return executor.ParamList(params, key)
 }
 
 // formatPrecheckResult formats precheck result as string
@@ -1908,6 +2522,9 @@ func formatPrecheckResult(result *installer.PrecheckResult) string {
 			statusIcon = "⚠"
 		}
 		sb += fmt.Sprintf("%s %s: %s\n", statusIcon, item.Name, item.Message)
+		if item.Suggestion != "" {
+			sb += fmt.Sprintf("    Fix / 修复: %s\n", item.Suggestion)
+		}
 	}
 
 	sb += "================================\n"
@@ -1983,34 +2600,173 @@ var versionCmd = &cobra.Command{
 	},
 }
 
+// serviceCmd manages the Agent operating system service
+// serviceCmd 管理 Agent 的操作系统服务
+var serviceCmd = &cobra.Command{
+	Use:   "service",
+	Short: "Manage the Agent Windows service / 管理 Agent Windows 服务",
+}
+
+// serviceInstallCmd registers the Agent as a Windows service
+// serviceInstallCmd 将 Agent 注册为 Windows 服务
+var serviceInstallCmd = &cobra.Command{
+	Use:   "install",
+	Short: "Register and start the Agent Windows service / 注册并启动 Agent Windows 服务",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return installService(configFile)
+	},
+}
+
+// serviceUninstallCmd removes the Agent Windows service
+// serviceUninstallCmd 删除 Agent Windows 服务
+var serviceUninstallCmd = &cobra.Command{
+	Use:   "uninstall",
+	Short: "Stop and remove the Agent Windows service / 停止并删除 Agent Windows 服务",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return uninstallService()
+	},
+}
+
+// configCmd groups configuration helpers
+// configCmd 汇总配置相关的辅助命令
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Inspect the Agent configuration / 检查 Agent 配置",
+}
+
+// configValidateCmd loads the layered configuration and validates it without starting the Agent
+// configValidateCmd 加载分层配置并校验，但不启动 Agent
+var configValidateCmd = &cobra.Command{
+	Use:          "validate",
+	Short:        "Validate config file, environment and flags / 校验配置文件、环境变量与命令行参数",
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if _, err := loadConfig(); err != nil {
+			return err
+		}
+		fmt.Printf("Configuration is valid: %s / 配置有效：%s\n", config.ResolveConfigPath(configFile), config.ResolveConfigPath(configFile))
+		return nil
+	},
+}
+
+// doctorJSON selects JSON output for the doctor command
+// doctorJSON 为 doctor 命令选择 JSON 输出
+var doctorJSON bool
+
+// doctorTimeout bounds each network step of the doctor command
+// doctorTimeout 限制 doctor 命令每个网络步骤的耗时
+var doctorTimeout time.Duration
+
+// doctorCmd runs local diagnostics to debug an Agent that cannot connect
+// doctorCmd 执行本地诊断，用于排查 Agent 无法连接的问题
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Diagnose config, environment and Control Plane connectivity / 诊断配置、环境与 Control Plane 连通性",
+	Long: `Run all installation prechecks locally, test every Control Plane address (TCP, TLS handshake,
+register dry-run) and report existing SeaTunnel installations. Exits non-zero when a check fails.
+在本地执行全部安装预检查，逐个测试 Control Plane 地址（TCP、TLS 握手、注册试运行），
+并报告已有的 SeaTunnel 安装。存在失败检查时以非零状态退出。`,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE:          runDoctor,
+}
+
 // configFile is the path to the configuration file
 // configFile 是配置文件的路径
 var configFile string
 
+// configOverrides holds repeated --set key=value flags
+// configOverrides 保存重复的 --set key=value 参数
+var configOverrides []string
+
 func init() {
 	// Add flags to root command
 	// 向根命令添加标志
 	rootCmd.PersistentFlags().StringVarP(&configFile, "config", "c", "", "config file path (default: /etc/seatunnelx-agent/config.yaml)")
+	rootCmd.PersistentFlags().StringArrayVar(&configOverrides, "set", nil, "override a config key, e.g. --set log.level=debug (repeatable)")
 
 	// Add subcommands
 	// 添加子命令
 	rootCmd.AddCommand(versionCmd)
+	serviceCmd.AddCommand(serviceInstallCmd, serviceUninstallCmd)
+	rootCmd.AddCommand(serviceCmd)
+	configCmd.AddCommand(configValidateCmd)
+	rootCmd.AddCommand(configCmd)
+	doctorCmd.Flags().BoolVar(&doctorJSON, "json", false, "print the report as JSON")
+	doctorCmd.Flags().DurationVar(&doctorTimeout, "timeout", doctor.DefaultTimeout, "timeout of each network check")
+	rootCmd.AddCommand(doctorCmd)
+}
+
+// loadConfig loads defaults < config file < environment < --set flags and validates the result
+// loadConfig 按 默认值 < 配置文件 < 环境变量 < --set 参数 加载配置并校验
+func loadConfig() (*config.Config, error) {
+	overrides, err := config.ParseSetFlags(configOverrides)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := config.LoadWithPriority(configFile, overrides)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w / 加载配置失败：%w", err, err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config: %w / 无效配置：%w", err, err)
+	}
+	return cfg, nil
+}
+
+// runDoctor loads the configuration leniently so that a broken config is reported instead of
+// aborting, runs the diagnostics and prints the report
+// runDoctor 宽松地加载配置，使损坏的配置被报告而非直接中止，随后执行诊断并打印报告
+func runDoctor(cmd *cobra.Command, args []string) error {
+	opts := doctor.Options{ConfigPath: config.ResolveConfigPath(configFile), Timeout: doctorTimeout}
+	overrides, err := config.ParseSetFlags(configOverrides)
+	if err != nil {
+		opts.ConfigErr = err
+	} else if cfg, err := config.LoadWithPriority(configFile, overrides); err != nil {
+		opts.ConfigErr = err
+	} else {
+		opts.Config = cfg
+		opts.ConfigErr = cfg.Validate()
+	}
+
+	metricsCollector := collector.NewMetricsCollector(nil)
+	opts.Register = &pb.RegisterRequest{
+		Hostname:     metricsCollector.GetHostname(),
+		IpAddress:    metricsCollector.GetIPAddress(),
+		OsType:       runtime.GOOS,
+		Arch:         runtime.GOARCH,
+		AgentVersion: Version,
+		SystemInfo:   metricsCollector.GetSystemInfo(),
+	}
+	if opts.Config != nil {
+		opts.Register.AgentId = opts.Config.Agent.ID
+		opts.Register.EnrollmentToken = opts.Config.Agent.EnrollmentToken
+		opts.Register.AgentSecret, _ = config.LoadAgentSecret(opts.Config.Agent.SecretFile)
+	}
+
+	report := doctor.New(opts).Run(cmd.Context())
+	if doctorJSON {
+		err = report.WriteJSON(cmd.OutOrStdout())
+	} else {
+		err = report.WriteText(cmd.OutOrStdout())
+	}
+	if err != nil {
+		return err
+	}
+	if failed := report.FailedCount(); failed > 0 {
+		return fmt.Errorf("%d doctor check(s) failed / %d 项诊断检查失败", failed, failed)
+	}
+	return nil
 }
 
 // runAgent is the main entry point for the Agent service
 // runAgent 是 Agent 服务的主入口点
 func runAgent(cmd *cobra.Command, args []string) error {
-	// Load configuration
-	// 加载配置
-	cfg, err := config.Load(configFile)
+	// Load and validate configuration
+	// 加载并验证配置
+	cfg, err := loadConfig()
 	if err != nil {
-		return fmt.Errorf("failed to load config: %w / 加载配置失败：%w", err, err)
-	}
-
-	// Validate configuration
-	// 验证配置
-	if err := cfg.Validate(); err != nil {
-		return fmt.Errorf("invalid config: %w / 无效配置：%w", err, err)
+		return err
 	}
 
 	// 初始化 Agent 日志（同时输出到控制台和文件）
@@ -2018,6 +2774,25 @@ func runAgent(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to init logger: %w / 初始化日志失败：%w", err, err)
 	}
 
+	// Export command spans to the collector when telemetry is enabled
+	// 启用遥测时将命令 span 导出到收集器
+	shutdownTracing, err := tracing.Init(context.Background(), cfg.Telemetry, cfg.Agent.ID)
+	if err != nil {
+		logger.WarnF(context.Background(), "Failed to init tracing, spans disabled: %v / 初始化追踪失败，已禁用 span：%v", err, err)
+	} else {
+		defer func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			_ = shutdownTracing(ctx)
+		}()
+	}
+
+	// Hand control to the Windows Service Control Manager when started as a service
+	// 以服务方式启动时将控制权交给 Windows 服务控制管理器
+	if handled, err := runAsService(cfg); handled || err != nil {
+		return err
+	}
+
 	// Create agent
 	// 创建 Agent
 	agent := NewAgent(cfg)
@@ -2074,13 +2849,31 @@ func isNotFoundError(err error) bool {
 
 // handleDiscoverClustersCommand handles the DISCOVER_CLUSTERS command (simplified)
 // handleDiscoverClustersCommand 处理 DISCOVER_CLUSTERS 命令（简化版）
-// Only scans for running SeaTunnel processes, returns PID, role, install_dir
-// 只扫描运行中的 SeaTunnel 进程，返回 PID、角色、安装目录
+// Scans for running SeaTunnel processes, returns PID, role, install_dir.
+// With an install_dir parameter it inspects that installation (version, ports, config files) instead.
+// 扫描运行中的 SeaTunnel 进程，返回 PID、角色、安装目录。
+// 指定 install_dir 参数时改为检查该安装（版本、端口、配置文件）。
 func (a *Agent) handleDiscoverClustersCommand(ctx context.Context, cmd *pb.CommandRequest, reporter executor.ProgressReporter) (*pb.CommandResponse, error) {
-	reporter.Report(10, "Scanning for SeaTunnel processes... / 正在扫描 SeaTunnel 进程...")
-
 	// Use simplified process discovery / 使用简化的进程发现
 	processDiscovery := discovery.NewProcessDiscovery()
+
+	// With install_dir, inspect that installation for cluster adoption instead of listing processes
+	// 指定 install_dir 时，检查该安装目录以纳管集群，而不是列出进程
+	if installDir := strings.TrimSpace(cmd.Parameters["install_dir"]); installDir != "" {
+		reporter.Report(10, fmt.Sprintf("Inspecting SeaTunnel installation %s... / 正在检查 SeaTunnel 安装 %s...", installDir, installDir))
+		inspection, err := processDiscovery.InspectInstallDir(installDir)
+		if err != nil {
+			return executor.CreateErrorResponse(cmd.CommandId, err.Error()), err
+		}
+		jsonOutput, err := json.Marshal(inspection)
+		if err != nil {
+			return executor.CreateErrorResponse(cmd.CommandId, err.Error()), err
+		}
+		reporter.Report(100, "Installation inspection completed / 安装检查完成")
+		return executor.CreateSuccessResponse(cmd.CommandId, string(jsonOutput)), nil
+	}
+
+	reporter.Report(10, "Scanning for SeaTunnel processes... / 正在扫描 SeaTunnel 进程...")
 	processes, err := processDiscovery.DiscoverProcesses()
 	if err != nil {
 		return executor.CreateErrorResponse(cmd.CommandId, err.Error()), err
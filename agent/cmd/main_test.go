@@ -21,6 +21,7 @@ import (
 	"testing"
 	"time"
 
+	pb "github.com/seatunnel/seatunnelX/agent"
 	"github.com/seatunnel/seatunnelX/agent/internal/config"
 	"github.com/seatunnel/seatunnelX/agent/internal/monitor"
 	"github.com/stretchr/testify/assert"
@@ -173,3 +174,50 @@ func TestExtractProcessEventReportFieldsPreservesDetails(t *testing.T) {
 	assert.Equal(t, "exit status 1", details["error"])
 	assert.Equal(t, "3", details["retry_count"])
 }
+
+// TestApplyRemoteConfigKeepsLatest tests that only the latest pending remote config is queued
+// TestApplyRemoteConfigKeepsLatest 测试仅保留最新的待处理远程配置
+func TestApplyRemoteConfigKeepsLatest(t *testing.T) {
+	agent := NewAgent(&config.Config{Heartbeat: config.HeartbeatConfig{Interval: 10 * time.Second}})
+
+	agent.applyRemoteConfig(&pb.AgentConfig{HeartbeatInterval: 20})
+	agent.applyRemoteConfig(&pb.AgentConfig{HeartbeatInterval: 30})
+
+	require.Len(t, agent.configCh, 1)
+	cfg := <-agent.configCh
+	assert.Equal(t, int32(30), cfg.HeartbeatInterval)
+}
+
+// TestSetYAMLSectionValue tests in-place update of section values in the agent config file
+// TestSetYAMLSectionValue 测试原地更新 Agent 配置文件中的段内键值
+func TestSetYAMLSectionValue(t *testing.T) {
+	lines := []string{
+		"heartbeat:",
+		"  interval: 10s",
+		"log:",
+		"  # comment",
+		"  level: info",
+		"  file: /var/log/agent.log",
+	}
+
+	assert.True(t, setYAMLSectionValue(lines, "heartbeat", "interval", "30s"))
+	assert.True(t, setYAMLSectionValue(lines, "log", "level", "debug"))
+	assert.False(t, setYAMLSectionValue(lines, "heartbeat", "level", "debug"))
+	assert.Equal(t, "  interval: 30s", lines[1])
+	assert.Equal(t, "  level: debug", lines[4])
+}
+
+// TestParseRemoteInterval tests parsing of remote interval values
+// TestParseRemoteInterval 测试远程间隔值的解析
+func TestParseRemoteInterval(t *testing.T) {
+	d, err := parseRemoteInterval("15")
+	require.NoError(t, err)
+	assert.Equal(t, 15*time.Second, d)
+
+	d, err = parseRemoteInterval("2m")
+	require.NoError(t, err)
+	assert.Equal(t, 2*time.Minute, d)
+
+	_, err = parseRemoteInterval("abc")
+	assert.Error(t, err)
+}
@@ -0,0 +1,49 @@
+//go:build !windows
+// +build !windows
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"errors"
+
+	"github.com/seatunnel/seatunnelX/agent/internal/config"
+)
+
+// errServiceUnsupported is returned by service management outside Windows, where the install script registers a systemd unit
+// errServiceUnsupported 在非 Windows 平台的服务管理中返回，这些平台由安装脚本注册 systemd 单元
+var errServiceUnsupported = errors.New("service registration is only supported on Windows; use the install script to register the systemd unit / 仅 Windows 支持服务注册，请使用安装脚本注册 systemd 单元")
+
+// runAsService reports false: outside Windows the Agent always runs in the foreground under systemd
+// runAsService 返回 false：非 Windows 平台上 Agent 始终在 systemd 下前台运行
+func runAsService(cfg *config.Config) (bool, error) {
+	return false, nil
+}
+
+// installService is not supported outside Windows
+// installService 在非 Windows 平台上不受支持
+func installService(configPath string) error {
+	return errServiceUnsupported
+}
+
+// uninstallService is not supported outside Windows
+// uninstallService 在非 Windows 平台上不受支持
+func uninstallService() error {
+	return errServiceUnsupported
+}
@@ -0,0 +1,163 @@
+//go:build windows
+// +build windows
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/seatunnel/seatunnelX/agent/internal/config"
+	"github.com/seatunnel/seatunnelX/agent/internal/logger"
+	"golang.org/x/sys/windows/svc"
+	"golang.org/x/sys/windows/svc/mgr"
+)
+
+// windowsServiceName is the name the Agent registers with the Windows Service Control Manager
+// windowsServiceName 是 Agent 在 Windows 服务控制管理器中注册的名称
+const windowsServiceName = "seatunnelx-agent"
+
+// agentService adapts the Agent to the Windows service lifecycle
+// agentService 将 Agent 适配到 Windows 服务生命周期
+type agentService struct {
+	cfg *config.Config
+}
+
+// Execute runs the Agent until the Service Control Manager asks it to stop
+// Execute 运行 Agent，直到服务控制管理器请求停止
+func (s *agentService) Execute(args []string, requests <-chan svc.ChangeRequest, status chan<- svc.Status) (bool, uint32) {
+	const accepted = svc.AcceptStop | svc.AcceptShutdown
+	status <- svc.Status{State: svc.StartPending}
+
+	agent := NewAgent(s.cfg)
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- agent.Run()
+	}()
+	status <- svc.Status{State: svc.Running, Accepts: accepted}
+
+	for {
+		select {
+		case err := <-errChan:
+			if err != nil {
+				logger.ErrorF(context.Background(), "Agent stopped with error: %v / Agent 异常停止：%v", err, err)
+				return true, 1
+			}
+			return false, 0
+		case req := <-requests:
+			switch req.Cmd {
+			case svc.Interrogate:
+				status <- req.CurrentStatus
+			case svc.Stop, svc.Shutdown:
+				logger.InfoF(context.Background(), "Received service control %d / 收到服务控制命令 %d", req.Cmd, req.Cmd)
+				status <- svc.Status{State: svc.StopPending}
+				agent.Shutdown()
+				return false, 0
+			}
+		}
+	}
+}
+
+// runAsService runs the Agent under the Windows Service Control Manager when started by it.
+// It reports false when the Agent was started from a console.
+// runAsService 在由 Windows 服务控制管理器启动时以服务方式运行 Agent。
+// 从控制台启动时返回 false。
+func runAsService(cfg *config.Config) (bool, error) {
+	isService, err := svc.IsWindowsService()
+	if err != nil || !isService {
+		return false, err
+	}
+	return true, svc.Run(windowsServiceName, &agentService{cfg: cfg})
+}
+
+// installService registers the Agent as an automatically started Windows service
+// installService 将 Agent 注册为自动启动的 Windows 服务
+func installService(configPath string) error {
+	exePath, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to resolve agent executable: %w", err)
+	}
+	if configPath != "" {
+		if configPath, err = filepath.Abs(configPath); err != nil {
+			return fmt.Errorf("failed to resolve config path: %w", err)
+		}
+	}
+
+	m, err := mgr.Connect()
+	if err != nil {
+		return fmt.Errorf("failed to connect to service manager: %w", err)
+	}
+	defer m.Disconnect()
+
+	if existing, err := m.OpenService(windowsServiceName); err == nil {
+		existing.Close()
+		return fmt.Errorf("service %s already exists", windowsServiceName)
+	}
+
+	var args []string
+	if configPath != "" {
+		args = append(args, "--config", configPath)
+	}
+	s, err := m.CreateService(windowsServiceName, exePath, mgr.Config{
+		DisplayName: "SeaTunnelX Agent",
+		Description: "SeaTunnelX Agent - Node daemon for SeaTunnel cluster management",
+		StartType:   mgr.StartAutomatic,
+	}, args...)
+	if err != nil {
+		return fmt.Errorf("failed to create service: %w", err)
+	}
+	defer s.Close()
+
+	// Restart the Agent after crashes, like Restart=always in the systemd unit
+	// 崩溃后重启 Agent，与 systemd 单元中的 Restart=always 一致
+	recovery := []mgr.RecoveryAction{
+		{Type: mgr.ServiceRestart, Delay: 10 * time.Second},
+		{Type: mgr.ServiceRestart, Delay: 10 * time.Second},
+		{Type: mgr.ServiceRestart, Delay: 30 * time.Second},
+	}
+	if err := s.SetRecoveryActions(recovery, uint32((24 * time.Hour).Seconds())); err != nil {
+		return fmt.Errorf("failed to set service recovery actions: %w", err)
+	}
+	return s.Start()
+}
+
+// uninstallService stops and removes the Agent Windows service
+// uninstallService 停止并删除 Agent Windows 服务
+func uninstallService() error {
+	m, err := mgr.Connect()
+	if err != nil {
+		return fmt.Errorf("failed to connect to service manager: %w", err)
+	}
+	defer m.Disconnect()
+
+	s, err := m.OpenService(windowsServiceName)
+	if err != nil {
+		return fmt.Errorf("service %s is not installed", windowsServiceName)
+	}
+	defer s.Close()
+
+	if _, err := s.Control(svc.Stop); err != nil {
+		logger.WarnF(context.Background(), "Failed to stop service before removal: %v / 删除前停止服务失败：%v", err, err)
+	}
+	return s.Delete()
+}
@@ -53,21 +53,21 @@ func (a *Agent) handleManagedUpgradeCommand(ctx context.Context, cmd *pb.Command
 		return executor.CreateSuccessResponse(cmd.CommandId, fmt.Sprintf("target_dir=%s", targetDir)), nil
 	case "sync_connectors_manifest":
 		installDir := getParamString(cmd.Parameters, "install_dir", "")
-		keepFiles := splitCSV(cmd.Parameters["keep_files"])
+		keepFiles := getParamStringSlice(cmd.Parameters, "keep_files")
 		if err := a.installerManager.SyncConnectorsManifest(installDir, keepFiles); err != nil {
 			return executor.CreateErrorResponse(cmd.CommandId, err.Error()), err
 		}
 		return executor.CreateSuccessResponse(cmd.CommandId, fmt.Sprintf("connectors_dir=%s/connectors", installDir)), nil
 	case "sync_lib_manifest":
 		installDir := getParamString(cmd.Parameters, "install_dir", "")
-		keepFiles := splitCSV(cmd.Parameters["keep_files"])
+		keepFiles := getParamStringSlice(cmd.Parameters, "keep_files")
 		if err := a.installerManager.SyncLibManifest(installDir, keepFiles); err != nil {
 			return executor.CreateErrorResponse(cmd.CommandId, err.Error()), err
 		}
 		return executor.CreateSuccessResponse(cmd.CommandId, fmt.Sprintf("lib_dir=%s/lib", installDir)), nil
 	case "sync_plugins_manifest":
 		installDir := getParamString(cmd.Parameters, "install_dir", "")
-		keepFiles := splitCSV(cmd.Parameters["keep_files"])
+		keepFiles := getParamStringSlice(cmd.Parameters, "keep_files")
 		if err := a.installerManager.SyncPluginsManifest(installDir, keepFiles); err != nil {
 			return executor.CreateErrorResponse(cmd.CommandId, err.Error()), err
 		}
@@ -133,19 +133,3 @@ func (a *Agent) handleManagedUpgradeCommand(ctx context.Context, cmd *pb.Command
 		return executor.CreateErrorResponse(cmd.CommandId, err.Error()), err
 	}
 }
-
-func splitCSV(value string) []string {
-	if strings.TrimSpace(value) == "" {
-		return nil
-	}
-	parts := strings.Split(value, ",")
-	result := make([]string, 0, len(parts))
-	for _, part := range parts {
-		trimmed := strings.TrimSpace(part)
-		if trimmed == "" {
-			continue
-		}
-		result = append(result, trimmed)
-	}
-	return result
-}
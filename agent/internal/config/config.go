@@ -21,7 +21,7 @@
 // Configuration loading priority (highest to lowest):
 // 配置加载优先级（从高到低）：
 // 1. Command line arguments / 命令行参数
-// 2. Environment variables / 环境变量
+// 2. Environment variables (SEATUNNELX_AGENT_*, then legacy AGENT_*) / 环境变量（SEATUNNELX_AGENT_*，其次为旧的 AGENT_*）
 // 3. Configuration file / 配置文件
 // 4. Default values / 默认值
 package config
@@ -47,6 +47,17 @@ const (
 	DefaultLogMaxBackups       = 3
 	DefaultLogMaxAge           = 7 // days
 	DefaultSeaTunnelInstallDir = "/opt/seatunnel"
+	DefaultPackageCacheDir     = "/var/lib/seatunnelx-agent/packages"
+	DefaultPluginCacheDir      = "/var/lib/seatunnelx-agent/plugins"
+	DefaultAgentSecretFile     = "/var/lib/seatunnelx-agent/agent.secret"
+	DefaultPluginCacheMaxSize  = 2048 // MB
+	DefaultPluginCacheMaxAge   = 30   // days
+	DefaultFailbackInterval    = 30 * time.Second
+	DefaultFailureThreshold    = 3
+	DefaultTelemetryEndpoint   = "localhost:4317"
+	// DefaultMaxConcurrentCommands is the default number of commands executed at the same time
+	// DefaultMaxConcurrentCommands 是默认可同时执行的命令数量
+	DefaultMaxConcurrentCommands = 4
 )
 
 // Config represents the Agent configuration
@@ -66,6 +77,9 @@ type Config struct {
 
 	// SeaTunnel configuration / SeaTunnel 配置
 	SeaTunnel SeaTunnelConfig `mapstructure:"seatunnel"`
+
+	// Telemetry configuration / 遥测配置
+	Telemetry TelemetryConfig `mapstructure:"telemetry"`
 }
 
 // AgentConfig contains Agent-specific configuration
@@ -74,6 +88,18 @@ type AgentConfig struct {
 	// ID is the unique identifier for this Agent (auto-generated if empty)
 	// ID 是此 Agent 的唯一标识符（如果为空则自动生成）
 	ID string `mapstructure:"id"`
+
+	// MaxConcurrentCommands limits how many commands run at the same time (<= 0 means unlimited)
+	// MaxConcurrentCommands 限制同时执行的命令数量（小于等于 0 表示不限制）
+	MaxConcurrentCommands int `mapstructure:"max_concurrent_commands"`
+
+	// EnrollmentToken is the single-use token presented to the Control Plane during registration
+	// EnrollmentToken 是注册时提交给 Control Plane 的一次性注册令牌
+	EnrollmentToken string `mapstructure:"enrollment_token"`
+
+	// SecretFile stores the secret issued by the Control Plane on enrollment
+	// SecretFile 保存注册时 Control Plane 签发的 Agent 密钥
+	SecretFile string `mapstructure:"secret_file"`
 }
 
 // ControlPlaneConfig contains Control Plane connection settings
@@ -88,6 +114,14 @@ type ControlPlaneConfig struct {
 
 	// Token for authentication / 用于认证的 Token
 	Token string `mapstructure:"token"`
+
+	// FailbackInterval is how often the preferred (first) address is probed while failed over
+	// FailbackInterval 是故障转移期间探测首选（第一个）地址的间隔
+	FailbackInterval time.Duration `mapstructure:"failback_interval"`
+
+	// FailureThreshold is the number of consecutive unavailable RPCs before failing over
+	// FailureThreshold 是触发故障转移前连续不可用 RPC 的次数
+	FailureThreshold int `mapstructure:"failure_threshold"`
 }
 
 // TLSConfig contains TLS settings
@@ -142,6 +176,22 @@ type LogConfig struct {
 	MaxAge int `mapstructure:"max_age"`
 }
 
+// TelemetryConfig contains OpenTelemetry tracing settings
+// TelemetryConfig 包含 OpenTelemetry 追踪设置
+type TelemetryConfig struct {
+	// Enabled indicates whether command spans are exported
+	// Enabled 表示是否导出命令 span
+	Enabled bool `mapstructure:"enabled"`
+
+	// Endpoint is the OTLP gRPC collector address, normally the one used by the Control Plane
+	// Endpoint 是 OTLP gRPC 收集器地址，通常与 Control Plane 使用的相同
+	Endpoint string `mapstructure:"endpoint"`
+
+	// Insecure disables TLS towards the collector
+	// Insecure 表示连接收集器时不使用 TLS
+	Insecure bool `mapstructure:"insecure"`
+}
+
 // SeaTunnelConfig contains SeaTunnel-related settings
 // SeaTunnelConfig 包含 SeaTunnel 相关设置
 // Note: SeaTunnel manages its own config and log directories internally
@@ -153,55 +203,28 @@ type SeaTunnelConfig struct {
 	// by Control Plane commands when installing SeaTunnel
 	// 这只是作为后备使用；实际的 install_dir 在安装 SeaTunnel 时由 Control Plane 命令指定
 	InstallDir string `mapstructure:"install_dir"`
-}
 
-// Load loads configuration from file and environment variables
-// Load 从文件和环境变量加载配置
-func Load(configPath string) (*Config, error) {
-	v := viper.New()
+	// PackageCacheDir caches verified installation packages so repeated installs skip the transfer
+	// PackageCacheDir 缓存已校验的安装包，重复安装时跳过传输
+	PackageCacheDir string `mapstructure:"package_cache_dir"`
 
-	// Set default values / 设置默认值
-	setDefaults(v)
+	// PluginCacheDir caches verified plugin files by SHA-256 so other clusters skip the transfer
+	// PluginCacheDir 按 SHA-256 缓存已校验的插件文件，其他集群安装时跳过传输
+	PluginCacheDir string `mapstructure:"plugin_cache_dir"`
 
-	// Set config file path / 设置配置文件路径
-	if configPath != "" {
-		v.SetConfigFile(configPath)
-	} else {
-		// Check environment variable / 检查环境变量
-		envPath := os.Getenv("AGENT_CONFIG_PATH")
-		if envPath != "" {
-			v.SetConfigFile(envPath)
-		} else {
-			v.SetConfigFile(DefaultConfigPath)
-		}
-	}
+	// PluginCacheMaxSizeMB bounds the plugin cache size; least-recently-used files are evicted first (0 = unlimited)
+	// PluginCacheMaxSizeMB 限制插件缓存大小，优先淘汰最近最少使用的文件（0 表示不限制）
+	PluginCacheMaxSizeMB int64 `mapstructure:"plugin_cache_max_size_mb"`
 
-	// Enable environment variable override / 启用环境变量覆盖
-	v.SetEnvPrefix("AGENT")
-	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
-	v.AutomaticEnv()
-
-	// Read config file / 读取配置文件
-	if err := v.ReadInConfig(); err != nil {
-		// Config file not found is not an error if we have defaults
-		// 如果有默认值，配置文件未找到不是错误
-		var configFileNotFoundError viper.ConfigFileNotFoundError
-		if !errors.As(err, &configFileNotFoundError) {
-			// Check if file exists / 检查文件是否存在
-			if _, statErr := os.Stat(v.ConfigFileUsed()); statErr == nil {
-				return nil, fmt.Errorf("failed to read config file: %w", err)
-			}
-			// File doesn't exist, use defaults / 文件不存在，使用默认值
-		}
-	}
-
-	// Unmarshal config / 解析配置
-	var cfg Config
-	if err := v.Unmarshal(&cfg); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
-	}
+	// PluginCacheMaxAgeDays evicts plugin files not used for this many days (0 = never)
+	// PluginCacheMaxAgeDays 淘汰超过该天数未使用的插件文件（0 表示永不过期）
+	PluginCacheMaxAgeDays int `mapstructure:"plugin_cache_max_age_days"`
+}
 
-	return &cfg, nil
+// Load loads configuration from defaults, the config file and environment variables
+// Load 从默认值、配置文件和环境变量加载配置
+func Load(configPath string) (*Config, error) {
+	return LoadWithPriority(configPath, nil)
 }
 
 // setDefaults sets default configuration values
@@ -209,11 +232,19 @@ func Load(configPath string) (*Config, error) {
 func setDefaults(v *viper.Viper) {
 	// Agent defaults / Agent 默认值
 	v.SetDefault("agent.id", "")
+	v.SetDefault("agent.max_concurrent_commands", DefaultMaxConcurrentCommands)
+	v.SetDefault("agent.enrollment_token", "")
+	v.SetDefault("agent.secret_file", DefaultAgentSecretFile)
 
 	// Control Plane defaults / Control Plane 默认值
 	v.SetDefault("control_plane.addresses", []string{})
 	v.SetDefault("control_plane.tls.enabled", false)
+	v.SetDefault("control_plane.tls.cert_file", "")
+	v.SetDefault("control_plane.tls.key_file", "")
+	v.SetDefault("control_plane.tls.ca_file", "")
 	v.SetDefault("control_plane.token", "")
+	v.SetDefault("control_plane.failback_interval", DefaultFailbackInterval)
+	v.SetDefault("control_plane.failure_threshold", DefaultFailureThreshold)
 
 	// Heartbeat defaults / 心跳默认值
 	v.SetDefault("heartbeat.interval", DefaultHeartbeatInterval)
@@ -225,10 +256,19 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("log.max_backups", DefaultLogMaxBackups)
 	v.SetDefault("log.max_age", DefaultLogMaxAge)
 
+	// Telemetry defaults / 遥测默认值
+	v.SetDefault("telemetry.enabled", false)
+	v.SetDefault("telemetry.endpoint", DefaultTelemetryEndpoint)
+	v.SetDefault("telemetry.insecure", false)
+
 	// SeaTunnel defaults / SeaTunnel 默认值
 	// Note: config_dir and log_dir are automatically derived from install_dir
 	// 注意：config_dir 和 log_dir 自动基于 install_dir 计算
 	v.SetDefault("seatunnel.install_dir", DefaultSeaTunnelInstallDir)
+	v.SetDefault("seatunnel.package_cache_dir", DefaultPackageCacheDir)
+	v.SetDefault("seatunnel.plugin_cache_dir", DefaultPluginCacheDir)
+	v.SetDefault("seatunnel.plugin_cache_max_size_mb", DefaultPluginCacheMaxSize)
+	v.SetDefault("seatunnel.plugin_cache_max_age_days", DefaultPluginCacheMaxAge)
 }
 
 // Validate validates the configuration
@@ -238,6 +278,17 @@ func (c *Config) Validate() error {
 	if len(c.ControlPlane.Addresses) == 0 {
 		return errors.New("control_plane.addresses is required")
 	}
+	for i, addr := range c.ControlPlane.Addresses {
+		if strings.TrimSpace(addr) == "" {
+			return fmt.Errorf("control_plane.addresses[%d] must not be empty", i)
+		}
+	}
+	if c.ControlPlane.FailureThreshold < 0 {
+		return errors.New("control_plane.failure_threshold must not be negative")
+	}
+	if c.ControlPlane.FailbackInterval < 0 {
+		return errors.New("control_plane.failback_interval must not be negative")
+	}
 
 	// Validate TLS configuration / 验证 TLS 配置
 	if c.ControlPlane.TLS.Enabled {
@@ -255,11 +306,21 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("invalid log level: %s (must be debug, info, warn, or error)", c.Log.Level)
 	}
 
+	// Validate log rotation / 验证日志轮转参数
+	if c.Log.MaxSize < 0 || c.Log.MaxBackups < 0 || c.Log.MaxAge < 0 {
+		return errors.New("log.max_size, log.max_backups and log.max_age must not be negative")
+	}
+
 	// Validate heartbeat interval / 验证心跳间隔
 	if c.Heartbeat.Interval < time.Second {
 		return errors.New("heartbeat.interval must be at least 1 second")
 	}
 
+	// Validate telemetry / 验证遥测配置
+	if c.Telemetry.Enabled && strings.TrimSpace(c.Telemetry.Endpoint) == "" {
+		return errors.New("telemetry.endpoint is required when telemetry is enabled")
+	}
+
 	return nil
 }
 
@@ -306,6 +367,10 @@ seatunnel:
   # Note: config_dir and log_dir are automatically derived from install_dir
   # 注意：config_dir 和 log_dir 自动基于 install_dir 计算
   install_dir: "%s"
+  package_cache_dir: "%s"
+  plugin_cache_dir: "%s"
+  plugin_cache_max_size_mb: %d
+  plugin_cache_max_age_days: %d
 `,
 		c.Agent.ID,
 		formatAddresses(c.ControlPlane.Addresses),
@@ -321,6 +386,10 @@ seatunnel:
 		c.Log.MaxBackups,
 		c.Log.MaxAge,
 		c.SeaTunnel.InstallDir,
+		c.SeaTunnel.PackageCacheDir,
+		c.SeaTunnel.PluginCacheDir,
+		c.SeaTunnel.PluginCacheMaxSizeMB,
+		c.SeaTunnel.PluginCacheMaxAgeDays,
 	)
 	return []byte(yamlContent), nil
 }
@@ -409,7 +478,11 @@ func (c *Config) Equal(other *Config) bool {
 	// Compare SeaTunnel / 比较 SeaTunnel
 	// Note: Only compare InstallDir since ConfigDir and LogDir are derived from it
 	// 注意：只比较 InstallDir，因为 ConfigDir 和 LogDir 是从它派生的
-	if c.SeaTunnel.InstallDir != other.SeaTunnel.InstallDir {
+	if c.SeaTunnel.InstallDir != other.SeaTunnel.InstallDir ||
+		c.SeaTunnel.PackageCacheDir != other.SeaTunnel.PackageCacheDir ||
+		c.SeaTunnel.PluginCacheDir != other.SeaTunnel.PluginCacheDir ||
+		c.SeaTunnel.PluginCacheMaxSizeMB != other.SeaTunnel.PluginCacheMaxSizeMB ||
+		c.SeaTunnel.PluginCacheMaxAgeDays != other.SeaTunnel.PluginCacheMaxAgeDays {
 		return false
 	}
 
@@ -420,42 +493,48 @@ func (c *Config) Equal(other *Config) bool {
 // LoadWithPriority 使用显式优先级处理加载配置
 // Priority: cmdArgs > envVars > configFile > defaults
 // 优先级：命令行参数 > 环境变量 > 配置文件 > 默认值
+// Keys outside the schema are rejected in every layer (see UnknownKeysError).
+// 每一层中不属于配置结构的配置项都会被拒绝（见 UnknownKeysError）。
 func LoadWithPriority(configPath string, cmdArgs map[string]interface{}) (*Config, error) {
 	v := viper.New()
 
 	// Set default values / 设置默认值
 	setDefaults(v)
 
-	// Set config file path / 设置配置文件路径
-	if configPath != "" {
-		v.SetConfigFile(configPath)
-	} else {
-		// Check environment variable / 检查环境变量
-		envPath := os.Getenv("AGENT_CONFIG_PATH")
-		if envPath != "" {
-			v.SetConfigFile(envPath)
-		} else {
-			v.SetConfigFile(DefaultConfigPath)
+	// Read config file; a missing file falls back to defaults
+	// 读取配置文件；文件不存在时使用默认值
+	path := ResolveConfigPath(configPath)
+	v.SetConfigFile(path)
+	if _, statErr := os.Stat(path); statErr == nil {
+		if err := v.ReadInConfig(); err != nil {
+			return nil, fmt.Errorf("failed to read config file: %w", err)
+		}
+		fileKeys, err := configFileKeys(path)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkKeys(path, fileKeys); err != nil {
+			return nil, err
 		}
 	}
 
-	// Enable environment variable override / 启用环境变量覆盖
-	v.SetEnvPrefix("AGENT")
-	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
-	v.AutomaticEnv()
-
-	// Read config file / 读取配置文件
-	if err := v.ReadInConfig(); err != nil {
-		var configFileNotFoundError viper.ConfigFileNotFoundError
-		if !errors.As(err, &configFileNotFoundError) {
-			if _, statErr := os.Stat(v.ConfigFileUsed()); statErr == nil {
-				return nil, fmt.Errorf("failed to read config file: %w", err)
-			}
-		}
+	// Environment variable overrides / 环境变量覆盖
+	if err := checkEnv(os.Environ()); err != nil {
+		return nil, err
+	}
+	if err := bindEnv(v); err != nil {
+		return nil, fmt.Errorf("failed to bind environment variables: %w", err)
 	}
 
 	// Apply command line arguments (highest priority)
 	// 应用命令行参数（最高优先级）
+	flagKeys := make([]string, 0, len(cmdArgs))
+	for key := range cmdArgs {
+		flagKeys = append(flagKeys, key)
+	}
+	if err := checkKeys("command line flags", flagKeys); err != nil {
+		return nil, err
+	}
 	for key, value := range cmdArgs {
 		v.Set(key, value)
 	}
@@ -468,3 +547,14 @@ func LoadWithPriority(configPath string, cmdArgs map[string]interface{}) (*Confi
 
 	return &cfg, nil
 }
+
+// configFileKeys returns the keys set by the config file alone, without defaults
+// configFileKeys 返回仅由配置文件设置的配置项（不含默认值）
+func configFileKeys(path string) ([]string, error) {
+	v := viper.New()
+	v.SetConfigFile(path)
+	if err := v.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+	return v.AllKeys(), nil
+}
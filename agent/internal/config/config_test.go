@@ -292,3 +292,80 @@ control_plane:
 	// 环境变量应该覆盖默认值
 	assert.Equal(t, "debug", cfg.Log.Level)
 }
+
+// TestLoadLayeredConfig tests defaults < file < environment < flags and strict key checks
+// TestLoadLayeredConfig 测试 默认值 < 文件 < 环境变量 < 命令行参数 以及严格的配置项校验
+func TestLoadLayeredConfig(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+	err := os.WriteFile(configPath, []byte(`
+control_plane:
+  addresses:
+    - "localhost:9090"
+log:
+  level: warn
+  max_size: 20
+`), 0644)
+	require.NoError(t, err)
+
+	t.Setenv("AGENT_LOG_MAX_SIZE", "30")
+	t.Setenv("SEATUNNELX_AGENT_LOG_MAX_SIZE", "40")
+	t.Setenv("SEATUNNELX_AGENT_CONTROL_PLANE_ADDRESSES", "cp-1:9090,cp-2:9090")
+
+	cfg, err := LoadWithPriority(configPath, map[string]interface{}{"log.level": "error"})
+	require.NoError(t, err)
+	assert.Equal(t, "error", cfg.Log.Level)
+	assert.Equal(t, 40, cfg.Log.MaxSize)
+	assert.Equal(t, []string{"cp-1:9090", "cp-2:9090"}, cfg.ControlPlane.Addresses)
+	assert.Equal(t, DefaultLogMaxAge, cfg.Log.MaxAge)
+
+	t.Setenv("SEATUNNELX_AGENT_LOG_LEVL", "debug")
+	_, err = Load(configPath)
+	var unknown *UnknownKeysError
+	require.ErrorAs(t, err, &unknown)
+	assert.Equal(t, []string{"SEATUNNELX_AGENT_LOG_LEVL"}, unknown.Keys)
+	assert.Contains(t, err.Error(), "did you mean SEATUNNELX_AGENT_LOG_LEVEL?")
+	os.Unsetenv("SEATUNNELX_AGENT_LOG_LEVL")
+
+	_, err = LoadWithPriority(configPath, map[string]interface{}{"log.colour": "red"})
+	require.ErrorAs(t, err, &unknown)
+	assert.Equal(t, "command line flags", unknown.Source)
+}
+
+// TestLoadConfigRejectsUnknownFileKeys tests that unknown file keys are listed with suggestions
+// TestLoadConfigRejectsUnknownFileKeys 测试配置文件中的未知配置项会被列出并给出建议
+func TestLoadConfigRejectsUnknownFileKeys(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+	err := os.WriteFile(configPath, []byte(`
+agent:
+  install_dir: /opt/seatunnel
+control_plane:
+  addresses:
+    - "localhost:9090"
+  tokn: "secret"
+heartbeat:
+  intervall: 5s
+`), 0644)
+	require.NoError(t, err)
+
+	_, err = Load(configPath)
+	var unknown *UnknownKeysError
+	require.ErrorAs(t, err, &unknown)
+	assert.Equal(t, []string{"agent.install_dir", "control_plane.tokn", "heartbeat.intervall"}, unknown.Keys)
+	assert.Equal(t, "seatunnel.install_dir", unknown.Suggestions["agent.install_dir"])
+	assert.Equal(t, "control_plane.token", unknown.Suggestions["control_plane.tokn"])
+	assert.Equal(t, "heartbeat.interval", unknown.Suggestions["heartbeat.intervall"])
+}
+
+// TestParseSetFlags tests parsing of --set key=value flags
+// TestParseSetFlags 测试 --set key=value 参数解析
+func TestParseSetFlags(t *testing.T) {
+	overrides, err := ParseSetFlags([]string{"log.level=debug", "control_plane.addresses=a:1,b:2"})
+	require.NoError(t, err)
+	assert.Equal(t, "debug", overrides["log.level"])
+	assert.Equal(t, []string{"a:1", "b:2"}, overrides["control_plane.addresses"])
+
+	_, err = ParseSetFlags([]string{"log.level"})
+	assert.Error(t, err)
+}
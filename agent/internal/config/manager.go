@@ -24,7 +24,10 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
+
+	"gopkg.in/yaml.v3"
 )
 
 // ConfigType 配置文件类型
@@ -196,6 +199,110 @@ func (m *Manager) UpdateConfig(installDir string, configType string, content str
 	}, nil
 }
 
+// ApplyConfig 校验并原子地应用配置文件，应用前总是备份原文件
+// 写入失败时保留原文件不变；返回结果中包含备份文件路径
+func (m *Manager) ApplyConfig(installDir string, configType string, content string) (*UpdateConfigResult, error) {
+	ct := ConfigType(configType)
+	relativePath := GetConfigFilePath(ct)
+	if relativePath == "" {
+		return &UpdateConfigResult{
+			Success: false,
+			Message: fmt.Sprintf("unsupported config type: %s", configType),
+		}, nil
+	}
+	if err := validateConfigContent(ct, content); err != nil {
+		return &UpdateConfigResult{
+			Success: false,
+			Message: fmt.Sprintf("invalid %s: %v", configType, err),
+		}, nil
+	}
+
+	fullPath := filepath.Join(installDir, relativePath)
+
+	// 备份原文件（文件不存在时无需备份）
+	var backupPath string
+	if _, err := os.Stat(fullPath); err == nil {
+		backupPath, err = m.backupConfig(installDir, fullPath, configType)
+		if err != nil {
+			return &UpdateConfigResult{
+				Success: false,
+				Message: fmt.Sprintf("failed to backup config file: %v", err),
+			}, nil
+		}
+	}
+
+	dir := filepath.Dir(fullPath)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return &UpdateConfigResult{
+			Success:    false,
+			Message:    fmt.Sprintf("failed to create config directory: %v", err),
+			BackupPath: backupPath,
+		}, nil
+	}
+
+	// 先写临时文件再重命名，避免进程读到写了一半的配置
+	tmpFile, err := os.CreateTemp(dir, "."+filepath.Base(fullPath)+".*.tmp")
+	if err != nil {
+		return &UpdateConfigResult{
+			Success:    false,
+			Message:    fmt.Sprintf("failed to create temp config file: %v", err),
+			BackupPath: backupPath,
+		}, nil
+	}
+	tmpPath := tmpFile.Name()
+	_, writeErr := tmpFile.WriteString(content)
+	closeErr := tmpFile.Close()
+	if writeErr == nil {
+		writeErr = closeErr
+	}
+	if writeErr == nil {
+		writeErr = os.Chmod(tmpPath, 0644)
+	}
+	if writeErr == nil {
+		writeErr = os.Rename(tmpPath, fullPath)
+	}
+	if writeErr != nil {
+		_ = os.Remove(tmpPath)
+		return &UpdateConfigResult{
+			Success:    false,
+			Message:    fmt.Sprintf("failed to write config file: %v", writeErr),
+			BackupPath: backupPath,
+		}, nil
+	}
+
+	return &UpdateConfigResult{
+		Success:    true,
+		Message:    "config file applied successfully",
+		BackupPath: backupPath,
+	}, nil
+}
+
+// validateConfigContent 校验配置内容：YAML 文件必须可解析且根为映射，JVM 参数行必须以 '-' 开头
+func validateConfigContent(configType ConfigType, content string) error {
+	if strings.TrimSpace(content) == "" {
+		return fmt.Errorf("content is empty")
+	}
+	switch configType {
+	case ConfigTypeSeatunnel, ConfigTypeHazelcast, ConfigTypeHazelcastClient,
+		ConfigTypeHazelcastMaster, ConfigTypeHazelcastWorker:
+		var root yaml.Node
+		if err := yaml.Unmarshal([]byte(content), &root); err != nil {
+			return err
+		}
+		if len(root.Content) == 0 || root.Content[0].Kind != yaml.MappingNode {
+			return fmt.Errorf("root must be a mapping object")
+		}
+	case ConfigTypeJVMOptions, ConfigTypeJVMMasterOptions, ConfigTypeJVMWorkerOptions:
+		for i, line := range strings.Split(content, "\n") {
+			trimmed := strings.TrimSpace(line)
+			if trimmed != "" && !strings.HasPrefix(trimmed, "#") && !strings.HasPrefix(trimmed, "-") {
+				return fmt.Errorf("line %d must start with '-' or '#'", i+1)
+			}
+		}
+	}
+	return nil
+}
+
 // backupConfig 备份配置文件
 func (m *Manager) backupConfig(installDir, filePath, configType string) (string, error) {
 	// 创建备份目录
@@ -0,0 +1,63 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestApplyConfig tests validated config apply with backup
+// TestApplyConfig 测试带备份的配置校验与应用
+func TestApplyConfig(t *testing.T) {
+	installDir := t.TempDir()
+	configPath := filepath.Join(installDir, "config", "seatunnel.yaml")
+	require.NoError(t, os.MkdirAll(filepath.Dir(configPath), 0755))
+	require.NoError(t, os.WriteFile(configPath, []byte("seatunnel:\n  engine: {}\n"), 0644))
+
+	manager := NewManager()
+
+	// Invalid YAML is rejected and the file is left untouched / 非法 YAML 被拒绝且原文件不变
+	result, err := manager.ApplyConfig(installDir, string(ConfigTypeSeatunnel), "seatunnel: [\n")
+	require.NoError(t, err)
+	assert.False(t, result.Success)
+	content, err := os.ReadFile(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, "seatunnel:\n  engine: {}\n", string(content))
+
+	// Valid content is written and the old file is backed up / 合法内容被写入且原文件已备份
+	result, err = manager.ApplyConfig(installDir, string(ConfigTypeSeatunnel), "seatunnel:\n  engine:\n    backup-count: 2\n")
+	require.NoError(t, err)
+	require.True(t, result.Success, result.Message)
+	require.NotEmpty(t, result.BackupPath)
+	backup, err := os.ReadFile(result.BackupPath)
+	require.NoError(t, err)
+	assert.Equal(t, "seatunnel:\n  engine: {}\n", string(backup))
+	content, err = os.ReadFile(configPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "backup-count: 2")
+
+	// JVM options must be flags or comments / JVM 参数必须为参数或注释
+	result, err = manager.ApplyConfig(installDir, string(ConfigTypeJVMOptions), "-Xms2g\nXmx2g\n")
+	require.NoError(t, err)
+	assert.False(t, result.Success)
+}
@@ -0,0 +1,239 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// Environment variable names
+// 环境变量名
+const (
+	// EnvPrefix prefixes environment overrides, e.g. SEATUNNELX_AGENT_LOG_LEVEL overrides log.level
+	// EnvPrefix 是环境变量覆盖的前缀，例如 SEATUNNELX_AGENT_LOG_LEVEL 覆盖 log.level
+	EnvPrefix = "SEATUNNELX_AGENT"
+	// LegacyEnvPrefix is the older prefix, still honored with lower priority than EnvPrefix
+	// LegacyEnvPrefix 是旧前缀，仍然生效但优先级低于 EnvPrefix
+	LegacyEnvPrefix = "AGENT"
+	// EnvConfigPath selects the config file when --config is not given
+	// EnvConfigPath 在未指定 --config 时选择配置文件
+	EnvConfigPath = EnvPrefix + "_CONFIG"
+	// LegacyEnvConfigPath is the older name of EnvConfigPath
+	// LegacyEnvConfigPath 是 EnvConfigPath 的旧名称
+	LegacyEnvConfigPath = "AGENT_CONFIG_PATH"
+)
+
+// deprecatedKeys are accepted in config files for compatibility but ignored
+// deprecatedKeys 为兼容性在配置文件中被接受但会被忽略
+var deprecatedKeys = map[string]string{
+	"seatunnel.config_dir": "derived from seatunnel.install_dir",
+	"seatunnel.log_dir":    "derived from seatunnel.install_dir",
+}
+
+// UnknownKeysError reports config keys that are not part of the schema
+// UnknownKeysError 报告不属于配置结构的配置项
+type UnknownKeysError struct {
+	// Source is where the keys came from (file path, environment or flags)
+	// Source 是配置项的来源（文件路径、环境变量或命令行参数）
+	Source string
+	Keys   []string
+	// Suggestions maps an unknown key to the closest known key
+	// Suggestions 将未知配置项映射到最接近的已知配置项
+	Suggestions map[string]string
+}
+
+// Error implements the error interface
+// Error 实现 error 接口
+func (e *UnknownKeysError) Error() string {
+	parts := make([]string, 0, len(e.Keys))
+	for _, key := range e.Keys {
+		if suggestion := e.Suggestions[key]; suggestion != "" {
+			parts = append(parts, fmt.Sprintf("%s (did you mean %s?)", key, suggestion))
+			continue
+		}
+		parts = append(parts, key)
+	}
+	return fmt.Sprintf("unknown config keys in %s: %s / %s 中存在未知配置项: %s",
+		e.Source, strings.Join(parts, ", "), e.Source, strings.Join(parts, ", "))
+}
+
+// SchemaKeys returns every supported config key in dotted form, sorted
+// SchemaKeys 返回所有支持的配置项（点分形式，已排序）
+func SchemaKeys() []string {
+	v := viper.New()
+	setDefaults(v)
+	keys := v.AllKeys()
+	sort.Strings(keys)
+	return keys
+}
+
+// EnvName returns the environment variable that overrides a config key
+// EnvName 返回覆盖某个配置项的环境变量名
+func EnvName(key string) string {
+	return EnvPrefix + "_" + strings.ToUpper(strings.ReplaceAll(key, ".", "_"))
+}
+
+// checkKeys rejects keys outside the schema, suggesting the closest known key
+// checkKeys 拒绝不在配置结构中的配置项，并给出最接近的已知配置项
+func checkKeys(source string, keys []string) error {
+	known := SchemaKeys()
+	knownSet := make(map[string]bool, len(known))
+	for _, key := range known {
+		knownSet[key] = true
+	}
+
+	unknown := &UnknownKeysError{Source: source, Suggestions: map[string]string{}}
+	for _, key := range keys {
+		key = strings.ToLower(key)
+		if knownSet[key] {
+			continue
+		}
+		if _, deprecated := deprecatedKeys[key]; deprecated {
+			continue
+		}
+		unknown.Keys = append(unknown.Keys, key)
+		if suggestion := closestKey(key, known); suggestion != "" {
+			unknown.Suggestions[key] = suggestion
+		}
+	}
+	if len(unknown.Keys) == 0 {
+		return nil
+	}
+	sort.Strings(unknown.Keys)
+	return unknown
+}
+
+// checkEnv rejects SEATUNNELX_AGENT_* variables that do not map to a config key
+// checkEnv 拒绝无法映射到配置项的 SEATUNNELX_AGENT_* 环境变量
+func checkEnv(environ []string) error {
+	byEnv := map[string]string{}
+	for _, key := range SchemaKeys() {
+		byEnv[EnvName(key)] = key
+	}
+
+	var keys []string
+	for _, entry := range environ {
+		name, _, _ := strings.Cut(entry, "=")
+		if !strings.HasPrefix(name, EnvPrefix+"_") || name == EnvConfigPath {
+			continue
+		}
+		if key, ok := byEnv[name]; ok {
+			keys = append(keys, key)
+			continue
+		}
+		// Map the variable back to a dotted key so suggestions can be computed
+		// 将环境变量还原为点分形式以便给出建议
+		keys = append(keys, strings.ToLower(strings.TrimPrefix(name, EnvPrefix+"_")))
+	}
+	if err := checkKeys("environment", keys); err != nil {
+		unknown := err.(*UnknownKeysError)
+		for i, key := range unknown.Keys {
+			name := EnvPrefix + "_" + strings.ToUpper(key)
+			if suggestion := unknown.Suggestions[key]; suggestion != "" {
+				unknown.Suggestions[name] = EnvName(suggestion)
+			}
+			unknown.Keys[i] = name
+		}
+		return unknown
+	}
+	return nil
+}
+
+// bindEnv binds every schema key to SEATUNNELX_AGENT_<KEY>, falling back to AGENT_<KEY>
+// bindEnv 将每个配置项绑定到 SEATUNNELX_AGENT_<KEY>，并回退到 AGENT_<KEY>
+func bindEnv(v *viper.Viper) error {
+	for _, key := range SchemaKeys() {
+		suffix := strings.ToUpper(strings.ReplaceAll(key, ".", "_"))
+		if err := v.BindEnv(key, EnvPrefix+"_"+suffix, LegacyEnvPrefix+"_"+suffix); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ParseSetFlags parses repeated --set key=value flags into config overrides
+// ParseSetFlags 将重复的 --set key=value 参数解析为配置覆盖值
+func ParseSetFlags(values []string) (map[string]interface{}, error) {
+	overrides := make(map[string]interface{}, len(values))
+	for _, value := range values {
+		key, raw, ok := strings.Cut(value, "=")
+		key = strings.ToLower(strings.TrimSpace(key))
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid --set %q: expected key=value / 无效的 --set %q：应为 key=value", value, value)
+		}
+		if key == "control_plane.addresses" {
+			overrides[key] = strings.Split(raw, ",")
+			continue
+		}
+		overrides[key] = raw
+	}
+	return overrides, nil
+}
+
+// ResolveConfigPath returns the explicit path, then SEATUNNELX_AGENT_CONFIG, AGENT_CONFIG_PATH and the default
+// ResolveConfigPath 依次返回显式路径、SEATUNNELX_AGENT_CONFIG、AGENT_CONFIG_PATH 与默认路径
+func ResolveConfigPath(configPath string) string {
+	for _, candidate := range []string{configPath, os.Getenv(EnvConfigPath), os.Getenv(LegacyEnvConfigPath)} {
+		if strings.TrimSpace(candidate) != "" {
+			return candidate
+		}
+	}
+	return DefaultConfigPath
+}
+
+// closestKey returns the known key nearest to key: the same leaf name in another section,
+// otherwise the one within a small edit distance
+// closestKey 返回与 key 最接近的已知配置项：优先选择其他分组中同名的叶子项，否则选择编辑距离较小者
+func closestKey(key string, known []string) string {
+	leaf := key[strings.LastIndex(key, ".")+1:]
+	best, bestDistance := "", 4
+	for _, candidate := range known {
+		if candidate[strings.LastIndex(candidate, ".")+1:] == leaf {
+			return candidate
+		}
+		if distance := editDistance(key, candidate); distance < bestDistance {
+			best, bestDistance = candidate, distance
+		}
+	}
+	return best
+}
+
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
@@ -0,0 +1,76 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// LoadAgentSecret reads the Agent secret from path; a missing file yields an empty secret.
+// LoadAgentSecret 从 path 读取 Agent 密钥，文件不存在时返回空密钥。
+func LoadAgentSecret(path string) (string, error) {
+	if path == "" {
+		return "", nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to read agent secret: %w", err)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// SaveAgentSecret writes the Agent secret to path, readable by the owner only.
+// The file is replaced atomically so a crash never leaves a truncated secret.
+// SaveAgentSecret 将 Agent 密钥写入 path，仅所有者可读；文件以原子方式替换，崩溃时不会留下截断的密钥。
+func SaveAgentSecret(path, secret string) error {
+	if path == "" {
+		return fmt.Errorf("agent secret file is not configured")
+	}
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return fmt.Errorf("failed to create agent secret directory: %w", err)
+	}
+	tmpFile, err := os.CreateTemp(dir, "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create temp agent secret file: %w", err)
+	}
+	tmpPath := tmpFile.Name()
+	_, writeErr := tmpFile.WriteString(secret + "\n")
+	closeErr := tmpFile.Close()
+	if writeErr == nil {
+		writeErr = closeErr
+	}
+	if writeErr == nil {
+		writeErr = os.Chmod(tmpPath, 0600)
+	}
+	if writeErr == nil {
+		writeErr = os.Rename(tmpPath, path)
+	}
+	if writeErr != nil {
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("failed to write agent secret: %w", writeErr)
+	}
+	return nil
+}
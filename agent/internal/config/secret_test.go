@@ -0,0 +1,57 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestAgentSecretRoundTrip tests that a saved secret is read back and kept private.
+// TestAgentSecretRoundTrip 测试保存的密钥可读回且仅所有者可读。
+func TestAgentSecretRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state", "agent.secret")
+
+	secret, err := LoadAgentSecret(path)
+	if err != nil || secret != "" {
+		t.Fatalf("missing file should yield an empty secret, got %q (%v)", secret, err)
+	}
+
+	if err := SaveAgentSecret(path, "s3cret"); err != nil {
+		t.Fatalf("SaveAgentSecret returned error: %v", err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("stat secret file: %v", err)
+	}
+	if perm := info.Mode().Perm(); perm != 0600 {
+		t.Fatalf("expected mode 0600, got %o", perm)
+	}
+	secret, err = LoadAgentSecret(path)
+	if err != nil || secret != "s3cret" {
+		t.Fatalf("expected s3cret, got %q (%v)", secret, err)
+	}
+
+	if err := SaveAgentSecret(path, "rotated"); err != nil {
+		t.Fatalf("SaveAgentSecret returned error: %v", err)
+	}
+	if secret, _ := LoadAgentSecret(path); secret != "rotated" {
+		t.Fatalf("expected rotated secret, got %q", secret)
+	}
+}
@@ -0,0 +1,43 @@
+//go:build windows
+// +build windows
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package diagnostics
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"golang.org/x/sys/windows"
+)
+
+// getAvailableBytes returns the free space available to the Agent on the drive holding path
+// getAvailableBytes 返回 path 所在驱动器上 Agent 可用的空闲空间
+func getAvailableBytes(path string) (int64, error) {
+	cleanPath := filepath.Clean(path)
+	dir, err := windows.UTF16PtrFromString(cleanPath)
+	if err != nil {
+		return 0, err
+	}
+	var freeBytesAvailable, totalBytes, totalFreeBytes uint64
+	if err := windows.GetDiskFreeSpaceEx(dir, &freeBytesAvailable, &totalBytes, &totalFreeBytes); err != nil {
+		return 0, fmt.Errorf("GetDiskFreeSpaceEx %s: %w", cleanPath, err)
+	}
+	return int64(freeBytesAvailable), nil
+}
@@ -29,7 +29,6 @@ import (
 	"runtime"
 	"strconv"
 	"strings"
-	"syscall"
 	"time"
 )
 
@@ -318,15 +317,6 @@ func estimateJVMDumpBytes(pid int) int64 {
 	return rssPages * 4096
 }
 
-func getAvailableBytes(path string) (int64, error) {
-	cleanPath := filepath.Clean(path)
-	var stat syscall.Statfs_t
-	if err := syscall.Statfs(cleanPath, &stat); err != nil {
-		return 0, fmt.Errorf("statfs %s: %w", cleanPath, err)
-	}
-	return int64(stat.Bavail) * int64(stat.Bsize), nil
-}
-
 func buildDumpFileName(prefix, role, ext string) string {
 	return fmt.Sprintf("%s-%s-%s.%s", prefix, normalizeRole(role), time.Now().UTC().Format("20060102-150405"), ext)
 }
@@ -0,0 +1,285 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package diagnostics
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DiagnoseAction identifies one section collected by the DIAGNOSE command.
+// DiagnoseAction 标识 DIAGNOSE 指令采集的一个诊断片段。
+type DiagnoseAction string
+
+const (
+	DiagnoseActionThreadDump    DiagnoseAction = "thread_dump"
+	DiagnoseActionHeapInfo      DiagnoseAction = "heap_info"
+	DiagnoseActionHeapHistogram DiagnoseAction = "heap_histogram"
+)
+
+// MaxDiagnoseSectionBytes caps the content returned inline for each section;
+// the full output is always kept in the section's output file.
+// MaxDiagnoseSectionBytes 限制每个片段内联返回的内容大小，完整输出始终保存在输出文件中。
+const MaxDiagnoseSectionBytes = 1 << 20
+
+// JattachEnv overrides the location of the bundled jattach binary.
+// JattachEnv 用于覆盖内置 jattach 可执行文件的位置。
+const JattachEnv = "SEATUNNELX_JATTACH"
+
+// DefaultDiagnoseActions is used when the request does not specify actions.
+// DefaultDiagnoseActions 在请求未指定采集项时使用。
+var DefaultDiagnoseActions = []DiagnoseAction{DiagnoseActionThreadDump, DiagnoseActionHeapInfo}
+
+// ErrUnknownDiagnoseAction is returned for unsupported diagnose actions.
+// ErrUnknownDiagnoseAction 表示不支持的诊断采集项。
+var ErrUnknownDiagnoseAction = errors.New("unknown diagnose action")
+
+type DiagnoseSection struct {
+	Action     DiagnoseAction `json:"action"`
+	Tool       string         `json:"tool,omitempty"`
+	OutputPath string         `json:"output_path,omitempty"`
+	SizeBytes  int64          `json:"size_bytes"`
+	Truncated  bool           `json:"truncated"`
+	Content    string         `json:"content,omitempty"`
+	Error      string         `json:"error,omitempty"`
+}
+
+type DiagnoseResult struct {
+	PID         int               `json:"pid"`
+	Role        string            `json:"role"`
+	InstallDir  string            `json:"install_dir"`
+	Sections    []DiagnoseSection `json:"sections"`
+	CollectedAt time.Time         `json:"collected_at"`
+}
+
+// Failed reports whether no section could be collected.
+// Failed 表示是否所有片段都采集失败。
+func (r *DiagnoseResult) Failed() bool {
+	for _, section := range r.Sections {
+		if section.Error == "" {
+			return false
+		}
+	}
+	return true
+}
+
+// diagnoseInvocation is one way of running a JVM attach tool for an action.
+// diagnoseInvocation 表示针对某个采集项调用 JVM attach 工具的一种方式。
+type diagnoseInvocation struct {
+	tool string
+	args func(pid string) []string
+}
+
+var diagnoseInvocations = map[DiagnoseAction][]diagnoseInvocation{
+	DiagnoseActionThreadDump: {
+		{tool: "jcmd", args: func(pid string) []string { return []string{pid, "Thread.print", "-l"} }},
+		{tool: "jstack", args: func(pid string) []string { return []string{"-l", pid} }},
+		{tool: "jattach", args: func(pid string) []string { return []string{pid, "threaddump"} }},
+	},
+	DiagnoseActionHeapInfo: {
+		{tool: "jcmd", args: func(pid string) []string { return []string{pid, "GC.heap_info"} }},
+		{tool: "jattach", args: func(pid string) []string { return []string{pid, "jcmd", "GC.heap_info"} }},
+	},
+	DiagnoseActionHeapHistogram: {
+		{tool: "jcmd", args: func(pid string) []string { return []string{pid, "GC.class_histogram"} }},
+		{tool: "jmap", args: func(pid string) []string { return []string{"-histo", pid} }},
+		{tool: "jattach", args: func(pid string) []string { return []string{pid, "inspectheap"} }},
+	},
+}
+
+// Hooks replaced in tests.
+// 测试中替换的钩子。
+var (
+	locateSeaTunnelPID = findSeaTunnelPID
+	locateDiagnoseTool = lookupDiagnoseTool
+	runDiagnoseTool    = func(ctx context.Context, path string, args []string) ([]byte, error) {
+		return exec.CommandContext(ctx, path, args...).CombinedOutput()
+	}
+)
+
+// ParseDiagnoseActions parses a comma separated action list, falling back to
+// DefaultDiagnoseActions when empty. Duplicates are dropped.
+// ParseDiagnoseActions 解析逗号分隔的采集项列表，为空时使用 DefaultDiagnoseActions，重复项会被忽略。
+func ParseDiagnoseActions(raw string) ([]DiagnoseAction, error) {
+	if strings.TrimSpace(raw) == "" {
+		return append([]DiagnoseAction(nil), DefaultDiagnoseActions...), nil
+	}
+	seen := make(map[DiagnoseAction]bool)
+	var actions []DiagnoseAction
+	for _, part := range strings.Split(raw, ",") {
+		action := DiagnoseAction(strings.ToLower(strings.TrimSpace(part)))
+		if action == "" || seen[action] {
+			continue
+		}
+		if _, ok := diagnoseInvocations[action]; !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownDiagnoseAction, action)
+		}
+		seen[action] = true
+		actions = append(actions, action)
+	}
+	if len(actions) == 0 {
+		return append([]DiagnoseAction(nil), DefaultDiagnoseActions...), nil
+	}
+	return actions, nil
+}
+
+// CollectJVMDiagnosis locates the SeaTunnel JVM and collects each requested
+// section in order. A failing section is recorded and does not stop the others;
+// onSection, when set, is called as soon as each section finishes.
+// CollectJVMDiagnosis 定位 SeaTunnel JVM 并按顺序采集各诊断片段。单个片段失败会被记录而不影响其他片段；
+// 若设置了 onSection，则每个片段完成后立即回调。
+func CollectJVMDiagnosis(ctx context.Context, installDir, role, outputDir string, actions []DiagnoseAction, onSection func(DiagnoseSection)) (*DiagnoseResult, error) {
+	if len(actions) == 0 {
+		actions = DefaultDiagnoseActions
+	}
+	pid, err := locateSeaTunnelPID(installDir, role)
+	if err != nil {
+		return nil, fmt.Errorf("未找到 SeaTunnel 进程 / failed to locate SeaTunnel process: %w", err)
+	}
+	if outputDir == "" {
+		outputDir = filepath.Join(installDir, "logs", "diagnostics")
+	}
+	if err := os.MkdirAll(outputDir, 0o755); err != nil {
+		return nil, fmt.Errorf("create diagnostics output dir: %w", err)
+	}
+
+	result := &DiagnoseResult{
+		PID:         pid,
+		Role:        normalizeRole(role),
+		InstallDir:  installDir,
+		Sections:    make([]DiagnoseSection, 0, len(actions)),
+		CollectedAt: time.Now().UTC(),
+	}
+	for _, action := range actions {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		section := collectDiagnoseSection(ctx, pid, role, outputDir, action)
+		result.Sections = append(result.Sections, section)
+		if onSection != nil {
+			onSection(section)
+		}
+	}
+	return result, nil
+}
+
+func collectDiagnoseSection(ctx context.Context, pid int, role, outputDir string, action DiagnoseAction) DiagnoseSection {
+	section := DiagnoseSection{Action: action}
+	output, tool, err := runDiagnoseAction(ctx, pid, action)
+	if err != nil {
+		section.Error = err.Error()
+		return section
+	}
+	section.Tool = tool
+	section.SizeBytes = int64(len(output))
+
+	filePath := filepath.Join(outputDir, buildDumpFileName(strings.ReplaceAll(string(action), "_", "-"), role, "txt"))
+	if err := os.WriteFile(filePath, output, 0o644); err != nil {
+		section.Error = fmt.Sprintf("write %s output: %v", action, err)
+	} else {
+		section.OutputPath = filePath
+	}
+
+	if len(output) > MaxDiagnoseSectionBytes {
+		output = output[:MaxDiagnoseSectionBytes]
+		section.Truncated = true
+	}
+	section.Content = string(output)
+	return section
+}
+
+func runDiagnoseAction(ctx context.Context, pid int, action DiagnoseAction) ([]byte, string, error) {
+	invocations, ok := diagnoseInvocations[action]
+	if !ok {
+		return nil, "", fmt.Errorf("%w: %s", ErrUnknownDiagnoseAction, action)
+	}
+	pidArg := strconv.Itoa(pid)
+	var tried []string
+	var failures []string
+	for _, invocation := range invocations {
+		tried = append(tried, invocation.tool)
+		path, err := locateDiagnoseTool(invocation.tool)
+		if err != nil {
+			continue
+		}
+		output, cmdErr := runDiagnoseTool(ctx, path, invocation.args(pidArg))
+		if cmdErr == nil {
+			return output, invocation.tool, nil
+		}
+		failures = append(failures, formatCommandFailure(invocation.tool, cmdErr, output))
+	}
+	if len(failures) == 0 {
+		return nil, "", fmt.Errorf("未检测到可用的诊断工具（%s），请安装 JDK 或在 Agent 目录放置 jattach / none of %s found; install a JDK or place jattach next to the agent", strings.Join(tried, "/"), strings.Join(tried, "/"))
+	}
+	return nil, "", fmt.Errorf("%s 采集失败 / failed to collect %s: %s", action, action, strings.Join(failures, "; "))
+}
+
+// lookupDiagnoseTool resolves a JDK tool from PATH. jattach is additionally
+// looked up via JattachEnv and next to the agent executable, where release
+// packages bundle it for hosts that only have a JRE.
+// lookupDiagnoseTool 从 PATH 中查找 JDK 工具。jattach 还会通过 JattachEnv 以及 Agent 可执行文件所在目录查找，
+// 以便在仅安装 JRE 的主机上使用随发布包附带的 jattach。
+func lookupDiagnoseTool(tool string) (string, error) {
+	if tool != "jattach" {
+		return exec.LookPath(tool)
+	}
+	if override := strings.TrimSpace(os.Getenv(JattachEnv)); override != "" {
+		if isExecutableFile(override) {
+			return override, nil
+		}
+	}
+	if path, err := exec.LookPath(tool); err == nil {
+		return path, nil
+	}
+	if exe, err := os.Executable(); err == nil {
+		dir := filepath.Dir(exe)
+		for _, name := range []string{"jattach", fmt.Sprintf("jattach-%s-%s", runtime.GOOS, runtime.GOARCH)} {
+			for _, candidate := range []string{filepath.Join(dir, name), filepath.Join(dir, "tools", name)} {
+				if isExecutableFile(candidate) {
+					return candidate, nil
+				}
+			}
+		}
+	}
+	return "", exec.ErrNotFound
+}
+
+func isExecutableFile(path string) bool {
+	info, err := os.Stat(path)
+	if err != nil || info.IsDir() {
+		return false
+	}
+	return runtime.GOOS == "windows" || info.Mode()&0o111 != 0
+}
+
+func MarshalDiagnoseResult(result *DiagnoseResult) (string, error) {
+	payload, err := json.Marshal(result)
+	if err != nil {
+		return "", err
+	}
+	return string(payload), nil
+}
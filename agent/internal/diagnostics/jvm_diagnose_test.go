@@ -0,0 +1,129 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package diagnostics
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func stubDiagnoseHooks(t *testing.T, tools map[string]bool, run func(path string, args []string) ([]byte, error)) {
+	t.Helper()
+	origPID, origLocate, origRun := locateSeaTunnelPID, locateDiagnoseTool, runDiagnoseTool
+	t.Cleanup(func() {
+		locateSeaTunnelPID, locateDiagnoseTool, runDiagnoseTool = origPID, origLocate, origRun
+	})
+	locateSeaTunnelPID = func(string, string) (int, error) { return 4242, nil }
+	locateDiagnoseTool = func(tool string) (string, error) {
+		if tools[tool] {
+			return "/usr/bin/" + tool, nil
+		}
+		return "", errors.New("not found")
+	}
+	runDiagnoseTool = func(_ context.Context, path string, args []string) ([]byte, error) {
+		return run(path, args)
+	}
+}
+
+func TestParseDiagnoseActions(t *testing.T) {
+	actions, err := ParseDiagnoseActions("")
+	require.NoError(t, err)
+	assert.Equal(t, DefaultDiagnoseActions, actions)
+
+	actions, err = ParseDiagnoseActions(" Heap_Histogram, thread_dump,heap_histogram ")
+	require.NoError(t, err)
+	assert.Equal(t, []DiagnoseAction{DiagnoseActionHeapHistogram, DiagnoseActionThreadDump}, actions)
+
+	_, err = ParseDiagnoseActions("thread_dump,heap_dump")
+	assert.ErrorIs(t, err, ErrUnknownDiagnoseAction)
+}
+
+func TestCollectJVMDiagnosisFallsBackToJattach(t *testing.T) {
+	var calls []string
+	stubDiagnoseHooks(t, map[string]bool{"jattach": true}, func(path string, args []string) ([]byte, error) {
+		calls = append(calls, path+" "+strings.Join(args, " "))
+		return []byte("output of " + strings.Join(args[1:], " ")), nil
+	})
+
+	outputDir := t.TempDir()
+	var streamed []DiagnoseAction
+	result, err := CollectJVMDiagnosis(context.Background(), "/opt/seatunnel", "master", outputDir,
+		[]DiagnoseAction{DiagnoseActionThreadDump, DiagnoseActionHeapInfo},
+		func(section DiagnoseSection) { streamed = append(streamed, section.Action) })
+	require.NoError(t, err)
+
+	assert.Equal(t, 4242, result.PID)
+	assert.Equal(t, []string{
+		"/usr/bin/jattach 4242 threaddump",
+		"/usr/bin/jattach 4242 jcmd GC.heap_info",
+	}, calls)
+	assert.Equal(t, []DiagnoseAction{DiagnoseActionThreadDump, DiagnoseActionHeapInfo}, streamed)
+	require.Len(t, result.Sections, 2)
+	assert.False(t, result.Failed())
+	for _, section := range result.Sections {
+		assert.Equal(t, "jattach", section.Tool)
+		assert.Empty(t, section.Error)
+		data, readErr := os.ReadFile(section.OutputPath)
+		require.NoError(t, readErr)
+		assert.Equal(t, section.Content, string(data))
+	}
+}
+
+func TestCollectJVMDiagnosisRecordsSectionFailures(t *testing.T) {
+	stubDiagnoseHooks(t, map[string]bool{"jcmd": true, "jstack": true}, func(path string, args []string) ([]byte, error) {
+		if strings.HasSuffix(path, "jcmd") {
+			return []byte("AttachNotSupportedException"), errors.New("exit status 1")
+		}
+		return []byte(strings.Repeat("x", MaxDiagnoseSectionBytes+10)), nil
+	})
+
+	result, err := CollectJVMDiagnosis(context.Background(), "/opt/seatunnel", "", t.TempDir(),
+		[]DiagnoseAction{DiagnoseActionThreadDump, DiagnoseActionHeapInfo}, nil)
+	require.NoError(t, err)
+	require.Len(t, result.Sections, 2)
+
+	threadDump := result.Sections[0]
+	assert.Equal(t, "jstack", threadDump.Tool)
+	assert.True(t, threadDump.Truncated)
+	assert.Len(t, threadDump.Content, MaxDiagnoseSectionBytes)
+	assert.EqualValues(t, MaxDiagnoseSectionBytes+10, threadDump.SizeBytes)
+
+	heapInfo := result.Sections[1]
+	assert.Contains(t, heapInfo.Error, "AttachNotSupportedException")
+	assert.Empty(t, heapInfo.OutputPath)
+	assert.False(t, result.Failed())
+}
+
+func TestCollectJVMDiagnosisReportsMissingTools(t *testing.T) {
+	stubDiagnoseHooks(t, nil, func(string, []string) ([]byte, error) {
+		t.Fatal("no tool should run")
+		return nil, nil
+	})
+
+	result, err := CollectJVMDiagnosis(context.Background(), "/opt/seatunnel", "worker", t.TempDir(),
+		[]DiagnoseAction{DiagnoseActionHeapHistogram}, nil)
+	require.NoError(t, err)
+	assert.True(t, result.Failed())
+	assert.Contains(t, result.Sections[0].Error, "jcmd/jmap/jattach")
+}
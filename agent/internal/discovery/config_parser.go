@@ -187,7 +187,7 @@ func (r *ConfigReader) parseHazelcastPort(filePath string) int {
 		trimmed := strings.TrimSpace(line)
 
 		// Check if we're entering port section / 检查是否进入 port 部分
-		if strings.HasPrefix(trimmed, "port:") && !strings.Contains(trimmed, "port:") {
+		if strings.HasPrefix(trimmed, "port:") {
 			// "port:" alone means we're entering a section
 			// 单独的 "port:" 表示进入一个部分
 			if trimmed == "port:" {
@@ -0,0 +1,124 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package discovery
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ErrNotSeaTunnelInstall indicates the directory does not look like a SeaTunnel installation.
+// ErrNotSeaTunnelInstall 表示该目录不是 SeaTunnel 安装目录。
+var ErrNotSeaTunnelInstall = errors.New("not a SeaTunnel installation directory / 不是 SeaTunnel 安装目录")
+
+// InstallInspection describes an existing SeaTunnel installation found on this host.
+// InstallInspection 描述在本机发现的已有 SeaTunnel 安装。
+type InstallInspection struct {
+	InstallDir string `json:"install_dir"`
+	Version    string `json:"version"`
+	// DeploymentMode is "separated" when role-specific hazelcast configs exist, otherwise "hybrid".
+	// DeploymentMode 在存在角色专用 hazelcast 配置时为 "separated"，否则为 "hybrid"。
+	DeploymentMode string `json:"deployment_mode"`
+	// HazelcastPort is the master (or hybrid) member port; WorkerPort is only read in separated mode.
+	// HazelcastPort 为 master（或混合模式）成员端口；WorkerPort 仅在分离模式下读取。
+	HazelcastPort int      `json:"hazelcast_port"`
+	WorkerPort    int      `json:"worker_port,omitempty"`
+	APIPort       int      `json:"api_port,omitempty"`
+	ConfigFiles   []string `json:"config_files"`
+	// Processes lists the SeaTunnel processes running from the directory.
+	// Processes 列出从该目录运行的 SeaTunnel 进程。
+	Processes []InstallProcess `json:"processes"`
+}
+
+// InstallProcess is a SeaTunnel process running from an inspected installation.
+// InstallProcess 是从被检查安装目录运行的 SeaTunnel 进程。
+type InstallProcess struct {
+	PID  int    `json:"pid"`
+	Role string `json:"role"`
+}
+
+// InspectInstallDir inspects an installation directory and the process running from it, if any.
+// InspectInstallDir 检查安装目录以及从该目录运行的进程（如有）。
+func (d *ProcessDiscovery) InspectInstallDir(installDir string) (*InstallInspection, error) {
+	processes, err := d.DiscoverProcesses()
+	if err != nil {
+		return nil, err
+	}
+	return inspectInstallDir(installDir, processes)
+}
+
+// inspectInstallDir detects version, ports and config files of installDir and matches it against processes.
+// inspectInstallDir 检测 installDir 的版本、端口和配置文件，并与进程列表匹配。
+func inspectInstallDir(installDir string, processes []*DiscoveredProcess) (*InstallInspection, error) {
+	installDir = filepath.Clean(strings.TrimSpace(installDir))
+	if !filepath.IsAbs(installDir) {
+		return nil, fmt.Errorf("install dir must be an absolute path: %s / 安装目录必须为绝对路径: %s", installDir, installDir)
+	}
+	configDir := filepath.Join(installDir, "config")
+	if _, err := os.Stat(filepath.Join(configDir, "seatunnel.yaml")); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrNotSeaTunnelInstall, installDir)
+	}
+
+	inspection := &InstallInspection{
+		InstallDir:     installDir,
+		Version:        NewVersionDetector().DetectVersion(installDir),
+		DeploymentMode: "hybrid",
+		ConfigFiles:    make([]string, 0),
+		Processes:      make([]InstallProcess, 0),
+	}
+	if fileExists(filepath.Join(configDir, "hazelcast-master.yaml")) && fileExists(filepath.Join(configDir, "hazelcast-worker.yaml")) {
+		inspection.DeploymentMode = "separated"
+	}
+
+	for _, proc := range processes {
+		if proc != nil && filepath.Clean(proc.InstallDir) == installDir {
+			inspection.Processes = append(inspection.Processes, InstallProcess{PID: proc.PID, Role: proc.Role})
+		}
+	}
+
+	reader := NewConfigReader()
+	if inspection.DeploymentMode == "separated" {
+		inspection.HazelcastPort = reader.ReadHazelcastPort(installDir, "master")
+		inspection.WorkerPort = reader.ReadHazelcastPort(installDir, "worker")
+	} else {
+		inspection.HazelcastPort = reader.ReadHazelcastPort(installDir, "hybrid")
+	}
+	inspection.APIPort = reader.ReadAPIPort(installDir)
+
+	entries, err := os.ReadDir(configDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config dir: %w / 读取配置目录失败: %w", err, err)
+	}
+	for _, entry := range entries {
+		if entry.Type().IsRegular() {
+			inspection.ConfigFiles = append(inspection.ConfigFiles, filepath.ToSlash(filepath.Join("config", entry.Name())))
+		}
+	}
+	sort.Strings(inspection.ConfigFiles)
+
+	return inspection, nil
+}
+
+func fileExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir()
+}
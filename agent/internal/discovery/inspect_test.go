@@ -0,0 +1,70 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package discovery
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestInspectInstallDir(t *testing.T) {
+	installDir := t.TempDir()
+	files := map[string]string{
+		"config/seatunnel.yaml":                "seatunnel:\n  engine:\n    http:\n      enable-http: true\n      port: 8090\n",
+		"config/hazelcast-master.yaml":         "hazelcast:\n  network:\n    port:\n      port: 5901\n",
+		"config/hazelcast-worker.yaml":         "hazelcast:\n  network:\n    port:\n      port: 5902\n",
+		"lib/seatunnel-engine-core-2.3.12.jar": "",
+	}
+	for name, content := range files {
+		path := filepath.Join(installDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("failed to create dir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	inspection, err := inspectInstallDir(installDir, []*DiscoveredProcess{
+		{PID: 4321, InstallDir: installDir, Role: "master"},
+		{PID: 9876, InstallDir: "/opt/other-seatunnel", Role: "worker"},
+	})
+	if err != nil {
+		t.Fatalf("inspectInstallDir returned error: %v", err)
+	}
+	if inspection.Version != "2.3.12" || inspection.DeploymentMode != "separated" {
+		t.Fatalf("unexpected version or mode: %+v", inspection)
+	}
+	if len(inspection.Processes) != 1 || inspection.Processes[0].Role != "master" || inspection.Processes[0].PID != 4321 {
+		t.Fatalf("expected running master process to be matched, got %+v", inspection)
+	}
+	if inspection.HazelcastPort != 5901 || inspection.WorkerPort != 5902 || inspection.APIPort != 8090 {
+		t.Fatalf("unexpected ports: %+v", inspection)
+	}
+	if len(inspection.ConfigFiles) != 3 || inspection.ConfigFiles[0] != "config/hazelcast-master.yaml" {
+		t.Fatalf("unexpected config files: %v", inspection.ConfigFiles)
+	}
+}
+
+func TestInspectInstallDirRejectsNonSeaTunnelDir(t *testing.T) {
+	if _, err := inspectInstallDir(t.TempDir(), nil); !errors.Is(err, ErrNotSeaTunnelInstall) {
+		t.Fatalf("expected ErrNotSeaTunnelInstall, got %v", err)
+	}
+}
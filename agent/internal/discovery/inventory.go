@@ -0,0 +1,64 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package discovery
+
+import (
+	"sync"
+	"time"
+)
+
+// DefaultInventoryTTL is how long a SeaTunnel process inventory is reused before the host is scanned again.
+// DefaultInventoryTTL 是 SeaTunnel 进程清单在重新扫描主机前被复用的时长。
+const DefaultInventoryTTL = time.Minute
+
+// Inventory keeps a periodically refreshed list of the SeaTunnel processes running on this host,
+// so heartbeats can report it without scanning processes on every beat.
+// Inventory 维护本机运行中 SeaTunnel 进程的定期刷新列表，使心跳无需每次都扫描进程即可上报。
+type Inventory struct {
+	scan      func() ([]*DiscoveredProcess, error)
+	ttl       time.Duration
+	mu        sync.Mutex
+	processes []*DiscoveredProcess
+	scannedAt time.Time
+}
+
+// NewInventory creates an Inventory that rescans after ttl (DefaultInventoryTTL when ttl <= 0).
+// NewInventory 创建在 ttl 后重新扫描的 Inventory（ttl <= 0 时使用 DefaultInventoryTTL）。
+func NewInventory(ttl time.Duration) *Inventory {
+	if ttl <= 0 {
+		ttl = DefaultInventoryTTL
+	}
+	return &Inventory{scan: NewProcessScanner().ScanProcesses, ttl: ttl}
+}
+
+// Snapshot returns the latest process list and when it was scanned, rescanning once it is stale.
+// A failed scan keeps the previous list; ok is false until one scan has succeeded.
+// Snapshot 返回最新的进程列表及其扫描时间，过期时重新扫描。
+// 扫描失败时保留上一份列表；在首次扫描成功前 ok 为 false。
+func (i *Inventory) Snapshot() (processes []*DiscoveredProcess, scannedAt time.Time, ok bool) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if i.scannedAt.IsZero() || time.Since(i.scannedAt) >= i.ttl {
+		if scanned, err := i.scan(); err == nil {
+			i.processes = scanned
+			i.scannedAt = time.Now()
+		}
+	}
+	return i.processes, i.scannedAt, !i.scannedAt.IsZero()
+}
@@ -0,0 +1,59 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package discovery
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestInventorySnapshotCachesScansUntilStale(t *testing.T) {
+	scans := 0
+	fail := false
+	inventory := &Inventory{ttl: time.Hour, scan: func() ([]*DiscoveredProcess, error) {
+		scans++
+		if fail {
+			return nil, errors.New("ps failed")
+		}
+		return []*DiscoveredProcess{{PID: 100 + scans, Role: "master", InstallDir: "/opt/seatunnel"}}, nil
+	}}
+
+	fail = true
+	if _, _, ok := inventory.Snapshot(); ok {
+		t.Fatalf("expected no inventory before a successful scan")
+	}
+
+	fail = false
+	first, scannedAt, ok := inventory.Snapshot()
+	if !ok || len(first) != 1 || first[0].PID != 102 {
+		t.Fatalf("unexpected first snapshot: %+v ok=%v", first, ok)
+	}
+	if again, _, _ := inventory.Snapshot(); scans != 2 || again[0].PID != 102 {
+		t.Fatalf("expected cached snapshot, scans=%d", scans)
+	}
+
+	// A stale inventory is rescanned; a failed rescan keeps the previous list
+	// 过期清单会重新扫描；重新扫描失败时保留上一份列表
+	inventory.scannedAt = scannedAt.Add(-2 * time.Hour)
+	fail = true
+	kept, _, ok := inventory.Snapshot()
+	if !ok || scans != 3 || kept[0].PID != 102 {
+		t.Fatalf("expected previous list after failed rescan, got %+v scans=%d", kept, scans)
+	}
+}
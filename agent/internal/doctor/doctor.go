@@ -0,0 +1,491 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package doctor runs local diagnostics that explain why an Agent cannot connect to the
+// Control Plane, without needing the Control Plane to be reachable.
+// Package doctor 执行本地诊断，用于在无法连接 Control Plane 时离线排查 Agent 未连接的原因。
+package doctor
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	pb "github.com/seatunnel/seatunnelX/agent"
+	"github.com/seatunnel/seatunnelX/agent/internal/config"
+	"github.com/seatunnel/seatunnelX/agent/internal/discovery"
+	agentgrpc "github.com/seatunnel/seatunnelX/agent/internal/grpc"
+	"github.com/seatunnel/seatunnelX/agent/internal/installer"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// DefaultTimeout bounds each network step of a Control Plane check.
+// DefaultTimeout 限制 Control Plane 检查中每个网络步骤的耗时。
+const DefaultTimeout = 5 * time.Second
+
+// Category groups related checks in a report.
+// Category 在报告中对相关检查进行分组。
+type Category string
+
+const (
+	// CategoryConfig covers loading and validating the Agent configuration
+	// CategoryConfig 涵盖 Agent 配置的加载与校验
+	CategoryConfig Category = "config"
+
+	// CategoryPrecheck covers the local environment prechecks
+	// CategoryPrecheck 涵盖本地环境预检查
+	CategoryPrecheck Category = "precheck"
+
+	// CategoryControlPlane covers connectivity to each Control Plane address
+	// CategoryControlPlane 涵盖到每个 Control Plane 地址的连通性
+	CategoryControlPlane Category = "control_plane"
+
+	// CategorySeaTunnel covers existing SeaTunnel installations on this host
+	// CategorySeaTunnel 涵盖本机已有的 SeaTunnel 安装
+	CategorySeaTunnel Category = "seatunnel"
+)
+
+// Check is a single diagnostic result.
+// Check 是单个诊断结果。
+type Check struct {
+	Category   Category               `json:"category"`
+	Name       string                 `json:"name"`
+	Status     installer.CheckStatus  `json:"status"`
+	Message    string                 `json:"message"`
+	Details    map[string]interface{} `json:"details,omitempty"`
+	Suggestion string                 `json:"suggestion,omitempty"`
+}
+
+// Report is the outcome of a doctor run.
+// Report 是一次 doctor 运行的结果。
+type Report struct {
+	AgentVersion  string                `json:"agent_version"`
+	Hostname      string                `json:"hostname"`
+	OS            string                `json:"os"`
+	Arch          string                `json:"arch"`
+	ConfigPath    string                `json:"config_path"`
+	GeneratedAt   time.Time             `json:"generated_at"`
+	Checks        []Check               `json:"checks"`
+	OverallStatus installer.CheckStatus `json:"overall_status"`
+	Summary       string                `json:"summary"`
+}
+
+// Options configures a doctor run.
+// Options 配置一次 doctor 运行。
+type Options struct {
+	// Config is the loaded configuration; nil when it could not be loaded at all
+	// Config 是已加载的配置；完全无法加载时为 nil
+	Config *config.Config
+
+	// ConfigPath is the resolved configuration file path
+	// ConfigPath 是解析后的配置文件路径
+	ConfigPath string
+
+	// ConfigErr is the error from loading or validating the configuration
+	// ConfigErr 是加载或校验配置时的错误
+	ConfigErr error
+
+	// Register carries the identity sent in the register dry-run
+	// Register 携带注册试运行时发送的身份信息
+	Register *pb.RegisterRequest
+
+	// Timeout bounds each network step; DefaultTimeout when zero
+	// Timeout 限制每个网络步骤；为 0 时使用 DefaultTimeout
+	Timeout time.Duration
+}
+
+// Doctor runs the diagnostics.
+// Doctor 执行诊断。
+type Doctor struct {
+	opts Options
+
+	// runPrechecks runs the environment prechecks (replaceable for testing)
+	// runPrechecks 执行环境预检查（可在测试中替换）
+	runPrechecks func(ctx context.Context, params *installer.PrecheckParams) (*installer.PrecheckResult, error)
+
+	// discoverProcesses lists running SeaTunnel processes (replaceable for testing)
+	// discoverProcesses 列出运行中的 SeaTunnel 进程（可在测试中替换）
+	discoverProcesses func() ([]*discovery.DiscoveredProcess, error)
+
+	// inspectInstall inspects a SeaTunnel installation directory (replaceable for testing)
+	// inspectInstall 检查 SeaTunnel 安装目录（可在测试中替换）
+	inspectInstall func(installDir string) (*discovery.InstallInspection, error)
+}
+
+// New creates a Doctor with the default system probes.
+// New 使用默认的系统探测创建 Doctor。
+func New(opts Options) *Doctor {
+	if opts.Timeout <= 0 {
+		opts.Timeout = DefaultTimeout
+	}
+	if opts.Register == nil {
+		opts.Register = &pb.RegisterRequest{}
+	}
+	processDiscovery := discovery.NewProcessDiscovery()
+	return &Doctor{
+		opts: opts,
+		runPrechecks: func(ctx context.Context, params *installer.PrecheckParams) (*installer.PrecheckResult, error) {
+			return installer.NewPrechecker(params).RunAll(ctx)
+		},
+		discoverProcesses: processDiscovery.DiscoverProcesses,
+		inspectInstall:    processDiscovery.InspectInstallDir,
+	}
+}
+
+// Run executes all checks and returns the report.
+// Run 执行所有检查并返回报告。
+func (d *Doctor) Run(ctx context.Context) *Report {
+	report := &Report{
+		AgentVersion: d.opts.Register.AgentVersion,
+		Hostname:     d.opts.Register.Hostname,
+		OS:           runtime.GOOS,
+		Arch:         runtime.GOARCH,
+		ConfigPath:   d.opts.ConfigPath,
+		GeneratedAt:  time.Now(),
+	}
+
+	report.Checks = append(report.Checks, d.checkConfig())
+	seatunnelChecks, running := d.checkSeaTunnel()
+	report.Checks = append(report.Checks, d.checkPrechecks(ctx, running)...)
+	report.Checks = append(report.Checks, d.checkControlPlane(ctx)...)
+	report.Checks = append(report.Checks, seatunnelChecks...)
+
+	report.summarize()
+	return report
+}
+
+// checkConfig reports whether the configuration loaded and validated.
+// checkConfig 报告配置是否加载并校验成功。
+func (d *Doctor) checkConfig() Check {
+	check := Check{Category: CategoryConfig, Name: "config", Details: map[string]interface{}{"path": d.opts.ConfigPath}}
+	if d.opts.ConfigErr != nil {
+		check.Status = installer.CheckStatusFailed
+		check.Message = d.opts.ConfigErr.Error()
+		check.Suggestion = "Run 'seatunnelx-agent config validate' and fix the reported keys / 运行 'seatunnelx-agent config validate' 并修正报告的配置项"
+		return check
+	}
+	check.Status = installer.CheckStatusPassed
+	check.Message = "Configuration is valid / 配置有效"
+	return check
+}
+
+// checkPrechecks runs the installation prechecks against the configured install dir. Busy ports
+// are only a warning while SeaTunnel is running on this host, since it holds those ports itself.
+// checkPrechecks 针对配置的安装目录执行安装预检查。本机 SeaTunnel 运行中时端口占用仅为警告，
+// 因为这些端口正是由它占用的。
+func (d *Doctor) checkPrechecks(ctx context.Context, seatunnelRunning bool) []Check {
+	params := installer.DefaultPrecheckParams()
+	params.Architecture = runtime.GOARCH
+	if d.opts.Config != nil && d.opts.Config.SeaTunnel.InstallDir != "" {
+		params.InstallDir = d.opts.Config.SeaTunnel.InstallDir
+	}
+
+	result, err := d.runPrechecks(ctx, params)
+	if err != nil {
+		return []Check{{
+			Category: CategoryPrecheck,
+			Name:     "precheck",
+			Status:   installer.CheckStatusFailed,
+			Message:  fmt.Sprintf("Prechecks did not complete: %v / 预检查未完成：%v", err, err),
+		}}
+	}
+
+	checks := make([]Check, 0, len(result.Items))
+	for _, item := range result.Items {
+		check := Check{
+			Category:   CategoryPrecheck,
+			Name:       string(item.Name),
+			Status:     item.Status,
+			Message:    item.Message,
+			Details:    item.Details,
+			Suggestion: item.Suggestion,
+		}
+		if item.Name == installer.CheckNamePorts && item.Status == installer.CheckStatusFailed && seatunnelRunning {
+			check.Status = installer.CheckStatusWarning
+			check.Suggestion = "SeaTunnel is running on this host and may be holding these ports / 本机 SeaTunnel 正在运行，端口可能由其占用"
+		}
+		checks = append(checks, check)
+	}
+	return checks
+}
+
+// checkControlPlane probes every configured Control Plane address in order.
+// checkControlPlane 按顺序探测每个已配置的 Control Plane 地址。
+func (d *Doctor) checkControlPlane(ctx context.Context) []Check {
+	cfg := d.opts.Config
+	if cfg == nil {
+		return []Check{{
+			Category: CategoryControlPlane,
+			Name:     "control_plane",
+			Status:   installer.CheckStatusFailed,
+			Message:  "Skipped because the configuration could not be loaded / 配置无法加载，已跳过",
+		}}
+	}
+	if len(cfg.ControlPlane.Addresses) == 0 {
+		return []Check{{
+			Category:   CategoryControlPlane,
+			Name:       "control_plane",
+			Status:     installer.CheckStatusFailed,
+			Message:    "No Control Plane address configured / 未配置 Control Plane 地址",
+			Suggestion: "Set control_plane.addresses or SEATUNNELX_AGENT_CONTROL_PLANE_ADDRESSES / 设置 control_plane.addresses 或 SEATUNNELX_AGENT_CONTROL_PLANE_ADDRESSES",
+		}}
+	}
+
+	client := agentgrpc.NewClient(cfg)
+	var checks []Check
+	for _, addr := range cfg.ControlPlane.Addresses {
+		checks = append(checks, d.checkEndpoint(ctx, client, addr)...)
+	}
+	return checks
+}
+
+// checkEndpoint runs the TCP, TLS and register dry-run steps for one address, stopping at the
+// first failed step since later steps cannot succeed without it.
+// checkEndpoint 对单个地址依次执行 TCP、TLS 与注册试运行，遇到首个失败步骤即停止，
+// 因为后续步骤依赖前一步成功。
+func (d *Doctor) checkEndpoint(ctx context.Context, client *agentgrpc.Client, addr string) []Check {
+	tcp := d.checkTCP(ctx, addr)
+	checks := []Check{tcp}
+	if tcp.Status == installer.CheckStatusFailed {
+		return checks
+	}
+	if d.opts.Config.ControlPlane.TLS.Enabled {
+		handshake := d.checkTLS(ctx, client, addr)
+		checks = append(checks, handshake)
+		if handshake.Status == installer.CheckStatusFailed {
+			return checks
+		}
+	}
+	return append(checks, d.checkRegister(ctx, client, addr))
+}
+
+// checkTCP dials the address.
+// checkTCP 拨号该地址。
+func (d *Doctor) checkTCP(ctx context.Context, addr string) Check {
+	check := Check{Category: CategoryControlPlane, Name: addr + " tcp", Details: map[string]interface{}{"address": addr}}
+	dialer := net.Dialer{Timeout: d.opts.Timeout}
+	start := time.Now()
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		check.Status = installer.CheckStatusFailed
+		check.Message = fmt.Sprintf("TCP connection failed: %v / TCP 连接失败：%v", err, err)
+		check.Suggestion = "Check that the Control Plane gRPC port is listening and not blocked by a firewall / 检查 Control Plane gRPC 端口是否监听且未被防火墙拦截"
+		return check
+	}
+	_ = conn.Close()
+	check.Status = installer.CheckStatusPassed
+	check.Details["latency_ms"] = time.Since(start).Milliseconds()
+	check.Message = "TCP connection succeeded / TCP 连接成功"
+	return check
+}
+
+// checkTLS performs a TLS handshake with the configured certificates.
+// checkTLS 使用已配置的证书执行 TLS 握手。
+func (d *Doctor) checkTLS(ctx context.Context, client *agentgrpc.Client, addr string) Check {
+	check := Check{Category: CategoryControlPlane, Name: addr + " tls", Details: map[string]interface{}{"address": addr}}
+	tlsConfig, err := client.TLSConfig()
+	if err != nil {
+		check.Status = installer.CheckStatusFailed
+		check.Message = err.Error()
+		check.Suggestion = "Check control_plane.tls.cert_file, key_file and ca_file / 检查 control_plane.tls.cert_file、key_file 与 ca_file"
+		return check
+	}
+	if tlsConfig.ServerName == "" {
+		if host, _, err := net.SplitHostPort(addr); err == nil {
+			tlsConfig.ServerName = host
+		}
+	}
+
+	dialer := tls.Dialer{NetDialer: &net.Dialer{Timeout: d.opts.Timeout}, Config: tlsConfig}
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		check.Status = installer.CheckStatusFailed
+		check.Message = fmt.Sprintf("TLS handshake failed: %v / TLS 握手失败：%v", err, err)
+		check.Suggestion = "Make sure the Control Plane certificate is signed by the configured CA and matches the address / 确保 Control Plane 证书由配置的 CA 签发且与地址匹配"
+		return check
+	}
+	defer conn.Close()
+
+	state := conn.(*tls.Conn).ConnectionState()
+	check.Details["version"] = tls.VersionName(state.Version)
+	if len(state.PeerCertificates) > 0 {
+		cert := state.PeerCertificates[0]
+		check.Details["subject"] = cert.Subject.String()
+		check.Details["not_after"] = cert.NotAfter.UTC().Format(time.RFC3339)
+	}
+	check.Status = installer.CheckStatusPassed
+	check.Message = "TLS handshake succeeded / TLS 握手成功"
+	return check
+}
+
+// checkRegister opens a gRPC connection and sends a dry-run registration, which runs the Control
+// Plane admission checks without registering the Agent.
+// checkRegister 建立 gRPC 连接并发送注册试运行，由 Control Plane 执行准入检查但不注册 Agent。
+func (d *Doctor) checkRegister(ctx context.Context, client *agentgrpc.Client, addr string) Check {
+	check := Check{Category: CategoryControlPlane, Name: addr + " register", Details: map[string]interface{}{"address": addr}}
+	conn, err := client.DialEndpoint(ctx, addr)
+	if err != nil {
+		check.Status = installer.CheckStatusFailed
+		check.Message = fmt.Sprintf("gRPC connection failed: %v / gRPC 连接失败：%v", err, err)
+		check.Suggestion = "Make sure the address points at the Control Plane gRPC port and the TLS setting matches the server / 确认地址指向 Control Plane gRPC 端口且 TLS 设置与服务端一致"
+		return check
+	}
+	defer conn.Close()
+
+	rpcCtx, cancel := context.WithTimeout(ctx, d.opts.Timeout)
+	defer cancel()
+	req := proto.Clone(d.opts.Register).(*pb.RegisterRequest)
+	req.DryRun = true
+	resp, err := pb.NewAgentServiceClient(conn).Register(rpcCtx, req)
+	if err != nil {
+		check.Status, check.Suggestion = registerFailure(err)
+		check.Message = fmt.Sprintf("Register dry-run failed: %v / 注册试运行失败：%v", err, err)
+		return check
+	}
+	if !resp.Success {
+		check.Status = installer.CheckStatusFailed
+		check.Message = fmt.Sprintf("Register dry-run rejected: %s / 注册试运行被拒绝：%s", resp.Message, resp.Message)
+		return check
+	}
+	check.Status = installer.CheckStatusPassed
+	check.Message = "Register dry-run accepted / 注册试运行通过"
+	return check
+}
+
+// registerFailure maps a register error to a status and suggestion.
+// registerFailure 将注册错误映射为状态与建议。
+func registerFailure(err error) (installer.CheckStatus, string) {
+	switch status.Code(err) {
+	case codes.ResourceExhausted:
+		return installer.CheckStatusWarning, "Registration is rate limited; wait and retry / 注册被限流，请稍后重试"
+	case codes.FailedPrecondition:
+		return installer.CheckStatusFailed, "Approve this Agent in the Control Plane / 在 Control Plane 中审批此 Agent"
+	case codes.PermissionDenied:
+		return installer.CheckStatusFailed, "This Agent matches a deny rule in the Control Plane / 此 Agent 命中了 Control Plane 的拒绝规则"
+	case codes.Unauthenticated:
+		return installer.CheckStatusFailed, "Check control_plane.token / 检查 control_plane.token"
+	}
+	return installer.CheckStatusFailed, ""
+}
+
+// checkSeaTunnel reports existing SeaTunnel installations: the configured install dir and the
+// directories of running processes. It also returns whether any SeaTunnel process is running.
+// checkSeaTunnel 报告已有的 SeaTunnel 安装：配置的安装目录以及运行中进程所在目录。
+// 同时返回是否有 SeaTunnel 进程正在运行。
+func (d *Doctor) checkSeaTunnel() ([]Check, bool) {
+	processes, err := d.discoverProcesses()
+	if err != nil {
+		return []Check{{
+			Category: CategorySeaTunnel,
+			Name:     "processes",
+			Status:   installer.CheckStatusWarning,
+			Message:  fmt.Sprintf("Failed to scan SeaTunnel processes: %v / 扫描 SeaTunnel 进程失败：%v", err, err),
+		}}, false
+	}
+
+	dirs := make(map[string]struct{})
+	if d.opts.Config != nil && strings.TrimSpace(d.opts.Config.SeaTunnel.InstallDir) != "" {
+		dirs[filepath.Clean(d.opts.Config.SeaTunnel.InstallDir)] = struct{}{}
+	}
+	for _, proc := range processes {
+		if proc != nil && proc.InstallDir != "" {
+			dirs[filepath.Clean(proc.InstallDir)] = struct{}{}
+		}
+	}
+	sorted := make([]string, 0, len(dirs))
+	for dir := range dirs {
+		sorted = append(sorted, dir)
+	}
+	sort.Strings(sorted)
+
+	var checks []Check
+	for _, dir := range sorted {
+		inspection, err := d.inspectInstall(dir)
+		if errors.Is(err, discovery.ErrNotSeaTunnelInstall) {
+			continue
+		}
+		check := Check{Category: CategorySeaTunnel, Name: dir, Details: map[string]interface{}{"install_dir": dir}}
+		if err != nil {
+			check.Status = installer.CheckStatusWarning
+			check.Message = fmt.Sprintf("Failed to inspect installation: %v / 检查安装失败：%v", err, err)
+			checks = append(checks, check)
+			continue
+		}
+		check.Status = installer.CheckStatusPassed
+		check.Details["version"] = inspection.Version
+		check.Details["deployment_mode"] = inspection.DeploymentMode
+		check.Details["hazelcast_port"] = inspection.HazelcastPort
+		check.Details["processes"] = inspection.Processes
+		check.Message = fmt.Sprintf("SeaTunnel %s (%s), %d running process(es) / SeaTunnel %s（%s），%d 个运行中进程",
+			inspection.Version, inspection.DeploymentMode, len(inspection.Processes),
+			inspection.Version, inspection.DeploymentMode, len(inspection.Processes))
+		checks = append(checks, check)
+	}
+	if len(checks) == 0 {
+		checks = append(checks, Check{
+			Category: CategorySeaTunnel,
+			Name:     "installations",
+			Status:   installer.CheckStatusPassed,
+			Message:  "No existing SeaTunnel installation found / 未发现已有的 SeaTunnel 安装",
+		})
+	}
+	return checks, len(processes) > 0
+}
+
+// summarize computes the overall status and summary.
+// summarize 计算总体状态与摘要。
+func (r *Report) summarize() {
+	passed, warnings, failed := 0, 0, 0
+	r.OverallStatus = installer.CheckStatusPassed
+	for _, check := range r.Checks {
+		switch check.Status {
+		case installer.CheckStatusPassed:
+			passed++
+		case installer.CheckStatusWarning:
+			warnings++
+			if r.OverallStatus == installer.CheckStatusPassed {
+				r.OverallStatus = installer.CheckStatusWarning
+			}
+		case installer.CheckStatusFailed:
+			failed++
+			r.OverallStatus = installer.CheckStatusFailed
+		}
+	}
+	r.Summary = fmt.Sprintf("%d passed, %d warnings, %d failed / %d 通过，%d 警告，%d 失败",
+		passed, warnings, failed, passed, warnings, failed)
+}
+
+// FailedCount returns the number of failed checks.
+// FailedCount 返回失败的检查数量。
+func (r *Report) FailedCount() int {
+	count := 0
+	for _, check := range r.Checks {
+		if check.Status == installer.CheckStatusFailed {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,235 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package doctor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	pb "github.com/seatunnel/seatunnelX/agent"
+	"github.com/seatunnel/seatunnelX/agent/internal/config"
+	"github.com/seatunnel/seatunnelX/agent/internal/discovery"
+	"github.com/seatunnel/seatunnelX/agent/internal/installer"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeControlPlane records register requests and answers them with a fixed error.
+// fakeControlPlane 记录注册请求并以固定错误应答。
+type fakeControlPlane struct {
+	pb.UnimplementedAgentServiceServer
+	err      error
+	requests chan *pb.RegisterRequest
+}
+
+func (f *fakeControlPlane) Register(ctx context.Context, req *pb.RegisterRequest) (*pb.RegisterResponse, error) {
+	f.requests <- req
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &pb.RegisterResponse{Success: true, AssignedId: req.AgentId}, nil
+}
+
+func startControlPlane(t *testing.T, cp *fakeControlPlane) string {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	server := grpc.NewServer()
+	pb.RegisterAgentServiceServer(server, cp)
+	go func() { _ = server.Serve(lis) }()
+	t.Cleanup(server.Stop)
+	return lis.Addr().String()
+}
+
+// closedAddress returns a local address nothing is listening on.
+// closedAddress 返回一个无人监听的本地地址。
+func closedAddress(t *testing.T) string {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	addr := lis.Addr().String()
+	_ = lis.Close()
+	return addr
+}
+
+func newTestDoctor(cfg *config.Config) *Doctor {
+	d := New(Options{
+		Config:   cfg,
+		Register: &pb.RegisterRequest{AgentId: "agent-1", Hostname: "node-1", IpAddress: "10.0.0.1"},
+		Timeout:  2 * time.Second,
+	})
+	d.runPrechecks = func(ctx context.Context, params *installer.PrecheckParams) (*installer.PrecheckResult, error) {
+		return &installer.PrecheckResult{Items: []installer.PrecheckItem{
+			{Name: installer.CheckNameJava, Status: installer.CheckStatusPassed, Message: "Java 11"},
+			{Name: installer.CheckNamePorts, Status: installer.CheckStatusFailed, Message: "port 5801 in use"},
+		}}, nil
+	}
+	d.discoverProcesses = func() ([]*discovery.DiscoveredProcess, error) { return nil, nil }
+	d.inspectInstall = func(installDir string) (*discovery.InstallInspection, error) {
+		return nil, discovery.ErrNotSeaTunnelInstall
+	}
+	return d
+}
+
+func findCheck(report *Report, name string) *Check {
+	for i := range report.Checks {
+		if report.Checks[i].Name == name {
+			return &report.Checks[i]
+		}
+	}
+	return nil
+}
+
+// TestRunChecksEachControlPlaneAddress tests the TCP and register dry-run steps per address.
+// TestRunChecksEachControlPlaneAddress 测试逐个地址执行 TCP 与注册试运行步骤。
+func TestRunChecksEachControlPlaneAddress(t *testing.T) {
+	cp := &fakeControlPlane{requests: make(chan *pb.RegisterRequest, 1)}
+	healthy := startControlPlane(t, cp)
+	down := closedAddress(t)
+
+	cfg := &config.Config{}
+	cfg.ControlPlane.Addresses = []string{healthy, down}
+	report := newTestDoctor(cfg).Run(context.Background())
+
+	if check := findCheck(report, healthy+" register"); check == nil || check.Status != installer.CheckStatusPassed {
+		t.Fatalf("Expected register dry-run to pass, got %+v", check)
+	}
+	select {
+	case req := <-cp.requests:
+		if !req.DryRun || req.AgentId != "agent-1" {
+			t.Errorf("Expected a dry-run register for agent-1, got %+v", req)
+		}
+	default:
+		t.Fatal("Expected the Control Plane to receive a register request")
+	}
+
+	if check := findCheck(report, down+" tcp"); check == nil || check.Status != installer.CheckStatusFailed {
+		t.Fatalf("Expected TCP check to fail for %s, got %+v", down, check)
+	}
+	if check := findCheck(report, down+" register"); check != nil {
+		t.Errorf("Expected register to be skipped after TCP failure, got %+v", check)
+	}
+	if report.OverallStatus != installer.CheckStatusFailed || report.FailedCount() != 2 {
+		t.Errorf("Expected 2 failed checks, got %d (%s)", report.FailedCount(), report.OverallStatus)
+	}
+}
+
+// TestRunReportsPendingApproval tests that an admission error surfaces with a suggestion.
+// TestRunReportsPendingApproval 测试准入错误会带着修复建议呈现。
+func TestRunReportsPendingApproval(t *testing.T) {
+	cp := &fakeControlPlane{
+		requests: make(chan *pb.RegisterRequest, 1),
+		err:      status.Error(codes.FailedPrecondition, "agent pending approval"),
+	}
+	addr := startControlPlane(t, cp)
+
+	cfg := &config.Config{}
+	cfg.ControlPlane.Addresses = []string{addr}
+	report := newTestDoctor(cfg).Run(context.Background())
+
+	check := findCheck(report, addr+" register")
+	if check == nil || check.Status != installer.CheckStatusFailed || !strings.Contains(check.Suggestion, "Approve") {
+		t.Fatalf("Expected pending approval failure with suggestion, got %+v", check)
+	}
+}
+
+// TestRunDowngradesPortsWhenSeaTunnelRunning tests busy ports are a warning next to a running SeaTunnel.
+// TestRunDowngradesPortsWhenSeaTunnelRunning 测试本机 SeaTunnel 运行时端口占用降级为警告。
+func TestRunDowngradesPortsWhenSeaTunnelRunning(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.SeaTunnel.InstallDir = "/opt/seatunnel"
+	d := newTestDoctor(cfg)
+	d.discoverProcesses = func() ([]*discovery.DiscoveredProcess, error) {
+		return []*discovery.DiscoveredProcess{{PID: 42, InstallDir: "/opt/seatunnel", Role: "hybrid"}}, nil
+	}
+	d.inspectInstall = func(installDir string) (*discovery.InstallInspection, error) {
+		return &discovery.InstallInspection{
+			InstallDir:     installDir,
+			Version:        "2.3.12",
+			DeploymentMode: "hybrid",
+			Processes:      []discovery.InstallProcess{{PID: 42, Role: "hybrid"}},
+		}, nil
+	}
+	report := d.Run(context.Background())
+
+	if check := findCheck(report, "ports"); check == nil || check.Status != installer.CheckStatusWarning {
+		t.Fatalf("Expected ports check downgraded to warning, got %+v", check)
+	}
+	check := findCheck(report, "/opt/seatunnel")
+	if check == nil || check.Details["version"] != "2.3.12" {
+		t.Fatalf("Expected existing installation to be reported, got %+v", check)
+	}
+}
+
+// TestRunWithoutConfig tests that a config load failure is reported and connectivity is skipped.
+// TestRunWithoutConfig 测试配置加载失败会被报告且跳过连通性检查。
+func TestRunWithoutConfig(t *testing.T) {
+	d := newTestDoctor(nil)
+	d.opts.ConfigErr = errors.New("unknown config keys")
+	report := d.Run(context.Background())
+
+	if check := findCheck(report, "config"); check == nil || check.Status != installer.CheckStatusFailed {
+		t.Fatalf("Expected config check to fail, got %+v", check)
+	}
+	if check := findCheck(report, "control_plane"); check == nil || check.Status != installer.CheckStatusFailed {
+		t.Fatalf("Expected control plane check to be skipped as failed, got %+v", check)
+	}
+}
+
+// TestReportOutput tests the text and JSON renderings.
+// TestReportOutput 测试文本与 JSON 输出。
+func TestReportOutput(t *testing.T) {
+	report := &Report{Hostname: "node-1", Checks: []Check{
+		{Category: CategoryConfig, Name: "config", Status: installer.CheckStatusPassed, Message: "ok"},
+		{Category: CategoryControlPlane, Name: "cp:5000 tcp", Status: installer.CheckStatusFailed, Message: "refused", Suggestion: "open firewall"},
+	}}
+	report.summarize()
+
+	var text bytes.Buffer
+	if err := report.WriteText(&text); err != nil {
+		t.Fatalf("WriteText: %v", err)
+	}
+	for _, want := range []string{"✓ config: ok", "✗ cp:5000 tcp: refused", "Fix / 修复: open firewall", "Overall: FAILED"} {
+		if !strings.Contains(text.String(), want) {
+			t.Errorf("Expected text report to contain %q:\n%s", want, text.String())
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := report.WriteJSON(&buf); err != nil {
+		t.Fatalf("WriteJSON: %v", err)
+	}
+	var decoded Report
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if decoded.OverallStatus != installer.CheckStatusFailed || len(decoded.Checks) != 2 {
+		t.Errorf("Unexpected decoded report: %+v", decoded)
+	}
+}
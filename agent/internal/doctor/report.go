@@ -0,0 +1,93 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package doctor
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/seatunnel/seatunnelX/agent/internal/installer"
+)
+
+// categoryTitles are the section headings of the text report.
+// categoryTitles 是文本报告的分节标题。
+var categoryTitles = map[Category]string{
+	CategoryConfig:       "Configuration / 配置",
+	CategoryPrecheck:     "Environment prechecks / 环境预检查",
+	CategoryControlPlane: "Control Plane connectivity / Control Plane 连通性",
+	CategorySeaTunnel:    "SeaTunnel installations / SeaTunnel 安装",
+}
+
+// WriteJSON writes the report as indented JSON.
+// WriteJSON 以缩进 JSON 格式写出报告。
+func (r *Report) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(r)
+}
+
+// WriteText writes the report in a human-readable form grouped by category.
+// WriteText 按分类以人类可读的格式写出报告。
+func (r *Report) WriteText(w io.Writer) error {
+	var sb strings.Builder
+	sb.WriteString("SeaTunnelX Agent Doctor / Agent 诊断\n")
+	sb.WriteString("================================\n")
+	fmt.Fprintf(&sb, "Agent:     %s\n", r.AgentVersion)
+	fmt.Fprintf(&sb, "Host:      %s (%s/%s)\n", r.Hostname, r.OS, r.Arch)
+	fmt.Fprintf(&sb, "Config:    %s\n", r.ConfigPath)
+	fmt.Fprintf(&sb, "Generated: %s\n", r.GeneratedAt.Format(time.RFC3339))
+
+	var current Category
+	for _, check := range r.Checks {
+		if check.Category != current {
+			current = check.Category
+			fmt.Fprintf(&sb, "\n%s\n", categoryTitles[current])
+		}
+		fmt.Fprintf(&sb, "  %s %s: %s\n", statusIcon(check.Status), check.Name, check.Message)
+		if check.Suggestion != "" {
+			fmt.Fprintf(&sb, "      Fix / 修复: %s\n", check.Suggestion)
+		}
+	}
+
+	sb.WriteString("================================\n")
+	switch r.OverallStatus {
+	case installer.CheckStatusPassed:
+		sb.WriteString("Overall: PASSED / 总体：通过")
+	case installer.CheckStatusWarning:
+		sb.WriteString("Overall: PASSED WITH WARNINGS / 总体：通过（有警告）")
+	default:
+		sb.WriteString("Overall: FAILED / 总体：失败")
+	}
+	fmt.Fprintf(&sb, " (%s)\n", r.Summary)
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+func statusIcon(status installer.CheckStatus) string {
+	switch status {
+	case installer.CheckStatusFailed:
+		return "✗"
+	case installer.CheckStatusWarning:
+		return "⚠"
+	}
+	return "✓"
+}
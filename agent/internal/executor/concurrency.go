@@ -0,0 +1,223 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package executor
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	pb "github.com/seatunnel/seatunnelX/agent"
+)
+
+// DefaultCancelGracePeriod is how long a cancelled command may spend cleaning up
+// DefaultCancelGracePeriod 是被取消命令可用于清理的默认时长
+const DefaultCancelGracePeriod = 30 * time.Second
+
+// defaultCategoryLimits returns per-category limits applied on top of the global limit.
+// Installer commands touch the same install directories and are serialized, as are maintenance scripts.
+// defaultCategoryLimits 返回在全局限制之上的分类限制。
+// 安装类命令会操作相同的安装目录，因此串行执行；运维脚本同样串行执行。
+func defaultCategoryLimits() map[string]int {
+	return map[string]int{
+		"installer":   1,
+		"maintenance": 1,
+	}
+}
+
+// runningCommand is one in-flight command that can be cancelled
+// runningCommand 表示一个可被取消的执行中命令
+type runningCommand struct {
+	cancel    context.CancelFunc
+	mu        sync.Mutex
+	cancelled bool
+}
+
+func (r *runningCommand) markCancelled() {
+	r.mu.Lock()
+	r.cancelled = true
+	r.mu.Unlock()
+	r.cancel()
+}
+
+func (r *runningCommand) isCancelled() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.cancelled
+}
+
+// claimCommand registers an in-flight command. When the same command ID is already running or
+// has a remembered result, it returns the response to send instead of executing again.
+// claimCommand 登记执行中的命令。若相同命令 ID 正在执行或已有结果记录，
+// 则返回应发送的响应而不再重复执行。
+func (e *CommandExecutor) claimCommand(cmd *pb.CommandRequest, cancel context.CancelFunc) (*runningCommand, *pb.CommandResponse) {
+	e.runningMu.Lock()
+	defer e.runningMu.Unlock()
+	if _, exists := e.running[cmd.CommandId]; exists {
+		return nil, CreateProgressResponse(cmd.CommandId, 0,
+			"Duplicate command ignored, already running / 重复命令已忽略，命令正在执行")
+	}
+	if e.results != nil && IsIdempotentCommandType(cmd.Type) {
+		if cached, ok := e.results.Get(cmd.CommandId); ok {
+			return nil, cached
+		}
+	}
+	run := &runningCommand{cancel: cancel}
+	e.running[cmd.CommandId] = run
+	return run, nil
+}
+
+func (e *CommandExecutor) untrackCommand(commandID string) {
+	e.runningMu.Lock()
+	defer e.runningMu.Unlock()
+	delete(e.running, commandID)
+}
+
+// CancelCommand cancels an in-flight command by ID
+// CancelCommand 按 ID 取消执行中的命令
+func (e *CommandExecutor) CancelCommand(commandID string) error {
+	e.runningMu.Lock()
+	run, ok := e.running[commandID]
+	e.runningMu.Unlock()
+	if !ok {
+		return fmt.Errorf("command %s is not running / 命令 %s 未在执行", commandID, commandID)
+	}
+	run.markCancelled()
+	return nil
+}
+
+// RunningCommands returns the IDs of in-flight commands
+// RunningCommands 返回执行中命令的 ID
+func (e *CommandExecutor) RunningCommands() []string {
+	e.runningMu.Lock()
+	defer e.runningMu.Unlock()
+	ids := make([]string, 0, len(e.running))
+	for id := range e.running {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// handleCancelRequest cancels the target command and answers the cancel request itself
+// handleCancelRequest 取消目标命令并应答取消请求本身
+func (e *CommandExecutor) handleCancelRequest(cmd *pb.CommandRequest) *pb.CommandResponse {
+	target := cmd.Parameters["target_command_id"]
+	if target == "" {
+		return CreateErrorResponse(cmd.CommandId, "target_command_id is required / target_command_id 不能为空")
+	}
+	if err := e.CancelCommand(target); err != nil {
+		return CreateErrorResponse(cmd.CommandId, err.Error())
+	}
+	return CreateSuccessResponse(cmd.CommandId,
+		fmt.Sprintf("Cancellation requested for command %s / 已请求取消命令 %s", target, target))
+}
+
+// SetMaxConcurrentCommands sets the global concurrency limit; values <= 0 disable the limit
+// SetMaxConcurrentCommands 设置全局并发上限；小于等于 0 表示不限制
+func (e *CommandExecutor) SetMaxConcurrentCommands(limit int) {
+	e.limiter.setGlobalLimit(limit)
+}
+
+// SetCategoryConcurrencyLimit sets the concurrency limit of one command category (see RouteCommand)
+// SetCategoryConcurrencyLimit 设置某一命令类别（见 RouteCommand）的并发上限
+func (e *CommandExecutor) SetCategoryConcurrencyLimit(category string, limit int) {
+	e.limiter.setCategoryLimit(category, limit)
+}
+
+// SetCancelGracePeriod sets how long a cancelled command may spend cleaning up
+// SetCancelGracePeriod 设置被取消命令可用于清理的时长
+func (e *CommandExecutor) SetCancelGracePeriod(period time.Duration) {
+	e.cancelGracePeriod = period
+}
+
+// concurrencyLimiter hands out execution slots per Agent and per command category
+// concurrencyLimiter 按 Agent 全局与命令类别分配执行槽位
+type concurrencyLimiter struct {
+	mu         sync.Mutex
+	global     chan struct{}
+	categories map[string]chan struct{}
+}
+
+func newConcurrencyLimiter(globalLimit int, categoryLimits map[string]int) *concurrencyLimiter {
+	l := &concurrencyLimiter{categories: make(map[string]chan struct{})}
+	l.setGlobalLimit(globalLimit)
+	for category, limit := range categoryLimits {
+		l.setCategoryLimit(category, limit)
+	}
+	return l
+}
+
+func (l *concurrencyLimiter) setGlobalLimit(limit int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.global = newSlots(limit)
+}
+
+func (l *concurrencyLimiter) setCategoryLimit(category string, limit int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if limit <= 0 {
+		delete(l.categories, category)
+		return
+	}
+	l.categories[category] = newSlots(limit)
+}
+
+func newSlots(limit int) chan struct{} {
+	if limit <= 0 {
+		return nil
+	}
+	return make(chan struct{}, limit)
+}
+
+// acquire waits for a category slot and then a global slot; the returned func releases both
+// acquire 依次等待类别槽位与全局槽位；返回的函数会释放二者
+func (l *concurrencyLimiter) acquire(ctx context.Context, category string, reporter ProgressReporter) (func(), error) {
+	l.mu.Lock()
+	categorySlots := l.categories[category]
+	globalSlots := l.global
+	l.mu.Unlock()
+
+	var held []chan struct{}
+	release := func() {
+		for _, slots := range held {
+			<-slots
+		}
+	}
+	for _, slots := range []chan struct{}{categorySlots, globalSlots} {
+		if slots == nil {
+			continue
+		}
+		select {
+		case slots <- struct{}{}:
+		default:
+			if reporter != nil {
+				_ = reporter.Report(0, "Waiting for an execution slot / 等待执行槽位")
+			}
+			select {
+			case slots <- struct{}{}:
+			case <-ctx.Done():
+				release()
+				return nil, ctx.Err()
+			}
+		}
+		held = append(held, slots)
+	}
+	return release, nil
+}
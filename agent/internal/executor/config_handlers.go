@@ -20,14 +20,27 @@ package executor
 import (
 	"context"
 	"encoding/json"
+	"strconv"
+	"strings"
 
 	pb "github.com/seatunnel/seatunnelX/agent"
 	"github.com/seatunnel/seatunnelX/agent/internal/config"
 )
 
+// AgentConfigTarget 是 UPDATE_CONFIG 中表示 Agent 自身配置（而非 SeaTunnel 配置文件）的 config_type
+const AgentConfigTarget = "agent"
+
+// ApplyConfigAction 是 UPDATE_CONFIG 的 action 参数取值，表示 APPLY_CONFIG 语义：
+// 校验内容、总是备份原文件并原子写入
+const ApplyConfigAction = "apply_config"
+
+// AgentConfigApplier 将 Control Plane 下发的 Agent 配置应用到运行中的 Agent
+type AgentConfigApplier func(cfg *pb.AgentConfig)
+
 // ConfigHandlers 配置相关命令处理器
 type ConfigHandlers struct {
-	configManager *config.Manager
+	configManager      *config.Manager
+	agentConfigApplier AgentConfigApplier
 }
 
 // NewConfigHandlers 创建配置处理器实例
@@ -37,6 +50,11 @@ func NewConfigHandlers() *ConfigHandlers {
 	}
 }
 
+// SetAgentConfigApplier 设置 Agent 配置热加载回调
+func (h *ConfigHandlers) SetAgentConfigApplier(applier AgentConfigApplier) {
+	h.agentConfigApplier = applier
+}
+
 // RegisterHandlers 注册所有配置相关的命令处理器
 func (h *ConfigHandlers) RegisterHandlers(executor *CommandExecutor) {
 	executor.RegisterHandler(pb.CommandType_PULL_CONFIG, h.HandlePullConfig)
@@ -89,9 +107,19 @@ func (h *ConfigHandlers) HandlePullConfig(ctx context.Context, cmd *pb.CommandRe
 //   - config_type: 配置类型
 //   - content: 新的配置内容
 //   - backup: 是否备份原文件 ("true" 或 "false")
+//   - action: 为 "apply_config" 时按 APPLY_CONFIG 语义处理，见 handleApplyConfig
+//
+// 当 config_type 为 "agent" 时，改为热加载 Agent 自身配置，参数见 handleUpdateAgentConfig。
 func (h *ConfigHandlers) HandleUpdateConfig(ctx context.Context, cmd *pb.CommandRequest, reporter ProgressReporter) (*pb.CommandResponse, error) {
 	installDir := cmd.Parameters["install_dir"]
 	configType := cmd.Parameters["config_type"]
+
+	if configType == AgentConfigTarget {
+		return h.handleUpdateAgentConfig(cmd)
+	}
+	if cmd.Parameters["action"] == ApplyConfigAction {
+		return h.handleApplyConfig(cmd, reporter)
+	}
 	content := cmd.Parameters["content"]
 	backupStr := cmd.Parameters["backup"]
 
@@ -131,6 +159,94 @@ func (h *ConfigHandlers) HandleUpdateConfig(ctx context.Context, cmd *pb.Command
 	return CreateSuccessResponse(cmd.CommandId, result.ToJSON()), nil
 }
 
+// handleApplyConfig 处理 APPLY_CONFIG：校验配置内容，备份原文件后原子写入
+// 参数:
+//   - install_dir: SeaTunnel 安装目录
+//   - config_type: 配置类型
+//   - content: 新的配置内容
+func (h *ConfigHandlers) handleApplyConfig(cmd *pb.CommandRequest, reporter ProgressReporter) (*pb.CommandResponse, error) {
+	installDir := cmd.Parameters["install_dir"]
+	configType := cmd.Parameters["config_type"]
+	content := cmd.Parameters["content"]
+
+	if installDir == "" {
+		return CreateErrorResponse(cmd.CommandId, "install_dir parameter is required"), nil
+	}
+	if configType == "" {
+		return CreateErrorResponse(cmd.CommandId, "config_type parameter is required"), nil
+	}
+
+	if reporter != nil {
+		reporter.Report(10, "Starting to apply config file...")
+	}
+
+	result, err := h.configManager.ApplyConfig(installDir, configType, content)
+	if err != nil {
+		return CreateErrorResponse(cmd.CommandId, err.Error()), nil
+	}
+	if !result.Success {
+		return CreateErrorResponse(cmd.CommandId, result.Message), nil
+	}
+
+	if reporter != nil {
+		reporter.Report(100, "Config file applied successfully")
+	}
+
+	return CreateSuccessResponse(cmd.CommandId, result.ToJSON()), nil
+}
+
+// handleUpdateAgentConfig 热加载 Agent 配置
+// 参数:
+//   - heartbeat_interval: 心跳间隔（秒）
+//   - log_level: 日志级别 (debug, info, warn, error)
+//   - 其余参数原样放入 AgentConfig.Extra（如 metrics_interval）
+func (h *ConfigHandlers) handleUpdateAgentConfig(cmd *pb.CommandRequest) (*pb.CommandResponse, error) {
+	if h.agentConfigApplier == nil {
+		return CreateErrorResponse(cmd.CommandId, "agent config hot-reload is not supported"), nil
+	}
+
+	cfg := &pb.AgentConfig{Extra: make(map[string]string)}
+	for key, value := range cmd.Parameters {
+		value = strings.TrimSpace(value)
+		switch key {
+		case "config_type":
+		case "heartbeat_interval":
+			seconds, err := strconv.Atoi(value)
+			if err != nil || seconds <= 0 {
+				return CreateErrorResponse(cmd.CommandId, "invalid heartbeat_interval: "+value), nil
+			}
+			cfg.HeartbeatInterval = int32(seconds)
+		case "log_level":
+			level, ok := parseAgentLogLevel(value)
+			if !ok {
+				return CreateErrorResponse(cmd.CommandId, "invalid log_level: "+value), nil
+			}
+			cfg.LogLevel = int32(level)
+		default:
+			cfg.Extra[key] = value
+		}
+	}
+
+	h.agentConfigApplier(cfg)
+	return CreateSuccessResponse(cmd.CommandId, "agent config accepted"), nil
+}
+
+// parseAgentLogLevel 将日志级别字符串转换为 protobuf 枚举
+func parseAgentLogLevel(level string) (pb.LogLevel, bool) {
+	switch strings.ToLower(level) {
+	case "debug":
+		return pb.LogLevel_DEBUG, true
+	case "info":
+		return pb.LogLevel_INFO, true
+	case "warn", "warning":
+		return pb.LogLevel_WARN, true
+	case "error":
+		return pb.LogLevel_ERROR, true
+	default:
+		return pb.LogLevel_LOG_LEVEL_UNSPECIFIED, false
+	}
+}
+
 // HandleRollbackConfig 处理回滚配置命令
 // 参数:
 //   - install_dir: SeaTunnel 安装目录
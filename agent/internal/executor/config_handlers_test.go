@@ -0,0 +1,88 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package executor
+
+import (
+	"context"
+	"testing"
+
+	pb "github.com/seatunnel/seatunnelX/agent"
+)
+
+func TestHandleUpdateAgentConfigAppliesConfig(t *testing.T) {
+	handlers := NewConfigHandlers()
+	var applied *pb.AgentConfig
+	handlers.SetAgentConfigApplier(func(cfg *pb.AgentConfig) { applied = cfg })
+
+	resp, err := handlers.HandleUpdateConfig(context.Background(), &pb.CommandRequest{
+		CommandId: "cmd-1",
+		Parameters: map[string]string{
+			"config_type":        AgentConfigTarget,
+			"heartbeat_interval": "15",
+			"log_level":          "DEBUG",
+			"metrics_interval":   "30",
+		},
+	}, nil)
+	if err != nil || resp.Status != pb.CommandStatus_SUCCESS {
+		t.Fatalf("expected success, got %+v err=%v", resp, err)
+	}
+	if applied == nil {
+		t.Fatal("expected the config to be applied")
+	}
+	if applied.HeartbeatInterval != 15 || applied.LogLevel != int32(pb.LogLevel_DEBUG) || applied.Extra["metrics_interval"] != "30" {
+		t.Fatalf("unexpected applied config: %+v", applied)
+	}
+	if _, ok := applied.Extra["config_type"]; ok {
+		t.Fatalf("config_type must not leak into Extra: %+v", applied.Extra)
+	}
+}
+
+func TestHandleUpdateAgentConfigRejectsInvalidConfig(t *testing.T) {
+	cases := []struct {
+		name   string
+		params map[string]string
+	}{
+		{"non-numeric heartbeat", map[string]string{"heartbeat_interval": "soon"}},
+		{"non-positive heartbeat", map[string]string{"heartbeat_interval": "0"}},
+		{"unknown log level", map[string]string{"log_level": "verbose"}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			handlers := NewConfigHandlers()
+			applied := false
+			handlers.SetAgentConfigApplier(func(*pb.AgentConfig) { applied = true })
+
+			tc.params["config_type"] = AgentConfigTarget
+			resp, err := handlers.HandleUpdateConfig(context.Background(), &pb.CommandRequest{CommandId: "cmd-1", Parameters: tc.params}, nil)
+			if err != nil || resp.Status != pb.CommandStatus_FAILED {
+				t.Fatalf("expected a failed response, got %+v err=%v", resp, err)
+			}
+			if applied {
+				t.Fatal("a rejected config must not be applied")
+			}
+		})
+	}
+
+	resp, err := NewConfigHandlers().HandleUpdateConfig(context.Background(), &pb.CommandRequest{
+		CommandId:  "cmd-2",
+		Parameters: map[string]string{"config_type": AgentConfigTarget, "heartbeat_interval": "15"},
+	}, nil)
+	if err != nil || resp.Status != pb.CommandStatus_FAILED {
+		t.Fatalf("expected failure without a config applier, got %+v err=%v", resp, err)
+	}
+}
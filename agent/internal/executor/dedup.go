@@ -0,0 +1,267 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package executor
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	pb "github.com/seatunnel/seatunnelX/agent"
+)
+
+// DefaultCommandResultStorePath is where executed command results are persisted
+// DefaultCommandResultStorePath 是已执行命令结果的持久化路径
+const DefaultCommandResultStorePath = "/var/lib/seatunnelx-agent/command_results.json"
+
+const (
+	// defaultCommandResultTTL is how long a command result is remembered for deduplication
+	// defaultCommandResultTTL 是命令结果用于去重的保留时长
+	defaultCommandResultTTL = 24 * time.Hour
+
+	// defaultCommandResultMaxEntries bounds the number of remembered command results
+	// defaultCommandResultMaxEntries 限制保留的命令结果数量
+	defaultCommandResultMaxEntries = 500
+
+	// maxCachedOutputBytes bounds the output kept per command result
+	// maxCachedOutputBytes 限制每条命令结果保留的输出大小
+	maxCachedOutputBytes = 64 * 1024
+)
+
+// idempotentCommandTypes are commands with side effects that must not run twice for one command ID.
+// Read-only commands are cheap and safe to re-execute, so their results are not persisted.
+// idempotentCommandTypes 是具有副作用、同一命令 ID 不得重复执行的命令类型。
+// 只读命令可安全重复执行，因此不持久化其结果。
+var idempotentCommandTypes = map[pb.CommandType]bool{
+	pb.CommandType_INSTALL:            true,
+	pb.CommandType_UNINSTALL:          true,
+	pb.CommandType_UPGRADE:            true,
+	pb.CommandType_START:              true,
+	pb.CommandType_STOP:               true,
+	pb.CommandType_RESTART:            true,
+	pb.CommandType_UPDATE_CONFIG:      true,
+	pb.CommandType_ROLLBACK_CONFIG:    true,
+	pb.CommandType_INSTALL_PLUGIN:     true,
+	pb.CommandType_UNINSTALL_PLUGIN:   true,
+	pb.CommandType_TRANSFER_PACKAGE:   true,
+	pb.CommandType_TRANSFER_PLUGIN:    true,
+	pb.CommandType_MARK_MANUAL_STOP:   true,
+	pb.CommandType_CLEAR_MANUAL_STOP:  true,
+	pb.CommandType_REMOVE_INSTALL_DIR: true,
+	pb.CommandType_EXEC_SCRIPT:        true,
+}
+
+// IsIdempotentCommandType reports whether results of the command type are deduplicated by command ID
+// IsIdempotentCommandType 判断该命令类型的结果是否按命令 ID 去重
+func IsIdempotentCommandType(cmdType pb.CommandType) bool {
+	return idempotentCommandTypes[cmdType]
+}
+
+// cachedCommandResult is one persisted terminal command result
+// cachedCommandResult 表示一条持久化的命令终态结果
+type cachedCommandResult struct {
+	CommandID  string    `json:"command_id"`
+	Type       string    `json:"type"`
+	Status     int32     `json:"status"`
+	Progress   int32     `json:"progress"`
+	Output     string    `json:"output,omitempty"`
+	Error      string    `json:"error,omitempty"`
+	FinishedAt time.Time `json:"finished_at"`
+}
+
+// CommandResultStore remembers recently executed command IDs with their results so that
+// commands re-sent after a stream reconnect are answered from cache instead of re-executed.
+// CommandResultStore 记录最近执行的命令 ID 及其结果，
+// 使命令流重连后被重发的命令直接返回缓存结果而不是重复执行。
+type CommandResultStore struct {
+	path       string
+	ttl        time.Duration
+	maxEntries int
+
+	mu      sync.Mutex
+	entries map[string]*cachedCommandResult
+}
+
+// NewCommandResultStore creates a store persisted at path and loads existing entries.
+// An empty path keeps results in memory only. The returned store is usable even when loading fails.
+// NewCommandResultStore 创建持久化到 path 的存储并加载已有记录。
+// path 为空时仅保存在内存中。即使加载失败，返回的存储仍可使用。
+func NewCommandResultStore(path string) (*CommandResultStore, error) {
+	s := &CommandResultStore{
+		path:       path,
+		ttl:        defaultCommandResultTTL,
+		maxEntries: defaultCommandResultMaxEntries,
+		entries:    make(map[string]*cachedCommandResult),
+	}
+	if path == "" {
+		return s, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return s, nil
+		}
+		return s, fmt.Errorf("failed to read command result store: %w", err)
+	}
+	var items []*cachedCommandResult
+	if err := json.Unmarshal(data, &items); err != nil {
+		return s, fmt.Errorf("failed to parse command result store: %w", err)
+	}
+	for _, item := range items {
+		if item != nil && item.CommandID != "" {
+			s.entries[item.CommandID] = item
+		}
+	}
+	s.pruneLocked(time.Now())
+	return s, nil
+}
+
+// Get returns the cached response of a previously executed command
+// Get 返回已执行命令的缓存响应
+func (s *CommandResultStore) Get(commandID string) (*pb.CommandResponse, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	item, ok := s.entries[commandID]
+	if !ok || time.Since(item.FinishedAt) > s.ttl {
+		return nil, false
+	}
+	return &pb.CommandResponse{
+		CommandId: item.CommandID,
+		Status:    pb.CommandStatus(item.Status),
+		Progress:  item.Progress,
+		Output:    item.Output,
+		Error:     item.Error,
+		Timestamp: time.Now().UnixMilli(),
+	}, true
+}
+
+// Put records a terminal command response and persists the store.
+// The entry is kept in memory even when persisting fails.
+// Put 记录命令终态响应并持久化。
+// 即使持久化失败，记录仍保留在内存中。
+func (s *CommandResultStore) Put(cmdType pb.CommandType, resp *pb.CommandResponse) error {
+	if resp == nil || resp.CommandId == "" {
+		return nil
+	}
+	output := resp.Output
+	if len(output) > maxCachedOutputBytes {
+		output = output[:maxCachedOutputBytes]
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	s.entries[resp.CommandId] = &cachedCommandResult{
+		CommandID:  resp.CommandId,
+		Type:       cmdType.String(),
+		Status:     int32(resp.Status),
+		Progress:   resp.Progress,
+		Output:     output,
+		Error:      resp.Error,
+		FinishedAt: now,
+	}
+	s.pruneLocked(now)
+	return s.saveLocked()
+}
+
+// Len returns the number of remembered command results
+// Len 返回已记录的命令结果数量
+func (s *CommandResultStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.entries)
+}
+
+// pruneLocked drops expired entries and keeps at most maxEntries of the newest ones
+// pruneLocked 删除过期记录，并仅保留最新的 maxEntries 条
+func (s *CommandResultStore) pruneLocked(now time.Time) {
+	for id, item := range s.entries {
+		if now.Sub(item.FinishedAt) > s.ttl {
+			delete(s.entries, id)
+		}
+	}
+	if len(s.entries) <= s.maxEntries {
+		return
+	}
+	items := s.sortedLocked()
+	for _, item := range items[:len(items)-s.maxEntries] {
+		delete(s.entries, item.CommandID)
+	}
+}
+
+func (s *CommandResultStore) sortedLocked() []*cachedCommandResult {
+	items := make([]*cachedCommandResult, 0, len(s.entries))
+	for _, item := range s.entries {
+		items = append(items, item)
+	}
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].FinishedAt.Before(items[j].FinishedAt)
+	})
+	return items
+}
+
+// saveLocked writes the store atomically via a temp file and rename
+// saveLocked 通过临时文件加重命名的方式原子写入存储
+func (s *CommandResultStore) saveLocked() error {
+	if s.path == "" {
+		return nil
+	}
+	data, err := json.Marshal(s.sortedLocked())
+	if err != nil {
+		return fmt.Errorf("failed to encode command result store: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create command result store dir: %w", err)
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write command result store: %w", err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return fmt.Errorf("failed to replace command result store: %w", err)
+	}
+	return nil
+}
+
+// recordResult remembers the terminal result of a side-effecting command.
+// Persist errors are ignored: the in-memory entry still deduplicates until the Agent restarts.
+// recordResult 记录有副作用命令的终态结果。
+// 持久化错误会被忽略：内存中的记录在 Agent 重启前仍可去重。
+func (e *CommandExecutor) recordResult(cmd *pb.CommandRequest, resp *pb.CommandResponse) {
+	if e.results == nil || resp == nil || !IsIdempotentCommandType(cmd.Type) || !isTerminalCommandStatus(resp.Status) {
+		return
+	}
+	_ = e.results.Put(cmd.Type, resp)
+}
+
+// isTerminalCommandStatus reports whether the status ends a command
+// isTerminalCommandStatus 判断状态是否为命令终态
+func isTerminalCommandStatus(status pb.CommandStatus) bool {
+	switch status {
+	case pb.CommandStatus_SUCCESS, pb.CommandStatus_FAILED, pb.CommandStatus_CANCELLED, pb.CommandStatus_TIMEOUT:
+		return true
+	default:
+		return false
+	}
+}
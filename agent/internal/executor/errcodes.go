@@ -0,0 +1,86 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package executor
+
+import (
+	"context"
+	"errors"
+
+	pb "github.com/seatunnel/seatunnelX/agent"
+	"github.com/seatunnel/seatunnelX/agent/internal/installer"
+	"github.com/seatunnel/seatunnelX/internal/pkg/errcode"
+	"github.com/seatunnel/seatunnelX/internal/pkg/maintscript"
+)
+
+// ErrorCodeFor maps a handler error to its catalog code; unknown errors map to ST-EXEC-001.
+// ErrorCodeFor 将处理器错误映射为目录错误码；未知错误映射为 ST-EXEC-001。
+func ErrorCodeFor(err error) errcode.Code {
+	if code, ok := errcode.Of(err); ok {
+		return code
+	}
+	switch {
+	case errors.Is(err, ErrCommandTimeout), errors.Is(err, context.DeadlineExceeded):
+		return errcode.CommandTimeout
+	case errors.Is(err, ErrCommandCancelled), errors.Is(err, context.Canceled):
+		return errcode.CommandCancelled
+	case errors.Is(err, ErrUnknownCommandType), errors.Is(err, ErrHandlerNotRegistered),
+		errors.Is(err, maintscript.ErrUnknownScript):
+		return errcode.UnsupportedCommand
+	case errors.Is(err, maintscript.ErrInvalidArgument):
+		return errcode.InvalidParameters
+	case errors.Is(err, installer.ErrPackageNotFound):
+		return errcode.PackageNotFound
+	case errors.Is(err, installer.ErrChecksumMismatch):
+		return errcode.ChecksumMismatch
+	case errors.Is(err, installer.ErrDownloadFailed):
+		return errcode.DownloadFailed
+	case errors.Is(err, installer.ErrExtractionFailed):
+		return errcode.ExtractionFailed
+	case errors.Is(err, installer.ErrConfigGenerationFailed):
+		return errcode.ConfigGenerationFailed
+	case errors.Is(err, installer.ErrInstallDirNotWritable):
+		return errcode.InstallDirNotWritable
+	case errors.Is(err, installer.ErrInvalidDeploymentMode):
+		return errcode.InvalidDeploymentMode
+	case errors.Is(err, installer.ErrInvalidNodeRole):
+		return errcode.InvalidNodeRole
+	case errors.Is(err, installer.ErrInvalidMirrorSource):
+		return errcode.InvalidMirrorSource
+	case errors.Is(err, installer.ErrClusterMembershipTimeout):
+		return errcode.ClusterMembershipTimeout
+	default:
+		return errcode.CommandFailed
+	}
+}
+
+// withErrorCode fills in the error code of a terminal non-success response that does not carry one.
+// withErrorCode 为未携带错误码的非成功终态响应补充错误码。
+func withErrorCode(resp *pb.CommandResponse) *pb.CommandResponse {
+	if resp == nil || resp.ErrorCode != "" {
+		return resp
+	}
+	switch resp.Status {
+	case pb.CommandStatus_FAILED:
+		resp.ErrorCode = string(errcode.CommandFailed)
+	case pb.CommandStatus_TIMEOUT:
+		resp.ErrorCode = string(errcode.CommandTimeout)
+	case pb.CommandStatus_CANCELLED:
+		resp.ErrorCode = string(errcode.CommandCancelled)
+	}
+	return resp
+}
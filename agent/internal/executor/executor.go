@@ -34,6 +34,11 @@ import (
 	"time"
 
 	pb "github.com/seatunnel/seatunnelX/agent"
+	"github.com/seatunnel/seatunnelX/agent/internal/config"
+	"github.com/seatunnel/seatunnelX/agent/internal/tracing"
+	"github.com/seatunnel/seatunnelX/internal/pkg/errcode"
+	"github.com/seatunnel/seatunnelX/internal/pkg/tracex"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // Common errors for command execution
@@ -68,6 +73,14 @@ type ProgressReporter interface {
 	Report(progress int32, output string) error
 }
 
+// OutputReporter is optionally implemented by a ProgressReporter that can stream raw command output
+// OutputReporter 由可流式上报原始命令输出的 ProgressReporter 选择性实现
+type OutputReporter interface {
+	// ReportOutput sends an incremental stdout/stderr chunk
+	// ReportOutput 发送一段增量 stdout/stderr 输出
+	ReportOutput(chunk string) error
+}
+
 // CommandHandler is a function type that handles a specific command type
 // CommandHandler 是处理特定命令类型的函数类型
 // It receives the context, command request, and a progress reporter
@@ -88,14 +101,42 @@ type CommandExecutor struct {
 	// defaultTimeout is the default timeout for command execution
 	// defaultTimeout 是命令执行的默认超时时间
 	defaultTimeout time.Duration
+
+	// limiter bounds how many commands run at the same time
+	// limiter 限制同时执行的命令数量
+	limiter *concurrencyLimiter
+
+	// running tracks in-flight commands by ID so they can be cancelled
+	// running 按 ID 跟踪执行中的命令以支持取消
+	running map[string]*runningCommand
+
+	// runningMu protects the running map
+	// runningMu 保护 running 映射
+	runningMu sync.Mutex
+
+	// cancelGracePeriod is how long a cancelled handler may spend cleaning up before CANCELLED is reported
+	// cancelGracePeriod 是被取消的处理器在上报 CANCELLED 前可用于清理的时间
+	cancelGracePeriod time.Duration
+
+	// results remembers terminal results of side-effecting commands for deduplication (optional)
+	// results 记录有副作用命令的终态结果用于去重（可选）
+	results *CommandResultStore
+
+	// progressHeartbeatInterval is how often silent running commands re-report progress
+	// progressHeartbeatInterval 是静默执行中命令重新上报进度的间隔
+	progressHeartbeatInterval time.Duration
 }
 
 // NewCommandExecutor creates a new CommandExecutor instance
 // NewCommandExecutor 创建一个新的 CommandExecutor 实例
 func NewCommandExecutor() *CommandExecutor {
 	return &CommandExecutor{
-		handlers:       make(map[pb.CommandType]CommandHandler),
-		defaultTimeout: 5 * time.Minute, // Default 5 minutes timeout / 默认 5 分钟超时
+		handlers:                  make(map[pb.CommandType]CommandHandler),
+		defaultTimeout:            5 * time.Minute, // Default 5 minutes timeout / 默认 5 分钟超时
+		limiter:                   newConcurrencyLimiter(config.DefaultMaxConcurrentCommands, defaultCategoryLimits()),
+		running:                   make(map[string]*runningCommand),
+		cancelGracePeriod:         DefaultCancelGracePeriod,
+		progressHeartbeatInterval: DefaultProgressHeartbeatInterval,
 	}
 }
 
@@ -136,6 +177,12 @@ func (e *CommandExecutor) GetRegisteredTypes() []pb.CommandType {
 	return types
 }
 
+// SetResultStore sets the store used to deduplicate re-sent commands
+// SetResultStore 设置用于对重发命令去重的存储
+func (e *CommandExecutor) SetResultStore(store *CommandResultStore) {
+	e.results = store
+}
+
 // SetDefaultTimeout sets the default timeout for command execution
 // SetDefaultTimeout 设置命令执行的默认超时时间
 func (e *CommandExecutor) SetDefaultTimeout(timeout time.Duration) {
@@ -146,7 +193,9 @@ func (e *CommandExecutor) SetDefaultTimeout(timeout time.Duration) {
 // Execute 执行命令并返回结果
 // It routes the command to the appropriate handler based on command type
 // 它根据命令类型将命令路由到适当的处理器
-func (e *CommandExecutor) Execute(ctx context.Context, cmd *pb.CommandRequest, reporter ProgressReporter) (*pb.CommandResponse, error) {
+// Side-effecting commands already executed under the same ID return the cached result instead of re-executing.
+// 已使用相同 ID 执行过的有副作用命令直接返回缓存结果，不会重复执行。
+func (e *CommandExecutor) Execute(ctx context.Context, cmd *pb.CommandRequest, reporter ProgressReporter) (resp *pb.CommandResponse, err error) {
 	// Validate command / 验证命令
 	if cmd == nil {
 		return nil, errors.New("command request is nil")
@@ -156,6 +205,16 @@ func (e *CommandExecutor) Execute(ctx context.Context, cmd *pb.CommandRequest, r
 		return nil, errors.New("command ID is required")
 	}
 
+	// Cancel requests bypass concurrency limits / 取消请求不受并发限制
+	if cmd.Type == pb.CommandType_CANCEL {
+		return e.handleCancelRequest(cmd), nil
+	}
+
+	// Continue the Control Plane trace carried in the command metadata / 延续命令元数据中携带的 Control Plane 追踪
+	ctx, span := tracing.Start(tracex.Extract(ctx, cmd.Metadata), "Agent."+CommandTypeToString(cmd.Type),
+		attribute.String("seatunnel.command_id", cmd.CommandId))
+	defer func() { tracing.End(span, commandError(resp, err)) }()
+
 	// Get handler for command type / 获取命令类型的处理器
 	e.mu.RLock()
 	handler, exists := e.handlers[cmd.Type]
@@ -175,6 +234,36 @@ func (e *CommandExecutor) Execute(ctx context.Context, cmd *pb.CommandRequest, r
 	execCtx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
+	// Track the command so it can be cancelled by ID; duplicates are answered without re-executing
+	// 跟踪命令以便按 ID 取消；重复命令不会再次执行
+	run, duplicate := e.claimCommand(cmd, cancel)
+	if duplicate != nil {
+		return duplicate, nil
+	}
+	defer e.untrackCommand(cmd.CommandId)
+	// Recorded before untracking so a re-sent command always sees either the running entry or the result
+	// 在取消跟踪之前记录结果，确保重发的命令总能看到执行中记录或结果
+	defer func() { e.recordResult(cmd, resp) }()
+
+	// Heartbeat while the command waits or runs silently / 命令等待或静默执行期间发送心跳
+	live := newLivenessReporter(reporter)
+	stopHeartbeat := live.start(e.progressHeartbeatInterval)
+	defer stopHeartbeat()
+	reporter = live
+
+	// Wait for an execution slot / 等待执行槽位
+	release, err := e.limiter.acquire(execCtx, RouteCommand(cmd.Type), reporter)
+	if err != nil {
+		if run.isCancelled() {
+			return CreateCancelledResponse(cmd.CommandId, "Command cancelled before it started / 命令在开始前已取消"), ErrCommandCancelled
+		}
+		if errors.Is(err, context.DeadlineExceeded) {
+			return CreateTimeoutResponse(cmd.CommandId, ErrCommandTimeout.Error()), ErrCommandTimeout
+		}
+		return e.createErrorResponse(cmd.CommandId, ErrCommandCancelled), ErrCommandCancelled
+	}
+	defer release()
+
 	// Create a channel for the result / 创建结果通道
 	resultCh := make(chan *pb.CommandResponse, 1)
 	errCh := make(chan error, 1)
@@ -192,17 +281,48 @@ func (e *CommandExecutor) Execute(ctx context.Context, cmd *pb.CommandRequest, r
 	// Wait for result or timeout / 等待结果或超时
 	select {
 	case resp := <-resultCh:
-		return resp, nil
+		if run.isCancelled() && (resp == nil || resp.Status != pb.CommandStatus_SUCCESS) {
+			return CreateCancelledResponse(cmd.CommandId, "Command cancelled / 命令已取消"), ErrCommandCancelled
+		}
+		return withErrorCode(resp), nil
 	case err := <-errCh:
+		if run.isCancelled() {
+			return CreateCancelledResponse(cmd.CommandId, "Command cancelled / 命令已取消"), ErrCommandCancelled
+		}
+		if errors.Is(execCtx.Err(), context.DeadlineExceeded) {
+			return CreateTimeoutResponse(cmd.CommandId, ErrCommandTimeout.Error()), ErrCommandTimeout
+		}
 		return e.createErrorResponse(cmd.CommandId, err), err
 	case <-execCtx.Done():
+		if run.isCancelled() {
+			// Give the handler a chance to clean up partial state before reporting
+			// 上报前给处理器留出清理部分状态的时间
+			select {
+			case <-resultCh:
+			case <-errCh:
+			case <-time.After(e.cancelGracePeriod):
+			}
+			return CreateCancelledResponse(cmd.CommandId, "Command cancelled / 命令已取消"), ErrCommandCancelled
+		}
 		if errors.Is(execCtx.Err(), context.DeadlineExceeded) {
-			return e.createErrorResponse(cmd.CommandId, ErrCommandTimeout), ErrCommandTimeout
+			return CreateTimeoutResponse(cmd.CommandId, ErrCommandTimeout.Error()), ErrCommandTimeout
 		}
 		return e.createErrorResponse(cmd.CommandId, ErrCommandCancelled), ErrCommandCancelled
 	}
 }
 
+// commandError returns the error to record on the command span: the execution error or a failed response.
+// commandError 返回需要记录在命令 span 上的错误：执行错误或失败的响应。
+func commandError(resp *pb.CommandResponse, err error) error {
+	if err != nil {
+		return err
+	}
+	if resp != nil && resp.Status == pb.CommandStatus_FAILED {
+		return errors.New(resp.Error)
+	}
+	return nil
+}
+
 // RouteCommand determines the appropriate handler category for a command type
 // RouteCommand 确定命令类型的适当处理器类别
 // Returns the category name for logging/debugging purposes
@@ -215,10 +335,12 @@ func RouteCommand(cmdType pb.CommandType) string {
 		return "installer"
 	case pb.CommandType_START, pb.CommandType_STOP, pb.CommandType_RESTART, pb.CommandType_STATUS:
 		return "process"
-	case pb.CommandType_COLLECT_LOGS, pb.CommandType_JVM_DUMP, pb.CommandType_THREAD_DUMP:
+	case pb.CommandType_COLLECT_LOGS, pb.CommandType_JVM_DUMP, pb.CommandType_THREAD_DUMP, pb.CommandType_DIAGNOSE:
 		return "diagnostic"
 	case pb.CommandType_UPDATE_CONFIG, pb.CommandType_ROLLBACK_CONFIG, pb.CommandType_PULL_CONFIG:
 		return "config"
+	case pb.CommandType_EXEC_SCRIPT:
+		return "maintenance"
 	default:
 		return "unknown"
 	}
@@ -233,6 +355,7 @@ func (e *CommandExecutor) createErrorResponse(commandID string, err error) *pb.C
 		Progress:  0,
 		Output:    "",
 		Error:     err.Error(),
+		ErrorCode: string(ErrorCodeFor(err)),
 		Timestamp: time.Now().UnixMilli(),
 	}
 }
@@ -263,6 +386,45 @@ func CreateProgressResponse(commandID string, progress int32, output string) *pb
 	}
 }
 
+// CreateOutputChunkResponse creates a running CommandResponse carrying an incremental output chunk
+// CreateOutputChunkResponse 创建携带增量输出片段的运行中 CommandResponse
+func CreateOutputChunkResponse(commandID string, chunk string) *pb.CommandResponse {
+	return &pb.CommandResponse{
+		CommandId:   commandID,
+		Status:      pb.CommandStatus_RUNNING,
+		OutputChunk: chunk,
+		Timestamp:   time.Now().UnixMilli(),
+	}
+}
+
+// CreateCancelledResponse creates a CommandResponse with cancelled status
+// CreateCancelledResponse 创建带有取消状态的 CommandResponse
+func CreateCancelledResponse(commandID string, output string) *pb.CommandResponse {
+	return &pb.CommandResponse{
+		CommandId: commandID,
+		Status:    pb.CommandStatus_CANCELLED,
+		Progress:  0,
+		Output:    output,
+		Error:     ErrCommandCancelled.Error(),
+		ErrorCode: string(errcode.CommandCancelled),
+		Timestamp: time.Now().UnixMilli(),
+	}
+}
+
+// CreateTimeoutResponse creates a CommandResponse with timeout status
+// CreateTimeoutResponse 创建带有超时状态的 CommandResponse
+func CreateTimeoutResponse(commandID string, errMsg string) *pb.CommandResponse {
+	return &pb.CommandResponse{
+		CommandId: commandID,
+		Status:    pb.CommandStatus_TIMEOUT,
+		Progress:  0,
+		Output:    "",
+		Error:     errMsg,
+		ErrorCode: string(errcode.CommandTimeout),
+		Timestamp: time.Now().UnixMilli(),
+	}
+}
+
 // CreateErrorResponse creates a CommandResponse with failed status
 // CreateErrorResponse 创建带有失败状态的 CommandResponse
 func CreateErrorResponse(commandID string, errMsg string) *pb.CommandResponse {
@@ -272,6 +434,7 @@ func CreateErrorResponse(commandID string, errMsg string) *pb.CommandResponse {
 		Progress:  0,
 		Output:    "",
 		Error:     errMsg,
+		ErrorCode: string(errcode.CommandFailed),
 		Timestamp: time.Now().UnixMilli(),
 	}
 }
@@ -324,6 +487,10 @@ type CallbackReporter struct {
 	// Callback is the function to call with progress updates
 	// Callback 是用于进度更新的回调函数
 	Callback func(commandID string, progress int32, output string) error
+
+	// OutputCallback is the optional function to call with incremental output chunks
+	// OutputCallback 是用于增量输出片段的可选回调函数
+	OutputCallback func(commandID string, chunk string) error
 }
 
 // Report calls the callback function with the progress update
@@ -335,6 +502,15 @@ func (r *CallbackReporter) Report(progress int32, output string) error {
 	return r.Callback(r.CommandID, progress, output)
 }
 
+// ReportOutput calls the output callback with an incremental output chunk
+// ReportOutput 使用增量输出片段调用输出回调函数
+func (r *CallbackReporter) ReportOutput(chunk string) error {
+	if r.OutputCallback == nil {
+		return nil
+	}
+	return r.OutputCallback(r.CommandID, chunk)
+}
+
 // CommandTypeToString converts a CommandType to its string representation
 // CommandTypeToString 将 CommandType 转换为其字符串表示
 func CommandTypeToString(cmdType pb.CommandType) string {
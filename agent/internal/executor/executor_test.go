@@ -0,0 +1,255 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package executor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	pb "github.com/seatunnel/seatunnelX/agent"
+	"github.com/seatunnel/seatunnelX/agent/internal/installer"
+	"github.com/seatunnel/seatunnelX/internal/pkg/errcode"
+)
+
+func TestExecuteCancelRequestCancelsRunningCommand(t *testing.T) {
+	exec := NewCommandExecutor()
+	exec.SetCancelGracePeriod(time.Second)
+
+	cleaned := make(chan struct{})
+	started := make(chan struct{})
+	exec.RegisterHandler(pb.CommandType_INSTALL, func(ctx context.Context, cmd *pb.CommandRequest, reporter ProgressReporter) (*pb.CommandResponse, error) {
+		close(started)
+		<-ctx.Done()
+		close(cleaned)
+		return nil, ctx.Err()
+	})
+
+	type result struct {
+		resp *pb.CommandResponse
+		err  error
+	}
+	done := make(chan result, 1)
+	go func() {
+		resp, err := exec.Execute(context.Background(), &pb.CommandRequest{CommandId: "install-1", Type: pb.CommandType_INSTALL}, &NoOpReporter{})
+		done <- result{resp, err}
+	}()
+	<-started
+
+	cancelResp, err := exec.Execute(context.Background(), &pb.CommandRequest{
+		CommandId: "cancel-1",
+		Type:      pb.CommandType_CANCEL,
+		Parameters: map[string]string{
+			"target_command_id": "install-1",
+		},
+	}, &NoOpReporter{})
+	if err != nil || cancelResp.Status != pb.CommandStatus_SUCCESS {
+		t.Fatalf("expected cancel request to succeed, got resp=%+v err=%v", cancelResp, err)
+	}
+
+	res := <-done
+	if !errors.Is(res.err, ErrCommandCancelled) || res.resp.Status != pb.CommandStatus_CANCELLED {
+		t.Fatalf("expected CANCELLED response, got resp=%+v err=%v", res.resp, res.err)
+	}
+	select {
+	case <-cleaned:
+	default:
+		t.Fatal("expected handler to finish cleanup before CANCELLED was reported")
+	}
+	if len(exec.RunningCommands()) != 0 {
+		t.Fatalf("expected no running commands, got %v", exec.RunningCommands())
+	}
+}
+
+func TestExecuteCancelUnknownCommandFails(t *testing.T) {
+	exec := NewCommandExecutor()
+	resp, err := exec.Execute(context.Background(), &pb.CommandRequest{
+		CommandId: "cancel-2",
+		Type:      pb.CommandType_CANCEL,
+		Parameters: map[string]string{
+			"target_command_id": "missing",
+		},
+	}, &NoOpReporter{})
+	if err != nil || resp.Status != pb.CommandStatus_FAILED {
+		t.Fatalf("expected FAILED response for unknown target, got resp=%+v err=%v", resp, err)
+	}
+}
+
+func TestExecuteInstallerCommandsAreSerialized(t *testing.T) {
+	exec := NewCommandExecutor()
+
+	release := make(chan struct{})
+	running := make(chan string, 2)
+	exec.RegisterHandler(pb.CommandType_INSTALL, func(ctx context.Context, cmd *pb.CommandRequest, reporter ProgressReporter) (*pb.CommandResponse, error) {
+		running <- cmd.CommandId
+		<-release
+		return CreateSuccessResponse(cmd.CommandId, "ok"), nil
+	})
+
+	done := make(chan struct{}, 2)
+	for _, id := range []string{"install-a", "install-b"} {
+		go func(id string) {
+			_, _ = exec.Execute(context.Background(), &pb.CommandRequest{CommandId: id, Type: pb.CommandType_INSTALL}, &NoOpReporter{})
+			done <- struct{}{}
+		}(id)
+	}
+
+	<-running
+	select {
+	case id := <-running:
+		t.Fatalf("expected second install to wait for a slot, but %s started", id)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	close(release)
+	<-running
+	<-done
+	<-done
+}
+
+func TestExecuteDeduplicatesResentInstallCommand(t *testing.T) {
+	storePath := filepath.Join(t.TempDir(), "command_results.json")
+	store, err := NewCommandResultStore(storePath)
+	if err != nil {
+		t.Fatalf("NewCommandResultStore returned error: %v", err)
+	}
+	exec := NewCommandExecutor()
+	exec.SetResultStore(store)
+
+	var runs atomic.Int32
+	exec.RegisterHandler(pb.CommandType_INSTALL, func(ctx context.Context, cmd *pb.CommandRequest, reporter ProgressReporter) (*pb.CommandResponse, error) {
+		runs.Add(1)
+		return CreateSuccessResponse(cmd.CommandId, "installed"), nil
+	})
+
+	cmd := &pb.CommandRequest{CommandId: "install-dup", Type: pb.CommandType_INSTALL}
+	for i := 0; i < 2; i++ {
+		resp, err := exec.Execute(context.Background(), cmd, &NoOpReporter{})
+		if err != nil || resp.Status != pb.CommandStatus_SUCCESS || resp.Output != "installed" {
+			t.Fatalf("attempt %d: unexpected resp=%+v err=%v", i, resp, err)
+		}
+	}
+	if runs.Load() != 1 {
+		t.Fatalf("expected install handler to run once, ran %d times", runs.Load())
+	}
+
+	// Results survive an Agent restart / 结果在 Agent 重启后仍然保留
+	reloaded, err := NewCommandResultStore(storePath)
+	if err != nil {
+		t.Fatalf("reload returned error: %v", err)
+	}
+	if cached, ok := reloaded.Get("install-dup"); !ok || cached.Status != pb.CommandStatus_SUCCESS {
+		t.Fatalf("expected persisted result, got %+v ok=%v", cached, ok)
+	}
+}
+
+func TestExecuteIgnoresDuplicateWhileRunning(t *testing.T) {
+	store, _ := NewCommandResultStore("")
+	exec := NewCommandExecutor()
+	exec.SetResultStore(store)
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	exec.RegisterHandler(pb.CommandType_UNINSTALL, func(ctx context.Context, cmd *pb.CommandRequest, reporter ProgressReporter) (*pb.CommandResponse, error) {
+		close(started)
+		<-release
+		return CreateSuccessResponse(cmd.CommandId, "removed"), nil
+	})
+
+	cmd := &pb.CommandRequest{CommandId: "uninstall-dup", Type: pb.CommandType_UNINSTALL}
+	done := make(chan struct{})
+	go func() {
+		_, _ = exec.Execute(context.Background(), cmd, &NoOpReporter{})
+		close(done)
+	}()
+	<-started
+
+	resp, err := exec.Execute(context.Background(), cmd, &NoOpReporter{})
+	if err != nil || resp.Status != pb.CommandStatus_RUNNING {
+		t.Fatalf("expected RUNNING response for in-flight duplicate, got resp=%+v err=%v", resp, err)
+	}
+	close(release)
+	<-done
+	if store.Len() != 1 {
+		t.Fatalf("expected one remembered result, got %d", store.Len())
+	}
+}
+
+func TestExecuteSendsHeartbeatsAndReportsTimeout(t *testing.T) {
+	exec := NewCommandExecutor()
+	exec.SetProgressHeartbeatInterval(50 * time.Millisecond)
+	exec.RegisterHandler(pb.CommandType_INSTALL, func(ctx context.Context, cmd *pb.CommandRequest, reporter ProgressReporter) (*pb.CommandResponse, error) {
+		_ = reporter.Report(40, "[download] downloading package")
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+
+	var heartbeats, updates atomic.Int32
+	reporter := &CallbackReporter{CommandID: "install-timeout", Callback: func(commandID string, progress int32, output string) error {
+		if output == "" {
+			if progress != 40 {
+				t.Errorf("expected heartbeat to repeat the last progress, got %d", progress)
+			}
+			heartbeats.Add(1)
+		} else {
+			updates.Add(1)
+		}
+		return nil
+	}}
+
+	resp, err := exec.Execute(context.Background(), &pb.CommandRequest{CommandId: "install-timeout", Type: pb.CommandType_INSTALL, Timeout: 1}, reporter)
+	if !errors.Is(err, ErrCommandTimeout) || resp.Status != pb.CommandStatus_TIMEOUT {
+		t.Fatalf("expected TIMEOUT response, got resp=%+v err=%v", resp, err)
+	}
+	if updates.Load() != 1 {
+		t.Fatalf("expected the handler update to be forwarded once, got %d", updates.Load())
+	}
+	if heartbeats.Load() < 5 {
+		t.Fatalf("expected heartbeats while the handler was silent, got %d", heartbeats.Load())
+	}
+}
+
+func TestExecuteAttachesErrorCodes(t *testing.T) {
+	exec := NewCommandExecutor()
+	exec.RegisterHandler(pb.CommandType_INSTALL, func(ctx context.Context, cmd *pb.CommandRequest, reporter ProgressReporter) (*pb.CommandResponse, error) {
+		err := fmt.Errorf("verify package: %w", installer.ErrChecksumMismatch)
+		return CreateErrorResponse(cmd.CommandId, err.Error()), err
+	})
+	exec.RegisterHandler(pb.CommandType_PRECHECK, func(ctx context.Context, cmd *pb.CommandRequest, reporter ProgressReporter) (*pb.CommandResponse, error) {
+		return &pb.CommandResponse{CommandId: cmd.CommandId, Status: pb.CommandStatus_FAILED, Error: "port in use"}, nil
+	})
+
+	resp, err := exec.Execute(context.Background(), &pb.CommandRequest{CommandId: "install-checksum", Type: pb.CommandType_INSTALL}, &NoOpReporter{})
+	if err == nil || resp.ErrorCode != string(errcode.ChecksumMismatch) {
+		t.Fatalf("expected checksum_mismatch code, got resp=%+v err=%v", resp, err)
+	}
+
+	resp, err = exec.Execute(context.Background(), &pb.CommandRequest{CommandId: "precheck-failed", Type: pb.CommandType_PRECHECK}, &NoOpReporter{})
+	if err != nil || resp.ErrorCode != string(errcode.CommandFailed) {
+		t.Fatalf("expected command_failed code, got resp=%+v err=%v", resp, err)
+	}
+
+	resp, _ = exec.Execute(context.Background(), &pb.CommandRequest{CommandId: "no-handler", Type: pb.CommandType_UPGRADE}, &NoOpReporter{})
+	if resp.ErrorCode != string(errcode.UnsupportedCommand) {
+		t.Fatalf("expected unsupported_command code, got %q", resp.ErrorCode)
+	}
+}
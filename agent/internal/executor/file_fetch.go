@@ -0,0 +1,59 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package executor
+
+import (
+	"context"
+	"errors"
+
+	"github.com/seatunnel/seatunnelX/internal/pkg/filestream"
+)
+
+// FileFetcher pulls a file offered by the Control Plane through the FetchFile RPC,
+// handing every decompressed chunk to fn in order.
+// FileFetcher 通过 FetchFile RPC 拉取 Control Plane 提供的文件，并按顺序将每个解压后的数据块交给 fn。
+type FileFetcher func(ctx context.Context, transferID string, offset int64, fn func(data []byte, offset, totalSize int64, isLast bool) error) error
+
+// fileFetcher is set by the Agent once the gRPC client exists.
+// fileFetcher 在 gRPC 客户端创建后由 Agent 设置。
+var fileFetcher FileFetcher
+
+// SetFileFetcher sets the fetcher used by transfer commands in streaming mode.
+// SetFileFetcher 设置流式模式下传输命令使用的拉取函数。
+func SetFileFetcher(fetcher FileFetcher) {
+	fileFetcher = fetcher
+}
+
+// isStreamTransfer reports whether a transfer command asks the Agent to pull the file itself.
+// isStreamTransfer 判断传输命令是否要求 Agent 自行拉取文件。
+func isStreamTransfer(params map[string]string) bool {
+	return params[filestream.ParamTransferMode] == filestream.ModeStream
+}
+
+// fetchStreamedFile pulls the whole file referenced by a streaming transfer command.
+// fetchStreamedFile 拉取流式传输命令引用的完整文件。
+func fetchStreamedFile(ctx context.Context, params map[string]string, fn func(data []byte, offset, totalSize int64, isLast bool) error) error {
+	if fileFetcher == nil {
+		return errors.New("file fetcher not configured / 文件拉取器未配置")
+	}
+	transferID := params[filestream.ParamTransferID]
+	if transferID == "" {
+		return errors.New("transfer_id is required for streaming transfer / 流式传输需要 transfer_id")
+	}
+	return fileFetcher(ctx, transferID, 0, fn)
+}
@@ -0,0 +1,98 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package executor
+
+import (
+	"sync"
+	"time"
+)
+
+// DefaultProgressHeartbeatInterval is how often a silent running command re-reports its progress
+// DefaultProgressHeartbeatInterval 是静默执行中的命令重新上报进度的默认间隔
+const DefaultProgressHeartbeatInterval = 20 * time.Second
+
+// livenessReporter forwards progress and re-sends the latest progress as a heartbeat while the handler is silent,
+// so the Control Plane can tell a long-running command from one whose Agent died.
+// Heartbeats carry no output, which the Control Plane treats as liveness only.
+// livenessReporter 转发进度，并在处理器静默时将最新进度作为心跳重新发送，
+// 使 Control Plane 能区分长时间执行的命令与 Agent 已失联的命令。心跳不携带输出，Control Plane 仅将其视为存活信号。
+type livenessReporter struct {
+	inner ProgressReporter
+
+	mu         sync.Mutex
+	progress   int32
+	lastReport time.Time
+}
+
+// newLivenessReporter wraps reporter; a nil reporter only tracks progress
+// newLivenessReporter 包装 reporter；reporter 为 nil 时仅记录进度
+func newLivenessReporter(reporter ProgressReporter) *livenessReporter {
+	return &livenessReporter{inner: reporter, lastReport: time.Now()}
+}
+
+// Report records the progress and forwards it
+// Report 记录进度并转发
+func (r *livenessReporter) Report(progress int32, output string) error {
+	r.mu.Lock()
+	r.progress = progress
+	r.lastReport = time.Now()
+	r.mu.Unlock()
+	if r.inner == nil {
+		return nil
+	}
+	return r.inner.Report(progress, output)
+}
+
+// start sends heartbeats every interval without reports until the returned stop function is called.
+// A non-positive interval disables heartbeats.
+// start 在没有上报的每个间隔发送心跳，直到调用返回的 stop 函数。间隔不大于 0 时不发送心跳。
+func (r *livenessReporter) start(interval time.Duration) (stop func()) {
+	if interval <= 0 || r.inner == nil {
+		return func() {}
+	}
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case now := <-ticker.C:
+				r.mu.Lock()
+				silent := now.Sub(r.lastReport) >= interval
+				progress := r.progress
+				if silent {
+					r.lastReport = now
+				}
+				r.mu.Unlock()
+				if silent {
+					_ = r.inner.Report(progress, "")
+				}
+			}
+		}
+	}()
+	var once sync.Once
+	return func() { once.Do(func() { close(done) }) }
+}
+
+// SetProgressHeartbeatInterval sets how often silent running commands send heartbeats; non-positive disables them
+// SetProgressHeartbeatInterval 设置静默执行中命令发送心跳的间隔；不大于 0 时禁用心跳
+func (e *CommandExecutor) SetProgressHeartbeatInterval(interval time.Duration) {
+	e.progressHeartbeatInterval = interval
+}
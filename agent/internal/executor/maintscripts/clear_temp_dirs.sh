@@ -0,0 +1,39 @@
+#!/bin/sh
+# Licensed to the Apache Software Foundation (ASF) under one or more
+# contributor license agreements.  See the NOTICE file distributed with
+# this work for additional information regarding copyright ownership.
+# The ASF licenses this file to You under the Apache License, Version 2.0
+# (the "License"); you may not use this file except in compliance with
+# the License.  You may obtain a copy of the License at
+#
+#    http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# Remove files older than N days from the SeaTunnel temp directories.
+# 清理 SeaTunnel 临时目录中超过 N 天的文件。
+# Usage: clear_temp_dirs.sh <install_dir> <older_than_days>
+set -eu
+
+install_dir="$1"
+older_than_days="$2"
+
+[ -d "$install_dir" ] || { echo "install dir not found: $install_dir" >&2; exit 2; }
+
+total=0
+for dir in "$install_dir/temp" "$install_dir/tmp"; do
+  if [ ! -d "$dir" ]; then
+    echo "skip $dir: not found"
+    continue
+  fi
+  count=$(find "$dir" -mindepth 1 -type f -mtime +"$older_than_days" | wc -l | tr -d ' ')
+  find "$dir" -mindepth 1 -type f -mtime +"$older_than_days" -exec rm -f {} +
+  find "$dir" -mindepth 1 -type d -empty -exec rmdir {} + 2>/dev/null || true
+  echo "removed $count file(s) from $dir"
+  total=$((total + count))
+done
+echo "total removed: $total"
@@ -0,0 +1,28 @@
+#!/bin/sh
+# Licensed to the Apache Software Foundation (ASF) under one or more
+# contributor license agreements.  See the NOTICE file distributed with
+# this work for additional information regarding copyright ownership.
+# The ASF licenses this file to You under the Apache License, Version 2.0
+# (the "License"); you may not use this file except in compliance with
+# the License.  You may obtain a copy of the License at
+#
+#    http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# Report disk usage of the SeaTunnel install directory and its filesystem.
+# 报告 SeaTunnel 安装目录及其所在文件系统的磁盘使用情况。
+# Usage: disk_usage.sh <install_dir>
+set -eu
+
+install_dir="$1"
+
+[ -d "$install_dir" ] || { echo "install dir not found: $install_dir" >&2; exit 2; }
+
+df -h "$install_dir"
+echo
+du -sh "$install_dir"
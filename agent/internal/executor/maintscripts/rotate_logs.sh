@@ -0,0 +1,40 @@
+#!/bin/sh
+# Licensed to the Apache Software Foundation (ASF) under one or more
+# contributor license agreements.  See the NOTICE file distributed with
+# this work for additional information regarding copyright ownership.
+# The ASF licenses this file to You under the Apache License, Version 2.0
+# (the "License"); you may not use this file except in compliance with
+# the License.  You may obtain a copy of the License at
+#
+#    http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# Compress oversized SeaTunnel logs in place and purge archives older than N days.
+# 原地压缩过大的 SeaTunnel 日志，并清理超过 N 天的归档。
+# Usage: rotate_logs.sh <install_dir> <max_size_mb> <keep_days>
+set -eu
+
+install_dir="$1"
+max_size_mb="$2"
+keep_days="$3"
+log_dir="$install_dir/logs"
+
+[ -d "$log_dir" ] || { echo "log dir not found: $log_dir" >&2; exit 2; }
+
+stamp=$(date +%Y%m%d%H%M%S)
+find "$log_dir" -maxdepth 1 -type f -name '*.log' -size +"${max_size_mb}M" | while IFS= read -r file; do
+  gzip -c "$file" > "$file.$stamp.gz"
+  : > "$file"
+  echo "rotated $file -> $file.$stamp.gz"
+done
+
+find "$log_dir" -maxdepth 1 -type f -name '*.log.*.gz' -mtime +"$keep_days" | while IFS= read -r archive; do
+  rm -f "$archive"
+  echo "purged $archive"
+done
+echo "rotation finished"
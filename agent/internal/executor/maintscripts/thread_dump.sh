@@ -0,0 +1,37 @@
+#!/bin/sh
+# Licensed to the Apache Software Foundation (ASF) under one or more
+# contributor license agreements.  See the NOTICE file distributed with
+# this work for additional information regarding copyright ownership.
+# The ASF licenses this file to You under the Apache License, Version 2.0
+# (the "License"); you may not use this file except in compliance with
+# the License.  You may obtain a copy of the License at
+#
+#    http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# Print a thread dump of a running Java process.
+# 打印运行中 Java 进程的线程转储。
+# Usage: thread_dump.sh <pid>
+set -eu
+
+pid="$1"
+
+kill -0 "$pid" 2>/dev/null || { echo "process $pid is not running" >&2; exit 2; }
+case "$(ps -p "$pid" -o comm= 2>/dev/null)" in
+  *java*) ;;
+  *) echo "process $pid is not a Java process" >&2; exit 2 ;;
+esac
+
+if command -v jstack >/dev/null 2>&1; then
+  exec jstack "$pid"
+fi
+if [ -n "${JAVA_HOME:-}" ] && [ -x "$JAVA_HOME/bin/jstack" ]; then
+  exec "$JAVA_HOME/bin/jstack" "$pid"
+fi
+echo "jstack not found in PATH or JAVA_HOME" >&2
+exit 3
@@ -0,0 +1,161 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package executor
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	// outputStreamFlushSize is the buffered size that triggers an immediate flush
+	// outputStreamFlushSize 是触发立即上报的缓冲区大小
+	outputStreamFlushSize = 16 * 1024
+
+	// outputStreamFlushInterval bounds how long buffered output waits before it is sent
+	// outputStreamFlushInterval 限制缓冲输出在上报前的最长等待时间
+	outputStreamFlushInterval = 500 * time.Millisecond
+
+	// MaxStreamedOutputBytes caps the output streamed for a single command
+	// MaxStreamedOutputBytes 限制单个命令可流式上报的输出量
+	MaxStreamedOutputBytes = 16 * 1024 * 1024
+)
+
+// OutputStreamWriter batches stdout/stderr writes into output chunks sent through an OutputReporter.
+// Writes never fail so a slow or disconnected Control Plane does not interrupt the running process.
+// OutputStreamWriter 将 stdout/stderr 写入合并为输出片段，通过 OutputReporter 上报。
+// 写入从不失败，避免 Control Plane 变慢或断开时中断正在运行的进程。
+type OutputStreamWriter struct {
+	reporter OutputReporter
+
+	mu        sync.Mutex
+	buf       []byte
+	sent      int64
+	truncated bool
+	streamed  bool
+	failed    bool
+	closed    bool
+	stop      chan struct{}
+	done      chan struct{}
+}
+
+// NewOutputStreamWriter creates a writer streaming to reporter. When reporter cannot stream output
+// the writer discards everything and Streamed reports false.
+// NewOutputStreamWriter 创建向 reporter 流式上报的写入器。reporter 不支持流式输出时，
+// 写入器丢弃所有内容且 Streamed 返回 false。
+func NewOutputStreamWriter(reporter ProgressReporter) *OutputStreamWriter {
+	w := &OutputStreamWriter{
+		stop: make(chan struct{}),
+		done: make(chan struct{}),
+	}
+	outputReporter, ok := reporter.(OutputReporter)
+	if !ok {
+		close(w.done)
+		return w
+	}
+	w.reporter = outputReporter
+	go w.flushLoop()
+	return w
+}
+
+// Write implements io.Writer.
+// Write 实现 io.Writer。
+func (w *OutputStreamWriter) Write(p []byte) (int, error) {
+	if w.reporter == nil {
+		return len(p), nil
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed || w.truncated {
+		return len(p), nil
+	}
+
+	data := p
+	if room := MaxStreamedOutputBytes - w.sent - int64(len(w.buf)); int64(len(data)) > room {
+		data = data[:max(room, 0)]
+		w.truncated = true
+	}
+	w.buf = append(w.buf, data...)
+	if w.truncated {
+		w.buf = append(w.buf, fmt.Sprintf("\n... streamed output truncated at %d bytes / 流式输出已在 %d 字节处截断\n", MaxStreamedOutputBytes, MaxStreamedOutputBytes)...)
+	}
+	if len(w.buf) >= outputStreamFlushSize || w.truncated {
+		w.flushLocked()
+	}
+	return len(p), nil
+}
+
+// Close flushes the remaining output and stops the background flusher.
+// Close 上报剩余输出并停止后台上报协程。
+func (w *OutputStreamWriter) Close() error {
+	w.mu.Lock()
+	if w.closed {
+		w.mu.Unlock()
+		return nil
+	}
+	w.closed = true
+	if w.reporter != nil {
+		close(w.stop)
+		w.flushLocked()
+	}
+	w.mu.Unlock()
+	<-w.done
+	return nil
+}
+
+// Streamed reports whether the output was fully delivered as chunks, so the final response
+// does not need to be appended to the command log again.
+// Streamed 返回输出是否已全部以片段形式上报，此时终态响应无需再次追加到命令日志。
+func (w *OutputStreamWriter) Streamed() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.streamed && !w.failed
+}
+
+func (w *OutputStreamWriter) flushLoop() {
+	defer close(w.done)
+	ticker := time.NewTicker(outputStreamFlushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-w.stop:
+			return
+		case <-ticker.C:
+			w.mu.Lock()
+			w.flushLocked()
+			w.mu.Unlock()
+		}
+	}
+}
+
+// flushLocked sends the buffered output. Callers hold w.mu.
+// flushLocked 上报缓冲的输出。调用方需持有 w.mu。
+func (w *OutputStreamWriter) flushLocked() {
+	if len(w.buf) == 0 {
+		return
+	}
+	chunk := string(w.buf)
+	w.sent += int64(len(w.buf))
+	w.buf = w.buf[:0]
+	if err := w.reporter.ReportOutput(chunk); err != nil {
+		w.failed = true
+		return
+	}
+	w.streamed = true
+}
@@ -0,0 +1,118 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package executor
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	pb "github.com/seatunnel/seatunnelX/agent"
+	"github.com/seatunnel/seatunnelX/internal/pkg/maintscript"
+)
+
+// chunkRecorder collects output chunks reported through CallbackReporter.
+type chunkRecorder struct {
+	mu     sync.Mutex
+	chunks []string
+	fail   bool
+}
+
+func (r *chunkRecorder) reporter(commandID string) *CallbackReporter {
+	return &CallbackReporter{
+		CommandID: commandID,
+		Callback:  func(string, int32, string) error { return nil },
+		OutputCallback: func(_ string, chunk string) error {
+			r.mu.Lock()
+			defer r.mu.Unlock()
+			if r.fail {
+				return errors.New("stream closed")
+			}
+			r.chunks = append(r.chunks, chunk)
+			return nil
+		},
+	}
+}
+
+func (r *chunkRecorder) output() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return strings.Join(r.chunks, "")
+}
+
+func TestOutputStreamWriterBatchesAndFlushesOnClose(t *testing.T) {
+	recorder := &chunkRecorder{}
+	w := NewOutputStreamWriter(recorder.reporter("cmd-1"))
+	for i := 0; i < 3; i++ {
+		if _, err := w.Write([]byte("line\n")); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+	}
+	big := strings.Repeat("x", outputStreamFlushSize)
+	if _, err := w.Write([]byte(big)); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if got := recorder.output(); got != "line\nline\nline\n"+big {
+		t.Fatalf("expected buffer flushed once it reached the flush size, got %d bytes", len(got))
+	}
+	_, _ = w.Write([]byte("tail\n"))
+	if err := w.Close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+	if !strings.HasSuffix(recorder.output(), big+"tail\n") || !w.Streamed() {
+		t.Fatalf("expected remaining output flushed on close, streamed=%t", w.Streamed())
+	}
+}
+
+func TestOutputStreamWriterWithoutOutputReporter(t *testing.T) {
+	w := NewOutputStreamWriter(&NoOpReporter{})
+	if n, err := w.Write([]byte("discarded")); err != nil || n != 9 {
+		t.Fatalf("expected write to succeed, got n=%d err=%v", n, err)
+	}
+	_ = w.Close()
+	if w.Streamed() {
+		t.Fatalf("expected nothing streamed without an output reporter")
+	}
+}
+
+func TestOutputStreamWriterReportsFailedDelivery(t *testing.T) {
+	recorder := &chunkRecorder{fail: true}
+	w := NewOutputStreamWriter(recorder.reporter("cmd-2"))
+	_, _ = w.Write([]byte("lost\n"))
+	_ = w.Close()
+	if w.Streamed() {
+		t.Fatalf("expected Streamed to be false when a chunk could not be delivered")
+	}
+}
+
+func TestHandleExecScriptCommand_StreamsOutput(t *testing.T) {
+	recorder := &chunkRecorder{}
+	resp, err := HandleExecScriptCommand(context.Background(), &pb.CommandRequest{
+		CommandId:  "script-stream",
+		Type:       pb.CommandType_EXEC_SCRIPT,
+		Parameters: maintscript.EncodeParams("rotate_logs", map[string]string{"install_dir": t.TempDir()}),
+	}, recorder.reporter("script-stream"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !resp.OutputStreamed || recorder.output() != resp.Output {
+		t.Fatalf("expected streamed output %q to match final output %q (streamed=%t)", recorder.output(), resp.Output, resp.OutputStreamed)
+	}
+}
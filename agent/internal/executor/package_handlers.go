@@ -23,10 +23,12 @@ import (
 	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
 
 	pb "github.com/seatunnel/seatunnelX/agent"
@@ -39,8 +41,8 @@ type PackageTransferManager struct {
 	// tempDir 是传输过程中存储临时文件的目录
 	tempDir string
 
-	// packageDir is the directory for storing completed packages
-	// packageDir 是存储完成传输的安装包的目录
+	// packageDir is the package cache directory; verified packages are kept under <version>/<checksum>
+	// packageDir 是安装包缓存目录；已校验的安装包保存在 <version>/<checksum> 下
 	packageDir string
 
 	// activeTransfers tracks ongoing transfers by version
@@ -102,6 +104,22 @@ func (m *PackageTransferManager) SetDirectories(tempDir, packageDir string) {
 	}
 }
 
+// SetCacheDir sets the package cache directory, keeping the current one if it cannot be created
+// SetCacheDir 设置安装包缓存目录，无法创建时保留当前目录
+func (m *PackageTransferManager) SetCacheDir(cacheDir string) error {
+	if cacheDir == "" {
+		return nil
+	}
+	if err := os.MkdirAll(cacheDir, 0755); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.packageDir = cacheDir
+	return nil
+}
+
 // TransferPackageRequest represents a package transfer chunk request
 // TransferPackageRequest 表示安装包传输块请求
 type TransferPackageRequest struct {
@@ -136,6 +154,11 @@ func HandleTransferPackageCommand(ctx context.Context, cmd *pb.CommandRequest, r
 		}, nil
 	}
 
+	// Pull the whole package through FetchFile in streaming mode / 流式模式下通过 FetchFile 拉取整个安装包
+	if isStreamTransfer(cmd.Parameters) {
+		return handleStreamTransferPackage(ctx, cmd, req), nil
+	}
+
 	// Process the chunk / 处理数据块
 	mgr := GetPackageTransferManager()
 	resp, err := mgr.ReceiveChunk(ctx, req)
@@ -175,6 +198,53 @@ func HandleTransferPackageCommand(ctx context.Context, cmd *pb.CommandRequest, r
 	}, nil
 }
 
+// handleStreamTransferPackage receives a package pulled through FetchFile and answers once it is complete
+// handleStreamTransferPackage 接收通过 FetchFile 拉取的安装包，完成后才响应
+func handleStreamTransferPackage(ctx context.Context, cmd *pb.CommandRequest, req *TransferPackageRequest) *pb.CommandResponse {
+	mgr := GetPackageTransferManager()
+	mgr.AbortTransfer(req.Version)
+
+	var result *TransferPackageResponse
+	err := fetchStreamedFile(ctx, cmd.Parameters, func(data []byte, offset, totalSize int64, isLast bool) error {
+		chunkReq := &TransferPackageRequest{
+			Version:   req.Version,
+			FileName:  req.FileName,
+			Chunk:     data,
+			Offset:    offset,
+			TotalSize: totalSize,
+			IsLast:    isLast,
+		}
+		if isLast {
+			chunkReq.Checksum = req.Checksum
+		}
+		resp, err := mgr.ReceiveChunk(ctx, chunkReq)
+		if err != nil {
+			return err
+		}
+		if !resp.Success {
+			return errors.New(resp.Message)
+		}
+		result = resp
+		return nil
+	})
+	if err != nil {
+		mgr.AbortTransfer(req.Version)
+		return &pb.CommandResponse{
+			CommandId: cmd.CommandId,
+			Status:    pb.CommandStatus_FAILED,
+			Error:     fmt.Sprintf("Failed to fetch package: %v / 拉取安装包失败: %v", err, err),
+		}
+	}
+
+	respJSON, _ := json.Marshal(result)
+	return &pb.CommandResponse{
+		CommandId: cmd.CommandId,
+		Status:    pb.CommandStatus_SUCCESS,
+		Progress:  100,
+		Output:    string(respJSON),
+	}
+}
+
 // parseTransferPackageRequest parses the transfer package request from command parameters
 // parseTransferPackageRequest 从命令参数解析传输安装包请求
 func parseTransferPackageRequest(params map[string]string) (*TransferPackageRequest, error) {
@@ -285,8 +355,15 @@ func (m *PackageTransferManager) ReceiveChunk(ctx context.Context, req *Transfer
 			}
 		}
 
-		// Move to final location / 移动到最终位置
+		// Move to final location; verified packages go into the cache / 移动到最终位置；已校验的安装包进入缓存
 		finalPath := filepath.Join(m.packageDir, req.FileName)
+		if req.Checksum != "" && isPackageCacheKey(req.Version) {
+			finalPath = m.cachedPackagePath(req.Version, req.Checksum, req.FileName)
+			if err := os.MkdirAll(filepath.Dir(finalPath), 0755); err != nil {
+				m.cleanupTransfer(req.Version)
+				return nil, fmt.Errorf("failed to create cache directory: %w / 创建缓存目录失败: %w", err, err)
+			}
+		}
 		if err := os.Rename(state.tempPath, finalPath); err != nil {
 			// Try copy if rename fails (cross-device) / 如果重命名失败则尝试复制（跨设备）
 			if err := copyFile(state.tempPath, finalPath); err != nil {
@@ -296,6 +373,16 @@ func (m *PackageTransferManager) ReceiveChunk(ctx context.Context, req *Transfer
 			os.Remove(state.tempPath)
 		}
 
+		// Record the verified checksum so later installs can reuse the package
+		// 记录已校验的校验和，供后续安装复用
+		if req.Checksum != "" && isPackageCacheKey(req.Version) {
+			if err := os.WriteFile(finalPath+".sha256", []byte(req.Checksum), 0644); err != nil {
+				os.Remove(finalPath)
+				delete(m.activeTransfers, req.Version)
+				return nil, fmt.Errorf("failed to record checksum: %w / 记录校验和失败: %w", err, err)
+			}
+		}
+
 		// Cleanup state / 清理状态
 		delete(m.activeTransfers, req.Version)
 
@@ -314,6 +401,14 @@ func (m *PackageTransferManager) ReceiveChunk(ctx context.Context, req *Transfer
 	}, nil
 }
 
+// AbortTransfer drops a partial transfer of the version, if any
+// AbortTransfer 丢弃该版本未完成的传输（如有）
+func (m *PackageTransferManager) AbortTransfer(version string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.cleanupTransfer(version)
+}
+
 // cleanupTransfer cleans up a failed transfer
 // cleanupTransfer 清理失败的传输
 func (m *PackageTransferManager) cleanupTransfer(version string) {
@@ -341,6 +436,80 @@ func (m *PackageTransferManager) HasPackage(version string) bool {
 	return err == nil
 }
 
+// cachedPackagePath returns the cache path of a package keyed by version and checksum
+// cachedPackagePath 返回按版本和校验和定位的缓存安装包路径
+func (m *PackageTransferManager) cachedPackagePath(version, checksum, fileName string) string {
+	return filepath.Join(m.packageDir, version, checksum, filepath.Base(fileName))
+}
+
+// isPackageCacheKey reports whether a value is safe to use as a cache path element
+// isPackageCacheKey 判断值是否可以安全地用作缓存路径元素
+func isPackageCacheKey(value string) bool {
+	return value != "" && value != "." && value != ".." && !strings.ContainsAny(value, `/\`)
+}
+
+// LookupCachedPackage returns the cached package matching version, checksum and size.
+// Only packages whose checksum was verified on receipt are returned.
+// LookupCachedPackage 返回与版本、校验和和大小匹配的缓存安装包。
+// 只返回接收时已校验校验和的安装包。
+func (m *PackageTransferManager) LookupCachedPackage(version, checksum, fileName string, size int64) (string, bool) {
+	if !isPackageCacheKey(version) || !isPackageCacheKey(checksum) {
+		return "", false
+	}
+	if fileName == "" {
+		fileName = fmt.Sprintf("apache-seatunnel-%s-bin.tar.gz", version)
+	}
+
+	m.mu.RLock()
+	path := m.cachedPackagePath(version, checksum, fileName)
+	m.mu.RUnlock()
+
+	info, err := os.Stat(path)
+	if err != nil || info.IsDir() {
+		return "", false
+	}
+	if size > 0 && info.Size() != size {
+		return "", false
+	}
+	recorded, err := os.ReadFile(path + ".sha256")
+	if err != nil || strings.TrimSpace(string(recorded)) != checksum {
+		return "", false
+	}
+	return path, true
+}
+
+// handleCheckPackageCache handles the check_package_cache sub-command
+// handleCheckPackageCache 处理 check_package_cache 子命令
+func handleCheckPackageCache(ctx context.Context, params map[string]string) (*PrecheckResult, error) {
+	version := params["version"]
+	checksum := params["checksum"]
+	if version == "" || checksum == "" {
+		return &PrecheckResult{
+			Success: false,
+			Message: "version and checksum parameters are required",
+		}, nil
+	}
+
+	var size int64
+	if sizeStr := params["total_size"]; sizeStr != "" {
+		fmt.Sscanf(sizeStr, "%d", &size)
+	}
+
+	path, ok := GetPackageTransferManager().LookupCachedPackage(version, checksum, params["file_name"], size)
+	if !ok {
+		return &PrecheckResult{
+			Success: true,
+			Message: "Package not cached / 安装包未缓存",
+			Details: map[string]string{"cached": "false"},
+		}, nil
+	}
+	return &PrecheckResult{
+		Success: true,
+		Message: "Package cached / 安装包已缓存",
+		Details: map[string]string{"cached": "true", "path": path},
+	}, nil
+}
+
 // calculateFileChecksum calculates SHA256 checksum of a file
 // calculateFileChecksum 计算文件的 SHA256 校验和
 func calculateFileChecksum(filePath string) (string, error) {
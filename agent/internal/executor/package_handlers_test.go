@@ -0,0 +1,115 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package executor
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	pb "github.com/seatunnel/seatunnelX/agent"
+	"github.com/seatunnel/seatunnelX/internal/pkg/filestream"
+)
+
+func TestPackageTransferManager_CachesVerifiedPackage(t *testing.T) {
+	content := []byte("seatunnel package")
+	sum := sha256.Sum256(content)
+	checksum := hex.EncodeToString(sum[:])
+
+	mgr := &PackageTransferManager{
+		tempDir:         t.TempDir(),
+		packageDir:      t.TempDir(),
+		activeTransfers: make(map[string]*packageTransferState),
+	}
+	if _, ok := mgr.LookupCachedPackage("2.3.12", checksum, "", int64(len(content))); ok {
+		t.Fatalf("expected empty cache")
+	}
+
+	resp, err := mgr.ReceiveChunk(context.Background(), &TransferPackageRequest{
+		Version:   "2.3.12",
+		FileName:  "apache-seatunnel-2.3.12-bin.tar.gz",
+		Chunk:     content,
+		TotalSize: int64(len(content)),
+		IsLast:    true,
+		Checksum:  checksum,
+	})
+	if err != nil || !resp.Success {
+		t.Fatalf("ReceiveChunk failed: resp=%+v err=%v", resp, err)
+	}
+	if want := filepath.Join(mgr.packageDir, "2.3.12", checksum, "apache-seatunnel-2.3.12-bin.tar.gz"); resp.LocalPath != want {
+		t.Fatalf("expected package in cache at %s, got %s", want, resp.LocalPath)
+	}
+
+	path, ok := mgr.LookupCachedPackage("2.3.12", checksum, "", int64(len(content)))
+	if !ok || path != resp.LocalPath {
+		t.Fatalf("expected cached package %s, got %s ok=%t", resp.LocalPath, path, ok)
+	}
+	if _, ok := mgr.LookupCachedPackage("2.3.12", checksum, "", 1); ok {
+		t.Fatalf("expected size mismatch to miss the cache")
+	}
+	if _, ok := mgr.LookupCachedPackage("2.3.12", "..", "", 0); ok {
+		t.Fatalf("expected unsafe checksum to miss the cache")
+	}
+}
+
+func TestHandleTransferPackageCommand_StreamMode(t *testing.T) {
+	content := []byte("streamed seatunnel package")
+	sum := sha256.Sum256(content)
+	checksum := hex.EncodeToString(sum[:])
+
+	GetPackageTransferManager().SetDirectories(t.TempDir(), t.TempDir())
+	var fetchedID string
+	SetFileFetcher(func(ctx context.Context, transferID string, offset int64, fn func(data []byte, offset, totalSize int64, isLast bool) error) error {
+		fetchedID = transferID
+		total := int64(len(content))
+		if err := fn(content[:10], 0, total, false); err != nil {
+			return err
+		}
+		return fn(content[10:], 10, total, true)
+	})
+	defer SetFileFetcher(nil)
+
+	resp, err := HandleTransferPackageCommand(context.Background(), &pb.CommandRequest{
+		CommandId: "cmd-1",
+		Parameters: map[string]string{
+			"version":                    "2.3.12-stream",
+			"checksum":                   checksum,
+			filestream.ParamTransferMode: filestream.ModeStream,
+			filestream.ParamTransferID:   "transfer-1",
+		},
+	}, nil)
+	if err != nil || resp.Status != pb.CommandStatus_SUCCESS {
+		t.Fatalf("expected SUCCESS, got resp=%+v err=%v", resp, err)
+	}
+	if fetchedID != "transfer-1" {
+		t.Fatalf("expected transfer-1 to be fetched, got %q", fetchedID)
+	}
+
+	var result TransferPackageResponse
+	if err := json.Unmarshal([]byte(resp.Output), &result); err != nil {
+		t.Fatalf("failed to parse output: %v", err)
+	}
+	data, err := os.ReadFile(result.LocalPath)
+	if err != nil || string(data) != string(content) {
+		t.Fatalf("expected streamed package at %s, got %q err=%v", result.LocalPath, data, err)
+	}
+}
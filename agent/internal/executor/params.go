@@ -0,0 +1,79 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package executor
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/seatunnel/seatunnelX/agent/internal/installer"
+)
+
+// ParamList splits a comma-separated command parameter, trimming entries and dropping empty ones.
+// ParamList 拆分逗号分隔的命令参数，去除首尾空白并丢弃空项。
+func ParamList(params map[string]string, key string) []string {
+	return SplitList(params[key])
+}
+
+// SplitList splits a comma-separated value, trimming entries and dropping empty ones.
+// SplitList 拆分逗号分隔的值，去除首尾空白并丢弃空项。
+func SplitList(raw string) []string {
+	if strings.TrimSpace(raw) == "" {
+		return nil
+	}
+	parts := strings.Split(raw, ",")
+	result := make([]string, 0, len(parts))
+	for _, part := range parts {
+		if trimmed := strings.TrimSpace(part); trimmed != "" {
+			result = append(result, trimmed)
+		}
+	}
+	return result
+}
+
+// ParamIntList parses a comma-separated integer parameter whose entries may be ranges such as
+// "9000-9005". Ranges are inclusive, expanded in order and capped at installer.MaxPortScanRange values.
+// ParamIntList 解析逗号分隔的整数参数，其中的项可以是 "9000-9005" 这样的范围。
+// 范围包含两端，按顺序展开，且最多展开 installer.MaxPortScanRange 个值。
+func ParamIntList(params map[string]string, key string) ([]int, error) {
+	entries := ParamList(params, key)
+	result := make([]int, 0, len(entries))
+	for _, entry := range entries {
+		low, high, isRange := strings.Cut(entry, "-")
+		start, err := strconv.Atoi(strings.TrimSpace(low))
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s entry %q", key, entry)
+		}
+		if !isRange {
+			result = append(result, start)
+			continue
+		}
+		end, err := strconv.Atoi(strings.TrimSpace(high))
+		if err != nil || end < start {
+			return nil, fmt.Errorf("invalid %s range %q", key, entry)
+		}
+		if end-start+1 > installer.MaxPortScanRange {
+			return nil, fmt.Errorf("%s range %q exceeds %d values", key, entry, installer.MaxPortScanRange)
+		}
+		for value := start; value <= end; value++ {
+			result = append(result, value)
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,50 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package executor
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParamList(t *testing.T) {
+	params := map[string]string{"master_addresses": " 10.0.0.1, ,10.0.0.2 ,"}
+	if got := ParamList(params, "master_addresses"); !reflect.DeepEqual(got, []string{"10.0.0.1", "10.0.0.2"}) {
+		t.Fatalf("unexpected list %v", got)
+	}
+	if got := ParamList(params, "worker_addresses"); got != nil {
+		t.Fatalf("expected nil for a missing parameter, got %v", got)
+	}
+}
+
+func TestParamIntList(t *testing.T) {
+	got, err := ParamIntList(map[string]string{"required_ports": "5801, 9000-9003,8080"}, "required_ports")
+	if err != nil {
+		t.Fatalf("ParamIntList returned error: %v", err)
+	}
+	if want := []int{5801, 9000, 9001, 9002, 9003, 8080}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+
+	for _, raw := range []string{"58o1", "9005-9000", "9000-", "1-100000"} {
+		if _, err := ParamIntList(map[string]string{"required_ports": raw}, "required_ports"); err == nil || !strings.Contains(err.Error(), "required_ports") {
+			t.Fatalf("expected %q to be rejected, got %v", raw, err)
+		}
+	}
+}
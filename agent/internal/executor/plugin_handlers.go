@@ -25,7 +25,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strconv"
-	"strings"
+	"time"
 
 	pb "github.com/seatunnel/seatunnelX/agent"
 	"github.com/seatunnel/seatunnelX/agent/internal/plugin"
@@ -65,6 +65,23 @@ func GetPluginManager() *plugin.Manager {
 	return pluginManager
 }
 
+// InitPluginCache attaches a content-addressed plugin cache to the plugin manager and runs an initial GC.
+// InitPluginCache 为插件管理器挂载按内容寻址的插件缓存并执行一次初始 GC。
+func InitPluginCache(dir string, maxBytes int64, maxAge time.Duration) (*plugin.Cache, error) {
+	if pluginManager == nil {
+		return nil, fmt.Errorf("plugin manager not initialized / 插件管理器未初始化")
+	}
+	cache, err := plugin.NewCache(dir, maxBytes, maxAge)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := cache.GC(); err != nil {
+		return nil, err
+	}
+	pluginManager.SetCache(cache)
+	return cache, nil
+}
+
 // RegisterPluginHandlers registers all plugin-related command handlers.
 // RegisterPluginHandlers 注册所有插件相关的命令处理器。
 func RegisterPluginHandlers(executor *CommandExecutor) {
@@ -109,6 +126,17 @@ func HandleTransferPluginCommand(ctx context.Context, cmd *pb.CommandRequest, re
 		return CreateErrorResponse(cmd.CommandId, "missing required parameters: plugin_name, version, file_name"), nil
 	}
 
+	// Copy the file from the plugin cache when the Control Plane found it there
+	// Control Plane 确认插件缓存已有该文件时直接从缓存复制
+	if cmd.Parameters["from_cache"] == "true" {
+		return handleCachedTransferPlugin(cmd, fileType, targetDir, fileName, checksum), nil
+	}
+
+	// Pull the whole file through FetchFile in streaming mode / 流式模式下通过 FetchFile 拉取整个文件
+	if isStreamTransfer(cmd.Parameters) {
+		return handleStreamTransferPlugin(ctx, cmd, pluginName, version, fileType, targetDir, fileName, checksum), nil
+	}
+
 	// Parse numeric parameters / 解析数字参数
 	offset, _ := strconv.ParseInt(offsetStr, 10, 64)
 	totalSize, _ := strconv.ParseInt(totalSizeStr, 10, 64)
@@ -156,6 +184,92 @@ func HandleTransferPluginCommand(ctx context.Context, cmd *pb.CommandRequest, re
 	return CreateProgressResponse(cmd.CommandId, progress, fmt.Sprintf("Received %d/%d bytes", receivedBytes, totalSize)), nil
 }
 
+// handleStreamTransferPlugin receives a plugin file pulled through FetchFile and finalizes it.
+// handleStreamTransferPlugin 接收通过 FetchFile 拉取的插件文件并完成传输。
+func handleStreamTransferPlugin(ctx context.Context, cmd *pb.CommandRequest, pluginName, version, fileType, targetDir, fileName, checksum string) *pb.CommandResponse {
+	err := fetchStreamedFile(ctx, cmd.Parameters, func(data []byte, offset, totalSize int64, isLast bool) error {
+		chunkChecksum := ""
+		if isLast {
+			chunkChecksum = checksum
+		}
+		_, err := pluginManager.ReceivePluginChunk(pluginName, version, fileType, targetDir, fileName, data, offset, totalSize, isLast, chunkChecksum)
+		return err
+	})
+	if err != nil {
+		return CreateErrorResponse(cmd.CommandId, fmt.Sprintf("failed to fetch file: %v", err))
+	}
+
+	targetPath, err := pluginManager.FinalizeTransfer(pluginName, version, targetDir, fileName)
+	if err != nil {
+		return CreateErrorResponse(cmd.CommandId, fmt.Sprintf("failed to finalize transfer: %v", err))
+	}
+
+	result := &PluginResult{
+		Success:       true,
+		Message:       fmt.Sprintf("File transfer completed: %s / 文件传输完成: %s", fileName, fileName),
+		ConnectorPath: targetPath,
+	}
+	output, _ := json.Marshal(result)
+	return CreateSuccessResponse(cmd.CommandId, string(output))
+}
+
+// handleCachedTransferPlugin installs a plugin file from the plugin cache instead of receiving it.
+// handleCachedTransferPlugin 从插件缓存安装插件文件，而不是接收传输。
+func handleCachedTransferPlugin(cmd *pb.CommandRequest, fileType, targetDir, fileName, checksum string) *pb.CommandResponse {
+	totalSize, _ := strconv.ParseInt(cmd.Parameters["total_size"], 10, 64)
+	targetPath, err := pluginManager.InstallFromCache(fileType, targetDir, fileName, checksum, totalSize)
+	if err != nil {
+		return CreateErrorResponse(cmd.CommandId, fmt.Sprintf("failed to install from plugin cache: %v", err))
+	}
+
+	result := &PluginResult{
+		Success:       true,
+		Message:       fmt.Sprintf("File installed from plugin cache: %s / 已从插件缓存安装文件: %s", fileName, fileName),
+		ConnectorPath: targetPath,
+	}
+	output, _ := json.Marshal(result)
+	return CreateSuccessResponse(cmd.CommandId, string(output))
+}
+
+// handleHasPlugin handles the has_plugin sub-command
+// handleHasPlugin 处理 has_plugin 子命令
+func handleHasPlugin(ctx context.Context, params map[string]string) (*PrecheckResult, error) {
+	checksum := params["checksum"]
+	if checksum == "" {
+		return &PrecheckResult{
+			Success: false,
+			Message: "checksum parameter is required",
+		}, nil
+	}
+
+	var cache *plugin.Cache
+	if pluginManager != nil {
+		cache = pluginManager.GetCache()
+	}
+	if cache == nil {
+		return &PrecheckResult{
+			Success: true,
+			Message: "Plugin cache disabled / 插件缓存未启用",
+			Details: map[string]string{"cached": "false"},
+		}, nil
+	}
+
+	size, _ := strconv.ParseInt(params["total_size"], 10, 64)
+	path, ok := cache.Lookup(checksum, size)
+	if !ok {
+		return &PrecheckResult{
+			Success: true,
+			Message: "Plugin not cached / 插件未缓存",
+			Details: map[string]string{"cached": "false"},
+		}, nil
+	}
+	return &PrecheckResult{
+		Success: true,
+		Message: "Plugin cached / 插件已缓存",
+		Details: map[string]string{"cached": "true", "path": path},
+	}, nil
+}
+
 // HandleInstallPluginCommand handles the INSTALL_PLUGIN command type.
 // HandleInstallPluginCommand 处理 INSTALL_PLUGIN 命令类型。
 // This command installs a plugin from temp directory to SeaTunnel directories.
@@ -170,7 +284,6 @@ func HandleInstallPluginCommand(ctx context.Context, cmd *pb.CommandRequest, rep
 	artifactID := cmd.Parameters["artifact_id"] // Maven artifact ID (e.g., connector-cdc-mysql)
 	version := cmd.Parameters["version"]
 	installPath := cmd.Parameters["install_path"]
-	dependenciesStr := cmd.Parameters["dependencies"]
 
 	if pluginName == "" || version == "" {
 		return CreateErrorResponse(cmd.CommandId, "missing required parameters: plugin_name, version"), nil
@@ -183,10 +296,7 @@ func HandleInstallPluginCommand(ctx context.Context, cmd *pb.CommandRequest, rep
 	}
 
 	// Parse dependencies / 解析依赖
-	var dependencies []string
-	if dependenciesStr != "" {
-		dependencies = strings.Split(dependenciesStr, ",")
-	}
+	dependencies := ParamList(cmd.Parameters, "dependencies")
 
 	if reporter != nil {
 		reporter.Report(10, fmt.Sprintf("Installing plugin %s v%s / 正在安装插件 %s v%s", pluginName, version, pluginName, version))
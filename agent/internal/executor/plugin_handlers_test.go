@@ -0,0 +1,91 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package executor
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	pb "github.com/seatunnel/seatunnelX/agent"
+)
+
+func TestHasPluginAndCachedTransfer(t *testing.T) {
+	previous := pluginManager
+	InitPluginManager(t.TempDir())
+	defer func() { pluginManager = previous }()
+
+	content := []byte("connector jar content")
+	sum := sha256.Sum256(content)
+	checksum := hex.EncodeToString(sum[:])
+	params := map[string]string{
+		"checksum":   checksum,
+		"total_size": strconv.Itoa(len(content)),
+	}
+
+	result, err := handleHasPlugin(context.Background(), params)
+	if err != nil || !result.Success || result.Details["cached"] != "false" {
+		t.Fatalf("expected cache-disabled miss, got %+v err=%v", result, err)
+	}
+
+	cache, err := InitPluginCache(t.TempDir(), 0, 0)
+	if err != nil {
+		t.Fatalf("InitPluginCache failed: %v", err)
+	}
+	src := filepath.Join(t.TempDir(), "connector-jdbc-1.0.0.jar")
+	if err := os.WriteFile(src, content, 0644); err != nil {
+		t.Fatalf("write source: %v", err)
+	}
+	if err := cache.Put(src, checksum); err != nil {
+		t.Fatalf("cache put: %v", err)
+	}
+
+	result, err = handleHasPlugin(context.Background(), params)
+	if err != nil || !result.Success || result.Details["cached"] != "true" {
+		t.Fatalf("expected cache hit, got %+v err=%v", result, err)
+	}
+
+	installPath := t.TempDir()
+	resp, err := HandleTransferPluginCommand(context.Background(), &pb.CommandRequest{
+		CommandId: "cmd-1",
+		Parameters: map[string]string{
+			"plugin_name":  "jdbc",
+			"version":      "1.0.0",
+			"file_type":    "connector",
+			"file_name":    "connector-jdbc-1.0.0.jar",
+			"checksum":     checksum,
+			"total_size":   strconv.Itoa(len(content)),
+			"install_path": installPath,
+			"from_cache":   "true",
+		},
+	}, nil)
+	if err != nil || resp.Status != pb.CommandStatus_SUCCESS {
+		t.Fatalf("expected SUCCESS, got resp=%+v err=%v", resp, err)
+	}
+	data, err := os.ReadFile(filepath.Join(installPath, "connectors", "connector-jdbc-1.0.0.jar"))
+	if err != nil || string(data) != string(content) {
+		t.Fatalf("expected connector installed from cache, got %q err=%v", data, err)
+	}
+	if stats := cache.Stats(); stats.Hits != 2 || stats.Entries != 1 {
+		t.Fatalf("unexpected cache stats: %+v", stats)
+	}
+}
@@ -20,6 +20,7 @@ package executor
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
@@ -38,6 +39,10 @@ const (
 	// PrecheckSubCommandCheckPort 检查端口是否可用或正在监听
 	PrecheckSubCommandCheckPort PrecheckSubCommand = "check_port"
 
+	// PrecheckSubCommandSuggestPorts scans a port range for free alternatives to conflicting ports
+	// PrecheckSubCommandSuggestPorts 扫描端口范围，为冲突端口寻找可用的替代端口
+	PrecheckSubCommandSuggestPorts PrecheckSubCommand = "suggest_ports"
+
 	// PrecheckSubCommandCheckDirectory checks if a directory exists and is writable
 	// PrecheckSubCommandCheckDirectory 检查目录是否存在且可写
 	PrecheckSubCommandCheckDirectory PrecheckSubCommand = "check_directory"
@@ -54,10 +59,22 @@ const (
 	// PrecheckSubCommandCheckJava 检查 Java 是否已安装及其版本
 	PrecheckSubCommandCheckJava PrecheckSubCommand = "check_java"
 
+	// PrecheckSubCommandCheckDiskSpace checks free space for package download and extraction.
+	// PrecheckSubCommandCheckDiskSpace 检查安装包下载与解压所需的可用空间。
+	PrecheckSubCommandCheckDiskSpace PrecheckSubCommand = "check_disk_space"
+
+	// PrecheckSubCommandCheckSystem runs one system limit or kernel parameter check.
+	// PrecheckSubCommandCheckSystem 执行一项系统限制或内核参数检查。
+	PrecheckSubCommandCheckSystem PrecheckSubCommand = "check_system"
+
 	// PrecheckSubCommandCheckTCP checks whether a remote TCP endpoint is reachable.
 	// PrecheckSubCommandCheckTCP 检查远程 TCP 端点是否可达。
 	PrecheckSubCommandCheckTCP PrecheckSubCommand = "check_tcp"
 
+	// PrecheckSubCommandCheckConnectivity probes cluster member endpoints and reports the local clock.
+	// PrecheckSubCommandCheckConnectivity 探测集群成员端点并上报本机时钟。
+	PrecheckSubCommandCheckConnectivity PrecheckSubCommand = "check_connectivity"
+
 	// PrecheckSubCommandCheckPathReady checks whether a local path exists or can be created.
 	// PrecheckSubCommandCheckPathReady 检查本地路径是否已存在或可创建。
 	PrecheckSubCommandCheckPathReady PrecheckSubCommand = "check_path_ready"
@@ -70,6 +87,10 @@ const (
 	// PrecheckSubCommandCleanupPath 清理本地路径下的内容。
 	PrecheckSubCommandCleanupPath PrecheckSubCommand = "cleanup_path"
 
+	// PrecheckSubCommandValidateCheckpointStorage writes, reads and deletes a test object on checkpoint storage.
+	// PrecheckSubCommandValidateCheckpointStorage 在 checkpoint 存储上写入、读取并删除测试对象。
+	PrecheckSubCommandValidateCheckpointStorage PrecheckSubCommand = "validate_checkpoint_storage"
+
 	// PrecheckSubCommandSeatunnelXJavaProxyProbe performs a real runtime read/write probe.
 	// PrecheckSubCommandSeatunnelXJavaProxyProbe 执行真实运行时读写探测。
 	PrecheckSubCommandSeatunnelXJavaProxyProbe PrecheckSubCommand = "seatunnelx_java_proxy_probe"
@@ -108,6 +129,14 @@ const (
 	// PrecheckSubCommandSyncJobLogs reads one job log file through the agent.
 	PrecheckSubCommandSyncJobLogs PrecheckSubCommand = "sync_job_logs"
 
+	// PrecheckSubCommandCheckPackageCache checks whether a verified package is already cached.
+	// PrecheckSubCommandCheckPackageCache 检查已校验的安装包是否已缓存。
+	PrecheckSubCommandCheckPackageCache PrecheckSubCommand = "check_package_cache"
+
+	// PrecheckSubCommandHasPlugin checks whether a plugin file is already in the plugin cache.
+	// PrecheckSubCommandHasPlugin 检查插件文件是否已在插件缓存中。
+	PrecheckSubCommandHasPlugin PrecheckSubCommand = "has_plugin"
+
 	// PrecheckSubCommandFull runs all precheck items
 	// PrecheckSubCommandFull 运行所有预检查项
 	PrecheckSubCommandFull PrecheckSubCommand = "full"
@@ -141,6 +170,8 @@ func HandlePrecheckCommand(ctx context.Context, cmd *pb.CommandRequest, reporter
 	switch subCommand {
 	case PrecheckSubCommandCheckPort:
 		result, err = handleCheckPort(ctx, cmd.Parameters)
+	case PrecheckSubCommandSuggestPorts:
+		result, err = handleSuggestPorts(ctx, cmd.Parameters)
 	case PrecheckSubCommandCheckDirectory:
 		result, err = handleCheckDirectory(ctx, cmd.Parameters)
 	case PrecheckSubCommandCheckHTTP:
@@ -149,14 +180,22 @@ func HandlePrecheckCommand(ctx context.Context, cmd *pb.CommandRequest, reporter
 		result, err = handleCheckProcess(ctx, cmd.Parameters)
 	case PrecheckSubCommandCheckJava:
 		result, err = handleCheckJava(ctx, cmd.Parameters)
+	case PrecheckSubCommandCheckDiskSpace:
+		result, err = handleCheckDiskSpace(ctx, cmd.Parameters)
+	case PrecheckSubCommandCheckSystem:
+		result, err = handleCheckSystem(ctx, cmd.Parameters)
 	case PrecheckSubCommandCheckTCP:
 		result, err = handleCheckTCP(ctx, cmd.Parameters)
+	case PrecheckSubCommandCheckConnectivity:
+		result, err = handleCheckConnectivity(ctx, cmd.Parameters)
 	case PrecheckSubCommandCheckPathReady:
 		result, err = handleCheckPathReady(ctx, cmd.Parameters)
 	case PrecheckSubCommandStatPath:
 		result, err = handleStatPath(ctx, cmd.Parameters)
 	case PrecheckSubCommandCleanupPath:
 		result, err = handleCleanupPath(ctx, cmd.Parameters)
+	case PrecheckSubCommandValidateCheckpointStorage:
+		result, err = handleValidateCheckpointStorage(ctx, cmd.Parameters)
 	case PrecheckSubCommandSeatunnelXJavaProxyProbe:
 		result, err = handleSeatunnelXJavaProxyProbe(ctx, cmd.Parameters)
 	case PrecheckSubCommandSeatunnelXJavaProxyStat:
@@ -181,6 +220,10 @@ func HandlePrecheckCommand(ctx context.Context, cmd *pb.CommandRequest, reporter
 		result, err = handleSyncLocalLogs(ctx, cmd.Parameters)
 	case PrecheckSubCommandSyncJobLogs:
 		result, err = handleSyncJobLogs(ctx, cmd.Parameters)
+	case PrecheckSubCommandCheckPackageCache:
+		result, err = handleCheckPackageCache(ctx, cmd.Parameters)
+	case PrecheckSubCommandHasPlugin:
+		result, err = handleHasPlugin(ctx, cmd.Parameters)
 	case PrecheckSubCommandFull:
 		result, err = handleFullPrecheck(ctx, cmd.Parameters, reporter)
 	default:
@@ -232,6 +275,60 @@ func handleCheckPort(ctx context.Context, params map[string]string) (*PrecheckRe
 	}, nil
 }
 
+// handleSuggestPorts handles the suggest_ports sub-command. It succeeds only when the
+// range holds as many free ports as requested; the ports found are returned either way.
+// handleSuggestPorts 处理 suggest_ports 子命令。仅当范围内可用端口数量满足请求时才成功，
+// 无论成功与否都会返回已找到的端口。
+func handleSuggestPorts(ctx context.Context, params map[string]string) (*PrecheckResult, error) {
+	start, startErr := strconv.Atoi(params["range_start"])
+	end, endErr := strconv.Atoi(params["range_end"])
+	if startErr != nil || endErr != nil || start < 1 || end > 65535 || start > end {
+		return &PrecheckResult{
+			Success: false,
+			Message: fmt.Sprintf("invalid port range: %s-%s", params["range_start"], params["range_end"]),
+		}, nil
+	}
+	if end-start+1 > installer.MaxPortScanRange {
+		return &PrecheckResult{
+			Success: false,
+			Message: fmt.Sprintf("port range %d-%d exceeds %d ports", start, end, installer.MaxPortScanRange),
+		}, nil
+	}
+	count := 1
+	if countStr := params["count"]; countStr != "" {
+		parsed, err := strconv.Atoi(countStr)
+		if err != nil || parsed < 1 {
+			return &PrecheckResult{
+				Success: false,
+				Message: fmt.Sprintf("invalid count: %s", countStr),
+			}, nil
+		}
+		count = parsed
+	}
+	excludedPorts, err := ParamIntList(params, "exclude")
+	if err != nil {
+		return &PrecheckResult{Success: false, Message: err.Error()}, nil
+	}
+	exclude := make(map[int]bool, len(excludedPorts))
+	for _, port := range excludedPorts {
+		exclude[port] = true
+	}
+
+	ports := installer.SuggestFreePorts(start, end, count, exclude)
+	formatted := make([]string, len(ports))
+	for i, port := range ports {
+		formatted[i] = strconv.Itoa(port)
+	}
+	result := &PrecheckResult{
+		Success: len(ports) == count,
+		Message: fmt.Sprintf("Found %d of %d free ports in %d-%d", len(ports), count, start, end),
+		Details: map[string]string{
+			"ports": strings.Join(formatted, ","),
+		},
+	}
+	return result, nil
+}
+
 // handleCheckDirectory handles the check_directory sub-command
 // handleCheckDirectory 处理 check_directory 子命令
 func handleCheckDirectory(ctx context.Context, params map[string]string) (*PrecheckResult, error) {
@@ -324,6 +421,112 @@ func handleCheckJava(ctx context.Context, params map[string]string) (*PrecheckRe
 	}, nil
 }
 
+// handleCheckDiskSpace handles the check_disk_space sub-command.
+// handleCheckDiskSpace 处理 check_disk_space 子命令。
+func handleCheckDiskSpace(ctx context.Context, params map[string]string) (*PrecheckResult, error) {
+	installDir := params["install_dir"]
+	if installDir == "" {
+		return &PrecheckResult{
+			Success: false,
+			Message: "install_dir parameter is required",
+		}, nil
+	}
+
+	packageSize, err := strconv.ParseInt(params["package_size"], 10, 64)
+	if err != nil || packageSize <= 0 {
+		return &PrecheckResult{
+			Success: false,
+			Message: fmt.Sprintf("invalid package_size: %s", params["package_size"]),
+		}, nil
+	}
+
+	expansionFactor := installer.DefaultPackageExpansionFactor
+	if raw := params["expansion_factor"]; raw != "" {
+		expansionFactor, err = strconv.ParseFloat(raw, 64)
+		if err != nil || expansionFactor < 1 {
+			return &PrecheckResult{
+				Success: false,
+				Message: fmt.Sprintf("invalid expansion_factor: %s", raw),
+			}, nil
+		}
+	}
+
+	checkResult, err := installer.CheckPackageDiskSpace(installDir, params["temp_dir"], packageSize, expansionFactor)
+	if err != nil {
+		return &PrecheckResult{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	install, temp := checkResult.Targets[0], checkResult.Targets[1]
+	return &PrecheckResult{
+		Success: checkResult.Success,
+		Message: checkResult.Message,
+		Details: map[string]string{
+			"package_size":            strconv.FormatInt(packageSize, 10),
+			"expansion_factor":        strconv.FormatFloat(expansionFactor, 'f', -1, 64),
+			"install_dir":             install.Path,
+			"install_required_bytes":  strconv.FormatInt(install.RequiredBytes, 10),
+			"install_available_bytes": strconv.FormatInt(install.AvailableBytes, 10),
+			"temp_dir":                temp.Path,
+			"temp_required_bytes":     strconv.FormatInt(temp.RequiredBytes, 10),
+			"temp_available_bytes":    strconv.FormatInt(temp.AvailableBytes, 10),
+			"shared_filesystem":       strconv.FormatBool(install.SharedWith != ""),
+		},
+	}, nil
+}
+
+// handleCheckSystem handles the check_system sub-command. The item status and suggested fix
+// are returned in details since warnings still count as success.
+// handleCheckSystem 处理 check_system 子命令。由于警告仍视为成功，检查状态与修复建议通过 details 返回。
+func handleCheckSystem(ctx context.Context, params map[string]string) (*PrecheckResult, error) {
+	checkParams := installer.DefaultPrecheckParams()
+	if portStr := params["cluster_port"]; portStr != "" {
+		port, err := strconv.Atoi(portStr)
+		if err != nil {
+			return &PrecheckResult{
+				Success: false,
+				Message: fmt.Sprintf("invalid cluster_port: %s", portStr),
+			}, nil
+		}
+		checkParams.ClusterPort = port
+	}
+	if refStr := params["reference_time_ms"]; refStr != "" {
+		referenceTimeMs, err := strconv.ParseInt(refStr, 10, 64)
+		if err != nil {
+			return &PrecheckResult{
+				Success: false,
+				Message: fmt.Sprintf("invalid reference_time_ms: %s", refStr),
+			}, nil
+		}
+		checkParams.ReferenceTimeMs = referenceTimeMs
+	}
+
+	item, err := installer.NewPrechecker(checkParams).RunSystemCheck(ctx, installer.CheckName(params["check"]))
+	if err != nil {
+		return &PrecheckResult{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	details := make(map[string]string, len(item.Details)+2)
+	for k, v := range item.Details {
+		details[k] = fmt.Sprintf("%v", v)
+	}
+	details["status"] = string(item.Status)
+	if item.Suggestion != "" {
+		details["suggestion"] = item.Suggestion
+	}
+
+	return &PrecheckResult{
+		Success: item.Status != installer.CheckStatusFailed,
+		Message: item.Message,
+		Details: details,
+	}, nil
+}
+
 // handleCheckTCP handles the check_tcp sub-command.
 // handleCheckTCP 处理 check_tcp 子命令。
 func handleCheckTCP(ctx context.Context, params map[string]string) (*PrecheckResult, error) {
@@ -356,6 +559,43 @@ func handleCheckTCP(ctx context.Context, params map[string]string) (*PrecheckRes
 	}, nil
 }
 
+// handleCheckConnectivity handles the check_connectivity sub-command. The local clock is read when
+// the command arrives and the handling time is reported, so the Control Plane can estimate the
+// clock skew from its own send and receive times.
+// handleCheckConnectivity 处理 check_connectivity 子命令。命令到达时读取本机时钟并上报处理耗时，
+// 使 Control Plane 能根据自身的发送与接收时间估算时钟偏差。
+func handleCheckConnectivity(ctx context.Context, params map[string]string) (*PrecheckResult, error) {
+	start := time.Now()
+	targets := ParamList(params, "targets")
+	timeout := 3 * time.Second
+	if timeoutStr := params["timeout_seconds"]; timeoutStr != "" {
+		if sec, convErr := strconv.Atoi(timeoutStr); convErr == nil && sec > 0 {
+			timeout = time.Duration(sec) * time.Second
+		}
+	}
+
+	probes := installer.ProbeTCPTargets(ctx, targets, timeout)
+	encoded, err := json.Marshal(probes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize probe results: %w", err)
+	}
+	reachable := 0
+	for _, probe := range probes {
+		if probe.Reachable {
+			reachable++
+		}
+	}
+	return &PrecheckResult{
+		Success: reachable == len(probes),
+		Message: fmt.Sprintf("%d/%d member endpoints reachable", reachable, len(probes)),
+		Details: map[string]string{
+			"agent_time_ms": strconv.FormatInt(start.UnixMilli(), 10),
+			"elapsed_ms":    strconv.FormatInt(time.Since(start).Milliseconds(), 10),
+			"probes":        string(encoded),
+		},
+	}, nil
+}
+
 // handleCheckPathReady handles the check_path_ready sub-command.
 // handleCheckPathReady 处理 check_path_ready 子命令。
 func handleCheckPathReady(ctx context.Context, params map[string]string) (*PrecheckResult, error) {
@@ -408,6 +648,46 @@ func handleCleanupPath(ctx context.Context, params map[string]string) (*Precheck
 	}, nil
 }
 
+// handleValidateCheckpointStorage handles the validate_checkpoint_storage sub-command. Without a
+// Hadoop client, HDFS falls back to the seatunnelx-java-proxy probe when install_dir is given.
+// handleValidateCheckpointStorage 处理 validate_checkpoint_storage 子命令。没有 Hadoop 客户端时，
+// 若提供了 install_dir，HDFS 回退为 seatunnelx-java-proxy 探测。
+func handleValidateCheckpointStorage(ctx context.Context, params map[string]string) (*PrecheckResult, error) {
+	cfg, err := checkpointConfigFromParams(params)
+	if err != nil {
+		return &PrecheckResult{Success: false, Message: err.Error()}, nil
+	}
+	result, err := installer.ValidateCheckpointStorage(ctx, cfg)
+	if errors.Is(err, installer.ErrHDFSClientNotFound) && params["install_dir"] != "" {
+		probeParams := make(map[string]string, len(params)+1)
+		for key, value := range params {
+			probeParams[key] = value
+		}
+		probeParams["kind"] = "checkpoint"
+		return handleSeatunnelXJavaProxyProbe(ctx, probeParams)
+	}
+	if err != nil {
+		return &PrecheckResult{Success: false, Message: err.Error()}, nil
+	}
+	details := map[string]string{
+		"storage_type": string(result.StorageType),
+		"backend":      result.Backend,
+		"location":     result.Location,
+	}
+	for _, step := range result.Steps {
+		details[string(step.Step)] = strconv.FormatBool(step.Success)
+		details[string(step.Step)+"_duration_ms"] = strconv.FormatInt(step.DurationMs, 10)
+		if step.Message != "" {
+			details[string(step.Step)+"_error"] = step.Message
+		}
+	}
+	return &PrecheckResult{
+		Success: result.Success,
+		Message: result.Message,
+		Details: details,
+	}, nil
+}
+
 func handleSeatunnelXJavaProxyProbe(ctx context.Context, params map[string]string) (*PrecheckResult, error) {
 	kind := params["kind"]
 	installDir := params["install_dir"]
@@ -20,9 +20,11 @@ package executor
 import (
 	"context"
 	"encoding/json"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -262,3 +264,79 @@ func TestHandleSyncLocalStatusDetectsFinishedRuntimeWithoutProcess(t *testing.T)
 		t.Fatalf("expected success status, got %#v", payload["status"])
 	}
 }
+
+func TestHandleCheckConnectivityClassifiesProbes(t *testing.T) {
+	listening, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	defer listening.Close()
+	closed, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	closedAddr := closed.Addr().String()
+	_ = closed.Close()
+
+	before := time.Now().UnixMilli()
+	result, err := handleCheckConnectivity(context.Background(), map[string]string{
+		"targets":         listening.Addr().String() + ", " + closedAddr,
+		"timeout_seconds": "1",
+	})
+	if err != nil {
+		t.Fatalf("handleCheckConnectivity returned error: %v", err)
+	}
+	if !result.Success {
+		t.Fatalf("refused connections still prove reachability, got %#v", result)
+	}
+	if agentTime, _ := strconv.ParseInt(result.Details["agent_time_ms"], 10, 64); agentTime < before {
+		t.Fatalf("unexpected agent_time_ms %q", result.Details["agent_time_ms"])
+	}
+
+	var probes []installer.TCPProbeResult
+	if err := json.Unmarshal([]byte(result.Details["probes"]), &probes); err != nil {
+		t.Fatalf("decode probes: %v", err)
+	}
+	if len(probes) != 2 || !probes[0].Listening || probes[1].Listening || !probes[1].Reachable || probes[1].Error == "" {
+		t.Fatalf("unexpected probes %#v", probes)
+	}
+}
+
+func TestHandleSuggestPortsSkipsBusyAndExcludedPorts(t *testing.T) {
+	busy, err := net.Listen("tcp", ":0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	defer busy.Close()
+	port := busy.Addr().(*net.TCPAddr).Port
+	if port+20 > 65535 {
+		t.Skip("ephemeral port too close to the end of the range")
+	}
+
+	result, err := handleSuggestPorts(context.Background(), map[string]string{
+		"range_start": strconv.Itoa(port),
+		"range_end":   strconv.Itoa(port + 20),
+		"count":       "2",
+		"exclude":     strconv.Itoa(port + 1),
+	})
+	if err != nil {
+		t.Fatalf("handleSuggestPorts returned error: %v", err)
+	}
+	if !result.Success {
+		t.Fatalf("expected two free ports, got %#v", result)
+	}
+	suggested := strings.Split(result.Details["ports"], ",")
+	if len(suggested) != 2 {
+		t.Fatalf("expected two ports, got %q", result.Details["ports"])
+	}
+	for _, value := range suggested {
+		if value == strconv.Itoa(port) || value == strconv.Itoa(port+1) {
+			t.Fatalf("busy or excluded port suggested: %q", result.Details["ports"])
+		}
+	}
+
+	result, err = handleSuggestPorts(context.Background(), map[string]string{"range_start": "9000", "range_end": "8000"})
+	if err != nil || result.Success {
+		t.Fatalf("expected an inverted range to be rejected, got %#v, %v", result, err)
+	}
+}
@@ -0,0 +1,140 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package executor
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"runtime"
+	"time"
+
+	pb "github.com/seatunnel/seatunnelX/agent"
+	"github.com/seatunnel/seatunnelX/internal/pkg/errcode"
+	"github.com/seatunnel/seatunnelX/internal/pkg/maintscript"
+)
+
+// maintenanceScripts holds the allow-listed maintenance scripts shipped inside the Agent binary
+// maintenanceScripts 保存随 Agent 二进制一起发布的白名单运维脚本
+//
+//go:embed maintscripts/*.sh
+var maintenanceScripts embed.FS
+
+// scriptWaitDelay bounds how long output pipes may stay open after a script is killed
+// scriptWaitDelay 限制脚本被终止后输出管道保持打开的最长时间
+const scriptWaitDelay = 5 * time.Second
+
+// RegisterScriptHandlers registers the maintenance script handler with the executor
+// RegisterScriptHandlers 向执行器注册运维脚本处理器
+func RegisterScriptHandlers(executor *CommandExecutor) {
+	executor.RegisterHandler(pb.CommandType_EXEC_SCRIPT, HandleExecScriptCommand)
+}
+
+// HandleExecScriptCommand runs an allow-listed maintenance script with schema-validated arguments.
+// Arguments are passed as positional parameters, stdout and stderr are captured as the command output.
+// HandleExecScriptCommand 使用经 schema 校验的参数执行白名单运维脚本。
+// 参数作为位置参数传递，标准输出与标准错误作为命令输出捕获。
+func HandleExecScriptCommand(ctx context.Context, cmd *pb.CommandRequest, reporter ProgressReporter) (*pb.CommandResponse, error) {
+	name, args := maintscript.DecodeParams(cmd.Parameters)
+	script, err := maintscript.Lookup(name)
+	if err != nil {
+		return nil, err
+	}
+	argv, err := script.Validate(args)
+	if err != nil {
+		return nil, err
+	}
+	if runtime.GOOS == "windows" {
+		return nil, errcode.Wrap(errcode.UnsupportedCommand, errors.New("maintenance scripts require a POSIX shell"))
+	}
+	body, err := maintenanceScripts.ReadFile("maintscripts/" + script.Name + ".sh")
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s is not shipped with this agent", maintscript.ErrUnknownScript, script.Name)
+	}
+
+	_ = reporter.Report(10, fmt.Sprintf("Running script %s... / 正在执行脚本 %s...", script.Name, script.Name))
+
+	// Stream output live to the command log while keeping a capped copy for the result
+	// 将输出实时流式写入命令日志，同时保留一份有上限的副本作为结果
+	output := &cappedBuffer{limit: maintscript.MaxOutputBytes}
+	stream := NewOutputStreamWriter(reporter)
+	combined := io.MultiWriter(output, stream)
+	run := exec.CommandContext(ctx, "/bin/sh", append([]string{"-c", string(body), script.Name}, argv...)...)
+	run.Stdout = combined
+	run.Stderr = combined
+	run.WaitDelay = scriptWaitDelay
+	runErr := run.Run()
+	_ = stream.Close()
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	if runErr != nil {
+		exitCode := -1
+		var exitErr *exec.ExitError
+		if errors.As(runErr, &exitErr) {
+			exitCode = exitErr.ExitCode()
+		}
+		return &pb.CommandResponse{
+			CommandId:      cmd.CommandId,
+			Status:         pb.CommandStatus_FAILED,
+			Progress:       100,
+			Output:         output.String(),
+			OutputStreamed: stream.Streamed(),
+			Error:          fmt.Sprintf("script %s exited with code %d: %v / 脚本 %s 退出码 %d", script.Name, exitCode, runErr, script.Name, exitCode),
+			ErrorCode:      string(errcode.CommandFailed),
+			Timestamp:      time.Now().UnixMilli(),
+		}, nil
+	}
+	resp := CreateSuccessResponse(cmd.CommandId, output.String())
+	resp.OutputStreamed = stream.Streamed()
+	return resp, nil
+}
+
+// cappedBuffer keeps the first limit bytes written to it and drops the rest
+// cappedBuffer 保留写入的前 limit 个字节并丢弃其余部分
+type cappedBuffer struct {
+	buf       bytes.Buffer
+	limit     int
+	truncated bool
+}
+
+// Write implements io.Writer; it never fails so the script is not interrupted by a full buffer
+// Write 实现 io.Writer；它从不失败，避免缓冲区写满时中断脚本
+func (b *cappedBuffer) Write(p []byte) (int, error) {
+	if room := b.limit - b.buf.Len(); room < len(p) {
+		b.truncated = true
+		b.buf.Write(p[:max(room, 0)])
+	} else {
+		b.buf.Write(p)
+	}
+	return len(p), nil
+}
+
+// String returns the captured output, marking it when truncated
+// String 返回捕获的输出，被截断时附加标记
+func (b *cappedBuffer) String() string {
+	if b.truncated {
+		return b.buf.String() + fmt.Sprintf("\n... output truncated at %d bytes / 输出已在 %d 字节处截断", b.limit, b.limit)
+	}
+	return b.buf.String()
+}
@@ -0,0 +1,124 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package executor
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	pb "github.com/seatunnel/seatunnelX/agent"
+	"github.com/seatunnel/seatunnelX/internal/pkg/errcode"
+	"github.com/seatunnel/seatunnelX/internal/pkg/maintscript"
+)
+
+func TestEveryAllowListedScriptIsShipped(t *testing.T) {
+	for _, script := range maintscript.List() {
+		if _, err := maintenanceScripts.ReadFile("maintscripts/" + script.Name + ".sh"); err != nil {
+			t.Errorf("script %s is allow-listed but not embedded: %v", script.Name, err)
+		}
+	}
+}
+
+func TestHandleExecScriptCommand_ClearTempDirs(t *testing.T) {
+	installDir := t.TempDir()
+	tempDir := filepath.Join(installDir, "temp")
+	if err := os.MkdirAll(tempDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	stale := filepath.Join(tempDir, "stale.tmp")
+	fresh := filepath.Join(tempDir, "fresh.tmp")
+	for _, file := range []string{stale, fresh} {
+		if err := os.WriteFile(file, []byte("x"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	old := time.Now().Add(-10 * 24 * time.Hour)
+	if err := os.Chtimes(stale, old, old); err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := HandleExecScriptCommand(context.Background(), &pb.CommandRequest{
+		CommandId:  "script-1",
+		Type:       pb.CommandType_EXEC_SCRIPT,
+		Parameters: maintscript.EncodeParams("clear_temp_dirs", map[string]string{"install_dir": installDir, "older_than_days": "7"}),
+	}, &NoOpReporter{})
+	if err != nil || resp.Status != pb.CommandStatus_SUCCESS {
+		t.Fatalf("expected success, got resp=%+v err=%v", resp, err)
+	}
+	if !strings.Contains(resp.Output, "removed 1 file(s) from "+tempDir) {
+		t.Errorf("unexpected output: %s", resp.Output)
+	}
+	if _, err := os.Stat(stale); !os.IsNotExist(err) {
+		t.Errorf("expected stale file to be removed, stat err=%v", err)
+	}
+	if _, err := os.Stat(fresh); err != nil {
+		t.Errorf("expected fresh file to be kept: %v", err)
+	}
+}
+
+func TestHandleExecScriptCommand_FailureKeepsOutput(t *testing.T) {
+	resp, err := HandleExecScriptCommand(context.Background(), &pb.CommandRequest{
+		CommandId:  "script-2",
+		Type:       pb.CommandType_EXEC_SCRIPT,
+		Parameters: maintscript.EncodeParams("rotate_logs", map[string]string{"install_dir": t.TempDir()}),
+	}, &NoOpReporter{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Status != pb.CommandStatus_FAILED || resp.ErrorCode != string(errcode.CommandFailed) {
+		t.Fatalf("expected failed response, got %+v", resp)
+	}
+	if !strings.Contains(resp.Output, "log dir not found") {
+		t.Errorf("expected stderr in output, got %q", resp.Output)
+	}
+}
+
+func TestHandleExecScriptCommand_RejectsInvalidRequests(t *testing.T) {
+	cases := map[string]struct {
+		params map[string]string
+		code   errcode.Code
+	}{
+		"unknown script":  {maintscript.EncodeParams("rm_rf", nil), errcode.UnsupportedCommand},
+		"shell in args":   {maintscript.EncodeParams("disk_usage", map[string]string{"install_dir": "/tmp;id"}), errcode.InvalidParameters},
+		"unknown arg":     {maintscript.EncodeParams("disk_usage", map[string]string{"install_dir": "/tmp", "x": "1"}), errcode.InvalidParameters},
+		"filesystem root": {maintscript.EncodeParams("clear_temp_dirs", map[string]string{"install_dir": "/"}), errcode.InvalidParameters},
+	}
+	for name, tc := range cases {
+		_, err := HandleExecScriptCommand(context.Background(), &pb.CommandRequest{
+			CommandId:  "script-" + name,
+			Type:       pb.CommandType_EXEC_SCRIPT,
+			Parameters: tc.params,
+		}, &NoOpReporter{})
+		if err == nil || ErrorCodeFor(err) != tc.code {
+			t.Errorf("%s: expected %s, got %v", name, tc.code, err)
+		}
+	}
+}
+
+func TestCappedBufferTruncates(t *testing.T) {
+	b := &cappedBuffer{limit: 4}
+	_, _ = b.Write([]byte("abc"))
+	_, _ = b.Write([]byte("def"))
+	if got := b.String(); !strings.HasPrefix(got, "abcd\n") || !strings.Contains(got, "truncated") {
+		t.Fatalf("unexpected buffer content %q", got)
+	}
+}
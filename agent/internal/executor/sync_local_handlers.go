@@ -30,7 +30,6 @@ import (
 	"strconv"
 	"strings"
 	"sync"
-	"syscall"
 	"text/template"
 	"time"
 )
@@ -226,7 +225,7 @@ func handleSyncLocalStatus(ctx context.Context, params map[string]string) (*Prec
 	status := runtime.Status
 	runtime.mu.Unlock()
 
-	alive := pid > 0 && syscall.Kill(pid, 0) == nil
+	alive := pid > 0 && syncLocalProcessAlive(pid)
 	if alive {
 		status.State = "running"
 	}
@@ -345,16 +344,16 @@ func handleSyncLocalStop(ctx context.Context, params map[string]string) (*Preche
 		runtime.mu.RUnlock()
 	}
 	if pid > 0 {
-		_ = syscall.Kill(pid, syscall.SIGTERM)
+		_ = terminateSyncLocalProcess(pid)
 		deadline := time.Now().Add(10 * time.Second)
 		for time.Now().Before(deadline) {
-			if syscall.Kill(pid, 0) != nil {
+			if !syncLocalProcessAlive(pid) {
 				break
 			}
 			time.Sleep(300 * time.Millisecond)
 		}
-		if syscall.Kill(pid, 0) == nil {
-			_ = syscall.Kill(pid, syscall.SIGKILL)
+		if syncLocalProcessAlive(pid) {
+			_ = killSyncLocalProcess(pid)
 		}
 	}
 	if runtime != nil {
@@ -0,0 +1,41 @@
+//go:build !windows
+// +build !windows
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package executor
+
+import "syscall"
+
+// syncLocalProcessAlive reports whether the local sync job process is alive
+// syncLocalProcessAlive 判断本地同步作业进程是否存活
+func syncLocalProcessAlive(pid int) bool {
+	return syscall.Kill(pid, 0) == nil
+}
+
+// terminateSyncLocalProcess asks the local sync job process to exit
+// terminateSyncLocalProcess 请求本地同步作业进程退出
+func terminateSyncLocalProcess(pid int) error {
+	return syscall.Kill(pid, syscall.SIGTERM)
+}
+
+// killSyncLocalProcess forcibly kills the local sync job process
+// killSyncLocalProcess 强制终止本地同步作业进程
+func killSyncLocalProcess(pid int) error {
+	return syscall.Kill(pid, syscall.SIGKILL)
+}
@@ -0,0 +1,55 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package grpc
+
+import (
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Retry delays for registrations the Control Plane did not admit.
+// Control Plane 未准入的注册请求的重试间隔。
+const (
+	// PendingRegistrationRetry is used while the Agent waits for admin approval or is rate limited.
+	// PendingRegistrationRetry 用于 Agent 等待管理员审批或被限流时。
+	PendingRegistrationRetry = 30 * time.Second
+
+	// DeniedRegistrationRetry is used while the Agent is on the deny-list.
+	// DeniedRegistrationRetry 用于 Agent 在拒绝列表中时。
+	DeniedRegistrationRetry = 5 * time.Minute
+)
+
+// RegistrationRetryDelay reports whether a registration error is an admission decision of the
+// Control Plane (rate limit, deny-list or pending approval) and how long to wait before retrying.
+// RegistrationRetryDelay 判断注册错误是否为 Control Plane 的准入决定（限流、拒绝列表或待审批），
+// 并返回重试前的等待时间。
+func RegistrationRetryDelay(err error) (time.Duration, bool) {
+	st, ok := status.FromError(err)
+	if !ok {
+		return 0, false
+	}
+	switch st.Code() {
+	case codes.ResourceExhausted, codes.FailedPrecondition:
+		return PendingRegistrationRetry, true
+	case codes.PermissionDenied:
+		return DeniedRegistrationRetry, true
+	}
+	return 0, false
+}
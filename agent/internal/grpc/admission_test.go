@@ -0,0 +1,45 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package grpc
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// TestRegistrationRetryDelay tests recognizing admission decisions in registration errors.
+// TestRegistrationRetryDelay 测试从注册错误中识别准入决定。
+func TestRegistrationRetryDelay(t *testing.T) {
+	pending := fmt.Errorf("registration failed: %w", status.Error(codes.FailedPrecondition, "pending approval"))
+	if delay, ok := RegistrationRetryDelay(pending); !ok || delay != PendingRegistrationRetry {
+		t.Fatalf("Expected pending retry delay, got %v %v", delay, ok)
+	}
+	if delay, ok := RegistrationRetryDelay(status.Error(codes.PermissionDenied, "denied")); !ok || delay != DeniedRegistrationRetry {
+		t.Fatalf("Expected denied retry delay, got %v %v", delay, ok)
+	}
+	if _, ok := RegistrationRetryDelay(status.Error(codes.Unavailable, "down")); ok {
+		t.Fatal("Unavailable is not an admission decision")
+	}
+	if _, ok := RegistrationRetryDelay(errors.New("client not connected")); ok {
+		t.Fatal("Plain errors are not admission decisions")
+	}
+}
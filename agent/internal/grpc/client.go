@@ -26,7 +26,9 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"os"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -34,9 +36,13 @@ import (
 	pb "github.com/seatunnel/seatunnelX/agent"
 	"github.com/seatunnel/seatunnelX/agent/internal/config"
 	"github.com/seatunnel/seatunnelX/agent/internal/logger"
+	"github.com/seatunnel/seatunnelX/internal/pkg/errcode"
+	"github.com/seatunnel/seatunnelX/internal/pkg/filestream"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
 )
 
 // Default values for exponential backoff
@@ -151,8 +157,16 @@ type Client struct {
 	heartbeatTicker *time.Ticker                                                    // 心跳定时器
 	heartbeatMu     sync.Mutex                                                      // 心跳锁
 	lastHeartbeat   time.Time                                                       // 最后心跳时间
+	heartbeatRTT    time.Duration                                                   // 上一次心跳往返耗时
 	cmdStream       grpc.BidiStreamingClient[pb.CommandResponse, pb.CommandRequest] // 命令流
 	cmdStreamMu     sync.Mutex                                                      // 命令流锁
+	cmdSendMu       sync.Mutex                                                      // 命令流发送锁，串行化 Send
+	addrIndex       int                                                             // 当前 Control Plane 地址下标
+	unhealthy       map[string]time.Time                                            // 最近失败的 Control Plane 地址
+	rpcFailures     int                                                             // 当前地址连续失败次数
+	reconnectAt     time.Time                                                       // Control Plane 提示的最早重连时间
+	pluginCache     func() *pb.PluginCacheStats                                     // 插件缓存统计提供者
+	inventory       func() *pb.SeaTunnelInventory                                   // SeaTunnel 进程清单提供者
 }
 
 // GetDiagnosticsLogCursors fetches diagnostics log cursors from Control Plane.
@@ -173,14 +187,58 @@ func (c *Client) GetDiagnosticsLogCursors(ctx context.Context) (*pb.DiagnosticsC
 	return client.GetDiagnosticsLogCursors(ctx, &pb.DiagnosticsCursorRequest{AgentId: agentID})
 }
 
+// FetchFile pulls a file offered by the Control Plane starting at offset and hands every
+// decompressed chunk to fn in order. The stream ends after the chunk marked last.
+// FetchFile 从 offset 开始拉取 Control Plane 提供的文件，并按顺序将每个解压后的数据块交给 fn。
+// 标记为最后一块的数据块之后流结束。
+func (c *Client) FetchFile(ctx context.Context, transferID string, offset int64, fn func(data []byte, offset, totalSize int64, isLast bool) error) error {
+	c.mu.RLock()
+	client := c.client
+	agentID := c.agentID
+	c.mu.RUnlock()
+
+	if client == nil {
+		return errors.New("client not connected")
+	}
+
+	stream, err := client.FetchFile(ctx, &pb.FetchFileRequest{
+		AgentId:    agentID,
+		TransferId: transferID,
+		Offset:     offset,
+	})
+	if err != nil {
+		return err
+	}
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			return errors.New("file stream ended before the last chunk / 文件流在最后一块之前结束")
+		}
+		if err != nil {
+			return err
+		}
+		data, err := filestream.Decompress(chunk.Data, chunk.Compression)
+		if err != nil {
+			return fmt.Errorf("failed to decompress chunk at offset %d: %w", chunk.Offset, err)
+		}
+		if err := fn(data, chunk.Offset, chunk.TotalSize, chunk.IsLast); err != nil {
+			return err
+		}
+		if chunk.IsLast {
+			return nil
+		}
+	}
+}
+
 // NewClient creates a new gRPC client
 // NewClient 创建新的 gRPC 客户端
 func NewClient(cfg *config.Config) *Client {
 	return &Client{
-		config:  cfg,
-		agentID: cfg.Agent.ID,
-		backoff: NewExponentialBackoff(),
-		stopCh:  make(chan struct{}),
+		config:    cfg,
+		agentID:   cfg.Agent.ID,
+		backoff:   NewExponentialBackoff(),
+		stopCh:    make(chan struct{}),
+		unhealthy: make(map[string]time.Time),
 	}
 }
 
@@ -188,26 +246,54 @@ func NewClient(cfg *config.Config) *Client {
 // Connect 建立与 Control Plane 的连接
 func (c *Client) Connect(ctx context.Context) error {
 	c.mu.Lock()
-	defer c.mu.Unlock()
-
 	if c.connected {
+		c.mu.Unlock()
 		return nil
 	}
+	addresses := c.config.ControlPlane.Addresses
+	candidates := c.candidateAddresses()
+	c.mu.Unlock()
 
-	// Try each address in the list
-	// 尝试列表中的每个地址
+	// Try each address in preference order, skipping recently failed ones unless nothing else is left.
+	// With multiple addresses, only a READY connection counts so that a dead Control Plane is skipped.
+	// 按优先级尝试每个地址，除非别无选择否则跳过最近失败的地址。
+	// 配置多个地址时，仅 READY 的连接视为成功，从而跳过已宕机的 Control Plane。
 	var lastErr error
-	for _, addr := range c.config.ControlPlane.Addresses {
-		conn, err := c.dialWithOptions(ctx, addr)
+	for _, idx := range candidates {
+		addr := addresses[idx]
+		var conn *grpc.ClientConn
+		var err error
+		if len(addresses) > 1 {
+			conn, err = c.probeEndpoint(ctx, addr)
+		} else {
+			conn, err = c.dialWithOptions(ctx, addr)
+		}
 		if err != nil {
 			lastErr = err
+			c.mu.Lock()
+			c.markEndpointUnhealthy(addr)
+			c.mu.Unlock()
 			continue
 		}
 
+		c.mu.Lock()
+		if c.connected {
+			c.mu.Unlock()
+			_ = conn.Close()
+			return nil
+		}
 		c.conn = conn
 		c.client = pb.NewAgentServiceClient(conn)
+		c.addrIndex = idx
+		c.rpcFailures = 0
 		c.connected = true
 		c.backoff.Reset()
+		delete(c.unhealthy, addr)
+		c.mu.Unlock()
+
+		if idx > 0 {
+			logger.WarnF(ctx, "Connected to failover Control Plane %s / 已连接到备用 Control Plane %s", addr, addr)
+		}
 		return nil
 	}
 
@@ -357,9 +443,9 @@ func (c *Client) Reconnect(ctx context.Context) error {
 		default:
 		}
 
-		// Calculate backoff duration
-		// 计算退避时间
-		backoffDuration := c.backoff.NextBackoff()
+		// Calculate backoff duration, honoring a reconnect hint from a draining Control Plane
+		// 计算退避时间，并遵循排空中的 Control Plane 给出的重连提示
+		backoffDuration := c.reconnectWait(c.backoff.NextBackoff())
 
 		// Wait for backoff duration
 		// 等待退避时间
@@ -428,6 +514,26 @@ func (c *Client) Register(ctx context.Context, req *pb.RegisterRequest) (*pb.Reg
 	return resp, nil
 }
 
+// SetPluginCacheStatsProvider sets the function whose plugin cache stats are attached to heartbeats
+// SetPluginCacheStatsProvider 设置为心跳附加插件缓存统计的函数
+func (c *Client) SetPluginCacheStatsProvider(provider func() *pb.PluginCacheStats) {
+	c.heartbeatMu.Lock()
+	defer c.heartbeatMu.Unlock()
+	c.pluginCache = provider
+}
+
+// SetSeaTunnelInventoryProvider sets the function whose SeaTunnel process inventory is attached to heartbeats
+// SetSeaTunnelInventoryProvider 设置为心跳附加 SeaTunnel 进程清单的函数
+func (c *Client) SetSeaTunnelInventoryProvider(provider func() *pb.SeaTunnelInventory) {
+	c.heartbeatMu.Lock()
+	defer c.heartbeatMu.Unlock()
+	c.inventory = provider
+}
+
+// MetadataHeartbeatRTT is the metadata key carrying the round-trip time (milliseconds) of the previous heartbeat.
+// MetadataHeartbeatRTT 是携带上一次心跳往返耗时（毫秒）的 metadata 键。
+const MetadataHeartbeatRTT = "x-seatunnelx-heartbeat-rtt-ms"
+
 // SendHeartbeat sends a heartbeat to Control Plane
 // SendHeartbeat 向 Control Plane 发送心跳
 func (c *Client) SendHeartbeat(ctx context.Context, usage *pb.ResourceUsage, processes []*pb.ProcessStatus) (*pb.HeartbeatResponse, error) {
@@ -445,16 +551,38 @@ func (c *Client) SendHeartbeat(ctx context.Context, usage *pb.ResourceUsage, pro
 		ResourceUsage: usage,
 		Processes:     processes,
 	}
+	c.heartbeatMu.Lock()
+	pluginCache := c.pluginCache
+	inventory := c.inventory
+	rtt := c.heartbeatRTT
+	c.heartbeatMu.Unlock()
+	if pluginCache != nil {
+		req.PluginCache = pluginCache()
+	}
+	if inventory != nil {
+		req.SeatunnelInventory = inventory()
+	}
+	req.ControlPlaneEndpoint, req.ControlPlaneFailover = c.CurrentEndpoint()
+
+	// Report the round-trip time of the previous heartbeat ack
+	// 上报上一次心跳应答的往返耗时
+	rpcCtx := ctx
+	if rtt > 0 {
+		rpcCtx = metadata.AppendToOutgoingContext(rpcCtx, MetadataHeartbeatRTT, strconv.FormatInt(rtt.Milliseconds(), 10))
+	}
 
-	resp, err := client.Heartbeat(ctx, req)
+	sentAt := time.Now()
+	resp, err := client.Heartbeat(rpcCtx, req)
+	c.recordRPCResult(err)
 	if err != nil {
 		return nil, fmt.Errorf("heartbeat failed: %w", err)
 	}
 
-	// Update last heartbeat time
-	// 更新最后心跳时间
+	// Update last heartbeat time and round-trip time
+	// 更新最后心跳时间与往返耗时
 	c.heartbeatMu.Lock()
 	c.lastHeartbeat = time.Now()
+	c.heartbeatRTT = c.lastHeartbeat.Sub(sentAt)
 	c.heartbeatMu.Unlock()
 
 	return resp, nil
@@ -556,12 +684,39 @@ func (c *Client) SendLogEntries(ctx context.Context, entries []*pb.LogEntry) err
 	return nil
 }
 
+// streamMigrationDrainDelay is how long the old command stream stays open after a new one is
+// established, so the Control Plane can switch to the new stream before the old one half-closes.
+// streamMigrationDrainDelay 是新命令流建立后旧流保持打开的时长，
+// 以便 Control Plane 在旧流半关闭前切换到新流。
+const streamMigrationDrainDelay = 5 * time.Second
+
+// commandStream is the client side of the bidirectional command stream.
+// commandStream 是双向指令流的客户端一侧。
+type commandStream = grpc.BidiStreamingClient[pb.CommandResponse, pb.CommandRequest]
+
+// streamRecvResult carries the terminal receive error of a command stream.
+// streamRecvResult 携带命令流接收结束时的错误。
+type streamRecvResult struct {
+	stream commandStream
+	err    error
+}
+
 // StartCommandStream starts the bidirectional command stream
 // StartCommandStream 启动双向指令流
+//
+// When the server sends GOAWAY (e.g. keepalive MaxConnectionAge), the underlying connection leaves
+// READY. A new command stream is then opened on a fresh transport before the old one is closed;
+// the old stream keeps receiving until the server finishes it, so commands already in flight are
+// not lost, and all responses are sent on whichever stream is current.
+// 当服务端发送 GOAWAY（如 keepalive MaxConnectionAge）时，底层连接会离开 READY 状态。
+// 此时先在新的传输上打开新的命令流，再关闭旧流；旧流继续接收直到服务端结束，
+// 因此已在途的指令不会丢失，所有响应都通过当前命令流发送。
 func (c *Client) StartCommandStream(ctx context.Context, handler CommandHandler) error {
 	c.mu.RLock()
 	client := c.client
+	conn := c.conn
 	agentID := c.agentID
+	stopCh := c.stopCh
 	c.mu.RUnlock()
 
 	if client == nil {
@@ -572,19 +727,81 @@ func (c *Client) StartCommandStream(ctx context.Context, handler CommandHandler)
 		return errors.New("agent ID not set, please register first")
 	}
 
+	streamCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := c.openCommandStream(streamCtx, client, agentID)
+	if err != nil {
+		return err
+	}
+	defer c.clearCommandStream()
+
+	logger.InfoF(ctx, "Command stream established successfully for agent %s / 命令流建立成功，Agent: %s", agentID, agentID)
+
+	recvDone := make(chan streamRecvResult, 2)
+	go c.receiveCommands(streamCtx, stream, handler, recvDone)
+
+	migrateCh := make(chan struct{}, 1)
+	if conn != nil {
+		go watchConnectionDrain(streamCtx, conn, migrateCh)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-stopCh:
+			return nil
+		case <-migrateCh:
+			newStream, err := c.openCommandStream(streamCtx, client, agentID)
+			if err != nil {
+				// Keep using the old stream; a real outage surfaces as a receive error.
+				// 继续使用旧流；真正的故障会以接收错误的形式暴露。
+				logger.WarnF(ctx, "Command stream migration failed: %v / 命令流迁移失败：%v", err, err)
+				continue
+			}
+			logger.InfoF(ctx, "Connection draining (GOAWAY), migrated command stream / 连接正在排空（GOAWAY），命令流已迁移")
+
+			old := stream
+			stream = newStream
+			go c.receiveCommands(streamCtx, newStream, handler, recvDone)
+			go func() {
+				select {
+				case <-time.After(streamMigrationDrainDelay):
+				case <-streamCtx.Done():
+				}
+				c.closeCommandStreamSend(old)
+			}()
+		case res := <-recvDone:
+			if res.stream != stream {
+				logger.InfoF(ctx, "Old command stream drained / 旧命令流已排空")
+				continue
+			}
+			if delay, ok := parseReconnectHint(res.stream.Trailer()); ok {
+				// The Control Plane is shutting down: drop the connection so neither heartbeats nor
+				// gRPC auto-reconnect hit it, and reconnect only after the hinted, jittered delay.
+				// Control Plane 正在关闭：断开连接，使心跳和 gRPC 自动重连都不再访问它，
+				// 并仅在提示的（带抖动的）延迟之后重连。
+				logger.InfoF(ctx, "Control Plane is draining, reconnecting in %v / Control Plane 正在排空，%v 后重连", delay, delay)
+				c.holdReconnect(delay)
+				_ = c.Disconnect()
+				return fmt.Errorf("%w, reconnect in %v: %v", ErrControlPlaneDraining, delay, res.err)
+			}
+			return fmt.Errorf("command stream receive error: %w", res.err)
+		}
+	}
+}
+
+// openCommandStream creates a command stream, identifies the Agent and makes it the current stream.
+// openCommandStream 创建命令流、发送 Agent 标识，并将其设为当前命令流。
+func (c *Client) openCommandStream(ctx context.Context, client pb.AgentServiceClient, agentID string) (commandStream, error) {
 	// Create bidirectional stream
 	// 创建双向流
 	stream, err := client.CommandStream(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to create command stream: %w", err)
+		return nil, fmt.Errorf("failed to create command stream: %w", err)
 	}
 
-	// Save stream for later use (e.g., sending process events)
-	// 保存 stream 以便后续使用（如发送进程事件）
-	c.cmdStreamMu.Lock()
-	c.cmdStream = stream
-	c.cmdStreamMu.Unlock()
-
 	// Send initial message with Agent ID to identify ourselves
 	// 发送包含 Agent ID 的初始消息来标识自己
 	initMsg := &pb.CommandResponse{
@@ -594,35 +811,39 @@ func (c *Client) StartCommandStream(ctx context.Context, handler CommandHandler)
 		Timestamp: time.Now().UnixMilli(),
 	}
 	if err := stream.Send(initMsg); err != nil {
-		return fmt.Errorf("failed to send init message: %w", err)
+		return nil, fmt.Errorf("failed to send init message: %w", err)
 	}
 
-	logger.InfoF(ctx, "Command stream established successfully for agent %s / 命令流建立成功，Agent: %s", agentID, agentID)
+	// Save stream for later use (e.g., sending responses and process events)
+	// 保存 stream 以便后续使用（如发送响应和进程事件）
+	c.cmdStreamMu.Lock()
+	c.cmdStream = stream
+	c.cmdStreamMu.Unlock()
 
-	// Start goroutine to receive commands and send responses
-	// 启动 goroutine 接收指令并发送响应
-	for {
-		select {
-		case <-ctx.Done():
-			c.cmdStreamMu.Lock()
-			c.cmdStream = nil
-			c.cmdStreamMu.Unlock()
-			return ctx.Err()
-		case <-c.stopCh:
-			c.cmdStreamMu.Lock()
-			c.cmdStream = nil
-			c.cmdStreamMu.Unlock()
-			return nil
-		default:
-		}
+	return stream, nil
+}
+
+// clearCommandStream forgets the current command stream.
+// clearCommandStream 清除当前命令流。
+func (c *Client) clearCommandStream() {
+	c.cmdStreamMu.Lock()
+	c.cmdStream = nil
+	c.cmdStreamMu.Unlock()
+}
 
+// receiveCommands receives commands from one stream until it ends and dispatches them to handler.
+// receiveCommands 从单个流接收指令直到其结束，并分派给处理器。
+func (c *Client) receiveCommands(ctx context.Context, stream commandStream, handler CommandHandler, done chan<- streamRecvResult) {
+	for {
 		// Receive command from Control Plane
 		// 从 Control Plane 接收指令
 		cmd, err := stream.Recv()
 		if err != nil {
-			// Log error and exit (in production, handle reconnection)
-			// 记录错误并退出（生产环境处理重连）
-			return fmt.Errorf("command stream receive error: %w", err)
+			select {
+			case done <- streamRecvResult{stream: stream, err: err}:
+			case <-ctx.Done():
+			}
+			return
 		}
 
 		// Handle command in a separate goroutine
@@ -632,25 +853,93 @@ func (c *Client) StartCommandStream(ctx context.Context, handler CommandHandler)
 			// 执行指令处理器
 			resp, err := handler(ctx, cmd)
 			if err != nil {
-				// Create error response
-				// 创建错误响应
+				// Create error response, keeping the code the handler already mapped
+				// 创建错误响应，保留处理器已映射的错误码
+				code := string(errcode.OrDefault(err, errcode.CommandFailed))
+				if resp != nil && resp.ErrorCode != "" {
+					code = resp.ErrorCode
+				}
 				resp = &pb.CommandResponse{
 					CommandId: cmd.CommandId,
 					Status:    pb.CommandStatus_FAILED,
 					Error:     err.Error(),
+					ErrorCode: code,
 					Timestamp: time.Now().UnixMilli(),
 				}
 			}
 
-			// Send response back to Control Plane
-			// 将响应发送回 Control Plane
-			if sendErr := stream.Send(resp); sendErr != nil {
+			// Send response back on the current stream, which may differ from the one the command came on
+			// 通过当前命令流发送响应（可能与接收指令的流不同）
+			if sendErr := c.sendOnCommandStream(resp); sendErr != nil {
 				logger.ErrorF(ctx, "Failed to send command response: %v", sendErr)
 			}
 		}(cmd)
 	}
 }
 
+// currentCommandStream returns the current command stream, or nil when none is established.
+// currentCommandStream 返回当前命令流，未建立时返回 nil。
+func (c *Client) currentCommandStream() commandStream {
+	c.cmdStreamMu.Lock()
+	defer c.cmdStreamMu.Unlock()
+	return c.cmdStream
+}
+
+// sendOnCommandStream sends a message on the current command stream.
+// If the stream was migrated while sending, the message is retried once on the new stream.
+// cmdStreamMu is not held during Send so openCommandStream can swap in the new stream meanwhile.
+// sendOnCommandStream 通过当前命令流发送消息。
+// 若发送期间命令流发生迁移，则在新流上重试一次。
+// Send 期间不持有 cmdStreamMu，以便 openCommandStream 同时替换为新流。
+func (c *Client) sendOnCommandStream(resp *pb.CommandResponse) error {
+	c.cmdSendMu.Lock()
+	defer c.cmdSendMu.Unlock()
+
+	stream := c.currentCommandStream()
+	if stream == nil {
+		return errors.New("command stream not established")
+	}
+	err := stream.Send(resp)
+	if err == nil {
+		return nil
+	}
+	if current := c.currentCommandStream(); current != nil && current != stream {
+		return current.Send(resp)
+	}
+	return err
+}
+
+// closeCommandStreamSend half-closes a replaced command stream, which keeps receiving until the server
+// ends it. CloseSend must not run concurrently with Send, so it waits for an in-flight send to finish.
+// closeCommandStreamSend 半关闭已被替换的命令流，该流继续接收直到服务端结束。
+// CloseSend 不能与 Send 并发执行，因此会等待正在进行的发送完成。
+func (c *Client) closeCommandStreamSend(stream commandStream) {
+	c.cmdSendMu.Lock()
+	defer c.cmdSendMu.Unlock()
+	_ = stream.CloseSend()
+}
+
+// watchConnectionDrain signals migrate whenever the connection leaves READY (e.g. after GOAWAY).
+// watchConnectionDrain 在连接离开 READY 状态（如收到 GOAWAY）时发出迁移信号。
+func watchConnectionDrain(ctx context.Context, conn *grpc.ClientConn, migrate chan<- struct{}) {
+	for {
+		if !conn.WaitForStateChange(ctx, connectivity.Ready) {
+			return
+		}
+		select {
+		case migrate <- struct{}{}:
+		default:
+		}
+		// Opening the new stream reconnects; wait for READY before watching the next drain.
+		// 打开新流会触发重连；等待恢复 READY 后再监听下一次排空。
+		for state := conn.GetState(); state != connectivity.Ready; state = conn.GetState() {
+			if !conn.WaitForStateChange(ctx, state) {
+				return
+			}
+		}
+	}
+}
+
 // ReportCommandResult sends a command execution result to Control Plane
 // ReportCommandResult 向 Control Plane 发送指令执行结果
 func (c *Client) ReportCommandResult(ctx context.Context, resp *pb.CommandResponse) error {
@@ -662,16 +951,9 @@ func (c *Client) ReportCommandResult(ctx context.Context, resp *pb.CommandRespon
 		return errors.New("client not connected")
 	}
 
-	// Create a new stream for reporting
-	// 创建新的流用于上报
-	stream, err := client.CommandStream(ctx)
-	if err != nil {
-		return fmt.Errorf("failed to create command stream: %w", err)
-	}
-
-	// Send the response
-	// 发送响应
-	if err := stream.Send(resp); err != nil {
+	// Report on the established command stream so results follow stream migration
+	// 通过已建立的命令流上报，使结果跟随命令流迁移
+	if err := c.sendOnCommandStream(resp); err != nil {
 		return fmt.Errorf("failed to send command result: %w", err)
 	}
 
@@ -739,10 +1021,7 @@ func (t *HeartbeatTracker) Clear() {
 // ReportProcessEvent sends a process event to Control Plane.
 // ReportProcessEvent 向 Control Plane 发送进程事件。
 func (c *Client) ReportProcessEvent(ctx context.Context, event *pb.ProcessEventReport) error {
-	c.cmdStreamMu.Lock()
-	stream := c.cmdStream
-	c.cmdStreamMu.Unlock()
-
+	stream := c.currentCommandStream()
 	if stream == nil {
 		return errors.New("command stream not established, cannot send process event")
 	}
@@ -762,11 +1041,7 @@ func (c *Client) ReportProcessEvent(ctx context.Context, event *pb.ProcessEventR
 		Timestamp: time.Now().UnixMilli(),
 	}
 
-	c.cmdStreamMu.Lock()
-	err = stream.Send(resp)
-	c.cmdStreamMu.Unlock()
-
-	if err != nil {
+	if err := c.sendOnCommandStream(resp); err != nil {
 		return fmt.Errorf("failed to send process event: %w", err)
 	}
 
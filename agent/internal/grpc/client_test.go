@@ -0,0 +1,173 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package grpc
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	pb "github.com/seatunnel/seatunnelX/agent"
+	"google.golang.org/grpc"
+)
+
+// fakeCommandStream records sent messages; when block is set, Send waits on it and then fails.
+// fakeCommandStream 记录已发送的消息；设置 block 时 Send 先等待其关闭再返回失败。
+type fakeCommandStream struct {
+	commandStream
+	mu      sync.Mutex
+	sent    []*pb.CommandResponse
+	block   chan struct{}
+	sending chan struct{}
+
+	// inSend and closedDuringSend are left unsynchronized on purpose so the race detector
+	// reports a CloseSend racing with a blocked Send.
+	inSend           bool
+	closedDuringSend bool
+}
+
+func (s *fakeCommandStream) Send(resp *pb.CommandResponse) error {
+	if s.block != nil && resp.CommandId != "AGENT_INIT" {
+		s.inSend = true
+		close(s.sending)
+		<-s.block
+		s.inSend = false
+		return errors.New("stream drained")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sent = append(s.sent, resp)
+	return nil
+}
+
+func (s *fakeCommandStream) CloseSend() error {
+	if s.inSend {
+		s.closedDuringSend = true
+	}
+	return nil
+}
+
+func (s *fakeCommandStream) sentIDs() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ids := make([]string, 0, len(s.sent))
+	for _, resp := range s.sent {
+		ids = append(ids, resp.CommandId)
+	}
+	return ids
+}
+
+// fakeStreamServiceClient hands out a prepared command stream.
+// fakeStreamServiceClient 返回预先准备好的命令流。
+type fakeStreamServiceClient struct {
+	pb.AgentServiceClient
+	stream *fakeCommandStream
+}
+
+func (f *fakeStreamServiceClient) CommandStream(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[pb.CommandResponse, pb.CommandRequest], error) {
+	return f.stream, nil
+}
+
+// TestSendOnCommandStreamRetriesOnMigratedStream tests that a send failing on a drained stream
+// is retried on the stream opened while the send was in progress.
+// TestSendOnCommandStreamRetriesOnMigratedStream 测试在已排空的流上发送失败时，
+// 消息会在发送期间新打开的流上重试。
+func TestSendOnCommandStreamRetriesOnMigratedStream(t *testing.T) {
+	c := newFailoverTestClient("cp-a:50051")
+	oldStream := &fakeCommandStream{block: make(chan struct{}), sending: make(chan struct{})}
+	newStream := &fakeCommandStream{}
+	c.cmdStream = oldStream
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- c.sendOnCommandStream(&pb.CommandResponse{CommandId: "cmd-1"})
+	}()
+	<-oldStream.sending
+
+	// Migrate while the send is still blocked on the old stream
+	// 在旧流上的发送仍阻塞时完成迁移
+	opened := make(chan error, 1)
+	go func() {
+		_, err := c.openCommandStream(context.Background(), &fakeStreamServiceClient{stream: newStream}, "agent-1")
+		opened <- err
+	}()
+	select {
+	case err := <-opened:
+		if err != nil {
+			t.Fatalf("openCommandStream failed: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("openCommandStream blocked behind an in-flight send")
+	}
+	close(oldStream.block)
+
+	if err := <-errCh; err != nil {
+		t.Fatalf("Expected send to be retried on the new stream, got %v", err)
+	}
+	if got := newStream.sentIDs(); len(got) != 2 || got[0] != "AGENT_INIT" || got[1] != "cmd-1" {
+		t.Fatalf("Expected init and retried response on the new stream, got %v", got)
+	}
+}
+
+// TestSendOnCommandStreamWithoutStream tests sending before a command stream is established.
+// TestSendOnCommandStreamWithoutStream 测试命令流建立前发送消息。
+func TestSendOnCommandStreamWithoutStream(t *testing.T) {
+	c := newFailoverTestClient("cp-a:50051")
+	if err := c.sendOnCommandStream(&pb.CommandResponse{CommandId: "cmd-1"}); err == nil {
+		t.Fatal("Expected error without a command stream")
+	}
+}
+
+// TestCloseCommandStreamSendWaitsForInFlightSend tests that half-closing a migrated stream waits
+// for a send still blocked on it.
+// TestCloseCommandStreamSendWaitsForInFlightSend 测试半关闭已迁移的流时会等待其上仍阻塞的发送完成。
+func TestCloseCommandStreamSendWaitsForInFlightSend(t *testing.T) {
+	c := newFailoverTestClient("cp-a:50051")
+	oldStream := &fakeCommandStream{block: make(chan struct{}), sending: make(chan struct{})}
+	c.cmdStream = oldStream
+
+	sendDone := make(chan error, 1)
+	go func() {
+		sendDone <- c.sendOnCommandStream(&pb.CommandResponse{CommandId: "cmd-1"})
+	}()
+	<-oldStream.sending
+
+	closed := make(chan struct{})
+	go func() {
+		c.closeCommandStreamSend(oldStream)
+		close(closed)
+	}()
+	select {
+	case <-closed:
+		t.Fatal("CloseSend ran while a send was in flight")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(oldStream.block)
+	<-sendDone
+	select {
+	case <-closed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("CloseSend did not run after the send finished")
+	}
+	if oldStream.closedDuringSend {
+		t.Fatal("CloseSend ran concurrently with Send")
+	}
+}
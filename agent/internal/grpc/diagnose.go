@@ -0,0 +1,40 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package grpc
+
+import (
+	"context"
+	"crypto/tls"
+
+	"google.golang.org/grpc"
+)
+
+// DialEndpoint dials addr with the configured TLS and token settings and waits until the
+// connection is READY. It does not change the client's current connection, so it can be used
+// to diagnose every Control Plane address independently.
+// DialEndpoint 使用已配置的 TLS 与 token 设置拨号 addr 并等待连接进入 READY 状态。
+// 它不会改变客户端当前的连接，因此可用于逐个诊断 Control Plane 地址。
+func (c *Client) DialEndpoint(ctx context.Context, addr string) (*grpc.ClientConn, error) {
+	return c.probeEndpoint(ctx, addr)
+}
+
+// TLSConfig returns the client TLS configuration built from the configured certificate files.
+// TLSConfig 返回根据已配置证书文件构建的客户端 TLS 配置。
+func (c *Client) TLSConfig() (*tls.Config, error) {
+	return c.loadTLSConfig()
+}
@@ -0,0 +1,78 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package grpc
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// MetadataReconnectAfter is the trailer key the Control Plane sets (in milliseconds) when it
+// closes the command stream because it is shutting down.
+// MetadataReconnectAfter 是 Control Plane 因关闭而结束命令流时设置的 trailer 键（毫秒）。
+const MetadataReconnectAfter = "x-seatunnelx-reconnect-after-ms"
+
+// maxReconnectHint caps the delay accepted from the Control Plane.
+// maxReconnectHint 限制从 Control Plane 接受的最大延迟。
+const maxReconnectHint = 10 * time.Minute
+
+// ErrControlPlaneDraining indicates the command stream was closed by a draining Control Plane.
+// ErrControlPlaneDraining 表示命令流被正在排空的 Control Plane 关闭。
+var ErrControlPlaneDraining = errors.New("control plane is draining")
+
+// parseReconnectHint extracts the reconnect delay from command stream trailer metadata.
+// parseReconnectHint 从命令流 trailer metadata 中解析重连延迟。
+func parseReconnectHint(md metadata.MD) (time.Duration, bool) {
+	values := md.Get(MetadataReconnectAfter)
+	if len(values) == 0 {
+		return 0, false
+	}
+	ms, err := strconv.ParseInt(values[0], 10, 64)
+	if err != nil || ms < 0 {
+		return 0, false
+	}
+	delay := time.Duration(ms) * time.Millisecond
+	if delay > maxReconnectHint {
+		delay = maxReconnectHint
+	}
+	return delay, true
+}
+
+// holdReconnect makes Reconnect wait at least delay before its next connection attempt.
+// holdReconnect 使 Reconnect 在下一次连接尝试前至少等待 delay。
+func (c *Client) holdReconnect(delay time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.reconnectAt = time.Now().Add(delay)
+}
+
+// reconnectWait returns how long to wait before the next connection attempt: the backoff,
+// extended to honor a reconnect hint from the Control Plane.
+// reconnectWait 返回下一次连接尝试前的等待时间：即退避时间，并延长以遵循 Control Plane 的重连提示。
+func (c *Client) reconnectWait(backoff time.Duration) time.Duration {
+	c.mu.RLock()
+	reconnectAt := c.reconnectAt
+	c.mu.RUnlock()
+	if wait := time.Until(reconnectAt); wait > backoff {
+		return wait
+	}
+	return backoff
+}
@@ -0,0 +1,67 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package grpc
+
+import (
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// TestParseReconnectHint tests parsing the reconnect hint from command stream trailers.
+// TestParseReconnectHint 测试从命令流 trailer 中解析重连提示。
+func TestParseReconnectHint(t *testing.T) {
+	tests := []struct {
+		name  string
+		md    metadata.MD
+		want  time.Duration
+		found bool
+	}{
+		{name: "missing", md: metadata.MD{}},
+		{name: "invalid", md: metadata.Pairs(MetadataReconnectAfter, "soon")},
+		{name: "negative", md: metadata.Pairs(MetadataReconnectAfter, "-1")},
+		{name: "valid", md: metadata.Pairs(MetadataReconnectAfter, "12500"), want: 12500 * time.Millisecond, found: true},
+		{name: "capped", md: metadata.Pairs(MetadataReconnectAfter, "86400000"), want: maxReconnectHint, found: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, found := parseReconnectHint(tt.md)
+			if got != tt.want || found != tt.found {
+				t.Fatalf("parseReconnectHint() = %v, %v; want %v, %v", got, found, tt.want, tt.found)
+			}
+		})
+	}
+}
+
+// TestReconnectWaitHonorsHint tests that Reconnect waits for the hinted delay instead of the backoff.
+// TestReconnectWaitHonorsHint 测试 Reconnect 等待提示的延迟而不是退避时间。
+func TestReconnectWaitHonorsHint(t *testing.T) {
+	c := newFailoverTestClient("cp-a:50051")
+	if got := c.reconnectWait(time.Second); got != time.Second {
+		t.Fatalf("Expected plain backoff without hint, got %v", got)
+	}
+
+	c.holdReconnect(time.Minute)
+	if got := c.reconnectWait(time.Second); got <= 50*time.Second {
+		t.Fatalf("Expected wait close to the hinted minute, got %v", got)
+	}
+	if got := c.reconnectWait(2 * time.Minute); got != 2*time.Minute {
+		t.Fatalf("Expected longer backoff to win, got %v", got)
+	}
+}
@@ -0,0 +1,232 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package grpc
+
+import (
+	"context"
+	"time"
+
+	pb "github.com/seatunnel/seatunnelX/agent"
+	"github.com/seatunnel/seatunnelX/agent/internal/config"
+	"github.com/seatunnel/seatunnelX/agent/internal/logger"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/status"
+)
+
+// endpointProbeTimeout bounds how long a single Control Plane address is probed for READY.
+// endpointProbeTimeout 限制单个 Control Plane 地址探测 READY 的最长时间。
+const endpointProbeTimeout = 5 * time.Second
+
+// EndpointSwitchHandler is called after the client switched to another Control Plane address.
+// EndpointSwitchHandler 在客户端切换到另一个 Control Plane 地址后被调用。
+type EndpointSwitchHandler func(addr string)
+
+// CurrentEndpoint returns the Control Plane address in use and whether it is a failover address.
+// CurrentEndpoint 返回当前使用的 Control Plane 地址以及是否为故障转移地址。
+func (c *Client) CurrentEndpoint() (addr string, failover bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if !c.connected || c.addrIndex >= len(c.config.ControlPlane.Addresses) {
+		return "", false
+	}
+	return c.config.ControlPlane.Addresses[c.addrIndex], c.addrIndex > 0
+}
+
+// failbackInterval returns the configured failback probe interval.
+// failbackInterval 返回配置的回切探测间隔。
+func (c *Client) failbackInterval() time.Duration {
+	if c.config.ControlPlane.FailbackInterval > 0 {
+		return c.config.ControlPlane.FailbackInterval
+	}
+	return config.DefaultFailbackInterval
+}
+
+// failureThreshold returns the configured consecutive failure threshold.
+// failureThreshold 返回配置的连续失败阈值。
+func (c *Client) failureThreshold() int {
+	if c.config.ControlPlane.FailureThreshold > 0 {
+		return c.config.ControlPlane.FailureThreshold
+	}
+	return config.DefaultFailureThreshold
+}
+
+// isEndpointHealthy reports whether addr has not failed within the last failback interval.
+// isEndpointHealthy 判断 addr 在最近一个回切间隔内是否未失败。
+// Caller must hold c.mu.
+// 调用方必须持有 c.mu。
+func (c *Client) isEndpointHealthy(addr string) bool {
+	failedAt, ok := c.unhealthy[addr]
+	return !ok || time.Since(failedAt) > c.failbackInterval()
+}
+
+// markEndpointUnhealthy records a failure of addr.
+// markEndpointUnhealthy 记录 addr 的一次失败。
+// Caller must hold c.mu.
+// 调用方必须持有 c.mu。
+func (c *Client) markEndpointUnhealthy(addr string) {
+	c.unhealthy[addr] = time.Now()
+}
+
+// candidateAddresses returns address indexes in connection order: healthy ones in configured
+// order first, then recently failed ones as a last resort.
+// candidateAddresses 按连接顺序返回地址下标：先按配置顺序返回健康地址，最近失败的地址作为兜底。
+// Caller must hold c.mu.
+// 调用方必须持有 c.mu。
+func (c *Client) candidateAddresses() []int {
+	healthy := make([]int, 0, len(c.config.ControlPlane.Addresses))
+	var failed []int
+	for i, addr := range c.config.ControlPlane.Addresses {
+		if c.isEndpointHealthy(addr) {
+			healthy = append(healthy, i)
+		} else {
+			failed = append(failed, i)
+		}
+	}
+	return append(healthy, failed...)
+}
+
+// probeEndpoint dials addr and waits until the connection is READY.
+// probeEndpoint 拨号 addr 并等待连接进入 READY 状态。
+func (c *Client) probeEndpoint(ctx context.Context, addr string) (*grpc.ClientConn, error) {
+	conn, err := c.dialWithOptions(ctx, addr)
+	if err != nil {
+		return nil, err
+	}
+	if !waitForReady(ctx, conn, endpointProbeTimeout) {
+		_ = conn.Close()
+		return nil, status.Errorf(codes.Unavailable, "control plane %s not ready within %v", addr, endpointProbeTimeout)
+	}
+	return conn, nil
+}
+
+// waitForReady triggers connection and waits for READY until timeout.
+// waitForReady 触发连接并在超时前等待进入 READY 状态。
+func waitForReady(ctx context.Context, conn *grpc.ClientConn, timeout time.Duration) bool {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	conn.Connect()
+	for {
+		state := conn.GetState()
+		if state == connectivity.Ready {
+			return true
+		}
+		if !conn.WaitForStateChange(ctx, state) {
+			return false
+		}
+	}
+}
+
+// recordRPCResult tracks consecutive transport failures of the current endpoint. Once the
+// threshold is reached with more than one address configured, the endpoint is marked unhealthy
+// and the client is flagged disconnected so the reconnect loop fails over to the next address.
+// recordRPCResult 跟踪当前端点的连续传输失败。配置了多个地址且达到阈值后，
+// 该端点被标记为不健康，客户端被标记为断开，由重连循环切换到下一个地址。
+func (c *Client) recordRPCResult(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err == nil {
+		c.rpcFailures = 0
+		return
+	}
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded:
+	default:
+		return
+	}
+
+	c.rpcFailures++
+	if c.rpcFailures < c.failureThreshold() || len(c.config.ControlPlane.Addresses) < 2 || !c.connected {
+		return
+	}
+
+	addr := c.config.ControlPlane.Addresses[c.addrIndex]
+	c.markEndpointUnhealthy(addr)
+	c.connected = false
+	c.rpcFailures = 0
+	logger.WarnF(context.Background(), "Control Plane %s unhealthy after %d failures, failing over / Control Plane %s 连续 %d 次失败，执行故障转移",
+		addr, c.failureThreshold(), addr, c.failureThreshold())
+}
+
+// RunFailback periodically probes addresses preferred over the current one while failed over,
+// and switches back as soon as one is READY. onSwitch is invoked after a switch so the caller
+// can re-register on the new endpoint.
+// RunFailback 在故障转移期间定期探测优先级高于当前地址的地址，一旦就绪立即回切。
+// 切换后调用 onSwitch，以便调用方在新端点上重新注册。
+func (c *Client) RunFailback(ctx context.Context, onSwitch EndpointSwitchHandler) {
+	ticker := time.NewTicker(c.failbackInterval())
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if addr, ok := c.tryFailback(ctx); ok && onSwitch != nil {
+				onSwitch(addr)
+			}
+		}
+	}
+}
+
+// tryFailback switches to the most preferred READY address ahead of the current one.
+// tryFailback 切换到当前地址之前、优先级最高且已就绪的地址。
+func (c *Client) tryFailback(ctx context.Context) (string, bool) {
+	c.mu.RLock()
+	current := c.addrIndex
+	connected := c.connected
+	addresses := c.config.ControlPlane.Addresses
+	c.mu.RUnlock()
+
+	if !connected || current == 0 {
+		return "", false
+	}
+
+	for i := 0; i < current && i < len(addresses); i++ {
+		conn, err := c.probeEndpoint(ctx, addresses[i])
+		if err != nil {
+			continue
+		}
+
+		c.mu.Lock()
+		if !c.connected || c.addrIndex != current {
+			// Connection state changed meanwhile; let the reconnect path decide.
+			// 期间连接状态已变化，交由重连流程处理。
+			c.mu.Unlock()
+			_ = conn.Close()
+			return "", false
+		}
+		old := c.conn
+		c.conn = conn
+		c.client = pb.NewAgentServiceClient(conn)
+		c.addrIndex = i
+		c.rpcFailures = 0
+		delete(c.unhealthy, addresses[i])
+		c.mu.Unlock()
+
+		if old != nil {
+			_ = old.Close()
+		}
+		logger.InfoF(ctx, "Failed back to preferred Control Plane %s / 已回切到首选 Control Plane %s", addresses[i], addresses[i])
+		return addresses[i], true
+	}
+	return "", false
+}
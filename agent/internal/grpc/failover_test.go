@@ -0,0 +1,105 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package grpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pb "github.com/seatunnel/seatunnelX/agent"
+	"github.com/seatunnel/seatunnelX/agent/internal/config"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func newFailoverTestClient(addresses ...string) *Client {
+	cfg := &config.Config{}
+	cfg.ControlPlane.Addresses = addresses
+	cfg.ControlPlane.FailbackInterval = time.Minute
+	cfg.ControlPlane.FailureThreshold = 2
+	return NewClient(cfg)
+}
+
+// TestCandidateAddressesSkipsUnhealthy tests that recently failed endpoints are tried last.
+// TestCandidateAddressesSkipsUnhealthy 测试最近失败的端点被放到最后尝试。
+func TestCandidateAddressesSkipsUnhealthy(t *testing.T) {
+	c := newFailoverTestClient("cp-a:50051", "cp-b:50051", "cp-c:50051")
+	c.markEndpointUnhealthy("cp-a:50051")
+
+	got := c.candidateAddresses()
+	want := []int{1, 2, 0}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("Expected candidate order %v, got %v", want, got)
+		}
+	}
+}
+
+// TestRecordRPCResultTriggersFailover tests that consecutive transport failures mark the endpoint unhealthy.
+// TestRecordRPCResultTriggersFailover 测试连续传输失败会将端点标记为不健康。
+func TestRecordRPCResultTriggersFailover(t *testing.T) {
+	c := newFailoverTestClient("cp-a:50051", "cp-b:50051")
+	c.connected = true
+
+	unavailable := status.Error(codes.Unavailable, "connection refused")
+	c.recordRPCResult(unavailable)
+	c.recordRPCResult(status.Error(codes.NotFound, "agent not found"))
+	if !c.IsConnected() {
+		t.Fatal("Expected client to stay connected below the failure threshold")
+	}
+
+	c.recordRPCResult(unavailable)
+	if c.IsConnected() {
+		t.Fatal("Expected client to be flagged disconnected at the failure threshold")
+	}
+	if c.isEndpointHealthy("cp-a:50051") {
+		t.Error("Expected preferred endpoint to be marked unhealthy")
+	}
+}
+
+// fakeHeartbeatServiceClient records the last heartbeat request.
+// fakeHeartbeatServiceClient 记录最近一次心跳请求。
+type fakeHeartbeatServiceClient struct {
+	pb.AgentServiceClient
+	last *pb.HeartbeatRequest
+}
+
+func (f *fakeHeartbeatServiceClient) Heartbeat(ctx context.Context, req *pb.HeartbeatRequest, opts ...grpc.CallOption) (*pb.HeartbeatResponse, error) {
+	f.last = req
+	return &pb.HeartbeatResponse{Success: true}, nil
+}
+
+// TestSendHeartbeatReportsControlPlaneEndpoint tests that heartbeats carry the endpoint in use and the failover flag.
+// TestSendHeartbeatReportsControlPlaneEndpoint 测试心跳携带当前使用的端点与故障转移标记。
+func TestSendHeartbeatReportsControlPlaneEndpoint(t *testing.T) {
+	c := newFailoverTestClient("cp-a:50051", "cp-b:50051")
+	fake := &fakeHeartbeatServiceClient{}
+	c.client = fake
+	c.connected = true
+	c.addrIndex = 1
+
+	if _, err := c.SendHeartbeat(context.Background(), nil, nil); err != nil {
+		t.Fatalf("SendHeartbeat failed: %v", err)
+	}
+	if fake.last.GetControlPlaneEndpoint() != "cp-b:50051" || !fake.last.GetControlPlaneFailover() {
+		t.Fatalf("Expected failover endpoint cp-b:50051, got %q failover=%v",
+			fake.last.GetControlPlaneEndpoint(), fake.last.GetControlPlaneFailover())
+	}
+}
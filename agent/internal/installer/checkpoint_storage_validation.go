@@ -0,0 +1,561 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package installer
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// checkpointStorageValidationTimeout bounds one full write/read/delete round trip.
+// checkpointStorageValidationTimeout 限制一次完整写入/读取/删除往返的耗时。
+const checkpointStorageValidationTimeout = 60 * time.Second
+
+// ErrHDFSClientNotFound indicates no Hadoop client is available to reach HDFS from this host.
+// ErrHDFSClientNotFound 表示本机没有可用于访问 HDFS 的 Hadoop 客户端。
+var ErrHDFSClientNotFound = errors.New("hdfs client not found; install the Hadoop client or set HADOOP_HOME")
+
+// CheckpointStorageValidationStep names one step of the validation round trip.
+// CheckpointStorageValidationStep 表示校验往返中的一个步骤。
+type CheckpointStorageValidationStep string
+
+const (
+	// CheckpointStorageStepWrite writes the test object.
+	// CheckpointStorageStepWrite 写入测试对象。
+	CheckpointStorageStepWrite CheckpointStorageValidationStep = "write"
+
+	// CheckpointStorageStepRead reads the test object back and compares its content.
+	// CheckpointStorageStepRead 读回测试对象并比对内容。
+	CheckpointStorageStepRead CheckpointStorageValidationStep = "read"
+
+	// CheckpointStorageStepDelete deletes the test object.
+	// CheckpointStorageStepDelete 删除测试对象。
+	CheckpointStorageStepDelete CheckpointStorageValidationStep = "delete"
+)
+
+// CheckpointStorageStepResult is the outcome of one validation step.
+// CheckpointStorageStepResult 是单个校验步骤的结果。
+type CheckpointStorageStepResult struct {
+	Step       CheckpointStorageValidationStep `json:"step"`
+	Success    bool                            `json:"success"`
+	Message    string                          `json:"message,omitempty"`
+	DurationMs int64                           `json:"duration_ms"`
+}
+
+// CheckpointStorageValidationResult is the outcome of a checkpoint storage validation.
+// CheckpointStorageValidationResult 是 checkpoint 存储校验的结果。
+type CheckpointStorageValidationResult struct {
+	Success     bool                          `json:"success"`
+	StorageType CheckpointStorageType         `json:"storage_type"`
+	Backend     string                        `json:"backend,omitempty"`
+	Location    string                        `json:"location,omitempty"`
+	Message     string                        `json:"message"`
+	Steps       []CheckpointStorageStepResult `json:"steps,omitempty"`
+}
+
+// checkpointStorageBackend is a storage the validator can write, read and delete a test object on.
+// checkpointStorageBackend 是校验器可在其上写入、读取和删除测试对象的存储。
+type checkpointStorageBackend interface {
+	name() string
+	location(key string) string
+	put(ctx context.Context, key string, data []byte) error
+	get(ctx context.Context, key string) ([]byte, error)
+	delete(ctx context.Context, key string) error
+	close()
+}
+
+// checkpointStorageBackendFactory opens a backend for a validated checkpoint config.
+// checkpointStorageBackendFactory 为已校验的 checkpoint 配置打开存储后端。
+type checkpointStorageBackendFactory func(ctx context.Context, cfg *CheckpointConfig) (checkpointStorageBackend, error)
+
+// checkpointStorageBackends maps each storage type to its backend; new storage types plug in here.
+// checkpointStorageBackends 将存储类型映射到对应后端；新增存储类型在此接入。
+var checkpointStorageBackends = map[CheckpointStorageType]checkpointStorageBackendFactory{
+	CheckpointStorageLocalFile: newLocalCheckpointStorageBackend,
+	CheckpointStorageHDFS:      newHDFSCheckpointStorageBackend,
+	CheckpointStorageOSS:       newObjectCheckpointStorageBackend,
+	CheckpointStorageS3:        newObjectCheckpointStorageBackend,
+}
+
+// ValidateCheckpointStorage writes, reads back and deletes a small test object on the configured
+// checkpoint storage using the supplied credentials. The delete step is attempted whenever the
+// write succeeded, so a failed read does not leave the test object behind.
+// ValidateCheckpointStorage 使用给定凭证在 checkpoint 存储上写入、读回并删除一个小测试对象。
+// 只要写入成功就会尝试删除，读取失败也不会遗留测试对象。
+func ValidateCheckpointStorage(ctx context.Context, cfg *CheckpointConfig) (*CheckpointStorageValidationResult, error) {
+	if cfg == nil {
+		return nil, errors.New("checkpoint config is required")
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	factory, ok := checkpointStorageBackends[cfg.StorageType]
+	if !ok {
+		return nil, fmt.Errorf("unsupported checkpoint storage type: %s", cfg.StorageType)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, checkpointStorageValidationTimeout)
+	defer cancel()
+
+	backend, err := factory(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	defer backend.close()
+
+	key := fmt.Sprintf("seatunnelx-validate-%d.probe", time.Now().UnixNano())
+	payload := []byte(fmt.Sprintf("seatunnelx checkpoint storage validation %s\n", key))
+	result := &CheckpointStorageValidationResult{
+		StorageType: cfg.StorageType,
+		Backend:     backend.name(),
+		Location:    backend.location(key),
+	}
+
+	write := runCheckpointStorageStep(CheckpointStorageStepWrite, func() error {
+		return backend.put(ctx, key, payload)
+	})
+	result.Steps = append(result.Steps, write)
+	if write.Success {
+		result.Steps = append(result.Steps, runCheckpointStorageStep(CheckpointStorageStepRead, func() error {
+			data, err := backend.get(ctx, key)
+			if err != nil {
+				return err
+			}
+			if !bytes.Equal(data, payload) {
+				return fmt.Errorf("read back %d bytes that do not match the %d bytes written", len(data), len(payload))
+			}
+			return nil
+		}))
+		result.Steps = append(result.Steps, runCheckpointStorageStep(CheckpointStorageStepDelete, func() error {
+			return backend.delete(ctx, key)
+		}))
+	}
+
+	result.Success = true
+	for _, step := range result.Steps {
+		if !step.Success {
+			result.Success = false
+			result.Message = fmt.Sprintf("checkpoint storage %s failed at %s: %s", cfg.StorageType, step.Step, step.Message)
+			break
+		}
+	}
+	if result.Success {
+		result.Message = fmt.Sprintf("checkpoint storage %s is writable, readable and deletable at %s", cfg.StorageType, result.Location)
+	}
+	return result, nil
+}
+
+func runCheckpointStorageStep(step CheckpointStorageValidationStep, fn func() error) CheckpointStorageStepResult {
+	start := time.Now()
+	err := fn()
+	result := CheckpointStorageStepResult{
+		Step:       step,
+		Success:    err == nil,
+		DurationMs: time.Since(start).Milliseconds(),
+	}
+	if err != nil {
+		result.Message = err.Error()
+	}
+	return result
+}
+
+// localCheckpointStorageBackend validates LOCAL_FILE storage directly on the filesystem.
+// localCheckpointStorageBackend 直接在文件系统上校验 LOCAL_FILE 存储。
+type localCheckpointStorageBackend struct {
+	dir string
+}
+
+func newLocalCheckpointStorageBackend(_ context.Context, cfg *CheckpointConfig) (checkpointStorageBackend, error) {
+	dir := filepath.Clean(strings.TrimSpace(cfg.Namespace))
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create namespace %s: %w", dir, err)
+	}
+	return &localCheckpointStorageBackend{dir: dir}, nil
+}
+
+func (b *localCheckpointStorageBackend) name() string { return "local" }
+
+func (b *localCheckpointStorageBackend) location(key string) string {
+	return filepath.Join(b.dir, key)
+}
+
+func (b *localCheckpointStorageBackend) put(_ context.Context, key string, data []byte) error {
+	return os.WriteFile(b.location(key), data, 0600)
+}
+
+func (b *localCheckpointStorageBackend) get(_ context.Context, key string) ([]byte, error) {
+	return os.ReadFile(b.location(key))
+}
+
+func (b *localCheckpointStorageBackend) delete(_ context.Context, key string) error {
+	return os.Remove(b.location(key))
+}
+
+func (b *localCheckpointStorageBackend) close() {}
+
+// checkpointStorageCommandRunner runs an external command; replaced in tests.
+// checkpointStorageCommandRunner 执行外部命令；测试中可替换。
+var checkpointStorageCommandRunner = func(ctx context.Context, env []string, stdin []byte, name string, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Env = append(os.Environ(), env...)
+	if stdin != nil {
+		cmd.Stdin = bytes.NewReader(stdin)
+	}
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("%s: %w: %s", filepath.Base(name), err, lastLines(msg, 3))
+		}
+		return nil, fmt.Errorf("%s: %w", filepath.Base(name), err)
+	}
+	return stdout.Bytes(), nil
+}
+
+// lookupCheckpointStorageBinary locates a Hadoop or Kerberos client binary; replaced in tests.
+// lookupCheckpointStorageBinary 查找 Hadoop 或 Kerberos 客户端程序；测试中可替换。
+var lookupCheckpointStorageBinary = func(name string) (string, error) {
+	if name == "hdfs" {
+		if home := strings.TrimSpace(os.Getenv("HADOOP_HOME")); home != "" {
+			candidate := filepath.Join(home, "bin", "hdfs")
+			if fileExists(candidate) {
+				return candidate, nil
+			}
+		}
+	}
+	return exec.LookPath(name)
+}
+
+// hdfsCheckpointStorageBackend validates HDFS storage through the local Hadoop client, logging in
+// with the configured keytab first when Kerberos is enabled.
+// hdfsCheckpointStorageBackend 通过本机 Hadoop 客户端校验 HDFS 存储；启用 Kerberos 时先使用配置的
+// keytab 登录。
+type hdfsCheckpointStorageBackend struct {
+	hdfsBin  string
+	dir      string
+	options  []string
+	env      []string
+	cacheDir string
+}
+
+func newHDFSCheckpointStorageBackend(ctx context.Context, cfg *CheckpointConfig) (checkpointStorageBackend, error) {
+	hdfsBin, err := lookupCheckpointStorageBinary("hdfs")
+	if err != nil {
+		return nil, ErrHDFSClientNotFound
+	}
+	pluginConfig, err := buildCheckpointPluginConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	backend := &hdfsCheckpointStorageBackend{
+		hdfsBin: hdfsBin,
+		dir:     path.Clean("/" + strings.TrimSpace(cfg.Namespace)),
+		options: hdfsGenericOptions(pluginConfig),
+	}
+
+	principal := strings.TrimSpace(cfg.KerberosPrincipal)
+	if principal == "" {
+		return backend, nil
+	}
+	keytab := strings.TrimSpace(cfg.KerberosKeytabFilePath)
+	if keytab == "" {
+		return nil, errors.New("kerberos_keytab_file_path is required when kerberos_principal is set")
+	}
+	if !fileExists(keytab) {
+		return nil, fmt.Errorf("kerberos keytab %s does not exist on this host", keytab)
+	}
+	kinitBin, err := lookupCheckpointStorageBinary("kinit")
+	if err != nil {
+		return nil, errors.New("kinit not found; install the Kerberos client to validate kerberized HDFS")
+	}
+	// Use a private credential cache so the login neither relies on nor clobbers the agent user's tickets.
+	// 使用独立的凭证缓存，既不依赖也不覆盖 Agent 用户已有的票据。
+	backend.cacheDir, err = os.MkdirTemp("", "seatunnelx-krb5cc-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create kerberos credential cache: %w", err)
+	}
+	backend.env = []string{"KRB5CCNAME=FILE:" + filepath.Join(backend.cacheDir, "krb5cc")}
+	backend.options = append(backend.options, "-D", "hadoop.security.authentication=kerberos")
+	if _, err := checkpointStorageCommandRunner(ctx, backend.env, nil, kinitBin, "-kt", keytab, principal); err != nil {
+		backend.close()
+		return nil, fmt.Errorf("kerberos login as %s failed: %w", principal, err)
+	}
+	return backend, nil
+}
+
+// hdfsGenericOptions turns the Hadoop entries of the checkpoint plugin config into -D options.
+// hdfsGenericOptions 将 checkpoint 插件配置中的 Hadoop 配置项转换为 -D 选项。
+func hdfsGenericOptions(pluginConfig map[string]string) []string {
+	keys := make([]string, 0, len(pluginConfig))
+	for key := range pluginConfig {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	options := make([]string, 0, len(keys)*2)
+	for _, key := range keys {
+		hadoopKey := key
+		switch {
+		case key == "fs.defaultFS":
+		case strings.HasPrefix(key, "seatunnel.hadoop."):
+			hadoopKey = strings.TrimPrefix(key, "seatunnel.hadoop.")
+		default:
+			continue
+		}
+		options = append(options, "-D", hadoopKey+"="+pluginConfig[key])
+	}
+	return options
+}
+
+func (b *hdfsCheckpointStorageBackend) name() string { return "hdfs_client" }
+
+func (b *hdfsCheckpointStorageBackend) location(key string) string {
+	return path.Join(b.dir, key)
+}
+
+func (b *hdfsCheckpointStorageBackend) run(ctx context.Context, stdin []byte, args ...string) ([]byte, error) {
+	fullArgs := append([]string{"dfs"}, b.options...)
+	fullArgs = append(fullArgs, args...)
+	return checkpointStorageCommandRunner(ctx, b.env, stdin, b.hdfsBin, fullArgs...)
+}
+
+func (b *hdfsCheckpointStorageBackend) put(ctx context.Context, key string, data []byte) error {
+	if _, err := b.run(ctx, nil, "-mkdir", "-p", b.dir); err != nil {
+		return err
+	}
+	_, err := b.run(ctx, data, "-put", "-f", "-", b.location(key))
+	return err
+}
+
+func (b *hdfsCheckpointStorageBackend) get(ctx context.Context, key string) ([]byte, error) {
+	return b.run(ctx, nil, "-cat", b.location(key))
+}
+
+func (b *hdfsCheckpointStorageBackend) delete(ctx context.Context, key string) error {
+	_, err := b.run(ctx, nil, "-rm", "-skipTrash", b.location(key))
+	return err
+}
+
+func (b *hdfsCheckpointStorageBackend) close() {
+	if b.cacheDir != "" {
+		_ = os.RemoveAll(b.cacheDir)
+	}
+}
+
+// objectCheckpointStorageBackend validates OSS and S3 storage through their S3-compatible REST API
+// signed with AWS Signature Version 4.
+// objectCheckpointStorageBackend 通过 S3 兼容 REST API（AWS 签名 V4）校验 OSS 与 S3 存储。
+type objectCheckpointStorageBackend struct {
+	client    *http.Client
+	endpoint  *url.URL
+	bucket    string
+	prefix    string
+	region    string
+	accessKey string
+	secretKey string
+	// virtualHosted addresses the bucket as a subdomain, which OSS requires.
+	// virtualHosted 以子域名方式访问存储桶，OSS 要求使用该方式。
+	virtualHosted bool
+}
+
+func newObjectCheckpointStorageBackend(_ context.Context, cfg *CheckpointConfig) (checkpointStorageBackend, error) {
+	rawEndpoint := strings.TrimSpace(cfg.StorageEndpoint)
+	if !strings.Contains(rawEndpoint, "://") {
+		rawEndpoint = "https://" + rawEndpoint
+	}
+	endpoint, err := url.Parse(rawEndpoint)
+	if err != nil || endpoint.Host == "" {
+		return nil, fmt.Errorf("invalid storage_endpoint %q", cfg.StorageEndpoint)
+	}
+	bucket := strings.TrimSpace(cfg.StorageBucket)
+	for _, scheme := range []string{"s3a://", "s3n://", "s3://", "oss://"} {
+		bucket = strings.TrimPrefix(bucket, scheme)
+	}
+	bucket = strings.Trim(bucket, "/")
+	if bucket == "" {
+		return nil, errors.New("storage_bucket is required for OSS/S3 storage")
+	}
+	return &objectCheckpointStorageBackend{
+		client:        &http.Client{Timeout: 30 * time.Second},
+		endpoint:      endpoint,
+		bucket:        bucket,
+		prefix:        strings.Trim(strings.TrimSpace(cfg.Namespace), "/"),
+		region:        objectStorageRegion(cfg.StorageType, endpoint.Hostname()),
+		accessKey:     cfg.StorageAccessKey,
+		secretKey:     cfg.StorageSecretKey,
+		virtualHosted: cfg.StorageType == CheckpointStorageOSS,
+	}, nil
+}
+
+// objectStorageRegion derives the signing region from well-known endpoint host names and falls
+// back to us-east-1, which S3-compatible stores such as MinIO accept.
+// objectStorageRegion 从常见的端点主机名推导签名区域，否则回退为 MinIO 等 S3 兼容存储接受的 us-east-1。
+func objectStorageRegion(storageType CheckpointStorageType, host string) string {
+	labels := strings.Split(host, ".")
+	if storageType == CheckpointStorageOSS {
+		if strings.HasPrefix(labels[0], "oss-") {
+			return strings.TrimSuffix(labels[0], "-internal")
+		}
+		return "us-east-1"
+	}
+	if len(labels) >= 3 && strings.HasSuffix(host, ".amazonaws.com") {
+		if labels[0] == "s3" && len(labels) >= 4 {
+			return labels[1]
+		}
+		if strings.HasPrefix(labels[0], "s3-") {
+			return strings.TrimPrefix(labels[0], "s3-")
+		}
+	}
+	return "us-east-1"
+}
+
+func (b *objectCheckpointStorageBackend) name() string { return "s3_api" }
+
+func (b *objectCheckpointStorageBackend) objectKey(key string) string {
+	if b.prefix == "" {
+		return key
+	}
+	return b.prefix + "/" + key
+}
+
+func (b *objectCheckpointStorageBackend) location(key string) string {
+	return b.bucket + "/" + b.objectKey(key)
+}
+
+func (b *objectCheckpointStorageBackend) objectURL(key string) *url.URL {
+	u := *b.endpoint
+	if b.virtualHosted {
+		u.Host = b.bucket + "." + u.Host
+		u.Path = "/" + b.objectKey(key)
+	} else {
+		u.Path = "/" + b.bucket + "/" + b.objectKey(key)
+	}
+	u.RawQuery = ""
+	return &u
+}
+
+func (b *objectCheckpointStorageBackend) do(ctx context.Context, method, key string, body []byte) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, method, b.objectURL(key).String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.ContentLength = int64(len(body))
+	signAWSV4(req, body, b.accessKey, b.secretKey, b.region, time.Now().UTC())
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("%s %s returned %s: %s", method, b.location(key), resp.Status, lastLines(strings.TrimSpace(string(data)), 3))
+	}
+	return data, nil
+}
+
+func (b *objectCheckpointStorageBackend) put(ctx context.Context, key string, data []byte) error {
+	_, err := b.do(ctx, http.MethodPut, key, data)
+	return err
+}
+
+func (b *objectCheckpointStorageBackend) get(ctx context.Context, key string) ([]byte, error) {
+	return b.do(ctx, http.MethodGet, key, nil)
+}
+
+func (b *objectCheckpointStorageBackend) delete(ctx context.Context, key string) error {
+	_, err := b.do(ctx, http.MethodDelete, key, nil)
+	return err
+}
+
+func (b *objectCheckpointStorageBackend) close() {}
+
+// signAWSV4 signs req with AWS Signature Version 4 for the s3 service.
+// signAWSV4 为 s3 服务使用 AWS 签名 V4 对请求签名。
+func signAWSV4(req *http.Request, body []byte, accessKey, secretKey, region string, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	payloadHash := sha256Hex(body)
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	signedHeaders := "host;x-amz-content-sha256;x-amz-date"
+	canonicalHeaders := "host:" + req.URL.Host + "\n" +
+		"x-amz-content-sha256:" + payloadHash + "\n" +
+		"x-amz-date:" + amzDate + "\n"
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		canonicalHeaders,
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := date + "/" + region + "/s3/aws4_request"
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + sha256Hex([]byte(canonicalRequest))
+
+	key := hmacSHA256([]byte("AWS4"+secretKey), date)
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		accessKey, scope, signedHeaders, signature,
+	))
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// lastLines keeps the last n lines of s so command and HTTP errors stay readable.
+// lastLines 保留 s 的最后 n 行，使命令与 HTTP 错误保持可读。
+func lastLines(s string, n int) string {
+	lines := strings.Split(s, "\n")
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,192 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package installer
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestValidateCheckpointStorage_LocalFile(t *testing.T) {
+	namespace := filepath.Join(t.TempDir(), "checkpoint")
+
+	result, err := ValidateCheckpointStorage(context.Background(), &CheckpointConfig{
+		StorageType: CheckpointStorageLocalFile,
+		Namespace:   namespace,
+	})
+	if err != nil {
+		t.Fatalf("ValidateCheckpointStorage returned error: %v", err)
+	}
+	if !result.Success || len(result.Steps) != 3 {
+		t.Fatalf("expected write/read/delete to succeed, got %+v", result)
+	}
+	entries, err := os.ReadDir(namespace)
+	if err != nil {
+		t.Fatalf("failed to read namespace: %v", err)
+	}
+	if len(entries) != 0 {
+		t.Fatalf("expected test object to be deleted, found %d entries", len(entries))
+	}
+}
+
+func TestValidateCheckpointStorage_S3(t *testing.T) {
+	var mu sync.Mutex
+	objects := map[string][]byte{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=AKID/") {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		switch r.Method {
+		case http.MethodPut:
+			body, _ := io.ReadAll(r.Body)
+			objects[r.URL.Path] = body
+		case http.MethodGet:
+			body, ok := objects[r.URL.Path]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_, _ = w.Write(body)
+		case http.MethodDelete:
+			delete(objects, r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	result, err := ValidateCheckpointStorage(context.Background(), &CheckpointConfig{
+		StorageType:      CheckpointStorageS3,
+		Namespace:        "/seatunnel/checkpoint/",
+		StorageEndpoint:  server.URL,
+		StorageAccessKey: "AKID",
+		StorageSecretKey: "secret",
+		StorageBucket:    "s3a://bucket",
+	})
+	if err != nil {
+		t.Fatalf("ValidateCheckpointStorage returned error: %v", err)
+	}
+	if !result.Success {
+		t.Fatalf("expected S3 validation to succeed, got %+v", result)
+	}
+	if !strings.HasPrefix(result.Location, "bucket/seatunnel/checkpoint/") {
+		t.Fatalf("unexpected location %q", result.Location)
+	}
+	if len(objects) != 0 {
+		t.Fatalf("expected test object to be deleted, got %v", objects)
+	}
+}
+
+func TestValidateCheckpointStorage_HDFSKerberos(t *testing.T) {
+	keytab := filepath.Join(t.TempDir(), "seatunnel.keytab")
+	if err := os.WriteFile(keytab, []byte("keytab"), 0600); err != nil {
+		t.Fatalf("failed to write keytab: %v", err)
+	}
+
+	originalLookup, originalRunner := lookupCheckpointStorageBinary, checkpointStorageCommandRunner
+	defer func() {
+		lookupCheckpointStorageBinary, checkpointStorageCommandRunner = originalLookup, originalRunner
+	}()
+	lookupCheckpointStorageBinary = func(name string) (string, error) { return "/opt/bin/" + name, nil }
+
+	var calls [][]string
+	var stored []byte
+	checkpointStorageCommandRunner = func(_ context.Context, env []string, stdin []byte, name string, args ...string) ([]byte, error) {
+		if len(env) != 1 || !strings.HasPrefix(env[0], "KRB5CCNAME=FILE:") {
+			t.Fatalf("expected a private kerberos credential cache, got env %v", env)
+		}
+		calls = append(calls, append([]string{filepath.Base(name)}, args...))
+		switch {
+		case slices.Contains(args, "-put"):
+			stored = stdin
+		case slices.Contains(args, "-cat"):
+			return stored, nil
+		}
+		return nil, nil
+	}
+
+	result, err := ValidateCheckpointStorage(context.Background(), &CheckpointConfig{
+		StorageType:            CheckpointStorageHDFS,
+		Namespace:              "/seatunnel/checkpoint",
+		HDFSNameNodeHost:       "nn1",
+		HDFSNameNodePort:       8020,
+		KerberosPrincipal:      "seatunnel@EXAMPLE.COM",
+		KerberosKeytabFilePath: keytab,
+	})
+	if err != nil {
+		t.Fatalf("ValidateCheckpointStorage returned error: %v", err)
+	}
+	if !result.Success {
+		t.Fatalf("expected HDFS validation to succeed, got %+v", result)
+	}
+	if len(calls) == 0 || strings.Join(calls[0], " ") != "kinit -kt "+keytab+" seatunnel@EXAMPLE.COM" {
+		t.Fatalf("expected kinit with the keytab first, got %v", calls)
+	}
+	put := strings.Join(calls[2], " ")
+	if !strings.Contains(put, "-D fs.defaultFS=hdfs://nn1:8020") || !strings.Contains(put, "-D hadoop.security.authentication=kerberos") {
+		t.Fatalf("expected HDFS and kerberos options on put, got %q", put)
+	}
+	if last := calls[len(calls)-1]; !slices.Contains(last, "-rm") {
+		t.Fatalf("expected the test file to be removed last, got %v", last)
+	}
+}
+
+func TestValidateCheckpointStorage_HDFSClientMissing(t *testing.T) {
+	originalLookup := lookupCheckpointStorageBinary
+	defer func() { lookupCheckpointStorageBinary = originalLookup }()
+	lookupCheckpointStorageBinary = func(name string) (string, error) { return "", os.ErrNotExist }
+
+	_, err := ValidateCheckpointStorage(context.Background(), &CheckpointConfig{
+		StorageType:      CheckpointStorageHDFS,
+		Namespace:        "/seatunnel/checkpoint",
+		HDFSNameNodeHost: "nn1",
+		HDFSNameNodePort: 8020,
+	})
+	if err != ErrHDFSClientNotFound {
+		t.Fatalf("expected ErrHDFSClientNotFound, got %v", err)
+	}
+}
+
+func TestObjectStorageRegion(t *testing.T) {
+	cases := []struct {
+		storageType CheckpointStorageType
+		host        string
+		want        string
+	}{
+		{CheckpointStorageS3, "s3.eu-west-1.amazonaws.com", "eu-west-1"},
+		{CheckpointStorageS3, "s3-us-west-2.amazonaws.com", "us-west-2"},
+		{CheckpointStorageS3, "s3.amazonaws.com", "us-east-1"},
+		{CheckpointStorageS3, "minio.local", "us-east-1"},
+		{CheckpointStorageOSS, "oss-cn-hangzhou-internal.aliyuncs.com", "oss-cn-hangzhou"},
+	}
+	for _, tc := range cases {
+		if got := objectStorageRegion(tc.storageType, tc.host); got != tc.want {
+			t.Errorf("objectStorageRegion(%s, %s) = %s, want %s", tc.storageType, tc.host, got, tc.want)
+		}
+	}
+}
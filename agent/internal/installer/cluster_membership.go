@@ -0,0 +1,308 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package installer
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// hazelcastClusterPath is the Hazelcast REST endpoint (CLUSTER_READ group) that lists cluster members.
+// hazelcastClusterPath 是列出集群成员的 Hazelcast REST 端点（CLUSTER_READ 组）。
+const hazelcastClusterPath = "/hazelcast/rest/cluster"
+
+const (
+	defaultMembershipTimeout  = 120 * time.Second
+	defaultMembershipInterval = 3 * time.Second
+	membershipLogTailLines    = 40
+)
+
+// ErrClusterMembershipTimeout indicates the node did not show up in the member list before the deadline.
+// ErrClusterMembershipTimeout 表示节点在截止时间前未出现在成员列表中。
+var ErrClusterMembershipTimeout = errors.New("node did not join the hazelcast cluster before timeout")
+
+// hazelcastMemberPattern matches lines like "Member [10.0.0.1]:5801 - <uuid> this".
+// hazelcastMemberPattern 匹配形如 "Member [10.0.0.1]:5801 - <uuid> this" 的行。
+var hazelcastMemberPattern = regexp.MustCompile(`Member \[([^\]]+)\]:(\d+)(?:\s+-\s+\S+)?(\s+this)?`)
+
+// ClusterMembershipParams describes which member to wait for and where to look for it.
+// ClusterMembershipParams 描述需要等待的成员以及查找位置。
+type ClusterMembershipParams struct {
+	// Address and Port identify the started node as other members see it.
+	// Address 和 Port 标识其他成员看到的已启动节点。
+	Address string
+	Port    int
+	// Seeds are host:port Hazelcast endpoints of members that were already running; when any is
+	// reachable the node must appear in its member list, otherwise the node's own endpoint is used.
+	// Seeds 是已在运行成员的 host:port Hazelcast 端点；只要有可达的种子，节点就必须出现在其成员列表中，
+	// 否则使用节点自身的端点。
+	Seeds      []string
+	InstallDir string
+	Timeout    time.Duration
+	Interval   time.Duration
+}
+
+// HazelcastMember is one entry of a Hazelcast member list.
+// HazelcastMember 是 Hazelcast 成员列表中的一项。
+type HazelcastMember struct {
+	Host  string `json:"host"`
+	Port  int    `json:"port"`
+	Local bool   `json:"local"`
+}
+
+// String formats the member as host:port.
+// String 将成员格式化为 host:port。
+func (m HazelcastMember) String() string {
+	return net.JoinHostPort(m.Host, strconv.Itoa(m.Port))
+}
+
+// ClusterMembershipResult reports the outcome of a membership verification.
+// ClusterMembershipResult 报告成员资格校验的结果。
+type ClusterMembershipResult struct {
+	Joined    bool     `json:"joined"`
+	Endpoint  string   `json:"endpoint,omitempty"`
+	Members   []string `json:"members,omitempty"`
+	Attempts  int      `json:"attempts"`
+	Elapsed   string   `json:"elapsed"`
+	LastError string   `json:"last_error,omitempty"`
+	// LogTail holds the last lines of the engine log when verification fails.
+	// LogTail 在校验失败时保存引擎日志的最后若干行。
+	LogTail []string `json:"log_tail,omitempty"`
+}
+
+// Diagnostics renders the failure details for command output.
+// Diagnostics 生成用于命令输出的失败详情。
+func (r *ClusterMembershipResult) Diagnostics() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "attempts=%d elapsed=%s", r.Attempts, r.Elapsed)
+	if r.Endpoint != "" {
+		fmt.Fprintf(&b, " endpoint=%s", r.Endpoint)
+	}
+	if len(r.Members) > 0 {
+		fmt.Fprintf(&b, " members=[%s]", strings.Join(r.Members, ", "))
+	}
+	if r.LastError != "" {
+		fmt.Fprintf(&b, " last_error=%s", r.LastError)
+	}
+	if len(r.LogTail) > 0 {
+		b.WriteString("\nengine log tail / 引擎日志末尾:\n")
+		b.WriteString(strings.Join(r.LogTail, "\n"))
+	}
+	return b.String()
+}
+
+// fetchHazelcastMembers is swapped in tests to avoid real HTTP calls.
+// fetchHazelcastMembers 在测试中被替换以避免真实的 HTTP 调用。
+var fetchHazelcastMembers = func(ctx context.Context, endpoint string) ([]HazelcastMember, error) {
+	reqCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(reqCtx, http.MethodGet, "http://"+endpoint+hazelcastClusterPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s returned HTTP %d", hazelcastClusterPath, resp.StatusCode)
+	}
+	members := parseHazelcastMembers(string(body))
+	if len(members) == 0 {
+		return nil, fmt.Errorf("%s returned no members", hazelcastClusterPath)
+	}
+	return members, nil
+}
+
+// parseHazelcastMembers extracts the member list from the Hazelcast cluster REST response.
+// parseHazelcastMembers 从 Hazelcast 集群 REST 响应中提取成员列表。
+func parseHazelcastMembers(body string) []HazelcastMember {
+	var members []HazelcastMember
+	for _, match := range hazelcastMemberPattern.FindAllStringSubmatch(body, -1) {
+		port, err := strconv.Atoi(match[2])
+		if err != nil {
+			continue
+		}
+		members = append(members, HazelcastMember{Host: match[1], Port: port, Local: match[3] != ""})
+	}
+	return members
+}
+
+// VerifyClusterMembership polls the Hazelcast member list until the node appears or the timeout
+// elapses. Reachable seeds must list the node; without them the node's own endpoint must report
+// itself as a member.
+// VerifyClusterMembership 轮询 Hazelcast 成员列表，直到节点出现或超时。可达的种子必须包含该节点；
+// 没有可达种子时，节点自身的端点必须将自己报告为成员。
+func VerifyClusterMembership(ctx context.Context, params ClusterMembershipParams) (*ClusterMembershipResult, error) {
+	if params.Port <= 0 {
+		return nil, errors.New("hazelcast port is required")
+	}
+	address := strings.TrimSpace(params.Address)
+	if address == "" {
+		address = "127.0.0.1"
+	}
+	timeout := params.Timeout
+	if timeout <= 0 {
+		timeout = defaultMembershipTimeout
+	}
+	interval := params.Interval
+	if interval <= 0 {
+		interval = defaultMembershipInterval
+	}
+	self := net.JoinHostPort(address, strconv.Itoa(params.Port))
+	seeds := make([]string, 0, len(params.Seeds))
+	for _, seed := range params.Seeds {
+		if seed = strings.TrimSpace(seed); seed != "" && seed != self {
+			seeds = append(seeds, seed)
+		}
+	}
+
+	start := time.Now()
+	deadline := start.Add(timeout)
+	result := &ClusterMembershipResult{}
+	for {
+		result.Attempts++
+		endpoint, members, err := lookupMembers(ctx, self, seeds)
+		result.Endpoint = endpoint
+		if err != nil {
+			result.LastError = err.Error()
+		} else {
+			result.LastError = ""
+			result.Members = formatMembers(members)
+			if containsMember(members, address, params.Port, endpoint == self) {
+				result.Joined = true
+				result.Elapsed = time.Since(start).Round(time.Millisecond).String()
+				return result, nil
+			}
+		}
+
+		wait := interval
+		if remaining := time.Until(deadline); remaining < wait {
+			wait = remaining
+		}
+		if wait <= 0 {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			result.Elapsed = time.Since(start).Round(time.Millisecond).String()
+			result.LogTail = tailEngineLog(params.InstallDir, membershipLogTailLines)
+			return result, ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+
+	result.Elapsed = time.Since(start).Round(time.Millisecond).String()
+	result.LogTail = tailEngineLog(params.InstallDir, membershipLogTailLines)
+	return result, fmt.Errorf("%w: %s not found after %s", ErrClusterMembershipTimeout, self, timeout)
+}
+
+// lookupMembers returns the member list of the first reachable seed, falling back to the node itself.
+// lookupMembers 返回第一个可达种子的成员列表，否则回退到节点自身。
+func lookupMembers(ctx context.Context, self string, seeds []string) (string, []HazelcastMember, error) {
+	var errs []string
+	for _, seed := range seeds {
+		members, err := fetchHazelcastMembers(ctx, seed)
+		if err == nil {
+			return seed, members, nil
+		}
+		errs = append(errs, fmt.Sprintf("%s: %v", seed, err))
+	}
+	members, err := fetchHazelcastMembers(ctx, self)
+	if err != nil {
+		errs = append(errs, fmt.Sprintf("%s: %v", self, err))
+		return self, nil, errors.New(strings.Join(errs, "; "))
+	}
+	return self, members, nil
+}
+
+// containsMember reports whether the node is in the list; the node's own endpoint may also identify
+// it by the local marker, since it can bind to an address other than the one the Control Plane knows.
+// containsMember 判断节点是否在列表中；节点自身端点也可通过本地标记识别，因为其绑定地址可能与 Control Plane 已知的不同。
+func containsMember(members []HazelcastMember, address string, port int, ownEndpoint bool) bool {
+	for _, member := range members {
+		if member.Port != port {
+			continue
+		}
+		if member.Host == address || (ownEndpoint && member.Local) {
+			return true
+		}
+	}
+	return false
+}
+
+func formatMembers(members []HazelcastMember) []string {
+	formatted := make([]string, 0, len(members))
+	for _, member := range members {
+		formatted = append(formatted, member.String())
+	}
+	return formatted
+}
+
+// tailEngineLog returns the last lines of the most recently written engine log under installDir/logs.
+// tailEngineLog 返回 installDir/logs 下最近写入的引擎日志的最后若干行。
+func tailEngineLog(installDir string, lines int) []string {
+	if installDir == "" {
+		return nil
+	}
+	matches, _ := filepath.Glob(filepath.Join(installDir, "logs", "seatunnel-engine-*.log"))
+	if len(matches) == 0 {
+		return nil
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		left, leftErr := os.Stat(matches[i])
+		right, rightErr := os.Stat(matches[j])
+		if leftErr != nil || rightErr != nil {
+			return leftErr == nil
+		}
+		return left.ModTime().After(right.ModTime())
+	})
+	file, err := os.Open(matches[0])
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+
+	tail := make([]string, 0, lines)
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		if len(tail) == lines {
+			tail = tail[1:]
+		}
+		tail = append(tail, scanner.Text())
+	}
+	return tail
+}
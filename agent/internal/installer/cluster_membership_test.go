@@ -0,0 +1,112 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package installer
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const hazelcastClusterResponse = `
+Members {size:2, ver:2} [
+	Member [10.0.0.1]:5801 - 6a4f7a52-2b0c-4c3e-9d6c-8f3f5b1e0a11
+	Member [10.0.0.2]:5801 - 0b8e5f7c-1d2a-4e6f-8a9b-7c6d5e4f3a22 this
+]
+
+ConnectionCount: 1
+AllConnectionCount: 2
+`
+
+func TestParseHazelcastMembers(t *testing.T) {
+	members := parseHazelcastMembers(hazelcastClusterResponse)
+	if len(members) != 2 {
+		t.Fatalf("expected 2 members, got %+v", members)
+	}
+	if members[0].String() != "10.0.0.1:5801" || members[0].Local {
+		t.Fatalf("unexpected first member: %+v", members[0])
+	}
+	if !members[1].Local || members[1].Host != "10.0.0.2" {
+		t.Fatalf("expected second member to be local, got %+v", members[1])
+	}
+}
+
+func TestVerifyClusterMembership_waitsForNodeInSeedMemberList(t *testing.T) {
+	calls := 0
+	original := fetchHazelcastMembers
+	fetchHazelcastMembers = func(ctx context.Context, endpoint string) ([]HazelcastMember, error) {
+		if endpoint != "10.0.0.1:5801" {
+			t.Fatalf("expected the seed to be queried, got %s", endpoint)
+		}
+		calls++
+		if calls < 3 {
+			return []HazelcastMember{{Host: "10.0.0.1", Port: 5801, Local: true}}, nil
+		}
+		return parseHazelcastMembers(hazelcastClusterResponse), nil
+	}
+	defer func() { fetchHazelcastMembers = original }()
+
+	result, err := VerifyClusterMembership(context.Background(), ClusterMembershipParams{
+		Address:  "10.0.0.2",
+		Port:     5801,
+		Seeds:    []string{"10.0.0.1:5801"},
+		Timeout:  time.Second,
+		Interval: time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("VerifyClusterMembership returned error: %v", err)
+	}
+	if !result.Joined || result.Attempts != 3 || result.Endpoint != "10.0.0.1:5801" {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+}
+
+func TestVerifyClusterMembership_timesOutWithDiagnostics(t *testing.T) {
+	installDir := t.TempDir()
+	logDir := filepath.Join(installDir, "logs")
+	if err := os.MkdirAll(logDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(logDir, "seatunnel-engine-server.log"), []byte("starting\nBindException: Address already in use\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	original := fetchHazelcastMembers
+	fetchHazelcastMembers = func(ctx context.Context, endpoint string) ([]HazelcastMember, error) {
+		return nil, errors.New("connection refused")
+	}
+	defer func() { fetchHazelcastMembers = original }()
+
+	result, err := VerifyClusterMembership(context.Background(), ClusterMembershipParams{
+		Address:    "10.0.0.2",
+		Port:       5801,
+		InstallDir: installDir,
+		Timeout:    20 * time.Millisecond,
+		Interval:   5 * time.Millisecond,
+	})
+	if !errors.Is(err, ErrClusterMembershipTimeout) {
+		t.Fatalf("expected ErrClusterMembershipTimeout, got %v", err)
+	}
+	diagnostics := result.Diagnostics()
+	if !strings.Contains(diagnostics, "connection refused") || !strings.Contains(diagnostics, "BindException") {
+		t.Fatalf("expected last error and log tail in diagnostics, got %q", diagnostics)
+	}
+}
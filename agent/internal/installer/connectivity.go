@@ -0,0 +1,84 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package installer
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// TCPProbeResult is the outcome of connecting to one cluster member address.
+// TCPProbeResult 是连接单个集群成员地址的结果。
+type TCPProbeResult struct {
+	// Target is the probed host:port / Target 是被探测的 host:port
+	Target string `json:"target"`
+	// Reachable is true when the host answered, either by accepting or by refusing the connection
+	// Reachable 表示主机有应答，无论是接受还是拒绝连接
+	Reachable bool `json:"reachable"`
+	// Listening is true when the TCP handshake completed / Listening 表示 TCP 握手完成
+	Listening bool `json:"listening"`
+	// LatencyMs is the time until the host answered / LatencyMs 是主机应答所用的时间
+	LatencyMs float64 `json:"latency_ms"`
+	// Error is the dial error, if any / Error 是拨号错误（如有）
+	Error string `json:"error,omitempty"`
+}
+
+// ProbeTCPTargets dials every target concurrently. A refused connection still proves the network
+// path, which matters before SeaTunnel is installed; timeouts point at firewalls or routing.
+// ProbeTCPTargets 并发拨号所有目标。连接被拒绝仍能证明网络路径可达，这在 SeaTunnel 安装前很重要；
+// 超时则通常意味着防火墙或路由问题。
+func ProbeTCPTargets(ctx context.Context, targets []string, timeout time.Duration) []TCPProbeResult {
+	results := make([]TCPProbeResult, len(targets))
+	var wg sync.WaitGroup
+	for i, target := range targets {
+		wg.Add(1)
+		go func(i int, target string) {
+			defer wg.Done()
+			results[i] = probeTCPTarget(ctx, target, timeout)
+		}(i, target)
+	}
+	wg.Wait()
+	return results
+}
+
+// probeTCPTarget dials a single target and classifies the outcome.
+// probeTCPTarget 拨号单个目标并对结果分类。
+func probeTCPTarget(ctx context.Context, target string, timeout time.Duration) TCPProbeResult {
+	result := TCPProbeResult{Target: target}
+	dialer := net.Dialer{Timeout: timeout}
+	start := time.Now()
+	conn, err := dialer.DialContext(ctx, "tcp", target)
+	elapsed := time.Since(start)
+	if err == nil {
+		_ = conn.Close()
+		result.Reachable = true
+		result.Listening = true
+		result.LatencyMs = float64(elapsed.Microseconds()) / 1000
+		return result
+	}
+	result.Error = err.Error()
+	if errors.Is(err, syscall.ECONNREFUSED) {
+		result.Reachable = true
+		result.LatencyMs = float64(elapsed.Microseconds()) / 1000
+	}
+	return result
+}
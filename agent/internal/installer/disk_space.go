@@ -0,0 +1,180 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package installer
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+)
+
+// DefaultPackageExpansionFactor is the ratio of space needed in the install directory to the
+// compressed package size: extracted files plus connectors and plugin jars downloaded later.
+// DefaultPackageExpansionFactor 是安装目录所需空间与压缩包大小之比：
+// 包括解压后的文件以及之后下载的连接器和插件 jar。
+const DefaultPackageExpansionFactor = 3.0
+
+// diskUsage describes the filesystem that holds a path
+// diskUsage 描述某个路径所在的文件系统
+type diskUsage struct {
+	// device identifies the filesystem, used to detect paths sharing one filesystem
+	// device 标识文件系统，用于识别共享同一文件系统的路径
+	device string
+
+	// availableBytes is the space available to unprivileged users
+	// availableBytes 是非特权用户可用的空间
+	availableBytes int64
+}
+
+// DiskSpaceTarget is the space check result of one path
+// DiskSpaceTarget 是单个路径的空间检查结果
+type DiskSpaceTarget struct {
+	// Path is the checked path
+	// Path 是被检查的路径
+	Path string `json:"path"`
+
+	// RequiredBytes is the space this path needs on its own, excluding any path it shares a filesystem with
+	// RequiredBytes 是该路径自身所需空间，不含共享同一文件系统的其他路径
+	RequiredBytes int64 `json:"required_bytes"`
+
+	// AvailableBytes is the space available on the filesystem of the path
+	// AvailableBytes 是该路径所在文件系统的可用空间
+	AvailableBytes int64 `json:"available_bytes"`
+
+	// SharedWith is the other checked path on the same filesystem, if any
+	// SharedWith 是位于同一文件系统的另一个被检查路径（如有）
+	SharedWith string `json:"shared_with,omitempty"`
+}
+
+// DiskSpaceCheckResult is the result of a package disk space check
+// DiskSpaceCheckResult 是安装包磁盘空间检查的结果
+type DiskSpaceCheckResult struct {
+	// Success is true when every target has enough space
+	// Success 为 true 表示所有目标空间充足
+	Success bool `json:"success"`
+
+	// Message is a human-readable description of the result
+	// Message 是结果的人类可读描述
+	Message string `json:"message"`
+
+	// Targets are the checked paths (install dir, then temp dir)
+	// Targets 是被检查的路径（先安装目录，后临时目录）
+	Targets []DiskSpaceTarget `json:"targets"`
+}
+
+// RequiredInstallBytes returns the space needed in the install directory for a package,
+// saturating at math.MaxInt64 for sizes that would overflow.
+// RequiredInstallBytes 返回安装包在安装目录中所需的空间，溢出时取 math.MaxInt64。
+func RequiredInstallBytes(packageSize int64, expansionFactor float64) int64 {
+	if expansionFactor <= 0 {
+		expansionFactor = DefaultPackageExpansionFactor
+	}
+	required := float64(packageSize) * expansionFactor
+	if required >= math.MaxInt64 {
+		return math.MaxInt64
+	}
+	return int64(required)
+}
+
+// CheckPackageDiskSpace checks that installDir can hold the extracted package and that tempDir
+// can hold the package archive. When both are on one filesystem the install directory must also
+// leave room for the archive.
+// CheckPackageDiskSpace 检查 installDir 能否容纳解压后的安装包，tempDir 能否容纳安装包文件。
+// 两者位于同一文件系统时，安装目录还需为安装包文件预留空间。
+func CheckPackageDiskSpace(installDir, tempDir string, packageSize int64, expansionFactor float64) (*DiskSpaceCheckResult, error) {
+	if packageSize <= 0 {
+		return nil, fmt.Errorf("invalid package size: %d", packageSize)
+	}
+	if tempDir == "" {
+		tempDir = os.TempDir()
+	}
+
+	installUsage, err := statDiskUsage(installDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat filesystem of %s: %w", installDir, err)
+	}
+	tempUsage, err := statDiskUsage(tempDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat filesystem of %s: %w", tempDir, err)
+	}
+
+	install := DiskSpaceTarget{
+		Path:           installDir,
+		RequiredBytes:  RequiredInstallBytes(packageSize, expansionFactor),
+		AvailableBytes: installUsage.availableBytes,
+	}
+	temp := DiskSpaceTarget{
+		Path:           tempDir,
+		RequiredBytes:  packageSize,
+		AvailableBytes: tempUsage.availableBytes,
+	}
+	// reserved is the space another checked path takes from the same filesystem
+	// reserved 是同一文件系统上其他被检查路径占用的空间
+	var reserved int64
+	if installUsage.device == tempUsage.device {
+		reserved = temp.RequiredBytes
+		install.SharedWith = tempDir
+		temp.SharedWith = installDir
+	}
+
+	result := &DiskSpaceCheckResult{
+		Success: true,
+		Targets: []DiskSpaceTarget{install, temp},
+	}
+	for _, check := range []struct {
+		target   DiskSpaceTarget
+		reserved int64
+	}{{install, reserved}, {temp, 0}} {
+		target := check.target
+		// Compare without adding the requirements up so huge packages cannot overflow int64
+		// 不将所需空间相加再比较，避免超大安装包导致 int64 溢出
+		if target.AvailableBytes < target.RequiredBytes || target.AvailableBytes-target.RequiredBytes < check.reserved {
+			result.Success = false
+			result.Message = fmt.Sprintf(
+				"Insufficient disk space on %s: available %d MB < required %d MB + %d MB shared / %s 磁盘空间不足：可用 %d MB < 所需 %d MB + 共享 %d MB",
+				target.Path, target.AvailableBytes/(1024*1024), target.RequiredBytes/(1024*1024), check.reserved/(1024*1024),
+				target.Path, target.AvailableBytes/(1024*1024), target.RequiredBytes/(1024*1024), check.reserved/(1024*1024),
+			)
+			return result, nil
+		}
+	}
+	result.Message = fmt.Sprintf(
+		"Disk space is sufficient: %s requires %d MB, %s requires %d MB / 磁盘空间充足：%s 需要 %d MB，%s 需要 %d MB",
+		install.Path, install.RequiredBytes/(1024*1024), temp.Path, temp.RequiredBytes/(1024*1024),
+		install.Path, install.RequiredBytes/(1024*1024), temp.Path, temp.RequiredBytes/(1024*1024),
+	)
+	return result, nil
+}
+
+// nearestExistingPath returns path or its closest existing ancestor, since the install
+// directory usually does not exist before installation.
+// nearestExistingPath 返回 path 或其最近的已存在祖先目录，因为安装目录在安装前通常不存在。
+func nearestExistingPath(path string) string {
+	current := filepath.Clean(path)
+	for {
+		if _, err := os.Stat(current); err == nil {
+			return current
+		}
+		parent := filepath.Dir(current)
+		if parent == current {
+			return current
+		}
+		current = parent
+	}
+}
@@ -0,0 +1,87 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package installer
+
+import (
+	"math"
+	"path/filepath"
+	"testing"
+)
+
+// TestRequiredInstallBytes tests the install dir requirement calculation.
+// TestRequiredInstallBytes 测试安装目录所需空间计算。
+func TestRequiredInstallBytes(t *testing.T) {
+	if got := RequiredInstallBytes(100, 2.5); got != 250 {
+		t.Errorf("Expected 250, got %d", got)
+	}
+	if got := RequiredInstallBytes(100, 0); got != 300 {
+		t.Errorf("Expected default factor to give 300, got %d", got)
+	}
+}
+
+// TestCheckPackageDiskSpace tests the disk space check on a shared filesystem.
+// TestCheckPackageDiskSpace 测试共享文件系统上的磁盘空间检查。
+func TestCheckPackageDiskSpace(t *testing.T) {
+	base := t.TempDir()
+	installDir := filepath.Join(base, "not", "created", "seatunnel")
+	tempDir := filepath.Join(base, "tmp")
+
+	result, err := CheckPackageDiskSpace(installDir, tempDir, 1024, 3)
+	if err != nil {
+		t.Fatalf("CheckPackageDiskSpace failed: %v", err)
+	}
+	if !result.Success {
+		t.Fatalf("Expected 1KB package to fit, got: %s", result.Message)
+	}
+	install, temp := result.Targets[0], result.Targets[1]
+	if install.SharedWith != tempDir || temp.SharedWith != installDir {
+		t.Errorf("Expected both paths on the same filesystem, got %+v", result.Targets)
+	}
+	if install.RequiredBytes != 3072 || temp.RequiredBytes != 1024 {
+		t.Errorf("Expected separate requirements of 3072 and 1024 bytes, got %d and %d", install.RequiredBytes, temp.RequiredBytes)
+	}
+
+	result, err = CheckPackageDiskSpace(installDir, tempDir, 1<<62, 1)
+	if err != nil {
+		t.Fatalf("CheckPackageDiskSpace failed: %v", err)
+	}
+	if result.Success {
+		t.Error("Expected huge package to fail the disk space check")
+	}
+
+	result, err = CheckPackageDiskSpace(installDir, tempDir, math.MaxInt64/2, 3)
+	if err != nil {
+		t.Fatalf("CheckPackageDiskSpace failed: %v", err)
+	}
+	if result.Success || result.Targets[0].RequiredBytes != math.MaxInt64 {
+		t.Errorf("Expected overflowing requirement to saturate and fail, got %+v", result)
+	}
+
+	if _, err := CheckPackageDiskSpace(installDir, tempDir, 0, 3); err == nil {
+		t.Error("Expected error for zero package size")
+	}
+}
+
+// TestNearestExistingPath tests resolving a missing path to its existing ancestor.
+// TestNearestExistingPath 测试将不存在的路径解析为其已存在的祖先目录。
+func TestNearestExistingPath(t *testing.T) {
+	base := t.TempDir()
+	if got := nearestExistingPath(filepath.Join(base, "a", "b")); got != base {
+		t.Errorf("Expected %s, got %s", base, got)
+	}
+}
@@ -0,0 +1,39 @@
+//go:build !windows
+// +build !windows
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package installer
+
+import (
+	"fmt"
+	"syscall"
+)
+
+// statDiskUsage returns the filesystem usage of path using statfs.
+// statDiskUsage 使用 statfs 返回 path 所在文件系统的使用情况。
+func statDiskUsage(path string) (*diskUsage, error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(nearestExistingPath(path), &stat); err != nil {
+		return nil, err
+	}
+	return &diskUsage{
+		device:         fmt.Sprintf("%v", stat.Fsid),
+		availableBytes: int64(stat.Bavail) * int64(stat.Bsize),
+	}, nil
+}
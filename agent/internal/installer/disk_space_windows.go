@@ -0,0 +1,48 @@
+//go:build windows
+// +build windows
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package installer
+
+import (
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/sys/windows"
+)
+
+// statDiskUsage returns the free space of the drive holding path.
+// Windows has no statfs, so the drive letter identifies the filesystem.
+// statDiskUsage 返回 path 所在驱动器的可用空间。
+// Windows 没有 statfs，因此以驱动器盘符标识文件系统。
+func statDiskUsage(path string) (*diskUsage, error) {
+	path = nearestExistingPath(path)
+	dir, err := windows.UTF16PtrFromString(path)
+	if err != nil {
+		return nil, err
+	}
+	var freeBytesAvailable, totalBytes, totalFreeBytes uint64
+	if err := windows.GetDiskFreeSpaceEx(dir, &freeBytesAvailable, &totalBytes, &totalFreeBytes); err != nil {
+		return nil, err
+	}
+	return &diskUsage{
+		device:         strings.ToUpper(filepath.VolumeName(path)),
+		availableBytes: int64(freeBytesAvailable),
+	}, nil
+}
@@ -0,0 +1,363 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package installer
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// DryRunTemplateParamPrefix prefixes command parameters that carry config templates for a dry run
+// DryRunTemplateParamPrefix 是试运行时携带配置模板的命令参数前缀
+const DryRunTemplateParamPrefix = "config_template."
+
+// maxDryRunTemplateBytes caps the size of a single config template read from a package
+// maxDryRunTemplateBytes 限制从安装包读取的单个配置模板大小
+const maxDryRunTemplateBytes = 1 << 20
+
+// Template sources reported by a dry run
+// 试运行报告的模板来源
+const (
+	DryRunTemplatesFromRequest  = "request"
+	DryRunTemplatesFromPackage  = "package"
+	DryRunTemplatesFromExisting = "existing_install"
+)
+
+// Package sources reported by a dry run
+// 试运行报告的安装包来源
+const (
+	DryRunPackageLocal  = "agent_local"
+	DryRunPackageMirror = "mirror"
+)
+
+// DryRunResult describes what an installation would do without touching the install directory
+// DryRunResult 描述一次安装将执行的内容，但不修改安装目录
+type DryRunResult struct {
+	// Valid is true when parameters, prechecks and package checks all passed
+	// Valid 为 true 表示参数、预检查和安装包检查均通过
+	Valid bool `json:"valid"`
+
+	// Errors lists blocking problems
+	// Errors 列出阻塞性问题
+	Errors []string `json:"errors,omitempty"`
+
+	// Warnings lists non-blocking problems
+	// Warnings 列出非阻塞性问题
+	Warnings []string `json:"warnings,omitempty"`
+
+	// Precheck is the Agent-side environment precheck result
+	// Precheck 是 Agent 侧环境预检查结果
+	Precheck *PrecheckResult `json:"precheck,omitempty"`
+
+	// PackageSource is where the package would come from (agent_local or mirror)
+	// PackageSource 是安装包来源（agent_local 或 mirror）
+	PackageSource string `json:"package_source,omitempty"`
+
+	// PackageLocation is the local path or download URL of the package
+	// PackageLocation 是安装包的本地路径或下载 URL
+	PackageLocation string `json:"package_location,omitempty"`
+
+	// TemplateSource is where the config templates were taken from
+	// TemplateSource 是配置模板的来源
+	TemplateSource string `json:"template_source,omitempty"`
+
+	// RenderedFiles maps install-dir relative paths to the content that would be written
+	// RenderedFiles 将相对安装目录的路径映射到将要写入的内容
+	RenderedFiles map[string]string `json:"rendered_files,omitempty"`
+}
+
+func (r *DryRunResult) addError(format string, args ...interface{}) {
+	r.Errors = append(r.Errors, fmt.Sprintf(format, args...))
+	r.Valid = false
+}
+
+func (r *DryRunResult) addWarning(format string, args ...interface{}) {
+	r.Warnings = append(r.Warnings, fmt.Sprintf(format, args...))
+}
+
+// DryRun validates params, runs prechecks, checks package availability and renders the config files
+// that an installation would write. Templates may be supplied by the caller; otherwise they are read
+// from the local package or an existing installation. Nothing under params.InstallDir is modified.
+// DryRun 校验参数、执行预检查、检查安装包可用性并渲染安装将写入的配置文件。
+// 模板可由调用方提供，否则从本地安装包或已有安装读取。不会修改 params.InstallDir 下的任何内容。
+func (m *InstallerManager) DryRun(ctx context.Context, params *InstallParams, templates map[string]string) *DryRunResult {
+	result := &DryRunResult{Valid: true}
+
+	if err := params.Validate(); err != nil {
+		result.addError("Invalid parameters: %v / 无效参数：%v", err, err)
+		return result
+	}
+
+	result.Precheck = m.dryRunPrecheck(ctx, params)
+	if result.Precheck != nil && result.Precheck.OverallStatus == CheckStatusFailed {
+		result.addError("Precheck failed: %s / 预检查失败：%s", result.Precheck.Summary, result.Precheck.Summary)
+	}
+
+	m.dryRunCheckPackage(ctx, params, result)
+
+	if len(templates) > 0 {
+		result.TemplateSource = DryRunTemplatesFromRequest
+	} else {
+		templates, result.TemplateSource = loadDryRunTemplates(params)
+	}
+	if len(templates) == 0 {
+		result.addWarning("No config templates available, rendering skipped / 没有可用的配置模板，已跳过渲染")
+		return result
+	}
+
+	rendered, err := m.renderDryRunConfigs(params, templates)
+	if err != nil {
+		result.addError("Failed to render configs: %v / 渲染配置失败：%v", err, err)
+		return result
+	}
+	result.RenderedFiles = rendered
+
+	if (params.Checkpoint != nil && params.Checkpoint.StorageType == CheckpointStorageOSS) ||
+		(params.IMAP != nil && params.IMAP.StorageType == IMAPStorageOSS) {
+		result.addWarning("OSS runtime libraries would be downloaded into %s / OSS 运行时依赖将下载到 %s",
+			filepath.Join(params.InstallDir, "lib"), filepath.Join(params.InstallDir, "lib"))
+	}
+	return result
+}
+
+func (m *InstallerManager) dryRunPrecheck(ctx context.Context, params *InstallParams) *PrecheckResult {
+	precheckParams := DefaultPrecheckParams()
+	precheckParams.InstallDir = params.InstallDir
+	precheckParams.ClusterPort = params.ClusterPort
+	precheckParams.Ports = []int{params.ClusterPort}
+	if params.DeploymentMode == DeploymentModeSeparated && params.WorkerPort > 0 {
+		precheckParams.Ports = append(precheckParams.Ports, params.WorkerPort)
+	}
+	if params.EnableHTTP == nil || *params.EnableHTTP {
+		precheckParams.Ports = append(precheckParams.Ports, params.HTTPPort)
+	}
+
+	result, err := NewPrechecker(precheckParams).RunAll(ctx)
+	if err != nil {
+		return &PrecheckResult{
+			OverallStatus: CheckStatusFailed,
+			Summary:       err.Error(),
+		}
+	}
+	return result
+}
+
+func (m *InstallerManager) dryRunCheckPackage(ctx context.Context, params *InstallParams, result *DryRunResult) {
+	if params.PackagePath != "" {
+		result.PackageSource = DryRunPackageLocal
+		result.PackageLocation = params.PackagePath
+		if _, err := os.Stat(params.PackagePath); err != nil {
+			result.addError("Package not found on Agent: %s / Agent 上未找到安装包：%s", params.PackagePath, params.PackagePath)
+		}
+		return
+	}
+
+	downloadURL := params.GetDownloadURLWithMirror()
+	result.PackageSource = DryRunPackageMirror
+	result.PackageLocation = downloadURL
+
+	probeCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(probeCtx, http.MethodHead, downloadURL, nil)
+	if err != nil {
+		result.addError("Invalid download URL %s: %v / 下载地址无效 %s：%v", downloadURL, err, downloadURL, err)
+		return
+	}
+	resp, err := m.httpClient.Do(req)
+	if err != nil {
+		result.addWarning("Mirror is not reachable from Agent, package must be transferred by the Control Plane: %v / Agent 无法访问镜像源，需由控制平面传输安装包：%v", err, err)
+		return
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		result.addWarning("Mirror returned HTTP %d for %s / 镜像源对 %s 返回 HTTP %d", resp.StatusCode, downloadURL, downloadURL, resp.StatusCode)
+	}
+}
+
+// loadDryRunTemplates reads config templates from the local package or an existing installation
+// loadDryRunTemplates 从本地安装包或已有安装中读取配置模板
+func loadDryRunTemplates(params *InstallParams) (map[string]string, string) {
+	if params.PackagePath != "" {
+		if templates, err := ReadPackageConfigTemplates(params.PackagePath); err == nil && len(templates) > 0 {
+			return templates, DryRunTemplatesFromPackage
+		}
+	}
+
+	configDir := filepath.Join(params.InstallDir, "config")
+	entries, err := os.ReadDir(configDir)
+	if err != nil {
+		return nil, ""
+	}
+	templates := make(map[string]string)
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() || strings.HasSuffix(entry.Name(), ".bak") {
+			continue
+		}
+		content, err := os.ReadFile(filepath.Join(configDir, entry.Name()))
+		if err != nil || len(content) > maxDryRunTemplateBytes {
+			continue
+		}
+		templates[entry.Name()] = string(content)
+	}
+	return templates, DryRunTemplatesFromExisting
+}
+
+// ReadPackageConfigTemplates reads the top-level files of the config directory from a tar.gz or zip package
+// ReadPackageConfigTemplates 从 tar.gz 或 zip 安装包读取 config 目录下的顶层文件
+func ReadPackageConfigTemplates(packagePath string) (map[string]string, error) {
+	templates := make(map[string]string)
+	collect := func(name string, size int64, open func() (io.ReadCloser, error)) error {
+		name, ok := configTemplateName(name)
+		if !ok || size > maxDryRunTemplateBytes {
+			return nil
+		}
+		reader, err := open()
+		if err != nil {
+			return err
+		}
+		defer reader.Close()
+		content, err := io.ReadAll(io.LimitReader(reader, maxDryRunTemplateBytes))
+		if err != nil {
+			return err
+		}
+		templates[name] = string(content)
+		return nil
+	}
+
+	if isZipPackage(packagePath) {
+		archive, err := zip.OpenReader(packagePath)
+		if err != nil {
+			return nil, err
+		}
+		defer archive.Close()
+		for _, file := range archive.File {
+			if file.FileInfo().IsDir() {
+				continue
+			}
+			if err := collect(file.Name, int64(file.UncompressedSize64), file.Open); err != nil {
+				return nil, err
+			}
+		}
+		return templates, nil
+	}
+
+	file, err := os.Open(packagePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	gzReader, err := gzip.NewReader(file)
+	if err != nil {
+		return nil, err
+	}
+	defer gzReader.Close()
+
+	tarReader := tar.NewReader(gzReader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		open := func() (io.ReadCloser, error) { return io.NopCloser(tarReader), nil }
+		if err := collect(header.Name, header.Size, open); err != nil {
+			return nil, err
+		}
+	}
+	return templates, nil
+}
+
+// configTemplateName returns the file name for entries shaped like <root>/config/<file>
+// configTemplateName 对形如 <root>/config/<file> 的条目返回文件名
+func configTemplateName(entryName string) (string, bool) {
+	relative := stripFirstComponent(path.Clean(filepath.ToSlash(entryName)))
+	dir, name := path.Split(relative)
+	if dir != "config/" || name == "" {
+		return "", false
+	}
+	return name, true
+}
+
+// renderDryRunConfigs applies the configure steps to a throwaway copy of the templates
+// renderDryRunConfigs 在模板的临时副本上执行配置步骤
+func (m *InstallerManager) renderDryRunConfigs(params *InstallParams, templates map[string]string) (map[string]string, error) {
+	stagingDir, err := os.MkdirTemp("", "seatunnelx-dry-run-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(stagingDir)
+
+	configDir := filepath.Join(stagingDir, "config")
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		return nil, err
+	}
+	for name, content := range templates {
+		if name != filepath.Base(name) || name == "." || name == ".." {
+			return nil, fmt.Errorf("invalid template name %q", name)
+		}
+		if err := os.WriteFile(filepath.Join(configDir, name), []byte(content), 0644); err != nil {
+			return nil, err
+		}
+	}
+
+	staged := *params
+	staged.InstallDir = stagingDir
+	if _, err := m.ConfigureCluster(&staged); err != nil {
+		return nil, err
+	}
+	if staged.Checkpoint != nil {
+		if err := writeCheckpointStorageConfig(&staged); err != nil {
+			return nil, err
+		}
+	}
+	if staged.IMAP != nil {
+		if err := writeIMAPStorageConfig(&staged); err != nil {
+			return nil, err
+		}
+	}
+	if err := m.configureJVM(&staged); err != nil {
+		return nil, err
+	}
+
+	rendered := make(map[string]string)
+	for name, template := range templates {
+		content, err := os.ReadFile(filepath.Join(configDir, name))
+		if err != nil {
+			return nil, err
+		}
+		if string(content) != template {
+			rendered[path.Join("config", name)] = string(content)
+		}
+	}
+	return rendered, nil
+}
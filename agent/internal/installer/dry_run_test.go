@@ -0,0 +1,125 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package installer
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func dryRunTemplates() map[string]string {
+	return map[string]string{
+		"hazelcast.yaml": `hazelcast:
+  cluster-name: seatunnel
+  network:
+    join:
+      tcp-ip:
+        enabled: true
+        member-list:
+          - localhost
+    port:
+      auto-increment: false
+      port: 5801
+`,
+		"hazelcast-client.yaml": `hazelcast-client:
+  cluster-name: seatunnel
+  network:
+    cluster-members:
+      - localhost:5801
+`,
+		"seatunnel.yaml": `seatunnel:
+  engine:
+    http:
+      enable-http: false
+      port: 8080
+`,
+		"jvm_options": "# JVM Heap\n-Xms2g\n-Xmx2g\n",
+	}
+}
+
+func TestRenderDryRunConfigs(t *testing.T) {
+	installDir := filepath.Join(t.TempDir(), "seatunnel")
+	params := &InstallParams{
+		Version:         "2.3.12",
+		InstallDir:      installDir,
+		DeploymentMode:  DeploymentModeHybrid,
+		NodeRole:        NodeRoleMasterWorker,
+		ClusterPort:     5801,
+		HTTPPort:        8080,
+		MasterAddresses: []string{"10.0.0.1", "10.0.0.2"},
+		JVM:             &JVMConfig{HybridHeapSize: 4},
+	}
+
+	rendered, err := NewInstallerManager().renderDryRunConfigs(params, dryRunTemplates())
+	if err != nil {
+		t.Fatalf("renderDryRunConfigs returned error: %v", err)
+	}
+
+	hazelcast, ok := rendered["config/hazelcast.yaml"]
+	if !ok || !strings.Contains(hazelcast, "10.0.0.2:5801") {
+		t.Fatalf("expected hazelcast.yaml to list cluster members, got %q", hazelcast)
+	}
+	if jvm := rendered["config/jvm_options"]; !strings.Contains(jvm, "-Xmx4g") {
+		t.Fatalf("expected jvm_options to set 4g heap, got %q", jvm)
+	}
+	if _, err := os.Stat(installDir); !os.IsNotExist(err) {
+		t.Fatalf("expected dry run to leave install dir untouched, stat err=%v", err)
+	}
+}
+
+func TestReadPackageConfigTemplates(t *testing.T) {
+	packagePath := filepath.Join(t.TempDir(), "apache-seatunnel-2.3.12-bin.tar.gz")
+	file, err := os.Create(packagePath)
+	if err != nil {
+		t.Fatalf("failed to create package: %v", err)
+	}
+	gzWriter := gzip.NewWriter(file)
+	tarWriter := tar.NewWriter(gzWriter)
+	entries := map[string]string{
+		"apache-seatunnel-2.3.12/config/seatunnel.yaml":      "seatunnel: {}\n",
+		"apache-seatunnel-2.3.12/config/v2.batch.config":     "env {}\n",
+		"apache-seatunnel-2.3.12/config/nested/ignored.yaml": "ignored: true\n",
+		"apache-seatunnel-2.3.12/bin/seatunnel.sh":           "#!/bin/sh\n",
+	}
+	for name, content := range entries {
+		if err := tarWriter.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatalf("failed to write header: %v", err)
+		}
+		if _, err := tarWriter.Write([]byte(content)); err != nil {
+			t.Fatalf("failed to write entry: %v", err)
+		}
+	}
+	tarWriter.Close()
+	gzWriter.Close()
+	file.Close()
+
+	templates, err := ReadPackageConfigTemplates(packagePath)
+	if err != nil {
+		t.Fatalf("ReadPackageConfigTemplates returned error: %v", err)
+	}
+	if len(templates) != 2 {
+		t.Fatalf("expected 2 top-level config templates, got %v", templates)
+	}
+	if templates["seatunnel.yaml"] != "seatunnel: {}\n" {
+		t.Fatalf("unexpected seatunnel.yaml template: %q", templates["seatunnel.yaml"])
+	}
+}
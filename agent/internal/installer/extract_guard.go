@@ -0,0 +1,190 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package installer
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// MaxExtractedBytes bounds the total uncompressed size of one package
+	// MaxExtractedBytes 限制单个安装包解压后的总大小
+	MaxExtractedBytes int64 = 8 << 30
+
+	// MaxExtractedFiles bounds the number of entries written from one package
+	// MaxExtractedFiles 限制单个安装包解压出的条目数量
+	MaxExtractedFiles = 200000
+)
+
+var (
+	// ErrArchiveTooLarge indicates a package exceeds the extracted size or file count limits
+	// ErrArchiveTooLarge 表示安装包超出解压大小或文件数量限制
+	ErrArchiveTooLarge = errors.New("archive exceeds extraction limits")
+
+	// ErrUnsafeSymlink indicates a symlink whose target is absolute or escapes the destination
+	// ErrUnsafeSymlink 表示目标为绝对路径或越出目标目录的符号链接
+	ErrUnsafeSymlink = errors.New("unsafe symlink target")
+)
+
+// extractBudget tracks how much of the extraction limits a package has used
+// extractBudget 记录安装包已使用的解压限额
+type extractBudget struct {
+	maxBytes int64
+	maxFiles int
+	bytes    int64
+	files    int
+}
+
+// newExtractBudget returns a budget with the default extraction limits
+// newExtractBudget 返回使用默认解压限制的额度
+func newExtractBudget() *extractBudget {
+	return &extractBudget{maxBytes: MaxExtractedBytes, maxFiles: MaxExtractedFiles}
+}
+
+// addEntry counts one more entry against the file limit
+// addEntry 将一个条目计入文件数量限制
+func (b *extractBudget) addEntry(name string) error {
+	b.files++
+	if b.files > b.maxFiles {
+		return fmt.Errorf("%w: %w: more than %d entries (at %s)", ErrExtractionFailed, ErrArchiveTooLarge, b.maxFiles, name)
+	}
+	return nil
+}
+
+// copy writes src to dst, failing once the total extracted size would exceed the limit.
+// Declared sizes are not trusted; the limit applies to the bytes actually decompressed.
+// copy 将 src 写入 dst，解压总大小超过限制时失败；不信任声明的大小，按实际解压的字节计算。
+func (b *extractBudget) copy(dst io.Writer, src io.Reader, name string) error {
+	remaining := b.maxBytes - b.bytes
+	written, err := io.Copy(dst, io.LimitReader(src, remaining+1))
+	b.bytes += written
+	if err != nil {
+		return fmt.Errorf("%w: failed to write file: %v", ErrExtractionFailed, err)
+	}
+	if written > remaining {
+		return fmt.Errorf("%w: %w: more than %d bytes (at %s)", ErrExtractionFailed, ErrArchiveTooLarge, b.maxBytes, name)
+	}
+	return nil
+}
+
+// safeFileMode keeps only the permission bits of an archive entry, dropping setuid, setgid and sticky bits
+// safeFileMode 仅保留归档条目的权限位，去除 setuid、setgid 与 sticky 位
+func safeFileMode(mode os.FileMode, fallback os.FileMode) os.FileMode {
+	perm := mode.Perm()
+	if perm == 0 {
+		return fallback
+	}
+	return perm
+}
+
+// withinDir reports whether path is dir itself or inside it
+// withinDir 判断 path 是否为 dir 本身或位于其中
+func withinDir(dir, path string) bool {
+	dir = filepath.Clean(dir)
+	path = filepath.Clean(path)
+	return path == dir || strings.HasPrefix(path, dir+string(os.PathSeparator))
+}
+
+// physicalPath resolves the symlinks of the existing part of path and appends the part that does not exist yet
+// physicalPath 解析 path 中已存在部分的符号链接，并拼接尚不存在的部分
+func physicalPath(path string) (string, error) {
+	existing := filepath.Clean(path)
+	rest := ""
+	for {
+		if _, err := os.Lstat(existing); err == nil {
+			break
+		} else if !os.IsNotExist(err) {
+			return "", err
+		}
+		parent := filepath.Dir(existing)
+		if parent == existing {
+			break
+		}
+		rest = filepath.Join(filepath.Base(existing), rest)
+		existing = parent
+	}
+	resolved, err := filepath.EvalSymlinks(existing)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(resolved, rest), nil
+}
+
+// ensureWithinDir rejects path when it resolves outside destDir through symlinks already extracted on disk
+// ensureWithinDir 在 path 经由已解压到磁盘的符号链接解析到 destDir 之外时拒绝
+func ensureWithinDir(destDir, path string) error {
+	realDest, err := physicalPath(destDir)
+	if err != nil {
+		return fmt.Errorf("%w: failed to resolve destination: %v", ErrExtractionFailed, err)
+	}
+	realPath, err := physicalPath(path)
+	if err != nil || !withinDir(realDest, realPath) {
+		return fmt.Errorf("%w: %w: %s resolves outside %s", ErrExtractionFailed, ErrUnsafeSymlink, path, destDir)
+	}
+	return nil
+}
+
+// ensureRegularTarget rejects writing a file over an existing symlink, which would write through the link
+// ensureRegularTarget 拒绝在已有符号链接上写入文件，否则会经由链接写入
+func ensureRegularTarget(destDir, path string) error {
+	if err := ensureWithinDir(destDir, filepath.Dir(path)); err != nil {
+		return err
+	}
+	if info, err := os.Lstat(path); err == nil && info.Mode()&os.ModeSymlink != 0 {
+		return fmt.Errorf("%w: %w: %s is a symlink", ErrExtractionFailed, ErrUnsafeSymlink, path)
+	}
+	return nil
+}
+
+// validateSymlinkTarget rejects symlinks that are absolute or resolve outside destDir.
+// The target is resolved against the physical parent, so links created by earlier entries cannot be chained out.
+// validateSymlinkTarget 拒绝绝对路径或解析后位于 destDir 之外的符号链接；
+// 目标按父目录的实际路径解析，因此无法借助先前条目创建的链接串联越界。
+func validateSymlinkTarget(destDir, linkPath, target string) error {
+	unsafe := fmt.Errorf("%w: %w: %s -> %s", ErrExtractionFailed, ErrUnsafeSymlink, linkPath, target)
+	if target == "" || filepath.IsAbs(target) || strings.HasPrefix(filepath.ToSlash(target), "/") {
+		return unsafe
+	}
+	resolved := filepath.Join(filepath.Dir(linkPath), filepath.FromSlash(target))
+	if !withinDir(destDir, resolved) {
+		return unsafe
+	}
+
+	realDest, err := physicalPath(destDir)
+	if err != nil {
+		return fmt.Errorf("%w: failed to resolve destination: %v", ErrExtractionFailed, err)
+	}
+	realParent, err := physicalPath(filepath.Dir(linkPath))
+	if err != nil || !withinDir(realDest, realParent) {
+		return unsafe
+	}
+	if !withinDir(realDest, filepath.Join(realParent, filepath.FromSlash(target))) {
+		return unsafe
+	}
+	// Targets that already exist are resolved physically, since ".." after a symlink follows the link
+	// 已存在的目标按实际路径解析，因为符号链接之后的 ".." 会跟随链接
+	if real, err := filepath.EvalSymlinks(realParent + string(os.PathSeparator) + filepath.FromSlash(target)); err == nil && !withinDir(realDest, real) {
+		return unsafe
+	}
+	return nil
+}
@@ -0,0 +1,213 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package installer
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeTarGz builds a tar.gz package from the given headers, using each header's Name as content for regular files.
+// writeTarGz 根据给定头构建 tar.gz 安装包，普通文件以其 Name 作为内容。
+func writeTarGz(t *testing.T, headers []*tar.Header) string {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for _, header := range headers {
+		body := []byte(header.Name)
+		if header.Typeflag == tar.TypeReg {
+			header.Size = int64(len(body))
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			t.Fatal(err)
+		}
+		if header.Typeflag == tar.TypeReg {
+			if _, err := tw.Write(body); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "apache-seatunnel-bin.tar.gz")
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// TestUntarGzStripsSpecialModeBits tests that setuid, setgid and sticky bits never reach extracted files.
+// TestUntarGzStripsSpecialModeBits 测试 setuid、setgid 与 sticky 位不会出现在解压出的文件上。
+func TestUntarGzStripsSpecialModeBits(t *testing.T) {
+	packagePath := writeTarGz(t, []*tar.Header{
+		{Name: "top/bin/", Typeflag: tar.TypeDir, Mode: 01755},
+		{Name: "top/bin/seatunnel.sh", Typeflag: tar.TypeReg, Mode: 06755},
+		{Name: "top/lib/seatunnel.jar", Typeflag: tar.TypeReg, Mode: 02644},
+	})
+	destDir := filepath.Join(t.TempDir(), "seatunnel")
+	if err := untarGz(context.Background(), packagePath, destDir, nil); err != nil {
+		t.Fatalf("untarGz failed: %v", err)
+	}
+
+	for _, rel := range []string{"bin", "bin/seatunnel.sh", "lib/seatunnel.jar"} {
+		info, err := os.Stat(filepath.Join(destDir, rel))
+		if err != nil {
+			t.Fatalf("Expected %s to be extracted: %v", rel, err)
+		}
+		if info.Mode()&(os.ModeSetuid|os.ModeSetgid|os.ModeSticky) != 0 {
+			t.Errorf("Expected %s to have no special bits, got %v", rel, info.Mode())
+		}
+	}
+	info, _ := os.Stat(filepath.Join(destDir, "bin/seatunnel.sh"))
+	if info.Mode().Perm()&0100 == 0 {
+		t.Errorf("Expected launcher to stay executable, got %v", info.Mode())
+	}
+}
+
+// TestUntarGzValidatesSymlinks tests that only symlinks resolving inside the destination are created.
+// TestUntarGzValidatesSymlinks 测试仅创建解析后位于目标目录内的符号链接。
+func TestUntarGzValidatesSymlinks(t *testing.T) {
+	packagePath := writeTarGz(t, []*tar.Header{
+		{Name: "top/lib/seatunnel-2.3.12.jar", Typeflag: tar.TypeReg, Mode: 0644},
+		{Name: "top/lib/seatunnel.jar", Typeflag: tar.TypeSymlink, Linkname: "seatunnel-2.3.12.jar"},
+		{Name: "top/connectors/lib", Typeflag: tar.TypeSymlink, Linkname: "../lib"},
+	})
+	destDir := filepath.Join(t.TempDir(), "seatunnel")
+	if err := untarGz(context.Background(), packagePath, destDir, nil); err != nil {
+		t.Fatalf("untarGz failed: %v", err)
+	}
+	if target, err := os.Readlink(filepath.Join(destDir, "connectors/lib")); err != nil || target != "../lib" {
+		t.Fatalf("Expected relative symlink to be created, got %q, %v", target, err)
+	}
+
+	for name, target := range map[string]string{
+		"absolute": "/etc/passwd",
+		"escaping": "../../../etc",
+		"nested":   "lib/../../..",
+	} {
+		t.Run(name, func(t *testing.T) {
+			packagePath := writeTarGz(t, []*tar.Header{
+				{Name: "top/lib/link", Typeflag: tar.TypeSymlink, Linkname: target},
+			})
+			err := untarGz(context.Background(), packagePath, filepath.Join(t.TempDir(), "seatunnel"), nil)
+			if !errors.Is(err, ErrUnsafeSymlink) || !errors.Is(err, ErrExtractionFailed) {
+				t.Fatalf("Expected unsafe symlink %q to be rejected, got %v", target, err)
+			}
+		})
+	}
+}
+
+// TestUntarGzRejectsChainedSymlinkEscape tests that symlinks created by earlier entries cannot be chained out of the destination.
+// TestUntarGzRejectsChainedSymlinkEscape 测试无法借助先前条目创建的符号链接串联越出目标目录。
+func TestUntarGzRejectsChainedSymlinkEscape(t *testing.T) {
+	for name, headers := range map[string][]*tar.Header{
+		"link through link": {
+			{Name: "top/a", Typeflag: tar.TypeSymlink, Linkname: "."},
+			{Name: "top/a/b", Typeflag: tar.TypeSymlink, Linkname: ".."},
+			{Name: "top/a/b/x", Typeflag: tar.TypeReg, Mode: 0644},
+		},
+		"link through link twice": {
+			{Name: "top/a", Typeflag: tar.TypeSymlink, Linkname: "."},
+			{Name: "top/a/b", Typeflag: tar.TypeSymlink, Linkname: "../.."},
+			{Name: "top/a/b/x", Typeflag: tar.TypeReg, Mode: 0644},
+		},
+		"dot-dot after link": {
+			{Name: "top/a", Typeflag: tar.TypeSymlink, Linkname: "."},
+			{Name: "top/c", Typeflag: tar.TypeSymlink, Linkname: "a/.."},
+			{Name: "top/c/x", Typeflag: tar.TypeReg, Mode: 0644},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			packagePath := writeTarGz(t, headers)
+			err := untarGz(context.Background(), packagePath, filepath.Join(dir, "seatunnel"), nil)
+			if !errors.Is(err, ErrUnsafeSymlink) || !errors.Is(err, ErrExtractionFailed) {
+				t.Fatalf("Expected chained symlink escape to be rejected, got %v", err)
+			}
+			if _, err := os.Stat(filepath.Join(dir, "x")); !os.IsNotExist(err) {
+				t.Fatalf("Expected nothing to be written outside the destination, got %v", err)
+			}
+		})
+	}
+}
+
+// TestUntarGzRejectsFileOverSymlink tests that a regular file entry is never written through an existing symlink.
+// TestUntarGzRejectsFileOverSymlink 测试普通文件条目不会经由已有符号链接写入。
+func TestUntarGzRejectsFileOverSymlink(t *testing.T) {
+	packagePath := writeTarGz(t, []*tar.Header{
+		{Name: "top/lib/seatunnel-2.3.12.jar", Typeflag: tar.TypeReg, Mode: 0644},
+		{Name: "top/lib/seatunnel.jar", Typeflag: tar.TypeSymlink, Linkname: "seatunnel-2.3.12.jar"},
+		{Name: "top/lib/seatunnel.jar", Typeflag: tar.TypeReg, Mode: 0644},
+	})
+	err := untarGz(context.Background(), packagePath, filepath.Join(t.TempDir(), "seatunnel"), nil)
+	if !errors.Is(err, ErrUnsafeSymlink) {
+		t.Fatalf("Expected writing through a symlink to be rejected, got %v", err)
+	}
+}
+
+// TestUntarGzRejectsSiblingPrefixTraversal tests that a path sharing the destination prefix is still rejected.
+// TestUntarGzRejectsSiblingPrefixTraversal 测试与目标目录同前缀的路径仍会被拒绝。
+func TestUntarGzRejectsSiblingPrefixTraversal(t *testing.T) {
+	dir := t.TempDir()
+	packagePath := writeTarGz(t, []*tar.Header{
+		{Name: "top/../seatunnel-evil/x.txt", Typeflag: tar.TypeReg, Mode: 0644},
+	})
+	if err := untarGz(context.Background(), packagePath, filepath.Join(dir, "seatunnel"), nil); err == nil {
+		t.Fatal("Expected sibling directory traversal to be rejected")
+	}
+	if _, err := os.Stat(filepath.Join(dir, "seatunnel-evil")); !os.IsNotExist(err) {
+		t.Fatalf("Expected nothing to be written outside the destination, got %v", err)
+	}
+}
+
+// TestExtractBudgetLimits tests the size and entry count limits that guard against decompression bombs.
+// TestExtractBudgetLimits 测试防范解压炸弹的大小与条目数量限制。
+func TestExtractBudgetLimits(t *testing.T) {
+	budget := &extractBudget{maxBytes: 10, maxFiles: 2}
+	if err := budget.copy(io.Discard, strings.NewReader("123456"), "a"); err != nil {
+		t.Fatalf("Expected first file within budget, got %v", err)
+	}
+	if err := budget.copy(io.Discard, strings.NewReader("7890"), "b"); err != nil {
+		t.Fatalf("Expected file filling the budget exactly to pass, got %v", err)
+	}
+	if err := budget.copy(io.Discard, strings.NewReader("x"), "c"); !errors.Is(err, ErrArchiveTooLarge) {
+		t.Fatalf("Expected size limit to be enforced, got %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := budget.addEntry("entry"); err != nil {
+			t.Fatalf("Expected entry %d within budget, got %v", i, err)
+		}
+	}
+	if err := budget.addEntry("entry"); !errors.Is(err, ErrArchiveTooLarge) {
+		t.Fatalf("Expected entry limit to be enforced, got %v", err)
+	}
+}
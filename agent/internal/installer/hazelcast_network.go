@@ -0,0 +1,169 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package installer
+
+import (
+	"fmt"
+	"strings"
+
+	seatunnelmeta "github.com/seatunnel/seatunnelX/internal/seatunnel"
+	"gopkg.in/yaml.v3"
+)
+
+// defaultRESTEndpointGroups are enabled when no REST endpoint groups are configured
+// defaultRESTEndpointGroups 在未配置 REST 端点组时启用
+var defaultRESTEndpointGroups = []string{"CLUSTER_WRITE", "DATA"}
+
+// HazelcastNetworkConfig contains advanced Hazelcast member network settings for NAT and multi-NIC hosts
+// HazelcastNetworkConfig 包含适用于 NAT 与多网卡主机的 Hazelcast 成员高级网络配置
+type HazelcastNetworkConfig struct {
+	// PublicAddress is the host[:port] this member advertises to the others
+	// PublicAddress 是本成员向其他成员公布的 host[:port]
+	PublicAddress string `json:"public_address,omitempty"`
+
+	// Interfaces restricts binding to matching NICs, as IPv4 CIDRs or Hazelcast patterns
+	// Interfaces 仅绑定匹配的网卡，支持 IPv4 CIDR 或 Hazelcast 通配形式
+	Interfaces []string `json:"interfaces,omitempty"`
+
+	// AdvertisedAddresses replaces the member-list derived from master/worker addresses
+	// AdvertisedAddresses 替换由 master/worker 地址推导出的 member-list
+	AdvertisedAddresses []string `json:"advertised_addresses,omitempty"`
+
+	// RESTEndpointGroups is the exact set of enabled REST endpoint groups
+	// RESTEndpointGroups 是启用的 REST 端点组集合
+	RESTEndpointGroups []string `json:"rest_endpoint_groups,omitempty"`
+}
+
+// Validate validates the Hazelcast network configuration
+// Validate 验证 Hazelcast 网络配置
+func (h *HazelcastNetworkConfig) Validate() error {
+	if h.PublicAddress != "" {
+		if err := seatunnelmeta.ValidateHazelcastAddress(h.PublicAddress); err != nil {
+			return fmt.Errorf("public_address: %w", err)
+		}
+	}
+	for _, value := range h.Interfaces {
+		if _, err := seatunnelmeta.HazelcastInterfacePattern(value); err != nil {
+			return fmt.Errorf("interfaces: %w", err)
+		}
+	}
+	for _, value := range h.AdvertisedAddresses {
+		if err := seatunnelmeta.ValidateHazelcastAddress(value); err != nil {
+			return fmt.Errorf("advertised_addresses: %w", err)
+		}
+	}
+	if _, err := seatunnelmeta.NormalizeHazelcastRESTEndpointGroups(h.RESTEndpointGroups); err != nil {
+		return fmt.Errorf("rest_endpoint_groups: %w", err)
+	}
+	return nil
+}
+
+// advertisedMemberList returns the advertised addresses with the given port added where missing
+// advertisedMemberList 返回公布地址列表，缺少端口的地址补上给定端口
+func (h *HazelcastNetworkConfig) advertisedMemberList(port int) []string {
+	if h == nil {
+		return nil
+	}
+	members := make([]string, 0, len(h.AdvertisedAddresses))
+	for _, addr := range h.AdvertisedAddresses {
+		addr = strings.TrimSpace(addr)
+		if addr == "" {
+			continue
+		}
+		if !seatunnelmeta.HazelcastAddressHasPort(addr) {
+			addr = formatHostPort(addr, port)
+		}
+		members = append(members, addr)
+	}
+	return members
+}
+
+// formatHostPort joins a host and port, bracketing IPv6 literals
+// formatHostPort 拼接主机与端口，IPv6 字面量会加上方括号
+func formatHostPort(host string, port int) string {
+	if strings.Contains(host, ":") {
+		return fmt.Sprintf("[%s]:%d", host, port)
+	}
+	return fmt.Sprintf("%s:%d", host, port)
+}
+
+// applyHazelcastNetwork writes public-address, interfaces and REST endpoint groups into a member config
+// applyHazelcastNetwork 将 public-address、interfaces 与 REST 端点组写入成员配置
+func applyHazelcastNetwork(root *yaml.Node, cfg *HazelcastNetworkConfig) error {
+	groups, custom := defaultRESTEndpointGroups, false
+	if cfg != nil {
+		normalized, err := seatunnelmeta.NormalizeHazelcastRESTEndpointGroups(cfg.RESTEndpointGroups)
+		if err != nil {
+			return err
+		}
+		if len(normalized) > 0 {
+			groups, custom = normalized, true
+		}
+	}
+
+	// Enable Hazelcast REST API with the selected endpoint groups; the path is created when missing.
+	// 启用 Hazelcast REST API 及所选端点组；路径不存在时会自动创建。
+	if err := setYAMLValueCreate(root, []string{"hazelcast", "network", "rest-api", "enabled"}, true); err != nil {
+		return err
+	}
+	enabled := make(map[string]bool, len(groups))
+	for _, group := range groups {
+		enabled[group] = true
+	}
+	for _, group := range seatunnelmeta.HazelcastRESTEndpointGroups {
+		// Only a custom set disables the remaining groups / 仅自定义集合会关闭其余的组
+		if !enabled[group] && !custom {
+			continue
+		}
+		if err := setYAMLValueCreate(root, []string{"hazelcast", "network", "rest-api", "endpoint-groups", group, "enabled"}, enabled[group]); err != nil {
+			return err
+		}
+	}
+	if cfg == nil {
+		return nil
+	}
+
+	if cfg.PublicAddress != "" {
+		if err := setYAMLValueCreate(root, []string{"hazelcast", "network", "public-address"}, strings.TrimSpace(cfg.PublicAddress)); err != nil {
+			return err
+		}
+	}
+
+	if len(cfg.Interfaces) > 0 {
+		patterns := make([]string, 0, len(cfg.Interfaces))
+		for _, value := range cfg.Interfaces {
+			pattern, err := seatunnelmeta.HazelcastInterfacePattern(value)
+			if err != nil {
+				return err
+			}
+			patterns = append(patterns, pattern)
+		}
+		if err := setYAMLValueCreate(root, []string{"hazelcast", "network", "interfaces", "enabled"}, true); err != nil {
+			return err
+		}
+		if err := setYAMLValueCreate(root, []string{"hazelcast", "network", "interfaces", "interfaces"}, patterns); err != nil {
+			return err
+		}
+		// Without this Hazelcast still listens on every address and only picks the advertised one from the list.
+		// 未设置时 Hazelcast 仍监听所有地址，仅从列表中选择对外地址。
+		if err := setYAMLValueCreate(root, []string{"hazelcast", "properties", "hazelcast.socket.bind.any"}, false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,183 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package installer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+const hazelcastMemberFixture = `hazelcast:
+  cluster-name: seatunnel
+  network:
+    rest-api:
+      enabled: false
+      endpoint-groups:
+        CLUSTER_WRITE:
+          enabled: true
+        DATA:
+          enabled: true
+    join:
+      tcp-ip:
+        enabled: true
+        member-list:
+          - localhost
+    port:
+      auto-increment: false
+      port: 5801
+  properties:
+    hazelcast.invocation.max.retry.count: 20
+`
+
+// hazelcastNetworkSection parses the network section written to a hazelcast member config
+// hazelcastNetworkSection 解析写入 hazelcast 成员配置的 network 段
+type hazelcastNetworkSection struct {
+	Hazelcast struct {
+		Network struct {
+			PublicAddress string `yaml:"public-address"`
+			RestAPI       struct {
+				Enabled        bool `yaml:"enabled"`
+				EndpointGroups map[string]struct {
+					Enabled bool `yaml:"enabled"`
+				} `yaml:"endpoint-groups"`
+			} `yaml:"rest-api"`
+			Join struct {
+				TCPIP struct {
+					MemberList []string `yaml:"member-list"`
+				} `yaml:"tcp-ip"`
+			} `yaml:"join"`
+			Interfaces struct {
+				Enabled    bool     `yaml:"enabled"`
+				Interfaces []string `yaml:"interfaces"`
+			} `yaml:"interfaces"`
+		} `yaml:"network"`
+		Properties map[string]interface{} `yaml:"properties"`
+	} `yaml:"hazelcast"`
+}
+
+func writeHazelcastFixture(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "hazelcast.yaml")
+	if err := os.WriteFile(path, []byte(hazelcastMemberFixture), 0644); err != nil {
+		t.Fatalf("write fixture: %v", err)
+	}
+	return path
+}
+
+func readHazelcastNetwork(t *testing.T, path string) hazelcastNetworkSection {
+	t.Helper()
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read config: %v", err)
+	}
+	var section hazelcastNetworkSection
+	if err := yaml.Unmarshal(content, &section); err != nil {
+		t.Fatalf("parse config: %v", err)
+	}
+	return section
+}
+
+func TestModifyHazelcastConfigWritesAdvancedNetwork(t *testing.T) {
+	path := writeHazelcastFixture(t)
+	params := &InstallParams{
+		DeploymentMode:  DeploymentModeHybrid,
+		ClusterPort:     5801,
+		MasterAddresses: []string{"10.0.1.10", "10.0.1.11"},
+		Hazelcast: &HazelcastNetworkConfig{
+			PublicAddress:       "203.0.113.10:15801",
+			Interfaces:          []string{"10.0.1.0/24", "172.16.4-7.*"},
+			AdvertisedAddresses: []string{"203.0.113.10:15801", "203.0.113.11"},
+			RESTEndpointGroups:  []string{"cluster_write", "DATA", "HEALTH_CHECK"},
+		},
+	}
+	if err := (&InstallerManager{}).modifyHazelcastConfig(path, params); err != nil {
+		t.Fatalf("modifyHazelcastConfig: %v", err)
+	}
+
+	network := readHazelcastNetwork(t, path).Hazelcast
+	if network.Network.PublicAddress != "203.0.113.10:15801" {
+		t.Fatalf("unexpected public-address %q", network.Network.PublicAddress)
+	}
+	if got := network.Network.Join.TCPIP.MemberList; len(got) != 2 || got[0] != "203.0.113.10:15801" || got[1] != "203.0.113.11:5801" {
+		t.Fatalf("unexpected member-list %v", got)
+	}
+	if got := network.Network.Interfaces; !got.Enabled || len(got.Interfaces) != 2 || got.Interfaces[0] != "10.0.1.*" || got.Interfaces[1] != "172.16.4-7.*" {
+		t.Fatalf("unexpected interfaces %+v", got)
+	}
+	if bindAny, ok := network.Properties["hazelcast.socket.bind.any"].(bool); !ok || bindAny {
+		t.Fatalf("expected hazelcast.socket.bind.any=false, got %v", network.Properties["hazelcast.socket.bind.any"])
+	}
+	if network.Properties["hazelcast.invocation.max.retry.count"] != 20 {
+		t.Fatalf("existing properties must be kept, got %v", network.Properties)
+	}
+
+	groups := network.Network.RestAPI.EndpointGroups
+	if !network.Network.RestAPI.Enabled {
+		t.Fatal("expected rest-api to be enabled")
+	}
+	for _, group := range []string{"CLUSTER_WRITE", "DATA", "HEALTH_CHECK"} {
+		if !groups[group].Enabled {
+			t.Fatalf("expected %s to be enabled, got %+v", group, groups)
+		}
+	}
+	if group, ok := groups["CLUSTER_READ"]; !ok || group.Enabled {
+		t.Fatalf("expected CLUSTER_READ to be disabled explicitly, got %+v", groups)
+	}
+}
+
+func TestModifyHazelcastConfigKeepsDefaultsWithoutNetworkConfig(t *testing.T) {
+	path := writeHazelcastFixture(t)
+	params := &InstallParams{
+		DeploymentMode:  DeploymentModeHybrid,
+		MasterAddresses: []string{"10.0.1.10"},
+	}
+	if err := (&InstallerManager{}).modifyHazelcastConfig(path, params); err != nil {
+		t.Fatalf("modifyHazelcastConfig: %v", err)
+	}
+
+	network := readHazelcastNetwork(t, path).Hazelcast.Network
+	if network.PublicAddress != "" || network.Interfaces.Enabled {
+		t.Fatalf("expected no advanced network settings, got %+v", network)
+	}
+	if got := network.Join.TCPIP.MemberList; len(got) != 1 || got[0] != "10.0.1.10:5801" {
+		t.Fatalf("unexpected member-list %v", got)
+	}
+	if len(network.RestAPI.EndpointGroups) != 2 || !network.RestAPI.EndpointGroups["CLUSTER_WRITE"].Enabled || !network.RestAPI.EndpointGroups["DATA"].Enabled {
+		t.Fatalf("unexpected endpoint groups %+v", network.RestAPI.EndpointGroups)
+	}
+}
+
+func TestHazelcastNetworkConfigValidate(t *testing.T) {
+	valid := &HazelcastNetworkConfig{PublicAddress: "st.example.com", Interfaces: []string{"10.0.0.0/16"}, RESTEndpointGroups: []string{"CLUSTER_WRITE", "DATA"}}
+	if err := valid.Validate(); err != nil {
+		t.Fatalf("expected valid config, got %v", err)
+	}
+	for name, cfg := range map[string]*HazelcastNetworkConfig{
+		"public address": {PublicAddress: "bad host"},
+		"interface":      {Interfaces: []string{"eth0"}},
+		"advertised":     {AdvertisedAddresses: []string{"10.0.0.1:0"}},
+		"rest groups":    {RESTEndpointGroups: []string{"HEALTH_CHECK"}},
+	} {
+		if err := cfg.Validate(); err == nil {
+			t.Fatalf("expected %s to be rejected", name)
+		}
+	}
+}
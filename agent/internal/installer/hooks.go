@@ -0,0 +1,162 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package installer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"time"
+
+	"github.com/seatunnel/seatunnelX/agent/internal/logger"
+	"github.com/seatunnel/seatunnelX/internal/pkg/installhook"
+)
+
+// stepSkipped reports whether the install request asked to skip the step.
+// stepSkipped 判断安装请求是否要求跳过该步骤。
+func (p *InstallParams) stepSkipped(step InstallStep) bool {
+	for _, skipped := range p.SkipSteps {
+		if skipped == step {
+			return true
+		}
+	}
+	return false
+}
+
+// runStepHooks runs the hooks registered for the step and phase in order, records their results and
+// returns an error when a hook with FailOnError fails.
+// runStepHooks 按顺序执行该步骤与时机注册的钩子并记录结果；设置 FailOnError 的钩子失败时返回错误。
+func (m *InstallerManager) runStepHooks(ctx context.Context, params *InstallParams, step InstallStep, phase installhook.Phase, reporter ProgressReporter, result *InstallResult) error {
+	progress := 0
+	if phase == installhook.PhasePost {
+		progress = 100
+	}
+	for _, hook := range params.Hooks {
+		if hook.Step != string(step) || hook.Phase != phase {
+			continue
+		}
+		logger.InfoF(ctx, "[InstallStepByStep] Running %s hook %s for step %s", phase, hook.Name, step)
+		hookResult := m.runHook(ctx, params, hook)
+		result.HookResults = append(result.HookResults, hookResult)
+		reporter.Report(step, progress, installhook.FormatResult(hookResult))
+		if !hookResult.Success {
+			logger.WarnF(ctx, "[InstallStepByStep] Hook %s failed: %s", hook.Name, hookResult.Error)
+			if hook.FailOnError {
+				return fmt.Errorf("%s hook %s failed: %s / %s 钩子 %s 执行失败：%s",
+					phase, hook.Name, hookResult.Error, phase, hook.Name, hookResult.Error)
+			}
+		}
+	}
+	return nil
+}
+
+// runHook runs one hook within its timeout.
+// runHook 在超时时间内执行单个钩子。
+func (m *InstallerManager) runHook(ctx context.Context, params *InstallParams, hook installhook.Hook) installhook.Result {
+	result := installhook.Result{
+		Name:      hook.Name,
+		Step:      hook.Step,
+		Phase:     hook.Phase,
+		Type:      hook.Type(),
+		StartedAt: time.Now(),
+	}
+	hookCtx, cancel := context.WithTimeout(ctx, hook.Timeout())
+	defer cancel()
+
+	var err error
+	if result.Type == installhook.TypeScript {
+		err = runScriptHook(hookCtx, params, hook, &result)
+	} else {
+		err = m.runWebhookHook(hookCtx, params, hook, &result)
+	}
+	if errors.Is(hookCtx.Err(), context.DeadlineExceeded) {
+		err = fmt.Errorf("timed out after %s", hook.Timeout())
+	}
+	result.DurationMs = time.Since(result.StartedAt).Milliseconds()
+	result.Success = err == nil
+	if err != nil {
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// runScriptHook executes a script that exists on the host; the step context is passed as
+// environment variables, never interpolated into shell source.
+// runScriptHook 执行主机上已存在的脚本；步骤上下文通过环境变量传递，不会拼接进 shell 源码。
+func runScriptHook(ctx context.Context, params *InstallParams, hook installhook.Hook, result *installhook.Result) error {
+	if _, err := os.Stat(hook.Script); err != nil {
+		return fmt.Errorf("script not found: %w", err)
+	}
+	cmd := exec.CommandContext(ctx, hook.Script)
+	cmd.Env = append(os.Environ(),
+		"SEATUNNELX_HOOK_NAME="+hook.Name,
+		"SEATUNNELX_HOOK_STEP="+hook.Step,
+		"SEATUNNELX_HOOK_PHASE="+string(hook.Phase),
+		"SEATUNNELX_VERSION="+params.Version,
+		"SEATUNNELX_INSTALL_DIR="+params.InstallDir,
+		"SEATUNNELX_CLUSTER_ID="+params.ClusterID,
+		"SEATUNNELX_NODE_ROLE="+string(params.NodeRole),
+	)
+	output, err := cmd.CombinedOutput()
+	result.Output = installhook.TruncateOutput(string(output))
+	if cmd.ProcessState != nil {
+		result.ExitCode = cmd.ProcessState.ExitCode()
+	}
+	return err
+}
+
+// runWebhookHook POSTs the step context to the webhook; any non-2xx status fails the hook.
+// runWebhookHook 将步骤上下文 POST 到 Webhook；非 2xx 状态视为钩子失败。
+func (m *InstallerManager) runWebhookHook(ctx context.Context, params *InstallParams, hook installhook.Hook, result *installhook.Result) error {
+	body, err := json.Marshal(installhook.Payload{
+		Hook:       hook.Name,
+		Step:       hook.Step,
+		Phase:      hook.Phase,
+		HostID:     params.HostID,
+		ClusterID:  params.ClusterID,
+		Version:    params.Version,
+		InstallDir: params.InstallDir,
+		NodeRole:   string(params.NodeRole),
+	})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, hook.WebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := m.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	output, _ := io.ReadAll(io.LimitReader(resp.Body, installhook.MaxOutputBytes))
+	result.StatusCode = resp.StatusCode
+	result.Output = installhook.TruncateOutput(string(output))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned HTTP %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package installer
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/seatunnel/seatunnelX/internal/pkg/installhook"
+)
+
+func TestInstallParamsStepSkipped(t *testing.T) {
+	params := &InstallParams{SkipSteps: []InstallStep{InstallStepInstallPlugins}}
+	if !params.stepSkipped(InstallStepInstallPlugins) {
+		t.Fatal("expected install_plugins to be skipped")
+	}
+	if params.stepSkipped(InstallStepExtract) {
+		t.Fatal("expected extract not to be skipped")
+	}
+}
+
+func TestRunStepHooksRunsScriptAndWebhook(t *testing.T) {
+	script := filepath.Join(t.TempDir(), "hook.sh")
+	if err := os.WriteFile(script, []byte("#!/bin/sh\necho \"$SEATUNNELX_HOOK_STEP $SEATUNNELX_VERSION\"\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	var payload installhook.Payload
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	params := &InstallParams{
+		Version: "2.3.12",
+		HostID:  "7",
+		Hooks: []installhook.Hook{
+			{Name: "script", Step: string(InstallStepExtract), Phase: installhook.PhasePost, Script: script},
+			{Name: "notify", Step: string(InstallStepExtract), Phase: installhook.PhasePost, WebhookURL: server.URL},
+			{Name: "other", Step: string(InstallStepExtract), Phase: installhook.PhasePre, WebhookURL: server.URL},
+		},
+	}
+	reporter := &recordingProgressReporter{}
+	result := &InstallResult{}
+	if err := NewInstallerManager().runStepHooks(context.Background(), params, InstallStepExtract, installhook.PhasePost, reporter, result); err != nil {
+		t.Fatalf("runStepHooks: %v", err)
+	}
+	if len(result.HookResults) != 2 {
+		t.Fatalf("expected 2 hook results, got %d", len(result.HookResults))
+	}
+	if r := result.HookResults[0]; !r.Success || strings.TrimSpace(r.Output) != "extract 2.3.12" {
+		t.Fatalf("unexpected script result: %+v", r)
+	}
+	if r := result.HookResults[1]; !r.Success || r.StatusCode != http.StatusNoContent {
+		t.Fatalf("unexpected webhook result: %+v", r)
+	}
+	if payload.HostID != "7" || payload.Step != string(InstallStepExtract) {
+		t.Fatalf("unexpected webhook payload: %+v", payload)
+	}
+	if len(reporter.messages) != 2 || !strings.HasPrefix(reporter.messages[0], installhook.ResultPrefix) {
+		t.Fatalf("expected hook results to be reported, got %#v", reporter.messages)
+	}
+}
+
+func TestRunStepHooksFailOnError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	hook := installhook.Hook{Name: "gate", Step: string(InstallStepExtract), Phase: installhook.PhasePre, WebhookURL: server.URL}
+	params := &InstallParams{Hooks: []installhook.Hook{hook}}
+	manager := NewInstallerManager()
+
+	result := &InstallResult{}
+	if err := manager.runStepHooks(context.Background(), params, InstallStepExtract, installhook.PhasePre, &recordingProgressReporter{}, result); err != nil {
+		t.Fatalf("expected non-fatal hook failure, got %v", err)
+	}
+	if len(result.HookResults) != 1 || result.HookResults[0].Success {
+		t.Fatalf("expected failed hook result, got %+v", result.HookResults)
+	}
+
+	params.Hooks[0].FailOnError = true
+	if err := manager.runStepHooks(context.Background(), params, InstallStepExtract, installhook.PhasePre, &recordingProgressReporter{}, &InstallResult{}); err == nil {
+		t.Fatal("expected fail_on_error hook to abort the step")
+	}
+}
@@ -0,0 +1,98 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package installer
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/seatunnel/seatunnelX/agent/internal/logger"
+	"github.com/seatunnel/seatunnelX/agent/internal/state"
+)
+
+// resumableSteps are the steps whose result survives on disk and can be skipped when an
+// interrupted installation of the same version is run again. Configuration steps are cheap
+// and idempotent, so they always run.
+// resumableSteps 是结果保留在磁盘上、重新执行同版本中断安装时可跳过的步骤。
+// 配置步骤开销小且幂等，因此总是执行。
+var resumableSteps = map[InstallStep]bool{
+	InstallStepDownload: true,
+	InstallStepVerify:   true,
+	InstallStepExtract:  true,
+}
+
+// SetStateStore sets the store used to persist installation progress across Agent restarts
+// SetStateStore 设置用于在 Agent 重启之间持久化安装进度的存储
+func (m *InstallerManager) SetStateStore(store *state.Store) {
+	m.state = store
+}
+
+// InterruptedInstalls returns installations that were in progress when the Agent last stopped.
+// Call it before any new installation starts; running installations are recorded in the same store.
+// InterruptedInstalls 返回 Agent 上次停止时仍在进行的安装。
+// 应在任何新安装开始前调用；运行中的安装也记录在同一存储中。
+func (m *InstallerManager) InterruptedInstalls() []*state.InstallRecord {
+	return m.state.Installs()
+}
+
+// beginInstallRecord returns the progress record for the installation, reusing the record of an
+// interrupted run of the same version so completed steps can be resumed.
+// beginInstallRecord 返回本次安装的进度记录；若存在同版本的中断安装记录则复用，以便恢复已完成的步骤。
+func (m *InstallerManager) beginInstallRecord(ctx context.Context, params *InstallParams) *state.InstallRecord {
+	if previous, ok := m.state.GetInstall(params.InstallDir); ok && previous.Version == params.Version {
+		logger.InfoF(ctx, "[InstallStepByStep] Resuming interrupted installation of %s (completed: %v) / 恢复中断的安装 %s（已完成：%v）",
+			params.InstallDir, previous.CompletedSteps, params.InstallDir, previous.CompletedSteps)
+		previous.CommandID = params.CommandID
+		return previous
+	}
+	return &state.InstallRecord{
+		InstallDir: params.InstallDir,
+		CommandID:  params.CommandID,
+		Version:    params.Version,
+		StartedAt:  time.Now(),
+	}
+}
+
+// saveInstallRecord persists installation progress; failures only cost resumability
+// saveInstallRecord 持久化安装进度；失败只影响可恢复性
+func (m *InstallerManager) saveInstallRecord(ctx context.Context, record *state.InstallRecord) {
+	if err := m.state.PutInstall(record); err != nil {
+		logger.WarnF(ctx, "[InstallStepByStep] Failed to persist install progress: %v / 持久化安装进度失败：%v", err, err)
+	}
+}
+
+// finishInstallRecord drops the progress record once the installation returned;
+// only a killed Agent leaves the record behind.
+// finishInstallRecord 在安装返回后删除进度记录；只有 Agent 被终止时记录才会保留。
+func (m *InstallerManager) finishInstallRecord(ctx context.Context, params *InstallParams) {
+	if err := m.state.DeleteInstall(params.InstallDir); err != nil {
+		logger.WarnF(ctx, "[InstallStepByStep] Failed to clear install progress: %v / 清理安装进度失败：%v", err, err)
+	}
+}
+
+// canResumeStep reports whether the step was completed by an interrupted run and its result is still on disk
+// canResumeStep 判断步骤是否已由中断的安装完成且其结果仍在磁盘上
+func canResumeStep(record *state.InstallRecord, step InstallStep, params *InstallParams) bool {
+	if !resumableSteps[step] || !record.HasCompleted(string(InstallStepExtract)) {
+		return false
+	}
+	_, err := os.Stat(filepath.Join(params.InstallDir, "bin"))
+	return err == nil
+}
@@ -0,0 +1,292 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package installer
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/seatunnel/seatunnelX/agent/internal/logger"
+)
+
+// ManagedJavaRelease is the Temurin OpenJDK 11 release installed by auto_install_java.
+// ManagedJavaRelease 是 auto_install_java 安装的 Temurin OpenJDK 11 版本。
+const ManagedJavaRelease = "11.0.25+9"
+
+// JavaMirror represents an OpenJDK download source
+// JavaMirror 表示 OpenJDK 下载源
+type JavaMirror string
+
+const (
+	// JavaMirrorTsinghua is the Adoptium mirror of Tsinghua University (fastest in China)
+	// JavaMirrorTsinghua 是清华大学的 Adoptium 镜像（国内最快）
+	JavaMirrorTsinghua JavaMirror = "tsinghua"
+
+	// JavaMirrorAdoptium is the official Adoptium release on GitHub
+	// JavaMirrorAdoptium 是 GitHub 上的 Adoptium 官方发布
+	JavaMirrorAdoptium JavaMirror = "adoptium"
+)
+
+// javaMirrorURLs maps Java mirrors to URL templates taking the architecture and package name.
+// javaMirrorURLs 将 Java 下载源映射为以架构与安装包名为参数的 URL 模板。
+var javaMirrorURLs = map[JavaMirror]string{
+	JavaMirrorTsinghua: "https://mirrors.tuna.tsinghua.edu.cn/Adoptium/11/jdk/%s/linux/%s",
+	JavaMirrorAdoptium: "https://github.com/adoptium/temurin11-binaries/releases/download/jdk-11.0.25%%2B9/%[2]s",
+}
+
+// javaInstallRoot is the directory managed Java runtimes are installed under; overridden in tests.
+// javaInstallRoot 是托管 Java 运行时的安装根目录；测试中会被覆盖。
+var javaInstallRoot = "/opt/java"
+
+// javaEnvScript is the SeaTunnel env script sourced by the bin/ launch scripts.
+// javaEnvScript 是 bin/ 启动脚本会加载的 SeaTunnel 环境脚本。
+const javaEnvScript = "seatunnel-env.sh"
+
+const (
+	javaEnvBlockBegin = "# >>> SeaTunnelX managed Java >>>"
+	javaEnvBlockEnd   = "# <<< SeaTunnelX managed Java <<<"
+)
+
+// ValidateJavaMirror validates if the Java mirror is known
+// ValidateJavaMirror 验证 Java 下载源是否有效
+func ValidateJavaMirror(mirror JavaMirror) bool {
+	_, ok := javaMirrorURLs[mirror]
+	return ok
+}
+
+// managedJavaHome returns the Java home created by auto-installation.
+// managedJavaHome 返回自动安装创建的 Java 目录。
+func managedJavaHome() string {
+	return filepath.Join(javaInstallRoot, "jdk-"+ManagedJavaRelease)
+}
+
+// managedJavaArch maps GOARCH to the architecture name used by Adoptium packages.
+// managedJavaArch 将 GOARCH 映射为 Adoptium 安装包使用的架构名称。
+func managedJavaArch(goarch string) (string, error) {
+	switch goarch {
+	case "amd64":
+		return "x64", nil
+	case "arm64":
+		return "aarch64", nil
+	default:
+		return "", fmt.Errorf("automatic Java installation does not support architecture %s / 自动安装 Java 不支持架构 %s", goarch, goarch)
+	}
+}
+
+// ManagedJavaPackageName returns the OpenJDK package file name for an Adoptium architecture.
+// ManagedJavaPackageName 返回指定 Adoptium 架构的 OpenJDK 安装包文件名。
+func ManagedJavaPackageName(arch string) string {
+	return fmt.Sprintf("OpenJDK11U-jdk_%s_linux_hotspot_%s.tar.gz", arch, strings.ReplaceAll(ManagedJavaRelease, "+", "_"))
+}
+
+// javaMirrorOrder returns the preferred mirror first. Without a preference, nodes installing
+// from the Apache archive start with the official release and the others with the mirror in China.
+// javaMirrorOrder 返回以首选下载源开头的顺序。未指定时，从 Apache 官方地址安装的节点先使用官方发布，
+// 其余节点先使用国内镜像。
+func javaMirrorOrder(preferred JavaMirror, packageMirror MirrorSource) []JavaMirror {
+	if preferred == "" {
+		preferred = JavaMirrorTsinghua
+		if packageMirror == MirrorApache {
+			preferred = JavaMirrorAdoptium
+		}
+	}
+	ordered := []JavaMirror{preferred}
+	for _, mirror := range []JavaMirror{JavaMirrorTsinghua, JavaMirrorAdoptium} {
+		if mirror != preferred {
+			ordered = append(ordered, mirror)
+		}
+	}
+	return ordered
+}
+
+// executeStepInstallJava installs OpenJDK 11 when requested and no supported Java is present,
+// then points the SeaTunnel env script at it. An existing managed installation is reused.
+// executeStepInstallJava 在请求自动安装且没有受支持的 Java 时安装 OpenJDK 11，并在 SeaTunnel
+// 环境脚本中指向它。已存在的托管安装会被复用。
+func (m *InstallerManager) executeStepInstallJava(ctx context.Context, params *InstallParams, reporter ProgressReporter) error {
+	if !params.AutoInstallJava {
+		reporter.Report(InstallStepInstallJava, 100, "Java installation skipped / 跳过 Java 安装")
+		return nil
+	}
+
+	home := managedJavaHome()
+	if !javaHomeReady(home) {
+		if version := detectJavaMajorVersion(); version >= JavaMinVersion && version <= JavaMaxRecommendedVersion {
+			reporter.Report(InstallStepInstallJava, 100, fmt.Sprintf("Java %d already installed / Java %d 已安装", version, version))
+			return nil
+		}
+		if runtime.GOOS != "linux" {
+			return fmt.Errorf("automatic Java installation is only supported on Linux / 自动安装 Java 仅支持 Linux")
+		}
+		if err := m.installManagedJava(ctx, params, home, reporter); err != nil {
+			return err
+		}
+	}
+
+	reporter.Report(InstallStepInstallJava, 90, "Writing JAVA_HOME... / 写入 JAVA_HOME...")
+	if err := writeJavaEnv(filepath.Join(params.InstallDir, "config", javaEnvScript), home); err != nil {
+		return fmt.Errorf("write %s: %w / 写入 %s 失败: %w", javaEnvScript, err, javaEnvScript, err)
+	}
+	params.JavaHome = home
+	reporter.Report(InstallStepInstallJava, 100, fmt.Sprintf("Using Java at %s / 使用 %s 中的 Java", home, home))
+	return nil
+}
+
+// installManagedJava obtains the OpenJDK package (local path, bundled copy or download) and
+// extracts it into home through a staging directory, so an interrupted run never leaves a
+// half-extracted Java home behind.
+// installManagedJava 获取 OpenJDK 安装包（本地路径、随附安装包或下载），并经临时目录解压到 home，
+// 避免中断时留下未解压完整的 Java 目录。
+func (m *InstallerManager) installManagedJava(ctx context.Context, params *InstallParams, home string, reporter ProgressReporter) error {
+	arch, err := managedJavaArch(runtime.GOARCH)
+	if err != nil {
+		return err
+	}
+	name := ManagedJavaPackageName(arch)
+
+	packagePath := params.JavaPackagePath
+	if packagePath == "" && params.JavaBundleDir != "" {
+		if bundled := filepath.Join(params.JavaBundleDir, name); fileExists(bundled) {
+			packagePath = bundled
+		}
+	}
+	if packagePath == "" {
+		reporter.Report(InstallStepInstallJava, 10, fmt.Sprintf("Downloading %s... / 下载 %s...", name, name))
+		packagePath = filepath.Join(m.tempDir, name)
+		if err := m.downloadJavaWithFallback(ctx, arch, name, packagePath, javaMirrorOrder(params.JavaMirror, params.Mirror)); err != nil {
+			return err
+		}
+		defer os.Remove(packagePath)
+	} else if !fileExists(packagePath) {
+		return fmt.Errorf("java package %s not found / Java 安装包 %s 不存在", packagePath, packagePath)
+	}
+
+	reporter.Report(InstallStepInstallJava, 60, fmt.Sprintf("Extracting Java to %s... / 解压 Java 到 %s...", home, home))
+	staging := home + ".partial"
+	if err := os.RemoveAll(staging); err != nil {
+		return err
+	}
+	if err := untarGz(ctx, packagePath, staging, nil); err != nil {
+		_ = os.RemoveAll(staging)
+		return err
+	}
+	if !javaHomeReady(staging) {
+		_ = os.RemoveAll(staging)
+		return fmt.Errorf("%s does not contain bin/java / %s 中不包含 bin/java", packagePath, packagePath)
+	}
+	if err := os.Rename(staging, home); err != nil {
+		_ = os.RemoveAll(staging)
+		return err
+	}
+	logger.InfoF(ctx, "[InstallJava] Installed OpenJDK %s at %s / 已在 %s 安装 OpenJDK %s", ManagedJavaRelease, home, home, ManagedJavaRelease)
+	return nil
+}
+
+// downloadJavaWithFallback tries each mirror in order and verifies the published SHA-256 when the
+// mirror serves one.
+// downloadJavaWithFallback 按顺序尝试各下载源，并在下载源提供 SHA-256 时进行校验。
+func (m *InstallerManager) downloadJavaWithFallback(ctx context.Context, arch, name, targetPath string, mirrors []JavaMirror) error {
+	var lastErr error
+	for _, mirror := range mirrors {
+		url := fmt.Sprintf(javaMirrorURLs[mirror], arch, name)
+		if err := m.downloadFile(ctx, url, targetPath); err != nil {
+			lastErr = fmt.Errorf("%s: %w", mirror, err)
+			continue
+		}
+		checksum, err := m.fetchJavaChecksum(ctx, url+".sha256.txt")
+		if err != nil {
+			logger.WarnF(ctx, "[InstallJava] No checksum from %s, skipping verification: %v / %s 未提供校验和，跳过校验", mirror, err, mirror)
+			return nil
+		}
+		if err := m.VerifyChecksum(targetPath, checksum); err != nil {
+			_ = os.Remove(targetPath)
+			lastErr = fmt.Errorf("%s: %w", mirror, err)
+			continue
+		}
+		return nil
+	}
+	if lastErr == nil {
+		lastErr = fmt.Errorf("no download mirror available")
+	}
+	return fmt.Errorf("download %s failed: %w / 下载 %s 失败", name, lastErr, name)
+}
+
+// fetchJavaChecksum reads an Adoptium ".sha256.txt" file ("<hex>  <file name>").
+// fetchJavaChecksum 读取 Adoptium 的 ".sha256.txt" 文件（"<hex>  <文件名>"）。
+func (m *InstallerManager) fetchJavaChecksum(ctx context.Context, url string) (string, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+	response, err := m.httpClient.Do(request)
+	if err != nil {
+		return "", err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected status code %d", response.StatusCode)
+	}
+	body, err := io.ReadAll(io.LimitReader(response.Body, 4096))
+	if err != nil {
+		return "", err
+	}
+	fields := strings.Fields(string(body))
+	if len(fields) == 0 || len(fields[0]) != 64 {
+		return "", fmt.Errorf("malformed checksum file")
+	}
+	return fields[0], nil
+}
+
+// writeJavaEnv replaces (or appends) the managed JAVA_HOME block in the env script, leaving the
+// rest of the script untouched.
+// writeJavaEnv 替换（或追加）环境脚本中托管的 JAVA_HOME 片段，脚本其余内容保持不变。
+func writeJavaEnv(path, home string) error {
+	content, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	existing := string(content)
+	if begin := strings.Index(existing, javaEnvBlockBegin); begin >= 0 {
+		if end := strings.Index(existing[begin:], javaEnvBlockEnd); end >= 0 {
+			existing = existing[:begin] + strings.TrimPrefix(existing[begin+end+len(javaEnvBlockEnd):], "\n")
+		}
+	}
+	if existing == "" {
+		existing = "#!/usr/bin/env bash\n"
+	}
+	if !strings.HasSuffix(existing, "\n") {
+		existing += "\n"
+	}
+	block := fmt.Sprintf("%s\nexport JAVA_HOME=%q\nexport PATH=\"$JAVA_HOME/bin:$PATH\"\n%s\n", javaEnvBlockBegin, home, javaEnvBlockEnd)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(existing+block), 0755)
+}
+
+// javaHomeReady reports whether home contains a java executable.
+// javaHomeReady 判断 home 中是否包含 java 可执行文件。
+func javaHomeReady(home string) bool {
+	return fileExists(filepath.Join(home, "bin", "java"))
+}
@@ -0,0 +1,206 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package installer
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// writeJavaTarball writes a minimal JDK tarball with a single top-level directory.
+// writeJavaTarball 写入只包含一个顶层目录的最小 JDK 安装包。
+func writeJavaTarball(t *testing.T, path string) []byte {
+	t.Helper()
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gz := gzip.NewWriter(file)
+	tw := tar.NewWriter(gz)
+	top := "jdk-" + ManagedJavaRelease + "/"
+	for _, header := range []*tar.Header{
+		{Name: top, Typeflag: tar.TypeDir, Mode: 0755},
+		{Name: top + "bin/", Typeflag: tar.TypeDir, Mode: 0755},
+		{Name: top + "bin/java", Typeflag: tar.TypeReg, Mode: 0755, Size: int64(len("#!/bin/sh\n"))},
+	} {
+		if err := tw.WriteHeader(header); err != nil {
+			t.Fatal(err)
+		}
+		if header.Typeflag == tar.TypeReg {
+			if _, err := tw.Write([]byte("#!/bin/sh\n")); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := file.Close(); err != nil {
+		t.Fatal(err)
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return content
+}
+
+func withManagedJavaTestEnv(t *testing.T, systemJava int) {
+	t.Helper()
+	if runtime.GOOS != "linux" {
+		t.Skip("automatic Java installation is Linux only")
+	}
+	if _, err := managedJavaArch(runtime.GOARCH); err != nil {
+		t.Skip(err)
+	}
+	oldRoot, oldDetect, oldURLs := javaInstallRoot, detectJavaMajorVersion, javaMirrorURLs
+	javaInstallRoot = t.TempDir()
+	detectJavaMajorVersion = func() int { return systemJava }
+	t.Cleanup(func() { javaInstallRoot, detectJavaMajorVersion, javaMirrorURLs = oldRoot, oldDetect, oldURLs })
+}
+
+func TestExecuteStepInstallJava_installsFromPackageAndWritesEnv(t *testing.T) {
+	withManagedJavaTestEnv(t, 0)
+	installDir := t.TempDir()
+	envPath := filepath.Join(installDir, "config", javaEnvScript)
+	if err := os.MkdirAll(filepath.Dir(envPath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(envPath, []byte("#!/usr/bin/env bash\nSEATUNNEL_HOME=/opt/seatunnel\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	packagePath := filepath.Join(t.TempDir(), "jdk.tar.gz")
+	writeJavaTarball(t, packagePath)
+
+	m := NewInstallerManager()
+	params := &InstallParams{InstallDir: installDir, AutoInstallJava: true, JavaPackagePath: packagePath}
+	if err := m.executeStepInstallJava(context.Background(), params, &NoOpProgressReporter{}); err != nil {
+		t.Fatalf("install java failed: %v", err)
+	}
+	if params.JavaHome != managedJavaHome() || !javaHomeReady(params.JavaHome) {
+		t.Fatalf("JavaHome = %q, want extracted %q", params.JavaHome, managedJavaHome())
+	}
+	if _, err := os.Stat(managedJavaHome() + ".partial"); !os.IsNotExist(err) {
+		t.Fatalf("staging directory should be gone, stat err = %v", err)
+	}
+
+	// Rerun reuses the managed Java and keeps a single managed block
+	// 重复执行复用已安装的 Java，且只保留一个托管片段
+	params = &InstallParams{InstallDir: installDir, AutoInstallJava: true, JavaPackagePath: filepath.Join(t.TempDir(), "missing.tar.gz")}
+	if err := m.executeStepInstallJava(context.Background(), params, &NoOpProgressReporter{}); err != nil {
+		t.Fatalf("rerun failed: %v", err)
+	}
+	content, err := os.ReadFile(envPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	env := string(content)
+	if !strings.Contains(env, "SEATUNNEL_HOME=/opt/seatunnel") {
+		t.Fatalf("existing env lines should be kept, got:\n%s", env)
+	}
+	if strings.Count(env, javaEnvBlockBegin) != 1 || !strings.Contains(env, fmt.Sprintf("export JAVA_HOME=%q", managedJavaHome())) {
+		t.Fatalf("expected exactly one managed JAVA_HOME block, got:\n%s", env)
+	}
+}
+
+func TestExecuteStepInstallJava_skipsWhenDisabledOrJavaPresent(t *testing.T) {
+	withManagedJavaTestEnv(t, 11)
+	installDir := t.TempDir()
+	m := NewInstallerManager()
+
+	for _, params := range []*InstallParams{
+		{InstallDir: installDir},
+		{InstallDir: installDir, AutoInstallJava: true},
+	} {
+		if err := m.executeStepInstallJava(context.Background(), params, &NoOpProgressReporter{}); err != nil {
+			t.Fatalf("step failed: %v", err)
+		}
+		if params.JavaHome != "" {
+			t.Fatalf("JavaHome should stay empty, got %q", params.JavaHome)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(installDir, "config", javaEnvScript)); !os.IsNotExist(err) {
+		t.Fatalf("env script should not be written, stat err = %v", err)
+	}
+}
+
+func TestExecuteStepInstallJava_downloadFallsBackToNextMirror(t *testing.T) {
+	withManagedJavaTestEnv(t, 0)
+	content := writeJavaTarball(t, filepath.Join(t.TempDir(), "jdk.tar.gz"))
+	sum := sha256.Sum256(content)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/broken/"):
+			http.NotFound(w, r)
+		case strings.HasSuffix(r.URL.Path, ".sha256.txt"):
+			fmt.Fprintf(w, "%s  %s\n", hex.EncodeToString(sum[:]), filepath.Base(strings.TrimSuffix(r.URL.Path, ".sha256.txt")))
+		default:
+			_, _ = w.Write(content)
+		}
+	}))
+	defer server.Close()
+	javaMirrorURLs = map[JavaMirror]string{
+		JavaMirrorTsinghua: server.URL + "/broken/%s/%s",
+		JavaMirrorAdoptium: server.URL + "/ok/%s/%s",
+	}
+
+	m := NewInstallerManager()
+	m.tempDir = t.TempDir()
+	params := &InstallParams{InstallDir: t.TempDir(), AutoInstallJava: true, JavaMirror: JavaMirrorTsinghua}
+	if err := m.executeStepInstallJava(context.Background(), params, &NoOpProgressReporter{}); err != nil {
+		t.Fatalf("install java failed: %v", err)
+	}
+	if !javaHomeReady(params.JavaHome) {
+		t.Fatalf("java not installed at %q", params.JavaHome)
+	}
+	if entries, _ := os.ReadDir(m.tempDir); len(entries) != 0 {
+		t.Fatalf("downloaded package should be removed, got %d entries", len(entries))
+	}
+}
+
+func TestJavaMirrorOrder(t *testing.T) {
+	tests := []struct {
+		preferred JavaMirror
+		mirror    MirrorSource
+		want      JavaMirror
+	}{
+		{"", MirrorAliyun, JavaMirrorTsinghua},
+		{"", MirrorApache, JavaMirrorAdoptium},
+		{JavaMirrorTsinghua, MirrorApache, JavaMirrorTsinghua},
+	}
+	for _, tt := range tests {
+		order := javaMirrorOrder(tt.preferred, tt.mirror)
+		if len(order) != 2 || order[0] != tt.want {
+			t.Errorf("javaMirrorOrder(%q, %q) = %v, want %s first", tt.preferred, tt.mirror, order, tt.want)
+		}
+	}
+}
@@ -0,0 +1,379 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package installer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/seatunnel/seatunnelX/agent/internal/logger"
+	"github.com/seatunnel/seatunnelX/agent/internal/state"
+)
+
+const (
+	// MaxLogFileSizeMB bounds the size of one log file before it is rolled over
+	// MaxLogFileSizeMB 限制单个日志文件滚动前的大小
+	MaxLogFileSizeMB = 10240
+
+	// MaxLogBackups bounds the number of rolled log files kept per appender
+	// MaxLogBackups 限制每个 appender 保留的滚动日志文件数量
+	MaxLogBackups = 1000
+
+	// defaultGCLogFileCount and defaultGCLogFileSizeMB apply when rotation sizes are unset
+	// defaultGCLogFileCount 与 defaultGCLogFileSizeMB 在未设置滚动参数时使用
+	defaultGCLogFileCount  = 5
+	defaultGCLogFileSizeMB = 100
+
+	// GCLogBlockBegin and GCLogBlockEnd delimit the GC log flags managed in jvm_options files
+	// GCLogBlockBegin 与 GCLogBlockEnd 界定 jvm_options 文件中托管的 GC 日志参数
+	GCLogBlockBegin = "# BEGIN SEATUNNELX MANAGED GC LOG OPTIONS"
+	GCLogBlockEnd   = "# END SEATUNNELX MANAGED GC LOG OPTIONS"
+)
+
+// LogConfig contains engine log rotation, GC log and log disk quota settings
+// LogConfig 包含引擎日志滚动、GC 日志与日志磁盘配额设置
+type LogConfig struct {
+	// MaxFileSizeMB rolls a log file over once it reaches this size, 0 keeps the packaged policy
+	// MaxFileSizeMB 日志文件达到该大小时滚动，为 0 时保留安装包自带策略
+	MaxFileSizeMB int `json:"max_file_size_mb,omitempty"`
+
+	// MaxBackups is the number of rolled files kept per log, 0 keeps all of them
+	// MaxBackups 是每个日志保留的滚动文件数量，为 0 时全部保留
+	MaxBackups int `json:"max_backups,omitempty"`
+
+	// Compress gzips rolled log files
+	// Compress 使用 gzip 压缩滚动后的日志文件
+	Compress bool `json:"compress,omitempty"`
+
+	// GCLog enables rotated GC logging into the logs directory
+	// GCLog 启用写入日志目录的滚动 GC 日志
+	GCLog bool `json:"gc_log,omitempty"`
+
+	// DiskQuotaMB caps the total size of the logs directory, enforced by the Agent cleanup job; 0 disables it
+	// DiskQuotaMB 限制日志目录的总大小，由 Agent 清理任务执行；为 0 时不启用
+	DiskQuotaMB int `json:"disk_quota_mb,omitempty"`
+}
+
+// Validate validates the log configuration
+// Validate 验证日志配置
+func (l *LogConfig) Validate() error {
+	if l.MaxFileSizeMB < 0 || l.MaxFileSizeMB > MaxLogFileSizeMB {
+		return fmt.Errorf("max_file_size_mb must be between 0 and %d", MaxLogFileSizeMB)
+	}
+	if l.MaxBackups < 0 || l.MaxBackups > MaxLogBackups {
+		return fmt.Errorf("max_backups must be between 0 and %d", MaxLogBackups)
+	}
+	if l.DiskQuotaMB < 0 {
+		return errors.New("disk_quota_mb must not be negative")
+	}
+	return nil
+}
+
+// detectJavaMajorVersion returns the major version of the Java runtime on the host, 0 if unknown
+// detectJavaMajorVersion 返回主机上 Java 运行时的主版本号，未知时返回 0
+var detectJavaMajorVersion = func() int {
+	major, _, err := (&DefaultSystemInfoProvider{}).GetJavaVersion()
+	if err != nil {
+		return 0
+	}
+	return major
+}
+
+// configureLogs applies log rotation to log4j2.properties and GC logging to the jvm_options files
+// configureLogs 将日志滚动写入 log4j2.properties，并将 GC 日志写入 jvm_options 文件
+func (m *InstallerManager) configureLogs(configDir string, params *InstallParams) error {
+	if params.Log == nil {
+		return nil
+	}
+
+	log4j2Path := filepath.Join(configDir, "log4j2.properties")
+	if err := rewriteConfigFile(log4j2Path, func(content string) string {
+		return renderLog4j2Rotation(content, params.Log)
+	}); err != nil {
+		return err
+	}
+
+	legacy := false
+	if params.Log.GCLog {
+		if major := detectJavaMajorVersion(); major > 0 && major < 9 {
+			legacy = true
+		}
+	}
+	logsDir := filepath.Join(params.InstallDir, "logs")
+	files := map[string]string{"jvm_options": "gc.log"}
+	if params.DeploymentMode == DeploymentModeSeparated {
+		files = map[string]string{"jvm_master_options": "gc-master.log", "jvm_worker_options": "gc-worker.log"}
+	}
+	for optionsFile, gcFile := range files {
+		gcLogPath := filepath.Join(logsDir, gcFile)
+		if err := rewriteConfigFile(filepath.Join(configDir, optionsFile), func(content string) string {
+			return renderGCLogOptions(content, params.Log, gcLogPath, legacy)
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// recordLogPolicy registers or clears the logs directory quota enforced by the Agent cleanup job
+// recordLogPolicy 注册或清除由 Agent 清理任务执行的日志目录配额
+func (m *InstallerManager) recordLogPolicy(ctx context.Context, params *InstallParams) {
+	if params.Log == nil {
+		return
+	}
+	var err error
+	if params.Log.DiskQuotaMB > 0 {
+		err = m.state.PutLogPolicy(&state.LogPolicyRecord{
+			InstallDir: params.InstallDir,
+			QuotaBytes: int64(params.Log.DiskQuotaMB) * 1024 * 1024,
+		})
+	} else {
+		err = m.state.DeleteLogPolicy(params.InstallDir)
+	}
+	if err != nil {
+		logger.WarnF(ctx, "[InstallStepByStep] Failed to persist log quota: %v / 持久化日志配额失败：%v", err, err)
+	}
+}
+
+// rewriteConfigFile backs up and rewrites a config file; missing files are skipped
+// rewriteConfigFile 备份并改写配置文件；文件不存在时跳过
+func rewriteConfigFile(filePath string, render func(string) string) error {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("%w: failed to read %s: %v", ErrConfigGenerationFailed, filePath, err)
+	}
+	if err := backupFile(filePath); err != nil {
+		return fmt.Errorf("%w: %v", ErrConfigGenerationFailed, err)
+	}
+	if err := os.WriteFile(filePath, []byte(render(string(content))), 0o644); err != nil {
+		return fmt.Errorf("%w: failed to write %s: %v", ErrConfigGenerationFailed, filePath, err)
+	}
+	return nil
+}
+
+// renderLog4j2Rotation applies size based rollover, backup retention and compression to every
+// RollingFile appender of a log4j2.properties file. Rendering is idempotent.
+// renderLog4j2Rotation 为 log4j2.properties 中的每个 RollingFile appender 设置按大小滚动、备份保留与压缩；渲染是幂等的。
+func renderLog4j2Rotation(content string, cfg *LogConfig) string {
+	props := parseProperties(content)
+	for _, prefix := range props.rollingAppenders() {
+		if cfg.MaxFileSizeMB > 0 {
+			props.setDefault(prefix+"policies.type", "Policies")
+			props.set(prefix+"policies.size.type", "SizeBasedTriggeringPolicy")
+			props.set(prefix+"policies.size.size", fmt.Sprintf("%dMB", cfg.MaxFileSizeMB))
+		}
+
+		if pattern, ok := props.get(prefix + "filePattern"); ok {
+			pattern = strings.TrimSuffix(pattern, ".gz")
+			if cfg.Compress {
+				pattern += ".gz"
+			}
+			props.set(prefix+"filePattern", pattern)
+		}
+
+		props.remove(prefix + "strategy.action.")
+		fileName, ok := props.get(prefix + "fileName")
+		if cfg.MaxBackups <= 0 || !ok {
+			continue
+		}
+		basePath, base := ".", fileName
+		if index := strings.LastIndex(fileName, "/"); index >= 0 {
+			basePath, base = fileName[:index], fileName[index+1:]
+		}
+		props.setDefault(prefix+"strategy.type", "DefaultRolloverStrategy")
+		props.set(prefix+"strategy.action.type", "Delete")
+		props.set(prefix+"strategy.action.basePath", basePath)
+		props.set(prefix+"strategy.action.maxDepth", "1")
+		props.set(prefix+"strategy.action.condition.type", "IfFileName")
+		props.set(prefix+"strategy.action.condition.glob", base+".*")
+		props.set(prefix+"strategy.action.condition.nested.type", "IfAccumulatedFileCount")
+		props.set(prefix+"strategy.action.condition.nested.exceeds", fmt.Sprintf("%d", cfg.MaxBackups))
+	}
+	return props.String()
+}
+
+// renderGCLogOptions regenerates the managed GC log block of a jvm_options file; the block is
+// dropped when GC logging is disabled. Java 8 uses the legacy rotation flags.
+// renderGCLogOptions 重新生成 jvm_options 文件中托管的 GC 日志块；关闭 GC 日志时删除该块。Java 8 使用旧版滚动参数。
+func renderGCLogOptions(content string, cfg *LogConfig, gcLogPath string, legacy bool) string {
+	lines := strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+	result := make([]string, 0, len(lines)+8)
+	inBlock := false
+	for _, line := range lines {
+		switch strings.TrimSpace(line) {
+		case GCLogBlockBegin:
+			inBlock = true
+			continue
+		case GCLogBlockEnd:
+			inBlock = false
+			continue
+		}
+		if !inBlock {
+			result = append(result, line)
+		}
+	}
+	for len(result) > 0 && strings.TrimSpace(result[len(result)-1]) == "" {
+		result = result[:len(result)-1]
+	}
+	if !cfg.GCLog {
+		return strings.Join(result, "\n") + "\n"
+	}
+
+	fileCount, fileSizeMB := cfg.MaxBackups, cfg.MaxFileSizeMB
+	if fileCount <= 0 {
+		fileCount = defaultGCLogFileCount
+	}
+	if fileSizeMB <= 0 {
+		fileSizeMB = defaultGCLogFileSizeMB
+	}
+	var flags []string
+	if legacy {
+		flags = []string{
+			"-Xloggc:" + gcLogPath,
+			"-XX:+PrintGCDetails",
+			"-XX:+PrintGCDateStamps",
+			"-XX:+UseGCLogFileRotation",
+			fmt.Sprintf("-XX:NumberOfGCLogFiles=%d", fileCount),
+			fmt.Sprintf("-XX:GCLogFileSize=%dM", fileSizeMB),
+		}
+	} else {
+		flags = []string{fmt.Sprintf("-Xlog:gc*:file=%s:time,uptime,level,tags:filecount=%d,filesize=%dm", gcLogPath, fileCount, fileSizeMB)}
+	}
+
+	if len(result) > 0 {
+		result = append(result, "")
+	}
+	result = append(result, GCLogBlockBegin)
+	result = append(result, flags...)
+	result = append(result, GCLogBlockEnd)
+	return strings.Join(result, "\n") + "\n"
+}
+
+// propertiesFile is a line preserving view of a Java properties file
+// propertiesFile 是保留原始行的 Java properties 文件视图
+type propertiesFile struct {
+	lines []string
+}
+
+func parseProperties(content string) *propertiesFile {
+	return &propertiesFile{lines: strings.Split(content, "\n")}
+}
+
+func (p *propertiesFile) String() string {
+	return strings.Join(p.lines, "\n")
+}
+
+// entry returns the key and value of a property line
+// entry 返回属性行的键和值
+func (p *propertiesFile) entry(index int) (string, string, bool) {
+	trimmed := strings.TrimSpace(p.lines[index])
+	if trimmed == "" || strings.HasPrefix(trimmed, "#") || strings.HasPrefix(trimmed, "!") {
+		return "", "", false
+	}
+	key, value, ok := strings.Cut(trimmed, "=")
+	if !ok {
+		return "", "", false
+	}
+	return strings.TrimSpace(key), strings.TrimSpace(value), true
+}
+
+func (p *propertiesFile) get(key string) (string, bool) {
+	for index := range p.lines {
+		if k, v, ok := p.entry(index); ok && k == key {
+			return v, true
+		}
+	}
+	return "", false
+}
+
+// set replaces the value of key, or inserts it after the last property sharing its appender prefix
+// set 替换 key 的值，不存在时插入到同一 appender 前缀的最后一个属性之后
+func (p *propertiesFile) set(key, value string) {
+	line := key + " = " + value
+	found := false
+	for index := range p.lines {
+		if k, _, ok := p.entry(index); ok && k == key {
+			p.lines[index] = line
+			found = true
+		}
+	}
+	if found {
+		return
+	}
+
+	group := key
+	if parts := strings.SplitN(key, ".", 3); len(parts) == 3 {
+		group = parts[0] + "." + parts[1] + "."
+	}
+	insertAt := -1
+	for index := range p.lines {
+		if k, _, ok := p.entry(index); ok && strings.HasPrefix(k, group) {
+			insertAt = index + 1
+		}
+	}
+	if insertAt < 0 {
+		insertAt = len(p.lines)
+		for insertAt > 0 && strings.TrimSpace(p.lines[insertAt-1]) == "" {
+			insertAt--
+		}
+	}
+	p.lines = append(p.lines[:insertAt], append([]string{line}, p.lines[insertAt:]...)...)
+}
+
+func (p *propertiesFile) setDefault(key, value string) {
+	if _, ok := p.get(key); !ok {
+		p.set(key, value)
+	}
+}
+
+// remove drops every property whose key starts with prefix
+// remove 删除所有键以 prefix 开头的属性
+func (p *propertiesFile) remove(prefix string) {
+	kept := make([]string, 0, len(p.lines))
+	for index, line := range p.lines {
+		if k, _, ok := p.entry(index); ok && strings.HasPrefix(k, prefix) {
+			continue
+		}
+		kept = append(kept, line)
+	}
+	p.lines = kept
+}
+
+// rollingAppenders returns the "appender.<id>." prefixes of RollingFile appenders
+// rollingAppenders 返回 RollingFile appender 的 "appender.<id>." 前缀
+func (p *propertiesFile) rollingAppenders() []string {
+	var prefixes []string
+	for index := range p.lines {
+		k, v, ok := p.entry(index)
+		if !ok || !strings.EqualFold(v, "RollingFile") {
+			continue
+		}
+		parts := strings.Split(k, ".")
+		if len(parts) == 3 && parts[0] == "appender" && parts[2] == "type" {
+			prefixes = append(prefixes, "appender."+parts[1]+".")
+		}
+	}
+	return prefixes
+}
@@ -0,0 +1,177 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package installer
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/seatunnel/seatunnelX/agent/internal/state"
+)
+
+const log4j2RotationFixture = `rootLogger.level = INFO
+rootLogger.appenderRef.file.ref = fileAppender
+
+appender.file.type = RollingFile
+appender.file.name = fileAppender
+appender.file.fileName = ${file_path}/${file_name}.log
+appender.file.filePattern = ${file_path}/${file_name}.log.%d{yyyy-MM-dd}-%i
+appender.file.append = true
+appender.file.layout.type = PatternLayout
+appender.file.layout.pattern = %d{yyyy-MM-dd HH:mm:ss,SSS} %-5p [%-30.30c{1.}] [%t] - %m%n
+appender.file.policies.type = Policies
+appender.file.policies.time.type = TimeBasedTriggeringPolicy
+appender.file.policies.time.modulate = true
+appender.file.strategy.type = DefaultRolloverStrategy
+appender.file.strategy.fileIndex = nomax
+
+appender.consoleStdout.type = Console
+appender.consoleStdout.name = consoleStdoutAppender
+`
+
+func TestRenderLog4j2RotationConfiguresRollingAppenders(t *testing.T) {
+	cfg := &LogConfig{MaxFileSizeMB: 256, MaxBackups: 20, Compress: true}
+	rendered := renderLog4j2Rotation(log4j2RotationFixture, cfg)
+
+	props := parseProperties(rendered)
+	expected := map[string]string{
+		"appender.file.policies.size.type":                       "SizeBasedTriggeringPolicy",
+		"appender.file.policies.size.size":                       "256MB",
+		"appender.file.filePattern":                              "${file_path}/${file_name}.log.%d{yyyy-MM-dd}-%i.gz",
+		"appender.file.strategy.action.type":                     "Delete",
+		"appender.file.strategy.action.basePath":                 "${file_path}",
+		"appender.file.strategy.action.condition.glob":           "${file_name}.log.*",
+		"appender.file.strategy.action.condition.nested.type":    "IfAccumulatedFileCount",
+		"appender.file.strategy.action.condition.nested.exceeds": "20",
+		"appender.file.policies.time.type":                       "TimeBasedTriggeringPolicy",
+	}
+	for key, want := range expected {
+		if got, ok := props.get(key); !ok || got != want {
+			t.Errorf("%s = %q (present=%v), want %q", key, got, ok, want)
+		}
+	}
+	if _, ok := props.get("appender.consoleStdout.policies.size.size"); ok {
+		t.Error("console appender must not get a rollover policy")
+	}
+	if strings.Index(rendered, "appender.file.policies.size.size") > strings.Index(rendered, "appender.consoleStdout.type") {
+		t.Error("new properties should stay grouped with their appender")
+	}
+
+	if again := renderLog4j2Rotation(rendered, cfg); again != rendered {
+		t.Fatalf("rendering must be idempotent, got:\n%s", again)
+	}
+
+	relaxed := renderLog4j2Rotation(rendered, &LogConfig{MaxFileSizeMB: 256})
+	if strings.Contains(relaxed, ".gz") || strings.Contains(relaxed, "strategy.action") {
+		t.Fatalf("disabling compression and retention should drop them, got:\n%s", relaxed)
+	}
+}
+
+func TestRenderGCLogOptions(t *testing.T) {
+	content := "-Xms2g\n-Xmx2g\n"
+	cfg := &LogConfig{GCLog: true, MaxBackups: 3, MaxFileSizeMB: 50}
+
+	unified := renderGCLogOptions(content, cfg, "/opt/seatunnel/logs/gc.log", false)
+	want := "-Xms2g\n-Xmx2g\n\n" + GCLogBlockBegin + "\n" +
+		"-Xlog:gc*:file=/opt/seatunnel/logs/gc.log:time,uptime,level,tags:filecount=3,filesize=50m\n" +
+		GCLogBlockEnd + "\n"
+	if unified != want {
+		t.Fatalf("unexpected unified GC log options:\n%s", unified)
+	}
+	if again := renderGCLogOptions(unified, cfg, "/opt/seatunnel/logs/gc.log", false); again != unified {
+		t.Fatalf("rendering must be idempotent, got:\n%s", again)
+	}
+
+	legacy := renderGCLogOptions(unified, cfg, "/opt/seatunnel/logs/gc.log", true)
+	if strings.Contains(legacy, "-Xlog:") || !strings.Contains(legacy, "-XX:NumberOfGCLogFiles=3") || !strings.Contains(legacy, "-XX:GCLogFileSize=50M") {
+		t.Fatalf("unexpected legacy GC log options:\n%s", legacy)
+	}
+
+	if disabled := renderGCLogOptions(legacy, &LogConfig{}, "", false); disabled != content {
+		t.Fatalf("disabling GC log should restore the original file, got %q", disabled)
+	}
+}
+
+func TestConfigureLogsRecordsQuota(t *testing.T) {
+	installDir := t.TempDir()
+	configDir := filepath.Join(installDir, "config")
+	if err := os.MkdirAll(configDir, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	for name, content := range map[string]string{
+		"log4j2.properties":  log4j2RotationFixture,
+		"jvm_master_options": "-Xms2g\n",
+		"jvm_worker_options": "-Xms2g\n",
+	} {
+		if err := os.WriteFile(filepath.Join(configDir, name), []byte(content), 0o644); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+	originalDetect := detectJavaMajorVersion
+	detectJavaMajorVersion = func() int { return 8 }
+	t.Cleanup(func() { detectJavaMajorVersion = originalDetect })
+
+	store, _ := state.NewStore("")
+	manager := NewInstallerManager()
+	manager.SetStateStore(store)
+	params := &InstallParams{
+		InstallDir:     installDir,
+		DeploymentMode: DeploymentModeSeparated,
+		Log:            &LogConfig{MaxFileSizeMB: 100, GCLog: true, DiskQuotaMB: 512},
+	}
+	if err := manager.configureLogs(configDir, params); err != nil {
+		t.Fatalf("configureLogs returned error: %v", err)
+	}
+	worker, _ := os.ReadFile(filepath.Join(configDir, "jvm_worker_options"))
+	if !strings.Contains(string(worker), "-Xloggc:"+filepath.Join(installDir, "logs", "gc-worker.log")) {
+		t.Fatalf("expected legacy worker GC log flags, got:\n%s", worker)
+	}
+	if _, err := os.Stat(filepath.Join(configDir, "log4j2.properties.bak")); err != nil {
+		t.Fatalf("expected log4j2 backup: %v", err)
+	}
+
+	manager.recordLogPolicy(t.Context(), params)
+	policies := store.LogPolicies()
+	if len(policies) != 1 || policies[0].InstallDir != installDir || policies[0].QuotaBytes != 512*1024*1024 {
+		t.Fatalf("unexpected log policies: %+v", policies)
+	}
+
+	params.Log.DiskQuotaMB = 0
+	manager.recordLogPolicy(t.Context(), params)
+	if policies := store.LogPolicies(); len(policies) != 0 {
+		t.Fatalf("expected quota to be cleared, got %+v", policies)
+	}
+}
+
+func TestLogConfigValidate(t *testing.T) {
+	if err := (&LogConfig{MaxFileSizeMB: 100, MaxBackups: 10, DiskQuotaMB: 1024}).Validate(); err != nil {
+		t.Fatalf("expected valid config, got %v", err)
+	}
+	for _, cfg := range []*LogConfig{
+		{MaxFileSizeMB: -1},
+		{MaxFileSizeMB: MaxLogFileSizeMB + 1},
+		{MaxBackups: MaxLogBackups + 1},
+		{DiskQuotaMB: -5},
+	} {
+		if err := cfg.Validate(); err == nil {
+			t.Errorf("expected %+v to be rejected", cfg)
+		}
+	}
+}
@@ -31,6 +31,7 @@ import (
 	"compress/gzip"
 	"context"
 	"crypto/sha256"
+	"crypto/sha512"
 	"encoding/hex"
 	"errors"
 	"fmt"
@@ -38,12 +39,16 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
-	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/seatunnel/seatunnelX/agent/internal/logger"
+	"github.com/seatunnel/seatunnelX/agent/internal/state"
+	"github.com/seatunnel/seatunnelX/agent/internal/tracing"
+	"github.com/seatunnel/seatunnelX/internal/pkg/installhook"
+	"github.com/seatunnel/seatunnelX/internal/pkg/jvmopts"
 	seatunnelmeta "github.com/seatunnel/seatunnelX/internal/seatunnel"
 	"gopkg.in/yaml.v3"
 )
@@ -86,6 +91,10 @@ var (
 	// ErrInvalidMirrorSource indicates an invalid mirror source
 	// ErrInvalidMirrorSource 表示无效的镜像源
 	ErrInvalidMirrorSource = errors.New("invalid mirror source")
+
+	// ErrPortConflict indicates the master and worker ports collide on a host running both roles
+	// ErrPortConflict 表示同时运行两种角色的主机上 master 与 worker 端口冲突
+	ErrPortConflict = errors.New("cluster port and worker port must differ")
 )
 
 // MirrorSource represents the download mirror source
@@ -244,6 +253,10 @@ const (
 	// InstallStepExtract 是解压步骤
 	InstallStepExtract InstallStep = "extract"
 
+	// InstallStepInstallJava is the optional OpenJDK installation step
+	// InstallStepInstallJava 是可选的 OpenJDK 安装步骤
+	InstallStepInstallJava InstallStep = "install_java"
+
 	// InstallStepConfigureCluster is the cluster configuration step
 	// InstallStepConfigureCluster 是集群配置步骤
 	InstallStepConfigureCluster InstallStep = "configure_cluster"
@@ -264,6 +277,10 @@ const (
 	// InstallStepInstallPlugins 是插件安装步骤
 	InstallStepInstallPlugins InstallStep = "install_plugins"
 
+	// InstallStepConfigureSystemd is the optional systemd unit registration step
+	// InstallStepConfigureSystemd 是可选的 systemd unit 注册步骤
+	InstallStepConfigureSystemd InstallStep = "configure_systemd"
+
 	// InstallStepRegisterCluster is the cluster registration step
 	// InstallStepRegisterCluster 是集群注册步骤
 	InstallStepRegisterCluster InstallStep = "register_cluster"
@@ -380,6 +397,29 @@ type JVMConfig struct {
 	// WorkerHeapSize is the heap size for worker nodes (in GB)
 	// WorkerHeapSize 是 worker 节点的堆内存大小（GB）
 	WorkerHeapSize int `json:"worker_heap_size"`
+
+	// GC selects the garbage collector (g1, zgc, parallel, shenandoah, serial)
+	// GC 选择垃圾回收器（g1、zgc、parallel、shenandoah、serial）
+	GC string `json:"gc,omitempty"`
+
+	// MaxDirectMemoryMB sets -XX:MaxDirectMemorySize (in MB)
+	// MaxDirectMemoryMB 设置 -XX:MaxDirectMemorySize（MB）
+	MaxDirectMemoryMB int `json:"max_direct_memory_mb,omitempty"`
+
+	// ExtraOptions are additional JVM options, one flag per entry
+	// ExtraOptions 是额外的 JVM 参数，每项一个参数
+	ExtraOptions []string `json:"extra_options,omitempty"`
+}
+
+// fileOptions returns the options rendered into one jvm_options file with the given heap size
+// fileOptions 返回以指定堆大小渲染到单个 jvm_options 文件的参数
+func (j *JVMConfig) fileOptions(heapSizeGB int) jvmopts.Options {
+	return jvmopts.Options{
+		HeapSizeGB:        heapSizeGB,
+		GC:                jvmopts.GC(j.GC),
+		MaxDirectMemoryMB: j.MaxDirectMemoryMB,
+		ExtraOptions:      j.ExtraOptions,
+	}
 }
 
 // ConnectorConfig contains connector installation configuration
@@ -470,19 +510,21 @@ type StepInfo struct {
 
 // InstallationSteps defines all installation steps in order
 // InstallationSteps 定义所有安装步骤的顺序
-// Note: Agent manages SeaTunnel process lifecycle, no systemd auto-start needed
-// 注意：Agent 管理 SeaTunnel 进程生命周期，不需要 systemd 开机自启动
+// Note: Agent manages SeaTunnel process lifecycle; configure_systemd optionally hands it to systemd for boot persistence
+// 注意：Agent 管理 SeaTunnel 进程生命周期；configure_systemd 可选地交由 systemd 托管以便开机自启动
 // Note: Precheck is done separately via Prechecker, not part of installation steps
 // 注意：预检通过 Prechecker 单独完成，不是安装步骤的一部分
 var InstallationSteps = []StepInfo{
 	{Step: InstallStepDownload, Name: "download", Description: "Download package / 下载安装包", Retryable: true},
 	{Step: InstallStepVerify, Name: "verify", Description: "Verify checksum / 验证校验和", Retryable: true},
 	{Step: InstallStepExtract, Name: "extract", Description: "Extract package / 解压安装包", Retryable: true},
+	{Step: InstallStepInstallJava, Name: "install_java", Description: "Install Java / 安装 Java", Retryable: true},
 	{Step: InstallStepConfigureCluster, Name: "configure_cluster", Description: "Configure cluster / 配置集群", Retryable: true},
 	{Step: InstallStepConfigureCheckpoint, Name: "configure_checkpoint", Description: "Configure checkpoint / 配置检查点", Retryable: true},
 	{Step: InstallStepConfigureIMAP, Name: "configure_imap", Description: "Configure IMAP / 配置 IMAP", Retryable: true},
 	{Step: InstallStepConfigureJVM, Name: "configure_jvm", Description: "Configure JVM / 配置 JVM", Retryable: true},
 	{Step: InstallStepInstallPlugins, Name: "install_plugins", Description: "Install plugins / 安装插件", Retryable: true},
+	{Step: InstallStepConfigureSystemd, Name: "configure_systemd", Description: "Configure systemd / 配置 systemd", Retryable: true},
 	{Step: InstallStepRegisterCluster, Name: "register_cluster", Description: "Register to cluster / 注册到集群", Retryable: true},
 	{Step: InstallStepComplete, Name: "complete", Description: "Complete / 完成", Retryable: false},
 }
@@ -623,8 +665,8 @@ type PackageTransferInfo struct {
 	// FileSize 是安装包文件大小（字节）
 	FileSize int64 `json:"file_size"`
 
-	// Checksum is the SHA256 checksum for verification
-	// Checksum 是用于验证的 SHA256 校验和
+	// Checksum is the SHA256 or SHA-512 checksum for verification
+	// Checksum 是用于验证的 SHA256 或 SHA-512 校验和
 	Checksum string `json:"checksum"`
 
 	// DownloadURL is the URL to download from (for URL source)
@@ -887,8 +929,8 @@ type InstallParams struct {
 	// PackagePath 是本地安装包路径（传输后设置或用于本地源）
 	PackagePath string `json:"package_path,omitempty"`
 
-	// ExpectedChecksum is the expected SHA256 checksum of the package
-	// ExpectedChecksum 是安装包的预期 SHA256 校验和
+	// ExpectedChecksum is the expected SHA256 or SHA-512 checksum of the package
+	// ExpectedChecksum 是安装包的预期 SHA256 或 SHA-512 校验和
 	ExpectedChecksum string `json:"expected_checksum,omitempty"`
 
 	// DeploymentMode is the deployment mode (hybrid/separated)
@@ -955,6 +997,10 @@ type InstallParams struct {
 	// JobLogMode 控制混合日志或单 Job 独立日志文件模式。
 	JobLogMode JobLogMode `json:"job_log_mode,omitempty"`
 
+	// Log is the engine log rotation, GC log and log disk quota configuration
+	// Log 是引擎日志滚动、GC 日志与日志磁盘配额配置
+	Log *LogConfig `json:"log,omitempty"`
+
 	// JVM is the JVM memory configuration
 	// JVM 是 JVM 内存配置
 	JVM *JVMConfig `json:"jvm,omitempty"`
@@ -971,9 +1017,53 @@ type InstallParams struct {
 	// Connector 是连接器安装配置
 	Connector *ConnectorConfig `json:"connector,omitempty"`
 
+	// Hazelcast is the advanced Hazelcast member network configuration
+	// Hazelcast 是 Hazelcast 成员高级网络配置
+	Hazelcast *HazelcastNetworkConfig `json:"hazelcast,omitempty"`
+
+	// EnableSystemd registers a systemd unit so SeaTunnel starts again after a reboot (Linux with systemd only)
+	// EnableSystemd 注册 systemd unit，使 SeaTunnel 在主机重启后自动启动（仅限使用 systemd 的 Linux）
+	EnableSystemd bool `json:"enable_systemd,omitempty"`
+
+	// AutoInstallJava installs OpenJDK 11 under /opt/java when no supported Java is found
+	// AutoInstallJava 在未找到受支持的 Java 时将 OpenJDK 11 安装到 /opt/java
+	AutoInstallJava bool `json:"auto_install_java,omitempty"`
+
+	// JavaMirror is the preferred OpenJDK download source, the others are tried afterwards
+	// JavaMirror 是首选的 OpenJDK 下载源，失败后依次尝试其他下载源
+	JavaMirror JavaMirror `json:"java_mirror,omitempty"`
+
+	// JavaPackagePath is a local OpenJDK tar.gz used instead of downloading one
+	// JavaPackagePath 是本地 OpenJDK tar.gz 安装包，设置后不再下载
+	JavaPackagePath string `json:"java_package_path,omitempty"`
+
+	// JavaBundleDir is searched for a bundled OpenJDK package before downloading
+	// JavaBundleDir 是下载前查找随附 OpenJDK 安装包的目录
+	JavaBundleDir string `json:"java_bundle_dir,omitempty"`
+
+	// JavaHome is set by the install_java step to the Java home written into the env script
+	// JavaHome 由 install_java 步骤设置为写入环境脚本的 Java 目录
+	JavaHome string `json:"java_home,omitempty"`
+
 	// ClusterID is the cluster ID to register after installation (for cluster registration)
 	// ClusterID 是安装后要注册的集群 ID（用于集群注册）
 	ClusterID string `json:"cluster_id,omitempty"`
+
+	// CommandID is the INSTALL command driving this installation, recorded in the Agent state
+	// CommandID 是驱动本次安装的 INSTALL 命令 ID，记录在 Agent 状态中
+	CommandID string `json:"command_id,omitempty"`
+
+	// HostID is the Control Plane host ID, passed to webhook hooks
+	// HostID 是 Control Plane 中的主机 ID，传递给 Webhook 钩子
+	HostID string `json:"host_id,omitempty"`
+
+	// SkipSteps are steps managed outside SeaTunnelX that are reported as skipped
+	// SkipSteps 是由 SeaTunnelX 之外管理、上报为已跳过的步骤
+	SkipSteps []InstallStep `json:"skip_steps,omitempty"`
+
+	// Hooks run before and after the steps they are registered for
+	// Hooks 在其注册的步骤前后执行
+	Hooks []installhook.Hook `json:"hooks,omitempty"`
 }
 
 // DefaultInstallParams returns default installation parameters
@@ -1192,7 +1282,7 @@ func (j *JVMConfig) Validate() error {
 	if j.WorkerHeapSize < 1 {
 		return errors.New("worker_heap_size must be at least 1 GB")
 	}
-	return nil
+	return j.fileOptions(j.HybridHeapSize).Validate()
 }
 
 // BoolPtr returns a pointer to a bool value (helper function)
@@ -1212,6 +1302,11 @@ func (p *InstallParams) Validate() error {
 		return ErrInvalidNodeRole
 	}
 
+	if p.DeploymentMode == DeploymentModeSeparated && p.NodeRole == NodeRoleMasterWorker &&
+		p.ClusterPort > 0 && p.ClusterPort == p.WorkerPort {
+		return fmt.Errorf("%w: both use %d", ErrPortConflict, p.ClusterPort)
+	}
+
 	if p.Version == "" {
 		return errors.New("version is required")
 	}
@@ -1234,6 +1329,13 @@ func (p *InstallParams) Validate() error {
 		}
 	}
 
+	// Validate log config if provided / 验证日志配置（如果提供）
+	if p.Log != nil {
+		if err := p.Log.Validate(); err != nil {
+			return fmt.Errorf("log config validation failed: %w", err)
+		}
+	}
+
 	// Validate checkpoint config if provided / 验证检查点配置（如果提供）
 	if p.Checkpoint != nil {
 		if err := p.Checkpoint.Validate(); err != nil {
@@ -1247,6 +1349,17 @@ func (p *InstallParams) Validate() error {
 		}
 	}
 
+	// Validate Hazelcast network config if provided / 验证 Hazelcast 网络配置（如果提供）
+	if p.Hazelcast != nil {
+		if err := p.Hazelcast.Validate(); err != nil {
+			return fmt.Errorf("hazelcast network config validation failed: %w", err)
+		}
+	}
+
+	if p.JavaMirror != "" && !ValidateJavaMirror(p.JavaMirror) {
+		return fmt.Errorf("invalid java_mirror %q", p.JavaMirror)
+	}
+
 	return nil
 }
 
@@ -1325,6 +1438,10 @@ type InstallResult struct {
 	// Error is the error message if installation failed
 	// Error 是安装失败时的错误消息
 	Error string `json:"error,omitempty"`
+
+	// HookResults are the results of the step hooks that ran, in order
+	// HookResults 是已执行的步骤钩子结果，按执行顺序排列
+	HookResults []installhook.Result `json:"hook_results,omitempty"`
 }
 
 // InstallerManager manages SeaTunnel installation
@@ -1337,6 +1454,10 @@ type InstallerManager struct {
 	// tempDir is the temporary directory for downloads
 	// tempDir 是下载的临时目录
 	tempDir string
+
+	// state persists installation progress across Agent restarts (optional)
+	// state 在 Agent 重启之间持久化安装进度（可选）
+	state *state.Store
 }
 
 // NewInstallerManager creates a new InstallerManager instance
@@ -1390,23 +1511,41 @@ func (m *InstallerManager) InstallStepByStep(ctx context.Context, params *Instal
 
 	logger.InfoF(ctx, "[InstallStepByStep] JVM config: %+v", params.JVM)
 
+	// Remember what existed before so a cancelled run only removes what it created
+	// 记录安装前已存在的内容，取消时只清理本次创建的部分
+	_, statErr := os.Stat(params.InstallDir)
+	installDirExisted := statErr == nil
+	packagePathBefore := params.PackagePath
+	defer func() {
+		if ctx.Err() != nil && !result.Success {
+			m.cleanupCancelledInstall(ctx, params, installDirExisted, packagePathBefore)
+		}
+	}()
+
 	// Execute each step / 执行每个步骤
 	// Note: Precheck should be done separately via Prechecker before calling this
 	// 注意：预检应该在调用此方法之前通过 Prechecker 单独完成
 	steps := []struct {
 		step    InstallStep
-		execute func() error
+		execute func(context.Context) error
 	}{
-		{InstallStepDownload, func() error { return m.executeStepDownload(ctx, params, reporter) }},
-		{InstallStepVerify, func() error { return m.executeStepVerify(params, reporter) }},
-		{InstallStepExtract, func() error { return m.executeStepExtract(ctx, params, reporter) }},
-		{InstallStepConfigureCluster, func() error { return m.executeStepConfigureCluster(params, reporter) }},
-		{InstallStepConfigureCheckpoint, func() error { return m.executeStepConfigureCheckpoint(ctx, params, reporter) }},
-		{InstallStepConfigureIMAP, func() error { return m.executeStepConfigureIMAP(ctx, params, reporter) }},
-		{InstallStepConfigureJVM, func() error { return m.executeStepConfigureJVM(params, reporter) }},
-		{InstallStepInstallPlugins, func() error { return m.executeStepInstallPlugins(ctx, params, reporter) }},
-		{InstallStepRegisterCluster, func() error { return m.executeStepRegisterCluster(params, reporter) }},
-	}
+		{InstallStepDownload, func(ctx context.Context) error { return m.executeStepDownload(ctx, params, reporter) }},
+		{InstallStepVerify, func(context.Context) error { return m.executeStepVerify(params, reporter) }},
+		{InstallStepExtract, func(ctx context.Context) error { return m.executeStepExtract(ctx, params, reporter) }},
+		{InstallStepInstallJava, func(ctx context.Context) error { return m.executeStepInstallJava(ctx, params, reporter) }},
+		{InstallStepConfigureCluster, func(context.Context) error { return m.executeStepConfigureCluster(params, reporter) }},
+		{InstallStepConfigureCheckpoint, func(ctx context.Context) error { return m.executeStepConfigureCheckpoint(ctx, params, reporter) }},
+		{InstallStepConfigureIMAP, func(ctx context.Context) error { return m.executeStepConfigureIMAP(ctx, params, reporter) }},
+		{InstallStepConfigureJVM, func(context.Context) error { return m.executeStepConfigureJVM(params, reporter) }},
+		{InstallStepInstallPlugins, func(ctx context.Context) error { return m.executeStepInstallPlugins(ctx, params, reporter) }},
+		{InstallStepConfigureSystemd, func(ctx context.Context) error { return m.executeStepConfigureSystemd(ctx, params, reporter) }},
+		{InstallStepRegisterCluster, func(context.Context) error { return m.executeStepRegisterCluster(params, reporter) }},
+	}
+
+	// Track progress in the Agent state so an interrupted run can be resumed or reported
+	// 在 Agent 状态中记录进度，以便中断的安装可被恢复或上报
+	record := m.beginInstallRecord(ctx, params)
+	defer m.finishInstallRecord(ctx, params)
 
 	for _, s := range steps {
 		select {
@@ -1418,9 +1557,32 @@ func (m *InstallerManager) InstallStepByStep(ctx context.Context, params *Instal
 		default:
 		}
 
+		if params.stepSkipped(s.step) {
+			logger.InfoF(ctx, "[InstallStepByStep] Step %s skipped by request", s.step)
+			reporter.ReportStepSkipped(s.step, "Skipped by request / 按请求跳过")
+			continue
+		}
+
+		if canResumeStep(record, s.step, params) {
+			logger.InfoF(ctx, "[InstallStepByStep] Step %s already completed by interrupted run, skipping", s.step)
+			reporter.ReportStepSkipped(s.step, "Completed by interrupted installation / 已由中断的安装完成")
+			continue
+		}
+
 		logger.InfoF(ctx, "[InstallStepByStep] Executing step: %s", s.step)
+		record.CurrentStep = string(s.step)
+		m.saveInstallRecord(ctx, record)
 		reporter.ReportStepStart(s.step)
-		if err := s.execute(); err != nil {
+		err := m.runStepHooks(ctx, params, s.step, installhook.PhasePre, reporter, result)
+		if err == nil {
+			stepCtx, span := tracing.Start(ctx, "Install."+string(s.step))
+			err = s.execute(stepCtx)
+			tracing.End(span, err)
+		}
+		if err == nil {
+			err = m.runStepHooks(ctx, params, s.step, installhook.PhasePost, reporter, result)
+		}
+		if err != nil {
 			logger.ErrorF(ctx, "[InstallStepByStep] Step %s failed: %v", s.step, err)
 			reporter.ReportStepFailed(s.step, err)
 			result.Success = false
@@ -1430,6 +1592,8 @@ func (m *InstallerManager) InstallStepByStep(ctx context.Context, params *Instal
 			return result, err
 		}
 		logger.InfoF(ctx, "[InstallStepByStep] Step %s completed", s.step)
+		record.CompletedSteps = append(record.CompletedSteps, string(s.step))
+		m.saveInstallRecord(ctx, record)
 		reporter.ReportStepComplete(s.step)
 	}
 
@@ -1443,6 +1607,26 @@ func (m *InstallerManager) InstallStepByStep(ctx context.Context, params *Instal
 	return result, nil
 }
 
+// cleanupCancelledInstall removes partial state left by a cancelled installation:
+// the downloaded package and an install directory created by this run.
+// cleanupCancelledInstall 清理被取消安装遗留的部分状态：
+// 已下载的安装包以及本次运行创建的安装目录。
+func (m *InstallerManager) cleanupCancelledInstall(ctx context.Context, params *InstallParams, installDirExisted bool, packagePathBefore string) {
+	if params.PackagePath != "" && params.PackagePath != packagePathBefore &&
+		strings.HasPrefix(filepath.Clean(params.PackagePath), filepath.Clean(m.tempDir)+string(os.PathSeparator)) {
+		if err := os.Remove(params.PackagePath); err != nil && !os.IsNotExist(err) {
+			logger.WarnF(ctx, "[InstallStepByStep] Failed to remove partial package %s: %v / 清理未完成的安装包失败", params.PackagePath, err)
+		}
+	}
+	if !installDirExisted && params.InstallDir != "" {
+		if err := os.RemoveAll(params.InstallDir); err != nil {
+			logger.WarnF(ctx, "[InstallStepByStep] Failed to remove partial install dir %s: %v / 清理未完成的安装目录失败", params.InstallDir, err)
+			return
+		}
+		logger.InfoF(ctx, "[InstallStepByStep] Removed partial install dir %s after cancellation / 取消后已清理未完成的安装目录", params.InstallDir)
+	}
+}
+
 // ExecuteStep executes a single installation step (for retry support)
 // ExecuteStep 执行单个安装步骤（支持重试）
 func (m *InstallerManager) ExecuteStep(ctx context.Context, step InstallStep, params *InstallParams, reporter ProgressReporter) error {
@@ -1451,6 +1635,7 @@ func (m *InstallerManager) ExecuteStep(ctx context.Context, step InstallStep, pa
 	}
 
 	reporter.ReportStepStart(step)
+	ctx, span := tracing.Start(ctx, "Install."+string(step))
 
 	var err error
 	switch step {
@@ -1460,6 +1645,8 @@ func (m *InstallerManager) ExecuteStep(ctx context.Context, step InstallStep, pa
 		err = m.executeStepVerify(params, reporter)
 	case InstallStepExtract:
 		err = m.executeStepExtract(ctx, params, reporter)
+	case InstallStepInstallJava:
+		err = m.executeStepInstallJava(ctx, params, reporter)
 	case InstallStepConfigureCluster:
 		err = m.executeStepConfigureCluster(params, reporter)
 	case InstallStepConfigureCheckpoint:
@@ -1470,11 +1657,14 @@ func (m *InstallerManager) ExecuteStep(ctx context.Context, step InstallStep, pa
 		err = m.executeStepConfigureJVM(params, reporter)
 	case InstallStepInstallPlugins:
 		err = m.executeStepInstallPlugins(ctx, params, reporter)
+	case InstallStepConfigureSystemd:
+		err = m.executeStepConfigureSystemd(ctx, params, reporter)
 	case InstallStepRegisterCluster:
 		err = m.executeStepRegisterCluster(params, reporter)
 	default:
 		err = fmt.Errorf("unknown step: %s / 未知步骤：%s", step, step)
 	}
+	tracing.End(span, err)
 
 	if err != nil {
 		reporter.ReportStepFailed(step, err)
@@ -1657,6 +1847,7 @@ func (m *InstallerManager) executeStepConfigureCluster(params *InstallParams, re
 	if _, err := m.ConfigureCluster(params); err != nil {
 		return err
 	}
+	m.recordLogPolicy(context.Background(), params)
 	reporter.Report(InstallStepConfigureCluster, 100, "Cluster configured / 集群配置完成")
 	return nil
 }
@@ -1844,16 +2035,18 @@ func (m *InstallerManager) executeStepRegisterCluster(params *InstallParams, rep
 	}
 
 	// Verify start script exists / 验证启动脚本存在
-	startScript := filepath.Join(params.InstallDir, "bin", "seatunnel-cluster.sh")
+	startScript := seatunnelScriptPath(params.InstallDir, "seatunnel-cluster")
 	if _, err := os.Stat(startScript); os.IsNotExist(err) {
 		reporter.Report(InstallStepRegisterCluster, 50, "Warning: start script not found, cluster may not start properly / 警告：启动脚本不存在，集群可能无法正常启动")
 	}
 
 	reporter.Report(InstallStepRegisterCluster, 80, "Installation verified, ready for cluster startup / 安装已验证，准备启动集群")
 
-	// Note: Actual cluster startup is handled by Control Plane sending START command
-	// 注意：实际的集群启动由 Control Plane 发送 START 命令处理
-	reporter.Report(InstallStepRegisterCluster, 100, "Cluster registration completed, waiting for startup command / 集群注册完成，等待启动命令")
+	// Note: Actual cluster startup is handled by Control Plane sending START command, which
+	// verifies Hazelcast membership through VerifyClusterMembership once the process is up
+	// 注意：实际的集群启动由 Control Plane 发送 START 命令处理，进程启动后通过
+	// VerifyClusterMembership 校验 Hazelcast 成员资格
+	reporter.Report(InstallStepRegisterCluster, 100, "Installation ready, membership is verified when the node starts / 安装就绪，节点启动时将校验集群成员资格")
 	return nil
 }
 
@@ -1881,7 +2074,12 @@ func (m *InstallerManager) configureCheckpointStorage(params *InstallParams) err
 			return fmt.Errorf("%w: failed to prepare OSS runtime dependencies: %v", ErrConfigGenerationFailed, err)
 		}
 	}
+	return writeCheckpointStorageConfig(params)
+}
 
+// writeCheckpointStorageConfig rewrites the checkpoint section of seatunnel.yaml without preparing runtime dependencies
+// writeCheckpointStorageConfig 仅改写 seatunnel.yaml 的检查点配置，不准备运行时依赖
+func writeCheckpointStorageConfig(params *InstallParams) error {
 	seatunnelYaml := filepath.Join(params.InstallDir, "config", "seatunnel.yaml")
 
 	// Backup original file / 备份原始文件
@@ -1936,7 +2134,12 @@ func (m *InstallerManager) configureIMAPStorage(params *InstallParams) error {
 			return fmt.Errorf("%w: failed to prepare OSS runtime dependencies: %v", ErrConfigGenerationFailed, err)
 		}
 	}
+	return writeIMAPStorageConfig(params)
+}
 
+// writeIMAPStorageConfig rewrites the hazelcast map-store section without preparing runtime dependencies
+// writeIMAPStorageConfig 仅改写 hazelcast map-store 配置，不准备运行时依赖
+func writeIMAPStorageConfig(params *InstallParams) error {
 	configFiles := []string{}
 	configDir := filepath.Join(params.InstallDir, "config")
 	if params.DeploymentMode == DeploymentModeSeparated {
@@ -2245,12 +2448,16 @@ func (m *InstallerManager) configureJVM(params *InstallParams) error {
 	configDir := filepath.Join(params.InstallDir, "config")
 	logger.InfoF(ctx, "[configureJVM] Config dir: %s, DeploymentMode: %s", configDir, params.DeploymentMode)
 
+	if err := params.JVM.fileOptions(0).Validate(); err != nil {
+		return fmt.Errorf("%w: %v", ErrConfigGenerationFailed, err)
+	}
+
 	// Configure based on deployment mode / 根据部署模式配置
 	if params.DeploymentMode == DeploymentModeHybrid {
 		// Hybrid mode: configure jvm_options / 混合模式：配置 jvm_options
 		jvmOptionsPath := filepath.Join(configDir, "jvm_options")
 		logger.InfoF(ctx, "[configureJVM] Hybrid mode: modifying %s with heap=%dGB", jvmOptionsPath, params.JVM.HybridHeapSize)
-		if err := m.modifyJVMOptions(jvmOptionsPath, params.JVM.HybridHeapSize); err != nil {
+		if err := m.modifyJVMOptions(jvmOptionsPath, params.JVM.fileOptions(params.JVM.HybridHeapSize)); err != nil {
 			logger.ErrorF(ctx, "[configureJVM] Error modifying %s: %v", jvmOptionsPath, err)
 			return err
 		}
@@ -2260,14 +2467,14 @@ func (m *InstallerManager) configureJVM(params *InstallParams) error {
 		// 分离模式：配置 jvm_master_options 和 jvm_worker_options
 		masterOptionsPath := filepath.Join(configDir, "jvm_master_options")
 		logger.InfoF(ctx, "[configureJVM] Separated mode: modifying %s with heap=%dGB", masterOptionsPath, params.JVM.MasterHeapSize)
-		if err := m.modifyJVMOptions(masterOptionsPath, params.JVM.MasterHeapSize); err != nil {
+		if err := m.modifyJVMOptions(masterOptionsPath, params.JVM.fileOptions(params.JVM.MasterHeapSize)); err != nil {
 			logger.ErrorF(ctx, "[configureJVM] Error modifying %s: %v", masterOptionsPath, err)
 			return err
 		}
 
 		workerOptionsPath := filepath.Join(configDir, "jvm_worker_options")
 		logger.InfoF(ctx, "[configureJVM] Separated mode: modifying %s with heap=%dGB", workerOptionsPath, params.JVM.WorkerHeapSize)
-		if err := m.modifyJVMOptions(workerOptionsPath, params.JVM.WorkerHeapSize); err != nil {
+		if err := m.modifyJVMOptions(workerOptionsPath, params.JVM.fileOptions(params.JVM.WorkerHeapSize)); err != nil {
 			logger.ErrorF(ctx, "[configureJVM] Error modifying %s: %v", workerOptionsPath, err)
 			return err
 		}
@@ -2276,8 +2483,8 @@ func (m *InstallerManager) configureJVM(params *InstallParams) error {
 	return nil
 }
 
-// modifyJVMOptions modifies JVM options file to set heap size
-// modifyJVMOptions 修改 JVM 选项文件以设置堆大小
+// modifyJVMOptions modifies JVM options file to set heap size, GC, direct memory and extra options
+// modifyJVMOptions 修改 JVM 选项文件以设置堆大小、GC、直接内存与额外参数
 // JVM options files are NOT YAML - they are plain text with JVM flags
 // JVM 选项文件不是 YAML - 它们是带有 JVM 标志的纯文本
 //
@@ -2293,11 +2500,12 @@ func (m *InstallerManager) configureJVM(params *InstallParams) error {
 //	# -Xms2g
 //	# -Xmx2g
 //
-// This function handles both formats - uncomments if needed and sets the correct heap size
-// 此函数处理两种格式 - 如果需要则取消注释并设置正确的堆大小
-func (m *InstallerManager) modifyJVMOptions(filePath string, heapSizeGB int) error {
+// Both formats are handled by jvmopts.Render, which uncomments and sets the heap lines and
+// regenerates the managed block holding the other settings
+// 两种格式均由 jvmopts.Render 处理：取消注释并设置堆参数，同时重新生成包含其他设置的托管块
+func (m *InstallerManager) modifyJVMOptions(filePath string, opts jvmopts.Options) error {
 	ctx := context.Background()
-	logger.InfoF(ctx, "[modifyJVMOptions] Starting: file=%s, heapSize=%dGB", filePath, heapSizeGB)
+	logger.InfoF(ctx, "[modifyJVMOptions] Starting: file=%s, heapSize=%dGB, gc=%s", filePath, opts.HeapSizeGB, opts.GC)
 
 	// Backup original file / 备份原始文件
 	if err := backupFile(filePath); err != nil {
@@ -2312,41 +2520,7 @@ func (m *InstallerManager) modifyJVMOptions(filePath string, heapSizeGB int) err
 		return fmt.Errorf("%w: failed to read %s: %v", ErrConfigGenerationFailed, filePath, err)
 	}
 
-	logger.InfoF(ctx, "[modifyJVMOptions] File content length: %d bytes", len(content))
-	lines := strings.Split(string(content), "\n")
-	var result []string
-	xmsModified := false
-	xmxModified := false
-
-	// Regex patterns for matching JVM heap options (commented or not)
-	// 匹配 JVM 堆选项的正则表达式（注释或非注释）
-	// Matches: "# -Xms2g", "#  -Xms2g", "-Xms2g", "# -Xms4g", etc.
-	xmsPattern := regexp.MustCompile(`^#?\s*-Xms\d+g\s*$`)
-	xmxPattern := regexp.MustCompile(`^#?\s*-Xmx\d+g\s*$`)
-
-	for _, line := range lines {
-		trimmed := strings.TrimSpace(line)
-
-		if xmsPattern.MatchString(trimmed) {
-			// Replace with uncommented and correct heap size
-			// 替换为取消注释并设置正确的堆大小
-			logger.InfoF(ctx, "[modifyJVMOptions] Found Xms line: '%s' -> '-Xms%dg'", trimmed, heapSizeGB)
-			result = append(result, fmt.Sprintf("-Xms%dg", heapSizeGB))
-			xmsModified = true
-		} else if xmxPattern.MatchString(trimmed) {
-			// Replace with uncommented and correct heap size
-			// 替换为取消注释并设置正确的堆大小
-			logger.InfoF(ctx, "[modifyJVMOptions] Found Xmx line: '%s' -> '-Xmx%dg'", trimmed, heapSizeGB)
-			result = append(result, fmt.Sprintf("-Xmx%dg", heapSizeGB))
-			xmxModified = true
-		} else {
-			// Keep other lines unchanged / 保持其他行不变
-			result = append(result, line)
-		}
-	}
-
-	logger.InfoF(ctx, "[modifyJVMOptions] Modifications: Xms=%v, Xmx=%v", xmsModified, xmxModified)
-	contentStr := strings.Join(result, "\n")
+	contentStr := jvmopts.Render(string(content), opts)
 
 	// Write modified content / 写入修改后的内容
 	if err := os.WriteFile(filePath, []byte(contentStr), 0644); err != nil {
@@ -2358,51 +2532,129 @@ func (m *InstallerManager) modifyJVMOptions(filePath string, heapSizeGB int) err
 	return nil
 }
 
-// downloadPackage downloads the installation package from the given URL
-// downloadPackage 从给定 URL 下载安装包
+// Package download retry settings: failed attempts are retried with a doubling delay and
+// resume from the bytes already written when the server supports HTTP Range requests.
+// 安装包下载重试设置：失败后按翻倍间隔重试，服务端支持 HTTP Range 时从已写入的字节处续传。
+var (
+	packageDownloadAttempts   = 5
+	packageDownloadRetryDelay = 2 * time.Second
+)
+
+// downloadPackage downloads the installation package from the given URL, retrying
+// interrupted transfers and resuming them where the server allows it
+// downloadPackage 从给定 URL 下载安装包，传输中断时重试并在服务端允许时断点续传
 func (m *InstallerManager) downloadPackage(ctx context.Context, url string, reporter ProgressReporter) (string, error) {
+	// Create temp file / 创建临时文件
+	tempFile, err := os.CreateTemp(m.tempDir, "seatunnel-*.tar.gz")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temp file: %w", err)
+	}
+	packagePath := tempFile.Name()
+	tempFile.Close()
+
+	delay := packageDownloadRetryDelay
+	for attempt := 1; ; attempt++ {
+		retry, err := m.downloadPackageAttempt(ctx, url, packagePath, reporter)
+		if err == nil {
+			return packagePath, nil
+		}
+		if !retry || attempt >= packageDownloadAttempts || ctx.Err() != nil {
+			os.Remove(packagePath)
+			if ctx.Err() != nil {
+				return "", ctx.Err()
+			}
+			return "", err
+		}
+
+		reporter.Report(InstallStepDownload, 0, fmt.Sprintf("Download interrupted, retrying in %s (%d/%d): %v / 下载中断，%s 后重试（%d/%d）", delay, attempt, packageDownloadAttempts, err, delay, attempt, packageDownloadAttempts))
+		select {
+		case <-ctx.Done():
+			os.Remove(packagePath)
+			return "", ctx.Err()
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
+
+// downloadPackageAttempt fetches the package once, continuing from the current size of
+// packagePath. It reports whether a failure is worth retrying.
+// downloadPackageAttempt 执行一次下载，从 packagePath 当前大小处继续，并返回失败是否值得重试。
+func (m *InstallerManager) downloadPackageAttempt(ctx context.Context, url, packagePath string, reporter ProgressReporter) (bool, error) {
+	var offset int64
+	if info, err := os.Stat(packagePath); err == nil {
+		offset = info.Size()
+	}
+
 	// Create request with context / 创建带上下文的请求
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
-		return "", fmt.Errorf("failed to create request: %w", err)
+		return false, fmt.Errorf("failed to create request: %w", err)
+	}
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
 	}
 
 	// Execute request / 执行请求
 	resp, err := m.httpClient.Do(req)
 	if err != nil {
-		return "", fmt.Errorf("%w: %v", ErrDownloadFailed, err)
+		return true, fmt.Errorf("%w: %v", ErrDownloadFailed, err)
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("%w: HTTP status %d", ErrDownloadFailed, resp.StatusCode)
+	flags := os.O_WRONLY | os.O_APPEND
+	switch {
+	case resp.StatusCode == http.StatusPartialContent && offset > 0:
+		// Resume from the bytes already on disk, unless the server sent another range
+		// 从磁盘上已有的字节处续传，除非服务端返回了其他区间
+		if start, ok := contentRangeStart(resp.Header.Get("Content-Range")); !ok || start != offset {
+			if err := os.Truncate(packagePath, 0); err != nil {
+				return false, fmt.Errorf("failed to reset temp file: %w", err)
+			}
+			return true, fmt.Errorf("%w: requested bytes from %d, got Content-Range %q", ErrDownloadFailed, offset, resp.Header.Get("Content-Range"))
+		}
+	case resp.StatusCode == http.StatusOK:
+		// The server ignored the range, start over / 服务端忽略了 Range，重新下载
+		offset = 0
+		flags = os.O_WRONLY | os.O_TRUNC
+	case resp.StatusCode == http.StatusRequestedRangeNotSatisfiable:
+		// The partial file no longer matches the remote one / 部分文件与远端文件不再一致
+		if err := os.Truncate(packagePath, 0); err != nil {
+			return false, fmt.Errorf("failed to reset temp file: %w", err)
+		}
+		return true, fmt.Errorf("%w: HTTP status %d", ErrDownloadFailed, resp.StatusCode)
+	default:
+		retry := resp.StatusCode >= http.StatusInternalServerError ||
+			resp.StatusCode == http.StatusTooManyRequests ||
+			resp.StatusCode == http.StatusRequestTimeout
+		return retry, fmt.Errorf("%w: HTTP status %d", ErrDownloadFailed, resp.StatusCode)
 	}
 
-	// Create temp file / 创建临时文件
-	tempFile, err := os.CreateTemp(m.tempDir, "seatunnel-*.tar.gz")
+	file, err := os.OpenFile(packagePath, flags, 0644)
 	if err != nil {
-		return "", fmt.Errorf("failed to create temp file: %w", err)
+		return false, fmt.Errorf("failed to open temp file: %w", err)
 	}
-	defer tempFile.Close()
+	defer file.Close()
 
 	// Download with progress reporting / 带进度上报的下载
-	totalSize := resp.ContentLength
-	var downloaded int64
+	var totalSize int64
+	if resp.ContentLength > 0 {
+		totalSize = offset + resp.ContentLength
+	}
+	downloaded := offset
 
 	buf := make([]byte, 32*1024) // 32KB buffer
 	for {
 		select {
 		case <-ctx.Done():
-			os.Remove(tempFile.Name())
-			return "", ctx.Err()
+			return false, ctx.Err()
 		default:
 		}
 
 		n, err := resp.Body.Read(buf)
 		if n > 0 {
-			if _, writeErr := tempFile.Write(buf[:n]); writeErr != nil {
-				os.Remove(tempFile.Name())
-				return "", fmt.Errorf("failed to write to temp file: %w", writeErr)
+			if _, writeErr := file.Write(buf[:n]); writeErr != nil {
+				return false, fmt.Errorf("failed to write to temp file: %w", writeErr)
 			}
 			downloaded += int64(n)
 
@@ -2417,25 +2669,52 @@ func (m *InstallerManager) downloadPackage(ctx context.Context, url string, repo
 			break
 		}
 		if err != nil {
-			os.Remove(tempFile.Name())
-			return "", fmt.Errorf("%w: %v", ErrDownloadFailed, err)
+			return true, fmt.Errorf("%w: %v", ErrDownloadFailed, err)
 		}
 	}
 
-	return tempFile.Name(), nil
+	if totalSize > 0 && downloaded < totalSize {
+		return true, fmt.Errorf("%w: received %d of %d bytes", ErrDownloadFailed, downloaded, totalSize)
+	}
+	return false, nil
 }
 
-// VerifyChecksum verifies the SHA256 checksum of a file
-// VerifyChecksum 验证文件的 SHA256 校验和
-func (m *InstallerManager) VerifyChecksum(filePath, expectedChecksum string) error {
-	actualChecksum, err := CalculateChecksum(filePath)
-	if err != nil {
-		return fmt.Errorf("failed to calculate checksum: %w", err)
+// contentRangeStart returns the first byte position of a "bytes start-end/size" Content-Range header.
+// contentRangeStart 返回 "bytes start-end/size" 格式 Content-Range 头的起始字节位置。
+func contentRangeStart(header string) (int64, bool) {
+	spec, ok := strings.CutPrefix(strings.TrimSpace(header), "bytes ")
+	if !ok {
+		return 0, false
+	}
+	start, _, ok := strings.Cut(spec, "-")
+	if !ok {
+		return 0, false
 	}
+	value, err := strconv.ParseInt(strings.TrimSpace(start), 10, 64)
+	if err != nil || value < 0 {
+		return 0, false
+	}
+	return value, true
+}
 
+// VerifyChecksum verifies the checksum of a file. A 128-character checksum is treated as
+// SHA-512 (the format Apache publishes), anything else as SHA256
+// VerifyChecksum 验证文件的校验和。128 个字符的校验和按 SHA-512（Apache 发布的格式）处理，其余按 SHA256 处理
+func (m *InstallerManager) VerifyChecksum(filePath, expectedChecksum string) error {
 	// Normalize checksums for comparison (lowercase)
 	// 规范化校验和以进行比较（小写）
 	expectedChecksum = strings.ToLower(strings.TrimSpace(expectedChecksum))
+
+	var actualChecksum string
+	var err error
+	if len(expectedChecksum) == sha512.Size*2 {
+		actualChecksum, err = calculateSHA512(filePath)
+	} else {
+		actualChecksum, err = CalculateChecksum(filePath)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to calculate checksum: %w", err)
+	}
 	actualChecksum = strings.ToLower(actualChecksum)
 
 	if actualChecksum != expectedChecksum {
@@ -2462,9 +2741,50 @@ func CalculateChecksum(filePath string) (string, error) {
 	return hex.EncodeToString(hash.Sum(nil)), nil
 }
 
-// extractPackage extracts a tar.gz package to the specified directory
-// extractPackage 将 tar.gz 安装包解压到指定目录
+// calculateSHA512 calculates the SHA-512 checksum of a file
+// calculateSHA512 计算文件的 SHA-512 校验和
+func calculateSHA512(filePath string) (string, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to open file: %w", err)
+	}
+	defer file.Close()
+
+	hash := sha512.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", fmt.Errorf("failed to calculate hash: %w", err)
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// extractPackage extracts a tar.gz or zip package to the specified directory
+// extractPackage 将 tar.gz 或 zip 安装包解压到指定目录
 func (m *InstallerManager) extractPackage(ctx context.Context, packagePath, destDir string, reporter ProgressReporter) error {
+	if isZipPackage(packagePath) {
+		return m.extractZipPackage(ctx, packagePath, destDir, reporter)
+	}
+
+	err := untarGz(ctx, packagePath, destDir, func(fileCount int) {
+		if fileCount%100 == 0 {
+			reporter.Report(InstallStepExtract, 50, fmt.Sprintf("Extracted %d files... / 已解压 %d 个文件...", fileCount, fileCount))
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := WriteManagedInstallMarker(destDir); err != nil {
+		return fmt.Errorf("%w: failed to write install marker: %v", ErrExtractionFailed, err)
+	}
+
+	return nil
+}
+
+// untarGz extracts a tar.gz archive into destDir, stripping the top-level directory.
+// onFile, if set, is called with the running count after each regular file.
+// untarGz 将 tar.gz 归档解压到 destDir，并去除顶层目录。onFile 非空时在每个普通文件后以累计数量调用。
+func untarGz(ctx context.Context, packagePath, destDir string, onFile func(fileCount int)) error {
 	// Open the package file / 打开安装包文件
 	file, err := os.Open(packagePath)
 	if err != nil {
@@ -2488,6 +2808,7 @@ func (m *InstallerManager) extractPackage(ctx context.Context, packagePath, dest
 	}
 
 	// Extract files / 解压文件
+	budget := newExtractBudget()
 	fileCount := 0
 	for {
 		select {
@@ -2510,40 +2831,61 @@ func (m *InstallerManager) extractPackage(ctx context.Context, packagePath, dest
 		targetPath := filepath.Join(destDir, stripFirstComponent(header.Name))
 
 		// Security check: prevent path traversal / 安全检查：防止路径遍历
-		if !strings.HasPrefix(filepath.Clean(targetPath), filepath.Clean(destDir)) {
+		if !withinDir(destDir, targetPath) {
 			return fmt.Errorf("%w: invalid file path in archive: %s", ErrExtractionFailed, header.Name)
 		}
+		// Guard against decompression bombs / 防范解压炸弹
+		if err := budget.addEntry(header.Name); err != nil {
+			return err
+		}
 
+		// Permissions come from the archive without setuid/setgid/sticky bits
+		// 权限取自归档，但去除 setuid/setgid/sticky 位
+		mode := header.FileInfo().Mode()
 		switch header.Typeflag {
 		case tar.TypeDir:
-			if err := os.MkdirAll(targetPath, os.FileMode(header.Mode)); err != nil {
+			// Symlinks extracted earlier must not lead outside the destination
+			// 先前解压出的符号链接不得指向目标目录之外
+			if err := ensureWithinDir(destDir, targetPath); err != nil {
+				return err
+			}
+			if err := os.MkdirAll(targetPath, safeFileMode(mode, 0755)); err != nil {
 				return fmt.Errorf("%w: failed to create directory: %v", ErrExtractionFailed, err)
 			}
 		case tar.TypeReg:
+			if err := ensureRegularTarget(destDir, targetPath); err != nil {
+				return err
+			}
 			// Create parent directory / 创建父目录
 			if err := os.MkdirAll(filepath.Dir(targetPath), 0755); err != nil {
 				return fmt.Errorf("%w: failed to create parent directory: %v", ErrExtractionFailed, err)
 			}
 
 			// Create file / 创建文件
-			outFile, err := os.OpenFile(targetPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, os.FileMode(header.Mode))
+			outFile, err := os.OpenFile(targetPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, safeFileMode(mode, 0644))
 			if err != nil {
 				return fmt.Errorf("%w: failed to create file: %v", ErrExtractionFailed, err)
 			}
 
-			// Copy content / 复制内容
-			if _, err := io.Copy(outFile, tarReader); err != nil {
+			// Copy content within the size budget / 在大小限额内复制内容
+			if err := budget.copy(outFile, tarReader, header.Name); err != nil {
 				outFile.Close()
-				return fmt.Errorf("%w: failed to write file: %v", ErrExtractionFailed, err)
+				return err
 			}
 			outFile.Close()
 
 			fileCount++
-			if fileCount%100 == 0 {
-				reporter.Report(InstallStepExtract, 50, fmt.Sprintf("Extracted %d files... / 已解压 %d 个文件...", fileCount, fileCount))
+			if onFile != nil {
+				onFile(fileCount)
 			}
 		case tar.TypeSymlink:
-			// Create symlink / 创建符号链接
+			// Only links that stay inside the destination are allowed / 仅允许指向目标目录内部的链接
+			if err := validateSymlinkTarget(destDir, targetPath, header.Linkname); err != nil {
+				return err
+			}
+			if err := os.MkdirAll(filepath.Dir(targetPath), 0755); err != nil {
+				return fmt.Errorf("%w: failed to create parent directory: %v", ErrExtractionFailed, err)
+			}
 			if err := os.Symlink(header.Linkname, targetPath); err != nil {
 				// Ignore symlink errors on Windows / 在 Windows 上忽略符号链接错误
 				if !os.IsExist(err) {
@@ -2553,10 +2895,6 @@ func (m *InstallerManager) extractPackage(ctx context.Context, packagePath, dest
 		}
 	}
 
-	if err := WriteManagedInstallMarker(destDir); err != nil {
-		return fmt.Errorf("%w: failed to write install marker: %v", ErrExtractionFailed, err)
-	}
-
 	return nil
 }
 
@@ -2620,10 +2958,17 @@ func (m *InstallerManager) ConfigureCluster(params *InstallParams) (string, erro
 		return "", err
 	}
 
-	// Modify log4j2.properties for mixed/per-job job log output
-	// 修改 log4j2.properties 以支持混合模式或单 Job 独立日志输出
-	log4j2Path := filepath.Join(configDir, "log4j2.properties")
-	if err := m.modifyLog4j2Config(log4j2Path, params); err != nil {
+	// Modify log4j2.properties for mixed/per-job job log output; older releases lack the routing appender
+	// 修改 log4j2.properties 以支持混合模式或单 Job 独立日志输出；旧版本没有 routing appender
+	if seatunnelmeta.SupportsCapability(params.Version, seatunnelmeta.CapabilityJobLogMode) {
+		log4j2Path := filepath.Join(configDir, "log4j2.properties")
+		if err := m.modifyLog4j2Config(log4j2Path, params); err != nil {
+			return "", err
+		}
+	}
+
+	// Apply log rotation and GC logging / 应用日志滚动与 GC 日志设置
+	if err := m.configureLogs(configDir, params); err != nil {
 		return "", err
 	}
 
@@ -2718,6 +3063,11 @@ func (m *InstallerManager) modifyHazelcastConfig(filePath string, params *Instal
 		}
 	}
 
+	// Advertised addresses replace the derived member list (NAT, multi-NIC) / 公布地址替换推导出的成员列表（NAT、多网卡）
+	if advertised := params.Hazelcast.advertisedMemberList(port); len(advertised) > 0 {
+		memberList = advertised
+	}
+
 	// If no addresses, use localhost / 如果没有地址，使用 localhost
 	if len(memberList) == 0 {
 		memberList = append(memberList, fmt.Sprintf("127.0.0.1:%d", port))
@@ -2731,11 +3081,10 @@ func (m *InstallerManager) modifyHazelcastConfig(filePath string, params *Instal
 		return fmt.Errorf("%w: failed to set port: %v", ErrConfigGenerationFailed, err)
 	}
 
-	// Enable Hazelcast REST API with basic endpoints (best-effort). / 启用 Hazelcast REST API 以及基础端点（最佳努力）
-	// If the path does not exist, it will be created. / 如果路径不存在，则创建
-	_ = setYAMLValueCreate(&root, []string{"hazelcast", "network", "rest-api", "enabled"}, true)
-	_ = setYAMLValueCreate(&root, []string{"hazelcast", "network", "rest-api", "endpoint-groups", "CLUSTER_WRITE", "enabled"}, true)
-	_ = setYAMLValueCreate(&root, []string{"hazelcast", "network", "rest-api", "endpoint-groups", "DATA", "enabled"}, true)
+	// Apply REST endpoint groups and advanced network settings / 应用 REST 端点组与高级网络配置
+	if err := applyHazelcastNetwork(&root, params.Hazelcast); err != nil {
+		return fmt.Errorf("%w: failed to set hazelcast network: %v", ErrConfigGenerationFailed, err)
+	}
 
 	// Write modified content / 写入修改后的内容
 	output, err := yaml.Marshal(&root)
@@ -0,0 +1,151 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package installer
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha512"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestDownloadPackageResumesInterruptedTransfer tests that a dropped download resumes with a Range request.
+// TestDownloadPackageResumesInterruptedTransfer 测试下载中断后通过 Range 请求续传。
+func TestDownloadPackageResumesInterruptedTransfer(t *testing.T) {
+	restoreDelay := packageDownloadRetryDelay
+	packageDownloadRetryDelay = time.Millisecond
+	defer func() { packageDownloadRetryDelay = restoreDelay }()
+
+	content := bytes.Repeat([]byte("seatunnel"), 16*1024)
+	var requests atomic.Int32
+	var resumedFrom atomic.Value
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+			w.WriteHeader(http.StatusOK)
+			w.Write(content[:len(content)/2])
+			w.(http.Flusher).Flush()
+			panic(http.ErrAbortHandler)
+		}
+		resumedFrom.Store(r.Header.Get("Range"))
+		http.ServeContent(w, r, "seatunnel.tar.gz", time.Time{}, bytes.NewReader(content))
+	}))
+	defer server.Close()
+
+	manager := NewInstallerManagerWithClient(server.Client())
+	manager.tempDir = t.TempDir()
+	path, err := manager.downloadPackage(context.Background(), server.URL, &NoOpProgressReporter{})
+	if err != nil {
+		t.Fatalf("downloadPackage failed: %v", err)
+	}
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read package: %v", err)
+	}
+	if !bytes.Equal(got, content) {
+		t.Fatalf("Expected %d bytes of package content, got %d", len(content), len(got))
+	}
+	if rng, _ := resumedFrom.Load().(string); rng == "" || rng == "bytes=0-" {
+		t.Errorf("Expected the retry to resume with a Range header, got %q", rng)
+	}
+
+	hash := sha512.Sum512(content)
+	if err := manager.VerifyChecksum(path, hex.EncodeToString(hash[:])); err != nil {
+		t.Errorf("Expected SHA-512 checksum to verify, got %v", err)
+	}
+}
+
+// TestDownloadPackageRestartsOnMismatchedContentRange tests that a 206 response for another range is not appended.
+// TestDownloadPackageRestartsOnMismatchedContentRange 测试区间不符的 206 响应不会被追加，而是重新下载。
+func TestDownloadPackageRestartsOnMismatchedContentRange(t *testing.T) {
+	restoreDelay := packageDownloadRetryDelay
+	packageDownloadRetryDelay = time.Millisecond
+	defer func() { packageDownloadRetryDelay = restoreDelay }()
+
+	content := bytes.Repeat([]byte("seatunnel"), 16*1024)
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch requests.Add(1) {
+		case 1:
+			w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+			w.WriteHeader(http.StatusOK)
+			w.Write(content[:len(content)/2])
+			w.(http.Flusher).Flush()
+			panic(http.ErrAbortHandler)
+		case 2:
+			// Answer the resume with the whole file instead of the requested range
+			// 以完整文件而非所请求的区间应答续传请求
+			w.Header().Set("Content-Range", fmt.Sprintf("bytes 0-%d/%d", len(content)-1, len(content)))
+			w.WriteHeader(http.StatusPartialContent)
+			w.Write(content)
+		default:
+			http.ServeContent(w, r, "seatunnel.tar.gz", time.Time{}, bytes.NewReader(content))
+		}
+	}))
+	defer server.Close()
+
+	manager := NewInstallerManagerWithClient(server.Client())
+	manager.tempDir = t.TempDir()
+	path, err := manager.downloadPackage(context.Background(), server.URL, &NoOpProgressReporter{})
+	if err != nil {
+		t.Fatalf("downloadPackage failed: %v", err)
+	}
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read package: %v", err)
+	}
+	if !bytes.Equal(got, content) {
+		t.Fatalf("Expected %d bytes of package content, got %d", len(content), len(got))
+	}
+	if requests.Load() != 3 {
+		t.Errorf("Expected the mismatched range to trigger a fresh download, got %d requests", requests.Load())
+	}
+}
+
+// TestDownloadPackageDoesNotRetryClientErrors tests that an expired or forbidden URL fails immediately.
+// TestDownloadPackageDoesNotRetryClientErrors 测试过期或无权限的地址立即失败而不重试。
+func TestDownloadPackageDoesNotRetryClientErrors(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	manager := NewInstallerManagerWithClient(server.Client())
+	manager.tempDir = t.TempDir()
+	_, err := manager.downloadPackage(context.Background(), server.URL, &NoOpProgressReporter{})
+	if !errors.Is(err, ErrDownloadFailed) {
+		t.Fatalf("Expected ErrDownloadFailed, got %v", err)
+	}
+	if requests.Load() != 1 {
+		t.Errorf("Expected a single request, got %d", requests.Load())
+	}
+	if entries, _ := os.ReadDir(manager.tempDir); len(entries) != 0 {
+		t.Errorf("Expected the partial file to be removed, found %d entries", len(entries))
+	}
+}
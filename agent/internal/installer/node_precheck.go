@@ -59,6 +59,36 @@ func CheckPortListening(port int) *NodePrecheckResult {
 	}
 }
 
+// MaxPortScanRange caps how many ports SuggestFreePorts probes in one call.
+// MaxPortScanRange 限制 SuggestFreePorts 单次探测的端口数量。
+const MaxPortScanRange = 4096
+
+// SuggestFreePorts returns up to count ports in [start, end] that can be bound on all
+// interfaces, skipping the excluded ones so suggestions never collide with planned ports.
+// SuggestFreePorts 返回 [start, end] 范围内最多 count 个可在所有网卡上绑定的端口，
+// 并跳过排除的端口，避免建议端口与计划使用的端口冲突。
+func SuggestFreePorts(start, end, count int, exclude map[int]bool) []int {
+	suggested := make([]int, 0, count)
+	for port := start; port <= end && len(suggested) < count; port++ {
+		if exclude[port] || !portBindable(port) {
+			continue
+		}
+		suggested = append(suggested, port)
+	}
+	return suggested
+}
+
+// portBindable reports whether nothing holds the port on any local address.
+// portBindable 判断端口是否未被任何本地地址占用。
+func portBindable(port int) bool {
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+	if err != nil {
+		return false
+	}
+	_ = listener.Close()
+	return true
+}
+
 // CheckTCPConnection checks whether a remote TCP endpoint is reachable.
 // CheckTCPConnection 检查远程 TCP 端点是否可达。
 func CheckTCPConnection(host string, port int, timeout time.Duration) *NodePrecheckResult {
@@ -0,0 +1,156 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package installer
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// zipMagic is the local file header signature that starts every zip archive
+// zipMagic 是每个 zip 压缩包开头的本地文件头签名
+var zipMagic = []byte("PK\x03\x04")
+
+// scriptExt returns the extension of SeaTunnel launcher scripts on this platform
+// scriptExt 返回当前平台 SeaTunnel 启动脚本的扩展名
+func scriptExt() string {
+	if runtime.GOOS == "windows" {
+		return ".cmd"
+	}
+	return ".sh"
+}
+
+// seatunnelScriptPath returns the platform specific path of a script under bin, e.g. seatunnel-cluster.sh or seatunnel-cluster.cmd
+// seatunnelScriptPath 返回 bin 目录下脚本的平台相关路径，例如 seatunnel-cluster.sh 或 seatunnel-cluster.cmd
+func seatunnelScriptPath(installDir, name string) string {
+	return filepath.Join(installDir, "bin", name+scriptExt())
+}
+
+// isZipPackage reports whether the package is a zip archive, by extension or file signature
+// isZipPackage 通过扩展名或文件签名判断安装包是否为 zip 压缩包
+func isZipPackage(packagePath string) bool {
+	if strings.EqualFold(filepath.Ext(packagePath), ".zip") {
+		return true
+	}
+	file, err := os.Open(packagePath)
+	if err != nil {
+		return false
+	}
+	defer file.Close()
+	header := make([]byte, len(zipMagic))
+	if _, err := io.ReadFull(file, header); err != nil {
+		return false
+	}
+	return bytes.Equal(header, zipMagic)
+}
+
+// extractZipPackage extracts a zip package to destDir, stripping the top-level directory like extractPackage
+// extractZipPackage 将 zip 安装包解压到 destDir，与 extractPackage 一样去除顶层目录
+func (m *InstallerManager) extractZipPackage(ctx context.Context, packagePath, destDir string, reporter ProgressReporter) error {
+	archive, err := zip.OpenReader(packagePath)
+	if err != nil {
+		return fmt.Errorf("%w: failed to open zip package: %v", ErrExtractionFailed, err)
+	}
+	defer archive.Close()
+
+	if err := os.MkdirAll(destDir, 0755); err != nil {
+		return fmt.Errorf("%w: failed to create destination directory: %v", ErrExtractionFailed, err)
+	}
+
+	budget := newExtractBudget()
+	fileCount := 0
+	for _, entry := range archive.File {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		relative := stripFirstComponent(entry.Name)
+		if relative == "" {
+			continue
+		}
+		targetPath := filepath.Join(destDir, filepath.FromSlash(relative))
+		if !withinDir(destDir, targetPath) {
+			return fmt.Errorf("%w: invalid file path in archive: %s", ErrExtractionFailed, entry.Name)
+		}
+		if err := budget.addEntry(entry.Name); err != nil {
+			return err
+		}
+
+		if entry.FileInfo().IsDir() {
+			if err := os.MkdirAll(targetPath, 0755); err != nil {
+				return fmt.Errorf("%w: failed to create directory: %v", ErrExtractionFailed, err)
+			}
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(targetPath), 0755); err != nil {
+			return fmt.Errorf("%w: failed to create parent directory: %v", ErrExtractionFailed, err)
+		}
+		if err := extractZipEntry(entry, targetPath, budget); err != nil {
+			return err
+		}
+
+		fileCount++
+		if fileCount%100 == 0 {
+			reporter.Report(InstallStepExtract, 50, fmt.Sprintf("Extracted %d files... / 已解压 %d 个文件...", fileCount, fileCount))
+		}
+	}
+
+	if err := WriteManagedInstallMarker(destDir); err != nil {
+		return fmt.Errorf("%w: failed to write install marker: %v", ErrExtractionFailed, err)
+	}
+	return nil
+}
+
+// extractZipEntry writes a single zip entry to targetPath within the extraction budget.
+// Zip archives built on Windows carry no Unix mode, so launcher scripts are made executable explicitly.
+// extractZipEntry 在解压限额内将单个 zip 条目写入 targetPath。
+// 在 Windows 上打包的 zip 不带 Unix 权限，因此显式为启动脚本设置可执行权限。
+func extractZipEntry(entry *zip.File, targetPath string, budget *extractBudget) error {
+	mode := safeFileMode(entry.Mode(), 0644)
+	if strings.HasSuffix(targetPath, ".sh") {
+		mode |= 0111
+	}
+
+	reader, err := entry.Open()
+	if err != nil {
+		return fmt.Errorf("%w: failed to open %s: %v", ErrExtractionFailed, entry.Name, err)
+	}
+	defer reader.Close()
+
+	outFile, err := os.OpenFile(targetPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode)
+	if err != nil {
+		return fmt.Errorf("%w: failed to create file: %v", ErrExtractionFailed, err)
+	}
+	if err := budget.copy(outFile, reader, entry.Name); err != nil {
+		outFile.Close()
+		return err
+	}
+	if err := outFile.Close(); err != nil {
+		return fmt.Errorf("%w: failed to write file: %v", ErrExtractionFailed, err)
+	}
+	return nil
+}
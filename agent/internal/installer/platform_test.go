@@ -0,0 +1,97 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package installer
+
+import (
+	"archive/zip"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestExtractZipPackage tests that zip packages are detected and extracted without the top-level directory.
+// TestExtractZipPackage 测试 zip 安装包可被识别并去除顶层目录解压。
+func TestExtractZipPackage(t *testing.T) {
+	dir := t.TempDir()
+	// No .zip extension: the file signature must identify the format
+	// 无 .zip 扩展名：必须通过文件签名识别格式
+	packagePath := filepath.Join(dir, "apache-seatunnel-bin.pkg")
+	file, err := os.Create(packagePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writer := zip.NewWriter(file)
+	entries := map[string]string{
+		"apache-seatunnel-2.3.12/bin/seatunnel-cluster.sh":  "#!/bin/bash\n",
+		"apache-seatunnel-2.3.12/bin/seatunnel-cluster.cmd": "@echo off\r\n",
+		"apache-seatunnel-2.3.12/config/seatunnel.yaml":     "seatunnel: {}\n",
+	}
+	for name, content := range entries {
+		w, err := writer.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	file.Close()
+
+	if !isZipPackage(packagePath) {
+		t.Fatal("Expected zip signature to be detected")
+	}
+	destDir := filepath.Join(dir, "seatunnel")
+	m := NewInstallerManager()
+	if err := m.extractPackage(context.Background(), packagePath, destDir, &NoOpProgressReporter{}); err != nil {
+		t.Fatalf("extractPackage failed: %v", err)
+	}
+	for _, rel := range []string{"bin/seatunnel-cluster.cmd", "config/seatunnel.yaml"} {
+		if _, err := os.Stat(filepath.Join(destDir, rel)); err != nil {
+			t.Errorf("Expected %s to be extracted: %v", rel, err)
+		}
+	}
+	info, err := os.Stat(filepath.Join(destDir, "bin", "seatunnel-cluster.sh"))
+	if err != nil {
+		t.Fatalf("Expected start script to be extracted: %v", err)
+	}
+	if info.Mode().Perm()&0100 == 0 {
+		t.Errorf("Expected shell script to be executable, got mode %v", info.Mode())
+	}
+}
+
+// TestExtractZipPackageRejectsTraversal tests that zip entries escaping the destination are rejected.
+// TestExtractZipPackageRejectsTraversal 测试越出目标目录的 zip 条目会被拒绝。
+func TestExtractZipPackageRejectsTraversal(t *testing.T) {
+	dir := t.TempDir()
+	packagePath := filepath.Join(dir, "evil.zip")
+	file, _ := os.Create(packagePath)
+	writer := zip.NewWriter(file)
+	w, _ := writer.Create("top/../../escaped.txt")
+	_, _ = w.Write([]byte("x"))
+	_ = writer.Close()
+	file.Close()
+
+	err := NewInstallerManager().extractPackage(context.Background(), packagePath, filepath.Join(dir, "dest"), &NoOpProgressReporter{})
+	if err == nil {
+		t.Fatal("Expected path traversal to be rejected")
+	}
+}
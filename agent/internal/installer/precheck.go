@@ -24,7 +24,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"net"
+	"os"
 	"os/exec"
+	"path/filepath"
 	"regexp"
 	"runtime"
 	"strconv"
@@ -105,6 +107,10 @@ type PrecheckItem struct {
 	// Details contains additional information about the check
 	// Details 包含检查的附加信息
 	Details map[string]interface{} `json:"details,omitempty"`
+
+	// Suggestion is a suggested fix when the check did not pass
+	// Suggestion 是检查未通过时的修复建议
+	Suggestion string `json:"suggestion,omitempty"`
 }
 
 // PrecheckResult contains all precheck results
@@ -149,6 +155,14 @@ type PrecheckParams struct {
 	// Architecture is the CPU architecture (amd64, arm64) for Java download info
 	// Architecture 是 CPU 架构（amd64、arm64），用于 Java 下载信息
 	Architecture string `json:"architecture"`
+
+	// ClusterPort is the Hazelcast cluster port checked against firewall rules
+	// ClusterPort 是需要检查防火墙规则的 Hazelcast 集群端口
+	ClusterPort int `json:"cluster_port"`
+
+	// ReferenceTimeMs is the Control Plane clock (Unix millis) used to measure clock skew; 0 skips it
+	// ReferenceTimeMs 是用于测量时钟偏差的 Control Plane 时钟（Unix 毫秒）；为 0 时跳过
+	ReferenceTimeMs int64 `json:"reference_time_ms"`
 }
 
 // DefaultPrecheckParams returns default precheck parameters
@@ -161,6 +175,7 @@ func DefaultPrecheckParams() *PrecheckParams {
 		InstallDir:     "/opt/seatunnel",
 		Ports:          []int{5801, 5802, 8080}, // Default SeaTunnel ports / 默认 SeaTunnel 端口
 		Architecture:   "amd64",                 // Default architecture / 默认架构
+		ClusterPort:    5801,                    // Default Hazelcast port / 默认 Hazelcast 端口
 	}
 }
 
@@ -174,6 +189,10 @@ type Prechecker struct {
 	// systemInfoProvider provides system information (for testing)
 	// systemInfoProvider 提供系统信息（用于测试）
 	systemInfoProvider SystemInfoProvider
+
+	// limitsProvider provides system limits and kernel parameters (for testing)
+	// limitsProvider 提供系统限制与内核参数（用于测试）
+	limitsProvider SystemLimitsProvider
 }
 
 // SystemInfoProvider is an interface for getting system information
@@ -213,6 +232,7 @@ func NewPrechecker(params *PrecheckParams) *Prechecker {
 	return &Prechecker{
 		params:             params,
 		systemInfoProvider: &DefaultSystemInfoProvider{},
+		limitsProvider:     &DefaultSystemInfoProvider{},
 	}
 }
 
@@ -225,24 +245,29 @@ func NewPrecheckerWithProvider(params *PrecheckParams, provider SystemInfoProvid
 	return &Prechecker{
 		params:             params,
 		systemInfoProvider: provider,
+		limitsProvider:     &DefaultSystemInfoProvider{},
 	}
 }
 
 // RunAll executes all prechecks and returns the results
 // RunAll 执行所有预检查并返回结果
 func (p *Prechecker) RunAll(ctx context.Context) (*PrecheckResult, error) {
-	result := &PrecheckResult{
-		Items:         make([]PrecheckItem, 0, 5),
-		OverallStatus: CheckStatusPassed,
-	}
-
 	// Run all checks / 运行所有检查
-	checks := []func(context.Context) PrecheckItem{
+	return p.runChecks(ctx, []func(context.Context) PrecheckItem{
 		p.CheckMemory,
 		p.CheckCPU,
 		p.CheckDisk,
 		p.CheckPorts,
 		p.CheckJava,
+	})
+}
+
+// runChecks executes the given checks and aggregates their results
+// runChecks 执行给定的检查并汇总结果
+func (p *Prechecker) runChecks(ctx context.Context, checks []func(context.Context) PrecheckItem) (*PrecheckResult, error) {
+	result := &PrecheckResult{
+		Items:         make([]PrecheckItem, 0, len(checks)),
+		OverallStatus: CheckStatusPassed,
 	}
 
 	passedCount := 0
@@ -752,6 +777,11 @@ func (d *DefaultSystemInfoProvider) GetAvailableDiskSpaceMB(path string) (int64,
 	case "linux", "darwin":
 		return d.getUnixDiskSpace(path)
 	case "windows":
+		// Query the drive through the Win32 API; wmic is missing on recent Windows releases
+		// 通过 Win32 API 查询驱动器；较新的 Windows 版本已移除 wmic
+		if usage, err := statDiskUsage(path); err == nil {
+			return usage.availableBytes / (1024 * 1024), nil
+		}
 		return d.getWindowsDiskSpace(path)
 	default:
 		return 0, fmt.Errorf("unsupported OS: %s / 不支持的操作系统：%s", runtime.GOOS, runtime.GOOS)
@@ -867,7 +897,7 @@ func (d *DefaultSystemInfoProvider) IsPortAvailable(port int) bool {
 // GetJavaVersion 返回已安装的 Java 版本（主版本号）
 func (d *DefaultSystemInfoProvider) GetJavaVersion() (int, string, error) {
 	// Try java -version / 尝试 java -version
-	cmd := exec.Command("java", "-version")
+	cmd := exec.Command(javaExecutable(), "-version")
 	output, err := cmd.CombinedOutput()
 	if err != nil {
 		return 0, "", fmt.Errorf("java command failed: %w", err)
@@ -877,6 +907,31 @@ func (d *DefaultSystemInfoProvider) GetJavaVersion() (int, string, error) {
 	return parseJavaVersion(versionStr)
 }
 
+// javaExecutable returns java from PATH, falling back to JAVA_HOME.
+// Windows services often run without the user's PATH, so JAVA_HOME is the reliable source there.
+// javaExecutable 返回 PATH 中的 java，找不到时回退到 JAVA_HOME。
+// Windows 服务通常不带用户的 PATH 运行，因此 JAVA_HOME 是更可靠的来源。
+func javaExecutable() string {
+	if path, err := exec.LookPath("java"); err == nil {
+		return path
+	}
+	if javaHome := strings.TrimSpace(os.Getenv("JAVA_HOME")); javaHome != "" {
+		name := "java"
+		if runtime.GOOS == "windows" {
+			name = "java.exe"
+		}
+		candidate := filepath.Join(javaHome, "bin", name)
+		if _, err := os.Stat(candidate); err == nil {
+			return candidate
+		}
+	}
+	// Fall back to the Java installed by auto_install_java / 回退到 auto_install_java 安装的 Java
+	if managed := filepath.Join(managedJavaHome(), "bin", "java"); fileExists(managed) {
+		return managed
+	}
+	return "java"
+}
+
 // parseJavaVersion parses Java version from java -version output
 // parseJavaVersion 从 java -version 输出解析 Java 版本
 func parseJavaVersion(output string) (int, string, error) {
@@ -0,0 +1,556 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package installer
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// System limit and kernel parameter check names
+// 系统限制与内核参数检查名称
+const (
+	// CheckNameOpenFiles is the open files limit (ulimit -n) check name
+	// CheckNameOpenFiles 是打开文件数限制（ulimit -n）检查名称
+	CheckNameOpenFiles CheckName = "open_files"
+
+	// CheckNameMaxUserProcesses is the max user processes (ulimit -u) check name
+	// CheckNameMaxUserProcesses 是最大用户进程数（ulimit -u）检查名称
+	CheckNameMaxUserProcesses CheckName = "max_user_processes"
+
+	// CheckNameMaxMapCount is the vm.max_map_count check name
+	// CheckNameMaxMapCount 是 vm.max_map_count 检查名称
+	CheckNameMaxMapCount CheckName = "max_map_count"
+
+	// CheckNameSwap is the swap check name
+	// CheckNameSwap 是 swap 检查名称
+	CheckNameSwap CheckName = "swap"
+
+	// CheckNameClockSync is the NTP / clock skew check name
+	// CheckNameClockSync 是 NTP / 时钟偏差检查名称
+	CheckNameClockSync CheckName = "clock_sync"
+
+	// CheckNameFirewall is the cluster port firewall check name
+	// CheckNameFirewall 是集群端口防火墙检查名称
+	CheckNameFirewall CheckName = "firewall"
+)
+
+// System limit thresholds for SeaTunnel / Hazelcast
+// SeaTunnel / Hazelcast 的系统限制阈值
+const (
+	// MinOpenFiles is the open files limit below which the check fails
+	// MinOpenFiles 是打开文件数限制的失败阈值
+	MinOpenFiles = 4096
+
+	// RecommendedOpenFiles is the recommended open files limit
+	// RecommendedOpenFiles 是推荐的打开文件数限制
+	RecommendedOpenFiles = 65536
+
+	// RecommendedMaxUserProcesses is the recommended max user processes limit
+	// RecommendedMaxUserProcesses 是推荐的最大用户进程数限制
+	RecommendedMaxUserProcesses = 4096
+
+	// RecommendedMaxMapCount is the recommended vm.max_map_count
+	// RecommendedMaxMapCount 是推荐的 vm.max_map_count
+	RecommendedMaxMapCount = 262144
+
+	// ClockSkewWarnThreshold is the clock skew above which the check warns
+	// ClockSkewWarnThreshold 是时钟偏差告警阈值
+	ClockSkewWarnThreshold = time.Second
+
+	// ClockSkewFailThreshold is the clock skew above which the check fails
+	// ClockSkewFailThreshold 是时钟偏差失败阈值
+	ClockSkewFailThreshold = 30 * time.Second
+)
+
+// SystemCheckNames returns the system limit and kernel parameter check names in order
+// SystemCheckNames 返回系统限制与内核参数检查名称（按顺序）
+func SystemCheckNames() []CheckName {
+	return []CheckName{
+		CheckNameOpenFiles,
+		CheckNameMaxUserProcesses,
+		CheckNameMaxMapCount,
+		CheckNameSwap,
+		CheckNameClockSync,
+		CheckNameFirewall,
+	}
+}
+
+// FirewallStatus describes the host firewall and whether it allows a port
+// FirewallStatus 描述主机防火墙及其是否放行某端口
+type FirewallStatus struct {
+	// Name is the firewall in use (firewalld, ufw); empty when none is active
+	// Name 是正在使用的防火墙（firewalld、ufw）；无活动防火墙时为空
+	Name string
+
+	// PortAllowed reports whether the port is explicitly allowed
+	// PortAllowed 表示端口是否被明确放行
+	PortAllowed bool
+}
+
+// SystemLimitsProvider is an interface for getting system limits and kernel parameters
+// SystemLimitsProvider 是获取系统限制与内核参数的接口
+type SystemLimitsProvider interface {
+	// GetOpenFilesLimit returns the soft open files limit inherited by SeaTunnel
+	// GetOpenFilesLimit 返回 SeaTunnel 继承的打开文件数软限制
+	GetOpenFilesLimit() (int64, error)
+
+	// GetMaxUserProcesses returns the soft max user processes limit; -1 means unlimited
+	// GetMaxUserProcesses 返回最大用户进程数软限制；-1 表示不限制
+	GetMaxUserProcesses() (int64, error)
+
+	// GetMaxMapCount returns vm.max_map_count
+	// GetMaxMapCount 返回 vm.max_map_count
+	GetMaxMapCount() (int64, error)
+
+	// GetSwapTotalMB returns the total swap size in MB
+	// GetSwapTotalMB 返回 swap 总大小（MB）
+	GetSwapTotalMB() (int64, error)
+
+	// IsNTPSynchronized reports whether the system clock is synchronized by NTP
+	// IsNTPSynchronized 返回系统时钟是否已通过 NTP 同步
+	IsNTPSynchronized() (bool, error)
+
+	// GetFirewallStatus returns the firewall status for a TCP port
+	// GetFirewallStatus 返回 TCP 端口的防火墙状态
+	GetFirewallStatus(port int) (*FirewallStatus, error)
+}
+
+// SetSystemLimitsProvider replaces the system limits provider (for testing)
+// SetSystemLimitsProvider 替换系统限制提供者（用于测试）
+func (p *Prechecker) SetSystemLimitsProvider(provider SystemLimitsProvider) {
+	p.limitsProvider = provider
+}
+
+// RunSystemChecks executes the system limit and kernel parameter checks
+// RunSystemChecks 执行系统限制与内核参数检查
+func (p *Prechecker) RunSystemChecks(ctx context.Context) (*PrecheckResult, error) {
+	checks := make([]func(context.Context) PrecheckItem, 0, len(SystemCheckNames()))
+	for _, name := range SystemCheckNames() {
+		check, _ := p.systemCheck(name)
+		checks = append(checks, check)
+	}
+	return p.runChecks(ctx, checks)
+}
+
+// RunSystemCheck executes a single system check by name
+// RunSystemCheck 按名称执行单个系统检查
+func (p *Prechecker) RunSystemCheck(ctx context.Context, name CheckName) (PrecheckItem, error) {
+	check, ok := p.systemCheck(name)
+	if !ok {
+		return PrecheckItem{}, fmt.Errorf("unknown system check: %s", name)
+	}
+	return check(ctx), nil
+}
+
+// systemCheck returns the check function for a system check name
+// systemCheck 返回系统检查名称对应的检查函数
+func (p *Prechecker) systemCheck(name CheckName) (func(context.Context) PrecheckItem, bool) {
+	switch name {
+	case CheckNameOpenFiles:
+		return p.CheckOpenFiles, true
+	case CheckNameMaxUserProcesses:
+		return p.CheckMaxUserProcesses, true
+	case CheckNameMaxMapCount:
+		return p.CheckMaxMapCount, true
+	case CheckNameSwap:
+		return p.CheckSwap, true
+	case CheckNameClockSync:
+		return p.CheckClockSync, true
+	case CheckNameFirewall:
+		return p.CheckFirewall, true
+	default:
+		return nil, false
+	}
+}
+
+// CheckOpenFiles checks the open files limit (ulimit -n)
+// CheckOpenFiles 检查打开文件数限制（ulimit -n）
+func (p *Prechecker) CheckOpenFiles(ctx context.Context) PrecheckItem {
+	item := PrecheckItem{
+		Name:    CheckNameOpenFiles,
+		Details: make(map[string]interface{}),
+	}
+	item.Details["min"] = MinOpenFiles
+	item.Details["recommended"] = RecommendedOpenFiles
+
+	suggestion := fmt.Sprintf("Add \"* soft nofile %d\" and \"* hard nofile %d\" to /etc/security/limits.conf (or LimitNOFILE=%d in the agent systemd unit) and restart the agent / 在 /etc/security/limits.conf 中添加 \"* soft nofile %d\" 和 \"* hard nofile %d\"（或在 Agent 的 systemd 单元中设置 LimitNOFILE=%d）并重启 Agent",
+		RecommendedOpenFiles, RecommendedOpenFiles, RecommendedOpenFiles,
+		RecommendedOpenFiles, RecommendedOpenFiles, RecommendedOpenFiles)
+
+	limit, err := p.limitsProvider.GetOpenFilesLimit()
+	if err != nil {
+		item.Status = CheckStatusWarning
+		item.Message = fmt.Sprintf("Failed to get open files limit: %v / 获取打开文件数限制失败：%v", err, err)
+		return item
+	}
+	item.Details["current"] = limit
+
+	switch {
+	case limit >= 0 && limit < MinOpenFiles:
+		item.Status = CheckStatusFailed
+		item.Message = fmt.Sprintf("Open files limit %d < minimum %d / 打开文件数限制 %d < 最低要求 %d",
+			limit, MinOpenFiles, limit, MinOpenFiles)
+		item.Suggestion = suggestion
+	case limit >= 0 && limit < RecommendedOpenFiles:
+		item.Status = CheckStatusWarning
+		item.Message = fmt.Sprintf("Open files limit %d < recommended %d / 打开文件数限制 %d < 推荐值 %d",
+			limit, RecommendedOpenFiles, limit, RecommendedOpenFiles)
+		item.Suggestion = suggestion
+	default:
+		item.Status = CheckStatusPassed
+		item.Message = fmt.Sprintf("Open files limit %s is sufficient / 打开文件数限制 %s 满足要求",
+			formatLimit(limit), formatLimit(limit))
+	}
+	return item
+}
+
+// CheckMaxUserProcesses checks the max user processes limit (ulimit -u)
+// CheckMaxUserProcesses 检查最大用户进程数限制（ulimit -u）
+func (p *Prechecker) CheckMaxUserProcesses(ctx context.Context) PrecheckItem {
+	item := PrecheckItem{
+		Name:    CheckNameMaxUserProcesses,
+		Details: make(map[string]interface{}),
+	}
+	item.Details["recommended"] = RecommendedMaxUserProcesses
+
+	limit, err := p.limitsProvider.GetMaxUserProcesses()
+	if err != nil {
+		item.Status = CheckStatusWarning
+		item.Message = fmt.Sprintf("Failed to get max user processes: %v / 获取最大用户进程数失败：%v", err, err)
+		return item
+	}
+	item.Details["current"] = limit
+
+	if limit >= 0 && limit < RecommendedMaxUserProcesses {
+		item.Status = CheckStatusWarning
+		item.Message = fmt.Sprintf("Max user processes %d < recommended %d / 最大用户进程数 %d < 推荐值 %d",
+			limit, RecommendedMaxUserProcesses, limit, RecommendedMaxUserProcesses)
+		item.Suggestion = fmt.Sprintf("Add \"* soft nproc %d\" and \"* hard nproc %d\" to /etc/security/limits.conf (or LimitNPROC=%d in the agent systemd unit) / 在 /etc/security/limits.conf 中添加 \"* soft nproc %d\" 和 \"* hard nproc %d\"（或在 Agent 的 systemd 单元中设置 LimitNPROC=%d）",
+			RecommendedMaxUserProcesses, RecommendedMaxUserProcesses, RecommendedMaxUserProcesses,
+			RecommendedMaxUserProcesses, RecommendedMaxUserProcesses, RecommendedMaxUserProcesses)
+		return item
+	}
+
+	item.Status = CheckStatusPassed
+	item.Message = fmt.Sprintf("Max user processes %s is sufficient / 最大用户进程数 %s 满足要求",
+		formatLimit(limit), formatLimit(limit))
+	return item
+}
+
+// CheckMaxMapCount checks vm.max_map_count
+// CheckMaxMapCount 检查 vm.max_map_count
+func (p *Prechecker) CheckMaxMapCount(ctx context.Context) PrecheckItem {
+	item := PrecheckItem{
+		Name:    CheckNameMaxMapCount,
+		Details: make(map[string]interface{}),
+	}
+	item.Details["recommended"] = RecommendedMaxMapCount
+
+	count, err := p.limitsProvider.GetMaxMapCount()
+	if err != nil {
+		item.Status = CheckStatusWarning
+		item.Message = fmt.Sprintf("Failed to get vm.max_map_count: %v / 获取 vm.max_map_count 失败：%v", err, err)
+		return item
+	}
+	item.Details["current"] = count
+
+	if count < RecommendedMaxMapCount {
+		item.Status = CheckStatusWarning
+		item.Message = fmt.Sprintf("vm.max_map_count %d < recommended %d / vm.max_map_count %d < 推荐值 %d",
+			count, RecommendedMaxMapCount, count, RecommendedMaxMapCount)
+		item.Suggestion = fmt.Sprintf("sysctl -w vm.max_map_count=%d && echo \"vm.max_map_count=%d\" >> /etc/sysctl.conf",
+			RecommendedMaxMapCount, RecommendedMaxMapCount)
+		return item
+	}
+
+	item.Status = CheckStatusPassed
+	item.Message = fmt.Sprintf("vm.max_map_count %d is sufficient / vm.max_map_count %d 满足要求", count, count)
+	return item
+}
+
+// CheckSwap checks whether swap is enabled; swapping JVM heap pauses Hazelcast heartbeats
+// CheckSwap 检查是否启用了 swap；JVM 堆被换出会导致 Hazelcast 心跳停顿
+func (p *Prechecker) CheckSwap(ctx context.Context) PrecheckItem {
+	item := PrecheckItem{
+		Name:    CheckNameSwap,
+		Details: make(map[string]interface{}),
+	}
+
+	swapMB, err := p.limitsProvider.GetSwapTotalMB()
+	if err != nil {
+		item.Status = CheckStatusWarning
+		item.Message = fmt.Sprintf("Failed to get swap info: %v / 获取 swap 信息失败：%v", err, err)
+		return item
+	}
+	item.Details["swap_total_mb"] = swapMB
+
+	if swapMB > 0 {
+		item.Status = CheckStatusWarning
+		item.Message = fmt.Sprintf("Swap is enabled (%d MB), JVM pauses may cause cluster members to be removed / 已启用 swap（%d MB），JVM 停顿可能导致集群成员被移除",
+			swapMB, swapMB)
+		item.Suggestion = "swapoff -a and remove swap entries from /etc/fstab, or set vm.swappiness=1 / 执行 swapoff -a 并移除 /etc/fstab 中的 swap 条目，或设置 vm.swappiness=1"
+		return item
+	}
+
+	item.Status = CheckStatusPassed
+	item.Message = "Swap is disabled / swap 已禁用"
+	return item
+}
+
+// CheckClockSync checks NTP synchronization and, when a reference time is given, the clock skew
+// against the Control Plane. All nodes are compared with the same clock, so this bounds the skew
+// between nodes as well.
+// CheckClockSync 检查 NTP 同步状态，并在提供参考时间时检查与 Control Plane 的时钟偏差。
+// 所有节点都与同一时钟比较，因此也限制了节点之间的时钟偏差。
+func (p *Prechecker) CheckClockSync(ctx context.Context) PrecheckItem {
+	item := PrecheckItem{
+		Name:    CheckNameClockSync,
+		Status:  CheckStatusPassed,
+		Details: make(map[string]interface{}),
+	}
+	suggestion := "Enable time synchronization, e.g. systemctl enable --now chronyd (or timedatectl set-ntp true) / 启用时间同步，例如 systemctl enable --now chronyd（或 timedatectl set-ntp true）"
+
+	messages := make([]string, 0, 2)
+	if p.params.ReferenceTimeMs > 0 {
+		skew := time.Since(time.UnixMilli(p.params.ReferenceTimeMs))
+		if skew < 0 {
+			skew = -skew
+		}
+		item.Details["clock_skew_ms"] = skew.Milliseconds()
+		switch {
+		case skew > ClockSkewFailThreshold:
+			item.Status = CheckStatusFailed
+			item.Suggestion = suggestion
+		case skew > ClockSkewWarnThreshold:
+			item.Status = CheckStatusWarning
+			item.Suggestion = suggestion
+		}
+		messages = append(messages, fmt.Sprintf("clock skew %v / 时钟偏差 %v", skew.Round(time.Millisecond), skew.Round(time.Millisecond)))
+	}
+
+	synced, err := p.limitsProvider.IsNTPSynchronized()
+	switch {
+	case err != nil:
+		messages = append(messages, fmt.Sprintf("NTP status unknown: %v / NTP 状态未知：%v", err, err))
+		if item.Status == CheckStatusPassed {
+			item.Status = CheckStatusWarning
+		}
+	case !synced:
+		item.Details["ntp_synchronized"] = false
+		messages = append(messages, "NTP not synchronized / NTP 未同步")
+		if item.Status == CheckStatusPassed {
+			item.Status = CheckStatusWarning
+		}
+		item.Suggestion = suggestion
+	default:
+		item.Details["ntp_synchronized"] = true
+		messages = append(messages, "NTP synchronized / NTP 已同步")
+	}
+
+	item.Message = strings.Join(messages, "; ")
+	return item
+}
+
+// CheckFirewall checks that the host firewall allows the cluster port
+// CheckFirewall 检查主机防火墙是否放行集群端口
+func (p *Prechecker) CheckFirewall(ctx context.Context) PrecheckItem {
+	item := PrecheckItem{
+		Name:    CheckNameFirewall,
+		Details: make(map[string]interface{}),
+	}
+
+	port := p.params.ClusterPort
+	if port <= 0 {
+		item.Status = CheckStatusPassed
+		item.Message = "No cluster port to check / 无需检查集群端口"
+		return item
+	}
+	item.Details["cluster_port"] = port
+
+	status, err := p.limitsProvider.GetFirewallStatus(port)
+	if err != nil {
+		item.Status = CheckStatusWarning
+		item.Message = fmt.Sprintf("Failed to get firewall status: %v / 获取防火墙状态失败：%v", err, err)
+		return item
+	}
+	if status.Name == "" {
+		item.Status = CheckStatusPassed
+		item.Message = "No active firewall detected / 未检测到活动的防火墙"
+		return item
+	}
+	item.Details["firewall"] = status.Name
+
+	if status.PortAllowed {
+		item.Status = CheckStatusPassed
+		item.Message = fmt.Sprintf("%s allows cluster port %d / %s 已放行集群端口 %d", status.Name, port, status.Name, port)
+		return item
+	}
+
+	item.Status = CheckStatusWarning
+	item.Message = fmt.Sprintf("%s does not explicitly allow cluster port %d, other nodes may fail to join / %s 未明确放行集群端口 %d，其他节点可能无法加入",
+		status.Name, port, status.Name, port)
+	switch status.Name {
+	case "firewalld":
+		item.Suggestion = fmt.Sprintf("firewall-cmd --permanent --add-port=%d/tcp && firewall-cmd --reload", port)
+	case "ufw":
+		item.Suggestion = fmt.Sprintf("ufw allow %d/tcp", port)
+	}
+	return item
+}
+
+// formatLimit formats a resource limit, where a negative value means unlimited
+// formatLimit 格式化资源限制，负值表示不限制
+func formatLimit(limit int64) string {
+	if limit < 0 {
+		return "unlimited"
+	}
+	return strconv.FormatInt(limit, 10)
+}
+
+// ============================================================================
+// DefaultSystemInfoProvider SystemLimitsProvider implementations
+// DefaultSystemInfoProvider 的 SystemLimitsProvider 实现
+// ============================================================================
+
+// GetOpenFilesLimit returns the soft open files limit from /proc/self/limits
+// GetOpenFilesLimit 从 /proc/self/limits 返回打开文件数软限制
+func (d *DefaultSystemInfoProvider) GetOpenFilesLimit() (int64, error) {
+	return readProcLimit("Max open files")
+}
+
+// GetMaxUserProcesses returns the soft max user processes limit from /proc/self/limits
+// GetMaxUserProcesses 从 /proc/self/limits 返回最大用户进程数软限制
+func (d *DefaultSystemInfoProvider) GetMaxUserProcesses() (int64, error) {
+	return readProcLimit("Max processes")
+}
+
+// GetMaxMapCount returns vm.max_map_count from /proc/sys/vm/max_map_count
+// GetMaxMapCount 从 /proc/sys/vm/max_map_count 返回 vm.max_map_count
+func (d *DefaultSystemInfoProvider) GetMaxMapCount() (int64, error) {
+	data, err := os.ReadFile("/proc/sys/vm/max_map_count")
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(strings.TrimSpace(string(data)), 10, 64)
+}
+
+// GetSwapTotalMB returns SwapTotal from /proc/meminfo in MB
+// GetSwapTotalMB 从 /proc/meminfo 返回 SwapTotal（MB）
+func (d *DefaultSystemInfoProvider) GetSwapTotalMB() (int64, error) {
+	file, err := os.Open("/proc/meminfo")
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) >= 2 && fields[0] == "SwapTotal:" {
+			kb, err := strconv.ParseInt(fields[1], 10, 64)
+			if err != nil {
+				return 0, err
+			}
+			return kb / 1024, nil
+		}
+	}
+	return 0, fmt.Errorf("SwapTotal not found in /proc/meminfo")
+}
+
+// IsNTPSynchronized queries timedatectl for the NTP synchronization state
+// IsNTPSynchronized 通过 timedatectl 查询 NTP 同步状态
+func (d *DefaultSystemInfoProvider) IsNTPSynchronized() (bool, error) {
+	output, err := exec.Command("timedatectl", "show", "-p", "NTPSynchronized", "--value").Output()
+	if err != nil {
+		return false, fmt.Errorf("timedatectl failed: %w", err)
+	}
+	return strings.TrimSpace(string(output)) == "yes", nil
+}
+
+// GetFirewallStatus checks firewalld first, then ufw
+// GetFirewallStatus 依次检查 firewalld 和 ufw
+func (d *DefaultSystemInfoProvider) GetFirewallStatus(port int) (*FirewallStatus, error) {
+	if output, err := exec.Command("firewall-cmd", "--state").Output(); err == nil && strings.TrimSpace(string(output)) == "running" {
+		output, _ = exec.Command("firewall-cmd", fmt.Sprintf("--query-port=%d/tcp", port)).Output()
+		return &FirewallStatus{
+			Name:        "firewalld",
+			PortAllowed: strings.TrimSpace(string(output)) == "yes",
+		}, nil
+	}
+
+	if output, err := exec.Command("ufw", "status").Output(); err == nil && strings.Contains(string(output), "Status: active") {
+		return &FirewallStatus{
+			Name:        "ufw",
+			PortAllowed: ufwAllowsPort(string(output), port),
+		}, nil
+	}
+
+	return &FirewallStatus{}, nil
+}
+
+// ufwAllowsPort reports whether `ufw status` output contains an ALLOW rule for port
+// ufwAllowsPort 判断 `ufw status` 输出中是否包含放行 port 的 ALLOW 规则
+func ufwAllowsPort(output string, port int) bool {
+	portStr := strconv.Itoa(port)
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || !strings.Contains(line, "ALLOW") {
+			continue
+		}
+		if fields[0] == portStr || fields[0] == portStr+"/tcp" {
+			return true
+		}
+	}
+	return false
+}
+
+// readProcLimit returns the soft limit of the named resource from /proc/self/limits.
+// The agent starts SeaTunnel, so SeaTunnel inherits these limits.
+// readProcLimit 从 /proc/self/limits 返回指定资源的软限制。
+// SeaTunnel 由 Agent 启动，因此会继承这些限制。
+func readProcLimit(name string) (int64, error) {
+	file, err := os.Open("/proc/self/limits")
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, name) {
+			continue
+		}
+		fields := strings.Fields(strings.TrimPrefix(line, name))
+		if len(fields) == 0 {
+			break
+		}
+		if fields[0] == "unlimited" {
+			return -1, nil
+		}
+		return strconv.ParseInt(fields[0], 10, 64)
+	}
+	return 0, fmt.Errorf("%s not found in /proc/self/limits", name)
+}
@@ -0,0 +1,159 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package installer
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// mockLimitsProvider is a SystemLimitsProvider with fixed values
+// mockLimitsProvider 是返回固定值的 SystemLimitsProvider
+type mockLimitsProvider struct {
+	openFiles    int64
+	maxProcesses int64
+	maxMapCount  int64
+	swapMB       int64
+	ntpSynced    bool
+	ntpErr       error
+	firewall     *FirewallStatus
+}
+
+func (m *mockLimitsProvider) GetOpenFilesLimit() (int64, error)   { return m.openFiles, nil }
+func (m *mockLimitsProvider) GetMaxUserProcesses() (int64, error) { return m.maxProcesses, nil }
+func (m *mockLimitsProvider) GetMaxMapCount() (int64, error)      { return m.maxMapCount, nil }
+func (m *mockLimitsProvider) GetSwapTotalMB() (int64, error)      { return m.swapMB, nil }
+func (m *mockLimitsProvider) IsNTPSynchronized() (bool, error)    { return m.ntpSynced, m.ntpErr }
+func (m *mockLimitsProvider) GetFirewallStatus(port int) (*FirewallStatus, error) {
+	return m.firewall, nil
+}
+
+// newSystemPrechecker creates a Prechecker backed by a mock limits provider
+// newSystemPrechecker 创建使用模拟限制提供者的 Prechecker
+func newSystemPrechecker(params *PrecheckParams, provider *mockLimitsProvider) *Prechecker {
+	p := NewPrechecker(params)
+	p.SetSystemLimitsProvider(provider)
+	return p
+}
+
+// TestRunSystemChecksHealthyHost tests that a well-tuned host passes every system check.
+// TestRunSystemChecksHealthyHost 测试调优良好的主机通过所有系统检查。
+func TestRunSystemChecksHealthyHost(t *testing.T) {
+	params := DefaultPrecheckParams()
+	params.ReferenceTimeMs = time.Now().UnixMilli()
+	p := newSystemPrechecker(params, &mockLimitsProvider{
+		openFiles:    RecommendedOpenFiles,
+		maxProcesses: -1,
+		maxMapCount:  RecommendedMaxMapCount,
+		ntpSynced:    true,
+		firewall:     &FirewallStatus{Name: "firewalld", PortAllowed: true},
+	})
+
+	result, err := p.RunSystemChecks(context.Background())
+	if err != nil {
+		t.Fatalf("RunSystemChecks failed: %v", err)
+	}
+	if len(result.Items) != len(SystemCheckNames()) {
+		t.Fatalf("Expected %d items, got %d", len(SystemCheckNames()), len(result.Items))
+	}
+	for _, item := range result.Items {
+		if item.Status != CheckStatusPassed || item.Suggestion != "" {
+			t.Errorf("Expected %s to pass without suggestion, got %s: %s", item.Name, item.Status, item.Message)
+		}
+	}
+	if result.OverallStatus != CheckStatusPassed {
+		t.Errorf("Expected overall passed, got %s", result.OverallStatus)
+	}
+}
+
+// TestRunSystemChecksMisconfiguredHost tests statuses and suggestions on a misconfigured host.
+// TestRunSystemChecksMisconfiguredHost 测试配置不当主机的检查状态与修复建议。
+func TestRunSystemChecksMisconfiguredHost(t *testing.T) {
+	params := DefaultPrecheckParams()
+	params.ReferenceTimeMs = time.Now().Add(-time.Minute).UnixMilli()
+	p := newSystemPrechecker(params, &mockLimitsProvider{
+		openFiles:    1024,
+		maxProcesses: 1024,
+		maxMapCount:  65530,
+		swapMB:       2048,
+		ntpErr:       errors.New("timedatectl not found"),
+		firewall:     &FirewallStatus{Name: "ufw"},
+	})
+
+	result, err := p.RunSystemChecks(context.Background())
+	if err != nil {
+		t.Fatalf("RunSystemChecks failed: %v", err)
+	}
+
+	expected := map[CheckName]CheckStatus{
+		CheckNameOpenFiles:        CheckStatusFailed,
+		CheckNameMaxUserProcesses: CheckStatusWarning,
+		CheckNameMaxMapCount:      CheckStatusWarning,
+		CheckNameSwap:             CheckStatusWarning,
+		CheckNameClockSync:        CheckStatusFailed,
+		CheckNameFirewall:         CheckStatusWarning,
+	}
+	for name, status := range expected {
+		item := result.GetCheck(name)
+		if item == nil {
+			t.Fatalf("Missing check %s", name)
+		}
+		if item.Status != status {
+			t.Errorf("Expected %s to be %s, got %s: %s", name, status, item.Status, item.Message)
+		}
+		if item.Suggestion == "" {
+			t.Errorf("Expected %s to carry a suggested fix", name)
+		}
+	}
+	if result.OverallStatus != CheckStatusFailed {
+		t.Errorf("Expected overall failed, got %s", result.OverallStatus)
+	}
+	if got := result.GetCheck(CheckNameFirewall).Suggestion; got != "ufw allow 5801/tcp" {
+		t.Errorf("Unexpected firewall suggestion: %s", got)
+	}
+}
+
+// TestRunSystemCheckUnknown tests that an unknown check name is rejected.
+// TestRunSystemCheckUnknown 测试未知检查名称会被拒绝。
+func TestRunSystemCheckUnknown(t *testing.T) {
+	p := newSystemPrechecker(nil, &mockLimitsProvider{})
+	if _, err := p.RunSystemCheck(context.Background(), "selinux"); err == nil {
+		t.Error("Expected error for unknown system check")
+	}
+}
+
+// TestUfwAllowsPort tests parsing of ufw status output.
+// TestUfwAllowsPort 测试 ufw status 输出的解析。
+func TestUfwAllowsPort(t *testing.T) {
+	output := `Status: active
+
+To                         Action      From
+--                         ------      ----
+22/tcp                     ALLOW       Anywhere
+5801/tcp                   ALLOW       10.0.0.0/8
+8080                       DENY        Anywhere
+`
+	if !ufwAllowsPort(output, 5801) {
+		t.Error("Expected port 5801 to be allowed")
+	}
+	if ufwAllowsPort(output, 8080) {
+		t.Error("Expected port 8080 not to be allowed")
+	}
+}
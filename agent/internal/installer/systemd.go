@@ -0,0 +1,94 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package installer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/seatunnel/seatunnelX/agent/internal/process"
+)
+
+// systemdAvailable is overridden in tests.
+// systemdAvailable 在测试中会被覆盖。
+var systemdAvailable = process.SystemdAvailable
+
+// systemdUnitEnv lists Agent environment variables copied into generated units,
+// since systemd starts services with an almost empty environment.
+// systemdUnitEnv 列出复制到生成 unit 中的 Agent 环境变量，因为 systemd 启动服务时环境变量几乎为空。
+var systemdUnitEnv = []string{"JAVA_HOME", "PATH"}
+
+// systemdUnitRole returns the role baked into the unit: hybrid nodes run without -r.
+// systemdUnitRole 返回写入 unit 的角色：混合模式节点不带 -r 参数运行。
+func systemdUnitRole(params *InstallParams) string {
+	if params.DeploymentMode == DeploymentModeHybrid {
+		return ""
+	}
+	return string(params.NodeRole)
+}
+
+// executeStepConfigureSystemd installs and enables a seatunnel unit for the node role so the
+// process survives reboots. Hosts without systemd keep the Agent-managed direct exec.
+// executeStepConfigureSystemd 为节点角色安装并启用 seatunnel unit，使进程在主机重启后仍能恢复。
+// 没有 systemd 的主机继续由 Agent 直接启动进程。
+func (m *InstallerManager) executeStepConfigureSystemd(ctx context.Context, params *InstallParams, reporter ProgressReporter) error {
+	if !params.EnableSystemd {
+		reporter.Report(InstallStepConfigureSystemd, 100, "Systemd integration skipped / 跳过 systemd 集成")
+		return nil
+	}
+	if !systemdAvailable() {
+		reporter.Report(InstallStepConfigureSystemd, 100, "systemd not available, the Agent will manage the process directly / systemd 不可用，将由 Agent 直接管理进程")
+		return nil
+	}
+
+	role := systemdUnitRole(params)
+	unit := process.SystemdUnitName(role)
+	env := make(map[string]string, len(systemdUnitEnv))
+	for _, key := range systemdUnitEnv {
+		if value := os.Getenv(key); value != "" {
+			env[key] = value
+		}
+	}
+	// Prefer the Java installed by the install_java step / 优先使用 install_java 步骤安装的 Java
+	if params.JavaHome != "" {
+		path := env["PATH"]
+		if path == "" {
+			path = "/usr/local/bin:/usr/bin:/bin"
+		}
+		env["JAVA_HOME"] = params.JavaHome
+		env["PATH"] = filepath.Join(params.JavaHome, "bin") + ":" + path
+	}
+
+	reporter.Report(InstallStepConfigureSystemd, 30, fmt.Sprintf("Writing unit %s... / 写入 unit %s...", unit, unit))
+	path := filepath.Join(systemdUnitDir, unit)
+	if err := os.WriteFile(path, []byte(process.RenderSystemdUnit(params.InstallDir, role, env)), 0644); err != nil {
+		return fmt.Errorf("write systemd unit %s: %w / 写入 systemd unit %s 失败: %w", path, err, path, err)
+	}
+	if err := runSystemctl(ctx, "daemon-reload"); err != nil {
+		return fmt.Errorf("systemctl daemon-reload: %w", err)
+	}
+	// Enable only: the Control Plane starts the node afterwards through the process manager
+	// 仅启用：之后由 Control Plane 通过进程管理器启动节点
+	if err := runSystemctl(ctx, "enable", unit); err != nil {
+		return fmt.Errorf("systemctl enable %s: %w", unit, err)
+	}
+	reporter.Report(InstallStepConfigureSystemd, 100, fmt.Sprintf("Unit %s installed and enabled / unit %s 已安装并启用", unit, unit))
+	return nil
+}
@@ -0,0 +1,67 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package installer
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestExecuteStepConfigureSystemd(t *testing.T) {
+	unitDir := t.TempDir()
+	oldUnitDir, oldSystemctl, oldAvailable := systemdUnitDir, systemctlCommand, systemdAvailable
+	systemdUnitDir, systemctlCommand = unitDir, filepath.Join(unitDir, "no-systemctl")
+	defer func() { systemdUnitDir, systemctlCommand, systemdAvailable = oldUnitDir, oldSystemctl, oldAvailable }()
+
+	m := NewInstallerManager()
+	params := &InstallParams{InstallDir: "/opt/seatunnel", DeploymentMode: DeploymentModeSeparated, NodeRole: NodeRoleWorker}
+
+	// Disabled by default / 默认不启用
+	systemdAvailable = func() bool { return true }
+	if err := m.executeStepConfigureSystemd(context.Background(), params, &NoOpProgressReporter{}); err != nil {
+		t.Fatalf("disabled step failed: %v", err)
+	}
+	if entries, _ := os.ReadDir(unitDir); len(entries) != 0 {
+		t.Fatalf("no unit expected when systemd integration is disabled, got %d entries", len(entries))
+	}
+
+	// Hosts without systemd fall back to direct exec / 没有 systemd 的主机回退到直接启动
+	params.EnableSystemd = true
+	systemdAvailable = func() bool { return false }
+	if err := m.executeStepConfigureSystemd(context.Background(), params, &NoOpProgressReporter{}); err != nil {
+		t.Fatalf("step without systemd failed: %v", err)
+	}
+	if entries, _ := os.ReadDir(unitDir); len(entries) != 0 {
+		t.Fatalf("no unit expected without systemd, got %d entries", len(entries))
+	}
+
+	systemdAvailable = func() bool { return true }
+	if err := m.executeStepConfigureSystemd(context.Background(), params, &NoOpProgressReporter{}); err != nil {
+		t.Fatalf("step failed: %v", err)
+	}
+	content, err := os.ReadFile(filepath.Join(unitDir, "seatunnel-worker.service"))
+	if err != nil {
+		t.Fatalf("worker unit not written: %v", err)
+	}
+	if !strings.Contains(string(content), "seatunnel-cluster.sh -r worker") {
+		t.Fatalf("worker unit should start the worker role, got:\n%s", content)
+	}
+}
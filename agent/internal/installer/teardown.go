@@ -0,0 +1,135 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package installer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// systemdUnitDir and systemctlCommand are overridden in tests.
+// systemdUnitDir 与 systemctlCommand 在测试中会被覆盖。
+var (
+	systemdUnitDir   = "/etc/systemd/system"
+	systemctlCommand = "systemctl"
+)
+
+// TeardownResult lists the runtime artifacts removed for an installation directory.
+// TeardownResult 列出为安装目录移除的运行时产物。
+type TeardownResult struct {
+	InstallDir   string   `json:"install_dir"`
+	RemovedFiles []string `json:"removed_files,omitempty"`
+	RemovedUnits []string `json:"removed_units,omitempty"`
+	Warnings     []string `json:"warnings,omitempty"`
+}
+
+// TeardownRuntimeArtifacts removes what the Agent generated around an installation when its
+// cluster is deleted: the managed seatunnelx-java-proxy service with its pid/port state and
+// systemd units that point into the installation. The installation directory itself is kept.
+// TeardownRuntimeArtifacts 在集群删除时移除 Agent 围绕安装目录生成的内容：托管的 seatunnelx-java-proxy
+// 服务及其 pid/port 状态，以及指向该安装目录的 systemd unit。安装目录本身保留。
+func TeardownRuntimeArtifacts(ctx context.Context, installDir string) (*TeardownResult, error) {
+	clean, err := normalizeInstallDirPath(installDir)
+	if err != nil {
+		return nil, err
+	}
+	result := &TeardownResult{InstallDir: clean}
+
+	stateDir := filepath.Join(clean, seatunnelxJavaProxyStateDirName)
+	if _, err := os.Stat(stateDir); err == nil {
+		if status, err := StopManagedSeatunnelXJavaProxyService(ctx, clean); err != nil && status != nil && status.Managed {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("stop seatunnelx-java-proxy: %v", err))
+		}
+		if err := os.RemoveAll(stateDir); err != nil {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("remove %s: %v", stateDir, err))
+		} else {
+			result.RemovedFiles = append(result.RemovedFiles, stateDir)
+		}
+	}
+
+	if runtime.GOOS == "linux" {
+		removeSystemdUnits(ctx, clean, result)
+	}
+	return result, nil
+}
+
+// removeSystemdUnits disables and deletes SeaTunnel units whose definition references the installation.
+// removeSystemdUnits 停用并删除定义中引用该安装目录的 SeaTunnel unit。
+func removeSystemdUnits(ctx context.Context, installDir string, result *TeardownResult) {
+	entries, err := os.ReadDir(systemdUnitDir)
+	if err != nil {
+		return
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".service") || !strings.HasPrefix(name, "seatunnel") ||
+			strings.HasPrefix(name, "seatunnelx-agent") {
+			continue
+		}
+		path := filepath.Join(systemdUnitDir, name)
+		content, err := os.ReadFile(path)
+		if err != nil || !unitReferencesDir(string(content), installDir) {
+			continue
+		}
+		if err := runSystemctl(ctx, "disable", "--now", name); err != nil {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("disable %s: %v", name, err))
+		}
+		if err := os.Remove(path); err != nil {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("remove %s: %v", path, err))
+			continue
+		}
+		result.RemovedUnits = append(result.RemovedUnits, name)
+	}
+	if len(result.RemovedUnits) > 0 {
+		_ = runSystemctl(ctx, "daemon-reload")
+	}
+}
+
+// runSystemctl runs systemctl when it is available; hosts without systemd have nothing to disable.
+// runSystemctl 在 systemctl 可用时执行；没有 systemd 的主机无需停用任何内容。
+func runSystemctl(ctx context.Context, args ...string) error {
+	path, err := exec.LookPath(systemctlCommand)
+	if err != nil {
+		return nil
+	}
+	if out, err := exec.CommandContext(ctx, path, args...).CombinedOutput(); err != nil {
+		return fmt.Errorf("%w: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// unitReferencesDir reports whether a unit file mentions the directory as a whole path element.
+// unitReferencesDir 判断 unit 文件是否以完整路径元素的形式引用该目录。
+func unitReferencesDir(content, dir string) bool {
+	for rest := content; ; {
+		idx := strings.Index(rest, dir)
+		if idx < 0 {
+			return false
+		}
+		end := idx + len(dir)
+		if end == len(rest) || strings.ContainsRune("/ \t\r\n\"'=", rune(rest[end])) {
+			return true
+		}
+		rest = rest[end:]
+	}
+}
@@ -0,0 +1,73 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package installer
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestTeardownRuntimeArtifacts(t *testing.T) {
+	installDir := filepath.Join(t.TempDir(), "seatunnel")
+	stateDir := filepath.Join(installDir, seatunnelxJavaProxyStateDirName, seatunnelxJavaProxyServiceDirName)
+	if err := os.MkdirAll(stateDir, 0o755); err != nil {
+		t.Fatalf("failed to create state dir: %v", err)
+	}
+
+	unitDir := t.TempDir()
+	oldUnitDir, oldSystemctl := systemdUnitDir, systemctlCommand
+	systemdUnitDir, systemctlCommand = unitDir, filepath.Join(unitDir, "no-systemctl")
+	defer func() { systemdUnitDir, systemctlCommand = oldUnitDir, oldSystemctl }()
+
+	units := map[string]string{
+		"seatunnel-master.service": "[Service]\nExecStart=" + installDir + "/bin/seatunnel-cluster.sh -r master\n",
+		"seatunnel-other.service":  "[Service]\nExecStart=" + installDir + "-other/bin/seatunnel-cluster.sh\n",
+		"seatunnelx-agent.service": "[Service]\nWorkingDirectory=" + installDir + "\n",
+		"unrelated-worker.service": "[Service]\nExecStart=" + installDir + "/bin/seatunnel-cluster.sh\n",
+	}
+	for name, content := range units {
+		if err := os.WriteFile(filepath.Join(unitDir, name), []byte(content), 0o644); err != nil {
+			t.Fatalf("failed to write unit: %v", err)
+		}
+	}
+
+	result, err := TeardownRuntimeArtifacts(context.Background(), installDir)
+	if err != nil {
+		t.Fatalf("TeardownRuntimeArtifacts returned error: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(installDir, seatunnelxJavaProxyStateDirName)); !os.IsNotExist(err) {
+		t.Fatalf("expected state dir to be removed, stat err=%v", err)
+	}
+	if _, err := os.Stat(installDir); err != nil {
+		t.Fatalf("expected install dir to be kept: %v", err)
+	}
+	if runtime.GOOS != "linux" {
+		return
+	}
+	if len(result.RemovedUnits) != 1 || result.RemovedUnits[0] != "seatunnel-master.service" {
+		t.Fatalf("expected only seatunnel-master.service to be removed, got %v", result.RemovedUnits)
+	}
+	for _, kept := range []string{"seatunnel-other.service", "seatunnelx-agent.service", "unrelated-worker.service"} {
+		if _, err := os.Stat(filepath.Join(unitDir, kept)); err != nil {
+			t.Fatalf("expected %s to be kept: %v", kept, err)
+		}
+	}
+}
@@ -24,6 +24,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"runtime"
 	"strings"
 	"time"
 )
@@ -125,7 +126,7 @@ func (m *InstallerManager) VerifyInstallLayout(installDir string) error {
 		return fmt.Errorf("install_dir is required")
 	}
 	requiredPaths := []string{
-		filepath.Join(installDir, "bin", "seatunnel-cluster.sh"),
+		seatunnelScriptPath(installDir, "seatunnel-cluster"),
 		filepath.Join(installDir, "config"),
 	}
 	for _, requiredPath := range requiredPaths {
@@ -146,7 +147,7 @@ func (m *InstallerManager) RunSmokeTestTemplate(ctx context.Context, installDir
 		return "", err
 	}
 
-	scriptPath := filepath.Join(installDir, "bin", "seatunnel.sh")
+	scriptPath := seatunnelScriptPath(installDir, "seatunnel")
 	configPath := filepath.Join(installDir, "config", "v2.batch.config.template")
 	if _, err := os.Stat(scriptPath); err != nil {
 		return "", fmt.Errorf("smoke test script is missing: %w", err)
@@ -158,7 +159,12 @@ func (m *InstallerManager) RunSmokeTestTemplate(ctx context.Context, installDir
 	smokeCtx, cancel := context.WithTimeout(ctx, 5*time.Minute)
 	defer cancel()
 
-	cmd := exec.CommandContext(smokeCtx, "bash", "-lc", "./bin/seatunnel.sh -c config/v2.batch.config.template")
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(smokeCtx, "cmd", "/c", scriptPath, "-c", configPath)
+	} else {
+		cmd = exec.CommandContext(smokeCtx, "bash", "-lc", "./bin/seatunnel.sh -c config/v2.batch.config.template")
+	}
 	cmd.Dir = installDir
 	output, err := cmd.CombinedOutput()
 	summary := summarizeCommandOutput(string(output))
@@ -25,6 +25,7 @@ import (
 	"sync"
 
 	"github.com/seatunnel/seatunnelX/agent/internal/config"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 	"gopkg.in/natefinch/lumberjack.v2"
@@ -34,6 +35,9 @@ var (
 	rootLogger *zap.Logger
 	initOnce   sync.Once
 	initErr    error
+
+	// atomicLevel 支持运行期动态调整日志级别（如 Control Plane 下发配置）
+	atomicLevel = zap.NewAtomicLevelAt(zapcore.InfoLevel)
 )
 
 // Init 初始化 Agent 日志：
@@ -67,12 +71,12 @@ func Init(cfg *config.Config) error {
 			EncodeCaller:   zapcore.ShortCallerEncoder,
 		}
 
-		level := parseLevel(cfg.Log.Level)
+		atomicLevel.SetLevel(parseLevel(cfg.Log.Level))
 
 		core := zapcore.NewCore(
 			zapcore.NewJSONEncoder(encoderCfg),
 			w,
-			level,
+			atomicLevel,
 		)
 
 		rootLogger = zap.New(core,
@@ -130,6 +134,16 @@ func parseLevel(level string) zapcore.Level {
 	}
 }
 
+// SetLevel 在运行期调整日志级别，无需重建 logger
+func SetLevel(level string) {
+	atomicLevel.SetLevel(parseLevel(level))
+}
+
+// GetLevel 返回当前生效的日志级别字符串
+func GetLevel() string {
+	return atomicLevel.Level().String()
+}
+
 // L 返回底层 *zap.SugaredLogger，便于在复杂场景下直接使用
 func L() *zap.SugaredLogger {
 	if rootLogger == nil {
@@ -139,13 +153,28 @@ func L() *zap.SugaredLogger {
 	return rootLogger.Sugar()
 }
 
-// DebugF 与 internal/logger 一致：首参为 context，用于后续扩展（如 trace）
+// traceFields 在 ctx 携带有效 span 时返回 traceID/spanID 字段，便于与 Control Plane 日志关联
+func traceFields(ctx context.Context) []zap.Field {
+	if ctx == nil {
+		return nil
+	}
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return nil
+	}
+	return []zap.Field{
+		zap.String("traceID", sc.TraceID().String()),
+		zap.String("spanID", sc.SpanID().String()),
+	}
+}
+
+// DebugF 与 internal/logger 一致：首参为 context，携带 span 时附加 trace 字段
 func DebugF(ctx context.Context, format string, args ...interface{}) {
 	if rootLogger == nil {
 		L().Debugf(format, args...)
 		return
 	}
-	rootLogger.Debug(fmt.Sprintf(format, args...))
+	rootLogger.Debug(fmt.Sprintf(format, args...), traceFields(ctx)...)
 }
 
 // InfoF 与 internal/logger 一致
@@ -154,7 +183,7 @@ func InfoF(ctx context.Context, format string, args ...interface{}) {
 		L().Infof(format, args...)
 		return
 	}
-	rootLogger.Info(fmt.Sprintf(format, args...))
+	rootLogger.Info(fmt.Sprintf(format, args...), traceFields(ctx)...)
 }
 
 // WarnF 与 internal/logger 一致
@@ -163,7 +192,7 @@ func WarnF(ctx context.Context, format string, args ...interface{}) {
 		L().Warnf(format, args...)
 		return
 	}
-	rootLogger.Warn(fmt.Sprintf(format, args...))
+	rootLogger.Warn(fmt.Sprintf(format, args...), traceFields(ctx)...)
 }
 
 // ErrorF 与 internal/logger 一致
@@ -172,5 +201,5 @@ func ErrorF(ctx context.Context, format string, args ...interface{}) {
 		L().Errorf(format, args...)
 		return
 	}
-	rootLogger.Error(fmt.Sprintf(format, args...))
+	rootLogger.Error(fmt.Sprintf(format, args...), traceFields(ctx)...)
 }
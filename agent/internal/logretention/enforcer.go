@@ -0,0 +1,213 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package logretention enforces the log disk quota of managed SeaTunnel installations.
+// logretention 包为受管 SeaTunnel 安装目录执行日志磁盘配额。
+package logretention
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/seatunnel/seatunnelX/agent/internal/logger"
+	"github.com/seatunnel/seatunnelX/agent/internal/state"
+)
+
+const (
+	defaultInterval     = 10 * time.Minute
+	defaultActiveWindow = 10 * time.Minute
+)
+
+// protectedPatterns match live log files directly under the logs directory that must never be
+// deleted while the engine may still hold them open.
+// protectedPatterns 匹配 logs 目录下引擎可能仍在写入、绝不能删除的当前日志文件。
+var protectedPatterns = []string{"seatunnel-engine-*.log", "gc*.log", "*.out"}
+
+// PolicySource provides the configured log disk quotas.
+// PolicySource 提供已配置的日志磁盘配额。
+type PolicySource interface {
+	LogPolicies() []*state.LogPolicyRecord
+	DeleteLogPolicy(installDir string) error
+}
+
+// Result describes one enforcement pass over an installation's logs directory.
+// Result 描述对一个安装目录 logs 目录的一次配额执行结果。
+type Result struct {
+	InstallDir   string   `json:"install_dir"`
+	QuotaBytes   int64    `json:"quota_bytes"`
+	TotalBytes   int64    `json:"total_bytes"`
+	FreedBytes   int64    `json:"freed_bytes"`
+	RemovedFiles []string `json:"removed_files,omitempty"`
+}
+
+// Enforcer periodically deletes the oldest log files of installations exceeding their quota.
+// Enforcer 定期删除超出配额的安装目录中最旧的日志文件。
+type Enforcer struct {
+	policies     PolicySource
+	interval     time.Duration
+	activeWindow time.Duration
+	now          func() time.Time
+}
+
+// NewEnforcer creates an Enforcer backed by the given policy source.
+// NewEnforcer 基于给定的配额来源创建 Enforcer。
+func NewEnforcer(policies PolicySource) *Enforcer {
+	return &Enforcer{
+		policies:     policies,
+		interval:     defaultInterval,
+		activeWindow: defaultActiveWindow,
+		now:          time.Now,
+	}
+}
+
+// Start runs one pass immediately and then one pass per interval until ctx is done.
+// Start 立即执行一次，之后按间隔周期执行，直到 ctx 结束。
+func (e *Enforcer) Start(ctx context.Context) {
+	if e == nil || e.policies == nil {
+		return
+	}
+
+	go func() {
+		e.EnforceOnce(ctx)
+
+		ticker := time.NewTicker(e.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				e.EnforceOnce(ctx)
+			}
+		}
+	}()
+}
+
+// EnforceOnce applies every configured quota once; policies of removed installations are dropped.
+// EnforceOnce 执行一次所有已配置的配额；已移除安装目录的配额会被清理。
+func (e *Enforcer) EnforceOnce(ctx context.Context) []*Result {
+	if e == nil || e.policies == nil {
+		return nil
+	}
+
+	var results []*Result
+	for _, policy := range e.policies.LogPolicies() {
+		if ctx.Err() != nil {
+			break
+		}
+		if _, err := os.Stat(policy.InstallDir); errors.Is(err, fs.ErrNotExist) {
+			if err := e.policies.DeleteLogPolicy(policy.InstallDir); err != nil {
+				logger.WarnF(ctx, "[LogRetention] Failed to drop stale policy %s: %v / 删除失效配额 %s 失败：%v", policy.InstallDir, err, policy.InstallDir, err)
+			}
+			continue
+		}
+		result, err := e.enforce(policy)
+		if err != nil {
+			logger.WarnF(ctx, "[LogRetention] Failed to enforce quota for %s: %v / 执行 %s 的日志配额失败：%v", policy.InstallDir, err, policy.InstallDir, err)
+			continue
+		}
+		if len(result.RemovedFiles) > 0 {
+			logger.InfoF(ctx, "[LogRetention] Removed %d log files (%d bytes) under %s / 已删除 %s 下 %d 个日志文件（%d 字节）",
+				len(result.RemovedFiles), result.FreedBytes, policy.InstallDir, policy.InstallDir, len(result.RemovedFiles), result.FreedBytes)
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+type logFile struct {
+	path    string
+	size    int64
+	modTime time.Time
+}
+
+// enforce deletes the oldest removable files under <install>/logs until the total fits the quota.
+// enforce 删除 <install>/logs 下最旧的可删除文件，直到总大小不超过配额。
+func (e *Enforcer) enforce(policy *state.LogPolicyRecord) (*Result, error) {
+	result := &Result{InstallDir: policy.InstallDir, QuotaBytes: policy.QuotaBytes}
+	if policy.QuotaBytes <= 0 {
+		return result, nil
+	}
+
+	logsDir := filepath.Join(policy.InstallDir, "logs")
+	activeSince := e.now().Add(-e.activeWindow)
+	var candidates []logFile
+	err := filepath.WalkDir(logsDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if path == logsDir && errors.Is(err, fs.ErrNotExist) {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		result.TotalBytes += info.Size()
+		if info.ModTime().After(activeSince) || isProtected(logsDir, path) {
+			return nil
+		}
+		candidates = append(candidates, logFile{path: path, size: info.Size(), modTime: info.ModTime()})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].modTime.Equal(candidates[j].modTime) {
+			return candidates[i].path < candidates[j].path
+		}
+		return candidates[i].modTime.Before(candidates[j].modTime)
+	})
+	for _, file := range candidates {
+		if result.TotalBytes <= policy.QuotaBytes {
+			break
+		}
+		if err := os.Remove(file.path); err != nil {
+			continue
+		}
+		result.TotalBytes -= file.size
+		result.FreedBytes += file.size
+		result.RemovedFiles = append(result.RemovedFiles, file.path)
+	}
+	return result, nil
+}
+
+// isProtected reports whether path is a live log file directly under logsDir.
+// isProtected 判断 path 是否为 logsDir 下正在使用的日志文件。
+func isProtected(logsDir, path string) bool {
+	if filepath.Dir(path) != logsDir {
+		return false
+	}
+	name := filepath.Base(path)
+	for _, pattern := range protectedPatterns {
+		if matched, _ := filepath.Match(pattern, name); matched {
+			return true
+		}
+	}
+	return false
+}
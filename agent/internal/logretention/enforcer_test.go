@@ -0,0 +1,95 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package logretention
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/seatunnel/seatunnelX/agent/internal/state"
+)
+
+func writeLog(t *testing.T, path string, size int, modTime time.Time) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(path, make([]byte, size), 0o644); err != nil {
+		t.Fatalf("write %s: %v", path, err)
+	}
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatalf("chtimes %s: %v", path, err)
+	}
+}
+
+func TestEnforceOnceDeletesOldestFilesUntilWithinQuota(t *testing.T) {
+	installDir := t.TempDir()
+	logsDir := filepath.Join(installDir, "logs")
+	now := time.Now()
+	old := now.Add(-48 * time.Hour)
+
+	writeLog(t, filepath.Join(logsDir, "seatunnel-engine-server.log"), 400, old)
+	writeLog(t, filepath.Join(logsDir, "seatunnel-engine-server.log.2026-01-01-1.gz"), 300, old)
+	writeLog(t, filepath.Join(logsDir, "seatunnel-engine-server.log.2026-01-02-1.gz"), 300, old.Add(time.Hour))
+	writeLog(t, filepath.Join(logsDir, "job-1", "job-1.log"), 300, old.Add(2*time.Hour))
+	writeLog(t, filepath.Join(logsDir, "job-2.log"), 300, now)
+
+	store, _ := state.NewStore("")
+	if err := store.PutLogPolicy(&state.LogPolicyRecord{InstallDir: installDir, QuotaBytes: 1100}); err != nil {
+		t.Fatalf("PutLogPolicy: %v", err)
+	}
+
+	results := NewEnforcer(store).EnforceOnce(context.Background())
+	if len(results) != 1 {
+		t.Fatalf("expected one result, got %d", len(results))
+	}
+	result := results[0]
+	if result.TotalBytes != 1000 || result.FreedBytes != 600 || len(result.RemovedFiles) != 2 {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	for _, removed := range []string{"seatunnel-engine-server.log.2026-01-01-1.gz", "seatunnel-engine-server.log.2026-01-02-1.gz"} {
+		if _, err := os.Stat(filepath.Join(logsDir, removed)); !os.IsNotExist(err) {
+			t.Errorf("expected %s to be removed", removed)
+		}
+	}
+	for _, kept := range []string{"seatunnel-engine-server.log", filepath.Join("job-1", "job-1.log"), "job-2.log"} {
+		if _, err := os.Stat(filepath.Join(logsDir, kept)); err != nil {
+			t.Errorf("expected %s to be kept: %v", kept, err)
+		}
+	}
+}
+
+func TestEnforceOnceDropsPoliciesOfRemovedInstallations(t *testing.T) {
+	store, _ := state.NewStore("")
+	kept := t.TempDir()
+	missing := filepath.Join(t.TempDir(), "gone")
+	_ = store.PutLogPolicy(&state.LogPolicyRecord{InstallDir: kept, QuotaBytes: 1024})
+	_ = store.PutLogPolicy(&state.LogPolicyRecord{InstallDir: missing, QuotaBytes: 1024})
+
+	results := NewEnforcer(store).EnforceOnce(context.Background())
+	if len(results) != 1 || results[0].InstallDir != kept || results[0].TotalBytes != 0 {
+		t.Fatalf("unexpected results: %+v", results)
+	}
+	policies := store.LogPolicies()
+	if len(policies) != 1 || policies[0].InstallDir != kept {
+		t.Fatalf("expected stale policy to be dropped, got %+v", policies)
+	}
+}
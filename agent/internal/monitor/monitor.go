@@ -206,8 +206,11 @@ func (m *ProcessMonitor) Stop() error {
 
 // monitorLoop runs the monitoring loop
 // monitorLoop 运行监控循环
+// The interval is re-read after every tick so SetMonitorInterval takes effect without restart.
+// 每次 tick 后重新读取间隔，使 SetMonitorInterval 无需重启即可生效。
 func (m *ProcessMonitor) monitorLoop() {
-	ticker := time.NewTicker(m.monitorInterval)
+	interval := m.getMonitorInterval()
+	ticker := time.NewTicker(interval)
 	defer ticker.Stop()
 
 	for {
@@ -216,10 +219,26 @@ func (m *ProcessMonitor) monitorLoop() {
 			return
 		case <-ticker.C:
 			m.checkAllProcesses()
+			if next := m.getMonitorInterval(); next != interval {
+				interval = next
+				ticker.Reset(interval)
+				logger.InfoF(m.ctx, "[ProcessMonitor] Interval changed to %v / 监控间隔已调整为 %v", interval, interval)
+			}
 		}
 	}
 }
 
+// getMonitorInterval returns the current monitoring interval
+// getMonitorInterval 返回当前监控间隔
+func (m *ProcessMonitor) getMonitorInterval() time.Duration {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if m.monitorInterval <= 0 {
+		return DefaultMonitorInterval
+	}
+	return m.monitorInterval
+}
+
 // checkAllProcesses checks the status of all tracked processes
 // checkAllProcesses 检查所有跟踪进程的状态
 // Requirements 3.1, 3.6: Check process status, detect consecutive failures
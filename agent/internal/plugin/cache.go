@@ -0,0 +1,268 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package plugin
+
+import (
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CacheStats is a snapshot of the plugin cache reported in heartbeats.
+// CacheStats 是心跳中上报的插件缓存快照。
+type CacheStats struct {
+	Entries   int64 `json:"entries"`
+	SizeBytes int64 `json:"size_bytes"`
+	MaxBytes  int64 `json:"max_bytes"`
+	Hits      int64 `json:"hits"`
+	Misses    int64 `json:"misses"`
+	Evictions int64 `json:"evictions"`
+}
+
+// Cache is a content-addressed store of verified plugin files keyed by their SHA-256 digest.
+// Cache 是按 SHA-256 摘要寻址的已校验插件文件存储。
+// Entries are evicted by age first and then least-recently-used until the cache fits maxBytes.
+// 条目先按存活时间淘汰，再按最近最少使用淘汰，直到缓存不超过 maxBytes。
+type Cache struct {
+	dir      string
+	maxBytes int64
+	maxAge   time.Duration
+
+	mu        sync.Mutex
+	hits      int64
+	misses    int64
+	evictions int64
+}
+
+// cacheEntry describes one cached file during GC.
+// cacheEntry 描述 GC 期间的单个缓存文件。
+type cacheEntry struct {
+	path    string
+	size    int64
+	modTime time.Time
+}
+
+// NewCache creates the cache directory and returns a cache bounded by maxBytes and maxAge.
+// A zero maxBytes or maxAge disables the corresponding limit.
+// NewCache 创建缓存目录并返回受 maxBytes 和 maxAge 限制的缓存。
+// maxBytes 或 maxAge 为 0 时不启用对应限制。
+func NewCache(dir string, maxBytes int64, maxAge time.Duration) (*Cache, error) {
+	if strings.TrimSpace(dir) == "" {
+		return nil, fmt.Errorf("plugin cache dir is empty / 插件缓存目录为空")
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	return &Cache{dir: dir, maxBytes: maxBytes, maxAge: maxAge}, nil
+}
+
+// Dir returns the cache directory.
+// Dir 返回缓存目录。
+func (c *Cache) Dir() string {
+	return c.dir
+}
+
+// isCacheKey reports whether checksum is a lowercase or uppercase hex SHA-256 digest.
+// isCacheKey 判断 checksum 是否为十六进制 SHA-256 摘要。
+func isCacheKey(checksum string) bool {
+	if len(checksum) != 64 {
+		return false
+	}
+	_, err := hex.DecodeString(checksum)
+	return err == nil
+}
+
+func (c *Cache) entryPath(checksum string) string {
+	return filepath.Join(c.dir, strings.ToLower(checksum))
+}
+
+// Lookup returns the cached path for checksum when an entry of the given size exists.
+// A size of 0 skips the size check. Hits refresh the entry for LRU eviction.
+// Lookup 在存在给定大小的条目时返回 checksum 对应的缓存路径。
+// size 为 0 时跳过大小检查。命中会刷新条目的 LRU 时间。
+func (c *Cache) Lookup(checksum string, size int64) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !isCacheKey(checksum) {
+		c.misses++
+		return "", false
+	}
+	path := c.entryPath(checksum)
+	info, err := os.Stat(path)
+	if err != nil || !info.Mode().IsRegular() || (size > 0 && info.Size() != size) {
+		c.misses++
+		return "", false
+	}
+	now := time.Now()
+	_ = os.Chtimes(path, now, now)
+	c.hits++
+	return path, true
+}
+
+// Put copies a verified file into the cache under checksum and runs GC.
+// Put 将已校验文件以 checksum 为键复制到缓存并执行 GC。
+func (c *Cache) Put(srcPath, checksum string) error {
+	if !isCacheKey(checksum) {
+		return fmt.Errorf("invalid plugin cache key: %s", checksum)
+	}
+
+	c.mu.Lock()
+	path := c.entryPath(checksum)
+	if _, err := os.Stat(path); err == nil {
+		now := time.Now()
+		_ = os.Chtimes(path, now, now)
+		c.mu.Unlock()
+		return nil
+	}
+	tmpPath := path + ".tmp"
+	if err := copyFile(srcPath, tmpPath); err != nil {
+		_ = os.Remove(tmpPath)
+		c.mu.Unlock()
+		return err
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		_ = os.Remove(tmpPath)
+		c.mu.Unlock()
+		return err
+	}
+	c.mu.Unlock()
+
+	_, err := c.GC()
+	return err
+}
+
+// Materialize copies the cached file for checksum to dst.
+// Materialize 将 checksum 对应的缓存文件复制到 dst。
+func (c *Cache) Materialize(checksum, dst string) error {
+	if !isCacheKey(checksum) {
+		return fmt.Errorf("invalid plugin cache key: %s", checksum)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	tmpPath := dst + ".tmp"
+	if err := copyFile(c.entryPath(checksum), tmpPath); err != nil {
+		_ = os.Remove(tmpPath)
+		return err
+	}
+	if err := os.Rename(tmpPath, dst); err != nil {
+		_ = os.Remove(tmpPath)
+		return err
+	}
+	return nil
+}
+
+// GC removes entries older than maxAge, then evicts least-recently-used entries until the
+// cache fits maxBytes. It returns the number of evicted entries.
+// GC 删除超过 maxAge 的条目，然后淘汰最近最少使用的条目直到缓存不超过 maxBytes。
+// 返回被淘汰的条目数。
+func (c *Cache) GC() (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entries, err := c.listEntries()
+	if err != nil {
+		return 0, err
+	}
+
+	evicted := 0
+	var total int64
+	kept := entries[:0]
+	cutoff := time.Now().Add(-c.maxAge)
+	for _, entry := range entries {
+		if c.maxAge > 0 && entry.modTime.Before(cutoff) {
+			if err := os.Remove(entry.path); err == nil {
+				evicted++
+				continue
+			}
+		}
+		kept = append(kept, entry)
+		total += entry.size
+	}
+
+	if c.maxBytes > 0 && total > c.maxBytes {
+		sort.Slice(kept, func(i, j int) bool { return kept[i].modTime.Before(kept[j].modTime) })
+		for _, entry := range kept {
+			if total <= c.maxBytes {
+				break
+			}
+			if err := os.Remove(entry.path); err != nil {
+				continue
+			}
+			total -= entry.size
+			evicted++
+		}
+	}
+
+	c.evictions += int64(evicted)
+	return evicted, nil
+}
+
+// Stats returns the current cache size together with hit, miss and eviction counters.
+// Stats 返回当前缓存大小以及命中、未命中和淘汰计数。
+func (c *Cache) Stats() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats := CacheStats{
+		MaxBytes:  c.maxBytes,
+		Hits:      c.hits,
+		Misses:    c.misses,
+		Evictions: c.evictions,
+	}
+	entries, err := c.listEntries()
+	if err != nil {
+		return stats
+	}
+	for _, entry := range entries {
+		stats.Entries++
+		stats.SizeBytes += entry.size
+	}
+	return stats
+}
+
+// listEntries lists cache files, ignoring in-flight temporary files. Callers hold c.mu.
+// listEntries 列出缓存文件，忽略写入中的临时文件。调用方需持有 c.mu。
+func (c *Cache) listEntries() ([]cacheEntry, error) {
+	dirEntries, err := os.ReadDir(c.dir)
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]cacheEntry, 0, len(dirEntries))
+	for _, dirEntry := range dirEntries {
+		if !dirEntry.Type().IsRegular() || !isCacheKey(dirEntry.Name()) {
+			continue
+		}
+		info, err := dirEntry.Info()
+		if err != nil {
+			continue
+		}
+		entries = append(entries, cacheEntry{
+			path:    filepath.Join(c.dir, dirEntry.Name()),
+			size:    info.Size(),
+			modTime: info.ModTime(),
+		})
+	}
+	return entries, nil
+}
@@ -0,0 +1,154 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package plugin
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeCacheSource(t *testing.T, dir, name string, content []byte) (string, string) {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, content, 0644); err != nil {
+		t.Fatalf("write source: %v", err)
+	}
+	digest := sha256.Sum256(content)
+	return path, hex.EncodeToString(digest[:])
+}
+
+func TestCacheLookupTracksHitsAndMisses(t *testing.T) {
+	cache, err := NewCache(t.TempDir(), 0, 0)
+	if err != nil {
+		t.Fatalf("new cache: %v", err)
+	}
+	src, checksum := writeCacheSource(t, t.TempDir(), "a.jar", []byte("plugin-a"))
+
+	if _, ok := cache.Lookup(checksum, 8); ok {
+		t.Fatalf("expected miss before put")
+	}
+	if err := cache.Put(src, checksum); err != nil {
+		t.Fatalf("put: %v", err)
+	}
+	if _, ok := cache.Lookup(checksum, 9); ok {
+		t.Fatalf("expected miss for size mismatch")
+	}
+	if _, ok := cache.Lookup("../"+checksum[3:], 0); ok {
+		t.Fatalf("expected miss for invalid key")
+	}
+	path, ok := cache.Lookup(checksum, 8)
+	if !ok {
+		t.Fatalf("expected hit after put")
+	}
+	if data, err := os.ReadFile(path); err != nil || string(data) != "plugin-a" {
+		t.Fatalf("unexpected cached content %q (%v)", data, err)
+	}
+
+	stats := cache.Stats()
+	if stats.Entries != 1 || stats.SizeBytes != 8 || stats.Hits != 1 || stats.Misses != 3 {
+		t.Fatalf("unexpected stats: %+v", stats)
+	}
+}
+
+func TestCacheGCEvictsByAgeThenLeastRecentlyUsed(t *testing.T) {
+	dir := t.TempDir()
+	unbounded, err := NewCache(dir, 0, 0)
+	if err != nil {
+		t.Fatalf("new cache: %v", err)
+	}
+	srcDir := t.TempDir()
+	now := time.Now()
+	checksums := make([]string, 0, 3)
+	for i, name := range []string{"old.jar", "lru.jar", "recent.jar"} {
+		src, checksum := writeCacheSource(t, srcDir, name, []byte(name+"-0123456"))
+		if err := unbounded.Put(src, checksum); err != nil {
+			t.Fatalf("put %s: %v", name, err)
+		}
+		// Age the entries: old is past maxAge, lru is older than recent
+		modTime := now.Add(-time.Duration(2-i) * time.Hour)
+		if i == 0 {
+			modTime = now.Add(-48 * time.Hour)
+		}
+		if err := os.Chtimes(filepath.Join(dir, checksum), modTime, modTime); err != nil {
+			t.Fatalf("chtimes: %v", err)
+		}
+		checksums = append(checksums, checksum)
+	}
+
+	cache, err := NewCache(dir, 20, 24*time.Hour)
+	if err != nil {
+		t.Fatalf("new cache: %v", err)
+	}
+	evicted, err := cache.GC()
+	if err != nil {
+		t.Fatalf("gc: %v", err)
+	}
+	if evicted != 2 {
+		t.Fatalf("expected 2 evictions, got %d", evicted)
+	}
+	for i, checksum := range checksums {
+		_, statErr := os.Stat(filepath.Join(dir, checksum))
+		if exists := statErr == nil; exists != (i == 2) {
+			t.Fatalf("entry %d exists=%v after gc", i, exists)
+		}
+	}
+	if stats := cache.Stats(); stats.Entries != 1 || stats.Evictions != 2 || stats.MaxBytes != 20 {
+		t.Fatalf("unexpected stats: %+v", stats)
+	}
+}
+
+func TestFinalizeTransferPopulatesCacheForInstallFromCache(t *testing.T) {
+	cache, err := NewCache(t.TempDir(), 0, 0)
+	if err != nil {
+		t.Fatalf("new cache: %v", err)
+	}
+	content := []byte("connector jar content")
+	digest := sha256.Sum256(content)
+	checksum := hex.EncodeToString(digest[:])
+
+	first := NewManager(t.TempDir())
+	first.SetCache(cache)
+	if _, err := first.ReceivePluginChunk("jdbc", "1.0.0", "connector", "connectors", "connector-jdbc-1.0.0.jar", content, 0, int64(len(content)), true, checksum); err != nil {
+		t.Fatalf("receive chunk: %v", err)
+	}
+	if _, err := first.FinalizeTransfer("jdbc", "1.0.0", "connectors", "connector-jdbc-1.0.0.jar"); err != nil {
+		t.Fatalf("finalize transfer: %v", err)
+	}
+
+	second := NewManager(t.TempDir())
+	second.SetCache(cache)
+	path, err := second.InstallFromCache("connector", "connectors", "connector-jdbc-1.0.0.jar", checksum, int64(len(content)))
+	if err != nil {
+		t.Fatalf("install from cache: %v", err)
+	}
+	if path != filepath.Join(second.GetConnectorsDir(), "connector-jdbc-1.0.0.jar") {
+		t.Fatalf("unexpected install path %s", path)
+	}
+	if data, err := os.ReadFile(path); err != nil || string(data) != string(content) {
+		t.Fatalf("expected cached connector content, got %q (%v)", data, err)
+	}
+
+	if _, err := second.InstallFromCache("dependency", "lib", "other.jar", checksum[:63]+"0", 0); !errors.Is(err, ErrPluginNotFound) {
+		t.Fatalf("expected ErrPluginNotFound for uncached content, got %v", err)
+	}
+}
@@ -28,9 +28,12 @@ package plugin
 
 import (
 	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"hash"
 	"io"
 	"os"
 	"path/filepath"
@@ -111,6 +114,10 @@ type Manager struct {
 	// transfers 跟踪正在进行的文件传输
 	transfers map[string]*TransferState
 	mu        sync.RWMutex
+
+	// cache keeps verified plugin files so later transfers of the same content can be skipped
+	// cache 保存已校验的插件文件，使相同内容的后续传输可以跳过
+	cache *Cache
 }
 
 // NewManager creates a new plugin Manager instance.
@@ -138,6 +145,18 @@ func (m *Manager) GetSeaTunnelPath() string {
 	return m.seatunnelPath
 }
 
+// SetCache attaches the content-addressed plugin cache.
+// SetCache 设置按内容寻址的插件缓存。
+func (m *Manager) SetCache(cache *Cache) {
+	m.cache = cache
+}
+
+// GetCache returns the plugin cache, or nil when caching is disabled.
+// GetCache 返回插件缓存，未启用缓存时返回 nil。
+func (m *Manager) GetCache() *Cache {
+	return m.cache
+}
+
 // GetConnectorsDir returns the connectors directory path.
 // GetConnectorsDir 返回连接器目录路径。
 func (m *Manager) GetConnectorsDir() string {
@@ -234,7 +253,7 @@ func (m *Manager) FinalizeTransfer(pluginName, version, targetDir, fileName stri
 
 	// Verify checksum if provided / 如果提供了校验和则验证
 	if state.Checksum != "" {
-		actualChecksum, err := calculateSHA1(state.TempPath)
+		actualChecksum, err := calculateChecksum(state.TempPath, state.Checksum)
 		if err != nil {
 			os.Remove(state.TempPath)
 			return "", fmt.Errorf("failed to calculate checksum: %w", err)
@@ -246,18 +265,17 @@ func (m *Manager) FinalizeTransfer(pluginName, version, targetDir, fileName stri
 		}
 	}
 
+	// Keep a verified copy for later transfers; caching is best effort
+	// 为后续传输保留一份已校验副本；缓存失败不影响本次传输
+	if m.cache != nil && isCacheKey(state.Checksum) {
+		_ = m.cache.Put(state.TempPath, state.Checksum)
+	}
+
 	// Determine target directory based on file type / 根据文件类型确定目标目录
-	var targetRoot string
-	switch state.FileType {
-	case "connector":
-		targetRoot = m.GetConnectorsDir()
-	default:
-		resolved, err := m.resolveTargetDir(state.TargetDir)
-		if err != nil {
-			_ = os.Remove(state.TempPath)
-			return "", err
-		}
-		targetRoot = resolved
+	targetRoot, err := m.targetRoot(state.FileType, state.TargetDir)
+	if err != nil {
+		_ = os.Remove(state.TempPath)
+		return "", err
 	}
 
 	// Create target directory if not exists / 如果目标目录不存在则创建
@@ -326,6 +344,44 @@ func (m *Manager) InstallPlugin(pluginName, version, installPath string, depende
 	return connectorPath, libPaths, nil
 }
 
+// InstallFromCache places a cached plugin file at its target location without a transfer.
+// It returns ErrPluginNotFound when the cache has no entry for checksum and size.
+// InstallFromCache 无需传输，直接将缓存的插件文件放到目标位置。
+// 缓存中没有对应 checksum 和大小的条目时返回 ErrPluginNotFound。
+func (m *Manager) InstallFromCache(fileType, targetDir, fileName, checksum string, size int64) (string, error) {
+	if m.cache == nil {
+		return "", ErrPluginNotFound
+	}
+	if err := validatePluginIdentifier("file_name", fileName); err != nil {
+		return "", err
+	}
+	if _, ok := m.cache.Lookup(checksum, size); !ok {
+		return "", ErrPluginNotFound
+	}
+
+	targetRoot, err := m.targetRoot(fileType, targetDir)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(targetRoot, 0755); err != nil {
+		return "", fmt.Errorf("failed to create target directory: %w", err)
+	}
+	targetPath := filepath.Join(targetRoot, fileName)
+	if err := m.cache.Materialize(checksum, targetPath); err != nil {
+		return "", fmt.Errorf("failed to copy cached plugin: %w", err)
+	}
+	return targetPath, nil
+}
+
+// targetRoot resolves the directory a transferred file of fileType is placed in.
+// targetRoot 解析指定文件类型的传输文件所放置的目录。
+func (m *Manager) targetRoot(fileType, targetDir string) (string, error) {
+	if fileType == "connector" {
+		return m.GetConnectorsDir(), nil
+	}
+	return m.resolveTargetDir(targetDir)
+}
+
 func (m *Manager) resolveTargetDir(targetDir string) (string, error) {
 	targetDir = strings.TrimSpace(targetDir)
 	if targetDir == "" {
@@ -662,16 +718,27 @@ func parseConnectorFileName(filename string) (name, version string) {
 	return filename, ""
 }
 
-// calculateSHA1 calculates the SHA1 checksum of a file.
-// calculateSHA1 计算文件的 SHA1 校验和。
-func calculateSHA1(filePath string) (string, error) {
+// calculateChecksum calculates the checksum of a file with the algorithm implied by the
+// length of the expected hex digest: SHA-512, SHA-256, or SHA-1 for older Control Planes.
+// calculateChecksum 按预期十六进制摘要的长度选择算法计算文件校验和：
+// SHA-512、SHA-256，或兼容旧版 Control Plane 的 SHA-1。
+func calculateChecksum(filePath, expected string) (string, error) {
+	var hasher hash.Hash
+	switch len(strings.TrimSpace(expected)) {
+	case sha512.Size * 2:
+		hasher = sha512.New()
+	case sha256.Size * 2:
+		hasher = sha256.New()
+	default:
+		hasher = sha1.New()
+	}
+
 	file, err := os.Open(filePath)
 	if err != nil {
 		return "", err
 	}
 	defer file.Close()
 
-	hasher := sha1.New()
 	if _, err := io.Copy(hasher, file); err != nil {
 		return "", err
 	}
@@ -18,9 +18,12 @@
 package plugin
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -74,3 +77,28 @@ func TestUninstallPluginRemovesConnectorWithinConnectorsDir(t *testing.T) {
 		t.Fatalf("expected connector file removed, stat err: %v", err)
 	}
 }
+
+func TestFinalizeTransferVerifiesSHA256Checksum(t *testing.T) {
+	baseDir := t.TempDir()
+	manager := NewManager(baseDir)
+	content := []byte("connector jar content")
+	digest := sha256.Sum256(content)
+
+	if _, err := manager.ReceivePluginChunk("jdbc", "1.0.0", "connector", "connectors", "connector-jdbc-1.0.0.jar", content, 0, int64(len(content)), true, strings.Repeat("0", 64)); err != nil {
+		t.Fatalf("receive chunk: %v", err)
+	}
+	if _, err := manager.FinalizeTransfer("jdbc", "1.0.0", "connectors", "connector-jdbc-1.0.0.jar"); !errors.Is(err, ErrInvalidChecksum) {
+		t.Fatalf("expected ErrInvalidChecksum, got %v", err)
+	}
+
+	if _, err := manager.ReceivePluginChunk("jdbc", "1.0.0", "connector", "connectors", "connector-jdbc-1.0.0.jar", content, 0, int64(len(content)), true, hex.EncodeToString(digest[:])); err != nil {
+		t.Fatalf("receive chunk: %v", err)
+	}
+	path, err := manager.FinalizeTransfer("jdbc", "1.0.0", "connectors", "connector-jdbc-1.0.0.jar")
+	if err != nil {
+		t.Fatalf("finalize transfer: %v", err)
+	}
+	if data, err := os.ReadFile(path); err != nil || string(data) != string(content) {
+		t.Fatalf("expected installed connector content, got %q (%v)", data, err)
+	}
+}
@@ -43,6 +43,7 @@ import (
 	"time"
 
 	"github.com/seatunnel/seatunnelX/agent/internal/logger"
+	"github.com/seatunnel/seatunnelX/agent/internal/state"
 )
 
 // Common errors for process management
@@ -166,10 +167,22 @@ type ManagedProcess struct {
 	// InstallDir 是安装目录
 	InstallDir string `json:"install_dir"`
 
+	// Role is the node role the process was started with
+	// Role 是启动进程时使用的节点角色
+	Role string `json:"role,omitempty"`
+
 	// LastError is the last error encountered
 	// LastError 是最后遇到的错误
 	LastError string `json:"last_error,omitempty"`
 
+	// RestartCount is the number of consecutive restarts performed by the restart policy
+	// RestartCount 是重启策略执行的连续重启次数
+	RestartCount int `json:"restart_count,omitempty"`
+
+	// SystemdUnit is the systemd unit controlling the process, empty when started directly
+	// SystemdUnit 是控制该进程的 systemd unit，直接启动时为空
+	SystemdUnit string `json:"systemd_unit,omitempty"`
+
 	// cmd is the underlying exec.Cmd (internal use)
 	// cmd 是底层的 exec.Cmd（内部使用）
 	cmd *exec.Cmd
@@ -182,15 +195,37 @@ type ManagedProcess struct {
 // ProcessInfo contains information about a process for external use
 // ProcessInfo 包含用于外部使用的进程信息
 type ProcessInfo struct {
-	Name        string        `json:"name"`
-	PID         int           `json:"pid"`
-	Status      ProcessStatus `json:"status"`
-	StartTime   time.Time     `json:"start_time"`
-	Uptime      time.Duration `json:"uptime"`
-	CPUUsage    float64       `json:"cpu_usage"`
-	MemoryUsage int64         `json:"memory_usage"`
-	InstallDir  string        `json:"install_dir"`
-	LastError   string        `json:"last_error,omitempty"`
+	Name         string        `json:"name"`
+	PID          int           `json:"pid"`
+	Status       ProcessStatus `json:"status"`
+	StartTime    time.Time     `json:"start_time"`
+	Uptime       time.Duration `json:"uptime"`
+	CPUUsage     float64       `json:"cpu_usage"`
+	MemoryUsage  int64         `json:"memory_usage"`
+	InstallDir   string        `json:"install_dir"`
+	Role         string        `json:"role,omitempty"`
+	LastError    string        `json:"last_error,omitempty"`
+	RestartCount int           `json:"restart_count,omitempty"`
+	SystemdUnit  string        `json:"systemd_unit,omitempty"`
+}
+
+// info returns a snapshot of the process; caller must hold proc.mu.
+// info 返回进程快照；调用方必须持有 proc.mu。
+func (proc *ManagedProcess) info() *ProcessInfo {
+	return &ProcessInfo{
+		Name:         proc.Name,
+		PID:          proc.PID,
+		Status:       proc.Status,
+		StartTime:    proc.StartTime,
+		Uptime:       proc.Uptime,
+		CPUUsage:     proc.CPUUsage,
+		MemoryUsage:  proc.MemoryUsage,
+		InstallDir:   proc.InstallDir,
+		Role:         proc.Role,
+		LastError:    proc.LastError,
+		RestartCount: proc.RestartCount,
+		SystemdUnit:  proc.SystemdUnit,
+	}
 }
 
 // StartParams contains parameters for starting a process
@@ -223,6 +258,18 @@ type StartParams struct {
 	// Timeout for startup (optional, defaults to DefaultStartTimeout)
 	// 启动超时时间（可选，默认为 DefaultStartTimeout）
 	Timeout time.Duration `json:"timeout,omitempty"`
+
+	// RestartPolicy controls restarts after unexpected exits (optional, defaults to never)
+	// RestartPolicy 控制意外退出后的重启（可选，默认为 never）
+	RestartPolicy RestartPolicy `json:"restart_policy,omitempty"`
+
+	// MaxRestarts limits consecutive restarts (optional, see RestartPolicy for defaults)
+	// MaxRestarts 限制连续重启次数（可选，默认值见 RestartPolicy）
+	MaxRestarts int `json:"max_restarts,omitempty"`
+
+	// RestartBackoff is the delay before the first restart, doubled per attempt (optional, defaults to DefaultRestartBackoff)
+	// RestartBackoff 是第一次重启前的等待时间，每次尝试翻倍（可选，默认为 DefaultRestartBackoff）
+	RestartBackoff time.Duration `json:"restart_backoff,omitempty"`
 }
 
 // StopParams contains parameters for stopping a process
@@ -282,6 +329,10 @@ type ProcessManager struct {
 	// processes 按名称存储托管进程
 	processes sync.Map
 
+	// restarts stores restart policy trackers by process name
+	// restarts 按进程名存储重启策略跟踪器
+	restarts sync.Map
+
 	// monitorCtx is the context for the monitor goroutine
 	// monitorCtx 是监控 goroutine 的上下文
 	monitorCtx context.Context
@@ -302,6 +353,10 @@ type ProcessManager struct {
 	// eventHandler 在进程事件发生时被调用
 	eventHandler ProcessEventHandler
 
+	// state persists started processes across Agent restarts (optional)
+	// state 在 Agent 重启之间持久化已启动的进程（可选）
+	state *state.Store
+
 	// mu protects manager state
 	// mu 保护管理器状态
 	mu sync.RWMutex
@@ -420,6 +475,7 @@ func (m *ProcessManager) checkAllProcesses() {
 				proc.Status = StatusStopped
 				proc.LastError = "Process exited unexpectedly / 进程意外退出"
 				m.notifyEvent(name, EventCrashed, proc)
+				go m.handleUnexpectedExit(name, time.Since(proc.StartTime))
 			} else {
 				// Update metrics / 更新指标
 				proc.Uptime = time.Since(proc.StartTime)
@@ -441,18 +497,7 @@ func (m *ProcessManager) notifyEvent(name string, event ProcessEvent, proc *Mana
 	m.mu.RUnlock()
 
 	if handler != nil {
-		info := &ProcessInfo{
-			Name:        proc.Name,
-			PID:         proc.PID,
-			Status:      proc.Status,
-			StartTime:   proc.StartTime,
-			Uptime:      proc.Uptime,
-			CPUUsage:    proc.CPUUsage,
-			MemoryUsage: proc.MemoryUsage,
-			InstallDir:  proc.InstallDir,
-			LastError:   proc.LastError,
-		}
-		handler(name, event, info)
+		handler(name, event, proc.info())
 	}
 }
 
@@ -464,6 +509,22 @@ func (m *ProcessManager) StartProcess(ctx context.Context, name string, params *
 	if params == nil {
 		return errors.New("start params is nil")
 	}
+	if _, err := ParseRestartPolicy(string(params.RestartPolicy)); err != nil {
+		return err
+	}
+
+	// A user-initiated start resets the restart policy state
+	// 用户发起的启动会重置重启策略状态
+	m.resetRestartTracker(name, params)
+	return m.startProcess(ctx, name, params)
+}
+
+// startProcess starts the process without touching restart policy state.
+// startProcess 启动进程，不修改重启策略状态。
+func (m *ProcessManager) startProcess(ctx context.Context, name string, params *StartParams) error {
+	if params == nil {
+		return errors.New("start params is nil")
+	}
 
 	if params.InstallDir == "" {
 		return ErrInvalidInstallDir
@@ -493,9 +554,16 @@ func (m *ProcessManager) StartProcess(ctx context.Context, name string, params *
 		Name:       name,
 		Status:     StatusStarting,
 		InstallDir: params.InstallDir,
+		Role:       params.Role,
 	}
 	m.processes.Store(name, proc)
 
+	// Prefer systemd when the installer registered a unit for this node, so the process survives reboots
+	// 安装器为该节点注册了 unit 时优先使用 systemd，使进程在主机重启后仍能恢复
+	if unit := FindSystemdUnit(params.InstallDir, params.Role); unit != "" {
+		return m.startSystemdUnit(ctx, name, proc, params, unit)
+	}
+
 	// Set timeout / 设置超时
 	timeout := params.Timeout
 	if timeout == 0 {
@@ -571,7 +639,9 @@ func (m *ProcessManager) StartProcess(ctx context.Context, name string, params *
 
 	// Wait a bit for the daemon process to start
 	// 等待守护进程启动
-	time.Sleep(3 * time.Second)
+	if err := sleepWithContext(ctx, 3*time.Second); err != nil {
+		return err
+	}
 
 	// Find the actual SeaTunnel process by searching for Java process
 	// 通过搜索 Java 进程找到实际的 SeaTunnel 进程
@@ -593,6 +663,7 @@ func (m *ProcessManager) StartProcess(ctx context.Context, name string, params *
 	proc.LastError = ""
 	proc.mu.Unlock()
 
+	m.persistProcess(name, proc, params)
 	m.notifyEvent(name, EventStarted, proc)
 
 	// Start a goroutine to monitor the process
@@ -615,40 +686,37 @@ func findSeaTunnelProcess(installDir string, role string) (int, error) {
 
 	// Use pgrep or ps to find the process
 	// 使用 pgrep 或 ps 查找进程
-	var cmd *exec.Cmd
 	if runtime.GOOS == "windows" {
-		// On Windows, use wmic / 在 Windows 上使用 wmic
-		cmd = exec.Command("wmic", "process", "where", fmt.Sprintf("CommandLine like '%%%s%%' and CommandLine like '%%SeaTunnel%%'", installDir), "get", "ProcessId")
-	} else {
-		// On Linux, use ps + grep to find the process more reliably
-		// 在 Linux 上使用 ps + grep 更可靠地查找进程
-		var grepCmd string
-		if isHybridMode {
-			// For hybrid mode, find processes without -r flag or with SEATUNNEL_HOME matching installDir
-			// 混合模式，查找没有 -r 参数的进程或 SEATUNNEL_HOME 匹配 installDir 的进程
-			grepCmd = fmt.Sprintf("ps -ef | grep '%s' | grep -v '\\-r master' | grep -v '\\-r worker' | grep -v grep | awk '{print $2}'", appMain)
-		} else {
-			// For separated mode, find processes with specific role
-			// 分离模式，查找特定角色的进程
-			grepCmd = fmt.Sprintf("ps -ef | grep '%s' | grep '\\-r %s' | grep -v grep | awk '{print $2}'", appMain, role)
+		if pids := findSeaTunnelPIDsWindows(context.Background(), appMain, installDir, role, isHybridMode); len(pids) > 0 {
+			return pids[0], nil
 		}
-		cmd = exec.Command("/bin/bash", "-c", grepCmd)
+		return 0, fmt.Errorf("no SeaTunnel process found / 未找到 SeaTunnel 进程")
+	}
+
+	// On Linux, use ps + grep to find the process more reliably
+	// 在 Linux 上使用 ps + grep 更可靠地查找进程
+	var grepCmd string
+	if isHybridMode {
+		// For hybrid mode, find processes without -r flag or with SEATUNNEL_HOME matching installDir
+		// 混合模式，查找没有 -r 参数的进程或 SEATUNNEL_HOME 匹配 installDir 的进程
+		grepCmd = fmt.Sprintf("ps -ef | grep '%s' | grep -v '\\-r master' | grep -v '\\-r worker' | grep -v grep | awk '{print $2}'", appMain)
+	} else {
+		// For separated mode, find processes with specific role
+		// 分离模式，查找特定角色的进程
+		grepCmd = fmt.Sprintf("ps -ef | grep '%s' | grep '\\-r %s' | grep -v grep | awk '{print $2}'", appMain, role)
 	}
+	cmd := exec.Command("/bin/bash", "-c", grepCmd)
 
 	output, err := cmd.Output()
 	if err != nil {
 		// If ps+grep fails, try pgrep as fallback / 如果 ps+grep 失败，尝试 pgrep 作为备用
-		if runtime.GOOS != "windows" {
-			pattern := installDir
-			if !isHybridMode {
-				pattern = fmt.Sprintf("seatunnel.*%s", role)
-			}
-			fallbackCmd := exec.Command("pgrep", "-f", pattern)
-			output, err = fallbackCmd.Output()
-			if err != nil {
-				return 0, err
-			}
-		} else {
+		pattern := installDir
+		if !isHybridMode {
+			pattern = fmt.Sprintf("seatunnel.*%s", role)
+		}
+		fallbackCmd := exec.Command("pgrep", "-f", pattern)
+		output, err = fallbackCmd.Output()
+		if err != nil {
 			return 0, err
 		}
 	}
@@ -657,7 +725,7 @@ func findSeaTunnelProcess(installDir string, role string) (int, error) {
 	lines := strings.Split(strings.TrimSpace(string(output)), "\n")
 	for _, line := range lines {
 		line = strings.TrimSpace(line)
-		if line == "" || line == "ProcessId" {
+		if line == "" {
 			continue
 		}
 		pid, err := strconv.Atoi(line)
@@ -687,10 +755,18 @@ func (m *ProcessManager) monitorProcess(name string, proc *ManagedProcess) {
 
 		if pid <= 0 || !isProcessAlive(pid) {
 			proc.mu.Lock()
+			if proc.Status != StatusRunning {
+				// Already handled by checkAllProcesses or StopProcess
+				// 已由 checkAllProcesses 或 StopProcess 处理
+				proc.mu.Unlock()
+				return
+			}
 			proc.Status = StatusStopped
 			proc.PID = 0
+			uptime := time.Since(proc.StartTime)
 			proc.mu.Unlock()
 			m.notifyEvent(name, EventCrashed, proc)
+			m.handleUnexpectedExit(name, uptime)
 			return
 		}
 	}
@@ -703,6 +779,18 @@ func (m *ProcessManager) monitorProcess(name string, proc *ManagedProcess) {
 func (m *ProcessManager) StopProcess(ctx context.Context, name string, params *StopParams) error {
 	const appMain = "org.apache.seatunnel.core.starter.seatunnel.SeaTunnelServer"
 
+	// An explicit stop is not a failure: cancel restarts and keep monitors from reporting a crash
+	// 主动停止不属于失败：取消重启，并避免监控将其上报为崩溃
+	m.cancelRestarts(name)
+	if value, ok := m.processes.Load(name); ok {
+		proc := value.(*ManagedProcess)
+		proc.mu.Lock()
+		if proc.Status == StatusRunning {
+			proc.Status = StatusStopping
+		}
+		proc.mu.Unlock()
+	}
+
 	// Set timeout / 设置超时
 	timeout := m.gracefulTimeout
 	if params != nil && params.Timeout > 0 {
@@ -716,23 +804,28 @@ func (m *ProcessManager) StopProcess(ctx context.Context, name string, params *S
 		role = params.Role
 	}
 
+	// Units are stopped through systemd; otherwise Restart=on-failure would bring a killed JVM back
+	// 由 systemd 管理的进程通过 systemd 停止，否则 Restart=on-failure 会重新拉起被杀死的 JVM
+	if m.stopSystemdUnit(ctx, name, params, timeout) {
+		return nil
+	}
+
 	// Determine if this is hybrid mode / 判断是否为混合模式
 	// Hybrid mode: empty, "hybrid", or "master/worker"
 	// 混合模式：空、"hybrid" 或 "master/worker"
 	isHybridMode := role == "" || role == "hybrid" || role == "master/worker"
 
-	var grepCmd string
-	if isHybridMode {
-		// For hybrid mode, find processes without -r flag / 混合模式，查找没有 -r 参数的进程
-		grepCmd = fmt.Sprintf("ps -ef | grep '%s' | grep -v '\\-r master' | grep -v '\\-r worker' | grep -v grep | awk '{print $2}'", appMain)
-	} else {
-		// For separated mode, find processes with specific role / 分离模式，查找特定角色的进程
-		grepCmd = fmt.Sprintf("ps -ef | grep '%s' | grep '\\-r %s' | grep -v grep | awk '{print $2}'", appMain, role)
-	}
-
 	// Execute ps command to find PIDs / 执行 ps 命令查找 PID
 	var pids []int
 	if runtime.GOOS != "windows" {
+		var grepCmd string
+		if isHybridMode {
+			// For hybrid mode, find processes without -r flag / 混合模式，查找没有 -r 参数的进程
+			grepCmd = fmt.Sprintf("ps -ef | grep '%s' | grep -v '\\-r master' | grep -v '\\-r worker' | grep -v grep | awk '{print $2}'", appMain)
+		} else {
+			// For separated mode, find processes with specific role / 分离模式，查找特定角色的进程
+			grepCmd = fmt.Sprintf("ps -ef | grep '%s' | grep '\\-r %s' | grep -v grep | awk '{print $2}'", appMain, role)
+		}
 		cmd := exec.CommandContext(ctx, "/bin/bash", "-c", grepCmd)
 		output, _ := cmd.Output()
 		pidStrs := strings.Fields(strings.TrimSpace(string(output)))
@@ -741,13 +834,24 @@ func (m *ProcessManager) StopProcess(ctx context.Context, name string, params *S
 				pids = append(pids, pid)
 			}
 		}
+	} else {
+		installDir := ""
+		if params != nil {
+			installDir = params.InstallDir
+		}
+		pids = findSeaTunnelPIDsWindows(ctx, appMain, installDir, role, isHybridMode)
+		// Let the bundled stop script shut the cluster member down gracefully first
+		// 先由自带的停止脚本优雅关闭集群成员
+		if len(pids) > 0 && installDir != "" {
+			runWindowsStopScript(ctx, installDir, role, isHybridMode)
+		}
 	}
 
 	// Also check tracked process / 同时检查跟踪的进程
 	if value, ok := m.processes.Load(name); ok {
 		proc := value.(*ManagedProcess)
 		proc.mu.RLock()
-		if proc.PID > 0 && proc.Status == StatusRunning {
+		if proc.PID > 0 && (proc.Status == StatusRunning || proc.Status == StatusStopping) {
 			// Add tracked PID if not already in list / 如果不在列表中则添加跟踪的 PID
 			found := false
 			for _, p := range pids {
@@ -773,6 +877,7 @@ func (m *ProcessManager) StopProcess(ctx context.Context, name string, params *S
 			proc.PID = 0
 			proc.mu.Unlock()
 		}
+		m.forgetProcess(name)
 		return nil
 	}
 
@@ -796,7 +901,9 @@ func (m *ProcessManager) StopProcess(ctx context.Context, name string, params *S
 		if allDead {
 			break
 		}
-		time.Sleep(500 * time.Millisecond)
+		if err := sleepWithContext(ctx, 500*time.Millisecond); err != nil {
+			return err
+		}
 	}
 
 	// Force kill any remaining processes / 强制杀死任何剩余的进程
@@ -816,6 +923,7 @@ func (m *ProcessManager) StopProcess(ctx context.Context, name string, params *S
 		proc.mu.Unlock()
 		m.notifyEvent(name, EventStopped, proc)
 	}
+	m.forgetProcess(name)
 
 	logger.InfoF(ctx, "Stopped %d SeaTunnel process(es) for role '%s' / 停止了 %d 个角色 '%s' 的 SeaTunnel 进程", len(pids), role, len(pids), role)
 	return nil
@@ -829,7 +937,9 @@ func (m *ProcessManager) RestartProcess(ctx context.Context, name string, startP
 	// Always try to stop first / 始终先尝试停止
 	_ = m.StopProcess(ctx, name, stopParams)
 	// Wait for process to fully exit / 等待进程完全退出
-	time.Sleep(3 * time.Second)
+	if err := sleepWithContext(ctx, 3*time.Second); err != nil {
+		return err
+	}
 
 	// Start the process / 启动进程
 	return m.StartProcess(ctx, name, startParams)
@@ -862,17 +972,7 @@ func (m *ProcessManager) GetStatus(ctx context.Context, name string) (*ProcessIn
 		}
 	}
 
-	return &ProcessInfo{
-		Name:        proc.Name,
-		PID:         proc.PID,
-		Status:      proc.Status,
-		StartTime:   proc.StartTime,
-		Uptime:      proc.Uptime,
-		CPUUsage:    proc.CPUUsage,
-		MemoryUsage: proc.MemoryUsage,
-		InstallDir:  proc.InstallDir,
-		LastError:   proc.LastError,
-	}, nil
+	return proc.info(), nil
 }
 
 // ListProcesses returns information about all managed processes
@@ -883,17 +983,7 @@ func (m *ProcessManager) ListProcesses() []*ProcessInfo {
 	m.processes.Range(func(key, value interface{}) bool {
 		proc := value.(*ManagedProcess)
 		proc.mu.RLock()
-		info := &ProcessInfo{
-			Name:        proc.Name,
-			PID:         proc.PID,
-			Status:      proc.Status,
-			StartTime:   proc.StartTime,
-			Uptime:      proc.Uptime,
-			CPUUsage:    proc.CPUUsage,
-			MemoryUsage: proc.MemoryUsage,
-			InstallDir:  proc.InstallDir,
-			LastError:   proc.LastError,
-		}
+		info := proc.info()
 		proc.mu.RUnlock()
 		processes = append(processes, info)
 		return true
@@ -906,6 +996,7 @@ func (m *ProcessManager) ListProcesses() []*ProcessInfo {
 // RemoveProcess 从管理中移除进程（不停止它）
 func (m *ProcessManager) RemoveProcess(name string) {
 	m.processes.Delete(name)
+	m.forgetProcess(name)
 }
 
 // IsRunning checks if a process is running
@@ -1069,10 +1160,15 @@ func sendSignal(pid int, sig syscall.Signal) error {
 	}
 
 	if runtime.GOOS == "windows" {
-		// On Windows, we can only kill the process
-		// 在 Windows 上，我们只能终止进程
-		if sig == syscall.SIGKILL || sig == syscall.SIGTERM {
-			return process.Kill()
+		// Windows has no signals: taskkill asks the process tree to close, /F forces it
+		// Windows 没有信号：taskkill 请求关闭进程树，/F 强制终止
+		switch sig {
+		case syscall.SIGTERM:
+			return exec.Command("taskkill", "/T", "/PID", strconv.Itoa(pid)).Run()
+		case syscall.SIGKILL:
+			if err := exec.Command("taskkill", "/F", "/T", "/PID", strconv.Itoa(pid)).Run(); err != nil {
+				return process.Kill()
+			}
 		}
 		return nil
 	}
@@ -1281,3 +1377,16 @@ func TailLogs(ctx context.Context, logFile string, output chan<- string) error {
 		}
 	}
 }
+
+// sleepWithContext waits for the given duration unless ctx is cancelled first.
+// sleepWithContext 等待指定时长，若 ctx 先被取消则提前返回。
+func sleepWithContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
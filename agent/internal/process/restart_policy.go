@@ -0,0 +1,278 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package process
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/seatunnel/seatunnelX/agent/internal/logger"
+)
+
+// RestartPolicy controls whether the ProcessManager restarts a process after it exits unexpectedly.
+// SeaTunnel runs as a daemon, so exit codes are not observable: any exit not requested via
+// StopProcess is treated as a failure.
+// RestartPolicy 控制 ProcessManager 是否在进程意外退出后重启它。
+// SeaTunnel 以守护进程方式运行，无法获取退出码：任何非 StopProcess 触发的退出都视为失败。
+type RestartPolicy string
+
+const (
+	// RestartNever never restarts the process (default)
+	// RestartNever 从不重启进程（默认）
+	RestartNever RestartPolicy = "never"
+
+	// RestartOnFailure restarts after an unexpected exit, up to MaxRestarts (DefaultMaxRestarts if unset)
+	// RestartOnFailure 在意外退出后重启，最多 MaxRestarts 次（未设置时为 DefaultMaxRestarts）
+	RestartOnFailure RestartPolicy = "on-failure"
+
+	// RestartAlways restarts after every unexpected exit; MaxRestarts 0 means unlimited
+	// RestartAlways 每次意外退出后都重启；MaxRestarts 为 0 表示不限次数
+	RestartAlways RestartPolicy = "always"
+)
+
+// Restart policy defaults
+// 重启策略默认值
+const (
+	// DefaultMaxRestarts is the restart limit for on-failure when MaxRestarts is unset
+	// DefaultMaxRestarts 是 on-failure 策略未设置 MaxRestarts 时的重启上限
+	DefaultMaxRestarts = 3
+
+	// DefaultRestartBackoff is the delay before the first restart attempt
+	// DefaultRestartBackoff 是第一次重启尝试前的等待时间
+	DefaultRestartBackoff = 10 * time.Second
+
+	// MaxRestartBackoff caps the exponential backoff between restart attempts
+	// MaxRestartBackoff 是重启尝试之间指数退避的上限
+	MaxRestartBackoff = 5 * time.Minute
+
+	// RestartResetWindow is how long a process must stay up before its restart count is reset
+	// RestartResetWindow 是进程需持续运行多久才重置其重启计数
+	RestartResetWindow = 10 * time.Minute
+)
+
+// Additional process events emitted by restart policies
+// 重启策略产生的额外进程事件
+const (
+	// EventRestarted indicates the process was restarted by its restart policy
+	// EventRestarted 表示进程已被重启策略重启
+	EventRestarted ProcessEvent = "restarted"
+
+	// EventRestartFailed indicates a restart attempt failed or the restart limit was reached
+	// EventRestartFailed 表示重启尝试失败或已达到重启上限
+	EventRestartFailed ProcessEvent = "restart_failed"
+)
+
+// ParseRestartPolicy parses a restart policy string; empty means never.
+// ParseRestartPolicy 解析重启策略字符串；空字符串表示 never。
+func ParseRestartPolicy(value string) (RestartPolicy, error) {
+	switch RestartPolicy(strings.ToLower(strings.TrimSpace(value))) {
+	case "", RestartNever:
+		return RestartNever, nil
+	case RestartOnFailure:
+		return RestartOnFailure, nil
+	case RestartAlways:
+		return RestartAlways, nil
+	default:
+		return RestartNever, fmt.Errorf("invalid restart policy %q, expected never, on-failure or always", value)
+	}
+}
+
+// maxRestarts returns the effective restart limit; 0 means unlimited.
+// maxRestarts 返回生效的重启上限；0 表示不限次数。
+func (p *StartParams) maxRestarts() int {
+	if p.MaxRestarts > 0 {
+		return p.MaxRestarts
+	}
+	if p.RestartPolicy == RestartOnFailure {
+		return DefaultMaxRestarts
+	}
+	return 0
+}
+
+// restartBackoff returns the delay before the given restart attempt (1-based).
+// restartBackoff 返回第 attempt 次（从 1 开始）重启前的等待时间。
+func (p *StartParams) restartBackoff(attempt int) time.Duration {
+	backoff := p.RestartBackoff
+	if backoff <= 0 {
+		backoff = DefaultRestartBackoff
+	}
+	for i := 1; i < attempt && backoff < MaxRestartBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > MaxRestartBackoff {
+		backoff = MaxRestartBackoff
+	}
+	return backoff
+}
+
+// restartTracker holds the restart policy state of a named process across restarts.
+// restartTracker 保存命名进程跨重启的重启策略状态。
+type restartTracker struct {
+	mu sync.Mutex
+
+	// params are the start parameters used for restarts
+	// params 是重启时使用的启动参数
+	params *StartParams
+
+	// attempts is the number of consecutive restart attempts
+	// attempts 是连续重启尝试次数
+	attempts int
+
+	// pending indicates a restart is scheduled
+	// pending 表示已安排重启
+	pending bool
+
+	// stopped indicates the process was stopped on purpose; restarts are cancelled
+	// stopped 表示进程被主动停止，重启将被取消
+	stopped bool
+}
+
+// resetRestartTracker installs a fresh tracker for a user-initiated start.
+// resetRestartTracker 为用户发起的启动安装新的跟踪器。
+func (m *ProcessManager) resetRestartTracker(name string, params *StartParams) {
+	if params.RestartPolicy == "" || params.RestartPolicy == RestartNever {
+		m.restarts.Delete(name)
+		return
+	}
+	m.restarts.Store(name, &restartTracker{params: params})
+}
+
+// cancelRestarts marks the process as stopped on purpose so no restart is scheduled.
+// cancelRestarts 将进程标记为主动停止，从而不再安排重启。
+func (m *ProcessManager) cancelRestarts(name string) {
+	if value, ok := m.restarts.Load(name); ok {
+		tracker := value.(*restartTracker)
+		tracker.mu.Lock()
+		tracker.stopped = true
+		tracker.mu.Unlock()
+	}
+}
+
+// restartContext returns the context restarts run under.
+// restartContext 返回重启运行所用的上下文。
+func (m *ProcessManager) restartContext() context.Context {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if m.monitorCtx != nil {
+		return m.monitorCtx
+	}
+	return context.Background()
+}
+
+// handleUnexpectedExit applies the restart policy after a process exited without StopProcess.
+// uptime is how long the process ran; a long-running process resets the restart count.
+// handleUnexpectedExit 在进程非 StopProcess 退出后执行重启策略。
+// uptime 为进程运行时长；长时间运行的进程会重置重启计数。
+func (m *ProcessManager) handleUnexpectedExit(name string, uptime time.Duration) {
+	value, ok := m.restarts.Load(name)
+	if !ok {
+		return
+	}
+	tracker := value.(*restartTracker)
+
+	tracker.mu.Lock()
+	if uptime >= RestartResetWindow {
+		tracker.attempts = 0
+	}
+	tracker.mu.Unlock()
+
+	m.scheduleRestart(name, tracker)
+}
+
+// scheduleRestart schedules the next restart attempt with backoff, or reports that the limit was reached.
+// scheduleRestart 按退避安排下一次重启尝试，或上报已达到重启上限。
+func (m *ProcessManager) scheduleRestart(name string, tracker *restartTracker) {
+	tracker.mu.Lock()
+	if tracker.pending || tracker.stopped {
+		tracker.mu.Unlock()
+		return
+	}
+	if limit := tracker.params.maxRestarts(); limit > 0 && tracker.attempts >= limit {
+		attempts := tracker.attempts
+		tracker.mu.Unlock()
+		m.notifyRestartEvent(name, EventRestartFailed, attempts,
+			fmt.Sprintf("Restart limit reached after %d attempts / 已达到重启上限（%d 次）", attempts, attempts))
+		return
+	}
+	tracker.attempts++
+	attempt := tracker.attempts
+	delay := tracker.params.restartBackoff(attempt)
+	tracker.pending = true
+	tracker.mu.Unlock()
+
+	ctx := m.restartContext()
+	logger.WarnF(ctx, "Process %s exited unexpectedly, restart attempt %d in %v / 进程 %s 意外退出，%v 后进行第 %d 次重启",
+		name, attempt, delay, name, delay, attempt)
+	go m.runRestart(ctx, name, tracker, attempt, delay)
+}
+
+// runRestart waits for the backoff and restarts the process.
+// runRestart 等待退避时间后重启进程。
+func (m *ProcessManager) runRestart(ctx context.Context, name string, tracker *restartTracker, attempt int, delay time.Duration) {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		tracker.mu.Lock()
+		tracker.pending = false
+		tracker.mu.Unlock()
+		return
+	case <-timer.C:
+	}
+
+	tracker.mu.Lock()
+	tracker.pending = false
+	if tracker.stopped {
+		tracker.mu.Unlock()
+		return
+	}
+	params := tracker.params
+	tracker.mu.Unlock()
+
+	if err := m.startProcess(ctx, name, params); err != nil {
+		m.notifyRestartEvent(name, EventRestartFailed, attempt,
+			fmt.Sprintf("Restart attempt %d failed: %v / 第 %d 次重启失败：%v", attempt, err, attempt, err))
+		m.scheduleRestart(name, tracker)
+		return
+	}
+	m.notifyRestartEvent(name, EventRestarted, attempt, "")
+}
+
+// notifyRestartEvent records the restart count on the process and emits a restart event.
+// notifyRestartEvent 在进程上记录重启次数并发出重启事件。
+func (m *ProcessManager) notifyRestartEvent(name string, event ProcessEvent, attempt int, message string) {
+	value, ok := m.processes.Load(name)
+	if !ok {
+		return
+	}
+	proc := value.(*ManagedProcess)
+	proc.mu.Lock()
+	proc.RestartCount = attempt
+	if message != "" {
+		proc.LastError = message
+	}
+	proc.mu.Unlock()
+
+	proc.mu.RLock()
+	defer proc.mu.RUnlock()
+	m.notifyEvent(name, event, proc)
+}
@@ -0,0 +1,104 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package process
+
+import (
+	"testing"
+	"time"
+)
+
+// TestParseRestartPolicy tests restart policy parsing.
+// TestParseRestartPolicy 测试重启策略解析。
+func TestParseRestartPolicy(t *testing.T) {
+	cases := map[string]RestartPolicy{
+		"":           RestartNever,
+		"never":      RestartNever,
+		"On-Failure": RestartOnFailure,
+		" always ":   RestartAlways,
+	}
+	for input, want := range cases {
+		got, err := ParseRestartPolicy(input)
+		if err != nil || got != want {
+			t.Errorf("ParseRestartPolicy(%q) = %q, %v; want %q", input, got, err, want)
+		}
+	}
+	if _, err := ParseRestartPolicy("sometimes"); err == nil {
+		t.Error("Expected error for invalid restart policy")
+	}
+}
+
+// TestStartParamsRestartLimits tests effective restart limits and backoff.
+// TestStartParamsRestartLimits 测试生效的重启上限与退避。
+func TestStartParamsRestartLimits(t *testing.T) {
+	if got := (&StartParams{RestartPolicy: RestartOnFailure}).maxRestarts(); got != DefaultMaxRestarts {
+		t.Errorf("Expected on-failure default limit %d, got %d", DefaultMaxRestarts, got)
+	}
+	if got := (&StartParams{RestartPolicy: RestartAlways}).maxRestarts(); got != 0 {
+		t.Errorf("Expected always to be unlimited, got %d", got)
+	}
+
+	params := &StartParams{RestartBackoff: 2 * time.Minute}
+	expected := []time.Duration{2 * time.Minute, 4 * time.Minute, MaxRestartBackoff}
+	for i, want := range expected {
+		if got := params.restartBackoff(i + 1); got != want {
+			t.Errorf("Attempt %d: expected backoff %v, got %v", i+1, want, got)
+		}
+	}
+}
+
+// TestScheduleRestartLimitReached tests that exhausting the restart limit reports restart_failed.
+// TestScheduleRestartLimitReached 测试重启次数耗尽时上报 restart_failed。
+func TestScheduleRestartLimitReached(t *testing.T) {
+	m := NewProcessManager()
+	var events []ProcessEvent
+	m.SetEventHandler(func(name string, event ProcessEvent, info *ProcessInfo) {
+		events = append(events, event)
+	})
+
+	params := &StartParams{InstallDir: "/opt/seatunnel", RestartPolicy: RestartOnFailure, MaxRestarts: 2}
+	m.processes.Store("seatunnel", &ManagedProcess{Name: "seatunnel", Status: StatusStopped})
+	m.resetRestartTracker("seatunnel", params)
+	value, _ := m.restarts.Load("seatunnel")
+	tracker := value.(*restartTracker)
+	tracker.attempts = 2
+
+	m.handleUnexpectedExit("seatunnel", time.Second)
+
+	if len(events) != 1 || events[0] != EventRestartFailed {
+		t.Fatalf("Expected a single restart_failed event, got %v", events)
+	}
+	if tracker.pending {
+		t.Error("Expected no restart to be scheduled after the limit")
+	}
+}
+
+// TestCancelRestartsOnStop tests that an explicit stop prevents restarts.
+// TestCancelRestartsOnStop 测试主动停止会阻止重启。
+func TestCancelRestartsOnStop(t *testing.T) {
+	m := NewProcessManager()
+	m.resetRestartTracker("seatunnel", &StartParams{InstallDir: "/opt/seatunnel", RestartPolicy: RestartAlways})
+	m.cancelRestarts("seatunnel")
+
+	m.handleUnexpectedExit("seatunnel", time.Second)
+
+	value, _ := m.restarts.Load("seatunnel")
+	tracker := value.(*restartTracker)
+	if tracker.pending || tracker.attempts != 0 {
+		t.Errorf("Expected no restart after stop, pending=%v attempts=%d", tracker.pending, tracker.attempts)
+	}
+}
@@ -0,0 +1,174 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package process
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/seatunnel/seatunnelX/agent/internal/logger"
+	"github.com/seatunnel/seatunnelX/agent/internal/state"
+)
+
+// SetStateStore sets the store used to persist started processes across Agent restarts
+// SetStateStore 设置用于在 Agent 重启之间持久化已启动进程的存储
+func (m *ProcessManager) SetStateStore(store *state.Store) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.state = store
+}
+
+// stateStore returns the configured state store, or nil
+// stateStore 返回已配置的状态存储，未配置时返回 nil
+func (m *ProcessManager) stateStore() *state.Store {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.state
+}
+
+// persistProcess records a running process so it can be re-adopted after an Agent restart.
+// persistProcess 记录运行中的进程，以便 Agent 重启后重新接管。
+func (m *ProcessManager) persistProcess(name string, proc *ManagedProcess, params *StartParams) {
+	store := m.stateStore()
+	if store == nil {
+		return
+	}
+	proc.mu.RLock()
+	rec := &state.ProcessRecord{
+		Name:       name,
+		PID:        proc.PID,
+		InstallDir: proc.InstallDir,
+		Role:       proc.Role,
+		StartTime:  proc.StartTime,
+	}
+	proc.mu.RUnlock()
+	if params != nil {
+		if data, err := json.Marshal(params); err == nil {
+			rec.StartParams = data
+		}
+	}
+	if err := store.PutProcess(rec); err != nil {
+		logger.WarnF(context.Background(), "Failed to persist process %s: %v / 持久化进程 %s 失败：%v", name, err, name, err)
+	}
+}
+
+// forgetProcess removes the persisted record of a process
+// forgetProcess 删除进程的持久化记录
+func (m *ProcessManager) forgetProcess(name string) {
+	store := m.stateStore()
+	if store == nil {
+		return
+	}
+	if err := store.DeleteProcess(name); err != nil {
+		logger.WarnF(context.Background(), "Failed to remove persisted process %s: %v / 删除持久化进程 %s 失败：%v", name, err, name, err)
+	}
+}
+
+// RecoverProcesses re-adopts processes recorded in the state store by a previous Agent run.
+// The recorded PID is adopted when it is still alive and belongs to the recorded install directory;
+// otherwise the SeaTunnel process of the install directory is searched again. Records whose process
+// is gone are dropped. Restart policies are restored from the recorded start parameters.
+// RecoverProcesses 重新接管上一次 Agent 运行记录在状态存储中的进程。
+// 记录的 PID 仍存活且属于记录的安装目录时直接接管；否则重新查找该安装目录的 SeaTunnel 进程。
+// 进程已不存在的记录会被删除。重启策略从记录的启动参数中恢复。
+func (m *ProcessManager) RecoverProcesses(ctx context.Context) []*ProcessInfo {
+	store := m.stateStore()
+	if store == nil {
+		return nil
+	}
+
+	var recovered []*ProcessInfo
+	for _, rec := range store.Processes() {
+		pid := rec.PID
+		if !processMatchesInstallDir(pid, rec.InstallDir) {
+			pid = 0
+			if found, err := findSeaTunnelProcess(rec.InstallDir, rec.Role); err == nil && processMatchesInstallDir(found, rec.InstallDir) {
+				pid = found
+			}
+		}
+		if pid <= 0 {
+			logger.InfoF(ctx, "Persisted process %s (PID %d) is no longer running, dropping it / 持久化进程 %s（PID %d）已不在运行，删除记录",
+				rec.Name, rec.PID, rec.Name, rec.PID)
+			m.forgetProcess(rec.Name)
+			continue
+		}
+
+		var params *StartParams
+		if len(rec.StartParams) > 0 {
+			params = &StartParams{}
+			if err := json.Unmarshal(rec.StartParams, params); err != nil {
+				params = nil
+			}
+		}
+
+		startTime := rec.StartTime
+		if startTime.IsZero() {
+			startTime = time.Now()
+		}
+		proc := &ManagedProcess{
+			Name:       rec.Name,
+			PID:        pid,
+			Status:     StatusRunning,
+			StartTime:  startTime,
+			Uptime:     time.Since(startTime),
+			InstallDir: rec.InstallDir,
+			Role:       rec.Role,
+		}
+		m.processes.Store(rec.Name, proc)
+		if params != nil {
+			m.resetRestartTracker(rec.Name, params)
+		}
+		if pid != rec.PID {
+			m.persistProcess(rec.Name, proc, params)
+		}
+
+		logger.InfoF(ctx, "Re-adopted process %s (PID %d) / 已重新接管进程 %s（PID %d）", rec.Name, pid, rec.Name, pid)
+		go m.monitorProcess(rec.Name, proc)
+
+		proc.mu.RLock()
+		recovered = append(recovered, proc.info())
+		proc.mu.RUnlock()
+	}
+	return recovered
+}
+
+// processMatchesInstallDir reports whether pid is alive and runs from installDir.
+// PIDs are reused by the OS, so on Linux the command line is checked against the install directory;
+// other platforms only check liveness.
+// processMatchesInstallDir 判断 pid 是否存活且运行自 installDir。
+// 操作系统会复用 PID，因此在 Linux 上会校验命令行是否包含安装目录；其他平台仅检查存活。
+func processMatchesInstallDir(pid int, installDir string) bool {
+	if pid <= 0 || !isProcessAlive(pid) {
+		return false
+	}
+	if runtime.GOOS != "linux" || installDir == "" {
+		return true
+	}
+	data, err := os.ReadFile(fmt.Sprintf("/proc/%d/cmdline", pid))
+	if err != nil {
+		return false
+	}
+	cmdline := strings.ReplaceAll(string(data), "\x00", " ")
+	return strings.Contains(cmdline, filepath.Clean(installDir))
+}
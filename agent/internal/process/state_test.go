@@ -0,0 +1,71 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package process
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/seatunnel/seatunnelX/agent/internal/state"
+)
+
+// TestRecoverProcesses tests that live recorded processes are re-adopted and dead ones are dropped.
+// TestRecoverProcesses 测试存活的记录进程被重新接管，已退出的进程记录被删除。
+func TestRecoverProcesses(t *testing.T) {
+	store, err := state.NewStore(filepath.Join(t.TempDir(), "state.json"))
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+	// The test binary itself stands in for a running SeaTunnel process of its own directory
+	// 测试进程本身充当其所在目录下运行的 SeaTunnel 进程
+	installDir := filepath.Dir(os.Args[0])
+	_ = store.PutProcess(&state.ProcessRecord{
+		Name:        "seatunnel",
+		PID:         os.Getpid(),
+		InstallDir:  installDir,
+		StartTime:   time.Now().Add(-time.Minute),
+		StartParams: []byte(`{"restart_policy":"always"}`),
+	})
+	_ = store.PutProcess(&state.ProcessRecord{Name: "seatunnel-worker", PID: 1 << 30, InstallDir: t.TempDir(), Role: "worker"})
+
+	m := NewProcessManager()
+	m.SetStateStore(store)
+	recovered := m.RecoverProcesses(context.Background())
+
+	if len(recovered) != 1 || recovered[0].Name != "seatunnel" || recovered[0].PID != os.Getpid() {
+		t.Fatalf("Expected only the live process to be re-adopted, got %+v", recovered)
+	}
+	if info, err := m.GetStatus(context.Background(), "seatunnel"); err != nil || info.Status != StatusRunning {
+		t.Fatalf("Expected re-adopted process to be running, got %+v, %v", info, err)
+	}
+	if _, ok := m.restarts.Load("seatunnel"); !ok {
+		t.Error("Expected restart policy to be restored from persisted start params")
+	}
+	records := store.Processes()
+	if len(records) != 1 || records[0].Name != "seatunnel" {
+		t.Errorf("Expected dead process record to be dropped, got %+v", records)
+	}
+
+	m.RemoveProcess("seatunnel")
+	if len(store.Processes()) != 0 {
+		t.Error("Expected RemoveProcess to drop the persisted record")
+	}
+}
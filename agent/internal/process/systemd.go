@@ -0,0 +1,261 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package process
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/seatunnel/seatunnelX/agent/internal/logger"
+)
+
+// systemdUnitDir, systemdRuntimeDir and systemctlCommand are overridden in tests.
+// systemdUnitDir、systemdRuntimeDir 与 systemctlCommand 在测试中会被覆盖。
+var (
+	systemdUnitDir    = "/etc/systemd/system"
+	systemdRuntimeDir = "/run/systemd/system"
+	systemctlCommand  = "systemctl"
+)
+
+// SystemdUnitDir returns the directory SeaTunnel units are installed into
+// SystemdUnitDir 返回 SeaTunnel unit 的安装目录
+func SystemdUnitDir() string {
+	return systemdUnitDir
+}
+
+// SystemdAvailable reports whether the host is booted with systemd and systemctl can be used.
+// SystemdAvailable 判断主机是否由 systemd 引导且可以使用 systemctl。
+func SystemdAvailable() bool {
+	if runtime.GOOS != "linux" {
+		return false
+	}
+	if _, err := os.Stat(systemdRuntimeDir); err != nil {
+		return false
+	}
+	_, err := exec.LookPath(systemctlCommand)
+	return err == nil
+}
+
+// SystemdUnitName returns the unit name for a node role: seatunnel.service in hybrid mode,
+// seatunnel-<role>.service for separated master and worker nodes.
+// SystemdUnitName 返回节点角色对应的 unit 名称：混合模式为 seatunnel.service，
+// 分离模式的 master 与 worker 节点为 seatunnel-<role>.service。
+func SystemdUnitName(role string) string {
+	if isHybridRole(role) {
+		return "seatunnel.service"
+	}
+	return fmt.Sprintf("seatunnel-%s.service", role)
+}
+
+// RenderSystemdUnit renders a unit that runs the SeaTunnel cluster script in the foreground,
+// so systemd supervises the JVM directly and starts it again after a reboot.
+// RenderSystemdUnit 渲染在前台运行 SeaTunnel 集群脚本的 unit，
+// 由 systemd 直接托管 JVM，并在重启后再次拉起。
+func RenderSystemdUnit(installDir, role string, env map[string]string) string {
+	installDir = filepath.Clean(installDir)
+	execStart := getStartScript(installDir)
+	if !isHybridRole(role) {
+		execStart += " -r " + role
+	}
+	keys := make([]string, 0, len(env))
+	for k := range env {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	b.WriteString("# Generated by SeaTunnelX Agent, do not edit\n")
+	b.WriteString("[Unit]\n")
+	fmt.Fprintf(&b, "Description=Apache SeaTunnel %s\n", describeRole(role))
+	b.WriteString("After=network-online.target\n")
+	b.WriteString("Wants=network-online.target\n\n")
+	b.WriteString("[Service]\n")
+	b.WriteString("Type=simple\n")
+	fmt.Fprintf(&b, "WorkingDirectory=%s\n", installDir)
+	fmt.Fprintf(&b, "Environment=%s\n", strconv.Quote("SEATUNNEL_HOME="+installDir))
+	for _, k := range keys {
+		fmt.Fprintf(&b, "Environment=%s\n", strconv.Quote(k+"="+env[k]))
+	}
+	fmt.Fprintf(&b, "ExecStart=/bin/bash %s\n", execStart)
+	// The JVM exits with 143 on SIGTERM, which is a regular stop
+	// JVM 收到 SIGTERM 后以 143 退出，属于正常停止
+	b.WriteString("SuccessExitStatus=143\n")
+	fmt.Fprintf(&b, "TimeoutStopSec=%d\n", int(DefaultGracefulTimeout.Seconds()))
+	b.WriteString("Restart=on-failure\n")
+	b.WriteString("RestartSec=10\n")
+	b.WriteString("LimitNOFILE=65536\n\n")
+	b.WriteString("[Install]\n")
+	b.WriteString("WantedBy=multi-user.target\n")
+	return b.String()
+}
+
+// FindSystemdUnit returns the installed unit for the role when it runs the given installation,
+// or "" when SeaTunnel is not managed by systemd on this host.
+// FindSystemdUnit 在该角色的 unit 已安装且运行指定安装目录时返回其名称，
+// 本机未通过 systemd 管理 SeaTunnel 时返回空字符串。
+func FindSystemdUnit(installDir, role string) string {
+	if installDir == "" || !SystemdAvailable() {
+		return ""
+	}
+	name := SystemdUnitName(role)
+	content, err := os.ReadFile(filepath.Join(systemdUnitDir, name))
+	if err != nil {
+		return ""
+	}
+	if !strings.Contains(string(content), "WorkingDirectory="+filepath.Clean(installDir)+"\n") {
+		return ""
+	}
+	return name
+}
+
+// Systemctl runs systemctl with the given arguments and returns its combined output on failure.
+// Systemctl 以给定参数执行 systemctl，失败时返回其合并输出。
+func Systemctl(ctx context.Context, args ...string) error {
+	out, err := exec.CommandContext(ctx, systemctlCommand, args...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("systemctl %s: %w: %s", strings.Join(args, " "), err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// systemdMainPID returns the main PID systemd tracks for the unit, or 0 when it is not running.
+// systemdMainPID 返回 systemd 为该 unit 跟踪的主进程 PID，未运行时返回 0。
+func systemdMainPID(ctx context.Context, unit string) int {
+	out, err := exec.CommandContext(ctx, systemctlCommand, "show", "--property=MainPID", "--value", unit).Output()
+	if err != nil {
+		return 0
+	}
+	pid, _ := strconv.Atoi(strings.TrimSpace(string(out)))
+	return pid
+}
+
+// isHybridRole reports whether the role runs master and worker in one process.
+// isHybridRole 判断该角色是否在同一进程中运行 master 与 worker。
+func isHybridRole(role string) bool {
+	return role == "" || role == "hybrid" || role == "master/worker"
+}
+
+// describeRole returns a human readable role for unit descriptions.
+// describeRole 返回用于 unit 描述的可读角色名称。
+func describeRole(role string) string {
+	if isHybridRole(role) {
+		return "(hybrid)"
+	}
+	return "(" + role + ")"
+}
+
+// startSystemdUnit starts the process through its systemd unit and adopts the resulting JVM.
+// startSystemdUnit 通过 systemd unit 启动进程并接管生成的 JVM。
+func (m *ProcessManager) startSystemdUnit(ctx context.Context, name string, proc *ManagedProcess, params *StartParams, unit string) error {
+	proc.mu.Lock()
+	proc.SystemdUnit = unit
+	proc.StartTime = time.Now()
+	proc.mu.Unlock()
+
+	logger.InfoF(ctx, "Starting SeaTunnel through systemd unit %s / 通过 systemd unit %s 启动 SeaTunnel", unit, unit)
+	if err := Systemctl(ctx, "start", unit); err != nil {
+		proc.mu.Lock()
+		proc.Status = StatusError
+		proc.LastError = fmt.Sprintf("Failed to start unit %s: %v / 启动 unit %s 失败：%v", unit, err, unit, err)
+		proc.mu.Unlock()
+		return fmt.Errorf("%w: %v", ErrStartFailed, err)
+	}
+
+	// Wait for the JVM to come up / 等待 JVM 启动
+	if err := sleepWithContext(ctx, 3*time.Second); err != nil {
+		return err
+	}
+	pid, err := findSeaTunnelProcess(params.InstallDir, params.Role)
+	if err != nil || pid <= 0 {
+		pid = systemdMainPID(ctx, unit)
+	}
+	if pid <= 0 {
+		proc.mu.Lock()
+		proc.Status = StatusError
+		proc.LastError = fmt.Sprintf("SeaTunnel process not found after starting %s, see journalctl -u %s / 启动 %s 后未找到 SeaTunnel 进程，请查看 journalctl -u %s", unit, unit, unit, unit)
+		proc.mu.Unlock()
+		return fmt.Errorf("%w: SeaTunnel process not found after starting %s", ErrStartFailed, unit)
+	}
+
+	proc.mu.Lock()
+	proc.PID = pid
+	proc.Status = StatusRunning
+	proc.LastError = ""
+	proc.mu.Unlock()
+
+	m.persistProcess(name, proc, params)
+	m.notifyEvent(name, EventStarted, proc)
+	go m.monitorProcess(name, proc)
+	return nil
+}
+
+// stopSystemdUnit stops the process through its systemd unit; it returns false when the process
+// is not managed by systemd or systemctl failed, so the caller falls back to signals.
+// stopSystemdUnit 通过 systemd unit 停止进程；进程不由 systemd 管理或 systemctl 失败时返回 false，
+// 由调用方回退到信号方式。
+func (m *ProcessManager) stopSystemdUnit(ctx context.Context, name string, params *StopParams, timeout time.Duration) bool {
+	var installDir, role, unit string
+	if params != nil {
+		installDir, role = params.InstallDir, params.Role
+	}
+	value, tracked := m.processes.Load(name)
+	if tracked {
+		proc := value.(*ManagedProcess)
+		proc.mu.RLock()
+		unit = proc.SystemdUnit
+		if installDir == "" {
+			installDir, role = proc.InstallDir, proc.Role
+		}
+		proc.mu.RUnlock()
+	}
+	if unit == "" {
+		unit = FindSystemdUnit(installDir, role)
+	}
+	if unit == "" {
+		return false
+	}
+
+	// Give systemd the graceful timeout plus its own SIGKILL margin
+	// 为 systemd 预留优雅超时以及其自身发送 SIGKILL 的余量
+	stopCtx, cancel := context.WithTimeout(ctx, timeout+10*time.Second)
+	defer cancel()
+	if err := Systemctl(stopCtx, "stop", unit); err != nil {
+		logger.WarnF(ctx, "Failed to stop unit %s, falling back to signals: %v / 停止 unit %s 失败，回退到信号方式：%v", unit, err, unit, err)
+		return false
+	}
+
+	if tracked {
+		proc := value.(*ManagedProcess)
+		proc.mu.Lock()
+		proc.Status = StatusStopped
+		proc.PID = 0
+		proc.mu.Unlock()
+		m.notifyEvent(name, EventStopped, proc)
+	}
+	m.forgetProcess(name)
+	logger.InfoF(ctx, "Stopped systemd unit %s / 已停止 systemd unit %s", unit, unit)
+	return true
+}
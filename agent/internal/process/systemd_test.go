@@ -0,0 +1,122 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package process
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// fakeSystemd points the package at a temporary unit dir and a systemctl stub that logs its arguments.
+// fakeSystemd 将包指向临时 unit 目录以及记录参数的 systemctl 桩程序。
+func fakeSystemd(t *testing.T) (unitDir, logFile string) {
+	t.Helper()
+	if runtime.GOOS != "linux" {
+		t.Skip("systemd is only supported on Linux")
+	}
+	dir := t.TempDir()
+	unitDir = filepath.Join(dir, "units")
+	runtimeDir := filepath.Join(dir, "run")
+	logFile = filepath.Join(dir, "systemctl.log")
+	for _, d := range []string{unitDir, runtimeDir} {
+		if err := os.MkdirAll(d, 0o755); err != nil {
+			t.Fatalf("mkdir %s: %v", d, err)
+		}
+	}
+	stub := filepath.Join(dir, "systemctl")
+	script := "#!/bin/sh\necho \"$@\" >> " + logFile + "\n"
+	if err := os.WriteFile(stub, []byte(script), 0o755); err != nil {
+		t.Fatalf("write systemctl stub: %v", err)
+	}
+
+	oldUnitDir, oldRuntimeDir, oldSystemctl := systemdUnitDir, systemdRuntimeDir, systemctlCommand
+	systemdUnitDir, systemdRuntimeDir, systemctlCommand = unitDir, runtimeDir, stub
+	t.Cleanup(func() { systemdUnitDir, systemdRuntimeDir, systemctlCommand = oldUnitDir, oldRuntimeDir, oldSystemctl })
+	return unitDir, logFile
+}
+
+func TestRenderSystemdUnit(t *testing.T) {
+	hybrid := RenderSystemdUnit("/opt/seatunnel/", "master/worker", map[string]string{"JAVA_HOME": "/usr/lib/jvm/java-11"})
+	for _, want := range []string{
+		"WorkingDirectory=/opt/seatunnel\n",
+		"Environment=\"SEATUNNEL_HOME=/opt/seatunnel\"\n",
+		"Environment=\"JAVA_HOME=/usr/lib/jvm/java-11\"\n",
+		"ExecStart=/bin/bash " + getStartScript("/opt/seatunnel") + "\n",
+		"WantedBy=multi-user.target",
+	} {
+		if !strings.Contains(hybrid, want) {
+			t.Fatalf("hybrid unit should contain %q, got:\n%s", want, hybrid)
+		}
+	}
+	if strings.Contains(hybrid, " -d") {
+		t.Fatalf("unit must run the script in the foreground, got:\n%s", hybrid)
+	}
+
+	worker := RenderSystemdUnit("/opt/seatunnel", "worker", nil)
+	if !strings.Contains(worker, "seatunnel-cluster.sh -r worker\n") {
+		t.Fatalf("worker unit should pass the role, got:\n%s", worker)
+	}
+	if SystemdUnitName("worker") != "seatunnel-worker.service" || SystemdUnitName("") != "seatunnel.service" {
+		t.Fatalf("unexpected unit names %q, %q", SystemdUnitName("worker"), SystemdUnitName(""))
+	}
+}
+
+func TestFindSystemdUnit(t *testing.T) {
+	unitDir, _ := fakeSystemd(t)
+	installDir := "/opt/seatunnel-2.3.12"
+	if err := os.WriteFile(filepath.Join(unitDir, "seatunnel-master.service"), []byte(RenderSystemdUnit(installDir, "master", nil)), 0o644); err != nil {
+		t.Fatalf("write unit: %v", err)
+	}
+
+	if got := FindSystemdUnit(installDir, "master"); got != "seatunnel-master.service" {
+		t.Fatalf("expected master unit, got %q", got)
+	}
+	if got := FindSystemdUnit(installDir, "worker"); got != "" {
+		t.Fatalf("worker has no unit, got %q", got)
+	}
+	if got := FindSystemdUnit("/opt/seatunnel-other", "master"); got != "" {
+		t.Fatalf("unit of another installation must not be used, got %q", got)
+	}
+}
+
+func TestStopProcessUsesSystemdUnit(t *testing.T) {
+	_, logFile := fakeSystemd(t)
+	m := NewProcessManager()
+	m.processes.Store("seatunnel", &ManagedProcess{
+		Name:        "seatunnel",
+		Status:      StatusRunning,
+		InstallDir:  "/opt/seatunnel",
+		SystemdUnit: "seatunnel.service",
+	})
+
+	if err := m.StopProcess(context.Background(), "seatunnel", &StopParams{Graceful: true}); err != nil {
+		t.Fatalf("StopProcess failed: %v", err)
+	}
+	calls, err := os.ReadFile(logFile)
+	if err != nil || strings.TrimSpace(string(calls)) != "stop seatunnel.service" {
+		t.Fatalf("expected systemctl stop seatunnel.service, got %q (%v)", calls, err)
+	}
+	info, err := m.GetStatus(context.Background(), "seatunnel")
+	if err != nil || info.Status != StatusStopped {
+		t.Fatalf("expected stopped status, got %+v (%v)", info, err)
+	}
+}
@@ -0,0 +1,94 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package process
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/seatunnel/seatunnelX/agent/internal/logger"
+)
+
+// findSeaTunnelPIDsWindows lists SeaTunnel server PIDs on Windows via CIM.
+// wmic is deprecated and missing on recent Windows releases, so PowerShell is used instead.
+// findSeaTunnelPIDsWindows 通过 CIM 列出 Windows 上的 SeaTunnel 服务 PID。
+// wmic 已被弃用且在较新的 Windows 版本中缺失，因此改用 PowerShell。
+func findSeaTunnelPIDsWindows(ctx context.Context, appMain, installDir, role string, isHybridMode bool) []int {
+	script := fmt.Sprintf("Get-CimInstance Win32_Process -Filter \"CommandLine like '%%%s%%'\" | ForEach-Object { \"$($_.ProcessId)`t$($_.CommandLine)\" }", appMain)
+	output, err := exec.CommandContext(ctx, "powershell", "-NoProfile", "-NonInteractive", "-Command", script).Output()
+	if err != nil {
+		return nil
+	}
+	return parseWindowsProcessList(string(output), installDir, role, isHybridMode)
+}
+
+// parseWindowsProcessList parses "PID<TAB>CommandLine" lines and keeps the processes of installDir and role
+// parseWindowsProcessList 解析 "PID<TAB>命令行" 格式的行，并保留属于 installDir 和 role 的进程
+func parseWindowsProcessList(output, installDir, role string, isHybridMode bool) []int {
+	installDir = strings.ToLower(strings.TrimRight(installDir, "\\/"))
+	var pids []int
+	for _, line := range strings.Split(output, "\n") {
+		parts := strings.SplitN(strings.TrimSpace(line), "\t", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		pid, err := strconv.Atoi(strings.TrimSpace(parts[0]))
+		if err != nil || pid <= 0 {
+			continue
+		}
+		commandLine := strings.ToLower(parts[1])
+		if strings.Contains(commandLine, "powershell") {
+			continue
+		}
+		if installDir != "" && !strings.Contains(commandLine, installDir) {
+			continue
+		}
+		if isHybridMode {
+			if strings.Contains(commandLine, "-r master") || strings.Contains(commandLine, "-r worker") {
+				continue
+			}
+		} else if !strings.Contains(commandLine, "-r "+strings.ToLower(role)) {
+			continue
+		}
+		pids = append(pids, pid)
+	}
+	return pids
+}
+
+// runWindowsStopScript runs stop-seatunnel-cluster.cmd so the member leaves the cluster gracefully
+// runWindowsStopScript 运行 stop-seatunnel-cluster.cmd，使成员优雅地离开集群
+func runWindowsStopScript(ctx context.Context, installDir, role string, isHybridMode bool) {
+	stopScript := getStopScript(installDir)
+	if _, err := os.Stat(stopScript); err != nil {
+		return
+	}
+	args := []string{"/c", stopScript}
+	if !isHybridMode {
+		args = append(args, "-r", role)
+	}
+	cmd := exec.CommandContext(ctx, "cmd", args...)
+	cmd.Dir = installDir
+	cmd.Env = append(os.Environ(), fmt.Sprintf("SEATUNNEL_HOME=%s", installDir))
+	if output, err := cmd.CombinedOutput(); err != nil {
+		logger.WarnF(ctx, "Stop script failed: %v, output: %s / 停止脚本执行失败：%v，输出：%s", err, output, err, output)
+	}
+}
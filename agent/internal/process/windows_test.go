@@ -0,0 +1,43 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package process
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestParseWindowsProcessList tests filtering of CIM process listings by install dir and role.
+// TestParseWindowsProcessList 测试按安装目录和角色过滤 CIM 进程列表。
+func TestParseWindowsProcessList(t *testing.T) {
+	output := "101\tjava.exe -cp C:\\SeaTunnel\\lib\\* org.apache.seatunnel.core.starter.seatunnel.SeaTunnelServer\r\n" +
+		"102\tjava.exe -cp C:\\SeaTunnel\\lib\\* org.apache.seatunnel.core.starter.seatunnel.SeaTunnelServer -r worker\r\n" +
+		"103\tjava.exe -cp D:\\Other\\lib\\* org.apache.seatunnel.core.starter.seatunnel.SeaTunnelServer\r\n" +
+		"104\tpowershell -Command Get-CimInstance SeaTunnelServer\r\n" +
+		"garbage line\r\n"
+
+	if got := parseWindowsProcessList(output, "C:\\SeaTunnel\\", "", true); !reflect.DeepEqual(got, []int{101}) {
+		t.Errorf("Hybrid: expected [101], got %v", got)
+	}
+	if got := parseWindowsProcessList(output, "c:\\seatunnel", "worker", false); !reflect.DeepEqual(got, []int{102}) {
+		t.Errorf("Worker: expected [102], got %v", got)
+	}
+	if got := parseWindowsProcessList(output, "", "", true); !reflect.DeepEqual(got, []int{101, 103}) {
+		t.Errorf("Any dir: expected [101 103], got %v", got)
+	}
+}
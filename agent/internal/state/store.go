@@ -0,0 +1,389 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package state persists Agent runtime state across Agent restarts.
+// state 包在 Agent 重启之间持久化 Agent 运行时状态。
+//
+// The store keeps the processes started by the ProcessManager (so they can be re-adopted),
+// the installations in progress (so they can be resumed or reported as interrupted)
+// and the log disk quotas of installations (so the cleanup job keeps enforcing them).
+// 存储记录 ProcessManager 启动的进程（以便重新接管）、正在进行的安装（以便恢复或上报为中断）
+// 以及各安装的日志磁盘配额（以便清理任务持续执行）。
+package state
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// DefaultStatePath is where the Agent state is persisted
+// DefaultStatePath 是 Agent 状态的持久化路径
+const DefaultStatePath = "/var/lib/seatunnelx-agent/state.json"
+
+// ProcessRecord is the persisted state of a process started by the Agent
+// ProcessRecord 是 Agent 启动的进程的持久化状态
+type ProcessRecord struct {
+	// Name is the managed process name
+	// Name 是托管进程名称
+	Name string `json:"name"`
+
+	// PID is the last known PID of the SeaTunnel Java process
+	// PID 是 SeaTunnel Java 进程最后已知的 PID
+	PID int `json:"pid"`
+
+	// InstallDir is the SeaTunnel installation directory
+	// InstallDir 是 SeaTunnel 安装目录
+	InstallDir string `json:"install_dir"`
+
+	// Role is the node role the process was started with
+	// Role 是启动进程时使用的节点角色
+	Role string `json:"role,omitempty"`
+
+	// StartTime is when the process was started
+	// StartTime 是进程启动的时间
+	StartTime time.Time `json:"start_time"`
+
+	// StartParams are the encoded start parameters, used to restore restart policies
+	// StartParams 是编码后的启动参数，用于恢复重启策略
+	StartParams json.RawMessage `json:"start_params,omitempty"`
+}
+
+// InstallRecord is the persisted progress of an installation
+// InstallRecord 是安装进度的持久化记录
+type InstallRecord struct {
+	// InstallDir identifies the installation; only one installation runs per directory
+	// InstallDir 标识安装；每个目录同一时间只运行一个安装
+	InstallDir string `json:"install_dir"`
+
+	// CommandID is the INSTALL command that started the installation
+	// CommandID 是发起安装的 INSTALL 命令 ID
+	CommandID string `json:"command_id,omitempty"`
+
+	// Version is the SeaTunnel version being installed
+	// Version 是正在安装的 SeaTunnel 版本
+	Version string `json:"version"`
+
+	// CurrentStep is the step that was running when the record was last written
+	// CurrentStep 是最后一次写入记录时正在执行的步骤
+	CurrentStep string `json:"current_step"`
+
+	// CompletedSteps are the steps that finished successfully
+	// CompletedSteps 是已成功完成的步骤
+	CompletedSteps []string `json:"completed_steps,omitempty"`
+
+	// StartedAt is when the installation started
+	// StartedAt 是安装开始的时间
+	StartedAt time.Time `json:"started_at"`
+
+	// UpdatedAt is when the record was last written
+	// UpdatedAt 是记录最后写入的时间
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// LogPolicyRecord is the log disk quota of an installation
+// LogPolicyRecord 是安装目录的日志磁盘配额
+type LogPolicyRecord struct {
+	// InstallDir identifies the installation whose logs directory is managed
+	// InstallDir 标识日志目录受管理的安装
+	InstallDir string `json:"install_dir"`
+
+	// QuotaBytes is the maximum total size of the logs directory
+	// QuotaBytes 是日志目录允许的最大总大小
+	QuotaBytes int64 `json:"quota_bytes"`
+
+	// UpdatedAt is when the record was last written
+	// UpdatedAt 是记录最后写入的时间
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// HasCompleted reports whether the step finished successfully
+// HasCompleted 判断步骤是否已成功完成
+func (r *InstallRecord) HasCompleted(step string) bool {
+	for _, s := range r.CompletedSteps {
+		if s == step {
+			return true
+		}
+	}
+	return false
+}
+
+// snapshot is the on-disk layout of the store
+// snapshot 是存储在磁盘上的结构
+type snapshot struct {
+	Processes   []*ProcessRecord   `json:"processes"`
+	Installs    []*InstallRecord   `json:"installs"`
+	LogPolicies []*LogPolicyRecord `json:"log_policies,omitempty"`
+}
+
+// Store is a JSON file backed store of Agent runtime state.
+// Every mutation is written to disk immediately so the state survives crashes.
+// Store 是基于 JSON 文件的 Agent 运行时状态存储。
+// 每次修改都会立即写盘，以便在崩溃后保留状态。
+type Store struct {
+	path string
+
+	mu          sync.Mutex
+	processes   map[string]*ProcessRecord
+	installs    map[string]*InstallRecord
+	logPolicies map[string]*LogPolicyRecord
+}
+
+// NewStore creates a store persisted at path and loads existing records.
+// An empty path keeps state in memory only. The returned store is usable even when loading fails.
+// NewStore 创建持久化到 path 的存储并加载已有记录。
+// path 为空时仅保存在内存中。即使加载失败，返回的存储仍可使用。
+func NewStore(path string) (*Store, error) {
+	s := &Store{
+		path:        path,
+		processes:   make(map[string]*ProcessRecord),
+		installs:    make(map[string]*InstallRecord),
+		logPolicies: make(map[string]*LogPolicyRecord),
+	}
+	if path == "" {
+		return s, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return s, nil
+		}
+		return s, fmt.Errorf("failed to read agent state: %w", err)
+	}
+	var snap snapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return s, fmt.Errorf("failed to parse agent state: %w", err)
+	}
+	for _, rec := range snap.Processes {
+		if rec != nil && rec.Name != "" {
+			s.processes[rec.Name] = rec
+		}
+	}
+	for _, rec := range snap.Installs {
+		if rec != nil && rec.InstallDir != "" {
+			s.installs[rec.InstallDir] = rec
+		}
+	}
+	for _, rec := range snap.LogPolicies {
+		if rec != nil && rec.InstallDir != "" {
+			s.logPolicies[rec.InstallDir] = rec
+		}
+	}
+	return s, nil
+}
+
+// PutProcess records a started process
+// PutProcess 记录已启动的进程
+func (s *Store) PutProcess(rec *ProcessRecord) error {
+	if s == nil || rec == nil || rec.Name == "" {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	copied := *rec
+	s.processes[rec.Name] = &copied
+	return s.saveLocked()
+}
+
+// DeleteProcess forgets a process
+// DeleteProcess 删除进程记录
+func (s *Store) DeleteProcess(name string) error {
+	if s == nil {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.processes[name]; !ok {
+		return nil
+	}
+	delete(s.processes, name)
+	return s.saveLocked()
+}
+
+// Processes returns all process records sorted by name
+// Processes 返回按名称排序的所有进程记录
+func (s *Store) Processes() []*ProcessRecord {
+	if s == nil {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	records := make([]*ProcessRecord, 0, len(s.processes))
+	for _, rec := range s.processes {
+		copied := *rec
+		records = append(records, &copied)
+	}
+	sort.Slice(records, func(i, j int) bool { return records[i].Name < records[j].Name })
+	return records
+}
+
+// PutInstall records installation progress
+// PutInstall 记录安装进度
+func (s *Store) PutInstall(rec *InstallRecord) error {
+	if s == nil || rec == nil || rec.InstallDir == "" {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	copied := *rec
+	copied.CompletedSteps = append([]string(nil), rec.CompletedSteps...)
+	copied.UpdatedAt = time.Now()
+	s.installs[rec.InstallDir] = &copied
+	return s.saveLocked()
+}
+
+// GetInstall returns the installation record for an install directory
+// GetInstall 返回安装目录对应的安装记录
+func (s *Store) GetInstall(installDir string) (*InstallRecord, bool) {
+	if s == nil {
+		return nil, false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	rec, ok := s.installs[installDir]
+	if !ok {
+		return nil, false
+	}
+	copied := *rec
+	copied.CompletedSteps = append([]string(nil), rec.CompletedSteps...)
+	return &copied, true
+}
+
+// DeleteInstall forgets an installation
+// DeleteInstall 删除安装记录
+func (s *Store) DeleteInstall(installDir string) error {
+	if s == nil {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.installs[installDir]; !ok {
+		return nil
+	}
+	delete(s.installs, installDir)
+	return s.saveLocked()
+}
+
+// Installs returns all installation records sorted by start time
+// Installs 返回按开始时间排序的所有安装记录
+func (s *Store) Installs() []*InstallRecord {
+	if s == nil {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	records := make([]*InstallRecord, 0, len(s.installs))
+	for _, rec := range s.installs {
+		copied := *rec
+		copied.CompletedSteps = append([]string(nil), rec.CompletedSteps...)
+		records = append(records, &copied)
+	}
+	sort.Slice(records, func(i, j int) bool { return records[i].StartedAt.Before(records[j].StartedAt) })
+	return records
+}
+
+// PutLogPolicy records the log disk quota of an installation
+// PutLogPolicy 记录安装目录的日志磁盘配额
+func (s *Store) PutLogPolicy(rec *LogPolicyRecord) error {
+	if s == nil || rec == nil || rec.InstallDir == "" {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	copied := *rec
+	copied.UpdatedAt = time.Now()
+	s.logPolicies[rec.InstallDir] = &copied
+	return s.saveLocked()
+}
+
+// DeleteLogPolicy forgets the log disk quota of an installation
+// DeleteLogPolicy 删除安装目录的日志磁盘配额
+func (s *Store) DeleteLogPolicy(installDir string) error {
+	if s == nil {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.logPolicies[installDir]; !ok {
+		return nil
+	}
+	delete(s.logPolicies, installDir)
+	return s.saveLocked()
+}
+
+// LogPolicies returns all log disk quotas sorted by install directory
+// LogPolicies 返回按安装目录排序的所有日志磁盘配额
+func (s *Store) LogPolicies() []*LogPolicyRecord {
+	if s == nil {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	records := make([]*LogPolicyRecord, 0, len(s.logPolicies))
+	for _, rec := range s.logPolicies {
+		copied := *rec
+		records = append(records, &copied)
+	}
+	sort.Slice(records, func(i, j int) bool { return records[i].InstallDir < records[j].InstallDir })
+	return records
+}
+
+// saveLocked writes the store atomically via a temp file and rename
+// saveLocked 通过临时文件加重命名的方式原子写入存储
+func (s *Store) saveLocked() error {
+	if s.path == "" {
+		return nil
+	}
+	snap := snapshot{
+		Processes: make([]*ProcessRecord, 0, len(s.processes)),
+		Installs:  make([]*InstallRecord, 0, len(s.installs)),
+	}
+	for _, rec := range s.logPolicies {
+		snap.LogPolicies = append(snap.LogPolicies, rec)
+	}
+	for _, rec := range s.processes {
+		snap.Processes = append(snap.Processes, rec)
+	}
+	for _, rec := range s.installs {
+		snap.Installs = append(snap.Installs, rec)
+	}
+	sort.Slice(snap.Processes, func(i, j int) bool { return snap.Processes[i].Name < snap.Processes[j].Name })
+	sort.Slice(snap.Installs, func(i, j int) bool { return snap.Installs[i].InstallDir < snap.Installs[j].InstallDir })
+	sort.Slice(snap.LogPolicies, func(i, j int) bool { return snap.LogPolicies[i].InstallDir < snap.LogPolicies[j].InstallDir })
+
+	data, err := json.MarshalIndent(snap, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode agent state: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create agent state dir: %w", err)
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write agent state: %w", err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return fmt.Errorf("failed to replace agent state: %w", err)
+	}
+	return nil
+}
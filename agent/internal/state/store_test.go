@@ -0,0 +1,105 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package state
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+// TestStoreRoundTrip tests that process and install records survive a reload.
+// TestStoreRoundTrip 测试进程和安装记录在重新加载后仍然保留。
+func TestStoreRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	store, err := NewStore(path)
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+	if err := store.PutProcess(&ProcessRecord{Name: "seatunnel", PID: 42, InstallDir: "/opt/seatunnel"}); err != nil {
+		t.Fatalf("PutProcess failed: %v", err)
+	}
+	if err := store.PutInstall(&InstallRecord{InstallDir: "/opt/seatunnel", CommandID: "cmd-1", Version: "2.3.12", CompletedSteps: []string{"download"}}); err != nil {
+		t.Fatalf("PutInstall failed: %v", err)
+	}
+
+	reloaded, err := NewStore(path)
+	if err != nil {
+		t.Fatalf("Reload failed: %v", err)
+	}
+	processes := reloaded.Processes()
+	if len(processes) != 1 || processes[0].PID != 42 {
+		t.Fatalf("Expected persisted process with PID 42, got %+v", processes)
+	}
+	rec, ok := reloaded.GetInstall("/opt/seatunnel")
+	if !ok || rec.CommandID != "cmd-1" || !rec.HasCompleted("download") || rec.HasCompleted("extract") {
+		t.Fatalf("Unexpected install record: %+v", rec)
+	}
+
+	if err := reloaded.DeleteProcess("seatunnel"); err != nil {
+		t.Fatalf("DeleteProcess failed: %v", err)
+	}
+	if err := reloaded.DeleteInstall("/opt/seatunnel"); err != nil {
+		t.Fatalf("DeleteInstall failed: %v", err)
+	}
+	again, _ := NewStore(path)
+	if len(again.Processes()) != 0 || len(again.Installs()) != 0 {
+		t.Fatal("Expected deleted records to stay deleted after reload")
+	}
+}
+
+// TestStoreLogPolicies tests that log quotas survive a reload and can be replaced and deleted.
+// TestStoreLogPolicies 测试日志配额在重新加载后保留，并可被替换和删除。
+func TestStoreLogPolicies(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	store, _ := NewStore(path)
+	if err := store.PutLogPolicy(&LogPolicyRecord{InstallDir: "/opt/b", QuotaBytes: 1 << 30}); err != nil {
+		t.Fatalf("PutLogPolicy failed: %v", err)
+	}
+	if err := store.PutLogPolicy(&LogPolicyRecord{InstallDir: "/opt/a", QuotaBytes: 1 << 20}); err != nil {
+		t.Fatalf("PutLogPolicy failed: %v", err)
+	}
+	if err := store.PutLogPolicy(&LogPolicyRecord{InstallDir: "/opt/a", QuotaBytes: 2 << 20}); err != nil {
+		t.Fatalf("PutLogPolicy failed: %v", err)
+	}
+
+	reloaded, _ := NewStore(path)
+	policies := reloaded.LogPolicies()
+	if len(policies) != 2 || policies[0].InstallDir != "/opt/a" || policies[0].QuotaBytes != 2<<20 {
+		t.Fatalf("Unexpected log policies: %+v", policies)
+	}
+
+	if err := reloaded.DeleteLogPolicy("/opt/a"); err != nil {
+		t.Fatalf("DeleteLogPolicy failed: %v", err)
+	}
+	again, _ := NewStore(path)
+	if policies := again.LogPolicies(); len(policies) != 1 || policies[0].InstallDir != "/opt/b" {
+		t.Fatalf("Expected only /opt/b to remain, got %+v", policies)
+	}
+}
+
+// TestNilStore tests that a nil store is a no-op.
+// TestNilStore 测试 nil 存储为空操作。
+func TestNilStore(t *testing.T) {
+	var store *Store
+	if err := store.PutProcess(&ProcessRecord{Name: "seatunnel"}); err != nil {
+		t.Fatalf("Expected nil store to ignore writes, got %v", err)
+	}
+	if store.Processes() != nil || store.Installs() != nil || store.LogPolicies() != nil {
+		t.Fatal("Expected nil store to return no records")
+	}
+}
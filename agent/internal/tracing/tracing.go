@@ -0,0 +1,101 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package tracing exports Agent command spans to the OpenTelemetry collector used by the Control Plane.
+// tracing 包将 Agent 命令 span 导出到 Control Plane 所使用的 OpenTelemetry 收集器。
+package tracing
+
+import (
+	"context"
+	"os"
+
+	"github.com/seatunnel/seatunnelX/agent/internal/config"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ServiceName identifies Agent spans in the collector.
+// ServiceName 在收集器中标识 Agent 的 span。
+const ServiceName = "seatunnelx-agent"
+
+const instrumentationName = "github.com/seatunnel/seatunnelX/agent"
+
+// Init installs the global tracer provider when telemetry is enabled and returns its shutdown function.
+// Spans are no-ops while telemetry is disabled or Init has not been called.
+// Init 在启用遥测时安装全局 tracer provider，并返回其关闭函数。
+// 未启用遥测或未调用 Init 时 span 为空操作。
+func Init(ctx context.Context, cfg config.TelemetryConfig, agentID string) (func(context.Context) error, error) {
+	if !cfg.Enabled {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	opts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(cfg.Endpoint)}
+	if cfg.Insecure {
+		opts = append(opts, otlptracegrpc.WithInsecure())
+	}
+	exporter, err := otlptracegrpc.New(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	attrs := []attribute.KeyValue{semconv.ServiceName(ServiceName)}
+	if hostname, err := os.Hostname(); err == nil {
+		attrs = append(attrs, semconv.HostName(hostname))
+	}
+	if agentID != "" {
+		attrs = append(attrs, semconv.ServiceInstanceID(agentID))
+	}
+	res, err := resource.Merge(
+		resource.Default(),
+		resource.NewWithAttributes(semconv.SchemaURL, attrs...),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		// Follow the Control Plane's sampling decision carried in the command metadata
+		// 遵循命令元数据中携带的 Control Plane 采样决定
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.AlwaysSample())),
+	)
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
+
+// Start starts a span named name as a child of the span in ctx.
+// Start 以 ctx 中的 span 为父 span 启动名为 name 的 span。
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentationName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End records err on span, if any, and ends it.
+// End 在 span 上记录 err（如有）并结束它。
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -29,7 +29,11 @@ import {
   Layers,
   RefreshCw,
 } from 'lucide-react';
-import {OverviewService, OverviewData} from '@/lib/services/dashboard';
+import {
+  OverviewService,
+  OverviewData,
+  OverviewStats,
+} from '@/lib/services/dashboard';
 import {MonitoringOverview} from '@/components/common/monitoring';
 import {Button} from '@/components/ui/button';
 import Link from 'next/link';
@@ -39,6 +43,7 @@ export default function DashboardPage() {
   const [data, setData] = useState<OverviewData | null>(null);
   const [loading, setLoading] = useState(true);
   const [error, setError] = useState<string | null>(null);
+  const [live, setLive] = useState(false);
 
   const fetchData = async () => {
     setLoading(true);
@@ -54,9 +59,28 @@ export default function DashboardPage() {
 
   useEffect(() => {
     fetchData();
+    return OverviewService.subscribeOverview((message) => {
+      if (message.type === 'snapshot') {
+        setData(message.data as OverviewData);
+        setError(null);
+        setLoading(false);
+      } else if (message.type === 'stats') {
+        const nextStats = message.data as OverviewStats;
+        setData((current) =>
+          current ? {...current, stats: nextStats} : current,
+        );
+      }
+    }, setLive);
+  }, []);
+
+  // 实时连接断开时回退为轮询 / Poll while the live connection is down
+  useEffect(() => {
+    if (live) {
+      return;
+    }
     const interval = setInterval(fetchData, 30000);
     return () => clearInterval(interval);
-  }, []);
+  }, [live]);
 
   const stats = data?.stats;
 
@@ -64,7 +88,9 @@ export default function DashboardPage() {
     {
       title: t('totalHosts'),
       value: stats?.total_hosts ?? 0,
-      subValue: `${stats?.online_hosts ?? 0} ${t('online')}`,
+      subValue: stats?.maintenance_hosts
+        ? `${stats.online_hosts} ${t('online')} · ${stats.maintenance_hosts} ${t('maintenance')}`
+        : `${stats?.online_hosts ?? 0} ${t('online')}`,
       icon: Server,
       color: 'text-primary',
       bgColor: 'bg-primary/10',
@@ -113,7 +139,12 @@ export default function DashboardPage() {
           <Ship className='h-6 w-6 text-primary' />
           <div>
             <h1 className='text-lg font-bold leading-tight'>{t('title')}</h1>
-            <p className='text-xs text-muted-foreground'>{t('subtitle')}</p>
+            <p className='text-xs text-muted-foreground'>
+              {t('subtitle')}
+              {stats?.running_installations
+                ? ` · ${t('runningInstallations', {count: stats.running_installations})}`
+                : ''}
+            </p>
           </div>
         </div>
         <Button variant='outline' size='sm' onClick={fetchData} disabled={loading}>
@@ -26,6 +26,7 @@
  * 显示命令执行的详细信息，包括输出日志和错误信息。
  */
 
+import {useEffect, useState} from 'react';
 import {useTranslations} from 'next-intl';
 import {Badge} from '@/components/ui/badge';
 import {Progress} from '@/components/ui/progress';
@@ -48,7 +49,12 @@ import {
   XCircle,
   Loader2,
 } from 'lucide-react';
-import {CommandLogInfo, CommandStatus} from '@/lib/services/audit/types';
+import {AuditService} from '@/lib/services/audit/audit.service';
+import {
+  CommandLogInfo,
+  CommandOutput,
+  CommandStatus,
+} from '@/lib/services/audit/types';
 
 interface CommandDetailProps {
   open: boolean;
@@ -72,6 +78,8 @@ function getStatusIcon(status: CommandStatus) {
       return <XCircle className='h-5 w-5 text-red-500' />;
     case CommandStatus.CANCELLED:
       return <AlertCircle className='h-5 w-5 text-gray-500' />;
+    case CommandStatus.TIMEOUT:
+      return <Clock className='h-5 w-5 text-red-500' />;
     default:
       return <Clock className='h-5 w-5 text-gray-500' />;
   }
@@ -93,6 +101,7 @@ function getStatusBadgeVariant(
     case CommandStatus.PENDING:
       return 'outline';
     case CommandStatus.FAILED:
+    case CommandStatus.TIMEOUT:
       return 'destructive';
     case CommandStatus.CANCELLED:
       return 'outline';
@@ -142,6 +151,47 @@ export function CommandDetail({
   command,
 }: CommandDetailProps) {
   const t = useTranslations();
+  const [output, setOutput] = useState(command.output);
+  const [status, setStatus] = useState(command.status);
+  const [progress, setProgress] = useState(command.progress);
+  const [live, setLive] = useState(false);
+
+  const active =
+    command.status === CommandStatus.RUNNING ||
+    command.status === CommandStatus.PENDING;
+
+  /**
+   * Follow console output while the command is still running
+   * 命令执行期间实时跟随控制台输出
+   */
+  useEffect(() => {
+    setOutput(command.output);
+    setStatus(command.status);
+    setProgress(command.progress);
+    if (!open || !active) {
+      return;
+    }
+
+    let received = '';
+    const source = new EventSource(
+      AuditService.getCommandOutputStreamUrl(command.id, 0),
+    );
+    setLive(true);
+    source.addEventListener('output', (event) => {
+      const chunk = JSON.parse((event as MessageEvent).data) as CommandOutput;
+      received += chunk.output;
+      setOutput(received);
+      setStatus(chunk.status);
+      setProgress(chunk.progress);
+    });
+    const stop = () => {
+      source.close();
+      setLive(false);
+    };
+    source.addEventListener('done', stop);
+    source.onerror = stop;
+    return stop;
+  }, [open, active, command]);
 
   return (
     <Sheet open={open} onOpenChange={onOpenChange}>
@@ -160,10 +210,10 @@ export function CommandDetail({
           {/* Status Overview / 状态概览 */}
           <div className='flex items-center justify-between p-4 bg-muted rounded-lg'>
             <div className='flex items-center gap-3'>
-              {getStatusIcon(command.status)}
+              {getStatusIcon(status)}
               <div>
-                <Badge variant={getStatusBadgeVariant(command.status)}>
-                  {t(`audit.statuses.${command.status}`)}
+                <Badge variant={getStatusBadgeVariant(status)}>
+                  {t(`audit.statuses.${status}`)}
                 </Badge>
                 <p className='text-sm text-muted-foreground mt-1'>
                   {command.command_type}
@@ -172,8 +222,8 @@ export function CommandDetail({
             </div>
             <div className='text-right'>
               <div className='flex items-center gap-2'>
-                <Progress value={command.progress} className='w-24 h-2' />
-                <span className='text-sm font-medium'>{command.progress}%</span>
+                <Progress value={progress} className='w-24 h-2' />
+                <span className='text-sm font-medium'>{progress}%</span>
               </div>
               <p className='text-xs text-muted-foreground mt-1'>
                 {formatDuration(command.started_at, command.finished_at)}
@@ -245,17 +295,25 @@ export function CommandDetail({
           {/* Output and Error Tabs / 输出和错误标签页 */}
           <Tabs defaultValue='output' className='w-full'>
             <TabsList className='grid w-full grid-cols-3'>
-              <TabsTrigger value='output'>{t('audit.output')}</TabsTrigger>
+              <TabsTrigger value='output' className='gap-1'>
+                {t('audit.output')}
+                {live && (
+                  <span className='flex items-center gap-1 text-xs text-blue-500'>
+                    <span className='h-1.5 w-1.5 rounded-full bg-blue-500 animate-pulse' />
+                    {t('audit.liveOutput')}
+                  </span>
+                )}
+              </TabsTrigger>
               <TabsTrigger value='error'>{t('audit.error')}</TabsTrigger>
               <TabsTrigger value='parameters'>
                 {t('audit.parameters')}
               </TabsTrigger>
             </TabsList>
             <TabsContent value='output' className='mt-4'>
-              <ScrollArea className='h-[200px] w-full rounded-md border p-4'>
-                {command.output ? (
-                  <pre className='text-xs font-mono whitespace-pre-wrap'>
-                    {command.output}
+              <ScrollArea className='h-[320px] w-full rounded-md border bg-zinc-950 p-4'>
+                {output ? (
+                  <pre className='text-xs font-mono whitespace-pre-wrap text-zinc-100'>
+                    {output}
                   </pre>
                 ) : (
                   <p className='text-sm text-muted-foreground'>
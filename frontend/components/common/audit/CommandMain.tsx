@@ -254,6 +254,9 @@ export function CommandMain() {
             <SelectItem value={CommandStatus.CANCELLED}>
               {t('audit.statuses.cancelled')}
             </SelectItem>
+            <SelectItem value={CommandStatus.TIMEOUT}>
+              {t('audit.statuses.timeout')}
+            </SelectItem>
           </SelectContent>
         </Select>
 
@@ -71,6 +71,7 @@ function getStatusBadgeVariant(
     case CommandStatus.PENDING:
       return 'outline';
     case CommandStatus.FAILED:
+    case CommandStatus.TIMEOUT:
       return 'destructive';
     case CommandStatus.CANCELLED:
       return 'outline';
@@ -53,7 +53,12 @@ import {
   DialogTitle,
   DialogDescription,
 } from '@/components/animate-ui/radix/dialog';
-import {SquareArrowUpRight, LoaderCircle, Github} from 'lucide-react';
+import {
+  SquareArrowUpRight,
+  LoaderCircle,
+  Github,
+  KeyRound,
+} from 'lucide-react';
 import {useAuth} from '@/hooks/use-auth';
 import services from '@/lib/services';
 import {cn} from '@/lib/utils';
@@ -168,7 +173,13 @@ export function LoginForm({className, ...props}: LoginFormProps) {
 
   const showGitHubLogin = enabledOAuthProviders.includes('github');
   const showGoogleLogin = enabledOAuthProviders.includes('google');
-  const hasEnabledOAuthProviders = showGitHubLogin || showGoogleLogin;
+  // 其余提供商为配置的通用 OIDC 提供商（Keycloak、Okta 等），按名称显示
+  const oidcProviders = enabledOAuthProviders
+    .filter((provider) => provider !== 'github' && provider !== 'google')
+    .sort();
+  const oauthButtonCount =
+    Number(showGitHubLogin) + Number(showGoogleLogin) + oidcProviders.length;
+  const hasEnabledOAuthProviders = oauthButtonCount > 0;
 
   /**
    * 验证表单输入
@@ -344,9 +355,7 @@ export function LoginForm({className, ...props}: LoginFormProps) {
                 <div
                   className={cn(
                     'grid gap-4',
-                    showGitHubLogin && showGoogleLogin
-                      ? 'grid-cols-2'
-                      : 'grid-cols-1',
+                    oauthButtonCount > 1 ? 'grid-cols-2' : 'grid-cols-1',
                   )}
                 >
                   {showGitHubLogin ? (
@@ -371,6 +380,19 @@ export function LoginForm({className, ...props}: LoginFormProps) {
                       Google
                     </Button>
                   ) : null}
+                  {oidcProviders.map((provider) => (
+                    <Button
+                      key={provider}
+                      type='button'
+                      variant='outline'
+                      className='capitalize'
+                      onClick={() => handleOAuthLogin(provider)}
+                      disabled={isButtonLoading}
+                    >
+                      <KeyRound className='h-4 w-4' />
+                      {provider}
+                    </Button>
+                  ))}
                 </div>
               </>
             ) : null}
@@ -374,6 +374,23 @@ describe('LoginForm', () => {
         expect(mockLoginWithOAuth).toHaveBeenCalledWith('google', '/dashboard');
       });
     });
+
+    it('应该为配置的 OIDC 提供商显示登录按钮', async () => {
+      mockGetEnabledOAuthProviders.mockResolvedValue(['github', 'keycloak']);
+      render(<LoginForm />);
+
+      const keycloakButton = await screen.findByRole('button', {
+        name: /keycloak/,
+      });
+      fireEvent.click(keycloakButton);
+
+      await waitFor(() => {
+        expect(mockLoginWithOAuth).toHaveBeenCalledWith(
+          'keycloak',
+          '/dashboard',
+        );
+      });
+    });
   });
 
   describe('错误处理', () => {
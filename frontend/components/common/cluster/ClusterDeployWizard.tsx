@@ -95,6 +95,7 @@ import type {
   CheckStatus,
   RuntimeStorageValidationKind,
   RuntimeStorageValidationResult,
+  ResourceAdvice,
 } from '@/lib/services/installer/types';
 
 // Wizard step types / 向导步骤类型
@@ -292,6 +293,10 @@ export function ClusterDeployWizard({
   >({});
   const [validatingKind, setValidatingKind] =
     useState<RuntimeStorageValidationKind | null>(null);
+  const [resourceAdvice, setResourceAdvice] = useState<ResourceAdvice | null>(
+    null,
+  );
+  const [advising, setAdvising] = useState(false);
 
   // Load available hosts / 加载可用主机
   const loadHosts = useCallback(async () => {
@@ -506,6 +511,34 @@ export function ClusterDeployWizard({
     );
   }, [precheckResults, precheckRunning]);
 
+  // Ports listened on by the same host must differ; a separated host may run both master and worker
+  // 同一主机监听的端口不能相同；分离模式下同一主机可能同时运行 master 与 worker
+  const hasPortCollision = useMemo(() => {
+    const sharedHost =
+      config.deploymentMode === DeploymentMode.SEPARATED &&
+      selectedHosts.some(
+        (h) =>
+          h.roles.includes(NodeRole.MASTER) &&
+          h.roles.includes(NodeRole.WORKER),
+      );
+    const ports = [
+      config.clusterPort,
+      ...(sharedHost ? [config.workerPort] : []),
+      ...(httpServiceSupported && config.runtime.enable_http
+        ? [config.httpPort]
+        : []),
+    ];
+    return new Set(ports).size !== ports.length;
+  }, [
+    config.clusterPort,
+    config.deploymentMode,
+    config.httpPort,
+    config.runtime.enable_http,
+    config.workerPort,
+    httpServiceSupported,
+    selectedHosts,
+  ]);
+
   // Check if precheck has been run / 检查是否已运行过预检查
   const precheckHasRun = useMemo(() => {
     return precheckResults.length > 0 && !precheckRunning;
@@ -699,6 +732,76 @@ export function ClusterDeployWizard({
     [config.checkpoint, config.imap, getRuntimeStorageMissingFields, selectedHostIds, t],
   );
 
+  // Ask the advisor for heap sizes and slots fitting the selected hosts / 请求适合所选主机的堆内存与 slot 建议
+  const fetchResourceAdvice = useCallback(async () => {
+    if (selectedHostIds.length === 0) {
+      toast.warning(t('installer.runtimeStorage.noHostsSelected'));
+      return;
+    }
+    const separated = config.deploymentMode === DeploymentMode.SEPARATED;
+    try {
+      setAdvising(true);
+      const advice = await services.installer.adviseResources({
+        host_ids: selectedHostIds,
+        deployment_mode: config.deploymentMode,
+        master_host_ids: separated
+          ? selectedHosts
+              .filter((h) => h.roles.includes(NodeRole.MASTER))
+              .map((h) => h.host.id)
+          : undefined,
+        jvm: config.jvm,
+      });
+      setResourceAdvice(advice);
+    } catch (error) {
+      toast.error(
+        error instanceof Error
+          ? error.message
+          : t('cluster.wizard.resourceAdviceFailed'),
+      );
+    } finally {
+      setAdvising(false);
+    }
+  }, [config.deploymentMode, config.jvm, selectedHostIds, selectedHosts, t]);
+
+  const applyResourceAdvice = useCallback(() => {
+    if (!resourceAdvice) {
+      return;
+    }
+    const separated = config.deploymentMode === DeploymentMode.SEPARATED;
+    updateConfig({
+      jvm: separated
+        ? {
+            ...config.jvm,
+            master_heap_size: resourceAdvice.jvm.master_heap_size,
+            worker_heap_size: resourceAdvice.jvm.worker_heap_size,
+          }
+        : {
+            ...config.jvm,
+            hybrid_heap_size: resourceAdvice.jvm.hybrid_heap_size,
+          },
+      runtime: {
+        ...config.runtime,
+        dynamic_slot: resourceAdvice.dynamic_slot,
+        slot_num: resourceAdvice.slot_num,
+      },
+    });
+    // Applied heaps no longer match the checked plan / 应用后的堆内存已不同于检查时的计划
+    setResourceAdvice({
+      ...resourceAdvice,
+      warnings: resourceAdvice.warnings.filter(
+        (w) => w.code !== 'heap_exceeds_memory',
+      ),
+    });
+    toast.success(t('cluster.wizard.resourceAdviceApplied'));
+  }, [
+    config.deploymentMode,
+    config.jvm,
+    config.runtime,
+    resourceAdvice,
+    t,
+    updateConfig,
+  ]);
+
   // Toggle host selection / 切换主机选择
   const toggleHostSelection = useCallback((hostId: number) => {
     setHostsWithRole((prev) =>
@@ -709,6 +812,7 @@ export function ClusterDeployWizard({
     // Reset precheck results when host selection changes / 主机选择变化时重置预检查结果
     setPrecheckResults([]);
     setPrecheckRunning(false);
+    setResourceAdvice(null);
   }, []);
 
   // Toggle one role for a host (separated mode). Keeps at least one role. / 切换主机的某一角色（分离模式），至少保留一个角色
@@ -759,7 +863,7 @@ export function ClusterDeployWizard({
       case 'precheck':
         return allPrechecksPassed; // Must pass precheck / 必须通过预检查
       case 'config':
-        if (config.version.length === 0) {
+        if (config.version.length === 0 || hasPortCollision) {
           return false;
         }
         return (
@@ -775,7 +879,7 @@ export function ClusterDeployWizard({
       default:
         return false;
     }
-  }, [currentStep.id, config, selectedHosts, deployStatus, allPrechecksPassed, getRuntimeStorageMissingFields]);
+  }, [currentStep.id, config, selectedHosts, deployStatus, allPrechecksPassed, getRuntimeStorageMissingFields, hasPortCollision]);
 
   // Handle deploy / 处理部署
   const handleDeploy = useCallback(async () => {
@@ -1630,17 +1734,36 @@ export function ClusterDeployWizard({
                       </p>
                     </div>
                   )}
+                  {hasPortCollision && (
+                    <p className='text-xs text-destructive'>
+                      {t('cluster.wizard.portCollision')}
+                    </p>
+                  )}
                 </CardContent>
               </Card>
 
               {/* JVM Config / JVM 配置 */}
               <Card>
-                <CardHeader className='pb-2'>
+                <CardHeader className='pb-2 flex flex-row items-center justify-between space-y-0'>
                   <CardTitle className='text-base'>
                     {t('installer.jvmConfig')}
                   </CardTitle>
+                  <Button
+                    type='button'
+                    variant='outline'
+                    size='sm'
+                    onClick={fetchResourceAdvice}
+                    disabled={advising}
+                  >
+                    {advising ? (
+                      <Loader2 className='h-4 w-4 mr-1 animate-spin' />
+                    ) : (
+                      <Info className='h-4 w-4 mr-1' />
+                    )}
+                    {t('cluster.wizard.resourceAdvice')}
+                  </Button>
                 </CardHeader>
-                <CardContent>
+                <CardContent className='space-y-3'>
                   {config.deploymentMode === DeploymentMode.HYBRID ? (
                     <div className='space-y-2'>
                       <Label>{t('installer.hybridHeapSize')} (GB)</Label>
@@ -1700,6 +1823,50 @@ export function ClusterDeployWizard({
                       </div>
                     </div>
                   )}
+                  {resourceAdvice && (
+                    <div className='rounded-md border p-3 space-y-2 text-sm'>
+                      <p>
+                        {config.deploymentMode === DeploymentMode.SEPARATED
+                          ? t('cluster.wizard.resourceAdviceSeparated', {
+                              master: resourceAdvice.jvm.master_heap_size,
+                              worker: resourceAdvice.jvm.worker_heap_size,
+                              slots: resourceAdvice.slot_num,
+                            })
+                          : t('cluster.wizard.resourceAdviceHybrid', {
+                              heap: resourceAdvice.jvm.hybrid_heap_size,
+                              slots: resourceAdvice.slot_num,
+                            })}
+                      </p>
+                      {resourceAdvice.deployment_mode !==
+                        config.deploymentMode && (
+                        <p className='text-xs text-muted-foreground'>
+                          {t('cluster.wizard.resourceAdviceMode', {
+                            mode: resourceAdvice.deployment_mode,
+                          })}
+                        </p>
+                      )}
+                      {resourceAdvice.warnings.map((warning) => (
+                        <p
+                          key={`${warning.host_id}-${warning.code}`}
+                          className='flex items-start gap-1 text-xs text-yellow-600'
+                        >
+                          <AlertTriangle className='h-3 w-3 mt-0.5 shrink-0' />
+                          <span>
+                            {warning.host_name || warning.host_id}:{' '}
+                            {warning.message}
+                          </span>
+                        </p>
+                      ))}
+                      <Button
+                        type='button'
+                        size='sm'
+                        variant='secondary'
+                        onClick={applyResourceAdvice}
+                      >
+                        {t('cluster.wizard.resourceAdviceApply')}
+                      </Button>
+                    </div>
+                  )}
                 </CardContent>
               </Card>
             </div>
@@ -91,6 +91,8 @@ import {
   ChevronUp,
   Database,
   Search,
+  Network,
+  Stethoscope,
 } from 'lucide-react';
 import {Checkbox} from '@/components/ui/checkbox';
 import {motion} from 'motion/react';
@@ -122,6 +124,8 @@ import {
 import {EditClusterDialog} from './EditClusterDialog';
 import {AddNodeDialog} from './AddNodeDialog';
 import {EditNodeDialog} from './EditNodeDialog';
+import {NodeDiagnoseDialog} from './NodeDiagnoseDialog';
+import {ClusterPrecheckDialog} from './ClusterPrecheckDialog';
 import {ClusterPlugins} from './ClusterPlugins';
 import {ClusterConfigs} from './ClusterConfigs';
 import {MonitorConfigPanel} from './MonitorConfigPanel';
@@ -166,6 +170,7 @@ function getStatusBadgeVariant(
     case NodeStatus.ERROR:
       return 'destructive';
     case NodeStatus.OFFLINE:
+    case NodeStatus.DRAINING:
       return 'outline';
     default:
       return 'secondary';
@@ -393,6 +398,7 @@ export function ClusterDetail({clusterId}: ClusterDetailProps) {
   const [isEditDialogOpen, setIsEditDialogOpen] = useState(false);
   const [isAddNodeDialogOpen, setIsAddNodeDialogOpen] = useState(false);
   const [isEditNodeDialogOpen, setIsEditNodeDialogOpen] = useState(false);
+  const [isPrecheckDialogOpen, setIsPrecheckDialogOpen] = useState(false);
   const [nodeToEdit, setNodeToEdit] = useState<NodeInfo | null>(null);
   const [isDeleteDialogOpen, setIsDeleteDialogOpen] = useState(false);
   const [forceDelete, setForceDelete] = useState(false);
@@ -422,6 +428,7 @@ export function ClusterDetail({clusterId}: ClusterDetailProps) {
   const [logMode, setLogMode] = useState<string>('tail');
   const [logFilter, setLogFilter] = useState<string>('');
   const [logDate, setLogDate] = useState<string>('');
+  const [diagnoseNode, setDiagnoseNode] = useState<NodeInfo | null>(null);
 
   /**
    * Load cluster data
@@ -1729,6 +1736,13 @@ export function ClusterDetail({clusterId}: ClusterDetailProps) {
             <Bug className='h-4 w-4 mr-2' />
             {t('cluster.openDiagnostics')}
           </Button>
+          <Button
+            variant='outline'
+            onClick={() => setIsPrecheckDialogOpen(true)}
+          >
+            <Network className='h-4 w-4 mr-2' />
+            {t('cluster.clusterPrecheck.entry')}
+          </Button>
           <Button
             variant='outline'
             onClick={() =>
@@ -2411,6 +2425,15 @@ export function ClusterDetail({clusterId}: ClusterDetailProps) {
                               >
                                 <FileText className='h-4 w-4' />
                               </Button>
+                              <Button
+                                variant='ghost'
+                                size='icon'
+                                onClick={() => setDiagnoseNode(node)}
+                                disabled={node.status !== NodeStatus.RUNNING}
+                                title={t('cluster.diagnose.title')}
+                              >
+                                <Stethoscope className='h-4 w-4' />
+                              </Button>
                               <Button
                                 variant='ghost'
                                 size='icon'
@@ -2701,6 +2724,20 @@ export function ClusterDetail({clusterId}: ClusterDetailProps) {
         onSuccess={handleNodeEdited}
       />
 
+      {/* Node JVM Diagnose Dialog / 节点 JVM 诊断对话框 */}
+      <NodeDiagnoseDialog
+        open={diagnoseNode !== null}
+        onOpenChange={(open) => !open && setDiagnoseNode(null)}
+        clusterId={clusterId}
+        node={diagnoseNode}
+      />
+
+      <ClusterPrecheckDialog
+        open={isPrecheckDialogOpen}
+        onOpenChange={setIsPrecheckDialogOpen}
+        clusterId={clusterId}
+      />
+
       {/* Delete Cluster Dialog / 删除集群对话框 */}
       <AlertDialog
         open={isDeleteDialogOpen}
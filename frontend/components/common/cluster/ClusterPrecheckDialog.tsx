@@ -0,0 +1,284 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+'use client';
+
+import {useCallback, useEffect, useState} from 'react';
+import {useTranslations} from 'next-intl';
+import {toast} from 'sonner';
+import {
+  AlertCircle,
+  CheckCircle2,
+  Loader2,
+  MinusCircle,
+  RefreshCw,
+  XCircle,
+} from 'lucide-react';
+
+import services from '@/lib/services';
+import {Button} from '@/components/ui/button';
+import {
+  Dialog,
+  DialogContent,
+  DialogDescription,
+  DialogFooter,
+  DialogHeader,
+  DialogTitle,
+} from '@/components/ui/dialog';
+import {
+  ClusterPrecheckNode,
+  ClusterPrecheckResult,
+  ConnectivityCell,
+  PrecheckCheckItem,
+} from '@/lib/services/cluster/types';
+
+interface ClusterPrecheckDialogProps {
+  open: boolean;
+  onOpenChange: (open: boolean) => void;
+  clusterId: number;
+}
+
+function getStatusIcon(status: PrecheckCheckItem['status']) {
+  switch (status) {
+    case 'passed':
+      return <CheckCircle2 className='h-4 w-4 text-green-500' />;
+    case 'failed':
+      return <XCircle className='h-4 w-4 text-red-500' />;
+    case 'warning':
+      return <AlertCircle className='h-4 w-4 text-yellow-500' />;
+    default:
+      return <MinusCircle className='h-4 w-4 text-muted-foreground' />;
+  }
+}
+
+function getCellClassName(status: PrecheckCheckItem['status']): string {
+  switch (status) {
+    case 'passed':
+      return 'bg-green-500/10';
+    case 'failed':
+      return 'bg-red-500/10';
+    case 'warning':
+      return 'bg-yellow-500/10';
+    default:
+      return 'bg-muted/40';
+  }
+}
+
+function memberLabel(node: ClusterPrecheckNode): string {
+  return node.host_name || node.address || `#${node.node_id}`;
+}
+
+/**
+ * ClusterPrecheckDialog - N×N member connectivity and clock skew matrix
+ * ClusterPrecheckDialog - N×N 成员连通性与时钟偏差矩阵
+ */
+export function ClusterPrecheckDialog({
+  open,
+  onOpenChange,
+  clusterId,
+}: ClusterPrecheckDialogProps) {
+  const t = useTranslations();
+  const [loading, setLoading] = useState(false);
+  const [result, setResult] = useState<ClusterPrecheckResult | null>(null);
+
+  const runPrecheck = useCallback(async () => {
+    setLoading(true);
+    try {
+      setResult(await services.cluster.precheckCluster(clusterId));
+    } catch (error) {
+      toast.error(
+        error instanceof Error ? error.message : t('cluster.precheckError'),
+      );
+    } finally {
+      setLoading(false);
+    }
+  }, [clusterId, t]);
+
+  useEffect(() => {
+    if (open) {
+      setResult(null);
+      void runPrecheck();
+    }
+  }, [open, runPrecheck]);
+
+  const renderCell = (
+    cell: ConnectivityCell | undefined,
+    diagonal: boolean,
+  ) => {
+    if (diagonal || !cell) {
+      return <span className='text-muted-foreground'>—</span>;
+    }
+    return (
+      <div
+        className='flex items-center justify-center gap-1'
+        title={cell.error || undefined}
+      >
+        {getStatusIcon(cell.status)}
+        {cell.reachable && (
+          <span className='text-xs'>{cell.latency_ms.toFixed(1)}ms</span>
+        )}
+      </div>
+    );
+  };
+
+  return (
+    <Dialog open={open} onOpenChange={onOpenChange}>
+      <DialogContent className='sm:max-w-4xl'>
+        <DialogHeader>
+          <DialogTitle>{t('cluster.clusterPrecheck.title')}</DialogTitle>
+          <DialogDescription>
+            {t('cluster.clusterPrecheck.description')}
+          </DialogDescription>
+        </DialogHeader>
+
+        {loading && !result && (
+          <div className='flex items-center justify-center py-10 text-sm text-muted-foreground'>
+            <Loader2 className='mr-2 h-4 w-4 animate-spin' />
+            {t('cluster.clusterPrecheck.running')}
+          </div>
+        )}
+
+        {result && (
+          <div className='space-y-4'>
+            <div className='flex items-center gap-2'>
+              {result.success ? (
+                <CheckCircle2 className='h-5 w-5 text-green-500' />
+              ) : (
+                <XCircle className='h-5 w-5 text-red-500' />
+              )}
+              <span className='text-sm font-medium'>
+                {result.success
+                  ? t('cluster.precheckPassed')
+                  : t('cluster.precheckFailed')}
+              </span>
+            </div>
+
+            <div className='space-y-1 rounded-md border bg-muted/50 p-3'>
+              {result.checks.map((check) => (
+                <div
+                  key={check.name}
+                  className='flex items-start gap-2 text-xs'
+                >
+                  {getStatusIcon(check.status)}
+                  <div>
+                    <span className='font-medium'>
+                      {t(`cluster.precheckItems.${check.name}`, {
+                        defaultValue: check.name,
+                      })}
+                      :
+                    </span>{' '}
+                    <span className='text-muted-foreground'>
+                      {check.message}
+                    </span>
+                  </div>
+                </div>
+              ))}
+            </div>
+
+            {result.nodes.length > 0 && (
+              <div className='overflow-x-auto rounded-md border'>
+                <table className='w-full text-sm'>
+                  <thead>
+                    <tr className='border-b'>
+                      <th className='p-2 text-left font-medium'>
+                        {t('cluster.clusterPrecheck.fromTo')}
+                      </th>
+                      {result.nodes.map((node) => (
+                        <th
+                          key={node.node_id}
+                          className='p-2 text-center font-medium'
+                        >
+                          <div>{memberLabel(node)}</div>
+                          <div className='text-xs font-normal text-muted-foreground'>
+                            {node.address}
+                          </div>
+                        </th>
+                      ))}
+                      <th className='p-2 text-center font-medium'>
+                        {t('cluster.clusterPrecheck.clockSkew')}
+                      </th>
+                    </tr>
+                  </thead>
+                  <tbody>
+                    {result.nodes.map((node, i) => (
+                      <tr
+                        key={node.node_id}
+                        className='border-b last:border-0'
+                      >
+                        <td className='p-2'>
+                          <div className='font-medium'>{memberLabel(node)}</div>
+                          {node.message && (
+                            <div className='text-xs text-muted-foreground'>
+                              {node.message}
+                            </div>
+                          )}
+                        </td>
+                        {result.nodes.map((peer, j) => (
+                          <td
+                            key={peer.node_id}
+                            className={`p-2 text-center ${getCellClassName(
+                              result.matrix[i]?.[j]?.status ?? 'skipped',
+                            )}`}
+                          >
+                            {renderCell(result.matrix[i]?.[j], i === j)}
+                          </td>
+                        ))}
+                        <td
+                          className={`p-2 text-center ${getCellClassName(
+                            node.clock_status,
+                          )}`}
+                        >
+                          <div className='flex items-center justify-center gap-1'>
+                            {getStatusIcon(node.clock_status)}
+                            {node.clock_skew_ms !== undefined && (
+                              <span className='text-xs'>
+                                {node.clock_skew_ms > 0 ? '+' : ''}
+                                {node.clock_skew_ms}ms
+                              </span>
+                            )}
+                          </div>
+                        </td>
+                      </tr>
+                    ))}
+                  </tbody>
+                </table>
+              </div>
+            )}
+
+            <p className='text-xs text-muted-foreground'>
+              {t('cluster.clusterPrecheck.legend')}
+            </p>
+          </div>
+        )}
+
+        <DialogFooter className='gap-2 sm:gap-0'>
+          <Button variant='outline' onClick={() => onOpenChange(false)}>
+            {t('common.close')}
+          </Button>
+          <Button onClick={runPrecheck} disabled={loading}>
+            {loading ? (
+              <Loader2 className='mr-2 h-4 w-4 animate-spin' />
+            ) : (
+              <RefreshCw className='mr-2 h-4 w-4' />
+            )}
+            {t('cluster.clusterPrecheck.rerun')}
+          </Button>
+        </DialogFooter>
+      </DialogContent>
+    </Dialog>
+  );
+}
@@ -0,0 +1,260 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+'use client';
+
+/**
+ * Node JVM Diagnose Dialog
+ * 节点 JVM 诊断对话框
+ *
+ * Collects thread dump, heap info and class histogram from a node JVM
+ * and lets the user view or download each section.
+ * 采集节点 JVM 的线程栈、堆信息与类直方图，并支持查看或下载各部分内容。
+ */
+
+import {useEffect, useState} from 'react';
+import {useTranslations} from 'next-intl';
+import {Download, Loader2, Stethoscope} from 'lucide-react';
+
+import services from '@/lib/services';
+import {Button} from '@/components/ui/button';
+import {Checkbox} from '@/components/ui/checkbox';
+import {
+  Dialog,
+  DialogContent,
+  DialogDescription,
+  DialogFooter,
+  DialogHeader,
+  DialogTitle,
+} from '@/components/ui/dialog';
+import {Tabs, TabsContent, TabsList, TabsTrigger} from '@/components/ui/tabs';
+import {
+  DiagnoseAction,
+  NodeDiagnosis,
+  NodeDiagnosisSection,
+  NodeInfo,
+} from '@/lib/services/cluster/types';
+
+const DIAGNOSE_ACTIONS: DiagnoseAction[] = [
+  'thread_dump',
+  'heap_info',
+  'heap_histogram',
+];
+
+const DEFAULT_ACTIONS: DiagnoseAction[] = ['thread_dump', 'heap_info'];
+
+interface NodeDiagnoseDialogProps {
+  open: boolean;
+  onOpenChange: (open: boolean) => void;
+  clusterId: number;
+  node: NodeInfo | null;
+}
+
+function downloadSection(node: NodeInfo, section: NodeDiagnosisSection) {
+  const blob = new Blob([section.content || ''], {type: 'text/plain'});
+  const url = URL.createObjectURL(blob);
+  const link = document.createElement('a');
+  link.href = url;
+  link.download = `${section.action}-${node.host_name || node.host_id}-${node.id}.txt`;
+  document.body.appendChild(link);
+  link.click();
+  document.body.removeChild(link);
+  URL.revokeObjectURL(url);
+}
+
+export function NodeDiagnoseDialog({
+  open,
+  onOpenChange,
+  clusterId,
+  node,
+}: NodeDiagnoseDialogProps) {
+  const t = useTranslations();
+  const [actions, setActions] = useState<DiagnoseAction[]>(DEFAULT_ACTIONS);
+  const [loading, setLoading] = useState(false);
+  const [diagnosis, setDiagnosis] = useState<NodeDiagnosis | null>(null);
+  const [error, setError] = useState<string | null>(null);
+
+  useEffect(() => {
+    if (open) {
+      setActions(DEFAULT_ACTIONS);
+      setDiagnosis(null);
+      setError(null);
+    }
+  }, [open, node?.id]);
+
+  const toggleAction = (action: DiagnoseAction, checked: boolean) => {
+    setActions((prev) =>
+      checked
+        ? DIAGNOSE_ACTIONS.filter((a) => a === action || prev.includes(a))
+        : prev.filter((a) => a !== action),
+    );
+  };
+
+  const handleCollect = async () => {
+    if (!node || actions.length === 0) {
+      return;
+    }
+    setLoading(true);
+    setError(null);
+    setDiagnosis(null);
+    try {
+      const result = await services.cluster.diagnoseNodeSafe(
+        clusterId,
+        node.id,
+        {actions},
+      );
+      if (result.success && result.data) {
+        setDiagnosis(result.data);
+      } else {
+        setError(result.error || t('cluster.diagnose.error'));
+      }
+    } finally {
+      setLoading(false);
+    }
+  };
+
+  const sections = diagnosis?.sections ?? [];
+
+  return (
+    <Dialog open={open} onOpenChange={onOpenChange}>
+      <DialogContent
+        className='max-h-[90vh]'
+        style={{maxWidth: '90vw', width: '1200px'}}
+      >
+        <DialogHeader>
+          <DialogTitle>
+            {t('cluster.diagnose.title')} - {node?.host_name}
+          </DialogTitle>
+          <DialogDescription>
+            {t('cluster.diagnose.description')}
+          </DialogDescription>
+        </DialogHeader>
+
+        <div className='flex flex-wrap gap-4 items-center'>
+          {DIAGNOSE_ACTIONS.map((action) => (
+            <label
+              key={action}
+              className='flex items-center gap-2 text-sm cursor-pointer'
+            >
+              <Checkbox
+                checked={actions.includes(action)}
+                onCheckedChange={(checked) =>
+                  toggleAction(action, checked === true)
+                }
+                disabled={loading}
+              />
+              {t(`cluster.diagnose.actions.${action}`)}
+            </label>
+          ))}
+          <Button
+            size='sm'
+            onClick={handleCollect}
+            disabled={loading || !node || actions.length === 0}
+          >
+            {loading ? (
+              <Loader2 className='h-4 w-4 animate-spin' />
+            ) : (
+              <Stethoscope className='h-4 w-4' />
+            )}
+            <span className='ml-1'>{t('cluster.diagnose.collect')}</span>
+          </Button>
+        </div>
+
+        {diagnosis && (
+          <div className='text-xs text-muted-foreground'>
+            {t('cluster.diagnose.summary', {
+              pid: diagnosis.pid,
+              time: new Date(diagnosis.collected_at).toLocaleString(),
+            })}
+          </div>
+        )}
+
+        <div className='h-[60vh]'>
+          {loading ? (
+            <div className='flex items-center justify-center h-full'>
+              <Loader2 className='h-6 w-6 animate-spin' />
+            </div>
+          ) : error ? (
+            <div className='text-sm text-destructive whitespace-pre-wrap'>
+              {error}
+            </div>
+          ) : sections.length > 0 ? (
+            <Tabs defaultValue={sections[0].action} className='h-full'>
+              <TabsList>
+                {sections.map((section) => (
+                  <TabsTrigger key={section.action} value={section.action}>
+                    {t(`cluster.diagnose.actions.${section.action}`)}
+                  </TabsTrigger>
+                ))}
+              </TabsList>
+              {sections.map((section) => (
+                <TabsContent
+                  key={section.action}
+                  value={section.action}
+                  className='space-y-2'
+                >
+                  {section.error ? (
+                    <div className='text-sm text-destructive whitespace-pre-wrap'>
+                      {section.error}
+                    </div>
+                  ) : (
+                    <>
+                      <div className='flex items-center justify-between gap-2 text-xs text-muted-foreground'>
+                        <span className='truncate'>
+                          {section.tool} · {section.output_path}
+                          {section.truncated &&
+                            ` · ${t('cluster.diagnose.truncated')}`}
+                        </span>
+                        {node && (
+                          <Button
+                            variant='outline'
+                            size='sm'
+                            onClick={() => downloadSection(node, section)}
+                          >
+                            <Download className='h-4 w-4' />
+                            <span className='ml-1'>
+                              {t('cluster.diagnose.download')}
+                            </span>
+                          </Button>
+                        )}
+                      </div>
+                      <div className='overflow-auto h-[52vh] bg-muted rounded-md p-4'>
+                        <pre className='text-xs font-mono whitespace-pre'>
+                          {section.content}
+                        </pre>
+                      </div>
+                    </>
+                  )}
+                </TabsContent>
+              ))}
+            </Tabs>
+          ) : (
+            <div className='flex items-center justify-center h-full text-sm text-muted-foreground'>
+              {t('cluster.diagnose.empty')}
+            </div>
+          )}
+        </div>
+
+        <DialogFooter>
+          <Button variant='outline' onClick={() => onOpenChange(false)}>
+            {t('common.close')}
+          </Button>
+        </DialogFooter>
+      </DialogContent>
+    </Dialog>
+  );
+}
@@ -39,6 +39,7 @@ import {
   CardHeader,
   CardTitle,
 } from '@/components/ui/card';
+import {Checkbox} from '@/components/ui/checkbox';
 import {Input} from '@/components/ui/input';
 import {Label} from '@/components/ui/label';
 import {Separator} from '@/components/ui/separator';
@@ -68,10 +69,11 @@ import type {AvailableVersions} from '@/lib/services/installer/types';
 import type {ClusterInfo} from '@/lib/services/cluster/types';
 import type {
   BlockingIssue,
+  CompatibilityItem,
   CreatePlanRequest,
   PrecheckResult,
 } from '@/lib/services/st-upgrade';
-import {getIssueCategoryLabel} from './utils';
+import {getCompatibilityBadgeVariant, getIssueCategoryLabel} from './utils';
 
 interface ClusterUpgradePrepareProps {
   clusterId: number;
@@ -90,6 +92,7 @@ export function ClusterUpgradePrepare({clusterId}: ClusterUpgradePrepareProps) {
   const [targetInstallDirTouched, setTargetInstallDirTouched] = useState(false);
   const [packageChecksum, setPackageChecksum] = useState('');
   const [connectorNamesText, setConnectorNamesText] = useState('');
+  const [acknowledgeWarnings, setAcknowledgeWarnings] = useState(false);
   const [confirmedRequest, setConfirmedRequest] =
     useState<CreatePlanRequest | null>(null);
   const [precheck, setPrecheck] = useState<PrecheckResult | null>(null);
@@ -115,6 +118,9 @@ export function ClusterUpgradePrepare({clusterId}: ClusterUpgradePrepareProps) {
       setTargetInstallDir(draft.request.target_install_dir);
       setTargetInstallDirTouched(true);
     }
+    if (draft.request?.acknowledge_warnings) {
+      setAcknowledgeWarnings(true);
+    }
     if (draft.request) {
       setConfirmedRequest(draft.request);
     }
@@ -183,8 +189,10 @@ export function ClusterUpgradePrepare({clusterId}: ClusterUpgradePrepareProps) {
         .split(',')
         .map((name) => name.trim())
         .filter(Boolean),
+      acknowledge_warnings: acknowledgeWarnings || undefined,
     }),
     [
+      acknowledgeWarnings,
       clusterId,
       connectorNamesText,
       packageChecksum,
@@ -275,6 +283,7 @@ export function ClusterUpgradePrepare({clusterId}: ClusterUpgradePrepareProps) {
     setPrecheck(null);
     setPackageChecksum('');
     setConnectorNamesText('');
+    setAcknowledgeWarnings(false);
     const nextVersion =
       resolveSeatunnelVersion(availableVersions || undefined) ||
       cluster?.version ||
@@ -660,6 +669,74 @@ export function ClusterUpgradePrepare({clusterId}: ClusterUpgradePrepareProps) {
             </Card>
           ) : null}
 
+          {precheck?.compatibility_report ? (
+            <Card data-testid='upgrade-prepare-compatibility'>
+              <CardHeader>
+                <div className='flex items-center justify-between gap-3'>
+                  <CardTitle>{t('compatibilityTitle')}</CardTitle>
+                  <Badge
+                    variant={getCompatibilityBadgeVariant(
+                      precheck.compatibility_report.status,
+                    )}
+                  >
+                    {t(
+                      `compatibilityStatus.${precheck.compatibility_report.status}`,
+                    )}
+                  </Badge>
+                </div>
+                <CardDescription>
+                  {t('compatibilityDescription', {
+                    source: precheck.compatibility_report.source_version || '-',
+                    target: precheck.compatibility_report.target_version,
+                  })}
+                </CardDescription>
+              </CardHeader>
+              <CardContent className='space-y-4'>
+                <div className='grid gap-4 xl:grid-cols-2'>
+                  <CompatibilitySection
+                    title={t('compatibilityConfigChanges')}
+                    emptyText={t('compatibilityNoItems')}
+                    items={precheck.compatibility_report.config_changes}
+                  />
+                  <CompatibilitySection
+                    title={t('compatibilityConnectors')}
+                    emptyText={t('compatibilityNoItems')}
+                    items={precheck.compatibility_report.connectors}
+                  />
+                  <CompatibilitySection
+                    title={t('compatibilityCheckpointNotes')}
+                    emptyText={t('compatibilityNoItems')}
+                    items={precheck.compatibility_report.checkpoint_notes}
+                  />
+                  <CompatibilitySection
+                    title={t('compatibilityManualActions')}
+                    emptyText={t('compatibilityNoItems')}
+                    items={precheck.compatibility_report.manual_actions}
+                  />
+                </div>
+                {precheck.compatibility_report.status === 'warning' ? (
+                  <label className='flex items-start gap-3 rounded-md border p-3'>
+                    <Checkbox
+                      data-testid='upgrade-prepare-acknowledge-warnings'
+                      checked={acknowledgeWarnings}
+                      onCheckedChange={(checked) =>
+                        setAcknowledgeWarnings(checked === true)
+                      }
+                    />
+                    <div className='space-y-1'>
+                      <div className='text-sm font-medium'>
+                        {t('acknowledgeWarnings')}
+                      </div>
+                      <p className='text-xs text-muted-foreground'>
+                        {t('acknowledgeWarningsHint')}
+                      </p>
+                    </div>
+                  </label>
+                ) : null}
+              </CardContent>
+            </Card>
+          ) : null}
+
           {precheck?.node_targets?.length ? (
             <Card data-testid='upgrade-prepare-node-targets'>
               <CardHeader>
@@ -742,6 +819,49 @@ function SummaryCard({icon, title, description, lines}: SummaryCardProps) {
   );
 }
 
+interface CompatibilitySectionProps {
+  title: string;
+  emptyText: string;
+  items: CompatibilityItem[];
+}
+
+function CompatibilitySection({
+  title,
+  emptyText,
+  items,
+}: CompatibilitySectionProps) {
+  return (
+    <div className='space-y-3 rounded-lg border p-4'>
+      <div className='flex items-center justify-between gap-3'>
+        <div className='text-sm font-medium'>{title}</div>
+        <Badge variant='secondary'>{items.length}</Badge>
+      </div>
+      {items.length === 0 ? (
+        <div className='text-xs text-muted-foreground'>{emptyText}</div>
+      ) : (
+        <ul className='space-y-2 text-sm text-muted-foreground'>
+          {items.map((item) => (
+            <li
+              key={`${item.code}-${item.subject || ''}`}
+              className='rounded-md bg-muted/40 p-3'
+            >
+              <div className='flex items-center gap-2'>
+                <Badge variant={getCompatibilityBadgeVariant(item.severity)}>
+                  {item.severity}
+                </Badge>
+                <span className='font-mono text-xs text-foreground'>
+                  {item.subject || item.code}
+                </span>
+              </div>
+              <div className='mt-1'>{item.message}</div>
+            </li>
+          ))}
+        </ul>
+      )}
+    </div>
+  );
+}
+
 function buildSuggestedTargetInstallDir(
   currentInstallDir?: string,
   sourceVersion?: string,
@@ -781,5 +901,6 @@ function normalizeUpgradeRequest(request: CreatePlanRequest) {
       .map((name) => name.trim())
       .filter(Boolean)
       .sort(),
+    acknowledge_warnings: Boolean(request.acknowledge_warnings),
   };
 }
@@ -18,6 +18,8 @@
 import {sanitizeConfigMergePlan} from '@/lib/services/st-upgrade';
 import type {
   CheckCategory,
+  CompatibilitySeverity,
+  CompatibilityStatus,
   ConfigConflict,
   ConfigMergeFile,
   ConfigMergePlan,
@@ -105,11 +107,29 @@ export function getIssueCategoryLabel(category: CheckCategory): string {
       return '节点';
     case 'config':
       return '配置';
+    case 'compatibility':
+      return '兼容性';
     default:
       return category;
   }
 }
 
+export function getCompatibilityBadgeVariant(
+  level: CompatibilityStatus | CompatibilitySeverity,
+): 'default' | 'secondary' | 'destructive' | 'outline' {
+  switch (level) {
+    case 'pass':
+      return 'default';
+    case 'fail':
+      return 'destructive';
+    case 'warning':
+      return 'outline';
+    case 'info':
+    default:
+      return 'secondary';
+  }
+}
+
 function normalizeConflictStatus(conflict: ConfigConflict): ConfigConflict {
   return {
     ...conflict,
@@ -125,6 +125,8 @@ function getStatusBadgeVariant(
       return 'outline';
     case HostStatus.ERROR:
       return 'destructive';
+    case HostStatus.MAINTENANCE:
+      return 'secondary';
     default:
       return 'secondary';
   }
@@ -137,6 +139,7 @@ function getStatusBadgeVariant(
 export function HostDetail({open, onOpenChange, host, onEdit}: HostDetailProps) {
   const t = useTranslations();
   const [installCommand, setInstallCommand] = useState<string>('');
+  const [installCommandExpiresAt, setInstallCommandExpiresAt] = useState<string>('');
   const [loadingCommand, setLoadingCommand] = useState(false);
 
   /**
@@ -149,6 +152,7 @@ export function HostDetail({open, onOpenChange, host, onEdit}: HostDetailProps)
       const result = await services.host.getInstallCommandSafe(host.id);
       if (result.success && result.data) {
         setInstallCommand(result.data.command);
+        setInstallCommandExpiresAt(result.data.expires_at);
       }
     } catch (err) {
       console.error('Failed to load install command:', err);
@@ -164,6 +168,14 @@ export function HostDetail({open, onOpenChange, host, onEdit}: HostDetailProps)
     // eslint-disable-next-line react-hooks/exhaustive-deps
   }, [open, host.id, host.host_type]);
 
+  /**
+   * Uninstall command derived from the install command, without the enrollment token
+   * 由安装命令推导的卸载命令（去掉注册令牌）
+   */
+  const uninstallCommand = installCommand
+    .replace('/install.sh', '/uninstall.sh')
+    .replace(/ENROLLMENT_TOKEN=\S+ /, '');
+
   /**
    * Copy install command to clipboard
    * 复制安装命令到剪贴板
@@ -373,6 +385,13 @@ export function HostDetail({open, onOpenChange, host, onEdit}: HostDetailProps)
                     >
                       <Copy className='h-4 w-4' />
                     </Button>
+                    {installCommandExpiresAt && (
+                      <p className='mt-2 text-xs text-muted-foreground'>
+                        {t('host.installCommandExpiresAt', {
+                          time: new Date(installCommandExpiresAt).toLocaleString(),
+                        })}
+                      </p>
+                    )}
                   </div>
                 ) : (
                   <div className='text-sm text-muted-foreground'>
@@ -390,14 +409,14 @@ export function HostDetail({open, onOpenChange, host, onEdit}: HostDetailProps)
                 {installCommand ? (
                   <div className='relative'>
                     <pre className='bg-muted p-3 rounded-md text-xs overflow-x-auto'>
-                      {installCommand.replace('/install.sh', '/uninstall.sh')}
+                      {uninstallCommand}
                     </pre>
                     <Button
                       variant='ghost'
                       size='icon'
                       className='absolute top-2 right-2'
                       onClick={() => {
-                        navigator.clipboard.writeText(installCommand.replace('/install.sh', '/uninstall.sh'));
+                        navigator.clipboard.writeText(uninstallCommand);
                         toast.success(t('host.commandCopied'));
                       }}
                     >
@@ -177,6 +177,35 @@ export function HostMain() {
     }
   };
 
+  /**
+   * Handle maintenance mode toggle
+   * 处理维护模式切换
+   */
+  const handleToggleMaintenance = async (host: HostInfo) => {
+    const enabled = !host.maintenance;
+    let reason = '';
+    if (enabled) {
+      const input = window.prompt(
+        t('host.maintenanceReasonPrompt', {name: host.name}),
+      );
+      if (input === null) {
+        return;
+      }
+      reason = input.trim();
+    }
+    try {
+      await services.host.setMaintenance(host.id, {enabled, reason});
+      toast.success(
+        enabled ? t('host.maintenanceEnabled') : t('host.maintenanceDisabled'),
+      );
+      loadHosts();
+    } catch (error) {
+      toast.error(
+        error instanceof Error ? error.message : t('host.maintenanceError'),
+      );
+    }
+  };
+
   /**
    * Handle host created
    * 处理主机创建完成
@@ -316,6 +345,9 @@ export function HostMain() {
             <SelectItem value={HostStatus.ERROR}>
               {t('host.statuses.error')}
             </SelectItem>
+            <SelectItem value={HostStatus.MAINTENANCE}>
+              {t('host.statuses.maintenance')}
+            </SelectItem>
           </SelectContent>
         </Select>
 
@@ -341,6 +373,7 @@ export function HostMain() {
           onViewDetail={handleViewDetail}
           onEdit={handleEdit}
           onDelete={handleDelete}
+          onToggleMaintenance={handleToggleMaintenance}
         />
       </motion.div>
 
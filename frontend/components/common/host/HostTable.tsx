@@ -53,7 +53,16 @@ import {
   TooltipProvider,
   TooltipTrigger,
 } from '@/components/ui/tooltip';
-import {Eye, Pencil, Trash2, Server, Container, Cloud, Search} from 'lucide-react';
+import {
+  Eye,
+  Pencil,
+  Trash2,
+  Server,
+  Container,
+  Cloud,
+  Search,
+  Wrench,
+} from 'lucide-react';
 import {HostInfo, HostType, HostStatus} from '@/lib/services/host/types';
 
 interface HostTableProps {
@@ -67,6 +76,7 @@ interface HostTableProps {
   onEdit: (host: HostInfo) => void;
   onDelete: (host: HostInfo) => void;
   onDiscoverCluster?: (host: HostInfo) => void;
+  onToggleMaintenance?: (host: HostInfo) => void;
 }
 
 /**
@@ -103,6 +113,8 @@ function getStatusBadgeVariant(
       return 'outline';
     case HostStatus.ERROR:
       return 'destructive';
+    case HostStatus.MAINTENANCE:
+      return 'secondary';
     default:
       return 'secondary';
   }
@@ -141,6 +153,7 @@ export function HostTable({
   onEdit,
   onDelete,
   onDiscoverCluster,
+  onToggleMaintenance,
 }: HostTableProps) {
   const t = useTranslations();
 
@@ -157,7 +170,7 @@ export function HostTable({
               <TableHead>{t('host.status')}</TableHead>
               <TableHead>{t('host.resources')}</TableHead>
               <TableHead>{t('host.createdAt')}</TableHead>
-              <TableHead className='w-[120px]'>{t('host.actions')}</TableHead>
+              <TableHead className='w-[160px]'>{t('host.actions')}</TableHead>
             </TableRow>
           </TableHeader>
           <TableBody>
@@ -222,6 +235,11 @@ export function HostTable({
                     <Badge variant={getStatusBadgeVariant(host.status)}>
                       {t(`host.statuses.${host.status}`)}
                     </Badge>
+                    {host.maintenance && host.maintenance_reason && (
+                      <p className='text-xs text-muted-foreground mt-1 truncate max-w-[160px]'>
+                        {host.maintenance_reason}
+                      </p>
+                    )}
                   </TableCell>
                   <TableCell>
                     <div className='text-sm space-y-1'>
@@ -286,6 +304,29 @@ export function HostTable({
                         </TooltipProvider>
                       )}
 
+                      {onToggleMaintenance && (
+                        <TooltipProvider>
+                          <Tooltip>
+                            <TooltipTrigger asChild>
+                              <Button
+                                variant='ghost'
+                                size='icon'
+                                onClick={() => onToggleMaintenance(host)}
+                              >
+                                <Wrench
+                                  className={`h-4 w-4 ${host.maintenance ? 'text-amber-500' : ''}`}
+                                />
+                              </Button>
+                            </TooltipTrigger>
+                            <TooltipContent>
+                              {host.maintenance
+                                ? t('host.exitMaintenance')
+                                : t('host.enterMaintenance')}
+                            </TooltipContent>
+                          </Tooltip>
+                        </TooltipProvider>
+                      )}
+
                       <TooltipProvider>
                         <Tooltip>
                           <TooltipTrigger asChild>
@@ -130,6 +130,7 @@ export function ConfigStep({
   const availableVersions = packages?.versions || [];
   const localPackages = packages?.local_packages || [];
   const recommendedVersion = packages?.recommended_version;
+  const unapprovedVersions = packages?.unapproved_versions || {};
   const versionCapabilities = useMemo(
     () => resolveSeatunnelVersionCapabilities(packages, config.version),
     [packages, config.version],
@@ -437,14 +438,26 @@ export function ConfigStep({
                     </SelectTrigger>
                     <SelectContent>
                       {availableVersions.map((version) => (
-                        <SelectItem key={version} value={version}>
-                          <div className='flex items-center gap-2'>
+                        <SelectItem
+                          key={version}
+                          value={version}
+                          disabled={Boolean(unapprovedVersions[version])}
+                        >
+                          <div
+                            className='flex items-center gap-2'
+                            title={unapprovedVersions[version]}
+                          >
                             {version}
                             {version === recommendedVersion && (
                               <Badge variant='secondary' className='text-xs'>
                                 {t('installer.recommended')}
                               </Badge>
                             )}
+                            {unapprovedVersions[version] && (
+                              <Badge variant='outline' className='text-xs'>
+                                {t('installer.versionNotApproved')}
+                              </Badge>
+                            )}
                           </div>
                         </SelectItem>
                       ))}
@@ -480,12 +493,24 @@ export function ConfigStep({
                       </SelectTrigger>
                       <SelectContent>
                         {localPackages.map((pkg) => (
-                          <SelectItem key={pkg.version} value={pkg.version}>
-                            <div className='flex items-center gap-2'>
+                          <SelectItem
+                            key={pkg.version}
+                            value={pkg.version}
+                            disabled={Boolean(unapprovedVersions[pkg.version])}
+                          >
+                            <div
+                              className='flex items-center gap-2'
+                              title={unapprovedVersions[pkg.version]}
+                            >
                               {pkg.version}
                               <span className='text-xs text-muted-foreground'>
                                 ({(pkg.file_size / 1024 / 1024).toFixed(1)} MB)
                               </span>
+                              {unapprovedVersions[pkg.version] && (
+                                <Badge variant='outline' className='text-xs'>
+                                  {t('installer.versionNotApproved')}
+                                </Badge>
+                              )}
                             </div>
                           </SelectItem>
                         ))}
@@ -76,10 +76,12 @@ const stepNames: Record<string, {en: string; zh: string}> = {
   download: {en: 'Download Package', zh: '下载安装包'},
   verify: {en: 'Verify Checksum', zh: '校验文件'},
   extract: {en: 'Extract Package', zh: '解压安装包'},
+  install_java: {en: 'Install Java', zh: '安装 Java'},
   configure_cluster: {en: 'Configure Cluster', zh: '配置集群'},
   configure_checkpoint: {en: 'Configure Checkpoint', zh: '配置检查点'},
   configure_jvm: {en: 'Configure JVM', zh: '配置 JVM'},
   install_plugins: {en: 'Install Plugins', zh: '安装插件'},
+  configure_systemd: {en: 'Configure systemd', zh: '配置 systemd'},
   register_cluster: {en: 'Register Cluster', zh: '注册集群'},
   complete: {en: 'Complete', zh: '完成'},
 };
@@ -239,6 +241,44 @@ export function InstallStep({
             <p className='text-sm text-muted-foreground mt-1'>{step.message}</p>
           )}
 
+          {/* Hook results / 钩子结果 */}
+          {step.hooks && step.hooks.length > 0 && (
+            <div
+              className='mt-2 space-y-1'
+              data-testid={`install-step-hooks-${step.step}`}
+            >
+              {step.hooks.map((hook) => (
+                <div
+                  key={`${hook.phase}-${hook.name}`}
+                  className='flex items-start gap-2 text-xs'
+                >
+                  {hook.success ? (
+                    <CheckCircle2 className='h-3.5 w-3.5 mt-0.5 text-green-500 flex-shrink-0' />
+                  ) : (
+                    <XCircle className='h-3.5 w-3.5 mt-0.5 text-red-500 flex-shrink-0' />
+                  )}
+                  <div className='min-w-0'>
+                    <span className='font-medium'>
+                      {t('installer.hooks.label', {
+                        phase: t(`installer.hooks.phases.${hook.phase}`),
+                        name: hook.name,
+                      })}
+                    </span>
+                    <span className='text-muted-foreground'>
+                      {' '}
+                      · {hook.duration_ms} ms
+                    </span>
+                    {(hook.error || hook.output) && (
+                      <pre className='mt-1 whitespace-pre-wrap break-all text-muted-foreground'>
+                        {hook.error || hook.output}
+                      </pre>
+                    )}
+                  </div>
+                </div>
+              ))}
+            </div>
+          )}
+
           {/* Error and retry / 错误和重试 */}
           {step.status === 'failed' && (
             <div className='mt-2 space-y-2'>
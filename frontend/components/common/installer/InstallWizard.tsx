@@ -338,6 +338,8 @@ export function InstallWizard({
         min_disk_space_mb: 5120,
         install_dir: config.installDir || undefined,
         ports,
+        version: config.version || undefined,
+        cluster_port: config.clusterPort,
       });
     } catch (err) {
       console.error('Precheck failed:', err);
@@ -361,6 +363,7 @@ export function InstallWizard({
             loading={precheckLoading}
             error={precheckError}
             onRunPrecheck={handleRunPrecheck}
+            hostId={hostId}
           />
         );
       case 'config':
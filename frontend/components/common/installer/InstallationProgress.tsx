@@ -71,10 +71,12 @@ const stepNames: Record<string, { en: string; zh: string }> = {
   download: { en: 'Download Package', zh: '下载安装包' },
   verify: { en: 'Verify Checksum', zh: '校验文件' },
   extract: { en: 'Extract Package', zh: '解压安装包' },
+  install_java: { en: 'Install Java', zh: '安装 Java' },
   configure_cluster: { en: 'Configure Cluster', zh: '配置集群' },
   configure_checkpoint: { en: 'Configure Checkpoint', zh: '配置检查点' },
   configure_jvm: { en: 'Configure JVM', zh: '配置 JVM' },
   install_plugins: { en: 'Install Plugins', zh: '安装插件' },
+  configure_systemd: { en: 'Configure systemd', zh: '配置 systemd' },
   register_cluster: { en: 'Register Cluster', zh: '注册集群' },
   complete: { en: 'Complete', zh: '完成' },
 };
@@ -220,6 +222,12 @@ export function InstallationProgress({
               <CardDescription>
                 {status.message || t('installer.installingSeaTunnel')}
               </CardDescription>
+              {status.queue_position ? (
+                <p className="mt-1 flex items-center gap-1 text-xs text-muted-foreground">
+                  <Clock className="h-3 w-3" />
+                  {t('installer.queuePosition', { position: status.queue_position })}
+                </p>
+              ) : null}
             </div>
           </div>
           <div className="flex items-center gap-2">
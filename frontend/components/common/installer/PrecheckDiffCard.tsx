@@ -0,0 +1,189 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+/**
+ * Precheck Diff Card Component
+ * 预检查对比卡片组件
+ *
+ * Shows what changed between the last passing precheck run and the current one.
+ * 显示最近一次通过的预检查与当前预检查之间的差异。
+ */
+
+'use client';
+
+import {useEffect, useState} from 'react';
+import {useTranslations} from 'next-intl';
+import {
+  Card,
+  CardContent,
+  CardDescription,
+  CardHeader,
+  CardTitle,
+} from '@/components/ui/card';
+import {Badge} from '@/components/ui/badge';
+import {cn} from '@/lib/utils';
+import {ArrowRight, GitCompare, Loader2} from 'lucide-react';
+import {installerService} from '@/lib/services/installer';
+import type {
+  PrecheckDiff,
+  PrecheckItemChange,
+} from '@/lib/services/installer/types';
+
+interface PrecheckDiffCardProps {
+  /** Host ID / 主机 ID */
+  hostId: number | string;
+  /** Precheck run to compare / 需要对比的预检查记录 */
+  runId: number;
+}
+
+/**
+ * Format a detail value for display
+ * 格式化详情值用于展示
+ */
+function formatDetail(value: unknown): string {
+  if (value === undefined || value === null) {
+    return '-';
+  }
+  return typeof value === 'object' ? JSON.stringify(value) : String(value);
+}
+
+export function PrecheckDiffCard({hostId, runId}: PrecheckDiffCardProps) {
+  const t = useTranslations();
+  const [diff, setDiff] = useState<PrecheckDiff | null>(null);
+  const [loading, setLoading] = useState(false);
+  const [error, setError] = useState<string | null>(null);
+
+  useEffect(() => {
+    let cancelled = false;
+    setLoading(true);
+    setError(null);
+    installerService
+      .diffPrecheckRuns(hostId, {target: runId})
+      .then((data) => {
+        if (!cancelled) {
+          setDiff(data);
+        }
+      })
+      .catch((err) => {
+        if (!cancelled) {
+          setError(err instanceof Error ? err.message : String(err));
+        }
+      })
+      .finally(() => {
+        if (!cancelled) {
+          setLoading(false);
+        }
+      });
+    return () => {
+      cancelled = true;
+    };
+  }, [hostId, runId]);
+
+  // Render one changed check / 渲染一项变化的检查
+  const renderChange = (change: PrecheckItemChange) => (
+    <div
+      key={`${change.change}-${change.name}`}
+      className={cn(
+        'rounded-lg border p-3 text-sm',
+        change.regressed &&
+          'border-red-200 dark:border-red-900/50 bg-red-50/50 dark:bg-red-900/10',
+      )}
+    >
+      <div className='flex items-center justify-between gap-2'>
+        <span className='font-medium'>
+          {t(`installer.precheckItems.${change.name.toLowerCase()}`) ||
+            change.name}
+        </span>
+        <div className='flex items-center gap-1'>
+          {change.regressed && (
+            <Badge variant='destructive'>
+              {t('installer.precheckDiff.regressed')}
+            </Badge>
+          )}
+          <Badge variant='outline'>
+            {t(`installer.precheckDiff.${change.change}`)}
+          </Badge>
+        </div>
+      </div>
+      <div className='mt-2 flex items-center gap-2 text-xs text-muted-foreground'>
+        <span>{change.before?.status ?? '-'}</span>
+        <ArrowRight className='h-3 w-3' />
+        <span>{change.after?.status ?? '-'}</span>
+      </div>
+      {change.after && change.before?.message !== change.after.message && (
+        <p className='mt-1 text-xs text-muted-foreground'>
+          {change.after.message}
+        </p>
+      )}
+      {change.changed_details && change.changed_details.length > 0 && (
+        <div className='mt-2 space-y-1 text-xs'>
+          {change.changed_details.map((key) => (
+            <div key={key} className='flex items-center gap-2'>
+              <span className='font-medium'>{key}:</span>
+              <span className='text-muted-foreground line-through'>
+                {formatDetail(change.before?.details?.[key])}
+              </span>
+              <ArrowRight className='h-3 w-3' />
+              <span>{formatDetail(change.after?.details?.[key])}</span>
+            </div>
+          ))}
+        </div>
+      )}
+    </div>
+  );
+
+  return (
+    <Card>
+      <CardHeader className='pb-3'>
+        <CardTitle className='flex items-center gap-2 text-base'>
+          <GitCompare className='h-4 w-4' />
+          {t('installer.precheckDiff.title')}
+        </CardTitle>
+        {diff?.base && (
+          <CardDescription>
+            {t('installer.precheckDiff.comparedWith', {
+              time: new Date(diff.base.created_at).toLocaleString(),
+              status: diff.base.overall_status,
+            })}
+          </CardDescription>
+        )}
+      </CardHeader>
+      <CardContent>
+        {loading ? (
+          <div className='flex items-center gap-2 text-sm text-muted-foreground'>
+            <Loader2 className='h-4 w-4 animate-spin' />
+            {t('common.loading')}
+          </div>
+        ) : error ? (
+          <p className='text-sm text-destructive'>{error}</p>
+        ) : !diff?.base ? (
+          <p className='text-sm text-muted-foreground'>
+            {t('installer.precheckDiff.noBase')}
+          </p>
+        ) : diff.changes.length === 0 ? (
+          <p className='text-sm text-muted-foreground'>
+            {t('installer.precheckDiff.noChanges')}
+          </p>
+        ) : (
+          <div className='space-y-2'>{diff.changes.map(renderChange)}</div>
+        )}
+      </CardContent>
+    </Card>
+  );
+}
+
+export default PrecheckDiffCard;
@@ -49,12 +49,20 @@ import {
   Coffee,
   Loader2,
   PlayCircle,
+  FileStack,
+  Layers,
+  Binary,
+  ArrowDownUp,
+  Clock,
+  Shield,
+  Wrench,
 } from 'lucide-react';
 import type {
   PrecheckResult,
   PrecheckItem,
   CheckStatus,
 } from '@/lib/services/installer/types';
+import {PrecheckDiffCard} from './PrecheckDiffCard';
 
 interface PrecheckStepProps {
   /** Precheck result / 预检查结果 */
@@ -65,6 +73,8 @@ interface PrecheckStepProps {
   error: string | null;
   /** Callback to run precheck / 运行预检查的回调 */
   onRunPrecheck: () => void;
+  /** Host ID, enables comparison with the last passing run / 主机 ID，用于与最近一次通过的记录对比 */
+  hostId?: number | string;
 }
 
 // Icon mapping for precheck items / 预检查项的图标映射
@@ -72,8 +82,15 @@ const itemIcons: Record<string, React.ComponentType<{className?: string}>> = {
   memory: MemoryStick,
   cpu: Cpu,
   disk: HardDrive,
+  disk_space: HardDrive,
   ports: Network,
   java: Coffee,
+  open_files: FileStack,
+  max_user_processes: Layers,
+  max_map_count: Binary,
+  swap: ArrowDownUp,
+  clock_sync: Clock,
+  firewall: Shield,
 };
 
 // Status configuration / 状态配置
@@ -111,6 +128,7 @@ export function PrecheckStep({
   loading,
   error,
   onRunPrecheck,
+  hostId,
 }: PrecheckStepProps) {
   const t = useTranslations();
 
@@ -169,6 +187,16 @@ export function PrecheckStep({
           </div>
           <p className='text-sm text-muted-foreground mt-1'>{item.message}</p>
 
+          {/* Suggested fix / 修复建议 */}
+          {item.suggestion && (
+            <div className='mt-2 flex items-start gap-2 text-xs'>
+              <Wrench className='h-3.5 w-3.5 mt-0.5 flex-shrink-0 text-muted-foreground' />
+              <code className='break-all rounded bg-muted px-1.5 py-0.5'>
+                {item.suggestion}
+              </code>
+            </div>
+          )}
+
           {/* Details / 详细信息 */}
           {item.details && Object.keys(item.details).length > 0 && (
             <div className='mt-2 text-xs text-muted-foreground space-y-1'>
@@ -263,6 +291,13 @@ export function PrecheckStep({
         </Card>
       )}
 
+      {/* Changes since the last passing run / 与最近一次通过记录的差异 */}
+      {hostId !== undefined &&
+        result?.run_id !== undefined &&
+        result.overall_status !== 'passed' && (
+          <PrecheckDiffCard hostId={hostId} runId={result.run_id} />
+        )}
+
       {/* Empty state / 空状态 */}
       {!result && !loading && !error && (
         <Card>
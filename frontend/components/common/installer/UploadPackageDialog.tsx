@@ -66,8 +66,8 @@ export function UploadPackageDialog({
 
   // Extract version from filename / 从文件名提取版本
   const extractVersion = (filename: string): string => {
-    // Format: apache-seatunnel-{version}-bin.tar.gz
-    const match = filename.match(/apache-seatunnel-(.+)-bin\.tar\.gz/);
+    // Format: apache-seatunnel-{version}-bin.tar.gz or .zip
+    const match = filename.match(/apache-seatunnel-(.+)-bin\.(tar\.gz|zip)$/);
     return match ? match[1] : '';
   };
 
@@ -120,7 +120,7 @@ export function UploadPackageDialog({
     }
 
     // Validate file extension / 验证文件扩展名
-    if (!file.name.endsWith('.tar.gz')) {
+    if (!file.name.endsWith('.tar.gz') && !file.name.endsWith('.zip')) {
       setError(t('installer.invalidFileFormat'));
       return;
     }
@@ -213,7 +213,7 @@ export function UploadPackageDialog({
                 </p>
                 <input
                   type="file"
-                  accept=".tar.gz"
+                  accept=".tar.gz,.zip"
                   onChange={handleFileInputChange}
                   className="absolute inset-0 w-full h-full opacity-0 cursor-pointer"
                   disabled={uploading}
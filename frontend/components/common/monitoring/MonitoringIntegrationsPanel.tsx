@@ -111,6 +111,7 @@ export function MonitoringIntegrationsPanel() {
       wecom: t('phase3.channelTypes.wecom'),
       dingtalk: t('phase3.channelTypes.dingtalk'),
       feishu: t('phase3.channelTypes.feishu'),
+      slack: t('phase3.channelTypes.slack'),
     }),
     [t],
   );
@@ -433,6 +434,7 @@ export function MonitoringIntegrationsPanel() {
                     {typeLabelMap.dingtalk}
                   </SelectItem>
                   <SelectItem value='feishu'>{typeLabelMap.feishu}</SelectItem>
+                  <SelectItem value='slack'>{typeLabelMap.slack}</SelectItem>
                 </SelectContent>
               </Select>
             </div>
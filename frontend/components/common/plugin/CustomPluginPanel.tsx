@@ -0,0 +1,271 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+/**
+ * Custom Plugin Panel Component
+ * 自定义插件面板组件
+ */
+
+'use client';
+
+import {useCallback, useEffect, useState} from 'react';
+import {useTranslations} from 'next-intl';
+import {toast} from 'sonner';
+import {RefreshCw, Trash2, Upload} from 'lucide-react';
+import {Button} from '@/components/ui/button';
+import {
+  Card,
+  CardContent,
+  CardDescription,
+  CardHeader,
+  CardTitle,
+} from '@/components/ui/card';
+import {Input} from '@/components/ui/input';
+import {Label} from '@/components/ui/label';
+import {
+  Table,
+  TableBody,
+  TableCell,
+  TableHead,
+  TableHeader,
+  TableRow,
+} from '@/components/ui/table';
+import {PluginService} from '@/lib/services/plugin';
+import type {CustomPlugin} from '@/lib/services/plugin';
+
+interface CustomPluginPanelProps {
+  /** SeaTunnel version of the uploads / 上传插件对应的 SeaTunnel 版本 */
+  seatunnelVersion: string;
+  /** Called after an upload or delete / 上传或删除后回调 */
+  onChanged?: () => void;
+}
+
+/**
+ * Upload private connector jars and manage them
+ * 上传并管理私有连接器 jar
+ */
+export function CustomPluginPanel({
+  seatunnelVersion,
+  onChanged,
+}: CustomPluginPanelProps) {
+  const t = useTranslations();
+  const [plugins, setPlugins] = useState<CustomPlugin[]>([]);
+  const [loading, setLoading] = useState(false);
+  const [uploading, setUploading] = useState(false);
+  const [file, setFile] = useState<File | null>(null);
+  const [name, setName] = useState('');
+  const [displayName, setDisplayName] = useState('');
+  const [description, setDescription] = useState('');
+  const [fileInputKey, setFileInputKey] = useState(0);
+
+  const loadPlugins = useCallback(async () => {
+    setLoading(true);
+    try {
+      setPlugins(await PluginService.listCustomPlugins(seatunnelVersion));
+    } catch (err) {
+      toast.error(err instanceof Error ? err.message : String(err));
+    } finally {
+      setLoading(false);
+    }
+  }, [seatunnelVersion]);
+
+  useEffect(() => {
+    loadPlugins();
+  }, [loadPlugins]);
+
+  const resetForm = () => {
+    setFile(null);
+    setName('');
+    setDisplayName('');
+    setDescription('');
+    setFileInputKey((key) => key + 1);
+  };
+
+  const handleUpload = async () => {
+    if (!file) {
+      toast.error(t('plugin.uploadJarRequired'));
+      return;
+    }
+    if (!name.trim()) {
+      toast.error(t('plugin.customNameRequired'));
+      return;
+    }
+    setUploading(true);
+    try {
+      await PluginService.uploadCustomPlugin({
+        file,
+        name: name.trim(),
+        seatunnel_version: seatunnelVersion || undefined,
+        display_name: displayName.trim() || undefined,
+        description: description.trim() || undefined,
+      });
+      toast.success(t('plugin.customUploadSuccess'));
+      resetForm();
+      await loadPlugins();
+      onChanged?.();
+    } catch (err) {
+      toast.error(
+        err instanceof Error ? err.message : t('plugin.customUploadFailed'),
+      );
+    } finally {
+      setUploading(false);
+    }
+  };
+
+  const handleDelete = async (plugin: CustomPlugin) => {
+    try {
+      await PluginService.deleteCustomPlugin(plugin.id);
+      toast.success(t('plugin.customDeleteSuccess'));
+      await loadPlugins();
+      onChanged?.();
+    } catch (err) {
+      toast.error(
+        err instanceof Error ? err.message : t('plugin.customDeleteFailed'),
+      );
+    }
+  };
+
+  return (
+    <div className='space-y-4'>
+      <Card data-testid='plugin-custom-upload-form'>
+        <CardHeader>
+          <CardTitle className='text-base'>
+            {t('plugin.uploadCustomPlugin')}
+          </CardTitle>
+          <CardDescription>{t('plugin.customPluginHint')}</CardDescription>
+        </CardHeader>
+        <CardContent className='space-y-4'>
+          <div className='grid gap-4 md:grid-cols-3'>
+            <div className='space-y-2 md:col-span-3'>
+              <Label htmlFor='customPluginJar'>
+                {t('plugin.uploadJarFile')} *
+              </Label>
+              <Input
+                key={fileInputKey}
+                id='customPluginJar'
+                type='file'
+                accept='.jar'
+                data-testid='plugin-custom-jar-input'
+                onChange={(e) => setFile(e.target.files?.[0] || null)}
+              />
+            </div>
+            <div className='space-y-2'>
+              <Label htmlFor='customPluginName'>
+                {t('plugin.customName')} *
+              </Label>
+              <Input
+                id='customPluginName'
+                value={name}
+                onChange={(e) => setName(e.target.value)}
+                placeholder={t('plugin.customNamePlaceholder')}
+              />
+            </div>
+            <div className='space-y-2'>
+              <Label htmlFor='customPluginDisplayName'>
+                {t('plugin.customDisplayName')}
+              </Label>
+              <Input
+                id='customPluginDisplayName'
+                value={displayName}
+                onChange={(e) => setDisplayName(e.target.value)}
+              />
+            </div>
+            <div className='space-y-2'>
+              <Label htmlFor='customPluginDescription'>
+                {t('plugin.customDescription')}
+              </Label>
+              <Input
+                id='customPluginDescription'
+                value={description}
+                onChange={(e) => setDescription(e.target.value)}
+              />
+            </div>
+          </div>
+          <div className='flex justify-end gap-2'>
+            <Button variant='outline' onClick={resetForm} disabled={uploading}>
+              {t('common.cancel')}
+            </Button>
+            <Button onClick={handleUpload} disabled={uploading}>
+              {uploading ? (
+                <RefreshCw className='mr-2 h-4 w-4 animate-spin' />
+              ) : (
+                <Upload className='mr-2 h-4 w-4' />
+              )}
+              {t('plugin.uploadJar')}
+            </Button>
+          </div>
+        </CardContent>
+      </Card>
+
+      <Card>
+        <CardContent className='pt-6'>
+          {loading ? (
+            <div className='flex justify-center py-10'>
+              <RefreshCw
+                className='h-6 w-6 animate-spin text-muted-foreground'
+              />
+            </div>
+          ) : plugins.length === 0 ? (
+            <p className='py-10 text-center text-sm text-muted-foreground'>
+              {t('plugin.customEmpty')}
+            </p>
+          ) : (
+            <Table data-testid='plugin-custom-table'>
+              <TableHeader>
+                <TableRow>
+                  <TableHead>{t('plugin.customName')}</TableHead>
+                  <TableHead>{t('plugin.customDisplayName')}</TableHead>
+                  <TableHead>{t('plugin.customFile')}</TableHead>
+                  <TableHead>SHA-256</TableHead>
+                  <TableHead className='w-[80px]'>
+                    {t('common.actions')}
+                  </TableHead>
+                </TableRow>
+              </TableHeader>
+              <TableBody>
+                {plugins.map((plugin) => (
+                  <TableRow key={plugin.id}>
+                    <TableCell className='font-mono text-xs'>
+                      {plugin.name}
+                    </TableCell>
+                    <TableCell>{plugin.display_name}</TableCell>
+                    <TableCell className='text-xs text-muted-foreground'>
+                      {plugin.original_file_name}
+                    </TableCell>
+                    <TableCell className='font-mono text-xs'>
+                      {plugin.sha256.slice(0, 12)}
+                    </TableCell>
+                    <TableCell>
+                      <Button
+                        variant='ghost'
+                        size='sm'
+                        title={t('common.delete')}
+                        onClick={() => handleDelete(plugin)}
+                      >
+                        <Trash2 className='h-4 w-4 text-destructive' />
+                      </Button>
+                    </TableCell>
+                  </TableRow>
+                ))}
+              </TableBody>
+            </Table>
+          )}
+        </CardContent>
+      </Card>
+    </div>
+  );
+}
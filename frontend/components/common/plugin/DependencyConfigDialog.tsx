@@ -39,6 +39,7 @@ import {
   TableRow,
 } from '@/components/ui/table';
 import {Badge} from '@/components/ui/badge';
+import {Checkbox} from '@/components/ui/checkbox';
 import {toast} from 'sonner';
 import {FileCode, Package, Plus, RefreshCw, Trash2, Upload} from 'lucide-react';
 import {PluginService} from '@/lib/services/plugin';
@@ -139,6 +140,7 @@ export function PluginDependencyConfigSection({
   const [groupId, setGroupId] = useState('');
   const [artifactId, setArtifactId] = useState('');
   const [version, setVersion] = useState('');
+  const [resolveTransitive, setResolveTransitive] = useState(true);
   const [adding, setAdding] = useState(false);
   const [showXmlParse, setShowXmlParse] = useState(false);
   const [mavenXml, setMavenXml] = useState('');
@@ -180,6 +182,7 @@ export function PluginDependencyConfigSection({
     setGroupId('');
     setArtifactId('');
     setVersion('');
+    setResolveTransitive(true);
     setShowAddForm(false);
     setShowXmlParse(false);
     setMavenXml('');
@@ -208,6 +211,7 @@ export function PluginDependencyConfigSection({
         artifact_id: artifactId.trim(),
         version: version.trim(),
         seatunnel_version: seatunnelVersion,
+        resolve_transitive: resolveTransitive,
       });
       toast.success(t('plugin.addDependencySuccess'));
       resetForms();
@@ -420,6 +424,25 @@ export function PluginDependencyConfigSection({
               />
             </div>
           </div>
+          {/* Transitive resolution / 传递依赖解析 */}
+          <div className='flex items-start gap-2'>
+            <Checkbox
+              id='resolveTransitive'
+              checked={resolveTransitive}
+              onCheckedChange={(checked) =>
+                setResolveTransitive(checked === true)
+              }
+              className='mt-0.5'
+            />
+            <div className='space-y-1'>
+              <Label htmlFor='resolveTransitive'>
+                {t('plugin.resolveTransitive')}
+              </Label>
+              <p className='text-xs text-muted-foreground'>
+                {t('plugin.resolveTransitiveDesc')}
+              </p>
+            </div>
+          </div>
           <div className='flex justify-end gap-2'>
             <Button variant='outline' onClick={resetForms} disabled={adding}>
               {t('common.cancel')}
@@ -547,6 +570,14 @@ export function PluginDependencyConfigSection({
                         ? t('plugin.sourceUpload')
                         : t('plugin.sourceMaven')}
                     </Badge>
+                    {dep.resolved_from && (
+                      <div
+                        className='mt-1 text-xs text-muted-foreground whitespace-nowrap'
+                        title={dep.resolved_from}
+                      >
+                        {t('plugin.transitiveDependency')}
+                      </div>
+                    )}
                   </TableCell>
                   <TableCell className='font-mono text-xs whitespace-nowrap'>
                     {dep.group_id}
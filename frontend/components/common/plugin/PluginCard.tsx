@@ -158,6 +158,9 @@ export function PluginCard({
             >
               {t(`plugin.category.${plugin.category}`)}
             </Badge>
+            {plugin.custom && (
+              <Badge variant='secondary'>{t('plugin.customBadge')}</Badge>
+            )}
           </div>
           <span className='text-xs text-muted-foreground'>
             v{plugin.version}
@@ -323,6 +323,9 @@ export function PluginDetailDialog({
                 >
                   {t(`plugin.category.${plugin.category}`)}
                 </Badge>
+                {plugin.custom && (
+                  <Badge variant='secondary'>{t('plugin.customBadge')}</Badge>
+                )}
                 <Badge variant='secondary'>v{plugin.version}</Badge>
               </div>
 
@@ -87,6 +87,7 @@ import {PluginGrid} from './PluginGrid';
 import {PluginDetailDialog} from './PluginDetailDialog';
 import {InstallPluginDialog} from './InstallPluginDialog';
 import {BatchInstallDialog} from './BatchInstallDialog';
+import {CustomPluginPanel} from './CustomPluginPanel';
 import {Pagination} from '@/components/ui/pagination';
 import {
   Table,
@@ -1470,16 +1471,10 @@ export function PluginMain() {
         </TabsContent>
 
         <TabsContent value='custom' className='mt-4'>
-          <Card>
-            <CardContent>
-              <div className='text-center py-10'>
-                <Upload className='h-12 w-12 mx-auto text-muted-foreground mb-4' />
-                <p className='text-muted-foreground text-sm'>
-                  {t('plugin.customComingSoon')}
-                </p>
-              </div>
-            </CardContent>
-          </Card>
+          <CustomPluginPanel
+            seatunnelVersion={selectedVersion}
+            onChanged={() => void loadPlugins({force: true})}
+          />
         </TabsContent>
       </Tabs>
 
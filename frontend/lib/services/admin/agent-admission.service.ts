@@ -0,0 +1,117 @@
+/**
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+import {BaseService} from '../core/base.service';
+
+/**
+ * 拒绝规则类型
+ */
+export type AgentDenyRuleType = 'ip' | 'agent_id';
+
+/**
+ * 待审批 Agent
+ */
+export interface PendingAgent {
+  agent_id: string;
+  hostname: string;
+  ip_address: string;
+  version: string;
+  os_type: string;
+  arch: string;
+  first_seen_at: string;
+  last_seen_at: string;
+  attempt_count: number;
+}
+
+/**
+ * Agent 审批记录
+ */
+export interface AgentApproval {
+  id: number;
+  agent_id: string;
+  ip_address: string;
+  hostname: string;
+  approved_by: number;
+  created_at: string;
+}
+
+/**
+ * Agent 拒绝规则
+ */
+export interface AgentDenyRule {
+  id: number;
+  type: AgentDenyRuleType;
+  value: string;
+  reason: string;
+  created_by: number;
+  created_at: string;
+}
+
+/**
+ * 新增拒绝规则请求
+ */
+export interface CreateAgentDenyRuleRequest {
+  type: AgentDenyRuleType;
+  value: string;
+  reason?: string;
+}
+
+/**
+ * Agent 准入控制服务（审批与拒绝列表）
+ */
+export class AgentAdmissionService extends BaseService {
+  protected static readonly basePath = '/admin/agents';
+
+  /**
+   * 获取待审批 Agent 列表
+   */
+  static async listPendingAgents(): Promise<PendingAgent[]> {
+    return this.get<PendingAgent[]>('/pending');
+  }
+
+  /**
+   * 审批通过 Agent
+   */
+  static async approveAgent(agentId: string): Promise<AgentApproval> {
+    return this.post<AgentApproval>(
+      `/pending/${encodeURIComponent(agentId)}/approve`,
+    );
+  }
+
+  /**
+   * 获取拒绝列表
+   */
+  static async listDenyRules(): Promise<AgentDenyRule[]> {
+    return this.get<AgentDenyRule[]>('/deny-list');
+  }
+
+  /**
+   * 新增拒绝规则
+   */
+  static async addDenyRule(
+    data: CreateAgentDenyRuleRequest,
+  ): Promise<AgentDenyRule> {
+    return this.post<AgentDenyRule>('/deny-list', data);
+  }
+
+  /**
+   * 删除拒绝规则
+   */
+  static async removeDenyRule(id: number): Promise<void> {
+    return this.delete(`/deny-list/${id}`);
+  }
+}
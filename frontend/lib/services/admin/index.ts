@@ -4,3 +4,5 @@
  */
 
 export * from './user.service';
+export * from './agent-admission.service';
+export * from './maintenance-script.service';
@@ -0,0 +1,84 @@
+/**
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+
+import {BaseService} from '../core/base.service';
+import type {CommandLogInfo} from '../audit/types';
+
+/**
+ * 运维脚本参数类型
+ */
+export type ScriptArgType = 'string' | 'int' | 'bool' | 'enum' | 'install_dir';
+
+/**
+ * 运维脚本参数定义
+ */
+export interface ScriptArgSpec {
+  name: string;
+  type: ScriptArgType;
+  description: string;
+  required: boolean;
+  default?: string;
+  enum?: string[];
+  min?: number;
+  max?: number;
+}
+
+/**
+ * 白名单运维脚本
+ */
+export interface MaintenanceScript {
+  name: string;
+  description: string;
+  args: ScriptArgSpec[];
+  timeout_seconds: number;
+}
+
+/**
+ * 执行运维脚本请求
+ * install_dir 类型的参数由服务端根据 cluster_id（及 role）对应的集群节点解析，不能手动传入
+ */
+export interface ExecScriptRequest {
+  script: string;
+  args?: Record<string, string>;
+  cluster_id?: number;
+  role?: string;
+}
+
+/**
+ * 运维脚本服务（仅管理员）
+ */
+export class MaintenanceScriptService extends BaseService {
+  protected static readonly basePath = '/admin';
+
+  /**
+   * 获取白名单运维脚本列表
+   */
+  static async listScripts(): Promise<MaintenanceScript[]> {
+    return this.get<MaintenanceScript[]>('/scripts');
+  }
+
+  /**
+   * 在主机上执行运维脚本，返回包含完整输出的命令日志
+   */
+  static async execScript(
+    hostId: number,
+    data: ExecScriptRequest,
+  ): Promise<CommandLogInfo> {
+    return this.post<CommandLogInfo>(`/hosts/${hostId}/exec-script`, data);
+  }
+}
@@ -17,6 +17,11 @@
 
 import {BaseService} from '../core/base.service';
 
+/**
+ * 用户角色
+ */
+export type UserRole = 'admin' | 'operator' | 'viewer';
+
 /**
  * 用户信息
  */
@@ -28,6 +33,7 @@ export interface UserInfo {
   avatar_url?: string;
   is_active: boolean;
   is_admin: boolean;
+  role: UserRole;
   last_login_at: string;
   created_at: string;
 }
@@ -60,6 +66,7 @@ export interface CreateUserRequest {
   nickname?: string;
   email?: string;
   is_admin?: boolean;
+  role?: UserRole;
 }
 
 /**
@@ -71,6 +78,18 @@ export interface UpdateUserRequest {
   email?: string;
   is_active?: boolean;
   is_admin?: boolean;
+  role?: UserRole;
+}
+
+/**
+ * 资源级角色绑定
+ */
+export interface RoleBinding {
+  id?: number;
+  user_id?: number;
+  resource_type: 'cluster' | 'project';
+  resource_id: number;
+  role: UserRole;
 }
 
 /**
@@ -129,4 +148,21 @@ export class AdminUserService extends BaseService {
   static async deleteUser(id: number): Promise<void> {
     return this.delete(`/${id}`);
   }
+
+  /**
+   * 获取用户的资源级角色绑定
+   */
+  static async listRoleBindings(id: number): Promise<RoleBinding[]> {
+    return this.get<RoleBinding[]>(`/${id}/role-bindings`);
+  }
+
+  /**
+   * 整体替换用户的资源级角色绑定
+   */
+  static async replaceRoleBindings(
+    id: number,
+    bindings: RoleBinding[],
+  ): Promise<RoleBinding[]> {
+    return this.put<RoleBinding[]>(`/${id}/role-bindings`, {bindings});
+  }
 }
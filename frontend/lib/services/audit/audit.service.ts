@@ -34,8 +34,14 @@ import {
   ListAuditLogsRequest,
   ListCommandLogsResponse,
   GetCommandLogResponse,
+  CommandOutput,
+  GetCommandOutputRequest,
+  GetCommandOutputResponse,
   ListAuditLogsResponse,
   GetAuditLogResponse,
+  BatchCommandRequest,
+  BatchCommandResult,
+  BatchCommandResponse,
 } from './types';
 
 /**
@@ -111,6 +117,60 @@ export class AuditService extends BaseService {
     return response.data.data;
   }
 
+  /**
+   * Get a window of command console output
+   * 获取命令控制台输出窗口
+   *
+   * @param logId - Command log ID / 命令日志 ID
+   * @param params - Offset or tail lines / 起始偏移或末尾行数
+   * @returns Command output window / 命令输出窗口
+   */
+  static async getCommandOutput(
+    logId: number,
+    params: GetCommandOutputRequest = {},
+  ): Promise<CommandOutput> {
+    const response = await apiClient.get<GetCommandOutputResponse>(
+      `${this.commandsPath}/${logId}/output`,
+      {params},
+    );
+
+    if (response.data.error_msg) {
+      throw new Error(response.data.error_msg);
+    }
+
+    return response.data.data;
+  }
+
+  /**
+   * Get the SSE url that follows command output from an offset
+   * 获取从指定偏移开始跟随命令输出的 SSE 地址
+   */
+  static getCommandOutputStreamUrl(logId: number, offset: number): string {
+    return `/api/v1${this.commandsPath}/${logId}/output?follow=true&offset=${offset}`;
+  }
+
+  /**
+   * Run a command on many hosts and wait for the aggregated result
+   * 在多台主机上执行命令并等待汇总结果
+   *
+   * @param request - Batch command request / 批量命令请求
+   * @returns Per-host results / 各主机执行结果
+   */
+  static async executeBatchCommand(
+    request: BatchCommandRequest,
+  ): Promise<BatchCommandResult> {
+    const response = await apiClient.post<BatchCommandResponse>(
+      `${this.commandsPath}/batch`,
+      request,
+    );
+
+    if (response.data.error_msg) {
+      throw new Error(response.data.error_msg);
+    }
+
+    return response.data.data;
+  }
+
   // ==================== Audit Log Methods 审计日志方法 ====================
 
   /**
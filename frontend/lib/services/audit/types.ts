@@ -40,6 +40,8 @@ export enum CommandStatus {
   FAILED = 'failed',
   /** Cancelled / 已取消 */
   CANCELLED = 'cancelled',
+  /** Past its deadline or stopped reporting progress / 超过截止时间或停止上报进度 */
+  TIMEOUT = 'timeout',
 }
 
 /**
@@ -89,6 +91,40 @@ export interface CommandLogInfo {
   created_by: number | null;
 }
 
+/**
+ * A window of command console output for tail/follow views
+ * 命令控制台输出窗口，用于 tail/follow 视图
+ */
+export interface CommandOutput {
+  /** Unique command ID / 唯一命令 ID */
+  command_id: string;
+  /** Execution status / 执行状态 */
+  status: CommandStatus;
+  /** Execution progress (0-100) / 执行进度 */
+  progress: number;
+  /** Byte offset of output / 输出的字节偏移 */
+  offset: number;
+  /** Offset to read what follows / 读取后续内容的偏移 */
+  next_offset: number;
+  /** Total output size in bytes / 输出总字节数 */
+  size: number;
+  /** Output in this window / 当前窗口的输出 */
+  output: string;
+  /** Whether the command finished and all output was read / 命令是否结束且输出已读完 */
+  finished: boolean;
+}
+
+/**
+ * Get command output request parameters
+ * 获取命令输出请求参数
+ */
+export interface GetCommandOutputRequest {
+  /** Byte offset to read from / 读取起始字节偏移 */
+  offset?: number;
+  /** Number of trailing lines when offset is omitted / 未指定 offset 时返回的末尾行数 */
+  tail?: number;
+}
+
 /**
  * Audit log information returned from API
  * API 返回的审计日志信息
@@ -116,6 +152,8 @@ export interface AuditLogInfo {
   ip_address: string;
   /** User agent string / 用户代理字符串 */
   user_agent: string;
+  /** Correlated request ID, empty for legacy / 关联的请求 ID */
+  request_id?: string;
   /** Creation time / 创建时间 */
   created_at: string;
 }
@@ -167,6 +205,8 @@ export interface ListAuditLogsRequest {
   resource_id?: string;
   /** Filter by trigger: auto | manual / 按触发方式过滤 */
   trigger?: string;
+  /** Filter by request ID / 按请求 ID 过滤 */
+  request_id?: string;
   /** Filter by start time (RFC3339 format) / 按开始时间过滤（RFC3339 格式） */
   start_time?: string;
   /** Filter by end time (RFC3339 format) / 按结束时间过滤（RFC3339 格式） */
@@ -195,6 +235,55 @@ export interface AuditLogListData {
   logs: AuditLogInfo[];
 }
 
+/**
+ * Batch command request, hosts are selected by host_ids, label_selector or both
+ * 批量命令请求，通过 host_ids、label_selector 或两者选择主机
+ */
+export interface BatchCommandRequest {
+  /** Host IDs / 主机 ID 列表 */
+  host_ids?: number[];
+  /** Label selector, e.g. "zone=az1" / 标签选择器，例如 "zone=az1" */
+  label_selector?: string;
+  /** Command type, e.g. STATUS, COLLECT_LOGS, RESTART / 命令类型 */
+  command_type: string;
+  /** Command parameters / 命令参数 */
+  params?: Record<string, string>;
+  /** Hosts commanded in parallel / 并行执行的主机数 */
+  concurrency?: number;
+  /** Per-host timeout in seconds / 单台主机超时时间（秒） */
+  timeout_seconds?: number;
+}
+
+/**
+ * Batch command result of one host
+ * 批量命令在单台主机上的结果
+ */
+export interface BatchHostResult {
+  host_id: number;
+  host_name: string;
+  agent_id?: string;
+  /** success, failed, timeout, cancelled / 执行状态 */
+  status: string;
+  success: boolean;
+  output?: string;
+  error?: string;
+  error_code?: string;
+  duration_ms: number;
+}
+
+/**
+ * Aggregated batch command result
+ * 批量命令汇总结果
+ */
+export interface BatchCommandResult {
+  command_type: string;
+  total: number;
+  succeeded: number;
+  failed: number;
+  duration_ms: number;
+  results: BatchHostResult[];
+}
+
 /**
  * Backend response structure
  * 后端响应结构
@@ -218,6 +307,12 @@ export type ListCommandLogsResponse = BackendResponse<CommandLogListData>;
  */
 export type GetCommandLogResponse = BackendResponse<CommandLogInfo>;
 
+/**
+ * Get command output response type
+ * 获取命令输出响应类型
+ */
+export type GetCommandOutputResponse = BackendResponse<CommandOutput>;
+
 /**
  * List audit logs response type
  * 获取审计日志列表响应类型
@@ -229,3 +324,9 @@ export type ListAuditLogsResponse = BackendResponse<AuditLogListData>;
  * 获取审计日志详情响应类型
  */
 export type GetAuditLogResponse = BackendResponse<AuditLogInfo>;
+
+/**
+ * Batch command response type
+ * 批量命令响应类型
+ */
+export type BatchCommandResponse = BackendResponse<BatchCommandResult>;
@@ -174,7 +174,7 @@ export class AuthService extends BaseService {
   /**
    * 获取OAuth登录URL
    * 注意：OAuth使用不同的基础路径，需要绕过basePath
-   * @param provider - OAuth提供商（如 'github', 'google'）
+   * @param provider - OAuth提供商（如 'github', 'google' 或配置的 OIDC 提供商名称）
    * @returns 登录授权URL
    */
   static async getOAuthLoginURL(provider?: string): Promise<string> {
@@ -199,7 +199,7 @@ export class AuthService extends BaseService {
 
   /**
    * 执行OAuth登录流程
-   * @param provider - OAuth提供商（如 'github', 'google'）
+   * @param provider - OAuth提供商（如 'github', 'google' 或配置的 OIDC 提供商名称）
    * @param redirectTo - 登录成功后重定向的页面路径
    */
   static async loginWithOAuth(
@@ -45,6 +45,8 @@ export interface LoginResponseData {
   avatar_url?: string;
   /** 是否管理员 */
   is_admin: boolean;
+  /** 全局角色 */
+  role?: 'admin' | 'operator' | 'viewer';
   /** 是否激活 */
   is_active?: boolean;
 }
@@ -39,12 +39,23 @@ import {
   UpdateNodeRequest,
   PrecheckRequest,
   PrecheckResult,
+  ClusterPrecheckResult,
   ListClustersRequest,
   ListClustersResponse,
   CreateClusterResponse,
+  AdoptClusterRequest,
+  AdoptClusterResponse,
+  AdoptClusterResult,
+  ClusterMetrics,
+  GetClusterMetricsResponse,
   GetClusterResponse,
   UpdateClusterResponse,
   DeleteClusterResponse,
+  TeardownReport,
+  RecycledCluster,
+  ListRecycledClustersResponse,
+  RestoreClusterResponse,
+  PurgeClusterResponse,
   GetNodesResponse,
   AddNodeResponse,
   AddNodesResponse,
@@ -59,6 +70,7 @@ import {
   InspectCheckpointRuntimeStorageResponse,
   InspectIMAPRuntimeStorageResponse,
   PrecheckNodeResponse,
+  ClusterPrecheckResponse,
   RuntimeStorageDetails,
   RuntimeStorageCleanupResult,
   RuntimeStorageValidationResult,
@@ -71,6 +83,22 @@ import {
   SeatunnelXJavaProxyLogPreviewResult,
   SeatunnelXJavaProxyResponse,
   SeatunnelXJavaProxyStatus,
+  ScaleClusterRequest,
+  ScaleTask,
+  DrainOptions,
+  ClusterOperationRequest,
+  ScaleTaskResponse,
+  ListScaleTasksResponse,
+  ClusterJVMConfig,
+  UpdateClusterJVMRequest,
+  JVMPreviewResult,
+  JVMUpdateResult,
+  GetClusterJVMResponse,
+  PreviewClusterJVMResponse,
+  UpdateClusterJVMResponse,
+  DiagnoseNodeRequest,
+  DiagnoseNodeResponse,
+  NodeDiagnosis,
 } from './types';
 
 /**
@@ -103,6 +131,10 @@ export class ClusterService extends BaseService {
         name: params.name,
         status: params.status,
         deployment_mode: params.deployment_mode,
+        host_id: params.host_id,
+        sort: params.sort,
+        start_time: params.start_time,
+        end_time: params.end_time,
       },
     });
 
@@ -152,6 +184,28 @@ export class ClusterService extends BaseService {
     return response.data.data;
   }
 
+  /**
+   * Adopt an externally installed cluster without reinstalling
+   * 在不重装的情况下纳管外部安装的集群
+   *
+   * @param data - Hosts and install dirs to adopt / 待纳管的主机和安装目录
+   * @returns Adopted cluster and per-node results / 纳管的集群及各节点结果
+   */
+  static async adoptCluster(
+    data: AdoptClusterRequest,
+  ): Promise<AdoptClusterResult> {
+    const response = await apiClient.post<AdoptClusterResponse>(
+      `${this.basePath}/adopt`,
+      data,
+    );
+
+    if (response.data.error_msg) {
+      throw new Error(response.data.error_msg);
+    }
+
+    return response.data.data;
+  }
+
   /**
    * Update an existing cluster
    * 更新现有集群
@@ -186,7 +240,7 @@ export class ClusterService extends BaseService {
   static async deleteCluster(
     clusterId: number,
     options?: {forceDelete?: boolean},
-  ): Promise<void> {
+  ): Promise<RecycledCluster> {
     const params =
       options?.forceDelete === true ? {force_delete: '1'} : undefined;
     const response = await apiClient.delete<DeleteClusterResponse>(
@@ -197,6 +251,57 @@ export class ClusterService extends BaseService {
     if (response.data.error_msg) {
       throw new Error(response.data.error_msg);
     }
+
+    return response.data.data;
+  }
+
+  /**
+   * List clusters in the recycle bin
+   * 列出回收站中的集群
+   */
+  static async listRecycledClusters(name?: string): Promise<RecycledCluster[]> {
+    const response = await apiClient.get<ListRecycledClustersResponse>(
+      `${this.basePath}/recycle-bin`,
+      {params: name ? {name} : undefined},
+    );
+
+    if (response.data.error_msg) {
+      throw new Error(response.data.error_msg);
+    }
+
+    return response.data.data || [];
+  }
+
+  /**
+   * Restore a cluster from the recycle bin
+   * 从回收站恢复集群
+   */
+  static async restoreCluster(clusterId: number): Promise<ClusterInfo> {
+    const response = await apiClient.post<RestoreClusterResponse>(
+      `${this.basePath}/${clusterId}/restore`,
+    );
+
+    if (response.data.error_msg) {
+      throw new Error(response.data.error_msg);
+    }
+
+    return response.data.data;
+  }
+
+  /**
+   * Tear down a recycled cluster without waiting for the retention window
+   * 不等待保留期，立即拆除回收站中的集群
+   */
+  static async purgeCluster(clusterId: number): Promise<TeardownReport> {
+    const response = await apiClient.delete<PurgeClusterResponse>(
+      `${this.basePath}/recycle-bin/${clusterId}`,
+    );
+
+    if (response.data.error_msg) {
+      throw new Error(response.data.error_msg);
+    }
+
+    return response.data.data;
   }
 
   // ==================== Node Management Methods 节点管理方法 ====================
@@ -331,6 +436,185 @@ export class ClusterService extends BaseService {
     return response.data.data;
   }
 
+  /**
+   * Check member connectivity and clock skew across the cluster
+   * 检查集群成员间连通性与时钟偏差
+   *
+   * @param clusterId - Cluster ID / 集群 ID
+   * @returns Connectivity matrix and clock skew result / 连通性矩阵与时钟偏差结果
+   */
+  static async precheckCluster(
+    clusterId: number,
+  ): Promise<ClusterPrecheckResult> {
+    const response = await apiClient.post<ClusterPrecheckResponse>(
+      `${this.basePath}/${clusterId}/precheck`,
+    );
+
+    if (response.data.error_msg) {
+      throw new Error(response.data.error_msg);
+    }
+
+    return response.data.data;
+  }
+
+  // ==================== Scale Methods 扩缩容方法 ====================
+
+  /**
+   * Scale a running cluster (add/remove nodes with rolling restart)
+   * 扩缩容运行中的集群（添加/移除节点并滚动重启）
+   *
+   * @param clusterId - Cluster ID / 集群 ID
+   * @param data - Scale request / 扩缩容请求
+   * @returns Created scale task / 创建的扩缩容任务
+   */
+  static async scaleCluster(
+    clusterId: number,
+    data: ScaleClusterRequest,
+  ): Promise<ScaleTask> {
+    const response = await apiClient.post<ScaleTaskResponse>(
+      `${this.basePath}/${clusterId}/scale`,
+      data,
+    );
+
+    if (response.data.error_msg) {
+      throw new Error(response.data.error_msg);
+    }
+
+    return response.data.data;
+  }
+
+  /**
+   * Decommission a node: drain its jobs, stop it and remove it from the cluster
+   * 下线节点：排空其作业、停止并从集群移除
+   *
+   * @param clusterId - Cluster ID / 集群 ID
+   * @param nodeId - Node ID / 节点 ID
+   * @param options - Drain options / 排空参数
+   * @returns Created scale task / 创建的扩缩容任务
+   */
+  static async decommissionNode(
+    clusterId: number,
+    nodeId: number,
+    options?: DrainOptions,
+  ): Promise<ScaleTask> {
+    const response = await apiClient.post<ScaleTaskResponse>(
+      `${this.basePath}/${clusterId}/nodes/${nodeId}/decommission`,
+      options || {},
+    );
+
+    if (response.data.error_msg) {
+      throw new Error(response.data.error_msg);
+    }
+
+    return response.data.data;
+  }
+
+  /**
+   * List recent scale tasks of a cluster
+   * 获取集群最近的扩缩容任务
+   *
+   * @param clusterId - Cluster ID / 集群 ID
+   */
+  static async listScaleTasks(clusterId: number): Promise<ScaleTask[]> {
+    const response = await apiClient.get<ListScaleTasksResponse>(
+      `${this.basePath}/${clusterId}/scale/tasks`,
+    );
+
+    if (response.data.error_msg) {
+      throw new Error(response.data.error_msg);
+    }
+
+    return response.data.data || [];
+  }
+
+  /**
+   * Get a scale task with its log
+   * 获取扩缩容任务及其日志
+   *
+   * @param clusterId - Cluster ID / 集群 ID
+   * @param taskId - Scale task ID / 扩缩容任务 ID
+   */
+  static async getScaleTask(
+    clusterId: number,
+    taskId: number,
+  ): Promise<ScaleTask> {
+    const response = await apiClient.get<ScaleTaskResponse>(
+      `${this.basePath}/${clusterId}/scale/tasks/${taskId}`,
+    );
+
+    if (response.data.error_msg) {
+      throw new Error(response.data.error_msg);
+    }
+
+    return response.data.data;
+  }
+
+  // ==================== JVM Methods JVM 调优方法 ====================
+
+  /**
+   * Get cluster-level JVM settings
+   * 获取集群级 JVM 配置
+   *
+   * @param clusterId - Cluster ID / 集群 ID
+   */
+  static async getClusterJVM(clusterId: number): Promise<ClusterJVMConfig> {
+    const response = await apiClient.get<GetClusterJVMResponse>(
+      `${this.basePath}/${clusterId}/jvm`,
+    );
+
+    if (response.data.error_msg) {
+      throw new Error(response.data.error_msg);
+    }
+
+    return response.data.data || {};
+  }
+
+  /**
+   * Preview the jvm_options of each node with the given settings
+   * 使用给定配置预览各节点的 jvm_options
+   *
+   * @param clusterId - Cluster ID / 集群 ID
+   * @param jvm - JVM settings / JVM 配置
+   */
+  static async previewClusterJVM(
+    clusterId: number,
+    jvm: ClusterJVMConfig,
+  ): Promise<JVMPreviewResult> {
+    const response = await apiClient.post<PreviewClusterJVMResponse>(
+      `${this.basePath}/${clusterId}/jvm/preview`,
+      jvm,
+    );
+
+    if (response.data.error_msg) {
+      throw new Error(response.data.error_msg);
+    }
+
+    return response.data.data;
+  }
+
+  /**
+   * Update cluster JVM settings and schedule a rolling restart
+   * 更新集群 JVM 配置并调度滚动重启
+   *
+   * @param clusterId - Cluster ID / 集群 ID
+   * @param data - Update request / 更新请求
+   */
+  static async updateClusterJVM(
+    clusterId: number,
+    data: UpdateClusterJVMRequest,
+  ): Promise<JVMUpdateResult> {
+    const response = await apiClient.put<UpdateClusterJVMResponse>(
+      `${this.basePath}/${clusterId}/jvm`,
+      data,
+    );
+
+    if (response.data.error_msg) {
+      throw new Error(response.data.error_msg);
+    }
+
+    return response.data.data;
+  }
+
   // ==================== Cluster Operation Methods 集群操作方法 ====================
 
   /**
@@ -376,11 +660,16 @@ export class ClusterService extends BaseService {
    * 重启集群
    *
    * @param clusterId - Cluster ID / 集群 ID
+   * @param options - Optional restart mode / 可选的重启模式
    * @returns Operation result / 操作结果
    */
-  static async restartCluster(clusterId: number): Promise<OperationResult> {
+  static async restartCluster(
+    clusterId: number,
+    options?: ClusterOperationRequest,
+  ): Promise<OperationResult> {
     const response = await apiClient.post<ClusterOperationResponse>(
       `${this.basePath}/${clusterId}/restart`,
+      options,
     );
 
     if (response.data.error_msg) {
@@ -409,6 +698,30 @@ export class ClusterService extends BaseService {
     return response.data.data;
   }
 
+  /**
+   * Get cluster overview metrics
+   * 获取集群概览指标
+   *
+   * @param clusterId - Cluster ID / 集群 ID
+   * @param since - RFC3339 start of the history, default last hour / 历史起始时间，默认最近一小时
+   * @returns Latest snapshot and history / 最新快照及历史
+   */
+  static async getClusterMetrics(
+    clusterId: number,
+    since?: string,
+  ): Promise<ClusterMetrics> {
+    const response = await apiClient.get<GetClusterMetricsResponse>(
+      `${this.basePath}/${clusterId}/metrics`,
+      {params: since ? {since} : undefined},
+    );
+
+    if (response.data.error_msg) {
+      throw new Error(response.data.error_msg);
+    }
+
+    return response.data.data;
+  }
+
   /**
    * Get runtime storage details
    * 获取运行时存储详情
@@ -750,11 +1063,12 @@ export class ClusterService extends BaseService {
     options?: {forceDelete?: boolean},
   ): Promise<{
     success: boolean;
+    data?: RecycledCluster;
     error?: string;
   }> {
     try {
-      await this.deleteCluster(clusterId, options);
-      return {success: true};
+      const data = await this.deleteCluster(clusterId, options);
+      return {success: true, data};
     } catch (error) {
       const errorMessage =
         error instanceof Error ? error.message : '删除集群失败';
@@ -1278,6 +1592,25 @@ export class ClusterService extends BaseService {
     return response.data.data;
   }
 
+  /**
+   * Diagnose node JVM: thread dump, heap info or class histogram
+   * 诊断节点 JVM：线程栈、堆信息或类直方图
+   */
+  static async diagnoseNode(
+    clusterId: number,
+    nodeId: number,
+    data?: DiagnoseNodeRequest,
+  ): Promise<NodeDiagnosis> {
+    const response = await apiClient.post<DiagnoseNodeResponse>(
+      `${this.basePath}/${clusterId}/nodes/${nodeId}/diagnose`,
+      data ?? {},
+    );
+    if (response.data.error_msg) {
+      throw new Error(response.data.error_msg);
+    }
+    return response.data.data;
+  }
+
   /**
    * Start node (with error handling)
    * 启动节点（带错误处理）
@@ -1378,4 +1711,27 @@ export class ClusterService extends BaseService {
       return {success: false, error: errorMessage};
     }
   }
+
+  /**
+   * Diagnose node JVM (with error handling)
+   * 诊断节点 JVM（带错误处理）
+   */
+  static async diagnoseNodeSafe(
+    clusterId: number,
+    nodeId: number,
+    data?: DiagnoseNodeRequest,
+  ): Promise<{
+    success: boolean;
+    data?: NodeDiagnosis;
+    error?: string;
+  }> {
+    try {
+      const result = await this.diagnoseNode(clusterId, nodeId, data);
+      return {success: true, data: result};
+    } catch (error) {
+      const errorMessage =
+        error instanceof Error ? error.message : '节点诊断失败';
+      return {success: false, error: errorMessage};
+    }
+  }
 }
@@ -83,6 +83,8 @@ export enum NodeStatus {
   ERROR = 'error',
   /** Host/agent is offline; display-only / 主机或 Agent 离线，仅展示用 */
   OFFLINE = 'offline',
+  /** Node is being decommissioned / 节点正在下线排空 */
+  DRAINING = 'draining',
 }
 
 /**
@@ -125,6 +127,11 @@ export interface ClusterJVMConfig {
   hybrid_heap_size?: number;
   master_heap_size?: number;
   worker_heap_size?: number;
+  /** GC collector: g1, zgc, parallel, shenandoah, serial / GC 收集器 */
+  gc?: string;
+  max_direct_memory_mb?: number;
+  /** Additional JVM options, one per entry / 额外 JVM 参数，每项一个 */
+  extra_options?: string[];
 }
 
 /**
@@ -172,10 +179,22 @@ export interface ClusterInfo {
   version: string;
   /** Cluster status / 集群状态 */
   status: ClusterStatus;
+  /**
+   * How the cluster came under management: installed by SeaTunnelX or adopted
+   * 集群纳入管理的方式：由 SeaTunnelX 安装或纳管已有集群
+   */
+  source?: 'installed' | 'adopted';
   /** Installation directory / 安装目录 */
   install_dir: string;
   /** Cluster configuration / 集群配置 */
   config: ClusterConfig;
+  /**
+   * Label selectors constraining host placement per role
+   * 各角色主机放置的标签选择器
+   */
+  node_selectors?: Partial<Record<NodeRole, string>>;
+  /** Owning project, 0 for shared / 所属项目，0 表示共享 */
+  project_id?: number;
   /** Number of nodes / 节点数量 */
   node_count: number;
   /** Number of nodes whose host is online / 主机在线的节点数 */
@@ -252,6 +271,10 @@ export interface NodeStatusInfo {
   process_pid: number;
   /** Process status / 进程状态 */
   process_status: string;
+  /** Whether node config files drifted / 节点配置文件是否漂移 */
+  config_drifted?: boolean;
+  /** Drifted config types / 已漂移的配置类型 */
+  drifted_configs?: string[];
 }
 
 /**
@@ -273,6 +296,8 @@ export interface ClusterStatusInfo {
   online_nodes: number;
   /** Number of offline nodes / 离线节点数 */
   offline_nodes: number;
+  /** Nodes with drifted config / 配置漂移的节点数 */
+  drifted_nodes?: number;
   /** Node status list / 节点状态列表 */
   nodes: NodeStatusInfo[];
 }
@@ -460,6 +485,23 @@ export interface NodeOperationResult {
   message: string;
 }
 
+/**
+ * Ordered phase of a separated-mode cluster operation
+ * 分离模式集群操作的有序阶段
+ */
+export interface OperationPhaseResult {
+  /** Phase name / 阶段名称 */
+  phase: 'masters' | 'master_quorum' | 'workers';
+  /** Whether the phase succeeded / 阶段是否成功 */
+  success: boolean;
+  /** Whether the phase was skipped / 阶段是否被跳过 */
+  skipped?: boolean;
+  /** Result message / 结果消息 */
+  message: string;
+  /** Nodes of the phase / 阶段涉及的节点 */
+  node_ids: number[];
+}
+
 /**
  * Cluster operation result
  * 集群操作结果
@@ -475,6 +517,8 @@ export interface OperationResult {
   message: string;
   /** Node operation results / 节点操作结果 */
   node_results: NodeOperationResult[];
+  /** Phases of a separated-mode operation / 分离模式操作的阶段 */
+  phases?: OperationPhaseResult[];
 }
 
 /**
@@ -494,6 +538,13 @@ export interface CreateClusterRequest {
   install_dir?: string;
   /** Cluster configuration / 集群配置 */
   config?: ClusterConfig;
+  /**
+   * Label selectors constraining host placement per role
+   * 各角色主机放置的标签选择器
+   */
+  node_selectors?: Partial<Record<NodeRole, string>>;
+  /** Owning project, 0 for shared / 所属项目，0 表示共享 */
+  project_id?: number;
   /** Initial nodes to add (from discovery) / 初始节点（来自发现） */
   nodes?: Array<{
     host_id: number;
@@ -504,6 +555,46 @@ export interface CreateClusterRequest {
   }>;
 }
 
+/**
+ * Request to adopt an externally installed cluster without reinstalling
+ * 在不重装的情况下纳管外部安装集群的请求
+ */
+export interface AdoptClusterRequest {
+  /** Cluster name (required) / 集群名称（必填） */
+  name: string;
+  /** Description / 描述 */
+  description?: string;
+  /** Default install dir for nodes / 节点默认安装目录 */
+  install_dir?: string;
+  /** Existing installations to adopt / 待纳管的已有安装 */
+  nodes: Array<{
+    host_id: number;
+    install_dir?: string;
+    /** Only needed for stopped nodes of separated installs / 仅分离模式下未运行的节点需要 */
+    role?: NodeRole;
+  }>;
+}
+
+/** Per-node adoption result / 单节点纳管结果 */
+export interface AdoptedNode {
+  node_id: number;
+  host_id: number;
+  host_name: string;
+  role: NodeRole;
+  install_dir: string;
+  status: NodeStatus;
+  process_pid?: number;
+  config_files: string[];
+  configs_imported: boolean;
+  warning?: string;
+}
+
+/** Cluster adoption result / 集群纳管结果 */
+export interface AdoptClusterResult {
+  cluster: ClusterInfo;
+  nodes: AdoptedNode[];
+}
+
 /**
  * Request to update an existing cluster
  * 更新现有集群的请求
@@ -519,6 +610,11 @@ export interface UpdateClusterRequest {
   install_dir?: string;
   /** Cluster configuration / 集群配置 */
   config?: ClusterConfig;
+  /**
+   * Label selectors constraining host placement per role
+   * 各角色主机放置的标签选择器
+   */
+  node_selectors?: Partial<Record<NodeRole, string>>;
 }
 
 /**
@@ -649,11 +745,21 @@ export function getClusterJVMConfig(
     hybrid_heap_size: toNumberOrUndefined(jvm.hybrid_heap_size),
     master_heap_size: toNumberOrUndefined(jvm.master_heap_size),
     worker_heap_size: toNumberOrUndefined(jvm.worker_heap_size),
+    gc: typeof jvm.gc === 'string' && jvm.gc ? jvm.gc : undefined,
+    max_direct_memory_mb: toNumberOrUndefined(jvm.max_direct_memory_mb),
+    extra_options: Array.isArray(jvm.extra_options)
+      ? jvm.extra_options.filter(
+          (option): option is string => typeof option === 'string',
+        )
+      : undefined,
   };
   if (
     result.hybrid_heap_size === undefined &&
     result.master_heap_size === undefined &&
-    result.worker_heap_size === undefined
+    result.worker_heap_size === undefined &&
+    result.gc === undefined &&
+    result.max_direct_memory_mb === undefined &&
+    !result.extra_options?.length
   ) {
     return undefined;
   }
@@ -800,6 +906,14 @@ export interface ListClustersRequest {
   status?: ClusterStatus;
   /** Filter by deployment mode / 按部署模式过滤 */
   deployment_mode?: DeploymentMode;
+  /** Filter by node host / 按节点所在主机过滤 */
+  host_id?: number;
+  /** Sort fields, e.g. "-created_at,name" / 排序字段，例如 "-created_at,name" */
+  sort?: string;
+  /** Created at or after (RFC3339 or YYYY-MM-DD) / 创建时间下限 */
+  start_time?: string;
+  /** Created at or before (RFC3339 or YYYY-MM-DD) / 创建时间上限 */
+  end_time?: string;
 }
 
 /**
@@ -838,8 +952,62 @@ export type GetClusterResponse = BackendResponse<ClusterInfo>;
 /** Update cluster response type / 更新集群响应类型 */
 export type UpdateClusterResponse = BackendResponse<ClusterInfo>;
 
-/** Delete cluster response type / 删除集群响应类型 */
-export type DeleteClusterResponse = BackendResponse<null>;
+/** Teardown step status / 拆除步骤状态 */
+export type TeardownStepStatus = 'success' | 'failed' | 'skipped';
+
+/** Teardown step result / 拆除步骤结果 */
+export interface TeardownStep {
+  status: TeardownStepStatus;
+  message?: string;
+}
+
+/** Per-node teardown result / 单节点拆除结果 */
+export interface NodeTeardownResult {
+  node_id: number;
+  host_id: number;
+  host_name?: string;
+  agent_id?: string;
+  role: NodeRole;
+  install_dir: string;
+  stop: TeardownStep;
+  removed_files?: string[];
+  removed_units?: string[];
+  remove_install_dir: TeardownStep;
+}
+
+/** Cluster teardown report / 集群拆除报告 */
+export interface TeardownReport {
+  cluster_id: number;
+  cluster_name: string;
+  remove_install_dir: boolean;
+  nodes: NodeTeardownResult[];
+  released_plugins: number;
+  plugin_release_error?: string;
+  started_at: string;
+  finished_at: string;
+}
+
+/** Adopt cluster response type / 纳管集群响应类型 */
+export type AdoptClusterResponse = BackendResponse<AdoptClusterResult>;
+
+/** Cluster in the recycle bin / 回收站中的集群 */
+export interface RecycledCluster extends ClusterInfo {
+  deleted_at: string;
+  purge_at: string;
+  remove_install_dir: boolean;
+}
+
+/** Delete cluster response type, the cluster is moved to the recycle bin / 删除集群响应类型，集群被移入回收站 */
+export type DeleteClusterResponse = BackendResponse<RecycledCluster>;
+
+/** List recycled clusters response type / 列出回收站集群响应类型 */
+export type ListRecycledClustersResponse = BackendResponse<RecycledCluster[]>;
+
+/** Restore cluster response type / 恢复集群响应类型 */
+export type RestoreClusterResponse = BackendResponse<ClusterInfo>;
+
+/** Purge recycled cluster response type / 清理回收站集群响应类型 */
+export type PurgeClusterResponse = BackendResponse<TeardownReport>;
 
 /** Get nodes response type / 获取节点列表响应类型 */
 export type GetNodesResponse = BackendResponse<NodeInfo[]>;
@@ -910,8 +1078,8 @@ export interface PrecheckRequest {
 export interface PrecheckCheckItem {
   /** Check name / 检查名称 */
   name: string;
-  /** Check status: passed, failed, skipped / 检查状态：通过、失败、跳过 */
-  status: 'passed' | 'failed' | 'skipped';
+  /** Check status: passed, warning, failed, skipped / 检查状态：通过、警告、失败、跳过 */
+  status: 'passed' | 'warning' | 'failed' | 'skipped';
   /** Detail message / 详细信息 */
   message: string;
 }
@@ -932,5 +1100,288 @@ export interface PrecheckResult {
 /** Precheck node response type / 节点预检查响应类型 */
 export type PrecheckNodeResponse = BackendResponse<PrecheckResult>;
 
+/**
+ * Member of the cluster connectivity matrix
+ * 集群连通性矩阵中的成员
+ */
+export interface ClusterPrecheckNode {
+  node_id: number;
+  host_id: number;
+  host_name: string;
+  role: NodeRole;
+  /** Hazelcast member address host:port / Hazelcast 成员地址 host:port */
+  address: string;
+  agent_online: boolean;
+  /** Node clock minus Control Plane clock / 节点时钟减去 Control Plane 时钟 */
+  clock_skew_ms?: number;
+  clock_status: PrecheckCheckItem['status'];
+  message?: string;
+}
+
+/**
+ * Probe from one member to another
+ * 一个成员到另一个成员的探测结果
+ */
+export interface ConnectivityCell {
+  status: PrecheckCheckItem['status'];
+  reachable: boolean;
+  listening: boolean;
+  latency_ms: number;
+  error?: string;
+}
+
+/**
+ * Cluster connectivity and clock skew precheck result
+ * 集群连通性与时钟偏差预检查结果
+ */
+export interface ClusterPrecheckResult {
+  cluster_id: number;
+  success: boolean;
+  message: string;
+  checked_at: string;
+  nodes: ClusterPrecheckNode[];
+  /** matrix[i][j] is the probe from nodes[i] to nodes[j] / matrix[i][j] 为 nodes[i] 到 nodes[j] 的探测 */
+  matrix: ConnectivityCell[][];
+  checks: PrecheckCheckItem[];
+}
+
+/** Cluster precheck response type / 集群预检查响应类型 */
+export type ClusterPrecheckResponse = BackendResponse<ClusterPrecheckResult>;
+
 /** Update node response type / 更新节点响应类型 */
 export type UpdateNodeResponse = BackendResponse<NodeInfo>;
+
+/**
+ * Node to add when scaling a cluster
+ * 扩容时要添加的节点
+ */
+export interface ScaleNodeRequest {
+  /** Host ID (required) / 主机 ID（必填） */
+  host_id: number;
+  /** Node role, defaults to worker / 节点角色，默认 worker */
+  role?: NodeRole;
+  /** SeaTunnel installation directory / SeaTunnel 安装目录 */
+  install_dir?: string;
+  /** Hazelcast cluster port / Hazelcast 集群端口 */
+  hazelcast_port?: number;
+  /** REST API port / REST API 端口 */
+  api_port?: number;
+  /** Worker hazelcast port (Hybrid only) / Worker Hazelcast 端口（仅混合模式） */
+  worker_port?: number;
+}
+
+/**
+ * Request to scale a running cluster
+ * 运行中集群的扩缩容请求
+ */
+export interface ScaleClusterRequest {
+  /** Nodes to add / 要添加的节点 */
+  add_nodes?: ScaleNodeRequest[];
+  /** Node IDs to remove / 要移除的节点 ID */
+  remove_node_ids?: number[];
+  /** Install mode: online or offline / 安装模式：在线或离线 */
+  install_mode?: 'online' | 'offline';
+  /** Download mirror / 下载镜像源 */
+  mirror?: string;
+  /** Offline package path / 离线安装包路径 */
+  package_path?: string;
+  /** Package delivery: pushed by the Control Plane or downloaded by the Agent / 安装包分发：控制面推送或 Agent 直接下载 */
+  package_delivery?: 'push' | 'direct';
+  /** Drain removed nodes before stopping them / 停止被移除节点前先排空 */
+  drain?: DrainOptions;
+}
+
+/** Action for jobs still running at the drain deadline / 排空截止时仍在运行作业的处理方式 */
+export type DrainTimeoutAction = 'savepoint' | 'fail';
+
+/**
+ * Node drain options
+ * 节点排空参数
+ */
+export interface DrainOptions {
+  /** Wait for jobs to finish, default 600 / 等待作业结束的秒数，默认 600 */
+  timeout_seconds?: number;
+  /** Default savepoint / 默认 savepoint */
+  on_timeout?: DrainTimeoutAction;
+}
+
+/** Scale task status / 扩缩容任务状态 */
+export type ScaleTaskStatus = 'pending' | 'running' | 'success' | 'failed';
+
+/**
+ * Cluster scale task
+ * 集群扩缩容任务
+ */
+export interface ScaleTask {
+  id: number;
+  cluster_id: number;
+  status: ScaleTaskStatus;
+  /** Current step / 当前步骤 */
+  step: string;
+  request: ScaleClusterRequest;
+  /** Task log (only in detail) / 任务日志（仅详情返回） */
+  log?: string;
+  error?: string;
+  created_by: string;
+  started_at?: string;
+  finished_at?: string;
+  created_at: string;
+  updated_at: string;
+}
+
+/** Scale task response type / 扩缩容任务响应类型 */
+export type ScaleTaskResponse = BackendResponse<ScaleTask>;
+/** Scale task list response type / 扩缩容任务列表响应类型 */
+export type ListScaleTasksResponse = BackendResponse<ScaleTask[]>;
+
+/** Cluster operation mode / 集群操作模式 */
+export type OperationMode = 'parallel' | 'rolling';
+
+/**
+ * Rolling operation options
+ * 滚动操作参数
+ */
+export interface RollingOptions {
+  /** Nodes restarted at the same time, default 1 / 同时重启的节点数，默认 1 */
+  max_unavailable?: number;
+  /** Stop at the first failure, default true / 首次失败即中止，默认 true */
+  abort_on_failure?: boolean;
+  /** Rejoin wait per node, default 300 / 单节点重新入群等待秒数，默认 300 */
+  rejoin_timeout_seconds?: number;
+}
+
+/**
+ * Optional body of cluster restart
+ * 集群重启的可选请求体
+ */
+export interface ClusterOperationRequest {
+  mode?: OperationMode;
+  rolling?: RollingOptions;
+}
+
+/**
+ * Request of updating cluster JVM settings
+ * 更新集群 JVM 配置的请求
+ */
+export interface UpdateClusterJVMRequest {
+  jvm: ClusterJVMConfig;
+  /** Schedule a rolling restart, default true / 是否调度滚动重启，默认 true */
+  restart?: boolean;
+  rolling?: RollingOptions;
+}
+
+/** Effect of JVM settings on one node / JVM 配置对单个节点的影响 */
+export interface NodeJVMPreview {
+  node_id: number;
+  host_id: number;
+  role: NodeRole;
+  config_type: string;
+  current?: string;
+  rendered?: string;
+  changed: boolean;
+  effective_flags?: string[];
+  error?: string;
+}
+
+/** Per-node JVM preview / 各节点 JVM 预览 */
+export interface JVMPreviewResult {
+  cluster_id: number;
+  jvm: ClusterJVMConfig;
+  nodes: NodeJVMPreview[];
+}
+
+/** Result of updating cluster JVM settings / 更新集群 JVM 配置的结果 */
+export interface JVMUpdateResult extends JVMPreviewResult {
+  success: boolean;
+  restart_scheduled: boolean;
+}
+
+/** Cluster JVM response type / 集群 JVM 配置响应类型 */
+export type GetClusterJVMResponse = BackendResponse<ClusterJVMConfig>;
+/** JVM preview response type / JVM 预览响应类型 */
+export type PreviewClusterJVMResponse = BackendResponse<JVMPreviewResult>;
+/** JVM update response type / JVM 更新响应类型 */
+export type UpdateClusterJVMResponse = BackendResponse<JVMUpdateResult>;
+
+/** Per-node JVM metrics reported by the engine / 引擎上报的单节点 JVM 指标 */
+export interface NodeMetrics {
+  node_id: number;
+  host_id: number;
+  role: NodeRole;
+  address: string;
+  reachable: boolean;
+  error?: string;
+  processors: number;
+  heap_used_bytes: number;
+  heap_max_bytes: number;
+  heap_used_percent: number;
+  process_load_percent: number;
+  system_load_percent: number;
+  thread_count: number;
+  minor_gc_count: number;
+  major_gc_count: number;
+}
+
+/** One collected cluster metrics snapshot / 一次采集的集群指标快照 */
+export interface ClusterMetricsSnapshot {
+  id: number;
+  cluster_id: number;
+  /** REST API version used, v1 or v2 / 使用的 REST API 版本 */
+  api_mode: string;
+  total_slot: number;
+  unassigned_slot: number;
+  used_slot: number;
+  slot_utilization: number;
+  running_jobs: number;
+  worker_count: number;
+  nodes: NodeMetrics[];
+  error?: string;
+  collected_at: string;
+}
+
+/** Cluster metrics with history / 集群指标及历史 */
+export interface ClusterMetrics {
+  cluster_id: number;
+  latest: ClusterMetricsSnapshot | null;
+  history: ClusterMetricsSnapshot[];
+}
+
+/** Cluster metrics response type / 集群指标响应类型 */
+export type GetClusterMetricsResponse = BackendResponse<ClusterMetrics>;
+
+/** JVM diagnose action / JVM 诊断采集项 */
+export type DiagnoseAction = 'thread_dump' | 'heap_info' | 'heap_histogram';
+
+/** Diagnose node request / 节点诊断请求 */
+export interface DiagnoseNodeRequest {
+  /** Empty means thread dump and heap info / 为空时采集线程栈与堆信息 */
+  actions?: DiagnoseAction[];
+}
+
+/** Output of one diagnose action / 单个诊断采集项的输出 */
+export interface NodeDiagnosisSection {
+  action: DiagnoseAction;
+  tool?: string;
+  /** Full output path on the node / 节点上完整输出的路径 */
+  output_path?: string;
+  size_bytes: number;
+  /** Content only holds the head of the output / 内容仅包含输出开头部分 */
+  truncated: boolean;
+  content?: string;
+  error?: string;
+}
+
+/** JVM diagnosis collected from a node / 从节点采集的 JVM 诊断结果 */
+export interface NodeDiagnosis {
+  cluster_id: number;
+  node_id: number;
+  host_id: number;
+  pid: number;
+  role: string;
+  install_dir: string;
+  sections: NodeDiagnosisSection[];
+  collected_at: string;
+}
+
+/** Diagnose node response type / 节点诊断响应类型 */
+export type DiagnoseNodeResponse = BackendResponse<NodeDiagnosis>;
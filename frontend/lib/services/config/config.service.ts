@@ -42,6 +42,17 @@ import type {
   PromoteConfigResponse,
   SyncConfigResponse,
   NormalizeConfigResponse,
+  ApplyConfigRequest,
+  ApplyConfigResult,
+  ApplyConfigResponse,
+  ConfigDriftInfo,
+  ConfigDriftResponse,
+  ResyncConfigRequest,
+  SyncAllResponse,
+  TemplateVariable,
+  TemplateVariablesResponse,
+  RenderedConfig,
+  RenderedConfigResponse,
 } from './types';
 
 /**
@@ -107,6 +118,39 @@ export class ConfigService extends BaseService {
     return response.data.data.content;
   }
 
+  /**
+   * List variables usable in config templates
+   * 获取配置模板可用变量
+   *
+   * @returns Template variables / 模板变量
+   */
+  static async getTemplateVariables(): Promise<TemplateVariable[]> {
+    const response = await apiClient.get<TemplateVariablesResponse>(
+      `${this.basePath}/configs/template-variables`
+    );
+    if (response.data.error_msg) {
+      throw new Error(localizeBackendText(response.data.error_msg));
+    }
+    return response.data.data;
+  }
+
+  /**
+   * Preview a node config rendered with the variables of its node
+   * 预览使用节点变量渲染后的节点配置
+   *
+   * @param configId - Node config ID / 节点配置 ID
+   * @returns Rendered config / 渲染后的配置
+   */
+  static async getRenderedConfig(configId: number): Promise<RenderedConfig> {
+    const response = await apiClient.get<RenderedConfigResponse>(
+      `${this.basePath}/configs/${configId}/rendered`
+    );
+    if (response.data.error_msg) {
+      throw new Error(localizeBackendText(response.data.error_msg));
+    }
+    return response.data.data;
+  }
+
   /**
    * Update config content
    * 更新配置内容
@@ -462,4 +506,84 @@ export class ConfigService extends BaseService {
     }
     return response.data.data;
   }
+
+  /**
+   * Get the latest config drift results of a cluster
+   * 获取集群最近一次的配置漂移检测结果
+   *
+   * @param clusterId - Cluster ID / 集群 ID
+   * @returns Drift results / 漂移检测结果
+   */
+  static async getConfigDrift(clusterId: number): Promise<ConfigDriftInfo[]> {
+    const response = await apiClient.get<ConfigDriftResponse>(
+      `${this.basePath}/clusters/${clusterId}/configs/drift`
+    );
+    if (response.data.error_msg) {
+      throw new Error(localizeBackendText(response.data.error_msg));
+    }
+    return response.data.data;
+  }
+
+  /**
+   * Detect config drift of a cluster now
+   * 立即检测集群配置漂移
+   *
+   * @param clusterId - Cluster ID / 集群 ID
+   * @returns Drift results / 漂移检测结果
+   */
+  static async checkConfigDrift(
+    clusterId: number
+  ): Promise<ConfigDriftInfo[]> {
+    const response = await apiClient.post<ConfigDriftResponse>(
+      `${this.basePath}/clusters/${clusterId}/configs/drift/check`
+    );
+    if (response.data.error_msg) {
+      throw new Error(localizeBackendText(response.data.error_msg));
+    }
+    return response.data.data;
+  }
+
+  /**
+   * Push stored node configs back to drifted nodes
+   * 将已保存的节点配置重新同步到漂移节点
+   *
+   * @param clusterId - Cluster ID / 集群 ID
+   * @param request - Hosts to resync / 需同步的主机
+   * @returns Resync result / 重新同步结果
+   */
+  static async resyncConfigs(
+    clusterId: number,
+    request: ResyncConfigRequest = {}
+  ): Promise<SyncAllResponse['data']> {
+    const response = await apiClient.post<SyncAllResponse>(
+      `${this.basePath}/clusters/${clusterId}/configs/resync`,
+      request
+    );
+    if (response.data.error_msg) {
+      throw new Error(localizeBackendText(response.data.error_msg));
+    }
+    return response.data.data;
+  }
+
+  /**
+   * Validate, store and apply a cluster config to all nodes
+   * 校验、保存并将集群配置应用到所有节点
+   *
+   * @param clusterId - Cluster ID / 集群 ID
+   * @param request - Apply request / 应用请求
+   * @returns Apply result / 应用结果
+   */
+  static async applyClusterConfig(
+    clusterId: number,
+    request: ApplyConfigRequest
+  ): Promise<ApplyConfigResult> {
+    const response = await apiClient.post<ApplyConfigResponse>(
+      `${this.basePath}/clusters/${clusterId}/configs/apply`,
+      request
+    );
+    if (response.data.error_msg) {
+      throw new Error(localizeBackendText(response.data.error_msg));
+    }
+    return response.data.data;
+  }
 }
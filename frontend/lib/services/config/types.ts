@@ -278,3 +278,132 @@ export type SyncAllResponse = ApiResponse<{
   synced_count: number;
   push_errors: PushError[];
 }>;
+
+/**
+ * Config drift detection result of one node config
+ * 单个节点配置的漂移检测结果
+ */
+export interface ConfigDriftInfo {
+  /** Cluster ID / 集群 ID */
+  cluster_id: number;
+  /** Host ID / 主机 ID */
+  host_id: number;
+  /** Host name / 主机名称 */
+  host_name?: string;
+  /** Host IP address / 主机 IP 地址 */
+  host_ip?: string;
+  /** Config type / 配置类型 */
+  config_type: ConfigType;
+  /** Compared node config ID / 比对的节点配置 ID */
+  config_id: number;
+  /** Stored version compared / 比对的已保存版本 */
+  stored_version: number;
+  /** Whether the node file drifted / 节点文件是否漂移 */
+  drifted: boolean;
+  /** Last pull error / 最近一次拉取错误 */
+  error?: string;
+  /** When the drift was first detected / 首次发现漂移的时间 */
+  detected_at?: string;
+  /** Last check time / 最近一次检测时间 */
+  checked_at: string;
+}
+
+/**
+ * Resync config request
+ * 重新同步节点配置请求
+ */
+export interface ResyncConfigRequest {
+  /** Hosts to resync, all drifted nodes when empty / 需同步的主机，为空时同步所有漂移节点 */
+  host_ids?: number[];
+}
+
+/** Config drift response type / 配置漂移响应类型 */
+export type ConfigDriftResponse = ApiResponse<ConfigDriftInfo[]>;
+
+/**
+ * Apply cluster config request
+ * 编辑并应用集群配置请求
+ */
+export interface ApplyConfigRequest {
+  /** Config type / 配置类型 */
+  config_type: ConfigType;
+  /** New config content / 新的配置内容 */
+  content: string;
+  /** Change comment / 修改说明 */
+  comment?: string;
+  /** Rolling restart after all nodes applied / 全部节点应用后滚动重启 */
+  rolling_restart?: boolean;
+}
+
+/**
+ * Node that applied the config
+ * 已应用配置的节点
+ */
+export interface AppliedNode {
+  /** Host ID / 主机 ID */
+  host_id: number;
+  /** Host IP address / 主机 IP 地址 */
+  host_ip?: string;
+  /** Backup path of the previous file / 原文件备份路径 */
+  backup_path?: string;
+}
+
+/**
+ * Apply cluster config result
+ * 应用集群配置结果
+ */
+export interface ApplyConfigResult {
+  /** Config type / 配置类型 */
+  config_type: ConfigType;
+  /** Template version after apply / 应用后的模板版本 */
+  template_version: number;
+  /** Number of node configs changed / 变更的节点配置数 */
+  synced_count: number;
+  /** Nodes that applied the config / 已应用配置的节点 */
+  applied_nodes: AppliedNode[];
+  /** Nodes that failed / 应用失败的节点 */
+  push_errors: PushError[];
+  /** Rolling restart result / 滚动重启结果 */
+  restart?: {
+    triggered: boolean;
+    success: boolean;
+    message: string;
+  };
+}
+
+/** Apply config response type / 应用配置响应类型 */
+export type ApplyConfigResponse = ApiResponse<ApplyConfigResult>;
+
+/**
+ * Variable usable in config templates, e.g. {{node.ip}}
+ * 配置模板中可用的变量，例如 {{node.ip}}
+ */
+export interface TemplateVariable {
+  /** Variable name / 变量名 */
+  name: string;
+  /** Description / 描述 */
+  description: string;
+  /** Example value / 示例值 */
+  example: string;
+}
+
+/**
+ * Node config rendered with the variables of its node
+ * 使用所在节点变量渲染后的节点配置
+ */
+export interface RenderedConfig {
+  /** Config ID / 配置 ID */
+  config_id: number;
+  /** Host ID / 主机 ID */
+  host_id: number;
+  /** Config type / 配置类型 */
+  config_type: ConfigType;
+  /** Rendered content / 渲染后的内容 */
+  content: string;
+}
+
+/** Template variables response type / 模板变量响应类型 */
+export type TemplateVariablesResponse = ApiResponse<TemplateVariable[]>;
+
+/** Rendered config response type / 渲染配置响应类型 */
+export type RenderedConfigResponse = ApiResponse<RenderedConfig>;
@@ -16,6 +16,7 @@
  */
 
 import axios, {AxiosError, AxiosResponse} from 'axios';
+import {ApiRequestError} from './error-codes';
 import {ApiError, ApiResponse} from './types';
 
 /**
@@ -28,6 +29,9 @@ const apiClient = axios.create({
   baseURL: '/api/v1', // API 基础路径 / API base path
   timeout: 60000, // 60 seconds for plugin fetching from Maven / 60秒超时，用于从 Maven 获取插件
   withCredentials: true,
+  // 双重提交 CSRF：回传后端下发的令牌 Cookie / Double-submit CSRF: echo the token cookie
+  xsrfCookieName: 'seatunnel_csrf_token',
+  xsrfHeaderName: 'X-CSRF-Token',
   headers: {
     'Content-Type': 'application/json',
   },
@@ -80,11 +84,15 @@ apiClient.interceptors.response.use(
       return redirectToLogin(window.location.pathname);
     }
 
-    // 处理后端返回的错误信息
+    // 处理后端返回的错误信息（附带错误码）
     if (error.response?.data?.error_msg) {
-      const apiError = new Error(error.response.data.error_msg);
-      apiError.name = 'ApiError';
-      return Promise.reject(apiError);
+      return Promise.reject(
+        new ApiRequestError(
+          error.response.data.error_msg,
+          error.response.data.error_code,
+          error.response.status,
+        ),
+      );
     }
 
     // 处理网络错误
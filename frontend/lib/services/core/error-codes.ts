@@ -0,0 +1,109 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+/**
+ * 错误码目录（与后端 internal/pkg/errcode 保持一致）
+ * Error-code catalog, kept in sync with internal/pkg/errcode on the backend
+ */
+export const ErrorCodes = {
+  INVALID_REQUEST: 'ST-GEN-001',
+  UNAUTHORIZED: 'ST-GEN-002',
+  FORBIDDEN: 'ST-GEN-003',
+  NOT_FOUND: 'ST-GEN-004',
+  CONFLICT: 'ST-GEN-005',
+  INTERNAL: 'ST-GEN-006',
+  UNAVAILABLE: 'ST-GEN-007',
+  TIMEOUT: 'ST-GEN-008',
+  RATE_LIMITED: 'ST-GEN-009',
+
+  AGENT_NOT_CONNECTED: 'ST-AGENT-001',
+  AGENT_NOT_FOUND: 'ST-AGENT-002',
+  COMMAND_TIMEOUT: 'ST-AGENT-003',
+  COMMAND_QUEUE_EXPIRED: 'ST-AGENT-004',
+  COMMAND_CANCELLED: 'ST-AGENT-005',
+  CONTROL_PLANE_DRAINING: 'ST-AGENT-006',
+  AGENT_REJECTED: 'ST-AGENT-007',
+  COMMAND_NOT_FOUND: 'ST-AGENT-008',
+  PENDING_AGENT_NOT_FOUND: 'ST-AGENT-009',
+  COMMAND_STREAM_NOT_READY: 'ST-AGENT-010',
+
+  COMMAND_FAILED: 'ST-EXEC-001',
+  UNSUPPORTED_COMMAND: 'ST-EXEC-002',
+  INVALID_PARAMETERS: 'ST-EXEC-003',
+
+  PACKAGE_NOT_FOUND: 'ST-INST-001',
+  INSTALL_DIR_NOT_WRITABLE: 'ST-INST-002',
+  INVALID_DEPLOYMENT_MODE: 'ST-INST-003',
+  INVALID_NODE_ROLE: 'ST-INST-004',
+  INVALID_MIRROR_SOURCE: 'ST-INST-005',
+  CONFIG_GENERATION_FAILED: 'ST-INST-006',
+  CLUSTER_MEMBERSHIP_TIMEOUT: 'ST-INST-007',
+  DOWNLOAD_FAILED: 'ST-INST-010',
+  EXTRACTION_FAILED: 'ST-INST-011',
+  CHECKSUM_MISMATCH: 'ST-INST-012',
+
+  PRECHECK_FAILED: 'ST-PRE-001',
+
+  CLUSTER_NOT_FOUND: 'ST-CLUSTER-001',
+  CLUSTER_NAME_DUPLICATE: 'ST-CLUSTER-002',
+  CLUSTER_NODE_NOT_FOUND: 'ST-CLUSTER-003',
+  CLUSTER_NODE_EXISTS: 'ST-CLUSTER-004',
+  NODE_AGENT_NOT_INSTALLED: 'ST-CLUSTER-005',
+  CLUSTER_HAS_RUNNING_TASK: 'ST-CLUSTER-006',
+  CLUSTER_NOT_RUNNING: 'ST-CLUSTER-007',
+  SCALE_IN_PROGRESS: 'ST-CLUSTER-008',
+  CROSS_PROJECT_NODE: 'ST-CLUSTER-009',
+  NODE_SELECTOR_MISMATCH: 'ST-CLUSTER-010',
+  CLUSTER_ALREADY_ADOPTED: 'ST-CLUSTER-011',
+  CLUSTER_ADOPTION_FAILED: 'ST-CLUSTER-012',
+
+  HOST_NOT_FOUND: 'ST-HOST-001',
+  HOST_NAME_DUPLICATE: 'ST-HOST-002',
+  HOST_IP_DUPLICATE: 'ST-HOST-003',
+  HOST_IP_INVALID: 'ST-HOST-004',
+  HOST_HAS_CLUSTER: 'ST-HOST-005',
+  HOST_LABEL_INVALID: 'ST-HOST-006',
+  HOST_IMPORT_INVALID: 'ST-HOST-007',
+} as const;
+
+export type ErrorCode = (typeof ErrorCodes)[keyof typeof ErrorCodes];
+
+/**
+ * 后端返回的 API 错误，携带错误码与 HTTP 状态码
+ * API error returned by the backend, carrying the error code and HTTP status
+ */
+export class ApiRequestError extends Error {
+  /** 错误码，如 ST-AGENT-001 */
+  readonly code?: string;
+  /** HTTP 状态码 */
+  readonly status?: number;
+
+  constructor(message: string, code?: string, status?: number) {
+    super(message);
+    this.name = 'ApiError';
+    this.code = code;
+    this.status = status;
+  }
+}
+
+/**
+ * 获取错误对象上的错误码
+ * Get the error code attached to an error, if any
+ */
+export function getErrorCode(error: unknown): string | undefined {
+  return error instanceof ApiRequestError ? error.code : undefined;
+}
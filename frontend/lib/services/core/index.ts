@@ -16,5 +16,6 @@
  */
 
 export * from './types';
+export * from './error-codes';
 export {BaseService} from './base.service';
 export {default as apiClient} from './api-client';
@@ -0,0 +1,180 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+/**
+ * Resumable chunked upload
+ * 可续传分片上传
+ *
+ * Uploads a file through the init / append / complete endpoints. Each chunk is sent at an explicit
+ * offset with its SHA-256 (when the browser exposes WebCrypto); after a failure the client asks the
+ * server for the stored offset and resumes from there.
+ * 通过初始化 / 追加 / 完成接口上传文件。每个分片按明确的偏移量发送，并附带其 SHA-256（浏览器支持 WebCrypto 时）；
+ * 失败后向服务端查询已保存的偏移量并从该位置续传。
+ */
+
+import apiClient from './api-client';
+
+const UPLOAD_CHUNK_SIZE = 8 * 1024 * 1024; // 8MB
+const UPLOAD_MAX_RETRIES = 5;
+const UPLOAD_RETRY_DELAY_MS = 1000;
+
+/**
+ * Resumable upload state returned by the server
+ * 服务端返回的可续传上传状态
+ */
+export interface UploadSession {
+  upload_id: string;
+  file_name: string;
+  total_size: number;
+  offset: number;
+  sha256?: string;
+  metadata?: Record<string, string>;
+  created_at: string;
+  updated_at: string;
+}
+
+interface UploadEnvelope<T> {
+  error_msg: string;
+  data: T | null;
+}
+
+export interface ResumableUploadOptions {
+  /** Upload collection endpoint, e.g. /packages/uploads / 上传集合接口，例如 /packages/uploads */
+  endpoint: string;
+  /** Extra fields of the init request / 初始化请求的附加字段 */
+  fields: Record<string, unknown>;
+  file: File;
+  onProgress?: (percent: number) => void;
+}
+
+function unwrap<T>(envelope: UploadEnvelope<T>, emptyMessage: string): T {
+  if (envelope.error_msg) {
+    throw new Error(envelope.error_msg);
+  }
+  if (!envelope.data) {
+    throw new Error(emptyMessage);
+  }
+  return envelope.data;
+}
+
+async function chunkSHA256(chunk: Blob): Promise<string | undefined> {
+  // WebCrypto is only available in secure contexts (HTTPS or localhost)
+  // WebCrypto 仅在安全上下文（HTTPS 或 localhost）中可用
+  if (typeof crypto === 'undefined' || !crypto.subtle) {
+    return undefined;
+  }
+  const digest = await crypto.subtle.digest(
+    'SHA-256',
+    await chunk.arrayBuffer(),
+  );
+  return Array.from(new Uint8Array(digest), (byte) =>
+    byte.toString(16).padStart(2, '0'),
+  ).join('');
+}
+
+function wait(ms: number): Promise<void> {
+  return new Promise((resolve) => setTimeout(resolve, ms));
+}
+
+/**
+ * Upload a file in resumable chunks and return the result of the complete endpoint
+ * 以可续传分片上传文件，并返回完成接口的结果
+ */
+export async function uploadResumable<T>({
+  endpoint,
+  fields,
+  file,
+  onProgress,
+}: ResumableUploadOptions): Promise<T> {
+  if (!file || file.size <= 0) {
+    throw new Error('上传文件不能为空 / Upload file is empty');
+  }
+
+  const initResponse = await apiClient.post<UploadEnvelope<UploadSession>>(
+    endpoint,
+    {
+      ...fields,
+      file_name: file.name,
+      total_size: file.size,
+    },
+  );
+  const session = unwrap(
+    initResponse.data,
+    '上传会话创建失败 / Empty upload session response',
+  );
+  const sessionUrl = `${endpoint}/${session.upload_id}`;
+
+  let offset = 0;
+  let failures = 0;
+  let resume = false;
+  onProgress?.(0);
+
+  while (offset < file.size) {
+    try {
+      if (resume) {
+        // Continue from the offset the server actually stored
+        // 从服务端实际保存的偏移量继续
+        const current = await apiClient.get<UploadEnvelope<UploadSession>>(
+          sessionUrl,
+        );
+        offset = unwrap(
+          current.data,
+          '上传会话不存在 / Upload not found',
+        ).offset;
+        resume = false;
+        continue;
+      }
+
+      const chunk = file.slice(
+        offset,
+        Math.min(offset + UPLOAD_CHUNK_SIZE, file.size),
+      );
+      const response = await apiClient.patch<UploadEnvelope<UploadSession>>(
+        sessionUrl,
+        chunk,
+        {
+          params: {offset, sha256: await chunkSHA256(chunk)},
+          headers: {'Content-Type': 'application/octet-stream'},
+        },
+      );
+      offset = unwrap(
+        response.data,
+        '分片上传返回为空 / Empty chunk upload response',
+      ).offset;
+      failures = 0;
+      onProgress?.(Math.min(99, Math.floor((offset / file.size) * 100)));
+    } catch (error) {
+      failures += 1;
+      if (failures > UPLOAD_MAX_RETRIES) {
+        await apiClient.delete(sessionUrl).catch(() => undefined);
+        throw error;
+      }
+      await wait(UPLOAD_RETRY_DELAY_MS * failures);
+      resume = true;
+    }
+  }
+
+  const completeResponse = await apiClient.post<UploadEnvelope<T>>(
+    `${sessionUrl}/complete`,
+  );
+  const result = unwrap(
+    completeResponse.data,
+    '上传未完成，请重试 / Upload did not complete',
+  );
+  onProgress?.(100);
+  return result;
+}
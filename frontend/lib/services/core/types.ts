@@ -20,6 +20,12 @@
  * @template T - 响应数据类型
  */
 export interface ApiResponse<T = unknown> {
+  /** 响应码，成功时为 OK，失败时与 error_code 一致（仅标准信封接口返回） */
+  code?: string;
+  /** 响应消息，成功时为空字符串 */
+  message?: string;
+  /** 请求 ID，与响应头 X-Request-ID 一致，用于关联日志与审计记录 */
+  request_id?: string;
   /** 错误信息，成功时为空字符串 */
   error_msg: string;
   /** 响应数据 */
@@ -43,6 +49,10 @@ export interface PaginatedResponse<T = unknown> {
 export interface ApiError {
   /** 错误信息 */
   error_msg: string;
+  /** 错误码，如 ST-AGENT-001，见 error-codes.ts */
+  error_code?: string;
+  /** 请求 ID，用于排查时关联服务端日志 */
+  request_id?: string;
 }
 
 /**
@@ -41,6 +41,7 @@ import {
   ClusterSummariesApiResponse,
   HostSummariesApiResponse,
   RecentActivitiesApiResponse,
+  OverviewStreamMessage,
 } from './types';
 
 /**
@@ -280,6 +281,38 @@ export class OverviewService extends BaseService {
     return response.data.data;
   }
 
+  /**
+   * Get overview WebSocket url / 获取概览 WebSocket 地址
+   */
+  static getOverviewStreamUrl(): string {
+    const protocol = window.location.protocol === 'https:' ? 'wss:' : 'ws:';
+    return `${protocol}//${window.location.host}/api/v1${this.basePath}/ws`;
+  }
+
+  /**
+   * Subscribe to overview updates / 订阅概览实时更新
+   * @returns Function that closes the connection / 关闭连接的函数
+   */
+  static subscribeOverview(
+    onMessage: (message: OverviewStreamMessage) => void,
+    onStateChange?: (connected: boolean) => void,
+  ): () => void {
+    const socket = new WebSocket(this.getOverviewStreamUrl());
+    socket.onopen = () => onStateChange?.(true);
+    socket.onclose = () => onStateChange?.(false);
+    socket.onmessage = (event: MessageEvent<string>) => {
+      try {
+        onMessage(JSON.parse(event.data) as OverviewStreamMessage);
+      } catch {
+        console.warn('Failed to parse overview message:', event.data);
+      }
+    };
+    return () => {
+      socket.onclose = null;
+      socket.close();
+    };
+  }
+
   // ==================== Safe Methods 安全方法 ====================
 
   /**
@@ -321,6 +354,7 @@ export class OverviewService extends BaseService {
         error_nodes: 0,
         total_agents: 0,
         online_agents: 0,
+        running_installations: 0,
       },
       cluster_summaries: [],
       host_summaries: [],
@@ -300,6 +300,8 @@ export interface TooltipProps {
 export interface OverviewStats {
   total_hosts: number;
   online_hosts: number;
+  /** Hosts currently in maintenance mode / 处于维护模式的主机数 */
+  maintenance_hosts?: number;
   total_clusters: number;
   running_clusters: number;
   stopped_clusters: number;
@@ -310,6 +312,7 @@ export interface OverviewStats {
   error_nodes: number;
   total_agents: number;
   online_agents: number;
+  running_installations?: number;
 }
 
 /**
@@ -318,7 +321,10 @@ export interface OverviewStats {
 export interface ClusterSummary {
   id: number;
   name: string;
-  /** DB status; may be "unhealthy" when running but 0 nodes online */
+  /**
+   * DB status; may be "unhealthy" when running but 0 nodes online,
+   * or "maintenance" when those nodes sit on hosts under maintenance
+   */
   status: string;
   deployment_mode: string;
   total_nodes: number;
@@ -340,6 +346,7 @@ export interface HostSummary {
   is_online: boolean;
   agent_status: string;
   node_count: number;
+  maintenance?: boolean;
 }
 
 /**
@@ -370,3 +377,24 @@ export type OverviewStatsApiResponse = ApiResponse<OverviewStats>;
 export type ClusterSummariesApiResponse = ApiResponse<ClusterSummary[]>;
 export type HostSummariesApiResponse = ApiResponse<HostSummary[]>;
 export type RecentActivitiesApiResponse = ApiResponse<RecentActivity[]>;
+
+/**
+ * Overview WebSocket message type / 概览 WebSocket 消息类型
+ */
+export type OverviewStreamMessageType =
+  | 'snapshot'
+  | 'stats'
+  | 'ping'
+  | 'agent_status'
+  | 'cluster_status'
+  | 'node_status'
+  | 'installations';
+
+/**
+ * Overview WebSocket message / 概览 WebSocket 消息
+ */
+export interface OverviewStreamMessage {
+  type: OverviewStreamMessageType;
+  timestamp: string;
+  data?: unknown;
+}
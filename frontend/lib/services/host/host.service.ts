@@ -30,14 +30,31 @@ import {
   HostListData,
   CreateHostRequest,
   UpdateHostRequest,
+  SetMaintenanceRequest,
   ListHostsRequest,
   ListHostsResponse,
   CreateHostResponse,
   GetHostResponse,
   UpdateHostResponse,
   DeleteHostResponse,
+  RecycledHost,
+  ListRecycledHostsResponse,
+  RestoreHostResponse,
   GetInstallCommandResponse,
   InstallCommandData,
+  ImportHostsRequest,
+  ImportHostsResponse,
+  ImportHostsResult,
+  SaveSSHCredentialRequest,
+  SSHCredentialInfo,
+  SSHCredentialResponse,
+  SSHDeployTask,
+  SSHDeployTaskResponse,
+  ListSSHDeployTasksResponse,
+  PatchHostLabelsRequest,
+  LabelValues,
+  HostLabelsResponse,
+  ListLabelValuesResponse,
 } from './types';
 
 /**
@@ -69,6 +86,11 @@ export class HostService extends BaseService {
         status: params.status,
         agent_status: params.agent_status,
         is_online: params.is_online,
+        label_selector: params.label_selector,
+        cluster_id: params.cluster_id,
+        sort: params.sort,
+        start_time: params.start_time,
+        end_time: params.end_time,
       },
     });
 
@@ -143,6 +165,30 @@ export class HostService extends BaseService {
     return response.data.data;
   }
 
+  /**
+   * Turn maintenance mode of a host on or off
+   * 开启或关闭主机维护模式
+   *
+   * @param hostId - Host ID / 主机 ID
+   * @param data - Maintenance settings / 维护模式设置
+   * @returns Updated host information / 更新后的主机信息
+   */
+  static async setMaintenance(
+    hostId: number,
+    data: SetMaintenanceRequest,
+  ): Promise<HostInfo> {
+    const response = await apiClient.put<UpdateHostResponse>(
+      `${this.basePath}/${hostId}/maintenance`,
+      data,
+    );
+
+    if (response.data.error_msg) {
+      throw new Error(response.data.error_msg);
+    }
+
+    return response.data.data;
+  }
+
   /**
    * Delete a host
    * 删除主机
@@ -159,6 +205,53 @@ export class HostService extends BaseService {
     }
   }
 
+  /**
+   * List hosts in the recycle bin
+   * 列出回收站中的主机
+   */
+  static async listRecycledHosts(name?: string): Promise<RecycledHost[]> {
+    const response = await apiClient.get<ListRecycledHostsResponse>(
+      `${this.basePath}/recycle-bin`,
+      {params: name ? {name} : undefined},
+    );
+
+    if (response.data.error_msg) {
+      throw new Error(response.data.error_msg);
+    }
+
+    return response.data.data || [];
+  }
+
+  /**
+   * Restore a host from the recycle bin
+   * 从回收站恢复主机
+   */
+  static async restoreHost(hostId: number): Promise<HostInfo> {
+    const response = await apiClient.post<RestoreHostResponse>(
+      `${this.basePath}/${hostId}/restore`,
+    );
+
+    if (response.data.error_msg) {
+      throw new Error(response.data.error_msg);
+    }
+
+    return response.data.data;
+  }
+
+  /**
+   * Remove a recycled host without waiting for the retention window
+   * 不等待保留期，立即删除回收站中的主机
+   */
+  static async purgeHost(hostId: number): Promise<void> {
+    const response = await apiClient.delete<DeleteHostResponse>(
+      `${this.basePath}/recycle-bin/${hostId}`,
+    );
+
+    if (response.data.error_msg) {
+      throw new Error(response.data.error_msg);
+    }
+  }
+
   /**
    * Get Agent install command for a host
    * 获取主机的 Agent 安装命令
@@ -178,6 +271,247 @@ export class HostService extends BaseService {
     return response.data.data;
   }
 
+  /**
+   * Import hosts in bulk from a JSON list or an uploaded CSV/JSON file.
+   * Nothing is created when any row is invalid; rejected rows are in errors.
+   * 从 JSON 列表或上传的 CSV/JSON 文件批量导入主机。
+   * 任一行无效时不会创建任何主机，被拒绝的行在 errors 中返回。
+   *
+   * @param payload - Host list or file / 主机列表或文件
+   * @returns Import result / 导入结果
+   */
+  static async importHosts(
+    payload: ImportHostsRequest | File,
+  ): Promise<ImportHostsResult> {
+    let body: ImportHostsRequest | FormData = payload as ImportHostsRequest;
+    if (payload instanceof File) {
+      body = new FormData();
+      body.append('file', payload);
+    }
+    const response = await apiClient.post<ImportHostsResponse>(
+      `${this.basePath}/import`,
+      body,
+      {validateStatus: (status) => status < 500},
+    );
+
+    if (response.data.error_msg) {
+      const error = new Error(response.data.error_msg);
+      Object.assign(error, {result: response.data.data});
+      throw error;
+    }
+
+    return response.data.data;
+  }
+
+  /**
+   * Save the SSH credential used to deploy the Agent (stored encrypted)
+   * 保存用于部署 Agent 的 SSH 凭证（加密存储）
+   *
+   * @param hostId - Host ID / 主机 ID
+   * @param data - Credential / 凭证
+   * @returns Stored credential without secrets / 不含密文的凭证信息
+   */
+  static async saveSSHCredential(
+    hostId: number,
+    data: SaveSSHCredentialRequest,
+  ): Promise<SSHCredentialInfo> {
+    const response = await apiClient.put<SSHCredentialResponse>(
+      `${this.basePath}/${hostId}/ssh-credential`,
+      data,
+    );
+    if (response.data.error_msg) {
+      throw new Error(response.data.error_msg);
+    }
+    return response.data.data;
+  }
+
+  /**
+   * Get the stored SSH credential of a host
+   * 获取主机已保存的 SSH 凭证
+   *
+   * @param hostId - Host ID / 主机 ID
+   * @returns Stored credential without secrets / 不含密文的凭证信息
+   */
+  static async getSSHCredential(hostId: number): Promise<SSHCredentialInfo> {
+    const response = await apiClient.get<SSHCredentialResponse>(
+      `${this.basePath}/${hostId}/ssh-credential`,
+    );
+    if (response.data.error_msg) {
+      throw new Error(response.data.error_msg);
+    }
+    return response.data.data;
+  }
+
+  /**
+   * Delete the stored SSH credential of a host
+   * 删除主机已保存的 SSH 凭证
+   *
+   * @param hostId - Host ID / 主机 ID
+   */
+  static async deleteSSHCredential(hostId: number): Promise<void> {
+    const response = await apiClient.delete<SSHCredentialResponse>(
+      `${this.basePath}/${hostId}/ssh-credential`,
+    );
+    if (response.data.error_msg) {
+      throw new Error(response.data.error_msg);
+    }
+  }
+
+  /**
+   * Deploy the Agent over SSH; poll getSSHDeployTask for progress
+   * 通过 SSH 部署 Agent；通过 getSSHDeployTask 轮询进度
+   *
+   * @param hostId - Host ID / 主机 ID
+   * @returns Created deploy task / 创建的部署任务
+   */
+  static async startSSHDeploy(hostId: number): Promise<SSHDeployTask> {
+    const response = await apiClient.post<SSHDeployTaskResponse>(
+      `${this.basePath}/${hostId}/ssh-deploy`,
+    );
+    if (response.data.error_msg) {
+      throw new Error(response.data.error_msg);
+    }
+    return response.data.data;
+  }
+
+  /**
+   * List recent SSH deploy tasks of a host (without logs)
+   * 获取主机最近的 SSH 部署任务（不含日志）
+   *
+   * @param hostId - Host ID / 主机 ID
+   * @returns Deploy tasks / 部署任务列表
+   */
+  static async listSSHDeployTasks(hostId: number): Promise<SSHDeployTask[]> {
+    const response = await apiClient.get<ListSSHDeployTasksResponse>(
+      `${this.basePath}/${hostId}/ssh-deploy/tasks`,
+    );
+    if (response.data.error_msg) {
+      throw new Error(response.data.error_msg);
+    }
+    return response.data.data || [];
+  }
+
+  /**
+   * Get an SSH deploy task with its captured log
+   * 获取 SSH 部署任务及其日志
+   *
+   * @param hostId - Host ID / 主机 ID
+   * @param taskId - Task ID / 任务 ID
+   * @returns Deploy task / 部署任务
+   */
+  static async getSSHDeployTask(
+    hostId: number,
+    taskId: number,
+  ): Promise<SSHDeployTask> {
+    const response = await apiClient.get<SSHDeployTaskResponse>(
+      `${this.basePath}/${hostId}/ssh-deploy/tasks/${taskId}`,
+    );
+    if (response.data.error_msg) {
+      throw new Error(response.data.error_msg);
+    }
+    return response.data.data;
+  }
+
+  /**
+   * List label keys in use with their values
+   * 获取在用的标签键及其取值
+   *
+   * @returns Label keys and values / 标签键及取值
+   */
+  static async getLabelValues(): Promise<LabelValues[]> {
+    const response = await apiClient.get<ListLabelValuesResponse>(
+      `${this.basePath}/labels`,
+    );
+    if (response.data.error_msg) {
+      throw new Error(response.data.error_msg);
+    }
+    return response.data.data || [];
+  }
+
+  /**
+   * Get the labels of a host
+   * 获取主机标签
+   *
+   * @param hostId - Host ID / 主机 ID
+   * @returns Labels / 标签
+   */
+  static async getHostLabels(
+    hostId: number,
+  ): Promise<Record<string, string>> {
+    const response = await apiClient.get<HostLabelsResponse>(
+      `${this.basePath}/${hostId}/labels`,
+    );
+    if (response.data.error_msg) {
+      throw new Error(response.data.error_msg);
+    }
+    return response.data.data || {};
+  }
+
+  /**
+   * Replace all labels of a host
+   * 整体替换主机标签
+   *
+   * @param hostId - Host ID / 主机 ID
+   * @param labels - New labels / 新标签
+   * @returns Stored labels / 保存后的标签
+   */
+  static async replaceHostLabels(
+    hostId: number,
+    labels: Record<string, string>,
+  ): Promise<Record<string, string>> {
+    const response = await apiClient.put<HostLabelsResponse>(
+      `${this.basePath}/${hostId}/labels`,
+      {labels},
+    );
+    if (response.data.error_msg) {
+      throw new Error(response.data.error_msg);
+    }
+    return response.data.data || {};
+  }
+
+  /**
+   * Set and remove some labels of a host, keeping the others
+   * 设置并删除主机的部分标签，其余保持不变
+   *
+   * @param hostId - Host ID / 主机 ID
+   * @param data - Labels to set and keys to remove / 要设置的标签与要删除的键
+   * @returns Stored labels / 保存后的标签
+   */
+  static async patchHostLabels(
+    hostId: number,
+    data: PatchHostLabelsRequest,
+  ): Promise<Record<string, string>> {
+    const response = await apiClient.patch<HostLabelsResponse>(
+      `${this.basePath}/${hostId}/labels`,
+      data,
+    );
+    if (response.data.error_msg) {
+      throw new Error(response.data.error_msg);
+    }
+    return response.data.data || {};
+  }
+
+  /**
+   * Delete one label of a host
+   * 删除主机的一个标签
+   *
+   * @param hostId - Host ID / 主机 ID
+   * @param key - Label key / 标签键
+   * @returns Stored labels / 保存后的标签
+   */
+  static async deleteHostLabel(
+    hostId: number,
+    key: string,
+  ): Promise<Record<string, string>> {
+    const response = await apiClient.delete<HostLabelsResponse>(
+      `${this.basePath}/${hostId}/labels/${encodeURIComponent(key)}`,
+    );
+    if (response.data.error_msg) {
+      throw new Error(response.data.error_msg);
+    }
+    return response.data.data || {};
+  }
+
   // ==================== Safe Methods (with error handling) 安全方法（带错误处理） ====================
 
   /**
@@ -51,6 +51,8 @@ export enum HostStatus {
   OFFLINE = 'offline',
   /** Error state / 错误状态 */
   ERROR = 'error',
+  /** Under maintenance / 维护中 */
+  MAINTENANCE = 'maintenance',
 }
 
 /**
@@ -81,6 +83,16 @@ export interface HostInfo {
   description: string;
   /** Connection status / 连接状态 */
   status: HostStatus;
+  /** Labels / 标签 */
+  labels?: Record<string, string>;
+  /** Owning project, 0 for shared / 所属项目，0 表示共享 */
+  project_id?: number;
+  /** Whether the host is in maintenance mode / 主机是否处于维护模式 */
+  maintenance?: boolean;
+  /** Maintenance reason / 维护原因 */
+  maintenance_reason?: string;
+  /** Maintenance start time / 维护开始时间 */
+  maintenance_started_at?: string | null;
 
   /** CPU usage percentage (0-100) / CPU 使用率百分比 */
   cpu_usage: number;
@@ -98,6 +110,8 @@ export interface HostInfo {
   ip_address?: string;
   /** SSH port / SSH 端口 */
   ssh_port?: number;
+  /** SSH user / SSH 用户 */
+  ssh_user?: string;
   /** Agent ID / Agent ID */
   agent_id?: string;
   /** Agent status / Agent 状态 */
@@ -159,12 +173,18 @@ export interface CreateHostRequest {
   host_type?: HostType;
   /** Description / 描述 */
   description?: string;
+  /** Labels / 标签 */
+  labels?: Record<string, string>;
+  /** Owning project, 0 for shared / 所属项目，0 表示共享 */
+  project_id?: number;
 
   // bare_metal fields / 物理机字段
   /** IP address (required for bare_metal) / IP 地址（物理机必填） */
   ip_address?: string;
   /** SSH port / SSH 端口 */
   ssh_port?: number;
+  /** SSH user / SSH 用户 */
+  ssh_user?: string;
 
   // docker fields / Docker 字段
   /** Docker API URL (required for docker) / Docker API 地址（Docker 必填） */
@@ -194,12 +214,16 @@ export interface UpdateHostRequest {
   name?: string;
   /** Description / 描述 */
   description?: string;
+  /** Labels / 标签 */
+  labels?: Record<string, string>;
 
   // bare_metal fields / 物理机字段
   /** IP address / IP 地址 */
   ip_address?: string;
   /** SSH port / SSH 端口 */
   ssh_port?: number;
+  /** SSH user / SSH 用户 */
+  ssh_user?: string;
 
   // docker fields / Docker 字段
   /** Docker API URL / Docker API 地址 */
@@ -220,6 +244,17 @@ export interface UpdateHostRequest {
   k8s_token?: string;
 }
 
+/**
+ * Request to turn maintenance mode on or off
+ * 开启或关闭维护模式的请求
+ */
+export interface SetMaintenanceRequest {
+  /** Whether maintenance mode is enabled / 是否开启维护模式 */
+  enabled: boolean;
+  /** Maintenance reason / 维护原因 */
+  reason?: string;
+}
+
 /**
  * Request parameters for listing hosts
  * 获取主机列表的请求参数
@@ -241,6 +276,19 @@ export interface ListHostsRequest {
   agent_status?: AgentStatus;
   /** Filter by online status / 按在线状态过滤 */
   is_online?: boolean;
+  /**
+   * Label selector, e.g. "zone=az1,disk in (ssd,nvme)"
+   * 标签选择器，例如 "zone=az1,disk in (ssd,nvme)"
+   */
+  label_selector?: string;
+  /** Filter by cluster membership / 按所属集群过滤 */
+  cluster_id?: number;
+  /** Sort fields, e.g. "-created_at,name" / 排序字段，例如 "-created_at,name" */
+  sort?: string;
+  /** Created at or after (RFC3339 or YYYY-MM-DD) / 创建时间下限 */
+  start_time?: string;
+  /** Created at or before (RFC3339 or YYYY-MM-DD) / 创建时间上限 */
+  end_time?: string;
 }
 
 /**
@@ -293,7 +341,28 @@ export type UpdateHostResponse = BackendResponse<HostInfo>;
  * Delete host response type
  * 删除主机响应类型
  */
-export type DeleteHostResponse = BackendResponse<null>;
+export type DeleteHostResponse = BackendResponse<RecycledHost | null>;
+
+/**
+ * Host in the recycle bin
+ * 回收站中的主机
+ */
+export interface RecycledHost extends HostInfo {
+  deleted_at: string;
+  purge_at: string;
+}
+
+/**
+ * List recycled hosts response type
+ * 列出回收站主机响应类型
+ */
+export type ListRecycledHostsResponse = BackendResponse<RecycledHost[]>;
+
+/**
+ * Restore host response type
+ * 恢复主机响应类型
+ */
+export type RestoreHostResponse = BackendResponse<HostInfo>;
 
 /**
  * Install command data
@@ -302,6 +371,8 @@ export type DeleteHostResponse = BackendResponse<null>;
 export interface InstallCommandData {
   /** Install command / 安装命令 */
   command: string;
+  /** Enrollment token expiry time / 注册令牌过期时间 */
+  expires_at: string;
 }
 
 /**
@@ -310,6 +381,131 @@ export interface InstallCommandData {
  */
 export type GetInstallCommandResponse = BackendResponse<InstallCommandData>;
 
+/**
+ * Request to import hosts in bulk
+ * 批量导入主机的请求
+ */
+export interface ImportHostsRequest {
+  hosts: CreateHostRequest[];
+}
+
+/**
+ * Rejected import row (row is the CSV line number or 1-based index)
+ * 被拒绝的导入行（row 为 CSV 行号或从 1 开始的序号）
+ */
+export interface HostImportError {
+  row: number;
+  name?: string;
+  message: string;
+}
+
+/**
+ * Imported host with its Agent install commands
+ * 已导入的主机及其 Agent 安装命令
+ */
+export interface ImportedHost {
+  host: HostInfo;
+  install_command?: string;
+  ssh_command?: string;
+}
+
+/**
+ * Host import result
+ * 主机导入结果
+ */
+export interface ImportHostsResult {
+  total: number;
+  created: number;
+  hosts: ImportedHost[];
+  errors?: HostImportError[];
+}
+
+/**
+ * Import hosts response type
+ * 批量导入主机响应类型
+ */
+export type ImportHostsResponse = BackendResponse<ImportHostsResult>;
+
+/**
+ * SSH authentication method
+ * SSH 认证方式
+ */
+export type SSHAuthType = 'password' | 'key';
+
+/**
+ * Save SSH credential request (username defaults to host ssh_user)
+ * 保存 SSH 凭证请求（username 默认使用主机的 ssh_user）
+ */
+export interface SaveSSHCredentialRequest {
+  username?: string;
+  auth_type: SSHAuthType;
+  password?: string;
+  private_key?: string;
+  passphrase?: string;
+}
+
+/**
+ * Stored SSH credential (secrets are never returned)
+ * 已保存的 SSH 凭证（不返回密文）
+ */
+export interface SSHCredentialInfo {
+  host_id: number;
+  username: string;
+  auth_type: SSHAuthType;
+  host_key_fingerprint?: string;
+  updated_at: string;
+}
+
+/**
+ * SSH deploy task status
+ * SSH 部署任务状态
+ */
+export type SSHDeployTaskStatus = 'pending' | 'running' | 'success' | 'failed';
+
+/**
+ * SSH Agent deploy task (log is omitted in lists)
+ * SSH Agent 部署任务（列表中不含日志）
+ */
+export interface SSHDeployTask {
+  id: number;
+  host_id: number;
+  status: SSHDeployTaskStatus;
+  step: string;
+  platform: string;
+  log?: string;
+  error?: string;
+  created_by: string;
+  started_at?: string;
+  finished_at?: string;
+  created_at: string;
+  updated_at: string;
+}
+
+export type SSHCredentialResponse = BackendResponse<SSHCredentialInfo>;
+export type SSHDeployTaskResponse = BackendResponse<SSHDeployTask>;
+export type ListSSHDeployTasksResponse = BackendResponse<SSHDeployTask[]>;
+
+/**
+ * Patch host labels request: set entries and remove keys
+ * 修改主机标签请求：设置条目并删除指定键
+ */
+export interface PatchHostLabelsRequest {
+  set?: Record<string, string>;
+  remove?: string[];
+}
+
+/**
+ * A label key with the values used across hosts
+ * 标签键及其在各主机上出现过的值
+ */
+export interface LabelValues {
+  key: string;
+  values: string[];
+}
+
+export type HostLabelsResponse = BackendResponse<Record<string, string>>;
+export type ListLabelValuesResponse = BackendResponse<LabelValues[]>;
+
 /**
  * Associated cluster info (returned when deletion fails due to cluster association)
  * 关联的集群信息（删除失败时返回）
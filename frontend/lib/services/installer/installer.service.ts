@@ -21,6 +21,7 @@
  */
 
 import apiClient from '../core/api-client';
+import {uploadResumable} from '../core/resumable-upload';
 import {localizeBackendText} from '@/lib/i18n/localize-text';
 import type {
   AvailableVersions,
@@ -30,23 +31,37 @@ import type {
   InstallationRequest,
   InstallationStatus,
   ListPackagesResponse,
+  VersionPolicy,
+  VersionPolicyResponse,
   GetPackageInfoResponse,
-  UploadChunkResponse,
   DeletePackageResponse,
   PrecheckResponse,
+  PrecheckRun,
+  PrecheckDiff,
+  ListPrecheckRunsResponse,
+  PrecheckRunResponse,
+  PrecheckDiffResponse,
   InstallResponse,
   DownloadTask,
   DownloadRequest,
   DownloadResponse,
   DownloadListResponse,
   MirrorSource,
+  MirrorProbeResult,
+  MirrorListResponse,
   RuntimeStorageValidationRequest,
   RuntimeStorageValidationResponse,
   RuntimeStorageValidationResult,
+  ResourceAdviceRequest,
+  ResourceAdviceResponse,
+  ResourceAdvice,
+  InstallationTemplate,
+  InstallationTemplateRequest,
+  InstallationTemplateResponse,
+  ListInstallationTemplatesResponse,
 } from './types';
 
 const API_PREFIX = '';
-const DEFAULT_UPLOAD_CHUNK_SIZE = 8 * 1024 * 1024; // 8MB
 
 // ==================== Package Management 安装包管理 ====================
 
@@ -62,6 +77,37 @@ export async function listPackages(): Promise<AvailableVersions> {
   return response.data.data!;
 }
 
+/**
+ * Get the installable version policy (admin only)
+ * 获取可安装版本策略（仅管理员）
+ */
+export async function getVersionPolicy(): Promise<VersionPolicy> {
+  const response = await apiClient.get<VersionPolicyResponse>(
+    '/admin/installer/version-policy',
+  );
+  if (response.data.error_msg) {
+    throw new Error(response.data.error_msg);
+  }
+  return response.data.data!;
+}
+
+/**
+ * Update the installable version policy (admin only)
+ * 更新可安装版本策略（仅管理员）
+ */
+export async function updateVersionPolicy(
+  policy: VersionPolicy,
+): Promise<VersionPolicy> {
+  const response = await apiClient.put<VersionPolicyResponse>(
+    '/admin/installer/version-policy',
+    policy,
+  );
+  if (response.data.error_msg) {
+    throw new Error(response.data.error_msg);
+  }
+  return response.data.data!;
+}
+
 /**
  * Get package info by version
  * 根据版本获取安装包信息
@@ -77,79 +123,20 @@ export async function getPackageInfo(version: string): Promise<PackageInfo> {
 }
 
 /**
- * Upload offline package
- * 上传离线安装包
+ * Upload offline package in resumable chunks
+ * 以可续传分片上传离线安装包
  */
 export async function uploadPackage(
   file: File,
   version: string,
   onProgress?: (percent: number) => void,
 ): Promise<PackageInfo> {
-  if (!file || file.size <= 0) {
-    throw new Error('上传文件不能为空 / Upload file is empty');
-  }
-
-  const totalSize = file.size;
-  const totalChunks = Math.ceil(totalSize / DEFAULT_UPLOAD_CHUNK_SIZE);
-  const uploadID = createChunkUploadID(version);
-  let finalPackage: PackageInfo | null = null;
-
-  onProgress?.(0);
-
-  for (let chunkIndex = 0; chunkIndex < totalChunks; chunkIndex += 1) {
-    const start = chunkIndex * DEFAULT_UPLOAD_CHUNK_SIZE;
-    const end = Math.min(start + DEFAULT_UPLOAD_CHUNK_SIZE, totalSize);
-    const chunkBlob = file.slice(start, end);
-
-    const formData = new FormData();
-    formData.append('file', chunkBlob, file.name);
-    formData.append('version', version);
-    formData.append('upload_id', uploadID);
-    formData.append('chunk_index', String(chunkIndex));
-    formData.append('total_chunks', String(totalChunks));
-    formData.append('total_size', String(totalSize));
-    formData.append('file_name', file.name);
-
-    const response = await apiClient.post<UploadChunkResponse>(
-      `${API_PREFIX}/packages/upload/chunk`,
-      formData,
-      {
-        headers: {
-          'Content-Type': 'multipart/form-data',
-        },
-      },
-    );
-    if (response.data.error_msg) {
-      throw new Error(response.data.error_msg);
-    }
-
-    const result = response.data.data;
-    if (!result) {
-      throw new Error('分片上传返回为空 / Empty chunk upload response');
-    }
-
-    const isLastChunk = chunkIndex === totalChunks - 1;
-    if (isLastChunk && result.completed && result.package) {
-      finalPackage = result.package;
-      onProgress?.(100);
-      continue;
-    }
-
-    const percent = Math.floor(((chunkIndex + 1) / totalChunks) * 100);
-    onProgress?.(Math.min(99, percent));
-  }
-
-  if (!finalPackage) {
-    throw new Error('分片上传未完成，请重试 / Chunk upload did not complete');
-  }
-
-  return finalPackage;
-}
-
-function createChunkUploadID(version: string): string {
-  const sanitizedVersion = version.replace(/[^0-9A-Za-z_-]/g, '_');
-  const randomPart = Math.random().toString(36).slice(2, 10);
-  return `upload_${Date.now()}_${sanitizedVersion}_${randomPart}`.slice(0, 120);
+  return uploadResumable<PackageInfo>({
+    endpoint: `${API_PREFIX}/packages/uploads`,
+    fields: {version},
+    file,
+    onProgress,
+  });
 }
 
 /**
@@ -185,6 +172,59 @@ export async function runPrecheck(
   return response.data.data!;
 }
 
+/**
+ * List stored precheck runs of a host, newest first
+ * 获取主机已保存的预检查记录，按时间倒序
+ */
+export async function listPrecheckHistory(
+  hostId: number | string,
+  limit?: number,
+): Promise<PrecheckRun[]> {
+  const response = await apiClient.get<ListPrecheckRunsResponse>(
+    `${API_PREFIX}/hosts/${hostId}/precheck/history`,
+    {params: limit ? {limit} : undefined},
+  );
+  if (response.data.error_msg) {
+    throw new Error(response.data.error_msg);
+  }
+  return response.data.data || [];
+}
+
+/**
+ * Get a stored precheck run
+ * 获取已保存的预检查记录
+ */
+export async function getPrecheckRun(
+  hostId: number | string,
+  runId: number,
+): Promise<PrecheckRun> {
+  const response = await apiClient.get<PrecheckRunResponse>(
+    `${API_PREFIX}/hosts/${hostId}/precheck/history/${runId}`,
+  );
+  if (response.data.error_msg) {
+    throw new Error(response.data.error_msg);
+  }
+  return response.data.data!;
+}
+
+/**
+ * Compare two precheck runs; by default the latest run against the last passing one
+ * 比较两次预检查记录；默认比较最新记录与最近一次通过的记录
+ */
+export async function diffPrecheckRuns(
+  hostId: number | string,
+  options?: {base?: number; target?: number},
+): Promise<PrecheckDiff> {
+  const response = await apiClient.get<PrecheckDiffResponse>(
+    `${API_PREFIX}/hosts/${hostId}/precheck/diff`,
+    {params: options},
+  );
+  if (response.data.error_msg) {
+    throw new Error(response.data.error_msg);
+  }
+  return response.data.data!;
+}
+
 /**
  * Validate runtime storage connectivity for checkpoint or IMAP.
  * 校验 checkpoint 或 IMAP 的运行时存储连通性。
@@ -212,6 +252,35 @@ export async function validateRuntimeStorage(
   };
 }
 
+/**
+ * Recommend deployment mode, heap sizes and slots for the hosts of a new cluster.
+ * 为新集群选定的主机推荐部署模式、堆内存与 slot 数量。
+ */
+export async function adviseResources(
+  request: ResourceAdviceRequest,
+): Promise<ResourceAdvice> {
+  const response = await apiClient.post<ResourceAdviceResponse>(
+    `${API_PREFIX}/installer/resource-advice`,
+    request,
+  );
+  if (response.data.error_msg) {
+    throw new Error(localizeBackendText(response.data.error_msg));
+  }
+  const advice = response.data.data!;
+  const localize = (warning: ResourceAdvice['warnings'][number]) => ({
+    ...warning,
+    message: localizeBackendText(warning.message),
+  });
+  return {
+    ...advice,
+    warnings: (advice.warnings || []).map(localize),
+    hosts: (advice.hosts || []).map((host) => ({
+      ...host,
+      warnings: (host.warnings || []).map(localize),
+    })),
+  };
+}
+
 // ==================== Installation 安装 ====================
 
 /**
@@ -340,6 +409,20 @@ export async function listDownloads(): Promise<DownloadTask[]> {
   return response.data.data || [];
 }
 
+/**
+ * List mirror availability and latency
+ * 获取镜像可用性和延迟
+ */
+export async function listMirrors(refresh = false): Promise<MirrorProbeResult[]> {
+  const response = await apiClient.get<MirrorListResponse>(`${API_PREFIX}/packages/mirrors`, {
+    params: refresh ? {refresh: true} : undefined,
+  });
+  if (response.data.error_msg) {
+    throw new Error(response.data.error_msg);
+  }
+  return response.data.data || [];
+}
+
 // ==================== Version Management 版本管理 ====================
 
 interface RefreshVersionsResponse {
@@ -362,6 +445,84 @@ export async function refreshVersions(): Promise<{ versions: string[]; warning?:
   };
 }
 
+// ==================== Installation Templates 安装模板 ====================
+
+/**
+ * List installation templates
+ * 获取安装模板列表
+ */
+export async function listInstallationTemplates(): Promise<InstallationTemplate[]> {
+  const response = await apiClient.get<ListInstallationTemplatesResponse>(
+    `${API_PREFIX}/installation-templates`
+  );
+  if (response.data.error_msg) {
+    throw new Error(response.data.error_msg);
+  }
+  return response.data.data || [];
+}
+
+/**
+ * Get installation template
+ * 获取安装模板
+ */
+export async function getInstallationTemplate(id: number): Promise<InstallationTemplate> {
+  const response = await apiClient.get<InstallationTemplateResponse>(
+    `${API_PREFIX}/installation-templates/${id}`
+  );
+  if (response.data.error_msg) {
+    throw new Error(response.data.error_msg);
+  }
+  return response.data.data!;
+}
+
+/**
+ * Create installation template
+ * 创建安装模板
+ */
+export async function createInstallationTemplate(
+  request: InstallationTemplateRequest
+): Promise<InstallationTemplate> {
+  const response = await apiClient.post<InstallationTemplateResponse>(
+    `${API_PREFIX}/installation-templates`,
+    request
+  );
+  if (response.data.error_msg) {
+    throw new Error(response.data.error_msg);
+  }
+  return response.data.data!;
+}
+
+/**
+ * Update installation template
+ * 更新安装模板
+ */
+export async function updateInstallationTemplate(
+  id: number,
+  request: InstallationTemplateRequest
+): Promise<InstallationTemplate> {
+  const response = await apiClient.put<InstallationTemplateResponse>(
+    `${API_PREFIX}/installation-templates/${id}`,
+    request
+  );
+  if (response.data.error_msg) {
+    throw new Error(response.data.error_msg);
+  }
+  return response.data.data!;
+}
+
+/**
+ * Delete installation template
+ * 删除安装模板
+ */
+export async function deleteInstallationTemplate(id: number): Promise<void> {
+  const response = await apiClient.delete<InstallationTemplateResponse>(
+    `${API_PREFIX}/installation-templates/${id}`
+  );
+  if (response.data.error_msg) {
+    throw new Error(response.data.error_msg);
+  }
+}
+
 // ==================== Export all functions 导出所有函数 ====================
 
 export const installerService = {
@@ -375,14 +536,25 @@ export const installerService = {
   getDownloadStatus,
   cancelDownload,
   listDownloads,
+  listMirrors,
   // Version management / 版本管理
   refreshVersions,
   // Precheck / 预检查
   runPrecheck,
+  listPrecheckHistory,
+  getPrecheckRun,
+  diffPrecheckRuns,
   validateRuntimeStorage,
+  adviseResources,
   // Installation / 安装
   startInstallation,
   getInstallationStatus,
   retryStep,
   cancelInstallation,
+  // Installation templates / 安装模板
+  listInstallationTemplates,
+  getInstallationTemplate,
+  createInstallationTemplate,
+  updateInstallationTemplate,
+  deleteInstallationTemplate,
 };
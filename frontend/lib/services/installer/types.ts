@@ -34,6 +34,12 @@ export type MirrorSource = 'aliyun' | 'apache' | 'huaweicloud';
  */
 export type InstallMode = 'online' | 'offline';
 
+/**
+ * Package delivery: pushed by the Control Plane or downloaded by the Agent
+ * 安装包分发方式：由控制面推送或由 Agent 直接下载
+ */
+export type PackageDelivery = 'push' | 'direct';
+
 /**
  * Deployment mode for SeaTunnel cluster
  * SeaTunnel 集群部署模式
@@ -65,11 +71,13 @@ export type InstallStep =
   | 'download'
   | 'verify'
   | 'extract'
+  | 'install_java'
   | 'configure_cluster'
   | 'configure_checkpoint'
   | 'configure_imap'
   | 'configure_jvm'
   | 'install_plugins'
+  | 'configure_systemd'
   | 'register_cluster'
   | 'complete';
 
@@ -110,6 +118,8 @@ export interface PackageInfo {
   file_name: string;
   file_size: number;
   checksum?: string;
+  sha512?: string; // Verified official SHA-512 / 已校验的官方 SHA-512
+  verified: boolean;
   download_urls: Record<MirrorSource, string>;
   is_local: boolean;
   local_path?: string;
@@ -125,8 +135,41 @@ export interface AvailableVersions {
   recommended_version: string;
   local_packages: PackageInfo[];
   version_capabilities: Record<string, SeaTunnelVersionCapabilities>;
+  /** Number of local packages before paging / 分页前的本地安装包总数 */
+  local_total?: number;
+  /** Active version policy, omitted when every version is allowed / 当前生效的版本策略，允许所有版本时省略 */
+  version_policy?: VersionPolicy;
+  /** Versions rejected by the policy and why / 被策略拒绝的版本及原因 */
+  unapproved_versions?: Record<string, string>;
+}
+
+/**
+ * Admin-managed policy for installable SeaTunnel versions
+ * 管理员维护的可安装 SeaTunnel 版本策略
+ */
+export interface VersionPolicy {
+  minimum_version: string;
+  allowed_versions: string[];
+  denied_versions: string[];
+  hide_unapproved: boolean;
+  updated_by?: string;
+  updated_at?: string;
 }
 
+/**
+ * Version-gated capability name
+ * 受版本控制的能力名称
+ */
+export type SeaTunnelCapability =
+  | 'dynamic_slot'
+  | 'slot_num'
+  | 'history_job_expire_minutes'
+  | 'scheduled_deletion_enable'
+  | 'job_schedule_strategy'
+  | 'slot_allocation_strategy'
+  | 'http_service'
+  | 'job_log_mode';
+
 /**
  * Version-aware runtime capability metadata
  * 版本感知运行时能力元数据
@@ -148,6 +191,8 @@ export interface SeaTunnelVersionCapabilities {
   default_slot_allocation_strategy: SlotAllocationStrategy;
   default_http_enabled: boolean;
   default_job_log_mode: JobLogMode;
+  /** First version supporting each capability / 各能力的最低支持版本 */
+  min_versions?: Record<SeaTunnelCapability, string>;
 }
 
 /**
@@ -180,6 +225,38 @@ export interface JVMConfig {
   worker_heap_size: number;
 }
 
+/**
+ * Log rotation and log disk quota configuration
+ * 日志滚动与日志磁盘配额配置
+ */
+export interface LogConfig {
+  /** Roll engine logs at this size in MB / 引擎日志滚动大小（MB） */
+  max_file_size_mb?: number;
+  /** Rolled files kept per log / 每个日志保留的滚动文件数 */
+  max_backups?: number;
+  /** Gzip rolled engine logs / 压缩滚动后的引擎日志 */
+  compress?: boolean;
+  /** Enable rotated JVM GC logs / 开启带滚动的 GC 日志 */
+  gc_log?: boolean;
+  /** Logs directory quota in MB enforced by the Agent / Agent 执行的日志目录配额（MB） */
+  disk_quota_mb?: number;
+}
+
+/**
+ * Advanced Hazelcast member network configuration for NAT and multi-NIC hosts
+ * 适用于 NAT 与多网卡主机的 Hazelcast 成员高级网络配置
+ */
+export interface HazelcastNetworkConfig {
+  /** host[:port] this node advertises to other members / 本节点向其他成员公布的 host[:port] */
+  public_address?: string;
+  /** NICs to bind, as IPv4 CIDRs or Hazelcast patterns / 绑定的网卡，IPv4 CIDR 或 Hazelcast 通配形式 */
+  interfaces?: string[];
+  /** Member addresses replacing the derived member-list / 替换推导出的 member-list 的成员地址 */
+  advertised_addresses?: string[];
+  /** Enabled REST endpoint groups, must keep CLUSTER_WRITE and DATA / 启用的 REST 端点组，必须包含 CLUSTER_WRITE 与 DATA */
+  rest_endpoint_groups?: string[];
+}
+
 /**
  * Checkpoint storage configuration
  * 检查点存储配置
@@ -287,10 +364,39 @@ export interface InstallationRequest {
   history_job_expire_minutes?: number;
   scheduled_deletion_enable?: boolean;
   job_log_mode?: JobLogMode;
+  log?: LogConfig;
   jvm?: JVMConfig;
   checkpoint?: CheckpointConfig;
   imap?: IMAPConfig;
   connector?: ConnectorConfig;
+  hazelcast?: HazelcastNetworkConfig;
+  enable_systemd?: boolean; // Register a systemd unit for boot persistence / 注册 systemd unit 以便开机自启动
+  auto_install_java?: boolean; // Install OpenJDK 11 when no supported Java is found / 未找到受支持的 Java 时安装 OpenJDK 11
+  java_mirror?: 'tsinghua' | 'adoptium'; // OpenJDK download source / OpenJDK 下载源
+  use_suggested_ports?: boolean; // Replace busy ports with free ones / 用空闲端口替换被占用端口
+  template_id?: number; // Installation template; request fields override it / 安装模板，请求字段优先
+  skip_package_verification?: boolean; // Transfer without a verified official SHA-512 / 跳过官方 SHA-512 校验
+  package_delivery?: PackageDelivery; // push (default) or direct download by the Agent / push（默认）或由 Agent 直接下载
+  priority?: number; // Higher runs first when installations queue / 安装排队时数值高者优先
+  skip_steps?: InstallStep[]; // Optional steps left out by the Agent / 由 Agent 跳过的可选步骤
+}
+
+/**
+ * Result of a hook run before or after an installation step
+ * 安装步骤前后执行的钩子结果
+ */
+export interface HookResult {
+  name: string;
+  step: InstallStep;
+  phase: 'pre' | 'post';
+  type: 'script' | 'webhook';
+  success: boolean;
+  exit_code?: number;
+  status_code?: number;
+  output?: string;
+  error?: string;
+  started_at: string;
+  duration_ms: number;
 }
 
 /**
@@ -308,6 +414,7 @@ export interface StepInfo {
   start_time?: string;
   end_time?: string;
   retryable: boolean;
+  hooks?: HookResult[];
 }
 
 /**
@@ -318,6 +425,8 @@ export interface InstallationStatus {
   id: string;
   host_id: string;
   cluster_id?: string;
+  command_id?: string;
+  task_id?: string;
   status: StepStatus;
   current_step: InstallStep;
   steps: StepInfo[];
@@ -327,6 +436,20 @@ export interface InstallationStatus {
   warnings?: string[];
   start_time: string;
   end_time?: string;
+  transfer?: TransferProgress;
+  queue_position?: number; // Position while waiting for a concurrency slot / 等待并发名额时的排队位置
+}
+
+/**
+ * Package transfer throughput and ETA
+ * 安装包传输吞吐量和预计剩余时间
+ */
+export interface TransferProgress {
+  sent_bytes: number;
+  total_bytes: number;
+  percent: number;
+  bytes_per_second: number;
+  eta_seconds: number;
 }
 
 // ==================== Precheck Types 预检查类型 ====================
@@ -341,6 +464,16 @@ export interface PrecheckRequest {
   min_disk_space_mb?: number;
   install_dir?: string;
   ports?: number[];
+  version?: string;
+  expansion_factor?: number;
+  cluster_port?: number;
+  worker_port?: number;
+  http_port?: number;
+  /** Range scanned for free alternatives to busy ports / 为被占用端口查找替代端口的扫描范围 */
+  port_range_start?: number;
+  port_range_end?: number;
+  /** Checkpoint storage to validate with a write/read/delete round trip / 需进行写入/读取/删除往返校验的 checkpoint 存储 */
+  checkpoint?: CheckpointConfig;
 }
 
 /**
@@ -352,6 +485,8 @@ export interface PrecheckItem {
   status: CheckStatus;
   message: string;
   details?: Record<string, unknown>;
+  /** Suggested fix when the check did not pass / 检查未通过时的修复建议 */
+  suggestion?: string;
 }
 
 /**
@@ -362,6 +497,67 @@ export interface PrecheckResult {
   items: PrecheckItem[];
   overall_status: CheckStatus;
   summary: string;
+  /** Free alternatives for busy ports, mergeable into InstallationRequest / 被占用端口的替代端口，可直接合并到安装请求 */
+  suggested_ports?: SuggestedPorts;
+  /** ID of the stored history record / 已保存的历史记录 ID */
+  run_id?: number;
+}
+
+/**
+ * Suggested alternative ports
+ * 建议的替代端口
+ */
+export interface SuggestedPorts {
+  cluster_port?: number;
+  worker_port?: number;
+  http_port?: number;
+}
+
+/**
+ * Stored precheck run
+ * 已保存的预检查记录
+ */
+export interface PrecheckRun {
+  id: number;
+  host_id: number;
+  overall_status: CheckStatus;
+  summary: string;
+  install_dir: string;
+  version: string;
+  items: PrecheckItem[];
+  created_at: string;
+}
+
+/**
+ * How a check differs between two runs
+ * 某项检查在两次运行之间的差异类型
+ */
+export type PrecheckChangeType = 'added' | 'removed' | 'changed';
+
+/**
+ * Difference of one check between two runs
+ * 某项检查在两次运行之间的差异
+ */
+export interface PrecheckItemChange {
+  name: string;
+  change: PrecheckChangeType;
+  before?: PrecheckItem;
+  after?: PrecheckItem;
+  /** Detail keys whose values differ / 取值发生变化的详情字段 */
+  changed_details?: string[];
+  /** Whether the check status got worse / 检查状态是否变差 */
+  regressed: boolean;
+}
+
+/**
+ * Comparison of two precheck runs
+ * 两次预检查记录的对比
+ */
+export interface PrecheckDiff {
+  /** Last passing run, or the previous run when none passed / 最近一次通过的记录，若没有则为上一次记录 */
+  base: PrecheckRun | null;
+  target: PrecheckRun;
+  changes: PrecheckItemChange[];
 }
 
 // ==================== API Response Types API 响应类型 ====================
@@ -375,6 +571,15 @@ export interface ListPackagesResponse {
   data: AvailableVersions | null;
 }
 
+/**
+ * Version policy response
+ * 版本策略响应
+ */
+export interface VersionPolicyResponse {
+  error_msg: string;
+  data: VersionPolicy | null;
+}
+
 /**
  * Get package info response
  * 获取安装包信息响应
@@ -432,6 +637,33 @@ export interface PrecheckResponse {
   data: PrecheckResult | null;
 }
 
+/**
+ * Precheck history response
+ * 预检查历史响应
+ */
+export interface ListPrecheckRunsResponse {
+  error_msg: string;
+  data: PrecheckRun[] | null;
+}
+
+/**
+ * Precheck run response
+ * 预检查记录响应
+ */
+export interface PrecheckRunResponse {
+  error_msg: string;
+  data: PrecheckRun | null;
+}
+
+/**
+ * Precheck diff response
+ * 预检查对比响应
+ */
+export interface PrecheckDiffResponse {
+  error_msg: string;
+  data: PrecheckDiff | null;
+}
+
 /**
  * Installation response
  * 安装响应
@@ -470,6 +702,7 @@ export interface DownloadTask {
   speed: number;
   message?: string;
   error?: string;
+  verified: boolean;
   start_time: string;
   end_time?: string;
 }
@@ -528,6 +761,68 @@ export interface RuntimeStorageValidationResponse {
   data: RuntimeStorageValidationResult | null;
 }
 
+/**
+ * Resource advice request for the hosts of a new cluster
+ * 新集群选定主机的资源规划请求
+ */
+export interface ResourceAdviceRequest {
+  host_ids: number[];
+  deployment_mode?: DeploymentMode;
+  master_host_ids?: number[];
+  /** Planned heap sizes checked against host memory / 需要与主机内存比对的计划堆内存 */
+  jvm?: JVMConfig;
+}
+
+/**
+ * Resource warning for an undersized host
+ * 资源不足主机的告警
+ */
+export interface ResourceWarning {
+  code:
+    | 'resource_unknown'
+    | 'memory_low'
+    | 'cpu_low'
+    | 'disk_low'
+    | 'heap_exceeds_memory';
+  host_id: number;
+  host_name?: string;
+  message: string;
+}
+
+/**
+ * Reported capacity and recommendation of one host
+ * 单台主机的上报容量与推荐配置
+ */
+export interface HostResourceAdvice {
+  host_id: number;
+  host_name?: string;
+  role: NodeRole;
+  cpu_cores: number;
+  memory_gb: number;
+  free_disk_gb: number;
+  recommended_heap_gb: number;
+  warnings?: ResourceWarning[];
+}
+
+/**
+ * Recommended deployment for the chosen hosts
+ * 选定主机的推荐部署方案
+ */
+export interface ResourceAdvice {
+  deployment_mode: DeploymentMode;
+  master_host_ids?: number[];
+  jvm: JVMConfig;
+  dynamic_slot: boolean;
+  slot_num: number;
+  hosts: HostResourceAdvice[];
+  warnings: ResourceWarning[];
+}
+
+export interface ResourceAdviceResponse {
+  error_msg: string;
+  data: ResourceAdvice | null;
+}
+
 /**
  * Download response
  * 下载响应
@@ -545,3 +840,89 @@ export interface DownloadListResponse {
   error_msg: string;
   data: DownloadTask[] | null;
 }
+
+/**
+ * Mirror probe result
+ * 镜像探测结果
+ */
+export interface MirrorProbeResult {
+  mirror: MirrorSource;
+  base_url: string;
+  available: boolean;
+  latency_ms: number;
+  status_code?: number;
+  error?: string;
+  checked_at: string;
+}
+
+/**
+ * Mirror list response
+ * 镜像列表响应
+ */
+export interface MirrorListResponse {
+  error_msg: string;
+  data: MirrorProbeResult[] | null;
+}
+
+// ==================== Installation Template Types 安装模板类型 ====================
+
+/**
+ * Reusable installation settings stored in a template
+ * 模板中保存的可复用安装配置
+ */
+export interface InstallationTemplateSpec {
+  install_dir?: string;
+  install_mode?: InstallMode;
+  mirror?: MirrorSource;
+  deployment_mode?: DeploymentMode;
+  cluster_port?: number;
+  worker_port?: number;
+  http_port?: number;
+  enable_http?: boolean;
+  jvm?: JVMConfig;
+  checkpoint?: CheckpointConfig;
+  imap?: IMAPConfig;
+  connector?: ConnectorConfig;
+}
+
+/**
+ * Installation template
+ * 安装模板
+ */
+export interface InstallationTemplate {
+  id: number;
+  name: string;
+  description: string;
+  spec: InstallationTemplateSpec;
+  created_by: number;
+  created_at: string;
+  updated_at: string;
+}
+
+/**
+ * Installation template create/update request
+ * 安装模板创建/更新请求
+ */
+export interface InstallationTemplateRequest {
+  name: string;
+  description?: string;
+  spec: InstallationTemplateSpec;
+}
+
+/**
+ * Installation template response
+ * 安装模板响应
+ */
+export interface InstallationTemplateResponse {
+  error_msg: string;
+  data: InstallationTemplate | null;
+}
+
+/**
+ * Installation template list response
+ * 安装模板列表响应
+ */
+export interface ListInstallationTemplatesResponse {
+  error_msg: string;
+  data: InstallationTemplate[] | null;
+}
@@ -42,6 +42,10 @@ import type {
   NotificationChannel,
   NotificationChannelConnectionTestResult,
   NotificationChannelDraftTestRequest,
+  HeartbeatAlertFilterParams,
+  HeartbeatAlertListData,
+  HeartbeatAlertRule,
+  HeartbeatAlertRuleListData,
   NotificationChannelListData,
   NotificationDeliveryFilterParams,
   NotificationDeliveryListData,
@@ -50,13 +54,17 @@ import type {
   NotifiableUserListData,
   NotificationRoute,
   NotificationRouteListData,
+  PlatformEventSubscription,
+  PlatformEventSubscriptionListData,
   PlatformHealthData,
   RemoteAlertFilterParams,
   RemoteAlertListData,
   SilenceAlertRequest,
   UpsertAlertPolicyRequest,
+  UpsertHeartbeatAlertRuleRequest,
   UpsertNotificationChannelRequest,
   UpsertNotificationRouteRequest,
+  UpsertPlatformEventSubscriptionRequest,
   UpdateAlertRuleRequest,
 } from './types';
 
@@ -527,6 +535,98 @@ export class MonitoringService extends BaseService {
     return this.delete<{id: number}>(`/notification-routes/${id}`);
   }
 
+  /**
+   * List platform event subscriptions.
+   * 获取平台事件订阅列表。
+   */
+  static async listPlatformEventSubscriptions(): Promise<PlatformEventSubscriptionListData> {
+    return this.get<PlatformEventSubscriptionListData>('/event-subscriptions');
+  }
+
+  /**
+   * Create platform event subscription.
+   * 创建平台事件订阅。
+   */
+  static async createPlatformEventSubscription(
+    payload: UpsertPlatformEventSubscriptionRequest,
+  ): Promise<PlatformEventSubscription> {
+    return this.post<PlatformEventSubscription>('/event-subscriptions', payload);
+  }
+
+  /**
+   * Update platform event subscription.
+   * 更新平台事件订阅。
+   */
+  static async updatePlatformEventSubscription(
+    id: number,
+    payload: UpsertPlatformEventSubscriptionRequest,
+  ): Promise<PlatformEventSubscription> {
+    return this.put<PlatformEventSubscription>(
+      `/event-subscriptions/${id}`,
+      payload,
+    );
+  }
+
+  /**
+   * Delete platform event subscription.
+   * 删除平台事件订阅。
+   */
+  static async deletePlatformEventSubscription(
+    id: number,
+  ): Promise<{id: number}> {
+    return this.delete<{id: number}>(`/event-subscriptions/${id}`);
+  }
+
+  /**
+   * List heartbeat metric alert rules.
+   * 获取心跳指标告警规则列表。
+   */
+  static async listHeartbeatAlertRules(): Promise<HeartbeatAlertRuleListData> {
+    return this.get<HeartbeatAlertRuleListData>('/heartbeat-rules');
+  }
+
+  /**
+   * Create heartbeat metric alert rule.
+   * 创建心跳指标告警规则。
+   */
+  static async createHeartbeatAlertRule(
+    payload: UpsertHeartbeatAlertRuleRequest,
+  ): Promise<HeartbeatAlertRule> {
+    return this.post<HeartbeatAlertRule>('/heartbeat-rules', payload);
+  }
+
+  /**
+   * Update heartbeat metric alert rule.
+   * 更新心跳指标告警规则。
+   */
+  static async updateHeartbeatAlertRule(
+    id: number,
+    payload: UpsertHeartbeatAlertRuleRequest,
+  ): Promise<HeartbeatAlertRule> {
+    return this.put<HeartbeatAlertRule>(`/heartbeat-rules/${id}`, payload);
+  }
+
+  /**
+   * Delete heartbeat metric alert rule.
+   * 删除心跳指标告警规则。
+   */
+  static async deleteHeartbeatAlertRule(id: number): Promise<{id: number}> {
+    return this.delete<{id: number}>(`/heartbeat-rules/${id}`);
+  }
+
+  /**
+   * List heartbeat metric alerts.
+   * 获取心跳指标告警列表。
+   */
+  static async listHeartbeatAlerts(
+    params?: HeartbeatAlertFilterParams,
+  ): Promise<HeartbeatAlertListData> {
+    return this.get<HeartbeatAlertListData>(
+      '/heartbeat-alerts',
+      params as Record<string, unknown> | undefined,
+    );
+  }
+
   // ==================== Safe Methods 安全方法 ====================
 
   static async getOverviewSafe(): Promise<{
@@ -507,7 +507,8 @@ export type NotificationChannelType =
   | 'email'
   | 'wecom'
   | 'dingtalk'
-  | 'feishu';
+  | 'feishu'
+  | 'slack';
 
 export type NotificationEmailSecurity = 'none' | 'starttls' | 'ssl';
 
@@ -610,7 +611,123 @@ export interface NotificationRouteListData {
   routes: NotificationRoute[];
 }
 
-export type NotificationDeliveryEventType = 'firing' | 'resolved' | 'test';
+export type PlatformEventType =
+  | 'agent_offline'
+  | 'installation_failed'
+  | 'node_crash'
+  | 'upgrade_completed'
+  | 'upgrade_failed'
+  | 'metric_alert'
+  | 'metric_recovered';
+
+export interface PlatformEventSubscription {
+  id: number;
+  name: string;
+  enabled: boolean;
+  channel_id: number;
+  event_types: PlatformEventType[];
+  cluster_id?: string;
+  description?: string;
+  created_at: string;
+  updated_at: string;
+}
+
+export interface PlatformEventSubscriptionListData {
+  generated_at: string;
+  total: number;
+  event_types: PlatformEventType[];
+  subscriptions: PlatformEventSubscription[];
+}
+
+export interface UpsertPlatformEventSubscriptionRequest {
+  name: string;
+  enabled?: boolean;
+  channel_id: number;
+  event_types?: PlatformEventType[];
+  cluster_id?: string;
+  description?: string;
+}
+
+export type HeartbeatMetric = 'cpu_usage' | 'memory_usage' | 'disk_usage';
+export type HeartbeatAlertOperator = '>' | '>=' | '<' | '<=';
+export type HeartbeatAlertStatus = 'pending' | 'firing' | 'resolved';
+
+export interface HeartbeatAlertRule {
+  id: number;
+  name: string;
+  enabled: boolean;
+  metric: HeartbeatMetric;
+  operator: HeartbeatAlertOperator;
+  threshold: number;
+  duration_seconds: number;
+  severity: AlertSeverity;
+  host_id: number;
+  send_recovery: boolean;
+  description?: string;
+  created_at: string;
+  updated_at: string;
+}
+
+export interface HeartbeatAlertRuleListData {
+  generated_at: string;
+  total: number;
+  rules: HeartbeatAlertRule[];
+}
+
+export interface UpsertHeartbeatAlertRuleRequest {
+  name: string;
+  enabled?: boolean;
+  metric: HeartbeatMetric;
+  operator: HeartbeatAlertOperator;
+  threshold: number;
+  duration_seconds?: number;
+  severity?: AlertSeverity;
+  host_id?: number;
+  send_recovery?: boolean;
+  description?: string;
+}
+
+export interface HeartbeatAlert {
+  id: number;
+  rule_id: number;
+  rule_name: string;
+  host_id: number;
+  host_name: string;
+  metric: HeartbeatMetric;
+  operator: HeartbeatAlertOperator;
+  threshold: number;
+  severity: AlertSeverity;
+  status: HeartbeatAlertStatus;
+  last_value: number;
+  started_at: string;
+  fired_at?: string | null;
+  resolved_at?: string | null;
+  last_evaluated_at: string;
+  created_at: string;
+  updated_at: string;
+}
+
+export interface HeartbeatAlertFilterParams {
+  status?: HeartbeatAlertStatus;
+  host_id?: number;
+  rule_id?: number;
+  page?: number;
+  page_size?: number;
+}
+
+export interface HeartbeatAlertListData {
+  generated_at: string;
+  total: number;
+  page: number;
+  page_size: number;
+  alerts: HeartbeatAlert[];
+}
+
+export type NotificationDeliveryEventType =
+  | 'firing'
+  | 'resolved'
+  | 'test'
+  | PlatformEventType;
 export type NotificationDeliveryStatus =
   | 'pending'
   | 'sending'
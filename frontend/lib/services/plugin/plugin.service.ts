@@ -22,6 +22,7 @@
 
 import { BaseService } from '../core/base.service';
 import apiClient from '../core/api-client';
+import { uploadResumable } from '../core/resumable-upload';
 import type {
   Plugin,
   InstalledPlugin,
@@ -31,6 +32,9 @@ import type {
   PluginDownloadProgress,
   LocalPlugin,
   PluginInstallStatus,
+  BulkPluginOperationRequest,
+  RetryPluginOperationRequest,
+  PluginOperation,
   PluginDependencyConfig,
   AddDependencyRequest,
   UploadDependencyRequest,
@@ -39,6 +43,9 @@ import type {
   OfficialDependenciesResponse,
   AnalyzeOfficialDependenciesRequest,
   DownloadAllPluginsRequest,
+  PluginIndexInfo,
+  CustomPlugin,
+  UploadCustomPluginRequest,
 } from './types';
 
 // Import DownloadAllPluginsProgress type / 导入下载所有插件进度类型
@@ -295,6 +302,58 @@ export class PluginService extends BaseService {
     );
   }
 
+  // ==================== Bulk Plugin Operations 批量插件操作 ====================
+
+  /**
+   * Install or uninstall several plugins on every node of a cluster
+   * 在集群所有节点上批量安装或卸载插件
+   * @param clusterId - Cluster ID / 集群 ID
+   * @param request - Bulk operation request / 批量操作请求
+   * @returns Started operation / 已启动的操作
+   */
+  static async startPluginOperation(
+    clusterId: number,
+    request: BulkPluginOperationRequest
+  ): Promise<PluginOperation> {
+    return this.post<PluginOperation>(
+      `/clusters/${clusterId}/plugins/operations`,
+      request
+    );
+  }
+
+  /**
+   * Get per-node, per-plugin progress of a bulk operation
+   * 获取批量操作按节点、按插件的进度
+   * @param clusterId - Cluster ID / 集群 ID
+   * @param operationId - Operation ID / 操作 ID
+   */
+  static async getPluginOperation(
+    clusterId: number,
+    operationId: string
+  ): Promise<PluginOperation> {
+    return this.get<PluginOperation>(
+      `/clusters/${clusterId}/plugins/operations/${encodeURIComponent(operationId)}`
+    );
+  }
+
+  /**
+   * Retry failed nodes of a bulk operation
+   * 重试批量操作中的失败节点
+   * @param clusterId - Cluster ID / 集群 ID
+   * @param operationId - Operation ID / 操作 ID
+   * @param request - Optional plugin/node filters / 可选的插件/节点过滤
+   */
+  static async retryPluginOperation(
+    clusterId: number,
+    operationId: string,
+    request: RetryPluginOperationRequest = {}
+  ): Promise<PluginOperation> {
+    return this.post<PluginOperation>(
+      `/clusters/${clusterId}/plugins/operations/${encodeURIComponent(operationId)}/retry`,
+      request
+    );
+  }
+
   // ==================== Plugin Dependency Config 插件依赖配置 ====================
 
   /**
@@ -426,4 +485,75 @@ export class PluginService extends BaseService {
       request,
     );
   }
+
+  /**
+   * Get the offline plugin index in use
+   * 获取当前使用的离线插件索引
+   */
+  static async getPluginIndex(): Promise<PluginIndexInfo> {
+    return this.get<PluginIndexInfo>('/plugins/index');
+  }
+
+  /**
+   * Refresh the offline plugin index from an uploaded file or the configured URL
+   * 通过上传文件或配置地址刷新离线插件索引
+   */
+  static async refreshPluginIndex(file?: File): Promise<PluginIndexInfo> {
+    const formData = new FormData();
+    if (file) {
+      formData.append('file', file);
+    }
+    const response = await apiClient.post<{
+      error_msg: string;
+      data: PluginIndexInfo | null;
+    }>(`${this.basePath}/plugins/index/refresh`, formData, {
+      headers: {
+        'Content-Type': 'multipart/form-data',
+      },
+    });
+    if (response.data.error_msg) {
+      throw new Error(response.data.error_msg);
+    }
+    if (!response.data.data) {
+      throw new Error('刷新插件索引返回为空 / Empty plugin index response');
+    }
+    return response.data.data;
+  }
+
+  /**
+   * List uploaded custom plugins
+   * 获取已上传的自定义插件
+   */
+  static async listCustomPlugins(version?: string): Promise<CustomPlugin[]> {
+    const params: Record<string, string> = {};
+    if (version) {
+      params.version = version;
+    }
+    return (await this.get<CustomPlugin[]>('/plugins/custom', params)) || [];
+  }
+
+  /**
+   * Upload a custom connector jar in resumable chunks
+   * 以可续传分片上传自定义连接器 jar
+   */
+  static async uploadCustomPlugin(
+    request: UploadCustomPluginRequest,
+    onProgress?: (percent: number) => void,
+  ): Promise<CustomPlugin> {
+    const { file, ...fields } = request;
+    return uploadResumable<CustomPlugin>({
+      endpoint: `${this.basePath}/plugins/custom/uploads`,
+      fields,
+      file,
+      onProgress,
+    });
+  }
+
+  /**
+   * Delete a custom plugin
+   * 删除自定义插件
+   */
+  static async deleteCustomPlugin(id: number): Promise<void> {
+    await this.delete<unknown>(`/plugins/custom/${id}`);
+  }
 }
@@ -44,7 +44,7 @@ export type MirrorSource = 'apache' | 'aliyun' | 'huaweicloud';
  * Available plugin list source
  * 可用插件列表来源
  */
-export type PluginListSource = 'database' | 'remote';
+export type PluginListSource = 'database' | 'remote' | 'index';
 
 /**
  * Official dependency status
@@ -86,6 +86,7 @@ export interface PluginDependency {
   target_dir: string; // connectors/ or lib/ or plugins/<mapping>
   source_type?: PluginDependencySource;
   original_file_name?: string;
+  resolved_from?: string; // 传递依赖来源坐标 / Root coordinate of a transitive dependency
 }
 
 /**
@@ -107,6 +108,7 @@ export interface Plugin {
   dependency_count?: number;
   dependency_baseline_version?: string;
   dependency_resolution_mode?: DependencyResolutionMode;
+  custom?: boolean; // 用户上传的自定义插件 / Uploaded custom plugin
 }
 
 /**
@@ -143,6 +145,7 @@ export interface InstallPluginRequest {
   version: string;
   mirror?: MirrorSource;
   profile_keys?: string[];
+  allow_unverified?: boolean; // 允许安装无法校验的 jar / Install unverifiable jars
 }
 
 /**
@@ -200,6 +203,8 @@ export interface GetPluginInfoResponse {
 export interface ListInstalledPluginsResponse {
   error_msg: string;
   data: InstalledPlugin[] | null;
+  /** Number of plugins matching the filter / 匹配过滤条件的插件总数 */
+  total?: number;
 }
 
 /**
@@ -241,6 +246,69 @@ export interface PluginInstallStatus {
   error?: string;
 }
 
+// ==================== Bulk Plugin Operation Types 批量插件操作类型 ====================
+
+export type PluginOperationAction = 'install' | 'uninstall';
+
+export type PluginOperationStatus =
+  | 'pending'
+  | 'running'
+  | 'succeeded'
+  | 'failed'
+  | 'partial_failed';
+
+export interface BulkPluginItem {
+  plugin_name: string;
+  version?: string; // 安装必填 / Required for install
+  profile_keys?: string[];
+}
+
+export interface BulkPluginOperationRequest {
+  action: PluginOperationAction;
+  plugins: BulkPluginItem[];
+  mirror?: MirrorSource;
+  max_parallel?: number; // 0 表示全部节点并发 / 0 = all nodes in parallel
+  allow_unverified?: boolean; // 允许安装无法校验的 jar / Install unverifiable jars
+}
+
+export interface RetryPluginOperationRequest {
+  plugin_names?: string[];
+  node_ids?: number[];
+}
+
+export interface PluginOperationNode {
+  node_id: number;
+  host_id: number;
+  agent_id?: string;
+  status: PluginOperationStatus;
+  attempts: number;
+  message?: string;
+  error?: string;
+  updated_at: string;
+}
+
+export interface PluginOperationPlugin {
+  plugin_name: string;
+  version?: string;
+  status: PluginOperationStatus;
+  progress: number;
+  message?: string;
+  error?: string;
+  nodes: PluginOperationNode[];
+}
+
+export interface PluginOperation {
+  id: string;
+  cluster_id: number;
+  action: PluginOperationAction;
+  status: PluginOperationStatus;
+  progress: number;
+  plugins: PluginOperationPlugin[];
+  created_at: string;
+  updated_at: string;
+  finished_at?: string;
+}
+
 // ==================== Plugin Download Types 插件下载类型 ====================
 
 /**
@@ -360,6 +428,7 @@ export interface PluginDependencyConfig {
   original_file_name?: string;
   file_size?: number;
   checksum?: string;
+  resolved_from?: string;
   created_at: string;
   updated_at: string;
 }
@@ -462,6 +531,8 @@ export interface AddDependencyRequest {
   version: string; // 必填 / Required
   seatunnel_version?: string;
   target_dir?: string;
+  resolve_transitive?: boolean; // 同时解析传递依赖 / Also resolve transitive dependencies
+  mirror?: MirrorSource;
 }
 
 export interface UploadDependencyRequest {
@@ -503,3 +574,49 @@ export interface DisableDependencyResponse {
   error_msg: string;
   data: PluginDependencyDisable | null;
 }
+
+/**
+ * Offline plugin index info
+ * 离线插件索引信息
+ */
+export interface PluginIndexInfo {
+  origin: 'bundled' | 'refreshed';
+  schema_version: number;
+  generated_at: string;
+  versions: string[];
+  plugin_count: number;
+  source_url: string;
+}
+
+/**
+ * Uploaded custom connector
+ * 用户上传的自定义连接器
+ */
+export interface CustomPlugin {
+  id: number;
+  name: string;
+  seatunnel_version: string;
+  display_name: string;
+  artifact_id: string;
+  group_id: string;
+  category: PluginCategory;
+  description: string;
+  doc_url?: string;
+  original_file_name: string;
+  file_size: number;
+  sha256: string;
+  uploaded_by?: string;
+  created_at: string;
+  updated_at: string;
+}
+
+export interface UploadCustomPluginRequest {
+  file: File;
+  name: string;
+  seatunnel_version?: string;
+  display_name?: string;
+  group_id?: string;
+  category?: PluginCategory;
+  description?: string;
+  doc_url?: string;
+}
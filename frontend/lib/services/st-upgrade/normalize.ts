@@ -16,6 +16,8 @@
  */
 
 import type {
+  CompatibilityItem,
+  CompatibilityReport,
   ConfigMergeFile,
   ConfigMergePlan,
   PrecheckResult,
@@ -61,6 +63,33 @@ export function sanitizeConfigMergePlan(plan?: ConfigMergePlan | null): ConfigMe
   };
 }
 
+function sanitizeCompatibilityItems(
+  items?: CompatibilityItem[] | null,
+): CompatibilityItem[] {
+  return Array.isArray(items)
+    ? items.map((item) => ({
+        ...item,
+        message: localizeUpgradeText(item.message),
+      }))
+    : [];
+}
+
+export function sanitizeCompatibilityReport(
+  report?: CompatibilityReport | null,
+): CompatibilityReport | undefined {
+  if (!report) {
+    return undefined;
+  }
+
+  return {
+    ...report,
+    config_changes: sanitizeCompatibilityItems(report.config_changes),
+    connectors: sanitizeCompatibilityItems(report.connectors),
+    checkpoint_notes: sanitizeCompatibilityItems(report.checkpoint_notes),
+    manual_actions: sanitizeCompatibilityItems(report.manual_actions),
+  };
+}
+
 export function sanitizePrecheckResult(precheck?: PrecheckResult | null): PrecheckResult | null {
   if (!precheck) {
     return null;
@@ -76,6 +105,7 @@ export function sanitizePrecheckResult(precheck?: PrecheckResult | null): Preche
       : [],
     node_targets: Array.isArray(precheck.node_targets) ? precheck.node_targets : [],
     config_merge_plan: sanitizeConfigMergePlan(precheck.config_merge_plan) || precheck.config_merge_plan,
+    compatibility_report: sanitizeCompatibilityReport(precheck.compatibility_report),
   };
 }
 
@@ -20,6 +20,8 @@
  * SeaTunnel 升级服务类型定义
  */
 
+import type {RollingOptions} from '../cluster/types';
+
 export type PlanStatus =
   | 'draft'
   | 'ready'
@@ -40,6 +42,8 @@ export type ExecutionStatus =
   | 'rollback_failed'
   | 'cancelled';
 
+export type UpgradeStrategy = 'stop_start' | 'rolling';
+
 export type StepCode =
   | 'PRECHECK_PACKAGE'
   | 'PRECHECK_CONNECTOR'
@@ -54,6 +58,7 @@ export type StepCode =
   | 'STOP_CLUSTER'
   | 'SWITCH_VERSION'
   | 'START_CLUSTER'
+  | 'ROLLING_RESTART'
   | 'HEALTH_CHECK'
   | 'SMOKE_TEST'
   | 'COMPLETE'
@@ -71,7 +76,12 @@ export type LogEventType =
   | 'failed'
   | 'rollback'
   | 'note';
-export type CheckCategory = 'package' | 'connector' | 'node' | 'config';
+export type CheckCategory =
+  | 'package'
+  | 'connector'
+  | 'node'
+  | 'config'
+  | 'compatibility';
 export type ConfigConflictStatus = 'pending' | 'resolved';
 export type AssetSource =
   | 'local_package'
@@ -186,6 +196,32 @@ export interface PlanStep {
   required: boolean;
 }
 
+export type CompatibilitySeverity = 'info' | 'warning' | 'fail';
+export type CompatibilityStatus = 'pass' | 'warning' | 'fail';
+
+export interface CompatibilityItem {
+  severity: CompatibilitySeverity;
+  code: string;
+  subject?: string;
+  message: string;
+  metadata?: Record<string, string>;
+}
+
+/**
+ * Pre-upgrade compatibility report / 升级前兼容性报告
+ */
+export interface CompatibilityReport {
+  source_version: string;
+  target_version: string;
+  status: CompatibilityStatus;
+  acknowledged: boolean;
+  config_changes: CompatibilityItem[];
+  connectors: CompatibilityItem[];
+  checkpoint_notes: CompatibilityItem[];
+  manual_actions: CompatibilityItem[];
+  generated_at: string;
+}
+
 export interface UpgradePlanSnapshot {
   cluster_id: number;
   deployment_mode?: string;
@@ -196,6 +232,9 @@ export interface UpgradePlanSnapshot {
   config_merge_plan: ConfigMergePlan;
   node_targets: NodeTarget[];
   steps: PlanStep[];
+  strategy?: UpgradeStrategy;
+  rolling?: RollingOptions;
+  compatibility_report?: CompatibilityReport;
   generated_at: string;
 }
 
@@ -215,6 +254,7 @@ export interface PrecheckResult {
   package_manifest?: PackageManifest;
   connector_manifest?: ConnectorManifest;
   config_merge_plan?: ConfigMergePlan;
+  compatibility_report?: CompatibilityReport;
   node_targets: NodeTarget[];
   generated_at: string;
 }
@@ -227,10 +267,15 @@ export interface PrecheckRequest {
   connector_names?: string[];
   node_ids?: number[];
   target_install_dir?: string;
+  /** Accept compatibility report warnings / 确认兼容性报告中的警告 */
+  acknowledge_warnings?: boolean;
 }
 
 export interface CreatePlanRequest extends PrecheckRequest {
   config_merge_plan?: ConfigMergePlan;
+  /** Defaults to stop_start / 默认为 stop_start */
+  strategy?: UpgradeStrategy;
+  rolling?: RollingOptions;
 }
 
 export interface ExecutePlanRequest {
@@ -0,0 +1,289 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package admin
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/seatunnel/seatunnelX/internal/apps/audit"
+	"github.com/seatunnel/seatunnelX/internal/apps/auth"
+	"github.com/seatunnel/seatunnelX/internal/db"
+	"github.com/seatunnel/seatunnelX/internal/logger"
+	"gorm.io/gorm"
+)
+
+// ==================== 项目（租户）管理 ====================
+
+// CreateProjectRequest 创建项目请求
+type CreateProjectRequest struct {
+	Name        string `json:"name" binding:"required,max=100"`
+	Description string `json:"description"`
+}
+
+// ProjectResponse 单个项目响应
+type ProjectResponse struct {
+	ErrorMsg string        `json:"error_msg"`
+	Data     *auth.Project `json:"data"`
+}
+
+// ProjectListResponse 项目列表响应
+type ProjectListResponse struct {
+	ErrorMsg string          `json:"error_msg"`
+	Data     []*auth.Project `json:"data"`
+}
+
+// ReplaceProjectMembersRequest 替换项目成员请求
+type ReplaceProjectMembersRequest struct {
+	UserIDs []uint64 `json:"user_ids"`
+}
+
+// ProjectMembersResponse 项目成员响应
+type ProjectMembersResponse struct {
+	ErrorMsg string   `json:"error_msg"`
+	Data     []uint64 `json:"data"`
+}
+
+// TransferOwnershipRequest 资源归属转移请求
+type TransferOwnershipRequest struct {
+	ResourceType string `json:"resource_type" binding:"required"` // host / cluster
+	ResourceID   uint   `json:"resource_id" binding:"required"`
+	ProjectID    uint   `json:"project_id"` // 目标项目，0 表示释放为共享资源
+	// IncludeHosts 转移集群时一并转移其节点所在的主机
+	IncludeHosts bool `json:"include_hosts"`
+}
+
+// TransferOwnershipResponse 资源归属转移响应
+type TransferOwnershipResponse struct {
+	ErrorMsg string                 `json:"error_msg"`
+	Data     *TransferOwnershipData `json:"data"`
+}
+
+// TransferOwnershipData 资源归属转移结果
+type TransferOwnershipData struct {
+	ResourceType string `json:"resource_type"`
+	ResourceID   uint   `json:"resource_id"`
+	ProjectID    uint   `json:"project_id"`
+	HostIDs      []uint `json:"host_ids,omitempty"`
+}
+
+// projectStatusCode 将项目相关错误映射为 HTTP 状态码
+func projectStatusCode(err error) int {
+	switch {
+	case errors.Is(err, auth.ErrProjectNotFound), errors.Is(err, auth.ErrTenantResourceAbsent):
+		return http.StatusNotFound
+	case errors.Is(err, auth.ErrProjectAlreadyExists):
+		return http.StatusConflict
+	case errors.Is(err, auth.ErrProjectNameEmpty), errors.Is(err, auth.ErrTenantResourceType):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// ListProjectsHandler 获取所有项目
+// @Tags admin
+// @Produce json
+// @Success 200 {object} ProjectListResponse
+// @Router /api/v1/admin/projects [get]
+func ListProjectsHandler(c *gin.Context) {
+	projects, err := auth.ListProjects(db.DB(c.Request.Context()))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ProjectListResponse{ErrorMsg: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, ProjectListResponse{Data: projects})
+}
+
+// CreateProjectHandler 创建项目
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param request body CreateProjectRequest true "项目信息"
+// @Success 200 {object} ProjectResponse
+// @Router /api/v1/admin/projects [post]
+func CreateProjectHandler(c *gin.Context) {
+	var req CreateProjectRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ProjectResponse{ErrorMsg: err.Error()})
+		return
+	}
+
+	project := &auth.Project{Name: req.Name, Description: req.Description}
+	if err := auth.CreateProject(db.DB(c.Request.Context()), project); err != nil {
+		c.JSON(projectStatusCode(err), ProjectResponse{ErrorMsg: err.Error()})
+		return
+	}
+
+	auditRepo := audit.NewRepository(db.DB(c.Request.Context()))
+	_ = audit.RecordFromGin(c, auditRepo, auth.GetUserIDFromContext(c), auth.GetUsernameFromContext(c),
+		"create", "project", audit.UintID(project.ID), project.Name, audit.AuditDetails{"trigger": "manual"})
+	logger.InfoF(c.Request.Context(), "[Admin] 创建项目成功: %s", project.Name)
+	c.JSON(http.StatusOK, ProjectResponse{Data: project})
+}
+
+// DeleteProjectHandler 删除项目，项目下的主机与集群释放为共享资源
+// @Tags admin
+// @Produce json
+// @Param id path int true "项目ID"
+// @Success 200 {object} ProjectResponse
+// @Router /api/v1/admin/projects/{id} [delete]
+func DeleteProjectHandler(c *gin.Context) {
+	projectID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ProjectResponse{ErrorMsg: "无效的项目 ID"})
+		return
+	}
+
+	project, err := auth.GetProject(db.DB(c.Request.Context()), uint(projectID))
+	if err != nil {
+		c.JSON(projectStatusCode(err), ProjectResponse{ErrorMsg: err.Error()})
+		return
+	}
+	if err := auth.DeleteProject(db.DB(c.Request.Context()), project.ID); err != nil {
+		c.JSON(projectStatusCode(err), ProjectResponse{ErrorMsg: err.Error()})
+		return
+	}
+
+	auditRepo := audit.NewRepository(db.DB(c.Request.Context()))
+	_ = audit.RecordFromGin(c, auditRepo, auth.GetUserIDFromContext(c), auth.GetUsernameFromContext(c),
+		"delete", "project", audit.UintID(project.ID), project.Name, audit.AuditDetails{"trigger": "manual"})
+	logger.InfoF(c.Request.Context(), "[Admin] 删除项目成功: %s", project.Name)
+	c.JSON(http.StatusOK, ProjectResponse{Data: project})
+}
+
+// ListProjectMembersHandler 获取项目成员用户 ID
+// @Tags admin
+// @Produce json
+// @Param id path int true "项目ID"
+// @Success 200 {object} ProjectMembersResponse
+// @Router /api/v1/admin/projects/{id}/members [get]
+func ListProjectMembersHandler(c *gin.Context) {
+	projectID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ProjectMembersResponse{ErrorMsg: "无效的项目 ID"})
+		return
+	}
+	if _, err := auth.GetProject(db.DB(c.Request.Context()), uint(projectID)); err != nil {
+		c.JSON(projectStatusCode(err), ProjectMembersResponse{ErrorMsg: err.Error()})
+		return
+	}
+
+	userIDs, err := auth.ListProjectMembers(db.DB(c.Request.Context()), uint(projectID))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ProjectMembersResponse{ErrorMsg: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, ProjectMembersResponse{Data: userIDs})
+}
+
+// ReplaceProjectMembersHandler 整体替换项目成员
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path int true "项目ID"
+// @Param request body ReplaceProjectMembersRequest true "成员用户 ID 列表"
+// @Success 200 {object} ProjectMembersResponse
+// @Router /api/v1/admin/projects/{id}/members [put]
+func ReplaceProjectMembersHandler(c *gin.Context) {
+	projectID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ProjectMembersResponse{ErrorMsg: "无效的项目 ID"})
+		return
+	}
+
+	var req ReplaceProjectMembersRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ProjectMembersResponse{ErrorMsg: err.Error()})
+		return
+	}
+	for _, userID := range req.UserIDs {
+		if _, err := auth.FindByID(db.DB(c.Request.Context()), userID); err != nil {
+			c.JSON(http.StatusBadRequest, ProjectMembersResponse{ErrorMsg: "用户不存在: " + strconv.FormatUint(userID, 10)})
+			return
+		}
+	}
+
+	if err := auth.ReplaceProjectMembers(db.DB(c.Request.Context()), uint(projectID), req.UserIDs); err != nil {
+		c.JSON(projectStatusCode(err), ProjectMembersResponse{ErrorMsg: err.Error()})
+		return
+	}
+
+	auditRepo := audit.NewRepository(db.DB(c.Request.Context()))
+	_ = audit.RecordFromGin(c, auditRepo, auth.GetUserIDFromContext(c), auth.GetUsernameFromContext(c),
+		"update", "project_members", audit.UintID(uint(projectID)), "",
+		audit.AuditDetails{"trigger": "manual", "members": len(req.UserIDs)})
+	logger.InfoF(c.Request.Context(), "[Admin] 更新项目成员成功: project=%d, %d 人", projectID, len(req.UserIDs))
+
+	userIDs, err := auth.ListProjectMembers(db.DB(c.Request.Context()), uint(projectID))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ProjectMembersResponse{ErrorMsg: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, ProjectMembersResponse{Data: userIDs})
+}
+
+// TransferOwnershipHandler 将主机或集群转移到其他项目
+// 转移集群时可通过 include_hosts 一并转移其节点所在的主机，避免出现跨项目节点
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param request body TransferOwnershipRequest true "转移信息"
+// @Success 200 {object} TransferOwnershipResponse
+// @Router /api/v1/admin/ownership/transfer [post]
+func TransferOwnershipHandler(c *gin.Context) {
+	var req TransferOwnershipRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, TransferOwnershipResponse{ErrorMsg: err.Error()})
+		return
+	}
+
+	resourceType := auth.ResourceType(req.ResourceType)
+	data := &TransferOwnershipData{ResourceType: req.ResourceType, ResourceID: req.ResourceID, ProjectID: req.ProjectID}
+	err := db.DB(c.Request.Context()).Transaction(func(tx *gorm.DB) error {
+		if err := auth.TransferResource(tx, resourceType, req.ResourceID, req.ProjectID); err != nil {
+			return err
+		}
+		if resourceType != auth.ResourceCluster || !req.IncludeHosts {
+			return nil
+		}
+		if err := tx.Table("cluster_nodes").Where("cluster_id = ?", req.ResourceID).
+			Distinct().Pluck("host_id", &data.HostIDs).Error; err != nil {
+			return err
+		}
+		for _, hostID := range data.HostIDs {
+			if err := auth.TransferResource(tx, auth.ResourceHost, hostID, req.ProjectID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		c.JSON(projectStatusCode(err), TransferOwnershipResponse{ErrorMsg: err.Error()})
+		return
+	}
+
+	auditRepo := audit.NewRepository(db.DB(c.Request.Context()))
+	_ = audit.RecordFromGin(c, auditRepo, auth.GetUserIDFromContext(c), auth.GetUsernameFromContext(c),
+		"transfer", req.ResourceType, audit.UintID(req.ResourceID), "",
+		audit.AuditDetails{"trigger": "manual", "project_id": req.ProjectID, "hosts": len(data.HostIDs)})
+	logger.InfoF(c.Request.Context(), "[Admin] 资源归属转移成功: %s %d -> project %d", req.ResourceType, req.ResourceID, req.ProjectID)
+	c.JSON(http.StatusOK, TransferOwnershipResponse{Data: data})
+}
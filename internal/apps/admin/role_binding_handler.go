@@ -0,0 +1,147 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package admin
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/seatunnel/seatunnelX/internal/apps/audit"
+	"github.com/seatunnel/seatunnelX/internal/apps/auth"
+	"github.com/seatunnel/seatunnelX/internal/db"
+	"github.com/seatunnel/seatunnelX/internal/logger"
+)
+
+// ==================== 资源级角色绑定 ====================
+
+// RoleBindingItem 单条资源级角色绑定
+type RoleBindingItem struct {
+	ResourceType string `json:"resource_type" binding:"required"` // cluster / project
+	ResourceID   uint   `json:"resource_id" binding:"required"`
+	Role         string `json:"role" binding:"required"` // admin / operator / viewer
+}
+
+// ReplaceRoleBindingsRequest 替换用户角色绑定请求
+type ReplaceRoleBindingsRequest struct {
+	Bindings []RoleBindingItem `json:"bindings"`
+}
+
+// RoleBindingsResponse 用户角色绑定响应
+type RoleBindingsResponse struct {
+	ErrorMsg string              `json:"error_msg"`
+	Data     []*auth.RoleBinding `json:"data"`
+}
+
+// ListRoleBindingsHandler 获取用户的资源级角色绑定
+// @Tags admin
+// @Produce json
+// @Param id path int true "用户ID"
+// @Success 200 {object} RoleBindingsResponse
+// @Router /api/v1/admin/users/{id}/role-bindings [get]
+func ListRoleBindingsHandler(c *gin.Context) {
+	userID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, RoleBindingsResponse{ErrorMsg: "无效的用户 ID"})
+		return
+	}
+	if _, err := auth.FindByID(db.DB(c.Request.Context()), userID); err != nil {
+		c.JSON(http.StatusNotFound, RoleBindingsResponse{ErrorMsg: "用户不存在"})
+		return
+	}
+
+	bindings, err := auth.ListRoleBindings(db.DB(c.Request.Context()), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, RoleBindingsResponse{ErrorMsg: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, RoleBindingsResponse{Data: bindings})
+}
+
+// ReplaceRoleBindingsHandler 整体替换用户的资源级角色绑定
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path int true "用户ID"
+// @Param request body ReplaceRoleBindingsRequest true "角色绑定列表"
+// @Success 200 {object} RoleBindingsResponse
+// @Router /api/v1/admin/users/{id}/role-bindings [put]
+func ReplaceRoleBindingsHandler(c *gin.Context) {
+	userID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, RoleBindingsResponse{ErrorMsg: "无效的用户 ID"})
+		return
+	}
+
+	var req ReplaceRoleBindingsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, RoleBindingsResponse{ErrorMsg: err.Error()})
+		return
+	}
+
+	user, err := auth.FindByID(db.DB(c.Request.Context()), userID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, RoleBindingsResponse{ErrorMsg: "用户不存在"})
+		return
+	}
+
+	bindings := make([]*auth.RoleBinding, 0, len(req.Bindings))
+	seen := make(map[string]bool, len(req.Bindings))
+	for _, item := range req.Bindings {
+		resourceType, err := auth.ParseResourceType(item.ResourceType)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, RoleBindingsResponse{ErrorMsg: err.Error()})
+			return
+		}
+		role, err := auth.ParseRole(item.Role)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, RoleBindingsResponse{ErrorMsg: err.Error()})
+			return
+		}
+		key := fmt.Sprintf("%s/%d", resourceType, item.ResourceID)
+		if seen[key] {
+			c.JSON(http.StatusBadRequest, RoleBindingsResponse{ErrorMsg: "重复的资源角色绑定: " + key})
+			return
+		}
+		seen[key] = true
+		bindings = append(bindings, &auth.RoleBinding{
+			ResourceType: resourceType,
+			ResourceID:   item.ResourceID,
+			Role:         role,
+		})
+	}
+
+	if err := auth.ReplaceRoleBindings(db.DB(c.Request.Context()), userID, bindings); err != nil {
+		c.JSON(http.StatusInternalServerError, RoleBindingsResponse{ErrorMsg: err.Error()})
+		return
+	}
+
+	auditRepo := audit.NewRepository(db.DB(c.Request.Context()))
+	_ = audit.RecordFromGin(c, auditRepo, auth.GetUserIDFromContext(c), auth.GetUsernameFromContext(c),
+		"update", "user_role_bindings", strconv.FormatUint(userID, 10), user.Username,
+		audit.AuditDetails{"trigger": "manual", "bindings": len(bindings)})
+	logger.InfoF(c.Request.Context(), "[Admin] 更新用户角色绑定成功: %s, %d 条", user.Username, len(bindings))
+
+	saved, err := auth.ListRoleBindings(db.DB(c.Request.Context()), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, RoleBindingsResponse{ErrorMsg: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, RoleBindingsResponse{Data: saved})
+}
@@ -0,0 +1,127 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package admin
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/seatunnel/seatunnelX/internal/apps/audit"
+	"github.com/seatunnel/seatunnelX/internal/apps/auth"
+	"github.com/seatunnel/seatunnelX/internal/db"
+	"github.com/seatunnel/seatunnelX/internal/logger"
+)
+
+// ==================== 会话管理 ====================
+
+// ListSessionsHandler 列出活跃会话，可按 user_id 过滤
+// @Tags admin
+// @Produce json
+// @Param user_id query int false "用户ID"
+// @Success 200 {object} auth.SessionListResponse
+// @Router /api/v1/admin/sessions [get]
+func ListSessionsHandler(c *gin.Context) {
+	var userID uint64
+	if raw := c.Query("user_id"); raw != "" {
+		parsed, err := strconv.ParseUint(raw, 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, auth.SessionListResponse{ErrorMsg: "无效的用户 ID"})
+			return
+		}
+		userID = parsed
+	}
+
+	records, err := auth.ListSessions(db.DB(c.Request.Context()), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, auth.SessionListResponse{ErrorMsg: err.Error()})
+		return
+	}
+	auth.MarkCurrentSession(c, records)
+	c.JSON(http.StatusOK, auth.SessionListResponse{Data: records})
+}
+
+// RevokeSessionHandler 强制下线指定会话
+// @Tags admin
+// @Produce json
+// @Param id path string true "会话ID"
+// @Success 200 {object} auth.RevokeSessionsResponse
+// @Router /api/v1/admin/sessions/{id} [delete]
+func RevokeSessionHandler(c *gin.Context) {
+	database := db.DB(c.Request.Context())
+	record, err := auth.FindSession(database, c.Param("id"))
+	if err != nil {
+		if errors.Is(err, auth.ErrSessionNotFound) {
+			c.JSON(http.StatusNotFound, auth.RevokeSessionsResponse{ErrorMsg: "会话不存在"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, auth.RevokeSessionsResponse{ErrorMsg: err.Error()})
+		return
+	}
+	if err := auth.RevokeSession(database, record.ID); err != nil && !errors.Is(err, auth.ErrSessionNotFound) {
+		c.JSON(http.StatusInternalServerError, auth.RevokeSessionsResponse{ErrorMsg: err.Error()})
+		return
+	}
+
+	auditRepo := audit.NewRepository(database)
+	_ = audit.RecordFromGin(c, auditRepo, auth.GetUserIDFromContext(c), auth.GetUsernameFromContext(c),
+		"revoke_session", "user", strconv.FormatUint(record.UserID, 10), record.Username,
+		audit.AuditDetails{"trigger": "manual", "ip_address": record.IPAddress})
+	logger.InfoF(c.Request.Context(), "[Admin] 强制下线会话成功: %s", record.Username)
+	c.JSON(http.StatusOK, auth.RevokeSessionsResponse{Data: gin.H{"revoked": 1}})
+}
+
+// RevokeUserSessionsHandler 强制下线指定用户的所有会话
+// @Tags admin
+// @Produce json
+// @Param id path int true "用户ID"
+// @Success 200 {object} auth.RevokeSessionsResponse
+// @Router /api/v1/admin/users/{id}/sessions [delete]
+func RevokeUserSessionsHandler(c *gin.Context) {
+	userID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, auth.RevokeSessionsResponse{ErrorMsg: "无效的用户 ID"})
+		return
+	}
+
+	database := db.DB(c.Request.Context())
+	user, err := auth.FindByID(database, userID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, auth.RevokeSessionsResponse{ErrorMsg: "用户不存在"})
+		return
+	}
+
+	// 管理员下线自己时保留当前会话
+	exceptID := ""
+	if userID == auth.GetUserIDFromContext(c) {
+		exceptID = auth.CurrentSessionID(c)
+	}
+	revoked, err := auth.RevokeUserSessions(database, userID, exceptID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, auth.RevokeSessionsResponse{ErrorMsg: err.Error()})
+		return
+	}
+
+	auditRepo := audit.NewRepository(database)
+	_ = audit.RecordFromGin(c, auditRepo, auth.GetUserIDFromContext(c), auth.GetUsernameFromContext(c),
+		"revoke_sessions", "user", strconv.FormatUint(userID, 10), user.Username,
+		audit.AuditDetails{"trigger": "manual", "revoked": revoked})
+	logger.InfoF(c.Request.Context(), "[Admin] 强制下线用户会话成功: %s count=%d", user.Username, revoked)
+	c.JSON(http.StatusOK, auth.RevokeSessionsResponse{Data: gin.H{"revoked": revoked}})
+}
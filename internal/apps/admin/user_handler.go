@@ -115,6 +115,7 @@ type CreateUserRequest struct {
 	Nickname string `json:"nickname" binding:"max=100"`
 	Email    string `json:"email" binding:"omitempty,max=255,email"`
 	IsAdmin  bool   `json:"is_admin"`
+	Role     string `json:"role"` // admin / operator / viewer，为空时按 is_admin 推导
 }
 
 // CreateUserResponse 创建用户响应
@@ -144,13 +145,27 @@ func CreateUserHandler(c *gin.Context) {
 		return
 	}
 
+	// 解析角色，角色与 is_admin 保持一致
+	role := auth.DefaultRole
+	if req.IsAdmin {
+		role = auth.RoleAdmin
+	}
+	if req.Role != "" {
+		role, err = auth.ParseRole(req.Role)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, CreateUserResponse{ErrorMsg: err.Error()})
+			return
+		}
+	}
+
 	// 创建用户
 	user := &auth.User{
 		Username: req.Username,
 		Nickname: strings.TrimSpace(req.Nickname),
 		Email:    strings.TrimSpace(req.Email),
 		IsActive: true,
-		IsAdmin:  req.IsAdmin,
+		IsAdmin:  role == auth.RoleAdmin,
+		Role:     string(role),
 	}
 
 	// 设置密码
@@ -181,6 +196,7 @@ type UpdateUserRequest struct {
 	Email    *string `json:"email" binding:"omitempty,max=255,email"`
 	IsActive *bool   `json:"is_active"`
 	IsAdmin  *bool   `json:"is_admin"`
+	Role     *string `json:"role"` // admin / operator / viewer
 }
 
 // UpdateUserResponse 更新用户响应
@@ -231,6 +247,28 @@ func UpdateUserHandler(c *gin.Context) {
 	}
 	if req.IsAdmin != nil {
 		updates["is_admin"] = *req.IsAdmin
+		if *req.IsAdmin {
+			updates["role"] = string(auth.RoleAdmin)
+		} else if user.EffectiveRole() == auth.RoleAdmin {
+			updates["role"] = string(auth.DefaultRole)
+		}
+	}
+	if req.Role != nil {
+		role, err := auth.ParseRole(*req.Role)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, UpdateUserResponse{ErrorMsg: err.Error()})
+			return
+		}
+		updates["role"] = string(role)
+		updates["is_admin"] = role == auth.RoleAdmin
+	}
+
+	// 不允许取消自己的管理员权限，避免系统失去管理员
+	if userID == auth.GetUserIDFromContext(c) {
+		if isAdmin, ok := updates["is_admin"].(bool); ok && !isAdmin {
+			c.JSON(http.StatusBadRequest, UpdateUserResponse{ErrorMsg: "不能取消当前登录用户的管理员权限"})
+			return
+		}
 	}
 
 	// 更新密码
@@ -250,6 +288,18 @@ func UpdateUserHandler(c *gin.Context) {
 		}
 	}
 
+	// 禁用用户或重置密码后强制下线其已有会话（修改自己时保留当前会话）
+	deactivated := req.IsActive != nil && !*req.IsActive
+	if deactivated || req.Password != "" {
+		exceptID := ""
+		if userID == auth.GetUserIDFromContext(c) {
+			exceptID = auth.CurrentSessionID(c)
+		}
+		if _, err := auth.RevokeUserSessions(db.DB(c.Request.Context()), userID, exceptID); err != nil {
+			logger.ErrorF(c.Request.Context(), "[Admin] 下线用户会话失败: %s, %v", user.Username, err)
+		}
+	}
+
 	// 重新查询用户信息
 	user, _ = auth.FindByID(db.DB(c.Request.Context()), userID)
 
@@ -301,6 +351,9 @@ func DeleteUserHandler(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, DeleteUserResponse{ErrorMsg: err.Error()})
 		return
 	}
+	if _, err := auth.RevokeUserSessions(db.DB(c.Request.Context()), userID, ""); err != nil {
+		logger.ErrorF(c.Request.Context(), "[Admin] 下线用户会话失败: %s, %v", user.Username, err)
+	}
 
 	auditRepo := audit.NewRepository(db.DB(c.Request.Context()))
 	_ = audit.RecordFromGin(c, auditRepo, auth.GetUserIDFromContext(c), auth.GetUsernameFromContext(c),
@@ -0,0 +1,404 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package agent
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	pb "github.com/seatunnel/seatunnelX/internal/proto/agent"
+)
+
+// Default values for registration admission control.
+// 注册准入控制的默认值。
+const (
+	// DefaultRegistrationRateLimit is the number of registrations allowed per IP per minute.
+	// DefaultRegistrationRateLimit 是每个 IP 每分钟允许的注册次数。
+	DefaultRegistrationRateLimit = 30
+
+	// pendingAgentTTL is how long a pending Agent stays listed without retrying registration.
+	// pendingAgentTTL 是待审批 Agent 在未重试注册时保留在列表中的时长。
+	pendingAgentTTL = 10 * time.Minute
+)
+
+// Admission errors.
+// 准入相关错误。
+var (
+	// ErrRegistrationRateLimited indicates too many registrations from one IP address.
+	// ErrRegistrationRateLimited 表示同一 IP 的注册过于频繁。
+	ErrRegistrationRateLimited = errors.New("agent: registration rate limit exceeded for this IP")
+
+	// ErrAgentDenied indicates the Agent ID or IP address is on the deny-list.
+	// ErrAgentDenied 表示 Agent ID 或 IP 地址在拒绝列表中。
+	ErrAgentDenied = errors.New("agent: registration denied")
+
+	// ErrAgentPendingApproval indicates the Agent must be approved by an admin before it is admitted.
+	// ErrAgentPendingApproval 表示 Agent 需经管理员审批后才能接入。
+	ErrAgentPendingApproval = errors.New("agent: pending admin approval")
+
+	// ErrPendingAgentNotFound indicates no pending Agent matches the given ID.
+	// ErrPendingAgentNotFound 表示没有匹配的待审批 Agent。
+	ErrPendingAgentNotFound = errors.New("agent: pending agent not found")
+
+	// ErrInvalidDenyRule indicates a deny rule with an unknown type or empty value.
+	// ErrInvalidDenyRule 表示拒绝规则类型未知或值为空。
+	ErrInvalidDenyRule = errors.New("agent: invalid deny rule")
+
+	// ErrDenyRuleNotFound indicates the deny rule does not exist.
+	// ErrDenyRuleNotFound 表示拒绝规则不存在。
+	ErrDenyRuleNotFound = errors.New("agent: deny rule not found")
+
+	// ErrAdmissionStoreUnavailable indicates approvals and deny rules are not configured.
+	// ErrAdmissionStoreUnavailable 表示未配置审批与拒绝规则存储。
+	ErrAdmissionStoreUnavailable = errors.New("agent: admission store is not configured")
+)
+
+// DenyRuleType is what a deny rule matches on.
+// DenyRuleType 是拒绝规则的匹配对象。
+type DenyRuleType string
+
+const (
+	// DenyRuleTypeIP matches the Agent IP address.
+	// DenyRuleTypeIP 匹配 Agent IP 地址。
+	DenyRuleTypeIP DenyRuleType = "ip"
+	// DenyRuleTypeAgentID matches the Agent ID.
+	// DenyRuleTypeAgentID 匹配 Agent ID。
+	DenyRuleTypeAgentID DenyRuleType = "agent_id"
+)
+
+// AgentApproval records an Agent admitted by an admin.
+// AgentApproval 记录经管理员审批接入的 Agent。
+type AgentApproval struct {
+	ID         uint      `json:"id" gorm:"primaryKey;autoIncrement"`
+	AgentID    string    `json:"agent_id" gorm:"size:100;not null;uniqueIndex"`
+	IPAddress  string    `json:"ip_address" gorm:"size:50"`
+	Hostname   string    `json:"hostname" gorm:"size:255"`
+	ApprovedBy uint      `json:"approved_by"`
+	CreatedAt  time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// TableName specifies the Agent approval table name.
+// TableName 指定 Agent 审批表名。
+func (AgentApproval) TableName() string {
+	return "agent_approvals"
+}
+
+// AgentDenyRule blocks registrations from an IP address or Agent ID.
+// AgentDenyRule 拒绝来自某个 IP 地址或 Agent ID 的注册。
+type AgentDenyRule struct {
+	ID        uint         `json:"id" gorm:"primaryKey;autoIncrement"`
+	Type      DenyRuleType `json:"type" gorm:"size:20;not null;uniqueIndex:idx_agent_deny_rule"`
+	Value     string       `json:"value" gorm:"size:255;not null;uniqueIndex:idx_agent_deny_rule"`
+	Reason    string       `json:"reason" gorm:"size:500"`
+	CreatedBy uint         `json:"created_by"`
+	CreatedAt time.Time    `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// TableName specifies the Agent deny rule table name.
+// TableName 指定 Agent 拒绝规则表名。
+func (AgentDenyRule) TableName() string {
+	return "agent_deny_rules"
+}
+
+// matches reports whether the rule applies to the given Agent.
+// matches 判断规则是否适用于给定 Agent。
+func (r *AgentDenyRule) matches(agentID, ipAddress string) bool {
+	switch r.Type {
+	case DenyRuleTypeIP:
+		return r.Value == ipAddress
+	case DenyRuleTypeAgentID:
+		return r.Value == agentID
+	}
+	return false
+}
+
+// PendingAgent is an Agent waiting for admin approval.
+// PendingAgent 是等待管理员审批的 Agent。
+type PendingAgent struct {
+	AgentID      string    `json:"agent_id"`
+	Hostname     string    `json:"hostname"`
+	IPAddress    string    `json:"ip_address"`
+	Version      string    `json:"version"`
+	OSType       string    `json:"os_type"`
+	Arch         string    `json:"arch"`
+	FirstSeenAt  time.Time `json:"first_seen_at"`
+	LastSeenAt   time.Time `json:"last_seen_at"`
+	AttemptCount int       `json:"attempt_count"`
+}
+
+// AdmissionStore persists Agent approvals and deny rules.
+// AdmissionStore 持久化 Agent 审批记录与拒绝规则。
+type AdmissionStore interface {
+	IsAgentApproved(ctx context.Context, agentID, ipAddress, hostname string) (bool, error)
+	CreateApproval(ctx context.Context, approval *AgentApproval) error
+	ListDenyRules(ctx context.Context) ([]*AgentDenyRule, error)
+	CreateDenyRule(ctx context.Context, rule *AgentDenyRule) error
+	DeleteDenyRule(ctx context.Context, id uint) error
+}
+
+// ipRateLimiter is a per-IP token bucket refilled at limit tokens per minute.
+// ipRateLimiter 是按 IP 划分的令牌桶，每分钟补充 limit 个令牌。
+type ipRateLimiter struct {
+	mu      sync.Mutex
+	limit   int
+	buckets map[string]*tokenBucket
+}
+
+// tokenBucket is the rate limit state of one IP address.
+// tokenBucket 是单个 IP 地址的限流状态。
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// newIPRateLimiter creates a limiter; limit <= 0 disables rate limiting.
+// newIPRateLimiter 创建限流器；limit <= 0 表示不限流。
+func newIPRateLimiter(limit int) *ipRateLimiter {
+	return &ipRateLimiter{limit: limit, buckets: make(map[string]*tokenBucket)}
+}
+
+// allow consumes one token for ip and reports whether the registration may proceed.
+// allow 为 ip 消耗一个令牌，并返回是否允许本次注册。
+func (l *ipRateLimiter) allow(ip string, now time.Time) bool {
+	if l.limit <= 0 {
+		return true
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	capacity := float64(l.limit)
+	bucket, ok := l.buckets[ip]
+	if !ok {
+		bucket = &tokenBucket{tokens: capacity, last: now}
+		l.buckets[ip] = bucket
+	}
+	bucket.tokens += now.Sub(bucket.last).Minutes() * capacity
+	if bucket.tokens > capacity {
+		bucket.tokens = capacity
+	}
+	bucket.last = now
+
+	// Full buckets carry no state; drop them so the map does not grow with every IP ever seen.
+	// 满令牌的桶没有状态，删除以免映射随出现过的 IP 无限增长。
+	for key, b := range l.buckets {
+		if key != ip && now.Sub(b.last) > time.Minute {
+			delete(l.buckets, key)
+		}
+	}
+
+	if bucket.tokens < 1 {
+		return false
+	}
+	bucket.tokens--
+	return true
+}
+
+// SetAdmissionStore sets the store for Agent approvals and deny rules.
+// SetAdmissionStore 设置 Agent 审批记录与拒绝规则的存储。
+func (m *Manager) SetAdmissionStore(store AdmissionStore) {
+	m.admissionStore = store
+}
+
+// CheckRegistration runs the admission checks of a registration without registering the Agent
+// or recording it as pending, so a dry run leaves no state behind.
+// CheckRegistration 执行注册的准入检查，但不注册 Agent 也不记录为待审批，试运行不会留下任何状态。
+func (m *Manager) CheckRegistration(ctx context.Context, req *pb.RegisterRequest) error {
+	return m.checkAdmission(ctx, req, false)
+}
+
+// admitRegistration applies rate limiting, the deny-list and pending approval to a registration.
+// admitRegistration 对注册请求依次执行限流、拒绝列表与待审批检查。
+func (m *Manager) admitRegistration(ctx context.Context, req *pb.RegisterRequest) error {
+	return m.checkAdmission(ctx, req, true)
+}
+
+// checkAdmission implements the admission checks; recordPending controls whether an unapproved
+// Agent is added to the pending list.
+// checkAdmission 实现准入检查；recordPending 控制是否将未审批的 Agent 加入待审批列表。
+func (m *Manager) checkAdmission(ctx context.Context, req *pb.RegisterRequest, recordPending bool) error {
+	now := time.Now()
+	if !m.registrationLimiter.allow(req.IpAddress, now) {
+		return ErrRegistrationRateLimited
+	}
+	if m.admissionStore == nil {
+		return nil
+	}
+
+	rules, err := m.admissionStore.ListDenyRules(ctx)
+	if err != nil {
+		return err
+	}
+	for _, rule := range rules {
+		if rule.matches(req.AgentId, req.IpAddress) {
+			return ErrAgentDenied
+		}
+	}
+
+	if !m.config.RequireApproval {
+		return nil
+	}
+	approved, err := m.admissionStore.IsAgentApproved(ctx, req.AgentId, req.IpAddress, req.Hostname)
+	if err != nil {
+		return err
+	}
+	if approved {
+		return nil
+	}
+	if recordPending {
+		m.recordPendingAgent(req, now)
+	}
+	return ErrAgentPendingApproval
+}
+
+// pendingKey identifies a pending Agent by host, since Agents without a configured ID get a new
+// generated ID on every registration attempt.
+// pendingKey 按主机标识待审批 Agent，因为未配置 ID 的 Agent 每次注册都会获得新生成的 ID。
+func pendingKey(ipAddress, hostname string) string {
+	return ipAddress + "/" + hostname
+}
+
+// recordPendingAgent adds or refreshes a pending Agent.
+// recordPendingAgent 添加或刷新待审批 Agent。
+func (m *Manager) recordPendingAgent(req *pb.RegisterRequest, now time.Time) {
+	m.pendingMu.Lock()
+	defer m.pendingMu.Unlock()
+
+	key := pendingKey(req.IpAddress, req.Hostname)
+	pending, ok := m.pendingAgents[key]
+	if !ok {
+		pending = &PendingAgent{FirstSeenAt: now}
+		m.pendingAgents[key] = pending
+	}
+	pending.AgentID = req.AgentId
+	pending.Hostname = req.Hostname
+	pending.IPAddress = req.IpAddress
+	pending.Version = req.AgentVersion
+	pending.OSType = req.OsType
+	pending.Arch = req.Arch
+	pending.LastSeenAt = now
+	pending.AttemptCount++
+}
+
+// ListPendingAgents returns Agents waiting for approval, dropping those that stopped retrying.
+// ListPendingAgents 返回等待审批的 Agent，并移除已停止重试的条目。
+func (m *Manager) ListPendingAgents() []*PendingAgent {
+	m.pendingMu.Lock()
+	defer m.pendingMu.Unlock()
+
+	now := time.Now()
+	agents := make([]*PendingAgent, 0, len(m.pendingAgents))
+	for key, pending := range m.pendingAgents {
+		if now.Sub(pending.LastSeenAt) > pendingAgentTTL {
+			delete(m.pendingAgents, key)
+			continue
+		}
+		copied := *pending
+		agents = append(agents, &copied)
+	}
+	return agents
+}
+
+// ApproveAgent admits a pending Agent; it is registered normally on its next registration attempt.
+// The approval covers both the Agent ID and its host (IP address and hostname).
+// ApproveAgent 审批通过待审批 Agent；其下一次注册时将正常接入。
+// 审批同时覆盖 Agent ID 及其所在主机（IP 地址与主机名）。
+func (m *Manager) ApproveAgent(ctx context.Context, agentID string, approvedBy uint) (*AgentApproval, error) {
+	if m.admissionStore == nil {
+		return nil, ErrAdmissionStoreUnavailable
+	}
+	var pending PendingAgent
+	found := false
+	m.pendingMu.Lock()
+	for _, candidate := range m.pendingAgents {
+		if candidate.AgentID == agentID {
+			pending, found = *candidate, true
+			break
+		}
+	}
+	m.pendingMu.Unlock()
+	if !found {
+		return nil, ErrPendingAgentNotFound
+	}
+
+	approval := &AgentApproval{
+		AgentID:    agentID,
+		IPAddress:  pending.IPAddress,
+		Hostname:   pending.Hostname,
+		ApprovedBy: approvedBy,
+	}
+	if err := m.admissionStore.CreateApproval(ctx, approval); err != nil {
+		return nil, err
+	}
+
+	m.pendingMu.Lock()
+	delete(m.pendingAgents, pendingKey(pending.IPAddress, pending.Hostname))
+	m.pendingMu.Unlock()
+	return approval, nil
+}
+
+// ListDenyRules returns the deny-list.
+// ListDenyRules 返回拒绝列表。
+func (m *Manager) ListDenyRules(ctx context.Context) ([]*AgentDenyRule, error) {
+	if m.admissionStore == nil {
+		return nil, ErrAdmissionStoreUnavailable
+	}
+	return m.admissionStore.ListDenyRules(ctx)
+}
+
+// AddDenyRule adds a deny rule and drops matching connected and pending Agents.
+// Dropped Agents fail their next heartbeat, re-register and are then rejected.
+// AddDenyRule 添加拒绝规则，并移除匹配的已连接与待审批 Agent。
+// 被移除的 Agent 下一次心跳失败后会重新注册，随即被拒绝。
+func (m *Manager) AddDenyRule(ctx context.Context, rule *AgentDenyRule) error {
+	if m.admissionStore == nil {
+		return ErrAdmissionStoreUnavailable
+	}
+	rule.Value = strings.TrimSpace(rule.Value)
+	if rule.Value == "" || (rule.Type != DenyRuleTypeIP && rule.Type != DenyRuleTypeAgentID) {
+		return ErrInvalidDenyRule
+	}
+	if err := m.admissionStore.CreateDenyRule(ctx, rule); err != nil {
+		return err
+	}
+
+	for _, conn := range m.ListAgents() {
+		if rule.matches(conn.AgentID, conn.IPAddress) {
+			m.UnregisterAgent(conn.AgentID)
+		}
+	}
+	m.pendingMu.Lock()
+	for key, pending := range m.pendingAgents {
+		if rule.matches(pending.AgentID, pending.IPAddress) {
+			delete(m.pendingAgents, key)
+		}
+	}
+	m.pendingMu.Unlock()
+	return nil
+}
+
+// RemoveDenyRule deletes a deny rule.
+// RemoveDenyRule 删除拒绝规则。
+func (m *Manager) RemoveDenyRule(ctx context.Context, id uint) error {
+	if m.admissionStore == nil {
+		return ErrAdmissionStoreUnavailable
+	}
+	return m.admissionStore.DeleteDenyRule(ctx, id)
+}
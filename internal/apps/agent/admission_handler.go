@@ -0,0 +1,203 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package agent
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/seatunnel/seatunnelX/internal/apps/audit"
+	"github.com/seatunnel/seatunnelX/internal/apps/auth"
+	"github.com/seatunnel/seatunnelX/internal/pkg/errcode"
+)
+
+// AdmissionHandler provides admin HTTP handlers for Agent approvals and the deny-list.
+// AdmissionHandler 提供 Agent 审批与拒绝列表的管理员 HTTP 处理器。
+type AdmissionHandler struct {
+	manager   *Manager
+	auditRepo *audit.Repository
+}
+
+// NewAdmissionHandler creates a new AdmissionHandler instance.
+// NewAdmissionHandler 创建一个新的 AdmissionHandler 实例。
+// auditRepo may be nil; audit logging is skipped when nil.
+func NewAdmissionHandler(manager *Manager, auditRepo *audit.Repository) *AdmissionHandler {
+	return &AdmissionHandler{manager: manager, auditRepo: auditRepo}
+}
+
+// ==================== Request/Response Types 请求/响应类型 ====================
+
+// DenyRuleRequest is the payload for adding a deny rule.
+// DenyRuleRequest 是新增拒绝规则的请求体。
+type DenyRuleRequest struct {
+	Type   DenyRuleType `json:"type" binding:"required"`
+	Value  string       `json:"value" binding:"required"`
+	Reason string       `json:"reason"`
+}
+
+// PendingAgentsResponse represents the response for listing pending Agents.
+// PendingAgentsResponse 表示待审批 Agent 列表的响应。
+type PendingAgentsResponse struct {
+	ErrorMsg string          `json:"error_msg"`
+	Data     []*PendingAgent `json:"data"`
+}
+
+// AgentApprovalResponse represents the response carrying an approval.
+// AgentApprovalResponse 表示返回审批记录的响应。
+type AgentApprovalResponse struct {
+	ErrorMsg string         `json:"error_msg"`
+	Data     *AgentApproval `json:"data"`
+}
+
+// DenyRulesResponse represents the response for listing deny rules.
+// DenyRulesResponse 表示拒绝规则列表的响应。
+type DenyRulesResponse struct {
+	ErrorMsg string           `json:"error_msg"`
+	Data     []*AgentDenyRule `json:"data"`
+}
+
+// DenyRuleResponse represents the response carrying a deny rule.
+// DenyRuleResponse 表示返回单条拒绝规则的响应。
+type DenyRuleResponse struct {
+	ErrorMsg string         `json:"error_msg"`
+	Data     *AgentDenyRule `json:"data"`
+}
+
+// ==================== Handlers 处理器 ====================
+
+// ListPendingAgents handles GET /api/v1/admin/agents/pending - lists Agents waiting for approval.
+// ListPendingAgents 处理 GET /api/v1/admin/agents/pending - 列出等待审批的 Agent。
+// @Tags admin
+// @Produce json
+// @Success 200 {object} PendingAgentsResponse
+// @Router /api/v1/admin/agents/pending [get]
+func (h *AdmissionHandler) ListPendingAgents(c *gin.Context) {
+	c.JSON(http.StatusOK, PendingAgentsResponse{Data: h.manager.ListPendingAgents()})
+}
+
+// ApproveAgent handles POST /api/v1/admin/agents/pending/:agentId/approve - admits a pending Agent.
+// ApproveAgent 处理 POST /api/v1/admin/agents/pending/:agentId/approve - 审批通过待审批 Agent。
+// @Tags admin
+// @Produce json
+// @Param agentId path string true "Agent ID"
+// @Success 200 {object} AgentApprovalResponse
+// @Router /api/v1/admin/agents/pending/{agentId}/approve [post]
+func (h *AdmissionHandler) ApproveAgent(c *gin.Context) {
+	approval, err := h.manager.ApproveAgent(c.Request.Context(), c.Param("agentId"), uint(auth.GetUserIDFromContext(c)))
+	if err != nil {
+		c.JSON(admissionErrorStatus(c, err), AgentApprovalResponse{ErrorMsg: err.Error()})
+		return
+	}
+
+	_ = audit.RecordFromGin(c, h.auditRepo, auth.GetUserIDFromContext(c), auth.GetUsernameFromContext(c),
+		"approve", "agent", approval.AgentID, approval.Hostname, audit.AuditDetails{"ip_address": approval.IPAddress})
+	c.JSON(http.StatusOK, AgentApprovalResponse{Data: approval})
+}
+
+// ListDenyRules handles GET /api/v1/admin/agents/deny-list - lists deny rules.
+// ListDenyRules 处理 GET /api/v1/admin/agents/deny-list - 列出拒绝规则。
+// @Tags admin
+// @Produce json
+// @Success 200 {object} DenyRulesResponse
+// @Router /api/v1/admin/agents/deny-list [get]
+func (h *AdmissionHandler) ListDenyRules(c *gin.Context) {
+	rules, err := h.manager.ListDenyRules(c.Request.Context())
+	if err != nil {
+		c.JSON(admissionErrorStatus(c, err), DenyRulesResponse{ErrorMsg: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, DenyRulesResponse{Data: rules})
+}
+
+// AddDenyRule handles POST /api/v1/admin/agents/deny-list - denies an IP address or Agent ID.
+// AddDenyRule 处理 POST /api/v1/admin/agents/deny-list - 拒绝某个 IP 地址或 Agent ID。
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param request body DenyRuleRequest true "拒绝规则"
+// @Success 200 {object} DenyRuleResponse
+// @Router /api/v1/admin/agents/deny-list [post]
+func (h *AdmissionHandler) AddDenyRule(c *gin.Context) {
+	var req DenyRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, DenyRuleResponse{ErrorMsg: err.Error()})
+		return
+	}
+
+	rule := &AgentDenyRule{
+		Type:      req.Type,
+		Value:     req.Value,
+		Reason:    req.Reason,
+		CreatedBy: uint(auth.GetUserIDFromContext(c)),
+	}
+	if err := h.manager.AddDenyRule(c.Request.Context(), rule); err != nil {
+		c.JSON(admissionErrorStatus(c, err), DenyRuleResponse{ErrorMsg: err.Error()})
+		return
+	}
+
+	_ = audit.RecordFromGin(c, h.auditRepo, auth.GetUserIDFromContext(c), auth.GetUsernameFromContext(c),
+		"create", "agent_deny_rule", audit.UintID(rule.ID), rule.Value, audit.AuditDetails{"type": string(rule.Type), "reason": rule.Reason})
+	c.JSON(http.StatusOK, DenyRuleResponse{Data: rule})
+}
+
+// RemoveDenyRule handles DELETE /api/v1/admin/agents/deny-list/:id - removes a deny rule.
+// RemoveDenyRule 处理 DELETE /api/v1/admin/agents/deny-list/:id - 删除拒绝规则。
+// @Tags admin
+// @Produce json
+// @Param id path int true "规则ID"
+// @Success 200 {object} DenyRuleResponse
+// @Router /api/v1/admin/agents/deny-list/{id} [delete]
+func (h *AdmissionHandler) RemoveDenyRule(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, DenyRuleResponse{ErrorMsg: "无效的规则 ID / Invalid rule ID"})
+		return
+	}
+	if err := h.manager.RemoveDenyRule(c.Request.Context(), uint(id)); err != nil {
+		c.JSON(admissionErrorStatus(c, err), DenyRuleResponse{ErrorMsg: err.Error()})
+		return
+	}
+
+	_ = audit.RecordFromGin(c, h.auditRepo, auth.GetUserIDFromContext(c), auth.GetUsernameFromContext(c),
+		"delete", "agent_deny_rule", audit.UintID(uint(id)), "", nil)
+	c.JSON(http.StatusOK, DenyRuleResponse{})
+}
+
+// admissionStatusCode maps admission errors to HTTP status codes.
+// admissionStatusCode 将准入错误映射为 HTTP 状态码。
+func admissionStatusCode(err error) int {
+	switch {
+	case errors.Is(err, ErrPendingAgentNotFound), errors.Is(err, ErrDenyRuleNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrInvalidDenyRule):
+		return http.StatusBadRequest
+	case errors.Is(err, ErrAdmissionStoreUnavailable):
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// admissionErrorStatus records the catalog code of err on the request and returns its HTTP status.
+// admissionErrorStatus 在请求上记录 err 的目录错误码并返回其 HTTP 状态码。
+func admissionErrorStatus(c *gin.Context, err error) int {
+	errcode.Attach(c, ErrorCode(err))
+	return admissionStatusCode(err)
+}
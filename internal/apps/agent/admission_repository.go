@@ -0,0 +1,85 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package agent
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// AdmissionRepository is the database-backed AdmissionStore.
+// AdmissionRepository 是基于数据库的 AdmissionStore 实现。
+type AdmissionRepository struct {
+	db *gorm.DB
+}
+
+// NewAdmissionRepository creates a new AdmissionRepository instance.
+// NewAdmissionRepository 创建一个新的 AdmissionRepository 实例。
+func NewAdmissionRepository(db *gorm.DB) *AdmissionRepository {
+	return &AdmissionRepository{db: db}
+}
+
+// IsAgentApproved reports whether the Agent ID, or the host it runs on, has been approved.
+// IsAgentApproved 判断该 Agent ID 或其所在主机是否已审批通过。
+func (r *AdmissionRepository) IsAgentApproved(ctx context.Context, agentID, ipAddress, hostname string) (bool, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&AgentApproval{}).
+		Where("agent_id = ? OR (ip_address = ? AND hostname = ?)", agentID, ipAddress, hostname).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// CreateApproval records an approval.
+// CreateApproval 记录审批结果。
+func (r *AdmissionRepository) CreateApproval(ctx context.Context, approval *AgentApproval) error {
+	return r.db.WithContext(ctx).Create(approval).Error
+}
+
+// ListDenyRules returns all deny rules, newest first.
+// ListDenyRules 返回全部拒绝规则，按创建时间倒序。
+func (r *AdmissionRepository) ListDenyRules(ctx context.Context) ([]*AgentDenyRule, error) {
+	var rules []*AgentDenyRule
+	if err := r.db.WithContext(ctx).Order("id DESC").Find(&rules).Error; err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+// CreateDenyRule inserts a deny rule.
+// CreateDenyRule 新增拒绝规则。
+func (r *AdmissionRepository) CreateDenyRule(ctx context.Context, rule *AgentDenyRule) error {
+	return r.db.WithContext(ctx).Create(rule).Error
+}
+
+// DeleteDenyRule deletes a deny rule by ID.
+// DeleteDenyRule 根据 ID 删除拒绝规则。
+func (r *AdmissionRepository) DeleteDenyRule(ctx context.Context, id uint) error {
+	result := r.db.WithContext(ctx).Delete(&AgentDenyRule{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrDenyRuleNotFound
+	}
+	return nil
+}
+
+var _ AdmissionStore = (*AdmissionRepository)(nil)
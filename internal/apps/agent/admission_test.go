@@ -0,0 +1,146 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package agent
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	pb "github.com/seatunnel/seatunnelX/internal/proto/agent"
+)
+
+// fakeAdmissionStore keeps approvals and deny rules in memory.
+// fakeAdmissionStore 在内存中保存审批记录与拒绝规则。
+type fakeAdmissionStore struct {
+	approvals []*AgentApproval
+	rules     []*AgentDenyRule
+}
+
+func (s *fakeAdmissionStore) IsAgentApproved(ctx context.Context, agentID, ipAddress, hostname string) (bool, error) {
+	for _, approval := range s.approvals {
+		if approval.AgentID == agentID || (approval.IPAddress == ipAddress && approval.Hostname == hostname) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (s *fakeAdmissionStore) CreateApproval(ctx context.Context, approval *AgentApproval) error {
+	s.approvals = append(s.approvals, approval)
+	return nil
+}
+
+func (s *fakeAdmissionStore) ListDenyRules(ctx context.Context) ([]*AgentDenyRule, error) {
+	return s.rules, nil
+}
+
+func (s *fakeAdmissionStore) CreateDenyRule(ctx context.Context, rule *AgentDenyRule) error {
+	rule.ID = uint(len(s.rules) + 1)
+	s.rules = append(s.rules, rule)
+	return nil
+}
+
+func (s *fakeAdmissionStore) DeleteDenyRule(ctx context.Context, id uint) error {
+	for i, rule := range s.rules {
+		if rule.ID == id {
+			s.rules = append(s.rules[:i], s.rules[i+1:]...)
+			return nil
+		}
+	}
+	return ErrDenyRuleNotFound
+}
+
+// TestRegistrationRateLimitPerIP tests that registrations are limited per IP address.
+// TestRegistrationRateLimitPerIP 测试注册按 IP 地址限流。
+func TestRegistrationRateLimitPerIP(t *testing.T) {
+	m := NewManager(&ManagerConfig{RegistrationRateLimit: 2})
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		if _, err := m.RegisterAgent(ctx, &pb.RegisterRequest{AgentId: "agent-rate", IpAddress: "10.0.0.1"}); err != nil {
+			t.Fatalf("Registration %d should be allowed, got %v", i+1, err)
+		}
+	}
+	if _, err := m.RegisterAgent(ctx, &pb.RegisterRequest{AgentId: "agent-rate", IpAddress: "10.0.0.1"}); !errors.Is(err, ErrRegistrationRateLimited) {
+		t.Fatalf("Expected ErrRegistrationRateLimited, got %v", err)
+	}
+	if _, err := m.RegisterAgent(ctx, &pb.RegisterRequest{AgentId: "agent-other", IpAddress: "10.0.0.2"}); err != nil {
+		t.Fatalf("Other IPs should not be limited, got %v", err)
+	}
+
+	limiter := newIPRateLimiter(2)
+	now := time.Now()
+	limiter.allow("10.0.0.3", now)
+	limiter.allow("10.0.0.3", now)
+	if !limiter.allow("10.0.0.3", now.Add(30*time.Second)) {
+		t.Fatal("Expected a token to be refilled after half a minute")
+	}
+}
+
+// TestPendingApprovalAndDenyList tests pending approval and deny rules.
+// TestPendingApprovalAndDenyList 测试待审批与拒绝规则。
+func TestPendingApprovalAndDenyList(t *testing.T) {
+	m := NewManager(&ManagerConfig{RequireApproval: true, RegistrationRateLimit: -1})
+	m.SetAdmissionStore(&fakeAdmissionStore{})
+	ctx := context.Background()
+
+	// Agents without a configured ID register with a new generated ID each time
+	// 未配置 ID 的 Agent 每次注册都会使用新生成的 ID
+	for _, agentID := range []string{"agent-gen-1", "agent-gen-2"} {
+		req := &pb.RegisterRequest{AgentId: agentID, IpAddress: "10.0.1.1", Hostname: "node-1"}
+		if _, err := m.RegisterAgent(ctx, req); !errors.Is(err, ErrAgentPendingApproval) {
+			t.Fatalf("Expected ErrAgentPendingApproval, got %v", err)
+		}
+	}
+	dryRun := &pb.RegisterRequest{AgentId: "agent-gen-dry", IpAddress: "10.0.1.2", Hostname: "node-2", DryRun: true}
+	if err := m.CheckRegistration(ctx, dryRun); !errors.Is(err, ErrAgentPendingApproval) {
+		t.Fatalf("Expected ErrAgentPendingApproval from dry run, got %v", err)
+	}
+	pending := m.ListPendingAgents()
+	if len(pending) != 1 || pending[0].AgentID != "agent-gen-2" || pending[0].AttemptCount != 2 {
+		t.Fatalf("Expected one pending host with the latest agent ID, got %+v", pending)
+	}
+	if _, ok := m.GetAgent("agent-gen-2"); ok {
+		t.Fatal("Pending agents must not be registered")
+	}
+
+	if _, err := m.ApproveAgent(ctx, "agent-gen-2", 1); err != nil {
+		t.Fatalf("ApproveAgent failed: %v", err)
+	}
+	if _, err := m.RegisterAgent(ctx, &pb.RegisterRequest{AgentId: "agent-gen-3", IpAddress: "10.0.1.1", Hostname: "node-1"}); err != nil {
+		t.Fatalf("Approved host should register, got %v", err)
+	}
+	if len(m.ListPendingAgents()) != 0 {
+		t.Fatal("Expected no pending agents after approval")
+	}
+
+	if err := m.AddDenyRule(ctx, &AgentDenyRule{Type: DenyRuleTypeIP, Value: "10.0.1.1"}); err != nil {
+		t.Fatalf("AddDenyRule failed: %v", err)
+	}
+	if _, ok := m.GetAgent("agent-gen-3"); ok {
+		t.Fatal("Expected denied agent to be dropped")
+	}
+	if _, err := m.RegisterAgent(ctx, &pb.RegisterRequest{AgentId: "agent-gen-4", IpAddress: "10.0.1.1", Hostname: "node-1"}); !errors.Is(err, ErrAgentDenied) {
+		t.Fatalf("Expected ErrAgentDenied, got %v", err)
+	}
+	if err := m.AddDenyRule(ctx, &AgentDenyRule{Type: "hostname", Value: "node-1"}); !errors.Is(err, ErrInvalidDenyRule) {
+		t.Fatalf("Expected ErrInvalidDenyRule, got %v", err)
+	}
+}
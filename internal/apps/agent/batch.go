@@ -0,0 +1,260 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package agent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/seatunnel/seatunnelX/internal/pkg/errcode"
+	pb "github.com/seatunnel/seatunnelX/internal/proto/agent"
+)
+
+const (
+	// DefaultBatchConcurrency is the number of Agents commanded in parallel when unspecified.
+	// DefaultBatchConcurrency 是未指定时并行下发命令的 Agent 数量。
+	DefaultBatchConcurrency = 10
+
+	// MaxBatchConcurrency caps the fan-out of a single batch.
+	// MaxBatchConcurrency 限制单个批次的最大并发数。
+	MaxBatchConcurrency = 50
+
+	// MaxBatchTargets caps the number of hosts in a single batch.
+	// MaxBatchTargets 限制单个批次的主机数量。
+	MaxBatchTargets = 500
+
+	// MaxBatchTimeout caps the per-host timeout of a batch command.
+	// MaxBatchTimeout 限制批量命令在单台主机上的超时时间。
+	MaxBatchTimeout = 30 * time.Minute
+)
+
+var (
+	// ErrBatchCommandUnsupported indicates the command type cannot be run as a batch.
+	// ErrBatchCommandUnsupported 表示该命令类型不支持批量执行。
+	ErrBatchCommandUnsupported = errors.New("agent: command type not supported for batch execution")
+
+	// ErrBatchNoTargets indicates the batch selected no hosts.
+	// ErrBatchNoTargets 表示批次未选中任何主机。
+	ErrBatchNoTargets = errors.New("agent: batch selects no hosts")
+
+	// ErrBatchTooManyTargets indicates the batch selected more than MaxBatchTargets hosts.
+	// ErrBatchTooManyTargets 表示批次选中的主机超过 MaxBatchTargets。
+	ErrBatchTooManyTargets = errors.New("agent: batch selects too many hosts")
+
+	// ErrNoAgentInstalled indicates the target host has no Agent installed.
+	// ErrNoAgentInstalled 表示目标主机未安装 Agent。
+	ErrNoAgentInstalled = errors.New("agent: host has no agent installed")
+)
+
+// batchCommandTypes lists the ad-hoc operations allowed in a batch with their default per-host timeout.
+// Commands that need structured parameters (install, config, plugins) stay with their dedicated APIs.
+// batchCommandTypes 列出允许批量执行的临时操作及其默认单机超时。
+// 需要结构化参数的命令（安装、配置、插件）仍使用各自的专用 API。
+var batchCommandTypes = map[pb.CommandType]time.Duration{
+	pb.CommandType_PRECHECK:     30 * time.Second,
+	pb.CommandType_START:        3 * time.Minute,
+	pb.CommandType_STOP:         time.Minute,
+	pb.CommandType_RESTART:      3 * time.Minute,
+	pb.CommandType_STATUS:       30 * time.Second,
+	pb.CommandType_COLLECT_LOGS: 2 * time.Minute,
+	pb.CommandType_THREAD_DUMP:  2 * time.Minute,
+	pb.CommandType_DIAGNOSE:     5 * time.Minute,
+	pb.CommandType_JVM_DUMP:     10 * time.Minute,
+}
+
+// ParseBatchCommandType parses a batch command type such as "STATUS", "collect_logs" or "get_logs".
+// ParseBatchCommandType 解析批量命令类型，如 "STATUS"、"collect_logs" 或 "get_logs"。
+func ParseBatchCommandType(value string) (pb.CommandType, error) {
+	name := strings.ToUpper(strings.TrimSpace(value))
+	if name == "GET_LOGS" {
+		name = pb.CommandType_COLLECT_LOGS.String()
+	}
+	cmdType, ok := pb.CommandType_value[name]
+	if !ok {
+		return pb.CommandType_COMMAND_TYPE_UNSPECIFIED, fmt.Errorf("%w: %q", ErrBatchCommandUnsupported, value)
+	}
+	if _, ok := batchCommandTypes[pb.CommandType(cmdType)]; !ok {
+		return pb.CommandType_COMMAND_TYPE_UNSPECIFIED, fmt.Errorf("%w: %q", ErrBatchCommandUnsupported, value)
+	}
+	return pb.CommandType(cmdType), nil
+}
+
+// BatchTarget is a host selected for a batch command.
+// BatchTarget 是批量命令选中的主机。
+type BatchTarget struct {
+	HostID   uint
+	HostName string
+	AgentID  string
+}
+
+// BatchCommand describes a command fanned out to many Agents.
+// BatchCommand 描述扇出到多个 Agent 的命令。
+type BatchCommand struct {
+	Type   pb.CommandType
+	Params map[string]string
+	// Timeout is the per-host timeout, the command type default when zero.
+	// Timeout 为单台主机的超时时间，为 0 时使用命令类型的默认值。
+	Timeout time.Duration
+	// Concurrency bounds parallel dispatch, DefaultBatchConcurrency when zero.
+	// Concurrency 限制并行下发数量，为 0 时使用 DefaultBatchConcurrency。
+	Concurrency int
+}
+
+// BatchHostResult is the outcome of a batch command on one host.
+// BatchHostResult 是批量命令在单台主机上的执行结果。
+type BatchHostResult struct {
+	HostID     uint   `json:"host_id"`
+	HostName   string `json:"host_name"`
+	AgentID    string `json:"agent_id,omitempty"`
+	Status     string `json:"status"`
+	Success    bool   `json:"success"`
+	Output     string `json:"output,omitempty"`
+	Error      string `json:"error,omitempty"`
+	ErrorCode  string `json:"error_code,omitempty"`
+	DurationMs int64  `json:"duration_ms"`
+}
+
+// BatchResult aggregates the per-host results of a batch command in target order.
+// BatchResult 按目标顺序汇总批量命令的单机结果。
+type BatchResult struct {
+	CommandType string             `json:"command_type"`
+	Total       int                `json:"total"`
+	Succeeded   int                `json:"succeeded"`
+	Failed      int                `json:"failed"`
+	DurationMs  int64              `json:"duration_ms"`
+	Results     []*BatchHostResult `json:"results"`
+}
+
+// ExecuteBatch sends cmd to every target with bounded concurrency and waits for all results.
+// A failure on one host never aborts the others; cancelling ctx fails the hosts not yet dispatched.
+// ExecuteBatch 以受限并发向每个目标发送 cmd 并等待全部结果。
+// 单台主机失败不会中止其他主机；取消 ctx 会使尚未下发的主机失败。
+func (m *Manager) ExecuteBatch(ctx context.Context, cmd BatchCommand, targets []BatchTarget) (*BatchResult, error) {
+	defaultTimeout, ok := batchCommandTypes[cmd.Type]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrBatchCommandUnsupported, cmd.Type)
+	}
+	if len(targets) == 0 {
+		return nil, ErrBatchNoTargets
+	}
+	if len(targets) > MaxBatchTargets {
+		return nil, fmt.Errorf("%w: %d > %d", ErrBatchTooManyTargets, len(targets), MaxBatchTargets)
+	}
+
+	timeout := cmd.Timeout
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+	timeout = min(timeout, MaxBatchTimeout)
+	concurrency := cmd.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultBatchConcurrency
+	}
+	concurrency = min(concurrency, MaxBatchConcurrency, len(targets))
+
+	started := time.Now()
+	result := &BatchResult{
+		CommandType: cmd.Type.String(),
+		Total:       len(targets),
+		Results:     make([]*BatchHostResult, len(targets)),
+	}
+
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, target := range targets {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			result.Results[i] = failedBatchHostResult(target, ctx.Err(), 0)
+			continue
+		}
+		wg.Add(1)
+		go func(i int, target BatchTarget) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			result.Results[i] = m.executeBatchTarget(ctx, cmd, target, timeout)
+		}(i, target)
+	}
+	wg.Wait()
+
+	for _, r := range result.Results {
+		if r.Success {
+			result.Succeeded++
+		} else {
+			result.Failed++
+		}
+	}
+	result.DurationMs = time.Since(started).Milliseconds()
+	return result, nil
+}
+
+// executeBatchTarget runs the batch command on a single host.
+// executeBatchTarget 在单台主机上执行批量命令。
+func (m *Manager) executeBatchTarget(ctx context.Context, cmd BatchCommand, target BatchTarget, timeout time.Duration) *BatchHostResult {
+	started := time.Now()
+	if target.AgentID == "" {
+		return failedBatchHostResult(target, ErrNoAgentInstalled, 0)
+	}
+
+	resp, err := m.SendCommand(ctx, target.AgentID, cmd.Type, maps.Clone(cmd.Params), timeout)
+	if err != nil {
+		return failedBatchHostResult(target, err, time.Since(started))
+	}
+	return &BatchHostResult{
+		HostID:     target.HostID,
+		HostName:   target.HostName,
+		AgentID:    target.AgentID,
+		Status:     commandStatusString(resp.Status),
+		Success:    resp.Status == pb.CommandStatus_SUCCESS,
+		Output:     resp.Output,
+		Error:      resp.Error,
+		ErrorCode:  resp.GetErrorCode(),
+		DurationMs: time.Since(started).Milliseconds(),
+	}
+}
+
+// failedBatchHostResult builds the result of a host whose command could not be dispatched or completed.
+// failedBatchHostResult 构建命令无法下发或完成的主机结果。
+func failedBatchHostResult(target BatchTarget, err error, elapsed time.Duration) *BatchHostResult {
+	status := "failed"
+	code := ErrorCode(err)
+	switch {
+	case errors.Is(err, context.Canceled):
+		status = "cancelled"
+		code = errcode.CommandCancelled
+	case errors.Is(err, context.DeadlineExceeded), code == errcode.CommandTimeout:
+		status = "timeout"
+		code = errcode.CommandTimeout
+	}
+	return &BatchHostResult{
+		HostID:     target.HostID,
+		HostName:   target.HostName,
+		AgentID:    target.AgentID,
+		Status:     status,
+		Error:      err.Error(),
+		ErrorCode:  string(code),
+		DurationMs: elapsed.Milliseconds(),
+	}
+}
@@ -0,0 +1,216 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package agent
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/seatunnel/seatunnelX/internal/apps/audit"
+	"github.com/seatunnel/seatunnelX/internal/apps/auth"
+	"github.com/seatunnel/seatunnelX/internal/apps/host"
+	"github.com/seatunnel/seatunnelX/internal/pkg/errcode"
+)
+
+// BatchHandler provides the HTTP handler for running a command on many Agents at once.
+// BatchHandler 提供在多个 Agent 上同时执行命令的 HTTP 处理器。
+type BatchHandler struct {
+	manager     *Manager
+	hostService *host.Service
+	auditRepo   *audit.Repository
+}
+
+// NewBatchHandler creates a new BatchHandler instance.
+// NewBatchHandler 创建一个新的 BatchHandler 实例。
+// auditRepo may be nil; audit logging is skipped when nil.
+func NewBatchHandler(manager *Manager, hostService *host.Service, auditRepo *audit.Repository) *BatchHandler {
+	return &BatchHandler{manager: manager, hostService: hostService, auditRepo: auditRepo}
+}
+
+// BatchCommandRequest is the payload for POST /api/v1/commands/batch.
+// Hosts are selected by host_ids, label_selector or both; with both, a host must satisfy each.
+// BatchCommandRequest 是 POST /api/v1/commands/batch 的请求体。
+// 通过 host_ids、label_selector 或两者选择主机；两者同时指定时主机需同时满足。
+type BatchCommandRequest struct {
+	HostIDs       []uint `json:"host_ids"`
+	LabelSelector string `json:"label_selector"`
+	// CommandType is e.g. STATUS, COLLECT_LOGS, RESTART / CommandType 如 STATUS、COLLECT_LOGS、RESTART
+	CommandType string            `json:"command_type" binding:"required"`
+	Params      map[string]string `json:"params"`
+	Concurrency int               `json:"concurrency"`
+	// TimeoutSeconds overrides the per-host timeout / TimeoutSeconds 覆盖单台主机的超时时间
+	TimeoutSeconds int `json:"timeout_seconds"`
+}
+
+// BatchCommandResponse represents the response carrying the aggregated batch result.
+// BatchCommandResponse 表示返回批量汇总结果的响应。
+type BatchCommandResponse struct {
+	ErrorMsg string       `json:"error_msg"`
+	Data     *BatchResult `json:"data"`
+}
+
+// ExecuteBatch handles POST /api/v1/commands/batch - runs a command on the selected hosts.
+// ExecuteBatch 处理 POST /api/v1/commands/batch - 在选中的主机上执行命令。
+// @Tags commands
+// @Accept json
+// @Produce json
+// @Param request body BatchCommandRequest true "批量命令"
+// @Success 200 {object} BatchCommandResponse
+// @Router /api/v1/commands/batch [post]
+func (h *BatchHandler) ExecuteBatch(c *gin.Context) {
+	var req BatchCommandRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, BatchCommandResponse{ErrorMsg: err.Error()})
+		return
+	}
+	if len(req.HostIDs) == 0 && req.LabelSelector == "" {
+		errcode.Attach(c, errcode.InvalidRequest)
+		c.JSON(http.StatusBadRequest, BatchCommandResponse{ErrorMsg: "需要指定 host_ids 或 label_selector / host_ids or label_selector is required"})
+		return
+	}
+	if req.Concurrency < 0 || req.TimeoutSeconds < 0 {
+		errcode.Attach(c, errcode.InvalidRequest)
+		c.JSON(http.StatusBadRequest, BatchCommandResponse{ErrorMsg: "concurrency 和 timeout_seconds 不能为负数 / concurrency and timeout_seconds must not be negative"})
+		return
+	}
+	cmdType, err := ParseBatchCommandType(req.CommandType)
+	if err != nil {
+		c.JSON(batchErrorStatus(c, err), BatchCommandResponse{ErrorMsg: err.Error()})
+		return
+	}
+
+	targets, missing, err := h.resolveTargets(c, &req)
+	if err != nil {
+		c.JSON(batchErrorStatus(c, err), BatchCommandResponse{ErrorMsg: err.Error()})
+		return
+	}
+
+	result, err := h.manager.ExecuteBatch(c.Request.Context(), BatchCommand{
+		Type:        cmdType,
+		Params:      req.Params,
+		Timeout:     time.Duration(req.TimeoutSeconds) * time.Second,
+		Concurrency: req.Concurrency,
+	}, targets)
+	if err != nil {
+		c.JSON(batchErrorStatus(c, err), BatchCommandResponse{ErrorMsg: err.Error()})
+		return
+	}
+	for _, id := range missing {
+		result.Results = append(result.Results, &BatchHostResult{
+			HostID:    id,
+			Status:    "failed",
+			Error:     host.ErrHostNotFound.Error(),
+			ErrorCode: string(errcode.HostNotFound),
+		})
+		result.Total++
+		result.Failed++
+	}
+
+	_ = audit.RecordFromGin(c, h.auditRepo, auth.GetUserIDFromContext(c), auth.GetUsernameFromContext(c),
+		"execute", "command", "", result.CommandType, audit.AuditDetails{
+			"host_ids":       batchHostIDs(result),
+			"label_selector": req.LabelSelector,
+			"total":          result.Total,
+			"succeeded":      result.Succeeded,
+			"failed":         result.Failed,
+		})
+	c.JSON(http.StatusOK, BatchCommandResponse{Data: result})
+}
+
+// resolveTargets selects the hosts of a batch request within the caller's tenant scope.
+// Requested host IDs that do not exist or are not visible to the caller are returned as missing.
+// resolveTargets 在调用者的租户范围内选择批量请求的主机。
+// 不存在或调用者不可见的主机 ID 作为 missing 返回。
+func (h *BatchHandler) resolveTargets(c *gin.Context, req *BatchCommandRequest) ([]BatchTarget, []uint, error) {
+	selector, err := host.ParseLabelSelector(req.LabelSelector)
+	if err != nil {
+		return nil, nil, err
+	}
+	scope := auth.GetTenantScope(c)
+	hosts, _, err := h.hostService.List(c.Request.Context(), &host.HostFilter{
+		LabelSelector:    selector,
+		ProjectIDs:       scope.ProjectIDs,
+		RestrictProjects: !scope.All,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	byID := make(map[uint]*host.Host, len(hosts))
+	for _, hst := range hosts {
+		byID[hst.ID] = hst
+	}
+	var selected []*host.Host
+	var missing []uint
+	if len(req.HostIDs) == 0 {
+		selected = hosts
+	} else {
+		seen := make(map[uint]bool, len(req.HostIDs))
+		for _, id := range req.HostIDs {
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+			if hst, ok := byID[id]; ok {
+				selected = append(selected, hst)
+			} else if selector.Empty() {
+				missing = append(missing, id)
+			}
+		}
+	}
+	if len(selected) == 0 {
+		return nil, nil, ErrBatchNoTargets
+	}
+
+	targets := make([]BatchTarget, len(selected))
+	for i, hst := range selected {
+		targets[i] = BatchTarget{HostID: hst.ID, HostName: hst.Name, AgentID: hst.AgentID}
+	}
+	return targets, missing, nil
+}
+
+// batchHostIDs lists the host IDs of a batch result for audit details.
+// batchHostIDs 列出批量结果中的主机 ID，用于审计详情。
+func batchHostIDs(result *BatchResult) []string {
+	ids := make([]string, len(result.Results))
+	for i, r := range result.Results {
+		ids[i] = strconv.FormatUint(uint64(r.HostID), 10)
+	}
+	return ids
+}
+
+// batchErrorStatus records the catalog code of err on the request and returns its HTTP status.
+// batchErrorStatus 在请求上记录 err 的目录错误码并返回其 HTTP 状态码。
+func batchErrorStatus(c *gin.Context, err error) int {
+	switch {
+	case errors.Is(err, host.ErrHostLabelSelectorInvalid):
+		errcode.Attach(c, errcode.InvalidRequest)
+		return http.StatusBadRequest
+	case errors.Is(err, ErrBatchCommandUnsupported),
+		errors.Is(err, ErrBatchNoTargets),
+		errors.Is(err, ErrBatchTooManyTargets):
+		errcode.Attach(c, ErrorCode(err))
+		return http.StatusBadRequest
+	default:
+		errcode.Attach(c, ErrorCode(err))
+		return http.StatusInternalServerError
+	}
+}
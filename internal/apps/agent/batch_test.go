@@ -0,0 +1,158 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package agent
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/seatunnel/seatunnelX/internal/pkg/errcode"
+	pb "github.com/seatunnel/seatunnelX/internal/proto/agent"
+	"google.golang.org/grpc"
+)
+
+// respondingStream answers every command successfully after a short delay and tracks peak parallelism.
+// respondingStream 在短暂延迟后成功应答每条命令，并记录峰值并发数。
+type respondingStream struct {
+	grpc.ServerStream
+	m       *Manager
+	agentID string
+	tracker *inFlightTracker
+}
+
+type inFlightTracker struct {
+	mu      sync.Mutex
+	current int
+	peak    int
+}
+
+func (t *inFlightTracker) add(delta int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.current += delta
+	t.peak = max(t.peak, t.current)
+}
+
+func (s *respondingStream) Send(req *pb.CommandRequest) error {
+	s.tracker.add(1)
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		s.tracker.add(-1)
+		s.m.HandleCommandResponse(&pb.CommandResponse{
+			CommandId: req.CommandId,
+			Status:    pb.CommandStatus_SUCCESS,
+			Output:    s.agentID + ":" + req.Parameters["sub_command"],
+		})
+	}()
+	return nil
+}
+
+func (s *respondingStream) Recv() (*pb.CommandResponse, error) {
+	return nil, errors.New("not implemented")
+}
+
+// TestExecuteBatch tests fan-out with bounded concurrency and per-host results in target order.
+// TestExecuteBatch 测试受限并发的扇出以及按目标顺序返回的单机结果。
+func TestExecuteBatch(t *testing.T) {
+	m := NewManager(nil)
+	ctx := context.Background()
+	tracker := &inFlightTracker{}
+	var targets []BatchTarget
+	for i, id := range []string{"agent-b1", "agent-b2", "agent-b3", "agent-b4"} {
+		_, _ = m.RegisterAgent(ctx, &pb.RegisterRequest{AgentId: id, IpAddress: "192.168.10.1"})
+		if err := m.SetAgentStream(id, &respondingStream{m: m, agentID: id, tracker: tracker}); err != nil {
+			t.Fatalf("SetAgentStream failed: %v", err)
+		}
+		targets = append(targets, BatchTarget{HostID: uint(i + 1), HostName: id, AgentID: id})
+	}
+	targets = append(targets,
+		BatchTarget{HostID: 5, HostName: "no-agent"},
+		BatchTarget{HostID: 6, HostName: "gone", AgentID: "agent-missing"},
+	)
+
+	result, err := m.ExecuteBatch(ctx, BatchCommand{
+		Type:        pb.CommandType_PRECHECK,
+		Params:      map[string]string{"sub_command": "check_java"},
+		Concurrency: 2,
+	}, targets)
+	if err != nil {
+		t.Fatalf("ExecuteBatch failed: %v", err)
+	}
+
+	if result.Total != 6 || result.Succeeded != 4 || result.Failed != 2 {
+		t.Fatalf("Unexpected summary: total=%d succeeded=%d failed=%d", result.Total, result.Succeeded, result.Failed)
+	}
+	for i, r := range result.Results[:4] {
+		if r.HostID != uint(i+1) || !r.Success || r.Status != "success" || r.Output != r.AgentID+":check_java" {
+			t.Errorf("Unexpected result %d: %+v", i, r)
+		}
+	}
+	if r := result.Results[4]; r.Success || r.ErrorCode != string(errcode.AgentNotFound) {
+		t.Errorf("Expected host without Agent to fail with %s, got %+v", errcode.AgentNotFound, r)
+	}
+	if r := result.Results[5]; r.Success || r.ErrorCode != string(errcode.AgentNotFound) {
+		t.Errorf("Expected unknown Agent to fail with %s, got %+v", errcode.AgentNotFound, r)
+	}
+	if tracker.peak > 2 {
+		t.Errorf("Expected at most 2 commands in flight, got %d", tracker.peak)
+	}
+}
+
+// TestExecuteBatchRejectsInvalidBatches tests validation of command types and targets.
+// TestExecuteBatchRejectsInvalidBatches 测试命令类型与目标的校验。
+func TestExecuteBatchRejectsInvalidBatches(t *testing.T) {
+	m := NewManager(nil)
+	ctx := context.Background()
+	target := []BatchTarget{{HostID: 1, AgentID: "agent-x"}}
+
+	if _, err := m.ExecuteBatch(ctx, BatchCommand{Type: pb.CommandType_INSTALL}, target); !errors.Is(err, ErrBatchCommandUnsupported) {
+		t.Errorf("Expected ErrBatchCommandUnsupported, got %v", err)
+	}
+	if _, err := m.ExecuteBatch(ctx, BatchCommand{Type: pb.CommandType_STATUS}, nil); !errors.Is(err, ErrBatchNoTargets) {
+		t.Errorf("Expected ErrBatchNoTargets, got %v", err)
+	}
+	tooMany := make([]BatchTarget, MaxBatchTargets+1)
+	if _, err := m.ExecuteBatch(ctx, BatchCommand{Type: pb.CommandType_STATUS}, tooMany); !errors.Is(err, ErrBatchTooManyTargets) {
+		t.Errorf("Expected ErrBatchTooManyTargets, got %v", err)
+	}
+}
+
+// TestParseBatchCommandType tests command type parsing and the batch allowlist.
+// TestParseBatchCommandType 测试命令类型解析与批量白名单。
+func TestParseBatchCommandType(t *testing.T) {
+	cases := map[string]pb.CommandType{
+		"STATUS":       pb.CommandType_STATUS,
+		"collect_logs": pb.CommandType_COLLECT_LOGS,
+		"get_logs":     pb.CommandType_COLLECT_LOGS,
+		" restart ":    pb.CommandType_RESTART,
+	}
+	for value, want := range cases {
+		got, err := ParseBatchCommandType(value)
+		if err != nil || got != want {
+			t.Errorf("ParseBatchCommandType(%q) = %v, %v; want %v", value, got, err, want)
+		}
+	}
+	for _, value := range []string{"INSTALL", "UPDATE_CONFIG", "bogus", ""} {
+		if _, err := ParseBatchCommandType(value); !errors.Is(err, ErrBatchCommandUnsupported) {
+			t.Errorf("ParseBatchCommandType(%q) expected ErrBatchCommandUnsupported, got %v", value, err)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package agent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	pb "github.com/seatunnel/seatunnelX/internal/proto/agent"
+)
+
+// cancelRequestTimeout bounds how long the Agent may take to acknowledge a cancel request.
+// cancelRequestTimeout 限制 Agent 确认取消请求的最长时间。
+const cancelRequestTimeout = 30 * time.Second
+
+var (
+	// ErrCommandNotFound indicates the command is unknown or already expired.
+	// ErrCommandNotFound 表示命令不存在或已过期。
+	ErrCommandNotFound = errors.New("agent: command not found")
+
+	// ErrCommandFinished indicates the command already reached a terminal status.
+	// ErrCommandFinished 表示命令已处于终止状态。
+	ErrCommandFinished = errors.New("agent: command already finished")
+
+	// ErrCommandCancelled indicates a queued command was cancelled before delivery.
+	// ErrCommandCancelled 表示排队命令在投递前被取消。
+	ErrCommandCancelled = errors.New("agent: command cancelled")
+)
+
+// CancelCommand cancels a pending or running command by ID.
+// Queued commands are dropped locally; delivered commands are cancelled on the Agent,
+// which reports CANCELLED once partial state has been cleaned up.
+// CancelCommand 按 ID 取消待执行或执行中的命令。
+// 排队中的命令在本地直接丢弃；已投递的命令由 Agent 取消，清理完部分状态后上报 CANCELLED。
+func (m *Manager) CancelCommand(ctx context.Context, commandID string) error {
+	cmdCtx, ok := m.GetCommand(commandID)
+	if !ok {
+		return ErrCommandNotFound
+	}
+	if cmdCtx.IsDone() {
+		return ErrCommandFinished
+	}
+
+	if m.cancelQueuedCommand(cmdCtx) {
+		return nil
+	}
+
+	resp, err := m.SendCommand(ctx, cmdCtx.AgentID, pb.CommandType_CANCEL, map[string]string{
+		"target_command_id": commandID,
+	}, cancelRequestTimeout)
+	if err != nil {
+		return err
+	}
+	if resp.Status != pb.CommandStatus_SUCCESS {
+		return fmt.Errorf("agent: cancel rejected: %s", resp.Error)
+	}
+	return nil
+}
+
+// cancelQueuedCommand removes a not-yet-delivered command from its Agent queue.
+// It reports whether the command was found in the queue.
+// cancelQueuedCommand 将尚未投递的命令从 Agent 队列中移除。
+// 返回命令是否在队列中被找到。
+func (m *Manager) cancelQueuedCommand(cmdCtx *CommandContext) bool {
+	var cancelled *queuedCommand
+
+	m.queueMu.Lock()
+	pending := m.commandQueues[cmdCtx.AgentID]
+	for i, qc := range pending {
+		if qc.cmdCtx == cmdCtx {
+			cancelled = qc
+			pending = append(pending[:i:i], pending[i+1:]...)
+			break
+		}
+	}
+	if cancelled != nil {
+		if len(pending) == 0 {
+			delete(m.commandQueues, cmdCtx.AgentID)
+		} else {
+			m.commandQueues[cmdCtx.AgentID] = pending
+		}
+	}
+	m.queueMu.Unlock()
+
+	if cancelled == nil {
+		return false
+	}
+
+	cmdCtx.mu.Lock()
+	cmdCtx.LastStatus = pb.CommandStatus_CANCELLED
+	cmdCtx.LastOutput = "Command cancelled before delivery / 命令在投递前已取消"
+	cmdCtx.LastError = ErrCommandCancelled.Error()
+	cmdCtx.Done = true
+	cmdCtx.mu.Unlock()
+	cancelled.delivered <- ErrCommandCancelled
+
+	go func(commandID string) {
+		time.Sleep(5 * time.Minute)
+		m.commands.Delete(commandID)
+	}(cmdCtx.CommandID)
+	return true
+}
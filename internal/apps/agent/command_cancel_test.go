@@ -0,0 +1,106 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package agent
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	pb "github.com/seatunnel/seatunnelX/internal/proto/agent"
+)
+
+// TestCancelQueuedCommand tests that a queued command is dropped and marked cancelled.
+// TestCancelQueuedCommand 测试排队中的命令被丢弃并标记为已取消。
+func TestCancelQueuedCommand(t *testing.T) {
+	m := NewManager(nil)
+	ctx := context.Background()
+	_, _ = m.RegisterAgent(ctx, &pb.RegisterRequest{AgentId: "agent-cancel-q", IpAddress: "192.168.9.2"})
+	m.HandleDisconnect("agent-cancel-q")
+
+	commandID, err := m.SendCommandAsync(context.Background(), "agent-cancel-q", pb.CommandType_INSTALL, nil, time.Minute)
+	if err != nil {
+		t.Fatalf("Expected command to be queued, got error: %v", err)
+	}
+	if err := m.CancelCommand(ctx, commandID); err != nil {
+		t.Fatalf("CancelCommand failed: %v", err)
+	}
+	if got := m.QueuedCommandCount("agent-cancel-q"); got != 0 {
+		t.Fatalf("Expected queue to be empty, got %d", got)
+	}
+	if status, _, _, _ := m.GetCommandStatus(commandID); status != "cancelled" {
+		t.Errorf("Expected status 'cancelled', got '%s'", status)
+	}
+	if err := m.CancelCommand(ctx, commandID); !errors.Is(err, ErrCommandFinished) {
+		t.Errorf("Expected ErrCommandFinished on second cancel, got %v", err)
+	}
+}
+
+// TestCancelRunningCommandSendsCancelRequest tests that a delivered command is cancelled on the Agent.
+// TestCancelRunningCommandSendsCancelRequest 测试已投递的命令通过 Agent 取消。
+func TestCancelRunningCommandSendsCancelRequest(t *testing.T) {
+	m := NewManager(nil)
+	ctx := context.Background()
+	_, _ = m.RegisterAgent(ctx, &pb.RegisterRequest{AgentId: "agent-cancel-r", IpAddress: "192.168.9.3"})
+	stream := &fakeCommandStream{}
+	if err := m.SetAgentStream("agent-cancel-r", stream); err != nil {
+		t.Fatalf("SetAgentStream failed: %v", err)
+	}
+
+	commandID, err := m.SendCommandAsync(context.Background(), "agent-cancel-r", pb.CommandType_INSTALL, nil, time.Minute)
+	if err != nil {
+		t.Fatalf("SendCommandAsync failed: %v", err)
+	}
+
+	errCh := make(chan error, 1)
+	go func() { errCh <- m.CancelCommand(ctx, commandID) }()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for stream.sentCount() < 2 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if stream.sentCount() != 2 {
+		t.Fatalf("Expected cancel request to be sent, got %d commands", stream.sentCount())
+	}
+	stream.mu.Lock()
+	cancelReq := stream.sent[1]
+	stream.mu.Unlock()
+	if cancelReq.Type != pb.CommandType_CANCEL || cancelReq.Parameters["target_command_id"] != commandID {
+		t.Fatalf("Unexpected cancel request: %v %v", cancelReq.Type, cancelReq.Parameters)
+	}
+
+	m.HandleCommandResponse(&pb.CommandResponse{CommandId: cancelReq.CommandId, Status: pb.CommandStatus_SUCCESS})
+	if err := <-errCh; err != nil {
+		t.Fatalf("CancelCommand failed: %v", err)
+	}
+
+	m.HandleCommandResponse(&pb.CommandResponse{CommandId: commandID, Status: pb.CommandStatus_CANCELLED})
+	if status, _, _, _ := m.GetCommandStatus(commandID); status != "cancelled" {
+		t.Errorf("Expected status 'cancelled', got '%s'", status)
+	}
+}
+
+// TestCancelUnknownCommand tests cancelling an unknown command.
+// TestCancelUnknownCommand 测试取消不存在的命令。
+func TestCancelUnknownCommand(t *testing.T) {
+	m := NewManager(nil)
+	if err := m.CancelCommand(context.Background(), "missing"); !errors.Is(err, ErrCommandNotFound) {
+		t.Fatalf("Expected ErrCommandNotFound, got %v", err)
+	}
+}
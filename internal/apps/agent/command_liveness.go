@@ -0,0 +1,131 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package agent
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	pb "github.com/seatunnel/seatunnelX/internal/proto/agent"
+)
+
+const (
+	// DefaultCommandProgressTimeout is how long a delivered command may go without progress before it times out.
+	// Agents send progress heartbeats for silent commands well within this window.
+	// DefaultCommandProgressTimeout 是已投递命令无进度多久后判定超时的默认时长。
+	// Agent 会在该窗口内为静默命令发送进度心跳。
+	DefaultCommandProgressTimeout = 3 * time.Minute
+
+	// commandDeadlineGrace is added to a command timeout before the Control Plane gives up on it,
+	// leaving the Agent time to report its own TIMEOUT first.
+	// commandDeadlineGrace 会追加到命令超时时间之后，Control Plane 才放弃该命令，
+	// 以便 Agent 先上报自身的 TIMEOUT。
+	commandDeadlineGrace = time.Minute
+)
+
+var (
+	// ErrCommandStalled indicates no progress arrived for a running command within the progress window.
+	// ErrCommandStalled 表示执行中的命令在进度窗口内没有任何进度上报。
+	ErrCommandStalled = errors.New("agent: no progress from agent, command timed out")
+
+	// ErrCommandDeadlineExceeded indicates a command ran past its execution deadline without a result.
+	// ErrCommandDeadlineExceeded 表示命令超过执行截止时间仍未返回结果。
+	ErrCommandDeadlineExceeded = errors.New("agent: command execution deadline exceeded")
+)
+
+// SetOnCommandTimeout sets an optional hook invoked with the synthesized TIMEOUT response.
+// SetOnCommandTimeout 设置 Control Plane 判定命令超时时的可选回调，参数为合成的 TIMEOUT 响应。
+func (m *Manager) SetOnCommandTimeout(fn func(resp *pb.CommandResponse)) {
+	m.onCommandTimeout = fn
+}
+
+// isProgressHeartbeat reports whether a response only signals that the command is still running.
+// isProgressHeartbeat 判断响应是否仅用于表明命令仍在执行。
+func isProgressHeartbeat(resp *pb.CommandResponse) bool {
+	return resp.Status == pb.CommandStatus_RUNNING && resp.Output == "" && resp.Error == ""
+}
+
+// expireStalledCommands times out delivered commands that are past their deadline or silent for too long.
+// Queued commands are governed by CommandQueueTTL instead.
+// expireStalledCommands 使超过截止时间或静默过久的已投递命令超时。排队中的命令由 CommandQueueTTL 控制。
+func (m *Manager) expireStalledCommands(now time.Time) {
+	progressTimeout := m.config.CommandProgressTimeout
+	m.commands.Range(func(key, value any) bool {
+		cmdCtx := value.(*CommandContext)
+
+		cmdCtx.mu.RLock()
+		skip := cmdCtx.Done || cmdCtx.LastStatus == pb.CommandStatus_PENDING
+		lastActivity := cmdCtx.LastActivity
+		deadline := time.Time{}
+		if cmdCtx.Timeout > 0 {
+			deadline = cmdCtx.CreatedAt.Add(cmdCtx.Timeout + commandDeadlineGrace)
+		}
+		cmdCtx.mu.RUnlock()
+		if skip {
+			return true
+		}
+
+		switch {
+		case !deadline.IsZero() && now.After(deadline):
+			m.timeoutCommand(cmdCtx, ErrCommandDeadlineExceeded)
+		case progressTimeout > 0 && now.Sub(lastActivity) > progressTimeout:
+			m.timeoutCommand(cmdCtx, fmt.Errorf("%w (silent for %s)", ErrCommandStalled, now.Sub(lastActivity).Truncate(time.Second)))
+		}
+		return true
+	})
+}
+
+// timeoutCommand marks a command TIMEOUT, wakes any waiter and notifies the timeout hook.
+// timeoutCommand 将命令标记为 TIMEOUT，唤醒等待方并通知超时回调。
+func (m *Manager) timeoutCommand(cmdCtx *CommandContext, reason error) {
+	cmdCtx.mu.Lock()
+	if cmdCtx.Done {
+		cmdCtx.mu.Unlock()
+		return
+	}
+	cmdCtx.LastStatus = pb.CommandStatus_TIMEOUT
+	cmdCtx.LastError = reason.Error()
+	cmdCtx.Done = true
+	resp := &pb.CommandResponse{
+		CommandId: cmdCtx.CommandID,
+		Status:    pb.CommandStatus_TIMEOUT,
+		Progress:  cmdCtx.LastProgress,
+		Error:     reason.Error(),
+		ErrorCode: string(ErrorCode(reason)),
+		Timestamp: time.Now().UnixMilli(),
+	}
+	cmdCtx.mu.Unlock()
+
+	if cmdCtx.ResultChan != nil {
+		select {
+		case cmdCtx.ResultChan <- resp:
+		default:
+		}
+	}
+	if m.onCommandTimeout != nil {
+		m.onCommandTimeout(resp)
+	}
+
+	// Keep for status queries like completed commands, then clean up.
+	// 与已完成命令一样保留以供状态查询，随后清理。
+	go func(commandID string) {
+		time.Sleep(5 * time.Minute)
+		m.commands.Delete(commandID)
+	}(cmdCtx.CommandID)
+}
@@ -0,0 +1,112 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package agent
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/seatunnel/seatunnelX/internal/pkg/errcode"
+	pb "github.com/seatunnel/seatunnelX/internal/proto/agent"
+)
+
+// TestStalledCommandTimesOut tests that heartbeats keep a command alive and silence times it out.
+// TestStalledCommandTimesOut 测试心跳可使命令保持存活，静默则导致超时。
+func TestStalledCommandTimesOut(t *testing.T) {
+	m := NewManager(&ManagerConfig{CommandProgressTimeout: time.Minute})
+	ctx := context.Background()
+	_, _ = m.RegisterAgent(ctx, &pb.RegisterRequest{AgentId: "agent-live", IpAddress: "192.168.9.2"})
+	if err := m.SetAgentStream("agent-live", &fakeCommandStream{}); err != nil {
+		t.Fatalf("SetAgentStream failed: %v", err)
+	}
+
+	var timedOut []*pb.CommandResponse
+	m.SetOnCommandTimeout(func(resp *pb.CommandResponse) { timedOut = append(timedOut, resp) })
+
+	commandID, err := m.SendCommandAsync(context.Background(), "agent-live", pb.CommandType_INSTALL, nil, time.Hour)
+	if err != nil {
+		t.Fatalf("SendCommandAsync failed: %v", err)
+	}
+	m.HandleCommandResponse(&pb.CommandResponse{CommandId: commandID, Status: pb.CommandStatus_RUNNING, Progress: 40, Output: "[download] downloading"})
+	m.HandleCommandResponse(&pb.CommandResponse{CommandId: commandID, Status: pb.CommandStatus_RUNNING, Progress: 40})
+
+	// A heartbeat refreshes liveness without replacing the last progress message.
+	// 心跳刷新存活时间，但不会覆盖最后的进度消息。
+	m.expireStalledCommands(time.Now().Add(50 * time.Second))
+	if status, progress, message, _ := m.GetCommandStatus(commandID); status != "running" || progress != 40 || message != "[download] downloading" {
+		t.Fatalf("Expected command to keep running with its last progress, got %s/%d/%q", status, progress, message)
+	}
+
+	m.expireStalledCommands(time.Now().Add(2 * time.Minute))
+	status, _, message, _ := m.GetCommandStatus(commandID)
+	if status != "timeout" || !strings.Contains(message, ErrCommandStalled.Error()) {
+		t.Fatalf("Expected stalled command to time out, got %s: %s", status, message)
+	}
+	if len(timedOut) != 1 || timedOut[0].Status != pb.CommandStatus_TIMEOUT || timedOut[0].ErrorCode != string(errcode.CommandTimeout) {
+		t.Fatalf("Expected the timeout hook to receive one TIMEOUT response, got %v", timedOut)
+	}
+
+	// Late responses from the Agent no longer change the outcome.
+	// Agent 的迟到响应不再改变结果。
+	m.HandleCommandResponse(&pb.CommandResponse{CommandId: commandID, Status: pb.CommandStatus_SUCCESS})
+	if status, _, _, _ := m.GetCommandStatus(commandID); status != "timeout" {
+		t.Fatalf("Expected status to stay 'timeout', got %s", status)
+	}
+}
+
+// TestCommandDeadlineWakesWaiter tests that a command past its deadline returns a TIMEOUT result to SendCommand.
+// TestCommandDeadlineWakesWaiter 测试超过截止时间的命令向 SendCommand 返回 TIMEOUT 结果。
+func TestCommandDeadlineWakesWaiter(t *testing.T) {
+	m := NewManager(&ManagerConfig{CommandProgressTimeout: -1})
+	ctx := context.Background()
+	_, _ = m.RegisterAgent(ctx, &pb.RegisterRequest{AgentId: "agent-deadline", IpAddress: "192.168.9.3"})
+	stream := &fakeCommandStream{}
+	if err := m.SetAgentStream("agent-deadline", stream); err != nil {
+		t.Fatalf("SetAgentStream failed: %v", err)
+	}
+
+	type result struct {
+		resp *pb.CommandResponse
+		err  error
+	}
+	done := make(chan result, 1)
+	go func() {
+		resp, err := m.SendCommand(ctx, "agent-deadline", pb.CommandType_START, nil, time.Hour)
+		done <- result{resp, err}
+	}()
+	for stream.sentCount() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	// Progress timeout is disabled, so only the deadline applies.
+	// 进度超时已禁用，因此仅截止时间生效。
+	m.expireStalledCommands(time.Now().Add(30 * time.Minute))
+	select {
+	case <-done:
+		t.Fatal("Expected command to keep waiting before its deadline")
+	default:
+	}
+
+	m.expireStalledCommands(time.Now().Add(2 * time.Hour))
+	res := <-done
+	if res.err != nil || res.resp.Status != pb.CommandStatus_TIMEOUT || !strings.Contains(res.resp.Error, ErrCommandDeadlineExceeded.Error()) {
+		t.Fatalf("Expected TIMEOUT response, got resp=%+v err=%v", res.resp, res.err)
+	}
+}
@@ -0,0 +1,256 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package agent
+
+import (
+	"errors"
+	"time"
+
+	pb "github.com/seatunnel/seatunnelX/internal/proto/agent"
+	"google.golang.org/grpc"
+)
+
+// DefaultCommandQueueTTL is how long a command may wait for a disconnected Agent to reconnect.
+// DefaultCommandQueueTTL 是命令等待断开的 Agent 重连的默认时长。
+const DefaultCommandQueueTTL = 60 * time.Second
+
+// ErrCommandQueueExpired indicates a queued command was not delivered before its TTL elapsed.
+// ErrCommandQueueExpired 表示排队的命令在 TTL 到期前未能投递。
+var ErrCommandQueueExpired = errors.New("agent: queued command expired before agent reconnected")
+
+// queuedCommand is a command waiting for an Agent command stream to be re-established.
+// queuedCommand 是等待 Agent 命令流重新建立的命令。
+type queuedCommand struct {
+	// cmdCtx is the command context tracked in Manager.commands.
+	// cmdCtx 是在 Manager.commands 中跟踪的命令上下文。
+	cmdCtx *CommandContext
+
+	// request is the command request to deliver.
+	// request 是待投递的命令请求。
+	request *pb.CommandRequest
+
+	// expireAt is the deadline for delivery.
+	// expireAt 是投递截止时间。
+	expireAt time.Time
+
+	// delivered receives nil once sent, or the delivery error (e.g. ErrCommandQueueExpired).
+	// delivered 在发送成功时收到 nil，否则收到投递错误（如 ErrCommandQueueExpired）。
+	delivered chan error
+}
+
+// canQueue reports whether commands for this connection should be queued instead of failing.
+// Only transient stream loss is queued; Agents marked offline by heartbeat timeout fail fast.
+// canQueue 判断该连接的命令是否应排队而非直接失败。
+// 仅对命令流的短暂丢失进行排队；因心跳超时被标记为离线的 Agent 直接失败。
+func canQueue(conn *AgentConnection) bool {
+	return conn.GetStatus() != AgentStatusOffline
+}
+
+// dispatchCommand sends the request on the Agent stream, or queues it when the stream is unavailable.
+// It returns the queued entry when the command was queued, nil when it was sent directly.
+// The stream is re-checked and the command enqueued under queueMu. SetAgentStream installs the new stream
+// before flushCommandQueue takes that lock, so a command is either sent on the new stream or queued in
+// time for the flush, never stranded in between.
+// dispatchCommand 通过 Agent 流发送请求；若流不可用则将其排队。
+// 命令被排队时返回排队项，直接发送成功时返回 nil。
+// 在 queueMu 下重新检查命令流并入队。SetAgentStream 在 flushCommandQueue 获取该锁之前设置新流，
+// 因此命令要么通过新流发送，要么在投递前入队，不会滞留在两者之间。
+func (m *Manager) dispatchCommand(conn *AgentConnection, cmdCtx *CommandContext, req *pb.CommandRequest) (*queuedCommand, error) {
+	var failed grpc.BidiStreamingServer[pb.CommandResponse, pb.CommandRequest]
+	for {
+		if stream := liveStream(conn); stream != nil && stream != failed {
+			if err := stream.Send(req); err == nil {
+				return nil, nil
+			}
+			failed = stream
+		}
+
+		if !canQueue(conn) {
+			return nil, ErrAgentNotConnected
+		}
+
+		m.queueMu.Lock()
+		if stream := liveStream(conn); stream != nil && stream != failed {
+			// A new stream was installed meanwhile; send on it directly.
+			// 期间已建立新流，直接通过新流发送。
+			m.queueMu.Unlock()
+			continue
+		}
+		qc := newQueuedCommand(cmdCtx, req, m.config.CommandQueueTTL)
+		m.commandQueues[conn.AgentID] = append(m.commandQueues[conn.AgentID], qc)
+		m.queueMu.Unlock()
+
+		if failed != nil {
+			m.detachFailedStream(conn, failed)
+		}
+		return qc, nil
+	}
+}
+
+// liveStream returns the command stream of a connected Agent, or nil.
+// liveStream 返回已连接 Agent 的命令流，否则返回 nil。
+func liveStream(conn *AgentConnection) grpc.BidiStreamingServer[pb.CommandResponse, pb.CommandRequest] {
+	conn.mu.RLock()
+	defer conn.mu.RUnlock()
+	if conn.Status != AgentStatusConnected {
+		return nil
+	}
+	return conn.Stream
+}
+
+// detachFailedStream clears a stream that failed to send, so later commands queue for the reconnect
+// instead of reusing the broken stream. A stream that was already replaced is left alone.
+// detachFailedStream 清除发送失败的命令流，使后续命令排队等待重连而不是继续使用已中断的流。
+// 已被替换的命令流不受影响。
+func (m *Manager) detachFailedStream(conn *AgentConnection, failed grpc.BidiStreamingServer[pb.CommandResponse, pb.CommandRequest]) {
+	conn.mu.Lock()
+	detached := conn.Stream == failed
+	if detached {
+		conn.Stream = nil
+		conn.Status = AgentStatusDisconnected
+	}
+	conn.mu.Unlock()
+	if detached {
+		publishAgentStatus(conn, AgentStatusDisconnected)
+	}
+}
+
+// newQueuedCommand creates a queue entry and marks the command as pending.
+// newQueuedCommand 创建排队项并将命令标记为等待中。
+func newQueuedCommand(cmdCtx *CommandContext, req *pb.CommandRequest, ttl time.Duration) *queuedCommand {
+	cmdCtx.mu.Lock()
+	cmdCtx.LastStatus = pb.CommandStatus_PENDING
+	cmdCtx.LastOutput = "Agent reconnecting, command queued / Agent 正在重连，命令已排队"
+	cmdCtx.mu.Unlock()
+
+	return &queuedCommand{
+		cmdCtx:    cmdCtx,
+		request:   req,
+		expireAt:  time.Now().Add(ttl),
+		delivered: make(chan error, 1),
+	}
+}
+
+// flushCommandQueue delivers queued commands once an Agent stream is re-established.
+// Commands are sent in FIFO order; expired ones are failed with ErrCommandQueueExpired.
+// flushCommandQueue 在 Agent 流重新建立后投递排队的命令。
+// 命令按 FIFO 顺序发送；已过期的命令以 ErrCommandQueueExpired 失败。
+func (m *Manager) flushCommandQueue(agentID string, stream grpc.BidiStreamingServer[pb.CommandResponse, pb.CommandRequest]) {
+	m.queueMu.Lock()
+	pending := m.commandQueues[agentID]
+	delete(m.commandQueues, agentID)
+	m.queueMu.Unlock()
+
+	now := time.Now()
+	for i, qc := range pending {
+		if qc.cmdCtx.IsDone() {
+			continue
+		}
+		if now.After(qc.expireAt) {
+			m.failQueuedCommand(qc, ErrCommandQueueExpired)
+			continue
+		}
+		if err := stream.Send(qc.request); err != nil {
+			// Stream broke again; keep the remaining commands queued for the next reconnect.
+			// 流再次中断；剩余命令继续排队等待下一次重连。
+			m.queueMu.Lock()
+			m.commandQueues[agentID] = append(pending[i:], m.commandQueues[agentID]...)
+			m.queueMu.Unlock()
+			m.redeliverAfterFailedFlush(agentID, stream)
+			return
+		}
+		qc.cmdCtx.mu.Lock()
+		qc.cmdCtx.LastStatus = pb.CommandStatus_RUNNING
+		qc.cmdCtx.LastOutput = "Command sent, waiting for response / 命令已发送，等待响应"
+		qc.cmdCtx.LastActivity = time.Now()
+		qc.cmdCtx.mu.Unlock()
+		qc.delivered <- nil
+	}
+}
+
+// redeliverAfterFailedFlush detaches the stream a flush failed on and, if the Agent already
+// reconnected on a newer stream whose own flush ran before the commands were re-queued, flushes again.
+// redeliverAfterFailedFlush 清除投递失败的命令流；若 Agent 已通过更新的命令流重连，
+// 且其投递早于命令重新入队，则再次投递。
+func (m *Manager) redeliverAfterFailedFlush(agentID string, failed grpc.BidiStreamingServer[pb.CommandResponse, pb.CommandRequest]) {
+	conn, ok := m.GetAgent(agentID)
+	if !ok {
+		return
+	}
+	m.detachFailedStream(conn, failed)
+	if stream := liveStream(conn); stream != nil {
+		m.flushCommandQueue(agentID, stream)
+	}
+}
+
+// expireQueuedCommands fails queued commands whose TTL has elapsed.
+// expireQueuedCommands 使 TTL 已到期的排队命令失败。
+func (m *Manager) expireQueuedCommands() {
+	now := time.Now()
+	var expired []*queuedCommand
+
+	m.queueMu.Lock()
+	for agentID, pending := range m.commandQueues {
+		kept := pending[:0]
+		for _, qc := range pending {
+			if now.After(qc.expireAt) || qc.cmdCtx.IsDone() {
+				expired = append(expired, qc)
+				continue
+			}
+			kept = append(kept, qc)
+		}
+		if len(kept) == 0 {
+			delete(m.commandQueues, agentID)
+		} else {
+			m.commandQueues[agentID] = kept
+		}
+	}
+	m.queueMu.Unlock()
+
+	for _, qc := range expired {
+		m.failQueuedCommand(qc, ErrCommandQueueExpired)
+	}
+}
+
+// failQueuedCommand marks a queued command as failed and notifies any waiter.
+// failQueuedCommand 将排队命令标记为失败并通知等待方。
+func (m *Manager) failQueuedCommand(qc *queuedCommand, err error) {
+	qc.cmdCtx.mu.Lock()
+	if !qc.cmdCtx.Done {
+		qc.cmdCtx.LastStatus = pb.CommandStatus_FAILED
+		qc.cmdCtx.LastError = err.Error()
+		qc.cmdCtx.Done = true
+	}
+	qc.cmdCtx.mu.Unlock()
+	qc.delivered <- err
+
+	// Keep for status queries like completed commands, then clean up.
+	// 与已完成命令一样保留以供状态查询，随后清理。
+	go func(commandID string) {
+		time.Sleep(5 * time.Minute)
+		m.commands.Delete(commandID)
+	}(qc.cmdCtx.CommandID)
+}
+
+// QueuedCommandCount returns the number of commands waiting for the Agent to reconnect.
+// QueuedCommandCount 返回等待 Agent 重连的命令数量。
+func (m *Manager) QueuedCommandCount(agentID string) int {
+	m.queueMu.Lock()
+	defer m.queueMu.Unlock()
+	return len(m.commandQueues[agentID])
+}
@@ -0,0 +1,196 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package agent
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	pb "github.com/seatunnel/seatunnelX/internal/proto/agent"
+	"google.golang.org/grpc"
+)
+
+// fakeCommandStream records commands sent to an Agent.
+// fakeCommandStream 记录发送给 Agent 的命令。
+type fakeCommandStream struct {
+	grpc.ServerStream
+	mu   sync.Mutex
+	sent []*pb.CommandRequest
+}
+
+func (s *fakeCommandStream) Send(req *pb.CommandRequest) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sent = append(s.sent, req)
+	return nil
+}
+
+func (s *fakeCommandStream) Recv() (*pb.CommandResponse, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (s *fakeCommandStream) sentCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.sent)
+}
+
+// TestCommandQueuedUntilStreamReestablished tests store-and-forward during reconnect.
+// TestCommandQueuedUntilStreamReestablished 测试重连期间的命令暂存与转发。
+func TestCommandQueuedUntilStreamReestablished(t *testing.T) {
+	m := NewManager(nil)
+	ctx := context.Background()
+	_, _ = m.RegisterAgent(ctx, &pb.RegisterRequest{AgentId: "agent-queue", IpAddress: "192.168.9.1"})
+	m.HandleDisconnect("agent-queue")
+
+	commandID, err := m.SendCommandAsync(context.Background(), "agent-queue", pb.CommandType_STATUS, nil, time.Minute)
+	if err != nil {
+		t.Fatalf("Expected command to be queued, got error: %v", err)
+	}
+	if got := m.QueuedCommandCount("agent-queue"); got != 1 {
+		t.Fatalf("Expected 1 queued command, got %d", got)
+	}
+	if status, _, _, _ := m.GetCommandStatus(commandID); status != "pending" {
+		t.Errorf("Expected status 'pending', got '%s'", status)
+	}
+
+	stream := &fakeCommandStream{}
+	if err := m.SetAgentStream("agent-queue", stream); err != nil {
+		t.Fatalf("SetAgentStream failed: %v", err)
+	}
+
+	if stream.sentCount() != 1 || stream.sent[0].CommandId != commandID {
+		t.Fatalf("Expected queued command to be delivered after reconnect")
+	}
+	if got := m.QueuedCommandCount("agent-queue"); got != 0 {
+		t.Errorf("Expected empty queue after flush, got %d", got)
+	}
+}
+
+// TestQueuedCommandExpires tests that queued commands fail after the TTL.
+// TestQueuedCommandExpires 测试排队命令在 TTL 后失败。
+func TestQueuedCommandExpires(t *testing.T) {
+	m := NewManager(&ManagerConfig{CommandQueueTTL: 50 * time.Millisecond})
+	ctx := context.Background()
+	_, _ = m.RegisterAgent(ctx, &pb.RegisterRequest{AgentId: "agent-expire", IpAddress: "192.168.9.2"})
+	m.HandleDisconnect("agent-expire")
+
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		m.expireQueuedCommands()
+	}()
+
+	_, err := m.SendCommand(ctx, "agent-expire", pb.CommandType_STATUS, nil, 5*time.Second)
+	if !errors.Is(err, ErrCommandQueueExpired) {
+		t.Fatalf("Expected ErrCommandQueueExpired, got %v", err)
+	}
+}
+
+// TestOfflineAgentNotQueued tests that commands to offline agents fail fast.
+// TestOfflineAgentNotQueued 测试发往离线 Agent 的命令立即失败。
+func TestOfflineAgentNotQueued(t *testing.T) {
+	m := NewManager(nil)
+	ctx := context.Background()
+	conn, _ := m.RegisterAgent(ctx, &pb.RegisterRequest{AgentId: "agent-offline", IpAddress: "192.168.9.3"})
+	conn.SetStatus(AgentStatusOffline)
+
+	if _, err := m.SendCommandAsync(context.Background(), "agent-offline", pb.CommandType_STATUS, nil, time.Minute); !errors.Is(err, ErrAgentNotConnected) {
+		t.Fatalf("Expected ErrAgentNotConnected, got %v", err)
+	}
+	if got := m.QueuedCommandCount("agent-offline"); got != 0 {
+		t.Errorf("Expected no queued commands, got %d", got)
+	}
+}
+
+// failingCommandStream fails every Send, running onSend first to simulate a concurrent reconnect.
+// failingCommandStream 每次 Send 都失败，并先执行 onSend 以模拟并发重连。
+type failingCommandStream struct {
+	fakeCommandStream
+	onSend func()
+}
+
+func (s *failingCommandStream) Send(*pb.CommandRequest) error {
+	if s.onSend != nil {
+		s.onSend()
+	}
+	return errors.New("stream broken")
+}
+
+// TestFailedSendQueuesAndDetachesStream tests that a command whose send fails waits for the reconnect.
+// TestFailedSendQueuesAndDetachesStream 测试发送失败的命令会排队等待重连。
+func TestFailedSendQueuesAndDetachesStream(t *testing.T) {
+	m := NewManager(nil)
+	ctx := context.Background()
+	conn, _ := m.RegisterAgent(ctx, &pb.RegisterRequest{AgentId: "agent-broken", IpAddress: "192.168.9.4"})
+	broken := &failingCommandStream{}
+	if err := m.SetAgentStream("agent-broken", broken); err != nil {
+		t.Fatalf("SetAgentStream failed: %v", err)
+	}
+
+	commandID, err := m.SendCommandAsync(ctx, "agent-broken", pb.CommandType_STATUS, nil, time.Minute)
+	if err != nil {
+		t.Fatalf("Expected command to be queued, got error: %v", err)
+	}
+	if got := m.QueuedCommandCount("agent-broken"); got != 1 {
+		t.Fatalf("Expected 1 queued command, got %d", got)
+	}
+	if conn.GetStream() != nil || conn.GetStatus() != AgentStatusDisconnected {
+		t.Fatalf("Expected the broken stream to be detached, got status %s", conn.GetStatus())
+	}
+
+	stream := &fakeCommandStream{}
+	if err := m.SetAgentStream("agent-broken", stream); err != nil {
+		t.Fatalf("SetAgentStream failed: %v", err)
+	}
+	if stream.sentCount() != 1 || stream.sent[0].CommandId != commandID {
+		t.Fatalf("Expected queued command to be delivered after reconnect")
+	}
+}
+
+// TestFailedSendUsesStreamInstalledMeanwhile tests that a reconnect racing a failed send is not missed.
+// TestFailedSendUsesStreamInstalledMeanwhile 测试与发送失败并发的重连不会导致命令滞留。
+func TestFailedSendUsesStreamInstalledMeanwhile(t *testing.T) {
+	m := NewManager(nil)
+	ctx := context.Background()
+	_, _ = m.RegisterAgent(ctx, &pb.RegisterRequest{AgentId: "agent-race", IpAddress: "192.168.9.5"})
+
+	stream := &fakeCommandStream{}
+	broken := &failingCommandStream{}
+	broken.onSend = func() {
+		// The Agent reconnects and its (empty) queue is flushed before the failed send returns
+		// Agent 在发送失败返回前完成重连，并已投递（空的）队列
+		_ = m.SetAgentStream("agent-race", stream)
+	}
+	if err := m.SetAgentStream("agent-race", broken); err != nil {
+		t.Fatalf("SetAgentStream failed: %v", err)
+	}
+
+	commandID, err := m.SendCommandAsync(ctx, "agent-race", pb.CommandType_STATUS, nil, time.Minute)
+	if err != nil {
+		t.Fatalf("SendCommandAsync failed: %v", err)
+	}
+	if stream.sentCount() != 1 || stream.sent[0].CommandId != commandID {
+		t.Fatalf("Expected the command to be sent on the new stream")
+	}
+	if got := m.QueuedCommandCount("agent-race"); got != 0 {
+		t.Errorf("Expected no stranded commands, got %d", got)
+	}
+}
@@ -0,0 +1,63 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package agent
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pb "github.com/seatunnel/seatunnelX/internal/proto/agent"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TestSendCommandAsyncPropagatesTraceContext tests that the caller's span travels in command metadata.
+// TestSendCommandAsyncPropagatesTraceContext 测试调用方的 span 通过命令元数据传递。
+func TestSendCommandAsyncPropagatesTraceContext(t *testing.T) {
+	m := NewManager(nil)
+	_, _ = m.RegisterAgent(context.Background(), &pb.RegisterRequest{AgentId: "agent-trace", IpAddress: "192.168.9.9"})
+	stream := &fakeCommandStream{}
+	if err := m.SetAgentStream("agent-trace", stream); err != nil {
+		t.Fatalf("SetAgentStream failed: %v", err)
+	}
+
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+	}))
+
+	if _, err := m.SendCommandAsync(ctx, "agent-trace", pb.CommandType_INSTALL, nil, time.Minute); err != nil {
+		t.Fatalf("SendCommandAsync failed: %v", err)
+	}
+	if _, err := m.SendCommandAsync(context.Background(), "agent-trace", pb.CommandType_STATUS, nil, time.Minute); err != nil {
+		t.Fatalf("SendCommandAsync failed: %v", err)
+	}
+
+	if stream.sentCount() != 2 {
+		t.Fatalf("Expected 2 commands sent, got %d", stream.sentCount())
+	}
+	if got := stream.sent[0].Metadata["traceparent"]; got != "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01" {
+		t.Errorf("Expected traceparent metadata, got %q", got)
+	}
+	if len(stream.sent[1].Metadata) != 0 {
+		t.Errorf("Expected no metadata without a span, got %v", stream.sent[1].Metadata)
+	}
+}
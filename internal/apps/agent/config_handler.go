@@ -0,0 +1,149 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package agent
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/seatunnel/seatunnelX/internal/apps/audit"
+	"github.com/seatunnel/seatunnelX/internal/apps/auth"
+	"github.com/seatunnel/seatunnelX/internal/pkg/errcode"
+	pb "github.com/seatunnel/seatunnelX/internal/proto/agent"
+)
+
+var (
+	// ErrInvalidAgentConfig indicates an Agent config update without valid fields.
+	// ErrInvalidAgentConfig 表示 Agent 配置更新中没有有效字段。
+	ErrInvalidAgentConfig = errors.New("agent: invalid agent config")
+	// ErrAgentConfigRejected indicates the Agent refused to apply the pushed config.
+	// ErrAgentConfigRejected 表示 Agent 拒绝应用下发的配置。
+	ErrAgentConfigRejected = errors.New("agent: push config rejected")
+)
+
+// ConfigHandler provides the admin HTTP handler for hot-reloading Agent runtime configuration.
+// ConfigHandler 提供热加载 Agent 运行期配置的管理员 HTTP 处理器。
+type ConfigHandler struct {
+	manager   *Manager
+	auditRepo *audit.Repository
+}
+
+// NewConfigHandler creates a new ConfigHandler instance.
+// NewConfigHandler 创建一个新的 ConfigHandler 实例。
+// auditRepo may be nil; audit logging is skipped when nil.
+func NewConfigHandler(manager *Manager, auditRepo *audit.Repository) *ConfigHandler {
+	return &ConfigHandler{manager: manager, auditRepo: auditRepo}
+}
+
+// AgentConfigRequest is the payload for updating an Agent's runtime configuration.
+// Omitted or zero fields are left unchanged on the Agent.
+// AgentConfigRequest 是更新 Agent 运行期配置的请求体。
+// 省略或为零值的字段在 Agent 端保持不变。
+type AgentConfigRequest struct {
+	// HeartbeatInterval in seconds / HeartbeatInterval 心跳间隔（秒）
+	HeartbeatInterval int `json:"heartbeat_interval"`
+	// LogLevel is debug, info, warn or error / LogLevel 为 debug、info、warn 或 error
+	LogLevel string `json:"log_level"`
+	// MetricsInterval in seconds / MetricsInterval 指标采集间隔（秒）
+	MetricsInterval int `json:"metrics_interval"`
+}
+
+// AgentConfigResponse represents the response of an Agent config update.
+// AgentConfigResponse 表示 Agent 配置更新的响应。
+type AgentConfigResponse struct {
+	ErrorMsg string              `json:"error_msg"`
+	Data     *AgentConfigRequest `json:"data"`
+}
+
+// toProto validates the request and converts it to the config pushed to the Agent.
+// toProto 校验请求并转换为下发给 Agent 的配置。
+func (r *AgentConfigRequest) toProto() (*pb.AgentConfig, error) {
+	if r.HeartbeatInterval < 0 || r.MetricsInterval < 0 {
+		return nil, ErrInvalidAgentConfig
+	}
+	cfg := &pb.AgentConfig{HeartbeatInterval: int32(r.HeartbeatInterval)}
+	if r.LogLevel != "" {
+		level, ok := pb.LogLevel_value[strings.ToUpper(strings.TrimSpace(r.LogLevel))]
+		if !ok || level == int32(pb.LogLevel_LOG_LEVEL_UNSPECIFIED) {
+			return nil, ErrInvalidAgentConfig
+		}
+		cfg.LogLevel = level
+	}
+	if r.MetricsInterval > 0 {
+		cfg.Extra = map[string]string{"metrics_interval": strconv.Itoa(r.MetricsInterval)}
+	}
+	if cfg.HeartbeatInterval == 0 && cfg.LogLevel == 0 && len(cfg.Extra) == 0 {
+		return nil, ErrInvalidAgentConfig
+	}
+	return cfg, nil
+}
+
+// UpdateAgentConfig handles PUT /api/v1/admin/agents/:agentId/config - hot-reloads an Agent's runtime configuration.
+// UpdateAgentConfig 处理 PUT /api/v1/admin/agents/:agentId/config - 热加载 Agent 的运行期配置。
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param agentId path string true "Agent ID"
+// @Param request body AgentConfigRequest true "Agent 配置"
+// @Success 200 {object} AgentConfigResponse
+// @Router /api/v1/admin/agents/{agentId}/config [put]
+func (h *ConfigHandler) UpdateAgentConfig(c *gin.Context) {
+	var req AgentConfigRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, AgentConfigResponse{ErrorMsg: err.Error()})
+		return
+	}
+	cfg, err := req.toProto()
+	if err != nil {
+		c.JSON(agentConfigErrorStatus(c, err), AgentConfigResponse{ErrorMsg: err.Error()})
+		return
+	}
+
+	agentID := c.Param("agentId")
+	if err := h.manager.PushAgentConfig(c.Request.Context(), agentID, cfg); err != nil {
+		c.JSON(agentConfigErrorStatus(c, err), AgentConfigResponse{ErrorMsg: err.Error()})
+		return
+	}
+
+	_ = audit.RecordFromGin(c, h.auditRepo, auth.GetUserIDFromContext(c), auth.GetUsernameFromContext(c),
+		"update", "agent_config", agentID, agentID, audit.AuditDetails{
+			"heartbeat_interval": req.HeartbeatInterval,
+			"log_level":          req.LogLevel,
+			"metrics_interval":   req.MetricsInterval,
+		})
+	c.JSON(http.StatusOK, AgentConfigResponse{Data: &req})
+}
+
+// agentConfigErrorStatus records the catalog code of err on the request and returns its HTTP status.
+// agentConfigErrorStatus 在请求上记录 err 的目录错误码并返回其 HTTP 状态码。
+func agentConfigErrorStatus(c *gin.Context, err error) int {
+	errcode.Attach(c, ErrorCode(err))
+	switch {
+	case errors.Is(err, ErrInvalidAgentConfig), errors.Is(err, ErrAgentConfigRejected):
+		return http.StatusBadRequest
+	case errors.Is(err, ErrAgentNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrAgentNotConnected), errors.Is(err, ErrStreamNotAvailable):
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,89 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package agent
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// drainPollInterval is how often WaitInFlight re-checks pending commands.
+// drainPollInterval 是 WaitInFlight 重新检查待处理命令的间隔。
+const drainPollInterval = 200 * time.Millisecond
+
+// ErrManagerDraining indicates the Control Plane is shutting down and no longer accepts new commands.
+// ErrManagerDraining 表示 Control Plane 正在关闭，不再接受新命令。
+var ErrManagerDraining = errors.New("agent: control plane is draining, new commands are rejected")
+
+// BeginDrain stops the manager from accepting new commands; commands already sent keep running.
+// BeginDrain 使管理器停止接受新命令；已发送的命令继续执行。
+func (m *Manager) BeginDrain() {
+	m.draining.Store(true)
+}
+
+// IsDraining returns whether the manager is draining.
+// IsDraining 返回管理器是否正在排空。
+func (m *Manager) IsDraining() bool {
+	return m.draining.Load()
+}
+
+// InFlightCount returns the number of commands that have not reached a terminal status.
+// InFlightCount 返回尚未进入终止状态的命令数量。
+func (m *Manager) InFlightCount() int {
+	count := 0
+	m.commands.Range(func(_, value any) bool {
+		if !value.(*CommandContext).IsDone() {
+			count++
+		}
+		return true
+	})
+	return count
+}
+
+// InFlightCountByAgent returns the number of unfinished commands per Agent.
+// InFlightCountByAgent 返回每个 Agent 未完成的命令数。
+func (m *Manager) InFlightCountByAgent() map[string]int {
+	counts := make(map[string]int)
+	m.commands.Range(func(_, value any) bool {
+		if cmdCtx := value.(*CommandContext); !cmdCtx.IsDone() {
+			counts[cmdCtx.AgentID]++
+		}
+		return true
+	})
+	return counts
+}
+
+// WaitInFlight blocks until every in-flight command finishes or ctx is done.
+// It returns the number of commands still running when it gave up.
+// WaitInFlight 阻塞直到所有在途命令完成或 ctx 结束，返回放弃等待时仍在运行的命令数。
+func (m *Manager) WaitInFlight(ctx context.Context) int {
+	ticker := time.NewTicker(drainPollInterval)
+	defer ticker.Stop()
+	for {
+		pending := m.InFlightCount()
+		if pending == 0 {
+			return 0
+		}
+		select {
+		case <-ctx.Done():
+			return pending
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,62 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package agent
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	pb "github.com/seatunnel/seatunnelX/internal/proto/agent"
+)
+
+// TestDrainRejectsNewCommandsAndWaitsInFlight tests that draining blocks new commands but lets sent ones finish.
+// TestDrainRejectsNewCommandsAndWaitsInFlight 测试排空时拒绝新命令，但允许已发送的命令完成。
+func TestDrainRejectsNewCommandsAndWaitsInFlight(t *testing.T) {
+	m := NewManager(nil)
+	ctx := context.Background()
+	_, _ = m.RegisterAgent(ctx, &pb.RegisterRequest{AgentId: "agent-drain", IpAddress: "192.168.9.5"})
+	if err := m.SetAgentStream("agent-drain", &fakeCommandStream{}); err != nil {
+		t.Fatalf("SetAgentStream failed: %v", err)
+	}
+
+	commandID, err := m.SendCommandAsync(context.Background(), "agent-drain", pb.CommandType_STATUS, nil, time.Minute)
+	if err != nil {
+		t.Fatalf("SendCommandAsync failed: %v", err)
+	}
+
+	m.BeginDrain()
+	if _, err := m.SendCommandAsync(context.Background(), "agent-drain", pb.CommandType_STATUS, nil, time.Minute); !errors.Is(err, ErrManagerDraining) {
+		t.Fatalf("Expected ErrManagerDraining, got %v", err)
+	}
+	if _, err := m.SendCommand(ctx, "agent-drain", pb.CommandType_STATUS, nil, time.Minute); !errors.Is(err, ErrManagerDraining) {
+		t.Fatalf("Expected ErrManagerDraining, got %v", err)
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	if pending := m.WaitInFlight(waitCtx); pending != 1 {
+		t.Fatalf("Expected 1 pending command after timeout, got %d", pending)
+	}
+
+	m.HandleCommandResponse(&pb.CommandResponse{CommandId: commandID, Status: pb.CommandStatus_SUCCESS})
+	if pending := m.WaitInFlight(ctx); pending != 0 {
+		t.Fatalf("Expected no pending commands, got %d", pending)
+	}
+}
@@ -19,9 +19,74 @@
 // agent 包提供 SeaTunnel Control Plane 的 Agent 连接管理功能。
 package agent
 
+import (
+	"errors"
+
+	"github.com/seatunnel/seatunnelX/internal/pkg/errcode"
+)
+
 // Error messages for Agent Manager operations (defined in manager.go)
 // Agent Manager 操作的错误消息（在 manager.go 中定义）
 // - ErrAgentNotFound: Agent not found / 未找到 Agent
 // - ErrAgentNotConnected: Agent not connected / Agent 未连接
 // - ErrCommandTimeout: Command execution timeout / 命令执行超时
 // - ErrStreamNotAvailable: Command stream not available / 命令流不可用
+// - ErrCommandQueueExpired: Queued command expired before agent reconnected / 排队命令在 Agent 重连前过期（在 command_queue.go 中定义）
+// - ErrCommandNotFound / ErrCommandFinished / ErrCommandCancelled: Command cancellation errors / 命令取消相关错误（在 command_cancel.go 中定义）
+// - ErrCommandStalled / ErrCommandDeadlineExceeded: Command liveness timeouts / 命令存活超时（在 command_liveness.go 中定义）
+// - ErrManagerDraining: Control Plane is draining / Control Plane 正在排空（在 drain.go 中定义）
+// - ErrRegistrationRateLimited / ErrAgentDenied / ErrAgentPendingApproval: Registration admission errors / 注册准入相关错误（在 admission.go 中定义）
+// - ErrBatchCommandUnsupported / ErrBatchNoTargets / ErrBatchTooManyTargets / ErrNoAgentInstalled: Batch and script command errors / 批量与脚本命令相关错误（在 batch.go 中定义）
+// - ErrInvalidAgentConfig / ErrAgentConfigRejected: Agent config hot-reload errors / Agent 配置热加载相关错误（在 config_handler.go 中定义）
+
+// ErrorCode maps Agent Manager errors to catalog codes; unknown errors map to ST-GEN-006.
+// ErrorCode 将 Agent Manager 错误映射为目录错误码；未知错误映射为 ST-GEN-006。
+func ErrorCode(err error) errcode.Code {
+	if code, ok := errcode.Of(err); ok {
+		return code
+	}
+	switch {
+	case errors.Is(err, ErrAgentNotConnected):
+		return errcode.AgentNotConnected
+	case errors.Is(err, ErrAgentNotFound):
+		return errcode.AgentNotFound
+	case errors.Is(err, ErrStreamNotAvailable):
+		return errcode.CommandStreamNotReady
+	case errors.Is(err, ErrCommandTimeout),
+		errors.Is(err, ErrCommandStalled),
+		errors.Is(err, ErrCommandDeadlineExceeded):
+		return errcode.CommandTimeout
+	case errors.Is(err, ErrCommandQueueExpired):
+		return errcode.CommandQueueExpired
+	case errors.Is(err, ErrCommandCancelled):
+		return errcode.CommandCancelled
+	case errors.Is(err, ErrCommandNotFound):
+		return errcode.CommandNotFound
+	case errors.Is(err, ErrCommandFinished):
+		return errcode.Conflict
+	case errors.Is(err, ErrManagerDraining):
+		return errcode.ControlPlaneDraining
+	case errors.Is(err, ErrRegistrationRateLimited):
+		return errcode.RateLimited
+	case errors.Is(err, ErrAgentDenied), errors.Is(err, ErrAgentPendingApproval):
+		return errcode.AgentRejected
+	case errors.Is(err, ErrPendingAgentNotFound):
+		return errcode.PendingAgentNotFound
+	case errors.Is(err, ErrDenyRuleNotFound):
+		return errcode.NotFound
+	case errors.Is(err, ErrInvalidDenyRule):
+		return errcode.InvalidRequest
+	case errors.Is(err, ErrAdmissionStoreUnavailable):
+		return errcode.Unavailable
+	case errors.Is(err, ErrBatchCommandUnsupported):
+		return errcode.UnsupportedCommand
+	case errors.Is(err, ErrBatchNoTargets), errors.Is(err, ErrBatchTooManyTargets):
+		return errcode.InvalidRequest
+	case errors.Is(err, ErrNoAgentInstalled):
+		return errcode.AgentNotFound
+	case errors.Is(err, ErrInvalidAgentConfig), errors.Is(err, ErrAgentConfigRejected):
+		return errcode.InvalidRequest
+	default:
+		return errcode.Internal
+	}
+}
@@ -0,0 +1,134 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package agent
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/seatunnel/seatunnelX/internal/pkg/filestream"
+	pb "github.com/seatunnel/seatunnelX/internal/proto/agent"
+)
+
+// ErrFileTransferNotFound indicates the transfer is unknown, finished or belongs to another Agent.
+// ErrFileTransferNotFound 表示传输不存在、已结束或属于其他 Agent。
+var ErrFileTransferNotFound = errors.New("agent: file transfer not found")
+
+// FileTransfer is a local file offered to one Agent through the FetchFile RPC.
+// FileTransfer 是通过 FetchFile RPC 提供给某个 Agent 的本地文件。
+type FileTransfer struct {
+	ID          string
+	AgentID     string
+	Path        string
+	Size        int64
+	ChunkSize   int
+	Compression string
+
+	// OnProgress receives throughput and ETA after every chunk sent; may be nil.
+	// OnProgress 在每个数据块发送后接收吞吐量和预计剩余时间；可为 nil。
+	OnProgress func(filestream.Snapshot)
+
+	mu       sync.Mutex
+	progress *filestream.Progress
+}
+
+// ReportProgress records that sent bytes have been streamed to the Agent.
+// ReportProgress 记录已向 Agent 流式发送 sent 字节。
+func (t *FileTransfer) ReportProgress(sent int64) {
+	if t.OnProgress == nil {
+		return
+	}
+	t.mu.Lock()
+	snapshot := t.progress.Update(sent)
+	t.mu.Unlock()
+	t.OnProgress(snapshot)
+}
+
+// RegisterFileTransfer offers a local file to an Agent and returns the transfer handle.
+// RegisterFileTransfer 向 Agent 提供本地文件并返回传输句柄。
+func (m *Manager) RegisterFileTransfer(agentID, path string, onProgress func(filestream.Snapshot)) (*FileTransfer, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	transfer := &FileTransfer{
+		ID:          uuid.New().String(),
+		AgentID:     agentID,
+		Path:        path,
+		Size:        info.Size(),
+		ChunkSize:   m.config.FileTransferChunkSize,
+		Compression: m.config.FileTransferCompression,
+		OnProgress:  onProgress,
+		progress:    filestream.NewProgress(info.Size()),
+	}
+	m.fileTransfers.Store(transfer.ID, transfer)
+	return transfer, nil
+}
+
+// UnregisterFileTransfer stops offering a file; later FetchFile calls fail with not found.
+// UnregisterFileTransfer 停止提供文件；之后的 FetchFile 调用返回未找到。
+func (m *Manager) UnregisterFileTransfer(transferID string) {
+	m.fileTransfers.Delete(transferID)
+}
+
+// LookupFileTransfer returns the transfer registered for the Agent.
+// LookupFileTransfer 返回为该 Agent 注册的传输。
+func (m *Manager) LookupFileTransfer(agentID, transferID string) (*FileTransfer, error) {
+	value, ok := m.fileTransfers.Load(transferID)
+	if !ok {
+		return nil, ErrFileTransferNotFound
+	}
+	transfer := value.(*FileTransfer)
+	if transfer.AgentID != agentID {
+		return nil, ErrFileTransferNotFound
+	}
+	return transfer, nil
+}
+
+// StreamFileToAgent sends a transfer command in streaming mode and lets the Agent pull localPath
+// through FetchFile. It returns filestream.ErrUnsupported when the Agent only handles chunked commands,
+// which older Agents reveal by answering the first command with a RUNNING chunk acknowledgement.
+// StreamFileToAgent 以流式模式发送传输命令，由 Agent 通过 FetchFile 拉取 localPath。
+// 当 Agent 只支持分块命令时返回 filestream.ErrUnsupported；旧版 Agent 会以 RUNNING 的分块确认响应首个命令。
+func (m *Manager) StreamFileToAgent(ctx context.Context, agentID string, cmdType pb.CommandType, params map[string]string, localPath string, timeout time.Duration, onProgress func(filestream.Snapshot)) (*pb.CommandResponse, error) {
+	transfer, err := m.RegisterFileTransfer(agentID, localPath, onProgress)
+	if err != nil {
+		return nil, err
+	}
+	defer m.UnregisterFileTransfer(transfer.ID)
+
+	streamParams := make(map[string]string, len(params)+2)
+	for key, value := range params {
+		streamParams[key] = value
+	}
+	streamParams[filestream.ParamTransferMode] = filestream.ModeStream
+	streamParams[filestream.ParamTransferID] = transfer.ID
+
+	resp, err := m.SendCommand(ctx, agentID, cmdType, streamParams, timeout)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Status == pb.CommandStatus_RUNNING {
+		return nil, filestream.ErrUnsupported
+	}
+	return resp, nil
+}
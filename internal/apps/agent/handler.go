@@ -61,6 +61,14 @@ type Handler struct {
 	// heartbeatInterval is the heartbeat interval in seconds from Control Plane config.
 	// heartbeatInterval 是来自 Control Plane 配置的心跳间隔（秒）。
 	heartbeatInterval int
+
+	// telemetryEndpoint is the OTLP collector written into generated Agent configs.
+	// telemetryEndpoint 是写入生成的 Agent 配置的 OTLP 收集器地址。
+	telemetryEndpoint string
+
+	// telemetryInsecure disables TLS towards the collector.
+	// telemetryInsecure 表示连接收集器时不使用 TLS。
+	telemetryInsecure bool
 }
 
 // HandlerConfig holds configuration for the Agent Handler.
@@ -89,6 +97,14 @@ type HandlerConfig struct {
 	// HeartbeatInterval is the heartbeat interval in seconds.
 	// HeartbeatInterval 是心跳间隔（秒）。
 	HeartbeatInterval int
+
+	// TelemetryEndpoint is the OTLP collector Agents export spans to; empty disables Agent tracing.
+	// TelemetryEndpoint 是 Agent 导出 span 的 OTLP 收集器地址；为空时不启用 Agent 追踪。
+	TelemetryEndpoint string
+
+	// TelemetryInsecure disables TLS towards the collector.
+	// TelemetryInsecure 表示连接收集器时不使用 TLS。
+	TelemetryInsecure bool
 }
 
 // NewHandler creates a new Handler instance.
@@ -126,6 +142,8 @@ func NewHandler(cfg *HandlerConfig) *Handler {
 		seatunnelxJavaProxyScriptPath: cfg.SeatunnelXJavaProxyScriptPath,
 		grpcPort:                      cfg.GRPCPort,
 		heartbeatInterval:             cfg.HeartbeatInterval,
+		telemetryEndpoint:             cfg.TelemetryEndpoint,
+		telemetryInsecure:             cfg.TelemetryInsecure,
 	}
 }
 
@@ -147,22 +165,7 @@ type ErrorResponse struct {
 // @Success 200 {string} string "Install script"
 // @Router /api/v1/agent/install.sh [get]
 func (h *Handler) GetInstallScript(c *gin.Context) {
-	// Use InstallScriptGenerator to generate the install script
-	// 使用 InstallScriptGenerator 生成安装脚本
-	generator, err := NewInstallScriptGenerator(&InstallScriptConfig{
-		ControlPlaneAddr:  h.getControlPlaneURL(),
-		GRPCAddr:          h.getGRPCAddr(),
-		HeartbeatInterval: h.heartbeatInterval,
-	})
-	if err != nil {
-		logger.ErrorF(c.Request.Context(), "[Agent] Failed to create install script generator: %v", err)
-		c.JSON(http.StatusInternalServerError, ErrorResponse{ErrorMsg: "Failed to generate install script / 生成安装脚本失败"})
-		return
-	}
-
-	// Generate the install script
-	// 生成安装脚本
-	script, err := generator.Generate()
+	script, err := h.InstallScript()
 	if err != nil {
 		logger.ErrorF(c.Request.Context(), "[Agent] Failed to generate install script: %v", err)
 		c.JSON(http.StatusInternalServerError, ErrorResponse{ErrorMsg: "Failed to generate install script / 生成安装脚本失败"})
@@ -179,6 +182,38 @@ func (h *Handler) GetInstallScript(c *gin.Context) {
 	c.String(http.StatusOK, script)
 }
 
+// InstallScript generates the Agent install script for this Control Plane.
+// InstallScript 生成当前 Control Plane 的 Agent 安装脚本。
+func (h *Handler) InstallScript() (string, error) {
+	generator, err := NewInstallScriptGenerator(&InstallScriptConfig{
+		ControlPlaneAddr:  h.getControlPlaneURL(),
+		GRPCAddr:          h.getGRPCAddr(),
+		HeartbeatInterval: h.heartbeatInterval,
+		TelemetryEndpoint: h.telemetryEndpoint,
+		TelemetryInsecure: h.telemetryInsecure,
+	})
+	if err != nil {
+		return "", fmt.Errorf("create install script generator: %w", err)
+	}
+	return generator.Generate()
+}
+
+// BinaryPath returns the local Agent binary for a platform as reported by uname (e.g. Linux/x86_64).
+// BinaryPath 返回指定平台（如 uname 输出的 Linux/x86_64）对应的本地 Agent 二进制文件路径。
+func (h *Handler) BinaryPath(osType, arch string) (string, error) {
+	osType = NormalizeOS(osType)
+	arch = NormalizeArch(arch)
+	binaryName, ok := supportedArchitectures[osType][arch]
+	if !ok {
+		return "", fmt.Errorf("unsupported platform %s-%s", osType, arch)
+	}
+	binaryPath := filepath.Join(h.agentBinaryDir, binaryName)
+	if _, err := os.Stat(binaryPath); err != nil {
+		return "", fmt.Errorf("agent binary not found for %s-%s: %w", osType, arch, err)
+	}
+	return binaryPath, nil
+}
+
 // ==================== Download Handler 下载处理器 ====================
 
 // supportedArchitectures defines the supported OS and architecture combinations.
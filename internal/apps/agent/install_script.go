@@ -44,6 +44,14 @@ type InstallScriptGenerator struct {
 	// heartbeatInterval 是心跳间隔（秒）。
 	heartbeatInterval int
 
+	// telemetryEndpoint is the OTLP collector written into the Agent config; empty disables tracing.
+	// telemetryEndpoint 是写入 Agent 配置的 OTLP 收集器地址；为空时不启用追踪。
+	telemetryEndpoint string
+
+	// telemetryInsecure disables TLS towards the collector.
+	// telemetryInsecure 表示连接收集器时不使用 TLS。
+	telemetryInsecure bool
+
 	// template is the parsed install script template.
 	// template 是解析后的安装脚本模板。
 	template *template.Template
@@ -63,6 +71,14 @@ type InstallScriptConfig struct {
 	// HeartbeatInterval is the heartbeat interval in seconds from Control Plane config.
 	// HeartbeatInterval 是来自 Control Plane 配置的心跳间隔（秒）。
 	HeartbeatInterval int
+
+	// TelemetryEndpoint is the OTLP collector Agents export spans to; empty disables Agent tracing.
+	// TelemetryEndpoint 是 Agent 导出 span 的 OTLP 收集器地址；为空时不启用 Agent 追踪。
+	TelemetryEndpoint string
+
+	// TelemetryInsecure disables TLS towards the collector.
+	// TelemetryInsecure 表示连接收集器时不使用 TLS。
+	TelemetryInsecure bool
 }
 
 // InstallScriptData holds data for rendering the install script template.
@@ -111,6 +127,18 @@ type InstallScriptData struct {
 	// HeartbeatInterval is the heartbeat interval string (e.g., "60s").
 	// HeartbeatInterval 是心跳间隔字符串（如 "60s"）。
 	HeartbeatInterval string
+
+	// TelemetryEnabled indicates whether the Agent exports spans.
+	// TelemetryEnabled 表示 Agent 是否导出 span。
+	TelemetryEnabled bool
+
+	// TelemetryEndpoint is the OTLP collector address.
+	// TelemetryEndpoint 是 OTLP 收集器地址。
+	TelemetryEndpoint string
+
+	// TelemetryInsecure disables TLS towards the collector.
+	// TelemetryInsecure 表示连接收集器时不使用 TLS。
+	TelemetryInsecure bool
 }
 
 // SupportedPlatform represents a supported OS and architecture combination.
@@ -194,6 +222,8 @@ func NewInstallScriptGenerator(cfg *InstallScriptConfig) (*InstallScriptGenerato
 		controlPlaneAddr:  controlPlaneAddr,
 		grpcAddr:          grpcAddr,
 		heartbeatInterval: heartbeatInterval,
+		telemetryEndpoint: strings.TrimSpace(cfg.TelemetryEndpoint),
+		telemetryInsecure: cfg.TelemetryInsecure,
 		template:          tmpl,
 	}, nil
 }
@@ -214,6 +244,9 @@ func (g *InstallScriptGenerator) Generate() (string, error) {
 		SeatunnelXJavaProxyJarFileName:    seatunnelmeta.SeatunnelXJavaProxyJarFileName(seatunnelmeta.DefaultSeatunnelXJavaProxyVersion),
 		SeatunnelXJavaProxyScriptFileName: seatunnelmeta.SeatunnelXJavaProxyScriptFileName,
 		HeartbeatInterval:                 fmt.Sprintf("%ds", g.heartbeatInterval),
+		TelemetryEnabled:                  g.telemetryEndpoint != "",
+		TelemetryEndpoint:                 g.telemetryEndpoint,
+		TelemetryInsecure:                 g.telemetryInsecure,
 	}
 
 	return g.GenerateWithData(data)
@@ -530,7 +563,23 @@ download_agent() {
     local arch=$2
     local download_url="${CONTROL_PLANE_ADDR}/api/v1/agent/download?os=${os_type}&arch=${arch}"
     local temp_file="/tmp/${AGENT_BINARY}"
-    
+
+    # Use a binary already pushed to this host (e.g. by the SSH deployer)
+    # 使用已推送到本机的二进制文件（例如由 SSH 部署器推送）
+    if [ -n "${AGENT_BINARY_FILE:-}" ]; then
+        if [ ! -s "${AGENT_BINARY_FILE}" ]; then
+            log_error "Agent binary file is missing or empty: ${AGENT_BINARY_FILE}"
+            log_error "Agent 二进制文件不存在或为空: ${AGENT_BINARY_FILE}"
+            exit 1
+        fi
+        if [ "${AGENT_BINARY_FILE}" != "${temp_file}" ]; then
+            cp "${AGENT_BINARY_FILE}" "${temp_file}"
+        fi
+        log_info "Using pre-uploaded Agent binary: ${AGENT_BINARY_FILE}"
+        log_info "使用已上传的 Agent 二进制文件: ${AGENT_BINARY_FILE}"
+        return 0
+    fi
+
     log_step "Downloading Agent binary..."
     log_step "正在下载 Agent 二进制文件..."
     log_info "URL: ${download_url}"
@@ -674,6 +723,9 @@ install_agent() {
 # Agent 设置（固定 ID 保证主服务重启后仍能识别本机）
 agent:
   id: "${AGENT_ID}"
+  # Single-use enrollment token issued with the install command
+  # 随安装命令签发的一次性注册令牌
+  enrollment_token: "${ENROLLMENT_TOKEN:-}"
 
 # Control Plane connection settings
 # Control Plane 连接设置
@@ -692,6 +744,12 @@ control_plane:
   # Authentication token
   # 认证 Token
   token: ""
+  # Probe interval of the preferred (first) address while failed over to another one
+  # 故障转移到其他地址后探测首选（第一个）地址的间隔
+  failback_interval: 30s
+  # Consecutive unavailable RPCs before failing over to the next address
+  # 切换到下一个地址前允许的连续不可用 RPC 次数
+  failure_threshold: 3
 
 # Heartbeat settings
 # 心跳设置
@@ -725,6 +783,19 @@ seatunnel:
   # Default installation directory for SeaTunnel
   # SeaTunnel 的默认安装目录
   install_dir: /opt/seatunnel
+
+# Telemetry settings (command spans join the Control Plane trace)
+# 遥测设置（命令 span 加入 Control Plane 的追踪链路）
+telemetry:
+  # Whether to export spans to the OTLP collector
+  # 是否将 span 导出到 OTLP 收集器
+  enabled: {{.TelemetryEnabled}}
+  # OTLP gRPC collector address
+  # OTLP gRPC 收集器地址
+  endpoint: "{{.TelemetryEndpoint}}"
+  # Connect to the collector without TLS
+  # 连接收集器时不使用 TLS
+  insecure: {{.TelemetryInsecure}}
 EOF
     
     log_info "Configuration file created at ${CONFIG_DIR}/config.yaml"
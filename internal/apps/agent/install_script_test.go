@@ -118,6 +118,36 @@ func TestInstallScriptGenerate(t *testing.T) {
 	}
 }
 
+// TestInstallScriptGenerateTelemetry tests that the collector settings reach the Agent config.
+// TestInstallScriptGenerateTelemetry 测试收集器设置写入 Agent 配置。
+func TestInstallScriptGenerateTelemetry(t *testing.T) {
+	gen, err := NewInstallScriptGenerator(&InstallScriptConfig{
+		TelemetryEndpoint: "otel-collector:4317",
+		TelemetryInsecure: true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create generator: %v", err)
+	}
+	script, err := gen.Generate()
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	for _, expected := range []string{"telemetry:\n  # Whether", "enabled: true", "endpoint: \"otel-collector:4317\"", "insecure: true"} {
+		if !strings.Contains(script, expected) {
+			t.Errorf("Generated script missing telemetry content: %s", expected)
+		}
+	}
+
+	gen, _ = NewInstallScriptGenerator(nil)
+	script, err = gen.Generate()
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if !strings.Contains(script, "  enabled: false\n  # OTLP gRPC collector address") {
+		t.Error("Expected telemetry to be disabled without an endpoint")
+	}
+}
+
 // TestInstallScriptGenerateWithData tests script generation with custom data.
 // TestInstallScriptGenerateWithData 测试使用自定义数据生成脚本。
 func TestInstallScriptGenerateWithData(t *testing.T) {
@@ -22,10 +22,16 @@ package agent
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strconv"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/seatunnel/seatunnelX/internal/pkg/eventbus"
+	"github.com/seatunnel/seatunnelX/internal/pkg/filestream"
+	"github.com/seatunnel/seatunnelX/internal/pkg/tracex"
 	pb "github.com/seatunnel/seatunnelX/internal/proto/agent"
 	"google.golang.org/grpc"
 )
@@ -119,11 +125,76 @@ type AgentConnection struct {
 	// ConnectedAt 是 Agent 连接的时间戳。
 	ConnectedAt time.Time
 
+	// ControlPlaneEndpoint is the Control Plane address the Agent reports it is connected to.
+	// ControlPlaneEndpoint 是 Agent 上报的当前所连接的 Control Plane 地址。
+	ControlPlaneEndpoint string
+
+	// FailoverActive indicates the Agent is connected to a non-preferred Control Plane address.
+	// FailoverActive 表示 Agent 当前连接的是非首选 Control Plane 地址。
+	FailoverActive bool
+
+	// PluginCache is the latest plugin cache usage reported in heartbeats, nil for older Agents.
+	// PluginCache 是心跳中上报的最新插件缓存使用情况，旧版 Agent 为 nil。
+	PluginCache *pb.PluginCacheStats
+
+	// Inventory is the latest SeaTunnel install inventory reported in heartbeats, nil for older Agents.
+	// Inventory 是心跳中上报的最新 SeaTunnel 安装清单，旧版 Agent 为 nil。
+	Inventory *pb.SeaTunnelInventory
+
 	// mu protects concurrent access to the connection.
 	// mu 保护对连接的并发访问。
 	mu sync.RWMutex
 }
 
+// SetControlPlaneEndpoint records the Control Plane endpoint status reported by the Agent.
+// SetControlPlaneEndpoint 记录 Agent 上报的 Control Plane 端点状态。
+func (c *AgentConnection) SetControlPlaneEndpoint(endpoint string, failover bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ControlPlaneEndpoint = endpoint
+	c.FailoverActive = failover
+}
+
+// GetControlPlaneEndpoint returns the Control Plane endpoint status reported by the Agent.
+// GetControlPlaneEndpoint 返回 Agent 上报的 Control Plane 端点状态。
+func (c *AgentConnection) GetControlPlaneEndpoint() (string, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.ControlPlaneEndpoint, c.FailoverActive
+}
+
+// SetPluginCacheStats records the plugin cache usage reported by the Agent.
+// SetPluginCacheStats 记录 Agent 上报的插件缓存使用情况。
+func (c *AgentConnection) SetPluginCacheStats(stats *pb.PluginCacheStats) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.PluginCache = stats
+}
+
+// GetPluginCacheStats returns the plugin cache usage reported by the Agent.
+// GetPluginCacheStats 返回 Agent 上报的插件缓存使用情况。
+func (c *AgentConnection) GetPluginCacheStats() *pb.PluginCacheStats {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.PluginCache
+}
+
+// SetSeaTunnelInventory records the SeaTunnel install inventory reported by the Agent.
+// SetSeaTunnelInventory 记录 Agent 上报的 SeaTunnel 安装清单。
+func (c *AgentConnection) SetSeaTunnelInventory(inventory *pb.SeaTunnelInventory) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.Inventory = inventory
+}
+
+// GetSeaTunnelInventory returns the SeaTunnel install inventory reported by the Agent.
+// GetSeaTunnelInventory 返回 Agent 上报的 SeaTunnel 安装清单。
+func (c *AgentConnection) GetSeaTunnelInventory() *pb.SeaTunnelInventory {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.Inventory
+}
+
 // IsOnline checks if the Agent is online based on heartbeat timeout.
 // IsOnline 根据心跳超时检查 Agent 是否在线。
 func (c *AgentConnection) IsOnline(timeout time.Duration) bool {
@@ -140,6 +211,14 @@ func (c *AgentConnection) UpdateHeartbeat() {
 	c.LastHeartbeat = time.Now()
 }
 
+// GetLastHeartbeat returns the last heartbeat timestamp.
+// GetLastHeartbeat 返回最后心跳时间戳。
+func (c *AgentConnection) GetLastHeartbeat() time.Time {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.LastHeartbeat
+}
+
 // SetStatus sets the connection status.
 // SetStatus 设置连接状态。
 func (c *AgentConnection) SetStatus(status AgentStatus) {
@@ -223,6 +302,10 @@ type CommandContext struct {
 	// LastError 是最后已知的错误消息。
 	LastError string
 
+	// LastActivity is when the command was delivered or the Agent last reported on it.
+	// LastActivity 是命令投递或 Agent 最后一次上报该命令的时间。
+	LastActivity time.Time
+
 	// mu protects concurrent access.
 	// mu 保护并发访问。
 	mu sync.RWMutex
@@ -287,6 +370,30 @@ type ManagerConfig struct {
 	// CheckInterval is the interval for checking heartbeat timeouts.
 	// CheckInterval 是检查心跳超时的间隔。
 	CheckInterval time.Duration
+
+	// CommandQueueTTL is how long commands wait for a reconnecting Agent before failing.
+	// CommandQueueTTL 是命令等待 Agent 重连的最长时间，超时后失败。
+	CommandQueueTTL time.Duration
+
+	// CommandProgressTimeout is how long a delivered command may go without progress before it is marked TIMEOUT; negative disables it.
+	// CommandProgressTimeout 是已投递命令无进度多久后被标记为 TIMEOUT；负数表示禁用。
+	CommandProgressTimeout time.Duration
+
+	// FileTransferChunkSize is the chunk size for streaming file transfers (bytes).
+	// FileTransferChunkSize 是流式文件传输的数据块大小（字节）。
+	FileTransferChunkSize int
+
+	// FileTransferCompression is the chunk compression for streaming file transfers.
+	// FileTransferCompression 是流式文件传输的数据块压缩算法。
+	FileTransferCompression string
+
+	// RegistrationRateLimit is the number of registrations allowed per IP per minute; negative disables it.
+	// RegistrationRateLimit 是每个 IP 每分钟允许的注册次数；负数表示不限流。
+	RegistrationRateLimit int
+
+	// RequireApproval keeps newly seen Agents pending until an admin approves them.
+	// RequireApproval 使新出现的 Agent 保持待审批状态，直到管理员审批通过。
+	RequireApproval bool
 }
 
 // Manager manages Agent connections and command dispatching.
@@ -301,14 +408,30 @@ type Manager struct {
 	// commands 按命令 ID 存储待处理的命令。
 	commands sync.Map // map[string]*CommandContext
 
+	// fileTransfers stores files offered to Agents through FetchFile, by transfer ID.
+	// fileTransfers 按传输 ID 存储通过 FetchFile 提供给 Agent 的文件。
+	fileTransfers sync.Map // map[string]*FileTransfer
+
 	// hostUpdater is used to update host status.
 	// hostUpdater 用于更新主机状态。
 	hostUpdater HostStatusUpdater
 
+	// onCommandTimeout is invoked with the synthesized response when the Control Plane times out a command.
+	// onCommandTimeout 在 Control Plane 判定命令超时时以合成的响应调用。
+	onCommandTimeout func(resp *pb.CommandResponse)
+
 	// config holds the manager configuration.
 	// config 保存管理器配置。
 	config *ManagerConfig
 
+	// commandQueues stores commands waiting for an Agent stream, by agent ID.
+	// commandQueues 按 Agent ID 存储等待 Agent 流的命令。
+	commandQueues map[string][]*queuedCommand
+
+	// queueMu protects commandQueues.
+	// queueMu 保护 commandQueues。
+	queueMu sync.Mutex
+
 	// stopChan is used to signal the manager to stop.
 	// stopChan 用于通知管理器停止。
 	stopChan chan struct{}
@@ -320,6 +443,34 @@ type Manager struct {
 	// mu protects the running state.
 	// mu 保护运行状态。
 	mu sync.RWMutex
+
+	// registrationLimiter rate-limits registrations per IP address.
+	// registrationLimiter 按 IP 地址限制注册频率。
+	registrationLimiter *ipRateLimiter
+
+	// admissionStore persists approvals and deny rules; nil disables both.
+	// admissionStore 持久化审批记录与拒绝规则；为 nil 时两者均不生效。
+	admissionStore AdmissionStore
+
+	// pendingAgents stores Agents waiting for approval, by host (see pendingKey).
+	// pendingAgents 按主机存储等待审批的 Agent（见 pendingKey）。
+	pendingAgents map[string]*PendingAgent
+
+	// pendingMu protects pendingAgents.
+	// pendingMu 保护 pendingAgents。
+	pendingMu sync.Mutex
+
+	// draining rejects new commands while the Control Plane shuts down.
+	// draining 在 Control Plane 关闭期间拒绝新命令。
+	draining atomic.Bool
+
+	// ownership shares which replica holds each Agent stream; nil in single-instance mode.
+	// ownership 在副本间共享各 Agent 命令流的持有者；单实例模式下为 nil。
+	ownership OwnershipRegistry
+
+	// forwarder routes commands to Agents connected to other replicas; nil in single-instance mode.
+	// forwarder 将命令路由到连接在其他副本上的 Agent；单实例模式下为 nil。
+	forwarder CommandForwarder
 }
 
 // NewManager creates a new Agent Manager instance.
@@ -338,10 +489,28 @@ func NewManager(config *ManagerConfig) *Manager {
 	if config.CheckInterval <= 0 {
 		config.CheckInterval = DefaultCheckInterval
 	}
+	if config.CommandQueueTTL <= 0 {
+		config.CommandQueueTTL = DefaultCommandQueueTTL
+	}
+	if config.CommandProgressTimeout == 0 {
+		config.CommandProgressTimeout = DefaultCommandProgressTimeout
+	}
+	if config.RegistrationRateLimit == 0 {
+		config.RegistrationRateLimit = DefaultRegistrationRateLimit
+	}
+	config.FileTransferChunkSize = filestream.ClampChunkSize(config.FileTransferChunkSize)
+	if compression, err := filestream.NormalizeCompression(config.FileTransferCompression); err == nil {
+		config.FileTransferCompression = compression
+	} else {
+		config.FileTransferCompression = filestream.CompressionNone
+	}
 
 	return &Manager{
-		config:   config,
-		stopChan: make(chan struct{}),
+		config:              config,
+		commandQueues:       make(map[string][]*queuedCommand),
+		registrationLimiter: newIPRateLimiter(config.RegistrationRateLimit),
+		pendingAgents:       make(map[string]*PendingAgent),
+		stopChan:            make(chan struct{}),
 	}
 }
 
@@ -397,6 +566,12 @@ func (m *Manager) IsRunning() bool {
 // RegisterAgent 注册一个新的 Agent 连接。
 // Requirements: 1.2 - Handles Agent registration and connection management.
 func (m *Manager) RegisterAgent(ctx context.Context, req *pb.RegisterRequest) (*AgentConnection, error) {
+	// Apply admission control: rate limit, deny-list and pending approval
+	// 执行准入控制：限流、拒绝列表与待审批检查
+	if err := m.admitRegistration(ctx, req); err != nil {
+		return nil, err
+	}
+
 	// Create new connection
 	// 创建新连接
 	conn := &AgentConnection{
@@ -435,6 +610,15 @@ func (m *Manager) RegisterAgent(ctx context.Context, req *pb.RegisterRequest) (*
 	// Store connection
 	// 存储连接
 	m.agents.Store(req.AgentId, conn)
+	m.claimOwnership(ctx, req.AgentId)
+	publishAgentStatus(conn, AgentStatusConnected)
+	eventbus.Emit(ctx, eventbus.AgentRegistered{
+		AgentID:   conn.AgentID,
+		HostID:    conn.HostID,
+		IPAddress: conn.IPAddress,
+		Hostname:  conn.Hostname,
+		Version:   conn.Version,
+	})
 
 	return conn, nil
 }
@@ -509,11 +693,17 @@ func (m *Manager) HandleHeartbeat(ctx context.Context, req *pb.HeartbeatRequest)
 
 	// Heartbeat proves the Agent is reachable again; recover status from offline/disconnected.
 	// 心跳表明 Agent 已重新可达；将状态从 offline/disconnected 恢复为 connected。
-	conn.SetStatus(AgentStatusConnected)
+	m.markConnected(conn)
 
 	// Update heartbeat timestamp
 	// 更新心跳时间戳
 	conn.UpdateHeartbeat()
+	if req.PluginCache != nil {
+		conn.SetPluginCacheStats(req.PluginCache)
+	}
+	if req.SeatunnelInventory != nil {
+		conn.SetSeaTunnelInventory(req.SeatunnelInventory)
+	}
 
 	// Update host heartbeat data if updater is available
 	// 如果更新器可用，更新主机心跳数据
@@ -545,27 +735,41 @@ func (m *Manager) SetAgentStream(agentID string, stream grpc.BidiStreamingServer
 
 	// A valid command stream means the Agent is connected and can accept commands again.
 	// 有效的命令流意味着 Agent 已重新连接并可再次接收命令。
-	conn.SetStatus(AgentStatusConnected)
+	m.markConnected(conn)
 	conn.SetStream(stream)
+	m.claimOwnership(context.Background(), agentID)
+
+	// Deliver commands queued while the Agent was reconnecting.
+	// 投递 Agent 重连期间排队的命令。
+	m.flushCommandQueue(agentID, stream)
 	return nil
 }
 
 // SendCommand sends a command to an Agent and waits for the result.
 // SendCommand 向 Agent 发送命令并等待结果。
 // Requirements: 1.5 - Implements command dispatching and result receiving.
+// If the Agent stream is temporarily unavailable (e.g. GOAWAY reconnect), the command is queued
+// and delivered once the stream is re-established, failing after CommandQueueTTL.
+// 若 Agent 流暂时不可用（如 GOAWAY 重连），命令将排队并在流重建后投递，超过 CommandQueueTTL 则失败。
 func (m *Manager) SendCommand(ctx context.Context, agentID string, cmdType pb.CommandType, params map[string]string, timeout time.Duration) (*pb.CommandResponse, error) {
 	conn, ok := m.GetAgent(agentID)
+	if !ok || conn.GetStream() == nil {
+		// The Agent may be connected to another Control Plane replica.
+		// 该 Agent 可能连接在其他 Control Plane 副本上。
+		if resp, forwarded, err := m.forwardCommand(ctx, agentID, cmdType, params, timeout); forwarded {
+			return resp, err
+		}
+	}
 	if !ok {
 		return nil, ErrAgentNotFound
 	}
 
-	if conn.GetStatus() != AgentStatusConnected {
-		return nil, ErrAgentNotConnected
+	if m.IsDraining() {
+		return nil, ErrManagerDraining
 	}
 
-	stream := conn.GetStream()
-	if stream == nil {
-		return nil, ErrStreamNotAvailable
+	if !canQueue(conn) {
+		return nil, ErrAgentNotConnected
 	}
 
 	// Generate command ID
@@ -583,6 +787,7 @@ func (m *Manager) SendCommand(ctx context.Context, agentID string, cmdType pb.Co
 		CreatedAt:  time.Now(),
 		ResultChan: make(chan *pb.CommandResponse, 1),
 	}
+	cmdCtx.LastActivity = cmdCtx.CreatedAt
 
 	// Store command context
 	// 存储命令上下文
@@ -596,43 +801,60 @@ func (m *Manager) SendCommand(ctx context.Context, agentID string, cmdType pb.Co
 		Type:       cmdType,
 		Parameters: params,
 		Timeout:    int32(timeout.Seconds()),
+		Metadata:   tracex.Inject(ctx),
 	}
 
-	// Send command through stream
-	// 通过流发送命令
-	if err := stream.Send(cmdReq); err != nil {
+	// Send command through stream, or queue it while the Agent reconnects
+	// 通过流发送命令，Agent 重连期间则排队
+	queued, err := m.dispatchCommand(conn, cmdCtx, cmdReq)
+	if err != nil {
 		return nil, err
 	}
+	var delivered <-chan error
+	if queued != nil {
+		delivered = queued.delivered
+	}
 
 	// Wait for result with timeout
 	// 带超时等待结果
-	select {
-	case result := <-cmdCtx.ResultChan:
-		return result, nil
-	case <-time.After(timeout):
-		cmdCtx.MarkDone()
-		return nil, ErrCommandTimeout
-	case <-ctx.Done():
-		cmdCtx.MarkDone()
-		return nil, ctx.Err()
+	deadline := time.After(timeout)
+	for {
+		select {
+		case err := <-delivered:
+			if err != nil {
+				return nil, err
+			}
+			delivered = nil
+		case result := <-cmdCtx.ResultChan:
+			return result, nil
+		case <-deadline:
+			cmdCtx.MarkDone()
+			return nil, ErrCommandTimeout
+		case <-ctx.Done():
+			cmdCtx.MarkDone()
+			return nil, ctx.Err()
+		}
 	}
 }
 
 // SendCommandAsync sends a command to an Agent without waiting for the result.
 // SendCommandAsync 向 Agent 发送命令但不等待结果。
-func (m *Manager) SendCommandAsync(agentID string, cmdType pb.CommandType, params map[string]string, timeout time.Duration) (string, error) {
+// Commands issued while the Agent stream is reconnecting are queued (status pending).
+// Agent 流重连期间发出的命令会被排队（状态为 pending）。
+// The trace context of ctx travels with the command so Agent spans join the caller's trace.
+// ctx 中的追踪上下文随命令下发，使 Agent 端 span 加入调用方的追踪链路。
+func (m *Manager) SendCommandAsync(ctx context.Context, agentID string, cmdType pb.CommandType, params map[string]string, timeout time.Duration) (string, error) {
 	conn, ok := m.GetAgent(agentID)
 	if !ok {
 		return "", ErrAgentNotFound
 	}
 
-	if conn.GetStatus() != AgentStatusConnected {
-		return "", ErrAgentNotConnected
+	if m.IsDraining() {
+		return "", ErrManagerDraining
 	}
 
-	stream := conn.GetStream()
-	if stream == nil {
-		return "", ErrStreamNotAvailable
+	if !canQueue(conn) {
+		return "", ErrAgentNotConnected
 	}
 
 	// Generate command ID
@@ -652,6 +874,7 @@ func (m *Manager) SendCommandAsync(agentID string, cmdType pb.CommandType, param
 		LastProgress: 0,
 		LastOutput:   "Command sent, waiting for response / 命令已发送，等待响应",
 	}
+	cmdCtx.LastActivity = cmdCtx.CreatedAt
 
 	// Store command context
 	// 存储命令上下文
@@ -664,11 +887,12 @@ func (m *Manager) SendCommandAsync(agentID string, cmdType pb.CommandType, param
 		Type:       cmdType,
 		Parameters: params,
 		Timeout:    int32(timeout.Seconds()),
+		Metadata:   tracex.Inject(ctx),
 	}
 
-	// Send command through stream
-	// 通过流发送命令
-	if err := stream.Send(cmdReq); err != nil {
+	// Send command through stream, or queue it while the Agent reconnects
+	// 通过流发送命令，Agent 重连期间则排队
+	if _, err := m.dispatchCommand(conn, cmdCtx, cmdReq); err != nil {
 		m.commands.Delete(commandID)
 		return "", err
 	}
@@ -676,6 +900,37 @@ func (m *Manager) SendCommandAsync(agentID string, cmdType pb.CommandType, param
 	return commandID, nil
 }
 
+// PushAgentConfig pushes runtime configuration to a connected Agent for hot-reload.
+// PushAgentConfig 向已连接的 Agent 推送运行期配置以进行热加载。
+// Zero-valued fields are left untouched on the Agent; Extra entries (e.g. metrics_interval) are passed through.
+// 零值字段不会修改 Agent 端配置；Extra 中的条目（如 metrics_interval）原样透传。
+func (m *Manager) PushAgentConfig(ctx context.Context, agentID string, cfg *pb.AgentConfig) error {
+	if cfg == nil {
+		return nil
+	}
+
+	params := make(map[string]string, len(cfg.Extra)+3)
+	for key, value := range cfg.Extra {
+		params[key] = value
+	}
+	params["config_type"] = "agent"
+	if cfg.HeartbeatInterval > 0 {
+		params["heartbeat_interval"] = strconv.Itoa(int(cfg.HeartbeatInterval))
+	}
+	if level, ok := pb.LogLevel_name[cfg.LogLevel]; ok && cfg.LogLevel != int32(pb.LogLevel_LOG_LEVEL_UNSPECIFIED) {
+		params["log_level"] = level
+	}
+
+	resp, err := m.SendCommand(ctx, agentID, pb.CommandType_UPDATE_CONFIG, params, 30*time.Second)
+	if err != nil {
+		return err
+	}
+	if resp.Status == pb.CommandStatus_FAILED {
+		return fmt.Errorf("%w: %s", ErrAgentConfigRejected, resp.Error)
+	}
+	return nil
+}
+
 // HandleCommandResponse processes a command response from an Agent.
 // HandleCommandResponse 处理来自 Agent 的命令响应。
 // Requirements: 1.5 - Receives and processes command execution results.
@@ -695,8 +950,18 @@ func (m *Manager) HandleCommandResponse(resp *pb.CommandResponse) {
 		return
 	}
 
+	// Progress heartbeats only prove the command is alive / 进度心跳仅表明命令仍存活
+	if isProgressHeartbeat(resp) {
+		cmdCtx.mu.Lock()
+		cmdCtx.LastStatus = resp.Status
+		cmdCtx.LastActivity = time.Now()
+		cmdCtx.mu.Unlock()
+		return
+	}
+
 	// Update last known status / 更新最后已知状态
 	cmdCtx.mu.Lock()
+	cmdCtx.LastActivity = time.Now()
 	cmdCtx.LastStatus = resp.Status
 	cmdCtx.LastProgress = resp.Progress
 	cmdCtx.LastOutput = resp.Output
@@ -718,7 +983,8 @@ func (m *Manager) HandleCommandResponse(resp *pb.CommandResponse) {
 	// 如果是终止状态则标记为完成
 	if resp.Status == pb.CommandStatus_SUCCESS ||
 		resp.Status == pb.CommandStatus_FAILED ||
-		resp.Status == pb.CommandStatus_CANCELLED {
+		resp.Status == pb.CommandStatus_CANCELLED ||
+		resp.Status == pb.CommandStatus_TIMEOUT {
 		cmdCtx.MarkDone()
 		// Don't delete immediately, keep for status queries
 		// 不要立即删除，保留用于状态查询
@@ -751,24 +1017,6 @@ func (m *Manager) GetCommandStatus(commandID string) (status string, progress in
 	cmdCtx.mu.RLock()
 	defer cmdCtx.mu.RUnlock()
 
-	// Convert pb.CommandStatus to string
-	// 将 pb.CommandStatus 转换为字符串
-	var statusStr string
-	switch cmdCtx.LastStatus {
-	case pb.CommandStatus_PENDING:
-		statusStr = "pending"
-	case pb.CommandStatus_RUNNING:
-		statusStr = "running"
-	case pb.CommandStatus_SUCCESS:
-		statusStr = "success"
-	case pb.CommandStatus_FAILED:
-		statusStr = "failed"
-	case pb.CommandStatus_CANCELLED:
-		statusStr = "cancelled"
-	default:
-		statusStr = "unknown"
-	}
-
 	// Use error message if available, otherwise use output
 	// 如果有错误消息则使用错误消息，否则使用输出
 	msg := cmdCtx.LastOutput
@@ -776,7 +1024,28 @@ func (m *Manager) GetCommandStatus(commandID string) (status string, progress in
 		msg = cmdCtx.LastError
 	}
 
-	return statusStr, int(cmdCtx.LastProgress), msg, nil
+	return commandStatusString(cmdCtx.LastStatus), int(cmdCtx.LastProgress), msg, nil
+}
+
+// commandStatusString converts a pb.CommandStatus to its API string form.
+// commandStatusString 将 pb.CommandStatus 转换为 API 字符串形式。
+func commandStatusString(status pb.CommandStatus) string {
+	switch status {
+	case pb.CommandStatus_PENDING:
+		return "pending"
+	case pb.CommandStatus_RUNNING:
+		return "running"
+	case pb.CommandStatus_SUCCESS:
+		return "success"
+	case pb.CommandStatus_FAILED:
+		return "failed"
+	case pb.CommandStatus_CANCELLED:
+		return "cancelled"
+	case pb.CommandStatus_TIMEOUT:
+		return "timeout"
+	default:
+		return "unknown"
+	}
 }
 
 // HandleDisconnect handles an Agent disconnection.
@@ -789,13 +1058,80 @@ func (m *Manager) HandleDisconnect(agentID string) {
 
 	conn.SetStatus(AgentStatusDisconnected)
 	conn.SetStream(nil)
+	publishAgentStatus(conn, AgentStatusDisconnected)
+
+	ctx := context.Background()
+	m.releaseOwnership(ctx, agentID)
 
 	// Mark host as offline if updater is available
 	// 如果更新器可用，将主机标记为离线
 	if m.hostUpdater != nil {
-		ctx := context.Background()
 		_ = m.hostUpdater.MarkHostOffline(ctx, agentID)
 	}
+	emitAgentOffline(ctx, conn, AgentStatusDisconnected)
+}
+
+// markConnected sets the Agent status to connected and publishes the change
+// when the Agent was previously offline or disconnected.
+// markConnected 将 Agent 状态置为 connected，若此前为离线或断开状态则发布状态变更。
+func (m *Manager) markConnected(conn *AgentConnection) {
+	conn.mu.Lock()
+	prev := conn.Status
+	conn.Status = AgentStatusConnected
+	conn.mu.Unlock()
+	if prev != AgentStatusConnected {
+		publishAgentStatus(conn, AgentStatusConnected)
+	}
+}
+
+// AgentStatusEvent is the payload published on eventbus.TopicAgentStatus.
+// AgentStatusEvent 是发布到 eventbus.TopicAgentStatus 的事件数据。
+type AgentStatusEvent struct {
+	AgentID   string      `json:"agent_id"`
+	HostID    uint        `json:"host_id"`
+	IPAddress string      `json:"ip_address"`
+	Status    AgentStatus `json:"status"`
+}
+
+// publishAgentStatus publishes an Agent status change on the process event bus.
+// publishAgentStatus 在进程事件总线上发布 Agent 状态变更。
+func publishAgentStatus(conn *AgentConnection, status AgentStatus) {
+	eventbus.Publish(eventbus.TopicAgentStatus, AgentStatusEvent{
+		AgentID:   conn.AgentID,
+		HostID:    conn.HostID,
+		IPAddress: conn.IPAddress,
+		Status:    status,
+	})
+}
+
+// emitAgentOffline emits eventbus.AgentOffline for an Agent that went offline or disconnected.
+// emitAgentOffline 为离线或断开连接的 Agent 发出 eventbus.AgentOffline 事件。
+func emitAgentOffline(ctx context.Context, conn *AgentConnection, status AgentStatus) {
+	eventbus.Emit(ctx, eventbus.AgentOffline{
+		AgentID:   conn.AgentID,
+		HostID:    conn.HostID,
+		IPAddress: conn.IPAddress,
+		Hostname:  conn.Hostname,
+		Status:    string(status),
+	})
+}
+
+// HandleStreamClosed handles the end of one command stream of an Agent.
+// During stream migration (server GOAWAY) the Agent opens a new stream before the old one ends,
+// so the Agent is only treated as disconnected when the closed stream is still the current one.
+// HandleStreamClosed 处理 Agent 某条命令流的结束。
+// 命令流迁移（服务端 GOAWAY）期间 Agent 会在旧流结束前打开新流，
+// 因此仅当关闭的流仍是当前流时才视为 Agent 断开连接。
+func (m *Manager) HandleStreamClosed(agentID string, stream grpc.BidiStreamingServer[pb.CommandResponse, pb.CommandRequest]) bool {
+	conn, ok := m.GetAgent(agentID)
+	if !ok {
+		return false
+	}
+	if current := conn.GetStream(); current != nil && current != stream {
+		return false
+	}
+	m.HandleDisconnect(agentID)
+	return true
 }
 
 // heartbeatChecker runs in the background to check for heartbeat timeouts.
@@ -809,6 +1145,8 @@ func (m *Manager) heartbeatChecker(ctx context.Context) {
 		select {
 		case <-ticker.C:
 			m.checkHeartbeatTimeouts(ctx)
+			m.expireQueuedCommands()
+			m.expireStalledCommands(time.Now())
 		case <-m.stopChan:
 			return
 		case <-ctx.Done():
@@ -833,12 +1171,14 @@ func (m *Manager) checkHeartbeatTimeouts(ctx context.Context) {
 		// 检查心跳是否超时
 		if !conn.IsOnline(m.config.HeartbeatTimeout) {
 			conn.SetStatus(AgentStatusOffline)
+			publishAgentStatus(conn, AgentStatusOffline)
 
 			// Update host status if updater is available
 			// 如果更新器可用，更新主机状态
 			if m.hostUpdater != nil {
 				_ = m.hostUpdater.MarkHostOffline(ctx, conn.AgentID)
 			}
+			emitAgentOffline(ctx, conn, AgentStatusOffline)
 		}
 
 		return true
@@ -270,6 +270,60 @@ func TestHeartbeatHandling(t *testing.T) {
 	}
 }
 
+// TestHeartbeatRecordsPluginCacheStats tests that plugin cache stats from heartbeats are kept on the connection.
+// TestHeartbeatRecordsPluginCacheStats 测试心跳中的插件缓存统计会保存在连接上。
+func TestHeartbeatRecordsPluginCacheStats(t *testing.T) {
+	m := NewManager(nil)
+	ctx := context.Background()
+	if _, err := m.RegisterAgent(ctx, &pb.RegisterRequest{AgentId: "agent-cache", IpAddress: "192.168.1.110"}); err != nil {
+		t.Fatalf("Failed to register agent: %v", err)
+	}
+
+	if err := m.HandleHeartbeat(ctx, &pb.HeartbeatRequest{AgentId: "agent-cache"}); err != nil {
+		t.Fatalf("Failed to handle heartbeat: %v", err)
+	}
+	conn, _ := m.GetAgent("agent-cache")
+	if conn.GetPluginCacheStats() != nil {
+		t.Fatalf("Expected no plugin cache stats from heartbeat without them")
+	}
+
+	stats := &pb.PluginCacheStats{Entries: 3, SizeBytes: 4096, MaxBytes: 8192, Hits: 5, Misses: 2, Evictions: 1}
+	if err := m.HandleHeartbeat(ctx, &pb.HeartbeatRequest{AgentId: "agent-cache", PluginCache: stats}); err != nil {
+		t.Fatalf("Failed to handle heartbeat: %v", err)
+	}
+	if got := conn.GetPluginCacheStats(); got.GetEntries() != 3 || got.GetHits() != 5 || got.GetEvictions() != 1 {
+		t.Fatalf("Expected plugin cache stats to be recorded, got %v", got)
+	}
+}
+
+// TestHeartbeatRecordsSeaTunnelInventory tests that the install inventory from heartbeats is kept on the connection.
+// TestHeartbeatRecordsSeaTunnelInventory 测试心跳中的安装清单会保存在连接上。
+func TestHeartbeatRecordsSeaTunnelInventory(t *testing.T) {
+	m := NewManager(nil)
+	ctx := context.Background()
+	if _, err := m.RegisterAgent(ctx, &pb.RegisterRequest{AgentId: "agent-inventory", IpAddress: "192.168.1.111"}); err != nil {
+		t.Fatalf("Failed to register agent: %v", err)
+	}
+
+	inventory := &pb.SeaTunnelInventory{
+		ScannedAt: 1700000000000,
+		Installs:  []*pb.SeaTunnelInstall{{InstallDir: "/opt/seatunnel", Version: "2.3.12", Role: "master", Pid: 1001, HazelcastPort: 5801, HttpPort: 8080}},
+	}
+	if err := m.HandleHeartbeat(ctx, &pb.HeartbeatRequest{AgentId: "agent-inventory", SeatunnelInventory: inventory}); err != nil {
+		t.Fatalf("Failed to handle heartbeat: %v", err)
+	}
+	conn, _ := m.GetAgent("agent-inventory")
+
+	// A heartbeat without inventory keeps the last one / 不带清单的心跳保留上一次的清单
+	if err := m.HandleHeartbeat(ctx, &pb.HeartbeatRequest{AgentId: "agent-inventory"}); err != nil {
+		t.Fatalf("Failed to handle heartbeat: %v", err)
+	}
+	got := conn.GetSeaTunnelInventory()
+	if len(got.GetInstalls()) != 1 || got.GetInstalls()[0].GetVersion() != "2.3.12" || got.GetInstalls()[0].GetHazelcastPort() != 5801 {
+		t.Fatalf("Expected inventory to be recorded, got %v", got)
+	}
+}
+
 // TestHeartbeatTimeout tests the heartbeat timeout detection.
 // TestHeartbeatTimeout 测试心跳超时检测。
 // Requirements: 3.4 - Marks hosts as offline if no heartbeat received for timeout period.
@@ -542,3 +596,31 @@ func TestHeartbeatRestoresConnectedStatus(t *testing.T) {
 		t.Errorf("Expected status 'connected' after heartbeat, got '%s'", conn.GetStatus())
 	}
 }
+
+// TestHandleStreamClosedAfterMigration tests that a superseded stream does not disconnect the Agent.
+// TestHandleStreamClosedAfterMigration 测试已被替代的命令流关闭时不会使 Agent 断开。
+func TestHandleStreamClosedAfterMigration(t *testing.T) {
+	m := NewManager(nil)
+	ctx := context.Background()
+	_, _ = m.RegisterAgent(ctx, &pb.RegisterRequest{AgentId: "agent-migrate", IpAddress: "192.168.3.2"})
+
+	oldStream := &fakeCommandStream{}
+	newStream := &fakeCommandStream{}
+	_ = m.SetAgentStream("agent-migrate", oldStream)
+	_ = m.SetAgentStream("agent-migrate", newStream)
+
+	if m.HandleStreamClosed("agent-migrate", oldStream) {
+		t.Error("Expected superseded stream close to be ignored")
+	}
+	conn, _ := m.GetAgent("agent-migrate")
+	if conn.GetStatus() != AgentStatusConnected || conn.GetStream() != newStream {
+		t.Fatal("Expected agent to stay connected on the new stream")
+	}
+
+	if !m.HandleStreamClosed("agent-migrate", newStream) {
+		t.Error("Expected current stream close to disconnect the agent")
+	}
+	if conn.GetStatus() != AgentStatusDisconnected {
+		t.Errorf("Expected status 'disconnected', got '%s'", conn.GetStatus())
+	}
+}
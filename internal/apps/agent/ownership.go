@@ -0,0 +1,93 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package agent
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	pb "github.com/seatunnel/seatunnelX/internal/proto/agent"
+)
+
+// ErrAgentNotRemote indicates no other Control Plane replica holds the Agent's stream.
+// ErrAgentNotRemote 表示没有其他 Control Plane 副本持有该 Agent 的命令流。
+var ErrAgentNotRemote = errors.New("agent: agent is not connected to another control plane instance")
+
+// OwnershipRegistry records which Control Plane replica holds the command stream of each Agent.
+// OwnershipRegistry 记录每个 Agent 的命令流由哪个 Control Plane 副本持有。
+type OwnershipRegistry interface {
+	// Claim marks this replica as the owner of the Agent.
+	// Claim 将当前副本标记为该 Agent 的持有者。
+	Claim(ctx context.Context, agentID string) error
+
+	// Release drops the ownership of the Agent when this replica still holds it.
+	// Release 在当前副本仍持有该 Agent 时释放其归属。
+	Release(ctx context.Context, agentID string) error
+}
+
+// CommandForwarder sends a command to an Agent connected to another Control Plane replica.
+// It returns ErrAgentNotRemote when no live peer owns the Agent.
+// CommandForwarder 向连接在其他 Control Plane 副本上的 Agent 发送命令。
+// 若没有存活的副本持有该 Agent，返回 ErrAgentNotRemote。
+type CommandForwarder interface {
+	ForwardCommand(ctx context.Context, agentID string, cmdType pb.CommandType, params map[string]string, timeout time.Duration) (*pb.CommandResponse, error)
+}
+
+// SetOwnershipRegistry sets the registry that shares Agent ownership between replicas.
+// SetOwnershipRegistry 设置在副本间共享 Agent 归属的注册表。
+func (m *Manager) SetOwnershipRegistry(registry OwnershipRegistry) {
+	m.ownership = registry
+}
+
+// SetCommandForwarder sets the forwarder used for Agents connected to other replicas.
+// SetCommandForwarder 设置用于连接在其他副本上的 Agent 的命令转发器。
+func (m *Manager) SetCommandForwarder(forwarder CommandForwarder) {
+	m.forwarder = forwarder
+}
+
+// claimOwnership records this replica as the owner of the Agent; failures only affect routing from peers.
+// claimOwnership 记录当前副本为该 Agent 的持有者；失败仅影响其他副本的命令路由。
+func (m *Manager) claimOwnership(ctx context.Context, agentID string) {
+	if m.ownership != nil {
+		_ = m.ownership.Claim(ctx, agentID)
+	}
+}
+
+// releaseOwnership drops this replica's ownership of the Agent.
+// releaseOwnership 释放当前副本对该 Agent 的归属。
+func (m *Manager) releaseOwnership(ctx context.Context, agentID string) {
+	if m.ownership != nil {
+		_ = m.ownership.Release(ctx, agentID)
+	}
+}
+
+// forwardCommand hands the command to the replica holding the Agent's stream.
+// forwarded is false when there is no such replica and the command should be handled locally.
+// forwardCommand 将命令交给持有该 Agent 命令流的副本。
+// 若不存在这样的副本，forwarded 为 false，命令应在本地处理。
+func (m *Manager) forwardCommand(ctx context.Context, agentID string, cmdType pb.CommandType, params map[string]string, timeout time.Duration) (*pb.CommandResponse, bool, error) {
+	if m.forwarder == nil {
+		return nil, false, nil
+	}
+	resp, err := m.forwarder.ForwardCommand(ctx, agentID, cmdType, params, timeout)
+	if errors.Is(err, ErrAgentNotRemote) {
+		return nil, false, nil
+	}
+	return resp, true, err
+}
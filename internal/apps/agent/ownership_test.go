@@ -0,0 +1,106 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package agent
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	pb "github.com/seatunnel/seatunnelX/internal/proto/agent"
+)
+
+type fakeOwnershipRegistry struct {
+	claimed  []string
+	released []string
+}
+
+func (r *fakeOwnershipRegistry) Claim(_ context.Context, agentID string) error {
+	r.claimed = append(r.claimed, agentID)
+	return nil
+}
+
+func (r *fakeOwnershipRegistry) Release(_ context.Context, agentID string) error {
+	r.released = append(r.released, agentID)
+	return nil
+}
+
+type fakeCommandForwarder struct {
+	remote map[string]bool
+	calls  int
+}
+
+func (f *fakeCommandForwarder) ForwardCommand(_ context.Context, agentID string, _ pb.CommandType, _ map[string]string, _ time.Duration) (*pb.CommandResponse, error) {
+	if !f.remote[agentID] {
+		return nil, ErrAgentNotRemote
+	}
+	f.calls++
+	return &pb.CommandResponse{Status: pb.CommandStatus_SUCCESS, Output: "forwarded"}, nil
+}
+
+// TestOwnershipIsClaimedOnConnectAndReleasedOnDisconnect tests that the registry follows the Agent stream.
+// TestOwnershipIsClaimedOnConnectAndReleasedOnDisconnect 测试归属注册表跟随 Agent 命令流变化。
+func TestOwnershipIsClaimedOnConnectAndReleasedOnDisconnect(t *testing.T) {
+	m := NewManager(nil)
+	registry := &fakeOwnershipRegistry{}
+	m.SetOwnershipRegistry(registry)
+
+	if _, err := m.RegisterAgent(context.Background(), &pb.RegisterRequest{AgentId: "agent-ha", IpAddress: "192.168.9.10"}); err != nil {
+		t.Fatalf("RegisterAgent failed: %v", err)
+	}
+	if err := m.SetAgentStream("agent-ha", &fakeCommandStream{}); err != nil {
+		t.Fatalf("SetAgentStream failed: %v", err)
+	}
+	m.HandleDisconnect("agent-ha")
+
+	if len(registry.claimed) != 2 || registry.claimed[0] != "agent-ha" {
+		t.Fatalf("Expected claims on register and stream, got %v", registry.claimed)
+	}
+	if len(registry.released) != 1 || registry.released[0] != "agent-ha" {
+		t.Fatalf("Expected release on disconnect, got %v", registry.released)
+	}
+}
+
+// TestSendCommandForwardsAgentsConnectedElsewhere tests that commands reach Agents held by other replicas.
+// TestSendCommandForwardsAgentsConnectedElsewhere 测试命令可送达由其他副本持有的 Agent。
+func TestSendCommandForwardsAgentsConnectedElsewhere(t *testing.T) {
+	m := NewManager(nil)
+	forwarder := &fakeCommandForwarder{remote: map[string]bool{"agent-remote": true, "agent-moved": true}}
+	m.SetCommandForwarder(forwarder)
+	ctx := context.Background()
+
+	resp, err := m.SendCommand(ctx, "agent-remote", pb.CommandType_STATUS, nil, time.Second)
+	if err != nil || resp.Output != "forwarded" {
+		t.Fatalf("Expected forwarded response, got %v, %v", resp, err)
+	}
+
+	// A stale local entry without a stream defers to the replica the Agent reconnected to.
+	_, _ = m.RegisterAgent(ctx, &pb.RegisterRequest{AgentId: "agent-moved", IpAddress: "192.168.9.11"})
+	m.HandleDisconnect("agent-moved")
+	if _, err := m.SendCommand(ctx, "agent-moved", pb.CommandType_STATUS, nil, time.Second); err != nil {
+		t.Fatalf("Expected forwarded command for moved agent, got %v", err)
+	}
+
+	if _, err := m.SendCommand(ctx, "agent-unknown", pb.CommandType_STATUS, nil, time.Second); !errors.Is(err, ErrAgentNotFound) {
+		t.Fatalf("Expected ErrAgentNotFound, got %v", err)
+	}
+	if forwarder.calls != 2 {
+		t.Fatalf("Expected 2 forwarded commands, got %d", forwarder.calls)
+	}
+}
@@ -0,0 +1,270 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package agent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/seatunnel/seatunnelX/internal/apps/audit"
+	"github.com/seatunnel/seatunnelX/internal/apps/auth"
+	"github.com/seatunnel/seatunnelX/internal/apps/cluster"
+	"github.com/seatunnel/seatunnelX/internal/apps/host"
+	"github.com/seatunnel/seatunnelX/internal/logger"
+	"github.com/seatunnel/seatunnelX/internal/pkg/errcode"
+	"github.com/seatunnel/seatunnelX/internal/pkg/maintscript"
+	pb "github.com/seatunnel/seatunnelX/internal/proto/agent"
+)
+
+// execScriptCommandType is the command type recorded in command logs for script runs.
+// execScriptCommandType 是脚本执行在命令日志中记录的命令类型。
+const execScriptCommandType = "exec_script"
+
+// ErrScriptNodeNotFound indicates the host runs no node of the cluster a script targets.
+// ErrScriptNodeNotFound 表示主机上没有脚本目标集群的节点。
+var ErrScriptNodeNotFound = errors.New("agent: host runs no node of the target cluster")
+
+// ScriptHandler provides admin HTTP handlers for running allow-listed maintenance scripts on hosts.
+// ScriptHandler 提供在主机上执行白名单运维脚本的管理员 HTTP 处理器。
+type ScriptHandler struct {
+	manager     *Manager
+	hostService *host.Service
+	clusterRepo *cluster.Repository
+	auditRepo   *audit.Repository
+}
+
+// NewScriptHandler creates a new ScriptHandler instance.
+// NewScriptHandler 创建一个新的 ScriptHandler 实例。
+// auditRepo may be nil; command and audit logging are skipped when nil.
+func NewScriptHandler(manager *Manager, hostService *host.Service, clusterRepo *cluster.Repository, auditRepo *audit.Repository) *ScriptHandler {
+	return &ScriptHandler{manager: manager, hostService: hostService, clusterRepo: clusterRepo, auditRepo: auditRepo}
+}
+
+// ExecScriptRequest is the payload for running a maintenance script.
+// Scripts working on a SeaTunnel installation take ClusterID (and Role when the host runs several
+// nodes of the cluster); their install directory is resolved from the registered node.
+// ExecScriptRequest 是执行运维脚本的请求体。
+// 作用于 SeaTunnel 安装的脚本需要 ClusterID（主机上有该集群多个节点时还需 Role），
+// 其安装目录根据已注册的节点解析。
+type ExecScriptRequest struct {
+	Script    string            `json:"script" binding:"required"`
+	Args      map[string]string `json:"args"`
+	ClusterID uint              `json:"cluster_id"`
+	Role      string            `json:"role"`
+}
+
+// ScriptsResponse represents the response for listing maintenance scripts.
+// ScriptsResponse 表示运维脚本列表的响应。
+type ScriptsResponse struct {
+	ErrorMsg string               `json:"error_msg"`
+	Data     []maintscript.Script `json:"data"`
+}
+
+// ExecScriptResponse represents the response carrying the command log of a script run.
+// ExecScriptResponse 表示返回脚本执行命令日志的响应。
+type ExecScriptResponse struct {
+	ErrorMsg string            `json:"error_msg"`
+	Data     *audit.CommandLog `json:"data"`
+}
+
+// ListScripts handles GET /api/v1/admin/scripts - lists the allow-listed maintenance scripts.
+// ListScripts 处理 GET /api/v1/admin/scripts - 列出白名单运维脚本。
+// @Tags admin
+// @Produce json
+// @Success 200 {object} ScriptsResponse
+// @Router /api/v1/admin/scripts [get]
+func (h *ScriptHandler) ListScripts(c *gin.Context) {
+	c.JSON(http.StatusOK, ScriptsResponse{Data: maintscript.List()})
+}
+
+// ExecScript handles POST /api/v1/admin/hosts/:id/exec-script - runs a maintenance script on a host.
+// The run is recorded as a command log with its full output, whether it succeeds or not.
+// ExecScript 处理 POST /api/v1/admin/hosts/:id/exec-script - 在主机上执行运维脚本。
+// 无论成功与否，执行都会连同完整输出记录为命令日志。
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path int true "主机ID"
+// @Param request body ExecScriptRequest true "运维脚本"
+// @Success 200 {object} ExecScriptResponse
+// @Router /api/v1/admin/hosts/{id}/exec-script [post]
+func (h *ScriptHandler) ExecScript(c *gin.Context) {
+	hostID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ExecScriptResponse{ErrorMsg: "无效的主机 ID / Invalid host ID"})
+		return
+	}
+	var req ExecScriptRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ExecScriptResponse{ErrorMsg: err.Error()})
+		return
+	}
+
+	script, err := maintscript.Lookup(req.Script)
+	if err != nil {
+		c.JSON(scriptErrorStatus(c, err), ExecScriptResponse{ErrorMsg: err.Error()})
+		return
+	}
+	installDirArg, needsInstallDir := script.InstallDirArg()
+	if _, ok := req.Args[installDirArg]; needsInstallDir && ok {
+		err := fmt.Errorf("%w: %s is resolved from the cluster node and cannot be set / %s 由集群节点解析，不能手动指定",
+			maintscript.ErrInvalidArgument, installDirArg, installDirArg)
+		c.JSON(scriptErrorStatus(c, err), ExecScriptResponse{ErrorMsg: err.Error()})
+		return
+	}
+
+	target, err := h.hostService.Get(c.Request.Context(), uint(hostID))
+	if err != nil {
+		c.JSON(scriptErrorStatus(c, err), ExecScriptResponse{ErrorMsg: err.Error()})
+		return
+	}
+
+	args := make(map[string]string, len(req.Args)+1)
+	for name, value := range req.Args {
+		args[name] = value
+	}
+	if needsInstallDir {
+		installDir, err := h.resolveInstallDir(c.Request.Context(), req.ClusterID, target.ID, req.Role)
+		if err != nil {
+			c.JSON(scriptErrorStatus(c, err), ExecScriptResponse{ErrorMsg: err.Error()})
+			return
+		}
+		args[installDirArg] = installDir
+	}
+	if _, err := script.Validate(args); err != nil {
+		c.JSON(scriptErrorStatus(c, err), ExecScriptResponse{ErrorMsg: err.Error()})
+		return
+	}
+	if target.AgentID == "" {
+		c.JSON(scriptErrorStatus(c, ErrNoAgentInstalled), ExecScriptResponse{ErrorMsg: ErrNoAgentInstalled.Error()})
+		return
+	}
+
+	startedAt := time.Now()
+	resp, err := h.manager.SendCommand(c.Request.Context(), target.AgentID, pb.CommandType_EXEC_SCRIPT,
+		maintscript.EncodeParams(script.Name, args), script.Timeout)
+	finishedAt := time.Now()
+
+	userID := uint(auth.GetUserIDFromContext(c))
+	cmdLog := &audit.CommandLog{
+		CommandID:   uuid.New().String(),
+		AgentID:     target.AgentID,
+		HostID:      &target.ID,
+		CommandType: execScriptCommandType,
+		Parameters:  audit.CommandParameters{"script": script.Name, "args": args},
+		StartedAt:   &startedAt,
+		FinishedAt:  &finishedAt,
+		CreatedBy:   &userID,
+	}
+	if err != nil {
+		cmdLog.Status = audit.CommandStatusFailed
+		if ErrorCode(err) == errcode.CommandTimeout {
+			cmdLog.Status = audit.CommandStatusTimeout
+		}
+		cmdLog.Error = err.Error()
+	} else {
+		cmdLog.CommandID = resp.CommandId
+		cmdLog.Status = audit.CommandStatus(commandStatusString(resp.Status))
+		cmdLog.Progress = int(resp.Progress)
+		cmdLog.Output = resp.Output
+		cmdLog.Error = resp.Error
+	}
+
+	if h.auditRepo != nil {
+		if err := h.auditRepo.CreateCommandLog(c.Request.Context(), cmdLog); err != nil {
+			logger.ErrorF(c.Request.Context(), "[Agent] 记录脚本命令日志失败 / Failed to record script command log: %s, %v", cmdLog.CommandID, err)
+		}
+	}
+	_ = audit.RecordFromGin(c, h.auditRepo, auth.GetUserIDFromContext(c), auth.GetUsernameFromContext(c),
+		"execute", "script", audit.UintID(target.ID), script.Name, audit.AuditDetails{
+			"command_id": cmdLog.CommandID,
+			"host_name":  target.Name,
+			"args":       args,
+			"status":     string(cmdLog.Status),
+		})
+	c.JSON(http.StatusOK, ExecScriptResponse{Data: cmdLog})
+}
+
+// resolveInstallDir returns the install directory of the node the host runs for the cluster.
+// resolveInstallDir 返回主机上该集群节点的安装目录。
+func (h *ScriptHandler) resolveInstallDir(ctx context.Context, clusterID, hostID uint, role string) (string, error) {
+	if clusterID == 0 {
+		return "", fmt.Errorf("%w: cluster_id is required / 需要指定 cluster_id", maintscript.ErrInvalidArgument)
+	}
+	if h.clusterRepo == nil {
+		return "", fmt.Errorf("%w: cluster %d", ErrScriptNodeNotFound, clusterID)
+	}
+	target, err := h.clusterRepo.GetByID(ctx, clusterID, true)
+	if err != nil {
+		return "", err
+	}
+
+	installDir := ""
+	for _, node := range target.Nodes {
+		if node.HostID != hostID || (role != "" && string(node.Role) != role) {
+			continue
+		}
+		dir := node.InstallDir
+		if dir == "" {
+			dir = target.InstallDir
+		}
+		if installDir != "" && dir != installDir {
+			return "", fmt.Errorf("%w: the host runs several nodes of cluster %d, role is required / 主机上有该集群多个节点，需要指定 role",
+				maintscript.ErrInvalidArgument, clusterID)
+		}
+		installDir = dir
+	}
+	if installDir == "" {
+		return "", fmt.Errorf("%w: cluster %d, host %d", ErrScriptNodeNotFound, clusterID, hostID)
+	}
+	return installDir, nil
+}
+
+// scriptErrorStatus records the catalog code of err on the request and returns its HTTP status.
+// scriptErrorStatus 在请求上记录 err 的目录错误码并返回其 HTTP 状态码。
+func scriptErrorStatus(c *gin.Context, err error) int {
+	switch {
+	case errors.Is(err, maintscript.ErrUnknownScript):
+		errcode.Attach(c, errcode.UnsupportedCommand)
+		return http.StatusBadRequest
+	case errors.Is(err, maintscript.ErrInvalidArgument):
+		errcode.Attach(c, errcode.InvalidParameters)
+		return http.StatusBadRequest
+	case errors.Is(err, host.ErrHostNotFound):
+		errcode.Attach(c, errcode.HostNotFound)
+		return http.StatusNotFound
+	case errors.Is(err, cluster.ErrClusterNotFound):
+		errcode.Attach(c, errcode.ClusterNotFound)
+		return http.StatusNotFound
+	case errors.Is(err, ErrScriptNodeNotFound):
+		errcode.Attach(c, errcode.ClusterNodeNotFound)
+		return http.StatusNotFound
+	case errors.Is(err, ErrNoAgentInstalled):
+		errcode.Attach(c, errcode.NodeAgentNotInstalled)
+		return http.StatusConflict
+	default:
+		errcode.Attach(c, ErrorCode(err))
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,170 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package agent
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-contrib/sessions/cookie"
+	"github.com/gin-gonic/gin"
+	"github.com/glebarez/sqlite"
+	"github.com/seatunnel/seatunnelX/internal/apps/audit"
+	"github.com/seatunnel/seatunnelX/internal/apps/cluster"
+	"github.com/seatunnel/seatunnelX/internal/apps/host"
+	pb "github.com/seatunnel/seatunnelX/internal/proto/agent"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// setupScriptHandler creates a ScriptHandler backed by a SQLite database with one host per Agent ID.
+// An empty Agent ID creates a host without Agent. Cluster 1 runs a master and a worker on host 1,
+// installed in /opt/seatunnel-master and /opt/seatunnel-worker, and a worker on host 2 in /opt/seatunnel.
+// setupScriptHandler 创建基于 SQLite 数据库的 ScriptHandler，每个 Agent ID 对应一台主机。
+// 空 Agent ID 创建未安装 Agent 的主机。集群 1 在主机 1 上运行安装于 /opt/seatunnel-master 与
+// /opt/seatunnel-worker 的 master 和 worker，在主机 2 上运行安装于 /opt/seatunnel 的 worker。
+func setupScriptHandler(t *testing.T, m *Manager, agentIDs ...string) (*gin.Engine, *gorm.DB) {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "test.db")), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	if err := db.AutoMigrate(&host.Host{}, &cluster.Cluster{}, &cluster.ClusterNode{}, &audit.CommandLog{}, &audit.AuditLog{}); err != nil {
+		t.Fatalf("Failed to migrate: %v", err)
+	}
+	for i, agentID := range agentIDs {
+		h := &host.Host{Name: "script-host-" + string(rune('a'+i)), IPAddress: "192.168.20.1", AgentID: agentID}
+		if err := db.Create(h).Error; err != nil {
+			t.Fatalf("Failed to create host: %v", err)
+		}
+	}
+	target := &cluster.Cluster{Name: "script-cluster", DeploymentMode: cluster.DeploymentModeSeparated, InstallDir: "/opt/seatunnel"}
+	if err := db.Create(target).Error; err != nil {
+		t.Fatalf("Failed to create cluster: %v", err)
+	}
+	for _, node := range []*cluster.ClusterNode{
+		{ClusterID: target.ID, HostID: 1, Role: cluster.NodeRoleMaster, InstallDir: "/opt/seatunnel-master"},
+		{ClusterID: target.ID, HostID: 1, Role: cluster.NodeRoleWorker, InstallDir: "/opt/seatunnel-worker"},
+		{ClusterID: target.ID, HostID: 2, Role: cluster.NodeRoleWorker},
+	} {
+		if err := db.Create(node).Error; err != nil {
+			t.Fatalf("Failed to create cluster node: %v", err)
+		}
+	}
+
+	clusterRepo := cluster.NewRepository(db)
+	hostService := host.NewService(host.NewRepository(db), clusterRepo, nil)
+	handler := NewScriptHandler(m, hostService, clusterRepo, audit.NewRepository(db))
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(sessions.Sessions("test-session", cookie.NewStore([]byte("test-secret"))))
+	r.GET("/api/v1/admin/scripts", handler.ListScripts)
+	r.POST("/api/v1/admin/hosts/:id/exec-script", handler.ExecScript)
+	return r, db
+}
+
+func postExecScript(r *gin.Engine, hostID string, body any) *httptest.ResponseRecorder {
+	payload, _ := json.Marshal(body)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/hosts/"+hostID+"/exec-script", bytes.NewReader(payload))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+// TestExecScriptRecordsCommandLog tests that a script run is dispatched and recorded with its output.
+// TestExecScriptRecordsCommandLog 测试脚本执行被下发并连同输出记录到命令日志。
+func TestExecScriptRecordsCommandLog(t *testing.T) {
+	m := NewManager(nil)
+	_, _ = m.RegisterAgent(context.Background(), &pb.RegisterRequest{AgentId: "agent-script", IpAddress: "192.168.20.1"})
+	stream := &respondingStream{m: m, agentID: "agent-script", tracker: &inFlightTracker{}}
+	if err := m.SetAgentStream("agent-script", stream); err != nil {
+		t.Fatalf("SetAgentStream failed: %v", err)
+	}
+	r, db := setupScriptHandler(t, m, "agent-script")
+
+	w := postExecScript(r, "1", ExecScriptRequest{Script: "disk_usage", ClusterID: 1, Role: "worker"})
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp ExecScriptResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if resp.Data == nil || resp.Data.Status != audit.CommandStatusSuccess || resp.Data.Output != "agent-script:" {
+		t.Fatalf("Unexpected command log: %+v", resp.Data)
+	}
+
+	var stored audit.CommandLog
+	if err := db.Where("command_id = ?", resp.Data.CommandID).First(&stored).Error; err != nil {
+		t.Fatalf("Expected command log to be stored: %v", err)
+	}
+	if stored.CommandType != execScriptCommandType || stored.Parameters["script"] != "disk_usage" {
+		t.Errorf("Unexpected stored command log: %+v", stored)
+	}
+	if args, _ := stored.Parameters["args"].(map[string]any); args["install_dir"] != "/opt/seatunnel-worker" {
+		t.Errorf("Expected install_dir to be resolved from the worker node, got %+v", stored.Parameters["args"])
+	}
+	var audits int64
+	db.Model(&audit.AuditLog{}).Where("action = ? AND resource_type = ?", "execute", "script").Count(&audits)
+	if audits != 1 {
+		t.Errorf("Expected 1 audit log, got %d", audits)
+	}
+}
+
+// TestExecScriptRejectsInvalidRequests tests validation before any command is sent.
+// TestExecScriptRejectsInvalidRequests 测试在发送命令前进行的校验。
+func TestExecScriptRejectsInvalidRequests(t *testing.T) {
+	r, db := setupScriptHandler(t, NewManager(nil), "agent-offline", "")
+
+	cases := []struct {
+		name   string
+		hostID string
+		body   ExecScriptRequest
+		want   int
+	}{
+		{"unknown script", "1", ExecScriptRequest{Script: "bash"}, http.StatusBadRequest},
+		{"invalid argument", "1", ExecScriptRequest{Script: "clear_temp_dirs", ClusterID: 1, Role: "master", Args: map[string]string{"older_than_days": "$(id)"}}, http.StatusBadRequest},
+		{"caller install dir", "1", ExecScriptRequest{Script: "clear_temp_dirs", ClusterID: 1, Args: map[string]string{"install_dir": "/"}}, http.StatusBadRequest},
+		{"missing cluster", "1", ExecScriptRequest{Script: "disk_usage"}, http.StatusBadRequest},
+		{"ambiguous node", "1", ExecScriptRequest{Script: "disk_usage", ClusterID: 1}, http.StatusBadRequest},
+		{"unknown cluster", "1", ExecScriptRequest{Script: "disk_usage", ClusterID: 9}, http.StatusNotFound},
+		{"no node on host", "1", ExecScriptRequest{Script: "disk_usage", ClusterID: 1, Role: "client"}, http.StatusNotFound},
+		{"unknown host", "99", ExecScriptRequest{Script: "disk_usage", ClusterID: 1}, http.StatusNotFound},
+		{"host without agent", "2", ExecScriptRequest{Script: "disk_usage", ClusterID: 1}, http.StatusConflict},
+	}
+	for _, tc := range cases {
+		if w := postExecScript(r, tc.hostID, tc.body); w.Code != tc.want {
+			t.Errorf("%s: expected %d, got %d: %s", tc.name, tc.want, w.Code, w.Body.String())
+		}
+	}
+	var logs int64
+	db.Model(&audit.CommandLog{}).Count(&logs)
+	if logs != 0 {
+		t.Errorf("Expected no command logs, got %d", logs)
+	}
+}
@@ -46,6 +46,9 @@ var (
 	// ErrResourceTypeEmpty indicates the resource type is empty.
 	// ErrResourceTypeEmpty 表示资源类型为空。
 	ErrResourceTypeEmpty = errors.New("audit: resource type cannot be empty")
+	// ErrInvalidRedactionPattern indicates a sensitive parameter key pattern is malformed.
+	// ErrInvalidRedactionPattern 表示敏感参数键模式格式错误。
+	ErrInvalidRedactionPattern = errors.New("audit: invalid redaction pattern")
 )
 
 // Error codes for audit and command log operations.
@@ -20,12 +20,16 @@
 package audit
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/seatunnel/seatunnelX/internal/pkg/pagex"
 )
 
 // Handler provides HTTP handlers for audit and command log operations.
@@ -45,8 +49,7 @@ func NewHandler(repo *Repository) *Handler {
 // ListCommandLogsRequest represents the request for listing command logs.
 // ListCommandLogsRequest 表示获取命令日志列表的请求。
 type ListCommandLogsRequest struct {
-	Current     int           `json:"current" form:"current" binding:"min=1"`
-	Size        int           `json:"size" form:"size" binding:"min=1,max=100"`
+	pagex.Query
 	CommandID   string        `json:"command_id" form:"command_id"`
 	AgentID     string        `json:"agent_id" form:"agent_id"`
 	HostID      *uint         `json:"host_id" form:"host_id"`
@@ -73,21 +76,65 @@ type GetCommandLogResponse struct {
 	Data     *CommandLogInfo `json:"data"`
 }
 
+// GetCommandOutputRequest represents the query of a command output request.
+// GetCommandOutputRequest 表示获取命令输出请求的查询参数。
+type GetCommandOutputRequest struct {
+	// Offset is the byte offset to read from; when omitted the last Tail lines are returned.
+	// Offset 是读取起始字节偏移；未指定时返回最后 Tail 行。
+	Offset *int64 `form:"offset" binding:"omitempty,min=0"`
+	Tail   int    `form:"tail" binding:"omitempty,min=0,max=100000"`
+	// Follow keeps the connection open and streams new output as server-sent events.
+	// Follow 保持连接并以 SSE 推送新输出。
+	Follow bool `form:"follow"`
+}
+
+// GetCommandOutputResponse represents the response for getting command output.
+// GetCommandOutputResponse 表示获取命令输出的响应。
+type GetCommandOutputResponse struct {
+	ErrorMsg string         `json:"error_msg"`
+	Data     *CommandOutput `json:"data"`
+}
+
 // ListAuditLogsRequest represents the request for listing audit logs.
 // ListAuditLogsRequest 表示获取审计日志列表的请求。
 type ListAuditLogsRequest struct {
-	Current      int    `json:"current" form:"current" binding:"min=1"`
-	Size         int    `json:"size" form:"size" binding:"min=1,max=100"`
+	pagex.Query
 	UserID       *uint  `json:"user_id" form:"user_id"`
 	Username     string `json:"username" form:"username"`
 	Action       string `json:"action" form:"action"`
 	ResourceType string `json:"resource_type" form:"resource_type"`
 	ResourceID   string `json:"resource_id" form:"resource_id"`
 	Trigger      string `json:"trigger" form:"trigger"` // "auto" | "manual"
+	RequestID    string `json:"request_id" form:"request_id"`
 	StartTime    string `json:"start_time" form:"start_time"`
 	EndTime      string `json:"end_time" form:"end_time"`
 }
 
+// commandLogListSpec defines paging and the sortable fields of the command log list.
+// commandLogListSpec 定义命令日志列表的分页方式和可排序字段。
+var commandLogListSpec = pagex.Spec{
+	Fields: map[string]string{
+		"command_type": "command_type",
+		"status":       "status",
+		"started_at":   "started_at",
+		"finished_at":  "finished_at",
+		"created_at":   "created_at",
+	},
+	DefaultSort: "-created_at",
+}
+
+// auditLogListSpec defines paging and the sortable fields of the audit log list.
+// auditLogListSpec 定义审计日志列表的分页方式和可排序字段。
+var auditLogListSpec = pagex.Spec{
+	Fields: map[string]string{
+		"username":      "username",
+		"action":        "action",
+		"resource_type": "resource_type",
+		"created_at":    "created_at",
+	},
+	DefaultSort: "-created_at",
+}
+
 // ListAuditLogsResponse represents the response for listing audit logs.
 // ListAuditLogsResponse 表示获取审计日志列表的响应。
 type ListAuditLogsResponse struct {
@@ -116,11 +163,16 @@ type GetAuditLogResponse struct {
 // @Router /api/v1/commands [get]
 // Requirements: 10.1, 10.4
 func (h *Handler) ListCommandLogs(c *gin.Context) {
-	req := &ListCommandLogsRequest{Current: 1, Size: 20}
+	req := &ListCommandLogsRequest{}
 	if err := c.ShouldBindQuery(req); err != nil {
 		c.JSON(http.StatusBadRequest, ListCommandLogsResponse{ErrorMsg: err.Error()})
 		return
 	}
+	page, err := req.Resolve(commandLogListSpec)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ListCommandLogsResponse{ErrorMsg: err.Error()})
+		return
+	}
 
 	// Parse time filters - 解析时间过滤条件
 	var startTime, endTime *time.Time
@@ -157,8 +209,9 @@ func (h *Handler) ListCommandLogs(c *gin.Context) {
 		Status:      req.Status,
 		StartTime:   startTime,
 		EndTime:     endTime,
-		Page:        req.Current,
-		PageSize:    req.Size,
+		Page:        page.Page,
+		PageSize:    page.PageSize,
+		Sort:        page.Sort,
 	}
 
 	logs, total, err := h.repo.ListCommandLogs(c.Request.Context(), filter)
@@ -211,6 +264,103 @@ func (h *Handler) GetCommandLog(c *gin.Context) {
 	c.JSON(http.StatusOK, GetCommandLogResponse{Data: log.ToCommandLogInfo()})
 }
 
+// commandOutputPollInterval is how often a followed command log is re-read for new output.
+// commandOutputPollInterval 是 follow 模式下重新读取命令日志新输出的间隔。
+var commandOutputPollInterval = time.Second
+
+// GetCommandOutput handles GET /api/v1/commands/:id/output - reads the console output of a command.
+// The id is either the command log ID or the command ID. With follow=true new output is streamed
+// as "output" server-sent events until the command finishes, followed by a "done" event.
+// GetCommandOutput 处理 GET /api/v1/commands/:id/output - 读取命令的控制台输出。
+// id 可以是命令日志 ID 或命令 ID。follow=true 时以 "output" SSE 事件推送新输出，
+// 命令结束后发送 "done" 事件。
+// @Tags audit
+// @Produce json
+// @Param id path string true "命令日志ID或命令ID"
+// @Param request query GetCommandOutputRequest false "查询参数"
+// @Success 200 {object} GetCommandOutputResponse
+// @Router /api/v1/commands/{id}/output [get]
+func (h *Handler) GetCommandOutput(c *gin.Context) {
+	req := &GetCommandOutputRequest{}
+	if err := c.ShouldBindQuery(req); err != nil {
+		c.JSON(http.StatusBadRequest, GetCommandOutputResponse{ErrorMsg: err.Error()})
+		return
+	}
+
+	log, err := h.loadCommandLog(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(h.getStatusCodeForError(err), GetCommandOutputResponse{ErrorMsg: err.Error()})
+		return
+	}
+
+	offset := int64(-1)
+	if req.Offset != nil {
+		offset = *req.Offset
+	}
+	window := log.OutputWindow(offset, req.Tail)
+	if !req.Follow {
+		c.JSON(http.StatusOK, GetCommandOutputResponse{Data: window})
+		return
+	}
+
+	flusher, ok := c.Writer.(http.Flusher)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, GetCommandOutputResponse{ErrorMsg: "streaming is not supported"})
+		return
+	}
+	c.Writer.Header().Set("Content-Type", "text/event-stream")
+	c.Writer.Header().Set("Cache-Control", "no-cache")
+	c.Writer.Header().Set("Connection", "keep-alive")
+	c.Writer.Header().Set("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	writeEvent := func(event string, data *CommandOutput) {
+		payload, _ := json.Marshal(data)
+		_, _ = fmt.Fprintf(c.Writer, "event: %s\ndata: %s\n\n", event, payload)
+		flusher.Flush()
+	}
+
+	writeEvent("output", window)
+	pollTicker := time.NewTicker(commandOutputPollInterval)
+	defer pollTicker.Stop()
+	keepAliveTicker := time.NewTicker(15 * time.Second)
+	defer keepAliveTicker.Stop()
+
+	for !window.Finished {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case <-keepAliveTicker.C:
+			_, _ = fmt.Fprint(c.Writer, ": keep-alive\n\n")
+			flusher.Flush()
+			continue
+		case <-pollTicker.C:
+		}
+
+		log, err = h.repo.GetCommandLogByID(c.Request.Context(), log.ID)
+		if err != nil {
+			_, _ = fmt.Fprintf(c.Writer, "event: error\ndata: %q\n\n", err.Error())
+			flusher.Flush()
+			return
+		}
+		previous := window
+		window = log.OutputWindow(previous.NextOffset, 0)
+		if window.Output != "" || window.Status != previous.Status || window.Finished {
+			writeEvent("output", window)
+		}
+	}
+	writeEvent("done", window)
+}
+
+// loadCommandLog resolves a command log by its numeric ID or, failing that, by its command ID.
+// loadCommandLog 按数字 ID 解析命令日志，否则按命令 ID 查找。
+func (h *Handler) loadCommandLog(ctx context.Context, id string) (*CommandLog, error) {
+	if logID, err := strconv.ParseUint(id, 10, 64); err == nil {
+		return h.repo.GetCommandLogByID(ctx, uint(logID))
+	}
+	return h.repo.GetCommandLogByCommandID(ctx, id)
+}
+
 // ==================== Audit Log Handlers 审计日志处理器 ====================
 
 // ListAuditLogs handles GET /api/v1/audit-logs - lists audit logs with filtering and pagination.
@@ -222,11 +372,16 @@ func (h *Handler) GetCommandLog(c *gin.Context) {
 // @Router /api/v1/audit-logs [get]
 // Requirements: 10.4
 func (h *Handler) ListAuditLogs(c *gin.Context) {
-	req := &ListAuditLogsRequest{Current: 1, Size: 20}
+	req := &ListAuditLogsRequest{}
 	if err := c.ShouldBindQuery(req); err != nil {
 		c.JSON(http.StatusBadRequest, ListAuditLogsResponse{ErrorMsg: err.Error()})
 		return
 	}
+	page, err := req.Resolve(auditLogListSpec)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ListAuditLogsResponse{ErrorMsg: err.Error()})
+		return
+	}
 
 	// Parse time filters - 解析时间过滤条件
 	var startTime, endTime *time.Time
@@ -263,10 +418,12 @@ func (h *Handler) ListAuditLogs(c *gin.Context) {
 		ResourceType: req.ResourceType,
 		ResourceID:   req.ResourceID,
 		Trigger:      req.Trigger,
+		RequestID:    req.RequestID,
 		StartTime:    startTime,
 		EndTime:      endTime,
-		Page:         req.Current,
-		PageSize:     req.Size,
+		Page:         page.Page,
+		PageSize:     page.PageSize,
+		Sort:         page.Sort,
 	}
 
 	logs, total, err := h.repo.ListAuditLogs(c.Request.Context(), filter)
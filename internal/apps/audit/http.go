@@ -22,6 +22,7 @@ import (
 	"strconv"
 
 	"github.com/gin-gonic/gin"
+	"github.com/seatunnel/seatunnelX/internal/pkg/requestid"
 )
 
 // RecordFromGin writes an audit log entry from an HTTP request (user from session, IP, User-Agent from request).
@@ -55,6 +56,7 @@ func RecordFromGin(c *gin.Context, repo *Repository, userID uint64, username, ac
 		Details:      details,
 		IPAddress:    ip,
 		UserAgent:    ua,
+		RequestID:    requestid.FromContext(c.Request.Context()),
 	}
 	if details != nil {
 		if v, ok := details["trigger"]; ok {
@@ -101,6 +103,7 @@ func RecordFromGinNoUser(repo *Repository, req *http.Request, userID uint64, use
 		Details:      details,
 		IPAddress:    ip,
 		UserAgent:    ua,
+		RequestID:    requestid.FromContext(req.Context()),
 	}
 	if details != nil {
 		if v, ok := details["trigger"]; ok {
@@ -0,0 +1,58 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package audit
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/seatunnel/seatunnelX/internal/pkg/requestid"
+)
+
+// TestRecordFromGin_storesRequestID verifies audit records carry the request ID and can be filtered by it.
+// TestRecordFromGin_storesRequestID 验证审计记录携带请求 ID 并可按其过滤。
+func TestRecordFromGin_storesRequestID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+	repo := NewRepository(db)
+
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	req := httptest.NewRequest("POST", "/api/v1/hosts", nil)
+	c.Request = req.WithContext(requestid.WithContext(req.Context(), "req-abc-123"))
+
+	if err := RecordFromGin(c, repo, 1, "admin", "create", "host", "1", "h1", nil); err != nil {
+		t.Fatalf("RecordFromGin: %v", err)
+	}
+	if err := RecordFromGinNoUser(repo, httptest.NewRequest("POST", "/api/v1/hosts", nil), 1, "admin", "create", "host", "2", "h2", nil); err != nil {
+		t.Fatalf("RecordFromGinNoUser: %v", err)
+	}
+
+	logs, total, err := repo.ListAuditLogs(context.Background(), &AuditLogFilter{RequestID: "req-abc-123"})
+	if err != nil {
+		t.Fatalf("ListAuditLogs: %v", err)
+	}
+	if total != 1 || len(logs) != 1 {
+		t.Fatalf("expected 1 log for request ID, got total=%d len=%d", total, len(logs))
+	}
+	if logs[0].RequestID != "req-abc-123" || logs[0].ResourceID != "1" {
+		t.Fatalf("unexpected log: %+v", logs[0])
+	}
+}
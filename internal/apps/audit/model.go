@@ -24,6 +24,9 @@ import (
 	"encoding/json"
 	"errors"
 	"time"
+
+	"github.com/seatunnel/seatunnelX/internal/pkg/pagex"
+	"github.com/seatunnel/seatunnelX/internal/pkg/secrets"
 )
 
 // CommandStatus represents the execution status of a command.
@@ -46,6 +49,9 @@ const (
 	// CommandStatusCancelled indicates the command was cancelled.
 	// CommandStatusCancelled 表示命令已被取消。
 	CommandStatusCancelled CommandStatus = "cancelled"
+	// CommandStatusTimeout indicates the command ran past its deadline or stopped reporting progress.
+	// CommandStatusTimeout 表示命令超过截止时间或停止上报进度。
+	CommandStatusTimeout CommandStatus = "timeout"
 )
 
 // CommandParameters represents the JSON parameters for a command.
@@ -53,12 +59,13 @@ const (
 type CommandParameters map[string]interface{}
 
 // Value implements the driver.Valuer interface for database storage.
-// Value 实现 driver.Valuer 接口用于数据库存储。
+// Values selected by the redaction policy are masked; command logs never need them after dispatch.
+// Value 实现 driver.Valuer 接口用于数据库存储。脱敏策略选中的值会被屏蔽，命令下发后的日志无需保留。
 func (p CommandParameters) Value() (driver.Value, error) {
 	if p == nil {
 		return nil, nil
 	}
-	return json.Marshal(p)
+	return json.Marshal(CurrentRedactionPolicy().RedactParameters(p))
 }
 
 // Scan implements the sql.Scanner interface for database retrieval.
@@ -79,13 +86,13 @@ func (p *CommandParameters) Scan(value interface{}) error {
 // AuditDetails 表示审计日志条目的 JSON 详情。
 type AuditDetails map[string]interface{}
 
-// Value implements the driver.Valuer interface for database storage.
-// Value 实现 driver.Valuer 接口用于数据库存储。
+// Value implements the driver.Valuer interface for database storage. Credentials are masked.
+// Value 实现 driver.Valuer 接口用于数据库存储。凭证会被屏蔽。
 func (d AuditDetails) Value() (driver.Value, error) {
 	if d == nil {
 		return nil, nil
 	}
-	return json.Marshal(d)
+	return json.Marshal(secrets.RedactMap(d))
 }
 
 // Scan implements the sql.Scanner interface for database retrieval.
@@ -145,6 +152,9 @@ type AuditLog struct {
 	Details  AuditDetails `json:"details" gorm:"type:json"`
 	IPAddress string      `json:"ip_address" gorm:"size:45"`
 	UserAgent string      `json:"user_agent" gorm:"size:500"`
+	// RequestID correlates the record with the HTTP request and its log lines.
+	// RequestID 关联触发本记录的 HTTP 请求及其日志。
+	RequestID string      `json:"request_id" gorm:"size:64;index"`
 	CreatedAt time.Time   `json:"created_at" gorm:"autoCreateTime;index"`
 }
 
@@ -167,6 +177,8 @@ type CommandLogFilter struct {
 	CreatedBy   *uint         `json:"created_by"`
 	Page        int           `json:"page"`
 	PageSize    int           `json:"page_size"`
+	// Sort orders the result, newest first when empty / Sort 指定排序，为空时按创建时间倒序
+	Sort []pagex.SortField `json:"sort,omitempty"`
 }
 
 // AuditLogFilter represents filter criteria for querying audit logs.
@@ -181,10 +193,13 @@ type AuditLogFilter struct {
 	// Trigger filters by trigger column: "auto" (agent) or "manual" (user).
 	// Trigger 按 trigger 字段过滤：auto（Agent 自动）或 manual（手动）。
 	Trigger   string     `json:"trigger"`
+	RequestID string     `json:"request_id"`
 	StartTime *time.Time `json:"start_time"`
 	EndTime   *time.Time `json:"end_time"`
 	Page      int        `json:"page"`
 	PageSize  int        `json:"page_size"`
+	// Sort orders the result, newest first when empty / Sort 指定排序，为空时按创建时间倒序
+	Sort []pagex.SortField `json:"sort,omitempty"`
 }
 
 // CommandLogInfo represents command log information for API responses.
@@ -207,7 +222,9 @@ type CommandLogInfo struct {
 }
 
 // ToCommandLogInfo converts a CommandLog to CommandLogInfo.
+// Parameters are redacted again so rows stored under an older policy do not leak.
 // ToCommandLogInfo 将 CommandLog 转换为 CommandLogInfo。
+// 参数会再次脱敏，避免按旧策略保存的记录泄露敏感值。
 func (c *CommandLog) ToCommandLogInfo() *CommandLogInfo {
 	return &CommandLogInfo{
 		ID:          c.ID,
@@ -215,7 +232,7 @@ func (c *CommandLog) ToCommandLogInfo() *CommandLogInfo {
 		AgentID:     c.AgentID,
 		HostID:      c.HostID,
 		CommandType: c.CommandType,
-		Parameters:  c.Parameters,
+		Parameters:  CurrentRedactionPolicy().RedactParameters(c.Parameters),
 		Status:      c.Status,
 		Progress:    c.Progress,
 		Output:      c.Output,
@@ -241,6 +258,7 @@ type AuditLogInfo struct {
 	Details      AuditDetails `json:"details"`
 	IPAddress    string       `json:"ip_address"`
 	UserAgent    string       `json:"user_agent"`
+	RequestID    string       `json:"request_id"`
 	CreatedAt    time.Time    `json:"created_at"`
 }
 
@@ -259,6 +277,7 @@ func (a *AuditLog) ToAuditLogInfo() *AuditLogInfo {
 		Details:      a.Details,
 		IPAddress:    a.IPAddress,
 		UserAgent:    a.UserAgent,
+		RequestID:    a.RequestID,
 		CreatedAt:    a.CreatedAt,
 	}
 }
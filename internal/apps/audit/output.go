@@ -0,0 +1,107 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package audit
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// maxOutputWindowBytes caps the console output returned by one output request.
+// maxOutputWindowBytes 限制单次输出请求返回的控制台内容大小。
+const maxOutputWindowBytes = 1024 * 1024
+
+// CommandOutput is a window of a command's console output used by tail/follow views.
+// CommandOutput 是命令控制台输出的一个窗口，用于 tail/follow 视图。
+type CommandOutput struct {
+	CommandID string        `json:"command_id"`
+	Status    CommandStatus `json:"status"`
+	Progress  int           `json:"progress"`
+	// Offset is the byte offset of Output within the full console output.
+	// Offset 是 Output 在完整控制台输出中的字节偏移。
+	Offset int64 `json:"offset"`
+	// NextOffset is passed back as offset to read what follows this window.
+	// NextOffset 作为 offset 回传以读取该窗口之后的内容。
+	NextOffset int64  `json:"next_offset"`
+	Size       int64  `json:"size"`
+	Output     string `json:"output"`
+	Finished   bool   `json:"finished"`
+}
+
+// IsFinished reports whether the command reached a terminal status.
+// IsFinished 判断命令是否已进入终止状态。
+func (c *CommandLog) IsFinished() bool {
+	switch c.Status {
+	case CommandStatusSuccess, CommandStatusFailed, CommandStatusCancelled, CommandStatusTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// OutputWindow returns the console output starting at offset. A negative offset selects the
+// last tail lines instead (the whole output when tail is not positive).
+// OutputWindow 返回从 offset 开始的控制台输出。offset 为负数时改为选取最后 tail 行
+// （tail 不为正数时返回全部输出）。
+func (c *CommandLog) OutputWindow(offset int64, tail int) *CommandOutput {
+	size := int64(len(c.Output))
+	start := offset
+	if start < 0 {
+		start = tailOffset(c.Output, tail)
+	}
+	start = min(start, size)
+	for start > 0 && start < size && !utf8.RuneStart(c.Output[start]) {
+		start--
+	}
+
+	end := min(start+maxOutputWindowBytes, size)
+	for end > start && end < size && !utf8.RuneStart(c.Output[end]) {
+		end--
+	}
+
+	return &CommandOutput{
+		CommandID:  c.CommandID,
+		Status:     c.Status,
+		Progress:   c.Progress,
+		Offset:     start,
+		NextOffset: end,
+		Size:       size,
+		Output:     c.Output[start:end],
+		Finished:   c.IsFinished() && end == size,
+	}
+}
+
+// tailOffset returns the byte offset where the last n lines of output begin.
+// tailOffset 返回输出最后 n 行的起始字节偏移。
+func tailOffset(output string, n int) int64 {
+	if n <= 0 {
+		return 0
+	}
+	end := strings.TrimSuffix(output, "\n")
+	for i := 0; i < n; i++ {
+		idx := strings.LastIndexByte(end, '\n')
+		if idx < 0 {
+			return 0
+		}
+		if i == n-1 {
+			return int64(idx + 1)
+		}
+		end = end[:idx]
+	}
+	return 0
+}
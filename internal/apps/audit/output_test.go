@@ -0,0 +1,98 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestCommandLogOutputWindow(t *testing.T) {
+	log := &CommandLog{CommandID: "cmd-1", Status: CommandStatusRunning, Output: "one\ntwo\nthree\n"}
+
+	if got := log.OutputWindow(-1, 2); got.Output != "two\nthree\n" || got.Offset != 4 || got.NextOffset != 14 {
+		t.Fatalf("unexpected tail window: %+v", got)
+	}
+	if got := log.OutputWindow(-1, 0); got.Output != log.Output || got.Offset != 0 {
+		t.Fatalf("expected whole output without tail, got %+v", got)
+	}
+	if got := log.OutputWindow(8, 0); got.Output != "three\n" || got.Size != 14 || got.Finished {
+		t.Fatalf("unexpected offset window: %+v", got)
+	}
+	if got := log.OutputWindow(100, 0); got.Output != "" || got.Offset != 14 {
+		t.Fatalf("expected offset past the end to be clamped, got %+v", got)
+	}
+
+	log.Status = CommandStatusSuccess
+	if got := log.OutputWindow(14, 0); !got.Finished {
+		t.Fatalf("expected finished window at the end of a completed command, got %+v", got)
+	}
+}
+
+func TestGetCommandOutput(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+	repo := NewRepository(db)
+	ctx := context.Background()
+	log := &CommandLog{CommandID: "cmd-output", AgentID: "agent-1", CommandType: "exec_script", Status: CommandStatusRunning, Output: "a\nb\nc\n"}
+	if err := repo.CreateCommandLog(ctx, log); err != nil {
+		t.Fatalf("CreateCommandLog: %v", err)
+	}
+
+	router := gin.New()
+	router.GET("/commands/:id/output", NewHandler(repo).GetCommandOutput)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/commands/cmd-output/output?tail=1", nil))
+	var resp GetCommandOutputResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil || rec.Code != http.StatusOK {
+		t.Fatalf("unexpected response %d: %s", rec.Code, rec.Body.String())
+	}
+	if resp.Data.Output != "c\n" || resp.Data.NextOffset != 6 {
+		t.Fatalf("unexpected tail output: %+v", resp.Data)
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/commands/999/output", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 for unknown log, got %d", rec.Code)
+	}
+
+	previousInterval := commandOutputPollInterval
+	commandOutputPollInterval = 10 * time.Millisecond
+	defer func() { commandOutputPollInterval = previousInterval }()
+	go func() {
+		time.Sleep(30 * time.Millisecond)
+		_ = repo.UpdateCommandLogStatus(ctx, log.ID, map[string]interface{}{"output": log.Output + "d\n", "status": CommandStatusSuccess})
+	}()
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/commands/cmd-output/output?offset=6&follow=true", nil))
+	body := rec.Body.String()
+	if !strings.Contains(body, `"output":"d\n"`) || !strings.HasSuffix(strings.TrimSpace(body), `"finished":true}`) || !strings.Contains(body, "event: done") {
+		t.Fatalf("expected followed output and a done event, got %s", body)
+	}
+}
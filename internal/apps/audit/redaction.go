@@ -0,0 +1,160 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package audit
+
+import (
+	"fmt"
+	"path"
+	"strings"
+	"sync/atomic"
+
+	"github.com/seatunnel/seatunnelX/internal/pkg/secrets"
+)
+
+// RedactionPolicy decides which command parameters are masked before command logs are
+// persisted or returned by the API. Keys recognized by secrets.IsSensitiveKey or the
+// built-in patterns are always masked; configured patterns add to them.
+// RedactionPolicy 决定命令日志在持久化或由 API 返回前需要屏蔽的命令参数。
+// secrets.IsSensitiveKey 或内置模式识别的键始终被屏蔽，配置的模式在此基础上追加。
+type RedactionPolicy struct {
+	patterns []string
+}
+
+// builtinPatterns cover command parameters that secrets.IsSensitiveKey does not, such as
+// Kerberos principals and the keytab paths of checkpoint and IMAP storage.
+// builtinPatterns 覆盖 secrets.IsSensitiveKey 未识别的命令参数，例如 Kerberos principal
+// 以及 checkpoint 与 IMAP 存储的 keytab 路径。
+var builtinPatterns = []string{"*kerberos*", "*keytab*"}
+
+// activePolicy is the policy applied to command parameters; nil means built-in keys only.
+// activePolicy 是应用于命令参数的策略；为 nil 时仅使用内置键。
+var activePolicy atomic.Pointer[RedactionPolicy]
+
+// NewRedactionPolicy creates a policy from case-insensitive key patterns. Patterns use
+// path.Match wildcards, e.g. "kerberos_*" or "*_token".
+// NewRedactionPolicy 根据不区分大小写的键模式创建策略。模式使用 path.Match 通配符，
+// 例如 "kerberos_*" 或 "*_token"。
+func NewRedactionPolicy(patterns []string) (*RedactionPolicy, error) {
+	policy := &RedactionPolicy{}
+	for _, pattern := range patterns {
+		pattern = strings.ToLower(strings.TrimSpace(pattern))
+		if pattern == "" {
+			continue
+		}
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidRedactionPattern, pattern)
+		}
+		policy.patterns = append(policy.patterns, pattern)
+	}
+	return policy, nil
+}
+
+// SetRedactionPolicy installs the policy applied to all command logs; nil restores the built-in keys.
+// SetRedactionPolicy 设置应用于所有命令日志的策略；传入 nil 恢复为内置键。
+func SetRedactionPolicy(policy *RedactionPolicy) {
+	activePolicy.Store(policy)
+}
+
+// CurrentRedactionPolicy returns the installed policy, or nil when only built-in keys apply.
+// CurrentRedactionPolicy 返回当前策略；仅使用内置键时返回 nil。
+func CurrentRedactionPolicy() *RedactionPolicy {
+	return activePolicy.Load()
+}
+
+// IsSensitive reports whether the value of a parameter key must be masked.
+// IsSensitive 判断参数键的值是否需要屏蔽。
+func (p *RedactionPolicy) IsSensitive(key string) bool {
+	if secrets.IsSensitiveKey(key) {
+		return true
+	}
+	key = strings.ToLower(key)
+	if matchesAny(builtinPatterns, key) {
+		return true
+	}
+	return p != nil && matchesAny(p.patterns, key)
+}
+
+func matchesAny(patterns []string, key string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, key); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// RedactParameters returns a deep copy of params with sensitive values masked. Everything
+// nested under a sensitive key is masked as well.
+// RedactParameters 返回屏蔽了敏感值的 params 深拷贝，敏感键下嵌套的所有内容同样被屏蔽。
+func (p *RedactionPolicy) RedactParameters(params CommandParameters) CommandParameters {
+	if params == nil {
+		return nil
+	}
+	return p.redactMap(params, false)
+}
+
+func (p *RedactionPolicy) redactMap(m map[string]interface{}, sensitive bool) map[string]interface{} {
+	out := make(map[string]interface{}, len(m))
+	for key, value := range m {
+		out[key] = p.redactValue(sensitive || p.IsSensitive(key), value)
+	}
+	return out
+}
+
+func (p *RedactionPolicy) redactValue(sensitive bool, value interface{}) interface{} {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case string:
+		if sensitive {
+			return secrets.Redact(v)
+		}
+		return v
+	case map[string]interface{}:
+		return p.redactMap(v, sensitive)
+	case map[string]string:
+		out := make(map[string]string, len(v))
+		for key, item := range v {
+			if sensitive || p.IsSensitive(key) {
+				item = secrets.Redact(item)
+			}
+			out[key] = item
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, item := range v {
+			out[i] = p.redactValue(sensitive, item)
+		}
+		return out
+	case []string:
+		out := make([]string, len(v))
+		for i, item := range v {
+			if sensitive {
+				item = secrets.Redact(item)
+			}
+			out[i] = item
+		}
+		return out
+	default:
+		if sensitive {
+			return secrets.Mask
+		}
+		return v
+	}
+}
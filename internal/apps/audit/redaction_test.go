@@ -0,0 +1,109 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package audit
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/seatunnel/seatunnelX/internal/pkg/secrets"
+)
+
+// TestRedactionPolicyMasksSensitiveParameters tests built-in, configured and nested keys.
+// TestRedactionPolicyMasksSensitiveParameters 测试内置键、配置键与嵌套键的屏蔽。
+func TestRedactionPolicyMasksSensitiveParameters(t *testing.T) {
+	policy, err := NewRedactionPolicy([]string{"*_Token", "jdbc_url"})
+	if err != nil {
+		t.Fatalf("NewRedactionPolicy: %v", err)
+	}
+
+	params := CommandParameters{
+		"storage_secret_key":              "s3-secret",
+		"checkpoint_kerberos_principal":   "seatunnel@EXAMPLE.COM",
+		"checkpoint_kerberos_keytab_path": "/etc/security/seatunnel.keytab",
+		"registry_token":                  "abc",
+		"JDBC_URL":                        "jdbc:mysql://root:pw@db/seatunnel",
+		"install_dir":                     "/opt/seatunnel",
+		"plugins":                         []interface{}{"jdbc", "kafka"},
+		"storage": map[string]interface{}{
+			"bucket":     "s3a://checkpoints",
+			"access_key": "AKIA",
+		},
+	}
+	redacted := policy.RedactParameters(params)
+
+	for _, key := range []string{"storage_secret_key", "checkpoint_kerberos_principal", "checkpoint_kerberos_keytab_path", "registry_token", "JDBC_URL"} {
+		if redacted[key] != secrets.Mask {
+			t.Errorf("%s = %v, want mask", key, redacted[key])
+		}
+	}
+	if redacted["install_dir"] != "/opt/seatunnel" {
+		t.Errorf("install_dir = %v, want unchanged", redacted["install_dir"])
+	}
+	storage := redacted["storage"].(map[string]interface{})
+	if storage["access_key"] != secrets.Mask || storage["bucket"] != "s3a://checkpoints" {
+		t.Errorf("storage = %v, want only access_key masked", storage)
+	}
+	if params["registry_token"] != "abc" {
+		t.Errorf("RedactParameters modified its input")
+	}
+
+	if _, err := NewRedactionPolicy([]string{"[token"}); !errors.Is(err, ErrInvalidRedactionPattern) {
+		t.Errorf("NewRedactionPolicy malformed pattern error = %v, want ErrInvalidRedactionPattern", err)
+	}
+}
+
+// TestCommandLogParametersRedactedOnPersistAndOutput tests that the installed policy applies to storage and API output.
+// TestCommandLogParametersRedactedOnPersistAndOutput 测试已设置的策略作用于存储与 API 输出。
+func TestCommandLogParametersRedactedOnPersistAndOutput(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+	repo := NewRepository(db)
+	ctx := context.Background()
+
+	policy, err := NewRedactionPolicy([]string{"*_token"})
+	if err != nil {
+		t.Fatalf("NewRedactionPolicy: %v", err)
+	}
+	SetRedactionPolicy(policy)
+	defer SetRedactionPolicy(nil)
+
+	log := &CommandLog{
+		CommandID:   "cmd-redact",
+		AgentID:     "agent-1",
+		CommandType: "install",
+		Parameters:  CommandParameters{"registry_token": "abc", "version": "2.3.12"},
+		Status:      CommandStatusPending,
+	}
+	if err := repo.CreateCommandLog(ctx, log); err != nil {
+		t.Fatalf("CreateCommandLog: %v", err)
+	}
+	stored, err := repo.GetCommandLogByCommandID(ctx, "cmd-redact")
+	if err != nil {
+		t.Fatalf("GetCommandLogByCommandID: %v", err)
+	}
+	if stored.Parameters["registry_token"] != secrets.Mask || stored.Parameters["version"] != "2.3.12" {
+		t.Errorf("stored parameters = %v, want registry_token masked", stored.Parameters)
+	}
+
+	legacy := &CommandLog{Parameters: CommandParameters{"kerberos_principal": "seatunnel@EXAMPLE.COM"}}
+	if got := legacy.ToCommandLogInfo().Parameters["kerberos_principal"]; got != secrets.Mask {
+		t.Errorf("API parameters kerberos_principal = %v, want mask", got)
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"context"
 	"errors"
 
+	"github.com/seatunnel/seatunnelX/internal/pkg/pagex"
 	"gorm.io/gorm"
 )
 
@@ -143,18 +144,17 @@ func (r *Repository) ListCommandLogs(ctx context.Context, filter *CommandLogFilt
 		return nil, 0, err
 	}
 
-	// Apply pagination - 应用分页
-	if filter != nil && filter.PageSize > 0 {
-		offset := 0
-		if filter.Page > 0 {
-			offset = (filter.Page - 1) * filter.PageSize
-		}
-		query = query.Offset(offset).Limit(filter.PageSize)
+	// Apply sorting and pagination - 应用排序和分页
+	var sort []pagex.SortField
+	if filter != nil {
+		sort = filter.Sort
+		query = pagex.Paginate(query, filter.Page, filter.PageSize)
 	}
+	query = pagex.Order(query, sort, "created_at DESC")
 
 	// Execute query - 执行查询
 	var logs []*CommandLog
-	if err := query.Order("created_at DESC").Find(&logs).Error; err != nil {
+	if err := query.Find(&logs).Error; err != nil {
 		return nil, 0, err
 	}
 
@@ -279,6 +279,10 @@ func (r *Repository) ListAuditLogs(ctx context.Context, filter *AuditLogFilter)
 		if filter.Trigger == "auto" || filter.Trigger == "manual" {
 			query = query.Where("trigger = ?", filter.Trigger)
 		}
+		// Filter by request ID - 按请求 ID 过滤
+		if filter.RequestID != "" {
+			query = query.Where("request_id = ?", filter.RequestID)
+		}
 		// Filter by time range - 按时间范围过滤
 		if filter.StartTime != nil {
 			query = query.Where("created_at >= ?", *filter.StartTime)
@@ -294,18 +298,17 @@ func (r *Repository) ListAuditLogs(ctx context.Context, filter *AuditLogFilter)
 		return nil, 0, err
 	}
 
-	// Apply pagination - 应用分页
-	if filter != nil && filter.PageSize > 0 {
-		offset := 0
-		if filter.Page > 0 {
-			offset = (filter.Page - 1) * filter.PageSize
-		}
-		query = query.Offset(offset).Limit(filter.PageSize)
+	// Apply sorting and pagination - 应用排序和分页
+	var sort []pagex.SortField
+	if filter != nil {
+		sort = filter.Sort
+		query = pagex.Paginate(query, filter.Page, filter.PageSize)
 	}
+	query = pagex.Order(query, sort, "created_at DESC")
 
 	// Execute query - 执行查询
 	var logs []*AuditLog
-	if err := query.Order("created_at DESC").Find(&logs).Error; err != nil {
+	if err := query.Find(&logs).Error; err != nil {
 		return nil, 0, err
 	}
 
@@ -0,0 +1,136 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/seatunnel/seatunnelX/internal/config"
+	"gorm.io/gorm"
+)
+
+// ErrExternalUserConflict 外部账号对应的用户名已被其他认证来源占用
+var ErrExternalUserConflict = errors.New("auth: 外部账号与已有用户冲突")
+
+// ExternalIdentity 外部认证源（LDAP、OIDC）认证成功后的统一用户信息
+type ExternalIdentity struct {
+	// ExternalID 外部账号唯一标识，格式: source:id，写入 User.OAuthID
+	ExternalID string
+	Username   string
+	Email      string
+	Nickname   string
+	AvatarURL  string
+	// Role 由组映射得到的全局角色，为空时不修改已有用户的角色
+	Role Role
+}
+
+// ExternalDefaultRole 外部账号未匹配任何角色映射且未配置 default_role 时，新建用户使用的最小权限角色
+const ExternalDefaultRole = RoleViewer
+
+// roleRank 角色权限高低，用于多个映射同时匹配时取最高权限
+var roleRank = map[Role]int{RoleViewer: 1, RoleOperator: 2, RoleAdmin: 3}
+
+// MapExternalRole 按 role_mappings 将外部组（LDAP 组 DN 或 OIDC 声明值）映射为全局角色
+// 多个映射匹配时取权限最高者；均未匹配时使用 defaultRole，两者皆无时返回空角色
+func MapExternalRole(groups []string, mappings []config.RoleMappingConfig, defaultRole string) Role {
+	var best Role
+	for _, mapping := range mappings {
+		role, err := ParseRole(mapping.Role)
+		if err != nil {
+			continue
+		}
+		for _, group := range groups {
+			if strings.EqualFold(strings.TrimSpace(group), strings.TrimSpace(mapping.Group)) && roleRank[role] > roleRank[best] {
+				best = role
+			}
+		}
+	}
+	if best != "" {
+		return best
+	}
+	if role, err := ParseRole(defaultRole); err == nil && defaultRole != "" {
+		return role
+	}
+	return ""
+}
+
+// ProvisionExternalUser 按外部账号标识查找用户，不存在时创建；每次登录同步邮箱、昵称与映射角色
+// 未映射到角色的新用户以 ExternalDefaultRole 创建，而不是落入未设置角色时的默认运维角色
+func ProvisionExternalUser(db *gorm.DB, identity ExternalIdentity) (*User, error) {
+	var user User
+	err := db.Where(&User{OAuthID: identity.ExternalID}).First(&user).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	if err == nil {
+		updates := map[string]interface{}{"last_login_at": time.Now()}
+		if identity.Email != "" {
+			updates["email"] = identity.Email
+		}
+		if identity.Nickname != "" {
+			updates["nickname"] = identity.Nickname
+		}
+		if identity.AvatarURL != "" {
+			updates["avatar_url"] = identity.AvatarURL
+		}
+		if identity.Role != "" {
+			updates["role"] = string(identity.Role)
+			updates["is_admin"] = identity.Role == RoleAdmin
+		}
+		if err := db.Model(&user).Updates(updates).Error; err != nil {
+			return nil, err
+		}
+		return FindByID(db, user.ID)
+	}
+
+	username := strings.TrimSpace(identity.Username)
+	if username == "" {
+		return nil, fmt.Errorf("auth: 外部账号缺少用户名: %s", identity.ExternalID)
+	}
+	var count int64
+	if err := db.Model(&User{}).Where("username = ?", username).Count(&count).Error; err != nil {
+		return nil, err
+	}
+	if count > 0 {
+		return nil, ErrExternalUserConflict
+	}
+
+	role := identity.Role
+	if role == "" {
+		role = ExternalDefaultRole
+	}
+	user = User{
+		Username:    username,
+		Nickname:    identity.Nickname,
+		Email:       identity.Email,
+		AvatarURL:   identity.AvatarURL,
+		OAuthID:     identity.ExternalID,
+		IsActive:    true,
+		Role:        string(role),
+		IsAdmin:     role == RoleAdmin,
+		LastLoginAt: time.Now(),
+	}
+	if err := db.Create(&user).Error; err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
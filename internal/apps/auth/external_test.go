@@ -0,0 +1,102 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package auth
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/seatunnel/seatunnelX/internal/config"
+)
+
+func TestMapExternalRole(t *testing.T) {
+	mappings := []config.RoleMappingConfig{
+		{Group: "cn=dev,dc=example,dc=com", Role: "operator"},
+		{Group: "CN=Admins,DC=example,DC=com", Role: "admin"},
+		{Group: "auditors", Role: "viewer"},
+	}
+
+	tests := []struct {
+		name        string
+		groups      []string
+		defaultRole string
+		want        Role
+	}{
+		{"highest privilege wins", []string{"cn=dev,dc=example,dc=com", "cn=admins,dc=example,dc=com"}, "", RoleAdmin},
+		{"case insensitive", []string{"AUDITORS"}, "", RoleViewer},
+		{"default role", []string{"other"}, "viewer", RoleViewer},
+		{"no match keeps role", []string{"other"}, "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MapExternalRole(tt.groups, mappings, tt.defaultRole); got != tt.want {
+				t.Fatalf("MapExternalRole = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProvisionExternalUser(t *testing.T) {
+	db := setupSessionTestDB(t)
+
+	user, err := ProvisionExternalUser(db, ExternalIdentity{
+		ExternalID: "ldap:alice",
+		Username:   "alice",
+		Email:      "alice@example.com",
+		Role:       RoleViewer,
+	})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if user.EffectiveRole() != RoleViewer || !user.IsActive || user.PasswordHash != "" {
+		t.Fatalf("unexpected new user: %+v", user)
+	}
+
+	// 再次登录时同步角色；角色为空时保留已有角色
+	updated, err := ProvisionExternalUser(db, ExternalIdentity{ExternalID: "ldap:alice", Username: "alice", Role: RoleAdmin})
+	if err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if updated.ID != user.ID || updated.EffectiveRole() != RoleAdmin || !updated.IsAdmin {
+		t.Fatalf("role should be synced: %+v", updated)
+	}
+	kept, err := ProvisionExternalUser(db, ExternalIdentity{ExternalID: "ldap:alice", Username: "alice"})
+	if err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if kept.EffectiveRole() != RoleAdmin || kept.Email != "alice@example.com" {
+		t.Fatalf("role and email should be kept: %+v", kept)
+	}
+
+	// 未映射到角色的新用户以最小权限创建
+	unmapped, err := ProvisionExternalUser(db, ExternalIdentity{ExternalID: "ldap:carol", Username: "carol"})
+	if err != nil {
+		t.Fatalf("create unmapped: %v", err)
+	}
+	if unmapped.EffectiveRole() != ExternalDefaultRole || unmapped.IsAdmin {
+		t.Fatalf("unmapped user should be a viewer: %+v", unmapped)
+	}
+
+	// 不允许外部账号接管同名的本地用户
+	if _, err := createTestUser(db, "bob", "password123", true); err != nil {
+		t.Fatalf("create local user: %v", err)
+	}
+	if _, err := ProvisionExternalUser(db, ExternalIdentity{ExternalID: "ldap:bob", Username: "bob"}); !errors.Is(err, ErrExternalUserConflict) {
+		t.Fatalf("expected ErrExternalUserConflict, got %v", err)
+	}
+}
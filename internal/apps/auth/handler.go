@@ -19,6 +19,7 @@
 package auth
 
 import (
+	"errors"
 	"net/http"
 	"net/mail"
 	"strings"
@@ -41,7 +42,9 @@ const (
 	ErrMsgEmptyCredentials   = "用户名和密码不能为空"
 	ErrMsgUserInactive       = "用户账户已禁用"
 	ErrMsgSessionError       = "会话错误"
+	ErrMsgSessionExpired     = "会话已失效，请重新登录"
 	ErrMsgInternalError      = "内部服务器错误"
+	ErrMsgLDAPUnavailable    = "LDAP 认证服务不可用"
 )
 
 // LoginRequest 登录请求
@@ -105,20 +108,34 @@ func Login(c *gin.Context) {
 		return
 	}
 
-	// 查找用户
+	// 查找用户：设置了本地密码的用户走本地认证，其余用户在启用 LDAP 时走 LDAP 认证
 	user, err := FindByUsername(db.GetDB(c.Request.Context()), username)
-	if err != nil {
-		// 不暴露用户是否存在，统一返回凭证错误
-		logger.InfoF(c.Request.Context(), "[Auth] 登录失败 - 用户不存在: %s", username)
-		c.JSON(http.StatusUnauthorized, LoginResponse{ErrorMsg: ErrMsgInvalidCredentials})
-		return
-	}
+	if (err != nil || user.PasswordHash == "") && LDAPEnabled() {
+		user, err = loginWithLDAP(c.Request.Context(), db.GetDB(c.Request.Context()), username, password)
+		if err != nil {
+			if errors.Is(err, ErrLDAPInvalidCredentials) || errors.Is(err, ErrExternalUserConflict) {
+				logger.InfoF(c.Request.Context(), "[Auth] LDAP 登录失败: %s, %v", username, err)
+				c.JSON(http.StatusUnauthorized, LoginResponse{ErrorMsg: ErrMsgInvalidCredentials})
+				return
+			}
+			logger.ErrorF(c.Request.Context(), "[Auth] LDAP 认证异常: %s, %v", username, err)
+			c.JSON(http.StatusServiceUnavailable, LoginResponse{ErrorMsg: ErrMsgLDAPUnavailable})
+			return
+		}
+	} else {
+		if err != nil {
+			// 不暴露用户是否存在，统一返回凭证错误
+			logger.InfoF(c.Request.Context(), "[Auth] 登录失败 - 用户不存在: %s", username)
+			c.JSON(http.StatusUnauthorized, LoginResponse{ErrorMsg: ErrMsgInvalidCredentials})
+			return
+		}
 
-	// 验证密码
-	if !user.CheckPassword(password) {
-		logger.InfoF(c.Request.Context(), "[Auth] 登录失败 - 密码错误: %s", username)
-		c.JSON(http.StatusUnauthorized, LoginResponse{ErrorMsg: ErrMsgInvalidCredentials})
-		return
+		// 验证密码
+		if !user.CheckPassword(password) {
+			logger.InfoF(c.Request.Context(), "[Auth] 登录失败 - 密码错误: %s", username)
+			c.JSON(http.StatusUnauthorized, LoginResponse{ErrorMsg: ErrMsgInvalidCredentials})
+			return
+		}
 	}
 
 	// 检查用户是否激活
@@ -134,11 +151,8 @@ func Login(c *gin.Context) {
 		// 不影响登录流程，继续执行
 	}
 
-	// 创建会话
-	session := sessions.Default(c)
-	session.Set(SessionKeyUserID, user.ID)
-	session.Set(SessionKeyUsername, user.Username)
-	if err := session.Save(); err != nil {
+	// 创建会话（服务端记录 + Cookie），超出并发上限时登出最久未使用的会话
+	if _, err := StartSession(c, db.GetDB(c.Request.Context()), user, CurrentSessionPolicy()); err != nil {
 		logger.ErrorF(c.Request.Context(), "[Auth] 保存会话失败: %v", err)
 		c.JSON(http.StatusInternalServerError, LoginResponse{ErrorMsg: ErrMsgSessionError})
 		return
@@ -160,9 +174,8 @@ func Logout(c *gin.Context) {
 	userID := session.Get(SessionKeyUserID)
 	username := session.Get(SessionKeyUsername)
 
-	// 清除会话
-	session.Clear()
-	if err := session.Save(); err != nil {
+	// 删除服务端会话记录并清除 Cookie 会话
+	if err := EndSession(c, db.GetDB(c.Request.Context())); err != nil {
 		logger.ErrorF(c.Request.Context(), "[Auth] 清除会话失败: %v", err)
 		c.JSON(http.StatusInternalServerError, LogoutResponse{ErrorMsg: ErrMsgSessionError})
 		return
@@ -0,0 +1,224 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package auth
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/seatunnel/seatunnelX/internal/config"
+	"gorm.io/gorm"
+)
+
+// LDAP 默认属性与过滤器
+const (
+	defaultLDAPUserFilter        = "(uid=%s)"
+	defaultLDAPUsernameAttribute = "uid"
+	defaultLDAPEmailAttribute    = "mail"
+	defaultLDAPNameAttribute     = "cn"
+	defaultLDAPGroupAttribute    = "memberOf"
+	defaultLDAPTimeout           = 10 * time.Second
+	defaultLDAPGroupSizeLimit    = 100
+
+	// ldapExternalIDPrefix LDAP 用户在 User.OAuthID 中的前缀
+	ldapExternalIDPrefix = "ldap:"
+)
+
+// LDAP 认证错误定义
+var (
+	ErrLDAPDisabled           = errors.New("auth: 未启用 LDAP 认证")
+	ErrLDAPInvalidCredentials = errors.New("auth: LDAP 用户名或密码错误")
+)
+
+// LDAPEnabled 是否启用了 LDAP 认证
+func LDAPEnabled() bool {
+	return config.Config != nil && config.Config.Auth.LDAP.Enabled
+}
+
+// IsLDAPUser 用户是否由 LDAP 认证源创建
+func (u *User) IsLDAPUser() bool {
+	return strings.HasPrefix(u.OAuthID, ldapExternalIDPrefix)
+}
+
+// AuthenticateLDAP 使用服务账号搜索用户条目，再以用户 DN 和密码绑定校验
+// 成功时返回带有映射角色的外部身份
+func AuthenticateLDAP(ctx context.Context, cfg config.LDAPConfig, username, password string) (*ExternalIdentity, error) {
+	if !cfg.Enabled {
+		return nil, ErrLDAPDisabled
+	}
+	if username == "" || password == "" {
+		return nil, ErrLDAPInvalidCredentials
+	}
+
+	conn, timeout, err := dialLDAP(ctx, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("auth: 连接 LDAP 服务器失败: %w", err)
+	}
+	defer conn.Close()
+
+	if cfg.BindDN != "" {
+		if err := conn.Bind(cfg.BindDN, cfg.BindPassword); err != nil {
+			return nil, fmt.Errorf("auth: LDAP 服务账号绑定失败: %w", err)
+		}
+	}
+
+	usernameAttr := withDefault(cfg.UsernameAttribute, defaultLDAPUsernameAttribute)
+	emailAttr := withDefault(cfg.EmailAttribute, defaultLDAPEmailAttribute)
+	nameAttr := withDefault(cfg.NameAttribute, defaultLDAPNameAttribute)
+	groupAttr := withDefault(cfg.GroupAttribute, defaultLDAPGroupAttribute)
+
+	filter := strings.ReplaceAll(withDefault(cfg.UserFilter, defaultLDAPUserFilter), "%s", ldap.EscapeFilter(username))
+	entries, err := searchLDAP(conn, ldap.NewSearchRequest(
+		cfg.BaseDN, ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 2, int(timeout/time.Second), false,
+		filter, []string{usernameAttr, emailAttr, nameAttr, groupAttr}, nil,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("auth: 搜索 LDAP 用户失败: %w", err)
+	}
+	// 未找到或匹配多个条目时统一视为凭证错误，不暴露用户是否存在
+	if len(entries) != 1 {
+		return nil, ErrLDAPInvalidCredentials
+	}
+	entry := entries[0]
+
+	groups := entry.GetEqualFoldAttributeValues(groupAttr)
+	if cfg.GroupBaseDN != "" {
+		groupEntries, err := searchLDAP(conn, ldap.NewSearchRequest(
+			cfg.GroupBaseDN, ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, defaultLDAPGroupSizeLimit, int(timeout/time.Second), false,
+			strings.ReplaceAll(cfg.GroupFilter, "%s", ldap.EscapeFilter(entry.DN)), []string{"cn"}, nil,
+		))
+		if err != nil {
+			return nil, fmt.Errorf("auth: 搜索 LDAP 用户组失败: %w", err)
+		}
+		for _, group := range groupEntries {
+			groups = append(groups, group.DN)
+		}
+	}
+
+	if err := conn.Bind(entry.DN, password); err != nil {
+		if ldap.IsErrorWithCode(err, ldap.LDAPResultInvalidCredentials) {
+			return nil, ErrLDAPInvalidCredentials
+		}
+		return nil, fmt.Errorf("auth: LDAP 用户绑定失败: %w", err)
+	}
+
+	login := withDefault(entry.GetEqualFoldAttributeValue(usernameAttr), username)
+	return &ExternalIdentity{
+		ExternalID: ldapExternalIDPrefix + strings.ToLower(login),
+		Username:   login,
+		Email:      entry.GetEqualFoldAttributeValue(emailAttr),
+		Nickname:   entry.GetEqualFoldAttributeValue(nameAttr),
+		Role:       MapExternalRole(groups, cfg.RoleMappings, cfg.DefaultRole),
+	}, nil
+}
+
+// loginWithLDAP 通过 LDAP 认证用户，并创建或同步对应的本地用户
+func loginWithLDAP(ctx context.Context, db *gorm.DB, username, password string) (*User, error) {
+	identity, err := AuthenticateLDAP(ctx, config.Config.Auth.LDAP, username, password)
+	if err != nil {
+		return nil, err
+	}
+	return ProvisionExternalUser(db, *identity)
+}
+
+// dialLDAP 连接 LDAP 服务器并按配置执行 StartTLS，返回连接与单次请求超时
+// 连接在 ctx 取消时关闭，使进行中的请求立即失败
+func dialLDAP(ctx context.Context, cfg config.LDAPConfig) (*ldap.Conn, time.Duration, error) {
+	u, err := url.Parse(cfg.URL)
+	if err != nil {
+		return nil, 0, err
+	}
+	tlsConfig, err := ldapTLSConfig(cfg)
+	if err != nil {
+		return nil, 0, err
+	}
+	// StartTLS 使用 tls.Client，需显式指定 ServerName 才能校验证书
+	tlsConfig.ServerName = u.Hostname()
+
+	timeout := time.Duration(cfg.TimeoutSeconds) * time.Second
+	if timeout <= 0 {
+		timeout = defaultLDAPTimeout
+	}
+	conn, err := ldap.DialURL(cfg.URL,
+		ldap.DialWithDialer(&net.Dialer{Timeout: timeout}),
+		ldap.DialWithTLSConfig(tlsConfig),
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+	conn.SetTimeout(timeout)
+	context.AfterFunc(ctx, func() { _ = conn.Close() })
+
+	if cfg.StartTLS && strings.EqualFold(u.Scheme, "ldap") {
+		if err := conn.StartTLS(tlsConfig); err != nil {
+			_ = conn.Close()
+			return nil, 0, err
+		}
+	}
+	return conn, timeout, nil
+}
+
+// searchLDAP 执行搜索；超出大小限制时返回已收到的条目，由调用方判断条目数量
+func searchLDAP(conn *ldap.Conn, req *ldap.SearchRequest) ([]*ldap.Entry, error) {
+	result, err := conn.Search(req)
+	if err != nil {
+		if result != nil && ldap.IsErrorWithCode(err, ldap.LDAPResultSizeLimitExceeded) {
+			return result.Entries, nil
+		}
+		return nil, err
+	}
+	return result.Entries, nil
+}
+
+// ldapTLSConfig 根据 CA 文件与校验开关构造 TLS 配置
+func ldapTLSConfig(cfg config.LDAPConfig) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: cfg.InsecureSkipVerify, // 仅供测试环境使用
+	}
+	if cfg.CAFile == "" {
+		return tlsConfig, nil
+	}
+	pem, err := os.ReadFile(cfg.CAFile)
+	if err != nil {
+		return nil, fmt.Errorf("auth: 读取 LDAP CA 文件失败: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("auth: LDAP CA 文件中没有有效证书: %s", cfg.CAFile)
+	}
+	tlsConfig.RootCAs = pool
+	return tlsConfig, nil
+}
+
+// withDefault 值为空时返回默认值
+func withDefault(value, fallback string) string {
+	if strings.TrimSpace(value) == "" {
+		return fallback
+	}
+	return value
+}
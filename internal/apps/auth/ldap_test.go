@@ -0,0 +1,181 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package auth
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	ber "github.com/go-asn1-ber/asn1-ber"
+	"github.com/go-ldap/ldap/v3"
+	"github.com/seatunnel/seatunnelX/internal/config"
+)
+
+const (
+	testLDAPServiceDN = "cn=svc,dc=example,dc=com"
+	testLDAPUserDN    = "uid=alice,ou=people,dc=example,dc=com"
+)
+
+// fakeLDAPServer 为单个目录条目响应绑定与搜索请求
+type fakeLDAPServer struct {
+	listener net.Listener
+}
+
+func newFakeLDAPServer(t *testing.T) *fakeLDAPServer {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	s := &fakeLDAPServer{listener: listener}
+	t.Cleanup(func() { _ = listener.Close() })
+	go s.serve()
+	return s
+}
+
+func (s *fakeLDAPServer) url() string {
+	return "ldap://" + s.listener.Addr().String()
+}
+
+func (s *fakeLDAPServer) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(conn)
+	}
+}
+
+func (s *fakeLDAPServer) handle(conn net.Conn) {
+	defer conn.Close()
+	for {
+		msg, err := ber.ReadPacket(conn)
+		if err != nil || len(msg.Children) < 2 {
+			return
+		}
+		id := msg.Children[0].Value.(int64)
+		op := msg.Children[1]
+		reply := func(ops ...*ber.Packet) {
+			for _, o := range ops {
+				envelope := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "")
+				envelope.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, id, ""))
+				envelope.AppendChild(o)
+				_, _ = conn.Write(envelope.Bytes())
+			}
+		}
+		switch op.Tag {
+		case ldap.ApplicationBindRequest:
+			dn, password := op.Children[1].Value.(string), op.Children[2].Data.String()
+			code := int64(ldap.LDAPResultInvalidCredentials)
+			if (dn == testLDAPServiceDN && password == "svc-secret") || (dn == testLDAPUserDN && password == "alice-secret") {
+				code = ldap.LDAPResultSuccess
+			}
+			reply(fakeLDAPResult(ldap.ApplicationBindResponse, code))
+		case ldap.ApplicationSearchRequest:
+			filter, _ := ldap.DecompileFilter(op.Children[6])
+			var ops []*ber.Packet
+			if filter == "(uid=alice)" {
+				ops = append(ops, fakeLDAPEntry(testLDAPUserDN, map[string][]string{
+					"uid":      {"alice"},
+					"mail":     {"alice@example.com"},
+					"memberOf": {"cn=admins,dc=example,dc=com", "cn=dev,dc=example,dc=com"},
+				}))
+			}
+			ops = append(ops, fakeLDAPResult(ldap.ApplicationSearchResultDone, ldap.LDAPResultSuccess))
+			reply(ops...)
+		case ldap.ApplicationUnbindRequest:
+			return
+		}
+	}
+}
+
+func fakeLDAPResult(tag ber.Tag, code int64) *ber.Packet {
+	result := ber.Encode(ber.ClassApplication, ber.TypeConstructed, tag, nil, "")
+	result.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagEnumerated, code, ""))
+	result.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", ""))
+	result.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", ""))
+	return result
+}
+
+func fakeLDAPEntry(dn string, attributes map[string][]string) *ber.Packet {
+	entry := ber.Encode(ber.ClassApplication, ber.TypeConstructed, ldap.ApplicationSearchResultEntry, nil, "")
+	entry.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, dn, ""))
+	attrs := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "")
+	for name, values := range attributes {
+		attr := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "")
+		attr.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, name, ""))
+		set := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSet, nil, "")
+		for _, value := range values {
+			set.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, value, ""))
+		}
+		attr.AppendChild(set)
+		attrs.AppendChild(attr)
+	}
+	entry.AppendChild(attrs)
+	return entry
+}
+
+func TestAuthenticateLDAP(t *testing.T) {
+	server := newFakeLDAPServer(t)
+	cfg := config.LDAPConfig{
+		Enabled:      true,
+		URL:          server.url(),
+		BindDN:       testLDAPServiceDN,
+		BindPassword: "svc-secret",
+		BaseDN:       "dc=example,dc=com",
+		RoleMappings: []config.RoleMappingConfig{{Group: "cn=admins,dc=example,dc=com", Role: "admin"}},
+	}
+
+	identity, err := AuthenticateLDAP(context.Background(), cfg, "alice", "alice-secret")
+	if err != nil {
+		t.Fatalf("AuthenticateLDAP: %v", err)
+	}
+	if identity.ExternalID != "ldap:alice" || identity.Email != "alice@example.com" || identity.Role != RoleAdmin {
+		t.Fatalf("unexpected identity: %+v", identity)
+	}
+
+	// 密码错误、用户不存在与空密码统一返回凭证错误
+	for _, tt := range []struct{ username, password string }{
+		{"alice", "wrong"},
+		{"bob", "alice-secret"},
+		{"alice", ""},
+	} {
+		if _, err := AuthenticateLDAP(context.Background(), cfg, tt.username, tt.password); !errors.Is(err, ErrLDAPInvalidCredentials) {
+			t.Fatalf("AuthenticateLDAP(%q, %q): expected ErrLDAPInvalidCredentials, got %v", tt.username, tt.password, err)
+		}
+	}
+}
+
+func TestAuthenticateLDAP_ServiceBindFailure(t *testing.T) {
+	server := newFakeLDAPServer(t)
+	cfg := config.LDAPConfig{Enabled: true, URL: server.url(), BindDN: testLDAPServiceDN, BindPassword: "wrong", BaseDN: "dc=example,dc=com"}
+
+	_, err := AuthenticateLDAP(context.Background(), cfg, "alice", "alice-secret")
+	if err == nil || errors.Is(err, ErrLDAPInvalidCredentials) {
+		t.Fatalf("expected service bind error, got %v", err)
+	}
+}
+
+func TestAuthenticateLDAP_Disabled(t *testing.T) {
+	if _, err := AuthenticateLDAP(context.Background(), config.LDAPConfig{}, "alice", "alice-secret"); !errors.Is(err, ErrLDAPDisabled) {
+		t.Fatalf("expected ErrLDAPDisabled, got %v", err)
+	}
+}
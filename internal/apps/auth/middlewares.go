@@ -19,9 +19,12 @@
 package auth
 
 import (
+	"context"
+	"errors"
 	"net/http"
 	"strings"
 
+	"github.com/gin-contrib/sessions"
 	"github.com/gin-gonic/gin"
 	"github.com/seatunnel/seatunnelX/internal/db"
 	"github.com/seatunnel/seatunnelX/internal/logger"
@@ -57,6 +60,12 @@ func LoginRequired() gin.HandlerFunc {
 			return
 		}
 
+		// 校验服务端会话记录（是否被登出、过期、绑定的客户端是否一致），并滑动续期
+		if _, err := ValidateSession(c, db.GetDB(ctx), userID, CurrentSessionPolicy()); err != nil {
+			abortInvalidSession(c, ctx, userID, err)
+			return
+		}
+
 		// 从数据库加载用户，确保用户存在且激活
 		user, err := FindByID(db.GetDB(ctx), userID)
 		if err != nil {
@@ -104,6 +113,11 @@ func LoginRequiredSessionOnly() gin.HandlerFunc {
 			return
 		}
 
+		if err := ValidateSessionCached(c, db.GetDB(ctx), userID, CurrentSessionPolicy()); err != nil {
+			abortInvalidSession(c, ctx, userID, err)
+			return
+		}
+
 		username := strings.TrimSpace(GetUsernameFromContext(c))
 		if username == "" {
 			username = "unknown"
@@ -121,6 +135,27 @@ func LoginRequiredSessionOnly() gin.HandlerFunc {
 	}
 }
 
+// abortInvalidSession 拒绝会话校验失败的请求；会话已失效时清除 Cookie 会话，要求重新登录
+func abortInvalidSession(c *gin.Context, ctx context.Context, userID uint64, err error) {
+	if !errors.Is(err, ErrSessionInvalid) && !errors.Is(err, ErrSessionExpired) && !errors.Is(err, ErrSessionBinding) {
+		logger.ErrorF(ctx, "[LoginRequired] 校验会话失败: %d, %v", userID, err)
+		c.AbortWithStatusJSON(http.StatusInternalServerError, ErrorResponse{
+			ErrorMsg: ErrMsgInternalError,
+			Data:     nil,
+		})
+		return
+	}
+
+	logger.InfoF(ctx, "[LoginRequired] 会话已失效: %d, %v", userID, err)
+	session := sessions.Default(c)
+	session.Clear()
+	_ = session.Save()
+	c.AbortWithStatusJSON(http.StatusUnauthorized, ErrorResponse{
+		ErrorMsg: ErrMsgSessionExpired,
+		Data:     nil,
+	})
+}
+
 // AdminRequired 管理员权限验证中间件
 // 验证用户是否为管理员，如果不是则返回 403 错误
 // 注意：此中间件应在 LoginRequired 之后使用
@@ -66,6 +66,7 @@ type User struct {
 	OAuthID      string    `json:"oauth_id" gorm:"size:255;index"`               // OAuth 提供商 ID，格式: provider:id
 	IsActive     bool      `json:"is_active" gorm:"default:true"`
 	IsAdmin      bool      `json:"is_admin" gorm:"default:false"`
+	Role         string    `json:"role" gorm:"size:32"` // 全局角色：admin / operator / viewer，为空时视为 operator
 	LastLoginAt  time.Time `json:"last_login_at"`
 	CreatedAt    time.Time `json:"created_at" gorm:"autoCreateTime"`
 	UpdatedAt    time.Time `json:"updated_at" gorm:"autoUpdateTime"`
@@ -170,6 +171,7 @@ type UserInfo struct {
 	AvatarURL   string    `json:"avatar_url"`
 	IsActive    bool      `json:"is_active"`
 	IsAdmin     bool      `json:"is_admin"`
+	Role        Role      `json:"role"`
 	LastLoginAt time.Time `json:"last_login_at"`
 	CreatedAt   time.Time `json:"created_at"`
 }
@@ -185,6 +187,7 @@ func (u *User) ToUserInfo() *UserInfo {
 		AvatarURL:   u.AvatarURL,
 		IsActive:    u.IsActive,
 		IsAdmin:     u.IsAdmin,
+		Role:        u.EffectiveRole(),
 		LastLoginAt: u.LastLoginAt,
 		CreatedAt:   u.CreatedAt,
 	}
@@ -193,7 +196,8 @@ func (u *User) ToUserInfo() *UserInfo {
 // FindByOAuthID 根据 OAuth ID 查找用户
 func FindByOAuthID(db *gorm.DB, oauthID string) (*User, error) {
 	var user User
-	if err := db.Where("oauth_id = ?", oauthID).First(&user).Error; err != nil {
+	// 使用结构体条件，列名由 GORM 命名策略决定（OAuthID 对应 o_auth_id）
+	if err := db.Where(&User{OAuthID: oauthID}).First(&user).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, ErrUserNotFound
 		}
@@ -0,0 +1,364 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package auth
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/seatunnel/seatunnelX/internal/db"
+	"github.com/seatunnel/seatunnelX/internal/logger"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Role 用户角色
+type Role string
+
+const (
+	// RoleAdmin 管理员：可执行所有操作并管理用户
+	RoleAdmin Role = "admin"
+	// RoleOperator 运维：可读取与启停，但不能删除资源
+	RoleOperator Role = "operator"
+	// RoleViewer 只读：仅可访问只读接口
+	RoleViewer Role = "viewer"
+)
+
+// DefaultRole 未设置角色的已有用户默认为运维角色
+const DefaultRole = RoleOperator
+
+// Permission 操作权限级别
+type Permission string
+
+const (
+	// PermissionRead 读取资源
+	PermissionRead Permission = "read"
+	// PermissionOperate 创建、修改、启停资源
+	PermissionOperate Permission = "operate"
+	// PermissionManage 删除资源、管理凭据与用户
+	PermissionManage Permission = "manage"
+)
+
+// ResourceType 权限作用的资源类型
+type ResourceType string
+
+const (
+	// ResourceGlobal 全局资源（主机、安装包、插件仓库等），仅使用用户的全局角色
+	ResourceGlobal ResourceType = ""
+	// ResourceCluster 集群
+	ResourceCluster ResourceType = "cluster"
+	// ResourceProject 项目
+	ResourceProject ResourceType = "project"
+)
+
+// 上下文键常量
+const (
+	ContextKeyRole = "auth_role"
+)
+
+// 错误定义
+var (
+	ErrInvalidRole         = errors.New("auth: 无效的角色")
+	ErrInvalidResourceType = errors.New("auth: 无效的资源类型")
+)
+
+// ParseRole 解析角色字符串
+func ParseRole(value string) (Role, error) {
+	switch role := Role(strings.ToLower(strings.TrimSpace(value))); role {
+	case RoleAdmin, RoleOperator, RoleViewer:
+		return role, nil
+	default:
+		return "", ErrInvalidRole
+	}
+}
+
+// ParseResourceType 解析可绑定角色的资源类型
+func ParseResourceType(value string) (ResourceType, error) {
+	switch resourceType := ResourceType(strings.ToLower(strings.TrimSpace(value))); resourceType {
+	case ResourceCluster, ResourceProject:
+		return resourceType, nil
+	default:
+		return "", ErrInvalidResourceType
+	}
+}
+
+// Allows 判断角色是否具有指定权限
+func (r Role) Allows(permission Permission) bool {
+	switch r {
+	case RoleAdmin:
+		return true
+	case RoleOperator:
+		return permission == PermissionRead || permission == PermissionOperate
+	case RoleViewer:
+		return permission == PermissionRead
+	default:
+		return false
+	}
+}
+
+// EffectiveRole 返回用户的全局角色
+// IsAdmin 始终视为管理员；未设置角色的历史用户视为默认角色
+func (u *User) EffectiveRole() Role {
+	if u.IsAdmin {
+		return RoleAdmin
+	}
+	if role, err := ParseRole(u.Role); err == nil {
+		return role
+	}
+	return DefaultRole
+}
+
+// RoleBinding 资源级角色绑定表
+// 为用户在某个集群或项目上指定角色，覆盖其全局角色（管理员除外）
+type RoleBinding struct {
+	ID           uint64       `json:"id" gorm:"primaryKey;autoIncrement"`
+	UserID       uint64       `json:"user_id" gorm:"uniqueIndex:idx_role_binding_resource;not null"`
+	ResourceType ResourceType `json:"resource_type" gorm:"uniqueIndex:idx_role_binding_resource;size:32;not null"`
+	ResourceID   uint         `json:"resource_id" gorm:"uniqueIndex:idx_role_binding_resource;not null"`
+	Role         Role         `json:"role" gorm:"size:32;not null"`
+	CreatedAt    time.Time    `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt    time.Time    `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// TableName 指定表名
+func (RoleBinding) TableName() string {
+	return "auth_role_bindings"
+}
+
+// ListRoleBindings 获取用户的所有资源级角色绑定
+func ListRoleBindings(db *gorm.DB, userID uint64) ([]*RoleBinding, error) {
+	var bindings []*RoleBinding
+	err := db.Where("user_id = ?", userID).
+		Order("resource_type").Order("resource_id").
+		Find(&bindings).Error
+	return bindings, err
+}
+
+// ReplaceRoleBindings 使用新的绑定列表整体替换用户的资源级角色绑定
+func ReplaceRoleBindings(db *gorm.DB, userID uint64, bindings []*RoleBinding) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&RoleBinding{}).Error; err != nil {
+			return err
+		}
+		for _, binding := range bindings {
+			binding.ID = 0
+			binding.UserID = userID
+			if err := tx.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "user_id"}, {Name: "resource_type"}, {Name: "resource_id"}},
+				DoUpdates: clause.AssignmentColumns([]string{"role", "updated_at"}),
+			}).Create(binding).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// ResolveRole 计算用户在资源上的有效角色
+// 管理员始终为管理员；优先使用资源自身的绑定，其次使用资源所属项目的绑定，否则使用全局角色
+func ResolveRole(db *gorm.DB, user *User, resourceType ResourceType, resourceID uint) (Role, error) {
+	role := user.EffectiveRole()
+	if role == RoleAdmin || resourceType == ResourceGlobal || resourceID == 0 {
+		return role, nil
+	}
+
+	bound, found, err := findBoundRole(db, user.ID, resourceType, resourceID)
+	if err != nil {
+		return "", err
+	}
+	if found {
+		return bound, nil
+	}
+
+	// 归属于项目的资源继承项目上的绑定
+	if _, ok := tenantTables[resourceType]; ok {
+		projectID, err := GetResourceProject(db, resourceType, resourceID)
+		if errors.Is(err, ErrTenantResourceAbsent) || projectID == 0 {
+			return role, nil
+		}
+		if err != nil {
+			return "", err
+		}
+		bound, found, err := findBoundRole(db, user.ID, ResourceProject, projectID)
+		if err != nil {
+			return "", err
+		}
+		if found {
+			return bound, nil
+		}
+	}
+	return role, nil
+}
+
+// findBoundRole 查询用户在指定资源上直接绑定的角色
+func findBoundRole(db *gorm.DB, userID uint64, resourceType ResourceType, resourceID uint) (Role, bool, error) {
+	var binding RoleBinding
+	err := db.Where("user_id = ? AND resource_type = ? AND resource_id = ?", userID, resourceType, resourceID).
+		First(&binding).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+	bound, err := ParseRole(string(binding.Role))
+	if err != nil {
+		return "", false, nil
+	}
+	return bound, true, nil
+}
+
+// PermissionForMethod 根据 HTTP 方法推导所需权限
+// 只读方法需要 read；DELETE 需要 manage；其余写操作需要 operate
+func PermissionForMethod(method string) Permission {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return PermissionRead
+	case http.MethodDelete:
+		return PermissionManage
+	default:
+		return PermissionOperate
+	}
+}
+
+// RBAC 基于角色的访问控制中间件
+// 解析用户在资源上的有效角色并存入上下文，再按 HTTP 方法校验权限
+// idParam 为空或路径中不存在该参数时使用全局角色
+// 注意：此中间件应在 LoginRequired 之后使用
+func RBAC(resourceType ResourceType, idParam string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user := GetUserFromContext(c)
+		if user == nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrorResponse{
+				ErrorMsg: "未登录",
+				Data:     nil,
+			})
+			return
+		}
+
+		var resourceID uint
+		if idParam != "" {
+			if value, err := strconv.ParseUint(c.Param(idParam), 10, 32); err == nil {
+				resourceID = uint(value)
+			}
+		}
+
+		ctx := c.Request.Context()
+		role, err := ResolveRole(db.GetDB(ctx), user, resourceType, resourceID)
+		if err != nil {
+			logger.ErrorF(ctx, "[RBAC] 解析角色失败: %d %s, %v", user.ID, user.Username, err)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, ErrorResponse{
+				ErrorMsg: "权限校验失败",
+				Data:     nil,
+			})
+			return
+		}
+		c.Set(ContextKeyRole, role)
+
+		permission := PermissionForMethod(c.Request.Method)
+		if !role.Allows(permission) {
+			logger.InfoF(ctx, "[RBAC] 权限不足: %d %s role=%s permission=%s %s %s",
+				user.ID, user.Username, role, permission, c.Request.Method, c.FullPath())
+			c.AbortWithStatusJSON(http.StatusForbidden, ErrorResponse{
+				ErrorMsg: permissionDeniedMessage(permission),
+				Data:     nil,
+			})
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// Authorize 在处理器中校验当前用户是否具有指定权限
+// 使用 RBAC 中间件解析的角色；无权限时写入 403 响应并返回 false
+func Authorize(c *gin.Context, permission Permission) bool {
+	role := GetRoleFromContext(c)
+	if role.Allows(permission) {
+		return true
+	}
+	c.AbortWithStatusJSON(http.StatusForbidden, ErrorResponse{
+		ErrorMsg: permissionDeniedMessage(permission),
+		Data:     nil,
+	})
+	return false
+}
+
+// AuthorizeResource 在处理器中校验当前用户在指定资源上的权限
+// 用于资源 ID 来自请求体而非路径的场景；无权限时写入 403 响应并返回 false
+// 支持项目归属的资源还会校验租户范围
+func AuthorizeResource(c *gin.Context, permission Permission, resourceType ResourceType, resourceID uint) bool {
+	user := GetUserFromContext(c)
+	if user == nil {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, ErrorResponse{
+			ErrorMsg: "未登录",
+			Data:     nil,
+		})
+		return false
+	}
+
+	role, err := ResolveRole(db.GetDB(c.Request.Context()), user, resourceType, resourceID)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, ErrorResponse{
+			ErrorMsg: "权限校验失败",
+			Data:     nil,
+		})
+		return false
+	}
+	if !role.Allows(permission) {
+		c.AbortWithStatusJSON(http.StatusForbidden, ErrorResponse{
+			ErrorMsg: permissionDeniedMessage(permission),
+			Data:     nil,
+		})
+		return false
+	}
+	if _, ok := tenantTables[resourceType]; ok {
+		return AuthorizeTenant(c, resourceType, resourceID)
+	}
+	return true
+}
+
+// GetRoleFromContext 从 Gin 上下文获取当前用户的有效角色
+// 未经过 RBAC 中间件时回退到用户的全局角色
+func GetRoleFromContext(c *gin.Context) Role {
+	if value, exists := c.Get(ContextKeyRole); exists {
+		if role, ok := value.(Role); ok {
+			return role
+		}
+	}
+	if user := GetUserFromContext(c); user != nil {
+		return user.EffectiveRole()
+	}
+	return ""
+}
+
+// permissionDeniedMessage 返回权限不足的提示信息
+func permissionDeniedMessage(permission Permission) string {
+	switch permission {
+	case PermissionManage:
+		return "需要管理员权限"
+	case PermissionOperate:
+		return "需要运维权限"
+	default:
+		return "无访问权限"
+	}
+}
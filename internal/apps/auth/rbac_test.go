@@ -0,0 +1,123 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package auth
+
+import (
+	"net/http"
+	"testing"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// TestRoleAllows 测试角色权限矩阵
+func TestRoleAllows(t *testing.T) {
+	cases := []struct {
+		role       Role
+		permission Permission
+		want       bool
+	}{
+		{RoleAdmin, PermissionManage, true},
+		{RoleOperator, PermissionOperate, true},
+		{RoleOperator, PermissionManage, false},
+		{RoleViewer, PermissionRead, true},
+		{RoleViewer, PermissionOperate, false},
+		{Role("unknown"), PermissionRead, false},
+	}
+	for _, tc := range cases {
+		if got := tc.role.Allows(tc.permission); got != tc.want {
+			t.Errorf("%s.Allows(%s) = %v, want %v", tc.role, tc.permission, got, tc.want)
+		}
+	}
+
+	if PermissionForMethod(http.MethodGet) != PermissionRead ||
+		PermissionForMethod(http.MethodPost) != PermissionOperate ||
+		PermissionForMethod(http.MethodDelete) != PermissionManage {
+		t.Fatal("unexpected permission mapping for HTTP methods")
+	}
+}
+
+// TestResolveRoleUsesResourceBindings 测试资源级角色绑定覆盖全局角色
+func TestResolveRoleUsesResourceBindings(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open sqlite db: %v", err)
+	}
+	if err := db.AutoMigrate(&RoleBinding{}, &tenantTestCluster{}); err != nil {
+		t.Fatalf("failed to migrate role bindings: %v", err)
+	}
+
+	user := &User{ID: 7, Role: string(RoleViewer)}
+	if err := ReplaceRoleBindings(db, user.ID, []*RoleBinding{
+		{ResourceType: ResourceCluster, ResourceID: 1, Role: RoleOperator},
+	}); err != nil {
+		t.Fatalf("ReplaceRoleBindings returned error: %v", err)
+	}
+
+	if role, err := ResolveRole(db, user, ResourceCluster, 1); err != nil || role != RoleOperator {
+		t.Fatalf("expected operator on bound cluster, got %s (%v)", role, err)
+	}
+	if role, err := ResolveRole(db, user, ResourceCluster, 2); err != nil || role != RoleViewer {
+		t.Fatalf("expected global viewer role on unbound cluster, got %s (%v)", role, err)
+	}
+
+	admin := &User{ID: 8, IsAdmin: true}
+	if role, err := ResolveRole(db, admin, ResourceCluster, 1); err != nil || role != RoleAdmin {
+		t.Fatalf("expected admin to stay admin, got %s (%v)", role, err)
+	}
+	if (&User{}).EffectiveRole() != DefaultRole {
+		t.Fatal("expected users without role to fall back to the default role")
+	}
+}
+
+// TestResolveRoleInheritsProjectBindings 测试集群继承所属项目上的角色绑定
+func TestResolveRoleInheritsProjectBindings(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open sqlite db: %v", err)
+	}
+	if err := db.AutoMigrate(&RoleBinding{}, &tenantTestCluster{}); err != nil {
+		t.Fatalf("failed to migrate role bindings: %v", err)
+	}
+	for _, cluster := range []*tenantTestCluster{{ID: 1, ProjectID: 3}, {ID: 2, ProjectID: 3}, {ID: 3}} {
+		if err := db.Create(cluster).Error; err != nil {
+			t.Fatalf("failed to create cluster: %v", err)
+		}
+	}
+
+	user := &User{ID: 7, Role: string(RoleViewer)}
+	if err := ReplaceRoleBindings(db, user.ID, []*RoleBinding{
+		{ResourceType: ResourceProject, ResourceID: 3, Role: RoleOperator},
+		{ResourceType: ResourceCluster, ResourceID: 2, Role: RoleViewer},
+	}); err != nil {
+		t.Fatalf("ReplaceRoleBindings returned error: %v", err)
+	}
+
+	if role, err := ResolveRole(db, user, ResourceCluster, 1); err != nil || role != RoleOperator {
+		t.Fatalf("expected operator inherited from the project, got %s (%v)", role, err)
+	}
+	if role, err := ResolveRole(db, user, ResourceCluster, 2); err != nil || role != RoleViewer {
+		t.Fatalf("expected the cluster binding to override the project binding, got %s (%v)", role, err)
+	}
+	if role, err := ResolveRole(db, user, ResourceCluster, 3); err != nil || role != RoleViewer {
+		t.Fatalf("expected global role on a shared cluster, got %s (%v)", role, err)
+	}
+	if role, err := ResolveRole(db, user, ResourceCluster, 99); err != nil || role != RoleViewer {
+		t.Fatalf("expected global role on a missing cluster, got %s (%v)", role, err)
+	}
+}
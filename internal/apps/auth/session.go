@@ -0,0 +1,349 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package auth
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-gonic/gin"
+	"github.com/seatunnel/seatunnelX/internal/config"
+	"gorm.io/gorm"
+)
+
+// SessionKeyID 会话 Cookie 中保存服务端会话记录 ID 的键
+const SessionKeyID = "sid"
+
+// DefaultSessionTTL 未配置 session_age 与 idle_timeout 时会话记录的有效期
+const DefaultSessionTTL = 7 * 24 * time.Hour
+
+// sessionCacheTTL 轻量登录校验缓存会话校验结果的时长
+const sessionCacheTTL = 30 * time.Second
+
+// 会话相关错误定义
+var (
+	ErrSessionInvalid  = errors.New("auth: 会话无效或已被登出")
+	ErrSessionExpired  = errors.New("auth: 会话已过期")
+	ErrSessionBinding  = errors.New("auth: 会话与当前客户端不匹配")
+	ErrSessionNotFound = errors.New("auth: 会话不存在")
+)
+
+// UserSession 服务端会话记录表
+// 登录时创建，Cookie 中仅保存记录 ID；删除记录即强制该会话下线
+type UserSession struct {
+	ID         string    `json:"id" gorm:"primaryKey;size:64"`
+	UserID     uint64    `json:"user_id" gorm:"index;not null"`
+	Username   string    `json:"username" gorm:"size:50"`
+	IPAddress  string    `json:"ip_address" gorm:"size:64"`
+	UserAgent  string    `json:"user_agent" gorm:"size:512"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+	ExpiresAt  time.Time `json:"expires_at" gorm:"index"`
+
+	// Current 是否为发起请求的会话（仅用于 API 响应）
+	Current bool `json:"current" gorm:"-"`
+}
+
+// TableName 指定表名
+func (UserSession) TableName() string {
+	return "user_sessions"
+}
+
+// SessionPolicy 会话过期、并发与绑定策略
+type SessionPolicy struct {
+	// AbsoluteTTL 会话自创建起的最长有效期，0 表示不限制
+	AbsoluteTTL time.Duration
+	// IdleTimeout 滑动过期时间，0 表示不启用
+	IdleTimeout time.Duration
+	// MaxPerUser 单个用户的最大并发会话数，0 表示不限制
+	MaxPerUser int
+	// BindIP 与 BindUserAgent 要求会话只能从登录时的客户端使用
+	BindIP        bool
+	BindUserAgent bool
+}
+
+// CurrentSessionPolicy 根据 app.session_age 与 app.session 配置生成会话策略
+func CurrentSessionPolicy() SessionPolicy {
+	appConfig := config.Config.App
+	return SessionPolicy{
+		AbsoluteTTL:   time.Duration(appConfig.SessionAge) * time.Second,
+		IdleTimeout:   time.Duration(appConfig.Session.IdleTimeout) * time.Second,
+		MaxPerUser:    appConfig.Session.MaxPerUser,
+		BindIP:        appConfig.Session.BindIP,
+		BindUserAgent: appConfig.Session.BindUserAgent,
+	}
+}
+
+// expiresAt 计算会话的过期时间：取绝对有效期与滑动过期中较早者
+func (p SessionPolicy) expiresAt(createdAt, lastSeenAt time.Time) time.Time {
+	var expires time.Time
+	if p.AbsoluteTTL > 0 {
+		expires = createdAt.Add(p.AbsoluteTTL)
+	}
+	if p.IdleTimeout > 0 {
+		if idle := lastSeenAt.Add(p.IdleTimeout); expires.IsZero() || idle.Before(expires) {
+			expires = idle
+		}
+	}
+	if expires.IsZero() {
+		expires = createdAt.Add(DefaultSessionTTL)
+	}
+	return expires
+}
+
+// touchInterval 滑动续期写库的最小间隔，避免每个请求都更新会话记录
+func (p SessionPolicy) touchInterval() time.Duration {
+	interval := p.IdleTimeout / 10
+	if interval > time.Minute {
+		interval = time.Minute
+	}
+	return interval
+}
+
+// validatedSessions 轻量登录校验的会话缓存（会话 ID -> 缓存过期时间）
+var validatedSessions sync.Map
+
+// StartSession 为用户创建服务端会话记录并写入 Cookie 会话
+// 超出并发会话上限时登出该用户最久未使用的会话
+func StartSession(c *gin.Context, db *gorm.DB, user *User, policy SessionPolicy) (*UserSession, error) {
+	id, err := newSessionID()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	record := &UserSession{
+		ID:         id,
+		UserID:     user.ID,
+		Username:   user.Username,
+		IPAddress:  c.ClientIP(),
+		UserAgent:  truncate(c.Request.UserAgent(), 512),
+		CreatedAt:  now,
+		LastSeenAt: now,
+		ExpiresAt:  policy.expiresAt(now, now),
+	}
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		// 顺带清理所有已过期的会话记录
+		if err := tx.Where("expires_at <= ?", now).Delete(&UserSession{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Create(record).Error; err != nil {
+			return err
+		}
+		if policy.MaxPerUser <= 0 {
+			return nil
+		}
+		var evicted []string
+		if err := tx.Model(&UserSession{}).
+			Where("user_id = ? AND expires_at > ?", user.ID, now).
+			Order("last_seen_at DESC, created_at DESC").
+			Offset(policy.MaxPerUser).
+			Pluck("id", &evicted).Error; err != nil {
+			return err
+		}
+		if len(evicted) == 0 {
+			return nil
+		}
+		forgetSessions(evicted...)
+		return tx.Where("id IN ?", evicted).Delete(&UserSession{}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	session := sessions.Default(c)
+	session.Set(SessionKeyID, record.ID)
+	session.Set(SessionKeyUserID, user.ID)
+	session.Set(SessionKeyUsername, user.Username)
+	if err := session.Save(); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+// ValidateSession 校验请求携带的会话：记录存在且属于 userID、未过期、满足绑定策略
+// 启用滑动过期时续期会话记录并刷新 Cookie
+func ValidateSession(c *gin.Context, db *gorm.DB, userID uint64, policy SessionPolicy) (*UserSession, error) {
+	id := CurrentSessionID(c)
+	if id == "" {
+		return nil, ErrSessionInvalid
+	}
+
+	var record UserSession
+	if err := db.Where("id = ?", id).First(&record).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			forgetSessions(id)
+			return nil, ErrSessionInvalid
+		}
+		return nil, err
+	}
+	if record.UserID != userID {
+		return nil, ErrSessionInvalid
+	}
+
+	now := time.Now()
+	if !now.Before(record.ExpiresAt) {
+		forgetSessions(id)
+		_ = db.Where("id = ?", id).Delete(&UserSession{}).Error
+		return nil, ErrSessionExpired
+	}
+	if policy.BindIP && record.IPAddress != c.ClientIP() {
+		return nil, ErrSessionBinding
+	}
+	if policy.BindUserAgent && record.UserAgent != truncate(c.Request.UserAgent(), 512) {
+		return nil, ErrSessionBinding
+	}
+
+	if policy.IdleTimeout > 0 && now.Sub(record.LastSeenAt) >= policy.touchInterval() {
+		record.LastSeenAt = now
+		record.ExpiresAt = policy.expiresAt(record.CreatedAt, now)
+		if err := db.Model(&UserSession{}).Where("id = ?", id).Updates(map[string]interface{}{
+			"last_seen_at": record.LastSeenAt,
+			"expires_at":   record.ExpiresAt,
+		}).Error; err != nil {
+			return nil, err
+		}
+		// 重新保存 Cookie 使其有效期随会话一起滑动
+		_ = sessions.Default(c).Save()
+	}
+	return &record, nil
+}
+
+// ValidateSessionCached 与 ValidateSession 相同，但在 sessionCacheTTL 内复用校验结果，适用于高频代理请求
+// 本副本上的登出立即生效，其他副本上的登出最多延迟 sessionCacheTTL 生效
+func ValidateSessionCached(c *gin.Context, db *gorm.DB, userID uint64, policy SessionPolicy) error {
+	id := CurrentSessionID(c)
+	if id == "" {
+		return ErrSessionInvalid
+	}
+	now := time.Now()
+	if until, ok := validatedSessions.Load(id); ok && now.Before(until.(time.Time)) {
+		return nil
+	}
+	record, err := ValidateSession(c, db, userID, policy)
+	if err != nil {
+		return err
+	}
+	until := now.Add(sessionCacheTTL)
+	if record.ExpiresAt.Before(until) {
+		until = record.ExpiresAt
+	}
+	validatedSessions.Store(id, until)
+	return nil
+}
+
+// EndSession 删除当前请求的会话记录并清空 Cookie 会话
+func EndSession(c *gin.Context, db *gorm.DB) error {
+	session := sessions.Default(c)
+	if id := CurrentSessionID(c); id != "" {
+		forgetSessions(id)
+		if err := db.Where("id = ?", id).Delete(&UserSession{}).Error; err != nil {
+			return err
+		}
+	}
+	session.Clear()
+	return session.Save()
+}
+
+// CurrentSessionID 返回当前请求的服务端会话记录 ID，未登录时返回空字符串
+func CurrentSessionID(c *gin.Context) string {
+	id, _ := sessions.Default(c).Get(SessionKeyID).(string)
+	return id
+}
+
+// ListSessions 列出未过期的会话，按最近使用时间倒序；userID 为 0 时列出所有用户的会话
+func ListSessions(db *gorm.DB, userID uint64) ([]*UserSession, error) {
+	query := db.Model(&UserSession{}).Where("expires_at > ?", time.Now())
+	if userID != 0 {
+		query = query.Where("user_id = ?", userID)
+	}
+	var records []*UserSession
+	err := query.Order("last_seen_at DESC").Find(&records).Error
+	return records, err
+}
+
+// FindSession 根据 ID 查找会话
+func FindSession(db *gorm.DB, id string) (*UserSession, error) {
+	var record UserSession
+	if err := db.Where("id = ?", id).First(&record).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrSessionNotFound
+		}
+		return nil, err
+	}
+	return &record, nil
+}
+
+// RevokeSession 强制下线指定会话
+func RevokeSession(db *gorm.DB, id string) error {
+	result := db.Where("id = ?", id).Delete(&UserSession{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrSessionNotFound
+	}
+	forgetSessions(id)
+	return nil
+}
+
+// RevokeUserSessions 强制下线用户除 exceptID 外的所有会话，返回下线的会话数
+func RevokeUserSessions(db *gorm.DB, userID uint64, exceptID string) (int64, error) {
+	query := db.Model(&UserSession{}).Where("user_id = ?", userID)
+	if exceptID != "" {
+		query = query.Where("id <> ?", exceptID)
+	}
+	var ids []string
+	if err := query.Pluck("id", &ids).Error; err != nil {
+		return 0, err
+	}
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	forgetSessions(ids...)
+	result := db.Where("id IN ?", ids).Delete(&UserSession{})
+	return result.RowsAffected, result.Error
+}
+
+// forgetSessions 清除会话的轻量校验缓存
+func forgetSessions(ids ...string) {
+	for _, id := range ids {
+		validatedSessions.Delete(id)
+	}
+}
+
+// newSessionID 生成 256 位随机会话 ID
+func newSessionID() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// truncate 按字节截断字符串，保证写入定长列
+func truncate(value string, limit int) string {
+	if len(value) <= limit {
+		return value
+	}
+	return value[:limit]
+}
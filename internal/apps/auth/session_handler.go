@@ -0,0 +1,114 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package auth
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/seatunnel/seatunnelX/internal/db"
+	"github.com/seatunnel/seatunnelX/internal/logger"
+)
+
+// SessionListResponse 会话列表响应
+type SessionListResponse struct {
+	ErrorMsg string         `json:"error_msg"`
+	Data     []*UserSession `json:"data"`
+}
+
+// RevokeSessionsResponse 下线会话响应
+type RevokeSessionsResponse struct {
+	ErrorMsg string `json:"error_msg"`
+	Data     any    `json:"data"`
+}
+
+// MarkCurrentSession 标记列表中属于当前请求的会话
+func MarkCurrentSession(c *gin.Context, records []*UserSession) {
+	current := CurrentSessionID(c)
+	for _, record := range records {
+		record.Current = current != "" && record.ID == current
+	}
+}
+
+// ListMySessions 列出当前用户的活跃会话
+// @Tags auth
+// @Produce json
+// @Success 200 {object} SessionListResponse
+// @Router /api/v1/auth/sessions [get]
+func ListMySessions(c *gin.Context) {
+	userID := GetUserIDFromContext(c)
+	records, err := ListSessions(db.GetDB(c.Request.Context()), userID)
+	if err != nil {
+		logger.ErrorF(c.Request.Context(), "[Auth] 查询会话失败: user_id=%d err=%v", userID, err)
+		c.JSON(http.StatusInternalServerError, SessionListResponse{ErrorMsg: ErrMsgInternalError})
+		return
+	}
+	MarkCurrentSession(c, records)
+	c.JSON(http.StatusOK, SessionListResponse{Data: records})
+}
+
+// LogoutOtherSessions 登出当前用户除本会话外的所有会话
+// @Tags auth
+// @Produce json
+// @Success 200 {object} RevokeSessionsResponse
+// @Router /api/v1/auth/sessions/logout-others [post]
+func LogoutOtherSessions(c *gin.Context) {
+	userID := GetUserIDFromContext(c)
+	current := CurrentSessionID(c)
+	if current == "" {
+		c.JSON(http.StatusUnauthorized, RevokeSessionsResponse{ErrorMsg: ErrMsgSessionExpired})
+		return
+	}
+	revoked, err := RevokeUserSessions(db.GetDB(c.Request.Context()), userID, current)
+	if err != nil {
+		logger.ErrorF(c.Request.Context(), "[Auth] 登出其他会话失败: user_id=%d err=%v", userID, err)
+		c.JSON(http.StatusInternalServerError, RevokeSessionsResponse{ErrorMsg: ErrMsgInternalError})
+		return
+	}
+	logger.InfoF(c.Request.Context(), "[Auth] 已登出其他会话: user_id=%d count=%d", userID, revoked)
+	c.JSON(http.StatusOK, RevokeSessionsResponse{Data: gin.H{"revoked": revoked}})
+}
+
+// RevokeMySession 登出当前用户的指定会话
+// @Tags auth
+// @Produce json
+// @Param id path string true "会话ID"
+// @Success 200 {object} RevokeSessionsResponse
+// @Router /api/v1/auth/sessions/{id} [delete]
+func RevokeMySession(c *gin.Context) {
+	userID := GetUserIDFromContext(c)
+	database := db.GetDB(c.Request.Context())
+	record, err := FindSession(database, c.Param("id"))
+	if err != nil || record.UserID != userID {
+		// 不区分会话不存在与属于其他用户，避免泄露会话 ID
+		if err == nil || errors.Is(err, ErrSessionNotFound) {
+			c.JSON(http.StatusNotFound, RevokeSessionsResponse{ErrorMsg: "会话不存在"})
+			return
+		}
+		logger.ErrorF(c.Request.Context(), "[Auth] 查询会话失败: user_id=%d err=%v", userID, err)
+		c.JSON(http.StatusInternalServerError, RevokeSessionsResponse{ErrorMsg: ErrMsgInternalError})
+		return
+	}
+	if err := RevokeSession(database, record.ID); err != nil && !errors.Is(err, ErrSessionNotFound) {
+		logger.ErrorF(c.Request.Context(), "[Auth] 登出会话失败: user_id=%d err=%v", userID, err)
+		c.JSON(http.StatusInternalServerError, RevokeSessionsResponse{ErrorMsg: ErrMsgInternalError})
+		return
+	}
+	c.JSON(http.StatusOK, RevokeSessionsResponse{Data: gin.H{"revoked": 1}})
+}
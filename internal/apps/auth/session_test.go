@@ -0,0 +1,239 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-contrib/sessions/cookie"
+	"github.com/gin-gonic/gin"
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// 测试辅助函数：创建包含会话表的测试数据库
+func setupSessionTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("创建测试数据库失败: %v", err)
+	}
+	if err := db.AutoMigrate(&User{}, &UserSession{}); err != nil {
+		t.Fatalf("迁移会话表失败: %v", err)
+	}
+	return db
+}
+
+// sessionClient 模拟浏览器：保存 Cookie 并固定客户端 IP 与 User-Agent
+type sessionClient struct {
+	router    *gin.Engine
+	cookies   []*http.Cookie
+	ip        string
+	userAgent string
+}
+
+// 测试辅助函数：创建带登录与受保护接口的测试路由
+func newSessionRouter(db *gorm.DB, user *User, policy SessionPolicy) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(sessions.Sessions("test-session", cookie.NewStore([]byte("test-secret"))))
+	r.POST("/login", func(c *gin.Context) {
+		if _, err := StartSession(c, db, user, policy); err != nil {
+			c.Status(http.StatusInternalServerError)
+			return
+		}
+		c.Status(http.StatusOK)
+	})
+	r.GET("/me", func(c *gin.Context) {
+		if _, err := ValidateSession(c, db, user.ID, policy); err != nil {
+			c.String(http.StatusUnauthorized, err.Error())
+			return
+		}
+		c.String(http.StatusOK, CurrentSessionID(c))
+	})
+	return r
+}
+
+func (s *sessionClient) do(method, path string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, nil)
+	req.RemoteAddr = s.ip + ":12345"
+	req.Header.Set("User-Agent", s.userAgent)
+	for _, ck := range s.cookies {
+		req.AddCookie(ck)
+	}
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	if cookies := w.Result().Cookies(); len(cookies) > 0 {
+		s.cookies = cookies
+	}
+	return w
+}
+
+func createSessionTestUser(t *testing.T, db *gorm.DB) *User {
+	t.Helper()
+	user, err := createTestUser(db, "alice", "password123", true)
+	if err != nil {
+		t.Fatalf("创建测试用户失败: %v", err)
+	}
+	return user
+}
+
+func TestStartSession_EvictsLeastRecentlyUsedBeyondLimit(t *testing.T) {
+	db := setupSessionTestDB(t)
+	user := createSessionTestUser(t, db)
+	router := newSessionRouter(db, user, SessionPolicy{MaxPerUser: 2})
+
+	clients := make([]*sessionClient, 3)
+	for i := range clients {
+		clients[i] = &sessionClient{router: router, ip: "10.0.0.1", userAgent: "test"}
+		if w := clients[i].do(http.MethodPost, "/login"); w.Code != http.StatusOK {
+			t.Fatalf("login %d: status %d", i, w.Code)
+		}
+		// 保证 last_seen_at 严格递增
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	if w := clients[0].do(http.MethodGet, "/me"); w.Code != http.StatusUnauthorized {
+		t.Fatalf("oldest session should be evicted, got %d", w.Code)
+	}
+	for i := 1; i < 3; i++ {
+		if w := clients[i].do(http.MethodGet, "/me"); w.Code != http.StatusOK {
+			t.Fatalf("session %d should stay valid, got %d", i, w.Code)
+		}
+	}
+
+	records, err := ListSessions(db, user.ID)
+	if err != nil {
+		t.Fatalf("ListSessions: %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("expected 2 sessions, got %d", len(records))
+	}
+}
+
+func TestValidateSession_SlidingExpiration(t *testing.T) {
+	db := setupSessionTestDB(t)
+	user := createSessionTestUser(t, db)
+	policy := SessionPolicy{IdleTimeout: time.Hour}
+	router := newSessionRouter(db, user, policy)
+	client := &sessionClient{router: router, ip: "10.0.0.1", userAgent: "test"}
+	client.do(http.MethodPost, "/login")
+
+	id := client.do(http.MethodGet, "/me").Body.String()
+	// 模拟会话闲置 50 分钟：仍在闲置期内，访问后应续期
+	past := time.Now().Add(-50 * time.Minute)
+	if err := db.Model(&UserSession{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"last_seen_at": past,
+		"expires_at":   past.Add(time.Hour),
+	}).Error; err != nil {
+		t.Fatalf("update session: %v", err)
+	}
+	if w := client.do(http.MethodGet, "/me"); w.Code != http.StatusOK {
+		t.Fatalf("idle session within timeout should be valid, got %d", w.Code)
+	}
+	record, err := FindSession(db, id)
+	if err != nil {
+		t.Fatalf("FindSession: %v", err)
+	}
+	if time.Until(record.ExpiresAt) < 55*time.Minute {
+		t.Fatalf("session should slide forward, expires at %v", record.ExpiresAt)
+	}
+
+	// 闲置超时后会话失效并被删除
+	if err := db.Model(&UserSession{}).Where("id = ?", id).Update("expires_at", time.Now().Add(-time.Second)).Error; err != nil {
+		t.Fatalf("update session: %v", err)
+	}
+	if w := client.do(http.MethodGet, "/me"); w.Code != http.StatusUnauthorized {
+		t.Fatalf("expired session should be rejected, got %d", w.Code)
+	}
+	if _, err := FindSession(db, id); err != ErrSessionNotFound {
+		t.Fatalf("expired session should be removed, got %v", err)
+	}
+}
+
+func TestSessionPolicy_ExpiresAtUsesEarliestDeadline(t *testing.T) {
+	created := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	lastSeen := created.Add(90 * time.Minute)
+
+	policy := SessionPolicy{AbsoluteTTL: 2 * time.Hour, IdleTimeout: time.Hour}
+	if got := policy.expiresAt(created, lastSeen); !got.Equal(created.Add(2 * time.Hour)) {
+		t.Fatalf("absolute TTL should cap sliding expiration, got %v", got)
+	}
+	if got := (SessionPolicy{}).expiresAt(created, lastSeen); !got.Equal(created.Add(DefaultSessionTTL)) {
+		t.Fatalf("default TTL expected, got %v", got)
+	}
+}
+
+func TestValidateSession_Binding(t *testing.T) {
+	db := setupSessionTestDB(t)
+	user := createSessionTestUser(t, db)
+	router := newSessionRouter(db, user, SessionPolicy{BindIP: true, BindUserAgent: true})
+	client := &sessionClient{router: router, ip: "10.0.0.1", userAgent: "browser-a"}
+	client.do(http.MethodPost, "/login")
+
+	if w := client.do(http.MethodGet, "/me"); w.Code != http.StatusOK {
+		t.Fatalf("same client should be valid, got %d", w.Code)
+	}
+
+	client.ip = "10.0.0.2"
+	if w := client.do(http.MethodGet, "/me"); w.Code != http.StatusUnauthorized {
+		t.Fatalf("changed IP should be rejected, got %d", w.Code)
+	}
+
+	client.ip = "10.0.0.1"
+	client.userAgent = "browser-b"
+	if w := client.do(http.MethodGet, "/me"); w.Code != http.StatusUnauthorized {
+		t.Fatalf("changed user agent should be rejected, got %d", w.Code)
+	}
+}
+
+func TestRevokeUserSessions_KeepsExceptedSession(t *testing.T) {
+	db := setupSessionTestDB(t)
+	user := createSessionTestUser(t, db)
+	router := newSessionRouter(db, user, SessionPolicy{})
+
+	current := &sessionClient{router: router, ip: "10.0.0.1", userAgent: "test"}
+	other := &sessionClient{router: router, ip: "10.0.0.2", userAgent: "test"}
+	current.do(http.MethodPost, "/login")
+	other.do(http.MethodPost, "/login")
+	currentID := current.do(http.MethodGet, "/me").Body.String()
+
+	revoked, err := RevokeUserSessions(db, user.ID, currentID)
+	if err != nil {
+		t.Fatalf("RevokeUserSessions: %v", err)
+	}
+	if revoked != 1 {
+		t.Fatalf("expected 1 revoked session, got %d", revoked)
+	}
+	if w := current.do(http.MethodGet, "/me"); w.Code != http.StatusOK {
+		t.Fatalf("current session should stay valid, got %d", w.Code)
+	}
+	if w := other.do(http.MethodGet, "/me"); w.Code != http.StatusUnauthorized {
+		t.Fatalf("other session should be revoked, got %d", w.Code)
+	}
+	if err := RevokeSession(db, "missing"); err != ErrSessionNotFound {
+		t.Fatalf("expected ErrSessionNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,310 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package auth
+
+import (
+	"errors"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/seatunnel/seatunnelX/internal/db"
+	"github.com/seatunnel/seatunnelX/internal/logger"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ResourceHost 主机（仅用于租户归属，不支持资源级角色绑定）
+const ResourceHost ResourceType = "host"
+
+// ContextKeyTenantScope 上下文中缓存租户范围的键
+const ContextKeyTenantScope = "auth_tenant_scope"
+
+// 租户相关错误定义
+var (
+	ErrProjectNotFound      = errors.New("auth: 项目不存在")
+	ErrProjectNameEmpty     = errors.New("auth: 项目名称不能为空")
+	ErrProjectAlreadyExists = errors.New("auth: 项目名称已存在")
+	ErrTenantResourceType   = errors.New("auth: 该资源类型不支持项目归属")
+	ErrTenantResourceAbsent = errors.New("auth: 资源不存在")
+)
+
+// tenantTables 支持项目归属的资源类型及其表名，这些表都包含 id 与 project_id 列
+var tenantTables = map[ResourceType]string{
+	ResourceHost:    "hosts",
+	ResourceCluster: "clusters",
+}
+
+// Project 项目（租户）表
+// 主机与集群通过 project_id 归属到项目；project_id 为 0 的资源为共享资源，所有用户可见
+type Project struct {
+	ID          uint      `json:"id" gorm:"primaryKey;autoIncrement"`
+	Name        string    `json:"name" gorm:"size:100;uniqueIndex;not null"`
+	Description string    `json:"description" gorm:"type:text"`
+	CreatedAt   time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt   time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// TableName 指定表名
+func (Project) TableName() string {
+	return "auth_projects"
+}
+
+// ProjectMember 项目成员表
+type ProjectMember struct {
+	ID        uint64    `json:"id" gorm:"primaryKey;autoIncrement"`
+	ProjectID uint      `json:"project_id" gorm:"uniqueIndex:idx_project_member;not null"`
+	UserID    uint64    `json:"user_id" gorm:"uniqueIndex:idx_project_member;index;not null"`
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// TableName 指定表名
+func (ProjectMember) TableName() string {
+	return "auth_project_members"
+}
+
+// ListProjects 获取所有项目
+func ListProjects(db *gorm.DB) ([]*Project, error) {
+	var projects []*Project
+	err := db.Order("name").Find(&projects).Error
+	return projects, err
+}
+
+// GetProject 根据 ID 获取项目
+func GetProject(db *gorm.DB, id uint) (*Project, error) {
+	var project Project
+	if err := db.First(&project, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrProjectNotFound
+		}
+		return nil, err
+	}
+	return &project, nil
+}
+
+// CreateProject 创建项目
+func CreateProject(db *gorm.DB, project *Project) error {
+	project.Name = strings.TrimSpace(project.Name)
+	if project.Name == "" {
+		return ErrProjectNameEmpty
+	}
+	var count int64
+	if err := db.Model(&Project{}).Where("name = ?", project.Name).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return ErrProjectAlreadyExists
+	}
+	return db.Create(project).Error
+}
+
+// DeleteProject 删除项目及其成员关系
+// 项目下的主机与集群不会被删除，而是释放为共享资源
+func DeleteProject(db *gorm.DB, id uint) error {
+	if _, err := GetProject(db, id); err != nil {
+		return err
+	}
+	return db.Transaction(func(tx *gorm.DB) error {
+		for _, table := range tenantTables {
+			if err := tx.Table(table).Where("project_id = ?", id).Update("project_id", 0).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Where("project_id = ?", id).Delete(&ProjectMember{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&Project{}, id).Error
+	})
+}
+
+// ListProjectMembers 获取项目的成员用户 ID
+func ListProjectMembers(db *gorm.DB, projectID uint) ([]uint64, error) {
+	var userIDs []uint64
+	err := db.Model(&ProjectMember{}).Where("project_id = ?", projectID).
+		Order("user_id").Pluck("user_id", &userIDs).Error
+	return userIDs, err
+}
+
+// ReplaceProjectMembers 使用新的成员列表整体替换项目成员
+func ReplaceProjectMembers(db *gorm.DB, projectID uint, userIDs []uint64) error {
+	if _, err := GetProject(db, projectID); err != nil {
+		return err
+	}
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("project_id = ?", projectID).Delete(&ProjectMember{}).Error; err != nil {
+			return err
+		}
+		for _, userID := range userIDs {
+			member := &ProjectMember{ProjectID: projectID, UserID: userID}
+			if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(member).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// GetResourceProject 返回资源所属的项目 ID，0 表示共享资源
+func GetResourceProject(db *gorm.DB, resourceType ResourceType, resourceID uint) (uint, error) {
+	table, ok := tenantTables[resourceType]
+	if !ok {
+		return 0, ErrTenantResourceType
+	}
+	var projectIDs []uint
+	if err := db.Table(table).Where("id = ?", resourceID).Pluck("project_id", &projectIDs).Error; err != nil {
+		return 0, err
+	}
+	if len(projectIDs) == 0 {
+		return 0, ErrTenantResourceAbsent
+	}
+	return projectIDs[0], nil
+}
+
+// TransferResource 将资源转移到指定项目，projectID 为 0 时释放为共享资源
+func TransferResource(db *gorm.DB, resourceType ResourceType, resourceID uint, projectID uint) error {
+	table, ok := tenantTables[resourceType]
+	if !ok {
+		return ErrTenantResourceType
+	}
+	if projectID != 0 {
+		if _, err := GetProject(db, projectID); err != nil {
+			return err
+		}
+	}
+	result := db.Table(table).Where("id = ?", resourceID).Update("project_id", projectID)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		if _, err := GetResourceProject(db, resourceType, resourceID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// TenantScope 用户可访问的项目范围
+// All 为 true 时（管理员）可访问所有资源；否则仅可访问共享资源及所属项目的资源
+type TenantScope struct {
+	All        bool
+	ProjectIDs []uint
+}
+
+// Allows 判断范围内是否可访问归属于 projectID 的资源
+func (s TenantScope) Allows(projectID uint) bool {
+	return s.All || projectID == 0 || slices.Contains(s.ProjectIDs, projectID)
+}
+
+// ResolveTenantScope 计算用户的租户范围
+func ResolveTenantScope(db *gorm.DB, user *User) (TenantScope, error) {
+	if user.EffectiveRole() == RoleAdmin {
+		return TenantScope{All: true}, nil
+	}
+	var projectIDs []uint
+	if err := db.Model(&ProjectMember{}).Where("user_id = ?", user.ID).
+		Order("project_id").Pluck("project_id", &projectIDs).Error; err != nil {
+		return TenantScope{}, err
+	}
+	return TenantScope{ProjectIDs: projectIDs}, nil
+}
+
+// GetTenantScope 从 Gin 上下文获取当前用户的租户范围，首次调用时解析并缓存
+// 未登录或解析失败时仅允许访问共享资源
+func GetTenantScope(c *gin.Context) TenantScope {
+	if value, exists := c.Get(ContextKeyTenantScope); exists {
+		if scope, ok := value.(TenantScope); ok {
+			return scope
+		}
+	}
+	user := GetUserFromContext(c)
+	if user == nil {
+		return TenantScope{}
+	}
+	scope, err := ResolveTenantScope(db.GetDB(c.Request.Context()), user)
+	if err != nil {
+		logger.ErrorF(c.Request.Context(), "[Tenant] 解析租户范围失败: %d %s, %v", user.ID, user.Username, err)
+		return TenantScope{}
+	}
+	c.Set(ContextKeyTenantScope, scope)
+	return scope
+}
+
+// TenantGuard 租户隔离中间件
+// 路径参数 idParam 指向的资源不属于当前用户可访问的项目时返回 403
+// 资源不存在或路径中无该参数时放行，由处理器返回相应结果
+// 注意：此中间件应在 LoginRequired 之后使用
+func TenantGuard(resourceType ResourceType, idParam string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		value, err := strconv.ParseUint(c.Param(idParam), 10, 32)
+		if err != nil {
+			c.Next()
+			return
+		}
+		if !AuthorizeTenant(c, resourceType, uint(value)) {
+			return
+		}
+		c.Next()
+	}
+}
+
+// AuthorizeTenant 在处理器中校验当前用户是否可访问指定资源所属的项目
+// 用于资源 ID 来自请求体而非路径的场景；无权限时写入 403 响应并返回 false
+func AuthorizeTenant(c *gin.Context, resourceType ResourceType, resourceID uint) bool {
+	scope := GetTenantScope(c)
+	if scope.All {
+		return true
+	}
+	ctx := c.Request.Context()
+	projectID, err := GetResourceProject(db.GetDB(ctx), resourceType, resourceID)
+	if errors.Is(err, ErrTenantResourceAbsent) {
+		return true
+	}
+	if err != nil {
+		logger.ErrorF(ctx, "[Tenant] 查询资源归属失败: %s %d, %v", resourceType, resourceID, err)
+		c.AbortWithStatusJSON(http.StatusInternalServerError, ErrorResponse{
+			ErrorMsg: "权限校验失败",
+			Data:     nil,
+		})
+		return false
+	}
+	if scope.Allows(projectID) {
+		return true
+	}
+	logger.InfoF(ctx, "[Tenant] 跨租户访问被拒绝: %s %d project=%d %s %s",
+		resourceType, resourceID, projectID, c.Request.Method, c.FullPath())
+	c.AbortWithStatusJSON(http.StatusForbidden, ErrorResponse{
+		ErrorMsg: "无权访问其他项目的资源",
+		Data:     nil,
+	})
+	return false
+}
+
+// AuthorizeProject 校验当前用户是否可将资源归属到指定项目（0 表示共享）
+func AuthorizeProject(c *gin.Context, projectID uint) bool {
+	if GetTenantScope(c).Allows(projectID) {
+		return true
+	}
+	c.AbortWithStatusJSON(http.StatusForbidden, ErrorResponse{
+		ErrorMsg: "无权访问该项目",
+		Data:     nil,
+	})
+	return false
+}
@@ -0,0 +1,104 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package auth
+
+import (
+	"errors"
+	"testing"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// tenantTestCluster 与 tenantTestHost 仅包含租户校验所需列
+type tenantTestCluster struct {
+	ID        uint
+	ProjectID uint
+}
+
+func (tenantTestCluster) TableName() string {
+	return "clusters"
+}
+
+type tenantTestHost struct {
+	ID        uint
+	ProjectID uint
+}
+
+func (tenantTestHost) TableName() string {
+	return "hosts"
+}
+
+// TestTenantScopeAndTransfer 测试租户范围解析与资源归属转移
+func TestTenantScopeAndTransfer(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open sqlite db: %v", err)
+	}
+	if err := db.AutoMigrate(&Project{}, &ProjectMember{}, &tenantTestCluster{}, &tenantTestHost{}); err != nil {
+		t.Fatalf("failed to migrate tenant tables: %v", err)
+	}
+
+	teamA := &Project{Name: "team-a"}
+	teamB := &Project{Name: "team-b"}
+	for _, project := range []*Project{teamA, teamB} {
+		if err := CreateProject(db, project); err != nil {
+			t.Fatalf("CreateProject returned error: %v", err)
+		}
+	}
+	if err := CreateProject(db, &Project{Name: " team-a "}); !errors.Is(err, ErrProjectAlreadyExists) {
+		t.Fatalf("expected duplicate project name to be rejected, got %v", err)
+	}
+	if err := ReplaceProjectMembers(db, teamA.ID, []uint64{7, 7}); err != nil {
+		t.Fatalf("ReplaceProjectMembers returned error: %v", err)
+	}
+
+	scope, err := ResolveTenantScope(db, &User{ID: 7})
+	if err != nil {
+		t.Fatalf("ResolveTenantScope returned error: %v", err)
+	}
+	if !scope.Allows(0) || !scope.Allows(teamA.ID) || scope.Allows(teamB.ID) {
+		t.Fatalf("expected shared and team-a resources only, got %+v", scope)
+	}
+	if admin, _ := ResolveTenantScope(db, &User{ID: 8, IsAdmin: true}); !admin.All {
+		t.Fatal("expected admin to access all projects")
+	}
+
+	if err := db.Create(&tenantTestCluster{ID: 1, ProjectID: teamB.ID}).Error; err != nil {
+		t.Fatalf("failed to create cluster: %v", err)
+	}
+	if err := TransferResource(db, ResourceCluster, 1, teamA.ID); err != nil {
+		t.Fatalf("TransferResource returned error: %v", err)
+	}
+	if projectID, err := GetResourceProject(db, ResourceCluster, 1); err != nil || projectID != teamA.ID {
+		t.Fatalf("expected cluster in team-a, got %d (%v)", projectID, err)
+	}
+	if err := TransferResource(db, ResourceCluster, 2, teamA.ID); !errors.Is(err, ErrTenantResourceAbsent) {
+		t.Fatalf("expected missing cluster to be reported, got %v", err)
+	}
+
+	if err := DeleteProject(db, teamA.ID); err != nil {
+		t.Fatalf("DeleteProject returned error: %v", err)
+	}
+	if projectID, _ := GetResourceProject(db, ResourceCluster, 1); projectID != 0 {
+		t.Fatalf("expected cluster to become shared after project deletion, got %d", projectID)
+	}
+	if members, _ := ListProjectMembers(db, teamA.ID); len(members) != 0 {
+		t.Fatalf("expected members to be removed, got %v", members)
+	}
+}
@@ -0,0 +1,265 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package backup
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/seatunnel/seatunnelX/internal/apps/cluster"
+	appconfig "github.com/seatunnel/seatunnelX/internal/apps/config"
+	"github.com/seatunnel/seatunnelX/internal/apps/plugin"
+)
+
+// ArchiveFormatVersion is the layout version written into manifest.json.
+// ArchiveFormatVersion 是写入 manifest.json 的归档格式版本。
+const ArchiveFormatVersion = 1
+
+// Archive entry names.
+// 归档条目名称。
+const (
+	manifestEntry = "manifest.json"
+	clusterEntry  = "metadata/cluster.json"
+	pluginsEntry  = "metadata/plugins.json"
+	configsEntry  = "metadata/configs.json"
+	nodesDir      = "nodes/"
+)
+
+// maxArchiveEntrySize bounds a single entry read from an archive.
+// maxArchiveEntrySize 限制从归档中读取的单个条目大小。
+const maxArchiveEntrySize = 64 << 20
+
+// Manifest describes the content of an archive.
+// Manifest 描述归档内容。
+type Manifest struct {
+	FormatVersion  int            `json:"format_version"`
+	BackupID       uint           `json:"backup_id"`
+	ClusterID      uint           `json:"cluster_id"`
+	ClusterName    string         `json:"cluster_name"`
+	DeploymentMode string         `json:"deployment_mode"`
+	Version        string         `json:"version"`
+	CreatedAt      time.Time      `json:"created_at"`
+	Files          []ManifestFile `json:"files"`
+	// Errors lists node files that could not be read while the backup was taken.
+	// Errors 列出备份时读取失败的节点文件。
+	Errors []string `json:"errors,omitempty"`
+}
+
+// ManifestFile is one node config file inside the archive.
+// ManifestFile 是归档中的一个节点配置文件。
+type ManifestFile struct {
+	HostID     uint                 `json:"host_id"`
+	ConfigType appconfig.ConfigType `json:"config_type"`
+	Path       string               `json:"path"`
+	Size       int64                `json:"size"`
+	SHA256     string               `json:"sha256"`
+}
+
+// Snapshot is the decoded content of an archive.
+// Snapshot 是归档解码后的内容。
+type Snapshot struct {
+	Manifest Manifest
+	// Cluster is the cluster record with its nodes.
+	// Cluster 是包含节点的集群记录。
+	Cluster *cluster.Cluster
+	Plugins []plugin.InstalledPlugin
+	// Configs are the templates and node configs stored by the Control Plane.
+	// Configs 是控制面保存的模板与节点配置。
+	Configs []*appconfig.ConfigInfo
+	// NodeFiles are the config files read from the nodes, keyed by host and config type.
+	// NodeFiles 是从节点读取的配置文件，按主机与配置类型索引。
+	NodeFiles map[uint]map[appconfig.ConfigType]string
+}
+
+// nodeFilePath returns the archive path of a node config file.
+// nodeFilePath 返回节点配置文件在归档中的路径。
+func nodeFilePath(hostID uint, configType appconfig.ConfigType) string {
+	return nodesDir + strconv.FormatUint(uint64(hostID), 10) + "/" + appconfig.GetConfigFilePath(configType)
+}
+
+// WriteArchive writes the snapshot as a gzip-compressed tar archive and fills in the manifest file list.
+// WriteArchive 将快照写为 gzip 压缩的 tar 归档，并填充清单中的文件列表。
+func WriteArchive(w io.Writer, snapshot *Snapshot) error {
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	modTime := snapshot.Manifest.CreatedAt
+	if modTime.IsZero() {
+		modTime = time.Now()
+	}
+
+	snapshot.Manifest.FormatVersion = ArchiveFormatVersion
+	snapshot.Manifest.Files = snapshot.Manifest.Files[:0]
+	for _, hostID := range sortedHostIDs(snapshot.NodeFiles) {
+		files := snapshot.NodeFiles[hostID]
+		for _, configType := range sortedConfigTypes(files) {
+			content := []byte(files[configType])
+			sum := sha256.Sum256(content)
+			entry := ManifestFile{
+				HostID:     hostID,
+				ConfigType: configType,
+				Path:       nodeFilePath(hostID, configType),
+				Size:       int64(len(content)),
+				SHA256:     hex.EncodeToString(sum[:]),
+			}
+			if err := writeTarEntry(tw, entry.Path, content, modTime); err != nil {
+				return err
+			}
+			snapshot.Manifest.Files = append(snapshot.Manifest.Files, entry)
+		}
+	}
+
+	for _, item := range []struct {
+		name  string
+		value interface{}
+	}{
+		{clusterEntry, snapshot.Cluster},
+		{pluginsEntry, snapshot.Plugins},
+		{configsEntry, snapshot.Configs},
+		{manifestEntry, snapshot.Manifest},
+	} {
+		data, err := json.MarshalIndent(item.value, "", "  ")
+		if err != nil {
+			return fmt.Errorf("encode %s: %w", item.name, err)
+		}
+		if err := writeTarEntry(tw, item.name, data, modTime); err != nil {
+			return err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+func writeTarEntry(tw *tar.Writer, name string, data []byte, modTime time.Time) error {
+	if err := tw.WriteHeader(&tar.Header{
+		Name:    name,
+		Mode:    0o640,
+		Size:    int64(len(data)),
+		ModTime: modTime,
+	}); err != nil {
+		return err
+	}
+	_, err := tw.Write(data)
+	return err
+}
+
+// ReadArchive decodes an archive written by WriteArchive and verifies the checksum of every node file.
+// ReadArchive 解码由 WriteArchive 写出的归档，并校验每个节点文件的校验和。
+func ReadArchive(r io.Reader) (*Snapshot, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("open backup archive: %w", err)
+	}
+	defer gz.Close()
+
+	entries := make(map[string][]byte)
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read backup archive: %w", err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		if header.Size > maxArchiveEntrySize {
+			return nil, fmt.Errorf("backup archive entry %s is too large", header.Name)
+		}
+		data, err := io.ReadAll(io.LimitReader(tr, maxArchiveEntrySize))
+		if err != nil {
+			return nil, fmt.Errorf("read backup archive entry %s: %w", header.Name, err)
+		}
+		entries[header.Name] = data
+	}
+
+	snapshot := &Snapshot{NodeFiles: make(map[uint]map[appconfig.ConfigType]string)}
+	if err := decodeEntry(entries, manifestEntry, &snapshot.Manifest); err != nil {
+		return nil, err
+	}
+	if snapshot.Manifest.FormatVersion > ArchiveFormatVersion {
+		return nil, fmt.Errorf("backup archive format %d is newer than supported format %d", snapshot.Manifest.FormatVersion, ArchiveFormatVersion)
+	}
+	if err := decodeEntry(entries, clusterEntry, &snapshot.Cluster); err != nil {
+		return nil, err
+	}
+	if err := decodeEntry(entries, pluginsEntry, &snapshot.Plugins); err != nil {
+		return nil, err
+	}
+	if err := decodeEntry(entries, configsEntry, &snapshot.Configs); err != nil {
+		return nil, err
+	}
+
+	for _, file := range snapshot.Manifest.Files {
+		data, ok := entries[file.Path]
+		if !ok {
+			return nil, fmt.Errorf("backup archive is missing %s", file.Path)
+		}
+		sum := sha256.Sum256(data)
+		if hex.EncodeToString(sum[:]) != file.SHA256 {
+			return nil, fmt.Errorf("checksum mismatch for %s", file.Path)
+		}
+		if snapshot.NodeFiles[file.HostID] == nil {
+			snapshot.NodeFiles[file.HostID] = make(map[appconfig.ConfigType]string)
+		}
+		snapshot.NodeFiles[file.HostID][file.ConfigType] = string(data)
+	}
+	return snapshot, nil
+}
+
+func decodeEntry(entries map[string][]byte, name string, target interface{}) error {
+	data, ok := entries[name]
+	if !ok {
+		return fmt.Errorf("backup archive is missing %s", name)
+	}
+	if err := json.Unmarshal(data, target); err != nil {
+		return fmt.Errorf("decode %s: %w", name, err)
+	}
+	return nil
+}
+
+func sortedHostIDs(files map[uint]map[appconfig.ConfigType]string) []uint {
+	hostIDs := make([]uint, 0, len(files))
+	for hostID := range files {
+		hostIDs = append(hostIDs, hostID)
+	}
+	sort.Slice(hostIDs, func(i, j int) bool { return hostIDs[i] < hostIDs[j] })
+	return hostIDs
+}
+
+func sortedConfigTypes(files map[appconfig.ConfigType]string) []appconfig.ConfigType {
+	types := make([]appconfig.ConfigType, 0, len(files))
+	for configType := range files {
+		types = append(types, configType)
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i] < types[j] })
+	return types
+}
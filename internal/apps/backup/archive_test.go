@@ -0,0 +1,117 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package backup
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/seatunnel/seatunnelX/internal/apps/cluster"
+	appconfig "github.com/seatunnel/seatunnelX/internal/apps/config"
+	"github.com/seatunnel/seatunnelX/internal/apps/plugin"
+)
+
+func testSnapshot() *Snapshot {
+	hostID := uint(11)
+	return &Snapshot{
+		Manifest: Manifest{BackupID: 3, ClusterID: 1, ClusterName: "prod", DeploymentMode: "hybrid", CreatedAt: time.Unix(1700000000, 0).UTC()},
+		Cluster: &cluster.Cluster{ID: 1, Name: "prod", DeploymentMode: cluster.DeploymentModeHybrid, Nodes: []cluster.ClusterNode{
+			{ID: 5, ClusterID: 1, HostID: hostID, Role: cluster.NodeRoleMasterWorker, InstallDir: "/opt/seatunnel"},
+		}},
+		Plugins: []plugin.InstalledPlugin{{ID: 2, ClusterID: 1, PluginName: "jdbc", Version: "2.3.8"}},
+		Configs: []*appconfig.ConfigInfo{
+			{ID: 8, ClusterID: 1, ConfigType: appconfig.ConfigTypeSeatunnel, Content: "seatunnel: {}\n", IsTemplate: true},
+			{ID: 9, ClusterID: 1, HostID: &hostID, ConfigType: appconfig.ConfigTypeSeatunnel, Content: "seatunnel: {}\n"},
+		},
+		NodeFiles: map[uint]map[appconfig.ConfigType]string{
+			hostID: {
+				appconfig.ConfigTypeSeatunnel:  "seatunnel:\n  engine: {}\n",
+				appconfig.ConfigTypeJVMOptions: "-Xms2g\n-Xmx2g\n",
+			},
+		},
+	}
+}
+
+func TestArchiveRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	snapshot := testSnapshot()
+	if err := WriteArchive(&buf, snapshot); err != nil {
+		t.Fatalf("WriteArchive returned error: %v", err)
+	}
+	if len(snapshot.Manifest.Files) != 2 || snapshot.Manifest.Files[0].Path != "nodes/11/config/jvm_options" {
+		t.Fatalf("unexpected manifest files: %+v", snapshot.Manifest.Files)
+	}
+
+	decoded, err := ReadArchive(&buf)
+	if err != nil {
+		t.Fatalf("ReadArchive returned error: %v", err)
+	}
+	if decoded.Manifest.FormatVersion != ArchiveFormatVersion || decoded.Manifest.ClusterName != "prod" {
+		t.Fatalf("unexpected manifest: %+v", decoded.Manifest)
+	}
+	if decoded.Cluster == nil || len(decoded.Cluster.Nodes) != 1 || decoded.Cluster.Nodes[0].InstallDir != "/opt/seatunnel" {
+		t.Fatalf("unexpected cluster record: %+v", decoded.Cluster)
+	}
+	if len(decoded.Plugins) != 1 || decoded.Plugins[0].PluginName != "jdbc" {
+		t.Fatalf("unexpected plugins: %+v", decoded.Plugins)
+	}
+	if len(decoded.Configs) != 2 || decoded.Configs[1].HostID == nil || *decoded.Configs[1].HostID != 11 {
+		t.Fatalf("unexpected configs: %+v", decoded.Configs)
+	}
+	if decoded.NodeFiles[11][appconfig.ConfigTypeJVMOptions] != "-Xms2g\n-Xmx2g\n" {
+		t.Fatalf("unexpected node files: %+v", decoded.NodeFiles)
+	}
+}
+
+func TestReadArchiveRejectsChecksumMismatch(t *testing.T) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	manifest := Manifest{FormatVersion: ArchiveFormatVersion, ClusterID: 1, Files: []ManifestFile{{
+		HostID:     11,
+		ConfigType: appconfig.ConfigTypeLog4j2,
+		Path:       nodeFilePath(11, appconfig.ConfigTypeLog4j2),
+		SHA256:     strings.Repeat("0", 64),
+	}}}
+	entries := map[string]interface{}{
+		manifestEntry: manifest,
+		clusterEntry:  &cluster.Cluster{ID: 1},
+		pluginsEntry:  []plugin.InstalledPlugin{},
+		configsEntry:  []*appconfig.ConfigInfo{},
+	}
+	for name, value := range entries {
+		data, _ := json.Marshal(value)
+		if err := writeTarEntry(tw, name, data, time.Now()); err != nil {
+			t.Fatalf("write entry: %v", err)
+		}
+	}
+	if err := writeTarEntry(tw, manifest.Files[0].Path, []byte("rootLogger.level = INFO\n"), time.Now()); err != nil {
+		t.Fatalf("write entry: %v", err)
+	}
+	_ = tw.Close()
+	_ = gz.Close()
+
+	if _, err := ReadArchive(&buf); err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
+		t.Fatalf("expected checksum mismatch, got %v", err)
+	}
+}
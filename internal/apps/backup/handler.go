@@ -0,0 +1,254 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package backup
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/seatunnel/seatunnelX/internal/apps/audit"
+	"github.com/seatunnel/seatunnelX/internal/apps/auth"
+	"github.com/seatunnel/seatunnelX/internal/apps/cluster"
+	appconfig "github.com/seatunnel/seatunnelX/internal/apps/config"
+	"github.com/seatunnel/seatunnelX/internal/apps/response"
+)
+
+// Handler serves the cluster backup API.
+// Handler 提供集群备份 API。
+type Handler struct {
+	service   *Service
+	auditRepo *audit.Repository
+}
+
+// NewHandler creates a backup handler; auditRepo may be nil, in which case audit logging is skipped.
+// NewHandler 创建备份处理器；auditRepo 可为 nil，此时不记录审计日志。
+func NewHandler(service *Service, auditRepo *audit.Repository) *Handler {
+	return &Handler{service: service, auditRepo: auditRepo}
+}
+
+// ListBackups handles GET /api/v1/clusters/:id/backups.
+// ListBackups 处理 GET /api/v1/clusters/:id/backups，返回集群备份列表。
+func (h *Handler) ListBackups(c *gin.Context) {
+	clusterID, ok := parseID(c, "id")
+	if !ok {
+		return
+	}
+	backups, err := h.service.ListBackups(c.Request.Context(), clusterID)
+	if err != nil {
+		response.Error(c, errorStatus(err), err.Error())
+		return
+	}
+	response.OK(c, backups)
+}
+
+// CreateBackup handles POST /api/v1/clusters/:id/backups and starts a backup in the background.
+// CreateBackup 处理 POST /api/v1/clusters/:id/backups，在后台开始一次备份。
+func (h *Handler) CreateBackup(c *gin.Context) {
+	clusterID, ok := parseID(c, "id")
+	if !ok {
+		return
+	}
+	backup, err := h.service.CreateBackup(c.Request.Context(), clusterID, BackupTriggerManual, uint(auth.GetUserIDFromContext(c)))
+	if err != nil {
+		response.Error(c, errorStatus(err), err.Error())
+		return
+	}
+	_ = audit.RecordFromGin(c, h.auditRepo, auth.GetUserIDFromContext(c), auth.GetUsernameFromContext(c),
+		"create", "cluster_backup", audit.UintID(backup.ID), backup.ClusterName, audit.AuditDetails{
+			"cluster_id": clusterID,
+			"storage":    backup.StorageType,
+		})
+	response.JSON(c, http.StatusAccepted, backup)
+}
+
+// GetBackup handles GET /api/v1/clusters/:id/backups/:backupId.
+// GetBackup 处理 GET /api/v1/clusters/:id/backups/:backupId，返回备份详情。
+func (h *Handler) GetBackup(c *gin.Context) {
+	clusterID, ok := parseID(c, "id")
+	if !ok {
+		return
+	}
+	backupID, ok := parseID(c, "backupId")
+	if !ok {
+		return
+	}
+	backup, err := h.service.GetBackup(c.Request.Context(), clusterID, backupID)
+	if err != nil {
+		response.Error(c, errorStatus(err), err.Error())
+		return
+	}
+	response.OK(c, backup)
+}
+
+// DownloadBackup handles GET /api/v1/clusters/:id/backups/:backupId/download and streams the archive.
+// DownloadBackup 处理 GET /api/v1/clusters/:id/backups/:backupId/download，以流方式返回归档。
+func (h *Handler) DownloadBackup(c *gin.Context) {
+	clusterID, ok := parseID(c, "id")
+	if !ok {
+		return
+	}
+	backupID, ok := parseID(c, "backupId")
+	if !ok {
+		return
+	}
+	backup, reader, err := h.service.OpenArchive(c.Request.Context(), clusterID, backupID)
+	if err != nil {
+		response.Error(c, errorStatus(err), err.Error())
+		return
+	}
+	defer reader.Close()
+
+	filename := fmt.Sprintf("%s-backup-%d.tar.gz", backup.ClusterName, backup.ID)
+	c.Header("Content-Type", "application/gzip")
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	c.Header("Content-Length", strconv.FormatInt(backup.SizeBytes, 10))
+	c.Status(http.StatusOK)
+	_, _ = io.Copy(c.Writer, reader)
+}
+
+// DeleteBackup handles DELETE /api/v1/clusters/:id/backups/:backupId.
+// DeleteBackup 处理 DELETE /api/v1/clusters/:id/backups/:backupId，删除备份及其归档。
+func (h *Handler) DeleteBackup(c *gin.Context) {
+	clusterID, ok := parseID(c, "id")
+	if !ok {
+		return
+	}
+	backupID, ok := parseID(c, "backupId")
+	if !ok {
+		return
+	}
+	backup, err := h.service.DeleteBackup(c.Request.Context(), clusterID, backupID)
+	if err != nil {
+		response.Error(c, errorStatus(err), err.Error())
+		return
+	}
+	_ = audit.RecordFromGin(c, h.auditRepo, auth.GetUserIDFromContext(c), auth.GetUsernameFromContext(c),
+		"delete", "cluster_backup", audit.UintID(backup.ID), backup.ClusterName, audit.AuditDetails{
+			"cluster_id": clusterID,
+		})
+	response.OK(c, nil)
+}
+
+// RestoreBackup handles POST /api/v1/clusters/:id/backups/:backupId/restore and replays the saved configs onto the nodes.
+// RestoreBackup 处理 POST /api/v1/clusters/:id/backups/:backupId/restore，将保存的配置回放到节点。
+func (h *Handler) RestoreBackup(c *gin.Context) {
+	clusterID, ok := parseID(c, "id")
+	if !ok {
+		return
+	}
+	backupID, ok := parseID(c, "backupId")
+	if !ok {
+		return
+	}
+	var req RestoreRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			response.Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	result, err := h.service.Restore(c.Request.Context(), clusterID, backupID, &req, uint(auth.GetUserIDFromContext(c)))
+	if err != nil {
+		response.Error(c, errorStatus(err), err.Error())
+		return
+	}
+	_ = audit.RecordFromGin(c, h.auditRepo, auth.GetUserIDFromContext(c), auth.GetUsernameFromContext(c),
+		"restore", "cluster_backup", audit.UintID(backupID), "", audit.AuditDetails{
+			"cluster_id":         clusterID,
+			"restored_templates": len(result.RestoredTemplates),
+			"restored_files":     len(result.RestoredFiles),
+			"errors":             len(result.Errors),
+			"rolling_restart":    req.RollingRestart,
+		})
+	response.OK(c, result)
+}
+
+// GetPolicy handles GET /api/v1/clusters/:id/backup-policy.
+// GetPolicy 处理 GET /api/v1/clusters/:id/backup-policy，返回集群备份策略。
+func (h *Handler) GetPolicy(c *gin.Context) {
+	clusterID, ok := parseID(c, "id")
+	if !ok {
+		return
+	}
+	policy, err := h.service.GetPolicy(c.Request.Context(), clusterID)
+	if err != nil {
+		response.Error(c, errorStatus(err), err.Error())
+		return
+	}
+	response.OK(c, policy)
+}
+
+// UpdatePolicy handles PUT /api/v1/clusters/:id/backup-policy.
+// UpdatePolicy 处理 PUT /api/v1/clusters/:id/backup-policy，更新集群备份策略。
+func (h *Handler) UpdatePolicy(c *gin.Context) {
+	clusterID, ok := parseID(c, "id")
+	if !ok {
+		return
+	}
+	var req UpdatePolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	policy, err := h.service.UpdatePolicy(c.Request.Context(), clusterID, &req, uint(auth.GetUserIDFromContext(c)))
+	if err != nil {
+		response.Error(c, errorStatus(err), err.Error())
+		return
+	}
+	_ = audit.RecordFromGin(c, h.auditRepo, auth.GetUserIDFromContext(c), auth.GetUsernameFromContext(c),
+		"update", "cluster_backup_policy", audit.UintID(clusterID), "", audit.AuditDetails{
+			"enabled":   policy.Enabled,
+			"cron_expr": policy.CronExpr,
+			"timezone":  policy.Timezone,
+			"retention": policy.Retention,
+		})
+	response.OK(c, policy)
+}
+
+func parseID(c *gin.Context, name string) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param(name), 10, 32)
+	if err != nil || id == 0 {
+		response.Error(c, http.StatusBadRequest, "invalid "+name)
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// errorStatus maps service errors to HTTP status codes.
+// errorStatus 将服务错误映射为 HTTP 状态码。
+func errorStatus(err error) int {
+	var validationErr *appconfig.ValidationError
+	switch {
+	case errors.Is(err, ErrBackupNotFound), errors.Is(err, cluster.ErrClusterNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrBackupInProgress), errors.Is(err, ErrBackupNotRestorable):
+		return http.StatusConflict
+	case errors.Is(err, ErrInvalidPolicy), errors.Is(err, appconfig.ErrClusterRestarterUnavailable), errors.As(err, &validationErr):
+		return http.StatusBadRequest
+	case errors.Is(err, ErrStorageUnavailable):
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,199 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package backup snapshots cluster configuration files and Control Plane records into archives
+// and restores the saved configs onto cluster nodes.
+// Package backup 将集群配置文件与控制面记录快照为归档，并可将保存的配置恢复到集群节点。
+package backup
+
+import (
+	"errors"
+	"time"
+
+	appconfig "github.com/seatunnel/seatunnelX/internal/apps/config"
+)
+
+var (
+	// ErrBackupNotFound is returned when a backup does not exist or belongs to another cluster.
+	// ErrBackupNotFound 表示备份不存在或属于其他集群。
+	ErrBackupNotFound = errors.New("backup not found")
+	// ErrBackupInProgress is returned when the cluster already has a running backup.
+	// ErrBackupInProgress 表示集群已有正在执行的备份。
+	ErrBackupInProgress = errors.New("a backup of this cluster is already running")
+	// ErrBackupNotRestorable is returned when restoring a backup that did not complete.
+	// ErrBackupNotRestorable 表示尝试恢复未完成的备份。
+	ErrBackupNotRestorable = errors.New("backup is not completed and cannot be restored")
+	// ErrStorageUnavailable is returned when no backup storage is configured or the backup lives in another storage.
+	// ErrStorageUnavailable 表示未配置备份存储，或备份位于其他存储中。
+	ErrStorageUnavailable = errors.New("backup storage is not available")
+	// ErrInvalidPolicy is returned when a backup policy fails validation.
+	// ErrInvalidPolicy 表示备份策略校验失败。
+	ErrInvalidPolicy = errors.New("invalid backup policy")
+)
+
+// BackupStatus is the state of a backup.
+// BackupStatus 备份状态。
+type BackupStatus string
+
+const (
+	// BackupStatusRunning means the archive is being built.
+	// BackupStatusRunning 表示正在生成归档。
+	BackupStatusRunning BackupStatus = "running"
+	// BackupStatusSucceeded means every node file was saved.
+	// BackupStatusSucceeded 表示所有节点文件均已保存。
+	BackupStatusSucceeded BackupStatus = "succeeded"
+	// BackupStatusPartial means the archive was stored but some node files could not be read.
+	// BackupStatusPartial 表示归档已保存，但部分节点文件读取失败。
+	BackupStatusPartial BackupStatus = "partial"
+	// BackupStatusFailed means no archive was stored.
+	// BackupStatusFailed 表示未能保存归档。
+	BackupStatusFailed BackupStatus = "failed"
+)
+
+// BackupTrigger tells how a backup was started.
+// BackupTrigger 备份触发方式。
+type BackupTrigger string
+
+const (
+	// BackupTriggerManual is a backup started through the API.
+	// BackupTriggerManual 表示通过 API 手动触发。
+	BackupTriggerManual BackupTrigger = "manual"
+	// BackupTriggerScheduled is a backup started by the cluster backup policy.
+	// BackupTriggerScheduled 表示由集群备份策略定时触发。
+	BackupTriggerScheduled BackupTrigger = "scheduled"
+)
+
+// DefaultRetention is the number of scheduled backups kept per cluster when the policy does not set one.
+// DefaultRetention 是策略未设置时每个集群保留的定时备份数量。
+const DefaultRetention = 7
+
+// Backup is one archive of a cluster's configs and Control Plane records.
+// Backup 是一个集群配置与控制面记录的归档。
+type Backup struct {
+	ID          uint          `json:"id" gorm:"primaryKey;autoIncrement"`
+	ClusterID   uint          `json:"cluster_id" gorm:"index;not null"`
+	ClusterName string        `json:"cluster_name" gorm:"size:100"`
+	Trigger     BackupTrigger `json:"trigger" gorm:"column:trigger_type;size:20;not null"`
+	Status      BackupStatus  `json:"status" gorm:"size:20;not null;index"`
+	// StorageType is the backend holding the archive (local or s3).
+	// StorageType 是保存归档的存储类型（local 或 s3）。
+	StorageType string `json:"storage_type" gorm:"size:20"`
+	// Location is the archive key inside the storage.
+	// Location 是归档在存储中的键。
+	Location  string `json:"location" gorm:"size:512"`
+	SizeBytes int64  `json:"size_bytes"`
+	// Checksum is the SHA-256 of the archive.
+	// Checksum 是归档的 SHA-256 校验和。
+	Checksum   string     `json:"checksum" gorm:"size:64"`
+	NodeCount  int        `json:"node_count"`
+	FileCount  int        `json:"file_count"`
+	Error      string     `json:"error,omitempty" gorm:"type:text"`
+	CreatedBy  uint       `json:"created_by"`
+	CreatedAt  time.Time  `json:"created_at" gorm:"autoCreateTime;index"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+}
+
+// TableName specifies the backup table name.
+// TableName 指定备份表名。
+func (Backup) TableName() string {
+	return "cluster_backups"
+}
+
+// Restorable reports whether the backup holds an archive that can be restored.
+// Restorable 返回备份是否包含可恢复的归档。
+func (b *Backup) Restorable() bool {
+	return b.Status == BackupStatusSucceeded || b.Status == BackupStatusPartial
+}
+
+// BackupPolicy schedules backups of one cluster.
+// BackupPolicy 集群的定时备份策略。
+type BackupPolicy struct {
+	ID        uint   `json:"id" gorm:"primaryKey;autoIncrement"`
+	ClusterID uint   `json:"cluster_id" gorm:"uniqueIndex;not null"`
+	Enabled   bool   `json:"enabled" gorm:"not null;default:false"`
+	CronExpr  string `json:"cron_expr" gorm:"size:100"`
+	Timezone  string `json:"timezone" gorm:"size:64"`
+	// Retention is the number of scheduled backups kept; older ones are deleted. Manual backups are never pruned.
+	// Retention 是保留的定时备份数量，更早的会被删除；手动备份不会被清理。
+	Retention int        `json:"retention" gorm:"not null;default:7"`
+	LastRunAt *time.Time `json:"last_run_at,omitempty"`
+	UpdatedBy uint       `json:"updated_by"`
+	CreatedAt time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// TableName specifies the backup policy table name.
+// TableName 指定备份策略表名。
+func (BackupPolicy) TableName() string {
+	return "cluster_backup_policies"
+}
+
+// UpdatePolicyRequest updates the backup policy of a cluster.
+// UpdatePolicyRequest 更新集群备份策略的请求。
+type UpdatePolicyRequest struct {
+	Enabled   bool   `json:"enabled"`
+	CronExpr  string `json:"cron_expr"`
+	Timezone  string `json:"timezone"`
+	Retention int    `json:"retention"`
+}
+
+// RestoreRequest selects what a restore replays onto the cluster.
+// RestoreRequest 指定恢复时回放到集群的内容。
+type RestoreRequest struct {
+	// HostIDs limits the restore to these nodes, empty means every node in the backup.
+	// HostIDs 将恢复限制在这些节点，为空表示备份中的所有节点。
+	HostIDs []uint `json:"host_ids"`
+	// ConfigTypes limits the restore to these config files, empty means all of them.
+	// ConfigTypes 将恢复限制在这些配置文件，为空表示全部。
+	ConfigTypes []appconfig.ConfigType `json:"config_types"`
+	// SkipTemplates keeps the current cluster templates untouched.
+	// SkipTemplates 为 true 时不恢复集群模板。
+	SkipTemplates bool `json:"skip_templates"`
+	// RollingRestart restarts the cluster after every selected file was applied.
+	// RollingRestart 为 true 时在所有选中文件应用成功后滚动重启集群。
+	RollingRestart bool `json:"rolling_restart"`
+}
+
+// RestoredFile is one config file written back to a node.
+// RestoredFile 是写回节点的一个配置文件。
+type RestoredFile struct {
+	HostID     uint                 `json:"host_id"`
+	HostIP     string               `json:"host_ip,omitempty"`
+	ConfigType appconfig.ConfigType `json:"config_type"`
+	// BackupPath is where the node saved the file it replaced.
+	// BackupPath 是节点上被替换文件的备份路径。
+	BackupPath string `json:"backup_path,omitempty"`
+}
+
+// RestoreIssue describes a file that was skipped or failed during a restore.
+// RestoreIssue 描述恢复过程中被跳过或失败的文件。
+type RestoreIssue struct {
+	HostID     uint                 `json:"host_id,omitempty"`
+	ConfigType appconfig.ConfigType `json:"config_type,omitempty"`
+	Message    string               `json:"message"`
+}
+
+// RestoreResult reports the outcome of a restore.
+// RestoreResult 恢复结果。
+type RestoreResult struct {
+	BackupID          uint                          `json:"backup_id"`
+	RestoredTemplates []appconfig.ConfigType        `json:"restored_templates"`
+	RestoredFiles     []*RestoredFile               `json:"restored_files"`
+	Skipped           []*RestoreIssue               `json:"skipped"`
+	Errors            []*RestoreIssue               `json:"errors"`
+	Restart           *appconfig.ApplyRestartResult `json:"restart,omitempty"`
+}
@@ -0,0 +1,147 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package backup
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Repository persists backups and backup policies.
+// Repository 持久化备份与备份策略。
+type Repository struct {
+	db *gorm.DB
+}
+
+// NewRepository creates a backup repository.
+// NewRepository 创建备份仓库。
+func NewRepository(db *gorm.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// Create inserts a backup record.
+// Create 新增备份记录。
+func (r *Repository) Create(ctx context.Context, backup *Backup) error {
+	return r.db.WithContext(ctx).Create(backup).Error
+}
+
+// Update saves a backup record.
+// Update 保存备份记录。
+func (r *Repository) Update(ctx context.Context, backup *Backup) error {
+	return r.db.WithContext(ctx).Save(backup).Error
+}
+
+// Get returns a backup of the cluster.
+// Get 获取集群的某个备份。
+func (r *Repository) Get(ctx context.Context, clusterID, id uint) (*Backup, error) {
+	var backup Backup
+	err := r.db.WithContext(ctx).Where("id = ? AND cluster_id = ?", id, clusterID).First(&backup).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrBackupNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &backup, nil
+}
+
+// ListByCluster returns the backups of a cluster, newest first.
+// ListByCluster 获取集群的备份列表，按时间倒序。
+func (r *Repository) ListByCluster(ctx context.Context, clusterID uint) ([]*Backup, error) {
+	var backups []*Backup
+	err := r.db.WithContext(ctx).
+		Where("cluster_id = ?", clusterID).
+		Order("created_at DESC, id DESC").
+		Find(&backups).Error
+	return backups, err
+}
+
+// ListFinishedScheduled returns the finished scheduled backups of a cluster, newest first.
+// ListFinishedScheduled 获取集群已结束的定时备份，按时间倒序。
+func (r *Repository) ListFinishedScheduled(ctx context.Context, clusterID uint) ([]*Backup, error) {
+	var backups []*Backup
+	err := r.db.WithContext(ctx).
+		Where("cluster_id = ? AND trigger_type = ? AND status <> ?", clusterID, BackupTriggerScheduled, BackupStatusRunning).
+		Order("created_at DESC, id DESC").
+		Find(&backups).Error
+	return backups, err
+}
+
+// HasRunning reports whether the cluster has a running backup.
+// HasRunning 返回集群是否有正在执行的备份。
+func (r *Repository) HasRunning(ctx context.Context, clusterID uint) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&Backup{}).
+		Where("cluster_id = ? AND status = ?", clusterID, BackupStatusRunning).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// FailStale marks running backups created before the cutoff as failed. A backup is bounded by
+// backupTimeout, so one still running past it was interrupted by a restart of its replica.
+// FailStale 将截止时间之前创建且仍在运行的备份标记为失败。备份耗时受 backupTimeout 限制，
+// 超过该时间仍在运行说明其所在副本已重启而中断。
+func (r *Repository) FailStale(ctx context.Context, cutoff time.Time, message string) (int64, error) {
+	result := r.db.WithContext(ctx).Model(&Backup{}).
+		Where("status = ? AND created_at < ?", BackupStatusRunning, cutoff).
+		Updates(map[string]interface{}{"status": BackupStatusFailed, "error": message, "finished_at": time.Now()})
+	return result.RowsAffected, result.Error
+}
+
+// Delete removes a backup record.
+// Delete 删除备份记录。
+func (r *Repository) Delete(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Delete(&Backup{}, id).Error
+}
+
+// GetPolicy returns the backup policy of a cluster, or nil when none was saved.
+// GetPolicy 获取集群备份策略，未保存时返回 nil。
+func (r *Repository) GetPolicy(ctx context.Context, clusterID uint) (*BackupPolicy, error) {
+	var policy BackupPolicy
+	err := r.db.WithContext(ctx).Where("cluster_id = ?", clusterID).First(&policy).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &policy, nil
+}
+
+// SavePolicy inserts or updates a backup policy.
+// SavePolicy 新增或更新备份策略。
+func (r *Repository) SavePolicy(ctx context.Context, policy *BackupPolicy) error {
+	return r.db.WithContext(ctx).Save(policy).Error
+}
+
+// ListEnabledPolicies returns every enabled backup policy.
+// ListEnabledPolicies 获取所有已启用的备份策略。
+func (r *Repository) ListEnabledPolicies(ctx context.Context) ([]*BackupPolicy, error) {
+	var policies []*BackupPolicy
+	err := r.db.WithContext(ctx).Where("enabled = ?", true).Order("cluster_id").Find(&policies).Error
+	return policies, err
+}
+
+// MarkPolicyRun records when a policy last started a backup.
+// MarkPolicyRun 记录策略最近一次触发备份的时间。
+func (r *Repository) MarkPolicyRun(ctx context.Context, policyID uint, at time.Time) error {
+	return r.db.WithContext(ctx).Model(&BackupPolicy{}).Where("id = ?", policyID).Update("last_run_at", at).Error
+}
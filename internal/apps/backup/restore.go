@@ -0,0 +1,164 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package backup
+
+import (
+	"context"
+	"fmt"
+
+	appconfig "github.com/seatunnel/seatunnelX/internal/apps/config"
+	"github.com/seatunnel/seatunnelX/internal/logger"
+)
+
+// Restore replays the configs saved in a backup onto the cluster: templates are stored again and node
+// files are applied through the Agent (each node keeps a copy of the file it replaced).
+// Cluster, node and plugin records in the archive are kept for reference and are not written back.
+// Restore 将备份中保存的配置回放到集群：重新保存模板，并通过 Agent 将节点文件应用到各节点
+// （节点会保留被替换文件的副本）。归档中的集群、节点与插件记录仅供参考，不会写回。
+func (s *Service) Restore(ctx context.Context, clusterID, backupID uint, req *RestoreRequest, userID uint) (*RestoreResult, error) {
+	if req.RollingRestart && s.restarter == nil {
+		return nil, appconfig.ErrClusterRestarterUnavailable
+	}
+	backup, reader, err := s.OpenArchive(ctx, clusterID, backupID)
+	if err != nil {
+		return nil, err
+	}
+	snapshot, err := ReadArchive(reader)
+	reader.Close()
+	if err != nil {
+		return nil, err
+	}
+	if snapshot.Manifest.ClusterID != clusterID {
+		return nil, fmt.Errorf("backup archive belongs to cluster %d", snapshot.Manifest.ClusterID)
+	}
+	clusterRecord, err := s.clusters.GetByID(ctx, clusterID, true)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &RestoreResult{
+		BackupID:          backup.ID,
+		RestoredTemplates: make([]appconfig.ConfigType, 0),
+		RestoredFiles:     make([]*RestoredFile, 0),
+		Skipped:           make([]*RestoreIssue, 0),
+		Errors:            make([]*RestoreIssue, 0),
+	}
+	comment := fmt.Sprintf("Restored from backup #%d", backup.ID)
+	typeSelected := selectionSet(req.ConfigTypes)
+	hostSelected := selectionSet(req.HostIDs)
+
+	// Stored node configs back up node files that could not be read when the backup was taken.
+	// 备份时读取失败的节点文件，使用控制面保存的节点配置兜底。
+	nodeFiles := make(map[uint]map[appconfig.ConfigType]string, len(snapshot.NodeFiles))
+	for hostID, files := range snapshot.NodeFiles {
+		nodeFiles[hostID] = files
+	}
+	templates := make(map[appconfig.ConfigType]string)
+	for _, stored := range snapshot.Configs {
+		if stored.HostID == nil {
+			templates[stored.ConfigType] = stored.Content
+			continue
+		}
+		if nodeFiles[*stored.HostID] == nil {
+			nodeFiles[*stored.HostID] = make(map[appconfig.ConfigType]string)
+		}
+		if _, ok := nodeFiles[*stored.HostID][stored.ConfigType]; !ok {
+			nodeFiles[*stored.HostID][stored.ConfigType] = stored.Content
+		}
+	}
+
+	if !req.SkipTemplates {
+		for _, configType := range sortedConfigTypes(templates) {
+			if !typeSelected(configType) {
+				continue
+			}
+			if _, err := s.configs.RestoreConfig(ctx, clusterID, nil, configType, templates[configType], comment, userID); err != nil {
+				result.Errors = append(result.Errors, &RestoreIssue{ConfigType: configType, Message: "恢复模板失败: " + err.Error()})
+				continue
+			}
+			result.RestoredTemplates = append(result.RestoredTemplates, configType)
+		}
+	}
+
+	currentHosts := make(map[uint]bool)
+	for _, hostID := range clusterHostIDs(clusterRecord) {
+		currentHosts[hostID] = true
+	}
+	for _, hostID := range req.HostIDs {
+		if _, ok := nodeFiles[hostID]; !ok {
+			result.Skipped = append(result.Skipped, &RestoreIssue{HostID: hostID, Message: "host is not in the backup"})
+		}
+	}
+	for _, hostID := range sortedHostIDs(nodeFiles) {
+		if !hostSelected(hostID) {
+			continue
+		}
+		if !currentHosts[hostID] {
+			result.Skipped = append(result.Skipped, &RestoreIssue{HostID: hostID, Message: "host is no longer a node of the cluster"})
+			continue
+		}
+		files := nodeFiles[hostID]
+		for _, configType := range sortedConfigTypes(files) {
+			if !typeSelected(configType) {
+				continue
+			}
+			id := hostID
+			applied, err := s.configs.RestoreConfig(ctx, clusterID, &id, configType, files[configType], comment, userID)
+			if err != nil {
+				result.Errors = append(result.Errors, &RestoreIssue{HostID: hostID, ConfigType: configType, Message: err.Error()})
+				continue
+			}
+			restored := &RestoredFile{HostID: hostID, ConfigType: configType}
+			if applied != nil {
+				restored.HostIP = applied.HostIP
+				restored.BackupPath = applied.BackupPath
+			}
+			result.RestoredFiles = append(result.RestoredFiles, restored)
+		}
+	}
+
+	if !req.RollingRestart {
+		return result, nil
+	}
+	if len(result.Errors) > 0 || len(result.RestoredFiles) == 0 {
+		// Restarting with partially restored configs could split the cluster.
+		// 部分配置未恢复时重启可能导致集群分裂。
+		result.Restart = &appconfig.ApplyRestartResult{Message: "rolling restart skipped because no file was restored or some files failed"}
+		return result, nil
+	}
+	success, message, err := s.restarter.RollingRestartCluster(ctx, clusterID)
+	if err != nil {
+		logger.WarnF(ctx, "[Backup] rolling restart after restore failed: cluster=%d, err=%v", clusterID, err)
+		message = err.Error()
+	}
+	result.Restart = &appconfig.ApplyRestartResult{Triggered: true, Success: success && err == nil, Message: message}
+	return result, nil
+}
+
+// selectionSet returns a predicate matching the given values, or everything when values is empty.
+// selectionSet 返回匹配给定值的判断函数，values 为空时匹配全部。
+func selectionSet[T comparable](values []T) func(T) bool {
+	if len(values) == 0 {
+		return func(T) bool { return true }
+	}
+	set := make(map[T]bool, len(values))
+	for _, value := range values {
+		set[value] = true
+	}
+	return func(value T) bool { return set[value] }
+}
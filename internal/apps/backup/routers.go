@@ -0,0 +1,33 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package backup
+
+import "github.com/gin-gonic/gin"
+
+// RegisterRoutes registers the backup routes on the cluster router group.
+// RegisterRoutes 在集群路由组上注册备份路由。
+func RegisterRoutes(clusters *gin.RouterGroup, handler *Handler) {
+	clusters.GET("/:id/backups", handler.ListBackups)
+	clusters.POST("/:id/backups", handler.CreateBackup)
+	clusters.GET("/:id/backups/:backupId", handler.GetBackup)
+	clusters.GET("/:id/backups/:backupId/download", handler.DownloadBackup)
+	clusters.DELETE("/:id/backups/:backupId", handler.DeleteBackup)
+	clusters.POST("/:id/backups/:backupId/restore", handler.RestoreBackup)
+	clusters.GET("/:id/backup-policy", handler.GetPolicy)
+	clusters.PUT("/:id/backup-policy", handler.UpdatePolicy)
+}
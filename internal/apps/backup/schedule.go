@@ -0,0 +1,169 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package backup
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/seatunnel/seatunnelX/internal/logger"
+	"github.com/seatunnel/seatunnelX/internal/pkg/leader"
+	"github.com/seatunnel/seatunnelX/internal/pkg/schedulex"
+	"github.com/seatunnel/seatunnelX/internal/pkg/worker"
+)
+
+// GetPolicy returns the backup policy of a cluster, or a disabled default when none was saved.
+// GetPolicy 获取集群备份策略，未保存时返回禁用的默认策略。
+func (s *Service) GetPolicy(ctx context.Context, clusterID uint) (*BackupPolicy, error) {
+	policy, err := s.repo.GetPolicy(ctx, clusterID)
+	if err != nil {
+		return nil, err
+	}
+	if policy == nil {
+		policy = &BackupPolicy{
+			ClusterID: clusterID,
+			Timezone:  schedulex.DefaultTimezone,
+			Retention: DefaultRetention,
+		}
+	}
+	return policy, nil
+}
+
+// UpdatePolicy validates and saves the backup policy of a cluster.
+// UpdatePolicy 校验并保存集群备份策略。
+func (s *Service) UpdatePolicy(ctx context.Context, clusterID uint, req *UpdatePolicyRequest, userID uint) (*BackupPolicy, error) {
+	if _, err := s.clusters.GetByID(ctx, clusterID, false); err != nil {
+		return nil, err
+	}
+	cronExpr := strings.TrimSpace(req.CronExpr)
+	if req.Enabled || cronExpr != "" {
+		if err := schedulex.Validate(cronExpr); err != nil {
+			return nil, fmt.Errorf("%w: invalid cron expression: %v", ErrInvalidPolicy, err)
+		}
+	}
+	timezone := schedulex.NormalizeTimezone(req.Timezone)
+	if _, err := schedulex.LoadLocation(timezone); err != nil {
+		return nil, fmt.Errorf("%w: invalid timezone: %v", ErrInvalidPolicy, err)
+	}
+	if req.Retention < 0 {
+		return nil, fmt.Errorf("%w: retention must not be negative", ErrInvalidPolicy)
+	}
+
+	policy, err := s.GetPolicy(ctx, clusterID)
+	if err != nil {
+		return nil, err
+	}
+	policy.Enabled = req.Enabled
+	policy.CronExpr = cronExpr
+	policy.Timezone = timezone
+	policy.Retention = req.Retention
+	if policy.Retention == 0 {
+		policy.Retention = DefaultRetention
+	}
+	policy.UpdatedBy = userID
+	if err := s.repo.SavePolicy(ctx, policy); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// StartScheduler starts the loop that runs scheduled backups and fails interrupted ones on the leader replica (only once).
+// StartScheduler 启动定时备份循环，并在 leader 副本上将中断的备份标记为失败（仅启动一次）。
+func (s *Service) StartScheduler(ctx context.Context) {
+	if s == nil || s.repo == nil {
+		return
+	}
+	s.schedulerOnce.Do(func() {
+		go func() {
+			defer worker.Start("backup-scheduler")()
+
+			ticker := time.NewTicker(time.Minute)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case now := <-ticker.C:
+					if leader.IsLeader() {
+						s.failInterruptedBackups(ctx, now)
+						s.runScheduledBackups(ctx, now)
+					}
+				}
+			}
+		}()
+	})
+}
+
+// failInterruptedBackups marks backups that outlived backupTimeout as failed.
+// failInterruptedBackups 将超过 backupTimeout 仍未结束的备份标记为失败。
+func (s *Service) failInterruptedBackups(ctx context.Context, now time.Time) {
+	count, err := s.repo.FailStale(ctx, now.Add(-backupTimeout-time.Minute), "interrupted by Control Plane restart")
+	if err != nil {
+		logger.WarnF(ctx, "[Backup] fail interrupted backups failed: %v", err)
+		return
+	}
+	if count > 0 {
+		logger.InfoF(ctx, "[Backup] marked %d interrupted backups as failed", count)
+	}
+}
+
+// runScheduledBackups starts a backup for every enabled policy whose cron matches the current minute.
+// runScheduledBackups 为 cron 匹配当前分钟的每个已启用策略触发备份。
+func (s *Service) runScheduledBackups(ctx context.Context, now time.Time) {
+	policies, err := s.repo.ListEnabledPolicies(ctx)
+	if err != nil {
+		logger.WarnF(ctx, "[Backup] list backup policies failed: %v", err)
+		return
+	}
+	for _, policy := range policies {
+		matched, windowStart, _, err := schedulex.MatchMinuteWindow(policy.CronExpr, now, policy.Timezone)
+		if err != nil {
+			logger.WarnF(ctx, "[Backup] backup policy ignored: cluster=%d, err=%v", policy.ClusterID, err)
+			continue
+		}
+		if !matched || (policy.LastRunAt != nil && !policy.LastRunAt.Before(windowStart)) {
+			continue
+		}
+		if hosts := s.maintenanceHosts(ctx, policy.ClusterID); len(hosts) > 0 {
+			logger.InfoF(ctx, "[Backup] scheduled backup paused: cluster=%d, hosts under maintenance=%s", policy.ClusterID, strings.Join(hosts, ", "))
+			continue
+		}
+		if err := s.repo.MarkPolicyRun(ctx, policy.ID, now); err != nil {
+			logger.WarnF(ctx, "[Backup] mark backup policy run failed: cluster=%d, err=%v", policy.ClusterID, err)
+			continue
+		}
+		if _, err := s.CreateBackup(ctx, policy.ClusterID, BackupTriggerScheduled, 0); err != nil {
+			logger.WarnF(ctx, "[Backup] scheduled backup not started: cluster=%d, err=%v", policy.ClusterID, err)
+		}
+	}
+}
+
+// maintenanceHosts returns the hosts of the cluster that are in maintenance mode, if any.
+// maintenanceHosts 返回集群中处于维护模式的主机（如有）。
+func (s *Service) maintenanceHosts(ctx context.Context, clusterID uint) []string {
+	if s.maintenance == nil {
+		return nil
+	}
+	hosts, err := s.maintenance.MaintenanceHostNames(ctx, clusterID)
+	if err != nil {
+		return nil
+	}
+	return hosts
+}
@@ -0,0 +1,395 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package backup
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/seatunnel/seatunnelX/internal/apps/cluster"
+	appconfig "github.com/seatunnel/seatunnelX/internal/apps/config"
+	"github.com/seatunnel/seatunnelX/internal/apps/plugin"
+	"github.com/seatunnel/seatunnelX/internal/logger"
+)
+
+// backupTimeout bounds a single backup, including reading every node file.
+// backupTimeout 限制单次备份的耗时，包括读取所有节点文件。
+const backupTimeout = 30 * time.Minute
+
+// ClusterSource reads cluster records with their nodes.
+// ClusterSource 读取包含节点的集群记录。
+type ClusterSource interface {
+	GetByID(ctx context.Context, id uint, preloadNodes bool) (*cluster.Cluster, error)
+}
+
+// PluginSource reads the plugins installed on a cluster.
+// PluginSource 读取集群已安装的插件。
+type PluginSource interface {
+	ListByCluster(ctx context.Context, clusterID uint) ([]plugin.InstalledPlugin, error)
+}
+
+// ConfigStore reads stored and live cluster configs and writes restored ones back.
+// ConfigStore 读取已保存与节点上的集群配置，并写回恢复的配置。
+type ConfigStore interface {
+	GetByCluster(ctx context.Context, clusterID uint) ([]*appconfig.ConfigInfo, error)
+	PullNodeConfig(ctx context.Context, clusterID uint, hostID uint, configType appconfig.ConfigType) (string, error)
+	RestoreConfig(ctx context.Context, clusterID uint, hostID *uint, configType appconfig.ConfigType, content, comment string, userID uint) (*appconfig.AppliedNode, error)
+}
+
+// MaintenanceChecker reports the hosts of a cluster that are in maintenance mode.
+// MaintenanceChecker 返回集群中处于维护模式的主机。
+type MaintenanceChecker interface {
+	MaintenanceHostNames(ctx context.Context, clusterID uint) ([]string, error)
+}
+
+// Service takes, stores, schedules and restores cluster backups.
+// Service 负责集群备份的生成、存储、定时调度与恢复。
+type Service struct {
+	repo      *Repository
+	clusters  ClusterSource
+	plugins   PluginSource
+	configs   ConfigStore
+	storage   Storage
+	restarter appconfig.ClusterRestarter
+	// maintenance pauses scheduled backups of clusters with hosts in maintenance (optional)
+	// maintenance 暂停有主机处于维护模式的集群的定时备份（可选）
+	maintenance MaintenanceChecker
+
+	mu      sync.Mutex
+	running map[uint]bool
+	wg      sync.WaitGroup
+
+	schedulerOnce sync.Once
+}
+
+// NewService creates a backup service. storage may be nil, in which case backups fail with ErrStorageUnavailable.
+// NewService 创建备份服务。storage 可为 nil，此时备份会返回 ErrStorageUnavailable。
+func NewService(repo *Repository, clusters ClusterSource, plugins PluginSource, configs ConfigStore, storage Storage) *Service {
+	return &Service{
+		repo:     repo,
+		clusters: clusters,
+		plugins:  plugins,
+		configs:  configs,
+		storage:  storage,
+		running:  make(map[uint]bool),
+	}
+}
+
+// SetClusterRestarter sets the restarter used when a restore asks for a rolling restart.
+// SetClusterRestarter 设置恢复请求滚动重启时使用的集群重启器。
+func (s *Service) SetClusterRestarter(restarter appconfig.ClusterRestarter) {
+	s.restarter = restarter
+}
+
+// SetMaintenanceChecker sets the checker that pauses scheduled backups during host maintenance.
+// SetMaintenanceChecker 设置主机维护期间暂停定时备份的检查器。
+func (s *Service) SetMaintenanceChecker(checker MaintenanceChecker) {
+	s.maintenance = checker
+}
+
+// CreateBackup records a new backup of the cluster and builds its archive in the background.
+// CreateBackup 记录一个新的集群备份，并在后台生成归档。
+func (s *Service) CreateBackup(ctx context.Context, clusterID uint, trigger BackupTrigger, userID uint) (*Backup, error) {
+	if s.storage == nil {
+		return nil, ErrStorageUnavailable
+	}
+	clusterRecord, err := s.clusters.GetByID(ctx, clusterID, false)
+	if err != nil {
+		return nil, err
+	}
+	if !s.acquire(clusterID) {
+		return nil, ErrBackupInProgress
+	}
+	// A backup started on another replica is only visible through the database.
+	// 其他副本发起的备份只能通过数据库感知。
+	if running, err := s.repo.HasRunning(ctx, clusterID); err != nil || running {
+		s.release(clusterID)
+		if err != nil {
+			return nil, err
+		}
+		return nil, ErrBackupInProgress
+	}
+
+	backup := &Backup{
+		ClusterID:   clusterID,
+		ClusterName: clusterRecord.Name,
+		Trigger:     trigger,
+		Status:      BackupStatusRunning,
+		StorageType: s.storage.Type(),
+		CreatedBy:   userID,
+	}
+	if err := s.repo.Create(ctx, backup); err != nil {
+		s.release(clusterID)
+		return nil, err
+	}
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		defer s.release(clusterID)
+		runCtx, cancel := context.WithTimeout(context.Background(), backupTimeout)
+		defer cancel()
+		s.runBackup(runCtx, backup)
+	}()
+	return backup, nil
+}
+
+func (s *Service) acquire(clusterID uint) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.running[clusterID] {
+		return false
+	}
+	s.running[clusterID] = true
+	return true
+}
+
+func (s *Service) release(clusterID uint) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.running, clusterID)
+}
+
+// runBackup collects the snapshot, stores the archive and records the outcome on the backup.
+// runBackup 采集快照、保存归档，并将结果记录到备份上。
+func (s *Service) runBackup(ctx context.Context, backup *Backup) {
+	err := s.buildAndStore(ctx, backup)
+	now := time.Now()
+	backup.FinishedAt = &now
+	if err != nil {
+		backup.Status = BackupStatusFailed
+		backup.Error = err.Error()
+		logger.WarnF(ctx, "[Backup] backup failed: cluster=%d, backup=%d, err=%v", backup.ClusterID, backup.ID, err)
+	}
+	if err := s.repo.Update(context.Background(), backup); err != nil {
+		logger.WarnF(ctx, "[Backup] save backup result failed: backup=%d, err=%v", backup.ID, err)
+	}
+	if backup.Trigger == BackupTriggerScheduled {
+		s.pruneScheduled(context.Background(), backup.ClusterID)
+	}
+}
+
+func (s *Service) buildAndStore(ctx context.Context, backup *Backup) error {
+	snapshot, err := s.collect(ctx, backup)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp("", "seatunnelx-backup-*.tar.gz")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	hash := sha256.New()
+	if err := WriteArchive(io.MultiWriter(tmp, hash), snapshot); err != nil {
+		return fmt.Errorf("write archive: %w", err)
+	}
+	size, err := tmp.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	key := fmt.Sprintf("cluster-%d/%s-%d.tar.gz", backup.ClusterID, backup.CreatedAt.UTC().Format("20060102T150405Z"), backup.ID)
+	if err := s.storage.Put(ctx, key, tmp, size); err != nil {
+		return fmt.Errorf("store archive: %w", err)
+	}
+
+	backup.Location = key
+	backup.SizeBytes = size
+	backup.Checksum = hex.EncodeToString(hash.Sum(nil))
+	backup.FileCount = len(snapshot.Manifest.Files)
+	backup.Status = BackupStatusSucceeded
+	if len(snapshot.Manifest.Errors) > 0 {
+		backup.Status = BackupStatusPartial
+		backup.Error = strings.Join(snapshot.Manifest.Errors, "; ")
+	}
+	return nil
+}
+
+// collect reads the Control Plane records and pulls every config file of every node.
+// A node file that cannot be read is recorded in the manifest instead of failing the backup.
+// collect 读取控制面记录并拉取每个节点的所有配置文件。
+// 读取失败的节点文件会记录到清单中，而不会使整个备份失败。
+func (s *Service) collect(ctx context.Context, backup *Backup) (*Snapshot, error) {
+	clusterRecord, err := s.clusters.GetByID(ctx, backup.ClusterID, true)
+	if err != nil {
+		return nil, err
+	}
+	plugins, err := s.plugins.ListByCluster(ctx, backup.ClusterID)
+	if err != nil {
+		return nil, fmt.Errorf("list plugins: %w", err)
+	}
+	configs, err := s.configs.GetByCluster(ctx, backup.ClusterID)
+	if err != nil {
+		return nil, fmt.Errorf("list configs: %w", err)
+	}
+
+	snapshot := &Snapshot{
+		Manifest: Manifest{
+			BackupID:       backup.ID,
+			ClusterID:      clusterRecord.ID,
+			ClusterName:    clusterRecord.Name,
+			DeploymentMode: string(clusterRecord.DeploymentMode),
+			Version:        clusterRecord.Version,
+			CreatedAt:      backup.CreatedAt,
+		},
+		Cluster:   clusterRecord,
+		Plugins:   plugins,
+		Configs:   configs,
+		NodeFiles: make(map[uint]map[appconfig.ConfigType]string),
+	}
+
+	configTypes := appconfig.GetConfigTypesForMode(string(clusterRecord.DeploymentMode))
+	for _, hostID := range clusterHostIDs(clusterRecord) {
+		files := make(map[appconfig.ConfigType]string, len(configTypes))
+		for _, configType := range configTypes {
+			content, err := s.configs.PullNodeConfig(ctx, backup.ClusterID, hostID, configType)
+			if err != nil {
+				snapshot.Manifest.Errors = append(snapshot.Manifest.Errors, fmt.Sprintf("host %d %s: %v", hostID, configType, err))
+				continue
+			}
+			files[configType] = content
+		}
+		if len(files) > 0 {
+			snapshot.NodeFiles[hostID] = files
+		}
+	}
+	backup.NodeCount = len(snapshot.NodeFiles)
+	return snapshot, nil
+}
+
+// clusterHostIDs returns the distinct hosts of the cluster nodes in node order.
+// clusterHostIDs 按节点顺序返回集群节点所在的不重复主机。
+func clusterHostIDs(clusterRecord *cluster.Cluster) []uint {
+	seen := make(map[uint]bool, len(clusterRecord.Nodes))
+	hostIDs := make([]uint, 0, len(clusterRecord.Nodes))
+	for _, node := range clusterRecord.Nodes {
+		if seen[node.HostID] {
+			continue
+		}
+		seen[node.HostID] = true
+		hostIDs = append(hostIDs, node.HostID)
+	}
+	return hostIDs
+}
+
+// ListBackups returns the backups of a cluster, newest first.
+// ListBackups 获取集群的备份列表，按时间倒序。
+func (s *Service) ListBackups(ctx context.Context, clusterID uint) ([]*Backup, error) {
+	return s.repo.ListByCluster(ctx, clusterID)
+}
+
+// GetBackup returns one backup of a cluster.
+// GetBackup 获取集群的某个备份。
+func (s *Service) GetBackup(ctx context.Context, clusterID, backupID uint) (*Backup, error) {
+	return s.repo.Get(ctx, clusterID, backupID)
+}
+
+// OpenArchive opens the archive of a completed backup for download.
+// OpenArchive 打开已完成备份的归档以供下载。
+func (s *Service) OpenArchive(ctx context.Context, clusterID, backupID uint) (*Backup, io.ReadCloser, error) {
+	backup, err := s.repo.Get(ctx, clusterID, backupID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !backup.Restorable() {
+		return nil, nil, ErrBackupNotRestorable
+	}
+	if err := s.checkStorage(backup); err != nil {
+		return nil, nil, err
+	}
+	reader, err := s.storage.Open(ctx, backup.Location)
+	if err != nil {
+		return nil, nil, fmt.Errorf("open archive: %w", err)
+	}
+	return backup, reader, nil
+}
+
+// DeleteBackup removes a backup and its archive.
+// DeleteBackup 删除备份及其归档。
+func (s *Service) DeleteBackup(ctx context.Context, clusterID, backupID uint) (*Backup, error) {
+	backup, err := s.repo.Get(ctx, clusterID, backupID)
+	if err != nil {
+		return nil, err
+	}
+	if backup.Status == BackupStatusRunning {
+		return nil, ErrBackupInProgress
+	}
+	if err := s.deleteBackup(ctx, backup); err != nil {
+		return nil, err
+	}
+	return backup, nil
+}
+
+func (s *Service) deleteBackup(ctx context.Context, backup *Backup) error {
+	if backup.Location != "" {
+		if err := s.checkStorage(backup); err != nil {
+			return err
+		}
+		if err := s.storage.Delete(ctx, backup.Location); err != nil {
+			return fmt.Errorf("delete archive: %w", err)
+		}
+	}
+	return s.repo.Delete(ctx, backup.ID)
+}
+
+// checkStorage verifies that the archive of the backup lives in the configured storage.
+// checkStorage 校验备份归档位于当前配置的存储中。
+func (s *Service) checkStorage(backup *Backup) error {
+	if s.storage == nil {
+		return ErrStorageUnavailable
+	}
+	if backup.StorageType != s.storage.Type() {
+		return fmt.Errorf("%w: backup is stored in %s storage but %s storage is configured", ErrStorageUnavailable, backup.StorageType, s.storage.Type())
+	}
+	return nil
+}
+
+// pruneScheduled deletes the scheduled backups of a cluster beyond the policy retention.
+// pruneScheduled 删除超出策略保留数量的集群定时备份。
+func (s *Service) pruneScheduled(ctx context.Context, clusterID uint) {
+	retention := DefaultRetention
+	if policy, err := s.repo.GetPolicy(ctx, clusterID); err == nil && policy != nil && policy.Retention > 0 {
+		retention = policy.Retention
+	}
+	backups, err := s.repo.ListFinishedScheduled(ctx, clusterID)
+	if err != nil {
+		logger.WarnF(ctx, "[Backup] list scheduled backups failed: cluster=%d, err=%v", clusterID, err)
+		return
+	}
+	if len(backups) <= retention {
+		return
+	}
+	for _, backup := range backups[retention:] {
+		if err := s.deleteBackup(ctx, backup); err != nil {
+			logger.WarnF(ctx, "[Backup] prune backup failed: cluster=%d, backup=%d, err=%v", clusterID, backup.ID, err)
+		}
+	}
+}
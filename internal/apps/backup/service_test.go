@@ -0,0 +1,281 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package backup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/seatunnel/seatunnelX/internal/apps/cluster"
+	appconfig "github.com/seatunnel/seatunnelX/internal/apps/config"
+	"github.com/seatunnel/seatunnelX/internal/apps/plugin"
+)
+
+type fakeClusters struct {
+	cluster *cluster.Cluster
+}
+
+func (f *fakeClusters) GetByID(_ context.Context, id uint, _ bool) (*cluster.Cluster, error) {
+	if f.cluster == nil || f.cluster.ID != id {
+		return nil, cluster.ErrClusterNotFound
+	}
+	copied := *f.cluster
+	return &copied, nil
+}
+
+type fakePlugins struct{}
+
+func (fakePlugins) ListByCluster(_ context.Context, clusterID uint) ([]plugin.InstalledPlugin, error) {
+	return []plugin.InstalledPlugin{{ID: 1, ClusterID: clusterID, PluginName: "jdbc", Version: "2.3.8"}}, nil
+}
+
+// fakeConfigs keeps node files keyed by "host/type"; a missing key fails the pull.
+// fakeConfigs 以 "host/type" 为键保存节点文件，键不存在时拉取失败。
+type fakeConfigs struct {
+	files    map[string]string
+	stored   []*appconfig.ConfigInfo
+	restored []string
+}
+
+func (f *fakeConfigs) GetByCluster(_ context.Context, _ uint) ([]*appconfig.ConfigInfo, error) {
+	return f.stored, nil
+}
+
+func (f *fakeConfigs) PullNodeConfig(_ context.Context, _ uint, hostID uint, configType appconfig.ConfigType) (string, error) {
+	content, ok := f.files[fmt.Sprintf("%d/%s", hostID, configType)]
+	if !ok {
+		return "", errors.New("agent not connected")
+	}
+	return content, nil
+}
+
+func (f *fakeConfigs) RestoreConfig(_ context.Context, _ uint, hostID *uint, configType appconfig.ConfigType, content, _ string, _ uint) (*appconfig.AppliedNode, error) {
+	if hostID == nil {
+		f.restored = append(f.restored, "template/"+string(configType))
+		return nil, nil
+	}
+	key := fmt.Sprintf("%d/%s", *hostID, configType)
+	f.files[key] = content
+	f.restored = append(f.restored, key)
+	return &appconfig.AppliedNode{HostID: *hostID, BackupPath: key + ".bak"}, nil
+}
+
+func newTestService(t *testing.T) (*Service, *fakeConfigs, string) {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "backup.db")), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open sqlite db: %v", err)
+	}
+	if err := db.AutoMigrate(&Backup{}, &BackupPolicy{}); err != nil {
+		t.Fatalf("failed to migrate backup models: %v", err)
+	}
+	hostA, hostB := uint(11), uint(12)
+	clusters := &fakeClusters{cluster: &cluster.Cluster{
+		ID:             1,
+		Name:           "prod",
+		DeploymentMode: cluster.DeploymentModeHybrid,
+		Nodes: []cluster.ClusterNode{
+			{ID: 1, ClusterID: 1, HostID: hostA, Role: cluster.NodeRoleMasterWorker},
+			{ID: 2, ClusterID: 1, HostID: hostB, Role: cluster.NodeRoleMasterWorker},
+		},
+	}}
+	configs := &fakeConfigs{files: make(map[string]string)}
+	for _, hostID := range []uint{hostA, hostB} {
+		for _, configType := range appconfig.HybridConfigTypes {
+			configs.files[fmt.Sprintf("%d/%s", hostID, configType)] = fmt.Sprintf("# %s on %d v1\n", configType, hostID)
+		}
+	}
+	// Host B's hazelcast.yaml cannot be read, the stored node config covers it on restore.
+	// 主机 B 的 hazelcast.yaml 无法读取，恢复时使用已保存的节点配置兜底。
+	delete(configs.files, fmt.Sprintf("%d/%s", hostB, appconfig.ConfigTypeHazelcast))
+	configs.stored = []*appconfig.ConfigInfo{
+		{ID: 1, ClusterID: 1, ConfigType: appconfig.ConfigTypeHazelcast, Content: "# template v1\n", IsTemplate: true},
+		{ID: 2, ClusterID: 1, HostID: &hostB, ConfigType: appconfig.ConfigTypeHazelcast, Content: "# stored hazelcast on 12\n"},
+	}
+
+	dir := t.TempDir()
+	service := NewService(NewRepository(db), clusters, fakePlugins{}, configs, NewLocalStorage(dir))
+	return service, configs, dir
+}
+
+func TestCreateBackupAndRestore(t *testing.T) {
+	service, configs, dir := newTestService(t)
+	ctx := context.Background()
+
+	backup, err := service.CreateBackup(ctx, 1, BackupTriggerManual, 7)
+	if err != nil {
+		t.Fatalf("CreateBackup returned error: %v", err)
+	}
+	if backup.Status != BackupStatusRunning {
+		t.Fatalf("expected running backup, got %s", backup.Status)
+	}
+	service.wg.Wait()
+
+	backup, err = service.GetBackup(ctx, 1, backup.ID)
+	if err != nil {
+		t.Fatalf("GetBackup returned error: %v", err)
+	}
+	if backup.Status != BackupStatusPartial || backup.FileCount != 9 || backup.NodeCount != 2 || backup.Error == "" {
+		t.Fatalf("unexpected backup result: %+v", backup)
+	}
+	if info, err := os.Stat(filepath.Join(dir, filepath.FromSlash(backup.Location))); err != nil || info.Size() != backup.SizeBytes {
+		t.Fatalf("expected stored archive of %d bytes, got %v, %v", backup.SizeBytes, info, err)
+	}
+
+	// Drift the nodes, then restore everything.
+	// 修改节点文件后恢复全部配置。
+	for key := range configs.files {
+		configs.files[key] = "# drifted\n"
+	}
+	result, err := service.Restore(ctx, 1, backup.ID, &RestoreRequest{}, 7)
+	if err != nil {
+		t.Fatalf("Restore returned error: %v", err)
+	}
+	if len(result.RestoredTemplates) != 1 || len(result.RestoredFiles) != 10 || len(result.Errors) != 0 {
+		t.Fatalf("unexpected restore result: %+v", result)
+	}
+	if got := configs.files["11/seatunnel.yaml"]; got != "# seatunnel.yaml on 11 v1\n" {
+		t.Fatalf("expected node file restored from the archive, got %q", got)
+	}
+	if got := configs.files["12/hazelcast.yaml"]; got != "# stored hazelcast on 12\n" {
+		t.Fatalf("expected unreadable node file restored from the stored config, got %q", got)
+	}
+
+	configs.restored = nil
+	result, err = service.Restore(ctx, 1, backup.ID, &RestoreRequest{
+		HostIDs:       []uint{12, 99},
+		ConfigTypes:   []appconfig.ConfigType{appconfig.ConfigTypeJVMOptions},
+		SkipTemplates: true,
+	}, 7)
+	if err != nil {
+		t.Fatalf("Restore returned error: %v", err)
+	}
+	if len(configs.restored) != 1 || configs.restored[0] != "12/jvm_options" {
+		t.Fatalf("expected only host 12 jvm_options to be restored, got %v", configs.restored)
+	}
+	if len(result.Skipped) != 1 || result.Skipped[0].HostID != 99 {
+		t.Fatalf("expected host 99 to be skipped, got %+v", result.Skipped)
+	}
+
+	if _, err := service.Restore(ctx, 1, backup.ID, &RestoreRequest{RollingRestart: true}, 7); !errors.Is(err, appconfig.ErrClusterRestarterUnavailable) {
+		t.Fatalf("expected ErrClusterRestarterUnavailable, got %v", err)
+	}
+	if _, err := service.GetBackup(ctx, 2, backup.ID); !errors.Is(err, ErrBackupNotFound) {
+		t.Fatalf("expected backup of another cluster to be hidden, got %v", err)
+	}
+}
+
+func TestScheduledBackupsArePrunedToRetention(t *testing.T) {
+	service, _, dir := newTestService(t)
+	ctx := context.Background()
+
+	if _, err := service.UpdatePolicy(ctx, 1, &UpdatePolicyRequest{Enabled: true, CronExpr: "not a cron"}, 1); !errors.Is(err, ErrInvalidPolicy) {
+		t.Fatalf("expected ErrInvalidPolicy, got %v", err)
+	}
+	if _, err := service.UpdatePolicy(ctx, 1, &UpdatePolicyRequest{Enabled: true, CronExpr: "* * * * *", Timezone: "UTC", Retention: 2}, 1); err != nil {
+		t.Fatalf("UpdatePolicy returned error: %v", err)
+	}
+
+	manual, err := service.CreateBackup(ctx, 1, BackupTriggerManual, 1)
+	if err != nil {
+		t.Fatalf("CreateBackup returned error: %v", err)
+	}
+	service.wg.Wait()
+
+	start := time.Date(2026, 1, 1, 3, 0, 0, 0, time.UTC)
+	for i := 0; i < 3; i++ {
+		service.runScheduledBackups(ctx, start.Add(time.Duration(i)*time.Minute))
+		service.wg.Wait()
+	}
+	// The same minute does not start a second backup.
+	// 同一分钟内不会重复触发备份。
+	service.runScheduledBackups(ctx, start.Add(2*time.Minute+30*time.Second))
+	service.wg.Wait()
+
+	backups, err := service.ListBackups(ctx, 1)
+	if err != nil {
+		t.Fatalf("ListBackups returned error: %v", err)
+	}
+	scheduled := 0
+	for _, backup := range backups {
+		if backup.Trigger == BackupTriggerScheduled {
+			scheduled++
+		}
+	}
+	if len(backups) != 3 || scheduled != 2 {
+		t.Fatalf("expected the manual backup and 2 scheduled backups, got %d (%d scheduled)", len(backups), scheduled)
+	}
+	archives, _ := filepath.Glob(filepath.Join(dir, "cluster-1", "*.tar.gz"))
+	if len(archives) != 3 {
+		t.Fatalf("expected pruned archives to be deleted, got %v", archives)
+	}
+	if _, err := service.GetBackup(ctx, 1, manual.ID); err != nil {
+		t.Fatalf("manual backup should never be pruned: %v", err)
+	}
+
+	stale := &Backup{ClusterID: 1, Trigger: BackupTriggerManual, Status: BackupStatusRunning, CreatedAt: time.Now().Add(-2 * backupTimeout)}
+	if err := service.repo.Create(ctx, stale); err != nil {
+		t.Fatalf("create stale backup: %v", err)
+	}
+	service.failInterruptedBackups(ctx, time.Now())
+	if stale, err = service.GetBackup(ctx, 1, stale.ID); err != nil || stale.Status != BackupStatusFailed {
+		t.Fatalf("expected interrupted backup to be failed, got %+v, %v", stale, err)
+	}
+}
+
+type fakeMaintenance struct {
+	hosts []string
+}
+
+func (f *fakeMaintenance) MaintenanceHostNames(ctx context.Context, clusterID uint) ([]string, error) {
+	return f.hosts, nil
+}
+
+func TestScheduledBackupsPausedDuringHostMaintenance(t *testing.T) {
+	service, _, _ := newTestService(t)
+	ctx := context.Background()
+	maintenance := &fakeMaintenance{hosts: []string{"node-1"}}
+	service.SetMaintenanceChecker(maintenance)
+
+	if _, err := service.UpdatePolicy(ctx, 1, &UpdatePolicyRequest{Enabled: true, CronExpr: "* * * * *", Timezone: "UTC"}, 1); err != nil {
+		t.Fatalf("UpdatePolicy returned error: %v", err)
+	}
+
+	start := time.Date(2026, 1, 1, 3, 0, 0, 0, time.UTC)
+	service.runScheduledBackups(ctx, start)
+	service.wg.Wait()
+	if backups, _ := service.ListBackups(ctx, 1); len(backups) != 0 {
+		t.Fatalf("expected no backup while a host is under maintenance, got %d", len(backups))
+	}
+
+	// The schedule resumes once maintenance ends / 维护结束后恢复调度
+	maintenance.hosts = nil
+	service.runScheduledBackups(ctx, start.Add(time.Minute))
+	service.wg.Wait()
+	if backups, _ := service.ListBackups(ctx, 1); len(backups) != 1 {
+		t.Fatalf("expected the schedule to resume after maintenance, got %d backups", len(backups))
+	}
+}
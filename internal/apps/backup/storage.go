@@ -0,0 +1,224 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package backup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+
+	"github.com/seatunnel/seatunnelX/internal/config"
+)
+
+// Storage keeps backup archives.
+// Storage 保存备份归档。
+type Storage interface {
+	// Type returns the storage type recorded on backups.
+	// Type 返回记录在备份上的存储类型。
+	Type() string
+	// Put writes an archive of the given size under key.
+	// Put 以 key 写入指定大小的归档。
+	Put(ctx context.Context, key string, r io.Reader, size int64) error
+	// Open reads the archive stored under key.
+	// Open 读取 key 对应的归档。
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete removes the archive stored under key, a missing archive is not an error.
+	// Delete 删除 key 对应的归档，归档不存在时不报错。
+	Delete(ctx context.Context, key string) error
+}
+
+// NewStorage creates the storage selected by the backup configuration.
+// NewStorage 按备份配置创建存储。
+func NewStorage(cfg config.BackupConfig) (Storage, error) {
+	switch cfg.Storage {
+	case "", config.BackupStorageLocal:
+		return NewLocalStorage(cfg.LocalDir), nil
+	case config.BackupStorageS3:
+		return NewS3Storage(cfg.S3)
+	default:
+		return nil, fmt.Errorf("unsupported backup storage %q", cfg.Storage)
+	}
+}
+
+// LocalStorage keeps archives in a directory of the Control Plane host.
+// LocalStorage 将归档保存在控制面主机的目录中。
+type LocalStorage struct {
+	dir string
+}
+
+// NewLocalStorage creates a storage rooted at dir.
+// NewLocalStorage 创建以 dir 为根目录的存储。
+func NewLocalStorage(dir string) *LocalStorage {
+	return &LocalStorage{dir: dir}
+}
+
+// Type implements Storage.
+// Type 实现 Storage 接口。
+func (s *LocalStorage) Type() string {
+	return config.BackupStorageLocal
+}
+
+// Put implements Storage. The archive is written to a temporary file first so a failed write leaves no partial archive.
+// Put 实现 Storage 接口。先写入临时文件，写入失败时不会留下不完整的归档。
+func (s *LocalStorage) Put(_ context.Context, key string, r io.Reader, _ int64) error {
+	target, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(target), 0o750); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(target), ".backup-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), target)
+}
+
+// Open implements Storage.
+// Open 实现 Storage 接口。
+func (s *LocalStorage) Open(_ context.Context, key string) (io.ReadCloser, error) {
+	target, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	return os.Open(target)
+}
+
+// Delete implements Storage.
+// Delete 实现 Storage 接口。
+func (s *LocalStorage) Delete(_ context.Context, key string) error {
+	target, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(target); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// path maps a key to a file below the storage directory, rejecting keys that escape it.
+// path 将 key 映射为存储目录下的文件路径，拒绝越出目录的 key。
+func (s *LocalStorage) path(key string) (string, error) {
+	cleaned := path.Clean("/" + key)
+	if cleaned == "/" || strings.Contains(key, "..") {
+		return "", fmt.Errorf("invalid backup key %q", key)
+	}
+	return filepath.Join(s.dir, filepath.FromSlash(strings.TrimPrefix(cleaned, "/"))), nil
+}
+
+// S3Storage keeps archives in an S3-compatible object store such as MinIO or Aliyun OSS.
+// S3Storage 将归档保存在 S3 兼容对象存储中，例如 MinIO 或阿里云 OSS。
+type S3Storage struct {
+	client *minio.Client
+	bucket string
+	prefix string
+}
+
+// NewS3Storage creates an object store client from the configuration.
+// NewS3Storage 根据配置创建对象存储客户端。
+func NewS3Storage(cfg config.BackupS3Config) (*S3Storage, error) {
+	parsed, err := url.Parse(strings.TrimSpace(cfg.Endpoint))
+	if err != nil {
+		return nil, err
+	}
+	host := parsed.Host
+	if host == "" {
+		host = strings.TrimSpace(cfg.Endpoint)
+	}
+	lookup := minio.BucketLookupPath
+	if cfg.VirtualHostStyle {
+		lookup = minio.BucketLookupDNS
+	}
+	client, err := minio.New(host, &minio.Options{
+		Creds:        credentials.NewStaticV4(cfg.AccessKey, cfg.SecretKey, ""),
+		Secure:       strings.EqualFold(parsed.Scheme, "https"),
+		Region:       cfg.Region,
+		BucketLookup: lookup,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &S3Storage{
+		client: client,
+		bucket: strings.TrimSpace(cfg.Bucket),
+		prefix: strings.Trim(strings.TrimSpace(cfg.Prefix), "/"),
+	}, nil
+}
+
+// Type implements Storage.
+// Type 实现 Storage 接口。
+func (s *S3Storage) Type() string {
+	return config.BackupStorageS3
+}
+
+// Put implements Storage.
+// Put 实现 Storage 接口。
+func (s *S3Storage) Put(ctx context.Context, key string, r io.Reader, size int64) error {
+	_, err := s.client.PutObject(ctx, s.bucket, s.objectKey(key), r, size, minio.PutObjectOptions{
+		ContentType: "application/gzip",
+	})
+	return err
+}
+
+// Open implements Storage.
+// Open 实现 Storage 接口。
+func (s *S3Storage) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	object, err := s.client.GetObject(ctx, s.bucket, s.objectKey(key), minio.GetObjectOptions{})
+	if err != nil {
+		return nil, err
+	}
+	// GetObject is lazy, Stat surfaces a missing object before the caller starts streaming.
+	// GetObject 为惰性请求，Stat 可在开始读取前发现对象不存在。
+	if _, err := object.Stat(); err != nil {
+		object.Close()
+		return nil, err
+	}
+	return object, nil
+}
+
+// Delete implements Storage.
+// Delete 实现 Storage 接口。
+func (s *S3Storage) Delete(ctx context.Context, key string) error {
+	return s.client.RemoveObject(ctx, s.bucket, s.objectKey(key), minio.RemoveObjectOptions{})
+}
+
+func (s *S3Storage) objectKey(key string) string {
+	if s.prefix == "" {
+		return key
+	}
+	return s.prefix + "/" + key
+}
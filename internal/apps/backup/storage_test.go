@@ -0,0 +1,64 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package backup
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestLocalStoragePutOpenDelete(t *testing.T) {
+	ctx := context.Background()
+	storage := NewLocalStorage(t.TempDir())
+
+	if err := storage.Put(ctx, "cluster-1/a.tar.gz", strings.NewReader("archive"), 7); err != nil {
+		t.Fatalf("Put returned error: %v", err)
+	}
+	reader, err := storage.Open(ctx, "cluster-1/a.tar.gz")
+	if err != nil {
+		t.Fatalf("Open returned error: %v", err)
+	}
+	data, _ := io.ReadAll(reader)
+	reader.Close()
+	if string(data) != "archive" {
+		t.Fatalf("unexpected archive content %q", data)
+	}
+
+	if err := storage.Delete(ctx, "cluster-1/a.tar.gz"); err != nil {
+		t.Fatalf("Delete returned error: %v", err)
+	}
+	if err := storage.Delete(ctx, "cluster-1/a.tar.gz"); err != nil {
+		t.Fatalf("deleting a missing archive should succeed, got %v", err)
+	}
+	if _, err := storage.Open(ctx, "cluster-1/a.tar.gz"); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("expected missing archive, got %v", err)
+	}
+}
+
+func TestLocalStorageRejectsEscapingKeys(t *testing.T) {
+	storage := NewLocalStorage(t.TempDir())
+	for _, key := range []string{"../outside.tar.gz", "cluster-1/../../x", ""} {
+		if err := storage.Put(context.Background(), key, strings.NewReader("x"), 1); err == nil {
+			t.Fatalf("expected key %q to be rejected", key)
+		}
+	}
+}
@@ -0,0 +1,309 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/seatunnel/seatunnelX/internal/logger"
+)
+
+// AdoptClusterRequest describes an externally installed SeaTunnel cluster to bring under management.
+// AdoptClusterRequest 描述一个需要纳管的外部安装 SeaTunnel 集群。
+type AdoptClusterRequest struct {
+	Name        string `json:"name" binding:"required,max=100"`
+	Description string `json:"description"`
+	// InstallDir is used by nodes that do not set their own install dir.
+	// InstallDir 用于未单独设置安装目录的节点。
+	InstallDir string             `json:"install_dir"`
+	Nodes      []AdoptNodeRequest `json:"nodes" binding:"required,min=1,dive"`
+}
+
+// AdoptNodeRequest describes one existing installation to adopt as a cluster node.
+// AdoptNodeRequest 描述一个作为集群节点纳管的已有安装。
+type AdoptNodeRequest struct {
+	HostID     uint   `json:"host_id" binding:"required"`
+	InstallDir string `json:"install_dir,omitempty"`
+	// Role is detected from the running process; it is required only for stopped nodes of a
+	// separated installation, or when master and worker run from the same directory.
+	// Role 从运行中的进程检测；仅当分离模式安装的节点未运行，或 master 与 worker 共用同一目录时需要指定。
+	Role NodeRole `json:"role,omitempty"`
+}
+
+// InstallInspection is what an agent detects in an existing installation directory.
+// InstallInspection 是 Agent 在已有安装目录中检测到的信息。
+type InstallInspection struct {
+	InstallDir     string   `json:"install_dir"`
+	Version        string   `json:"version"`
+	DeploymentMode string   `json:"deployment_mode"`
+	HazelcastPort  int      `json:"hazelcast_port"`
+	WorkerPort     int      `json:"worker_port,omitempty"`
+	APIPort        int      `json:"api_port,omitempty"`
+	ConfigFiles    []string `json:"config_files"`
+	Processes      []struct {
+		PID  int    `json:"pid"`
+		Role string `json:"role"`
+	} `json:"processes"`
+}
+
+// AdoptedNode is the outcome of adopting one node.
+// AdoptedNode 是单个节点的纳管结果。
+type AdoptedNode struct {
+	NodeID          uint       `json:"node_id"`
+	HostID          uint       `json:"host_id"`
+	HostName        string     `json:"host_name"`
+	Role            NodeRole   `json:"role"`
+	InstallDir      string     `json:"install_dir"`
+	Status          NodeStatus `json:"status"`
+	ProcessPID      int        `json:"process_pid,omitempty"`
+	ConfigFiles     []string   `json:"config_files"`
+	ConfigsImported bool       `json:"configs_imported"`
+	Warning         string     `json:"warning,omitempty"`
+}
+
+// AdoptClusterResult is the outcome of adopting a cluster.
+// AdoptClusterResult 是纳管集群的结果。
+type AdoptClusterResult struct {
+	Cluster *ClusterInfo   `json:"cluster"`
+	Nodes   []*AdoptedNode `json:"nodes"`
+}
+
+// adoptCandidate is an inspected node waiting to be created.
+// adoptCandidate 是已检查、等待创建的节点。
+type adoptCandidate struct {
+	host       *HostInfo
+	inspection *InstallInspection
+	node       *ClusterNode
+}
+
+// AdoptCluster imports an externally installed cluster without reinstalling it: every node's agent
+// inspects its install dir, the nodes are recorded with the detected version, ports and process,
+// and the node configs are pulled into config management.
+// AdoptCluster 在不重装的情况下导入外部安装的集群：各节点 Agent 检查安装目录，
+// 按检测到的版本、端口和进程记录节点，并将节点配置拉取到配置管理中。
+func (s *Service) AdoptCluster(ctx context.Context, req *AdoptClusterRequest, userID uint) (*AdoptClusterResult, error) {
+	if s.hostProvider == nil || s.agentSender == nil {
+		return nil, ErrAdoptUnavailable
+	}
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, ErrClusterNameEmpty
+	}
+	if len(req.Nodes) == 0 {
+		return nil, ErrNodeBatchEntriesRequired
+	}
+	exists, err := s.repo.ExistsByName(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, ErrClusterNameDuplicate
+	}
+
+	candidates := make([]*adoptCandidate, 0, len(req.Nodes))
+	for _, nodeReq := range req.Nodes {
+		candidate, err := s.inspectAdoptNode(ctx, nodeReq, req.InstallDir)
+		if err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, candidate)
+	}
+
+	version := candidates[0].inspection.Version
+	mode := DeploymentModeHybrid
+	for _, candidate := range candidates {
+		if candidate.inspection.Version != version || version == "" || version == "unknown" {
+			return nil, fmt.Errorf("%w: %s on %s, %s on %s", ErrAdoptVersionMismatch,
+				version, candidates[0].host.Name, candidate.inspection.Version, candidate.host.Name)
+		}
+		if candidate.node.Role != NodeRoleMasterWorker {
+			mode = DeploymentModeSeparated
+		}
+	}
+	for _, candidate := range candidates {
+		if _, err := normalizeNodeRoleForDeployment(mode, candidate.node.Role); err != nil {
+			return nil, fmt.Errorf("%w: hybrid and separated installations cannot be mixed", err)
+		}
+	}
+
+	cluster := &Cluster{
+		Name:           name,
+		Description:    req.Description,
+		DeploymentMode: mode,
+		Version:        version,
+		Status:         ClusterStatusStopped,
+		Source:         ClusterSourceAdopted,
+		InstallDir:     firstNonEmpty(req.InstallDir, candidates[0].node.InstallDir),
+		CreatedBy:      userID,
+	}
+	err = s.repo.Transaction(ctx, func(tx *Repository) error {
+		if err := tx.Create(ctx, cluster); err != nil {
+			return err
+		}
+		for _, candidate := range candidates {
+			candidate.node.ClusterID = cluster.ID
+			if err := tx.AddNode(ctx, candidate.node); err != nil {
+				return fmt.Errorf("host %s: %w", candidate.host.Name, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	logger.InfoF(ctx, "[Cluster] Adopted cluster / 已纳管集群: name=%s, id=%d, version=%s, mode=%s, nodes=%d",
+		cluster.Name, cluster.ID, cluster.Version, cluster.DeploymentMode, len(candidates))
+
+	result := &AdoptClusterResult{Nodes: make([]*AdoptedNode, 0, len(candidates))}
+	for _, candidate := range candidates {
+		result.Nodes = append(result.Nodes, s.importAdoptedNodeConfigs(ctx, cluster.ID, candidate, userID))
+	}
+
+	s.updateClusterStatusFromNodes(ctx, cluster.ID)
+	s.notifyClusterTopologyChanged(ctx, cluster.ID)
+
+	adopted, err := s.repo.GetByID(ctx, cluster.ID, true)
+	if err != nil {
+		return nil, err
+	}
+	result.Cluster = adopted.ToClusterInfo()
+	return result, nil
+}
+
+// inspectAdoptNode asks the host agent to inspect the installation and builds the node to create.
+// inspectAdoptNode 请求主机 Agent 检查安装目录，并构建待创建的节点。
+func (s *Service) inspectAdoptNode(ctx context.Context, req AdoptNodeRequest, defaultInstallDir string) (*adoptCandidate, error) {
+	host, err := s.ensureHostReady(ctx, req.HostID)
+	if err != nil {
+		return nil, err
+	}
+	if host.AgentID == "" {
+		return nil, ErrNodeAgentNotInstalled
+	}
+	installDir := resolveNodeInstallDir(req.InstallDir, defaultInstallDir)
+
+	success, output, err := s.agentSender.SendCommand(ctx, host.AgentID, "discover_clusters", map[string]string{
+		"install_dir": installDir,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: host %s: %v", ErrAdoptInspectFailed, host.Name, err)
+	}
+	if !success {
+		return nil, fmt.Errorf("%w: host %s: %s", ErrAdoptInspectFailed, host.Name, output)
+	}
+	var inspection InstallInspection
+	if err := json.Unmarshal([]byte(output), &inspection); err != nil || inspection.InstallDir == "" {
+		return nil, fmt.Errorf("%w: host %s: unexpected agent output (agent may need an upgrade)", ErrAdoptInspectFailed, host.Name)
+	}
+
+	node, err := adoptedNode(req.Role, &inspection)
+	if err != nil {
+		return nil, fmt.Errorf("host %s: %w", host.Name, err)
+	}
+	node.HostID = host.ID
+
+	_, _, found, err := s.repo.GetNodeByHostAndInstallDirAndRole(ctx, host.ID, node.InstallDir, string(node.Role))
+	if err != nil {
+		return nil, err
+	}
+	if found {
+		return nil, fmt.Errorf("%w: %s on host %s", ErrAdoptAlreadyManaged, node.InstallDir, host.Name)
+	}
+	return &adoptCandidate{host: host, inspection: &inspection, node: node}, nil
+}
+
+// adoptedNode resolves the role, ports and process state of an inspected installation.
+// adoptedNode 解析被检查安装的角色、端口和进程状态。
+func adoptedNode(requestedRole NodeRole, inspection *InstallInspection) (*ClusterNode, error) {
+	if requestedRole != "" && !isValidNodeRole(requestedRole) {
+		return nil, ErrInvalidNodeRole
+	}
+	separated := inspection.DeploymentMode == string(DeploymentModeSeparated)
+
+	role := requestedRole
+	if !separated {
+		role = NodeRoleMasterWorker
+	} else if role == "" {
+		if len(inspection.Processes) != 1 {
+			return nil, ErrAdoptRoleUnknown
+		}
+		role = discoveryNodeRole(inspection.Processes[0].Role)
+	}
+
+	node := &ClusterNode{
+		Role:       role,
+		InstallDir: filepath.Clean(inspection.InstallDir),
+		Status:     NodeStatusStopped,
+	}
+	for _, proc := range inspection.Processes {
+		if discoveryNodeRole(proc.Role) == role {
+			node.Status = NodeStatusRunning
+			node.ProcessPID = proc.PID
+			break
+		}
+	}
+
+	mode := DeploymentModeHybrid
+	if separated {
+		mode = DeploymentModeSeparated
+	}
+	node.HazelcastPort, node.APIPort, node.WorkerPort = GetDefaultPorts(role, mode)
+	hazelcastPort := inspection.HazelcastPort
+	if role == NodeRoleWorker {
+		hazelcastPort = inspection.WorkerPort
+	}
+	if hazelcastPort > 0 {
+		node.HazelcastPort = hazelcastPort
+	}
+	if inspection.APIPort > 0 && role != NodeRoleWorker {
+		node.APIPort = inspection.APIPort
+	}
+	return node, nil
+}
+
+// importAdoptedNodeConfigs pulls the config files of an adopted node into config management.
+// Failures are reported as warnings because the node is already recorded.
+// importAdoptedNodeConfigs 将已纳管节点的配置文件拉取到配置管理中。
+// 由于节点已记录，失败仅作为警告返回。
+func (s *Service) importAdoptedNodeConfigs(ctx context.Context, clusterID uint, candidate *adoptCandidate, userID uint) *AdoptedNode {
+	adopted := &AdoptedNode{
+		NodeID:      candidate.node.ID,
+		HostID:      candidate.host.ID,
+		HostName:    candidate.host.Name,
+		Role:        candidate.node.Role,
+		InstallDir:  candidate.node.InstallDir,
+		Status:      candidate.node.Status,
+		ProcessPID:  candidate.node.ProcessPID,
+		ConfigFiles: candidate.inspection.ConfigFiles,
+	}
+	if s.configStore == nil {
+		adopted.Warning = "config management not configured, configs were not imported / 配置管理未配置，未导入配置"
+		return adopted
+	}
+	if err := s.configStore.InitClusterConfigs(ctx, clusterID, candidate.host.ID, candidate.node.InstallDir, userID); err != nil {
+		logger.WarnF(ctx, "[Cluster] Adopt: import configs failed / 纳管：导入配置失败: cluster_id=%d, host_id=%d, err=%v", clusterID, candidate.host.ID, err)
+		adopted.Warning = err.Error()
+		return adopted
+	}
+	adopted.ConfigsImported = true
+	return adopted
+}
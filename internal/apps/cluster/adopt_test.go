@@ -0,0 +1,136 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cluster
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func newAdoptTestService(t *testing.T, outputs map[string]string) (*Service, *Repository, *fakeClusterConfigStore) {
+	t.Helper()
+	db, cleanup := setupServiceTestDB(t)
+	t.Cleanup(cleanup)
+
+	repo := NewRepository(db)
+	hostProvider := NewMockHostProvider()
+	now := time.Now()
+	for i, agentID := range []string{"agent-1", "agent-2"} {
+		hostProvider.AddHost(&HostInfo{
+			ID:            uint(i + 1),
+			Name:          agentID,
+			HostType:      "bare_metal",
+			AgentID:       agentID,
+			AgentStatus:   "installed",
+			LastHeartbeat: &now,
+		})
+	}
+
+	svc := NewService(repo, hostProvider, nil)
+	svc.SetAgentCommandSender(&scriptedAgentSender{
+		send: func(ctx context.Context, agentID string, commandType string, params map[string]string) (bool, string, error) {
+			if commandType != "discover_clusters" || params["install_dir"] == "" {
+				t.Fatalf("unexpected command %s %v", commandType, params)
+			}
+			return true, outputs[agentID], nil
+		},
+	})
+	configStore := &fakeClusterConfigStore{}
+	svc.SetClusterConfigStore(configStore)
+	return svc, repo, configStore
+}
+
+func TestService_AdoptCluster_separatedInstallation(t *testing.T) {
+	svc, repo, configStore := newAdoptTestService(t, map[string]string{
+		"agent-1": `{"install_dir":"/opt/seatunnel","version":"2.3.12","deployment_mode":"separated","hazelcast_port":5901,"worker_port":5902,"api_port":8090,"config_files":["config/seatunnel.yaml"],"processes":[{"pid":111,"role":"master"}]}`,
+		"agent-2": `{"install_dir":"/opt/seatunnel","version":"2.3.12","deployment_mode":"separated","hazelcast_port":5901,"worker_port":5902,"config_files":["config/seatunnel.yaml"],"processes":[]}`,
+	})
+	ctx := context.Background()
+
+	result, err := svc.AdoptCluster(ctx, &AdoptClusterRequest{
+		Name:       "legacy",
+		InstallDir: "/opt/seatunnel",
+		Nodes: []AdoptNodeRequest{
+			{HostID: 1},
+			{HostID: 2, Role: NodeRoleWorker},
+		},
+	}, 7)
+	if err != nil {
+		t.Fatalf("AdoptCluster returned error: %v", err)
+	}
+	if result.Cluster.Source != ClusterSourceAdopted || result.Cluster.DeploymentMode != DeploymentModeSeparated || result.Cluster.Version != "2.3.12" {
+		t.Fatalf("unexpected adopted cluster: %+v", result.Cluster)
+	}
+	if result.Cluster.Status != ClusterStatusRunning {
+		t.Fatalf("expected running cluster with a running master, got %s", result.Cluster.Status)
+	}
+	if len(configStore.inits) != 2 || !result.Nodes[0].ConfigsImported {
+		t.Fatalf("expected configs to be imported from both nodes, got %v %+v", configStore.inits, result.Nodes[0])
+	}
+
+	nodes, err := repo.GetNodesByClusterID(ctx, result.Cluster.ID)
+	if err != nil {
+		t.Fatalf("GetNodesByClusterID returned error: %v", err)
+	}
+	byRole := map[NodeRole]*ClusterNode{}
+	for _, node := range nodes {
+		byRole[node.Role] = node
+	}
+	master, worker := byRole[NodeRoleMaster], byRole[NodeRoleWorker]
+	if master == nil || master.ProcessPID != 111 || master.Status != NodeStatusRunning || master.HazelcastPort != 5901 || master.APIPort != 8090 {
+		t.Fatalf("unexpected master node: %+v", master)
+	}
+	if worker == nil || worker.Status != NodeStatusStopped || worker.HazelcastPort != 5902 || worker.APIPort != 0 {
+		t.Fatalf("unexpected worker node: %+v", worker)
+	}
+
+	if _, err := svc.AdoptCluster(ctx, &AdoptClusterRequest{
+		Name:  "legacy-again",
+		Nodes: []AdoptNodeRequest{{HostID: 1, InstallDir: "/opt/seatunnel"}},
+	}, 7); !errors.Is(err, ErrAdoptAlreadyManaged) {
+		t.Fatalf("expected ErrAdoptAlreadyManaged, got %v", err)
+	}
+}
+
+func TestService_AdoptCluster_rejectsInconsistentNodes(t *testing.T) {
+	svc, repo, _ := newAdoptTestService(t, map[string]string{
+		"agent-1": `{"install_dir":"/opt/seatunnel","version":"2.3.12","deployment_mode":"hybrid","hazelcast_port":5801,"processes":[]}`,
+		"agent-2": `{"install_dir":"/opt/seatunnel","version":"2.3.11","deployment_mode":"hybrid","hazelcast_port":5801,"processes":[]}`,
+	})
+	ctx := context.Background()
+
+	_, err := svc.AdoptCluster(ctx, &AdoptClusterRequest{
+		Name:  "mixed",
+		Nodes: []AdoptNodeRequest{{HostID: 1}, {HostID: 2}},
+	}, 7)
+	if !errors.Is(err, ErrAdoptVersionMismatch) {
+		t.Fatalf("expected ErrAdoptVersionMismatch, got %v", err)
+	}
+	if exists, _ := repo.ExistsByName(ctx, "mixed"); exists {
+		t.Fatalf("expected no cluster to be created for a rejected adoption")
+	}
+}
+
+func TestAdoptedNode_requiresRoleForStoppedSeparatedNode(t *testing.T) {
+	_, err := adoptedNode("", &InstallInspection{InstallDir: "/opt/seatunnel", DeploymentMode: "separated"})
+	if !errors.Is(err, ErrAdoptRoleUnknown) {
+		t.Fatalf("expected ErrAdoptRoleUnknown, got %v", err)
+	}
+}
@@ -0,0 +1,353 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Clock skew thresholds, matching the Agent clock_sync precheck.
+// 时钟偏差阈值，与 Agent 的 clock_sync 预检查一致。
+const (
+	ClockSkewWarnMs int64 = 1000
+	ClockSkewFailMs int64 = 30000
+)
+
+// connectivityProbeTimeoutSeconds bounds each member dial on the agents.
+// connectivityProbeTimeoutSeconds 限制 Agent 上每次成员拨号的时长。
+const connectivityProbeTimeoutSeconds = 3
+
+// ClusterPrecheckNode is one member of the connectivity matrix.
+// ClusterPrecheckNode 是连通性矩阵中的一个成员。
+type ClusterPrecheckNode struct {
+	NodeID      uint     `json:"node_id"`
+	HostID      uint     `json:"host_id"`
+	HostName    string   `json:"host_name"`
+	Role        NodeRole `json:"role"`
+	Address     string   `json:"address"`
+	AgentOnline bool     `json:"agent_online"`
+	// ClockSkewMs is the node clock minus the Control Plane clock, nil when it could not be measured.
+	// ClockSkewMs 是节点时钟减去 Control Plane 时钟，无法测量时为 nil。
+	ClockSkewMs *int64 `json:"clock_skew_ms,omitempty"`
+	ClockStatus string `json:"clock_status"`
+	Message     string `json:"message,omitempty"`
+}
+
+// ConnectivityCell is the probe from one member to another; Matrix[i][j] goes from Nodes[i] to Nodes[j].
+// ConnectivityCell 是一个成员到另一个成员的探测结果；Matrix[i][j] 表示 Nodes[i] 到 Nodes[j]。
+type ConnectivityCell struct {
+	Status string `json:"status"` // passed, warning, failed, skipped / 通过、警告、失败、跳过
+	// Reachable means the host answered; Listening means the member port accepted the connection.
+	// Reachable 表示主机有应答；Listening 表示成员端口接受了连接。
+	Reachable bool    `json:"reachable"`
+	Listening bool    `json:"listening"`
+	LatencyMs float64 `json:"latency_ms"`
+	Error     string  `json:"error,omitempty"`
+}
+
+// ClusterPrecheckResult is the cluster-level connectivity and clock skew precheck.
+// ClusterPrecheckResult 是集群级连通性与时钟偏差预检查结果。
+type ClusterPrecheckResult struct {
+	ClusterID uint                   `json:"cluster_id"`
+	Success   bool                   `json:"success"`
+	Message   string                 `json:"message"`
+	CheckedAt time.Time              `json:"checked_at"`
+	Nodes     []*ClusterPrecheckNode `json:"nodes"`
+	Matrix    [][]*ConnectivityCell  `json:"matrix"`
+	Checks    []*PrecheckCheckItem   `json:"checks"`
+}
+
+// connectivityProbe mirrors the Agent check_connectivity probe result.
+// connectivityProbe 对应 Agent check_connectivity 的探测结果。
+type connectivityProbe struct {
+	Target    string  `json:"target"`
+	Reachable bool    `json:"reachable"`
+	Listening bool    `json:"listening"`
+	LatencyMs float64 `json:"latency_ms"`
+	Error     string  `json:"error"`
+}
+
+// PrecheckCluster instructs every member's agent to dial every other member address and compares
+// each node clock with the Control Plane clock, producing an N×N connectivity matrix. Hazelcast
+// misbehaves with partial connectivity and clock skew, so both are checked before scaling or starting.
+// PrecheckCluster 让每个成员的 Agent 拨号其他所有成员地址，并将各节点时钟与 Control Plane 时钟比较，
+// 生成 N×N 连通性矩阵。Hazelcast 在部分连通和时钟偏差下会异常，因此在扩容或启动前检查两者。
+func (s *Service) PrecheckCluster(ctx context.Context, clusterID uint) (*ClusterPrecheckResult, error) {
+	cluster, err := s.repo.GetByID(ctx, clusterID, true)
+	if err != nil {
+		return nil, err
+	}
+	result := &ClusterPrecheckResult{
+		ClusterID: clusterID,
+		CheckedAt: time.Now(),
+		Nodes:     make([]*ClusterPrecheckNode, 0, len(cluster.Nodes)),
+		Matrix:    make([][]*ConnectivityCell, len(cluster.Nodes)),
+		Checks:    make([]*PrecheckCheckItem, 0, 3),
+	}
+	if s.hostProvider == nil || s.agentSender == nil {
+		result.Message = "Host provider or agent command sender not configured / 主机提供者或 Agent 命令发送器未配置"
+		return result, nil
+	}
+
+	agentIDs := make([]string, len(cluster.Nodes))
+	for i := range cluster.Nodes {
+		node := &cluster.Nodes[i]
+		member := &ClusterPrecheckNode{NodeID: node.ID, HostID: node.HostID, Role: node.Role, ClockStatus: PrecheckStatusSkipped}
+		if hostInfo, err := s.hostProvider.GetHostByID(ctx, node.HostID); err != nil {
+			member.Message = fmt.Sprintf("Failed to get host: %v / 获取主机失败: %v", err, err)
+		} else {
+			member.HostName = hostInfo.Name
+			if hostInfo.IPAddress != "" && node.HazelcastPort > 0 {
+				member.Address = net.JoinHostPort(hostInfo.IPAddress, strconv.Itoa(node.HazelcastPort))
+			}
+			member.AgentOnline = hostInfo.AgentID != "" && hostInfo.AgentStatus == "installed" && hostInfo.IsOnline(s.heartbeatTimeout)
+			if member.AgentOnline {
+				agentIDs[i] = hostInfo.AgentID
+			} else {
+				member.Message = "Agent is offline / Agent 离线"
+			}
+		}
+		result.Nodes = append(result.Nodes, member)
+	}
+
+	var wg sync.WaitGroup
+	for i := range result.Nodes {
+		result.Matrix[i] = make([]*ConnectivityCell, len(result.Nodes))
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			s.probeClusterMembers(ctx, result, i, agentIDs[i])
+		}(i)
+	}
+	wg.Wait()
+
+	result.Checks = append(result.Checks,
+		summarizeAgentStatus(result.Nodes),
+		summarizeConnectivity(result),
+		summarizeClockSkew(result.Nodes),
+	)
+	result.Success = true
+	for _, check := range result.Checks {
+		if check.Status == PrecheckStatusFailed {
+			result.Success = false
+		}
+	}
+	if result.Success {
+		result.Message = "All members can reach each other and clocks are in sync / 所有成员互相可达且时钟同步"
+	} else {
+		result.Message = "Cluster precheck found problems / 集群预检查发现问题"
+	}
+	return result, nil
+}
+
+// probeClusterMembers fills row i of the matrix and the clock skew of node i from one agent command.
+// probeClusterMembers 通过一次 Agent 命令填充矩阵第 i 行以及节点 i 的时钟偏差。
+func (s *Service) probeClusterMembers(ctx context.Context, result *ClusterPrecheckResult, i int, agentID string) {
+	row := result.Matrix[i]
+	member := result.Nodes[i]
+	targets := make([]string, 0, len(result.Nodes))
+	columns := make([]int, 0, len(result.Nodes))
+	for j, peer := range result.Nodes {
+		switch {
+		case j == i:
+			row[j] = &ConnectivityCell{Status: PrecheckStatusSkipped}
+		case agentID == "":
+			row[j] = &ConnectivityCell{Status: PrecheckStatusSkipped, Error: "source agent is offline / 源 Agent 离线"}
+		case peer.Address == "":
+			row[j] = &ConnectivityCell{Status: PrecheckStatusSkipped, Error: "member address unknown / 成员地址未知"}
+		default:
+			targets = append(targets, peer.Address)
+			columns = append(columns, j)
+		}
+	}
+	if agentID == "" {
+		return
+	}
+
+	params := map[string]string{
+		"sub_command":     "check_connectivity",
+		"targets":         strings.Join(targets, ","),
+		"timeout_seconds": strconv.Itoa(connectivityProbeTimeoutSeconds),
+	}
+	sentAt := time.Now()
+	_, output, err := s.agentSender.SendCommand(ctx, agentID, "check_connectivity", params)
+	receivedAt := time.Now()
+	failColumns := func(msg string) {
+		for _, j := range columns {
+			row[j] = &ConnectivityCell{Status: PrecheckStatusSkipped, Error: msg}
+		}
+		member.Message = msg
+	}
+	if err != nil {
+		failColumns(fmt.Sprintf("Failed to run connectivity check: %v / 执行连通性检查失败: %v", err, err))
+		return
+	}
+
+	var resp struct {
+		Message string            `json:"message"`
+		Details map[string]string `json:"details"`
+	}
+	var probes []connectivityProbe
+	if jsonErr := json.Unmarshal([]byte(strings.TrimSpace(output)), &resp); jsonErr != nil ||
+		json.Unmarshal([]byte(resp.Details["probes"]), &probes) != nil || len(probes) != len(columns) {
+		// Older agents report an unknown sub-command / 旧版 Agent 会返回未知子命令
+		failColumns(fmt.Sprintf("Agent does not support connectivity checks: %s / Agent 不支持连通性检查: %s", output, output))
+		return
+	}
+
+	for k, j := range columns {
+		probe := probes[k]
+		cell := &ConnectivityCell{Reachable: probe.Reachable, Listening: probe.Listening, LatencyMs: probe.LatencyMs, Error: probe.Error}
+		switch {
+		case probe.Listening:
+			cell.Status = PrecheckStatusPassed
+		case probe.Reachable:
+			cell.Status = PrecheckStatusWarning
+		default:
+			cell.Status = PrecheckStatusFailed
+		}
+		row[j] = cell
+	}
+
+	// NTP-style estimate: the agent read its clock halfway through the round trip minus its own handling time
+	// 类 NTP 估算：Agent 读取时钟的时刻位于扣除其处理耗时后的往返中点
+	agentTimeMs, timeErr := strconv.ParseInt(resp.Details["agent_time_ms"], 10, 64)
+	elapsedMs, _ := strconv.ParseInt(resp.Details["elapsed_ms"], 10, 64)
+	if timeErr != nil || agentTimeMs <= 0 {
+		return
+	}
+	roundTripMs := receivedAt.Sub(sentAt).Milliseconds() - elapsedMs
+	if roundTripMs < 0 {
+		roundTripMs = 0
+	}
+	skew := agentTimeMs - (sentAt.UnixMilli() + roundTripMs/2)
+	member.ClockSkewMs = &skew
+	member.ClockStatus = clockSkewStatus(skew)
+}
+
+// clockSkewStatus classifies an absolute clock skew.
+// clockSkewStatus 对时钟偏差的绝对值分级。
+func clockSkewStatus(skewMs int64) string {
+	if skewMs < 0 {
+		skewMs = -skewMs
+	}
+	switch {
+	case skewMs > ClockSkewFailMs:
+		return PrecheckStatusFailed
+	case skewMs > ClockSkewWarnMs:
+		return PrecheckStatusWarning
+	default:
+		return PrecheckStatusPassed
+	}
+}
+
+// summarizeAgentStatus fails when any member cannot take part in the check.
+// summarizeAgentStatus 在任一成员无法参与检查时失败。
+func summarizeAgentStatus(nodes []*ClusterPrecheckNode) *PrecheckCheckItem {
+	item := &PrecheckCheckItem{Name: "agent_status", Status: PrecheckStatusPassed}
+	var offline []string
+	for _, node := range nodes {
+		if !node.AgentOnline {
+			offline = append(offline, memberLabel(node))
+		}
+	}
+	if len(offline) > 0 {
+		item.Status = PrecheckStatusFailed
+		item.Message = fmt.Sprintf("Agents offline: %s / Agent 离线: %s", strings.Join(offline, ", "), strings.Join(offline, ", "))
+		return item
+	}
+	item.Message = fmt.Sprintf("All %d agents are online / 全部 %d 个 Agent 在线", len(nodes), len(nodes))
+	return item
+}
+
+// summarizeConnectivity fails on unreachable pairs and warns when hosts answer but member ports are closed.
+// summarizeConnectivity 在成员对不可达时失败，在主机有应答但成员端口未监听时给出警告。
+func summarizeConnectivity(result *ClusterPrecheckResult) *PrecheckCheckItem {
+	item := &PrecheckCheckItem{Name: "connectivity", Status: PrecheckStatusPassed}
+	var failed, closed []string
+	for i, row := range result.Matrix {
+		for j, cell := range row {
+			if cell == nil {
+				continue
+			}
+			pair := memberLabel(result.Nodes[i]) + " -> " + result.Nodes[j].Address
+			switch cell.Status {
+			case PrecheckStatusFailed:
+				failed = append(failed, pair)
+			case PrecheckStatusWarning:
+				closed = append(closed, pair)
+			}
+		}
+	}
+	switch {
+	case len(failed) > 0:
+		item.Status = PrecheckStatusFailed
+		item.Message = fmt.Sprintf("Unreachable: %s / 不可达: %s", strings.Join(failed, "; "), strings.Join(failed, "; "))
+	case len(closed) > 0:
+		item.Status = PrecheckStatusWarning
+		item.Message = fmt.Sprintf("Hosts reachable but member ports not listening: %s / 主机可达但成员端口未监听: %s", strings.Join(closed, "; "), strings.Join(closed, "; "))
+	default:
+		item.Message = "All probed member pairs are reachable / 所有已探测的成员对均可达"
+	}
+	return item
+}
+
+// summarizeClockSkew reports the largest clock skew against the Control Plane.
+// summarizeClockSkew 报告相对 Control Plane 的最大时钟偏差。
+func summarizeClockSkew(nodes []*ClusterPrecheckNode) *PrecheckCheckItem {
+	item := &PrecheckCheckItem{Name: "clock_skew", Status: PrecheckStatusSkipped, Message: "No clock measured / 未测量到时钟"}
+	var worst *ClusterPrecheckNode
+	var worstAbs int64 = -1
+	for _, node := range nodes {
+		if node.ClockSkewMs == nil {
+			continue
+		}
+		abs := *node.ClockSkewMs
+		if abs < 0 {
+			abs = -abs
+		}
+		if abs > worstAbs {
+			worst, worstAbs = node, abs
+		}
+	}
+	if worst == nil {
+		return item
+	}
+	item.Status = clockSkewStatus(worstAbs)
+	item.Message = fmt.Sprintf("Max clock skew %dms on %s (warn > %dms, fail > %dms) / 最大时钟偏差 %dms，位于 %s（警告 > %dms，失败 > %dms）",
+		*worst.ClockSkewMs, memberLabel(worst), ClockSkewWarnMs, ClockSkewFailMs,
+		*worst.ClockSkewMs, memberLabel(worst), ClockSkewWarnMs, ClockSkewFailMs)
+	return item
+}
+
+// memberLabel names a member by host and role.
+// memberLabel 以主机和角色命名成员。
+func memberLabel(node *ClusterPrecheckNode) string {
+	name := node.HostName
+	if name == "" {
+		name = fmt.Sprintf("host-%d", node.HostID)
+	}
+	return fmt.Sprintf("%s(%s)", name, node.Role)
+}
@@ -0,0 +1,130 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestService_PrecheckCluster_buildsMatrixAndClockSkew(t *testing.T) {
+	svc, _, _ := newScaleTestService(t)
+	ctx := context.Background()
+
+	svc.SetAgentCommandSender(&scriptedAgentSender{send: func(ctx context.Context, agentID string, commandType string, params map[string]string) (bool, string, error) {
+		if commandType != "check_connectivity" {
+			return true, "ok", nil
+		}
+		if agentID == "agent-3" {
+			// An older agent without the sub-command.
+			// 不支持该子命令的旧版 Agent。
+			return false, "unknown precheck sub-command: check_connectivity", nil
+		}
+		agentTime := time.Now()
+		if agentID == "agent-2" {
+			agentTime = agentTime.Add(45 * time.Second)
+		}
+		var probes []connectivityProbe
+		for _, target := range strings.Split(params["targets"], ",") {
+			probe := connectivityProbe{Target: target, Reachable: true, Listening: true, LatencyMs: 0.4}
+			if agentID == "agent-2" && strings.HasPrefix(target, "10.0.0.3:") {
+				probe.Listening = false
+				probe.Error = "connection refused"
+			}
+			probes = append(probes, probe)
+		}
+		probesJSON, _ := json.Marshal(probes)
+		output, _ := json.Marshal(map[string]interface{}{
+			"success": true,
+			"details": map[string]string{
+				"agent_time_ms": strconv.FormatInt(agentTime.UnixMilli(), 10),
+				"elapsed_ms":    "1",
+				"probes":        string(probesJSON),
+			},
+		})
+		return true, string(output), nil
+	}})
+
+	cluster, err := svc.Create(ctx, &CreateClusterRequest{Name: "precheck-cluster", DeploymentMode: DeploymentModeHybrid, Version: "2.3.12"})
+	if err != nil {
+		t.Fatalf("Create returned error: %v", err)
+	}
+	for _, hostID := range []uint{1, 2, 3} {
+		if _, err := svc.AddNode(ctx, cluster.ID, &AddNodeRequest{HostID: hostID, Role: NodeRoleMasterWorker, SkipPrecheck: true}); err != nil {
+			t.Fatalf("AddNode(%d) returned error: %v", hostID, err)
+		}
+	}
+
+	result, err := svc.PrecheckCluster(ctx, cluster.ID)
+	if err != nil {
+		t.Fatalf("PrecheckCluster returned error: %v", err)
+	}
+	if len(result.Nodes) != 3 || len(result.Matrix) != 3 {
+		t.Fatalf("expected 3x3 matrix, got %d nodes and %d rows", len(result.Nodes), len(result.Matrix))
+	}
+	if result.Success {
+		t.Fatalf("expected precheck to fail on clock skew, got %+v", result)
+	}
+
+	expected := [][]string{
+		{PrecheckStatusSkipped, PrecheckStatusPassed, PrecheckStatusPassed},
+		{PrecheckStatusPassed, PrecheckStatusSkipped, PrecheckStatusWarning},
+		{PrecheckStatusSkipped, PrecheckStatusSkipped, PrecheckStatusSkipped},
+	}
+	for i, row := range expected {
+		for j, status := range row {
+			if got := result.Matrix[i][j].Status; got != status {
+				t.Fatalf("matrix[%d][%d] = %s, want %s", i, j, got, status)
+			}
+		}
+	}
+	if !strings.Contains(result.Matrix[2][0].Error, "does not support") {
+		t.Fatalf("expected old agent to be reported, got %q", result.Matrix[2][0].Error)
+	}
+
+	if result.Nodes[0].ClockStatus != PrecheckStatusPassed || result.Nodes[1].ClockStatus != PrecheckStatusFailed {
+		t.Fatalf("unexpected clock statuses: %s, %s", result.Nodes[0].ClockStatus, result.Nodes[1].ClockStatus)
+	}
+	if skew := *result.Nodes[1].ClockSkewMs; skew < 44000 || skew > 46000 {
+		t.Fatalf("expected ~45s skew on host-2, got %dms", skew)
+	}
+	if result.Nodes[2].ClockSkewMs != nil {
+		t.Fatalf("expected no skew for the old agent, got %d", *result.Nodes[2].ClockSkewMs)
+	}
+
+	statuses := map[string]string{}
+	for _, check := range result.Checks {
+		statuses[check.Name] = check.Status
+	}
+	if statuses["agent_status"] != PrecheckStatusPassed || statuses["connectivity"] != PrecheckStatusWarning || statuses["clock_skew"] != PrecheckStatusFailed {
+		t.Fatalf("unexpected summary checks: %v", statuses)
+	}
+}
+
+func TestClockSkewStatus(t *testing.T) {
+	cases := map[int64]string{0: PrecheckStatusPassed, -900: PrecheckStatusPassed, 1500: PrecheckStatusWarning, -31000: PrecheckStatusFailed}
+	for skew, want := range cases {
+		if got := clockSkewStatus(skew); got != want {
+			t.Fatalf("clockSkewStatus(%d) = %s, want %s", skew, got, want)
+		}
+	}
+}
@@ -0,0 +1,239 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultDrainTimeoutSeconds = 600
+	maxDrainTimeoutSeconds     = 24 * 60 * 60
+	defaultDrainPollInterval   = 5 * time.Second
+	// drainSavepointTimeout bounds the wait for savepointed jobs to leave the node.
+	// drainSavepointTimeout 限制等待执行保存点的作业离开节点的时长。
+	drainSavepointTimeout = 5 * time.Minute
+)
+
+// DrainTimeoutAction decides what happens to jobs still running on a draining node at the deadline.
+// DrainTimeoutAction 决定排空截止时仍在节点上运行的作业如何处理。
+type DrainTimeoutAction string
+
+const (
+	// DrainTimeoutSavepoint stops the remaining jobs with a savepoint so they can be resumed later (default).
+	// DrainTimeoutSavepoint 以保存点方式停止剩余作业，以便之后恢复（默认）。
+	DrainTimeoutSavepoint DrainTimeoutAction = "savepoint"
+	// DrainTimeoutFail aborts the decommission and puts the node back into service.
+	// DrainTimeoutFail 中止下线并让节点恢复服务。
+	DrainTimeoutFail DrainTimeoutAction = "fail"
+)
+
+// DrainOptions controls how nodes are drained before they are removed.
+// DrainOptions 控制节点移除前的排空方式。
+type DrainOptions struct {
+	// TimeoutSeconds is how long to wait for jobs on the node to finish, default 600.
+	// TimeoutSeconds 是等待节点上作业结束的时长，默认 600。
+	TimeoutSeconds int                `json:"timeout_seconds,omitempty"`
+	OnTimeout      DrainTimeoutAction `json:"on_timeout,omitempty"`
+}
+
+// normalize fills defaults and validates the options.
+// normalize 补全默认值并校验参数。
+func (o *DrainOptions) normalize() (*DrainOptions, error) {
+	normalized := &DrainOptions{TimeoutSeconds: defaultDrainTimeoutSeconds, OnTimeout: DrainTimeoutSavepoint}
+	if o == nil {
+		return normalized, nil
+	}
+	if o.TimeoutSeconds < 0 || o.TimeoutSeconds > maxDrainTimeoutSeconds {
+		return nil, fmt.Errorf("%w: timeout_seconds must be between 0 and %d", ErrInvalidDrainOptions, maxDrainTimeoutSeconds)
+	}
+	if o.TimeoutSeconds > 0 {
+		normalized.TimeoutSeconds = o.TimeoutSeconds
+	}
+	switch o.OnTimeout {
+	case "":
+	case DrainTimeoutSavepoint, DrainTimeoutFail:
+		normalized.OnTimeout = o.OnTimeout
+	default:
+		return nil, fmt.Errorf("%w: unknown on_timeout %q", ErrInvalidDrainOptions, o.OnTimeout)
+	}
+	return normalized, nil
+}
+
+// DrainEngineClient finds and stops the jobs running on an engine member.
+// DrainEngineClient 查找并停止在引擎成员上运行的作业。
+type DrainEngineClient interface {
+	// ListMemberJobs returns the IDs of running jobs with tasks on the member ("host:port").
+	// ListMemberJobs 返回在该成员（"host:port"）上有任务的运行中作业 ID。
+	ListMemberJobs(ctx context.Context, endpoint *EngineEndpoint, member string) ([]string, error)
+	// StopJobWithSavepoint stops a job after taking a savepoint.
+	// StopJobWithSavepoint 在生成保存点后停止作业。
+	StopJobWithSavepoint(ctx context.Context, endpoint *EngineEndpoint, jobID string) error
+}
+
+// SetDrainEngineClient sets the engine client used to drain nodes.
+// SetDrainEngineClient 设置用于排空节点的引擎客户端。
+func (s *Service) SetDrainEngineClient(client DrainEngineClient) {
+	s.drainClient = client
+}
+
+// DecommissionNode starts a scale task that drains a node, stops it, removes it from the cluster
+// and rewrites the member lists of the remaining nodes without restarting them.
+// DecommissionNode 启动一个扩缩容任务：排空节点、停止并从集群移除，再改写其余节点的成员列表（不重启其余节点）。
+func (s *Service) DecommissionNode(ctx context.Context, clusterID, nodeID uint, opts *DrainOptions, createdBy string) (*ScaleTask, error) {
+	drain, err := opts.normalize()
+	if err != nil {
+		return nil, err
+	}
+	return s.StartScale(ctx, clusterID, &ScaleClusterRequest{RemoveNodeIDs: []uint{nodeID}, Drain: drain}, createdBy)
+}
+
+// drainScaleNodes marks the nodes as draining and waits until no running job has tasks on them.
+// On failure the nodes are put back to running.
+// drainScaleNodes 将节点标记为排空中，并等待没有运行中作业在其上有任务；失败时节点恢复为运行状态。
+func (s *Service) drainScaleNodes(ctx context.Context, tracker *scaleTracker, removals, remaining []*ClusterNode, hosts map[uint]*HostInfo, opts *DrainOptions) error {
+	endpoints := drainEngineEndpoints(remaining, hosts)
+	if len(endpoints) == 0 {
+		return fmt.Errorf("no running node left to query the engine REST API / 没有可用于查询引擎 REST API 的运行中节点")
+	}
+	for _, node := range removals {
+		if err := s.repo.UpdateNodeStatus(ctx, node.ID, NodeStatusDraining); err != nil {
+			return err
+		}
+		tracker.logf("node %d is draining", node.ID)
+	}
+
+	for _, node := range removals {
+		host := hosts[node.HostID]
+		if host == nil {
+			continue
+		}
+		member := net.JoinHostPort(host.IPAddress, strconv.Itoa(node.HazelcastPort))
+		if err := s.drainNode(ctx, tracker, node, member, endpoints, opts); err != nil {
+			for _, drained := range removals {
+				_ = s.repo.UpdateNodeStatus(ctx, drained.ID, NodeStatusRunning)
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// drainNode waits (bounded) for the jobs on one member to finish, then applies the timeout action.
+// drainNode 有限等待单个成员上的作业结束，超时后执行相应的处理动作。
+func (s *Service) drainNode(ctx context.Context, tracker *scaleTracker, node *ClusterNode, member string, endpoints []*EngineEndpoint, opts *DrainOptions) error {
+	timeout := time.Duration(opts.TimeoutSeconds) * time.Second
+	jobs, endpoint, err := s.waitMemberJobs(ctx, tracker, member, endpoints, timeout)
+	if err != nil || len(jobs) == 0 {
+		if err == nil {
+			tracker.logf("node %d has no running tasks", node.ID)
+		}
+		return err
+	}
+
+	if opts.OnTimeout != DrainTimeoutSavepoint {
+		return fmt.Errorf("node %d still runs jobs %s after %s / 节点 %d 在 %s 后仍有作业 %s 在运行",
+			node.ID, strings.Join(jobs, ","), timeout, node.ID, timeout, strings.Join(jobs, ","))
+	}
+	for _, jobID := range jobs {
+		if err := s.drainClient.StopJobWithSavepoint(ctx, endpoint, jobID); err != nil {
+			return fmt.Errorf("stop job %s with savepoint: %w", jobID, err)
+		}
+		tracker.logf("stopped job %s on node %d with savepoint", jobID, node.ID)
+	}
+	jobs, _, err = s.waitMemberJobs(ctx, tracker, member, endpoints, drainSavepointTimeout)
+	if err != nil {
+		return err
+	}
+	if len(jobs) > 0 {
+		return fmt.Errorf("jobs %s did not stop after savepoint / 作业 %s 在保存点后未停止", strings.Join(jobs, ","), strings.Join(jobs, ","))
+	}
+	return nil
+}
+
+// waitMemberJobs polls the engine until no job runs on the member or the timeout expires,
+// returning the jobs still running and the endpoint that answered last.
+// waitMemberJobs 轮询引擎直到成员上没有作业或超时，返回仍在运行的作业及最后应答的端点。
+func (s *Service) waitMemberJobs(ctx context.Context, tracker *scaleTracker, member string, endpoints []*EngineEndpoint, timeout time.Duration) ([]string, *EngineEndpoint, error) {
+	deadline := time.Now().Add(timeout)
+	var lastJobs string
+	for {
+		jobs, endpoint, err := s.listMemberJobs(ctx, member, endpoints)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(jobs) == 0 || !time.Now().Before(deadline) {
+			return jobs, endpoint, nil
+		}
+		if joined := strings.Join(jobs, ","); joined != lastJobs {
+			tracker.logf("waiting for jobs %s on %s", joined, member)
+			lastJobs = joined
+		}
+		select {
+		case <-ctx.Done():
+			return nil, nil, ctx.Err()
+		case <-time.After(s.drainPollInterval):
+		}
+	}
+}
+
+// listMemberJobs queries the first endpoint that answers.
+// listMemberJobs 查询首个应答的端点。
+func (s *Service) listMemberJobs(ctx context.Context, member string, endpoints []*EngineEndpoint) ([]string, *EngineEndpoint, error) {
+	var lastErr error
+	for _, endpoint := range endpoints {
+		jobs, err := s.drainClient.ListMemberJobs(ctx, endpoint, member)
+		if err == nil {
+			return jobs, endpoint, nil
+		}
+		lastErr = err
+	}
+	return nil, nil, fmt.Errorf("query running jobs failed: %w / 查询运行中作业失败: %w", lastErr, lastErr)
+}
+
+// drainEngineEndpoints lists the REST endpoints of the nodes that stay in the cluster:
+// REST API V2 on the HTTP port first, then REST API V1 on the Hazelcast port.
+// drainEngineEndpoints 列出保留节点的 REST 端点：优先 HTTP 端口上的 REST API V2，其次 Hazelcast 端口上的 REST API V1。
+func drainEngineEndpoints(remaining []*ClusterNode, hosts map[uint]*HostInfo) []*EngineEndpoint {
+	endpoints := make([]*EngineEndpoint, 0, len(remaining)*2)
+	for _, legacy := range []bool{false, true} {
+		for _, node := range remaining {
+			host := hosts[node.HostID]
+			if host == nil || strings.TrimSpace(host.IPAddress) == "" || node.Status != NodeStatusRunning {
+				continue
+			}
+			port := node.APIPort
+			if legacy {
+				port = node.HazelcastPort
+			}
+			if port <= 0 {
+				continue
+			}
+			endpoints = append(endpoints, &EngineEndpoint{
+				BaseURL: "http://" + net.JoinHostPort(host.IPAddress, strconv.Itoa(port)),
+				Legacy:  legacy,
+			})
+		}
+	}
+	return endpoints
+}
@@ -0,0 +1,170 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cluster
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// engineLegacyRESTPrefix is the REST API V1 prefix served on the Hazelcast port.
+// engineLegacyRESTPrefix 是 Hazelcast 端口上 REST API V1 的路径前缀。
+const engineLegacyRESTPrefix = "/hazelcast/rest/maps"
+
+// EngineEndpoint is one SeaTunnel engine REST endpoint; Legacy endpoints serve REST API V1.
+// EngineEndpoint 是一个 SeaTunnel 引擎 REST 端点；Legacy 端点提供 REST API V1。
+type EngineEndpoint struct {
+	BaseURL string
+	Legacy  bool
+}
+
+func (e *EngineEndpoint) url(path string) string {
+	base := strings.TrimRight(e.BaseURL, "/")
+	if e.Legacy {
+		base += engineLegacyRESTPrefix
+	}
+	return base + path
+}
+
+// HTTPDrainEngineClient is the default DrainEngineClient backed by the engine REST API.
+// HTTPDrainEngineClient 是基于引擎 REST API 的默认 DrainEngineClient。
+type HTTPDrainEngineClient struct {
+	httpClient *http.Client
+}
+
+// NewHTTPDrainEngineClient creates an engine REST client for draining nodes.
+// NewHTTPDrainEngineClient 创建用于排空节点的引擎 REST 客户端。
+func NewHTTPDrainEngineClient() *HTTPDrainEngineClient {
+	return &HTTPDrainEngineClient{httpClient: &http.Client{Timeout: 10 * time.Second}}
+}
+
+// ListMemberJobs fetches GET /running-jobs and returns the jobs whose payload references the member,
+// either as "host:port" or in the Hazelcast "[host]:port" form used for task locations.
+// ListMemberJobs 获取 GET /running-jobs，返回负载中引用该成员的作业（"host:port" 或任务位置使用的 Hazelcast "[host]:port" 形式）。
+func (c *HTTPDrainEngineClient) ListMemberJobs(ctx context.Context, endpoint *EngineEndpoint, member string) ([]string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint.url("/running-jobs"), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 16<<20))
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("cluster: GET running-jobs failed: status=%d body=%s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	var jobs []map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if err := decoder.Decode(&jobs); err != nil {
+		return nil, fmt.Errorf("cluster: decode running-jobs: %w", err)
+	}
+	return memberJobIDs(jobs, member), nil
+}
+
+// StopJobWithSavepoint calls POST /stop-job with isStopWithSavePoint=true.
+// StopJobWithSavepoint 调用 POST /stop-job 并设置 isStopWithSavePoint=true。
+func (c *HTTPDrainEngineClient) StopJobWithSavepoint(ctx context.Context, endpoint *EngineEndpoint, jobID string) error {
+	var (
+		target string
+		body   io.Reader
+	)
+	if endpoint.Legacy {
+		target = endpoint.url("/stop-job") + "?jobId=" + url.QueryEscape(jobID)
+	} else {
+		payload, err := json.Marshal(map[string]interface{}{"jobId": jobID, "isStopWithSavePoint": true})
+		if err != nil {
+			return err
+		}
+		target = endpoint.url("/stop-job")
+		body = bytes.NewReader(payload)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target, body)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 4<<10))
+		return fmt.Errorf("cluster: stop job %s failed: status=%d body=%s", jobID, resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+	return nil
+}
+
+// memberJobIDs returns the IDs of jobs whose payload references the member address.
+// memberJobIDs 返回负载中引用该成员地址的作业 ID。
+func memberJobIDs(jobs []map[string]interface{}, member string) []string {
+	patterns := []string{member}
+	if host, port, err := net.SplitHostPort(member); err == nil {
+		patterns = append(patterns, "["+host+"]:"+port)
+	}
+	ids := make([]string, 0, len(jobs))
+	for _, job := range jobs {
+		if !referencesMember(job, patterns) {
+			continue
+		}
+		if id := strings.TrimSpace(fmt.Sprint(job["jobId"])); id != "" && job["jobId"] != nil {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+func referencesMember(value interface{}, patterns []string) bool {
+	switch typed := value.(type) {
+	case string:
+		for _, pattern := range patterns {
+			if strings.Contains(typed, pattern) {
+				return true
+			}
+		}
+	case map[string]interface{}:
+		for key, item := range typed {
+			if referencesMember(key, patterns) || referencesMember(item, patterns) {
+				return true
+			}
+		}
+	case []interface{}:
+		for _, item := range typed {
+			if referencesMember(item, patterns) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,197 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cluster
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	appconfig "github.com/seatunnel/seatunnelX/internal/apps/config"
+)
+
+// fakeDrainEngineClient reports the scripted jobs for each poll and records savepoint stops.
+// fakeDrainEngineClient 按轮询次数返回预设作业并记录保存点停止请求。
+type fakeDrainEngineClient struct {
+	polls     [][]string
+	calls     int
+	members   []string
+	savepoint []string
+}
+
+func (f *fakeDrainEngineClient) ListMemberJobs(ctx context.Context, endpoint *EngineEndpoint, member string) ([]string, error) {
+	f.members = append(f.members, member)
+	if len(f.polls) == 0 {
+		return nil, nil
+	}
+	index := f.calls
+	if index >= len(f.polls) {
+		index = len(f.polls) - 1
+	}
+	f.calls++
+	return f.polls[index], nil
+}
+
+func (f *fakeDrainEngineClient) StopJobWithSavepoint(ctx context.Context, endpoint *EngineEndpoint, jobID string) error {
+	f.savepoint = append(f.savepoint, jobID)
+	f.polls = [][]string{nil}
+	f.calls = 0
+	return nil
+}
+
+func newDecommissionTestService(t *testing.T, drain *fakeDrainEngineClient) (*Service, *Repository, *fileConfigAgentClient, *mockOperationAgentSender, *Cluster) {
+	t.Helper()
+	svc, repo, _ := newScaleTestService(t)
+	svc.drainPollInterval = 10 * time.Millisecond
+	ctx := context.Background()
+
+	hazelcast := strings.Replace(scaleTestHazelcast, "          - 10.0.0.1:5801\n", "          - 10.0.0.1:5801\n          - 10.0.0.2:5801\n", 1)
+	files := &fileConfigAgentClient{files: map[string]string{
+		"1/hazelcast.yaml":        hazelcast,
+		"1/hazelcast-client.yaml": scaleTestHazelcastClient,
+		"2/hazelcast.yaml":        hazelcast,
+		"2/hazelcast-client.yaml": scaleTestHazelcastClient,
+	}}
+	agentSender := &mockOperationAgentSender{}
+	svc.SetAgentCommandSender(agentSender)
+	svc.SetConfigAgentClient(files)
+	svc.SetNodeInstaller(&fakeNodeInstaller{})
+	svc.SetClusterConfigStore(&fakeClusterConfigStore{templates: map[appconfig.ConfigType]string{}})
+	svc.SetDrainEngineClient(drain)
+
+	cluster, err := svc.Create(ctx, &CreateClusterRequest{Name: "drain-cluster", DeploymentMode: DeploymentModeHybrid, Version: "2.3.12"})
+	if err != nil {
+		t.Fatalf("Create returned error: %v", err)
+	}
+	for _, hostID := range []uint{1, 2} {
+		node, err := svc.AddNode(ctx, cluster.ID, &AddNodeRequest{HostID: hostID, Role: NodeRoleMasterWorker, SkipPrecheck: true})
+		if err != nil {
+			t.Fatalf("AddNode(%d) returned error: %v", hostID, err)
+		}
+		if err := repo.UpdateNodeStatus(ctx, node.ID, NodeStatusRunning); err != nil {
+			t.Fatalf("UpdateNodeStatus returned error: %v", err)
+		}
+	}
+	if err := repo.UpdateStatus(ctx, cluster.ID, ClusterStatusRunning); err != nil {
+		t.Fatalf("UpdateStatus returned error: %v", err)
+	}
+	return svc, repo, files, agentSender, cluster
+}
+
+func TestService_DecommissionNode_drainsThenRemovesWithoutRestart(t *testing.T) {
+	drain := &fakeDrainEngineClient{polls: [][]string{{"1001"}, {"1001"}, nil}}
+	svc, repo, files, agentSender, cluster := newDecommissionTestService(t, drain)
+	ctx := context.Background()
+
+	nodes, err := repo.GetNodesByClusterID(ctx, cluster.ID)
+	if err != nil || len(nodes) != 2 {
+		t.Fatalf("expected 2 nodes, got %d (%v)", len(nodes), err)
+	}
+	removed := nodes[1]
+
+	if _, err := svc.DecommissionNode(ctx, cluster.ID, removed.ID, &DrainOptions{OnTimeout: "kill"}, "admin"); !errors.Is(err, ErrInvalidDrainOptions) {
+		t.Fatalf("expected ErrInvalidDrainOptions, got %v", err)
+	}
+
+	task, err := svc.DecommissionNode(ctx, cluster.ID, removed.ID, &DrainOptions{TimeoutSeconds: 30}, "admin")
+	if err != nil {
+		t.Fatalf("DecommissionNode returned error: %v", err)
+	}
+	svc.scaleWG.Wait()
+
+	stored, err := svc.GetScaleTask(ctx, cluster.ID, task.ID)
+	if err != nil {
+		t.Fatalf("GetScaleTask returned error: %v", err)
+	}
+	if stored.Status != ScaleTaskStatusSuccess || stored.Step != ScaleStepComplete {
+		t.Fatalf("expected successful task, got status=%s step=%s error=%s\n%s", stored.Status, stored.Step, stored.Error, stored.Log)
+	}
+	if len(drain.savepoint) != 0 {
+		t.Fatalf("jobs finished in time and must not be savepointed, got %v", drain.savepoint)
+	}
+	if len(drain.members) == 0 || drain.members[0] != "10.0.0.2:5801" {
+		t.Fatalf("expected drain queries for member 10.0.0.2:5801, got %v", drain.members)
+	}
+
+	var operations []string
+	for _, command := range agentSender.commands {
+		switch command.commandType {
+		case string(OperationStart), string(OperationStop), string(OperationRestart):
+			operations = append(operations, command.agentID+":"+command.commandType)
+		}
+	}
+	if strings.Join(operations, ",") != "agent-2:stop" {
+		t.Fatalf("expected only the decommissioned node to be stopped, got %v", operations)
+	}
+	if hazelcast := files.files["1/hazelcast.yaml"]; strings.Contains(hazelcast, "10.0.0.2:5801") {
+		t.Fatalf("remaining member list still contains the removed node:\n%s", hazelcast)
+	}
+	if nodes, err := repo.GetNodesByClusterID(ctx, cluster.ID); err != nil || len(nodes) != 1 {
+		t.Fatalf("expected 1 node after decommission, got %d (%v)", len(nodes), err)
+	}
+}
+
+func TestService_DecommissionNode_timeoutActions(t *testing.T) {
+	ctx := context.Background()
+
+	drain := &fakeDrainEngineClient{polls: [][]string{{"1001"}}}
+	svc, repo, _, _, cluster := newDecommissionTestService(t, drain)
+	nodes, _ := repo.GetNodesByClusterID(ctx, cluster.ID)
+	task, err := svc.DecommissionNode(ctx, cluster.ID, nodes[1].ID, &DrainOptions{TimeoutSeconds: 1, OnTimeout: DrainTimeoutFail}, "admin")
+	if err != nil {
+		t.Fatalf("DecommissionNode returned error: %v", err)
+	}
+	svc.scaleWG.Wait()
+	stored, _ := svc.GetScaleTask(ctx, cluster.ID, task.ID)
+	if stored.Status != ScaleTaskStatusFailed || stored.Step != ScaleStepDrain {
+		t.Fatalf("expected failed drain, got status=%s step=%s", stored.Status, stored.Step)
+	}
+	node, err := repo.GetNodeByID(ctx, nodes[1].ID)
+	if err != nil || node.Status != NodeStatusRunning {
+		t.Fatalf("expected node back to running, got %+v (%v)", node, err)
+	}
+
+	drain = &fakeDrainEngineClient{polls: [][]string{{"1001"}}}
+	svc, repo, _, _, cluster = newDecommissionTestService(t, drain)
+	nodes, _ = repo.GetNodesByClusterID(ctx, cluster.ID)
+	task, err = svc.DecommissionNode(ctx, cluster.ID, nodes[1].ID, &DrainOptions{TimeoutSeconds: 1}, "admin")
+	if err != nil {
+		t.Fatalf("DecommissionNode returned error: %v", err)
+	}
+	svc.scaleWG.Wait()
+	stored, _ = svc.GetScaleTask(ctx, cluster.ID, task.ID)
+	if stored.Status != ScaleTaskStatusSuccess {
+		t.Fatalf("expected success after savepoint, got status=%s error=%s\n%s", stored.Status, stored.Error, stored.Log)
+	}
+	if strings.Join(drain.savepoint, ",") != "1001" {
+		t.Fatalf("expected job 1001 to be stopped with savepoint, got %v", drain.savepoint)
+	}
+}
+
+func TestMemberJobIDs(t *testing.T) {
+	jobs := []map[string]interface{}{
+		{"jobId": "1", "jobDag": map[string]interface{}{"tasks": []interface{}{map[string]interface{}{"address": "[10.0.0.2]:5801"}}}},
+		{"jobId": "2", "jobDag": map[string]interface{}{"tasks": []interface{}{"10.0.0.3:5801"}}},
+		{"jobId": "3", "location": "10.0.0.2:5801"},
+	}
+	if got := strings.Join(memberJobIDs(jobs, "10.0.0.2:5801"), ","); got != "1,3" {
+		t.Fatalf("memberJobIDs = %q, want 1,3", got)
+	}
+}
@@ -0,0 +1,169 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Diagnose actions supported by the agent DIAGNOSE command.
+// Agent DIAGNOSE 指令支持的诊断采集项。
+const (
+	DiagnoseActionThreadDump    = "thread_dump"
+	DiagnoseActionHeapInfo      = "heap_info"
+	DiagnoseActionHeapHistogram = "heap_histogram"
+)
+
+var validDiagnoseActions = map[string]bool{
+	DiagnoseActionThreadDump:    true,
+	DiagnoseActionHeapInfo:      true,
+	DiagnoseActionHeapHistogram: true,
+}
+
+// DiagnoseNodeRequest selects what to collect from a node JVM; empty means thread dump and heap info.
+// DiagnoseNodeRequest 选择从节点 JVM 采集的内容，为空时采集线程栈与堆信息。
+type DiagnoseNodeRequest struct {
+	Actions []string `json:"actions"`
+}
+
+// NodeDiagnosisSection is the output of one diagnose action.
+// NodeDiagnosisSection 是单个诊断采集项的输出。
+type NodeDiagnosisSection struct {
+	Action string `json:"action"`
+	Tool   string `json:"tool,omitempty"`
+	// OutputPath is where the agent kept the full output on the node.
+	// OutputPath 是 Agent 在节点上保存完整输出的路径。
+	OutputPath string `json:"output_path,omitempty"`
+	SizeBytes  int64  `json:"size_bytes"`
+	// Truncated is true when Content only holds the head of the output.
+	// Truncated 为 true 表示 Content 仅包含输出的开头部分。
+	Truncated bool   `json:"truncated"`
+	Content   string `json:"content,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// NodeDiagnosis is the JVM diagnosis collected from a cluster node.
+// NodeDiagnosis 是从集群节点采集的 JVM 诊断结果。
+type NodeDiagnosis struct {
+	ClusterID   uint                    `json:"cluster_id"`
+	NodeID      uint                    `json:"node_id"`
+	HostID      uint                    `json:"host_id"`
+	PID         int                     `json:"pid"`
+	Role        string                  `json:"role"`
+	InstallDir  string                  `json:"install_dir"`
+	Sections    []*NodeDiagnosisSection `json:"sections"`
+	CollectedAt time.Time               `json:"collected_at"`
+}
+
+// normalizeDiagnoseActions validates and de-duplicates the requested actions.
+// normalizeDiagnoseActions 校验并去重请求的采集项。
+func normalizeDiagnoseActions(actions []string) ([]string, error) {
+	seen := make(map[string]bool, len(actions))
+	normalized := make([]string, 0, len(actions))
+	for _, action := range actions {
+		action = strings.ToLower(strings.TrimSpace(action))
+		if action == "" || seen[action] {
+			continue
+		}
+		if !validDiagnoseActions[action] {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidDiagnoseAction, action)
+		}
+		seen[action] = true
+		normalized = append(normalized, action)
+	}
+	return normalized, nil
+}
+
+// DiagnoseNode asks the node's agent to attach to the SeaTunnel JVM and collect
+// thread dumps, heap info or class histograms.
+// DiagnoseNode 请求节点所在 Agent 附加到 SeaTunnel JVM，采集线程栈、堆信息或类直方图。
+func (s *Service) DiagnoseNode(ctx context.Context, clusterID, nodeID uint, req *DiagnoseNodeRequest) (*NodeDiagnosis, error) {
+	if req == nil {
+		req = &DiagnoseNodeRequest{}
+	}
+	actions, err := normalizeDiagnoseActions(req.Actions)
+	if err != nil {
+		return nil, err
+	}
+
+	node, err := s.repo.GetNodeByID(ctx, nodeID)
+	if err != nil {
+		return nil, err
+	}
+	if node.ClusterID != clusterID {
+		return nil, ErrNodeNotFound
+	}
+	cluster, err := s.repo.GetByID(ctx, clusterID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.hostProvider == nil {
+		return nil, fmt.Errorf("host provider not configured / 主机提供者未配置")
+	}
+	hostInfo, err := s.hostProvider.GetHostByID(ctx, node.HostID)
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(hostInfo.AgentID) == "" {
+		return nil, ErrNodeAgentNotInstalled
+	}
+	if !hostInfo.IsOnline(s.heartbeatTimeout) {
+		return nil, fmt.Errorf("host is offline / 主机离线")
+	}
+	if s.agentSender == nil {
+		return nil, fmt.Errorf("agent sender not configured / Agent 发送器未配置")
+	}
+
+	installDir := node.InstallDir
+	if installDir == "" {
+		installDir = "/opt/seatunnel"
+	}
+	role := string(node.Role)
+	if cluster.DeploymentMode == DeploymentModeHybrid {
+		role = "hybrid"
+	}
+	params := map[string]string{
+		"install_dir": installDir,
+		"role":        role,
+	}
+	if len(actions) > 0 {
+		params["actions"] = strings.Join(actions, ",")
+	}
+
+	success, message, err := s.agentSender.SendCommand(ctx, hostInfo.AgentID, "diagnose", params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to diagnose node: %w / 节点诊断失败: %v", err, err)
+	}
+	if !success {
+		return nil, fmt.Errorf("failed to diagnose node: %s / 节点诊断失败: %s", message, message)
+	}
+
+	diagnosis := &NodeDiagnosis{}
+	if err := json.Unmarshal([]byte(message), diagnosis); err != nil {
+		return nil, fmt.Errorf("failed to decode diagnose result: %v / 解析诊断结果失败: %v", err, err)
+	}
+	diagnosis.ClusterID = clusterID
+	diagnosis.NodeID = nodeID
+	diagnosis.HostID = node.HostID
+	return diagnosis, nil
+}
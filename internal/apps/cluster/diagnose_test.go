@@ -0,0 +1,107 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cluster
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func newDiagnoseTestService(t *testing.T, mode DeploymentMode, role NodeRole, send func(ctx context.Context, agentID string, commandType string, params map[string]string) (bool, string, error)) (*Service, *Cluster, *ClusterNode) {
+	t.Helper()
+	svc, _, _ := newScaleTestService(t)
+	svc.SetAgentCommandSender(&scriptedAgentSender{send: send})
+	ctx := context.Background()
+
+	cluster, err := svc.Create(ctx, &CreateClusterRequest{Name: "diagnose-cluster", DeploymentMode: mode, Version: "2.3.12"})
+	if err != nil {
+		t.Fatalf("Create returned error: %v", err)
+	}
+	node, err := svc.AddNode(ctx, cluster.ID, &AddNodeRequest{HostID: 1, Role: role, InstallDir: "/data/seatunnel", SkipPrecheck: true})
+	if err != nil {
+		t.Fatalf("AddNode returned error: %v", err)
+	}
+	return svc, cluster, node
+}
+
+func TestService_DiagnoseNode_sendsDiagnoseCommandAndDecodesSections(t *testing.T) {
+	var gotType string
+	var gotParams map[string]string
+	svc, cluster, node := newDiagnoseTestService(t, DeploymentModeSeparated, NodeRoleWorker, func(ctx context.Context, agentID string, commandType string, params map[string]string) (bool, string, error) {
+		gotType = commandType
+		gotParams = params
+		return true, `{"pid":4321,"role":"worker","install_dir":"/data/seatunnel","sections":[{"action":"thread_dump","tool":"jattach","size_bytes":5,"content":"stack"},{"action":"heap_histogram","error":"jmap => denied"}]}`, nil
+	})
+
+	diagnosis, err := svc.DiagnoseNode(context.Background(), cluster.ID, node.ID, &DiagnoseNodeRequest{Actions: []string{" Thread_Dump", "heap_histogram", "thread_dump"}})
+	if err != nil {
+		t.Fatalf("DiagnoseNode returned error: %v", err)
+	}
+
+	if gotType != "diagnose" {
+		t.Fatalf("expected diagnose command, got %q", gotType)
+	}
+	if gotParams["actions"] != "thread_dump,heap_histogram" || gotParams["role"] != "worker" || gotParams["install_dir"] != "/data/seatunnel" {
+		t.Fatalf("unexpected params: %#v", gotParams)
+	}
+	if diagnosis.PID != 4321 || diagnosis.NodeID != node.ID || diagnosis.HostID != 1 {
+		t.Fatalf("unexpected diagnosis: %#v", diagnosis)
+	}
+	if len(diagnosis.Sections) != 2 || diagnosis.Sections[0].Content != "stack" || diagnosis.Sections[1].Error == "" {
+		t.Fatalf("unexpected sections: %#v", diagnosis.Sections)
+	}
+}
+
+func TestService_DiagnoseNode_usesHybridRoleAndDefaultActions(t *testing.T) {
+	var gotParams map[string]string
+	svc, cluster, node := newDiagnoseTestService(t, DeploymentModeHybrid, NodeRoleMasterWorker, func(ctx context.Context, agentID string, commandType string, params map[string]string) (bool, string, error) {
+		gotParams = params
+		return true, `{"pid":1,"sections":[]}`, nil
+	})
+
+	if _, err := svc.DiagnoseNode(context.Background(), cluster.ID, node.ID, nil); err != nil {
+		t.Fatalf("DiagnoseNode returned error: %v", err)
+	}
+	if gotParams["role"] != "hybrid" {
+		t.Fatalf("expected hybrid role, got %q", gotParams["role"])
+	}
+	if _, ok := gotParams["actions"]; ok {
+		t.Fatalf("expected agent default actions, got %q", gotParams["actions"])
+	}
+}
+
+func TestService_DiagnoseNode_rejectsUnknownActionAndReportsAgentFailure(t *testing.T) {
+	svc, cluster, node := newDiagnoseTestService(t, DeploymentModeHybrid, NodeRoleMasterWorker, func(ctx context.Context, agentID string, commandType string, params map[string]string) (bool, string, error) {
+		return false, "no SeaTunnel process found", nil
+	})
+
+	_, err := svc.DiagnoseNode(context.Background(), cluster.ID, node.ID, &DiagnoseNodeRequest{Actions: []string{"heap_dump"}})
+	if !errors.Is(err, ErrInvalidDiagnoseAction) {
+		t.Fatalf("expected ErrInvalidDiagnoseAction, got %v", err)
+	}
+
+	_, err = svc.DiagnoseNode(context.Background(), cluster.ID, node.ID, nil)
+	if err == nil {
+		t.Fatal("expected agent failure to be returned")
+	}
+
+	if _, err := svc.DiagnoseNode(context.Background(), cluster.ID+1, node.ID, nil); !errors.Is(err, ErrNodeNotFound) {
+		t.Fatalf("expected ErrNodeNotFound for foreign cluster, got %v", err)
+	}
+}
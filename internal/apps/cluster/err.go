@@ -49,6 +49,9 @@ var (
 	// ErrInvalidWorkerPort indicates an invalid worker port.
 	// ErrInvalidWorkerPort 表示无效的 worker 端口。
 	ErrInvalidWorkerPort = errors.New("cluster: worker port must be valid when provided")
+	// ErrNodePortConflict indicates a node port collides with its own ports or another node on the same host.
+	// ErrNodePortConflict 表示节点端口与自身其他端口或同一主机上的其他节点冲突。
+	ErrNodePortConflict = errors.New("cluster: node port collides with another port on the same host")
 	// ErrNodeBatchEntriesRequired indicates batch add request has no entries.
 	// ErrNodeBatchEntriesRequired 表示批量加节点请求未提供任何条目。
 	ErrNodeBatchEntriesRequired = errors.New("cluster: at least one node entry is required")
@@ -58,6 +61,69 @@ var (
 	// ErrPrecheckFailed indicates the node precheck failed.
 	// ErrPrecheckFailed 表示节点预检查失败。
 	ErrPrecheckFailed = errors.New("cluster: node precheck failed")
+	// ErrInvalidNodeSelector indicates a node selector has an unknown role or cannot be parsed.
+	// ErrInvalidNodeSelector 表示节点选择器的角色未知或无法解析。
+	ErrInvalidNodeSelector = errors.New("cluster: invalid node selector")
+	// ErrNodeSelectorMismatch indicates the host labels do not satisfy the selector of the node role.
+	// ErrNodeSelectorMismatch 表示主机标签不满足该节点角色的选择器。
+	ErrNodeSelectorMismatch = errors.New("cluster: host labels do not match the node selector of this role")
+	// ErrCrossProjectNode indicates the host and the cluster belong to different projects.
+	// ErrCrossProjectNode 表示主机与集群属于不同的项目。
+	ErrCrossProjectNode = errors.New("cluster: host and cluster belong to different projects")
+	// ErrClusterNotRecycled indicates the cluster is not in the recycle bin.
+	// ErrClusterNotRecycled 表示集群不在回收站中。
+	ErrClusterNotRecycled = errors.New("cluster: cluster is not in the recycle bin")
+	// ErrClusterNotRunning indicates the operation requires a running cluster.
+	// ErrClusterNotRunning 表示该操作要求集群处于运行状态。
+	ErrClusterNotRunning = errors.New("cluster: cluster is not running")
+	// ErrScaleUnavailable indicates the installer, agent or config dependencies of scaling are not configured.
+	// ErrScaleUnavailable 表示扩缩容所需的安装器、Agent 或配置依赖未配置。
+	ErrScaleUnavailable = errors.New("cluster: scaling is not available")
+	// ErrScaleRequestEmpty indicates a scale request adds and removes nothing.
+	// ErrScaleRequestEmpty 表示扩缩容请求既未添加也未移除节点。
+	ErrScaleRequestEmpty = errors.New("cluster: scale request must add or remove at least one node")
+	// ErrScaleDuplicateHost indicates a host appears more than once or already belongs to the cluster.
+	// ErrScaleDuplicateHost 表示主机重复出现或已属于该集群。
+	ErrScaleDuplicateHost = errors.New("cluster: host is duplicated or already in the cluster")
+	// ErrScaleRemoveNotAllowed indicates the node cannot be removed by scaling.
+	// ErrScaleRemoveNotAllowed 表示该节点不能通过缩容移除。
+	ErrScaleRemoveNotAllowed = errors.New("cluster: only worker nodes can be removed and at least one master must remain")
+	// ErrScaleInProgress indicates another scale task of the cluster is still running.
+	// ErrScaleInProgress 表示该集群已有扩缩容任务正在执行。
+	ErrScaleInProgress = errors.New("cluster: a scale task is already in progress")
+	// ErrScaleTaskNotFound indicates the scale task does not exist.
+	// ErrScaleTaskNotFound 表示扩缩容任务不存在。
+	ErrScaleTaskNotFound = errors.New("cluster: scale task not found")
+	// ErrInvalidRollingOptions indicates rolling operation options are out of range.
+	// ErrInvalidRollingOptions 表示滚动操作参数超出范围。
+	ErrInvalidRollingOptions = errors.New("cluster: invalid rolling operation options")
+	// ErrInvalidOperationMode indicates an unknown cluster operation mode.
+	// ErrInvalidOperationMode 表示未知的集群操作模式。
+	ErrInvalidOperationMode = errors.New("cluster: invalid operation mode")
+	// ErrAdoptUnavailable indicates the agent dependencies needed to adopt a cluster are not configured.
+	// ErrAdoptUnavailable 表示纳管集群所需的 Agent 依赖未配置。
+	ErrAdoptUnavailable = errors.New("cluster: cluster adoption is not available")
+	// ErrAdoptInspectFailed indicates an agent could not inspect the installation to adopt.
+	// ErrAdoptInspectFailed 表示 Agent 无法检查待纳管的安装。
+	ErrAdoptInspectFailed = errors.New("cluster: failed to inspect installation")
+	// ErrAdoptVersionMismatch indicates the adopted nodes run different or unknown SeaTunnel versions.
+	// ErrAdoptVersionMismatch 表示待纳管节点的 SeaTunnel 版本不一致或无法识别。
+	ErrAdoptVersionMismatch = errors.New("cluster: adopted nodes must run the same known SeaTunnel version")
+	// ErrAdoptRoleUnknown indicates the role of a stopped node in a separated installation cannot be detected.
+	// ErrAdoptRoleUnknown 表示无法检测分离模式安装中未运行节点的角色。
+	ErrAdoptRoleUnknown = errors.New("cluster: node role cannot be detected and must be specified")
+	// ErrAdoptAlreadyManaged indicates the installation already belongs to a managed cluster.
+	// ErrAdoptAlreadyManaged 表示该安装已属于某个被管理的集群。
+	ErrAdoptAlreadyManaged = errors.New("cluster: installation is already managed")
+	// ErrJVMTuningUnavailable indicates the config agent client needed to rewrite jvm_options is not configured.
+	// ErrJVMTuningUnavailable 表示改写 jvm_options 所需的配置 Agent 客户端未配置。
+	ErrJVMTuningUnavailable = errors.New("cluster: jvm tuning is not available")
+	// ErrInvalidDrainOptions indicates node drain options are out of range.
+	// ErrInvalidDrainOptions 表示节点排空参数超出范围。
+	ErrInvalidDrainOptions = errors.New("cluster: invalid drain options")
+	// ErrInvalidDiagnoseAction indicates an unsupported JVM diagnose action.
+	// ErrInvalidDiagnoseAction 表示不支持的 JVM 诊断采集项。
+	ErrInvalidDiagnoseAction = errors.New("cluster: invalid diagnose action")
 )
 
 // Error codes for cluster management operations.
@@ -33,7 +33,11 @@ import (
 	"github.com/seatunnel/seatunnelX/internal/apps/audit"
 	"github.com/seatunnel/seatunnelX/internal/apps/auth"
 	installerapp "github.com/seatunnel/seatunnelX/internal/apps/installer"
+	"github.com/seatunnel/seatunnelX/internal/apps/response"
 	"github.com/seatunnel/seatunnelX/internal/logger"
+	"github.com/seatunnel/seatunnelX/internal/pkg/errcode"
+	"github.com/seatunnel/seatunnelX/internal/pkg/jvmopts"
+	"github.com/seatunnel/seatunnelX/internal/pkg/pagex"
 )
 
 // Handler provides HTTP handlers for cluster management operations.
@@ -124,18 +128,35 @@ func (h *Handler) buildNodeOperationEvent(ctx context.Context, clusterID uint, n
 // ListClustersRequest represents the request for listing clusters.
 // ListClustersRequest 表示获取集群列表的请求。
 type ListClustersRequest struct {
-	Current        int            `json:"current" form:"current" binding:"min=1"`
-	Size           int            `json:"size" form:"size" binding:"min=1,max=100"`
+	pagex.Query
+	pagex.TimeQuery
 	Name           string         `json:"name" form:"name"`
 	Status         ClusterStatus  `json:"status" form:"status"`
 	DeploymentMode DeploymentMode `json:"deployment_mode" form:"deployment_mode"`
+	// HostID keeps only clusters that have a node on the host
+	// HostID 仅保留在该主机上有节点的集群
+	HostID uint `json:"host_id" form:"host_id"`
+}
+
+// clusterListSpec defines paging and the sortable fields of the cluster list.
+// clusterListSpec 定义集群列表的分页方式和可排序字段。
+var clusterListSpec = pagex.Spec{
+	Fields: map[string]string{
+		"name":            "name",
+		"status":          "status",
+		"version":         "version",
+		"deployment_mode": "deployment_mode",
+		"created_at":      "created_at",
+		"updated_at":      "updated_at",
+	},
+	DefaultSort: "-created_at",
 }
 
 // ListClustersResponse represents the response for listing clusters.
 // ListClustersResponse 表示获取集群列表的响应。
 type ListClustersResponse struct {
-	ErrorMsg string `json:"error_msg"`
-	Data     *struct {
+	response.Meta
+	Data *struct {
 		Total    int64          `json:"total"`
 		Clusters []*ClusterInfo `json:"clusters"`
 	} `json:"data"`
@@ -144,130 +165,207 @@ type ListClustersResponse struct {
 // CreateClusterResponse represents the response for creating a cluster.
 // CreateClusterResponse 表示创建集群的响应。
 type CreateClusterResponse struct {
-	ErrorMsg string       `json:"error_msg"`
-	Data     *ClusterInfo `json:"data"`
+	response.Meta
+	Data *ClusterInfo `json:"data"`
 }
 
 // GetClusterResponse represents the response for getting a cluster.
 // GetClusterResponse 表示获取集群详情的响应。
 type GetClusterResponse struct {
-	ErrorMsg string       `json:"error_msg"`
-	Data     *ClusterInfo `json:"data"`
+	response.Meta
+	Data *ClusterInfo `json:"data"`
 }
 
 // UpdateClusterResponse represents the response for updating a cluster.
 // UpdateClusterResponse 表示更新集群的响应。
 type UpdateClusterResponse struct {
-	ErrorMsg string       `json:"error_msg"`
-	Data     *ClusterInfo `json:"data"`
+	response.Meta
+	Data *ClusterInfo `json:"data"`
 }
 
-// DeleteClusterResponse represents the response for deleting a cluster.
-// DeleteClusterResponse 表示删除集群的响应。
+// DeleteClusterResponse represents the response for deleting a cluster; data is the recycle bin entry.
+// DeleteClusterResponse 表示删除集群的响应；data 为回收站条目。
 type DeleteClusterResponse struct {
-	ErrorMsg string `json:"error_msg"`
-	Data     any    `json:"data"`
+	response.Meta
+	Data *RecycledCluster `json:"data"`
+}
+
+// ListRecycledClustersResponse represents the response for listing the cluster recycle bin.
+// ListRecycledClustersResponse 表示列出集群回收站的响应。
+type ListRecycledClustersResponse struct {
+	response.Meta
+	Data []*RecycledCluster `json:"data"`
+}
+
+// RestoreClusterResponse represents the response for restoring a cluster from the recycle bin.
+// RestoreClusterResponse 表示从回收站恢复集群的响应。
+type RestoreClusterResponse struct {
+	response.Meta
+	Data *ClusterInfo `json:"data"`
+}
+
+// PurgeClusterResponse represents the response for purging a recycled cluster; data is the teardown report.
+// PurgeClusterResponse 表示清理回收站集群的响应；data 为拆除报告。
+type PurgeClusterResponse struct {
+	response.Meta
+	Data *TeardownReport `json:"data"`
+}
+
+// GetClusterJVMResponse represents the response for getting cluster JVM settings.
+// GetClusterJVMResponse 表示获取集群 JVM 配置的响应。
+type GetClusterJVMResponse struct {
+	response.Meta
+	Data *JVMConfig `json:"data"`
+}
+
+// PreviewClusterJVMResponse represents the response for previewing cluster JVM settings.
+// PreviewClusterJVMResponse 表示预览集群 JVM 配置的响应。
+type PreviewClusterJVMResponse struct {
+	response.Meta
+	Data *JVMPreviewResult `json:"data"`
+}
+
+// UpdateClusterJVMResponse represents the response for updating cluster JVM settings.
+// UpdateClusterJVMResponse 表示更新集群 JVM 配置的响应。
+type UpdateClusterJVMResponse struct {
+	response.Meta
+	Data *JVMUpdateResult `json:"data"`
 }
 
 // AddNodeResponse represents the response for adding a node to a cluster.
 // AddNodeResponse 表示向集群添加节点的响应。
 type AddNodeResponse struct {
-	ErrorMsg string    `json:"error_msg"`
-	Data     *NodeInfo `json:"data"`
+	response.Meta
+	Data *NodeInfo `json:"data"`
 }
 
 // AddNodesResponse represents the response for batch-adding nodes to a cluster.
 // AddNodesResponse 表示批量向集群添加节点的响应。
 type AddNodesResponse struct {
-	ErrorMsg string      `json:"error_msg"`
-	Data     []*NodeInfo `json:"data"`
+	response.Meta
+	Data []*NodeInfo `json:"data"`
 }
 
 // RemoveNodeResponse represents the response for removing a node from a cluster.
 // RemoveNodeResponse 表示从集群移除节点的响应。
 type RemoveNodeResponse struct {
-	ErrorMsg string `json:"error_msg"`
-	Data     any    `json:"data"`
+	response.Meta
+	Data any `json:"data"`
+}
+
+// AdoptClusterResponse represents the response for adopting an existing cluster.
+// AdoptClusterResponse 表示纳管已有集群的响应。
+type AdoptClusterResponse struct {
+	response.Meta
+	Data *AdoptClusterResult `json:"data"`
+}
+
+// ScaleTaskResponse represents the response for a single scale task.
+// ScaleTaskResponse 表示单个扩缩容任务的响应。
+type ScaleTaskResponse struct {
+	response.Meta
+	Data *ScaleTask `json:"data"`
+}
+
+// ListScaleTasksResponse represents the response for listing scale tasks.
+// ListScaleTasksResponse 表示扩缩容任务列表的响应。
+type ListScaleTasksResponse struct {
+	response.Meta
+	Data []*ScaleTask `json:"data"`
 }
 
 // GetNodesResponse represents the response for getting cluster nodes.
 // GetNodesResponse 表示获取集群节点列表的响应。
 type GetNodesResponse struct {
-	ErrorMsg string      `json:"error_msg"`
-	Data     []*NodeInfo `json:"data"`
+	response.Meta
+	Data []*NodeInfo `json:"data"`
 }
 
 // ClusterOperationResponse represents the response for cluster operations (start/stop/restart).
 // ClusterOperationResponse 表示集群操作（启动/停止/重启）的响应。
 type ClusterOperationResponse struct {
-	ErrorMsg string           `json:"error_msg"`
-	Data     *OperationResult `json:"data"`
+	response.Meta
+	Data *OperationResult `json:"data"`
 }
 
 // GetClusterStatusResponse represents the response for getting cluster status.
 // GetClusterStatusResponse 表示获取集群状态的响应。
 type GetClusterStatusResponse struct {
-	ErrorMsg string             `json:"error_msg"`
-	Data     *ClusterStatusInfo `json:"data"`
+	response.Meta
+	Data *ClusterStatusInfo `json:"data"`
+}
+
+// GetClusterHealthResponse represents the response for getting node health check states.
+// GetClusterHealthResponse 表示获取节点健康检查状态的响应。
+type GetClusterHealthResponse struct {
+	response.Meta
+	Data []*NodeHealth `json:"data"`
 }
 
 // GetRuntimeStorageResponse represents runtime storage details response.
 // GetRuntimeStorageResponse 表示运行时存储详情响应。
 type GetRuntimeStorageResponse struct {
-	ErrorMsg string                 `json:"error_msg"`
-	Data     *RuntimeStorageDetails `json:"data"`
+	response.Meta
+	Data *RuntimeStorageDetails `json:"data"`
 }
 
 // CleanupRuntimeStorageResponse represents runtime storage cleanup response.
 // CleanupRuntimeStorageResponse 表示运行时存储清理响应。
 type CleanupRuntimeStorageResponse struct {
-	ErrorMsg string                       `json:"error_msg"`
-	Data     *RuntimeStorageCleanupResult `json:"data"`
+	response.Meta
+	Data *RuntimeStorageCleanupResult `json:"data"`
 }
 
 // ValidateRuntimeStorageResponse represents runtime storage connectivity validation response.
 // ValidateRuntimeStorageResponse 表示运行时存储连通性校验响应。
 type ValidateRuntimeStorageResponse struct {
-	ErrorMsg string                                       `json:"error_msg"`
-	Data     *installerapp.RuntimeStorageValidationResult `json:"data"`
+	response.Meta
+	Data *installerapp.RuntimeStorageValidationResult `json:"data"`
 }
 
 type ListRuntimeStorageResponse struct {
-	ErrorMsg string                    `json:"error_msg"`
-	Data     *RuntimeStorageListResult `json:"data"`
+	response.Meta
+	Data *RuntimeStorageListResult `json:"data"`
 }
 
 type PreviewRuntimeStorageResponse struct {
-	ErrorMsg string                       `json:"error_msg"`
-	Data     *RuntimeStoragePreviewResult `json:"data"`
+	response.Meta
+	Data *RuntimeStoragePreviewResult `json:"data"`
 }
 
 type InspectCheckpointRuntimeStorageResponse struct {
-	ErrorMsg string                                 `json:"error_msg"`
-	Data     *RuntimeStorageCheckpointInspectResult `json:"data"`
+	response.Meta
+	Data *RuntimeStorageCheckpointInspectResult `json:"data"`
 }
 
 type InspectIMAPRuntimeStorageResponse struct {
-	ErrorMsg string                           `json:"error_msg"`
-	Data     *RuntimeStorageIMAPInspectResult `json:"data"`
+	response.Meta
+	Data *RuntimeStorageIMAPInspectResult `json:"data"`
 }
 
 // SeatunnelXJavaProxyResponse represents a managed seatunnelx-java-proxy response.
 type SeatunnelXJavaProxyResponse struct {
-	ErrorMsg string                     `json:"error_msg"`
-	Data     *SeatunnelXJavaProxyStatus `json:"data"`
+	response.Meta
+	Data *SeatunnelXJavaProxyStatus `json:"data"`
 }
 
 type SeatunnelXJavaProxyLogPreviewResponse struct {
-	ErrorMsg string                               `json:"error_msg"`
-	Data     *SeatunnelXJavaProxyLogPreviewResult `json:"data"`
+	response.Meta
+	Data *SeatunnelXJavaProxyLogPreviewResult `json:"data"`
 }
 
 // PrecheckNodeResponse represents the response for node precheck.
 // PrecheckNodeResponse 表示节点预检查的响应。
 type PrecheckNodeResponse struct {
-	ErrorMsg string          `json:"error_msg"`
-	Data     *PrecheckResult `json:"data"`
+	response.Meta
+	Data *PrecheckResult `json:"data"`
+}
+
+// ClusterPrecheckResponse represents the response for the cluster connectivity precheck.
+// ClusterPrecheckResponse 表示集群连通性预检查的响应。
+type ClusterPrecheckResponse struct {
+	response.Meta
+	Data *ClusterPrecheckResult `json:"data"`
 }
 
 // ==================== Cluster CRUD Handlers 集群 CRUD 处理器 ====================
@@ -283,36 +381,78 @@ type PrecheckNodeResponse struct {
 func (h *Handler) CreateCluster(c *gin.Context) {
 	var req CreateClusterRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, CreateClusterResponse{ErrorMsg: err.Error()})
+		response.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if !auth.AuthorizeProject(c, req.ProjectID) {
+		return
+	}
+	hostIDs := make([]uint, 0, len(req.Nodes))
+	for _, node := range req.Nodes {
+		hostIDs = append(hostIDs, node.HostID)
+	}
+	if !authorizeHosts(c, hostIDs...) {
 		return
 	}
 
 	cluster, err := h.service.Create(c.Request.Context(), &req)
 	if err != nil {
-		statusCode := h.getStatusCodeForError(err)
-		c.JSON(statusCode, CreateClusterResponse{ErrorMsg: err.Error()})
+		statusCode := h.errorStatus(c, err)
+		response.Error(c, statusCode, err.Error())
 		return
 	}
 
 	_ = audit.RecordFromGin(c, h.auditRepo, auth.GetUserIDFromContext(c), auth.GetUsernameFromContext(c),
 		"create", "cluster", audit.UintID(cluster.ID), cluster.Name, audit.AuditDetails{"trigger": "manual"})
 	logger.InfoF(c.Request.Context(), "[Cluster] 创建集群成功: %s (mode: %s)", cluster.Name, cluster.DeploymentMode)
-	c.JSON(http.StatusOK, CreateClusterResponse{Data: cluster.ToClusterInfo()})
+	response.OK(c, cluster.ToClusterInfo())
+}
+
+// AdoptCluster handles POST /api/v1/clusters/adopt - imports an externally installed cluster without reinstalling.
+// AdoptCluster 处理 POST /api/v1/clusters/adopt - 在不重装的情况下导入外部安装的集群。
+func (h *Handler) AdoptCluster(c *gin.Context) {
+	var req AdoptClusterRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	hostIDs := make([]uint, 0, len(req.Nodes))
+	for _, node := range req.Nodes {
+		hostIDs = append(hostIDs, node.HostID)
+	}
+	if !authorizeHosts(c, hostIDs...) {
+		return
+	}
+
+	result, err := h.service.AdoptCluster(c.Request.Context(), &req, uint(auth.GetUserIDFromContext(c)))
+	if err != nil {
+		response.Error(c, h.errorStatus(c, err), err.Error())
+		return
+	}
+
+	_ = audit.RecordFromGin(c, h.auditRepo, auth.GetUserIDFromContext(c), auth.GetUsernameFromContext(c),
+		"adopt", "cluster", audit.UintID(result.Cluster.ID), result.Cluster.Name, audit.AuditDetails{
+			"trigger": "manual",
+			"version": result.Cluster.Version,
+			"nodes":   len(result.Nodes),
+		})
+	logger.InfoF(c.Request.Context(), "[Cluster] 纳管集群成功: %s (version: %s, nodes: %d)", result.Cluster.Name, result.Cluster.Version, len(result.Nodes))
+	response.OK(c, result)
 }
 
 // GetSeatunnelXJavaProxyStatus handles GET /api/v1/clusters/:id/seatunnelx-java-proxy/status.
 func (h *Handler) GetSeatunnelXJavaProxyStatus(c *gin.Context) {
 	clusterID, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, SeatunnelXJavaProxyResponse{ErrorMsg: "invalid cluster id"})
+		response.Error(c, http.StatusBadRequest, "invalid cluster id")
 		return
 	}
 	status, err := h.service.GetSeatunnelXJavaProxyStatus(c.Request.Context(), uint(clusterID))
 	if err != nil {
-		c.JSON(h.getStatusCodeForError(err), SeatunnelXJavaProxyResponse{ErrorMsg: err.Error(), Data: status})
+		response.ErrorWithData(c, h.errorStatus(c, err), err.Error(), status)
 		return
 	}
-	c.JSON(http.StatusOK, SeatunnelXJavaProxyResponse{Data: status})
+	response.OK(c, status)
 }
 
 // StartSeatunnelXJavaProxy handles POST /api/v1/clusters/:id/seatunnelx-java-proxy/start.
@@ -340,38 +480,38 @@ func (h *Handler) RestartSeatunnelXJavaProxy(c *gin.Context) {
 func (h *Handler) PreviewSeatunnelXJavaProxyServiceLog(c *gin.Context) {
 	clusterID, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, SeatunnelXJavaProxyLogPreviewResponse{ErrorMsg: "invalid cluster id"})
+		response.Error(c, http.StatusBadRequest, "invalid cluster id")
 		return
 	}
 	lines := 200
 	if linesValue := strings.TrimSpace(c.Query("lines")); linesValue != "" {
 		parsed, parseErr := strconv.Atoi(linesValue)
 		if parseErr != nil || parsed <= 0 {
-			c.JSON(http.StatusBadRequest, SeatunnelXJavaProxyLogPreviewResponse{ErrorMsg: "invalid lines"})
+			response.Error(c, http.StatusBadRequest, "invalid lines")
 			return
 		}
 		lines = parsed
 	}
 	result, err := h.service.GetSeatunnelXJavaProxyServiceLog(c.Request.Context(), uint(clusterID), lines)
 	if err != nil {
-		c.JSON(h.getStatusCodeForError(err), SeatunnelXJavaProxyLogPreviewResponse{ErrorMsg: err.Error(), Data: result})
+		response.ErrorWithData(c, h.errorStatus(c, err), err.Error(), result)
 		return
 	}
-	c.JSON(http.StatusOK, SeatunnelXJavaProxyLogPreviewResponse{Data: result})
+	response.OK(c, result)
 }
 
 func (h *Handler) handleSeatunnelXJavaProxyOperation(c *gin.Context, fn func(context.Context, uint) (*SeatunnelXJavaProxyStatus, error)) {
 	clusterID, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, SeatunnelXJavaProxyResponse{ErrorMsg: "invalid cluster id"})
+		response.Error(c, http.StatusBadRequest, "invalid cluster id")
 		return
 	}
 	status, err := fn(c.Request.Context(), uint(clusterID))
 	if err != nil {
-		c.JSON(h.getStatusCodeForError(err), SeatunnelXJavaProxyResponse{ErrorMsg: err.Error(), Data: status})
+		response.ErrorWithData(c, h.errorStatus(c, err), err.Error(), status)
 		return
 	}
-	c.JSON(http.StatusOK, SeatunnelXJavaProxyResponse{Data: status})
+	response.OK(c, status)
 }
 
 // ListClusters handles GET /api/v1/clusters - lists clusters with filtering and pagination.
@@ -382,36 +522,50 @@ func (h *Handler) handleSeatunnelXJavaProxyOperation(c *gin.Context, fn func(con
 // @Success 200 {object} ListClustersResponse
 // @Router /api/v1/clusters [get]
 func (h *Handler) ListClusters(c *gin.Context) {
-	req := &ListClustersRequest{Current: 1, Size: 20}
+	req := &ListClustersRequest{}
 	if err := c.ShouldBindQuery(req); err != nil {
-		c.JSON(http.StatusBadRequest, ListClustersResponse{ErrorMsg: err.Error()})
+		response.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	page, err := req.Resolve(clusterListSpec)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	createdAt, err := req.Range()
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, err.Error())
 		return
 	}
 
 	// Build filter from request
 	// 从请求构建过滤条件
+	scope := auth.GetTenantScope(c)
 	filter := &ClusterFilter{
-		Name:           req.Name,
-		Status:         req.Status,
-		DeploymentMode: req.DeploymentMode,
-		Page:           req.Current,
-		PageSize:       req.Size,
+		Name:             req.Name,
+		Status:           req.Status,
+		DeploymentMode:   req.DeploymentMode,
+		HostID:           req.HostID,
+		CreatedAt:        createdAt,
+		ProjectIDs:       scope.ProjectIDs,
+		RestrictProjects: !scope.All,
+		Page:             page.Page,
+		PageSize:         page.PageSize,
+		Sort:             page.Sort,
 	}
 
 	clusters, total, err := h.service.ListWithInfo(c.Request.Context(), filter)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, ListClustersResponse{ErrorMsg: err.Error()})
+		response.Error(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
-	c.JSON(http.StatusOK, ListClustersResponse{
-		Data: &struct {
-			Total    int64          `json:"total"`
-			Clusters []*ClusterInfo `json:"clusters"`
-		}{
-			Total:    total,
-			Clusters: clusters,
-		},
+	response.OK(c, &struct {
+		Total    int64          `json:"total"`
+		Clusters []*ClusterInfo `json:"clusters"`
+	}{
+		Total:    total,
+		Clusters: clusters,
 	})
 }
 
@@ -425,18 +579,18 @@ func (h *Handler) ListClusters(c *gin.Context) {
 func (h *Handler) GetCluster(c *gin.Context) {
 	clusterID, err := strconv.ParseUint(c.Param("id"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, GetClusterResponse{ErrorMsg: "无效的集群 ID / Invalid cluster ID"})
+		response.Error(c, http.StatusBadRequest, "无效的集群 ID / Invalid cluster ID")
 		return
 	}
 
 	cluster, err := h.service.Get(c.Request.Context(), uint(clusterID))
 	if err != nil {
-		statusCode := h.getStatusCodeForError(err)
-		c.JSON(statusCode, GetClusterResponse{ErrorMsg: err.Error()})
+		statusCode := h.errorStatus(c, err)
+		response.Error(c, statusCode, err.Error())
 		return
 	}
 
-	c.JSON(http.StatusOK, GetClusterResponse{Data: cluster.ToClusterInfo()})
+	response.OK(c, cluster.ToClusterInfo())
 }
 
 // UpdateCluster handles PUT /api/v1/clusters/:id - updates an existing cluster.
@@ -451,63 +605,142 @@ func (h *Handler) GetCluster(c *gin.Context) {
 func (h *Handler) UpdateCluster(c *gin.Context) {
 	clusterID, err := strconv.ParseUint(c.Param("id"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, UpdateClusterResponse{ErrorMsg: "无效的集群 ID / Invalid cluster ID"})
+		response.Error(c, http.StatusBadRequest, "无效的集群 ID / Invalid cluster ID")
 		return
 	}
 
 	var req UpdateClusterRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, UpdateClusterResponse{ErrorMsg: err.Error()})
+		response.Error(c, http.StatusBadRequest, err.Error())
 		return
 	}
 
 	cluster, err := h.service.Update(c.Request.Context(), uint(clusterID), &req)
 	if err != nil {
-		statusCode := h.getStatusCodeForError(err)
-		c.JSON(statusCode, UpdateClusterResponse{ErrorMsg: err.Error()})
+		statusCode := h.errorStatus(c, err)
+		response.Error(c, statusCode, err.Error())
 		return
 	}
 
 	_ = audit.RecordFromGin(c, h.auditRepo, auth.GetUserIDFromContext(c), auth.GetUsernameFromContext(c),
 		"update", "cluster", audit.UintID(cluster.ID), cluster.Name, audit.AuditDetails{"trigger": "manual"})
 	logger.InfoF(c.Request.Context(), "[Cluster] 更新集群成功: %s", cluster.Name)
-	c.JSON(http.StatusOK, UpdateClusterResponse{Data: cluster.ToClusterInfo()})
+	response.OK(c, cluster.ToClusterInfo())
 }
 
-// DeleteCluster handles DELETE /api/v1/clusters/:id - deletes a cluster.
-// DeleteCluster 处理 DELETE /api/v1/clusters/:id - 删除集群。
+// DeleteCluster handles DELETE /api/v1/clusters/:id - moves a cluster to the recycle bin.
+// The nodes are torn down by the purge job once the retention window has elapsed.
+// DeleteCluster 处理 DELETE /api/v1/clusters/:id - 将集群移入回收站，保留期结束后由清理任务拆除节点。
 // @Tags clusters
 // @Produce json
 // @Param id path int true "集群ID"
+// @Param force_delete query bool false "清理时删除安装目录"
 // @Success 200 {object} DeleteClusterResponse
 // @Router /api/v1/clusters/{id} [delete]
 func (h *Handler) DeleteCluster(c *gin.Context) {
 	clusterID, err := strconv.ParseUint(c.Param("id"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, DeleteClusterResponse{ErrorMsg: "无效的集群 ID / Invalid cluster ID"})
+		response.Error(c, http.StatusBadRequest, "无效的集群 ID / Invalid cluster ID")
 		return
 	}
 
-	// Get cluster name for logging before deletion
-	// 在删除前获取集群名用于日志记录
-	cluster, err := h.service.Get(c.Request.Context(), uint(clusterID))
+	forceDelete := c.Query("force_delete") == "1" || c.Query("force_delete") == "true"
+	recycled, err := h.service.Recycle(c.Request.Context(), uint(clusterID), forceDelete)
 	if err != nil {
-		statusCode := h.getStatusCodeForError(err)
-		c.JSON(statusCode, DeleteClusterResponse{ErrorMsg: err.Error()})
+		statusCode := h.errorStatus(c, err)
+		response.Error(c, statusCode, err.Error())
 		return
 	}
 
-	forceDelete := c.Query("force_delete") == "1" || c.Query("force_delete") == "true"
-	if err := h.service.Delete(c.Request.Context(), uint(clusterID), forceDelete); err != nil {
-		statusCode := h.getStatusCodeForError(err)
-		c.JSON(statusCode, DeleteClusterResponse{ErrorMsg: err.Error()})
+	_ = audit.RecordFromGin(c, h.auditRepo, auth.GetUserIDFromContext(c), auth.GetUsernameFromContext(c),
+		"delete", "cluster", audit.UintID(uint(clusterID)), recycled.Name, audit.AuditDetails{
+			"trigger":            "manual",
+			"recycle_bin":        true,
+			"remove_install_dir": forceDelete,
+			"purge_at":           recycled.PurgeAt,
+		})
+	logger.InfoF(c.Request.Context(), "[Cluster] 集群已移入回收站: %s", recycled.Name)
+	response.OK(c, recycled)
+}
+
+// ListRecycledClusters handles GET /api/v1/clusters/recycle-bin - lists deleted clusters that can be restored.
+// ListRecycledClusters 处理 GET /api/v1/clusters/recycle-bin - 列出可恢复的已删除集群。
+// @Tags clusters
+// @Produce json
+// @Param name query string false "集群名称"
+// @Success 200 {object} ListRecycledClustersResponse
+// @Router /api/v1/clusters/recycle-bin [get]
+func (h *Handler) ListRecycledClusters(c *gin.Context) {
+	scope := auth.GetTenantScope(c)
+	clusters, err := h.service.ListRecycled(c.Request.Context(), &ClusterFilter{
+		Name:             c.Query("name"),
+		ProjectIDs:       scope.ProjectIDs,
+		RestrictProjects: !scope.All,
+	})
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	response.OK(c, clusters)
+}
+
+// RestoreCluster handles POST /api/v1/clusters/:id/restore - restores a cluster from the recycle bin.
+// RestoreCluster 处理 POST /api/v1/clusters/:id/restore - 从回收站恢复集群。
+// @Tags clusters
+// @Produce json
+// @Param id path int true "集群ID"
+// @Success 200 {object} RestoreClusterResponse
+// @Router /api/v1/clusters/{id}/restore [post]
+func (h *Handler) RestoreCluster(c *gin.Context) {
+	clusterID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "无效的集群 ID / Invalid cluster ID")
+		return
+	}
+
+	cluster, err := h.service.Restore(c.Request.Context(), uint(clusterID))
+	if err != nil {
+		statusCode := h.errorStatus(c, err)
+		response.Error(c, statusCode, err.Error())
 		return
 	}
 
 	_ = audit.RecordFromGin(c, h.auditRepo, auth.GetUserIDFromContext(c), auth.GetUsernameFromContext(c),
-		"delete", "cluster", audit.UintID(uint(clusterID)), cluster.Name, audit.AuditDetails{"trigger": "manual"})
-	logger.InfoF(c.Request.Context(), "[Cluster] 删除集群成功: %s", cluster.Name)
-	c.JSON(http.StatusOK, DeleteClusterResponse{})
+		"restore", "cluster", audit.UintID(cluster.ID), cluster.Name, audit.AuditDetails{"trigger": "manual"})
+	logger.InfoF(c.Request.Context(), "[Cluster] 集群已从回收站恢复: %s", cluster.Name)
+	response.OK(c, cluster.ToClusterInfo())
+}
+
+// PurgeCluster handles DELETE /api/v1/clusters/recycle-bin/:id - tears down a recycled cluster without waiting for the retention window.
+// PurgeCluster 处理 DELETE /api/v1/clusters/recycle-bin/:id - 不等待保留期，立即拆除回收站中的集群。
+// @Tags clusters
+// @Produce json
+// @Param id path int true "集群ID"
+// @Success 200 {object} PurgeClusterResponse
+// @Router /api/v1/clusters/recycle-bin/{id} [delete]
+func (h *Handler) PurgeCluster(c *gin.Context) {
+	clusterID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "无效的集群 ID / Invalid cluster ID")
+		return
+	}
+
+	report, err := h.service.Purge(c.Request.Context(), uint(clusterID))
+	if err != nil {
+		statusCode := h.errorStatus(c, err)
+		response.ErrorWithData(c, statusCode, err.Error(), report)
+		return
+	}
+
+	_ = audit.RecordFromGin(c, h.auditRepo, auth.GetUserIDFromContext(c), auth.GetUsernameFromContext(c),
+		"purge", "cluster", audit.UintID(uint(clusterID)), report.ClusterName, audit.AuditDetails{
+			"trigger":          "manual",
+			"nodes":            len(report.Nodes),
+			"failed_nodes":     report.FailedNodes(),
+			"released_plugins": report.ReleasedPlugins,
+		})
+	logger.InfoF(c.Request.Context(), "[Cluster] 回收站集群已清理: %s", report.ClusterName)
+	response.OK(c, report)
 }
 
 // ==================== Node Management Handlers 节点管理处理器 ====================
@@ -524,20 +757,23 @@ func (h *Handler) DeleteCluster(c *gin.Context) {
 func (h *Handler) AddNode(c *gin.Context) {
 	clusterID, err := strconv.ParseUint(c.Param("id"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, AddNodeResponse{ErrorMsg: "无效的集群 ID / Invalid cluster ID"})
+		response.Error(c, http.StatusBadRequest, "无效的集群 ID / Invalid cluster ID")
 		return
 	}
 
 	var req AddNodeRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, AddNodeResponse{ErrorMsg: err.Error()})
+		response.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if !authorizeHosts(c, req.HostID) {
 		return
 	}
 
 	node, err := h.service.AddNode(c.Request.Context(), uint(clusterID), &req)
 	if err != nil {
-		statusCode := h.getStatusCodeForError(err)
-		c.JSON(statusCode, AddNodeResponse{ErrorMsg: err.Error()})
+		statusCode := h.errorStatus(c, err)
+		response.Error(c, statusCode, err.Error())
 		return
 	}
 
@@ -546,7 +782,7 @@ func (h *Handler) AddNode(c *gin.Context) {
 	_ = audit.RecordFromGin(c, h.auditRepo, auth.GetUserIDFromContext(c), auth.GetUsernameFromContext(c),
 		"add_node", "cluster_node", resID, resourceName, audit.AuditDetails{"trigger": "manual"})
 	logger.InfoF(c.Request.Context(), "[Cluster] 添加节点成功: cluster_id=%d, host_id=%d, role=%s, hazelcast_port=%d", clusterID, req.HostID, node.Role, node.HazelcastPort)
-	c.JSON(http.StatusOK, AddNodeResponse{Data: buildNodeInfo(node)})
+	response.OK(c, buildNodeInfo(node))
 }
 
 // AddNodes handles POST /api/v1/clusters/:id/nodes/batch - adds multiple logical nodes for one host atomically.
@@ -554,20 +790,23 @@ func (h *Handler) AddNode(c *gin.Context) {
 func (h *Handler) AddNodes(c *gin.Context) {
 	clusterID, err := strconv.ParseUint(c.Param("id"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, AddNodesResponse{ErrorMsg: "无效的集群 ID / Invalid cluster ID"})
+		response.Error(c, http.StatusBadRequest, "无效的集群 ID / Invalid cluster ID")
 		return
 	}
 
 	var req AddNodesRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, AddNodesResponse{ErrorMsg: err.Error()})
+		response.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if !authorizeHosts(c, req.HostID) {
 		return
 	}
 
 	nodes, err := h.service.AddNodes(c.Request.Context(), uint(clusterID), &req)
 	if err != nil {
-		statusCode := h.getStatusCodeForError(err)
-		c.JSON(statusCode, AddNodesResponse{ErrorMsg: err.Error()})
+		statusCode := h.errorStatus(c, err)
+		response.Error(c, statusCode, err.Error())
 		return
 	}
 
@@ -589,7 +828,7 @@ func (h *Handler) AddNodes(c *gin.Context) {
 			"roles":   strings.Join(roleLabels, ","),
 		})
 	logger.InfoF(c.Request.Context(), "[Cluster] 批量添加节点成功: cluster_id=%d, host_id=%d, roles=%s", clusterID, req.HostID, strings.Join(roleLabels, ","))
-	c.JSON(http.StatusOK, AddNodesResponse{Data: nodeInfos})
+	response.OK(c, nodeInfos)
 }
 
 // RemoveNode handles DELETE /api/v1/clusters/:id/nodes/:nodeId - removes a node from a cluster.
@@ -603,21 +842,21 @@ func (h *Handler) AddNodes(c *gin.Context) {
 func (h *Handler) RemoveNode(c *gin.Context) {
 	clusterID, err := strconv.ParseUint(c.Param("id"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, RemoveNodeResponse{ErrorMsg: "无效的集群 ID / Invalid cluster ID"})
+		response.Error(c, http.StatusBadRequest, "无效的集群 ID / Invalid cluster ID")
 		return
 	}
 
 	nodeID, err := strconv.ParseUint(c.Param("nodeId"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, RemoveNodeResponse{ErrorMsg: "无效的节点 ID / Invalid node ID"})
+		response.Error(c, http.StatusBadRequest, "无效的节点 ID / Invalid node ID")
 		return
 	}
 
 	resourceName := h.getClusterNodeResourceName(c, uint(clusterID), uint(nodeID))
 
 	if err := h.service.RemoveNode(c.Request.Context(), uint(clusterID), uint(nodeID)); err != nil {
-		statusCode := h.getStatusCodeForError(err)
-		c.JSON(statusCode, RemoveNodeResponse{ErrorMsg: err.Error()})
+		statusCode := h.errorStatus(c, err)
+		response.Error(c, statusCode, err.Error())
 		return
 	}
 
@@ -625,7 +864,7 @@ func (h *Handler) RemoveNode(c *gin.Context) {
 	_ = audit.RecordFromGin(c, h.auditRepo, auth.GetUserIDFromContext(c), auth.GetUsernameFromContext(c),
 		"remove_node", "cluster_node", resID, resourceName, audit.AuditDetails{"trigger": "manual"})
 	logger.InfoF(c.Request.Context(), "[Cluster] 移除节点成功: cluster_id=%d, node_id=%d", clusterID, nodeID)
-	c.JSON(http.StatusOK, RemoveNodeResponse{})
+	response.OK(c, nil)
 }
 
 // UpdateNode handles PUT /api/v1/clusters/:id/nodes/:nodeId - updates a node in a cluster.
@@ -641,26 +880,26 @@ func (h *Handler) RemoveNode(c *gin.Context) {
 func (h *Handler) UpdateNode(c *gin.Context) {
 	clusterID, err := strconv.ParseUint(c.Param("id"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, AddNodeResponse{ErrorMsg: "无效的集群 ID / Invalid cluster ID"})
+		response.Error(c, http.StatusBadRequest, "无效的集群 ID / Invalid cluster ID")
 		return
 	}
 
 	nodeID, err := strconv.ParseUint(c.Param("nodeId"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, AddNodeResponse{ErrorMsg: "无效的节点 ID / Invalid node ID"})
+		response.Error(c, http.StatusBadRequest, "无效的节点 ID / Invalid node ID")
 		return
 	}
 
 	var req UpdateNodeRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, AddNodeResponse{ErrorMsg: err.Error()})
+		response.Error(c, http.StatusBadRequest, err.Error())
 		return
 	}
 
 	node, err := h.service.UpdateNode(c.Request.Context(), uint(clusterID), uint(nodeID), &req)
 	if err != nil {
-		statusCode := h.getStatusCodeForError(err)
-		c.JSON(statusCode, AddNodeResponse{ErrorMsg: err.Error()})
+		statusCode := h.errorStatus(c, err)
+		response.Error(c, statusCode, err.Error())
 		return
 	}
 
@@ -669,7 +908,7 @@ func (h *Handler) UpdateNode(c *gin.Context) {
 	_ = audit.RecordFromGin(c, h.auditRepo, auth.GetUserIDFromContext(c), auth.GetUsernameFromContext(c),
 		"update_node", "cluster_node", resID, resourceName, audit.AuditDetails{"trigger": "manual"})
 	logger.InfoF(c.Request.Context(), "[Cluster] 更新节点成功: cluster_id=%d, node_id=%d", clusterID, nodeID)
-	c.JSON(http.StatusOK, AddNodeResponse{Data: buildNodeInfo(node)})
+	response.OK(c, buildNodeInfo(node))
 }
 
 // GetNodes handles GET /api/v1/clusters/:id/nodes - gets all nodes for a cluster.
@@ -682,18 +921,18 @@ func (h *Handler) UpdateNode(c *gin.Context) {
 func (h *Handler) GetNodes(c *gin.Context) {
 	clusterID, err := strconv.ParseUint(c.Param("id"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, GetNodesResponse{ErrorMsg: "无效的集群 ID / Invalid cluster ID"})
+		response.Error(c, http.StatusBadRequest, "无效的集群 ID / Invalid cluster ID")
 		return
 	}
 
 	nodes, err := h.service.GetNodes(c.Request.Context(), uint(clusterID))
 	if err != nil {
-		statusCode := h.getStatusCodeForError(err)
-		c.JSON(statusCode, GetNodesResponse{ErrorMsg: err.Error()})
+		statusCode := h.errorStatus(c, err)
+		response.Error(c, statusCode, err.Error())
 		return
 	}
 
-	c.JSON(http.StatusOK, GetNodesResponse{Data: nodes})
+	response.OK(c, nodes)
 }
 
 // ==================== Cluster Operation Handlers 集群操作处理器 ====================
@@ -708,21 +947,21 @@ func (h *Handler) GetNodes(c *gin.Context) {
 func (h *Handler) StartCluster(c *gin.Context) {
 	clusterID, err := strconv.ParseUint(c.Param("id"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, ClusterOperationResponse{ErrorMsg: "无效的集群 ID / Invalid cluster ID"})
+		response.Error(c, http.StatusBadRequest, "无效的集群 ID / Invalid cluster ID")
 		return
 	}
 
 	result, err := h.service.Start(c.Request.Context(), uint(clusterID))
 	if err != nil {
-		statusCode := h.getStatusCodeForError(err)
-		c.JSON(statusCode, ClusterOperationResponse{ErrorMsg: err.Error()})
+		statusCode := h.errorStatus(c, err)
+		response.Error(c, statusCode, err.Error())
 		return
 	}
 	clusterName := h.getClusterNameForAudit(c.Request.Context(), uint(clusterID))
 	_ = audit.RecordFromGin(c, h.auditRepo, auth.GetUserIDFromContext(c), auth.GetUsernameFromContext(c),
 		"start", "cluster", audit.UintID(uint(clusterID)), clusterName, audit.AuditDetails{"trigger": "manual"})
 	logger.InfoF(c.Request.Context(), "[Cluster] 启动集群: cluster_id=%d, success=%v", clusterID, result.Success)
-	c.JSON(http.StatusOK, ClusterOperationResponse{Data: result})
+	response.OK(c, result)
 }
 
 // StopCluster handles POST /api/v1/clusters/:id/stop - stops a cluster.
@@ -735,21 +974,21 @@ func (h *Handler) StartCluster(c *gin.Context) {
 func (h *Handler) StopCluster(c *gin.Context) {
 	clusterID, err := strconv.ParseUint(c.Param("id"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, ClusterOperationResponse{ErrorMsg: "无效的集群 ID / Invalid cluster ID"})
+		response.Error(c, http.StatusBadRequest, "无效的集群 ID / Invalid cluster ID")
 		return
 	}
 
 	result, err := h.service.Stop(c.Request.Context(), uint(clusterID))
 	if err != nil {
-		statusCode := h.getStatusCodeForError(err)
-		c.JSON(statusCode, ClusterOperationResponse{ErrorMsg: err.Error()})
+		statusCode := h.errorStatus(c, err)
+		response.Error(c, statusCode, err.Error())
 		return
 	}
 	clusterName := h.getClusterNameForAudit(c.Request.Context(), uint(clusterID))
 	_ = audit.RecordFromGin(c, h.auditRepo, auth.GetUserIDFromContext(c), auth.GetUsernameFromContext(c),
 		"stop", "cluster", audit.UintID(uint(clusterID)), clusterName, audit.AuditDetails{"trigger": "manual"})
 	logger.InfoF(c.Request.Context(), "[Cluster] 停止集群: cluster_id=%d, success=%v", clusterID, result.Success)
-	c.JSON(http.StatusOK, ClusterOperationResponse{Data: result})
+	response.OK(c, result)
 }
 
 // RestartCluster handles POST /api/v1/clusters/:id/restart - restarts a cluster.
@@ -757,25 +996,45 @@ func (h *Handler) StopCluster(c *gin.Context) {
 // @Tags clusters
 // @Produce json
 // @Param id path int true "集群ID"
+// @Param request body ClusterOperationRequest false "重启模式（parallel/rolling）"
 // @Success 200 {object} ClusterOperationResponse
 // @Router /api/v1/clusters/{id}/restart [post]
 func (h *Handler) RestartCluster(c *gin.Context) {
 	clusterID, err := strconv.ParseUint(c.Param("id"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, ClusterOperationResponse{ErrorMsg: "无效的集群 ID / Invalid cluster ID"})
+		response.Error(c, http.StatusBadRequest, "无效的集群 ID / Invalid cluster ID")
 		return
 	}
 
-	result, err := h.service.Restart(c.Request.Context(), uint(clusterID))
+	// The body is optional; without it all nodes are restarted at once.
+	// 请求体可选；不传时同时重启所有节点。
+	var req ClusterOperationRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			response.Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	var result *OperationResult
+	switch req.Mode {
+	case "", OperationModeParallel:
+		req.Mode = OperationModeParallel
+		result, err = h.service.Restart(c.Request.Context(), uint(clusterID))
+	case OperationModeRolling:
+		result, err = h.service.RollingRestart(c.Request.Context(), uint(clusterID), req.Rolling)
+	default:
+		err = ErrInvalidOperationMode
+	}
 	if err != nil {
-		statusCode := h.getStatusCodeForError(err)
-		c.JSON(statusCode, ClusterOperationResponse{ErrorMsg: err.Error()})
+		statusCode := h.errorStatus(c, err)
+		response.Error(c, statusCode, err.Error())
 		return
 	}
 	clusterName := h.getClusterNameForAudit(c.Request.Context(), uint(clusterID))
 	_ = audit.RecordFromGin(c, h.auditRepo, auth.GetUserIDFromContext(c), auth.GetUsernameFromContext(c),
-		"restart", "cluster", audit.UintID(uint(clusterID)), clusterName, audit.AuditDetails{"trigger": "manual"})
-	logger.InfoF(c.Request.Context(), "[Cluster] 重启集群: cluster_id=%d, success=%v", clusterID, result.Success)
+		"restart", "cluster", audit.UintID(uint(clusterID)), clusterName, audit.AuditDetails{"trigger": "manual", "mode": string(req.Mode)})
+	logger.InfoF(c.Request.Context(), "[Cluster] 重启集群: cluster_id=%d, mode=%s, success=%v", clusterID, req.Mode, result.Success)
 	h.notifyOperationExecuted(c.Request.Context(), &OperationEvent{
 		ClusterID:   uint(clusterID),
 		ClusterName: clusterName,
@@ -785,7 +1044,7 @@ func (h *Handler) RestartCluster(c *gin.Context) {
 		Operator:    auth.GetUsernameFromContext(c),
 		Trigger:     "manual_api",
 	})
-	c.JSON(http.StatusOK, ClusterOperationResponse{Data: result})
+	response.OK(c, result)
 }
 
 // GetClusterStatus handles GET /api/v1/clusters/:id/status - gets the status of a cluster.
@@ -798,18 +1057,42 @@ func (h *Handler) RestartCluster(c *gin.Context) {
 func (h *Handler) GetClusterStatus(c *gin.Context) {
 	clusterID, err := strconv.ParseUint(c.Param("id"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, GetClusterStatusResponse{ErrorMsg: "无效的集群 ID / Invalid cluster ID"})
+		response.Error(c, http.StatusBadRequest, "无效的集群 ID / Invalid cluster ID")
 		return
 	}
 
 	status, err := h.service.GetStatus(c.Request.Context(), uint(clusterID))
 	if err != nil {
-		statusCode := h.getStatusCodeForError(err)
-		c.JSON(statusCode, GetClusterStatusResponse{ErrorMsg: err.Error()})
+		statusCode := h.errorStatus(c, err)
+		response.Error(c, statusCode, err.Error())
 		return
 	}
 
-	c.JSON(http.StatusOK, GetClusterStatusResponse{Data: status})
+	response.OK(c, status)
+}
+
+// GetClusterHealth handles GET /api/v1/clusters/:id/health - gets the latest node health check states.
+// GetClusterHealth 处理 GET /api/v1/clusters/:id/health - 获取最近的节点健康检查状态。
+// @Tags clusters
+// @Produce json
+// @Param id path int true "集群ID"
+// @Success 200 {object} GetClusterHealthResponse
+// @Router /api/v1/clusters/{id}/health [get]
+func (h *Handler) GetClusterHealth(c *gin.Context) {
+	clusterID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "无效的集群 ID / Invalid cluster ID")
+		return
+	}
+
+	health, err := h.service.GetNodeHealth(c.Request.Context(), uint(clusterID))
+	if err != nil {
+		statusCode := h.errorStatus(c, err)
+		response.Error(c, statusCode, err.Error())
+		return
+	}
+
+	response.OK(c, health)
 }
 
 // GetRuntimeStorage handles GET /api/v1/clusters/:id/runtime-storage.
@@ -817,15 +1100,15 @@ func (h *Handler) GetClusterStatus(c *gin.Context) {
 func (h *Handler) GetRuntimeStorage(c *gin.Context) {
 	clusterID, err := strconv.ParseUint(c.Param("id"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, GetRuntimeStorageResponse{ErrorMsg: "无效的集群 ID / Invalid cluster ID"})
+		response.Error(c, http.StatusBadRequest, "无效的集群 ID / Invalid cluster ID")
 		return
 	}
 	result, err := h.service.GetRuntimeStorageDetails(c.Request.Context(), uint(clusterID))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, GetRuntimeStorageResponse{ErrorMsg: err.Error()})
+		response.Error(c, http.StatusInternalServerError, err.Error())
 		return
 	}
-	c.JSON(http.StatusOK, GetRuntimeStorageResponse{Data: result})
+	response.OK(c, result)
 }
 
 // ValidateRuntimeStorage handles POST /api/v1/clusters/:id/runtime-storage/:kind/validate.
@@ -833,23 +1116,23 @@ func (h *Handler) GetRuntimeStorage(c *gin.Context) {
 func (h *Handler) ValidateRuntimeStorage(c *gin.Context) {
 	clusterID, err := strconv.ParseUint(c.Param("id"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, ValidateRuntimeStorageResponse{ErrorMsg: "无效的集群 ID / Invalid cluster ID"})
+		response.Error(c, http.StatusBadRequest, "无效的集群 ID / Invalid cluster ID")
 		return
 	}
 	kind := installerapp.RuntimeStorageValidationKind(strings.ToLower(strings.TrimSpace(c.Param("kind"))))
 	result, err := h.service.ValidateRuntimeStorage(c.Request.Context(), uint(clusterID), kind)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, ValidateRuntimeStorageResponse{ErrorMsg: err.Error()})
+		response.Error(c, http.StatusBadRequest, err.Error())
 		return
 	}
-	c.JSON(http.StatusOK, ValidateRuntimeStorageResponse{Data: result})
+	response.OK(c, result)
 }
 
 // ListRuntimeStorage handles POST /api/v1/clusters/:id/runtime-storage/:kind/list.
 func (h *Handler) ListRuntimeStorage(c *gin.Context) {
 	clusterID, err := strconv.ParseUint(c.Param("id"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, ListRuntimeStorageResponse{ErrorMsg: "无效的集群 ID / Invalid cluster ID"})
+		response.Error(c, http.StatusBadRequest, "无效的集群 ID / Invalid cluster ID")
 		return
 	}
 	kind := installerapp.RuntimeStorageValidationKind(strings.ToLower(strings.TrimSpace(c.Param("kind"))))
@@ -866,17 +1149,17 @@ func (h *Handler) ListRuntimeStorage(c *gin.Context) {
 	}
 	result, err := h.service.ListRuntimeStorage(c.Request.Context(), uint(clusterID), kind, req.Path, req.Recursive, req.Limit)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, ListRuntimeStorageResponse{ErrorMsg: err.Error()})
+		response.Error(c, http.StatusBadRequest, err.Error())
 		return
 	}
-	c.JSON(http.StatusOK, ListRuntimeStorageResponse{Data: result})
+	response.OK(c, result)
 }
 
 // PreviewRuntimeStorage handles POST /api/v1/clusters/:id/runtime-storage/:kind/preview.
 func (h *Handler) PreviewRuntimeStorage(c *gin.Context) {
 	clusterID, err := strconv.ParseUint(c.Param("id"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, PreviewRuntimeStorageResponse{ErrorMsg: "无效的集群 ID / Invalid cluster ID"})
+		response.Error(c, http.StatusBadRequest, "无效的集群 ID / Invalid cluster ID")
 		return
 	}
 	kind := installerapp.RuntimeStorageValidationKind(strings.ToLower(strings.TrimSpace(c.Param("kind"))))
@@ -889,17 +1172,17 @@ func (h *Handler) PreviewRuntimeStorage(c *gin.Context) {
 	}
 	result, err := h.service.PreviewRuntimeStorage(c.Request.Context(), uint(clusterID), kind, req.Path, req.MaxBytes)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, PreviewRuntimeStorageResponse{ErrorMsg: err.Error()})
+		response.Error(c, http.StatusBadRequest, err.Error())
 		return
 	}
-	c.JSON(http.StatusOK, PreviewRuntimeStorageResponse{Data: result})
+	response.OK(c, result)
 }
 
 // InspectCheckpointRuntimeStorage handles POST /api/v1/clusters/:id/runtime-storage/checkpoint/inspect.
 func (h *Handler) InspectCheckpointRuntimeStorage(c *gin.Context) {
 	clusterID, err := strconv.ParseUint(c.Param("id"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, InspectCheckpointRuntimeStorageResponse{ErrorMsg: "无效的集群 ID / Invalid cluster ID"})
+		response.Error(c, http.StatusBadRequest, "无效的集群 ID / Invalid cluster ID")
 		return
 	}
 	var req struct {
@@ -916,17 +1199,17 @@ func (h *Handler) InspectCheckpointRuntimeStorage(c *gin.Context) {
 		req.JobConfig,
 	)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, InspectCheckpointRuntimeStorageResponse{ErrorMsg: err.Error()})
+		response.Error(c, http.StatusBadRequest, err.Error())
 		return
 	}
-	c.JSON(http.StatusOK, InspectCheckpointRuntimeStorageResponse{Data: result})
+	response.OK(c, result)
 }
 
 // InspectIMAPRuntimeStorage handles POST /api/v1/clusters/:id/runtime-storage/imap/inspect.
 func (h *Handler) InspectIMAPRuntimeStorage(c *gin.Context) {
 	clusterID, err := strconv.ParseUint(c.Param("id"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, InspectIMAPRuntimeStorageResponse{ErrorMsg: "无效的集群 ID / Invalid cluster ID"})
+		response.Error(c, http.StatusBadRequest, "无效的集群 ID / Invalid cluster ID")
 		return
 	}
 	var req struct {
@@ -937,10 +1220,10 @@ func (h *Handler) InspectIMAPRuntimeStorage(c *gin.Context) {
 	}
 	result, err := h.service.InspectIMAPRuntimeStorage(c.Request.Context(), uint(clusterID), req.Path)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, InspectIMAPRuntimeStorageResponse{ErrorMsg: err.Error()})
+		response.Error(c, http.StatusBadRequest, err.Error())
 		return
 	}
-	c.JSON(http.StatusOK, InspectIMAPRuntimeStorageResponse{Data: result})
+	response.OK(c, result)
 }
 
 // ProxyWebUI handles proxying the SeaTunnel Web UI through the control plane.
@@ -948,13 +1231,13 @@ func (h *Handler) InspectIMAPRuntimeStorage(c *gin.Context) {
 func (h *Handler) ProxyWebUI(c *gin.Context) {
 	clusterID, err := strconv.ParseUint(c.Param("id"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error_msg": "无效的集群 ID / Invalid cluster ID"})
+		response.Error(c, http.StatusBadRequest, "无效的集群 ID / Invalid cluster ID")
 		return
 	}
 
 	nodes, err := h.service.GetNodes(c.Request.Context(), uint(clusterID))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error_msg": err.Error()})
+		response.Error(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
@@ -975,7 +1258,7 @@ func (h *Handler) ProxyWebUI(c *gin.Context) {
 	}
 
 	if targetNode == nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error_msg": "当前集群未找到可用的 SeaTunnel Web UI 节点 / No available SeaTunnel Web UI node found"})
+		response.Error(c, http.StatusBadRequest, "当前集群未找到可用的 SeaTunnel Web UI 节点 / No available SeaTunnel Web UI node found")
 		return
 	}
 
@@ -984,13 +1267,13 @@ func (h *Handler) ProxyWebUI(c *gin.Context) {
 		upstreamHost = strings.TrimSpace(targetNode.HostName)
 	}
 	if upstreamHost == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error_msg": "目标节点缺少主机地址，无法代理 Web UI / Target node has no host address for Web UI proxy"})
+		response.Error(c, http.StatusBadRequest, "目标节点缺少主机地址，无法代理 Web UI / Target node has no host address for Web UI proxy")
 		return
 	}
 
 	target, err := url.Parse("http://" + upstreamHost + ":" + strconv.Itoa(targetNode.APIPort))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error_msg": err.Error()})
+		response.Error(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
@@ -1065,17 +1348,22 @@ func (h *Handler) ProxyWebUI(c *gin.Context) {
 // CleanupIMAPStorage handles POST /api/v1/clusters/:id/runtime-storage/imap/cleanup.
 // CleanupIMAPStorage 处理 POST /api/v1/clusters/:id/runtime-storage/imap/cleanup。
 func (h *Handler) CleanupIMAPStorage(c *gin.Context) {
+	// Cleaning checkpoint storage is destructive and requires manage permission.
+	// 清理 IMAP 存储属于破坏性操作，需要管理权限。
+	if !auth.Authorize(c, auth.PermissionManage) {
+		return
+	}
 	clusterID, err := strconv.ParseUint(c.Param("id"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, CleanupRuntimeStorageResponse{ErrorMsg: "无效的集群 ID / Invalid cluster ID"})
+		response.Error(c, http.StatusBadRequest, "无效的集群 ID / Invalid cluster ID")
 		return
 	}
 	result, err := h.service.CleanupIMAPStorage(c.Request.Context(), uint(clusterID))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, CleanupRuntimeStorageResponse{ErrorMsg: err.Error()})
+		response.Error(c, http.StatusBadRequest, err.Error())
 		return
 	}
-	c.JSON(http.StatusOK, CleanupRuntimeStorageResponse{Data: result})
+	response.OK(c, result)
 }
 
 // ==================== Node Precheck Handlers 节点预检查处理器 ====================
@@ -1092,25 +1380,50 @@ func (h *Handler) CleanupIMAPStorage(c *gin.Context) {
 func (h *Handler) PrecheckNode(c *gin.Context) {
 	clusterID, err := strconv.ParseUint(c.Param("id"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, PrecheckNodeResponse{ErrorMsg: "无效的集群 ID / Invalid cluster ID"})
+		response.Error(c, http.StatusBadRequest, "无效的集群 ID / Invalid cluster ID")
 		return
 	}
 
 	var req PrecheckRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, PrecheckNodeResponse{ErrorMsg: err.Error()})
+		response.Error(c, http.StatusBadRequest, err.Error())
 		return
 	}
 
 	result, err := h.service.PrecheckNode(c.Request.Context(), uint(clusterID), &req)
 	if err != nil {
-		statusCode := h.getStatusCodeForError(err)
-		c.JSON(statusCode, PrecheckNodeResponse{ErrorMsg: err.Error()})
+		statusCode := h.errorStatus(c, err)
+		response.Error(c, statusCode, err.Error())
 		return
 	}
 
 	logger.InfoF(c.Request.Context(), "[Cluster] 节点预检查完成: cluster_id=%d, host_id=%d, success=%v", clusterID, req.HostID, result.Success)
-	c.JSON(http.StatusOK, PrecheckNodeResponse{Data: result})
+	response.OK(c, result)
+}
+
+// PrecheckCluster handles POST /api/v1/clusters/:id/precheck - checks member connectivity and clock skew.
+// PrecheckCluster 处理 POST /api/v1/clusters/:id/precheck - 检查成员间连通性与时钟偏差。
+// @Tags clusters
+// @Produce json
+// @Param id path int true "集群ID"
+// @Success 200 {object} ClusterPrecheckResponse
+// @Router /api/v1/clusters/{id}/precheck [post]
+func (h *Handler) PrecheckCluster(c *gin.Context) {
+	clusterID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "无效的集群 ID / Invalid cluster ID")
+		return
+	}
+
+	result, err := h.service.PrecheckCluster(c.Request.Context(), uint(clusterID))
+	if err != nil {
+		statusCode := h.errorStatus(c, err)
+		response.Error(c, statusCode, err.Error())
+		return
+	}
+
+	logger.InfoF(c.Request.Context(), "[Cluster] 集群连通性预检查完成: cluster_id=%d, success=%v", clusterID, result.Success)
+	response.OK(c, result)
 }
 
 // ==================== Helper Methods 辅助方法 ====================
@@ -1142,7 +1455,8 @@ func (h *Handler) getClusterNameForAudit(ctx context.Context, clusterID uint) st
 // getStatusCodeForError 根据错误返回适当的 HTTP 状态码。
 func (h *Handler) getStatusCodeForError(err error) int {
 	switch {
-	case errors.Is(err, ErrClusterNotFound):
+	case errors.Is(err, ErrClusterNotFound),
+		errors.Is(err, ErrClusterNotRecycled):
 		return http.StatusNotFound
 	case errors.Is(err, ErrClusterNameDuplicate):
 		return http.StatusConflict
@@ -1154,7 +1468,8 @@ func (h *Handler) getStatusCodeForError(err error) int {
 		return http.StatusConflict
 	case errors.Is(err, ErrNodeNotFound):
 		return http.StatusNotFound
-	case errors.Is(err, ErrNodeAlreadyExists):
+	case errors.Is(err, ErrNodeAlreadyExists),
+		errors.Is(err, ErrNodePortConflict):
 		return http.StatusConflict
 	case errors.Is(err, ErrNodeAgentNotInstalled),
 		errors.Is(err, ErrInvalidHazelcastPort),
@@ -1162,13 +1477,290 @@ func (h *Handler) getStatusCodeForError(err error) int {
 		errors.Is(err, ErrInvalidWorkerPort),
 		errors.Is(err, ErrNodeBatchEntriesRequired),
 		errors.Is(err, ErrInvalidNodeJVMOverride),
-		errors.Is(err, ErrPrecheckFailed):
+		errors.Is(err, ErrPrecheckFailed),
+		errors.Is(err, ErrInvalidNodeSelector),
+		errors.Is(err, ErrNodeSelectorMismatch):
+		return http.StatusBadRequest
+	case errors.Is(err, ErrCrossProjectNode):
+		return http.StatusForbidden
+	case errors.Is(err, ErrScaleTaskNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrScaleInProgress):
+		return http.StatusConflict
+	case errors.Is(err, ErrClusterNotRunning),
+		errors.Is(err, ErrScaleRequestEmpty),
+		errors.Is(err, ErrScaleDuplicateHost),
+		errors.Is(err, ErrScaleRemoveNotAllowed),
+		errors.Is(err, ErrInvalidRollingOptions),
+		errors.Is(err, ErrInvalidOperationMode),
+		errors.Is(err, ErrInvalidDrainOptions),
+		errors.Is(err, ErrInvalidDiagnoseAction),
+		errors.Is(err, jvmopts.ErrInvalidOptions):
+		return http.StatusBadRequest
+	case errors.Is(err, ErrScaleUnavailable),
+		errors.Is(err, ErrAdoptUnavailable),
+		errors.Is(err, ErrJVMTuningUnavailable):
+		return http.StatusServiceUnavailable
+	case errors.Is(err, ErrAdoptAlreadyManaged):
+		return http.StatusConflict
+	case errors.Is(err, ErrAdoptInspectFailed),
+		errors.Is(err, ErrAdoptVersionMismatch),
+		errors.Is(err, ErrAdoptRoleUnknown):
 		return http.StatusBadRequest
 	default:
 		return http.StatusInternalServerError
 	}
 }
 
+// errorStatus records the catalog code of err on the request and returns its HTTP status.
+// errorStatus 在请求上记录 err 的目录错误码并返回其 HTTP 状态码。
+func (h *Handler) errorStatus(c *gin.Context, err error) int {
+	status := h.getStatusCodeForError(err)
+	errcode.Attach(c, clusterErrorCode(err, status))
+	return status
+}
+
+// clusterErrorCode maps cluster errors to catalog codes, falling back to the general code for status.
+// clusterErrorCode 将集群错误映射为目录错误码，无法识别时回退为状态码对应的通用错误码。
+func clusterErrorCode(err error, status int) errcode.Code {
+	if code, ok := errcode.Of(err); ok {
+		return code
+	}
+	switch {
+	case errors.Is(err, ErrClusterNotFound):
+		return errcode.ClusterNotFound
+	case errors.Is(err, ErrClusterNameDuplicate):
+		return errcode.ClusterNameDuplicate
+	case errors.Is(err, ErrNodeNotFound):
+		return errcode.ClusterNodeNotFound
+	case errors.Is(err, ErrNodeAlreadyExists):
+		return errcode.ClusterNodeExists
+	case errors.Is(err, ErrNodeAgentNotInstalled):
+		return errcode.NodeAgentNotInstalled
+	case errors.Is(err, ErrClusterHasRunningTask):
+		return errcode.ClusterHasRunningTask
+	case errors.Is(err, ErrClusterNotRunning):
+		return errcode.ClusterNotRunning
+	case errors.Is(err, ErrScaleInProgress):
+		return errcode.ScaleInProgress
+	case errors.Is(err, ErrCrossProjectNode):
+		return errcode.CrossProjectNode
+	case errors.Is(err, ErrNodeSelectorMismatch):
+		return errcode.NodeSelectorMismatch
+	case errors.Is(err, ErrPrecheckFailed):
+		return errcode.PrecheckFailed
+	case errors.Is(err, ErrInvalidDeploymentMode):
+		return errcode.InvalidDeploymentMode
+	case errors.Is(err, ErrInvalidNodeRole):
+		return errcode.InvalidNodeRole
+	case errors.Is(err, ErrAdoptAlreadyManaged):
+		return errcode.ClusterAlreadyAdopted
+	case errors.Is(err, ErrAdoptInspectFailed),
+		errors.Is(err, ErrAdoptVersionMismatch),
+		errors.Is(err, ErrAdoptRoleUnknown):
+		return errcode.ClusterAdoptionFailure
+	default:
+		return errcode.ForHTTPStatus(status)
+	}
+}
+
+// ==================== JVM Handlers JVM 调优处理器 ====================
+
+// GetClusterJVM handles GET /api/v1/clusters/:id/jvm - returns cluster-level JVM settings.
+// GetClusterJVM 处理 GET /api/v1/clusters/:id/jvm - 返回集群级 JVM 配置。
+func (h *Handler) GetClusterJVM(c *gin.Context) {
+	clusterID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "无效的集群 ID / Invalid cluster ID")
+		return
+	}
+
+	jvm, err := h.service.GetJVM(c.Request.Context(), uint(clusterID))
+	if err != nil {
+		response.Error(c, h.errorStatus(c, err), err.Error())
+		return
+	}
+	response.OK(c, jvm)
+}
+
+// PreviewClusterJVM handles POST /api/v1/clusters/:id/jvm/preview - renders jvm_options of each node without applying.
+// PreviewClusterJVM 处理 POST /api/v1/clusters/:id/jvm/preview - 渲染各节点的 jvm_options 但不生效。
+func (h *Handler) PreviewClusterJVM(c *gin.Context) {
+	clusterID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "无效的集群 ID / Invalid cluster ID")
+		return
+	}
+
+	var req JVMConfig
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := h.service.PreviewJVM(c.Request.Context(), uint(clusterID), &req)
+	if err != nil {
+		response.Error(c, h.errorStatus(c, err), err.Error())
+		return
+	}
+	response.OK(c, result)
+}
+
+// UpdateClusterJVM handles PUT /api/v1/clusters/:id/jvm - regenerates jvm_options on each node and schedules a rolling restart.
+// UpdateClusterJVM 处理 PUT /api/v1/clusters/:id/jvm - 重新生成各节点的 jvm_options 并调度滚动重启。
+func (h *Handler) UpdateClusterJVM(c *gin.Context) {
+	clusterID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "无效的集群 ID / Invalid cluster ID")
+		return
+	}
+
+	var req UpdateJVMRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := h.service.UpdateJVM(c.Request.Context(), uint(clusterID), &req)
+	if err != nil {
+		response.Error(c, h.errorStatus(c, err), err.Error())
+		return
+	}
+
+	clusterName := h.getClusterNameForAudit(c.Request.Context(), uint(clusterID))
+	_ = audit.RecordFromGin(c, h.auditRepo, auth.GetUserIDFromContext(c), auth.GetUsernameFromContext(c),
+		"update_jvm", "cluster", audit.UintID(uint(clusterID)), clusterName, audit.AuditDetails{
+			"trigger":           "manual",
+			"jvm":               result.JVM,
+			"success":           result.Success,
+			"restart_scheduled": result.RestartScheduled,
+		})
+	logger.InfoF(c.Request.Context(), "[Cluster] JVM 配置已更新: cluster_id=%d, success=%v, restart_scheduled=%v", clusterID, result.Success, result.RestartScheduled)
+	response.OK(c, result)
+}
+
+// ==================== Scale Handlers 扩缩容处理器 ====================
+
+// StartScale handles POST /api/v1/clusters/:id/scale - adds/removes nodes of a running cluster.
+// StartScale 处理 POST /api/v1/clusters/:id/scale - 为运行中的集群添加/移除节点。
+func (h *Handler) StartScale(c *gin.Context) {
+	clusterID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "无效的集群 ID / Invalid cluster ID")
+		return
+	}
+
+	var req ScaleClusterRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	hostIDs := make([]uint, 0, len(req.AddNodes))
+	for _, node := range req.AddNodes {
+		hostIDs = append(hostIDs, node.HostID)
+	}
+	if !authorizeHosts(c, hostIDs...) {
+		return
+	}
+
+	task, err := h.service.StartScale(c.Request.Context(), uint(clusterID), &req, auth.GetUsernameFromContext(c))
+	if err != nil {
+		response.Error(c, h.errorStatus(c, err), err.Error())
+		return
+	}
+
+	clusterName := h.getClusterNameForAudit(c.Request.Context(), uint(clusterID))
+	_ = audit.RecordFromGin(c, h.auditRepo, auth.GetUserIDFromContext(c), auth.GetUsernameFromContext(c),
+		"scale", "cluster", audit.UintID(uint(clusterID)), clusterName, audit.AuditDetails{
+			"trigger": "manual",
+			"task_id": task.ID,
+			"add":     len(req.AddNodes),
+			"remove":  len(req.RemoveNodeIDs),
+		})
+	logger.InfoF(c.Request.Context(), "[Cluster] 扩缩容任务已创建: cluster_id=%d, task_id=%d, add=%d, remove=%d", clusterID, task.ID, len(req.AddNodes), len(req.RemoveNodeIDs))
+	response.JSON(c, http.StatusAccepted, task)
+}
+
+// DecommissionNode handles POST /api/v1/clusters/:id/nodes/:nodeId/decommission - drains, stops and removes a node.
+// DecommissionNode 处理 POST /api/v1/clusters/:id/nodes/:nodeId/decommission - 排空、停止并移除节点。
+func (h *Handler) DecommissionNode(c *gin.Context) {
+	clusterID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "无效的集群 ID / Invalid cluster ID")
+		return
+	}
+	nodeID, err := strconv.ParseUint(c.Param("nodeId"), 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "无效的节点 ID / Invalid node ID")
+		return
+	}
+
+	var req DrainOptions
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			response.Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	resourceName := h.getClusterNodeResourceName(c, uint(clusterID), uint(nodeID))
+	task, err := h.service.DecommissionNode(c.Request.Context(), uint(clusterID), uint(nodeID), &req, auth.GetUsernameFromContext(c))
+	if err != nil {
+		response.Error(c, h.errorStatus(c, err), err.Error())
+		return
+	}
+
+	resID := audit.UintID(uint(clusterID)) + "/" + audit.UintID(uint(nodeID))
+	_ = audit.RecordFromGin(c, h.auditRepo, auth.GetUserIDFromContext(c), auth.GetUsernameFromContext(c),
+		"decommission", "cluster_node", resID, resourceName, audit.AuditDetails{
+			"trigger":         "manual",
+			"task_id":         task.ID,
+			"timeout_seconds": task.Request.Drain.TimeoutSeconds,
+			"on_timeout":      task.Request.Drain.OnTimeout,
+		})
+	logger.InfoF(c.Request.Context(), "[Cluster] 节点下线任务已创建: cluster_id=%d, node_id=%d, task_id=%d", clusterID, nodeID, task.ID)
+	response.JSON(c, http.StatusAccepted, task)
+}
+
+// ListScaleTasks handles GET /api/v1/clusters/:id/scale/tasks - lists recent scale tasks.
+// ListScaleTasks 处理 GET /api/v1/clusters/:id/scale/tasks - 获取最近的扩缩容任务。
+func (h *Handler) ListScaleTasks(c *gin.Context) {
+	clusterID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "无效的集群 ID / Invalid cluster ID")
+		return
+	}
+
+	tasks, err := h.service.ListScaleTasks(c.Request.Context(), uint(clusterID))
+	if err != nil {
+		response.Error(c, h.errorStatus(c, err), err.Error())
+		return
+	}
+	response.OK(c, tasks)
+}
+
+// GetScaleTask handles GET /api/v1/clusters/:id/scale/tasks/:taskId - gets a scale task with its log.
+// GetScaleTask 处理 GET /api/v1/clusters/:id/scale/tasks/:taskId - 获取扩缩容任务及其日志。
+func (h *Handler) GetScaleTask(c *gin.Context) {
+	clusterID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "无效的集群 ID / Invalid cluster ID")
+		return
+	}
+	taskID, err := strconv.ParseUint(c.Param("taskId"), 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "无效的任务 ID / Invalid task ID")
+		return
+	}
+
+	task, err := h.service.GetScaleTask(c.Request.Context(), uint(clusterID), uint(taskID))
+	if err != nil {
+		response.Error(c, h.errorStatus(c, err), err.Error())
+		return
+	}
+	response.OK(c, task)
+}
+
 // ==================== Node Operation Handlers 节点操作处理器 ====================
 
 // StartNode handles POST /api/v1/clusters/:id/nodes/:nodeId/start - starts a node.
@@ -1176,20 +1768,20 @@ func (h *Handler) getStatusCodeForError(err error) int {
 func (h *Handler) StartNode(c *gin.Context) {
 	clusterID, err := strconv.ParseUint(c.Param("id"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, ClusterOperationResponse{ErrorMsg: "无效的集群 ID / Invalid cluster ID"})
+		response.Error(c, http.StatusBadRequest, "无效的集群 ID / Invalid cluster ID")
 		return
 	}
 
 	nodeID, err := strconv.ParseUint(c.Param("nodeId"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, ClusterOperationResponse{ErrorMsg: "无效的节点 ID / Invalid node ID"})
+		response.Error(c, http.StatusBadRequest, "无效的节点 ID / Invalid node ID")
 		return
 	}
 
 	result, err := h.service.StartNode(c.Request.Context(), uint(clusterID), uint(nodeID))
 	if err != nil {
-		statusCode := h.getStatusCodeForError(err)
-		c.JSON(statusCode, ClusterOperationResponse{ErrorMsg: err.Error()})
+		statusCode := h.errorStatus(c, err)
+		response.Error(c, statusCode, err.Error())
 		return
 	}
 
@@ -1202,7 +1794,7 @@ func (h *Handler) StartNode(c *gin.Context) {
 	_ = audit.RecordFromGin(c, h.auditRepo, auth.GetUserIDFromContext(c), auth.GetUsernameFromContext(c),
 		"start_node", "cluster_node", resID, resourceName, audit.AuditDetails{"trigger": "manual"})
 	logger.InfoF(c.Request.Context(), "[Cluster] 启动节点: cluster_id=%d, node_id=%d, success=%v", clusterID, nodeID, result.Success)
-	c.JSON(http.StatusOK, ClusterOperationResponse{Data: result})
+	response.OK(c, result)
 }
 
 // StopNode handles POST /api/v1/clusters/:id/nodes/:nodeId/stop - stops a node.
@@ -1210,20 +1802,20 @@ func (h *Handler) StartNode(c *gin.Context) {
 func (h *Handler) StopNode(c *gin.Context) {
 	clusterID, err := strconv.ParseUint(c.Param("id"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, ClusterOperationResponse{ErrorMsg: "无效的集群 ID / Invalid cluster ID"})
+		response.Error(c, http.StatusBadRequest, "无效的集群 ID / Invalid cluster ID")
 		return
 	}
 
 	nodeID, err := strconv.ParseUint(c.Param("nodeId"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, ClusterOperationResponse{ErrorMsg: "无效的节点 ID / Invalid node ID"})
+		response.Error(c, http.StatusBadRequest, "无效的节点 ID / Invalid node ID")
 		return
 	}
 
 	result, err := h.service.StopNode(c.Request.Context(), uint(clusterID), uint(nodeID))
 	if err != nil {
-		statusCode := h.getStatusCodeForError(err)
-		c.JSON(statusCode, ClusterOperationResponse{ErrorMsg: err.Error()})
+		statusCode := h.errorStatus(c, err)
+		response.Error(c, statusCode, err.Error())
 		return
 	}
 
@@ -1244,7 +1836,7 @@ func (h *Handler) StopNode(c *gin.Context) {
 		result,
 		auth.GetUsernameFromContext(c),
 	))
-	c.JSON(http.StatusOK, ClusterOperationResponse{Data: result})
+	response.OK(c, result)
 }
 
 // RestartNode handles POST /api/v1/clusters/:id/nodes/:nodeId/restart - restarts a node.
@@ -1252,20 +1844,20 @@ func (h *Handler) StopNode(c *gin.Context) {
 func (h *Handler) RestartNode(c *gin.Context) {
 	clusterID, err := strconv.ParseUint(c.Param("id"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, ClusterOperationResponse{ErrorMsg: "无效的集群 ID / Invalid cluster ID"})
+		response.Error(c, http.StatusBadRequest, "无效的集群 ID / Invalid cluster ID")
 		return
 	}
 
 	nodeID, err := strconv.ParseUint(c.Param("nodeId"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, ClusterOperationResponse{ErrorMsg: "无效的节点 ID / Invalid node ID"})
+		response.Error(c, http.StatusBadRequest, "无效的节点 ID / Invalid node ID")
 		return
 	}
 
 	result, err := h.service.RestartNode(c.Request.Context(), uint(clusterID), uint(nodeID))
 	if err != nil {
-		statusCode := h.getStatusCodeForError(err)
-		c.JSON(statusCode, ClusterOperationResponse{ErrorMsg: err.Error()})
+		statusCode := h.errorStatus(c, err)
+		response.Error(c, statusCode, err.Error())
 		return
 	}
 
@@ -1286,14 +1878,14 @@ func (h *Handler) RestartNode(c *gin.Context) {
 		result,
 		auth.GetUsernameFromContext(c),
 	))
-	c.JSON(http.StatusOK, ClusterOperationResponse{Data: result})
+	response.OK(c, result)
 }
 
 // GetNodeLogsResponse represents the response for getting node logs.
 // GetNodeLogsResponse 表示获取节点日志的响应。
 type GetNodeLogsResponse struct {
-	ErrorMsg string `json:"error_msg"`
-	Data     *struct {
+	response.Meta
+	Data *struct {
 		Logs string `json:"logs"`
 	} `json:"data"`
 }
@@ -1308,13 +1900,13 @@ type GetNodeLogsResponse struct {
 func (h *Handler) GetNodeLogs(c *gin.Context) {
 	clusterID, err := strconv.ParseUint(c.Param("id"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, GetNodeLogsResponse{ErrorMsg: "无效的集群 ID / Invalid cluster ID"})
+		response.Error(c, http.StatusBadRequest, "无效的集群 ID / Invalid cluster ID")
 		return
 	}
 
 	nodeID, err := strconv.ParseUint(c.Param("nodeId"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, GetNodeLogsResponse{ErrorMsg: "无效的节点 ID / Invalid node ID"})
+		response.Error(c, http.StatusBadRequest, "无效的节点 ID / Invalid node ID")
 		return
 	}
 
@@ -1331,12 +1923,74 @@ func (h *Handler) GetNodeLogs(c *gin.Context) {
 
 	logs, err := h.service.GetNodeLogs(c.Request.Context(), uint(clusterID), uint(nodeID), req)
 	if err != nil {
-		statusCode := h.getStatusCodeForError(err)
-		c.JSON(statusCode, GetNodeLogsResponse{ErrorMsg: err.Error()})
+		statusCode := h.errorStatus(c, err)
+		response.Error(c, statusCode, err.Error())
 		return
 	}
 
-	c.JSON(http.StatusOK, GetNodeLogsResponse{Data: &struct {
+	response.OK(c, &struct {
 		Logs string `json:"logs"`
-	}{Logs: logs}})
+	}{Logs: logs})
+}
+
+// DiagnoseNodeResponse represents the response for diagnosing a node JVM.
+// DiagnoseNodeResponse 表示节点 JVM 诊断的响应。
+type DiagnoseNodeResponse struct {
+	response.Meta
+	Data *NodeDiagnosis `json:"data"`
+}
+
+// DiagnoseNode handles POST /api/v1/clusters/:id/nodes/:nodeId/diagnose - collects thread dump and heap info.
+// DiagnoseNode 处理 POST /api/v1/clusters/:id/nodes/:nodeId/diagnose - 采集线程栈与堆信息。
+func (h *Handler) DiagnoseNode(c *gin.Context) {
+	clusterID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "无效的集群 ID / Invalid cluster ID")
+		return
+	}
+
+	nodeID, err := strconv.ParseUint(c.Param("nodeId"), 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "无效的节点 ID / Invalid node ID")
+		return
+	}
+
+	req := &DiagnoseNodeRequest{}
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(req); err != nil {
+			response.Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	diagnosis, err := h.service.DiagnoseNode(c.Request.Context(), uint(clusterID), uint(nodeID), req)
+	if err != nil {
+		response.Error(c, h.errorStatus(c, err), err.Error())
+		return
+	}
+
+	clusterName, nodeDisplay := h.service.GetClusterNodeDisplayInfo(c.Request.Context(), uint(clusterID), uint(nodeID))
+	resourceName := clusterName
+	if nodeDisplay != "" {
+		resourceName = clusterName + "（" + nodeDisplay + "）"
+	}
+	actions := make([]string, 0, len(diagnosis.Sections))
+	for _, section := range diagnosis.Sections {
+		actions = append(actions, section.Action)
+	}
+	resID := audit.UintID(uint(clusterID)) + "/" + audit.UintID(uint(nodeID))
+	_ = audit.RecordFromGin(c, h.auditRepo, auth.GetUserIDFromContext(c), auth.GetUsernameFromContext(c),
+		"diagnose_node", "cluster_node", resID, resourceName, audit.AuditDetails{"actions": actions, "pid": diagnosis.PID})
+	response.OK(c, diagnosis)
+}
+
+// authorizeHosts rejects requests that place nodes on hosts of projects the caller cannot access.
+// authorizeHosts 拒绝将节点放置到调用者无权访问项目的主机上的请求。
+func authorizeHosts(c *gin.Context, hostIDs ...uint) bool {
+	for _, hostID := range hostIDs {
+		if !auth.AuthorizeTenant(c, auth.ResourceHost, hostID) {
+			return false
+		}
+	}
+	return true
 }
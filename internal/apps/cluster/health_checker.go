@@ -0,0 +1,489 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/seatunnel/seatunnelX/internal/logger"
+	"github.com/seatunnel/seatunnelX/internal/pkg/eventbus"
+	"github.com/seatunnel/seatunnelX/internal/pkg/leader"
+	"github.com/seatunnel/seatunnelX/internal/pkg/worker"
+)
+
+// DefaultHealthCheckInterval is the default interval between node health check rounds.
+// DefaultHealthCheckInterval 是节点健康检查轮次之间的默认间隔。
+const DefaultHealthCheckInterval = 30 * time.Second
+
+const (
+	defaultHealthFailureThreshold   = 3
+	defaultRecoveryMaxAttempts      = 3
+	defaultRecoveryInitialBackoffS  = 30
+	defaultRecoveryMaxBackoffS      = 600
+	healthCheckClusterConfigKey     = "health_check"
+	healthCheckHazelcastReadyPath   = "/hazelcast/health/ready"
+	healthCheckRESTOverviewPath     = "/overview"
+	healthCheckAgentOfflineMessage  = "agent offline / Agent 离线"
+	healthCheckNoProbeTargetMessage = "no probe target configured / 未配置探测目标"
+)
+
+// HealthCheckPolicy is the per-cluster health check and automatic recovery policy,
+// stored under the "health_check" key of the cluster config.
+// HealthCheckPolicy 是集群级健康检查与自动恢复策略，存放在集群配置的 "health_check" 键下。
+type HealthCheckPolicy struct {
+	// AutoRecover enables automatic RESTART of unhealthy nodes.
+	// AutoRecover 启用对不健康节点的自动重启。
+	AutoRecover bool `json:"auto_recover"`
+	// FailureThreshold is the number of consecutive failed probes before a node is marked unhealthy.
+	// FailureThreshold 是节点被标记为不健康前的连续探测失败次数。
+	FailureThreshold int `json:"failure_threshold"`
+	// MaxRestartAttempts is the number of automatic restarts before escalating.
+	// MaxRestartAttempts 是升级告警前的自动重启次数。
+	MaxRestartAttempts int `json:"max_restart_attempts"`
+	// InitialBackoffSeconds is the delay after the first restart attempt.
+	// InitialBackoffSeconds 是第一次重启尝试后的等待时间。
+	InitialBackoffSeconds int `json:"initial_backoff_seconds"`
+	// MaxBackoffSeconds caps the exponential backoff between restart attempts.
+	// MaxBackoffSeconds 是重启尝试之间指数退避的上限。
+	MaxBackoffSeconds int `json:"max_backoff_seconds"`
+}
+
+// DefaultHealthCheckPolicy returns the policy used when a cluster has none configured.
+// Automatic recovery is disabled by default.
+// DefaultHealthCheckPolicy 返回集群未配置时使用的策略，默认不启用自动恢复。
+func DefaultHealthCheckPolicy() *HealthCheckPolicy {
+	return &HealthCheckPolicy{
+		FailureThreshold:      defaultHealthFailureThreshold,
+		MaxRestartAttempts:    defaultRecoveryMaxAttempts,
+		InitialBackoffSeconds: defaultRecoveryInitialBackoffS,
+		MaxBackoffSeconds:     defaultRecoveryMaxBackoffS,
+	}
+}
+
+// GetHealthCheckPolicy returns the health check policy from cluster config, filled with defaults.
+// GetHealthCheckPolicy 从集群配置中返回健康检查策略，并补全默认值。
+func (c ClusterConfig) GetHealthCheckPolicy() *HealthCheckPolicy {
+	policy := DefaultHealthCheckPolicy()
+	raw, ok := c[healthCheckClusterConfigKey]
+	if !ok || raw == nil {
+		return policy
+	}
+
+	payload, err := json.Marshal(raw)
+	if err != nil {
+		return policy
+	}
+	var parsed HealthCheckPolicy
+	if err := json.Unmarshal(payload, &parsed); err != nil {
+		return policy
+	}
+
+	policy.AutoRecover = parsed.AutoRecover
+	if parsed.FailureThreshold > 0 {
+		policy.FailureThreshold = parsed.FailureThreshold
+	}
+	if parsed.MaxRestartAttempts > 0 {
+		policy.MaxRestartAttempts = parsed.MaxRestartAttempts
+	}
+	if parsed.InitialBackoffSeconds > 0 {
+		policy.InitialBackoffSeconds = parsed.InitialBackoffSeconds
+	}
+	if parsed.MaxBackoffSeconds > 0 {
+		policy.MaxBackoffSeconds = parsed.MaxBackoffSeconds
+	}
+	return policy
+}
+
+// restartBackoff returns the wait after the given restart attempt (1-based): initial * 2^(attempt-1), capped.
+// restartBackoff 返回第 attempt 次（从 1 开始）重启后的等待时间：initial * 2^(attempt-1)，有上限。
+func (p *HealthCheckPolicy) restartBackoff(attempt int) time.Duration {
+	backoff := time.Duration(p.InitialBackoffSeconds) * time.Second
+	maxBackoff := time.Duration(p.MaxBackoffSeconds) * time.Second
+	for i := 1; i < attempt && backoff < maxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > maxBackoff {
+		backoff = maxBackoff
+	}
+	return backoff
+}
+
+// NodeHealth is the latest health check state of a cluster node.
+// NodeHealth 是集群节点最近一次的健康检查状态。
+type NodeHealth struct {
+	ClusterID           uint       `json:"cluster_id"`
+	NodeID              uint       `json:"node_id"`
+	HostID              uint       `json:"host_id"`
+	Healthy             bool       `json:"healthy"`
+	HTTPReachable       bool       `json:"http_reachable"`
+	ClusterMember       bool       `json:"cluster_member"`
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	LastError           string     `json:"last_error,omitempty"`
+	LastCheckedAt       time.Time  `json:"last_checked_at"`
+	RestartAttempts     int        `json:"restart_attempts"`
+	NextRestartAt       *time.Time `json:"next_restart_at,omitempty"`
+	Escalated           bool       `json:"escalated"`
+}
+
+// RecoveryEscalation describes a node that automatic recovery failed to bring back.
+// RecoveryEscalation 描述自动恢复未能恢复的节点。
+type RecoveryEscalation struct {
+	ClusterID   uint   `json:"cluster_id"`
+	ClusterName string `json:"cluster_name"`
+	NodeID      uint   `json:"node_id"`
+	HostID      uint   `json:"host_id"`
+	Role        string `json:"role"`
+	Attempts    int    `json:"attempts"`
+	LastError   string `json:"last_error"`
+}
+
+// recoveryAction is the action decided for an unhealthy node.
+// recoveryAction 是为不健康节点决定的动作。
+type recoveryAction int
+
+const (
+	recoveryNone recoveryAction = iota
+	recoveryRestart
+	recoveryEscalate
+)
+
+// nextRecoveryAction decides whether to restart or escalate an unhealthy node under this policy.
+// nextRecoveryAction 根据策略决定对不健康节点执行重启还是升级告警。
+func (p *HealthCheckPolicy) nextRecoveryAction(state *NodeHealth, now time.Time) recoveryAction {
+	if !p.AutoRecover || state.Healthy || state.ConsecutiveFailures < p.FailureThreshold || state.Escalated {
+		return recoveryNone
+	}
+	if state.NextRestartAt != nil && now.Before(*state.NextRestartAt) {
+		return recoveryNone
+	}
+	if state.RestartAttempts >= p.MaxRestartAttempts {
+		return recoveryEscalate
+	}
+	return recoveryRestart
+}
+
+// SetOnRecoveryEscalation sets the hook called when automatic recovery of a node is exhausted
+// (typically used to write an audit event).
+// SetOnRecoveryEscalation 设置节点自动恢复次数耗尽时的回调（通常用于写入审计事件）。
+func (s *Service) SetOnRecoveryEscalation(fn func(context.Context, *RecoveryEscalation)) {
+	s.onRecoveryEscalation = fn
+}
+
+// StartHealthChecker starts the background node health checker.
+// StartHealthChecker 启动后台节点健康检查。
+func (s *Service) StartHealthChecker(ctx context.Context) {
+	if s == nil || s.repo == nil || s.hostProvider == nil || s.agentSender == nil {
+		return
+	}
+
+	interval := s.healthCheckInterval
+	if interval <= 0 {
+		interval = DefaultHealthCheckInterval
+	}
+
+	s.healthCheckRuntime.Do(func() {
+		go func() {
+			defer worker.Start("cluster-health-checker")()
+
+			ticker := time.NewTicker(interval)
+			defer ticker.Stop()
+
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					if leader.IsLeader() {
+						s.runHealthCheckRound(ctx, time.Now())
+					}
+				}
+			}
+		}()
+	})
+}
+
+// GetNodeHealth returns the latest health state of the nodes of a cluster.
+// GetNodeHealth 返回集群节点最近的健康状态。
+func (s *Service) GetNodeHealth(ctx context.Context, clusterID uint) ([]*NodeHealth, error) {
+	if _, err := s.repo.GetByID(ctx, clusterID, false); err != nil {
+		return nil, err
+	}
+
+	s.healthMu.Lock()
+	defer s.healthMu.Unlock()
+	result := make([]*NodeHealth, 0)
+	for _, state := range s.nodeHealth {
+		if state.ClusterID == clusterID {
+			copied := *state
+			result = append(result, &copied)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].NodeID < result[j].NodeID })
+	return result, nil
+}
+
+// runHealthCheckRound probes every running node of every cluster once.
+// runHealthCheckRound 对所有集群的所有运行中节点执行一轮探测。
+func (s *Service) runHealthCheckRound(ctx context.Context, now time.Time) {
+	// Unpaginated so that no cluster is left unprobed however many there are
+	// 不分页查询，无论集群数量多少都不会遗漏
+	clusters, _, err := s.repo.List(ctx, nil)
+	if err != nil {
+		logger.WarnF(ctx, "[ClusterHealth] list clusters failed: %v", err)
+		return
+	}
+
+	for _, item := range clusters {
+		if item == nil || item.ID == 0 {
+			continue
+		}
+		nodes, err := s.repo.GetNodesByClusterID(ctx, item.ID)
+		if err != nil {
+			logger.WarnF(ctx, "[ClusterHealth] list nodes failed: cluster=%d, err=%v", item.ID, err)
+			continue
+		}
+
+		policy := item.Config.GetHealthCheckPolicy()
+		statusChanged := false
+		for _, node := range nodes {
+			if s.checkNodeHealth(ctx, item, node, policy, now) {
+				statusChanged = true
+			}
+		}
+		if statusChanged {
+			s.updateClusterStatusFromNodes(ctx, item.ID)
+		}
+	}
+	s.pruneNodeHealth(clusters)
+}
+
+// checkNodeHealth probes one node, updates its state and applies the recovery policy.
+// It returns true when the persisted node status was changed.
+// checkNodeHealth 探测单个节点，更新其状态并执行恢复策略；持久化的节点状态发生变化时返回 true。
+func (s *Service) checkNodeHealth(ctx context.Context, cluster *Cluster, node *ClusterNode, policy *HealthCheckPolicy, now time.Time) bool {
+	s.healthMu.Lock()
+	previous, tracked := s.nodeHealth[node.ID]
+	failing := tracked && !previous.Healthy
+	s.healthMu.Unlock()
+
+	// Only nodes expected to be running are probed; error/stopped nodes are followed only while
+	// they are failing health checks (e.g. a restart attempt left the process down).
+	// 仅探测应处于运行状态的节点；错误/停止状态节点仅在健康检查失败期间继续跟踪（如重启后进程仍未拉起）。
+	switch node.Status {
+	case NodeStatusRunning:
+	case NodeStatusError, NodeStatusStopped:
+		if !failing {
+			return false
+		}
+	default:
+		return false
+	}
+
+	httpOK, memberOK, probeErr := s.probeNode(ctx, node)
+
+	s.healthMu.Lock()
+	if s.nodeHealth == nil {
+		s.nodeHealth = make(map[uint]*NodeHealth)
+	}
+	state, ok := s.nodeHealth[node.ID]
+	if !ok {
+		state = &NodeHealth{ClusterID: cluster.ID, NodeID: node.ID, HostID: node.HostID}
+		s.nodeHealth[node.ID] = state
+	}
+	state.LastCheckedAt = now
+	state.HTTPReachable = httpOK
+	state.ClusterMember = memberOK
+	if probeErr == "" {
+		state.Healthy = true
+		state.ConsecutiveFailures = 0
+		state.LastError = ""
+		state.RestartAttempts = 0
+		state.NextRestartAt = nil
+		state.Escalated = false
+	} else {
+		state.Healthy = false
+		state.ConsecutiveFailures++
+		state.LastError = probeErr
+	}
+	snapshot := *state
+	s.healthMu.Unlock()
+
+	if snapshot.Healthy {
+		if node.Status != NodeStatusRunning {
+			logger.InfoF(ctx, "[ClusterHealth] node recovered: cluster=%d, node=%d", cluster.ID, node.ID)
+			_ = s.repo.UpdateNodeStatus(ctx, node.ID, NodeStatusRunning)
+			return true
+		}
+		return false
+	}
+
+	statusChanged := false
+	if snapshot.ConsecutiveFailures >= policy.FailureThreshold && node.Status == NodeStatusRunning {
+		logger.WarnF(ctx, "[ClusterHealth] node unhealthy: cluster=%d, node=%d, failures=%d, err=%s",
+			cluster.ID, node.ID, snapshot.ConsecutiveFailures, snapshot.LastError)
+		_ = s.repo.UpdateNodeStatus(ctx, node.ID, NodeStatusError)
+		statusChanged = true
+		eventbus.Emit(ctx, eventbus.NodeCrashed{
+			ClusterID:   cluster.ID,
+			ClusterName: cluster.Name,
+			NodeID:      node.ID,
+			HostID:      node.HostID,
+			Role:        string(node.Role),
+			Failures:    snapshot.ConsecutiveFailures,
+			Error:       snapshot.LastError,
+		})
+	}
+
+	switch policy.nextRecoveryAction(&snapshot, now) {
+	case recoveryRestart:
+		s.recoverNode(ctx, cluster, node, policy, now)
+		statusChanged = true
+	case recoveryEscalate:
+		s.escalateRecovery(ctx, cluster, node, &snapshot)
+	}
+	return statusChanged
+}
+
+// probeNode checks the node's SeaTunnel REST port and Hazelcast membership via its Agent.
+// It returns an empty error string when the node is healthy.
+// probeNode 通过 Agent 检查节点的 SeaTunnel REST 端口与 Hazelcast 集群成员状态；健康时返回空错误字符串。
+func (s *Service) probeNode(ctx context.Context, node *ClusterNode) (httpOK bool, memberOK bool, probeErr string) {
+	hostInfo, err := s.hostProvider.GetHostByID(ctx, node.HostID)
+	if err != nil || hostInfo.AgentID == "" || !hostInfo.IsOnline(s.heartbeatTimeout) {
+		return false, false, healthCheckAgentOfflineMessage
+	}
+
+	var failures []string
+	probed := false
+
+	// SeaTunnel REST API V2 (master/hybrid with api_port)
+	// SeaTunnel REST API V2（配置了 api_port 的 master/hybrid）
+	if node.APIPort > 0 {
+		probed = true
+		httpOK = s.probeHTTP(ctx, hostInfo.AgentID, fmt.Sprintf("http://127.0.0.1:%d%s", node.APIPort, healthCheckRESTOverviewPath))
+		if !httpOK {
+			failures = append(failures, fmt.Sprintf("http port %d unreachable / HTTP 端口 %d 不可达", node.APIPort, node.APIPort))
+		}
+	} else {
+		httpOK = true
+	}
+
+	// Hazelcast health endpoint only reports ready once the member has joined the cluster
+	// Hazelcast 健康端点仅在成员加入集群后才返回就绪
+	if node.HazelcastPort > 0 {
+		probed = true
+		memberOK = s.probeHTTP(ctx, hostInfo.AgentID, fmt.Sprintf("http://127.0.0.1:%d%s", node.HazelcastPort, healthCheckHazelcastReadyPath))
+		if !memberOK {
+			failures = append(failures, fmt.Sprintf("not a ready hazelcast member on port %d / 端口 %d 上的 Hazelcast 成员未就绪", node.HazelcastPort, node.HazelcastPort))
+		}
+	} else {
+		memberOK = true
+	}
+
+	if !probed {
+		return httpOK, memberOK, healthCheckNoProbeTargetMessage
+	}
+	return httpOK, memberOK, strings.Join(failures, "; ")
+}
+
+// probeHTTP asks the Agent to check an HTTP endpoint on its host.
+// probeHTTP 请求 Agent 检查其主机上的 HTTP 端点。
+func (s *Service) probeHTTP(ctx context.Context, agentID, url string) bool {
+	success, _, err := s.agentSender.SendCommand(ctx, agentID, "check_http", map[string]string{"url": url})
+	return err == nil && success
+}
+
+// recoverNode issues a RESTART for an unhealthy node and schedules the next attempt with backoff.
+// recoverNode 对不健康节点发起重启，并按退避安排下一次尝试。
+func (s *Service) recoverNode(ctx context.Context, cluster *Cluster, node *ClusterNode, policy *HealthCheckPolicy, now time.Time) {
+	s.healthMu.Lock()
+	state := s.nodeHealth[node.ID]
+	state.RestartAttempts++
+	attempt := state.RestartAttempts
+	next := now.Add(policy.restartBackoff(attempt))
+	state.NextRestartAt = &next
+	s.healthMu.Unlock()
+
+	logger.WarnF(ctx, "[ClusterHealth] auto restarting node: cluster=%d, node=%d, attempt=%d/%d",
+		cluster.ID, node.ID, attempt, policy.MaxRestartAttempts)
+
+	result, err := s.restartNodeWithResolvedSpec(ctx, cluster.ID, node)
+	if err != nil {
+		logger.WarnF(ctx, "[ClusterHealth] auto restart failed: cluster=%d, node=%d, err=%v", cluster.ID, node.ID, err)
+		return
+	}
+	if result != nil && !result.Success {
+		logger.WarnF(ctx, "[ClusterHealth] auto restart failed: cluster=%d, node=%d, message=%s", cluster.ID, node.ID, result.Message)
+	}
+}
+
+// escalateRecovery marks recovery as exhausted and fires the escalation hook once.
+// escalateRecovery 将恢复标记为已耗尽并触发一次升级回调。
+func (s *Service) escalateRecovery(ctx context.Context, cluster *Cluster, node *ClusterNode, snapshot *NodeHealth) {
+	s.healthMu.Lock()
+	s.nodeHealth[node.ID].Escalated = true
+	s.healthMu.Unlock()
+
+	logger.ErrorF(ctx, "[ClusterHealth] automatic recovery exhausted: cluster=%d, node=%d, attempts=%d, err=%s",
+		cluster.ID, node.ID, snapshot.RestartAttempts, snapshot.LastError)
+
+	if s.onRecoveryEscalation == nil {
+		return
+	}
+	s.onRecoveryEscalation(ctx, &RecoveryEscalation{
+		ClusterID:   cluster.ID,
+		ClusterName: cluster.Name,
+		NodeID:      node.ID,
+		HostID:      node.HostID,
+		Role:        string(node.Role),
+		Attempts:    snapshot.RestartAttempts,
+		LastError:   snapshot.LastError,
+	})
+}
+
+// forgetNodeHealth drops the health state of a node, e.g. after it was stopped on purpose.
+// forgetNodeHealth 清除节点的健康状态，例如节点被主动停止后。
+func (s *Service) forgetNodeHealth(nodeID uint) {
+	s.healthMu.Lock()
+	defer s.healthMu.Unlock()
+	delete(s.nodeHealth, nodeID)
+}
+
+// pruneNodeHealth drops states of clusters that no longer exist.
+// pruneNodeHealth 清理已不存在集群的健康状态。
+func (s *Service) pruneNodeHealth(clusters []*Cluster) {
+	existing := make(map[uint]struct{}, len(clusters))
+	for _, item := range clusters {
+		if item != nil {
+			existing[item.ID] = struct{}{}
+		}
+	}
+
+	s.healthMu.Lock()
+	defer s.healthMu.Unlock()
+	for nodeID, state := range s.nodeHealth {
+		if _, ok := existing[state.ClusterID]; !ok {
+			delete(s.nodeHealth, nodeID)
+		}
+	}
+}
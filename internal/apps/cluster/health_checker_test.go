@@ -0,0 +1,138 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cluster
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestClusterConfig_GetHealthCheckPolicy(t *testing.T) {
+	policy := ClusterConfig{}.GetHealthCheckPolicy()
+	if policy.AutoRecover {
+		t.Fatal("expected auto recovery disabled by default")
+	}
+	if policy.FailureThreshold != defaultHealthFailureThreshold {
+		t.Fatalf("expected default failure threshold %d, got %d", defaultHealthFailureThreshold, policy.FailureThreshold)
+	}
+
+	policy = ClusterConfig{
+		"health_check": map[string]interface{}{
+			"auto_recover":         true,
+			"max_restart_attempts": 5,
+		},
+	}.GetHealthCheckPolicy()
+	if !policy.AutoRecover || policy.MaxRestartAttempts != 5 {
+		t.Fatalf("expected configured policy, got %+v", policy)
+	}
+	if policy.InitialBackoffSeconds != defaultRecoveryInitialBackoffS {
+		t.Fatalf("expected default initial backoff, got %d", policy.InitialBackoffSeconds)
+	}
+}
+
+func TestHealthCheckPolicy_restartBackoff(t *testing.T) {
+	policy := &HealthCheckPolicy{InitialBackoffSeconds: 10, MaxBackoffSeconds: 60}
+	expected := []time.Duration{10 * time.Second, 20 * time.Second, 40 * time.Second, 60 * time.Second, 60 * time.Second}
+	for i, want := range expected {
+		if got := policy.restartBackoff(i + 1); got != want {
+			t.Fatalf("attempt %d: expected %v, got %v", i+1, want, got)
+		}
+	}
+}
+
+func TestService_runHealthCheckRound_restartsAndEscalates(t *testing.T) {
+	db, cleanup := setupServiceTestDB(t)
+	defer cleanup()
+
+	repo := NewRepository(db)
+	hostProvider := NewMockHostProvider()
+	now := time.Now()
+	hostProvider.AddHost(&HostInfo{ID: 1, Name: "host-1", IPAddress: "127.0.0.1", AgentID: "agent-1", LastHeartbeat: &now})
+
+	restarts := 0
+	service := NewService(repo, hostProvider, &ServiceConfig{HeartbeatTimeout: time.Hour})
+	service.SetAgentCommandSender(&scriptedAgentSender{
+		send: func(ctx context.Context, agentID string, commandType string, params map[string]string) (bool, string, error) {
+			switch commandType {
+			case "check_http":
+				return false, "connection refused", nil
+			case "restart":
+				restarts++
+				return true, "ok", nil
+			}
+			return true, "SeaTunnel process found: PID=4321, role=hybrid", nil
+		},
+	})
+	var escalations []*RecoveryEscalation
+	service.SetOnRecoveryEscalation(func(ctx context.Context, escalation *RecoveryEscalation) {
+		escalations = append(escalations, escalation)
+	})
+
+	ctx := context.Background()
+	cluster := &Cluster{
+		Name:           "health-demo",
+		DeploymentMode: DeploymentModeHybrid,
+		InstallDir:     "/opt/seatunnel",
+		Status:         ClusterStatusRunning,
+		Config: ClusterConfig{
+			"health_check": map[string]interface{}{
+				"auto_recover":            true,
+				"failure_threshold":       1,
+				"max_restart_attempts":    1,
+				"initial_backoff_seconds": 60,
+			},
+		},
+	}
+	if err := repo.Create(ctx, cluster); err != nil {
+		t.Fatalf("create cluster failed: %v", err)
+	}
+	node := &ClusterNode{ClusterID: cluster.ID, HostID: 1, Role: NodeRoleMasterWorker, HazelcastPort: 5801, Status: NodeStatusRunning}
+	if err := repo.AddNode(ctx, node); err != nil {
+		t.Fatalf("add node failed: %v", err)
+	}
+
+	service.runHealthCheckRound(ctx, now)
+	if restarts != 1 {
+		t.Fatalf("expected one automatic restart, got %d", restarts)
+	}
+
+	// Within backoff: no further action / 退避期内不再处理
+	service.runHealthCheckRound(ctx, now.Add(30*time.Second))
+	if restarts != 1 || len(escalations) != 0 {
+		t.Fatalf("expected no action within backoff, restarts=%d escalations=%d", restarts, len(escalations))
+	}
+
+	// After backoff with attempts exhausted: escalate exactly once / 退避结束且次数耗尽：仅升级一次
+	service.runHealthCheckRound(ctx, now.Add(2*time.Minute))
+	service.runHealthCheckRound(ctx, now.Add(3*time.Minute))
+	if restarts != 1 {
+		t.Fatalf("expected no restart beyond max attempts, got %d", restarts)
+	}
+	if len(escalations) != 1 || escalations[0].NodeID != node.ID {
+		t.Fatalf("expected one escalation for node %d, got %+v", node.ID, escalations)
+	}
+
+	health, err := service.GetNodeHealth(ctx, cluster.ID)
+	if err != nil {
+		t.Fatalf("GetNodeHealth failed: %v", err)
+	}
+	if len(health) != 1 || health[0].Healthy || !health[0].Escalated {
+		t.Fatalf("expected escalated unhealthy node, got %+v", health)
+	}
+}
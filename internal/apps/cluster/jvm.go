@@ -0,0 +1,252 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	appconfig "github.com/seatunnel/seatunnelX/internal/apps/config"
+	"github.com/seatunnel/seatunnelX/internal/logger"
+	"github.com/seatunnel/seatunnelX/internal/pkg/jvmopts"
+)
+
+// UpdateJVMRequest is the body of updating cluster JVM settings.
+// UpdateJVMRequest 是更新集群 JVM 配置的请求体。
+type UpdateJVMRequest struct {
+	JVM JVMConfig `json:"jvm"`
+	// Restart schedules a rolling restart after the files are pushed, default true.
+	// Restart 表示推送文件后是否调度滚动重启，默认 true。
+	Restart *bool           `json:"restart,omitempty"`
+	Rolling *RollingOptions `json:"rolling,omitempty"`
+}
+
+// NodeJVMPreview is the effect of JVM settings on one node's jvm_options file.
+// NodeJVMPreview 是 JVM 配置对单个节点 jvm_options 文件的影响。
+type NodeJVMPreview struct {
+	NodeID     uint                 `json:"node_id"`
+	HostID     uint                 `json:"host_id"`
+	Role       NodeRole             `json:"role"`
+	ConfigType appconfig.ConfigType `json:"config_type"`
+	Current    string               `json:"current,omitempty"`
+	Rendered   string               `json:"rendered,omitempty"`
+	Changed    bool                 `json:"changed"`
+	// EffectiveFlags are the active flags of the rendered file.
+	// EffectiveFlags 是渲染后文件中生效的参数。
+	EffectiveFlags []string `json:"effective_flags,omitempty"`
+	Error          string   `json:"error,omitempty"`
+}
+
+// JVMPreviewResult lists the per-node effect of JVM settings.
+// JVMPreviewResult 列出 JVM 配置对各节点的影响。
+type JVMPreviewResult struct {
+	ClusterID uint              `json:"cluster_id"`
+	JVM       JVMConfig         `json:"jvm"`
+	Nodes     []*NodeJVMPreview `json:"nodes"`
+}
+
+// JVMUpdateResult is the outcome of updating cluster JVM settings.
+// JVMUpdateResult 是更新集群 JVM 配置的结果。
+type JVMUpdateResult struct {
+	JVMPreviewResult
+	Success bool `json:"success"`
+	// RestartScheduled is true when a rolling restart was started in background.
+	// RestartScheduled 为 true 表示已在后台发起滚动重启。
+	RestartScheduled bool `json:"restart_scheduled"`
+}
+
+// GetJVM returns the cluster-level JVM settings.
+// GetJVM 返回集群级 JVM 配置。
+func (s *Service) GetJVM(ctx context.Context, clusterID uint) (*JVMConfig, error) {
+	cluster, err := s.repo.GetByID(ctx, clusterID, false)
+	if err != nil {
+		return nil, err
+	}
+	if cfg := cluster.Config.GetJVMConfig(); cfg != nil {
+		return cfg, nil
+	}
+	return &JVMConfig{}, nil
+}
+
+// PreviewJVM renders the jvm_options file of every node with the given settings without pushing it.
+// PreviewJVM 使用给定配置渲染每个节点的 jvm_options 文件，但不推送。
+func (s *Service) PreviewJVM(ctx context.Context, clusterID uint, jvm *JVMConfig) (*JVMPreviewResult, error) {
+	cluster, settings, err := s.prepareJVM(ctx, clusterID, jvm)
+	if err != nil {
+		return nil, err
+	}
+	result := &JVMPreviewResult{ClusterID: clusterID, JVM: *settings}
+	for i := range cluster.Nodes {
+		result.Nodes = append(result.Nodes, s.previewNodeJVM(ctx, cluster, &cluster.Nodes[i], settings))
+	}
+	return result, nil
+}
+
+// UpdateJVM stores the JVM settings, regenerates jvm_options on each node through the agent
+// and schedules a rolling restart when the cluster is running and every file was pushed.
+// UpdateJVM 保存 JVM 配置，通过 Agent 重新生成各节点的 jvm_options，
+// 并在集群运行中且所有文件推送成功时调度滚动重启。
+func (s *Service) UpdateJVM(ctx context.Context, clusterID uint, req *UpdateJVMRequest) (*JVMUpdateResult, error) {
+	if req == nil {
+		req = &UpdateJVMRequest{}
+	}
+	if _, err := req.Rolling.normalize(); err != nil {
+		return nil, err
+	}
+	cluster, settings, err := s.prepareJVM(ctx, clusterID, &req.JVM)
+	if err != nil {
+		return nil, err
+	}
+
+	if cluster.Config == nil {
+		cluster.Config = ClusterConfig{}
+	}
+	cluster.Config["jvm"] = settings
+	if err := s.repo.Update(ctx, cluster); err != nil {
+		return nil, err
+	}
+
+	result := &JVMUpdateResult{
+		JVMPreviewResult: JVMPreviewResult{ClusterID: clusterID, JVM: *settings},
+		Success:          true,
+	}
+	changed := false
+	for i := range cluster.Nodes {
+		node := &cluster.Nodes[i]
+		preview := s.previewNodeJVM(ctx, cluster, node, settings)
+		if preview.Error == "" && preview.Changed {
+			installDir := resolveNodeInstallDir(node.InstallDir, cluster.InstallDir)
+			if err := s.configAgentClient.PushConfig(ctx, node.HostID, installDir, preview.ConfigType, preview.Rendered); err != nil {
+				preview.Error = fmt.Sprintf("push %s to host %d: %v", preview.ConfigType, node.HostID, err)
+			} else {
+				changed = true
+			}
+		}
+		if preview.Error != "" {
+			result.Success = false
+			logger.WarnF(ctx, "[Cluster] update jvm options failed: cluster=%d, node=%d, error=%s", clusterID, node.ID, preview.Error)
+		}
+		result.Nodes = append(result.Nodes, preview)
+	}
+
+	restart := req.Restart == nil || *req.Restart
+	if restart && changed && result.Success && cluster.Status == ClusterStatusRunning {
+		result.RestartScheduled = true
+		rolling := req.Rolling
+		go func(parent context.Context) {
+			restartCtx := context.Background()
+			defer func() {
+				if r := recover(); r != nil {
+					logger.WarnF(parent, "[Cluster] jvm rolling restart panic recovered: cluster=%d, panic=%v", clusterID, r)
+				}
+			}()
+			started := time.Now()
+			op, err := s.RollingRestart(restartCtx, clusterID, rolling)
+			switch {
+			case err != nil:
+				logger.WarnF(restartCtx, "[Cluster] jvm rolling restart failed: cluster=%d, error=%v", clusterID, err)
+			case !op.Success:
+				logger.WarnF(restartCtx, "[Cluster] jvm rolling restart finished with failures: cluster=%d, message=%s", clusterID, op.Message)
+			default:
+				logger.InfoF(restartCtx, "[Cluster] jvm rolling restart finished: cluster=%d, elapsed=%s", clusterID, time.Since(started))
+			}
+		}(ctx)
+	}
+	return result, nil
+}
+
+// prepareJVM loads the cluster and validates the settings against every role.
+// prepareJVM 加载集群并针对每种角色校验配置。
+func (s *Service) prepareJVM(ctx context.Context, clusterID uint, jvm *JVMConfig) (*Cluster, *JVMConfig, error) {
+	if s.configAgentClient == nil {
+		return nil, nil, ErrJVMTuningUnavailable
+	}
+	if jvm == nil {
+		jvm = &JVMConfig{}
+	}
+	settings := *jvm
+	settings.ExtraOptions = append([]string(nil), jvm.ExtraOptions...)
+	for _, heap := range []int{settings.HybridHeapSize, settings.MasterHeapSize, settings.WorkerHeapSize} {
+		if err := jvmFileOptions(&settings, heap).Validate(); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	cluster, err := s.repo.GetByID(ctx, clusterID, true)
+	if err != nil {
+		return nil, nil, err
+	}
+	return cluster, &settings, nil
+}
+
+// previewNodeJVM pulls the node's jvm_options file and renders it with the settings plus node overrides.
+// previewNodeJVM 拉取节点的 jvm_options 文件，并按配置与节点覆盖值渲染。
+func (s *Service) previewNodeJVM(ctx context.Context, cluster *Cluster, node *ClusterNode, settings *JVMConfig) *NodeJVMPreview {
+	configType, heap := jvmConfigTypeForNode(cluster.DeploymentMode, node, settings)
+	preview := &NodeJVMPreview{
+		NodeID:     node.ID,
+		HostID:     node.HostID,
+		Role:       node.Role,
+		ConfigType: configType,
+	}
+	installDir := resolveNodeInstallDir(node.InstallDir, cluster.InstallDir)
+	content, err := s.configAgentClient.PullConfig(ctx, node.HostID, installDir, configType)
+	if err != nil {
+		preview.Error = fmt.Sprintf("pull %s from host %d: %v", configType, node.HostID, err)
+		return preview
+	}
+	preview.Current = content
+	preview.Rendered = jvmopts.Render(content, jvmFileOptions(settings, heap))
+	preview.Changed = preview.Rendered != content
+	preview.EffectiveFlags = jvmopts.ActiveFlags(preview.Rendered)
+	return preview
+}
+
+// jvmConfigTypeForNode returns the jvm_options file of a node and its heap size in GB, honoring node overrides.
+// jvmConfigTypeForNode 返回节点对应的 jvm_options 文件及堆大小（GB），并考虑节点覆盖值。
+func jvmConfigTypeForNode(mode DeploymentMode, node *ClusterNode, settings *JVMConfig) (appconfig.ConfigType, int) {
+	resolved := *settings
+	if node.Overrides.JVM != nil {
+		if node.Overrides.JVM.HybridHeapSize != nil {
+			resolved.HybridHeapSize = *node.Overrides.JVM.HybridHeapSize
+		}
+		if node.Overrides.JVM.MasterHeapSize != nil {
+			resolved.MasterHeapSize = *node.Overrides.JVM.MasterHeapSize
+		}
+		if node.Overrides.JVM.WorkerHeapSize != nil {
+			resolved.WorkerHeapSize = *node.Overrides.JVM.WorkerHeapSize
+		}
+	}
+	if mode == DeploymentModeSeparated {
+		if node.Role == NodeRoleWorker {
+			return appconfig.ConfigTypeJVMWorkerOptions, resolved.WorkerHeapSize
+		}
+		return appconfig.ConfigTypeJVMMasterOptions, resolved.MasterHeapSize
+	}
+	return appconfig.ConfigTypeJVMOptions, resolved.HybridHeapSize
+}
+
+func jvmFileOptions(settings *JVMConfig, heapSizeGB int) jvmopts.Options {
+	return jvmopts.Options{
+		HeapSizeGB:        heapSizeGB,
+		GC:                jvmopts.GC(settings.GC),
+		MaxDirectMemoryMB: settings.MaxDirectMemoryMB,
+		ExtraOptions:      settings.ExtraOptions,
+	}
+}
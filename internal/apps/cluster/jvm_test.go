@@ -0,0 +1,121 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cluster
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	appconfig "github.com/seatunnel/seatunnelX/internal/apps/config"
+	"github.com/seatunnel/seatunnelX/internal/pkg/jvmopts"
+)
+
+const jvmTestOptions = `# JVM Heap
+-Xms2g
+-Xmx2g
+-XX:+UseG1GC
+`
+
+func TestService_UpdateJVM_regeneratesNodeJVMOptions(t *testing.T) {
+	svc, repo, _ := newScaleTestService(t)
+	ctx := context.Background()
+
+	files := &fileConfigAgentClient{files: map[string]string{
+		"1/" + string(appconfig.ConfigTypeJVMMasterOptions): jvmTestOptions,
+		"2/" + string(appconfig.ConfigTypeJVMWorkerOptions): jvmTestOptions,
+	}}
+	svc.SetConfigAgentClient(files)
+
+	cluster, err := svc.Create(ctx, &CreateClusterRequest{Name: "jvm-cluster", DeploymentMode: DeploymentModeSeparated, Version: "2.3.12"})
+	if err != nil {
+		t.Fatalf("Create returned error: %v", err)
+	}
+	if _, err := svc.AddNode(ctx, cluster.ID, &AddNodeRequest{HostID: 1, Role: NodeRoleMaster, SkipPrecheck: true}); err != nil {
+		t.Fatalf("AddNode(master) returned error: %v", err)
+	}
+	workerHeap := 8
+	if _, err := svc.AddNode(ctx, cluster.ID, &AddNodeRequest{
+		HostID:       2,
+		Role:         NodeRoleWorker,
+		SkipPrecheck: true,
+		Overrides:    &NodeOverrides{JVM: &NodeJVMOverrides{WorkerHeapSize: &workerHeap}},
+	}); err != nil {
+		t.Fatalf("AddNode(worker) returned error: %v", err)
+	}
+
+	if _, err := svc.PreviewJVM(ctx, cluster.ID, &JVMConfig{ExtraOptions: []string{"-Xmx4g"}}); !errors.Is(err, jvmopts.ErrInvalidOptions) {
+		t.Fatalf("expected ErrInvalidOptions, got %v", err)
+	}
+
+	jvm := JVMConfig{MasterHeapSize: 4, WorkerHeapSize: 6, GC: "zgc", ExtraOptions: []string{"-XX:+HeapDumpOnOutOfMemoryError"}}
+	preview, err := svc.PreviewJVM(ctx, cluster.ID, &jvm)
+	if err != nil {
+		t.Fatalf("PreviewJVM returned error: %v", err)
+	}
+	if len(preview.Nodes) != 2 {
+		t.Fatalf("expected 2 node previews, got %d", len(preview.Nodes))
+	}
+	for _, node := range preview.Nodes {
+		if !node.Changed || node.Error != "" {
+			t.Fatalf("unexpected preview of node %d: %+v", node.NodeID, node)
+		}
+	}
+	if files.files["1/"+string(appconfig.ConfigTypeJVMMasterOptions)] != jvmTestOptions {
+		t.Fatal("PreviewJVM must not push files")
+	}
+
+	result, err := svc.UpdateJVM(ctx, cluster.ID, &UpdateJVMRequest{JVM: jvm})
+	if err != nil {
+		t.Fatalf("UpdateJVM returned error: %v", err)
+	}
+	if !result.Success || result.RestartScheduled {
+		t.Fatalf("expected success without restart for a stopped cluster, got %+v", result)
+	}
+
+	master := files.files["1/"+string(appconfig.ConfigTypeJVMMasterOptions)]
+	for _, want := range []string{"-Xms4g", "-Xmx4g", "# -XX:+UseG1GC", "-XX:+UseZGC", "-XX:+HeapDumpOnOutOfMemoryError"} {
+		if !strings.Contains(master, want+"\n") {
+			t.Fatalf("master jvm options missing %q:\n%s", want, master)
+		}
+	}
+	worker := files.files["2/"+string(appconfig.ConfigTypeJVMWorkerOptions)]
+	if !strings.Contains(worker, "-Xmx8g\n") {
+		t.Fatalf("worker heap override not applied:\n%s", worker)
+	}
+
+	stored, err := repo.GetByID(ctx, cluster.ID, false)
+	if err != nil {
+		t.Fatalf("GetByID returned error: %v", err)
+	}
+	got := stored.Config.GetJVMConfig()
+	if got == nil || got.GC != "zgc" || got.MasterHeapSize != 4 || len(got.ExtraOptions) != 1 {
+		t.Fatalf("unexpected stored jvm config: %+v", got)
+	}
+
+	again, err := svc.PreviewJVM(ctx, cluster.ID, &jvm)
+	if err != nil {
+		t.Fatalf("PreviewJVM returned error: %v", err)
+	}
+	for _, node := range again.Nodes {
+		if node.Changed {
+			t.Fatalf("expected no change after update on node %d:\n%s", node.NodeID, node.Rendered)
+		}
+	}
+}
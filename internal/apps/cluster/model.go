@@ -23,6 +23,10 @@ import (
 	"encoding/json"
 	"errors"
 	"time"
+
+	"github.com/seatunnel/seatunnelX/internal/pkg/pagex"
+	"github.com/seatunnel/seatunnelX/internal/pkg/secrets"
+	"gorm.io/gorm"
 )
 
 // DeploymentMode represents the deployment mode of a SeaTunnel cluster.
@@ -51,6 +55,19 @@ const (
 	ClusterStatusError ClusterStatus = "error"
 )
 
+// ClusterSource records how a cluster came under management.
+// ClusterSource 记录集群纳入管理的方式。
+type ClusterSource string
+
+const (
+	// ClusterSourceInstalled indicates the cluster was created and installed by SeaTunnelX.
+	// ClusterSourceInstalled 表示集群由 SeaTunnelX 创建并安装。
+	ClusterSourceInstalled ClusterSource = "installed"
+	// ClusterSourceAdopted indicates an externally installed cluster was adopted without reinstalling.
+	// ClusterSourceAdopted 表示外部安装的集群未经重装被纳管。
+	ClusterSourceAdopted ClusterSource = "adopted"
+)
+
 // NodeRole represents the role of a node in a cluster.
 type NodeRole string
 
@@ -78,22 +95,46 @@ const (
 	NodeStatusStopped NodeStatus = "stopped"
 	// NodeStatusError indicates the node is in an error state.
 	NodeStatusError NodeStatus = "error"
+	// NodeStatusDraining indicates the node is being decommissioned and waits for its jobs to finish.
+	// NodeStatusDraining 表示节点正在下线，等待其上的作业结束。
+	NodeStatusDraining NodeStatus = "draining"
 	// NodeStatusOffline indicates the host/agent is offline; display-only, not persisted.
 	NodeStatusOffline NodeStatus = "offline"
 )
 
+// ClusterStatusEvent is the payload published on eventbus.TopicClusterStatus.
+// ClusterStatusEvent 是发布到 eventbus.TopicClusterStatus 的事件数据。
+type ClusterStatusEvent struct {
+	ClusterID uint          `json:"cluster_id"`
+	Status    ClusterStatus `json:"status"`
+}
+
+// NodeStatusEvent is the payload published on eventbus.TopicNodeStatus.
+// NodeStatusEvent 是发布到 eventbus.TopicNodeStatus 的事件数据。
+type NodeStatusEvent struct {
+	NodeID uint       `json:"node_id"`
+	Status NodeStatus `json:"status"`
+}
+
 // ClusterConfig represents the JSON configuration for a cluster.
 type ClusterConfig map[string]interface{}
 
 // Value implements the driver.Valuer interface for database storage.
+// Credentials such as storage keys and keytab paths are sealed before they are written.
+// Value 实现 driver.Valuer 接口用于数据库存储，存储密钥、keytab 路径等凭证在写入前加密。
 func (c ClusterConfig) Value() (driver.Value, error) {
 	if c == nil {
 		return nil, nil
 	}
-	return json.Marshal(c)
+	sealed, err := secrets.SealMap(c)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(sealed)
 }
 
 // Scan implements the sql.Scanner interface for database retrieval.
+// Scan 实现 sql.Scanner 接口用于数据库读取，并解密已加密的凭证。
 func (c *ClusterConfig) Scan(value interface{}) error {
 	if value == nil {
 		*c = nil
@@ -103,7 +144,16 @@ func (c *ClusterConfig) Scan(value interface{}) error {
 	if !ok {
 		return errors.New("cluster: failed to scan ClusterConfig - expected []byte")
 	}
-	return json.Unmarshal(bytes, c)
+	var raw map[string]interface{}
+	if err := json.Unmarshal(bytes, &raw); err != nil {
+		return err
+	}
+	opened, err := secrets.OpenMap(raw)
+	if err != nil {
+		return err
+	}
+	*c = opened
+	return nil
 }
 
 // JVMConfig represents cluster-level effective JVM configuration.
@@ -112,6 +162,18 @@ type JVMConfig struct {
 	HybridHeapSize int `json:"hybrid_heap_size,omitempty"`
 	MasterHeapSize int `json:"master_heap_size,omitempty"`
 	WorkerHeapSize int `json:"worker_heap_size,omitempty"`
+	// GC, MaxDirectMemoryMB and ExtraOptions are shared by all roles.
+	// GC、MaxDirectMemoryMB 与 ExtraOptions 由所有角色共享。
+	GC                string   `json:"gc,omitempty"`
+	MaxDirectMemoryMB int      `json:"max_direct_memory_mb,omitempty"`
+	ExtraOptions      []string `json:"extra_options,omitempty"`
+}
+
+// IsEmpty returns whether no JVM value is configured.
+// IsEmpty 返回是否未配置任何 JVM 值。
+func (c JVMConfig) IsEmpty() bool {
+	return c.HybridHeapSize == 0 && c.MasterHeapSize == 0 && c.WorkerHeapSize == 0 &&
+		c.GC == "" && c.MaxDirectMemoryMB == 0 && len(c.ExtraOptions) == 0
 }
 
 // NodeJVMOverrides represents node-level JVM override values.
@@ -185,6 +247,37 @@ func (o *NodeOverrides) Scan(value interface{}) error {
 	}
 }
 
+// NodeSelectors maps a node role to the host label selector its hosts must match,
+// e.g. {"master": "role=control", "worker": "disk=ssd,zone in (az1,az2)"}.
+// NodeSelectors 将节点角色映射到其主机必须满足的标签选择器，
+// 例如 {"master": "role=control", "worker": "disk=ssd,zone in (az1,az2)"}。
+type NodeSelectors map[NodeRole]string
+
+// Value implements the driver.Valuer interface for database storage.
+// Value 实现 driver.Valuer 接口用于数据库存储。
+func (n NodeSelectors) Value() (driver.Value, error) {
+	if len(n) == 0 {
+		return nil, nil
+	}
+	return json.Marshal(n)
+}
+
+// Scan implements the sql.Scanner interface for database retrieval.
+// Scan 实现 sql.Scanner 接口用于数据库读取。
+func (n *NodeSelectors) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*n = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(v, n)
+	case string:
+		return json.Unmarshal([]byte(v), n)
+	default:
+		return errors.New("cluster: failed to scan NodeSelectors - expected []byte or string")
+	}
+}
+
 // Cluster represents a SeaTunnel cluster consisting of multiple nodes.
 type Cluster struct {
 	ID             uint           `json:"id" gorm:"primaryKey;autoIncrement"`
@@ -193,12 +286,19 @@ type Cluster struct {
 	DeploymentMode DeploymentMode `json:"deployment_mode" gorm:"size:20;not null"`
 	Version        string         `json:"version" gorm:"size:20"`
 	Status         ClusterStatus  `json:"status" gorm:"size:20;default:created;index"`
+	Source         ClusterSource  `json:"source" gorm:"size:20;default:installed"`
 	InstallDir     string         `json:"install_dir" gorm:"size:255"`
 	Config         ClusterConfig  `json:"config" gorm:"type:json"`
+	NodeSelectors  NodeSelectors  `json:"node_selectors,omitempty" gorm:"type:text"` // Host label selectors per role / 各角色的主机标签选择器
+	ProjectID      uint           `json:"project_id" gorm:"default:0;index"`         // Owning project, 0 for shared / 所属项目，0 表示共享
 	CreatedAt      time.Time      `json:"created_at" gorm:"autoCreateTime"`
 	UpdatedAt      time.Time      `json:"updated_at" gorm:"autoUpdateTime"`
 	CreatedBy      uint           `json:"created_by"`
 	Nodes          []ClusterNode  `json:"nodes" gorm:"foreignKey:ClusterID"`
+	// DeletedAt marks a cluster moved to the recycle bin / DeletedAt 标记已移入回收站的集群
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+	// PurgeInstallDir asks the purge job to remove install directories / PurgeInstallDir 要求清理任务删除安装目录
+	PurgeInstallDir bool `json:"-" gorm:"default:false"`
 }
 
 // TableName specifies the table name for the Cluster model.
@@ -235,8 +335,18 @@ type ClusterFilter struct {
 	Name           string         `json:"name"`
 	Status         ClusterStatus  `json:"status"`
 	DeploymentMode DeploymentMode `json:"deployment_mode"`
-	Page           int            `json:"page"`
-	PageSize       int            `json:"page_size"`
+	// HostID keeps only clusters with a node on the host / HostID 仅保留在该主机上有节点的集群
+	HostID uint `json:"host_id,omitempty"`
+	// CreatedAt restricts the creation time / CreatedAt 限制创建时间范围
+	CreatedAt pagex.TimeRange `json:"created_at,omitempty"`
+	// ProjectIDs limits results to shared clusters and these projects when RestrictProjects is set
+	// ProjectIDs 在 RestrictProjects 为 true 时将结果限制为共享集群及这些项目的集群
+	ProjectIDs       []uint `json:"-"`
+	RestrictProjects bool   `json:"-"`
+	Page             int    `json:"page"`
+	PageSize         int    `json:"page_size"`
+	// Sort orders the result, newest first when empty / Sort 指定排序，为空时按创建时间倒序
+	Sort []pagex.SortField `json:"sort,omitempty"`
 }
 
 // ClusterInfo represents cluster information for API responses.
@@ -247,8 +357,11 @@ type ClusterInfo struct {
 	DeploymentMode DeploymentMode `json:"deployment_mode"`
 	Version        string         `json:"version"`
 	Status         ClusterStatus  `json:"status"`
+	Source         ClusterSource  `json:"source"`
 	InstallDir     string         `json:"install_dir"`
 	Config         ClusterConfig  `json:"config"`
+	NodeSelectors  NodeSelectors  `json:"node_selectors,omitempty"`
+	ProjectID      uint           `json:"project_id"`
 	NodeCount      int            `json:"node_count"`
 	OnlineNodes    int            `json:"online_nodes"`  // number of nodes whose host is online / 主机在线的节点数
 	HealthStatus   string         `json:"health_status"` // healthy, unhealthy, unknown / 健康状态
@@ -257,6 +370,7 @@ type ClusterInfo struct {
 }
 
 // ToClusterInfo converts a Cluster to ClusterInfo (OnlineNodes and HealthStatus are set by caller).
+// Credentials in the cluster config are masked.
 func (c *Cluster) ToClusterInfo() *ClusterInfo {
 	return &ClusterInfo{
 		ID:             c.ID,
@@ -265,8 +379,11 @@ func (c *Cluster) ToClusterInfo() *ClusterInfo {
 		DeploymentMode: c.DeploymentMode,
 		Version:        c.Version,
 		Status:         c.Status,
+		Source:         c.Source,
 		InstallDir:     c.InstallDir,
-		Config:         c.Config,
+		Config:         secrets.RedactMap(c.Config),
+		NodeSelectors:  c.NodeSelectors,
+		ProjectID:      c.ProjectID,
 		NodeCount:      len(c.Nodes),
 		CreatedAt:      c.CreatedAt,
 		UpdatedAt:      c.UpdatedAt,
@@ -282,6 +399,12 @@ type CreateClusterRequest struct {
 	Version        string         `json:"version" binding:"required"`
 	InstallDir     string         `json:"install_dir"`
 	Config         ClusterConfig  `json:"config"`
+	// NodeSelectors constrains which hosts may run each role (optional)
+	// NodeSelectors 限制各角色可部署的主机（可选）
+	NodeSelectors NodeSelectors `json:"node_selectors,omitempty"`
+	// ProjectID assigns the cluster to a project, 0 keeps it shared (optional)
+	// ProjectID 将集群归属到项目，0 表示共享（可选）
+	ProjectID uint `json:"project_id,omitempty"`
 	// Nodes to auto-create from discovery (optional)
 	// 从发现自动创建的节点（可选）
 	Nodes []CreateNodeFromDiscovery `json:"nodes,omitempty"`
@@ -304,6 +427,9 @@ type UpdateClusterRequest struct {
 	Version     *string        `json:"version"`
 	InstallDir  *string        `json:"install_dir"`
 	Config      *ClusterConfig `json:"config"`
+	// NodeSelectors replaces the per-role host label selectors; existing nodes are not re-checked
+	// NodeSelectors 替换各角色的主机标签选择器，不会重新校验已有节点
+	NodeSelectors *NodeSelectors `json:"node_selectors"`
 }
 
 // AddNodeRequest represents a request to add a node to a cluster.
@@ -359,22 +485,23 @@ type UpdateNodeRequest struct {
 // NodeInfo represents node information for API responses.
 // 节点信息，用于 API 响应
 type NodeInfo struct {
-	ID            uint          `json:"id"`
-	ClusterID     uint          `json:"cluster_id"`
-	HostID        uint          `json:"host_id"`
-	HostName      string        `json:"host_name"`
-	HostIP        string        `json:"host_ip"`
-	Role          NodeRole      `json:"role"`
-	InstallDir    string        `json:"install_dir"`    // SeaTunnel installation directory / SeaTunnel 安装目录
-	HazelcastPort int           `json:"hazelcast_port"` // Hazelcast cluster port / Hazelcast 集群端口
-	APIPort       int           `json:"api_port"`       // REST API port (Master only) / REST API 端口（仅 Master）
-	WorkerPort    int           `json:"worker_port"`    // Worker hazelcast port (Hybrid only) / Worker Hazelcast 端口（仅混合模式）
-	Overrides     NodeOverrides `json:"overrides"`      // Node-level JSON overrides / 节点级 JSON 覆盖配置
-	Status        NodeStatus    `json:"status"`         // Unified status: pending, installing, running, stopped, error, offline / 统一状态
-	IsOnline      bool          `json:"is_online"`      // Whether host is online; when false, status may be shown as offline / 主机是否在线
-	ProcessPID    int           `json:"process_pid"`    // SeaTunnel process PID / SeaTunnel 进程 PID
-	CreatedAt     time.Time     `json:"created_at"`
-	UpdatedAt     time.Time     `json:"updated_at"`
+	ID              uint          `json:"id"`
+	ClusterID       uint          `json:"cluster_id"`
+	HostID          uint          `json:"host_id"`
+	HostName        string        `json:"host_name"`
+	HostIP          string        `json:"host_ip"`
+	Role            NodeRole      `json:"role"`
+	InstallDir      string        `json:"install_dir"`      // SeaTunnel installation directory / SeaTunnel 安装目录
+	HazelcastPort   int           `json:"hazelcast_port"`   // Hazelcast cluster port / Hazelcast 集群端口
+	APIPort         int           `json:"api_port"`         // REST API port (Master only) / REST API 端口（仅 Master）
+	WorkerPort      int           `json:"worker_port"`      // Worker hazelcast port (Hybrid only) / Worker Hazelcast 端口（仅混合模式）
+	Overrides       NodeOverrides `json:"overrides"`        // Node-level JSON overrides / 节点级 JSON 覆盖配置
+	Status          NodeStatus    `json:"status"`           // Unified status: pending, installing, running, stopped, error, offline / 统一状态
+	IsOnline        bool          `json:"is_online"`        // Whether host is online; when false, status may be shown as offline / 主机是否在线
+	HostMaintenance bool          `json:"host_maintenance"` // Whether host is in maintenance mode / 主机是否处于维护模式
+	ProcessPID      int           `json:"process_pid"`      // SeaTunnel process PID / SeaTunnel 进程 PID
+	CreatedAt       time.Time     `json:"created_at"`
+	UpdatedAt       time.Time     `json:"updated_at"`
 }
 
 // DefaultPorts defines default port values for different node roles
@@ -432,7 +559,7 @@ type PrecheckResult struct {
 // PrecheckCheckItem 表示预检查中的单个检查项。
 type PrecheckCheckItem struct {
 	Name    string `json:"name"`    // Check name / 检查名称
-	Status  string `json:"status"`  // passed, failed, skipped / 通过、失败、跳过
+	Status  string `json:"status"`  // passed, warning, failed, skipped / 通过、警告、失败、跳过
 	Message string `json:"message"` // Detail message / 详细信息
 }
 
@@ -442,4 +569,5 @@ const (
 	PrecheckStatusPassed  = "passed"
 	PrecheckStatusFailed  = "failed"
 	PrecheckStatusSkipped = "skipped"
+	PrecheckStatusWarning = "warning"
 )
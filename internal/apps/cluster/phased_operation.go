@@ -0,0 +1,228 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cluster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/seatunnel/seatunnelX/internal/apps/task"
+	"github.com/seatunnel/seatunnelX/internal/logger"
+)
+
+const (
+	// defaultOperationPhaseTimeout bounds each phase of a separated-mode start/stop/restart.
+	// defaultOperationPhaseTimeout 限定分离模式启动/停止/重启中每个阶段的时长。
+	defaultOperationPhaseTimeout = 5 * time.Minute
+
+	phaseSkippedAfterFailureText = "skipped: masters did not reach hazelcast quorum / 已跳过：master 节点未达到 hazelcast 法定数量"
+	phaseTimedOutText            = "phase timed out before the node was operated / 阶段超时，节点未执行操作"
+)
+
+// OperationPhase names one ordered phase of a separated-mode cluster operation.
+// OperationPhase 表示分离模式集群操作中的一个有序阶段。
+type OperationPhase string
+
+const (
+	// OperationPhaseMasters operates the master nodes.
+	// OperationPhaseMasters 操作 master 节点。
+	OperationPhaseMasters OperationPhase = "masters"
+	// OperationPhaseMasterQuorum waits until a majority of masters are ready hazelcast members.
+	// OperationPhaseMasterQuorum 等待多数 master 成为就绪的 hazelcast 成员。
+	OperationPhaseMasterQuorum OperationPhase = "master_quorum"
+	// OperationPhaseWorkers operates the worker nodes.
+	// OperationPhaseWorkers 操作 worker 节点。
+	OperationPhaseWorkers OperationPhase = "workers"
+)
+
+// OperationPhaseResult reports the outcome of one phase.
+// OperationPhaseResult 报告单个阶段的结果。
+type OperationPhaseResult struct {
+	Phase   OperationPhase `json:"phase"`
+	Success bool           `json:"success"`
+	Skipped bool           `json:"skipped,omitempty"`
+	Message string         `json:"message"`
+	NodeIDs []uint         `json:"node_ids"`
+}
+
+// executePhasedOperation applies an operation to a separated cluster in role dependency order.
+// Start and restart operate masters first, wait for a hazelcast quorum of masters, then operate
+// workers; stop operates workers first, then masters. Every phase has its own timeout.
+// executePhasedOperation 按角色依赖顺序对分离模式集群执行操作。
+// 启动和重启先操作 master，等待 master 达到 hazelcast 法定数量后再操作 worker；
+// 停止则先操作 worker，再操作 master。每个阶段都有独立的超时时间。
+func (s *Service) executePhasedOperation(ctx context.Context, cluster *Cluster, operation OperationType, result *OperationResult) {
+	var masters, workers []*ClusterNode
+	for i := range cluster.Nodes {
+		if isMasterCapable(cluster.Nodes[i].Role) {
+			masters = append(masters, &cluster.Nodes[i])
+		} else {
+			workers = append(workers, &cluster.Nodes[i])
+		}
+	}
+
+	if operation == OperationStop {
+		// A failed worker does not keep the masters running; the failure is reported instead.
+		// worker 失败不会阻止停止 master，失败会在结果中报告。
+		s.runNodePhase(ctx, cluster, OperationPhaseWorkers, workers, operation, result)
+		s.runNodePhase(ctx, cluster, OperationPhaseMasters, masters, operation, result)
+		return
+	}
+
+	operated := s.runNodePhase(ctx, cluster, OperationPhaseMasters, masters, operation, result)
+	if len(masters) > 0 && !s.runQuorumPhase(ctx, masters, operated, result) {
+		phase := &OperationPhaseResult{
+			Phase:   OperationPhaseWorkers,
+			Skipped: true,
+			Message: phaseSkippedAfterFailureText,
+			NodeIDs: nodeIDs(workers),
+		}
+		for _, node := range workers {
+			result.NodeResults = append(result.NodeResults, &NodeOperationResult{
+				NodeID:  node.ID,
+				HostID:  node.HostID,
+				Message: phaseSkippedAfterFailureText,
+			})
+		}
+		result.Phases = append(result.Phases, phase)
+		return
+	}
+	s.runNodePhase(ctx, cluster, OperationPhaseWorkers, workers, operation, result)
+}
+
+// runNodePhase operates the nodes of one phase and returns the nodes that succeeded.
+// runNodePhase 操作单个阶段的节点，并返回操作成功的节点。
+func (s *Service) runNodePhase(ctx context.Context, cluster *Cluster, phase OperationPhase, nodes []*ClusterNode, operation OperationType, result *OperationResult) []*ClusterNode {
+	phaseResult := &OperationPhaseResult{Phase: phase, Success: true, NodeIDs: nodeIDs(nodes)}
+	result.Phases = append(result.Phases, phaseResult)
+	if len(nodes) == 0 {
+		phaseResult.Message = "no nodes / 无节点"
+		return nil
+	}
+
+	reporter := task.ReporterFromContext(ctx)
+	step := string(phase)
+	reporter.StartStep(step, fmt.Sprintf("%s %d %s node(s) / %s %d 个 %s 节点", operation, len(nodes), phase, operation, len(nodes), phase))
+
+	phaseCtx, cancel := context.WithTimeout(ctx, s.phaseTimeout)
+	defer cancel()
+
+	var operated []*ClusterNode
+	failed := 0
+	for _, node := range nodes {
+		var nodeResult *NodeOperationResult
+		if phaseCtx.Err() != nil {
+			nodeResult = &NodeOperationResult{NodeID: node.ID, HostID: node.HostID, Message: phaseTimedOutText}
+		} else {
+			nodeResult = s.operateNode(phaseCtx, cluster, node, operation)
+		}
+		if !nodeResult.Success && phaseCtx.Err() != nil {
+			// The phase context is gone; record the error status with the parent context.
+			// 阶段上下文已失效，使用父上下文记录错误状态。
+			_ = s.repo.UpdateNodeStatus(ctx, node.ID, NodeStatusError)
+		}
+		result.NodeResults = append(result.NodeResults, nodeResult)
+		if nodeResult.Success {
+			operated = append(operated, node)
+			continue
+		}
+		failed++
+		result.Success = false
+		logger.WarnF(ctx, "[Cluster] %s %s phase node failed: cluster=%d, node=%d, message=%s", operation, phase, cluster.ID, node.ID, nodeResult.Message)
+	}
+
+	if failed > 0 {
+		phaseResult.Success = false
+		phaseResult.Message = fmt.Sprintf("%d/%d node(s) failed / %d/%d 个节点失败", failed, len(nodes), failed, len(nodes))
+		reporter.FailStep(step, errors.New(phaseResult.Message))
+	} else {
+		phaseResult.Message = fmt.Sprintf("%d node(s) succeeded / %d 个节点成功", len(nodes), len(nodes))
+		reporter.CompleteStep(step, "")
+	}
+	return operated
+}
+
+// runQuorumPhase waits until a majority of the masters are ready hazelcast members.
+// runQuorumPhase 等待多数 master 成为就绪的 hazelcast 成员。
+func (s *Service) runQuorumPhase(ctx context.Context, masters, operated []*ClusterNode, result *OperationResult) bool {
+	phaseResult := &OperationPhaseResult{Phase: OperationPhaseMasterQuorum, NodeIDs: nodeIDs(operated)}
+	result.Phases = append(result.Phases, phaseResult)
+
+	reporter := task.ReporterFromContext(ctx)
+	step := string(OperationPhaseMasterQuorum)
+	quorum := len(masters)/2 + 1
+	reporter.StartStep(step, fmt.Sprintf("Wait for %d ready master(s) / 等待 %d 个 master 就绪", quorum, quorum))
+
+	if err := s.waitMasterQuorum(ctx, operated, quorum); err != nil {
+		result.Success = false
+		phaseResult.Message = err.Error()
+		reporter.FailStep(step, err)
+		return false
+	}
+	phaseResult.Success = true
+	phaseResult.Message = fmt.Sprintf("%d/%d master(s) ready / %d/%d 个 master 就绪", quorum, len(masters), quorum, len(masters))
+	reporter.CompleteStep(step, "")
+	return true
+}
+
+// waitMasterQuorum polls the operated masters through their Agents until quorum of them are ready.
+// waitMasterQuorum 通过 Agent 轮询已操作的 master，直到就绪数量达到法定数量。
+func (s *Service) waitMasterQuorum(ctx context.Context, masters []*ClusterNode, quorum int) error {
+	if len(masters) < quorum {
+		return fmt.Errorf("only %d master(s) started, quorum is %d / 仅启动了 %d 个 master，法定数量为 %d",
+			len(masters), quorum, len(masters), quorum)
+	}
+	if s.hostProvider == nil || s.agentSender == nil {
+		return nil
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, s.phaseTimeout)
+	defer cancel()
+	for {
+		ready := 0
+		var failures []string
+		for _, node := range masters {
+			httpOK, memberOK, probeErr := s.probeNode(waitCtx, node)
+			if probeErr == healthCheckNoProbeTargetMessage || (httpOK && memberOK && probeErr == "") {
+				ready++
+				continue
+			}
+			failures = append(failures, fmt.Sprintf("node %d: %s", node.ID, probeErr))
+		}
+		if ready >= quorum {
+			return nil
+		}
+		select {
+		case <-waitCtx.Done():
+			return fmt.Errorf("%d/%d master(s) ready within %s, quorum is %d: %s / %s 内仅 %d/%d 个 master 就绪，法定数量为 %d",
+				ready, len(masters), s.phaseTimeout, quorum, strings.Join(failures, "; "), s.phaseTimeout, ready, len(masters), quorum)
+		case <-time.After(s.rejoinPollInterval):
+		}
+	}
+}
+
+func nodeIDs(nodes []*ClusterNode) []uint {
+	ids := make([]uint, 0, len(nodes))
+	for _, node := range nodes {
+		ids = append(ids, node.ID)
+	}
+	return ids
+}
@@ -0,0 +1,137 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cluster
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newSeparatedTestCluster(t *testing.T, svc *Service) *Cluster {
+	t.Helper()
+	ctx := context.Background()
+	cluster, err := svc.Create(ctx, &CreateClusterRequest{Name: "separated-cluster", DeploymentMode: DeploymentModeSeparated, Version: "2.3.12"})
+	if err != nil {
+		t.Fatalf("Create returned error: %v", err)
+	}
+	// The worker is added first so that ordering cannot come from insertion order.
+	// 先添加 worker，确保顺序不是来自插入顺序。
+	for _, node := range []struct {
+		hostID uint
+		role   NodeRole
+	}{{3, NodeRoleWorker}, {1, NodeRoleMaster}, {2, NodeRoleMaster}} {
+		if _, err := svc.AddNode(ctx, cluster.ID, &AddNodeRequest{HostID: node.hostID, Role: node.role, SkipPrecheck: true}); err != nil {
+			t.Fatalf("AddNode(%d) returned error: %v", node.hostID, err)
+		}
+	}
+	return cluster
+}
+
+func TestService_SeparatedStartAndStopFollowRoleOrder(t *testing.T) {
+	svc, _, _ := newScaleTestService(t)
+	svc.rejoinPollInterval = 10 * time.Millisecond
+	ctx := context.Background()
+
+	var operations []string
+	svc.SetAgentCommandSender(&scriptedAgentSender{send: func(ctx context.Context, agentID string, commandType string, params map[string]string) (bool, string, error) {
+		switch commandType {
+		case string(OperationStart), string(OperationStop):
+			operations = append(operations, commandType+":"+agentID)
+		case "check_process":
+			return true, "SeaTunnel process found: PID=4321, role=master", nil
+		}
+		return true, "ok", nil
+	}})
+	cluster := newSeparatedTestCluster(t, svc)
+
+	result, err := svc.Start(ctx, cluster.ID)
+	if err != nil {
+		t.Fatalf("Start returned error: %v", err)
+	}
+	if !result.Success || len(result.NodeResults) != 3 {
+		t.Fatalf("expected successful start of 3 nodes, got %+v", result)
+	}
+	var phases []string
+	for _, phase := range result.Phases {
+		phases = append(phases, string(phase.Phase))
+	}
+	if strings.Join(phases, ",") != "masters,master_quorum,workers" {
+		t.Fatalf("unexpected start phases: %v", phases)
+	}
+
+	result, err = svc.Stop(ctx, cluster.ID)
+	if err != nil {
+		t.Fatalf("Stop returned error: %v", err)
+	}
+	if !result.Success || len(result.Phases) != 2 || result.Phases[0].Phase != OperationPhaseWorkers {
+		t.Fatalf("expected workers to be stopped first, got %+v", result.Phases)
+	}
+
+	want := "start:agent-1,start:agent-2,start:agent-3,stop:agent-3,stop:agent-1,stop:agent-2"
+	if got := strings.Join(operations, ","); got != want {
+		t.Fatalf("unexpected operation order:\n got %s\nwant %s", got, want)
+	}
+}
+
+func TestService_SeparatedStartSkipsWorkersWithoutMasterQuorum(t *testing.T) {
+	svc, repo, _ := newScaleTestService(t)
+	svc.rejoinPollInterval = 10 * time.Millisecond
+	svc.phaseTimeout = 200 * time.Millisecond
+	ctx := context.Background()
+
+	var started []string
+	svc.SetAgentCommandSender(&scriptedAgentSender{send: func(ctx context.Context, agentID string, commandType string, params map[string]string) (bool, string, error) {
+		switch commandType {
+		case string(OperationStart):
+			started = append(started, agentID)
+		case "check_http":
+			// Only one of the two masters ever becomes ready, below the quorum of 2.
+			// 两个 master 中只有一个就绪，低于法定数量 2。
+			return agentID == "agent-1", "", nil
+		}
+		return true, "ok", nil
+	}})
+	cluster := newSeparatedTestCluster(t, svc)
+
+	result, err := svc.Start(ctx, cluster.ID)
+	if err != nil {
+		t.Fatalf("Start returned error: %v", err)
+	}
+	if result.Success || len(result.Phases) != 3 {
+		t.Fatalf("expected failed start with 3 phases, got %+v", result)
+	}
+	if quorum := result.Phases[1]; quorum.Success || !strings.Contains(quorum.Message, "quorum is 2") {
+		t.Fatalf("expected quorum phase failure, got %+v", quorum)
+	}
+	if workers := result.Phases[2]; !workers.Skipped {
+		t.Fatalf("expected worker phase to be skipped, got %+v", workers)
+	}
+	if strings.Join(started, ",") != "agent-1,agent-2" {
+		t.Fatalf("expected only masters to be started, got %v", started)
+	}
+
+	updated, err := repo.GetByID(ctx, cluster.ID, false)
+	if err != nil {
+		t.Fatalf("GetByID returned error: %v", err)
+	}
+	if updated.Status != ClusterStatusError {
+		t.Fatalf("expected cluster status error, got %s", updated.Status)
+	}
+}
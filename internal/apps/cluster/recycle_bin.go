@@ -0,0 +1,258 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cluster
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/seatunnel/seatunnelX/internal/logger"
+	"github.com/seatunnel/seatunnelX/internal/pkg/leader"
+	"github.com/seatunnel/seatunnelX/internal/pkg/worker"
+	"gorm.io/gorm"
+)
+
+// DefaultRecycleRetention is how long a deleted cluster stays restorable before it is purged.
+// DefaultRecycleRetention 是已删除集群在被清理前可恢复的默认保留时长。
+const DefaultRecycleRetention = 7 * 24 * time.Hour
+
+// DefaultRecyclePurgeInterval is the default interval of the recycle bin purge job.
+// DefaultRecyclePurgeInterval 是回收站清理任务的默认执行间隔。
+const DefaultRecyclePurgeInterval = 30 * time.Minute
+
+// RecycledCluster describes a cluster in the recycle bin.
+// RecycledCluster 描述回收站中的集群。
+type RecycledCluster struct {
+	*ClusterInfo
+	DeletedAt        time.Time `json:"deleted_at"`
+	PurgeAt          time.Time `json:"purge_at"`
+	RemoveInstallDir bool      `json:"remove_install_dir"`
+}
+
+// ==================== Repository 仓库 ====================
+
+// Recycle moves a cluster to the recycle bin, remembering whether the purge removes install directories.
+// Recycle 将集群移入回收站，并记录清理时是否删除安装目录。
+func (r *Repository) Recycle(ctx context.Context, id uint, removeInstallDir bool) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&Cluster{}).Where("id = ?", id).Update("purge_install_dir", removeInstallDir).Error; err != nil {
+			return err
+		}
+		result := tx.Delete(&Cluster{}, id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrClusterNotFound
+		}
+		return nil
+	})
+}
+
+// GetRecycled retrieves a cluster of the recycle bin with its nodes.
+// Returns ErrClusterNotRecycled if the cluster is not in the recycle bin.
+// GetRecycled 获取回收站中的集群及其节点。
+func (r *Repository) GetRecycled(ctx context.Context, id uint) (*Cluster, error) {
+	var cluster Cluster
+	if err := r.db.WithContext(ctx).Unscoped().Preload("Nodes").
+		Where("deleted_at IS NOT NULL").First(&cluster, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrClusterNotRecycled
+		}
+		return nil, err
+	}
+	return &cluster, nil
+}
+
+// ListRecycled lists the clusters of the recycle bin, newest deletion first.
+// Only the name and project filters are applied.
+// deletedBefore, when non-zero, keeps only clusters deleted before that time.
+// ListRecycled 列出回收站中的集群，最近删除的在前；仅应用名称与项目过滤条件。
+func (r *Repository) ListRecycled(ctx context.Context, filter *ClusterFilter, deletedBefore time.Time) ([]*Cluster, error) {
+	query := r.db.WithContext(ctx).Unscoped().Model(&Cluster{}).Where("deleted_at IS NOT NULL")
+	if !deletedBefore.IsZero() {
+		query = query.Where("deleted_at < ?", deletedBefore)
+	}
+	if filter != nil {
+		if filter.Name != "" {
+			query = query.Where("name LIKE ?", "%"+filter.Name+"%")
+		}
+		if filter.RestrictProjects {
+			query = applyProjectScope(query, filter.ProjectIDs)
+		}
+	}
+
+	var clusters []*Cluster
+	if err := query.Preload("Nodes").Order("deleted_at DESC").Find(&clusters).Error; err != nil {
+		return nil, err
+	}
+	return clusters, nil
+}
+
+// Restore moves a cluster out of the recycle bin.
+// Returns ErrClusterNotRecycled if the cluster is not in the recycle bin.
+// Restore 将集群移出回收站。
+func (r *Repository) Restore(ctx context.Context, id uint) error {
+	result := r.db.WithContext(ctx).Unscoped().Model(&Cluster{}).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Updates(map[string]interface{}{"deleted_at": nil, "purge_install_dir": false})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrClusterNotRecycled
+	}
+	return nil
+}
+
+// ==================== Service 服务 ====================
+
+// SetOnClusterPurged sets the hook called after the purge job tears down a recycled cluster
+// (typically used to write an audit event).
+// SetOnClusterPurged 设置清理任务拆除回收站集群后的回调（通常用于写入审计事件）。
+func (s *Service) SetOnClusterPurged(fn func(context.Context, *Cluster, *TeardownReport, error)) {
+	s.onClusterPurged = fn
+}
+
+// RecycleRetention returns how long a deleted cluster stays in the recycle bin.
+// RecycleRetention 返回已删除集群在回收站中的保留时长。
+func (s *Service) RecycleRetention() time.Duration {
+	return s.recycleRetention
+}
+
+// Recycle moves a cluster to the recycle bin instead of tearing it down immediately.
+// The nodes are torn down by the purge job once the retention window has elapsed.
+// Recycle 将集群移入回收站而不是立即拆除；保留期结束后由清理任务拆除节点。
+func (s *Service) Recycle(ctx context.Context, id uint, removeInstallDir bool) (*RecycledCluster, error) {
+	cluster, err := s.repo.GetByID(ctx, id, false)
+	if err != nil {
+		return nil, err
+	}
+
+	// Same guard as a direct deletion / 与直接删除相同的校验
+	if cluster.Status == ClusterStatusDeploying || cluster.Status == ClusterStatusRunning {
+		return nil, ErrClusterHasRunningTask
+	}
+
+	if err := s.repo.Recycle(ctx, id, removeInstallDir); err != nil {
+		return nil, err
+	}
+	s.notifyClusterTopologyChanged(ctx, id)
+
+	recycled, err := s.repo.GetRecycled(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.toRecycledCluster(recycled), nil
+}
+
+// ListRecycled lists the clusters of the recycle bin.
+// ListRecycled 列出回收站中的集群。
+func (s *Service) ListRecycled(ctx context.Context, filter *ClusterFilter) ([]*RecycledCluster, error) {
+	clusters, err := s.repo.ListRecycled(ctx, filter, time.Time{})
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*RecycledCluster, 0, len(clusters))
+	for _, cluster := range clusters {
+		result = append(result, s.toRecycledCluster(cluster))
+	}
+	return result, nil
+}
+
+// Restore moves a cluster out of the recycle bin.
+// Restore 将集群从回收站恢复。
+func (s *Service) Restore(ctx context.Context, id uint) (*Cluster, error) {
+	if err := s.repo.Restore(ctx, id); err != nil {
+		return nil, err
+	}
+	s.notifyClusterTopologyChanged(ctx, id)
+	return s.repo.GetByID(ctx, id, true)
+}
+
+// Purge tears down a recycled cluster and removes it permanently.
+// Purge 拆除回收站中的集群并将其永久删除。
+func (s *Service) Purge(ctx context.Context, id uint) (*TeardownReport, error) {
+	cluster, err := s.repo.GetRecycled(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.destroyCluster(ctx, cluster, cluster.PurgeInstallDir)
+}
+
+// PurgeExpired purges the clusters whose retention window has elapsed and returns how many were purged.
+// PurgeExpired 清理保留期已过的集群，返回清理的数量。
+func (s *Service) PurgeExpired(ctx context.Context, now time.Time) int {
+	expired, err := s.repo.ListRecycled(ctx, nil, now.Add(-s.recycleRetention))
+	if err != nil {
+		logger.WarnF(ctx, "[Cluster] list expired recycled clusters failed: %v", err)
+		return 0
+	}
+
+	purged := 0
+	for _, cluster := range expired {
+		report, err := s.destroyCluster(ctx, cluster, cluster.PurgeInstallDir)
+		if err != nil {
+			logger.WarnF(ctx, "[Cluster] purge recycled cluster failed: cluster=%d, err=%v", cluster.ID, err)
+		} else {
+			purged++
+		}
+		if s.onClusterPurged != nil {
+			s.onClusterPurged(ctx, cluster, report, err)
+		}
+	}
+	return purged
+}
+
+// StartRecyclePurger starts the background job purging expired clusters of the recycle bin.
+// StartRecyclePurger 启动清理回收站过期集群的后台任务。
+func (s *Service) StartRecyclePurger(ctx context.Context) {
+	if s == nil || s.repo == nil {
+		return
+	}
+
+	s.recyclePurgeRuntime.Do(func() {
+		go func() {
+			defer worker.Start("cluster-recycle-purger")()
+
+			ticker := time.NewTicker(s.recyclePurgeInterval)
+			defer ticker.Stop()
+
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					if leader.IsLeader() {
+						s.PurgeExpired(ctx, time.Now())
+					}
+				}
+			}
+		}()
+	})
+}
+
+func (s *Service) toRecycledCluster(cluster *Cluster) *RecycledCluster {
+	return &RecycledCluster{
+		ClusterInfo:      cluster.ToClusterInfo(),
+		DeletedAt:        cluster.DeletedAt.Time,
+		PurgeAt:          cluster.DeletedAt.Time.Add(s.recycleRetention),
+		RemoveInstallDir: cluster.PurgeInstallDir,
+	}
+}
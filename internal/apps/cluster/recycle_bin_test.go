@@ -0,0 +1,85 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cluster
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestClusterRecycleBin_restoreAndPurge(t *testing.T) {
+	db, cleanup := setupServiceTestDB(t)
+	defer cleanup()
+
+	repo := NewRepository(db)
+	svc := NewService(repo, NewMockHostProvider(), &ServiceConfig{RecycleRetention: time.Hour})
+	ctx := context.Background()
+
+	created, err := svc.Create(ctx, &CreateClusterRequest{Name: "recycled", DeploymentMode: DeploymentModeHybrid})
+	if err != nil {
+		t.Fatalf("Create returned error: %v", err)
+	}
+
+	recycled, err := svc.Recycle(ctx, created.ID, true)
+	if err != nil {
+		t.Fatalf("Recycle returned error: %v", err)
+	}
+	if !recycled.PurgeAt.Equal(recycled.DeletedAt.Add(time.Hour)) || !recycled.RemoveInstallDir {
+		t.Fatalf("unexpected recycle bin entry %+v", recycled)
+	}
+	if _, err := svc.Get(ctx, created.ID); !errors.Is(err, ErrClusterNotFound) {
+		t.Fatalf("expected recycled cluster to be hidden, got %v", err)
+	}
+	if _, err := svc.Create(ctx, &CreateClusterRequest{Name: "recycled", DeploymentMode: DeploymentModeHybrid}); !errors.Is(err, ErrClusterNameDuplicate) {
+		t.Fatalf("expected name of recycled cluster to stay reserved, got %v", err)
+	}
+
+	restored, err := svc.Restore(ctx, created.ID)
+	if err != nil || restored.ID != created.ID {
+		t.Fatalf("Restore returned %+v, %v", restored, err)
+	}
+	if _, err := svc.Restore(ctx, created.ID); !errors.Is(err, ErrClusterNotRecycled) {
+		t.Fatalf("expected ErrClusterNotRecycled for an active cluster, got %v", err)
+	}
+
+	if _, err := svc.Recycle(ctx, created.ID, false); err != nil {
+		t.Fatalf("Recycle returned error: %v", err)
+	}
+	if purged := svc.PurgeExpired(ctx, time.Now()); purged != 0 {
+		t.Fatalf("expected nothing purged inside the retention window, got %d", purged)
+	}
+
+	var purgedClusters []string
+	svc.SetOnClusterPurged(func(_ context.Context, cluster *Cluster, report *TeardownReport, err error) {
+		if err != nil || report == nil {
+			t.Errorf("unexpected purge result %+v, %v", report, err)
+		}
+		purgedClusters = append(purgedClusters, cluster.Name)
+	})
+	if purged := svc.PurgeExpired(ctx, time.Now().Add(2*time.Hour)); purged != 1 || len(purgedClusters) != 1 {
+		t.Fatalf("expected the expired cluster to be purged, got %d %v", purged, purgedClusters)
+	}
+	if _, err := repo.GetRecycled(ctx, created.ID); !errors.Is(err, ErrClusterNotRecycled) {
+		t.Fatalf("expected purged cluster to be gone, got %v", err)
+	}
+	if exists, _ := repo.ExistsByName(ctx, "recycled"); exists {
+		t.Fatal("expected the name to be released after purge")
+	}
+}
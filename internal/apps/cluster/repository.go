@@ -22,6 +22,8 @@ import (
 	"errors"
 	"time"
 
+	"github.com/seatunnel/seatunnelX/internal/pkg/eventbus"
+	"github.com/seatunnel/seatunnelX/internal/pkg/pagex"
 	"gorm.io/gorm"
 )
 
@@ -52,9 +54,9 @@ func (r *Repository) Create(ctx context.Context, cluster *Cluster) error {
 		return ErrClusterNameEmpty
 	}
 
-	// Check for duplicate name
+	// Check for duplicate name, names of recycled clusters stay reserved until purge
 	var count int64
-	if err := r.db.WithContext(ctx).Model(&Cluster{}).Where("name = ?", cluster.Name).Count(&count).Error; err != nil {
+	if err := r.db.WithContext(ctx).Unscoped().Model(&Cluster{}).Where("name = ?", cluster.Name).Count(&count).Error; err != nil {
 		return err
 	}
 	if count > 0 {
@@ -110,6 +112,15 @@ func (r *Repository) List(ctx context.Context, filter *ClusterFilter) ([]*Cluste
 		if filter.DeploymentMode != "" {
 			query = query.Where("deployment_mode = ?", filter.DeploymentMode)
 		}
+		// Restrict to shared clusters and the caller's projects / 限制为共享集群及调用者所属项目的集群
+		if filter.RestrictProjects {
+			query = applyProjectScope(query, filter.ProjectIDs)
+		}
+		// Filter by node host / 按节点所在主机过滤
+		if filter.HostID > 0 {
+			query = query.Where("id IN (?)", r.db.Model(&ClusterNode{}).Select("cluster_id").Where("host_id = ?", filter.HostID))
+		}
+		query = filter.CreatedAt.Apply(query, "created_at")
 	}
 
 	// Get total count
@@ -118,18 +129,17 @@ func (r *Repository) List(ctx context.Context, filter *ClusterFilter) ([]*Cluste
 		return nil, 0, err
 	}
 
-	// Apply pagination
-	if filter != nil && filter.PageSize > 0 {
-		offset := 0
-		if filter.Page > 0 {
-			offset = (filter.Page - 1) * filter.PageSize
-		}
-		query = query.Offset(offset).Limit(filter.PageSize)
+	// Apply sorting and pagination / 应用排序和分页
+	var sort []pagex.SortField
+	if filter != nil {
+		sort = filter.Sort
+		query = pagex.Paginate(query, filter.Page, filter.PageSize)
 	}
+	query = pagex.Order(query, sort, "created_at DESC")
 
 	// Execute query with nodes preloaded
 	var clusters []*Cluster
-	if err := query.Preload("Nodes").Order("created_at DESC").Find(&clusters).Error; err != nil {
+	if err := query.Preload("Nodes").Find(&clusters).Error; err != nil {
 		return nil, 0, err
 	}
 
@@ -152,7 +162,7 @@ func (r *Repository) Update(ctx context.Context, cluster *Cluster) error {
 	// Check for duplicate name if name is being changed
 	if cluster.Name != "" && cluster.Name != existing.Name {
 		var count int64
-		if err := r.db.WithContext(ctx).Model(&Cluster{}).Where("name = ? AND id != ?", cluster.Name, cluster.ID).Count(&count).Error; err != nil {
+		if err := r.db.WithContext(ctx).Unscoped().Model(&Cluster{}).Where("name = ? AND id != ?", cluster.Name, cluster.ID).Count(&count).Error; err != nil {
 			return err
 		}
 		if count > 0 {
@@ -163,7 +173,7 @@ func (r *Repository) Update(ctx context.Context, cluster *Cluster) error {
 	return r.db.WithContext(ctx).Save(cluster).Error
 }
 
-// Delete removes a cluster record from the database.
+// Delete permanently removes a cluster record from the database, including a recycled one.
 // Returns ErrClusterNotFound if the cluster does not exist.
 // Note: This also deletes all associated cluster nodes due to foreign key cascade.
 func (r *Repository) Delete(ctx context.Context, id uint) error {
@@ -174,7 +184,7 @@ func (r *Repository) Delete(ctx context.Context, id uint) error {
 		}
 
 		// Delete the cluster
-		result := tx.Delete(&Cluster{}, id)
+		result := tx.Unscoped().Delete(&Cluster{}, id)
 		if result.Error != nil {
 			return result.Error
 		}
@@ -194,13 +204,14 @@ func (r *Repository) UpdateStatus(ctx context.Context, id uint, status ClusterSt
 	if result.RowsAffected == 0 {
 		return ErrClusterNotFound
 	}
+	eventbus.Publish(eventbus.TopicClusterStatus, ClusterStatusEvent{ClusterID: id, Status: status})
 	return nil
 }
 
-// ExistsByName checks if a cluster with the given name exists.
+// ExistsByName checks if a cluster with the given name exists, including clusters in the recycle bin.
 func (r *Repository) ExistsByName(ctx context.Context, name string) (bool, error) {
 	var count int64
-	if err := r.db.WithContext(ctx).Model(&Cluster{}).Where("name = ?", name).Count(&count).Error; err != nil {
+	if err := r.db.WithContext(ctx).Unscoped().Model(&Cluster{}).Where("name = ?", name).Count(&count).Error; err != nil {
 		return false, err
 	}
 	return count > 0, nil
@@ -351,6 +362,7 @@ func (r *Repository) UpdateNodeStatus(ctx context.Context, nodeID uint, status N
 	if result.RowsAffected == 0 {
 		return ErrNodeNotFound
 	}
+	eventbus.Publish(eventbus.TopicNodeStatus, NodeStatusEvent{NodeID: nodeID, Status: status})
 	return nil
 }
 
@@ -383,9 +395,10 @@ func (r *Repository) CountNodesByClusterID(ctx context.Context, clusterID uint)
 
 // GetClustersWithHostID retrieves all clusters that have a specific host as a node.
 // This is useful for checking cluster associations before deleting a host.
+// Clusters in the recycle bin are included since their purge still needs the host.
 func (r *Repository) GetClustersWithHostID(ctx context.Context, hostID uint) ([]*Cluster, error) {
 	var clusters []*Cluster
-	if err := r.db.WithContext(ctx).
+	if err := r.db.WithContext(ctx).Unscoped().
 		Joins("JOIN cluster_nodes ON cluster_nodes.cluster_id = clusters.id").
 		Where("cluster_nodes.host_id = ?", hostID).
 		Find(&clusters).Error; err != nil {
@@ -454,3 +467,12 @@ func (r *Repository) GetNodesByClusterIDWithHost(ctx context.Context, clusterID
 	}
 	return nodes, nil
 }
+
+// applyProjectScope keeps shared rows (project_id = 0) and rows owned by the given projects.
+// applyProjectScope 仅保留共享记录（project_id = 0）及指定项目拥有的记录。
+func applyProjectScope(query *gorm.DB, projectIDs []uint) *gorm.DB {
+	if len(projectIDs) == 0 {
+		return query.Where("project_id = ?", 0)
+	}
+	return query.Where("project_id = ? OR project_id IN ?", 0, projectIDs)
+}
@@ -0,0 +1,256 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cluster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/seatunnel/seatunnelX/internal/apps/task"
+	"github.com/seatunnel/seatunnelX/internal/logger"
+)
+
+const (
+	defaultRollingMaxUnavailable   = 1
+	defaultRollingRejoinTimeoutS   = 300
+	defaultRollingRejoinPoll       = 5 * time.Second
+	rollingSkippedAfterFailureText = "skipped: rolling operation aborted after a failure / 已跳过：滚动操作因失败而中止"
+)
+
+// OperationMode selects how a cluster-wide operation is applied to nodes.
+// OperationMode 选择集群级操作作用于节点的方式。
+type OperationMode string
+
+const (
+	// OperationModeParallel operates all nodes at once (default).
+	// OperationModeParallel 同时操作所有节点（默认）。
+	OperationModeParallel OperationMode = "parallel"
+	// OperationModeRolling operates nodes batch by batch and waits for each batch to rejoin.
+	// OperationModeRolling 分批操作节点，并等待每批节点重新加入集群。
+	OperationModeRolling OperationMode = "rolling"
+)
+
+// RollingOptions controls a rolling restart.
+// RollingOptions 控制滚动重启。
+type RollingOptions struct {
+	// MaxUnavailable is the number of nodes restarted at the same time, default 1.
+	// MaxUnavailable 是同时重启的节点数，默认 1。
+	MaxUnavailable int `json:"max_unavailable,omitempty"`
+	// AbortOnFailure stops the rollout at the first failed batch, default true.
+	// AbortOnFailure 在第一批失败时中止滚动，默认 true。
+	AbortOnFailure *bool `json:"abort_on_failure,omitempty"`
+	// RejoinTimeoutSeconds is how long to wait for a node to rejoin the hazelcast cluster, default 300.
+	// RejoinTimeoutSeconds 是等待节点重新加入 hazelcast 集群的时长，默认 300。
+	RejoinTimeoutSeconds int `json:"rejoin_timeout_seconds,omitempty"`
+}
+
+// ClusterOperationRequest is the optional body of cluster restart.
+// ClusterOperationRequest 是集群重启的可选请求体。
+type ClusterOperationRequest struct {
+	Mode    OperationMode   `json:"mode,omitempty"`
+	Rolling *RollingOptions `json:"rolling,omitempty"`
+}
+
+// normalize fills defaults and validates the options.
+// normalize 补全默认值并校验参数。
+func (o *RollingOptions) normalize() (RollingOptions, error) {
+	abort := true
+	normalized := RollingOptions{
+		MaxUnavailable:       defaultRollingMaxUnavailable,
+		AbortOnFailure:       &abort,
+		RejoinTimeoutSeconds: defaultRollingRejoinTimeoutS,
+	}
+	if o == nil {
+		return normalized, nil
+	}
+	if o.MaxUnavailable < 0 || o.RejoinTimeoutSeconds < 0 {
+		return normalized, ErrInvalidRollingOptions
+	}
+	if o.MaxUnavailable > 0 {
+		normalized.MaxUnavailable = o.MaxUnavailable
+	}
+	if o.AbortOnFailure != nil {
+		abort = *o.AbortOnFailure
+	}
+	if o.RejoinTimeoutSeconds > 0 {
+		normalized.RejoinTimeoutSeconds = o.RejoinTimeoutSeconds
+	}
+	return normalized, nil
+}
+
+// RollingRestart restarts the nodes of a cluster batch by batch: workers first, then master-capable nodes.
+// Each batch has at most MaxUnavailable nodes, and the next batch starts only after every node of the
+// current batch is verified by its Agent to be a ready hazelcast member again.
+// RollingRestart 分批重启集群节点：先 worker，后可作为 master 的节点。
+// 每批最多 MaxUnavailable 个节点，且只有当前批次所有节点经 Agent 确认重新成为就绪的 hazelcast 成员后才开始下一批。
+func (s *Service) RollingRestart(ctx context.Context, clusterID uint, opts *RollingOptions) (*OperationResult, error) {
+	return s.runOperationTask(ctx, task.TaskTypeRollingRestart, clusterID, opts, func(ctx context.Context) (*OperationResult, error) {
+		return s.rollingRestart(ctx, clusterID, opts)
+	})
+}
+
+// rollingRestart performs the rolling restart; each batch is reported as a task step.
+// rollingRestart 执行滚动重启；每个批次作为一个任务步骤上报。
+func (s *Service) rollingRestart(ctx context.Context, clusterID uint, opts *RollingOptions) (*OperationResult, error) {
+	options, err := opts.normalize()
+	if err != nil {
+		return nil, err
+	}
+
+	cluster, err := s.repo.GetByID(ctx, clusterID, true)
+	if err != nil {
+		return nil, err
+	}
+	active, err := s.repo.HasActiveScaleTask(ctx, clusterID)
+	if err != nil {
+		return nil, err
+	}
+	if active {
+		return nil, ErrScaleInProgress
+	}
+
+	nodes := make([]*ClusterNode, 0, len(cluster.Nodes))
+	for i := range cluster.Nodes {
+		nodes = append(nodes, &cluster.Nodes[i])
+	}
+	ordered := rollingRestartOrder(nil, nodes)
+
+	result := &OperationResult{
+		ClusterID:   clusterID,
+		Operation:   OperationRestart,
+		Success:     true,
+		NodeResults: make([]*NodeOperationResult, 0, len(ordered)),
+	}
+	rejoinTimeout := time.Duration(options.RejoinTimeoutSeconds) * time.Second
+	aborted := false
+	reporter := task.ReporterFromContext(ctx)
+
+	for start := 0; start < len(ordered); start += options.MaxUnavailable {
+		end := start + options.MaxUnavailable
+		if end > len(ordered) {
+			end = len(ordered)
+		}
+		batch := ordered[start:end]
+
+		if aborted {
+			for _, node := range batch {
+				result.NodeResults = append(result.NodeResults, &NodeOperationResult{
+					NodeID:  node.ID,
+					HostID:  node.HostID,
+					Message: rollingSkippedAfterFailureText,
+				})
+			}
+			continue
+		}
+
+		step := fmt.Sprintf("batch-%d", start/options.MaxUnavailable+1)
+		reporter.StartStep(step, fmt.Sprintf("Restart %d node(s) / 重启 %d 个节点", len(batch), len(batch)))
+		batchResults := make([]*NodeOperationResult, 0, len(batch))
+		for _, node := range batch {
+			batchResults = append(batchResults, s.restartNodeForRolling(ctx, cluster, node))
+		}
+		for i, node := range batch {
+			if !batchResults[i].Success {
+				continue
+			}
+			if err := s.waitNodeRejoined(ctx, node, rejoinTimeout); err != nil {
+				batchResults[i].Success = false
+				batchResults[i].Message = err.Error()
+			}
+		}
+
+		var batchErr error
+		for _, nodeResult := range batchResults {
+			result.NodeResults = append(result.NodeResults, nodeResult)
+			if nodeResult.Success {
+				continue
+			}
+			result.Success = false
+			batchErr = errors.New(nodeResult.Message)
+			logger.WarnF(ctx, "[Cluster] rolling restart node failed: cluster=%d, node=%d, message=%s", clusterID, nodeResult.NodeID, nodeResult.Message)
+			if *options.AbortOnFailure {
+				aborted = true
+			}
+		}
+		if batchErr != nil {
+			reporter.FailStep(step, batchErr)
+		} else {
+			reporter.CompleteStep(step, "")
+		}
+		reporter.Update(end*100/len(ordered), fmt.Sprintf("%d/%d nodes restarted / 已重启 %d/%d 个节点", end, len(ordered), end, len(ordered)))
+		if ctx.Err() != nil {
+			aborted = true
+		}
+	}
+
+	switch {
+	case result.Success:
+		result.Message = "Rolling restart completed successfully"
+	case aborted:
+		result.Message = "Rolling restart aborted after a failure"
+	default:
+		result.Message = "Rolling restart completed with errors"
+	}
+	return result, nil
+}
+
+// restartNodeForRolling restarts one node and converts the outcome into a node result.
+// restartNodeForRolling 重启单个节点并将结果转换为节点结果。
+func (s *Service) restartNodeForRolling(ctx context.Context, cluster *Cluster, node *ClusterNode) *NodeOperationResult {
+	nodeResult := &NodeOperationResult{NodeID: node.ID, HostID: node.HostID}
+	opResult, err := s.executeNodeOperationWithResolvedNode(ctx, cluster, node, OperationRestart)
+	if err != nil {
+		nodeResult.Message = err.Error()
+		return nodeResult
+	}
+	if len(opResult.NodeResults) > 0 {
+		nodeResult = opResult.NodeResults[0]
+	}
+	nodeResult.Success = opResult.Success
+	if nodeResult.Message == "" {
+		nodeResult.Message = opResult.Message
+	}
+	return nodeResult
+}
+
+// waitNodeRejoined polls the node through its Agent until it is a ready hazelcast member again.
+// waitNodeRejoined 通过 Agent 轮询节点，直到其重新成为就绪的 hazelcast 成员。
+func (s *Service) waitNodeRejoined(ctx context.Context, node *ClusterNode, timeout time.Duration) error {
+	if s.hostProvider == nil || s.agentSender == nil {
+		return nil
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	lastErr := ""
+	for {
+		httpOK, memberOK, probeErr := s.probeNode(waitCtx, node)
+		if probeErr == healthCheckNoProbeTargetMessage || (httpOK && memberOK && probeErr == "") {
+			return nil
+		}
+		lastErr = probeErr
+		select {
+		case <-waitCtx.Done():
+			return fmt.Errorf("node %d did not rejoin the cluster within %s: %s / 节点 %d 未在 %s 内重新加入集群：%s",
+				node.ID, timeout, lastErr, node.ID, timeout, lastErr)
+		case <-time.After(s.rejoinPollInterval):
+		}
+	}
+}
@@ -0,0 +1,78 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cluster
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestService_RollingRestart_abortsWhenNodeDoesNotRejoin(t *testing.T) {
+	svc, _, _ := newScaleTestService(t)
+	svc.rejoinPollInterval = 50 * time.Millisecond
+	ctx := context.Background()
+
+	var operations []string
+	svc.SetAgentCommandSender(&scriptedAgentSender{send: func(ctx context.Context, agentID string, commandType string, params map[string]string) (bool, string, error) {
+		switch commandType {
+		case "check_http":
+			// host-1 never becomes a ready hazelcast member again.
+			// host-1 始终无法重新成为就绪的 hazelcast 成员。
+			return agentID != "agent-1", "", nil
+		case "check_process":
+			return true, "SeaTunnel process found: PID=4321, role=hybrid", nil
+		case string(OperationRestart):
+			operations = append(operations, agentID)
+		}
+		return true, "ok", nil
+	}})
+
+	cluster, err := svc.Create(ctx, &CreateClusterRequest{Name: "rolling-cluster", DeploymentMode: DeploymentModeHybrid, Version: "2.3.12"})
+	if err != nil {
+		t.Fatalf("Create returned error: %v", err)
+	}
+	for _, hostID := range []uint{1, 2} {
+		if _, err := svc.AddNode(ctx, cluster.ID, &AddNodeRequest{HostID: hostID, Role: NodeRoleMasterWorker, SkipPrecheck: true}); err != nil {
+			t.Fatalf("AddNode(%d) returned error: %v", hostID, err)
+		}
+	}
+
+	if _, err := svc.RollingRestart(ctx, cluster.ID, &RollingOptions{MaxUnavailable: -1}); !errors.Is(err, ErrInvalidRollingOptions) {
+		t.Fatalf("expected ErrInvalidRollingOptions, got %v", err)
+	}
+
+	result, err := svc.RollingRestart(ctx, cluster.ID, &RollingOptions{RejoinTimeoutSeconds: 1})
+	if err != nil {
+		t.Fatalf("RollingRestart returned error: %v", err)
+	}
+	if result.Success || len(result.NodeResults) != 2 {
+		t.Fatalf("expected failed rolling restart with 2 node results, got %+v", result)
+	}
+	if !strings.Contains(result.NodeResults[0].Message, "did not rejoin") {
+		t.Fatalf("expected rejoin failure on first node, got %q", result.NodeResults[0].Message)
+	}
+	if result.NodeResults[1].Success || result.NodeResults[1].Message != rollingSkippedAfterFailureText {
+		t.Fatalf("expected second node to be skipped, got %+v", result.NodeResults[1])
+	}
+	if strings.Join(operations, ",") != "agent-1" {
+		t.Fatalf("expected only the first node to be restarted, got %v", operations)
+	}
+}
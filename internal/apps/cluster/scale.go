@@ -0,0 +1,1024 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cluster
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	appconfig "github.com/seatunnel/seatunnelX/internal/apps/config"
+	installerapp "github.com/seatunnel/seatunnelX/internal/apps/installer"
+	"github.com/seatunnel/seatunnelX/internal/logger"
+	"gopkg.in/yaml.v3"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultScaleTaskTimeout      = 60 * time.Minute
+	defaultScaleInstallTimeout   = 30 * time.Minute
+	defaultScaleNodeReadyTimeout = 3 * time.Minute
+	defaultScaleSettleDelay      = 15 * time.Second
+	defaultScalePollInterval     = 2 * time.Second
+	maxScaleTaskLogBytes         = 128 * 1024
+	scaleTaskListLimit           = 20
+)
+
+// ScaleTaskStatus represents the status of a scale task.
+// ScaleTaskStatus 表示扩缩容任务的状态。
+type ScaleTaskStatus string
+
+const (
+	ScaleTaskStatusPending ScaleTaskStatus = "pending"
+	ScaleTaskStatusRunning ScaleTaskStatus = "running"
+	ScaleTaskStatusSuccess ScaleTaskStatus = "success"
+	ScaleTaskStatusFailed  ScaleTaskStatus = "failed"
+)
+
+// Scale task steps, in execution order.
+// 扩缩容任务步骤（按执行顺序）。
+const (
+	ScaleStepPrecheck       = "precheck"
+	ScaleStepRegisterNodes  = "register_nodes"
+	ScaleStepInstall        = "install"
+	ScaleStepPushConfig     = "push_config"
+	ScaleStepDrain          = "drain"
+	ScaleStepRemoveNodes    = "remove_nodes"
+	ScaleStepMemberList     = "update_member_list"
+	ScaleStepRollingRestart = "rolling_restart"
+	ScaleStepComplete       = "complete"
+)
+
+// NodeInstaller prechecks hosts and installs SeaTunnel on them (implemented by installer.Service).
+// NodeInstaller 对主机执行预检查并安装 SeaTunnel（由 installer.Service 实现）。
+type NodeInstaller interface {
+	RunPrecheck(ctx context.Context, hostID uint, req *installerapp.PrecheckRequest) (*installerapp.PrecheckResult, error)
+	StartInstallation(ctx context.Context, req *installerapp.InstallationRequest) (*installerapp.InstallationStatus, error)
+	GetInstallationStatus(ctx context.Context, hostID uint) (*installerapp.InstallationStatus, error)
+}
+
+// ClusterConfigStore reads stored cluster config templates and refreshes stored node configs
+// (implemented by config.Service).
+// ClusterConfigStore 读取已保存的集群配置模板并刷新节点配置记录（由 config.Service 实现）。
+type ClusterConfigStore interface {
+	GetTemplateContent(ctx context.Context, clusterID uint, configType appconfig.ConfigType) (string, error)
+	InitClusterConfigs(ctx context.Context, clusterID uint, hostID uint, installDir string, userID uint) error
+}
+
+// ScaleNodeRequest describes one node to add by scaling.
+// ScaleNodeRequest 描述扩容时要添加的一个节点。
+type ScaleNodeRequest struct {
+	HostID uint `json:"host_id" binding:"required"`
+	// Role defaults to worker in separated mode and master/worker in hybrid mode.
+	// Role 在分离模式下默认为 worker，在混合模式下默认为 master/worker。
+	Role          NodeRole `json:"role,omitempty"`
+	InstallDir    string   `json:"install_dir,omitempty"`
+	HazelcastPort int      `json:"hazelcast_port,omitempty"`
+	APIPort       int      `json:"api_port,omitempty"`
+	WorkerPort    int      `json:"worker_port,omitempty"`
+}
+
+// ScaleClusterRequest adds and/or removes nodes of a running cluster.
+// ScaleClusterRequest 为运行中的集群添加和/或移除节点。
+type ScaleClusterRequest struct {
+	AddNodes      []ScaleNodeRequest        `json:"add_nodes,omitempty"`
+	RemoveNodeIDs []uint                    `json:"remove_node_ids,omitempty"`
+	InstallMode   installerapp.InstallMode  `json:"install_mode,omitempty"`
+	Mirror        installerapp.MirrorSource `json:"mirror,omitempty"`
+	PackagePath   string                    `json:"package_path,omitempty"`
+	// PackageDelivery lets the new nodes download the package themselves instead of receiving it from the Control Plane.
+	// PackageDelivery 让新节点自行下载安装包，而不是从控制面接收。
+	PackageDelivery installerapp.PackageDelivery `json:"package_delivery,omitempty"`
+	// Drain waits for jobs on removed nodes to finish before stopping them; remaining nodes are not restarted
+	// when no node is added.
+	// Drain 在停止被移除节点前等待其上作业结束；未添加节点时不重启其余节点。
+	Drain *DrainOptions `json:"drain,omitempty"`
+}
+
+// Value implements the driver.Valuer interface for database storage.
+// Value 实现 driver.Valuer 接口用于数据库存储。
+func (r ScaleClusterRequest) Value() (driver.Value, error) {
+	return json.Marshal(r)
+}
+
+// Scan implements the sql.Scanner interface for database retrieval.
+// Scan 实现 sql.Scanner 接口用于数据库读取。
+func (r *ScaleClusterRequest) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*r = ScaleClusterRequest{}
+		return nil
+	case []byte:
+		return json.Unmarshal(v, r)
+	case string:
+		return json.Unmarshal([]byte(v), r)
+	default:
+		return errors.New("cluster: failed to scan ScaleClusterRequest - expected []byte or string")
+	}
+}
+
+// ScaleTask records one scale-out / scale-in run and its log.
+// ScaleTask 记录一次扩容/缩容执行及其日志。
+type ScaleTask struct {
+	ID         uint                `json:"id" gorm:"primaryKey;autoIncrement"`
+	ClusterID  uint                `json:"cluster_id" gorm:"index;not null"`
+	Status     ScaleTaskStatus     `json:"status" gorm:"size:20;index;not null"`
+	Step       string              `json:"step" gorm:"size:50"`
+	Request    ScaleClusterRequest `json:"request" gorm:"type:text"`
+	Log        string              `json:"log,omitempty" gorm:"type:text"`
+	Error      string              `json:"error,omitempty" gorm:"type:text"`
+	CreatedBy  string              `json:"created_by" gorm:"size:100"`
+	StartedAt  *time.Time          `json:"started_at,omitempty"`
+	FinishedAt *time.Time          `json:"finished_at,omitempty"`
+	CreatedAt  time.Time           `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt  time.Time           `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// TableName specifies the table name for the ScaleTask model.
+func (ScaleTask) TableName() string {
+	return "cluster_scale_tasks"
+}
+
+// SetNodeInstaller sets the installer used to precheck and install nodes added by scaling.
+// SetNodeInstaller 设置扩容时用于预检查和安装节点的安装器。
+func (s *Service) SetNodeInstaller(installer NodeInstaller) {
+	s.nodeInstaller = installer
+}
+
+// SetClusterConfigStore sets the stored config source used when scaling.
+// SetClusterConfigStore 设置扩缩容时使用的已保存配置来源。
+func (s *Service) SetClusterConfigStore(store ClusterConfigStore) {
+	s.configStore = store
+}
+
+// RecoverInterruptedScaleTasks fails scale tasks left unfinished by a previous process.
+// RecoverInterruptedScaleTasks 将上一个进程遗留的未完成扩缩容任务标记为失败。
+func (s *Service) RecoverInterruptedScaleTasks(ctx context.Context) error {
+	return s.repo.FailActiveScaleTasks(ctx, "interrupted by Control Plane restart / Control Plane 重启导致任务中断")
+}
+
+// StartScale validates a scale request and runs it in the background.
+// New nodes are prechecked, installed with the cluster's stored configs, then the hazelcast
+// member-lists of all nodes are rewritten and nodes are restarted one at a time.
+// StartScale 校验扩缩容请求并在后台执行。
+// 新节点先预检查，再使用集群已保存的配置安装，随后改写所有节点的 hazelcast member-list 并逐个滚动重启。
+func (s *Service) StartScale(ctx context.Context, clusterID uint, req *ScaleClusterRequest, createdBy string) (*ScaleTask, error) {
+	if s.hostProvider == nil || s.agentSender == nil || s.configAgentClient == nil || s.nodeInstaller == nil || s.configStore == nil {
+		return nil, ErrScaleUnavailable
+	}
+	if len(req.AddNodes) == 0 && len(req.RemoveNodeIDs) == 0 {
+		return nil, ErrScaleRequestEmpty
+	}
+	if req.Drain != nil {
+		if s.drainClient == nil {
+			return nil, ErrScaleUnavailable
+		}
+		drain, err := req.Drain.normalize()
+		if err != nil {
+			return nil, err
+		}
+		req.Drain = drain
+	}
+
+	cluster, err := s.repo.GetByID(ctx, clusterID, false)
+	if err != nil {
+		return nil, err
+	}
+	if cluster.Status != ClusterStatusRunning {
+		return nil, ErrClusterNotRunning
+	}
+	nodes, err := s.repo.GetNodesByClusterID(ctx, clusterID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.planScaleNodes(ctx, cluster, nodes, req); err != nil {
+		return nil, err
+	}
+	if _, err := planScaleRemovals(cluster, nodes, req.RemoveNodeIDs); err != nil {
+		return nil, err
+	}
+
+	s.scaleMu.Lock()
+	defer s.scaleMu.Unlock()
+	active, err := s.repo.HasActiveScaleTask(ctx, clusterID)
+	if err != nil {
+		return nil, err
+	}
+	if active {
+		return nil, ErrScaleInProgress
+	}
+
+	task := &ScaleTask{
+		ClusterID: clusterID,
+		Status:    ScaleTaskStatusPending,
+		Request:   *req,
+		CreatedBy: createdBy,
+	}
+	if err := s.repo.CreateScaleTask(ctx, task); err != nil {
+		return nil, err
+	}
+
+	s.scaleWG.Add(1)
+	go s.runScale(task)
+	return task, nil
+}
+
+// GetScaleTask returns a scale task of a cluster with its log.
+// GetScaleTask 返回集群的扩缩容任务及其日志。
+func (s *Service) GetScaleTask(ctx context.Context, clusterID, taskID uint) (*ScaleTask, error) {
+	return s.repo.GetScaleTask(ctx, clusterID, taskID)
+}
+
+// ListScaleTasks returns the recent scale tasks of a cluster without logs.
+// ListScaleTasks 返回集群最近的扩缩容任务（不含日志）。
+func (s *Service) ListScaleTasks(ctx context.Context, clusterID uint) ([]*ScaleTask, error) {
+	return s.repo.ListScaleTasks(ctx, clusterID, scaleTaskListLimit)
+}
+
+// planScaleNodes validates the nodes to add and returns them normalized, without persisting.
+// planScaleNodes 校验待添加节点并返回规范化后的节点（不落库）。
+func (s *Service) planScaleNodes(ctx context.Context, cluster *Cluster, existing []*ClusterNode, req *ScaleClusterRequest) ([]*ClusterNode, error) {
+	usedHosts := make(map[uint]struct{}, len(existing)+len(req.AddNodes))
+	for _, node := range existing {
+		usedHosts[node.HostID] = struct{}{}
+	}
+
+	planned := make([]*ClusterNode, 0, len(req.AddNodes))
+	for _, nodeReq := range req.AddNodes {
+		if _, used := usedHosts[nodeReq.HostID]; used {
+			return nil, fmt.Errorf("%w: host %d", ErrScaleDuplicateHost, nodeReq.HostID)
+		}
+		usedHosts[nodeReq.HostID] = struct{}{}
+
+		role := nodeReq.Role
+		if role == "" {
+			role = NodeRoleWorker
+		}
+		hostInfo, err := s.ensureHostReady(ctx, nodeReq.HostID)
+		if err != nil {
+			return nil, err
+		}
+		node, err := buildNodeForCreate(cluster.ID, nodeReq.HostID, cluster, role, nodeReq.InstallDir,
+			nodeReq.HazelcastPort, nodeReq.APIPort, nodeReq.WorkerPort, nil)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkNodePlacement(cluster, hostInfo, node.Role); err != nil {
+			return nil, err
+		}
+		if err := checkNodePortConflicts(node, nil); err != nil {
+			return nil, err
+		}
+		planned = append(planned, node)
+	}
+	return planned, nil
+}
+
+// planScaleRemovals returns the nodes to remove; only workers (or hybrid nodes) can be removed
+// and at least one master-capable node must remain.
+// planScaleRemovals 返回待移除的节点；仅允许移除 worker（或混合节点），且至少保留一个可作为 master 的节点。
+func planScaleRemovals(cluster *Cluster, existing []*ClusterNode, nodeIDs []uint) ([]*ClusterNode, error) {
+	byID := make(map[uint]*ClusterNode, len(existing))
+	masters := 0
+	for _, node := range existing {
+		byID[node.ID] = node
+		if isMasterCapable(node.Role) {
+			masters++
+		}
+	}
+
+	removals := make([]*ClusterNode, 0, len(nodeIDs))
+	seen := make(map[uint]struct{}, len(nodeIDs))
+	for _, nodeID := range nodeIDs {
+		node, ok := byID[nodeID]
+		if !ok {
+			return nil, fmt.Errorf("%w: node %d", ErrNodeNotFound, nodeID)
+		}
+		if _, dup := seen[nodeID]; dup {
+			continue
+		}
+		seen[nodeID] = struct{}{}
+		if cluster.DeploymentMode == DeploymentModeSeparated && node.Role != NodeRoleWorker {
+			return nil, fmt.Errorf("%w: node %d is a %s", ErrScaleRemoveNotAllowed, nodeID, node.Role)
+		}
+		if isMasterCapable(node.Role) {
+			masters--
+		}
+		removals = append(removals, node)
+	}
+	if masters < 1 {
+		return nil, ErrScaleRemoveNotAllowed
+	}
+	return removals, nil
+}
+
+func isMasterCapable(role NodeRole) bool {
+	return role == NodeRoleMaster || role == NodeRoleMasterWorker
+}
+
+// runScale executes a scale task and records its outcome.
+// runScale 执行扩缩容任务并记录结果。
+func (s *Service) runScale(task *ScaleTask) {
+	defer s.scaleWG.Done()
+	ctx, cancel := context.WithTimeout(context.Background(), defaultScaleTaskTimeout)
+	defer cancel()
+
+	tracker := &scaleTracker{repo: s.repo, task: task}
+	tracker.start()
+	err := s.scale(ctx, tracker, task.ClusterID, &task.Request)
+	tracker.finish(err)
+	s.notifyClusterTopologyChanged(ctx, task.ClusterID)
+}
+
+func (s *Service) scale(ctx context.Context, tracker *scaleTracker, clusterID uint, req *ScaleClusterRequest) error {
+	cluster, err := s.repo.GetByID(ctx, clusterID, false)
+	if err != nil {
+		return err
+	}
+	existing, err := s.repo.GetNodesByClusterID(ctx, clusterID)
+	if err != nil {
+		return err
+	}
+	planned, err := s.planScaleNodes(ctx, cluster, existing, req)
+	if err != nil {
+		return err
+	}
+	removals, err := planScaleRemovals(cluster, existing, req.RemoveNodeIDs)
+	if err != nil {
+		return err
+	}
+
+	if len(planned) > 0 {
+		tracker.step(ScaleStepPrecheck)
+		for _, node := range planned {
+			if err := s.precheckScaleNode(ctx, tracker, cluster, node); err != nil {
+				return err
+			}
+		}
+
+		tracker.step(ScaleStepRegisterNodes)
+		if err := s.repo.Transaction(ctx, func(tx *Repository) error {
+			for _, node := range planned {
+				node.Status = NodeStatusInstalling
+				if err := tx.AddNode(ctx, node); err != nil {
+					return err
+				}
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+		for _, node := range planned {
+			tracker.logf("registered %s node %d on host %d", node.Role, node.ID, node.HostID)
+		}
+	}
+
+	// Member addresses reflect the topology after scaling.
+	// 成员地址反映扩缩容之后的拓扑。
+	removed := make(map[uint]struct{}, len(removals))
+	for _, node := range removals {
+		removed[node.ID] = struct{}{}
+	}
+	remaining := make([]*ClusterNode, 0, len(existing))
+	for _, node := range existing {
+		if _, ok := removed[node.ID]; !ok {
+			remaining = append(remaining, node)
+		}
+	}
+	target := append(append([]*ClusterNode{}, remaining...), planned...)
+	hosts, err := s.loadScaleHosts(ctx, append(append([]*ClusterNode{}, target...), removals...))
+	if err != nil {
+		return err
+	}
+	members, err := buildScaleMembers(target, hosts)
+	if err != nil {
+		return err
+	}
+
+	if len(planned) > 0 {
+		tracker.step(ScaleStepInstall)
+		for _, node := range planned {
+			if err := s.installScaleNode(ctx, tracker, cluster, node, target, hosts, req); err != nil {
+				s.markScaleNodesFailed(ctx, planned)
+				return err
+			}
+		}
+
+		tracker.step(ScaleStepPushConfig)
+		for _, node := range planned {
+			if err := s.pushTemplatesToNode(ctx, tracker, cluster, node, members); err != nil {
+				s.markScaleNodesFailed(ctx, planned)
+				return err
+			}
+		}
+	}
+
+	if len(removals) > 0 && req.Drain != nil {
+		tracker.step(ScaleStepDrain)
+		if err := s.drainScaleNodes(ctx, tracker, removals, remaining, hosts, req.Drain); err != nil {
+			return err
+		}
+	}
+
+	if len(removals) > 0 {
+		tracker.step(ScaleStepRemoveNodes)
+		for _, node := range removals {
+			s.removeScaleNode(ctx, tracker, cluster, node, hosts[node.HostID])
+		}
+	}
+
+	tracker.step(ScaleStepMemberList)
+	for _, node := range remaining {
+		if err := s.updateNodeMemberList(ctx, tracker, cluster, node, members); err != nil {
+			return err
+		}
+	}
+	for _, node := range target {
+		if err := s.configStore.InitClusterConfigs(ctx, cluster.ID, node.HostID, resolveNodeInstallDir(node.InstallDir, cluster.InstallDir), 0); err != nil {
+			tracker.logf("warning: refresh stored configs of host %d failed: %v", node.HostID, err)
+		}
+	}
+
+	// Hazelcast only reads member lists when joining, so a drained removal leaves the remaining nodes
+	// running instead of restarting them under their jobs.
+	// Hazelcast 仅在加入集群时读取成员列表，因此排空移除时保留其余节点运行，而不是在作业运行中重启它们。
+	if req.Drain != nil && len(planned) == 0 {
+		tracker.logf("skipping rolling restart: member lists take effect on the next restart of each node")
+		tracker.step(ScaleStepComplete)
+		tracker.logf("cluster now has %d node(s)", len(target))
+		return nil
+	}
+
+	tracker.step(ScaleStepRollingRestart)
+	for i, node := range rollingRestartOrder(planned, remaining) {
+		operation := OperationRestart
+		if i < len(planned) {
+			operation = OperationStart
+		}
+		if err := s.restartScaleNode(ctx, tracker, cluster, node, hosts[node.HostID], operation); err != nil {
+			return err
+		}
+		if i < len(planned)+len(remaining)-1 && s.scaleSettleDelay > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(s.scaleSettleDelay):
+			}
+		}
+	}
+
+	tracker.step(ScaleStepComplete)
+	tracker.logf("cluster now has %d node(s)", len(target))
+	return nil
+}
+
+// precheckScaleNode runs the installer precheck on a new host and fails on any failed item.
+// precheckScaleNode 在新主机上执行安装预检查，任一项失败即返回错误。
+func (s *Service) precheckScaleNode(ctx context.Context, tracker *scaleTracker, cluster *Cluster, node *ClusterNode) error {
+	ports := []int{node.HazelcastPort}
+	if node.APIPort > 0 {
+		ports = append(ports, node.APIPort)
+	}
+	if node.WorkerPort > 0 {
+		ports = append(ports, node.WorkerPort)
+	}
+	result, err := s.nodeInstaller.RunPrecheck(ctx, node.HostID, &installerapp.PrecheckRequest{
+		InstallDir:  resolveNodeInstallDir(node.InstallDir, cluster.InstallDir),
+		Ports:       ports,
+		Version:     cluster.Version,
+		ClusterPort: node.HazelcastPort,
+	})
+	if err != nil {
+		return fmt.Errorf("precheck host %d: %w", node.HostID, err)
+	}
+	var failed []string
+	for _, item := range result.Items {
+		switch item.Status {
+		case installerapp.CheckStatusFailed:
+			failed = append(failed, fmt.Sprintf("%s: %s", item.Name, item.Message))
+		case installerapp.CheckStatusWarning:
+			tracker.logf("host %d precheck warning %s: %s", node.HostID, item.Name, item.Message)
+		}
+	}
+	if result.OverallStatus == installerapp.CheckStatusFailed || len(failed) > 0 {
+		return fmt.Errorf("%w: host %d: %s", ErrPrecheckFailed, node.HostID, strings.Join(failed, "; "))
+	}
+	tracker.logf("host %d precheck passed", node.HostID)
+	return nil
+}
+
+// installScaleNode installs SeaTunnel on a new node without starting it and waits for the result.
+// installScaleNode 在新节点上安装 SeaTunnel（不启动）并等待结果。
+func (s *Service) installScaleNode(ctx context.Context, tracker *scaleTracker, cluster *Cluster, node *ClusterNode, target []*ClusterNode, hosts map[uint]*HostInfo, req *ScaleClusterRequest) error {
+	installReq := buildScaleInstallRequest(cluster, node, target, hosts, req)
+	tracker.logf("installing SeaTunnel %s on host %d (%s) into %s", installReq.Version, node.HostID, node.Role, installReq.InstallDir)
+	if _, err := s.nodeInstaller.StartInstallation(ctx, installReq); err != nil {
+		return fmt.Errorf("start installation on host %d: %w", node.HostID, err)
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, s.scaleInstallTimeout)
+	defer cancel()
+	for {
+		status, err := s.nodeInstaller.GetInstallationStatus(waitCtx, node.HostID)
+		if err == nil && status != nil {
+			switch status.Status {
+			case installerapp.StepStatusSuccess:
+				_ = s.repo.UpdateNodeStatus(ctx, node.ID, NodeStatusStopped)
+				tracker.logf("host %d installed", node.HostID)
+				return nil
+			case installerapp.StepStatusFailed:
+				return fmt.Errorf("install on host %d failed: %s", node.HostID, firstNonEmpty(status.Error, status.Message))
+			}
+		}
+		select {
+		case <-waitCtx.Done():
+			return fmt.Errorf("install on host %d: %w", node.HostID, waitCtx.Err())
+		case <-time.After(s.scalePollInterval):
+		}
+	}
+}
+
+// buildScaleInstallRequest builds the installation request of a new node from the cluster settings.
+// buildScaleInstallRequest 根据集群设置构建新节点的安装请求。
+func buildScaleInstallRequest(cluster *Cluster, node *ClusterNode, target []*ClusterNode, hosts map[uint]*HostInfo, req *ScaleClusterRequest) *installerapp.InstallationRequest {
+	installMode := req.InstallMode
+	if installMode == "" {
+		installMode = installerapp.InstallModeOnline
+	}
+	installReq := &installerapp.InstallationRequest{
+		HostID:          strconv.FormatUint(uint64(node.HostID), 10),
+		ClusterID:       strconv.FormatUint(uint64(cluster.ID), 10),
+		Version:         cluster.Version,
+		InstallDir:      resolveNodeInstallDir(node.InstallDir, cluster.InstallDir),
+		InstallMode:     installMode,
+		Mirror:          req.Mirror,
+		PackagePath:     req.PackagePath,
+		PackageDelivery: req.PackageDelivery,
+		DeploymentMode:  installerapp.DeploymentMode(cluster.DeploymentMode),
+		NodeRole:        installerapp.NodeRole(node.Role),
+		HTTPPort:        node.APIPort,
+		SkipNodeStart:   true,
+	}
+
+	for _, member := range target {
+		host := hosts[member.HostID]
+		if host == nil {
+			continue
+		}
+		switch {
+		case cluster.DeploymentMode == DeploymentModeHybrid || member.Role == NodeRoleMaster:
+			installReq.MasterAddresses = append(installReq.MasterAddresses, host.IPAddress)
+			if installReq.ClusterPort == 0 {
+				installReq.ClusterPort = member.HazelcastPort
+			}
+		case member.Role == NodeRoleWorker:
+			installReq.WorkerAddresses = append(installReq.WorkerAddresses, host.IPAddress)
+			if installReq.WorkerPort == 0 {
+				installReq.WorkerPort = member.HazelcastPort
+			}
+		}
+	}
+	switch node.Role {
+	case NodeRoleWorker:
+		installReq.WorkerPort = node.HazelcastPort
+	default:
+		installReq.ClusterPort = node.HazelcastPort
+		if node.WorkerPort > 0 {
+			installReq.WorkerPort = node.WorkerPort
+		}
+	}
+	return installReq
+}
+
+// pushTemplatesToNode pushes the cluster's stored config templates to a new node, adapted to the node.
+// JVM options are left to the installer, which already resolves cluster and node-level heap settings.
+// pushTemplatesToNode 将集群已保存的配置模板按节点调整后推送到新节点。
+// JVM 选项由安装器处理，安装器已解析集群与节点级堆内存设置。
+func (s *Service) pushTemplatesToNode(ctx context.Context, tracker *scaleTracker, cluster *Cluster, node *ClusterNode, members *scaleMembers) error {
+	installDir := resolveNodeInstallDir(node.InstallDir, cluster.InstallDir)
+	configTypes := []appconfig.ConfigType{
+		appconfig.ConfigTypeSeatunnel,
+		hazelcastConfigType(cluster.DeploymentMode, node.Role),
+		appconfig.ConfigTypeHazelcastClient,
+		appconfig.ConfigTypeLog4j2,
+	}
+	for _, configType := range configTypes {
+		content, err := s.configStore.GetTemplateContent(ctx, cluster.ID, configType)
+		if err != nil || strings.TrimSpace(content) == "" {
+			tracker.logf("no stored %s template, host %d keeps the installed file", configType, node.HostID)
+			continue
+		}
+		rendered, err := renderScaleNodeConfig(configType, content, node, members)
+		if err != nil {
+			return fmt.Errorf("render %s for host %d: %w", configType, node.HostID, err)
+		}
+		if err := s.configAgentClient.PushConfig(ctx, node.HostID, installDir, configType, rendered); err != nil {
+			return fmt.Errorf("push %s to host %d: %w", configType, node.HostID, err)
+		}
+		tracker.logf("pushed stored %s to host %d", configType, node.HostID)
+	}
+	return nil
+}
+
+// updateNodeMemberList rewrites the hazelcast member lists of an existing node.
+// updateNodeMemberList 改写已有节点的 hazelcast 成员列表。
+func (s *Service) updateNodeMemberList(ctx context.Context, tracker *scaleTracker, cluster *Cluster, node *ClusterNode, members *scaleMembers) error {
+	installDir := resolveNodeInstallDir(node.InstallDir, cluster.InstallDir)
+	for _, configType := range []appconfig.ConfigType{hazelcastConfigType(cluster.DeploymentMode, node.Role), appconfig.ConfigTypeHazelcastClient} {
+		content, err := s.configAgentClient.PullConfig(ctx, node.HostID, installDir, configType)
+		if err != nil {
+			return fmt.Errorf("pull %s from host %d: %w", configType, node.HostID, err)
+		}
+		var updated string
+		if configType == appconfig.ConfigTypeHazelcastClient {
+			updated, err = setHazelcastClientMembers(content, members.client)
+		} else {
+			updated, err = setHazelcastMembers(content, members.server, 0)
+		}
+		if err != nil {
+			return fmt.Errorf("update %s of host %d: %w", configType, node.HostID, err)
+		}
+		if updated == content {
+			continue
+		}
+		if err := s.configAgentClient.PushConfig(ctx, node.HostID, installDir, configType, updated); err != nil {
+			return fmt.Errorf("push %s to host %d: %w", configType, node.HostID, err)
+		}
+		tracker.logf("updated %s member list on host %d", configType, node.HostID)
+	}
+	return nil
+}
+
+// removeScaleNode stops a node and deletes it; a stop failure is logged so offline hosts can still be removed.
+// removeScaleNode 停止并删除节点；停止失败仅记录日志，以便离线主机也能被移除。
+func (s *Service) removeScaleNode(ctx context.Context, tracker *scaleTracker, cluster *Cluster, node *ClusterNode, host *HostInfo) {
+	result, err := s.executeNodeOperationWithResolvedNode(ctx, cluster, node, OperationStop)
+	switch {
+	case err != nil:
+		tracker.logf("warning: stop node %d failed: %v", node.ID, err)
+	case !result.Success:
+		tracker.logf("warning: stop node %d failed: %s", node.ID, result.Message)
+	}
+	if err := s.repo.RemoveNode(ctx, node.ID); err != nil {
+		tracker.logf("warning: delete node %d failed: %v", node.ID, err)
+		return
+	}
+	s.forgetNodeHealth(node.ID)
+	name := ""
+	if host != nil {
+		name = host.Name
+	}
+	tracker.logf("removed %s node %d (host %d %s)", node.Role, node.ID, node.HostID, name)
+}
+
+// restartScaleNode starts or restarts a node and waits until its process is running.
+// restartScaleNode 启动或重启节点并等待其进程运行。
+func (s *Service) restartScaleNode(ctx context.Context, tracker *scaleTracker, cluster *Cluster, node *ClusterNode, host *HostInfo, operation OperationType) error {
+	tracker.logf("%s %s node %d on host %d", operation, node.Role, node.ID, node.HostID)
+	result, err := s.executeNodeOperationWithResolvedNode(ctx, cluster, node, operation)
+	if err != nil {
+		return fmt.Errorf("%s node %d: %w", operation, node.ID, err)
+	}
+	if !result.Success {
+		return fmt.Errorf("%s node %d: %s", operation, node.ID, result.Message)
+	}
+	if host == nil || host.AgentID == "" {
+		return nil
+	}
+
+	readyCtx, cancel := context.WithTimeout(ctx, s.scaleNodeReadyTimeout)
+	defer cancel()
+	params := map[string]string{"role": checkProcessRole(node.Role)}
+	for {
+		running, _, err := s.agentSender.SendCommand(readyCtx, host.AgentID, "check_process", params)
+		if err == nil && running {
+			tracker.logf("node %d is running", node.ID)
+			return nil
+		}
+		select {
+		case <-readyCtx.Done():
+			return fmt.Errorf("node %d did not come back after %s: %w", node.ID, operation, readyCtx.Err())
+		case <-time.After(s.scalePollInterval):
+		}
+	}
+}
+
+// markScaleNodesFailed flags new nodes that did not finish so operators can retry or remove them.
+// markScaleNodesFailed 将未完成的新节点标记为错误，便于运维重试或移除。
+func (s *Service) markScaleNodesFailed(ctx context.Context, nodes []*ClusterNode) {
+	for _, node := range nodes {
+		current, err := s.repo.GetNodeByID(ctx, node.ID)
+		if err == nil && current.Status == NodeStatusInstalling {
+			_ = s.repo.UpdateNodeStatus(ctx, node.ID, NodeStatusError)
+		}
+	}
+}
+
+func (s *Service) loadScaleHosts(ctx context.Context, nodes []*ClusterNode) (map[uint]*HostInfo, error) {
+	hosts := make(map[uint]*HostInfo, len(nodes))
+	for _, node := range nodes {
+		if _, ok := hosts[node.HostID]; ok {
+			continue
+		}
+		host, err := s.hostProvider.GetHostByID(ctx, node.HostID)
+		if err != nil {
+			return nil, fmt.Errorf("get host %d: %w", node.HostID, err)
+		}
+		hosts[node.HostID] = host
+	}
+	return hosts, nil
+}
+
+// rollingRestartOrder starts new nodes first, then restarts workers and finally master-capable nodes.
+// rollingRestartOrder 先启动新节点，再重启 worker，最后重启可作为 master 的节点。
+func rollingRestartOrder(added, remaining []*ClusterNode) []*ClusterNode {
+	ordered := append([]*ClusterNode{}, added...)
+	existing := append([]*ClusterNode{}, remaining...)
+	sort.SliceStable(existing, func(i, j int) bool {
+		return !isMasterCapable(existing[i].Role) && isMasterCapable(existing[j].Role)
+	})
+	return append(ordered, existing...)
+}
+
+func checkProcessRole(role NodeRole) string {
+	switch role {
+	case NodeRoleMaster:
+		return "master"
+	case NodeRoleWorker:
+		return "worker"
+	default:
+		return "hybrid"
+	}
+}
+
+// ==================== Member list rendering 成员列表生成 ====================
+
+// scaleMembers holds the hazelcast addresses of the cluster after scaling.
+// scaleMembers 保存扩缩容后集群的 hazelcast 地址。
+type scaleMembers struct {
+	// server lists every member; client lists master-capable members.
+	// server 包含所有成员；client 仅包含可作为 master 的成员。
+	server []string
+	client []string
+}
+
+func buildScaleMembers(nodes []*ClusterNode, hosts map[uint]*HostInfo) (*scaleMembers, error) {
+	ordered := append([]*ClusterNode{}, nodes...)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return isMasterCapable(ordered[i].Role) && !isMasterCapable(ordered[j].Role)
+	})
+	members := &scaleMembers{}
+	for _, node := range ordered {
+		host := hosts[node.HostID]
+		if host == nil || strings.TrimSpace(host.IPAddress) == "" {
+			return nil, fmt.Errorf("host %d has no IP address", node.HostID)
+		}
+		address := fmt.Sprintf("%s:%d", host.IPAddress, node.HazelcastPort)
+		members.server = append(members.server, address)
+		if isMasterCapable(node.Role) {
+			members.client = append(members.client, address)
+		}
+	}
+	return members, nil
+}
+
+// hazelcastConfigType returns the hazelcast member config file of a node role.
+// hazelcastConfigType 返回节点角色对应的 hazelcast 成员配置文件。
+func hazelcastConfigType(mode DeploymentMode, role NodeRole) appconfig.ConfigType {
+	if mode == DeploymentModeSeparated {
+		if role == NodeRoleWorker {
+			return appconfig.ConfigTypeHazelcastWorker
+		}
+		return appconfig.ConfigTypeHazelcastMaster
+	}
+	return appconfig.ConfigTypeHazelcast
+}
+
+func renderScaleNodeConfig(configType appconfig.ConfigType, content string, node *ClusterNode, members *scaleMembers) (string, error) {
+	switch configType {
+	case appconfig.ConfigTypeHazelcast, appconfig.ConfigTypeHazelcastMaster, appconfig.ConfigTypeHazelcastWorker:
+		return setHazelcastMembers(content, members.server, node.HazelcastPort)
+	case appconfig.ConfigTypeHazelcastClient:
+		return setHazelcastClientMembers(content, members.client)
+	case appconfig.ConfigTypeSeatunnel:
+		if node.APIPort > 0 {
+			return updateSeatunnelHTTPPort(content, node.APIPort)
+		}
+	}
+	return content, nil
+}
+
+// setHazelcastMembers sets hazelcast.network.join.tcp-ip.member-list and, when port > 0, the member port.
+// setHazelcastMembers 设置 hazelcast.network.join.tcp-ip.member-list，port > 0 时同时设置成员端口。
+func setHazelcastMembers(content string, members []string, port int) (string, error) {
+	root, top, err := parseYAMLDocument(content)
+	if err != nil {
+		return "", err
+	}
+	network := ensureYAMLMapChild(ensureYAMLMapChild(top, "hazelcast"), "network")
+	tcpIP := ensureYAMLMapChild(ensureYAMLMapChild(network, "join"), "tcp-ip")
+	if sameYAMLSequence(tcpIP, "member-list", members) && port <= 0 {
+		return content, nil
+	}
+	setYAMLMapSequence(tcpIP, "member-list", members)
+	if port > 0 {
+		setYAMLMapValue(ensureYAMLMapChild(network, "port"), "port", strconv.Itoa(port))
+	}
+	return marshalYAMLDocument(root)
+}
+
+// setHazelcastClientMembers sets hazelcast-client.network.cluster-members.
+// setHazelcastClientMembers 设置 hazelcast-client.network.cluster-members。
+func setHazelcastClientMembers(content string, members []string) (string, error) {
+	root, top, err := parseYAMLDocument(content)
+	if err != nil {
+		return "", err
+	}
+	network := ensureYAMLMapChild(ensureYAMLMapChild(top, "hazelcast-client"), "network")
+	if sameYAMLSequence(network, "cluster-members", members) {
+		return content, nil
+	}
+	setYAMLMapSequence(network, "cluster-members", members)
+	return marshalYAMLDocument(root)
+}
+
+func parseYAMLDocument(content string) (*yaml.Node, *yaml.Node, error) {
+	var root yaml.Node
+	if err := yaml.Unmarshal([]byte(content), &root); err != nil {
+		return nil, nil, fmt.Errorf("invalid yaml: %w / 非法 yaml: %w", err, err)
+	}
+	if len(root.Content) == 0 {
+		root.Kind = yaml.DocumentNode
+		root.Content = []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}
+	}
+	return &root, root.Content[0], nil
+}
+
+func marshalYAMLDocument(root *yaml.Node) (string, error) {
+	out, err := yaml.Marshal(root)
+	if err != nil {
+		return "", fmt.Errorf("marshal yaml failed: %w / 序列化 yaml 失败: %w", err, err)
+	}
+	return string(out), nil
+}
+
+func sameYAMLSequence(parent *yaml.Node, key string, values []string) bool {
+	if parent.Kind != yaml.MappingNode {
+		return false
+	}
+	for i := 0; i < len(parent.Content)-1; i += 2 {
+		if parent.Content[i].Value != key {
+			continue
+		}
+		seq := parent.Content[i+1]
+		if seq.Kind != yaml.SequenceNode || len(seq.Content) != len(values) {
+			return false
+		}
+		for j, item := range seq.Content {
+			if item.Value != values[j] {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+func setYAMLMapSequence(parent *yaml.Node, key string, values []string) {
+	seq := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+	for _, value := range values {
+		seq.Content = append(seq.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value})
+	}
+	for i := 0; i < len(parent.Content)-1; i += 2 {
+		if parent.Content[i].Value == key {
+			parent.Content[i+1] = seq
+			return
+		}
+	}
+	parent.Content = append(parent.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, seq)
+}
+
+// ==================== Task tracking 任务跟踪 ====================
+
+// scaleTracker persists the step and log of a running scale task.
+// scaleTracker 持久化执行中扩缩容任务的步骤与日志。
+type scaleTracker struct {
+	repo *Repository
+	task *ScaleTask
+}
+
+func (t *scaleTracker) start() {
+	now := time.Now()
+	t.task.Status = ScaleTaskStatusRunning
+	t.task.StartedAt = &now
+	t.save()
+}
+
+func (t *scaleTracker) step(step string) {
+	t.task.Step = step
+	t.logf("==> %s", step)
+}
+
+func (t *scaleTracker) logf(format string, args ...interface{}) {
+	line := fmt.Sprintf("%s %s\n", time.Now().Format("15:04:05"), fmt.Sprintf(format, args...))
+	t.task.Log += line
+	if len(t.task.Log) > maxScaleTaskLogBytes {
+		t.task.Log = t.task.Log[len(t.task.Log)-maxScaleTaskLogBytes:]
+	}
+	logger.InfoF(context.Background(), "[ClusterScale] cluster=%d task=%d %s", t.task.ClusterID, t.task.ID, strings.TrimSpace(line))
+	t.save()
+}
+
+func (t *scaleTracker) finish(err error) {
+	now := time.Now()
+	t.task.FinishedAt = &now
+	if err != nil {
+		t.task.Status = ScaleTaskStatusFailed
+		t.task.Error = err.Error()
+		t.logf("failed: %v", err)
+		return
+	}
+	t.task.Status = ScaleTaskStatusSuccess
+	t.save()
+}
+
+func (t *scaleTracker) save() {
+	if err := t.repo.UpdateScaleTask(context.Background(), t.task); err != nil {
+		logger.WarnF(context.Background(), "[ClusterScale] save task %d failed: %v", t.task.ID, err)
+	}
+}
+
+// ==================== Repository 数据访问 ====================
+
+// CreateScaleTask creates a scale task record.
+// CreateScaleTask 创建扩缩容任务记录。
+func (r *Repository) CreateScaleTask(ctx context.Context, task *ScaleTask) error {
+	return r.db.WithContext(ctx).Create(task).Error
+}
+
+// UpdateScaleTask saves a scale task record.
+// UpdateScaleTask 保存扩缩容任务记录。
+func (r *Repository) UpdateScaleTask(ctx context.Context, task *ScaleTask) error {
+	return r.db.WithContext(ctx).Save(task).Error
+}
+
+// GetScaleTask retrieves a scale task of a cluster.
+// GetScaleTask 获取集群的扩缩容任务。
+func (r *Repository) GetScaleTask(ctx context.Context, clusterID, taskID uint) (*ScaleTask, error) {
+	var task ScaleTask
+	if err := r.db.WithContext(ctx).Where("id = ? AND cluster_id = ?", taskID, clusterID).First(&task).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrScaleTaskNotFound
+		}
+		return nil, err
+	}
+	return &task, nil
+}
+
+// ListScaleTasks lists the most recent scale tasks of a cluster without logs.
+// ListScaleTasks 获取集群最近的扩缩容任务（不含日志）。
+func (r *Repository) ListScaleTasks(ctx context.Context, clusterID uint, limit int) ([]*ScaleTask, error) {
+	var tasks []*ScaleTask
+	err := r.db.WithContext(ctx).Omit("log").Where("cluster_id = ?", clusterID).
+		Order("id DESC").Limit(limit).Find(&tasks).Error
+	return tasks, err
+}
+
+// HasActiveScaleTask reports whether the cluster has a pending or running scale task.
+// HasActiveScaleTask 判断集群是否有待执行或执行中的扩缩容任务。
+func (r *Repository) HasActiveScaleTask(ctx context.Context, clusterID uint) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&ScaleTask{}).
+		Where("cluster_id = ? AND status IN ?", clusterID, []ScaleTaskStatus{ScaleTaskStatusPending, ScaleTaskStatusRunning}).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// FailActiveScaleTasks marks every pending or running scale task as failed.
+// FailActiveScaleTasks 将所有待执行或执行中的扩缩容任务标记为失败。
+func (r *Repository) FailActiveScaleTasks(ctx context.Context, reason string) error {
+	return r.db.WithContext(ctx).Model(&ScaleTask{}).
+		Where("status IN ?", []ScaleTaskStatus{ScaleTaskStatusPending, ScaleTaskStatusRunning}).
+		Updates(map[string]interface{}{"status": ScaleTaskStatusFailed, "error": reason, "finished_at": time.Now()}).Error
+}
@@ -0,0 +1,258 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cluster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	appconfig "github.com/seatunnel/seatunnelX/internal/apps/config"
+	installerapp "github.com/seatunnel/seatunnelX/internal/apps/installer"
+)
+
+type fakeNodeInstaller struct {
+	mu       sync.Mutex
+	requests []*installerapp.InstallationRequest
+}
+
+func (f *fakeNodeInstaller) RunPrecheck(ctx context.Context, hostID uint, req *installerapp.PrecheckRequest) (*installerapp.PrecheckResult, error) {
+	return &installerapp.PrecheckResult{OverallStatus: installerapp.CheckStatusPassed}, nil
+}
+
+func (f *fakeNodeInstaller) StartInstallation(ctx context.Context, req *installerapp.InstallationRequest) (*installerapp.InstallationStatus, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.requests = append(f.requests, req)
+	return &installerapp.InstallationStatus{HostID: req.HostID, Status: installerapp.StepStatusRunning}, nil
+}
+
+func (f *fakeNodeInstaller) GetInstallationStatus(ctx context.Context, hostID uint) (*installerapp.InstallationStatus, error) {
+	return &installerapp.InstallationStatus{Status: installerapp.StepStatusSuccess}, nil
+}
+
+type fakeClusterConfigStore struct {
+	templates map[appconfig.ConfigType]string
+	inits     []uint
+}
+
+func (f *fakeClusterConfigStore) GetTemplateContent(ctx context.Context, clusterID uint, configType appconfig.ConfigType) (string, error) {
+	content, ok := f.templates[configType]
+	if !ok {
+		return "", appconfig.ErrTemplateNotFound
+	}
+	return content, nil
+}
+
+func (f *fakeClusterConfigStore) InitClusterConfigs(ctx context.Context, clusterID uint, hostID uint, installDir string, userID uint) error {
+	f.inits = append(f.inits, hostID)
+	return nil
+}
+
+// fileConfigAgentClient keeps one file per host and config type.
+// fileConfigAgentClient 按主机和配置类型保存文件内容。
+type fileConfigAgentClient struct {
+	files map[string]string
+}
+
+func (f *fileConfigAgentClient) key(hostID uint, configType appconfig.ConfigType) string {
+	return fmt.Sprintf("%d/%s", hostID, configType)
+}
+
+func (f *fileConfigAgentClient) PullConfig(ctx context.Context, hostID uint, installDir string, configType appconfig.ConfigType) (string, error) {
+	return f.files[f.key(hostID, configType)], nil
+}
+
+func (f *fileConfigAgentClient) PushConfig(ctx context.Context, hostID uint, installDir string, configType appconfig.ConfigType, content string) error {
+	f.files[f.key(hostID, configType)] = content
+	return nil
+}
+
+const scaleTestHazelcast = `hazelcast:
+  cluster-name: seatunnel
+  network:
+    join:
+      tcp-ip:
+        enabled: true
+        member-list:
+          - 10.0.0.1:5801
+    port:
+      auto-increment: false
+      port: 5801
+`
+
+const scaleTestHazelcastClient = `hazelcast-client:
+  cluster-name: seatunnel
+  network:
+    cluster-members:
+      - 10.0.0.1:5801
+`
+
+func newScaleTestService(t *testing.T) (*Service, *Repository, *MockHostProvider) {
+	t.Helper()
+	db, cleanup := setupServiceTestDB(t)
+	t.Cleanup(cleanup)
+	if err := db.AutoMigrate(&ScaleTask{}); err != nil {
+		t.Fatalf("Failed to migrate scale tasks: %v", err)
+	}
+
+	repo := NewRepository(db)
+	hosts := NewMockHostProvider()
+	now := time.Now()
+	for i := uint(1); i <= 3; i++ {
+		hosts.AddHost(&HostInfo{
+			ID:            i,
+			Name:          fmt.Sprintf("host-%d", i),
+			HostType:      "bare_metal",
+			IPAddress:     fmt.Sprintf("10.0.0.%d", i),
+			AgentID:       fmt.Sprintf("agent-%d", i),
+			AgentStatus:   "installed",
+			LastHeartbeat: &now,
+		})
+	}
+
+	svc := NewService(repo, hosts, nil)
+	svc.scaleSettleDelay = 0
+	svc.scalePollInterval = 10 * time.Millisecond
+	return svc, repo, hosts
+}
+
+func TestService_StartScale_addsNodeAndUpdatesMemberLists(t *testing.T) {
+	svc, repo, _ := newScaleTestService(t)
+	ctx := context.Background()
+
+	installer := &fakeNodeInstaller{}
+	store := &fakeClusterConfigStore{templates: map[appconfig.ConfigType]string{
+		appconfig.ConfigTypeHazelcast:       scaleTestHazelcast,
+		appconfig.ConfigTypeHazelcastClient: scaleTestHazelcastClient,
+	}}
+	files := &fileConfigAgentClient{files: map[string]string{
+		"1/hazelcast.yaml":        scaleTestHazelcast,
+		"1/hazelcast-client.yaml": scaleTestHazelcastClient,
+	}}
+	agentSender := &mockOperationAgentSender{processStopped: true}
+	svc.SetAgentCommandSender(agentSender)
+	svc.SetConfigAgentClient(files)
+	svc.SetNodeInstaller(installer)
+	svc.SetClusterConfigStore(store)
+
+	cluster, err := svc.Create(ctx, &CreateClusterRequest{
+		Name:           "scale-cluster",
+		DeploymentMode: DeploymentModeHybrid,
+		Version:        "2.3.12",
+		InstallDir:     "/opt/seatunnel",
+	})
+	if err != nil {
+		t.Fatalf("Create returned error: %v", err)
+	}
+	if _, err := svc.AddNode(ctx, cluster.ID, &AddNodeRequest{HostID: 1, Role: NodeRoleMasterWorker, SkipPrecheck: true}); err != nil {
+		t.Fatalf("AddNode returned error: %v", err)
+	}
+
+	if _, err := svc.StartScale(ctx, cluster.ID, &ScaleClusterRequest{AddNodes: []ScaleNodeRequest{{HostID: 2}}}, "admin"); !errors.Is(err, ErrClusterNotRunning) {
+		t.Fatalf("expected ErrClusterNotRunning, got %v", err)
+	}
+	// From here on the restarted nodes must be seen running / 此后重启的节点需要被探测为运行中
+	agentSender.processStopped = false
+	if err := repo.UpdateStatus(ctx, cluster.ID, ClusterStatusRunning); err != nil {
+		t.Fatalf("UpdateStatus returned error: %v", err)
+	}
+	if _, err := svc.StartScale(ctx, cluster.ID, &ScaleClusterRequest{}, "admin"); !errors.Is(err, ErrScaleRequestEmpty) {
+		t.Fatalf("expected ErrScaleRequestEmpty, got %v", err)
+	}
+	if _, err := svc.StartScale(ctx, cluster.ID, &ScaleClusterRequest{AddNodes: []ScaleNodeRequest{{HostID: 1}}}, "admin"); !errors.Is(err, ErrScaleDuplicateHost) {
+		t.Fatalf("expected ErrScaleDuplicateHost, got %v", err)
+	}
+
+	task, err := svc.StartScale(ctx, cluster.ID, &ScaleClusterRequest{AddNodes: []ScaleNodeRequest{{HostID: 2}}}, "admin")
+	if err != nil {
+		t.Fatalf("StartScale returned error: %v", err)
+	}
+	svc.scaleWG.Wait()
+
+	stored, err := svc.GetScaleTask(ctx, cluster.ID, task.ID)
+	if err != nil {
+		t.Fatalf("GetScaleTask returned error: %v", err)
+	}
+	if stored.Status != ScaleTaskStatusSuccess || stored.Step != ScaleStepComplete {
+		t.Fatalf("expected successful task, got status=%s step=%s error=%s\n%s", stored.Status, stored.Step, stored.Error, stored.Log)
+	}
+
+	if len(installer.requests) != 1 {
+		t.Fatalf("expected one installation, got %d", len(installer.requests))
+	}
+	installReq := installer.requests[0]
+	if !installReq.SkipNodeStart || installReq.HostID != "2" || strings.Join(installReq.MasterAddresses, ",") != "10.0.0.1,10.0.0.2" {
+		t.Fatalf("unexpected installation request %+v", installReq)
+	}
+
+	for _, hostID := range []uint{1, 2} {
+		hazelcast := files.files[fmt.Sprintf("%d/hazelcast.yaml", hostID)]
+		if !strings.Contains(hazelcast, "10.0.0.1:5801") || !strings.Contains(hazelcast, "10.0.0.2:5801") {
+			t.Fatalf("host %d hazelcast.yaml missing members:\n%s", hostID, hazelcast)
+		}
+		client := files.files[fmt.Sprintf("%d/hazelcast-client.yaml", hostID)]
+		if !strings.Contains(client, "10.0.0.2:5801") {
+			t.Fatalf("host %d hazelcast-client.yaml missing new member:\n%s", hostID, client)
+		}
+	}
+
+	var operations []string
+	for _, command := range agentSender.commands {
+		if command.commandType == string(OperationStart) || command.commandType == string(OperationRestart) ||
+			command.commandType == string(OperationStop) {
+			operations = append(operations, command.agentID+":"+command.commandType)
+		}
+	}
+	if strings.Join(operations, ",") != "agent-2:start,agent-1:restart" &&
+		strings.Join(operations, ",") != "agent-2:start,agent-1:stop,agent-1:start" {
+		t.Fatalf("unexpected rolling operations %v", operations)
+	}
+
+	nodes, err := repo.GetNodesByClusterID(ctx, cluster.ID)
+	if err != nil || len(nodes) != 2 {
+		t.Fatalf("expected 2 nodes after scaling, got %d (%v)", len(nodes), err)
+	}
+
+	// Removing the only remaining master-capable nodes is rejected.
+	// 移除所有可作为 master 的节点会被拒绝。
+	if _, err := svc.StartScale(ctx, cluster.ID, &ScaleClusterRequest{RemoveNodeIDs: []uint{nodes[0].ID, nodes[1].ID}}, "admin"); !errors.Is(err, ErrScaleRemoveNotAllowed) {
+		t.Fatalf("expected ErrScaleRemoveNotAllowed, got %v", err)
+	}
+}
+
+func TestSetHazelcastMembers_keepsOtherSettings(t *testing.T) {
+	updated, err := setHazelcastMembers(scaleTestHazelcast, []string{"10.0.0.1:5801", "10.0.0.2:5801"}, 5811)
+	if err != nil {
+		t.Fatalf("setHazelcastMembers returned error: %v", err)
+	}
+	for _, want := range []string{"cluster-name: seatunnel", "- 10.0.0.2:5801", "port: 5811", "auto-increment: false"} {
+		if !strings.Contains(updated, want) {
+			t.Fatalf("expected %q in:\n%s", want, updated)
+		}
+	}
+
+	unchanged, err := setHazelcastClientMembers(scaleTestHazelcastClient, []string{"10.0.0.1:5801"})
+	if err != nil || unchanged != scaleTestHazelcastClient {
+		t.Fatalf("expected client config to stay unchanged, got %q (%v)", unchanged, err)
+	}
+}
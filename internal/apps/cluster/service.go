@@ -25,11 +25,15 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	appconfig "github.com/seatunnel/seatunnelX/internal/apps/config"
 	installerapp "github.com/seatunnel/seatunnelX/internal/apps/installer"
+	"github.com/seatunnel/seatunnelX/internal/apps/task"
 	"github.com/seatunnel/seatunnelX/internal/logger"
+	"github.com/seatunnel/seatunnelX/internal/pkg/labelx"
+	"github.com/seatunnel/seatunnelX/internal/pkg/secrets"
 	"gopkg.in/yaml.v3"
 )
 
@@ -62,6 +66,9 @@ type HostInfo struct {
 	AgentStatus      string
 	LastHeartbeat    *time.Time
 	ProcessStartedAt *time.Time // when set, online requires heartbeat after this (e.g. API process start)
+	Labels           map[string]string
+	ProjectID        uint // owning project, 0 for shared / 所属项目，0 表示共享
+	Maintenance      bool // host is in maintenance mode / 主机处于维护模式
 }
 
 // IsOnline checks if the host is online based on heartbeat timeout.
@@ -86,6 +93,14 @@ type HostProvider interface {
 	GetHostByID(ctx context.Context, id uint) (*HostInfo, error)
 }
 
+// ConfigDriftProvider reports config files that differ between the Control Plane and nodes.
+// ConfigDriftProvider 提供控制面与节点之间不一致的配置文件信息。
+type ConfigDriftProvider interface {
+	// GetDriftedConfigTypes returns drifted config types grouped by host ID.
+	// GetDriftedConfigTypes 返回按主机 ID 分组的已漂移配置类型。
+	GetDriftedConfigTypes(ctx context.Context, clusterID uint) (map[uint][]string, error)
+}
+
 // ClusterStatusInfo represents detailed cluster status information.
 // ClusterStatusInfo 表示详细的集群状态信息。
 type ClusterStatusInfo struct {
@@ -96,6 +111,7 @@ type ClusterStatusInfo struct {
 	TotalNodes   int               `json:"total_nodes"`
 	OnlineNodes  int               `json:"online_nodes"`
 	OfflineNodes int               `json:"offline_nodes"`
+	DriftedNodes int               `json:"drifted_nodes"` // Nodes whose config differs from the stored version / 配置与已保存版本不一致的节点数
 	Nodes        []*NodeStatusInfo `json:"nodes"`
 }
 
@@ -110,6 +126,9 @@ type NodeStatusInfo struct {
 	Status     NodeStatus `json:"status"`      // Unified status: pending, installing, running, stopped, error / 统一状态
 	IsOnline   bool       `json:"is_online"`   // Whether host is online / 主机是否在线
 	ProcessPID int        `json:"process_pid"` // SeaTunnel process PID / SeaTunnel 进程 PID
+
+	ConfigDrifted  bool     `json:"config_drifted"`            // Whether node config files drifted / 节点配置文件是否漂移
+	DriftedConfigs []string `json:"drifted_configs,omitempty"` // Drifted config types / 已漂移的配置类型
 }
 
 // OperationType represents the type of cluster operation.
@@ -136,6 +155,9 @@ type OperationResult struct {
 	Success     bool                   `json:"success"`
 	Message     string                 `json:"message"`
 	NodeResults []*NodeOperationResult `json:"node_results"`
+	// Phases reports the ordered phases of a separated-mode operation.
+	// Phases 报告分离模式操作的有序阶段。
+	Phases []*OperationPhaseResult `json:"phases,omitempty"`
 }
 
 // NodeOperationResult represents the result of an operation on a single node.
@@ -174,13 +196,49 @@ type Service struct {
 	agentSender              AgentCommandSender
 	configAgentClient        ConfigAgentClient
 	onBeforeClusterDelete    func(context.Context, uint) // optional hook for monitor cleanup etc.
+	pluginRecordReleaser     PluginRecordReleaser        // optional hook releasing installed-plugin records
 	onClusterTopologyChanged func(context.Context, uint) // optional hook for observability sync etc.
+
+	// Node health checker state / 节点健康检查状态
+	onRecoveryEscalation func(context.Context, *RecoveryEscalation) // optional hook for audit on failed auto recovery
+	healthCheckInterval  time.Duration
+	healthCheckRuntime   sync.Once
+	healthMu             sync.Mutex
+	nodeHealth           map[uint]*NodeHealth
+
+	// Cluster scaling state / 集群扩缩容状态
+	nodeInstaller         NodeInstaller
+	configStore           ClusterConfigStore
+	scaleMu               sync.Mutex
+	scaleWG               sync.WaitGroup
+	scaleSettleDelay      time.Duration
+	scalePollInterval     time.Duration
+	scaleInstallTimeout   time.Duration
+	scaleNodeReadyTimeout time.Duration
+	rejoinPollInterval    time.Duration
+	phaseTimeout          time.Duration
+	drainClient           DrainEngineClient
+	drainPollInterval     time.Duration
+
+	// Config drift state provider / 配置漂移状态提供者
+	configDriftProvider ConfigDriftProvider
+	// Recycle bin state / 回收站状态
+	onClusterPurged      func(context.Context, *Cluster, *TeardownReport, error) // optional hook for audit on purge
+	recycleRetention     time.Duration
+	recyclePurgeInterval time.Duration
+	recyclePurgeRuntime  sync.Once
+
+	// Task tracking of cluster operations (optional) / 集群操作的任务跟踪（可选）
+	taskManager *task.Manager
 }
 
 // ServiceConfig holds configuration for the Cluster Service.
 // ServiceConfig 保存 Cluster Service 的配置。
 type ServiceConfig struct {
-	HeartbeatTimeout time.Duration
+	HeartbeatTimeout     time.Duration
+	HealthCheckInterval  time.Duration
+	RecycleRetention     time.Duration
+	RecyclePurgeInterval time.Duration
 }
 
 // NewService creates a new Service instance.
@@ -190,11 +248,37 @@ func NewService(repo *Repository, hostProvider HostProvider, cfg *ServiceConfig)
 	if cfg != nil && cfg.HeartbeatTimeout > 0 {
 		timeout = cfg.HeartbeatTimeout
 	}
+	healthCheckInterval := DefaultHealthCheckInterval
+	if cfg != nil && cfg.HealthCheckInterval > 0 {
+		healthCheckInterval = cfg.HealthCheckInterval
+	}
+	recycleRetention := DefaultRecycleRetention
+	if cfg != nil && cfg.RecycleRetention > 0 {
+		recycleRetention = cfg.RecycleRetention
+	}
+	recyclePurgeInterval := DefaultRecyclePurgeInterval
+	if cfg != nil && cfg.RecyclePurgeInterval > 0 {
+		recyclePurgeInterval = cfg.RecyclePurgeInterval
+	}
 
 	return &Service{
-		repo:             repo,
-		hostProvider:     hostProvider,
-		heartbeatTimeout: timeout,
+		repo:                repo,
+		hostProvider:        hostProvider,
+		heartbeatTimeout:    timeout,
+		healthCheckInterval: healthCheckInterval,
+		nodeHealth:          make(map[uint]*NodeHealth),
+
+		recycleRetention:     recycleRetention,
+		recyclePurgeInterval: recyclePurgeInterval,
+
+		scaleSettleDelay:      defaultScaleSettleDelay,
+		scalePollInterval:     defaultScalePollInterval,
+		scaleInstallTimeout:   defaultScaleInstallTimeout,
+		scaleNodeReadyTimeout: defaultScaleNodeReadyTimeout,
+		rejoinPollInterval:    defaultRollingRejoinPoll,
+		phaseTimeout:          defaultOperationPhaseTimeout,
+		drainClient:           NewHTTPDrainEngineClient(),
+		drainPollInterval:     defaultDrainPollInterval,
 	}
 }
 
@@ -210,6 +294,12 @@ func (s *Service) SetConfigAgentClient(client ConfigAgentClient) {
 	s.configAgentClient = client
 }
 
+// SetConfigDriftProvider sets the provider used to flag drifted nodes in the cluster status.
+// SetConfigDriftProvider 设置用于在集群状态中标记配置漂移节点的提供者。
+func (s *Service) SetConfigDriftProvider(provider ConfigDriftProvider) {
+	s.configDriftProvider = provider
+}
+
 // SetOnBeforeClusterDelete sets an optional hook called before cluster DB deletion (e.g. monitor config cleanup).
 // SetOnBeforeClusterDelete 设置删除集群前可选钩子（如清理监控配置）。
 func (s *Service) SetOnBeforeClusterDelete(fn func(context.Context, uint)) {
@@ -255,6 +345,28 @@ func (s *Service) Create(ctx context.Context, req *CreateClusterRequest) (*Clust
 		return nil, ErrInvalidDeploymentMode
 	}
 
+	// Validate node selectors and check discovered nodes against them before creating anything
+	// 校验节点选择器，并在创建前检查发现的节点是否满足
+	if err := validateNodeSelectors(req.DeploymentMode, req.NodeSelectors); err != nil {
+		return nil, err
+	}
+	if len(req.NodeSelectors) > 0 {
+		draft := &Cluster{Name: req.Name, DeploymentMode: req.DeploymentMode, NodeSelectors: req.NodeSelectors, ProjectID: req.ProjectID}
+		for _, nodeReq := range req.Nodes {
+			role, err := normalizeNodeRoleForDeployment(req.DeploymentMode, discoveryNodeRole(nodeReq.Role))
+			if err != nil {
+				continue
+			}
+			hostInfo, err := s.getHostForPlacement(ctx, nodeReq.HostID)
+			if err != nil {
+				return nil, err
+			}
+			if err := checkNodePlacement(draft, hostInfo, role); err != nil {
+				return nil, err
+			}
+		}
+	}
+
 	// Create cluster
 	// 创建集群
 	cluster := &Cluster{
@@ -263,8 +375,11 @@ func (s *Service) Create(ctx context.Context, req *CreateClusterRequest) (*Clust
 		DeploymentMode: req.DeploymentMode,
 		Version:        req.Version,
 		Status:         ClusterStatusCreated,
+		Source:         ClusterSourceInstalled,
 		InstallDir:     req.InstallDir,
 		Config:         req.Config,
+		NodeSelectors:  req.NodeSelectors,
+		ProjectID:      req.ProjectID,
 	}
 
 	if err := s.repo.Create(ctx, cluster); err != nil {
@@ -280,18 +395,7 @@ func (s *Service) Create(ctx context.Context, req *CreateClusterRequest) (*Clust
 		for _, nodeReq := range req.Nodes {
 			// Convert role string to NodeRole
 			// 将角色字符串转换为 NodeRole
-			var role NodeRole
-			switch nodeReq.Role {
-			case "master":
-				role = NodeRoleMaster
-			case "worker":
-				role = NodeRoleWorker
-			case "hybrid":
-				role = NodeRoleMasterWorker
-			default:
-				// Default to hybrid if unknown role / 未知角色默认为混合模式
-				role = NodeRoleMasterWorker
-			}
+			role := discoveryNodeRole(nodeReq.Role)
 
 			// Use discovered ports if available, otherwise use defaults
 			// 如果有发现的端口则使用，否则使用默认值
@@ -439,7 +543,16 @@ func (s *Service) Update(ctx context.Context, id uint, req *UpdateClusterRequest
 	}
 
 	if req.Config != nil {
-		cluster.Config = *req.Config
+		// Clients echo back the masked credentials they were shown; keep the stored values for those.
+		// 客户端会回传展示给它的掩码凭证，这些字段保留已保存的值。
+		cluster.Config = secrets.RestoreMasked(*req.Config, cluster.Config)
+	}
+
+	if req.NodeSelectors != nil {
+		if err := validateNodeSelectors(cluster.DeploymentMode, *req.NodeSelectors); err != nil {
+			return nil, err
+		}
+		cluster.NodeSelectors = *req.NodeSelectors
 	}
 
 	if err := s.repo.Update(ctx, cluster); err != nil {
@@ -452,104 +565,51 @@ func (s *Service) Update(ctx context.Context, id uint, req *UpdateClusterRequest
 }
 
 // Delete removes a cluster after checking for running tasks.
-// Before DB deletion it sends stop to all nodes' agents (best effort). If forceRemoveInstallDir is true, also sends REMOVE_INSTALL_DIR to each agent.
-// Delete 在检查运行中的任务后删除集群；删除前向各节点 Agent 发送停止命令；若 forceRemoveInstallDir 为 true 则再发送删除安装目录命令。
+// Before DB deletion it tears down every node (stop process, remove runtime artifacts, and REMOVE_INSTALL_DIR
+// when forceRemoveInstallDir is true), releases installed-plugin records and returns the teardown report.
+// Delete 在检查运行中的任务后删除集群；删除前拆除每个节点（停止进程、移除运行时产物，forceRemoveInstallDir 为 true 时删除安装目录），
+// 释放已安装插件记录并返回拆除报告。
 // Requirements: 7.5 - Checks if cluster has running tasks before deletion.
-func (s *Service) Delete(ctx context.Context, id uint, forceRemoveInstallDir bool) error {
+func (s *Service) Delete(ctx context.Context, id uint, forceRemoveInstallDir bool) (*TeardownReport, error) {
 	// Get cluster to check status
 	// 获取集群以检查状态
 	cluster, err := s.repo.GetByID(ctx, id, false)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Check if cluster has running tasks (deploying or running status)
 	// 检查集群是否有运行中的任务（部署中或运行中状态）
 	if cluster.Status == ClusterStatusDeploying || cluster.Status == ClusterStatusRunning {
-		return ErrClusterHasRunningTask
+		return nil, ErrClusterHasRunningTask
 	}
 
-	// Get cluster with nodes to send stop to each node's agent (best effort)
-	// 获取带节点的集群，向各节点 Agent 发送停止命令（尽力而为）
+	// Tear down member nodes before the row goes away (best effort, recorded per node)
+	// 删除记录前拆除成员节点（尽力而为，逐节点记录结果）
 	clusterWithNodes, err := s.repo.GetByID(ctx, id, true)
-	if err == nil && len(clusterWithNodes.Nodes) > 0 {
-		s.stopProcessesForDeletion(ctx, clusterWithNodes)
-		if forceRemoveInstallDir {
-			s.removeInstallDirOnAgents(ctx, clusterWithNodes)
-		}
+	if err != nil {
+		clusterWithNodes = cluster
 	}
+	return s.destroyCluster(ctx, clusterWithNodes, forceRemoveInstallDir)
+}
+
+// destroyCluster tears down the nodes of a cluster and permanently removes its records.
+// destroyCluster 拆除集群的节点并永久删除其记录。
+func (s *Service) destroyCluster(ctx context.Context, cluster *Cluster, removeInstallDir bool) (*TeardownReport, error) {
+	report := s.teardownCluster(ctx, cluster, removeInstallDir)
 
 	// Optional hook (e.g. delete monitor config and events for this cluster)
 	// 可选钩子（如删除该集群的监控配置与事件）
 	if s.onBeforeClusterDelete != nil {
-		s.onBeforeClusterDelete(ctx, id)
-	}
-
-	if err := s.repo.Delete(ctx, id); err != nil {
-		return err
+		s.onBeforeClusterDelete(ctx, cluster.ID)
 	}
 
-	s.notifyClusterTopologyChanged(ctx, id)
-	return nil
-}
-
-// stopProcessesForDeletion sends stop command to each node's agent so actual SeaTunnel processes are stopped.
-// Best effort: logs errors but does not fail the deletion.
-// stopProcessesForDeletion 向各节点 Agent 发送停止命令以停止主机上的 SeaTunnel 进程；尽力而为，不阻断删除。
-func (s *Service) stopProcessesForDeletion(ctx context.Context, cluster *Cluster) {
-	if s.hostProvider == nil || s.agentSender == nil {
-		logger.WarnF(ctx, "[Cluster] Delete: skip notifying agents (hostProvider or agentSender not set) / 删除集群：未通知 Agent（主机提供者或命令发送器未配置）")
-		return
-	}
-	for _, node := range cluster.Nodes {
-		hostInfo, err := s.hostProvider.GetHostByID(ctx, node.HostID)
-		if err != nil || hostInfo.AgentID == "" {
-			logger.WarnF(ctx, "[Cluster] Delete: skip node (no host or no agent) / 删除集群：跳过节点: node_id=%d, host_id=%d", node.ID, node.HostID)
-			continue
-		}
-		installDir := node.InstallDir
-		if installDir == "" {
-			installDir = cluster.InstallDir
-		}
-		params := map[string]string{
-			"cluster_id":  fmt.Sprintf("%d", cluster.ID),
-			"node_id":     fmt.Sprintf("%d", node.ID),
-			"role":        string(node.Role),
-			"install_dir": installDir,
-		}
-		logger.InfoF(ctx, "[Cluster] Delete: sending stop to agent / 删除集群：向 Agent 发送停止命令: agent_id=%s, node_id=%d", hostInfo.AgentID, node.ID)
-		_, _, err = s.agentSender.SendCommand(ctx, hostInfo.AgentID, string(OperationStop), params)
-		if err != nil {
-			logger.WarnF(ctx, "[Cluster] Delete: stop process on agent failed / 删除集群时向 Agent 发送停止失败: host_id=%d, node_id=%d, err=%v", node.HostID, node.ID, err)
-		}
+	if err := s.repo.Delete(ctx, cluster.ID); err != nil {
+		return report, err
 	}
-}
 
-// removeInstallDirOnAgents sends REMOVE_INSTALL_DIR to each node's agent so the install directory is removed on the host (force delete).
-// removeInstallDirOnAgents 向各节点 Agent 发送删除安装目录命令（强制删除时）。
-func (s *Service) removeInstallDirOnAgents(ctx context.Context, cluster *Cluster) {
-	if s.hostProvider == nil || s.agentSender == nil {
-		return
-	}
-	for _, node := range cluster.Nodes {
-		hostInfo, err := s.hostProvider.GetHostByID(ctx, node.HostID)
-		if err != nil || hostInfo.AgentID == "" {
-			continue
-		}
-		installDir := node.InstallDir
-		if installDir == "" {
-			installDir = cluster.InstallDir
-		}
-		if installDir == "" {
-			continue
-		}
-		params := map[string]string{"install_dir": installDir}
-		logger.InfoF(ctx, "[Cluster] Delete: sending remove_install_dir to agent / 删除集群：向 Agent 发送删除安装目录: agent_id=%s, install_dir=%s", hostInfo.AgentID, installDir)
-		_, _, err = s.agentSender.SendCommand(ctx, hostInfo.AgentID, "remove_install_dir", params)
-		if err != nil {
-			logger.WarnF(ctx, "[Cluster] Delete: remove_install_dir on agent failed / 删除集群时向 Agent 发送删除安装目录失败: host_id=%d, err=%v", node.HostID, err)
-		}
-	}
+	s.notifyClusterTopologyChanged(ctx, cluster.ID)
+	return report, nil
 }
 
 // UpdateStatus updates the status of a cluster.
@@ -659,6 +719,49 @@ func resolveNodePorts(role NodeRole, deploymentMode DeploymentMode, hazelcastPor
 	return hazelcastPort, apiPort, workerPort, nil
 }
 
+// checkNodePortConflicts rejects a node whose ports collide with each other or with the ports of
+// the cluster's other nodes on the same host, e.g. a master and a worker sharing one host.
+// checkNodePortConflicts 拒绝端口彼此冲突、或与集群中同一主机上其他节点端口冲突的节点，例如同机部署的 master 与 worker。
+func checkNodePortConflicts(node *ClusterNode, clusterNodes []*ClusterNode) error {
+	used := make(map[int]string)
+	for _, other := range clusterNodes {
+		if other == nil || other == node || other.HostID != node.HostID || (node.ID != 0 && other.ID == node.ID) {
+			continue
+		}
+		for _, port := range nodeListenPorts(other) {
+			used[port.port] = fmt.Sprintf("%s node %s", other.Role, port.name)
+		}
+	}
+	for _, port := range nodeListenPorts(node) {
+		if owner, ok := used[port.port]; ok {
+			return fmt.Errorf("%w: %s %d is already used by %s", ErrNodePortConflict, port.name, port.port, owner)
+		}
+		used[port.port] = fmt.Sprintf("%s node %s", node.Role, port.name)
+	}
+	return nil
+}
+
+type nodePort struct {
+	name string
+	port int
+}
+
+// nodeListenPorts returns the ports a node listens on; resolveNodePorts has already zeroed those its role does not use.
+// nodeListenPorts 返回节点监听的端口；resolveNodePorts 已将其角色不使用的端口置零。
+func nodeListenPorts(node *ClusterNode) []nodePort {
+	ports := make([]nodePort, 0, 3)
+	for _, port := range []nodePort{
+		{name: "hazelcast_port", port: node.HazelcastPort},
+		{name: "api_port", port: node.APIPort},
+		{name: "worker_port", port: node.WorkerPort},
+	} {
+		if port.port > 0 {
+			ports = append(ports, port)
+		}
+	}
+	return ports
+}
+
 func validateNodeOverrides(overrides NodeOverrides) error {
 	normalized := overrides.Normalize()
 	if normalized.JVM == nil {
@@ -678,20 +781,75 @@ func validateNodeOverrides(overrides NodeOverrides) error {
 	return nil
 }
 
-func (s *Service) ensureHostReady(ctx context.Context, hostID uint) error {
+func (s *Service) ensureHostReady(ctx context.Context, hostID uint) (*HostInfo, error) {
+	hostInfo, err := s.getHostForPlacement(ctx, hostID)
+	if err != nil || hostInfo == nil {
+		return nil, err
+	}
+
+	if hostInfo.HostType == "bare_metal" || hostInfo.HostType == "" {
+		if hostInfo.AgentStatus != "installed" {
+			return nil, ErrNodeAgentNotInstalled
+		}
+	}
+	return hostInfo, nil
+}
+
+// getHostForPlacement returns the host, or nil when no host provider is configured.
+// getHostForPlacement 返回主机信息；未配置主机提供者时返回 nil。
+func (s *Service) getHostForPlacement(ctx context.Context, hostID uint) (*HostInfo, error) {
 	if s.hostProvider == nil {
-		return nil
+		return nil, nil
 	}
+	return s.hostProvider.GetHostByID(ctx, hostID)
+}
 
-	hostInfo, err := s.hostProvider.GetHostByID(ctx, hostID)
-	if err != nil {
-		return err
+// discoveryNodeRole converts a discovered role string to a NodeRole, defaulting to hybrid.
+// discoveryNodeRole 将发现的角色字符串转换为 NodeRole，未知角色默认为混合模式。
+func discoveryNodeRole(role string) NodeRole {
+	switch role {
+	case "master":
+		return NodeRoleMaster
+	case "worker":
+		return NodeRoleWorker
+	default:
+		return NodeRoleMasterWorker
 	}
+}
 
-	if hostInfo.HostType == "bare_metal" || hostInfo.HostType == "" {
-		if hostInfo.AgentStatus != "installed" {
-			return ErrNodeAgentNotInstalled
+// validateNodeSelectors checks that every selector targets a role of the deployment mode and parses.
+// validateNodeSelectors 校验每个选择器的角色属于该部署模式且表达式可解析。
+func validateNodeSelectors(mode DeploymentMode, selectors NodeSelectors) error {
+	for role, expr := range selectors {
+		if normalized, err := normalizeNodeRoleForDeployment(mode, role); err != nil || normalized != role {
+			return fmt.Errorf("%w: role %q is not used in %s mode", ErrInvalidNodeSelector, role, mode)
 		}
+		if _, err := labelx.Parse(expr); err != nil {
+			return fmt.Errorf("%w: %s: %v", ErrInvalidNodeSelector, role, err)
+		}
+	}
+	return nil
+}
+
+// checkNodePlacement verifies that the host belongs to the cluster's project (or either is shared)
+// and that the host labels satisfy the cluster's selector for the role.
+// checkNodePlacement 校验主机属于集群所在项目（或任一方为共享），且主机标签满足集群对该角色的选择器。
+func checkNodePlacement(cluster *Cluster, hostInfo *HostInfo, role NodeRole) error {
+	if hostInfo != nil && hostInfo.ProjectID != 0 && cluster.ProjectID != 0 && hostInfo.ProjectID != cluster.ProjectID {
+		return fmt.Errorf("%w: host %q belongs to project %d, cluster to project %d",
+			ErrCrossProjectNode, hostInfo.Name, hostInfo.ProjectID, cluster.ProjectID)
+	}
+	expr := strings.TrimSpace(cluster.NodeSelectors[role])
+	if expr == "" || hostInfo == nil {
+		return nil
+	}
+	selector, err := labelx.Parse(expr)
+	if err != nil {
+		return fmt.Errorf("%w: %s: %v", ErrInvalidNodeSelector, role, err)
+	}
+	if unmatched := selector.Unmatched(hostInfo.Labels); len(unmatched) > 0 {
+		return fmt.Errorf("%w: host %q cannot run %s, unmatched: %s",
+			ErrNodeSelectorMismatch, hostInfo.Name, role, labelx.Selector(unmatched).String())
 	}
 	return nil
 }
@@ -793,7 +951,23 @@ func (c ClusterConfig) GetJVMConfig() *JVMConfig {
 		if value, ok := parseIntValue(typed["worker_heap_size"]); ok {
 			cfg.WorkerHeapSize = value
 		}
-		if cfg.HybridHeapSize == 0 && cfg.MasterHeapSize == 0 && cfg.WorkerHeapSize == 0 {
+		if value, ok := typed["gc"].(string); ok {
+			cfg.GC = value
+		}
+		if value, ok := parseIntValue(typed["max_direct_memory_mb"]); ok {
+			cfg.MaxDirectMemoryMB = value
+		}
+		switch extra := typed["extra_options"].(type) {
+		case []string:
+			cfg.ExtraOptions = append([]string(nil), extra...)
+		case []interface{}:
+			for _, item := range extra {
+				if option, ok := item.(string); ok {
+					cfg.ExtraOptions = append(cfg.ExtraOptions, option)
+				}
+			}
+		}
+		if cfg.IsEmpty() {
 			return nil
 		}
 		return cfg
@@ -806,7 +980,7 @@ func (c ClusterConfig) GetJVMConfig() *JVMConfig {
 		if err := json.Unmarshal(payload, &cfg); err != nil {
 			return nil
 		}
-		if cfg.HybridHeapSize == 0 && cfg.MasterHeapSize == 0 && cfg.WorkerHeapSize == 0 {
+		if cfg.IsEmpty() {
 			return nil
 		}
 		return &cfg
@@ -833,7 +1007,7 @@ func (n *ClusterNode) ResolveJVM(clusterConfig ClusterConfig) *JVMConfig {
 		}
 	}
 
-	if resolved.HybridHeapSize == 0 && resolved.MasterHeapSize == 0 && resolved.WorkerHeapSize == 0 {
+	if resolved.IsEmpty() {
 		return nil
 	}
 	return &resolved
@@ -848,7 +1022,8 @@ func (s *Service) AddNode(ctx context.Context, clusterID uint, req *AddNodeReque
 		return nil, err
 	}
 
-	if err := s.ensureHostReady(ctx, req.HostID); err != nil {
+	hostInfo, err := s.ensureHostReady(ctx, req.HostID)
+	if err != nil {
 		return nil, err
 	}
 
@@ -867,6 +1042,18 @@ func (s *Service) AddNode(ctx context.Context, clusterID uint, req *AddNodeReque
 		return nil, err
 	}
 
+	if err := checkNodePlacement(cluster, hostInfo, node.Role); err != nil {
+		return nil, err
+	}
+
+	clusterNodes, err := s.repo.GetNodesByClusterID(ctx, clusterID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkNodePortConflicts(node, clusterNodes); err != nil {
+		return nil, err
+	}
+
 	if err := s.repo.AddNode(ctx, node); err != nil {
 		return nil, err
 	}
@@ -896,7 +1083,13 @@ func (s *Service) AddNodes(ctx context.Context, clusterID uint, req *AddNodesReq
 		return nil, ErrNodeBatchEntriesRequired
 	}
 
-	if err := s.ensureHostReady(ctx, req.HostID); err != nil {
+	hostInfo, err := s.ensureHostReady(ctx, req.HostID)
+	if err != nil {
+		return nil, err
+	}
+
+	clusterNodes, err := s.repo.GetNodesByClusterID(ctx, clusterID)
+	if err != nil {
 		return nil, err
 	}
 
@@ -920,11 +1113,18 @@ func (s *Service) AddNodes(ctx context.Context, clusterID uint, req *AddNodesReq
 				return err
 			}
 
+			if err := checkNodePlacement(cluster, hostInfo, node.Role); err != nil {
+				return err
+			}
+
 			if _, exists := seenRoles[node.Role]; exists {
 				return ErrNodeAlreadyExists
 			}
 			seenRoles[node.Role] = struct{}{}
 
+			if err := checkNodePortConflicts(node, append(clusterNodes, createdNodes...)); err != nil {
+				return err
+			}
 			if err := tx.AddNode(ctx, node); err != nil {
 				return err
 			}
@@ -1018,6 +1218,7 @@ func (s *Service) GetNodes(ctx context.Context, clusterID uint) ([]*NodeInfo, er
 			if err == nil {
 				nodeInfo.HostName = hostInfo.Name
 				nodeInfo.HostIP = hostInfo.IPAddress
+				nodeInfo.HostMaintenance = hostInfo.Maintenance
 				nodeInfo.IsOnline = hostInfo.IsOnline(s.heartbeatTimeout)
 				if !nodeInfo.IsOnline {
 					nodeInfo.Status = NodeStatusOffline
@@ -1034,6 +1235,25 @@ func (s *Service) GetNodes(ctx context.Context, clusterID uint) ([]*NodeInfo, er
 	return nodeInfos, nil
 }
 
+// MaintenanceHostNames returns the names of the cluster's hosts that are in maintenance mode.
+// MaintenanceHostNames 返回集群中处于维护模式的主机名称。
+func (s *Service) MaintenanceHostNames(ctx context.Context, clusterID uint) ([]string, error) {
+	nodes, err := s.GetNodes(ctx, clusterID)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	seen := make(map[uint]bool)
+	for _, node := range nodes {
+		if !node.HostMaintenance || seen[node.HostID] {
+			continue
+		}
+		seen[node.HostID] = true
+		names = append(names, node.HostName)
+	}
+	return names, nil
+}
+
 // GetNode retrieves a specific node by ID.
 // GetNode 根据 ID 获取特定节点。
 func (s *Service) GetNode(ctx context.Context, nodeID uint) (*ClusterNode, error) {
@@ -1116,9 +1336,12 @@ func (s *Service) ResolveNodeJVMByClusterAndHostAndRole(ctx context.Context, clu
 	}
 
 	return &installerapp.JVMConfig{
-		HybridHeapSize: resolved.HybridHeapSize,
-		MasterHeapSize: resolved.MasterHeapSize,
-		WorkerHeapSize: resolved.WorkerHeapSize,
+		HybridHeapSize:    resolved.HybridHeapSize,
+		MasterHeapSize:    resolved.MasterHeapSize,
+		WorkerHeapSize:    resolved.WorkerHeapSize,
+		GC:                resolved.GC,
+		MaxDirectMemoryMB: resolved.MaxDirectMemoryMB,
+		ExtraOptions:      append([]string(nil), resolved.ExtraOptions...),
 	}, nil
 }
 
@@ -1208,6 +1431,14 @@ func (s *Service) UpdateNode(ctx context.Context, clusterID uint, nodeID uint, r
 	node.APIPort = resolvedAPIPort
 	node.WorkerPort = resolvedWorkerPort
 
+	clusterNodes, err := s.repo.GetNodesByClusterID(ctx, clusterID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkNodePortConflicts(node, clusterNodes); err != nil {
+		return nil, err
+	}
+
 	if req.Overrides != nil {
 		normalizedOverrides := req.Overrides.Normalize()
 		if err := validateNodeOverrides(normalizedOverrides); err != nil {
@@ -1380,13 +1611,25 @@ func (s *Service) GetStatus(ctx context.Context, clusterID uint) (*ClusterStatus
 	onlineCount := 0
 	offlineCount := 0
 
+	var driftedConfigs map[uint][]string
+	if s.configDriftProvider != nil {
+		if driftedConfigs, err = s.configDriftProvider.GetDriftedConfigTypes(ctx, clusterID); err != nil {
+			logger.WarnF(ctx, "[Cluster] get config drift failed: cluster=%d, err=%v", clusterID, err)
+		}
+	}
+
 	for i, node := range cluster.Nodes {
 		nodeStatus := &NodeStatusInfo{
-			NodeID:     node.ID,
-			HostID:     node.HostID,
-			Role:       node.Role,
-			Status:     node.Status,
-			ProcessPID: node.ProcessPID,
+			NodeID:         node.ID,
+			HostID:         node.HostID,
+			Role:           node.Role,
+			Status:         node.Status,
+			ProcessPID:     node.ProcessPID,
+			DriftedConfigs: driftedConfigs[node.HostID],
+		}
+		if len(nodeStatus.DriftedConfigs) > 0 {
+			nodeStatus.ConfigDrifted = true
+			statusInfo.DriftedNodes++
 		}
 
 		// Get host information and online status; when host is offline, show node status as offline
@@ -1476,21 +1719,27 @@ func (s *Service) IsClusterHealthy(ctx context.Context, clusterID uint) (bool, e
 // Start 启动集群中的所有节点。
 // Requirements: 6.1 - Executes SeaTunnel start script, waits for process startup, verifies process alive.
 func (s *Service) Start(ctx context.Context, clusterID uint) (*OperationResult, error) {
-	return s.executeOperation(ctx, clusterID, OperationStart)
+	return s.runOperationTask(ctx, task.TaskTypeStart, clusterID, nil, func(ctx context.Context) (*OperationResult, error) {
+		return s.executeOperation(ctx, clusterID, OperationStart)
+	})
 }
 
 // Stop stops all nodes in a cluster.
 // Stop 停止集群中的所有节点。
 // Requirements: 6.2 - Sends SIGTERM, waits for graceful shutdown (max 30s), sends SIGKILL if timeout.
 func (s *Service) Stop(ctx context.Context, clusterID uint) (*OperationResult, error) {
-	return s.executeOperation(ctx, clusterID, OperationStop)
+	return s.runOperationTask(ctx, task.TaskTypeStop, clusterID, nil, func(ctx context.Context) (*OperationResult, error) {
+		return s.executeOperation(ctx, clusterID, OperationStop)
+	})
 }
 
 // Restart restarts all nodes in a cluster.
 // Restart 重启集群中的所有节点。
 // Requirements: 6.3 - Executes stop first, waits for complete exit, then executes start.
 func (s *Service) Restart(ctx context.Context, clusterID uint) (*OperationResult, error) {
-	return s.executeOperation(ctx, clusterID, OperationRestart)
+	return s.runOperationTask(ctx, task.TaskTypeRestart, clusterID, nil, func(ctx context.Context) (*OperationResult, error) {
+		return s.executeOperation(ctx, clusterID, OperationRestart)
+	})
 }
 
 // executeOperation executes an operation on all nodes in a cluster.
@@ -1519,121 +1768,131 @@ func (s *Service) executeOperation(ctx context.Context, clusterID uint, operatio
 		}
 	}
 
-	// Execute operation on each node
-	// 在每个节点上执行操作
-	for _, node := range cluster.Nodes {
-		nodeResult := &NodeOperationResult{
-			NodeID: node.ID,
-			HostID: node.HostID,
+	// Execute operation on each node; separated clusters follow role dependencies
+	// 在每个节点上执行操作；分离模式集群按角色依赖顺序执行
+	if cluster.DeploymentMode == DeploymentModeSeparated {
+		s.executePhasedOperation(ctx, cluster, operation, result)
+	} else {
+		for i := range cluster.Nodes {
+			nodeResult := s.operateNode(ctx, cluster, &cluster.Nodes[i], operation)
+			result.NodeResults = append(result.NodeResults, nodeResult)
+			if !nodeResult.Success {
+				result.Success = false
+			}
 		}
+	}
 
-		// Get host information
-		// 获取主机信息
-		if s.hostProvider != nil {
-			hostInfo, err := s.hostProvider.GetHostByID(ctx, node.HostID)
-			if err != nil {
+	// Update cluster status based on overall result
+	// 根据整体结果更新集群状态
+	if result.Success {
+		switch operation {
+		case OperationStart, OperationRestart:
+			_ = s.repo.UpdateStatus(ctx, clusterID, ClusterStatusRunning)
+		case OperationStop:
+			_ = s.repo.UpdateStatus(ctx, clusterID, ClusterStatusStopped)
+		}
+		result.Message = "Operation completed successfully"
+	} else {
+		_ = s.repo.UpdateStatus(ctx, clusterID, ClusterStatusError)
+		result.Message = "Operation completed with errors"
+	}
+
+	return result, nil
+}
+
+// operateNode executes an operation on one node of a cluster and records the node status.
+// operateNode 在集群的单个节点上执行操作并记录节点状态。
+func (s *Service) operateNode(ctx context.Context, cluster *Cluster, node *ClusterNode, operation OperationType) *NodeOperationResult {
+	nodeResult := &NodeOperationResult{
+		NodeID: node.ID,
+		HostID: node.HostID,
+	}
+
+	// Get host information
+	// 获取主机信息
+	if s.hostProvider != nil {
+		hostInfo, err := s.hostProvider.GetHostByID(ctx, node.HostID)
+		if err != nil {
+			nodeResult.Success = false
+			nodeResult.Message = "Failed to get host information: " + err.Error()
+			return nodeResult
+		}
+
+		nodeResult.HostName = hostInfo.Name
+
+		// Check if host is online (for bare_metal hosts)
+		// 检查主机是否在线（对于物理机/VM 主机）
+		if hostInfo.HostType == "bare_metal" || hostInfo.HostType == "" {
+			if !hostInfo.IsOnline(s.heartbeatTimeout) {
 				nodeResult.Success = false
-				nodeResult.Message = "Failed to get host information: " + err.Error()
-				result.NodeResults = append(result.NodeResults, nodeResult)
-				result.Success = false
-				continue
+				nodeResult.Message = "Host is offline"
+				return nodeResult
 			}
 
-			nodeResult.HostName = hostInfo.Name
-
-			// Check if host is online (for bare_metal hosts)
-			// 检查主机是否在线（对于物理机/VM 主机）
-			if hostInfo.HostType == "bare_metal" || hostInfo.HostType == "" {
-				if !hostInfo.IsOnline(s.heartbeatTimeout) {
-					nodeResult.Success = false
-					nodeResult.Message = "Host is offline"
-					result.NodeResults = append(result.NodeResults, nodeResult)
-					result.Success = false
-					continue
+			// Send command to agent if sender is available
+			// 如果发送器可用，向 Agent 发送命令
+			if s.agentSender != nil && hostInfo.AgentID != "" {
+				installDir := node.InstallDir
+				if installDir == "" {
+					installDir = cluster.InstallDir
+				}
+				params := map[string]string{
+					"cluster_id":  fmt.Sprintf("%d", cluster.ID),
+					"node_id":     fmt.Sprintf("%d", node.ID),
+					"role":        string(node.Role),
+					"install_dir": installDir,
+				}
+				if operation == OperationStart || operation == OperationRestart {
+					s.addMembershipVerificationParams(ctx, params, node, hostInfo)
 				}
 
-				// Send command to agent if sender is available
-				// 如果发送器可用，向 Agent 发送命令
-				if s.agentSender != nil && hostInfo.AgentID != "" {
-					installDir := node.InstallDir
-					if installDir == "" {
-						installDir = cluster.InstallDir
-					}
-					params := map[string]string{
-						"cluster_id":  fmt.Sprintf("%d", clusterID),
-						"node_id":     fmt.Sprintf("%d", node.ID),
-						"role":        string(node.Role),
-						"install_dir": installDir,
-					}
-
-					success, message, err := s.agentSender.SendCommand(ctx, hostInfo.AgentID, string(operation), params)
-					if err != nil {
-						nodeResult.Success = false
-						nodeResult.Message = "Failed to send command: " + err.Error()
-						result.Success = false
-					} else {
-						nodeResult.Success = success
-						nodeResult.Message = message
-						if !success {
-							result.Success = false
-						}
-					}
+				success, message, err := s.agentSender.SendCommand(ctx, hostInfo.AgentID, string(operation), params)
+				if err != nil {
+					nodeResult.Success = false
+					nodeResult.Message = "Failed to send command: " + err.Error()
 				} else {
-					// Agent sender not available, mark as pending
-					// Agent 发送器不可用，标记为待处理
-					nodeResult.Success = true
-					nodeResult.Message = "Operation queued (Agent sender not configured)"
+					nodeResult.Success = success
+					nodeResult.Message = message
 				}
 			} else {
-				// For Docker/K8s hosts, operations will be handled by respective managers
-				// 对于 Docker/K8s 主机，操作将由相应的管理器处理
+				// Agent sender not available, mark as pending
+				// Agent 发送器不可用，标记为待处理
 				nodeResult.Success = true
-				nodeResult.Message = "Operation queued for " + hostInfo.HostType + " host"
+				nodeResult.Message = "Operation queued (Agent sender not configured)"
 			}
 		} else {
-			// No host provider, mark as pending
-			// 没有主机提供者，标记为待处理
+			// For Docker/K8s hosts, operations will be handled by respective managers
+			// 对于 Docker/K8s 主机，操作将由相应的管理器处理
 			nodeResult.Success = true
-			nodeResult.Message = "Operation queued (host provider not configured)"
-		}
-
-		// Update node status based on operation
-		// 根据操作更新节点状态
-		if nodeResult.Success {
-			switch operation {
-			case OperationStart:
-				_ = s.repo.UpdateNodeStatus(ctx, node.ID, NodeStatusRunning)
-				s.detectAndUpdateNodeProcess(ctx, &node, node.HostID)
-			case OperationStop:
-				_ = s.repo.UpdateNodeStatus(ctx, node.ID, NodeStatusStopped)
-				_ = s.repo.UpdateNodeProcess(ctx, node.ID, 0, "stopped")
-			case OperationRestart:
-				_ = s.repo.UpdateNodeStatus(ctx, node.ID, NodeStatusRunning)
-				s.detectAndUpdateNodeProcess(ctx, &node, node.HostID)
-			}
-		} else {
-			_ = s.repo.UpdateNodeStatus(ctx, node.ID, NodeStatusError)
+			nodeResult.Message = "Operation queued for " + hostInfo.HostType + " host"
 		}
-
-		result.NodeResults = append(result.NodeResults, nodeResult)
+	} else {
+		// No host provider, mark as pending
+		// 没有主机提供者，标记为待处理
+		nodeResult.Success = true
+		nodeResult.Message = "Operation queued (host provider not configured)"
 	}
 
-	// Update cluster status based on overall result
-	// 根据整体结果更新集群状态
-	if result.Success {
+	// Update node status based on operation
+	// 根据操作更新节点状态
+	if nodeResult.Success {
 		switch operation {
-		case OperationStart, OperationRestart:
-			_ = s.repo.UpdateStatus(ctx, clusterID, ClusterStatusRunning)
+		case OperationStart:
+			_ = s.repo.UpdateNodeStatus(ctx, node.ID, NodeStatusRunning)
+			s.detectAndUpdateNodeProcess(ctx, node, node.HostID)
 		case OperationStop:
-			_ = s.repo.UpdateStatus(ctx, clusterID, ClusterStatusStopped)
+			_ = s.repo.UpdateNodeStatus(ctx, node.ID, NodeStatusStopped)
+			_ = s.repo.UpdateNodeProcess(ctx, node.ID, 0, "stopped")
+			s.forgetNodeHealth(node.ID)
+		case OperationRestart:
+			_ = s.repo.UpdateNodeStatus(ctx, node.ID, NodeStatusRunning)
+			s.detectAndUpdateNodeProcess(ctx, node, node.HostID)
 		}
-		result.Message = "Operation completed successfully"
 	} else {
-		_ = s.repo.UpdateStatus(ctx, clusterID, ClusterStatusError)
-		result.Message = "Operation completed with errors"
+		_ = s.repo.UpdateNodeStatus(ctx, node.ID, NodeStatusError)
 	}
 
-	return result, nil
+	return nodeResult
 }
 
 // GetClustersByHostID retrieves all clusters that have a specific host as a node.
@@ -1847,6 +2106,45 @@ func (s *Service) PrecheckNode(ctx context.Context, clusterID uint, req *Prechec
 	return result, nil
 }
 
+// membershipVerificationTimeout bounds how long the Agent waits for a started node to join the cluster.
+// membershipVerificationTimeout 限定 Agent 等待已启动节点加入集群的时长。
+const membershipVerificationTimeout = 120 * time.Second
+
+// addMembershipVerificationParams asks the Agent to confirm after START/RESTART that the node joined
+// the Hazelcast cluster, using the other running members as seeds.
+// addMembershipVerificationParams 要求 Agent 在 START/RESTART 后确认节点已加入 Hazelcast 集群，
+// 并以其他运行中的成员作为种子。
+func (s *Service) addMembershipVerificationParams(ctx context.Context, params map[string]string, node *ClusterNode, hostInfo *HostInfo) {
+	if node.HazelcastPort <= 0 {
+		return
+	}
+	params["verify_membership"] = "true"
+	params["hazelcast_port"] = strconv.Itoa(node.HazelcastPort)
+	params["membership_timeout"] = strconv.Itoa(int(membershipVerificationTimeout / time.Second))
+	if hostInfo != nil && hostInfo.IPAddress != "" {
+		params["member_address"] = hostInfo.IPAddress
+	}
+
+	nodes, err := s.repo.GetNodesByClusterID(ctx, node.ClusterID)
+	if err != nil {
+		return
+	}
+	var seeds []string
+	for _, peer := range nodes {
+		if peer.ID == node.ID || peer.Status != NodeStatusRunning || peer.HazelcastPort <= 0 {
+			continue
+		}
+		peerHost, err := s.hostProvider.GetHostByID(ctx, peer.HostID)
+		if err != nil || peerHost.IPAddress == "" {
+			continue
+		}
+		seeds = append(seeds, fmt.Sprintf("%s:%d", peerHost.IPAddress, peer.HazelcastPort))
+	}
+	if len(seeds) > 0 {
+		params["membership_seeds"] = strings.Join(seeds, ",")
+	}
+}
+
 // detectAndUpdateNodeProcess detects SeaTunnel process status via Agent and updates node.
 // detectAndUpdateNodeProcess 通过 Agent 检测 SeaTunnel 进程状态并更新节点。
 func (s *Service) detectAndUpdateNodeProcess(ctx context.Context, node *ClusterNode, hostID uint) {
@@ -2092,6 +2390,9 @@ func (s *Service) executeNodeOperationWithResolvedNode(ctx context.Context, clus
 			"role":        string(node.Role),
 			"install_dir": installDir,
 		}
+		if operation == OperationStart || operation == OperationRestart {
+			s.addMembershipVerificationParams(ctx, params, node, hostInfo)
+		}
 
 		success, message, err := s.agentSender.SendCommand(ctx, hostInfo.AgentID, string(operation), params)
 		if err != nil {
@@ -2116,6 +2417,7 @@ func (s *Service) executeNodeOperationWithResolvedNode(ctx context.Context, clus
 		case OperationStop:
 			_ = s.repo.UpdateNodeStatus(ctx, node.ID, NodeStatusStopped)
 			_ = s.repo.UpdateNodeProcess(ctx, node.ID, 0, "stopped")
+			s.forgetNodeHealth(node.ID)
 		case OperationRestart:
 			_ = s.repo.UpdateNodeStatus(ctx, node.ID, NodeStatusRunning)
 		}
@@ -19,6 +19,7 @@ package cluster
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -43,6 +44,8 @@ type mockAgentCommand struct {
 
 type mockOperationAgentSender struct {
 	commands []mockAgentCommand
+	// processStopped makes check_process report no running SeaTunnel process, so clusters stay stopped.
+	processStopped bool
 }
 
 func (m *mockOperationAgentSender) SendCommand(ctx context.Context, agentID string, commandType string, params map[string]string) (bool, string, error) {
@@ -57,6 +60,9 @@ func (m *mockOperationAgentSender) SendCommand(ctx context.Context, agentID stri
 	})
 
 	if commandType == "check_process" {
+		if m.processStopped {
+			return false, "SeaTunnel process not found", nil
+		}
 		return true, "SeaTunnel process found: PID=4321, role=hybrid", nil
 	}
 
@@ -804,7 +810,7 @@ func TestClusterServiceBasicOperations(t *testing.T) {
 
 	// Test Delete
 	// 测试删除
-	err = svc.Delete(ctx, cluster.ID, false)
+	_, err = svc.Delete(ctx, cluster.ID, false)
 	if err != nil {
 		t.Fatalf("Failed to delete cluster: %v", err)
 	}
@@ -847,7 +853,7 @@ func TestClusterServiceDeleteConstraint(t *testing.T) {
 
 	// Try to delete running cluster
 	// 尝试删除运行中的集群
-	err = svc.Delete(ctx, cluster.ID, false)
+	_, err = svc.Delete(ctx, cluster.ID, false)
 	if err != ErrClusterHasRunningTask {
 		t.Errorf("Expected ErrClusterHasRunningTask, got: %v", err)
 	}
@@ -939,6 +945,97 @@ func TestClusterServiceStartUsesNodeInstallDirAndRefreshesProcess(t *testing.T)
 	}
 }
 
+func TestClusterServiceDeleteTearsDownNodesAndReportsResults(t *testing.T) {
+	db, cleanup := setupServiceTestDB(t)
+	defer cleanup()
+
+	repo := NewRepository(db)
+	mockHostProvider := NewMockHostProvider()
+	now := time.Now()
+	mockHostProvider.AddHost(&HostInfo{
+		ID:            1,
+		Name:          "host-1",
+		HostType:      "bare_metal",
+		IPAddress:     "127.0.0.1",
+		AgentID:       "agent-1",
+		AgentStatus:   "installed",
+		LastHeartbeat: &now,
+	})
+
+	svc := NewService(repo, mockHostProvider, nil)
+	var commands []mockAgentCommand
+	svc.SetAgentCommandSender(&scriptedAgentSender{
+		send: func(ctx context.Context, agentID string, commandType string, params map[string]string) (bool, string, error) {
+			// Keep the cluster stopped so it can be deleted; process probes are not part of the teardown
+			// 保持集群为停止状态以便删除；进程探测不属于拆除流程
+			if commandType == "check_process" {
+				return false, "SeaTunnel process not found", nil
+			}
+			commands = append(commands, mockAgentCommand{agentID: agentID, commandType: commandType, params: params})
+			switch commandType {
+			case string(OperationStop):
+				return true, `{"removed_files":["/opt/seatunnel/.seatunnelx"],"removed_units":["seatunnel.service"]}`, nil
+			case "remove_install_dir":
+				return false, "permission denied", nil
+			}
+			return true, "ok", nil
+		},
+	})
+	var releasedCluster uint
+	svc.SetPluginRecordReleaser(func(ctx context.Context, clusterID uint) (int64, error) {
+		releasedCluster = clusterID
+		return 2, nil
+	})
+	ctx := context.Background()
+
+	cluster, err := svc.Create(ctx, &CreateClusterRequest{
+		Name:           "teardown-cluster",
+		DeploymentMode: DeploymentModeHybrid,
+		Version:        "2.3.12",
+		InstallDir:     "/opt/seatunnel",
+	})
+	if err != nil {
+		t.Fatalf("Create returned error: %v", err)
+	}
+	if _, err := svc.AddNode(ctx, cluster.ID, &AddNodeRequest{
+		HostID:        1,
+		Role:          NodeRoleMasterWorker,
+		HazelcastPort: 5801,
+		APIPort:       8080,
+		WorkerPort:    5802,
+		SkipPrecheck:  true,
+	}); err != nil {
+		t.Fatalf("AddNode returned error: %v", err)
+	}
+
+	report, err := svc.Delete(ctx, cluster.ID, true)
+	if err != nil {
+		t.Fatalf("Delete returned error: %v", err)
+	}
+	if len(report.Nodes) != 1 {
+		t.Fatalf("expected one node in teardown report, got %+v", report.Nodes)
+	}
+	node := report.Nodes[0]
+	if node.Stop.Status != TeardownStepSuccess || len(node.RemovedUnits) != 1 || len(node.RemovedFiles) != 1 {
+		t.Fatalf("expected successful stop with removed artifacts, got %+v", node)
+	}
+	if node.RemoveInstallDir.Status != TeardownStepFailed || node.RemoveInstallDir.Message != "permission denied" {
+		t.Fatalf("expected failed install dir removal to be reported, got %+v", node.RemoveInstallDir)
+	}
+	if report.FailedNodes() != 1 {
+		t.Fatalf("expected one failed node, got %d", report.FailedNodes())
+	}
+	if report.ReleasedPlugins != 2 || releasedCluster != cluster.ID {
+		t.Fatalf("expected plugin records of cluster %d to be released, got %d for %d", cluster.ID, report.ReleasedPlugins, releasedCluster)
+	}
+	if len(commands) == 0 || commands[0].commandType != string(OperationStop) || commands[0].params["teardown"] != "true" {
+		t.Fatalf("expected stop command with teardown enabled, got %+v", commands)
+	}
+	if _, err := repo.GetByID(ctx, cluster.ID, false); err == nil {
+		t.Fatalf("expected cluster row to be deleted despite failed teardown step")
+	}
+}
+
 func intPtr(v int) *int {
 	return &v
 }
@@ -1231,6 +1328,148 @@ func TestService_AddNodes_sameHostSeparatedCreatesMasterAndWorkerAtomically(t *t
 	}
 }
 
+func TestService_AddNodes_sameHostRejectsCollidingPorts(t *testing.T) {
+	db, cleanup := setupServiceTestDB(t)
+	defer cleanup()
+
+	repo := NewRepository(db)
+	mockHostProvider := NewMockHostProvider()
+	now := time.Now()
+	mockHostProvider.AddHost(&HostInfo{
+		ID:            1,
+		Name:          "separated-host",
+		HostType:      "bare_metal",
+		IPAddress:     "127.0.0.2",
+		AgentStatus:   "installed",
+		LastHeartbeat: &now,
+	})
+
+	svc := NewService(repo, mockHostProvider, nil)
+	ctx := context.Background()
+
+	cluster, err := svc.Create(ctx, &CreateClusterRequest{
+		Name:           "separated-ports",
+		DeploymentMode: DeploymentModeSeparated,
+	})
+	if err != nil {
+		t.Fatalf("Create returned error: %v", err)
+	}
+
+	_, err = svc.AddNodes(ctx, cluster.ID, &AddNodesRequest{
+		HostID: 1,
+		Entries: []AddNodeEntryRequest{
+			{Role: NodeRoleMaster, HazelcastPort: 5801, APIPort: 8080},
+			{Role: NodeRoleWorker, HazelcastPort: 5801},
+		},
+	})
+	if !errors.Is(err, ErrNodePortConflict) {
+		t.Fatalf("expected ErrNodePortConflict, got %v", err)
+	}
+	if storedNodes, _ := repo.GetNodesByClusterID(ctx, cluster.ID); len(storedNodes) != 0 {
+		t.Fatalf("expected the batch to be rolled back, got %d nodes", len(storedNodes))
+	}
+
+	master, err := svc.AddNode(ctx, cluster.ID, &AddNodeRequest{HostID: 1, Role: NodeRoleMaster, HazelcastPort: 5801, APIPort: 8080})
+	if err != nil {
+		t.Fatalf("AddNode returned error: %v", err)
+	}
+	if _, err := svc.AddNode(ctx, cluster.ID, &AddNodeRequest{HostID: 1, Role: NodeRoleWorker, HazelcastPort: 8080}); !errors.Is(err, ErrNodePortConflict) {
+		t.Fatalf("expected worker on the master api port to conflict, got %v", err)
+	}
+	worker, err := svc.AddNode(ctx, cluster.ID, &AddNodeRequest{HostID: 1, Role: NodeRoleWorker, HazelcastPort: 5802})
+	if err != nil {
+		t.Fatalf("AddNode returned error: %v", err)
+	}
+	if _, err := svc.UpdateNode(ctx, cluster.ID, worker.ID, &UpdateNodeRequest{HazelcastPort: intPtr(master.HazelcastPort)}); !errors.Is(err, ErrNodePortConflict) {
+		t.Fatalf("expected update onto the master port to conflict, got %v", err)
+	}
+}
+
+func TestService_NodeSelectors_restrictPlacementByHostLabels(t *testing.T) {
+	db, cleanup := setupServiceTestDB(t)
+	defer cleanup()
+
+	repo := NewRepository(db)
+	mockHostProvider := NewMockHostProvider()
+	now := time.Now()
+	mockHostProvider.AddHost(&HostInfo{
+		ID:            1,
+		Name:          "master-host",
+		HostType:      "bare_metal",
+		AgentStatus:   "installed",
+		LastHeartbeat: &now,
+		Labels:        map[string]string{"zone": "az1", "role": "control"},
+	})
+	mockHostProvider.AddHost(&HostInfo{
+		ID:            2,
+		Name:          "etl-host",
+		HostType:      "bare_metal",
+		AgentStatus:   "installed",
+		LastHeartbeat: &now,
+		Labels:        map[string]string{"zone": "az1", "role": "etl", "disk": "ssd"},
+	})
+
+	svc := NewService(repo, mockHostProvider, nil)
+	ctx := context.Background()
+
+	if _, err := svc.Create(ctx, &CreateClusterRequest{
+		Name:           "bad-selector-role",
+		DeploymentMode: DeploymentModeHybrid,
+		NodeSelectors:  NodeSelectors{NodeRoleMaster: "zone=az1"},
+	}); !errors.Is(err, ErrInvalidNodeSelector) {
+		t.Fatalf("expected ErrInvalidNodeSelector for a master selector in hybrid mode, got %v", err)
+	}
+	if _, err := svc.Create(ctx, &CreateClusterRequest{
+		Name:           "discovered-mismatch",
+		DeploymentMode: DeploymentModeSeparated,
+		NodeSelectors:  NodeSelectors{NodeRoleWorker: "role=etl"},
+		Nodes:          []CreateNodeFromDiscovery{{HostID: 1, Role: "worker"}},
+	}); !errors.Is(err, ErrNodeSelectorMismatch) {
+		t.Fatalf("expected ErrNodeSelectorMismatch for discovered nodes, got %v", err)
+	}
+
+	cluster, err := svc.Create(ctx, &CreateClusterRequest{
+		Name:           "placed",
+		DeploymentMode: DeploymentModeSeparated,
+		NodeSelectors: NodeSelectors{
+			NodeRoleMaster: "role=control",
+			NodeRoleWorker: "zone=az1, role in (etl, batch), disk",
+		},
+	})
+	if err != nil {
+		t.Fatalf("Create returned error: %v", err)
+	}
+
+	if _, err := svc.AddNode(ctx, cluster.ID, &AddNodeRequest{HostID: 1, Role: NodeRoleWorker}); !errors.Is(err, ErrNodeSelectorMismatch) {
+		t.Fatalf("expected ErrNodeSelectorMismatch for worker on master host, got %v", err)
+	}
+	if _, err := svc.AddNode(ctx, cluster.ID, &AddNodeRequest{HostID: 1, Role: NodeRoleMaster}); err != nil {
+		t.Fatalf("AddNode master returned error: %v", err)
+	}
+	if _, err := svc.AddNodes(ctx, cluster.ID, &AddNodesRequest{
+		HostID:  2,
+		Entries: []AddNodeEntryRequest{{Role: NodeRoleWorker}, {Role: NodeRoleMaster}},
+	}); !errors.Is(err, ErrNodeSelectorMismatch) {
+		t.Fatalf("expected AddNodes to reject the master entry, got %v", err)
+	}
+	if nodes, _ := repo.GetNodesByClusterID(ctx, cluster.ID); len(nodes) != 1 {
+		t.Fatalf("rejected batch must not create nodes, got %d nodes", len(nodes))
+	}
+
+	if _, err := svc.Update(ctx, cluster.ID, &UpdateClusterRequest{
+		NodeSelectors: &NodeSelectors{NodeRoleMasterWorker: "zone=az1"},
+	}); !errors.Is(err, ErrInvalidNodeSelector) {
+		t.Fatalf("expected ErrInvalidNodeSelector on update, got %v", err)
+	}
+	stored, err := repo.GetByID(ctx, cluster.ID, false)
+	if err != nil {
+		t.Fatalf("GetByID returned error: %v", err)
+	}
+	if stored.NodeSelectors[NodeRoleWorker] != "zone=az1, role in (etl, batch), disk" {
+		t.Fatalf("node selectors should be persisted, got %+v", stored.NodeSelectors)
+	}
+}
+
 func TestClusterNode_ResolveJVM_usesNodeOverrideOverClusterDefault(t *testing.T) {
 	clusterConfig := ClusterConfig{
 		"jvm": map[string]interface{}{
@@ -1259,3 +1498,48 @@ func TestClusterNode_ResolveJVM_usesNodeOverrideOverClusterDefault(t *testing.T)
 		t.Fatalf("expected worker heap override 10GB, got %d", resolved.WorkerHeapSize)
 	}
 }
+
+func TestService_StartNode_requestsMembershipVerificationWithRunningSeeds(t *testing.T) {
+	db, cleanup := setupServiceTestDB(t)
+	defer cleanup()
+
+	repo := NewRepository(db)
+	hostProvider := NewMockHostProvider()
+	now := time.Now()
+	hostProvider.AddHost(&HostInfo{ID: 1, Name: "host-1", IPAddress: "10.0.0.1", AgentID: "agent-1", LastHeartbeat: &now})
+	hostProvider.AddHost(&HostInfo{ID: 2, Name: "host-2", IPAddress: "10.0.0.2", AgentID: "agent-2", LastHeartbeat: &now})
+
+	var startParams map[string]string
+	service := NewService(repo, hostProvider, &ServiceConfig{HeartbeatTimeout: time.Hour})
+	service.SetAgentCommandSender(&scriptedAgentSender{
+		send: func(ctx context.Context, agentID string, commandType string, params map[string]string) (bool, string, error) {
+			if commandType == "start" {
+				startParams = params
+			}
+			return true, "ok", nil
+		},
+	})
+
+	ctx := context.Background()
+	cluster := &Cluster{Name: "membership-demo", DeploymentMode: DeploymentModeHybrid, InstallDir: "/opt/seatunnel", Status: ClusterStatusRunning}
+	if err := repo.Create(ctx, cluster); err != nil {
+		t.Fatalf("create cluster failed: %v", err)
+	}
+	running := &ClusterNode{ClusterID: cluster.ID, HostID: 1, Role: NodeRoleMasterWorker, HazelcastPort: 5801, Status: NodeStatusRunning}
+	added := &ClusterNode{ClusterID: cluster.ID, HostID: 2, Role: NodeRoleMasterWorker, HazelcastPort: 5802, Status: NodeStatusStopped}
+	for _, node := range []*ClusterNode{running, added} {
+		if err := repo.AddNode(ctx, node); err != nil {
+			t.Fatalf("add node failed: %v", err)
+		}
+	}
+
+	if _, err := service.executeNodeOperationWithResolvedNode(ctx, cluster, added, OperationStart); err != nil {
+		t.Fatalf("start node failed: %v", err)
+	}
+	if startParams["verify_membership"] != "true" || startParams["hazelcast_port"] != "5802" || startParams["member_address"] != "10.0.0.2" {
+		t.Fatalf("expected membership verification params, got %v", startParams)
+	}
+	if startParams["membership_seeds"] != "10.0.0.1:5801" {
+		t.Fatalf("expected the running node as seed, got %q", startParams["membership_seeds"])
+	}
+}
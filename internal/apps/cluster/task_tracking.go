@@ -0,0 +1,92 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cluster
+
+import (
+	"context"
+	"errors"
+
+	"github.com/seatunnel/seatunnelX/internal/apps/task"
+	"github.com/seatunnel/seatunnelX/internal/logger"
+)
+
+// SetTaskManager sets the task manager used to record cluster operations as tasks.
+// SetTaskManager 设置用于将集群操作记录为任务的任务管理器。
+func (s *Service) SetTaskManager(manager *task.Manager) {
+	s.taskManager = manager
+}
+
+// runOperationTask runs a cluster operation as a synchronous task. An operation that completes
+// with failed nodes marks the task failed while the caller still receives the full result.
+// runOperationTask 将集群操作作为同步任务运行。操作完成但有节点失败时任务记为失败，
+// 调用方仍会得到完整结果。
+func (s *Service) runOperationTask(ctx context.Context, taskType task.TaskType, clusterID uint, payload interface{}, op func(ctx context.Context) (*OperationResult, error)) (*OperationResult, error) {
+	if s.taskManager == nil {
+		return op(ctx)
+	}
+	params, err := task.ParamsFrom(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		result *OperationResult
+		opErr  error
+		ran    bool
+	)
+	_, err = s.taskManager.Run(ctx, &task.CreateTaskRequest{
+		Type:      taskType,
+		ClusterID: clusterID,
+		Params:    params,
+		Source:    task.TaskSourceCluster,
+	}, "", func(ctx context.Context, _ *task.Task, _ *task.Reporter) (map[string]interface{}, error) {
+		ran = true
+		result, opErr = op(ctx)
+		if opErr != nil {
+			return nil, opErr
+		}
+		summary := operationResultSummary(result)
+		if !result.Success {
+			return summary, errors.New(result.Message)
+		}
+		return summary, nil
+	})
+	if !ran {
+		logger.WarnF(ctx, "[Cluster] 创建集群操作任务失败 / failed to create cluster operation task: cluster=%d, type=%s, err=%v", clusterID, taskType, err)
+		return op(ctx)
+	}
+	return result, opErr
+}
+
+// operationResultSummary converts an operation result into a task result.
+// operationResultSummary 将操作结果转换为任务结果。
+func operationResultSummary(result *OperationResult) map[string]interface{} {
+	failed := 0
+	for _, nodeResult := range result.NodeResults {
+		if !nodeResult.Success {
+			failed++
+		}
+	}
+	return map[string]interface{}{
+		"operation":    string(result.Operation),
+		"success":      result.Success,
+		"message":      result.Message,
+		"total_nodes":  len(result.NodeResults),
+		"failed_nodes": failed,
+	}
+}
@@ -0,0 +1,64 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cluster
+
+import (
+	"context"
+	"testing"
+
+	"github.com/seatunnel/seatunnelX/internal/apps/task"
+)
+
+func TestClusterOperationRecordedAsTask(t *testing.T) {
+	db, cleanup := setupServiceTestDB(t)
+	defer cleanup()
+
+	mockHostProvider := NewMockHostProvider()
+	mockHostProvider.AddHost(&HostInfo{ID: 1, Name: "host-1", HostType: "bare_metal", AgentID: "agent-1", AgentStatus: "installed"})
+	svc := NewService(NewRepository(db), mockHostProvider, nil)
+	taskManager := task.NewManager()
+	svc.SetTaskManager(taskManager)
+	ctx := context.Background()
+
+	cluster, err := svc.Create(ctx, &CreateClusterRequest{Name: "tracked", DeploymentMode: DeploymentModeHybrid, Version: "2.3.12"})
+	if err != nil {
+		t.Fatalf("Create returned error: %v", err)
+	}
+	if _, err := svc.AddNode(ctx, cluster.ID, &AddNodeRequest{HostID: 1, Role: NodeRoleMasterWorker, SkipPrecheck: true}); err != nil {
+		t.Fatalf("AddNode returned error: %v", err)
+	}
+
+	// The host has no heartbeat, so the stop fails on its only node
+	// 主机没有心跳，因此唯一节点上的停止操作失败
+	result, err := svc.Stop(ctx, cluster.ID)
+	if err != nil || result == nil || result.Success {
+		t.Fatalf("expected a failed operation result, got %+v err=%v", result, err)
+	}
+
+	tasks, total, err := taskManager.ListAllTasks(ctx, &task.TaskFilter{ClusterID: cluster.ID})
+	if err != nil || total != 1 {
+		t.Fatalf("ListAllTasks = %v, %d, %v", tasks, total, err)
+	}
+	recorded := tasks[0]
+	if recorded.Type != task.TaskTypeStop || recorded.Source != task.TaskSourceCluster || recorded.Status != task.TaskStatusFailed {
+		t.Fatalf("unexpected task: %+v", recorded)
+	}
+	if recorded.Error != result.Message || recorded.Result["failed_nodes"] != 1 {
+		t.Fatalf("expected the operation summary on the task, got %+v", recorded)
+	}
+}
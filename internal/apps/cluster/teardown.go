@@ -0,0 +1,206 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/seatunnel/seatunnelX/internal/logger"
+)
+
+// TeardownStepStatus is the outcome of one teardown step on a node.
+// TeardownStepStatus 是节点上某个拆除步骤的结果。
+type TeardownStepStatus string
+
+const (
+	TeardownStepSuccess TeardownStepStatus = "success"
+	TeardownStepFailed  TeardownStepStatus = "failed"
+	TeardownStepSkipped TeardownStepStatus = "skipped"
+)
+
+// TeardownStep is the result of one teardown step on a node.
+// TeardownStep 是节点上某个拆除步骤的结果。
+type TeardownStep struct {
+	Status  TeardownStepStatus `json:"status"`
+	Message string             `json:"message,omitempty"`
+}
+
+// NodeTeardownResult is the teardown outcome of one cluster node.
+// NodeTeardownResult 是单个集群节点的拆除结果。
+type NodeTeardownResult struct {
+	NodeID     uint     `json:"node_id"`
+	HostID     uint     `json:"host_id"`
+	HostName   string   `json:"host_name,omitempty"`
+	AgentID    string   `json:"agent_id,omitempty"`
+	Role       NodeRole `json:"role"`
+	InstallDir string   `json:"install_dir"`
+
+	// Stop covers stopping the process and removing runtime artifacts (pid/state files, systemd units).
+	// Stop 包括停止进程以及移除运行时产物（pid/状态文件、systemd unit）。
+	Stop             TeardownStep `json:"stop"`
+	RemovedFiles     []string     `json:"removed_files,omitempty"`
+	RemovedUnits     []string     `json:"removed_units,omitempty"`
+	RemoveInstallDir TeardownStep `json:"remove_install_dir"`
+}
+
+// TeardownReport summarizes the teardown performed before a cluster row is deleted.
+// TeardownReport 汇总删除集群记录前执行的拆除操作。
+type TeardownReport struct {
+	ClusterID        uint                  `json:"cluster_id"`
+	ClusterName      string                `json:"cluster_name"`
+	RemoveInstallDir bool                  `json:"remove_install_dir"`
+	Nodes            []*NodeTeardownResult `json:"nodes"`
+	// ReleasedPlugins is the number of installed-plugin records released for the cluster.
+	// ReleasedPlugins 是为该集群释放的已安装插件记录数。
+	ReleasedPlugins    int64     `json:"released_plugins"`
+	PluginReleaseError string    `json:"plugin_release_error,omitempty"`
+	StartedAt          time.Time `json:"started_at"`
+	FinishedAt         time.Time `json:"finished_at"`
+}
+
+// FailedNodes returns the number of nodes with a failed teardown step.
+// FailedNodes 返回存在失败拆除步骤的节点数。
+func (r *TeardownReport) FailedNodes() int {
+	failed := 0
+	for _, node := range r.Nodes {
+		if node.Stop.Status == TeardownStepFailed || node.RemoveInstallDir.Status == TeardownStepFailed {
+			failed++
+		}
+	}
+	return failed
+}
+
+// PluginRecordReleaser releases installed-plugin records of a deleted cluster.
+// PluginRecordReleaser 释放被删除集群的已安装插件记录。
+type PluginRecordReleaser func(ctx context.Context, clusterID uint) (int64, error)
+
+// SetPluginRecordReleaser sets the hook that releases installed-plugin records during cluster teardown.
+// SetPluginRecordReleaser 设置集群拆除时释放已安装插件记录的钩子。
+func (s *Service) SetPluginRecordReleaser(fn PluginRecordReleaser) {
+	s.pluginRecordReleaser = fn
+}
+
+// teardownCluster stops every member node, optionally removes install dirs and releases plugin
+// records. Every step is best effort; failures are recorded in the report instead of aborting.
+// teardownCluster 停止所有成员节点，可选删除安装目录并释放插件记录。
+// 每个步骤都尽力而为；失败会记录在报告中而不会中止。
+func (s *Service) teardownCluster(ctx context.Context, cluster *Cluster, removeInstallDir bool) *TeardownReport {
+	report := &TeardownReport{
+		ClusterID:        cluster.ID,
+		ClusterName:      cluster.Name,
+		RemoveInstallDir: removeInstallDir,
+		Nodes:            make([]*NodeTeardownResult, 0, len(cluster.Nodes)),
+		StartedAt:        time.Now(),
+	}
+
+	for _, node := range cluster.Nodes {
+		report.Nodes = append(report.Nodes, s.teardownNode(ctx, cluster, node, removeInstallDir))
+	}
+
+	if s.pluginRecordReleaser != nil {
+		released, err := s.pluginRecordReleaser(ctx, cluster.ID)
+		report.ReleasedPlugins = released
+		if err != nil {
+			report.PluginReleaseError = err.Error()
+			logger.WarnF(ctx, "[Cluster] Delete: release plugin records failed / 删除集群时释放插件记录失败: cluster_id=%d, err=%v", cluster.ID, err)
+		}
+	}
+
+	report.FinishedAt = time.Now()
+	return report
+}
+
+// teardownNode stops the node process with teardown enabled and removes its install dir if requested.
+// teardownNode 以拆除模式停止节点进程，并按需删除其安装目录。
+func (s *Service) teardownNode(ctx context.Context, cluster *Cluster, node ClusterNode, removeInstallDir bool) *NodeTeardownResult {
+	installDir := node.InstallDir
+	if installDir == "" {
+		installDir = cluster.InstallDir
+	}
+	result := &NodeTeardownResult{
+		NodeID:           node.ID,
+		HostID:           node.HostID,
+		Role:             node.Role,
+		InstallDir:       installDir,
+		Stop:             TeardownStep{Status: TeardownStepSkipped},
+		RemoveInstallDir: TeardownStep{Status: TeardownStepSkipped},
+	}
+
+	if s.hostProvider == nil || s.agentSender == nil {
+		result.Stop.Message = "agent command sender not configured / Agent 命令发送器未配置"
+		return result
+	}
+	hostInfo, err := s.hostProvider.GetHostByID(ctx, node.HostID)
+	if err != nil || hostInfo.AgentID == "" {
+		logger.WarnF(ctx, "[Cluster] Delete: skip node (no host or no agent) / 删除集群：跳过节点: node_id=%d, host_id=%d", node.ID, node.HostID)
+		result.Stop.Message = "host has no agent / 主机未安装 Agent"
+		return result
+	}
+	result.HostName = hostInfo.Name
+	result.AgentID = hostInfo.AgentID
+
+	params := map[string]string{
+		"cluster_id":  fmt.Sprintf("%d", cluster.ID),
+		"node_id":     fmt.Sprintf("%d", node.ID),
+		"role":        string(node.Role),
+		"install_dir": installDir,
+		"teardown":    "true",
+	}
+	logger.InfoF(ctx, "[Cluster] Delete: sending stop to agent / 删除集群：向 Agent 发送停止命令: agent_id=%s, node_id=%d", hostInfo.AgentID, node.ID)
+	result.Stop = runTeardownCommand(ctx, s.agentSender, hostInfo.AgentID, string(OperationStop), params, func(output string) {
+		var artifacts struct {
+			RemovedFiles []string `json:"removed_files"`
+			RemovedUnits []string `json:"removed_units"`
+		}
+		if json.Unmarshal([]byte(output), &artifacts) == nil {
+			result.RemovedFiles = artifacts.RemovedFiles
+			result.RemovedUnits = artifacts.RemovedUnits
+		}
+	})
+	if result.Stop.Status == TeardownStepFailed {
+		logger.WarnF(ctx, "[Cluster] Delete: stop process on agent failed / 删除集群时向 Agent 发送停止失败: host_id=%d, node_id=%d, err=%s", node.HostID, node.ID, result.Stop.Message)
+	}
+
+	if removeInstallDir && installDir != "" {
+		logger.InfoF(ctx, "[Cluster] Delete: sending remove_install_dir to agent / 删除集群：向 Agent 发送删除安装目录: agent_id=%s, install_dir=%s", hostInfo.AgentID, installDir)
+		result.RemoveInstallDir = runTeardownCommand(ctx, s.agentSender, hostInfo.AgentID, "remove_install_dir", map[string]string{"install_dir": installDir}, nil)
+		if result.RemoveInstallDir.Status == TeardownStepFailed {
+			logger.WarnF(ctx, "[Cluster] Delete: remove_install_dir on agent failed / 删除集群时向 Agent 发送删除安装目录失败: host_id=%d, err=%s", node.HostID, result.RemoveInstallDir.Message)
+		}
+	}
+	return result
+}
+
+// runTeardownCommand sends one teardown command and converts the outcome into a step result.
+// runTeardownCommand 发送一个拆除命令并将结果转换为步骤结果。
+func runTeardownCommand(ctx context.Context, sender AgentCommandSender, agentID, commandType string, params map[string]string, onSuccess func(output string)) TeardownStep {
+	success, output, err := sender.SendCommand(ctx, agentID, commandType, params)
+	if err != nil {
+		return TeardownStep{Status: TeardownStepFailed, Message: err.Error()}
+	}
+	if !success {
+		return TeardownStep{Status: TeardownStepFailed, Message: output}
+	}
+	if onSuccess != nil {
+		onSuccess(output)
+	}
+	return TeardownStep{Status: TeardownStepSuccess}
+}
@@ -0,0 +1,224 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package clustermetrics
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// legacyRESTPrefix is the REST API V1 prefix served on the Hazelcast port.
+	// legacyRESTPrefix 是 Hazelcast 端口上 REST API V1 的路径前缀。
+	legacyRESTPrefix = "/hazelcast/rest/maps"
+
+	overviewPath         = "/overview"
+	runningJobsPath      = "/running-jobs"
+	systemMonitoringPath = "/system-monitoring-information"
+
+	apiModeV1 = "v1"
+	apiModeV2 = "v2"
+)
+
+// Endpoint is one SeaTunnel engine REST endpoint.
+// Legacy endpoints serve REST API V1 under /hazelcast/rest/maps on the Hazelcast port.
+// Endpoint 是一个 SeaTunnel 引擎 REST 端点；Legacy 端点在 Hazelcast 端口的 /hazelcast/rest/maps 下提供 REST API V1。
+type Endpoint struct {
+	BaseURL string
+	Legacy  bool
+}
+
+// APIMode returns the REST API version the endpoint speaks.
+// APIMode 返回端点使用的 REST API 版本。
+func (e *Endpoint) APIMode() string {
+	if e.Legacy {
+		return apiModeV1
+	}
+	return apiModeV2
+}
+
+func (e *Endpoint) url(path string) string {
+	base := strings.TrimRight(e.BaseURL, "/")
+	if e.Legacy {
+		base += legacyRESTPrefix
+	}
+	return base + path
+}
+
+// EngineOverview is the cluster-wide subset of the engine overview used by the dashboard.
+// EngineOverview 是仪表盘使用的引擎集群概览子集。
+type EngineOverview struct {
+	TotalSlot      int
+	UnassignedSlot int
+	Workers        int
+	RunningJobs    int
+}
+
+// EngineClient reads cluster overview metrics from the SeaTunnel engine REST API.
+// EngineClient 从 SeaTunnel 引擎 REST API 读取集群概览指标。
+type EngineClient interface {
+	GetOverview(ctx context.Context, endpoint *Endpoint) (*EngineOverview, error)
+	CountRunningJobs(ctx context.Context, endpoint *Endpoint) (int, error)
+	GetSystemMonitoring(ctx context.Context, endpoint *Endpoint) ([]map[string]string, error)
+}
+
+// HTTPEngineClient is the default EngineClient backed by net/http.
+// HTTPEngineClient 是基于 net/http 的默认 EngineClient。
+type HTTPEngineClient struct {
+	httpClient *http.Client
+}
+
+// NewHTTPEngineClient creates an engine REST client.
+// NewHTTPEngineClient 创建引擎 REST 客户端。
+func NewHTTPEngineClient() *HTTPEngineClient {
+	return &HTTPEngineClient{httpClient: &http.Client{Timeout: 10 * time.Second}}
+}
+
+// GetOverview fetches GET /overview.
+// GetOverview 获取 GET /overview。
+func (c *HTTPEngineClient) GetOverview(ctx context.Context, endpoint *Endpoint) (*EngineOverview, error) {
+	var payload map[string]interface{}
+	if err := c.getJSON(ctx, endpoint.url(overviewPath), &payload); err != nil {
+		return nil, err
+	}
+	return &EngineOverview{
+		TotalSlot:      intValue(payload["totalSlot"]),
+		UnassignedSlot: intValue(payload["unassignedSlot"]),
+		Workers:        intValue(payload["works"]),
+		RunningJobs:    intValue(payload["runningJobs"]),
+	}, nil
+}
+
+// CountRunningJobs fetches GET /running-jobs and returns the number of jobs.
+// CountRunningJobs 获取 GET /running-jobs 并返回作业数量。
+func (c *HTTPEngineClient) CountRunningJobs(ctx context.Context, endpoint *Endpoint) (int, error) {
+	var jobs []json.RawMessage
+	if err := c.getJSON(ctx, endpoint.url(runningJobsPath), &jobs); err != nil {
+		return 0, err
+	}
+	return len(jobs), nil
+}
+
+// GetSystemMonitoring fetches GET /system-monitoring-information, one entry per cluster member.
+// GetSystemMonitoring 获取 GET /system-monitoring-information，每个集群成员一条记录。
+func (c *HTTPEngineClient) GetSystemMonitoring(ctx context.Context, endpoint *Endpoint) ([]map[string]string, error) {
+	var members []map[string]interface{}
+	if err := c.getJSON(ctx, endpoint.url(systemMonitoringPath), &members); err != nil {
+		return nil, err
+	}
+	result := make([]map[string]string, 0, len(members))
+	for _, member := range members {
+		entry := make(map[string]string, len(member))
+		for key, value := range member {
+			entry[key] = strings.TrimSpace(fmt.Sprint(value))
+		}
+		result = append(result, entry)
+	}
+	return result, nil
+}
+
+func (c *HTTPEngineClient) getJSON(ctx context.Context, url string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 4<<20))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("clustermetrics: GET %s failed: status=%d body=%s", url, resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("clustermetrics: decode %s: %w", url, err)
+	}
+	return nil
+}
+
+// intValue reads an engine number that may be encoded as a JSON number or string.
+// intValue 读取可能以 JSON 数字或字符串编码的引擎数值。
+func intValue(value interface{}) int {
+	switch v := value.(type) {
+	case float64:
+		return int(v)
+	case string:
+		n, _ := strconv.Atoi(strings.TrimSpace(v))
+		return n
+	default:
+		return 0
+	}
+}
+
+// parseByteSize parses Hazelcast memory values such as "135.7M" or "3.6G" into bytes.
+// parseByteSize 将 "135.7M"、"3.6G" 等 Hazelcast 内存值解析为字节数。
+func parseByteSize(value string) int64 {
+	value = strings.ToUpper(strings.TrimSpace(value))
+	value = strings.TrimSuffix(value, "B")
+	multiplier := float64(1)
+	if value != "" {
+		switch value[len(value)-1] {
+		case 'K':
+			multiplier = 1 << 10
+		case 'M':
+			multiplier = 1 << 20
+		case 'G':
+			multiplier = 1 << 30
+		case 'T':
+			multiplier = 1 << 40
+		}
+		if multiplier > 1 {
+			value = value[:len(value)-1]
+		}
+	}
+	number, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil {
+		return 0
+	}
+	return int64(number * multiplier)
+}
+
+// parsePercent parses values such as "24.78%" into 24.78.
+// parsePercent 将 "24.78%" 等值解析为 24.78。
+func parsePercent(value string) float64 {
+	number, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(value), "%"), 64)
+	if err != nil {
+		return 0
+	}
+	return number
+}
+
+// parseCount parses integer counters, ignoring malformed values.
+// parseCount 解析整数计数器，忽略格式错误的值。
+func parseCount(value string) int64 {
+	number, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+	if err != nil {
+		return 0
+	}
+	return number
+}
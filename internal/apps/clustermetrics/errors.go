@@ -0,0 +1,32 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package clustermetrics
+
+import "errors"
+
+// Error definitions for clustermetrics package.
+// 集群指标包的错误定义。
+var (
+	// ErrClusterProviderUnavailable indicates the collector has no cluster provider.
+	// ErrClusterProviderUnavailable 表示采集器未配置集群提供者。
+	ErrClusterProviderUnavailable = errors.New("cluster metrics provider is not configured / 集群指标提供者未配置")
+
+	// ErrNoEngineEndpoint indicates no running node exposes a REST port.
+	// ErrNoEngineEndpoint 表示没有运行中的节点暴露 REST 端口。
+	ErrNoEngineEndpoint = errors.New("no running node exposes a SeaTunnel REST endpoint / 没有运行中的节点暴露 SeaTunnel REST 端点")
+)
@@ -0,0 +1,80 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package clustermetrics
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	clusterapp "github.com/seatunnel/seatunnelX/internal/apps/cluster"
+)
+
+// Handler handles cluster metrics HTTP requests.
+// Handler 处理集群指标 HTTP 请求。
+type Handler struct {
+	service *Service
+}
+
+// NewHandler creates a new cluster metrics handler.
+// NewHandler 创建新的集群指标处理器。
+func NewHandler(service *Service) *Handler {
+	return &Handler{service: service}
+}
+
+// GetClusterMetrics handles GET /api/v1/clusters/:id/metrics
+// GetClusterMetrics 处理 GET /api/v1/clusters/:id/metrics
+// @Summary Get cluster overview metrics
+// @Description Latest slot utilization, running job count and per-node JVM stats, plus history since the given time (default: last hour)
+// @Tags Cluster
+// @Produce json
+// @Param id path int true "Cluster ID"
+// @Param since query string false "RFC3339 start time of the history"
+// @Param limit query int false "Maximum history points"
+// @Success 200 {object} Response
+// @Router /api/v1/clusters/{id}/metrics [get]
+func (h *Handler) GetClusterMetrics(c *gin.Context) {
+	clusterID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, Response{ErrorMsg: "invalid cluster id / 无效的集群 ID"})
+		return
+	}
+
+	var since time.Time
+	if raw := c.Query("since"); raw != "" {
+		if since, err = time.Parse(time.RFC3339, raw); err != nil {
+			c.JSON(http.StatusBadRequest, Response{ErrorMsg: "invalid since, expected RFC3339 / since 无效，应为 RFC3339 格式"})
+			return
+		}
+	}
+	limit, _ := strconv.Atoi(c.Query("limit"))
+
+	data, err := h.service.GetClusterMetrics(c.Request.Context(), uint(clusterID), since, limit)
+	if errors.Is(err, clusterapp.ErrClusterNotFound) {
+		c.JSON(http.StatusNotFound, Response{ErrorMsg: err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, Response{ErrorMsg: "Failed to get cluster metrics: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{Data: data})
+}
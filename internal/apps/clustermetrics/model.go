@@ -0,0 +1,132 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package clustermetrics polls the SeaTunnel engine REST API of managed clusters and
+// stores cluster overview metrics snapshots for the dashboard.
+// Package clustermetrics 轮询受管集群的 SeaTunnel 引擎 REST API，并保存供仪表盘使用的集群概览指标快照。
+package clustermetrics
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// Response is the standard API response envelope.
+// Response 是标准 API 响应结构。
+type Response struct {
+	ErrorMsg string      `json:"error_msg"`
+	Data     interface{} `json:"data"`
+}
+
+// NodeMetrics holds the JVM stats of one cluster node reported by the engine.
+// NodeMetrics 保存引擎上报的单个集群节点 JVM 指标。
+type NodeMetrics struct {
+	NodeID             uint    `json:"node_id"`
+	HostID             uint    `json:"host_id"`
+	Role               string  `json:"role"`
+	Address            string  `json:"address"`
+	Reachable          bool    `json:"reachable"`
+	Error              string  `json:"error,omitempty"`
+	Processors         int     `json:"processors"`
+	HeapUsedBytes      int64   `json:"heap_used_bytes"`
+	HeapMaxBytes       int64   `json:"heap_max_bytes"`
+	HeapUsedPercent    float64 `json:"heap_used_percent"`
+	ProcessLoadPercent float64 `json:"process_load_percent"`
+	SystemLoadPercent  float64 `json:"system_load_percent"`
+	ThreadCount        int     `json:"thread_count"`
+	MinorGCCount       int64   `json:"minor_gc_count"`
+	MajorGCCount       int64   `json:"major_gc_count"`
+}
+
+// NodeMetricsList is the JSON column type storing per-node metrics of a snapshot.
+// NodeMetricsList 是保存快照中各节点指标的 JSON 列类型。
+type NodeMetricsList []*NodeMetrics
+
+// Value implements driver.Valuer for NodeMetricsList.
+// Value 实现 NodeMetricsList 的 driver.Valuer 接口。
+func (l NodeMetricsList) Value() (driver.Value, error) {
+	if l == nil {
+		return "[]", nil
+	}
+	return json.Marshal(l)
+}
+
+// Scan implements sql.Scanner for NodeMetricsList.
+// Scan 实现 NodeMetricsList 的 sql.Scanner 接口。
+func (l *NodeMetricsList) Scan(value interface{}) error {
+	if value == nil {
+		*l = NodeMetricsList{}
+		return nil
+	}
+	var bytes []byte
+	switch v := value.(type) {
+	case []byte:
+		bytes = v
+	case string:
+		bytes = []byte(v)
+	default:
+		return errors.New("clustermetrics: failed to scan NodeMetricsList - expected []byte")
+	}
+	return json.Unmarshal(bytes, l)
+}
+
+// MetricsSnapshot is one collected overview of a cluster.
+// MetricsSnapshot 是一次采集得到的集群概览。
+type MetricsSnapshot struct {
+	ID              uint            `json:"id" gorm:"primaryKey;autoIncrement"`
+	ClusterID       uint            `json:"cluster_id" gorm:"index:idx_cluster_metrics_cluster_time;not null"`
+	APIMode         string          `json:"api_mode" gorm:"size:10"`
+	TotalSlot       int             `json:"total_slot"`
+	UnassignedSlot  int             `json:"unassigned_slot"`
+	UsedSlot        int             `json:"used_slot"`
+	SlotUtilization float64         `json:"slot_utilization"`
+	RunningJobs     int             `json:"running_jobs"`
+	WorkerCount     int             `json:"worker_count"`
+	Nodes           NodeMetricsList `json:"nodes" gorm:"type:json"`
+	Error           string          `json:"error,omitempty" gorm:"size:1000"`
+	CollectedAt     time.Time       `json:"collected_at" gorm:"index:idx_cluster_metrics_cluster_time;not null"`
+}
+
+// TableName specifies the table name for MetricsSnapshot.
+// TableName 指定 MetricsSnapshot 的表名。
+func (MetricsSnapshot) TableName() string {
+	return "cluster_metrics_snapshots"
+}
+
+// ClusterMetrics is the payload served to the dashboard.
+// ClusterMetrics 是提供给仪表盘的数据。
+type ClusterMetrics struct {
+	ClusterID uint               `json:"cluster_id"`
+	Latest    *MetricsSnapshot   `json:"latest"`
+	History   []*MetricsSnapshot `json:"history"`
+}
+
+// NodeTarget describes one cluster node the collector can query.
+// NodeTarget 描述采集器可以查询的一个集群节点。
+type NodeTarget struct {
+	NodeID        uint
+	HostID        uint
+	HostIP        string
+	Role          string
+	APIPort       int
+	HazelcastPort int
+	WorkerPort    int
+	Running       bool
+	Online        bool
+}
@@ -0,0 +1,92 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package clustermetrics
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Repository provides data access for cluster metrics snapshots.
+// Repository 提供集群指标快照的数据访问。
+type Repository struct {
+	db *gorm.DB
+}
+
+// NewRepository creates a new cluster metrics repository.
+// NewRepository 创建新的集群指标仓库。
+func NewRepository(db *gorm.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// Create stores a snapshot.
+// Create 保存一条快照。
+func (r *Repository) Create(ctx context.Context, snapshot *MetricsSnapshot) error {
+	return r.db.WithContext(ctx).Create(snapshot).Error
+}
+
+// GetLatest returns the most recent snapshot of a cluster, or nil when none exists.
+// GetLatest 返回集群最近的快照，不存在时返回 nil。
+func (r *Repository) GetLatest(ctx context.Context, clusterID uint) (*MetricsSnapshot, error) {
+	var snapshot MetricsSnapshot
+	err := r.db.WithContext(ctx).
+		Where("cluster_id = ?", clusterID).
+		Order("collected_at DESC").
+		First(&snapshot).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &snapshot, nil
+}
+
+// ListSince returns up to limit snapshots of a cluster collected at or after since, oldest first.
+// ListSince 返回集群在 since 之后采集的最多 limit 条快照，按时间升序。
+func (r *Repository) ListSince(ctx context.Context, clusterID uint, since time.Time, limit int) ([]*MetricsSnapshot, error) {
+	var snapshots []*MetricsSnapshot
+	err := r.db.WithContext(ctx).
+		Where("cluster_id = ? AND collected_at >= ?", clusterID, since).
+		Order("collected_at DESC").
+		Limit(limit).
+		Find(&snapshots).Error
+	if err != nil {
+		return nil, err
+	}
+	for i, j := 0, len(snapshots)-1; i < j; i, j = i+1, j-1 {
+		snapshots[i], snapshots[j] = snapshots[j], snapshots[i]
+	}
+	return snapshots, nil
+}
+
+// DeleteBefore removes snapshots collected before the cutoff.
+// DeleteBefore 删除 cutoff 之前采集的快照。
+func (r *Repository) DeleteBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Where("collected_at < ?", cutoff).Delete(&MetricsSnapshot{})
+	return result.RowsAffected, result.Error
+}
+
+// DeleteByClusterID removes all snapshots of a cluster.
+// DeleteByClusterID 删除集群的全部快照。
+func (r *Repository) DeleteByClusterID(ctx context.Context, clusterID uint) error {
+	return r.db.WithContext(ctx).Where("cluster_id = ?", clusterID).Delete(&MetricsSnapshot{}).Error
+}
@@ -0,0 +1,342 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package clustermetrics
+
+import (
+	"context"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/seatunnel/seatunnelX/internal/logger"
+	"github.com/seatunnel/seatunnelX/internal/pkg/leader"
+	"github.com/seatunnel/seatunnelX/internal/pkg/worker"
+)
+
+const (
+	// DefaultCollectInterval is the default interval between collection rounds.
+	// DefaultCollectInterval 是采集轮次之间的默认间隔。
+	DefaultCollectInterval = time.Minute
+
+	// DefaultRetention is how long snapshots are kept.
+	// DefaultRetention 是快照的保留时长。
+	DefaultRetention = 24 * time.Hour
+
+	defaultHistoryWindow = time.Hour
+	maxHistoryPoints     = 1440
+
+	nodeNotRunningMessage  = "node not running / 节点未运行"
+	nodeNotReportedMessage = "not reported by engine / 引擎未上报该节点"
+)
+
+// ClusterProvider supplies the clusters and node endpoints the collector polls.
+// ClusterProvider 提供采集器需要轮询的集群及节点端点。
+type ClusterProvider interface {
+	// ListClusterIDs returns the IDs of all managed clusters.
+	// ListClusterIDs 返回所有受管集群的 ID。
+	ListClusterIDs(ctx context.Context) ([]uint, error)
+	// GetNodeTargets returns the nodes of a cluster with their host address and ports.
+	// GetNodeTargets 返回集群节点及其主机地址和端口。
+	GetNodeTargets(ctx context.Context, clusterID uint) ([]*NodeTarget, error)
+}
+
+// Service collects, stores and serves cluster overview metrics.
+// Service 采集、保存并提供集群概览指标。
+type Service struct {
+	repo      *Repository
+	provider  ClusterProvider
+	client    EngineClient
+	interval  time.Duration
+	retention time.Duration
+	now       func() time.Time
+	runtime   sync.Once
+}
+
+// NewService creates a new cluster metrics service.
+// NewService 创建新的集群指标服务。
+func NewService(repo *Repository, provider ClusterProvider) *Service {
+	return &Service{
+		repo:      repo,
+		provider:  provider,
+		client:    NewHTTPEngineClient(),
+		interval:  DefaultCollectInterval,
+		retention: DefaultRetention,
+		now:       time.Now,
+	}
+}
+
+// StartCollector starts the background collection loop.
+// StartCollector 启动后台采集循环。
+func (s *Service) StartCollector(ctx context.Context) {
+	if s == nil || s.repo == nil || s.provider == nil {
+		return
+	}
+	s.runtime.Do(func() {
+		go func() {
+			defer worker.Start("cluster-metrics-collector")()
+
+			ticker := time.NewTicker(s.interval)
+			defer ticker.Stop()
+
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					if leader.IsLeader() {
+						s.collectRound(ctx)
+					}
+				}
+			}
+		}()
+	})
+}
+
+// collectRound collects every cluster once and prunes expired snapshots.
+// collectRound 对所有集群各采集一次并清理过期快照。
+func (s *Service) collectRound(ctx context.Context) {
+	clusterIDs, err := s.provider.ListClusterIDs(ctx)
+	if err != nil {
+		logger.WarnF(ctx, "[ClusterMetrics] list clusters failed: %v", err)
+		return
+	}
+	for _, clusterID := range clusterIDs {
+		if _, err := s.CollectCluster(ctx, clusterID); err != nil {
+			logger.WarnF(ctx, "[ClusterMetrics] collect failed: cluster=%d, err=%v", clusterID, err)
+		}
+	}
+	if _, err := s.repo.DeleteBefore(ctx, s.now().Add(-s.retention)); err != nil {
+		logger.WarnF(ctx, "[ClusterMetrics] prune snapshots failed: %v", err)
+	}
+}
+
+// CollectCluster polls the engine of one cluster and stores the snapshot.
+// An unreachable engine still yields a stored snapshot carrying the error.
+// CollectCluster 轮询单个集群的引擎并保存快照；引擎不可达时仍保存带错误信息的快照。
+func (s *Service) CollectCluster(ctx context.Context, clusterID uint) (*MetricsSnapshot, error) {
+	if s.provider == nil {
+		return nil, ErrClusterProviderUnavailable
+	}
+	nodes, err := s.provider.GetNodeTargets(ctx, clusterID)
+	if err != nil {
+		return nil, err
+	}
+	snapshot := s.collectSnapshot(ctx, clusterID, nodes)
+	if err := s.repo.Create(ctx, snapshot); err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// GetClusterMetrics returns the latest snapshot and the history since the given time.
+// A cluster without snapshots is collected on demand.
+// GetClusterMetrics 返回最新快照及指定时间以来的历史；尚无快照的集群会即时采集一次。
+func (s *Service) GetClusterMetrics(ctx context.Context, clusterID uint, since time.Time, limit int) (*ClusterMetrics, error) {
+	if since.IsZero() {
+		since = s.now().Add(-defaultHistoryWindow)
+	}
+	if limit <= 0 || limit > maxHistoryPoints {
+		limit = maxHistoryPoints
+	}
+
+	latest, err := s.repo.GetLatest(ctx, clusterID)
+	if err != nil {
+		return nil, err
+	}
+	if latest == nil {
+		if latest, err = s.CollectCluster(ctx, clusterID); err != nil {
+			return nil, err
+		}
+	}
+
+	history, err := s.repo.ListSince(ctx, clusterID, since, limit)
+	if err != nil {
+		return nil, err
+	}
+	return &ClusterMetrics{ClusterID: clusterID, Latest: latest, History: history}, nil
+}
+
+// DeleteClusterMetrics removes all snapshots of a cluster.
+// DeleteClusterMetrics 删除集群的全部快照。
+func (s *Service) DeleteClusterMetrics(ctx context.Context, clusterID uint) error {
+	return s.repo.DeleteByClusterID(ctx, clusterID)
+}
+
+// collectSnapshot queries the first reachable engine endpoint for the cluster-wide overview,
+// running jobs and per-member system monitoring, and maps members back to nodes.
+// collectSnapshot 向首个可达的引擎端点查询集群概览、运行中作业和各成员系统监控信息，并将成员映射回节点。
+func (s *Service) collectSnapshot(ctx context.Context, clusterID uint, nodes []*NodeTarget) *MetricsSnapshot {
+	snapshot := &MetricsSnapshot{ClusterID: clusterID, CollectedAt: s.now()}
+
+	var (
+		endpoint *Endpoint
+		overview *EngineOverview
+		lastErr  error = ErrNoEngineEndpoint
+	)
+	for _, candidate := range engineEndpoints(nodes) {
+		result, err := s.client.GetOverview(ctx, candidate)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		endpoint, overview = candidate, result
+		break
+	}
+	if endpoint == nil {
+		snapshot.Error = lastErr.Error()
+		snapshot.Nodes = buildNodeMetrics(nodes, nil, lastErr.Error())
+		return snapshot
+	}
+
+	snapshot.APIMode = endpoint.APIMode()
+	snapshot.TotalSlot = overview.TotalSlot
+	snapshot.UnassignedSlot = overview.UnassignedSlot
+	snapshot.UsedSlot = overview.TotalSlot - overview.UnassignedSlot
+	if snapshot.UsedSlot < 0 {
+		snapshot.UsedSlot = 0
+	}
+	if overview.TotalSlot > 0 {
+		snapshot.SlotUtilization = float64(snapshot.UsedSlot) * 100 / float64(overview.TotalSlot)
+	}
+	snapshot.WorkerCount = overview.Workers
+
+	snapshot.RunningJobs = overview.RunningJobs
+	if count, err := s.client.CountRunningJobs(ctx, endpoint); err == nil {
+		snapshot.RunningJobs = count
+	} else {
+		logger.WarnF(ctx, "[ClusterMetrics] list running jobs failed, using overview count: cluster=%d, err=%v", clusterID, err)
+	}
+
+	members, err := s.client.GetSystemMonitoring(ctx, endpoint)
+	if err != nil {
+		snapshot.Error = err.Error()
+		snapshot.Nodes = buildNodeMetrics(nodes, nil, err.Error())
+		return snapshot
+	}
+	snapshot.Nodes = buildNodeMetrics(nodes, members, nodeNotReportedMessage)
+	return snapshot
+}
+
+// engineEndpoints lists the REST endpoints of running nodes on online hosts: REST API V2 on the
+// configured HTTP port first, then REST API V1 on the Hazelcast port; masters before workers.
+// engineEndpoints 列出在线主机上运行中节点的 REST 端点：优先使用配置的 HTTP 端口上的 REST API V2，
+// 其次为 Hazelcast 端口上的 REST API V1；master 优先于 worker。
+func engineEndpoints(nodes []*NodeTarget) []*Endpoint {
+	candidates := make([]*NodeTarget, 0, len(nodes))
+	for _, node := range nodes {
+		if node != nil && node.Running && node.Online && strings.TrimSpace(node.HostIP) != "" {
+			candidates = append(candidates, node)
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Role != "worker" && candidates[j].Role == "worker"
+	})
+
+	endpoints := make([]*Endpoint, 0, len(candidates)*2)
+	for _, node := range candidates {
+		if node.APIPort > 0 {
+			endpoints = append(endpoints, &Endpoint{BaseURL: "http://" + net.JoinHostPort(node.HostIP, strconv.Itoa(node.APIPort))})
+		}
+	}
+	for _, node := range candidates {
+		if node.HazelcastPort > 0 {
+			endpoints = append(endpoints, &Endpoint{BaseURL: "http://" + net.JoinHostPort(node.HostIP, strconv.Itoa(node.HazelcastPort)), Legacy: true})
+		}
+	}
+	return endpoints
+}
+
+// buildNodeMetrics maps system monitoring members to nodes by "host:port", falling back to a
+// unique port match when the engine reports hostnames instead of IPs.
+// buildNodeMetrics 按 "host:port" 将系统监控成员映射到节点；引擎上报主机名而非 IP 时，回退为端口唯一匹配。
+func buildNodeMetrics(nodes []*NodeTarget, members []map[string]string, missingMessage string) NodeMetricsList {
+	byAddress := make(map[string]map[string]string, len(members))
+	byPort := make(map[string][]map[string]string, len(members))
+	for _, member := range members {
+		port := member["port"]
+		byAddress[net.JoinHostPort(member["host"], port)] = member
+		byPort[port] = append(byPort[port], member)
+	}
+
+	result := make(NodeMetricsList, 0, len(nodes))
+	for _, node := range nodes {
+		if node == nil {
+			continue
+		}
+		metrics := &NodeMetrics{NodeID: node.NodeID, HostID: node.HostID, Role: node.Role}
+		result = append(result, metrics)
+		if !node.Running {
+			metrics.Error = nodeNotRunningMessage
+			continue
+		}
+
+		var member map[string]string
+		for _, port := range []int{node.HazelcastPort, node.WorkerPort} {
+			if port <= 0 {
+				continue
+			}
+			portText := strconv.Itoa(port)
+			if found, ok := byAddress[net.JoinHostPort(node.HostIP, portText)]; ok {
+				member = found
+				break
+			}
+			if candidates := byPort[portText]; len(candidates) == 1 && countNodesWithPort(nodes, port) == 1 {
+				member = candidates[0]
+				break
+			}
+		}
+		if member == nil {
+			metrics.Error = missingMessage
+			continue
+		}
+		applyMemberMetrics(metrics, member)
+	}
+	return result
+}
+
+func countNodesWithPort(nodes []*NodeTarget, port int) int {
+	count := 0
+	for _, node := range nodes {
+		if node != nil && (node.HazelcastPort == port || node.WorkerPort == port) {
+			count++
+		}
+	}
+	return count
+}
+
+// applyMemberMetrics copies the JVM stats of one system monitoring entry.
+// applyMemberMetrics 复制一条系统监控记录中的 JVM 指标。
+func applyMemberMetrics(metrics *NodeMetrics, member map[string]string) {
+	metrics.Reachable = true
+	metrics.Address = net.JoinHostPort(member["host"], member["port"])
+	metrics.Processors = int(parseCount(member["processors"]))
+	metrics.HeapUsedBytes = parseByteSize(member["heap.memory.used"])
+	metrics.HeapMaxBytes = parseByteSize(member["heap.memory.max"])
+	metrics.HeapUsedPercent = parsePercent(member["heap.memory.used/max"])
+	if metrics.HeapUsedPercent == 0 && metrics.HeapMaxBytes > 0 {
+		metrics.HeapUsedPercent = float64(metrics.HeapUsedBytes) * 100 / float64(metrics.HeapMaxBytes)
+	}
+	metrics.ProcessLoadPercent = parsePercent(member["load.process"])
+	metrics.SystemLoadPercent = parsePercent(member["load.system"])
+	metrics.ThreadCount = int(parseCount(member["thread.count"]))
+	metrics.MinorGCCount = parseCount(member["minor.gc.count"])
+	metrics.MajorGCCount = parseCount(member["major.gc.count"])
+}
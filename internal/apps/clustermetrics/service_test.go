@@ -0,0 +1,203 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package clustermetrics
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func setupClusterMetricsTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	tempDir, err := os.MkdirTemp("", "cluster_metrics_test_*")
+	if err != nil {
+		t.Fatalf("create temp dir: %v", err)
+	}
+	database, err := gorm.Open(sqlite.Open(filepath.Join(tempDir, "test.db")), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		_ = os.RemoveAll(tempDir)
+		t.Fatalf("open database: %v", err)
+	}
+	if err := database.AutoMigrate(&MetricsSnapshot{}); err != nil {
+		_ = os.RemoveAll(tempDir)
+		t.Fatalf("migrate snapshot table: %v", err)
+	}
+	t.Cleanup(func() {
+		if sqlDB, _ := database.DB(); sqlDB != nil {
+			_ = sqlDB.Close()
+		}
+		_ = os.RemoveAll(tempDir)
+	})
+	return database
+}
+
+type fakeClusterProvider struct {
+	nodes map[uint][]*NodeTarget
+}
+
+func (p *fakeClusterProvider) ListClusterIDs(ctx context.Context) ([]uint, error) {
+	ids := make([]uint, 0, len(p.nodes))
+	for id := range p.nodes {
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+func (p *fakeClusterProvider) GetNodeTargets(ctx context.Context, clusterID uint) ([]*NodeTarget, error) {
+	return p.nodes[clusterID], nil
+}
+
+type fakeEngineClient struct {
+	failing  map[string]bool
+	overview *EngineOverview
+	running  int
+	members  []map[string]string
+	queried  []string
+}
+
+func (c *fakeEngineClient) GetOverview(ctx context.Context, endpoint *Endpoint) (*EngineOverview, error) {
+	c.queried = append(c.queried, endpoint.url(overviewPath))
+	if c.failing[endpoint.BaseURL] {
+		return nil, errors.New("connection refused")
+	}
+	return c.overview, nil
+}
+
+func (c *fakeEngineClient) CountRunningJobs(ctx context.Context, endpoint *Endpoint) (int, error) {
+	return c.running, nil
+}
+
+func (c *fakeEngineClient) GetSystemMonitoring(ctx context.Context, endpoint *Endpoint) ([]map[string]string, error) {
+	return c.members, nil
+}
+
+func testNodeTargets() []*NodeTarget {
+	return []*NodeTarget{
+		{NodeID: 1, HostID: 11, HostIP: "10.0.0.1", Role: "master", APIPort: 8080, HazelcastPort: 5801, Running: true, Online: true},
+		{NodeID: 2, HostID: 12, HostIP: "10.0.0.2", Role: "worker", HazelcastPort: 5802, Running: true, Online: true},
+		{NodeID: 3, HostID: 13, HostIP: "10.0.0.3", Role: "worker", HazelcastPort: 5802, Running: false, Online: true},
+	}
+}
+
+func TestCollectClusterAggregatesEngineMetrics(t *testing.T) {
+	client := &fakeEngineClient{
+		overview: &EngineOverview{TotalSlot: 4, UnassignedSlot: 1, Workers: 1, RunningJobs: 9},
+		running:  2,
+		members: []map[string]string{
+			{"host": "10.0.0.1", "port": "5801", "processors": "8", "heap.memory.used": "512.0M", "heap.memory.max": "2.0G", "heap.memory.used/max": "25.00%", "load.process": "12.50%", "thread.count": "117"},
+			{"host": "10.0.0.2", "port": "5802", "processors": "4", "heap.memory.used": "1.0G", "heap.memory.max": "4.0G", "minor.gc.count": "6"},
+		},
+	}
+	service := NewService(NewRepository(setupClusterMetricsTestDB(t)), &fakeClusterProvider{nodes: map[uint][]*NodeTarget{7: testNodeTargets()}})
+	service.client = client
+
+	snapshot, err := service.CollectCluster(context.Background(), 7)
+	if err != nil {
+		t.Fatalf("CollectCluster returned error: %v", err)
+	}
+	if snapshot.APIMode != apiModeV2 || snapshot.UsedSlot != 3 || snapshot.SlotUtilization != 75 || snapshot.RunningJobs != 2 {
+		t.Fatalf("unexpected cluster metrics: %+v", snapshot)
+	}
+	if len(snapshot.Nodes) != 3 {
+		t.Fatalf("expected metrics for 3 nodes, got %+v", snapshot.Nodes)
+	}
+	master, worker, stopped := snapshot.Nodes[0], snapshot.Nodes[1], snapshot.Nodes[2]
+	if !master.Reachable || master.HeapUsedBytes != 512<<20 || master.HeapUsedPercent != 25 || master.ThreadCount != 117 {
+		t.Fatalf("unexpected master metrics: %+v", master)
+	}
+	if !worker.Reachable || worker.HeapUsedPercent != 25 || worker.MinorGCCount != 6 {
+		t.Fatalf("unexpected worker metrics: %+v", worker)
+	}
+	if stopped.Reachable || stopped.Error != nodeNotRunningMessage {
+		t.Fatalf("expected stopped node to be reported as not running, got %+v", stopped)
+	}
+
+	metrics, err := service.GetClusterMetrics(context.Background(), 7, time.Time{}, 0)
+	if err != nil {
+		t.Fatalf("GetClusterMetrics returned error: %v", err)
+	}
+	if metrics.Latest == nil || metrics.Latest.ID != snapshot.ID || len(metrics.History) != 1 {
+		t.Fatalf("expected stored snapshot to be served, got %+v", metrics)
+	}
+	if len(metrics.Latest.Nodes) != 3 || metrics.Latest.Nodes[0].ThreadCount != 117 {
+		t.Fatalf("expected node metrics to round-trip through storage, got %+v", metrics.Latest.Nodes)
+	}
+}
+
+func TestCollectClusterFallsBackToLegacyEndpoint(t *testing.T) {
+	client := &fakeEngineClient{
+		failing:  map[string]bool{"http://10.0.0.1:8080": true},
+		overview: &EngineOverview{},
+	}
+	service := NewService(NewRepository(setupClusterMetricsTestDB(t)), &fakeClusterProvider{nodes: map[uint][]*NodeTarget{7: testNodeTargets()}})
+	service.client = client
+
+	snapshot, err := service.CollectCluster(context.Background(), 7)
+	if err != nil {
+		t.Fatalf("CollectCluster returned error: %v", err)
+	}
+	if snapshot.APIMode != apiModeV1 || snapshot.Error != "" {
+		t.Fatalf("expected legacy endpoint to be used, got %+v", snapshot)
+	}
+	if len(client.queried) != 2 || client.queried[1] != "http://10.0.0.1:5801/hazelcast/rest/maps/overview" {
+		t.Fatalf("expected fallback to master REST API V1, got %v", client.queried)
+	}
+}
+
+func TestCollectClusterStoresErrorWhenEngineUnreachable(t *testing.T) {
+	nodes := testNodeTargets()
+	for _, node := range nodes {
+		node.Online = false
+	}
+	service := NewService(NewRepository(setupClusterMetricsTestDB(t)), &fakeClusterProvider{nodes: map[uint][]*NodeTarget{7: nodes}})
+	service.client = &fakeEngineClient{}
+
+	snapshot, err := service.CollectCluster(context.Background(), 7)
+	if err != nil {
+		t.Fatalf("CollectCluster returned error: %v", err)
+	}
+	if snapshot.Error != ErrNoEngineEndpoint.Error() || snapshot.ID == 0 {
+		t.Fatalf("expected stored snapshot with endpoint error, got %+v", snapshot)
+	}
+}
+
+func TestParseByteSize(t *testing.T) {
+	testCases := map[string]int64{
+		"135.5M": int64(135.5 * (1 << 20)),
+		"2G":     2 << 30,
+		"512KB":  512 << 10,
+		"1024":   1024,
+		"n/a":    0,
+	}
+	for input, want := range testCases {
+		if got := parseByteSize(input); got != want {
+			t.Fatalf("parseByteSize(%q) = %d, want %d", input, got, want)
+		}
+	}
+}
@@ -0,0 +1,192 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/seatunnel/seatunnelX/internal/logger"
+)
+
+// ErrClusterRestarterUnavailable is returned when a restart is requested but no restarter is configured.
+// ErrClusterRestarterUnavailable 表示请求了重启但未配置重启器。
+var ErrClusterRestarterUnavailable = errors.New("cluster restarter is not configured")
+
+// ClusterRestarter restarts the nodes of a cluster after configs are applied.
+// ClusterRestarter 在配置应用后重启集群节点。
+type ClusterRestarter interface {
+	// RollingRestartCluster restarts nodes one batch at a time and reports whether every node rejoined.
+	// RollingRestartCluster 分批重启节点，并返回是否所有节点都重新加入集群。
+	RollingRestartCluster(ctx context.Context, clusterID uint) (bool, string, error)
+}
+
+// ApplyConfigRequest 编辑并应用集群配置请求
+type ApplyConfigRequest struct {
+	ConfigType     ConfigType `json:"config_type" binding:"required"`
+	Content        string     `json:"content" binding:"required"`
+	Comment        string     `json:"comment"`
+	RollingRestart bool       `json:"rolling_restart"` // 全部节点应用成功后滚动重启集群
+}
+
+// AppliedNode 已应用配置的节点
+type AppliedNode struct {
+	HostID     uint   `json:"host_id"`
+	HostIP     string `json:"host_ip,omitempty"`
+	BackupPath string `json:"backup_path,omitempty"` // 节点上原文件的备份路径
+}
+
+// ApplyRestartResult 应用配置后的重启结果
+type ApplyRestartResult struct {
+	Triggered bool   `json:"triggered"`
+	Success   bool   `json:"success"`
+	Message   string `json:"message"`
+}
+
+// ApplyConfigResult 应用集群配置结果
+type ApplyConfigResult struct {
+	ConfigType      ConfigType          `json:"config_type"`
+	TemplateVersion int                 `json:"template_version"`
+	SyncedCount     int                 `json:"synced_count"`
+	AppliedNodes    []*AppliedNode      `json:"applied_nodes"`
+	PushErrors      []*PushError        `json:"push_errors"`
+	Restart         *ApplyRestartResult `json:"restart,omitempty"`
+}
+
+// SetClusterRestarter sets the restarter used after configs are applied.
+// SetClusterRestarter 设置配置应用后使用的集群重启器。
+func (s *Service) SetClusterRestarter(restarter ClusterRestarter) {
+	s.restarter = restarter
+}
+
+// ApplyClusterConfig validates a new cluster config, stores it as the template and node configs,
+// applies it to every node through the Agent with automatic backup, and optionally rolling-restarts the cluster.
+// ApplyClusterConfig 校验新的集群配置，保存为模板与节点配置，
+// 通过 Agent 自动备份后应用到所有节点，并可选地滚动重启集群。
+func (s *Service) ApplyClusterConfig(ctx context.Context, clusterID uint, req *ApplyConfigRequest, userID uint) (*ApplyConfigResult, error) {
+	if err := validateConfigContent(req.ConfigType, req.Content); err != nil {
+		return nil, err
+	}
+	if req.RollingRestart && s.restarter == nil {
+		return nil, ErrClusterRestarterUnavailable
+	}
+
+	template, err := s.repo.GetTemplate(ctx, clusterID, req.ConfigType)
+	if err != nil {
+		return nil, ErrTemplateNotFound
+	}
+	nodeConfigs, err := s.repo.ListNodeConfigs(ctx, clusterID, req.ConfigType)
+	if err != nil {
+		return nil, err
+	}
+
+	comment := req.Comment
+	if comment == "" {
+		comment = "Applied to cluster"
+	}
+
+	result := &ApplyConfigResult{
+		ConfigType:   req.ConfigType,
+		AppliedNodes: make([]*AppliedNode, 0, len(nodeConfigs)),
+		PushErrors:   make([]*PushError, 0),
+	}
+
+	err = s.repo.Transaction(ctx, func(tx *Repository) error {
+		if template.Content == req.Content {
+			return nil
+		}
+		template.Content = req.Content
+		template.Version = template.Version + 1
+		template.UpdatedBy = userID
+		template.UpdatedAt = time.Now()
+		if err := tx.Update(ctx, template); err != nil {
+			return err
+		}
+		return tx.CreateVersion(ctx, &ConfigVersion{
+			ConfigID:  template.ID,
+			Version:   template.Version,
+			Content:   req.Content,
+			Comment:   comment,
+			CreatedBy: userID,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	result.TemplateVersion = template.Version
+
+	result.SyncedCount, err = s.syncNodeConfigsFromTemplate(ctx, template, nodeConfigs, userID, comment)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, nc := range nodeConfigs {
+		hostID := *nc.HostID
+		if s.nodeInfoProvider == nil || s.agentClient == nil {
+			result.PushErrors = append(result.PushErrors, s.newPushError(ctx, hostID, "config agent client is not configured"))
+			continue
+		}
+		installDir, err := s.nodeInfoProvider.GetNodeInstallDir(ctx, clusterID, hostID)
+		if err != nil || installDir == "" {
+			message := "节点安装目录为空"
+			if err != nil {
+				message = "获取节点安装目录失败: " + err.Error()
+			}
+			result.PushErrors = append(result.PushErrors, s.newPushError(ctx, hostID, message))
+			continue
+		}
+		rendered, err := s.renderNodeConfig(ctx, clusterID, hostID, installDir, req.ConfigType, req.Content)
+		if err != nil {
+			result.PushErrors = append(result.PushErrors, s.newPushError(ctx, hostID, "渲染配置模板失败: "+err.Error()))
+			continue
+		}
+		backupPath, err := s.agentClient.ApplyConfig(ctx, hostID, installDir, req.ConfigType, rendered)
+		if err != nil {
+			result.PushErrors = append(result.PushErrors, s.newPushError(ctx, hostID, "应用配置失败: "+err.Error()))
+			continue
+		}
+		s.syncDerivedRuntimeMetadata(ctx, clusterID, nc.HostID, req.ConfigType, rendered)
+
+		applied := &AppliedNode{HostID: hostID, BackupPath: backupPath}
+		if s.hostProvider != nil {
+			if host, err := s.hostProvider.GetHostByID(ctx, hostID); err == nil {
+				applied.HostIP = host.IPAddress
+			}
+		}
+		result.AppliedNodes = append(result.AppliedNodes, applied)
+	}
+
+	if !req.RollingRestart {
+		return result, nil
+	}
+	if len(result.PushErrors) > 0 {
+		// Restarting with partially applied configs could split the cluster.
+		// 部分节点未应用配置时重启可能导致集群分裂。
+		result.Restart = &ApplyRestartResult{Message: "rolling restart skipped because some nodes failed to apply the config"}
+		return result, nil
+	}
+
+	success, message, err := s.restarter.RollingRestartCluster(ctx, clusterID)
+	if err != nil {
+		logger.WarnF(ctx, "[Config] rolling restart after config apply failed: cluster=%d, err=%v", clusterID, err)
+		message = err.Error()
+	}
+	result.Restart = &ApplyRestartResult{Triggered: true, Success: success && err == nil, Message: message}
+	return result, nil
+}
@@ -0,0 +1,182 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type testClusterRestarter struct {
+	calls []uint
+}
+
+func (r *testClusterRestarter) RollingRestartCluster(_ context.Context, clusterID uint) (bool, string, error) {
+	r.calls = append(r.calls, clusterID)
+	return true, "Rolling restart completed successfully", nil
+}
+
+func TestApplyClusterConfigPushesToNodesAndRollingRestarts(t *testing.T) {
+	service, db, _, _ := newConfigTestService(t)
+	ctx := context.Background()
+	clusterID := uint(51)
+	original := "seatunnel:\n  engine:\n    backup-count: 1\n"
+
+	if err := db.WithContext(ctx).Create(&Config{
+		ClusterID:  clusterID,
+		ConfigType: ConfigTypeSeatunnel,
+		FilePath:   GetConfigFilePath(ConfigTypeSeatunnel),
+		Content:    original,
+		Version:    1,
+	}).Error; err != nil {
+		t.Fatalf("failed to create template: %v", err)
+	}
+	for _, hostID := range []uint{61, 62} {
+		id := hostID
+		if err := db.WithContext(ctx).Create(&Config{
+			ClusterID:  clusterID,
+			HostID:     &id,
+			ConfigType: ConfigTypeSeatunnel,
+			FilePath:   GetConfigFilePath(ConfigTypeSeatunnel),
+			Content:    original,
+			Version:    1,
+		}).Error; err != nil {
+			t.Fatalf("failed to create node config: %v", err)
+		}
+	}
+
+	agent := &fileAgentClient{files: map[string]string{
+		"61/seatunnel.yaml": original,
+		"62/seatunnel.yaml": original,
+	}}
+	service.agentClient = agent
+
+	updated := "seatunnel:\n  engine:\n    backup-count: 2\n"
+	if _, err := service.ApplyClusterConfig(ctx, clusterID, &ApplyConfigRequest{
+		ConfigType:     ConfigTypeSeatunnel,
+		Content:        updated,
+		RollingRestart: true,
+	}, 1); !errors.Is(err, ErrClusterRestarterUnavailable) {
+		t.Fatalf("expected ErrClusterRestarterUnavailable, got %v", err)
+	}
+
+	restarter := &testClusterRestarter{}
+	service.SetClusterRestarter(restarter)
+
+	_, err := service.ApplyClusterConfig(ctx, clusterID, &ApplyConfigRequest{
+		ConfigType: ConfigTypeSeatunnel,
+		Content:    "seatunnel:\n  engine:\n    backup-count: -1\n",
+	}, 1)
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("expected validation error for negative backup-count, got %v", err)
+	}
+
+	result, err := service.ApplyClusterConfig(ctx, clusterID, &ApplyConfigRequest{
+		ConfigType:     ConfigTypeSeatunnel,
+		Content:        updated,
+		RollingRestart: true,
+	}, 1)
+	if err != nil {
+		t.Fatalf("ApplyClusterConfig returned error: %v", err)
+	}
+	if result.TemplateVersion != 2 || result.SyncedCount != 2 || len(result.AppliedNodes) != 2 || len(result.PushErrors) != 0 {
+		t.Fatalf("unexpected apply result: %+v", result)
+	}
+	for _, node := range result.AppliedNodes {
+		if node.BackupPath == "" || agent.files[node.BackupPath] != original {
+			t.Fatalf("expected backup of original config for host %d, got %q", node.HostID, node.BackupPath)
+		}
+	}
+	if agent.files["61/seatunnel.yaml"] != updated || agent.files["62/seatunnel.yaml"] != updated {
+		t.Fatalf("expected updated config on all nodes, got %v", agent.files)
+	}
+	if result.Restart == nil || !result.Restart.Triggered || !result.Restart.Success || len(restarter.calls) != 1 {
+		t.Fatalf("expected one successful rolling restart, got %+v (%v)", result.Restart, restarter.calls)
+	}
+}
+
+func TestRestoreConfigAppliesNodeConfigAndStoresVersion(t *testing.T) {
+	service, db, _, _ := newConfigTestService(t)
+	ctx := context.Background()
+	clusterID := uint(71)
+	hostID := uint(81)
+	current := "seatunnel:\n  engine:\n    backup-count: 3\n"
+	saved := "seatunnel:\n  engine:\n    backup-count: 1\n"
+
+	if err := db.WithContext(ctx).Create(&Config{
+		ClusterID:  clusterID,
+		HostID:     &hostID,
+		ConfigType: ConfigTypeSeatunnel,
+		FilePath:   GetConfigFilePath(ConfigTypeSeatunnel),
+		Content:    current,
+		Version:    4,
+	}).Error; err != nil {
+		t.Fatalf("failed to create node config: %v", err)
+	}
+	agent := &fileAgentClient{files: map[string]string{"81/seatunnel.yaml": current}}
+	service.agentClient = agent
+
+	applied, err := service.RestoreConfig(ctx, clusterID, &hostID, ConfigTypeSeatunnel, saved, "Restored from backup #1", 1)
+	if err != nil {
+		t.Fatalf("RestoreConfig returned error: %v", err)
+	}
+	if applied == nil || applied.BackupPath == "" || agent.files[applied.BackupPath] != current {
+		t.Fatalf("expected on-node backup of the current config, got %+v", applied)
+	}
+	if agent.files["81/seatunnel.yaml"] != saved {
+		t.Fatalf("expected restored content on node, got %q", agent.files["81/seatunnel.yaml"])
+	}
+	nodeConfig, err := service.repo.GetNodeConfig(ctx, clusterID, hostID, ConfigTypeSeatunnel)
+	if err != nil {
+		t.Fatalf("GetNodeConfig returned error: %v", err)
+	}
+	if nodeConfig.Content != saved || nodeConfig.Version != 5 {
+		t.Fatalf("expected node config version 5 with restored content, got v%d %q", nodeConfig.Version, nodeConfig.Content)
+	}
+
+	// A missing template is recreated without touching any node.
+	// 缺失的模板会被重新创建，且不会写入任何节点。
+	if applied, err := service.RestoreConfig(ctx, clusterID, nil, ConfigTypeSeatunnel, saved, "Restored from backup #1", 1); err != nil || applied != nil {
+		t.Fatalf("expected template restore without node apply, got %+v, %v", applied, err)
+	}
+	template, err := service.repo.GetTemplate(ctx, clusterID, ConfigTypeSeatunnel)
+	if err != nil || template.Content != saved || template.Version != 1 {
+		t.Fatalf("expected recreated template, got %+v, %v", template, err)
+	}
+
+	if _, err := service.RestoreConfig(ctx, clusterID, &hostID, ConfigTypeSeatunnel, "seatunnel:\n  engine:\n    backup-count: -1\n", "", 1); err == nil {
+		t.Fatal("expected invalid content to be rejected")
+	}
+}
+
+func TestValidateConfigContentChecksSchemaAndJVMOptions(t *testing.T) {
+	if err := validateConfigContent(ConfigTypeHazelcast, "hazelcast:\n  network:\n    port:\n      port: 70000\n"); err == nil {
+		t.Fatal("expected out-of-range hazelcast port to fail validation")
+	}
+	if err := validateConfigContent(ConfigTypeHazelcast, "hazelcast:\n  network:\n    join:\n      tcp-ip:\n        member-list: 10.0.0.1\n"); err == nil {
+		t.Fatal("expected scalar member-list to fail validation")
+	}
+	if err := validateConfigContent(ConfigTypeJVMOptions, "# heap\n-Xms2g\n\n-Xmx2g\n"); err != nil {
+		t.Fatalf("expected valid jvm options, got %v", err)
+	}
+	if err := validateConfigContent(ConfigTypeJVMOptions, "-Xms2g\nXmx2g\n"); err == nil {
+		t.Fatal("expected jvm option without '-' to fail validation")
+	}
+}
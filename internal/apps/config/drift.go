@@ -0,0 +1,429 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/seatunnel/seatunnelX/internal/logger"
+	"github.com/seatunnel/seatunnelX/internal/pkg/leader"
+	"github.com/seatunnel/seatunnelX/internal/pkg/worker"
+	"gopkg.in/yaml.v3"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// DefaultDriftCheckInterval is the default interval of the config drift detection job.
+// DefaultDriftCheckInterval 是配置漂移检测任务的默认间隔。
+const DefaultDriftCheckInterval = 10 * time.Minute
+
+// DriftConfigTypes are the config files compared between the Control Plane and nodes.
+// DriftConfigTypes 是在控制面与节点之间比对的配置文件。
+var DriftConfigTypes = []ConfigType{
+	ConfigTypeSeatunnel,
+	ConfigTypeHazelcast,
+	ConfigTypeHazelcastMaster,
+	ConfigTypeHazelcastWorker,
+}
+
+// ConfigDrift 节点配置漂移检测结果表
+// 每个节点的每种配置类型保留一条最新记录
+type ConfigDrift struct {
+	ID            uint       `json:"id" gorm:"primaryKey;autoIncrement"`
+	ClusterID     uint       `json:"cluster_id" gorm:"uniqueIndex:idx_config_drift_node;not null"`
+	HostID        uint       `json:"host_id" gorm:"uniqueIndex:idx_config_drift_node;not null"`
+	ConfigType    ConfigType `json:"config_type" gorm:"uniqueIndex:idx_config_drift_node;size:50;not null"`
+	ConfigID      uint       `json:"config_id"`                  // 比对的节点配置 ID
+	StoredVersion int        `json:"stored_version"`             // 比对时的已保存版本
+	Drifted       bool       `json:"drifted"`                    // 节点文件是否与已保存版本不一致
+	Error         string     `json:"error" gorm:"size:1024"`     // 最近一次拉取失败的原因
+	DetectedAt    *time.Time `json:"detected_at"`                // 首次发现漂移的时间
+	CheckedAt     time.Time  `json:"checked_at" gorm:"not null"` // 最近一次检测时间
+}
+
+// TableName 指定表名
+func (ConfigDrift) TableName() string {
+	return "config_drifts"
+}
+
+// ConfigDriftInfo 配置漂移信息（用于 API 响应）
+type ConfigDriftInfo struct {
+	ClusterID     uint       `json:"cluster_id"`
+	HostID        uint       `json:"host_id"`
+	HostName      string     `json:"host_name,omitempty"`
+	HostIP        string     `json:"host_ip,omitempty"`
+	ConfigType    ConfigType `json:"config_type"`
+	ConfigID      uint       `json:"config_id"`
+	StoredVersion int        `json:"stored_version"`
+	Drifted       bool       `json:"drifted"`
+	Error         string     `json:"error,omitempty"`
+	DetectedAt    *time.Time `json:"detected_at,omitempty"`
+	CheckedAt     time.Time  `json:"checked_at"`
+}
+
+// ResyncConfigRequest 重新同步节点配置请求
+// HostIDs 为空时重新同步所有已漂移的节点
+type ResyncConfigRequest struct {
+	HostIDs []uint `json:"host_ids"`
+}
+
+// SetDriftCheckInterval overrides the drift detection interval; it must be called before StartDriftDetector.
+// SetDriftCheckInterval 覆盖漂移检测间隔，需在 StartDriftDetector 之前调用。
+func (s *Service) SetDriftCheckInterval(interval time.Duration) {
+	s.driftInterval = interval
+}
+
+// StartDriftDetector starts the periodic config drift detection job once.
+// StartDriftDetector 启动周期性的配置漂移检测任务（仅启动一次）。
+func (s *Service) StartDriftDetector(ctx context.Context) {
+	if s == nil || s.repo == nil || s.nodeInfoProvider == nil || s.agentClient == nil {
+		return
+	}
+
+	interval := s.driftInterval
+	if interval <= 0 {
+		interval = DefaultDriftCheckInterval
+	}
+
+	s.driftRuntime.Do(func() {
+		go func() {
+			defer worker.Start("config-drift-detector")()
+
+			ticker := time.NewTicker(interval)
+			defer ticker.Stop()
+
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					if leader.IsLeader() {
+						s.runDriftDetectionRound(ctx)
+					}
+				}
+			}
+		}()
+	})
+}
+
+// runDriftDetectionRound checks every cluster that has node configs.
+// runDriftDetectionRound 检测所有存在节点配置的集群。
+func (s *Service) runDriftDetectionRound(ctx context.Context) {
+	clusterIDs, err := s.repo.ListNodeConfigClusterIDs(ctx, DriftConfigTypes)
+	if err != nil {
+		logger.WarnF(ctx, "[Config] list clusters for drift detection failed: %v", err)
+		return
+	}
+	for _, clusterID := range clusterIDs {
+		if ctx.Err() != nil {
+			return
+		}
+		if _, err := s.DetectClusterDrift(ctx, clusterID); err != nil {
+			logger.WarnF(ctx, "[Config] drift detection failed: cluster=%d, err=%v", clusterID, err)
+		}
+	}
+}
+
+// DetectClusterDrift pulls the drift-checked config files from every node of a cluster through the Agent
+// and compares them with the stored node configs.
+// DetectClusterDrift 通过 Agent 拉取集群所有节点上需检测的配置文件，并与已保存的节点配置比对。
+func (s *Service) DetectClusterDrift(ctx context.Context, clusterID uint) ([]*ConfigDriftInfo, error) {
+	return s.detectDrift(ctx, clusterID, nil)
+}
+
+// GetClusterDrift 获取集群最近一次的配置漂移检测结果
+func (s *Service) GetClusterDrift(ctx context.Context, clusterID uint) ([]*ConfigDriftInfo, error) {
+	drifts, err := s.repo.ListDrifts(ctx, clusterID)
+	if err != nil {
+		return nil, err
+	}
+	return s.toDriftInfos(ctx, drifts), nil
+}
+
+// GetDriftedConfigTypes returns the drifted config types of a cluster grouped by host ID.
+// GetDriftedConfigTypes 返回集群中已漂移的配置类型，按主机 ID 分组。
+func (s *Service) GetDriftedConfigTypes(ctx context.Context, clusterID uint) (map[uint][]string, error) {
+	drifts, err := s.repo.ListDrifts(ctx, clusterID)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[uint][]string)
+	for _, drift := range drifts {
+		if drift.Drifted {
+			result[drift.HostID] = append(result[drift.HostID], string(drift.ConfigType))
+		}
+	}
+	return result, nil
+}
+
+// ResyncNodeConfigs pushes the stored node configs back to drifted nodes and checks them again.
+// When hostIDs is empty, every drifted node of the cluster is re-synced.
+// ResyncNodeConfigs 将已保存的节点配置重新推送到漂移节点并再次检测。
+// hostIDs 为空时重新同步集群中所有已漂移的节点。
+func (s *Service) ResyncNodeConfigs(ctx context.Context, clusterID uint, hostIDs []uint) (*SyncAllResult, error) {
+	if s.nodeInfoProvider == nil || s.agentClient == nil {
+		return nil, errors.New("config agent client is not configured")
+	}
+
+	drifts, err := s.repo.ListDrifts(ctx, clusterID)
+	if err != nil {
+		return nil, err
+	}
+	selected := make(map[uint]bool, len(hostIDs))
+	for _, hostID := range hostIDs {
+		selected[hostID] = true
+	}
+
+	result := &SyncAllResult{PushErrors: make([]*PushError, 0)}
+	resynced := make(map[uint]bool)
+	for _, drift := range drifts {
+		if len(selected) > 0 {
+			if !selected[drift.HostID] {
+				continue
+			}
+		} else if !drift.Drifted {
+			continue
+		}
+
+		config, err := s.repo.GetNodeConfig(ctx, clusterID, drift.HostID, drift.ConfigType)
+		if err != nil {
+			result.PushErrors = append(result.PushErrors, s.newPushError(ctx, drift.HostID, "获取节点配置失败: "+err.Error()))
+			continue
+		}
+		installDir, err := s.nodeInfoProvider.GetNodeInstallDir(ctx, clusterID, drift.HostID)
+		if err != nil || installDir == "" {
+			message := "节点安装目录为空"
+			if err != nil {
+				message = "获取节点安装目录失败: " + err.Error()
+			}
+			result.PushErrors = append(result.PushErrors, s.newPushError(ctx, drift.HostID, message))
+			continue
+		}
+		rendered, err := s.pushNodeConfig(ctx, clusterID, drift.HostID, installDir, config.ConfigType, config.Content)
+		if err != nil {
+			result.PushErrors = append(result.PushErrors, s.newPushError(ctx, drift.HostID, "推送配置失败: "+err.Error()))
+			continue
+		}
+		s.syncDerivedRuntimeMetadata(ctx, clusterID, config.HostID, config.ConfigType, rendered)
+		result.SyncedCount++
+		resynced[drift.HostID] = true
+	}
+
+	if len(resynced) > 0 {
+		if _, err := s.detectDrift(ctx, clusterID, resynced); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// detectDrift compares the node configs of a cluster, optionally limited to some hosts.
+// detectDrift 比对集群的节点配置，可限定部分主机。
+func (s *Service) detectDrift(ctx context.Context, clusterID uint, hosts map[uint]bool) ([]*ConfigDriftInfo, error) {
+	if s.nodeInfoProvider == nil || s.agentClient == nil {
+		return nil, errors.New("config agent client is not configured")
+	}
+
+	drifts := make([]*ConfigDrift, 0)
+	installDirs := make(map[uint]string)
+	for _, configType := range DriftConfigTypes {
+		nodeConfigs, err := s.repo.ListNodeConfigs(ctx, clusterID, configType)
+		if err != nil {
+			return nil, err
+		}
+		for _, nc := range nodeConfigs {
+			hostID := *nc.HostID
+			if hosts != nil && !hosts[hostID] {
+				continue
+			}
+
+			drift := &ConfigDrift{
+				ClusterID:     clusterID,
+				HostID:        hostID,
+				ConfigType:    configType,
+				ConfigID:      nc.ID,
+				StoredVersion: nc.Version,
+				CheckedAt:     time.Now(),
+			}
+			previous, err := s.repo.GetDrift(ctx, clusterID, hostID, configType)
+			if err != nil && !errors.Is(err, ErrConfigNotFound) {
+				return nil, err
+			}
+
+			installDir, ok := installDirs[hostID]
+			if !ok {
+				installDir, err = s.nodeInfoProvider.GetNodeInstallDir(ctx, clusterID, hostID)
+				if err != nil {
+					// The node may have been removed from the cluster.
+					// 节点可能已从集群中移除。
+					continue
+				}
+				installDirs[hostID] = installDir
+			}
+
+			content, expected, pullErr := "", "", error(nil)
+			if installDir == "" {
+				pullErr = errors.New("node install dir is empty")
+			} else if expected, pullErr = s.renderNodeConfig(ctx, clusterID, hostID, installDir, configType, nc.Content); pullErr == nil {
+				content, pullErr = s.agentClient.PullConfig(ctx, hostID, installDir, configType)
+			}
+			if pullErr != nil {
+				// Keep the last known drift state when the node cannot be reached.
+				// 无法访问节点时保留上一次的漂移状态。
+				drift.Error = pullErr.Error()
+				if previous != nil {
+					drift.Drifted = previous.Drifted
+					drift.DetectedAt = previous.DetectedAt
+				}
+			} else {
+				drift.Drifted = !sameConfigContent(configType, expected, content)
+				if drift.Drifted {
+					drift.DetectedAt = &drift.CheckedAt
+					if previous != nil && previous.Drifted && previous.DetectedAt != nil {
+						drift.DetectedAt = previous.DetectedAt
+					}
+				}
+			}
+
+			if err := s.repo.SaveDrift(ctx, drift); err != nil {
+				return nil, err
+			}
+			if drift.Drifted && (previous == nil || !previous.Drifted) {
+				logger.WarnF(ctx, "[Config] config drift detected: cluster=%d, host=%d, type=%s", clusterID, hostID, configType)
+			}
+			drifts = append(drifts, drift)
+		}
+	}
+	return s.toDriftInfos(ctx, drifts), nil
+}
+
+// toDriftInfos 转换为 ConfigDriftInfo 列表
+func (s *Service) toDriftInfos(ctx context.Context, drifts []*ConfigDrift) []*ConfigDriftInfo {
+	hosts := make(map[uint]*HostInfo)
+	infos := make([]*ConfigDriftInfo, 0, len(drifts))
+	for _, drift := range drifts {
+		info := &ConfigDriftInfo{
+			ClusterID:     drift.ClusterID,
+			HostID:        drift.HostID,
+			ConfigType:    drift.ConfigType,
+			ConfigID:      drift.ConfigID,
+			StoredVersion: drift.StoredVersion,
+			Drifted:       drift.Drifted,
+			Error:         drift.Error,
+			DetectedAt:    drift.DetectedAt,
+			CheckedAt:     drift.CheckedAt,
+		}
+		if s.hostProvider != nil {
+			host, ok := hosts[drift.HostID]
+			if !ok {
+				host, _ = s.hostProvider.GetHostByID(ctx, drift.HostID)
+				hosts[drift.HostID] = host
+			}
+			if host != nil {
+				info.HostName = host.Name
+				info.HostIP = host.IPAddress
+			}
+		}
+		infos = append(infos, info)
+	}
+	return infos
+}
+
+// newPushError 构造推送错误信息，尽量带上主机 IP
+func (s *Service) newPushError(ctx context.Context, hostID uint, message string) *PushError {
+	pushErr := &PushError{HostID: hostID, Message: message}
+	if s.hostProvider != nil {
+		if host, err := s.hostProvider.GetHostByID(ctx, hostID); err == nil {
+			pushErr.HostIP = host.IPAddress
+		}
+	}
+	return pushErr
+}
+
+// sameConfigContent compares two config contents, ignoring YAML formatting and comments when both parse.
+// sameConfigContent 比较两份配置内容；两者均可解析时忽略 YAML 格式与注释差异。
+func sameConfigContent(configType ConfigType, stored, actual string) bool {
+	if isYAMLConfigType(configType) {
+		var storedValue, actualValue interface{}
+		if yaml.Unmarshal([]byte(stored), &storedValue) == nil && yaml.Unmarshal([]byte(actual), &actualValue) == nil {
+			return reflect.DeepEqual(storedValue, actualValue)
+		}
+	}
+	return normalizeConfigText(stored) == normalizeConfigText(actual)
+}
+
+// isYAMLConfigType 判断配置类型是否为 YAML 文件
+func isYAMLConfigType(configType ConfigType) bool {
+	return strings.HasSuffix(string(configType), ".yaml")
+}
+
+// normalizeConfigText 去除行尾空白与换行符差异
+func normalizeConfigText(content string) string {
+	lines := strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t")
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+// GetDrift 获取节点某类型配置的漂移记录
+func (r *Repository) GetDrift(ctx context.Context, clusterID uint, hostID uint, configType ConfigType) (*ConfigDrift, error) {
+	var drift ConfigDrift
+	err := r.db.WithContext(ctx).
+		Where("cluster_id = ? AND host_id = ? AND config_type = ?", clusterID, hostID, configType).
+		First(&drift).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrConfigNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &drift, nil
+}
+
+// SaveDrift 保存漂移记录（按集群、主机、配置类型覆盖）
+func (r *Repository) SaveDrift(ctx context.Context, drift *ConfigDrift) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "cluster_id"}, {Name: "host_id"}, {Name: "config_type"}},
+		DoUpdates: clause.AssignmentColumns([]string{"config_id", "stored_version", "drifted", "error", "detected_at", "checked_at"}),
+	}).Create(drift).Error
+}
+
+// ListDrifts 获取集群所有漂移记录
+func (r *Repository) ListDrifts(ctx context.Context, clusterID uint) ([]*ConfigDrift, error) {
+	var drifts []*ConfigDrift
+	err := r.db.WithContext(ctx).
+		Where("cluster_id = ?", clusterID).
+		Order("host_id").Order("config_type").
+		Find(&drifts).Error
+	return drifts, err
+}
+
+// ListNodeConfigClusterIDs 获取存在指定类型节点配置的集群 ID 列表
+func (r *Repository) ListNodeConfigClusterIDs(ctx context.Context, configTypes []ConfigType) ([]uint, error) {
+	var clusterIDs []uint
+	err := r.db.WithContext(ctx).Model(&Config{}).
+		Where("host_id IS NOT NULL AND config_type IN ?", configTypes).
+		Distinct().Order("cluster_id").
+		Pluck("cluster_id", &clusterIDs).Error
+	return clusterIDs, err
+}
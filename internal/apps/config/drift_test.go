@@ -0,0 +1,114 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"context"
+	"fmt"
+	"testing"
+)
+
+type fileAgentClient struct {
+	files map[string]string
+}
+
+func (c *fileAgentClient) PullConfig(_ context.Context, hostID uint, _ string, configType ConfigType) (string, error) {
+	content, ok := c.files[fmt.Sprintf("%d/%s", hostID, configType)]
+	if !ok {
+		return "", fmt.Errorf("%s not found", configType)
+	}
+	return content, nil
+}
+
+func (c *fileAgentClient) PushConfig(_ context.Context, hostID uint, _ string, configType ConfigType, content string) error {
+	c.files[fmt.Sprintf("%d/%s", hostID, configType)] = content
+	return nil
+}
+
+func (c *fileAgentClient) ApplyConfig(_ context.Context, hostID uint, _ string, configType ConfigType, content string) (string, error) {
+	key := fmt.Sprintf("%d/%s", hostID, configType)
+	backupPath := ""
+	if previous, ok := c.files[key]; ok {
+		backupPath = key + ".bak"
+		c.files[backupPath] = previous
+	}
+	c.files[key] = content
+	return backupPath, nil
+}
+
+func TestDetectClusterDriftAndResync(t *testing.T) {
+	service, db, _, _ := newConfigTestService(t)
+	if err := db.AutoMigrate(&ConfigDrift{}); err != nil {
+		t.Fatalf("failed to migrate config drift: %v", err)
+	}
+	ctx := context.Background()
+	clusterID := uint(31)
+	stored := "seatunnel:\n  engine:\n    backup-count: 1\n"
+
+	for _, hostID := range []uint{41, 42} {
+		id := hostID
+		if err := db.WithContext(ctx).Create(&Config{
+			ClusterID:  clusterID,
+			HostID:     &id,
+			ConfigType: ConfigTypeSeatunnel,
+			FilePath:   GetConfigFilePath(ConfigTypeSeatunnel),
+			Content:    stored,
+			Version:    1,
+		}).Error; err != nil {
+			t.Fatalf("failed to create node config: %v", err)
+		}
+	}
+
+	agent := &fileAgentClient{files: map[string]string{
+		// Formatting and comments differ but the content is the same.
+		// 格式与注释不同但内容一致。
+		"41/seatunnel.yaml": "# edited\nseatunnel:\n  engine:\n      backup-count: 1\n",
+		"42/seatunnel.yaml": "seatunnel:\n  engine:\n    backup-count: 3\n",
+	}}
+	service.agentClient = agent
+
+	drifts, err := service.DetectClusterDrift(ctx, clusterID)
+	if err != nil {
+		t.Fatalf("DetectClusterDrift returned error: %v", err)
+	}
+	if len(drifts) != 2 {
+		t.Fatalf("expected 2 drift results, got %d", len(drifts))
+	}
+	drifted, err := service.GetDriftedConfigTypes(ctx, clusterID)
+	if err != nil {
+		t.Fatalf("GetDriftedConfigTypes returned error: %v", err)
+	}
+	if len(drifted) != 1 || len(drifted[42]) != 1 || drifted[42][0] != string(ConfigTypeSeatunnel) {
+		t.Fatalf("expected only host 42 to drift, got %v", drifted)
+	}
+
+	result, err := service.ResyncNodeConfigs(ctx, clusterID, nil)
+	if err != nil {
+		t.Fatalf("ResyncNodeConfigs returned error: %v", err)
+	}
+	if result.SyncedCount != 1 || len(result.PushErrors) != 0 {
+		t.Fatalf("unexpected resync result: %+v", result)
+	}
+	if agent.files["42/seatunnel.yaml"] != stored {
+		t.Fatalf("expected stored config to be pushed, got %q", agent.files["42/seatunnel.yaml"])
+	}
+	drifted, err = service.GetDriftedConfigTypes(ctx, clusterID)
+	if err != nil || len(drifted) != 0 {
+		t.Fatalf("expected no drift after resync, got %v (%v)", drifted, err)
+	}
+}
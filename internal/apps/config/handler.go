@@ -91,6 +91,48 @@ func (h *Handler) GetConfig(c *gin.Context) {
 	c.JSON(http.StatusOK, Response{ErrorMsg: "", Data: config})
 }
 
+// GetTemplateVariables 获取配置模板可用变量
+// @Summary 获取配置模板可用变量
+// @Tags Config
+// @Produce json
+// @Success 200 {object} Response
+// @Router /api/v1/configs/template-variables [get]
+func (h *Handler) GetTemplateVariables(c *gin.Context) {
+	c.JSON(http.StatusOK, Response{ErrorMsg: "", Data: TemplateVariables})
+}
+
+// RenderConfig 预览节点配置按节点变量渲染后的内容
+// @Summary 预览渲染后的节点配置
+// @Tags Config
+// @Produce json
+// @Param id path int true "配置ID"
+// @Success 200 {object} Response
+// @Router /api/v1/configs/{id}/rendered [get]
+func (h *Handler) RenderConfig(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, Response{ErrorMsg: "invalid config id", Data: nil})
+		return
+	}
+
+	rendered, err := h.service.RenderConfig(c.Request.Context(), uint(id))
+	if err != nil {
+		if err == ErrConfigNotFound {
+			c.JSON(http.StatusNotFound, Response{ErrorMsg: "config not found", Data: nil})
+			return
+		}
+		var validationErr *ValidationError
+		if err == ErrCannotRenderTemplate || errors.As(err, &validationErr) {
+			c.JSON(http.StatusBadRequest, Response{ErrorMsg: err.Error(), Data: nil})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, Response{ErrorMsg: err.Error(), Data: nil})
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{ErrorMsg: "", Data: rendered})
+}
+
 // NormalizeConfig 智能修复（规范化）配置内容
 // @Summary 规范化配置内容
 // @Tags Config
@@ -458,6 +500,134 @@ func (h *Handler) SyncTemplateToAllNodes(c *gin.Context) {
 	}})
 }
 
+// ApplyClusterConfig 编辑并应用集群配置
+// @Summary 应用集群配置
+// @Description 校验配置后保存为集群模板与节点配置，经 Agent 备份后原子写入所有节点，可选滚动重启
+// @Tags Config
+// @Accept json
+// @Produce json
+// @Param id path int true "集群ID"
+// @Param body body ApplyConfigRequest true "应用请求"
+// @Success 200 {object} Response
+// @Router /api/v1/clusters/{id}/configs/apply [post]
+func (h *Handler) ApplyClusterConfig(c *gin.Context) {
+	clusterID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, Response{ErrorMsg: "invalid cluster id", Data: nil})
+		return
+	}
+
+	var req ApplyConfigRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, Response{ErrorMsg: err.Error(), Data: nil})
+		return
+	}
+
+	result, err := h.service.ApplyClusterConfig(c.Request.Context(), uint(clusterID), &req, getUserID(c))
+	if err != nil {
+		if err == ErrTemplateNotFound {
+			c.JSON(http.StatusNotFound, Response{ErrorMsg: "configuration template not found", Data: nil})
+			return
+		}
+		var validationErr *ValidationError
+		if errors.As(err, &validationErr) {
+			c.JSON(http.StatusBadRequest, Response{ErrorMsg: validationErr.Error(), Data: nil})
+			return
+		}
+		if err == ErrClusterRestarterUnavailable {
+			c.JSON(http.StatusBadRequest, Response{ErrorMsg: err.Error(), Data: nil})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, Response{ErrorMsg: err.Error(), Data: nil})
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{ErrorMsg: "", Data: result})
+}
+
+// GetClusterConfigDrift 获取集群配置漂移检测结果
+// @Summary 获取集群配置漂移
+// @Tags Config
+// @Produce json
+// @Param id path int true "集群ID"
+// @Success 200 {object} Response
+// @Router /api/v1/clusters/{id}/configs/drift [get]
+func (h *Handler) GetClusterConfigDrift(c *gin.Context) {
+	clusterID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, Response{ErrorMsg: "invalid cluster id", Data: nil})
+		return
+	}
+
+	drifts, err := h.service.GetClusterDrift(c.Request.Context(), uint(clusterID))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, Response{ErrorMsg: err.Error(), Data: nil})
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{ErrorMsg: "", Data: drifts})
+}
+
+// DetectClusterConfigDrift 立即检测集群配置漂移
+// @Summary 检测集群配置漂移
+// @Tags Config
+// @Produce json
+// @Param id path int true "集群ID"
+// @Success 200 {object} Response
+// @Router /api/v1/clusters/{id}/configs/drift/check [post]
+func (h *Handler) DetectClusterConfigDrift(c *gin.Context) {
+	clusterID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, Response{ErrorMsg: "invalid cluster id", Data: nil})
+		return
+	}
+
+	drifts, err := h.service.DetectClusterDrift(c.Request.Context(), uint(clusterID))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, Response{ErrorMsg: err.Error(), Data: nil})
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{ErrorMsg: "", Data: drifts})
+}
+
+// ResyncClusterConfigs 将已保存的节点配置重新同步到漂移节点
+// @Summary 重新同步漂移节点配置
+// @Tags Config
+// @Accept json
+// @Produce json
+// @Param id path int true "集群ID"
+// @Param body body ResyncConfigRequest false "重新同步请求"
+// @Success 200 {object} Response
+// @Router /api/v1/clusters/{id}/configs/resync [post]
+func (h *Handler) ResyncClusterConfigs(c *gin.Context) {
+	clusterID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, Response{ErrorMsg: "invalid cluster id", Data: nil})
+		return
+	}
+
+	var req ResyncConfigRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, Response{ErrorMsg: err.Error(), Data: nil})
+			return
+		}
+	}
+
+	result, err := h.service.ResyncNodeConfigs(c.Request.Context(), uint(clusterID), req.HostIDs)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, Response{ErrorMsg: err.Error(), Data: nil})
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{ErrorMsg: "", Data: map[string]interface{}{
+		"message":      "node configs resynced",
+		"synced_count": result.SyncedCount,
+		"push_errors":  result.PushErrors,
+	}})
+}
+
 // getUserID 从上下文获取用户ID
 func getUserID(c *gin.Context) uint {
 	if userID, exists := c.Get("user_id"); exists {
@@ -0,0 +1,148 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrConfigAgentUnavailable is returned when a node config is read or written without an Agent client.
+// ErrConfigAgentUnavailable 表示在未配置 Agent 客户端时读取或写入节点配置。
+var ErrConfigAgentUnavailable = errors.New("config agent client is not configured")
+
+// PullNodeConfig reads the current content of one config file from a cluster node through the Agent.
+// PullNodeConfig 通过 Agent 读取集群节点上某个配置文件的当前内容。
+func (s *Service) PullNodeConfig(ctx context.Context, clusterID uint, hostID uint, configType ConfigType) (string, error) {
+	installDir, err := s.nodeInstallDir(ctx, clusterID, hostID)
+	if err != nil {
+		return "", err
+	}
+	return s.agentClient.PullConfig(ctx, hostID, installDir, configType)
+}
+
+// RestoreConfig writes a previously saved config back. A nil hostID restores the cluster template, which
+// is only stored; otherwise the content is applied to the node through the Agent (with an on-node backup)
+// and then stored as a new node config version.
+// RestoreConfig 写回先前保存的配置。hostID 为空时恢复集群模板，仅保存到数据库；
+// 否则先通过 Agent 应用到节点（节点上自动备份原文件），再保存为新的节点配置版本。
+func (s *Service) RestoreConfig(ctx context.Context, clusterID uint, hostID *uint, configType ConfigType, content, comment string, userID uint) (*AppliedNode, error) {
+	if err := validateConfigContent(configType, content); err != nil {
+		return nil, err
+	}
+
+	var applied *AppliedNode
+	metadataContent := content
+	if hostID != nil {
+		installDir, err := s.nodeInstallDir(ctx, clusterID, *hostID)
+		if err != nil {
+			return nil, err
+		}
+		rendered, err := s.renderNodeConfig(ctx, clusterID, *hostID, installDir, configType, content)
+		if err != nil {
+			return nil, fmt.Errorf("渲染配置模板失败: %w", err)
+		}
+		backupPath, err := s.agentClient.ApplyConfig(ctx, *hostID, installDir, configType, rendered)
+		if err != nil {
+			return nil, fmt.Errorf("应用配置失败: %w", err)
+		}
+		applied = &AppliedNode{HostID: *hostID, BackupPath: backupPath}
+		metadataContent = rendered
+		if s.hostProvider != nil {
+			if host, err := s.hostProvider.GetHostByID(ctx, *hostID); err == nil {
+				applied.HostIP = host.IPAddress
+			}
+		}
+	}
+
+	if err := s.saveRestoredConfig(ctx, clusterID, hostID, configType, content, comment, userID); err != nil {
+		return applied, err
+	}
+	if hostID != nil || configType == ConfigTypeLog4j2 {
+		s.syncDerivedRuntimeMetadata(ctx, clusterID, hostID, configType, metadataContent)
+	}
+	return applied, nil
+}
+
+// nodeInstallDir resolves the install directory of a node and checks that the Agent client is usable.
+// nodeInstallDir 解析节点安装目录，并检查 Agent 客户端是否可用。
+func (s *Service) nodeInstallDir(ctx context.Context, clusterID uint, hostID uint) (string, error) {
+	if s.nodeInfoProvider == nil || s.agentClient == nil {
+		return "", ErrConfigAgentUnavailable
+	}
+	installDir, err := s.nodeInfoProvider.GetNodeInstallDir(ctx, clusterID, hostID)
+	if err != nil {
+		return "", fmt.Errorf("获取节点安装目录失败: %w", err)
+	}
+	if installDir == "" {
+		return "", errors.New("节点安装目录为空")
+	}
+	return installDir, nil
+}
+
+// saveRestoredConfig stores restored content as a new version, creating the config record when it is missing.
+// saveRestoredConfig 将恢复的内容保存为新版本；配置记录不存在时创建。
+func (s *Service) saveRestoredConfig(ctx context.Context, clusterID uint, hostID *uint, configType ConfigType, content, comment string, userID uint) error {
+	return s.repo.Transaction(ctx, func(tx *Repository) error {
+		var (
+			config *Config
+			err    error
+		)
+		if hostID == nil {
+			config, err = tx.GetTemplate(ctx, clusterID, configType)
+		} else {
+			config, err = tx.GetNodeConfig(ctx, clusterID, *hostID, configType)
+		}
+		switch {
+		case errors.Is(err, ErrConfigNotFound):
+			config = &Config{
+				ClusterID:  clusterID,
+				HostID:     hostID,
+				ConfigType: configType,
+				FilePath:   GetConfigFilePath(configType),
+				Content:    content,
+				Version:    1,
+				UpdatedBy:  userID,
+			}
+			if err := tx.Create(ctx, config); err != nil {
+				return err
+			}
+		case err != nil:
+			return err
+		case config.Content == content:
+			return nil
+		default:
+			config.Content = content
+			config.Version = config.Version + 1
+			config.UpdatedBy = userID
+			config.UpdatedAt = time.Now()
+			if err := tx.Update(ctx, config); err != nil {
+				return err
+			}
+		}
+		return tx.CreateVersion(ctx, &ConfigVersion{
+			ConfigID:  config.ID,
+			Version:   config.Version,
+			Content:   content,
+			Comment:   comment,
+			CreatedBy: userID,
+		})
+	})
+}
@@ -29,13 +29,19 @@ func RegisterRoutes(router *gin.RouterGroup, handler *Handler) {
 		clusters.GET("/:id/configs", handler.GetClusterConfigs)
 		clusters.POST("/:id/configs/init", handler.InitClusterConfigs)
 		clusters.POST("/:id/configs/sync-all", handler.SyncTemplateToAllNodes)
+		clusters.POST("/:id/configs/apply", handler.ApplyClusterConfig)
+		clusters.GET("/:id/configs/drift", handler.GetClusterConfigDrift)
+		clusters.POST("/:id/configs/drift/check", handler.DetectClusterConfigDrift)
+		clusters.POST("/:id/configs/resync", handler.ResyncClusterConfigs)
 	}
 
 	// 配置操作路由
 	configs := router.Group("/configs")
 	{
 		configs.POST("/normalize", handler.NormalizeConfig)
+		configs.GET("/template-variables", handler.GetTemplateVariables)
 		configs.GET("/:id", handler.GetConfig)
+		configs.GET("/:id/rendered", handler.RenderConfig)
 		configs.PUT("/:id", handler.UpdateConfig)
 		configs.GET("/:id/versions", handler.GetConfigVersions)
 		configs.POST("/:id/rollback", handler.RollbackConfig)
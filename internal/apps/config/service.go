@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"gopkg.in/yaml.v3"
@@ -32,6 +33,7 @@ var (
 	ErrTemplateNotFound      = errors.New("configuration template not found")
 	ErrCannotPromoteTemplate = errors.New("cannot promote template config")
 	ErrCannotSyncTemplate    = errors.New("cannot sync template config from itself")
+	ErrCannotRenderTemplate  = errors.New("cluster template is rendered per node; render a node config instead")
 )
 
 // HostProvider 主机信息提供者接口
@@ -46,15 +48,21 @@ type NodeInfoProvider interface {
 
 // HostInfo 主机信息
 type HostInfo struct {
-	ID        uint
-	Name      string
-	IPAddress string
+	ID          uint
+	Name        string
+	IPAddress   string
+	CPUCores    int
+	TotalMemory int64 // 总内存（字节）
+	Labels      map[string]string
 }
 
 // AgentClient Agent 客户端接口
 type AgentClient interface {
 	PullConfig(ctx context.Context, hostID uint, installDir string, configType ConfigType) (string, error)
 	PushConfig(ctx context.Context, hostID uint, installDir string, configType ConfigType, content string) error
+	// ApplyConfig validates, backs up and atomically writes a config file on the node, returning the backup path.
+	// ApplyConfig 在节点上校验、备份并原子写入配置文件，返回备份路径。
+	ApplyConfig(ctx context.Context, hostID uint, installDir string, configType ConfigType, content string) (string, error)
 }
 
 // PortMetadataUpdater updates cluster node API port metadata after config changes.
@@ -72,6 +80,10 @@ type Service struct {
 	nodeInfoProvider NodeInfoProvider
 	agentClient      AgentClient
 	portUpdater      PortMetadataUpdater
+	restarter        ClusterRestarter
+
+	driftInterval time.Duration
+	driftRuntime  sync.Once
 }
 
 // NewService 创建配置服务实例
@@ -105,6 +117,15 @@ func (s *Service) NormalizeContent(_ context.Context, req *NormalizeConfigReques
 	return normalizeConfigContent(req.ConfigType, req.Content)
 }
 
+// GetTemplateContent 获取集群模板内容
+func (s *Service) GetTemplateContent(ctx context.Context, clusterID uint, configType ConfigType) (string, error) {
+	template, err := s.repo.GetTemplate(ctx, clusterID, configType)
+	if err != nil {
+		return "", ErrTemplateNotFound
+	}
+	return template.Content, nil
+}
+
 // GetByCluster 获取集群所有配置
 func (s *Service) GetByCluster(ctx context.Context, clusterID uint) ([]*ConfigInfo, error) {
 	configs, err := s.repo.ListByCluster(ctx, clusterID)
@@ -221,11 +242,11 @@ func (s *Service) Update(ctx context.Context, id uint, req *UpdateConfigRequest,
 		if dirErr != nil {
 			info.PushError = "获取节点安装目录失败: " + dirErr.Error()
 		} else if installDir != "" {
-			pushErr := s.agentClient.PushConfig(ctx, *config.HostID, installDir, config.ConfigType, config.Content)
+			rendered, pushErr := s.pushNodeConfig(ctx, config.ClusterID, *config.HostID, installDir, config.ConfigType, config.Content)
 			if pushErr != nil {
 				info.PushError = "推送配置到节点失败: " + pushErr.Error()
 			} else {
-				s.syncDerivedRuntimeMetadata(ctx, config.ClusterID, config.HostID, config.ConfigType, config.Content)
+				s.syncDerivedRuntimeMetadata(ctx, config.ClusterID, config.HostID, config.ConfigType, rendered)
 			}
 		}
 	}
@@ -314,8 +335,7 @@ func (s *Service) Rollback(ctx context.Context, id uint, req *RollbackConfigRequ
 		if dirErr != nil {
 			info.PushError = "获取节点安装目录失败: " + dirErr.Error()
 		} else if installDir != "" {
-			pushErr := s.agentClient.PushConfig(ctx, *config.HostID, installDir, config.ConfigType, config.Content)
-			if pushErr != nil {
+			if _, pushErr := s.pushNodeConfig(ctx, config.ClusterID, *config.HostID, installDir, config.ConfigType, config.Content); pushErr != nil {
 				info.PushError = "推送配置到节点失败: " + pushErr.Error()
 			}
 		}
@@ -481,8 +501,7 @@ func (s *Service) SyncFromTemplate(ctx context.Context, id uint, req *SyncConfig
 		if dirErr != nil {
 			info.PushError = "获取节点安装目录失败: " + dirErr.Error()
 		} else if installDir != "" {
-			pushErr := s.agentClient.PushConfig(ctx, *config.HostID, installDir, config.ConfigType, config.Content)
-			if pushErr != nil {
+			if _, pushErr := s.pushNodeConfig(ctx, config.ClusterID, *config.HostID, installDir, config.ConfigType, config.Content); pushErr != nil {
 				info.PushError = "推送配置到节点失败: " + pushErr.Error()
 			}
 		}
@@ -638,33 +657,7 @@ func (s *Service) SyncTemplateToAllNodes(ctx context.Context, clusterID uint, co
 		PushErrors:  make([]*PushError, 0),
 	}
 
-	err = s.repo.Transaction(ctx, func(tx *Repository) error {
-		for _, nc := range nodeConfigs {
-			if nc.Content == template.Content {
-				continue // 内容相同跳过
-			}
-			nc.Content = template.Content
-			nc.Version = nc.Version + 1
-			nc.UpdatedBy = userID
-			nc.UpdatedAt = time.Now()
-			if err := tx.Update(ctx, nc); err != nil {
-				return err
-			}
-			// 创建节点版本
-			nodeVersion := &ConfigVersion{
-				ConfigID:  nc.ID,
-				Version:   nc.Version,
-				Content:   template.Content,
-				Comment:   "Synced from cluster template (batch)",
-				CreatedBy: userID,
-			}
-			if err := tx.CreateVersion(ctx, nodeVersion); err != nil {
-				return err
-			}
-			result.SyncedCount++
-		}
-		return nil
-	})
+	result.SyncedCount, err = s.syncNodeConfigsFromTemplate(ctx, template, nodeConfigs, userID, "Synced from cluster template (batch)")
 	if err != nil {
 		return nil, err
 	}
@@ -687,7 +680,8 @@ func (s *Service) SyncTemplateToAllNodes(ctx context.Context, clusterID uint, co
 					}
 					result.PushErrors = append(result.PushErrors, pushErr)
 				} else if installDir != "" {
-					if pushErr := s.agentClient.PushConfig(ctx, *nc.HostID, installDir, configType, template.Content); pushErr != nil {
+					rendered, pushErr := s.pushNodeConfig(ctx, clusterID, *nc.HostID, installDir, configType, template.Content)
+					if pushErr != nil {
 						errInfo := &PushError{
 							HostID:  *nc.HostID,
 							Message: "推送配置失败: " + pushErr.Error(),
@@ -700,7 +694,7 @@ func (s *Service) SyncTemplateToAllNodes(ctx context.Context, clusterID uint, co
 						}
 						result.PushErrors = append(result.PushErrors, errInfo)
 					} else {
-						s.syncDerivedRuntimeMetadata(ctx, clusterID, nc.HostID, configType, template.Content)
+						s.syncDerivedRuntimeMetadata(ctx, clusterID, nc.HostID, configType, rendered)
 					}
 				}
 			}
@@ -710,6 +704,39 @@ func (s *Service) SyncTemplateToAllNodes(ctx context.Context, clusterID uint, co
 	return result, nil
 }
 
+// syncNodeConfigsFromTemplate 将模板内容写入节点配置并记录版本，返回实际变更的数量
+func (s *Service) syncNodeConfigsFromTemplate(ctx context.Context, template *Config, nodeConfigs []*Config, userID uint, comment string) (int, error) {
+	synced := 0
+	err := s.repo.Transaction(ctx, func(tx *Repository) error {
+		for _, nc := range nodeConfigs {
+			if nc.Content == template.Content {
+				continue // 内容相同跳过
+			}
+			nc.Content = template.Content
+			nc.Version = nc.Version + 1
+			nc.UpdatedBy = userID
+			nc.UpdatedAt = time.Now()
+			if err := tx.Update(ctx, nc); err != nil {
+				return err
+			}
+			// 创建节点版本
+			nodeVersion := &ConfigVersion{
+				ConfigID:  nc.ID,
+				Version:   nc.Version,
+				Content:   template.Content,
+				Comment:   comment,
+				CreatedBy: userID,
+			}
+			if err := tx.CreateVersion(ctx, nodeVersion); err != nil {
+				return err
+			}
+			synced++
+		}
+		return nil
+	})
+	return synced, err
+}
+
 // PushConfigToNode 推送配置到节点
 func (s *Service) PushConfigToNode(ctx context.Context, id uint, installDir string) error {
 	config, err := s.repo.GetByID(ctx, id)
@@ -725,7 +752,8 @@ func (s *Service) PushConfigToNode(ctx context.Context, id uint, installDir stri
 		return err
 	}
 
-	return s.agentClient.PushConfig(ctx, *config.HostID, installDir, config.ConfigType, config.Content)
+	_, err = s.pushNodeConfig(ctx, config.ClusterID, *config.HostID, installDir, config.ConfigType, config.Content)
+	return err
 }
 
 // toConfigInfo 转换为 ConfigInfo
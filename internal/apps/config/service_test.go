@@ -46,6 +46,11 @@ func (c *testAgentClient) PushConfig(_ context.Context, _ uint, _ string, _ Conf
 	return nil
 }
 
+func (c *testAgentClient) ApplyConfig(_ context.Context, _ uint, _ string, _ ConfigType, _ string) (string, error) {
+	c.pushCalls++
+	return "", nil
+}
+
 type portUpdateCall struct {
 	clusterID uint
 	hostID    uint
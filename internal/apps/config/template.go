@@ -0,0 +1,229 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// templateVariablePattern matches a template variable such as {{node.ip}} or {{ host.cpu_cores }}.
+// templateVariablePattern 匹配 {{node.ip}}、{{ host.cpu_cores }} 等模板变量。
+var templateVariablePattern = regexp.MustCompile(`\{\{\s*([A-Za-z0-9_./-]+)\s*\}\}`)
+
+const (
+	hostLabelVariablePrefix = "host.labels."
+	templateMaskFormat      = "__SEATUNNELX_TEMPLATE_VAR_%d__"
+)
+
+// TemplateVariable describes one variable that cluster configs may reference.
+// Variables are rendered per node right before the Agent writes the file; the stored
+// template and node configs keep the variables.
+// TemplateVariable 描述集群配置中可引用的一个变量。
+// 变量在 Agent 写入文件前按节点渲染；存储的模板与节点配置保留变量原文。
+type TemplateVariable struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Example     string `json:"example"`
+}
+
+// TemplateVariables lists the supported variables; host labels are available as {{host.labels.<key>}}.
+// TemplateVariables 列出支持的变量；主机标签可通过 {{host.labels.<key>}} 引用。
+var TemplateVariables = []TemplateVariable{
+	{Name: "cluster.id", Description: "Cluster ID / 集群 ID", Example: "1"},
+	{Name: "node.host_id", Description: "Host ID of the node / 节点所在主机 ID", Example: "1"},
+	{Name: "node.ip", Description: "IP address of the node / 节点 IP 地址", Example: "127.0.0.1"},
+	{Name: "node.install_dir", Description: "SeaTunnel install directory of the node / 节点 SeaTunnel 安装目录", Example: "/opt/seatunnel"},
+	{Name: "host.name", Description: "Host name / 主机名称", Example: "host"},
+	{Name: "host.ip", Description: "Host IP address / 主机 IP 地址", Example: "127.0.0.1"},
+	{Name: "host.cpu_cores", Description: "CPU cores reported by the Agent / Agent 上报的 CPU 核数", Example: "1"},
+	{Name: "host.memory_gb", Description: "Total memory in GiB reported by the Agent / Agent 上报的总内存（GiB）", Example: "1"},
+	{Name: "host.memory_mb", Description: "Total memory in MiB reported by the Agent / Agent 上报的总内存（MiB）", Example: "1024"},
+	{Name: hostLabelVariablePrefix + "<key>", Description: "Value of a host label / 主机标签的值", Example: "label"},
+}
+
+// hasTemplateVariables reports whether content references any template variable.
+// hasTemplateVariables 判断内容是否引用了模板变量。
+func hasTemplateVariables(content string) bool {
+	return templateVariablePattern.MatchString(content)
+}
+
+// renderConfigTemplate substitutes template variables with values and fails on any unresolved variable.
+// renderConfigTemplate 用给定值替换模板变量，存在无法解析的变量时返回错误。
+func renderConfigTemplate(configType ConfigType, content string, values map[string]string) (string, error) {
+	missing := make(map[string]bool)
+	rendered := templateVariablePattern.ReplaceAllStringFunc(content, func(match string) string {
+		name := templateVariablePattern.FindStringSubmatch(match)[1]
+		if value, ok := values[name]; ok {
+			return value
+		}
+		missing[name] = true
+		return match
+	})
+	if len(missing) > 0 {
+		names := make([]string, 0, len(missing))
+		for name := range missing {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return "", &ValidationError{
+			ConfigType: configType,
+			Message:    fmt.Sprintf("Unresolved template variables in %s: %s", configType, strings.Join(names, ", ")),
+		}
+	}
+	return rendered, nil
+}
+
+// exampleTemplateValues returns example values for every known variable referenced by content,
+// so that a template can be validated without a concrete node.
+// exampleTemplateValues 为内容中引用的已知变量返回示例值，使模板无需具体节点即可校验。
+func exampleTemplateValues(content string) map[string]string {
+	values := make(map[string]string, len(TemplateVariables))
+	for _, variable := range TemplateVariables {
+		values[variable.Name] = variable.Example
+	}
+	for _, match := range templateVariablePattern.FindAllStringSubmatch(content, -1) {
+		if key := strings.TrimPrefix(match[1], hostLabelVariablePrefix); key != match[1] && key != "" {
+			values[match[1]] = "label"
+		}
+	}
+	return values
+}
+
+// maskTemplateVariables replaces variables with plain YAML-safe tokens and returns a function
+// that puts them back, so formatting tools can process templates.
+// maskTemplateVariables 将变量替换为 YAML 安全的普通标记，并返回还原函数，便于格式化工具处理模板。
+func maskTemplateVariables(content string) (string, func(string) string) {
+	var originals []string
+	masked := templateVariablePattern.ReplaceAllStringFunc(content, func(match string) string {
+		originals = append(originals, match)
+		return fmt.Sprintf(templateMaskFormat, len(originals)-1)
+	})
+	return masked, func(s string) string {
+		for i, original := range originals {
+			s = strings.Replace(s, fmt.Sprintf(templateMaskFormat, i), original, 1)
+		}
+		return s
+	}
+}
+
+// nodeTemplateValues collects the variable values of the node on hostID.
+// Values that are unknown (e.g. hardware not yet reported) are left out so rendering fails loudly.
+// nodeTemplateValues 收集 hostID 所在节点的变量值。
+// 未知的值（如硬件信息尚未上报）不会填充，以便渲染时明确报错。
+func (s *Service) nodeTemplateValues(ctx context.Context, clusterID uint, hostID uint, installDir string) (map[string]string, error) {
+	if s.hostProvider == nil {
+		return nil, errors.New("host provider is not configured")
+	}
+	host, err := s.hostProvider.GetHostByID(ctx, hostID)
+	if err != nil {
+		return nil, err
+	}
+
+	values := map[string]string{
+		"cluster.id":   strconv.FormatUint(uint64(clusterID), 10),
+		"node.host_id": strconv.FormatUint(uint64(hostID), 10),
+		"host.name":    host.Name,
+	}
+	if host.IPAddress != "" {
+		values["node.ip"] = host.IPAddress
+		values["host.ip"] = host.IPAddress
+	}
+	if installDir != "" {
+		values["node.install_dir"] = installDir
+	}
+	if host.CPUCores > 0 {
+		values["host.cpu_cores"] = strconv.Itoa(host.CPUCores)
+	}
+	if host.TotalMemory > 0 {
+		values["host.memory_gb"] = strconv.FormatInt(host.TotalMemory>>30, 10)
+		values["host.memory_mb"] = strconv.FormatInt(host.TotalMemory>>20, 10)
+	}
+	for key, value := range host.Labels {
+		values[hostLabelVariablePrefix+key] = value
+	}
+	return values, nil
+}
+
+// renderNodeConfig renders a stored config for the node on hostID; content without variables is returned as is.
+// renderNodeConfig 为 hostID 所在节点渲染存储的配置；不含变量的内容原样返回。
+func (s *Service) renderNodeConfig(ctx context.Context, clusterID uint, hostID uint, installDir string, configType ConfigType, content string) (string, error) {
+	if !hasTemplateVariables(content) {
+		return content, nil
+	}
+	values, err := s.nodeTemplateValues(ctx, clusterID, hostID, installDir)
+	if err != nil {
+		return "", fmt.Errorf("resolve template variables: %w", err)
+	}
+	rendered, err := renderConfigTemplate(configType, content, values)
+	if err != nil {
+		return "", err
+	}
+	if err := validateConfigContent(configType, rendered); err != nil {
+		return "", err
+	}
+	return rendered, nil
+}
+
+// pushNodeConfig renders a config for one node and pushes it through the Agent, returning the rendered content.
+// pushNodeConfig 为单个节点渲染配置并通过 Agent 推送，返回渲染后的内容。
+func (s *Service) pushNodeConfig(ctx context.Context, clusterID uint, hostID uint, installDir string, configType ConfigType, content string) (string, error) {
+	rendered, err := s.renderNodeConfig(ctx, clusterID, hostID, installDir, configType, content)
+	if err != nil {
+		return "", fmt.Errorf("渲染配置模板失败: %w", err)
+	}
+	return rendered, s.agentClient.PushConfig(ctx, hostID, installDir, configType, rendered)
+}
+
+// RenderedConfig is a node config rendered with the values of its node.
+// RenderedConfig 是使用所在节点的变量值渲染后的节点配置。
+type RenderedConfig struct {
+	ConfigID   uint       `json:"config_id"`
+	HostID     uint       `json:"host_id"`
+	ConfigType ConfigType `json:"config_type"`
+	Content    string     `json:"content"`
+}
+
+// RenderConfig previews the content the Agent would write for a node config.
+// RenderConfig 预览 Agent 将为节点配置写入的内容。
+func (s *Service) RenderConfig(ctx context.Context, id uint) (*RenderedConfig, error) {
+	config, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if config.HostID == nil {
+		return nil, ErrCannotRenderTemplate
+	}
+
+	installDir := ""
+	if s.nodeInfoProvider != nil {
+		if installDir, err = s.nodeInfoProvider.GetNodeInstallDir(ctx, config.ClusterID, *config.HostID); err != nil {
+			return nil, err
+		}
+	}
+	content, err := s.renderNodeConfig(ctx, config.ClusterID, *config.HostID, installDir, config.ConfigType, config.Content)
+	if err != nil {
+		return nil, err
+	}
+	return &RenderedConfig{ConfigID: config.ID, HostID: *config.HostID, ConfigType: config.ConfigType, Content: content}, nil
+}
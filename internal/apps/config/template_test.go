@@ -0,0 +1,139 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+type testHostProvider struct {
+	hosts map[uint]*HostInfo
+}
+
+func (p *testHostProvider) GetHostByID(_ context.Context, id uint) (*HostInfo, error) {
+	host, ok := p.hosts[id]
+	if !ok {
+		return nil, errors.New("host not found")
+	}
+	return host, nil
+}
+
+func TestApplyClusterConfigRendersTemplatePerNode(t *testing.T) {
+	service, db, _, _ := newConfigTestService(t)
+	if err := db.AutoMigrate(&ConfigDrift{}); err != nil {
+		t.Fatalf("failed to migrate drift model: %v", err)
+	}
+	ctx := context.Background()
+	clusterID := uint(91)
+	original := "seatunnel:\n  engine:\n    backup-count: 1\n"
+	for _, hostID := range []*uint{nil, ptrUint(81), ptrUint(82)} {
+		if err := db.WithContext(ctx).Create(&Config{
+			ClusterID:  clusterID,
+			HostID:     hostID,
+			ConfigType: ConfigTypeSeatunnel,
+			FilePath:   GetConfigFilePath(ConfigTypeSeatunnel),
+			Content:    original,
+			Version:    1,
+		}).Error; err != nil {
+			t.Fatalf("failed to create config: %v", err)
+		}
+	}
+	service.hostProvider = &testHostProvider{hosts: map[uint]*HostInfo{
+		81: {ID: 81, Name: "small", IPAddress: "10.0.0.81", CPUCores: 4, Labels: map[string]string{"zone": "a"}},
+		82: {ID: 82, Name: "large", IPAddress: "10.0.0.82", CPUCores: 16, Labels: map[string]string{"zone": "b"}},
+	}}
+	agent := &fileAgentClient{files: map[string]string{}}
+	service.agentClient = agent
+
+	template := "seatunnel:\n  engine:\n    backup-count: {{ host.cpu_cores }}\n    # {{node.ip}} in {{host.labels.zone}}\n"
+	result, err := service.ApplyClusterConfig(ctx, clusterID, &ApplyConfigRequest{ConfigType: ConfigTypeSeatunnel, Content: template}, 1)
+	if err != nil {
+		t.Fatalf("ApplyClusterConfig returned error: %v", err)
+	}
+	if len(result.AppliedNodes) != 2 || len(result.PushErrors) != 0 {
+		t.Fatalf("unexpected apply result: %+v", result)
+	}
+	if want := "seatunnel:\n  engine:\n    backup-count: 4\n    # 10.0.0.81 in a\n"; agent.files["81/seatunnel.yaml"] != want {
+		t.Fatalf("unexpected rendered config for host 81: %q", agent.files["81/seatunnel.yaml"])
+	}
+	if want := "seatunnel:\n  engine:\n    backup-count: 16\n    # 10.0.0.82 in b\n"; agent.files["82/seatunnel.yaml"] != want {
+		t.Fatalf("unexpected rendered config for host 82: %q", agent.files["82/seatunnel.yaml"])
+	}
+
+	stored, err := service.repo.GetNodeConfig(ctx, clusterID, 81, ConfigTypeSeatunnel)
+	if err != nil {
+		t.Fatalf("GetNodeConfig returned error: %v", err)
+	}
+	if stored.Content != template {
+		t.Fatalf("expected node config to keep the template variables, got %q", stored.Content)
+	}
+	rendered, err := service.RenderConfig(ctx, stored.ID)
+	if err != nil || rendered.Content != agent.files["81/seatunnel.yaml"] {
+		t.Fatalf("unexpected render preview: %+v, %v", rendered, err)
+	}
+
+	drifts, err := service.DetectClusterDrift(ctx, clusterID)
+	if err != nil {
+		t.Fatalf("DetectClusterDrift returned error: %v", err)
+	}
+	for _, drift := range drifts {
+		if drift.Drifted {
+			t.Fatalf("rendered nodes should not be reported as drifted: %+v", drift)
+		}
+	}
+
+	// A host without reported hardware cannot render the template and is reported, not written.
+	// 未上报硬件信息的主机无法渲染模板，会被报告而不会写入。
+	service.hostProvider.(*testHostProvider).hosts[82].CPUCores = 0
+	result, err = service.ApplyClusterConfig(ctx, clusterID, &ApplyConfigRequest{ConfigType: ConfigTypeSeatunnel, Content: template + "\n"}, 1)
+	if err != nil {
+		t.Fatalf("ApplyClusterConfig returned error: %v", err)
+	}
+	if len(result.PushErrors) != 1 || result.PushErrors[0].HostID != 82 || !strings.Contains(result.PushErrors[0].Message, "host.cpu_cores") {
+		t.Fatalf("expected render failure for host 82, got %+v", result.PushErrors)
+	}
+}
+
+func TestValidateConfigContentChecksTemplateVariables(t *testing.T) {
+	if err := validateConfigContent(ConfigTypeSeatunnel, "seatunnel:\n  engine:\n    backup-count: {{host.cpu_cores}}\n"); err != nil {
+		t.Fatalf("expected template to validate, got %v", err)
+	}
+	err := validateConfigContent(ConfigTypeSeatunnel, "seatunnel:\n  engine:\n    backup-count: {{host.gpu_cores}}\n")
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) || !strings.Contains(err.Error(), "host.gpu_cores") {
+		t.Fatalf("expected unresolved variable error, got %v", err)
+	}
+	if err := validateConfigContent(ConfigTypeSeatunnel, "seatunnel:\n  engine:\n    backup-count: {{host.name}}\n"); err == nil {
+		t.Fatal("expected schema validation of the rendered template to fail")
+	}
+
+	normalized, err := normalizeConfigContent(ConfigTypeHazelcast, "hazelcast:\n    network:\n        public-address: {{node.ip}}:5801\n")
+	if err != nil {
+		t.Fatalf("normalizeConfigContent returned error: %v", err)
+	}
+	if !strings.Contains(normalized, "public-address: {{node.ip}}:5801") {
+		t.Fatalf("expected variables to survive normalization, got %q", normalized)
+	}
+}
+
+func ptrUint(v uint) *uint {
+	return &v
+}
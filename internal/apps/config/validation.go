@@ -19,6 +19,7 @@ package config
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"gopkg.in/yaml.v3"
@@ -48,18 +49,168 @@ func shouldValidateYAML(configType ConfigType) bool {
 }
 
 func validateConfigContent(configType ConfigType, content string) error {
+	// Templates are validated as rendered with example values; each node is validated again at push time.
+	// 模板使用示例值渲染后校验；推送时还会按节点再次校验。
+	if hasTemplateVariables(content) {
+		rendered, err := renderConfigTemplate(configType, content, exampleTemplateValues(content))
+		if err != nil {
+			return err
+		}
+		content = rendered
+	}
+	if isJVMOptionsConfigType(configType) {
+		return validateJVMOptions(configType, content)
+	}
 	if !shouldValidateYAML(configType) {
 		return nil
 	}
 
-	_, _, err := parseAndValidateYAML(configType, content)
-	return err
+	_, mapping, err := parseAndValidateYAML(configType, content)
+	if err != nil {
+		return err
+	}
+	return validateYAMLSchema(configType, mapping)
+}
+
+// yamlSchemaRule describes the expected shape of one optional YAML path.
+// yamlSchemaRule 描述一个可选 YAML 路径应有的结构。
+type yamlSchemaRule struct {
+	path []string
+	kind string
+}
+
+const (
+	yamlSchemaMapping        = "mapping"
+	yamlSchemaSequence       = "sequence"
+	yamlSchemaPort           = "port"
+	yamlSchemaNonNegativeInt = "non-negative integer"
+	yamlSchemaBool           = "boolean"
+)
+
+var seatunnelSchemaRules = []yamlSchemaRule{
+	{path: []string{"seatunnel", "engine"}, kind: yamlSchemaMapping},
+	{path: []string{"seatunnel", "engine", "backup-count"}, kind: yamlSchemaNonNegativeInt},
+	{path: []string{"seatunnel", "engine", "slot-service", "dynamic-slot"}, kind: yamlSchemaBool},
+	{path: []string{"seatunnel", "engine", "checkpoint", "interval"}, kind: yamlSchemaNonNegativeInt},
+	{path: []string{"seatunnel", "engine", "checkpoint", "timeout"}, kind: yamlSchemaNonNegativeInt},
+	{path: []string{"seatunnel", "engine", "http", "enable-http"}, kind: yamlSchemaBool},
+	{path: []string{"seatunnel", "engine", "http", "port"}, kind: yamlSchemaPort},
+}
+
+var hazelcastSchemaRules = []yamlSchemaRule{
+	{path: []string{"hazelcast", "network"}, kind: yamlSchemaMapping},
+	{path: []string{"hazelcast", "network", "port"}, kind: yamlSchemaPort},
+	{path: []string{"hazelcast", "network", "join", "tcp-ip", "enabled"}, kind: yamlSchemaBool},
+	{path: []string{"hazelcast", "network", "join", "tcp-ip", "member-list"}, kind: yamlSchemaSequence},
+}
+
+var hazelcastClientSchemaRules = []yamlSchemaRule{
+	{path: []string{"hazelcast-client", "network"}, kind: yamlSchemaMapping},
+	{path: []string{"hazelcast-client", "network", "cluster-members"}, kind: yamlSchemaSequence},
+}
+
+// validateYAMLSchema checks the well-known SeaTunnel/Hazelcast settings present in a parsed config.
+// validateYAMLSchema 校验已解析配置中存在的 SeaTunnel/Hazelcast 常用配置项。
+func validateYAMLSchema(configType ConfigType, mapping *yaml.Node) error {
+	var rules []yamlSchemaRule
+	switch configType {
+	case ConfigTypeSeatunnel:
+		rules = seatunnelSchemaRules
+	case ConfigTypeHazelcast, ConfigTypeHazelcastMaster, ConfigTypeHazelcastWorker:
+		rules = hazelcastSchemaRules
+	case ConfigTypeHazelcastClient:
+		rules = hazelcastClientSchemaRules
+	}
+
+	for _, rule := range rules {
+		node := mapping
+		for _, key := range rule.path {
+			node = findYAMLMapChild(node, key)
+			if node == nil {
+				break
+			}
+		}
+		if node == nil {
+			continue
+		}
+		if !matchesYAMLSchemaKind(node, rule.kind) {
+			return &ValidationError{
+				ConfigType: configType,
+				Message:    fmt.Sprintf("Invalid %s: '%s' (line %d) must be a %s", configType, strings.Join(rule.path, "."), node.Line, rule.kind),
+			}
+		}
+	}
+	return nil
+}
+
+// matchesYAMLSchemaKind 判断节点是否符合期望的结构类型
+func matchesYAMLSchemaKind(node *yaml.Node, kind string) bool {
+	switch kind {
+	case yamlSchemaMapping:
+		return node.Kind == yaml.MappingNode
+	case yamlSchemaSequence:
+		return node.Kind == yaml.SequenceNode
+	case yamlSchemaBool:
+		_, err := strconv.ParseBool(strings.TrimSpace(node.Value))
+		return node.Kind == yaml.ScalarNode && err == nil
+	case yamlSchemaNonNegativeInt:
+		value, err := strconv.Atoi(strings.TrimSpace(node.Value))
+		return node.Kind == yaml.ScalarNode && err == nil && value >= 0
+	case yamlSchemaPort:
+		// hazelcast network.port may be a mapping with its own port child.
+		// hazelcast 的 network.port 可以是包含 port 子项的映射。
+		if node.Kind == yaml.MappingNode {
+			node = findYAMLMapChild(node, "port")
+			if node == nil {
+				return true
+			}
+		}
+		value, err := strconv.Atoi(strings.TrimSpace(node.Value))
+		return node.Kind == yaml.ScalarNode && err == nil && value > 0 && value <= 65535
+	default:
+		return true
+	}
+}
+
+func isJVMOptionsConfigType(configType ConfigType) bool {
+	switch configType {
+	case ConfigTypeJVMOptions, ConfigTypeJVMMasterOptions, ConfigTypeJVMWorkerOptions:
+		return true
+	default:
+		return false
+	}
+}
+
+// validateJVMOptions requires every non-comment line to be a JVM option starting with '-'.
+// validateJVMOptions 要求每个非注释行都是以 '-' 开头的 JVM 参数。
+func validateJVMOptions(configType ConfigType, content string) error {
+	for i, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		if !strings.HasPrefix(trimmed, "-") {
+			return &ValidationError{
+				ConfigType: configType,
+				Message:    fmt.Sprintf("Invalid %s: line %d must start with '-' or '#': %s", configType, i+1, trimmed),
+			}
+		}
+	}
+	return nil
 }
 
 func normalizeConfigContent(configType ConfigType, content string) (string, error) {
 	if !shouldValidateYAML(configType) {
 		return content, nil
 	}
+	if hasTemplateVariables(content) {
+		masked, restore := maskTemplateVariables(content)
+		normalized, err := normalizeConfigContent(configType, masked)
+		if err != nil {
+			return "", err
+		}
+		return restore(normalized), nil
+	}
 
 	root, _, err := parseAndValidateYAML(configType, content)
 	if err != nil {
@@ -30,8 +30,9 @@ type DashboardDataResponse struct {
 // OverviewStats 表示仪表盘概览统计数据。
 type OverviewStats struct {
 	// Host statistics / 主机统计
-	TotalHosts  int `json:"total_hosts"`
-	OnlineHosts int `json:"online_hosts"`
+	TotalHosts       int `json:"total_hosts"`
+	OnlineHosts      int `json:"online_hosts"`
+	MaintenanceHosts int `json:"maintenance_hosts"`
 
 	// Cluster statistics / 集群统计
 	TotalClusters   int `json:"total_clusters"`
@@ -48,6 +49,9 @@ type OverviewStats struct {
 	// Agent statistics / Agent 统计
 	TotalAgents  int `json:"total_agents"`
 	OnlineAgents int `json:"online_agents"`
+
+	// Installation statistics / 安装统计
+	RunningInstallations int `json:"running_installations"`
 }
 
 // ClusterSummary represents a cluster summary for dashboard.
@@ -55,7 +59,7 @@ type OverviewStats struct {
 type ClusterSummary struct {
 	ID             uint   `json:"id"`
 	Name           string `json:"name"`
-	Status         string `json:"status"` // DB status; display as "unhealthy" when running but 0 online
+	Status         string `json:"status"` // DB status; display as "unhealthy" (or "maintenance" when a host is in maintenance) when running but 0 online
 	DeploymentMode string `json:"deployment_mode"`
 	TotalNodes     int    `json:"total_nodes"`
 	MasterNodes    int    `json:"master_nodes"`
@@ -73,6 +77,7 @@ type HostSummary struct {
 	IsOnline    bool   `json:"is_online"`
 	AgentStatus string `json:"agent_status"`
 	NodeCount   int    `json:"node_count"`
+	Maintenance bool   `json:"maintenance"`
 }
 
 // RecentActivity represents a recent activity log entry.
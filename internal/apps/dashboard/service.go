@@ -33,6 +33,8 @@ type OverviewService struct {
 	auditRepo        *audit.Repository
 	heartbeatTimeout time.Duration
 	processStartedAt time.Time // online requires heartbeat after this (e.g. API process start)
+	// runningInstallations counts running installations; nil reports zero.
+	runningInstallations func() int
 }
 
 // NewOverviewService creates a new dashboard overview service.
@@ -47,6 +49,11 @@ func NewOverviewService(hostRepo *host.Repository, clusterRepo *cluster.Reposito
 	}
 }
 
+// SetInstallationCounter sets the function reporting the number of running installations.
+func (s *OverviewService) SetInstallationCounter(counter func() int) {
+	s.runningInstallations = counter
+}
+
 // GetOverviewStats returns dashboard overview statistics.
 func (s *OverviewService) GetOverviewStats(ctx context.Context) (*OverviewStats, error) {
 	stats := &OverviewStats{}
@@ -60,6 +67,9 @@ func (s *OverviewService) GetOverviewStats(ctx context.Context) (*OverviewStats,
 		if h.IsOnlineWithSince(s.heartbeatTimeout, s.processStartedAt) {
 			stats.OnlineHosts++
 		}
+		if h.Maintenance {
+			stats.MaintenanceHosts++
+		}
 		if h.AgentStatus == host.AgentStatusInstalled {
 			stats.TotalAgents++
 			if h.IsOnlineWithSince(s.heartbeatTimeout, s.processStartedAt) {
@@ -112,6 +122,10 @@ func (s *OverviewService) GetOverviewStats(ctx context.Context) (*OverviewStats,
 		}
 	}
 
+	if s.runningInstallations != nil {
+		stats.RunningInstallations = s.runningInstallations()
+	}
+
 	return stats, nil
 }
 
@@ -141,6 +155,7 @@ func (s *OverviewService) GetClusterSummaries(ctx context.Context, limit int) ([
 			TotalNodes:     len(clusterWithNodes.Nodes),
 		}
 
+		inMaintenance := false
 		for _, n := range clusterWithNodes.Nodes {
 			if n.Role == cluster.NodeRoleMaster {
 				summary.MasterNodes++
@@ -152,13 +167,20 @@ func (s *OverviewService) GetClusterSummaries(ctx context.Context, limit int) ([
 			if online {
 				summary.OnlineNodes++
 			}
+			if err == nil && h.Maintenance {
+				inMaintenance = true
+			}
 			if n.Status == cluster.NodeStatusRunning && online {
 				summary.RunningNodes++
 			}
 		}
-		// When DB status is running but 0 nodes online, show as unhealthy on dashboard
+		// When DB status is running but 0 nodes online, show as unhealthy on dashboard,
+		// or as under maintenance when that is expected
 		if c.Status == cluster.ClusterStatusRunning && summary.OnlineNodes == 0 {
 			summary.Status = "unhealthy"
+			if inMaintenance {
+				summary.Status = "maintenance"
+			}
 		}
 
 		summaries = append(summaries, summary)
@@ -199,6 +221,7 @@ func (s *OverviewService) GetHostSummaries(ctx context.Context, limit int) ([]*H
 			IsOnline:    h.IsOnlineWithSince(s.heartbeatTimeout, s.processStartedAt),
 			AgentStatus: string(h.AgentStatus),
 			NodeCount:   hostNodeCount[h.ID],
+			Maintenance: h.Maintenance,
 		})
 	}
 
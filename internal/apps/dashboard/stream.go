@@ -0,0 +1,187 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dashboard
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/seatunnel/seatunnelX/internal/config"
+	"github.com/seatunnel/seatunnelX/internal/logger"
+	"github.com/seatunnel/seatunnelX/internal/pkg/eventbus"
+	"golang.org/x/net/websocket"
+)
+
+const (
+	// OverviewMessageSnapshot carries the full OverviewData and is sent once after connecting.
+	// OverviewMessageSnapshot 携带完整的 OverviewData，在连接建立后发送一次。
+	OverviewMessageSnapshot = "snapshot"
+	// OverviewMessageStats carries recomputed OverviewStats after state changes.
+	// OverviewMessageStats 携带状态变化后重新计算的 OverviewStats。
+	OverviewMessageStats = "stats"
+	// OverviewMessagePing keeps idle connections alive through proxies.
+	// OverviewMessagePing 用于在代理之间保持空闲连接存活。
+	OverviewMessagePing = "ping"
+)
+
+const (
+	// statsDebounce coalesces bursts of state changes into one stats message.
+	// statsDebounce 将一连串状态变化合并为一条统计消息。
+	statsDebounce = time.Second
+	// streamPingInterval is how often a ping is sent on an otherwise idle connection.
+	// streamPingInterval 是向连接发送 ping 的间隔。
+	streamPingInterval = 30 * time.Second
+	// streamWriteTimeout bounds how long a single message write may block.
+	// streamWriteTimeout 限制单条消息写入的最长阻塞时间。
+	streamWriteTimeout = 10 * time.Second
+)
+
+// OverviewMessage is one message pushed on the overview WebSocket.
+// Deltas use the event bus topic as type (agent_status, cluster_status, node_status, installations).
+// OverviewMessage 是概览 WebSocket 推送的一条消息。
+// 增量消息以事件总线主题作为 type（agent_status、cluster_status、node_status、installations）。
+type OverviewMessage struct {
+	Type      string      `json:"type"`
+	Timestamp time.Time   `json:"timestamp"`
+	Data      interface{} `json:"data,omitempty"`
+}
+
+// StreamOverview godoc
+// @Summary Stream dashboard overview updates
+// @Description Upgrade to a WebSocket that sends an overview snapshot, then pushes Agent, cluster, node and installation deltas followed by refreshed stats
+// @Tags Dashboard
+// @Success 101 {object} OverviewMessage
+// @Failure 403 {string} string "origin not allowed"
+// @Router /api/v1/dashboard/overview/ws [get]
+func (h *OverviewHandler) StreamOverview(c *gin.Context) {
+	server := websocket.Server{
+		Handshake: checkStreamOrigin,
+		Handler: func(ws *websocket.Conn) {
+			h.streamOverview(c.Request.Context(), ws)
+		},
+	}
+	server.ServeHTTP(c.Writer, c.Request)
+}
+
+// checkStreamOrigin accepts same-origin browsers, non-browser clients and the configured CORS origins.
+// checkStreamOrigin 接受同源浏览器、非浏览器客户端以及已配置的 CORS 来源。
+func checkStreamOrigin(_ *websocket.Config, req *http.Request) error {
+	origin := strings.TrimSpace(req.Header.Get("Origin"))
+	if origin == "" {
+		return nil
+	}
+	parsed, err := url.Parse(origin)
+	if err == nil && strings.EqualFold(parsed.Host, req.Host) {
+		return nil
+	}
+	origin = strings.TrimSuffix(origin, "/")
+	if config.Config != nil {
+		for _, allowed := range config.Config.App.CORS.AllowedOrigins {
+			allowed = strings.TrimSuffix(strings.TrimSpace(allowed), "/")
+			if allowed == "*" || strings.EqualFold(allowed, origin) {
+				return nil
+			}
+		}
+	}
+	return errors.New("origin not allowed")
+}
+
+// streamOverview sends the snapshot, then forwards bus events until the client goes away.
+// streamOverview 先发送快照，然后转发事件总线上的事件，直到客户端断开。
+func (h *OverviewHandler) streamOverview(ctx context.Context, ws *websocket.Conn) {
+	defer ws.Close()
+
+	// Subscribe before building the snapshot so no change in between is lost.
+	// 在生成快照前订阅，避免遗漏期间发生的变化。
+	events, unsubscribe := eventbus.Subscribe()
+	defer unsubscribe()
+
+	data, err := h.service.GetOverviewData(ctx)
+	if err != nil {
+		logger.WarnF(ctx, "[Dashboard] build overview snapshot failed: %v", err)
+		return
+	}
+	if !writeOverviewMessage(ws, OverviewMessage{Type: OverviewMessageSnapshot, Timestamp: time.Now(), Data: data}) {
+		return
+	}
+
+	// The client sends nothing meaningful; reading only detects when it closes.
+	// 客户端不会发送有意义的数据；读取仅用于感知连接关闭。
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		var discard string
+		for {
+			if err := websocket.Message.Receive(ws, &discard); err != nil {
+				return
+			}
+		}
+	}()
+
+	pingTicker := time.NewTicker(streamPingInterval)
+	defer pingTicker.Stop()
+	statsTimer := time.NewTimer(statsDebounce)
+	statsTimer.Stop()
+	statsPending := false
+
+	for {
+		select {
+		case <-closed:
+			return
+		case <-ctx.Done():
+			return
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			if !writeOverviewMessage(ws, OverviewMessage{Type: string(event.Topic), Timestamp: event.Timestamp, Data: event.Data}) {
+				return
+			}
+			if !statsPending {
+				statsPending = true
+				statsTimer.Reset(statsDebounce)
+			}
+		case <-statsTimer.C:
+			statsPending = false
+			stats, err := h.service.GetOverviewStats(ctx)
+			if err != nil {
+				logger.WarnF(ctx, "[Dashboard] refresh overview stats failed: %v", err)
+				continue
+			}
+			if !writeOverviewMessage(ws, OverviewMessage{Type: OverviewMessageStats, Timestamp: time.Now(), Data: stats}) {
+				return
+			}
+		case <-pingTicker.C:
+			if !writeOverviewMessage(ws, OverviewMessage{Type: OverviewMessagePing, Timestamp: time.Now()}) {
+				return
+			}
+		}
+	}
+}
+
+// writeOverviewMessage writes one JSON message and reports whether the connection is still usable.
+// writeOverviewMessage 写入一条 JSON 消息，并返回连接是否仍可用。
+func writeOverviewMessage(ws *websocket.Conn, message OverviewMessage) bool {
+	_ = ws.SetWriteDeadline(time.Now().Add(streamWriteTimeout))
+	return websocket.JSON.Send(ws, message) == nil
+}
@@ -0,0 +1,133 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dashboard
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/glebarez/sqlite"
+	"github.com/seatunnel/seatunnelX/internal/apps/audit"
+	"github.com/seatunnel/seatunnelX/internal/apps/cluster"
+	"github.com/seatunnel/seatunnelX/internal/apps/host"
+	"github.com/seatunnel/seatunnelX/internal/pkg/eventbus"
+	"golang.org/x/net/websocket"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func newStreamTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "dashboard.db")), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	if err := db.AutoMigrate(&host.Host{}, &cluster.Cluster{}, &cluster.ClusterNode{}, &audit.AuditLog{}); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			_ = sqlDB.Close()
+		}
+	})
+
+	service := NewOverviewService(host.NewRepository(db), cluster.NewRepository(db), audit.NewRepository(db), 30*time.Second, time.Now())
+	service.SetInstallationCounter(func() int { return 2 })
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/ws", NewOverviewHandler(service).StreamOverview)
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func receiveOverviewMessage(t *testing.T, ws *websocket.Conn) map[string]interface{} {
+	t.Helper()
+	_ = ws.SetReadDeadline(time.Now().Add(5 * time.Second))
+	var message map[string]interface{}
+	if err := websocket.JSON.Receive(ws, &message); err != nil {
+		t.Fatalf("receive message: %v", err)
+	}
+	return message
+}
+
+func TestStreamOverview_sendsSnapshotThenDeltaAndStats(t *testing.T) {
+	server := newStreamTestServer(t)
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http") + "/ws"
+
+	ws, err := websocket.Dial(wsURL, "", server.URL)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer ws.Close()
+
+	snapshot := receiveOverviewMessage(t, ws)
+	if snapshot["type"] != OverviewMessageSnapshot {
+		t.Fatalf("first message type = %v, want %s", snapshot["type"], OverviewMessageSnapshot)
+	}
+
+	eventbus.Publish(eventbus.TopicClusterStatus, cluster.ClusterStatusEvent{ClusterID: 7, Status: cluster.ClusterStatusRunning})
+
+	delta := receiveOverviewMessage(t, ws)
+	if delta["type"] != string(eventbus.TopicClusterStatus) {
+		t.Fatalf("delta type = %v, want %s", delta["type"], eventbus.TopicClusterStatus)
+	}
+	if data, _ := delta["data"].(map[string]interface{}); data["cluster_id"] != float64(7) {
+		t.Fatalf("delta data = %v", delta["data"])
+	}
+
+	stats := receiveOverviewMessage(t, ws)
+	if stats["type"] != OverviewMessageStats {
+		t.Fatalf("message type = %v, want %s", stats["type"], OverviewMessageStats)
+	}
+	if data, _ := stats["data"].(map[string]interface{}); data["running_installations"] != float64(2) {
+		t.Fatalf("stats data = %v", stats["data"])
+	}
+}
+
+func TestStreamOverview_rejectsForeignOrigin(t *testing.T) {
+	server := newStreamTestServer(t)
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http") + "/ws"
+
+	if _, err := websocket.Dial(wsURL, "", "https://evil.example.com"); err == nil {
+		t.Fatal("expected handshake from foreign origin to fail")
+	}
+}
+
+func TestCheckStreamOrigin(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "http://seatunnelx.local/ws", nil)
+	if err := checkStreamOrigin(nil, req); err != nil {
+		t.Fatalf("request without origin rejected: %v", err)
+	}
+	req.Header.Set("Origin", "https://seatunnelx.local")
+	if err := checkStreamOrigin(nil, req); err != nil {
+		t.Fatalf("same-host origin rejected: %v", err)
+	}
+	req.Header.Set("Origin", "https://other.local")
+	if err := checkStreamOrigin(nil, req); err == nil {
+		t.Fatal("foreign origin accepted")
+	}
+}
@@ -23,6 +23,8 @@ import (
 	"time"
 
 	"github.com/seatunnel/seatunnelX/internal/apps/cluster"
+	"github.com/seatunnel/seatunnelX/internal/pkg/leader"
+	"github.com/seatunnel/seatunnelX/internal/pkg/worker"
 )
 
 const autoPolicyEvaluationInterval = time.Minute
@@ -36,11 +38,15 @@ func (s *Service) StartAutoPolicyRuntime(ctx context.Context) {
 
 	s.autoPolicyRuntime.Do(func() {
 		go func() {
+			defer worker.Start("diagnostics-auto-policy")()
+
 			ticker := time.NewTicker(autoPolicyEvaluationInterval)
 			defer ticker.Stop()
 
 			for {
-				s.runAutoPolicyEvaluationRound(ctx, time.Now().UTC())
+				if leader.IsLeader() {
+					s.runAutoPolicyEvaluationRound(ctx, time.Now().UTC())
+				}
 
 				select {
 				case <-ctx.Done():
@@ -0,0 +1,66 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package diagnostics
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/seatunnel/seatunnelX/internal/logger"
+	"github.com/seatunnel/seatunnelX/internal/pkg/objstore"
+)
+
+// SetBundleStore sets the object store shared by all replicas, so a bundle collected by one replica can be
+// previewed and downloaded through any other.
+// SetBundleStore 设置所有副本共享的对象存储，使某个副本收集的诊断包可以通过其他副本预览和下载。
+func (s *Service) SetBundleStore(store objstore.Store) {
+	if s == nil {
+		return
+	}
+	s.bundleStore = store
+}
+
+func diagnosticBundleStorePrefix(taskID uint) string {
+	return fmt.Sprintf("tasks/%d", taskID)
+}
+
+// mirrorDiagnosticBundle copies the files of a finished task bundle into the shared store.
+// mirrorDiagnosticBundle 将已结束任务的诊断包文件复制到共享存储。
+func (s *Service) mirrorDiagnosticBundle(ctx context.Context, task *DiagnosticTask) {
+	if s == nil || s.bundleStore == nil || task == nil || strings.TrimSpace(task.BundleDir) == "" {
+		return
+	}
+	if err := objstore.UploadDir(ctx, s.bundleStore, diagnosticBundleStorePrefix(task.ID), task.BundleDir); err != nil {
+		logger.WarnF(ctx, "[DiagnosticsTask] mirror bundle to shared store failed: task_id=%d err=%v", task.ID, err)
+	}
+}
+
+// fetchDiagnosticBundle copies a task bundle missing locally from the shared store and reports whether any file was fetched.
+// fetchDiagnosticBundle 从共享存储获取本地缺失的诊断包，返回是否获取到文件。
+func (s *Service) fetchDiagnosticBundle(ctx context.Context, task *DiagnosticTask, bundleDir string) bool {
+	if s == nil || s.bundleStore == nil || task == nil {
+		return false
+	}
+	written, err := objstore.DownloadDir(ctx, s.bundleStore, diagnosticBundleStorePrefix(task.ID), bundleDir)
+	if err != nil {
+		logger.WarnF(ctx, "[DiagnosticsTask] fetch bundle from shared store failed: task_id=%d err=%v", task.ID, err)
+		return false
+	}
+	return written > 0
+}
@@ -547,7 +547,13 @@ func (h *Handler) resolveDiagnosticTaskFile(c *gin.Context, selector func(task *
 		return nil, "", fmt.Errorf("diagnostics: invalid task file path")
 	}
 	if _, err := os.Stat(absPath); err != nil {
-		return nil, "", err
+		// The bundle may have been collected by another replica / 诊断包可能由其他副本收集
+		if !h.service.fetchDiagnosticBundle(c.Request.Context(), task, absBundleDir) {
+			return nil, "", err
+		}
+		if _, err := os.Stat(absPath); err != nil {
+			return nil, "", err
+		}
 	}
 	return task, absPath, nil
 }
@@ -31,6 +31,7 @@ import (
 	"github.com/seatunnel/seatunnelX/internal/apps/monitor"
 	monitoringapp "github.com/seatunnel/seatunnelX/internal/apps/monitoring"
 	"github.com/seatunnel/seatunnelX/internal/db"
+	"github.com/seatunnel/seatunnelX/internal/pkg/objstore"
 )
 
 const (
@@ -75,6 +76,7 @@ type Service struct {
 	taskEventsOnce    sync.Once
 	autoPolicyRuntime sync.Once
 	policyChecker     *AutoPolicyChecker
+	bundleStore       objstore.Store
 }
 
 // ListLogCursorsByAgent returns all log cursors for the given agent.
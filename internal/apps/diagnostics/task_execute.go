@@ -644,6 +644,7 @@ func (s *Service) executeDiagnosticTask(ctx context.Context, taskID uint) {
 	if err := s.runDiagnosticTask(ctx, task); err != nil {
 		logger.ErrorF(ctx, "[DiagnosticsTask] run task failed: task_id=%d err=%v", taskID, err)
 	}
+	s.mirrorDiagnosticBundle(ctx, task)
 }
 
 func (s *Service) runDiagnosticTask(ctx context.Context, task *DiagnosticTask) error {
@@ -0,0 +1,303 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ha
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/seatunnel/seatunnelX/internal/apps/agent"
+	"github.com/seatunnel/seatunnelX/internal/apps/response"
+	"github.com/seatunnel/seatunnelX/internal/pkg/secrets"
+	"github.com/seatunnel/seatunnelX/internal/pkg/tracex"
+	pb "github.com/seatunnel/seatunnelX/internal/proto/agent"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// ForwardPath is the internal endpoint, below the API prefix, that executes forwarded commands.
+// ForwardPath 是执行转发命令的内部接口路径（位于 API 前缀之下）。
+const ForwardPath = "/v1/internal/ha/commands"
+
+// forwardGrace is added to the command timeout so the owner replica reports the timeout itself.
+// forwardGrace 附加在命令超时之上，使持有副本能自行返回超时结果。
+const forwardGrace = 5 * time.Second
+
+// forwardMaxAge bounds how old a forwarded command may be when it arrives; command IDs are remembered
+// for as long, so a captured request can be replayed neither later nor within the window.
+// forwardMaxAge 限制转发命令到达时的最大时长；命令 ID 在此期间内被记录，截获的请求在窗口内外均无法重放。
+const forwardMaxAge = 30 * time.Second
+
+// forwardRequest is the forwarded command. It travels sealed because parameters may carry credentials,
+// and a body that opens proves the sender holds the shared secret.
+// forwardRequest 是转发的命令。参数中可能含有凭证，因此以加密形式传输；能够解密即证明发送方持有共享密钥。
+type forwardRequest struct {
+	ID             string            `json:"id"`
+	AgentID        string            `json:"agent_id"`
+	Type           string            `json:"type"`
+	Parameters     map[string]string `json:"parameters"`
+	TimeoutSeconds int               `json:"timeout_seconds"`
+	IssuedAt       int64             `json:"issued_at"`
+}
+
+// sealedBody carries a forwarded command or its result sealed with the key derived from the shared secret.
+// sealedBody 携带以共享密钥派生的密钥加密的转发命令或其结果。
+type sealedBody struct {
+	Sealed string `json:"sealed" binding:"required"`
+}
+
+// forwardResult is the sealed result of a forwarded command, bound to the command by its ID.
+// forwardResult 是转发命令的加密结果，通过命令 ID 与命令绑定。
+type forwardResult struct {
+	ID     string          `json:"id"`
+	Result json.RawMessage `json:"result"`
+}
+
+// newForwardBox derives the box sealing forwarded traffic from the shared secret; nil when no secret is set.
+// newForwardBox 从共享密钥派生用于加密转发流量的 Box；未设置密钥时返回 nil。
+func newForwardBox(sharedSecret string) *secrets.Box {
+	if sharedSecret == "" {
+		return nil
+	}
+	box, err := secrets.NewBox(secrets.NewMasterKeyProvider("ha-forward/" + sharedSecret))
+	if err != nil {
+		return nil
+	}
+	return box
+}
+
+// sealJSON marshals v and seals it with box.
+// sealJSON 序列化 v 并使用 box 加密。
+func sealJSON(box *secrets.Box, v interface{}) (*sealedBody, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	sealed, err := box.Seal(string(data))
+	if err != nil {
+		return nil, err
+	}
+	return &sealedBody{Sealed: sealed}, nil
+}
+
+// openJSON opens a sealed body into v. Unsealed values are rejected.
+// openJSON 将加密内容解密到 v，未加密的值会被拒绝。
+func openJSON(box *secrets.Box, body *sealedBody, v interface{}) error {
+	if !secrets.IsSealed(body.Sealed) {
+		return errors.New("ha: forwarded body is not sealed")
+	}
+	data, err := box.Open(body.Sealed)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal([]byte(data), v)
+}
+
+// forwardEnvelope is the part of the response envelope read by the forwarding replica.
+// forwardEnvelope 是转发方读取的响应信封字段。
+type forwardEnvelope struct {
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data"`
+}
+
+// LocalCommandSender executes commands on Agents connected to this replica.
+// LocalCommandSender 在连接到本副本的 Agent 上执行命令。
+type LocalCommandSender interface {
+	GetAgent(agentID string) (*agent.AgentConnection, bool)
+	SendCommand(ctx context.Context, agentID string, cmdType pb.CommandType, params map[string]string, timeout time.Duration) (*pb.CommandResponse, error)
+}
+
+// ForwardCommand implements agent.CommandForwarder by posting the command to the owner replica.
+// ForwardCommand 通过将命令提交给持有副本来实现 agent.CommandForwarder。
+func (n *Node) ForwardCommand(ctx context.Context, agentID string, cmdType pb.CommandType, params map[string]string, timeout time.Duration) (*pb.CommandResponse, error) {
+	owner, err := n.store.Owner(ctx, agentID, time.Now().Add(-n.config.LeaseTTL))
+	if errors.Is(err, ErrNoOwner) || (err == nil && owner.ID == n.config.InstanceID) {
+		return nil, agent.ErrAgentNotRemote
+	}
+	if err != nil {
+		return nil, err
+	}
+	if owner.AdvertiseAddr == "" {
+		return nil, fmt.Errorf("ha: instance %s has no advertise address", owner.ID)
+	}
+	if n.box == nil {
+		return nil, errors.New("ha: shared secret is not configured")
+	}
+
+	commandID := uuid.New().String()
+	sealed, err := sealJSON(n.box, forwardRequest{
+		ID:             commandID,
+		AgentID:        agentID,
+		Type:           cmdType.String(),
+		Parameters:     params,
+		TimeoutSeconds: int(timeout.Seconds()),
+		IssuedAt:       time.Now().Unix(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	body, err := json.Marshal(sealed)
+	if err != nil {
+		return nil, err
+	}
+
+	reqCtx, cancel := context.WithTimeout(ctx, timeout+forwardGrace)
+	defer cancel()
+	url := strings.TrimSuffix(owner.AdvertiseAddr, "/") + strings.TrimSuffix(n.config.APIPrefix, "/") + ForwardPath
+	req, err := http.NewRequestWithContext(reqCtx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range tracex.Inject(ctx) {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("ha: forward command to %s: %w", owner.ID, err)
+	}
+	defer resp.Body.Close()
+
+	var envelope forwardEnvelope
+	if err := json.NewDecoder(resp.Body).Decode(&envelope); err != nil {
+		return nil, fmt.Errorf("ha: decode response of %s: %w", owner.ID, err)
+	}
+	switch resp.StatusCode {
+	case http.StatusOK:
+		var sealedResult sealedBody
+		if err := json.Unmarshal(envelope.Data, &sealedResult); err != nil {
+			return nil, fmt.Errorf("ha: decode command response of %s: %w", owner.ID, err)
+		}
+		var forwarded forwardResult
+		if err := openJSON(n.box, &sealedResult, &forwarded); err != nil {
+			return nil, fmt.Errorf("ha: open command response of %s: %w", owner.ID, err)
+		}
+		if forwarded.ID != commandID {
+			return nil, fmt.Errorf("ha: command response of %s does not match the command", owner.ID)
+		}
+		result := &pb.CommandResponse{}
+		if err := protojson.Unmarshal(forwarded.Result, result); err != nil {
+			return nil, fmt.Errorf("ha: decode command response of %s: %w", owner.ID, err)
+		}
+		return result, nil
+	case http.StatusNotFound:
+		return nil, agent.ErrAgentNotFound
+	case http.StatusGatewayTimeout:
+		return nil, agent.ErrCommandTimeout
+	default:
+		return nil, fmt.Errorf("ha: instance %s rejected command: %s", owner.ID, envelope.Message)
+	}
+}
+
+// CommandHandler executes commands forwarded by peer replicas on Agents connected here.
+// Only Agents with a live stream on this replica are served, so commands are never forwarded twice.
+// Commands and results are sealed with a key derived from the shared secret, which itself never crosses the
+// network: a command that opens is authentic, and its ID is accepted once within forwardMaxAge.
+// CommandHandler 在连接到本副本的 Agent 上执行其他副本转发来的命令。
+// 仅处理在本副本上有活动命令流的 Agent，因此命令不会被二次转发。
+// 命令与结果均使用由共享密钥派生的密钥加密，共享密钥本身不在网络上传输：能够解密的命令即为可信命令，
+// 且同一命令 ID 在 forwardMaxAge 内只被接受一次。
+func (n *Node) CommandHandler(sender LocalCommandSender) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if n.box == nil {
+			response.Error(c, http.StatusUnauthorized, "ha shared secret is not configured")
+			return
+		}
+
+		var body sealedBody
+		if err := c.ShouldBindJSON(&body); err != nil {
+			response.Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		var req forwardRequest
+		if err := openJSON(n.box, &body, &req); err != nil {
+			response.Error(c, http.StatusUnauthorized, "invalid forwarded command")
+			return
+		}
+		if age := time.Since(time.Unix(req.IssuedAt, 0)); age > forwardMaxAge || age < -forwardMaxAge {
+			response.Error(c, http.StatusUnauthorized, "forwarded command expired")
+			return
+		}
+		if req.ID == "" || !n.acceptForward(req.ID, time.Now()) {
+			response.Error(c, http.StatusUnauthorized, "forwarded command replayed")
+			return
+		}
+		cmdType, ok := pb.CommandType_value[req.Type]
+		if !ok {
+			response.Error(c, http.StatusBadRequest, "unknown command type: "+req.Type)
+			return
+		}
+		if conn, ok := sender.GetAgent(req.AgentID); !ok || conn.GetStream() == nil {
+			response.Error(c, http.StatusNotFound, agent.ErrAgentNotFound.Error())
+			return
+		}
+
+		// The otelgin middleware has already joined the forwarding replica's trace.
+		// otelgin 中间件已将请求接入转发方副本的追踪链路。
+		result, err := sender.SendCommand(c.Request.Context(), req.AgentID, pb.CommandType(cmdType), req.Parameters, time.Duration(req.TimeoutSeconds)*time.Second)
+		switch {
+		case err == nil:
+		case errors.Is(err, agent.ErrAgentNotFound), errors.Is(err, agent.ErrAgentNotConnected):
+			response.Error(c, http.StatusNotFound, err.Error())
+			return
+		case errors.Is(err, agent.ErrCommandTimeout):
+			response.Error(c, http.StatusGatewayTimeout, err.Error())
+			return
+		default:
+			response.Error(c, http.StatusBadGateway, err.Error())
+			return
+		}
+
+		data, err := protojson.Marshal(result)
+		if err != nil {
+			response.Error(c, http.StatusInternalServerError, err.Error())
+			return
+		}
+		sealed, err := sealJSON(n.box, forwardResult{ID: req.ID, Result: data})
+		if err != nil {
+			response.Error(c, http.StatusInternalServerError, err.Error())
+			return
+		}
+		response.OK(c, sealed)
+	}
+}
+
+// acceptForward records a forwarded command ID and reports whether it was not seen within forwardMaxAge.
+// acceptForward 记录转发命令 ID，并返回其在 forwardMaxAge 内是否未出现过。
+func (n *Node) acceptForward(id string, now time.Time) bool {
+	n.forwardMu.Lock()
+	defer n.forwardMu.Unlock()
+	for seen, at := range n.forwardSeen {
+		if now.Sub(at) > 2*forwardMaxAge {
+			delete(n.forwardSeen, seen)
+		}
+	}
+	if _, ok := n.forwardSeen[id]; ok {
+		return false
+	}
+	n.forwardSeen[id] = now
+	return true
+}
@@ -0,0 +1,195 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ha
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/seatunnel/seatunnelX/internal/apps/agent"
+	pb "github.com/seatunnel/seatunnelX/internal/proto/agent"
+	"google.golang.org/grpc"
+)
+
+type fakeStream struct {
+	grpc.BidiStreamingServer[pb.CommandResponse, pb.CommandRequest]
+}
+
+type fakeLocalSender struct {
+	agents map[string]*agent.AgentConnection
+	calls  []string
+}
+
+func (s *fakeLocalSender) GetAgent(agentID string) (*agent.AgentConnection, bool) {
+	conn, ok := s.agents[agentID]
+	return conn, ok
+}
+
+func (s *fakeLocalSender) SendCommand(_ context.Context, agentID string, cmdType pb.CommandType, params map[string]string, _ time.Duration) (*pb.CommandResponse, error) {
+	s.calls = append(s.calls, agentID+":"+cmdType.String())
+	return &pb.CommandResponse{CommandId: "cmd-1", Status: pb.CommandStatus_SUCCESS, Output: params["path"]}, nil
+}
+
+func TestForwardCommandRoutesToOwnerReplica(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctx := context.Background()
+	database := setupHATestDB(t)
+
+	conn := &agent.AgentConnection{AgentID: "agent-1"}
+	conn.SetStream(&fakeStream{})
+	sender := &fakeLocalSender{agents: map[string]*agent.AgentConnection{"agent-1": conn}}
+
+	owner := NewNode(database, Config{InstanceID: "cp-owner", APIPrefix: "/api", SharedSecret: "secret"})
+	engine := gin.New()
+	engine.POST("/api"+ForwardPath, owner.CommandHandler(sender))
+	server := httptest.NewServer(engine)
+	defer server.Close()
+	owner.config.AdvertiseAddr = server.URL
+
+	peer := NewNode(database, Config{InstanceID: "cp-peer", APIPrefix: "/api", SharedSecret: "secret"})
+	owner.Start(ctx)
+	defer owner.Stop(ctx)
+	peer.Start(ctx)
+	defer peer.Stop(ctx)
+
+	if _, err := peer.ForwardCommand(ctx, "agent-1", pb.CommandType_PULL_CONFIG, nil, time.Second); !errors.Is(err, agent.ErrAgentNotRemote) {
+		t.Fatalf("ForwardCommand() before claim error = %v, want ErrAgentNotRemote", err)
+	}
+
+	if err := owner.Claim(ctx, "agent-1"); err != nil {
+		t.Fatalf("Claim() error = %v", err)
+	}
+	resp, err := peer.ForwardCommand(ctx, "agent-1", pb.CommandType_PULL_CONFIG, map[string]string{"path": "/opt/seatunnel"}, time.Second)
+	if err != nil {
+		t.Fatalf("ForwardCommand() error = %v", err)
+	}
+	if resp.Status != pb.CommandStatus_SUCCESS || resp.Output != "/opt/seatunnel" {
+		t.Fatalf("ForwardCommand() = %+v", resp)
+	}
+	if len(sender.calls) != 1 || sender.calls[0] != "agent-1:PULL_CONFIG" {
+		t.Fatalf("owner executed %v", sender.calls)
+	}
+
+	// The owner never forwards to itself.
+	if _, err := owner.ForwardCommand(ctx, "agent-1", pb.CommandType_PULL_CONFIG, nil, time.Second); !errors.Is(err, agent.ErrAgentNotRemote) {
+		t.Fatalf("owner ForwardCommand() error = %v, want ErrAgentNotRemote", err)
+	}
+
+	// An Agent claimed by the owner but no longer connected there is reported as not found.
+	if err := owner.Claim(ctx, "agent-gone"); err != nil {
+		t.Fatalf("Claim() error = %v", err)
+	}
+	if _, err := peer.ForwardCommand(ctx, "agent-gone", pb.CommandType_PULL_CONFIG, nil, time.Second); !errors.Is(err, agent.ErrAgentNotFound) {
+		t.Fatalf("ForwardCommand(agent-gone) error = %v, want ErrAgentNotFound", err)
+	}
+}
+
+// postForwarded posts a sealed command to the handler, optionally with the shared secret in a header.
+func postForwarded(t *testing.T, engine *gin.Engine, body interface{}, headers map[string]string) int {
+	t.Helper()
+	data, err := json.Marshal(body)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	req := httptest.NewRequest(http.MethodPost, ForwardPath, bytes.NewReader(data))
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+	recorder := httptest.NewRecorder()
+	engine.ServeHTTP(recorder, req)
+	return recorder.Code
+}
+
+func TestCommandHandlerAuthenticatesBodyNotHeader(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	node := NewNode(setupHATestDB(t), Config{InstanceID: "cp-a", SharedSecret: "secret"})
+	conn := &agent.AgentConnection{AgentID: "agent-1"}
+	conn.SetStream(&fakeStream{})
+	sender := &fakeLocalSender{agents: map[string]*agent.AgentConnection{"agent-1": conn}}
+	engine := gin.New()
+	engine.POST(ForwardPath, node.CommandHandler(sender))
+
+	// Knowing the shared secret is useless without sealing the body with the key derived from it.
+	otherKey, err := sealJSON(newForwardBox("other"), forwardRequest{ID: "cmd-a", AgentID: "agent-1", Type: pb.CommandType_PULL_CONFIG.String(), IssuedAt: time.Now().Unix()})
+	if err != nil {
+		t.Fatalf("sealJSON() error = %v", err)
+	}
+	if code := postForwarded(t, engine, otherKey, map[string]string{"X-SeaTunnelX-HA-Token": "secret"}); code != http.StatusUnauthorized {
+		t.Fatalf("wrong key status = %d, want %d", code, http.StatusUnauthorized)
+	}
+	if len(sender.calls) != 0 {
+		t.Fatalf("rejected command reached the Agent: %v", sender.calls)
+	}
+
+	// A command sealed with the right key runs once; replaying it inside the window is rejected.
+	sealed, err := sealJSON(node.box, forwardRequest{ID: "cmd-b", AgentID: "agent-1", Type: pb.CommandType_PULL_CONFIG.String(), IssuedAt: time.Now().Unix()})
+	if err != nil {
+		t.Fatalf("sealJSON() error = %v", err)
+	}
+	if code := postForwarded(t, engine, sealed, nil); code != http.StatusOK {
+		t.Fatalf("sealed status = %d, want %d", code, http.StatusOK)
+	}
+	if code := postForwarded(t, engine, sealed, nil); code != http.StatusUnauthorized {
+		t.Fatalf("replayed status = %d, want %d", code, http.StatusUnauthorized)
+	}
+	if len(sender.calls) != 1 {
+		t.Fatalf("owner executed %v, want a single call", sender.calls)
+	}
+}
+
+func TestCommandHandlerRejectsUnsealedAndStaleCommands(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	node := NewNode(setupHATestDB(t), Config{InstanceID: "cp-a", SharedSecret: "secret"})
+	sender := &fakeLocalSender{agents: map[string]*agent.AgentConnection{"agent-1": {AgentID: "agent-1"}}}
+	engine := gin.New()
+	engine.POST(ForwardPath, node.CommandHandler(sender))
+
+	post := func(body interface{}) int { return postForwarded(t, engine, body, nil) }
+
+	plain, _ := json.Marshal(forwardRequest{AgentID: "agent-1", Type: pb.CommandType_PULL_CONFIG.String(), IssuedAt: time.Now().Unix()})
+	if code := post(sealedBody{Sealed: string(plain)}); code != http.StatusUnauthorized {
+		t.Fatalf("unsealed status = %d, want %d", code, http.StatusUnauthorized)
+	}
+
+	otherKey, err := sealJSON(newForwardBox("other"), forwardRequest{AgentID: "agent-1", Type: pb.CommandType_PULL_CONFIG.String(), IssuedAt: time.Now().Unix()})
+	if err != nil {
+		t.Fatalf("sealJSON() error = %v", err)
+	}
+	if code := post(otherKey); code != http.StatusUnauthorized {
+		t.Fatalf("wrong key status = %d, want %d", code, http.StatusUnauthorized)
+	}
+
+	stale, err := sealJSON(node.box, forwardRequest{ID: "cmd-stale", AgentID: "agent-1", Type: pb.CommandType_PULL_CONFIG.String(), IssuedAt: time.Now().Add(-time.Minute).Unix()})
+	if err != nil {
+		t.Fatalf("sealJSON() error = %v", err)
+	}
+	if code := post(stale); code != http.StatusUnauthorized {
+		t.Fatalf("stale status = %d, want %d", code, http.StatusUnauthorized)
+	}
+	if len(sender.calls) != 0 {
+		t.Fatalf("rejected commands reached the Agent: %v", sender.calls)
+	}
+}
@@ -0,0 +1,52 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ha
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/seatunnel/seatunnelX/internal/apps/response"
+)
+
+// InstancesResponse lists the live Control Plane replicas.
+// InstancesResponse 列出存活的 Control Plane 副本。
+type InstancesResponse struct {
+	Self      string      `json:"self"`
+	Leader    string      `json:"leader"`
+	Instances []*Instance `json:"instances"`
+}
+
+// ListInstances handles GET /api/v1/admin/ha/instances - lists live replicas and the current leader
+// ListInstances 处理 GET /api/v1/admin/ha/instances - 列出存活副本与当前 leader
+// @Tags admin
+// @Produce json
+// @Success 200 {object} InstancesResponse
+// @Router /api/v1/admin/ha/instances [get]
+func (n *Node) ListInstances(c *gin.Context) {
+	instances, leaderID, err := n.Instances(c.Request.Context())
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	response.OK(c, InstancesResponse{
+		Self:      n.config.InstanceID,
+		Leader:    leaderID,
+		Instances: instances,
+	})
+}
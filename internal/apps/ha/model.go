@@ -0,0 +1,74 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package ha lets several Control Plane replicas share one database and run behind a load balancer.
+// Replicas publish liveness in ha_instances, record which replica holds each Agent stream in
+// ha_agent_ownerships, forward commands to the owning replica over HTTP, and elect one leader
+// through a lease in ha_leases to run singleton background jobs.
+// Package ha 使多个 Control Plane 副本共享同一数据库并部署在负载均衡之后。
+// 副本在 ha_instances 中发布存活状态，在 ha_agent_ownerships 中记录各 Agent 命令流由哪个副本持有，
+// 通过 HTTP 将命令转发给持有副本，并借助 ha_leases 中的租约选出唯一 leader 执行单例后台任务。
+package ha
+
+import "time"
+
+// LeaderLeaseName is the lease that grants leadership of background jobs.
+// LeaderLeaseName 是授予后台任务 leader 身份的租约名。
+const LeaderLeaseName = "control-plane-leader"
+
+// Instance is a live Control Plane replica.
+// Instance 表示一个存活的 Control Plane 副本。
+type Instance struct {
+	ID            string    `gorm:"primaryKey;size:128" json:"id"`
+	AdvertiseAddr string    `gorm:"size:255" json:"advertise_addr"`
+	StartedAt     time.Time `json:"started_at"`
+	HeartbeatAt   time.Time `gorm:"index" json:"heartbeat_at"`
+}
+
+// TableName returns the table name for Instance.
+// TableName 返回 Instance 的表名。
+func (Instance) TableName() string {
+	return "ha_instances"
+}
+
+// AgentOwnership records the replica holding the command stream of an Agent.
+// AgentOwnership 记录持有某个 Agent 命令流的副本。
+type AgentOwnership struct {
+	AgentID    string    `gorm:"primaryKey;size:128" json:"agent_id"`
+	InstanceID string    `gorm:"size:128;index" json:"instance_id"`
+	ClaimedAt  time.Time `json:"claimed_at"`
+}
+
+// TableName returns the table name for AgentOwnership.
+// TableName 返回 AgentOwnership 的表名。
+func (AgentOwnership) TableName() string {
+	return "ha_agent_ownerships"
+}
+
+// Lease is a named, expiring lock held by one replica.
+// Lease 是由一个副本持有、会过期的具名锁。
+type Lease struct {
+	Name      string    `gorm:"primaryKey;size:64" json:"name"`
+	Holder    string    `gorm:"size:128" json:"holder"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// TableName returns the table name for Lease.
+// TableName 返回 Lease 的表名。
+func (Lease) TableName() string {
+	return "ha_leases"
+}
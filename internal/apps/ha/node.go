@@ -0,0 +1,251 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ha
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/seatunnel/seatunnelX/internal/logger"
+	"github.com/seatunnel/seatunnelX/internal/pkg/secrets"
+	"gorm.io/gorm"
+)
+
+// DefaultLeaseTTL is how long a replica stays alive and leader without renewing.
+// DefaultLeaseTTL 是副本在不续约的情况下保持存活与 leader 身份的时长。
+const DefaultLeaseTTL = 15 * time.Second
+
+// Config configures a Control Plane replica.
+// Config 配置一个 Control Plane 副本。
+type Config struct {
+	// InstanceID identifies the replica; empty generates hostname-uuid.
+	// InstanceID 标识副本；为空时生成 hostname-uuid。
+	InstanceID string
+
+	// AdvertiseAddr is the base URL peers use to reach this replica's HTTP API, e.g. http://10.0.0.1:8000.
+	// AdvertiseAddr 是其他副本访问本副本 HTTP API 的基础 URL，例如 http://10.0.0.1:8000。
+	AdvertiseAddr string
+
+	// APIPrefix is the HTTP API prefix shared by all replicas, e.g. /api.
+	// APIPrefix 是所有副本共用的 HTTP API 前缀，例如 /api。
+	APIPrefix string
+
+	// SharedSecret authenticates and seals forwarded commands between replicas; forwarding is refused without it.
+	// SharedSecret 用于副本之间转发命令的认证与加密；未设置时拒绝转发。
+	SharedSecret string
+
+	// LeaseTTL is the liveness and leadership lease duration; renewals happen every LeaseTTL/3.
+	// LeaseTTL 是存活与 leader 租约时长；每 LeaseTTL/3 续约一次。
+	LeaseTTL time.Duration
+}
+
+// Node is the high-availability runtime of one Control Plane replica.
+// Node 是单个 Control Plane 副本的高可用运行时。
+type Node struct {
+	store  *Store
+	config Config
+	client *http.Client
+	box    *secrets.Box
+
+	// forwardSeen remembers the IDs of forwarded commands accepted recently, rejecting replays.
+	// forwardSeen 记录最近接受的转发命令 ID，用于拒绝重放。
+	forwardMu   sync.Mutex
+	forwardSeen map[string]time.Time
+
+	startedAt time.Time
+	started   atomic.Bool
+	leading   atomic.Bool
+
+	// onOrphanedAgents is called on the leader with Agents whose replica died.
+	// onOrphanedAgents 在 leader 上以所属副本已失效的 Agent 调用。
+	onOrphanedAgents func(ctx context.Context, agentIDs []string)
+
+	stopOnce sync.Once
+	stopChan chan struct{}
+	done     chan struct{}
+}
+
+// NewNode creates a new Node instance.
+// NewNode 创建一个新的 Node 实例。
+func NewNode(db *gorm.DB, config Config) *Node {
+	if config.InstanceID == "" {
+		config.InstanceID = defaultInstanceID()
+	}
+	if config.LeaseTTL <= 0 {
+		config.LeaseTTL = DefaultLeaseTTL
+	}
+	return &Node{
+		store:       NewStore(db),
+		config:      config,
+		client:      &http.Client{},
+		box:         newForwardBox(config.SharedSecret),
+		forwardSeen: make(map[string]time.Time),
+		startedAt:   time.Now(),
+		stopChan:    make(chan struct{}),
+		done:        make(chan struct{}),
+	}
+}
+
+// defaultInstanceID returns hostname-uuid, unique across restarts of the same host.
+// defaultInstanceID 返回 hostname-uuid，同一主机重启后也保持唯一。
+func defaultInstanceID() string {
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		hostname = "seatunnelx"
+	}
+	return fmt.Sprintf("%s-%s", hostname, uuid.New().String()[:8])
+}
+
+// InstanceID returns the ID of this replica.
+// InstanceID 返回当前副本的 ID。
+func (n *Node) InstanceID() string {
+	return n.config.InstanceID
+}
+
+// IsLeader reports whether this replica holds the leader lease.
+// IsLeader 报告当前副本是否持有 leader 租约。
+func (n *Node) IsLeader() bool {
+	return n.leading.Load()
+}
+
+// SetOnOrphanedAgents sets the callback the leader runs for Agents whose replica stopped heartbeating,
+// typically marking their hosts offline.
+// SetOnOrphanedAgents 设置 leader 对所属副本已停止心跳的 Agent 执行的回调，通常用于将其主机标记为离线。
+func (n *Node) SetOnOrphanedAgents(fn func(ctx context.Context, agentIDs []string)) {
+	n.onOrphanedAgents = fn
+}
+
+// Start publishes liveness, competes for leadership once, and keeps both renewed in the background.
+// Start 发布存活状态并立即竞争一次 leader，随后在后台持续续约。
+func (n *Node) Start(ctx context.Context) {
+	if n.started.Swap(true) {
+		return
+	}
+	n.tick(ctx, time.Now())
+	go func() {
+		defer close(n.done)
+		ticker := time.NewTicker(n.config.LeaseTTL / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-n.stopChan:
+				return
+			case now := <-ticker.C:
+				n.tick(ctx, now)
+			}
+		}
+	}()
+}
+
+// Stop leaves the replica set: it gives up leadership and Agent ownership so peers take over immediately.
+// Stop 退出副本集合：放弃 leader 身份与 Agent 归属，使其他副本立即接管。
+func (n *Node) Stop(ctx context.Context) {
+	n.stopOnce.Do(func() {
+		close(n.stopChan)
+		if n.started.Load() {
+			<-n.done
+		}
+		n.leading.Store(false)
+		if err := n.store.ReleaseLease(ctx, LeaderLeaseName, n.config.InstanceID); err != nil {
+			logger.WarnF(ctx, "[HA] release leader lease failed: %v", err)
+		}
+		if err := n.store.RemoveInstance(ctx, n.config.InstanceID); err != nil {
+			logger.WarnF(ctx, "[HA] remove instance %s failed: %v", n.config.InstanceID, err)
+		}
+	})
+}
+
+// tick renews liveness and leadership, and lets the leader reap Agents of dead replicas.
+// tick 续约存活与 leader 租约，并由 leader 回收已失效副本的 Agent。
+func (n *Node) tick(ctx context.Context, now time.Time) {
+	if err := n.store.Heartbeat(ctx, &Instance{
+		ID:            n.config.InstanceID,
+		AdvertiseAddr: n.config.AdvertiseAddr,
+		StartedAt:     n.startedAt,
+		HeartbeatAt:   now,
+	}); err != nil {
+		logger.WarnF(ctx, "[HA] instance heartbeat failed: %v", err)
+	}
+
+	leading, err := n.store.TryAcquireLease(ctx, LeaderLeaseName, n.config.InstanceID, n.config.LeaseTTL, now)
+	if err != nil {
+		logger.WarnF(ctx, "[HA] leader lease renewal failed: %v", err)
+		leading = false
+	}
+	if leading != n.leading.Swap(leading) {
+		logger.InfoF(ctx, "[HA] instance %s leader=%t", n.config.InstanceID, leading)
+	}
+	if !leading {
+		return
+	}
+
+	orphaned, err := n.store.ReapOrphans(ctx, now.Add(-n.config.LeaseTTL))
+	if err != nil {
+		logger.WarnF(ctx, "[HA] reap orphaned agents failed: %v", err)
+		return
+	}
+	if len(orphaned) > 0 && n.onOrphanedAgents != nil {
+		n.onOrphanedAgents(ctx, orphaned)
+	}
+}
+
+// Claim implements agent.OwnershipRegistry.
+// Claim 实现 agent.OwnershipRegistry。
+func (n *Node) Claim(ctx context.Context, agentID string) error {
+	if err := n.store.Claim(ctx, agentID, n.config.InstanceID, time.Now()); err != nil {
+		logger.WarnF(ctx, "[HA] claim agent %s failed: %v", agentID, err)
+		return err
+	}
+	return nil
+}
+
+// Release implements agent.OwnershipRegistry.
+// Release 实现 agent.OwnershipRegistry。
+func (n *Node) Release(ctx context.Context, agentID string) error {
+	if err := n.store.Release(ctx, agentID, n.config.InstanceID); err != nil {
+		logger.WarnF(ctx, "[HA] release agent %s failed: %v", agentID, err)
+		return err
+	}
+	return nil
+}
+
+// Leases returns the lease store shared by all replicas, e.g. for cluster-wide concurrency slots.
+// Leases 返回所有副本共享的租约存储，例如用于集群级并发名额。
+func (n *Node) Leases() *Store {
+	return n.store
+}
+
+// Instances returns the live replicas and the current leader.
+// Instances 返回存活的副本以及当前 leader。
+func (n *Node) Instances(ctx context.Context) ([]*Instance, string, error) {
+	now := time.Now()
+	instances, err := n.store.ListInstances(ctx, now.Add(-n.config.LeaseTTL))
+	if err != nil {
+		return nil, "", err
+	}
+	leaderID, err := n.store.LeaseHolder(ctx, LeaderLeaseName, now)
+	return instances, leaderID, err
+}
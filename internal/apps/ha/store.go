@@ -0,0 +1,170 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ha
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrNoOwner indicates no live replica holds the Agent.
+// ErrNoOwner 表示没有存活的副本持有该 Agent。
+var ErrNoOwner = errors.New("ha: agent has no live owner")
+
+// Store persists replicas, Agent ownership and leases.
+// Store 持久化副本、Agent 归属与租约。
+type Store struct {
+	db *gorm.DB
+}
+
+// NewStore creates a new Store instance.
+// NewStore 创建一个新的 Store 实例。
+func NewStore(db *gorm.DB) *Store {
+	return &Store{db: db}
+}
+
+// Heartbeat creates or refreshes the liveness record of a replica.
+// Heartbeat 创建或刷新副本的存活记录。
+func (s *Store) Heartbeat(ctx context.Context, instance *Instance) error {
+	return s.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"advertise_addr", "heartbeat_at"}),
+	}).Create(instance).Error
+}
+
+// RemoveInstance deletes a replica together with the Agents it owns.
+// RemoveInstance 删除副本及其持有的 Agent 归属。
+func (s *Store) RemoveInstance(ctx context.Context, instanceID string) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("instance_id = ?", instanceID).Delete(&AgentOwnership{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("id = ?", instanceID).Delete(&Instance{}).Error
+	})
+}
+
+// ListInstances returns the replicas whose heartbeat is newer than since.
+// ListInstances 返回心跳晚于 since 的副本。
+func (s *Store) ListInstances(ctx context.Context, since time.Time) ([]*Instance, error) {
+	var instances []*Instance
+	err := s.db.WithContext(ctx).Where("heartbeat_at >= ?", since).Order("id").Find(&instances).Error
+	return instances, err
+}
+
+// Claim records instanceID as the owner of the Agent, replacing any previous owner.
+// Claim 将 instanceID 记录为该 Agent 的持有者，替换之前的持有者。
+func (s *Store) Claim(ctx context.Context, agentID, instanceID string, now time.Time) error {
+	return s.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "agent_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"instance_id", "claimed_at"}),
+	}).Create(&AgentOwnership{AgentID: agentID, InstanceID: instanceID, ClaimedAt: now}).Error
+}
+
+// Release drops the ownership of the Agent when instanceID still holds it.
+// Release 在 instanceID 仍持有该 Agent 时释放其归属。
+func (s *Store) Release(ctx context.Context, agentID, instanceID string) error {
+	return s.db.WithContext(ctx).
+		Where("agent_id = ? AND instance_id = ?", agentID, instanceID).
+		Delete(&AgentOwnership{}).Error
+}
+
+// Owner returns the replica holding the Agent if its heartbeat is newer than since.
+// Owner 返回持有该 Agent 的副本（要求其心跳晚于 since）。
+func (s *Store) Owner(ctx context.Context, agentID string, since time.Time) (*Instance, error) {
+	var instance Instance
+	err := s.db.WithContext(ctx).
+		Joins("JOIN ha_agent_ownerships ON ha_agent_ownerships.instance_id = ha_instances.id").
+		Where("ha_agent_ownerships.agent_id = ? AND ha_instances.heartbeat_at >= ?", agentID, since).
+		First(&instance).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrNoOwner
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &instance, nil
+}
+
+// ReapOrphans removes the ownership of Agents whose replica stopped heartbeating before since,
+// drops those replicas, and returns the orphaned Agent IDs.
+// ReapOrphans 移除所属副本心跳早于 since 的 Agent 归属，删除这些副本，并返回成为孤儿的 Agent ID。
+func (s *Store) ReapOrphans(ctx context.Context, since time.Time) ([]string, error) {
+	var agentIDs []string
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		live := tx.Model(&Instance{}).Select("id").Where("heartbeat_at >= ?", since)
+		if err := tx.Model(&AgentOwnership{}).
+			Where("instance_id NOT IN (?)", live).
+			Order("agent_id").
+			Pluck("agent_id", &agentIDs).Error; err != nil {
+			return err
+		}
+		if len(agentIDs) > 0 {
+			// Keep claims a live replica made since the scan.
+			// 保留扫描之后由存活副本重新声明的归属。
+			if err := tx.Where("agent_id IN ? AND instance_id NOT IN (?)", agentIDs, live).
+				Delete(&AgentOwnership{}).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Where("heartbeat_at < ?", since).Delete(&Instance{}).Error
+	})
+	return agentIDs, err
+}
+
+// TryAcquireLease takes or renews the lease for holder. It succeeds when the lease is free,
+// expired, or already held by holder.
+// TryAcquireLease 为 holder 获取或续约租约。租约空闲、已过期或已由 holder 持有时成功。
+func (s *Store) TryAcquireLease(ctx context.Context, name, holder string, ttl time.Duration, now time.Time) (bool, error) {
+	db := s.db.WithContext(ctx)
+	result := db.Model(&Lease{}).
+		Where("name = ? AND (holder = ? OR expires_at < ?)", name, holder, now).
+		Updates(map[string]interface{}{"holder": holder, "expires_at": now.Add(ttl)})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	if result.RowsAffected > 0 {
+		return true, nil
+	}
+	result = db.Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&Lease{Name: name, Holder: holder, ExpiresAt: now.Add(ttl)})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// ReleaseLease gives up the lease when holder still holds it.
+// ReleaseLease 在 holder 仍持有租约时将其释放。
+func (s *Store) ReleaseLease(ctx context.Context, name, holder string) error {
+	return s.db.WithContext(ctx).Where("name = ? AND holder = ?", name, holder).Delete(&Lease{}).Error
+}
+
+// LeaseHolder returns the current holder of an unexpired lease, or "" when it is free.
+// LeaseHolder 返回未过期租约的当前持有者，租约空闲时返回 ""。
+func (s *Store) LeaseHolder(ctx context.Context, name string, now time.Time) (string, error) {
+	var lease Lease
+	err := s.db.WithContext(ctx).Where("name = ? AND expires_at >= ?", name, now).First(&lease).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", nil
+	}
+	return lease.Holder, err
+}
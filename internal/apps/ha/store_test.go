@@ -0,0 +1,143 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ha
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func setupHATestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	database, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "ha.db")), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	if err := database.AutoMigrate(&Instance{}, &AgentOwnership{}, &Lease{}); err != nil {
+		t.Fatalf("failed to migrate: %v", err)
+	}
+	return database
+}
+
+func TestStoreOwnerFollowsClaimsAndLiveness(t *testing.T) {
+	ctx := context.Background()
+	store := NewStore(setupHATestDB(t))
+	now := time.Now()
+
+	for _, id := range []string{"cp-a", "cp-b"} {
+		if err := store.Heartbeat(ctx, &Instance{ID: id, AdvertiseAddr: "http://" + id, StartedAt: now, HeartbeatAt: now}); err != nil {
+			t.Fatalf("Heartbeat(%s) error = %v", id, err)
+		}
+	}
+	if _, err := store.Owner(ctx, "agent-1", now.Add(-time.Minute)); err != ErrNoOwner {
+		t.Fatalf("Owner() before claim error = %v, want ErrNoOwner", err)
+	}
+
+	if err := store.Claim(ctx, "agent-1", "cp-a", now); err != nil {
+		t.Fatalf("Claim() error = %v", err)
+	}
+	if err := store.Claim(ctx, "agent-1", "cp-b", now); err != nil {
+		t.Fatalf("Claim() takeover error = %v", err)
+	}
+	owner, err := store.Owner(ctx, "agent-1", now.Add(-time.Minute))
+	if err != nil || owner.ID != "cp-b" || owner.AdvertiseAddr != "http://cp-b" {
+		t.Fatalf("Owner() = %+v, %v, want cp-b", owner, err)
+	}
+
+	// A stale release from the previous owner must not drop the new claim.
+	if err := store.Release(ctx, "agent-1", "cp-a"); err != nil {
+		t.Fatalf("Release() error = %v", err)
+	}
+	if _, err := store.Owner(ctx, "agent-1", now.Add(-time.Minute)); err != nil {
+		t.Fatalf("Owner() after stale release error = %v", err)
+	}
+
+	// An owner that stopped heartbeating is not returned.
+	if _, err := store.Owner(ctx, "agent-1", now.Add(time.Second)); err != ErrNoOwner {
+		t.Fatalf("Owner() of dead instance error = %v, want ErrNoOwner", err)
+	}
+}
+
+func TestStoreReapOrphansDropsAgentsOfDeadInstances(t *testing.T) {
+	ctx := context.Background()
+	store := NewStore(setupHATestDB(t))
+	now := time.Now()
+
+	_ = store.Heartbeat(ctx, &Instance{ID: "cp-live", HeartbeatAt: now})
+	_ = store.Heartbeat(ctx, &Instance{ID: "cp-dead", HeartbeatAt: now.Add(-time.Minute)})
+	_ = store.Claim(ctx, "agent-live", "cp-live", now)
+	_ = store.Claim(ctx, "agent-dead-1", "cp-dead", now)
+	_ = store.Claim(ctx, "agent-dead-2", "cp-dead", now)
+
+	orphaned, err := store.ReapOrphans(ctx, now.Add(-15*time.Second))
+	if err != nil {
+		t.Fatalf("ReapOrphans() error = %v", err)
+	}
+	if len(orphaned) != 2 || orphaned[0] != "agent-dead-1" || orphaned[1] != "agent-dead-2" {
+		t.Fatalf("ReapOrphans() = %v, want agent-dead-1 and agent-dead-2", orphaned)
+	}
+
+	instances, err := store.ListInstances(ctx, time.Time{})
+	if err != nil || len(instances) != 1 || instances[0].ID != "cp-live" {
+		t.Fatalf("ListInstances() = %v, %v, want only cp-live", instances, err)
+	}
+	if _, err := store.Owner(ctx, "agent-live", now.Add(-time.Minute)); err != nil {
+		t.Fatalf("Owner(agent-live) error = %v", err)
+	}
+}
+
+func TestStoreLeaseIsExclusiveUntilExpiry(t *testing.T) {
+	ctx := context.Background()
+	store := NewStore(setupHATestDB(t))
+	now := time.Now()
+	ttl := 15 * time.Second
+
+	acquired, err := store.TryAcquireLease(ctx, LeaderLeaseName, "cp-a", ttl, now)
+	if err != nil || !acquired {
+		t.Fatalf("cp-a TryAcquireLease() = %t, %v, want acquired", acquired, err)
+	}
+	if acquired, _ := store.TryAcquireLease(ctx, LeaderLeaseName, "cp-b", ttl, now.Add(time.Second)); acquired {
+		t.Fatal("cp-b acquired a lease held by cp-a")
+	}
+	if acquired, _ := store.TryAcquireLease(ctx, LeaderLeaseName, "cp-a", ttl, now.Add(5*time.Second)); !acquired {
+		t.Fatal("cp-a failed to renew its own lease")
+	}
+	if holder, _ := store.LeaseHolder(ctx, LeaderLeaseName, now.Add(10*time.Second)); holder != "cp-a" {
+		t.Fatalf("LeaseHolder() = %q, want cp-a", holder)
+	}
+
+	// cp-a stops renewing; cp-b takes over once the renewed lease expires.
+	if acquired, _ := store.TryAcquireLease(ctx, LeaderLeaseName, "cp-b", ttl, now.Add(21*time.Second)); !acquired {
+		t.Fatal("cp-b failed to take over an expired lease")
+	}
+	if err := store.ReleaseLease(ctx, LeaderLeaseName, "cp-a"); err != nil {
+		t.Fatalf("ReleaseLease() error = %v", err)
+	}
+	if holder, _ := store.LeaseHolder(ctx, LeaderLeaseName, now.Add(22*time.Second)); holder != "cp-b" {
+		t.Fatalf("LeaseHolder() after stale release = %q, want cp-b", holder)
+	}
+}
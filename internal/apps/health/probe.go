@@ -0,0 +1,182 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package health
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/seatunnel/seatunnelX/internal/pkg/worker"
+)
+
+// readinessCheckTimeout bounds each dependency check of the readiness probe.
+// readinessCheckTimeout 限定就绪探针中每项依赖检查的时长。
+const readinessCheckTimeout = 2 * time.Second
+
+const (
+	StatusUp   = "up"
+	StatusDown = "down"
+)
+
+// CheckFunc reports whether one dependency of the Control Plane is usable.
+// CheckFunc 报告 Control Plane 的某项依赖是否可用。
+type CheckFunc func(ctx context.Context) error
+
+// DependencyStatus is the result of one readiness check.
+// DependencyStatus 是单项就绪检查的结果。
+type DependencyStatus struct {
+	Name      string `json:"name"`
+	Status    string `json:"status"`
+	LatencyMS int64  `json:"latency_ms"`
+	Error     string `json:"error,omitempty"`
+}
+
+// ReadinessReport is the body of the readiness probe.
+// ReadinessReport 是就绪探针的响应内容。
+type ReadinessReport struct {
+	Ready        bool               `json:"ready"`
+	ShuttingDown bool               `json:"shutting_down,omitempty"`
+	Dependencies []DependencyStatus `json:"dependencies"`
+	Workers      []worker.State     `json:"workers"`
+}
+
+type namedCheck struct {
+	name  string
+	check CheckFunc
+}
+
+var (
+	checksMu sync.RWMutex
+	checks   []namedCheck
+
+	shuttingDown atomic.Bool
+)
+
+// SetShuttingDown marks the replica as shutting down so the readiness probe fails and load
+// balancers stop routing new requests to it while in-flight ones drain.
+// SetShuttingDown 将副本标记为关闭中，使就绪探针失败，负载均衡器在在途请求排空期间不再转发新请求。
+func SetShuttingDown() {
+	shuttingDown.Store(true)
+}
+
+// RegisterCheck adds a dependency to the readiness probe; registering a name again replaces it.
+// RegisterCheck 向就绪探针添加一项依赖检查；重复注册同名检查会替换原检查。
+func RegisterCheck(name string, check CheckFunc) {
+	checksMu.Lock()
+	defer checksMu.Unlock()
+	for i := range checks {
+		if checks[i].name == name {
+			checks[i].check = check
+			return
+		}
+	}
+	checks = append(checks, namedCheck{name: name, check: check})
+}
+
+// Healthz godoc
+// Healthz is the liveness probe: it only reports that the process serves HTTP.
+// Healthz 是存活探针：仅表示进程能够处理 HTTP 请求。
+// @Tags health
+// @Produce json
+// @Success 200 {object} HealthResponse
+// @Router /healthz [get]
+func Healthz(c *gin.Context) {
+	c.JSON(http.StatusOK, HealthResponse{Data: gin.H{"status": StatusUp}})
+}
+
+// Readyz godoc
+// Readyz is the readiness probe: it checks every registered dependency and background worker
+// and answers 503 when any of them is down.
+// Readyz 是就绪探针：检查所有已注册依赖与后台任务，任一不可用时返回 503。
+// @Tags health
+// @Produce json
+// @Success 200 {object} HealthResponse
+// @Failure 503 {object} HealthResponse
+// @Router /readyz [get]
+func Readyz(c *gin.Context) {
+	report := CheckReadiness(c.Request.Context())
+	if !report.Ready {
+		c.JSON(http.StatusServiceUnavailable, HealthResponse{ErrorMsg: "not ready", Data: report})
+		return
+	}
+	c.JSON(http.StatusOK, HealthResponse{Data: report})
+}
+
+// CheckReadiness runs all dependency checks concurrently and collects the worker states.
+// CheckReadiness 并发执行所有依赖检查，并汇总后台任务状态。
+func CheckReadiness(ctx context.Context) *ReadinessReport {
+	checksMu.RLock()
+	registered := append([]namedCheck(nil), checks...)
+	checksMu.RUnlock()
+
+	report := &ReadinessReport{
+		Ready:        !shuttingDown.Load(),
+		ShuttingDown: shuttingDown.Load(),
+		Dependencies: make([]DependencyStatus, len(registered)),
+		Workers:      worker.Snapshot(),
+	}
+
+	var wg sync.WaitGroup
+	for i, item := range registered {
+		wg.Add(1)
+		go func(i int, item namedCheck) {
+			defer wg.Done()
+			checkCtx, cancel := context.WithTimeout(ctx, readinessCheckTimeout)
+			defer cancel()
+
+			started := time.Now()
+			err := runCheck(checkCtx, item.check)
+			status := DependencyStatus{Name: item.name, Status: StatusUp, LatencyMS: time.Since(started).Milliseconds()}
+			if err != nil {
+				status.Status = StatusDown
+				status.Error = err.Error()
+			}
+			report.Dependencies[i] = status
+		}(i, item)
+	}
+	wg.Wait()
+
+	for _, dependency := range report.Dependencies {
+		if dependency.Status != StatusUp {
+			report.Ready = false
+		}
+	}
+	for _, state := range report.Workers {
+		if !state.Running {
+			report.Ready = false
+		}
+	}
+	return report
+}
+
+// runCheck stops waiting for a check once its context is done, even if the check ignores the context.
+// runCheck 在上下文结束后停止等待检查结果，即使检查本身忽略了上下文。
+func runCheck(ctx context.Context, check CheckFunc) error {
+	done := make(chan error, 1)
+	go func() { done <- check(ctx) }()
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,98 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/seatunnel/seatunnelX/internal/pkg/worker"
+)
+
+func serveProbe(t *testing.T, handler gin.HandlerFunc) (int, *ReadinessReport) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.GET("/probe", handler)
+	recorder := httptest.NewRecorder()
+	engine.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/probe", nil))
+
+	var body struct {
+		Data ReadinessReport `json:"data"`
+	}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &body); err != nil {
+		t.Fatalf("failed to decode probe response: %v", err)
+	}
+	return recorder.Code, &body.Data
+}
+
+func TestReadyzReportsEachDependencyAndWorker(t *testing.T) {
+	t.Cleanup(func() {
+		checksMu.Lock()
+		checks = nil
+		checksMu.Unlock()
+	})
+
+	dbHealthy := true
+	RegisterCheck("database", func(context.Context) error {
+		if !dbHealthy {
+			return errors.New("connection refused")
+		}
+		return nil
+	})
+	RegisterCheck("session_store", func(context.Context) error { return nil })
+	stop := worker.Start("probe-test-worker")
+	defer stop()
+
+	code, report := serveProbe(t, Readyz)
+	if code != http.StatusOK || !report.Ready || len(report.Dependencies) != 2 {
+		t.Fatalf("expected ready with 2 dependencies, got %d %+v", code, report)
+	}
+
+	dbHealthy = false
+	code, report = serveProbe(t, Readyz)
+	if code != http.StatusServiceUnavailable || report.Ready {
+		t.Fatalf("expected 503 while the database is down, got %d %+v", code, report)
+	}
+	if dependency := report.Dependencies[0]; dependency.Name != "database" || dependency.Status != StatusDown || dependency.Error != "connection refused" {
+		t.Fatalf("unexpected database status: %+v", dependency)
+	}
+	if dependency := report.Dependencies[1]; dependency.Status != StatusUp {
+		t.Fatalf("unexpected session store status: %+v", dependency)
+	}
+
+	// A background worker that exited makes the replica unready.
+	// 已退出的后台任务会使副本变为未就绪。
+	dbHealthy = true
+	stop()
+	code, report = serveProbe(t, Readyz)
+	if code != http.StatusServiceUnavailable || report.Ready {
+		t.Fatalf("expected 503 after the worker stopped, got %d %+v", code, report)
+	}
+
+	// Liveness does not depend on dependencies.
+	// 存活探针不依赖外部依赖的状态。
+	if code, _ := serveProbe(t, Healthz); code != http.StatusOK {
+		t.Fatalf("expected healthz 200, got %d", code)
+	}
+}
@@ -0,0 +1,298 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package host
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// DefaultEnrollmentTokenTTL is how long an install command stays usable after it was generated.
+// DefaultEnrollmentTokenTTL 是安装命令生成后可使用的默认时长。
+const DefaultEnrollmentTokenTTL = time.Hour
+
+// EnrollmentToken is a single-use, time-limited token embedded in an install command.
+// Only the SHA-256 hash of the token is stored.
+// EnrollmentToken 是嵌入安装命令中的一次性、限时注册令牌；仅保存令牌的 SHA-256 哈希。
+type EnrollmentToken struct {
+	ID            uint       `json:"id" gorm:"primaryKey;autoIncrement"`
+	HostID        uint       `json:"host_id" gorm:"not null;index"`
+	TokenHash     string     `json:"-" gorm:"size:64;uniqueIndex;not null"`
+	ExpiresAt     time.Time  `json:"expires_at" gorm:"index"`
+	UsedAt        *time.Time `json:"used_at"`
+	UsedByAgentID string     `json:"used_by_agent_id" gorm:"size:100"`
+	CreatedAt     time.Time  `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// TableName specifies the table name for the EnrollmentToken model.
+func (EnrollmentToken) TableName() string {
+	return "host_enrollment_tokens"
+}
+
+// InstallCommand is an Agent install command bound to a host.
+// InstallCommand 是绑定到主机的 Agent 安装命令。
+type InstallCommand struct {
+	Command   string    `json:"command"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// ==================== Repository 仓库 ====================
+
+// CreateEnrollmentToken stores a new enrollment token.
+// CreateEnrollmentToken 保存新的注册令牌。
+func (r *Repository) CreateEnrollmentToken(ctx context.Context, token *EnrollmentToken) error {
+	return r.db.WithContext(ctx).Create(token).Error
+}
+
+// GetEnrollmentToken retrieves an enrollment token by its hash.
+// Returns ErrEnrollmentTokenInvalid if no token matches.
+// GetEnrollmentToken 根据哈希获取注册令牌。
+func (r *Repository) GetEnrollmentToken(ctx context.Context, tokenHash string) (*EnrollmentToken, error) {
+	var token EnrollmentToken
+	if err := r.db.WithContext(ctx).Where("token_hash = ?", tokenHash).First(&token).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrEnrollmentTokenInvalid
+		}
+		return nil, err
+	}
+	return &token, nil
+}
+
+// ConsumeEnrollmentToken marks an unused token as used by agentID and binds the Agent, with the hash
+// of its issued secret, to the token's host in one transaction. Returns ErrEnrollmentTokenUsed if
+// another registration won the race.
+// ConsumeEnrollmentToken 在一个事务中将未使用的令牌标记为被 agentID 使用，并将 Agent 及其签发密钥的哈希
+// 绑定到令牌所属主机。若被其他注册抢先使用则返回 ErrEnrollmentTokenUsed。
+func (r *Repository) ConsumeEnrollmentToken(ctx context.Context, token *EnrollmentToken, agentID, secretHash string, usedAt time.Time) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&EnrollmentToken{}).
+			Where("id = ? AND used_at IS NULL", token.ID).
+			Updates(map[string]interface{}{"used_at": usedAt, "used_by_agent_id": agentID})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrEnrollmentTokenUsed
+		}
+		result = tx.Model(&Host{}).Where("id = ?", token.HostID).
+			Updates(map[string]interface{}{"agent_id": agentID, "agent_secret_hash": secretHash})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrEnrollmentTokenInvalid
+		}
+		return nil
+	})
+}
+
+// SetAgentSecretHash stores the secret hash of the Agent bound to hostID, unless one was already issued.
+// Returns ErrEnrollmentTokenUsed if the host is bound to another Agent or already has a secret.
+// SetAgentSecretHash 为 hostID 上绑定的 Agent 保存密钥哈希（已签发过时不覆盖）。
+// 主机绑定了其他 Agent 或已有密钥时返回 ErrEnrollmentTokenUsed。
+func (r *Repository) SetAgentSecretHash(ctx context.Context, hostID uint, agentID, secretHash string) error {
+	result := r.db.WithContext(ctx).Model(&Host{}).
+		Where("id = ? AND agent_id = ? AND COALESCE(agent_secret_hash, '') = ''", hostID, agentID).
+		Update("agent_secret_hash", secretHash)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrEnrollmentTokenUsed
+	}
+	return nil
+}
+
+// ==================== Service 服务 ====================
+
+// GetInstallCommand generates the Agent install command for a host, carrying a new enrollment token.
+// GetInstallCommand 为主机生成携带新注册令牌的 Agent 安装命令。
+func (s *Service) GetInstallCommand(ctx context.Context, hostID uint) (*InstallCommand, error) {
+	if _, err := s.repo.GetByID(ctx, hostID); err != nil {
+		return nil, err
+	}
+	return s.issueInstallCommand(ctx, hostID)
+}
+
+// issueInstallCommand creates an enrollment token for hostID and builds the install command around it.
+// issueInstallCommand 为 hostID 创建注册令牌并构建包含该令牌的安装命令。
+func (s *Service) issueInstallCommand(ctx context.Context, hostID uint) (*InstallCommand, error) {
+	token, expiresAt, err := s.IssueEnrollmentToken(ctx, hostID)
+	if err != nil {
+		return nil, err
+	}
+	return &InstallCommand{Command: s.installCommand(token), ExpiresAt: expiresAt}, nil
+}
+
+// IssueEnrollmentToken creates a single-use enrollment token bound to hostID.
+// The plain token is only returned here; the database keeps its hash.
+// IssueEnrollmentToken 创建绑定到 hostID 的一次性注册令牌；明文令牌仅在此返回，数据库只保存其哈希。
+func (s *Service) IssueEnrollmentToken(ctx context.Context, hostID uint) (string, time.Time, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", time.Time{}, fmt.Errorf("generate enrollment token: %w", err)
+	}
+	token := base64.RawURLEncoding.EncodeToString(raw)
+	record := &EnrollmentToken{
+		HostID:    hostID,
+		TokenHash: hashSecret(token),
+		ExpiresAt: time.Now().Add(s.enrollmentTokenTTL),
+	}
+	if err := s.repo.CreateEnrollmentToken(ctx, record); err != nil {
+		return "", time.Time{}, err
+	}
+	return token, record.ExpiresAt, nil
+}
+
+// VerifyEnrollment checks the credentials presented by a registering Agent. While enrollment tokens
+// are required, an enrolled Agent must present the secret it was issued; otherwise the enrollment
+// token is checked and, unless dryRun is set, consumed, binding the Agent to the token's host.
+// The returned secret is non-empty only when a new one was issued, and must be handed to the
+// Agent. An Agent enrolled before secrets existed may trade its used token for a secret once.
+// VerifyEnrollment 校验注册 Agent 出示的凭据。要求注册令牌时，已注册的 Agent 必须出示签发给它的密钥；否则校验注册令牌，
+// 非试运行时消费该令牌并将 Agent 绑定到令牌所属主机。仅在签发了新密钥时返回非空密钥，调用方须将其交给 Agent。
+// 在引入密钥之前注册的 Agent 可凭已使用的令牌换取一次密钥。
+func (s *Service) VerifyEnrollment(ctx context.Context, token, secret, agentID string, dryRun bool) (string, error) {
+	token = strings.TrimSpace(token)
+	secret = strings.TrimSpace(secret)
+	if secret != "" {
+		// A reinstalled Agent may carry a stale secret alongside a fresh token
+		// 重新安装的 Agent 可能同时携带过期密钥和新令牌
+		err := s.verifyAgentSecret(ctx, agentID, secret)
+		if err == nil || !errors.Is(err, ErrAgentSecretInvalid) {
+			return "", err
+		}
+		if token == "" && s.requireEnrollmentToken {
+			return "", err
+		}
+	}
+	if token == "" {
+		if !s.requireEnrollmentToken {
+			return "", nil
+		}
+		return "", ErrEnrollmentTokenRequired
+	}
+
+	record, err := s.repo.GetEnrollmentToken(ctx, hashSecret(token))
+	if err != nil {
+		return "", err
+	}
+	if record.UsedAt != nil {
+		if agentID == "" || record.UsedByAgentID != agentID {
+			return "", ErrEnrollmentTokenUsed
+		}
+		return s.upgradeEnrolledAgent(ctx, record, agentID, dryRun)
+	}
+	now := time.Now()
+	if now.After(record.ExpiresAt) {
+		return "", ErrEnrollmentTokenExpired
+	}
+	if _, err := s.repo.GetByID(ctx, record.HostID); err != nil {
+		if errors.Is(err, ErrHostNotFound) {
+			return "", ErrEnrollmentTokenInvalid
+		}
+		return "", err
+	}
+	if dryRun {
+		return "", nil
+	}
+	issued, err := newAgentSecret()
+	if err != nil {
+		return "", err
+	}
+	if err := s.repo.ConsumeEnrollmentToken(ctx, record, agentID, hashSecret(issued), now); err != nil {
+		return "", err
+	}
+	return issued, nil
+}
+
+// verifyAgentSecret checks secret against the hash stored on the host bound to agentID.
+// verifyAgentSecret 将 secret 与 agentID 所绑定主机上保存的哈希进行比对。
+func (s *Service) verifyAgentSecret(ctx context.Context, agentID, secret string) error {
+	if agentID == "" {
+		return ErrAgentSecretInvalid
+	}
+	h, err := s.repo.GetByAgentID(ctx, agentID)
+	if err != nil {
+		if errors.Is(err, ErrHostNotFound) {
+			return ErrAgentSecretInvalid
+		}
+		return err
+	}
+	if h.AgentSecretHash == "" || subtle.ConstantTimeCompare([]byte(h.AgentSecretHash), []byte(hashSecret(secret))) != 1 {
+		return ErrAgentSecretInvalid
+	}
+	return nil
+}
+
+// upgradeEnrolledAgent issues a secret to the Agent that used record before secrets existed.
+// Once a secret was issued the used token is no longer accepted.
+// upgradeEnrolledAgent 为引入密钥之前使用 record 注册的 Agent 签发密钥；签发后已使用的令牌不再被接受。
+func (s *Service) upgradeEnrolledAgent(ctx context.Context, record *EnrollmentToken, agentID string, dryRun bool) (string, error) {
+	h, err := s.repo.GetByID(ctx, record.HostID)
+	if err != nil {
+		if errors.Is(err, ErrHostNotFound) {
+			return "", ErrEnrollmentTokenInvalid
+		}
+		return "", err
+	}
+	if h.AgentID != agentID {
+		return "", ErrEnrollmentTokenUsed
+	}
+	if h.AgentSecretHash != "" {
+		return "", ErrAgentSecretInvalid
+	}
+	if dryRun {
+		return "", nil
+	}
+	issued, err := newAgentSecret()
+	if err != nil {
+		return "", err
+	}
+	if err := s.repo.SetAgentSecretHash(ctx, h.ID, agentID, hashSecret(issued)); err != nil {
+		return "", err
+	}
+	return issued, nil
+}
+
+// newAgentSecret generates a random secret for an enrolled Agent.
+// newAgentSecret 为已注册的 Agent 生成随机密钥。
+func newAgentSecret() (string, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", fmt.Errorf("generate agent secret: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+// hashSecret returns the hex SHA-256 of an enrollment token or Agent secret.
+// hashSecret 返回注册令牌或 Agent 密钥的十六进制 SHA-256。
+func hashSecret(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:])
+}
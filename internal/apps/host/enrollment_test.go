@@ -0,0 +1,212 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package host
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// enrollmentToken extracts the token from an install command.
+// enrollmentToken 从安装命令中提取令牌。
+func enrollmentToken(t *testing.T, cmd *InstallCommand) string {
+	t.Helper()
+	_, rest, ok := strings.Cut(cmd.Command, "ENROLLMENT_TOKEN=")
+	if !ok {
+		t.Fatalf("install command carries no enrollment token: %s", cmd.Command)
+	}
+	return strings.Fields(rest)[0]
+}
+
+func newEnrollmentTestService(t *testing.T) (*Service, *Host) {
+	t.Helper()
+	db, cleanup := setupServiceTestDB(t)
+	t.Cleanup(cleanup)
+	svc := NewService(NewRepository(db), nil, &ServiceConfig{
+		ControlPlaneAddr:       "http://10.0.0.1:8000",
+		RequireEnrollmentToken: true,
+	})
+	host, err := svc.Create(context.Background(), &CreateHostRequest{Name: "node-1", IPAddress: "10.0.0.11"})
+	if err != nil {
+		t.Fatalf("Create returned error: %v", err)
+	}
+	return svc, host
+}
+
+// TestVerifyEnrollmentSingleUse tests that a token binds the first Agent and rejects any other.
+// TestVerifyEnrollmentSingleUse 测试令牌绑定首个 Agent 并拒绝其他 Agent。
+func TestVerifyEnrollmentSingleUse(t *testing.T) {
+	svc, host := newEnrollmentTestService(t)
+	ctx := context.Background()
+
+	cmd, err := svc.GetInstallCommand(ctx, host.ID)
+	if err != nil {
+		t.Fatalf("GetInstallCommand returned error: %v", err)
+	}
+	token := enrollmentToken(t, cmd)
+
+	// A dry run must not consume the token / 试运行不能消费令牌
+	if secret, err := svc.VerifyEnrollment(ctx, token, "", "agent-probe", true); err != nil || secret != "" {
+		t.Fatalf("dry run returned secret %q, error %v", secret, err)
+	}
+	secret, err := svc.VerifyEnrollment(ctx, token, "", "agent-a", false)
+	if err != nil {
+		t.Fatalf("first registration returned error: %v", err)
+	}
+	if secret == "" {
+		t.Fatal("expected a secret to be issued on enrollment")
+	}
+	bound, err := svc.repo.GetByID(ctx, host.ID)
+	if err != nil || bound.AgentID != "agent-a" {
+		t.Fatalf("expected host bound to agent-a, got %+v (%v)", bound, err)
+	}
+	if bound.AgentSecretHash == "" || bound.AgentSecretHash == secret {
+		t.Fatalf("expected only the secret hash to be stored, got %q", bound.AgentSecretHash)
+	}
+
+	// The same Agent re-registers with its secret / 同一 Agent 使用密钥重新注册
+	if issued, err := svc.VerifyEnrollment(ctx, token, secret, "agent-a", false); err != nil || issued != "" {
+		t.Fatalf("re-registration with the secret returned secret %q, error %v", issued, err)
+	}
+	if _, err := svc.VerifyEnrollment(ctx, "", secret, "agent-a", false); err != nil {
+		t.Fatalf("re-registration with the secret only returned error: %v", err)
+	}
+	if _, err := svc.VerifyEnrollment(ctx, token, "", "agent-a", false); !errors.Is(err, ErrAgentSecretInvalid) {
+		t.Fatalf("expected the used token alone to be rejected with ErrAgentSecretInvalid, got %v", err)
+	}
+	if _, err := svc.VerifyEnrollment(ctx, token, "", "agent-b", false); !errors.Is(err, ErrEnrollmentTokenUsed) {
+		t.Fatalf("expected ErrEnrollmentTokenUsed, got %v", err)
+	}
+	if _, err := svc.VerifyEnrollment(ctx, "not-a-token", "", "agent-b", false); !errors.Is(err, ErrEnrollmentTokenInvalid) {
+		t.Fatalf("expected ErrEnrollmentTokenInvalid, got %v", err)
+	}
+}
+
+// TestVerifyEnrollmentAgentSecret tests that a known agent_id alone does not admit a registration.
+// TestVerifyEnrollmentAgentSecret 测试仅凭已知的 agent_id 无法通过注册。
+func TestVerifyEnrollmentAgentSecret(t *testing.T) {
+	svc, host := newEnrollmentTestService(t)
+	ctx := context.Background()
+
+	cmd, err := svc.GetInstallCommand(ctx, host.ID)
+	if err != nil {
+		t.Fatalf("GetInstallCommand returned error: %v", err)
+	}
+	if _, err := svc.VerifyEnrollment(ctx, enrollmentToken(t, cmd), "", "agent-a", false); err != nil {
+		t.Fatalf("enrollment returned error: %v", err)
+	}
+
+	if _, err := svc.VerifyEnrollment(ctx, "", "", "agent-a", false); !errors.Is(err, ErrEnrollmentTokenRequired) {
+		t.Fatalf("expected ErrEnrollmentTokenRequired, got %v", err)
+	}
+	if _, err := svc.VerifyEnrollment(ctx, "", "guessed", "agent-a", false); !errors.Is(err, ErrAgentSecretInvalid) {
+		t.Fatalf("expected ErrAgentSecretInvalid for a wrong secret, got %v", err)
+	}
+	if _, err := svc.VerifyEnrollment(ctx, "", "guessed", "agent-unknown", false); !errors.Is(err, ErrAgentSecretInvalid) {
+		t.Fatalf("expected ErrAgentSecretInvalid for an unknown agent, got %v", err)
+	}
+
+	// A reinstall presents its stale secret with a fresh token and gets a new secret
+	// 重新安装时携带过期密钥和新令牌，将获得新密钥
+	cmd, err = svc.GetInstallCommand(ctx, host.ID)
+	if err != nil {
+		t.Fatalf("GetInstallCommand returned error: %v", err)
+	}
+	secret, err := svc.VerifyEnrollment(ctx, enrollmentToken(t, cmd), "stale", "agent-a", false)
+	if err != nil || secret == "" {
+		t.Fatalf("reinstall returned secret %q, error %v", secret, err)
+	}
+	if _, err := svc.VerifyEnrollment(ctx, "", secret, "agent-a", false); err != nil {
+		t.Fatalf("registration with the new secret returned error: %v", err)
+	}
+}
+
+// TestVerifyEnrollmentUpgradesEnrolledAgent tests that an Agent enrolled before secrets existed
+// trades its used token for a secret exactly once.
+// TestVerifyEnrollmentUpgradesEnrolledAgent 测试引入密钥前注册的 Agent 仅能凭已用令牌换取一次密钥。
+func TestVerifyEnrollmentUpgradesEnrolledAgent(t *testing.T) {
+	svc, host := newEnrollmentTestService(t)
+	ctx := context.Background()
+
+	cmd, err := svc.GetInstallCommand(ctx, host.ID)
+	if err != nil {
+		t.Fatalf("GetInstallCommand returned error: %v", err)
+	}
+	token := enrollmentToken(t, cmd)
+	if _, err := svc.VerifyEnrollment(ctx, token, "", "agent-a", false); err != nil {
+		t.Fatalf("enrollment returned error: %v", err)
+	}
+	if err := svc.repo.db.Model(&Host{}).Where("id = ?", host.ID).Update("agent_secret_hash", "").Error; err != nil {
+		t.Fatalf("clear secret hash: %v", err)
+	}
+
+	secret, err := svc.VerifyEnrollment(ctx, token, "", "agent-a", false)
+	if err != nil || secret == "" {
+		t.Fatalf("upgrade returned secret %q, error %v", secret, err)
+	}
+	if _, err := svc.VerifyEnrollment(ctx, token, "", "agent-a", false); !errors.Is(err, ErrAgentSecretInvalid) {
+		t.Fatalf("expected a second upgrade to be rejected with ErrAgentSecretInvalid, got %v", err)
+	}
+	if _, err := svc.VerifyEnrollment(ctx, token, secret, "agent-a", false); err != nil {
+		t.Fatalf("registration with the upgraded secret returned error: %v", err)
+	}
+}
+
+// TestVerifyEnrollmentExpired tests that an expired token is rejected.
+// TestVerifyEnrollmentExpired 测试过期令牌被拒绝。
+func TestVerifyEnrollmentExpired(t *testing.T) {
+	svc, host := newEnrollmentTestService(t)
+	ctx := context.Background()
+
+	svc.enrollmentTokenTTL = -time.Minute
+	cmd, err := svc.GetInstallCommand(ctx, host.ID)
+	if err != nil {
+		t.Fatalf("GetInstallCommand returned error: %v", err)
+	}
+	if _, err := svc.VerifyEnrollment(ctx, enrollmentToken(t, cmd), "", "agent-a", false); !errors.Is(err, ErrEnrollmentTokenExpired) {
+		t.Fatalf("expected ErrEnrollmentTokenExpired, got %v", err)
+	}
+}
+
+// TestVerifyEnrollmentWithoutToken tests that no Agent may register without a token or secret.
+// TestVerifyEnrollmentWithoutToken 测试未携带令牌或密钥的 Agent 均不能注册。
+func TestVerifyEnrollmentWithoutToken(t *testing.T) {
+	svc, host := newEnrollmentTestService(t)
+	ctx := context.Background()
+
+	if _, err := svc.VerifyEnrollment(ctx, "", "", "agent-new", false); !errors.Is(err, ErrEnrollmentTokenRequired) {
+		t.Fatalf("expected ErrEnrollmentTokenRequired, got %v", err)
+	}
+	if err := svc.repo.UpdateAgentStatus(ctx, host.ID, AgentStatusInstalled, "agent-known", "1.0.0"); err != nil {
+		t.Fatalf("UpdateAgentStatus returned error: %v", err)
+	}
+	if _, err := svc.VerifyEnrollment(ctx, "", "", "agent-known", false); !errors.Is(err, ErrEnrollmentTokenRequired) {
+		t.Fatalf("a bound agent without its secret should be rejected, got %v", err)
+	}
+
+	svc.requireEnrollmentToken = false
+	if _, err := svc.VerifyEnrollment(ctx, "", "", "agent-new", false); err != nil {
+		t.Fatalf("open registration should accept agents without a token, got %v", err)
+	}
+	if _, err := svc.VerifyEnrollment(ctx, "", "stale", "agent-new", false); err != nil {
+		t.Fatalf("open registration should ignore a stale secret, got %v", err)
+	}
+}
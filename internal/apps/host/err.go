@@ -37,6 +37,9 @@ var (
 	// ErrHostHasCluster indicates the host is associated with one or more clusters.
 	// ErrHostHasCluster 表示主机关联了一个或多个集群。
 	ErrHostHasCluster = errors.New("host: host is associated with clusters and cannot be deleted")
+	// ErrHostNotRecycled indicates the host is not in the recycle bin.
+	// ErrHostNotRecycled 表示主机不在回收站中。
+	ErrHostNotRecycled = errors.New("host: host is not in the recycle bin")
 	// ErrHostNameEmpty indicates the host name is empty.
 	// ErrHostNameEmpty 表示主机名为空。
 	ErrHostNameEmpty = errors.New("host: host name cannot be empty")
@@ -52,6 +55,36 @@ var (
 	// ErrK8sCredentialsRequired indicates K8s credentials are required.
 	// ErrK8sCredentialsRequired 表示需要 K8s 凭证。
 	ErrK8sCredentialsRequired = errors.New("host: kubernetes host requires kubeconfig or token")
+	// ErrHostImportEmpty indicates a host import contains no rows.
+	// ErrHostImportEmpty 表示主机导入内容为空。
+	ErrHostImportEmpty = errors.New("host: import contains no hosts")
+	// ErrHostImportTooLarge indicates a host import exceeds MaxHostImportSize rows.
+	// ErrHostImportTooLarge 表示主机导入超过 MaxHostImportSize 行。
+	ErrHostImportTooLarge = errors.New("host: import contains too many hosts")
+	// ErrHostImportInvalid indicates one or more rows of a host import failed validation.
+	// ErrHostImportInvalid 表示主机导入中有一行或多行校验失败。
+	ErrHostImportInvalid = errors.New("host: import contains invalid rows, no host was created")
+	// ErrHostLabelInvalid indicates a host label key or value is malformed.
+	// ErrHostLabelInvalid 表示主机标签的键或值格式错误。
+	ErrHostLabelInvalid = errors.New("host: invalid host label")
+	// ErrHostLabelSelectorInvalid indicates a label selector cannot be parsed.
+	// ErrHostLabelSelectorInvalid 表示标签选择器无法解析。
+	ErrHostLabelSelectorInvalid = errors.New("host: invalid label selector")
+	// ErrEnrollmentTokenRequired indicates an unknown Agent registered without an enrollment token.
+	// ErrEnrollmentTokenRequired 表示未知 Agent 未携带注册令牌进行注册。
+	ErrEnrollmentTokenRequired = errors.New("host: enrollment token is required to register a new agent")
+	// ErrEnrollmentTokenInvalid indicates the enrollment token does not exist or its host was removed.
+	// ErrEnrollmentTokenInvalid 表示注册令牌不存在或其所属主机已被删除。
+	ErrEnrollmentTokenInvalid = errors.New("host: invalid enrollment token")
+	// ErrEnrollmentTokenExpired indicates the enrollment token has expired.
+	// ErrEnrollmentTokenExpired 表示注册令牌已过期。
+	ErrEnrollmentTokenExpired = errors.New("host: enrollment token has expired")
+	// ErrEnrollmentTokenUsed indicates the enrollment token was already used by another Agent.
+	// ErrEnrollmentTokenUsed 表示注册令牌已被其他 Agent 使用。
+	ErrEnrollmentTokenUsed = errors.New("host: enrollment token has already been used")
+	// ErrAgentSecretInvalid indicates an enrolled Agent re-registered without its issued secret.
+	// ErrAgentSecretInvalid 表示已注册的 Agent 重新注册时未出示其签发的密钥。
+	ErrAgentSecretInvalid = errors.New("host: invalid agent secret")
 )
 
 // Error codes for host management operations.
@@ -66,4 +99,6 @@ const (
 	ErrCodeK8sAPIURLInvalid       = 2007
 	ErrCodeK8sCredentialsRequired = 2008
 	ErrCodeHostIPDuplicate        = 2009
+	ErrCodeHostImportInvalid      = 2010
+	ErrCodeHostLabelInvalid       = 2011
 )
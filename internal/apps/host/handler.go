@@ -20,14 +20,22 @@
 package host
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"path/filepath"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/seatunnel/seatunnelX/internal/apps/audit"
 	"github.com/seatunnel/seatunnelX/internal/apps/auth"
+	"github.com/seatunnel/seatunnelX/internal/apps/response"
 	"github.com/seatunnel/seatunnelX/internal/logger"
+	"github.com/seatunnel/seatunnelX/internal/pkg/errcode"
+	"github.com/seatunnel/seatunnelX/internal/pkg/pagex"
 )
 
 // Handler provides HTTP handlers for host management operations.
@@ -49,21 +57,42 @@ func NewHandler(service *Service, auditRepo *audit.Repository) *Handler {
 // ListHostsRequest represents the request for listing hosts.
 // ListHostsRequest 表示获取主机列表的请求。
 type ListHostsRequest struct {
-	Current     int         `json:"current" form:"current" binding:"min=1"`
-	Size        int         `json:"size" form:"size" binding:"min=1,max=100"`
+	pagex.Query
+	pagex.TimeQuery
 	Name        string      `json:"name" form:"name"`
 	HostType    HostType    `json:"host_type" form:"host_type"`
 	IPAddress   string      `json:"ip_address" form:"ip_address"`
 	Status      HostStatus  `json:"status" form:"status"`
 	AgentStatus AgentStatus `json:"agent_status" form:"agent_status"`
 	IsOnline    *bool       `json:"is_online" form:"is_online"`
+	// LabelSelector filters by labels, e.g. "zone=az1,disk in (ssd,nvme)"
+	// LabelSelector 按标签过滤，例如 "zone=az1,disk in (ssd,nvme)"
+	LabelSelector string `json:"label_selector" form:"label_selector"`
+	// ClusterID keeps only hosts that are nodes of the cluster
+	// ClusterID 仅保留属于该集群节点的主机
+	ClusterID uint `json:"cluster_id" form:"cluster_id"`
+}
+
+// hostListSpec defines paging and the sortable fields of the host list.
+// hostListSpec 定义主机列表的分页方式和可排序字段。
+var hostListSpec = pagex.Spec{
+	Fields: map[string]string{
+		"name":           "name",
+		"ip_address":     "ip_address",
+		"status":         "status",
+		"agent_status":   "agent_status",
+		"last_heartbeat": "last_heartbeat",
+		"created_at":     "created_at",
+		"updated_at":     "updated_at",
+	},
+	DefaultSort: "-created_at",
 }
 
 // ListHostsResponse represents the response for listing hosts.
 // ListHostsResponse 表示获取主机列表的响应。
 type ListHostsResponse struct {
-	ErrorMsg string `json:"error_msg"`
-	Data     *struct {
+	response.Meta
+	Data *struct {
 		Total int64       `json:"total"`
 		Hosts []*HostInfo `json:"hosts"`
 	} `json:"data"`
@@ -72,40 +101,77 @@ type ListHostsResponse struct {
 // CreateHostResponse represents the response for creating a host.
 // CreateHostResponse 表示创建主机的响应。
 type CreateHostResponse struct {
-	ErrorMsg string    `json:"error_msg"`
-	Data     *HostInfo `json:"data"`
+	response.Meta
+	Data *HostInfo `json:"data"`
 }
 
 // GetHostResponse represents the response for getting a host.
 // GetHostResponse 表示获取主机详情的响应。
 type GetHostResponse struct {
-	ErrorMsg string    `json:"error_msg"`
-	Data     *HostInfo `json:"data"`
+	response.Meta
+	Data *HostInfo `json:"data"`
 }
 
 // UpdateHostResponse represents the response for updating a host.
 // UpdateHostResponse 表示更新主机的响应。
 type UpdateHostResponse struct {
-	ErrorMsg string    `json:"error_msg"`
-	Data     *HostInfo `json:"data"`
+	response.Meta
+	Data *HostInfo `json:"data"`
 }
 
 // DeleteHostResponse represents the response for deleting a host.
 // DeleteHostResponse 表示删除主机的响应。
 type DeleteHostResponse struct {
-	ErrorMsg string `json:"error_msg"`
-	Data     any    `json:"data"`
+	response.Meta
+	Data any `json:"data"`
+}
+
+// ListRecycledHostsResponse represents the response for listing the host recycle bin.
+// ListRecycledHostsResponse 表示列出主机回收站的响应。
+type ListRecycledHostsResponse struct {
+	response.Meta
+	Data []*RecycledHost `json:"data"`
+}
+
+// RestoreHostResponse represents the response for restoring a host from the recycle bin.
+// RestoreHostResponse 表示从回收站恢复主机的响应。
+type RestoreHostResponse struct {
+	response.Meta
+	Data *HostInfo `json:"data"`
 }
 
 // GetInstallCommandResponse represents the response for getting install command.
 // GetInstallCommandResponse 表示获取安装命令的响应。
 type GetInstallCommandResponse struct {
-	ErrorMsg string `json:"error_msg"`
-	Data     *struct {
-		Command string `json:"command"`
-	} `json:"data"`
+	response.Meta
+	Data *InstallCommand `json:"data"`
+}
+
+// ImportHostsResponse represents the response for importing hosts.
+// ImportHostsResponse 表示批量导入主机的响应。
+type ImportHostsResponse struct {
+	response.Meta
+	Data *ImportHostsResult `json:"data"`
+}
+
+// HostLabelsResponse represents the response carrying the labels of a host.
+// HostLabelsResponse 表示返回主机标签的响应。
+type HostLabelsResponse struct {
+	response.Meta
+	Data HostLabels `json:"data"`
+}
+
+// ListLabelValuesResponse represents the response for listing label keys and values.
+// ListLabelValuesResponse 表示标签键与值列表的响应。
+type ListLabelValuesResponse struct {
+	response.Meta
+	Data []LabelValues `json:"data"`
 }
 
+// maxHostImportBytes limits the size of an uploaded host list.
+// maxHostImportBytes 限制上传主机列表的大小。
+const maxHostImportBytes = 5 << 20
+
 // ==================== Handlers 处理器 ====================
 
 // CreateHost handles POST /api/v1/hosts - creates a new host.
@@ -119,21 +185,24 @@ type GetInstallCommandResponse struct {
 func (h *Handler) CreateHost(c *gin.Context) {
 	var req CreateHostRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, CreateHostResponse{ErrorMsg: err.Error()})
+		response.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if !auth.AuthorizeProject(c, req.ProjectID) {
 		return
 	}
 
 	host, err := h.service.Create(c.Request.Context(), &req)
 	if err != nil {
-		statusCode := h.getStatusCodeForError(err)
-		c.JSON(statusCode, CreateHostResponse{ErrorMsg: err.Error()})
+		statusCode := h.errorStatus(c, err)
+		response.Error(c, statusCode, err.Error())
 		return
 	}
 
 	_ = audit.RecordFromGin(c, h.auditRepo, auth.GetUserIDFromContext(c), auth.GetUsernameFromContext(c),
 		"create", "host", audit.UintID(host.ID), host.Name, audit.AuditDetails{"trigger": "manual"})
 	logger.InfoF(c.Request.Context(), "[Host] 创建主机成功: %s (type: %s)", host.Name, host.HostType)
-	c.JSON(http.StatusOK, CreateHostResponse{Data: host.ToHostInfo(h.service.GetHeartbeatTimeout(), h.service.GetProcessStartedAt())})
+	response.OK(c, host.ToHostInfo(h.service.GetHeartbeatTimeout(), h.service.GetProcessStartedAt()))
 }
 
 // ListHosts handles GET /api/v1/hosts - lists hosts with filtering and pagination.
@@ -144,39 +213,60 @@ func (h *Handler) CreateHost(c *gin.Context) {
 // @Success 200 {object} ListHostsResponse
 // @Router /api/v1/hosts [get]
 func (h *Handler) ListHosts(c *gin.Context) {
-	req := &ListHostsRequest{Current: 1, Size: 20}
+	req := &ListHostsRequest{}
 	if err := c.ShouldBindQuery(req); err != nil {
-		c.JSON(http.StatusBadRequest, ListHostsResponse{ErrorMsg: err.Error()})
+		response.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	page, err := req.Resolve(hostListSpec)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	createdAt, err := req.Range()
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	selector, err := ParseLabelSelector(req.LabelSelector)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, err.Error())
 		return
 	}
 
 	// Build filter from request
 	// 从请求构建过滤条件
+	scope := auth.GetTenantScope(c)
 	filter := &HostFilter{
-		Name:        req.Name,
-		HostType:    req.HostType,
-		IPAddress:   req.IPAddress,
-		Status:      req.Status,
-		AgentStatus: req.AgentStatus,
-		IsOnline:    req.IsOnline,
-		Page:        req.Current,
-		PageSize:    req.Size,
+		Name:             req.Name,
+		HostType:         req.HostType,
+		IPAddress:        req.IPAddress,
+		Status:           req.Status,
+		AgentStatus:      req.AgentStatus,
+		IsOnline:         req.IsOnline,
+		LabelSelector:    selector,
+		ClusterID:        req.ClusterID,
+		CreatedAt:        createdAt,
+		ProjectIDs:       scope.ProjectIDs,
+		RestrictProjects: !scope.All,
+		Page:             page.Page,
+		PageSize:         page.PageSize,
+		Sort:             page.Sort,
 	}
 
 	hosts, total, err := h.service.ListWithInfo(c.Request.Context(), filter)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, ListHostsResponse{ErrorMsg: err.Error()})
+		response.Error(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
-	c.JSON(http.StatusOK, ListHostsResponse{
-		Data: &struct {
-			Total int64       `json:"total"`
-			Hosts []*HostInfo `json:"hosts"`
-		}{
-			Total: total,
-			Hosts: hosts,
-		},
+	response.OK(c, &struct {
+		Total int64       `json:"total"`
+		Hosts []*HostInfo `json:"hosts"`
+	}{
+		Total: total,
+		Hosts: hosts,
 	})
 }
 
@@ -190,18 +280,18 @@ func (h *Handler) ListHosts(c *gin.Context) {
 func (h *Handler) GetHost(c *gin.Context) {
 	hostID, err := strconv.ParseUint(c.Param("id"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, GetHostResponse{ErrorMsg: "无效的主机 ID / Invalid host ID"})
+		response.Error(c, http.StatusBadRequest, "无效的主机 ID / Invalid host ID")
 		return
 	}
 
 	host, err := h.service.Get(c.Request.Context(), uint(hostID))
 	if err != nil {
-		statusCode := h.getStatusCodeForError(err)
-		c.JSON(statusCode, GetHostResponse{ErrorMsg: err.Error()})
+		statusCode := h.errorStatus(c, err)
+		response.Error(c, statusCode, err.Error())
 		return
 	}
 
-	c.JSON(http.StatusOK, GetHostResponse{Data: host.ToHostInfo(h.service.GetHeartbeatTimeout(), h.service.GetProcessStartedAt())})
+	response.OK(c, host.ToHostInfo(h.service.GetHeartbeatTimeout(), h.service.GetProcessStartedAt()))
 }
 
 // UpdateHost handles PUT /api/v1/hosts/:id - updates an existing host.
@@ -216,31 +306,31 @@ func (h *Handler) GetHost(c *gin.Context) {
 func (h *Handler) UpdateHost(c *gin.Context) {
 	hostID, err := strconv.ParseUint(c.Param("id"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, UpdateHostResponse{ErrorMsg: "无效的主机 ID / Invalid host ID"})
+		response.Error(c, http.StatusBadRequest, "无效的主机 ID / Invalid host ID")
 		return
 	}
 
 	var req UpdateHostRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, UpdateHostResponse{ErrorMsg: err.Error()})
+		response.Error(c, http.StatusBadRequest, err.Error())
 		return
 	}
 
 	host, err := h.service.Update(c.Request.Context(), uint(hostID), &req)
 	if err != nil {
-		statusCode := h.getStatusCodeForError(err)
-		c.JSON(statusCode, UpdateHostResponse{ErrorMsg: err.Error()})
+		statusCode := h.errorStatus(c, err)
+		response.Error(c, statusCode, err.Error())
 		return
 	}
 
 	_ = audit.RecordFromGin(c, h.auditRepo, auth.GetUserIDFromContext(c), auth.GetUsernameFromContext(c),
 		"update", "host", audit.UintID(host.ID), host.Name, audit.AuditDetails{"trigger": "manual"})
 	logger.InfoF(c.Request.Context(), "[Host] 更新主机成功: %s", host.Name)
-	c.JSON(http.StatusOK, UpdateHostResponse{Data: host.ToHostInfo(h.service.GetHeartbeatTimeout(), h.service.GetProcessStartedAt())})
+	response.OK(c, host.ToHostInfo(h.service.GetHeartbeatTimeout(), h.service.GetProcessStartedAt()))
 }
 
-// DeleteHost handles DELETE /api/v1/hosts/:id - deletes a host.
-// DeleteHost 处理 DELETE /api/v1/hosts/:id - 删除主机。
+// DeleteHost handles DELETE /api/v1/hosts/:id - moves a host to the recycle bin.
+// DeleteHost 处理 DELETE /api/v1/hosts/:id - 将主机移入回收站。
 // @Tags hosts
 // @Produce json
 // @Param id path int true "主机ID"
@@ -249,43 +339,113 @@ func (h *Handler) UpdateHost(c *gin.Context) {
 func (h *Handler) DeleteHost(c *gin.Context) {
 	hostID, err := strconv.ParseUint(c.Param("id"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, DeleteHostResponse{ErrorMsg: "无效的主机 ID / Invalid host ID"})
+		response.Error(c, http.StatusBadRequest, "无效的主机 ID / Invalid host ID")
 		return
 	}
 
-	// Get host name for logging before deletion
-	// 在删除前获取主机名用于日志记录
-	host, err := h.service.Get(c.Request.Context(), uint(hostID))
+	recycled, err := h.service.Recycle(c.Request.Context(), uint(hostID))
 	if err != nil {
-		statusCode := h.getStatusCodeForError(err)
-		c.JSON(statusCode, DeleteHostResponse{ErrorMsg: err.Error()})
-		return
-	}
-
-	if err := h.service.Delete(c.Request.Context(), uint(hostID)); err != nil {
-		statusCode := h.getStatusCodeForError(err)
+		statusCode := h.errorStatus(c, err)
 		// If host has associated clusters, return the cluster info
 		// 如果主机关联了集群，返回集群信息
 		if errors.Is(err, ErrHostHasCluster) {
 			clusters, _ := h.service.GetAssociatedClusters(c.Request.Context(), uint(hostID))
-			c.JSON(statusCode, DeleteHostResponse{
-				ErrorMsg: err.Error(),
-				Data:     clusters,
-			})
+			response.ErrorWithData(c, statusCode, err.Error(), clusters)
 			return
 		}
-		c.JSON(statusCode, DeleteHostResponse{ErrorMsg: err.Error()})
+		response.Error(c, statusCode, err.Error())
 		return
 	}
 
 	_ = audit.RecordFromGin(c, h.auditRepo, auth.GetUserIDFromContext(c), auth.GetUsernameFromContext(c),
-		"delete", "host", audit.UintID(uint(hostID)), host.Name, audit.AuditDetails{"trigger": "manual"})
-	logger.InfoF(c.Request.Context(), "[Host] 删除主机成功: %s", host.Name)
-	c.JSON(http.StatusOK, DeleteHostResponse{})
+		"delete", "host", audit.UintID(uint(hostID)), recycled.Name, audit.AuditDetails{
+			"trigger":     "manual",
+			"recycle_bin": true,
+			"purge_at":    recycled.PurgeAt,
+		})
+	logger.InfoF(c.Request.Context(), "[Host] 主机已移入回收站: %s", recycled.Name)
+	response.OK(c, recycled)
+}
+
+// ListRecycledHosts handles GET /api/v1/hosts/recycle-bin - lists deleted hosts that can be restored.
+// ListRecycledHosts 处理 GET /api/v1/hosts/recycle-bin - 列出可恢复的已删除主机。
+// @Tags hosts
+// @Produce json
+// @Param name query string false "主机名称"
+// @Success 200 {object} ListRecycledHostsResponse
+// @Router /api/v1/hosts/recycle-bin [get]
+func (h *Handler) ListRecycledHosts(c *gin.Context) {
+	scope := auth.GetTenantScope(c)
+	hosts, err := h.service.ListRecycled(c.Request.Context(), &HostFilter{
+		Name:             c.Query("name"),
+		ProjectIDs:       scope.ProjectIDs,
+		RestrictProjects: !scope.All,
+	})
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	response.OK(c, hosts)
+}
+
+// RestoreHost handles POST /api/v1/hosts/:id/restore - restores a host from the recycle bin.
+// RestoreHost 处理 POST /api/v1/hosts/:id/restore - 从回收站恢复主机。
+// @Tags hosts
+// @Produce json
+// @Param id path int true "主机ID"
+// @Success 200 {object} RestoreHostResponse
+// @Router /api/v1/hosts/{id}/restore [post]
+func (h *Handler) RestoreHost(c *gin.Context) {
+	hostID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "无效的主机 ID / Invalid host ID")
+		return
+	}
+
+	host, err := h.service.Restore(c.Request.Context(), uint(hostID))
+	if err != nil {
+		statusCode := h.errorStatus(c, err)
+		response.Error(c, statusCode, err.Error())
+		return
+	}
+
+	_ = audit.RecordFromGin(c, h.auditRepo, auth.GetUserIDFromContext(c), auth.GetUsernameFromContext(c),
+		"restore", "host", audit.UintID(host.ID), host.Name, audit.AuditDetails{"trigger": "manual"})
+	logger.InfoF(c.Request.Context(), "[Host] 主机已从回收站恢复: %s", host.Name)
+	response.OK(c, host.ToHostInfo(h.service.GetHeartbeatTimeout(), h.service.GetProcessStartedAt()))
+}
+
+// PurgeHost handles DELETE /api/v1/hosts/recycle-bin/:id - removes a recycled host without waiting for the retention window.
+// PurgeHost 处理 DELETE /api/v1/hosts/recycle-bin/:id - 不等待保留期，立即删除回收站中的主机。
+// @Tags hosts
+// @Produce json
+// @Param id path int true "主机ID"
+// @Success 200 {object} DeleteHostResponse
+// @Router /api/v1/hosts/recycle-bin/{id} [delete]
+func (h *Handler) PurgeHost(c *gin.Context) {
+	hostID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "无效的主机 ID / Invalid host ID")
+		return
+	}
+
+	host, err := h.service.Purge(c.Request.Context(), uint(hostID))
+	if err != nil {
+		statusCode := h.errorStatus(c, err)
+		response.Error(c, statusCode, err.Error())
+		return
+	}
+
+	_ = audit.RecordFromGin(c, h.auditRepo, auth.GetUserIDFromContext(c), auth.GetUsernameFromContext(c),
+		"purge", "host", audit.UintID(host.ID), host.Name, audit.AuditDetails{"trigger": "manual"})
+	logger.InfoF(c.Request.Context(), "[Host] 回收站主机已清理: %s", host.Name)
+	response.OK(c, nil)
 }
 
 // GetInstallCommand handles GET /api/v1/hosts/:id/install-command - gets the Agent install command.
+// Every call issues a new single-use enrollment token bound to the host.
 // GetInstallCommand 处理 GET /api/v1/hosts/:id/install-command - 获取 Agent 安装命令。
+// 每次调用都会签发一个绑定到该主机的一次性注册令牌。
 // @Tags hosts
 // @Produce json
 // @Param id path int true "主机ID"
@@ -294,24 +454,255 @@ func (h *Handler) DeleteHost(c *gin.Context) {
 func (h *Handler) GetInstallCommand(c *gin.Context) {
 	hostID, err := strconv.ParseUint(c.Param("id"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, GetInstallCommandResponse{ErrorMsg: "无效的主机 ID / Invalid host ID"})
+		response.Error(c, http.StatusBadRequest, "无效的主机 ID / Invalid host ID")
 		return
 	}
 
 	command, err := h.service.GetInstallCommand(c.Request.Context(), uint(hostID))
 	if err != nil {
-		statusCode := h.getStatusCodeForError(err)
-		c.JSON(statusCode, GetInstallCommandResponse{ErrorMsg: err.Error()})
+		statusCode := h.errorStatus(c, err)
+		response.Error(c, statusCode, err.Error())
 		return
 	}
 
-	c.JSON(http.StatusOK, GetInstallCommandResponse{
-		Data: &struct {
-			Command string `json:"command"`
-		}{
-			Command: command,
-		},
-	})
+	response.OK(c, command)
+}
+
+// ImportHosts handles POST /api/v1/hosts/import - creates many hosts at once.
+// ImportHosts 处理 POST /api/v1/hosts/import - 批量创建主机。
+// Accepts a JSON body {"hosts": [...]}, a text/csv body, or a multipart "file" field (.csv or .json).
+// 支持 JSON 请求体 {"hosts": [...]}、text/csv 请求体或 multipart 的 "file" 字段（.csv 或 .json）。
+// @Tags hosts
+// @Accept json,text/csv,multipart/form-data
+// @Produce json
+// @Param request body ImportHostsRequest false "批量导入主机请求"
+// @Success 200 {object} ImportHostsResponse
+// @Router /api/v1/hosts/import [post]
+func (h *Handler) ImportHosts(c *gin.Context) {
+	reqs, rows, err := h.parseImportHosts(c)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	// Imported hosts are owned by ?project_id unless a row names its own project
+	// 导入的主机归属 ?project_id 指定的项目，除非某行自行指定了项目
+	projectID, _ := strconv.ParseUint(c.Query("project_id"), 10, 32)
+	for i := range reqs {
+		if reqs[i].ProjectID == 0 {
+			reqs[i].ProjectID = uint(projectID)
+		}
+		if !auth.AuthorizeProject(c, reqs[i].ProjectID) {
+			return
+		}
+	}
+
+	result, err := h.service.ImportHosts(c.Request.Context(), reqs, rows)
+	if err != nil {
+		statusCode := h.errorStatus(c, err)
+		response.ErrorWithData(c, statusCode, err.Error(), result)
+		return
+	}
+
+	_ = audit.RecordFromGin(c, h.auditRepo, auth.GetUserIDFromContext(c), auth.GetUsernameFromContext(c),
+		"import", "host", "", fmt.Sprintf("%d hosts", result.Created), audit.AuditDetails{"trigger": "manual", "count": result.Created})
+	logger.InfoF(c.Request.Context(), "[Host] 批量导入主机成功: %d 台", result.Created)
+	response.OK(c, result)
+}
+
+// parseImportHosts reads the host list from JSON, CSV or an uploaded file.
+// parseImportHosts 从 JSON、CSV 或上传文件中读取主机列表。
+func (h *Handler) parseImportHosts(c *gin.Context) ([]CreateHostRequest, []int, error) {
+	contentType := c.ContentType()
+	if contentType == "multipart/form-data" {
+		fileHeader, err := c.FormFile("file")
+		if err != nil {
+			return nil, nil, fmt.Errorf("file is required / 请上传文件: %w", err)
+		}
+		if fileHeader.Size > maxHostImportBytes {
+			return nil, nil, fmt.Errorf("file exceeds %d bytes / 文件过大", maxHostImportBytes)
+		}
+		file, err := fileHeader.Open()
+		if err != nil {
+			return nil, nil, err
+		}
+		defer file.Close()
+		if strings.EqualFold(filepath.Ext(fileHeader.Filename), ".json") {
+			return decodeImportHostsJSON(file)
+		}
+		return ParseHostImportCSV(file)
+	}
+
+	body := io.LimitReader(c.Request.Body, maxHostImportBytes)
+	if contentType == "text/csv" {
+		return ParseHostImportCSV(body)
+	}
+	return decodeImportHostsJSON(body)
+}
+
+func decodeImportHostsJSON(r io.Reader) ([]CreateHostRequest, []int, error) {
+	var req ImportHostsRequest
+	if err := json.NewDecoder(r).Decode(&req); err != nil {
+		return nil, nil, fmt.Errorf("invalid JSON / JSON 格式无效: %w", err)
+	}
+	return req.Hosts, nil, nil
+}
+
+// ListLabelValues handles GET /api/v1/hosts/labels - lists label keys in use and their values.
+// ListLabelValues 处理 GET /api/v1/hosts/labels - 获取在用的标签键及其值。
+// @Tags hosts
+// @Produce json
+// @Success 200 {object} ListLabelValuesResponse
+// @Router /api/v1/hosts/labels [get]
+func (h *Handler) ListLabelValues(c *gin.Context) {
+	values, err := h.service.ListLabelValues(c.Request.Context())
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	response.OK(c, values)
+}
+
+// GetLabels handles GET /api/v1/hosts/:id/labels - gets the labels of a host.
+// GetLabels 处理 GET /api/v1/hosts/:id/labels - 获取主机标签。
+// @Tags hosts
+// @Produce json
+// @Param id path int true "主机ID"
+// @Success 200 {object} HostLabelsResponse
+// @Router /api/v1/hosts/{id}/labels [get]
+func (h *Handler) GetLabels(c *gin.Context) {
+	hostID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "无效的主机 ID / Invalid host ID")
+		return
+	}
+
+	labels, err := h.service.GetLabels(c.Request.Context(), uint(hostID))
+	if err != nil {
+		response.Error(c, h.errorStatus(c, err), err.Error())
+		return
+	}
+	response.OK(c, labels)
+}
+
+// ReplaceLabels handles PUT /api/v1/hosts/:id/labels - replaces all labels of a host.
+// ReplaceLabels 处理 PUT /api/v1/hosts/:id/labels - 整体替换主机标签。
+// @Tags hosts
+// @Accept json
+// @Produce json
+// @Param id path int true "主机ID"
+// @Param request body ReplaceHostLabelsRequest true "标签"
+// @Success 200 {object} HostLabelsResponse
+// @Router /api/v1/hosts/{id}/labels [put]
+func (h *Handler) ReplaceLabels(c *gin.Context) {
+	hostID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "无效的主机 ID / Invalid host ID")
+		return
+	}
+
+	var req ReplaceHostLabelsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	labels, err := h.service.ReplaceLabels(c.Request.Context(), uint(hostID), req.Labels)
+	h.respondLabels(c, uint(hostID), labels, err)
+}
+
+// PatchLabels handles PATCH /api/v1/hosts/:id/labels - sets and removes some labels of a host.
+// PatchLabels 处理 PATCH /api/v1/hosts/:id/labels - 设置并删除主机的部分标签。
+// @Tags hosts
+// @Accept json
+// @Produce json
+// @Param id path int true "主机ID"
+// @Param request body PatchHostLabelsRequest true "标签变更"
+// @Success 200 {object} HostLabelsResponse
+// @Router /api/v1/hosts/{id}/labels [patch]
+func (h *Handler) PatchLabels(c *gin.Context) {
+	hostID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "无效的主机 ID / Invalid host ID")
+		return
+	}
+
+	var req PatchHostLabelsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	labels, err := h.service.PatchLabels(c.Request.Context(), uint(hostID), &req)
+	h.respondLabels(c, uint(hostID), labels, err)
+}
+
+// DeleteLabel handles DELETE /api/v1/hosts/:id/labels/:key - removes one label of a host.
+// DeleteLabel 处理 DELETE /api/v1/hosts/:id/labels/:key - 删除主机的一个标签。
+// @Tags hosts
+// @Produce json
+// @Param id path int true "主机ID"
+// @Param key path string true "标签键"
+// @Success 200 {object} HostLabelsResponse
+// @Router /api/v1/hosts/{id}/labels/{key} [delete]
+func (h *Handler) DeleteLabel(c *gin.Context) {
+	hostID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "无效的主机 ID / Invalid host ID")
+		return
+	}
+
+	labels, err := h.service.DeleteLabel(c.Request.Context(), uint(hostID), c.Param("key"))
+	h.respondLabels(c, uint(hostID), labels, err)
+}
+
+// SetMaintenance handles PUT /api/v1/hosts/:id/maintenance - turns maintenance mode of a host on or off.
+// SetMaintenance 处理 PUT /api/v1/hosts/:id/maintenance - 开启或关闭主机维护模式。
+// @Tags hosts
+// @Accept json
+// @Produce json
+// @Param id path int true "主机ID"
+// @Param request body SetMaintenanceRequest true "维护模式"
+// @Success 200 {object} GetHostResponse
+// @Router /api/v1/hosts/{id}/maintenance [put]
+func (h *Handler) SetMaintenance(c *gin.Context) {
+	hostID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "无效的主机 ID / Invalid host ID")
+		return
+	}
+
+	var req SetMaintenanceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	host, err := h.service.SetMaintenance(c.Request.Context(), uint(hostID), &req)
+	if err != nil {
+		response.Error(c, h.errorStatus(c, err), err.Error())
+		return
+	}
+
+	action := "disable_maintenance"
+	if req.Enabled {
+		action = "enable_maintenance"
+	}
+	_ = audit.RecordFromGin(c, h.auditRepo, auth.GetUserIDFromContext(c), auth.GetUsernameFromContext(c),
+		action, "host", audit.UintID(host.ID), host.Name, audit.AuditDetails{"reason": host.MaintenanceReason})
+	logger.InfoF(c.Request.Context(), "[Host] 主机维护模式已更新: %s, enabled=%v", host.Name, req.Enabled)
+	response.OK(c, host.ToHostInfo(h.service.GetHeartbeatTimeout(), h.service.GetProcessStartedAt()))
+}
+
+// respondLabels writes the result of a label change and records it in the audit log.
+// respondLabels 输出标签变更结果并记录审计日志。
+func (h *Handler) respondLabels(c *gin.Context, hostID uint, labels HostLabels, err error) {
+	if err != nil {
+		response.Error(c, h.errorStatus(c, err), err.Error())
+		return
+	}
+	_ = audit.RecordFromGin(c, h.auditRepo, auth.GetUserIDFromContext(c), auth.GetUsernameFromContext(c),
+		"update_labels", "host", audit.UintID(hostID), "", audit.AuditDetails{"labels": labels})
+	response.OK(c, labels)
 }
 
 // ==================== Helper Methods 辅助方法 ====================
@@ -320,7 +711,8 @@ func (h *Handler) GetInstallCommand(c *gin.Context) {
 // getStatusCodeForError 根据错误返回适当的 HTTP 状态码。
 func (h *Handler) getStatusCodeForError(err error) int {
 	switch {
-	case errors.Is(err, ErrHostNotFound):
+	case errors.Is(err, ErrHostNotFound),
+		errors.Is(err, ErrHostNotRecycled):
 		return http.StatusNotFound
 	case errors.Is(err, ErrHostNameDuplicate):
 		return http.StatusConflict
@@ -331,7 +723,12 @@ func (h *Handler) getStatusCodeForError(err error) int {
 		errors.Is(err, ErrHostTypeInvalid),
 		errors.Is(err, ErrDockerAPIURLInvalid),
 		errors.Is(err, ErrK8sAPIURLInvalid),
-		errors.Is(err, ErrK8sCredentialsRequired):
+		errors.Is(err, ErrK8sCredentialsRequired),
+		errors.Is(err, ErrHostImportEmpty),
+		errors.Is(err, ErrHostImportTooLarge),
+		errors.Is(err, ErrHostImportInvalid),
+		errors.Is(err, ErrHostLabelInvalid),
+		errors.Is(err, ErrHostLabelSelectorInvalid):
 		return http.StatusBadRequest
 	case errors.Is(err, ErrHostHasCluster):
 		return http.StatusConflict
@@ -339,3 +736,37 @@ func (h *Handler) getStatusCodeForError(err error) int {
 		return http.StatusInternalServerError
 	}
 }
+
+// errorStatus records the catalog code of err on the request and returns its HTTP status.
+// errorStatus 在请求上记录 err 的目录错误码并返回其 HTTP 状态码。
+func (h *Handler) errorStatus(c *gin.Context, err error) int {
+	status := h.getStatusCodeForError(err)
+	errcode.Attach(c, hostErrorCode(err, status))
+	return status
+}
+
+// hostErrorCode maps host errors to catalog codes, falling back to the general code for status.
+// hostErrorCode 将主机错误映射为目录错误码，无法识别时回退为状态码对应的通用错误码。
+func hostErrorCode(err error, status int) errcode.Code {
+	switch {
+	case errors.Is(err, ErrHostNotFound):
+		return errcode.HostNotFound
+	case errors.Is(err, ErrHostNameDuplicate):
+		return errcode.HostNameDuplicate
+	case errors.Is(err, ErrHostIPDuplicate):
+		return errcode.HostIPDuplicate
+	case errors.Is(err, ErrHostIPInvalid):
+		return errcode.HostIPInvalid
+	case errors.Is(err, ErrHostHasCluster):
+		return errcode.HostHasCluster
+	case errors.Is(err, ErrHostLabelInvalid),
+		errors.Is(err, ErrHostLabelSelectorInvalid):
+		return errcode.HostLabelInvalid
+	case errors.Is(err, ErrHostImportEmpty),
+		errors.Is(err, ErrHostImportTooLarge),
+		errors.Is(err, ErrHostImportInvalid):
+		return errcode.HostImportInvalid
+	default:
+		return errcode.ForHTTPStatus(status)
+	}
+}
@@ -0,0 +1,307 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package host
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// MaxHostImportSize is the maximum number of hosts accepted by one import.
+// MaxHostImportSize 是单次导入允许的最大主机数。
+const MaxHostImportSize = 500
+
+// hostImportColumns maps accepted CSV header names to canonical columns.
+// hostImportColumns 将可接受的 CSV 表头映射为规范列名。
+var hostImportColumns = map[string]string{
+	"name":        "name",
+	"hostname":    "name",
+	"ip":          "ip_address",
+	"ip_address":  "ip_address",
+	"ssh_port":    "ssh_port",
+	"port":        "ssh_port",
+	"ssh_user":    "ssh_user",
+	"user":        "ssh_user",
+	"host_type":   "host_type",
+	"type":        "host_type",
+	"description": "description",
+	"labels":      "labels",
+}
+
+// ImportHostsRequest represents a JSON host import.
+// ImportHostsRequest 表示 JSON 格式的主机导入请求。
+type ImportHostsRequest struct {
+	Hosts []CreateHostRequest `json:"hosts"`
+}
+
+// HostImportError describes why one import row was rejected. Row is 1-based; for CSV it is the line number.
+// HostImportError 描述某一导入行被拒绝的原因。Row 从 1 开始，CSV 中为行号。
+type HostImportError struct {
+	Row     int    `json:"row"`
+	Name    string `json:"name,omitempty"`
+	Message string `json:"message"`
+}
+
+// ImportedHost is a created host together with the commands to install its Agent.
+// ImportedHost 表示已创建的主机及其 Agent 安装命令。
+type ImportedHost struct {
+	Host           *HostInfo `json:"host"`
+	InstallCommand string    `json:"install_command,omitempty"`
+	SSHCommand     string    `json:"ssh_command,omitempty"`
+}
+
+// ImportHostsResult is the outcome of a host import.
+// ImportHostsResult 表示主机导入的结果。
+type ImportHostsResult struct {
+	Total   int               `json:"total"`
+	Created int               `json:"created"`
+	Hosts   []ImportedHost    `json:"hosts"`
+	Errors  []HostImportError `json:"errors,omitempty"`
+}
+
+// ParseHostImportCSV parses a CSV host list. The first line is a header; labels use "key=value;key2=value2".
+// ParseHostImportCSV 解析 CSV 主机列表。第一行为表头；labels 使用 "key=value;key2=value2" 格式。
+func ParseHostImportCSV(r io.Reader) ([]CreateHostRequest, []int, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, nil, ErrHostImportEmpty
+		}
+		return nil, nil, fmt.Errorf("host: invalid CSV header: %w", err)
+	}
+	columns := make([]string, len(header))
+	hasName := false
+	for i, name := range header {
+		key := strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		column, ok := hostImportColumns[key]
+		if !ok {
+			return nil, nil, fmt.Errorf("host: unknown CSV column %q", name)
+		}
+		columns[i] = column
+		hasName = hasName || column == "name"
+	}
+	if !hasName {
+		return nil, nil, fmt.Errorf("host: CSV header must contain a name or hostname column")
+	}
+
+	var reqs []CreateHostRequest
+	var lines []int
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		line, _ := reader.FieldPos(0)
+		if err != nil {
+			return nil, nil, fmt.Errorf("host: invalid CSV at line %d: %w", line, err)
+		}
+		if isBlankRecord(record) {
+			continue
+		}
+
+		var req CreateHostRequest
+		for i, value := range record {
+			if i >= len(columns) {
+				return nil, nil, fmt.Errorf("host: line %d has more fields than the header", line)
+			}
+			value = strings.TrimSpace(value)
+			switch columns[i] {
+			case "name":
+				req.Name = value
+			case "ip_address":
+				req.IPAddress = value
+			case "ssh_port":
+				if value == "" {
+					continue
+				}
+				port, err := strconv.Atoi(value)
+				if err != nil {
+					return nil, nil, fmt.Errorf("host: invalid ssh_port %q at line %d", value, line)
+				}
+				req.SSHPort = port
+			case "ssh_user":
+				req.SSHUser = value
+			case "host_type":
+				req.HostType = HostType(value)
+			case "description":
+				req.Description = value
+			case "labels":
+				labels, err := ParseHostLabels(value)
+				if err != nil {
+					return nil, nil, fmt.Errorf("host: %v at line %d", err, line)
+				}
+				req.Labels = labels
+			}
+		}
+		reqs = append(reqs, req)
+		lines = append(lines, line)
+	}
+	return reqs, lines, nil
+}
+
+// ParseHostLabels parses "key=value;key2=value2" into labels. An empty string yields nil.
+// ParseHostLabels 将 "key=value;key2=value2" 解析为标签，空字符串返回 nil。
+func ParseHostLabels(value string) (HostLabels, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil
+	}
+	labels := HostLabels{}
+	for _, pair := range strings.Split(value, ";") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		key, val, ok := strings.Cut(pair, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid label %q, expected key=value", pair)
+		}
+		labels[key] = strings.TrimSpace(val)
+	}
+	return labels, nil
+}
+
+func isBlankRecord(record []string) bool {
+	for _, value := range record {
+		if strings.TrimSpace(value) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// ImportHosts validates every row and creates all hosts in one transaction.
+// When any row is invalid or duplicates another host, nothing is created and every problem is reported.
+// rows optionally gives the source row number of each request (e.g. CSV line numbers).
+// ImportHosts 校验所有行并在同一事务中创建全部主机。
+// 只要有任一行无效或与其他主机重复，就不创建任何主机，并报告所有问题。
+// rows 可选地给出每个请求的来源行号（如 CSV 行号）。
+func (s *Service) ImportHosts(ctx context.Context, reqs []CreateHostRequest, rows []int) (*ImportHostsResult, error) {
+	if len(reqs) == 0 {
+		return nil, ErrHostImportEmpty
+	}
+	if len(reqs) > MaxHostImportSize {
+		return nil, fmt.Errorf("%w: %d > %d", ErrHostImportTooLarge, len(reqs), MaxHostImportSize)
+	}
+
+	result := &ImportHostsResult{Total: len(reqs)}
+	hosts := make([]*Host, 0, len(reqs))
+	seenNames := make(map[string]int, len(reqs))
+	seenIPs := make(map[string]int, len(reqs))
+
+	for i := range reqs {
+		row := i + 1
+		if i < len(rows) {
+			row = rows[i]
+		}
+		req := reqs[i]
+		req.Name = strings.TrimSpace(req.Name)
+		req.IPAddress = strings.TrimSpace(req.IPAddress)
+		reject := func(message string) {
+			result.Errors = append(result.Errors, HostImportError{Row: row, Name: req.Name, Message: message})
+		}
+
+		if len(req.Name) > 100 {
+			reject("host name exceeds 100 characters")
+			continue
+		}
+		host, err := s.buildHost(&req)
+		if err != nil {
+			reject(err.Error())
+			continue
+		}
+
+		if prev, dup := seenNames[host.Name]; dup {
+			reject(fmt.Sprintf("duplicate host name, already used at row %d", prev))
+			continue
+		}
+		seenNames[host.Name] = row
+		if _, err := s.repo.GetByName(ctx, host.Name); err == nil {
+			reject(ErrHostNameDuplicate.Error())
+			continue
+		} else if !errors.Is(err, ErrHostNotFound) {
+			return nil, err
+		}
+
+		if host.HostType == HostTypeBareMetal {
+			if prev, dup := seenIPs[host.IPAddress]; dup {
+				reject(fmt.Sprintf("duplicate IP address, already used at row %d", prev))
+				continue
+			}
+			seenIPs[host.IPAddress] = row
+			if _, err := s.repo.GetByIP(ctx, host.IPAddress); err == nil {
+				reject(ErrHostIPDuplicate.Error())
+				continue
+			} else if !errors.Is(err, ErrHostNotFound) {
+				return nil, err
+			}
+		}
+
+		hosts = append(hosts, host)
+	}
+
+	if len(result.Errors) > 0 {
+		return result, ErrHostImportInvalid
+	}
+
+	if err := s.repo.CreateBatch(ctx, hosts); err != nil {
+		return nil, err
+	}
+
+	result.Created = len(hosts)
+	result.Hosts = make([]ImportedHost, 0, len(hosts))
+	for _, host := range hosts {
+		imported := ImportedHost{Host: host.ToHostInfo(s.heartbeatTimeout, s.processStartedAt)}
+		// Only bare_metal hosts run an Agent; each gets its own enrollment token
+		// 只有物理机/VM 主机需要运行 Agent；每台主机使用各自的注册令牌
+		if host.HostType == HostTypeBareMetal {
+			installCmd, err := s.issueInstallCommand(ctx, host.ID)
+			if err != nil {
+				return nil, err
+			}
+			imported.InstallCommand = installCmd.Command
+			imported.SSHCommand = sshInstallCommand(host, installCmd.Command)
+		}
+		result.Hosts = append(result.Hosts, imported)
+	}
+	return result, nil
+}
+
+// sshInstallCommand wraps the install command so it can be run from an operator machine over SSH.
+// sshInstallCommand 包装安装命令，便于从运维机器通过 SSH 执行。
+func sshInstallCommand(host *Host, installCmd string) string {
+	target := host.IPAddress
+	if host.SSHUser != "" {
+		target = host.SSHUser + "@" + target
+	}
+	port := host.SSHPort
+	if port == 0 {
+		port = 22
+	}
+	return fmt.Sprintf("ssh -p %d %s '%s'", port, target, installCmd)
+}
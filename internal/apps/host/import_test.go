@@ -0,0 +1,138 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package host
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func newImportTestService(t *testing.T) (*Service, *Repository) {
+	t.Helper()
+	db, cleanup := setupServiceTestDB(t)
+	t.Cleanup(cleanup)
+	repo := NewRepository(db)
+	return NewService(repo, nil, &ServiceConfig{ControlPlaneAddr: "http://10.0.0.1:8000"}), repo
+}
+
+func TestParseHostImportCSV(t *testing.T) {
+	input := "hostname,ip,ssh_port,ssh_user,labels\n" +
+		"node-1,10.0.0.11,2222,deploy,env=prod;zone=a\n" +
+		"\n" +
+		"node-2,10.0.0.12,,,\n"
+
+	reqs, lines, err := ParseHostImportCSV(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ParseHostImportCSV returned error: %v", err)
+	}
+	if len(reqs) != 2 {
+		t.Fatalf("expected 2 hosts, got %d", len(reqs))
+	}
+	if reqs[0].Name != "node-1" || reqs[0].SSHPort != 2222 || reqs[0].SSHUser != "deploy" || reqs[0].Labels["zone"] != "a" {
+		t.Fatalf("unexpected first row: %+v", reqs[0])
+	}
+	if lines[0] != 2 || lines[1] != 4 {
+		t.Fatalf("expected CSV line numbers [2 4], got %v", lines)
+	}
+
+	if _, _, err := ParseHostImportCSV(strings.NewReader("name,rack\nnode-1,r1\n")); err == nil {
+		t.Fatal("expected error for unknown column")
+	}
+	if _, _, err := ParseHostImportCSV(strings.NewReader("name,labels\nnode-1,broken\n")); err == nil {
+		t.Fatal("expected error for malformed labels")
+	}
+}
+
+func TestImportHostsCreatesAllWithInstallCommands(t *testing.T) {
+	service, repo := newImportTestService(t)
+	ctx := context.Background()
+
+	result, err := service.ImportHosts(ctx, []CreateHostRequest{
+		{Name: "node-1", IPAddress: "10.0.0.11", SSHUser: "deploy", Labels: HostLabels{"env": "prod"}},
+		{Name: "node-2", IPAddress: "10.0.0.12", SSHPort: 2222},
+	}, nil)
+	if err != nil {
+		t.Fatalf("ImportHosts returned error: %v", err)
+	}
+	if result.Created != 2 || len(result.Hosts) != 2 {
+		t.Fatalf("expected 2 created hosts, got %+v", result)
+	}
+	installCmd := regexp.MustCompile(`^curl -sSL http://10\.0\.0\.1:8000/api/v1/agent/install\.sh \| ENROLLMENT_TOKEN=[A-Za-z0-9_-]{43} bash$`)
+	if !installCmd.MatchString(result.Hosts[0].InstallCommand) {
+		t.Fatalf("unexpected install command %q", result.Hosts[0].InstallCommand)
+	}
+	if result.Hosts[0].InstallCommand == result.Hosts[1].InstallCommand {
+		t.Fatal("each host should get its own enrollment token")
+	}
+	if result.Hosts[0].SSHCommand != "ssh -p 22 deploy@10.0.0.11 '"+result.Hosts[0].InstallCommand+"'" {
+		t.Fatalf("unexpected ssh command %q", result.Hosts[0].SSHCommand)
+	}
+	if !strings.HasPrefix(result.Hosts[1].SSHCommand, "ssh -p 2222 10.0.0.12 ") {
+		t.Fatalf("unexpected ssh command %q", result.Hosts[1].SSHCommand)
+	}
+
+	stored, err := repo.GetByName(ctx, "node-1")
+	if err != nil {
+		t.Fatalf("GetByName returned error: %v", err)
+	}
+	if stored.Labels["env"] != "prod" || stored.SSHUser != "deploy" {
+		t.Fatalf("labels and ssh user should be persisted, got %+v", stored)
+	}
+}
+
+func TestImportHostsRejectsDuplicatesWithoutCreating(t *testing.T) {
+	service, repo := newImportTestService(t)
+	ctx := context.Background()
+
+	if _, err := service.Create(ctx, &CreateHostRequest{Name: "existing", IPAddress: "10.0.0.10"}); err != nil {
+		t.Fatalf("Create returned error: %v", err)
+	}
+
+	result, err := service.ImportHosts(ctx, []CreateHostRequest{
+		{Name: "node-1", IPAddress: "10.0.0.11"},
+		{Name: "node-1", IPAddress: "10.0.0.12"},
+		{Name: "node-3", IPAddress: "10.0.0.11"},
+		{Name: "node-4", IPAddress: "10.0.0.10"},
+		{Name: "existing", IPAddress: "10.0.0.14"},
+		{Name: "node-6", IPAddress: "not-an-ip"},
+	}, []int{2, 3, 4, 5, 6, 7})
+	if !errors.Is(err, ErrHostImportInvalid) {
+		t.Fatalf("expected ErrHostImportInvalid, got %v", err)
+	}
+	if len(result.Errors) != 5 {
+		t.Fatalf("expected 5 row errors, got %+v", result.Errors)
+	}
+	if result.Errors[0].Row != 3 || !strings.Contains(result.Errors[0].Message, "row 2") {
+		t.Fatalf("duplicate name should point at the first row, got %+v", result.Errors[0])
+	}
+
+	hosts, total, err := repo.List(ctx, &HostFilter{}, DefaultHeartbeatTimeout, service.GetProcessStartedAt())
+	if err != nil {
+		t.Fatalf("List returned error: %v", err)
+	}
+	if total != 1 || len(hosts) != 1 {
+		t.Fatalf("no host should be created when a row is invalid, got %d", total)
+	}
+
+	if _, err := service.ImportHosts(ctx, nil, nil); !errors.Is(err, ErrHostImportEmpty) {
+		t.Fatalf("expected ErrHostImportEmpty, got %v", err)
+	}
+}
@@ -0,0 +1,225 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package host
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/seatunnel/seatunnelX/internal/pkg/labelx"
+	"gorm.io/gorm"
+)
+
+// PatchHostLabelsRequest adds/updates and removes labels of a host.
+// PatchHostLabelsRequest 用于新增/更新并删除主机标签。
+type PatchHostLabelsRequest struct {
+	Set    HostLabels `json:"set"`
+	Remove []string   `json:"remove"`
+}
+
+// ReplaceHostLabelsRequest replaces all labels of a host.
+// ReplaceHostLabelsRequest 用于整体替换主机标签。
+type ReplaceHostLabelsRequest struct {
+	Labels HostLabels `json:"labels"`
+}
+
+// LabelValues lists a label key and the values used across hosts.
+// LabelValues 表示一个标签键及其在各主机上出现过的值。
+type LabelValues struct {
+	Key    string   `json:"key"`
+	Values []string `json:"values"`
+}
+
+// ParseLabelSelector parses a label selector expression such as "zone=az1,disk in (ssd,nvme)".
+// ParseLabelSelector 解析标签选择器表达式，例如 "zone=az1,disk in (ssd,nvme)"。
+func ParseLabelSelector(expr string) (labelx.Selector, error) {
+	selector, err := labelx.Parse(expr)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrHostLabelSelectorInvalid, err)
+	}
+	return selector, nil
+}
+
+func validateHostLabels(labels HostLabels) error {
+	if err := labelx.Validate(labels); err != nil {
+		return fmt.Errorf("%w: %v", ErrHostLabelInvalid, err)
+	}
+	return nil
+}
+
+// GetLabels returns the labels of a host.
+// GetLabels 获取主机标签。
+func (s *Service) GetLabels(ctx context.Context, id uint) (HostLabels, error) {
+	host, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if host.Labels == nil {
+		return HostLabels{}, nil
+	}
+	return host.Labels, nil
+}
+
+// ReplaceLabels replaces all labels of a host.
+// ReplaceLabels 整体替换主机标签。
+func (s *Service) ReplaceLabels(ctx context.Context, id uint, labels HostLabels) (HostLabels, error) {
+	if labels == nil {
+		labels = HostLabels{}
+	}
+	if err := validateHostLabels(labels); err != nil {
+		return nil, err
+	}
+	if _, err := s.repo.GetByID(ctx, id); err != nil {
+		return nil, err
+	}
+	if err := s.repo.UpdateLabels(ctx, id, labels); err != nil {
+		return nil, err
+	}
+	return labels, nil
+}
+
+// PatchLabels sets the given labels and removes the listed keys, keeping the others.
+// PatchLabels 设置给定标签并删除指定键，其余标签保持不变。
+func (s *Service) PatchLabels(ctx context.Context, id uint, req *PatchHostLabelsRequest) (HostLabels, error) {
+	labels, err := s.GetLabels(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	merged := make(HostLabels, len(labels)+len(req.Set))
+	for key, value := range labels {
+		merged[key] = value
+	}
+	for _, key := range req.Remove {
+		delete(merged, key)
+	}
+	for key, value := range req.Set {
+		merged[key] = value
+	}
+	return s.ReplaceLabels(ctx, id, merged)
+}
+
+// DeleteLabel removes one label key from a host.
+// DeleteLabel 删除主机的一个标签键。
+func (s *Service) DeleteLabel(ctx context.Context, id uint, key string) (HostLabels, error) {
+	return s.PatchLabels(ctx, id, &PatchHostLabelsRequest{Remove: []string{key}})
+}
+
+// ListLabelValues returns every label key in use with its distinct values, sorted.
+// ListLabelValues 返回所有在用的标签键及其去重后的值（已排序）。
+func (s *Service) ListLabelValues(ctx context.Context) ([]LabelValues, error) {
+	all, err := s.repo.ListLabels(ctx)
+	if err != nil {
+		return nil, err
+	}
+	valueSets := make(map[string]map[string]struct{})
+	for _, labels := range all {
+		for key, value := range labels {
+			if valueSets[key] == nil {
+				valueSets[key] = make(map[string]struct{})
+			}
+			valueSets[key][value] = struct{}{}
+		}
+	}
+
+	result := make([]LabelValues, 0, len(valueSets))
+	for key, set := range valueSets {
+		values := make([]string, 0, len(set))
+		for value := range set {
+			values = append(values, value)
+		}
+		sort.Strings(values)
+		result = append(result, LabelValues{Key: key, Values: values})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Key < result[j].Key })
+	return result, nil
+}
+
+// UpdateLabels overwrites the labels column of a host.
+// UpdateLabels 覆盖主机的标签列。
+func (r *Repository) UpdateLabels(ctx context.Context, id uint, labels HostLabels) error {
+	return r.db.WithContext(ctx).Model(&Host{}).Where("id = ?", id).Update("labels", labels).Error
+}
+
+// ListLabels returns the labels of every host that has any.
+// ListLabels 返回所有带标签主机的标签。
+func (r *Repository) ListLabels(ctx context.Context) ([]HostLabels, error) {
+	var hosts []*Host
+	if err := r.db.WithContext(ctx).Select("labels").Where("labels IS NOT NULL").Find(&hosts).Error; err != nil {
+		return nil, err
+	}
+	labels := make([]HostLabels, 0, len(hosts))
+	for _, host := range hosts {
+		if len(host.Labels) > 0 {
+			labels = append(labels, host.Labels)
+		}
+	}
+	return labels, nil
+}
+
+// applyLabelSelector adds the SQL conditions of a selector.
+// Labels are stored as JSON text and label keys/values cannot contain quotes or colons,
+// so `"key":"value"` and `"key":` substrings identify a label unambiguously.
+// applyLabelSelector 为选择器添加 SQL 条件。
+// 标签以 JSON 文本存储，且键值不含引号和冒号，因此 `"key":"value"` 与 `"key":` 子串可唯一标识标签。
+func applyLabelSelector(query *gorm.DB, selector labelx.Selector) *gorm.DB {
+	for _, requirement := range selector {
+		switch requirement.Operator {
+		case labelx.OpEquals:
+			query = query.Where("labels LIKE ? ESCAPE '!'", labelPairPattern(requirement.Key, requirement.Values[0]))
+		case labelx.OpNotEquals:
+			query = query.Where("(labels IS NULL OR labels NOT LIKE ? ESCAPE '!')", labelPairPattern(requirement.Key, requirement.Values[0]))
+		case labelx.OpIn:
+			conditions := make([]string, len(requirement.Values))
+			args := make([]interface{}, len(requirement.Values))
+			for i, value := range requirement.Values {
+				conditions[i] = "labels LIKE ? ESCAPE '!'"
+				args[i] = labelPairPattern(requirement.Key, value)
+			}
+			query = query.Where("("+strings.Join(conditions, " OR ")+")", args...)
+		case labelx.OpNotIn:
+			for _, value := range requirement.Values {
+				query = query.Where("(labels IS NULL OR labels NOT LIKE ? ESCAPE '!')", labelPairPattern(requirement.Key, value))
+			}
+		case labelx.OpExists:
+			query = query.Where("labels LIKE ? ESCAPE '!'", labelKeyPattern(requirement.Key))
+		case labelx.OpDoesNotExist:
+			query = query.Where("(labels IS NULL OR labels NOT LIKE ? ESCAPE '!')", labelKeyPattern(requirement.Key))
+		}
+	}
+	return query
+}
+
+func labelKeyPattern(key string) string {
+	encoded, _ := json.Marshal(key)
+	return "%" + escapeLike(string(encoded)+":") + "%"
+}
+
+func labelPairPattern(key, value string) string {
+	encodedKey, _ := json.Marshal(key)
+	encodedValue, _ := json.Marshal(value)
+	return "%" + escapeLike(string(encodedKey)+":"+string(encodedValue)) + "%"
+}
+
+// escapeLike escapes LIKE wildcards using '!' as the escape character.
+// escapeLike 使用 '!' 作为转义字符转义 LIKE 通配符。
+func escapeLike(s string) string {
+	return strings.NewReplacer("!", "!!", "%", "!%", "_", "!_").Replace(s)
+}
@@ -0,0 +1,127 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package host
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func newLabelTestService(t *testing.T) *Service {
+	t.Helper()
+	db, cleanup := setupServiceTestDB(t)
+	t.Cleanup(cleanup)
+	return NewService(NewRepository(db), nil, nil)
+}
+
+func TestHostLabelsCRUD(t *testing.T) {
+	svc := newLabelTestService(t)
+	ctx := context.Background()
+
+	h, err := svc.Create(ctx, &CreateHostRequest{Name: "etl-1", IPAddress: "10.0.0.31", Labels: HostLabels{"zone": "az1"}})
+	if err != nil {
+		t.Fatalf("Create returned error: %v", err)
+	}
+	if _, err := svc.Create(ctx, &CreateHostRequest{Name: "bad", IPAddress: "10.0.0.32", Labels: HostLabels{"bad key": "x"}}); !errors.Is(err, ErrHostLabelInvalid) {
+		t.Fatalf("expected ErrHostLabelInvalid on create, got %v", err)
+	}
+
+	labels, err := svc.PatchLabels(ctx, h.ID, &PatchHostLabelsRequest{Set: HostLabels{"disk": "ssd", "role": "etl"}})
+	if err != nil {
+		t.Fatalf("PatchLabels returned error: %v", err)
+	}
+	if !reflect.DeepEqual(labels, HostLabels{"zone": "az1", "disk": "ssd", "role": "etl"}) {
+		t.Fatalf("unexpected labels after patch: %v", labels)
+	}
+
+	if _, err := svc.DeleteLabel(ctx, h.ID, "disk"); err != nil {
+		t.Fatalf("DeleteLabel returned error: %v", err)
+	}
+	stored, err := svc.GetLabels(ctx, h.ID)
+	if err != nil || !reflect.DeepEqual(stored, HostLabels{"zone": "az1", "role": "etl"}) {
+		t.Fatalf("unexpected stored labels %v (%v)", stored, err)
+	}
+
+	if _, err := svc.ReplaceLabels(ctx, h.ID, HostLabels{"zone": "a=b"}); !errors.Is(err, ErrHostLabelInvalid) {
+		t.Fatalf("expected ErrHostLabelInvalid on replace, got %v", err)
+	}
+	if _, err := svc.ReplaceLabels(ctx, h.ID+100, HostLabels{"zone": "az2"}); !errors.Is(err, ErrHostNotFound) {
+		t.Fatalf("expected ErrHostNotFound, got %v", err)
+	}
+}
+
+func TestListHostsByLabelSelector(t *testing.T) {
+	svc := newLabelTestService(t)
+	ctx := context.Background()
+
+	for _, req := range []*CreateHostRequest{
+		{Name: "master-1", IPAddress: "10.0.1.1", Labels: HostLabels{"zone": "az1", "role": "control"}},
+		{Name: "worker-1", IPAddress: "10.0.1.2", Labels: HostLabels{"zone": "az1", "role": "etl", "disk": "ssd"}},
+		{Name: "worker-2", IPAddress: "10.0.1.3", Labels: HostLabels{"zone": "az2", "role": "etl", "maintenance": ""}},
+		{Name: "plain", IPAddress: "10.0.1.4"},
+	} {
+		if _, err := svc.Create(ctx, req); err != nil {
+			t.Fatalf("Create %s returned error: %v", req.Name, err)
+		}
+	}
+
+	cases := []struct {
+		expr string
+		want []string
+	}{
+		{"zone=az1", []string{"master-1", "worker-1"}},
+		{"role in (etl, batch), !maintenance", []string{"worker-1"}},
+		{"zone!=az1", []string{"plain", "worker-2"}},
+		{"role notin (control)", []string{"plain", "worker-1", "worker-2"}},
+		{"disk", []string{"worker-1"}},
+		{"maintenance", []string{"worker-2"}},
+	}
+	for _, tc := range cases {
+		selector, err := ParseLabelSelector(tc.expr)
+		if err != nil {
+			t.Fatalf("ParseLabelSelector(%q) returned error: %v", tc.expr, err)
+		}
+		hosts, total, err := svc.List(ctx, &HostFilter{LabelSelector: selector})
+		if err != nil {
+			t.Fatalf("List(%q) returned error: %v", tc.expr, err)
+		}
+		names := make([]string, 0, len(hosts))
+		for _, h := range hosts {
+			names = append(names, h.Name)
+		}
+		sort.Strings(names)
+		if !reflect.DeepEqual(names, tc.want) || total != int64(len(tc.want)) {
+			t.Errorf("%q: got %v (total %d), want %v", tc.expr, names, total, tc.want)
+		}
+	}
+
+	if _, err := ParseLabelSelector("zone in ()"); !errors.Is(err, ErrHostLabelSelectorInvalid) {
+		t.Fatalf("expected ErrHostLabelSelectorInvalid, got %v", err)
+	}
+
+	values, err := svc.ListLabelValues(ctx)
+	if err != nil {
+		t.Fatalf("ListLabelValues returned error: %v", err)
+	}
+	if len(values) != 4 || values[2].Key != "role" || !reflect.DeepEqual(values[2].Values, []string{"control", "etl"}) {
+		t.Fatalf("unexpected label values %+v", values)
+	}
+}
@@ -0,0 +1,83 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package host
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// SetMaintenanceRequest turns maintenance mode of a host on or off.
+// SetMaintenanceRequest 用于开启或关闭主机维护模式。
+type SetMaintenanceRequest struct {
+	Enabled bool   `json:"enabled"`
+	Reason  string `json:"reason" binding:"max=255"`
+}
+
+// SetMaintenance turns maintenance mode of a host on or off.
+// While enabled, offline alerts of the host are suppressed, installations and plugin
+// operations on it are rejected and scheduled jobs of its clusters are paused.
+// SetMaintenance 开启或关闭主机维护模式。
+// 开启期间抑制该主机的离线告警，拒绝在其上的安装与插件操作，并暂停其所在集群的定时作业。
+func (s *Service) SetMaintenance(ctx context.Context, id uint, req *SetMaintenanceRequest) (*Host, error) {
+	host, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	reason := strings.TrimSpace(req.Reason)
+	var startedAt *time.Time
+	if req.Enabled {
+		// Keep the original start time when only the reason changes
+		// 仅修改原因时保留原有开始时间
+		startedAt = host.MaintenanceStartedAt
+		if !host.Maintenance || startedAt == nil {
+			now := time.Now()
+			startedAt = &now
+		}
+	} else {
+		reason = ""
+	}
+	if err := s.repo.UpdateMaintenance(ctx, id, req.Enabled, reason, startedAt); err != nil {
+		return nil, err
+	}
+	host.Maintenance = req.Enabled
+	host.MaintenanceReason = reason
+	host.MaintenanceStartedAt = startedAt
+	return host, nil
+}
+
+// IsUnderMaintenance reports whether the host is in maintenance mode.
+// IsUnderMaintenance 返回主机是否处于维护模式。
+func (s *Service) IsUnderMaintenance(ctx context.Context, id uint) (bool, error) {
+	host, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return false, err
+	}
+	return host.Maintenance, nil
+}
+
+// UpdateMaintenance updates the maintenance mode fields of a host.
+// UpdateMaintenance 更新主机的维护模式字段。
+func (r *Repository) UpdateMaintenance(ctx context.Context, id uint, enabled bool, reason string, startedAt *time.Time) error {
+	return r.db.WithContext(ctx).Model(&Host{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"maintenance":            enabled,
+		"maintenance_reason":     reason,
+		"maintenance_started_at": startedAt,
+	}).Error
+}
@@ -0,0 +1,80 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package host
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestSetMaintenance(t *testing.T) {
+	svc := newLabelTestService(t)
+	ctx := context.Background()
+
+	h, err := svc.Create(ctx, &CreateHostRequest{Name: "etl-1", IPAddress: "10.0.0.41"})
+	if err != nil {
+		t.Fatalf("Create returned error: %v", err)
+	}
+	if _, err := svc.Create(ctx, &CreateHostRequest{Name: "etl-2", IPAddress: "10.0.0.42"}); err != nil {
+		t.Fatalf("Create returned error: %v", err)
+	}
+
+	updated, err := svc.SetMaintenance(ctx, h.ID, &SetMaintenanceRequest{Enabled: true, Reason: " disk swap "})
+	if err != nil {
+		t.Fatalf("SetMaintenance returned error: %v", err)
+	}
+	if !updated.Maintenance || updated.MaintenanceReason != "disk swap" || updated.MaintenanceStartedAt == nil {
+		t.Fatalf("unexpected host after enabling maintenance: %+v", updated)
+	}
+	startedAt := *updated.MaintenanceStartedAt
+
+	info := updated.ToHostInfo(30*time.Second, time.Time{})
+	if info.Status != HostStatusMaintenance || !info.Maintenance {
+		t.Fatalf("expected maintenance display status, got %s", info.Status)
+	}
+	if inMaintenance, err := svc.IsUnderMaintenance(ctx, h.ID); err != nil || !inMaintenance {
+		t.Fatalf("expected host to be under maintenance, got %v (%v)", inMaintenance, err)
+	}
+
+	hosts, total, err := svc.List(ctx, &HostFilter{Status: HostStatusMaintenance})
+	if err != nil || total != 1 || hosts[0].ID != h.ID {
+		t.Fatalf("expected only the maintenance host, got %d hosts (%v)", total, err)
+	}
+
+	// Changing the reason keeps the start time / 修改原因保留开始时间
+	updated, err = svc.SetMaintenance(ctx, h.ID, &SetMaintenanceRequest{Enabled: true, Reason: "kernel upgrade"})
+	if err != nil || !updated.MaintenanceStartedAt.Equal(startedAt) {
+		t.Fatalf("expected start time to be kept, got %+v (%v)", updated, err)
+	}
+
+	updated, err = svc.SetMaintenance(ctx, h.ID, &SetMaintenanceRequest{Enabled: false, Reason: "ignored"})
+	if err != nil {
+		t.Fatalf("SetMaintenance returned error: %v", err)
+	}
+	if updated.Maintenance || updated.MaintenanceReason != "" || updated.MaintenanceStartedAt != nil {
+		t.Fatalf("unexpected host after disabling maintenance: %+v", updated)
+	}
+	if info := updated.ToHostInfo(30*time.Second, time.Time{}); info.Status == HostStatusMaintenance {
+		t.Fatalf("expected regular display status after maintenance, got %s", info.Status)
+	}
+
+	if _, err := svc.SetMaintenance(ctx, 999, &SetMaintenanceRequest{Enabled: true}); err == nil {
+		t.Fatal("expected error for a missing host")
+	}
+}
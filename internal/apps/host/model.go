@@ -43,10 +43,17 @@
 package host
 
 import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
 	"net"
 	"net/url"
 	"strings"
 	"time"
+
+	"github.com/seatunnel/seatunnelX/internal/pkg/labelx"
+	"github.com/seatunnel/seatunnelX/internal/pkg/pagex"
+	"gorm.io/gorm"
 )
 
 // HostType represents the type of host environment.
@@ -97,6 +104,9 @@ const (
 	// HostStatusError indicates the host has an error.
 	// HostStatusError 表示主机出现错误。
 	HostStatusError HostStatus = "error"
+	// HostStatusMaintenance is the display status of a host in maintenance mode.
+	// HostStatusMaintenance 是处于维护模式的主机的展示状态。
+	HostStatusMaintenance HostStatus = "maintenance"
 )
 
 // AgentStatus represents the installation status of an Agent on a host.
@@ -115,6 +125,42 @@ const (
 	AgentStatusOffline AgentStatus = "offline"
 )
 
+// HostLabels holds user-defined key/value labels of a host.
+// HostLabels 保存主机的用户自定义键值标签。
+type HostLabels map[string]string
+
+// Value implements the driver.Valuer interface.
+// Value 实现 driver.Valuer 接口。
+func (l HostLabels) Value() (driver.Value, error) {
+	if l == nil {
+		return nil, nil
+	}
+	// Stored as text so label selectors can match it with LIKE
+	// 以文本存储，以便标签选择器使用 LIKE 匹配
+	b, err := json.Marshal(l)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// Scan implements the sql.Scanner interface.
+// Scan 实现 sql.Scanner 接口。
+func (l *HostLabels) Scan(value interface{}) error {
+	if value == nil {
+		*l = nil
+		return nil
+	}
+	switch v := value.(type) {
+	case []byte:
+		return json.Unmarshal(v, l)
+	case string:
+		return json.Unmarshal([]byte(v), l)
+	default:
+		return errors.New("host: failed to scan HostLabels - expected []byte or string")
+	}
+}
+
 // Host represents a physical machine, VM, Docker host, or Kubernetes cluster that runs SeaTunnel services.
 // Host 表示运行 SeaTunnel 服务的物理机、虚拟机、Docker 主机或 Kubernetes 集群。
 type Host struct {
@@ -123,6 +169,14 @@ type Host struct {
 	HostType    HostType   `json:"host_type" gorm:"size:20;not null;default:bare_metal;index"`
 	Description string     `json:"description" gorm:"type:text"`
 	Status      HostStatus `json:"status" gorm:"size:20;default:pending;index"`
+	Labels      HostLabels `json:"labels" gorm:"type:text"`
+	// ProjectID is the owning project, 0 means shared by all tenants / ProjectID 为所属项目，0 表示所有租户共享
+	ProjectID uint `json:"project_id" gorm:"default:0;index"`
+
+	// Maintenance mode fields / 维护模式字段
+	Maintenance          bool       `json:"maintenance" gorm:"default:false;index"`
+	MaintenanceReason    string     `json:"maintenance_reason" gorm:"size:255"`
+	MaintenanceStartedAt *time.Time `json:"maintenance_started_at"`
 
 	// Common resource usage fields / 通用资源使用率字段
 	CPUUsage    float64    `json:"cpu_usage" gorm:"type:decimal(5,2)"`
@@ -133,6 +187,7 @@ type Host struct {
 	// bare_metal specific fields / 物理机/VM 专用字段
 	IPAddress     string      `json:"ip_address" gorm:"size:45"`
 	SSHPort       int         `json:"ssh_port" gorm:"default:22"`
+	SSHUser       string      `json:"ssh_user" gorm:"size:64"`
 	AgentID       string      `json:"agent_id" gorm:"size:100;index"`
 	AgentStatus   AgentStatus `json:"agent_status" gorm:"size:20;default:not_installed;index"`
 	AgentVersion  string      `json:"agent_version" gorm:"size:20"`
@@ -142,6 +197,8 @@ type Host struct {
 	TotalMemory   int64       `json:"total_memory"`
 	TotalDisk     int64       `json:"total_disk"`
 	LastHeartbeat *time.Time  `json:"last_heartbeat"`
+	// AgentSecretHash is the SHA-256 of the secret issued to the enrolled Agent / AgentSecretHash 是签发给已注册 Agent 的密钥的 SHA-256
+	AgentSecretHash string `json:"-" gorm:"size:64"`
 
 	// docker specific fields (Phase 2) / Docker 专用字段（第二阶段）
 	DockerAPIURL     string `json:"docker_api_url" gorm:"size:255"`
@@ -156,9 +213,10 @@ type Host struct {
 	K8sToken      string `json:"k8s_token" gorm:"type:text"`
 	K8sVersion    string `json:"k8s_version" gorm:"size:20"`
 
-	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
-	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
-	CreatedBy uint      `json:"created_by"`
+	CreatedAt time.Time      `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time      `json:"updated_at" gorm:"autoUpdateTime"`
+	CreatedBy uint           `json:"created_by"`
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"` // Set while the host is in the recycle bin / 主机位于回收站时设置
 }
 
 // TableName specifies the table name for the Host model.
@@ -204,8 +262,20 @@ type HostFilter struct {
 	Status      HostStatus  `json:"status"`
 	AgentStatus AgentStatus `json:"agent_status"`
 	IsOnline    *bool       `json:"is_online"`
-	Page        int         `json:"page"`
-	PageSize    int         `json:"page_size"`
+	// LabelSelector keeps only hosts whose labels match / LabelSelector 仅保留标签匹配的主机
+	LabelSelector labelx.Selector `json:"label_selector,omitempty"`
+	// ClusterID keeps only hosts that are nodes of the cluster / ClusterID 仅保留属于该集群节点的主机
+	ClusterID uint `json:"cluster_id,omitempty"`
+	// CreatedAt restricts the creation time / CreatedAt 限制创建时间范围
+	CreatedAt pagex.TimeRange `json:"created_at,omitempty"`
+	// ProjectIDs limits results to shared hosts and these projects when RestrictProjects is set
+	// ProjectIDs 在 RestrictProjects 为 true 时将结果限制为共享主机及这些项目的主机
+	ProjectIDs       []uint `json:"-"`
+	RestrictProjects bool   `json:"-"`
+	Page             int    `json:"page"`
+	PageSize         int    `json:"page_size"`
+	// Sort orders the result, newest first when empty / Sort 指定排序，为空时按创建时间倒序
+	Sort []pagex.SortField `json:"sort,omitempty"`
 }
 
 // HostInfo represents host information for API responses.
@@ -216,6 +286,13 @@ type HostInfo struct {
 	HostType    HostType   `json:"host_type"`
 	Description string     `json:"description"`
 	Status      HostStatus `json:"status"`
+	Labels      HostLabels `json:"labels,omitempty"`
+	ProjectID   uint       `json:"project_id"`
+
+	// Maintenance fields / 维护模式字段
+	Maintenance          bool       `json:"maintenance"`
+	MaintenanceReason    string     `json:"maintenance_reason,omitempty"`
+	MaintenanceStartedAt *time.Time `json:"maintenance_started_at,omitempty"`
 
 	// Common fields / 通用字段
 	CPUUsage    float64    `json:"cpu_usage"`
@@ -227,6 +304,7 @@ type HostInfo struct {
 	// bare_metal fields / 物理机字段
 	IPAddress     string      `json:"ip_address,omitempty"`
 	SSHPort       int         `json:"ssh_port,omitempty"`
+	SSHUser       string      `json:"ssh_user,omitempty"`
 	AgentID       string      `json:"agent_id,omitempty"`
 	AgentStatus   AgentStatus `json:"agent_status,omitempty"`
 	AgentVersion  string      `json:"agent_version,omitempty"`
@@ -262,6 +340,8 @@ func (h *Host) ToHostInfo(heartbeatTimeout time.Duration, since time.Time) *Host
 		HostType:    h.HostType,
 		Description: h.Description,
 		Status:      h.Status,
+		Labels:      h.Labels,
+		ProjectID:   h.ProjectID,
 		CPUUsage:    h.CPUUsage,
 		MemoryUsage: h.MemoryUsage,
 		DiskUsage:   h.DiskUsage,
@@ -276,6 +356,7 @@ func (h *Host) ToHostInfo(heartbeatTimeout time.Duration, since time.Time) *Host
 		info.IsOnline = h.IsOnlineWithSince(heartbeatTimeout, since)
 		info.IPAddress = h.IPAddress
 		info.SSHPort = h.SSHPort
+		info.SSHUser = h.SSHUser
 		info.AgentID = h.AgentID
 		info.AgentStatus = h.AgentStatus
 		info.AgentVersion = h.AgentVersion
@@ -315,6 +396,15 @@ func (h *Host) ToHostInfo(heartbeatTimeout time.Duration, since time.Time) *Host
 		}
 	}
 
+	// A host under maintenance is shown as such instead of offline or unhealthy
+	// 维护中的主机展示为维护状态，而不是离线或不健康
+	if h.Maintenance {
+		info.Maintenance = true
+		info.MaintenanceReason = h.MaintenanceReason
+		info.MaintenanceStartedAt = h.MaintenanceStartedAt
+		info.Status = HostStatusMaintenance
+	}
+
 	return info
 }
 
@@ -336,13 +426,16 @@ func agentStatusToHostStatus(s AgentStatus) HostStatus {
 // CreateHostRequest represents a request to create a new host.
 // CreateHostRequest 表示创建新主机的请求。
 type CreateHostRequest struct {
-	Name        string   `json:"name" binding:"required,max=100"`
-	HostType    HostType `json:"host_type"`
-	Description string   `json:"description"`
+	Name        string     `json:"name" binding:"required,max=100"`
+	HostType    HostType   `json:"host_type"`
+	Description string     `json:"description"`
+	Labels      HostLabels `json:"labels"`
+	ProjectID   uint       `json:"project_id"` // Owning project, 0 for shared / 所属项目，0 表示共享
 
 	// bare_metal fields / 物理机字段
 	IPAddress string `json:"ip_address"`
 	SSHPort   int    `json:"ssh_port"`
+	SSHUser   string `json:"ssh_user"`
 
 	// docker fields / Docker 字段
 	DockerAPIURL     string `json:"docker_api_url"`
@@ -359,12 +452,14 @@ type CreateHostRequest struct {
 // UpdateHostRequest represents a request to update an existing host.
 // UpdateHostRequest 表示更新现有主机的请求。
 type UpdateHostRequest struct {
-	Name        *string `json:"name"`
-	Description *string `json:"description"`
+	Name        *string     `json:"name"`
+	Description *string     `json:"description"`
+	Labels      *HostLabels `json:"labels"`
 
 	// bare_metal fields / 物理机字段
 	IPAddress *string `json:"ip_address"`
 	SSHPort   *int    `json:"ssh_port"`
+	SSHUser   *string `json:"ssh_user"`
 
 	// docker fields / Docker 字段
 	DockerAPIURL     *string `json:"docker_api_url"`
@@ -0,0 +1,248 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package host
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/seatunnel/seatunnelX/internal/logger"
+	"github.com/seatunnel/seatunnelX/internal/pkg/leader"
+	"github.com/seatunnel/seatunnelX/internal/pkg/worker"
+	"gorm.io/gorm"
+)
+
+// DefaultRecycleRetention is how long a deleted host stays restorable before it is purged.
+// DefaultRecycleRetention 是已删除主机在被清理前可恢复的默认保留时长。
+const DefaultRecycleRetention = 7 * 24 * time.Hour
+
+// DefaultRecyclePurgeInterval is the default interval of the recycle bin purge job.
+// DefaultRecyclePurgeInterval 是回收站清理任务的默认执行间隔。
+const DefaultRecyclePurgeInterval = 30 * time.Minute
+
+// RecycledHost describes a host in the recycle bin.
+// RecycledHost 描述回收站中的主机。
+type RecycledHost struct {
+	*HostInfo
+	DeletedAt time.Time `json:"deleted_at"`
+	PurgeAt   time.Time `json:"purge_at"`
+}
+
+// ==================== Repository 仓库 ====================
+
+// Recycle moves a host to the recycle bin.
+// Returns ErrHostNotFound if the host does not exist.
+// Recycle 将主机移入回收站。
+func (r *Repository) Recycle(ctx context.Context, id uint) error {
+	result := r.db.WithContext(ctx).Delete(&Host{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrHostNotFound
+	}
+	return nil
+}
+
+// GetRecycled retrieves a host of the recycle bin.
+// Returns ErrHostNotRecycled if the host is not in the recycle bin.
+// GetRecycled 获取回收站中的主机。
+func (r *Repository) GetRecycled(ctx context.Context, id uint) (*Host, error) {
+	var host Host
+	if err := r.db.WithContext(ctx).Unscoped().Where("deleted_at IS NOT NULL").First(&host, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrHostNotRecycled
+		}
+		return nil, err
+	}
+	return &host, nil
+}
+
+// ListRecycled lists the hosts of the recycle bin, newest deletion first.
+// Only the name and project filters are applied.
+// deletedBefore, when non-zero, keeps only hosts deleted before that time.
+// ListRecycled 列出回收站中的主机，最近删除的在前；仅应用名称与项目过滤条件。
+func (r *Repository) ListRecycled(ctx context.Context, filter *HostFilter, deletedBefore time.Time) ([]*Host, error) {
+	query := r.db.WithContext(ctx).Unscoped().Model(&Host{}).Where("deleted_at IS NOT NULL")
+	if !deletedBefore.IsZero() {
+		query = query.Where("deleted_at < ?", deletedBefore)
+	}
+	if filter != nil {
+		if filter.Name != "" {
+			query = query.Where("name LIKE ?", "%"+filter.Name+"%")
+		}
+		if filter.RestrictProjects {
+			query = applyProjectScope(query, filter.ProjectIDs)
+		}
+	}
+
+	var hosts []*Host
+	if err := query.Order("deleted_at DESC").Find(&hosts).Error; err != nil {
+		return nil, err
+	}
+	return hosts, nil
+}
+
+// Restore moves a host out of the recycle bin.
+// Returns ErrHostNotRecycled if the host is not in the recycle bin and ErrHostIPDuplicate
+// if another host took over its IP address in the meantime.
+// Restore 将主机移出回收站；若期间已有其他主机使用相同 IP，返回 ErrHostIPDuplicate。
+func (r *Repository) Restore(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var host Host
+		if err := tx.Unscoped().Where("deleted_at IS NOT NULL").First(&host, id).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrHostNotRecycled
+			}
+			return err
+		}
+
+		if (host.HostType == HostTypeBareMetal || host.HostType == "") && host.IPAddress != "" {
+			var count int64
+			if err := tx.Model(&Host{}).Where("ip_address = ?", host.IPAddress).Count(&count).Error; err != nil {
+				return err
+			}
+			if count > 0 {
+				return ErrHostIPDuplicate
+			}
+		}
+
+		return tx.Unscoped().Model(&Host{}).Where("id = ?", id).Update("deleted_at", nil).Error
+	})
+}
+
+// ==================== Service 服务 ====================
+
+// SetOnHostPurged sets the hook called after the purge job removes a recycled host
+// (typically used to write an audit event).
+// SetOnHostPurged 设置清理任务删除回收站主机后的回调（通常用于写入审计事件）。
+func (s *Service) SetOnHostPurged(fn func(context.Context, *Host, error)) {
+	s.onHostPurged = fn
+}
+
+// Recycle moves a host to the recycle bin after checking cluster associations.
+// The host is removed permanently by the purge job once the retention window has elapsed.
+// Recycle 在检查集群关联后将主机移入回收站；保留期结束后由清理任务永久删除。
+func (s *Service) Recycle(ctx context.Context, id uint) (*RecycledHost, error) {
+	if _, err := s.getDeletable(ctx, id); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Recycle(ctx, id); err != nil {
+		return nil, err
+	}
+
+	recycled, err := s.repo.GetRecycled(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.toRecycledHost(recycled), nil
+}
+
+// ListRecycled lists the hosts of the recycle bin.
+// ListRecycled 列出回收站中的主机。
+func (s *Service) ListRecycled(ctx context.Context, filter *HostFilter) ([]*RecycledHost, error) {
+	hosts, err := s.repo.ListRecycled(ctx, filter, time.Time{})
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*RecycledHost, 0, len(hosts))
+	for _, host := range hosts {
+		result = append(result, s.toRecycledHost(host))
+	}
+	return result, nil
+}
+
+// Restore moves a host out of the recycle bin.
+// Restore 将主机从回收站恢复。
+func (s *Service) Restore(ctx context.Context, id uint) (*Host, error) {
+	if err := s.repo.Restore(ctx, id); err != nil {
+		return nil, err
+	}
+	return s.repo.GetByID(ctx, id)
+}
+
+// Purge removes a recycled host permanently.
+// Purge 永久删除回收站中的主机。
+func (s *Service) Purge(ctx context.Context, id uint) (*Host, error) {
+	host, err := s.repo.GetRecycled(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return host, s.repo.Delete(ctx, id)
+}
+
+// PurgeExpired removes the hosts whose retention window has elapsed and returns how many were purged.
+// PurgeExpired 删除保留期已过的主机，返回清理的数量。
+func (s *Service) PurgeExpired(ctx context.Context, now time.Time) int {
+	expired, err := s.repo.ListRecycled(ctx, nil, now.Add(-s.recycleRetention))
+	if err != nil {
+		logger.WarnF(ctx, "[Host] list expired recycled hosts failed: %v", err)
+		return 0
+	}
+
+	purged := 0
+	for _, host := range expired {
+		err := s.repo.Delete(ctx, host.ID)
+		if err != nil {
+			logger.WarnF(ctx, "[Host] purge recycled host failed: host=%d, err=%v", host.ID, err)
+		} else {
+			purged++
+		}
+		if s.onHostPurged != nil {
+			s.onHostPurged(ctx, host, err)
+		}
+	}
+	return purged
+}
+
+// StartRecyclePurger starts the background job purging expired hosts of the recycle bin.
+// StartRecyclePurger 启动清理回收站过期主机的后台任务。
+func (s *Service) StartRecyclePurger(ctx context.Context) {
+	if s == nil || s.repo == nil {
+		return
+	}
+
+	s.recyclePurgeRuntime.Do(func() {
+		go func() {
+			defer worker.Start("host-recycle-purger")()
+
+			ticker := time.NewTicker(s.recyclePurgeInterval)
+			defer ticker.Stop()
+
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					if leader.IsLeader() {
+						s.PurgeExpired(ctx, time.Now())
+					}
+				}
+			}
+		}()
+	})
+}
+
+func (s *Service) toRecycledHost(host *Host) *RecycledHost {
+	return &RecycledHost{
+		HostInfo:  host.ToHostInfo(s.heartbeatTimeout, s.processStartedAt),
+		DeletedAt: host.DeletedAt.Time,
+		PurgeAt:   host.DeletedAt.Time.Add(s.recycleRetention),
+	}
+}
@@ -0,0 +1,84 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package host
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestHostRecycleBin_restoreAndPurge(t *testing.T) {
+	db, cleanup := setupServiceTestDB(t)
+	defer cleanup()
+
+	repo := NewRepository(db)
+	svc := NewService(repo, nil, &ServiceConfig{RecycleRetention: time.Hour})
+	ctx := context.Background()
+
+	created, err := svc.Create(ctx, &CreateHostRequest{Name: "recycled", IPAddress: "10.0.2.1"})
+	if err != nil {
+		t.Fatalf("Create returned error: %v", err)
+	}
+	if _, err := svc.Recycle(ctx, created.ID); err != nil {
+		t.Fatalf("Recycle returned error: %v", err)
+	}
+	if _, err := svc.Get(ctx, created.ID); !errors.Is(err, ErrHostNotFound) {
+		t.Fatalf("expected recycled host to be hidden, got %v", err)
+	}
+	if recycled, err := svc.ListRecycled(ctx, nil); err != nil || len(recycled) != 1 {
+		t.Fatalf("ListRecycled returned %v, %v", recycled, err)
+	}
+
+	// Another host took over the IP, restoring must not create a duplicate
+	// 其他主机已占用该 IP，恢复时不能产生重复
+	other, err := svc.Create(ctx, &CreateHostRequest{Name: "replacement", IPAddress: "10.0.2.1"})
+	if err != nil {
+		t.Fatalf("Create returned error: %v", err)
+	}
+	if _, err := svc.Restore(ctx, created.ID); !errors.Is(err, ErrHostIPDuplicate) {
+		t.Fatalf("expected ErrHostIPDuplicate, got %v", err)
+	}
+	if err := svc.Delete(ctx, other.ID); err != nil {
+		t.Fatalf("Delete returned error: %v", err)
+	}
+	if _, err := svc.Restore(ctx, created.ID); err != nil {
+		t.Fatalf("Restore returned error: %v", err)
+	}
+
+	if _, err := svc.Recycle(ctx, created.ID); err != nil {
+		t.Fatalf("Recycle returned error: %v", err)
+	}
+	var purgedHosts []uint
+	svc.SetOnHostPurged(func(_ context.Context, host *Host, err error) {
+		if err != nil {
+			t.Errorf("unexpected purge error: %v", err)
+		}
+		purgedHosts = append(purgedHosts, host.ID)
+	})
+	if purged := svc.PurgeExpired(ctx, time.Now()); purged != 0 {
+		t.Fatalf("expected nothing purged inside the retention window, got %d", purged)
+	}
+	if purged := svc.PurgeExpired(ctx, time.Now().Add(2*time.Hour)); purged != 1 || len(purgedHosts) != 1 {
+		t.Fatalf("expected the expired host to be purged, got %d %v", purged, purgedHosts)
+	}
+	if _, err := repo.GetRecycled(ctx, created.ID); !errors.Is(err, ErrHostNotRecycled) {
+		t.Fatalf("expected purged host to be gone, got %v", err)
+	}
+}
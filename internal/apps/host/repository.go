@@ -22,6 +22,7 @@ import (
 	"errors"
 	"time"
 
+	"github.com/seatunnel/seatunnelX/internal/pkg/pagex"
 	"gorm.io/gorm"
 )
 
@@ -41,6 +42,25 @@ func NewRepository(db *gorm.DB) *Repository {
 // Returns ErrHostIPDuplicate if a host with the same IP already exists.
 // Returns ErrHostIPInvalid if the IP address format is invalid (for bare_metal).
 func (r *Repository) Create(ctx context.Context, host *Host) error {
+	return createHost(r.db.WithContext(ctx), host)
+}
+
+// CreateBatch creates several hosts in one transaction; either all of them are created or none.
+// CreateBatch 在同一事务中创建多个主机，要么全部创建成功，要么全部不创建。
+func (r *Repository) CreateBatch(ctx context.Context, hosts []*Host) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, host := range hosts {
+			if err := createHost(tx, host); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// createHost validates uniqueness and inserts a host using the given database handle.
+// createHost 使用给定的数据库句柄校验唯一性并插入主机。
+func createHost(db *gorm.DB, host *Host) error {
 	// Validate host name is not empty
 	// 验证主机名不为空
 	if host.Name == "" {
@@ -55,10 +75,10 @@ func (r *Repository) Create(ctx context.Context, host *Host) error {
 		}
 	}
 
-	// Check for duplicate name
-	// 检查名称是否重复
+	// Check for duplicate name, names of recycled hosts stay reserved until purge
+	// 检查名称是否重复，回收站中主机的名称在清理前仍被占用
 	var count int64
-	if err := r.db.WithContext(ctx).Model(&Host{}).Where("name = ?", host.Name).Count(&count).Error; err != nil {
+	if err := db.Unscoped().Model(&Host{}).Where("name = ?", host.Name).Count(&count).Error; err != nil {
 		return err
 	}
 	if count > 0 {
@@ -69,7 +89,7 @@ func (r *Repository) Create(ctx context.Context, host *Host) error {
 	// 检查 IP 是否重复（物理机/VM）
 	if (host.HostType == HostTypeBareMetal || host.HostType == "") && host.IPAddress != "" {
 		count = 0
-		if err := r.db.WithContext(ctx).Model(&Host{}).Where("ip_address = ?", host.IPAddress).Count(&count).Error; err != nil {
+		if err := db.Model(&Host{}).Where("ip_address = ?", host.IPAddress).Count(&count).Error; err != nil {
 			return err
 		}
 		if count > 0 {
@@ -77,7 +97,7 @@ func (r *Repository) Create(ctx context.Context, host *Host) error {
 		}
 	}
 
-	return r.db.WithContext(ctx).Create(host).Error
+	return db.Create(host).Error
 }
 
 // GetByID retrieves a host by its ID.
@@ -152,12 +172,27 @@ func (r *Repository) List(ctx context.Context, filter *HostFilter, heartbeatTime
 			query = query.Where("ip_address LIKE ?", "%"+filter.IPAddress+"%")
 		}
 		// Filter by host status / 按主机状态过滤
-		if filter.Status != "" {
+		if filter.Status == HostStatusMaintenance {
+			query = query.Where("maintenance = ?", true)
+		} else if filter.Status != "" {
 			query = query.Where("status = ?", filter.Status)
 		}
 		if filter.AgentStatus != "" {
 			query = query.Where("agent_status = ?", filter.AgentStatus)
 		}
+		// Filter by label selector / 按标签选择器过滤
+		if !filter.LabelSelector.Empty() {
+			query = applyLabelSelector(query, filter.LabelSelector)
+		}
+		// Restrict to shared hosts and the caller's projects / 限制为共享主机及调用者所属项目的主机
+		if filter.RestrictProjects {
+			query = applyProjectScope(query, filter.ProjectIDs)
+		}
+		// Filter by cluster membership / 按集群成员关系过滤
+		if filter.ClusterID > 0 {
+			query = query.Where("id IN (?)", r.db.Table("cluster_nodes").Select("host_id").Where("cluster_id = ?", filter.ClusterID))
+		}
+		query = filter.CreatedAt.Apply(query, "created_at")
 	}
 
 	// Get total count
@@ -166,18 +201,17 @@ func (r *Repository) List(ctx context.Context, filter *HostFilter, heartbeatTime
 		return nil, 0, err
 	}
 
-	// Apply pagination
-	if filter != nil && filter.PageSize > 0 {
-		offset := 0
-		if filter.Page > 0 {
-			offset = (filter.Page - 1) * filter.PageSize
-		}
-		query = query.Offset(offset).Limit(filter.PageSize)
+	// Apply sorting and pagination / 应用排序和分页
+	var sort []pagex.SortField
+	if filter != nil {
+		sort = filter.Sort
+		query = pagex.Paginate(query, filter.Page, filter.PageSize)
 	}
+	query = pagex.Order(query, sort, "created_at DESC")
 
 	// Execute query
 	var hosts []*Host
-	if err := query.Order("created_at DESC").Find(&hosts).Error; err != nil {
+	if err := query.Find(&hosts).Error; err != nil {
 		return nil, 0, err
 	}
 
@@ -218,7 +252,7 @@ func (r *Repository) Update(ctx context.Context, host *Host) error {
 	// Check for duplicate name if name is being changed
 	if host.Name != "" && host.Name != existing.Name {
 		var count int64
-		if err := r.db.WithContext(ctx).Model(&Host{}).Where("name = ? AND id != ?", host.Name, host.ID).Count(&count).Error; err != nil {
+		if err := r.db.WithContext(ctx).Unscoped().Model(&Host{}).Where("name = ? AND id != ?", host.Name, host.ID).Count(&count).Error; err != nil {
 			return err
 		}
 		if count > 0 {
@@ -241,11 +275,11 @@ func (r *Repository) Update(ctx context.Context, host *Host) error {
 	return r.db.WithContext(ctx).Save(host).Error
 }
 
-// Delete removes a host record from the database.
+// Delete permanently removes a host record from the database, including a recycled one.
 // Returns ErrHostNotFound if the host does not exist.
 // Note: Cluster association check should be done at the service layer.
 func (r *Repository) Delete(ctx context.Context, id uint) error {
-	result := r.db.WithContext(ctx).Delete(&Host{}, id)
+	result := r.db.WithContext(ctx).Unscoped().Delete(&Host{}, id)
 	if result.Error != nil {
 		return result.Error
 	}
@@ -330,11 +364,20 @@ func (r *Repository) MarkOfflineHosts(ctx context.Context, timeout time.Duration
 	return result.RowsAffected, result.Error
 }
 
-// ExistsByName checks if a host with the given name exists.
+// ExistsByName checks if a host with the given name exists, including hosts in the recycle bin.
 func (r *Repository) ExistsByName(ctx context.Context, name string) (bool, error) {
 	var count int64
-	if err := r.db.WithContext(ctx).Model(&Host{}).Where("name = ?", name).Count(&count).Error; err != nil {
+	if err := r.db.WithContext(ctx).Unscoped().Model(&Host{}).Where("name = ?", name).Count(&count).Error; err != nil {
 		return false, err
 	}
 	return count > 0, nil
 }
+
+// applyProjectScope keeps shared rows (project_id = 0) and rows owned by the given projects.
+// applyProjectScope 仅保留共享记录（project_id = 0）及指定项目拥有的记录。
+func applyProjectScope(query *gorm.DB, projectIDs []uint) *gorm.DB {
+	if len(projectIDs) == 0 {
+		return query.Where("project_id = ?", 0)
+	}
+	return query.Where("project_id = ? OR project_id IN ?", 0, projectIDs)
+}
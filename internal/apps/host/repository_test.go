@@ -24,11 +24,13 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/glebarez/sqlite"
 	"github.com/leanovate/gopter"
 	"github.com/leanovate/gopter/gen"
 	"github.com/leanovate/gopter/prop"
+	"github.com/seatunnel/seatunnelX/internal/pkg/pagex"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 )
@@ -184,6 +186,71 @@ func TestCreateRejectsDuplicateIP(t *testing.T) {
 	}
 }
 
+// TestListRestrictsToCallerProjects tests that a project-scoped list keeps shared hosts and member projects only.
+func TestListRestrictsToCallerProjects(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := NewRepository(db)
+	ctx := context.Background()
+	for i, projectID := range []uint{0, 1, 2} {
+		host := &Host{Name: fmt.Sprintf("host-%d", i), HostType: HostTypeBareMetal, IPAddress: fmt.Sprintf("10.0.0.%d", i+1), ProjectID: projectID}
+		if err := repo.Create(ctx, host); err != nil {
+			t.Fatalf("create host failed: %v", err)
+		}
+	}
+
+	hosts, total, err := repo.List(ctx, &HostFilter{RestrictProjects: true, ProjectIDs: []uint{1}}, 0, time.Time{})
+	if err != nil {
+		t.Fatalf("List returned error: %v", err)
+	}
+	if total != 2 || len(hosts) != 2 {
+		t.Fatalf("expected shared and project-1 hosts, got total=%d hosts=%d", total, len(hosts))
+	}
+	for _, host := range hosts {
+		if host.ProjectID == 2 {
+			t.Fatalf("expected host of project 2 to be hidden, got %s", host.Name)
+		}
+	}
+	if _, total, _ := repo.List(ctx, &HostFilter{RestrictProjects: true}, 0, time.Time{}); total != 1 {
+		t.Fatalf("expected only the shared host without memberships, got %d", total)
+	}
+}
+
+func TestListSortsPagesAndFiltersByCluster(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+	if err := db.Exec("CREATE TABLE cluster_nodes (id INTEGER PRIMARY KEY, cluster_id INTEGER, host_id INTEGER)").Error; err != nil {
+		t.Fatalf("create cluster_nodes failed: %v", err)
+	}
+
+	repo := NewRepository(db)
+	ctx := context.Background()
+	for i, name := range []string{"charlie", "alpha", "bravo"} {
+		host := &Host{Name: name, HostType: HostTypeBareMetal, IPAddress: fmt.Sprintf("10.0.1.%d", i+1)}
+		if err := repo.Create(ctx, host); err != nil {
+			t.Fatalf("create host failed: %v", err)
+		}
+		if name != "bravo" {
+			db.Exec("INSERT INTO cluster_nodes (cluster_id, host_id) VALUES (7, ?)", host.ID)
+		}
+	}
+
+	sort, err := pagex.ParseSort("name", hostListSpec.Fields)
+	if err != nil {
+		t.Fatalf("ParseSort returned error: %v", err)
+	}
+	hosts, total, err := repo.List(ctx, &HostFilter{Sort: sort, Page: 2, PageSize: 2}, 0, time.Time{})
+	if err != nil || total != 3 || len(hosts) != 1 || hosts[0].Name != "charlie" {
+		t.Fatalf("expected second page with charlie, got total=%d hosts=%v err=%v", total, hosts, err)
+	}
+
+	hosts, total, err = repo.List(ctx, &HostFilter{ClusterID: 7, Sort: sort}, 0, time.Time{})
+	if err != nil || total != 2 || hosts[0].Name != "alpha" || hosts[1].Name != "charlie" {
+		t.Fatalf("expected cluster 7 hosts alpha and charlie, got total=%d hosts=%v err=%v", total, hosts, err)
+	}
+}
+
 // TestUpdateRejectsDuplicateIP tests that updating host IP to an existing one is rejected.
 func TestUpdateRejectsDuplicateIP(t *testing.T) {
 	db, cleanup := setupTestDB(t)
@@ -24,6 +24,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/seatunnel/seatunnelX/internal/apps/cluster"
@@ -41,13 +42,31 @@ type Service struct {
 	heartbeatTimeout time.Duration
 	controlPlaneAddr string
 	processStartedAt time.Time // process start time; online requires heartbeat after this
+
+	// Recycle bin state / 回收站状态
+	onHostPurged         func(context.Context, *Host, error) // optional hook for audit on purge
+	recycleRetention     time.Duration
+	recyclePurgeInterval time.Duration
+	recyclePurgeRuntime  sync.Once
+
+	// Agent enrollment / Agent 注册令牌
+	enrollmentTokenTTL     time.Duration
+	requireEnrollmentToken bool
 }
 
 // ServiceConfig holds configuration for the Host Service.
 // ServiceConfig 保存 Host Service 的配置。
 type ServiceConfig struct {
-	HeartbeatTimeout time.Duration
-	ControlPlaneAddr string
+	HeartbeatTimeout     time.Duration
+	ControlPlaneAddr     string
+	RecycleRetention     time.Duration
+	RecyclePurgeInterval time.Duration
+	// EnrollmentTokenTTL is how long a generated install command stays usable (default 1 hour).
+	// EnrollmentTokenTTL 是生成的安装命令的有效期（默认 1 小时）。
+	EnrollmentTokenTTL time.Duration
+	// RequireEnrollmentToken rejects registrations of unknown Agents that present no enrollment token.
+	// RequireEnrollmentToken 拒绝未出示注册令牌的未知 Agent 注册。
+	RequireEnrollmentToken bool
 }
 
 // NewService creates a new Service instance.
@@ -55,6 +74,10 @@ type ServiceConfig struct {
 func NewService(repo *Repository, clusterRepo *cluster.Repository, cfg *ServiceConfig) *Service {
 	timeout := DefaultHeartbeatTimeout
 	controlPlaneAddr := "localhost:8000"
+	recycleRetention := DefaultRecycleRetention
+	recyclePurgeInterval := DefaultRecyclePurgeInterval
+	enrollmentTokenTTL := DefaultEnrollmentTokenTTL
+	requireEnrollmentToken := false
 
 	if cfg != nil {
 		if cfg.HeartbeatTimeout > 0 {
@@ -63,14 +86,29 @@ func NewService(repo *Repository, clusterRepo *cluster.Repository, cfg *ServiceC
 		if cfg.ControlPlaneAddr != "" {
 			controlPlaneAddr = cfg.ControlPlaneAddr
 		}
+		if cfg.RecycleRetention > 0 {
+			recycleRetention = cfg.RecycleRetention
+		}
+		if cfg.RecyclePurgeInterval > 0 {
+			recyclePurgeInterval = cfg.RecyclePurgeInterval
+		}
+		if cfg.EnrollmentTokenTTL > 0 {
+			enrollmentTokenTTL = cfg.EnrollmentTokenTTL
+		}
+		requireEnrollmentToken = cfg.RequireEnrollmentToken
 	}
 
 	return &Service{
-		repo:             repo,
-		clusterRepo:      clusterRepo,
-		heartbeatTimeout: timeout,
-		controlPlaneAddr: controlPlaneAddr,
-		processStartedAt: time.Now(),
+		repo:                 repo,
+		clusterRepo:          clusterRepo,
+		heartbeatTimeout:     timeout,
+		controlPlaneAddr:     controlPlaneAddr,
+		processStartedAt:     time.Now(),
+		recycleRetention:     recycleRetention,
+		recyclePurgeInterval: recyclePurgeInterval,
+
+		enrollmentTokenTTL:     enrollmentTokenTTL,
+		requireEnrollmentToken: requireEnrollmentToken,
 	}
 }
 
@@ -83,6 +121,21 @@ func (s *Service) GetProcessStartedAt() time.Time {
 // Create 创建一个新主机并进行验证。
 // Requirements: 3.1 - Validates host name uniqueness and IP address format.
 func (s *Service) Create(ctx context.Context, req *CreateHostRequest) (*Host, error) {
+	host, err := s.buildHost(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Create(ctx, host); err != nil {
+		return nil, err
+	}
+
+	return host, nil
+}
+
+// buildHost validates a create request and builds the host record without persisting it.
+// buildHost 校验创建请求并构建主机记录（不落库）。
+func (s *Service) buildHost(req *CreateHostRequest) (*Host, error) {
 	// Validate host name is not empty
 	// 验证主机名不为空
 	if req.Name == "" {
@@ -102,6 +155,10 @@ func (s *Service) Create(ctx context.Context, req *CreateHostRequest) (*Host, er
 		return nil, ErrHostTypeInvalid
 	}
 
+	if err := validateHostLabels(req.Labels); err != nil {
+		return nil, err
+	}
+
 	// Create host based on type
 	// 根据类型创建主机
 	host := &Host{
@@ -109,6 +166,8 @@ func (s *Service) Create(ctx context.Context, req *CreateHostRequest) (*Host, er
 		HostType:    hostType,
 		Description: req.Description,
 		Status:      HostStatusPending,
+		Labels:      req.Labels,
+		ProjectID:   req.ProjectID,
 	}
 
 	// Validate and set type-specific fields
@@ -128,10 +187,6 @@ func (s *Service) Create(ctx context.Context, req *CreateHostRequest) (*Host, er
 		}
 	}
 
-	if err := s.repo.Create(ctx, host); err != nil {
-		return nil, err
-	}
-
 	return host, nil
 }
 
@@ -153,6 +208,7 @@ func (s *Service) validateBareMetalHost(req *CreateHostRequest, host *Host) erro
 
 	host.IPAddress = req.IPAddress
 	host.SSHPort = sshPort
+	host.SSHUser = strings.TrimSpace(req.SSHUser)
 	host.AgentStatus = AgentStatusNotInstalled
 	return nil
 }
@@ -266,6 +322,13 @@ func (s *Service) Update(ctx context.Context, id uint, req *UpdateHostRequest) (
 		host.Description = *req.Description
 	}
 
+	if req.Labels != nil {
+		if err := validateHostLabels(*req.Labels); err != nil {
+			return nil, err
+		}
+		host.Labels = *req.Labels
+	}
+
 	// Update type-specific fields based on host type
 	// 根据主机类型更新特定字段
 	switch host.HostType {
@@ -303,6 +366,10 @@ func (s *Service) updateBareMetalFields(req *UpdateHostRequest, host *Host) erro
 	if req.SSHPort != nil {
 		host.SSHPort = *req.SSHPort
 	}
+
+	if req.SSHUser != nil {
+		host.SSHUser = strings.TrimSpace(*req.SSHUser)
+	}
 	return nil
 }
 
@@ -354,11 +421,20 @@ func (s *Service) updateK8sFields(req *UpdateHostRequest, host *Host) error {
 // Delete 在检查集群关联后删除主机。
 // Requirements: 3.6 - Checks if host is associated with clusters before deletion.
 func (s *Service) Delete(ctx context.Context, id uint) error {
+	if _, err := s.getDeletable(ctx, id); err != nil {
+		return err
+	}
+	return s.repo.Delete(ctx, id)
+}
+
+// getDeletable returns the host if it exists and is not associated with any cluster.
+// getDeletable 在主机存在且未关联任何集群时返回该主机。
+func (s *Service) getDeletable(ctx context.Context, id uint) (*Host, error) {
 	// Check if host exists
 	// 检查主机是否存在
-	_, err := s.repo.GetByID(ctx, id)
+	host, err := s.repo.GetByID(ctx, id)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Check if host is associated with any clusters
@@ -366,14 +442,13 @@ func (s *Service) Delete(ctx context.Context, id uint) error {
 	if s.clusterRepo != nil {
 		clusters, err := s.clusterRepo.GetClustersWithHostID(ctx, id)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		if len(clusters) > 0 {
-			return ErrHostHasCluster
+			return nil, ErrHostHasCluster
 		}
 	}
-
-	return s.repo.Delete(ctx, id)
+	return host, nil
 }
 
 // GetAssociatedClusters returns the list of clusters associated with a host.
@@ -392,9 +467,12 @@ func (s *Service) GetAssociatedClusters(ctx context.Context, hostID uint) ([]*cl
 // and heartbeat updates can find the host (fixes "host not found" after Control Plane restart).
 // hostname is optional; when auto-creating, used for host name or fallback to "agent-{agentID}".
 func (s *Service) UpdateAgentStatus(ctx context.Context, ipAddress string, agentID string, version string, systemInfo *SystemInfo, hostname string) (*Host, error) {
-	// Find host by IP address
-	// 根据 IP 地址查找主机
+	// Find host by IP address, falling back to the host the Agent enrolled for
+	// 根据 IP 地址查找主机，找不到时回退到 Agent 通过注册令牌绑定的主机
 	host, err := s.repo.GetByIP(ctx, ipAddress)
+	if errors.Is(err, ErrHostNotFound) && agentID != "" {
+		host, err = s.repo.GetByAgentID(ctx, agentID)
+	}
 	if err != nil {
 		if errors.Is(err, ErrHostNotFound) {
 			// Auto-create host when no matching IP exists (e.g. after Control Plane restart,
@@ -541,26 +619,15 @@ func (s *Service) IsHostOnline(ctx context.Context, hostID uint) (bool, error) {
 	return host.IsOnline(s.heartbeatTimeout), nil
 }
 
-// GetInstallCommand generates the Agent installation command for a host.
-// GetInstallCommand 为主机生成 Agent 安装命令。
-// Requirements: 2.1 - Returns shell script with auto-detection logic and Control Plane address.
-func (s *Service) GetInstallCommand(ctx context.Context, hostID uint) (string, error) {
-	// Verify host exists
-	// 验证主机存在
-	_, err := s.repo.GetByID(ctx, hostID)
-	if err != nil {
-		return "", err
-	}
-
-	// Generate installation command
-	// 生成安装命令
-	// The command uses curl to download and execute the install script from Control Plane
-	// 该命令使用 curl 从 Control Plane 下载并执行安装脚本
+// installCommand builds the Agent installation command.
+// installCommand 构建 Agent 安装命令。
+func (s *Service) installCommand(enrollmentToken string) string {
+	// The command uses curl to download and execute the install script from Control Plane;
+	// the script hands the enrollment token to the Agent, which presents it when registering
+	// 该命令使用 curl 从 Control Plane 下载并执行安装脚本；脚本将注册令牌交给 Agent，由 Agent 在注册时出示
 	// controlPlaneAddr should be a full URL like "http://192.168.1.100:8000"
 	// controlPlaneAddr 应该是完整的 URL，如 "http://192.168.1.100:8000"
-	installCmd := fmt.Sprintf("curl -sSL %s/api/v1/agent/install.sh | bash", s.controlPlaneAddr)
-
-	return installCmd, nil
+	return fmt.Sprintf("curl -sSL %s/api/v1/agent/install.sh | ENROLLMENT_TOKEN=%s bash", s.controlPlaneAddr, enrollmentToken)
 }
 
 // SystemInfo represents system information reported by an Agent.
@@ -599,6 +666,9 @@ func (s *Service) GetHostByID(ctx context.Context, id uint) (*cluster.HostInfo,
 		AgentStatus:      string(host.AgentStatus),
 		LastHeartbeat:    host.LastHeartbeat,
 		ProcessStartedAt: &startedAt,
+		Labels:           host.Labels,
+		ProjectID:        host.ProjectID,
+		Maintenance:      host.Maintenance,
 	}, nil
 }
 
@@ -53,7 +53,7 @@ func setupServiceTestDB(t *testing.T) (*gorm.DB, func()) {
 
 	// Auto-migrate models
 	// 自动迁移模型
-	if err := db.AutoMigrate(&Host{}, &cluster.Cluster{}, &cluster.ClusterNode{}); err != nil {
+	if err := db.AutoMigrate(&Host{}, &EnrollmentToken{}, &cluster.Cluster{}, &cluster.ClusterNode{}); err != nil {
 		os.RemoveAll(tempDir)
 		t.Fatalf("Failed to migrate: %v", err)
 	}
@@ -802,10 +802,11 @@ func TestGetInstallCommand(t *testing.T) {
 
 	// Get install command
 	// 获取安装命令
-	cmd, err := svc.GetInstallCommand(ctx, host.ID)
+	installCmd, err := svc.GetInstallCommand(ctx, host.ID)
 	if err != nil {
 		t.Fatalf("Failed to get install command: %v", err)
 	}
+	cmd := installCmd.Command
 
 	// Verify command contains the control plane address
 	// 验证命令包含 Control Plane 地址
@@ -819,6 +820,15 @@ func TestGetInstallCommand(t *testing.T) {
 	if !containsString(cmd, "curl") {
 		t.Errorf("Install command should contain 'curl', got: %s", cmd)
 	}
+
+	// Verify command carries an enrollment token that expires
+	// 验证命令携带会过期的注册令牌
+	if !containsString(cmd, "ENROLLMENT_TOKEN=") {
+		t.Errorf("Install command should carry an enrollment token, got: %s", cmd)
+	}
+	if !installCmd.ExpiresAt.After(time.Now()) {
+		t.Errorf("Install command should expire in the future, got: %v", installCmd.ExpiresAt)
+	}
 }
 
 // containsString checks if a string contains a substring
@@ -0,0 +1,172 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sshdeploy
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// defaultDialTimeout bounds TCP connect and SSH handshake.
+// defaultDialTimeout 限制 TCP 连接与 SSH 握手的时间。
+const defaultDialTimeout = 15 * time.Second
+
+// Target describes how to reach and log in to a host.
+// Target 描述如何连接并登录主机。
+type Target struct {
+	Addr       string
+	Username   string
+	AuthType   AuthType
+	Password   string
+	PrivateKey string
+	Passphrase string
+	// HostKeyFingerprint, when set, must match the server key (SHA256 format).
+	// HostKeyFingerprint 非空时，服务端公钥必须与之匹配（SHA256 格式）。
+	HostKeyFingerprint string
+}
+
+// Session runs commands on a connected host.
+// Session 在已连接的主机上执行命令。
+type Session interface {
+	// Run executes cmd, feeding stdin (may be nil) and writing stdout/stderr to output.
+	// Run 执行 cmd，stdin 可为 nil，stdout/stderr 写入 output。
+	Run(ctx context.Context, cmd string, stdin io.Reader, output io.Writer) error
+	Close() error
+}
+
+// Dialer opens sessions; it is an interface so tests can replace SSH.
+// Dialer 用于建立会话；定义为接口以便测试替换 SSH 实现。
+type Dialer interface {
+	// Dial connects to target and returns the session and the server key fingerprint.
+	// Dial 连接目标主机，返回会话及服务端公钥指纹。
+	Dial(ctx context.Context, target *Target) (Session, string, error)
+}
+
+// sshDialer is the Dialer backed by golang.org/x/crypto/ssh.
+// sshDialer 是基于 golang.org/x/crypto/ssh 的 Dialer 实现。
+type sshDialer struct {
+	timeout time.Duration
+}
+
+// NewSSHDialer creates the default SSH Dialer.
+// NewSSHDialer 创建默认的 SSH Dialer。
+func NewSSHDialer() Dialer {
+	return &sshDialer{timeout: defaultDialTimeout}
+}
+
+// Dial implements Dialer.
+// Dial 实现 Dialer 接口。
+func (d *sshDialer) Dial(ctx context.Context, target *Target) (Session, string, error) {
+	auth, err := authMethod(target)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var fingerprint string
+	clientConfig := &ssh.ClientConfig{
+		User:    target.Username,
+		Auth:    []ssh.AuthMethod{auth},
+		Timeout: d.timeout,
+		HostKeyCallback: func(_ string, _ net.Addr, key ssh.PublicKey) error {
+			fingerprint = ssh.FingerprintSHA256(key)
+			if target.HostKeyFingerprint != "" && target.HostKeyFingerprint != fingerprint {
+				return fmt.Errorf("%w: expected %s, got %s", ErrHostKeyMismatch, target.HostKeyFingerprint, fingerprint)
+			}
+			return nil
+		},
+	}
+
+	dialer := &net.Dialer{Timeout: d.timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", target.Addr)
+	if err != nil {
+		return nil, "", fmt.Errorf("connect %s: %w", target.Addr, err)
+	}
+	_ = conn.SetDeadline(time.Now().Add(d.timeout))
+	sshConn, chans, reqs, err := ssh.NewClientConn(conn, target.Addr, clientConfig)
+	if err != nil {
+		conn.Close()
+		return nil, fingerprint, fmt.Errorf("ssh handshake with %s: %w", target.Addr, err)
+	}
+	_ = conn.SetDeadline(time.Time{})
+	return &sshSession{client: ssh.NewClient(sshConn, chans, reqs)}, fingerprint, nil
+}
+
+// authMethod builds the ssh.AuthMethod of a target.
+// authMethod 构建目标主机的认证方式。
+func authMethod(target *Target) (ssh.AuthMethod, error) {
+	switch target.AuthType {
+	case AuthTypePassword:
+		return ssh.Password(target.Password), nil
+	case AuthTypeKey:
+		var signer ssh.Signer
+		var err error
+		if target.Passphrase != "" {
+			signer, err = ssh.ParsePrivateKeyWithPassphrase([]byte(target.PrivateKey), []byte(target.Passphrase))
+		} else {
+			signer, err = ssh.ParsePrivateKey([]byte(target.PrivateKey))
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: parse private key: %v", ErrCredentialInvalid, err)
+		}
+		return ssh.PublicKeys(signer), nil
+	default:
+		return nil, fmt.Errorf("%w: unsupported auth type %q", ErrCredentialInvalid, target.AuthType)
+	}
+}
+
+// sshSession runs each command in a new SSH session on a shared client.
+// sshSession 在共享的 SSH 客户端上为每条命令创建新会话。
+type sshSession struct {
+	client *ssh.Client
+}
+
+// Run implements Session.
+// Run 实现 Session 接口。
+func (s *sshSession) Run(ctx context.Context, cmd string, stdin io.Reader, output io.Writer) error {
+	session, err := s.client.NewSession()
+	if err != nil {
+		return fmt.Errorf("open ssh session: %w", err)
+	}
+	defer session.Close()
+
+	session.Stdin = stdin
+	session.Stdout = output
+	session.Stderr = output
+
+	done := make(chan error, 1)
+	go func() { done <- session.Run(cmd) }()
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		_ = session.Signal(ssh.SIGKILL)
+		_ = session.Close()
+		return ctx.Err()
+	}
+}
+
+// Close implements Session.
+// Close 实现 Session 接口。
+func (s *sshSession) Close() error {
+	return s.client.Close()
+}
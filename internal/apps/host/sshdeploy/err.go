@@ -0,0 +1,49 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sshdeploy
+
+import "errors"
+
+// Error definitions for SSH deployment.
+// SSH 部署的错误定义。
+var (
+	// ErrCredentialNotFound indicates the host has no saved SSH credential.
+	// ErrCredentialNotFound 表示主机没有保存 SSH 凭证。
+	ErrCredentialNotFound = errors.New("sshdeploy: ssh credential not found")
+	// ErrCredentialInvalid indicates the credential request is incomplete or malformed.
+	// ErrCredentialInvalid 表示凭证请求不完整或格式错误。
+	ErrCredentialInvalid = errors.New("sshdeploy: invalid ssh credential")
+	// ErrSecretMissing indicates credential encryption is not initialised.
+	// ErrSecretMissing 表示凭证加密未初始化。
+	ErrSecretMissing = errors.New("sshdeploy: credential encryption is not configured")
+	// ErrCredentialNotSealed indicates a stored credential was not sealed with the master key.
+	// ErrCredentialNotSealed 表示保存的凭证未使用主密钥加密。
+	ErrCredentialNotSealed = errors.New("sshdeploy: stored ssh credential is not encrypted with the master key, save it again")
+	// ErrHostNotBareMetal indicates the target host is not a bare_metal host.
+	// ErrHostNotBareMetal 表示目标主机不是物理机/VM 类型。
+	ErrHostNotBareMetal = errors.New("sshdeploy: agent can only be deployed to bare_metal hosts")
+	// ErrDeployInProgress indicates another deployment of the host is still running.
+	// ErrDeployInProgress 表示该主机已有部署任务在执行。
+	ErrDeployInProgress = errors.New("sshdeploy: a deployment is already in progress for this host")
+	// ErrTaskNotFound indicates the deployment task does not exist.
+	// ErrTaskNotFound 表示部署任务不存在。
+	ErrTaskNotFound = errors.New("sshdeploy: deploy task not found")
+	// ErrHostKeyMismatch indicates the host key differs from the pinned fingerprint.
+	// ErrHostKeyMismatch 表示主机公钥与已固定的指纹不一致。
+	ErrHostKeyMismatch = errors.New("sshdeploy: ssh host key does not match the pinned fingerprint")
+)
@@ -0,0 +1,274 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sshdeploy
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/seatunnel/seatunnelX/internal/apps/audit"
+	"github.com/seatunnel/seatunnelX/internal/apps/auth"
+	"github.com/seatunnel/seatunnelX/internal/apps/host"
+	"github.com/seatunnel/seatunnelX/internal/apps/response"
+	"github.com/seatunnel/seatunnelX/internal/logger"
+	"github.com/seatunnel/seatunnelX/internal/pkg/errcode"
+)
+
+// Handler provides HTTP handlers for SSH credentials and Agent deployment.
+// Handler 提供 SSH 凭证与 Agent 部署的 HTTP 处理器。
+type Handler struct {
+	service   *Service
+	auditRepo *audit.Repository
+}
+
+// NewHandler creates a new Handler instance.
+// NewHandler 创建一个新的 Handler 实例。
+// auditRepo may be nil; audit logging is skipped when nil.
+func NewHandler(service *Service, auditRepo *audit.Repository) *Handler {
+	return &Handler{service: service, auditRepo: auditRepo}
+}
+
+// ==================== Request/Response Types 请求/响应类型 ====================
+
+// CredentialResponse represents the response carrying an SSH credential.
+// CredentialResponse 表示返回 SSH 凭证的响应。
+type CredentialResponse struct {
+	response.Meta
+	Data *CredentialInfo `json:"data"`
+}
+
+// DeployTaskResponse represents the response carrying a deploy task.
+// DeployTaskResponse 表示返回部署任务的响应。
+type DeployTaskResponse struct {
+	response.Meta
+	Data *DeployTask `json:"data"`
+}
+
+// ListDeployTasksResponse represents the response for listing deploy tasks.
+// ListDeployTasksResponse 表示部署任务列表的响应。
+type ListDeployTasksResponse struct {
+	response.Meta
+	Data []*DeployTask `json:"data"`
+}
+
+// ==================== Handlers 处理器 ====================
+
+// SaveCredential handles PUT /api/v1/hosts/:id/ssh-credential - saves the SSH login of a host.
+// SaveCredential 处理 PUT /api/v1/hosts/:id/ssh-credential - 保存主机的 SSH 登录信息。
+// @Tags hosts
+// @Accept json
+// @Produce json
+// @Param id path int true "主机ID"
+// @Param request body SaveCredentialRequest true "SSH 凭证"
+// @Success 200 {object} CredentialResponse
+// @Router /api/v1/hosts/{id}/ssh-credential [put]
+func (h *Handler) SaveCredential(c *gin.Context) {
+	// SSH credentials grant root-level host access and require manage permission.
+	// SSH 凭证可获得主机的高权限访问，需要管理权限。
+	if !auth.Authorize(c, auth.PermissionManage) {
+		return
+	}
+	hostID, ok := parseUintParam(c, "id")
+	if !ok {
+		response.Error(c, http.StatusBadRequest, "无效的主机 ID / Invalid host ID")
+		return
+	}
+
+	var req SaveCredentialRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	cred, err := h.service.SaveCredential(c.Request.Context(), hostID, &req)
+	if err != nil {
+		response.Error(c, errorStatus(c, err), err.Error())
+		return
+	}
+
+	_ = audit.RecordFromGin(c, h.auditRepo, auth.GetUserIDFromContext(c), auth.GetUsernameFromContext(c),
+		"update", "host_ssh_credential", audit.UintID(hostID), cred.Username, audit.AuditDetails{"auth_type": string(cred.AuthType)})
+	response.OK(c, cred.ToInfo())
+}
+
+// GetCredential handles GET /api/v1/hosts/:id/ssh-credential - gets the SSH login of a host without secrets.
+// GetCredential 处理 GET /api/v1/hosts/:id/ssh-credential - 获取主机的 SSH 登录信息（不含密文）。
+// @Tags hosts
+// @Produce json
+// @Param id path int true "主机ID"
+// @Success 200 {object} CredentialResponse
+// @Router /api/v1/hosts/{id}/ssh-credential [get]
+func (h *Handler) GetCredential(c *gin.Context) {
+	hostID, ok := parseUintParam(c, "id")
+	if !ok {
+		response.Error(c, http.StatusBadRequest, "无效的主机 ID / Invalid host ID")
+		return
+	}
+
+	cred, err := h.service.GetCredential(c.Request.Context(), hostID)
+	if err != nil {
+		response.Error(c, errorStatus(c, err), err.Error())
+		return
+	}
+	response.OK(c, cred.ToInfo())
+}
+
+// DeleteCredential handles DELETE /api/v1/hosts/:id/ssh-credential - removes the SSH login of a host.
+// DeleteCredential 处理 DELETE /api/v1/hosts/:id/ssh-credential - 删除主机的 SSH 登录信息。
+// @Tags hosts
+// @Produce json
+// @Param id path int true "主机ID"
+// @Success 200 {object} CredentialResponse
+// @Router /api/v1/hosts/{id}/ssh-credential [delete]
+func (h *Handler) DeleteCredential(c *gin.Context) {
+	hostID, ok := parseUintParam(c, "id")
+	if !ok {
+		response.Error(c, http.StatusBadRequest, "无效的主机 ID / Invalid host ID")
+		return
+	}
+
+	if err := h.service.DeleteCredential(c.Request.Context(), hostID); err != nil {
+		response.Error(c, errorStatus(c, err), err.Error())
+		return
+	}
+
+	_ = audit.RecordFromGin(c, h.auditRepo, auth.GetUserIDFromContext(c), auth.GetUsernameFromContext(c),
+		"delete", "host_ssh_credential", audit.UintID(hostID), "", nil)
+	response.OK(c, nil)
+}
+
+// StartDeploy handles POST /api/v1/hosts/:id/ssh-deploy - deploys the Agent over SSH in the background.
+// StartDeploy 处理 POST /api/v1/hosts/:id/ssh-deploy - 在后台通过 SSH 部署 Agent。
+// @Tags hosts
+// @Produce json
+// @Param id path int true "主机ID"
+// @Success 200 {object} DeployTaskResponse
+// @Router /api/v1/hosts/{id}/ssh-deploy [post]
+func (h *Handler) StartDeploy(c *gin.Context) {
+	if !auth.Authorize(c, auth.PermissionManage) {
+		return
+	}
+	hostID, ok := parseUintParam(c, "id")
+	if !ok {
+		response.Error(c, http.StatusBadRequest, "无效的主机 ID / Invalid host ID")
+		return
+	}
+
+	task, err := h.service.StartDeploy(c.Request.Context(), hostID, auth.GetUsernameFromContext(c))
+	if err != nil {
+		response.Error(c, errorStatus(c, err), err.Error())
+		return
+	}
+
+	_ = audit.RecordFromGin(c, h.auditRepo, auth.GetUserIDFromContext(c), auth.GetUsernameFromContext(c),
+		"deploy_agent", "host", audit.UintID(hostID), "", audit.AuditDetails{"trigger": "ssh", "task_id": task.ID})
+	logger.InfoF(c.Request.Context(), "[SSHDeploy] 已创建 Agent 部署任务 %d (host %d)", task.ID, hostID)
+	response.OK(c, task)
+}
+
+// ListTasks handles GET /api/v1/hosts/:id/ssh-deploy/tasks - lists recent deploy tasks without logs.
+// ListTasks 处理 GET /api/v1/hosts/:id/ssh-deploy/tasks - 获取最近的部署任务（不含日志）。
+// @Tags hosts
+// @Produce json
+// @Param id path int true "主机ID"
+// @Success 200 {object} ListDeployTasksResponse
+// @Router /api/v1/hosts/{id}/ssh-deploy/tasks [get]
+func (h *Handler) ListTasks(c *gin.Context) {
+	hostID, ok := parseUintParam(c, "id")
+	if !ok {
+		response.Error(c, http.StatusBadRequest, "无效的主机 ID / Invalid host ID")
+		return
+	}
+
+	tasks, err := h.service.ListTasks(c.Request.Context(), hostID)
+	if err != nil {
+		response.Error(c, errorStatus(c, err), err.Error())
+		return
+	}
+	response.OK(c, tasks)
+}
+
+// GetTask handles GET /api/v1/hosts/:id/ssh-deploy/tasks/:taskId - gets a deploy task with its log.
+// GetTask 处理 GET /api/v1/hosts/:id/ssh-deploy/tasks/:taskId - 获取部署任务及其日志。
+// @Tags hosts
+// @Produce json
+// @Param id path int true "主机ID"
+// @Param taskId path int true "任务ID"
+// @Success 200 {object} DeployTaskResponse
+// @Router /api/v1/hosts/{id}/ssh-deploy/tasks/{taskId} [get]
+func (h *Handler) GetTask(c *gin.Context) {
+	hostID, ok := parseUintParam(c, "id")
+	if !ok {
+		response.Error(c, http.StatusBadRequest, "无效的主机 ID / Invalid host ID")
+		return
+	}
+	taskID, ok := parseUintParam(c, "taskId")
+	if !ok {
+		response.Error(c, http.StatusBadRequest, "无效的任务 ID / Invalid task ID")
+		return
+	}
+
+	task, err := h.service.GetTask(c.Request.Context(), hostID, taskID)
+	if err != nil {
+		response.Error(c, errorStatus(c, err), err.Error())
+		return
+	}
+	response.OK(c, task)
+}
+
+// ==================== Helper Methods 辅助方法 ====================
+
+func parseUintParam(c *gin.Context, name string) (uint, bool) {
+	value, err := strconv.ParseUint(c.Param(name), 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return uint(value), true
+}
+
+// errorStatus records the catalog code of err on the request and returns its HTTP status.
+// errorStatus 在请求上记录 err 的目录错误码并返回其 HTTP 状态码。
+func errorStatus(c *gin.Context, err error) int {
+	status := getStatusCodeForError(err)
+	code := errcode.ForHTTPStatus(status)
+	if errors.Is(err, host.ErrHostNotFound) {
+		code = errcode.HostNotFound
+	}
+	errcode.Attach(c, code)
+	return status
+}
+
+// getStatusCodeForError returns the appropriate HTTP status code for an error.
+// getStatusCodeForError 根据错误返回适当的 HTTP 状态码。
+func getStatusCodeForError(err error) int {
+	switch {
+	case errors.Is(err, host.ErrHostNotFound),
+		errors.Is(err, ErrCredentialNotFound),
+		errors.Is(err, ErrTaskNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrCredentialInvalid),
+		errors.Is(err, ErrHostNotBareMetal):
+		return http.StatusBadRequest
+	case errors.Is(err, ErrDeployInProgress):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,111 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sshdeploy
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"github.com/seatunnel/seatunnelX/internal/pkg/secrets"
+	"gorm.io/gorm"
+)
+
+// MigrateLegacyCredentials re-seals credentials written by earlier releases, which used their own
+// key derived from ssh_deploy.credential_secret and an unprefixed ciphertext, with box.
+// MigrateLegacyCredentials 使用 box 重新加密早期版本写入的凭证。早期版本使用由
+// ssh_deploy.credential_secret 派生的独立密钥，且密文没有格式前缀。
+func MigrateLegacyCredentials(tx *gorm.DB, legacySecret string, box *secrets.Box) error {
+	var creds []Credential
+	if err := tx.Find(&creds).Error; err != nil {
+		return err
+	}
+	for _, cred := range creds {
+		if isSealedOrEmpty(cred.EncryptedSecret) && isSealedOrEmpty(cred.EncryptedPassphrase) {
+			continue
+		}
+		if box == nil {
+			return fmt.Errorf("%w: cannot re-encrypt the ssh credential of host %d", ErrSecretMissing, cred.HostID)
+		}
+		secret, err := resealLegacySecret(cred.EncryptedSecret, legacySecret, box)
+		if err != nil {
+			return fmt.Errorf("host %d: %w", cred.HostID, err)
+		}
+		passphrase, err := resealLegacySecret(cred.EncryptedPassphrase, legacySecret, box)
+		if err != nil {
+			return fmt.Errorf("host %d: %w", cred.HostID, err)
+		}
+		if err := tx.Model(&Credential{}).Where("id = ?", cred.ID).UpdateColumns(map[string]interface{}{
+			"encrypted_secret":     secret,
+			"encrypted_passphrase": passphrase,
+		}).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func isSealedOrEmpty(value string) bool {
+	return value == "" || secrets.IsSealed(value)
+}
+
+// resealLegacySecret decrypts a legacy ciphertext and seals it with box.
+// resealLegacySecret 解密历史密文并使用 box 重新加密。
+func resealLegacySecret(value, legacySecret string, box *secrets.Box) (string, error) {
+	if isSealedOrEmpty(value) {
+		return value, nil
+	}
+	plaintext, err := openLegacySecret(value, legacySecret)
+	if err != nil {
+		return "", err
+	}
+	return box.Seal(plaintext)
+}
+
+// openLegacySecret decrypts base64(nonce || AES-256-GCM ciphertext) with the legacy key.
+// openLegacySecret 使用历史密钥解密 base64(nonce || AES-256-GCM 密文)。
+func openLegacySecret(encoded, legacySecret string) (string, error) {
+	if legacySecret == "" {
+		return "", errors.New("sshdeploy: ssh_deploy.credential_secret is required to read credentials saved by earlier releases")
+	}
+	key := sha256.Sum256([]byte("seatunnelx/sshdeploy/" + legacySecret))
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return "", err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return "", err
+	}
+	sealed, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", fmt.Errorf("sshdeploy: decode legacy secret: %w", err)
+	}
+	nonceSize := aead.NonceSize()
+	if len(sealed) < nonceSize {
+		return "", errors.New("sshdeploy: legacy secret is too short")
+	}
+	plaintext, err := aead.Open(nil, sealed[:nonceSize], sealed[nonceSize:], nil)
+	if err != nil {
+		return "", fmt.Errorf("sshdeploy: decrypt legacy secret (was ssh_deploy.credential_secret changed?): %w", err)
+	}
+	return string(plaintext), nil
+}
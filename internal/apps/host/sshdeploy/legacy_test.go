@@ -0,0 +1,118 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sshdeploy
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/glebarez/sqlite"
+	"github.com/seatunnel/seatunnelX/internal/apps/host"
+	"github.com/seatunnel/seatunnelX/internal/pkg/secrets"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// sealLegacySecret produces a ciphertext in the format written by earlier releases.
+// sealLegacySecret 生成早期版本写入格式的密文。
+func sealLegacySecret(t *testing.T, plaintext, legacySecret string) string {
+	t.Helper()
+	key := sha256.Sum256([]byte("seatunnelx/sshdeploy/" + legacySecret))
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		t.Fatal(err)
+	}
+	return base64.StdEncoding.EncodeToString(aead.Seal(nonce, nonce, []byte(plaintext), nil))
+}
+
+func TestMigrateLegacyCredentials(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "test.db")), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	if err := db.AutoMigrate(&Credential{}); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	box, err := secrets.NewBox(secrets.NewMasterKeyProvider("master-key"))
+	if err != nil {
+		t.Fatalf("NewBox: %v", err)
+	}
+	sealed, _ := box.Seal("already-sealed")
+	creds := []*Credential{
+		{HostID: 1, Username: "root", AuthType: AuthTypePassword, EncryptedSecret: sealLegacySecret(t, "legacy-pass", "old-secret")},
+		{HostID: 2, Username: "root", AuthType: AuthTypeKey, EncryptedSecret: sealLegacySecret(t, "legacy-key", "old-secret"), EncryptedPassphrase: sealLegacySecret(t, "legacy-phrase", "old-secret")},
+		{HostID: 3, Username: "root", AuthType: AuthTypePassword, EncryptedSecret: sealed},
+	}
+	if err := db.Create(&creds).Error; err != nil {
+		t.Fatalf("create credentials: %v", err)
+	}
+
+	if err := MigrateLegacyCredentials(db, "wrong-secret", box); err == nil {
+		t.Fatal("expected a wrong legacy secret to fail the migration")
+	}
+	if err := MigrateLegacyCredentials(db, "old-secret", nil); !errors.Is(err, ErrSecretMissing) {
+		t.Fatalf("expected ErrSecretMissing without a box, got %v", err)
+	}
+	if err := MigrateLegacyCredentials(db, "old-secret", box); err != nil {
+		t.Fatalf("MigrateLegacyCredentials: %v", err)
+	}
+
+	service := NewService(NewRepository(db), host.NewRepository(db), &ServiceConfig{Box: box})
+	want := map[uint][2]string{1: {"legacy-pass", ""}, 2: {"legacy-key", "legacy-phrase"}, 3: {"already-sealed", ""}}
+	for hostID, values := range want {
+		cred, err := service.GetCredential(context.Background(), hostID)
+		if err != nil {
+			t.Fatalf("GetCredential(%d): %v", hostID, err)
+		}
+		secret, err := service.openSecret(cred.EncryptedSecret)
+		if err != nil || secret != values[0] {
+			t.Fatalf("host %d secret = %q, %v; want %q", hostID, secret, err, values[0])
+		}
+		passphrase, err := service.openSecret(cred.EncryptedPassphrase)
+		if err != nil || passphrase != values[1] {
+			t.Fatalf("host %d passphrase = %q, %v; want %q", hostID, passphrase, err, values[1])
+		}
+	}
+	if _, err := service.openSecret(sealLegacySecret(t, "x", "old-secret")); !errors.Is(err, ErrCredentialNotSealed) {
+		t.Fatalf("expected unsealed secrets to be rejected, got %v", err)
+	}
+}
+
+func TestSaveCredentialRequiresEncryption(t *testing.T) {
+	service := NewService(nil, nil, &ServiceConfig{})
+	if _, err := service.SaveCredential(context.Background(), 1, &SaveCredentialRequest{AuthType: AuthTypePassword, Password: "x"}); !errors.Is(err, ErrSecretMissing) {
+		t.Fatalf("expected ErrSecretMissing before secrets.Init, got %v", err)
+	}
+}
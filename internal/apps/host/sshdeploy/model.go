@@ -0,0 +1,148 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package sshdeploy bootstraps the SeaTunnelX Agent on bare-metal hosts over SSH from the Control Plane.
+// sshdeploy 包负责由 Control Plane 通过 SSH 在物理机/VM 主机上部署 SeaTunnelX Agent。
+package sshdeploy
+
+import (
+	"time"
+)
+
+// AuthType is the SSH authentication method.
+// AuthType 表示 SSH 认证方式。
+type AuthType string
+
+const (
+	// AuthTypePassword authenticates with a password.
+	// AuthTypePassword 使用密码认证。
+	AuthTypePassword AuthType = "password"
+	// AuthTypeKey authenticates with a PEM private key.
+	// AuthTypeKey 使用 PEM 私钥认证。
+	AuthTypeKey AuthType = "key"
+)
+
+// TaskStatus is the status of a deployment task.
+// TaskStatus 表示部署任务的状态。
+type TaskStatus string
+
+const (
+	TaskStatusPending TaskStatus = "pending"
+	TaskStatusRunning TaskStatus = "running"
+	TaskStatusSuccess TaskStatus = "success"
+	TaskStatusFailed  TaskStatus = "failed"
+)
+
+// Deployment steps, in execution order.
+// 部署步骤（按执行顺序）。
+const (
+	StepConnect      = "connect"
+	StepDetect       = "detect_platform"
+	StepUploadBinary = "upload_binary"
+	StepUploadScript = "upload_script"
+	StepInstall      = "install"
+	StepCleanup      = "cleanup"
+)
+
+// Credential stores the SSH login of a host. Secrets are sealed with the secrets master key.
+// Credential 保存主机的 SSH 登录信息，密码/私钥使用 secrets 主密钥加密存储。
+type Credential struct {
+	ID       uint     `gorm:"primaryKey" json:"id"`
+	HostID   uint     `gorm:"uniqueIndex;not null" json:"host_id"`
+	Username string   `gorm:"size:64;not null" json:"username"`
+	AuthType AuthType `gorm:"size:16;not null" json:"auth_type"`
+	// EncryptedSecret is the encrypted password or private key.
+	// EncryptedSecret 是加密后的密码或私钥。
+	EncryptedSecret string `gorm:"type:text;not null" json:"-"`
+	// EncryptedPassphrase is the encrypted private key passphrase, if any.
+	// EncryptedPassphrase 是加密后的私钥口令（可选）。
+	EncryptedPassphrase string `gorm:"type:text" json:"-"`
+	// HostKeyFingerprint is pinned on the first successful connection (SHA256).
+	// HostKeyFingerprint 在首次成功连接时固定（SHA256）。
+	HostKeyFingerprint string    `gorm:"size:128" json:"host_key_fingerprint"`
+	CreatedAt          time.Time `json:"created_at"`
+	UpdatedAt          time.Time `json:"updated_at"`
+}
+
+// TableName specifies the table name for Credential.
+// TableName 指定 Credential 的表名。
+func (Credential) TableName() string {
+	return "host_ssh_credentials"
+}
+
+// DeployTask tracks one Agent deployment to a host, including the captured remote output.
+// DeployTask 记录一次向主机部署 Agent 的任务，包括采集到的远程输出。
+type DeployTask struct {
+	ID         uint       `gorm:"primaryKey" json:"id"`
+	HostID     uint       `gorm:"index;not null" json:"host_id"`
+	Status     TaskStatus `gorm:"size:16;index;not null" json:"status"`
+	Step       string     `gorm:"size:32" json:"step"`
+	Platform   string     `gorm:"size:32" json:"platform"`
+	Log        string     `gorm:"type:text" json:"log"`
+	Error      string     `gorm:"type:text" json:"error,omitempty"`
+	CreatedBy  string     `gorm:"size:64" json:"created_by"`
+	StartedAt  *time.Time `json:"started_at,omitempty"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+// TableName specifies the table name for DeployTask.
+// TableName 指定 DeployTask 的表名。
+func (DeployTask) TableName() string {
+	return "host_ssh_deploy_tasks"
+}
+
+// IsFinished reports whether the task reached a terminal status.
+// IsFinished 判断任务是否已结束。
+func (t *DeployTask) IsFinished() bool {
+	return t.Status == TaskStatusSuccess || t.Status == TaskStatusFailed
+}
+
+// SaveCredentialRequest saves the SSH login of a host.
+// SaveCredentialRequest 表示保存主机 SSH 登录信息的请求。
+type SaveCredentialRequest struct {
+	// Username defaults to the host's ssh_user, then root.
+	// Username 默认使用主机的 ssh_user，其次为 root。
+	Username   string   `json:"username"`
+	AuthType   AuthType `json:"auth_type" binding:"required"`
+	Password   string   `json:"password"`
+	PrivateKey string   `json:"private_key"`
+	Passphrase string   `json:"passphrase"`
+}
+
+// CredentialInfo is the credential view returned by the API; secrets are never returned.
+// CredentialInfo 是 API 返回的凭证信息，不包含任何密文。
+type CredentialInfo struct {
+	HostID             uint      `json:"host_id"`
+	Username           string    `json:"username"`
+	AuthType           AuthType  `json:"auth_type"`
+	HostKeyFingerprint string    `json:"host_key_fingerprint,omitempty"`
+	UpdatedAt          time.Time `json:"updated_at"`
+}
+
+// ToInfo converts a Credential to CredentialInfo.
+// ToInfo 将 Credential 转换为 CredentialInfo。
+func (c *Credential) ToInfo() *CredentialInfo {
+	return &CredentialInfo{
+		HostID:             c.HostID,
+		Username:           c.Username,
+		AuthType:           c.AuthType,
+		HostKeyFingerprint: c.HostKeyFingerprint,
+		UpdatedAt:          c.UpdatedAt,
+	}
+}
@@ -0,0 +1,141 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sshdeploy
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+// Repository provides data access for SSH credentials and deploy tasks.
+// Repository 提供 SSH 凭证与部署任务的数据访问。
+type Repository struct {
+	db *gorm.DB
+}
+
+// NewRepository creates a new Repository instance.
+// NewRepository 创建一个新的 Repository 实例。
+func NewRepository(db *gorm.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// SaveCredential creates or replaces the credential of a host.
+// SaveCredential 创建或替换主机的凭证。
+func (r *Repository) SaveCredential(ctx context.Context, cred *Credential) error {
+	existing, err := r.GetCredential(ctx, cred.HostID)
+	if err != nil && !errors.Is(err, ErrCredentialNotFound) {
+		return err
+	}
+	if existing != nil {
+		cred.ID = existing.ID
+		cred.CreatedAt = existing.CreatedAt
+	}
+	return r.db.WithContext(ctx).Save(cred).Error
+}
+
+// GetCredential returns the credential of a host.
+// GetCredential 获取主机的凭证。
+func (r *Repository) GetCredential(ctx context.Context, hostID uint) (*Credential, error) {
+	var cred Credential
+	if err := r.db.WithContext(ctx).Where("host_id = ?", hostID).First(&cred).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCredentialNotFound
+		}
+		return nil, err
+	}
+	return &cred, nil
+}
+
+// DeleteCredential removes the credential of a host.
+// DeleteCredential 删除主机的凭证。
+func (r *Repository) DeleteCredential(ctx context.Context, hostID uint) error {
+	result := r.db.WithContext(ctx).Where("host_id = ?", hostID).Delete(&Credential{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrCredentialNotFound
+	}
+	return nil
+}
+
+// PinHostKey records the host key fingerprint seen on the first connection.
+// PinHostKey 记录首次连接时的主机公钥指纹。
+func (r *Repository) PinHostKey(ctx context.Context, hostID uint, fingerprint string) error {
+	return r.db.WithContext(ctx).Model(&Credential{}).
+		Where("host_id = ? AND (host_key_fingerprint = '' OR host_key_fingerprint IS NULL)", hostID).
+		Update("host_key_fingerprint", fingerprint).Error
+}
+
+// CreateTask inserts a deploy task.
+// CreateTask 创建部署任务。
+func (r *Repository) CreateTask(ctx context.Context, task *DeployTask) error {
+	return r.db.WithContext(ctx).Create(task).Error
+}
+
+// UpdateTask persists all fields of a deploy task.
+// UpdateTask 保存部署任务的全部字段。
+func (r *Repository) UpdateTask(ctx context.Context, task *DeployTask) error {
+	return r.db.WithContext(ctx).Save(task).Error
+}
+
+// GetTask returns a deploy task of a host.
+// GetTask 获取主机的部署任务。
+func (r *Repository) GetTask(ctx context.Context, hostID, taskID uint) (*DeployTask, error) {
+	var task DeployTask
+	if err := r.db.WithContext(ctx).Where("id = ? AND host_id = ?", taskID, hostID).First(&task).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrTaskNotFound
+		}
+		return nil, err
+	}
+	return &task, nil
+}
+
+// ListTasks returns the latest deploy tasks of a host without their logs.
+// ListTasks 返回主机最近的部署任务（不含日志）。
+func (r *Repository) ListTasks(ctx context.Context, hostID uint, limit int) ([]*DeployTask, error) {
+	var tasks []*DeployTask
+	err := r.db.WithContext(ctx).
+		Omit("log").
+		Where("host_id = ?", hostID).
+		Order("id DESC").
+		Limit(limit).
+		Find(&tasks).Error
+	return tasks, err
+}
+
+// HasActiveTask reports whether the host has a pending or running deploy task.
+// HasActiveTask 判断主机是否有等待中或执行中的部署任务。
+func (r *Repository) HasActiveTask(ctx context.Context, hostID uint) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&DeployTask{}).
+		Where("host_id = ? AND status IN ?", hostID, []TaskStatus{TaskStatusPending, TaskStatusRunning}).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// FailActiveTasks marks tasks left pending/running (e.g. by a restart) as failed.
+// FailActiveTasks 将遗留的等待中/执行中任务（例如服务重启导致）标记为失败。
+func (r *Repository) FailActiveTasks(ctx context.Context, reason string) error {
+	return r.db.WithContext(ctx).Model(&DeployTask{}).
+		Where("status IN ?", []TaskStatus{TaskStatusPending, TaskStatusRunning}).
+		Updates(map[string]interface{}{"status": TaskStatusFailed, "error": reason}).Error
+}
@@ -0,0 +1,568 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sshdeploy
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/seatunnel/seatunnelX/internal/apps/host"
+	"github.com/seatunnel/seatunnelX/internal/logger"
+	"github.com/seatunnel/seatunnelX/internal/pkg/secrets"
+)
+
+const (
+	// remoteBinaryPath is where the Agent binary is pushed before install.sh picks it up.
+	// remoteBinaryPath 是 Agent 二进制文件推送到远端的位置，随后由 install.sh 使用。
+	remoteBinaryPath = "/tmp/seatunnelx-agent.ssh-upload"
+	// remoteScriptPath is where the generated install script is pushed.
+	// remoteScriptPath 是生成的安装脚本推送到远端的位置。
+	remoteScriptPath = "/tmp/seatunnelx-agent-install.sh"
+
+	defaultDeployTimeout = 15 * time.Minute
+	maxTaskLogBytes      = 256 * 1024
+	logFlushInterval     = 2 * time.Second
+	taskListLimit        = 20
+)
+
+// ServiceConfig holds the dependencies of the SSH deployer.
+// ServiceConfig 保存 SSH 部署器的依赖。
+type ServiceConfig struct {
+	// Box seals stored passwords and private keys (default: the process-wide secrets Box).
+	// Box 用于加密保存的密码和私钥（默认使用进程级 secrets Box）。
+	Box *secrets.Box
+	// Timeout bounds one deployment (default 15 minutes).
+	// Timeout 限制单次部署的时长（默认 15 分钟）。
+	Timeout time.Duration
+	// InstallScript returns the Agent install script.
+	// InstallScript 返回 Agent 安装脚本。
+	InstallScript func() (string, error)
+	// BinaryPath returns the local Agent binary for the platform reported by `uname -s` / `uname -m`.
+	// BinaryPath 根据 `uname -s` / `uname -m` 的输出返回本地 Agent 二进制文件路径。
+	BinaryPath func(osType, arch string) (string, error)
+	// EnrollmentToken issues the single-use enrollment token handed to the installed Agent (optional).
+	// EnrollmentToken 签发交给所安装 Agent 的一次性注册令牌（可选）。
+	EnrollmentToken func(ctx context.Context, hostID uint) (string, error)
+	// Dialer opens SSH sessions (default NewSSHDialer()).
+	// Dialer 用于建立 SSH 会话（默认 NewSSHDialer()）。
+	Dialer Dialer
+}
+
+// Service deploys the Agent to hosts over SSH.
+// Service 通过 SSH 向主机部署 Agent。
+type Service struct {
+	repo     *Repository
+	hostRepo *host.Repository
+	cfg      ServiceConfig
+	box      *secrets.Box
+	boxErr   error
+	wg       sync.WaitGroup
+}
+
+// NewService creates a new Service instance.
+// NewService 创建一个新的 Service 实例。
+func NewService(repo *Repository, hostRepo *host.Repository, cfg *ServiceConfig) *Service {
+	s := &Service{repo: repo, hostRepo: hostRepo}
+	if cfg != nil {
+		s.cfg = *cfg
+	}
+	if s.cfg.Timeout <= 0 {
+		s.cfg.Timeout = defaultDeployTimeout
+	}
+	if s.cfg.Dialer == nil {
+		s.cfg.Dialer = NewSSHDialer()
+	}
+	s.box = s.cfg.Box
+	if s.box == nil {
+		box, err := secrets.Default()
+		if err != nil {
+			s.boxErr = fmt.Errorf("%w: %v", ErrSecretMissing, err)
+		}
+		s.box = box
+	}
+	return s
+}
+
+// RecoverInterruptedTasks fails tasks left unfinished by a previous process.
+// RecoverInterruptedTasks 将上一个进程遗留的未完成任务标记为失败。
+func (s *Service) RecoverInterruptedTasks(ctx context.Context) error {
+	return s.repo.FailActiveTasks(ctx, "interrupted by Control Plane restart / Control Plane 重启导致任务中断")
+}
+
+// SaveCredential encrypts and stores the SSH login of a host.
+// Saving a credential clears the pinned host key, so it is also how a reinstalled host is re-trusted.
+// SaveCredential 加密并保存主机的 SSH 登录信息。
+// 保存凭证会清除已固定的主机公钥，因此重装系统后的主机也通过重新保存凭证来重新信任。
+func (s *Service) SaveCredential(ctx context.Context, hostID uint, req *SaveCredentialRequest) (*Credential, error) {
+	if s.boxErr != nil {
+		return nil, s.boxErr
+	}
+	h, err := s.getBareMetalHost(ctx, hostID)
+	if err != nil {
+		return nil, err
+	}
+
+	username := strings.TrimSpace(req.Username)
+	if username == "" {
+		username = h.SSHUser
+	}
+	if username == "" {
+		username = "root"
+	}
+
+	var secret string
+	switch req.AuthType {
+	case AuthTypePassword:
+		if req.Password == "" {
+			return nil, fmt.Errorf("%w: password is required", ErrCredentialInvalid)
+		}
+		secret = req.Password
+	case AuthTypeKey:
+		if strings.TrimSpace(req.PrivateKey) == "" {
+			return nil, fmt.Errorf("%w: private_key is required", ErrCredentialInvalid)
+		}
+		if _, err := authMethod(&Target{AuthType: AuthTypeKey, PrivateKey: req.PrivateKey, Passphrase: req.Passphrase}); err != nil {
+			return nil, err
+		}
+		secret = req.PrivateKey
+	default:
+		return nil, fmt.Errorf("%w: auth_type must be password or key", ErrCredentialInvalid)
+	}
+
+	encryptedSecret, err := s.box.Seal(secret)
+	if err != nil {
+		return nil, err
+	}
+	encryptedPassphrase := ""
+	if req.AuthType == AuthTypeKey {
+		if encryptedPassphrase, err = s.box.Seal(req.Passphrase); err != nil {
+			return nil, err
+		}
+	}
+
+	cred := &Credential{
+		HostID:              hostID,
+		Username:            username,
+		AuthType:            req.AuthType,
+		EncryptedSecret:     encryptedSecret,
+		EncryptedPassphrase: encryptedPassphrase,
+	}
+	if err := s.repo.SaveCredential(ctx, cred); err != nil {
+		return nil, err
+	}
+	return cred, nil
+}
+
+// GetCredential returns the stored credential of a host.
+// GetCredential 获取主机保存的凭证。
+func (s *Service) GetCredential(ctx context.Context, hostID uint) (*Credential, error) {
+	return s.repo.GetCredential(ctx, hostID)
+}
+
+// ExportCredential returns the decrypted credential of a host as a save request, so it can be
+// moved to another Control Plane and stored there with that side's master key.
+// ExportCredential 以保存请求的形式返回主机解密后的凭证，便于迁移到另一个控制面并使用其主密钥重新保存。
+func (s *Service) ExportCredential(ctx context.Context, hostID uint) (*SaveCredentialRequest, error) {
+	if s.boxErr != nil {
+		return nil, s.boxErr
+	}
+	cred, err := s.repo.GetCredential(ctx, hostID)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := s.openSecret(cred.EncryptedSecret)
+	if err != nil {
+		return nil, err
+	}
+	passphrase, err := s.openSecret(cred.EncryptedPassphrase)
+	if err != nil {
+		return nil, err
+	}
+	req := &SaveCredentialRequest{Username: cred.Username, AuthType: cred.AuthType}
+	if cred.AuthType == AuthTypePassword {
+		req.Password = secret
+	} else {
+		req.PrivateKey = secret
+		req.Passphrase = passphrase
+	}
+	return req, nil
+}
+
+// DeleteCredential removes the stored credential of a host.
+// DeleteCredential 删除主机保存的凭证。
+func (s *Service) DeleteCredential(ctx context.Context, hostID uint) error {
+	return s.repo.DeleteCredential(ctx, hostID)
+}
+
+// StartDeploy creates a deploy task and runs it in the background.
+// StartDeploy 创建部署任务并在后台执行。
+func (s *Service) StartDeploy(ctx context.Context, hostID uint, createdBy string) (*DeployTask, error) {
+	if s.boxErr != nil {
+		return nil, s.boxErr
+	}
+	h, err := s.getBareMetalHost(ctx, hostID)
+	if err != nil {
+		return nil, err
+	}
+	cred, err := s.repo.GetCredential(ctx, hostID)
+	if err != nil {
+		return nil, err
+	}
+	active, err := s.repo.HasActiveTask(ctx, hostID)
+	if err != nil {
+		return nil, err
+	}
+	if active {
+		return nil, ErrDeployInProgress
+	}
+
+	task := &DeployTask{HostID: hostID, Status: TaskStatusPending, CreatedBy: createdBy}
+	if err := s.repo.CreateTask(ctx, task); err != nil {
+		return nil, err
+	}
+
+	snapshot := *task
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.run(task, h, cred)
+	}()
+	return &snapshot, nil
+}
+
+// GetTask returns a deploy task of a host, including its log.
+// GetTask 获取主机的部署任务（含日志）。
+func (s *Service) GetTask(ctx context.Context, hostID, taskID uint) (*DeployTask, error) {
+	return s.repo.GetTask(ctx, hostID, taskID)
+}
+
+// ListTasks returns the latest deploy tasks of a host.
+// ListTasks 返回主机最近的部署任务。
+func (s *Service) ListTasks(ctx context.Context, hostID uint) ([]*DeployTask, error) {
+	return s.repo.ListTasks(ctx, hostID, taskListLimit)
+}
+
+func (s *Service) getBareMetalHost(ctx context.Context, hostID uint) (*host.Host, error) {
+	h, err := s.hostRepo.GetByID(ctx, hostID)
+	if err != nil {
+		return nil, err
+	}
+	if h.HostType != host.HostTypeBareMetal {
+		return nil, ErrHostNotBareMetal
+	}
+	return h, nil
+}
+
+// run executes a deploy task and records its outcome.
+// run 执行部署任务并记录结果。
+func (s *Service) run(task *DeployTask, h *host.Host, cred *Credential) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.cfg.Timeout)
+	defer cancel()
+
+	tracker := newTaskTracker(s.repo, task)
+	tracker.start()
+
+	err := s.deploy(ctx, tracker, h, cred)
+	tracker.finish(err)
+	if err != nil {
+		logger.WarnF(ctx, "[SSHDeploy] 部署 Agent 到主机 %s 失败 / deploy failed: %v", h.Name, err)
+		return
+	}
+	logger.InfoF(ctx, "[SSHDeploy] 部署 Agent 到主机 %s 成功 / deploy succeeded", h.Name)
+}
+
+// deploy connects to the host, pushes the binary and install script, then runs the script.
+// deploy 连接主机，推送二进制文件与安装脚本，然后执行脚本。
+func (s *Service) deploy(ctx context.Context, tracker *taskTracker, h *host.Host, cred *Credential) error {
+	tracker.step(StepConnect)
+	target, err := s.buildTarget(h, cred)
+	if err != nil {
+		return err
+	}
+	tracker.logf("connecting to %s as %s", target.Addr, target.Username)
+	session, fingerprint, err := s.cfg.Dialer.Dial(ctx, target)
+	if err != nil {
+		return err
+	}
+	defer session.Close()
+	if cred.HostKeyFingerprint == "" && fingerprint != "" {
+		if err := s.repo.PinHostKey(ctx, cred.HostID, fingerprint); err != nil {
+			return fmt.Errorf("pin host key: %w", err)
+		}
+		tracker.logf("pinned host key %s", fingerprint)
+	}
+
+	defer func() {
+		tracker.step(StepCleanup)
+		// Cleanup uses its own context so it still runs after a timeout.
+		// 清理使用独立的 context，超时后仍会执行。
+		cleanupCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		cmd := fmt.Sprintf("rm -f %s %s", shellQuote(remoteBinaryPath), shellQuote(remoteScriptPath))
+		if err := session.Run(cleanupCtx, cmd, nil, tracker); err != nil {
+			tracker.logf("cleanup failed: %v", err)
+		}
+	}()
+
+	tracker.step(StepDetect)
+	var platform bytes.Buffer
+	if err := session.Run(ctx, "uname -s && uname -m", nil, io.MultiWriter(&platform, tracker)); err != nil {
+		return fmt.Errorf("detect platform: %w", err)
+	}
+	fields := strings.Fields(platform.String())
+	if len(fields) < 2 {
+		return fmt.Errorf("detect platform: unexpected uname output %q", platform.String())
+	}
+	osType, arch := fields[0], fields[1]
+	tracker.setPlatform(strings.ToLower(osType) + "/" + strings.ToLower(arch))
+
+	tracker.step(StepUploadBinary)
+	binaryPath, err := s.cfg.BinaryPath(osType, arch)
+	if err != nil {
+		return err
+	}
+	if err := uploadFile(ctx, session, binaryPath, remoteBinaryPath, "0755", tracker); err != nil {
+		return fmt.Errorf("upload agent binary: %w", err)
+	}
+
+	tracker.step(StepUploadScript)
+	script, err := s.cfg.InstallScript()
+	if err != nil {
+		return fmt.Errorf("generate install script: %w", err)
+	}
+	if err := uploadContent(ctx, session, strings.NewReader(script), remoteScriptPath, "0700"); err != nil {
+		return fmt.Errorf("upload install script: %w", err)
+	}
+	tracker.logf("uploaded install script (%d bytes) to %s", len(script), remoteScriptPath)
+
+	tracker.step(StepInstall)
+	var enrollmentToken string
+	if s.cfg.EnrollmentToken != nil {
+		if enrollmentToken, err = s.cfg.EnrollmentToken(ctx, h.ID); err != nil {
+			return fmt.Errorf("issue enrollment token: %w", err)
+		}
+	}
+	cmd, stdin := installCommand(target, enrollmentToken)
+	if err := session.Run(ctx, cmd, stdin, tracker); err != nil {
+		return fmt.Errorf("run install script: %w", err)
+	}
+	tracker.logf("agent installed, it will register with the Control Plane shortly")
+	return nil
+}
+
+// openSecret decrypts a stored secret. Values that were never sealed are rejected instead of
+// being used as-is, since legacy ciphertext would otherwise be sent as the password.
+// openSecret 解密保存的密文。未加密的值会被拒绝而不是原样使用，否则历史密文会被当作密码发送。
+func (s *Service) openSecret(value string) (string, error) {
+	if value != "" && !secrets.IsSealed(value) {
+		return "", ErrCredentialNotSealed
+	}
+	return s.box.Open(value)
+}
+
+// buildTarget decrypts the credential into an SSH target.
+// buildTarget 解密凭证并构建 SSH 目标。
+func (s *Service) buildTarget(h *host.Host, cred *Credential) (*Target, error) {
+	secret, err := s.openSecret(cred.EncryptedSecret)
+	if err != nil {
+		return nil, err
+	}
+	passphrase, err := s.openSecret(cred.EncryptedPassphrase)
+	if err != nil {
+		return nil, err
+	}
+	port := h.SSHPort
+	if port == 0 {
+		port = 22
+	}
+	target := &Target{
+		Addr:               net.JoinHostPort(h.IPAddress, strconv.Itoa(port)),
+		Username:           cred.Username,
+		AuthType:           cred.AuthType,
+		HostKeyFingerprint: cred.HostKeyFingerprint,
+	}
+	if cred.AuthType == AuthTypePassword {
+		target.Password = secret
+	} else {
+		target.PrivateKey = secret
+		target.Passphrase = passphrase
+	}
+	return target, nil
+}
+
+// installCommand runs the install script as root, using sudo for other users.
+// Password logins feed the password to `sudo -S`; key logins require passwordless sudo.
+// installCommand 以 root 身份执行安装脚本，非 root 用户使用 sudo。
+// 密码登录通过 `sudo -S` 输入密码；密钥登录要求免密 sudo。
+func installCommand(target *Target, enrollmentToken string) (string, io.Reader) {
+	env := "AGENT_BINARY_FILE=" + shellQuote(remoteBinaryPath)
+	if enrollmentToken != "" {
+		env += " ENROLLMENT_TOKEN=" + shellQuote(enrollmentToken)
+	}
+	cmd := fmt.Sprintf("env %s bash %s", env, shellQuote(remoteScriptPath))
+	switch {
+	case target.Username == "root":
+		return cmd, nil
+	case target.AuthType == AuthTypePassword:
+		return "sudo -S -p '' " + cmd, strings.NewReader(target.Password + "\n")
+	default:
+		return "sudo -n " + cmd, nil
+	}
+}
+
+// uploadFile streams a local file to the remote path over the session's stdin.
+// uploadFile 通过会话的 stdin 将本地文件传输到远端路径。
+func uploadFile(ctx context.Context, session Session, localPath, remotePath, mode string, tracker *taskTracker) error {
+	file, err := os.Open(localPath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+	if err := uploadContent(ctx, session, file, remotePath, mode); err != nil {
+		return err
+	}
+	tracker.logf("uploaded %s (%d bytes) to %s", localPath, info.Size(), remotePath)
+	return nil
+}
+
+func uploadContent(ctx context.Context, session Session, content io.Reader, remotePath, mode string) error {
+	cmd := fmt.Sprintf("cat > %[1]s && chmod %[2]s %[1]s", shellQuote(remotePath), mode)
+	var output bytes.Buffer
+	if err := session.Run(ctx, cmd, content, &output); err != nil {
+		if msg := strings.TrimSpace(output.String()); msg != "" {
+			return fmt.Errorf("%w: %s", err, msg)
+		}
+		return err
+	}
+	return nil
+}
+
+// shellQuote single-quotes s for a POSIX shell.
+// shellQuote 为 POSIX shell 对 s 加单引号。
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// taskTracker serializes task updates and captures remote output into the task log.
+// It is an io.Writer so command output can be streamed into it.
+// taskTracker 串行化任务更新，并将远程输出写入任务日志。
+// 它实现了 io.Writer，命令输出可直接写入。
+type taskTracker struct {
+	mu        sync.Mutex
+	repo      *Repository
+	task      *DeployTask
+	log       strings.Builder
+	truncated bool
+	lastFlush time.Time
+}
+
+func newTaskTracker(repo *Repository, task *DeployTask) *taskTracker {
+	return &taskTracker{repo: repo, task: task}
+}
+
+func (t *taskTracker) start() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	now := time.Now()
+	t.task.Status = TaskStatusRunning
+	t.task.StartedAt = &now
+	t.flushLocked()
+}
+
+func (t *taskTracker) step(step string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.task.Step = step
+	t.appendLocked([]byte("==> " + step + "\n"))
+	t.flushLocked()
+}
+
+func (t *taskTracker) setPlatform(platform string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.task.Platform = platform
+}
+
+func (t *taskTracker) logf(format string, args ...interface{}) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.appendLocked([]byte(fmt.Sprintf(format, args...) + "\n"))
+}
+
+// Write implements io.Writer; the log is persisted at most every logFlushInterval.
+// Write 实现 io.Writer；日志最多每 logFlushInterval 持久化一次。
+func (t *taskTracker) Write(p []byte) (int, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.appendLocked(p)
+	if time.Since(t.lastFlush) >= logFlushInterval {
+		t.flushLocked()
+	}
+	return len(p), nil
+}
+
+func (t *taskTracker) finish(err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	now := time.Now()
+	t.task.FinishedAt = &now
+	t.task.Status = TaskStatusSuccess
+	if err != nil {
+		t.task.Status = TaskStatusFailed
+		t.task.Error = err.Error()
+		if errors.Is(err, context.DeadlineExceeded) {
+			t.task.Error = "deployment timed out / 部署超时: " + err.Error()
+		}
+		t.appendLocked([]byte("ERROR: " + t.task.Error + "\n"))
+	}
+	t.flushLocked()
+}
+
+func (t *taskTracker) appendLocked(p []byte) {
+	if t.truncated {
+		return
+	}
+	if t.log.Len()+len(p) > maxTaskLogBytes {
+		t.log.Write(p[:maxTaskLogBytes-t.log.Len()])
+		t.log.WriteString("\n... log truncated ...\n")
+		t.truncated = true
+		return
+	}
+	t.log.Write(p)
+}
+
+func (t *taskTracker) flushLocked() {
+	t.lastFlush = time.Now()
+	t.task.Log = t.log.String()
+	if err := t.repo.UpdateTask(context.Background(), t.task); err != nil {
+		logger.ErrorF(context.Background(), "[SSHDeploy] 保存部署任务失败 / failed to save deploy task %d: %v", t.task.ID, err)
+	}
+}
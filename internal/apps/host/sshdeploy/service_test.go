@@ -0,0 +1,269 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sshdeploy
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/glebarez/sqlite"
+	"github.com/seatunnel/seatunnelX/internal/apps/host"
+	"github.com/seatunnel/seatunnelX/internal/pkg/secrets"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type fakeCommand struct {
+	cmd   string
+	stdin string
+}
+
+// fakeDialer records every command and answers uname; failOn makes matching commands fail.
+type fakeDialer struct {
+	mu          sync.Mutex
+	targets     []*Target
+	commands    []fakeCommand
+	fingerprint string
+	failOn      string
+}
+
+func (d *fakeDialer) Dial(_ context.Context, target *Target) (Session, string, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.targets = append(d.targets, target)
+	if target.HostKeyFingerprint != "" && target.HostKeyFingerprint != d.fingerprint {
+		return nil, "", ErrHostKeyMismatch
+	}
+	return &fakeSession{dialer: d}, d.fingerprint, nil
+}
+
+type fakeSession struct {
+	dialer *fakeDialer
+}
+
+func (s *fakeSession) Run(_ context.Context, cmd string, stdin io.Reader, output io.Writer) error {
+	var input []byte
+	if stdin != nil {
+		input, _ = io.ReadAll(stdin)
+	}
+	s.dialer.mu.Lock()
+	s.dialer.commands = append(s.dialer.commands, fakeCommand{cmd: cmd, stdin: string(input)})
+	failOn := s.dialer.failOn
+	s.dialer.mu.Unlock()
+
+	if cmd == "uname -s && uname -m" {
+		_, _ = io.WriteString(output, "Linux\nx86_64\n")
+	}
+	if failOn != "" && strings.Contains(cmd, failOn) {
+		_, _ = io.WriteString(output, "install.sh: something went wrong\n")
+		return errors.New("exit status 1")
+	}
+	return nil
+}
+
+func (s *fakeSession) Close() error { return nil }
+
+func newTestService(t *testing.T, dialer Dialer) (*Service, *host.Repository) {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "test.db")), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	if err := db.AutoMigrate(&host.Host{}, &Credential{}, &DeployTask{}); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	t.Cleanup(func() {
+		if sqlDB, _ := db.DB(); sqlDB != nil {
+			sqlDB.Close()
+		}
+	})
+
+	binary := filepath.Join(t.TempDir(), "seatunnelx-agent-linux-amd64")
+	if err := os.WriteFile(binary, []byte("agent-binary"), 0755); err != nil {
+		t.Fatalf("write binary: %v", err)
+	}
+	box, err := secrets.NewBox(secrets.NewMasterKeyProvider("test-secret"))
+	if err != nil {
+		t.Fatalf("NewBox: %v", err)
+	}
+	hostRepo := host.NewRepository(db)
+	service := NewService(NewRepository(db), hostRepo, &ServiceConfig{
+		Box:           box,
+		InstallScript: func() (string, error) { return "#!/bin/bash\necho install\n", nil },
+		BinaryPath: func(osType, arch string) (string, error) {
+			if osType != "Linux" || arch != "x86_64" {
+				return "", errors.New("unexpected platform")
+			}
+			return binary, nil
+		},
+		EnrollmentToken: func(ctx context.Context, hostID uint) (string, error) {
+			return "enroll-token", nil
+		},
+		Dialer: dialer,
+	})
+	return service, hostRepo
+}
+
+func createTestHost(t *testing.T, repo *host.Repository, hostType host.HostType) *host.Host {
+	t.Helper()
+	h := &host.Host{Name: "node-" + string(hostType), HostType: hostType, IPAddress: "10.0.0.21", SSHPort: 2222, SSHUser: "deploy"}
+	if hostType != host.HostTypeBareMetal {
+		h.IPAddress = ""
+	}
+	if err := repo.Create(context.Background(), h); err != nil {
+		t.Fatalf("create host: %v", err)
+	}
+	return h
+}
+
+func TestSaveCredentialEncryptsSecrets(t *testing.T) {
+	service, hostRepo := newTestService(t, &fakeDialer{})
+	h := createTestHost(t, hostRepo, host.HostTypeBareMetal)
+	ctx := context.Background()
+
+	cred, err := service.SaveCredential(ctx, h.ID, &SaveCredentialRequest{AuthType: AuthTypePassword, Password: "s3cret-pass"})
+	if err != nil {
+		t.Fatalf("SaveCredential returned error: %v", err)
+	}
+	if cred.Username != "deploy" {
+		t.Fatalf("username should default to the host ssh_user, got %q", cred.Username)
+	}
+	stored, err := service.GetCredential(ctx, h.ID)
+	if err != nil {
+		t.Fatalf("GetCredential returned error: %v", err)
+	}
+	if !secrets.IsSealed(stored.EncryptedSecret) || strings.Contains(stored.EncryptedSecret, "s3cret-pass") {
+		t.Fatalf("password must be stored encrypted, got %q", stored.EncryptedSecret)
+	}
+	target, err := service.buildTarget(h, stored)
+	if err != nil || target.Password != "s3cret-pass" || target.Addr != "10.0.0.21:2222" {
+		t.Fatalf("unexpected target %+v (%v)", target, err)
+	}
+
+	if _, err := service.SaveCredential(ctx, h.ID, &SaveCredentialRequest{AuthType: AuthTypeKey, PrivateKey: "not a key"}); !errors.Is(err, ErrCredentialInvalid) {
+		t.Fatalf("expected ErrCredentialInvalid for a malformed key, got %v", err)
+	}
+	docker := createTestHost(t, hostRepo, host.HostTypeDocker)
+	if _, err := service.SaveCredential(ctx, docker.ID, &SaveCredentialRequest{AuthType: AuthTypePassword, Password: "x"}); !errors.Is(err, ErrHostNotBareMetal) {
+		t.Fatalf("expected ErrHostNotBareMetal, got %v", err)
+	}
+}
+
+func TestDeployPushesBinaryAndRunsInstallScript(t *testing.T) {
+	dialer := &fakeDialer{fingerprint: "SHA256:abc"}
+	service, hostRepo := newTestService(t, dialer)
+	h := createTestHost(t, hostRepo, host.HostTypeBareMetal)
+	ctx := context.Background()
+
+	if _, err := service.StartDeploy(ctx, h.ID, "admin"); !errors.Is(err, ErrCredentialNotFound) {
+		t.Fatalf("expected ErrCredentialNotFound, got %v", err)
+	}
+	if _, err := service.SaveCredential(ctx, h.ID, &SaveCredentialRequest{AuthType: AuthTypePassword, Password: "pw"}); err != nil {
+		t.Fatalf("SaveCredential returned error: %v", err)
+	}
+
+	task, err := service.StartDeploy(ctx, h.ID, "admin")
+	if err != nil {
+		t.Fatalf("StartDeploy returned error: %v", err)
+	}
+	service.wg.Wait()
+
+	done, err := service.GetTask(ctx, h.ID, task.ID)
+	if err != nil {
+		t.Fatalf("GetTask returned error: %v", err)
+	}
+	if done.Status != TaskStatusSuccess || done.Platform != "linux/x86_64" || done.Step != StepCleanup {
+		t.Fatalf("unexpected task %+v", done)
+	}
+	if !strings.Contains(done.Log, "==> "+StepInstall) || !strings.Contains(done.Log, "pinned host key SHA256:abc") {
+		t.Fatalf("task log should capture every step, got:\n%s", done.Log)
+	}
+
+	var upload, install *fakeCommand
+	for i := range dialer.commands {
+		cmd := &dialer.commands[i]
+		if strings.HasPrefix(cmd.cmd, "cat > '"+remoteBinaryPath+"'") {
+			upload = cmd
+		}
+		if strings.Contains(cmd.cmd, "bash '"+remoteScriptPath+"'") {
+			install = cmd
+		}
+	}
+	if upload == nil || upload.stdin != "agent-binary" {
+		t.Fatalf("agent binary should be streamed to the host, got %+v", dialer.commands)
+	}
+	if install == nil || !strings.HasPrefix(install.cmd, "sudo -S -p '' env AGENT_BINARY_FILE=") || install.stdin != "pw\n" {
+		t.Fatalf("non-root password login should run the script through sudo -S, got %+v", install)
+	}
+	if !strings.Contains(install.cmd, "ENROLLMENT_TOKEN='enroll-token'") {
+		t.Fatalf("install command should pass the enrollment token, got %q", install.cmd)
+	}
+
+	cred, _ := service.GetCredential(ctx, h.ID)
+	if cred.HostKeyFingerprint != "SHA256:abc" {
+		t.Fatalf("host key should be pinned, got %q", cred.HostKeyFingerprint)
+	}
+}
+
+func TestDeployRecordsFailureAndCleansUp(t *testing.T) {
+	dialer := &fakeDialer{fingerprint: "SHA256:abc", failOn: "AGENT_BINARY_FILE"}
+	service, hostRepo := newTestService(t, dialer)
+	h := createTestHost(t, hostRepo, host.HostTypeBareMetal)
+	ctx := context.Background()
+	if _, err := service.SaveCredential(ctx, h.ID, &SaveCredentialRequest{Username: "root", AuthType: AuthTypePassword, Password: "pw"}); err != nil {
+		t.Fatalf("SaveCredential returned error: %v", err)
+	}
+
+	task, err := service.StartDeploy(ctx, h.ID, "admin")
+	if err != nil {
+		t.Fatalf("StartDeploy returned error: %v", err)
+	}
+	service.wg.Wait()
+
+	done, _ := service.GetTask(ctx, h.ID, task.ID)
+	if done.Status != TaskStatusFailed || !strings.Contains(done.Error, "run install script") {
+		t.Fatalf("expected failed install step, got %+v", done)
+	}
+	if !strings.Contains(done.Log, "install.sh: something went wrong") {
+		t.Fatalf("remote output should be captured, got:\n%s", done.Log)
+	}
+	last := dialer.commands[len(dialer.commands)-1]
+	if !strings.HasPrefix(last.cmd, "rm -f ") {
+		t.Fatalf("uploaded files should be removed after a failure, got %q", last.cmd)
+	}
+
+	// A changed host key is refused once the fingerprint is pinned.
+	dialer.fingerprint = "SHA256:other"
+	task, err = service.StartDeploy(ctx, h.ID, "admin")
+	if err != nil {
+		t.Fatalf("StartDeploy returned error: %v", err)
+	}
+	service.wg.Wait()
+	done, _ = service.GetTask(ctx, h.ID, task.ID)
+	if done.Status != TaskStatusFailed || !strings.Contains(done.Error, ErrHostKeyMismatch.Error()) {
+		t.Fatalf("expected host key mismatch, got %+v", done)
+	}
+}
@@ -0,0 +1,350 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package installer
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Resource planning thresholds used by the advisor.
+// 资源规划顾问使用的阈值。
+const (
+	// maxHeapMemoryRatio is the share of host RAM a requested heap may take before it is flagged.
+	// maxHeapMemoryRatio 是请求的堆内存可占主机内存的上限比例，超过即告警。
+	maxHeapMemoryRatio = 0.7
+	// recommendedHeapRatio leaves the rest of RAM to metaspace, direct memory and the OS.
+	// recommendedHeapRatio 为 metaspace、直接内存与操作系统预留其余内存。
+	recommendedHeapRatio = 0.5
+	// maxMasterHeapGB caps master heaps; masters only coordinate and do not run tasks.
+	// maxMasterHeapGB 限制 master 堆内存；master 只负责协调，不运行任务。
+	maxMasterHeapGB = 8
+	// defaultAdvisedHeapGB matches the wizard default when no host reported its memory.
+	// defaultAdvisedHeapGB 与向导默认值一致，用于没有主机上报内存的情况。
+	defaultAdvisedHeapGB = 2
+	minAdvisedMemoryGB   = 4
+	minAdvisedCPUCores   = 2
+	minAdvisedFreeDiskGB = 10
+	// separatedModeMinHosts is the host count from which dedicated masters pay off.
+	// separatedModeMinHosts 是使用独立 master 更划算的最小主机数。
+	separatedModeMinHosts = 4
+	separatedMasterCount  = 2
+	slotsPerCPUCore       = 2
+)
+
+const bytesPerGB = 1 << 30
+
+// Resource warning codes.
+// 资源告警代码。
+const (
+	ResourceWarningUnknown      = "resource_unknown"
+	ResourceWarningLowMemory    = "memory_low"
+	ResourceWarningLowCPU       = "cpu_low"
+	ResourceWarningLowDisk      = "disk_low"
+	ResourceWarningHeapTooLarge = "heap_exceeds_memory"
+)
+
+// ResourceAdviceRequest lists the hosts chosen for a new cluster and, optionally, the planned settings to check.
+// ResourceAdviceRequest 列出新集群选定的主机，以及可选的待检查计划配置。
+type ResourceAdviceRequest struct {
+	HostIDs []uint `json:"host_ids"`
+	// DeploymentMode and MasterHostIDs pin the topology; empty lets the advisor choose.
+	// DeploymentMode 与 MasterHostIDs 固定拓扑；为空时由顾问选择。
+	DeploymentMode DeploymentMode `json:"deployment_mode,omitempty"`
+	MasterHostIDs  []uint         `json:"master_host_ids,omitempty"`
+	// JVM is the planned heap configuration checked against host memory.
+	// JVM 是需要与主机内存比对的计划堆内存配置。
+	JVM *JVMConfig `json:"jvm,omitempty"`
+}
+
+// ResourceWarning flags a host that is undersized for its planned role.
+// ResourceWarning 标记对计划角色而言资源不足的主机。
+type ResourceWarning struct {
+	Code     string `json:"code"`
+	HostID   uint   `json:"host_id"`
+	HostName string `json:"host_name,omitempty"`
+	Message  string `json:"message"`
+}
+
+// HostResourceAdvice is the reported capacity and recommendation of one host.
+// HostResourceAdvice 是单台主机的上报容量与推荐配置。
+type HostResourceAdvice struct {
+	HostID            uint              `json:"host_id"`
+	HostName          string            `json:"host_name,omitempty"`
+	Role              NodeRole          `json:"role"`
+	CPUCores          int               `json:"cpu_cores"`
+	MemoryGB          float64           `json:"memory_gb"`
+	FreeDiskGB        float64           `json:"free_disk_gb"`
+	RecommendedHeapGB int               `json:"recommended_heap_gb"`
+	Warnings          []ResourceWarning `json:"warnings,omitempty"`
+}
+
+// ResourceAdvice is the recommended deployment for the chosen hosts.
+// ResourceAdvice 是针对选定主机的推荐部署方案。
+type ResourceAdvice struct {
+	DeploymentMode DeploymentMode        `json:"deployment_mode"`
+	MasterHostIDs  []uint                `json:"master_host_ids,omitempty"`
+	JVM            JVMConfig             `json:"jvm"`
+	DynamicSlot    bool                  `json:"dynamic_slot"`
+	SlotNum        int                   `json:"slot_num"`
+	Hosts          []*HostResourceAdvice `json:"hosts"`
+	Warnings       []ResourceWarning     `json:"warnings"`
+}
+
+// AdviseResources inspects the CPU, memory and disk reported by the hosts' heartbeats and
+// recommends a deployment mode, per-role heap sizes and a slot count. Undersized hosts and
+// planned heaps above 70% of a host's RAM are reported as warnings.
+// AdviseResources 检查主机心跳上报的 CPU、内存与磁盘，推荐部署模式、各角色堆内存与 slot 数量。
+// 资源不足的主机以及超过主机内存 70% 的计划堆内存会以告警形式返回。
+func (s *Service) AdviseResources(ctx context.Context, req *ResourceAdviceRequest) (*ResourceAdvice, error) {
+	if req == nil || len(req.HostIDs) == 0 {
+		return nil, ErrNoHostsToAdvise
+	}
+	if s.hostProvider == nil {
+		return nil, fmt.Errorf("host provider not configured / 主机提供者未配置")
+	}
+
+	hosts := make([]*HostInfo, 0, len(req.HostIDs))
+	seen := make(map[uint]bool, len(req.HostIDs))
+	for _, id := range req.HostIDs {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		host, err := s.hostProvider.GetHostByID(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("host %d: %w", id, err)
+		}
+		hosts = append(hosts, host)
+	}
+	return adviseResources(hosts, req), nil
+}
+
+// adviseResources builds the recommendation from already loaded hosts.
+// adviseResources 基于已加载的主机生成推荐方案。
+func adviseResources(hosts []*HostInfo, req *ResourceAdviceRequest) *ResourceAdvice {
+	advice := &ResourceAdvice{DeploymentMode: req.DeploymentMode, Warnings: []ResourceWarning{}}
+	if advice.DeploymentMode == "" {
+		advice.DeploymentMode = DeploymentModeHybrid
+		if len(hosts) >= separatedModeMinHosts {
+			advice.DeploymentMode = DeploymentModeSeparated
+		}
+	}
+
+	roles := assignAdvisedRoles(hosts, advice.DeploymentMode, req.MasterHostIDs)
+	for _, host := range hosts {
+		if roles[host.ID] == NodeRoleMaster {
+			advice.MasterHostIDs = append(advice.MasterHostIDs, host.ID)
+		}
+	}
+
+	// Every node of a role shares one heap setting, so the smallest host of the role decides it
+	// 同一角色的所有节点共用一个堆内存配置，因此由该角色中最小的主机决定
+	minMemory := make(map[NodeRole]int64)
+	minCores := 0
+	for _, host := range hosts {
+		role := roles[host.ID]
+		if host.TotalMemory > 0 && (minMemory[role] == 0 || host.TotalMemory < minMemory[role]) {
+			minMemory[role] = host.TotalMemory
+		}
+		if role != NodeRoleMaster && host.CPUCores > 0 && (minCores == 0 || host.CPUCores < minCores) {
+			minCores = host.CPUCores
+		}
+	}
+	if advice.DeploymentMode == DeploymentModeSeparated {
+		advice.JVM.MasterHeapSize = recommendedHeapGB(minMemory[NodeRoleMaster], maxMasterHeapGB)
+		advice.JVM.WorkerHeapSize = recommendedHeapGB(minMemory[NodeRoleWorker], 0)
+	} else {
+		advice.JVM.HybridHeapSize = recommendedHeapGB(minMemory[NodeRoleMasterWorker], 0)
+	}
+
+	// A fixed slot count keeps concurrent tasks within what the smallest worker can hold
+	// 固定 slot 数量，使并发任务不超过最小 worker 的承载能力
+	advice.DynamicSlot = minCores == 0
+	advice.SlotNum = minCores * slotsPerCPUCore
+	if advice.SlotNum == 0 {
+		advice.SlotNum = slotsPerCPUCore
+	}
+
+	for _, host := range hosts {
+		role := roles[host.ID]
+		hostAdvice := &HostResourceAdvice{
+			HostID:            host.ID,
+			HostName:          host.Name,
+			Role:              role,
+			CPUCores:          host.CPUCores,
+			MemoryGB:          roundGB(float64(host.TotalMemory) / bytesPerGB),
+			FreeDiskGB:        roundGB(freeDiskGB(host)),
+			RecommendedHeapGB: advisedHeapForRole(&advice.JVM, role),
+		}
+		hostAdvice.Warnings = hostResourceWarnings(host, role, req.JVM)
+		advice.Warnings = append(advice.Warnings, hostAdvice.Warnings...)
+		advice.Hosts = append(advice.Hosts, hostAdvice)
+	}
+	return advice
+}
+
+// assignAdvisedRoles maps each host to its role. In separated mode the explicit masters are used,
+// otherwise the hosts with the least memory become masters so workers keep the larger machines.
+// assignAdvisedRoles 为每台主机分配角色。分离模式下优先使用指定的 master，
+// 否则由内存最小的主机担任 master，使 worker 使用更大的机器。
+func assignAdvisedRoles(hosts []*HostInfo, mode DeploymentMode, masterIDs []uint) map[uint]NodeRole {
+	roles := make(map[uint]NodeRole, len(hosts))
+	if mode != DeploymentModeSeparated {
+		for _, host := range hosts {
+			roles[host.ID] = NodeRoleMasterWorker
+		}
+		return roles
+	}
+
+	for _, host := range hosts {
+		roles[host.ID] = NodeRoleWorker
+	}
+	if len(masterIDs) > 0 {
+		for _, id := range masterIDs {
+			if _, ok := roles[id]; ok {
+				roles[id] = NodeRoleMaster
+			}
+		}
+		return roles
+	}
+
+	bySize := append([]*HostInfo(nil), hosts...)
+	sort.SliceStable(bySize, func(i, j int) bool {
+		return bySize[i].TotalMemory < bySize[j].TotalMemory
+	})
+	masters := separatedMasterCount
+	if len(bySize)-masters < 1 {
+		masters = len(bySize) - 1
+	}
+	for _, host := range bySize[:masters] {
+		roles[host.ID] = NodeRoleMaster
+	}
+	return roles
+}
+
+// recommendedHeapGB returns half of the given memory in whole GB, at least 1 and at most maxGB when set.
+// recommendedHeapGB 返回给定内存的一半（整 GB），至少为 1，设置 maxGB 时不超过该值。
+func recommendedHeapGB(memoryBytes int64, maxGB int) int {
+	if memoryBytes <= 0 {
+		return defaultAdvisedHeapGB
+	}
+	heap := int(float64(memoryBytes) * recommendedHeapRatio / bytesPerGB)
+	if heap < 1 {
+		heap = 1
+	}
+	if maxGB > 0 && heap > maxGB {
+		heap = maxGB
+	}
+	return heap
+}
+
+// advisedHeapForRole returns the heap of the JVM setting that applies to role.
+// advisedHeapForRole 返回 JVM 配置中适用于该角色的堆内存。
+func advisedHeapForRole(jvm *JVMConfig, role NodeRole) int {
+	if jvm == nil {
+		return 0
+	}
+	switch role {
+	case NodeRoleMaster:
+		return jvm.MasterHeapSize
+	case NodeRoleWorker:
+		return jvm.WorkerHeapSize
+	default:
+		return jvm.HybridHeapSize
+	}
+}
+
+// hostResourceWarnings checks one host against the minimum sizing and the planned heap of its role.
+// hostResourceWarnings 按最低配置与所属角色的计划堆内存检查单台主机。
+func hostResourceWarnings(host *HostInfo, role NodeRole, planned *JVMConfig) []ResourceWarning {
+	var warnings []ResourceWarning
+	add := func(code, message string) {
+		warnings = append(warnings, ResourceWarning{Code: code, HostID: host.ID, HostName: host.Name, Message: message})
+	}
+
+	if host.CPUCores <= 0 || host.TotalMemory <= 0 {
+		add(ResourceWarningUnknown, "Agent has not reported CPU or memory yet / Agent 尚未上报 CPU 或内存信息")
+		return warnings
+	}
+	memoryGB := float64(host.TotalMemory) / bytesPerGB
+	if memoryGB < minAdvisedMemoryGB {
+		add(ResourceWarningLowMemory, fmt.Sprintf("%.1f GB RAM is below the recommended %d GB / 内存 %.1f GB 低于建议的 %d GB",
+			memoryGB, minAdvisedMemoryGB, memoryGB, minAdvisedMemoryGB))
+	}
+	if host.CPUCores < minAdvisedCPUCores {
+		add(ResourceWarningLowCPU, fmt.Sprintf("%d CPU core(s) is below the recommended %d / CPU 核数 %d 低于建议的 %d",
+			host.CPUCores, minAdvisedCPUCores, host.CPUCores, minAdvisedCPUCores))
+	}
+	if host.TotalDisk > 0 {
+		if free := freeDiskGB(host); free < minAdvisedFreeDiskGB {
+			add(ResourceWarningLowDisk, fmt.Sprintf("%.1f GB free disk is below the recommended %d GB / 可用磁盘 %.1f GB 低于建议的 %d GB",
+				free, minAdvisedFreeDiskGB, free, minAdvisedFreeDiskGB))
+		}
+	}
+	if heap := advisedHeapForRole(planned, role); heap > 0 && float64(heap) > memoryGB*maxHeapMemoryRatio {
+		add(ResourceWarningHeapTooLarge, fmt.Sprintf("%s heap %d GB exceeds 70%% of %.1f GB RAM / %s 堆内存 %d GB 超过内存 %.1f GB 的 70%%",
+			role, heap, memoryGB, role, heap, memoryGB))
+	}
+	return warnings
+}
+
+// installationResourceWarnings returns the sizing warnings of the host an installation targets,
+// so an oversized heap is visible on the installation before any step runs.
+// installationResourceWarnings 返回安装目标主机的资源告警，使过大的堆内存在任何步骤执行前即可在安装任务上看到。
+func (s *Service) installationResourceWarnings(ctx context.Context, req *InstallationRequest) []string {
+	if s.hostProvider == nil {
+		return nil
+	}
+	id, err := strconv.ParseUint(strings.TrimSpace(req.HostID), 10, 64)
+	if err != nil || id == 0 {
+		return nil
+	}
+	host, err := s.hostProvider.GetHostByID(ctx, uint(id))
+	if err != nil || host == nil || host.TotalMemory <= 0 {
+		return nil
+	}
+	role := req.NodeRole
+	if req.DeploymentMode != DeploymentModeSeparated {
+		role = NodeRoleMasterWorker
+	}
+
+	var warnings []string
+	for _, warning := range hostResourceWarnings(host, role, req.JVM) {
+		if warning.Code == ResourceWarningHeapTooLarge {
+			warnings = append(warnings, "Warning: "+warning.Message)
+		}
+	}
+	return warnings
+}
+
+func freeDiskGB(host *HostInfo) float64 {
+	if host.TotalDisk <= 0 {
+		return 0
+	}
+	usage := math.Min(math.Max(host.DiskUsage, 0), 100)
+	return float64(host.TotalDisk) * (100 - usage) / 100 / bytesPerGB
+}
+
+func roundGB(value float64) float64 {
+	return math.Round(value*10) / 10
+}
@@ -0,0 +1,127 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package installer
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type advisorHostProvider map[uint]*HostInfo
+
+func (p advisorHostProvider) GetHostByID(ctx context.Context, hostID uint) (*HostInfo, error) {
+	host, ok := p[hostID]
+	if !ok {
+		return nil, errors.New("host not found")
+	}
+	return host, nil
+}
+
+func advisorHost(id uint, cores int, memoryGB int64) *HostInfo {
+	return &HostInfo{ID: id, Name: "node", CPUCores: cores, TotalMemory: memoryGB * bytesPerGB, TotalDisk: 200 * bytesPerGB, DiskUsage: 50}
+}
+
+// TestAdviseResourcesRecommendsTopologyHeapAndSlots verifies mode, heap and slot recommendations.
+// TestAdviseResourcesRecommendsTopologyHeapAndSlots 验证部署模式、堆内存与 slot 推荐。
+func TestAdviseResourcesRecommendsTopologyHeapAndSlots(t *testing.T) {
+	service := NewService(t.TempDir(), &dryRunAgentManager{})
+	service.SetHostProvider(advisorHostProvider{
+		1: advisorHost(1, 8, 16),
+		2: advisorHost(2, 4, 8),
+		3: advisorHost(3, 16, 64),
+		4: advisorHost(4, 8, 32),
+		5: advisorHost(5, 16, 32),
+	})
+
+	hybrid, err := service.AdviseResources(context.Background(), &ResourceAdviceRequest{HostIDs: []uint{1, 2}})
+	if err != nil {
+		t.Fatalf("AdviseResources: %v", err)
+	}
+	if hybrid.DeploymentMode != DeploymentModeHybrid || hybrid.JVM.HybridHeapSize != 4 || hybrid.SlotNum != 8 || hybrid.DynamicSlot {
+		t.Fatalf("unexpected hybrid advice: %+v", hybrid)
+	}
+
+	separated, err := service.AdviseResources(context.Background(), &ResourceAdviceRequest{HostIDs: []uint{1, 2, 3, 4, 5}})
+	if err != nil {
+		t.Fatalf("AdviseResources: %v", err)
+	}
+	if separated.DeploymentMode != DeploymentModeSeparated {
+		t.Fatalf("expected separated mode for 5 hosts, got %s", separated.DeploymentMode)
+	}
+	if len(separated.MasterHostIDs) != 2 || separated.MasterHostIDs[0] != 1 || separated.MasterHostIDs[1] != 2 {
+		t.Fatalf("expected the two smallest hosts as masters, got %v", separated.MasterHostIDs)
+	}
+	// Master heap follows the 8 GB host, worker heap the 32 GB hosts, slots the 8-core worker
+	// master 堆内存取决于 8 GB 主机，worker 堆内存取决于 32 GB 主机，slot 取决于 8 核 worker
+	if separated.JVM.MasterHeapSize != 4 || separated.JVM.WorkerHeapSize != 16 || separated.SlotNum != 16 {
+		t.Fatalf("unexpected separated advice: %+v", separated.JVM)
+	}
+	if len(separated.Warnings) != 0 {
+		t.Fatalf("expected no warnings, got %+v", separated.Warnings)
+	}
+
+	if _, err := service.AdviseResources(context.Background(), &ResourceAdviceRequest{}); !errors.Is(err, ErrNoHostsToAdvise) {
+		t.Fatalf("expected ErrNoHostsToAdvise, got %v", err)
+	}
+}
+
+// TestAdviseResourcesWarnsOnUndersizedHosts verifies low resources and oversized heaps are flagged.
+// TestAdviseResourcesWarnsOnUndersizedHosts 验证资源不足与堆内存过大会被标记。
+func TestAdviseResourcesWarnsOnUndersizedHosts(t *testing.T) {
+	small := advisorHost(1, 1, 2)
+	small.DiskUsage = 98
+	service := NewService(t.TempDir(), &dryRunAgentManager{})
+	service.SetHostProvider(advisorHostProvider{1: small, 2: advisorHost(2, 8, 16), 3: {ID: 3}})
+
+	advice, err := service.AdviseResources(context.Background(), &ResourceAdviceRequest{
+		HostIDs: []uint{1, 2, 3},
+		JVM:     &JVMConfig{HybridHeapSize: 12},
+	})
+	if err != nil {
+		t.Fatalf("AdviseResources: %v", err)
+	}
+	codes := map[uint][]string{}
+	for _, warning := range advice.Warnings {
+		codes[warning.HostID] = append(codes[warning.HostID], warning.Code)
+	}
+	want := map[uint][]string{
+		1: {ResourceWarningLowMemory, ResourceWarningLowCPU, ResourceWarningLowDisk, ResourceWarningHeapTooLarge},
+		2: {ResourceWarningHeapTooLarge},
+		3: {ResourceWarningUnknown},
+	}
+	for id, expected := range want {
+		if len(codes[id]) != len(expected) {
+			t.Fatalf("host %d warnings = %v, want %v", id, codes[id], expected)
+		}
+		for i := range expected {
+			if codes[id][i] != expected[i] {
+				t.Fatalf("host %d warnings = %v, want %v", id, codes[id], expected)
+			}
+		}
+	}
+
+	// The same heap check surfaces on an installation before any step runs
+	// 同样的堆内存检查会在任何步骤执行前出现在安装任务上
+	warnings := service.installationResourceWarnings(context.Background(), &InstallationRequest{
+		HostID: "2", DeploymentMode: DeploymentModeHybrid, JVM: &JVMConfig{HybridHeapSize: 12},
+	})
+	if len(warnings) != 1 {
+		t.Fatalf("expected one installation warning, got %v", warnings)
+	}
+}
@@ -0,0 +1,451 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package installer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/seatunnel/seatunnelX/internal/config"
+	"github.com/seatunnel/seatunnelX/internal/logger"
+)
+
+// ErrInvalidConcurrencyLimits indicates a negative concurrency limit.
+// ErrInvalidConcurrencyLimits 表示并发上限为负数。
+var ErrInvalidConcurrencyLimits = errors.New("invalid concurrency limits / 并发上限不合法")
+
+const (
+	// installSlotLeasePrefix and transferSlotLeasePrefix name the cluster-wide slot leases in ha_leases.
+	// installSlotLeasePrefix 与 transferSlotLeasePrefix 是 ha_leases 中集群级名额租约的名称前缀。
+	installSlotLeasePrefix  = "installer-install-slot-"
+	transferSlotLeasePrefix = "installer-transfer-slot-"
+
+	// slotLeaseTTL is how long a slot stays taken when its replica stops renewing it.
+	// slotLeaseTTL 是副本停止续约后名额仍被占用的时长。
+	slotLeaseTTL = time.Minute
+)
+
+// slotPollInterval is how often a replica retries taking a cluster-wide slot.
+// slotPollInterval 是副本重试获取集群级名额的间隔。
+var slotPollInterval = 5 * time.Second
+
+// ConcurrencyLimits bounds installations and package transfers; 0 means unlimited.
+// Global limits are shared by all replicas and only apply when high availability is enabled.
+// ConcurrencyLimits 限制安装与安装包传输的并发数，0 表示不限制。全局上限由所有副本共享，仅在启用高可用时生效。
+type ConcurrencyLimits struct {
+	MaxInstallations          int `json:"max_installations"`
+	MaxPackageTransfers       int `json:"max_package_transfers"`
+	GlobalMaxInstallations    int `json:"global_max_installations"`
+	GlobalMaxPackageTransfers int `json:"global_max_package_transfers"`
+}
+
+// ConcurrencyStatus reports the limits of this replica and the work running and queued under them.
+// ConcurrencyStatus 报告本副本的并发上限以及正在运行与排队的任务数。
+type ConcurrencyStatus struct {
+	InstanceID           string            `json:"instance_id,omitempty"`
+	Limits               ConcurrencyLimits `json:"limits"`
+	RunningInstallations int               `json:"running_installations"`
+	QueuedInstallations  int               `json:"queued_installations"`
+	RunningTransfers     int               `json:"running_transfers"`
+	QueuedTransfers      int               `json:"queued_transfers"`
+}
+
+// SlotLeaser grants named, expiring leases shared by all Control Plane replicas; ha.Store implements it.
+// SlotLeaser 提供所有 Control Plane 副本共享的具名过期租约；ha.Store 实现了该接口。
+type SlotLeaser interface {
+	TryAcquireLease(ctx context.Context, name, holder string, ttl time.Duration, now time.Time) (bool, error)
+	ReleaseLease(ctx context.Context, name, holder string) error
+}
+
+// concurrencyLimitsFromConfig converts installer.concurrency into ConcurrencyLimits.
+// concurrencyLimitsFromConfig 将 installer.concurrency 转换为 ConcurrencyLimits。
+func concurrencyLimitsFromConfig(c config.InstallerConcurrencyConfig) ConcurrencyLimits {
+	return ConcurrencyLimits{
+		MaxInstallations:          c.MaxInstallations,
+		MaxPackageTransfers:       c.MaxPackageTransfers,
+		GlobalMaxInstallations:    c.GlobalMaxInstallations,
+		GlobalMaxPackageTransfers: c.GlobalMaxPackageTransfers,
+	}
+}
+
+// SetSlotLeaser enables the global limits, which take slots as leases shared by all replicas.
+// SetSlotLeaser 启用全局上限，名额以所有副本共享的租约形式获取。
+func (s *Service) SetSlotLeaser(leaser SlotLeaser, instanceID string) {
+	s.concurrencyMu.Lock()
+	defer s.concurrencyMu.Unlock()
+	s.slotLeaser = leaser
+	s.instanceID = instanceID
+}
+
+// GetConcurrencyStatus returns the concurrency limits of this replica with the current load.
+// GetConcurrencyStatus 返回本副本的并发上限及当前负载。
+func (s *Service) GetConcurrencyStatus() *ConcurrencyStatus {
+	s.concurrencyMu.RLock()
+	status := &ConcurrencyStatus{InstanceID: s.instanceID, Limits: s.concurrencyLimits}
+	s.concurrencyMu.RUnlock()
+	status.RunningInstallations, status.QueuedInstallations = s.installQueue.stats()
+	status.RunningTransfers, status.QueuedTransfers = s.transferQueue.stats()
+	return status
+}
+
+// SetConcurrencyLimits changes the concurrency limits of this replica at runtime. Queued work is admitted
+// right away when a limit is raised; work already running is not interrupted when it is lowered.
+// SetConcurrencyLimits 在运行时修改本副本的并发上限。提高上限时立即放行排队任务；降低上限不会中断已在运行的任务。
+func (s *Service) SetConcurrencyLimits(limits ConcurrencyLimits) (*ConcurrencyStatus, error) {
+	if limits.MaxInstallations < 0 || limits.MaxPackageTransfers < 0 ||
+		limits.GlobalMaxInstallations < 0 || limits.GlobalMaxPackageTransfers < 0 {
+		return nil, fmt.Errorf("%w: limits must not be negative", ErrInvalidConcurrencyLimits)
+	}
+	s.concurrencyMu.Lock()
+	s.concurrencyLimits = limits
+	s.concurrencyMu.Unlock()
+	s.installQueue.setLimit(limits.MaxInstallations)
+	s.transferQueue.setLimit(limits.MaxPackageTransfers)
+	return s.GetConcurrencyStatus(), nil
+}
+
+// admitInstallation waits until the installation may run under the replica and global limits.
+// The status shows the queue position meanwhile; CancelInstallation ends the wait.
+// admitInstallation 等待安装在副本与全局上限内获准运行，期间状态显示排队位置；CancelInstallation 可结束等待。
+func (s *Service) admitInstallation(ctx context.Context, req *InstallationRequest, status *InstallationStatus) (func(), error) {
+	ctx, done := s.queueContext(ctx, status)
+	release, err := s.installQueue.acquire(ctx, req.Priority, func(position int) {
+		message := "Installation started / 安装已开始"
+		if position > 0 {
+			message = fmt.Sprintf("Waiting in installation queue, position %d / 安装排队中，第 %d 位", position, position)
+		}
+		s.setQueueStatus(status, position, message)
+	})
+	if err != nil {
+		done()
+		return nil, err
+	}
+
+	s.concurrencyMu.RLock()
+	limit := s.concurrencyLimits.GlobalMaxInstallations
+	s.concurrencyMu.RUnlock()
+	releaseSlot, err := s.acquireGlobalSlot(ctx, installSlotLeasePrefix, limit, status.ID, func() {
+		s.setQueueStatus(status, 0, "Waiting for a free installation slot across Control Plane replicas / 正在等待控制面副本间的空闲安装名额")
+	})
+	if err != nil {
+		release()
+		done()
+		return nil, err
+	}
+	return func() {
+		releaseSlot()
+		release()
+		done()
+	}, nil
+}
+
+// admitPackageTransfer waits for a free package transfer slot under the replica and global limits.
+// admitPackageTransfer 等待副本与全局上限内的空闲安装包传输名额。
+func (s *Service) admitPackageTransfer(ctx context.Context, status *InstallationStatus) (func(), error) {
+	ctx, done := s.queueContext(ctx, status)
+	release, err := s.transferQueue.acquire(ctx, 0, func(position int) {
+		message := "Transferring package to Agent... / 正在传输安装包到 Agent..."
+		if position > 0 {
+			message = fmt.Sprintf("Waiting for a package transfer slot, position %d / 等待安装包传输名额，第 %d 位", position, position)
+		}
+		s.setQueueStatus(status, position, message)
+	})
+	if err != nil {
+		done()
+		return nil, err
+	}
+
+	holder := "transfer"
+	if status != nil {
+		holder = status.ID
+	}
+	s.concurrencyMu.RLock()
+	limit := s.concurrencyLimits.GlobalMaxPackageTransfers
+	s.concurrencyMu.RUnlock()
+	releaseSlot, err := s.acquireGlobalSlot(ctx, transferSlotLeasePrefix, limit, holder, func() {
+		s.setQueueStatus(status, 0, "Waiting for a free package transfer slot across Control Plane replicas / 正在等待控制面副本间的空闲传输名额")
+	})
+	if err != nil {
+		release()
+		done()
+		return nil, err
+	}
+	return func() {
+		releaseSlot()
+		release()
+		done()
+	}, nil
+}
+
+// queueContext derives the context of a wait in a queue and lets CancelInstallation end it.
+// queueContext 派生排队等待的上下文，使 CancelInstallation 可以结束等待。
+func (s *Service) queueContext(ctx context.Context, status *InstallationStatus) (context.Context, func()) {
+	ctx, cancel := context.WithCancel(ctx)
+	if status == nil {
+		return ctx, cancel
+	}
+	s.installMu.Lock()
+	status.dequeue = cancel
+	s.installMu.Unlock()
+	return ctx, func() {
+		s.installMu.Lock()
+		status.dequeue = nil
+		s.installMu.Unlock()
+		cancel()
+	}
+}
+
+// setQueueStatus records the queue position of an installation that has not been cancelled.
+// setQueueStatus 记录尚未取消的安装的排队位置。
+func (s *Service) setQueueStatus(status *InstallationStatus, position int, message string) {
+	if status == nil {
+		return
+	}
+	s.installMu.Lock()
+	defer s.installMu.Unlock()
+	if status.EndTime != nil {
+		return
+	}
+	status.QueuePosition = position
+	status.Message = message
+}
+
+// acquireGlobalSlot takes one of limit slot leases shared by all replicas, waiting until one frees up,
+// and renews it until the returned release is called. Without high availability or a limit it returns
+// immediately; lease errors are logged and the slot is skipped rather than blocking installations.
+// acquireGlobalSlot 获取所有副本共享的 limit 个名额租约之一，无空闲时等待，并在调用返回的 release 之前持续续约。
+// 未启用高可用或未设置上限时立即返回；租约出错时记录日志并跳过名额，而不阻塞安装。
+func (s *Service) acquireGlobalSlot(ctx context.Context, prefix string, limit int, id string, onWait func()) (func(), error) {
+	s.concurrencyMu.RLock()
+	leaser, holder := s.slotLeaser, s.instanceID+"/"+id
+	s.concurrencyMu.RUnlock()
+	if leaser == nil || limit <= 0 {
+		return func() {}, nil
+	}
+
+	waiting := false
+	for {
+		for i := 0; i < limit; i++ {
+			name := fmt.Sprintf("%s%d", prefix, i)
+			acquired, err := leaser.TryAcquireLease(ctx, name, holder, slotLeaseTTL, time.Now())
+			if err != nil {
+				logger.WarnF(ctx, "[Installer] 获取全局并发名额失败，跳过全局上限 / Failed to take global concurrency slot, skipping global limit: lease=%s, err=%v", name, err)
+				return func() {}, nil
+			}
+			if acquired {
+				return keepSlotLease(leaser, name, holder), nil
+			}
+		}
+		if !waiting {
+			waiting = true
+			onWait()
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(slotPollInterval):
+		}
+	}
+}
+
+// keepSlotLease renews a slot lease until the returned function releases it.
+// keepSlotLease 持续续约名额租约，直到调用返回的函数将其释放。
+func keepSlotLease(leaser SlotLeaser, name, holder string) func() {
+	stop := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(slotLeaseTTL / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				if _, err := leaser.TryAcquireLease(context.Background(), name, holder, slotLeaseTTL, time.Now()); err != nil {
+					logger.WarnF(context.Background(), "[Installer] 续约全局并发名额失败 / Failed to renew global concurrency slot: lease=%s, err=%v", name, err)
+				}
+			}
+		}
+	}()
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			close(stop)
+			if err := leaser.ReleaseLease(context.Background(), name, holder); err != nil {
+				logger.WarnF(context.Background(), "[Installer] 释放全局并发名额失败 / Failed to release global concurrency slot: lease=%s, err=%v", name, err)
+			}
+		})
+	}
+}
+
+// admissionQueue bounds how many holders run at once. Waiters are admitted by priority, higher first,
+// then in submission order.
+// admissionQueue 限制同时运行的数量。等待者按优先级（高者优先）放行，同优先级按提交顺序。
+type admissionQueue struct {
+	mu      sync.Mutex
+	limit   int
+	running int
+	seq     uint64
+	waiting []*admissionTicket
+
+	// notifyMu delivers position changes in the order they were computed
+	// notifyMu 保证位置变化按计算顺序送达
+	notifyMu sync.Mutex
+}
+
+// admissionTicket is one waiter of an admissionQueue.
+// admissionTicket 是 admissionQueue 中的一个等待者。
+type admissionTicket struct {
+	priority   int
+	seq        uint64
+	admitted   chan struct{}
+	onPosition func(position int)
+}
+
+// queueNotice is a queue position to report once the queue lock is released.
+// queueNotice 是在释放队列锁后上报的排队位置。
+type queueNotice struct {
+	ticket   *admissionTicket
+	position int
+}
+
+func newAdmissionQueue(limit int) *admissionQueue {
+	return &admissionQueue{limit: limit}
+}
+
+// acquire waits for a free slot and returns the function releasing it. onPosition is called with the
+// 1-based queue position whenever it changes, and with 0 once a waiter is admitted.
+// acquire 等待空闲名额并返回释放函数。排队位置（从 1 开始）变化时调用 onPosition，等待者获准时以 0 调用。
+func (q *admissionQueue) acquire(ctx context.Context, priority int, onPosition func(position int)) (func(), error) {
+	q.mu.Lock()
+	if len(q.waiting) == 0 && (q.limit <= 0 || q.running < q.limit) {
+		q.running++
+		q.mu.Unlock()
+		return q.releaseFunc(), nil
+	}
+	q.seq++
+	ticket := &admissionTicket{priority: priority, seq: q.seq, admitted: make(chan struct{}), onPosition: onPosition}
+	q.waiting = append(q.waiting, ticket)
+	sort.SliceStable(q.waiting, func(i, j int) bool {
+		if q.waiting[i].priority != q.waiting[j].priority {
+			return q.waiting[i].priority > q.waiting[j].priority
+		}
+		return q.waiting[i].seq < q.waiting[j].seq
+	})
+	notices := q.positionsLocked(nil)
+	q.unlockAndNotify(notices)
+
+	select {
+	case <-ticket.admitted:
+		return q.releaseFunc(), nil
+	case <-ctx.Done():
+	}
+
+	q.mu.Lock()
+	select {
+	case <-ticket.admitted:
+		// Admitted while the context ended: hand the slot back
+		// 在上下文结束的同时获准：归还名额
+		q.mu.Unlock()
+		q.releaseFunc()()
+		return nil, ctx.Err()
+	default:
+	}
+	for i, waiter := range q.waiting {
+		if waiter == ticket {
+			q.waiting = append(q.waiting[:i], q.waiting[i+1:]...)
+			break
+		}
+	}
+	notices = q.positionsLocked(nil)
+	q.unlockAndNotify(notices)
+	return nil, ctx.Err()
+}
+
+// releaseFunc returns the function giving back one slot; calling it more than once has no effect.
+// releaseFunc 返回归还一个名额的函数；重复调用无效。
+func (q *admissionQueue) releaseFunc() func() {
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			q.mu.Lock()
+			q.running--
+			notices := q.admitLocked()
+			q.unlockAndNotify(notices)
+		})
+	}
+}
+
+// setLimit changes the limit and admits waiters that now fit.
+// setLimit 修改上限并放行现在可以运行的等待者。
+func (q *admissionQueue) setLimit(limit int) {
+	q.mu.Lock()
+	q.limit = limit
+	notices := q.admitLocked()
+	q.unlockAndNotify(notices)
+}
+
+// stats returns the number of running holders and waiters.
+// stats 返回正在运行与等待的数量。
+func (q *admissionQueue) stats() (running, waiting int) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.running, len(q.waiting)
+}
+
+// admitLocked admits waiters while slots are free and returns the position changes to report.
+// admitLocked 在有空闲名额时放行等待者，并返回需要上报的位置变化。
+func (q *admissionQueue) admitLocked() []queueNotice {
+	var admitted []queueNotice
+	for len(q.waiting) > 0 && (q.limit <= 0 || q.running < q.limit) {
+		ticket := q.waiting[0]
+		q.waiting = q.waiting[1:]
+		q.running++
+		close(ticket.admitted)
+		admitted = append(admitted, queueNotice{ticket: ticket})
+	}
+	if len(admitted) == 0 {
+		return nil
+	}
+	return q.positionsLocked(admitted)
+}
+
+// positionsLocked appends the current position of every waiter to notices.
+// positionsLocked 将每个等待者的当前位置追加到 notices。
+func (q *admissionQueue) positionsLocked(notices []queueNotice) []queueNotice {
+	for i, ticket := range q.waiting {
+		notices = append(notices, queueNotice{ticket: ticket, position: i + 1})
+	}
+	return notices
+}
+
+// unlockAndNotify releases the queue lock and reports position changes outside of it.
+// unlockAndNotify 释放队列锁，并在锁外上报位置变化。
+func (q *admissionQueue) unlockAndNotify(notices []queueNotice) {
+	q.notifyMu.Lock()
+	defer q.notifyMu.Unlock()
+	q.mu.Unlock()
+	for _, notice := range notices {
+		if notice.ticket.onPosition != nil {
+			notice.ticket.onPosition(notice.position)
+		}
+	}
+}
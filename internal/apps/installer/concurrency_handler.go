@@ -0,0 +1,69 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package installer
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/seatunnel/seatunnelX/internal/apps/auth"
+	"github.com/seatunnel/seatunnelX/internal/apps/response"
+	"github.com/seatunnel/seatunnelX/internal/logger"
+)
+
+// ConcurrencyStatusResponse is the response for the installation concurrency limits.
+// ConcurrencyStatusResponse 是安装并发上限的响应。
+type ConcurrencyStatusResponse struct {
+	response.Meta
+	Data *ConcurrencyStatus `json:"data"`
+}
+
+// GetConcurrency handles GET /api/v1/admin/installer/concurrency - returns the limits and queue lengths of this replica.
+// GetConcurrency 处理 GET /api/v1/admin/installer/concurrency - 返回本副本的并发上限与队列长度。
+// @Tags admin
+// @Produce json
+// @Success 200 {object} ConcurrencyStatusResponse
+// @Router /api/v1/admin/installer/concurrency [get]
+func (h *Handler) GetConcurrency(c *gin.Context) {
+	response.OK(c, h.service.GetConcurrencyStatus())
+}
+
+// UpdateConcurrency handles PUT /api/v1/admin/installer/concurrency - changes the limits of this replica at runtime.
+// UpdateConcurrency 处理 PUT /api/v1/admin/installer/concurrency - 在运行时修改本副本的并发上限。
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param request body ConcurrencyLimits true "并发上限 / Concurrency limits"
+// @Success 200 {object} ConcurrencyStatusResponse
+// @Router /api/v1/admin/installer/concurrency [put]
+func (h *Handler) UpdateConcurrency(c *gin.Context) {
+	var limits ConcurrencyLimits
+	if err := c.ShouldBindJSON(&limits); err != nil {
+		response.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	status, err := h.service.SetConcurrencyLimits(limits)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	logger.InfoF(c.Request.Context(), "[Installer] 修改安装并发上限 / Installation concurrency limits changed: user=%d, limits=%+v",
+		auth.GetUserIDFromContext(c), limits)
+	response.OK(c, status)
+}
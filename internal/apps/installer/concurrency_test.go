@@ -0,0 +1,237 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package installer
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// waitFor polls cond until it holds or the test times out.
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestAdmissionQueueOrdersByPriorityThenSubmission(t *testing.T) {
+	queue := newAdmissionQueue(1)
+	release, err := queue.acquire(context.Background(), 0, nil)
+	if err != nil {
+		t.Fatalf("acquire: %v", err)
+	}
+
+	var mu sync.Mutex
+	var order []string
+	positions := map[string]int{}
+	releases := make(chan func(), 3)
+	enqueue := func(name string, priority, waiting int) {
+		go func() {
+			r, err := queue.acquire(context.Background(), priority, func(position int) {
+				mu.Lock()
+				positions[name] = position
+				mu.Unlock()
+			})
+			if err != nil {
+				t.Errorf("acquire %s: %v", name, err)
+				return
+			}
+			mu.Lock()
+			order = append(order, name)
+			mu.Unlock()
+			releases <- r
+		}()
+		waitFor(t, name+" to queue", func() bool {
+			_, queued := queue.stats()
+			return queued == waiting
+		})
+	}
+	enqueue("first", 0, 1)
+	enqueue("second", 0, 2)
+	enqueue("urgent", 10, 3)
+
+	mu.Lock()
+	if positions["urgent"] != 1 || positions["first"] != 2 || positions["second"] != 3 {
+		t.Fatalf("queue positions = %v", positions)
+	}
+	mu.Unlock()
+
+	release()
+	for i := 0; i < 3; i++ {
+		(<-releases)()
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(order) != 3 || order[0] != "urgent" || order[1] != "first" || order[2] != "second" {
+		t.Fatalf("admission order = %v", order)
+	}
+	if positions["second"] != 0 {
+		t.Fatalf("admitted waiter still reports position %d", positions["second"])
+	}
+	if running, queued := queue.stats(); running != 0 || queued != 0 {
+		t.Fatalf("stats = %d running, %d queued", running, queued)
+	}
+}
+
+func TestAdmissionQueueRaisedLimitAdmitsWaiters(t *testing.T) {
+	queue := newAdmissionQueue(1)
+	if _, err := queue.acquire(context.Background(), 0, nil); err != nil {
+		t.Fatalf("acquire: %v", err)
+	}
+	admitted := make(chan struct{})
+	go func() {
+		if _, err := queue.acquire(context.Background(), 0, nil); err == nil {
+			close(admitted)
+		}
+	}()
+	waitFor(t, "waiter to queue", func() bool {
+		_, queued := queue.stats()
+		return queued == 1
+	})
+
+	queue.setLimit(2)
+	select {
+	case <-admitted:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("waiter not admitted after raising the limit")
+	}
+}
+
+func TestCancelInstallationLeavesQueue(t *testing.T) {
+	service := NewService(t.TempDir(), nil)
+	if _, err := service.SetConcurrencyLimits(ConcurrencyLimits{MaxInstallations: 1}); err != nil {
+		t.Fatalf("SetConcurrencyLimits: %v", err)
+	}
+	running := newInstallationStatus(&InstallationRequest{HostID: "1"})
+	queued := newInstallationStatus(&InstallationRequest{HostID: "2"})
+	service.installations["1"] = running
+	service.installations["2"] = queued
+
+	release, err := service.admitInstallation(context.Background(), &InstallationRequest{HostID: "1"}, running)
+	if err != nil {
+		t.Fatalf("admitInstallation: %v", err)
+	}
+	defer release()
+
+	result := make(chan error, 1)
+	go func() {
+		_, err := service.admitInstallation(context.Background(), &InstallationRequest{HostID: "2"}, queued)
+		result <- err
+	}()
+	waitFor(t, "second installation to queue", func() bool {
+		service.installMu.RLock()
+		defer service.installMu.RUnlock()
+		return queued.QueuePosition == 1
+	})
+	if count := service.RunningInstallationCount(); count != 1 {
+		t.Fatalf("RunningInstallationCount = %d, want queued installation excluded", count)
+	}
+	if status := service.GetConcurrencyStatus(); status.RunningInstallations != 1 || status.QueuedInstallations != 1 {
+		t.Fatalf("GetConcurrencyStatus = %+v", status)
+	}
+
+	if _, err := service.CancelInstallation(context.Background(), 2); err != nil {
+		t.Fatalf("CancelInstallation: %v", err)
+	}
+	select {
+	case err := <-result:
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("admitInstallation after cancel = %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("cancelled installation still waiting in queue")
+	}
+	if _, err := service.SetConcurrencyLimits(ConcurrencyLimits{MaxInstallations: -1}); !errors.Is(err, ErrInvalidConcurrencyLimits) {
+		t.Fatalf("SetConcurrencyLimits accepted a negative limit: %v", err)
+	}
+}
+
+// memoryLeaser is an in-memory SlotLeaser.
+type memoryLeaser struct {
+	mu     sync.Mutex
+	leases map[string]string
+}
+
+func (l *memoryLeaser) TryAcquireLease(_ context.Context, name, holder string, _ time.Duration, _ time.Time) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if current, ok := l.leases[name]; ok && current != holder {
+		return false, nil
+	}
+	l.leases[name] = holder
+	return true, nil
+}
+
+func (l *memoryLeaser) ReleaseLease(_ context.Context, name, holder string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.leases[name] == holder {
+		delete(l.leases, name)
+	}
+	return nil
+}
+
+func TestGlobalSlotsAreSharedBetweenReplicas(t *testing.T) {
+	restore := slotPollInterval
+	slotPollInterval = 10 * time.Millisecond
+	defer func() { slotPollInterval = restore }()
+
+	leaser := &memoryLeaser{leases: map[string]string{}}
+	replicaA := NewService(t.TempDir(), nil)
+	replicaA.SetSlotLeaser(leaser, "replica-a")
+	replicaB := NewService(t.TempDir(), nil)
+	replicaB.SetSlotLeaser(leaser, "replica-b")
+
+	releaseA, err := replicaA.acquireGlobalSlot(context.Background(), installSlotLeasePrefix, 1, "install-a", func() {})
+	if err != nil {
+		t.Fatalf("acquireGlobalSlot on replica A: %v", err)
+	}
+
+	waiting := make(chan struct{})
+	acquired := make(chan func(), 1)
+	go func() {
+		releaseB, err := replicaB.acquireGlobalSlot(context.Background(), installSlotLeasePrefix, 1, "install-b", func() { close(waiting) })
+		if err == nil {
+			acquired <- releaseB
+		}
+	}()
+	select {
+	case <-waiting:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("replica B did not wait for the global slot")
+	}
+
+	releaseA()
+	select {
+	case releaseB := <-acquired:
+		releaseB()
+	case <-time.After(5 * time.Second):
+		t.Fatalf("replica B did not take the released global slot")
+	}
+	if len(leaser.leases) != 0 {
+		t.Fatalf("leases left behind: %v", leaser.leases)
+	}
+}
@@ -0,0 +1,391 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package installer
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/seatunnel/seatunnelX/internal/logger"
+	"github.com/seatunnel/seatunnelX/internal/pkg/downloadx"
+	"github.com/seatunnel/seatunnelX/internal/pkg/secrets"
+	"github.com/seatunnel/seatunnelX/internal/seatunnel"
+)
+
+// dryRunTemplateParamPrefix prefixes install parameters carrying config templates to the Agent.
+// dryRunTemplateParamPrefix 是向 Agent 传递配置模板的安装参数前缀。
+const dryRunTemplateParamPrefix = "config_template."
+
+// maxDryRunTemplateBytes caps the size of a single config template read from a package.
+// maxDryRunTemplateBytes 限制从安装包读取的单个配置模板大小。
+const maxDryRunTemplateBytes = 1 << 20
+
+// Package sources reported by a dry run.
+// 试运行报告的安装包来源。
+const (
+	dryRunPackageControlPlane = "control_plane"
+	dryRunPackageAgentCache   = "agent_cache"
+	dryRunPackageMirror       = "mirror"
+)
+
+// agentDryRunReport mirrors the JSON returned by the Agent for a dry-run INSTALL command.
+// agentDryRunReport 对应 Agent 在试运行 INSTALL 命令中返回的 JSON。
+type agentDryRunReport struct {
+	Valid          bool              `json:"valid"`
+	Errors         []string          `json:"errors"`
+	Warnings       []string          `json:"warnings"`
+	Precheck       json.RawMessage   `json:"precheck"`
+	TemplateSource string            `json:"template_source"`
+	RenderedFiles  map[string]string `json:"rendered_files"`
+}
+
+func (r *InstallationDryRunResult) addError(format string, args ...interface{}) {
+	r.Errors = append(r.Errors, fmt.Sprintf(format, args...))
+	r.Valid = false
+}
+
+func (r *InstallationDryRunResult) addWarning(format string, args ...interface{}) {
+	r.Warnings = append(r.Warnings, fmt.Sprintf(format, args...))
+}
+
+// runInstallationDryRun validates an installation request end to end without changing the target host.
+// runInstallationDryRun 端到端校验安装请求，但不修改目标主机。
+func (s *Service) runInstallationDryRun(ctx context.Context, req *InstallationRequest) *InstallationStatus {
+	startTime := time.Now()
+	result := &InstallationDryRunResult{Valid: true}
+
+	if err := validateInstallationRequest(req); err != nil {
+		result.addError("%v", err)
+		return newDryRunStatus(req, result, startTime)
+	}
+
+	hostID, err := parseHostID(req.HostID)
+	if err != nil {
+		result.addError("Invalid host ID: %v / 无效的主机 ID: %v", err, err)
+		return newDryRunStatus(req, result, startTime)
+	}
+
+	installDir := req.InstallDir
+	if installDir == "" {
+		installDir = seatunnel.DefaultInstallDir(req.Version)
+	}
+
+	if s.hostProvider != nil {
+		precheck, err := s.RunPrecheck(ctx, hostID, &PrecheckRequest{
+			InstallDir:      installDir,
+			Ports:           dryRunPorts(req),
+			Version:         req.Version,
+			ClusterPort:     req.ClusterPort,
+			WorkerPort:      req.WorkerPort,
+			HTTPPort:        req.HTTPPort,
+			AutoInstallJava: req.AutoInstallJava,
+		})
+		if err != nil {
+			result.addError("Precheck failed: %v / 预检查失败: %v", err, err)
+		} else {
+			result.Precheck = precheck
+			if precheck.OverallStatus == CheckStatusFailed {
+				result.addError("Precheck failed: %s / 预检查失败: %s", precheck.Summary, precheck.Summary)
+			}
+		}
+	} else {
+		result.addWarning("Host provider not configured, Control Plane precheck skipped / 主机提供者未配置，已跳过控制平面预检查")
+	}
+
+	var agentID string
+	connected := false
+	if s.agentManager != nil {
+		agentID, connected = s.agentManager.GetAgentByHostID(hostID)
+	}
+	if !connected || agentID == "" {
+		result.addError("Host agent not connected / 主机 Agent 未连接")
+	}
+
+	localPackagePath := s.checkDryRunPackage(ctx, req, agentID, connected, result)
+
+	params := buildInstallParams(req)
+	if result.Package != nil && result.Package.RemotePath != "" {
+		params["package_path"] = result.Package.RemotePath
+	} else {
+		delete(params, "package_path")
+	}
+	result.Params = redactInstallParams(params)
+
+	if !connected || agentID == "" {
+		return newDryRunStatus(req, result, startTime)
+	}
+
+	agentParams := make(map[string]string, len(params)+8)
+	for key, value := range params {
+		agentParams[key] = value
+	}
+	agentParams["dry_run"] = "true"
+	if localPackagePath != "" {
+		templates, err := readPackageConfigTemplates(localPackagePath)
+		if err != nil {
+			result.addWarning("Failed to read config templates from package: %v / 读取安装包配置模板失败: %v", err, err)
+		}
+		for name, content := range templates {
+			agentParams[dryRunTemplateParamPrefix+name] = content
+		}
+	}
+
+	success, output, err := s.agentManager.SendCommand(ctx, agentID, "install", agentParams)
+	if err != nil || !success {
+		if err == nil {
+			err = fmt.Errorf("%s", output)
+		}
+		result.addError("Agent dry run failed: %v / Agent 试运行失败: %v", err, err)
+		return newDryRunStatus(req, result, startTime)
+	}
+
+	var report agentDryRunReport
+	if err := json.Unmarshal([]byte(output), &report); err != nil {
+		result.addError("Invalid Agent dry run output: %v / Agent 试运行输出无效: %v", err, err)
+		return newDryRunStatus(req, result, startTime)
+	}
+	for _, message := range report.Errors {
+		result.addError("%s", message)
+	}
+	if !report.Valid && len(report.Errors) == 0 {
+		result.addError("Agent reported the request as invalid / Agent 判定请求无效")
+	}
+	result.Warnings = append(result.Warnings, report.Warnings...)
+	result.AgentPrecheck = report.Precheck
+	result.TemplateSource = report.TemplateSource
+	result.RenderedFiles = report.RenderedFiles
+
+	logger.InfoF(ctx, "[Installer] 安装试运行完成 / Installation dry run completed: host=%d, valid=%v, rendered=%d",
+		hostID, result.Valid, len(result.RenderedFiles))
+	return newDryRunStatus(req, result, startTime)
+}
+
+// checkDryRunPackage verifies that the package is available and can reach the Agent, returning its local path if any.
+// checkDryRunPackage 校验安装包可用且可传输到 Agent，如存在则返回其本地路径。
+func (s *Service) checkDryRunPackage(ctx context.Context, req *InstallationRequest, agentID string, connected bool, result *InstallationDryRunResult) string {
+	info := &DryRunPackageInfo{Mode: req.InstallMode}
+	result.Package = info
+
+	var localPath string
+	if req.InstallMode == InstallModeOffline {
+		resolved, err := s.resolveOfflinePackagePath(req)
+		if err != nil {
+			result.addError("%v", err)
+			return ""
+		}
+		localPath = resolved
+	} else {
+		localPath = filepath.Join(s.packageDir, packageFileName(req.Version))
+	}
+	info.LocalPath = localPath
+
+	stat, err := os.Stat(localPath)
+	if err == nil && stat.Mode().IsRegular() {
+		info.Source = dryRunPackageControlPlane
+		info.SizeBytes = stat.Size()
+		info.Available = true
+		if connected && agentID != "" {
+			info.Transferable = true
+			if remotePath, ok := s.getPreparedPackageRemotePath(agentID, req.Version, localPath); ok {
+				info.Source = dryRunPackageAgentCache
+				info.RemotePath = remotePath
+			}
+		}
+		return localPath
+	}
+
+	if req.InstallMode == InstallModeOffline {
+		result.addError("Offline package not found: %s / 离线安装包不存在: %s", localPath, localPath)
+		return ""
+	}
+
+	mirror := req.Mirror
+	if mirror == "" {
+		mirror = s.mirrorManager.BestMirror(ctx)
+	}
+	info.Source = dryRunPackageMirror
+	info.DownloadURL = getDownloadURLs(req.Version)[mirror]
+	if info.DownloadURL == "" {
+		result.addError("Unknown mirror: %s / 未知镜像源: %s", mirror, mirror)
+		return ""
+	}
+	size, err := probePackageURL(ctx, info.DownloadURL)
+	if err != nil {
+		result.addError("Package is not available from mirror %s: %v / 镜像源 %s 无法获取安装包: %v", mirror, err, mirror, err)
+		return ""
+	}
+	info.SizeBytes = size
+	info.Available = true
+	info.Transferable = connected && agentID != ""
+	result.addWarning("Package is not cached on the Control Plane and would be downloaded first; rendered configs use the Agent's templates / 控制平面未缓存安装包，将先下载；渲染配置使用 Agent 侧模板")
+	return ""
+}
+
+// probePackageURL issues a HEAD request and returns the advertised content length.
+// probePackageURL 发送 HEAD 请求并返回声明的内容长度。
+func probePackageURL(ctx context.Context, downloadURL string) (int64, error) {
+	client := downloadx.NewClient(10 * time.Second)
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, downloadURL, nil)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+	return resp.ContentLength, nil
+}
+
+// readPackageConfigTemplates reads the top-level files of the config directory from a tar.gz package.
+// readPackageConfigTemplates 从 tar.gz 安装包读取 config 目录下的顶层文件。
+func readPackageConfigTemplates(packagePath string) (map[string]string, error) {
+	file, err := os.Open(packagePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	gzReader, err := gzip.NewReader(file)
+	if err != nil {
+		return nil, err
+	}
+	defer gzReader.Close()
+
+	templates := make(map[string]string)
+	tarReader := tar.NewReader(gzReader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if header.Typeflag != tar.TypeReg || header.Size > maxDryRunTemplateBytes {
+			continue
+		}
+		parts := strings.SplitN(path.Clean(header.Name), "/", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		dir, name := path.Split(parts[1])
+		if dir != "config/" || name == "" {
+			continue
+		}
+		content, err := io.ReadAll(io.LimitReader(tarReader, maxDryRunTemplateBytes))
+		if err != nil {
+			return nil, err
+		}
+		templates[name] = string(content)
+	}
+	return templates, nil
+}
+
+// validateInstallationRequest checks the fields an installation cannot proceed without.
+// validateInstallationRequest 校验安装无法缺少的字段。
+func validateInstallationRequest(req *InstallationRequest) error {
+	if !packageVersionRegexp.MatchString(strings.TrimSpace(req.Version)) {
+		return ErrInvalidPackageVersion
+	}
+	switch req.InstallMode {
+	case InstallModeOnline, InstallModeOffline:
+	default:
+		return fmt.Errorf("invalid install mode: %s / 无效的安装模式: %s", req.InstallMode, req.InstallMode)
+	}
+	switch req.DeploymentMode {
+	case "", DeploymentModeHybrid, DeploymentModeSeparated:
+	default:
+		return fmt.Errorf("invalid deployment mode: %s / 无效的部署模式: %s", req.DeploymentMode, req.DeploymentMode)
+	}
+	switch req.NodeRole {
+	case "", NodeRoleMaster, NodeRoleWorker, NodeRoleMasterWorker:
+	default:
+		return fmt.Errorf("invalid node role: %s / 无效的节点角色: %s", req.NodeRole, req.NodeRole)
+	}
+	switch req.JavaMirror {
+	case "", "tsinghua", "adoptium":
+	default:
+		return fmt.Errorf("invalid java mirror: %s / 无效的 Java 下载源: %s", req.JavaMirror, req.JavaMirror)
+	}
+	for name, port := range map[string]int{"cluster_port": req.ClusterPort, "worker_port": req.WorkerPort, "http_port": req.HTTPPort} {
+		if port < 0 || port > 65535 {
+			return fmt.Errorf("invalid %s: %d / 无效的 %s: %d", name, port, name, port)
+		}
+	}
+	return validateVersionOptions(req)
+}
+
+// dryRunPorts returns the ports an installation would listen on.
+// dryRunPorts 返回安装后将监听的端口。
+func dryRunPorts(req *InstallationRequest) []int {
+	ports := make([]int, 0, 3)
+	for _, port := range []int{req.ClusterPort, req.WorkerPort, req.HTTPPort} {
+		if port > 0 {
+			ports = append(ports, port)
+		}
+	}
+	if len(ports) == 0 {
+		return nil
+	}
+	return ports
+}
+
+// redactInstallParams masks secret values before install parameters are returned to the caller.
+// redactInstallParams 在返回安装参数前屏蔽敏感值。
+func redactInstallParams(params map[string]string) map[string]string {
+	return secrets.RedactStringMap(params)
+}
+
+func newDryRunStatus(req *InstallationRequest, result *InstallationDryRunResult, startTime time.Time) *InstallationStatus {
+	now := time.Now()
+	status := &InstallationStatus{
+		ID:          uuid.New().String(),
+		HostID:      req.HostID,
+		ClusterID:   strings.TrimSpace(req.ClusterID),
+		Status:      StepStatusSuccess,
+		CurrentStep: InstallStepComplete,
+		Steps:       []StepInfo{},
+		Progress:    100,
+		Message:     "Dry run passed / 试运行通过",
+		Warnings:    result.Warnings,
+		StartTime:   startTime,
+		EndTime:     &now,
+		DryRun:      result,
+	}
+	if !result.Valid {
+		status.Status = StepStatusFailed
+		status.Message = "Dry run failed / 试运行未通过"
+		status.Error = strings.Join(result.Errors, "; ")
+	}
+	return status
+}
@@ -0,0 +1,223 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package installer
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/seatunnel/seatunnelX/internal/pkg/secrets"
+)
+
+type dryRunAgentManager struct {
+	commandType string
+	params      map[string]string
+	output      string
+}
+
+func (m *dryRunAgentManager) GetAgentByHostID(hostID uint) (string, bool) {
+	return "agent-1", true
+}
+
+func (m *dryRunAgentManager) SendInstallCommand(ctx context.Context, agentID string, params map[string]string) (string, error) {
+	return "", errors.New("dry run must not send a real install command")
+}
+
+func (m *dryRunAgentManager) GetCommandStatus(commandID string) (string, int, string, error) {
+	return "", 0, "", errors.New("not implemented")
+}
+
+func (m *dryRunAgentManager) SendCommand(ctx context.Context, agentID string, commandType string, params map[string]string) (bool, string, error) {
+	m.commandType = commandType
+	m.params = params
+	return true, m.output, nil
+}
+
+func (m *dryRunAgentManager) SendTransferPackageCommand(ctx context.Context, agentID string, version string, fileName string, chunk []byte, offset int64, totalSize int64, isLast bool, checksum string) (bool, int64, string, error) {
+	return false, 0, "", errors.New("dry run must not transfer packages")
+}
+
+func (m *dryRunAgentManager) CancelCommand(ctx context.Context, commandID string) error {
+	return nil
+}
+
+func writeDryRunPackage(t *testing.T, dir, version string) {
+	t.Helper()
+
+	file, err := os.Create(filepath.Join(dir, packageFileName(version)))
+	if err != nil {
+		t.Fatalf("failed to create package: %v", err)
+	}
+	defer file.Close()
+	gzWriter := gzip.NewWriter(file)
+	defer gzWriter.Close()
+	tarWriter := tar.NewWriter(gzWriter)
+	defer tarWriter.Close()
+
+	content := "seatunnel: {}\n"
+	header := &tar.Header{
+		Name:     "apache-seatunnel-" + version + "/config/seatunnel.yaml",
+		Mode:     0644,
+		Size:     int64(len(content)),
+		Typeflag: tar.TypeReg,
+	}
+	if err := tarWriter.WriteHeader(header); err != nil {
+		t.Fatalf("failed to write header: %v", err)
+	}
+	if _, err := tarWriter.Write([]byte(content)); err != nil {
+		t.Fatalf("failed to write entry: %v", err)
+	}
+}
+
+func TestService_StartInstallation_DryRun(t *testing.T) {
+	packageDir := t.TempDir()
+	writeDryRunPackage(t, packageDir, "2.3.12")
+
+	agentManager := &dryRunAgentManager{
+		output: `{"valid":true,"template_source":"request","rendered_files":{"config/seatunnel.yaml":"seatunnel:\n  engine: {}\n"}}`,
+	}
+	service := NewService(packageDir, agentManager)
+
+	status, err := service.StartInstallation(context.Background(), &InstallationRequest{
+		HostID:         "3",
+		Version:        "2.3.12",
+		InstallMode:    InstallModeOffline,
+		DeploymentMode: DeploymentModeHybrid,
+		NodeRole:       NodeRoleMasterWorker,
+		DryRun:         true,
+	})
+	if err != nil {
+		t.Fatalf("StartInstallation returned error: %v", err)
+	}
+	if status.Status != StepStatusSuccess || status.DryRun == nil || !status.DryRun.Valid {
+		t.Fatalf("expected successful dry run, got status=%s result=%+v", status.Status, status.DryRun)
+	}
+	if agentManager.commandType != "install" || agentManager.params["dry_run"] != "true" {
+		t.Fatalf("expected dry-run install command, got %s %v", agentManager.commandType, agentManager.params)
+	}
+	if agentManager.params[dryRunTemplateParamPrefix+"seatunnel.yaml"] != "seatunnel: {}\n" {
+		t.Fatalf("expected package config template to be forwarded, got %v", agentManager.params)
+	}
+	if status.DryRun.RenderedFiles["config/seatunnel.yaml"] == "" {
+		t.Fatalf("expected rendered seatunnel.yaml in result")
+	}
+	if _, err := service.GetInstallationStatus(context.Background(), 3); !errors.Is(err, ErrInstallationNotFound) {
+		t.Fatalf("expected dry run not to be tracked as an installation, got %v", err)
+	}
+}
+
+func TestService_StartInstallation_DryRunReportsMissingOfflinePackage(t *testing.T) {
+	agentManager := &dryRunAgentManager{output: `{"valid":true}`}
+	service := NewService(t.TempDir(), agentManager)
+
+	status, err := service.StartInstallation(context.Background(), &InstallationRequest{
+		HostID:      "3",
+		Version:     "2.3.12",
+		InstallMode: InstallModeOffline,
+		DryRun:      true,
+	})
+	if err != nil {
+		t.Fatalf("StartInstallation returned error: %v", err)
+	}
+	if status.Status != StepStatusFailed || status.DryRun.Valid {
+		t.Fatalf("expected failed dry run for missing package, got %+v", status.DryRun)
+	}
+	if status.DryRun.Package == nil || status.DryRun.Package.Available {
+		t.Fatalf("expected package to be reported unavailable, got %+v", status.DryRun.Package)
+	}
+}
+
+func TestInstallationTemplateSpec_SealsAndRedactsCredentials(t *testing.T) {
+	if err := secrets.Init(secrets.NewMasterKeyProvider("template-test")); err != nil {
+		t.Fatalf("secrets.Init returned error: %v", err)
+	}
+	spec := InstallationTemplateSpec{Checkpoint: &CheckpointConfig{
+		StorageType:      CheckpointStorageS3,
+		StorageAccessKey: "AKID",
+		StorageSecretKey: "secret",
+	}}
+
+	stored, err := spec.Value()
+	if err != nil {
+		t.Fatalf("Value returned error: %v", err)
+	}
+	if strings.Contains(string(stored.([]byte)), `"secret"`) || strings.Contains(string(stored.([]byte)), `"AKID"`) {
+		t.Fatalf("expected credentials to be sealed at rest, got %s", stored)
+	}
+	var loaded InstallationTemplateSpec
+	if err := loaded.Scan(stored); err != nil {
+		t.Fatalf("Scan returned error: %v", err)
+	}
+	if loaded.Checkpoint.StorageSecretKey != "secret" {
+		t.Fatalf("expected credentials to be opened on read, got %+v", loaded.Checkpoint)
+	}
+
+	template := &InstallationTemplate{Spec: loaded}
+	redacted := template.Redacted()
+	if redacted.Spec.Checkpoint.StorageSecretKey != secrets.Mask || template.Spec.Checkpoint.StorageSecretKey != "secret" {
+		t.Fatalf("expected a masked copy, got %+v", redacted.Spec.Checkpoint)
+	}
+
+	req := &InstallationRequest{Checkpoint: redacted.Spec.Checkpoint}
+	loaded.applyTemplate(req)
+	if req.Checkpoint.StorageSecretKey != "secret" || req.Checkpoint.StorageAccessKey != "AKID" {
+		t.Fatalf("expected masked credentials to be restored from the template, got %+v", req.Checkpoint)
+	}
+}
+
+func TestService_StartInstallation_DryRunForwardsJavaAutoInstall(t *testing.T) {
+	packageDir := t.TempDir()
+	writeDryRunPackage(t, packageDir, "2.3.12")
+	agentManager := &dryRunAgentManager{output: `{"valid":true}`}
+	service := NewService(packageDir, agentManager)
+
+	if _, err := service.StartInstallation(context.Background(), &InstallationRequest{
+		HostID:          "3",
+		Version:         "2.3.12",
+		InstallMode:     InstallModeOffline,
+		AutoInstallJava: true,
+		JavaMirror:      "adoptium",
+		DryRun:          true,
+	}); err != nil {
+		t.Fatalf("StartInstallation returned error: %v", err)
+	}
+	if agentManager.params["auto_install_java"] != "true" || agentManager.params["java_mirror"] != "adoptium" {
+		t.Fatalf("expected java auto-install params to be forwarded, got %v", agentManager.params)
+	}
+
+	status, err := service.StartInstallation(context.Background(), &InstallationRequest{
+		HostID:          "3",
+		Version:         "2.3.12",
+		InstallMode:     InstallModeOffline,
+		AutoInstallJava: true,
+		JavaMirror:      "unknown",
+		DryRun:          true,
+	})
+	if err != nil {
+		t.Fatalf("StartInstallation returned error: %v", err)
+	}
+	if status.DryRun.Valid || len(status.DryRun.Errors) == 0 || !strings.Contains(status.DryRun.Errors[0], "java mirror") {
+		t.Fatalf("expected invalid java mirror to be rejected, got %+v", status.DryRun)
+	}
+}
@@ -28,8 +28,11 @@ import (
 	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/seatunnel/seatunnelX/internal/apps/auth"
+	"github.com/seatunnel/seatunnelX/internal/apps/response"
 	"github.com/seatunnel/seatunnelX/internal/config"
 	"github.com/seatunnel/seatunnelX/internal/logger"
+	"github.com/seatunnel/seatunnelX/internal/pkg/pagex"
 )
 
 // Handler provides HTTP handlers for installation management.
@@ -49,38 +52,72 @@ func NewHandler(service *Service) *Handler {
 // ListPackagesResponse represents the response for listing packages.
 // ListPackagesResponse 表示获取安装包列表的响应。
 type ListPackagesResponse struct {
-	ErrorMsg string             `json:"error_msg"`
-	Data     *AvailableVersions `json:"data"`
+	response.Meta
+	Data *AvailableVersions `json:"data"`
+}
+
+// ListPackagesRequest represents the query for listing packages; paging and sorting apply to local packages.
+// ListPackagesRequest 表示获取安装包列表的查询参数；分页和排序作用于本地安装包。
+type ListPackagesRequest struct {
+	pagex.Query
+	// Version keeps local packages whose version contains the value / Version 仅保留版本号包含该值的本地安装包
+	Version string `json:"version" form:"version"`
+}
+
+// localPackageListSpec defines paging and the sortable fields of local packages.
+// localPackageListSpec 定义本地安装包的分页方式和可排序字段。
+var localPackageListSpec = pagex.Spec{
+	DefaultSize: 100,
+	MaxSize:     500,
+	Fields: map[string]string{
+		"version":     "version",
+		"file_size":   "file_size",
+		"uploaded_at": "uploaded_at",
+	},
+	DefaultSort: "-version",
 }
 
 // ListPackages handles GET /api/v1/packages - lists available packages.
 // ListPackages 处理 GET /api/v1/packages - 获取可用安装包列表。
 // @Tags packages
 // @Produce json
+// @Param request query ListPackagesRequest false "查询参数"
 // @Success 200 {object} ListPackagesResponse
 // @Router /api/v1/packages [get]
 func (h *Handler) ListPackages(c *gin.Context) {
+	req := &ListPackagesRequest{}
+	if err := c.ShouldBindQuery(req); err != nil {
+		response.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	page, err := req.Resolve(localPackageListSpec)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
 	versions, err := h.service.ListAvailableVersions(c.Request.Context())
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, ListPackagesResponse{ErrorMsg: err.Error()})
+		response.Error(c, http.StatusInternalServerError, err.Error())
 		return
 	}
+	versions.LocalPackages, versions.LocalTotal = pageLocalPackages(versions.LocalPackages, req.Version, page)
 
-	c.JSON(http.StatusOK, ListPackagesResponse{Data: versions})
+	response.OK(c, versions)
 }
 
 // GetPackageInfoResponse represents the response for getting package info.
 // GetPackageInfoResponse 表示获取安装包信息的响应。
 type GetPackageInfoResponse struct {
-	ErrorMsg string       `json:"error_msg"`
-	Data     *PackageInfo `json:"data"`
+	response.Meta
+	Data *PackageInfo `json:"data"`
 }
 
 // RefreshVersionsResponse represents the response for refreshing versions.
 // RefreshVersionsResponse 表示刷新版本列表的响应。
 type RefreshVersionsResponse struct {
-	ErrorMsg string   `json:"error_msg"`
-	Data     []string `json:"data"`
+	response.Meta
+	Data []string `json:"data"`
 }
 
 // RefreshVersions handles POST /api/v1/packages/versions/refresh - refreshes version list from Apache Archive.
@@ -94,15 +131,12 @@ func (h *Handler) RefreshVersions(c *gin.Context) {
 	if err != nil {
 		// Return fallback versions with warning / 返回备用版本并带警告
 		logger.WarnF(c.Request.Context(), "[Installer] 刷新版本列表失败，使用备用列表: %v", err)
-		c.JSON(http.StatusOK, RefreshVersionsResponse{
-			ErrorMsg: "无法从 Apache Archive 获取版本列表，使用备用列表 / Failed to fetch from Apache Archive, using fallback list",
-			Data:     versions,
-		})
+		response.ErrorWithData(c, http.StatusOK, "无法从 Apache Archive 获取版本列表，使用备用列表 / Failed to fetch from Apache Archive, using fallback list", versions)
 		return
 	}
 
 	logger.InfoF(c.Request.Context(), "[Installer] 刷新版本列表成功，共 %d 个版本", len(versions))
-	c.JSON(http.StatusOK, RefreshVersionsResponse{Data: versions})
+	response.OK(c, versions)
 }
 
 // GetPackageInfo handles GET /api/v1/packages/:version - gets package info.
@@ -115,31 +149,31 @@ func (h *Handler) RefreshVersions(c *gin.Context) {
 func (h *Handler) GetPackageInfo(c *gin.Context) {
 	version := c.Param("version")
 	if version == "" {
-		c.JSON(http.StatusBadRequest, GetPackageInfoResponse{ErrorMsg: "版本号不能为空 / Version is required"})
+		response.Error(c, http.StatusBadRequest, "版本号不能为空 / Version is required")
 		return
 	}
 
 	info, err := h.service.GetPackageInfo(c.Request.Context(), version)
 	if err != nil {
-		c.JSON(http.StatusNotFound, GetPackageInfoResponse{ErrorMsg: err.Error()})
+		response.Error(c, http.StatusNotFound, err.Error())
 		return
 	}
 
-	c.JSON(http.StatusOK, GetPackageInfoResponse{Data: info})
+	response.OK(c, info)
 }
 
 // UploadPackageResponse represents the response for uploading a package.
 // UploadPackageResponse 表示上传安装包的响应。
 type UploadPackageResponse struct {
-	ErrorMsg string       `json:"error_msg"`
-	Data     *PackageInfo `json:"data"`
+	response.Meta
+	Data *PackageInfo `json:"data"`
 }
 
 // UploadChunkResponse represents the response for uploading one package chunk.
 // UploadChunkResponse 表示上传单个安装包分片的响应。
 type UploadChunkResponse struct {
-	ErrorMsg string                    `json:"error_msg"`
-	Data     *PackageChunkUploadResult `json:"data"`
+	response.Meta
+	Data *PackageChunkUploadResult `json:"data"`
 }
 
 // UploadPackage handles POST /api/v1/packages/upload - uploads a package.
@@ -154,7 +188,7 @@ type UploadChunkResponse struct {
 func (h *Handler) UploadPackage(c *gin.Context) {
 	version := c.PostForm("version")
 	if version == "" {
-		c.JSON(http.StatusBadRequest, UploadPackageResponse{ErrorMsg: "版本号不能为空 / Version is required"})
+		response.Error(c, http.StatusBadRequest, "版本号不能为空 / Version is required")
 		return
 	}
 
@@ -170,19 +204,17 @@ func (h *Handler) UploadPackage(c *gin.Context) {
 			statusCode = http.StatusRequestEntityTooLarge
 			message = "上传内容被中断或超过网关限制（可能是 Cloudflare/Nginx 上传大小限制） / Upload interrupted or blocked by upstream size limit"
 		}
-		c.JSON(statusCode, UploadPackageResponse{ErrorMsg: message})
+		response.Error(c, statusCode, message)
 		return
 	}
 
 	maxPackageSize := config.GetMaxPackageSize()
 	if maxPackageSize > 0 && file.Size > maxPackageSize {
-		c.JSON(http.StatusRequestEntityTooLarge, UploadPackageResponse{
-			ErrorMsg: fmt.Sprintf(
-				"安装包超过配置上限（%.2f MB > %.2f MB） / Package exceeds configured max size",
-				float64(file.Size)/1024.0/1024.0,
-				float64(maxPackageSize)/1024.0/1024.0,
-			),
-		})
+		response.Error(c, http.StatusRequestEntityTooLarge, fmt.Sprintf(
+			"安装包超过配置上限（%.2f MB > %.2f MB） / Package exceeds configured max size",
+			float64(file.Size)/1024.0/1024.0,
+			float64(maxPackageSize)/1024.0/1024.0,
+		))
 		return
 	}
 
@@ -191,20 +223,22 @@ func (h *Handler) UploadPackage(c *gin.Context) {
 		switch {
 		case errors.Is(err, ErrInvalidPackageVersion),
 			errors.Is(err, ErrInvalidPackageFile),
+			errors.Is(err, ErrInvalidPackageLayout),
+			errors.Is(err, ErrPackageVersionMismatch),
 			errors.Is(err, ErrInvalidPackagePath):
-			c.JSON(http.StatusBadRequest, UploadPackageResponse{ErrorMsg: err.Error()})
+			response.Error(c, http.StatusBadRequest, err.Error())
 		case errors.Is(err, ErrPackageAlreadyExists):
-			c.JSON(http.StatusConflict, UploadPackageResponse{ErrorMsg: err.Error()})
+			response.Error(c, http.StatusConflict, err.Error())
 		case errors.Is(err, ErrPackageTooLarge):
-			c.JSON(http.StatusRequestEntityTooLarge, UploadPackageResponse{ErrorMsg: err.Error()})
+			response.Error(c, http.StatusRequestEntityTooLarge, err.Error())
 		default:
-			c.JSON(http.StatusInternalServerError, UploadPackageResponse{ErrorMsg: err.Error()})
+			response.Error(c, http.StatusInternalServerError, err.Error())
 		}
 		return
 	}
 
 	logger.InfoF(c.Request.Context(), "[Installer] 上传安装包成功: %s", version)
-	c.JSON(http.StatusOK, UploadPackageResponse{Data: info})
+	response.OK(c, info)
 }
 
 // UploadPackageChunk handles POST /api/v1/packages/upload/chunk - uploads one package chunk.
@@ -230,31 +264,31 @@ func (h *Handler) UploadPackageChunk(c *gin.Context) {
 	totalSizeStr := strings.TrimSpace(c.PostForm("total_size"))
 
 	if version == "" {
-		c.JSON(http.StatusBadRequest, UploadChunkResponse{ErrorMsg: "版本号不能为空 / Version is required"})
+		response.Error(c, http.StatusBadRequest, "版本号不能为空 / Version is required")
 		return
 	}
 	if uploadID == "" {
-		c.JSON(http.StatusBadRequest, UploadChunkResponse{ErrorMsg: "上传会话 ID 不能为空 / upload_id is required"})
+		response.Error(c, http.StatusBadRequest, "上传会话 ID 不能为空 / upload_id is required")
 		return
 	}
 	if chunkIndexStr == "" || totalChunksStr == "" || totalSizeStr == "" {
-		c.JSON(http.StatusBadRequest, UploadChunkResponse{ErrorMsg: "缺少分片元数据 / missing chunk metadata"})
+		response.Error(c, http.StatusBadRequest, "缺少分片元数据 / missing chunk metadata")
 		return
 	}
 
 	chunkIndex, err := strconv.Atoi(chunkIndexStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, UploadChunkResponse{ErrorMsg: "chunk_index 必须为整数 / chunk_index must be an integer"})
+		response.Error(c, http.StatusBadRequest, "chunk_index 必须为整数 / chunk_index must be an integer")
 		return
 	}
 	totalChunks, err := strconv.Atoi(totalChunksStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, UploadChunkResponse{ErrorMsg: "total_chunks 必须为整数 / total_chunks must be an integer"})
+		response.Error(c, http.StatusBadRequest, "total_chunks 必须为整数 / total_chunks must be an integer")
 		return
 	}
 	totalSize, err := strconv.ParseInt(totalSizeStr, 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, UploadChunkResponse{ErrorMsg: "total_size 必须为整数 / total_size must be an integer"})
+		response.Error(c, http.StatusBadRequest, "total_size 必须为整数 / total_size must be an integer")
 		return
 	}
 
@@ -268,7 +302,7 @@ func (h *Handler) UploadPackageChunk(c *gin.Context) {
 			statusCode = http.StatusRequestEntityTooLarge
 			message = "上传内容被中断或超过网关限制（可能是 Cloudflare/Nginx 上传大小限制） / Upload interrupted or blocked by upstream size limit"
 		}
-		c.JSON(statusCode, UploadChunkResponse{ErrorMsg: message})
+		response.Error(c, statusCode, message)
 		return
 	}
 
@@ -284,29 +318,31 @@ func (h *Handler) UploadPackageChunk(c *gin.Context) {
 		switch {
 		case errors.Is(err, ErrInvalidPackageVersion),
 			errors.Is(err, ErrInvalidPackageFile),
+			errors.Is(err, ErrInvalidPackageLayout),
+			errors.Is(err, ErrPackageVersionMismatch),
 			errors.Is(err, ErrInvalidPackagePath),
 			errors.Is(err, ErrInvalidUploadID),
 			errors.Is(err, ErrInvalidChunkIndex):
-			c.JSON(http.StatusBadRequest, UploadChunkResponse{ErrorMsg: err.Error()})
+			response.Error(c, http.StatusBadRequest, err.Error())
 		case errors.Is(err, ErrChunkOutOfOrder),
 			errors.Is(err, ErrPackageAlreadyExists):
-			c.JSON(http.StatusConflict, UploadChunkResponse{ErrorMsg: err.Error()})
+			response.Error(c, http.StatusConflict, err.Error())
 		case errors.Is(err, ErrPackageTooLarge):
-			c.JSON(http.StatusRequestEntityTooLarge, UploadChunkResponse{ErrorMsg: err.Error()})
+			response.Error(c, http.StatusRequestEntityTooLarge, err.Error())
 		default:
-			c.JSON(http.StatusInternalServerError, UploadChunkResponse{ErrorMsg: err.Error()})
+			response.Error(c, http.StatusInternalServerError, err.Error())
 		}
 		return
 	}
 
-	c.JSON(http.StatusOK, UploadChunkResponse{Data: result})
+	response.OK(c, result)
 }
 
 // DeletePackageResponse represents the response for deleting a package.
 // DeletePackageResponse 表示删除安装包的响应。
 type DeletePackageResponse struct {
-	ErrorMsg string `json:"error_msg"`
-	Data     any    `json:"data"`
+	response.Meta
+	Data any `json:"data"`
 }
 
 // DeletePackage handles DELETE /api/v1/packages/:version - deletes a local package.
@@ -319,17 +355,17 @@ type DeletePackageResponse struct {
 func (h *Handler) DeletePackage(c *gin.Context) {
 	version := c.Param("version")
 	if version == "" {
-		c.JSON(http.StatusBadRequest, DeletePackageResponse{ErrorMsg: "版本号不能为空 / Version is required"})
+		response.Error(c, http.StatusBadRequest, "版本号不能为空 / Version is required")
 		return
 	}
 
 	if err := h.service.DeletePackage(c.Request.Context(), version); err != nil {
-		c.JSON(http.StatusInternalServerError, DeletePackageResponse{ErrorMsg: err.Error()})
+		response.Error(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
 	logger.InfoF(c.Request.Context(), "[Installer] 删除安装包成功: %s", version)
-	c.JSON(http.StatusOK, DeletePackageResponse{})
+	response.OK(c, nil)
 }
 
 // ==================== Download APIs 下载 API ====================
@@ -337,15 +373,22 @@ func (h *Handler) DeletePackage(c *gin.Context) {
 // DownloadResponse represents the response for download operations.
 // DownloadResponse 表示下载操作的响应。
 type DownloadResponse struct {
-	ErrorMsg string        `json:"error_msg"`
-	Data     *DownloadTask `json:"data"`
+	response.Meta
+	Data *DownloadTask `json:"data"`
 }
 
 // DownloadListResponse represents the response for listing downloads.
 // DownloadListResponse 表示获取下载列表的响应。
 type DownloadListResponse struct {
-	ErrorMsg string          `json:"error_msg"`
-	Data     []*DownloadTask `json:"data"`
+	response.Meta
+	Data []*DownloadTask `json:"data"`
+}
+
+// MirrorListResponse represents the response for listing mirror probe results.
+// MirrorListResponse 表示获取镜像探测结果的响应。
+type MirrorListResponse struct {
+	response.Meta
+	Data []*MirrorProbeResult `json:"data"`
 }
 
 // StartDownload handles POST /api/v1/packages/download - starts downloading a package.
@@ -359,7 +402,7 @@ type DownloadListResponse struct {
 func (h *Handler) StartDownload(c *gin.Context) {
 	var req DownloadRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, DownloadResponse{ErrorMsg: err.Error()})
+		response.Error(c, http.StatusBadRequest, err.Error())
 		return
 	}
 
@@ -367,19 +410,19 @@ func (h *Handler) StartDownload(c *gin.Context) {
 	if err != nil {
 		// If download is already in progress, return the existing task / 如果下载已在进行中，返回现有任务
 		if err == ErrDownloadInProgress {
-			c.JSON(http.StatusOK, DownloadResponse{Data: task, ErrorMsg: "下载已在进行中 / Download already in progress"})
+			response.ErrorWithData(c, http.StatusOK, "下载已在进行中 / Download already in progress", task)
 			return
 		}
 		if errors.Is(err, ErrInvalidPackageVersion) {
-			c.JSON(http.StatusBadRequest, DownloadResponse{ErrorMsg: err.Error()})
+			response.Error(c, http.StatusBadRequest, err.Error())
 			return
 		}
-		c.JSON(http.StatusInternalServerError, DownloadResponse{ErrorMsg: err.Error()})
+		response.Error(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
 	logger.InfoF(c.Request.Context(), "[Installer] 开始下载安装包: %s from %s", req.Version, req.Mirror)
-	c.JSON(http.StatusOK, DownloadResponse{Data: task})
+	response.OK(c, task)
 }
 
 // GetDownloadStatus handles GET /api/v1/packages/download/:version - gets download status.
@@ -392,17 +435,21 @@ func (h *Handler) StartDownload(c *gin.Context) {
 func (h *Handler) GetDownloadStatus(c *gin.Context) {
 	version := c.Param("version")
 	if version == "" {
-		c.JSON(http.StatusBadRequest, DownloadResponse{ErrorMsg: "版本号不能为空 / Version is required"})
+		response.Error(c, http.StatusBadRequest, "版本号不能为空 / Version is required")
 		return
 	}
 
 	task, err := h.service.GetDownloadStatus(c.Request.Context(), version)
 	if err != nil {
-		c.JSON(http.StatusNotFound, DownloadResponse{ErrorMsg: err.Error()})
+		if errors.Is(err, ErrInvalidPackageVersion) {
+			response.Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		response.Error(c, http.StatusNotFound, err.Error())
 		return
 	}
 
-	c.JSON(http.StatusOK, DownloadResponse{Data: task})
+	response.OK(c, task)
 }
 
 // CancelDownload handles POST /api/v1/packages/download/:version/cancel - cancels a download.
@@ -415,22 +462,22 @@ func (h *Handler) GetDownloadStatus(c *gin.Context) {
 func (h *Handler) CancelDownload(c *gin.Context) {
 	version := c.Param("version")
 	if version == "" {
-		c.JSON(http.StatusBadRequest, DownloadResponse{ErrorMsg: "版本号不能为空 / Version is required"})
+		response.Error(c, http.StatusBadRequest, "版本号不能为空 / Version is required")
 		return
 	}
 
 	task, err := h.service.CancelDownload(c.Request.Context(), version)
 	if err != nil {
 		if errors.Is(err, ErrInvalidPackageVersion) {
-			c.JSON(http.StatusBadRequest, DownloadResponse{ErrorMsg: err.Error()})
+			response.Error(c, http.StatusBadRequest, err.Error())
 			return
 		}
-		c.JSON(http.StatusInternalServerError, DownloadResponse{ErrorMsg: err.Error()})
+		response.Error(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
 	logger.InfoF(c.Request.Context(), "[Installer] 取消下载: %s", version)
-	c.JSON(http.StatusOK, DownloadResponse{Data: task})
+	response.OK(c, task)
 }
 
 // ListDownloads handles GET /api/v1/packages/downloads - lists all download tasks.
@@ -441,7 +488,20 @@ func (h *Handler) CancelDownload(c *gin.Context) {
 // @Router /api/v1/packages/downloads [get]
 func (h *Handler) ListDownloads(c *gin.Context) {
 	tasks := h.service.ListDownloads(c.Request.Context())
-	c.JSON(http.StatusOK, DownloadListResponse{Data: tasks})
+	response.OK(c, tasks)
+}
+
+// ListMirrors handles GET /api/v1/packages/mirrors - lists mirror availability and latency.
+// ListMirrors 处理 GET /api/v1/packages/mirrors - 获取镜像可用性和延迟。
+// @Tags packages
+// @Produce json
+// @Param refresh query bool false "强制重新探测 / Force re-probe"
+// @Success 200 {object} MirrorListResponse
+// @Router /api/v1/packages/mirrors [get]
+func (h *Handler) ListMirrors(c *gin.Context) {
+	refresh := c.Query("refresh") == "1" || c.Query("refresh") == "true"
+	results := h.service.ListMirrors(c.Request.Context(), refresh)
+	response.OK(c, results)
 }
 
 // ==================== Precheck APIs 预检查 API ====================
@@ -454,20 +514,44 @@ type PrecheckRequest struct {
 	MinDiskSpaceMB int64  `json:"min_disk_space_mb"`
 	InstallDir     string `json:"install_dir"`
 	Ports          []int  `json:"ports"`
+	// Version is the SeaTunnel version to install, used to size the disk space check.
+	// Version 是待安装的 SeaTunnel 版本，用于计算磁盘空间检查所需大小。
+	Version string `json:"version,omitempty"`
+	// ExpansionFactor overrides the ratio of install dir space to package size.
+	// ExpansionFactor 覆盖安装目录所需空间与安装包大小之比。
+	ExpansionFactor float64 `json:"expansion_factor,omitempty"`
+	// ClusterPort is the Hazelcast port checked against firewall rules; defaults to the first port.
+	// ClusterPort 是需要检查防火墙规则的 Hazelcast 端口；默认取第一个端口。
+	ClusterPort int `json:"cluster_port,omitempty"`
+	// WorkerPort and HTTPPort map busy ports to install request fields; default to 5802 and 8080.
+	// WorkerPort 与 HTTPPort 用于将被占用端口对应到安装请求字段；默认为 5802 与 8080。
+	WorkerPort int `json:"worker_port,omitempty"`
+	HTTPPort   int `json:"http_port,omitempty"`
+	// PortRangeStart and PortRangeEnd bound the scan for alternative ports; by default the
+	// 100 ports after each busy port are scanned.
+	// PortRangeStart 与 PortRangeEnd 限定替代端口的扫描范围；默认扫描每个被占用端口之后的 100 个端口。
+	PortRangeStart int `json:"port_range_start,omitempty"`
+	PortRangeEnd   int `json:"port_range_end,omitempty"`
+	// Checkpoint, when set, adds a write/read/delete round trip against the checkpoint storage.
+	// Checkpoint 设置时，增加一次针对 checkpoint 存储的写入/读取/删除往返校验。
+	Checkpoint *CheckpointConfig `json:"checkpoint,omitempty"`
+	// AutoInstallJava downgrades a missing Java to a warning because the install will provide it.
+	// AutoInstallJava 将缺少 Java 降级为警告，因为安装过程会自动提供。
+	AutoInstallJava bool `json:"auto_install_java,omitempty"`
 }
 
 // PrecheckResponse represents the response for precheck.
 // PrecheckResponse 表示预检查响应。
 type PrecheckResponse struct {
-	ErrorMsg string          `json:"error_msg"`
-	Data     *PrecheckResult `json:"data"`
+	response.Meta
+	Data *PrecheckResult `json:"data"`
 }
 
 // RuntimeStorageValidationResponse represents runtime storage validation response.
 // RuntimeStorageValidationResponse 表示运行时存储校验响应。
 type RuntimeStorageValidationResponse struct {
-	ErrorMsg string                        `json:"error_msg"`
-	Data     *RuntimeStorageValidationResult `json:"data"`
+	response.Meta
+	Data *RuntimeStorageValidationResult `json:"data"`
 }
 
 // RunPrecheck handles POST /api/v1/hosts/:id/precheck - runs precheck on a host.
@@ -482,7 +566,7 @@ type RuntimeStorageValidationResponse struct {
 func (h *Handler) RunPrecheck(c *gin.Context) {
 	hostID, err := strconv.ParseUint(c.Param("id"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, PrecheckResponse{ErrorMsg: "无效的主机 ID / Invalid host ID"})
+		response.Error(c, http.StatusBadRequest, "无效的主机 ID / Invalid host ID")
 		return
 	}
 
@@ -494,11 +578,11 @@ func (h *Handler) RunPrecheck(c *gin.Context) {
 
 	result, err := h.service.RunPrecheck(c.Request.Context(), uint(hostID), &req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, PrecheckResponse{ErrorMsg: err.Error()})
+		response.Error(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
-	c.JSON(http.StatusOK, PrecheckResponse{Data: result})
+	response.OK(c, result)
 }
 
 // ValidateRuntimeStorage handles POST /api/v1/installer/runtime-storage/validate.
@@ -506,15 +590,48 @@ func (h *Handler) RunPrecheck(c *gin.Context) {
 func (h *Handler) ValidateRuntimeStorage(c *gin.Context) {
 	var req RuntimeStorageValidationRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, RuntimeStorageValidationResponse{ErrorMsg: err.Error()})
+		response.Error(c, http.StatusBadRequest, err.Error())
 		return
 	}
 	result, err := h.service.ValidateRuntimeStorage(c.Request.Context(), &req)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, RuntimeStorageValidationResponse{ErrorMsg: err.Error()})
+		response.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	response.OK(c, result)
+}
+
+// ResourceAdviceResponse represents the resource planning advice response.
+// ResourceAdviceResponse 表示资源规划建议响应。
+type ResourceAdviceResponse struct {
+	response.Meta
+	Data *ResourceAdvice `json:"data"`
+}
+
+// AdviseResources handles POST /api/v1/installer/resource-advice - recommends a deployment for the chosen hosts.
+// AdviseResources 处理 POST /api/v1/installer/resource-advice - 为选定主机推荐部署方案。
+// @Tags installation
+// @Accept json
+// @Produce json
+// @Param request body ResourceAdviceRequest true "选定主机与计划配置"
+// @Success 200 {object} ResourceAdviceResponse
+// @Router /api/v1/installer/resource-advice [post]
+func (h *Handler) AdviseResources(c *gin.Context) {
+	var req ResourceAdviceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	advice, err := h.service.AdviseResources(c.Request.Context(), &req)
+	if err != nil {
+		if errors.Is(err, ErrNoHostsToAdvise) {
+			response.Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		response.Error(c, http.StatusInternalServerError, err.Error())
 		return
 	}
-	c.JSON(http.StatusOK, RuntimeStorageValidationResponse{Data: result})
+	response.OK(c, advice)
 }
 
 // ==================== Installation APIs 安装 API ====================
@@ -522,8 +639,8 @@ func (h *Handler) ValidateRuntimeStorage(c *gin.Context) {
 // InstallResponse represents the response for installation.
 // InstallResponse 表示安装响应。
 type InstallResponse struct {
-	ErrorMsg string              `json:"error_msg"`
-	Data     *InstallationStatus `json:"data"`
+	response.Meta
+	Data *InstallationStatus `json:"data"`
 }
 
 // StartInstallation handles POST /api/v1/hosts/:id/install - starts installation.
@@ -538,27 +655,43 @@ type InstallResponse struct {
 func (h *Handler) StartInstallation(c *gin.Context) {
 	hostID, err := strconv.ParseUint(c.Param("id"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, InstallResponse{ErrorMsg: "无效的主机 ID / Invalid host ID"})
+		response.Error(c, http.StatusBadRequest, "无效的主机 ID / Invalid host ID")
 		return
 	}
 
 	var req InstallationRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, InstallResponse{ErrorMsg: err.Error()})
+		response.Error(c, http.StatusBadRequest, err.Error())
 		return
 	}
 
 	// Set host ID from path / 从路径设置主机 ID
 	req.HostID = strconv.FormatUint(hostID, 10)
 
+	// Installing into an existing cluster requires operate permission on that cluster.
+	// 安装到已有集群时需要该集群的运维权限。
+	if clusterID, err := strconv.ParseUint(req.ClusterID, 10, 32); err == nil && clusterID > 0 {
+		if !auth.AuthorizeResource(c, auth.PermissionOperate, auth.ResourceCluster, uint(clusterID)) {
+			return
+		}
+	}
+
 	status, err := h.service.StartInstallation(c.Request.Context(), &req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, InstallResponse{ErrorMsg: err.Error()})
+		if errors.Is(err, ErrInstallationTemplateNotFound) || errors.Is(err, ErrUnsupportedVersionOption) || errors.Is(err, ErrStepNotSkippable) || errors.Is(err, ErrPortCollision) || errors.Is(err, ErrInvalidHazelcastNetwork) || errors.Is(err, ErrInvalidPackageDelivery) || errors.Is(err, ErrVersionNotApproved) {
+			response.Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		if errors.Is(err, ErrNoFreePort) || errors.Is(err, ErrHostUnderMaintenance) {
+			response.Error(c, http.StatusConflict, err.Error())
+			return
+		}
+		response.Error(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
 	logger.InfoF(c.Request.Context(), "[Installer] 开始安装: host=%d, version=%s", hostID, req.Version)
-	c.JSON(http.StatusOK, InstallResponse{Data: status})
+	response.OK(c, status)
 }
 
 // GetInstallationStatus handles GET /api/v1/hosts/:id/install/status - gets installation status.
@@ -571,17 +704,17 @@ func (h *Handler) StartInstallation(c *gin.Context) {
 func (h *Handler) GetInstallationStatus(c *gin.Context) {
 	hostID, err := strconv.ParseUint(c.Param("id"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, InstallResponse{ErrorMsg: "无效的主机 ID / Invalid host ID"})
+		response.Error(c, http.StatusBadRequest, "无效的主机 ID / Invalid host ID")
 		return
 	}
 
 	status, err := h.service.GetInstallationStatus(c.Request.Context(), uint(hostID))
 	if err != nil {
-		c.JSON(http.StatusNotFound, InstallResponse{ErrorMsg: err.Error()})
+		response.Error(c, http.StatusNotFound, err.Error())
 		return
 	}
 
-	c.JSON(http.StatusOK, InstallResponse{Data: status})
+	response.OK(c, status)
 }
 
 // RetryStepRequest represents the request for retrying a step.
@@ -602,24 +735,24 @@ type RetryStepRequest struct {
 func (h *Handler) RetryStep(c *gin.Context) {
 	hostID, err := strconv.ParseUint(c.Param("id"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, InstallResponse{ErrorMsg: "无效的主机 ID / Invalid host ID"})
+		response.Error(c, http.StatusBadRequest, "无效的主机 ID / Invalid host ID")
 		return
 	}
 
 	var req RetryStepRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, InstallResponse{ErrorMsg: err.Error()})
+		response.Error(c, http.StatusBadRequest, err.Error())
 		return
 	}
 
 	status, err := h.service.RetryStep(c.Request.Context(), uint(hostID), req.Step)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, InstallResponse{ErrorMsg: err.Error()})
+		response.Error(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
 	logger.InfoF(c.Request.Context(), "[Installer] 重试步骤: host=%d, step=%s", hostID, req.Step)
-	c.JSON(http.StatusOK, InstallResponse{Data: status})
+	response.OK(c, status)
 }
 
 // CancelInstallation handles POST /api/v1/hosts/:id/install/cancel - cancels installation.
@@ -632,16 +765,16 @@ func (h *Handler) RetryStep(c *gin.Context) {
 func (h *Handler) CancelInstallation(c *gin.Context) {
 	hostID, err := strconv.ParseUint(c.Param("id"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, InstallResponse{ErrorMsg: "无效的主机 ID / Invalid host ID"})
+		response.Error(c, http.StatusBadRequest, "无效的主机 ID / Invalid host ID")
 		return
 	}
 
 	status, err := h.service.CancelInstallation(c.Request.Context(), uint(hostID))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, InstallResponse{ErrorMsg: err.Error()})
+		response.Error(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
 	logger.InfoF(c.Request.Context(), "[Installer] 取消安装: host=%d", hostID)
-	c.JSON(http.StatusOK, InstallResponse{Data: status})
+	response.OK(c, status)
 }
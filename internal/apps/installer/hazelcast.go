@@ -0,0 +1,104 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package installer
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/seatunnel/seatunnelX/internal/seatunnel"
+)
+
+// ErrInvalidHazelcastNetwork is returned when the advanced Hazelcast network settings are invalid.
+// ErrInvalidHazelcastNetwork 在 Hazelcast 高级网络配置无效时返回。
+var ErrInvalidHazelcastNetwork = errors.New("invalid hazelcast network config / 无效的 Hazelcast 网络配置")
+
+// validateHazelcastNetwork validates and normalizes the advanced Hazelcast network settings in place.
+// validateHazelcastNetwork 校验并就地规范化 Hazelcast 高级网络配置。
+func validateHazelcastNetwork(req *InstallationRequest) error {
+	cfg := req.Hazelcast
+	if cfg == nil {
+		return nil
+	}
+
+	cfg.PublicAddress = strings.TrimSpace(cfg.PublicAddress)
+	if cfg.PublicAddress != "" {
+		if err := seatunnel.ValidateHazelcastAddress(cfg.PublicAddress); err != nil {
+			return fmt.Errorf("%w: public_address: %v", ErrInvalidHazelcastNetwork, err)
+		}
+	}
+
+	interfaces := make([]string, 0, len(cfg.Interfaces))
+	for _, value := range cfg.Interfaces {
+		if strings.TrimSpace(value) == "" {
+			continue
+		}
+		pattern, err := seatunnel.HazelcastInterfacePattern(value)
+		if err != nil {
+			return fmt.Errorf("%w: interfaces: %v", ErrInvalidHazelcastNetwork, err)
+		}
+		interfaces = append(interfaces, pattern)
+	}
+	cfg.Interfaces = interfaces
+
+	advertised := make([]string, 0, len(cfg.AdvertisedAddresses))
+	for _, value := range cfg.AdvertisedAddresses {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		if err := seatunnel.ValidateHazelcastAddress(value); err != nil {
+			return fmt.Errorf("%w: advertised_addresses: %v", ErrInvalidHazelcastNetwork, err)
+		}
+		// Separated clusters listen on two ports, so the port of each member must be explicit.
+		// 分离模式集群监听两个端口，因此每个成员都必须显式指定端口。
+		if req.DeploymentMode == DeploymentModeSeparated && !seatunnel.HazelcastAddressHasPort(value) {
+			return fmt.Errorf("%w: advertised_addresses: %q needs a port in separated mode", ErrInvalidHazelcastNetwork, value)
+		}
+		advertised = append(advertised, value)
+	}
+	cfg.AdvertisedAddresses = advertised
+
+	groups, err := seatunnel.NormalizeHazelcastRESTEndpointGroups(cfg.RESTEndpointGroups)
+	if err != nil {
+		return fmt.Errorf("%w: rest_endpoint_groups: %v", ErrInvalidHazelcastNetwork, err)
+	}
+	cfg.RESTEndpointGroups = groups
+	return nil
+}
+
+// hazelcastNetworkParams converts the Hazelcast network settings into Agent install parameters.
+// hazelcastNetworkParams 将 Hazelcast 网络配置转换为 Agent 安装参数。
+func hazelcastNetworkParams(cfg *HazelcastNetworkConfig, params map[string]string) {
+	if cfg == nil {
+		return
+	}
+	if cfg.PublicAddress != "" {
+		params["hazelcast_public_address"] = cfg.PublicAddress
+	}
+	if len(cfg.Interfaces) > 0 {
+		params["hazelcast_interfaces"] = strings.Join(cfg.Interfaces, ",")
+	}
+	if len(cfg.AdvertisedAddresses) > 0 {
+		params["hazelcast_advertised_addresses"] = strings.Join(cfg.AdvertisedAddresses, ",")
+	}
+	if len(cfg.RESTEndpointGroups) > 0 {
+		params["hazelcast_rest_endpoint_groups"] = strings.Join(cfg.RESTEndpointGroups, ",")
+	}
+}
@@ -0,0 +1,69 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package installer
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidateHazelcastNetworkNormalizesSettings(t *testing.T) {
+	req := &InstallationRequest{
+		DeploymentMode: DeploymentModeHybrid,
+		Hazelcast: &HazelcastNetworkConfig{
+			PublicAddress:       " 203.0.113.10 ",
+			Interfaces:          []string{"10.0.1.0/24", " ", "172.16.*.*"},
+			AdvertisedAddresses: []string{"203.0.113.10", "203.0.113.11:5801"},
+			RESTEndpointGroups:  []string{"data", "cluster_write", "health_check"},
+		},
+	}
+	if err := validateHazelcastNetwork(req); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	params := buildInstallParams(req)
+	expected := map[string]string{
+		"hazelcast_public_address":       "203.0.113.10",
+		"hazelcast_interfaces":           "10.0.1.*,172.16.*.*",
+		"hazelcast_advertised_addresses": "203.0.113.10,203.0.113.11:5801",
+		"hazelcast_rest_endpoint_groups": "DATA,CLUSTER_WRITE,HEALTH_CHECK",
+	}
+	for key, want := range expected {
+		if params[key] != want {
+			t.Fatalf("param %s = %q, want %q", key, params[key], want)
+		}
+	}
+}
+
+func TestValidateHazelcastNetworkRejectsInvalidSettings(t *testing.T) {
+	invalid := []*InstallationRequest{
+		{Hazelcast: &HazelcastNetworkConfig{PublicAddress: "203.0.113.10:99999"}},
+		{Hazelcast: &HazelcastNetworkConfig{Interfaces: []string{"fd00::/64"}}},
+		{Hazelcast: &HazelcastNetworkConfig{RESTEndpointGroups: []string{"HEALTH_CHECK"}}},
+		// Separated clusters need explicit member ports / 分离模式集群需要显式的成员端口
+		{DeploymentMode: DeploymentModeSeparated, Hazelcast: &HazelcastNetworkConfig{AdvertisedAddresses: []string{"203.0.113.10"}}},
+	}
+	for i, req := range invalid {
+		if err := validateHazelcastNetwork(req); !errors.Is(err, ErrInvalidHazelcastNetwork) {
+			t.Fatalf("case %d: expected ErrInvalidHazelcastNetwork, got %v", i, err)
+		}
+	}
+	if err := validateHazelcastNetwork(&InstallationRequest{}); err != nil {
+		t.Fatalf("expected nil config to pass, got %v", err)
+	}
+}
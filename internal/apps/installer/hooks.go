@@ -0,0 +1,169 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package installer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/seatunnel/seatunnelX/internal/config"
+	"github.com/seatunnel/seatunnelX/internal/logger"
+	"github.com/seatunnel/seatunnelX/internal/pkg/installhook"
+)
+
+// ErrStepNotSkippable indicates the request asks to skip a step the installation cannot do without.
+// ErrStepNotSkippable 表示请求跳过了安装必不可少的步骤。
+var ErrStepNotSkippable = errors.New("installation step cannot be skipped / 该安装步骤不可跳过")
+
+// skippedStepMessage is shown on steps skipped by request.
+// skippedStepMessage 是按请求跳过的步骤上显示的消息。
+const skippedStepMessage = "Skipped by request / 按请求跳过"
+
+// skippableSteps lists the steps a request may skip; download, extract, cluster configuration and
+// registration produce the node itself and always run.
+// skippableSteps 列出请求可以跳过的步骤；下载、解压、集群配置与注册构成节点本身，始终执行。
+var skippableSteps = map[InstallStep]bool{
+	InstallStepInstallJava:         true,
+	InstallStepConfigureCheckpoint: true,
+	InstallStepConfigureIMAP:       true,
+	InstallStepConfigureJVM:        true,
+	InstallStepInstallPlugins:      true,
+	InstallStepConfigureSystemd:    true,
+}
+
+// installHooks are the step hooks sent with every installation, registered from the installer config.
+// installHooks 是随每次安装下发的步骤钩子，由安装配置注册。
+var installHooks []installhook.Hook
+
+// RegisterInstallHooks registers the configured step hooks; hooks on unknown steps are ignored.
+// It must be called during startup before any installation starts.
+// RegisterInstallHooks 注册配置的步骤钩子；挂载在未知步骤上的钩子会被忽略。
+// 必须在启动阶段、任何安装开始前调用。
+func RegisterInstallHooks(hooks []config.InstallHookConfig) {
+	installHooks = nil
+	for _, hookConfig := range hooks {
+		hook := hookConfig.Hook()
+		if !isInstallStep(InstallStep(hook.Step)) {
+			logger.WarnF(context.Background(), "[Installer] 忽略未知步骤上的钩子 / Ignoring hook on unknown step: hook=%s, step=%s", hook.Name, hook.Step)
+			continue
+		}
+		installHooks = append(installHooks, hook)
+	}
+}
+
+// isInstallStep reports whether the step is one of the Agent installation steps.
+// isInstallStep 判断是否为 Agent 安装步骤之一。
+func isInstallStep(step InstallStep) bool {
+	for _, s := range createInitialSteps() {
+		if s.Step == step && step != InstallStepComplete {
+			return true
+		}
+	}
+	return false
+}
+
+// validateSkipSteps rejects unknown or mandatory steps in SkipSteps.
+// validateSkipSteps 拒绝 SkipSteps 中的未知步骤或必需步骤。
+func validateSkipSteps(req *InstallationRequest) error {
+	for _, step := range req.SkipSteps {
+		if !skippableSteps[step] {
+			return fmt.Errorf("%w: %s", ErrStepNotSkippable, step)
+		}
+	}
+	return nil
+}
+
+// stepSkipped reports whether the request asks to skip the step.
+// stepSkipped 判断请求是否要求跳过该步骤。
+func (req *InstallationRequest) stepSkipped(step InstallStep) bool {
+	for _, skipped := range req.SkipSteps {
+		if skipped == step {
+			return true
+		}
+	}
+	return false
+}
+
+// hooksForRequest returns the registered hooks whose step is not skipped by the request.
+// hooksForRequest 返回所挂载步骤未被请求跳过的已注册钩子。
+func hooksForRequest(req *InstallationRequest) []installhook.Hook {
+	var hooks []installhook.Hook
+	for _, hook := range installHooks {
+		if !req.stepSkipped(InstallStep(hook.Step)) {
+			hooks = append(hooks, hook)
+		}
+	}
+	return hooks
+}
+
+// markSkippedSteps marks the steps skipped by the request on a new installation status.
+// markSkippedSteps 在新的安装状态上标记按请求跳过的步骤。
+func markSkippedSteps(status *InstallationStatus, req *InstallationRequest) {
+	for i := range status.Steps {
+		if req.stepSkipped(status.Steps[i].Step) {
+			status.Steps[i].Status = StepStatusSkipped
+			status.Steps[i].Progress = 100
+			status.Steps[i].Message = skippedStepMessage
+		}
+	}
+}
+
+// recordHookResult attaches a hook result to its step, replacing an earlier report of the same hook.
+// recordHookResult 将钩子结果挂到对应步骤，替换同一钩子先前的上报。
+func recordHookResult(status *InstallationStatus, result installhook.Result) {
+	for i := range status.Steps {
+		step := &status.Steps[i]
+		if string(step.Step) != result.Step {
+			continue
+		}
+		for j := range step.Hooks {
+			if step.Hooks[j].Name == result.Name && step.Hooks[j].Phase == result.Phase {
+				step.Hooks[j] = result
+				return
+			}
+		}
+		step.Hooks = append(step.Hooks, result)
+		return
+	}
+}
+
+// describeHookResult renders a hook result as a short step message.
+// describeHookResult 将钩子结果渲染为简短的步骤消息。
+func describeHookResult(result installhook.Result) string {
+	if result.Success {
+		return fmt.Sprintf("%s hook %s succeeded in %s / %s 钩子 %s 执行成功，耗时 %s",
+			result.Phase, result.Name, time.Duration(result.DurationMs)*time.Millisecond,
+			result.Phase, result.Name, time.Duration(result.DurationMs)*time.Millisecond)
+	}
+	return fmt.Sprintf("%s hook %s failed: %s / %s 钩子 %s 执行失败：%s",
+		result.Phase, result.Name, result.Error, result.Phase, result.Name, result.Error)
+}
+
+// splitHookTrailer separates the hook results trailer from the final Agent output.
+// splitHookTrailer 从 Agent 最终输出中分离钩子结果尾行。
+func splitHookTrailer(output string) (string, []installhook.Result) {
+	results := installhook.ParseTrailer(output)
+	if results == nil {
+		return output, nil
+	}
+	idx := strings.LastIndex(output, "\n"+installhook.TrailerPrefix)
+	return output[:idx], results
+}
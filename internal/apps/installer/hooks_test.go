@@ -0,0 +1,127 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package installer
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/seatunnel/seatunnelX/internal/config"
+	"github.com/seatunnel/seatunnelX/internal/pkg/installhook"
+)
+
+func TestValidateSkipSteps(t *testing.T) {
+	req := &InstallationRequest{SkipSteps: []InstallStep{InstallStepConfigureSystemd, InstallStepInstallPlugins}}
+	if err := validateSkipSteps(req); err != nil {
+		t.Fatalf("expected optional steps to be skippable, got %v", err)
+	}
+
+	for _, step := range []InstallStep{InstallStepDownload, InstallStepConfigureCluster, InstallStep("unknown")} {
+		req.SkipSteps = []InstallStep{step}
+		if err := validateSkipSteps(req); !errors.Is(err, ErrStepNotSkippable) {
+			t.Fatalf("expected ErrStepNotSkippable for %s, got %v", step, err)
+		}
+	}
+}
+
+func TestNewInstallationStatusMarksSkippedSteps(t *testing.T) {
+	status := newInstallationStatus(&InstallationRequest{HostID: "1", SkipSteps: []InstallStep{InstallStepConfigureSystemd}})
+	for _, step := range status.Steps {
+		skipped := step.Status == StepStatusSkipped
+		if skipped != (step.Step == InstallStepConfigureSystemd) {
+			t.Fatalf("unexpected status %s for step %s", step.Status, step.Step)
+		}
+	}
+
+	// Progress on a later step completes earlier steps but keeps skipped ones
+	updateStepStatus(status, string(InstallStepRegisterCluster), 50, "[register_cluster] registering")
+	for _, step := range status.Steps {
+		switch step.Step {
+		case InstallStepConfigureSystemd:
+			if step.Status != StepStatusSkipped {
+				t.Fatalf("expected skipped step to stay skipped, got %s", step.Status)
+			}
+		case InstallStepRegisterCluster:
+			if step.Status != StepStatusRunning {
+				t.Fatalf("expected current step to run, got %s", step.Status)
+			}
+		case InstallStepComplete:
+		default:
+			if step.Status != StepStatusSuccess {
+				t.Fatalf("expected %s to be complete, got %s", step.Step, step.Status)
+			}
+		}
+	}
+}
+
+func TestBuildInstallParamsSkipStepsAndHooks(t *testing.T) {
+	RegisterInstallHooks([]config.InstallHookConfig{
+		{Name: "notify", Step: "configure_cluster", Phase: "post", WebhookURL: "https://hooks.example.com/install"},
+		{Name: "systemd-audit", Step: "configure_systemd", Phase: "post", Script: "/opt/hooks/audit.sh"},
+		{Name: "bogus", Step: "reboot", Phase: "pre", Script: "/opt/hooks/reboot.sh"},
+	})
+	defer RegisterInstallHooks(nil)
+
+	params := buildInstallParams(&InstallationRequest{
+		Version:   "2.3.12",
+		HostID:    "1",
+		SkipSteps: []InstallStep{InstallStepConfigureSystemd, InstallStepInstallPlugins},
+	})
+	if got := params[installhook.ParamSkipSteps]; got != "configure_systemd,install_plugins" {
+		t.Fatalf("unexpected skip_steps param %q", got)
+	}
+	hooks, err := installhook.DecodeHooks(params[installhook.ParamHooks])
+	if err != nil {
+		t.Fatalf("decode hooks: %v", err)
+	}
+	if len(hooks) != 1 || hooks[0].Name != "notify" {
+		t.Fatalf("expected only the hook on a running step, got %+v", hooks)
+	}
+}
+
+func TestRecordHookResultReplacesEarlierReport(t *testing.T) {
+	status := newInstallationStatus(&InstallationRequest{HostID: "1"})
+	result := installhook.Result{Name: "notify", Step: string(InstallStepConfigureCluster), Phase: installhook.PhasePost}
+	recordHookResult(status, result)
+	result.Success = true
+	recordHookResult(status, result)
+
+	for _, step := range status.Steps {
+		if step.Step != InstallStepConfigureCluster {
+			continue
+		}
+		if len(step.Hooks) != 1 || !step.Hooks[0].Success {
+			t.Fatalf("expected one successful hook result, got %+v", step.Hooks)
+		}
+	}
+}
+
+func TestSplitHookTrailer(t *testing.T) {
+	results := []installhook.Result{{Name: "notify", Step: "configure_cluster", Phase: installhook.PhasePost, Success: true}}
+	message, parsed := splitHookTrailer("Installation completed / 安装完成" + installhook.FormatTrailer(results))
+	if message != "Installation completed / 安装完成" {
+		t.Fatalf("expected trailer to be stripped, got %q", message)
+	}
+	if len(parsed) != 1 || parsed[0].Name != "notify" {
+		t.Fatalf("unexpected hook results %+v", parsed)
+	}
+
+	if message, parsed := splitHookTrailer("[extract] extracting"); message != "[extract] extracting" || parsed != nil {
+		t.Fatalf("expected message without trailer to be unchanged, got %q %+v", message, parsed)
+	}
+}
@@ -0,0 +1,257 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package installer
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/seatunnel/seatunnelX/internal/config"
+	"github.com/seatunnel/seatunnelX/internal/pkg/downloadx"
+)
+
+// MirrorProbeTTL is how long mirror probe results are reused before probing again.
+// MirrorProbeTTL 是镜像探测结果在重新探测前的复用时间。
+const MirrorProbeTTL = 5 * time.Minute
+
+// mirrorProbeTimeout bounds a single mirror probe.
+// mirrorProbeTimeout 限制单个镜像探测的耗时。
+const mirrorProbeTimeout = 5 * time.Second
+
+// defaultMirrorOrder is the tie-breaking order used when probes are equal or unavailable.
+// defaultMirrorOrder 是探测结果相同或缺失时使用的默认顺序。
+var defaultMirrorOrder = []MirrorSource{MirrorAliyun, MirrorHuaweiCloud, MirrorApache}
+
+// customMirrorOrder lists registered custom mirrors; they are preferred over built-in mirrors.
+// customMirrorOrder 列出已注册的自定义镜像，优先于内置镜像。
+var customMirrorOrder []MirrorSource
+
+// RegisterCustomMirrors adds custom mirrors with a package base URL to MirrorURLs.
+// It must be called during startup before any download starts.
+// RegisterCustomMirrors 将配置了安装包基础 URL 的自定义镜像加入 MirrorURLs。
+// 必须在启动阶段、任何下载开始前调用。
+func RegisterCustomMirrors(mirrors []config.DownloadMirrorConfig) {
+	for _, mirror := range mirrors {
+		name := MirrorSource(strings.TrimSpace(mirror.Name))
+		baseURL := strings.TrimSuffix(strings.TrimSpace(mirror.PackageBaseURL), "/")
+		if name == "" || baseURL == "" {
+			continue
+		}
+		// Reusing a built-in name overrides its URL but keeps its built-in position
+		// 复用内置名称时仅覆盖其 URL，保持内置顺序
+		if _, exists := MirrorURLs[name]; !exists {
+			customMirrorOrder = append(customMirrorOrder, name)
+		}
+		MirrorURLs[name] = baseURL
+	}
+}
+
+func isCustomMirror(mirror MirrorSource) bool {
+	for _, item := range customMirrorOrder {
+		if item == mirror {
+			return true
+		}
+	}
+	return false
+}
+
+// MirrorProbeResult is the latest availability and latency of a mirror.
+// MirrorProbeResult 是镜像最近一次的可用性和延迟。
+type MirrorProbeResult struct {
+	Mirror     MirrorSource `json:"mirror"`
+	BaseURL    string       `json:"base_url"`
+	Available  bool         `json:"available"`
+	LatencyMs  int64        `json:"latency_ms"`
+	StatusCode int          `json:"status_code,omitempty"`
+	Error      string       `json:"error,omitempty"`
+	CheckedAt  time.Time    `json:"checked_at"`
+}
+
+// MirrorManager probes package mirrors, caches the results and orders download attempts.
+// MirrorManager 探测安装包镜像、缓存结果并决定下载尝试顺序。
+type MirrorManager struct {
+	client *http.Client
+	ttl    time.Duration
+
+	mu       sync.RWMutex
+	results  map[MirrorSource]*MirrorProbeResult
+	probedAt time.Time
+
+	// probeMu serializes probes so concurrent callers share one round of requests
+	// probeMu 串行化探测，使并发调用共享同一轮请求
+	probeMu sync.Mutex
+}
+
+// NewMirrorManager creates a mirror manager with the default probe TTL.
+// NewMirrorManager 使用默认探测有效期创建镜像管理器。
+func NewMirrorManager() *MirrorManager {
+	return &MirrorManager{
+		client:  downloadx.NewClient(mirrorProbeTimeout),
+		ttl:     MirrorProbeTTL,
+		results: make(map[MirrorSource]*MirrorProbeResult),
+	}
+}
+
+// Probe returns probe results for all mirrors, probing again when the cache is stale or force is set.
+// Probe 返回所有镜像的探测结果，缓存过期或 force 为 true 时重新探测。
+func (m *MirrorManager) Probe(ctx context.Context, force bool) []*MirrorProbeResult {
+	if !force && m.fresh() {
+		return m.snapshot()
+	}
+
+	m.probeMu.Lock()
+	defer m.probeMu.Unlock()
+	if !force && m.fresh() {
+		return m.snapshot()
+	}
+
+	results := make(map[MirrorSource]*MirrorProbeResult, len(MirrorURLs))
+	var resultsMu sync.Mutex
+	var wg sync.WaitGroup
+	for mirror, baseURL := range MirrorURLs {
+		wg.Add(1)
+		go func(mirror MirrorSource, baseURL string) {
+			defer wg.Done()
+			result := m.probeMirror(ctx, mirror, baseURL)
+			resultsMu.Lock()
+			results[mirror] = result
+			resultsMu.Unlock()
+		}(mirror, baseURL)
+	}
+	wg.Wait()
+
+	m.mu.Lock()
+	m.results = results
+	m.probedAt = time.Now()
+	m.mu.Unlock()
+	return m.snapshot()
+}
+
+// OrderMirrors returns mirrors in download-attempt order: the preferred mirror first,
+// then available mirrors by latency, then mirrors that failed their last probe.
+// OrderMirrors 返回下载尝试顺序：优先镜像在前，其次按延迟排序的可用镜像，最后是上次探测失败的镜像。
+func (m *MirrorManager) OrderMirrors(ctx context.Context, preferred MirrorSource) []MirrorSource {
+	results := m.Probe(ctx, false)
+
+	ordered := make([]MirrorSource, 0, len(MirrorURLs))
+	if _, ok := MirrorURLs[preferred]; ok {
+		ordered = append(ordered, preferred)
+	}
+	for _, result := range results {
+		if result.Mirror != preferred {
+			ordered = append(ordered, result.Mirror)
+		}
+	}
+	return ordered
+}
+
+// BestMirror returns the fastest available mirror, falling back to Aliyun.
+// BestMirror 返回最快的可用镜像，无可用结果时回退到阿里云。
+func (m *MirrorManager) BestMirror(ctx context.Context) MirrorSource {
+	if ordered := m.OrderMirrors(ctx, ""); len(ordered) > 0 {
+		return ordered[0]
+	}
+	return MirrorAliyun
+}
+
+// MarkFailed records a download failure so the mirror is tried last until the next probe.
+// MarkFailed 记录下载失败，使该镜像在下次探测前排在最后。
+func (m *MirrorManager) MarkFailed(mirror MirrorSource, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	result, ok := m.results[mirror]
+	if !ok {
+		result = &MirrorProbeResult{Mirror: mirror, BaseURL: MirrorURLs[mirror]}
+		m.results[mirror] = result
+	}
+	result.Available = false
+	result.CheckedAt = time.Now()
+	if err != nil {
+		result.Error = err.Error()
+	}
+}
+
+func (m *MirrorManager) fresh() bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return !m.probedAt.IsZero() && time.Since(m.probedAt) < m.ttl
+}
+
+// snapshot returns copies of the cached results sorted in attempt order.
+// snapshot 返回按尝试顺序排序的缓存结果副本。
+func (m *MirrorManager) snapshot() []*MirrorProbeResult {
+	m.mu.RLock()
+	results := make([]*MirrorProbeResult, 0, len(MirrorURLs))
+	for _, mirror := range append(append([]MirrorSource{}, customMirrorOrder...), defaultMirrorOrder...) {
+		if result, ok := m.results[mirror]; ok {
+			copied := *result
+			results = append(results, &copied)
+		} else if baseURL, ok := MirrorURLs[mirror]; ok {
+			results = append(results, &MirrorProbeResult{Mirror: mirror, BaseURL: baseURL, Available: true})
+		}
+	}
+	m.mu.RUnlock()
+
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Available != results[j].Available {
+			return results[i].Available
+		}
+		if custom := isCustomMirror(results[i].Mirror); custom != isCustomMirror(results[j].Mirror) {
+			return custom
+		}
+		if results[i].Available && results[i].LatencyMs != results[j].LatencyMs && results[i].LatencyMs > 0 && results[j].LatencyMs > 0 {
+			return results[i].LatencyMs < results[j].LatencyMs
+		}
+		return false
+	})
+	return results
+}
+
+func (m *MirrorManager) probeMirror(ctx context.Context, mirror MirrorSource, baseURL string) *MirrorProbeResult {
+	result := &MirrorProbeResult{Mirror: mirror, BaseURL: baseURL}
+	start := time.Now()
+	defer func() {
+		result.CheckedAt = time.Now()
+	}()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, baseURL+"/", nil)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	resp, err := m.client.Do(req)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	resp.Body.Close()
+
+	result.LatencyMs = time.Since(start).Milliseconds()
+	result.StatusCode = resp.StatusCode
+	if resp.StatusCode >= http.StatusBadRequest {
+		result.Error = fmt.Sprintf("unexpected status code: %d", resp.StatusCode)
+		return result
+	}
+	result.Available = true
+	return result
+}
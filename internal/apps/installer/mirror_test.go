@@ -0,0 +1,193 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package installer
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func withTestMirrors(t *testing.T, urls map[MirrorSource]string) {
+	t.Helper()
+	original := MirrorURLs
+	MirrorURLs = urls
+	t.Cleanup(func() {
+		MirrorURLs = original
+	})
+}
+
+func TestMirrorManager_OrderMirrors(t *testing.T) {
+	healthy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer healthy.Close()
+	broken := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer broken.Close()
+
+	withTestMirrors(t, map[MirrorSource]string{
+		MirrorAliyun:      broken.URL,
+		MirrorHuaweiCloud: healthy.URL,
+		MirrorApache:      healthy.URL,
+	})
+
+	manager := NewMirrorManager()
+	ordered := manager.OrderMirrors(context.Background(), "")
+	if len(ordered) != 3 || ordered[2] != MirrorAliyun {
+		t.Fatalf("expected unavailable mirror to be tried last, got %v", ordered)
+	}
+
+	ordered = manager.OrderMirrors(context.Background(), MirrorApache)
+	if ordered[0] != MirrorApache {
+		t.Fatalf("expected preferred mirror first, got %v", ordered)
+	}
+
+	manager.MarkFailed(MirrorHuaweiCloud, nil)
+	if best := manager.BestMirror(context.Background()); best != MirrorApache {
+		t.Fatalf("expected failed mirror to be demoted, got %s", best)
+	}
+}
+
+func TestService_StartDownload_FallsThroughMirrors(t *testing.T) {
+	const version = "2.3.12"
+	healthy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, packageFileName(version)) {
+			_, _ = w.Write([]byte("package"))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer healthy.Close()
+	missing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer missing.Close()
+
+	withTestMirrors(t, map[MirrorSource]string{
+		MirrorAliyun: missing.URL,
+		MirrorApache: healthy.URL,
+	})
+
+	service := NewService(t.TempDir(), nil)
+	service.tempDir = t.TempDir()
+	if _, err := service.StartDownload(context.Background(), &DownloadRequest{Version: version, Mirror: MirrorAliyun}); err != nil {
+		t.Fatalf("StartDownload returned error: %v", err)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		task, err := service.GetDownloadStatus(context.Background(), version)
+		if err != nil {
+			t.Fatalf("GetDownloadStatus returned error: %v", err)
+		}
+		service.downloadsMu.RLock()
+		status, mirror, taskErr := task.Status, task.Mirror, task.Error
+		service.downloadsMu.RUnlock()
+		if status == DownloadStatusCompleted {
+			if mirror != MirrorApache {
+				t.Fatalf("expected download to fall through to apache mirror, got %s", mirror)
+			}
+			break
+		}
+		if status == DownloadStatusFailed || time.Now().After(deadline) {
+			t.Fatalf("expected download to complete, got status=%s error=%s", status, taskErr)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	if _, err := os.Stat(filepath.Join(service.packageDir, packageFileName(version))); err != nil {
+		t.Fatalf("expected package to be saved: %v", err)
+	}
+}
+
+func TestService_EnsurePackageDownload_SharesOneDownload(t *testing.T) {
+	const version = "2.3.12"
+	var packageRequests atomic.Int32
+	release := make(chan struct{})
+	mirror := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, packageFileName(version)) {
+			packageRequests.Add(1)
+			<-release
+			_, _ = w.Write([]byte("package"))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer mirror.Close()
+	withTestMirrors(t, map[MirrorSource]string{MirrorApache: mirror.URL})
+
+	service := NewService(t.TempDir(), nil)
+	service.tempDir = t.TempDir()
+
+	const installations = 5
+	tasks := make([]*DownloadTask, installations)
+	var wg sync.WaitGroup
+	for i := 0; i < installations; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			task, err := service.ensurePackageDownload(context.Background(), version, MirrorApache)
+			if err != nil {
+				t.Errorf("ensurePackageDownload returned error: %v", err)
+			}
+			tasks[i] = task
+		}(i)
+	}
+	wg.Wait()
+	for _, task := range tasks {
+		if task == nil || task != tasks[0] {
+			t.Fatalf("expected all installations to share one download task")
+		}
+	}
+
+	status, err := service.GetDownloadStatus(context.Background(), " "+version+" ")
+	if err != nil || status.ID != tasks[0].ID {
+		t.Fatalf("expected status lookup by version to return the shared task, got %+v, %v", status, err)
+	}
+	close(release)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	for i := 0; i < installations; i++ {
+		final, err := service.waitForDownload(ctx, tasks[i], nil)
+		if err != nil || final.Status != DownloadStatusCompleted {
+			t.Fatalf("expected shared download to complete, got status=%s error=%v", final.Status, err)
+		}
+	}
+	if got := packageRequests.Load(); got != 1 {
+		t.Fatalf("expected one package request, got %d", got)
+	}
+
+	task, err := service.ensurePackageDownload(context.Background(), version, MirrorApache)
+	if err != nil || task != nil {
+		t.Fatalf("expected stored package to skip download, got %v, %v", task, err)
+	}
+}
@@ -0,0 +1,161 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package installer
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/seatunnel/seatunnelX/internal/logger"
+	"github.com/seatunnel/seatunnelX/internal/pkg/objstore"
+)
+
+// ErrInvalidPackageDelivery indicates an unknown package_delivery value.
+// ErrInvalidPackageDelivery 表示 package_delivery 取值未知。
+var ErrInvalidPackageDelivery = errors.New("invalid package delivery / 安装包分发方式不合法")
+
+// validatePackageDelivery rejects package_delivery values other than push and direct.
+// validatePackageDelivery 拒绝 push 和 direct 之外的 package_delivery 取值。
+func validatePackageDelivery(req *InstallationRequest) error {
+	switch req.PackageDelivery {
+	case "", PackageDeliveryPush, PackageDeliveryDirect:
+		return nil
+	default:
+		return fmt.Errorf("%w: %s", ErrInvalidPackageDelivery, req.PackageDelivery)
+	}
+}
+
+// resolveDirectPackageDownload picks the URL the Agent downloads the package from in direct delivery:
+// a presigned object store URL when the package is stored, otherwise the mirror URL with its official
+// SHA-512. It reports false when neither is available, and the package is then pushed as usual.
+// resolveDirectPackageDownload 为直接下载模式选择 Agent 拉取安装包的地址：安装包已存储时使用对象存储预签名地址，
+// 否则使用镜像地址及其官方 SHA-512；两者均不可用时返回 false，安装包按原方式推送。
+func (s *Service) resolveDirectPackageDownload(ctx context.Context, req *InstallationRequest) (*PackageDownload, bool) {
+	fileName := packageFileName(req.Version)
+	localPath := filepath.Join(s.packageDir, fileName)
+	if _, err := os.Stat(localPath); err == nil {
+		if err := s.ensurePackageVerified(ctx, req.Version, localPath, req.SkipPackageVerification); err != nil {
+			return nil, false
+		}
+		if download, ok := s.presignLocalPackage(ctx, localPath); ok {
+			return download, true
+		}
+	} else if download, ok := s.presignStoredPackage(ctx, fileName, req.SkipPackageVerification); ok {
+		return download, true
+	}
+
+	// Offline packages only exist on the Control Plane and its store
+	// 离线安装包只存在于控制面及其共享存储中
+	if req.InstallMode != InstallModeOnline {
+		return nil, false
+	}
+	return s.mirrorPackageDownload(ctx, req)
+}
+
+// presignStoredPackage presigns a package kept only in the shared store, taking its checksum from the
+// stored sidecars: the verified SHA-512, or the SHA-256 when package verification is skipped.
+// presignStoredPackage 为仅存在于共享存储中的安装包生成预签名地址，校验和取自存储的伴随文件：
+// 已校验的 SHA-512，或在跳过安装包校验时使用 SHA-256。
+func (s *Service) presignStoredPackage(ctx context.Context, fileName string, skipVerification bool) (*PackageDownload, bool) {
+	if s.packageStore == nil || s.presignExpiry <= 0 {
+		return nil, false
+	}
+	stored, err := s.packageStore.Stat(ctx, fileName)
+	if err != nil {
+		return nil, false
+	}
+
+	checksum, err := s.readStoredChecksum(ctx, filepath.Base(packageSHA512Path(fileName)))
+	if err == nil {
+		checksum, err = parseSHA512File(checksum)
+	}
+	if err != nil && packageVerificationSkipped(skipVerification) {
+		checksum, err = s.readStoredChecksum(ctx, filepath.Base(packageSHA256Path(fileName)))
+		if err == nil {
+			checksum, err = parseSHA256File(checksum)
+		}
+	}
+	if err != nil {
+		return nil, false
+	}
+
+	url, err := s.packageStore.PresignGet(ctx, fileName, s.presignExpiry)
+	if err != nil {
+		if !errors.Is(err, objstore.ErrPresignUnsupported) {
+			logger.WarnF(ctx, "[Installer] 生成安装包预签名地址失败 / Failed to presign package: key=%s, err=%v", fileName, err)
+		}
+		return nil, false
+	}
+	return &PackageDownload{URL: url, FileName: fileName, Checksum: checksum, Size: stored.Size}, true
+}
+
+// readStoredChecksum reads a checksum sidecar from the shared store.
+// readStoredChecksum 从共享存储读取校验和伴随文件。
+func (s *Service) readStoredChecksum(ctx context.Context, key string) (string, error) {
+	reader, err := s.packageStore.Open(ctx, key)
+	if err != nil {
+		return "", err
+	}
+	defer reader.Close()
+	content, err := io.ReadAll(io.LimitReader(reader, sha512FileMaxBytes))
+	if err != nil {
+		return "", err
+	}
+	return string(content), nil
+}
+
+// parseSHA256File extracts the checksum from a sha256sum formatted file.
+// parseSHA256File 从 sha256sum 格式的文件中提取校验和。
+func parseSHA256File(content string) (string, error) {
+	fields := strings.Fields(content)
+	if len(fields) == 0 {
+		return "", errors.New("empty sha256 file")
+	}
+	checksum := strings.ToLower(fields[0])
+	if decoded, err := hex.DecodeString(checksum); err != nil || len(decoded) != 32 {
+		return "", fmt.Errorf("invalid sha256 checksum: %q", fields[0])
+	}
+	return checksum, nil
+}
+
+// mirrorPackageDownload hands the Agent the mirror URL of the package together with its official SHA-512,
+// so the Agent verifies what it downloads without the Control Plane ever holding the package.
+// mirrorPackageDownload 将安装包的镜像地址及其官方 SHA-512 交给 Agent，
+// Agent 可校验下载内容，控制面无需持有安装包。
+func (s *Service) mirrorPackageDownload(ctx context.Context, req *InstallationRequest) (*PackageDownload, bool) {
+	mirror := req.Mirror
+	if mirror == "" {
+		mirror = s.mirrorManager.BestMirror(ctx)
+	}
+	url := getDownloadURLs(req.Version)[mirror]
+	if url == "" {
+		return nil, false
+	}
+	checksum, err := s.fetchOfficialSHA512(ctx, req.Version, url)
+	if err != nil {
+		logger.WarnF(ctx, "[Installer] 获取官方校验和失败，无法直接下载 / Failed to fetch official checksum for direct download: version=%s, err=%v", req.Version, err)
+		return nil, false
+	}
+	return &PackageDownload{URL: url, FileName: packageFileName(req.Version), Checksum: checksum}, true
+}
@@ -0,0 +1,142 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package installer
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/seatunnel/seatunnelX/internal/pkg/objstore"
+)
+
+// presignedStore is a local store that hands out fake presigned URLs.
+type presignedStore struct {
+	objstore.Store
+}
+
+func (s presignedStore) PresignGet(_ context.Context, key string, _ time.Duration) (string, error) {
+	return "https://bucket.example.com/" + key + "?X-Amz-Signature=abc", nil
+}
+
+func TestResolveDirectPackageDownloadFromMirror(t *testing.T) {
+	checksum := strings.Repeat("ab", 64)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/2.3.12/apache-seatunnel-2.3.12-bin.tar.gz.sha512" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(checksum + "  apache-seatunnel-2.3.12-bin.tar.gz\n"))
+	}))
+	defer server.Close()
+	withTestMirrors(t, map[MirrorSource]string{MirrorApache: server.URL})
+
+	service := NewService(t.TempDir(), nil)
+	req := &InstallationRequest{Version: "2.3.12", InstallMode: InstallModeOnline, Mirror: MirrorApache, PackageDelivery: PackageDeliveryDirect}
+	download, ok := service.resolveDirectPackageDownload(context.Background(), req)
+	if !ok {
+		t.Fatalf("resolveDirectPackageDownload found no source")
+	}
+	if download.URL != server.URL+"/2.3.12/apache-seatunnel-2.3.12-bin.tar.gz" || download.Checksum != checksum {
+		t.Fatalf("resolveDirectPackageDownload = %+v", download)
+	}
+
+	req.InstallMode = InstallModeOffline
+	if _, ok := service.resolveDirectPackageDownload(context.Background(), req); ok {
+		t.Fatalf("offline package resolved to a mirror URL")
+	}
+}
+
+func TestResolveDirectPackageDownloadFromStore(t *testing.T) {
+	ctx := context.Background()
+	store := presignedStore{objstore.NewLocalStore(t.TempDir())}
+	fileName := packageFileName("2.3.12")
+	if err := store.Put(ctx, fileName, strings.NewReader("package"), 7); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	service := NewService(t.TempDir(), nil)
+	service.SetPackageStore(store, time.Minute, false)
+	req := &InstallationRequest{Version: "2.3.12", InstallMode: InstallModeOffline, PackageDelivery: PackageDeliveryDirect}
+
+	// Without a verified checksum in the store the package is pushed instead
+	// 存储中没有已校验的校验和时改为推送安装包
+	if _, ok := service.resolveDirectPackageDownload(ctx, req); ok {
+		t.Fatalf("unverified stored package was presigned")
+	}
+
+	checksum := strings.Repeat("cd", 64)
+	if err := store.Put(ctx, fileName+".sha512", strings.NewReader(checksum+"  "+fileName+"\n"), -1); err != nil {
+		t.Fatalf("Put sidecar: %v", err)
+	}
+	download, ok := service.resolveDirectPackageDownload(ctx, req)
+	if !ok {
+		t.Fatalf("resolveDirectPackageDownload found no source")
+	}
+	if !strings.HasPrefix(download.URL, "https://bucket.example.com/"+fileName) || download.Checksum != checksum || download.Size != 7 {
+		t.Fatalf("resolveDirectPackageDownload = %+v", download)
+	}
+}
+
+func TestPresignLocalPackageMirrorsInBackground(t *testing.T) {
+	ctx := context.Background()
+	store := presignedStore{objstore.NewLocalStore(t.TempDir())}
+	service := NewService(t.TempDir(), nil)
+	service.SetPackageStore(store, time.Minute, false)
+	localPath := filepath.Join(service.packageDir, packageFileName("2.3.12"))
+	if err := os.WriteFile(localPath, []byte("package"), 0644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	// A package missing from the store is pushed while it is mirrored in the background
+	// 存储中缺失的安装包在后台同步期间按推送方式传输
+	if _, ok := service.presignLocalPackage(ctx, localPath); ok {
+		t.Fatalf("package missing from the store was presigned")
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		if _, err := store.Stat(ctx, packageFileName("2.3.12")); err == nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("package was not mirrored into the store")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	download, ok := service.presignLocalPackage(ctx, localPath)
+	if !ok || download.Size != 7 || !strings.HasPrefix(download.URL, "https://bucket.example.com/") {
+		t.Fatalf("presignLocalPackage = %+v, %v", download, ok)
+	}
+}
+
+func TestValidatePackageDelivery(t *testing.T) {
+	for _, delivery := range []PackageDelivery{"", PackageDeliveryPush, PackageDeliveryDirect} {
+		if err := validatePackageDelivery(&InstallationRequest{PackageDelivery: delivery}); err != nil {
+			t.Errorf("validatePackageDelivery(%q) = %v", delivery, err)
+		}
+	}
+	if err := validatePackageDelivery(&InstallationRequest{PackageDelivery: "carrier-pigeon"}); !errors.Is(err, ErrInvalidPackageDelivery) {
+		t.Errorf("validatePackageDelivery accepted an unknown value: %v", err)
+	}
+}
@@ -0,0 +1,278 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package installer
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// ErrInvalidPackageLayout indicates the archive is not a SeaTunnel binary distribution.
+// ErrInvalidPackageLayout 表示归档不是 SeaTunnel 二进制发行包。
+var ErrInvalidPackageLayout = errors.New("package is not a SeaTunnel binary distribution / 安装包不是 SeaTunnel 二进制发行包")
+
+// ErrPackageVersionMismatch indicates the archive contains a different SeaTunnel version than requested.
+// ErrPackageVersionMismatch 表示归档中的 SeaTunnel 版本与请求的版本不一致。
+var ErrPackageVersionMismatch = errors.New("package version mismatch / 安装包版本不一致")
+
+// requiredPackageFiles are the files every SeaTunnel binary distribution ships, relative to its top directory.
+// requiredPackageFiles 是每个 SeaTunnel 二进制发行包都包含的文件，相对于其顶层目录。
+var requiredPackageFiles = []string{
+	"bin/seatunnel.sh",
+	"bin/seatunnel-cluster.sh",
+	"config/seatunnel.yaml",
+}
+
+// Archive signatures used to detect the upload format regardless of its file name.
+// 用于识别上传格式的归档签名，与文件名无关。
+var (
+	gzipMagic = []byte{0x1f, 0x8b}
+	zipMagic  = []byte("PK\x03\x04")
+)
+
+// packageArchiveFormat returns "tar.gz", "zip" or "" for the file at filePath.
+// packageArchiveFormat 返回 filePath 处文件的格式："tar.gz"、"zip" 或 ""。
+func packageArchiveFormat(filePath string) (string, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	header := make([]byte, len(zipMagic))
+	n, err := io.ReadFull(file, header)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return "", nil
+	}
+	header = header[:n]
+	switch {
+	case bytes.HasPrefix(header, gzipMagic):
+		return "tar.gz", nil
+	case bytes.Equal(header, zipMagic):
+		return "zip", nil
+	}
+	return "", nil
+}
+
+// inspectPackageArchive checks that a tar.gz package holds a single apache-seatunnel-{version}
+// top directory containing the required launcher scripts and configuration.
+// inspectPackageArchive 校验 tar.gz 安装包只有一个 apache-seatunnel-{version} 顶层目录，且包含必需的启动脚本与配置。
+func inspectPackageArchive(filePath, version string) error {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	gzReader, err := gzip.NewReader(file)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidPackageLayout, err)
+	}
+	defer gzReader.Close()
+
+	expectedTop := "apache-seatunnel-" + version
+	found := make(map[string]bool, len(requiredPackageFiles))
+	tarReader := tar.NewReader(gzReader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidPackageLayout, err)
+		}
+		if header.Typeflag == tar.TypeXGlobalHeader {
+			continue
+		}
+		relative, err := packageEntryPath(header.Name, expectedTop)
+		if err != nil {
+			return err
+		}
+		if header.Typeflag == tar.TypeReg {
+			found[relative] = true
+		}
+	}
+
+	var missing []string
+	for _, name := range requiredPackageFiles {
+		if !found[name] {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("%w: missing %s", ErrInvalidPackageLayout, strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// packageEntryPath returns an entry name relative to the expected top directory, rejecting entries
+// outside it, path traversal and top directories naming another SeaTunnel version.
+// packageEntryPath 返回相对于预期顶层目录的条目路径，拒绝顶层目录之外的条目、路径遍历以及其他 SeaTunnel 版本的顶层目录。
+func packageEntryPath(name, expectedTop string) (string, error) {
+	name = strings.TrimPrefix(strings.ReplaceAll(name, "\\", "/"), "./")
+	if strings.HasPrefix(name, "/") {
+		return "", fmt.Errorf("%w: absolute entry %s", ErrInvalidPackageLayout, name)
+	}
+	for _, part := range strings.Split(name, "/") {
+		if part == ".." {
+			return "", fmt.Errorf("%w: entry escapes package %s", ErrInvalidPackageLayout, name)
+		}
+	}
+
+	top, rest, _ := strings.Cut(name, "/")
+	if top != expectedTop {
+		if found, ok := strings.CutPrefix(top, "apache-seatunnel-"); ok && found != "" {
+			return "", fmt.Errorf("%w: package contains %s, expected %s", ErrPackageVersionMismatch, found, strings.TrimPrefix(expectedTop, "apache-seatunnel-"))
+		}
+		return "", fmt.Errorf("%w: unexpected top-level entry %s, expected %s/", ErrInvalidPackageLayout, top, expectedTop)
+	}
+	return path.Clean("/" + rest)[1:], nil
+}
+
+// convertZipPackage rewrites a zip package as tar.gz so packages are stored, transferred and
+// inspected in a single format. Launcher scripts without Unix modes are made executable.
+// convertZipPackage 将 zip 安装包重写为 tar.gz，使安装包以单一格式存储、传输与检查；没有 Unix 权限的启动脚本会被设为可执行。
+func convertZipPackage(zipPath, tarGzPath string) error {
+	archive, err := zip.OpenReader(zipPath)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidPackageLayout, err)
+	}
+	defer archive.Close()
+
+	out, err := os.Create(tarGzPath)
+	if err != nil {
+		return err
+	}
+	gzWriter := gzip.NewWriter(out)
+	tarWriter := tar.NewWriter(gzWriter)
+
+	writeErr := func() error {
+		for _, entry := range archive.File {
+			if err := writeZipEntryAsTar(tarWriter, entry); err != nil {
+				return err
+			}
+		}
+		if err := tarWriter.Close(); err != nil {
+			return err
+		}
+		return gzWriter.Close()
+	}()
+	closeErr := out.Close()
+	if writeErr != nil {
+		return writeErr
+	}
+	return closeErr
+}
+
+// writeZipEntryAsTar copies one zip entry into the tar stream.
+// writeZipEntryAsTar 将一个 zip 条目复制到 tar 流中。
+func writeZipEntryAsTar(tarWriter *tar.Writer, entry *zip.File) error {
+	info := entry.FileInfo()
+	header := &tar.Header{
+		Name:    entry.Name,
+		ModTime: entry.Modified,
+		Mode:    int64(info.Mode().Perm()),
+	}
+
+	switch {
+	case info.IsDir():
+		header.Typeflag = tar.TypeDir
+		if header.Mode == 0 {
+			header.Mode = 0755
+		}
+		return tarWriter.WriteHeader(header)
+	case info.Mode()&os.ModeSymlink != 0:
+		reader, err := entry.Open()
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidPackageLayout, err)
+		}
+		target, err := io.ReadAll(io.LimitReader(reader, 4096))
+		reader.Close()
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidPackageLayout, err)
+		}
+		header.Typeflag = tar.TypeSymlink
+		header.Linkname = string(target)
+		header.Mode = 0777
+		return tarWriter.WriteHeader(header)
+	}
+
+	header.Typeflag = tar.TypeReg
+	header.Size = int64(entry.UncompressedSize64)
+	if header.Mode == 0 {
+		header.Mode = 0644
+	}
+	if strings.HasSuffix(entry.Name, ".sh") {
+		header.Mode |= 0111
+	}
+	if err := tarWriter.WriteHeader(header); err != nil {
+		return err
+	}
+	reader, err := entry.Open()
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidPackageLayout, err)
+	}
+	defer reader.Close()
+	if _, err := io.Copy(tarWriter, reader); err != nil {
+		return fmt.Errorf("%w: %s: %v", ErrInvalidPackageLayout, entry.Name, err)
+	}
+	return nil
+}
+
+// packageSHA256Path returns the sidecar file caching the SHA-256 checksum of a package.
+// packageSHA256Path 返回缓存安装包 SHA-256 校验和的伴随文件路径。
+func packageSHA256Path(packagePath string) string {
+	return packagePath + ".sha256"
+}
+
+// packageChecksum returns the SHA-256 checksum of a package, reusing the cached sidecar while it is
+// newer than the package and refreshing it otherwise.
+// packageChecksum 返回安装包的 SHA-256 校验和；伴随文件比安装包新时直接复用，否则重新计算并刷新。
+func packageChecksum(packagePath string) (string, error) {
+	packageInfo, err := os.Stat(packagePath)
+	if err != nil {
+		return "", err
+	}
+	sidecar := packageSHA256Path(packagePath)
+	if sidecarInfo, err := os.Stat(sidecar); err == nil && !sidecarInfo.ModTime().Before(packageInfo.ModTime()) {
+		if content, err := os.ReadFile(sidecar); err == nil {
+			if fields := strings.Fields(string(content)); len(fields) > 0 {
+				if decoded, err := hex.DecodeString(fields[0]); err == nil && len(decoded) == 32 {
+					return fields[0], nil
+				}
+			}
+		}
+	}
+
+	checksum, err := calculateChecksum(packagePath)
+	if err != nil {
+		return "", err
+	}
+	_ = os.WriteFile(sidecar, []byte(fmt.Sprintf("%s  %s\n", checksum, filepath.Base(packagePath))), 0644)
+	return checksum, nil
+}
@@ -0,0 +1,196 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package installer
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// buildSeaTunnelTarGz builds a minimal binary distribution under topDir; extra files are added as-is.
+// buildSeaTunnelTarGz 在 topDir 下构建最小的二进制发行包；extra 中的文件原样加入。
+func buildSeaTunnelTarGz(t *testing.T, topDir string, extra map[string]string) []byte {
+	t.Helper()
+	files := map[string]string{
+		topDir + "/bin/seatunnel.sh":         "#!/bin/bash\n",
+		topDir + "/bin/seatunnel-cluster.sh": "#!/bin/bash\n",
+		topDir + "/config/seatunnel.yaml":    "seatunnel: {}\n",
+	}
+	for name, content := range extra {
+		files[name] = content
+	}
+
+	var buf bytes.Buffer
+	gzWriter := gzip.NewWriter(&buf)
+	tarWriter := tar.NewWriter(gzWriter)
+	for name, content := range files {
+		if err := tarWriter.WriteHeader(&tar.Header{Name: name, Typeflag: tar.TypeReg, Mode: 0644, Size: int64(len(content))}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tarWriter.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tarWriter.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gzWriter.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func writeTempPackage(t *testing.T, content []byte) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "package.tar.gz")
+	if err := os.WriteFile(path, content, 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestInspectPackageArchive(t *testing.T) {
+	valid := writeTempPackage(t, buildSeaTunnelTarGz(t, "apache-seatunnel-2.3.12", map[string]string{
+		"./apache-seatunnel-2.3.12/lib/seatunnel-transforms-v2.jar": "jar",
+	}))
+	if err := inspectPackageArchive(valid, "2.3.12"); err != nil {
+		t.Fatalf("expected valid package, got %v", err)
+	}
+
+	if err := inspectPackageArchive(valid, "2.3.11"); !errors.Is(err, ErrPackageVersionMismatch) {
+		t.Fatalf("expected ErrPackageVersionMismatch, got %v", err)
+	}
+
+	cases := map[string][]byte{
+		"foreign top directory": buildSeaTunnelTarGz(t, "flink-1.18.1", nil),
+		"extra top entry":       buildSeaTunnelTarGz(t, "apache-seatunnel-2.3.12", map[string]string{"README.txt": "x"}),
+		"path traversal":        buildSeaTunnelTarGz(t, "apache-seatunnel-2.3.12", map[string]string{"apache-seatunnel-2.3.12/../../etc/cron.d/x": "x"}),
+		"not gzip":              []byte("plain text"),
+	}
+	for name, content := range cases {
+		if err := inspectPackageArchive(writeTempPackage(t, content), "2.3.12"); !errors.Is(err, ErrInvalidPackageLayout) {
+			t.Fatalf("%s: expected ErrInvalidPackageLayout, got %v", name, err)
+		}
+	}
+
+	// A package without launcher scripts is rejected / 缺少启动脚本的安装包会被拒绝
+	var buf bytes.Buffer
+	gzWriter := gzip.NewWriter(&buf)
+	tarWriter := tar.NewWriter(gzWriter)
+	_ = tarWriter.WriteHeader(&tar.Header{Name: "apache-seatunnel-2.3.12/config/seatunnel.yaml", Typeflag: tar.TypeReg, Mode: 0644})
+	_ = tarWriter.Close()
+	_ = gzWriter.Close()
+	err := inspectPackageArchive(writeTempPackage(t, buf.Bytes()), "2.3.12")
+	if !errors.Is(err, ErrInvalidPackageLayout) || !strings.Contains(err.Error(), "bin/seatunnel-cluster.sh") {
+		t.Fatalf("expected missing launcher to be reported, got %v", err)
+	}
+}
+
+func TestUploadPackageConvertsZipAndCachesChecksum(t *testing.T) {
+	var buf bytes.Buffer
+	zipWriter := zip.NewWriter(&buf)
+	for name, content := range map[string]string{
+		"apache-seatunnel-2.3.12/bin/seatunnel.sh":         "#!/bin/bash\n",
+		"apache-seatunnel-2.3.12/bin/seatunnel-cluster.sh": "#!/bin/bash\n",
+		"apache-seatunnel-2.3.12/config/seatunnel.yaml":    "seatunnel: {}\n",
+	} {
+		w, err := zipWriter.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, _ = w.Write([]byte(content))
+	}
+	if err := zipWriter.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	service := NewService(t.TempDir(), nil)
+	ctx := context.Background()
+	info, err := service.UploadPackage(ctx, "2.3.12", createUploadFileHeader(t, "file", "apache-seatunnel-2.3.12-bin.zip", buf.Bytes()))
+	if err != nil {
+		t.Fatalf("zip upload failed: %v", err)
+	}
+	if info.FileName != "apache-seatunnel-2.3.12-bin.tar.gz" {
+		t.Fatalf("expected canonical tar.gz name, got %s", info.FileName)
+	}
+	if format, _ := packageArchiveFormat(info.LocalPath); format != "tar.gz" {
+		t.Fatalf("expected stored package to be tar.gz, got %q", format)
+	}
+	if err := inspectPackageArchive(info.LocalPath, "2.3.12"); err != nil {
+		t.Fatalf("converted package should pass inspection: %v", err)
+	}
+
+	expected, err := calculateChecksum(info.LocalPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Checksum != expected {
+		t.Fatalf("expected checksum %s, got %s", expected, info.Checksum)
+	}
+	sidecar, err := os.ReadFile(packageSHA256Path(info.LocalPath))
+	if err != nil || !strings.HasPrefix(string(sidecar), expected) {
+		t.Fatalf("expected checksum to be stored next to the package, got %q, %v", sidecar, err)
+	}
+	if _, err := os.Stat(packageSHA512Path(info.LocalPath)); !os.IsNotExist(err) {
+		t.Fatalf("uploaded packages must not be marked as verified, got %v", err)
+	}
+
+	// A mismatched version is rejected and nothing is stored / 版本不一致的上传被拒绝且不落盘
+	_, err = service.UploadPackage(ctx, "2.3.11", createUploadFileHeader(t, "file", "apache-seatunnel-2.3.11-bin.tar.gz", buildSeaTunnelTarGz(t, "apache-seatunnel-2.3.12", nil)))
+	if !errors.Is(err, ErrPackageVersionMismatch) {
+		t.Fatalf("expected ErrPackageVersionMismatch, got %v", err)
+	}
+	if packageInfo, _ := service.GetPackageInfo(ctx, "2.3.11"); packageInfo.IsLocal {
+		t.Fatal("mismatched package must not be stored")
+	}
+}
+
+func TestPackageChecksumRefreshesStaleSidecar(t *testing.T) {
+	path := writeTempPackage(t, []byte("first"))
+	first, err := packageChecksum(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(path, []byte("second"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	// Make the package newer than its cached checksum / 使安装包比缓存的校验和更新
+	sidecarInfo, _ := os.Stat(packageSHA256Path(path))
+	later := sidecarInfo.ModTime().Add(2e9)
+	if err := os.Chtimes(path, later, later); err != nil {
+		t.Fatal(err)
+	}
+
+	second, err := packageChecksum(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected, _ := calculateChecksum(path)
+	if second == first || second != expected {
+		t.Fatalf("expected refreshed checksum %s, got %s (first %s)", expected, second, first)
+	}
+}
@@ -0,0 +1,80 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package installer
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/seatunnel/seatunnelX/internal/pkg/pagex"
+)
+
+// pageLocalPackages filters local packages by version, sorts them and returns one page with the matching total.
+// pageLocalPackages 按版本过滤本地安装包并排序，返回一页数据及匹配总数。
+func pageLocalPackages(packages []PackageInfo, version string, page pagex.Params) ([]PackageInfo, int) {
+	matched := make([]PackageInfo, 0, len(packages))
+	for _, pkg := range packages {
+		if version == "" || strings.Contains(pkg.Version, version) {
+			matched = append(matched, pkg)
+		}
+	}
+
+	sort.SliceStable(matched, func(i, j int) bool {
+		for _, field := range page.Sort {
+			c := compareLocalPackages(&matched[i], &matched[j], field.Field)
+			if c == 0 {
+				continue
+			}
+			if field.Desc {
+				return c > 0
+			}
+			return c < 0
+		}
+		return false
+	})
+
+	start, end := page.Bounds(len(matched))
+	return matched[start:end], len(matched)
+}
+
+// compareLocalPackages compares two local packages on one sortable field.
+// compareLocalPackages 按单个可排序字段比较两个本地安装包。
+func compareLocalPackages(a, b *PackageInfo, field string) int {
+	switch field {
+	case "file_size":
+		switch {
+		case a.FileSize < b.FileSize:
+			return -1
+		case a.FileSize > b.FileSize:
+			return 1
+		}
+		return 0
+	case "uploaded_at":
+		switch {
+		case a.UploadedAt == nil && b.UploadedAt == nil:
+			return 0
+		case a.UploadedAt == nil:
+			return -1
+		case b.UploadedAt == nil:
+			return 1
+		}
+		return a.UploadedAt.Compare(*b.UploadedAt)
+	default:
+		return compareVersions(a.Version, b.Version)
+	}
+}
@@ -0,0 +1,42 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package installer
+
+import (
+	"testing"
+
+	"github.com/seatunnel/seatunnelX/internal/pkg/pagex"
+)
+
+func TestPageLocalPackagesSortsByVersionAndPages(t *testing.T) {
+	packages := []PackageInfo{{Version: "2.3.9"}, {Version: "2.3.12"}, {Version: "2.3.10"}, {Version: "2.2.0"}}
+
+	page, err := pagex.Query{PageSize: 2}.Resolve(localPackageListSpec)
+	if err != nil {
+		t.Fatalf("Resolve returned error: %v", err)
+	}
+	got, total := pageLocalPackages(packages, "2.3", page)
+	if total != 3 || len(got) != 2 || got[0].Version != "2.3.12" || got[1].Version != "2.3.10" {
+		t.Fatalf("unexpected first page: total=%d packages=%+v", total, got)
+	}
+
+	page.Page = 2
+	if got, _ := pageLocalPackages(packages, "2.3", page); len(got) != 1 || got[0].Version != "2.3.9" {
+		t.Fatalf("unexpected second page: %+v", got)
+	}
+}
@@ -0,0 +1,209 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package installer
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/seatunnel/seatunnelX/internal/logger"
+	"github.com/seatunnel/seatunnelX/internal/pkg/objstore"
+)
+
+// PackageDownload describes a package the Agent pulls itself, from a presigned object store URL or a mirror.
+// PackageDownload 描述 Agent 通过对象存储预签名地址或镜像地址自行拉取的安装包。
+type PackageDownload struct {
+	URL      string
+	FileName string
+	Checksum string
+	Size     int64
+}
+
+// SetPackageStore sets the object store shared by all replicas. Packages stored locally are mirrored
+// into it and packages missing locally are fetched from it before falling back to the mirrors.
+// A positive presignExpiry lets Agents pull packages straight from the store in direct delivery;
+// presignByDefault does so for every installation instead of pushing packages through this replica.
+// SetPackageStore 设置所有副本共享的对象存储。本地安装包会同步到其中，本地缺失的安装包会先从中获取再回退到镜像源。
+// presignExpiry 大于 0 时直接下载模式下 Agent 从存储拉取安装包；presignByDefault 使所有安装都如此，而不经本副本中转。
+func (s *Service) SetPackageStore(store objstore.Store, presignExpiry time.Duration, presignByDefault bool) {
+	s.packageStore = store
+	s.presignExpiry = presignExpiry
+	s.presignByDefault = presignByDefault
+}
+
+// mirrorPackageToStore copies a local package and its checksum sidecars into the shared store.
+// Failures are logged only: the local copy stays usable by this replica.
+// mirrorPackageToStore 将本地安装包及其校验和文件复制到共享存储；失败仅记录日志，本副本仍可使用本地副本。
+func (s *Service) mirrorPackageToStore(ctx context.Context, localPath string) {
+	if s.packageStore == nil {
+		return
+	}
+	for _, path := range []string{localPath, packageSHA512Path(localPath), packageSHA256Path(localPath)} {
+		info, err := os.Stat(path)
+		if err != nil {
+			continue
+		}
+		key := filepath.Base(path)
+		if path == localPath {
+			if stored, err := s.packageStore.Stat(ctx, key); err == nil && stored.Size == info.Size() {
+				continue
+			}
+		}
+		if err := objstore.UploadFile(ctx, s.packageStore, key, path); err != nil {
+			logger.WarnF(ctx, "[Installer] 同步安装包到共享存储失败 / Failed to mirror package to shared store: key=%s, err=%v", key, err)
+			return
+		}
+	}
+}
+
+// mirrorPackageInBackground mirrors a local package into the shared store without blocking the caller,
+// skipping packages whose mirroring is already in progress.
+// mirrorPackageInBackground 在后台将本地安装包同步到共享存储，不阻塞调用方；已在同步中的安装包会被跳过。
+func (s *Service) mirrorPackageInBackground(localPath string) {
+	if s.packageStore == nil {
+		return
+	}
+	if _, running := s.mirroringPackages.LoadOrStore(localPath, struct{}{}); running {
+		return
+	}
+	go func() {
+		defer s.mirroringPackages.Delete(localPath)
+		s.mirrorPackageToStore(context.Background(), localPath)
+	}()
+}
+
+// hydratePackageFromStore fetches a package missing locally from the shared store and reports whether it is now local.
+// hydratePackageFromStore 从共享存储获取本地缺失的安装包，返回其是否已在本地。
+func (s *Service) hydratePackageFromStore(ctx context.Context, localPath string) bool {
+	if _, err := os.Stat(localPath); err == nil {
+		return true
+	}
+	if s.packageStore == nil {
+		return false
+	}
+	key := filepath.Base(localPath)
+	if err := objstore.DownloadFile(ctx, s.packageStore, key, localPath); err != nil {
+		if !errors.Is(err, objstore.ErrNotFound) {
+			logger.WarnF(ctx, "[Installer] 从共享存储获取安装包失败 / Failed to fetch package from shared store: key=%s, err=%v", key, err)
+		}
+		return false
+	}
+	// Sidecars are fetched after the package so the cached SHA-256 is not older than it
+	// 校验和文件在安装包之后获取，保证缓存的 SHA-256 不早于安装包
+	for _, sidecar := range []string{packageSHA512Path(localPath), packageSHA256Path(localPath)} {
+		if err := objstore.DownloadFile(ctx, s.packageStore, filepath.Base(sidecar), sidecar); err != nil && !errors.Is(err, objstore.ErrNotFound) {
+			logger.WarnF(ctx, "[Installer] 从共享存储获取校验和失败 / Failed to fetch package checksum from shared store: path=%s, err=%v", sidecar, err)
+		}
+	}
+	logger.InfoF(ctx, "[Installer] 已从共享存储获取安装包 / Package fetched from shared store: path=%s", localPath)
+	return true
+}
+
+// listStoredPackages returns the packages kept in the shared store but missing from the local directory.
+// listStoredPackages 返回共享存储中存在而本地目录缺失的安装包。
+func (s *Service) listStoredPackages(ctx context.Context, local map[string]struct{}) []PackageInfo {
+	if s.packageStore == nil {
+		return nil
+	}
+	objects, err := s.packageStore.List(ctx, "")
+	if err != nil {
+		logger.WarnF(ctx, "[Installer] 列出共享存储安装包失败 / Failed to list packages in shared store: %v", err)
+		return nil
+	}
+	packages := make([]PackageInfo, 0)
+	for _, object := range objects {
+		if !isSeaTunnelPackage(object.Key) {
+			continue
+		}
+		if _, ok := local[object.Key]; ok {
+			continue
+		}
+		version := extractVersionFromFileName(object.Key)
+		uploadedAt := object.ModTime
+		packages = append(packages, PackageInfo{
+			Version:      version,
+			FileName:     object.Key,
+			FileSize:     object.Size,
+			IsLocal:      true,
+			Stored:       true,
+			UploadedAt:   &uploadedAt,
+			DownloadURLs: getDownloadURLs(version),
+		})
+	}
+	return packages
+}
+
+// deleteStoredPackage removes a package and its sidecars from the shared store and reports whether the package was there.
+// deleteStoredPackage 从共享存储删除安装包及其校验和文件，返回安装包是否存在于存储中。
+func (s *Service) deleteStoredPackage(ctx context.Context, fileName string) (bool, error) {
+	if s.packageStore == nil {
+		return false, nil
+	}
+	_, statErr := s.packageStore.Stat(ctx, fileName)
+	for _, key := range []string{fileName, fileName + ".sha512", fileName + ".sha256"} {
+		if err := s.packageStore.Delete(ctx, key); err != nil {
+			return false, err
+		}
+	}
+	return statErr == nil, nil
+}
+
+// presignPackageDownload presigns the package for installations that did not ask for direct delivery,
+// as long as presigned downloads are enabled for all of them.
+// presignPackageDownload 在对所有安装启用预签名下载时，为未要求直接下载的安装生成预签名地址。
+func (s *Service) presignPackageDownload(ctx context.Context, localPath string) (*PackageDownload, bool) {
+	if !s.presignByDefault {
+		return nil, false
+	}
+	return s.presignLocalPackage(ctx, localPath)
+}
+
+// presignLocalPackage prepares a presigned URL the Agent downloads the package from. Packages are mirrored
+// when they are downloaded, uploaded or verified; one not in the store yet is mirrored in the background
+// and pushed this time. It reports false when presigned downloads are unavailable.
+// presignLocalPackage 生成 Agent 下载安装包的预签名地址。安装包在下载、上传或校验时同步到存储；
+// 尚未同步的安装包会在后台同步，本次仍按推送方式传输。不可用时返回 false。
+func (s *Service) presignLocalPackage(ctx context.Context, localPath string) (*PackageDownload, bool) {
+	if s.packageStore == nil || s.presignExpiry <= 0 {
+		return nil, false
+	}
+	info, err := os.Stat(localPath)
+	if err != nil {
+		return nil, false
+	}
+	fileName := filepath.Base(localPath)
+	if stored, err := s.packageStore.Stat(ctx, fileName); err != nil || stored.Size != info.Size() {
+		s.mirrorPackageInBackground(localPath)
+		return nil, false
+	}
+	checksum, err := packageChecksum(localPath)
+	if err != nil {
+		return nil, false
+	}
+	url, err := s.packageStore.PresignGet(ctx, fileName, s.presignExpiry)
+	if err != nil {
+		if !errors.Is(err, objstore.ErrPresignUnsupported) {
+			logger.WarnF(ctx, "[Installer] 生成安装包预签名地址失败 / Failed to presign package: key=%s, err=%v", fileName, err)
+		}
+		return nil, false
+	}
+	return &PackageDownload{URL: url, FileName: fileName, Checksum: checksum, Size: info.Size()}, true
+}
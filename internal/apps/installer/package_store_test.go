@@ -0,0 +1,104 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package installer
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/seatunnel/seatunnelX/internal/pkg/objstore"
+)
+
+func TestPackageStoreSharesPackagesBetweenReplicas(t *testing.T) {
+	ctx := context.Background()
+	store := objstore.Sub(objstore.NewLocalStore(t.TempDir()), "packages")
+	replicaA := NewService(t.TempDir(), nil)
+	replicaA.SetPackageStore(store, 0, false)
+	replicaB := NewService(t.TempDir(), nil)
+	replicaB.SetPackageStore(store, 0, false)
+
+	content := buildSeaTunnelTarGz(t, "apache-seatunnel-2.3.12", nil)
+	saved, err := replicaA.savePackageFromReader(ctx, "2.3.12", "apache-seatunnel-2.3.12-bin.tar.gz", int64(len(content)), bytes.NewReader(content))
+	if err != nil {
+		t.Fatalf("savePackageFromReader: %v", err)
+	}
+
+	stored := replicaB.listStoredPackages(ctx, map[string]struct{}{})
+	if len(stored) != 1 || stored[0].Version != "2.3.12" || !stored[0].Stored || stored[0].FileSize != int64(len(content)) {
+		t.Fatalf("listStoredPackages = %+v", stored)
+	}
+
+	info, err := replicaB.GetPackageInfo(ctx, "2.3.12")
+	if err != nil {
+		t.Fatalf("GetPackageInfo: %v", err)
+	}
+	if !info.IsLocal || info.Checksum != saved.Checksum {
+		t.Fatalf("GetPackageInfo on second replica = %+v, want local copy with checksum %s", info, saved.Checksum)
+	}
+	if task, err := replicaB.ensurePackageDownload(ctx, "2.3.12", ""); err != nil || task != nil {
+		t.Fatalf("ensurePackageDownload = %v, %v, want package served from the store", task, err)
+	}
+	if _, ok := replicaB.presignPackageDownload(ctx, info.LocalPath); ok {
+		t.Fatalf("presignPackageDownload succeeded with presigned downloads disabled")
+	}
+
+	if err := replicaB.DeletePackage(ctx, "2.3.12"); err != nil {
+		t.Fatalf("DeletePackage: %v", err)
+	}
+	if _, err := store.Stat(ctx, packageFileName("2.3.12")); !errors.Is(err, objstore.ErrNotFound) {
+		t.Fatalf("store still holds deleted package: %v", err)
+	}
+	// Replica A keeps its local copy but may delete it even though the store no longer has it.
+	// 副本 A 保留本地副本，存储中已删除时仍可删除本地副本。
+	if err := replicaA.DeletePackage(ctx, "2.3.12"); err != nil {
+		t.Fatalf("DeletePackage on first replica: %v", err)
+	}
+	if err := replicaA.DeletePackage(ctx, "2.3.12"); !errors.Is(err, ErrPackageNotFound) {
+		t.Fatalf("DeletePackage of missing package = %v, want ErrPackageNotFound", err)
+	}
+	if _, err := os.Stat(filepath.Join(replicaA.packageDir, packageFileName("2.3.12"))); !os.IsNotExist(err) {
+		t.Fatalf("local package still exists: %v", err)
+	}
+}
+
+func TestBuildInstallParamsPackageDownload(t *testing.T) {
+	req := &InstallationRequest{
+		Version: "2.3.12",
+		PackageDownload: &PackageDownload{
+			URL:      "https://bucket.example.com/packages/apache-seatunnel-2.3.12-bin.tar.gz?X-Amz-Signature=abc",
+			FileName: "apache-seatunnel-2.3.12-bin.tar.gz",
+			Checksum: "deadbeef",
+			Size:     42,
+		},
+	}
+	params := buildInstallParams(req)
+	if params["package_url"] != req.PackageDownload.URL || params["package_checksum"] != "deadbeef" ||
+		params["package_file_name"] != "apache-seatunnel-2.3.12-bin.tar.gz" || params["package_size"] != "42" {
+		t.Fatalf("buildInstallParams = %v", params)
+	}
+
+	req.PackagePath = "/tmp/seatunnel/apache-seatunnel-2.3.12-bin.tar.gz"
+	params = buildInstallParams(req)
+	if _, ok := params["package_url"]; ok {
+		t.Fatalf("package_url set although the package was transferred: %v", params)
+	}
+}
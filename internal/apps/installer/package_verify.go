@@ -0,0 +1,229 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package installer
+
+import (
+	"context"
+	"crypto/sha512"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/seatunnel/seatunnelX/internal/config"
+	"github.com/seatunnel/seatunnelX/internal/logger"
+)
+
+// ErrPackageUnverified indicates the package has no verified official SHA-512 checksum.
+// ErrPackageUnverified 表示安装包没有经过校验的官方 SHA-512 校验和。
+var ErrPackageUnverified = errors.New("package checksum is not verified / 安装包校验和未验证")
+
+// ErrPackageChecksumMismatch indicates the package does not match its official SHA-512 checksum.
+// ErrPackageChecksumMismatch 表示安装包与官方 SHA-512 校验和不一致。
+var ErrPackageChecksumMismatch = errors.New("package checksum mismatch / 安装包校验和不一致")
+
+// sha512FileMaxBytes bounds the size of a downloaded .sha512 file.
+// sha512FileMaxBytes 限制下载的 .sha512 文件大小。
+const sha512FileMaxBytes = 64 * 1024
+
+// packageSHA512Path returns the sidecar file storing the verified checksum of a package.
+// packageSHA512Path 返回保存安装包已校验校验和的伴随文件路径。
+func packageSHA512Path(packagePath string) string {
+	return packagePath + ".sha512"
+}
+
+// readPackageSHA512 returns the verified checksum stored next to the package.
+// readPackageSHA512 返回安装包旁保存的已校验校验和。
+func readPackageSHA512(packagePath string) (string, bool) {
+	content, err := os.ReadFile(packageSHA512Path(packagePath))
+	if err != nil {
+		return "", false
+	}
+	checksum, err := parseSHA512File(string(content))
+	if err != nil {
+		return "", false
+	}
+	return checksum, true
+}
+
+// writePackageSHA512 stores a verified checksum next to the package in sha512sum format.
+// writePackageSHA512 以 sha512sum 格式在安装包旁保存已校验的校验和。
+func writePackageSHA512(packagePath, checksum string) error {
+	content := fmt.Sprintf("%s  %s\n", checksum, filepath.Base(packagePath))
+	return os.WriteFile(packageSHA512Path(packagePath), []byte(content), 0644)
+}
+
+// parseSHA512File extracts the checksum from a .sha512 file. Both the sha512sum format
+// ("<hex>  <file>") and the gpg --print-md format ("<file>: XXXX XXXX ...") are accepted.
+// parseSHA512File 从 .sha512 文件中提取校验和，支持 sha512sum 格式和 gpg --print-md 格式。
+func parseSHA512File(content string) (string, error) {
+	content = strings.TrimSpace(content)
+	if idx := strings.Index(content, ":"); idx >= 0 {
+		content = strings.Join(strings.Fields(content[idx+1:]), "")
+	} else if fields := strings.Fields(content); len(fields) > 0 {
+		content = fields[0]
+	}
+	checksum := strings.ToLower(content)
+	if len(checksum) != sha512.Size*2 {
+		return "", fmt.Errorf("invalid sha512 checksum length: %d", len(checksum))
+	}
+	if _, err := hex.DecodeString(checksum); err != nil {
+		return "", fmt.Errorf("invalid sha512 checksum: %w", err)
+	}
+	return checksum, nil
+}
+
+// fileSHA512 calculates the SHA-512 checksum of a file.
+// fileSHA512 计算文件的 SHA-512 校验和。
+func fileSHA512(filePath string) (string, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	hash := sha512.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// officialSHA512URLs returns candidate .sha512 URLs: the given download URL first, then every mirror.
+// officialSHA512URLs 返回候选 .sha512 URL：优先给定下载 URL，其次所有镜像。
+func (s *Service) officialSHA512URLs(ctx context.Context, version, downloadURL string) []string {
+	urls := make([]string, 0, len(MirrorURLs)+1)
+	seen := make(map[string]struct{}, len(MirrorURLs)+1)
+	add := func(packageURL string) {
+		if packageURL == "" {
+			return
+		}
+		if _, ok := seen[packageURL]; ok {
+			return
+		}
+		seen[packageURL] = struct{}{}
+		urls = append(urls, packageURL+".sha512")
+	}
+
+	add(downloadURL)
+	mirrorURLs := getDownloadURLs(version)
+	for _, mirror := range s.mirrorManager.OrderMirrors(ctx, MirrorApache) {
+		add(mirrorURLs[mirror])
+	}
+	return urls
+}
+
+// fetchOfficialSHA512 downloads the first available official .sha512 file.
+// fetchOfficialSHA512 下载第一个可用的官方 .sha512 文件。
+func (s *Service) fetchOfficialSHA512(ctx context.Context, version, downloadURL string) (string, error) {
+	client := s.downloadClient
+	var lastErr error
+	for _, checksumURL := range s.officialSHA512URLs(ctx, version, downloadURL) {
+		checksum, err := fetchSHA512(ctx, client, checksumURL)
+		if err == nil {
+			return checksum, nil
+		}
+		lastErr = fmt.Errorf("%s: %w", checksumURL, err)
+	}
+	if lastErr == nil {
+		lastErr = errors.New("no checksum source available")
+	}
+	return "", lastErr
+}
+
+func fetchSHA512(ctx context.Context, client *http.Client, checksumURL string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, checksumURL, nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+	content, err := io.ReadAll(io.LimitReader(resp.Body, sha512FileMaxBytes))
+	if err != nil {
+		return "", err
+	}
+	return parseSHA512File(string(content))
+}
+
+// verifyPackage checks a package against its official SHA-512 checksum, fetching and
+// storing the checksum when it has not been verified before.
+// verifyPackage 根据官方 SHA-512 校验和验证安装包，首次验证时获取并保存校验和。
+func (s *Service) verifyPackage(ctx context.Context, version, packagePath string) (string, error) {
+	expected, stored := readPackageSHA512(packagePath)
+	if !stored {
+		fetched, err := s.fetchOfficialSHA512(ctx, version, "")
+		if err != nil {
+			return "", fmt.Errorf("%w: failed to fetch official sha512: %v", ErrPackageUnverified, err)
+		}
+		expected = fetched
+	}
+
+	actual, err := fileSHA512(packagePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to calculate sha512: %w", err)
+	}
+	if actual != expected {
+		return "", fmt.Errorf("%w: expected %s, got %s", ErrPackageChecksumMismatch, expected, actual)
+	}
+
+	if !stored {
+		if err := writePackageSHA512(packagePath, actual); err != nil {
+			logger.WarnF(ctx, "[Installer] 保存校验和失败 / Failed to store package checksum: path=%s, err=%v", packagePath, err)
+		} else {
+			s.mirrorPackageToStore(ctx, packagePath)
+		}
+	}
+	return actual, nil
+}
+
+// ensurePackageVerified verifies a package before it is transferred to an Agent,
+// unless verification is skipped by the request or by storage.skip_package_verification.
+// ensurePackageVerified 在安装包传输到 Agent 前进行校验，
+// 除非请求或 storage.skip_package_verification 明确跳过校验。
+func (s *Service) ensurePackageVerified(ctx context.Context, version, packagePath string, skip bool) error {
+	if packageVerificationSkipped(skip) {
+		logger.WarnF(ctx, "[Installer] 已跳过安装包校验 / Package verification skipped: version=%s, path=%s", version, packagePath)
+		return nil
+	}
+	checksum, err := s.verifyPackage(ctx, version, packagePath)
+	if err != nil {
+		return err
+	}
+	logger.InfoF(ctx, "[Installer] 安装包校验通过 / Package verified: version=%s, sha512=%s", version, checksum)
+	return nil
+}
+
+// packageVerificationSkipped reports whether the request or storage.skip_package_verification skips verification.
+// packageVerificationSkipped 判断请求或 storage.skip_package_verification 是否跳过校验。
+func packageVerificationSkipped(skip bool) bool {
+	return skip || config.GetStorageConfig().SkipPackageVerification
+}
@@ -0,0 +1,154 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package installer
+
+import (
+	"context"
+	"crypto/sha512"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseSHA512File(t *testing.T) {
+	sum := sha512.Sum512([]byte("package"))
+	checksum := hex.EncodeToString(sum[:])
+	upper := strings.ToUpper(checksum)
+
+	cases := map[string]string{
+		"sha512sum": checksum + "  apache-seatunnel-2.3.12-bin.tar.gz\n",
+		"gpg":       "apache-seatunnel-2.3.12-bin.tar.gz: " + upper[:64] + "\n " + upper[64:] + "\n",
+		"bare":      upper,
+	}
+	for name, content := range cases {
+		got, err := parseSHA512File(content)
+		if err != nil {
+			t.Fatalf("%s: parseSHA512File returned error: %v", name, err)
+		}
+		if got != checksum {
+			t.Fatalf("%s: expected %s, got %s", name, checksum, got)
+		}
+	}
+	if _, err := parseSHA512File("not-a-checksum  file"); err == nil {
+		t.Fatalf("expected invalid checksum to be rejected")
+	}
+}
+
+func runTestDownload(t *testing.T, service *Service, version string) *DownloadTask {
+	t.Helper()
+	if _, err := service.StartDownload(context.Background(), &DownloadRequest{Version: version, Mirror: MirrorApache}); err != nil {
+		t.Fatalf("StartDownload returned error: %v", err)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		task, err := service.GetDownloadStatus(context.Background(), version)
+		if err != nil {
+			t.Fatalf("GetDownloadStatus returned error: %v", err)
+		}
+		service.downloadsMu.RLock()
+		snapshot := *task
+		service.downloadsMu.RUnlock()
+		if snapshot.Status == DownloadStatusCompleted || snapshot.Status == DownloadStatusFailed {
+			return &snapshot
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("download did not finish, status=%s", snapshot.Status)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
+
+func TestService_Download_VerifiesOfficialSHA512(t *testing.T) {
+	const version = "2.3.12"
+	sum := sha512.Sum512([]byte("package"))
+	checksum := hex.EncodeToString(sum[:])
+	served := checksum
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, ".sha512"):
+			_, _ = w.Write([]byte(served + "  " + packageFileName(version) + "\n"))
+		case strings.HasSuffix(r.URL.Path, packageFileName(version)):
+			_, _ = w.Write([]byte("package"))
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer server.Close()
+	withTestMirrors(t, map[MirrorSource]string{MirrorApache: server.URL})
+
+	service := NewService(t.TempDir(), nil)
+	service.tempDir = t.TempDir()
+	task := runTestDownload(t, service, version)
+	if task.Status != DownloadStatusCompleted || !task.Verified {
+		t.Fatalf("expected verified download, got status=%s verified=%t error=%s", task.Status, task.Verified, task.Error)
+	}
+	info, err := service.GetPackageInfo(context.Background(), version)
+	if err != nil {
+		t.Fatalf("GetPackageInfo returned error: %v", err)
+	}
+	if !info.Verified || info.SHA512 != checksum {
+		t.Fatalf("expected package info to carry verified sha512, got verified=%t sha512=%s", info.Verified, info.SHA512)
+	}
+	if err := service.ensurePackageVerified(context.Background(), version, info.LocalPath, false); err != nil {
+		t.Fatalf("expected verified package to be transferable, got %v", err)
+	}
+
+	if err := service.DeletePackage(context.Background(), version); err != nil {
+		t.Fatalf("DeletePackage returned error: %v", err)
+	}
+	if _, err := os.Stat(packageSHA512Path(info.LocalPath)); !os.IsNotExist(err) {
+		t.Fatalf("expected checksum file to be removed with the package, stat err=%v", err)
+	}
+
+	served = strings.Repeat("0", sha512.Size*2)
+	task = runTestDownload(t, service, version)
+	if task.Status != DownloadStatusFailed || !strings.Contains(task.Error, "checksum mismatch") {
+		t.Fatalf("expected checksum mismatch failure, got status=%s error=%s", task.Status, task.Error)
+	}
+	if _, err := os.Stat(filepath.Join(service.packageDir, packageFileName(version))); !os.IsNotExist(err) {
+		t.Fatalf("expected mismatched package not to be saved, stat err=%v", err)
+	}
+}
+
+func TestService_EnsurePackageVerified_RefusesUnverified(t *testing.T) {
+	const version = "2.3.12"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+	withTestMirrors(t, map[MirrorSource]string{MirrorApache: server.URL})
+
+	service := NewService(t.TempDir(), nil)
+	packagePath := filepath.Join(service.packageDir, packageFileName(version))
+	if err := os.WriteFile(packagePath, []byte("package"), 0644); err != nil {
+		t.Fatalf("failed to write package: %v", err)
+	}
+
+	if err := service.ensurePackageVerified(context.Background(), version, packagePath, false); !errors.Is(err, ErrPackageUnverified) {
+		t.Fatalf("expected unverified package to be refused, got %v", err)
+	}
+	if err := service.ensurePackageVerified(context.Background(), version, packagePath, true); err != nil {
+		t.Fatalf("expected skip to allow transfer, got %v", err)
+	}
+}
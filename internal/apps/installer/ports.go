@@ -0,0 +1,301 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package installer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/seatunnel/seatunnelX/internal/logger"
+)
+
+// ErrNoFreePort is returned when use_suggested_ports finds no free alternative for a busy port.
+// ErrNoFreePort 在 use_suggested_ports 找不到被占用端口的可用替代端口时返回。
+var ErrNoFreePort = errors.New("no free alternative port found / 未找到可用的替代端口")
+
+// ErrPortCollision is returned when two SeaTunnel roles sharing a host are given the same port.
+// ErrPortCollision 在共用同一主机的两个 SeaTunnel 角色被分配了相同端口时返回。
+var ErrPortCollision = errors.New("master, worker and http ports must differ on the same host / 同一主机上的 master、worker 与 http 端口不能相同")
+
+// defaultPortSuggestionSpan is how far past a busy port the Agent scans when no range is given.
+// defaultPortSuggestionSpan 是未指定范围时 Agent 在被占用端口之后扫描的端口数量。
+const defaultPortSuggestionSpan = 100
+
+// Default SeaTunnel ports, matching the Agent install defaults.
+// SeaTunnel 默认端口，与 Agent 安装默认值一致。
+const (
+	defaultClusterPort = 5801
+	defaultWorkerPort  = 5802
+	defaultHTTPPort    = 8080
+)
+
+// checkPortsAvailable asks the Agent which ports are free; ports that cannot be checked count as busy.
+// checkPortsAvailable 询问 Agent 哪些端口可用；无法检查的端口视为被占用。
+func (s *Service) checkPortsAvailable(ctx context.Context, agentID string, ports []int) (available, unavailable []int) {
+	available = make([]int, 0, len(ports))
+	unavailable = make([]int, 0)
+	for _, port := range ports {
+		success, _, err := s.agentManager.SendCommand(ctx, agentID, "check_port", map[string]string{
+			"port": strconv.Itoa(port),
+		})
+		// A listening port is in use / 正在监听的端口即被占用
+		if err != nil || success {
+			unavailable = append(unavailable, port)
+		} else {
+			available = append(available, port)
+		}
+	}
+	return available, unavailable
+}
+
+// suggestAlternativePorts asks the Agent for one free port per conflict. Without an explicit
+// range each conflict is searched in the span right after it; planned and already suggested
+// ports are excluded so alternatives never collide.
+// suggestAlternativePorts 为每个冲突端口向 Agent 请求一个可用端口。未指定范围时在冲突端口之后的
+// 区间内查找；计划使用与已建议的端口会被排除，避免替代端口互相冲突。
+func (s *Service) suggestAlternativePorts(ctx context.Context, agentID string, planned, conflicts []int, rangeStart, rangeEnd int) map[int]int {
+	exclude := make([]string, 0, len(planned)+len(conflicts))
+	for _, port := range planned {
+		exclude = append(exclude, strconv.Itoa(port))
+	}
+
+	alternatives := make(map[int]int, len(conflicts))
+	for _, conflict := range conflicts {
+		start, end := rangeStart, rangeEnd
+		if start <= 0 || end <= 0 {
+			start, end = conflict+1, conflict+defaultPortSuggestionSpan
+		}
+		if end > 65535 {
+			end = 65535
+		}
+		success, output, err := s.agentManager.SendCommand(ctx, agentID, "suggest_ports", map[string]string{
+			"range_start": strconv.Itoa(start),
+			"range_end":   strconv.Itoa(end),
+			"count":       "1",
+			"exclude":     strings.Join(exclude, ","),
+		})
+		if err != nil || !success {
+			logger.WarnF(ctx, "[Installer] 端口建议失败 / port suggestion failed: port=%d, range=%d-%d, err=%v, output=%s", conflict, start, end, err, output)
+			continue
+		}
+		port := parseSuggestedPort(output)
+		if port <= 0 {
+			continue
+		}
+		alternatives[conflict] = port
+		exclude = append(exclude, strconv.Itoa(port))
+	}
+	return alternatives
+}
+
+// parseSuggestedPort reads the first port of a suggest_ports result.
+// parseSuggestedPort 读取 suggest_ports 结果中的第一个端口。
+func parseSuggestedPort(output string) int {
+	var parsed struct {
+		Details map[string]string `json:"details"`
+	}
+	if err := json.Unmarshal([]byte(strings.TrimSpace(output)), &parsed); err != nil {
+		return 0
+	}
+	first, _, _ := strings.Cut(parsed.Details["ports"], ",")
+	port, _ := strconv.Atoi(strings.TrimSpace(first))
+	return port
+}
+
+// validateRolePorts rejects requests whose role ports collide once defaults apply. In separated
+// mode the worker port only matters when a host runs both roles, i.e. the node is master/worker
+// or an address appears in both member lists; the http port is only bound by master-capable nodes.
+// validateRolePorts 在应用默认值后拒绝角色端口冲突的请求。分离模式下仅当主机同时运行两种角色
+// （节点为 master/worker，或某地址同时出现在两个成员列表中）时才需要比较 worker 端口；http 端口仅由可作为 master 的节点监听。
+func validateRolePorts(req *InstallationRequest) error {
+	clusterPort := portOrDefault(req.ClusterPort, defaultClusterPort)
+	workerPort := portOrDefault(req.WorkerPort, defaultWorkerPort)
+	httpPort := portOrDefault(req.HTTPPort, defaultHTTPPort)
+
+	sharedHost := req.NodeRole == NodeRoleMasterWorker || hasSharedAddress(req.MasterAddresses, req.WorkerAddresses)
+	runsMaster := req.NodeRole != NodeRoleWorker
+	runsWorker := req.DeploymentMode == DeploymentModeSeparated && (req.NodeRole == NodeRoleWorker || sharedHost)
+	if req.DeploymentMode == DeploymentModeSeparated && sharedHost {
+		runsMaster = true
+	}
+
+	bound := make(map[int]string, 3)
+	claim := func(name string, port int) error {
+		if other, ok := bound[port]; ok {
+			return fmt.Errorf("%w: %s and %s both use %d", ErrPortCollision, other, name, port)
+		}
+		bound[port] = name
+		return nil
+	}
+	if runsMaster {
+		if err := claim("cluster_port", clusterPort); err != nil {
+			return err
+		}
+	}
+	if runsWorker {
+		if err := claim("worker_port", workerPort); err != nil {
+			return err
+		}
+	}
+	httpEnabled := req.HTTPPort > 0
+	if req.EnableHTTP != nil {
+		httpEnabled = *req.EnableHTTP
+	}
+	if runsMaster && httpEnabled {
+		if err := claim("http_port", httpPort); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func portOrDefault(port, fallback int) int {
+	if port > 0 {
+		return port
+	}
+	return fallback
+}
+
+func hasSharedAddress(masters, workers []string) bool {
+	seen := make(map[string]struct{}, len(masters))
+	for _, address := range masters {
+		seen[strings.TrimSpace(address)] = struct{}{}
+	}
+	for _, address := range workers {
+		if _, ok := seen[strings.TrimSpace(address)]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// portRoles tells which precheck port plays which SeaTunnel role.
+// portRoles 表示预检查端口各自对应的 SeaTunnel 角色。
+type portRoles struct {
+	cluster, worker, http int
+}
+
+// precheckPortRoles resolves port roles the same way the install defaults do; the cluster port
+// falls back to the first checked port as in the firewall check.
+// precheckPortRoles 按安装默认值解析端口角色；cluster 端口与防火墙检查一样回退到首个检查端口。
+func precheckPortRoles(req *PrecheckRequest, ports []int) portRoles {
+	roles := portRoles{cluster: req.ClusterPort, worker: req.WorkerPort, http: req.HTTPPort}
+	if roles.cluster == 0 && len(ports) > 0 {
+		roles.cluster = ports[0]
+	}
+	if roles.worker == 0 {
+		roles.worker = defaultWorkerPort
+	}
+	if roles.http == 0 {
+		roles.http = defaultHTTPPort
+	}
+	return roles
+}
+
+// suggestions maps alternatives onto install request fields; nil when no role port conflicts.
+// suggestions 将替代端口映射为安装请求字段；没有角色端口冲突时返回 nil。
+func (r portRoles) suggestions(alternatives map[int]int) *SuggestedPorts {
+	suggested := &SuggestedPorts{
+		ClusterPort: alternatives[r.cluster],
+		WorkerPort:  alternatives[r.worker],
+		HTTPPort:    alternatives[r.http],
+	}
+	if *suggested == (SuggestedPorts{}) {
+		return nil
+	}
+	return suggested
+}
+
+// formatPortSuggestion renders the alternatives for the precheck item suggestion.
+// formatPortSuggestion 将替代端口渲染为预检查项的建议文本。
+func formatPortSuggestion(conflicts []int, alternatives map[int]int) string {
+	pairs := make([]string, 0, len(alternatives))
+	for _, port := range conflicts {
+		if alternative, ok := alternatives[port]; ok {
+			pairs = append(pairs, fmt.Sprintf("%d -> %d", port, alternative))
+		}
+	}
+	sort.Strings(pairs)
+	joined := strings.Join(pairs, ", ")
+	return fmt.Sprintf("Use free ports instead: %s / 可改用空闲端口: %s", joined, joined)
+}
+
+// applySuggestedPorts replaces busy ports of an install request with free alternatives from the
+// target Agent, so use_suggested_ports installs without a separate precheck round trip.
+// applySuggestedPorts 用目标 Agent 提供的可用端口替换安装请求中被占用的端口，
+// 使 use_suggested_ports 无需额外的预检查往返即可安装。
+func (s *Service) applySuggestedPorts(ctx context.Context, req *InstallationRequest) error {
+	hostID, err := parseHostID(req.HostID)
+	if err != nil {
+		return err
+	}
+	if s.agentManager == nil {
+		return ErrHostNotConnected
+	}
+	agentID, ok := s.agentManager.GetAgentByHostID(hostID)
+	if !ok || agentID == "" {
+		return ErrHostNotConnected
+	}
+
+	fields := []*int{&req.ClusterPort, &req.HTTPPort}
+	defaults := []int{defaultClusterPort, defaultHTTPPort}
+	// Hybrid nodes do not listen on the worker port / 混合模式节点不监听 worker 端口
+	if req.DeploymentMode == DeploymentModeSeparated || req.WorkerPort > 0 {
+		fields = append(fields, &req.WorkerPort)
+		defaults = append(defaults, defaultWorkerPort)
+	}
+	planned := make([]int, len(fields))
+	for i, field := range fields {
+		planned[i] = *field
+		if planned[i] <= 0 {
+			planned[i] = defaults[i]
+		}
+	}
+
+	_, busy := s.checkPortsAvailable(ctx, agentID, planned)
+	if len(busy) == 0 {
+		return nil
+	}
+	alternatives := s.suggestAlternativePorts(ctx, agentID, planned, busy, 0, 0)
+	for i, field := range fields {
+		if !containsPort(busy, planned[i]) {
+			continue
+		}
+		alternative, ok := alternatives[planned[i]]
+		if !ok {
+			return fmt.Errorf("%w: %d", ErrNoFreePort, planned[i])
+		}
+		*field = alternative
+	}
+	logger.InfoF(ctx, "[Installer] 已采用建议端口 / applied suggested ports: host=%d, cluster=%d, worker=%d, http=%d", hostID, req.ClusterPort, req.WorkerPort, req.HTTPPort)
+	return nil
+}
+
+func containsPort(ports []int, port int) bool {
+	for _, candidate := range ports {
+		if candidate == port {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,131 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package installer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// portAgentManager answers check_port and suggest_ports from a fixed set of busy ports.
+// portAgentManager 根据固定的占用端口集合响应 check_port 与 suggest_ports。
+type portAgentManager struct {
+	dryRunAgentManager
+	busy map[int]bool
+}
+
+func (m *portAgentManager) SendCommand(ctx context.Context, agentID string, commandType string, params map[string]string) (bool, string, error) {
+	switch commandType {
+	case "check_port":
+		port, _ := strconv.Atoi(params["port"])
+		return m.busy[port], "", nil
+	case "suggest_ports":
+		start, _ := strconv.Atoi(params["range_start"])
+		end, _ := strconv.Atoi(params["range_end"])
+		excluded := map[string]bool{}
+		for _, value := range strings.Split(params["exclude"], ",") {
+			excluded[value] = true
+		}
+		for port := start; port <= end; port++ {
+			if !m.busy[port] && !excluded[strconv.Itoa(port)] {
+				output, _ := json.Marshal(map[string]interface{}{"success": true, "details": map[string]string{"ports": strconv.Itoa(port)}})
+				return true, string(output), nil
+			}
+		}
+		return false, `{"success":false,"details":{"ports":""}}`, nil
+	}
+	return m.dryRunAgentManager.SendCommand(ctx, agentID, commandType, params)
+}
+
+func TestSuggestAlternativePortsAvoidsPlannedAndSuggestedPorts(t *testing.T) {
+	agentManager := &portAgentManager{busy: map[int]bool{5801: true, 5802: true, 5803: true}}
+	service := NewService(t.TempDir(), agentManager)
+	planned := []int{5801, 5802, 8080}
+
+	_, busy := service.checkPortsAvailable(context.Background(), "agent-1", planned)
+	alternatives := service.suggestAlternativePorts(context.Background(), "agent-1", planned, busy, 0, 0)
+	if alternatives[5801] != 5804 || alternatives[5802] != 5805 {
+		t.Fatalf("expected distinct free alternatives 5804/5805, got %v", alternatives)
+	}
+
+	suggested := precheckPortRoles(&PrecheckRequest{}, planned).suggestions(alternatives)
+	if suggested == nil || suggested.ClusterPort != 5804 || suggested.WorkerPort != 5805 || suggested.HTTPPort != 0 {
+		t.Fatalf("unexpected role mapping %+v", suggested)
+	}
+
+	ranged := service.suggestAlternativePorts(context.Background(), "agent-1", planned, []int{8080}, 9000, 9010)
+	if ranged[8080] != 9000 {
+		t.Fatalf("expected the configured range to be scanned, got %v", ranged)
+	}
+}
+
+func TestApplySuggestedPortsRewritesBusyInstallPorts(t *testing.T) {
+	agentManager := &portAgentManager{busy: map[int]bool{5801: true, 8080: true, 5802: true}}
+	service := NewService(t.TempDir(), agentManager)
+
+	// Hybrid nodes ignore the busy worker port / 混合模式节点忽略被占用的 worker 端口
+	req := &InstallationRequest{HostID: "3", DeploymentMode: DeploymentModeHybrid}
+	if err := service.applySuggestedPorts(context.Background(), req); err != nil {
+		t.Fatalf("applySuggestedPorts returned error: %v", err)
+	}
+	if req.ClusterPort != 5803 || req.HTTPPort != 8081 || req.WorkerPort != 0 {
+		t.Fatalf("unexpected ports cluster=%d http=%d worker=%d", req.ClusterPort, req.HTTPPort, req.WorkerPort)
+	}
+
+	for port := 8081; port <= 8080+defaultPortSuggestionSpan; port++ {
+		agentManager.busy[port] = true
+	}
+	req = &InstallationRequest{HostID: "3", ClusterPort: 6000}
+	if err := service.applySuggestedPorts(context.Background(), req); !errors.Is(err, ErrNoFreePort) {
+		t.Fatalf("expected ErrNoFreePort, got %v", err)
+	}
+}
+
+func TestValidateRolePortsRejectsCollisionsOnSharedHosts(t *testing.T) {
+	enabled := true
+	valid := []*InstallationRequest{
+		// Hybrid nodes never listen on the worker port / 混合模式节点不监听 worker 端口
+		{DeploymentMode: DeploymentModeHybrid, NodeRole: NodeRoleMasterWorker, ClusterPort: 5801, WorkerPort: 5801},
+		// Separate hosts may reuse the master port for workers / 不同主机上的 worker 可复用 master 端口
+		{DeploymentMode: DeploymentModeSeparated, NodeRole: NodeRoleWorker, ClusterPort: 5801, WorkerPort: 5801,
+			MasterAddresses: []string{"10.0.0.1"}, WorkerAddresses: []string{"10.0.0.2"}},
+		{DeploymentMode: DeploymentModeSeparated, NodeRole: NodeRoleMaster, ClusterPort: 5801, WorkerPort: 5802,
+			MasterAddresses: []string{"10.0.0.1"}, WorkerAddresses: []string{"10.0.0.1"}},
+	}
+	for i, req := range valid {
+		if err := validateRolePorts(req); err != nil {
+			t.Fatalf("case %d: unexpected error %v", i, err)
+		}
+	}
+
+	invalid := []*InstallationRequest{
+		{DeploymentMode: DeploymentModeSeparated, NodeRole: NodeRoleWorker, ClusterPort: 5801, WorkerPort: 5801,
+			MasterAddresses: []string{"10.0.0.1"}, WorkerAddresses: []string{"10.0.0.1"}},
+		{DeploymentMode: DeploymentModeSeparated, NodeRole: NodeRoleMasterWorker, WorkerPort: defaultClusterPort},
+		{DeploymentMode: DeploymentModeHybrid, NodeRole: NodeRoleMasterWorker, ClusterPort: 8080, EnableHTTP: &enabled},
+	}
+	for i, req := range invalid {
+		if err := validateRolePorts(req); !errors.Is(err, ErrPortCollision) {
+			t.Fatalf("case %d: expected ErrPortCollision, got %v", i, err)
+		}
+	}
+}
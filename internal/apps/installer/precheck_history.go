@@ -0,0 +1,401 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package installer
+
+import (
+	"bytes"
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/seatunnel/seatunnelX/internal/logger"
+	"gorm.io/gorm"
+)
+
+// ErrPrecheckRunNotFound indicates the referenced precheck run does not exist for the host.
+// ErrPrecheckRunNotFound 表示该主机下引用的预检查记录不存在。
+var ErrPrecheckRunNotFound = errors.New("precheck run not found / 预检查记录未找到")
+
+// ErrPrecheckHistoryUnavailable indicates precheck history is not configured on this service.
+// ErrPrecheckHistoryUnavailable 表示当前服务未配置预检查历史存储。
+var ErrPrecheckHistoryUnavailable = errors.New("precheck history is not configured / 预检查历史未配置")
+
+const (
+	// precheckHistoryRetention is the number of runs kept per host.
+	// precheckHistoryRetention 是每台主机保留的预检查记录数。
+	precheckHistoryRetention = 50
+	// defaultPrecheckHistoryLimit is the page size of the history endpoint.
+	// defaultPrecheckHistoryLimit 是历史接口的默认返回条数。
+	defaultPrecheckHistoryLimit = 20
+)
+
+// PrecheckItems stores precheck items as a JSON column.
+// PrecheckItems 以 JSON 列保存预检查项。
+type PrecheckItems []PrecheckItem
+
+// Value implements driver.Valuer for database storage.
+// Value 实现 driver.Valuer，用于数据库存储。
+func (items PrecheckItems) Value() (driver.Value, error) {
+	if items == nil {
+		return "[]", nil
+	}
+	return json.Marshal(items)
+}
+
+// Scan implements sql.Scanner for database retrieval.
+// Scan 实现 sql.Scanner，用于数据库读取。
+func (items *PrecheckItems) Scan(value interface{}) error {
+	if value == nil {
+		*items = PrecheckItems{}
+		return nil
+	}
+	var data []byte
+	switch v := value.(type) {
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return errors.New("installer: failed to scan PrecheckItems - expected []byte")
+	}
+	return json.Unmarshal(data, items)
+}
+
+// PrecheckRun is one persisted precheck execution on a host.
+// PrecheckRun 是主机上一次已持久化的预检查执行记录。
+type PrecheckRun struct {
+	ID            uint          `json:"id" gorm:"primaryKey;autoIncrement"`
+	HostID        uint          `json:"host_id" gorm:"not null;index:idx_precheck_runs_host_id,priority:1"`
+	OverallStatus CheckStatus   `json:"overall_status" gorm:"size:20;not null;index"`
+	Summary       string        `json:"summary" gorm:"type:text"`
+	InstallDir    string        `json:"install_dir" gorm:"size:255"`
+	Version       string        `json:"version" gorm:"size:50"`
+	Items         PrecheckItems `json:"items" gorm:"type:json"`
+	CreatedAt     time.Time     `json:"created_at" gorm:"autoCreateTime;index:idx_precheck_runs_host_id,priority:2"`
+}
+
+// TableName specifies the precheck run table name.
+// TableName 指定预检查记录表名。
+func (PrecheckRun) TableName() string {
+	return "precheck_runs"
+}
+
+// PrecheckChangeType describes how a check differs between two runs.
+// PrecheckChangeType 描述某项检查在两次运行之间的差异类型。
+type PrecheckChangeType string
+
+const (
+	PrecheckChangeAdded   PrecheckChangeType = "added"
+	PrecheckChangeRemoved PrecheckChangeType = "removed"
+	PrecheckChangeChanged PrecheckChangeType = "changed"
+)
+
+// PrecheckItemChange is the difference of one check between the base and the target run.
+// PrecheckItemChange 是某项检查在基准记录与目标记录之间的差异。
+type PrecheckItemChange struct {
+	Name   string             `json:"name"`
+	Change PrecheckChangeType `json:"change"`
+	Before *PrecheckItem      `json:"before,omitempty"`
+	After  *PrecheckItem      `json:"after,omitempty"`
+	// ChangedDetails lists the detail keys whose values differ.
+	// ChangedDetails 列出取值发生变化的详情字段。
+	ChangedDetails []string `json:"changed_details,omitempty"`
+	// Regressed is set when the check status got worse, e.g. passed to failed.
+	// Regressed 在检查状态变差时设置，例如从通过变为失败。
+	Regressed bool `json:"regressed"`
+}
+
+// PrecheckDiff compares two precheck runs of the same host.
+// PrecheckDiff 比较同一主机的两次预检查记录。
+type PrecheckDiff struct {
+	// Base is the last passing run before the target, or the previous run when none passed.
+	// Base 是目标记录之前最近一次通过的记录；若没有通过记录则为上一次记录。
+	Base    *PrecheckRun         `json:"base"`
+	Target  *PrecheckRun         `json:"target"`
+	Changes []PrecheckItemChange `json:"changes"`
+}
+
+// PrecheckRepository provides persistence access for precheck runs.
+// PrecheckRepository 提供预检查记录的持久化访问。
+type PrecheckRepository struct {
+	db *gorm.DB
+}
+
+// NewPrecheckRepository creates a new precheck run repository.
+// NewPrecheckRepository 创建预检查记录仓库实例。
+func NewPrecheckRepository(db *gorm.DB) *PrecheckRepository {
+	return &PrecheckRepository{db: db}
+}
+
+// Create stores a precheck run and drops the runs of the host beyond the retention.
+// Create 保存预检查记录，并删除该主机超出保留数量的旧记录。
+func (r *PrecheckRepository) Create(ctx context.Context, run *PrecheckRun) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(run).Error; err != nil {
+			return err
+		}
+		var cutoff []uint
+		if err := tx.Model(&PrecheckRun{}).Where("host_id = ?", run.HostID).
+			Order("id DESC").Offset(precheckHistoryRetention-1).Limit(1).Pluck("id", &cutoff).Error; err != nil {
+			return err
+		}
+		if len(cutoff) == 0 {
+			return nil
+		}
+		return tx.Where("host_id = ? AND id < ?", run.HostID, cutoff[0]).Delete(&PrecheckRun{}).Error
+	})
+}
+
+// ListByHost returns the latest runs of a host, newest first.
+// ListByHost 返回主机最近的预检查记录，按时间倒序。
+func (r *PrecheckRepository) ListByHost(ctx context.Context, hostID uint, limit int) ([]*PrecheckRun, error) {
+	var runs []*PrecheckRun
+	if err := r.db.WithContext(ctx).Where("host_id = ?", hostID).
+		Order("id DESC").Limit(limit).Find(&runs).Error; err != nil {
+		return nil, err
+	}
+	return runs, nil
+}
+
+// Get returns a run of the host by ID.
+// Get 根据 ID 获取主机的预检查记录。
+func (r *PrecheckRepository) Get(ctx context.Context, hostID, id uint) (*PrecheckRun, error) {
+	var run PrecheckRun
+	if err := r.db.WithContext(ctx).Where("host_id = ?", hostID).First(&run, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrPrecheckRunNotFound
+		}
+		return nil, err
+	}
+	return &run, nil
+}
+
+// Latest returns the newest run of the host before beforeID (0 means no bound),
+// optionally restricted to one overall status; it returns nil when there is none.
+// Latest 返回主机在 beforeID 之前（0 表示不限）的最新记录，可按整体状态过滤；不存在时返回 nil。
+func (r *PrecheckRepository) Latest(ctx context.Context, hostID, beforeID uint, status CheckStatus) (*PrecheckRun, error) {
+	query := r.db.WithContext(ctx).Where("host_id = ?", hostID)
+	if beforeID > 0 {
+		query = query.Where("id < ?", beforeID)
+	}
+	if status != "" {
+		query = query.Where("overall_status = ?", status)
+	}
+	var runs []*PrecheckRun
+	if err := query.Order("id DESC").Limit(1).Find(&runs).Error; err != nil {
+		return nil, err
+	}
+	if len(runs) == 0 {
+		return nil, nil
+	}
+	return runs[0], nil
+}
+
+// SetPrecheckRepository sets the repository for precheck history.
+// SetPrecheckRepository 设置预检查历史仓库。
+func (s *Service) SetPrecheckRepository(repo *PrecheckRepository) {
+	s.precheckRepo = repo
+}
+
+// recordPrecheckRun persists a precheck result; failures are logged and never fail the precheck.
+// recordPrecheckRun 持久化预检查结果；失败只记录日志，不影响预检查本身。
+func (s *Service) recordPrecheckRun(ctx context.Context, hostID uint, req *PrecheckRequest, result *PrecheckResult) {
+	if s.precheckRepo == nil || result == nil {
+		return
+	}
+	run := &PrecheckRun{
+		HostID:        hostID,
+		OverallStatus: result.OverallStatus,
+		Summary:       result.Summary,
+		Items:         PrecheckItems(result.Items),
+	}
+	if req != nil {
+		run.InstallDir = req.InstallDir
+		run.Version = req.Version
+	}
+	if err := s.precheckRepo.Create(ctx, run); err != nil {
+		logger.WarnF(ctx, "[Installer] 保存预检查记录失败 / Failed to save precheck run: host=%d, err=%v", hostID, err)
+		return
+	}
+	result.RunID = run.ID
+}
+
+// ListPrecheckHistory returns the latest precheck runs of a host, newest first.
+// ListPrecheckHistory 返回主机最近的预检查记录，按时间倒序。
+func (s *Service) ListPrecheckHistory(ctx context.Context, hostID uint, limit int) ([]*PrecheckRun, error) {
+	if s.precheckRepo == nil {
+		return nil, ErrPrecheckHistoryUnavailable
+	}
+	if limit <= 0 {
+		limit = defaultPrecheckHistoryLimit
+	}
+	if limit > precheckHistoryRetention {
+		limit = precheckHistoryRetention
+	}
+	return s.precheckRepo.ListByHost(ctx, hostID, limit)
+}
+
+// GetPrecheckRun returns one precheck run of a host.
+// GetPrecheckRun 返回主机的一条预检查记录。
+func (s *Service) GetPrecheckRun(ctx context.Context, hostID, runID uint) (*PrecheckRun, error) {
+	if s.precheckRepo == nil {
+		return nil, ErrPrecheckHistoryUnavailable
+	}
+	return s.precheckRepo.Get(ctx, hostID, runID)
+}
+
+// DiffPrecheckRuns compares two precheck runs of a host. A zero targetID selects the latest run;
+// a zero baseID selects the last passing run before the target, falling back to the previous run.
+// DiffPrecheckRuns 比较主机的两次预检查记录。targetID 为 0 时取最新记录；
+// baseID 为 0 时取目标之前最近一次通过的记录，若没有则取上一次记录。
+func (s *Service) DiffPrecheckRuns(ctx context.Context, hostID, baseID, targetID uint) (*PrecheckDiff, error) {
+	if s.precheckRepo == nil {
+		return nil, ErrPrecheckHistoryUnavailable
+	}
+
+	var target *PrecheckRun
+	var err error
+	if targetID > 0 {
+		target, err = s.precheckRepo.Get(ctx, hostID, targetID)
+	} else {
+		target, err = s.precheckRepo.Latest(ctx, hostID, 0, "")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if target == nil {
+		return nil, ErrPrecheckRunNotFound
+	}
+
+	var base *PrecheckRun
+	if baseID > 0 {
+		base, err = s.precheckRepo.Get(ctx, hostID, baseID)
+	} else {
+		base, err = s.precheckRepo.Latest(ctx, hostID, target.ID, CheckStatusPassed)
+		if err == nil && base == nil {
+			base, err = s.precheckRepo.Latest(ctx, hostID, target.ID, "")
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	diff := &PrecheckDiff{Base: base, Target: target, Changes: []PrecheckItemChange{}}
+	if base != nil {
+		diff.Changes = diffPrecheckItems(base.Items, target.Items)
+	}
+	return diff, nil
+}
+
+// diffPrecheckItems matches checks by name and reports added, removed and changed ones,
+// in target order followed by removed checks in base order.
+// diffPrecheckItems 按名称匹配检查项，报告新增、移除和变化的项，
+// 顺序为目标记录中的顺序，其后是基准记录中被移除的项。
+func diffPrecheckItems(base, target []PrecheckItem) []PrecheckItemChange {
+	baseByName := make(map[string]*PrecheckItem, len(base))
+	for i := range base {
+		baseByName[base[i].Name] = &base[i]
+	}
+
+	changes := make([]PrecheckItemChange, 0)
+	seen := make(map[string]bool, len(target))
+	for i := range target {
+		after := &target[i]
+		seen[after.Name] = true
+		before, ok := baseByName[after.Name]
+		if !ok {
+			changes = append(changes, PrecheckItemChange{
+				Name:      after.Name,
+				Change:    PrecheckChangeAdded,
+				After:     after,
+				Regressed: checkStatusRank(after.Status) > checkStatusRank(CheckStatusPassed),
+			})
+			continue
+		}
+		changedDetails := diffPrecheckDetails(before.Details, after.Details)
+		if before.Status == after.Status && before.Message == after.Message && len(changedDetails) == 0 {
+			continue
+		}
+		changes = append(changes, PrecheckItemChange{
+			Name:           after.Name,
+			Change:         PrecheckChangeChanged,
+			Before:         before,
+			After:          after,
+			ChangedDetails: changedDetails,
+			Regressed:      checkStatusRank(after.Status) > checkStatusRank(before.Status),
+		})
+	}
+	for i := range base {
+		if seen[base[i].Name] {
+			continue
+		}
+		changes = append(changes, PrecheckItemChange{
+			Name:   base[i].Name,
+			Change: PrecheckChangeRemoved,
+			Before: &base[i],
+		})
+	}
+	return changes
+}
+
+// diffPrecheckDetails returns the detail keys whose values differ, in lexical order.
+// diffPrecheckDetails 按字典序返回取值不同的详情字段。
+func diffPrecheckDetails(before, after map[string]interface{}) []string {
+	var keys []string
+	for _, key := range sortedDetailKeys(before, after) {
+		left, leftErr := json.Marshal(before[key])
+		right, rightErr := json.Marshal(after[key])
+		if leftErr != nil || rightErr != nil || !bytes.Equal(left, right) {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// sortedDetailKeys returns the union of the detail keys in lexical order.
+// sortedDetailKeys 返回详情字段的并集，按字典序排列。
+func sortedDetailKeys(maps ...map[string]interface{}) []string {
+	set := make(map[string]struct{})
+	for _, m := range maps {
+		for key := range m {
+			set[key] = struct{}{}
+		}
+	}
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// checkStatusRank orders check statuses from best to worst.
+// checkStatusRank 按从好到差的顺序为检查状态排序。
+func checkStatusRank(status CheckStatus) int {
+	switch status {
+	case CheckStatusPassed:
+		return 0
+	case CheckStatusWarning:
+		return 1
+	default:
+		return 2
+	}
+}
@@ -0,0 +1,162 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package installer
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/seatunnel/seatunnelX/internal/apps/response"
+)
+
+// PrecheckRunResponse is the response for a single precheck run.
+// PrecheckRunResponse 是单条预检查记录的响应。
+type PrecheckRunResponse struct {
+	response.Meta
+	Data *PrecheckRun `json:"data"`
+}
+
+// ListPrecheckRunsResponse is the response for listing precheck runs.
+// ListPrecheckRunsResponse 是预检查记录列表的响应。
+type ListPrecheckRunsResponse struct {
+	response.Meta
+	Data []*PrecheckRun `json:"data"`
+}
+
+// PrecheckDiffResponse is the response for comparing precheck runs.
+// PrecheckDiffResponse 是预检查记录对比的响应。
+type PrecheckDiffResponse struct {
+	response.Meta
+	Data *PrecheckDiff `json:"data"`
+}
+
+// precheckHistoryErrorStatus maps precheck history errors to HTTP status codes.
+// precheckHistoryErrorStatus 将预检查历史错误映射为 HTTP 状态码。
+func precheckHistoryErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, ErrPrecheckRunNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrPrecheckHistoryUnavailable):
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// parseOptionalRunID parses an optional run ID query parameter; an absent value yields 0.
+// parseOptionalRunID 解析可选的记录 ID 查询参数；未提供时返回 0。
+func parseOptionalRunID(c *gin.Context, name string) (uint, bool) {
+	raw := c.Query(name)
+	if raw == "" {
+		return 0, true
+	}
+	id, err := strconv.ParseUint(raw, 10, 64)
+	if err != nil || id == 0 {
+		response.Error(c, http.StatusBadRequest, "无效的预检查记录 ID / Invalid precheck run ID: "+name)
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// ListPrecheckHistory handles GET /api/v1/hosts/:id/precheck/history - lists precheck runs of a host.
+// ListPrecheckHistory 处理 GET /api/v1/hosts/:id/precheck/history - 获取主机的预检查历史。
+// @Tags installation
+// @Produce json
+// @Param id path int true "主机ID"
+// @Param limit query int false "返回条数，默认 20，最大 50"
+// @Success 200 {object} ListPrecheckRunsResponse
+// @Router /api/v1/hosts/{id}/precheck/history [get]
+func (h *Handler) ListPrecheckHistory(c *gin.Context) {
+	hostID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "无效的主机 ID / Invalid host ID")
+		return
+	}
+	limit, _ := strconv.Atoi(c.Query("limit"))
+
+	runs, err := h.service.ListPrecheckHistory(c.Request.Context(), uint(hostID), limit)
+	if err != nil {
+		response.Error(c, precheckHistoryErrorStatus(err), err.Error())
+		return
+	}
+	response.OK(c, runs)
+}
+
+// GetPrecheckRun handles GET /api/v1/hosts/:id/precheck/history/:runId - gets a precheck run.
+// GetPrecheckRun 处理 GET /api/v1/hosts/:id/precheck/history/:runId - 获取预检查记录。
+// @Tags installation
+// @Produce json
+// @Param id path int true "主机ID"
+// @Param runId path int true "预检查记录ID"
+// @Success 200 {object} PrecheckRunResponse
+// @Router /api/v1/hosts/{id}/precheck/history/{runId} [get]
+func (h *Handler) GetPrecheckRun(c *gin.Context) {
+	hostID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "无效的主机 ID / Invalid host ID")
+		return
+	}
+	runID, err := strconv.ParseUint(c.Param("runId"), 10, 64)
+	if err != nil || runID == 0 {
+		response.Error(c, http.StatusBadRequest, "无效的预检查记录 ID / Invalid precheck run ID")
+		return
+	}
+
+	run, err := h.service.GetPrecheckRun(c.Request.Context(), uint(hostID), uint(runID))
+	if err != nil {
+		response.Error(c, precheckHistoryErrorStatus(err), err.Error())
+		return
+	}
+	response.OK(c, run)
+}
+
+// DiffPrecheckRuns handles GET /api/v1/hosts/:id/precheck/diff - compares two precheck runs.
+// Without parameters the latest run is compared with the last passing run before it.
+// DiffPrecheckRuns 处理 GET /api/v1/hosts/:id/precheck/diff - 比较两次预检查记录。
+// 未指定参数时，将最新记录与其之前最近一次通过的记录比较。
+// @Tags installation
+// @Produce json
+// @Param id path int true "主机ID"
+// @Param base query int false "基准记录ID"
+// @Param target query int false "目标记录ID"
+// @Success 200 {object} PrecheckDiffResponse
+// @Router /api/v1/hosts/{id}/precheck/diff [get]
+func (h *Handler) DiffPrecheckRuns(c *gin.Context) {
+	hostID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "无效的主机 ID / Invalid host ID")
+		return
+	}
+	baseID, ok := parseOptionalRunID(c, "base")
+	if !ok {
+		return
+	}
+	targetID, ok := parseOptionalRunID(c, "target")
+	if !ok {
+		return
+	}
+
+	diff, err := h.service.DiffPrecheckRuns(c.Request.Context(), uint(hostID), baseID, targetID)
+	if err != nil {
+		response.Error(c, precheckHistoryErrorStatus(err), err.Error())
+		return
+	}
+	response.OK(c, diff)
+}
@@ -0,0 +1,160 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package installer
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func newPrecheckHistoryTestService(t *testing.T) *Service {
+	t.Helper()
+
+	database, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "test.db")), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	if err := database.AutoMigrate(&PrecheckRun{}); err != nil {
+		t.Fatalf("failed to migrate database: %v", err)
+	}
+
+	service := NewService(t.TempDir(), nil)
+	service.SetPrecheckRepository(NewPrecheckRepository(database))
+	return service
+}
+
+func TestRunPrecheckRecordsHistory(t *testing.T) {
+	service := newPrecheckHistoryTestService(t)
+	ctx := context.Background()
+
+	result, err := service.RunPrecheck(ctx, 7, &PrecheckRequest{InstallDir: "/opt/seatunnel", Version: "2.3.12"})
+	if err != nil {
+		t.Fatalf("RunPrecheck returned error: %v", err)
+	}
+	if result.RunID == 0 {
+		t.Fatalf("expected precheck result to reference its history record")
+	}
+
+	runs, err := service.ListPrecheckHistory(ctx, 7, 0)
+	if err != nil {
+		t.Fatalf("ListPrecheckHistory returned error: %v", err)
+	}
+	if len(runs) != 1 || runs[0].ID != result.RunID || runs[0].OverallStatus != CheckStatusFailed {
+		t.Fatalf("unexpected history: %+v", runs)
+	}
+	if runs[0].InstallDir != "/opt/seatunnel" || runs[0].Version != "2.3.12" || len(runs[0].Items) != 1 {
+		t.Fatalf("expected request context and items to be stored, got %+v", runs[0])
+	}
+	if _, err := service.GetPrecheckRun(ctx, 8, result.RunID); !errors.Is(err, ErrPrecheckRunNotFound) {
+		t.Fatalf("expected run of another host to be hidden, got %v", err)
+	}
+}
+
+func TestDiffPrecheckRunsComparesWithLastPassingRun(t *testing.T) {
+	service := newPrecheckHistoryTestService(t)
+	ctx := context.Background()
+	repo := service.precheckRepo
+
+	passing := &PrecheckRun{HostID: 1, OverallStatus: CheckStatusPassed, Items: PrecheckItems{
+		{Name: "agent", Status: CheckStatusPassed, Message: "ok"},
+		{Name: "memory", Status: CheckStatusPassed, Message: "ok", Details: map[string]interface{}{"available_mb": 8192, "required_mb": 4096}},
+		{Name: "disk", Status: CheckStatusPassed, Message: "ok"},
+	}}
+	warning := &PrecheckRun{HostID: 1, OverallStatus: CheckStatusWarning, Items: PrecheckItems{
+		{Name: "agent", Status: CheckStatusPassed, Message: "ok"},
+	}}
+	failing := &PrecheckRun{HostID: 1, OverallStatus: CheckStatusFailed, Items: PrecheckItems{
+		{Name: "agent", Status: CheckStatusPassed, Message: "ok"},
+		{Name: "memory", Status: CheckStatusFailed, Message: "insufficient", Details: map[string]interface{}{"available_mb": 2048, "required_mb": 4096}},
+		{Name: "java", Status: CheckStatusWarning, Message: "missing"},
+	}}
+	for _, run := range []*PrecheckRun{passing, warning, failing} {
+		if err := repo.Create(ctx, run); err != nil {
+			t.Fatalf("failed to create run: %v", err)
+		}
+	}
+
+	diff, err := service.DiffPrecheckRuns(ctx, 1, 0, 0)
+	if err != nil {
+		t.Fatalf("DiffPrecheckRuns returned error: %v", err)
+	}
+	if diff.Base == nil || diff.Base.ID != passing.ID || diff.Target.ID != failing.ID {
+		t.Fatalf("expected latest run compared with last passing run, got base=%+v target=%+v", diff.Base, diff.Target)
+	}
+	if len(diff.Changes) != 3 {
+		t.Fatalf("expected 3 changes, got %+v", diff.Changes)
+	}
+	memory := diff.Changes[0]
+	if memory.Name != "memory" || memory.Change != PrecheckChangeChanged || !memory.Regressed {
+		t.Fatalf("expected memory regression, got %+v", memory)
+	}
+	if len(memory.ChangedDetails) != 1 || memory.ChangedDetails[0] != "available_mb" {
+		t.Fatalf("expected only available_mb to change, got %v", memory.ChangedDetails)
+	}
+	if java := diff.Changes[1]; java.Name != "java" || java.Change != PrecheckChangeAdded || !java.Regressed {
+		t.Fatalf("expected added java warning, got %+v", java)
+	}
+	if disk := diff.Changes[2]; disk.Name != "disk" || disk.Change != PrecheckChangeRemoved {
+		t.Fatalf("expected removed disk check, got %+v", disk)
+	}
+
+	explicit, err := service.DiffPrecheckRuns(ctx, 1, warning.ID, failing.ID)
+	if err != nil {
+		t.Fatalf("DiffPrecheckRuns with explicit runs returned error: %v", err)
+	}
+	if explicit.Base.ID != warning.ID || len(explicit.Changes) != 2 {
+		t.Fatalf("expected explicit base to be used, got %+v", explicit)
+	}
+
+	if _, err := service.DiffPrecheckRuns(ctx, 2, 0, 0); !errors.Is(err, ErrPrecheckRunNotFound) {
+		t.Fatalf("expected not found for host without history, got %v", err)
+	}
+}
+
+func TestPrecheckRepositoryKeepsRetention(t *testing.T) {
+	service := newPrecheckHistoryTestService(t)
+	ctx := context.Background()
+
+	for i := 0; i < precheckHistoryRetention+5; i++ {
+		if err := service.precheckRepo.Create(ctx, &PrecheckRun{HostID: 3, OverallStatus: CheckStatusPassed}); err != nil {
+			t.Fatalf("failed to create run: %v", err)
+		}
+	}
+	if err := service.precheckRepo.Create(ctx, &PrecheckRun{HostID: 4, OverallStatus: CheckStatusPassed}); err != nil {
+		t.Fatalf("failed to create run: %v", err)
+	}
+
+	runs, err := service.precheckRepo.ListByHost(ctx, 3, 1000)
+	if err != nil {
+		t.Fatalf("ListByHost returned error: %v", err)
+	}
+	if len(runs) != precheckHistoryRetention {
+		t.Fatalf("expected %d retained runs, got %d", precheckHistoryRetention, len(runs))
+	}
+	if other, _ := service.precheckRepo.ListByHost(ctx, 4, 10); len(other) != 1 {
+		t.Fatalf("expected other hosts to be untouched, got %d runs", len(other))
+	}
+}
@@ -0,0 +1,111 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package installer
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/seatunnel/seatunnelX/internal/logger"
+	"github.com/seatunnel/seatunnelX/internal/pkg/chunkupload"
+)
+
+// resumableUploadDirName is the directory under tempDir holding partial package uploads.
+// resumableUploadDirName 是 tempDir 下保存未完成安装包上传的目录。
+const resumableUploadDirName = "resumable-package-uploads"
+
+// uploadMetadataVersion is the session metadata key holding the package version.
+// uploadMetadataVersion 是会话元数据中保存安装包版本的键。
+const uploadMetadataVersion = "version"
+
+// InitPackageUploadRequest starts a resumable package upload.
+// InitPackageUploadRequest 用于开始一个可续传的安装包上传。
+type InitPackageUploadRequest struct {
+	Version   string `json:"version" binding:"required"`
+	FileName  string `json:"file_name" binding:"required"`
+	TotalSize int64  `json:"total_size" binding:"required"`
+	// SHA256 of the whole file, verified on completion when set / 整个文件的 SHA256，设置后在完成时校验
+	SHA256 string `json:"sha256"`
+}
+
+// InitPackageUpload validates the package and starts a resumable upload for it.
+// InitPackageUpload 校验安装包信息并为其开始一个可续传上传。
+func (s *Service) InitPackageUpload(ctx context.Context, req *InitPackageUploadRequest) (*chunkupload.Session, error) {
+	version := strings.TrimSpace(req.Version)
+	fileName := strings.TrimSpace(req.FileName)
+	if err := validatePackageUploadInput(version, fileName, req.TotalSize); err != nil {
+		return nil, err
+	}
+	destPath, err := normalizePathInDir(s.packageDir, filepath.Join(s.packageDir, packageFileName(version)))
+	if err != nil {
+		return nil, ErrInvalidPackagePath
+	}
+	if _, err := os.Stat(destPath); err == nil {
+		return nil, ErrPackageAlreadyExists
+	}
+
+	session, err := s.uploads.Init(chunkupload.InitRequest{
+		FileName:  fileName,
+		TotalSize: req.TotalSize,
+		SHA256:    req.SHA256,
+		Metadata:  map[string]string{uploadMetadataVersion: version},
+	})
+	if err != nil {
+		return nil, err
+	}
+	logger.InfoF(ctx, "[Installer] 开始可续传上传 / Resumable package upload started: id=%s, version=%s, size=%d",
+		session.ID, version, session.TotalSize)
+	return session, nil
+}
+
+// GetPackageUpload returns a resumable package upload, including the offset to resume from.
+// GetPackageUpload 返回可续传的安装包上传，包括续传的起始偏移量。
+func (s *Service) GetPackageUpload(id string) (*chunkupload.Session, error) {
+	return s.uploads.Get(id)
+}
+
+// AppendPackageUpload writes one chunk of a resumable package upload at offset.
+// AppendPackageUpload 在 offset 处写入可续传安装包上传的一个分片。
+func (s *Service) AppendPackageUpload(id string, offset int64, checksum string, src io.Reader) (*chunkupload.Session, error) {
+	return s.uploads.Append(id, offset, checksum, src)
+}
+
+// CompletePackageUpload verifies and stores a fully uploaded package.
+// CompletePackageUpload 校验并保存已完整上传的安装包。
+func (s *Service) CompletePackageUpload(ctx context.Context, id string) (*PackageInfo, error) {
+	var info *PackageInfo
+	_, err := s.uploads.Complete(id, func(session *chunkupload.Session, path string) error {
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		info, err = s.savePackageFromReader(ctx, session.Metadata[uploadMetadataVersion], session.FileName, session.TotalSize, file)
+		return err
+	})
+	return info, err
+}
+
+// AbortPackageUpload discards a resumable package upload.
+// AbortPackageUpload 放弃一个可续传的安装包上传。
+func (s *Service) AbortPackageUpload(id string) error {
+	return s.uploads.Abort(id)
+}
@@ -0,0 +1,153 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package installer
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/seatunnel/seatunnelX/internal/apps/response"
+	"github.com/seatunnel/seatunnelX/internal/logger"
+	"github.com/seatunnel/seatunnelX/internal/pkg/chunkupload"
+)
+
+// PackageUploadSessionResponse is the response carrying the state of a resumable upload.
+// PackageUploadSessionResponse 是携带可续传上传状态的响应。
+type PackageUploadSessionResponse struct {
+	response.Meta
+	Data *chunkupload.Session `json:"data"`
+}
+
+// InitPackageUpload handles POST /api/v1/packages/uploads - starts a resumable package upload.
+// InitPackageUpload 处理 POST /api/v1/packages/uploads - 开始可续传的安装包上传。
+// @Tags packages
+// @Accept json
+// @Produce json
+// @Param request body InitPackageUploadRequest true "上传信息 / Upload info"
+// @Success 200 {object} PackageUploadSessionResponse
+// @Router /api/v1/packages/uploads [post]
+func (h *Handler) InitPackageUpload(c *gin.Context) {
+	var req InitPackageUploadRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	session, err := h.service.InitPackageUpload(c.Request.Context(), &req)
+	if err != nil {
+		respondPackageUploadError(c, err, nil)
+		return
+	}
+	response.OK(c, session)
+}
+
+// GetPackageUpload handles GET /api/v1/packages/uploads/:uploadId - returns the offset to resume from.
+// GetPackageUpload 处理 GET /api/v1/packages/uploads/:uploadId - 返回续传的起始偏移量。
+// @Tags packages
+// @Produce json
+// @Param uploadId path string true "上传会话 ID"
+// @Success 200 {object} PackageUploadSessionResponse
+// @Router /api/v1/packages/uploads/{uploadId} [get]
+func (h *Handler) GetPackageUpload(c *gin.Context) {
+	session, err := h.service.GetPackageUpload(c.Param("uploadId"))
+	if err != nil {
+		respondPackageUploadError(c, err, nil)
+		return
+	}
+	response.OK(c, session)
+}
+
+// AppendPackageUpload handles PATCH /api/v1/packages/uploads/:uploadId - appends the raw request body at offset.
+// A mismatching offset answers 409 with the current upload so the client can resume from its offset.
+// AppendPackageUpload 处理 PATCH /api/v1/packages/uploads/:uploadId - 在 offset 处追加原始请求体。
+// 偏移量不一致时返回 409 并携带当前上传状态，便于客户端从其偏移量续传。
+// @Tags packages
+// @Accept octet-stream
+// @Produce json
+// @Param uploadId path string true "上传会话 ID"
+// @Param offset query int true "分片起始偏移量 / Chunk offset"
+// @Param sha256 query string false "分片 SHA256 / Chunk SHA256"
+// @Success 200 {object} PackageUploadSessionResponse
+// @Failure 409 {object} PackageUploadSessionResponse
+// @Router /api/v1/packages/uploads/{uploadId} [patch]
+func (h *Handler) AppendPackageUpload(c *gin.Context) {
+	offset, err := strconv.ParseInt(c.Query("offset"), 10, 64)
+	if err != nil || offset < 0 {
+		response.Error(c, http.StatusBadRequest, "offset 必须为非负整数 / offset must be a non-negative integer")
+		return
+	}
+	session, err := h.service.AppendPackageUpload(c.Param("uploadId"), offset, c.Query("sha256"), c.Request.Body)
+	if err != nil {
+		respondPackageUploadError(c, err, session)
+		return
+	}
+	response.OK(c, session)
+}
+
+// CompletePackageUpload handles POST /api/v1/packages/uploads/:uploadId/complete - stores the uploaded package.
+// CompletePackageUpload 处理 POST /api/v1/packages/uploads/:uploadId/complete - 保存已上传的安装包。
+// @Tags packages
+// @Produce json
+// @Param uploadId path string true "上传会话 ID"
+// @Success 200 {object} UploadPackageResponse
+// @Router /api/v1/packages/uploads/{uploadId}/complete [post]
+func (h *Handler) CompletePackageUpload(c *gin.Context) {
+	info, err := h.service.CompletePackageUpload(c.Request.Context(), c.Param("uploadId"))
+	if err != nil {
+		respondPackageUploadError(c, err, nil)
+		return
+	}
+	logger.InfoF(c.Request.Context(), "[Installer] 上传安装包成功: %s", info.Version)
+	response.OK(c, info)
+}
+
+// AbortPackageUpload handles DELETE /api/v1/packages/uploads/:uploadId - discards a resumable upload.
+// AbortPackageUpload 处理 DELETE /api/v1/packages/uploads/:uploadId - 放弃可续传上传。
+// @Tags packages
+// @Produce json
+// @Param uploadId path string true "上传会话 ID"
+// @Success 200 {object} DeletePackageResponse
+// @Router /api/v1/packages/uploads/{uploadId} [delete]
+func (h *Handler) AbortPackageUpload(c *gin.Context) {
+	if err := h.service.AbortPackageUpload(c.Param("uploadId")); err != nil {
+		respondPackageUploadError(c, err, nil)
+		return
+	}
+	response.OK(c, nil)
+}
+
+func respondPackageUploadError(c *gin.Context, err error, session *chunkupload.Session) {
+	status := chunkupload.StatusCode(err)
+	switch {
+	case status != 0:
+	case errors.Is(err, ErrInvalidPackageVersion),
+		errors.Is(err, ErrInvalidPackageFile),
+		errors.Is(err, ErrInvalidPackageLayout),
+		errors.Is(err, ErrPackageVersionMismatch),
+		errors.Is(err, ErrInvalidPackagePath):
+		status = http.StatusBadRequest
+	case errors.Is(err, ErrPackageAlreadyExists):
+		status = http.StatusConflict
+	case errors.Is(err, ErrPackageTooLarge):
+		status = http.StatusRequestEntityTooLarge
+	default:
+		status = http.StatusInternalServerError
+	}
+	response.ErrorWithData(c, status, err.Error(), session)
+}
@@ -0,0 +1,81 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package installer
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/seatunnel/seatunnelX/internal/pkg/chunkupload"
+)
+
+func TestService_ResumablePackageUpload(t *testing.T) {
+	service := NewService(t.TempDir(), nil)
+	service.uploads = chunkupload.NewStore(t.TempDir(), 0)
+	ctx := context.Background()
+
+	content := buildSeaTunnelTarGz(t, "apache-seatunnel-2.3.12", nil)
+	sum := sha256.Sum256(content)
+	session, err := service.InitPackageUpload(ctx, &InitPackageUploadRequest{
+		Version:   "2.3.12",
+		FileName:  "apache-seatunnel-2.3.12-bin.tar.gz",
+		TotalSize: int64(len(content)),
+		SHA256:    hex.EncodeToString(sum[:]),
+	})
+	if err != nil {
+		t.Fatalf("InitPackageUpload failed: %v", err)
+	}
+
+	half := int64(len(content) / 2)
+	if _, err := service.AppendPackageUpload(session.ID, 0, "", bytes.NewReader(content[:half])); err != nil {
+		t.Fatalf("first chunk failed: %v", err)
+	}
+	// Resending the first chunk after a lost response reports where to resume.
+	current, err := service.AppendPackageUpload(session.ID, 0, "", bytes.NewReader(content[:half]))
+	if !errors.Is(err, chunkupload.ErrOffsetMismatch) || current.Offset != half {
+		t.Fatalf("expected offset mismatch at %d, got %+v err=%v", half, current, err)
+	}
+	if _, err := service.CompletePackageUpload(ctx, session.ID); !errors.Is(err, chunkupload.ErrUploadIncomplete) {
+		t.Fatalf("expected ErrUploadIncomplete, got %v", err)
+	}
+	if _, err := service.AppendPackageUpload(session.ID, current.Offset, "", bytes.NewReader(content[half:])); err != nil {
+		t.Fatalf("second chunk failed: %v", err)
+	}
+
+	info, err := service.CompletePackageUpload(ctx, session.ID)
+	if err != nil {
+		t.Fatalf("CompletePackageUpload failed: %v", err)
+	}
+	persisted, err := os.ReadFile(info.LocalPath)
+	if err != nil || !bytes.Equal(persisted, content) {
+		t.Fatalf("persisted package content mismatch, err=%v", err)
+	}
+
+	if _, err := service.InitPackageUpload(ctx, &InitPackageUploadRequest{
+		Version:   "2.3.12",
+		FileName:  "apache-seatunnel-2.3.12-bin.tar.gz",
+		TotalSize: int64(len(content)),
+	}); !errors.Is(err, ErrPackageAlreadyExists) {
+		t.Fatalf("expected ErrPackageAlreadyExists, got %v", err)
+	}
+}
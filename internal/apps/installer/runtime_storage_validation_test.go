@@ -0,0 +1,135 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package installer
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+type runtimeStorageHostProvider struct{}
+
+func (runtimeStorageHostProvider) GetHostByID(ctx context.Context, hostID uint) (*HostInfo, error) {
+	now := time.Now()
+	return &HostInfo{ID: hostID, Name: "node-1", AgentID: "agent-1", LastSeen: &now}, nil
+}
+
+// checkpointValidationAgentManager answers validate_checkpoint_storage, or rejects it like an old Agent.
+// checkpointValidationAgentManager 响应 validate_checkpoint_storage，或像旧版 Agent 一样拒绝该命令。
+type checkpointValidationAgentManager struct {
+	dryRunAgentManager
+	legacy   bool
+	commands []string
+}
+
+func (m *checkpointValidationAgentManager) SendCommand(ctx context.Context, agentID string, commandType string, params map[string]string) (bool, string, error) {
+	m.commands = append(m.commands, commandType)
+	m.params = params
+	switch commandType {
+	case "validate_checkpoint_storage":
+		if m.legacy {
+			return false, "unknown precheck sub-command: validate_checkpoint_storage", nil
+		}
+		return true, `{"success":true,"message":"checkpoint storage S3 is writable, readable and deletable","details":{"write":"true","read":"true","delete":"true"}}`, nil
+	case "check_tcp":
+		return true, `{"success":true,"message":"endpoint reachable"}`, nil
+	}
+	return false, "", nil
+}
+
+func s3CheckpointConfig() *CheckpointConfig {
+	return &CheckpointConfig{
+		StorageType:      CheckpointStorageS3,
+		Namespace:        "/seatunnel/checkpoint/",
+		StorageEndpoint:  "https://s3.eu-west-1.amazonaws.com",
+		StorageAccessKey: "AKID",
+		StorageSecretKey: "secret",
+		StorageBucket:    "s3a://bucket",
+	}
+}
+
+func TestService_ValidateRuntimeStorage_CheckpointRoundTrip(t *testing.T) {
+	agentManager := &checkpointValidationAgentManager{}
+	service := NewService(t.TempDir(), agentManager)
+	service.hostProvider = runtimeStorageHostProvider{}
+
+	result, err := service.ValidateRuntimeStorage(context.Background(), &RuntimeStorageValidationRequest{
+		HostIDs:    []uint{1},
+		Kind:       RuntimeStorageValidationCheckpoint,
+		Checkpoint: s3CheckpointConfig(),
+	})
+	if err != nil {
+		t.Fatalf("ValidateRuntimeStorage returned error: %v", err)
+	}
+	if !result.Success || result.Warning != "" {
+		t.Fatalf("expected a verified round trip without warning, got %+v", result)
+	}
+	if agentManager.params["storage_bucket"] != "s3a://bucket" || agentManager.params["sub_command"] != "validate_checkpoint_storage" {
+		t.Fatalf("expected checkpoint config to be forwarded, got %v", agentManager.params)
+	}
+	if result.Hosts[0].Details["delete"] != "true" {
+		t.Fatalf("expected step details, got %v", result.Hosts[0].Details)
+	}
+}
+
+func TestService_ValidateRuntimeStorage_LegacyAgentFallsBackToReachability(t *testing.T) {
+	agentManager := &checkpointValidationAgentManager{legacy: true}
+	service := NewService(t.TempDir(), agentManager)
+	service.hostProvider = runtimeStorageHostProvider{}
+
+	result, err := service.ValidateRuntimeStorage(context.Background(), &RuntimeStorageValidationRequest{
+		HostIDs:    []uint{1},
+		Kind:       RuntimeStorageValidationCheckpoint,
+		Checkpoint: s3CheckpointConfig(),
+	})
+	if err != nil {
+		t.Fatalf("ValidateRuntimeStorage returned error: %v", err)
+	}
+	if !result.Success || result.Warning == "" || result.Hosts[0].Details["mode"] != "reachability" {
+		t.Fatalf("expected reachability fallback with warning, got %+v", result)
+	}
+	if len(agentManager.commands) != 2 || agentManager.commands[1] != "check_tcp" {
+		t.Fatalf("expected fallback to check_tcp, got %v", agentManager.commands)
+	}
+}
+
+func TestService_CheckCheckpointStorage_FailedRoundTrip(t *testing.T) {
+	agentManager := &dryRunAgentManager{output: `{"success":false,"message":"checkpoint storage S3 failed at write: 403 Forbidden"}`}
+	service := NewService(t.TempDir(), &failingCheckpointAgentManager{agentManager})
+
+	item := service.checkCheckpointStorage(context.Background(), "agent-1", "/opt/seatunnel", &PrecheckRequest{
+		Version:    "2.3.12",
+		Checkpoint: s3CheckpointConfig(),
+	})
+	if item.Status != CheckStatusFailed || item.Suggestion == "" {
+		t.Fatalf("expected failed checkpoint item with suggestion, got %+v", item)
+	}
+	if agentManager.params["install_dir"] != "/opt/seatunnel" {
+		t.Fatalf("expected install_dir to be forwarded for the HDFS fallback, got %v", agentManager.params)
+	}
+}
+
+type failingCheckpointAgentManager struct {
+	*dryRunAgentManager
+}
+
+func (m *failingCheckpointAgentManager) SendCommand(ctx context.Context, agentID string, commandType string, params map[string]string) (bool, string, error) {
+	_, output, err := m.dryRunAgentManager.SendCommand(ctx, agentID, commandType, params)
+	return false, output, err
+}
@@ -20,6 +20,7 @@ package installer
 import (
 	"context"
 	"crypto/sha256"
+	"crypto/sha512"
 	"encoding/hex"
 	"encoding/json"
 	"errors"
@@ -39,9 +40,20 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/seatunnel/seatunnelX/internal/apps/task"
 	"github.com/seatunnel/seatunnelX/internal/config"
 	"github.com/seatunnel/seatunnelX/internal/logger"
+	"github.com/seatunnel/seatunnelX/internal/otel_trace"
+	"github.com/seatunnel/seatunnelX/internal/pkg/chunkupload"
+	"github.com/seatunnel/seatunnelX/internal/pkg/downloadx"
+	"github.com/seatunnel/seatunnelX/internal/pkg/eventbus"
+	"github.com/seatunnel/seatunnelX/internal/pkg/filestream"
+	"github.com/seatunnel/seatunnelX/internal/pkg/installhook"
+	"github.com/seatunnel/seatunnelX/internal/pkg/objstore"
+	"github.com/seatunnel/seatunnelX/internal/pkg/tracex"
 	"github.com/seatunnel/seatunnelX/internal/seatunnel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
 )
 
 // Common errors / 常见错误
@@ -59,6 +71,8 @@ var (
 	ErrInstallationInProgress = errors.New("installation already in progress / 安装任务正在进行中")
 	ErrHostNotConnected       = errors.New("host agent not connected / 主机 Agent 未连接")
 	ErrAgentNotFound          = errors.New("agent not found / Agent 未找到")
+	ErrHostUnderMaintenance   = errors.New("host is under maintenance / 主机处于维护模式")
+	ErrNoHostsToAdvise        = errors.New("at least one host is required / 至少需要选择一台主机")
 )
 
 var packageVersionRegexp = regexp.MustCompile(`^[0-9A-Za-z._+-]{1,64}$`)
@@ -86,6 +100,18 @@ type AgentManager interface {
 	// SendTransferPackageCommand sends a package transfer chunk to an agent
 	// SendTransferPackageCommand 向 Agent 发送安装包传输块
 	SendTransferPackageCommand(ctx context.Context, agentID string, version string, fileName string, chunk []byte, offset int64, totalSize int64, isLast bool, checksum string) (success bool, receivedBytes int64, localPath string, err error)
+
+	// CancelCommand cancels a pending or running command
+	// CancelCommand 取消待执行或执行中的命令
+	CancelCommand(ctx context.Context, commandID string) error
+}
+
+// PackageStreamer is optionally implemented by an AgentManager that can stream packages through FetchFile.
+// It returns filestream.ErrUnsupported when the Agent only accepts chunked transfer commands.
+// PackageStreamer 由可通过 FetchFile 流式传输安装包的 AgentManager 选择性实现。
+// 当 Agent 只接受分块传输命令时返回 filestream.ErrUnsupported。
+type PackageStreamer interface {
+	StreamPackageToAgent(ctx context.Context, agentID, version, fileName, localPath, checksum string, totalSize int64, onProgress func(filestream.Snapshot)) (remotePath string, err error)
 }
 
 // PluginTransferer is the interface for transferring plugins to agents
@@ -167,6 +193,13 @@ type HostInfo struct {
 	AgentID     string     `json:"agent_id"`
 	AgentStatus string     `json:"agent_status"`
 	LastSeen    *time.Time `json:"last_seen"`
+	Maintenance bool       `json:"maintenance,omitempty"`
+	// Resources reported by the Agent heartbeat; zero when the Agent has not reported yet.
+	// Agent 心跳上报的资源；Agent 尚未上报时为零值。
+	CPUCores    int     `json:"cpu_cores,omitempty"`
+	TotalMemory int64   `json:"total_memory,omitempty"` // bytes / 字节
+	TotalDisk   int64   `json:"total_disk,omitempty"`   // bytes / 字节
+	DiskUsage   float64 `json:"disk_usage,omitempty"`   // percent / 百分比
 }
 
 // IsOnline checks if the host agent is online within the timeout
@@ -253,6 +286,30 @@ type Service struct {
 	// configInitializer 用于安装完成后初始化集群配置
 	configInitializer ConfigInitializer
 
+	// downloadClient downloads packages through the configured proxy
+	// downloadClient 通过配置的代理下载安装包
+	downloadClient *http.Client
+
+	// mirrorManager probes mirrors and orders download attempts
+	// mirrorManager 探测镜像并决定下载尝试顺序
+	mirrorManager *MirrorManager
+
+	// templateRepo stores named installation templates
+	// templateRepo 存储命名安装模板
+	templateRepo *TemplateRepository
+
+	// precheckRepo stores precheck run history
+	// precheckRepo 存储预检查历史记录
+	precheckRepo *PrecheckRepository
+
+	// versionPolicyRepo stores the admin-managed version policy
+	// versionPolicyRepo 存储管理员维护的版本策略
+	versionPolicyRepo *VersionPolicyRepository
+
+	// taskManager records installations as tasks (optional)
+	// taskManager 将安装记录为任务（可选）
+	taskManager *task.Manager
+
 	// heartbeatTimeout is the timeout for agent heartbeat
 	// heartbeatTimeout 是 Agent 心跳超时时间
 	heartbeatTimeout time.Duration
@@ -261,6 +318,10 @@ type Service struct {
 	// chunkUploadMu 保护分片上传状态文件
 	chunkUploadMu sync.Mutex
 
+	// uploads keeps resumable package uploads under tempDir
+	// uploads 在 tempDir 下保存可续传的安装包上传
+	uploads *chunkupload.Store
+
 	// preparedAssetMu protects prepared package/plugin caches for Agent reuse.
 	// preparedAssetMu 保护用于复用 Agent 已准备安装包/插件的缓存。
 	preparedAssetMu sync.Mutex
@@ -270,6 +331,38 @@ type Service struct {
 	// preparedPlugins stores plugin bundles already transferred and installed on an Agent.
 	// preparedPlugins 保存已传输并安装到 Agent 的插件包标记。
 	preparedPlugins map[string]time.Time
+
+	// taskStateRepo shares installation and download status with other replicas (optional)
+	// taskStateRepo 与其他副本共享安装与下载状态（可选）
+	taskStateRepo     *TaskStateRepository
+	taskStateInterval time.Duration
+	// taskStateMu protects syncedTaskStates, the payloads last written to taskStateRepo
+	// taskStateMu 保护 syncedTaskStates，即最近写入 taskStateRepo 的内容
+	taskStateMu      sync.Mutex
+	syncedTaskStates map[string]string
+
+	// packageStore shares packages between Control Plane replicas (optional)
+	// packageStore 在控制面副本之间共享安装包（可选）
+	packageStore objstore.Store
+	// presignExpiry is the validity of presigned package URLs handed to Agents, zero disables them
+	// presignExpiry 是交给 Agent 的安装包预签名地址有效期，为 0 时不启用
+	presignExpiry time.Duration
+	// presignByDefault hands presigned URLs to Agents even when direct delivery is not requested
+	// presignByDefault 即使未请求直接下载也向 Agent 下发预签名地址
+	presignByDefault bool
+	// mirroringPackages holds the local package paths being mirrored into packageStore in the background
+	// mirroringPackages 记录正在后台同步到 packageStore 的本地安装包路径
+	mirroringPackages sync.Map
+
+	// installQueue and transferQueue bound concurrent installations and package transfers on this replica;
+	// slotLeaser enforces the global limits across replicas (optional).
+	// installQueue 与 transferQueue 限制本副本的并发安装与安装包传输；slotLeaser 在副本之间执行全局上限（可选）。
+	concurrencyMu     sync.RWMutex
+	concurrencyLimits ConcurrencyLimits
+	installQueue      *admissionQueue
+	transferQueue     *admissionQueue
+	slotLeaser        SlotLeaser
+	instanceID        string
 }
 
 type preparedPackageCacheEntry struct {
@@ -330,15 +423,23 @@ func NewService(packageDir string, agentManager AgentManager) *Service {
 		// Log error but continue / 记录错误但继续
 	}
 
+	limits := concurrencyLimitsFromConfig(config.GetInstallerConfig().Concurrency)
 	return &Service{
-		packageDir:       packageDir,
-		tempDir:          config.GetTempDir(),
-		installations:    make(map[string]*InstallationStatus),
-		downloads:        make(map[string]*DownloadTask),
-		agentManager:     agentManager,
-		heartbeatTimeout: 2 * time.Minute, // Default 2 minutes / 默认 2 分钟
-		preparedPackages: make(map[string]preparedPackageCacheEntry),
-		preparedPlugins:  make(map[string]time.Time),
+		packageDir:        packageDir,
+		tempDir:           config.GetTempDir(),
+		uploads:           chunkupload.NewStore(filepath.Join(config.GetTempDir(), resumableUploadDirName), chunkupload.DefaultTTL),
+		installations:     make(map[string]*InstallationStatus),
+		downloads:         make(map[string]*DownloadTask),
+		agentManager:      agentManager,
+		downloadClient:    downloadx.NewClient(0),
+		mirrorManager:     NewMirrorManager(),
+		heartbeatTimeout:  2 * time.Minute, // Default 2 minutes / 默认 2 分钟
+		preparedPackages:  make(map[string]preparedPackageCacheEntry),
+		preparedPlugins:   make(map[string]time.Time),
+		syncedTaskStates:  make(map[string]string),
+		concurrencyLimits: limits,
+		installQueue:      newAdmissionQueue(limits.MaxInstallations),
+		transferQueue:     newAdmissionQueue(limits.MaxPackageTransfers),
 	}
 }
 
@@ -390,6 +491,12 @@ func (s *Service) SetConfigInitializer(initializer ConfigInitializer) {
 	s.configInitializer = initializer
 }
 
+// SetTemplateRepository sets the repository for installation templates.
+// SetTemplateRepository 设置安装模板仓库。
+func (s *Service) SetTemplateRepository(repo *TemplateRepository) {
+	s.templateRepo = repo
+}
+
 // ==================== Version Management 版本管理 ====================
 
 // getVersions returns the version list, using cache if valid, otherwise fetching from Apache Archive.
@@ -424,7 +531,7 @@ func (s *Service) getVersions(ctx context.Context) []string {
 // fetchVersionsFromApache 从 Apache Archive 获取版本列表。
 func (s *Service) fetchVersionsFromApache(ctx context.Context) ([]string, error) {
 	// Create HTTP request with timeout / 创建带超时的 HTTP 请求
-	client := &http.Client{Timeout: 10 * time.Second}
+	client := downloadx.NewClient(10 * time.Second)
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ApacheArchiveURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
@@ -534,10 +641,11 @@ func (s *Service) ListAvailableVersions(ctx context.Context) (*AvailableVersions
 	// Scan local packages / 扫描本地安装包
 	entries, err := os.ReadDir(s.packageDir)
 	if err != nil {
-		// Directory might not exist, return empty list / 目录可能不存在，返回空列表
-		return result, nil
+		// Directory might not exist / 目录可能不存在
+		entries = nil
 	}
 
+	localNames := make(map[string]struct{}, len(entries))
 	for _, entry := range entries {
 		if entry.IsDir() {
 			continue
@@ -554,15 +662,20 @@ func (s *Service) ListAvailableVersions(ctx context.Context) (*AvailableVersions
 			continue
 		}
 
+		localNames[name] = struct{}{}
 		version := extractVersionFromFileName(name)
 		uploadedAt := info.ModTime()
+		localPath := filepath.Join(s.packageDir, name)
+		sha512Sum, verified := readPackageSHA512(localPath)
 
 		result.LocalPackages = append(result.LocalPackages, PackageInfo{
 			Version:      version,
 			FileName:     name,
 			FileSize:     info.Size(),
+			SHA512:       sha512Sum,
+			Verified:     verified,
 			IsLocal:      true,
-			LocalPath:    filepath.Join(s.packageDir, name),
+			LocalPath:    localPath,
 			UploadedAt:   &uploadedAt,
 			DownloadURLs: getDownloadURLs(version),
 		})
@@ -571,6 +684,15 @@ func (s *Service) ListAvailableVersions(ctx context.Context) (*AvailableVersions
 		}
 	}
 
+	// Packages another replica stored in the shared store / 其他副本保存到共享存储的安装包
+	for _, stored := range s.listStoredPackages(ctx, localNames) {
+		result.LocalPackages = append(result.LocalPackages, stored)
+		if stored.Version != "" {
+			result.VersionCapabilities[stored.Version] = seatunnel.CapabilitiesForVersion(stored.Version)
+		}
+	}
+
+	s.applyVersionPolicy(ctx, result)
 	return result, nil
 }
 
@@ -587,6 +709,7 @@ func (s *Service) GetPackageInfo(ctx context.Context, version string) (*PackageI
 		DownloadURLs: getDownloadURLs(version),
 	}
 
+	s.hydratePackageFromStore(ctx, localPath)
 	if fileInfo, err := os.Stat(localPath); err == nil {
 		info.IsLocal = true
 		info.LocalPath = localPath
@@ -594,11 +717,12 @@ func (s *Service) GetPackageInfo(ctx context.Context, version string) (*PackageI
 		uploadedAt := fileInfo.ModTime()
 		info.UploadedAt = &uploadedAt
 
-		// Calculate checksum / 计算校验和
-		checksum, err := calculateChecksum(localPath)
+		// Checksum cached next to the package / 读取安装包旁缓存的校验和
+		checksum, err := packageChecksum(localPath)
 		if err == nil {
 			info.Checksum = checksum
 		}
+		info.SHA512, info.Verified = readPackageSHA512(localPath)
 	}
 
 	return info, nil
@@ -767,7 +891,8 @@ func validatePackageUploadInput(version, fileName string, fileSize int64) error
 	if maxPackageSize > 0 && fileSize > maxPackageSize {
 		return ErrPackageTooLarge
 	}
-	if !strings.HasSuffix(strings.ToLower(strings.TrimSpace(fileName)), ".tar.gz") {
+	lowerName := strings.ToLower(strings.TrimSpace(fileName))
+	if !strings.HasSuffix(lowerName, ".tar.gz") && !strings.HasSuffix(lowerName, ".zip") {
 		return ErrInvalidPackageFile
 	}
 	return nil
@@ -810,18 +935,37 @@ func (s *Service) savePackageFromReader(ctx context.Context, version, fileName s
 		return nil, fmt.Errorf("failed to close package file: %w", err)
 	}
 
-	if err := os.Rename(tempPath, destPath); err != nil {
+	// Inspect the content instead of trusting the file name; zip uploads are stored as tar.gz
+	// 检查内容而非信任文件名；zip 上传会转换为 tar.gz 存储
+	packagePath, err := s.normalizeUploadedPackage(tempPath)
+	if err != nil {
+		return nil, err
+	}
+	if packagePath != tempPath {
+		defer os.Remove(packagePath)
+	}
+	if err := inspectPackageArchive(packagePath, version); err != nil {
+		return nil, err
+	}
+
+	if err := os.Rename(packagePath, destPath); err != nil {
 		return nil, fmt.Errorf("failed to move package file: %w", err)
 	}
+	// Uploaded packages are verified on first transfer / 上传的安装包在首次传输时校验
+	_ = os.Remove(packageSHA512Path(destPath))
 
 	fileInfo, err := os.Stat(destPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get package file info: %w", err)
 	}
 
-	checksum, _ := calculateChecksum(destPath)
+	checksum, err := packageChecksum(destPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate package checksum: %w", err)
+	}
 	uploadedAt := fileInfo.ModTime()
 	logger.InfoF(ctx, "[Installer] package saved: version=%s size=%d path=%s", version, fileInfo.Size(), destPath)
+	s.mirrorPackageToStore(ctx, destPath)
 	return &PackageInfo{
 		Version:      version,
 		FileName:     finalFileName,
@@ -834,6 +978,27 @@ func (s *Service) savePackageFromReader(ctx context.Context, version, fileName s
 	}, nil
 }
 
+// normalizeUploadedPackage returns the path of the upload as tar.gz, converting zip archives next to it.
+// normalizeUploadedPackage 返回 tar.gz 格式的上传文件路径，zip 归档会在旁边转换。
+func (s *Service) normalizeUploadedPackage(uploadPath string) (string, error) {
+	format, err := packageArchiveFormat(uploadPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read package file: %w", err)
+	}
+	switch format {
+	case "tar.gz":
+		return uploadPath, nil
+	case "zip":
+		convertedPath := uploadPath + ".tar.gz"
+		if err := convertZipPackage(uploadPath, convertedPath); err != nil {
+			_ = os.Remove(convertedPath)
+			return "", err
+		}
+		return convertedPath, nil
+	}
+	return "", fmt.Errorf("%w: not a tar.gz or zip archive", ErrInvalidPackageFile)
+}
+
 func (s *Service) getChunkUploadDir(uploadID string) (string, error) {
 	baseDir := filepath.Join(s.tempDir, "package-uploads")
 	if err := os.MkdirAll(baseDir, 0755); err != nil {
@@ -874,11 +1039,26 @@ func (s *Service) DeletePackage(ctx context.Context, version string) error {
 	fileName := packageFileName(version)
 	localPath := filepath.Join(s.packageDir, fileName)
 
+	stored, err := s.deleteStoredPackage(ctx, fileName)
+	if err != nil {
+		return err
+	}
 	if _, err := os.Stat(localPath); os.IsNotExist(err) {
+		if stored {
+			return nil
+		}
 		return ErrPackageNotFound
 	}
 
-	return os.Remove(localPath)
+	if err := os.Remove(localPath); err != nil {
+		return err
+	}
+	for _, sidecar := range []string{packageSHA512Path(localPath), packageSHA256Path(localPath)} {
+		if err := os.Remove(sidecar); err != nil && !os.IsNotExist(err) {
+			logger.WarnF(ctx, "[Installer] 删除校验和文件失败 / Failed to remove package checksum: %v", err)
+		}
+	}
+	return nil
 }
 
 // ==================== Package Download 安装包下载 ====================
@@ -891,6 +1071,10 @@ var ErrDownloadInProgress = errors.New("download already in progress / 下载已
 // ErrDownloadNotFound 表示下载任务未找到
 var ErrDownloadNotFound = errors.New("download task not found / 下载任务未找到")
 
+// downloadProgressInterval is how often download progress is published to followers
+// downloadProgressInterval 是向跟随者发布下载进度的间隔
+const downloadProgressInterval = 500 * time.Millisecond
+
 // StartDownload starts downloading a package from mirror to local storage.
 // StartDownload 开始从镜像源下载安装包到本地存储。
 func (s *Service) StartDownload(ctx context.Context, req *DownloadRequest) (*DownloadTask, error) {
@@ -900,68 +1084,184 @@ func (s *Service) StartDownload(ctx context.Context, req *DownloadRequest) (*Dow
 	}
 	req.Version = version
 
+	// Use the fastest probed mirror if not specified / 如果未指定则使用探测到的最快镜像源
+	mirror := req.Mirror
+	if mirror == "" {
+		mirror = s.mirrorManager.BestMirror(ctx)
+	}
+
+	// Another replica may already be downloading the version / 其他副本可能已在下载该版本
+	remote, _, err := s.remoteDownload(ctx, req.Version)
+	if err != nil {
+		return nil, err
+	}
+	if remote != nil && isDownloadActive(remote.Status) {
+		return remote, ErrDownloadInProgress
+	}
+
 	s.downloadsMu.Lock()
 	defer s.downloadsMu.Unlock()
 
 	// Check if download is already in progress / 检查是否已有下载正在进行
-	if existing, ok := s.downloads[req.Version]; ok {
-		if existing.Status == DownloadStatusDownloading || existing.Status == DownloadStatusPending {
-			return existing, ErrDownloadInProgress
-		}
-	}
-
-	// Use default mirror if not specified / 如果未指定则使用默认镜像源
-	mirror := req.Mirror
-	if mirror == "" {
-		mirror = MirrorAliyun
+	if existing, ok := s.downloads[req.Version]; ok && isDownloadActive(existing.Status) {
+		snapshot := *existing
+		return &snapshot, ErrDownloadInProgress
 	}
 
-	// Get download URL / 获取下载 URL
-	downloadURL := fmt.Sprintf("%s/%s/apache-seatunnel-%s-bin.tar.gz",
-		MirrorURLs[mirror], req.Version, req.Version)
+	snapshot := *s.startDownloadLocked(req.Version, mirror)
+	return &snapshot, nil
+}
 
-	// Create download task / 创建下载任务
+// startDownloadLocked registers a new download task for version and runs it in the background.
+// The caller must hold downloadsMu.
+// startDownloadLocked 为版本登记新的下载任务并在后台执行，调用方须持有 downloadsMu。
+func (s *Service) startDownloadLocked(version string, mirror MirrorSource) *DownloadTask {
 	task := &DownloadTask{
 		ID:          uuid.New().String(),
-		Version:     req.Version,
+		Version:     version,
 		Mirror:      mirror,
-		DownloadURL: downloadURL,
+		DownloadURL: getDownloadURLs(version)[mirror],
 		Status:      DownloadStatusPending,
 		Progress:    0,
 		Message:     "准备下载 / Preparing download",
 		StartTime:   time.Now(),
+		done:        make(chan struct{}),
 	}
-
-	s.downloads[req.Version] = task
+	s.downloads[version] = task
 
 	// Start download in background / 在后台开始下载
 	go s.runDownload(context.Background(), task)
+	return task
+}
 
-	return task, nil
+// isDownloadActive reports whether a download in the given status is still running.
+// isDownloadActive 判断处于该状态的下载是否仍在进行。
+func isDownloadActive(status DownloadStatus) bool {
+	return status == DownloadStatusPending || status == DownloadStatusDownloading
 }
 
-// GetDownloadStatus returns the current download status for a version.
-// GetDownloadStatus 返回某版本的当前下载状态。
+// GetDownloadStatus returns a snapshot of the current download status for a version.
+// GetDownloadStatus 返回某版本当前下载状态的快照。
 func (s *Service) GetDownloadStatus(ctx context.Context, version string) (*DownloadTask, error) {
-	s.downloadsMu.RLock()
-	defer s.downloadsMu.RUnlock()
+	version = strings.TrimSpace(version)
+	if !packageVersionRegexp.MatchString(version) {
+		return nil, ErrInvalidPackageVersion
+	}
 
+	s.downloadsMu.RLock()
 	task, ok := s.downloads[version]
-	if !ok {
+	var snapshot DownloadTask
+	if ok {
+		snapshot = *task
+	}
+	s.downloadsMu.RUnlock()
+	if ok {
+		return &snapshot, nil
+	}
+
+	// The download may run on another replica / 下载可能在其他副本上执行
+	remote, _, err := s.remoteDownload(ctx, version)
+	if err != nil {
+		return nil, err
+	}
+	if remote == nil {
 		return nil, ErrDownloadNotFound
 	}
+	return remote, nil
+}
+
+// ensurePackageDownload joins the running download of a version, or starts one when the
+// package is not stored locally. It returns nil when the package is already available.
+// ensurePackageDownload 加入某版本正在进行的下载，若本地没有安装包则启动下载；安装包已存在时返回 nil。
+func (s *Service) ensurePackageDownload(ctx context.Context, version string, mirror MirrorSource) (*DownloadTask, error) {
+	version = strings.TrimSpace(version)
+	if !packageVersionRegexp.MatchString(version) {
+		return nil, ErrInvalidPackageVersion
+	}
+	localPath := filepath.Join(s.packageDir, packageFileName(version))
+	if mirror == "" {
+		if s.hydratePackageFromStore(ctx, localPath) {
+			return nil, nil
+		}
+		mirror = s.mirrorManager.BestMirror(ctx)
+	}
+
+	s.downloadsMu.Lock()
+	defer s.downloadsMu.Unlock()
 
-	return task, nil
+	// Check under the lock so concurrent installations share one download
+	// 在锁内检查，保证并发安装共享同一个下载任务
+	if existing, ok := s.downloads[version]; ok && isDownloadActive(existing.Status) {
+		return existing, nil
+	}
+	if _, err := os.Stat(localPath); err == nil {
+		return nil, nil
+	}
+	return s.startDownloadLocked(version, mirror), nil
 }
 
-// CancelDownload cancels an ongoing download.
-// CancelDownload 取消正在进行的下载。
+// waitForDownload follows a download task until it finishes, passing progress snapshots to onProgress.
+// waitForDownload 跟随下载任务直到结束，并把进度快照交给 onProgress。
+func (s *Service) waitForDownload(ctx context.Context, task *DownloadTask, onProgress func(DownloadTask)) (DownloadTask, error) {
+	ticker := time.NewTicker(downloadProgressInterval)
+	defer ticker.Stop()
+
+	for {
+		s.downloadsMu.RLock()
+		snapshot := *task
+		s.downloadsMu.RUnlock()
+		if !isDownloadActive(snapshot.Status) {
+			return snapshot, nil
+		}
+		if onProgress != nil {
+			onProgress(snapshot)
+		}
+
+		select {
+		case <-task.done:
+			s.downloadsMu.RLock()
+			snapshot = *task
+			s.downloadsMu.RUnlock()
+			return snapshot, nil
+		case <-ticker.C:
+		case <-ctx.Done():
+			return snapshot, ctx.Err()
+		}
+	}
+}
+
+// CancelDownload cancels an ongoing download. A download running on another replica is cancelled
+// by that replica on its next state sync.
+// CancelDownload 取消正在进行的下载。在其他副本上执行的下载由该副本在下次状态同步时取消。
 func (s *Service) CancelDownload(ctx context.Context, version string) (*DownloadTask, error) {
 	version = strings.TrimSpace(version)
 	if !packageVersionRegexp.MatchString(version) {
 		return nil, ErrInvalidPackageVersion
 	}
 
+	task, err := s.cancelLocalDownload(ctx, version)
+	if !errors.Is(err, ErrDownloadNotFound) {
+		return task, err
+	}
+	remote, _, err := s.remoteDownload(ctx, version)
+	if err != nil {
+		return nil, err
+	}
+	if remote == nil {
+		return nil, ErrDownloadNotFound
+	}
+	if isDownloadActive(remote.Status) {
+		if _, err := s.taskStateRepo.RequestCancel(ctx, TaskStateDownload, version); err != nil {
+			return nil, err
+		}
+		remote.Message = "已请求取消下载 / Download cancellation requested"
+	}
+	return remote, nil
+}
+
+// cancelLocalDownload cancels a download running on this replica.
+// cancelLocalDownload 取消在本副本上执行的下载。
+func (s *Service) cancelLocalDownload(ctx context.Context, version string) (*DownloadTask, error) {
 	s.downloadsMu.Lock()
 	defer s.downloadsMu.Unlock()
 
@@ -970,8 +1270,9 @@ func (s *Service) CancelDownload(ctx context.Context, version string) (*Download
 		return nil, ErrDownloadNotFound
 	}
 
-	if task.Status != DownloadStatusDownloading && task.Status != DownloadStatusPending {
-		return task, nil // Already completed or failed / 已完成或失败
+	if !isDownloadActive(task.Status) {
+		snapshot := *task
+		return &snapshot, nil // Already completed or failed / 已完成或失败
 	}
 
 	now := time.Now()
@@ -983,25 +1284,78 @@ func (s *Service) CancelDownload(ctx context.Context, version string) (*Download
 	tempPath := filepath.Join(s.tempDir, fmt.Sprintf("apache-seatunnel-%s-bin.tar.gz.tmp", version))
 	os.Remove(tempPath)
 
-	return task, nil
+	snapshot := *task
+	return &snapshot, nil
 }
 
-// ListDownloads returns all download tasks.
-// ListDownloads 返回所有下载任务。
+// ListDownloads returns snapshots of all download tasks, including those running on other replicas.
+// ListDownloads 返回所有下载任务的快照，包括在其他副本上执行的任务。
 func (s *Service) ListDownloads(ctx context.Context) []*DownloadTask {
+	remote := s.remoteDownloads(ctx)
+
 	s.downloadsMu.RLock()
 	defer s.downloadsMu.RUnlock()
 
-	tasks := make([]*DownloadTask, 0, len(s.downloads))
-	for _, task := range s.downloads {
+	tasks := make([]*DownloadTask, 0, len(s.downloads)+len(remote))
+	for version, task := range s.downloads {
+		snapshot := *task
+		tasks = append(tasks, &snapshot)
+		delete(remote, version)
+	}
+	for _, task := range remote {
 		tasks = append(tasks, task)
 	}
 	return tasks
 }
 
+// ListMirrors returns the cached mirror probe results, probing again when stale or refresh is set.
+// ListMirrors 返回缓存的镜像探测结果，过期或 refresh 为 true 时重新探测。
+func (s *Service) ListMirrors(ctx context.Context, refresh bool) []*MirrorProbeResult {
+	return s.mirrorManager.Probe(ctx, refresh)
+}
+
+// openMirrorDownload requests the package from each mirror in attempt order until one answers 200.
+// openMirrorDownload 按尝试顺序向各镜像请求安装包，直到某个镜像返回 200。
+func (s *Service) openMirrorDownload(ctx context.Context, task *DownloadTask) (*http.Response, error) {
+	s.downloadsMu.RLock()
+	preferred := task.Mirror
+	s.downloadsMu.RUnlock()
+
+	urls := getDownloadURLs(task.Version)
+	var lastErr error
+	for _, mirror := range s.mirrorManager.OrderMirrors(ctx, preferred) {
+		downloadURL := urls[mirror]
+		s.downloadsMu.Lock()
+		task.Mirror = mirror
+		task.DownloadURL = downloadURL
+		s.downloadsMu.Unlock()
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, downloadURL, nil)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := s.downloadClient.Do(req)
+		if err == nil && resp.StatusCode == http.StatusOK {
+			return resp, nil
+		}
+		if err == nil {
+			resp.Body.Close()
+			err = fmt.Errorf("HTTP 错误 / HTTP error: %d", resp.StatusCode)
+		}
+		logger.WarnF(ctx, "[Installer] 镜像下载失败，尝试下一个镜像 / Mirror download failed, trying next mirror: version=%s, mirror=%s, error=%v", task.Version, mirror, err)
+		s.mirrorManager.MarkFailed(mirror, err)
+		lastErr = fmt.Errorf("%s: %w", mirror, err)
+	}
+	if lastErr == nil {
+		lastErr = fmt.Errorf("no download mirror available / 没有可用的下载镜像")
+	}
+	return nil, fmt.Errorf("请求失败 / Request failed: %w", lastErr)
+}
+
 // runDownload executes the download process.
 // runDownload 执行下载过程。
 func (s *Service) runDownload(ctx context.Context, task *DownloadTask) {
+	defer close(task.done)
 	logger.InfoF(ctx, "[Installer] 开始下载安装包 / Start downloading package: version=%s, mirror=%s", task.Version, task.Mirror)
 
 	s.downloadsMu.Lock()
@@ -1033,31 +1387,20 @@ func (s *Service) runDownload(ctx context.Context, task *DownloadTask) {
 		return
 	}
 
-	// Create HTTP request / 创建 HTTP 请求
-	resp, err := http.Get(task.DownloadURL)
+	// Open the download, falling through mirrors on HTTP errors / 打开下载，遇到 HTTP 错误时依次切换镜像
+	resp, err := s.openMirrorDownload(ctx, task)
 	if err != nil {
-		logger.ErrorF(ctx, "[Installer] 下载请求失败 / Download request failed: version=%s, error=%v", task.Version, err)
+		logger.ErrorF(ctx, "[Installer] 所有镜像下载失败 / Download failed on all mirrors: version=%s, error=%v", task.Version, err)
 		s.downloadsMu.Lock()
 		now := time.Now()
 		task.Status = DownloadStatusFailed
-		task.Error = fmt.Sprintf("请求失败 / Request failed: %v", err)
+		task.Error = err.Error()
 		task.EndTime = &now
 		s.downloadsMu.Unlock()
 		return
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		logger.ErrorF(ctx, "[Installer] 下载 HTTP 错误 / Download HTTP error: version=%s, status=%d", task.Version, resp.StatusCode)
-		s.downloadsMu.Lock()
-		now := time.Now()
-		task.Status = DownloadStatusFailed
-		task.Error = fmt.Sprintf("HTTP 错误 / HTTP error: %d", resp.StatusCode)
-		task.EndTime = &now
-		s.downloadsMu.Unlock()
-		return
-	}
-
 	// Get total size / 获取总大小
 	s.downloadsMu.Lock()
 	task.TotalBytes = resp.ContentLength
@@ -1078,6 +1421,7 @@ func (s *Service) runDownload(ctx context.Context, task *DownloadTask) {
 
 	// Download with progress tracking / 带进度跟踪的下载
 	buf := make([]byte, 32*1024) // 32KB buffer
+	hash := sha512.New()
 	var downloaded int64
 	lastUpdate := time.Now()
 	var lastDownloaded int64
@@ -1105,10 +1449,11 @@ func (s *Service) runDownload(ctx context.Context, task *DownloadTask) {
 				os.Remove(tempPath)
 				return
 			}
+			hash.Write(buf[:n])
 			downloaded += int64(n)
 
 			// Update progress every 500ms / 每 500ms 更新一次进度
-			if time.Since(lastUpdate) > 500*time.Millisecond {
+			if time.Since(lastUpdate) > downloadProgressInterval {
 				s.downloadsMu.Lock()
 				task.DownloadedBytes = downloaded
 				if task.TotalBytes > 0 {
@@ -1145,6 +1490,26 @@ func (s *Service) runDownload(ctx context.Context, task *DownloadTask) {
 	// Close file before moving / 移动前关闭文件
 	out.Close()
 
+	// Verify against the official .sha512 / 使用官方 .sha512 校验
+	s.downloadsMu.Lock()
+	task.Message = "正在校验 / Verifying checksum"
+	s.downloadsMu.Unlock()
+	actual := hex.EncodeToString(hash.Sum(nil))
+	expected, checksumErr := s.fetchOfficialSHA512(ctx, task.Version, task.DownloadURL)
+	if checksumErr != nil {
+		logger.WarnF(ctx, "[Installer] 无法获取官方校验和，安装包未校验 / Official sha512 unavailable, package left unverified: version=%s, error=%v", task.Version, checksumErr)
+	} else if actual != expected {
+		logger.ErrorF(ctx, "[Installer] 安装包校验失败 / Package checksum mismatch: version=%s, expected=%s, actual=%s", task.Version, expected, actual)
+		s.downloadsMu.Lock()
+		now := time.Now()
+		task.Status = DownloadStatusFailed
+		task.Error = fmt.Sprintf("%v: expected %s, got %s", ErrPackageChecksumMismatch, expected, actual)
+		task.EndTime = &now
+		s.downloadsMu.Unlock()
+		os.Remove(tempPath)
+		return
+	}
+
 	// Move temp file to final location / 将临时文件移动到最终位置
 	if err := os.Rename(tempPath, finalPath); err != nil {
 		s.downloadsMu.Lock()
@@ -1157,17 +1522,34 @@ func (s *Service) runDownload(ctx context.Context, task *DownloadTask) {
 		return
 	}
 
+	verified := false
+	if checksumErr == nil {
+		if err := writePackageSHA512(finalPath, actual); err != nil {
+			logger.WarnF(ctx, "[Installer] 保存校验和失败 / Failed to store package checksum: version=%s, error=%v", task.Version, err)
+		} else {
+			verified = true
+		}
+	} else {
+		_ = os.Remove(packageSHA512Path(finalPath))
+	}
+
 	// Mark as completed / 标记为完成
 	s.downloadsMu.Lock()
 	now := time.Now()
 	task.Status = DownloadStatusCompleted
 	task.Progress = 100
 	task.DownloadedBytes = downloaded
-	task.Message = "下载完成 / Download completed"
+	task.Verified = verified
+	if verified {
+		task.Message = "下载完成，校验通过 / Download completed and verified"
+	} else {
+		task.Message = "下载完成，未校验 / Download completed but not verified"
+	}
 	task.EndTime = &now
 	s.downloadsMu.Unlock()
 
-	logger.InfoF(ctx, "[Installer] 下载完成 / Download completed: version=%s, size=%d bytes", task.Version, downloaded)
+	logger.InfoF(ctx, "[Installer] 下载完成 / Download completed: version=%s, size=%d bytes, verified=%t", task.Version, downloaded, verified)
+	s.mirrorPackageToStore(ctx, finalPath)
 }
 
 // ==================== Precheck 预检查 ====================
@@ -1182,7 +1564,20 @@ var DefaultPrecheckPorts = []int{5801, 5802, 8080}
 // 这是安装预检查 - 端口应该可用（未被占用）。
 // This is opposite to PrecheckNode which checks if SeaTunnel is running.
 // 这与 PrecheckNode 相反，后者检查 SeaTunnel 是否正在运行。
+// Every result is kept in the precheck history of the host.
+// 每次结果都会保存到主机的预检查历史中。
 func (s *Service) RunPrecheck(ctx context.Context, hostID uint, req *PrecheckRequest) (*PrecheckResult, error) {
+	result, err := s.runPrecheck(ctx, hostID, req)
+	if err != nil {
+		return nil, err
+	}
+	s.recordPrecheckRun(ctx, hostID, req, result)
+	return result, nil
+}
+
+// runPrecheck performs the checks of RunPrecheck without recording them.
+// runPrecheck 执行 RunPrecheck 的各项检查，但不记录历史。
+func (s *Service) runPrecheck(ctx context.Context, hostID uint, req *PrecheckRequest) (*PrecheckResult, error) {
 	logger.InfoF(ctx, "[Installer] 开始预检查 / Start precheck: host=%d", hostID)
 
 	// Initialize result
@@ -1266,29 +1661,7 @@ func (s *Service) RunPrecheck(ctx context.Context, hostID uint, req *PrecheckReq
 	}
 	portsItem.Details["ports_to_check"] = ports
 
-	unavailablePorts := make([]int, 0)
-	availablePorts := make([]int, 0)
-
-	for _, port := range ports {
-		params := map[string]string{
-			"port": fmt.Sprintf("%d", port),
-		}
-		success, _, err := s.agentManager.SendCommand(ctx, hostInfo.AgentID, "check_port", params)
-		if err != nil {
-			// Error checking port, treat as unavailable
-			// 检查端口出错，视为不可用
-			unavailablePorts = append(unavailablePorts, port)
-		} else if success {
-			// Port is listening = port is IN USE = FAILED for installation
-			// 端口正在监听 = 端口被占用 = 安装失败
-			unavailablePorts = append(unavailablePorts, port)
-		} else {
-			// Port is not listening = port is AVAILABLE = PASSED for installation
-			// 端口未监听 = 端口可用 = 安装通过
-			availablePorts = append(availablePorts, port)
-		}
-	}
-
+	availablePorts, unavailablePorts := s.checkPortsAvailable(ctx, hostInfo.AgentID, ports)
 	portsItem.Details["available_ports"] = availablePorts
 	portsItem.Details["unavailable_ports"] = unavailablePorts
 
@@ -1299,6 +1672,15 @@ func (s *Service) RunPrecheck(ctx context.Context, hostID uint, req *PrecheckReq
 		portsItem.Status = CheckStatusFailed
 		portsItem.Message = fmt.Sprintf("Ports in use: %v / 端口被占用: %v", unavailablePorts, unavailablePorts)
 		result.OverallStatus = CheckStatusFailed
+
+		// Offer free alternatives the install request can take as is
+		// 提供可直接用于安装请求的可用替代端口
+		alternatives := s.suggestAlternativePorts(ctx, hostInfo.AgentID, ports, unavailablePorts, req.PortRangeStart, req.PortRangeEnd)
+		if len(alternatives) > 0 {
+			portsItem.Details["suggested_ports"] = alternatives
+			result.SuggestedPorts = precheckPortRoles(req, ports).suggestions(alternatives)
+			portsItem.Suggestion = formatPortSuggestion(unavailablePorts, alternatives)
+		}
 	}
 	result.Items = append(result.Items, portsItem)
 
@@ -1334,8 +1716,29 @@ func (s *Service) RunPrecheck(ctx context.Context, hostID uint, req *PrecheckReq
 	}
 	result.Items = append(result.Items, dirItem)
 
-	// Check 4: Java environment
-	// 检查 4：Java 环境
+	// Check 4: Disk space for package download and extraction
+	// 检查 4：安装包下载与解压所需磁盘空间
+	diskSpaceItem := s.checkPackageDiskSpace(ctx, hostInfo.AgentID, installDir, req)
+	if diskSpaceItem.Status == CheckStatusFailed {
+		result.OverallStatus = CheckStatusFailed
+	}
+	result.Items = append(result.Items, diskSpaceItem)
+
+	// Check 5: System limits and kernel parameters
+	// 检查 5：系统限制与内核参数
+	clusterPort := req.ClusterPort
+	if clusterPort <= 0 {
+		clusterPort = ports[0]
+	}
+	for _, item := range s.checkSystemLimits(ctx, hostInfo.AgentID, clusterPort) {
+		if item.Status == CheckStatusFailed {
+			result.OverallStatus = CheckStatusFailed
+		}
+		result.Items = append(result.Items, item)
+	}
+
+	// Check 6: Java environment
+	// 检查 6：Java 环境
 	// Supported: Java 8, 11 (passed)
 	// Other versions: warning (not blocking)
 	// Not installed: failed
@@ -1360,14 +1763,19 @@ func (s *Service) RunPrecheck(ctx context.Context, hostID uint, req *PrecheckReq
 		javaItem.Status = CheckStatusWarning
 		javaItem.Message = fmt.Sprintf("Failed to check Java: %v / 检查 Java 失败: %v", err, err)
 	} else if !success {
-		// Java not installed
-		// Java 未安装
-		javaItem.Status = CheckStatusFailed
-		javaItem.Message = "Java is not installed. Please install Java 8 or 11. / Java 未安装。请安装 Java 8 或 11。"
+		// Java not installed; with auto_install_java the install step provides OpenJDK 11
+		// Java 未安装；启用 auto_install_java 时由安装步骤提供 OpenJDK 11
 		if output != "" {
 			javaItem.Details["output"] = output
 		}
-		result.OverallStatus = CheckStatusFailed
+		if req.AutoInstallJava {
+			javaItem.Status = CheckStatusWarning
+			javaItem.Message = "Java is not installed, OpenJDK 11 will be installed automatically / Java 未安装，将自动安装 OpenJDK 11"
+		} else {
+			javaItem.Status = CheckStatusFailed
+			javaItem.Message = "Java is not installed. Please install Java 8 or 11. / Java 未安装。请安装 Java 8 或 11。"
+			result.OverallStatus = CheckStatusFailed
+		}
 	} else {
 		// Java is installed, check version from output
 		// Java 已安装，从输出检查版本
@@ -1399,6 +1807,16 @@ func (s *Service) RunPrecheck(ctx context.Context, hostID uint, req *PrecheckReq
 	}
 	result.Items = append(result.Items, javaItem)
 
+	// Check 7: Checkpoint storage round trip, only when the install will use a checkpoint config
+	// 检查 7：checkpoint 存储往返校验，仅在安装指定了 checkpoint 配置时执行
+	if req.Checkpoint != nil {
+		checkpointItem := s.checkCheckpointStorage(ctx, hostInfo.AgentID, installDir, req)
+		if checkpointItem.Status == CheckStatusFailed {
+			result.OverallStatus = CheckStatusFailed
+		}
+		result.Items = append(result.Items, checkpointItem)
+	}
+
 	// Set summary
 	// 设置摘要
 	passedCount := 0
@@ -1439,9 +1857,11 @@ func (s *Service) ValidateRuntimeStorage(ctx context.Context, req *RuntimeStorag
 	result := &RuntimeStorageValidationResult{
 		Success: true,
 		Kind:    req.Kind,
-		Warning: "Validation checks local path readiness or remote endpoint reachability only. Credentials and write permissions still need runtime verification. / 当前校验仅检查本地路径可用性或远程端点可达性，凭证与写入权限仍需运行时验证。",
 		Hosts:   make([]*RuntimeStorageValidationHostResult, 0, len(req.HostIDs)),
 	}
+	if req.Kind == RuntimeStorageValidationIMAP {
+		result.Warning = reachabilityOnlyValidationWarning
+	}
 
 	for _, hostID := range req.HostIDs {
 		hostInfo, err := s.hostProvider.GetHostByID(ctx, hostID)
@@ -1481,6 +1901,9 @@ func (s *Service) ValidateRuntimeStorage(ctx context.Context, req *RuntimeStorag
 		}
 		hostResult.HostID = hostID
 		hostResult.HostName = hostName
+		if hostResult.Details["mode"] == "reachability" {
+			result.Warning = reachabilityOnlyValidationWarning
+		}
 		if !hostResult.Success {
 			result.Success = false
 		}
@@ -1490,10 +1913,98 @@ func (s *Service) ValidateRuntimeStorage(ctx context.Context, req *RuntimeStorag
 	return result, nil
 }
 
+// reachabilityOnlyValidationWarning notes that a validation did not exercise credentials or writes.
+// reachabilityOnlyValidationWarning 提示该校验未验证凭证与写入权限。
+const reachabilityOnlyValidationWarning = "Validation checks local path readiness or remote endpoint reachability only. Credentials and write permissions still need runtime verification. / 当前校验仅检查本地路径可用性或远程端点可达性，凭证与写入权限仍需运行时验证。"
+
+// validateCheckpointStorageOnHost asks the Agent to write, read and delete a test object on the
+// checkpoint storage. Agents that predate the validate_checkpoint_storage command fall back to
+// the path and endpoint reachability checks.
+// validateCheckpointStorageOnHost 让 Agent 在 checkpoint 存储上写入、读取并删除测试对象。
+// 不支持 validate_checkpoint_storage 命令的旧版 Agent 回退为路径与端点可达性检查。
 func (s *Service) validateCheckpointStorageOnHost(ctx context.Context, host *HostInfo, cfg *CheckpointConfig) *RuntimeStorageValidationHostResult {
 	if cfg == nil {
 		return &RuntimeStorageValidationHostResult{Success: false, Message: "checkpoint config is required"}
 	}
+	return s.runCheckpointStorageValidation(ctx, host.AgentID, cfg, "", "")
+}
+
+func (s *Service) runCheckpointStorageValidation(ctx context.Context, agentID string, cfg *CheckpointConfig, installDir, version string) *RuntimeStorageValidationHostResult {
+	params := checkpointValidationParams(cfg)
+	params["sub_command"] = "validate_checkpoint_storage"
+	if installDir != "" {
+		params["install_dir"] = installDir
+		params["version"] = version
+	}
+	success, output, err := s.agentManager.SendCommand(ctx, agentID, "validate_checkpoint_storage", params)
+	if err != nil {
+		return &RuntimeStorageValidationHostResult{Success: false, Message: err.Error()}
+	}
+	if !success && strings.Contains(output, "unknown precheck sub-command") {
+		result := s.validateCheckpointReachabilityOnHost(ctx, agentID, cfg)
+		if result.Details == nil {
+			result.Details = map[string]string{}
+		}
+		result.Details["mode"] = "reachability"
+		return result
+	}
+	return runtimeStorageHostResultFromCommandOutput(success, output)
+}
+
+// checkpointValidationParams flattens a checkpoint config into Agent command parameters.
+// checkpointValidationParams 将 checkpoint 配置展开为 Agent 命令参数。
+func checkpointValidationParams(cfg *CheckpointConfig) map[string]string {
+	return map[string]string{
+		"storage_type":                 string(cfg.StorageType),
+		"namespace":                    cfg.Namespace,
+		"hdfs_namenode_host":           cfg.HDFSNameNodeHost,
+		"hdfs_namenode_port":           strconv.Itoa(cfg.HDFSNameNodePort),
+		"kerberos_principal":           cfg.KerberosPrincipal,
+		"kerberos_keytab_file_path":    cfg.KerberosKeytabFilePath,
+		"hdfs_ha_enabled":              strconv.FormatBool(cfg.HDFSHAEnabled),
+		"hdfs_name_services":           cfg.HDFSNameServices,
+		"hdfs_ha_namenodes":            cfg.HDFSHANamenodes,
+		"hdfs_namenode_rpc_address_1":  cfg.HDFSNamenodeRPCAddress1,
+		"hdfs_namenode_rpc_address_2":  cfg.HDFSNamenodeRPCAddress2,
+		"hdfs_failover_proxy_provider": cfg.HDFSFailoverProxyProvider,
+		"storage_endpoint":             cfg.StorageEndpoint,
+		"storage_access_key":           cfg.StorageAccessKey,
+		"storage_secret_key":           cfg.StorageSecretKey,
+		"storage_bucket":               cfg.StorageBucket,
+	}
+}
+
+// checkCheckpointStorage runs the checkpoint storage round trip as a precheck item.
+// checkCheckpointStorage 以预检查项的形式执行 checkpoint 存储往返校验。
+func (s *Service) checkCheckpointStorage(ctx context.Context, agentID, installDir string, req *PrecheckRequest) PrecheckItem {
+	item := PrecheckItem{
+		Name:    "checkpoint_storage",
+		Details: make(map[string]interface{}),
+	}
+	item.Details["storage_type"] = string(req.Checkpoint.StorageType)
+
+	result := s.runCheckpointStorageValidation(ctx, agentID, req.Checkpoint, installDir, req.Version)
+	for k, v := range result.Details {
+		item.Details[k] = v
+	}
+	switch {
+	case result.Success && result.Details["mode"] == "reachability":
+		item.Status = CheckStatusWarning
+		item.Message = fmt.Sprintf("Checkpoint storage is reachable, but this Agent cannot verify writes: %s / checkpoint 存储可达，但该 Agent 无法验证写入：%s",
+			result.Message, result.Message)
+	case result.Success:
+		item.Status = CheckStatusPassed
+		item.Message = result.Message
+	default:
+		item.Status = CheckStatusFailed
+		item.Message = result.Message
+		item.Suggestion = "Check the checkpoint storage address, credentials and permissions on the namespace / 请检查 checkpoint 存储地址、凭证以及命名空间权限"
+	}
+	return item
+}
+
+func (s *Service) validateCheckpointReachabilityOnHost(ctx context.Context, agentID string, cfg *CheckpointConfig) *RuntimeStorageValidationHostResult {
+	host := &HostInfo{AgentID: agentID}
 	switch cfg.StorageType {
 	case CheckpointStorageLocalFile:
 		return s.runPathReadyCheck(ctx, host.AgentID, strings.TrimSpace(cfg.Namespace))
@@ -1674,6 +2185,144 @@ func splitHostPortWithDefault(raw string, defaultPort int) (string, int) {
 	return trimmed, defaultPort
 }
 
+// DefaultPackageExpansionFactor is the default ratio of install dir space to package size,
+// covering extracted files plus connectors installed later.
+// DefaultPackageExpansionFactor 是安装目录所需空间与安装包大小的默认比例，
+// 涵盖解压后的文件以及之后安装的连接器。
+const DefaultPackageExpansionFactor = 3.0
+
+// checkPackageDiskSpace asks the Agent whether the install dir and temp dir have room for the
+// package of req.Version. The package size comes from the local package repository; the
+// install dir needs package size × expansion factor, the temp dir the package itself.
+// checkPackageDiskSpace 询问 Agent 安装目录和临时目录是否有足够空间容纳 req.Version 的安装包。
+// 安装包大小来自本地安装包仓库；安装目录需要 安装包大小 × 膨胀系数，临时目录需要安装包本身大小。
+func (s *Service) checkPackageDiskSpace(ctx context.Context, agentID, installDir string, req *PrecheckRequest) PrecheckItem {
+	item := PrecheckItem{
+		Name:    "disk_space",
+		Details: make(map[string]interface{}),
+	}
+	item.Details["install_dir"] = installDir
+
+	expansionFactor := req.ExpansionFactor
+	if expansionFactor < 1 {
+		expansionFactor = DefaultPackageExpansionFactor
+	}
+	item.Details["expansion_factor"] = expansionFactor
+
+	if req.Version == "" {
+		item.Status = CheckStatusWarning
+		item.Message = "Version not specified, disk space for the package is not checked / 未指定版本，未检查安装包所需磁盘空间"
+		return item
+	}
+	item.Details["version"] = req.Version
+
+	// Stat the package directly; GetPackageInfo would also checksum the whole file.
+	// 直接 stat 安装包；GetPackageInfo 还会对整个文件计算校验和。
+	fileInfo, err := os.Stat(filepath.Join(s.packageDir, packageFileName(req.Version)))
+	if err != nil || fileInfo.Size() <= 0 {
+		item.Status = CheckStatusWarning
+		item.Message = fmt.Sprintf("Package size of version %s is unknown, disk space is not checked / 版本 %s 的安装包大小未知，未检查磁盘空间",
+			req.Version, req.Version)
+		return item
+	}
+	packageSize := fileInfo.Size()
+	item.Details["package_size"] = packageSize
+
+	params := map[string]string{
+		"sub_command":      "check_disk_space",
+		"install_dir":      installDir,
+		"package_size":     strconv.FormatInt(packageSize, 10),
+		"expansion_factor": strconv.FormatFloat(expansionFactor, 'f', -1, 64),
+	}
+	success, output, err := s.agentManager.SendCommand(ctx, agentID, "check_disk_space", params)
+	if err != nil {
+		item.Status = CheckStatusWarning
+		item.Message = fmt.Sprintf("Failed to check disk space: %v / 检查磁盘空间失败: %v", err, err)
+		return item
+	}
+
+	var resp struct {
+		Message string            `json:"message"`
+		Details map[string]string `json:"details"`
+	}
+	if jsonErr := json.Unmarshal([]byte(strings.TrimSpace(output)), &resp); jsonErr == nil {
+		for k, v := range resp.Details {
+			item.Details[k] = v
+		}
+		output = resp.Message
+	}
+	if success {
+		item.Status = CheckStatusPassed
+	} else {
+		item.Status = CheckStatusFailed
+	}
+	item.Message = output
+	return item
+}
+
+// SystemPrecheckNames are the Agent-side system limit and kernel parameter checks, in order.
+// SystemPrecheckNames 是 Agent 端的系统限制与内核参数检查（按顺序）。
+var SystemPrecheckNames = []string{"open_files", "max_user_processes", "max_map_count", "swap", "clock_sync", "firewall"}
+
+// checkSystemLimits runs the system limit and kernel parameter checks on the Agent. The Control
+// Plane clock is sent as reference so every node's clock skew is measured against the same clock.
+// checkSystemLimits 在 Agent 上执行系统限制与内核参数检查。Control Plane 时钟作为参考时间发送，
+// 使每个节点的时钟偏差都以同一时钟为基准测量。
+func (s *Service) checkSystemLimits(ctx context.Context, agentID string, clusterPort int) []PrecheckItem {
+	items := make([]PrecheckItem, 0, len(SystemPrecheckNames))
+	for _, name := range SystemPrecheckNames {
+		item := PrecheckItem{
+			Name:    name,
+			Details: make(map[string]interface{}),
+		}
+		params := map[string]string{
+			"sub_command":       "check_system",
+			"check":             name,
+			"cluster_port":      strconv.Itoa(clusterPort),
+			"reference_time_ms": strconv.FormatInt(time.Now().UnixMilli(), 10),
+		}
+		success, output, err := s.agentManager.SendCommand(ctx, agentID, "check_system", params)
+		if err != nil {
+			item.Status = CheckStatusWarning
+			item.Message = fmt.Sprintf("Failed to run %s check: %v / 执行 %s 检查失败: %v", name, err, name, err)
+			items = append(items, item)
+			continue
+		}
+
+		var resp struct {
+			Message string            `json:"message"`
+			Details map[string]string `json:"details"`
+		}
+		if jsonErr := json.Unmarshal([]byte(strings.TrimSpace(output)), &resp); jsonErr != nil {
+			item.Status = CheckStatusWarning
+			item.Message = output
+			items = append(items, item)
+			continue
+		}
+
+		item.Message = resp.Message
+		item.Suggestion = resp.Details["suggestion"]
+		item.Status = CheckStatus(resp.Details["status"])
+		switch item.Status {
+		case CheckStatusPassed, CheckStatusWarning, CheckStatusFailed:
+		default:
+			// Older agents without the check report an unknown sub-command
+			// 不支持该检查的旧版 Agent 会返回未知子命令
+			item.Status = CheckStatusWarning
+			if !success && item.Message == "" {
+				item.Message = output
+			}
+		}
+		for k, v := range resp.Details {
+			if k != "status" && k != "suggestion" {
+				item.Details[k] = v
+			}
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
 // javaCheckResponse represents the JSON response from Agent's check_java command
 // javaCheckResponse 表示 Agent check_java 命令的 JSON 响应
 type javaCheckResponse struct {
@@ -1756,11 +2405,58 @@ func parseJavaVersionFromOutput(output string) int {
 // StartInstallation starts a new installation.
 // StartInstallation 开始新的安装。
 func (s *Service) StartInstallation(ctx context.Context, req *InstallationRequest) (*InstallationStatus, error) {
+	// Node-level JVM overrides take precedence over template defaults
+	// 节点级 JVM 覆盖优先于模板默认值
+	s.resolveInstallationJVM(ctx, req)
+	if err := s.applyInstallationTemplate(ctx, req); err != nil {
+		return nil, err
+	}
+
 	if req.InstallMode == "" {
 		req.InstallMode = InstallModeOnline
 	}
+	if req.UseSuggestedPorts {
+		if err := s.applySuggestedPorts(ctx, req); err != nil {
+			return nil, err
+		}
+	}
 
-	s.resolveInstallationJVM(ctx, req)
+	if err := validateSkipSteps(req); err != nil {
+		return nil, err
+	}
+	if err := validateRolePorts(req); err != nil {
+		return nil, err
+	}
+	if err := validateHazelcastNetwork(req); err != nil {
+		return nil, err
+	}
+	if err := validatePackageDelivery(req); err != nil {
+		return nil, err
+	}
+	if err := s.checkVersionPolicy(ctx, req.Version); err != nil {
+		return nil, err
+	}
+
+	// A dry run is synchronous and is not tracked as an installation
+	// 试运行同步执行，不作为安装任务跟踪
+	if req.DryRun {
+		return s.runInstallationDryRun(ctx, req), nil
+	}
+	if err := validateVersionOptions(req); err != nil {
+		return nil, err
+	}
+	if err := s.ensureHostNotInMaintenance(ctx, req.HostID); err != nil {
+		return nil, err
+	}
+
+	// Another replica may already be installing on the host / 其他副本可能已在该主机上安装
+	remote, _, err := s.remoteInstallation(ctx, req.HostID)
+	if err != nil {
+		return nil, err
+	}
+	if remote != nil && remote.Status == StepStatusRunning {
+		return nil, ErrInstallationInProgress
+	}
 
 	s.installMu.Lock()
 	defer s.installMu.Unlock()
@@ -1773,26 +2469,43 @@ func (s *Service) StartInstallation(ctx context.Context, req *InstallationReques
 	}
 
 	// Create new installation status / 创建新的安装状态
-	status := &InstallationStatus{
-		ID:          uuid.New().String(),
-		HostID:      req.HostID,
-		ClusterID:   strings.TrimSpace(req.ClusterID),
-		Status:      StepStatusRunning,
-		CurrentStep: InstallStepDownload,
-		Steps:       createInitialSteps(),
-		Progress:    0,
-		Message:     "Installation started / 安装已开始",
-		StartTime:   time.Now(),
+	status := newInstallationStatus(req)
+	for _, warning := range s.installationResourceWarnings(ctx, req) {
+		appendInstallationWarning(status, warning)
 	}
-
 	s.installations[req.HostID] = status
 
-	// Start installation in background / 在后台开始安装
-	go s.runInstallation(context.Background(), req, status)
+	// Start installation in background, tracked as a task when available / 在后台开始安装，可用时作为任务跟踪
+	if s.taskManager != nil {
+		t, err := s.submitInstallationTask(ctx, req, status)
+		if err == nil {
+			status.TaskID = t.ID
+			return status, nil
+		}
+		logger.WarnF(ctx, "[Installer] 创建安装任务失败 / failed to create installation task: %v", err)
+	}
+	go s.runInstallationAndNotify(tracex.ContinueFrom(context.Background(), ctx), req, status)
 
 	return status, nil
 }
 
+// ensureHostNotInMaintenance rejects new installations on hosts in maintenance mode.
+// ensureHostNotInMaintenance 拒绝在维护模式主机上发起新的安装。
+func (s *Service) ensureHostNotInMaintenance(ctx context.Context, hostID string) error {
+	if s.hostProvider == nil {
+		return nil
+	}
+	id, err := strconv.ParseUint(strings.TrimSpace(hostID), 10, 64)
+	if err != nil || id == 0 {
+		return nil
+	}
+	host, err := s.hostProvider.GetHostByID(ctx, uint(id))
+	if err != nil || host == nil || !host.Maintenance {
+		return nil
+	}
+	return fmt.Errorf("%w: %s", ErrHostUnderMaintenance, host.Name)
+}
+
 func (s *Service) resolveInstallationJVM(ctx context.Context, req *InstallationRequest) {
 	if s == nil || req == nil || req.JVM != nil || s.nodeJVMResolver == nil {
 		return
@@ -1823,19 +2536,43 @@ func (s *Service) resolveInstallationJVM(ctx context.Context, req *InstallationR
 	logger.InfoF(ctx, "[Installer] resolved node JVM config for installation: cluster=%d, host=%d, role=%s", clusterID, hostID, req.NodeRole)
 }
 
-// GetInstallationStatus returns the current installation status.
-// GetInstallationStatus 返回当前安装状态。
-func (s *Service) GetInstallationStatus(ctx context.Context, hostID uint) (*InstallationStatus, error) {
-	s.installMu.RLock()
-	defer s.installMu.RUnlock()
+// newInstallationStatus creates the initial status of an installation.
+// newInstallationStatus 创建安装的初始状态。
+func newInstallationStatus(req *InstallationRequest) *InstallationStatus {
+	status := &InstallationStatus{
+		ID:          uuid.New().String(),
+		HostID:      req.HostID,
+		ClusterID:   strings.TrimSpace(req.ClusterID),
+		Status:      StepStatusRunning,
+		CurrentStep: InstallStepDownload,
+		Steps:       createInitialSteps(),
+		Progress:    0,
+		Message:     "Installation started / 安装已开始",
+		StartTime:   time.Now(),
+	}
+	markSkippedSteps(status, req)
+	return status
+}
 
+// GetInstallationStatus returns the current installation status, wherever the installation runs.
+// GetInstallationStatus 返回当前安装状态，无论安装在哪个副本上执行。
+func (s *Service) GetInstallationStatus(ctx context.Context, hostID uint) (*InstallationStatus, error) {
 	hostIDStr := fmt.Sprintf("%d", hostID)
+	s.installMu.RLock()
 	status, ok := s.installations[hostIDStr]
-	if !ok {
-		return nil, ErrInstallationNotFound
+	s.installMu.RUnlock()
+	if ok {
+		return status, nil
 	}
 
-	return status, nil
+	remote, _, err := s.remoteInstallation(ctx, hostIDStr)
+	if err != nil {
+		return nil, err
+	}
+	if remote == nil {
+		return nil, ErrInstallationNotFound
+	}
+	return remote, nil
 }
 
 // RetryStep retries a failed installation step.
@@ -1868,29 +2605,144 @@ func (s *Service) RetryStep(ctx context.Context, hostID uint, step string) (*Ins
 	return status, nil
 }
 
-// CancelInstallation cancels an ongoing installation.
-// CancelInstallation 取消正在进行的安装。
+// CancelInstallation cancels an ongoing installation. An installation running on another replica is
+// cancelled by that replica on its next state sync.
+// CancelInstallation 取消正在进行的安装。在其他副本上执行的安装由该副本在下次状态同步时取消。
 func (s *Service) CancelInstallation(ctx context.Context, hostID uint) (*InstallationStatus, error) {
-	s.installMu.Lock()
-	defer s.installMu.Unlock()
+	status, err := s.cancelLocalInstallation(ctx, hostID)
+	if !errors.Is(err, ErrInstallationNotFound) {
+		return status, err
+	}
+	remote, _, err := s.remoteInstallation(ctx, fmt.Sprintf("%d", hostID))
+	if err != nil {
+		return nil, err
+	}
+	if remote == nil {
+		return nil, ErrInstallationNotFound
+	}
+	if remote.Status == StepStatusRunning {
+		if _, err := s.taskStateRepo.RequestCancel(ctx, TaskStateInstallation, remote.HostID); err != nil {
+			return nil, err
+		}
+		remote.Message = "已请求取消安装 / Installation cancellation requested"
+	}
+	return remote, nil
+}
 
+// cancelLocalInstallation cancels an installation running on this replica.
+// cancelLocalInstallation 取消在本副本上执行的安装。
+func (s *Service) cancelLocalInstallation(ctx context.Context, hostID uint) (*InstallationStatus, error) {
 	hostIDStr := fmt.Sprintf("%d", hostID)
+	s.installMu.RLock()
 	status, ok := s.installations[hostIDStr]
+	commandID := ""
+	if ok {
+		commandID = status.CommandID
+	}
+	s.installMu.RUnlock()
 	if !ok {
 		return nil, ErrInstallationNotFound
 	}
 
-	// TODO: Send cancel command to Agent
-	// TODO: 向 Agent 发送取消命令
+	// Ask the Agent to cancel the install command; it cleans up partial state and reports CANCELLED
+	// 请求 Agent 取消安装命令；Agent 清理部分状态后上报 CANCELLED
+	if commandID != "" && s.agentManager != nil {
+		if err := s.agentManager.CancelCommand(ctx, commandID); err != nil {
+			logger.WarnF(ctx, "[Installer] 取消安装命令失败 / Failed to cancel install command: host=%d, command=%s, error=%v", hostID, commandID, err)
+		}
+	}
 
+	s.installMu.Lock()
 	now := time.Now()
 	status.Status = StepStatusFailed
 	status.Message = "Installation cancelled / 安装已取消"
 	status.EndTime = &now
+	// Leave the installation or transfer queue when still waiting there
+	// 仍在安装或传输队列中等待时退出队列
+	if status.dequeue != nil {
+		status.dequeue()
+	}
+	s.installMu.Unlock()
+	s.publishRunningInstallations()
 
 	return status, nil
 }
 
+// RunningInstallationCount returns the number of installations currently running, not counting queued ones.
+// RunningInstallationCount 返回当前运行中的安装数量，不含排队中的安装。
+func (s *Service) RunningInstallationCount() int {
+	s.installMu.RLock()
+	defer s.installMu.RUnlock()
+	count := 0
+	for _, status := range s.installations {
+		if status.Status == StepStatusRunning && status.QueuePosition == 0 {
+			count++
+		}
+	}
+	return count
+}
+
+// InstallationsEvent is the payload published on eventbus.TopicInstallations.
+// InstallationsEvent 是发布到 eventbus.TopicInstallations 的事件数据。
+type InstallationsEvent struct {
+	Running int `json:"running"`
+}
+
+// publishRunningInstallations publishes the current running installation count on the process event bus.
+// publishRunningInstallations 在进程事件总线上发布当前运行中的安装数量。
+func (s *Service) publishRunningInstallations() {
+	eventbus.Publish(eventbus.TopicInstallations, InstallationsEvent{Running: s.RunningInstallationCount()})
+}
+
+// runInstallationAndNotify runs the installation and emits eventbus.InstallCompleted when it ends.
+// runInstallationAndNotify 运行安装流程，并在结束时发出 eventbus.InstallCompleted 事件。
+// The run is traced as one span; the install command sent to the Agent carries it as parent.
+// 整个安装过程记录为一个 span，下发给 Agent 的安装命令以其作为父 span。
+func (s *Service) runInstallationAndNotify(ctx context.Context, req *InstallationRequest, status *InstallationStatus) {
+	ctx, span := otel_trace.Start(ctx, "Installer.Install")
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("seatunnel.host_id", req.HostID),
+		attribute.String("seatunnel.cluster_id", req.ClusterID),
+		attribute.String("seatunnel.version", req.Version),
+	)
+
+	s.publishRunningInstallations()
+	defer s.publishRunningInstallations()
+	if release, err := s.admitInstallation(ctx, req, status); err != nil {
+		s.installMu.Lock()
+		if status.EndTime == nil {
+			now := time.Now()
+			status.Status = StepStatusFailed
+			status.Error = fmt.Sprintf("Installation was not admitted: %v / 安装未能开始: %v", err, err)
+			status.EndTime = &now
+		}
+		s.installMu.Unlock()
+	} else {
+		s.publishRunningInstallations()
+		s.runInstallation(ctx, req, status)
+		release()
+	}
+
+	s.installMu.RLock()
+	failed := status.Status == StepStatusFailed
+	snapshot := *status
+	s.installMu.RUnlock()
+	if failed {
+		span.SetStatus(codes.Error, snapshot.Error)
+	}
+	eventbus.Emit(ctx, eventbus.InstallCompleted{
+		InstallationID: snapshot.ID,
+		HostID:         snapshot.HostID,
+		ClusterID:      snapshot.ClusterID,
+		Version:        req.Version,
+		CurrentStep:    string(snapshot.CurrentStep),
+		Success:        !failed,
+		Error:          snapshot.Error,
+		Message:        snapshot.Message,
+	})
+}
+
 // runInstallation runs the installation process via Agent gRPC.
 // runInstallation 通过 Agent gRPC 运行安装过程。
 func (s *Service) runInstallation(ctx context.Context, req *InstallationRequest, status *InstallationStatus) {
@@ -1943,82 +2795,76 @@ func (s *Service) runInstallation(ctx context.Context, req *InstallationRequest,
 	// 对于在线/离线模式，先在 Control Plane 上确定安装包并传输到 Agent
 	var localPackagePath string
 
-	if req.InstallMode == InstallModeOnline {
+	// In direct delivery the Agent downloads the package itself instead of receiving it over the command stream
+	// 直接下载模式下由 Agent 自行下载安装包，而不是通过命令流接收
+	if req.PackageDelivery == PackageDeliveryDirect {
+		s.installMu.Lock()
+		status.Message = "Preparing direct package download... / 正在准备直接下载安装包..."
+		s.installMu.Unlock()
+		if download, ok := s.resolveDirectPackageDownload(ctx, req); ok {
+			logger.InfoF(ctx, "[Installer] Agent 将直接下载安装包 / Agent downloads package directly: agent=%s, version=%s, file=%s", agentID, req.Version, download.FileName)
+			s.installMu.Lock()
+			status.Message = "Agent is downloading the package directly... / Agent 正在直接下载安装包..."
+			s.installMu.Unlock()
+			req.PackageDownload = download
+		} else {
+			logger.WarnF(ctx, "[Installer] 无法直接下载，改由控制面传输安装包 / Direct download unavailable, transferring package through Control Plane: version=%s", req.Version)
+		}
+	}
+
+	if req.InstallMode == InstallModeOnline && req.PackageDownload == nil {
 		localPackagePath = filepath.Join(s.packageDir, packageFileName(req.Version))
 
-		if _, err := os.Stat(localPackagePath); os.IsNotExist(err) {
-			// Package not found locally, need to download first
-			// 本地未找到安装包，需要先下载
-			logger.InfoF(ctx, "[Installer] 本地未找到安装包，开始下载 / Package not found locally, starting download: version=%s", req.Version)
+		// Join a running download of this version or start one; nil means the package is already local
+		// 加入该版本正在进行的下载或启动新下载；返回 nil 表示安装包已在本地
+		task, err := s.ensurePackageDownload(ctx, req.Version, req.Mirror)
+		if err != nil {
+			logger.ErrorF(ctx, "[Installer] 启动下载失败 / Failed to start download: %v", err)
+			s.installMu.Lock()
+			now := time.Now()
+			status.Status = StepStatusFailed
+			status.Error = fmt.Sprintf("Failed to download package: %v / 下载安装包失败: %v", err, err)
+			status.EndTime = &now
+			s.installMu.Unlock()
+			return
+		}
+
+		if task != nil {
+			logger.InfoF(ctx, "[Installer] 本地未找到安装包，等待下载 / Package not found locally, waiting for download: version=%s, task=%s", req.Version, task.ID)
 
 			s.installMu.Lock()
 			status.Message = "Downloading package to Control Plane... / 正在下载安装包到控制平面..."
 			s.installMu.Unlock()
 
-			// Start download task
-			// 启动下载任务
-			mirror := req.Mirror
-			if mirror == "" {
-				mirror = MirrorAliyun
-			}
-			task, err := s.StartDownload(ctx, &DownloadRequest{
-				Version: req.Version,
-				Mirror:  mirror,
+			final, err := s.waitForDownload(ctx, task, func(snapshot DownloadTask) {
+				s.installMu.Lock()
+				status.Message = fmt.Sprintf("Downloading package... %d%% / 正在下载安装包... %d%%", snapshot.Progress, snapshot.Progress)
+				s.installMu.Unlock()
 			})
-			if err != nil && err != ErrDownloadInProgress {
-				logger.ErrorF(ctx, "[Installer] 启动下载失败 / Failed to start download: %v", err)
+			if err == nil && final.Status != DownloadStatusCompleted {
+				reason := final.Error
+				if reason == "" {
+					reason = final.Message
+				}
+				err = errors.New(reason)
+			}
+			if err != nil {
+				logger.ErrorF(ctx, "[Installer] 安装包下载失败 / Package download failed: %v", err)
 				s.installMu.Lock()
 				now := time.Now()
 				status.Status = StepStatusFailed
-				status.Error = fmt.Sprintf("Failed to download package: %v / 下载安装包失败: %v", err, err)
+				status.Error = fmt.Sprintf("Package download failed: %v / 安装包下载失败: %v", err, err)
 				status.EndTime = &now
 				s.installMu.Unlock()
 				return
 			}
-
-			// Wait for download to complete
-			// 等待下载完成（GetDownloadStatus 按 version 查 map，勿用 task.ID）
-			for {
-				task, err = s.GetDownloadStatus(ctx, task.Version)
-				if err != nil {
-					logger.ErrorF(ctx, "[Installer] 获取下载状态失败 / Failed to get download status: %v", err)
-					s.installMu.Lock()
-					now := time.Now()
-					status.Status = StepStatusFailed
-					status.Error = fmt.Sprintf("Failed to get download status: %v / 获取下载状态失败: %v", err, err)
-					status.EndTime = &now
-					s.installMu.Unlock()
-					return
-				}
-
-				if task.Status == DownloadStatusCompleted {
-					logger.InfoF(ctx, "[Installer] 安装包下载完成 / Package download completed: version=%s", req.Version)
-					break
-				}
-
-				if task.Status == DownloadStatusFailed {
-					logger.ErrorF(ctx, "[Installer] 安装包下载失败 / Package download failed: %s", task.Error)
-					s.installMu.Lock()
-					now := time.Now()
-					status.Status = StepStatusFailed
-					status.Error = fmt.Sprintf("Package download failed: %s / 安装包下载失败: %s", task.Error, task.Error)
-					status.EndTime = &now
-					s.installMu.Unlock()
-					return
-				}
-
-				s.installMu.Lock()
-				status.Message = fmt.Sprintf("Downloading package... %d%% / 正在下载安装包... %d%%", task.Progress, task.Progress)
-				s.installMu.Unlock()
-
-				time.Sleep(1 * time.Second)
-			}
+			logger.InfoF(ctx, "[Installer] 安装包下载完成 / Package download completed: version=%s", req.Version)
 		} else {
 			logger.InfoF(ctx, "[Installer] 使用本地已有安装包 / Using existing local package: %s", localPackagePath)
 		}
 	}
 
-	if req.InstallMode == InstallModeOffline {
+	if req.InstallMode == InstallModeOffline && req.PackageDownload == nil {
 		localPackagePath, err = s.resolveOfflinePackagePath(req)
 		if err != nil {
 			s.installMu.Lock()
@@ -2030,7 +2876,7 @@ func (s *Service) runInstallation(ctx context.Context, req *InstallationRequest,
 			return
 		}
 
-		if _, err := os.Stat(localPackagePath); err != nil {
+		if !s.hydratePackageFromStore(ctx, localPackagePath) {
 			logger.ErrorF(ctx, "[Installer] 离线安装包不存在 / Offline package not found: %s", localPackagePath)
 			s.installMu.Lock()
 			now := time.Now()
@@ -2058,42 +2904,68 @@ func (s *Service) runInstallation(ctx context.Context, req *InstallationRequest,
 			s.installMu.Unlock()
 			req.PackagePath = cachedRemotePath
 		} else {
-			// Transfer package to Agent via gRPC
-			// 通过 gRPC 传输安装包到 Agent
+			// Refuse to transfer packages without a verified official checksum
+			// 拒绝传输未通过官方校验和校验的安装包
 			s.installMu.Lock()
-			status.Message = "Transferring package to Agent... / 正在传输安装包到 Agent..."
+			status.Message = "Verifying package checksum... / 正在校验安装包..."
 			s.installMu.Unlock()
+			if err := s.ensurePackageVerified(ctx, req.Version, localPackagePath, req.SkipPackageVerification); err != nil {
+				logger.ErrorF(ctx, "[Installer] 安装包校验失败 / Package verification failed: %v", err)
+				s.installMu.Lock()
+				now := time.Now()
+				status.Status = StepStatusFailed
+				status.Error = fmt.Sprintf("Package verification failed: %v / 安装包校验失败: %v", err, err)
+				status.EndTime = &now
+				s.installMu.Unlock()
+				return
+			}
 
-			remotePath, err := s.transferPackageFileToAgent(ctx, agentID, req.Version, localPackagePath, status)
-			if err != nil {
-				if req.InstallMode == InstallModeOnline {
-					// Transfer failed, fallback to mirror download
-					// 传输失败，回退到镜像源下载
-					logger.WarnF(ctx, "[Installer] 安装包传输失败，回退到镜像源下载 / Package transfer failed, fallback to mirror download: %v", err)
-					// Continue with mirror download mode
-					// 继续使用镜像源下载模式
+			// Let the Agent pull the package from the shared store when presigned downloads are enabled
+			// 启用预签名下载时由 Agent 直接从共享存储拉取安装包
+			if download, ok := s.presignPackageDownload(ctx, localPackagePath); ok {
+				logger.InfoF(ctx, "[Installer] Agent 将从共享存储下载安装包 / Agent downloads package from shared store: agent=%s, version=%s", agentID, req.Version)
+				s.installMu.Lock()
+				status.Message = "Agent is downloading the package from object storage... / Agent 正在从对象存储下载安装包..."
+				s.installMu.Unlock()
+				req.PackageDownload = download
+			} else {
+				// Transfer package to Agent via gRPC
+				// 通过 gRPC 传输安装包到 Agent
+				s.installMu.Lock()
+				status.Message = "Transferring package to Agent... / 正在传输安装包到 Agent..."
+				s.installMu.Unlock()
+
+				remotePath, err := s.transferPackageFileToAgent(ctx, agentID, req.Version, localPackagePath, status)
+				if err != nil {
+					if req.InstallMode == InstallModeOnline {
+						// Transfer failed, fallback to mirror download
+						// 传输失败，回退到镜像源下载
+						logger.WarnF(ctx, "[Installer] 安装包传输失败，回退到镜像源下载 / Package transfer failed, fallback to mirror download: %v", err)
+						// Continue with mirror download mode
+						// 继续使用镜像源下载模式
+					} else {
+						s.installMu.Lock()
+						now := time.Now()
+						status.Status = StepStatusFailed
+						status.Error = fmt.Sprintf("Failed to transfer offline package: %v / 传输离线安装包失败: %v", err, err)
+						status.EndTime = &now
+						s.installMu.Unlock()
+						return
+					}
 				} else {
-					s.installMu.Lock()
-					now := time.Now()
-					status.Status = StepStatusFailed
-					status.Error = fmt.Sprintf("Failed to transfer offline package: %v / 传输离线安装包失败: %v", err, err)
-					status.EndTime = &now
-					s.installMu.Unlock()
-					return
+					// Transfer succeeded, update params to use package on Agent
+					// 传输成功，更新参数使用 Agent 本地安装包
+					logger.InfoF(ctx, "[Installer] 安装包传输成功 / Package transfer succeeded: remote_path=%s", remotePath)
+					req.PackagePath = remotePath
+					s.rememberPreparedPackage(agentID, req.Version, localPackagePath, remotePath)
 				}
-			} else {
-				// Transfer succeeded, update params to use package on Agent
-				// 传输成功，更新参数使用 Agent 本地安装包
-				logger.InfoF(ctx, "[Installer] 安装包传输成功 / Package transfer succeeded: remote_path=%s", remotePath)
-				req.PackagePath = remotePath
-				s.rememberPreparedPackage(agentID, req.Version, localPackagePath, remotePath)
 			}
 		}
 	}
 
 	// Transfer selected plugins to Agent before installation
 	// 在安装之前将选中的插件传输到 Agent
-	if req.Connector != nil && len(req.Connector.SelectedPlugins) > 0 && s.pluginTransferer != nil {
+	if req.Connector != nil && len(req.Connector.SelectedPlugins) > 0 && s.pluginTransferer != nil && !req.stepSkipped(InstallStepInstallPlugins) {
 		s.installMu.Lock()
 		status.Message = "Transferring plugins to Agent... / 正在传输插件到 Agent..."
 		s.installMu.Unlock()
@@ -2205,6 +3077,9 @@ func (s *Service) runInstallation(ctx context.Context, req *InstallationRequest,
 	}
 
 	logger.InfoF(ctx, "[Installer] 安装命令已发送 / Install command sent: host=%d, command=%s", hostID, commandID)
+	s.installMu.Lock()
+	status.CommandID = commandID
+	s.installMu.Unlock()
 
 	// Poll for command status updates
 	// 轮询命令状态更新
@@ -2239,6 +3114,20 @@ func (s *Service) pollInstallationStatus(ctx context.Context, commandID string,
 			}
 
 			s.installMu.Lock()
+			// The final output carries every hook result on its last line
+			// 最终输出的最后一行携带全部钩子结果
+			message, hookResults := splitHookTrailer(message)
+			for _, hookResult := range hookResults {
+				recordHookResult(status, hookResult)
+			}
+			// Hook results reported during a step become a readable step message
+			// 步骤执行中上报的钩子结果转换为可读的步骤消息
+			if idx := strings.Index(message, "] "); idx != -1 {
+				if hookResult, ok := installhook.ParseResult(message[idx+2:]); ok {
+					recordHookResult(status, hookResult)
+					message = message[:idx+2] + describeHookResult(hookResult)
+				}
+			}
 			status.Progress = progress
 			status.Message = message
 			if warningMessage := extractInstallationWarning(message); warningMessage != "" {
@@ -2271,12 +3160,21 @@ func (s *Service) pollInstallationStatus(ctx context.Context, commandID string,
 				// Mark all steps as complete
 				// 将所有步骤标记为完成
 				for j := range status.Steps {
+					if status.Steps[j].Status == StepStatusSkipped {
+						continue
+					}
 					status.Steps[j].Status = StepStatusSuccess
 					status.Steps[j].Progress = 100
 					status.Steps[j].EndTime = &now
 				}
+				if req.SkipNodeStart {
+					status.Message = "Installation completed / 安装完成"
+				}
 				s.installMu.Unlock()
 				logger.InfoF(ctx, "[Installer] 安装成功 / Installation succeeded: command=%s", commandID)
+				if req.SkipNodeStart {
+					return
+				}
 
 				// Start SeaTunnel cluster after installation
 				// 安装完成后启动 SeaTunnel 集群
@@ -2301,6 +3199,15 @@ func (s *Service) pollInstallationStatus(ctx context.Context, commandID string,
 				logger.InfoF(ctx, "[Installer] 安装已取消 / Installation cancelled: command=%s", commandID)
 				return
 
+			case "timeout":
+				now := time.Now()
+				status.Status = StepStatusFailed
+				status.Error = "Installation timed out / 安装超时: " + message
+				status.EndTime = &now
+				s.installMu.Unlock()
+				logger.ErrorF(ctx, "[Installer] 安装超时 / Installation timed out: command=%s, error=%s", commandID, message)
+				return
+
 			case "running":
 				// Status already updated above
 				// 状态已在上面更新
@@ -2458,10 +3365,16 @@ func updateStepStatus(status *InstallationStatus, currentStep string, progress i
 
 	now := time.Now()
 
+	// Skipped steps keep their status
+	// 已跳过的步骤保持其状态
+	if status.Steps[currentIdx].Status == StepStatusSkipped {
+		return
+	}
+
 	// Mark previous steps as complete
 	// 将之前的步骤标记为完成
 	for j := 0; j < currentIdx; j++ {
-		if status.Steps[j].Status != StepStatusSuccess {
+		if status.Steps[j].Status != StepStatusSuccess && status.Steps[j].Status != StepStatusSkipped {
 			status.Steps[j].Status = StepStatusSuccess
 			status.Steps[j].Progress = 100
 			status.Steps[j].EndTime = &now
@@ -2540,6 +3453,11 @@ func buildInstallParams(req *InstallationRequest) map[string]string {
 
 	if req.PackagePath != "" {
 		params["package_path"] = req.PackagePath
+	} else if req.PackageDownload != nil {
+		params["package_url"] = req.PackageDownload.URL
+		params["package_file_name"] = req.PackageDownload.FileName
+		params["package_checksum"] = req.PackageDownload.Checksum
+		params["package_size"] = strconv.FormatInt(req.PackageDownload.Size, 10)
 	}
 
 	// Add cluster configuration / 添加集群配置
@@ -2582,6 +3500,15 @@ func buildInstallParams(req *InstallationRequest) map[string]string {
 	if req.JobLogMode != "" {
 		params["job_log_mode"] = string(req.JobLogMode)
 	}
+	if req.EnableSystemd {
+		params["enable_systemd"] = "true"
+	}
+	if req.AutoInstallJava {
+		params["auto_install_java"] = "true"
+		if req.JavaMirror != "" {
+			params["java_mirror"] = req.JavaMirror
+		}
+	}
 
 	// Add JVM config / 添加 JVM 配置
 	if req.JVM != nil {
@@ -2590,10 +3517,41 @@ func buildInstallParams(req *InstallationRequest) map[string]string {
 		params["jvm_hybrid_heap"] = fmt.Sprintf("%d", req.JVM.HybridHeapSize)
 		params["jvm_master_heap"] = fmt.Sprintf("%d", req.JVM.MasterHeapSize)
 		params["jvm_worker_heap"] = fmt.Sprintf("%d", req.JVM.WorkerHeapSize)
+		if req.JVM.GC != "" {
+			params["jvm_gc"] = req.JVM.GC
+		}
+		if req.JVM.MaxDirectMemoryMB > 0 {
+			params["jvm_max_direct_memory_mb"] = fmt.Sprintf("%d", req.JVM.MaxDirectMemoryMB)
+		}
+		if len(req.JVM.ExtraOptions) > 0 {
+			params["jvm_extra_options"] = strings.Join(req.JVM.ExtraOptions, "\n")
+		}
 	} else {
 		logger.InfoF(context.Background(), "[Installer] JVM config is nil, using defaults")
 	}
 
+	// Add advanced Hazelcast network config / 添加 Hazelcast 高级网络配置
+	hazelcastNetworkParams(req.Hazelcast, params)
+
+	// Add log rotation config / 添加日志滚动配置
+	if req.Log != nil {
+		if req.Log.MaxFileSizeMB > 0 {
+			params["log_max_file_size_mb"] = fmt.Sprintf("%d", req.Log.MaxFileSizeMB)
+		}
+		if req.Log.MaxBackups > 0 {
+			params["log_max_backups"] = fmt.Sprintf("%d", req.Log.MaxBackups)
+		}
+		if req.Log.Compress {
+			params["log_compress"] = "true"
+		}
+		if req.Log.GCLog {
+			params["log_gc"] = "true"
+		}
+		if req.Log.DiskQuotaMB > 0 {
+			params["log_disk_quota_mb"] = fmt.Sprintf("%d", req.Log.DiskQuotaMB)
+		}
+	}
+
 	// Add checkpoint config / 添加检查点配置
 	if req.Checkpoint != nil {
 		params["checkpoint_storage_type"] = string(req.Checkpoint.StorageType)
@@ -2684,6 +3642,19 @@ func buildInstallParams(req *InstallationRequest) map[string]string {
 		}
 	}
 
+	// Add skipped steps and step hooks / 添加跳过的步骤与步骤钩子
+	if len(req.SkipSteps) > 0 {
+		skipSteps := make([]string, 0, len(req.SkipSteps))
+		for _, step := range req.SkipSteps {
+			skipSteps = append(skipSteps, string(step))
+		}
+		params[installhook.ParamSkipSteps] = strings.Join(skipSteps, ",")
+	}
+	if hooks := hooksForRequest(req); len(hooks) > 0 {
+		if encoded, err := installhook.EncodeHooks(hooks); err == nil {
+			params[installhook.ParamHooks] = encoded
+		}
+	}
 	return params
 }
 
@@ -2724,11 +3695,13 @@ func createInitialSteps() []StepInfo {
 		{Step: InstallStepDownload, Name: "download", Description: "Download package / 下载安装包", Status: StepStatusPending, Retryable: true},
 		{Step: InstallStepVerify, Name: "verify", Description: "Verify checksum / 验证校验和", Status: StepStatusPending, Retryable: true},
 		{Step: InstallStepExtract, Name: "extract", Description: "Extract package / 解压安装包", Status: StepStatusPending, Retryable: true},
+		{Step: InstallStepInstallJava, Name: "install_java", Description: "Install Java / 安装 Java", Status: StepStatusPending, Retryable: true},
 		{Step: InstallStepConfigureCluster, Name: "configure_cluster", Description: "Configure cluster / 配置集群", Status: StepStatusPending, Retryable: true},
 		{Step: InstallStepConfigureCheckpoint, Name: "configure_checkpoint", Description: "Configure checkpoint / 配置检查点", Status: StepStatusPending, Retryable: true},
 		{Step: InstallStepConfigureIMAP, Name: "configure_imap", Description: "Configure IMAP / 配置 IMAP", Status: StepStatusPending, Retryable: true},
 		{Step: InstallStepConfigureJVM, Name: "configure_jvm", Description: "Configure JVM / 配置 JVM", Status: StepStatusPending, Retryable: true},
 		{Step: InstallStepInstallPlugins, Name: "install_plugins", Description: "Install plugins / 安装插件", Status: StepStatusPending, Retryable: true},
+		{Step: InstallStepConfigureSystemd, Name: "configure_systemd", Description: "Configure systemd / 配置 systemd", Status: StepStatusPending, Retryable: true},
 		{Step: InstallStepRegisterCluster, Name: "register_cluster", Description: "Register to cluster / 注册到集群", Status: StepStatusPending, Retryable: true},
 		{Step: InstallStepComplete, Name: "complete", Description: "Complete / 完成", Status: StepStatusPending, Retryable: false},
 	}
@@ -2932,14 +3905,52 @@ func calculateChecksum(filePath string) (string, error) {
 
 // ==================== Package Transfer 安装包传输 ====================
 
+// agentPackageCacheResult is the check_package_cache output reported by the Agent.
+// agentPackageCacheResult 是 Agent 返回的 check_package_cache 结果。
+type agentPackageCacheResult struct {
+	Success bool              `json:"success"`
+	Details map[string]string `json:"details"`
+}
+
+// lookupAgentPackageCache asks the Agent whether it already caches a verified copy of the package.
+// Any error, including Agents that do not support the cache, is treated as a cache miss.
+// lookupAgentPackageCache 询问 Agent 是否已缓存经过校验的安装包副本。
+// 任何错误（包括 Agent 不支持缓存）都视为未命中。
+func (s *Service) lookupAgentPackageCache(ctx context.Context, agentID, version, fileName, checksum string, totalSize int64) (string, bool) {
+	success, output, err := s.agentManager.SendCommand(ctx, agentID, "check_package_cache", map[string]string{
+		"sub_command": "check_package_cache",
+		"version":     version,
+		"file_name":   fileName,
+		"checksum":    checksum,
+		"total_size":  strconv.FormatInt(totalSize, 10),
+	})
+	if err != nil || !success {
+		return "", false
+	}
+
+	var result agentPackageCacheResult
+	if err := json.Unmarshal([]byte(output), &result); err != nil {
+		return "", false
+	}
+	path := strings.TrimSpace(result.Details["path"])
+	if !result.Success || result.Details["cached"] != "true" || path == "" {
+		return "", false
+	}
+	return path, true
+}
+
 // PackageTransferChunkSize is the size of each chunk for package transfer (1MB)
 // PackageTransferChunkSize 是安装包传输每个块的大小（1MB）
 const PackageTransferChunkSize = 1024 * 1024
 
-// TransferPackageToAgent transfers a package to an Agent via gRPC
-// TransferPackageToAgent 通过 gRPC 将安装包传输到 Agent
+// TransferPackageToAgent verifies a package against its official SHA-512 and transfers it to an Agent via gRPC
+// TransferPackageToAgent 校验安装包官方 SHA-512 后通过 gRPC 将安装包传输到 Agent
 func (s *Service) TransferPackageToAgent(ctx context.Context, agentID string, version string, status *InstallationStatus) (remotePath string, err error) {
 	localPath := filepath.Join(s.packageDir, packageFileName(version))
+	s.hydratePackageFromStore(ctx, localPath)
+	if err := s.ensurePackageVerified(ctx, version, localPath, false); err != nil {
+		return "", err
+	}
 	return s.transferPackageFileToAgent(ctx, agentID, version, localPath, status)
 }
 
@@ -2957,11 +3968,49 @@ func (s *Service) transferPackageFileToAgent(ctx context.Context, agentID string
 	totalSize := fileInfo.Size()
 
 	// Calculate checksum / 计算校验和
-	checksum, err := calculateChecksum(localPath)
+	checksum, err := packageChecksum(localPath)
 	if err != nil {
 		return "", fmt.Errorf("failed to calculate checksum: %w / 计算校验和失败: %w", err, err)
 	}
 
+	// Skip the transfer when the Agent already caches a verified copy
+	// Agent 已缓存经过校验的副本时跳过传输
+	if cachedPath, ok := s.lookupAgentPackageCache(ctx, agentID, version, fileName, checksum, totalSize); ok {
+		logger.InfoF(ctx, "[Installer] Agent 已缓存安装包，跳过传输 / Agent already caches package, skipping transfer: agent=%s, version=%s, remote_path=%s",
+			agentID, version, cachedPath)
+		if status != nil {
+			s.installMu.Lock()
+			status.Message = "Reusing package cached on Agent... / 复用 Agent 缓存的安装包..."
+			s.installMu.Unlock()
+		}
+		return cachedPath, nil
+	}
+
+	// Wait for a free transfer slot so concurrent installations do not saturate disk and network
+	// 等待空闲传输名额，避免并发安装占满磁盘与网络
+	release, err := s.admitPackageTransfer(ctx, status)
+	if err != nil {
+		return "", fmt.Errorf("failed to wait for a package transfer slot: %w / 等待安装包传输名额失败: %w", err, err)
+	}
+	defer release()
+
+	// Stream raw bytes through FetchFile when the Agent supports it
+	// Agent 支持时通过 FetchFile 流式传输原始字节
+	if streamer, ok := s.agentManager.(PackageStreamer); ok {
+		remotePath, err := streamer.StreamPackageToAgent(ctx, agentID, version, fileName, localPath, checksum, totalSize, func(snapshot filestream.Snapshot) {
+			s.updateTransferStatus(status, snapshot)
+		})
+		if err == nil {
+			logger.InfoF(ctx, "[Installer] 安装包流式传输完成 / Package streamed: agent=%s, version=%s, remote_path=%s",
+				agentID, version, remotePath)
+			return remotePath, nil
+		}
+		if !errors.Is(err, filestream.ErrUnsupported) {
+			return "", fmt.Errorf("failed to stream package: %w / 流式传输安装包失败: %w", err, err)
+		}
+		logger.InfoF(ctx, "[Installer] Agent 不支持流式传输，改用分块传输 / Agent does not support streaming, using chunked transfer: agent=%s", agentID)
+	}
+
 	// Open file / 打开文件
 	file, err := os.Open(localPath)
 	if err != nil {
@@ -2970,6 +4019,7 @@ func (s *Service) transferPackageFileToAgent(ctx context.Context, agentID string
 	defer file.Close()
 
 	// Transfer in chunks / 分块传输
+	progress := filestream.NewProgress(totalSize)
 	buf := make([]byte, PackageTransferChunkSize)
 	var offset int64
 	var lastReceivedBytes int64
@@ -3013,12 +4063,7 @@ func (s *Service) transferPackageFileToAgent(ctx context.Context, agentID string
 		lastReceivedBytes = receivedBytes
 
 		// Update status / 更新状态
-		if status != nil {
-			s.installMu.Lock()
-			progress := int(float64(offset) / float64(totalSize) * 100)
-			status.Message = fmt.Sprintf("Transferring package... %d%% / 正在传输安装包... %d%%", progress, progress)
-			s.installMu.Unlock()
-		}
+		s.updateTransferStatus(status, progress.Update(offset))
 
 		// If last chunk, get the remote path / 如果是最后一块，获取远程路径
 		if isLast {
@@ -3032,3 +4077,17 @@ func (s *Service) transferPackageFileToAgent(ctx context.Context, agentID string
 
 	return remotePath, nil
 }
+
+// updateTransferStatus records transfer throughput and ETA on the installation status
+// updateTransferStatus 在安装状态上记录传输吞吐量和预计剩余时间
+func (s *Service) updateTransferStatus(status *InstallationStatus, snapshot filestream.Snapshot) {
+	if status == nil {
+		return
+	}
+	s.installMu.Lock()
+	defer s.installMu.Unlock()
+	status.Transfer = &snapshot
+	speed := float64(snapshot.BytesPerSecond) / (1024 * 1024)
+	status.Message = fmt.Sprintf("Transferring package... %d%% (%.1f MB/s, ETA %ds) / 正在传输安装包... %d%%（%.1f MB/s，剩余 %d 秒）",
+		snapshot.Percent, speed, snapshot.ETASeconds, snapshot.Percent, speed, snapshot.ETASeconds)
+}
@@ -25,6 +25,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -202,6 +203,29 @@ func TestBuildInstallParamsIncludesRuntimeConfig(t *testing.T) {
 	}
 }
 
+func TestBuildInstallParamsIncludesLogConfig(t *testing.T) {
+	params := buildInstallParams(&InstallationRequest{
+		Version:     "2.3.9",
+		InstallMode: InstallModeOnline,
+		Log:         &LogConfig{MaxFileSizeMB: 200, MaxBackups: 7, Compress: true, DiskQuotaMB: 4096},
+	})
+
+	expected := map[string]string{
+		"log_max_file_size_mb": "200",
+		"log_max_backups":      "7",
+		"log_compress":         "true",
+		"log_disk_quota_mb":    "4096",
+	}
+	for key, want := range expected {
+		if params[key] != want {
+			t.Fatalf("expected %s=%s, got %q", key, want, params[key])
+		}
+	}
+	if _, ok := params["log_gc"]; ok {
+		t.Fatalf("expected log_gc to be omitted, got %q", params["log_gc"])
+	}
+}
+
 // TestFallbackVersions tests that fallback versions are used when fetch fails
 // TestFallbackVersions 测试当获取失败时使用备用版本
 func TestFallbackVersions(t *testing.T) {
@@ -388,7 +412,7 @@ func TestService_UploadPackageValidation(t *testing.T) {
 	})
 
 	t.Run("invalid extension is rejected", func(t *testing.T) {
-		fileHeader := createUploadFileHeader(t, "file", "apache-seatunnel-2.3.12-bin.zip", []byte("test-data"))
+		fileHeader := createUploadFileHeader(t, "file", "apache-seatunnel-2.3.12-bin.rar", []byte("test-data"))
 
 		_, err := service.UploadPackage(ctx, "2.3.12", fileHeader)
 		if err == nil || !errors.Is(err, ErrInvalidPackageFile) {
@@ -397,12 +421,12 @@ func TestService_UploadPackageValidation(t *testing.T) {
 	})
 
 	t.Run("duplicate version is rejected", func(t *testing.T) {
-		fileHeader1 := createUploadFileHeader(t, "file", "apache-seatunnel-2.3.12-bin.tar.gz", []byte("test-data-1"))
+		fileHeader1 := createUploadFileHeader(t, "file", "apache-seatunnel-2.3.12-bin.tar.gz", buildSeaTunnelTarGz(t, "apache-seatunnel-2.3.12", nil))
 		if _, err := service.UploadPackage(ctx, "2.3.12", fileHeader1); err != nil {
 			t.Fatalf("first upload should succeed, got error: %v", err)
 		}
 
-		fileHeader2 := createUploadFileHeader(t, "file", "apache-seatunnel-2.3.12-bin.tar.gz", []byte("test-data-2"))
+		fileHeader2 := createUploadFileHeader(t, "file", "apache-seatunnel-2.3.12-bin.tar.gz", buildSeaTunnelTarGz(t, "apache-seatunnel-2.3.12", nil))
 		_, err := service.UploadPackage(ctx, "2.3.12", fileHeader2)
 		if err == nil || !errors.Is(err, ErrPackageAlreadyExists) {
 			t.Fatalf("expected ErrPackageAlreadyExists, got: %v", err)
@@ -430,8 +454,8 @@ func TestService_UploadPackageChunk_Success(t *testing.T) {
 
 	version := "2.3.12"
 	fileName := "apache-seatunnel-2.3.12-bin.tar.gz"
-	content := []byte("chunk-upload-integration-test-content-1234567890")
-	chunkSize := 10
+	content := buildSeaTunnelTarGz(t, "apache-seatunnel-2.3.12", nil)
+	chunkSize := 64
 	totalChunks := (len(content) + chunkSize - 1) / chunkSize
 	uploadID := "upload_chunk_12345678"
 
@@ -534,3 +558,60 @@ func TestService_CancelDownload_RejectsInvalidVersion(t *testing.T) {
 		t.Fatalf("expected ErrInvalidPackageVersion, got: %v", err)
 	}
 }
+
+func TestService_transferPackageFileToAgent_ReusesAgentCache(t *testing.T) {
+	packagePath := filepath.Join(t.TempDir(), packageFileName("2.3.12"))
+	if err := os.WriteFile(packagePath, []byte("package"), 0644); err != nil {
+		t.Fatalf("failed to write package: %v", err)
+	}
+
+	agentManager := &dryRunAgentManager{
+		output: `{"success":true,"details":{"cached":"true","path":"/var/lib/seatunnelx-agent/packages/2.3.12/abc/apache-seatunnel-2.3.12-bin.tar.gz"}}`,
+	}
+	service := NewService(t.TempDir(), agentManager)
+
+	remotePath, err := service.transferPackageFileToAgent(context.Background(), "agent-1", "2.3.12", packagePath, nil)
+	if err != nil {
+		t.Fatalf("expected cached package to be reused, got %v", err)
+	}
+	if remotePath != "/var/lib/seatunnelx-agent/packages/2.3.12/abc/apache-seatunnel-2.3.12-bin.tar.gz" {
+		t.Fatalf("unexpected remote path: %s", remotePath)
+	}
+	if agentManager.params["sub_command"] != "check_package_cache" || agentManager.params["checksum"] == "" {
+		t.Fatalf("expected cache lookup with checksum, got %v", agentManager.params)
+	}
+
+	agentManager.output = `{"success":true,"details":{"cached":"false"}}`
+	if _, err := service.transferPackageFileToAgent(context.Background(), "agent-1", "2.3.12", packagePath, nil); err == nil {
+		t.Fatalf("expected cache miss to fall back to chunk transfer")
+	}
+}
+
+type maintenanceHostProvider struct {
+	maintenance bool
+}
+
+func (p maintenanceHostProvider) GetHostByID(ctx context.Context, hostID uint) (*HostInfo, error) {
+	return &HostInfo{ID: hostID, Name: "node-1", AgentID: "agent-1", Maintenance: p.maintenance}, nil
+}
+
+// TestStartInstallationRejectsHostUnderMaintenance verifies new installations are blocked during host maintenance.
+// TestStartInstallationRejectsHostUnderMaintenance 验证主机维护期间禁止发起新的安装。
+func TestStartInstallationRejectsHostUnderMaintenance(t *testing.T) {
+	service := NewService(t.TempDir(), &dryRunAgentManager{})
+	service.SetHostProvider(maintenanceHostProvider{maintenance: true})
+
+	_, err := service.StartInstallation(context.Background(), &InstallationRequest{
+		HostID:         "3",
+		Version:        "2.3.12",
+		InstallMode:    InstallModeOffline,
+		DeploymentMode: DeploymentModeHybrid,
+		NodeRole:       NodeRoleMasterWorker,
+	})
+	if !errors.Is(err, ErrHostUnderMaintenance) {
+		t.Fatalf("expected ErrHostUnderMaintenance, got %v", err)
+	}
+	if _, err := service.GetInstallationStatus(context.Background(), 3); !errors.Is(err, ErrInstallationNotFound) {
+		t.Fatalf("expected rejected installation not to be tracked, got %v", err)
+	}
+}
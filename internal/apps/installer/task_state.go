@@ -0,0 +1,370 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package installer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/seatunnel/seatunnelX/internal/logger"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Installations and downloads run on the replica that started them. That replica mirrors their status
+// into installer_task_states so every replica can serve status reads, and picks up cancellations
+// other replicas record there.
+// 安装与下载在发起它们的副本上执行。该副本将其状态同步到 installer_task_states，使任一副本都能返回状态；
+// 其他副本记录在表中的取消请求也由该副本执行。
+
+// TaskStateKind distinguishes installation and download records.
+// TaskStateKind 区分安装记录与下载记录。
+type TaskStateKind string
+
+const (
+	TaskStateInstallation TaskStateKind = "installation"
+	TaskStateDownload     TaskStateKind = "download"
+)
+
+const (
+	// DefaultTaskStateSyncInterval is how often a replica mirrors its installations and downloads.
+	// DefaultTaskStateSyncInterval 是副本同步其安装与下载状态的间隔。
+	DefaultTaskStateSyncInterval = time.Second
+
+	// taskStateStaleAfter is how many sync intervals an active record survives without its owner touching it.
+	// taskStateStaleAfter 是活动记录在持有副本未刷新时保持有效的同步间隔数。
+	taskStateStaleAfter = 10
+)
+
+// TaskState is the shared status of one installation (keyed by host ID) or download (keyed by version).
+// TaskState 是一次安装（以主机 ID 为键）或下载（以版本为键）的共享状态。
+type TaskState struct {
+	Kind            TaskStateKind `json:"kind" gorm:"primaryKey;size:20"`
+	Key             string        `json:"key" gorm:"column:task_key;primaryKey;size:64"`
+	InstanceID      string        `json:"instance_id" gorm:"size:128;index"`
+	Active          bool          `json:"active"`
+	Payload         string        `json:"-" gorm:"type:text"`
+	CancelRequested bool          `json:"cancel_requested"`
+	UpdatedAt       time.Time     `json:"updated_at" gorm:"index"`
+}
+
+// TableName specifies the task state table name.
+// TableName 指定任务状态表名。
+func (TaskState) TableName() string {
+	return "installer_task_states"
+}
+
+// TaskStateRepository persists installation and download status shared by all replicas.
+// TaskStateRepository 持久化所有副本共享的安装与下载状态。
+type TaskStateRepository struct {
+	db *gorm.DB
+}
+
+// NewTaskStateRepository creates a new TaskStateRepository instance.
+// NewTaskStateRepository 创建一个新的 TaskStateRepository 实例。
+func NewTaskStateRepository(db *gorm.DB) *TaskStateRepository {
+	return &TaskStateRepository{db: db}
+}
+
+// Save creates or replaces a record; a pending cancellation survives the update.
+// Save 创建或覆盖记录；未处理的取消请求在更新后保留。
+func (r *TaskStateRepository) Save(ctx context.Context, state *TaskState) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "kind"}, {Name: "task_key"}},
+		DoUpdates: clause.AssignmentColumns([]string{"instance_id", "active", "payload", "updated_at"}),
+	}).Create(state).Error
+}
+
+// Touch refreshes the active records of instanceID so peers do not treat them as stale.
+// Touch 刷新 instanceID 的活动记录，避免其他副本将其视为过期。
+func (r *TaskStateRepository) Touch(ctx context.Context, instanceID string, now time.Time) error {
+	return r.db.WithContext(ctx).Model(&TaskState{}).
+		Where("instance_id = ? AND active = ?", instanceID, true).
+		Update("updated_at", now).Error
+}
+
+// Get returns one record, or nil when it does not exist.
+// Get 返回一条记录，不存在时返回 nil。
+func (r *TaskStateRepository) Get(ctx context.Context, kind TaskStateKind, key string) (*TaskState, error) {
+	var state TaskState
+	err := r.db.WithContext(ctx).Where("kind = ? AND task_key = ?", kind, key).First(&state).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
+
+// List returns all records of a kind.
+// List 返回某类的所有记录。
+func (r *TaskStateRepository) List(ctx context.Context, kind TaskStateKind) ([]*TaskState, error) {
+	var states []*TaskState
+	err := r.db.WithContext(ctx).Where("kind = ?", kind).Order("task_key").Find(&states).Error
+	return states, err
+}
+
+// RequestCancel flags an active record for cancellation by its owner. It reports whether a record was flagged.
+// RequestCancel 标记活动记录由其持有副本取消，返回是否有记录被标记。
+func (r *TaskStateRepository) RequestCancel(ctx context.Context, kind TaskStateKind, key string) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&TaskState{}).
+		Where("kind = ? AND task_key = ? AND active = ?", kind, key, true).
+		Update("cancel_requested", true)
+	return result.RowsAffected > 0, result.Error
+}
+
+// TakeCancelRequests returns the records of instanceID flagged for cancellation and clears the flags.
+// TakeCancelRequests 返回 instanceID 中被标记取消的记录并清除标记。
+func (r *TaskStateRepository) TakeCancelRequests(ctx context.Context, instanceID string) ([]*TaskState, error) {
+	var states []*TaskState
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("instance_id = ? AND cancel_requested = ?", instanceID, true).Find(&states).Error; err != nil {
+			return err
+		}
+		if len(states) == 0 {
+			return nil
+		}
+		return tx.Model(&TaskState{}).
+			Where("instance_id = ? AND cancel_requested = ?", instanceID, true).
+			Update("cancel_requested", false).Error
+	})
+	return states, err
+}
+
+// SetTaskStateStore shares installation and download status with other replicas through repo.
+// SetTaskStateStore 通过 repo 与其他副本共享安装与下载状态。
+func (s *Service) SetTaskStateStore(repo *TaskStateRepository, instanceID string) {
+	s.concurrencyMu.Lock()
+	s.instanceID = instanceID
+	s.concurrencyMu.Unlock()
+	s.taskStateRepo = repo
+	s.taskStateInterval = DefaultTaskStateSyncInterval
+}
+
+// StartTaskStateSync mirrors local installations and downloads into the shared store until ctx is done.
+// StartTaskStateSync 将本地安装与下载状态同步到共享存储，直到 ctx 结束。
+func (s *Service) StartTaskStateSync(ctx context.Context, interval time.Duration) {
+	if s.taskStateRepo == nil {
+		return
+	}
+	if interval <= 0 {
+		interval = DefaultTaskStateSyncInterval
+	}
+	s.taskStateInterval = interval
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.syncTaskStates(ctx)
+			}
+		}
+	}()
+}
+
+// syncTaskStates writes changed records, refreshes active ones and applies cancellations requested by peers.
+// syncTaskStates 写入有变化的记录，刷新活动记录，并执行其他副本请求的取消。
+func (s *Service) syncTaskStates(ctx context.Context) {
+	now := time.Now()
+	for _, state := range s.collectTaskStates(now) {
+		if err := s.taskStateRepo.Save(ctx, state); err != nil {
+			logger.WarnF(ctx, "[Installer] 同步任务状态失败 / failed to sync task state %s/%s: %v", state.Kind, state.Key, err)
+			continue
+		}
+		s.taskStateMu.Lock()
+		s.syncedTaskStates[string(state.Kind)+"/"+state.Key] = state.Payload
+		s.taskStateMu.Unlock()
+	}
+	if err := s.taskStateRepo.Touch(ctx, s.instanceID, now); err != nil {
+		logger.WarnF(ctx, "[Installer] 刷新任务状态失败 / failed to refresh task states: %v", err)
+	}
+
+	requests, err := s.taskStateRepo.TakeCancelRequests(ctx, s.instanceID)
+	if err != nil {
+		logger.WarnF(ctx, "[Installer] 读取取消请求失败 / failed to read cancel requests: %v", err)
+		return
+	}
+	for _, req := range requests {
+		switch req.Kind {
+		case TaskStateInstallation:
+			var hostID uint
+			if _, err := fmt.Sscanf(req.Key, "%d", &hostID); err == nil {
+				_, err = s.cancelLocalInstallation(ctx, hostID)
+				if err != nil && !errors.Is(err, ErrInstallationNotFound) {
+					logger.WarnF(ctx, "[Installer] 取消安装失败 / failed to cancel installation of host %s: %v", req.Key, err)
+				}
+			}
+		case TaskStateDownload:
+			if _, err := s.cancelLocalDownload(ctx, req.Key); err != nil && !errors.Is(err, ErrDownloadNotFound) {
+				logger.WarnF(ctx, "[Installer] 取消下载失败 / failed to cancel download %s: %v", req.Key, err)
+			}
+		}
+	}
+}
+
+// collectTaskStates returns the local records whose payload changed since the last sync.
+// collectTaskStates 返回自上次同步以来内容有变化的本地记录。
+func (s *Service) collectTaskStates(now time.Time) []*TaskState {
+	var states []*TaskState
+	add := func(kind TaskStateKind, key string, active bool, v interface{}) {
+		payload, err := json.Marshal(v)
+		if err != nil {
+			return
+		}
+		states = append(states, &TaskState{
+			Kind:       kind,
+			Key:        key,
+			InstanceID: s.instanceID,
+			Active:     active,
+			Payload:    string(payload),
+			UpdatedAt:  now,
+		})
+	}
+
+	s.installMu.RLock()
+	for key, status := range s.installations {
+		add(TaskStateInstallation, key, status.Status == StepStatusRunning, status)
+	}
+	s.installMu.RUnlock()
+
+	s.downloadsMu.RLock()
+	for key, task := range s.downloads {
+		add(TaskStateDownload, key, isDownloadActive(task.Status), task)
+	}
+	s.downloadsMu.RUnlock()
+
+	s.taskStateMu.Lock()
+	defer s.taskStateMu.Unlock()
+	changed := states[:0]
+	for _, state := range states {
+		if s.syncedTaskStates[string(state.Kind)+"/"+state.Key] != state.Payload {
+			changed = append(changed, state)
+		}
+	}
+	return changed
+}
+
+// isStale reports whether an active record was left behind by a replica that stopped syncing.
+// isStale 判断活动记录是否由已停止同步的副本遗留。
+func (s *Service) isStale(state *TaskState) bool {
+	return state.Active && time.Since(state.UpdatedAt) > taskStateStaleAfter*s.taskStateInterval
+}
+
+// remoteTaskState returns the shared record of an installation or download owned by another replica.
+// remoteTaskState 返回由其他副本持有的安装或下载的共享记录。
+func (s *Service) remoteTaskState(ctx context.Context, kind TaskStateKind, key string) (*TaskState, error) {
+	if s.taskStateRepo == nil {
+		return nil, nil
+	}
+	state, err := s.taskStateRepo.Get(ctx, kind, key)
+	if err != nil || state == nil || state.InstanceID == s.instanceID {
+		return nil, err
+	}
+	return state, nil
+}
+
+// remoteInstallation returns the installation of hostID running on another replica.
+// remoteInstallation 返回在其他副本上运行的 hostID 的安装。
+func (s *Service) remoteInstallation(ctx context.Context, hostID string) (*InstallationStatus, *TaskState, error) {
+	state, err := s.remoteTaskState(ctx, TaskStateInstallation, hostID)
+	if err != nil || state == nil {
+		return nil, nil, err
+	}
+	var status InstallationStatus
+	if err := json.Unmarshal([]byte(state.Payload), &status); err != nil {
+		return nil, nil, err
+	}
+	s.markAbandoned(state, &status.Status, &status.Error)
+	return &status, state, nil
+}
+
+// remoteDownload returns the download of version running on another replica.
+// remoteDownload 返回在其他副本上运行的 version 的下载。
+func (s *Service) remoteDownload(ctx context.Context, version string) (*DownloadTask, *TaskState, error) {
+	state, err := s.remoteTaskState(ctx, TaskStateDownload, version)
+	if err != nil || state == nil {
+		return nil, nil, err
+	}
+	task, err := decodeDownloadState(state)
+	if err != nil {
+		return nil, nil, err
+	}
+	s.markAbandonedDownload(state, task)
+	return task, state, nil
+}
+
+// remoteDownloads returns the downloads of other replicas, keyed by version.
+// remoteDownloads 返回其他副本的下载，以版本为键。
+func (s *Service) remoteDownloads(ctx context.Context) map[string]*DownloadTask {
+	if s.taskStateRepo == nil {
+		return nil
+	}
+	states, err := s.taskStateRepo.List(ctx, TaskStateDownload)
+	if err != nil {
+		logger.WarnF(ctx, "[Installer] 读取共享下载状态失败 / failed to list shared downloads: %v", err)
+		return nil
+	}
+	tasks := make(map[string]*DownloadTask, len(states))
+	for _, state := range states {
+		if state.InstanceID == s.instanceID {
+			continue
+		}
+		if task, err := decodeDownloadState(state); err == nil {
+			s.markAbandonedDownload(state, task)
+			tasks[state.Key] = task
+		}
+	}
+	return tasks
+}
+
+func decodeDownloadState(state *TaskState) (*DownloadTask, error) {
+	var task DownloadTask
+	if err := json.Unmarshal([]byte(state.Payload), &task); err != nil {
+		return nil, err
+	}
+	return &task, nil
+}
+
+// markAbandoned reports a running installation whose replica stopped syncing as failed.
+// markAbandoned 将所属副本已停止同步的运行中安装报告为失败。
+func (s *Service) markAbandoned(state *TaskState, status *StepStatus, errMsg *string) {
+	if s.isStale(state) {
+		*status = StepStatusFailed
+		*errMsg = errTaskStateAbandoned(state.InstanceID)
+	}
+}
+
+// markAbandonedDownload reports an active download whose replica stopped syncing as failed.
+// markAbandonedDownload 将所属副本已停止同步的下载报告为失败。
+func (s *Service) markAbandonedDownload(state *TaskState, task *DownloadTask) {
+	if s.isStale(state) {
+		task.Status = DownloadStatusFailed
+		task.Error = errTaskStateAbandoned(state.InstanceID)
+	}
+}
+
+func errTaskStateAbandoned(instanceID string) string {
+	return fmt.Sprintf("control plane instance %s stopped reporting / 执行该任务的控制面副本 %s 已停止上报", instanceID, instanceID)
+}
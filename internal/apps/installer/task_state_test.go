@@ -0,0 +1,125 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package installer
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// newTaskStateReplicas returns two services sharing one task state table, like two Control Plane replicas.
+// newTaskStateReplicas 返回共享同一任务状态表的两个服务，模拟两个控制面副本。
+func newTaskStateReplicas(t *testing.T) (*Service, *Service, *TaskStateRepository) {
+	t.Helper()
+
+	database, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "test.db")), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	if err := database.AutoMigrate(&TaskState{}); err != nil {
+		t.Fatalf("failed to migrate database: %v", err)
+	}
+	repo := NewTaskStateRepository(database)
+
+	owner := NewService(t.TempDir(), nil)
+	owner.SetTaskStateStore(repo, "cp-1")
+	peer := NewService(t.TempDir(), nil)
+	peer.SetTaskStateStore(repo, "cp-2")
+	return owner, peer, repo
+}
+
+func TestTaskStateSharesInstallationsAcrossReplicas(t *testing.T) {
+	owner, peer, _ := newTaskStateReplicas(t)
+	ctx := context.Background()
+
+	if _, err := peer.GetInstallationStatus(ctx, 7); !errors.Is(err, ErrInstallationNotFound) {
+		t.Fatalf("expected ErrInstallationNotFound before sync, got %v", err)
+	}
+
+	owner.installMu.Lock()
+	owner.installations["7"] = &InstallationStatus{ID: "install-7", HostID: "7", Status: StepStatusRunning, Progress: 40}
+	owner.installMu.Unlock()
+	owner.syncTaskStates(ctx)
+
+	status, err := peer.GetInstallationStatus(ctx, 7)
+	if err != nil || status.ID != "install-7" || status.Progress != 40 {
+		t.Fatalf("expected the peer to read the shared installation, got %+v (%v)", status, err)
+	}
+	if _, err := peer.StartInstallation(ctx, &InstallationRequest{HostID: "7", Version: "2.3.12"}); !errors.Is(err, ErrInstallationInProgress) {
+		t.Fatalf("expected ErrInstallationInProgress on the peer, got %v", err)
+	}
+
+	// The peer records the cancellation; the owner applies it on its next sync
+	// 对端副本记录取消请求；持有副本在下次同步时执行
+	if _, err := peer.CancelInstallation(ctx, 7); err != nil {
+		t.Fatalf("CancelInstallation on the peer returned error: %v", err)
+	}
+	owner.syncTaskStates(ctx)
+	owner.installMu.RLock()
+	cancelled := owner.installations["7"].Status
+	owner.installMu.RUnlock()
+	if cancelled != StepStatusFailed {
+		t.Fatalf("expected the owner to cancel the installation, got status %s", cancelled)
+	}
+
+	owner.syncTaskStates(ctx)
+	status, err = peer.GetInstallationStatus(ctx, 7)
+	if err != nil || status.Status != StepStatusFailed {
+		t.Fatalf("expected the peer to see the cancelled installation, got %+v (%v)", status, err)
+	}
+}
+
+func TestTaskStateSharesDownloadsAcrossReplicas(t *testing.T) {
+	owner, peer, repo := newTaskStateReplicas(t)
+	ctx := context.Background()
+
+	owner.downloadsMu.Lock()
+	owner.downloads["2.3.12"] = &DownloadTask{ID: "dl-1", Version: "2.3.12", Status: DownloadStatusDownloading, Progress: 10}
+	owner.downloadsMu.Unlock()
+	owner.syncTaskStates(ctx)
+
+	task, err := peer.GetDownloadStatus(ctx, "2.3.12")
+	if err != nil || task.ID != "dl-1" {
+		t.Fatalf("expected the peer to read the shared download, got %+v (%v)", task, err)
+	}
+	if tasks := peer.ListDownloads(ctx); len(tasks) != 1 || tasks[0].ID != "dl-1" {
+		t.Fatalf("expected the peer to list the shared download, got %+v", tasks)
+	}
+	if tasks := owner.ListDownloads(ctx); len(tasks) != 1 {
+		t.Fatalf("expected the owner to list its download once, got %+v", tasks)
+	}
+
+	// A download whose replica stopped syncing is reported as failed
+	// 所属副本已停止同步的下载报告为失败
+	if err := repo.Touch(ctx, "cp-1", time.Now().Add(-time.Hour)); err != nil {
+		t.Fatalf("Touch returned error: %v", err)
+	}
+	task, err = peer.GetDownloadStatus(ctx, "2.3.12")
+	if err != nil || task.Status != DownloadStatusFailed {
+		t.Fatalf("expected an abandoned download to be reported as failed, got %+v (%v)", task, err)
+	}
+}
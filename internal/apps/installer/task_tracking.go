@@ -0,0 +1,199 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package installer
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/seatunnel/seatunnelX/internal/apps/task"
+	"github.com/seatunnel/seatunnelX/internal/pkg/tracex"
+)
+
+// installationSyncInterval is how often installation status is mirrored into its task.
+// installationSyncInterval 是安装状态同步到任务的间隔。
+const installationSyncInterval = time.Second
+
+// installationTaskPayload is the typed payload recorded on installation tasks.
+// It deliberately leaves out storage and connector settings, which may carry credentials.
+// installationTaskPayload 是记录在安装任务上的类型化负载。
+// 其中刻意不包含可能带有凭证的存储和连接器配置。
+type installationTaskPayload struct {
+	HostID         string         `json:"host_id"`
+	ClusterID      string         `json:"cluster_id,omitempty"`
+	Version        string         `json:"version"`
+	InstallDir     string         `json:"install_dir,omitempty"`
+	InstallMode    InstallMode    `json:"install_mode"`
+	DeploymentMode DeploymentMode `json:"deployment_mode,omitempty"`
+	NodeRole       NodeRole       `json:"node_role,omitempty"`
+	TemplateID     uint           `json:"template_id,omitempty"`
+}
+
+// SetTaskManager sets the task manager used to track installations as tasks.
+// SetTaskManager 设置用于将安装作为任务跟踪的任务管理器。
+func (s *Service) SetTaskManager(manager *task.Manager) {
+	s.taskManager = manager
+}
+
+// submitInstallationTask runs the installation as an asynchronous task.
+// submitInstallationTask 将安装作为异步任务运行。
+func (s *Service) submitInstallationTask(ctx context.Context, req *InstallationRequest, status *InstallationStatus) (*task.Task, error) {
+	params, err := task.ParamsFrom(installationTaskPayload{
+		HostID:         req.HostID,
+		ClusterID:      strings.TrimSpace(req.ClusterID),
+		Version:        req.Version,
+		InstallDir:     req.InstallDir,
+		InstallMode:    req.InstallMode,
+		DeploymentMode: req.DeploymentMode,
+		NodeRole:       req.NodeRole,
+		TemplateID:     req.TemplateID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	hostID, _ := strconv.ParseUint(strings.TrimSpace(req.HostID), 10, 64)
+	clusterID, _ := strconv.ParseUint(strings.TrimSpace(req.ClusterID), 10, 64)
+	return s.taskManager.Submit(ctx, &task.CreateTaskRequest{
+		Type:      task.TaskTypeInstall,
+		HostID:    uint(hostID),
+		ClusterID: uint(clusterID),
+		Params:    params,
+		Source:    task.TaskSourceInstaller,
+	}, "", s.installationRunner(ctx, req, status))
+}
+
+// installationRunner returns the task runner of an installation. The first run uses the
+// status registered by StartInstallation; a retry registers a fresh installation for the host.
+// installationRunner 返回安装的任务执行器。首次执行使用 StartInstallation 注册的状态；
+// 重试时为该主机注册新的安装。
+// Runs stay in the trace of parent, the request that submitted the task.
+// 每次执行都沿用 parent（提交任务的请求）的追踪链路。
+func (s *Service) installationRunner(parent context.Context, req *InstallationRequest, initial *InstallationStatus) task.Runner {
+	var claimed sync.Once
+	return func(ctx context.Context, t *task.Task, reporter *task.Reporter) (map[string]interface{}, error) {
+		var status *InstallationStatus
+		claimed.Do(func() { status = initial })
+		if status == nil {
+			var err error
+			if status, err = s.restartInstallation(req, t.ID); err != nil {
+				return nil, err
+			}
+		}
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			s.runInstallationAndNotify(tracex.ContinueFrom(context.Background(), parent), req, status)
+		}()
+
+		hostID, _ := strconv.ParseUint(strings.TrimSpace(req.HostID), 10, 64)
+		ticker := time.NewTicker(installationSyncInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return s.finishInstallationTask(status, reporter)
+			case <-ticker.C:
+				s.syncInstallationTask(status, reporter)
+			case <-ctx.Done():
+				_, _ = s.CancelInstallation(context.Background(), uint(hostID))
+				return nil, ctx.Err()
+			}
+		}
+	}
+}
+
+// restartInstallation registers a new installation for a retried task.
+// restartInstallation 为重试的任务注册新的安装。
+func (s *Service) restartInstallation(req *InstallationRequest, taskID string) (*InstallationStatus, error) {
+	s.installMu.Lock()
+	defer s.installMu.Unlock()
+
+	if existing, ok := s.installations[req.HostID]; ok && existing.Status == StepStatusRunning {
+		return nil, ErrInstallationInProgress
+	}
+	status := newInstallationStatus(req)
+	status.TaskID = taskID
+	s.installations[req.HostID] = status
+	return status, nil
+}
+
+// syncInstallationTask mirrors installation steps and progress into the task.
+// syncInstallationTask 将安装步骤和进度同步到任务。
+func (s *Service) syncInstallationTask(status *InstallationStatus, reporter *task.Reporter) {
+	s.installMu.RLock()
+	steps := make([]task.TaskStep, 0, len(status.Steps))
+	for _, step := range status.Steps {
+		steps = append(steps, task.TaskStep{
+			Name:        string(step.Step),
+			Description: step.Description,
+			Status:      installStepTaskStatus(step.Status),
+			Progress:    step.Progress,
+			Message:     step.Message,
+			Error:       step.Error,
+			StartedAt:   step.StartTime,
+			CompletedAt: step.EndTime,
+		})
+	}
+	current := string(status.CurrentStep)
+	progress, message := status.Progress, status.Message
+	s.installMu.RUnlock()
+
+	reporter.SetSteps(steps, current)
+	reporter.Update(progress, message)
+}
+
+// finishInstallationTask records the final installation status as the task result.
+// finishInstallationTask 将最终安装状态记录为任务结果。
+func (s *Service) finishInstallationTask(status *InstallationStatus, reporter *task.Reporter) (map[string]interface{}, error) {
+	s.syncInstallationTask(status, reporter)
+
+	s.installMu.RLock()
+	defer s.installMu.RUnlock()
+	result := map[string]interface{}{"installation_id": status.ID}
+	if len(status.Warnings) > 0 {
+		result["warnings"] = append([]string(nil), status.Warnings...)
+	}
+	if status.Status != StepStatusFailed {
+		return result, nil
+	}
+	msg := status.Error
+	if msg == "" {
+		msg = status.Message
+	}
+	return result, errors.New(msg)
+}
+
+// installStepTaskStatus maps an installation step status to a task status.
+// installStepTaskStatus 将安装步骤状态映射为任务状态。
+func installStepTaskStatus(status StepStatus) task.TaskStatus {
+	switch status {
+	case StepStatusRunning:
+		return task.TaskStatusRunning
+	case StepStatusSuccess, StepStatusSkipped:
+		return task.TaskStatusSuccess
+	case StepStatusFailed:
+		return task.TaskStatusFailed
+	default:
+		return task.TaskStatusPending
+	}
+}
@@ -0,0 +1,380 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package installer
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/seatunnel/seatunnelX/internal/pkg/secrets"
+)
+
+// ErrInstallationTemplateNotFound indicates the referenced installation template does not exist.
+// ErrInstallationTemplateNotFound 表示引用的安装模板不存在。
+var ErrInstallationTemplateNotFound = errors.New("installation template not found / 安装模板未找到")
+
+// ErrInstallationTemplateNameExists indicates another template already uses the name.
+// ErrInstallationTemplateNameExists 表示已有其他模板使用该名称。
+var ErrInstallationTemplateNameExists = errors.New("installation template name already exists / 安装模板名称已存在")
+
+// ErrInstallationTemplatesUnavailable indicates templates are not configured on this service.
+// ErrInstallationTemplatesUnavailable 表示当前服务未配置安装模板存储。
+var ErrInstallationTemplatesUnavailable = errors.New("installation templates are not configured / 安装模板未配置")
+
+// InstallationTemplateSpec holds the reusable part of an installation request.
+// InstallationTemplateSpec 保存安装请求中可复用的部分。
+type InstallationTemplateSpec struct {
+	InstallDir     string            `json:"install_dir,omitempty"`
+	InstallMode    InstallMode       `json:"install_mode,omitempty"`
+	Mirror         MirrorSource      `json:"mirror,omitempty"`
+	DeploymentMode DeploymentMode    `json:"deployment_mode,omitempty"`
+	ClusterPort    int               `json:"cluster_port,omitempty"`
+	WorkerPort     int               `json:"worker_port,omitempty"`
+	HTTPPort       int               `json:"http_port,omitempty"`
+	EnableHTTP     *bool             `json:"enable_http,omitempty"`
+	Log            *LogConfig        `json:"log,omitempty"`
+	JVM            *JVMConfig        `json:"jvm,omitempty"`
+	Checkpoint     *CheckpointConfig `json:"checkpoint,omitempty"`
+	IMAP           *IMAPConfig       `json:"imap,omitempty"`
+	Connector      *ConnectorConfig  `json:"connector,omitempty"`
+}
+
+// Value implements driver.Valuer for template spec storage. Storage credentials are sealed.
+// Value 实现 driver.Valuer，用于模板内容存储。存储凭证会被加密。
+func (s InstallationTemplateSpec) Value() (driver.Value, error) {
+	sealed, err := s.mapSecrets(secrets.Seal)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(sealed)
+}
+
+// Scan implements sql.Scanner for template spec retrieval.
+// Scan 实现 sql.Scanner，用于模板内容读取。
+func (s *InstallationTemplateSpec) Scan(value interface{}) error {
+	if value == nil {
+		*s = InstallationTemplateSpec{}
+		return nil
+	}
+	var bytes []byte
+	switch v := value.(type) {
+	case []byte:
+		bytes = v
+	case string:
+		bytes = []byte(v)
+	default:
+		return errors.New("installer: failed to scan InstallationTemplateSpec - expected []byte")
+	}
+	var spec InstallationTemplateSpec
+	if err := json.Unmarshal(bytes, &spec); err != nil {
+		return err
+	}
+	opened, err := spec.mapSecrets(secrets.Open)
+	if err != nil {
+		return err
+	}
+	*s = opened
+	return nil
+}
+
+// storageSecretFields returns pointers to the credential fields of a storage config.
+// storageSecretFields 返回存储配置中凭证字段的指针。
+func storageSecretFields(checkpoint *CheckpointConfig, imap *IMAPConfig) []*string {
+	var fields []*string
+	if checkpoint != nil {
+		fields = append(fields, &checkpoint.StorageAccessKey, &checkpoint.StorageSecretKey, &checkpoint.KerberosKeytabFilePath)
+	}
+	if imap != nil {
+		fields = append(fields, &imap.StorageAccessKey, &imap.StorageSecretKey, &imap.KerberosKeytabFilePath)
+	}
+	return fields
+}
+
+// mapSecrets returns a copy of the spec with fn applied to every storage credential.
+// mapSecrets 返回对每个存储凭证应用 fn 后的模板内容副本。
+func (s InstallationTemplateSpec) mapSecrets(fn func(string) (string, error)) (InstallationTemplateSpec, error) {
+	out := s
+	if s.Checkpoint != nil {
+		checkpoint := *s.Checkpoint
+		out.Checkpoint = &checkpoint
+	}
+	if s.IMAP != nil {
+		imap := *s.IMAP
+		out.IMAP = &imap
+	}
+	for _, field := range storageSecretFields(out.Checkpoint, out.IMAP) {
+		value, err := fn(*field)
+		if err != nil {
+			return s, err
+		}
+		*field = value
+	}
+	return out, nil
+}
+
+// MapSecrets returns a copy of the spec with fn applied to every storage credential.
+// MapSecrets 返回对每个存储凭证应用 fn 后的模板内容副本。
+func (s InstallationTemplateSpec) MapSecrets(fn func(string) (string, error)) (InstallationTemplateSpec, error) {
+	return s.mapSecrets(fn)
+}
+
+// WithStoredSecrets returns a copy of the spec where credentials still holding the mask are
+// replaced by the ones in stored.
+// WithStoredSecrets 返回模板内容副本，其中仍为掩码的凭证会被替换为 stored 中的凭证。
+func (s InstallationTemplateSpec) WithStoredSecrets(stored InstallationTemplateSpec) InstallationTemplateSpec {
+	out, _ := s.mapSecrets(func(value string) (string, error) { return value, nil })
+	restoreMaskedSecrets(out.Checkpoint, stored.Checkpoint, out.IMAP, stored.IMAP)
+	return out
+}
+
+// Redacted returns a copy of the template with storage credentials masked for API responses.
+// Redacted 返回屏蔽了存储凭证的模板副本，用于 API 响应。
+func (t *InstallationTemplate) Redacted() *InstallationTemplate {
+	out := *t
+	out.Spec, _ = t.Spec.mapSecrets(func(value string) (string, error) {
+		return secrets.Redact(value), nil
+	})
+	return &out
+}
+
+// restoreMaskedSecrets keeps the stored credential wherever the incoming config still holds the
+// mask a client was shown.
+// restoreMaskedSecrets 在传入配置仍为展示给客户端的掩码时保留已保存的凭证。
+func restoreMaskedSecrets(checkpoint, storedCheckpoint *CheckpointConfig, imap, storedIMAP *IMAPConfig) {
+	if checkpoint != nil && storedCheckpoint != nil {
+		incoming, stored := storageSecretFields(checkpoint, nil), storageSecretFields(storedCheckpoint, nil)
+		for i := range incoming {
+			*incoming[i] = secrets.RestoreMaskedString(*incoming[i], *stored[i])
+		}
+	}
+	if imap != nil && storedIMAP != nil {
+		incoming, stored := storageSecretFields(nil, imap), storageSecretFields(nil, storedIMAP)
+		for i := range incoming {
+			*incoming[i] = secrets.RestoreMaskedString(*incoming[i], *stored[i])
+		}
+	}
+}
+
+// InstallationTemplate is a named installation profile stored in the database.
+// InstallationTemplate 是保存在数据库中的命名安装配置。
+type InstallationTemplate struct {
+	ID          uint                     `json:"id" gorm:"primaryKey;autoIncrement"`
+	Name        string                   `json:"name" gorm:"size:100;not null;uniqueIndex"`
+	Description string                   `json:"description" gorm:"size:500"`
+	Spec        InstallationTemplateSpec `json:"spec" gorm:"type:json;not null"`
+	CreatedBy   uint                     `json:"created_by"`
+	CreatedAt   time.Time                `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt   time.Time                `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// TableName specifies the installation template table name.
+// TableName 指定安装模板表名。
+func (InstallationTemplate) TableName() string {
+	return "installation_templates"
+}
+
+// InstallationTemplateRequest is the payload for creating or updating a template.
+// InstallationTemplateRequest 是创建或更新模板的请求体。
+type InstallationTemplateRequest struct {
+	Name        string                   `json:"name" binding:"required"`
+	Description string                   `json:"description"`
+	Spec        InstallationTemplateSpec `json:"spec"`
+}
+
+// applyTemplate fills every field the request leaves unset from the template spec.
+// Fields set on the request act as per-field overrides; JVM heap sizes are merged individually,
+// and masked credentials copied from a template response are replaced by the stored ones.
+// applyTemplate 用模板内容填充请求中未设置的字段。
+// 请求中已设置的字段作为逐字段覆盖；JVM 堆大小逐项合并；从模板响应复制的掩码凭证替换为已保存的值。
+func (s *InstallationTemplateSpec) applyTemplate(req *InstallationRequest) {
+	if req.InstallDir == "" {
+		req.InstallDir = s.InstallDir
+	}
+	if req.InstallMode == "" {
+		req.InstallMode = s.InstallMode
+	}
+	if req.Mirror == "" {
+		req.Mirror = s.Mirror
+	}
+	if req.DeploymentMode == "" {
+		req.DeploymentMode = s.DeploymentMode
+	}
+	if req.ClusterPort == 0 {
+		req.ClusterPort = s.ClusterPort
+	}
+	if req.WorkerPort == 0 {
+		req.WorkerPort = s.WorkerPort
+	}
+	if req.HTTPPort == 0 {
+		req.HTTPPort = s.HTTPPort
+	}
+	if req.EnableHTTP == nil && s.EnableHTTP != nil {
+		enableHTTP := *s.EnableHTTP
+		req.EnableHTTP = &enableHTTP
+	}
+	if s.JVM != nil {
+		if req.JVM == nil {
+			jvm := *s.JVM
+			req.JVM = &jvm
+		} else {
+			if req.JVM.HybridHeapSize == 0 {
+				req.JVM.HybridHeapSize = s.JVM.HybridHeapSize
+			}
+			if req.JVM.MasterHeapSize == 0 {
+				req.JVM.MasterHeapSize = s.JVM.MasterHeapSize
+			}
+			if req.JVM.WorkerHeapSize == 0 {
+				req.JVM.WorkerHeapSize = s.JVM.WorkerHeapSize
+			}
+			if req.JVM.GC == "" {
+				req.JVM.GC = s.JVM.GC
+			}
+			if req.JVM.MaxDirectMemoryMB == 0 {
+				req.JVM.MaxDirectMemoryMB = s.JVM.MaxDirectMemoryMB
+			}
+			if len(req.JVM.ExtraOptions) == 0 {
+				req.JVM.ExtraOptions = s.JVM.ExtraOptions
+			}
+		}
+	}
+	if req.Log == nil && s.Log != nil {
+		logConfig := *s.Log
+		req.Log = &logConfig
+	}
+	restoreMaskedSecrets(req.Checkpoint, s.Checkpoint, req.IMAP, s.IMAP)
+	if req.Checkpoint == nil && s.Checkpoint != nil {
+		checkpoint := *s.Checkpoint
+		req.Checkpoint = &checkpoint
+	}
+	if req.IMAP == nil && s.IMAP != nil {
+		imap := *s.IMAP
+		req.IMAP = &imap
+	}
+	if req.Connector == nil && s.Connector != nil {
+		connector := *s.Connector
+		connector.Connectors = append([]string(nil), s.Connector.Connectors...)
+		connector.SelectedPlugins = append([]string(nil), s.Connector.SelectedPlugins...)
+		req.Connector = &connector
+	}
+}
+
+// ListInstallationTemplates returns all installation templates.
+// ListInstallationTemplates 返回全部安装模板。
+func (s *Service) ListInstallationTemplates(ctx context.Context) ([]*InstallationTemplate, error) {
+	if s.templateRepo == nil {
+		return nil, ErrInstallationTemplatesUnavailable
+	}
+	return s.templateRepo.List(ctx)
+}
+
+// GetInstallationTemplate returns an installation template by ID.
+// GetInstallationTemplate 根据 ID 返回安装模板。
+func (s *Service) GetInstallationTemplate(ctx context.Context, id uint) (*InstallationTemplate, error) {
+	if s.templateRepo == nil {
+		return nil, ErrInstallationTemplatesUnavailable
+	}
+	return s.templateRepo.Get(ctx, id)
+}
+
+// CreateInstallationTemplate creates a named installation template.
+// CreateInstallationTemplate 创建命名安装模板。
+func (s *Service) CreateInstallationTemplate(ctx context.Context, req *InstallationTemplateRequest, userID uint) (*InstallationTemplate, error) {
+	if s.templateRepo == nil {
+		return nil, ErrInstallationTemplatesUnavailable
+	}
+	name, err := s.checkInstallationTemplateName(ctx, req.Name, 0)
+	if err != nil {
+		return nil, err
+	}
+	template := &InstallationTemplate{
+		Name:        name,
+		Description: strings.TrimSpace(req.Description),
+		Spec:        req.Spec,
+		CreatedBy:   userID,
+	}
+	if err := s.templateRepo.Create(ctx, template); err != nil {
+		return nil, err
+	}
+	return template, nil
+}
+
+// UpdateInstallationTemplate replaces the name, description and spec of a template.
+// UpdateInstallationTemplate 替换模板的名称、描述和内容。
+func (s *Service) UpdateInstallationTemplate(ctx context.Context, id uint, req *InstallationTemplateRequest) (*InstallationTemplate, error) {
+	if s.templateRepo == nil {
+		return nil, ErrInstallationTemplatesUnavailable
+	}
+	template, err := s.templateRepo.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	name, err := s.checkInstallationTemplateName(ctx, req.Name, id)
+	if err != nil {
+		return nil, err
+	}
+	restoreMaskedSecrets(req.Spec.Checkpoint, template.Spec.Checkpoint, req.Spec.IMAP, template.Spec.IMAP)
+	template.Name = name
+	template.Description = strings.TrimSpace(req.Description)
+	template.Spec = req.Spec
+	if err := s.templateRepo.Update(ctx, template); err != nil {
+		return nil, err
+	}
+	return template, nil
+}
+
+// DeleteInstallationTemplate deletes an installation template.
+// DeleteInstallationTemplate 删除安装模板。
+func (s *Service) DeleteInstallationTemplate(ctx context.Context, id uint) error {
+	if s.templateRepo == nil {
+		return ErrInstallationTemplatesUnavailable
+	}
+	return s.templateRepo.Delete(ctx, id)
+}
+
+func (s *Service) checkInstallationTemplateName(ctx context.Context, name string, excludeID uint) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", errors.New("template name is required / 模板名称不能为空")
+	}
+	exists, err := s.templateRepo.ExistsByName(ctx, name, excludeID)
+	if err != nil {
+		return "", err
+	}
+	if exists {
+		return "", ErrInstallationTemplateNameExists
+	}
+	return name, nil
+}
+
+// applyInstallationTemplate merges the referenced template into the request.
+// applyInstallationTemplate 将引用的模板合并到请求中。
+func (s *Service) applyInstallationTemplate(ctx context.Context, req *InstallationRequest) error {
+	if req.TemplateID == 0 {
+		return nil
+	}
+	template, err := s.GetInstallationTemplate(ctx, req.TemplateID)
+	if err != nil {
+		return err
+	}
+	template.Spec.applyTemplate(req)
+	return nil
+}
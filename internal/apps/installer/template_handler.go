@@ -0,0 +1,178 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package installer
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/seatunnel/seatunnelX/internal/apps/auth"
+	"github.com/seatunnel/seatunnelX/internal/apps/response"
+	"github.com/seatunnel/seatunnelX/internal/logger"
+)
+
+// InstallationTemplateResponse is the response for a single installation template.
+// InstallationTemplateResponse 是单个安装模板的响应。
+type InstallationTemplateResponse struct {
+	response.Meta
+	Data *InstallationTemplate `json:"data"`
+}
+
+// ListInstallationTemplatesResponse is the response for listing installation templates.
+// ListInstallationTemplatesResponse 是安装模板列表的响应。
+type ListInstallationTemplatesResponse struct {
+	response.Meta
+	Data []*InstallationTemplate `json:"data"`
+}
+
+// installationTemplateErrorStatus maps template errors to HTTP status codes.
+// installationTemplateErrorStatus 将模板错误映射为 HTTP 状态码。
+func installationTemplateErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, ErrInstallationTemplateNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrInstallationTemplateNameExists):
+		return http.StatusConflict
+	case errors.Is(err, ErrInstallationTemplatesUnavailable):
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func parseInstallationTemplateID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil || id == 0 {
+		response.Error(c, http.StatusBadRequest, "无效的模板 ID / Invalid template ID")
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// ListInstallationTemplates handles GET /api/v1/installation-templates - lists installation templates.
+// ListInstallationTemplates 处理 GET /api/v1/installation-templates - 获取安装模板列表。
+// @Tags installation-templates
+// @Produce json
+// @Success 200 {object} ListInstallationTemplatesResponse
+// @Router /api/v1/installation-templates [get]
+func (h *Handler) ListInstallationTemplates(c *gin.Context) {
+	templates, err := h.service.ListInstallationTemplates(c.Request.Context())
+	if err != nil {
+		response.Error(c, installationTemplateErrorStatus(err), err.Error())
+		return
+	}
+	redacted := make([]*InstallationTemplate, len(templates))
+	for i, template := range templates {
+		redacted[i] = template.Redacted()
+	}
+	response.OK(c, redacted)
+}
+
+// GetInstallationTemplate handles GET /api/v1/installation-templates/:id - gets an installation template.
+// GetInstallationTemplate 处理 GET /api/v1/installation-templates/:id - 获取安装模板。
+// @Tags installation-templates
+// @Produce json
+// @Param id path int true "模板ID"
+// @Success 200 {object} InstallationTemplateResponse
+// @Router /api/v1/installation-templates/{id} [get]
+func (h *Handler) GetInstallationTemplate(c *gin.Context) {
+	id, ok := parseInstallationTemplateID(c)
+	if !ok {
+		return
+	}
+	template, err := h.service.GetInstallationTemplate(c.Request.Context(), id)
+	if err != nil {
+		response.Error(c, installationTemplateErrorStatus(err), err.Error())
+		return
+	}
+	response.OK(c, template.Redacted())
+}
+
+// CreateInstallationTemplate handles POST /api/v1/installation-templates - creates an installation template.
+// CreateInstallationTemplate 处理 POST /api/v1/installation-templates - 创建安装模板。
+// @Tags installation-templates
+// @Accept json
+// @Produce json
+// @Param request body InstallationTemplateRequest true "模板内容"
+// @Success 200 {object} InstallationTemplateResponse
+// @Router /api/v1/installation-templates [post]
+func (h *Handler) CreateInstallationTemplate(c *gin.Context) {
+	var req InstallationTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	template, err := h.service.CreateInstallationTemplate(c.Request.Context(), &req, uint(auth.GetUserIDFromContext(c)))
+	if err != nil {
+		response.Error(c, installationTemplateErrorStatus(err), err.Error())
+		return
+	}
+
+	logger.InfoF(c.Request.Context(), "[Installer] 创建安装模板: id=%d, name=%s", template.ID, template.Name)
+	response.OK(c, template.Redacted())
+}
+
+// UpdateInstallationTemplate handles PUT /api/v1/installation-templates/:id - updates an installation template.
+// UpdateInstallationTemplate 处理 PUT /api/v1/installation-templates/:id - 更新安装模板。
+// @Tags installation-templates
+// @Accept json
+// @Produce json
+// @Param id path int true "模板ID"
+// @Param request body InstallationTemplateRequest true "模板内容"
+// @Success 200 {object} InstallationTemplateResponse
+// @Router /api/v1/installation-templates/{id} [put]
+func (h *Handler) UpdateInstallationTemplate(c *gin.Context) {
+	id, ok := parseInstallationTemplateID(c)
+	if !ok {
+		return
+	}
+	var req InstallationTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	template, err := h.service.UpdateInstallationTemplate(c.Request.Context(), id, &req)
+	if err != nil {
+		response.Error(c, installationTemplateErrorStatus(err), err.Error())
+		return
+	}
+	response.OK(c, template.Redacted())
+}
+
+// DeleteInstallationTemplate handles DELETE /api/v1/installation-templates/:id - deletes an installation template.
+// DeleteInstallationTemplate 处理 DELETE /api/v1/installation-templates/:id - 删除安装模板。
+// @Tags installation-templates
+// @Produce json
+// @Param id path int true "模板ID"
+// @Success 200 {object} InstallationTemplateResponse
+// @Router /api/v1/installation-templates/{id} [delete]
+func (h *Handler) DeleteInstallationTemplate(c *gin.Context) {
+	id, ok := parseInstallationTemplateID(c)
+	if !ok {
+		return
+	}
+	if err := h.service.DeleteInstallationTemplate(c.Request.Context(), id); err != nil {
+		response.Error(c, installationTemplateErrorStatus(err), err.Error())
+		return
+	}
+	response.OK(c, nil)
+}
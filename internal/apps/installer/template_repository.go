@@ -0,0 +1,107 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package installer
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// TemplateRepository provides persistence access for installation templates.
+// TemplateRepository 提供安装模板的持久化访问。
+type TemplateRepository struct {
+	db *gorm.DB
+}
+
+// NewTemplateRepository creates a new installation template repository.
+// NewTemplateRepository 创建安装模板仓库实例。
+func NewTemplateRepository(db *gorm.DB) *TemplateRepository {
+	return &TemplateRepository{db: db}
+}
+
+// List returns all installation templates ordered by name.
+// List 返回按名称排序的全部安装模板。
+func (r *TemplateRepository) List(ctx context.Context) ([]*InstallationTemplate, error) {
+	var templates []*InstallationTemplate
+	if err := r.db.WithContext(ctx).Order("name ASC").Find(&templates).Error; err != nil {
+		return nil, err
+	}
+	return templates, nil
+}
+
+// Get returns an installation template by ID.
+// Get 根据 ID 获取安装模板。
+func (r *TemplateRepository) Get(ctx context.Context, id uint) (*InstallationTemplate, error) {
+	var template InstallationTemplate
+	if err := r.db.WithContext(ctx).First(&template, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInstallationTemplateNotFound
+		}
+		return nil, err
+	}
+	return &template, nil
+}
+
+// ExistsByName reports whether a template other than excludeID uses the name.
+// ExistsByName 判断除 excludeID 外是否已有模板使用该名称。
+func (r *TemplateRepository) ExistsByName(ctx context.Context, name string, excludeID uint) (bool, error) {
+	var count int64
+	query := r.db.WithContext(ctx).Model(&InstallationTemplate{}).Where("name = ?", strings.TrimSpace(name))
+	if excludeID > 0 {
+		query = query.Where("id <> ?", excludeID)
+	}
+	if err := query.Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// Create creates an installation template.
+// Create 创建安装模板。
+func (r *TemplateRepository) Create(ctx context.Context, template *InstallationTemplate) error {
+	return r.db.WithContext(ctx).Create(template).Error
+}
+
+// Update updates an installation template.
+// Update 更新安装模板。
+func (r *TemplateRepository) Update(ctx context.Context, template *InstallationTemplate) error {
+	result := r.db.WithContext(ctx).Save(template)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrInstallationTemplateNotFound
+	}
+	return nil
+}
+
+// Delete deletes an installation template by ID.
+// Delete 根据 ID 删除安装模板。
+func (r *TemplateRepository) Delete(ctx context.Context, id uint) error {
+	result := r.db.WithContext(ctx).Delete(&InstallationTemplate{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrInstallationTemplateNotFound
+	}
+	return nil
+}
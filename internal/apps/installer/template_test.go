@@ -0,0 +1,131 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package installer
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func newTemplateTestService(t *testing.T) *Service {
+	t.Helper()
+
+	database, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "test.db")), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	if err := database.AutoMigrate(&InstallationTemplate{}); err != nil {
+		t.Fatalf("failed to migrate database: %v", err)
+	}
+
+	service := NewService(t.TempDir(), nil)
+	service.SetTemplateRepository(NewTemplateRepository(database))
+	return service
+}
+
+func TestInstallationTemplateSpec_applyTemplate(t *testing.T) {
+	enableHTTP := true
+	spec := &InstallationTemplateSpec{
+		DeploymentMode: DeploymentModeSeparated,
+		ClusterPort:    5801,
+		HTTPPort:       8080,
+		EnableHTTP:     &enableHTTP,
+		JVM:            &JVMConfig{HybridHeapSize: 4, MasterHeapSize: 2, WorkerHeapSize: 8},
+		Checkpoint:     &CheckpointConfig{StorageType: CheckpointStorageHDFS, Namespace: "/seatunnel/checkpoint/"},
+		Connector:      &ConnectorConfig{InstallConnectors: true, Connectors: []string{"jdbc"}},
+	}
+	req := &InstallationRequest{
+		Version:     "2.3.12",
+		ClusterPort: 15801,
+		JVM:         &JVMConfig{WorkerHeapSize: 16},
+	}
+
+	spec.applyTemplate(req)
+
+	if req.DeploymentMode != DeploymentModeSeparated || req.HTTPPort != 8080 || req.EnableHTTP == nil || !*req.EnableHTTP {
+		t.Fatalf("expected template fields to fill unset request fields, got %+v", req)
+	}
+	if req.ClusterPort != 15801 {
+		t.Fatalf("expected request cluster port to override template, got %d", req.ClusterPort)
+	}
+	if req.JVM.HybridHeapSize != 4 || req.JVM.MasterHeapSize != 2 || req.JVM.WorkerHeapSize != 16 {
+		t.Fatalf("expected JVM heap sizes to merge per field, got %+v", req.JVM)
+	}
+	if req.Checkpoint == nil || req.Checkpoint.StorageType != CheckpointStorageHDFS {
+		t.Fatalf("expected checkpoint from template, got %+v", req.Checkpoint)
+	}
+
+	req.Connector.Connectors[0] = "kafka"
+	if spec.Connector.Connectors[0] != "jdbc" {
+		t.Fatalf("expected request connectors not to alias template connectors")
+	}
+}
+
+func TestService_InstallationTemplateCRUD(t *testing.T) {
+	ctx := context.Background()
+	service := newTemplateTestService(t)
+
+	created, err := service.CreateInstallationTemplate(ctx, &InstallationTemplateRequest{
+		Name: " prod-hybrid ",
+		Spec: InstallationTemplateSpec{
+			DeploymentMode: DeploymentModeHybrid,
+			JVM:            &JVMConfig{HybridHeapSize: 8},
+		},
+	}, 1)
+	if err != nil {
+		t.Fatalf("CreateInstallationTemplate returned error: %v", err)
+	}
+	if created.Name != "prod-hybrid" {
+		t.Fatalf("expected trimmed name, got %q", created.Name)
+	}
+
+	if _, err := service.CreateInstallationTemplate(ctx, &InstallationTemplateRequest{Name: "prod-hybrid"}, 1); !errors.Is(err, ErrInstallationTemplateNameExists) {
+		t.Fatalf("expected duplicate name error, got %v", err)
+	}
+
+	loaded, err := service.GetInstallationTemplate(ctx, created.ID)
+	if err != nil {
+		t.Fatalf("GetInstallationTemplate returned error: %v", err)
+	}
+	if loaded.Spec.JVM == nil || loaded.Spec.JVM.HybridHeapSize != 8 {
+		t.Fatalf("expected spec to round-trip, got %+v", loaded.Spec)
+	}
+
+	req := &InstallationRequest{TemplateID: created.ID, Version: "2.3.12"}
+	if err := service.applyInstallationTemplate(ctx, req); err != nil {
+		t.Fatalf("applyInstallationTemplate returned error: %v", err)
+	}
+	if req.DeploymentMode != DeploymentModeHybrid || req.JVM == nil || req.JVM.HybridHeapSize != 8 {
+		t.Fatalf("expected template to be applied, got %+v", req)
+	}
+
+	if err := service.DeleteInstallationTemplate(ctx, created.ID); err != nil {
+		t.Fatalf("DeleteInstallationTemplate returned error: %v", err)
+	}
+	if _, err := service.StartInstallation(ctx, &InstallationRequest{HostID: "1", TemplateID: created.ID, Version: "2.3.12"}); !errors.Is(err, ErrInstallationTemplateNotFound) {
+		t.Fatalf("expected missing template error, got %v", err)
+	}
+}
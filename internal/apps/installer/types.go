@@ -18,8 +18,11 @@
 package installer
 
 import (
+	"encoding/json"
 	"time"
 
+	"github.com/seatunnel/seatunnelX/internal/pkg/filestream"
+	"github.com/seatunnel/seatunnelX/internal/pkg/installhook"
 	"github.com/seatunnel/seatunnelX/internal/seatunnel"
 )
 
@@ -42,6 +45,19 @@ const (
 	InstallModeOffline InstallMode = "offline"
 )
 
+// PackageDelivery represents how the package reaches the node
+// PackageDelivery 表示安装包送达节点的方式
+type PackageDelivery string
+
+const (
+	// PackageDeliveryPush streams the package from the Control Plane over the command stream
+	// PackageDeliveryPush 由控制面通过命令流推送安装包
+	PackageDeliveryPush PackageDelivery = "push"
+	// PackageDeliveryDirect lets the Agent download the package from object storage or a mirror
+	// PackageDeliveryDirect 由 Agent 从对象存储或镜像源直接下载安装包
+	PackageDeliveryDirect PackageDelivery = "direct"
+)
+
 // DeploymentMode represents the deployment mode
 // DeploymentMode 表示部署模式
 type DeploymentMode string
@@ -81,11 +97,13 @@ const (
 	InstallStepDownload            InstallStep = "download"
 	InstallStepVerify              InstallStep = "verify"
 	InstallStepExtract             InstallStep = "extract"
+	InstallStepInstallJava         InstallStep = "install_java"
 	InstallStepConfigureCluster    InstallStep = "configure_cluster"
 	InstallStepConfigureCheckpoint InstallStep = "configure_checkpoint"
 	InstallStepConfigureIMAP       InstallStep = "configure_imap"
 	InstallStepConfigureJVM        InstallStep = "configure_jvm"
 	InstallStepInstallPlugins      InstallStep = "install_plugins"
+	InstallStepConfigureSystemd    InstallStep = "configure_systemd"
 	InstallStepRegisterCluster     InstallStep = "register_cluster"
 	InstallStepComplete            InstallStep = "complete"
 )
@@ -107,10 +125,15 @@ type PackageInfo struct {
 	FileName     string                  `json:"file_name"`
 	FileSize     int64                   `json:"file_size"`
 	Checksum     string                  `json:"checksum,omitempty"`
+	SHA512       string                  `json:"sha512,omitempty"`
+	Verified     bool                    `json:"verified"`
 	DownloadURLs map[MirrorSource]string `json:"download_urls"`
 	IsLocal      bool                    `json:"is_local"`
 	LocalPath    string                  `json:"local_path,omitempty"`
 	UploadedAt   *time.Time              `json:"uploaded_at,omitempty"`
+	// Stored reports the package is only kept in the shared object store and is fetched on first use.
+	// Stored 表示安装包仅保存在共享对象存储中，首次使用时获取到本地。
+	Stored bool `json:"stored,omitempty"`
 }
 
 // AvailableVersions contains available SeaTunnel versions
@@ -120,6 +143,14 @@ type AvailableVersions struct {
 	RecommendedVersion  string                                   `json:"recommended_version"`
 	LocalPackages       []PackageInfo                            `json:"local_packages"`
 	VersionCapabilities map[string]seatunnel.VersionCapabilities `json:"version_capabilities"`
+	// LocalTotal is the number of local packages before paging / LocalTotal 是分页前的本地安装包总数
+	LocalTotal int `json:"local_total"`
+	// VersionPolicy is the active version policy, omitted when it allows every version
+	// VersionPolicy 是当前生效的版本策略，允许所有版本时省略
+	VersionPolicy *VersionPolicy `json:"version_policy,omitempty"`
+	// UnapprovedVersions maps each version the policy rejects to the reason
+	// UnapprovedVersions 记录被策略拒绝的版本及原因
+	UnapprovedVersions map[string]string `json:"unapproved_versions,omitempty"`
 }
 
 // JobScheduleStrategy represents the SeaTunnel job schedule strategy.
@@ -156,6 +187,38 @@ type JVMConfig struct {
 	HybridHeapSize int `json:"hybrid_heap_size"`
 	MasterHeapSize int `json:"master_heap_size"`
 	WorkerHeapSize int `json:"worker_heap_size"`
+	// GC, MaxDirectMemoryMB and ExtraOptions apply to every role / GC、直接内存与额外参数作用于所有角色
+	GC                string   `json:"gc,omitempty"`
+	MaxDirectMemoryMB int      `json:"max_direct_memory_mb,omitempty"`
+	ExtraOptions      []string `json:"extra_options,omitempty"`
+}
+
+// LogConfig contains log rotation and log disk quota settings
+// LogConfig 包含日志滚动与日志磁盘配额配置
+type LogConfig struct {
+	// MaxFileSizeMB rolls engine logs once a file reaches this size / 引擎日志文件达到该大小时滚动
+	MaxFileSizeMB int `json:"max_file_size_mb,omitempty"`
+	// MaxBackups keeps at most this many rolled files per log / 每个日志最多保留的滚动文件数
+	MaxBackups int `json:"max_backups,omitempty"`
+	// Compress gzips rolled engine logs / 压缩滚动后的引擎日志
+	Compress bool `json:"compress,omitempty"`
+	// GCLog enables rotated JVM GC logs / 开启带滚动的 JVM GC 日志
+	GCLog bool `json:"gc_log,omitempty"`
+	// DiskQuotaMB caps the logs directory; the Agent deletes the oldest files beyond it / 日志目录上限，超出后 Agent 删除最旧文件
+	DiskQuotaMB int `json:"disk_quota_mb,omitempty"`
+}
+
+// HazelcastNetworkConfig contains advanced Hazelcast member network settings for NAT and multi-NIC hosts
+// HazelcastNetworkConfig 包含适用于 NAT 与多网卡主机的 Hazelcast 成员高级网络配置
+type HazelcastNetworkConfig struct {
+	// PublicAddress is the host[:port] this node advertises to other members, e.g. its NAT address / 本节点向其他成员公布的 host[:port]，如 NAT 地址
+	PublicAddress string `json:"public_address,omitempty"`
+	// Interfaces restricts binding to matching NICs, as IPv4 CIDRs or Hazelcast patterns / 仅绑定匹配的网卡，支持 IPv4 CIDR 或 Hazelcast 通配形式
+	Interfaces []string `json:"interfaces,omitempty"`
+	// AdvertisedAddresses replaces the derived tcp-ip member-list with the addresses members reach each other on / 用成员间实际可达的地址替换推导出的 tcp-ip member-list
+	AdvertisedAddresses []string `json:"advertised_addresses,omitempty"`
+	// RESTEndpointGroups is the exact set of enabled REST endpoint groups; empty keeps the defaults / 启用的 REST 端点组集合，为空时保持默认
+	RESTEndpointGroups []string `json:"rest_endpoint_groups,omitempty"`
 }
 
 // CheckpointStorageType represents the checkpoint storage type
@@ -310,10 +373,52 @@ type InstallationRequest struct {
 	HistoryJobExpireMinutes *int                   `json:"history_job_expire_minutes,omitempty"`
 	ScheduledDeletionEnable *bool                  `json:"scheduled_deletion_enable,omitempty"`
 	JobLogMode              JobLogMode             `json:"job_log_mode,omitempty"`
+	Log                     *LogConfig             `json:"log,omitempty"`
 	JVM                     *JVMConfig             `json:"jvm,omitempty"`
 	Checkpoint              *CheckpointConfig      `json:"checkpoint,omitempty"`
 	IMAP                    *IMAPConfig            `json:"imap,omitempty"`
 	Connector               *ConnectorConfig       `json:"connector,omitempty"`
+	// Hazelcast holds advanced member network settings; public_address is specific to this node.
+	// Hazelcast 保存成员高级网络配置；public_address 仅针对本节点。
+	Hazelcast *HazelcastNetworkConfig `json:"hazelcast,omitempty"`
+	// EnableSystemd registers a systemd unit on the node so SeaTunnel starts again after a reboot.
+	// EnableSystemd 在节点上注册 systemd unit，使 SeaTunnel 在主机重启后自动启动。
+	EnableSystemd bool `json:"enable_systemd,omitempty"`
+	// AutoInstallJava installs OpenJDK 11 on the node when no supported Java is found.
+	// AutoInstallJava 在节点没有受支持的 Java 时自动安装 OpenJDK 11。
+	AutoInstallJava bool `json:"auto_install_java,omitempty"`
+	// JavaMirror selects the OpenJDK download source (tsinghua, adoptium); empty follows the package mirror.
+	// JavaMirror 选择 OpenJDK 下载源（tsinghua、adoptium）；为空时跟随安装包镜像。
+	JavaMirror string `json:"java_mirror,omitempty"`
+	// UseSuggestedPorts replaces busy cluster/worker/HTTP ports with free ones found by the Agent.
+	// UseSuggestedPorts 使用 Agent 找到的空闲端口替换被占用的 cluster/worker/HTTP 端口。
+	UseSuggestedPorts bool `json:"use_suggested_ports,omitempty"`
+	// SkipNodeStart leaves the node stopped after installation so the caller can push configs and start it.
+	// SkipNodeStart 安装完成后不启动节点，由调用方推送配置后再启动。
+	SkipNodeStart bool `json:"skip_node_start,omitempty"`
+	// DryRun validates the request, runs prechecks and renders configs without touching the target host.
+	// DryRun 校验请求、执行预检查并渲染配置，但不修改目标主机。
+	DryRun bool `json:"dry_run,omitempty"`
+	// TemplateID references an installation template; fields set on the request override the template.
+	// TemplateID 引用安装模板；请求中设置的字段覆盖模板内容。
+	TemplateID uint `json:"template_id,omitempty"`
+	// SkipPackageVerification transfers the package even if it has no verified official SHA-512.
+	// SkipPackageVerification 即使安装包未通过官方 SHA-512 校验也进行传输。
+	SkipPackageVerification bool `json:"skip_package_verification,omitempty"`
+	// PackageDelivery selects push (default) or direct, where the Agent downloads the package from a presigned
+	// object store URL or the mirror and the Control Plane only hands it the URL and checksum.
+	// PackageDelivery 选择 push（默认）或 direct；direct 模式下 Agent 从对象存储预签名地址或镜像源下载安装包，
+	// 控制面只下发地址和校验和。
+	PackageDelivery PackageDelivery `json:"package_delivery,omitempty"`
+	// Priority orders installations waiting for a concurrency slot, higher first; equal priorities run in submission order.
+	// Priority 决定等待并发名额的安装顺序，数值高者优先；优先级相同时按提交顺序。
+	Priority int `json:"priority,omitempty"`
+	// SkipSteps lists optional steps the Agent leaves out, e.g. configure_systemd on hosts managed elsewhere.
+	// SkipSteps 列出由 Agent 跳过的可选步骤，例如在由其他方式管理的主机上跳过 configure_systemd。
+	SkipSteps []InstallStep `json:"skip_steps,omitempty"`
+	// PackageDownload is set by the Control Plane when the Agent pulls the package itself.
+	// PackageDownload 在 Agent 自行拉取安装包时由控制面设置。
+	PackageDownload *PackageDownload `json:"-"`
 }
 
 // StepInfo contains information about an installation step
@@ -329,6 +434,9 @@ type StepInfo struct {
 	StartTime   *time.Time  `json:"start_time,omitempty"`
 	EndTime     *time.Time  `json:"end_time,omitempty"`
 	Retryable   bool        `json:"retryable"`
+	// Hooks are the results of the hooks run before and after the step.
+	// Hooks 是该步骤前后执行的钩子结果。
+	Hooks []installhook.Result `json:"hooks,omitempty"`
 }
 
 // InstallationStatus represents the current installation status
@@ -337,6 +445,8 @@ type InstallationStatus struct {
 	ID          string      `json:"id"`
 	HostID      string      `json:"host_id"`
 	ClusterID   string      `json:"cluster_id,omitempty"`
+	CommandID   string      `json:"command_id,omitempty"`
+	TaskID      string      `json:"task_id,omitempty"`
 	Status      StepStatus  `json:"status"`
 	CurrentStep InstallStep `json:"current_step"`
 	Steps       []StepInfo  `json:"steps"`
@@ -346,15 +456,64 @@ type InstallationStatus struct {
 	Warnings    []string    `json:"warnings,omitempty"`
 	StartTime   time.Time   `json:"start_time"`
 	EndTime     *time.Time  `json:"end_time,omitempty"`
+	// DryRun carries the dry-run report when the request had dry_run set.
+	// DryRun 在请求设置 dry_run 时携带试运行报告。
+	DryRun *InstallationDryRunResult `json:"dry_run,omitempty"`
+	// Transfer carries throughput and ETA while the package is sent to the Agent.
+	// Transfer 在向 Agent 发送安装包期间携带吞吐量和预计剩余时间。
+	Transfer *filestream.Snapshot `json:"transfer,omitempty"`
+	// QueuePosition is the 1-based position while the installation or its package transfer waits for a slot.
+	// QueuePosition 是安装或其安装包传输等待名额时的排队位置（从 1 开始）。
+	QueuePosition int `json:"queue_position,omitempty"`
+
+	// dequeue ends the wait in a concurrency queue when the installation is cancelled.
+	// dequeue 在安装被取消时结束并发队列中的等待。
+	dequeue func()
+}
+
+// DryRunPackageInfo describes where the package of a dry-run installation would come from.
+// DryRunPackageInfo 描述试运行安装的安装包来源。
+type DryRunPackageInfo struct {
+	Mode         InstallMode `json:"mode"`
+	Source       string      `json:"source,omitempty"`
+	LocalPath    string      `json:"local_path,omitempty"`
+	DownloadURL  string      `json:"download_url,omitempty"`
+	RemotePath   string      `json:"remote_path,omitempty"`
+	SizeBytes    int64       `json:"size_bytes,omitempty"`
+	Available    bool        `json:"available"`
+	Transferable bool        `json:"transferable"`
+}
+
+// InstallationDryRunResult is the outcome of a dry-run installation.
+// InstallationDryRunResult 是试运行安装的结果。
+type InstallationDryRunResult struct {
+	Valid    bool               `json:"valid"`
+	Errors   []string           `json:"errors,omitempty"`
+	Warnings []string           `json:"warnings,omitempty"`
+	Precheck *PrecheckResult    `json:"precheck,omitempty"`
+	Package  *DryRunPackageInfo `json:"package,omitempty"`
+	// Params are the install parameters that would be sent to the Agent.
+	// Params 是将发送给 Agent 的安装参数。
+	Params map[string]string `json:"params,omitempty"`
+	// AgentPrecheck is the raw Agent-side precheck result.
+	// AgentPrecheck 是 Agent 侧的原始预检查结果。
+	AgentPrecheck json.RawMessage `json:"agent_precheck,omitempty"`
+	// TemplateSource is where the Agent took the config templates from.
+	// TemplateSource 是 Agent 获取配置模板的来源。
+	TemplateSource string `json:"template_source,omitempty"`
+	// RenderedFiles maps install-dir relative paths to the content that would be written.
+	// RenderedFiles 将相对安装目录的路径映射到将要写入的内容。
+	RenderedFiles map[string]string `json:"rendered_files,omitempty"`
 }
 
 // PrecheckItem represents a single precheck result item
 // PrecheckItem 表示单个预检查结果项
 type PrecheckItem struct {
-	Name    string                 `json:"name"`
-	Status  CheckStatus            `json:"status"`
-	Message string                 `json:"message"`
-	Details map[string]interface{} `json:"details,omitempty"`
+	Name       string                 `json:"name"`
+	Status     CheckStatus            `json:"status"`
+	Message    string                 `json:"message"`
+	Details    map[string]interface{} `json:"details,omitempty"`
+	Suggestion string                 `json:"suggestion,omitempty"`
 }
 
 // PrecheckResult contains all precheck results
@@ -363,6 +522,21 @@ type PrecheckResult struct {
 	Items         []PrecheckItem `json:"items"`
 	OverallStatus CheckStatus    `json:"overall_status"`
 	Summary       string         `json:"summary"`
+	// SuggestedPorts holds free alternatives for busy ports, set only when ports conflict.
+	// SuggestedPorts 保存被占用端口的可用替代端口，仅在端口冲突时设置。
+	SuggestedPorts *SuggestedPorts `json:"suggested_ports,omitempty"`
+	// RunID is the ID of the precheck history record, set when history is enabled.
+	// RunID 是预检查历史记录的 ID，仅在启用历史记录时设置。
+	RunID uint `json:"run_id,omitempty"`
+}
+
+// SuggestedPorts uses the InstallationRequest port keys, so it can be merged into an install
+// request as is; zero fields have no conflict.
+// SuggestedPorts 使用 InstallationRequest 的端口字段名，可直接合并到安装请求中；为零的字段没有冲突。
+type SuggestedPorts struct {
+	ClusterPort int `json:"cluster_port,omitempty"`
+	WorkerPort  int `json:"worker_port,omitempty"`
+	HTTPPort    int `json:"http_port,omitempty"`
 }
 
 // DownloadStatus represents the status of a download task
@@ -391,8 +565,13 @@ type DownloadTask struct {
 	Speed           int64          `json:"speed"` // bytes per second
 	Message         string         `json:"message,omitempty"`
 	Error           string         `json:"error,omitempty"`
+	Verified        bool           `json:"verified"`
 	StartTime       time.Time      `json:"start_time"`
 	EndTime         *time.Time     `json:"end_time,omitempty"`
+
+	// done is closed when the download goroutine exits
+	// done 在下载协程退出时关闭
+	done chan struct{}
 }
 
 // DownloadRequest represents a request to download a package
@@ -0,0 +1,65 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package installer
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/seatunnel/seatunnelX/internal/seatunnel"
+)
+
+// ErrUnsupportedVersionOption indicates the request sets an option the target SeaTunnel version cannot honor.
+// ErrUnsupportedVersionOption 表示请求设置了目标 SeaTunnel 版本无法生效的选项。
+var ErrUnsupportedVersionOption = errors.New("option not supported by target SeaTunnel version / 目标 SeaTunnel 版本不支持该选项")
+
+// validateVersionOptions checks version-gated request fields against the capability matrix.
+// An unsupported option is accepted when it is unset or equal to the installer default,
+// because the wizard always sends defaults and the agent skips those steps; anything else is rejected.
+// validateVersionOptions 按能力矩阵校验受版本控制的请求字段。
+// 不支持的选项在未设置或等于安装器默认值时放行（向导总会发送默认值，Agent 会跳过对应步骤），其他取值一律拒绝。
+func validateVersionOptions(req *InstallationRequest) error {
+	defaults := seatunnel.CapabilitiesForVersion(req.Version)
+	checks := []struct {
+		capability seatunnel.Capability
+		requested  bool
+	}{
+		{seatunnel.CapabilityHTTPService, req.EnableHTTP != nil && *req.EnableHTTP},
+		{seatunnel.CapabilityDynamicSlot, req.DynamicSlot != nil && *req.DynamicSlot != defaults.DefaultDynamicSlot},
+		{seatunnel.CapabilitySlotNum, req.SlotNum != nil && *req.SlotNum != defaults.DefaultStaticSlotNum},
+		{seatunnel.CapabilitySlotAllocationStrategy, isNonDefaultOption(string(req.SlotAllocationStrategy), defaults.DefaultSlotAllocationStrategy)},
+		{seatunnel.CapabilityJobScheduleStrategy, isNonDefaultOption(string(req.JobScheduleStrategy), defaults.DefaultJobScheduleStrategy)},
+		{seatunnel.CapabilityHistoryJobExpireMinutes, req.HistoryJobExpireMinutes != nil && *req.HistoryJobExpireMinutes != defaults.DefaultHistoryJobExpireMinutes},
+		{seatunnel.CapabilityScheduledDeletionEnable, req.ScheduledDeletionEnable != nil && *req.ScheduledDeletionEnable != defaults.DefaultScheduledDeletionEnable},
+		{seatunnel.CapabilityJobLogMode, isNonDefaultOption(string(req.JobLogMode), defaults.DefaultJobLogMode)},
+	}
+	for _, check := range checks {
+		if check.requested && !seatunnel.SupportsCapability(req.Version, check.capability) {
+			minVersion := seatunnel.MinVersionFor(check.capability)
+			return fmt.Errorf("%w: %s requires SeaTunnel %s+, got %s / %s 需要 SeaTunnel %s 及以上版本，当前为 %s",
+				ErrUnsupportedVersionOption, check.capability, minVersion, req.Version, check.capability, minVersion, req.Version)
+		}
+	}
+	return nil
+}
+
+func isNonDefaultOption(value, defaultValue string) bool {
+	value = strings.TrimSpace(value)
+	return value != "" && !strings.EqualFold(value, defaultValue)
+}
@@ -0,0 +1,91 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package installer
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestValidateVersionOptions(t *testing.T) {
+	enabled := true
+	disabled := false
+	slotNum := 2
+	historyMinutes := 1440
+	customHistoryMinutes := 60
+
+	testCases := []struct {
+		name    string
+		req     *InstallationRequest
+		wantErr bool
+	}{
+		{
+			name: "wizard defaults on an old version",
+			req: &InstallationRequest{
+				Version:                 "2.3.2",
+				HTTPPort:                8080,
+				EnableHTTP:              &disabled,
+				DynamicSlot:             &enabled,
+				SlotNum:                 &slotNum,
+				SlotAllocationStrategy:  "RANDOM",
+				JobScheduleStrategy:     "REJECT",
+				HistoryJobExpireMinutes: &historyMinutes,
+				ScheduledDeletionEnable: &enabled,
+				JobLogMode:              "mixed",
+			},
+		},
+		{name: "http on 2.3.9", req: &InstallationRequest{Version: "2.3.9", EnableHTTP: &enabled}},
+		{name: "http before 2.3.9", req: &InstallationRequest{Version: "2.3.8", EnableHTTP: &enabled}, wantErr: true},
+		{name: "per-job logs on 2.3.8", req: &InstallationRequest{Version: "2.3.8", JobLogMode: JobLogModePerJob}},
+		{name: "per-job logs before 2.3.8", req: &InstallationRequest{Version: "2.3.7", JobLogMode: JobLogModePerJob}, wantErr: true},
+		{name: "history retention before 2.3.3", req: &InstallationRequest{Version: "2.3.2", HistoryJobExpireMinutes: &customHistoryMinutes}, wantErr: true},
+		{name: "scheduled deletion disabled before 2.3.9", req: &InstallationRequest{Version: "2.3.8", ScheduledDeletionEnable: &disabled}, wantErr: true},
+		{name: "slot allocation before 2.3.10", req: &InstallationRequest{Version: "2.3.9", SlotAllocationStrategy: SlotAllocationStrategySystemLoad}, wantErr: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := validateVersionOptions(testCase.req)
+			if testCase.wantErr && !errors.Is(err, ErrUnsupportedVersionOption) {
+				t.Fatalf("expected ErrUnsupportedVersionOption, got %v", err)
+			}
+			if !testCase.wantErr && err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestService_StartInstallation_RejectsUnsupportedVersionOption(t *testing.T) {
+	enabled := true
+	service := NewService(t.TempDir(), &dryRunAgentManager{})
+
+	_, err := service.StartInstallation(context.Background(), &InstallationRequest{
+		HostID:      "3",
+		Version:     "2.3.8",
+		InstallMode: InstallModeOffline,
+		EnableHTTP:  &enabled,
+	})
+	if !errors.Is(err, ErrUnsupportedVersionOption) {
+		t.Fatalf("expected ErrUnsupportedVersionOption, got %v", err)
+	}
+	if _, err := service.GetInstallationStatus(context.Background(), 3); !errors.Is(err, ErrInstallationNotFound) {
+		t.Fatalf("expected rejected request not to be tracked, got %v", err)
+	}
+}
@@ -0,0 +1,315 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package installer
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/seatunnel/seatunnelX/internal/logger"
+	"gorm.io/gorm"
+)
+
+// ErrVersionNotApproved indicates the version policy does not allow installing the SeaTunnel version.
+// ErrVersionNotApproved 表示版本策略不允许安装该 SeaTunnel 版本。
+var ErrVersionNotApproved = errors.New("SeaTunnel version is not approved by the version policy / SeaTunnel 版本未被版本策略批准")
+
+// ErrInvalidVersionPolicy indicates an invalid version policy update.
+// ErrInvalidVersionPolicy 表示版本策略更新内容不合法。
+var ErrInvalidVersionPolicy = errors.New("invalid version policy / 版本策略不合法")
+
+// VersionList stores SeaTunnel versions as a JSON column.
+// VersionList 以 JSON 列保存 SeaTunnel 版本列表。
+type VersionList []string
+
+// Value implements driver.Valuer for database storage.
+// Value 实现 driver.Valuer，用于数据库存储。
+func (versions VersionList) Value() (driver.Value, error) {
+	if versions == nil {
+		return "[]", nil
+	}
+	return json.Marshal(versions)
+}
+
+// Scan implements sql.Scanner for database retrieval.
+// Scan 实现 sql.Scanner，用于数据库读取。
+func (versions *VersionList) Scan(value interface{}) error {
+	if value == nil {
+		*versions = VersionList{}
+		return nil
+	}
+	var data []byte
+	switch v := value.(type) {
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return errors.New("installer: failed to scan VersionList - expected []byte")
+	}
+	return json.Unmarshal(data, versions)
+}
+
+// VersionPolicy restricts which SeaTunnel versions may be installed. An empty policy allows every version.
+// VersionPolicy 限制可安装的 SeaTunnel 版本，空策略允许所有版本。
+type VersionPolicy struct {
+	ID uint `json:"-" gorm:"primaryKey"`
+	// MinimumVersion rejects older versions; empty means no minimum.
+	// MinimumVersion 拒绝更旧的版本，为空表示不限制。
+	MinimumVersion string `json:"minimum_version" gorm:"size:50"`
+	// AllowedVersions, when not empty, is the only set of versions that may be installed.
+	// AllowedVersions 非空时，仅允许安装其中的版本。
+	AllowedVersions VersionList `json:"allowed_versions" gorm:"type:json"`
+	// DeniedVersions are never installable, e.g. releases with known defects.
+	// DeniedVersions 始终不可安装，例如存在已知缺陷的版本。
+	DeniedVersions VersionList `json:"denied_versions" gorm:"type:json"`
+	// HideUnapproved removes unapproved versions from the version list instead of only marking them.
+	// HideUnapproved 从版本列表中移除未批准的版本，而不仅是标记。
+	HideUnapproved bool      `json:"hide_unapproved"`
+	UpdatedBy      string    `json:"updated_by" gorm:"size:100"`
+	UpdatedAt      time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// TableName specifies the version policy table name.
+// TableName 指定版本策略表名。
+func (VersionPolicy) TableName() string {
+	return "installer_version_policies"
+}
+
+// Restricts reports whether the policy rejects any version at all.
+// Restricts 判断策略是否会拒绝任何版本。
+func (p *VersionPolicy) Restricts() bool {
+	return p != nil && (p.MinimumVersion != "" || len(p.AllowedVersions) > 0 || len(p.DeniedVersions) > 0)
+}
+
+// Evaluate returns why the policy rejects version, or an empty string when it is approved.
+// Evaluate 返回策略拒绝该版本的原因，版本被批准时返回空字符串。
+func (p *VersionPolicy) Evaluate(version string) string {
+	if !p.Restricts() {
+		return ""
+	}
+	version = strings.TrimSpace(version)
+	for _, denied := range p.DeniedVersions {
+		if denied == version {
+			return fmt.Sprintf("%s is on the deny list / %s 已被禁止安装", version, version)
+		}
+	}
+	if len(p.AllowedVersions) > 0 {
+		allowed := false
+		for _, candidate := range p.AllowedVersions {
+			if candidate == version {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return fmt.Sprintf("%s is not on the allow list / %s 不在允许列表中", version, version)
+		}
+	}
+	if p.MinimumVersion != "" && compareVersions(version, p.MinimumVersion) < 0 {
+		return fmt.Sprintf("%s is older than the minimum supported version %s / %s 低于最低支持版本 %s",
+			version, p.MinimumVersion, version, p.MinimumVersion)
+	}
+	return ""
+}
+
+// Check returns ErrVersionNotApproved with the reason when the policy rejects version.
+// Check 在策略拒绝该版本时返回带原因的 ErrVersionNotApproved。
+func (p *VersionPolicy) Check(version string) error {
+	if reason := p.Evaluate(version); reason != "" {
+		return fmt.Errorf("%w: %s", ErrVersionNotApproved, reason)
+	}
+	return nil
+}
+
+// VersionPolicyRepository provides persistence access for the version policy.
+// VersionPolicyRepository 提供版本策略的持久化访问。
+type VersionPolicyRepository struct {
+	db *gorm.DB
+}
+
+// NewVersionPolicyRepository creates a new version policy repository.
+// NewVersionPolicyRepository 创建版本策略仓库实例。
+func NewVersionPolicyRepository(db *gorm.DB) *VersionPolicyRepository {
+	return &VersionPolicyRepository{db: db}
+}
+
+// Get returns the stored policy, or an empty policy when none was saved yet.
+// Get 返回已保存的策略，尚未保存时返回空策略。
+func (r *VersionPolicyRepository) Get(ctx context.Context) (*VersionPolicy, error) {
+	var policies []*VersionPolicy
+	if err := r.db.WithContext(ctx).Order("id ASC").Limit(1).Find(&policies).Error; err != nil {
+		return nil, err
+	}
+	if len(policies) == 0 {
+		return &VersionPolicy{AllowedVersions: VersionList{}, DeniedVersions: VersionList{}}, nil
+	}
+	return policies[0], nil
+}
+
+// Save stores the policy as the single policy row.
+// Save 将策略保存为唯一的一行记录。
+func (r *VersionPolicyRepository) Save(ctx context.Context, policy *VersionPolicy) error {
+	current, err := r.Get(ctx)
+	if err != nil {
+		return err
+	}
+	policy.ID = current.ID
+	return r.db.WithContext(ctx).Save(policy).Error
+}
+
+// SetVersionPolicyRepository sets the repository for the version policy.
+// SetVersionPolicyRepository 设置版本策略仓库。
+func (s *Service) SetVersionPolicyRepository(repo *VersionPolicyRepository) {
+	s.versionPolicyRepo = repo
+}
+
+// GetVersionPolicy returns the current version policy; without a repository every version is allowed.
+// GetVersionPolicy 返回当前版本策略；未配置仓库时允许所有版本。
+func (s *Service) GetVersionPolicy(ctx context.Context) (*VersionPolicy, error) {
+	if s.versionPolicyRepo == nil {
+		return &VersionPolicy{AllowedVersions: VersionList{}, DeniedVersions: VersionList{}}, nil
+	}
+	return s.versionPolicyRepo.Get(ctx)
+}
+
+// UpdateVersionPolicy validates and stores the version policy.
+// UpdateVersionPolicy 校验并保存版本策略。
+func (s *Service) UpdateVersionPolicy(ctx context.Context, policy *VersionPolicy, updatedBy string) (*VersionPolicy, error) {
+	if s.versionPolicyRepo == nil {
+		return nil, fmt.Errorf("%w: version policy storage is not configured / 未配置版本策略存储", ErrInvalidVersionPolicy)
+	}
+	normalized, err := normalizeVersionPolicy(policy)
+	if err != nil {
+		return nil, err
+	}
+	normalized.UpdatedBy = updatedBy
+	if err := s.versionPolicyRepo.Save(ctx, normalized); err != nil {
+		return nil, err
+	}
+	return normalized, nil
+}
+
+// normalizeVersionPolicy trims, de-duplicates and validates the versions of a policy.
+// normalizeVersionPolicy 去除空白、去重并校验策略中的版本。
+func normalizeVersionPolicy(policy *VersionPolicy) (*VersionPolicy, error) {
+	if policy == nil {
+		return nil, fmt.Errorf("%w: policy is required / 策略不能为空", ErrInvalidVersionPolicy)
+	}
+	normalized := &VersionPolicy{
+		MinimumVersion: strings.TrimSpace(policy.MinimumVersion),
+		HideUnapproved: policy.HideUnapproved,
+	}
+	if normalized.MinimumVersion != "" && !packageVersionRegexp.MatchString(normalized.MinimumVersion) {
+		return nil, fmt.Errorf("%w: invalid minimum version %q / 最低版本不合法", ErrInvalidVersionPolicy, normalized.MinimumVersion)
+	}
+	var err error
+	if normalized.AllowedVersions, err = normalizeVersionList(policy.AllowedVersions); err != nil {
+		return nil, err
+	}
+	if normalized.DeniedVersions, err = normalizeVersionList(policy.DeniedVersions); err != nil {
+		return nil, err
+	}
+	for _, denied := range normalized.DeniedVersions {
+		for _, allowed := range normalized.AllowedVersions {
+			if denied == allowed {
+				return nil, fmt.Errorf("%w: %s is both allowed and denied / %s 同时出现在允许与禁止列表中", ErrInvalidVersionPolicy, denied, denied)
+			}
+		}
+	}
+	return normalized, nil
+}
+
+func normalizeVersionList(versions VersionList) (VersionList, error) {
+	seen := make(map[string]struct{}, len(versions))
+	result := VersionList{}
+	for _, version := range versions {
+		version = strings.TrimSpace(version)
+		if version == "" {
+			continue
+		}
+		if !packageVersionRegexp.MatchString(version) {
+			return nil, fmt.Errorf("%w: invalid version %q / 版本号不合法", ErrInvalidVersionPolicy, version)
+		}
+		if _, ok := seen[version]; ok {
+			continue
+		}
+		seen[version] = struct{}{}
+		result = append(result, version)
+	}
+	sort.Slice(result, func(i, j int) bool { return compareVersions(result[i], result[j]) > 0 })
+	return result, nil
+}
+
+// checkVersionPolicy rejects installing a version the policy does not approve.
+// checkVersionPolicy 拒绝安装策略未批准的版本。
+func (s *Service) checkVersionPolicy(ctx context.Context, version string) error {
+	policy, err := s.GetVersionPolicy(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load version policy: %w", err)
+	}
+	return policy.Check(version)
+}
+
+// applyVersionPolicy marks, or hides when configured, the versions the policy does not approve
+// and recommends the newest approved version.
+// applyVersionPolicy 标记（或按配置隐藏）策略未批准的版本，并推荐最新的已批准版本。
+func (s *Service) applyVersionPolicy(ctx context.Context, result *AvailableVersions) {
+	policy, err := s.GetVersionPolicy(ctx)
+	if err != nil {
+		logger.WarnF(ctx, "[Installer] 加载版本策略失败 / Failed to load version policy: %v", err)
+		return
+	}
+	if !policy.Restricts() {
+		return
+	}
+	result.VersionPolicy = policy
+	result.UnapprovedVersions = make(map[string]string)
+	for _, version := range result.Versions {
+		if reason := policy.Evaluate(version); reason != "" {
+			result.UnapprovedVersions[version] = reason
+		}
+	}
+	for _, pkg := range result.LocalPackages {
+		if reason := policy.Evaluate(pkg.Version); pkg.Version != "" && reason != "" {
+			result.UnapprovedVersions[pkg.Version] = reason
+		}
+	}
+
+	approved := make([]string, 0, len(result.Versions))
+	for _, version := range result.Versions {
+		if _, rejected := result.UnapprovedVersions[version]; !rejected {
+			approved = append(approved, version)
+		}
+	}
+	if policy.HideUnapproved {
+		result.Versions = approved
+	}
+	if _, rejected := result.UnapprovedVersions[result.RecommendedVersion]; rejected {
+		result.RecommendedVersion = ""
+		if len(approved) > 0 {
+			result.RecommendedVersion = approved[0]
+		}
+	}
+}
@@ -0,0 +1,79 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package installer
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/seatunnel/seatunnelX/internal/apps/auth"
+	"github.com/seatunnel/seatunnelX/internal/apps/response"
+	"github.com/seatunnel/seatunnelX/internal/logger"
+)
+
+// VersionPolicyResponse is the response for the installer version policy.
+// VersionPolicyResponse 是安装版本策略的响应。
+type VersionPolicyResponse struct {
+	response.Meta
+	Data *VersionPolicy `json:"data"`
+}
+
+// GetVersionPolicy handles GET /api/v1/admin/installer/version-policy - returns the installable version policy.
+// GetVersionPolicy 处理 GET /api/v1/admin/installer/version-policy - 返回可安装版本策略。
+// @Tags admin
+// @Produce json
+// @Success 200 {object} VersionPolicyResponse
+// @Router /api/v1/admin/installer/version-policy [get]
+func (h *Handler) GetVersionPolicy(c *gin.Context) {
+	policy, err := h.service.GetVersionPolicy(c.Request.Context())
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	response.OK(c, policy)
+}
+
+// UpdateVersionPolicy handles PUT /api/v1/admin/installer/version-policy - replaces the installable version policy.
+// UpdateVersionPolicy 处理 PUT /api/v1/admin/installer/version-policy - 替换可安装版本策略。
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param request body VersionPolicy true "版本策略 / Version policy"
+// @Success 200 {object} VersionPolicyResponse
+// @Router /api/v1/admin/installer/version-policy [put]
+func (h *Handler) UpdateVersionPolicy(c *gin.Context) {
+	var policy VersionPolicy
+	if err := c.ShouldBindJSON(&policy); err != nil {
+		response.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	saved, err := h.service.UpdateVersionPolicy(c.Request.Context(), &policy, auth.GetUsernameFromContext(c))
+	if err != nil {
+		if errors.Is(err, ErrInvalidVersionPolicy) {
+			response.Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		response.Error(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	logger.InfoF(c.Request.Context(), "[Installer] 修改安装版本策略 / Installation version policy changed: user=%d, minimum=%q, allowed=%v, denied=%v",
+		auth.GetUserIDFromContext(c), saved.MinimumVersion, saved.AllowedVersions, saved.DeniedVersions)
+	response.OK(c, saved)
+}
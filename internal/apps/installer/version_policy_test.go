@@ -0,0 +1,147 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package installer
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func newVersionPolicyTestService(t *testing.T) *Service {
+	t.Helper()
+
+	database, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "test.db")), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	if err := database.AutoMigrate(&VersionPolicy{}); err != nil {
+		t.Fatalf("failed to migrate database: %v", err)
+	}
+
+	service := NewService(t.TempDir(), nil)
+	service.SetVersionPolicyRepository(NewVersionPolicyRepository(database))
+	return service
+}
+
+func TestVersionPolicyEvaluate(t *testing.T) {
+	policy := &VersionPolicy{
+		MinimumVersion:  "2.3.8",
+		AllowedVersions: VersionList{"2.3.12", "2.3.11", "2.3.7"},
+		DeniedVersions:  VersionList{"2.3.11"},
+	}
+
+	if reason := policy.Evaluate("2.3.12"); reason != "" {
+		t.Fatalf("expected 2.3.12 to be approved, got %q", reason)
+	}
+	for _, version := range []string{"2.3.11", "2.3.10", "2.3.7"} {
+		if err := policy.Check(version); !errors.Is(err, ErrVersionNotApproved) {
+			t.Fatalf("expected %s to be rejected, got %v", version, err)
+		}
+	}
+	if reason := (&VersionPolicy{}).Evaluate("2.3.1"); reason != "" {
+		t.Fatalf("expected an empty policy to approve every version, got %q", reason)
+	}
+}
+
+func TestUpdateVersionPolicyNormalizesAndPersists(t *testing.T) {
+	service := newVersionPolicyTestService(t)
+	ctx := context.Background()
+
+	if _, err := service.UpdateVersionPolicy(ctx, &VersionPolicy{
+		AllowedVersions: VersionList{"2.3.12"},
+		DeniedVersions:  VersionList{"2.3.12"},
+	}, "admin"); !errors.Is(err, ErrInvalidVersionPolicy) {
+		t.Fatalf("expected a conflicting policy to be rejected, got %v", err)
+	}
+
+	for _, allowed := range []VersionList{{"2.3.11"}, {" 2.3.11 ", "2.3.12", "2.3.11", ""}} {
+		if _, err := service.UpdateVersionPolicy(ctx, &VersionPolicy{MinimumVersion: "2.3.10", AllowedVersions: allowed}, "admin"); err != nil {
+			t.Fatalf("UpdateVersionPolicy returned error: %v", err)
+		}
+	}
+
+	policy, err := service.GetVersionPolicy(ctx)
+	if err != nil {
+		t.Fatalf("GetVersionPolicy returned error: %v", err)
+	}
+	if policy.ID == 0 || policy.UpdatedBy != "admin" || policy.MinimumVersion != "2.3.10" {
+		t.Fatalf("unexpected stored policy: %+v", policy)
+	}
+	if len(policy.AllowedVersions) != 2 || policy.AllowedVersions[0] != "2.3.12" || policy.AllowedVersions[1] != "2.3.11" {
+		t.Fatalf("expected allowed versions to be de-duplicated and sorted, got %v", policy.AllowedVersions)
+	}
+
+	var count int64
+	if err := service.versionPolicyRepo.db.Model(&VersionPolicy{}).Count(&count).Error; err != nil {
+		t.Fatalf("failed to count policies: %v", err)
+	}
+	if count != 1 {
+		t.Fatalf("expected a single policy row, got %d", count)
+	}
+
+	if err := service.checkVersionPolicy(ctx, "2.3.9"); !errors.Is(err, ErrVersionNotApproved) {
+		t.Fatalf("expected installation of 2.3.9 to be rejected, got %v", err)
+	}
+}
+
+func TestApplyVersionPolicyMarksOrHidesVersions(t *testing.T) {
+	service := newVersionPolicyTestService(t)
+	ctx := context.Background()
+
+	newResult := func() *AvailableVersions {
+		return &AvailableVersions{
+			Versions:           []string{"2.3.12", "2.3.11", "2.3.10"},
+			RecommendedVersion: "2.3.12",
+		}
+	}
+
+	result := newResult()
+	service.applyVersionPolicy(ctx, result)
+	if result.VersionPolicy != nil || result.UnapprovedVersions != nil {
+		t.Fatalf("expected no policy annotations without a policy, got %+v", result)
+	}
+
+	if _, err := service.UpdateVersionPolicy(ctx, &VersionPolicy{DeniedVersions: VersionList{"2.3.12"}}, "admin"); err != nil {
+		t.Fatalf("UpdateVersionPolicy returned error: %v", err)
+	}
+	result = newResult()
+	service.applyVersionPolicy(ctx, result)
+	if len(result.Versions) != 3 || result.UnapprovedVersions["2.3.12"] == "" || len(result.UnapprovedVersions) != 1 {
+		t.Fatalf("expected 2.3.12 to be marked but listed, got %+v", result)
+	}
+	if result.RecommendedVersion != "2.3.11" {
+		t.Fatalf("expected the newest approved version to be recommended, got %q", result.RecommendedVersion)
+	}
+
+	if _, err := service.UpdateVersionPolicy(ctx, &VersionPolicy{MinimumVersion: "2.3.11", HideUnapproved: true}, "admin"); err != nil {
+		t.Fatalf("UpdateVersionPolicy returned error: %v", err)
+	}
+	result = newResult()
+	service.applyVersionPolicy(ctx, result)
+	if len(result.Versions) != 2 || result.Versions[1] != "2.3.11" || result.RecommendedVersion != "2.3.12" {
+		t.Fatalf("expected 2.3.10 to be hidden, got %+v", result)
+	}
+}
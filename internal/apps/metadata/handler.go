@@ -0,0 +1,124 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package metadata
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/seatunnel/seatunnelX/internal/apps/audit"
+	"github.com/seatunnel/seatunnelX/internal/apps/auth"
+	"github.com/seatunnel/seatunnelX/internal/apps/cluster"
+	"github.com/seatunnel/seatunnelX/internal/apps/host/sshdeploy"
+	"github.com/seatunnel/seatunnelX/internal/apps/response"
+)
+
+// Handler serves the metadata export/import API.
+// Handler 提供元数据导出/导入 API。
+type Handler struct {
+	service   *Service
+	auditRepo *audit.Repository
+}
+
+// NewHandler creates a metadata handler; auditRepo may be nil, in which case audit logging is skipped.
+// NewHandler 创建元数据处理器；auditRepo 可为 nil，此时不记录审计日志。
+func NewHandler(service *Service, auditRepo *audit.Repository) *Handler {
+	return &Handler{service: service, auditRepo: auditRepo}
+}
+
+// Export handles POST /api/v1/admin/metadata/export and returns the bundle as a JSON attachment.
+// Export 处理 POST /api/v1/admin/metadata/export，以 JSON 附件形式返回元数据包。
+func (h *Handler) Export(c *gin.Context) {
+	var req ExportRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			response.Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+	bundle, err := h.service.Export(c.Request.Context(), &req)
+	if err != nil {
+		response.Error(c, errorStatus(err), err.Error())
+		return
+	}
+	body, err := json.MarshalIndent(bundle, "", "  ")
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	_ = audit.RecordFromGin(c, h.auditRepo, auth.GetUserIDFromContext(c), auth.GetUsernameFromContext(c),
+		"export", "metadata", "", "", audit.AuditDetails{
+			"cluster_ids":            req.ClusterIDs,
+			"include_secrets":        req.IncludeSecrets,
+			"hosts":                  len(bundle.Hosts),
+			"clusters":               len(bundle.Clusters),
+			"installation_templates": len(bundle.InstallationTemplates),
+		})
+
+	filename := fmt.Sprintf("seatunnelx-metadata-%s.json", bundle.ExportedAt.Format("20060102-150405"))
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	c.Data(http.StatusOK, "application/json; charset=utf-8", body)
+}
+
+// Import handles POST /api/v1/admin/metadata/import.
+// Import 处理 POST /api/v1/admin/metadata/import，导入元数据包。
+func (h *Handler) Import(c *gin.Context) {
+	var req ImportRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	result, err := h.service.Import(c.Request.Context(), &req, uint(auth.GetUserIDFromContext(c)))
+	if err != nil {
+		response.Error(c, errorStatus(err), err.Error())
+		return
+	}
+	if !req.DryRun {
+		_ = audit.RecordFromGin(c, h.auditRepo, auth.GetUserIDFromContext(c), auth.GetUsernameFromContext(c),
+			"import", "metadata", "", "", audit.AuditDetails{
+				"strategy":    result.Strategy,
+				"secrets":     req.Bundle.Secrets.Included && req.Passphrase != "",
+				"created":     result.Counts[ActionCreated],
+				"overwritten": result.Counts[ActionOverwritten],
+				"renamed":     result.Counts[ActionRenamed],
+				"skipped":     result.Counts[ActionSkipped],
+				"failed":      result.Counts[ActionFailed],
+			})
+	}
+	response.OK(c, result)
+}
+
+// errorStatus maps service errors to HTTP status codes.
+// errorStatus 将服务错误映射为 HTTP 状态码。
+func errorStatus(err error) int {
+	switch {
+	case errors.Is(err, cluster.ErrClusterNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrInvalidBundle), errors.Is(err, ErrUnsupportedSchema), errors.Is(err, ErrInvalidStrategy),
+		errors.Is(err, ErrPassphraseRequired), errors.Is(err, ErrPassphraseMismatch):
+		return http.StatusBadRequest
+	case errors.Is(err, sshdeploy.ErrSecretMissing):
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,652 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package metadata
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"github.com/seatunnel/seatunnelX/internal/apps/auth"
+	"github.com/seatunnel/seatunnelX/internal/apps/cluster"
+	appconfig "github.com/seatunnel/seatunnelX/internal/apps/config"
+	"github.com/seatunnel/seatunnelX/internal/apps/host"
+	"github.com/seatunnel/seatunnelX/internal/apps/host/sshdeploy"
+	"github.com/seatunnel/seatunnelX/internal/apps/installer"
+	"github.com/seatunnel/seatunnelX/internal/apps/plugin"
+	"github.com/seatunnel/seatunnelX/internal/pkg/secrets"
+)
+
+// errDryRun rolls back the import transaction of a dry run.
+// errDryRun 用于回滚试运行的导入事务。
+var errDryRun = errors.New("metadata: dry run")
+
+// renameSuffix is appended to conflicting names by StrategyRename.
+// renameSuffix 由 StrategyRename 追加到冲突名称之后。
+const renameSuffix = "-imported"
+
+// importComment is recorded on config versions written by an import.
+// importComment 记录在导入写入的配置版本上。
+const importComment = "imported from metadata bundle / 从元数据包导入"
+
+// pendingCredential is an SSH credential saved once the import transaction has committed.
+// pendingCredential 是在导入事务提交后保存的 SSH 凭证。
+type pendingCredential struct {
+	hostID   uint
+	hostName string
+	req      *sshdeploy.SaveCredentialRequest
+}
+
+// importer applies one bundle inside a transaction.
+// importer 在一个事务中应用一个元数据包。
+type importer struct {
+	ctx      context.Context
+	tx       *gorm.DB
+	bundle   *Bundle
+	strategy ConflictStrategy
+	userID   uint
+	// box opens bundle secrets; nil when the bundle carries none or no passphrase was given.
+	// box 用于解密包内密钥；包不含密钥或未提供口令时为 nil。
+	box         *secrets.Box
+	projects    map[string]uint
+	hostIDs     map[string]uint
+	credentials []pendingCredential
+	result      *ImportResult
+}
+
+// Import applies a bundle. Templates, hosts and clusters are written in one transaction, so a
+// failing statement leaves nothing behind; records rejected by validation are reported and skipped.
+// Secrets still masked in the bundle keep the stored value on overwrite and stay empty otherwise.
+// SSH credentials are saved after the transaction commits.
+// Import 应用元数据包。安装模板、主机与集群在同一事务中写入，语句失败时不会留下任何数据；未通过校验的
+// 记录会被报告并跳过。包中仍被屏蔽的密钥在覆盖时保留已保存的值，其余情况留空。SSH 凭证在事务提交后保存。
+func (s *Service) Import(ctx context.Context, req *ImportRequest, userID uint) (*ImportResult, error) {
+	if req == nil || req.Bundle == nil {
+		return nil, ErrInvalidBundle
+	}
+	bundle := req.Bundle
+	if bundle.SchemaVersion < 1 {
+		return nil, ErrInvalidBundle
+	}
+	if bundle.SchemaVersion > SchemaVersion {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedSchema, bundle.SchemaVersion)
+	}
+	strategy := req.Strategy
+	if strategy == "" {
+		strategy = StrategySkip
+	}
+	switch strategy {
+	case StrategySkip, StrategyOverwrite, StrategyRename:
+	default:
+		return nil, ErrInvalidStrategy
+	}
+
+	var box *secrets.Box
+	if bundle.Secrets.Included && req.Passphrase != "" {
+		var err error
+		if box, err = passphraseBox(req.Passphrase); err != nil {
+			return nil, err
+		}
+		if verifier, err := box.Open(bundle.Secrets.Verifier); err != nil || verifier != verifierPlaintext {
+			return nil, ErrPassphraseMismatch
+		}
+	}
+
+	result := &ImportResult{DryRun: req.DryRun, Strategy: strategy, Items: []ImportItem{}, Counts: map[string]int{}}
+	var credentials []pendingCredential
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		im := &importer{
+			ctx:      ctx,
+			tx:       tx,
+			bundle:   bundle,
+			strategy: strategy,
+			userID:   userID,
+			box:      box,
+			hostIDs:  make(map[string]uint),
+			result:   result,
+		}
+		if err := im.run(); err != nil {
+			return err
+		}
+		credentials = im.credentials
+		if req.DryRun {
+			return errDryRun
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errDryRun) {
+		return nil, err
+	}
+
+	for _, cred := range credentials {
+		item := ImportItem{Kind: KindSSHCredential, Name: cred.hostName, Action: ActionCreated}
+		if s.credentials == nil {
+			item.Action, item.Message = ActionSkipped, "SSH deployment is disabled / 未启用 SSH 部署"
+		} else if !req.DryRun {
+			if _, err := s.credentials.SaveCredential(ctx, cred.hostID, cred.req); err != nil {
+				item.Action, item.Message = ActionFailed, err.Error()
+			}
+		}
+		result.add(item)
+	}
+	return result, nil
+}
+
+func (im *importer) run() error {
+	var projects []auth.Project
+	if err := im.tx.Find(&projects).Error; err != nil {
+		return err
+	}
+	im.projects = make(map[string]uint, len(projects))
+	for _, p := range projects {
+		im.projects[p.Name] = p.ID
+	}
+
+	for i := range im.bundle.InstallationTemplates {
+		if err := im.importTemplate(&im.bundle.InstallationTemplates[i]); err != nil {
+			return err
+		}
+	}
+	for i := range im.bundle.Hosts {
+		if err := im.importHost(&im.bundle.Hosts[i]); err != nil {
+			return err
+		}
+	}
+	for i := range im.bundle.Clusters {
+		if err := im.importCluster(&im.bundle.Clusters[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// openSecret decrypts a bundle secret. Without a box, sealed values become the mask so they are
+// handled like a bundle exported without secrets.
+// openSecret 解密包内密钥。没有 box 时加密值被视为掩码，与未携带密钥导出的包按相同方式处理。
+func (im *importer) openSecret(value string) (string, error) {
+	if im.box != nil {
+		return im.box.Open(value)
+	}
+	if secrets.IsSealed(value) {
+		return secrets.Mask, nil
+	}
+	return value, nil
+}
+
+func (im *importer) openMap(m map[string]interface{}) (map[string]interface{}, error) {
+	if im.box != nil {
+		return im.box.OpenMap(m)
+	}
+	if im.bundle.Secrets.Included {
+		return secrets.RedactMap(m), nil
+	}
+	return m, nil
+}
+
+// projectID maps a project name to its ID here; unknown projects fall back to shared (0).
+// projectID 将项目名称映射为本环境的 ID；未知项目回退为共享（0）。
+func (im *importer) projectID(name string, item *ImportItem) uint {
+	if name == "" {
+		return 0
+	}
+	if id, ok := im.projects[name]; ok {
+		return id
+	}
+	item.Message = fmt.Sprintf("project %q not found, imported as shared / 项目 %q 不存在，按共享资源导入", name, name)
+	return 0
+}
+
+// findByName loads the record named name into dest, including one in the recycle bin.
+// findByName 将名称为 name 的记录（包括回收站中的记录）加载到 dest。
+func (im *importer) findByName(dest interface{}, name string) (bool, error) {
+	err := im.tx.Unscoped().Where("name = ?", name).Take(dest).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// freeName returns the first unused "<name>-imported[-N]" name.
+// freeName 返回第一个未被占用的 "<name>-imported[-N]" 名称。
+func (im *importer) freeName(model interface{}, name string) (string, error) {
+	for i := 1; ; i++ {
+		candidate := name + renameSuffix
+		if i > 1 {
+			candidate = fmt.Sprintf("%s-%d", candidate, i)
+		}
+		var count int64
+		if err := im.tx.Unscoped().Model(model).Where("name = ?", candidate).Count(&count).Error; err != nil {
+			return "", err
+		}
+		if count == 0 {
+			return candidate, nil
+		}
+	}
+}
+
+// conflict decides what to do with a record whose name exists. It returns the name to create the
+// record under, or "" with the item already finished when the existing record is kept.
+// conflict 决定名称已存在的记录如何处理。返回用于创建记录的名称；保留已有记录时返回 "" 且条目已完成。
+func (im *importer) conflict(model interface{}, item *ImportItem, deleted bool) (string, error) {
+	switch im.strategy {
+	case StrategyRename:
+		name, err := im.freeName(model, item.Name)
+		if err != nil {
+			return "", err
+		}
+		item.Action, item.NewName = ActionRenamed, name
+		return name, nil
+	case StrategyOverwrite:
+		if deleted {
+			item.Action = ActionFailed
+			item.Message = "the existing record is in the recycle bin, restore or purge it first / 已有记录位于回收站，请先恢复或彻底删除"
+			return "", nil
+		}
+		item.Action = ActionOverwritten
+		return "", nil
+	default:
+		item.Action, item.Message = ActionSkipped, "already exists / 已存在"
+		return "", nil
+	}
+}
+
+func (im *importer) importTemplate(rec *InstallationTemplate) error {
+	item := ImportItem{Kind: KindInstallationTemplate, Name: rec.Name}
+	defer func() { im.result.add(item) }()
+
+	spec, err := rec.Spec.MapSecrets(im.openSecret)
+	if err != nil {
+		return err
+	}
+	var existing installer.InstallationTemplate
+	found, err := im.findByName(&existing, rec.Name)
+	if err != nil {
+		return err
+	}
+	name := rec.Name
+	if found {
+		if name, err = im.conflict(&installer.InstallationTemplate{}, &item, false); err != nil {
+			return err
+		}
+		if item.Action == ActionOverwritten {
+			existing.Description = rec.Description
+			existing.Spec = clearMaskedSpec(spec.WithStoredSecrets(existing.Spec))
+			return im.tx.Save(&existing).Error
+		}
+		if name == "" {
+			return nil
+		}
+	} else {
+		item.Action = ActionCreated
+	}
+	return im.tx.Create(&installer.InstallationTemplate{
+		Name:        name,
+		Description: rec.Description,
+		Spec:        clearMaskedSpec(spec),
+		CreatedBy:   im.userID,
+	}).Error
+}
+
+func clearMaskedSpec(spec installer.InstallationTemplateSpec) installer.InstallationTemplateSpec {
+	cleared, _ := spec.MapSecrets(func(value string) (string, error) {
+		return secrets.RestoreMaskedString(value, ""), nil
+	})
+	return cleared
+}
+
+func (im *importer) importHost(rec *HostRecord) error {
+	item := ImportItem{Kind: KindHost, Name: rec.Name}
+	defer func() { im.result.add(item) }()
+
+	kubeconfig, err := im.openSecret(rec.K8sKubeconfig)
+	if err != nil {
+		return err
+	}
+	token, err := im.openSecret(rec.K8sToken)
+	if err != nil {
+		return err
+	}
+	var existing host.Host
+	found, err := im.findByName(&existing, rec.Name)
+	if err != nil {
+		return err
+	}
+
+	target := &host.Host{}
+	name := rec.Name
+	if found {
+		if name, err = im.conflict(&host.Host{}, &item, existing.DeletedAt.Valid); err != nil {
+			return err
+		}
+		switch {
+		case item.Action == ActionOverwritten:
+			target = &existing
+			name = existing.Name
+			kubeconfig = secrets.RestoreMaskedString(kubeconfig, existing.K8sKubeconfig)
+			token = secrets.RestoreMaskedString(token, existing.K8sToken)
+		case name == "":
+			if item.Action == ActionSkipped && !existing.DeletedAt.Valid {
+				im.hostIDs[rec.Name] = existing.ID
+			}
+			return nil
+		}
+	} else {
+		item.Action = ActionCreated
+	}
+
+	target.Name = name
+	target.HostType = rec.HostType
+	target.Description = rec.Description
+	target.Labels = rec.Labels
+	target.ProjectID = im.projectID(rec.ProjectName, &item)
+	target.IPAddress = rec.IPAddress
+	target.SSHPort = rec.SSHPort
+	target.SSHUser = rec.SSHUser
+	target.OSType = rec.OSType
+	target.Arch = rec.Arch
+	target.CPUCores = rec.CPUCores
+	target.TotalMemory = rec.TotalMemory
+	target.TotalDisk = rec.TotalDisk
+	target.DockerAPIURL = rec.DockerAPIURL
+	target.DockerTLSEnabled = rec.DockerTLSEnabled
+	target.DockerCertPath = rec.DockerCertPath
+	target.K8sAPIURL = rec.K8sAPIURL
+	target.K8sNamespace = rec.K8sNamespace
+	target.K8sKubeconfig = secrets.RestoreMaskedString(kubeconfig, "")
+	target.K8sToken = secrets.RestoreMaskedString(token, "")
+
+	repo := host.NewRepository(im.tx)
+	if item.Action == ActionOverwritten {
+		err = repo.Update(im.ctx, target)
+	} else {
+		target.CreatedBy = im.userID
+		err = repo.Create(im.ctx, target)
+	}
+	if isHostValidationError(err) {
+		item.Action, item.NewName, item.Message = ActionFailed, "", err.Error()
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	im.hostIDs[rec.Name] = target.ID
+
+	if rec.SSHCredential != nil && im.box == nil {
+		im.result.add(ImportItem{Kind: KindSSHCredential, Name: target.Name, Action: ActionSkipped,
+			Message: "passphrase not provided / 未提供口令"})
+	}
+	if rec.SSHCredential != nil && im.box != nil {
+		cred := *rec.SSHCredential
+		for _, field := range []*string{&cred.Password, &cred.PrivateKey, &cred.Passphrase} {
+			if *field, err = im.box.Open(*field); err != nil {
+				return err
+			}
+		}
+		im.credentials = append(im.credentials, pendingCredential{hostID: target.ID, hostName: target.Name, req: &cred})
+	}
+	return nil
+}
+
+func isHostValidationError(err error) bool {
+	return errors.Is(err, host.ErrHostNameEmpty) || errors.Is(err, host.ErrHostNameDuplicate) ||
+		errors.Is(err, host.ErrHostIPInvalid) || errors.Is(err, host.ErrHostIPDuplicate)
+}
+
+func (im *importer) importCluster(rec *ClusterRecord) error {
+	item := ImportItem{Kind: KindCluster, Name: rec.Name}
+	defer func() { im.result.add(item) }()
+
+	if rec.Name == "" {
+		item.Action, item.Message = ActionFailed, cluster.ErrClusterNameEmpty.Error()
+		return nil
+	}
+	nodes, err := im.resolveNodes(rec.Nodes)
+	if err != nil {
+		var missing *missingHostError
+		if errors.As(err, &missing) {
+			item.Action, item.Message = ActionFailed, err.Error()
+			return nil
+		}
+		return err
+	}
+	config, err := im.openMap(rec.Config)
+	if err != nil {
+		return err
+	}
+
+	var existing cluster.Cluster
+	found, err := im.findByName(&existing, rec.Name)
+	if err != nil {
+		return err
+	}
+	target := &cluster.Cluster{}
+	name := rec.Name
+	if found {
+		if name, err = im.conflict(&cluster.Cluster{}, &item, existing.DeletedAt.Valid); err != nil {
+			return err
+		}
+		switch {
+		case item.Action == ActionOverwritten:
+			target = &existing
+			name = existing.Name
+			config = secrets.RestoreMasked(config, existing.Config)
+		case name == "":
+			return nil
+		}
+	} else {
+		item.Action = ActionCreated
+	}
+
+	target.Name = name
+	target.Description = rec.Description
+	target.DeploymentMode = rec.DeploymentMode
+	target.Version = rec.Version
+	target.Source = rec.Source
+	target.InstallDir = rec.InstallDir
+	target.Config = secrets.ClearMasked(config)
+	target.NodeSelectors = rec.NodeSelectors
+	target.ProjectID = im.projectID(rec.ProjectName, &item)
+
+	if item.Action == ActionOverwritten {
+		if err := im.tx.Omit("Nodes").Save(target).Error; err != nil {
+			return err
+		}
+	} else {
+		target.CreatedBy = im.userID
+		if err := im.tx.Omit("Nodes").Create(target).Error; err != nil {
+			return err
+		}
+	}
+	if err := im.replaceNodes(target.ID, nodes); err != nil {
+		return err
+	}
+	if err := im.importConfigTemplates(target.ID, rec.ConfigTemplates); err != nil {
+		return err
+	}
+	return im.replacePlugins(target.ID, rec.Plugins)
+}
+
+// missingHostError reports a node whose host is neither in the bundle nor in this environment.
+// missingHostError 表示节点引用的主机既不在包中也不在当前环境中。
+type missingHostError struct {
+	name string
+}
+
+func (e *missingHostError) Error() string {
+	return fmt.Sprintf("node host %q not found / 节点主机 %q 不存在", e.name, e.name)
+}
+
+// resolveNodes maps node host names to host IDs, preferring hosts imported from the bundle.
+// resolveNodes 将节点主机名映射为主机 ID，优先使用从包中导入的主机。
+func (im *importer) resolveNodes(records []NodeRecord) ([]cluster.ClusterNode, error) {
+	nodes := make([]cluster.ClusterNode, 0, len(records))
+	for _, rec := range records {
+		hostID, ok := im.hostIDs[rec.HostName]
+		if !ok {
+			var h host.Host
+			err := im.tx.Where("name = ?", rec.HostName).Take(&h).Error
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, &missingHostError{name: rec.HostName}
+			}
+			if err != nil {
+				return nil, err
+			}
+			hostID = h.ID
+		}
+		nodes = append(nodes, cluster.ClusterNode{
+			HostID:        hostID,
+			Role:          rec.Role,
+			InstallDir:    rec.InstallDir,
+			HazelcastPort: rec.HazelcastPort,
+			APIPort:       rec.APIPort,
+			WorkerPort:    rec.WorkerPort,
+			Overrides:     rec.Overrides.Normalize(),
+		})
+	}
+	return nodes, nil
+}
+
+// replaceNodes makes the cluster's nodes match nodes. A node on the same host with the same role
+// keeps its ID and runtime status.
+// replaceNodes 使集群节点与 nodes 一致。相同主机且相同角色的节点保留其 ID 与运行状态。
+func (im *importer) replaceNodes(clusterID uint, nodes []cluster.ClusterNode) error {
+	var current []cluster.ClusterNode
+	if err := im.tx.Where("cluster_id = ?", clusterID).Find(&current).Error; err != nil {
+		return err
+	}
+	kept := make(map[uint]bool, len(current))
+	for i := range nodes {
+		node := &nodes[i]
+		node.ClusterID = clusterID
+		for _, existing := range current {
+			if existing.HostID == node.HostID && existing.Role == node.Role && !kept[existing.ID] {
+				node.ID, node.Status, node.ProcessPID = existing.ID, existing.Status, existing.ProcessPID
+				node.LastEventAt, node.CreatedAt = existing.LastEventAt, existing.CreatedAt
+				kept[existing.ID] = true
+				break
+			}
+		}
+		if err := im.tx.Save(node).Error; err != nil {
+			return err
+		}
+	}
+	for _, existing := range current {
+		if !kept[existing.ID] {
+			if err := im.tx.Delete(&cluster.ClusterNode{}, existing.ID).Error; err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// importConfigTemplates upserts the cluster-level config templates, recording a version for every change.
+// importConfigTemplates 写入集群级配置模板，每次变更都会记录一个版本。
+func (im *importer) importConfigTemplates(clusterID uint, records []ConfigTemplateRecord) error {
+	for _, rec := range records {
+		var current appconfig.Config
+		err := im.tx.Where("cluster_id = ? AND host_id IS NULL AND config_type = ?", clusterID, rec.ConfigType).Take(&current).Error
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			current = appconfig.Config{
+				ClusterID:  clusterID,
+				ConfigType: rec.ConfigType,
+				FilePath:   rec.FilePath,
+				Content:    rec.Content,
+				Version:    1,
+				UpdatedBy:  im.userID,
+			}
+			if err := im.tx.Create(&current).Error; err != nil {
+				return err
+			}
+		case err != nil:
+			return err
+		case current.Content == rec.Content:
+			continue
+		default:
+			current.Content = rec.Content
+			if rec.FilePath != "" {
+				current.FilePath = rec.FilePath
+			}
+			current.Version++
+			current.UpdatedBy = im.userID
+			if err := im.tx.Save(&current).Error; err != nil {
+				return err
+			}
+		}
+		if err := im.tx.Create(&appconfig.ConfigVersion{
+			ConfigID:  current.ID,
+			Version:   current.Version,
+			Content:   current.Content,
+			Comment:   importComment,
+			CreatedBy: im.userID,
+		}).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// replacePlugins makes the cluster's installed-plugin records match records, keyed by plugin name.
+// replacePlugins 以插件名称为键，使集群的已安装插件记录与 records 一致。
+func (im *importer) replacePlugins(clusterID uint, records []PluginRecord) error {
+	var current []plugin.InstalledPlugin
+	if err := im.tx.Where("cluster_id = ?", clusterID).Find(&current).Error; err != nil {
+		return err
+	}
+	byName := make(map[string]plugin.InstalledPlugin, len(current))
+	for _, p := range current {
+		byName[p.PluginName] = p
+	}
+	wanted := make(map[string]bool, len(records))
+	for _, rec := range records {
+		wanted[rec.PluginName] = true
+		p := byName[rec.PluginName]
+		p.ClusterID = clusterID
+		p.PluginName = rec.PluginName
+		p.ArtifactID = rec.ArtifactID
+		p.Category = rec.Category
+		p.Version = rec.Version
+		p.Status = rec.Status
+		p.InstallPath = rec.InstallPath
+		p.InstalledAt = rec.InstalledAt
+		p.Dependencies = rec.Dependencies
+		if p.Status == "" {
+			p.Status = plugin.PluginStatusInstalled
+		}
+		if p.InstalledAt.IsZero() {
+			p.InstalledAt = im.tx.NowFunc()
+		}
+		if p.ID == 0 {
+			p.InstalledBy = im.userID
+		}
+		if err := im.tx.Save(&p).Error; err != nil {
+			return err
+		}
+	}
+	for _, p := range current {
+		if !wanted[p.PluginName] {
+			if err := im.tx.Delete(&plugin.InstalledPlugin{}, p.ID).Error; err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
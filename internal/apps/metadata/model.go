@@ -0,0 +1,252 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package metadata exports Control Plane metadata to a portable JSON bundle and imports it into
+// another environment.
+// metadata 包将控制面元数据导出为可迁移的 JSON 包，并导入到另一个环境。
+package metadata
+
+import (
+	"errors"
+	"time"
+
+	"github.com/seatunnel/seatunnelX/internal/apps/cluster"
+	appconfig "github.com/seatunnel/seatunnelX/internal/apps/config"
+	"github.com/seatunnel/seatunnelX/internal/apps/host"
+	"github.com/seatunnel/seatunnelX/internal/apps/host/sshdeploy"
+	"github.com/seatunnel/seatunnelX/internal/apps/installer"
+	"github.com/seatunnel/seatunnelX/internal/apps/plugin"
+)
+
+// SchemaVersion is the bundle format written by Export. Import rejects newer versions.
+// SchemaVersion 是 Export 写出的包格式版本，Import 拒绝更新的版本。
+const SchemaVersion = 1
+
+// minPassphraseLength is the shortest passphrase accepted for bundles carrying secrets.
+// minPassphraseLength 是携带密钥的包所接受的最短口令长度。
+const minPassphraseLength = 8
+
+var (
+	// ErrInvalidBundle indicates the bundle cannot be read.
+	// ErrInvalidBundle 表示无法读取该元数据包。
+	ErrInvalidBundle = errors.New("invalid metadata bundle / 元数据包无效")
+
+	// ErrUnsupportedSchema indicates the bundle was written by a newer version.
+	// ErrUnsupportedSchema 表示该包由更新的版本写出。
+	ErrUnsupportedSchema = errors.New("unsupported metadata bundle schema version / 不支持的元数据包格式版本")
+
+	// ErrInvalidStrategy indicates an unknown conflict strategy.
+	// ErrInvalidStrategy 表示未知的冲突处理策略。
+	ErrInvalidStrategy = errors.New("conflict strategy must be skip, overwrite or rename / 冲突策略必须为 skip、overwrite 或 rename")
+
+	// ErrPassphraseRequired indicates secrets were requested without a usable passphrase.
+	// ErrPassphraseRequired 表示请求包含密钥但未提供可用口令。
+	ErrPassphraseRequired = errors.New("a passphrase of at least 8 characters is required for secrets / 包含密钥时需要至少 8 个字符的口令")
+
+	// ErrPassphraseMismatch indicates the passphrase does not open the bundle secrets.
+	// ErrPassphraseMismatch 表示口令无法解密包中的密钥。
+	ErrPassphraseMismatch = errors.New("passphrase does not match the bundle / 口令与元数据包不匹配")
+)
+
+// ConflictStrategy decides what happens when an imported record's name already exists.
+// ConflictStrategy 决定导入记录名称已存在时的处理方式。
+type ConflictStrategy string
+
+const (
+	// StrategySkip keeps the existing record.
+	// StrategySkip 保留已有记录。
+	StrategySkip ConflictStrategy = "skip"
+	// StrategyOverwrite replaces the existing record with the imported one.
+	// StrategyOverwrite 用导入的记录替换已有记录。
+	StrategyOverwrite ConflictStrategy = "overwrite"
+	// StrategyRename imports the record under a new, unused name.
+	// StrategyRename 以未被占用的新名称导入记录。
+	StrategyRename ConflictStrategy = "rename"
+)
+
+// Bundle is the portable metadata document.
+// Bundle 是可迁移的元数据文档。
+type Bundle struct {
+	SchemaVersion         int                    `json:"schema_version"`
+	ExportedAt            time.Time              `json:"exported_at"`
+	Secrets               BundleSecrets          `json:"secrets"`
+	Hosts                 []HostRecord           `json:"hosts"`
+	Clusters              []ClusterRecord        `json:"clusters"`
+	InstallationTemplates []InstallationTemplate `json:"installation_templates"`
+}
+
+// BundleSecrets describes how secret values are carried. When Included is false every secret is
+// masked; otherwise they are sealed with a key derived from the export passphrase and Verifier
+// lets Import check the passphrase before touching any record.
+// BundleSecrets 描述密钥的携带方式。Included 为 false 时所有密钥均被屏蔽；否则使用由导出口令派生的
+// 密钥加密，Import 通过 Verifier 在修改任何记录前校验口令。
+type BundleSecrets struct {
+	Included bool   `json:"included"`
+	Verifier string `json:"verifier,omitempty"`
+}
+
+// HostRecord is an exported host. Runtime state such as agent status and usage is not carried.
+// HostRecord 是导出的主机，不携带 Agent 状态、资源使用率等运行时状态。
+type HostRecord struct {
+	Name             string                           `json:"name"`
+	HostType         host.HostType                    `json:"host_type"`
+	Description      string                           `json:"description,omitempty"`
+	Labels           host.HostLabels                  `json:"labels,omitempty"`
+	ProjectName      string                           `json:"project_name,omitempty"`
+	IPAddress        string                           `json:"ip_address,omitempty"`
+	SSHPort          int                              `json:"ssh_port,omitempty"`
+	SSHUser          string                           `json:"ssh_user,omitempty"`
+	OSType           string                           `json:"os_type,omitempty"`
+	Arch             string                           `json:"arch,omitempty"`
+	CPUCores         int                              `json:"cpu_cores,omitempty"`
+	TotalMemory      int64                            `json:"total_memory,omitempty"`
+	TotalDisk        int64                            `json:"total_disk,omitempty"`
+	DockerAPIURL     string                           `json:"docker_api_url,omitempty"`
+	DockerTLSEnabled bool                             `json:"docker_tls_enabled,omitempty"`
+	DockerCertPath   string                           `json:"docker_cert_path,omitempty"`
+	K8sAPIURL        string                           `json:"k8s_api_url,omitempty"`
+	K8sNamespace     string                           `json:"k8s_namespace,omitempty"`
+	K8sKubeconfig    string                           `json:"k8s_kubeconfig,omitempty"`
+	K8sToken         string                           `json:"k8s_token,omitempty"`
+	SSHCredential    *sshdeploy.SaveCredentialRequest `json:"ssh_credential,omitempty"`
+}
+
+// ClusterRecord is an exported cluster with its nodes, config templates and plugin records.
+// ClusterRecord 是导出的集群，包含节点、配置模板与插件记录。
+type ClusterRecord struct {
+	Name            string                 `json:"name"`
+	Description     string                 `json:"description,omitempty"`
+	DeploymentMode  cluster.DeploymentMode `json:"deployment_mode"`
+	Version         string                 `json:"version,omitempty"`
+	Source          cluster.ClusterSource  `json:"source,omitempty"`
+	InstallDir      string                 `json:"install_dir,omitempty"`
+	Config          map[string]interface{} `json:"config,omitempty"`
+	NodeSelectors   cluster.NodeSelectors  `json:"node_selectors,omitempty"`
+	ProjectName     string                 `json:"project_name,omitempty"`
+	Nodes           []NodeRecord           `json:"nodes"`
+	ConfigTemplates []ConfigTemplateRecord `json:"config_templates,omitempty"`
+	Plugins         []PluginRecord         `json:"plugins,omitempty"`
+}
+
+// NodeRecord is a cluster node; the host is referenced by name since IDs differ between environments.
+// NodeRecord 是集群节点；由于不同环境的 ID 不同，主机按名称引用。
+type NodeRecord struct {
+	HostName      string                `json:"host_name"`
+	Role          cluster.NodeRole      `json:"role"`
+	InstallDir    string                `json:"install_dir,omitempty"`
+	HazelcastPort int                   `json:"hazelcast_port,omitempty"`
+	APIPort       int                   `json:"api_port,omitempty"`
+	WorkerPort    int                   `json:"worker_port,omitempty"`
+	Overrides     cluster.NodeOverrides `json:"overrides,omitempty"`
+}
+
+// ConfigTemplateRecord is a cluster-level config template.
+// ConfigTemplateRecord 是集群级配置模板。
+type ConfigTemplateRecord struct {
+	ConfigType appconfig.ConfigType `json:"config_type"`
+	FilePath   string               `json:"file_path,omitempty"`
+	Content    string               `json:"content"`
+}
+
+// PluginRecord is an installed-plugin record of a cluster.
+// PluginRecord 是集群的已安装插件记录。
+type PluginRecord struct {
+	PluginName   string                             `json:"plugin_name"`
+	ArtifactID   string                             `json:"artifact_id,omitempty"`
+	Category     plugin.PluginCategory              `json:"category"`
+	Version      string                             `json:"version"`
+	Status       plugin.PluginStatus                `json:"status,omitempty"`
+	InstallPath  string                             `json:"install_path,omitempty"`
+	InstalledAt  time.Time                          `json:"installed_at"`
+	Dependencies plugin.InstalledPluginDependencies `json:"dependencies,omitempty"`
+}
+
+// InstallationTemplate is an exported installation template.
+// InstallationTemplate 是导出的安装模板。
+type InstallationTemplate struct {
+	Name        string                             `json:"name"`
+	Description string                             `json:"description,omitempty"`
+	Spec        installer.InstallationTemplateSpec `json:"spec"`
+}
+
+// ExportRequest selects what to export.
+// ExportRequest 指定导出内容。
+type ExportRequest struct {
+	// ClusterIDs limits the export to these clusters and the hosts they use; empty exports everything.
+	// ClusterIDs 将导出限制为这些集群及其使用的主机；为空时导出全部。
+	ClusterIDs []uint `json:"cluster_ids"`
+	// IncludeSecrets carries credentials sealed with Passphrase instead of masking them.
+	// IncludeSecrets 使用 Passphrase 加密并携带凭证，而不是屏蔽。
+	IncludeSecrets bool   `json:"include_secrets"`
+	Passphrase     string `json:"passphrase"`
+}
+
+// ImportRequest carries a bundle and how to apply it.
+// ImportRequest 携带元数据包及其应用方式。
+type ImportRequest struct {
+	Bundle   *Bundle          `json:"bundle" binding:"required"`
+	Strategy ConflictStrategy `json:"strategy"`
+	// Passphrase opens bundle secrets; without it secrets are left empty on new records.
+	// Passphrase 用于解密包中的密钥；未提供时新记录的密钥留空。
+	Passphrase string `json:"passphrase"`
+	// DryRun reports what would happen and rolls everything back.
+	// DryRun 仅报告将要执行的操作并全部回滚。
+	DryRun bool `json:"dry_run"`
+}
+
+// Import item kinds.
+// 导入条目类型。
+const (
+	KindHost                 = "host"
+	KindCluster              = "cluster"
+	KindInstallationTemplate = "installation_template"
+	KindSSHCredential        = "ssh_credential"
+)
+
+// Import actions.
+// 导入动作。
+const (
+	ActionCreated     = "created"
+	ActionOverwritten = "overwritten"
+	ActionRenamed     = "renamed"
+	ActionSkipped     = "skipped"
+	ActionFailed      = "failed"
+)
+
+// ImportItem reports the outcome for one record.
+// ImportItem 报告单条记录的导入结果。
+type ImportItem struct {
+	Kind    string `json:"kind"`
+	Name    string `json:"name"`
+	Action  string `json:"action"`
+	NewName string `json:"new_name,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// ImportResult summarizes an import.
+// ImportResult 汇总一次导入的结果。
+type ImportResult struct {
+	DryRun   bool             `json:"dry_run"`
+	Strategy ConflictStrategy `json:"strategy"`
+	Items    []ImportItem     `json:"items"`
+	Counts   map[string]int   `json:"counts"`
+}
+
+func (r *ImportResult) add(item ImportItem) {
+	r.Items = append(r.Items, item)
+	r.Counts[item.Action]++
+}
@@ -0,0 +1,297 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package metadata
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/seatunnel/seatunnelX/internal/apps/auth"
+	"github.com/seatunnel/seatunnelX/internal/apps/cluster"
+	appconfig "github.com/seatunnel/seatunnelX/internal/apps/config"
+	"github.com/seatunnel/seatunnelX/internal/apps/host"
+	"github.com/seatunnel/seatunnelX/internal/apps/host/sshdeploy"
+	"github.com/seatunnel/seatunnelX/internal/apps/installer"
+	"github.com/seatunnel/seatunnelX/internal/apps/plugin"
+	"github.com/seatunnel/seatunnelX/internal/pkg/secrets"
+)
+
+// verifierPlaintext is sealed into BundleSecrets.Verifier to check the import passphrase.
+// verifierPlaintext 被加密写入 BundleSecrets.Verifier，用于校验导入口令。
+const verifierPlaintext = "seatunnelx-metadata-bundle"
+
+// CredentialStore reads and writes host SSH credentials. It is nil when SSH deployment is disabled,
+// in which case credentials are neither exported nor imported.
+// CredentialStore 读写主机 SSH 凭证。未启用 SSH 部署时为 nil，此时不导出也不导入凭证。
+type CredentialStore interface {
+	ExportCredential(ctx context.Context, hostID uint) (*sshdeploy.SaveCredentialRequest, error)
+	SaveCredential(ctx context.Context, hostID uint, req *sshdeploy.SaveCredentialRequest) (*sshdeploy.Credential, error)
+}
+
+// Service exports and imports Control Plane metadata.
+// Service 导出与导入控制面元数据。
+type Service struct {
+	db          *gorm.DB
+	credentials CredentialStore
+}
+
+// NewService creates a metadata service; credentials may be nil.
+// NewService 创建元数据服务；credentials 可为 nil。
+func NewService(db *gorm.DB, credentials CredentialStore) *Service {
+	return &Service{db: db, credentials: credentials}
+}
+
+// passphraseBox returns the Box sealing bundle secrets for passphrase.
+// passphraseBox 返回用于加密包内密钥的 Box。
+func passphraseBox(passphrase string) (*secrets.Box, error) {
+	if len(passphrase) < minPassphraseLength {
+		return nil, ErrPassphraseRequired
+	}
+	return secrets.NewBox(secrets.NewMasterKeyProvider(passphrase))
+}
+
+// Export builds a bundle. Soft-deleted records are not exported. Without IncludeSecrets every
+// credential is masked and SSH credentials are left out.
+// Export 构建元数据包，不导出回收站中的记录。未设置 IncludeSecrets 时所有凭证均被屏蔽且不包含 SSH 凭证。
+func (s *Service) Export(ctx context.Context, req *ExportRequest) (*Bundle, error) {
+	if req == nil {
+		req = &ExportRequest{}
+	}
+	seal := func(value string) (string, error) { return secrets.Redact(value), nil }
+	sealMap := func(m map[string]interface{}) (map[string]interface{}, error) { return secrets.RedactMap(m), nil }
+	bundle := &Bundle{SchemaVersion: SchemaVersion, ExportedAt: time.Now().UTC()}
+	if req.IncludeSecrets {
+		box, err := passphraseBox(req.Passphrase)
+		if err != nil {
+			return nil, err
+		}
+		if bundle.Secrets.Verifier, err = box.Seal(verifierPlaintext); err != nil {
+			return nil, err
+		}
+		bundle.Secrets.Included = true
+		seal, sealMap = box.Seal, box.SealMap
+	}
+
+	db := s.db.WithContext(ctx)
+	projectNames, err := s.projectNames(db)
+	if err != nil {
+		return nil, err
+	}
+
+	var clusters []*cluster.Cluster
+	query := db.Preload("Nodes").Order("id")
+	if len(req.ClusterIDs) > 0 {
+		query = query.Where("id IN ?", req.ClusterIDs)
+	}
+	if err := query.Find(&clusters).Error; err != nil {
+		return nil, err
+	}
+	if len(req.ClusterIDs) > 0 && len(clusters) != len(uniqueIDs(req.ClusterIDs)) {
+		return nil, cluster.ErrClusterNotFound
+	}
+
+	var hosts []*host.Host
+	hostQuery := db.Order("id")
+	if len(req.ClusterIDs) > 0 {
+		var hostIDs []uint
+		for _, c := range clusters {
+			for _, node := range c.Nodes {
+				hostIDs = append(hostIDs, node.HostID)
+			}
+		}
+		hostQuery = hostQuery.Where("id IN ?", uniqueIDs(hostIDs))
+	}
+	if err := hostQuery.Find(&hosts).Error; err != nil {
+		return nil, err
+	}
+	hostNames := make(map[uint]string, len(hosts))
+	for _, h := range hosts {
+		record, err := s.exportHost(ctx, h, projectNames, req.IncludeSecrets, seal)
+		if err != nil {
+			return nil, fmt.Errorf("export host %s: %w", h.Name, err)
+		}
+		bundle.Hosts = append(bundle.Hosts, *record)
+		hostNames[h.ID] = h.Name
+	}
+
+	for _, c := range clusters {
+		record, err := s.exportCluster(db, c, hostNames, projectNames, sealMap)
+		if err != nil {
+			return nil, fmt.Errorf("export cluster %s: %w", c.Name, err)
+		}
+		bundle.Clusters = append(bundle.Clusters, *record)
+	}
+
+	var templates []*installer.InstallationTemplate
+	if err := db.Order("id").Find(&templates).Error; err != nil {
+		return nil, err
+	}
+	for _, t := range templates {
+		spec, err := t.Spec.MapSecrets(seal)
+		if err != nil {
+			return nil, fmt.Errorf("export installation template %s: %w", t.Name, err)
+		}
+		bundle.InstallationTemplates = append(bundle.InstallationTemplates, InstallationTemplate{
+			Name:        t.Name,
+			Description: t.Description,
+			Spec:        spec,
+		})
+	}
+	return bundle, nil
+}
+
+func (s *Service) exportHost(ctx context.Context, h *host.Host, projectNames map[uint]string, includeSecrets bool, seal func(string) (string, error)) (*HostRecord, error) {
+	record := &HostRecord{
+		Name:             h.Name,
+		HostType:         h.HostType,
+		Description:      h.Description,
+		Labels:           h.Labels,
+		ProjectName:      projectNames[h.ProjectID],
+		IPAddress:        h.IPAddress,
+		SSHPort:          h.SSHPort,
+		SSHUser:          h.SSHUser,
+		OSType:           h.OSType,
+		Arch:             h.Arch,
+		CPUCores:         h.CPUCores,
+		TotalMemory:      h.TotalMemory,
+		TotalDisk:        h.TotalDisk,
+		DockerAPIURL:     h.DockerAPIURL,
+		DockerTLSEnabled: h.DockerTLSEnabled,
+		DockerCertPath:   h.DockerCertPath,
+		K8sAPIURL:        h.K8sAPIURL,
+		K8sNamespace:     h.K8sNamespace,
+	}
+	var err error
+	if record.K8sKubeconfig, err = seal(h.K8sKubeconfig); err != nil {
+		return nil, err
+	}
+	if record.K8sToken, err = seal(h.K8sToken); err != nil {
+		return nil, err
+	}
+	if !includeSecrets || s.credentials == nil {
+		return record, nil
+	}
+	cred, err := s.credentials.ExportCredential(ctx, h.ID)
+	if errors.Is(err, sshdeploy.ErrCredentialNotFound) {
+		return record, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	for _, field := range []*string{&cred.Password, &cred.PrivateKey, &cred.Passphrase} {
+		if *field, err = seal(*field); err != nil {
+			return nil, err
+		}
+	}
+	record.SSHCredential = cred
+	return record, nil
+}
+
+func (s *Service) exportCluster(db *gorm.DB, c *cluster.Cluster, hostNames, projectNames map[uint]string, sealMap func(map[string]interface{}) (map[string]interface{}, error)) (*ClusterRecord, error) {
+	config, err := sealMap(c.Config)
+	if err != nil {
+		return nil, err
+	}
+	record := &ClusterRecord{
+		Name:           c.Name,
+		Description:    c.Description,
+		DeploymentMode: c.DeploymentMode,
+		Version:        c.Version,
+		Source:         c.Source,
+		InstallDir:     c.InstallDir,
+		Config:         config,
+		NodeSelectors:  c.NodeSelectors,
+		ProjectName:    projectNames[c.ProjectID],
+		Nodes:          []NodeRecord{},
+	}
+	sort.Slice(c.Nodes, func(i, j int) bool { return c.Nodes[i].ID < c.Nodes[j].ID })
+	for _, node := range c.Nodes {
+		hostName, ok := hostNames[node.HostID]
+		if !ok {
+			return nil, fmt.Errorf("%w: node %d", host.ErrHostNotFound, node.ID)
+		}
+		record.Nodes = append(record.Nodes, NodeRecord{
+			HostName:      hostName,
+			Role:          node.Role,
+			InstallDir:    node.InstallDir,
+			HazelcastPort: node.HazelcastPort,
+			APIPort:       node.APIPort,
+			WorkerPort:    node.WorkerPort,
+			Overrides:     node.Overrides,
+		})
+	}
+
+	var templates []*appconfig.Config
+	if err := db.Where("cluster_id = ? AND host_id IS NULL", c.ID).Order("config_type").Find(&templates).Error; err != nil {
+		return nil, err
+	}
+	for _, t := range templates {
+		record.ConfigTemplates = append(record.ConfigTemplates, ConfigTemplateRecord{
+			ConfigType: t.ConfigType,
+			FilePath:   t.FilePath,
+			Content:    t.Content,
+		})
+	}
+
+	var plugins []*plugin.InstalledPlugin
+	if err := db.Where("cluster_id = ?", c.ID).Order("id").Find(&plugins).Error; err != nil {
+		return nil, err
+	}
+	for _, p := range plugins {
+		record.Plugins = append(record.Plugins, PluginRecord{
+			PluginName:   p.PluginName,
+			ArtifactID:   p.ArtifactID,
+			Category:     p.Category,
+			Version:      p.Version,
+			Status:       p.Status,
+			InstallPath:  p.InstallPath,
+			InstalledAt:  p.InstalledAt,
+			Dependencies: p.Dependencies,
+		})
+	}
+	return record, nil
+}
+
+func (s *Service) projectNames(db *gorm.DB) (map[uint]string, error) {
+	var projects []auth.Project
+	if err := db.Find(&projects).Error; err != nil {
+		return nil, err
+	}
+	names := make(map[uint]string, len(projects))
+	for _, p := range projects {
+		names[p.ID] = p.Name
+	}
+	return names, nil
+}
+
+func uniqueIDs(ids []uint) []uint {
+	seen := make(map[uint]bool, len(ids))
+	out := make([]uint, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			out = append(out, id)
+		}
+	}
+	return out
+}
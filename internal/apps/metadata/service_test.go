@@ -0,0 +1,276 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package metadata
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/seatunnel/seatunnelX/internal/apps/auth"
+	"github.com/seatunnel/seatunnelX/internal/apps/cluster"
+	appconfig "github.com/seatunnel/seatunnelX/internal/apps/config"
+	"github.com/seatunnel/seatunnelX/internal/apps/host"
+	"github.com/seatunnel/seatunnelX/internal/apps/host/sshdeploy"
+	"github.com/seatunnel/seatunnelX/internal/apps/installer"
+	"github.com/seatunnel/seatunnelX/internal/apps/plugin"
+	"github.com/seatunnel/seatunnelX/internal/pkg/secrets"
+)
+
+// fakeCredentials keeps plaintext SSH credentials keyed by host ID.
+// fakeCredentials 以主机 ID 为键保存明文 SSH 凭证。
+type fakeCredentials map[uint]*sshdeploy.SaveCredentialRequest
+
+func (f fakeCredentials) ExportCredential(_ context.Context, hostID uint) (*sshdeploy.SaveCredentialRequest, error) {
+	cred, ok := f[hostID]
+	if !ok {
+		return nil, sshdeploy.ErrCredentialNotFound
+	}
+	copied := *cred
+	return &copied, nil
+}
+
+func (f fakeCredentials) SaveCredential(_ context.Context, hostID uint, req *sshdeploy.SaveCredentialRequest) (*sshdeploy.Credential, error) {
+	f[hostID] = req
+	return &sshdeploy.Credential{HostID: hostID, Username: req.Username, AuthType: req.AuthType}, nil
+}
+
+func openTestDB(t *testing.T, name string) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), name)), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open sqlite db: %v", err)
+	}
+	if err := db.AutoMigrate(&auth.Project{}, &host.Host{}, &cluster.Cluster{}, &cluster.ClusterNode{},
+		&appconfig.Config{}, &appconfig.ConfigVersion{}, &plugin.InstalledPlugin{}, &installer.InstallationTemplate{}); err != nil {
+		t.Fatalf("failed to migrate models: %v", err)
+	}
+	return db
+}
+
+// seedSource creates a project, two hosts, a cluster with a config template and a plugin, and an
+// installation template, each holding a credential.
+// seedSource 创建一个项目、两台主机、一个带配置模板与插件的集群以及一个安装模板，每项都带有凭证。
+func seedSource(t *testing.T, db *gorm.DB) (*Service, fakeCredentials) {
+	t.Helper()
+	project := &auth.Project{Name: "team-a"}
+	worker := &host.Host{Name: "worker-1", HostType: host.HostTypeBareMetal, IPAddress: "10.0.0.1", SSHPort: 22, ProjectID: 0}
+	k8s := &host.Host{Name: "k8s-1", HostType: host.HostTypeKubernetes, K8sAPIURL: "https://k8s:6443", K8sToken: "k8s-token"}
+	for _, record := range []interface{}{project, worker, k8s} {
+		if err := db.Create(record).Error; err != nil {
+			t.Fatalf("seed: %v", err)
+		}
+	}
+	c := &cluster.Cluster{
+		Name:           "prod",
+		DeploymentMode: cluster.DeploymentModeHybrid,
+		Version:        "2.3.8",
+		ProjectID:      project.ID,
+		Config:         cluster.ClusterConfig{"checkpoint": map[string]interface{}{"storage_secret_key": "cluster-secret", "namespace": "/cp"}},
+		Nodes:          []cluster.ClusterNode{{HostID: worker.ID, Role: cluster.NodeRoleMasterWorker, InstallDir: "/opt/seatunnel", HazelcastPort: 5801}},
+	}
+	if err := db.Create(c).Error; err != nil {
+		t.Fatalf("seed cluster: %v", err)
+	}
+	seed := []interface{}{
+		&appconfig.Config{ClusterID: c.ID, ConfigType: appconfig.ConfigTypeHazelcast, Content: "hazelcast: {}\n", Version: 1},
+		&plugin.InstalledPlugin{ClusterID: c.ID, PluginName: "jdbc", Category: plugin.PluginCategoryConnector, Version: "2.3.8", InstalledAt: time.Now()},
+		&installer.InstallationTemplate{Name: "s3-checkpoint", Spec: installer.InstallationTemplateSpec{
+			Checkpoint: &installer.CheckpointConfig{Namespace: "/cp", StorageSecretKey: "template-secret"},
+		}},
+	}
+	for _, record := range seed {
+		if err := db.Create(record).Error; err != nil {
+			t.Fatalf("seed: %v", err)
+		}
+	}
+	creds := fakeCredentials{worker.ID: {Username: "root", AuthType: sshdeploy.AuthTypePassword, Password: "ssh-password"}}
+	return NewService(db, creds), creds
+}
+
+func TestExportImportRoundTripWithSecrets(t *testing.T) {
+	ctx := context.Background()
+	source, _ := seedSource(t, openTestDB(t, "source.db"))
+
+	if _, err := source.Export(ctx, &ExportRequest{IncludeSecrets: true, Passphrase: "short"}); !errors.Is(err, ErrPassphraseRequired) {
+		t.Fatalf("expected ErrPassphraseRequired, got %v", err)
+	}
+	bundle, err := source.Export(ctx, &ExportRequest{IncludeSecrets: true, Passphrase: "move-to-staging"})
+	if err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	raw, _ := json.Marshal(bundle)
+	for _, plaintext := range []string{"cluster-secret", "template-secret", "k8s-token", "ssh-password"} {
+		if strings.Contains(string(raw), plaintext) {
+			t.Fatalf("bundle leaks %q: %s", plaintext, raw)
+		}
+	}
+	if len(bundle.Hosts) != 2 || len(bundle.Clusters) != 1 || len(bundle.InstallationTemplates) != 1 {
+		t.Fatalf("unexpected bundle contents: %s", raw)
+	}
+	if bundle.Clusters[0].ProjectName != "team-a" || bundle.Clusters[0].Nodes[0].HostName != "worker-1" {
+		t.Fatalf("cluster references not resolved by name: %+v", bundle.Clusters[0])
+	}
+
+	targetDB := openTestDB(t, "target.db")
+	if err := targetDB.Create(&auth.Project{Name: "other"}).Error; err != nil {
+		t.Fatalf("seed project: %v", err)
+	}
+	if err := targetDB.Create(&auth.Project{Name: "team-a"}).Error; err != nil {
+		t.Fatalf("seed project: %v", err)
+	}
+	targetCreds := fakeCredentials{}
+	target := NewService(targetDB, targetCreds)
+	if _, err := target.Import(ctx, &ImportRequest{Bundle: bundle, Passphrase: "wrong-passphrase"}, 1); !errors.Is(err, ErrPassphraseMismatch) {
+		t.Fatalf("expected ErrPassphraseMismatch, got %v", err)
+	}
+	result, err := target.Import(ctx, &ImportRequest{Bundle: bundle, Passphrase: "move-to-staging"}, 1)
+	if err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+	if result.Counts[ActionCreated] != 5 || result.Counts[ActionFailed] != 0 {
+		t.Fatalf("unexpected import result: %+v", result)
+	}
+
+	var imported cluster.Cluster
+	if err := targetDB.Preload("Nodes").Where("name = ?", "prod").Take(&imported).Error; err != nil {
+		t.Fatalf("load imported cluster: %v", err)
+	}
+	var worker host.Host
+	if err := targetDB.Where("name = ?", "worker-1").Take(&worker).Error; err != nil {
+		t.Fatalf("load imported host: %v", err)
+	}
+	checkpoint := imported.Config["checkpoint"].(map[string]interface{})
+	if checkpoint["storage_secret_key"] != "cluster-secret" || imported.ProjectID != 2 {
+		t.Fatalf("cluster secrets or project not imported: %+v", imported)
+	}
+	if len(imported.Nodes) != 1 || imported.Nodes[0].HostID != worker.ID {
+		t.Fatalf("node not mapped to imported host: %+v", imported.Nodes)
+	}
+	var template installer.InstallationTemplate
+	if err := targetDB.Where("name = ?", "s3-checkpoint").Take(&template).Error; err != nil || template.Spec.Checkpoint.StorageSecretKey != "template-secret" {
+		t.Fatalf("installation template not imported with secret: %+v, %v", template, err)
+	}
+	var k8s host.Host
+	if err := targetDB.Where("name = ?", "k8s-1").Take(&k8s).Error; err != nil || k8s.K8sToken != "k8s-token" {
+		t.Fatalf("k8s token not imported: %+v, %v", k8s, err)
+	}
+	if cred := targetCreds[worker.ID]; cred == nil || cred.Password != "ssh-password" {
+		t.Fatalf("ssh credential not re-saved: %+v", targetCreds)
+	}
+	var versions int64
+	targetDB.Model(&appconfig.ConfigVersion{}).Count(&versions)
+	var plugins int64
+	targetDB.Model(&plugin.InstalledPlugin{}).Where("cluster_id = ?", imported.ID).Count(&plugins)
+	if versions != 1 || plugins != 1 {
+		t.Fatalf("config versions = %d, plugins = %d", versions, plugins)
+	}
+}
+
+func TestImportConflictStrategies(t *testing.T) {
+	ctx := context.Background()
+	source, _ := seedSource(t, openTestDB(t, "source.db"))
+	bundle, err := source.Export(ctx, &ExportRequest{})
+	if err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	checkpoint := bundle.Clusters[0].Config["checkpoint"].(map[string]interface{})
+	if checkpoint["storage_secret_key"] != secrets.Mask || bundle.Hosts[0].SSHCredential != nil {
+		t.Fatalf("secrets not masked without include_secrets: %+v", bundle.Clusters[0].Config)
+	}
+	bundle.Clusters[0].Description = "from staging"
+
+	targetDB := openTestDB(t, "target.db")
+	existingHost := &host.Host{Name: "worker-1", HostType: host.HostTypeBareMetal, IPAddress: "10.0.0.1"}
+	if err := targetDB.Create(existingHost).Error; err != nil {
+		t.Fatalf("seed host: %v", err)
+	}
+	existing := &cluster.Cluster{
+		Name:           "prod",
+		DeploymentMode: cluster.DeploymentModeHybrid,
+		Description:    "local",
+		Config:         cluster.ClusterConfig{"checkpoint": map[string]interface{}{"storage_secret_key": "local-secret"}},
+	}
+	if err := targetDB.Create(existing).Error; err != nil {
+		t.Fatalf("seed cluster: %v", err)
+	}
+	target := NewService(targetDB, nil)
+
+	result, err := target.Import(ctx, &ImportRequest{Bundle: bundle, Strategy: StrategyOverwrite, DryRun: true}, 1)
+	if err != nil || !result.DryRun || result.Counts[ActionOverwritten] != 2 {
+		t.Fatalf("dry run result = %+v, err = %v", result, err)
+	}
+	var clusters int64
+	targetDB.Model(&cluster.Cluster{}).Count(&clusters)
+	if clusters != 1 {
+		t.Fatalf("dry run wrote %d clusters", clusters)
+	}
+
+	result, err = target.Import(ctx, &ImportRequest{Bundle: bundle, Strategy: StrategySkip}, 1)
+	if err != nil || result.Counts[ActionSkipped] != 2 || result.Counts[ActionCreated] != 2 {
+		t.Fatalf("skip result = %+v, err = %v", result, err)
+	}
+
+	result, err = target.Import(ctx, &ImportRequest{Bundle: bundle, Strategy: StrategyRename}, 1)
+	if err != nil {
+		t.Fatalf("rename Import() error = %v", err)
+	}
+	var renamed cluster.Cluster
+	if err := targetDB.Preload("Nodes").Where("name = ?", "prod-imported").Take(&renamed).Error; err != nil {
+		t.Fatalf("renamed cluster missing: %v (%+v)", err, result)
+	}
+	if renamed.Config["checkpoint"].(map[string]interface{})["storage_secret_key"] != "" {
+		t.Fatalf("masked secret stored on a new cluster: %+v", renamed.Config)
+	}
+	// The renamed copy of worker-1 reuses its IP and is rejected, so the renamed cluster's node
+	// falls back to the existing host of that name.
+	// worker-1 的重命名副本与其 IP 重复而被拒绝，因此重命名集群的节点回退到同名的已有主机。
+	for _, item := range result.Items {
+		if item.Kind == KindHost && item.Name == "worker-1" && item.Action != ActionFailed {
+			t.Fatalf("duplicate IP host should fail: %+v", item)
+		}
+	}
+	if len(renamed.Nodes) != 1 || renamed.Nodes[0].HostID != existingHost.ID {
+		t.Fatalf("renamed cluster should fall back to the existing host: %+v", renamed.Nodes)
+	}
+
+	if _, err := target.Import(ctx, &ImportRequest{Bundle: bundle, Strategy: StrategyOverwrite}, 1); err != nil {
+		t.Fatalf("overwrite Import() error = %v", err)
+	}
+	var overwritten cluster.Cluster
+	if err := targetDB.Preload("Nodes").First(&overwritten, existing.ID).Error; err != nil {
+		t.Fatalf("load overwritten cluster: %v", err)
+	}
+	if overwritten.Description != "from staging" || len(overwritten.Nodes) != 1 {
+		t.Fatalf("cluster not overwritten: %+v", overwritten)
+	}
+	if overwritten.Config["checkpoint"].(map[string]interface{})["storage_secret_key"] != "local-secret" {
+		t.Fatalf("masked secret replaced the stored one: %+v", overwritten.Config)
+	}
+
+	if _, err := target.Import(ctx, &ImportRequest{Bundle: bundle, Strategy: "merge"}, 1); !errors.Is(err, ErrInvalidStrategy) {
+		t.Fatalf("expected ErrInvalidStrategy, got %v", err)
+	}
+}
@@ -706,6 +706,10 @@ func buildLocalAlertPolicyPayload(
 				"text": message,
 			},
 		}, nil
+	case NotificationChannelTypeSlack:
+		return map[string]interface{}{
+			"text": message,
+		}, nil
 	case NotificationChannelTypeEmail:
 		return &emailNotificationPayload{
 			Subject: title,
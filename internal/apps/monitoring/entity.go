@@ -142,6 +142,9 @@ const (
 	// NotificationChannelTypeFeishu indicates Feishu webhook channel.
 	// NotificationChannelTypeFeishu 表示飞书渠道。
 	NotificationChannelTypeFeishu NotificationChannelType = "feishu"
+	// NotificationChannelTypeSlack indicates Slack incoming webhook channel.
+	// NotificationChannelTypeSlack 表示 Slack 渠道。
+	NotificationChannelTypeSlack NotificationChannelType = "slack"
 )
 
 // NotificationChannel represents one alert notification channel.
@@ -220,6 +223,78 @@ func (NotificationDelivery) TableName() string {
 	return "monitoring_notification_deliveries"
 }
 
+// PlatformEventSubscription routes platform lifecycle events to one notification channel.
+// PlatformEventSubscription 将平台生命周期事件路由到一个通知渠道。
+type PlatformEventSubscription struct {
+	ID          uint      `json:"id" gorm:"primaryKey;autoIncrement"`
+	Name        string    `json:"name" gorm:"size:120;not null;index"`
+	Enabled     bool      `json:"enabled"`
+	ChannelID   uint      `json:"channel_id" gorm:"not null;index"`
+	EventTypes  string    `json:"event_types" gorm:"size:500"` // comma separated, empty means all / 逗号分隔，为空表示全部
+	ClusterID   string    `json:"cluster_id" gorm:"size:64;index"`
+	Description string    `json:"description" gorm:"type:text"`
+	CreatedAt   time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt   time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// TableName specifies the table name for PlatformEventSubscription.
+// TableName 指定 PlatformEventSubscription 表名。
+func (PlatformEventSubscription) TableName() string {
+	return "monitoring_platform_event_subscriptions"
+}
+
+// HeartbeatAlertRule defines one threshold rule evaluated against Agent heartbeat metrics.
+// HeartbeatAlertRule 定义一条基于 Agent 心跳指标的阈值规则。
+type HeartbeatAlertRule struct {
+	ID              uint            `json:"id" gorm:"primaryKey;autoIncrement"`
+	Name            string          `json:"name" gorm:"size:160;not null;index"`
+	Enabled         bool            `json:"enabled"`
+	Metric          HeartbeatMetric `json:"metric" gorm:"size:30;not null;index"`
+	Operator        string          `json:"operator" gorm:"size:4;not null"`
+	Threshold       float64         `json:"threshold"`
+	DurationSeconds int             `json:"duration_seconds" gorm:"default:0"`
+	Severity        AlertSeverity   `json:"severity" gorm:"size:20;default:warning"`
+	HostID          uint            `json:"host_id" gorm:"index"` // 0 means all hosts / 0 表示全部主机
+	SendRecovery    bool            `json:"send_recovery" gorm:"default:true"`
+	Description     string          `json:"description" gorm:"type:text"`
+	CreatedAt       time.Time       `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt       time.Time       `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// TableName specifies the table name for HeartbeatAlertRule.
+// TableName 指定 HeartbeatAlertRule 表名。
+func (HeartbeatAlertRule) TableName() string {
+	return "monitoring_heartbeat_alert_rules"
+}
+
+// HeartbeatAlert records one pending, firing or resolved heartbeat metric alert.
+// HeartbeatAlert 记录一条待触发、触发中或已恢复的心跳指标告警。
+type HeartbeatAlert struct {
+	ID              uint                 `json:"id" gorm:"primaryKey;autoIncrement"`
+	RuleID          uint                 `json:"rule_id" gorm:"not null;index"`
+	RuleName        string               `json:"rule_name" gorm:"size:160"`
+	HostID          uint                 `json:"host_id" gorm:"not null;index"`
+	HostName        string               `json:"host_name" gorm:"size:100"`
+	Metric          HeartbeatMetric      `json:"metric" gorm:"size:30;not null"`
+	Operator        string               `json:"operator" gorm:"size:4"`
+	Threshold       float64              `json:"threshold"`
+	Severity        AlertSeverity        `json:"severity" gorm:"size:20"`
+	Status          HeartbeatAlertStatus `json:"status" gorm:"size:20;not null;index"`
+	LastValue       float64              `json:"last_value"`
+	StartedAt       time.Time            `json:"started_at"`
+	FiredAt         *time.Time           `json:"fired_at"`
+	ResolvedAt      *time.Time           `json:"resolved_at"`
+	LastEvaluatedAt time.Time            `json:"last_evaluated_at"`
+	CreatedAt       time.Time            `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt       time.Time            `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// TableName specifies the table name for HeartbeatAlert.
+// TableName 指定 HeartbeatAlert 表名。
+func (HeartbeatAlert) TableName() string {
+	return "monitoring_heartbeat_alerts"
+}
+
 // RemoteAlertRecord stores one Alertmanager webhook alert after normalization.
 // RemoteAlertRecord 保存标准化后的 Alertmanager webhook 告警记录。
 type RemoteAlertRecord struct {
@@ -0,0 +1,155 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package monitoring
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ListHeartbeatAlertRules handles GET /api/v1/monitoring/heartbeat-rules
+// ListHeartbeatAlertRules 处理心跳告警规则列表接口。
+func (h *Handler) ListHeartbeatAlertRules(c *gin.Context) {
+	data, err := h.service.ListHeartbeatAlertRules(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, Response{ErrorMsg: "Failed to list heartbeat alert rules: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, Response{Data: data})
+}
+
+// CreateHeartbeatAlertRule handles POST /api/v1/monitoring/heartbeat-rules
+// CreateHeartbeatAlertRule 处理新增心跳告警规则接口。
+func (h *Handler) CreateHeartbeatAlertRule(c *gin.Context) {
+	var req UpsertHeartbeatAlertRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, Response{ErrorMsg: "invalid request body: " + err.Error()})
+		return
+	}
+	if err := req.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, Response{ErrorMsg: err.Error()})
+		return
+	}
+
+	data, err := h.service.CreateHeartbeatAlertRule(c.Request.Context(), &req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, Response{ErrorMsg: "Failed to create heartbeat alert rule: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, Response{Data: data})
+}
+
+// UpdateHeartbeatAlertRule handles PUT /api/v1/monitoring/heartbeat-rules/:id
+// UpdateHeartbeatAlertRule 处理更新心跳告警规则接口。
+func (h *Handler) UpdateHeartbeatAlertRule(c *gin.Context) {
+	ruleID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, Response{ErrorMsg: "invalid rule id"})
+		return
+	}
+
+	var req UpsertHeartbeatAlertRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, Response{ErrorMsg: "invalid request body: " + err.Error()})
+		return
+	}
+	if err := req.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, Response{ErrorMsg: err.Error()})
+		return
+	}
+
+	data, err := h.service.UpdateHeartbeatAlertRule(c.Request.Context(), uint(ruleID), &req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, Response{ErrorMsg: "Failed to update heartbeat alert rule: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, Response{Data: data})
+}
+
+// DeleteHeartbeatAlertRule handles DELETE /api/v1/monitoring/heartbeat-rules/:id
+// DeleteHeartbeatAlertRule 处理删除心跳告警规则接口。
+func (h *Handler) DeleteHeartbeatAlertRule(c *gin.Context) {
+	ruleID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, Response{ErrorMsg: "invalid rule id"})
+		return
+	}
+
+	if err := h.service.DeleteHeartbeatAlertRule(c.Request.Context(), uint(ruleID)); err != nil {
+		c.JSON(http.StatusInternalServerError, Response{ErrorMsg: "Failed to delete heartbeat alert rule: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, Response{Data: gin.H{"id": ruleID}})
+}
+
+// ListHeartbeatAlerts handles GET /api/v1/monitoring/heartbeat-alerts
+// ListHeartbeatAlerts 处理心跳告警列表接口。
+func (h *Handler) ListHeartbeatAlerts(c *gin.Context) {
+	filter := &HeartbeatAlertFilter{}
+	if status := strings.TrimSpace(c.Query("status")); status != "" {
+		filter.Status = HeartbeatAlertStatus(strings.ToLower(status))
+		switch filter.Status {
+		case HeartbeatAlertStatusPending, HeartbeatAlertStatusFiring, HeartbeatAlertStatusResolved:
+		default:
+			c.JSON(http.StatusBadRequest, Response{ErrorMsg: "invalid status"})
+			return
+		}
+	}
+	if hostIDStr := strings.TrimSpace(c.Query("host_id")); hostIDStr != "" {
+		hostID, err := strconv.ParseUint(hostIDStr, 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, Response{ErrorMsg: "invalid host_id"})
+			return
+		}
+		filter.HostID = uint(hostID)
+	}
+	if ruleIDStr := strings.TrimSpace(c.Query("rule_id")); ruleIDStr != "" {
+		ruleID, err := strconv.ParseUint(ruleIDStr, 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, Response{ErrorMsg: "invalid rule_id"})
+			return
+		}
+		filter.RuleID = uint(ruleID)
+	}
+	if pageStr := strings.TrimSpace(c.Query("page")); pageStr != "" {
+		page, err := strconv.Atoi(pageStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, Response{ErrorMsg: "invalid page"})
+			return
+		}
+		filter.Page = page
+	}
+	if pageSizeStr := strings.TrimSpace(c.Query("page_size")); pageSizeStr != "" {
+		pageSize, err := strconv.Atoi(pageSizeStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, Response{ErrorMsg: "invalid page_size"})
+			return
+		}
+		filter.PageSize = pageSize
+	}
+
+	data, err := h.service.ListHeartbeatAlerts(c.Request.Context(), filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, Response{ErrorMsg: "Failed to list heartbeat alerts: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, Response{Data: data})
+}
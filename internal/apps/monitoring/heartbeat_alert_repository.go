@@ -0,0 +1,142 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package monitoring
+
+import "context"
+
+// ListHeartbeatAlertRules returns all heartbeat alert rules.
+// ListHeartbeatAlertRules 返回全部心跳告警规则。
+func (r *Repository) ListHeartbeatAlertRules(ctx context.Context) ([]*HeartbeatAlertRule, error) {
+	var rules []*HeartbeatAlertRule
+	if err := r.db.WithContext(ctx).Order("id ASC").Find(&rules).Error; err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+// ListEnabledHeartbeatAlertRules returns enabled heartbeat alert rules.
+// ListEnabledHeartbeatAlertRules 返回已启用的心跳告警规则。
+func (r *Repository) ListEnabledHeartbeatAlertRules(ctx context.Context) ([]*HeartbeatAlertRule, error) {
+	var rules []*HeartbeatAlertRule
+	if err := r.db.WithContext(ctx).
+		Where("enabled = ?", true).
+		Order("id ASC").
+		Find(&rules).Error; err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+// GetHeartbeatAlertRuleByID returns one heartbeat alert rule by ID.
+// GetHeartbeatAlertRuleByID 根据 ID 返回单条心跳告警规则。
+func (r *Repository) GetHeartbeatAlertRuleByID(ctx context.Context, id uint) (*HeartbeatAlertRule, error) {
+	var rule HeartbeatAlertRule
+	if err := r.db.WithContext(ctx).First(&rule, id).Error; err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+// CreateHeartbeatAlertRule creates one heartbeat alert rule.
+// CreateHeartbeatAlertRule 创建一条心跳告警规则。
+func (r *Repository) CreateHeartbeatAlertRule(ctx context.Context, rule *HeartbeatAlertRule) error {
+	return r.db.WithContext(ctx).Create(rule).Error
+}
+
+// SaveHeartbeatAlertRule saves one heartbeat alert rule.
+// SaveHeartbeatAlertRule 保存一条心跳告警规则。
+func (r *Repository) SaveHeartbeatAlertRule(ctx context.Context, rule *HeartbeatAlertRule) error {
+	return r.db.WithContext(ctx).Save(rule).Error
+}
+
+// DeleteHeartbeatAlertRule deletes one rule together with its unresolved alerts.
+// DeleteHeartbeatAlertRule 删除规则及其未恢复的告警。
+func (r *Repository) DeleteHeartbeatAlertRule(ctx context.Context, id uint) error {
+	db := r.db.WithContext(ctx)
+	if err := db.Where("rule_id = ? AND status <> ?", id, HeartbeatAlertStatusResolved).
+		Delete(&HeartbeatAlert{}).Error; err != nil {
+		return err
+	}
+	return db.Delete(&HeartbeatAlertRule{}, id).Error
+}
+
+// ListActiveHeartbeatAlertsByHost returns pending/firing alerts of one host.
+// ListActiveHeartbeatAlertsByHost 返回指定主机处于待触发/触发中的告警。
+func (r *Repository) ListActiveHeartbeatAlertsByHost(ctx context.Context, hostID uint) ([]*HeartbeatAlert, error) {
+	var alerts []*HeartbeatAlert
+	if err := r.db.WithContext(ctx).
+		Where("host_id = ? AND status IN ?", hostID, []HeartbeatAlertStatus{HeartbeatAlertStatusPending, HeartbeatAlertStatusFiring}).
+		Order("id ASC").
+		Find(&alerts).Error; err != nil {
+		return nil, err
+	}
+	return alerts, nil
+}
+
+// ListHeartbeatAlerts returns heartbeat alerts with filters and pagination.
+// ListHeartbeatAlerts 按过滤条件分页返回心跳告警。
+func (r *Repository) ListHeartbeatAlerts(ctx context.Context, filter *HeartbeatAlertFilter) ([]*HeartbeatAlert, int64, error) {
+	if filter == nil {
+		filter = &HeartbeatAlertFilter{}
+	}
+
+	query := r.db.WithContext(ctx).Model(&HeartbeatAlert{})
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.HostID > 0 {
+		query = query.Where("host_id = ?", filter.HostID)
+	}
+	if filter.RuleID > 0 {
+		query = query.Where("rule_id = ?", filter.RuleID)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if filter.Page > 0 && filter.PageSize > 0 {
+		offset := (filter.Page - 1) * filter.PageSize
+		query = query.Offset(offset).Limit(filter.PageSize)
+	}
+
+	var alerts []*HeartbeatAlert
+	if err := query.Order("updated_at DESC").Order("id DESC").Find(&alerts).Error; err != nil {
+		return nil, 0, err
+	}
+	return alerts, total, nil
+}
+
+// CreateHeartbeatAlert creates one heartbeat alert.
+// CreateHeartbeatAlert 创建一条心跳告警。
+func (r *Repository) CreateHeartbeatAlert(ctx context.Context, alert *HeartbeatAlert) error {
+	return r.db.WithContext(ctx).Create(alert).Error
+}
+
+// SaveHeartbeatAlert saves one heartbeat alert.
+// SaveHeartbeatAlert 保存一条心跳告警。
+func (r *Repository) SaveHeartbeatAlert(ctx context.Context, alert *HeartbeatAlert) error {
+	return r.db.WithContext(ctx).Save(alert).Error
+}
+
+// DeleteHeartbeatAlert deletes one heartbeat alert.
+// DeleteHeartbeatAlert 删除一条心跳告警。
+func (r *Repository) DeleteHeartbeatAlert(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Delete(&HeartbeatAlert{}, id).Error
+}
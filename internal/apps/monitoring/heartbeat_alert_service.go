@@ -0,0 +1,335 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package monitoring
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// EvaluateHeartbeatMetrics evaluates one heartbeat sample against enabled rules and raises/clears alerts.
+// EvaluateHeartbeatMetrics 使用已启用规则评估一次心跳采样，并触发/恢复告警。
+func (s *Service) EvaluateHeartbeatMetrics(ctx context.Context, sample *HeartbeatSample) error {
+	if s == nil || s.repo == nil || sample == nil || sample.HostID == 0 {
+		return nil
+	}
+	if sample.ObservedAt.IsZero() {
+		sample.ObservedAt = timeNowUTC()
+	}
+
+	s.heartbeatAlertMu.Lock()
+	defer s.heartbeatAlertMu.Unlock()
+
+	rules, err := s.repo.ListEnabledHeartbeatAlertRules(ctx)
+	if err != nil {
+		return err
+	}
+	activeAlerts, err := s.repo.ListActiveHeartbeatAlertsByHost(ctx, sample.HostID)
+	if err != nil {
+		return err
+	}
+	activeByRule := make(map[uint]*HeartbeatAlert, len(activeAlerts))
+	for _, alert := range activeAlerts {
+		activeByRule[alert.RuleID] = alert
+	}
+
+	var errs []error
+	for _, rule := range rules {
+		if rule.HostID != 0 && rule.HostID != sample.HostID {
+			continue
+		}
+		alert := activeByRule[rule.ID]
+		delete(activeByRule, rule.ID)
+		if err := s.evaluateHeartbeatAlertRule(ctx, rule, alert, sample); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	// Alerts whose rule was disabled or no longer applies are cleared without notification.
+	// 规则已禁用或不再适用的告警直接清理，不发送通知。
+	for _, alert := range activeByRule {
+		if err := s.clearHeartbeatAlert(ctx, alert, sample.ObservedAt); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (s *Service) evaluateHeartbeatAlertRule(ctx context.Context, rule *HeartbeatAlertRule, alert *HeartbeatAlert, sample *HeartbeatSample) error {
+	value, ok := heartbeatMetricValue(sample, rule.Metric)
+	if !ok {
+		return nil
+	}
+	now := sample.ObservedAt
+	breached := compareHeartbeatMetric(value, rule.Operator, rule.Threshold)
+
+	if !breached {
+		if alert == nil {
+			return nil
+		}
+		if alert.Status == HeartbeatAlertStatusPending {
+			return s.repo.DeleteHeartbeatAlert(ctx, alert.ID)
+		}
+		resolvedAt := now
+		alert.Status = HeartbeatAlertStatusResolved
+		alert.ResolvedAt = &resolvedAt
+		alert.LastValue = value
+		alert.LastEvaluatedAt = now
+		if err := s.repo.SaveHeartbeatAlert(ctx, alert); err != nil {
+			return err
+		}
+		if rule.SendRecovery {
+			s.PublishPlatformEvent(buildHeartbeatAlertPlatformEvent(PlatformEventMetricRecovered, alert, now))
+		}
+		return nil
+	}
+
+	if alert == nil {
+		alert = &HeartbeatAlert{
+			RuleID:    rule.ID,
+			RuleName:  rule.Name,
+			HostID:    sample.HostID,
+			HostName:  sample.HostName,
+			Metric:    rule.Metric,
+			Operator:  rule.Operator,
+			Threshold: rule.Threshold,
+			Severity:  rule.Severity,
+			Status:    HeartbeatAlertStatusPending,
+			StartedAt: now,
+		}
+	}
+	alert.LastValue = value
+	alert.LastEvaluatedAt = now
+
+	fire := alert.Status == HeartbeatAlertStatusPending &&
+		now.Sub(alert.StartedAt) >= time.Duration(rule.DurationSeconds)*time.Second
+	if fire {
+		firedAt := now
+		alert.Status = HeartbeatAlertStatusFiring
+		alert.FiredAt = &firedAt
+	}
+
+	if alert.ID == 0 {
+		if err := s.repo.CreateHeartbeatAlert(ctx, alert); err != nil {
+			return err
+		}
+	} else if err := s.repo.SaveHeartbeatAlert(ctx, alert); err != nil {
+		return err
+	}
+	if fire {
+		s.PublishPlatformEvent(buildHeartbeatAlertPlatformEvent(PlatformEventMetricAlert, alert, now))
+	}
+	return nil
+}
+
+func (s *Service) clearHeartbeatAlert(ctx context.Context, alert *HeartbeatAlert, now time.Time) error {
+	if alert.Status == HeartbeatAlertStatusPending {
+		return s.repo.DeleteHeartbeatAlert(ctx, alert.ID)
+	}
+	resolvedAt := now
+	alert.Status = HeartbeatAlertStatusResolved
+	alert.ResolvedAt = &resolvedAt
+	alert.LastEvaluatedAt = now
+	return s.repo.SaveHeartbeatAlert(ctx, alert)
+}
+
+func heartbeatMetricValue(sample *HeartbeatSample, metric HeartbeatMetric) (float64, bool) {
+	switch metric {
+	case HeartbeatMetricCPU:
+		return sample.CPUUsage, true
+	case HeartbeatMetricMemory:
+		return sample.MemoryUsage, true
+	case HeartbeatMetricDisk:
+		return sample.DiskUsage, true
+	default:
+		return 0, false
+	}
+}
+
+func compareHeartbeatMetric(value float64, operator string, threshold float64) bool {
+	switch operator {
+	case ">":
+		return value > threshold
+	case ">=":
+		return value >= threshold
+	case "<":
+		return value < threshold
+	case "<=":
+		return value <= threshold
+	default:
+		return false
+	}
+}
+
+func buildHeartbeatAlertPlatformEvent(eventType PlatformEventType, alert *HeartbeatAlert, at time.Time) *PlatformEvent {
+	title := fmt.Sprintf("Heartbeat alert firing: %s / 心跳指标告警触发：%s", alert.RuleName, alert.RuleName)
+	if eventType == PlatformEventMetricRecovered {
+		title = fmt.Sprintf("Heartbeat alert resolved: %s / 心跳指标告警恢复：%s", alert.RuleName, alert.RuleName)
+	}
+	return &PlatformEvent{
+		Type:     eventType,
+		HostID:   alert.HostID,
+		HostName: alert.HostName,
+		Title:    title,
+		Message: fmt.Sprintf("%s on host %s is %.2f%% (rule: %s %.2f%%) / 主机 %s 的 %s 为 %.2f%%（规则：%s %.2f%%）",
+			alert.Metric, alert.HostName, alert.LastValue, alert.Operator, alert.Threshold,
+			alert.HostName, alert.Metric, alert.LastValue, alert.Operator, alert.Threshold),
+		Details: map[string]string{
+			"rule_id":  strconv.FormatUint(uint64(alert.RuleID), 10),
+			"alert_id": strconv.FormatUint(uint64(alert.ID), 10),
+			"metric":   string(alert.Metric),
+			"severity": string(alert.Severity),
+			"value":    strconv.FormatFloat(alert.LastValue, 'f', 2, 64),
+		},
+		OccurredAt: at,
+	}
+}
+
+// ==================== Rule CRUD 规则管理 ====================
+
+// ListHeartbeatAlertRules returns all heartbeat alert rules.
+// ListHeartbeatAlertRules 返回全部心跳告警规则。
+func (s *Service) ListHeartbeatAlertRules(ctx context.Context) (*HeartbeatAlertRuleListData, error) {
+	if s.repo == nil {
+		return nil, fmt.Errorf("monitoring repository is not configured")
+	}
+	rules, err := s.repo.ListHeartbeatAlertRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &HeartbeatAlertRuleListData{
+		GeneratedAt: timeNowUTC(),
+		Total:       len(rules),
+		Rules:       rules,
+	}, nil
+}
+
+// CreateHeartbeatAlertRule creates one heartbeat alert rule.
+// CreateHeartbeatAlertRule 创建一条心跳告警规则。
+func (s *Service) CreateHeartbeatAlertRule(ctx context.Context, req *UpsertHeartbeatAlertRuleRequest) (*HeartbeatAlertRule, error) {
+	if s.repo == nil {
+		return nil, fmt.Errorf("monitoring repository is not configured")
+	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	rule := &HeartbeatAlertRule{Enabled: true, SendRecovery: true}
+	applyHeartbeatAlertRuleRequest(rule, req)
+	if err := s.repo.CreateHeartbeatAlertRule(ctx, rule); err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+// UpdateHeartbeatAlertRule updates one heartbeat alert rule.
+// UpdateHeartbeatAlertRule 更新一条心跳告警规则。
+func (s *Service) UpdateHeartbeatAlertRule(ctx context.Context, id uint, req *UpsertHeartbeatAlertRuleRequest) (*HeartbeatAlertRule, error) {
+	if s.repo == nil {
+		return nil, fmt.Errorf("monitoring repository is not configured")
+	}
+	if id == 0 {
+		return nil, fmt.Errorf("invalid rule id")
+	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	rule, err := s.repo.GetHeartbeatAlertRuleByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("heartbeat alert rule not found")
+		}
+		return nil, err
+	}
+	applyHeartbeatAlertRuleRequest(rule, req)
+	if err := s.repo.SaveHeartbeatAlertRule(ctx, rule); err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+// DeleteHeartbeatAlertRule deletes one heartbeat alert rule.
+// DeleteHeartbeatAlertRule 删除一条心跳告警规则。
+func (s *Service) DeleteHeartbeatAlertRule(ctx context.Context, id uint) error {
+	if s.repo == nil {
+		return fmt.Errorf("monitoring repository is not configured")
+	}
+	if id == 0 {
+		return fmt.Errorf("invalid rule id")
+	}
+	if _, err := s.repo.GetHeartbeatAlertRuleByID(ctx, id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("heartbeat alert rule not found")
+		}
+		return err
+	}
+	return s.repo.DeleteHeartbeatAlertRule(ctx, id)
+}
+
+// ListHeartbeatAlerts returns heartbeat alerts with pagination.
+// ListHeartbeatAlerts 分页返回心跳告警。
+func (s *Service) ListHeartbeatAlerts(ctx context.Context, filter *HeartbeatAlertFilter) (*HeartbeatAlertListData, error) {
+	if s.repo == nil {
+		return nil, fmt.Errorf("monitoring repository is not configured")
+	}
+	if filter == nil {
+		filter = &HeartbeatAlertFilter{}
+	}
+	if filter.Page <= 0 {
+		filter.Page = 1
+	}
+	if filter.PageSize <= 0 || filter.PageSize > 200 {
+		filter.PageSize = 20
+	}
+	alerts, total, err := s.repo.ListHeartbeatAlerts(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	return &HeartbeatAlertListData{
+		GeneratedAt: timeNowUTC(),
+		Total:       total,
+		Page:        filter.Page,
+		PageSize:    filter.PageSize,
+		Alerts:      alerts,
+	}, nil
+}
+
+func applyHeartbeatAlertRuleRequest(rule *HeartbeatAlertRule, req *UpsertHeartbeatAlertRuleRequest) {
+	rule.Name = strings.TrimSpace(req.Name)
+	if req.Enabled != nil {
+		rule.Enabled = *req.Enabled
+	}
+	rule.Metric = HeartbeatMetric(strings.TrimSpace(req.Metric))
+	rule.Operator = strings.TrimSpace(req.Operator)
+	rule.Threshold = req.Threshold
+	rule.DurationSeconds = req.DurationSeconds
+	rule.Severity = AlertSeverity(strings.TrimSpace(req.Severity))
+	if rule.Severity == "" {
+		rule.Severity = AlertSeverityWarning
+	}
+	rule.HostID = req.HostID
+	if req.SendRecovery != nil {
+		rule.SendRecovery = *req.SendRecovery
+	}
+	rule.Description = strings.TrimSpace(req.Description)
+}
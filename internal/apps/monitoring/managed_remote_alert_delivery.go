@@ -359,6 +359,10 @@ func buildManagedRemoteAlertPolicyPayload(
 				"text": message,
 			},
 		}, nil
+	case NotificationChannelTypeSlack:
+		return map[string]interface{}{
+			"text": message,
+		}, nil
 	case NotificationChannelTypeEmail:
 		return &emailNotificationPayload{
 			Subject: title,
@@ -1036,6 +1036,150 @@ type NotificationRouteListData struct {
 	Routes      []*NotificationRouteDTO `json:"routes"`
 }
 
+// PlatformEventSubscriptionDTO is frontend DTO for platform event subscriptions.
+// PlatformEventSubscriptionDTO 是前端使用的平台事件订阅 DTO。
+type PlatformEventSubscriptionDTO struct {
+	ID          uint                `json:"id"`
+	Name        string              `json:"name"`
+	Enabled     bool                `json:"enabled"`
+	ChannelID   uint                `json:"channel_id"`
+	EventTypes  []PlatformEventType `json:"event_types"`
+	ClusterID   string              `json:"cluster_id,omitempty"`
+	Description string              `json:"description,omitempty"`
+	CreatedAt   time.Time           `json:"created_at"`
+	UpdatedAt   time.Time           `json:"updated_at"`
+}
+
+// PlatformEventSubscriptionListData represents platform event subscription list payload.
+// PlatformEventSubscriptionListData 表示平台事件订阅列表响应。
+type PlatformEventSubscriptionListData struct {
+	GeneratedAt   time.Time                       `json:"generated_at"`
+	Total         int                             `json:"total"`
+	EventTypes    []PlatformEventType             `json:"event_types"`
+	Subscriptions []*PlatformEventSubscriptionDTO `json:"subscriptions"`
+}
+
+// HeartbeatMetric represents one usage metric carried by Agent heartbeats.
+// HeartbeatMetric 表示 Agent 心跳携带的资源使用率指标。
+type HeartbeatMetric string
+
+const (
+	// HeartbeatMetricCPU is CPU usage percent.
+	// HeartbeatMetricCPU 表示 CPU 使用率百分比。
+	HeartbeatMetricCPU HeartbeatMetric = "cpu_usage"
+	// HeartbeatMetricMemory is memory usage percent.
+	// HeartbeatMetricMemory 表示内存使用率百分比。
+	HeartbeatMetricMemory HeartbeatMetric = "memory_usage"
+	// HeartbeatMetricDisk is disk usage percent.
+	// HeartbeatMetricDisk 表示磁盘使用率百分比。
+	HeartbeatMetricDisk HeartbeatMetric = "disk_usage"
+)
+
+// HeartbeatAlertStatus represents lifecycle state of one heartbeat metric alert.
+// HeartbeatAlertStatus 表示心跳指标告警的生命周期状态。
+type HeartbeatAlertStatus string
+
+const (
+	// HeartbeatAlertStatusPending indicates the threshold is breached but the duration is not reached yet.
+	// HeartbeatAlertStatusPending 表示已超过阈值但尚未达到持续时间。
+	HeartbeatAlertStatusPending HeartbeatAlertStatus = "pending"
+	// HeartbeatAlertStatusFiring indicates the alert is firing.
+	// HeartbeatAlertStatusFiring 表示告警触发中。
+	HeartbeatAlertStatusFiring HeartbeatAlertStatus = "firing"
+	// HeartbeatAlertStatusResolved indicates the alert has recovered.
+	// HeartbeatAlertStatusResolved 表示告警已恢复。
+	HeartbeatAlertStatusResolved HeartbeatAlertStatus = "resolved"
+)
+
+// HeartbeatSample is one heartbeat metrics sample of a host.
+// HeartbeatSample 表示主机的一次心跳指标采样。
+type HeartbeatSample struct {
+	HostID      uint
+	HostName    string
+	CPUUsage    float64
+	MemoryUsage float64
+	DiskUsage   float64
+	ObservedAt  time.Time
+}
+
+// HeartbeatAlertRuleListData represents heartbeat alert rule list payload.
+// HeartbeatAlertRuleListData 表示心跳告警规则列表响应。
+type HeartbeatAlertRuleListData struct {
+	GeneratedAt time.Time             `json:"generated_at"`
+	Total       int                   `json:"total"`
+	Rules       []*HeartbeatAlertRule `json:"rules"`
+}
+
+// HeartbeatAlertFilter represents heartbeat alert query filters.
+// HeartbeatAlertFilter 表示心跳告警查询过滤条件。
+type HeartbeatAlertFilter struct {
+	Status   HeartbeatAlertStatus `json:"status"`
+	HostID   uint                 `json:"host_id"`
+	RuleID   uint                 `json:"rule_id"`
+	Page     int                  `json:"page"`
+	PageSize int                  `json:"page_size"`
+}
+
+// HeartbeatAlertListData represents heartbeat alert list payload.
+// HeartbeatAlertListData 表示心跳告警列表响应。
+type HeartbeatAlertListData struct {
+	GeneratedAt time.Time         `json:"generated_at"`
+	Total       int64             `json:"total"`
+	Page        int               `json:"page"`
+	PageSize    int               `json:"page_size"`
+	Alerts      []*HeartbeatAlert `json:"alerts"`
+}
+
+// UpsertHeartbeatAlertRuleRequest represents create/update payload for heartbeat alert rule.
+// UpsertHeartbeatAlertRuleRequest 表示心跳告警规则的新增/更新请求。
+type UpsertHeartbeatAlertRuleRequest struct {
+	Name            string  `json:"name"`
+	Enabled         *bool   `json:"enabled"`
+	Metric          string  `json:"metric"`
+	Operator        string  `json:"operator"`
+	Threshold       float64 `json:"threshold"`
+	DurationSeconds int     `json:"duration_seconds"`
+	Severity        string  `json:"severity"`
+	HostID          uint    `json:"host_id"`
+	SendRecovery    *bool   `json:"send_recovery"`
+	Description     string  `json:"description"`
+}
+
+// Validate validates heartbeat alert rule request.
+// Validate 验证心跳告警规则请求参数。
+func (r *UpsertHeartbeatAlertRuleRequest) Validate() error {
+	if r == nil {
+		return fmt.Errorf("empty request")
+	}
+	if strings.TrimSpace(r.Name) == "" {
+		return fmt.Errorf("name is required")
+	}
+	switch HeartbeatMetric(strings.TrimSpace(r.Metric)) {
+	case HeartbeatMetricCPU, HeartbeatMetricMemory, HeartbeatMetricDisk:
+	default:
+		return fmt.Errorf("invalid metric")
+	}
+	switch strings.TrimSpace(r.Operator) {
+	case ">", ">=", "<", "<=":
+	default:
+		return fmt.Errorf("invalid operator")
+	}
+	if r.Threshold < 0 || r.Threshold > 100 {
+		return fmt.Errorf("threshold must be between 0 and 100")
+	}
+	if r.DurationSeconds < 0 {
+		return fmt.Errorf("duration_seconds must not be negative")
+	}
+	if r.Severity != "" {
+		switch AlertSeverity(strings.TrimSpace(r.Severity)) {
+		case AlertSeverityWarning, AlertSeverityCritical:
+		default:
+			return fmt.Errorf("invalid severity")
+		}
+	}
+	return nil
+}
+
 // NotificationDeliveryFilter represents notification history query filters.
 // NotificationDeliveryFilter 表示通知历史查询过滤条件。
 type NotificationDeliveryFilter struct {
@@ -1098,6 +1242,37 @@ type UpsertNotificationRouteRequest struct {
 	MuteIfSilenced     *bool  `json:"mute_if_silenced"`
 }
 
+// UpsertPlatformEventSubscriptionRequest represents create/update payload for platform event subscription.
+// UpsertPlatformEventSubscriptionRequest 表示平台事件订阅的新增/更新请求。
+type UpsertPlatformEventSubscriptionRequest struct {
+	Name        string   `json:"name"`
+	Enabled     *bool    `json:"enabled"`
+	ChannelID   uint     `json:"channel_id"`
+	EventTypes  []string `json:"event_types"` // empty means all / 为空表示全部
+	ClusterID   string   `json:"cluster_id"`
+	Description string   `json:"description"`
+}
+
+// Validate validates platform event subscription request.
+// Validate 验证平台事件订阅请求参数。
+func (r *UpsertPlatformEventSubscriptionRequest) Validate() error {
+	if r == nil {
+		return fmt.Errorf("empty request")
+	}
+	if strings.TrimSpace(r.Name) == "" {
+		return fmt.Errorf("name is required")
+	}
+	if r.ChannelID == 0 {
+		return fmt.Errorf("channel_id is required")
+	}
+	for _, eventType := range r.EventTypes {
+		if !IsValidPlatformEventType(PlatformEventType(strings.ToLower(strings.TrimSpace(eventType)))) {
+			return fmt.Errorf("invalid event type: %s", eventType)
+		}
+	}
+	return nil
+}
+
 // Validate validates notification route request.
 // Validate 验证通知路由请求参数。
 func (r *UpsertNotificationRouteRequest) Validate() error {
@@ -1149,7 +1324,7 @@ func (r *UpsertNotificationChannelRequest) Validate() error {
 		return fmt.Errorf("name is required")
 	}
 	switch r.Type {
-	case NotificationChannelTypeWebhook, NotificationChannelTypeEmail, NotificationChannelTypeWeCom, NotificationChannelTypeDingTalk, NotificationChannelTypeFeishu:
+	case NotificationChannelTypeWebhook, NotificationChannelTypeEmail, NotificationChannelTypeWeCom, NotificationChannelTypeDingTalk, NotificationChannelTypeFeishu, NotificationChannelTypeSlack:
 	default:
 		return fmt.Errorf("invalid channel type")
 	}
@@ -20,15 +20,28 @@ package monitoring
 import (
 	"bytes"
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"html"
 	"io"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 )
 
+const (
+	// webhookTimestampHeader carries the unix timestamp used in the webhook signature.
+	// webhookTimestampHeader 携带参与 Webhook 签名的 Unix 时间戳。
+	webhookTimestampHeader = "X-SeaTunnelX-Timestamp"
+	// webhookSignatureHeader carries the HMAC-SHA256 signature of one webhook payload.
+	// webhookSignatureHeader 携带 Webhook 负载的 HMAC-SHA256 签名。
+	webhookSignatureHeader = "X-SeaTunnelX-Signature"
+)
+
 // ListNotificationDeliveries returns notification delivery history.
 // ListNotificationDeliveries 返回通知投递历史。
 func (s *Service) ListNotificationDeliveries(ctx context.Context, filter *NotificationDeliveryFilter) (*NotificationDeliveryListData, error) {
@@ -253,6 +266,11 @@ func sendNotification(ctx context.Context, channel *NotificationChannel, payload
 		return nil, err
 	}
 	req.Header.Set("Content-Type", "application/json")
+	if channel.Type == NotificationChannelTypeWebhook && strings.TrimSpace(channel.Secret) != "" {
+		timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+		req.Header.Set(webhookTimestampHeader, timestamp)
+		req.Header.Set(webhookSignatureHeader, signWebhookPayload(strings.TrimSpace(channel.Secret), timestamp, payloadBytes))
+	}
 
 	client := &http.Client{Timeout: 10 * time.Second}
 	resp, err := client.Do(req)
@@ -279,6 +297,16 @@ func sendNotification(ctx context.Context, channel *NotificationChannel, payload
 	return attempt, nil
 }
 
+// signWebhookPayload signs "<timestamp>.<body>" with HMAC-SHA256 so receivers can verify origin and freshness.
+// signWebhookPayload 使用 HMAC-SHA256 对 "<timestamp>.<body>" 签名，便于接收方校验来源与时效。
+func signWebhookPayload(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
 func buildRemoteAlertPayload(channel *NotificationChannel, record *RemoteAlertRecord, eventType NotificationDeliveryEventType) (interface{}, error) {
 	if channel == nil {
 		return nil, fmt.Errorf("notification channel not found")
@@ -333,6 +361,10 @@ func buildRemoteAlertPayload(channel *NotificationChannel, record *RemoteAlertRe
 				"text": message,
 			},
 		}, nil
+	case NotificationChannelTypeSlack:
+		return map[string]interface{}{
+			"text": message,
+		}, nil
 	case NotificationChannelTypeEmail:
 		return &emailNotificationPayload{
 			Subject: title,
@@ -139,3 +139,56 @@ func (r *Repository) ListNotificationDeliveries(ctx context.Context, filter *Not
 	}
 	return deliveries, total, nil
 }
+
+// ListPlatformEventSubscriptions returns all platform event subscriptions.
+// ListPlatformEventSubscriptions 返回全部平台事件订阅。
+func (r *Repository) ListPlatformEventSubscriptions(ctx context.Context) ([]*PlatformEventSubscription, error) {
+	var subscriptions []*PlatformEventSubscription
+	if err := r.db.WithContext(ctx).
+		Order("id DESC").
+		Find(&subscriptions).Error; err != nil {
+		return nil, err
+	}
+	return subscriptions, nil
+}
+
+// ListEnabledPlatformEventSubscriptions returns enabled platform event subscriptions.
+// ListEnabledPlatformEventSubscriptions 返回已启用的平台事件订阅。
+func (r *Repository) ListEnabledPlatformEventSubscriptions(ctx context.Context) ([]*PlatformEventSubscription, error) {
+	var subscriptions []*PlatformEventSubscription
+	if err := r.db.WithContext(ctx).
+		Where("enabled = ?", true).
+		Order("id ASC").
+		Find(&subscriptions).Error; err != nil {
+		return nil, err
+	}
+	return subscriptions, nil
+}
+
+// GetPlatformEventSubscriptionByID returns one platform event subscription by ID.
+// GetPlatformEventSubscriptionByID 根据 ID 返回单条平台事件订阅。
+func (r *Repository) GetPlatformEventSubscriptionByID(ctx context.Context, id uint) (*PlatformEventSubscription, error) {
+	var subscription PlatformEventSubscription
+	if err := r.db.WithContext(ctx).First(&subscription, id).Error; err != nil {
+		return nil, err
+	}
+	return &subscription, nil
+}
+
+// CreatePlatformEventSubscription creates one platform event subscription.
+// CreatePlatformEventSubscription 创建平台事件订阅。
+func (r *Repository) CreatePlatformEventSubscription(ctx context.Context, subscription *PlatformEventSubscription) error {
+	return r.db.WithContext(ctx).Select("*").Create(subscription).Error
+}
+
+// SavePlatformEventSubscription updates one platform event subscription.
+// SavePlatformEventSubscription 更新平台事件订阅。
+func (r *Repository) SavePlatformEventSubscription(ctx context.Context, subscription *PlatformEventSubscription) error {
+	return r.db.WithContext(ctx).Save(subscription).Error
+}
+
+// DeletePlatformEventSubscription deletes one platform event subscription.
+// DeletePlatformEventSubscription 删除平台事件订阅。
+func (r *Repository) DeletePlatformEventSubscription(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Delete(&PlatformEventSubscription{}, id).Error
+}
@@ -385,6 +385,10 @@ func buildTestPayload(channel *NotificationChannel, recipients []string) (interf
 				"text": fmt.Sprintf("[SeaTunnelX] %s", message),
 			},
 		}, nil
+	case NotificationChannelTypeSlack:
+		return map[string]interface{}{
+			"text": fmt.Sprintf("[SeaTunnelX] %s", message),
+		}, nil
 	case NotificationChannelTypeEmail:
 		return &emailNotificationPayload{
 			Subject: "SeaTunnelX notification test",
@@ -0,0 +1,32 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package monitoring
+
+import "errors"
+
+var (
+	// ErrPlatformEventSubscriptionNotFound indicates the requested platform event subscription does not exist.
+	// ErrPlatformEventSubscriptionNotFound 表示请求的平台事件订阅不存在。
+	ErrPlatformEventSubscriptionNotFound = errors.New("monitoring: platform event subscription not found")
+	// ErrPlatformEventSubscriptionInvalidID indicates the platform event subscription ID is invalid.
+	// ErrPlatformEventSubscriptionInvalidID 表示平台事件订阅 ID 非法。
+	ErrPlatformEventSubscriptionInvalidID = errors.New("monitoring: invalid platform event subscription id")
+	// ErrPlatformEventChannelNotFound indicates the notification channel of a subscription does not exist.
+	// ErrPlatformEventChannelNotFound 表示订阅引用的通知渠道不存在。
+	ErrPlatformEventChannelNotFound = errors.New("monitoring: platform event notification channel not found")
+)
@@ -0,0 +1,115 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package monitoring
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ListPlatformEventSubscriptions handles GET /api/v1/monitoring/event-subscriptions
+// ListPlatformEventSubscriptions 处理平台事件订阅列表接口。
+func (h *Handler) ListPlatformEventSubscriptions(c *gin.Context) {
+	data, err := h.service.ListPlatformEventSubscriptions(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, Response{ErrorMsg: "Failed to list platform event subscriptions: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, Response{Data: data})
+}
+
+// CreatePlatformEventSubscription handles POST /api/v1/monitoring/event-subscriptions
+// CreatePlatformEventSubscription 处理新增平台事件订阅接口。
+func (h *Handler) CreatePlatformEventSubscription(c *gin.Context) {
+	var req UpsertPlatformEventSubscriptionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, Response{ErrorMsg: "invalid request body: " + err.Error()})
+		return
+	}
+	if err := req.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, Response{ErrorMsg: err.Error()})
+		return
+	}
+
+	data, err := h.service.CreatePlatformEventSubscription(c.Request.Context(), &req)
+	if err != nil {
+		c.JSON(getPlatformEventSubscriptionStatusCode(err), Response{ErrorMsg: "Failed to create platform event subscription: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, Response{Data: data})
+}
+
+// UpdatePlatformEventSubscription handles PUT /api/v1/monitoring/event-subscriptions/:id
+// UpdatePlatformEventSubscription 处理更新平台事件订阅接口。
+func (h *Handler) UpdatePlatformEventSubscription(c *gin.Context) {
+	subscriptionID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, Response{ErrorMsg: "invalid subscription id"})
+		return
+	}
+
+	var req UpsertPlatformEventSubscriptionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, Response{ErrorMsg: "invalid request body: " + err.Error()})
+		return
+	}
+	if err := req.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, Response{ErrorMsg: err.Error()})
+		return
+	}
+
+	data, err := h.service.UpdatePlatformEventSubscription(c.Request.Context(), uint(subscriptionID), &req)
+	if err != nil {
+		c.JSON(getPlatformEventSubscriptionStatusCode(err), Response{ErrorMsg: "Failed to update platform event subscription: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, Response{Data: data})
+}
+
+// DeletePlatformEventSubscription handles DELETE /api/v1/monitoring/event-subscriptions/:id
+// DeletePlatformEventSubscription 处理删除平台事件订阅接口。
+func (h *Handler) DeletePlatformEventSubscription(c *gin.Context) {
+	subscriptionID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, Response{ErrorMsg: "invalid subscription id"})
+		return
+	}
+
+	if err := h.service.DeletePlatformEventSubscription(c.Request.Context(), uint(subscriptionID)); err != nil {
+		c.JSON(getPlatformEventSubscriptionStatusCode(err), Response{ErrorMsg: "Failed to delete platform event subscription: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, Response{Data: gin.H{"id": subscriptionID}})
+}
+
+func getPlatformEventSubscriptionStatusCode(err error) int {
+	switch {
+	case err == nil:
+		return http.StatusOK
+	case errors.Is(err, ErrPlatformEventSubscriptionNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrPlatformEventSubscriptionInvalidID),
+		errors.Is(err, ErrPlatformEventChannelNotFound):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
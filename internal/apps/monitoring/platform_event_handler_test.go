@@ -0,0 +1,72 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package monitoring
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestHandler_PlatformEventSubscription_mapsMissingRecordsToClientErrors(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	database, cleanup := setupMonitoringNotificationTestDB(t)
+	defer cleanup()
+	if err := database.AutoMigrate(&PlatformEventSubscription{}); err != nil {
+		t.Fatalf("failed to migrate platform event subscriptions: %v", err)
+	}
+	repo := NewRepository(database)
+	handler := NewHandler(NewService(nil, nil, repo))
+	engine := gin.New()
+	engine.POST("/event-subscriptions", handler.CreatePlatformEventSubscription)
+	engine.PUT("/event-subscriptions/:id", handler.UpdatePlatformEventSubscription)
+	engine.DELETE("/event-subscriptions/:id", handler.DeletePlatformEventSubscription)
+
+	channel := &NotificationChannel{Name: "ops-webhook", Type: NotificationChannelTypeWebhook, Enabled: true, Endpoint: "http://127.0.0.1:1"}
+	if err := repo.CreateNotificationChannel(context.Background(), channel); err != nil {
+		t.Fatalf("failed to create notification channel: %v", err)
+	}
+
+	serve := func(method, path, body string) int {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		recorder := httptest.NewRecorder()
+		engine.ServeHTTP(recorder, req)
+		return recorder.Code
+	}
+
+	valid := fmt.Sprintf(`{"name":"install failures","channel_id":%d,"event_types":["installation_failed"]}`, channel.ID)
+	if code := serve(http.MethodPut, "/event-subscriptions/999", valid); code != http.StatusNotFound {
+		t.Fatalf("update missing subscription status = %d, want %d", code, http.StatusNotFound)
+	}
+	if code := serve(http.MethodDelete, "/event-subscriptions/999", ""); code != http.StatusNotFound {
+		t.Fatalf("delete missing subscription status = %d, want %d", code, http.StatusNotFound)
+	}
+	unknownChannel := fmt.Sprintf(`{"name":"install failures","channel_id":%d,"event_types":["installation_failed"]}`, channel.ID+41)
+	if code := serve(http.MethodPost, "/event-subscriptions", unknownChannel); code != http.StatusBadRequest {
+		t.Fatalf("create with unknown channel status = %d, want %d", code, http.StatusBadRequest)
+	}
+	if code := serve(http.MethodPost, "/event-subscriptions", valid); code != http.StatusOK {
+		t.Fatalf("create status = %d, want %d", code, http.StatusOK)
+	}
+}
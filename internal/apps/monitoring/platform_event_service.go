@@ -0,0 +1,558 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package monitoring
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/seatunnel/seatunnelX/internal/apps/monitor"
+	"gorm.io/gorm"
+)
+
+// PlatformEventType represents one platform lifecycle event that can trigger notifications.
+// PlatformEventType 表示可触发通知的平台生命周期事件类型。
+type PlatformEventType string
+
+const (
+	// PlatformEventAgentOffline indicates an Agent lost its heartbeat or stream.
+	// PlatformEventAgentOffline 表示 Agent 心跳超时或连接断开。
+	PlatformEventAgentOffline PlatformEventType = "agent_offline"
+	// PlatformEventInstallationFailed indicates a SeaTunnel installation failed on one host.
+	// PlatformEventInstallationFailed 表示某主机上的 SeaTunnel 安装失败。
+	PlatformEventInstallationFailed PlatformEventType = "installation_failed"
+	// PlatformEventNodeCrash indicates a SeaTunnel process crashed unexpectedly.
+	// PlatformEventNodeCrash 表示 SeaTunnel 进程意外崩溃。
+	PlatformEventNodeCrash PlatformEventType = "node_crash"
+	// PlatformEventUpgradeCompleted indicates a cluster upgrade task succeeded.
+	// PlatformEventUpgradeCompleted 表示集群升级任务成功完成。
+	PlatformEventUpgradeCompleted PlatformEventType = "upgrade_completed"
+	// PlatformEventUpgradeFailed indicates a cluster upgrade task failed.
+	// PlatformEventUpgradeFailed 表示集群升级任务失败。
+	PlatformEventUpgradeFailed PlatformEventType = "upgrade_failed"
+	// PlatformEventMetricAlert indicates a heartbeat metric alert started firing.
+	// PlatformEventMetricAlert 表示心跳指标告警开始触发。
+	PlatformEventMetricAlert PlatformEventType = "metric_alert"
+	// PlatformEventMetricRecovered indicates a heartbeat metric alert recovered.
+	// PlatformEventMetricRecovered 表示心跳指标告警已恢复。
+	PlatformEventMetricRecovered PlatformEventType = "metric_recovered"
+)
+
+// platformEventSourceType marks delivery records produced by platform events.
+// platformEventSourceType 标记由平台事件产生的投递记录。
+const platformEventSourceType = "platform_event"
+
+// PlatformEventTypes lists all supported platform event types.
+// PlatformEventTypes 列出所有支持的平台事件类型。
+var PlatformEventTypes = []PlatformEventType{
+	PlatformEventAgentOffline,
+	PlatformEventInstallationFailed,
+	PlatformEventNodeCrash,
+	PlatformEventUpgradeCompleted,
+	PlatformEventUpgradeFailed,
+	PlatformEventMetricAlert,
+	PlatformEventMetricRecovered,
+}
+
+// PlatformEvent is one platform lifecycle event published by other modules.
+// PlatformEvent 表示其他模块发布的一条平台生命周期事件。
+type PlatformEvent struct {
+	Type        PlatformEventType `json:"type"`
+	ClusterID   string            `json:"cluster_id,omitempty"`
+	ClusterName string            `json:"cluster_name,omitempty"`
+	HostID      uint              `json:"host_id,omitempty"`
+	HostName    string            `json:"host_name,omitempty"`
+	Title       string            `json:"title"`
+	Message     string            `json:"message"`
+	Details     map[string]string `json:"details,omitempty"`
+	OccurredAt  time.Time         `json:"occurred_at"`
+}
+
+// notificationRetryPolicy controls retries of one notification send.
+// notificationRetryPolicy 控制单次通知发送的重试策略。
+type notificationRetryPolicy struct {
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+func defaultNotificationRetryPolicy() notificationRetryPolicy {
+	return notificationRetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: 2 * time.Second,
+		MaxBackoff:     30 * time.Second,
+	}
+}
+
+// IsValidPlatformEventType reports whether the event type is supported.
+// IsValidPlatformEventType 判断事件类型是否受支持。
+func IsValidPlatformEventType(eventType PlatformEventType) bool {
+	for _, item := range PlatformEventTypes {
+		if item == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// PublishPlatformEvent delivers one platform event asynchronously so callers are never blocked by slow receivers.
+// PublishPlatformEvent 异步投递平台事件，避免调用方被慢速接收端阻塞。
+func (s *Service) PublishPlatformEvent(event *PlatformEvent) {
+	if s == nil || s.repo == nil || event == nil {
+		return
+	}
+	copied := *event
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+		defer cancel()
+		if err := s.DeliverPlatformEvent(ctx, &copied); err != nil {
+			log.Printf("[Monitoring] deliver platform event failed: type=%s cluster=%s host=%d err=%v / 平台事件投递失败",
+				copied.Type, copied.ClusterID, copied.HostID, err)
+		}
+	}()
+}
+
+// PublishProcessEvent converts crash process events into node_crash platform events.
+// PublishProcessEvent 将进程崩溃事件转换为 node_crash 平台事件。
+func (s *Service) PublishProcessEvent(ctx context.Context, event *monitor.ProcessEvent) error {
+	if event == nil || event.EventType != monitor.EventTypeCrashed {
+		return nil
+	}
+	clusterName := ""
+	if s.clusterService != nil {
+		if clusterInfo, err := s.clusterService.Get(ctx, event.ClusterID); err == nil && clusterInfo != nil {
+			clusterName = clusterInfo.Name
+		}
+	}
+	s.PublishPlatformEvent(&PlatformEvent{
+		Type:        PlatformEventNodeCrash,
+		ClusterID:   strconv.FormatUint(uint64(event.ClusterID), 10),
+		ClusterName: clusterName,
+		HostID:      event.HostID,
+		Title:       "SeaTunnel node crashed / SeaTunnel 节点崩溃",
+		Message: fmt.Sprintf("Process %s (pid %d, role %s) crashed on host %d / 进程 %s（pid %d，角色 %s）在主机 %d 上崩溃",
+			event.ProcessName, event.PID, event.Role, event.HostID, event.ProcessName, event.PID, event.Role, event.HostID),
+		Details: map[string]string{
+			"node_id":     strconv.FormatUint(uint64(event.NodeID), 10),
+			"install_dir": event.InstallDir,
+		},
+		OccurredAt: event.CreatedAt,
+	})
+	return nil
+}
+
+// DeliverPlatformEvent sends one platform event to every matching subscription and records deliveries.
+// DeliverPlatformEvent 将平台事件发送给所有匹配的订阅并记录投递结果。
+func (s *Service) DeliverPlatformEvent(ctx context.Context, event *PlatformEvent) error {
+	if s.repo == nil {
+		return fmt.Errorf("monitoring repository is not configured")
+	}
+	if event == nil || !IsValidPlatformEventType(event.Type) {
+		return fmt.Errorf("invalid platform event type")
+	}
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = time.Now().UTC()
+	}
+	if strings.TrimSpace(event.Title) == "" {
+		event.Title = string(event.Type)
+	}
+
+	subscriptions, err := s.repo.ListEnabledPlatformEventSubscriptions(ctx)
+	if err != nil {
+		return err
+	}
+
+	sourceKey := buildPlatformEventSourceKey(event)
+	processedChannels := make(map[uint]struct{})
+	var errs []error
+	for _, subscription := range subscriptions {
+		if !matchPlatformEventSubscription(subscription, event) {
+			continue
+		}
+		if _, ok := processedChannels[subscription.ChannelID]; ok {
+			continue
+		}
+		processedChannels[subscription.ChannelID] = struct{}{}
+
+		channel, err := s.repo.GetNotificationChannelByID(ctx, subscription.ChannelID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				continue
+			}
+			errs = append(errs, err)
+			continue
+		}
+		if !channel.Enabled {
+			continue
+		}
+		if err := s.dispatchPlatformEventDelivery(ctx, sourceKey, event, channel); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (s *Service) dispatchPlatformEventDelivery(ctx context.Context, sourceKey string, event *PlatformEvent, channel *NotificationChannel) error {
+	delivery := &NotificationDelivery{
+		AlertID:     sourceKey,
+		SourceType:  platformEventSourceType,
+		SourceKey:   sourceKey,
+		ClusterID:   strings.TrimSpace(event.ClusterID),
+		ClusterName: strings.TrimSpace(event.ClusterName),
+		AlertName:   strings.TrimSpace(event.Title),
+		ChannelID:   channel.ID,
+		ChannelName: strings.TrimSpace(channel.Name),
+		EventType:   string(event.Type),
+		Status:      string(NotificationDeliveryStatusSending),
+	}
+	if err := s.repo.CreateNotificationDelivery(ctx, delivery); err != nil {
+		return err
+	}
+
+	payload, err := buildPlatformEventPayload(channel, event)
+	if err != nil {
+		delivery.Status = string(NotificationDeliveryStatusFailed)
+		delivery.LastError = err.Error()
+		return s.repo.SaveNotificationDelivery(ctx, delivery)
+	}
+
+	attempt, attempts, sendErr := sendNotificationWithRetry(ctx, channel, payload, s.platformEventRetry)
+	delivery.AttemptCount = attempts
+	if attempt != nil {
+		delivery.RequestPayload = attempt.RequestPayload
+		delivery.ResponseStatusCode = attempt.StatusCode
+		delivery.ResponseBodyExcerpt = attempt.ResponseBody
+		delivery.SentAt = attempt.SentAt
+	}
+	if sendErr != nil {
+		delivery.Status = string(NotificationDeliveryStatusFailed)
+		delivery.LastError = sendErr.Error()
+		return s.repo.SaveNotificationDelivery(ctx, delivery)
+	}
+	delivery.Status = string(NotificationDeliveryStatusSent)
+	delivery.LastError = ""
+	return s.repo.SaveNotificationDelivery(ctx, delivery)
+}
+
+// sendNotificationWithRetry retries transient send failures with exponential backoff.
+// sendNotificationWithRetry 对临时性发送失败按指数退避进行重试。
+func sendNotificationWithRetry(ctx context.Context, channel *NotificationChannel, payload interface{}, policy notificationRetryPolicy) (*notificationSendAttempt, int, error) {
+	if policy.MaxAttempts <= 0 {
+		policy.MaxAttempts = 1
+	}
+	backoff := policy.InitialBackoff
+
+	var (
+		attempt *notificationSendAttempt
+		err     error
+	)
+	for i := 1; i <= policy.MaxAttempts; i++ {
+		attempt, err = sendNotification(ctx, channel, payload)
+		if err == nil || !isRetryableNotificationFailure(attempt) || i == policy.MaxAttempts {
+			return attempt, i, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return attempt, i, err
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+		if policy.MaxBackoff > 0 && backoff > policy.MaxBackoff {
+			backoff = policy.MaxBackoff
+		}
+	}
+	return attempt, policy.MaxAttempts, err
+}
+
+// isRetryableNotificationFailure treats transport errors, 429 and 5xx as transient.
+// isRetryableNotificationFailure 将传输错误、429 与 5xx 视为临时性失败。
+func isRetryableNotificationFailure(attempt *notificationSendAttempt) bool {
+	if attempt == nil || attempt.StatusCode == 0 {
+		return true
+	}
+	return attempt.StatusCode == 429 || attempt.StatusCode >= 500
+}
+
+func buildPlatformEventSourceKey(event *PlatformEvent) string {
+	return fmt.Sprintf("platform:%s:%s:%d:%d", event.Type, strings.TrimSpace(event.ClusterID), event.HostID, event.OccurredAt.UnixNano())
+}
+
+func matchPlatformEventSubscription(subscription *PlatformEventSubscription, event *PlatformEvent) bool {
+	if subscription == nil || event == nil || !subscription.Enabled {
+		return false
+	}
+	if clusterID := strings.TrimSpace(subscription.ClusterID); clusterID != "" && clusterID != strings.TrimSpace(event.ClusterID) {
+		return false
+	}
+	eventTypes := splitPlatformEventTypes(subscription.EventTypes)
+	if len(eventTypes) == 0 {
+		return true
+	}
+	for _, eventType := range eventTypes {
+		if eventType == event.Type {
+			return true
+		}
+	}
+	return false
+}
+
+func buildPlatformEventPayload(channel *NotificationChannel, event *PlatformEvent) (interface{}, error) {
+	if channel == nil {
+		return nil, ErrPlatformEventChannelNotFound
+	}
+	message := buildPlatformEventMessageText(event)
+
+	switch channel.Type {
+	case NotificationChannelTypeWebhook:
+		return map[string]interface{}{
+			"title":   event.Title,
+			"message": event.Message,
+			"event": map[string]interface{}{
+				"type":         event.Type,
+				"cluster_id":   strings.TrimSpace(event.ClusterID),
+				"cluster_name": strings.TrimSpace(event.ClusterName),
+				"host_id":      event.HostID,
+				"host_name":    strings.TrimSpace(event.HostName),
+				"details":      event.Details,
+				"occurred_at":  event.OccurredAt.UTC().Format(time.RFC3339),
+			},
+			"sent_at": time.Now().UTC().Format(time.RFC3339),
+		}, nil
+	case NotificationChannelTypeWeCom, NotificationChannelTypeDingTalk:
+		return map[string]interface{}{
+			"msgtype": "text",
+			"text": map[string]string{
+				"content": message,
+			},
+		}, nil
+	case NotificationChannelTypeFeishu:
+		return map[string]interface{}{
+			"msg_type": "text",
+			"content": map[string]string{
+				"text": message,
+			},
+		}, nil
+	case NotificationChannelTypeSlack:
+		return map[string]interface{}{
+			"text": message,
+		}, nil
+	case NotificationChannelTypeEmail:
+		return &emailNotificationPayload{
+			Subject: fmt.Sprintf("[SeaTunnelX] %s", event.Title),
+			Text:    message,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported channel type: %s", channel.Type)
+	}
+}
+
+func buildPlatformEventMessageText(event *PlatformEvent) string {
+	lines := []string{fmt.Sprintf("[SeaTunnelX] %s", event.Title)}
+	if strings.TrimSpace(event.Message) != "" {
+		lines = append(lines, strings.TrimSpace(event.Message))
+	}
+	lines = append(lines, fmt.Sprintf("Event / 事件: %s", event.Type))
+	if clusterID := strings.TrimSpace(event.ClusterID); clusterID != "" {
+		cluster := clusterID
+		if name := strings.TrimSpace(event.ClusterName); name != "" {
+			cluster = fmt.Sprintf("%s (%s)", name, clusterID)
+		}
+		lines = append(lines, fmt.Sprintf("Cluster / 集群: %s", cluster))
+	}
+	if event.HostID > 0 || strings.TrimSpace(event.HostName) != "" {
+		host := strconv.FormatUint(uint64(event.HostID), 10)
+		if name := strings.TrimSpace(event.HostName); name != "" {
+			host = fmt.Sprintf("%s (%d)", name, event.HostID)
+		}
+		lines = append(lines, fmt.Sprintf("Host / 主机: %s", host))
+	}
+	keys := make([]string, 0, len(event.Details))
+	for key := range event.Details {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if value := strings.TrimSpace(event.Details[key]); value != "" {
+			lines = append(lines, fmt.Sprintf("%s: %s", key, value))
+		}
+	}
+	lines = append(lines, fmt.Sprintf("Time / 时间: %s", event.OccurredAt.UTC().Format(time.RFC3339)))
+	return strings.Join(lines, "\n")
+}
+
+// ==================== Subscription CRUD 订阅管理 ====================
+
+// ListPlatformEventSubscriptions returns all platform event subscriptions.
+// ListPlatformEventSubscriptions 返回全部平台事件订阅。
+func (s *Service) ListPlatformEventSubscriptions(ctx context.Context) (*PlatformEventSubscriptionListData, error) {
+	if s.repo == nil {
+		return nil, fmt.Errorf("monitoring repository is not configured")
+	}
+	subscriptions, err := s.repo.ListPlatformEventSubscriptions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]*PlatformEventSubscriptionDTO, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		items = append(items, toPlatformEventSubscriptionDTO(subscription))
+	}
+	return &PlatformEventSubscriptionListData{
+		GeneratedAt:   time.Now().UTC(),
+		Total:         len(items),
+		EventTypes:    PlatformEventTypes,
+		Subscriptions: items,
+	}, nil
+}
+
+// CreatePlatformEventSubscription creates one platform event subscription.
+// CreatePlatformEventSubscription 创建一条平台事件订阅。
+func (s *Service) CreatePlatformEventSubscription(ctx context.Context, req *UpsertPlatformEventSubscriptionRequest) (*PlatformEventSubscriptionDTO, error) {
+	if s.repo == nil {
+		return nil, fmt.Errorf("monitoring repository is not configured")
+	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.ensureNotificationChannelExists(ctx, req.ChannelID); err != nil {
+		return nil, err
+	}
+
+	subscription := &PlatformEventSubscription{}
+	applyPlatformEventSubscriptionRequest(subscription, req)
+	if err := s.repo.CreatePlatformEventSubscription(ctx, subscription); err != nil {
+		return nil, err
+	}
+	return toPlatformEventSubscriptionDTO(subscription), nil
+}
+
+// UpdatePlatformEventSubscription updates one platform event subscription.
+// UpdatePlatformEventSubscription 更新一条平台事件订阅。
+func (s *Service) UpdatePlatformEventSubscription(ctx context.Context, id uint, req *UpsertPlatformEventSubscriptionRequest) (*PlatformEventSubscriptionDTO, error) {
+	if s.repo == nil {
+		return nil, fmt.Errorf("monitoring repository is not configured")
+	}
+	if id == 0 {
+		return nil, ErrPlatformEventSubscriptionInvalidID
+	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	subscription, err := s.repo.GetPlatformEventSubscriptionByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrPlatformEventSubscriptionNotFound
+		}
+		return nil, err
+	}
+	if err := s.ensureNotificationChannelExists(ctx, req.ChannelID); err != nil {
+		return nil, err
+	}
+
+	applyPlatformEventSubscriptionRequest(subscription, req)
+	if err := s.repo.SavePlatformEventSubscription(ctx, subscription); err != nil {
+		return nil, err
+	}
+	return toPlatformEventSubscriptionDTO(subscription), nil
+}
+
+// DeletePlatformEventSubscription deletes one platform event subscription.
+// DeletePlatformEventSubscription 删除一条平台事件订阅。
+func (s *Service) DeletePlatformEventSubscription(ctx context.Context, id uint) error {
+	if s.repo == nil {
+		return fmt.Errorf("monitoring repository is not configured")
+	}
+	if id == 0 {
+		return ErrPlatformEventSubscriptionInvalidID
+	}
+	if _, err := s.repo.GetPlatformEventSubscriptionByID(ctx, id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrPlatformEventSubscriptionNotFound
+		}
+		return err
+	}
+	return s.repo.DeletePlatformEventSubscription(ctx, id)
+}
+
+func (s *Service) ensureNotificationChannelExists(ctx context.Context, channelID uint) error {
+	if _, err := s.repo.GetNotificationChannelByID(ctx, channelID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrPlatformEventChannelNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+func applyPlatformEventSubscriptionRequest(subscription *PlatformEventSubscription, req *UpsertPlatformEventSubscriptionRequest) {
+	enabled := true
+	if req.Enabled != nil {
+		enabled = *req.Enabled
+	}
+	eventTypes := make([]string, 0, len(req.EventTypes))
+	seen := make(map[string]struct{}, len(req.EventTypes))
+	for _, eventType := range req.EventTypes {
+		normalized := strings.ToLower(strings.TrimSpace(eventType))
+		if _, ok := seen[normalized]; ok || normalized == "" {
+			continue
+		}
+		seen[normalized] = struct{}{}
+		eventTypes = append(eventTypes, normalized)
+	}
+
+	subscription.Name = strings.TrimSpace(req.Name)
+	subscription.Enabled = enabled
+	subscription.ChannelID = req.ChannelID
+	subscription.EventTypes = strings.Join(eventTypes, ",")
+	subscription.ClusterID = strings.TrimSpace(req.ClusterID)
+	subscription.Description = strings.TrimSpace(req.Description)
+}
+
+func splitPlatformEventTypes(raw string) []PlatformEventType {
+	result := make([]PlatformEventType, 0)
+	for _, item := range strings.Split(raw, ",") {
+		if trimmed := strings.TrimSpace(item); trimmed != "" {
+			result = append(result, PlatformEventType(trimmed))
+		}
+	}
+	return result
+}
+
+func toPlatformEventSubscriptionDTO(subscription *PlatformEventSubscription) *PlatformEventSubscriptionDTO {
+	if subscription == nil {
+		return nil
+	}
+	return &PlatformEventSubscriptionDTO{
+		ID:          subscription.ID,
+		Name:        subscription.Name,
+		Enabled:     subscription.Enabled,
+		ChannelID:   subscription.ChannelID,
+		EventTypes:  splitPlatformEventTypes(subscription.EventTypes),
+		ClusterID:   subscription.ClusterID,
+		Description: subscription.Description,
+		CreatedAt:   subscription.CreatedAt,
+		UpdatedAt:   subscription.UpdatedAt,
+	}
+}
@@ -0,0 +1,129 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package monitoring
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestService_DeliverPlatformEvent_signsAndRetriesWebhook(t *testing.T) {
+	database, cleanup := setupMonitoringNotificationTestDB(t)
+	defer cleanup()
+	if err := database.AutoMigrate(&PlatformEventSubscription{}); err != nil {
+		t.Fatalf("failed to migrate platform event subscriptions: %v", err)
+	}
+
+	repo := NewRepository(database)
+	service := NewService(nil, nil, repo)
+	service.platformEventRetry = notificationRetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}
+	ctx := context.Background()
+
+	const secret = "s3cr3t"
+	var hitCount atomic.Int32
+	var signatureOK atomic.Bool
+	var lastBody atomic.Value
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write([]byte(r.Header.Get(webhookTimestampHeader) + "."))
+		mac.Write(body)
+		signatureOK.Store(r.Header.Get(webhookSignatureHeader) == "sha256="+hex.EncodeToString(mac.Sum(nil)))
+		lastBody.Store(string(body))
+		if hitCount.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	channel := &NotificationChannel{
+		Name:     "ops-webhook",
+		Type:     NotificationChannelTypeWebhook,
+		Enabled:  true,
+		Endpoint: server.URL,
+		Secret:   secret,
+	}
+	if err := repo.CreateNotificationChannel(ctx, channel); err != nil {
+		t.Fatalf("failed to create notification channel: %v", err)
+	}
+
+	if _, err := service.CreatePlatformEventSubscription(ctx, &UpsertPlatformEventSubscriptionRequest{
+		Name:       "install failures",
+		ChannelID:  channel.ID,
+		EventTypes: []string{string(PlatformEventInstallationFailed)},
+	}); err != nil {
+		t.Fatalf("CreatePlatformEventSubscription returned error: %v", err)
+	}
+
+	// Events outside the subscription filter are not delivered.
+	// 不在订阅过滤范围内的事件不会投递。
+	if err := service.DeliverPlatformEvent(ctx, &PlatformEvent{Type: PlatformEventUpgradeCompleted, ClusterID: "1"}); err != nil {
+		t.Fatalf("DeliverPlatformEvent returned error: %v", err)
+	}
+	if hitCount.Load() != 0 {
+		t.Fatalf("expected filtered event not to be delivered, got %d hits", hitCount.Load())
+	}
+
+	if err := service.DeliverPlatformEvent(ctx, &PlatformEvent{
+		Type:    PlatformEventInstallationFailed,
+		HostID:  3,
+		Title:   "SeaTunnel installation failed",
+		Message: "disk full",
+	}); err != nil {
+		t.Fatalf("DeliverPlatformEvent returned error: %v", err)
+	}
+	if hitCount.Load() != 2 {
+		t.Fatalf("expected one retry after 503, got %d hits", hitCount.Load())
+	}
+	if !signatureOK.Load() {
+		t.Fatal("expected webhook request to carry a valid HMAC signature")
+	}
+	if body, _ := lastBody.Load().(string); !strings.Contains(body, `"installation_failed"`) {
+		t.Fatalf("expected event type in payload, got %s", body)
+	}
+
+	deliveries, total, err := repo.ListNotificationDeliveries(ctx, &NotificationDeliveryFilter{Page: 1, PageSize: 10})
+	if err != nil {
+		t.Fatalf("ListNotificationDeliveries returned error: %v", err)
+	}
+	if total != 1 || deliveries[0].Status != string(NotificationDeliveryStatusSent) || deliveries[0].AttemptCount != 2 {
+		t.Fatalf("unexpected deliveries: total=%d %+v", total, deliveries)
+	}
+}
+
+func TestUpsertPlatformEventSubscriptionRequest_Validate(t *testing.T) {
+	req := &UpsertPlatformEventSubscriptionRequest{Name: "all", ChannelID: 1}
+	if err := req.Validate(); err != nil {
+		t.Fatalf("expected empty event types to be valid, got %v", err)
+	}
+	req.EventTypes = []string{"agent_offline", "unknown"}
+	if err := req.Validate(); err == nil {
+		t.Fatal("expected unknown event type to be rejected")
+	}
+}
@@ -36,6 +36,8 @@ import (
 	"github.com/seatunnel/seatunnelX/internal/apps/cluster"
 	"github.com/seatunnel/seatunnelX/internal/apps/monitor"
 	"github.com/seatunnel/seatunnelX/internal/config"
+	"github.com/seatunnel/seatunnelX/internal/pkg/leader"
+	"github.com/seatunnel/seatunnelX/internal/pkg/worker"
 	"gorm.io/gorm"
 )
 
@@ -55,6 +57,10 @@ type Service struct {
 	nodeHealthStartupSuppression time.Duration
 	nodeHealthOfflineObservedAt  map[uint]time.Time
 	nodeHealthStateMu            sync.Mutex
+
+	platformEventRetry notificationRetryPolicy
+
+	heartbeatAlertMu sync.Mutex
 }
 
 // NewService creates a monitoring service.
@@ -66,6 +72,7 @@ func NewService(clusterService *cluster.Service, monitorService *monitor.Service
 		repo:                         repo,
 		nodeHealthStartupSuppression: defaultNodeHealthStartupSuppressionWindow(),
 		nodeHealthOfflineObservedAt:  make(map[uint]time.Time),
+		platformEventRetry:           defaultNotificationRetryPolicy(),
 	}
 }
 
@@ -1079,12 +1086,16 @@ func (s *Service) StartNodeHealthEvaluator(ctx context.Context) {
 	}
 
 	go func() {
+		defer worker.Start("monitoring-node-health-evaluator")()
+
 		ticker := time.NewTicker(defaultNodeHealthEvaluationInterval)
 		defer ticker.Stop()
 
 		for {
-			if err := s.EvaluateNodeHealthAlerts(ctx); err != nil {
-				log.Printf("[Monitoring] evaluate node health alerts failed: %v / 评估节点健康告警失败: %v", err, err)
+			if leader.IsLeader() {
+				if err := s.EvaluateNodeHealthAlerts(ctx); err != nil {
+					log.Printf("[Monitoring] evaluate node health alerts failed: %v / 评估节点健康告警失败: %v", err, err)
+				}
 			}
 
 			select {
@@ -1228,6 +1239,10 @@ func shouldSkipNodeOfflineEvaluation(node *cluster.NodeInfo) bool {
 	if node == nil {
 		return true
 	}
+	// Hosts in maintenance mode are expected to go offline / 维护模式下的主机预期会离线
+	if node.HostMaintenance {
+		return true
+	}
 	switch node.Status {
 	case cluster.NodeStatusPending, cluster.NodeStatusInstalling:
 		return true
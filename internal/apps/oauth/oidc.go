@@ -0,0 +1,199 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/seatunnel/seatunnelX/internal/apps/auth"
+	"github.com/seatunnel/seatunnelX/internal/config"
+	"golang.org/x/oauth2"
+)
+
+// oidcDiscoveryTimeout OIDC 端点发现请求的超时时间
+const oidcDiscoveryTimeout = 10 * time.Second
+
+// oidcDiscovery {issuer}/.well-known/openid-configuration 中使用到的字段
+type oidcDiscovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	UserinfoEndpoint      string `json:"userinfo_endpoint"`
+}
+
+// oidcProvider 通用 OIDC 提供商
+// 端点在首次使用时通过 issuer 自动发现并缓存，发现失败时下次请求重试
+// 用户信息通过 userinfo 端点以 access token 获取，由提供商校验令牌，无需本地验证 ID Token 签名
+type oidcProvider struct {
+	cfg config.OIDCProviderConfig
+
+	mu          sync.Mutex
+	oauthConfig *oauth2.Config
+	userInfoURL string
+}
+
+// resolve 返回 OAuth2 配置，必要时执行端点发现
+func (p *oidcProvider) resolve(ctx context.Context) (*oauth2.Config, string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.oauthConfig != nil {
+		return p.oauthConfig, p.userInfoURL, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, oidcDiscoveryTimeout)
+	defer cancel()
+	discovery, err := discoverOIDC(ctx, p.cfg.Issuer)
+	if err != nil {
+		return nil, "", err
+	}
+
+	scopes := p.cfg.Scopes
+	if len(scopes) == 0 {
+		scopes = []string{"openid", "profile", "email"}
+	}
+	p.oauthConfig = &oauth2.Config{
+		ClientID:     p.cfg.ClientID,
+		ClientSecret: p.cfg.ClientSecret,
+		RedirectURL:  p.cfg.RedirectURI,
+		Scopes:       scopes,
+		Endpoint: oauth2.Endpoint{
+			AuthURL:  discovery.AuthorizationEndpoint,
+			TokenURL: discovery.TokenEndpoint,
+		},
+	}
+	p.userInfoURL = discovery.UserinfoEndpoint
+	return p.oauthConfig, p.userInfoURL, nil
+}
+
+// discoverOIDC 读取 issuer 的 OpenID 配置文档并校验 issuer 一致
+func discoverOIDC(ctx context.Context, issuer string) (*oidcDiscovery, error) {
+	issuer = strings.TrimSuffix(strings.TrimSpace(issuer), "/")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, issuer+"/.well-known/openid-configuration", nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover OIDC provider: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("OIDC discovery error: %s %s", resp.Status, string(body))
+	}
+
+	var discovery oidcDiscovery
+	if err := json.NewDecoder(resp.Body).Decode(&discovery); err != nil {
+		return nil, fmt.Errorf("failed to decode OIDC discovery document: %w", err)
+	}
+	if strings.TrimSuffix(discovery.Issuer, "/") != issuer {
+		return nil, fmt.Errorf("OIDC issuer mismatch: expected %s, got %s", issuer, discovery.Issuer)
+	}
+	if discovery.AuthorizationEndpoint == "" || discovery.TokenEndpoint == "" || discovery.UserinfoEndpoint == "" {
+		return nil, fmt.Errorf("OIDC discovery document of %s is missing required endpoints", issuer)
+	}
+	return &discovery, nil
+}
+
+// fetchUserInfo 获取 OIDC 用户信息，并按 groups 声明映射全局角色
+func (p *oidcProvider) fetchUserInfo(ctx context.Context, provider OAuthProvider, token *oauth2.Token) (*OAuthUserInfo, error) {
+	oauthConfig, userInfoURL, err := p.resolve(ctx)
+	if err != nil {
+		return nil, err
+	}
+	client := oauthConfig.Client(ctx, token)
+
+	resp, err := client.Get(userInfoURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get OIDC user info: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("OIDC userinfo error: %s", string(body))
+	}
+
+	var claims map[string]any
+	if err := json.NewDecoder(resp.Body).Decode(&claims); err != nil {
+		return nil, fmt.Errorf("failed to decode OIDC user info: %w", err)
+	}
+
+	subject := claimString(claims, "sub")
+	if subject == "" {
+		return nil, fmt.Errorf("OIDC user info is missing the sub claim")
+	}
+	email := claimString(claims, claimName(p.cfg.EmailClaim, "email"))
+	username := claimString(claims, claimName(p.cfg.UsernameClaim, "preferred_username"))
+	if username == "" && email != "" {
+		username = strings.SplitN(email, "@", 2)[0]
+	}
+	if username == "" {
+		username = subject
+	}
+
+	return &OAuthUserInfo{
+		ID:        subject,
+		Username:  username,
+		Email:     email,
+		Name:      claimString(claims, claimName(p.cfg.NameClaim, "name")),
+		AvatarURL: claimString(claims, "picture"),
+		Provider:  string(provider),
+		Role:      auth.MapExternalRole(claimStrings(claims, claimName(p.cfg.GroupsClaim, "groups")), p.cfg.RoleMappings, p.cfg.DefaultRole),
+	}, nil
+}
+
+// claimName 返回配置的声明名称，未配置时使用默认值
+func claimName(configured, fallback string) string {
+	if strings.TrimSpace(configured) == "" {
+		return fallback
+	}
+	return strings.TrimSpace(configured)
+}
+
+// claimString 读取字符串声明
+func claimString(claims map[string]any, name string) string {
+	value, _ := claims[name].(string)
+	return strings.TrimSpace(value)
+}
+
+// claimStrings 读取字符串数组声明，兼容单个字符串
+func claimStrings(claims map[string]any, name string) []string {
+	switch value := claims[name].(type) {
+	case string:
+		return []string{value}
+	case []any:
+		out := make([]string, 0, len(value))
+		for _, item := range value {
+			if s, ok := item.(string); ok {
+				out = append(out, s)
+			}
+		}
+		return out
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,100 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/seatunnel/seatunnelX/internal/apps/auth"
+	"github.com/seatunnel/seatunnelX/internal/config"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+)
+
+func newOIDCTestServer(t *testing.T, issuerOverride string) *httptest.Server {
+	t.Helper()
+	var server *httptest.Server
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		issuer := server.URL
+		if issuerOverride != "" {
+			issuer = issuerOverride
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 issuer,
+			"authorization_endpoint": server.URL + "/authorize",
+			"token_endpoint":         server.URL + "/token",
+			"userinfo_endpoint":      server.URL + "/userinfo",
+		})
+	})
+	mux.HandleFunc("/userinfo", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer test-access-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"sub":                "user-123",
+			"preferred_username": "alice",
+			"email":              "alice@example.com",
+			"name":               "Alice",
+			"roles":              []string{"sre", "platform-admins"},
+		})
+	})
+	server = httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestOIDCProviderDiscoveryAndUserInfo(t *testing.T) {
+	server := newOIDCTestServer(t, "")
+	provider := &oidcProvider{cfg: config.OIDCProviderConfig{
+		Name:        "keycloak",
+		Issuer:      server.URL + "/",
+		ClientID:    "seatunnelx",
+		GroupsClaim: "roles",
+		RoleMappings: []config.RoleMappingConfig{
+			{Group: "sre", Role: "operator"},
+			{Group: "platform-admins", Role: "admin"},
+		},
+	}}
+
+	conf, _, err := provider.resolve(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, server.URL+"/authorize", conf.Endpoint.AuthURL)
+	require.Equal(t, []string{"openid", "profile", "email"}, conf.Scopes)
+
+	info, err := provider.fetchUserInfo(context.Background(), "keycloak", &oauth2.Token{AccessToken: "test-access-token"})
+	require.NoError(t, err)
+	require.Equal(t, "user-123", info.ID)
+	require.Equal(t, "alice", info.Username)
+	require.Equal(t, "alice@example.com", info.Email)
+	require.Equal(t, "keycloak", info.Provider)
+	require.Equal(t, auth.RoleAdmin, info.Role)
+}
+
+func TestOIDCProviderRejectsIssuerMismatch(t *testing.T) {
+	server := newOIDCTestServer(t, "https://evil.example")
+	provider := &oidcProvider{cfg: config.OIDCProviderConfig{Name: "okta", Issuer: server.URL, ClientID: "x"}}
+
+	_, _, err := provider.resolve(context.Background())
+	require.ErrorContains(t, err, "issuer mismatch")
+}
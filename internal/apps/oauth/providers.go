@@ -26,6 +26,7 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/seatunnel/seatunnelX/internal/apps/auth"
 	"github.com/seatunnel/seatunnelX/internal/config"
 	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/github"
@@ -43,6 +44,7 @@ const (
 // OAuthProviderManager OAuth 提供商管理器
 type OAuthProviderManager struct {
 	providers map[OAuthProvider]*oauth2.Config
+	oidc      map[OAuthProvider]*oidcProvider
 }
 
 // 全局提供商管理器
@@ -52,6 +54,7 @@ var providerManager *OAuthProviderManager
 func InitOAuthProviders() {
 	providerManager = &OAuthProviderManager{
 		providers: make(map[OAuthProvider]*oauth2.Config),
+		oidc:      make(map[OAuthProvider]*oidcProvider),
 	}
 
 	// 初始化 GitHub OAuth
@@ -77,14 +80,28 @@ func InitOAuthProviders() {
 			Endpoint:     google.Endpoint,
 		}
 	}
+
+	// 初始化通用 OIDC 提供商（端点在首次登录时自动发现）
+	for _, oidcConfig := range config.Config.OAuthProviders.OIDC {
+		if !oidcConfig.Enabled || oidcConfig.ClientID == "" {
+			continue
+		}
+		name := OAuthProvider(strings.ToLower(strings.TrimSpace(oidcConfig.Name)))
+		providerManager.oidc[name] = &oidcProvider{cfg: oidcConfig}
+	}
 }
 
-// GetProvider 获取指定提供商的 OAuth 配置
-func GetProvider(provider OAuthProvider) (*oauth2.Config, error) {
+// GetProvider 获取指定提供商的 OAuth 配置，OIDC 提供商首次调用时执行端点发现
+func GetProvider(ctx context.Context, provider OAuthProvider) (*oauth2.Config, error) {
 	if providerManager == nil {
 		return nil, errors.New("OAuth providers not initialized")
 	}
 
+	if p, ok := providerManager.oidc[provider]; ok {
+		conf, _, err := p.resolve(ctx)
+		return conf, err
+	}
+
 	conf, ok := providerManager.providers[provider]
 	if !ok {
 		return nil, fmt.Errorf("OAuth provider '%s' not configured or disabled", provider)
@@ -99,6 +116,9 @@ func IsProviderEnabled(provider OAuthProvider) bool {
 		return false
 	}
 	_, ok := providerManager.providers[provider]
+	if !ok {
+		_, ok = providerManager.oidc[provider]
+	}
 	return ok
 }
 
@@ -108,10 +128,13 @@ func GetEnabledProviders() []OAuthProvider {
 		return nil
 	}
 
-	providers := make([]OAuthProvider, 0, len(providerManager.providers))
+	providers := make([]OAuthProvider, 0, len(providerManager.providers)+len(providerManager.oidc))
 	for p := range providerManager.providers {
 		providers = append(providers, p)
 	}
+	for p := range providerManager.oidc {
+		providers = append(providers, p)
+	}
 	return providers
 }
 
@@ -123,6 +146,8 @@ type OAuthUserInfo struct {
 	Name      string `json:"name"`
 	AvatarURL string `json:"avatar_url"`
 	Provider  string `json:"provider"`
+	// Role 由 OIDC 组映射得到的全局角色，为空时不修改已有用户角色，新用户使用 auth.ExternalDefaultRole
+	Role auth.Role `json:"role,omitempty"`
 }
 
 // FetchUserInfo 获取用户信息
@@ -132,9 +157,13 @@ func FetchUserInfo(ctx context.Context, provider OAuthProvider, token *oauth2.To
 		return fetchGitHubUserInfo(ctx, token)
 	case ProviderGoogle:
 		return fetchGoogleUserInfo(ctx, token)
-	default:
-		return nil, fmt.Errorf("unsupported provider: %s", provider)
 	}
+	if providerManager != nil {
+		if p, ok := providerManager.oidc[provider]; ok {
+			return p.fetchUserInfo(ctx, provider, token)
+		}
+	}
+	return nil, fmt.Errorf("unsupported provider: %s", provider)
 }
 
 // fetchGitHubUserInfo 获取 GitHub 用户信息
@@ -92,7 +92,7 @@ func writeOAuthPendingStates(ginSession sessions.Session, states map[string]int6
 
 // GetLoginURL godoc
 // @Tags oauth
-// @Param provider query string false "OAuth provider (github, google)"
+// @Param provider query string false "OAuth provider (github, google or a configured OIDC provider name)"
 // @Produce json
 // @Success 200 {object} GetLoginURLResponse
 // @Router /api/v1/oauth/login [get]
@@ -114,7 +114,7 @@ func GetLoginURL(c *gin.Context) {
 	}
 
 	// 获取提供商配置
-	oauthConfig, err := GetProvider(provider)
+	oauthConfig, err := GetProvider(c.Request.Context(), provider)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, GetLoginURLResponse{ErrorMsg: err.Error()})
 		return
@@ -193,7 +193,7 @@ func Callback(c *gin.Context) {
 	provider := OAuthProvider(parts[0])
 
 	// 获取提供商配置
-	oauthConfig, err := GetProvider(provider)
+	oauthConfig, err := GetProvider(c.Request.Context(), provider)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, CallbackResponse{ErrorMsg: err.Error()})
 		return
@@ -224,10 +224,13 @@ func Callback(c *gin.Context) {
 		return
 	}
 
-	// bind to session
-	ginSession.Set(UserIDKey, user.ID)
-	ginSession.Set(UserNameKey, user.Username)
-	if err := ginSession.Save(); err != nil {
+	if !user.IsActive {
+		c.JSON(http.StatusForbidden, CallbackResponse{ErrorMsg: BannedAccount})
+		return
+	}
+
+	// bind to session（服务端会话记录 + Cookie）
+	if _, err := auth.StartSession(c, db.DB(c.Request.Context()), user, auth.CurrentSessionPolicy()); err != nil {
 		c.JSON(http.StatusInternalServerError, CallbackResponse{ErrorMsg: err.Error()})
 		return
 	}
@@ -243,7 +246,7 @@ func findOrCreateOAuthUser(ctx context.Context, info *OAuthUserInfo) (*auth.User
 
 	// 先尝试通过 OAuth ID 查找
 	oauthID := fmt.Sprintf("%s:%s", info.Provider, info.ID)
-	tx := db.DB(ctx).Where("oauth_id = ?", oauthID).First(&user)
+	tx := db.DB(ctx).Where(&auth.User{OAuthID: oauthID}).First(&user)
 
 	if tx.Error == nil {
 		// 用户已存在，更新信息
@@ -254,12 +257,22 @@ func findOrCreateOAuthUser(ctx context.Context, info *OAuthUserInfo) (*auth.User
 		if strings.TrimSpace(info.Email) != "" {
 			user.Email = strings.TrimSpace(info.Email)
 		}
+		if info.Role != "" {
+			user.Role = string(info.Role)
+			user.IsAdmin = info.Role == auth.RoleAdmin
+		}
 		db.DB(ctx).Save(&user)
 		return &user, nil
 	}
 
 	username := buildOAuthUsername(ctx, info)
 
+	// 未映射到角色的新用户使用最小权限角色
+	role := info.Role
+	if role == "" {
+		role = auth.ExternalDefaultRole
+	}
+
 	// 创建新用户
 	user = auth.User{
 		Username:  username,
@@ -268,6 +281,8 @@ func findOrCreateOAuthUser(ctx context.Context, info *OAuthUserInfo) (*auth.User
 		OAuthID:   oauthID,
 		AvatarURL: info.AvatarURL,
 		IsActive:  true,
+		Role:      string(role),
+		IsAdmin:   role == auth.RoleAdmin,
 	}
 
 	if err := db.DB(ctx).Create(&user).Error; err != nil {
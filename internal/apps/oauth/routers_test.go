@@ -114,8 +114,16 @@ func TestFindOrCreateOAuthUserDoesNotLinkExistingUsername(t *testing.T) {
 	require.NotEqual(t, existing.ID, user.ID)
 	require.Equal(t, "admin_github_attacker-id", user.Username)
 	require.Equal(t, "github:attacker-id", user.OAuthID)
+	require.Equal(t, auth.RoleViewer, user.EffectiveRole())
 
 	var unchanged auth.User
 	require.NoError(t, db.DB(ctx).First(&unchanged, existing.ID).Error)
 	require.Empty(t, unchanged.OAuthID)
+
+	// 再次登录复用已关联的用户，并同步映射角色
+	info.Role = auth.RoleAdmin
+	again, err := findOrCreateOAuthUser(ctx, info)
+	require.NoError(t, err)
+	require.Equal(t, user.ID, again.ID)
+	require.True(t, again.IsAdmin)
 }
@@ -0,0 +1,612 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package plugin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/seatunnel/seatunnelX/internal/logger"
+)
+
+// pluginOperationRetention is how long finished bulk operations stay queryable.
+// pluginOperationRetention 是已完成的批量操作保留可查询的时长。
+const pluginOperationRetention = time.Hour
+
+// Bulk operation errors / 批量操作错误
+var (
+	ErrPluginOperationNotFound = errors.New("plugin operation not found / 插件操作未找到")
+	ErrPluginOperationRunning  = errors.New("plugin operation is still running / 插件操作仍在运行")
+	ErrNoFailedNodesToRetry    = errors.New("no failed nodes to retry / 没有可重试的失败节点")
+)
+
+// pluginOperationState holds a bulk operation together with the options needed to (re)run it.
+// pluginOperationState 保存批量操作及（重新）执行所需的选项。
+type pluginOperationState struct {
+	op              *PluginOperation
+	mirror          MirrorSource
+	maxParallel     int
+	allowUnverified bool
+	profileKeys     map[string][]string
+	running         bool
+}
+
+// StartPluginOperation validates a bulk request, registers the operation and runs it in the background.
+// Installing a plugin that is already installed reinstalls it, so a request can be safely repeated.
+// StartPluginOperation 校验批量请求、登记操作并在后台执行。
+// 安装已安装的插件会重新安装，因此请求可以安全地重复提交。
+func (s *Service) StartPluginOperation(ctx context.Context, clusterID uint, req *BulkPluginOperationRequest) (*PluginOperation, error) {
+	if req == nil || len(req.Plugins) == 0 {
+		return nil, fmt.Errorf("at least one plugin is required / 至少需要一个插件")
+	}
+	if req.Action != PluginOperationInstall && req.Action != PluginOperationUninstall {
+		return nil, fmt.Errorf("unsupported action %q / 不支持的操作: %q", req.Action, req.Action)
+	}
+	if s.clusterNodeGetter == nil || s.agentCommandSender == nil || s.hostInfoGetter == nil {
+		return nil, fmt.Errorf("agent integration not configured / Agent 集成未配置")
+	}
+	if err := s.ensureClusterNotInMaintenance(ctx, clusterID); err != nil {
+		return nil, err
+	}
+
+	nodes, err := s.clusterNodeGetter.GetClusterNodes(ctx, clusterID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cluster nodes: %w", err)
+	}
+	if len(nodes) == 0 {
+		return nil, fmt.Errorf("no nodes found in cluster / 集群中没有节点")
+	}
+
+	now := time.Now()
+	state := &pluginOperationState{
+		op: &PluginOperation{
+			ID:        uuid.New().String(),
+			ClusterID: clusterID,
+			Action:    req.Action,
+			Status:    PluginOperationStatusPending,
+			CreatedAt: now,
+			UpdatedAt: now,
+		},
+		mirror:          req.Mirror,
+		maxParallel:     req.MaxParallel,
+		allowUnverified: req.AllowUnverified,
+		profileKeys:     make(map[string][]string, len(req.Plugins)),
+	}
+
+	seen := make(map[string]struct{}, len(req.Plugins))
+	for _, item := range req.Plugins {
+		name := strings.TrimSpace(item.PluginName)
+		if name == "" {
+			return nil, fmt.Errorf("plugin_name is required / plugin_name 不能为空")
+		}
+		if _, dup := seen[name]; dup {
+			continue
+		}
+		seen[name] = struct{}{}
+
+		version := strings.TrimSpace(item.Version)
+		switch req.Action {
+		case PluginOperationInstall:
+			if version == "" {
+				return nil, fmt.Errorf("version is required to install %s / 安装 %s 需要指定版本", name, name)
+			}
+			if err := s.validateClusterPluginVersion(ctx, clusterID, version); err != nil {
+				return nil, err
+			}
+		case PluginOperationUninstall:
+			installed, err := s.repo.GetByClusterAndName(ctx, clusterID, name)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", name, err)
+			}
+			version = installed.Version
+		}
+
+		pluginProgress := PluginOperationPlugin{
+			PluginName: name,
+			Version:    version,
+			Status:     PluginOperationStatusPending,
+			Nodes:      make([]PluginOperationNode, 0, len(nodes)),
+		}
+		for _, node := range nodes {
+			pluginProgress.Nodes = append(pluginProgress.Nodes, PluginOperationNode{
+				NodeID:    node.NodeID,
+				HostID:    node.HostID,
+				Status:    PluginOperationStatusPending,
+				UpdatedAt: now,
+			})
+		}
+		state.op.Plugins = append(state.op.Plugins, pluginProgress)
+		state.profileKeys[name] = item.ProfileKeys
+	}
+
+	s.operationsMu.Lock()
+	s.pruneOperationsLocked(now)
+	state.running = true
+	s.operations[state.op.ID] = state
+	snapshot := copyPluginOperation(state.op)
+	s.operationsMu.Unlock()
+
+	go s.runPluginOperation(context.Background(), state, nodes)
+	return snapshot, nil
+}
+
+// GetPluginOperation returns a snapshot of one bulk operation of a cluster.
+// GetPluginOperation 返回集群某个批量操作的快照。
+func (s *Service) GetPluginOperation(clusterID uint, opID string) (*PluginOperation, error) {
+	s.operationsMu.RLock()
+	defer s.operationsMu.RUnlock()
+	state, ok := s.operations[opID]
+	if !ok || state.op.ClusterID != clusterID {
+		return nil, ErrPluginOperationNotFound
+	}
+	return copyPluginOperation(state.op), nil
+}
+
+// RetryPluginOperation re-runs the failed nodes of a finished operation, optionally filtered by plugin or node.
+// RetryPluginOperation 重新执行已结束操作中的失败节点，可按插件或节点过滤。
+func (s *Service) RetryPluginOperation(ctx context.Context, clusterID uint, opID string, req *RetryPluginOperationRequest) (*PluginOperation, error) {
+	if req == nil {
+		req = &RetryPluginOperationRequest{}
+	}
+	if s.clusterNodeGetter == nil || s.agentCommandSender == nil || s.hostInfoGetter == nil {
+		return nil, fmt.Errorf("agent integration not configured / Agent 集成未配置")
+	}
+	if err := s.ensureClusterNotInMaintenance(ctx, clusterID); err != nil {
+		return nil, err
+	}
+	nodes, err := s.clusterNodeGetter.GetClusterNodes(ctx, clusterID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cluster nodes: %w", err)
+	}
+
+	pluginFilter := make(map[string]struct{}, len(req.PluginNames))
+	for _, name := range req.PluginNames {
+		pluginFilter[strings.TrimSpace(name)] = struct{}{}
+	}
+	nodeFilter := make(map[uint]struct{}, len(req.NodeIDs))
+	for _, id := range req.NodeIDs {
+		nodeFilter[id] = struct{}{}
+	}
+
+	s.operationsMu.Lock()
+	state, ok := s.operations[opID]
+	if !ok || state.op.ClusterID != clusterID {
+		s.operationsMu.Unlock()
+		return nil, ErrPluginOperationNotFound
+	}
+	if state.running {
+		s.operationsMu.Unlock()
+		return nil, ErrPluginOperationRunning
+	}
+
+	now := time.Now()
+	retried := 0
+	for i := range state.op.Plugins {
+		plugin := &state.op.Plugins[i]
+		if _, ok := pluginFilter[plugin.PluginName]; len(pluginFilter) > 0 && !ok {
+			continue
+		}
+		for j := range plugin.Nodes {
+			node := &plugin.Nodes[j]
+			if node.Status != PluginOperationStatusFailed {
+				continue
+			}
+			if _, ok := nodeFilter[node.NodeID]; len(nodeFilter) > 0 && !ok {
+				continue
+			}
+			node.Status = PluginOperationStatusPending
+			node.Error = ""
+			node.Message = ""
+			node.UpdatedAt = now
+			retried++
+		}
+	}
+	if retried == 0 {
+		s.operationsMu.Unlock()
+		return nil, ErrNoFailedNodesToRetry
+	}
+	state.running = true
+	state.op.Status = PluginOperationStatusRunning
+	state.op.FinishedAt = nil
+	state.op.UpdatedAt = now
+	snapshot := copyPluginOperation(state.op)
+	s.operationsMu.Unlock()
+
+	go s.runPluginOperation(context.Background(), state, nodes)
+	return snapshot, nil
+}
+
+// runPluginOperation processes plugins one after another and fans each one out to its pending nodes in parallel.
+// runPluginOperation 逐个处理插件，并将每个插件并行分发到其待处理节点。
+func (s *Service) runPluginOperation(ctx context.Context, state *pluginOperationState, nodes []ClusterNodeInfo) {
+	op := state.op
+	nodeByID := make(map[uint]ClusterNodeInfo, len(nodes))
+	for _, node := range nodes {
+		nodeByID[node.NodeID] = node
+	}
+
+	s.updateOperation(state, func() { op.Status = PluginOperationStatusRunning })
+
+	for pluginIndex := range op.Plugins {
+		pending := s.pendingNodeIndexes(state, pluginIndex)
+		if len(pending) == 0 {
+			continue
+		}
+
+		var (
+			name        string
+			version     string
+			profileKeys []string
+		)
+		s.updateOperation(state, func() {
+			plugin := &op.Plugins[pluginIndex]
+			plugin.Status = PluginOperationStatusRunning
+			plugin.Error = ""
+			name, version = plugin.PluginName, plugin.Version
+			profileKeys = state.profileKeys[name]
+		})
+
+		var (
+			artifactID string
+			pluginInfo *Plugin
+			deps       []PluginDependency
+			prepErr    error
+		)
+		if op.Action == PluginOperationInstall {
+			pluginInfo, deps, prepErr = s.preparePluginOperationInstall(ctx, state, pluginIndex, name, version, profileKeys)
+			if pluginInfo != nil {
+				artifactID = pluginInfo.ArtifactID
+			}
+		}
+		if artifactID == "" {
+			artifactID = getArtifactID(name)
+		}
+
+		if prepErr != nil {
+			s.updateOperation(state, func() {
+				plugin := &op.Plugins[pluginIndex]
+				plugin.Error = prepErr.Error()
+				for _, nodeIndex := range pending {
+					node := &plugin.Nodes[nodeIndex]
+					node.Attempts++
+					node.Status = PluginOperationStatusFailed
+					node.Error = prepErr.Error()
+					node.UpdatedAt = time.Now()
+				}
+			})
+		} else {
+			s.fanOutPluginOperation(ctx, state, pluginIndex, pending, nodeByID, func(nodeCtx context.Context, node ClusterNodeInfo, agentID string) (string, error) {
+				if op.Action == PluginOperationInstall {
+					if err := s.transferPluginToAgent(nodeCtx, agentID, artifactID, name, version, node.InstallDir, deps, state.allowUnverified); err != nil {
+						return "", err
+					}
+					return "Plugin installed / 插件已安装", nil
+				}
+				return s.uninstallPluginFromNode(nodeCtx, agentID, name, version, node.InstallDir)
+			})
+		}
+
+		s.finishOperationPlugin(ctx, state, pluginIndex, pluginInfo, artifactID, deps)
+	}
+
+	var finalStatus PluginOperationStatus
+	s.updateOperation(state, func() {
+		now := time.Now()
+		op.Status = aggregateOperationStatus(op.Plugins)
+		op.FinishedAt = &now
+		state.running = false
+		finalStatus = op.Status
+	})
+	logger.InfoF(ctx, "[Plugin] Bulk %s operation %s on cluster %d finished: %s", op.Action, op.ID, op.ClusterID, finalStatus)
+}
+
+// preparePluginOperationInstall resolves plugin metadata and makes sure its files are on the Control Plane.
+// preparePluginOperationInstall 解析插件元数据并确保其文件已在 Control Plane 上。
+func (s *Service) preparePluginOperationInstall(ctx context.Context, state *pluginOperationState, pluginIndex int, name, version string, profileKeys []string) (*Plugin, []PluginDependency, error) {
+	pluginInfo, err := s.GetPluginInfo(ctx, name, version)
+	if err != nil {
+		return nil, nil, err
+	}
+	deps, err := s.GetPluginDependenciesForVersionAndProfiles(ctx, name, version, profileKeys)
+	if err != nil {
+		return pluginInfo, nil, fmt.Errorf("failed to resolve plugin dependencies: %w", err)
+	}
+	pluginInfo.Dependencies = deps
+
+	callback := func(p *DownloadProgress) {
+		s.updateOperation(state, func() {
+			state.op.Plugins[pluginIndex].Message = p.CurrentStep
+		})
+	}
+	if err := s.ensurePluginArtifactsDownloaded(ctx, pluginInfo, state.mirror, callback); err != nil {
+		return pluginInfo, deps, err
+	}
+	return pluginInfo, deps, nil
+}
+
+// fanOutPluginOperation runs fn for every pending node, bounded by the operation's parallelism.
+// fanOutPluginOperation 对每个待处理节点执行 fn，并发数受操作并发度限制。
+func (s *Service) fanOutPluginOperation(ctx context.Context, state *pluginOperationState, pluginIndex int, pending []int, nodeByID map[uint]ClusterNodeInfo, fn func(ctx context.Context, node ClusterNodeInfo, agentID string) (string, error)) {
+	parallel := state.maxParallel
+	if parallel <= 0 || parallel > len(pending) {
+		parallel = len(pending)
+	}
+	sem := make(chan struct{}, parallel)
+	var wg sync.WaitGroup
+
+	for _, nodeIndex := range pending {
+		var nodeID uint
+		s.updateOperation(state, func() {
+			node := &state.op.Plugins[pluginIndex].Nodes[nodeIndex]
+			node.Attempts++
+			node.Status = PluginOperationStatusRunning
+			node.UpdatedAt = time.Now()
+			nodeID = node.NodeID
+		})
+
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(nodeIndex int, nodeID uint) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			message, err := s.runPluginOperationNode(ctx, nodeByID, nodeID, state, pluginIndex, nodeIndex, fn)
+			s.updateOperation(state, func() {
+				node := &state.op.Plugins[pluginIndex].Nodes[nodeIndex]
+				node.UpdatedAt = time.Now()
+				if err != nil {
+					node.Status = PluginOperationStatusFailed
+					node.Error = err.Error()
+					return
+				}
+				node.Status = PluginOperationStatusSucceeded
+				node.Message = message
+			})
+		}(nodeIndex, nodeID)
+	}
+	wg.Wait()
+}
+
+func (s *Service) runPluginOperationNode(ctx context.Context, nodeByID map[uint]ClusterNodeInfo, nodeID uint, state *pluginOperationState, pluginIndex, nodeIndex int, fn func(ctx context.Context, node ClusterNodeInfo, agentID string) (string, error)) (string, error) {
+	node, ok := nodeByID[nodeID]
+	if !ok {
+		return "", fmt.Errorf("node %d no longer belongs to the cluster / 节点 %d 已不在集群中", nodeID, nodeID)
+	}
+	agentID, err := s.hostInfoGetter.GetHostAgentID(ctx, node.HostID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get agent ID for host %d: %w", node.HostID, err)
+	}
+	if agentID == "" {
+		return "", fmt.Errorf("agent not installed on host %d / 主机 %d 未安装 Agent", node.HostID, node.HostID)
+	}
+	s.updateOperation(state, func() {
+		state.op.Plugins[pluginIndex].Nodes[nodeIndex].AgentID = agentID
+	})
+	return fn(ctx, node, agentID)
+}
+
+// uninstallPluginFromNode asks one agent to remove the plugin files from its install dir.
+// uninstallPluginFromNode 请求单个 Agent 从安装目录删除插件文件。
+func (s *Service) uninstallPluginFromNode(ctx context.Context, agentID, pluginName, version, installDir string) (string, error) {
+	params := map[string]string{
+		"plugin_name":         pluginName,
+		"version":             version,
+		"install_path":        installDir,
+		"remove_dependencies": "true",
+	}
+	success, message, err := s.agentCommandSender.SendCommand(ctx, agentID, "uninstall_plugin", params)
+	if err != nil {
+		return "", err
+	}
+	if !success {
+		return "", fmt.Errorf("agent returned failure: %s / Agent 返回失败: %s", message, message)
+	}
+	return message, nil
+}
+
+// finishOperationPlugin summarizes node results and updates the installed-plugin record once every node succeeded.
+// finishOperationPlugin 汇总节点结果，并在所有节点成功后更新已安装插件记录。
+func (s *Service) finishOperationPlugin(ctx context.Context, state *pluginOperationState, pluginIndex int, pluginInfo *Plugin, artifactID string, deps []PluginDependency) {
+	var (
+		allSucceeded bool
+		name         string
+		version      string
+	)
+	s.updateOperation(state, func() {
+		plugin := &state.op.Plugins[pluginIndex]
+		plugin.Status, plugin.Progress = aggregateNodeStatus(plugin.Nodes)
+		allSucceeded = plugin.Status == PluginOperationStatusSucceeded
+		name, version = plugin.PluginName, plugin.Version
+	})
+	if !allSucceeded {
+		return
+	}
+
+	var err error
+	switch state.op.Action {
+	case PluginOperationInstall:
+		err = s.saveClusterInstalledPlugin(ctx, state.op.ClusterID, name, pluginInfo, artifactID, version, deps)
+	case PluginOperationUninstall:
+		if err = s.repo.DeleteByClusterAndName(ctx, state.op.ClusterID, name); errors.Is(err, ErrPluginNotFound) {
+			err = nil
+		}
+	}
+	s.updateOperation(state, func() {
+		plugin := &state.op.Plugins[pluginIndex]
+		if err != nil {
+			plugin.Status = PluginOperationStatusFailed
+			plugin.Error = err.Error()
+			return
+		}
+		plugin.Message = ""
+	})
+}
+
+// saveClusterInstalledPlugin creates or refreshes the installed-plugin record of a cluster.
+// saveClusterInstalledPlugin 创建或刷新集群的已安装插件记录。
+func (s *Service) saveClusterInstalledPlugin(ctx context.Context, clusterID uint, name string, pluginInfo *Plugin, artifactID, version string, deps []PluginDependency) error {
+	category := PluginCategoryConnector
+	if pluginInfo != nil && pluginInfo.Category != "" {
+		category = pluginInfo.Category
+	}
+	now := time.Now()
+	installPath := fmt.Sprintf("connectors/%s-%s.jar", artifactID, version)
+
+	existing, err := s.repo.GetByClusterAndName(ctx, clusterID, name)
+	if err == nil && existing != nil {
+		existing.ArtifactID = artifactID
+		existing.Category = category
+		existing.Version = version
+		existing.Status = PluginStatusInstalled
+		existing.InstallPath = installPath
+		existing.Dependencies = deps
+		existing.UpdatedAt = now
+		return s.repo.Update(ctx, existing)
+	}
+	if err != nil && !errors.Is(err, ErrPluginNotFound) {
+		return err
+	}
+	return s.repo.Create(ctx, &InstalledPlugin{
+		ClusterID:    clusterID,
+		PluginName:   name,
+		ArtifactID:   artifactID,
+		Category:     category,
+		Version:      version,
+		Status:       PluginStatusInstalled,
+		InstallPath:  installPath,
+		InstalledAt:  now,
+		UpdatedAt:    now,
+		Dependencies: deps,
+	})
+}
+
+func (s *Service) pendingNodeIndexes(state *pluginOperationState, pluginIndex int) []int {
+	s.operationsMu.RLock()
+	defer s.operationsMu.RUnlock()
+	indexes := make([]int, 0)
+	for i, node := range state.op.Plugins[pluginIndex].Nodes {
+		if node.Status == PluginOperationStatusPending {
+			indexes = append(indexes, i)
+		}
+	}
+	return indexes
+}
+
+// updateOperation applies fn under the operations lock and refreshes the aggregated progress.
+// updateOperation 在操作锁内执行 fn 并刷新汇总进度。
+func (s *Service) updateOperation(state *pluginOperationState, fn func()) {
+	s.operationsMu.Lock()
+	defer s.operationsMu.Unlock()
+	fn()
+	total := 0
+	for i := range state.op.Plugins {
+		plugin := &state.op.Plugins[i]
+		if plugin.Status == PluginOperationStatusRunning {
+			_, plugin.Progress = aggregateNodeStatus(plugin.Nodes)
+		}
+		total += plugin.Progress
+	}
+	if len(state.op.Plugins) > 0 {
+		state.op.Progress = total / len(state.op.Plugins)
+	}
+	state.op.UpdatedAt = time.Now()
+}
+
+// pruneOperationsLocked drops finished operations older than the retention window.
+// pruneOperationsLocked 删除超过保留时长的已完成操作。
+func (s *Service) pruneOperationsLocked(now time.Time) {
+	for id, state := range s.operations {
+		if state.running || state.op.FinishedAt == nil {
+			continue
+		}
+		if now.Sub(*state.op.FinishedAt) > pluginOperationRetention {
+			delete(s.operations, id)
+		}
+	}
+}
+
+// aggregateNodeStatus derives a plugin's status and progress from its nodes.
+// aggregateNodeStatus 根据节点状态推导插件的状态与进度。
+func aggregateNodeStatus(nodes []PluginOperationNode) (PluginOperationStatus, int) {
+	if len(nodes) == 0 {
+		return PluginOperationStatusSucceeded, 100
+	}
+	succeeded, failed, active := 0, 0, 0
+	for _, node := range nodes {
+		switch node.Status {
+		case PluginOperationStatusSucceeded:
+			succeeded++
+		case PluginOperationStatusFailed:
+			failed++
+		default:
+			active++
+		}
+	}
+	progress := (succeeded + failed) * 100 / len(nodes)
+	switch {
+	case active > 0:
+		return PluginOperationStatusRunning, progress
+	case failed == 0:
+		return PluginOperationStatusSucceeded, progress
+	case succeeded == 0:
+		return PluginOperationStatusFailed, progress
+	default:
+		return PluginOperationStatusPartialFailed, progress
+	}
+}
+
+// aggregateOperationStatus derives the overall status of a finished operation.
+// aggregateOperationStatus 推导已结束操作的整体状态。
+func aggregateOperationStatus(plugins []PluginOperationPlugin) PluginOperationStatus {
+	succeeded, failed := 0, 0
+	for _, plugin := range plugins {
+		switch plugin.Status {
+		case PluginOperationStatusSucceeded:
+			succeeded++
+		case PluginOperationStatusFailed:
+			failed++
+		}
+	}
+	switch {
+	case succeeded == len(plugins):
+		return PluginOperationStatusSucceeded
+	case failed == len(plugins):
+		return PluginOperationStatusFailed
+	default:
+		return PluginOperationStatusPartialFailed
+	}
+}
+
+func copyPluginOperation(op *PluginOperation) *PluginOperation {
+	clone := *op
+	clone.Plugins = make([]PluginOperationPlugin, len(op.Plugins))
+	for i, plugin := range op.Plugins {
+		clone.Plugins[i] = plugin
+		clone.Plugins[i].Nodes = append([]PluginOperationNode(nil), plugin.Nodes...)
+	}
+	if op.FinishedAt != nil {
+		finishedAt := *op.FinishedAt
+		clone.FinishedAt = &finishedAt
+	}
+	return &clone
+}
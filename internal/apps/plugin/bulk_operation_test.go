@@ -0,0 +1,218 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package plugin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+type stubClusterNodeGetter struct {
+	nodes []ClusterNodeInfo
+}
+
+func (s *stubClusterNodeGetter) GetClusterNodes(ctx context.Context, clusterID uint) ([]ClusterNodeInfo, error) {
+	return s.nodes, nil
+}
+
+type stubHostInfoGetter struct{}
+
+func (s *stubHostInfoGetter) GetHostAgentID(ctx context.Context, hostID uint) (string, error) {
+	return fmt.Sprintf("agent-%d", hostID), nil
+}
+
+// stubAgentCommandSender fails the first failuresLeft commands sent to failingAgent.
+type stubAgentCommandSender struct {
+	mu           sync.Mutex
+	failingAgent string
+	failuresLeft int
+	commands     []string
+}
+
+func (s *stubAgentCommandSender) SendCommand(ctx context.Context, agentID string, commandType string, params map[string]string) (bool, string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.commands = append(s.commands, agentID+":"+commandType+":"+params["plugin_name"])
+	if agentID == s.failingAgent && s.failuresLeft > 0 {
+		s.failuresLeft--
+		return false, "", errors.New("connection reset")
+	}
+	return true, "ok", nil
+}
+
+func waitPluginOperation(t *testing.T, service *Service, clusterID uint, opID string) *PluginOperation {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		op, err := service.GetPluginOperation(clusterID, opID)
+		if err != nil {
+			t.Fatalf("GetPluginOperation returned error: %v", err)
+		}
+		if op.FinishedAt != nil {
+			return op
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("operation %s did not finish in time", opID)
+	return nil
+}
+
+func TestBulkUninstallRetriesFailedNode(t *testing.T) {
+	service, repo := newTestPluginService(t)
+	ctx := context.Background()
+
+	for _, name := range []string{"jdbc", "kafka"} {
+		if err := repo.Create(ctx, &InstalledPlugin{
+			ClusterID:   7,
+			PluginName:  name,
+			ArtifactID:  "connector-" + name,
+			Category:    PluginCategoryConnector,
+			Version:     "2.3.12",
+			Status:      PluginStatusInstalled,
+			InstalledAt: time.Now(),
+		}); err != nil {
+			t.Fatalf("failed to create installed plugin: %v", err)
+		}
+	}
+
+	sender := &stubAgentCommandSender{failingAgent: "agent-2", failuresLeft: 1}
+	service.SetAgentCommandSender(sender)
+	service.SetHostInfoGetter(&stubHostInfoGetter{})
+	service.SetClusterNodeGetter(&stubClusterNodeGetter{nodes: []ClusterNodeInfo{
+		{NodeID: 11, HostID: 1, InstallDir: "/opt/seatunnel"},
+		{NodeID: 12, HostID: 2, InstallDir: "/opt/seatunnel"},
+	}})
+
+	started, err := service.StartPluginOperation(ctx, 7, &BulkPluginOperationRequest{
+		Action:  PluginOperationUninstall,
+		Plugins: []BulkPluginItem{{PluginName: "jdbc"}, {PluginName: "kafka"}},
+	})
+	if err != nil {
+		t.Fatalf("StartPluginOperation returned error: %v", err)
+	}
+
+	op := waitPluginOperation(t, service, 7, started.ID)
+	if op.Status != PluginOperationStatusPartialFailed {
+		t.Fatalf("expected partial_failed operation, got %s", op.Status)
+	}
+	failedPlugin := op.Plugins[0]
+	if failedPlugin.Status != PluginOperationStatusPartialFailed || failedPlugin.Nodes[1].Status != PluginOperationStatusFailed {
+		t.Fatalf("expected jdbc to fail on node 12, got %+v", failedPlugin)
+	}
+	if op.Plugins[1].Status != PluginOperationStatusSucceeded {
+		t.Fatalf("expected kafka to succeed, got %+v", op.Plugins[1])
+	}
+	if _, err := repo.GetByClusterAndName(ctx, 7, "jdbc"); err != nil {
+		t.Fatalf("jdbc record should be kept while a node failed: %v", err)
+	}
+	if _, err := repo.GetByClusterAndName(ctx, 7, "kafka"); !errors.Is(err, ErrPluginNotFound) {
+		t.Fatalf("kafka record should be deleted, got %v", err)
+	}
+
+	if _, err := service.RetryPluginOperation(ctx, 7, started.ID, &RetryPluginOperationRequest{NodeIDs: []uint{12}}); err != nil {
+		t.Fatalf("RetryPluginOperation returned error: %v", err)
+	}
+	op = waitPluginOperation(t, service, 7, started.ID)
+	if op.Status != PluginOperationStatusSucceeded || op.Progress != 100 {
+		t.Fatalf("expected succeeded operation after retry, got %s (%d%%)", op.Status, op.Progress)
+	}
+	if attempts := op.Plugins[0].Nodes[1].Attempts; attempts != 2 {
+		t.Fatalf("expected 2 attempts on retried node, got %d", attempts)
+	}
+	if attempts := op.Plugins[0].Nodes[0].Attempts; attempts != 1 {
+		t.Fatalf("succeeded node should not be retried, got %d attempts", attempts)
+	}
+	if _, err := repo.GetByClusterAndName(ctx, 7, "jdbc"); !errors.Is(err, ErrPluginNotFound) {
+		t.Fatalf("jdbc record should be deleted after retry, got %v", err)
+	}
+
+	if _, err := service.RetryPluginOperation(ctx, 7, started.ID, nil); !errors.Is(err, ErrNoFailedNodesToRetry) {
+		t.Fatalf("expected ErrNoFailedNodesToRetry, got %v", err)
+	}
+	if _, err := service.GetPluginOperation(8, started.ID); !errors.Is(err, ErrPluginOperationNotFound) {
+		t.Fatalf("operation must not be visible from another cluster, got %v", err)
+	}
+}
+
+func TestStartPluginOperationRejectsInvalidRequests(t *testing.T) {
+	service, _ := newTestPluginService(t)
+	service.SetAgentCommandSender(&stubAgentCommandSender{})
+	service.SetHostInfoGetter(&stubHostInfoGetter{})
+	service.SetClusterNodeGetter(&stubClusterNodeGetter{nodes: []ClusterNodeInfo{{NodeID: 1, HostID: 1}}})
+	ctx := context.Background()
+
+	if _, err := service.StartPluginOperation(ctx, 1, &BulkPluginOperationRequest{Action: "upgrade", Plugins: []BulkPluginItem{{PluginName: "jdbc"}}}); err == nil {
+		t.Fatal("expected unsupported action error")
+	}
+	if _, err := service.StartPluginOperation(ctx, 1, &BulkPluginOperationRequest{Action: PluginOperationInstall, Plugins: []BulkPluginItem{{PluginName: "jdbc"}}}); err == nil {
+		t.Fatal("expected missing version error")
+	}
+	if _, err := service.StartPluginOperation(ctx, 1, &BulkPluginOperationRequest{Action: PluginOperationUninstall, Plugins: []BulkPluginItem{{PluginName: "jdbc"}}}); !errors.Is(err, ErrPluginNotFound) {
+		t.Fatalf("expected ErrPluginNotFound for uninstalling a missing plugin, got %v", err)
+	}
+}
+
+func TestAggregateNodeStatus(t *testing.T) {
+	nodes := []PluginOperationNode{
+		{Status: PluginOperationStatusSucceeded},
+		{Status: PluginOperationStatusFailed},
+		{Status: PluginOperationStatusRunning},
+		{Status: PluginOperationStatusPending},
+	}
+	if status, progress := aggregateNodeStatus(nodes); status != PluginOperationStatusRunning || progress != 50 {
+		t.Fatalf("expected running/50, got %s/%d", status, progress)
+	}
+	if status, _ := aggregateNodeStatus(nodes[:2]); status != PluginOperationStatusPartialFailed {
+		t.Fatalf("expected partial_failed, got %s", status)
+	}
+	if status, _ := aggregateNodeStatus(nodes[1:2]); status != PluginOperationStatusFailed {
+		t.Fatalf("expected failed, got %s", status)
+	}
+}
+
+type stubMaintenanceChecker struct {
+	hosts []string
+}
+
+func (c *stubMaintenanceChecker) MaintenanceHostNames(ctx context.Context, clusterID uint) ([]string, error) {
+	return c.hosts, nil
+}
+
+func TestPluginOperationsRejectedDuringHostMaintenance(t *testing.T) {
+	service, _ := newTestPluginService(t)
+	service.SetAgentCommandSender(&stubAgentCommandSender{})
+	service.SetHostInfoGetter(&stubHostInfoGetter{})
+	service.SetClusterNodeGetter(&stubClusterNodeGetter{nodes: []ClusterNodeInfo{{NodeID: 1, HostID: 1}}})
+	service.SetMaintenanceChecker(&stubMaintenanceChecker{hosts: []string{"node-1"}})
+	ctx := context.Background()
+
+	req := &BulkPluginOperationRequest{Action: PluginOperationInstall, Plugins: []BulkPluginItem{{PluginName: "jdbc", Version: "2.3.12"}}}
+	if _, err := service.StartPluginOperation(ctx, 1, req); !errors.Is(err, ErrHostUnderMaintenance) {
+		t.Fatalf("expected ErrHostUnderMaintenance for a bulk operation, got %v", err)
+	}
+	if _, err := service.InstallPluginToCluster(ctx, 1, &InstallPluginRequest{PluginName: "jdbc", Version: "2.3.12"}); !errors.Is(err, ErrHostUnderMaintenance) {
+		t.Fatalf("expected ErrHostUnderMaintenance for install, got %v", err)
+	}
+	if err := service.UninstallPlugin(ctx, 1, "jdbc"); !errors.Is(err, ErrHostUnderMaintenance) {
+		t.Fatalf("expected ErrHostUnderMaintenance for uninstall, got %v", err)
+	}
+}
@@ -0,0 +1,241 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package plugin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/seatunnel/seatunnelX/internal/seatunnel"
+)
+
+// customPluginGroupID is the group assigned to custom plugins uploaded without one.
+// customPluginGroupID 是未指定分组的自定义插件使用的默认分组。
+const customPluginGroupID = "custom"
+
+var customPluginNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,99}$`)
+
+// Custom plugin errors / 自定义插件错误
+var (
+	ErrInvalidCustomPlugin  = errors.New("invalid custom plugin / 无效的自定义插件")
+	ErrCustomPluginConflict = errors.New("plugin name is already used by an official connector / 插件名称与官方连接器冲突")
+)
+
+// ListCustomPlugins returns uploaded custom plugins, optionally limited to one SeaTunnel version.
+// ListCustomPlugins 返回已上传的自定义插件，可按 SeaTunnel 版本过滤。
+func (s *Service) ListCustomPlugins(ctx context.Context, version string) ([]CustomPlugin, error) {
+	return s.repo.ListCustomPlugins(ctx, strings.TrimSpace(version))
+}
+
+// UploadCustomPlugin stores a private connector jar so it is listed and installed like an official one.
+// The jar is saved at the regular connector path and marked trusted, since there is no upstream checksum.
+// UploadCustomPlugin 保存私有连接器 jar，使其可像官方连接器一样在市场中展示并安装。
+// jar 保存在常规连接器路径，并因无上游校验和而标记为受信任。
+func (s *Service) UploadCustomPlugin(ctx context.Context, req *UploadCustomPluginRequest, file *multipart.FileHeader) (*CustomPlugin, error) {
+	if file == nil {
+		return nil, fmt.Errorf("%w: file is required / 必须上传文件", ErrInvalidCustomPlugin)
+	}
+	src, err := file.Open()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open uploaded jar: %w", err)
+	}
+	defer src.Close()
+	return s.saveCustomPlugin(ctx, req, file.Filename, src)
+}
+
+// validateCustomPlugin checks the upload request and returns the normalized name, category and SeaTunnel version.
+// validateCustomPlugin 校验上传请求，并返回规范化后的名称、分类与 SeaTunnel 版本。
+func (s *Service) validateCustomPlugin(ctx context.Context, req *UploadCustomPluginRequest, fileName string) (string, PluginCategory, string, error) {
+	if req == nil {
+		return "", "", "", fmt.Errorf("%w: request is required / 请求不能为空", ErrInvalidCustomPlugin)
+	}
+	if !strings.HasSuffix(strings.ToLower(fileName), ".jar") {
+		return "", "", "", fmt.Errorf("%w: only .jar files are supported / 仅支持上传 .jar 文件", ErrInvalidCustomPlugin)
+	}
+	name := strings.ToLower(strings.TrimSpace(req.Name))
+	if !customPluginNamePattern.MatchString(name) {
+		return "", "", "", fmt.Errorf("%w: name must contain lowercase letters, digits and dashes / 名称只能包含小写字母、数字和横杠", ErrInvalidCustomPlugin)
+	}
+	category := req.Category
+	switch category {
+	case "":
+		category = PluginCategoryConnector
+	case PluginCategoryConnector, PluginCategorySource, PluginCategorySink, PluginCategoryTransform:
+	default:
+		return "", "", "", fmt.Errorf("%w: unknown category %q / 未知分类", ErrInvalidCustomPlugin, category)
+	}
+	version := firstNonEmpty(req.SeatunnelVersion, seatunnel.DefaultVersion())
+	if s.isOfficialPlugin(ctx, version, name) {
+		return "", "", "", ErrCustomPluginConflict
+	}
+	return name, category, version, nil
+}
+
+// saveCustomPlugin stores the jar read from src and records the custom plugin.
+// saveCustomPlugin 保存从 src 读取的 jar 并记录自定义插件。
+func (s *Service) saveCustomPlugin(ctx context.Context, req *UploadCustomPluginRequest, fileName string, src io.Reader) (*CustomPlugin, error) {
+	name, category, version, err := s.validateCustomPlugin(ctx, req, fileName)
+	if err != nil {
+		return nil, err
+	}
+
+	// Name-based lookups (download state, install, uninstall) resolve the jar through this artifact ID.
+	// 基于名称的查找（下载状态、安装、卸载）都通过该 artifact ID 定位 jar。
+	artifactID := getArtifactIDForPath(name)
+	jarPath := s.downloader.GetConnectorPath(artifactID, version)
+	fileSize, err := storeCustomPluginJar(src, jarPath)
+	if err != nil {
+		return nil, err
+	}
+	if err := markArtifactTrusted(jarPath); err != nil {
+		removeArtifactFiles(jarPath)
+		return nil, fmt.Errorf("failed to record verification: %w", err)
+	}
+	verification, err := ReadArtifactVerification(jarPath)
+	if err != nil || verification == nil {
+		removeArtifactFiles(jarPath)
+		return nil, fmt.Errorf("failed to read verification record: %v", err)
+	}
+
+	displayName := firstNonEmpty(req.DisplayName, strings.Title(strings.ReplaceAll(name, "-", " ")))
+	record := &CustomPlugin{
+		Name:             name,
+		SeatunnelVersion: version,
+		DisplayName:      displayName,
+		ArtifactID:       artifactID,
+		GroupID:          firstNonEmpty(req.GroupID, customPluginGroupID),
+		Category:         category,
+		Description:      firstNonEmpty(req.Description, fmt.Sprintf("Custom %s connector / 自定义 %s 连接器", displayName, displayName)),
+		DocURL:           strings.TrimSpace(req.DocURL),
+		OriginalFileName: filepath.Base(fileName),
+		FileSize:         fileSize,
+		SHA256:           verification.SHA256,
+		UploadedBy:       req.UploadedBy,
+	}
+	if err := s.repo.UpsertCustomPlugin(ctx, record); err != nil {
+		removeArtifactFiles(jarPath)
+		return nil, err
+	}
+	plugin := customPluginToPlugin(record)
+	if err := s.downloader.writePluginMetadata(&plugin, nil); err != nil {
+		return nil, fmt.Errorf("failed to write plugin metadata: %w", err)
+	}
+	s.downloader.mirrorVersionToStore(ctx, version)
+
+	return s.repo.GetCustomPluginByName(ctx, version, name)
+}
+
+// DeleteCustomPlugin removes a custom plugin and its stored jar. Clusters that already installed it keep their copy.
+// DeleteCustomPlugin 删除自定义插件及其 jar，已安装该插件的集群保留各自的副本。
+func (s *Service) DeleteCustomPlugin(ctx context.Context, id uint) (*CustomPlugin, error) {
+	record, err := s.repo.GetCustomPlugin(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.DeleteCustomPlugin(ctx, id); err != nil {
+		return nil, err
+	}
+	jarPath := s.downloader.GetConnectorPath(record.ArtifactID, record.SeatunnelVersion)
+	metadataPath := s.downloader.getMetadataPath(record.Name, record.SeatunnelVersion)
+	removeArtifactFiles(jarPath)
+	_ = os.Remove(metadataPath)
+	s.downloader.removeFromStore(ctx, jarPath, verificationPath(jarPath), metadataPath)
+	return record, nil
+}
+
+// withCustomPlugins appends the custom plugins of a version to the official plugin list.
+// withCustomPlugins 将某版本的自定义插件追加到官方插件列表。
+func (s *Service) withCustomPlugins(ctx context.Context, version string, plugins []Plugin) []Plugin {
+	if s.repo == nil {
+		return plugins
+	}
+	custom, err := s.repo.ListCustomPlugins(ctx, version)
+	if err != nil || len(custom) == 0 {
+		return plugins
+	}
+	official := make(map[string]struct{}, len(plugins))
+	for _, plugin := range plugins {
+		official[plugin.Name] = struct{}{}
+	}
+	for i := range custom {
+		if _, exists := official[custom[i].Name]; exists {
+			continue
+		}
+		plugins = append(plugins, customPluginToPlugin(&custom[i]))
+	}
+	return plugins
+}
+
+// isOfficialPlugin reports whether the persisted catalog or the offline index already has the plugin.
+// isOfficialPlugin 判断已持久化目录或离线索引中是否已有该插件。
+func (s *Service) isOfficialPlugin(ctx context.Context, version, name string) bool {
+	catalog, _, _ := s.loadPluginsFromCatalog(ctx, version)
+	for _, plugin := range append(catalog, s.pluginsFromIndex(version)...) {
+		if plugin.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func customPluginToPlugin(record *CustomPlugin) Plugin {
+	return Plugin{
+		Name:        record.Name,
+		DisplayName: record.DisplayName,
+		Category:    record.Category,
+		Version:     record.SeatunnelVersion,
+		Description: record.Description,
+		GroupID:     record.GroupID,
+		ArtifactID:  record.ArtifactID,
+		DocURL:      record.DocURL,
+		Custom:      true,
+	}
+}
+
+// storeCustomPluginJar writes the uploaded jar to targetPath through a temporary file.
+// storeCustomPluginJar 通过临时文件将上传的 jar 写入 targetPath。
+func storeCustomPluginJar(src io.Reader, targetPath string) (int64, error) {
+	if err := os.MkdirAll(filepath.Dir(targetPath), 0755); err != nil {
+		return 0, fmt.Errorf("failed to create connector directory: %w", err)
+	}
+	tmpPath := targetPath + ".upload"
+	dst, err := os.Create(tmpPath)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create uploaded jar: %w", err)
+	}
+	written, err := io.Copy(dst, src)
+	if closeErr := dst.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(tmpPath)
+		return 0, fmt.Errorf("failed to save uploaded jar: %w", err)
+	}
+	if err := os.Rename(tmpPath, targetPath); err != nil {
+		_ = os.Remove(tmpPath)
+		return 0, fmt.Errorf("failed to save uploaded jar: %w", err)
+	}
+	return written, nil
+}
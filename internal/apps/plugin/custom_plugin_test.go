@@ -0,0 +1,115 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/seatunnel/seatunnelX/internal/pkg/chunkupload"
+)
+
+func TestUploadCustomPluginAppearsInMarketplace(t *testing.T) {
+	service, _ := newTestPluginServiceWithDownloader(t, t.TempDir())
+	ctx := context.Background()
+
+	file := createDependencyUploadFileHeader(t, "file", "acme-sink-1.0.0.jar", []byte("custom connector"))
+	record, err := service.UploadCustomPlugin(ctx, &UploadCustomPluginRequest{Name: "acme-sink", SeatunnelVersion: "2.3.12"}, file)
+	if err != nil {
+		t.Fatalf("UploadCustomPlugin returned error: %v", err)
+	}
+	if record.ID == 0 || record.ArtifactID != "connector-acme-sink" || record.GroupID != customPluginGroupID || record.SHA256 == "" {
+		t.Fatalf("unexpected custom plugin record: %+v", record)
+	}
+	if !service.downloader.IsConnectorDownloaded("acme-sink", "2.3.12") {
+		t.Fatalf("expected custom jar at the connector path")
+	}
+	verification, err := ReadArtifactVerification(service.downloader.GetConnectorPath(record.ArtifactID, "2.3.12"))
+	if err != nil || !verification.Verified() {
+		t.Fatalf("expected trusted verification record, got %+v, err=%v", verification, err)
+	}
+
+	info, err := service.GetPluginInfo(ctx, "acme-sink", "2.3.12")
+	if err != nil {
+		t.Fatalf("GetPluginInfo returned error: %v", err)
+	}
+	if !info.Custom || info.ArtifactID != record.ArtifactID {
+		t.Fatalf("expected custom plugin info, got %+v", info)
+	}
+
+	if _, err := service.UploadCustomPlugin(ctx, &UploadCustomPluginRequest{Name: "jdbc", SeatunnelVersion: "2.3.12"}, file); !errors.Is(err, ErrCustomPluginConflict) {
+		t.Fatalf("expected ErrCustomPluginConflict for official name, got %v", err)
+	}
+	if _, err := service.UploadCustomPlugin(ctx, &UploadCustomPluginRequest{Name: "../evil"}, file); !errors.Is(err, ErrInvalidCustomPlugin) {
+		t.Fatalf("expected ErrInvalidCustomPlugin for bad name, got %v", err)
+	}
+
+	if _, err := service.DeleteCustomPlugin(ctx, record.ID); err != nil {
+		t.Fatalf("DeleteCustomPlugin returned error: %v", err)
+	}
+	if _, err := os.Stat(service.downloader.GetConnectorPath(record.ArtifactID, "2.3.12")); !os.IsNotExist(err) {
+		t.Fatalf("expected custom jar to be removed, stat err=%v", err)
+	}
+	if _, err := service.GetPluginInfo(ctx, "acme-sink", "2.3.12"); !errors.Is(err, ErrPluginNotAvailable) {
+		t.Fatalf("expected deleted plugin to leave the marketplace, got %v", err)
+	}
+}
+
+func TestCustomPluginResumableUpload(t *testing.T) {
+	service, _ := newTestPluginServiceWithDownloader(t, t.TempDir())
+	service.uploads = chunkupload.NewStore(t.TempDir(), 0)
+	ctx := context.Background()
+
+	if _, err := service.InitCustomPluginUpload(ctx, &InitCustomPluginUploadRequest{
+		UploadCustomPluginRequest: UploadCustomPluginRequest{Name: "acme-source"},
+		FileName:                  "acme-source.zip",
+		TotalSize:                 10,
+	}); !errors.Is(err, ErrInvalidCustomPlugin) {
+		t.Fatalf("expected ErrInvalidCustomPlugin for a non-jar file, got %v", err)
+	}
+
+	content := []byte("resumable custom connector")
+	session, err := service.InitCustomPluginUpload(ctx, &InitCustomPluginUploadRequest{
+		UploadCustomPluginRequest: UploadCustomPluginRequest{Name: "acme-source", SeatunnelVersion: "2.3.12", Category: PluginCategorySource},
+		FileName:                  "acme-source-1.0.0.jar",
+		TotalSize:                 int64(len(content)),
+	})
+	if err != nil {
+		t.Fatalf("InitCustomPluginUpload returned error: %v", err)
+	}
+	for offset := 0; offset < len(content); offset += 10 {
+		end := min(offset+10, len(content))
+		if _, err := service.AppendCustomPluginUpload(session.ID, int64(offset), "", bytes.NewReader(content[offset:end])); err != nil {
+			t.Fatalf("AppendCustomPluginUpload at %d returned error: %v", offset, err)
+		}
+	}
+
+	record, err := service.CompleteCustomPluginUpload(ctx, session.ID)
+	if err != nil {
+		t.Fatalf("CompleteCustomPluginUpload returned error: %v", err)
+	}
+	if record.Category != PluginCategorySource || record.OriginalFileName != "acme-source-1.0.0.jar" || record.FileSize != int64(len(content)) {
+		t.Fatalf("unexpected custom plugin record: %+v", record)
+	}
+	if !service.downloader.IsConnectorDownloaded("acme-source", "2.3.12") {
+		t.Fatalf("expected custom jar at the connector path")
+	}
+}
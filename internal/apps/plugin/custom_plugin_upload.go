@@ -0,0 +1,112 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package plugin
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/seatunnel/seatunnelX/internal/config"
+	"github.com/seatunnel/seatunnelX/internal/pkg/chunkupload"
+)
+
+// customPluginUploadDirName is the directory under the temp directory holding partial custom plugin uploads.
+// customPluginUploadDirName 是临时目录下保存未完成自定义插件上传的目录。
+const customPluginUploadDirName = "resumable-plugin-uploads"
+
+// InitCustomPluginUploadRequest starts a resumable custom plugin upload; the plugin fields are
+// the same as for the single-request upload.
+// InitCustomPluginUploadRequest 用于开始可续传的自定义插件上传，插件字段与单次请求上传相同。
+type InitCustomPluginUploadRequest struct {
+	UploadCustomPluginRequest
+	FileName  string `json:"file_name" binding:"required"`  // 原始文件名 / Original file name
+	TotalSize int64  `json:"total_size" binding:"required"` // 文件总大小（字节）/ Total size in bytes
+	SHA256    string `json:"sha256,omitempty"`              // 整个文件的 SHA256（可选）/ SHA256 of the whole file (optional)
+}
+
+func newCustomPluginUploadStore() *chunkupload.Store {
+	return chunkupload.NewStore(filepath.Join(config.GetTempDir(), customPluginUploadDirName), chunkupload.DefaultTTL)
+}
+
+// InitCustomPluginUpload validates the plugin and starts a resumable upload of its jar.
+// InitCustomPluginUpload 校验插件信息并开始其 jar 的可续传上传。
+func (s *Service) InitCustomPluginUpload(ctx context.Context, req *InitCustomPluginUploadRequest) (*chunkupload.Session, error) {
+	if _, _, _, err := s.validateCustomPlugin(ctx, &req.UploadCustomPluginRequest, req.FileName); err != nil {
+		return nil, err
+	}
+	return s.uploads.Init(chunkupload.InitRequest{
+		FileName:  req.FileName,
+		TotalSize: req.TotalSize,
+		SHA256:    req.SHA256,
+		Metadata: map[string]string{
+			"name":              req.Name,
+			"seatunnel_version": req.SeatunnelVersion,
+			"display_name":      req.DisplayName,
+			"group_id":          req.GroupID,
+			"category":          string(req.Category),
+			"description":       req.Description,
+			"doc_url":           req.DocURL,
+			"uploaded_by":       req.UploadedBy,
+		},
+	})
+}
+
+// GetCustomPluginUpload returns a resumable custom plugin upload, including the offset to resume from.
+// GetCustomPluginUpload 返回可续传的自定义插件上传，包括续传的起始偏移量。
+func (s *Service) GetCustomPluginUpload(id string) (*chunkupload.Session, error) {
+	return s.uploads.Get(id)
+}
+
+// AppendCustomPluginUpload writes one chunk of a resumable custom plugin upload at offset.
+// AppendCustomPluginUpload 在 offset 处写入可续传自定义插件上传的一个分片。
+func (s *Service) AppendCustomPluginUpload(id string, offset int64, checksum string, src io.Reader) (*chunkupload.Session, error) {
+	return s.uploads.Append(id, offset, checksum, src)
+}
+
+// CompleteCustomPluginUpload stores the fully uploaded jar as a custom plugin.
+// CompleteCustomPluginUpload 将已完整上传的 jar 保存为自定义插件。
+func (s *Service) CompleteCustomPluginUpload(ctx context.Context, id string) (*CustomPlugin, error) {
+	var plugin *CustomPlugin
+	_, err := s.uploads.Complete(id, func(session *chunkupload.Session, path string) error {
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		plugin, err = s.saveCustomPlugin(ctx, &UploadCustomPluginRequest{
+			Name:             session.Metadata["name"],
+			SeatunnelVersion: session.Metadata["seatunnel_version"],
+			DisplayName:      session.Metadata["display_name"],
+			GroupID:          session.Metadata["group_id"],
+			Category:         PluginCategory(session.Metadata["category"]),
+			Description:      session.Metadata["description"],
+			DocURL:           session.Metadata["doc_url"],
+			UploadedBy:       session.Metadata["uploaded_by"],
+		}, session.FileName, file)
+		return err
+	})
+	return plugin, err
+}
+
+// AbortCustomPluginUpload discards a resumable custom plugin upload.
+// AbortCustomPluginUpload 放弃一个可续传的自定义插件上传。
+func (s *Service) AbortCustomPluginUpload(id string) error {
+	return s.uploads.Abort(id)
+}
@@ -0,0 +1,165 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package plugin
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/seatunnel/seatunnelX/internal/apps/audit"
+	"github.com/seatunnel/seatunnelX/internal/apps/auth"
+	"github.com/seatunnel/seatunnelX/internal/apps/response"
+	"github.com/seatunnel/seatunnelX/internal/logger"
+	"github.com/seatunnel/seatunnelX/internal/pkg/chunkupload"
+)
+
+// CustomPluginUploadSessionResponse is the response carrying the state of a resumable upload.
+// CustomPluginUploadSessionResponse 是携带可续传上传状态的响应。
+type CustomPluginUploadSessionResponse struct {
+	response.Meta
+	Data *chunkupload.Session `json:"data"`
+}
+
+// InitCustomPluginUpload handles POST /api/v1/plugins/custom/uploads - starts a resumable custom plugin upload.
+// InitCustomPluginUpload 处理 POST /api/v1/plugins/custom/uploads - 开始可续传的自定义插件上传。
+// @Tags plugins
+// @Accept json
+// @Produce json
+// @Param request body InitCustomPluginUploadRequest true "插件与文件信息 / Plugin and file info"
+// @Success 200 {object} CustomPluginUploadSessionResponse
+// @Router /api/v1/plugins/custom/uploads [post]
+func (h *Handler) InitCustomPluginUpload(c *gin.Context) {
+	if !auth.Authorize(c, auth.PermissionManage) {
+		return
+	}
+	var req InitCustomPluginUploadRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	req.UploadedBy = auth.GetUsernameFromContext(c)
+	session, err := h.service.InitCustomPluginUpload(c.Request.Context(), &req)
+	if err != nil {
+		respondCustomPluginUploadError(c, err, nil)
+		return
+	}
+	response.OK(c, session)
+}
+
+// GetCustomPluginUpload handles GET /api/v1/plugins/custom/uploads/:uploadId - returns the offset to resume from.
+// GetCustomPluginUpload 处理 GET /api/v1/plugins/custom/uploads/:uploadId - 返回续传的起始偏移量。
+// @Tags plugins
+// @Produce json
+// @Param uploadId path string true "上传会话 ID"
+// @Success 200 {object} CustomPluginUploadSessionResponse
+// @Router /api/v1/plugins/custom/uploads/{uploadId} [get]
+func (h *Handler) GetCustomPluginUpload(c *gin.Context) {
+	session, err := h.service.GetCustomPluginUpload(c.Param("uploadId"))
+	if err != nil {
+		respondCustomPluginUploadError(c, err, nil)
+		return
+	}
+	response.OK(c, session)
+}
+
+// AppendCustomPluginUpload handles PATCH /api/v1/plugins/custom/uploads/:uploadId - appends the raw request body at offset.
+// A mismatching offset answers 409 with the current upload so the client can resume from its offset.
+// AppendCustomPluginUpload 处理 PATCH /api/v1/plugins/custom/uploads/:uploadId - 在 offset 处追加原始请求体。
+// 偏移量不一致时返回 409 并携带当前上传状态，便于客户端从其偏移量续传。
+// @Tags plugins
+// @Accept octet-stream
+// @Produce json
+// @Param uploadId path string true "上传会话 ID"
+// @Param offset query int true "分片起始偏移量 / Chunk offset"
+// @Param sha256 query string false "分片 SHA256 / Chunk SHA256"
+// @Success 200 {object} CustomPluginUploadSessionResponse
+// @Failure 409 {object} CustomPluginUploadSessionResponse
+// @Router /api/v1/plugins/custom/uploads/{uploadId} [patch]
+func (h *Handler) AppendCustomPluginUpload(c *gin.Context) {
+	if !auth.Authorize(c, auth.PermissionManage) {
+		return
+	}
+	offset, err := strconv.ParseInt(c.Query("offset"), 10, 64)
+	if err != nil || offset < 0 {
+		response.Error(c, http.StatusBadRequest, "offset 必须为非负整数 / offset must be a non-negative integer")
+		return
+	}
+	session, err := h.service.AppendCustomPluginUpload(c.Param("uploadId"), offset, c.Query("sha256"), c.Request.Body)
+	if err != nil {
+		respondCustomPluginUploadError(c, err, session)
+		return
+	}
+	response.OK(c, session)
+}
+
+// CompleteCustomPluginUpload handles POST /api/v1/plugins/custom/uploads/:uploadId/complete - saves the uploaded jar as a custom plugin.
+// CompleteCustomPluginUpload 处理 POST /api/v1/plugins/custom/uploads/:uploadId/complete - 将已上传的 jar 保存为自定义插件。
+// @Tags plugins
+// @Produce json
+// @Param uploadId path string true "上传会话 ID"
+// @Success 200 {object} CustomPluginResponse
+// @Router /api/v1/plugins/custom/uploads/{uploadId}/complete [post]
+func (h *Handler) CompleteCustomPluginUpload(c *gin.Context) {
+	if !auth.Authorize(c, auth.PermissionManage) {
+		return
+	}
+	plugin, err := h.service.CompleteCustomPluginUpload(c.Request.Context(), c.Param("uploadId"))
+	if err != nil {
+		respondCustomPluginUploadError(c, err, nil)
+		return
+	}
+	_ = audit.RecordFromGin(c, h.auditRepo, auth.GetUserIDFromContext(c), auth.GetUsernameFromContext(c),
+		"upload_custom_plugin", "plugin", plugin.Name, plugin.DisplayName,
+		audit.AuditDetails{"trigger": "manual", "version": plugin.SeatunnelVersion, "sha256": plugin.SHA256})
+	logger.InfoF(c.Request.Context(), "[Plugin] 上传自定义插件成功: name=%s, version=%s", plugin.Name, plugin.SeatunnelVersion)
+	response.OK(c, plugin)
+}
+
+// AbortCustomPluginUpload handles DELETE /api/v1/plugins/custom/uploads/:uploadId - discards a resumable upload.
+// AbortCustomPluginUpload 处理 DELETE /api/v1/plugins/custom/uploads/:uploadId - 放弃可续传上传。
+// @Tags plugins
+// @Produce json
+// @Param uploadId path string true "上传会话 ID"
+// @Success 200 {object} CustomPluginUploadSessionResponse
+// @Router /api/v1/plugins/custom/uploads/{uploadId} [delete]
+func (h *Handler) AbortCustomPluginUpload(c *gin.Context) {
+	if !auth.Authorize(c, auth.PermissionManage) {
+		return
+	}
+	if err := h.service.AbortCustomPluginUpload(c.Param("uploadId")); err != nil {
+		respondCustomPluginUploadError(c, err, nil)
+		return
+	}
+	response.OK(c, nil)
+}
+
+func respondCustomPluginUploadError(c *gin.Context, err error, session *chunkupload.Session) {
+	status := chunkupload.StatusCode(err)
+	switch {
+	case status != 0:
+	case errors.Is(err, ErrInvalidCustomPlugin):
+		status = http.StatusBadRequest
+	case errors.Is(err, ErrCustomPluginConflict):
+		status = http.StatusConflict
+	default:
+		status = http.StatusInternalServerError
+	}
+	response.ErrorWithData(c, status, err.Error(), session)
+}
@@ -73,9 +73,55 @@ func (s *Service) AddDependency(ctx context.Context, req *AddDependencyRequest)
 	if err := s.repo.UpsertDependency(ctx, dep); err != nil {
 		return nil, err
 	}
+	if req.ResolveTransitive {
+		if err := s.addTransitiveDependencies(ctx, dep, req.Mirror); err != nil {
+			return nil, err
+		}
+	}
 	return s.repo.FindDependencyByNaturalKey(ctx, dep.PluginName, dep.SeatunnelVersion, dep.GroupID, dep.ArtifactID, dep.Version, dep.TargetDir, dep.SourceType)
 }
 
+// addTransitiveDependencies resolves the runtime dependencies of root from Maven and registers
+// them next to it. Previously resolved children of the same root are replaced, and entries the
+// user added explicitly are left untouched.
+// addTransitiveDependencies 从 Maven 解析 root 的运行时依赖并登记到同一目标目录。
+// 同一根坐标之前解析出的子依赖会被替换，用户显式添加的条目保持不变。
+func (s *Service) addTransitiveDependencies(ctx context.Context, root *PluginDependencyConfig, mirror MirrorSource) error {
+	rootCoordinate := MavenCoordinate{GroupID: root.GroupID, ArtifactID: root.ArtifactID, Version: root.Version}
+	resolved, err := NewMavenResolver(mirror).ResolveTransitive(ctx, rootCoordinate)
+	if err != nil {
+		return fmt.Errorf("failed to resolve transitive dependencies of %s: %w / 解析传递依赖失败", rootCoordinate, err)
+	}
+
+	resolvedFrom := rootCoordinate.String()
+	if err := s.repo.DeleteDependenciesResolvedFrom(ctx, root.PluginName, root.SeatunnelVersion, resolvedFrom); err != nil {
+		return err
+	}
+	for _, coordinate := range resolved {
+		existing, err := s.repo.FindDependencyByNaturalKey(ctx, root.PluginName, root.SeatunnelVersion, coordinate.GroupID, coordinate.ArtifactID, coordinate.Version, root.TargetDir, PluginDependencySourceMaven)
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			continue
+		}
+		child := &PluginDependencyConfig{
+			PluginName:       root.PluginName,
+			SeatunnelVersion: root.SeatunnelVersion,
+			GroupID:          coordinate.GroupID,
+			ArtifactID:       coordinate.ArtifactID,
+			Version:          coordinate.Version,
+			TargetDir:        root.TargetDir,
+			SourceType:       PluginDependencySourceMaven,
+			ResolvedFrom:     resolvedFrom,
+		}
+		if err := s.repo.UpsertDependency(ctx, child); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // UploadDependency uploads one custom jar and registers it as a user-added dependency.
 // UploadDependency 上传一个自定义 jar，并登记为用户新增依赖。
 func (s *Service) UploadDependency(ctx context.Context, req *UploadDependencyRequest, file *multipart.FileHeader) (*PluginDependencyConfig, error) {
@@ -137,8 +183,10 @@ func (s *Service) UploadDependency(ctx context.Context, req *UploadDependencyReq
 		_ = os.Remove(storedPath)
 		return nil, err
 	}
+	s.downloader.mirrorFileToStore(ctx, storedPath)
 	if existing != nil && existing.StoredPath != "" && existing.StoredPath != storedPath {
 		_ = os.Remove(existing.StoredPath)
+		s.downloader.removeFromStore(ctx, existing.StoredPath)
 	}
 	return s.repo.FindDependencyByNaturalKey(ctx, dep.PluginName, dep.SeatunnelVersion, dep.GroupID, dep.ArtifactID, dep.Version, dep.TargetDir, dep.SourceType)
 }
@@ -153,8 +201,17 @@ func (s *Service) DeleteDependency(ctx context.Context, depID uint) error {
 	if err := s.repo.DeleteDependency(ctx, depID); err != nil {
 		return err
 	}
+	if dep.SourceType == PluginDependencySourceMaven && dep.ResolvedFrom == "" {
+		// Drop transitive dependencies that were only registered because of this one.
+		// 删除仅因该依赖而登记的传递依赖。
+		rootCoordinate := MavenCoordinate{GroupID: dep.GroupID, ArtifactID: dep.ArtifactID, Version: dep.Version}
+		if err := s.repo.DeleteDependenciesResolvedFrom(ctx, dep.PluginName, dep.SeatunnelVersion, rootCoordinate.String()); err != nil {
+			return err
+		}
+	}
 	if dep.SourceType == PluginDependencySourceUpload && strings.TrimSpace(dep.StoredPath) != "" {
 		_ = os.Remove(dep.StoredPath)
+		s.downloader.removeFromStore(ctx, dep.StoredPath)
 	}
 	return nil
 }
@@ -21,8 +21,6 @@ package plugin
 
 import (
 	"context"
-	"crypto/sha1"
-	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -34,6 +32,10 @@ import (
 	"strings"
 	"sync"
 	"time"
+
+	"github.com/seatunnel/seatunnelX/internal/pkg/downloadx"
+	"github.com/seatunnel/seatunnelX/internal/pkg/objstore"
+	"golang.org/x/crypto/openpgp"
 )
 
 // Downloader errors / 下载器错误
@@ -93,6 +95,17 @@ type Downloader struct {
 	// cancelFuncs 存储活动下载的取消函数
 	cancelFuncs map[string]context.CancelFunc
 	cancelMu    sync.Mutex
+
+	// verifySignatures enables GPG checks of Apache artifacts against the KEYS file at keysURL
+	// verifySignatures 启用基于 keysURL 处 KEYS 文件的 Apache 构件 GPG 校验
+	verifySignatures bool
+	keysURL          string
+	keyRing          openpgp.EntityList
+	keysMu           sync.RWMutex
+
+	// store shares downloaded plugins between Control Plane replicas (optional)
+	// store 在控制面副本之间共享已下载的插件（可选）
+	store objstore.Store
 }
 
 type localPluginMetadata struct {
@@ -111,12 +124,11 @@ var connectorFilenamePattern = regexp.MustCompile(`^(connector-.+)-(\d+\.\d+\.\d
 // NewDownloader 创建一个新的 Downloader 实例。
 func NewDownloader(pluginsDir string) *Downloader {
 	return &Downloader{
-		pluginsDir: pluginsDir,
-		httpClient: &http.Client{
-			Timeout: 30 * time.Minute, // Long timeout for large files / 大文件的长超时
-		},
+		pluginsDir:      pluginsDir,
+		httpClient:      downloadx.NewClient(30 * time.Minute), // Long timeout for large files / 大文件的长超时
 		activeDownloads: make(map[string]*DownloadProgress),
 		cancelFuncs:     make(map[string]context.CancelFunc),
+		keysURL:         DefaultPluginKeysURL,
 	}
 }
 
@@ -494,7 +506,7 @@ func (d *Downloader) DownloadConnector(ctx context.Context, plugin *Plugin, mirr
 	}
 
 	// Download the jar file / 下载 jar 文件
-	_, sha1URL, err := d.downloadArtifactWithFallback(downloadCtx, urls, targetPath, progress, callback)
+	jarURL, err := d.downloadArtifactWithFallback(downloadCtx, urls, targetPath, progress, callback)
 	if err != nil {
 		progress.Status = "failed"
 		progress.Error = err.Error()
@@ -506,7 +518,7 @@ func (d *Downloader) DownloadConnector(ctx context.Context, plugin *Plugin, mirr
 		return err
 	}
 
-	// Verify checksum / 验证校验和
+	// Verify checksum and signature / 验证校验和与签名
 	progress.CurrentStep = "Verifying checksum / 验证校验和"
 	progress.CurrentArtifact = artifactID
 	progress.CurrentArtifactKind = "connector"
@@ -514,9 +526,9 @@ func (d *Downloader) DownloadConnector(ctx context.Context, plugin *Plugin, mirr
 		callback(progress)
 	}
 
-	if err := d.verifyChecksum(downloadCtx, targetPath, sha1URL); err != nil {
-		// Remove the downloaded file if checksum fails / 如果校验失败则删除下载的文件
-		os.Remove(targetPath)
+	if _, err := d.verifyArtifact(downloadCtx, targetPath, jarURL, plugin.GroupID); err != nil {
+		// Remove the downloaded file if verification fails / 如果校验失败则删除下载的文件
+		removeArtifactFiles(targetPath)
 		progress.Status = "failed"
 		progress.Error = err.Error()
 		now := time.Now()
@@ -602,13 +614,19 @@ func (d *Downloader) DownloadDependencies(ctx context.Context, plugin *Plugin, m
 
 		switch dep.SourceType {
 		case PluginDependencySourceUpload:
-			if err := d.copyUploadedDependency(targetPath, dep.StoredPath); err != nil {
+			if err := d.copyUploadedDependency(ctx, targetPath, dep.StoredPath); err != nil {
 				progress.Message = fmt.Sprintf("Warning: failed to copy uploaded dependency %s: %v", dep.ArtifactID, err)
 				if callback != nil {
 					callback(progress)
 				}
 				continue
 			}
+			if err := markArtifactTrusted(targetPath); err != nil {
+				progress.Message = fmt.Sprintf("Warning: failed to record uploaded dependency %s: %v", dep.ArtifactID, err)
+				if callback != nil {
+					callback(progress)
+				}
+			}
 		default:
 			// Build Maven URL / 构建 Maven URL
 			groupPath := strings.ReplaceAll(dep.GroupID, ".", "/")
@@ -616,7 +634,7 @@ func (d *Downloader) DownloadDependencies(ctx context.Context, plugin *Plugin, m
 			urls := d.buildArtifactURLs(baseURL, groupPath, dep.ArtifactID, dep.Version, jarName, mirror)
 
 			// Download the dependency / 下载依赖
-			_, sha1URL, err := d.downloadArtifactWithFallback(ctx, urls, targetPath, progress, callback)
+			jarURL, err := d.downloadArtifactWithFallback(ctx, urls, targetPath, progress, callback)
 			if err != nil {
 				// Log warning but continue with other dependencies / 记录警告但继续下载其他依赖
 				progress.Message = fmt.Sprintf("Warning: failed to download %s: %v", dep.ArtifactID, err)
@@ -626,13 +644,15 @@ func (d *Downloader) DownloadDependencies(ctx context.Context, plugin *Plugin, m
 				continue
 			}
 
-			// Verify checksum (optional for dependencies) / 验证校验和（依赖可选）
-			if err := d.verifyChecksum(ctx, targetPath, sha1URL); err != nil {
-				// Log warning but don't fail / 记录警告但不失败
-				progress.Message = fmt.Sprintf("Warning: checksum verification failed for %s", dep.ArtifactID)
+			// A corrupted dependency is dropped so it can never be installed; the install step
+			// reports it as missing. / 损坏的依赖会被删除以免被安装，安装时会报告其缺失。
+			if _, err := d.verifyArtifact(ctx, targetPath, jarURL, dep.GroupID); err != nil {
+				removeArtifactFiles(targetPath)
+				progress.Message = fmt.Sprintf("Warning: verification failed for %s: %v", dep.ArtifactID, err)
 				if callback != nil {
 					callback(progress)
 				}
+				continue
 			}
 		}
 
@@ -651,11 +671,13 @@ func (d *Downloader) DownloadDependencies(ctx context.Context, plugin *Plugin, m
 	return nil
 }
 
-func (d *Downloader) copyUploadedDependency(targetPath, storedPath string) error {
+func (d *Downloader) copyUploadedDependency(ctx context.Context, targetPath, storedPath string) error {
 	storedPath = strings.TrimSpace(storedPath)
 	if storedPath == "" {
 		return fmt.Errorf("stored path is empty / 上传依赖的存储路径为空")
 	}
+	// The jar may have been uploaded through another replica / 依赖可能是通过其他副本上传的
+	d.fetchFileFromStore(ctx, storedPath)
 	src, err := os.Open(storedPath)
 	if err != nil {
 		return err
@@ -692,22 +714,22 @@ func (d *Downloader) buildArtifactURLs(baseURL, groupPath, artifactID, version,
 	return urls
 }
 
-func (d *Downloader) downloadArtifactWithFallback(ctx context.Context, urls []string, targetPath string, progress *DownloadProgress, callback ProgressCallback) (string, string, error) {
+func (d *Downloader) downloadArtifactWithFallback(ctx context.Context, urls []string, targetPath string, progress *DownloadProgress, callback ProgressCallback) (string, error) {
 	var lastErr error
 	for _, jarURL := range urls {
 		if err := d.downloadFile(ctx, jarURL, targetPath, progress, callback); err != nil {
 			lastErr = err
 			if !errors.Is(err, ErrFileNotFound) {
-				return "", "", err
+				return "", err
 			}
 			continue
 		}
-		return jarURL, jarURL + ".sha1", nil
+		return jarURL, nil
 	}
 	if lastErr == nil {
 		lastErr = ErrFileNotFound
 	}
-	return "", "", lastErr
+	return "", lastErr
 }
 
 // DownloadPlugin downloads a plugin and all its dependencies.
@@ -777,6 +799,7 @@ func (d *Downloader) DownloadPlugin(ctx context.Context, plugin *Plugin, mirror
 	if err := d.writePluginMetadata(plugin, selectedProfileKeys); err != nil {
 		return fmt.Errorf("failed to write plugin metadata: %w", err)
 	}
+	d.mirrorVersionToStore(ctx, plugin.Version)
 	return nil
 }
 
@@ -880,60 +903,6 @@ func (d *Downloader) downloadFile(ctx context.Context, url, targetPath string, p
 	return nil
 }
 
-// verifyChecksum verifies the SHA1 checksum of a downloaded file.
-// verifyChecksum 验证下载文件的 SHA1 校验和。
-func (d *Downloader) verifyChecksum(ctx context.Context, filePath, sha1URL string) error {
-	// Download SHA1 checksum / 下载 SHA1 校验和
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, sha1URL, nil)
-	if err != nil {
-		return fmt.Errorf("failed to create checksum request: %w", err)
-	}
-
-	resp, err := d.httpClient.Do(req)
-	if err != nil {
-		// If checksum file not available, skip verification / 如果校验和文件不可用，跳过验证
-		return nil
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		// If checksum file not available, skip verification / 如果校验和文件不可用，跳过验证
-		return nil
-	}
-
-	// Read expected checksum / 读取预期的校验和
-	checksumBytes, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil // Skip verification on read error / 读取错误时跳过验证
-	}
-
-	expectedChecksum := strings.TrimSpace(string(checksumBytes))
-	// Some checksum files contain additional info, extract just the hash / 某些校验和文件包含额外信息，只提取哈希值
-	if idx := strings.Index(expectedChecksum, " "); idx > 0 {
-		expectedChecksum = expectedChecksum[:idx]
-	}
-
-	// Calculate actual checksum / 计算实际校验和
-	file, err := os.Open(filePath)
-	if err != nil {
-		return fmt.Errorf("failed to open file for checksum: %w", err)
-	}
-	defer file.Close()
-
-	hasher := sha1.New()
-	if _, err := io.Copy(hasher, file); err != nil {
-		return fmt.Errorf("failed to calculate checksum: %w", err)
-	}
-
-	actualChecksum := hex.EncodeToString(hasher.Sum(nil))
-
-	if !strings.EqualFold(actualChecksum, expectedChecksum) {
-		return fmt.Errorf("%w: expected %s, got %s", ErrChecksumMismatch, expectedChecksum, actualChecksum)
-	}
-
-	return nil
-}
-
 // ListLocalPlugins returns a list of locally downloaded plugins.
 // ListLocalPlugins 返回本地已下载的插件列表。
 func (d *Downloader) ListLocalPlugins() ([]LocalPlugin, error) {
@@ -1110,6 +1079,7 @@ func determinePluginCategory(name string) PluginCategory {
 // 注意：使用 GetConnectorPathByName 将插件名称映射到 artifact ID。
 func (d *Downloader) DeleteLocalPlugin(name, version string) error {
 	connectorPath := d.GetConnectorPathByName(name, version)
+	d.removeFromStore(context.Background(), connectorPath, verificationPath(connectorPath), d.getMetadataPath(name, version))
 
 	// Check if exists / 检查是否存在
 	if _, err := os.Stat(connectorPath); os.IsNotExist(err) {
@@ -1120,6 +1090,7 @@ func (d *Downloader) DeleteLocalPlugin(name, version string) error {
 	if err := os.Remove(connectorPath); err != nil {
 		return fmt.Errorf("failed to delete connector: %w", err)
 	}
+	_ = os.Remove(verificationPath(connectorPath))
 
 	_ = os.Remove(d.getMetadataPath(name, version))
 
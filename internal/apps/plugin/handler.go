@@ -22,11 +22,14 @@ import (
 	"io"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/seatunnel/seatunnelX/internal/apps/audit"
 	"github.com/seatunnel/seatunnelX/internal/apps/auth"
+	"github.com/seatunnel/seatunnelX/internal/apps/response"
 	"github.com/seatunnel/seatunnelX/internal/logger"
+	"github.com/seatunnel/seatunnelX/internal/pkg/pagex"
 )
 
 // Handler provides HTTP handlers for plugin management.
@@ -48,8 +51,8 @@ func NewHandler(service *Service, auditRepo *audit.Repository) *Handler {
 // ListPluginsResponse represents the response for listing plugins.
 // ListPluginsResponse 表示获取插件列表的响应。
 type ListPluginsResponse struct {
-	ErrorMsg string                    `json:"error_msg"`
-	Data     *AvailablePluginsResponse `json:"data"`
+	response.Meta
+	Data *AvailablePluginsResponse `json:"data"`
 }
 
 // ListAvailablePlugins handles GET /api/v1/plugins - lists available plugins.
@@ -66,11 +69,11 @@ func (h *Handler) ListAvailablePlugins(c *gin.Context) {
 
 	result, err := h.service.ListAvailablePlugins(c.Request.Context(), version, mirror)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, ListPluginsResponse{ErrorMsg: err.Error()})
+		response.Error(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
-	c.JSON(http.StatusOK, ListPluginsResponse{Data: result})
+	response.OK(c, result)
 }
 
 // RefreshAvailablePluginsRequest represents the request for refreshing connector catalog.
@@ -91,35 +94,35 @@ type RefreshAvailablePluginsRequest struct {
 func (h *Handler) RefreshAvailablePlugins(c *gin.Context) {
 	var req RefreshAvailablePluginsRequest
 	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
-		c.JSON(http.StatusBadRequest, ListPluginsResponse{ErrorMsg: "无效的请求参数 / Invalid request payload"})
+		response.Error(c, http.StatusBadRequest, "无效的请求参数 / Invalid request payload")
 		return
 	}
 
 	result, err := h.service.ListAvailablePlugins(c.Request.Context(), req.Version, req.Mirror)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, ListPluginsResponse{ErrorMsg: err.Error()})
+		response.Error(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
 	if _, err := h.service.RefreshPlugins(c.Request.Context(), result.Version, req.Mirror); err != nil {
-		c.JSON(http.StatusInternalServerError, ListPluginsResponse{ErrorMsg: err.Error()})
+		response.Error(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
 	refreshed, err := h.service.ListAvailablePlugins(c.Request.Context(), result.Version, req.Mirror)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, ListPluginsResponse{ErrorMsg: err.Error()})
+		response.Error(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
-	c.JSON(http.StatusOK, ListPluginsResponse{Data: refreshed})
+	response.OK(c, refreshed)
 }
 
 // GetPluginInfoResponse represents the response for getting plugin info.
 // GetPluginInfoResponse 表示获取插件信息的响应。
 type GetPluginInfoResponse struct {
-	ErrorMsg string  `json:"error_msg"`
-	Data     *Plugin `json:"data"`
+	response.Meta
+	Data *Plugin `json:"data"`
 }
 
 // GetPluginInfo handles GET /api/v1/plugins/:name - gets plugin info.
@@ -133,7 +136,7 @@ type GetPluginInfoResponse struct {
 func (h *Handler) GetPluginInfo(c *gin.Context) {
 	name := c.Param("name")
 	if name == "" {
-		c.JSON(http.StatusBadRequest, GetPluginInfoResponse{ErrorMsg: "插件名称不能为空 / Plugin name is required"})
+		response.Error(c, http.StatusBadRequest, "插件名称不能为空 / Plugin name is required")
 		return
 	}
 
@@ -141,11 +144,11 @@ func (h *Handler) GetPluginInfo(c *gin.Context) {
 
 	plugin, err := h.service.GetPluginInfo(c.Request.Context(), name, version)
 	if err != nil {
-		c.JSON(http.StatusNotFound, GetPluginInfoResponse{ErrorMsg: err.Error()})
+		response.Error(c, http.StatusNotFound, err.Error())
 		return
 	}
 
-	c.JSON(http.StatusOK, GetPluginInfoResponse{Data: plugin})
+	response.OK(c, plugin)
 }
 
 // ==================== Installed Plugins APIs 已安装插件 API ====================
@@ -153,8 +156,20 @@ func (h *Handler) GetPluginInfo(c *gin.Context) {
 // ListInstalledPluginsResponse represents the response for listing installed plugins.
 // ListInstalledPluginsResponse 表示获取已安装插件列表的响应。
 type ListInstalledPluginsResponse struct {
-	ErrorMsg string            `json:"error_msg"`
-	Data     []InstalledPlugin `json:"data"`
+	response.Meta
+	Data []InstalledPlugin `json:"data"`
+	// Total is the number of plugins matching the filter / Total 是匹配过滤条件的插件总数
+	Total int `json:"total"`
+}
+
+// ListInstalledPluginsRequest represents the query for listing installed plugins.
+// ListInstalledPluginsRequest 表示获取已安装插件列表的查询参数。
+type ListInstalledPluginsRequest struct {
+	pagex.Query
+	pagex.TimeQuery
+	Category PluginCategory `json:"category" form:"category"`
+	Status   PluginStatus   `json:"status" form:"status"`
+	Keyword  string         `json:"keyword" form:"keyword"`
 }
 
 // ListInstalledPlugins handles GET /api/v1/clusters/:id/plugins - lists installed plugins on a cluster.
@@ -162,22 +177,47 @@ type ListInstalledPluginsResponse struct {
 // @Tags plugins
 // @Produce json
 // @Param id path int true "集群ID"
+// @Param request query ListInstalledPluginsRequest false "查询参数"
 // @Success 200 {object} ListInstalledPluginsResponse
 // @Router /api/v1/clusters/{id}/plugins [get]
 func (h *Handler) ListInstalledPlugins(c *gin.Context) {
 	clusterID, err := strconv.ParseUint(c.Param("id"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, ListInstalledPluginsResponse{ErrorMsg: "无效的集群 ID / Invalid cluster ID"})
+		response.Error(c, http.StatusBadRequest, "无效的集群 ID / Invalid cluster ID")
 		return
 	}
 
-	plugins, err := h.service.ListInstalledPlugins(c.Request.Context(), uint(clusterID))
+	req := &ListInstalledPluginsRequest{}
+	if err := c.ShouldBindQuery(req); err != nil {
+		response.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	page, err := req.Resolve(installedPluginListSpec)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, ListInstalledPluginsResponse{ErrorMsg: err.Error()})
+		response.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	installedAt, err := req.Range()
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	c.JSON(http.StatusOK, ListInstalledPluginsResponse{Data: plugins})
+	plugins, total, err := h.service.ListInstalledPluginsPage(c.Request.Context(), uint(clusterID), &PluginFilter{
+		Category:    req.Category,
+		Status:      req.Status,
+		Keyword:     req.Keyword,
+		InstalledAt: installedAt,
+		Page:        page.Page,
+		PageSize:    page.PageSize,
+		Sort:        page.Sort,
+	})
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, ListInstalledPluginsResponse{Meta: response.NewMeta(c, http.StatusOK, ""), Data: plugins, Total: total})
 }
 
 // ==================== Plugin Installation APIs 插件安装 API ====================
@@ -185,8 +225,8 @@ func (h *Handler) ListInstalledPlugins(c *gin.Context) {
 // InstallPluginResponse represents the response for installing a plugin.
 // InstallPluginResponse 表示安装插件的响应。
 type InstallPluginResponse struct {
-	ErrorMsg string           `json:"error_msg"`
-	Data     *InstalledPlugin `json:"data"`
+	response.Meta
+	Data *InstalledPlugin `json:"data"`
 }
 
 // InstallPlugin handles POST /api/v1/clusters/:id/plugins - installs a plugin on a cluster.
@@ -201,19 +241,19 @@ type InstallPluginResponse struct {
 func (h *Handler) InstallPlugin(c *gin.Context) {
 	clusterID, err := strconv.ParseUint(c.Param("id"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, InstallPluginResponse{ErrorMsg: "无效的集群 ID / Invalid cluster ID"})
+		response.Error(c, http.StatusBadRequest, "无效的集群 ID / Invalid cluster ID")
 		return
 	}
 
 	var req InstallPluginRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, InstallPluginResponse{ErrorMsg: err.Error()})
+		response.Error(c, http.StatusBadRequest, err.Error())
 		return
 	}
 
 	installed, err := h.service.InstallPlugin(c.Request.Context(), uint(clusterID), &req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, InstallPluginResponse{ErrorMsg: err.Error()})
+		response.Error(c, pluginOperationErrorStatus(err, http.StatusInternalServerError), err.Error())
 		return
 	}
 
@@ -221,14 +261,14 @@ func (h *Handler) InstallPlugin(c *gin.Context) {
 	_ = audit.RecordFromGin(c, h.auditRepo, auth.GetUserIDFromContext(c), auth.GetUsernameFromContext(c),
 		"install", "plugin", resID, req.PluginName, audit.AuditDetails{"trigger": "manual"})
 	logger.InfoF(c.Request.Context(), "[Plugin] 安装插件成功: cluster=%d, plugin=%s", clusterID, req.PluginName)
-	c.JSON(http.StatusOK, InstallPluginResponse{Data: installed})
+	response.OK(c, installed)
 }
 
 // UninstallPluginResponse represents the response for uninstalling a plugin.
 // UninstallPluginResponse 表示卸载插件的响应。
 type UninstallPluginResponse struct {
-	ErrorMsg string `json:"error_msg"`
-	Data     any    `json:"data"`
+	response.Meta
+	Data any `json:"data"`
 }
 
 // UninstallPlugin handles DELETE /api/v1/clusters/:id/plugins/:name - uninstalls a plugin from a cluster.
@@ -242,18 +282,18 @@ type UninstallPluginResponse struct {
 func (h *Handler) UninstallPlugin(c *gin.Context) {
 	clusterID, err := strconv.ParseUint(c.Param("id"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, UninstallPluginResponse{ErrorMsg: "无效的集群 ID / Invalid cluster ID"})
+		response.Error(c, http.StatusBadRequest, "无效的集群 ID / Invalid cluster ID")
 		return
 	}
 
 	pluginName := c.Param("name")
 	if pluginName == "" {
-		c.JSON(http.StatusBadRequest, UninstallPluginResponse{ErrorMsg: "插件名称不能为空 / Plugin name is required"})
+		response.Error(c, http.StatusBadRequest, "插件名称不能为空 / Plugin name is required")
 		return
 	}
 
 	if err := h.service.UninstallPlugin(c.Request.Context(), uint(clusterID), pluginName); err != nil {
-		c.JSON(http.StatusInternalServerError, UninstallPluginResponse{ErrorMsg: err.Error()})
+		response.Error(c, pluginOperationErrorStatus(err, http.StatusInternalServerError), err.Error())
 		return
 	}
 
@@ -261,14 +301,14 @@ func (h *Handler) UninstallPlugin(c *gin.Context) {
 	_ = audit.RecordFromGin(c, h.auditRepo, auth.GetUserIDFromContext(c), auth.GetUsernameFromContext(c),
 		"uninstall", "plugin", resID, pluginName, audit.AuditDetails{"trigger": "manual"})
 	logger.InfoF(c.Request.Context(), "[Plugin] 卸载插件成功: cluster=%d, plugin=%s", clusterID, pluginName)
-	c.JSON(http.StatusOK, UninstallPluginResponse{})
+	response.OK(c, nil)
 }
 
 // EnableDisablePluginResponse represents the response for enabling/disabling a plugin.
 // EnableDisablePluginResponse 表示启用/禁用插件的响应。
 type EnableDisablePluginResponse struct {
-	ErrorMsg string           `json:"error_msg"`
-	Data     *InstalledPlugin `json:"data"`
+	response.Meta
+	Data *InstalledPlugin `json:"data"`
 }
 
 // EnablePlugin handles PUT /api/v1/clusters/:id/plugins/:name/enable - enables a plugin.
@@ -282,19 +322,19 @@ type EnableDisablePluginResponse struct {
 func (h *Handler) EnablePlugin(c *gin.Context) {
 	clusterID, err := strconv.ParseUint(c.Param("id"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, EnableDisablePluginResponse{ErrorMsg: "无效的集群 ID / Invalid cluster ID"})
+		response.Error(c, http.StatusBadRequest, "无效的集群 ID / Invalid cluster ID")
 		return
 	}
 
 	pluginName := c.Param("name")
 	if pluginName == "" {
-		c.JSON(http.StatusBadRequest, EnableDisablePluginResponse{ErrorMsg: "插件名称不能为空 / Plugin name is required"})
+		response.Error(c, http.StatusBadRequest, "插件名称不能为空 / Plugin name is required")
 		return
 	}
 
 	plugin, err := h.service.EnablePlugin(c.Request.Context(), uint(clusterID), pluginName)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, EnableDisablePluginResponse{ErrorMsg: err.Error()})
+		response.Error(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
@@ -302,7 +342,7 @@ func (h *Handler) EnablePlugin(c *gin.Context) {
 	_ = audit.RecordFromGin(c, h.auditRepo, auth.GetUserIDFromContext(c), auth.GetUsernameFromContext(c),
 		"enable", "plugin", resID, pluginName, audit.AuditDetails{"trigger": "manual"})
 	logger.InfoF(c.Request.Context(), "[Plugin] 启用插件成功: cluster=%d, plugin=%s", clusterID, pluginName)
-	c.JSON(http.StatusOK, EnableDisablePluginResponse{Data: plugin})
+	response.OK(c, plugin)
 }
 
 // DisablePlugin handles PUT /api/v1/clusters/:id/plugins/:name/disable - disables a plugin.
@@ -316,19 +356,19 @@ func (h *Handler) EnablePlugin(c *gin.Context) {
 func (h *Handler) DisablePlugin(c *gin.Context) {
 	clusterID, err := strconv.ParseUint(c.Param("id"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, EnableDisablePluginResponse{ErrorMsg: "无效的集群 ID / Invalid cluster ID"})
+		response.Error(c, http.StatusBadRequest, "无效的集群 ID / Invalid cluster ID")
 		return
 	}
 
 	pluginName := c.Param("name")
 	if pluginName == "" {
-		c.JSON(http.StatusBadRequest, EnableDisablePluginResponse{ErrorMsg: "插件名称不能为空 / Plugin name is required"})
+		response.Error(c, http.StatusBadRequest, "插件名称不能为空 / Plugin name is required")
 		return
 	}
 
 	plugin, err := h.service.DisablePlugin(c.Request.Context(), uint(clusterID), pluginName)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, EnableDisablePluginResponse{ErrorMsg: err.Error()})
+		response.Error(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
@@ -336,7 +376,7 @@ func (h *Handler) DisablePlugin(c *gin.Context) {
 	_ = audit.RecordFromGin(c, h.auditRepo, auth.GetUserIDFromContext(c), auth.GetUsernameFromContext(c),
 		"disable", "plugin", resID, pluginName, audit.AuditDetails{"trigger": "manual"})
 	logger.InfoF(c.Request.Context(), "[Plugin] 禁用插件成功: cluster=%d, plugin=%s", clusterID, pluginName)
-	c.JSON(http.StatusOK, EnableDisablePluginResponse{Data: plugin})
+	response.OK(c, plugin)
 }
 
 // ==================== Plugin Download APIs 插件下载 API ====================
@@ -352,8 +392,8 @@ type DownloadPluginRequest struct {
 // DownloadPluginResponse represents the response for downloading a plugin.
 // DownloadPluginResponse 表示下载插件的响应。
 type DownloadPluginResponse struct {
-	ErrorMsg string            `json:"error_msg"`
-	Data     *DownloadProgress `json:"data"`
+	response.Meta
+	Data *DownloadProgress `json:"data"`
 }
 
 // DownloadPlugin handles POST /api/v1/plugins/:name/download - downloads a plugin to Control Plane.
@@ -368,26 +408,26 @@ type DownloadPluginResponse struct {
 func (h *Handler) DownloadPlugin(c *gin.Context) {
 	name := c.Param("name")
 	if name == "" {
-		c.JSON(http.StatusBadRequest, DownloadPluginResponse{ErrorMsg: "插件名称不能为空 / Plugin name is required"})
+		response.Error(c, http.StatusBadRequest, "插件名称不能为空 / Plugin name is required")
 		return
 	}
 
 	var req DownloadPluginRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, DownloadPluginResponse{ErrorMsg: err.Error()})
+		response.Error(c, http.StatusBadRequest, err.Error())
 		return
 	}
 
 	progress, err := h.service.DownloadPlugin(c.Request.Context(), name, req.Version, req.Mirror, req.ProfileKeys)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, DownloadPluginResponse{ErrorMsg: err.Error()})
+		response.Error(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
 	_ = audit.RecordFromGin(c, h.auditRepo, auth.GetUserIDFromContext(c), auth.GetUsernameFromContext(c),
 		"download", "plugin", name+"/"+req.Version, name, audit.AuditDetails{"trigger": "manual"})
 	logger.InfoF(c.Request.Context(), "[Plugin] 开始下载插件: plugin=%s, version=%s", name, req.Version)
-	c.JSON(http.StatusOK, DownloadPluginResponse{Data: progress})
+	response.OK(c, progress)
 }
 
 // DownloadAllPluginsRequest represents a request to download all plugins.
@@ -401,8 +441,8 @@ type DownloadAllPluginsRequest struct {
 // DownloadAllPluginsResponse represents the response for downloading all plugins.
 // DownloadAllPluginsResponse 表示下载所有插件的响应。
 type DownloadAllPluginsResponse struct {
-	ErrorMsg string                      `json:"error_msg"`
-	Data     *DownloadAllPluginsProgress `json:"data"`
+	response.Meta
+	Data *DownloadAllPluginsProgress `json:"data"`
 }
 
 // DownloadAllPlugins handles POST /api/v1/plugins/download-all - downloads all plugins.
@@ -416,27 +456,27 @@ type DownloadAllPluginsResponse struct {
 func (h *Handler) DownloadAllPlugins(c *gin.Context) {
 	var req DownloadAllPluginsRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, DownloadAllPluginsResponse{ErrorMsg: err.Error()})
+		response.Error(c, http.StatusBadRequest, err.Error())
 		return
 	}
 
 	progress, err := h.service.DownloadAllPlugins(c.Request.Context(), req.Version, req.Mirror, req.SelectedPluginProfiles)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, DownloadAllPluginsResponse{ErrorMsg: err.Error()})
+		response.Error(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
 	_ = audit.RecordFromGin(c, h.auditRepo, auth.GetUserIDFromContext(c), auth.GetUsernameFromContext(c),
 		"download_all", "plugin", req.Version, "all", audit.AuditDetails{"trigger": "manual"})
 	logger.InfoF(c.Request.Context(), "[Plugin] 开始下载所有插件: version=%s, total=%d", req.Version, progress.Total)
-	c.JSON(http.StatusOK, DownloadAllPluginsResponse{Data: progress})
+	response.OK(c, progress)
 }
 
 // GetDownloadStatusResponse represents the response for getting download status.
 // GetDownloadStatusResponse 表示获取下载状态的响应。
 type GetDownloadStatusResponse struct {
-	ErrorMsg string            `json:"error_msg"`
-	Data     *DownloadProgress `json:"data"`
+	response.Meta
+	Data *DownloadProgress `json:"data"`
 }
 
 // GetDownloadStatus handles GET /api/v1/plugins/:name/download/status - gets download status.
@@ -450,26 +490,26 @@ type GetDownloadStatusResponse struct {
 func (h *Handler) GetDownloadStatus(c *gin.Context) {
 	name := c.Param("name")
 	if name == "" {
-		c.JSON(http.StatusBadRequest, GetDownloadStatusResponse{ErrorMsg: "插件名称不能为空 / Plugin name is required"})
+		response.Error(c, http.StatusBadRequest, "插件名称不能为空 / Plugin name is required")
 		return
 	}
 
 	version := c.Query("version")
 	if version == "" {
-		c.JSON(http.StatusBadRequest, GetDownloadStatusResponse{ErrorMsg: "版本号不能为空 / Version is required"})
+		response.Error(c, http.StatusBadRequest, "版本号不能为空 / Version is required")
 		return
 	}
 
 	profileKeys := c.QueryArray("profile_keys")
 	progress := h.service.GetDownloadStatus(name, version, profileKeys)
-	c.JSON(http.StatusOK, GetDownloadStatusResponse{Data: progress})
+	response.OK(c, progress)
 }
 
 // ListLocalPluginsResponse represents the response for listing local plugins.
 // ListLocalPluginsResponse 表示获取本地插件列表的响应。
 type ListLocalPluginsResponse struct {
-	ErrorMsg string        `json:"error_msg"`
-	Data     []LocalPlugin `json:"data"`
+	response.Meta
+	Data []LocalPlugin `json:"data"`
 }
 
 // ListLocalPlugins handles GET /api/v1/plugins/local - lists locally downloaded plugins.
@@ -481,18 +521,18 @@ type ListLocalPluginsResponse struct {
 func (h *Handler) ListLocalPlugins(c *gin.Context) {
 	plugins, err := h.service.ListLocalPlugins()
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, ListLocalPluginsResponse{ErrorMsg: err.Error()})
+		response.Error(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
-	c.JSON(http.StatusOK, ListLocalPluginsResponse{Data: plugins})
+	response.OK(c, plugins)
 }
 
 // ListActiveDownloadsResponse represents the response for listing active downloads.
 // ListActiveDownloadsResponse 表示获取活动下载列表的响应。
 type ListActiveDownloadsResponse struct {
-	ErrorMsg string              `json:"error_msg"`
-	Data     []*DownloadProgress `json:"data"`
+	response.Meta
+	Data []*DownloadProgress `json:"data"`
 }
 
 // ListActiveDownloads handles GET /api/v1/plugins/downloads - lists active download tasks.
@@ -503,14 +543,14 @@ type ListActiveDownloadsResponse struct {
 // @Router /api/v1/plugins/downloads [get]
 func (h *Handler) ListActiveDownloads(c *gin.Context) {
 	downloads := h.service.ListActiveDownloads()
-	c.JSON(http.StatusOK, ListActiveDownloadsResponse{Data: downloads})
+	response.OK(c, downloads)
 }
 
 // DeleteLocalPluginResponse represents the response for deleting a local plugin.
 // DeleteLocalPluginResponse 表示删除本地插件的响应。
 type DeleteLocalPluginResponse struct {
-	ErrorMsg string `json:"error_msg"`
-	Data     any    `json:"data"`
+	response.Meta
+	Data any `json:"data"`
 }
 
 // DeleteLocalPlugin handles DELETE /api/v1/plugins/:name/local - deletes a locally downloaded plugin.
@@ -524,25 +564,25 @@ type DeleteLocalPluginResponse struct {
 func (h *Handler) DeleteLocalPlugin(c *gin.Context) {
 	name := c.Param("name")
 	if name == "" {
-		c.JSON(http.StatusBadRequest, DeleteLocalPluginResponse{ErrorMsg: "插件名称不能为空 / Plugin name is required"})
+		response.Error(c, http.StatusBadRequest, "插件名称不能为空 / Plugin name is required")
 		return
 	}
 
 	version := c.Query("version")
 	if version == "" {
-		c.JSON(http.StatusBadRequest, DeleteLocalPluginResponse{ErrorMsg: "版本号不能为空 / Version is required"})
+		response.Error(c, http.StatusBadRequest, "版本号不能为空 / Version is required")
 		return
 	}
 
 	if err := h.service.DeleteLocalPlugin(name, version); err != nil {
-		c.JSON(http.StatusInternalServerError, DeleteLocalPluginResponse{ErrorMsg: err.Error()})
+		response.Error(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
 	_ = audit.RecordFromGin(c, h.auditRepo, auth.GetUserIDFromContext(c), auth.GetUsernameFromContext(c),
 		"delete_local", "plugin", name+"/"+version, name, audit.AuditDetails{"trigger": "manual"})
 	logger.InfoF(c.Request.Context(), "[Plugin] 删除本地插件成功: plugin=%s, version=%s", name, version)
-	c.JSON(http.StatusOK, DeleteLocalPluginResponse{})
+	response.OK(c, nil)
 }
 
 // ==================== Cluster Plugin Installation Progress API 集群插件安装进度 API ====================
@@ -550,8 +590,8 @@ func (h *Handler) DeleteLocalPlugin(c *gin.Context) {
 // GetInstallProgressResponse represents the response for getting plugin installation progress.
 // GetInstallProgressResponse 表示获取插件安装进度的响应。
 type GetInstallProgressResponse struct {
-	ErrorMsg string               `json:"error_msg"`
-	Data     *PluginInstallStatus `json:"data"`
+	response.Meta
+	Data *PluginInstallStatus `json:"data"`
 }
 
 // GetInstallProgress handles GET /api/v1/clusters/:id/plugins/:name/progress - gets plugin installation progress.
@@ -565,18 +605,144 @@ type GetInstallProgressResponse struct {
 func (h *Handler) GetInstallProgress(c *gin.Context) {
 	clusterID, err := strconv.ParseUint(c.Param("id"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, GetInstallProgressResponse{ErrorMsg: "无效的集群 ID / Invalid cluster ID"})
+		response.Error(c, http.StatusBadRequest, "无效的集群 ID / Invalid cluster ID")
 		return
 	}
 
 	pluginName := c.Param("name")
 	if pluginName == "" {
-		c.JSON(http.StatusBadRequest, GetInstallProgressResponse{ErrorMsg: "插件名称不能为空 / Plugin name is required"})
+		response.Error(c, http.StatusBadRequest, "插件名称不能为空 / Plugin name is required")
 		return
 	}
 
 	progress := h.service.GetInstallProgress(uint(clusterID), pluginName)
-	c.JSON(http.StatusOK, GetInstallProgressResponse{Data: progress})
+	response.OK(c, progress)
+}
+
+// ==================== Bulk Plugin Operation APIs 批量插件操作 API ====================
+
+// PluginOperationResponse represents the response carrying a bulk plugin operation.
+// PluginOperationResponse 表示包含批量插件操作的响应。
+type PluginOperationResponse struct {
+	response.Meta
+	Data *PluginOperation `json:"data"`
+}
+
+// StartPluginOperation handles POST /api/v1/clusters/:id/plugins/operations - installs or uninstalls plugins in bulk.
+// StartPluginOperation 处理 POST /api/v1/clusters/:id/plugins/operations - 批量安装或卸载插件。
+// @Tags plugins
+// @Accept json
+// @Produce json
+// @Param id path int true "集群ID"
+// @Param request body BulkPluginOperationRequest true "批量操作请求"
+// @Success 202 {object} PluginOperationResponse
+// @Router /api/v1/clusters/{id}/plugins/operations [post]
+func (h *Handler) StartPluginOperation(c *gin.Context) {
+	clusterID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "无效的集群 ID / Invalid cluster ID")
+		return
+	}
+
+	var req BulkPluginOperationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	op, err := h.service.StartPluginOperation(c.Request.Context(), uint(clusterID), &req)
+	if err != nil {
+		response.Error(c, pluginOperationErrorStatus(err, http.StatusBadRequest), err.Error())
+		return
+	}
+
+	pluginNames := make([]string, 0, len(op.Plugins))
+	for _, plugin := range op.Plugins {
+		pluginNames = append(pluginNames, plugin.PluginName)
+	}
+	_ = audit.RecordFromGin(c, h.auditRepo, auth.GetUserIDFromContext(c), auth.GetUsernameFromContext(c),
+		string(req.Action), "plugin", audit.UintID(uint(clusterID)), strings.Join(pluginNames, ","),
+		audit.AuditDetails{"trigger": "manual", "operation_id": op.ID, "plugins": pluginNames})
+	logger.InfoF(c.Request.Context(), "[Plugin] 批量插件操作已开始: cluster=%d, action=%s, operation=%s", clusterID, req.Action, op.ID)
+	response.JSON(c, http.StatusAccepted, op)
+}
+
+// GetPluginOperation handles GET /api/v1/clusters/:id/plugins/operations/:opId - gets per-node, per-plugin progress.
+// GetPluginOperation 处理 GET /api/v1/clusters/:id/plugins/operations/:opId - 获取按节点、按插件的进度。
+// @Tags plugins
+// @Produce json
+// @Param id path int true "集群ID"
+// @Param opId path string true "操作ID"
+// @Success 200 {object} PluginOperationResponse
+// @Router /api/v1/clusters/{id}/plugins/operations/{opId} [get]
+func (h *Handler) GetPluginOperation(c *gin.Context) {
+	clusterID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "无效的集群 ID / Invalid cluster ID")
+		return
+	}
+
+	op, err := h.service.GetPluginOperation(uint(clusterID), c.Param("opId"))
+	if err != nil {
+		response.Error(c, http.StatusNotFound, err.Error())
+		return
+	}
+	response.OK(c, op)
+}
+
+// RetryPluginOperation handles POST /api/v1/clusters/:id/plugins/operations/:opId/retry - retries failed nodes.
+// RetryPluginOperation 处理 POST /api/v1/clusters/:id/plugins/operations/:opId/retry - 重试失败节点。
+// @Tags plugins
+// @Accept json
+// @Produce json
+// @Param id path int true "集群ID"
+// @Param opId path string true "操作ID"
+// @Param request body RetryPluginOperationRequest false "重试过滤条件"
+// @Success 202 {object} PluginOperationResponse
+// @Router /api/v1/clusters/{id}/plugins/operations/{opId}/retry [post]
+func (h *Handler) RetryPluginOperation(c *gin.Context) {
+	clusterID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "无效的集群 ID / Invalid cluster ID")
+		return
+	}
+
+	var req RetryPluginOperationRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			response.Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	opID := c.Param("opId")
+	op, err := h.service.RetryPluginOperation(c.Request.Context(), uint(clusterID), opID, &req)
+	if err != nil {
+		status := http.StatusBadRequest
+		switch {
+		case errors.Is(err, ErrPluginOperationNotFound):
+			status = http.StatusNotFound
+		case errors.Is(err, ErrPluginOperationRunning),
+			errors.Is(err, ErrHostUnderMaintenance):
+			status = http.StatusConflict
+		}
+		response.Error(c, status, err.Error())
+		return
+	}
+
+	_ = audit.RecordFromGin(c, h.auditRepo, auth.GetUserIDFromContext(c), auth.GetUsernameFromContext(c),
+		"retry", "plugin", audit.UintID(uint(clusterID)), opID, audit.AuditDetails{"trigger": "manual", "operation_id": opID})
+	logger.InfoF(c.Request.Context(), "[Plugin] 批量插件操作重试: cluster=%d, operation=%s", clusterID, opID)
+	response.JSON(c, http.StatusAccepted, op)
+}
+
+// pluginOperationErrorStatus returns 409 for operations rejected during host maintenance and fallback otherwise.
+// pluginOperationErrorStatus 对因主机维护被拒绝的操作返回 409，其他情况返回 fallback。
+func pluginOperationErrorStatus(err error, fallback int) int {
+	if errors.Is(err, ErrHostUnderMaintenance) {
+		return http.StatusConflict
+	}
+	return fallback
 }
 
 // ==================== Plugin Dependency Config APIs 插件依赖配置 API ====================
@@ -584,8 +750,8 @@ func (h *Handler) GetInstallProgress(c *gin.Context) {
 // ListDependenciesResponse represents the response for listing plugin dependencies.
 // ListDependenciesResponse 表示获取插件依赖列表的响应。
 type ListDependenciesResponse struct {
-	ErrorMsg string                   `json:"error_msg"`
-	Data     []PluginDependencyConfig `json:"data"`
+	response.Meta
+	Data []PluginDependencyConfig `json:"data"`
 }
 
 // ListDependencies handles GET /api/v1/plugins/:name/dependencies - lists dependencies for a plugin.
@@ -598,25 +764,25 @@ type ListDependenciesResponse struct {
 func (h *Handler) ListDependencies(c *gin.Context) {
 	name := c.Param("name")
 	if name == "" {
-		c.JSON(http.StatusBadRequest, ListDependenciesResponse{ErrorMsg: "插件名称不能为空 / Plugin name is required"})
+		response.Error(c, http.StatusBadRequest, "插件名称不能为空 / Plugin name is required")
 		return
 	}
 
 	version := c.Query("version")
 	deps, err := h.service.ListDependencies(c.Request.Context(), name, version)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, ListDependenciesResponse{ErrorMsg: err.Error()})
+		response.Error(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
-	c.JSON(http.StatusOK, ListDependenciesResponse{Data: deps})
+	response.OK(c, deps)
 }
 
 // AddDependencyResponse represents the response for adding a dependency.
 // AddDependencyResponse 表示添加依赖的响应。
 type AddDependencyResponse struct {
-	ErrorMsg string                  `json:"error_msg"`
-	Data     *PluginDependencyConfig `json:"data"`
+	response.Meta
+	Data *PluginDependencyConfig `json:"data"`
 }
 
 // AddDependency handles POST /api/v1/plugins/:name/dependencies - adds a dependency to a plugin.
@@ -631,40 +797,45 @@ type AddDependencyResponse struct {
 func (h *Handler) AddDependency(c *gin.Context) {
 	name := c.Param("name")
 	if name == "" {
-		c.JSON(http.StatusBadRequest, AddDependencyResponse{ErrorMsg: "插件名称不能为空 / Plugin name is required"})
+		response.Error(c, http.StatusBadRequest, "插件名称不能为空 / Plugin name is required")
 		return
 	}
 
 	var req AddDependencyRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, AddDependencyResponse{ErrorMsg: err.Error()})
+		response.Error(c, http.StatusBadRequest, err.Error())
 		return
 	}
 	req.PluginName = name
 
 	dep, err := h.service.AddDependency(c.Request.Context(), &req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, AddDependencyResponse{ErrorMsg: err.Error()})
+		response.Error(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
 	_ = audit.RecordFromGin(c, h.auditRepo, auth.GetUserIDFromContext(c), auth.GetUsernameFromContext(c),
 		"add_dependency", "plugin", name, name, audit.AuditDetails{"trigger": "manual"})
 	logger.InfoF(c.Request.Context(), "[Plugin] 添加依赖成功: plugin=%s, dep=%s:%s", name, req.GroupID, req.ArtifactID)
-	c.JSON(http.StatusOK, AddDependencyResponse{Data: dep})
+	response.OK(c, dep)
 }
 
 // UploadDependency handles POST /api/v1/plugins/:name/dependencies/upload - uploads a custom jar dependency.
 // UploadDependency 处理 POST /api/v1/plugins/:name/dependencies/upload - 上传自定义 Jar 依赖。
 func (h *Handler) UploadDependency(c *gin.Context) {
+	// Uploaded jars are distributed to cluster nodes and require manage permission.
+	// 上传的 jar 会分发到集群节点，需要管理权限。
+	if !auth.Authorize(c, auth.PermissionManage) {
+		return
+	}
 	name := c.Param("name")
 	if name == "" {
-		c.JSON(http.StatusBadRequest, AddDependencyResponse{ErrorMsg: "插件名称不能为空 / Plugin name is required"})
+		response.Error(c, http.StatusBadRequest, "插件名称不能为空 / Plugin name is required")
 		return
 	}
 	file, err := c.FormFile("file")
 	if err != nil {
-		c.JSON(http.StatusBadRequest, AddDependencyResponse{ErrorMsg: "必须上传 jar 文件 / jar file is required"})
+		response.Error(c, http.StatusBadRequest, "必须上传 jar 文件 / jar file is required")
 		return
 	}
 	req := &UploadDependencyRequest{
@@ -677,20 +848,20 @@ func (h *Handler) UploadDependency(c *gin.Context) {
 	}
 	dep, err := h.service.UploadDependency(c.Request.Context(), req, file)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, AddDependencyResponse{ErrorMsg: err.Error()})
+		response.Error(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 	_ = audit.RecordFromGin(c, h.auditRepo, auth.GetUserIDFromContext(c), auth.GetUsernameFromContext(c),
 		"upload_dependency", "plugin", name, name, audit.AuditDetails{"trigger": "manual", "artifact_id": dep.ArtifactID})
 	logger.InfoF(c.Request.Context(), "[Plugin] 上传自定义依赖成功: plugin=%s, dep=%s", name, dep.ArtifactID)
-	c.JSON(http.StatusOK, AddDependencyResponse{Data: dep})
+	response.OK(c, dep)
 }
 
 // DeleteDependencyResponse represents the response for deleting a dependency.
 // DeleteDependencyResponse 表示删除依赖的响应。
 type DeleteDependencyResponse struct {
-	ErrorMsg string `json:"error_msg"`
-	Data     any    `json:"data"`
+	response.Meta
+	Data any `json:"data"`
 }
 
 // DeleteDependency handles DELETE /api/v1/plugins/:name/dependencies/:depId - deletes a dependency.
@@ -705,26 +876,26 @@ func (h *Handler) DeleteDependency(c *gin.Context) {
 	name := c.Param("name")
 	depID, err := strconv.ParseUint(c.Param("depId"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, DeleteDependencyResponse{ErrorMsg: "无效的依赖 ID / Invalid dependency ID"})
+		response.Error(c, http.StatusBadRequest, "无效的依赖 ID / Invalid dependency ID")
 		return
 	}
 
 	if err := h.service.DeleteDependency(c.Request.Context(), uint(depID)); err != nil {
-		c.JSON(http.StatusInternalServerError, DeleteDependencyResponse{ErrorMsg: err.Error()})
+		response.Error(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
 	_ = audit.RecordFromGin(c, h.auditRepo, auth.GetUserIDFromContext(c), auth.GetUsernameFromContext(c),
 		"delete_dependency", "plugin", name+"/"+strconv.FormatUint(depID, 10), name, audit.AuditDetails{"trigger": "manual"})
 	logger.InfoF(c.Request.Context(), "[Plugin] 删除依赖成功: depId=%d", depID)
-	c.JSON(http.StatusOK, DeleteDependencyResponse{})
+	response.OK(c, nil)
 }
 
 // DisableDependencyResponse represents the response for disabling one official dependency item.
 // DisableDependencyResponse 表示禁用官方依赖的响应。
 type DisableDependencyResponse struct {
-	ErrorMsg string                   `json:"error_msg"`
-	Data     *PluginDependencyDisable `json:"data"`
+	response.Meta
+	Data *PluginDependencyDisable `json:"data"`
 }
 
 // DisableDependency handles POST /api/v1/plugins/:name/dependencies/disables.
@@ -732,24 +903,24 @@ type DisableDependencyResponse struct {
 func (h *Handler) DisableDependency(c *gin.Context) {
 	name := c.Param("name")
 	if name == "" {
-		c.JSON(http.StatusBadRequest, DisableDependencyResponse{ErrorMsg: "插件名称不能为空 / Plugin name is required"})
+		response.Error(c, http.StatusBadRequest, "插件名称不能为空 / Plugin name is required")
 		return
 	}
 	var req DisableDependencyRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, DisableDependencyResponse{ErrorMsg: err.Error()})
+		response.Error(c, http.StatusBadRequest, err.Error())
 		return
 	}
 	req.PluginName = name
 	item, err := h.service.DisableDependency(c.Request.Context(), &req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, DisableDependencyResponse{ErrorMsg: err.Error()})
+		response.Error(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 	_ = audit.RecordFromGin(c, h.auditRepo, auth.GetUserIDFromContext(c), auth.GetUsernameFromContext(c),
 		"disable_official_dependency", "plugin", name, name, audit.AuditDetails{"artifact_id": req.ArtifactID, "version": req.Version})
 	logger.InfoF(c.Request.Context(), "[Plugin] 禁用官方依赖成功: plugin=%s, dep=%s:%s", name, req.GroupID, req.ArtifactID)
-	c.JSON(http.StatusOK, DisableDependencyResponse{Data: item})
+	response.OK(c, item)
 }
 
 // EnableDependency handles DELETE /api/v1/plugins/:name/dependencies/disables/:disableId.
@@ -758,24 +929,24 @@ func (h *Handler) EnableDependency(c *gin.Context) {
 	name := c.Param("name")
 	disableID, err := strconv.ParseUint(c.Param("disableId"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, DeleteDependencyResponse{ErrorMsg: "无效的禁用依赖 ID / Invalid disable ID"})
+		response.Error(c, http.StatusBadRequest, "无效的禁用依赖 ID / Invalid disable ID")
 		return
 	}
 	if err := h.service.EnableDependency(c.Request.Context(), uint(disableID)); err != nil {
-		c.JSON(http.StatusInternalServerError, DeleteDependencyResponse{ErrorMsg: err.Error()})
+		response.Error(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 	_ = audit.RecordFromGin(c, h.auditRepo, auth.GetUserIDFromContext(c), auth.GetUsernameFromContext(c),
 		"enable_official_dependency", "plugin", name+"/"+strconv.FormatUint(disableID, 10), name, audit.AuditDetails{"trigger": "manual"})
 	logger.InfoF(c.Request.Context(), "[Plugin] 恢复官方依赖成功: disableId=%d", disableID)
-	c.JSON(http.StatusOK, DeleteDependencyResponse{})
+	response.OK(c, nil)
 }
 
 // GetOfficialDependenciesResponse represents the response for official dependency lookup.
 // GetOfficialDependenciesResponse 表示获取官方依赖的响应。
 type GetOfficialDependenciesResponse struct {
-	ErrorMsg string                        `json:"error_msg"`
-	Data     *OfficialDependenciesResponse `json:"data"`
+	response.Meta
+	Data *OfficialDependenciesResponse `json:"data"`
 }
 
 // AnalyzeOfficialDependenciesRequest represents analyze request payload.
@@ -791,17 +962,17 @@ type AnalyzeOfficialDependenciesRequest struct {
 func (h *Handler) GetOfficialDependencies(c *gin.Context) {
 	name := c.Param("name")
 	if name == "" {
-		c.JSON(http.StatusBadRequest, GetOfficialDependenciesResponse{ErrorMsg: "插件名称不能为空 / Plugin name is required"})
+		response.Error(c, http.StatusBadRequest, "插件名称不能为空 / Plugin name is required")
 		return
 	}
 	version := c.Query("version")
 	profileKey := c.Query("profile_key")
 	data, err := h.service.GetOfficialDependencies(c.Request.Context(), name, version, profileKey)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, GetOfficialDependenciesResponse{ErrorMsg: err.Error()})
+		response.Error(c, http.StatusInternalServerError, err.Error())
 		return
 	}
-	c.JSON(http.StatusOK, GetOfficialDependenciesResponse{Data: data})
+	response.OK(c, data)
 }
 
 // AnalyzeOfficialDependencies handles POST /api/v1/plugins/:name/official-dependencies/analyze.
@@ -809,21 +980,195 @@ func (h *Handler) GetOfficialDependencies(c *gin.Context) {
 func (h *Handler) AnalyzeOfficialDependencies(c *gin.Context) {
 	name := c.Param("name")
 	if name == "" {
-		c.JSON(http.StatusBadRequest, GetOfficialDependenciesResponse{ErrorMsg: "插件名称不能为空 / Plugin name is required"})
+		response.Error(c, http.StatusBadRequest, "插件名称不能为空 / Plugin name is required")
 		return
 	}
 	var req AnalyzeOfficialDependenciesRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, GetOfficialDependenciesResponse{ErrorMsg: err.Error()})
+		response.Error(c, http.StatusBadRequest, err.Error())
 		return
 	}
 	data, err := h.service.AnalyzeOfficialDependencies(c.Request.Context(), name, req.Version, req.ProfileKey, req.ForceRefresh)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, GetOfficialDependenciesResponse{ErrorMsg: err.Error()})
+		response.Error(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 	_ = audit.RecordFromGin(c, h.auditRepo, auth.GetUserIDFromContext(c), auth.GetUsernameFromContext(c),
 		"analyze_official_dependency", "plugin", name, name, audit.AuditDetails{"trigger": "manual", "profile_key": req.ProfileKey, "version": req.Version})
 	logger.InfoF(c.Request.Context(), "[Plugin] 官方依赖分析完成: plugin=%s, profile=%s, version=%s", name, req.ProfileKey, req.Version)
-	c.JSON(http.StatusOK, GetOfficialDependenciesResponse{Data: data})
+	response.OK(c, data)
+}
+
+// PluginIndexResponse represents the response of the plugin index endpoints.
+// PluginIndexResponse 表示插件索引接口的响应。
+type PluginIndexResponse struct {
+	response.Meta
+	Data *PluginIndexInfo `json:"data"`
+}
+
+// GetPluginIndex handles GET /api/v1/plugins/index - describes the offline plugin index in use.
+// GetPluginIndex 处理 GET /api/v1/plugins/index - 查看当前使用的离线插件索引。
+// @Tags plugins
+// @Produce json
+// @Success 200 {object} PluginIndexResponse
+// @Router /api/v1/plugins/index [get]
+func (h *Handler) GetPluginIndex(c *gin.Context) {
+	response.OK(c, h.service.GetPluginIndexInfo())
+}
+
+// RefreshPluginIndex handles POST /api/v1/plugins/index/refresh - replaces the offline plugin index.
+// An uploaded "file" is used when present, otherwise the index is downloaded from storage.plugin_index_url.
+// RefreshPluginIndex 处理 POST /api/v1/plugins/index/refresh - 替换离线插件索引。
+// 上传了 "file" 时使用该文件，否则从 storage.plugin_index_url 下载。
+// @Tags plugins
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file false "plugin-index.json"
+// @Success 200 {object} PluginIndexResponse
+// @Router /api/v1/plugins/index/refresh [post]
+func (h *Handler) RefreshPluginIndex(c *gin.Context) {
+	if !auth.Authorize(c, auth.PermissionManage) {
+		return
+	}
+	var content []byte
+	if file, err := c.FormFile("file"); err == nil {
+		src, err := file.Open()
+		if err != nil {
+			response.Error(c, http.StatusBadRequest, "无法读取索引文件 / Failed to read index file")
+			return
+		}
+		content, err = io.ReadAll(io.LimitReader(src, 16<<20))
+		src.Close()
+		if err != nil {
+			response.Error(c, http.StatusBadRequest, "无法读取索引文件 / Failed to read index file")
+			return
+		}
+	}
+
+	info, err := h.service.RefreshPluginIndex(c.Request.Context(), content)
+	if err != nil {
+		status := http.StatusBadGateway
+		if errors.Is(err, ErrInvalidPluginIndex) {
+			status = http.StatusBadRequest
+		}
+		response.Error(c, status, err.Error())
+		return
+	}
+	trigger := "download"
+	if len(content) > 0 {
+		trigger = "upload"
+	}
+	_ = audit.RecordFromGin(c, h.auditRepo, auth.GetUserIDFromContext(c), auth.GetUsernameFromContext(c),
+		"refresh_plugin_index", "plugin", "index", "plugin-index", audit.AuditDetails{"trigger": trigger, "plugin_count": info.PluginCount})
+	response.OK(c, info)
+}
+
+// ListCustomPluginsResponse represents the response for listing custom plugins.
+// ListCustomPluginsResponse 表示获取自定义插件列表的响应。
+type ListCustomPluginsResponse struct {
+	response.Meta
+	Data []CustomPlugin `json:"data"`
+}
+
+// ListCustomPlugins handles GET /api/v1/plugins/custom - lists uploaded custom plugins.
+// ListCustomPlugins 处理 GET /api/v1/plugins/custom - 获取已上传的自定义插件。
+// @Tags plugins
+// @Produce json
+// @Param version query string false "SeaTunnel 版本"
+// @Success 200 {object} ListCustomPluginsResponse
+// @Router /api/v1/plugins/custom [get]
+func (h *Handler) ListCustomPlugins(c *gin.Context) {
+	plugins, err := h.service.ListCustomPlugins(c.Request.Context(), c.Query("version"))
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	response.OK(c, plugins)
+}
+
+// CustomPluginResponse represents the response for one custom plugin.
+// CustomPluginResponse 表示单个自定义插件的响应。
+type CustomPluginResponse struct {
+	response.Meta
+	Data *CustomPlugin `json:"data"`
+}
+
+// UploadCustomPlugin handles POST /api/v1/plugins/custom - uploads a private connector jar.
+// UploadCustomPlugin 处理 POST /api/v1/plugins/custom - 上传私有连接器 Jar。
+// @Tags plugins
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "连接器 Jar"
+// @Param name formData string true "插件名称"
+// @Success 200 {object} CustomPluginResponse
+// @Router /api/v1/plugins/custom [post]
+func (h *Handler) UploadCustomPlugin(c *gin.Context) {
+	// Custom jars are distributed to cluster nodes and require manage permission.
+	// 自定义 jar 会分发到集群节点，需要管理权限。
+	if !auth.Authorize(c, auth.PermissionManage) {
+		return
+	}
+	file, err := c.FormFile("file")
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "必须上传 jar 文件 / jar file is required")
+		return
+	}
+	req := &UploadCustomPluginRequest{
+		Name:             c.PostForm("name"),
+		SeatunnelVersion: c.PostForm("seatunnel_version"),
+		DisplayName:      c.PostForm("display_name"),
+		GroupID:          c.PostForm("group_id"),
+		Category:         PluginCategory(c.PostForm("category")),
+		Description:      c.PostForm("description"),
+		DocURL:           c.PostForm("doc_url"),
+		UploadedBy:       auth.GetUsernameFromContext(c),
+	}
+	plugin, err := h.service.UploadCustomPlugin(c.Request.Context(), req, file)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrInvalidCustomPlugin):
+			response.Error(c, http.StatusBadRequest, err.Error())
+		case errors.Is(err, ErrCustomPluginConflict):
+			response.Error(c, http.StatusConflict, err.Error())
+		default:
+			response.Error(c, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+	_ = audit.RecordFromGin(c, h.auditRepo, auth.GetUserIDFromContext(c), auth.GetUsernameFromContext(c),
+		"upload_custom_plugin", "plugin", plugin.Name, plugin.DisplayName,
+		audit.AuditDetails{"trigger": "manual", "version": plugin.SeatunnelVersion, "sha256": plugin.SHA256})
+	logger.InfoF(c.Request.Context(), "[Plugin] 上传自定义插件成功: name=%s, version=%s", plugin.Name, plugin.SeatunnelVersion)
+	response.OK(c, plugin)
+}
+
+// DeleteCustomPlugin handles DELETE /api/v1/plugins/custom/:id - deletes a custom plugin.
+// DeleteCustomPlugin 处理 DELETE /api/v1/plugins/custom/:id - 删除自定义插件。
+// @Tags plugins
+// @Produce json
+// @Param id path int true "自定义插件ID"
+// @Success 200 {object} CustomPluginResponse
+// @Router /api/v1/plugins/custom/{id} [delete]
+func (h *Handler) DeleteCustomPlugin(c *gin.Context) {
+	if !auth.Authorize(c, auth.PermissionManage) {
+		return
+	}
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "无效的自定义插件 ID / Invalid custom plugin ID")
+		return
+	}
+	plugin, err := h.service.DeleteCustomPlugin(c.Request.Context(), uint(id))
+	if err != nil {
+		if errors.Is(err, ErrCustomPluginNotFound) {
+			response.Error(c, http.StatusNotFound, err.Error())
+			return
+		}
+		response.Error(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	_ = audit.RecordFromGin(c, h.auditRepo, auth.GetUserIDFromContext(c), auth.GetUsernameFromContext(c),
+		"delete_custom_plugin", "plugin", plugin.Name, plugin.DisplayName,
+		audit.AuditDetails{"trigger": "manual", "version": plugin.SeatunnelVersion})
+	response.OK(c, plugin)
 }
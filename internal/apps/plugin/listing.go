@@ -0,0 +1,120 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package plugin
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/seatunnel/seatunnelX/internal/pkg/pagex"
+)
+
+// installedPluginListSpec defines paging and the sortable fields of a cluster's installed plugins.
+// Clusters rarely carry more than a page of plugins, so the default page is large.
+// installedPluginListSpec 定义集群已安装插件列表的分页方式和可排序字段。
+// 集群的插件数量很少超过一页，因此默认每页数量较大。
+var installedPluginListSpec = pagex.Spec{
+	DefaultSize: 100,
+	MaxSize:     500,
+	Fields: map[string]string{
+		"plugin_name":  "plugin_name",
+		"category":     "category",
+		"version":      "version",
+		"status":       "status",
+		"installed_at": "installed_at",
+		"updated_at":   "updated_at",
+	},
+	DefaultSort: "-installed_at",
+}
+
+// ListInstalledPluginsPage lists one page of the plugins installed on a cluster; ClusterID in filter is ignored.
+// It returns the page and the number of plugins matching the filter.
+// ListInstalledPluginsPage 获取集群已安装插件的一页；filter 中的 ClusterID 会被忽略。
+// 返回该页数据以及匹配过滤条件的插件总数。
+func (s *Service) ListInstalledPluginsPage(ctx context.Context, clusterID uint, filter *PluginFilter) ([]InstalledPlugin, int, error) {
+	plugins, err := s.ListInstalledPlugins(ctx, clusterID)
+	if err != nil {
+		return nil, 0, err
+	}
+	if filter == nil {
+		return plugins, len(plugins), nil
+	}
+
+	matched := make([]InstalledPlugin, 0, len(plugins))
+	keyword := strings.ToLower(filter.Keyword)
+	for _, p := range plugins {
+		if filter.Category != "" && p.Category != filter.Category {
+			continue
+		}
+		if filter.Status != "" && p.Status != filter.Status {
+			continue
+		}
+		if keyword != "" && !strings.Contains(strings.ToLower(p.PluginName), keyword) {
+			continue
+		}
+		if !filter.InstalledAt.Contains(p.InstalledAt) {
+			continue
+		}
+		matched = append(matched, p)
+	}
+
+	sortInstalledPlugins(matched, filter.Sort)
+	start, end := pagex.Params{Page: filter.Page, PageSize: filter.PageSize}.Bounds(len(matched))
+	return matched[start:end], len(matched), nil
+}
+
+// sortInstalledPlugins orders plugins like the repository does, newest first by default.
+// sortInstalledPlugins 按与仓库一致的方式排序插件，默认按安装时间倒序。
+func sortInstalledPlugins(plugins []InstalledPlugin, fields []pagex.SortField) {
+	if len(fields) == 0 {
+		fields = []pagex.SortField{{Field: "installed_at", Desc: true}}
+	}
+	sort.SliceStable(plugins, func(i, j int) bool {
+		for _, field := range fields {
+			c := compareInstalledPlugins(&plugins[i], &plugins[j], field.Field)
+			if c == 0 {
+				continue
+			}
+			if field.Desc {
+				return c > 0
+			}
+			return c < 0
+		}
+		return false
+	})
+}
+
+// compareInstalledPlugins compares two plugins on one sortable field.
+// compareInstalledPlugins 按单个可排序字段比较两个插件。
+func compareInstalledPlugins(a, b *InstalledPlugin, field string) int {
+	switch field {
+	case "plugin_name":
+		return strings.Compare(a.PluginName, b.PluginName)
+	case "category":
+		return strings.Compare(string(a.Category), string(b.Category))
+	case "version":
+		return strings.Compare(a.Version, b.Version)
+	case "status":
+		return strings.Compare(string(a.Status), string(b.Status))
+	case "updated_at":
+		return a.UpdatedAt.Compare(b.UpdatedAt)
+	default:
+		return a.InstalledAt.Compare(b.InstalledAt)
+	}
+}
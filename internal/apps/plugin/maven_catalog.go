@@ -0,0 +1,260 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/seatunnel/seatunnelX/internal/logger"
+	"github.com/seatunnel/seatunnelX/internal/pkg/downloadx"
+)
+
+// mavenCatalogWorkers bounds the concurrent maven-metadata.xml requests of one catalog scan.
+// mavenCatalogWorkers 限制一次目录扫描中并发的 maven-metadata.xml 请求数。
+const mavenCatalogWorkers = 16
+
+// errMavenNotFound marks a Maven path that does not exist on the mirror.
+// errMavenNotFound 表示镜像上不存在该 Maven 路径。
+var errMavenNotFound = errors.New("maven path not found / Maven 路径不存在")
+
+var connectorListingPattern = regexp.MustCompile(`<a[^>]*href="(connector-[^/"]+)/"[^>]*>`)
+
+// MavenMetadataCache keeps the last response of a Maven lookup so later scans can send
+// conditional requests (ETag / Last-Modified) and reuse the values on 304 Not Modified.
+// MavenMetadataCache 保存 Maven 查询的最近一次响应，后续扫描可发送条件请求
+// （ETag / Last-Modified），并在 304 Not Modified 时复用已解析的值。
+type MavenMetadataCache struct {
+	ID           uint      `gorm:"primaryKey" json:"id"`
+	URL          string    `gorm:"size:512;not null;uniqueIndex" json:"url"` // 请求地址 / Request URL
+	ETag         string    `gorm:"column:etag;size:255" json:"etag"`         // 响应 ETag / Response ETag
+	LastModified string    `gorm:"size:100" json:"last_modified"`            // 响应 Last-Modified / Response Last-Modified
+	Entries      string    `gorm:"type:text" json:"entries"`                 // 解析结果（JSON 数组）/ Parsed entries (JSON array)
+	CheckedAt    time.Time `gorm:"not null" json:"checked_at"`               // 最近检查时间 / Last checked at
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// TableName returns the table name for MavenMetadataCache.
+// TableName 返回 MavenMetadataCache 的表名。
+func (MavenMetadataCache) TableName() string {
+	return "maven_metadata_cache"
+}
+
+// fetchConnectorsFromMirror lists connector artifacts of the mirror and keeps those that publish the version.
+// Versions come from each artifact's maven-metadata.xml, checked by a bounded worker pool.
+// fetchConnectorsFromMirror 列出镜像上的连接器构件，并保留发布了该版本的连接器。
+// 版本信息来自各构件的 maven-metadata.xml，由有界工作池并发检查。
+func (s *Service) fetchConnectorsFromMirror(ctx context.Context, version string, mirror MirrorSource) ([]Plugin, error) {
+	client := downloadx.NewClient(PluginFetchTimeout)
+	candidates, err := s.conditionalMavenFetch(ctx, client, connectorRepoBaseURL(mirror)+"/", parseConnectorListing)
+	if err != nil {
+		logger.ErrorF(ctx, "[Plugin] Failed to fetch Maven repo: %v", err)
+		return nil, fmt.Errorf("failed to fetch Maven repo: %w", err)
+	}
+
+	logger.InfoF(ctx, "[Plugin] Checking %d connector candidates with %d workers", len(candidates), mavenCatalogWorkers)
+
+	jobs := make(chan string)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var plugins []Plugin
+	workers := mavenCatalogWorkers
+	if len(candidates) < workers {
+		workers = len(candidates)
+	}
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for artifactID := range jobs {
+				hasVersion, err := s.connectorHasVersion(ctx, client, artifactID, version, mirror)
+				if err != nil {
+					logger.DebugF(ctx, "[Plugin] Skip connector %s: %v", artifactID, err)
+					continue
+				}
+				if !hasVersion {
+					continue
+				}
+				plugin := s.createPluginFromArtifactID(artifactID, version)
+				mu.Lock()
+				plugins = append(plugins, plugin)
+				mu.Unlock()
+			}
+		}()
+	}
+	for _, artifactID := range candidates {
+		select {
+		case jobs <- artifactID:
+		case <-ctx.Done():
+		}
+	}
+	close(jobs)
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	sort.Slice(plugins, func(i, j int) bool { return plugins[i].Name < plugins[j].Name })
+	logger.InfoF(ctx, "[Plugin] Found %d connectors with version %s", len(plugins), version)
+	return plugins, nil
+}
+
+// connectorHasVersion checks the artifact's maven-metadata.xml, falling back to Apache when the mirror lacks it.
+// connectorHasVersion 检查构件的 maven-metadata.xml，镜像缺失时回退到 Apache。
+func (s *Service) connectorHasVersion(ctx context.Context, client *http.Client, artifactID, version string, mirror MirrorSource) (bool, error) {
+	url := fmt.Sprintf("%s/%s/maven-metadata.xml", connectorRepoBaseURL(mirror), artifactID)
+	versions, err := s.conditionalMavenFetch(ctx, client, url, parseMetadataVersions)
+	if err != nil {
+		if mirror != MirrorSourceApache {
+			return s.connectorHasVersion(ctx, client, artifactID, version, MirrorSourceApache)
+		}
+		if errors.Is(err, errMavenNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	for _, item := range versions {
+		if item == version {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// HasConnectorBuild reports whether a build of the connector exists for the SeaTunnel version:
+// a custom plugin registered for that version, or the version listed in the artifact's Maven metadata.
+// HasConnectorBuild 判断连接器是否存在该 SeaTunnel 版本的构建：
+// 已为该版本注册的自定义插件，或构件 Maven 元数据中列出了该版本。
+func (s *Service) HasConnectorBuild(ctx context.Context, pluginName, version string) (bool, error) {
+	if s.repo != nil {
+		custom, err := s.repo.ListCustomPlugins(ctx, version)
+		if err != nil {
+			return false, err
+		}
+		for _, record := range custom {
+			if record.Name == pluginName {
+				return true, nil
+			}
+		}
+	}
+	client := downloadx.NewClient(PluginFetchTimeout)
+	return s.connectorHasVersion(ctx, client, getArtifactID(pluginName), version, MirrorSourceApache)
+}
+
+// conditionalMavenFetch GETs url with the validators of the cached response and parses the body.
+// A 304 answer returns the cached values without downloading the document again.
+// conditionalMavenFetch 携带缓存响应的校验头请求 url 并解析响应体。
+// 返回 304 时直接使用缓存值，无需再次下载文档。
+func (s *Service) conditionalMavenFetch(ctx context.Context, client *http.Client, url string, parse func([]byte) ([]string, error)) ([]string, error) {
+	var cached *MavenMetadataCache
+	if s.repo != nil {
+		entry, err := s.repo.GetMavenMetadataCache(ctx, url)
+		if err != nil {
+			logger.WarnF(ctx, "[Plugin] 读取 Maven 缓存失败: url=%s, err=%v", url, err)
+		}
+		cached = entry
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	if cached != nil {
+		if cached.ETag != "" {
+			req.Header.Set("If-None-Match", cached.ETag)
+		}
+		if cached.LastModified != "" {
+			req.Header.Set("If-Modified-Since", cached.LastModified)
+		}
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified && cached != nil:
+		var values []string
+		if err := json.Unmarshal([]byte(cached.Entries), &values); err != nil {
+			return nil, fmt.Errorf("invalid cached values for %s: %w", url, err)
+		}
+		return values, nil
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, errMavenNotFound
+	case resp.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("unexpected status code %d from %s", resp.StatusCode, url)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	values, err := parse(body)
+	if err != nil {
+		return nil, err
+	}
+
+	etag, lastModified := resp.Header.Get("ETag"), resp.Header.Get("Last-Modified")
+	if s.repo != nil && (etag != "" || lastModified != "") {
+		encoded, _ := json.Marshal(values)
+		entry := &MavenMetadataCache{URL: url, ETag: etag, LastModified: lastModified, Entries: string(encoded), CheckedAt: time.Now()}
+		if err := s.repo.UpsertMavenMetadataCache(ctx, entry); err != nil {
+			logger.WarnF(ctx, "[Plugin] 写入 Maven 缓存失败: url=%s, err=%v", url, err)
+		}
+	}
+	return values, nil
+}
+
+// parseConnectorListing extracts connector artifact IDs from the group directory listing.
+// parseConnectorListing 从分组目录页面中提取连接器 artifact ID。
+func parseConnectorListing(body []byte) ([]string, error) {
+	var candidates []string
+	seen := make(map[string]bool)
+	for _, match := range connectorListingPattern.FindAllStringSubmatch(string(body), -1) {
+		artifactID := match[1]
+		if strings.Contains(artifactID, "-e2e") || isSkippedModule(artifactID) || seen[artifactID] {
+			continue
+		}
+		seen[artifactID] = true
+		candidates = append(candidates, artifactID)
+	}
+	return candidates, nil
+}
+
+// parseMetadataVersions returns the versions listed in a maven-metadata.xml document.
+// parseMetadataVersions 返回 maven-metadata.xml 文档中列出的版本。
+func parseMetadataVersions(body []byte) ([]string, error) {
+	var metadata mavenMetadata
+	if err := xml.Unmarshal(body, &metadata); err != nil {
+		return nil, fmt.Errorf("failed to parse maven metadata: %w", err)
+	}
+	return metadata.Versioning.Versions, nil
+}
@@ -0,0 +1,132 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestFetchConnectorsFromMirrorUsesMetadataAndConditionalRequests(t *testing.T) {
+	var mu sync.Mutex
+	served := map[string]int{}
+	notModified := 0
+	documents := map[string]string{
+		"/org/apache/seatunnel/": `<a href="connector-a/">connector-a/</a>
+<a href="connector-b/">connector-b/</a>
+<a href="connector-a-e2e/">connector-a-e2e/</a>`,
+		"/org/apache/seatunnel/connector-a/maven-metadata.xml": `<metadata><versioning><versions><version>2.3.11</version><version>2.3.12</version></versions></versioning></metadata>`,
+		"/org/apache/seatunnel/connector-b/maven-metadata.xml": `<metadata><versioning><versions><version>2.3.11</version></versions></versioning></metadata>`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := documents[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		etag := fmt.Sprintf(`"%d"`, len(body))
+		mu.Lock()
+		defer mu.Unlock()
+		if r.Header.Get("If-None-Match") == etag {
+			notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		served[r.URL.Path]++
+		w.Header().Set("ETag", etag)
+		_, _ = w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	const mirror MirrorSource = "maven-catalog-test"
+	MirrorURLs[mirror] = server.URL
+	defer delete(MirrorURLs, mirror)
+
+	service, _ := newTestPluginService(t)
+	ctx := context.Background()
+	for round := 1; round <= 2; round++ {
+		plugins, err := service.fetchConnectorsFromMirror(ctx, "2.3.12", mirror)
+		if err != nil {
+			t.Fatalf("round %d: fetchConnectorsFromMirror returned error: %v", round, err)
+		}
+		if len(plugins) != 1 || plugins[0].ArtifactID != "connector-a" {
+			t.Fatalf("round %d: expected only connector-a, got %+v", round, plugins)
+		}
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	for path, count := range served {
+		if count != 1 {
+			t.Fatalf("expected %s to be downloaded once, got %d", path, count)
+		}
+		if strings.Contains(path, "e2e") {
+			t.Fatalf("e2e module must not be checked: %s", path)
+		}
+	}
+	if notModified != 3 {
+		t.Fatalf("expected 3 conditional hits on the second scan, got %d", notModified)
+	}
+}
+
+func TestHasConnectorBuildChecksMetadataAndCustomPlugins(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/org/apache/seatunnel/connector-jdbc/maven-metadata.xml" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(`<metadata><versioning><versions><version>2.3.11</version></versions></versioning></metadata>`))
+	}))
+	defer server.Close()
+
+	apacheURL := MirrorURLs[MirrorSourceApache]
+	MirrorURLs[MirrorSourceApache] = server.URL
+	defer func() { MirrorURLs[MirrorSourceApache] = apacheURL }()
+
+	service, repo := newTestPluginService(t)
+	ctx := context.Background()
+	for _, tc := range []struct {
+		name    string
+		version string
+		want    bool
+	}{
+		{name: "jdbc", version: "2.3.11", want: true},
+		{name: "jdbc", version: "2.3.12", want: false},
+		{name: "unknown", version: "2.3.12", want: false},
+	} {
+		got, err := service.HasConnectorBuild(ctx, tc.name, tc.version)
+		if err != nil {
+			t.Fatalf("HasConnectorBuild(%s, %s) returned error: %v", tc.name, tc.version, err)
+		}
+		if got != tc.want {
+			t.Fatalf("HasConnectorBuild(%s, %s) = %v, want %v", tc.name, tc.version, got, tc.want)
+		}
+	}
+
+	if err := repo.UpsertCustomPlugin(ctx, &CustomPlugin{Name: "inhouse", SeatunnelVersion: "2.3.12", DisplayName: "In-house", ArtifactID: "connector-inhouse", GroupID: "com.example", Category: PluginCategoryConnector}); err != nil {
+		t.Fatalf("UpsertCustomPlugin returned error: %v", err)
+	}
+	if got, err := service.HasConnectorBuild(ctx, "inhouse", "2.3.12"); err != nil || !got {
+		t.Fatalf("expected custom plugin to count as a build, got %v, %v", got, err)
+	}
+}
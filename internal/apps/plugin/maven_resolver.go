@@ -0,0 +1,458 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package plugin
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/seatunnel/seatunnelX/internal/pkg/downloadx"
+)
+
+// DefaultMavenResolveDepth limits how deep transitive resolution walks the dependency tree.
+// DefaultMavenResolveDepth 限制传递依赖解析遍历依赖树的深度。
+const DefaultMavenResolveDepth = 6
+
+// maxParentChainDepth guards against cyclic or unreasonably deep parent POM chains.
+// maxParentChainDepth 防止父 POM 链循环或过深。
+const maxParentChainDepth = 10
+
+// runtimeProvidedGroupIDs lists groups already shipped by the SeaTunnel runtime and never copied into lib.
+// runtimeProvidedGroupIDs 列出 SeaTunnel 运行时已自带、无需复制到 lib 的 groupId。
+var runtimeProvidedGroupIDs = []string{"org.apache.seatunnel"}
+
+var pomPropertyPattern = regexp.MustCompile(`\$\{([^}]+)\}`)
+
+// MavenCoordinate identifies one Maven artifact.
+// MavenCoordinate 标识一个 Maven 构件。
+type MavenCoordinate struct {
+	GroupID    string `json:"group_id"`
+	ArtifactID string `json:"artifact_id"`
+	Version    string `json:"version"`
+}
+
+// String returns the coordinate in groupId:artifactId:version form.
+// String 以 groupId:artifactId:version 形式返回坐标。
+func (c MavenCoordinate) String() string {
+	return fmt.Sprintf("%s:%s:%s", c.GroupID, c.ArtifactID, c.Version)
+}
+
+func (c MavenCoordinate) key() string {
+	return c.GroupID + ":" + c.ArtifactID
+}
+
+// pomProject is the subset of a Maven POM needed for dependency resolution.
+// pomProject 是依赖解析所需的 Maven POM 子集。
+type pomProject struct {
+	GroupID              string           `xml:"groupId"`
+	ArtifactID           string           `xml:"artifactId"`
+	Version              string           `xml:"version"`
+	Parent               *pomParent       `xml:"parent"`
+	Properties           pomProperties    `xml:"properties"`
+	DependencyManagement pomDependencySet `xml:"dependencyManagement"`
+	Dependencies         []pomDependency  `xml:"dependencies>dependency"`
+}
+
+type pomParent struct {
+	GroupID    string `xml:"groupId"`
+	ArtifactID string `xml:"artifactId"`
+	Version    string `xml:"version"`
+}
+
+type pomDependencySet struct {
+	Dependencies []pomDependency `xml:"dependencies>dependency"`
+}
+
+type pomDependency struct {
+	GroupID    string         `xml:"groupId"`
+	ArtifactID string         `xml:"artifactId"`
+	Version    string         `xml:"version"`
+	Type       string         `xml:"type"`
+	Classifier string         `xml:"classifier"`
+	Scope      string         `xml:"scope"`
+	Optional   string         `xml:"optional"`
+	Exclusions []pomExclusion `xml:"exclusions>exclusion"`
+}
+
+type pomExclusion struct {
+	GroupID    string `xml:"groupId"`
+	ArtifactID string `xml:"artifactId"`
+}
+
+// pomProperties collects arbitrary <properties> children into a map.
+// pomProperties 将任意 <properties> 子元素收集为 map。
+type pomProperties map[string]string
+
+// UnmarshalXML implements xml.Unmarshaler.
+// UnmarshalXML 实现 xml.Unmarshaler。
+func (p *pomProperties) UnmarshalXML(decoder *xml.Decoder, start xml.StartElement) error {
+	props := make(pomProperties)
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+		switch element := token.(type) {
+		case xml.StartElement:
+			var value string
+			if err := decoder.DecodeElement(&value, &element); err != nil {
+				return err
+			}
+			props[element.Name.Local] = strings.TrimSpace(value)
+		case xml.EndElement:
+			*p = props
+			return nil
+		}
+	}
+}
+
+// effectivePOM is a POM merged with its parent chain.
+// effectivePOM 是与父 POM 链合并后的 POM。
+type effectivePOM struct {
+	coordinate   MavenCoordinate
+	properties   map[string]string
+	managed      map[string]string
+	dependencies []pomDependency
+}
+
+// MavenResolver resolves transitive runtime dependencies from Maven repository POMs.
+// MavenResolver 基于 Maven 仓库中的 POM 解析运行时传递依赖。
+type MavenResolver struct {
+	httpClient *http.Client
+	baseURLs   []string
+	maxDepth   int
+	poms       map[string]*pomProject
+	effective  map[string]*effectivePOM
+}
+
+// NewMavenResolver creates a resolver that queries the given mirror, falling back to Maven Central.
+// NewMavenResolver 创建一个查询指定镜像、并回退到 Maven Central 的解析器。
+func NewMavenResolver(mirror MirrorSource) *MavenResolver {
+	baseURLs := make([]string, 0, 2)
+	if base := MirrorURLs[mirror]; base != "" {
+		baseURLs = append(baseURLs, base)
+	}
+	if apacheBase := MirrorURLs[MirrorSourceApache]; len(baseURLs) == 0 || baseURLs[0] != apacheBase {
+		baseURLs = append(baseURLs, apacheBase)
+	}
+	return newMavenResolver(downloadx.NewClient(30*time.Second), baseURLs)
+}
+
+func newMavenResolver(httpClient *http.Client, baseURLs []string) *MavenResolver {
+	return &MavenResolver{
+		httpClient: httpClient,
+		baseURLs:   baseURLs,
+		maxDepth:   DefaultMavenResolveDepth,
+		poms:       make(map[string]*pomProject),
+		effective:  make(map[string]*effectivePOM),
+	}
+}
+
+// ResolveTransitive returns the runtime dependencies of root, excluding root itself.
+// Resolution is breadth-first with nearest-wins conflict handling, like Maven:
+// compile/runtime scopes are kept, optional/test/provided/system ones are dropped,
+// and exclusions declared on a path apply to everything below it.
+// ResolveTransitive 返回 root 的运行时依赖（不含 root 本身）。
+// 与 Maven 一致采用广度优先、就近优先的冲突处理：保留 compile/runtime 作用域，
+// 丢弃 optional/test/provided/system，路径上声明的排除项作用于其下所有依赖。
+func (r *MavenResolver) ResolveTransitive(ctx context.Context, root MavenCoordinate) ([]MavenCoordinate, error) {
+	rootPOM, err := r.loadEffectivePOM(ctx, root, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	type queued struct {
+		coordinate MavenCoordinate
+		depth      int
+		exclusions map[string]struct{}
+	}
+
+	seen := map[string]struct{}{root.key(): {}}
+	result := make([]MavenCoordinate, 0)
+	queue := []queued{{coordinate: root, depth: 0, exclusions: map[string]struct{}{}}}
+
+	for len(queue) > 0 {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
+
+		current := queue[0]
+		queue = queue[1:]
+		if current.depth >= r.maxDepth {
+			continue
+		}
+
+		pom, err := r.loadEffectivePOM(ctx, current.coordinate, 0)
+		if err != nil {
+			// Missing POMs for transitive artifacts should not fail the whole tree.
+			// 传递依赖缺少 POM 时不应导致整棵树解析失败。
+			if current.depth == 0 {
+				return nil, err
+			}
+			continue
+		}
+
+		for _, dep := range pom.dependencies {
+			if !isRuntimeDependency(dep) {
+				continue
+			}
+			groupID := pom.interpolate(dep.GroupID)
+			artifactID := pom.interpolate(dep.ArtifactID)
+			if groupID == "" || artifactID == "" || isRuntimeProvidedGroup(groupID) {
+				continue
+			}
+			depKey := groupID + ":" + artifactID
+			if _, excluded := current.exclusions[depKey]; excluded {
+				continue
+			}
+			if _, excluded := current.exclusions[groupID+":*"]; excluded {
+				continue
+			}
+			if _, ok := seen[depKey]; ok {
+				continue
+			}
+
+			// The root's dependencyManagement wins over the declaring POM, as in Maven.
+			// 与 Maven 一致，root 的 dependencyManagement 优先于声明方 POM。
+			version := rootPOM.managed[depKey]
+			if version == "" {
+				version = pom.interpolate(dep.Version)
+			}
+			if version == "" {
+				version = pom.managed[depKey]
+			}
+			version = normalizeMavenVersion(version)
+			if version == "" {
+				continue
+			}
+
+			seen[depKey] = struct{}{}
+			coordinate := MavenCoordinate{GroupID: groupID, ArtifactID: artifactID, Version: version}
+			result = append(result, coordinate)
+
+			childExclusions := make(map[string]struct{}, len(current.exclusions)+len(dep.Exclusions))
+			for key := range current.exclusions {
+				childExclusions[key] = struct{}{}
+			}
+			for _, exclusion := range dep.Exclusions {
+				childExclusions[strings.TrimSpace(exclusion.GroupID)+":"+strings.TrimSpace(exclusion.ArtifactID)] = struct{}{}
+			}
+			queue = append(queue, queued{coordinate: coordinate, depth: current.depth + 1, exclusions: childExclusions})
+		}
+	}
+
+	return result, nil
+}
+
+// loadEffectivePOM loads a POM and merges properties and dependencyManagement from its parents and imported BOMs.
+// loadEffectivePOM 加载 POM，并合并父 POM 与导入 BOM 中的属性和 dependencyManagement。
+func (r *MavenResolver) loadEffectivePOM(ctx context.Context, coordinate MavenCoordinate, depth int) (*effectivePOM, error) {
+	if cached, ok := r.effective[coordinate.String()]; ok {
+		return cached, nil
+	}
+	if depth > maxParentChainDepth {
+		return nil, fmt.Errorf("parent POM chain too deep at %s / 父 POM 链过深: %s", coordinate, coordinate)
+	}
+
+	pom, err := r.fetchPOM(ctx, coordinate)
+	if err != nil {
+		return nil, err
+	}
+
+	effective := &effectivePOM{
+		coordinate: coordinate,
+		properties: make(map[string]string),
+		managed:    make(map[string]string),
+	}
+
+	if pom.Parent != nil && pom.Parent.ArtifactID != "" {
+		parentCoordinate := MavenCoordinate{GroupID: pom.Parent.GroupID, ArtifactID: pom.Parent.ArtifactID, Version: pom.Parent.Version}
+		if parent, err := r.loadEffectivePOM(ctx, parentCoordinate, depth+1); err == nil {
+			for key, value := range parent.properties {
+				effective.properties[key] = value
+			}
+			for key, value := range parent.managed {
+				effective.managed[key] = value
+			}
+		}
+		effective.properties["project.parent.groupId"] = pom.Parent.GroupID
+		effective.properties["project.parent.version"] = pom.Parent.Version
+	}
+
+	for key, value := range pom.Properties {
+		effective.properties[key] = value
+	}
+	effective.properties["project.groupId"] = coordinate.GroupID
+	effective.properties["project.artifactId"] = coordinate.ArtifactID
+	effective.properties["project.version"] = coordinate.Version
+	effective.properties["pom.version"] = coordinate.Version
+
+	for _, managed := range pom.DependencyManagement.Dependencies {
+		groupID := effective.interpolate(managed.GroupID)
+		artifactID := effective.interpolate(managed.ArtifactID)
+		version := effective.interpolate(managed.Version)
+		if strings.EqualFold(managed.Scope, "import") && strings.EqualFold(managed.Type, "pom") {
+			bom, err := r.loadEffectivePOM(ctx, MavenCoordinate{GroupID: groupID, ArtifactID: artifactID, Version: version}, depth+1)
+			if err != nil {
+				continue
+			}
+			for key, value := range bom.managed {
+				if _, exists := effective.managed[key]; !exists {
+					effective.managed[key] = value
+				}
+			}
+			continue
+		}
+		if version != "" {
+			effective.managed[groupID+":"+artifactID] = version
+		}
+	}
+
+	effective.dependencies = pom.Dependencies
+	r.effective[coordinate.String()] = effective
+	return effective, nil
+}
+
+// fetchPOM downloads and parses one POM, trying each configured repository in order.
+// fetchPOM 下载并解析一个 POM，按顺序尝试每个已配置的仓库。
+func (r *MavenResolver) fetchPOM(ctx context.Context, coordinate MavenCoordinate) (*pomProject, error) {
+	if cached, ok := r.poms[coordinate.String()]; ok {
+		return cached, nil
+	}
+	if coordinate.GroupID == "" || coordinate.ArtifactID == "" || coordinate.Version == "" {
+		return nil, fmt.Errorf("incomplete maven coordinate %q / Maven 坐标不完整: %q", coordinate.String(), coordinate.String())
+	}
+
+	groupPath := strings.ReplaceAll(coordinate.GroupID, ".", "/")
+	pomName := fmt.Sprintf("%s-%s.pom", coordinate.ArtifactID, coordinate.Version)
+	var lastErr error
+	for _, baseURL := range r.baseURLs {
+		pomURL := fmt.Sprintf("%s/%s/%s/%s/%s", strings.TrimRight(baseURL, "/"), groupPath, coordinate.ArtifactID, coordinate.Version, pomName)
+		data, err := r.download(ctx, pomURL)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		var pom pomProject
+		if err := xml.Unmarshal(data, &pom); err != nil {
+			lastErr = fmt.Errorf("failed to parse POM %s: %w / 解析 POM 失败: %s", pomURL, err, pomURL)
+			continue
+		}
+		r.poms[coordinate.String()] = &pom
+		return &pom, nil
+	}
+	if lastErr == nil {
+		lastErr = ErrFileNotFound
+	}
+	return nil, fmt.Errorf("failed to fetch POM for %s: %w", coordinate, lastErr)
+}
+
+func (r *MavenResolver) download(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrFileNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: HTTP %d from %s", ErrDownloadFailed, resp.StatusCode, url)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// interpolate replaces ${...} placeholders with known properties; unresolved ones yield "".
+// interpolate 使用已知属性替换 ${...} 占位符；无法解析时返回空字符串。
+func (p *effectivePOM) interpolate(value string) string {
+	value = strings.TrimSpace(value)
+	for i := 0; i < 5 && strings.Contains(value, "${"); i++ {
+		value = pomPropertyPattern.ReplaceAllStringFunc(value, func(match string) string {
+			name := match[2 : len(match)-1]
+			if resolved, ok := p.properties[name]; ok {
+				return resolved
+			}
+			return match
+		})
+	}
+	if strings.Contains(value, "${") {
+		return ""
+	}
+	return value
+}
+
+// isRuntimeDependency reports whether dep ends up on the runtime classpath of a consumer.
+// isRuntimeDependency 判断 dep 是否会出现在使用方的运行时 classpath 中。
+func isRuntimeDependency(dep pomDependency) bool {
+	if strings.EqualFold(strings.TrimSpace(dep.Optional), "true") {
+		return false
+	}
+	if dep.Classifier != "" {
+		return false
+	}
+	switch strings.ToLower(strings.TrimSpace(dep.Type)) {
+	case "", "jar", "bundle":
+	default:
+		return false
+	}
+	switch strings.ToLower(strings.TrimSpace(dep.Scope)) {
+	case "", "compile", "runtime":
+		return true
+	default:
+		return false
+	}
+}
+
+func isRuntimeProvidedGroup(groupID string) bool {
+	for _, provided := range runtimeProvidedGroupIDs {
+		if groupID == provided || strings.HasPrefix(groupID, provided+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeMavenVersion turns simple version ranges into a concrete version.
+// A hard requirement like "[1.2.3]" becomes "1.2.3"; open ranges are not resolvable offline.
+// normalizeMavenVersion 将简单的版本区间转换为具体版本。
+// 形如 "[1.2.3]" 的固定版本转换为 "1.2.3"；开放区间无法离线解析。
+func normalizeMavenVersion(version string) string {
+	version = strings.TrimSpace(version)
+	if version == "" {
+		return ""
+	}
+	if !strings.ContainsAny(version, "[](),") {
+		return version
+	}
+	if strings.HasPrefix(version, "[") && strings.HasSuffix(version, "]") && !strings.Contains(version, ",") {
+		return strings.TrimSpace(version[1 : len(version)-1])
+	}
+	return ""
+}
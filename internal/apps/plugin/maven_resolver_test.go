@@ -0,0 +1,203 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package plugin
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// testMavenPOMs is a tiny repository:
+// hive-exec -> (parent hive-parent) hadoop-common, commons-lang (managed), junit(test), snappy(optional), seatunnel-api
+// hadoop-common -> guava (excluded by hive-exec), commons-lang 2.0 (loses to the nearer 2.6)
+var testMavenPOMs = map[string]string{
+	"/org/example/hive-parent/1.0/hive-parent-1.0.pom": `<project>
+  <groupId>org.example</groupId><artifactId>hive-parent</artifactId><version>1.0</version>
+  <properties><hadoop.version>3.1.0</hadoop.version></properties>
+  <dependencyManagement><dependencies>
+    <dependency><groupId>commons-lang</groupId><artifactId>commons-lang</artifactId><version>2.6</version></dependency>
+  </dependencies></dependencyManagement>
+</project>`,
+	"/org/example/hive-exec/2.3.9/hive-exec-2.3.9.pom": `<project>
+  <parent><groupId>org.example</groupId><artifactId>hive-parent</artifactId><version>1.0</version></parent>
+  <artifactId>hive-exec</artifactId>
+  <dependencies>
+    <dependency>
+      <groupId>org.example</groupId><artifactId>hadoop-common</artifactId><version>${hadoop.version}</version>
+      <exclusions><exclusion><groupId>com.google.guava</groupId><artifactId>guava</artifactId></exclusion></exclusions>
+    </dependency>
+    <dependency><groupId>commons-lang</groupId><artifactId>commons-lang</artifactId></dependency>
+    <dependency><groupId>junit</groupId><artifactId>junit</artifactId><version>4.13</version><scope>test</scope></dependency>
+    <dependency><groupId>org.xerial</groupId><artifactId>snappy</artifactId><version>1.1</version><optional>true</optional></dependency>
+    <dependency><groupId>org.apache.seatunnel</groupId><artifactId>seatunnel-api</artifactId><version>2.3.12</version></dependency>
+  </dependencies>
+</project>`,
+	"/org/example/hadoop-common/3.1.0/hadoop-common-3.1.0.pom": `<project>
+  <groupId>org.example</groupId><artifactId>hadoop-common</artifactId><version>3.1.0</version>
+  <dependencies>
+    <dependency><groupId>com.google.guava</groupId><artifactId>guava</artifactId><version>27.0</version></dependency>
+    <dependency><groupId>commons-lang</groupId><artifactId>commons-lang</artifactId><version>2.0</version></dependency>
+    <dependency><groupId>org.example</groupId><artifactId>hadoop-auth</artifactId><version>${project.version}</version><scope>runtime</scope></dependency>
+  </dependencies>
+</project>`,
+	"/commons-lang/commons-lang/2.6/commons-lang-2.6.pom": `<project><groupId>commons-lang</groupId><artifactId>commons-lang</artifactId><version>2.6</version></project>`,
+}
+
+func newTestMavenRepository(t *testing.T) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := testMavenPOMs[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestMavenResolverResolvesRuntimeDependencies(t *testing.T) {
+	server := newTestMavenRepository(t)
+	resolver := newMavenResolver(server.Client(), []string{server.URL})
+
+	resolved, err := resolver.ResolveTransitive(context.Background(), MavenCoordinate{GroupID: "org.example", ArtifactID: "hive-exec", Version: "2.3.9"})
+	if err != nil {
+		t.Fatalf("ResolveTransitive returned error: %v", err)
+	}
+
+	got := make([]string, 0, len(resolved))
+	for _, coordinate := range resolved {
+		got = append(got, coordinate.String())
+	}
+	want := []string{
+		"org.example:hadoop-common:3.1.0",
+		"commons-lang:commons-lang:2.6",
+		"org.example:hadoop-auth:3.1.0",
+	}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("unexpected resolution:\n got: %v\nwant: %v", got, want)
+	}
+}
+
+func TestMavenResolverFailsWhenRootPOMMissing(t *testing.T) {
+	server := newTestMavenRepository(t)
+	resolver := newMavenResolver(server.Client(), []string{server.URL})
+
+	if _, err := resolver.ResolveTransitive(context.Background(), MavenCoordinate{GroupID: "org.example", ArtifactID: "missing", Version: "1.0"}); err == nil {
+		t.Fatal("expected error for missing root POM")
+	}
+}
+
+func TestNormalizeMavenVersion(t *testing.T) {
+	cases := map[string]string{
+		"1.2.3":     "1.2.3",
+		"[1.2.3]":   "1.2.3",
+		"[1.0,2.0)": "",
+		"(,1.0]":    "",
+		"  2.0.1  ": "2.0.1",
+		"":          "",
+	}
+	for input, want := range cases {
+		if got := normalizeMavenVersion(input); got != want {
+			t.Errorf("normalizeMavenVersion(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestAddDependencyResolvesTransitiveAndDeleteCascades(t *testing.T) {
+	server := newTestMavenRepository(t)
+	originalApache := MirrorURLs[MirrorSourceApache]
+	MirrorURLs[MirrorSourceApache] = server.URL
+	t.Cleanup(func() { MirrorURLs[MirrorSourceApache] = originalApache })
+
+	service, repo := newTestPluginService(t)
+	ctx := context.Background()
+
+	root, err := service.AddDependency(ctx, &AddDependencyRequest{
+		PluginName:        "hive",
+		SeatunnelVersion:  "2.3.12",
+		GroupID:           "org.example",
+		ArtifactID:        "hive-exec",
+		Version:           "2.3.9",
+		TargetDir:         "lib",
+		ResolveTransitive: true,
+		Mirror:            MirrorSourceApache,
+	})
+	if err != nil {
+		t.Fatalf("AddDependency returned error: %v", err)
+	}
+
+	deps, err := repo.ListDependencies(ctx, "hive", "2.3.12")
+	if err != nil {
+		t.Fatalf("ListDependencies returned error: %v", err)
+	}
+	if len(deps) != 4 {
+		t.Fatalf("expected root plus 3 transitive dependencies, got %d", len(deps))
+	}
+	for _, dep := range deps {
+		if dep.ID == root.ID {
+			continue
+		}
+		if dep.ResolvedFrom != "org.example:hive-exec:2.3.9" || dep.TargetDir != "lib" {
+			t.Fatalf("unexpected transitive dependency %+v", dep)
+		}
+	}
+
+	if err := service.DeleteDependency(ctx, root.ID); err != nil {
+		t.Fatalf("DeleteDependency returned error: %v", err)
+	}
+	deps, err = repo.ListDependencies(ctx, "hive", "2.3.12")
+	if err != nil {
+		t.Fatalf("ListDependencies returned error: %v", err)
+	}
+	if len(deps) != 0 {
+		t.Fatalf("expected transitive dependencies to be deleted with their root, got %d", len(deps))
+	}
+}
+
+func TestInstalledPluginPersistsDependencies(t *testing.T) {
+	_, repo := newTestPluginService(t)
+	ctx := context.Background()
+
+	installed := &InstalledPlugin{
+		ClusterID:  1,
+		PluginName: "hive",
+		ArtifactID: "connector-hive",
+		Category:   PluginCategoryConnector,
+		Version:    "2.3.12",
+		Status:     PluginStatusInstalled,
+		Dependencies: InstalledPluginDependencies{
+			{GroupID: "org.example", ArtifactID: "hive-exec", Version: "2.3.9", TargetDir: "lib"},
+		},
+	}
+	if err := repo.Create(ctx, installed); err != nil {
+		t.Fatalf("Create returned error: %v", err)
+	}
+
+	stored, err := repo.GetByClusterAndName(ctx, 1, "hive")
+	if err != nil {
+		t.Fatalf("GetByClusterAndName returned error: %v", err)
+	}
+	if len(stored.Dependencies) != 1 || stored.Dependencies[0].ArtifactID != "hive-exec" {
+		t.Fatalf("expected persisted dependency, got %+v", stored.Dependencies)
+	}
+}
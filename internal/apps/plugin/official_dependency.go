@@ -30,6 +30,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/seatunnel/seatunnelX/internal/pkg/downloadx"
 	"github.com/seatunnel/seatunnelX/internal/seatunnel"
 )
 
@@ -174,7 +175,7 @@ func (s *Service) persistPluginCatalog(ctx context.Context, version string, plug
 			RefreshedAt:      &refreshedAt,
 		})
 	}
-	if source == PluginCatalogSourceRemote {
+	if source == PluginCatalogSourceRemote || source == PluginCatalogSourceIndex {
 		return s.repo.ReplaceCatalogEntriesByVersion(ctx, version, entries)
 	}
 	return s.repo.UpsertCatalogEntries(ctx, entries)
@@ -325,6 +326,7 @@ func (s *Service) getUserConfiguredDependencies(ctx context.Context, pluginName,
 			SourceType:       cfg.SourceType,
 			OriginalFileName: cfg.OriginalFileName,
 			StoredPath:       cfg.StoredPath,
+			ResolvedFrom:     cfg.ResolvedFrom,
 		})
 	}
 	return deps, nil
@@ -704,7 +706,7 @@ func (s *Service) buildProfileFromOfficialDoc(ctx context.Context, version strin
 
 func (s *Service) fetchOfficialDocMarkdown(ctx context.Context, version, docSlug string) (string, error) {
 	url := fmt.Sprintf(officialDocsRawBaseURL, version, docSlug)
-	client := &http.Client{Timeout: 30 * time.Second}
+	client := downloadx.NewClient(30 * time.Second)
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return "", err
@@ -891,7 +893,7 @@ func extractSupportedVersion(markdown string) string {
 func (s *Service) resolveLatestMavenVersion(ctx context.Context, groupID, artifactID string) (string, error) {
 	groupPath := strings.ReplaceAll(groupID, ".", "/")
 	url := fmt.Sprintf("%s/%s/%s/maven-metadata.xml", MirrorURLs[MirrorSourceApache], groupPath, artifactID)
-	client := &http.Client{Timeout: 15 * time.Second}
+	client := downloadx.NewClient(15 * time.Second)
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return "", err
@@ -0,0 +1,274 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/seatunnel/seatunnelX/internal/config"
+	"github.com/seatunnel/seatunnelX/internal/logger"
+)
+
+// PluginIndexSchemaVersion is the plugin index format understood by this build.
+// PluginIndexSchemaVersion 是当前版本可识别的插件索引格式版本。
+const PluginIndexSchemaVersion = 1
+
+const (
+	bundledPluginIndexPath = "seed/plugin-index.json"
+	pluginIndexFileName    = "plugin-index.json"
+)
+
+// ErrInvalidPluginIndex is returned when a plugin index cannot be parsed or validated.
+// ErrInvalidPluginIndex 表示插件索引无法解析或校验失败。
+var ErrInvalidPluginIndex = errors.New("invalid plugin index / 无效的插件索引")
+
+// PluginIndexOrigin tells where the active plugin index was loaded from.
+// PluginIndexOrigin 表示当前生效插件索引的来源。
+type PluginIndexOrigin string
+
+const (
+	PluginIndexOriginBundled   PluginIndexOrigin = "bundled"   // 随程序发布 / Shipped with the binary
+	PluginIndexOriginRefreshed PluginIndexOrigin = "refreshed" // 刷新后保存在插件目录 / Refreshed into the plugins dir
+)
+
+// PluginIndex is the offline connector manifest shipped with releases.
+// PluginIndex 是随版本发布的离线连接器清单。
+type PluginIndex struct {
+	SchemaVersion int                           `json:"schema_version"`
+	GeneratedAt   time.Time                     `json:"generated_at"`
+	Versions      map[string][]PluginIndexEntry `json:"versions"` // key: SeaTunnel version
+}
+
+// PluginIndexEntry describes one connector of a SeaTunnel version.
+// PluginIndexEntry 描述某个 SeaTunnel 版本的一个连接器。
+type PluginIndexEntry struct {
+	Name        string         `json:"name"`
+	DisplayName string         `json:"display_name,omitempty"`
+	ArtifactID  string         `json:"artifact_id"`
+	GroupID     string         `json:"group_id,omitempty"`
+	Category    PluginCategory `json:"category,omitempty"`
+	Description string         `json:"description,omitempty"`
+	DocURL      string         `json:"doc_url,omitempty"`
+}
+
+// PluginIndexInfo summarizes the active plugin index.
+// PluginIndexInfo 汇总当前生效的插件索引。
+type PluginIndexInfo struct {
+	Origin        PluginIndexOrigin `json:"origin"`
+	SchemaVersion int               `json:"schema_version"`
+	GeneratedAt   time.Time         `json:"generated_at"`
+	Versions      []string          `json:"versions"`
+	PluginCount   int               `json:"plugin_count"`
+	SourceURL     string            `json:"source_url"`
+}
+
+// parsePluginIndex decodes and validates a plugin index document.
+// parsePluginIndex 解析并校验插件索引文档。
+func parsePluginIndex(content []byte) (*PluginIndex, error) {
+	var index PluginIndex
+	if err := json.Unmarshal(content, &index); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPluginIndex, err)
+	}
+	if index.SchemaVersion < 1 || index.SchemaVersion > PluginIndexSchemaVersion {
+		return nil, fmt.Errorf("%w: unsupported schema_version %d", ErrInvalidPluginIndex, index.SchemaVersion)
+	}
+	if len(index.Versions) == 0 {
+		return nil, fmt.Errorf("%w: no versions", ErrInvalidPluginIndex)
+	}
+	for version, entries := range index.Versions {
+		if strings.TrimSpace(version) == "" {
+			return nil, fmt.Errorf("%w: empty version key", ErrInvalidPluginIndex)
+		}
+		seen := make(map[string]struct{}, len(entries))
+		for i, entry := range entries {
+			if strings.TrimSpace(entry.Name) == "" || strings.TrimSpace(entry.ArtifactID) == "" {
+				return nil, fmt.Errorf("%w: entry %d of %s needs name and artifact_id", ErrInvalidPluginIndex, i, version)
+			}
+			if _, dup := seen[entry.Name]; dup {
+				return nil, fmt.Errorf("%w: duplicate plugin %s in %s", ErrInvalidPluginIndex, entry.Name, version)
+			}
+			seen[entry.Name] = struct{}{}
+		}
+	}
+	return &index, nil
+}
+
+// loadBundledPluginIndex reads the index embedded in the binary.
+// loadBundledPluginIndex 读取内置在程序中的插件索引。
+func loadBundledPluginIndex() (*PluginIndex, error) {
+	content, err := officialDependencySeedFS.ReadFile(bundledPluginIndexPath)
+	if err != nil {
+		return nil, err
+	}
+	return parsePluginIndex(content)
+}
+
+func (s *Service) pluginIndexPath() string {
+	return filepath.Join(s.downloader.GetPluginsDir(), pluginIndexFileName)
+}
+
+// activePluginIndex returns the refreshed index when it is at least as new as the bundled one.
+// activePluginIndex 在刷新后的索引不旧于内置索引时优先返回刷新后的索引。
+func (s *Service) activePluginIndex() (*PluginIndex, PluginIndexOrigin) {
+	s.indexMu.Lock()
+	defer s.indexMu.Unlock()
+	if s.index != nil {
+		return s.index, s.indexOrigin
+	}
+
+	bundled, err := loadBundledPluginIndex()
+	if err != nil {
+		logger.WarnF(context.Background(), "[Plugin] 读取内置插件索引失败: %v", err)
+	}
+	s.index, s.indexOrigin = bundled, PluginIndexOriginBundled
+
+	if content, err := os.ReadFile(s.pluginIndexPath()); err == nil {
+		refreshed, parseErr := parsePluginIndex(content)
+		switch {
+		case parseErr != nil:
+			logger.WarnF(context.Background(), "[Plugin] 忽略无效的本地插件索引 %s: %v", s.pluginIndexPath(), parseErr)
+		case bundled == nil || !refreshed.GeneratedAt.Before(bundled.GeneratedAt):
+			s.index, s.indexOrigin = mergePluginIndexes(refreshed, bundled), PluginIndexOriginRefreshed
+		}
+	}
+	return s.index, s.indexOrigin
+}
+
+// mergePluginIndexes keeps versions of the fallback index that the primary one does not cover.
+// mergePluginIndexes 保留 primary 索引未覆盖的 fallback 索引版本。
+func mergePluginIndexes(primary, fallback *PluginIndex) *PluginIndex {
+	if fallback == nil {
+		return primary
+	}
+	for version, entries := range fallback.Versions {
+		if _, ok := primary.Versions[version]; !ok {
+			primary.Versions[version] = entries
+		}
+	}
+	return primary
+}
+
+// pluginsFromIndex lists the indexed connectors of a version, or nil when the version is not indexed.
+// pluginsFromIndex 返回索引中某版本的连接器，版本未收录时返回 nil。
+func (s *Service) pluginsFromIndex(version string) []Plugin {
+	index, _ := s.activePluginIndex()
+	if index == nil {
+		return nil
+	}
+	entries := index.Versions[version]
+	if len(entries) == 0 {
+		return nil
+	}
+	plugins := make([]Plugin, 0, len(entries))
+	for _, entry := range entries {
+		plugin := s.createPluginFromArtifactID(entry.ArtifactID, version)
+		plugin.Name = entry.Name
+		if entry.DisplayName != "" {
+			plugin.DisplayName = entry.DisplayName
+		}
+		if entry.GroupID != "" {
+			plugin.GroupID = entry.GroupID
+		}
+		if entry.Category != "" {
+			plugin.Category = entry.Category
+		}
+		if entry.Description != "" {
+			plugin.Description = entry.Description
+		}
+		if entry.DocURL != "" {
+			plugin.DocURL = entry.DocURL
+		}
+		plugins = append(plugins, plugin)
+	}
+	return s.filterHiddenPluginsForVersion(version, plugins)
+}
+
+// GetPluginIndexInfo describes the plugin index currently in use.
+// GetPluginIndexInfo 描述当前使用的插件索引。
+func (s *Service) GetPluginIndexInfo() *PluginIndexInfo {
+	index, origin := s.activePluginIndex()
+	info := &PluginIndexInfo{Origin: origin, Versions: []string{}, SourceURL: config.GetPluginIndexURL()}
+	if index == nil {
+		return info
+	}
+	info.SchemaVersion = index.SchemaVersion
+	info.GeneratedAt = index.GeneratedAt
+	for version, entries := range index.Versions {
+		info.Versions = append(info.Versions, version)
+		info.PluginCount += len(entries)
+	}
+	sort.Strings(info.Versions)
+	return info
+}
+
+// RefreshPluginIndex replaces the local plugin index with the given document, or downloads it
+// from storage.plugin_index_url when content is empty, and rebuilds the catalog of every indexed version.
+// RefreshPluginIndex 使用给定文档替换本地插件索引（content 为空时从 storage.plugin_index_url 下载），
+// 并重建索引中每个版本的插件目录。
+func (s *Service) RefreshPluginIndex(ctx context.Context, content []byte) (*PluginIndexInfo, error) {
+	if len(content) == 0 {
+		downloaded, err := s.downloader.fetchSmallFile(ctx, config.GetPluginIndexURL())
+		if err != nil {
+			return nil, fmt.Errorf("failed to download plugin index: %w / 下载插件索引失败", err)
+		}
+		content = downloaded
+	}
+	index, err := parsePluginIndex(content)
+	if err != nil {
+		return nil, err
+	}
+
+	path := s.pluginIndexPath()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create plugins directory: %w", err)
+	}
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, content, 0644); err != nil {
+		return nil, fmt.Errorf("failed to write plugin index: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		_ = os.Remove(tmpPath)
+		return nil, fmt.Errorf("failed to write plugin index: %w", err)
+	}
+
+	bundled, _ := loadBundledPluginIndex()
+	s.indexMu.Lock()
+	s.index, s.indexOrigin = mergePluginIndexes(index, bundled), PluginIndexOriginRefreshed
+	s.indexMu.Unlock()
+
+	refreshedAt := time.Now()
+	for version := range index.Versions {
+		plugins := s.pluginsFromIndex(version)
+		if err := s.persistPluginCatalog(ctx, version, plugins, PluginCatalogSourceIndex, "", refreshedAt); err != nil {
+			logger.WarnF(ctx, "[Plugin] 刷新插件索引后持久化目录失败: version=%s, err=%v", version, err)
+		}
+		s.pluginsMu.Lock()
+		delete(s.cachedPlugins, version)
+		delete(s.pluginsCacheTime, version)
+		s.pluginsMu.Unlock()
+	}
+	return s.GetPluginIndexInfo(), nil
+}
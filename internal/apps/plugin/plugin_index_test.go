@@ -0,0 +1,114 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package plugin
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+)
+
+func TestListAvailablePluginsUsesBundledIndexBeforeMaven(t *testing.T) {
+	service, _ := newTestPluginServiceWithDownloader(t, t.TempDir())
+	ctx := context.Background()
+	service.SetPluginFetcher(func(ctx context.Context, version string, mirror MirrorSource) ([]Plugin, MirrorSource, error) {
+		t.Fatalf("indexed version %s must not be fetched from Maven", version)
+		return nil, mirror, nil
+	})
+
+	result, err := service.ListAvailablePlugins(ctx, "2.3.12", MirrorSourceApache)
+	if err != nil {
+		t.Fatalf("ListAvailablePlugins returned error: %v", err)
+	}
+	if result.Source != PluginListSourceIndex {
+		t.Fatalf("expected source=index, got %q", result.Source)
+	}
+	names := make(map[string]Plugin, len(result.Plugins))
+	for _, item := range result.Plugins {
+		names[item.Name] = item
+	}
+	if names["jdbc"].ArtifactID != "connector-jdbc" {
+		t.Fatalf("expected jdbc from bundled index, got %+v", names["jdbc"])
+	}
+	if _, hidden := names["file-base"]; hidden {
+		t.Fatalf("expected hidden plugin to be filtered from the index")
+	}
+
+	// The second call is served from the persisted catalog / 第二次调用使用已持久化的目录
+	again, err := service.ListAvailablePlugins(ctx, "2.3.12", MirrorSourceApache)
+	if err != nil {
+		t.Fatalf("ListAvailablePlugins returned error: %v", err)
+	}
+	if again.Source != PluginListSourceDatabase || again.Total != result.Total {
+		t.Fatalf("expected persisted index catalog, got source=%q total=%d", again.Source, again.Total)
+	}
+}
+
+func TestRefreshPluginIndexReplacesCatalog(t *testing.T) {
+	pluginsDir := t.TempDir()
+	service, _ := newTestPluginServiceWithDownloader(t, pluginsDir)
+	ctx := context.Background()
+
+	if _, err := service.ListAvailablePlugins(ctx, "2.3.12", MirrorSourceApache); err != nil {
+		t.Fatalf("ListAvailablePlugins returned error: %v", err)
+	}
+
+	if _, err := service.RefreshPluginIndex(ctx, []byte(`{"schema_version": 99, "versions": {}}`)); !errors.Is(err, ErrInvalidPluginIndex) {
+		t.Fatalf("expected ErrInvalidPluginIndex, got %v", err)
+	}
+
+	index := []byte(`{
+  "schema_version": 1,
+  "generated_at": "2099-01-01T00:00:00Z",
+  "versions": {
+    "2.3.12": [
+      {"name": "jdbc", "artifact_id": "connector-jdbc", "display_name": "JDBC"},
+      {"name": "acme", "artifact_id": "connector-acme"}
+    ]
+  }
+}`)
+	info, err := service.RefreshPluginIndex(ctx, index)
+	if err != nil {
+		t.Fatalf("RefreshPluginIndex returned error: %v", err)
+	}
+	if info.Origin != PluginIndexOriginRefreshed || info.PluginCount != 2 {
+		t.Fatalf("unexpected index info: %+v", info)
+	}
+	if _, err := os.Stat(service.pluginIndexPath()); err != nil {
+		t.Fatalf("expected refreshed index to be stored: %v", err)
+	}
+
+	result, err := service.ListAvailablePlugins(ctx, "2.3.12", MirrorSourceApache)
+	if err != nil {
+		t.Fatalf("ListAvailablePlugins returned error: %v", err)
+	}
+	if result.Total != 2 {
+		t.Fatalf("expected catalog rebuilt from refreshed index, got %d plugins", result.Total)
+	}
+
+	// A new service instance picks the refreshed file over the older bundled index.
+	// 新的服务实例会优先使用比内置索引更新的刷新文件。
+	reloaded, _ := newTestPluginServiceWithDownloader(t, pluginsDir)
+	if got := reloaded.GetPluginIndexInfo(); got.Origin != PluginIndexOriginRefreshed {
+		t.Fatalf("expected refreshed index after reload, got %q", got.Origin)
+	}
+	if plugins := reloaded.pluginsFromIndex("2.3.12"); len(plugins) != 2 || plugins[0].DisplayName != "JDBC" {
+		t.Fatalf("unexpected plugins from refreshed index: %+v", plugins)
+	}
+}
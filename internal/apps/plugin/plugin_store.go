@@ -0,0 +1,134 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package plugin
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/seatunnel/seatunnelX/internal/logger"
+	"github.com/seatunnel/seatunnelX/internal/pkg/objstore"
+)
+
+// SetPluginStore sets the object store shared by all replicas. Downloaded plugins are mirrored into it
+// and plugins another replica downloaded are fetched from it before falling back to Maven.
+// SetPluginStore 设置所有副本共享的对象存储。已下载的插件会同步到其中，其他副本下载的插件会先从中获取再回退到 Maven。
+func (s *Service) SetPluginStore(store objstore.Store) {
+	s.downloader.store = store
+}
+
+// fetchVersionFromStore copies the plugin files of a SeaTunnel version kept in the shared store into the plugins directory.
+// fetchVersionFromStore 将共享存储中某 SeaTunnel 版本的插件文件复制到插件目录。
+func (d *Downloader) fetchVersionFromStore(ctx context.Context, version string) {
+	if d.store == nil || strings.TrimSpace(version) == "" {
+		return
+	}
+	written, err := objstore.DownloadDir(ctx, d.store, version, filepath.Join(d.pluginsDir, version))
+	if err != nil {
+		logger.WarnF(ctx, "[Plugin] 从共享存储获取插件失败 / Failed to fetch plugins from shared store: version=%s, err=%v", version, err)
+		return
+	}
+	if written > 0 {
+		logger.InfoF(ctx, "[Plugin] 已从共享存储获取插件文件 / Plugin files fetched from shared store: version=%s, files=%d", version, written)
+	}
+}
+
+// fetchAllFromStore copies every plugin file kept in the shared store into the plugins directory.
+// fetchAllFromStore 将共享存储中的所有插件文件复制到插件目录。
+func (d *Downloader) fetchAllFromStore(ctx context.Context) {
+	if d.store == nil {
+		return
+	}
+	if _, err := objstore.DownloadDir(ctx, d.store, "", d.pluginsDir); err != nil {
+		logger.WarnF(ctx, "[Plugin] 从共享存储获取插件失败 / Failed to fetch plugins from shared store: %v", err)
+	}
+}
+
+// mirrorVersionToStore copies the plugin files of a SeaTunnel version into the shared store.
+// mirrorVersionToStore 将某 SeaTunnel 版本的插件文件复制到共享存储。
+func (d *Downloader) mirrorVersionToStore(ctx context.Context, version string) {
+	if d.store == nil || strings.TrimSpace(version) == "" {
+		return
+	}
+	if err := objstore.UploadDir(ctx, d.store, version, filepath.Join(d.pluginsDir, version)); err != nil {
+		logger.WarnF(ctx, "[Plugin] 同步插件到共享存储失败 / Failed to mirror plugins to shared store: version=%s, err=%v", version, err)
+	}
+}
+
+// storeKey maps a file below the plugins directory to its key in the shared store.
+// storeKey 将插件目录下的文件映射为共享存储中的 key。
+func (d *Downloader) storeKey(localPath string) (string, bool) {
+	rel, err := filepath.Rel(d.pluginsDir, localPath)
+	if err != nil || rel == "." || strings.HasPrefix(rel, "..") {
+		return "", false
+	}
+	return filepath.ToSlash(rel), true
+}
+
+// mirrorFileToStore copies one file below the plugins directory into the shared store.
+// mirrorFileToStore 将插件目录下的单个文件复制到共享存储。
+func (d *Downloader) mirrorFileToStore(ctx context.Context, localPath string) {
+	if d.store == nil {
+		return
+	}
+	key, ok := d.storeKey(localPath)
+	if !ok {
+		return
+	}
+	if err := objstore.UploadFile(ctx, d.store, key, localPath); err != nil {
+		logger.WarnF(ctx, "[Plugin] 同步文件到共享存储失败 / Failed to mirror file to shared store: key=%s, err=%v", key, err)
+	}
+}
+
+// fetchFileFromStore copies one file missing below the plugins directory from the shared store.
+// fetchFileFromStore 从共享存储获取插件目录下缺失的单个文件。
+func (d *Downloader) fetchFileFromStore(ctx context.Context, localPath string) {
+	if d.store == nil {
+		return
+	}
+	if _, err := os.Stat(localPath); err == nil {
+		return
+	}
+	key, ok := d.storeKey(localPath)
+	if !ok {
+		return
+	}
+	if err := objstore.DownloadFile(ctx, d.store, key, localPath); err != nil && !errors.Is(err, objstore.ErrNotFound) {
+		logger.WarnF(ctx, "[Plugin] 从共享存储获取文件失败 / Failed to fetch file from shared store: key=%s, err=%v", key, err)
+	}
+}
+
+// removeFromStore deletes files below the plugins directory from the shared store, so other replicas do not fetch them again.
+// removeFromStore 从共享存储删除插件目录下的文件，避免其他副本再次获取。
+func (d *Downloader) removeFromStore(ctx context.Context, localPaths ...string) {
+	if d.store == nil {
+		return
+	}
+	for _, localPath := range localPaths {
+		key, ok := d.storeKey(localPath)
+		if !ok {
+			continue
+		}
+		if err := d.store.Delete(ctx, key); err != nil {
+			logger.WarnF(ctx, "[Plugin] 从共享存储删除文件失败 / Failed to delete file from shared store: key=%s, err=%v", key, err)
+		}
+	}
+}
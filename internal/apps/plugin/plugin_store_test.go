@@ -0,0 +1,66 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package plugin
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/seatunnel/seatunnelX/internal/pkg/objstore"
+)
+
+func TestDownloaderSharesPluginsThroughStore(t *testing.T) {
+	ctx := context.Background()
+	store := objstore.Sub(objstore.NewLocalStore(t.TempDir()), "plugins")
+	replicaA := NewDownloader(t.TempDir())
+	replicaA.store = store
+	replicaB := NewDownloader(t.TempDir())
+	replicaB.store = store
+
+	jarPath := replicaA.GetConnectorPathByName("jdbc", "2.3.12")
+	if err := os.MkdirAll(filepath.Dir(jarPath), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(jarPath, []byte("connector"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	replicaA.mirrorVersionToStore(ctx, "2.3.12")
+
+	if replicaB.IsConnectorDownloaded("jdbc", "2.3.12") {
+		t.Fatalf("connector present on second replica before fetching")
+	}
+	replicaB.fetchVersionFromStore(ctx, "2.3.12")
+	if !replicaB.IsConnectorDownloaded("jdbc", "2.3.12") {
+		t.Fatalf("connector not fetched from the store")
+	}
+	replicaB.fetchVersionFromStore(ctx, "2.3.1")
+	if replicaB.IsConnectorDownloaded("jdbc", "2.3.1") {
+		t.Fatalf("connector of another version fetched")
+	}
+
+	if err := replicaB.DeleteLocalPlugin("jdbc", "2.3.12"); err != nil {
+		t.Fatalf("DeleteLocalPlugin: %v", err)
+	}
+	key, _ := replicaA.storeKey(jarPath)
+	if _, err := store.Stat(ctx, key); !errors.Is(err, objstore.ErrNotFound) {
+		t.Fatalf("store still holds deleted connector: %v", err)
+	}
+}
@@ -20,14 +20,17 @@ package plugin
 import (
 	"context"
 	"errors"
+	"strings"
 
+	"github.com/seatunnel/seatunnelX/internal/pkg/pagex"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
 
 // Common errors / 常见错误
 var (
-	ErrPluginNotFound = errors.New("plugin not found / 插件未找到")
+	ErrPluginNotFound       = errors.New("plugin not found / 插件未找到")
+	ErrCustomPluginNotFound = errors.New("custom plugin not found / 自定义插件未找到")
 )
 
 // Repository provides data access for installed plugins.
@@ -98,6 +101,7 @@ func (r *Repository) List(ctx context.Context, filter *PluginFilter) ([]Installe
 		if filter.Keyword != "" {
 			query = query.Where("plugin_name LIKE ?", "%"+filter.Keyword+"%")
 		}
+		query = filter.InstalledAt.Apply(query, "installed_at")
 	}
 
 	// Count total / 统计总数
@@ -105,17 +109,16 @@ func (r *Repository) List(ctx context.Context, filter *PluginFilter) ([]Installe
 		return nil, 0, err
 	}
 
-	// Apply pagination / 应用分页
-	if filter != nil && filter.PageSize > 0 {
-		offset := 0
-		if filter.Page > 1 {
-			offset = (filter.Page - 1) * filter.PageSize
-		}
-		query = query.Offset(offset).Limit(filter.PageSize)
+	// Apply sorting and pagination / 应用排序和分页
+	var sort []pagex.SortField
+	if filter != nil {
+		sort = filter.Sort
+		query = pagex.Paginate(query, filter.Page, filter.PageSize)
 	}
+	query = pagex.Order(query, sort, "installed_at DESC")
 
 	// Execute query / 执行查询
-	if err := query.Order("installed_at DESC").Find(&plugins).Error; err != nil {
+	if err := query.Find(&plugins).Error; err != nil {
 		return nil, 0, err
 	}
 
@@ -178,6 +181,15 @@ func (r *Repository) DeleteByClusterAndName(ctx context.Context, clusterID uint,
 	return nil
 }
 
+// DeleteByCluster deletes all installed plugin records of a cluster and returns how many were deleted.
+// DeleteByCluster 删除集群的全部已安装插件记录，并返回删除数量。
+func (r *Repository) DeleteByCluster(ctx context.Context, clusterID uint) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Where("cluster_id = ?", clusterID).
+		Delete(&InstalledPlugin{})
+	return result.RowsAffected, result.Error
+}
+
 // ExistsByClusterAndName checks if a plugin is installed on a cluster.
 // ExistsByClusterAndName 检查插件是否已安装在集群上。
 func (r *Repository) ExistsByClusterAndName(ctx context.Context, clusterID uint, pluginName string) (bool, error) {
@@ -227,7 +239,7 @@ func (r *Repository) UpsertDependency(ctx context.Context, dep *PluginDependency
 				{Name: "target_dir"},
 				{Name: "source_type"},
 			},
-			DoUpdates: clause.AssignmentColumns([]string{"original_file_name", "stored_path", "file_size", "checksum", "updated_at"}),
+			DoUpdates: clause.AssignmentColumns([]string{"original_file_name", "stored_path", "file_size", "checksum", "resolved_from", "updated_at"}),
 		}).
 		Create(dep).Error
 }
@@ -261,6 +273,17 @@ func (r *Repository) DeleteDependency(ctx context.Context, id uint) error {
 	return nil
 }
 
+// DeleteDependenciesResolvedFrom deletes transitive dependencies registered for one root coordinate.
+// DeleteDependenciesResolvedFrom 删除某个根坐标登记的传递依赖。
+func (r *Repository) DeleteDependenciesResolvedFrom(ctx context.Context, pluginName, seatunnelVersion, resolvedFrom string) error {
+	if strings.TrimSpace(resolvedFrom) == "" {
+		return nil
+	}
+	return r.db.WithContext(ctx).
+		Where("plugin_name = ? AND seatunnel_version = ? AND resolved_from = ?", pluginName, seatunnelVersion, resolvedFrom).
+		Delete(&PluginDependencyConfig{}).Error
+}
+
 // GetDependencyByID retrieves a dependency by ID.
 // GetDependencyByID 通过 ID 获取依赖。
 func (r *Repository) GetDependencyByID(ctx context.Context, id uint) (*PluginDependencyConfig, error) {
@@ -483,3 +506,95 @@ func (r *Repository) DeleteStaleDependencyProfiles(ctx context.Context, seatunne
 		return nil
 	})
 }
+
+// ListCustomPlugins returns uploaded custom plugins, optionally limited to one SeaTunnel version.
+// ListCustomPlugins 返回已上传的自定义插件，可按 SeaTunnel 版本过滤。
+func (r *Repository) ListCustomPlugins(ctx context.Context, version string) ([]CustomPlugin, error) {
+	var plugins []CustomPlugin
+	query := r.db.WithContext(ctx).Order("seatunnel_version ASC, name ASC")
+	if strings.TrimSpace(version) != "" {
+		query = query.Where("seatunnel_version = ?", version)
+	}
+	if err := query.Find(&plugins).Error; err != nil {
+		return nil, err
+	}
+	return plugins, nil
+}
+
+// GetCustomPlugin retrieves a custom plugin by ID.
+// GetCustomPlugin 通过 ID 获取自定义插件。
+func (r *Repository) GetCustomPlugin(ctx context.Context, id uint) (*CustomPlugin, error) {
+	var plugin CustomPlugin
+	if err := r.db.WithContext(ctx).First(&plugin, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCustomPluginNotFound
+		}
+		return nil, err
+	}
+	return &plugin, nil
+}
+
+// GetCustomPluginByName retrieves a custom plugin by SeaTunnel version and name.
+// GetCustomPluginByName 通过 SeaTunnel 版本和名称获取自定义插件。
+func (r *Repository) GetCustomPluginByName(ctx context.Context, version, name string) (*CustomPlugin, error) {
+	var plugin CustomPlugin
+	if err := r.db.WithContext(ctx).
+		Where("seatunnel_version = ? AND name = ?", version, name).
+		First(&plugin).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCustomPluginNotFound
+		}
+		return nil, err
+	}
+	return &plugin, nil
+}
+
+// UpsertCustomPlugin creates or replaces a custom plugin by version + name.
+// UpsertCustomPlugin 按版本 + 名称创建或替换自定义插件。
+func (r *Repository) UpsertCustomPlugin(ctx context.Context, plugin *CustomPlugin) error {
+	return r.db.WithContext(ctx).
+		Select("*").
+		Omit("id").
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "seatunnel_version"}, {Name: "name"}},
+			DoUpdates: clause.AssignmentColumns([]string{"display_name", "artifact_id", "group_id", "category", "description", "doc_url", "original_file_name", "file_size", "sha256", "uploaded_by", "updated_at"}),
+		}).
+		Create(plugin).Error
+}
+
+// DeleteCustomPlugin deletes a custom plugin by ID.
+// DeleteCustomPlugin 通过 ID 删除自定义插件。
+func (r *Repository) DeleteCustomPlugin(ctx context.Context, id uint) error {
+	result := r.db.WithContext(ctx).Delete(&CustomPlugin{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrCustomPluginNotFound
+	}
+	return nil
+}
+
+// GetMavenMetadataCache returns the cached Maven response of a URL, or nil when none exists.
+// GetMavenMetadataCache 返回某 URL 的 Maven 响应缓存，不存在时返回 nil。
+func (r *Repository) GetMavenMetadataCache(ctx context.Context, url string) (*MavenMetadataCache, error) {
+	var entry MavenMetadataCache
+	if err := r.db.WithContext(ctx).Where("url = ?", url).First(&entry).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// UpsertMavenMetadataCache stores the latest Maven response of a URL.
+// UpsertMavenMetadataCache 保存某 URL 最新的 Maven 响应。
+func (r *Repository) UpsertMavenMetadataCache(ctx context.Context, entry *MavenMetadataCache) error {
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "url"}},
+			DoUpdates: clause.AssignmentColumns([]string{"etag", "last_modified", "entries", "checked_at", "updated_at"}),
+		}).
+		Create(entry).Error
+}
@@ -25,17 +25,19 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"io"
-	"net/http"
 	"os"
-	"regexp"
+	"path/filepath"
 	"sort"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/seatunnel/seatunnelX/internal/apps/task"
 	"github.com/seatunnel/seatunnelX/internal/config"
 	"github.com/seatunnel/seatunnelX/internal/logger"
+	"github.com/seatunnel/seatunnelX/internal/pkg/chunkupload"
+	"github.com/seatunnel/seatunnelX/internal/pkg/eventbus"
+	"github.com/seatunnel/seatunnelX/internal/pkg/filestream"
 	"github.com/seatunnel/seatunnelX/internal/seatunnel"
 )
 
@@ -48,6 +50,9 @@ var (
 	ErrPluginAlreadyExists = errors.New("plugin already installed / 插件已安装")
 	ErrVersionMismatch     = errors.New("plugin version does not match cluster version / 插件版本与集群版本不匹配")
 	ErrClusterVersionEmpty = errors.New("cluster version is not set / 集群版本未设置")
+	// ErrHostUnderMaintenance indicates a host of the cluster is in maintenance mode.
+	// ErrHostUnderMaintenance 表示集群中有主机处于维护模式。
+	ErrHostUnderMaintenance = errors.New("cluster host is under maintenance / 集群主机处于维护模式")
 )
 
 // SeaTunnel Maven repository and documentation URLs
@@ -80,6 +85,21 @@ func connectorRepoBaseURL(mirror MirrorSource) string {
 	return baseURL + "/" + ConnectorRepoGroupPath
 }
 
+// RegisterCustomMirrors adds custom mirrors with a Maven base URL to MirrorURLs.
+// It must be called during startup before any plugin download starts.
+// RegisterCustomMirrors 将配置了 Maven 基础 URL 的自定义镜像加入 MirrorURLs。
+// 必须在启动阶段、任何插件下载开始前调用。
+func RegisterCustomMirrors(mirrors []config.DownloadMirrorConfig) {
+	for _, mirror := range mirrors {
+		name := MirrorSource(strings.TrimSpace(mirror.Name))
+		baseURL := strings.TrimSuffix(strings.TrimSpace(mirror.MavenBaseURL), "/")
+		if name == "" || baseURL == "" {
+			continue
+		}
+		MirrorURLs[name] = baseURL
+	}
+}
+
 // isSkippedModule checks if the artifact ID should be skipped.
 // isSkippedModule 检查 artifact ID 是否应该被跳过。
 func isSkippedModule(artifactID string) bool {
@@ -117,6 +137,12 @@ type HostInfoGetter interface {
 	GetHostAgentID(ctx context.Context, hostID uint) (string, error)
 }
 
+// ClusterMaintenanceChecker reports the hosts of a cluster that are in maintenance mode.
+// ClusterMaintenanceChecker 返回集群中处于维护模式的主机。
+type ClusterMaintenanceChecker interface {
+	MaintenanceHostNames(ctx context.Context, clusterID uint) ([]string, error)
+}
+
 // Service provides plugin management functionality.
 // Service 提供插件管理功能。
 type Service struct {
@@ -139,6 +165,14 @@ type Service struct {
 	// hostInfoGetter 用于获取主机信息（包括 AgentID）
 	hostInfoGetter HostInfoGetter
 
+	// maintenanceChecker blocks plugin operations on clusters with hosts in maintenance (optional)
+	// maintenanceChecker 阻止对有主机处于维护模式的集群执行插件操作（可选）
+	maintenanceChecker ClusterMaintenanceChecker
+
+	// uploads keeps resumable custom plugin uploads under the temp directory
+	// uploads 在临时目录下保存可续传的自定义插件上传
+	uploads *chunkupload.Store
+
 	// Plugin cache / 插件缓存
 	cachedPlugins    map[string][]Plugin // key: version
 	pluginsCacheTime map[string]time.Time
@@ -148,9 +182,22 @@ type Service struct {
 	installProgress   map[string]*PluginInstallStatus // key: clusterID:pluginName
 	installProgressMu sync.RWMutex
 
+	// Bulk install/uninstall operations / 批量安装/卸载操作
+	operations   map[string]*pluginOperationState // key: operation ID
+	operationsMu sync.RWMutex
+
 	// bundled seed load markers / 内置基线加载标记
 	seedLoadedVersions map[string]bool
 	seedLoadedMu       sync.RWMutex
+
+	// taskManager records cluster plugin operations as tasks (optional)
+	// taskManager 将集群插件操作记录为任务（可选）
+	taskManager *task.Manager
+
+	// Offline plugin index, loaded lazily / 离线插件索引（延迟加载）
+	index       *PluginIndex
+	indexOrigin PluginIndexOrigin
+	indexMu     sync.Mutex
 }
 
 // NewService creates a new Service instance.
@@ -159,14 +206,17 @@ func NewService(repo *Repository) *Service {
 	service := &Service{
 		repo:               repo,
 		downloader:         NewDownloader(config.GetPluginsDir()),
+		uploads:            newCustomPluginUploadStore(),
 		cachedPlugins:      make(map[string][]Plugin),
 		pluginsCacheTime:   make(map[string]time.Time),
 		installProgress:    make(map[string]*PluginInstallStatus),
+		operations:         make(map[string]*pluginOperationState),
 		seedLoadedVersions: make(map[string]bool),
 	}
 	service.pluginFetcher = service.fetchPluginsFromDocs
 	service.officialDocFetcher = service.fetchOfficialDocMarkdown
 	service.mavenVersionLookup = service.resolveLatestMavenVersion
+	service.downloader.SetSignatureVerification(config.Config.Storage.VerifyPluginSignatures, config.GetPluginKeysURL())
 	return service
 }
 
@@ -176,9 +226,11 @@ func NewServiceWithDownloader(repo *Repository, pluginsDir string) *Service {
 	service := &Service{
 		repo:               repo,
 		downloader:         NewDownloader(pluginsDir),
+		uploads:            newCustomPluginUploadStore(),
 		cachedPlugins:      make(map[string][]Plugin),
 		pluginsCacheTime:   make(map[string]time.Time),
 		installProgress:    make(map[string]*PluginInstallStatus),
+		operations:         make(map[string]*pluginOperationState),
 		seedLoadedVersions: make(map[string]bool),
 	}
 	service.pluginFetcher = service.fetchPluginsFromDocs
@@ -219,6 +271,7 @@ func (s *Service) ListAvailablePlugins(ctx context.Context, version string, mirr
 	}
 
 	plugins, sourceMirror, refreshedAt, source, cacheHit := s.getPlugins(ctx, version)
+	plugins = s.withCustomPlugins(ctx, version, plugins)
 	s.ensureBundledSeedLoaded(ctx, version)
 	plugins = s.enrichPluginsWithDependencyState(ctx, version, plugins)
 
@@ -234,13 +287,22 @@ func (s *Service) ListAvailablePlugins(ctx context.Context, version string, mirr
 	}, nil
 }
 
-// getPlugins returns the plugin list, preferring persisted DB snapshots and falling back to Maven.
-// getPlugins 返回插件列表，优先使用数据库快照，不存在时回退到 Maven。
+// getPlugins returns the plugin list, preferring persisted DB snapshots, then the offline plugin index,
+// and falling back to Maven.
+// getPlugins 返回插件列表，优先使用数据库快照，其次使用离线插件索引，都不存在时回退到 Maven。
 func (s *Service) getPlugins(ctx context.Context, version string) ([]Plugin, MirrorSource, *time.Time, PluginListSource, bool) {
 	if persisted, sourceMirror, refreshedAt := s.loadPluginsFromCatalog(ctx, version); len(persisted) > 0 {
 		return persisted, sourceMirror, refreshedAt, PluginListSourceDatabase, false
 	}
 
+	if indexed := s.pluginsFromIndex(version); len(indexed) > 0 {
+		refreshedAt := time.Now()
+		if err := s.persistPluginCatalog(ctx, version, indexed, PluginCatalogSourceIndex, "", refreshedAt); err != nil {
+			logger.WarnF(ctx, "[Plugin] 持久化插件目录失败: %v", err)
+		}
+		return indexed, "", &refreshedAt, PluginListSourceIndex, false
+	}
+
 	fetcher := s.pluginFetcher
 	if fetcher == nil {
 		fetcher = s.fetchPluginsFromDocs
@@ -278,8 +340,8 @@ func (s *Service) fetchPluginsFromDocs(ctx context.Context, version string, mirr
 
 // fetchConnectorsFromMaven fetches connector list from Maven repository.
 // fetchConnectorsFromMaven 从 Maven 仓库获取连接器列表。
-// Uses concurrent version checking for better performance.
-// 使用并发版本检查以提高性能。
+// Falls back to the Apache repository when the mirror cannot be scanned.
+// 镜像无法扫描时回退到 Apache 仓库。
 func (s *Service) fetchConnectorsFromMaven(ctx context.Context, version string, mirror MirrorSource) ([]Plugin, MirrorSource, error) {
 	logger.InfoF(ctx, "[Plugin] Fetching connectors from Maven for version %s via mirror %s", version, mirror)
 
@@ -295,120 +357,6 @@ func (s *Service) fetchConnectorsFromMaven(ctx context.Context, version string,
 	return nil, mirror, err
 }
 
-func (s *Service) fetchConnectorsFromMirror(ctx context.Context, version string, mirror MirrorSource) ([]Plugin, error) {
-	// Fetch the main directory listing / 获取主目录列表
-	client := &http.Client{Timeout: PluginFetchTimeout}
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, connectorRepoBaseURL(mirror)+"/", nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	resp, err := client.Do(req)
-	if err != nil {
-		logger.ErrorF(ctx, "[Plugin] Failed to fetch Maven repo: %v", err)
-		return nil, fmt.Errorf("failed to fetch Maven repo: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		logger.ErrorF(ctx, "[Plugin] Maven repo returned status %d", resp.StatusCode)
-		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
-	}
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
-	}
-
-	// Parse HTML to extract connector names / 解析 HTML 提取连接器名称
-	pattern := `<a[^>]*href="(connector-[^/"]+)/"[^>]*>`
-	re := regexp.MustCompile(pattern)
-	matches := re.FindAllStringSubmatch(string(body), -1)
-
-	// Filter candidates / 过滤候选连接器
-	var candidates []string
-	seen := make(map[string]bool)
-	for _, match := range matches {
-		if len(match) < 2 {
-			continue
-		}
-		artifactID := match[1]
-		if strings.Contains(artifactID, "-e2e") || isSkippedModule(artifactID) || seen[artifactID] {
-			continue
-		}
-		seen[artifactID] = true
-		candidates = append(candidates, artifactID)
-	}
-
-	logger.InfoF(ctx, "[Plugin] Checking %d connector candidates concurrently", len(candidates))
-
-	// Concurrent version checking / 并发版本检查
-	const maxConcurrency = 10
-	sem := make(chan struct{}, maxConcurrency)
-	var wg sync.WaitGroup
-	var mu sync.Mutex
-	var plugins []Plugin
-
-	for _, artifactID := range candidates {
-		wg.Add(1)
-		go func(aid string) {
-			defer wg.Done()
-			sem <- struct{}{}        // acquire
-			defer func() { <-sem }() // release
-
-			hasVersion, err := s.checkConnectorVersion(ctx, aid, version, mirror)
-			if err != nil || !hasVersion {
-				return
-			}
-
-			plugin := s.createPluginFromArtifactID(aid, version)
-			mu.Lock()
-			plugins = append(plugins, plugin)
-			mu.Unlock()
-		}(artifactID)
-	}
-
-	wg.Wait()
-	logger.InfoF(ctx, "[Plugin] Found %d connectors with version %s", len(plugins), version)
-	return plugins, nil
-}
-
-// checkConnectorVersion checks if a connector has the specified version in Maven.
-// checkConnectorVersion 检查连接器在 Maven 中是否有指定版本。
-func (s *Service) checkConnectorVersion(ctx context.Context, artifactID, version string, mirror MirrorSource) (bool, error) {
-	url := fmt.Sprintf("%s/%s/", connectorRepoBaseURL(mirror), artifactID)
-
-	client := &http.Client{Timeout: 10 * time.Second}
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
-	if err != nil {
-		return false, err
-	}
-
-	resp, err := client.Do(req)
-	if err != nil {
-		return false, err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		if mirror != MirrorSourceApache {
-			return s.checkConnectorVersion(ctx, artifactID, version, MirrorSourceApache)
-		}
-		return false, nil
-	}
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return false, err
-	}
-
-	// Check if version directory exists / 检查版本目录是否存在
-	// Pattern: <a href="2.3.12/">2.3.12/</a>
-	pattern := fmt.Sprintf(`<a[^>]*href="%s/"`, regexp.QuoteMeta(version))
-	matched, _ := regexp.MatchString(pattern, string(body))
-	return matched, nil
-}
-
 // createPluginFromArtifactID creates a Plugin from Maven artifact ID.
 // createPluginFromArtifactID 从 Maven artifact ID 创建 Plugin。
 // Plugin name = artifact ID without "connector-" prefix (e.g., connector-jdbc -> jdbc)
@@ -480,8 +428,10 @@ func (s *Service) getPluginInfoWithMirror(ctx context.Context, name string, vers
 	// Normalize name to lowercase for comparison / 将名称转换为小写进行比较
 	normalizedName := strings.ToLower(name)
 
-	// Resolve from DB snapshot or remote fetch / 从数据库快照或远端抓取中解析
+	// Resolve from DB snapshot, offline index or remote fetch, plus custom uploads
+	// 从数据库快照、离线索引或远端抓取中解析，并包含自定义上传插件
 	fetchedPlugins, _, _, _, _ := s.getPlugins(ctx, version)
+	fetchedPlugins = s.withCustomPlugins(ctx, version, fetchedPlugins)
 	for _, p := range fetchedPlugins {
 		if strings.ToLower(p.Name) == normalizedName {
 			enriched := s.enrichPluginsWithDependencyState(ctx, version, []Plugin{p})
@@ -497,6 +447,14 @@ func (s *Service) getPluginInfoWithMirror(ctx context.Context, name string, vers
 
 // ==================== Installed Plugins 已安装插件 ====================
 
+// ReleaseClusterPlugins deletes the installed plugin records of a cluster that is being deleted.
+// Plugin files are left to the cluster teardown, which owns the install directories.
+// ReleaseClusterPlugins 删除待删除集群的已安装插件记录。
+// 插件文件由负责安装目录的集群清理流程处理。
+func (s *Service) ReleaseClusterPlugins(ctx context.Context, clusterID uint) (int64, error) {
+	return s.repo.DeleteByCluster(ctx, clusterID)
+}
+
 // ListInstalledPlugins returns installed plugins for a cluster.
 // ListInstalledPlugins 返回集群上已安装的插件列表。
 func (s *Service) ListInstalledPlugins(ctx context.Context, clusterID uint) ([]InstalledPlugin, error) {
@@ -534,7 +492,10 @@ func (s *Service) ListInstalledPlugins(ctx context.Context, clusterID uint) ([]I
 		if metadata, ok := localByKey[key]; ok {
 			plugins[index].SelectedProfileKeys = metadata.selectedProfileKeys
 			plugins[index].AttachedConnectors = metadata.attachedConnectors
-			plugins[index].Dependencies = metadata.dependencies
+			// Prefer the dependencies recorded at install time / 优先使用安装时记录的依赖
+			if len(plugins[index].Dependencies) == 0 {
+				plugins[index].Dependencies = metadata.dependencies
+			}
 		}
 	}
 
@@ -653,6 +614,9 @@ func (s *Service) InstallPlugin(ctx context.Context, clusterID uint, req *Instal
 // Sends uninstall_plugin command to each cluster node's agent to remove plugin files from install dir, then deletes the DB record.
 // 向集群各节点 Agent 发送 uninstall_plugin 命令以从安装目录删除插件文件，再删除数据库记录。
 func (s *Service) UninstallPlugin(ctx context.Context, clusterID uint, pluginName string) error {
+	if err := s.ensureClusterNotInMaintenance(ctx, clusterID); err != nil {
+		return err
+	}
 	plugin, err := s.repo.GetByClusterAndName(ctx, clusterID, pluginName)
 	if err != nil {
 		return err
@@ -764,6 +728,7 @@ func (s *Service) DownloadPlugin(ctx context.Context, name, version string, mirr
 		fmt.Printf("[DownloadPlugin] Loaded %d dependencies for %s\n", len(deps), name)
 	}
 
+	s.downloader.fetchVersionFromStore(ctx, version)
 	connectorReady := s.downloader.IsConnectorDownloaded(name, version)
 	dependenciesReady := s.arePluginDependenciesDownloaded(plugin)
 
@@ -860,6 +825,7 @@ func (s *Service) GetDownloadStatus(name, version string, profileKeys []string)
 // ListLocalPlugins returns a list of locally downloaded plugins.
 // ListLocalPlugins 返回本地已下载的插件列表。
 func (s *Service) ListLocalPlugins() ([]LocalPlugin, error) {
+	s.downloader.fetchAllFromStore(context.Background())
 	plugins, err := s.downloader.ListLocalPlugins()
 	if err != nil {
 		return nil, err
@@ -915,6 +881,8 @@ func (s *Service) DownloadAllPlugins(ctx context.Context, version string, mirror
 		mirror = MirrorSourceApache
 	}
 
+	s.downloader.fetchVersionFromStore(ctx, version)
+
 	// Get all available plugins / 获取所有可用插件
 	plugins, _, _, _, _ := s.getPlugins(ctx, version)
 	if len(plugins) == 0 {
@@ -1015,7 +983,11 @@ func (s *Service) GetInstallProgress(clusterID uint, pluginName string) *PluginI
 
 // setInstallProgress sets the installation progress for a plugin on a cluster.
 // setInstallProgress 设置集群上插件的安装进度。
-func (s *Service) setInstallProgress(clusterID uint, pluginName string, status *PluginInstallStatus) {
+// When the call runs inside a task, the progress is also reported to that task.
+// 当调用在任务中执行时，进度也会上报给该任务。
+func (s *Service) setInstallProgress(ctx context.Context, clusterID uint, pluginName string, status *PluginInstallStatus) {
+	task.ReporterFromContext(ctx).Update(status.Progress, status.Message)
+
 	key := fmt.Sprintf("%d:%s", clusterID, pluginName)
 
 	s.installProgressMu.Lock()
@@ -1043,6 +1015,14 @@ type AgentCommandSender interface {
 	SendCommand(ctx context.Context, agentID string, commandType string, params map[string]string) (bool, string, error)
 }
 
+// AgentFileStreamer is optionally implemented by an AgentCommandSender that can stream files through FetchFile.
+// It returns filestream.ErrUnsupported when the Agent only accepts chunked transfer commands.
+// AgentFileStreamer 由可通过 FetchFile 流式传输文件的 AgentCommandSender 选择性实现。
+// 当 Agent 只接受分块传输命令时返回 filestream.ErrUnsupported。
+type AgentFileStreamer interface {
+	StreamFileToAgent(ctx context.Context, agentID string, commandType string, params map[string]string, localPath string) (bool, string, error)
+}
+
 // SetAgentCommandSender sets the agent command sender for plugin installation.
 // SetAgentCommandSender 设置用于插件安装的 Agent 命令发送器。
 func (s *Service) SetAgentCommandSender(sender AgentCommandSender) {
@@ -1061,6 +1041,25 @@ func (s *Service) SetHostInfoGetter(getter HostInfoGetter) {
 	s.hostInfoGetter = getter
 }
 
+// SetMaintenanceChecker sets the checker that blocks plugin operations during host maintenance.
+// SetMaintenanceChecker 设置主机维护期间阻止插件操作的检查器。
+func (s *Service) SetMaintenanceChecker(checker ClusterMaintenanceChecker) {
+	s.maintenanceChecker = checker
+}
+
+// ensureClusterNotInMaintenance rejects plugin operations on clusters with hosts in maintenance mode.
+// ensureClusterNotInMaintenance 拒绝对有主机处于维护模式的集群执行插件操作。
+func (s *Service) ensureClusterNotInMaintenance(ctx context.Context, clusterID uint) error {
+	if s.maintenanceChecker == nil {
+		return nil
+	}
+	names, err := s.maintenanceChecker.MaintenanceHostNames(ctx, clusterID)
+	if err != nil || len(names) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%w: %s", ErrHostUnderMaintenance, strings.Join(names, ", "))
+}
+
 // InstallPluginToCluster installs a plugin to all nodes in a cluster.
 // InstallPluginToCluster 将插件安装到集群中的所有节点。
 // This method:
@@ -1069,19 +1068,40 @@ func (s *Service) SetHostInfoGetter(getter HostInfoGetter) {
 // 3. Transfers plugin files to each node's Agent
 // 4. Sends install command to each Agent
 // 5. Updates database record
-func (s *Service) InstallPluginToCluster(ctx context.Context, clusterID uint, req *InstallPluginRequest) (*InstalledPlugin, error) {
-	// Validate plugin version matches cluster version / 校验插件版本与集群版本是否匹配
-	if s.clusterGetter != nil {
-		clusterVersion, err := s.clusterGetter.GetClusterVersion(ctx, clusterID)
+// eventbus.PluginInstalled is emitted with the outcome.
+// 安装结束后发出携带结果的 eventbus.PluginInstalled 事件。
+func (s *Service) InstallPluginToCluster(ctx context.Context, clusterID uint, req *InstallPluginRequest) (installed *InstalledPlugin, err error) {
+	if err := s.ensureClusterNotInMaintenance(ctx, clusterID); err != nil {
+		return nil, err
+	}
+	defer func() {
+		event := eventbus.PluginInstalled{ClusterID: clusterID, PluginName: req.PluginName, Version: req.Version, Success: err == nil}
 		if err != nil {
-			return nil, err
-		}
-		if clusterVersion == "" {
-			return nil, ErrClusterVersionEmpty
+			event.Error = err.Error()
 		}
-		if req.Version != clusterVersion {
-			return nil, fmt.Errorf("%w: plugin version %s, cluster version %s", ErrVersionMismatch, req.Version, clusterVersion)
+		eventbus.Emit(ctx, event)
+	}()
+
+	if s.taskManager == nil {
+		return s.installPluginToCluster(ctx, clusterID, req)
+	}
+
+	_, err = s.runPluginTask(ctx, task.TaskTypeInstallPlugin, clusterID, req, func(ctx context.Context) (map[string]interface{}, error) {
+		var err error
+		if installed, err = s.installPluginToCluster(ctx, clusterID, req); err != nil {
+			return nil, err
 		}
+		return map[string]interface{}{"plugin_id": installed.ID, "plugin_name": installed.PluginName}, nil
+	})
+	return installed, err
+}
+
+// installPluginToCluster performs the cluster plugin installation.
+// installPluginToCluster 执行集群插件安装。
+func (s *Service) installPluginToCluster(ctx context.Context, clusterID uint, req *InstallPluginRequest) (*InstalledPlugin, error) {
+	// Validate plugin version matches cluster version / 校验插件版本与集群版本是否匹配
+	if err := s.validateClusterPluginVersion(ctx, clusterID, req.Version); err != nil {
+		return nil, err
 	}
 
 	// Check if plugin already installed / 检查插件是否已安装
@@ -1106,7 +1126,7 @@ func (s *Service) InstallPluginToCluster(ctx context.Context, clusterID uint, re
 		Progress:   0,
 		Message:    "Checking local plugin files / 检查本地插件文件",
 	}
-	s.setInstallProgress(clusterID, req.PluginName, progress)
+	s.setInstallProgress(ctx, clusterID, req.PluginName, progress)
 
 	// Check if plugin is downloaded locally / 检查插件是否已在本地下载
 	effectiveDeps, err := s.GetPluginDependenciesForVersionAndProfiles(ctx, req.PluginName, req.Version, req.ProfileKeys)
@@ -1115,46 +1135,23 @@ func (s *Service) InstallPluginToCluster(ctx context.Context, clusterID uint, re
 	}
 	pluginInfo.Dependencies = effectiveDeps
 
-	connectorReady := s.downloader.IsConnectorDownloaded(req.PluginName, req.Version)
-	dependenciesReady := s.arePluginDependenciesDownloaded(pluginInfo)
-	if !connectorReady || !dependenciesReady {
-		progress.Message = "Downloading plugin / 下载插件"
-		s.setInstallProgress(clusterID, req.PluginName, progress)
-
-		// Download plugin / 下载插件
-		mirror := req.Mirror
-		if mirror == "" {
-			mirror = MirrorSourceApache
-		}
-
-		progressCallback := func(p *DownloadProgress) {
-			progress.Progress = p.Progress / 2 // First half is download / 前半部分是下载
-			progress.Message = p.CurrentStep
-			s.setInstallProgress(clusterID, req.PluginName, progress)
-		}
-		if !connectorReady {
-			if err := s.downloader.DownloadConnector(ctx, pluginInfo, mirror, progressCallback); err != nil {
-				progress.Status = "failed"
-				progress.Error = err.Error()
-				s.setInstallProgress(clusterID, req.PluginName, progress)
-				return nil, fmt.Errorf("failed to download plugin connector: %w", err)
-			}
-		}
-		if !dependenciesReady {
-			if err := s.downloader.DownloadDependencies(ctx, pluginInfo, mirror, progressCallback); err != nil {
-				progress.Status = "failed"
-				progress.Error = err.Error()
-				s.setInstallProgress(clusterID, req.PluginName, progress)
-				return nil, fmt.Errorf("failed to download plugin dependencies: %w", err)
-			}
-		}
+	progressCallback := func(p *DownloadProgress) {
+		progress.Progress = p.Progress / 2 // First half is download / 前半部分是下载
+		progress.Message = p.CurrentStep
+		s.setInstallProgress(ctx, clusterID, req.PluginName, progress)
+	}
+	if err := s.ensurePluginArtifactsDownloaded(ctx, pluginInfo, req.Mirror, progressCallback); err != nil {
+		progress.Status = "failed"
+		progress.Error = err.Error()
+		s.setInstallProgress(ctx, clusterID, req.PluginName, progress)
+		return nil, err
 	}
 
 	// Update progress / 更新进度
 	progress.Progress = 50
 	progress.Status = "installing"
 	progress.Message = "Plugin downloaded, preparing installation / 插件已下载，准备安装"
-	s.setInstallProgress(clusterID, req.PluginName, progress)
+	s.setInstallProgress(ctx, clusterID, req.PluginName, progress)
 
 	// Get cluster nodes / 获取集群节点
 	// Log dependency status for debugging / 记录依赖状态用于调试
@@ -1175,7 +1172,7 @@ func (s *Service) InstallPluginToCluster(ctx context.Context, clusterID uint, re
 		if err != nil {
 			progress.Status = "failed"
 			progress.Error = fmt.Sprintf("Failed to get cluster nodes: %v / 获取集群节点失败: %v", err, err)
-			s.setInstallProgress(clusterID, req.PluginName, progress)
+			s.setInstallProgress(ctx, clusterID, req.PluginName, progress)
 			return nil, fmt.Errorf("failed to get cluster nodes: %w", err)
 		}
 
@@ -1184,7 +1181,7 @@ func (s *Service) InstallPluginToCluster(ctx context.Context, clusterID uint, re
 		if len(nodes) == 0 {
 			progress.Status = "failed"
 			progress.Error = "No nodes found in cluster / 集群中没有节点"
-			s.setInstallProgress(clusterID, req.PluginName, progress)
+			s.setInstallProgress(ctx, clusterID, req.PluginName, progress)
 			return nil, fmt.Errorf("no nodes found in cluster")
 		}
 
@@ -1195,14 +1192,14 @@ func (s *Service) InstallPluginToCluster(ctx context.Context, clusterID uint, re
 			nodeProgress := 50 + (i * 50 / totalNodes)
 			progress.Progress = nodeProgress
 			progress.Message = fmt.Sprintf("Installing to node %d/%d / 正在安装到节点 %d/%d", i+1, totalNodes, i+1, totalNodes)
-			s.setInstallProgress(clusterID, req.PluginName, progress)
+			s.setInstallProgress(ctx, clusterID, req.PluginName, progress)
 
 			// Get agent ID for this host / 获取此主机的 Agent ID
 			agentID, err := s.hostInfoGetter.GetHostAgentID(ctx, node.HostID)
 			if err != nil {
 				progress.Status = "failed"
 				progress.Error = fmt.Sprintf("Failed to get agent ID for host %d: %v / 获取主机 %d 的 Agent ID 失败: %v", node.HostID, err, node.HostID, err)
-				s.setInstallProgress(clusterID, req.PluginName, progress)
+				s.setInstallProgress(ctx, clusterID, req.PluginName, progress)
 				return nil, fmt.Errorf("failed to get agent ID for host %d: %w", node.HostID, err)
 			}
 
@@ -1211,16 +1208,16 @@ func (s *Service) InstallPluginToCluster(ctx context.Context, clusterID uint, re
 			if agentID == "" {
 				progress.Status = "failed"
 				progress.Error = fmt.Sprintf("Agent not installed on host %d / 主机 %d 未安装 Agent", node.HostID, node.HostID)
-				s.setInstallProgress(clusterID, req.PluginName, progress)
+				s.setInstallProgress(ctx, clusterID, req.PluginName, progress)
 				return nil, fmt.Errorf("agent not installed on host %d", node.HostID)
 			}
 
 			// Transfer plugin file to agent using artifact ID / 使用 artifact ID 传输插件文件到 Agent
 			fmt.Printf("[Plugin Install] Transferring plugin %s (artifact: %s) to agent %s...\n", req.PluginName, artifactID, agentID)
-			if err := s.transferPluginToAgent(ctx, agentID, artifactID, req.PluginName, req.Version, node.InstallDir, effectiveDeps); err != nil {
+			if err := s.transferPluginToAgent(ctx, agentID, artifactID, req.PluginName, req.Version, node.InstallDir, effectiveDeps, req.AllowUnverified); err != nil {
 				progress.Status = "failed"
 				progress.Error = fmt.Sprintf("Failed to transfer plugin to node %d: %v / 传输插件到节点 %d 失败: %v", node.NodeID, err, node.NodeID, err)
-				s.setInstallProgress(clusterID, req.PluginName, progress)
+				s.setInstallProgress(ctx, clusterID, req.PluginName, progress)
 				return nil, fmt.Errorf("failed to transfer plugin to node %d: %w", node.NodeID, err)
 			}
 		}
@@ -1228,21 +1225,22 @@ func (s *Service) InstallPluginToCluster(ctx context.Context, clusterID uint, re
 
 	// Create database record / 创建数据库记录
 	installed := &InstalledPlugin{
-		ClusterID:   clusterID,
-		PluginName:  req.PluginName,
-		ArtifactID:  artifactID,
-		Category:    pluginInfo.Category,
-		Version:     req.Version,
-		Status:      PluginStatusInstalled,
-		InstallPath: fmt.Sprintf("connectors/%s-%s.jar", artifactID, req.Version),
-		InstalledAt: time.Now(),
-		UpdatedAt:   time.Now(),
+		ClusterID:    clusterID,
+		PluginName:   req.PluginName,
+		ArtifactID:   artifactID,
+		Category:     pluginInfo.Category,
+		Version:      req.Version,
+		Status:       PluginStatusInstalled,
+		InstallPath:  fmt.Sprintf("connectors/%s-%s.jar", artifactID, req.Version),
+		InstalledAt:  time.Now(),
+		UpdatedAt:    time.Now(),
+		Dependencies: effectiveDeps,
 	}
 
 	if err := s.repo.Create(ctx, installed); err != nil {
 		progress.Status = "failed"
 		progress.Error = err.Error()
-		s.setInstallProgress(clusterID, req.PluginName, progress)
+		s.setInstallProgress(ctx, clusterID, req.PluginName, progress)
 		return nil, err
 	}
 
@@ -1250,7 +1248,7 @@ func (s *Service) InstallPluginToCluster(ctx context.Context, clusterID uint, re
 	progress.Status = "completed"
 	progress.Progress = 100
 	progress.Message = "Plugin installed successfully / 插件安装成功"
-	s.setInstallProgress(clusterID, req.PluginName, progress)
+	s.setInstallProgress(ctx, clusterID, req.PluginName, progress)
 
 	// Clear progress after a delay / 延迟后清除进度
 	go func() {
@@ -1261,9 +1259,74 @@ func (s *Service) InstallPluginToCluster(ctx context.Context, clusterID uint, re
 	return installed, nil
 }
 
+// validateClusterPluginVersion ensures a plugin version matches the SeaTunnel version of the cluster.
+// validateClusterPluginVersion 确保插件版本与集群的 SeaTunnel 版本一致。
+func (s *Service) validateClusterPluginVersion(ctx context.Context, clusterID uint, version string) error {
+	if s.clusterGetter == nil {
+		return nil
+	}
+	clusterVersion, err := s.clusterGetter.GetClusterVersion(ctx, clusterID)
+	if err != nil {
+		return err
+	}
+	if clusterVersion == "" {
+		return ErrClusterVersionEmpty
+	}
+	if version != clusterVersion {
+		return fmt.Errorf("%w: plugin version %s, cluster version %s", ErrVersionMismatch, version, clusterVersion)
+	}
+	return nil
+}
+
+// ensurePluginArtifactsDownloaded downloads the connector and its dependencies to the Control Plane when missing.
+// ensurePluginArtifactsDownloaded 在缺失时将连接器及其依赖下载到 Control Plane。
+func (s *Service) ensurePluginArtifactsDownloaded(ctx context.Context, pluginInfo *Plugin, mirror MirrorSource, callback ProgressCallback) error {
+	s.downloader.fetchVersionFromStore(ctx, pluginInfo.Version)
+	connectorReady := s.downloader.IsConnectorDownloaded(pluginInfo.Name, pluginInfo.Version)
+	dependenciesReady := s.arePluginDependenciesDownloaded(pluginInfo)
+	if connectorReady && dependenciesReady {
+		return nil
+	}
+	if mirror == "" {
+		mirror = MirrorSourceApache
+	}
+	if !connectorReady && pluginInfo.Custom {
+		return fmt.Errorf("custom plugin %s has no stored jar, upload it again / 自定义插件 %s 缺少 jar，请重新上传", pluginInfo.Name, pluginInfo.Name)
+	}
+	if !connectorReady {
+		if err := s.downloader.DownloadConnector(ctx, pluginInfo, mirror, callback); err != nil {
+			return fmt.Errorf("failed to download plugin connector: %w", err)
+		}
+	}
+	if !dependenciesReady {
+		if err := s.downloader.DownloadDependencies(ctx, pluginInfo, mirror, callback); err != nil {
+			return fmt.Errorf("failed to download plugin dependencies: %w", err)
+		}
+	}
+	s.downloader.mirrorVersionToStore(ctx, pluginInfo.Version)
+	return nil
+}
+
 // UninstallPluginFromCluster uninstalls a plugin from all nodes in a cluster.
 // UninstallPluginFromCluster 从集群中的所有节点卸载插件。
 func (s *Service) UninstallPluginFromCluster(ctx context.Context, clusterID uint, pluginName string) error {
+	if err := s.ensureClusterNotInMaintenance(ctx, clusterID); err != nil {
+		return err
+	}
+	if s.taskManager == nil {
+		return s.uninstallPluginFromCluster(ctx, clusterID, pluginName)
+	}
+
+	payload := map[string]string{"plugin_name": pluginName}
+	_, err := s.runPluginTask(ctx, task.TaskTypeUninstallPlugin, clusterID, payload, func(ctx context.Context) (map[string]interface{}, error) {
+		return nil, s.uninstallPluginFromCluster(ctx, clusterID, pluginName)
+	})
+	return err
+}
+
+// uninstallPluginFromCluster performs the cluster plugin uninstallation.
+// uninstallPluginFromCluster 执行集群插件卸载。
+func (s *Service) uninstallPluginFromCluster(ctx context.Context, clusterID uint, pluginName string) error {
 	// Check if plugin exists / 检查插件是否存在
 	plugin, err := s.repo.GetByClusterAndName(ctx, clusterID, pluginName)
 	if err != nil {
@@ -1277,7 +1340,7 @@ func (s *Service) UninstallPluginFromCluster(ctx context.Context, clusterID uint
 		Progress:   0,
 		Message:    "Uninstalling plugin / 正在卸载插件",
 	}
-	s.setInstallProgress(clusterID, pluginName, progress)
+	s.setInstallProgress(ctx, clusterID, pluginName, progress)
 
 	// TODO: Get cluster nodes and send uninstall commands to each Agent
 	// TODO: 获取集群节点并向每个 Agent 发送卸载命令
@@ -1286,7 +1349,7 @@ func (s *Service) UninstallPluginFromCluster(ctx context.Context, clusterID uint
 	if err := s.repo.Delete(ctx, plugin.ID); err != nil {
 		progress.Status = "failed"
 		progress.Error = err.Error()
-		s.setInstallProgress(clusterID, pluginName, progress)
+		s.setInstallProgress(ctx, clusterID, pluginName, progress)
 		return err
 	}
 
@@ -1294,7 +1357,7 @@ func (s *Service) UninstallPluginFromCluster(ctx context.Context, clusterID uint
 	progress.Status = "completed"
 	progress.Progress = 100
 	progress.Message = "Plugin uninstalled successfully / 插件卸载成功"
-	s.setInstallProgress(clusterID, pluginName, progress)
+	s.setInstallProgress(ctx, clusterID, pluginName, progress)
 
 	// Clear progress after a delay / 延迟后清除进度
 	go func() {
@@ -1317,7 +1380,9 @@ func (s *Service) UninstallPluginFromCluster(ctx context.Context, clusterID uint
 // Parameters:
 // - artifactID: Maven artifact ID (e.g., connector-cdc-mysql, connector-file-cos)
 // - pluginName: Plugin display name (e.g., mysql-cdc, cosfile)
-func (s *Service) transferPluginToAgent(ctx context.Context, agentID, artifactID, pluginName, version, installDir string, deps []PluginDependency) error {
+// Unless allowUnverified is set, every jar must carry a passing verification record.
+// 除非设置 allowUnverified，否则每个 jar 都必须有通过的校验记录。
+func (s *Service) transferPluginToAgent(ctx context.Context, agentID, artifactID, pluginName, version, installDir string, deps []PluginDependency, allowUnverified bool) error {
 	if s.agentCommandSender == nil {
 		return fmt.Errorf("agent command sender not configured / Agent 命令发送器未配置")
 	}
@@ -1325,15 +1390,14 @@ func (s *Service) transferPluginToAgent(ctx context.Context, agentID, artifactID
 	// 1. Transfer connector file / 传输连接器文件
 	// Use artifact ID directly for file name / 直接使用 artifact ID 作为文件名
 	connectorFileName := fmt.Sprintf("%s-%s.jar", artifactID, version)
-
-	// Read plugin file using artifact ID / 使用 artifact ID 读取插件文件
-	fileData, err := s.downloader.ReadPluginFileByArtifactID(artifactID, version)
-	if err != nil {
-		return fmt.Errorf("failed to read plugin file: %w / 读取插件文件失败: %w", err, err)
+	connectorPath := s.downloader.GetConnectorPath(artifactID, version)
+	s.downloader.fetchVersionFromStore(ctx, version)
+	if err := s.ensureArtifactVerified(connectorPath, allowUnverified); err != nil {
+		return err
 	}
 
-	// Transfer connector file in chunks / 分块传输连接器文件
-	if err := s.transferFileToAgent(ctx, agentID, pluginName, version, "connector", "connectors", connectorFileName, fileData, installDir); err != nil {
+	// Transfer connector file / 传输连接器文件
+	if err := s.transferFileToAgent(ctx, agentID, pluginName, version, "connector", "connectors", connectorFileName, connectorPath, installDir); err != nil {
 		return fmt.Errorf("failed to transfer connector: %w / 传输连接器失败: %w", err, err)
 	}
 
@@ -1343,24 +1407,21 @@ func (s *Service) transferPluginToAgent(ctx context.Context, agentID, artifactID
 
 		for _, dep := range deps {
 			// Check if dependency is downloaded / 检查依赖是否已下载
+			// A connector without its jars fails at job runtime, so missing dependencies abort the transfer.
+			// 缺少依赖 jar 的连接器会在作业运行时失败，因此依赖缺失时中止传输。
 			if !s.downloader.IsDependencyDownloaded(dep.ArtifactID, dep.Version, version, dep.TargetDir) {
-				fmt.Printf("[Plugin Transfer] Warning: dependency %s-%s not downloaded, skipping\n", dep.ArtifactID, dep.Version)
-				continue
+				return fmt.Errorf("dependency %s-%s not downloaded / 依赖 %s-%s 未下载", dep.ArtifactID, dep.Version, dep.ArtifactID, dep.Version)
 			}
 
-			// Read dependency file / 读取依赖文件
 			depPath := s.downloader.GetDependencyPath(dep.ArtifactID, dep.Version, version, dep.TargetDir)
-			depData, err := s.readFile(depPath)
-			if err != nil {
-				fmt.Printf("[Plugin Transfer] Warning: failed to read dependency %s: %v, skipping\n", dep.ArtifactID, err)
-				continue
+			if err := s.ensureArtifactVerified(depPath, allowUnverified); err != nil {
+				return err
 			}
 
 			// Transfer dependency file / 传输依赖文件
 			depFileName := fmt.Sprintf("%s-%s.jar", dep.ArtifactID, dep.Version)
-			if err := s.transferFileToAgent(ctx, agentID, pluginName, version, "dependency", dep.TargetDir, depFileName, depData, installDir); err != nil {
-				fmt.Printf("[Plugin Transfer] Warning: failed to transfer dependency %s: %v, skipping\n", dep.ArtifactID, err)
-				continue
+			if err := s.transferFileToAgent(ctx, agentID, pluginName, version, "dependency", dep.TargetDir, depFileName, depPath, installDir); err != nil {
+				return fmt.Errorf("failed to transfer dependency %s: %w / 传输依赖失败: %w", dep.ArtifactID, err, err)
 			}
 
 			fmt.Printf("[Plugin Transfer] Dependency transferred: %s\n", depFileName)
@@ -1388,13 +1449,83 @@ func (s *Service) transferPluginToAgent(ctx context.Context, agentID, artifactID
 	return nil
 }
 
-// transferFileToAgent transfers a single file to an Agent in chunks.
-// transferFileToAgent 分块传输单个文件到 Agent。
-func (s *Service) transferFileToAgent(ctx context.Context, agentID, pluginName, version, fileType, targetDir, fileName string, fileData []byte, installDir string) error {
+// ensureArtifactVerified refuses jars whose checksum or required signature could not be verified.
+// ensureArtifactVerified 拒绝校验和或必需签名未能通过校验的 jar。
+func (s *Service) ensureArtifactVerified(jarPath string, allowUnverified bool) error {
+	if allowUnverified {
+		return nil
+	}
+	verification, err := ReadArtifactVerification(jarPath)
+	if err != nil {
+		return fmt.Errorf("failed to read verification of %s: %w / 读取校验记录失败", filepath.Base(jarPath), err)
+	}
+	if !verification.Verified() {
+		return fmt.Errorf("%w: %s", ErrArtifactUnverified, filepath.Base(jarPath))
+	}
+	return nil
+}
+
+// transferFileToAgent transfers a single file to an Agent, streaming it through FetchFile when the
+// Agent supports it and falling back to base64 chunks in commands otherwise.
+// The SHA-256 of the whole file is sent so the Agent can verify what it received.
+// transferFileToAgent 传输单个文件到 Agent：Agent 支持时通过 FetchFile 流式传输，否则回退为命令中的 base64 分块。
+// 并发送整个文件的 SHA-256 供 Agent 校验。
+func (s *Service) transferFileToAgent(ctx context.Context, agentID, pluginName, version, fileType, targetDir, fileName, filePath, installDir string) error {
+	fileData, err := s.readFile(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w / 读取文件 %s 失败: %w", fileName, err, fileName, err)
+	}
+	totalSize := int64(len(fileData))
+	digest := sha256.Sum256(fileData)
+	checksum := hex.EncodeToString(digest[:])
+
+	// Skip the transfer when the Agent plugin cache already holds this content
+	// Agent 插件缓存已有相同内容时跳过传输
+	if s.agentHasPlugin(ctx, agentID, checksum, totalSize) {
+		success, _, err := s.agentCommandSender.SendCommand(ctx, agentID, "transfer_plugin", map[string]string{
+			"plugin_name":  pluginName,
+			"version":      version,
+			"file_type":    fileType,
+			"target_dir":   targetDir,
+			"file_name":    fileName,
+			"total_size":   fmt.Sprintf("%d", totalSize),
+			"checksum":     checksum,
+			"install_path": installDir,
+			"from_cache":   "true",
+		})
+		if err == nil && success {
+			logger.InfoF(ctx, "[Plugin] Agent %s already caches %s, transfer skipped", agentID, fileName)
+			return nil
+		}
+		// Fall back to a regular transfer, e.g. when the entry was evicted meanwhile
+		// 回退为常规传输，例如缓存条目已在此期间被淘汰
+	}
+
+	if streamer, ok := s.agentCommandSender.(AgentFileStreamer); ok {
+		params := map[string]string{
+			"plugin_name":  pluginName,
+			"version":      version,
+			"file_type":    fileType,
+			"target_dir":   targetDir,
+			"file_name":    fileName,
+			"total_size":   fmt.Sprintf("%d", totalSize),
+			"checksum":     checksum,
+			"install_path": installDir,
+		}
+		success, message, err := streamer.StreamFileToAgent(ctx, agentID, "transfer_plugin", params, filePath)
+		switch {
+		case err == nil && success:
+			return nil
+		case err == nil:
+			return fmt.Errorf("transfer file failed: %s / 传输文件失败: %s", message, message)
+		case !errors.Is(err, filestream.ErrUnsupported):
+			return fmt.Errorf("failed to stream file: %w / 流式传输文件失败: %w", err, err)
+		}
+	}
+
 	// Transfer file in chunks / 分块传输文件
 	// Chunk size: 1MB / 块大小: 1MB
 	const chunkSize = 1024 * 1024
-	totalSize := int64(len(fileData))
 	var offset int64 = 0
 
 	for offset < totalSize {
@@ -1422,6 +1553,9 @@ func (s *Service) transferFileToAgent(ctx context.Context, agentID, pluginName,
 			"is_last":      fmt.Sprintf("%t", isLast),
 			"install_path": installDir,
 		}
+		if isLast {
+			params["checksum"] = checksum
+		}
 
 		success, message, err := s.agentCommandSender.SendCommand(ctx, agentID, "transfer_plugin", params)
 		if err != nil {
@@ -1437,6 +1571,34 @@ func (s *Service) transferFileToAgent(ctx context.Context, agentID, pluginName,
 	return nil
 }
 
+// agentPluginCacheResult is the has_plugin output reported by the Agent.
+// agentPluginCacheResult 是 Agent 返回的 has_plugin 结果。
+type agentPluginCacheResult struct {
+	Success bool              `json:"success"`
+	Details map[string]string `json:"details"`
+}
+
+// agentHasPlugin asks the Agent whether its plugin cache holds a file with the given SHA-256 and size.
+// Any error, including Agents without a plugin cache, is treated as a cache miss.
+// agentHasPlugin 询问 Agent 的插件缓存是否有给定 SHA-256 和大小的文件。
+// 任何错误（包括 Agent 没有插件缓存）都视为未命中。
+func (s *Service) agentHasPlugin(ctx context.Context, agentID, checksum string, totalSize int64) bool {
+	success, output, err := s.agentCommandSender.SendCommand(ctx, agentID, "has_plugin", map[string]string{
+		"sub_command": "has_plugin",
+		"checksum":    checksum,
+		"total_size":  fmt.Sprintf("%d", totalSize),
+	})
+	if err != nil || !success {
+		return false
+	}
+
+	var result agentPluginCacheResult
+	if err := json.Unmarshal([]byte(output), &result); err != nil {
+		return false
+	}
+	return result.Success && result.Details["cached"] == "true"
+}
+
 func (s *Service) arePluginDependenciesDownloaded(plugin *Plugin) bool {
 	if plugin == nil {
 		return false
@@ -1497,7 +1659,7 @@ func (s *Service) TransferPluginToAgent(ctx context.Context, agentID, pluginName
 	}
 
 	// Use the existing transferPluginToAgent method / 使用现有的 transferPluginToAgent 方法
-	return s.transferPluginToAgent(ctx, agentID, artifactID, pluginName, version, installDir, deps)
+	return s.transferPluginToAgent(ctx, agentID, artifactID, pluginName, version, installDir, deps, false)
 }
 
 // GetPluginArtifactID returns the Maven artifact ID for a plugin name.
@@ -1544,6 +1706,7 @@ func (s *Service) DownloadPluginSync(ctx context.Context, pluginName, version, m
 		downloadMirror = MirrorSourceApache
 	}
 
+	s.downloader.fetchVersionFromStore(ctx, version)
 	connectorReady := s.downloader.IsConnectorDownloaded(pluginName, version)
 	dependenciesReady := s.arePluginDependenciesDownloaded(plugin)
 
@@ -26,6 +26,7 @@ import (
 	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"strconv"
 	"testing"
 	"time"
 
@@ -56,6 +57,8 @@ func newTestPluginService(t *testing.T) (*Service, *Repository) {
 		&PluginCatalogEntry{},
 		&PluginDependencyProfile{},
 		&PluginDependencyProfileItem{},
+		&CustomPlugin{},
+		&MavenMetadataCache{},
 	); err != nil {
 		t.Fatalf("failed to migrate plugin models: %v", err)
 	}
@@ -114,6 +117,8 @@ func newTestPluginServiceWithDownloader(t *testing.T, pluginsDir string) (*Servi
 		&PluginCatalogEntry{},
 		&PluginDependencyProfile{},
 		&PluginDependencyProfileItem{},
+		&CustomPlugin{},
+		&MavenMetadataCache{},
 	); err != nil {
 		t.Fatalf("failed to migrate plugin models: %v", err)
 	}
@@ -492,6 +497,8 @@ func TestListAvailablePluginsIgnoresLegacySeedCatalogSnapshot(t *testing.T) {
 func TestListAvailablePluginsFor2312UsesRemoteCatalogAndSeedDependencyBaseline(t *testing.T) {
 	service, _ := newTestPluginService(t)
 	ctx := context.Background()
+	// Without an offline index entry the catalog comes from Maven / 离线索引未收录时目录来自 Maven
+	service.index = &PluginIndex{SchemaVersion: PluginIndexSchemaVersion, Versions: map[string][]PluginIndexEntry{}}
 
 	service.SetPluginFetcher(func(ctx context.Context, version string, mirror MirrorSource) ([]Plugin, MirrorSource, error) {
 		return []Plugin{
@@ -1118,3 +1125,54 @@ func TestBuildPluginPreparationFingerprint_IsStableAndDetectsChanges(t *testing.
 		t.Fatalf("expected fingerprint to change when uploaded dependency payload changes")
 	}
 }
+
+// cachingAgentCommandSender reports every has_plugin lookup as a cache hit when cached is set.
+type cachingAgentCommandSender struct {
+	cached   bool
+	commands []map[string]string
+}
+
+func (s *cachingAgentCommandSender) SendCommand(ctx context.Context, agentID string, commandType string, params map[string]string) (bool, string, error) {
+	recorded := map[string]string{"command": commandType}
+	for key, value := range params {
+		recorded[key] = value
+	}
+	s.commands = append(s.commands, recorded)
+	if commandType == "has_plugin" {
+		output, _ := json.Marshal(map[string]interface{}{
+			"success": true,
+			"details": map[string]string{"cached": strconv.FormatBool(s.cached)},
+		})
+		return true, string(output), nil
+	}
+	return true, "ok", nil
+}
+
+func TestTransferFileToAgentSkipsTransferOnAgentCacheHit(t *testing.T) {
+	service, _ := newTestPluginService(t)
+	filePath := filepath.Join(t.TempDir(), "connector-jdbc-2.3.12.jar")
+	if err := os.WriteFile(filePath, bytes.Repeat([]byte("x"), 3*1024*1024), 0644); err != nil {
+		t.Fatalf("write plugin file: %v", err)
+	}
+
+	hit := &cachingAgentCommandSender{cached: true}
+	service.SetAgentCommandSender(hit)
+	if err := service.transferFileToAgent(context.Background(), "agent-1", "jdbc", "2.3.12", "connector", "connectors", "connector-jdbc-2.3.12.jar", filePath, "/opt/seatunnel"); err != nil {
+		t.Fatalf("transferFileToAgent returned error: %v", err)
+	}
+	if len(hit.commands) != 2 || hit.commands[0]["command"] != "has_plugin" || hit.commands[1]["from_cache"] != "true" {
+		t.Fatalf("expected has_plugin lookup followed by cached install, got %v", hit.commands)
+	}
+	if hit.commands[1]["chunk"] != "" || hit.commands[1]["checksum"] != hit.commands[0]["checksum"] {
+		t.Fatalf("expected cached install without file data, got %v", hit.commands[1])
+	}
+
+	miss := &cachingAgentCommandSender{}
+	service.SetAgentCommandSender(miss)
+	if err := service.transferFileToAgent(context.Background(), "agent-2", "jdbc", "2.3.12", "connector", "connectors", "connector-jdbc-2.3.12.jar", filePath, "/opt/seatunnel"); err != nil {
+		t.Fatalf("transferFileToAgent returned error: %v", err)
+	}
+	if len(miss.commands) != 4 || miss.commands[1]["chunk"] == "" || miss.commands[1]["from_cache"] != "" {
+		t.Fatalf("expected has_plugin lookup followed by 3 chunks, got %d commands", len(miss.commands))
+	}
+}
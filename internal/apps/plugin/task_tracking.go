@@ -0,0 +1,48 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package plugin
+
+import (
+	"context"
+
+	"github.com/seatunnel/seatunnelX/internal/apps/task"
+)
+
+// SetTaskManager sets the task manager used to record cluster plugin operations as tasks.
+// SetTaskManager 设置用于将集群插件操作记录为任务的任务管理器。
+func (s *Service) SetTaskManager(manager *task.Manager) {
+	s.taskManager = manager
+}
+
+// runPluginTask runs a cluster plugin operation as a synchronous task; progress reported
+// through setInstallProgress is mirrored into the task.
+// runPluginTask 将集群插件操作作为同步任务运行；通过 setInstallProgress 上报的进度会同步到任务。
+func (s *Service) runPluginTask(ctx context.Context, taskType task.TaskType, clusterID uint, payload interface{}, run func(ctx context.Context) (map[string]interface{}, error)) (*task.Task, error) {
+	params, err := task.ParamsFrom(payload)
+	if err != nil {
+		return nil, err
+	}
+	return s.taskManager.Run(ctx, &task.CreateTaskRequest{
+		Type:      taskType,
+		ClusterID: clusterID,
+		Params:    params,
+		Source:    task.TaskSourcePlugin,
+	}, "", func(ctx context.Context, _ *task.Task, _ *task.Reporter) (map[string]interface{}, error) {
+		return run(ctx)
+	})
+}
@@ -20,7 +20,12 @@
 package plugin
 
 import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
 	"time"
+
+	"github.com/seatunnel/seatunnelX/internal/pkg/pagex"
 )
 
 // PluginCategory represents the category of a plugin.
@@ -73,6 +78,7 @@ type PluginDependency struct {
 	SourceType       PluginDependencySource `json:"source_type,omitempty"`        // 依赖来源 / Dependency source
 	OriginalFileName string                 `json:"original_file_name,omitempty"` // 原始上传文件名 / Original uploaded file name
 	StoredPath       string                 `json:"-"`                            // 控制面存储路径（仅服务端内部使用）/ Stored path (server-side only)
+	ResolvedFrom     string                 `json:"resolved_from,omitempty"`      // 传递依赖来源坐标 / Root coordinate this transitive dependency was resolved from
 }
 
 // PluginDependencySource represents the source type of an effective dependency.
@@ -100,6 +106,7 @@ type PluginDependencyConfig struct {
 	StoredPath       string                 `gorm:"size:1024" json:"-"`                                                               // 控制面存储路径 / Stored path
 	FileSize         int64                  `gorm:"not null;default:0" json:"file_size,omitempty"`                                    // 文件大小 / File size
 	Checksum         string                 `gorm:"size:128" json:"checksum,omitempty"`                                               // 文件摘要 / File checksum
+	ResolvedFrom     string                 `gorm:"size:400;not null;default:'';index" json:"resolved_from,omitempty"`                // 传递依赖来源坐标 / Root coordinate for transitive dependencies
 	CreatedAt        time.Time              `json:"created_at"`
 	UpdatedAt        time.Time              `json:"updated_at"`
 }
@@ -147,6 +154,7 @@ type Plugin struct {
 	DependencyCount           int                      `json:"dependency_count,omitempty"`            // 生效依赖数量 / Effective dependency count
 	DependencyBaselineVersion string                   `json:"dependency_baseline_version,omitempty"` // 依赖基线版本 / Dependency baseline version
 	DependencyResolutionMode  DependencyResolutionMode `json:"dependency_resolution_mode,omitempty"`  // 依赖解析模式 / Dependency resolution mode
+	Custom                    bool                     `json:"custom,omitempty"`                      // 用户上传的自定义插件 / Uploaded custom plugin
 }
 
 // InstalledPlugin represents a plugin installed on a cluster (GORM model).
@@ -154,20 +162,60 @@ type Plugin struct {
 // Note: Plugins are managed at cluster level, not host level.
 // 注意：插件在集群级别管理，而非主机级别。
 type InstalledPlugin struct {
-	ID                  uint               `gorm:"primaryKey" json:"id"`
-	ClusterID           uint               `gorm:"index;not null" json:"cluster_id"`                 // 集群 ID / Cluster ID
-	PluginName          string             `gorm:"size:100;not null;index" json:"plugin_name"`       // 插件名称 / Plugin name
-	ArtifactID          string             `gorm:"size:100" json:"artifact_id"`                      // Maven artifact ID (e.g., connector-cdc-mysql)
-	Category            PluginCategory     `gorm:"size:20;not null" json:"category"`                 // 分类 / Category
-	Version             string             `gorm:"size:20;not null" json:"version"`                  // 版本号 / Version
-	Status              PluginStatus       `gorm:"size:20;not null;default:installed" json:"status"` // 状态 / Status
-	InstallPath         string             `gorm:"size:255" json:"install_path"`                     // 安装路径 / Install path
-	InstalledAt         time.Time          `gorm:"not null" json:"installed_at"`                     // 安装时间 / Installed at
-	UpdatedAt           time.Time          `json:"updated_at"`                                       // 更新时间 / Updated at
-	InstalledBy         uint               `json:"installed_by,omitempty"`                           // 安装者 ID / Installed by
-	SelectedProfileKeys []string           `gorm:"-" json:"selected_profile_keys,omitempty"`         // 选中的画像 / Selected profiles
-	AttachedConnectors  []string           `gorm:"-" json:"attached_connectors,omitempty"`           // 自动附带的连接器 / Attached connectors
-	Dependencies        []PluginDependency `gorm:"-" json:"dependencies,omitempty"`                  // 自动附带的依赖 / Attached dependencies
+	ID                  uint                        `gorm:"primaryKey" json:"id"`
+	ClusterID           uint                        `gorm:"index;not null" json:"cluster_id"`                 // 集群 ID / Cluster ID
+	PluginName          string                      `gorm:"size:100;not null;index" json:"plugin_name"`       // 插件名称 / Plugin name
+	ArtifactID          string                      `gorm:"size:100" json:"artifact_id"`                      // Maven artifact ID (e.g., connector-cdc-mysql)
+	Category            PluginCategory              `gorm:"size:20;not null" json:"category"`                 // 分类 / Category
+	Version             string                      `gorm:"size:20;not null" json:"version"`                  // 版本号 / Version
+	Status              PluginStatus                `gorm:"size:20;not null;default:installed" json:"status"` // 状态 / Status
+	InstallPath         string                      `gorm:"size:255" json:"install_path"`                     // 安装路径 / Install path
+	InstalledAt         time.Time                   `gorm:"not null" json:"installed_at"`                     // 安装时间 / Installed at
+	UpdatedAt           time.Time                   `json:"updated_at"`                                       // 更新时间 / Updated at
+	InstalledBy         uint                        `json:"installed_by,omitempty"`                           // 安装者 ID / Installed by
+	SelectedProfileKeys []string                    `gorm:"-" json:"selected_profile_keys,omitempty"`         // 选中的画像 / Selected profiles
+	AttachedConnectors  []string                    `gorm:"-" json:"attached_connectors,omitempty"`           // 自动附带的连接器 / Attached connectors
+	Dependencies        InstalledPluginDependencies `gorm:"type:text" json:"dependencies,omitempty"`          // 随插件安装的依赖 / Dependencies installed with the plugin
+}
+
+// InstalledPluginDependencies is the dependency list persisted on an installed-plugin record.
+// InstalledPluginDependencies 是持久化在已安装插件记录上的依赖列表。
+type InstalledPluginDependencies []PluginDependency
+
+// Value implements driver.Valuer for database storage.
+// Value 实现 driver.Valuer 以便存储到数据库。
+func (d InstalledPluginDependencies) Value() (driver.Value, error) {
+	if len(d) == 0 {
+		return nil, nil
+	}
+	data, err := json.Marshal(d)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// Scan implements sql.Scanner for database retrieval.
+// Scan 实现 sql.Scanner 以便从数据库读取。
+func (d *InstalledPluginDependencies) Scan(value interface{}) error {
+	if value == nil {
+		*d = nil
+		return nil
+	}
+	var data []byte
+	switch v := value.(type) {
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return errors.New("plugin: failed to scan InstalledPluginDependencies - expected []byte or string")
+	}
+	if len(data) == 0 {
+		*d = nil
+		return nil
+	}
+	return json.Unmarshal(data, d)
 }
 
 // TableName returns the table name for InstalledPlugin.
@@ -185,6 +233,10 @@ type PluginFilter struct {
 	Keyword   string         `json:"keyword,omitempty"`    // 搜索关键词 / Search keyword
 	Page      int            `json:"page,omitempty"`       // 页码 / Page number
 	PageSize  int            `json:"page_size,omitempty"`  // 每页数量 / Page size
+	// InstalledAt restricts the installation time / InstalledAt 限制安装时间范围
+	InstalledAt pagex.TimeRange `json:"installed_at,omitempty"`
+	// Sort orders the result, newest first when empty / Sort 指定排序，为空时按安装时间倒序
+	Sort []pagex.SortField `json:"sort,omitempty"`
 }
 
 // InstallPluginRequest represents a request to install a plugin.
@@ -194,6 +246,9 @@ type InstallPluginRequest struct {
 	Version     string       `json:"version" binding:"required"`     // 版本号 / Version
 	Mirror      MirrorSource `json:"mirror,omitempty"`               // 镜像源 / Mirror source
 	ProfileKeys []string     `json:"profile_keys,omitempty"`         // 选中的依赖画像 / Selected dependency profiles
+	// AllowUnverified installs jars whose checksum or signature could not be verified
+	// AllowUnverified 允许安装校验和或签名无法校验的 jar
+	AllowUnverified bool `json:"allow_unverified,omitempty"`
 }
 
 // PluginInstallStatus represents the installation status of a plugin.
@@ -206,6 +261,94 @@ type PluginInstallStatus struct {
 	Error      string `json:"error,omitempty"`   // 错误信息 / Error message
 }
 
+// PluginOperationAction is the action of a bulk plugin operation.
+// PluginOperationAction 表示批量插件操作的动作。
+type PluginOperationAction string
+
+const (
+	PluginOperationInstall   PluginOperationAction = "install"
+	PluginOperationUninstall PluginOperationAction = "uninstall"
+)
+
+// PluginOperationStatus is the status of a bulk operation, one plugin in it, or one node.
+// PluginOperationStatus 表示批量操作、其中某个插件或某个节点的状态。
+type PluginOperationStatus string
+
+const (
+	PluginOperationStatusPending       PluginOperationStatus = "pending"
+	PluginOperationStatusRunning       PluginOperationStatus = "running"
+	PluginOperationStatusSucceeded     PluginOperationStatus = "succeeded"
+	PluginOperationStatusFailed        PluginOperationStatus = "failed"
+	PluginOperationStatusPartialFailed PluginOperationStatus = "partial_failed"
+)
+
+// BulkPluginItem identifies one plugin in a bulk operation.
+// BulkPluginItem 标识批量操作中的一个插件。
+type BulkPluginItem struct {
+	PluginName  string   `json:"plugin_name" binding:"required"` // 插件名称 / Plugin name
+	Version     string   `json:"version,omitempty"`              // 版本号（安装必填）/ Version (required for install)
+	ProfileKeys []string `json:"profile_keys,omitempty"`         // 选中的依赖画像 / Selected dependency profiles
+}
+
+// BulkPluginOperationRequest installs or uninstalls several plugins on every node of a cluster.
+// BulkPluginOperationRequest 在集群所有节点上批量安装或卸载多个插件。
+type BulkPluginOperationRequest struct {
+	Action      PluginOperationAction `json:"action" binding:"required"`             // install / uninstall
+	Plugins     []BulkPluginItem      `json:"plugins" binding:"required,min=1,dive"` // 插件列表 / Plugins
+	Mirror      MirrorSource          `json:"mirror,omitempty"`                      // 镜像源 / Mirror source
+	MaxParallel int                   `json:"max_parallel,omitempty"`                // 节点并发数（0 表示全部并发）/ Node parallelism (0 = all nodes)
+	// AllowUnverified installs jars whose checksum or signature could not be verified
+	// AllowUnverified 允许安装校验和或签名无法校验的 jar
+	AllowUnverified bool `json:"allow_unverified,omitempty"`
+}
+
+// RetryPluginOperationRequest selects failed nodes of an operation to run again.
+// Empty filters retry every failed node.
+// RetryPluginOperationRequest 选择操作中需要重试的失败节点，过滤条件为空时重试全部失败节点。
+type RetryPluginOperationRequest struct {
+	PluginNames []string `json:"plugin_names,omitempty"` // 仅重试这些插件 / Only retry these plugins
+	NodeIDs     []uint   `json:"node_ids,omitempty"`     // 仅重试这些节点 / Only retry these nodes
+}
+
+// PluginOperationNode is the progress of one plugin on one node.
+// PluginOperationNode 表示某个插件在某个节点上的进度。
+type PluginOperationNode struct {
+	NodeID    uint                  `json:"node_id"`            // 节点 ID / Node ID
+	HostID    uint                  `json:"host_id"`            // 主机 ID / Host ID
+	AgentID   string                `json:"agent_id,omitempty"` // Agent ID
+	Status    PluginOperationStatus `json:"status"`             // 状态 / Status
+	Attempts  int                   `json:"attempts"`           // 已执行次数 / Attempts so far
+	Message   string                `json:"message,omitempty"`  // 消息 / Message
+	Error     string                `json:"error,omitempty"`    // 错误信息 / Error message
+	UpdatedAt time.Time             `json:"updated_at"`         // 更新时间 / Updated at
+}
+
+// PluginOperationPlugin is the progress of one plugin across all nodes.
+// PluginOperationPlugin 表示某个插件在所有节点上的进度。
+type PluginOperationPlugin struct {
+	PluginName string                `json:"plugin_name"`       // 插件名称 / Plugin name
+	Version    string                `json:"version,omitempty"` // 版本号 / Version
+	Status     PluginOperationStatus `json:"status"`            // 状态 / Status
+	Progress   int                   `json:"progress"`          // 进度 (0-100) / Progress
+	Message    string                `json:"message,omitempty"` // 消息 / Message
+	Error      string                `json:"error,omitempty"`   // 插件级错误（如下载失败）/ Plugin-level error (e.g. download failure)
+	Nodes      []PluginOperationNode `json:"nodes"`             // 节点进度 / Per-node progress
+}
+
+// PluginOperation is a bulk install/uninstall operation and its structured progress.
+// PluginOperation 表示一次批量安装/卸载操作及其结构化进度。
+type PluginOperation struct {
+	ID         string                  `json:"id"`                    // 操作 ID / Operation ID
+	ClusterID  uint                    `json:"cluster_id"`            // 集群 ID / Cluster ID
+	Action     PluginOperationAction   `json:"action"`                // 动作 / Action
+	Status     PluginOperationStatus   `json:"status"`                // 状态 / Status
+	Progress   int                     `json:"progress"`              // 进度 (0-100) / Progress
+	Plugins    []PluginOperationPlugin `json:"plugins"`               // 插件进度 / Per-plugin progress
+	CreatedAt  time.Time               `json:"created_at"`            // 创建时间 / Created at
+	UpdatedAt  time.Time               `json:"updated_at"`            // 更新时间 / Updated at
+	FinishedAt *time.Time              `json:"finished_at,omitempty"` // 完成时间 / Finished at
+}
+
 // AvailablePluginsResponse represents the response for listing available plugins.
 // AvailablePluginsResponse 表示获取可用插件列表的响应。
 type PluginListSource string
@@ -213,6 +356,7 @@ type PluginListSource string
 const (
 	PluginListSourceDatabase PluginListSource = "database"
 	PluginListSourceRemote   PluginListSource = "remote"
+	PluginListSourceIndex    PluginListSource = "index"
 )
 
 type AvailablePluginsResponse struct {
@@ -288,6 +432,10 @@ type AddDependencyRequest struct {
 	ArtifactID       string `json:"artifact_id" binding:"required"` // Maven artifactId
 	Version          string `json:"version" binding:"required"`     // 版本号（必填）/ Version (required)
 	TargetDir        string `json:"target_dir,omitempty"`           // 目标目录（可选）/ Target directory
+	// ResolveTransitive also registers the artifact's runtime dependencies from its POM.
+	// ResolveTransitive 同时根据 POM 登记该构件的运行时传递依赖。
+	ResolveTransitive bool         `json:"resolve_transitive,omitempty"`
+	Mirror            MirrorSource `json:"mirror,omitempty"` // 解析 POM 使用的镜像源 / Mirror used to fetch POMs
 }
 
 // UploadDependencyRequest represents a request to upload a custom dependency jar.
@@ -301,6 +449,45 @@ type UploadDependencyRequest struct {
 	TargetDir        string `json:"target_dir,omitempty"`        // 目标目录 / Target directory
 }
 
+// CustomPlugin is a private connector jar uploaded by a user and offered in the marketplace (GORM model).
+// CustomPlugin 表示用户上传、在插件市场中提供的私有连接器 jar（GORM 模型）。
+type CustomPlugin struct {
+	ID               uint           `gorm:"primaryKey" json:"id"`
+	Name             string         `gorm:"size:120;not null;index:idx_custom_plugin_version_name,unique" json:"name"`             // 插件名称 / Plugin name
+	SeatunnelVersion string         `gorm:"size:50;not null;index:idx_custom_plugin_version_name,unique" json:"seatunnel_version"` // SeaTunnel 版本 / SeaTunnel version
+	DisplayName      string         `gorm:"size:200;not null" json:"display_name"`                                                 // 显示名称 / Display name
+	ArtifactID       string         `gorm:"size:200;not null" json:"artifact_id"`                                                  // 存储用 artifactId / Artifact ID used for storage
+	GroupID          string         `gorm:"size:200;not null" json:"group_id"`                                                     // 分组标识 / Group ID
+	Category         PluginCategory `gorm:"size:50;not null" json:"category"`                                                      // 分类 / Category
+	Description      string         `gorm:"type:text" json:"description"`                                                          // 描述 / Description
+	DocURL           string         `gorm:"size:500" json:"doc_url,omitempty"`                                                     // 文档链接 / Documentation URL
+	OriginalFileName string         `gorm:"size:255" json:"original_file_name"`                                                    // 原始文件名 / Original uploaded file name
+	FileSize         int64          `gorm:"not null;default:0" json:"file_size"`                                                   // 文件大小 / File size
+	SHA256           string         `gorm:"size:64" json:"sha256"`                                                                 // 文件摘要 / File digest
+	UploadedBy       string         `gorm:"size:100" json:"uploaded_by,omitempty"`                                                 // 上传人 / Uploaded by
+	CreatedAt        time.Time      `json:"created_at"`
+	UpdatedAt        time.Time      `json:"updated_at"`
+}
+
+// TableName returns the table name for CustomPlugin.
+// TableName 返回 CustomPlugin 的表名。
+func (CustomPlugin) TableName() string {
+	return "custom_plugins"
+}
+
+// UploadCustomPluginRequest represents a request to upload a custom connector jar.
+// UploadCustomPluginRequest 表示上传自定义连接器 Jar 的请求。
+type UploadCustomPluginRequest struct {
+	Name             string         `json:"name"`                        // 插件名称 / Plugin name
+	SeatunnelVersion string         `json:"seatunnel_version,omitempty"` // SeaTunnel 版本 / SeaTunnel version
+	DisplayName      string         `json:"display_name,omitempty"`      // 显示名称 / Display name
+	GroupID          string         `json:"group_id,omitempty"`          // 分组（可选）/ Group ID (optional)
+	Category         PluginCategory `json:"category,omitempty"`          // 分类（可选）/ Category (optional)
+	Description      string         `json:"description,omitempty"`       // 描述 / Description
+	DocURL           string         `json:"doc_url,omitempty"`           // 文档链接 / Documentation URL
+	UploadedBy       string         `json:"-"`                           // 上传人 / Uploaded by
+}
+
 // DisableDependencyRequest represents a request to disable one official dependency item.
 // DisableDependencyRequest 表示禁用一条官方依赖的请求。
 type DisableDependencyRequest struct {
@@ -357,6 +544,7 @@ type PluginCatalogSource string
 const (
 	PluginCatalogSourceSeed   PluginCatalogSource = "seed"
 	PluginCatalogSourceRemote PluginCatalogSource = "remote"
+	PluginCatalogSourceIndex  PluginCatalogSource = "index" // 离线插件索引 / Offline plugin index
 )
 
 // PluginCatalogEntry stores discovered connector metadata in DB.
@@ -0,0 +1,168 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha512"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"golang.org/x/crypto/openpgp"
+	"golang.org/x/crypto/openpgp/armor"
+)
+
+var testJarContent = []byte("connector jar content")
+
+func newTestArtifactServer(t *testing.T, files map[string][]byte) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := files[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write(body)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func writeTestJar(t *testing.T) string {
+	t.Helper()
+	jarPath := filepath.Join(t.TempDir(), "connector-jdbc-2.3.12.jar")
+	if err := os.WriteFile(jarPath, testJarContent, 0644); err != nil {
+		t.Fatalf("write jar: %v", err)
+	}
+	return jarPath
+}
+
+func TestVerifyArtifactPrefersStrongestChecksum(t *testing.T) {
+	digest := sha512.Sum512(testJarContent)
+	server := newTestArtifactServer(t, map[string][]byte{
+		"/connector-jdbc-2.3.12.jar.sha512": []byte(hex.EncodeToString(digest[:]) + "  connector-jdbc-2.3.12.jar\n"),
+		"/connector-jdbc-2.3.12.jar.sha1":   []byte("0000000000000000000000000000000000000000"),
+	})
+	downloader := NewDownloader(t.TempDir())
+	jarPath := writeTestJar(t)
+
+	verification, err := downloader.verifyArtifact(context.Background(), jarPath, server.URL+"/connector-jdbc-2.3.12.jar", "org.apache.seatunnel")
+	if err != nil {
+		t.Fatalf("verifyArtifact returned error: %v", err)
+	}
+	if verification.ChecksumAlgorithm != "sha512" || !verification.Verified() {
+		t.Fatalf("expected sha512 verification, got %+v", verification)
+	}
+
+	stored, err := ReadArtifactVerification(jarPath)
+	if err != nil || stored == nil || stored.SHA256 == "" {
+		t.Fatalf("expected persisted verification record, got %+v (%v)", stored, err)
+	}
+}
+
+func TestVerifyArtifactRejectsMismatchAndRecordsUnavailable(t *testing.T) {
+	server := newTestArtifactServer(t, map[string][]byte{
+		"/bad.jar.sha256": []byte("deadbeef"),
+	})
+	downloader := NewDownloader(t.TempDir())
+	jarPath := writeTestJar(t)
+	ctx := context.Background()
+
+	if _, err := downloader.verifyArtifact(ctx, jarPath, server.URL+"/bad.jar", "org.example"); !errors.Is(err, ErrChecksumMismatch) {
+		t.Fatalf("expected ErrChecksumMismatch, got %v", err)
+	}
+
+	verification, err := downloader.verifyArtifact(ctx, jarPath, server.URL+"/missing.jar", "org.example")
+	if err != nil {
+		t.Fatalf("verifyArtifact returned error: %v", err)
+	}
+	if verification.ChecksumStatus != VerificationStatusUnavailable || verification.Verified() {
+		t.Fatalf("expected unavailable checksum, got %+v", verification)
+	}
+
+	service, _ := newTestPluginService(t)
+	if err := service.ensureArtifactVerified(jarPath, false); !errors.Is(err, ErrArtifactUnverified) {
+		t.Fatalf("expected ErrArtifactUnverified, got %v", err)
+	}
+	if err := service.ensureArtifactVerified(jarPath, true); err != nil {
+		t.Fatalf("allow_unverified should bypass verification, got %v", err)
+	}
+	if err := service.ensureArtifactVerified(filepath.Join(t.TempDir(), "unknown.jar"), false); !errors.Is(err, ErrArtifactUnverified) {
+		t.Fatalf("jars without a verification record must be refused, got %v", err)
+	}
+}
+
+func TestVerifyArtifactChecksSignature(t *testing.T) {
+	signer, err := openpgp.NewEntity("Release Manager", "", "rm@example.org", nil)
+	if err != nil {
+		t.Fatalf("create key: %v", err)
+	}
+	var keys bytes.Buffer
+	keyWriter, err := armor.Encode(&keys, openpgp.PublicKeyType, nil)
+	if err != nil {
+		t.Fatalf("armor keys: %v", err)
+	}
+	if err := signer.Serialize(keyWriter); err != nil {
+		t.Fatalf("serialize key: %v", err)
+	}
+	_ = keyWriter.Close()
+
+	var signature bytes.Buffer
+	if err := openpgp.ArmoredDetachSign(&signature, signer, bytes.NewReader(testJarContent), nil); err != nil {
+		t.Fatalf("sign jar: %v", err)
+	}
+
+	server := newTestArtifactServer(t, map[string][]byte{
+		"/KEYS":                              keys.Bytes(),
+		"/connector-jdbc-2.3.12.jar.asc":     signature.Bytes(),
+		"/connector-tampered-2.3.12.jar.asc": signature.Bytes(),
+	})
+	downloader := NewDownloader(t.TempDir())
+	downloader.SetSignatureVerification(true, server.URL+"/KEYS")
+	jarPath := writeTestJar(t)
+	ctx := context.Background()
+
+	verification, err := downloader.verifyArtifact(ctx, jarPath, server.URL+"/connector-jdbc-2.3.12.jar", "org.apache.seatunnel")
+	if err != nil {
+		t.Fatalf("verifyArtifact returned error: %v", err)
+	}
+	if verification.SignatureStatus != VerificationStatusVerified || verification.SignatureKeyID != signer.PrimaryKey.KeyIdString() {
+		t.Fatalf("expected verified signature, got %+v", verification)
+	}
+
+	if err := os.WriteFile(jarPath, []byte("tampered"), 0644); err != nil {
+		t.Fatalf("tamper jar: %v", err)
+	}
+	if _, err := downloader.verifyArtifact(ctx, jarPath, server.URL+"/connector-tampered-2.3.12.jar", "org.apache.seatunnel"); !errors.Is(err, ErrSignatureInvalid) {
+		t.Fatalf("expected ErrSignatureInvalid, got %v", err)
+	}
+
+	verification, err = downloader.verifyArtifact(ctx, jarPath, server.URL+"/connector-unsigned-2.3.12.jar", "org.apache.seatunnel")
+	if err != nil {
+		t.Fatalf("verifyArtifact returned error: %v", err)
+	}
+	if verification.SignatureStatus != VerificationStatusUnavailable {
+		t.Fatalf("expected unavailable signature, got %+v", verification)
+	}
+}
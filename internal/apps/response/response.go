@@ -0,0 +1,125 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package response writes the standard JSON envelope of the HTTP API.
+// response 包输出 HTTP API 的标准 JSON 响应信封。
+//
+// Every response carries code, message, data and request_id. Failures also repeat the message in
+// error_msg and the code in error_code, the fields clients used before the envelope existed.
+// 每个响应都包含 code、message、data 与 request_id。失败响应还会在 error_msg 与 error_code 中
+// 重复消息与错误码，这两个字段是引入信封之前客户端使用的字段。
+package response
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/seatunnel/seatunnelX/internal/pkg/errcode"
+	"github.com/seatunnel/seatunnelX/internal/pkg/requestid"
+)
+
+// CodeOK is the code of successful responses.
+// CodeOK 是成功响应的 code。
+const CodeOK = "OK"
+
+// Meta holds the envelope fields shared by all responses; typed responses embed it next to Data.
+// Meta 保存所有响应共有的信封字段；具体类型的响应将其与 Data 一起嵌入。
+type Meta struct {
+	// Code is "OK" on success or an error code such as ST-HOST-001 / Code 成功时为 "OK"，失败时为错误码
+	Code string `json:"code" example:"OK"`
+	// Message describes a failure or a success warning / Message 描述失败原因或成功时的警告
+	Message string `json:"message"`
+	// RequestID matches the X-Request-ID header, logs and audit records / RequestID 与 X-Request-ID 响应头、日志及审计记录一致
+	RequestID string `json:"request_id"`
+	// ErrorMsg repeats Message (deprecated) / ErrorMsg 重复 Message（已废弃）
+	ErrorMsg string `json:"error_msg"`
+	// ErrorCode repeats Code on failures (deprecated) / ErrorCode 失败时重复 Code（已废弃）
+	ErrorCode string `json:"error_code,omitempty"`
+}
+
+// Envelope is the response body written by the helpers.
+// Envelope 是辅助函数写出的响应体。
+type Envelope struct {
+	Meta
+	Data any `json:"data"`
+}
+
+// ErrorEnvelope documents failed responses in the OpenAPI spec.
+// ErrorEnvelope 用于在 OpenAPI 文档中描述失败响应。
+type ErrorEnvelope struct {
+	Meta
+	Data any `json:"data" swaggertype:"object"`
+}
+
+// OK writes data with status 200.
+// OK 以 200 状态码写出 data。
+func OK(c *gin.Context, data any) {
+	JSON(c, http.StatusOK, data)
+}
+
+// JSON writes data with a success status.
+// JSON 以成功状态码写出 data。
+func JSON(c *gin.Context, status int, data any) {
+	c.JSON(status, Envelope{Meta: NewMeta(c, status, ""), Data: data})
+}
+
+// Error writes a failure with message. The code is the one attached with errcode.Attach, else the
+// first coded error in c.Errors, else the general code for status.
+// Error 写出带 message 的失败响应。错误码优先取 errcode.Attach 记录的值，其次取 c.Errors 中带错误码的错误，
+// 最后按状态码取通用错误码。
+func Error(c *gin.Context, status int, message string) {
+	ErrorWithData(c, status, message, nil)
+}
+
+// ErrorWithData writes a failure that still carries data, such as partial results. With a status
+// below 400 the message is a warning on an otherwise successful response.
+// ErrorWithData 写出仍携带 data（如部分结果）的失败响应。状态码低于 400 时 message 作为成功响应的警告。
+func ErrorWithData(c *gin.Context, status int, message string, data any) {
+	c.JSON(status, Envelope{Meta: NewMeta(c, status, message), Data: data})
+}
+
+// ErrorCode resolves the error code of a failed request.
+// ErrorCode 解析失败请求的错误码。
+func ErrorCode(c *gin.Context, status int) errcode.Code {
+	if value, ok := c.Get(errcode.ContextKey); ok {
+		if code, ok := value.(errcode.Code); ok && code != "" {
+			return code
+		}
+	}
+	for _, ginErr := range c.Errors {
+		if code, ok := errcode.Of(ginErr.Err); ok {
+			return code
+		}
+	}
+	return errcode.ForHTTPStatus(status)
+}
+
+// NewMeta builds the envelope fields for typed responses that carry extra top-level fields.
+// NewMeta 为携带额外顶层字段的具体类型响应构造信封字段。
+func NewMeta(c *gin.Context, status int, message string) Meta {
+	meta := Meta{
+		Code:      CodeOK,
+		Message:   message,
+		RequestID: requestid.FromContext(c.Request.Context()),
+		ErrorMsg:  message,
+	}
+	if status >= http.StatusBadRequest {
+		meta.Code = string(ErrorCode(c, status))
+		meta.ErrorCode = meta.Code
+	}
+	return meta
+}
@@ -0,0 +1,95 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package response
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/seatunnel/seatunnelX/internal/pkg/errcode"
+	"github.com/seatunnel/seatunnelX/internal/pkg/requestid"
+)
+
+func perform(t *testing.T, handler gin.HandlerFunc) (int, map[string]any) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	c.Request = req.WithContext(requestid.WithContext(req.Context(), "req-1"))
+	handler(c)
+
+	var body map[string]any
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decode body: %v", err)
+	}
+	return w.Code, body
+}
+
+func TestOKWritesEnvelope(t *testing.T) {
+	status, body := perform(t, func(c *gin.Context) { OK(c, map[string]int{"total": 3}) })
+	if status != http.StatusOK {
+		t.Fatalf("expected 200, got %d", status)
+	}
+	if body["code"] != CodeOK || body["message"] != "" || body["error_msg"] != "" || body["request_id"] != "req-1" {
+		t.Fatalf("unexpected envelope: %v", body)
+	}
+	if _, ok := body["error_code"]; ok {
+		t.Fatalf("success responses must not carry error_code: %v", body)
+	}
+	if data, ok := body["data"].(map[string]any); !ok || data["total"] != float64(3) {
+		t.Fatalf("unexpected data: %v", body["data"])
+	}
+}
+
+func TestErrorResolvesCode(t *testing.T) {
+	_, body := perform(t, func(c *gin.Context) { Error(c, http.StatusNotFound, "missing") })
+	if body["code"] != string(errcode.NotFound) || body["error_code"] != body["code"] || body["message"] != "missing" || body["error_msg"] != "missing" {
+		t.Fatalf("unexpected envelope: %v", body)
+	}
+
+	_, body = perform(t, func(c *gin.Context) {
+		errcode.Attach(c, errcode.RateLimited)
+		Error(c, http.StatusBadRequest, "slow down")
+	})
+	if body["code"] != string(errcode.RateLimited) {
+		t.Fatalf("expected attached code, got %v", body["code"])
+	}
+
+	_, body = perform(t, func(c *gin.Context) {
+		_ = c.Error(errcode.Wrap(errcode.Conflict, errors.New("busy")))
+		Error(c, http.StatusInternalServerError, "busy")
+	})
+	if body["code"] != string(errcode.Conflict) {
+		t.Fatalf("expected code from c.Errors, got %v", body["code"])
+	}
+}
+
+func TestErrorWithDataBelow400IsWarning(t *testing.T) {
+	_, body := perform(t, func(c *gin.Context) { ErrorWithData(c, http.StatusOK, "using fallback list", []string{"2.3.9"}) })
+	if body["code"] != CodeOK || body["message"] != "using fallback list" {
+		t.Fatalf("unexpected envelope: %v", body)
+	}
+	if _, ok := body["error_code"]; ok {
+		t.Fatalf("warnings must not carry error_code: %v", body)
+	}
+}
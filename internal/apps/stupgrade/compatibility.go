@@ -0,0 +1,432 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package stupgrade
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/seatunnel/seatunnelX/internal/seatunnel"
+	"gopkg.in/yaml.v3"
+)
+
+// connectorBuildCheckWorkers 限制并发的连接器构建检查数。
+// connectorBuildCheckWorkers bounds the concurrent connector build lookups.
+const connectorBuildCheckWorkers = 8
+
+// ConnectorBuildChecker 定义检查连接器是否发布了目标版本构建的能力。
+// ConnectorBuildChecker defines the lookup of published connector builds for a target version.
+type ConnectorBuildChecker interface {
+	HasConnectorBuild(ctx context.Context, pluginName, version string) (bool, error)
+}
+
+// capabilityConfigKeys 是各版本能力对应的配置键，用于报告升级后新增的配置项。
+// capabilityConfigKeys maps version-gated capabilities to the config keys they introduce.
+var capabilityConfigKeys = map[seatunnel.Capability]string{
+	seatunnel.CapabilityHistoryJobExpireMinutes: "seatunnel.engine.history-job-expire-minutes",
+	seatunnel.CapabilityJobLogMode:              "log4j2.properties#routing",
+	seatunnel.CapabilityScheduledDeletionEnable: "seatunnel.engine.telemetry.logs.scheduled-deletion-enable",
+	seatunnel.CapabilityJobScheduleStrategy:     "seatunnel.engine.job-schedule-strategy",
+	seatunnel.CapabilityHTTPService:             "seatunnel.engine.http",
+	seatunnel.CapabilitySlotAllocationStrategy:  "seatunnel.engine.slot-service.slot-allocation-strategy",
+}
+
+// capabilityManualActions 是跨过某项能力的最低版本时需要手动处理的事项。
+// capabilityManualActions lists the manual actions required when an upgrade crosses a capability's first version.
+var capabilityManualActions = map[seatunnel.Capability]CompatibilityItem{
+	seatunnel.CapabilityHTTPService: {
+		Severity: CompatibilitySeverityWarning,
+		Code:     "review_rest_api_clients",
+		Subject:  "seatunnel.engine.http",
+		Message:  "the REST API v2 is served from seatunnel.engine.http; review clients of the Hazelcast REST endpoints / REST API v2 由 seatunnel.engine.http 提供，请检查仍在调用 Hazelcast REST 接口的客户端",
+	},
+	seatunnel.CapabilityJobLogMode: {
+		Severity: CompatibilitySeverityInfo,
+		Code:     "merge_log4j2_routing",
+		Subject:  "log4j2.properties",
+		Message:  "log4j2.properties gains the routing appender used for per-job logs; take the target file when merging / log4j2.properties 新增用于单作业日志的 routing appender，合并配置时请采用目标版本文件",
+	},
+}
+
+// buildCompatibilityReport 生成从源版本升级到目标版本的兼容性报告。
+// buildCompatibilityReport generates the compatibility report of upgrading from the source to the target version.
+func (s *Service) buildCompatibilityReport(ctx context.Context, sourceVersion, targetVersion string, connectors []string, mergePlan *ConfigMergePlan, nodeTargets []NodeTarget) *CompatibilityReport {
+	report := &CompatibilityReport{
+		SourceVersion:   sourceVersion,
+		TargetVersion:   targetVersion,
+		ConfigChanges:   make([]CompatibilityItem, 0),
+		Connectors:      make([]CompatibilityItem, 0),
+		CheckpointNotes: make([]CompatibilityItem, 0),
+		ManualActions:   make([]CompatibilityItem, 0),
+		GeneratedAt:     time.Now(),
+	}
+
+	report.ManualActions = append(report.ManualActions, versionPathItems(sourceVersion, targetVersion)...)
+	for _, capability := range crossedCapabilities(sourceVersion, targetVersion) {
+		report.ConfigChanges = append(report.ConfigChanges, compatibilityItem(
+			CompatibilitySeverityInfo,
+			"config_key_introduced",
+			capabilityConfigKeys[capability],
+			fmt.Sprintf("%s is available since %s / %s 自 %s 起可用", capabilityConfigKeys[capability], seatunnel.MinVersionFor(capability), capabilityConfigKeys[capability], seatunnel.MinVersionFor(capability)),
+			map[string]string{"since": seatunnel.MinVersionFor(capability)},
+		))
+		if action, ok := capabilityManualActions[capability]; ok {
+			report.ManualActions = append(report.ManualActions, compatibilityItem(action.Severity, action.Code, action.Subject, action.Message, map[string]string{"since": seatunnel.MinVersionFor(capability)}))
+		}
+	}
+	if mergePlan != nil {
+		report.ConfigChanges = append(report.ConfigChanges, configKeyChanges(mergePlan.Files)...)
+		report.CheckpointNotes = checkpointNotes(mergePlan.Files, sourceVersion, targetVersion, nodeTargets)
+	}
+	report.Connectors = s.checkConnectorBuilds(ctx, targetVersion, connectors)
+	for _, item := range report.Connectors {
+		if item.Severity == CompatibilitySeverityFail {
+			report.ManualActions = append(report.ManualActions, compatibilityItem(
+				CompatibilitySeverityFail,
+				"replace_connector",
+				item.Subject,
+				fmt.Sprintf("remove connector %s from the cluster or provide a build for %s before upgrading / 升级前请从集群移除连接器 %s，或提供其 %s 版本构建", item.Subject, targetVersion, item.Subject, targetVersion),
+				nil,
+			))
+		}
+	}
+	report.Status = compatibilityStatus(report)
+	return report
+}
+
+// versionPathItems 检查升级路径本身：禁止降级，跨越次版本线时给出警告。
+// versionPathItems checks the upgrade path itself: downgrades fail and crossing a minor release line warns.
+func versionPathItems(sourceVersion, targetVersion string) []CompatibilityItem {
+	items := make([]CompatibilityItem, 0)
+	if strings.TrimSpace(sourceVersion) == "" {
+		items = append(items, compatibilityItem(
+			CompatibilitySeverityWarning,
+			"source_version_unknown",
+			"",
+			"the current cluster version is unknown, version-specific changes cannot be listed / 当前集群版本未知，无法列出版本相关变化",
+			nil,
+		))
+		return items
+	}
+	if seatunnel.CompareVersions(targetVersion, sourceVersion) < 0 {
+		items = append(items, compatibilityItem(
+			CompatibilitySeverityFail,
+			"downgrade_not_supported",
+			"",
+			fmt.Sprintf("downgrading from %s to %s is not supported / 不支持从 %s 降级到 %s", sourceVersion, targetVersion, sourceVersion, targetVersion),
+			nil,
+		))
+		return items
+	}
+	if releaseLine(sourceVersion) != releaseLine(targetVersion) {
+		items = append(items, compatibilityItem(
+			CompatibilitySeverityWarning,
+			"release_line_changed",
+			"",
+			fmt.Sprintf("the upgrade crosses from %s.x to %s.x; review the release notes for breaking changes / 升级从 %s.x 跨越到 %s.x，请查阅发行说明中的不兼容变更", releaseLine(sourceVersion), releaseLine(targetVersion), releaseLine(sourceVersion), releaseLine(targetVersion)),
+			nil,
+		))
+	}
+	return items
+}
+
+// releaseLine 返回版本号的主次版本部分，例如 2.3.12 返回 2.3。
+// releaseLine returns the major.minor part of a version, e.g. 2.3 for 2.3.12.
+func releaseLine(version string) string {
+	parts := strings.SplitN(strings.TrimSpace(version), ".", 3)
+	if len(parts) < 2 {
+		return strings.Join(parts, ".")
+	}
+	return parts[0] + "." + parts[1]
+}
+
+// crossedCapabilities 返回首个支持版本位于 (source, target] 区间内的能力。
+// crossedCapabilities returns the capabilities whose first version lies in (source, target].
+func crossedCapabilities(sourceVersion, targetVersion string) []seatunnel.Capability {
+	if strings.TrimSpace(sourceVersion) == "" {
+		return nil
+	}
+	capabilities := make([]seatunnel.Capability, 0)
+	for capability := range capabilityConfigKeys {
+		if !seatunnel.SupportsCapability(sourceVersion, capability) && seatunnel.SupportsCapability(targetVersion, capability) {
+			capabilities = append(capabilities, capability)
+		}
+	}
+	sort.Slice(capabilities, func(i, j int) bool {
+		left, right := seatunnel.MinVersionFor(capabilities[i]), seatunnel.MinVersionFor(capabilities[j])
+		if left == right {
+			return capabilities[i] < capabilities[j]
+		}
+		return seatunnel.CompareVersions(left, right) < 0
+	})
+	return capabilities
+}
+
+// configKeyChanges 比较集群当前 YAML 配置与目标版本默认配置的键集合。
+// configKeyChanges compares the key sets of the cluster's YAML configs with the target version defaults.
+func configKeyChanges(files []ConfigMergeFile) []CompatibilityItem {
+	items := make([]CompatibilityItem, 0)
+	for _, file := range files {
+		if !strings.HasSuffix(file.ConfigType, ".yaml") || strings.TrimSpace(file.TargetContent) == "" {
+			continue
+		}
+		localKeys, localErr := flattenYAMLKeys(file.LocalContent)
+		targetKeys, targetErr := flattenYAMLKeys(file.TargetContent)
+		if localErr != nil || targetErr != nil {
+			continue
+		}
+		for _, key := range sortedKeys(targetKeys) {
+			if _, ok := localKeys[key]; !ok {
+				items = append(items, compatibilityItem(
+					CompatibilitySeverityInfo,
+					"config_key_added",
+					key,
+					fmt.Sprintf("%s: %s is new in the target default config / %s：%s 为目标版本默认配置新增项", file.ConfigType, key, file.ConfigType, key),
+					map[string]string{"config_type": file.ConfigType},
+				))
+			}
+		}
+		for _, key := range sortedKeys(localKeys) {
+			if _, ok := targetKeys[key]; !ok {
+				items = append(items, compatibilityItem(
+					CompatibilitySeverityWarning,
+					"config_key_not_in_target",
+					key,
+					fmt.Sprintf("%s: %s is not in the target default config, it may have been renamed or removed / %s：目标版本默认配置中没有 %s，可能已被重命名或移除", file.ConfigType, key, file.ConfigType, key),
+					map[string]string{"config_type": file.ConfigType},
+				))
+			}
+		}
+	}
+	return items
+}
+
+// flattenYAMLKeys 将 YAML 映射展开为以点号连接的叶子键集合，序列视为叶子。
+// flattenYAMLKeys flattens YAML mappings into dotted leaf keys; sequences count as leaves.
+func flattenYAMLKeys(content string) (map[string]struct{}, error) {
+	keys := make(map[string]struct{})
+	var root yaml.Node
+	if err := yaml.Unmarshal([]byte(content), &root); err != nil {
+		return nil, err
+	}
+	if len(root.Content) == 0 {
+		return keys, nil
+	}
+	var walk func(node *yaml.Node, prefix string)
+	walk = func(node *yaml.Node, prefix string) {
+		if node.Kind != yaml.MappingNode {
+			if prefix != "" {
+				keys[prefix] = struct{}{}
+			}
+			return
+		}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key := node.Content[i].Value
+			if prefix != "" {
+				key = prefix + "." + key
+			}
+			walk(node.Content[i+1], key)
+		}
+	}
+	walk(root.Content[0], "")
+	return keys, nil
+}
+
+// checkpointNotes 根据集群 seatunnel.yaml 中的检查点存储配置给出升级说明。
+// checkpointNotes derives upgrade notes from the checkpoint storage configured in the cluster's seatunnel.yaml.
+func checkpointNotes(files []ConfigMergeFile, sourceVersion, targetVersion string, nodeTargets []NodeTarget) []CompatibilityItem {
+	notes := make([]CompatibilityItem, 0)
+	var content string
+	for _, file := range files {
+		if file.ConfigType == "seatunnel.yaml" {
+			content = file.LocalContent
+			break
+		}
+	}
+	var config struct {
+		Seatunnel struct {
+			Engine struct {
+				Checkpoint struct {
+					Storage struct {
+						Type         string            `yaml:"type"`
+						PluginConfig map[string]string `yaml:"plugin-config"`
+					} `yaml:"storage"`
+				} `yaml:"checkpoint"`
+			} `yaml:"engine"`
+		} `yaml:"seatunnel"`
+	}
+	if err := yaml.Unmarshal([]byte(content), &config); err != nil {
+		return notes
+	}
+	storage := config.Seatunnel.Engine.Checkpoint.Storage
+	namespace := strings.TrimSpace(storage.PluginConfig["namespace"])
+	if storage.Type == "" && namespace == "" {
+		return notes
+	}
+
+	notes = append(notes, compatibilityItem(
+		CompatibilitySeverityInfo,
+		"checkpoint_restore_with_target_engine",
+		namespace,
+		fmt.Sprintf("jobs restored after the upgrade read checkpoints written by %s with the %s engine; stop jobs with a savepoint before upgrading / 升级后恢复的作业将由 %s 引擎读取 %s 写入的检查点，升级前请先以 savepoint 方式停止作业", sourceVersion, targetVersion, targetVersion, sourceVersion),
+		map[string]string{"storage_type": storage.Type},
+	))
+
+	// Local checkpoints under the old install directory are left behind by a side-by-side install
+	// 位于旧安装目录下的本地检查点不会随并行安装迁移
+	defaultFS := strings.TrimSpace(storage.PluginConfig["fs.defaultFS"])
+	if !strings.HasPrefix(defaultFS, "file:") || namespace == "" {
+		return notes
+	}
+	for _, target := range nodeTargets {
+		source := strings.TrimSuffix(target.SourceInstallDir, "/")
+		if source == "" || source == strings.TrimSuffix(target.TargetInstallDir, "/") {
+			continue
+		}
+		if !path.IsAbs(namespace) || strings.HasPrefix(path.Clean(namespace)+"/", source+"/") {
+			notes = append(notes, compatibilityItem(
+				CompatibilitySeverityWarning,
+				"checkpoint_namespace_in_install_dir",
+				namespace,
+				fmt.Sprintf("local checkpoint namespace %s lives under %s and is not carried to %s; move it or restart jobs without state / 本地检查点目录 %s 位于 %s 下，不会迁移到 %s，请迁移目录或以无状态方式重启作业", namespace, source, target.TargetInstallDir, namespace, source, target.TargetInstallDir),
+				map[string]string{"host": target.HostName},
+			))
+			break
+		}
+	}
+	return notes
+}
+
+// checkConnectorBuilds 检查每个连接器是否发布了目标版本的构建。
+// checkConnectorBuilds checks that every connector publishes a build for the target version.
+func (s *Service) checkConnectorBuilds(ctx context.Context, targetVersion string, connectors []string) []CompatibilityItem {
+	items := make([]CompatibilityItem, len(connectors))
+	if s.connectorChecker == nil {
+		for i, name := range connectors {
+			items[i] = compatibilityItem(CompatibilitySeverityInfo, "connector_build_not_checked", name,
+				fmt.Sprintf("connector %s was not checked against Maven / 未在 Maven 中核对连接器 %s", name, name), nil)
+		}
+		return items
+	}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	workers := connectorBuildCheckWorkers
+	if len(connectors) < workers {
+		workers = len(connectors)
+	}
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				items[i] = s.checkConnectorBuild(ctx, connectors[i], targetVersion)
+			}
+		}()
+	}
+	for i := range connectors {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	return items
+}
+
+func (s *Service) checkConnectorBuild(ctx context.Context, name, targetVersion string) CompatibilityItem {
+	metadata := map[string]string{"version": targetVersion}
+	available, err := s.connectorChecker.HasConnectorBuild(ctx, name, targetVersion)
+	switch {
+	case err != nil:
+		metadata["error"] = err.Error()
+		return compatibilityItem(CompatibilitySeverityWarning, "connector_build_unverified", name,
+			fmt.Sprintf("could not verify a %s build of connector %s / 无法确认连接器 %s 是否存在 %s 版本构建", targetVersion, name, name, targetVersion), metadata)
+	case !available:
+		return compatibilityItem(CompatibilitySeverityFail, "connector_build_missing", name,
+			fmt.Sprintf("connector %s has no build for %s / 连接器 %s 没有 %s 版本构建", name, targetVersion, name, targetVersion), metadata)
+	default:
+		return compatibilityItem(CompatibilitySeverityInfo, "connector_build_available", name,
+			fmt.Sprintf("connector %s is published for %s / 连接器 %s 已发布 %s 版本", name, targetVersion, name, targetVersion), metadata)
+	}
+}
+
+// compatibilityStatus 由最严重的条目决定报告结论。
+// compatibilityStatus derives the report verdict from its most severe item.
+func compatibilityStatus(report *CompatibilityReport) CompatibilityStatus {
+	status := CompatibilityStatusPass
+	for _, section := range [][]CompatibilityItem{report.ConfigChanges, report.Connectors, report.CheckpointNotes, report.ManualActions} {
+		for _, item := range section {
+			switch item.Severity {
+			case CompatibilitySeverityFail:
+				return CompatibilityStatusFail
+			case CompatibilitySeverityWarning:
+				status = CompatibilityStatusWarning
+			}
+		}
+	}
+	return status
+}
+
+// compatibilityIssues 将报告结论转换为预检查阻断问题：失败总是阻断，警告在未确认时阻断。
+// compatibilityIssues turns the report verdict into precheck issues: failures always block, warnings block until acknowledged.
+func compatibilityIssues(report *CompatibilityReport, acknowledged bool) []BlockingIssue {
+	switch report.Status {
+	case CompatibilityStatusFail:
+		return []BlockingIssue{blockingIssue(
+			CheckCategoryCompatibility,
+			"compatibility_report_failed",
+			"the compatibility report has failed items that must be fixed before upgrading / 兼容性报告存在必须在升级前处理的失败项",
+			nil,
+		)}
+	case CompatibilityStatusWarning:
+		report.Acknowledged = acknowledged
+		if acknowledged {
+			return nil
+		}
+		return []BlockingIssue{blockingIssue(
+			CheckCategoryCompatibility,
+			"compatibility_warnings_unacknowledged",
+			"acknowledge the compatibility report warnings to continue / 请确认兼容性报告中的警告后继续",
+			nil,
+		)}
+	}
+	return nil
+}
+
+func compatibilityItem(severity CompatibilitySeverity, code, subject, message string, metadata map[string]string) CompatibilityItem {
+	return CompatibilityItem{
+		Severity: severity,
+		Code:     code,
+		Subject:  subject,
+		Message:  normalizeUserVisibleText(message),
+		Metadata: metadata,
+	}
+}
+
+func sortedKeys(keys map[string]struct{}) []string {
+	result := make([]string, 0, len(keys))
+	for key := range keys {
+		result = append(result, key)
+	}
+	sort.Strings(result)
+	return result
+}
@@ -0,0 +1,199 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package stupgrade
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	appconfig "github.com/seatunnel/seatunnelX/internal/apps/config"
+	installerapp "github.com/seatunnel/seatunnelX/internal/apps/installer"
+	pluginapp "github.com/seatunnel/seatunnelX/internal/apps/plugin"
+	"github.com/seatunnel/seatunnelX/internal/seatunnel"
+)
+
+type stubConnectorBuildChecker struct {
+	builds map[string]bool
+	err    error
+}
+
+func (s *stubConnectorBuildChecker) HasConnectorBuild(ctx context.Context, pluginName, version string) (bool, error) {
+	return s.builds[pluginName+"@"+version], s.err
+}
+
+func newCompatibilityService(t *testing.T, localConfig, targetConfig string) *Service {
+	t.Helper()
+	service := newPlanningService(t, nil)
+	packagePath := createTestPackage(t, map[string]string{
+		"config/seatunnel.yaml": targetConfig,
+	})
+	service.SetPackageProvider(&stubPackageProvider{info: &installerapp.PackageInfo{
+		Version:   "2.3.12",
+		FileName:  "apache-seatunnel-2.3.12-bin.tar.gz",
+		IsLocal:   true,
+		LocalPath: packagePath,
+		FileSize:  4096,
+		Checksum:  "abc123",
+	}})
+	service.SetConfigProvider(&stubConfigProvider{configs: []*appconfig.ConfigInfo{{
+		ID:         1,
+		ClusterID:  1,
+		ConfigType: appconfig.ConfigTypeSeatunnel,
+		FilePath:   appconfig.GetConfigFilePath(appconfig.ConfigTypeSeatunnel),
+		Content:    localConfig,
+		IsTemplate: true,
+	}}})
+	return service
+}
+
+func TestService_RunPrecheck_compatibilityReportFailsMissingConnectorBuild(t *testing.T) {
+	service := newCompatibilityService(t, "seatunnel: default", "seatunnel: default")
+	service.SetPluginProvider(&stubPluginProvider{
+		installed: []pluginapp.InstalledPlugin{{PluginName: "jdbc", Version: "2.3.11"}},
+	})
+	service.SetConnectorBuildChecker(&stubConnectorBuildChecker{builds: map[string]bool{}})
+
+	result, err := service.RunPrecheck(context.Background(), &PrecheckRequest{
+		ClusterID:           1,
+		TargetVersion:       "2.3.12",
+		AcknowledgeWarnings: true,
+	})
+	if err != nil {
+		t.Fatalf("RunPrecheck returned error: %v", err)
+	}
+	if result.Ready {
+		t.Fatalf("expected a missing connector build to block the upgrade")
+	}
+	assertIssueCode(t, result.Issues, "compatibility_report_failed")
+	report := result.CompatibilityReport
+	if report == nil || report.Status != CompatibilityStatusFail {
+		t.Fatalf("expected failed compatibility report, got %+v", report)
+	}
+	if len(report.Connectors) != 1 || report.Connectors[0].Code != "connector_build_missing" {
+		t.Fatalf("expected missing jdbc build, got %+v", report.Connectors)
+	}
+	if len(report.ManualActions) == 0 || report.ManualActions[len(report.ManualActions)-1].Code != "replace_connector" {
+		t.Fatalf("expected a replace_connector manual action, got %+v", report.ManualActions)
+	}
+}
+
+func TestService_RunPrecheck_compatibilityWarningsRequireAcknowledgement(t *testing.T) {
+	localConfig := "seatunnel:\n  engine:\n    legacy-option: true\n"
+	targetConfig := "seatunnel:\n  engine:\n    backup-count: 1\n"
+	service := newCompatibilityService(t, localConfig, targetConfig)
+	service.SetConnectorBuildChecker(&stubConnectorBuildChecker{err: errors.New("unreachable")})
+
+	result, err := service.RunPrecheck(context.Background(), &PrecheckRequest{
+		ClusterID:     1,
+		TargetVersion: "2.3.12",
+	})
+	if err != nil {
+		t.Fatalf("RunPrecheck returned error: %v", err)
+	}
+	if result.Ready {
+		t.Fatalf("expected unacknowledged warnings to block the upgrade")
+	}
+	assertIssueCode(t, result.Issues, "compatibility_warnings_unacknowledged")
+	report := result.CompatibilityReport
+	if report.Status != CompatibilityStatusWarning || report.Acknowledged {
+		t.Fatalf("expected unacknowledged warning report, got status=%s acknowledged=%v", report.Status, report.Acknowledged)
+	}
+	assertCompatibilityItem(t, report.ConfigChanges, "config_key_added", "seatunnel.engine.backup-count")
+	assertCompatibilityItem(t, report.ConfigChanges, "config_key_not_in_target", "seatunnel.engine.legacy-option")
+
+	result, err = service.RunPrecheck(context.Background(), &PrecheckRequest{
+		ClusterID:           1,
+		TargetVersion:       "2.3.12",
+		AcknowledgeWarnings: true,
+	})
+	if err != nil {
+		t.Fatalf("RunPrecheck returned error: %v", err)
+	}
+	if !result.Ready {
+		t.Fatalf("expected acknowledged warnings not to block, got %+v", result.Issues)
+	}
+	if !result.CompatibilityReport.Acknowledged {
+		t.Fatalf("expected report to record the acknowledgement")
+	}
+}
+
+func TestService_RunPrecheck_compatibilityReportNotesLocalCheckpoints(t *testing.T) {
+	localConfig := `seatunnel:
+  engine:
+    checkpoint:
+      storage:
+        type: hdfs
+        plugin-config:
+          namespace: /opt/seatunnel-2.3.11/checkpoint/
+          fs.defaultFS: file:///
+`
+	service := newCompatibilityService(t, localConfig, localConfig)
+
+	result, err := service.RunPrecheck(context.Background(), &PrecheckRequest{
+		ClusterID:     1,
+		TargetVersion: "2.3.12",
+	})
+	if err != nil {
+		t.Fatalf("RunPrecheck returned error: %v", err)
+	}
+	notes := result.CompatibilityReport.CheckpointNotes
+	assertCompatibilityItem(t, notes, "checkpoint_restore_with_target_engine", "/opt/seatunnel-2.3.11/checkpoint/")
+	assertCompatibilityItem(t, notes, "checkpoint_namespace_in_install_dir", "/opt/seatunnel-2.3.11/checkpoint/")
+	assertIssueCode(t, result.Issues, "compatibility_warnings_unacknowledged")
+}
+
+func TestVersionPathItems(t *testing.T) {
+	if items := versionPathItems("2.3.12", "2.3.11"); len(items) != 1 || items[0].Code != "downgrade_not_supported" {
+		t.Fatalf("expected downgrade to fail, got %+v", items)
+	}
+	if items := versionPathItems("2.3.11", "2.4.0"); len(items) != 1 || items[0].Code != "release_line_changed" {
+		t.Fatalf("expected release line warning, got %+v", items)
+	}
+	if items := versionPathItems("2.3.11", "2.3.12"); len(items) != 0 {
+		t.Fatalf("expected patch upgrade to pass, got %+v", items)
+	}
+}
+
+func TestCrossedCapabilities(t *testing.T) {
+	got := crossedCapabilities("2.3.8", "2.3.10")
+	want := []seatunnel.Capability{
+		seatunnel.CapabilityHTTPService,
+		seatunnel.CapabilityJobScheduleStrategy,
+		seatunnel.CapabilityScheduledDeletionEnable,
+		seatunnel.CapabilitySlotAllocationStrategy,
+	}
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, got)
+		}
+	}
+}
+
+func assertCompatibilityItem(t *testing.T, items []CompatibilityItem, code, subject string) {
+	t.Helper()
+	for _, item := range items {
+		if item.Code == code && item.Subject == subject {
+			return
+		}
+	}
+	t.Fatalf("expected compatibility item %s(%s), got %+v", code, subject, items)
+}
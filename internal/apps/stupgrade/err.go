@@ -43,4 +43,8 @@ var (
 	// ErrUpgradePlanSnapshotEmpty 表示升级计划缺少快照内容。
 	// ErrUpgradePlanSnapshotEmpty indicates the upgrade plan snapshot is empty.
 	ErrUpgradePlanSnapshotEmpty = errors.New("st upgrade plan snapshot is empty")
+
+	// ErrInvalidUpgradeStrategy 表示升级策略未知。
+	// ErrInvalidUpgradeStrategy indicates an unknown upgrade strategy.
+	ErrInvalidUpgradeStrategy = errors.New("st upgrade strategy must be stop_start or rolling")
 )
@@ -33,6 +33,7 @@ import (
 type ClusterOperator interface {
 	Start(ctx context.Context, clusterID uint) (*clusterapp.OperationResult, error)
 	Stop(ctx context.Context, clusterID uint) (*clusterapp.OperationResult, error)
+	RollingRestart(ctx context.Context, clusterID uint, opts *clusterapp.RollingOptions) (*clusterapp.OperationResult, error)
 	Update(ctx context.Context, id uint, req *clusterapp.UpdateClusterRequest) (*clusterapp.Cluster, error)
 	UpdateNode(ctx context.Context, clusterID uint, nodeID uint, req *clusterapp.UpdateNodeRequest) (*clusterapp.ClusterNode, error)
 }
@@ -134,6 +135,7 @@ func (s *Service) executeTask(ctx context.Context, taskID uint) (*UpgradeTask, e
 		completedAt := time.Now()
 		task.CompletedAt = &completedAt
 		_ = s.UpdateTask(ctx, task)
+		s.notifyTaskFinished(ctx, task)
 		return s.GetTaskDetail(ctx, task.ID)
 	}
 
@@ -148,9 +150,19 @@ func (s *Service) executeTask(ctx context.Context, taskID uint) (*UpgradeTask, e
 	if err := s.UpdateTask(ctx, task); err != nil {
 		return nil, err
 	}
+	s.notifyTaskFinished(ctx, task)
 	return s.GetTaskDetail(ctx, task.ID)
 }
 
+// notifyTaskFinished 在升级任务结束后触发回调。
+// notifyTaskFinished fires the finish hook after an upgrade task ends.
+func (s *Service) notifyTaskFinished(ctx context.Context, task *UpgradeTask) {
+	if s.onTaskFinished == nil || task == nil {
+		return
+	}
+	s.onTaskFinished(ctx, task)
+}
+
 func (s *Service) executePlanSteps(ctx context.Context, task *UpgradeTask, plan UpgradePlanSnapshot, backupPaths map[string]string, nodesByKey map[string]*UpgradeNodeExecution, nodesByClusterNodeID map[uint]*UpgradeNodeExecution) error {
 	connectorKeepFiles := collectConnectorFileNames(plan.ConnectorManifest.Connectors)
 	libraryKeepFiles := collectLibraryFileNames(plan.ConnectorManifest.Libraries)
@@ -211,6 +223,9 @@ func (s *Service) executePlanSteps(ctx context.Context, task *UpgradeTask, plan
 		case StepCodeStartCluster:
 			stepErr = s.executeClusterLifecycleStep(ctx, task, step, clusterapp.OperationStart, nodesByClusterNodeID, ExecutionStatusRunning)
 			successMessage = "cluster started on target version / 目标版本集群已启动"
+		case StepCodeRollingRestart:
+			stepErr = s.executeRollingRestartStep(ctx, task, step, plan.Rolling, nodesByClusterNodeID)
+			successMessage = "all nodes restarted on target version and rejoined the cluster / 所有节点已滚动重启到目标版本并重新加入集群"
 		case StepCodeHealthCheck:
 			stepErr = s.executeHealthCheckStep(ctx, task, step, plan.NodeTargets, nodesByKey)
 			successMessage = fmt.Sprintf("health checks passed on %d node targets / %d 个节点目标健康检查通过", len(plan.NodeTargets), len(plan.NodeTargets))
@@ -695,6 +710,30 @@ func (s *Service) executeSmokeTestStep(ctx context.Context, task *UpgradeTask, s
 }
 
 func (s *Service) executeClusterLifecycleStep(ctx context.Context, task *UpgradeTask, step *UpgradeTaskStep, operation clusterapp.OperationType, nodesByClusterNodeID map[uint]*UpgradeNodeExecution, nodeSuccessStatus ExecutionStatus) error {
+	var run func() (*clusterapp.OperationResult, error)
+	switch operation {
+	case clusterapp.OperationStop:
+		run = func() (*clusterapp.OperationResult, error) { return s.clusterOperator.Stop(ctx, task.ClusterID) }
+	case clusterapp.OperationStart:
+		run = func() (*clusterapp.OperationResult, error) { return s.clusterOperator.Start(ctx, task.ClusterID) }
+	default:
+		return fmt.Errorf("unsupported cluster operation: %s", operation)
+	}
+	return s.runClusterOperationStep(ctx, task, step, operation, run, nodesByClusterNodeID, nodeSuccessStatus)
+}
+
+// executeRollingRestartStep 逐批重启节点到目标版本，每批节点重新加入集群后再继续。
+// executeRollingRestartStep restarts nodes onto the target version batch by batch, waiting for each batch to rejoin.
+func (s *Service) executeRollingRestartStep(ctx context.Context, task *UpgradeTask, step *UpgradeTaskStep, opts *clusterapp.RollingOptions, nodesByClusterNodeID map[uint]*UpgradeNodeExecution) error {
+	run := func() (*clusterapp.OperationResult, error) {
+		return s.clusterOperator.RollingRestart(ctx, task.ClusterID, opts)
+	}
+	return s.runClusterOperationStep(ctx, task, step, clusterapp.OperationRestart, run, nodesByClusterNodeID, ExecutionStatusRunning)
+}
+
+// runClusterOperationStep 执行集群级生命周期操作，并将每个节点的结果记录到步骤中。
+// runClusterOperationStep runs a cluster lifecycle operation and records each node result on the step.
+func (s *Service) runClusterOperationStep(ctx context.Context, task *UpgradeTask, step *UpgradeTaskStep, operation clusterapp.OperationType, run func() (*clusterapp.OperationResult, error), nodesByClusterNodeID map[uint]*UpgradeNodeExecution, nodeSuccessStatus ExecutionStatus) error {
 	commandSummary := fmt.Sprintf("cluster.%s cluster_id=%d", operation, task.ClusterID)
 	for _, node := range task.NodeExecutions {
 		trackedNode := nodesByClusterNodeID[node.ClusterNodeID]
@@ -707,18 +746,7 @@ func (s *Service) executeClusterLifecycleStep(ctx context.Context, task *Upgrade
 		}
 	}
 
-	var (
-		result *clusterapp.OperationResult
-		err    error
-	)
-	switch operation {
-	case clusterapp.OperationStop:
-		result, err = s.clusterOperator.Stop(ctx, task.ClusterID)
-	case clusterapp.OperationStart:
-		result, err = s.clusterOperator.Start(ctx, task.ClusterID)
-	default:
-		return fmt.Errorf("unsupported cluster operation: %s", operation)
-	}
+	result, err := run()
 	if err != nil {
 		for _, node := range nodesByClusterNodeID {
 			_ = s.failNodeStep(ctx, step, node, deriveFailureStatus(nodeSuccessStatus), err, commandSummary)
@@ -19,6 +19,7 @@ package stupgrade
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"testing"
@@ -32,6 +33,8 @@ import (
 type stubClusterOperator struct {
 	stopCalls         int
 	startCalls        int
+	rollingCalls      int
+	rollingOptions    *clusterapp.RollingOptions
 	clusterVersions   []string
 	clusterInstallDir []string
 	nodeInstallDirs   map[uint][]string
@@ -71,6 +74,24 @@ func (s *stubClusterOperator) Stop(ctx context.Context, clusterID uint) (*cluste
 	}, nil
 }
 
+func (s *stubClusterOperator) RollingRestart(ctx context.Context, clusterID uint, opts *clusterapp.RollingOptions) (*clusterapp.OperationResult, error) {
+	s.rollingCalls++
+	s.rollingOptions = opts
+	return &clusterapp.OperationResult{
+		ClusterID: clusterID,
+		Operation: clusterapp.OperationRestart,
+		Success:   true,
+		Message:   "rolling restart completed",
+		NodeResults: []*clusterapp.NodeOperationResult{{
+			NodeID:   11,
+			HostID:   101,
+			HostName: "node-a",
+			Success:  true,
+			Message:  "restarted and rejoined",
+		}},
+	}, nil
+}
+
 func (s *stubClusterOperator) Update(ctx context.Context, id uint, req *clusterapp.UpdateClusterRequest) (*clusterapp.Cluster, error) {
 	if req.Version != nil {
 		s.clusterVersions = append(s.clusterVersions, *req.Version)
@@ -258,6 +279,52 @@ func TestService_ExecutePlan_success(t *testing.T) {
 	}
 }
 
+func TestService_ExecutePlan_rollingStrategyRestartsWithoutStoppingCluster(t *testing.T) {
+	database := openTestDB(t)
+	repo := NewRepository(database)
+	clusterOperator := &stubClusterOperator{}
+	agentSender := &stubAgentCommandSender{agents: map[uint]string{101: "agent-node-a"}}
+	service := newExecutionService(t, repo, clusterOperator, agentSender)
+
+	if _, err := service.CreatePlanFromRequest(context.Background(), &CreatePlanRequest{
+		PrecheckRequest: PrecheckRequest{ClusterID: 1, TargetVersion: "2.3.12"},
+		Strategy:        "blue_green",
+	}, 7); !errors.Is(err, ErrInvalidUpgradeStrategy) {
+		t.Fatalf("expected ErrInvalidUpgradeStrategy, got %v", err)
+	}
+
+	result, err := service.CreatePlanFromRequest(context.Background(), &CreatePlanRequest{
+		PrecheckRequest: PrecheckRequest{ClusterID: 1, TargetVersion: "2.3.12"},
+		Strategy:        UpgradeStrategyRolling,
+		Rolling:         &clusterapp.RollingOptions{MaxUnavailable: 2},
+	}, 7)
+	if err != nil || result.Plan == nil {
+		t.Fatalf("CreatePlanFromRequest returned plan=%v err=%v", result, err)
+	}
+
+	task, err := service.ExecutePlan(context.Background(), result.Plan.ID, 7)
+	if err != nil {
+		t.Fatalf("ExecutePlan returned error: %v", err)
+	}
+	if task.Status != ExecutionStatusSucceeded {
+		t.Fatalf("expected task status succeeded, got %s (%s)", task.Status, task.FailureReason)
+	}
+	if clusterOperator.stopCalls != 0 || clusterOperator.startCalls != 0 || clusterOperator.rollingCalls != 1 {
+		t.Fatalf("expected only a rolling restart, got stop=%d start=%d rolling=%d", clusterOperator.stopCalls, clusterOperator.startCalls, clusterOperator.rollingCalls)
+	}
+	if clusterOperator.rollingOptions == nil || clusterOperator.rollingOptions.MaxUnavailable != 2 {
+		t.Fatalf("expected rolling options to be passed through, got %+v", clusterOperator.rollingOptions)
+	}
+	for _, step := range task.Steps {
+		if step.Code == StepCodeStopCluster || step.Code == StepCodeStartCluster {
+			t.Fatalf("rolling plan must not contain %s", step.Code)
+		}
+	}
+	if got := lastString(clusterOperator.nodeInstallDirs[11]); got != "/opt/seatunnel-2.3.12" {
+		t.Fatalf("expected node install dir switched to target version, got %q", got)
+	}
+}
+
 func TestService_ExecutePlan_sameInstallDirTriggersBackup(t *testing.T) {
 	database := openTestDB(t)
 	repo := NewRepository(database)
@@ -299,6 +299,8 @@ func getStatusCodeForError(err error) int {
 	switch {
 	case errors.Is(err, ErrUpgradePlanNotReady):
 		return http.StatusConflict
+	case errors.Is(err, ErrInvalidUpgradeStrategy), errors.Is(err, clusterapp.ErrInvalidRollingOptions):
+		return http.StatusBadRequest
 	case errors.Is(err, ErrUpgradePlanNotFound), errors.Is(err, ErrUpgradeTaskNotFound), errors.Is(err, clusterapp.ErrClusterNotFound), errors.Is(err, hostapp.ErrHostNotFound):
 		return http.StatusNotFound
 	default:
@@ -137,6 +137,14 @@ func (s *Service) RunPrecheck(ctx context.Context, req *PrecheckRequest) (*Prech
 	}
 	result.ConfigMergePlan = &configMergePlan
 	result.Issues = append(result.Issues, configIssues...)
+
+	installedPlugins, err := s.pluginProvider.ListInstalledPlugins(ctx, req.ClusterID)
+	if err != nil {
+		return nil, err
+	}
+	requiredConnectors := resolveRequiredConnectors(installedPlugins, req.ConnectorNames)
+	result.CompatibilityReport = s.buildCompatibilityReport(ctx, clusterInfo.Version, targetVersion, requiredConnectors, &configMergePlan, nodeTargets)
+	result.Issues = append(result.Issues, compatibilityIssues(result.CompatibilityReport, req.AcknowledgeWarnings)...)
 	result.Ready = !hasBlockingIssues(result.Issues)
 	return result, nil
 }
@@ -147,6 +155,16 @@ func (s *Service) CreatePlanFromRequest(ctx context.Context, req *CreatePlanRequ
 	if req == nil {
 		return nil, fmt.Errorf("st upgrade plan request is required")
 	}
+	switch req.Strategy {
+	case "":
+		req.Strategy = UpgradeStrategyStopStart
+	case UpgradeStrategyStopStart, UpgradeStrategyRolling:
+	default:
+		return nil, ErrInvalidUpgradeStrategy
+	}
+	if req.Rolling != nil && (req.Rolling.MaxUnavailable < 0 || req.Rolling.RejoinTimeoutSeconds < 0) {
+		return nil, clusterapp.ErrInvalidRollingOptions
+	}
 
 	precheck, err := s.RunPrecheck(ctx, &req.PrecheckRequest)
 	if err != nil {
@@ -187,16 +205,21 @@ func (s *Service) CreatePlanFromRequest(ctx context.Context, req *CreatePlanRequ
 	}
 
 	snapshot := UpgradePlanSnapshot{
-		ClusterID:         req.ClusterID,
-		DeploymentMode:    string(clusterInfo.DeploymentMode),
-		SourceVersion:     clusterInfo.Version,
-		TargetVersion:     precheck.TargetVersion,
-		PackageManifest:   *precheck.PackageManifest,
-		ConnectorManifest: *precheck.ConnectorManifest,
-		ConfigMergePlan:   *precheck.ConfigMergePlan,
-		NodeTargets:       append([]NodeTarget(nil), precheck.NodeTargets...),
-		Steps:             DefaultExecutionSteps(),
-		GeneratedAt:       time.Now(),
+		ClusterID:           req.ClusterID,
+		DeploymentMode:      string(clusterInfo.DeploymentMode),
+		SourceVersion:       clusterInfo.Version,
+		TargetVersion:       precheck.TargetVersion,
+		PackageManifest:     *precheck.PackageManifest,
+		ConnectorManifest:   *precheck.ConnectorManifest,
+		ConfigMergePlan:     *precheck.ConfigMergePlan,
+		NodeTargets:         append([]NodeTarget(nil), precheck.NodeTargets...),
+		Steps:               ExecutionStepsForStrategy(req.Strategy),
+		Strategy:            req.Strategy,
+		CompatibilityReport: precheck.CompatibilityReport,
+		GeneratedAt:         time.Now(),
+	}
+	if req.Strategy == UpgradeStrategyRolling {
+		snapshot.Rolling = req.Rolling
 	}
 	plan, err := s.CreatePlan(ctx, snapshot, createdBy, PlanStatusReady, 0)
 	if err != nil {
@@ -33,10 +33,12 @@ type Service struct {
 	hostProvider       HostProvider
 	packageProvider    PackageProvider
 	pluginProvider     PluginProvider
+	connectorChecker   ConnectorBuildChecker
 	configProvider     ConfigProvider
 	clusterOperator    ClusterOperator
 	packageTransferer  PackageTransferer
 	agentCommandSender AgentCommandSender
+	onTaskFinished     func(ctx context.Context, task *UpgradeTask)
 }
 
 // NewService 创建升级服务实例。
@@ -72,6 +74,12 @@ func (s *Service) SetPluginProvider(provider PluginProvider) {
 	s.pluginProvider = provider
 }
 
+// SetConnectorBuildChecker 设置连接器目标版本构建检查依赖。
+// SetConnectorBuildChecker sets the connector build lookup used by the compatibility report.
+func (s *Service) SetConnectorBuildChecker(checker ConnectorBuildChecker) {
+	s.connectorChecker = checker
+}
+
 // SetConfigProvider 设置配置查询依赖。
 // SetConfigProvider sets the config query dependency.
 func (s *Service) SetConfigProvider(provider ConfigProvider) {
@@ -96,6 +104,12 @@ func (s *Service) SetAgentCommandSender(sender AgentCommandSender) {
 	s.agentCommandSender = sender
 }
 
+// SetOnTaskFinished 设置升级任务结束（成功或失败）后的可选回调。
+// SetOnTaskFinished sets an optional hook invoked after an upgrade task succeeds or fails.
+func (s *Service) SetOnTaskFinished(fn func(ctx context.Context, task *UpgradeTask)) {
+	s.onTaskFinished = fn
+}
+
 // CreatePlan 持久化升级计划快照。
 // CreatePlan persists an upgrade plan snapshot.
 func (s *Service) CreatePlan(ctx context.Context, snapshot UpgradePlanSnapshot, createdBy uint, status PlanStatus, blockingIssueCount int) (*UpgradePlanRecord, error) {
@@ -292,8 +306,11 @@ func normalizePlanSnapshot(snapshot UpgradePlanSnapshot) UpgradePlanSnapshot {
 	if normalized.ConfigMergePlan.GeneratedAt.IsZero() {
 		normalized.ConfigMergePlan.GeneratedAt = normalized.GeneratedAt
 	}
+	if normalized.Strategy == "" {
+		normalized.Strategy = UpgradeStrategyStopStart
+	}
 	if len(normalized.Steps) == 0 {
-		normalized.Steps = DefaultExecutionSteps()
+		normalized.Steps = ExecutionStepsForStrategy(normalized.Strategy)
 	}
 	if !containsRollbackSteps(normalized.Steps) {
 		normalized.Steps = append(normalized.Steps, DefaultRollbackSteps(len(normalized.Steps)+1)...)
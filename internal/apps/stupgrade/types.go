@@ -17,7 +17,11 @@
 
 package stupgrade
 
-import "time"
+import (
+	"time"
+
+	clusterapp "github.com/seatunnel/seatunnelX/internal/apps/cluster"
+)
 
 // AssetSource 表示升级资产的来源。
 // AssetSource represents the source of an upgrade asset.
@@ -71,6 +75,19 @@ const (
 	ExecutionStatusCancelled         ExecutionStatus = "cancelled"
 )
 
+// UpgradeStrategy 表示新版本的切换方式。
+// UpgradeStrategy represents how nodes are switched to the new version.
+type UpgradeStrategy string
+
+const (
+	// UpgradeStrategyStopStart 停止整个集群后统一启动新版本（默认）。
+	// UpgradeStrategyStopStart stops the whole cluster and starts the new version at once (default).
+	UpgradeStrategyStopStart UpgradeStrategy = "stop_start"
+	// UpgradeStrategyRolling 逐批重启节点切换到新版本，集群保持可用。
+	// UpgradeStrategyRolling restarts nodes batch by batch onto the new version while the cluster stays available.
+	UpgradeStrategyRolling UpgradeStrategy = "rolling"
+)
+
 // StepCode 表示升级编排中的固定步骤编码。
 // StepCode represents the fixed step codes in the upgrade orchestration.
 type StepCode string
@@ -89,6 +106,7 @@ const (
 	StepCodeStopCluster       StepCode = "STOP_CLUSTER"
 	StepCodeSwitchVersion     StepCode = "SWITCH_VERSION"
 	StepCodeStartCluster      StepCode = "START_CLUSTER"
+	StepCodeRollingRestart    StepCode = "ROLLING_RESTART"
 	StepCodeHealthCheck       StepCode = "HEALTH_CHECK"
 	StepCodeSmokeTest         StepCode = "SMOKE_TEST"
 	StepCodeComplete          StepCode = "COMPLETE"
@@ -131,6 +149,29 @@ const (
 	CheckCategoryConnector CheckCategory = "connector"
 	CheckCategoryNode      CheckCategory = "node"
 	CheckCategoryConfig    CheckCategory = "config"
+	// CheckCategoryCompatibility 表示升级兼容性报告产生的问题。
+	// CheckCategoryCompatibility marks issues raised by the upgrade compatibility report.
+	CheckCategoryCompatibility CheckCategory = "compatibility"
+)
+
+// CompatibilitySeverity 表示兼容性报告条目的严重程度。
+// CompatibilitySeverity represents the severity of a compatibility report item.
+type CompatibilitySeverity string
+
+const (
+	CompatibilitySeverityInfo    CompatibilitySeverity = "info"
+	CompatibilitySeverityWarning CompatibilitySeverity = "warning"
+	CompatibilitySeverityFail    CompatibilitySeverity = "fail"
+)
+
+// CompatibilityStatus 表示兼容性报告的整体结论。
+// CompatibilityStatus represents the overall verdict of a compatibility report.
+type CompatibilityStatus string
+
+const (
+	CompatibilityStatusPass    CompatibilityStatus = "pass"
+	CompatibilityStatusWarning CompatibilityStatus = "warning"
+	CompatibilityStatusFail    CompatibilityStatus = "fail"
 )
 
 // ConfigConflictStatus 表示配置冲突状态。
@@ -271,16 +312,47 @@ type PlanStep struct {
 // UpgradePlanSnapshot 是升级资产与步骤的统一快照。
 // UpgradePlanSnapshot is the unified snapshot of upgrade assets and steps.
 type UpgradePlanSnapshot struct {
-	ClusterID         uint              `json:"cluster_id"`
-	DeploymentMode    string            `json:"deployment_mode,omitempty"`
-	SourceVersion     string            `json:"source_version"`
-	TargetVersion     string            `json:"target_version"`
-	PackageManifest   PackageManifest   `json:"package_manifest"`
-	ConnectorManifest ConnectorManifest `json:"connector_manifest"`
-	ConfigMergePlan   ConfigMergePlan   `json:"config_merge_plan"`
-	NodeTargets       []NodeTarget      `json:"node_targets"`
-	Steps             []PlanStep        `json:"steps"`
-	GeneratedAt       time.Time         `json:"generated_at"`
+	ClusterID         uint                       `json:"cluster_id"`
+	DeploymentMode    string                     `json:"deployment_mode,omitempty"`
+	SourceVersion     string                     `json:"source_version"`
+	TargetVersion     string                     `json:"target_version"`
+	PackageManifest   PackageManifest            `json:"package_manifest"`
+	ConnectorManifest ConnectorManifest          `json:"connector_manifest"`
+	ConfigMergePlan   ConfigMergePlan            `json:"config_merge_plan"`
+	NodeTargets       []NodeTarget               `json:"node_targets"`
+	Steps             []PlanStep                 `json:"steps"`
+	Strategy          UpgradeStrategy            `json:"strategy,omitempty"`
+	Rolling           *clusterapp.RollingOptions `json:"rolling,omitempty"`
+	GeneratedAt       time.Time                  `json:"generated_at"`
+	// CompatibilityReport 记录创建计划时的兼容性报告及确认状态。
+	// CompatibilityReport records the compatibility report and acknowledgement at plan creation.
+	CompatibilityReport *CompatibilityReport `json:"compatibility_report,omitempty"`
+}
+
+// CompatibilityItem 描述兼容性报告中的单个条目。
+// CompatibilityItem describes one entry of the compatibility report.
+type CompatibilityItem struct {
+	Severity CompatibilitySeverity `json:"severity"`
+	Code     string                `json:"code"`
+	// Subject 是条目涉及的对象，例如配置键或连接器名称。
+	// Subject is what the entry is about, e.g. a config key or a connector name.
+	Subject  string            `json:"subject,omitempty"`
+	Message  string            `json:"message"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+// CompatibilityReport 描述从源版本升级到目标版本的兼容性报告。
+// CompatibilityReport describes the compatibility of upgrading from the source to the target version.
+type CompatibilityReport struct {
+	SourceVersion   string              `json:"source_version"`
+	TargetVersion   string              `json:"target_version"`
+	Status          CompatibilityStatus `json:"status"`
+	Acknowledged    bool                `json:"acknowledged"`
+	ConfigChanges   []CompatibilityItem `json:"config_changes"`
+	Connectors      []CompatibilityItem `json:"connectors"`
+	CheckpointNotes []CompatibilityItem `json:"checkpoint_notes"`
+	ManualActions   []CompatibilityItem `json:"manual_actions"`
+	GeneratedAt     time.Time           `json:"generated_at"`
 }
 
 // BlockingIssue 描述阻断型预检查问题。
@@ -305,6 +377,9 @@ type PrecheckResult struct {
 	ConfigMergePlan   *ConfigMergePlan   `json:"config_merge_plan,omitempty"`
 	NodeTargets       []NodeTarget       `json:"node_targets"`
 	GeneratedAt       time.Time          `json:"generated_at"`
+	// CompatibilityReport 汇总配置键变化、连接器构建、检查点说明与需手动处理的事项。
+	// CompatibilityReport summarizes config key changes, connector builds, checkpoint notes and manual actions.
+	CompatibilityReport *CompatibilityReport `json:"compatibility_report,omitempty"`
 }
 
 // PrecheckRequest 描述升级预检查输入。
@@ -317,6 +392,9 @@ type PrecheckRequest struct {
 	ConnectorNames   []string `json:"connector_names,omitempty"`
 	NodeIDs          []uint   `json:"node_ids,omitempty"`
 	TargetInstallDir string   `json:"target_install_dir,omitempty"`
+	// AcknowledgeWarnings 确认兼容性报告中的警告，允许在存在警告时继续升级。
+	// AcknowledgeWarnings accepts the warnings of the compatibility report so the upgrade may proceed.
+	AcknowledgeWarnings bool `json:"acknowledge_warnings,omitempty"`
 }
 
 // CreatePlanRequest 描述升级计划生成输入。
@@ -324,6 +402,10 @@ type PrecheckRequest struct {
 type CreatePlanRequest struct {
 	PrecheckRequest
 	ConfigMergePlan *ConfigMergePlan `json:"config_merge_plan,omitempty"`
+	// Strategy 默认为 stop_start；rolling 时按 Rolling 参数逐批重启。
+	// Strategy defaults to stop_start; rolling restarts nodes batch by batch using Rolling.
+	Strategy UpgradeStrategy            `json:"strategy,omitempty"`
+	Rolling  *clusterapp.RollingOptions `json:"rolling,omitempty"`
 }
 
 // ExecutePlanRequest 描述升级计划执行输入。
@@ -400,6 +482,37 @@ func DefaultExecutionSteps() []PlanStep {
 	}
 }
 
+// RollingExecutionSteps 返回滚动升级的步骤顺序：不停集群，切换版本后逐批重启。
+// RollingExecutionSteps returns the step order of a rolling upgrade: the cluster is not stopped and nodes
+// are restarted batch by batch after the version switch.
+func RollingExecutionSteps() []PlanStep {
+	steps := make([]PlanStep, 0, len(DefaultExecutionSteps()))
+	for _, step := range DefaultExecutionSteps() {
+		switch step.Code {
+		case StepCodeStopCluster:
+			continue
+		case StepCodeSwitchVersion:
+			step.Description = "在集群运行期间切换到目标版本目录或 current 指针，节点重启后生效。"
+		case StepCodeStartCluster:
+			step.Code = StepCodeRollingRestart
+			step.Title = "滚动重启"
+			step.Description = "逐批重启节点到目标版本，每批节点重新加入集群后再继续。"
+		}
+		step.Sequence = len(steps) + 1
+		steps = append(steps, step)
+	}
+	return steps
+}
+
+// ExecutionStepsForStrategy 返回升级策略对应的步骤顺序。
+// ExecutionStepsForStrategy returns the step order of an upgrade strategy.
+func ExecutionStepsForStrategy(strategy UpgradeStrategy) []PlanStep {
+	if strategy == UpgradeStrategyRolling {
+		return RollingExecutionSteps()
+	}
+	return DefaultExecutionSteps()
+}
+
 // DefaultRollbackSteps 返回固定回滚步骤顺序。
 // DefaultRollbackSteps returns the fixed rollback step order.
 func DefaultRollbackSteps(startSequence int) []PlanStep {
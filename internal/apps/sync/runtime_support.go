@@ -230,6 +230,11 @@ func (s *Service) SetClusterVersionProvider(provider ClusterVersionProvider) {
 	s.clusterVersionProvider = provider
 }
 
+// SetMaintenanceChecker sets the checker that pauses schedules of clusters with hosts in maintenance.
+func (s *Service) SetMaintenanceChecker(checker ClusterMaintenanceChecker) {
+	s.maintenanceChecker = checker
+}
+
 func taskExecutionMode(task *Task) string {
 	if task == nil {
 		return "cluster"
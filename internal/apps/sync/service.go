@@ -33,7 +33,9 @@ import (
 	"time"
 
 	"github.com/seatunnel/seatunnelX/internal/config"
+	"github.com/seatunnel/seatunnelX/internal/pkg/leader"
 	"github.com/seatunnel/seatunnelX/internal/pkg/schedulex"
+	"github.com/seatunnel/seatunnelX/internal/pkg/worker"
 	"github.com/seatunnel/seatunnelX/internal/seatunnel"
 )
 
@@ -51,6 +53,7 @@ type Service struct {
 	executionTargetResolver ExecutionTargetResolver
 	clusterLogProvider      ClusterLogProvider
 	clusterVersionProvider  ClusterVersionProvider
+	maintenanceChecker      ClusterMaintenanceChecker
 }
 
 // ClusterVersionProvider provides SeaTunnel cluster version lookup.
@@ -58,6 +61,11 @@ type ClusterVersionProvider interface {
 	GetClusterVersion(ctx context.Context, clusterID uint) (string, error)
 }
 
+// ClusterMaintenanceChecker reports the hosts of a cluster that are in maintenance mode.
+type ClusterMaintenanceChecker interface {
+	MaintenanceHostNames(ctx context.Context, clusterID uint) ([]string, error)
+}
+
 const (
 	defaultPreviewRowLimit       = 100
 	maxPreviewRowLimit           = 10000
@@ -92,6 +100,8 @@ func (s *Service) StartPreviewRuntime(ctx context.Context) {
 		return
 	}
 	go func() {
+		defer worker.Start("sync-preview-cleanup")()
+
 		ticker := time.NewTicker(time.Minute)
 		defer ticker.Stop()
 		for {
@@ -99,6 +109,9 @@ func (s *Service) StartPreviewRuntime(ctx context.Context) {
 			case <-ctx.Done():
 				return
 			case <-ticker.C:
+				if !leader.IsLeader() {
+					continue
+				}
 				_ = s.cleanupExpiredPreviewSessions(ctx, s.previewDataTTL())
 				_ = s.stopTimedOutPreviewSessions(ctx)
 			}
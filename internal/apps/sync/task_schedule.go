@@ -24,7 +24,9 @@ import (
 	"strings"
 	"time"
 
+	"github.com/seatunnel/seatunnelX/internal/pkg/leader"
 	"github.com/seatunnel/seatunnelX/internal/pkg/schedulex"
+	"github.com/seatunnel/seatunnelX/internal/pkg/worker"
 )
 
 const (
@@ -149,6 +151,8 @@ func (s *Service) StartTaskScheduleRuntime(ctx context.Context) {
 		return
 	}
 	go func() {
+		defer worker.Start("sync-task-scheduler")()
+
 		ticker := time.NewTicker(time.Minute)
 		defer ticker.Stop()
 		for {
@@ -156,6 +160,9 @@ func (s *Service) StartTaskScheduleRuntime(ctx context.Context) {
 			case <-ctx.Done():
 				return
 			case now := <-ticker.C:
+				if !leader.IsLeader() {
+					continue
+				}
 				if err := s.triggerScheduledTasks(ctx, now); err != nil {
 					log.Printf("[SyncSchedule] tick failed: %v", err)
 				}
@@ -190,6 +197,10 @@ func (s *Service) triggerScheduledTasks(ctx context.Context, now time.Time) erro
 		if !matched {
 			continue
 		}
+		if hosts := s.maintenanceHosts(ctx, item.ClusterID); len(hosts) > 0 {
+			log.Printf("[SyncSchedule] task %d paused, cluster %d has hosts under maintenance: %s", item.ID, item.ClusterID, strings.Join(hosts, ", "))
+			continue
+		}
 		exists, err := s.repo.HasScheduledJobInstanceInWindow(ctx, item.ID, windowStart.UTC(), windowEnd.UTC())
 		if err != nil {
 			return err
@@ -204,6 +215,18 @@ func (s *Service) triggerScheduledTasks(ctx context.Context, now time.Time) erro
 	return nil
 }
 
+// maintenanceHosts returns the hosts of the cluster that are in maintenance mode, if any.
+func (s *Service) maintenanceHosts(ctx context.Context, clusterID uint) []string {
+	if s.maintenanceChecker == nil || clusterID == 0 {
+		return nil
+	}
+	hosts, err := s.maintenanceChecker.MaintenanceHostNames(ctx, clusterID)
+	if err != nil {
+		return nil
+	}
+	return hosts
+}
+
 func (s *Service) submitScheduledTask(ctx context.Context, task *Task) error {
 	version, err := s.repo.GetTaskVersionByVersion(ctx, task.ID, task.CurrentVersion)
 	if err != nil {
@@ -0,0 +1,76 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package task
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// CommandSender dispatches a command to an Agent and waits for its result
+// CommandSender 向 Agent 下发命令并等待结果
+type CommandSender interface {
+	SendCommand(ctx context.Context, agentID string, commandType string, params map[string]string) (bool, string, error)
+}
+
+// AgentResolver returns the ID of the Agent serving a host
+// AgentResolver 返回服务于某主机的 Agent ID
+type AgentResolver func(ctx context.Context, hostID uint) (string, error)
+
+// NewAgentCommandRunner returns a runner that executes a task as a single Agent command.
+// Task params are passed to the Agent as string parameters.
+// NewAgentCommandRunner 返回将任务作为单条 Agent 命令执行的执行器，任务参数以字符串形式传给 Agent。
+func NewAgentCommandRunner(sender CommandSender, resolve AgentResolver, commandType string) Runner {
+	return func(ctx context.Context, task *Task, reporter *Reporter) (map[string]interface{}, error) {
+		agentID := task.AgentID
+		if agentID == "" && resolve != nil {
+			resolved, err := resolve(ctx, task.HostID)
+			if err != nil {
+				return nil, err
+			}
+			agentID = resolved
+		}
+		if agentID == "" {
+			return nil, ErrAgentNotConnected
+		}
+
+		params := make(map[string]string, len(task.Params))
+		for key, value := range task.Params {
+			params[key] = fmt.Sprint(value)
+		}
+
+		reporter.StartStep(commandType, "Agent 命令 / Agent command")
+		success, output, err := sender.SendCommand(ctx, agentID, commandType, params)
+		if err != nil {
+			reporter.FailStep(commandType, err)
+			return nil, err
+		}
+		result := map[string]interface{}{"agent_id": agentID, "output": output}
+		if !success {
+			if output == "" {
+				output = "agent command failed / Agent 命令执行失败"
+			}
+			err := errors.New(output)
+			reporter.FailStep(commandType, err)
+			return result, err
+		}
+		reporter.CompleteStep(commandType, "")
+		return result, nil
+	}
+}
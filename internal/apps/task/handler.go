@@ -18,10 +18,13 @@
 package task
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
+	"github.com/seatunnel/seatunnelX/internal/apps/auth"
+	"github.com/seatunnel/seatunnelX/internal/pkg/pagex"
 )
 
 // Handler provides HTTP handlers for task management
@@ -48,7 +51,7 @@ type TaskResponse struct {
 // TaskListAPIResponse represents a task list response
 // TaskListAPIResponse 表示任务列表响应
 type TaskListAPIResponse struct {
-	ErrorMsg string  `json:"error_msg"`
+	ErrorMsg string            `json:"error_msg"`
 	Data     *TaskListResponse `json:"data"`
 }
 
@@ -69,17 +72,11 @@ func (h *Handler) CreateTask(c *gin.Context) {
 		return
 	}
 
-	// Get current user from context
-	createdBy := ""
-	if user, exists := c.Get("user"); exists {
-		if u, ok := user.(map[string]interface{}); ok {
-			if username, ok := u["username"].(string); ok {
-				createdBy = username
-			}
-		}
-	}
+	// Tasks created through the API always run the executor registered for their type
+	// 通过 API 创建的任务始终使用其类型注册的执行器
+	req.Source = TaskSourceAPI
 
-	task, err := h.manager.CreateTask(c.Request.Context(), &req, createdBy)
+	task, err := h.manager.CreateTask(c.Request.Context(), &req, auth.GetUsernameFromContext(c))
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, TaskResponse{ErrorMsg: err.Error()})
 		return
@@ -104,44 +101,71 @@ func (h *Handler) GetTask(c *gin.Context) {
 
 	task, err := h.manager.GetTask(c.Request.Context(), taskID)
 	if err != nil {
-		c.JSON(http.StatusNotFound, TaskResponse{ErrorMsg: err.Error()})
+		c.JSON(taskStatusCode(err), TaskResponse{ErrorMsg: err.Error()})
 		return
 	}
 
 	c.JSON(http.StatusOK, TaskResponse{Data: task})
 }
 
-// ListTasks handles GET /api/v1/tasks - lists all tasks
-// ListTasks 处理 GET /api/v1/tasks - 获取所有任务
+// ListTasksRequest represents the query of GET /api/v1/tasks
+// ListTasksRequest 表示 GET /api/v1/tasks 的查询参数
+type ListTasksRequest struct {
+	pagex.Query
+	pagex.TimeQuery
+	Status    TaskStatus `json:"status" form:"status"`
+	Type      TaskType   `json:"type" form:"type"`
+	Source    TaskSource `json:"source" form:"source"`
+	HostID    uint       `json:"host_id" form:"host_id"`
+	ClusterID uint       `json:"cluster_id" form:"cluster_id"`
+	// Limit is the legacy name of page_size / Limit 是 page_size 的旧名称
+	Limit int `json:"limit" form:"limit"`
+}
+
+// ListTasks handles GET /api/v1/tasks - lists the task history
+// ListTasks 处理 GET /api/v1/tasks - 获取任务历史
 // @Tags tasks
 // @Produce json
-// @Param status query string false "任务状态过滤"
-// @Param type query string false "任务类型过滤"
-// @Param limit query int false "返回数量限制"
+// @Param request query ListTasksRequest true "查询参数"
 // @Success 200 {object} TaskListAPIResponse
 // @Router /api/v1/tasks [get]
 func (h *Handler) ListTasks(c *gin.Context) {
-	var status *TaskStatus
-	var taskType *TaskType
-	limit := 100
-
-	if s := c.Query("status"); s != "" {
-		st := TaskStatus(s)
-		status = &st
+	req := &ListTasksRequest{}
+	if err := c.ShouldBindQuery(req); err != nil {
+		c.JSON(http.StatusBadRequest, TaskListAPIResponse{ErrorMsg: err.Error()})
+		return
 	}
-
-	if t := c.Query("type"); t != "" {
-		tt := TaskType(t)
-		taskType = &tt
+	if req.PageSize == 0 && req.Size == 0 {
+		req.PageSize = req.Limit
+	}
+	page, err := req.Resolve(ListSpec)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, TaskListAPIResponse{ErrorMsg: err.Error()})
+		return
+	}
+	createdAt, err := req.Range()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, TaskListAPIResponse{ErrorMsg: err.Error()})
+		return
 	}
 
-	if l := c.Query("limit"); l != "" {
-		if parsed, err := strconv.Atoi(l); err == nil {
-			limit = parsed
-		}
+	filter := &TaskFilter{
+		Source:    req.Source,
+		HostID:    req.HostID,
+		ClusterID: req.ClusterID,
+		CreatedAt: createdAt,
+		Page:      page.Page,
+		PageSize:  page.PageSize,
+		Sort:      page.Sort,
+	}
+	if req.Status != "" {
+		filter.Status = &req.Status
+	}
+	if req.Type != "" {
+		filter.Type = &req.Type
 	}
 
-	tasks, total, err := h.manager.ListAllTasks(c.Request.Context(), status, taskType, limit)
+	tasks, total, err := h.manager.ListAllTasks(c.Request.Context(), filter)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, TaskListAPIResponse{ErrorMsg: err.Error()})
 		return
@@ -206,7 +230,7 @@ func (h *Handler) StartTask(c *gin.Context) {
 	}
 
 	if err := h.manager.StartTask(c.Request.Context(), taskID); err != nil {
-		c.JSON(http.StatusInternalServerError, TaskResponse{ErrorMsg: err.Error()})
+		c.JSON(taskStatusCode(err), TaskResponse{ErrorMsg: err.Error()})
 		return
 	}
 
@@ -229,7 +253,7 @@ func (h *Handler) CancelTask(c *gin.Context) {
 	}
 
 	if err := h.manager.CancelTask(c.Request.Context(), taskID); err != nil {
-		c.JSON(http.StatusInternalServerError, TaskResponse{ErrorMsg: err.Error()})
+		c.JSON(taskStatusCode(err), TaskResponse{ErrorMsg: err.Error()})
 		return
 	}
 
@@ -253,9 +277,26 @@ func (h *Handler) RetryTask(c *gin.Context) {
 
 	task, err := h.manager.RetryTask(c.Request.Context(), taskID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, TaskResponse{ErrorMsg: err.Error()})
+		c.JSON(taskStatusCode(err), TaskResponse{ErrorMsg: err.Error()})
 		return
 	}
 
 	c.JSON(http.StatusOK, TaskResponse{Data: task})
 }
+
+// taskStatusCode maps task errors to HTTP status codes.
+// taskStatusCode 将任务错误映射为 HTTP 状态码。
+func taskStatusCode(err error) int {
+	switch {
+	case errors.Is(err, ErrTaskNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrTaskNotPending),
+		errors.Is(err, ErrTaskNotRetryable),
+		errors.Is(err, ErrMaxRetriesExceeded):
+		return http.StatusConflict
+	case errors.Is(err, ErrNoRunner):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
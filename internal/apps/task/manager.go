@@ -20,44 +20,65 @@ package task
 import (
 	"context"
 	"errors"
+	"fmt"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/seatunnel/seatunnelX/internal/logger"
+	"github.com/seatunnel/seatunnelX/internal/pkg/pagex"
 )
 
 // Common errors
 var (
-	ErrTaskNotFound      = errors.New("task not found / 任务未找到")
-	ErrAgentNotConnected = errors.New("agent not connected / Agent 未连接")
-	ErrTaskCancelled     = errors.New("task cancelled / 任务已取消")
-	ErrTaskTimeout       = errors.New("task timeout / 任务超时")
+	ErrTaskNotFound       = errors.New("task not found / 任务未找到")
+	ErrAgentNotConnected  = errors.New("agent not connected / Agent 未连接")
+	ErrTaskCancelled      = errors.New("task cancelled / 任务已取消")
+	ErrTaskTimeout        = errors.New("task timeout / 任务超时")
+	ErrTaskNotPending     = errors.New("task is not pending / 任务不处于等待状态")
+	ErrNoRunner           = errors.New("no executor registered for this task type / 该任务类型没有可用的执行器")
+	ErrTaskNotRetryable   = errors.New("task is not retryable / 任务不可重试")
+	ErrMaxRetriesExceeded = errors.New("max retries exceeded / 超过最大重试次数")
 )
 
+// interruptedReason is recorded on tasks that were still active when the Control Plane stopped
+// interruptedReason 记录在 Control Plane 停止时仍处于活动状态的任务上
+const interruptedReason = "任务因 Control Plane 重启而中断 / Task interrupted by Control Plane restart"
+
 // ProgressCallback is called when task progress is updated
 // ProgressCallback 在任务进度更新时被调用
 type ProgressCallback func(progress *TaskProgress)
 
-// Manager manages tasks for Control Plane to Agent communication
-// Manager 管理 Control Plane 到 Agent 通信的任务
+// Manager runs long-running operations as tasks and keeps their history
+// Manager 以任务形式运行长时间操作并保存其历史
 type Manager struct {
-	// tasks stores all tasks by ID
-	// tasks 按 ID 存储所有任务
+	// tasks stores the tasks of this process by ID; finished tasks are evicted by CleanupOldTasks
+	// tasks 按 ID 存储本进程内的任务，已结束任务由 CleanupOldTasks 清理
 	tasks map[string]*Task
 	mu    sync.RWMutex
 
-	// hostTasks stores task IDs by host ID for quick lookup
-	// hostTasks 按主机 ID 存储任务 ID，用于快速查找
-	hostTasks map[uint][]string
+	// runners maps task types to the runner used for tasks created through the API
+	// runners 记录通过 API 创建的任务所使用的各类型执行器
+	runners map[TaskType]Runner
+
+	// taskRunners remembers the runner of each task so it can be started and retried
+	// taskRunners 记录每个任务的执行器，以便启动与重试
+	taskRunners map[string]Runner
+
+	// cancels holds the cancel functions of running tasks
+	// cancels 保存执行中任务的取消函数
+	cancels map[string]context.CancelFunc
+
+	// repo persists task history (optional)
+	// repo 持久化任务历史（可选）
+	repo *Repository
 
 	// progressCallbacks stores callbacks for task progress updates
 	// progressCallbacks 存储任务进度更新的回调
 	progressCallbacks map[string][]ProgressCallback
 	callbackMu        sync.RWMutex
-
-	// agentManager is used to send commands to agents
-	// agentManager 用于向 Agent 发送命令
-	// agentManager *agent.Manager // TODO: inject agent manager
 }
 
 // NewManager creates a new task Manager
@@ -65,18 +86,54 @@ type Manager struct {
 func NewManager() *Manager {
 	return &Manager{
 		tasks:             make(map[string]*Task),
-		hostTasks:         make(map[uint][]string),
+		runners:           make(map[TaskType]Runner),
+		taskRunners:       make(map[string]Runner),
+		cancels:           make(map[string]context.CancelFunc),
 		progressCallbacks: make(map[string][]ProgressCallback),
 	}
 }
 
-// CreateTask creates a new task and returns it
-// CreateTask 创建新任务并返回
+// SetRepository enables persistent task history
+// SetRepository 启用任务历史持久化
+func (m *Manager) SetRepository(repo *Repository) {
+	m.repo = repo
+}
+
+// RecoverInterrupted marks tasks left active by a previous process as failed
+// RecoverInterrupted 将上一个进程遗留的活动任务标记为失败
+func (m *Manager) RecoverInterrupted(ctx context.Context) error {
+	if m.repo == nil {
+		return nil
+	}
+	count, err := m.repo.FailActive(ctx, interruptedReason)
+	if err == nil && count > 0 {
+		logger.WarnF(ctx, "[Task] 已将 %d 个中断的任务标记为失败 / marked %d interrupted tasks as failed", count, count)
+	}
+	return err
+}
+
+// RegisterRunner sets the runner for tasks of taskType created through the API
+// RegisterRunner 设置通过 API 创建的某类型任务所使用的执行器
+func (m *Manager) RegisterRunner(taskType TaskType, runner Runner) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.runners[taskType] = runner
+}
+
+// CreateTask creates a new pending task and returns it
+// CreateTask 创建新的等待中任务并返回
 func (m *Manager) CreateTask(ctx context.Context, req *CreateTaskRequest, createdBy string) (*Task, error) {
+	source := req.Source
+	if source == "" {
+		source = TaskSourceAPI
+	}
 	task := &Task{
 		ID:             uuid.New().String(),
 		Type:           req.Type,
 		HostID:         req.HostID,
+		AgentID:        req.AgentID,
+		ClusterID:      req.ClusterID,
+		Source:         source,
 		Status:         TaskStatusPending,
 		Progress:       0,
 		Message:        "任务已创建 / Task created",
@@ -90,110 +147,159 @@ func (m *Manager) CreateTask(ctx context.Context, req *CreateTaskRequest, create
 
 	m.mu.Lock()
 	m.tasks[task.ID] = task
-	m.hostTasks[req.HostID] = append(m.hostTasks[req.HostID], task.ID)
+	snapshot := cloneTask(task)
+	m.mu.Unlock()
+
+	m.persist(ctx, snapshot)
+	return snapshot, nil
+}
+
+// Submit creates a task and runs it in the background with runner
+// Submit 创建任务并使用 runner 在后台执行
+func (m *Manager) Submit(ctx context.Context, req *CreateTaskRequest, createdBy string, runner Runner) (*Task, error) {
+	if runner == nil {
+		return nil, ErrNoRunner
+	}
+	task, err := m.CreateTask(ctx, req, createdBy)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	m.taskRunners[task.ID] = runner
 	m.mu.Unlock()
 
-	return task, nil
+	runCtx, cancel := m.taskContext(context.Background(), task.TimeoutSeconds)
+	m.markRunning(runCtx, task.ID, cancel)
+	go m.execute(runCtx, cancel, task.ID, runner)
+
+	return m.GetTask(ctx, task.ID)
+}
+
+// Run creates a task and executes it with runner in the caller's goroutine.
+// It returns the finished task and the runner's error.
+// Run 创建任务并在调用方 goroutine 中使用 runner 执行，返回结束后的任务及 runner 的错误。
+func (m *Manager) Run(ctx context.Context, req *CreateTaskRequest, createdBy string, runner Runner) (*Task, error) {
+	if runner == nil {
+		return nil, ErrNoRunner
+	}
+	task, err := m.CreateTask(ctx, req, createdBy)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	m.taskRunners[task.ID] = runner
+	m.mu.Unlock()
+
+	runCtx, cancel := m.taskContext(ctx, task.TimeoutSeconds)
+	m.markRunning(runCtx, task.ID, cancel)
+	runErr := m.execute(runCtx, cancel, task.ID, runner)
+
+	finished, err := m.GetTask(ctx, task.ID)
+	if err != nil {
+		return nil, err
+	}
+	return finished, runErr
 }
 
-// GetTask returns a task by ID
-// GetTask 根据 ID 返回任务
+// GetTask returns a task by ID, falling back to the persisted history
+// GetTask 根据 ID 返回任务，内存中不存在时查询持久化历史
 func (m *Manager) GetTask(ctx context.Context, taskID string) (*Task, error) {
 	m.mu.RLock()
-	defer m.mu.RUnlock()
-
 	task, ok := m.tasks[taskID]
-	if !ok {
-		return nil, ErrTaskNotFound
+	if ok {
+		snapshot := cloneTask(task)
+		m.mu.RUnlock()
+		return snapshot, nil
 	}
+	m.mu.RUnlock()
 
-	return task, nil
+	if m.repo != nil {
+		return m.repo.Get(ctx, taskID)
+	}
+	return nil, ErrTaskNotFound
 }
 
-// ListTasks returns tasks for a host
-// ListTasks 返回主机的任务列表
+// ListTasks returns the newest tasks for a host
+// ListTasks 返回主机最新的任务列表
 func (m *Manager) ListTasks(ctx context.Context, hostID uint, limit int) ([]*Task, error) {
-	m.mu.RLock()
-	defer m.mu.RUnlock()
+	tasks, _, err := m.ListAllTasks(ctx, &TaskFilter{HostID: hostID, Limit: limit})
+	return tasks, err
+}
 
-	taskIDs, ok := m.hostTasks[hostID]
-	if !ok {
-		return []*Task{}, nil
+// ListAllTasks returns the newest tasks matching filter and the total number of matches
+// ListAllTasks 返回符合筛选条件的最新任务以及匹配总数
+func (m *Manager) ListAllTasks(ctx context.Context, filter *TaskFilter) ([]*Task, int, error) {
+	if filter == nil {
+		filter = &TaskFilter{}
 	}
 
-	tasks := make([]*Task, 0, len(taskIDs))
-	for _, id := range taskIDs {
-		if task, ok := m.tasks[id]; ok {
-			tasks = append(tasks, task)
+	if m.repo != nil {
+		tasks, total, err := m.repo.List(ctx, filter)
+		if err != nil {
+			return nil, 0, err
 		}
+		// Live tasks carry fresher progress than their last persisted state
+		// 内存中的任务比最后一次持久化的状态拥有更新的进度
+		m.mu.RLock()
+		for i, task := range tasks {
+			if live, ok := m.tasks[task.ID]; ok {
+				tasks[i] = cloneTask(live)
+			}
+		}
+		m.mu.RUnlock()
+		return tasks, total, nil
 	}
 
-	// Sort by created time descending and limit
-	// 按创建时间降序排序并限制数量
-	if limit > 0 && len(tasks) > limit {
-		tasks = tasks[len(tasks)-limit:]
-	}
-
-	return tasks, nil
-}
-
-// ListAllTasks returns all tasks with optional filtering
-// ListAllTasks 返回所有任务，支持可选过滤
-func (m *Manager) ListAllTasks(ctx context.Context, status *TaskStatus, taskType *TaskType, limit int) ([]*Task, int, error) {
 	m.mu.RLock()
-	defer m.mu.RUnlock()
-
 	tasks := make([]*Task, 0)
 	for _, task := range m.tasks {
-		// Filter by status
-		if status != nil && task.Status != *status {
-			continue
-		}
-		// Filter by type
-		if taskType != nil && task.Type != *taskType {
-			continue
+		if matchesFilter(task, filter) {
+			tasks = append(tasks, cloneTask(task))
 		}
-		tasks = append(tasks, task)
 	}
+	m.mu.RUnlock()
 
+	sortTasks(tasks, filter.Sort)
 	total := len(tasks)
-
-	// Limit results
-	if limit > 0 && len(tasks) > limit {
-		tasks = tasks[:limit]
+	if filter.PageSize > 0 {
+		start, end := pagex.Params{Page: filter.Page, PageSize: filter.PageSize}.Bounds(total)
+		tasks = tasks[start:end]
+	} else if filter.Limit > 0 && len(tasks) > filter.Limit {
+		tasks = tasks[:filter.Limit]
 	}
-
 	return tasks, total, nil
 }
 
-// StartTask starts executing a task
-// StartTask 开始执行任务
+// StartTask starts executing a pending task in the background
+// StartTask 在后台开始执行等待中的任务
 func (m *Manager) StartTask(ctx context.Context, taskID string) error {
 	m.mu.Lock()
 	task, ok := m.tasks[taskID]
 	if !ok {
 		m.mu.Unlock()
-		return ErrTaskNotFound
+		if _, err := m.GetTask(ctx, taskID); err != nil {
+			return err
+		}
+		return ErrTaskNotPending
 	}
-
-	now := time.Now()
-	task.Status = TaskStatusRunning
-	task.StartedAt = &now
-	task.Message = "任务开始执行 / Task started"
+	if task.Status != TaskStatusPending {
+		m.mu.Unlock()
+		return ErrTaskNotPending
+	}
+	runner := m.runnerForLocked(task)
+	if runner == nil {
+		m.mu.Unlock()
+		return ErrNoRunner
+	}
+	m.taskRunners[taskID] = runner
+	timeoutSeconds := task.TimeoutSeconds
 	m.mu.Unlock()
 
-	// TODO: Send command to Agent via gRPC
-	// TODO: 通过 gRPC 向 Agent 发送命令
-
-	// Notify progress callbacks
-	m.notifyProgress(&TaskProgress{
-		TaskID:    taskID,
-		Status:    TaskStatusRunning,
-		Progress:  0,
-		Message:   task.Message,
-		Timestamp: now,
-	})
-
+	runCtx, cancel := m.taskContext(context.Background(), timeoutSeconds)
+	m.markRunning(runCtx, taskID, cancel)
+	go m.execute(runCtx, cancel, taskID, runner)
 	return nil
 }
 
@@ -210,80 +316,106 @@ func (m *Manager) UpdateProgress(ctx context.Context, progress *TaskProgress) er
 	task.Status = progress.Status
 	task.Progress = progress.Progress
 	task.Message = progress.Message
+	if progress.CurrentStep != "" {
+		task.CurrentStep = progress.CurrentStep
+	}
+	if len(progress.Steps) > 0 {
+		task.Steps = append([]TaskStep(nil), progress.Steps...)
+	}
 
 	if progress.Error != "" {
 		task.Error = progress.Error
 	}
 
 	// Update completion time if task is done
-	if progress.Status == TaskStatusSuccess || progress.Status == TaskStatusFailed ||
-		progress.Status == TaskStatusCancelled || progress.Status == TaskStatusTimeout {
+	finished := progress.Status.IsFinished()
+	if finished {
 		now := time.Now()
 		task.CompletedAt = &now
+		delete(m.cancels, task.ID)
 	}
-
+	snapshot := cloneTask(task)
 	m.mu.Unlock()
 
+	if finished {
+		m.persist(ctx, snapshot)
+	}
+
 	// Notify progress callbacks
 	m.notifyProgress(progress)
 
 	return nil
 }
 
-// CancelTask cancels a running task
-// CancelTask 取消正在运行的任务
+// CancelTask cancels a pending or running task; running tasks stop once their runner returns
+// CancelTask 取消等待中或执行中的任务；执行中的任务在其执行器返回后结束
 func (m *Manager) CancelTask(ctx context.Context, taskID string) error {
 	m.mu.Lock()
 	task, ok := m.tasks[taskID]
 	if !ok {
 		m.mu.Unlock()
-		return ErrTaskNotFound
+		if _, err := m.GetTask(ctx, taskID); err != nil {
+			return err
+		}
+		return nil // Only finished tasks live in history
 	}
 
-	if task.Status != TaskStatusPending && task.Status != TaskStatusRunning {
+	if task.Status.IsFinished() {
 		m.mu.Unlock()
 		return nil // Already completed
 	}
 
+	if cancel, running := m.cancels[taskID]; running {
+		task.Message = "正在取消任务 / Cancelling task"
+		m.mu.Unlock()
+		cancel()
+		return nil
+	}
+
 	now := time.Now()
 	task.Status = TaskStatusCancelled
 	task.Message = "任务已取消 / Task cancelled"
 	task.CompletedAt = &now
+	snapshot := cloneTask(task)
 	m.mu.Unlock()
 
-	// TODO: Send cancel command to Agent
-	// TODO: 向 Agent 发送取消命令
+	m.persist(ctx, snapshot)
 
 	// Notify progress callbacks
 	m.notifyProgress(&TaskProgress{
 		TaskID:    taskID,
 		Status:    TaskStatusCancelled,
-		Progress:  task.Progress,
-		Message:   task.Message,
+		Progress:  snapshot.Progress,
+		Message:   snapshot.Message,
 		Timestamp: now,
 	})
 
 	return nil
 }
 
-// RetryTask retries a failed task
-// RetryTask 重试失败的任务
+// RetryTask re-runs a failed or timed-out task as a new task linked to the original
+// RetryTask 将失败或超时的任务作为关联原任务的新任务重新执行
 func (m *Manager) RetryTask(ctx context.Context, taskID string) (*Task, error) {
-	m.mu.Lock()
-	oldTask, ok := m.tasks[taskID]
-	if !ok {
-		m.mu.Unlock()
-		return nil, ErrTaskNotFound
+	oldTask, err := m.GetTask(ctx, taskID)
+	if err != nil {
+		return nil, err
 	}
 
 	if oldTask.Status != TaskStatusFailed && oldTask.Status != TaskStatusTimeout {
-		m.mu.Unlock()
 		return oldTask, nil // Not a failed task
 	}
-
+	if !oldTask.Retryable {
+		return nil, ErrTaskNotRetryable
+	}
 	if oldTask.RetryCount >= oldTask.MaxRetries {
+		return nil, ErrMaxRetriesExceeded
+	}
+
+	m.mu.Lock()
+	runner := m.runnerForLocked(oldTask)
+	if runner == nil {
 		m.mu.Unlock()
-		return nil, errors.New("max retries exceeded / 超过最大重试次数")
+		return nil, ErrNoRunner
 	}
 
 	// Create a new task based on the old one
@@ -291,6 +423,10 @@ func (m *Manager) RetryTask(ctx context.Context, taskID string) (*Task, error) {
 		ID:             uuid.New().String(),
 		Type:           oldTask.Type,
 		HostID:         oldTask.HostID,
+		AgentID:        oldTask.AgentID,
+		ClusterID:      oldTask.ClusterID,
+		Source:         oldTask.Source,
+		ParentID:       oldTask.ID,
 		Status:         TaskStatusPending,
 		Progress:       0,
 		Message:        "重试任务 / Retrying task",
@@ -304,10 +440,17 @@ func (m *Manager) RetryTask(ctx context.Context, taskID string) (*Task, error) {
 	}
 
 	m.tasks[newTask.ID] = newTask
-	m.hostTasks[newTask.HostID] = append(m.hostTasks[newTask.HostID], newTask.ID)
+	m.taskRunners[newTask.ID] = runner
+	snapshot := cloneTask(newTask)
 	m.mu.Unlock()
 
-	return newTask, nil
+	m.persist(ctx, snapshot)
+
+	runCtx, cancel := m.taskContext(context.Background(), newTask.TimeoutSeconds)
+	m.markRunning(runCtx, newTask.ID, cancel)
+	go m.execute(runCtx, cancel, newTask.ID, runner)
+
+	return m.GetTask(ctx, newTask.ID)
 }
 
 // RegisterProgressCallback registers a callback for task progress updates
@@ -340,8 +483,8 @@ func (m *Manager) notifyProgress(progress *TaskProgress) {
 	}
 }
 
-// CleanupOldTasks removes completed tasks older than the specified duration
-// CleanupOldTasks 移除超过指定时间的已完成任务
+// CleanupOldTasks evicts finished tasks older than maxAge from memory; persisted history is kept
+// CleanupOldTasks 从内存中移除超过指定时间的已结束任务，持久化历史会保留
 func (m *Manager) CleanupOldTasks(ctx context.Context, maxAge time.Duration) int {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -351,26 +494,311 @@ func (m *Manager) CleanupOldTasks(ctx context.Context, maxAge time.Duration) int
 
 	for id, task := range m.tasks {
 		// Only remove completed tasks
-		if task.Status != TaskStatusSuccess && task.Status != TaskStatusFailed &&
-			task.Status != TaskStatusCancelled && task.Status != TaskStatusTimeout {
+		if !task.Status.IsFinished() {
 			continue
 		}
 
 		// Check if task is old enough
 		if task.CompletedAt != nil && task.CompletedAt.Before(cutoff) {
 			delete(m.tasks, id)
+			delete(m.taskRunners, id)
 			removed++
+		}
+	}
+
+	return removed
+}
 
-			// Remove from hostTasks
-			hostTaskIDs := m.hostTasks[task.HostID]
-			for i, tid := range hostTaskIDs {
-				if tid == id {
-					m.hostTasks[task.HostID] = append(hostTaskIDs[:i], hostTaskIDs[i+1:]...)
-					break
-				}
+// StartCleanup periodically evicts finished tasks older than maxAge until ctx is done
+// StartCleanup 定期清理超过 maxAge 的已结束任务，直到 ctx 结束
+func (m *Manager) StartCleanup(ctx context.Context, interval, maxAge time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				m.CleanupOldTasks(ctx, maxAge)
 			}
 		}
+	}()
+}
+
+// ==================== Execution 执行 ====================
+
+// taskContext derives the execution context of a task, applying its timeout
+// taskContext 派生任务的执行上下文并应用其超时时间
+func (m *Manager) taskContext(parent context.Context, timeoutSeconds int) (context.Context, context.CancelFunc) {
+	if timeoutSeconds > 0 {
+		return context.WithTimeout(parent, time.Duration(timeoutSeconds)*time.Second)
+	}
+	return context.WithCancel(parent)
+}
+
+// runnerForLocked returns the runner of a task; API tasks fall back to the runner registered for their type
+// runnerForLocked 返回任务的执行器；API 创建的任务回退为其类型注册的执行器
+func (m *Manager) runnerForLocked(task *Task) Runner {
+	if runner, ok := m.taskRunners[task.ID]; ok {
+		return runner
+	}
+	if task.Source == TaskSourceAPI || task.Source == "" {
+		return m.runners[task.Type]
 	}
+	return nil
+}
 
-	return removed
+// markRunning moves a task to running and records its cancel function
+// markRunning 将任务置为执行中并记录其取消函数
+func (m *Manager) markRunning(ctx context.Context, taskID string, cancel context.CancelFunc) {
+	m.mu.Lock()
+	task, ok := m.tasks[taskID]
+	if !ok {
+		m.mu.Unlock()
+		return
+	}
+	now := time.Now()
+	task.Status = TaskStatusRunning
+	task.StartedAt = &now
+	task.Message = "任务开始执行 / Task started"
+	m.cancels[taskID] = cancel
+	snapshot := cloneTask(task)
+	m.mu.Unlock()
+
+	m.persist(ctx, snapshot)
+	m.notifyProgress(&TaskProgress{
+		TaskID:    taskID,
+		Status:    TaskStatusRunning,
+		Progress:  snapshot.Progress,
+		Message:   snapshot.Message,
+		Timestamp: now,
+	})
+}
+
+// execute runs a task to completion and records the outcome; it returns the runner's error
+// execute 执行任务直至结束并记录结果，返回执行器的错误
+func (m *Manager) execute(ctx context.Context, cancel context.CancelFunc, taskID string, runner Runner) error {
+	defer cancel()
+
+	snapshot, err := m.GetTask(ctx, taskID)
+	if err != nil {
+		return err
+	}
+	reporter := &Reporter{manager: m, taskID: taskID}
+	result, runErr := invokeRunner(context.WithValue(ctx, reporterKey{}, reporter), snapshot, reporter, runner)
+	m.finish(ctx, taskID, result, runErr)
+	return runErr
+}
+
+// invokeRunner calls runner, turning a panic into an error
+// invokeRunner 调用执行器，并将 panic 转换为错误
+func invokeRunner(ctx context.Context, task *Task, reporter *Reporter, runner Runner) (result map[string]interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("task runner panic: %v / 任务执行器异常: %v", r, r)
+		}
+	}()
+	return runner(ctx, task, reporter)
+}
+
+// finish records the terminal state of a task
+// finish 记录任务的终态
+func (m *Manager) finish(ctx context.Context, taskID string, result map[string]interface{}, runErr error) {
+	status := TaskStatusSuccess
+	switch {
+	case runErr == nil:
+	case errors.Is(runErr, ErrTaskCancelled), errors.Is(runErr, context.Canceled),
+		errors.Is(ctx.Err(), context.Canceled):
+		status = TaskStatusCancelled
+	case errors.Is(runErr, ErrTaskTimeout), errors.Is(runErr, context.DeadlineExceeded),
+		errors.Is(ctx.Err(), context.DeadlineExceeded):
+		status = TaskStatusTimeout
+	default:
+		status = TaskStatusFailed
+	}
+
+	m.mu.Lock()
+	task, ok := m.tasks[taskID]
+	if !ok {
+		m.mu.Unlock()
+		return
+	}
+	now := time.Now()
+	task.Status = status
+	task.CompletedAt = &now
+	if result != nil {
+		task.Result = result
+	}
+	switch status {
+	case TaskStatusSuccess:
+		task.Progress = 100
+		task.Message = "任务执行成功 / Task succeeded"
+	case TaskStatusCancelled:
+		task.Message = "任务已取消 / Task cancelled"
+	case TaskStatusTimeout:
+		task.Message = "任务超时 / Task timed out"
+	default:
+		task.Message = "任务执行失败 / Task failed"
+	}
+	if runErr != nil {
+		task.Error = runErr.Error()
+	}
+	// Close steps left running by the runner / 结束执行器遗留的执行中步骤
+	for i := range task.Steps {
+		if task.Steps[i].Status == TaskStatusRunning || (task.Steps[i].Status == TaskStatusPending && status != TaskStatusSuccess) {
+			task.Steps[i].Status = status
+			task.Steps[i].CompletedAt = &now
+		}
+	}
+	delete(m.cancels, taskID)
+	snapshot := cloneTask(task)
+	m.mu.Unlock()
+
+	m.persist(context.WithoutCancel(ctx), snapshot)
+	m.notifyProgress(&TaskProgress{
+		TaskID:      taskID,
+		Status:      status,
+		Progress:    snapshot.Progress,
+		Message:     snapshot.Message,
+		CurrentStep: snapshot.CurrentStep,
+		Steps:       snapshot.Steps,
+		Error:       snapshot.Error,
+		Timestamp:   now,
+	})
+}
+
+// updateRunning applies fn to a running task, optionally persisting the result
+// updateRunning 对执行中的任务应用 fn，并可选择持久化
+func (m *Manager) updateRunning(taskID string, persist bool, fn func(task *Task)) {
+	m.mu.Lock()
+	task, ok := m.tasks[taskID]
+	if !ok || task.Status != TaskStatusRunning {
+		m.mu.Unlock()
+		return
+	}
+	fn(task)
+	snapshot := cloneTask(task)
+	m.mu.Unlock()
+
+	if persist {
+		m.persist(context.Background(), snapshot)
+	}
+	m.notifyProgress(&TaskProgress{
+		TaskID:      taskID,
+		Status:      snapshot.Status,
+		Progress:    snapshot.Progress,
+		Message:     snapshot.Message,
+		CurrentStep: snapshot.CurrentStep,
+		Steps:       snapshot.Steps,
+		Timestamp:   time.Now(),
+	})
+}
+
+// persist saves a task snapshot to the history, logging failures
+// persist 将任务快照保存到历史记录，失败时仅记录日志
+func (m *Manager) persist(ctx context.Context, task *Task) {
+	if m.repo == nil {
+		return
+	}
+	if err := m.repo.Save(context.WithoutCancel(ctx), task); err != nil {
+		logger.WarnF(ctx, "[Task] 保存任务失败 / failed to save task %s: %v", task.ID, err)
+	}
+}
+
+// matchesFilter reports whether a task matches filter
+// matchesFilter 判断任务是否符合筛选条件
+func matchesFilter(task *Task, filter *TaskFilter) bool {
+	if filter.Status != nil && task.Status != *filter.Status {
+		return false
+	}
+	if filter.Type != nil && task.Type != *filter.Type {
+		return false
+	}
+	if filter.Source != "" && task.Source != filter.Source {
+		return false
+	}
+	if filter.HostID > 0 && task.HostID != filter.HostID {
+		return false
+	}
+	if filter.ClusterID > 0 && task.ClusterID != filter.ClusterID {
+		return false
+	}
+	return filter.CreatedAt.Contains(task.CreatedAt)
+}
+
+// sortTasks orders in-memory tasks like the repository does, newest first by default
+// sortTasks 按与仓库一致的方式对内存中的任务排序，默认按创建时间倒序
+func sortTasks(tasks []*Task, fields []pagex.SortField) {
+	if len(fields) == 0 {
+		fields = []pagex.SortField{{Field: "created_at", Desc: true}}
+	}
+	sort.SliceStable(tasks, func(i, j int) bool {
+		for _, field := range fields {
+			c := compareTasks(tasks[i], tasks[j], field.Field)
+			if c == 0 {
+				continue
+			}
+			if field.Desc {
+				return c > 0
+			}
+			return c < 0
+		}
+		return false
+	})
+}
+
+// compareTasks compares two tasks on one sortable field
+// compareTasks 按单个可排序字段比较两个任务
+func compareTasks(a, b *Task, field string) int {
+	switch field {
+	case "started_at":
+		return compareTimes(a.StartedAt, b.StartedAt)
+	case "completed_at":
+		return compareTimes(a.CompletedAt, b.CompletedAt)
+	case "status":
+		return strings.Compare(string(a.Status), string(b.Status))
+	case "type":
+		return strings.Compare(string(a.Type), string(b.Type))
+	case "progress":
+		return a.Progress - b.Progress
+	default:
+		return a.CreatedAt.Compare(b.CreatedAt)
+	}
+}
+
+// compareTimes compares optional times, unset sorting first
+// compareTimes 比较可选时间，未设置的排在前面
+func compareTimes(a, b *time.Time) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return -1
+	case b == nil:
+		return 1
+	default:
+		return a.Compare(*b)
+	}
+}
+
+// cloneTask copies a task so callers never share state with the running task
+// cloneTask 复制任务，使调用方不会与执行中的任务共享状态
+func cloneTask(task *Task) *Task {
+	clone := *task
+	clone.Steps = append([]TaskStep(nil), task.Steps...)
+	clone.Params = cloneMap(task.Params)
+	clone.Result = cloneMap(task.Result)
+	return &clone
+}
+
+func cloneMap(in map[string]interface{}) map[string]interface{} {
+	if in == nil {
+		return nil
+	}
+	out := make(map[string]interface{}, len(in))
+	for k, v := range in {
+		out[k] = v
+	}
+	return out
 }
@@ -0,0 +1,194 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package task
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func newTestRepository(t *testing.T) *Repository {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "task.db")), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	if err := db.AutoMigrate(&TaskRecord{}); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	return NewRepository(db)
+}
+
+func waitForStatus(t *testing.T, m *Manager, taskID string, want TaskStatus) *Task {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		task, err := m.GetTask(context.Background(), taskID)
+		if err == nil && task.Status == want {
+			return task
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	task, _ := m.GetTask(context.Background(), taskID)
+	t.Fatalf("task %s did not reach %s, got %+v", taskID, want, task)
+	return nil
+}
+
+func TestSubmitRecordsStepsResultAndHistory(t *testing.T) {
+	repo := newTestRepository(t)
+	m := NewManager()
+	m.SetRepository(repo)
+
+	type payload struct {
+		Version string `json:"version"`
+	}
+	params, err := ParamsFrom(payload{Version: "2.3.12"})
+	if err != nil {
+		t.Fatalf("ParamsFrom: %v", err)
+	}
+
+	task, err := m.Submit(context.Background(), &CreateTaskRequest{Type: TaskTypeInstall, HostID: 7, Params: params, Source: TaskSourceInstaller}, "admin",
+		func(ctx context.Context, task *Task, reporter *Reporter) (map[string]interface{}, error) {
+			var p payload
+			if err := task.DecodeParams(&p); err != nil || p.Version != "2.3.12" {
+				t.Errorf("DecodeParams = %+v, %v", p, err)
+			}
+			reporter.StartStep("download", "Download package")
+			reporter.Update(50, "downloading")
+			reporter.CompleteStep("download", "")
+			ReporterFromContext(ctx).StartStep("install", "Install")
+			return map[string]interface{}{"install_dir": "/opt/seatunnel"}, nil
+		})
+	if err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+
+	done := waitForStatus(t, m, task.ID, TaskStatusSuccess)
+	if done.Progress != 100 || len(done.Steps) != 2 || done.Steps[1].Status != TaskStatusSuccess || done.Result["install_dir"] != "/opt/seatunnel" {
+		t.Fatalf("unexpected finished task: %+v", done)
+	}
+
+	// History survives eviction from memory / 从内存移除后历史仍可查询
+	if removed := m.CleanupOldTasks(context.Background(), -time.Second); removed != 1 {
+		t.Fatalf("expected the finished task to be evicted, removed %d", removed)
+	}
+	stored, err := m.GetTask(context.Background(), task.ID)
+	if err != nil || stored.Status != TaskStatusSuccess || stored.Source != TaskSourceInstaller || len(stored.Steps) != 2 {
+		t.Fatalf("expected persisted history, got %+v err=%v", stored, err)
+	}
+	tasks, total, err := m.ListAllTasks(context.Background(), &TaskFilter{HostID: 7})
+	if err != nil || total != 1 || tasks[0].ID != task.ID {
+		t.Fatalf("ListAllTasks = %v, %d, %v", tasks, total, err)
+	}
+}
+
+func TestCancelStopsRunningTask(t *testing.T) {
+	m := NewManager()
+	started := make(chan struct{})
+	task, err := m.Submit(context.Background(), &CreateTaskRequest{Type: TaskTypeStart, ClusterID: 3, Source: TaskSourceCluster}, "",
+		func(ctx context.Context, task *Task, reporter *Reporter) (map[string]interface{}, error) {
+			close(started)
+			<-ctx.Done()
+			return nil, ctx.Err()
+		})
+	if err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	<-started
+
+	if err := m.CancelTask(context.Background(), task.ID); err != nil {
+		t.Fatalf("CancelTask: %v", err)
+	}
+	waitForStatus(t, m, task.ID, TaskStatusCancelled)
+}
+
+func TestRetryRerunsFailedTaskWithItsRunner(t *testing.T) {
+	m := NewManager()
+	attempts := 0
+	runner := func(ctx context.Context, task *Task, reporter *Reporter) (map[string]interface{}, error) {
+		attempts++
+		if attempts == 1 {
+			return nil, errors.New("agent unreachable")
+		}
+		return nil, nil
+	}
+
+	failed, err := m.Run(context.Background(), &CreateTaskRequest{Type: TaskTypeInstallPlugin, ClusterID: 1, Source: TaskSourcePlugin}, "", runner)
+	if err == nil || failed.Status != TaskStatusFailed || failed.Error != "agent unreachable" {
+		t.Fatalf("expected failed task, got %+v err=%v", failed, err)
+	}
+
+	retried, err := m.RetryTask(context.Background(), failed.ID)
+	if err != nil {
+		t.Fatalf("RetryTask: %v", err)
+	}
+	if retried.ParentID != failed.ID || retried.RetryCount != 1 {
+		t.Fatalf("unexpected retry task: %+v", retried)
+	}
+	waitForStatus(t, m, retried.ID, TaskStatusSuccess)
+}
+
+func TestStartTaskUsesRegisteredAgentRunner(t *testing.T) {
+	m := NewManager()
+	sender := &fakeSender{success: false, output: "port 5801 in use"}
+	m.RegisterRunner(TaskTypePrecheck, NewAgentCommandRunner(sender, func(ctx context.Context, hostID uint) (string, error) {
+		return "agent-9", nil
+	}, "full"))
+
+	task, err := m.CreateTask(context.Background(), &CreateTaskRequest{Type: TaskTypePrecheck, HostID: 9, Params: map[string]interface{}{"port": 5801}}, "")
+	if err != nil {
+		t.Fatalf("CreateTask: %v", err)
+	}
+	if err := m.StartTask(context.Background(), task.ID); err != nil {
+		t.Fatalf("StartTask: %v", err)
+	}
+	failed := waitForStatus(t, m, task.ID, TaskStatusFailed)
+	if sender.agentID != "agent-9" || sender.params["port"] != "5801" || failed.Error != "port 5801 in use" {
+		t.Fatalf("unexpected dispatch: sender=%+v task=%+v", sender, failed)
+	}
+	if err := m.StartTask(context.Background(), task.ID); !errors.Is(err, ErrTaskNotPending) {
+		t.Fatalf("expected ErrTaskNotPending, got %v", err)
+	}
+
+	unknown, _ := m.CreateTask(context.Background(), &CreateTaskRequest{Type: TaskTypeUpgrade, HostID: 9}, "")
+	if err := m.StartTask(context.Background(), unknown.ID); !errors.Is(err, ErrNoRunner) {
+		t.Fatalf("expected ErrNoRunner, got %v", err)
+	}
+}
+
+type fakeSender struct {
+	success bool
+	output  string
+	agentID string
+	params  map[string]string
+}
+
+func (f *fakeSender) SendCommand(ctx context.Context, agentID string, commandType string, params map[string]string) (bool, string, error) {
+	f.agentID = agentID
+	f.params = params
+	return f.success, f.output, nil
+}
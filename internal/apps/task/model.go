@@ -0,0 +1,173 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package task
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// TaskRecord is the persisted history entry of a task.
+// TaskRecord 是任务的持久化历史记录。
+type TaskRecord struct {
+	ID             string     `gorm:"primaryKey;size:36"`
+	Type           TaskType   `gorm:"size:32;index;not null"`
+	Source         TaskSource `gorm:"size:16;index"`
+	HostID         uint       `gorm:"index"`
+	AgentID        string     `gorm:"size:100"`
+	ClusterID      uint       `gorm:"index"`
+	ParentID       string     `gorm:"size:36"`
+	Status         TaskStatus `gorm:"size:16;index;not null"`
+	Progress       int
+	Message        string    `gorm:"type:text"`
+	Error          string    `gorm:"type:text"`
+	CurrentStep    string    `gorm:"size:64"`
+	Steps          TaskSteps `gorm:"type:text"`
+	Params         JSONMap   `gorm:"type:text"`
+	Result         JSONMap   `gorm:"type:text"`
+	TimeoutSeconds int
+	RetryCount     int
+	MaxRetries     int
+	Retryable      bool
+	CreatedBy      string `gorm:"size:64"`
+	CreatedAt      time.Time
+	StartedAt      *time.Time
+	CompletedAt    *time.Time
+	UpdatedAt      time.Time
+}
+
+// TableName specifies the table name for TaskRecord.
+// TableName 指定 TaskRecord 的表名。
+func (TaskRecord) TableName() string {
+	return "operation_tasks"
+}
+
+// JSONMap stores task params and results as JSON text.
+// JSONMap 以 JSON 文本存储任务参数与结果。
+type JSONMap map[string]interface{}
+
+// Value implements the driver.Valuer interface.
+// Value 实现 driver.Valuer 接口。
+func (m JSONMap) Value() (driver.Value, error) {
+	if m == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(m)
+	return string(data), err
+}
+
+// Scan implements the sql.Scanner interface.
+// Scan 实现 sql.Scanner 接口。
+func (m *JSONMap) Scan(value interface{}) error {
+	return scanJSON(value, m, "JSONMap")
+}
+
+// TaskSteps stores task steps as JSON text.
+// TaskSteps 以 JSON 文本存储任务步骤。
+type TaskSteps []TaskStep
+
+// Value implements the driver.Valuer interface.
+// Value 实现 driver.Valuer 接口。
+func (s TaskSteps) Value() (driver.Value, error) {
+	if s == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(s)
+	return string(data), err
+}
+
+// Scan implements the sql.Scanner interface.
+// Scan 实现 sql.Scanner 接口。
+func (s *TaskSteps) Scan(value interface{}) error {
+	return scanJSON(value, s, "TaskSteps")
+}
+
+func scanJSON(value interface{}, out interface{}, name string) error {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case []byte:
+		return json.Unmarshal(v, out)
+	case string:
+		return json.Unmarshal([]byte(v), out)
+	default:
+		return errors.New("task: failed to scan " + name + " - expected []byte or string")
+	}
+}
+
+// newTaskRecord converts a task into its persisted form.
+// newTaskRecord 将任务转换为持久化形式。
+func newTaskRecord(t *Task) *TaskRecord {
+	return &TaskRecord{
+		ID:             t.ID,
+		Type:           t.Type,
+		Source:         t.Source,
+		HostID:         t.HostID,
+		AgentID:        t.AgentID,
+		ClusterID:      t.ClusterID,
+		ParentID:       t.ParentID,
+		Status:         t.Status,
+		Progress:       t.Progress,
+		Message:        t.Message,
+		Error:          t.Error,
+		CurrentStep:    t.CurrentStep,
+		Steps:          TaskSteps(t.Steps),
+		Params:         JSONMap(t.Params),
+		Result:         JSONMap(t.Result),
+		TimeoutSeconds: t.TimeoutSeconds,
+		RetryCount:     t.RetryCount,
+		MaxRetries:     t.MaxRetries,
+		Retryable:      t.Retryable,
+		CreatedBy:      t.CreatedBy,
+		CreatedAt:      t.CreatedAt,
+		StartedAt:      t.StartedAt,
+		CompletedAt:    t.CompletedAt,
+	}
+}
+
+// toTask converts a persisted record back into a task.
+// toTask 将持久化记录转换回任务。
+func (r *TaskRecord) toTask() *Task {
+	return &Task{
+		ID:             r.ID,
+		Type:           r.Type,
+		Source:         r.Source,
+		HostID:         r.HostID,
+		AgentID:        r.AgentID,
+		ClusterID:      r.ClusterID,
+		ParentID:       r.ParentID,
+		Status:         r.Status,
+		Progress:       r.Progress,
+		Message:        r.Message,
+		Error:          r.Error,
+		CurrentStep:    r.CurrentStep,
+		Steps:          []TaskStep(r.Steps),
+		Params:         map[string]interface{}(r.Params),
+		Result:         map[string]interface{}(r.Result),
+		TimeoutSeconds: r.TimeoutSeconds,
+		RetryCount:     r.RetryCount,
+		MaxRetries:     r.MaxRetries,
+		Retryable:      r.Retryable,
+		CreatedBy:      r.CreatedBy,
+		CreatedAt:      r.CreatedAt,
+		StartedAt:      r.StartedAt,
+		CompletedAt:    r.CompletedAt,
+	}
+}
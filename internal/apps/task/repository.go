@@ -0,0 +1,116 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package task
+
+import (
+	"context"
+	"errors"
+
+	"github.com/seatunnel/seatunnelX/internal/pkg/pagex"
+	"gorm.io/gorm"
+)
+
+// Repository persists task history.
+// Repository 持久化任务历史。
+type Repository struct {
+	db *gorm.DB
+}
+
+// NewRepository creates a new Repository instance.
+// NewRepository 创建一个新的 Repository 实例。
+func NewRepository(db *gorm.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// Save creates or replaces a task record.
+// Save 创建或替换任务记录。
+func (r *Repository) Save(ctx context.Context, t *Task) error {
+	return r.db.WithContext(ctx).Save(newTaskRecord(t)).Error
+}
+
+// Get returns a task by ID.
+// Get 根据 ID 获取任务。
+func (r *Repository) Get(ctx context.Context, taskID string) (*Task, error) {
+	var record TaskRecord
+	if err := r.db.WithContext(ctx).Where("id = ?", taskID).First(&record).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrTaskNotFound
+		}
+		return nil, err
+	}
+	return record.toTask(), nil
+}
+
+// List returns the newest tasks matching filter and the total number of matches.
+// List 返回符合筛选条件的最新任务以及匹配总数。
+func (r *Repository) List(ctx context.Context, filter *TaskFilter) ([]*Task, int, error) {
+	query := r.db.WithContext(ctx).Model(&TaskRecord{})
+	if filter != nil {
+		if filter.Status != nil {
+			query = query.Where("status = ?", *filter.Status)
+		}
+		if filter.Type != nil {
+			query = query.Where("type = ?", *filter.Type)
+		}
+		if filter.Source != "" {
+			query = query.Where("source = ?", filter.Source)
+		}
+		if filter.HostID > 0 {
+			query = query.Where("host_id = ?", filter.HostID)
+		}
+		if filter.ClusterID > 0 {
+			query = query.Where("cluster_id = ?", filter.ClusterID)
+		}
+		query = filter.CreatedAt.Apply(query, "created_at")
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var sort []pagex.SortField
+	if filter != nil {
+		sort = filter.Sort
+		if filter.PageSize > 0 {
+			query = pagex.Paginate(query, filter.Page, filter.PageSize)
+		} else if filter.Limit > 0 {
+			query = query.Limit(filter.Limit)
+		}
+	}
+	query = pagex.Order(query, sort, "created_at DESC")
+	var records []*TaskRecord
+	if err := query.Find(&records).Error; err != nil {
+		return nil, 0, err
+	}
+
+	tasks := make([]*Task, 0, len(records))
+	for _, record := range records {
+		tasks = append(tasks, record.toTask())
+	}
+	return tasks, int(total), nil
+}
+
+// FailActive marks tasks left pending/running (e.g. by a restart) as failed.
+// FailActive 将遗留的等待中/执行中任务（例如服务重启导致）标记为失败。
+func (r *Repository) FailActive(ctx context.Context, reason string) (int64, error) {
+	result := r.db.WithContext(ctx).Model(&TaskRecord{}).
+		Where("status IN ?", []TaskStatus{TaskStatusPending, TaskStatusRunning}).
+		Updates(map[string]interface{}{"status": TaskStatusFailed, "error": reason})
+	return result.RowsAffected, result.Error
+}
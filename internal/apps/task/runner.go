@@ -0,0 +1,152 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package task
+
+import (
+	"context"
+	"time"
+)
+
+// Runner executes a task. It reports progress through the reporter, must return when ctx is done,
+// and its result map is stored on the task.
+// Runner 执行任务：通过 reporter 上报进度，在 ctx 结束时必须返回，返回的结果会保存到任务上。
+type Runner func(ctx context.Context, task *Task, reporter *Reporter) (map[string]interface{}, error)
+
+// Reporter updates the progress and steps of a running task.
+// All methods are safe on a nil Reporter, so operations can report unconditionally.
+// Reporter 更新执行中任务的进度与步骤。
+// 所有方法在 nil Reporter 上都可安全调用，因此业务操作可无条件上报。
+type Reporter struct {
+	manager *Manager
+	taskID  string
+}
+
+type reporterKey struct{}
+
+// ReporterFromContext returns the reporter of the task executing ctx, or nil.
+// ReporterFromContext 返回执行 ctx 的任务的 Reporter，不存在时返回 nil。
+func ReporterFromContext(ctx context.Context) *Reporter {
+	reporter, _ := ctx.Value(reporterKey{}).(*Reporter)
+	return reporter
+}
+
+// TaskID returns the ID of the reported task.
+// TaskID 返回被上报任务的 ID。
+func (r *Reporter) TaskID() string {
+	if r == nil {
+		return ""
+	}
+	return r.taskID
+}
+
+// Update sets the overall progress (0-100) and message.
+// Update 设置总体进度（0-100）与消息。
+func (r *Reporter) Update(progress int, message string) {
+	if r == nil {
+		return
+	}
+	r.manager.updateRunning(r.taskID, false, func(t *Task) {
+		if progress >= 0 && progress <= 100 {
+			t.Progress = progress
+		}
+		if message != "" {
+			t.Message = message
+		}
+	})
+}
+
+// StartStep marks a step as running, adding it when it is new.
+// StartStep 将步骤标记为执行中，新步骤会被追加。
+func (r *Reporter) StartStep(name, description string) {
+	if r == nil {
+		return
+	}
+	r.manager.updateRunning(r.taskID, true, func(t *Task) {
+		now := time.Now()
+		step := findStep(t, name)
+		if step == nil {
+			t.Steps = append(t.Steps, TaskStep{Name: name, Description: description})
+			step = &t.Steps[len(t.Steps)-1]
+		}
+		step.Status = TaskStatusRunning
+		step.StartedAt = &now
+		step.CompletedAt = nil
+		step.Error = ""
+		t.CurrentStep = name
+	})
+}
+
+// CompleteStep marks a step as successful.
+// CompleteStep 将步骤标记为成功。
+func (r *Reporter) CompleteStep(name, message string) {
+	r.finishStep(name, TaskStatusSuccess, message, "")
+}
+
+// FailStep marks a step as failed.
+// FailStep 将步骤标记为失败。
+func (r *Reporter) FailStep(name string, err error) {
+	msg := ""
+	if err != nil {
+		msg = err.Error()
+	}
+	r.finishStep(name, TaskStatusFailed, "", msg)
+}
+
+// SetSteps replaces all steps, for operations that already track their own step list.
+// SetSteps 整体替换步骤列表，适用于自身已维护步骤列表的操作。
+func (r *Reporter) SetSteps(steps []TaskStep, current string) {
+	if r == nil {
+		return
+	}
+	r.manager.updateRunning(r.taskID, true, func(t *Task) {
+		t.Steps = append([]TaskStep(nil), steps...)
+		t.CurrentStep = current
+	})
+}
+
+func (r *Reporter) finishStep(name string, status TaskStatus, message, errMsg string) {
+	if r == nil {
+		return
+	}
+	r.manager.updateRunning(r.taskID, true, func(t *Task) {
+		step := findStep(t, name)
+		if step == nil {
+			t.Steps = append(t.Steps, TaskStep{Name: name})
+			step = &t.Steps[len(t.Steps)-1]
+		}
+		now := time.Now()
+		step.Status = status
+		step.CompletedAt = &now
+		if status == TaskStatusSuccess {
+			step.Progress = 100
+		}
+		if message != "" {
+			step.Message = message
+		}
+		step.Error = errMsg
+	})
+}
+
+func findStep(t *Task, name string) *TaskStep {
+	for i := range t.Steps {
+		if t.Steps[i].Name == name {
+			return &t.Steps[i]
+		}
+	}
+	return nil
+}
@@ -17,10 +17,19 @@
 
 // Package task provides task management for Control Plane to Agent communication.
 // task 包提供 Control Plane 到 Agent 通信的任务管理功能。
+//
+// Every long-running operation (installation, plugin install, cluster start/stop, ...) runs as a task:
+// a Runner executes it, reports progress and steps through a Reporter, honours cancellation through its
+// context, and the task history is persisted so it stays visible via /api/v1/tasks.
+// 每个长时间运行的操作（安装、插件安装、集群启停等）都以任务形式执行：Runner 负责执行，
+// 通过 Reporter 上报进度与步骤，通过 context 响应取消，任务历史会被持久化并可通过 /api/v1/tasks 查看。
 package task
 
 import (
+	"encoding/json"
 	"time"
+
+	"github.com/seatunnel/seatunnelX/internal/pkg/pagex"
 )
 
 // TaskType represents the type of task
@@ -71,6 +80,32 @@ const (
 	// TaskTypeUninstallPlugin is for uninstalling plugins
 	// TaskTypeUninstallPlugin 用于卸载插件
 	TaskTypeUninstallPlugin TaskType = "uninstall_plugin"
+
+	// TaskTypeRollingRestart is for restarting cluster nodes one by one
+	// TaskTypeRollingRestart 用于逐个重启集群节点
+	TaskTypeRollingRestart TaskType = "rolling_restart"
+)
+
+// TaskSource identifies the module that created a task
+// TaskSource 标识创建任务的模块
+type TaskSource string
+
+const (
+	// TaskSourceAPI marks tasks created through /api/v1/tasks
+	// TaskSourceAPI 表示通过 /api/v1/tasks 创建的任务
+	TaskSourceAPI TaskSource = "api"
+
+	// TaskSourceInstaller marks tasks created by the installer
+	// TaskSourceInstaller 表示由安装模块创建的任务
+	TaskSourceInstaller TaskSource = "installer"
+
+	// TaskSourcePlugin marks tasks created by plugin management
+	// TaskSourcePlugin 表示由插件管理创建的任务
+	TaskSourcePlugin TaskSource = "plugin"
+
+	// TaskSourceCluster marks tasks created by cluster operations
+	// TaskSourceCluster 表示由集群操作创建的任务
+	TaskSourceCluster TaskSource = "cluster"
 )
 
 // TaskStatus represents the status of a task
@@ -103,6 +138,12 @@ const (
 	TaskStatusTimeout TaskStatus = "timeout"
 )
 
+// IsFinished reports whether the status is terminal
+// IsFinished 判断状态是否为终态
+func (s TaskStatus) IsFinished() bool {
+	return s == TaskStatusSuccess || s == TaskStatusFailed || s == TaskStatusCancelled || s == TaskStatusTimeout
+}
+
 // Task represents a task to be executed by an Agent
 // Task 表示要由 Agent 执行的任务
 type Task struct {
@@ -122,6 +163,18 @@ type Task struct {
 	// AgentID 是目标 Agent ID
 	AgentID string `json:"agent_id"`
 
+	// ClusterID is the target cluster ID (0 for host-level tasks)
+	// ClusterID 是目标集群 ID（主机级任务为 0）
+	ClusterID uint `json:"cluster_id,omitempty"`
+
+	// Source is the module that created the task
+	// Source 是创建任务的模块
+	Source TaskSource `json:"source"`
+
+	// ParentID is the task this one retries
+	// ParentID 是被本任务重试的原任务
+	ParentID string `json:"parent_id,omitempty"`
+
 	// Status is the current task status
 	// Status 是当前任务状态
 	Status TaskStatus `json:"status"`
@@ -138,6 +191,14 @@ type Task struct {
 	// Error 是失败时的错误消息
 	Error string `json:"error,omitempty"`
 
+	// CurrentStep is the name of the step being executed
+	// CurrentStep 是正在执行的步骤名称
+	CurrentStep string `json:"current_step,omitempty"`
+
+	// Steps contains the step statuses reported by the runner
+	// Steps 包含执行器上报的步骤状态
+	Steps []TaskStep `json:"steps,omitempty"`
+
 	// Params contains task-specific parameters
 	// Params 包含任务特定的参数
 	Params map[string]interface{} `json:"params,omitempty"`
@@ -262,6 +323,14 @@ type CreateTaskRequest struct {
 	// HostID 是目标主机 ID
 	HostID uint `json:"host_id" binding:"required"`
 
+	// AgentID is the target agent ID; resolved from the host when empty
+	// AgentID 是目标 Agent ID，为空时根据主机解析
+	AgentID string `json:"agent_id,omitempty"`
+
+	// ClusterID is the target cluster ID
+	// ClusterID 是目标集群 ID
+	ClusterID uint `json:"cluster_id,omitempty"`
+
 	// Params contains task-specific parameters
 	// Params 包含任务特定的参数
 	Params map[string]interface{} `json:"params,omitempty"`
@@ -269,6 +338,67 @@ type CreateTaskRequest struct {
 	// TimeoutSeconds is the task timeout in seconds
 	// TimeoutSeconds 是任务超时时间（秒）
 	TimeoutSeconds int `json:"timeout_seconds,omitempty"`
+
+	// Source is the creating module; set by the caller, never by the API
+	// Source 是创建任务的模块，由调用方设置，API 请求中不可指定
+	Source TaskSource `json:"-"`
+}
+
+// TaskFilter narrows a task listing; zero fields do not filter
+// TaskFilter 用于筛选任务列表，零值字段不参与过滤
+type TaskFilter struct {
+	Status    *TaskStatus
+	Type      *TaskType
+	Source    TaskSource
+	HostID    uint
+	ClusterID uint
+	// CreatedAt restricts the creation time / CreatedAt 限制创建时间范围
+	CreatedAt pagex.TimeRange
+	// Page and PageSize select one page; Limit caps the result when PageSize is not set
+	// Page 与 PageSize 选择一页；未设置 PageSize 时由 Limit 限制返回数量
+	Page     int
+	PageSize int
+	Limit    int
+	// Sort orders the result, newest first when empty / Sort 指定排序，为空时按创建时间倒序
+	Sort []pagex.SortField
+}
+
+// ListSpec defines paging and the sortable fields of task listings.
+// ListSpec 定义任务列表的分页方式和可排序字段。
+var ListSpec = pagex.Spec{
+	Fields: map[string]string{
+		"created_at":   "created_at",
+		"started_at":   "started_at",
+		"completed_at": "completed_at",
+		"status":       "status",
+		"type":         "type",
+		"progress":     "progress",
+	},
+	DefaultSort: "-created_at",
+}
+
+// ParamsFrom converts a typed payload into task params
+// ParamsFrom 将强类型载荷转换为任务参数
+func ParamsFrom(payload interface{}) (map[string]interface{}, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	params := map[string]interface{}{}
+	if err := json.Unmarshal(data, &params); err != nil {
+		return nil, err
+	}
+	return params, nil
+}
+
+// DecodeParams decodes the task params into a typed payload
+// DecodeParams 将任务参数解码为强类型载荷
+func (t *Task) DecodeParams(out interface{}) error {
+	data, err := json.Marshal(t.Params)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, out)
 }
 
 // TaskListResponse represents a list of tasks
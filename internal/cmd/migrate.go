@@ -0,0 +1,142 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"text/tabwriter"
+
+	"github.com/seatunnel/seatunnelX/internal/config"
+	"github.com/seatunnel/seatunnelX/internal/db"
+	"github.com/seatunnel/seatunnelX/internal/db/migrator"
+	"github.com/seatunnel/seatunnelX/internal/pkg/secrets"
+	"github.com/spf13/cobra"
+)
+
+var migrateSteps int
+
+var migrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Manage database schema migrations",
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		// Data migrations re-encrypt credentials, so the master key must be available
+		masterKey, err := config.GetSecretsMasterKey()
+		if err != nil {
+			log.Fatalf("[Migrate] refuse to start without a dedicated secrets master key: %v\n", err)
+		}
+		if err := secrets.Init(secrets.NewMasterKeyProvider(masterKey)); err != nil {
+			log.Fatalf("[Migrate] init secrets encryption failed: %v\n", err)
+		}
+		if err := db.InitDatabase(); err != nil {
+			log.Fatalf("[Migrate] init database failed: %v\n", err)
+		}
+		if !db.IsDatabaseInitialized() {
+			log.Fatalf("[Migrate] database is disabled\n")
+		}
+	},
+}
+
+var migrateUpCmd = &cobra.Command{
+	Use:   "up",
+	Short: "Apply all pending migrations",
+	Run: func(cmd *cobra.Command, args []string) {
+		runner := newMigrationRunner()
+		applied, err := runner.Up(context.Background())
+		for _, id := range applied {
+			log.Printf("[Migrate] applied %s\n", id)
+		}
+		if err != nil {
+			log.Fatalf("[Migrate] %v\n", err)
+		}
+		if len(applied) == 0 {
+			log.Println("[Migrate] schema is already up to date")
+		}
+	},
+}
+
+var migrateDownCmd = &cobra.Command{
+	Use:   "down",
+	Short: "Revert the latest applied migrations",
+	Run: func(cmd *cobra.Command, args []string) {
+		runner := newMigrationRunner()
+		reverted, err := runner.Down(context.Background(), migrateSteps)
+		for _, id := range reverted {
+			log.Printf("[Migrate] reverted %s\n", id)
+		}
+		if err != nil {
+			log.Fatalf("[Migrate] %v\n", err)
+		}
+	},
+}
+
+var migrateStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show applied and pending migrations",
+	Run: func(cmd *cobra.Command, args []string) {
+		statuses, err := newMigrationRunner().Status(context.Background())
+		if err != nil {
+			log.Fatalf("[Migrate] %v\n", err)
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		_, _ = fmt.Fprintln(w, "ID\tSTATE\tAPPLIED AT\tDESCRIPTION")
+		for _, status := range statuses {
+			state, appliedAt := "pending", "-"
+			if status.Applied {
+				state = "applied"
+				appliedAt = status.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			if status.Unknown {
+				state = "unknown"
+			}
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", status.ID, state, appliedAt, status.Description)
+		}
+		_ = w.Flush()
+	},
+}
+
+var migrateVersionCmd = &cobra.Command{
+	Use:   "version",
+	Short: "Print the current schema version",
+	Run: func(cmd *cobra.Command, args []string) {
+		version, err := newMigrationRunner().Version(context.Background())
+		if err != nil {
+			log.Fatalf("[Migrate] %v\n", err)
+		}
+		if version == "" {
+			version = "none"
+		}
+		fmt.Println(version)
+	},
+}
+
+func newMigrationRunner() *migrator.Runner {
+	runner, err := migrator.NewDefaultRunner()
+	if err != nil {
+		log.Fatalf("[Migrate] %v\n", err)
+	}
+	return runner
+}
+
+func init() {
+	migrateDownCmd.Flags().IntVar(&migrateSteps, "steps", 1, "number of migrations to revert")
+	migrateCmd.AddCommand(migrateUpCmd, migrateDownCmd, migrateStatusCmd, migrateVersionCmd)
+	rootCmd.AddCommand(migrateCmd)
+}
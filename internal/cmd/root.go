@@ -25,14 +25,25 @@
 package cmd
 
 import (
+	"github.com/seatunnel/seatunnelX/internal/config"
 	"github.com/seatunnel/seatunnelX/internal/db/migrator"
+	"github.com/seatunnel/seatunnelX/internal/pkg/secrets"
 	"github.com/spf13/cobra"
 	"log"
 )
 
 var rootCmd = &cobra.Command{
 	Use: "linux-do-cdk",
+	// Modes (api, scheduler, worker) are positional args; migrate is a subcommand.
+	Args: cobra.ArbitraryArgs,
 	PreRun: func(cmd *cobra.Command, args []string) {
+		masterKey, err := config.GetSecretsMasterKey()
+		if err != nil {
+			log.Fatalf("[CMD] refuse to start without a dedicated secrets master key: %s\n", err)
+		}
+		if err := secrets.Init(secrets.NewMasterKeyProvider(masterKey)); err != nil {
+			log.Fatalf("[CMD] init secrets encryption failed: %s\n", err)
+		}
 		migrator.Migrate()
 	},
 	Run: func(cmd *cobra.Command, args []string) {
@@ -20,10 +20,15 @@ package config
 import (
 	"fmt"
 	"log"
+	"net"
 	"net/url"
 	"os"
+	"path"
 	"strings"
+	"time"
 
+	"github.com/seatunnel/seatunnelX/internal/pkg/filestream"
+	"github.com/seatunnel/seatunnelX/internal/pkg/installhook"
 	"github.com/spf13/viper"
 )
 
@@ -90,11 +95,39 @@ func setDefaults(c *configModel) {
 	if c.Database.SQLitePath == "" {
 		c.Database.SQLitePath = "./data/seatunnel.db"
 	}
+	if c.Database.MigrationMode == "" {
+		c.Database.MigrationMode = MigrationModeAuto
+	}
 
 	if c.Sync.PreviewDataTTLMinutes <= 0 && c.Sync.PreviewDataTTLHours <= 0 {
 		c.Sync.PreviewDataTTLMinutes = 24 * 60
 	}
 
+	// 优雅关闭默认配置
+	if c.App.ShutdownTimeout == 0 {
+		c.App.ShutdownTimeout = 30
+	}
+
+	// 跨域与 CSRF 默认配置
+	if len(c.App.CORS.AllowedMethods) == 0 {
+		c.App.CORS.AllowedMethods = []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"}
+	}
+	if c.App.CSRF.CookieName == "" {
+		c.App.CSRF.CookieName = "seatunnel_csrf_token"
+	}
+	if c.App.CSRF.HeaderName == "" {
+		c.App.CSRF.HeaderName = "X-CSRF-Token"
+	}
+	if len(c.App.CORS.AllowedHeaders) == 0 {
+		c.App.CORS.AllowedHeaders = []string{"Content-Type", "Authorization", "X-Requested-With", c.App.CSRF.HeaderName}
+	}
+
+	// 敏感接口限流默认配置（每分钟）
+	setRateLimitDefaults(&c.App.RateLimit.Login, 10, 0)
+	setRateLimitDefaults(&c.App.RateLimit.PackageUpload, 600, 300)
+	setRateLimitDefaults(&c.App.RateLimit.Installation, 30, 20)
+	setRateLimitDefaults(&c.App.RateLimit.Command, 60, 30)
+
 	// 认证默认配置
 	if c.Auth.DefaultAdminUsername == "" {
 		c.Auth.DefaultAdminUsername = "admin"
@@ -133,6 +166,33 @@ func setDefaults(c *configModel) {
 	if c.GRPC.HeartbeatTimeout == 0 {
 		c.GRPC.HeartbeatTimeout = 30 // 30 seconds
 	}
+	if c.GRPC.CommandQueueTTL == 0 {
+		c.GRPC.CommandQueueTTL = 60 // 60 seconds
+	}
+	if c.GRPC.CommandProgressTimeout == 0 {
+		c.GRPC.CommandProgressTimeout = 180 // 3 minutes
+	}
+	if c.GRPC.FileTransferChunkSizeKB == 0 {
+		c.GRPC.FileTransferChunkSizeKB = 1024 // 1MB
+	}
+	if c.GRPC.FileTransferCompression == "" {
+		c.GRPC.FileTransferCompression = filestream.CompressionZstd
+	}
+	if c.GRPC.DrainTimeout == 0 {
+		c.GRPC.DrainTimeout = 30 // 30 seconds
+	}
+	if c.GRPC.ReconnectDelay == 0 {
+		c.GRPC.ReconnectDelay = 5 // 5 seconds
+	}
+	if c.GRPC.ReconnectJitter == 0 {
+		c.GRPC.ReconnectJitter = 30 // 30 seconds
+	}
+	if c.GRPC.RegistrationRateLimit == 0 {
+		c.GRPC.RegistrationRateLimit = 30 // 30 per minute per IP
+	}
+	if c.GRPC.EnrollmentTokenTTL == 0 {
+		c.GRPC.EnrollmentTokenTTL = 60 // 1 hour
+	}
 
 	// 存储默认配置
 	if c.Storage.BaseDir == "" {
@@ -153,6 +213,31 @@ func setDefaults(c *configModel) {
 	if c.Storage.CleanupIntervalHours == 0 {
 		c.Storage.CleanupIntervalHours = 24
 	}
+	if c.Storage.ObjectStore.Prefix == "" {
+		c.Storage.ObjectStore.Prefix = "seatunnelx"
+	}
+	if c.Storage.ObjectStore.PresignExpiryMinutes == 0 {
+		c.Storage.ObjectStore.PresignExpiryMinutes = 30
+	}
+
+	// 回收站默认配置
+	if c.RecycleBin.RetentionHours == 0 {
+		c.RecycleBin.RetentionHours = 168 // 7 days
+	}
+	if c.RecycleBin.PurgeIntervalMinutes == 0 {
+		c.RecycleBin.PurgeIntervalMinutes = 30
+	}
+
+	// 备份默认配置
+	if c.Backup.Storage == "" {
+		c.Backup.Storage = BackupStorageLocal
+	}
+	if c.Backup.LocalDir == "" {
+		c.Backup.LocalDir = "./data/backups"
+	}
+	if c.Backup.S3.Prefix == "" {
+		c.Backup.S3.Prefix = "seatunnelx-backups"
+	}
 
 	// 可观测性默认配置
 	if c.Observability.Prometheus.URL == "" {
@@ -190,6 +275,55 @@ func validateConfig(c *configModel) error {
 	if c == nil {
 		return nil
 	}
+	if err := validateDatabaseConfig(&c.Database); err != nil {
+		return err
+	}
+	if err := validateDownloadConfig(&c.Download); err != nil {
+		return err
+	}
+	if err := validateGRPCConfig(&c.GRPC); err != nil {
+		return err
+	}
+	if c.App.ShutdownTimeout < 0 {
+		return fmt.Errorf("app.shutdown_timeout must not be negative")
+	}
+	for _, proxy := range c.App.TrustedProxies {
+		if net.ParseIP(proxy) == nil {
+			if _, _, err := net.ParseCIDR(proxy); err != nil {
+				return fmt.Errorf("app.trusted_proxies: %q is not an IP address or CIDR", proxy)
+			}
+		}
+	}
+	if err := validateHTTPTLSConfig(c.App.Addr, &c.App.TLS); err != nil {
+		return err
+	}
+	if err := validateCORSConfig(&c.App.CORS); err != nil {
+		return err
+	}
+	if err := validateSessionConfig(&c.App.Session); err != nil {
+		return err
+	}
+	if err := validateHAConfig(&c.HA, c.App.SessionSecret); err != nil {
+		return err
+	}
+	if err := validateLDAPConfig(&c.Auth.LDAP); err != nil {
+		return err
+	}
+	if err := validateOIDCProviders(c.OAuthProviders.OIDC); err != nil {
+		return err
+	}
+	if err := validateBackupConfig(&c.Backup); err != nil {
+		return err
+	}
+	if err := validateObjectStoreConfig(&c.Storage.ObjectStore); err != nil {
+		return err
+	}
+	if err := validateInstallerConfig(&c.Installer); err != nil {
+		return err
+	}
+	if err := validateAuditConfig(&c.Audit); err != nil {
+		return err
+	}
 	if !c.Observability.Enabled {
 		return nil
 	}
@@ -215,6 +349,311 @@ func validateConfig(c *configModel) error {
 	return nil
 }
 
+func validateGRPCConfig(c *GRPCConfig) error {
+	chunkSize := c.FileTransferChunkSizeKB * 1024
+	if chunkSize != 0 && (chunkSize < filestream.MinChunkSize || chunkSize > filestream.MaxChunkSize) {
+		return fmt.Errorf("grpc.file_transfer_chunk_size_kb must be between %d and %d", filestream.MinChunkSize/1024, filestream.MaxChunkSize/1024)
+	}
+	if _, err := filestream.NormalizeCompression(c.FileTransferCompression); err != nil {
+		return fmt.Errorf("grpc.file_transfer_compression: %w", err)
+	}
+	return nil
+}
+
+func validateSessionConfig(c *SessionConfig) error {
+	if c.IdleTimeout < 0 {
+		return fmt.Errorf("app.session.idle_timeout must not be negative")
+	}
+	if c.MaxPerUser < 0 {
+		return fmt.Errorf("app.session.max_per_user must not be negative")
+	}
+	return nil
+}
+
+func validateHAConfig(c *HAConfig, sessionSecret string) error {
+	if !c.Enabled {
+		return nil
+	}
+	if strings.TrimSpace(c.AdvertiseAddr) == "" {
+		return fmt.Errorf("ha.advertise_addr is required when ha.enabled=true")
+	}
+	if err := validateOptionalHTTPURL("ha.advertise_addr", c.AdvertiseAddr); err != nil {
+		return err
+	}
+	// Forwarded commands carry credentials and are sealed with this secret; it must not double as the session secret
+	// 转发的命令携带凭证并以该密钥加密，不能与会话密钥共用
+	if strings.TrimSpace(c.SharedSecret) == "" {
+		return fmt.Errorf("ha.shared_secret is required when ha.enabled=true")
+	}
+	if strings.TrimSpace(c.SharedSecret) == strings.TrimSpace(sessionSecret) {
+		return fmt.Errorf("ha.shared_secret must differ from app.session_secret")
+	}
+	if c.LeaseTTLSeconds < 0 {
+		return fmt.Errorf("ha.lease_ttl_seconds must not be negative")
+	}
+	return nil
+}
+
+func validateBackupConfig(c *BackupConfig) error {
+	switch c.Storage {
+	case "", BackupStorageLocal:
+		return nil
+	case BackupStorageS3:
+	default:
+		return fmt.Errorf("backup.storage must be %q or %q", BackupStorageLocal, BackupStorageS3)
+	}
+	if err := validateRequiredHTTPURL("backup.s3.endpoint", c.S3.Endpoint); err != nil {
+		return err
+	}
+	if strings.TrimSpace(c.S3.Bucket) == "" {
+		return fmt.Errorf("backup.s3.bucket is required when backup.storage=s3")
+	}
+	if strings.TrimSpace(c.S3.AccessKey) == "" || strings.TrimSpace(c.S3.SecretKey) == "" {
+		return fmt.Errorf("backup.s3.access_key and backup.s3.secret_key are required when backup.storage=s3")
+	}
+	return nil
+}
+
+// Hook 转换为 Agent 执行的钩子定义
+// Hook converts the config into the hook definition executed by the Agent
+func (c InstallHookConfig) Hook() installhook.Hook {
+	return installhook.Hook{
+		Name:           strings.TrimSpace(c.Name),
+		Step:           strings.TrimSpace(c.Step),
+		Phase:          installhook.Phase(strings.TrimSpace(c.Phase)),
+		Script:         strings.TrimSpace(c.Script),
+		WebhookURL:     strings.TrimSpace(c.WebhookURL),
+		TimeoutSeconds: c.TimeoutSeconds,
+		FailOnError:    c.FailOnError,
+	}
+}
+
+func validateObjectStoreConfig(c *ObjectStoreConfig) error {
+	storeType := strings.ToLower(strings.TrimSpace(c.Type))
+	switch storeType {
+	case "":
+		return nil
+	case ObjectStoreLocal:
+		if strings.TrimSpace(c.LocalDir) == "" {
+			return fmt.Errorf("storage.object_store.local_dir is required when storage.object_store.type=local")
+		}
+		if c.PresignAgentDownloads {
+			return fmt.Errorf("storage.object_store.presign_agent_downloads requires an s3, oss or minio object store")
+		}
+		return nil
+	case ObjectStoreS3, ObjectStoreOSS, ObjectStoreMinIO:
+	default:
+		return fmt.Errorf("storage.object_store.type must be one of %q, %q, %q or %q", ObjectStoreLocal, ObjectStoreS3, ObjectStoreOSS, ObjectStoreMinIO)
+	}
+	if err := validateRequiredHTTPURL("storage.object_store.endpoint", c.Endpoint); err != nil {
+		return err
+	}
+	if strings.TrimSpace(c.Bucket) == "" {
+		return fmt.Errorf("storage.object_store.bucket is required when storage.object_store.type=%s", storeType)
+	}
+	if strings.TrimSpace(c.AccessKey) == "" || strings.TrimSpace(c.SecretKey) == "" {
+		return fmt.Errorf("storage.object_store.access_key and storage.object_store.secret_key are required when storage.object_store.type=%s", storeType)
+	}
+	if c.PresignExpiryMinutes < 0 || c.PresignExpiryMinutes > 7*24*60 {
+		return fmt.Errorf("storage.object_store.presign_expiry_minutes must be between 0 and %d", 7*24*60)
+	}
+	return nil
+}
+
+func validateInstallerConfig(c *InstallerConfig) error {
+	names := make(map[string]struct{}, len(c.Hooks))
+	for i, hookConfig := range c.Hooks {
+		hook := hookConfig.Hook()
+		if err := hook.Validate(); err != nil {
+			return fmt.Errorf("installer.hooks[%d]: %w", i, err)
+		}
+		if _, ok := names[hook.Name]; ok {
+			return fmt.Errorf("installer.hooks[%d]: duplicate hook name %q", i, hook.Name)
+		}
+		names[hook.Name] = struct{}{}
+	}
+	concurrency := c.Concurrency
+	if concurrency.MaxInstallations < 0 || concurrency.MaxPackageTransfers < 0 ||
+		concurrency.GlobalMaxInstallations < 0 || concurrency.GlobalMaxPackageTransfers < 0 {
+		return fmt.Errorf("installer.concurrency limits must not be negative")
+	}
+	return nil
+}
+
+func validateAuditConfig(c *AuditConfig) error {
+	for i, pattern := range c.Redaction.SensitiveKeys {
+		if _, err := path.Match(strings.ToLower(strings.TrimSpace(pattern)), ""); err != nil {
+			return fmt.Errorf("audit.redaction.sensitive_keys[%d]: invalid pattern %q", i, pattern)
+		}
+	}
+	return nil
+}
+
+func validateLDAPConfig(c *LDAPConfig) error {
+	if !c.Enabled {
+		return nil
+	}
+	u, err := url.Parse(strings.TrimSpace(c.URL))
+	if err != nil || (u.Scheme != "ldap" && u.Scheme != "ldaps") || u.Host == "" {
+		return fmt.Errorf("auth.ldap.url must be an ldap:// or ldaps:// URL")
+	}
+	if strings.TrimSpace(c.BaseDN) == "" {
+		return fmt.Errorf("auth.ldap.base_dn is required when auth.ldap.enabled=true")
+	}
+	if c.UserFilter != "" && !strings.Contains(c.UserFilter, "%s") {
+		return fmt.Errorf("auth.ldap.user_filter must contain %%s")
+	}
+	if c.GroupBaseDN != "" && !strings.Contains(c.GroupFilter, "%s") {
+		return fmt.Errorf("auth.ldap.group_filter must contain %%s when auth.ldap.group_base_dn is set")
+	}
+	if c.TimeoutSeconds < 0 {
+		return fmt.Errorf("auth.ldap.timeout_seconds must not be negative")
+	}
+	return validateRoleMappings("auth.ldap", c.RoleMappings, c.DefaultRole)
+}
+
+// reservedOIDCProviderNames 内置登录方式在 User.OAuthID 中使用的前缀，OIDC 提供商不能同名，避免外部账号标识冲突
+// reservedOIDCProviderNames are the OAuthID prefixes of built-in sign-in methods; OIDC providers must not reuse them
+var reservedOIDCProviderNames = map[string]bool{
+	"github": true,
+	"google": true,
+	"ldap":   true,
+}
+
+func validateOIDCProviders(providers []OIDCProviderConfig) error {
+	seen := make(map[string]bool, len(providers))
+	for i, p := range providers {
+		name := strings.ToLower(strings.TrimSpace(p.Name))
+		field := fmt.Sprintf("oauth_providers.oidc[%d]", i)
+		if name == "" || strings.ContainsAny(name, ": /?&") {
+			return fmt.Errorf("%s.name is required and must not contain ':', '/', '?', '&' or spaces", field)
+		}
+		if reservedOIDCProviderNames[name] || seen[name] {
+			return fmt.Errorf("%s.name %q is duplicated or reserved", field, name)
+		}
+		seen[name] = true
+		if !p.Enabled {
+			continue
+		}
+		if strings.TrimSpace(p.Issuer) == "" || strings.TrimSpace(p.ClientID) == "" {
+			return fmt.Errorf("%s.issuer and client_id are required when enabled", field)
+		}
+		if err := validateOptionalHTTPURL(field+".issuer", p.Issuer); err != nil {
+			return err
+		}
+		if err := validateRoleMappings(field, p.RoleMappings, p.DefaultRole); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateRoleMappings(field string, mappings []RoleMappingConfig, defaultRole string) error {
+	validRole := func(role string) bool {
+		switch strings.ToLower(strings.TrimSpace(role)) {
+		case "admin", "operator", "viewer":
+			return true
+		}
+		return false
+	}
+	for i, m := range mappings {
+		if strings.TrimSpace(m.Group) == "" || !validRole(m.Role) {
+			return fmt.Errorf("%s.role_mappings[%d] requires a group and a role of admin, operator or viewer", field, i)
+		}
+	}
+	if defaultRole != "" && !validRole(defaultRole) {
+		return fmt.Errorf("%s.default_role must be admin, operator or viewer", field)
+	}
+	return nil
+}
+
+// setRateLimitDefaults 填充未设置的限额，-1 保持为不限流
+func setRateLimitDefaults(rule *RateLimitRule, perIP, perUser int) {
+	if rule.PerIP == 0 {
+		rule.PerIP = perIP
+	}
+	if rule.PerUser == 0 {
+		rule.PerUser = perUser
+	}
+}
+
+func validateHTTPTLSConfig(addr string, c *HTTPTLSConfig) error {
+	if !c.Enabled {
+		return nil
+	}
+	if strings.TrimSpace(c.CertFile) == "" || strings.TrimSpace(c.KeyFile) == "" {
+		return fmt.Errorf("app.tls.cert_file and app.tls.key_file are required when app.tls.enabled is true")
+	}
+	if c.ReloadInterval < 0 {
+		return fmt.Errorf("app.tls.reload_interval must not be negative")
+	}
+	if c.HSTSMaxAge < 0 {
+		return fmt.Errorf("app.tls.hsts_max_age must not be negative")
+	}
+	if redirect := strings.TrimSpace(c.RedirectAddr); redirect != "" && redirect == strings.TrimSpace(addr) {
+		return fmt.Errorf("app.tls.redirect_addr must differ from app.addr")
+	}
+	return nil
+}
+
+func validateCORSConfig(c *CORSConfig) error {
+	for i, origin := range c.AllowedOrigins {
+		if strings.TrimSpace(origin) == "*" {
+			if c.AllowCredentials {
+				return fmt.Errorf("app.cors.allowed_origins cannot contain \"*\" when app.cors.allow_credentials is true")
+			}
+			continue
+		}
+		name := fmt.Sprintf("app.cors.allowed_origins[%d]", i)
+		if strings.TrimSpace(origin) == "" {
+			return fmt.Errorf("%s must not be empty", name)
+		}
+		if err := validateOptionalHTTPURL(name, origin); err != nil {
+			return err
+		}
+	}
+	if c.MaxAge < 0 {
+		return fmt.Errorf("app.cors.max_age must not be negative")
+	}
+	return nil
+}
+
+func validateDatabaseConfig(c *DatabaseConfig) error {
+	switch c.MigrationMode {
+	case "", MigrationModeAuto, MigrationModeCheck:
+		return nil
+	default:
+		return fmt.Errorf("database.migration_mode must be %q or %q", MigrationModeAuto, MigrationModeCheck)
+	}
+}
+
+func validateDownloadConfig(c *DownloadConfig) error {
+	names := make(map[string]struct{}, len(c.Mirrors))
+	for i, mirror := range c.Mirrors {
+		name := strings.TrimSpace(mirror.Name)
+		if name == "" {
+			return fmt.Errorf("download.mirrors[%d].name is required", i)
+		}
+		if _, ok := names[name]; ok {
+			return fmt.Errorf("download.mirrors[%d].name %q is duplicated", i, name)
+		}
+		names[name] = struct{}{}
+		if strings.TrimSpace(mirror.PackageBaseURL) == "" && strings.TrimSpace(mirror.MavenBaseURL) == "" {
+			return fmt.Errorf("download.mirrors[%d] requires package_base_url or maven_base_url", i)
+		}
+		if err := validateOptionalHTTPURL(fmt.Sprintf("download.mirrors[%d].package_base_url", i), mirror.PackageBaseURL); err != nil {
+			return err
+		}
+		if err := validateOptionalHTTPURL(fmt.Sprintf("download.mirrors[%d].maven_base_url", i), mirror.MavenBaseURL); err != nil {
+			return err
+		}
+	}
+	if err := validateOptionalHTTPURL("download.proxy.http_proxy", c.Proxy.HTTPProxy); err != nil {
+		return err
+	}
+	return validateOptionalHTTPURL("download.proxy.https_proxy", c.Proxy.HTTPSProxy)
+}
+
 func validateRequiredHTTPURL(name, raw string) error {
 	trimmed := strings.TrimSpace(raw)
 	if trimmed == "" {
@@ -257,6 +696,12 @@ func GetDatabaseType() string {
 	return Config.Database.Type
 }
 
+// GetMigrationMode 获取数据库迁移模式
+// GetMigrationMode returns the database migration mode
+func GetMigrationMode() string {
+	return Config.Database.MigrationMode
+}
+
 // GetSQLitePath 获取 SQLite 文件路径
 func GetSQLitePath() string {
 	return Config.Database.SQLitePath
@@ -272,6 +717,15 @@ func GetStorageConfig() StorageConfig {
 	return Config.Storage
 }
 
+// GetDownloadConfig 获取外部下载配置（自定义镜像与代理）
+// GetDownloadConfig returns the outbound download configuration
+func GetDownloadConfig() DownloadConfig {
+	if Config == nil {
+		return DownloadConfig{}
+	}
+	return Config.Download
+}
+
 // GetPackagesDir 获取安装包存储目录
 func GetPackagesDir() string {
 	if Config.Storage.PackagesDir != "" {
@@ -288,6 +742,56 @@ func GetPluginsDir() string {
 	return "./lib/plugins"
 }
 
+// GetPluginKeysURL 获取插件签名校验使用的 KEYS 文件地址
+func GetPluginKeysURL() string {
+	if Config.Storage.PluginKeysURL != "" {
+		return Config.Storage.PluginKeysURL
+	}
+	return "https://downloads.apache.org/seatunnel/KEYS"
+}
+
+// GetPluginIndexURL 获取离线插件索引的刷新地址
+func GetPluginIndexURL() string {
+	if Config.Storage.PluginIndexURL != "" {
+		return Config.Storage.PluginIndexURL
+	}
+	return "https://github.com/LeonYoah/SeaTunnelX/releases/latest/download/plugin-index.json"
+}
+
+// GetSSHCredentialSecret 获取早期版本加密 SSH 凭证的密钥，用于迁移到 secrets 主密钥
+func GetSSHCredentialSecret() string {
+	if Config.SSHDeploy.CredentialSecret != "" {
+		return Config.SSHDeploy.CredentialSecret
+	}
+	return Config.App.SessionSecret
+}
+
+// GetSecretsMasterKey 获取加密敏感配置的主密钥
+// 主密钥必须单独配置且不能与 app.session_secret 相同，否则轮换会话密钥会使已加密的数据无法解密
+func GetSecretsMasterKey() (string, error) {
+	return resolveSecretsMasterKey(Config)
+}
+
+// resolveSecretsMasterKey 校验并返回 c 中配置的主密钥
+func resolveSecretsMasterKey(c *configModel) (string, error) {
+	masterKey := strings.TrimSpace(c.Secrets.MasterKey)
+	if masterKey == "" {
+		return "", fmt.Errorf("secrets.master_key is required; when upgrading, set it to the current app.session_secret and then change app.session_secret")
+	}
+	if masterKey == strings.TrimSpace(c.App.SessionSecret) {
+		return "", fmt.Errorf("secrets.master_key must differ from app.session_secret; keep secrets.master_key and change app.session_secret")
+	}
+	return masterKey, nil
+}
+
+// GetSSHDeployTimeout 获取单次 SSH 部署的超时时间
+func GetSSHDeployTimeout() time.Duration {
+	if Config.SSHDeploy.TimeoutMinutes > 0 {
+		return time.Duration(Config.SSHDeploy.TimeoutMinutes) * time.Minute
+	}
+	return 15 * time.Minute
+}
+
 // GetTempDir 获取临时文件目录
 func GetTempDir() string {
 	if Config.Storage.TempDir != "" {
@@ -337,3 +841,63 @@ func GetGRPCPort() int {
 	}
 	return 9000
 }
+
+// GetHAConfig 获取控制面高可用配置
+// GetHAConfig returns the Control Plane high-availability configuration
+func GetHAConfig() HAConfig {
+	return Config.HA
+}
+
+// GetHASharedSecret 获取副本间转发命令的共享密钥
+// GetHASharedSecret returns the secret authenticating and sealing forwarded commands
+func GetHASharedSecret() string {
+	return strings.TrimSpace(Config.HA.SharedSecret)
+}
+
+// GetBackupConfig 获取集群备份配置
+// GetBackupConfig returns the cluster backup configuration
+func GetBackupConfig() BackupConfig {
+	if Config == nil {
+		return BackupConfig{Storage: BackupStorageLocal, LocalDir: "./data/backups"}
+	}
+	return Config.Backup
+}
+
+// GetObjectStoreConfig 获取共享对象存储配置
+// GetObjectStoreConfig returns the shared object store configuration
+func GetObjectStoreConfig() ObjectStoreConfig {
+	if Config == nil {
+		return ObjectStoreConfig{}
+	}
+	return Config.Storage.ObjectStore
+}
+
+// GetInstallerConfig 获取安装流程配置
+// GetInstallerConfig returns the installation flow configuration
+func GetInstallerConfig() InstallerConfig {
+	if Config == nil {
+		return InstallerConfig{}
+	}
+	return Config.Installer
+}
+
+// GetAuditConfig 获取审计配置
+// GetAuditConfig returns the audit configuration
+func GetAuditConfig() AuditConfig {
+	if Config == nil {
+		return AuditConfig{}
+	}
+	return Config.Audit
+}
+
+// GetAgentTelemetryEndpoint 获取写入 Agent 配置的 OTLP 收集器地址，未启用遥测时返回空字符串
+// GetAgentTelemetryEndpoint returns the OTLP collector written into Agent configs, or "" when telemetry is disabled
+func GetAgentTelemetryEndpoint() string {
+	if !Config.Telemetry.Enabled {
+		return ""
+	}
+	if endpoint := strings.TrimSpace(Config.Telemetry.AgentEndpoint); endpoint != "" {
+		return endpoint
+	}
+	return strings.TrimSpace(Config.Telemetry.Endpoint)
+}
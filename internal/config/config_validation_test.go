@@ -55,3 +55,341 @@ func TestValidateConfig_RemoteObservabilityHappyPath(t *testing.T) {
 		t.Fatalf("expected nil error, got: %v", err)
 	}
 }
+
+func TestValidateConfig_DownloadMirrors(t *testing.T) {
+	c := &configModel{}
+	c.Download.Mirrors = []DownloadMirrorConfig{
+		{Name: "nexus", PackageBaseURL: "https://nexus.example.com/repository/seatunnel"},
+		{Name: "nexus", MavenBaseURL: "https://nexus.example.com/repository/maven-public"},
+	}
+	if err := validateConfig(c); err == nil {
+		t.Fatalf("expected validation error for duplicated mirror name")
+	}
+
+	c.Download.Mirrors[1].Name = "nexus-maven"
+	if err := validateConfig(c); err != nil {
+		t.Fatalf("expected nil error, got: %v", err)
+	}
+
+	c.Download.Proxy.HTTPSProxy = "proxy.example.com:3128"
+	if err := validateConfig(c); err == nil {
+		t.Fatalf("expected validation error for proxy without scheme")
+	}
+}
+
+func TestValidateConfig_FileTransfer(t *testing.T) {
+	c := &configModel{}
+	c.GRPC.FileTransferChunkSizeKB = 4096
+	if err := validateConfig(c); err == nil {
+		t.Fatalf("expected validation error for oversized chunk")
+	}
+
+	c.GRPC.FileTransferChunkSizeKB = 512
+	c.GRPC.FileTransferCompression = "gzip"
+	if err := validateConfig(c); err == nil {
+		t.Fatalf("expected validation error for unsupported compression")
+	}
+
+	c.GRPC.FileTransferCompression = "zstd"
+	if err := validateConfig(c); err != nil {
+		t.Fatalf("expected nil error, got: %v", err)
+	}
+}
+
+func TestValidateConfig_HTTPTLS(t *testing.T) {
+	c := &configModel{}
+	c.App.Addr = ":8000"
+	c.App.TLS.Enabled = true
+	if err := validateConfig(c); err == nil {
+		t.Fatalf("expected validation error for missing certificate")
+	}
+
+	c.App.TLS.CertFile = "/etc/seatunnelx/tls/server.crt"
+	c.App.TLS.KeyFile = "/etc/seatunnelx/tls/server.key"
+	c.App.TLS.RedirectAddr = ":8000"
+	if err := validateConfig(c); err == nil {
+		t.Fatalf("expected validation error for redirect_addr equal to addr")
+	}
+
+	c.App.TLS.RedirectAddr = ":80"
+	c.App.TLS.HSTSMaxAge = -1
+	if err := validateConfig(c); err == nil {
+		t.Fatalf("expected validation error for negative hsts_max_age")
+	}
+
+	c.App.TLS.HSTSMaxAge = 31536000
+	c.App.TLS.ReloadInterval = 60
+	if err := validateConfig(c); err != nil {
+		t.Fatalf("expected nil error, got: %v", err)
+	}
+}
+
+func TestValidateConfig_ShutdownTimeout(t *testing.T) {
+	c := &configModel{}
+	c.App.ShutdownTimeout = -1
+	if err := validateConfig(c); err == nil {
+		t.Fatalf("expected validation error for negative shutdown_timeout")
+	}
+
+	c.App.ShutdownTimeout = 30
+	if err := validateConfig(c); err != nil {
+		t.Fatalf("expected nil error, got: %v", err)
+	}
+}
+
+func TestValidateConfig_TrustedProxies(t *testing.T) {
+	c := &configModel{}
+	c.App.TrustedProxies = []string{"10.0.0.0/8", "proxy.example.com"}
+	if err := validateConfig(c); err == nil {
+		t.Fatalf("expected validation error for hostname in trusted_proxies")
+	}
+
+	c.App.TrustedProxies = []string{"10.0.0.0/8", "192.168.1.10", "::1"}
+	if err := validateConfig(c); err != nil {
+		t.Fatalf("expected nil error, got: %v", err)
+	}
+}
+
+func TestValidateConfig_CORS(t *testing.T) {
+	c := &configModel{}
+	c.App.CORS.AllowedOrigins = []string{"ops.example.com"}
+	if err := validateConfig(c); err == nil {
+		t.Fatalf("expected validation error for origin without scheme")
+	}
+
+	c.App.CORS.AllowedOrigins = []string{"*"}
+	c.App.CORS.AllowCredentials = true
+	if err := validateConfig(c); err == nil {
+		t.Fatalf("expected validation error for wildcard origin with credentials")
+	}
+
+	c.App.CORS.AllowedOrigins = []string{"https://ops.example.com", "http://127.0.0.1:3000"}
+	if err := validateConfig(c); err != nil {
+		t.Fatalf("expected nil error, got: %v", err)
+	}
+}
+
+func TestValidateConfig_HA(t *testing.T) {
+	c := &configModel{}
+	c.HA.Enabled = true
+	if err := validateConfig(c); err == nil {
+		t.Fatalf("expected validation error for missing advertise address")
+	}
+
+	c.HA.AdvertiseAddr = "10.0.0.1:8000"
+	if err := validateConfig(c); err == nil {
+		t.Fatalf("expected validation error for advertise address without scheme")
+	}
+
+	c.HA.AdvertiseAddr = "http://10.0.0.1:8000"
+	if err := validateConfig(c); err == nil {
+		t.Fatalf("expected validation error for missing shared secret")
+	}
+
+	c.App.SessionSecret = "session"
+	c.HA.SharedSecret = "session"
+	if err := validateConfig(c); err == nil {
+		t.Fatalf("expected validation error for shared secret reusing the session secret")
+	}
+
+	c.HA.SharedSecret = "forward"
+	if err := validateConfig(c); err != nil {
+		t.Fatalf("expected nil error, got: %v", err)
+	}
+}
+
+func TestValidateConfig_Session(t *testing.T) {
+	c := &configModel{}
+	c.App.Session.IdleTimeout = -1
+	if err := validateConfig(c); err == nil {
+		t.Fatalf("expected validation error for negative idle timeout")
+	}
+
+	c.App.Session.IdleTimeout = 1800
+	c.App.Session.MaxPerUser = -1
+	if err := validateConfig(c); err == nil {
+		t.Fatalf("expected validation error for negative session limit")
+	}
+
+	c.App.Session.MaxPerUser = 3
+	if err := validateConfig(c); err != nil {
+		t.Fatalf("expected nil error, got: %v", err)
+	}
+}
+
+func TestValidateConfig_LDAP(t *testing.T) {
+	c := &configModel{}
+	c.Auth.LDAP = LDAPConfig{Enabled: true, URL: "http://ldap.example.com", BaseDN: "dc=example,dc=com"}
+	if err := validateConfig(c); err == nil {
+		t.Fatalf("expected validation error for non-ldap url")
+	}
+
+	c.Auth.LDAP.URL = "ldaps://ldap.example.com:636"
+	c.Auth.LDAP.UserFilter = "(uid=alice)"
+	if err := validateConfig(c); err == nil {
+		t.Fatalf("expected validation error for user filter without placeholder")
+	}
+
+	c.Auth.LDAP.UserFilter = "(sAMAccountName=%s)"
+	c.Auth.LDAP.RoleMappings = []RoleMappingConfig{{Group: "cn=admins,dc=example,dc=com", Role: "root"}}
+	if err := validateConfig(c); err == nil {
+		t.Fatalf("expected validation error for unknown role")
+	}
+
+	c.Auth.LDAP.RoleMappings[0].Role = "admin"
+	if err := validateConfig(c); err != nil {
+		t.Fatalf("expected nil error, got: %v", err)
+	}
+}
+
+func TestValidateConfig_OIDC(t *testing.T) {
+	c := &configModel{}
+	c.OAuthProviders.OIDC = []OIDCProviderConfig{{Name: "github", Enabled: true, Issuer: "https://idp.example.com", ClientID: "x"}}
+	if err := validateConfig(c); err == nil {
+		t.Fatalf("expected validation error for reserved provider name")
+	}
+
+	c.OAuthProviders.OIDC[0].Name = "LDAP"
+	if err := validateConfig(c); err == nil {
+		t.Fatalf("expected validation error for provider name colliding with ldap accounts")
+	}
+
+	c.OAuthProviders.OIDC[0].Name = "keycloak"
+	c.OAuthProviders.OIDC[0].Issuer = ""
+	if err := validateConfig(c); err == nil {
+		t.Fatalf("expected validation error for missing issuer")
+	}
+
+	c.OAuthProviders.OIDC[0].Issuer = "https://idp.example.com/realms/main"
+	c.OAuthProviders.OIDC = append(c.OAuthProviders.OIDC, OIDCProviderConfig{Name: "Keycloak"})
+	if err := validateConfig(c); err == nil {
+		t.Fatalf("expected validation error for duplicated provider name")
+	}
+
+	c.OAuthProviders.OIDC[1].Name = "okta"
+	if err := validateConfig(c); err != nil {
+		t.Fatalf("expected nil error, got: %v", err)
+	}
+}
+
+func TestValidateConfig_Backup(t *testing.T) {
+	c := &configModel{}
+	c.Backup.Storage = "ftp"
+	if err := validateConfig(c); err == nil {
+		t.Fatalf("expected validation error for unknown backup storage")
+	}
+
+	c.Backup.Storage = BackupStorageS3
+	c.Backup.S3.Endpoint = "http://minio:9000"
+	if err := validateConfig(c); err == nil {
+		t.Fatalf("expected validation error for missing bucket")
+	}
+
+	c.Backup.S3.Bucket = "backups"
+	if err := validateConfig(c); err == nil {
+		t.Fatalf("expected validation error for missing credentials")
+	}
+
+	c.Backup.S3.AccessKey = "ak"
+	c.Backup.S3.SecretKey = "sk"
+	if err := validateConfig(c); err != nil {
+		t.Fatalf("expected nil error, got: %v", err)
+	}
+}
+
+func TestValidateConfig_ObjectStore(t *testing.T) {
+	c := &configModel{}
+	c.Storage.ObjectStore.Type = "ftp"
+	if err := validateConfig(c); err == nil {
+		t.Fatalf("expected validation error for unknown object store type")
+	}
+
+	c.Storage.ObjectStore.Type = ObjectStoreLocal
+	if err := validateConfig(c); err == nil {
+		t.Fatalf("expected validation error for missing local_dir")
+	}
+	c.Storage.ObjectStore.LocalDir = "/mnt/seatunnelx"
+	c.Storage.ObjectStore.PresignAgentDownloads = true
+	if err := validateConfig(c); err == nil {
+		t.Fatalf("expected validation error for presigned downloads from a local store")
+	}
+
+	c.Storage.ObjectStore.Type = ObjectStoreOSS
+	c.Storage.ObjectStore.Endpoint = "https://oss-cn-hangzhou.aliyuncs.com"
+	c.Storage.ObjectStore.Bucket = "seatunnelx"
+	if err := validateConfig(c); err == nil {
+		t.Fatalf("expected validation error for missing credentials")
+	}
+
+	c.Storage.ObjectStore.AccessKey = "ak"
+	c.Storage.ObjectStore.SecretKey = "sk"
+	if err := validateConfig(c); err != nil {
+		t.Fatalf("expected nil error, got: %v", err)
+	}
+}
+
+func TestValidateConfig_InstallerHooks(t *testing.T) {
+	c := &configModel{}
+	c.Installer.Hooks = []InstallHookConfig{{Name: "notify", Step: "configure_cluster", Phase: "post", WebhookURL: "https://hooks.example.com/install"}}
+	if err := validateConfig(c); err != nil {
+		t.Fatalf("expected nil error, got: %v", err)
+	}
+
+	c.Installer.Hooks = append(c.Installer.Hooks, InstallHookConfig{Name: "notify", Step: "extract", Phase: "pre", Script: "/opt/hooks/pre.sh"})
+	if err := validateConfig(c); err == nil {
+		t.Fatalf("expected validation error for duplicate hook name")
+	}
+
+	c.Installer.Hooks[1].Name = "prepare"
+	c.Installer.Hooks[1].Script = "hooks/pre.sh"
+	if err := validateConfig(c); err == nil {
+		t.Fatalf("expected validation error for relative script path")
+	}
+
+	c.Installer.Hooks[1].Script = "/opt/hooks/pre.sh"
+	c.Installer.Hooks[1].Phase = "during"
+	if err := validateConfig(c); err == nil {
+		t.Fatalf("expected validation error for unknown phase")
+	}
+
+	c.Installer.Hooks[1].Phase = "pre"
+	c.Installer.Concurrency = InstallerConcurrencyConfig{MaxInstallations: 20, MaxPackageTransfers: 4}
+	if err := validateConfig(c); err != nil {
+		t.Fatalf("expected nil error, got: %v", err)
+	}
+	c.Installer.Concurrency.GlobalMaxPackageTransfers = -1
+	if err := validateConfig(c); err == nil {
+		t.Fatalf("expected validation error for negative concurrency limit")
+	}
+}
+
+func TestValidateConfig_AuditRedaction(t *testing.T) {
+	c := &configModel{}
+	c.Audit.Redaction.SensitiveKeys = []string{"*_token", "jdbc_url"}
+	if err := validateConfig(c); err != nil {
+		t.Fatalf("expected nil error, got: %v", err)
+	}
+
+	c.Audit.Redaction.SensitiveKeys = append(c.Audit.Redaction.SensitiveKeys, "[token")
+	if err := validateConfig(c); err == nil {
+		t.Fatalf("expected validation error for malformed pattern")
+	}
+}
+
+func TestResolveSecretsMasterKey(t *testing.T) {
+	c := &configModel{}
+	c.App.SessionSecret = "session"
+	if _, err := resolveSecretsMasterKey(c); err == nil {
+		t.Fatalf("expected error for missing master key")
+	}
+
+	c.Secrets.MasterKey = "session"
+	if _, err := resolveSecretsMasterKey(c); err == nil {
+		t.Fatalf("expected error for master key reusing the session secret")
+	}
+
+	c.Secrets.MasterKey = "master"
+	if key, err := resolveSecretsMasterKey(c); err != nil || key != "master" {
+		t.Fatalf("expected the dedicated master key, got %q (%v)", key, err)
+	}
+}
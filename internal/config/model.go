@@ -25,7 +25,15 @@ type configModel struct {
 	OAuthProviders OAuthProvidersConfig `mapstructure:"oauth_providers"`
 	Database       DatabaseConfig       `mapstructure:"database"`
 	Storage        StorageConfig        `mapstructure:"storage"`
+	Download       DownloadConfig       `mapstructure:"download"`
 	GRPC           GRPCConfig           `mapstructure:"grpc"`
+	SSHDeploy      SSHDeployConfig      `mapstructure:"ssh_deploy"`
+	Secrets        SecretsConfig        `mapstructure:"secrets"`
+	RecycleBin     RecycleBinConfig     `mapstructure:"recycle_bin"`
+	HA             HAConfig             `mapstructure:"ha"`
+	Backup         BackupConfig         `mapstructure:"backup"`
+	Installer      InstallerConfig      `mapstructure:"installer"`
+	Audit          AuditConfig          `mapstructure:"audit"`
 	Log            logConfig            `mapstructure:"log"`
 	Telemetry      TelemetryConfig      `mapstructure:"telemetry"`
 	Observability  ObservabilityConfig  `mapstructure:"observability"`
@@ -51,8 +59,40 @@ type OAuthProviderConfig struct {
 
 // OAuthProvidersConfig 多 OAuth 提供商配置
 type OAuthProvidersConfig struct {
-	GitHub OAuthProviderConfig `mapstructure:"github"`
-	Google OAuthProviderConfig `mapstructure:"google"`
+	GitHub OAuthProviderConfig  `mapstructure:"github"`
+	Google OAuthProviderConfig  `mapstructure:"google"`
+	OIDC   []OIDCProviderConfig `mapstructure:"oidc"`
+}
+
+// OIDCProviderConfig 通用 OpenID Connect 提供商配置（Keycloak、Okta、Azure AD 等）
+// OIDCProviderConfig configures a generic OpenID Connect provider (Keycloak, Okta, Azure AD, ...)
+type OIDCProviderConfig struct {
+	// Name 提供商标识，用于登录请求的 provider 参数，不能为 github、google、ldap / Provider key used as the login provider parameter; github, google and ldap are reserved
+	Name         string   `mapstructure:"name"`
+	Enabled      bool     `mapstructure:"enabled"`
+	Issuer       string   `mapstructure:"issuer"` // 通过 {issuer}/.well-known/openid-configuration 自动发现端点
+	ClientID     string   `mapstructure:"client_id"`
+	ClientSecret string   `mapstructure:"client_secret"`
+	RedirectURI  string   `mapstructure:"redirect_uri"`
+	Scopes       []string `mapstructure:"scopes"` // 为空时使用 openid profile email
+
+	// 用户信息声明名称 / Userinfo claim names
+	UsernameClaim string `mapstructure:"username_claim"` // 默认 preferred_username
+	EmailClaim    string `mapstructure:"email_claim"`    // 默认 email
+	NameClaim     string `mapstructure:"name_claim"`     // 默认 name
+	GroupsClaim   string `mapstructure:"groups_claim"`   // 默认 groups
+
+	// RoleMappings 将 groups 声明的值映射为全局角色 / Maps groups claim values to global roles
+	RoleMappings []RoleMappingConfig `mapstructure:"role_mappings"`
+	// DefaultRole 未匹配任何映射时的角色，为空时新用户使用系统默认角色且不修改已有用户
+	DefaultRole string `mapstructure:"default_role"`
+}
+
+// RoleMappingConfig 外部组到全局角色的映射
+// RoleMappingConfig maps an external group (LDAP group DN or OIDC claim value) to a global role
+type RoleMappingConfig struct {
+	Group string `mapstructure:"group"` // 组 DN 或声明值，不区分大小写
+	Role  string `mapstructure:"role"`  // admin / operator / viewer
 }
 
 // AppConfig 应用基本配置（导出供其他包使用）
@@ -76,6 +116,149 @@ type AppConfig struct {
 	// Example: "http://192.168.1.100:8000" or "https://seatunnel.example.com"
 	// 示例: "http://192.168.1.100:8000" 或 "https://seatunnel.example.com"
 	ExternalURL string `mapstructure:"external_url"`
+
+	// ShutdownTimeout is how long shutdown waits for in-flight HTTP requests such as package
+	// uploads and downloads (seconds, default: 30).
+	// ShutdownTimeout 是关闭时等待在途 HTTP 请求（如安装包上传与下载）完成的时长（秒，默认：30）。
+	ShutdownTimeout int `mapstructure:"shutdown_timeout"`
+
+	// TLS configures native HTTPS for the HTTP API server.
+	// TLS 配置 HTTP API 服务器的原生 HTTPS。
+	TLS HTTPTLSConfig `mapstructure:"tls"`
+
+	// CORS configures cross-origin access to the HTTP API.
+	// CORS 配置 HTTP API 的跨域访问。
+	CORS CORSConfig `mapstructure:"cors"`
+
+	// CSRF configures double-submit CSRF protection for session-cookie requests.
+	// CSRF 配置基于会话 Cookie 请求的双重提交 CSRF 防护。
+	CSRF CSRFConfig `mapstructure:"csrf"`
+
+	// RateLimit limits sensitive HTTP endpoints per client IP and per user.
+	// RateLimit 按客户端 IP 与用户对敏感 HTTP 接口限流。
+	RateLimit RateLimitConfig `mapstructure:"rate_limit"`
+
+	// TrustedProxies lists the reverse proxy IPs or CIDRs whose X-Forwarded-For / X-Real-IP headers
+	// are honored for the client IP. Empty trusts no proxy and uses the connection peer address.
+	// TrustedProxies 列出可信反向代理的 IP 或 CIDR，仅信任它们提供的 X-Forwarded-For / X-Real-IP 作为客户端 IP；
+	// 为空时不信任任何代理，直接使用连接对端地址。
+	TrustedProxies []string `mapstructure:"trusted_proxies"`
+
+	// Session configures server-side tracking of login sessions.
+	// Session 配置登录会话的服务端跟踪。
+	Session SessionConfig `mapstructure:"session"`
+}
+
+// SessionConfig holds the expiration, concurrency and binding rules of login sessions.
+// session_age stays the absolute lifetime of a session.
+// SessionConfig 保存登录会话的过期、并发与绑定规则，session_age 仍是会话的绝对有效期。
+type SessionConfig struct {
+	// IdleTimeout is the sliding expiration in seconds: a session unused for this long expires (0 disables it).
+	// IdleTimeout 是滑动过期时间（秒）：超过该时长未使用的会话过期（0 表示不启用）。
+	IdleTimeout int `mapstructure:"idle_timeout"`
+
+	// MaxPerUser caps the concurrent sessions of one user; the least recently used ones are logged out (0 = unlimited).
+	// MaxPerUser 限制单个用户的并发会话数，超出时登出最久未使用的会话（0 表示不限制）。
+	MaxPerUser int `mapstructure:"max_per_user"`
+
+	// BindIP and BindUserAgent reject a session used from another client IP or user agent than the one that logged in.
+	// BindIP 与 BindUserAgent 拒绝从不同于登录时的客户端 IP 或 User-Agent 使用会话。
+	BindIP        bool `mapstructure:"bind_ip"`
+	BindUserAgent bool `mapstructure:"bind_user_agent"`
+}
+
+// RateLimitConfig holds the limits of each group of sensitive endpoints.
+// RateLimitConfig 保存各组敏感接口的限流配置。
+type RateLimitConfig struct {
+	// Login covers password login (default: 10 per IP per minute).
+	// Login 作用于密码登录（默认：每个 IP 每分钟 10 次）。
+	Login RateLimitRule `mapstructure:"login"`
+
+	// PackageUpload covers package, package chunk and custom plugin uploads (default: 600 per IP, 300 per user).
+	// PackageUpload 作用于安装包、分片及自定义插件上传（默认：每个 IP 600 次、每个用户 300 次）。
+	PackageUpload RateLimitRule `mapstructure:"package_upload"`
+
+	// Installation covers starting and retrying installations (default: 30 per IP, 20 per user).
+	// Installation 作用于启动与重试安装（默认：每个 IP 30 次、每个用户 20 次）。
+	Installation RateLimitRule `mapstructure:"installation"`
+
+	// Command covers dispatching commands to Agents (default: 60 per IP, 30 per user).
+	// Command 作用于向 Agent 下发命令（默认：每个 IP 60 次、每个用户 30 次）。
+	Command RateLimitRule `mapstructure:"command"`
+}
+
+// RateLimitRule is a pair of per-minute limits; 0 uses the default and -1 disables the limit.
+// RateLimitRule 是一组每分钟限额；0 表示使用默认值，-1 表示不限流。
+type RateLimitRule struct {
+	PerIP   int `mapstructure:"per_ip"`
+	PerUser int `mapstructure:"per_user"`
+}
+
+// CORSConfig holds the cross-origin policy of the HTTP API. No allowed origins disables CORS.
+// CORSConfig 保存 HTTP API 的跨域策略。未配置允许的来源时不启用 CORS。
+type CORSConfig struct {
+	// AllowedOrigins lists origins such as "https://ops.example.com"; "*" allows any origin.
+	// AllowedOrigins 列出允许的来源，例如 "https://ops.example.com"；"*" 表示允许任意来源。
+	AllowedOrigins []string `mapstructure:"allowed_origins"`
+
+	// AllowedMethods and AllowedHeaders are answered to preflight requests.
+	// AllowedMethods 与 AllowedHeaders 用于应答预检请求。
+	AllowedMethods []string `mapstructure:"allowed_methods"`
+	AllowedHeaders []string `mapstructure:"allowed_headers"`
+
+	// AllowCredentials lets browsers send cookies cross-origin; it cannot be combined with "*".
+	// AllowCredentials 允许浏览器跨域携带 Cookie，不能与 "*" 同时使用。
+	AllowCredentials bool `mapstructure:"allow_credentials"`
+
+	// MaxAge is how long, in seconds, browsers may cache a preflight response.
+	// MaxAge 是浏览器缓存预检响应的时长（秒）。
+	MaxAge int `mapstructure:"max_age"`
+}
+
+// CSRFConfig holds the double-submit CSRF token settings.
+// CSRFConfig 保存双重提交 CSRF 令牌配置。
+type CSRFConfig struct {
+	// Enabled requires state-changing requests carrying the session cookie to echo the CSRF cookie in a header.
+	// Enabled 要求携带会话 Cookie 的写请求在请求头中回传 CSRF Cookie。
+	Enabled bool `mapstructure:"enabled"`
+
+	// CookieName and HeaderName name the token cookie and the header it is echoed in.
+	// CookieName 与 HeaderName 分别是令牌 Cookie 名称与回传令牌的请求头名称。
+	CookieName string `mapstructure:"cookie_name"`
+	HeaderName string `mapstructure:"header_name"`
+
+	// ExemptPaths are request path prefixes that skip the check, such as webhook receivers.
+	// ExemptPaths 是跳过校验的请求路径前缀，例如 Webhook 接收端点。
+	ExemptPaths []string `mapstructure:"exempt_paths"`
+}
+
+// HTTPTLSConfig holds HTTPS settings of the HTTP API server.
+// HTTPTLSConfig 保存 HTTP API 服务器的 HTTPS 配置。
+type HTTPTLSConfig struct {
+	// Enabled serves the API over HTTPS on app.addr and forces secure session cookies.
+	// Enabled 在 app.addr 上以 HTTPS 提供 API，并强制使用 Secure 会话 Cookie。
+	Enabled bool `mapstructure:"enabled"`
+
+	// CertFile and KeyFile are the PEM certificate chain and private key.
+	// CertFile 与 KeyFile 是 PEM 格式的证书链与私钥。
+	CertFile string `mapstructure:"cert_file"`
+	KeyFile  string `mapstructure:"key_file"`
+
+	// ReloadInterval is how often, in seconds, the files are checked for rotation (0 disables reloading).
+	// ReloadInterval 是检查证书轮换的间隔（秒，0 表示不自动重新加载）。
+	ReloadInterval int `mapstructure:"reload_interval"`
+
+	// RedirectAddr is an optional plain HTTP listen address that redirects every request to HTTPS.
+	// RedirectAddr 是可选的 HTTP 监听地址，所有请求都会被重定向到 HTTPS。
+	RedirectAddr string `mapstructure:"redirect_addr"`
+
+	// HSTSMaxAge is the Strict-Transport-Security max-age in seconds (0 disables the header).
+	// HSTSMaxAge 是 Strict-Transport-Security 的 max-age（秒，0 表示不发送该响应头）。
+	HSTSMaxAge int `mapstructure:"hsts_max_age"`
+
+	// HSTSIncludeSubdomains adds includeSubDomains to the HSTS header.
+	// HSTSIncludeSubdomains 为 HSTS 响应头添加 includeSubDomains。
+	HSTSIncludeSubdomains bool `mapstructure:"hsts_include_subdomains"`
 }
 
 // SyncConfig 同步工作台相关配置。
@@ -91,9 +274,42 @@ type SyncConfig struct {
 
 // authConfig 认证配置
 type authConfig struct {
-	DefaultAdminUsername string `mapstructure:"default_admin_username"`
-	DefaultAdminPassword string `mapstructure:"default_admin_password"`
-	BcryptCost           int    `mapstructure:"bcrypt_cost"`
+	DefaultAdminUsername string     `mapstructure:"default_admin_username"`
+	DefaultAdminPassword string     `mapstructure:"default_admin_password"`
+	BcryptCost           int        `mapstructure:"bcrypt_cost"`
+	LDAP                 LDAPConfig `mapstructure:"ldap"`
+}
+
+// LDAPConfig LDAP / Active Directory 认证配置
+// LDAPConfig configures LDAP / Active Directory bind authentication
+type LDAPConfig struct {
+	Enabled            bool   `mapstructure:"enabled"`
+	URL                string `mapstructure:"url"`       // ldap://host:389 或 ldaps://host:636
+	StartTLS           bool   `mapstructure:"start_tls"` // ldap:// 连接发送凭证前升级为 TLS
+	InsecureSkipVerify bool   `mapstructure:"insecure_skip_verify"`
+	CAFile             string `mapstructure:"ca_file"`
+	TimeoutSeconds     int    `mapstructure:"timeout_seconds"`
+
+	// 用于搜索用户的服务账号，为空时匿名搜索 / Service account used to search users, anonymous when empty
+	BindDN       string `mapstructure:"bind_dn"`
+	BindPassword string `mapstructure:"bind_password"`
+
+	BaseDN string `mapstructure:"base_dn"`
+	// UserFilter 用户搜索过滤器，%s 替换为转义后的登录名；AD 可使用 (sAMAccountName=%s)
+	UserFilter        string `mapstructure:"user_filter"`
+	UsernameAttribute string `mapstructure:"username_attribute"` // 默认 uid
+	EmailAttribute    string `mapstructure:"email_attribute"`    // 默认 mail
+	NameAttribute     string `mapstructure:"name_attribute"`     // 默认 cn
+	GroupAttribute    string `mapstructure:"group_attribute"`    // 用户条目上的组属性，默认 memberOf
+
+	// 可选的组搜索（目录未提供 memberOf 时使用），%s 替换为转义后的用户 DN
+	GroupBaseDN string `mapstructure:"group_base_dn"`
+	GroupFilter string `mapstructure:"group_filter"`
+
+	// RoleMappings 将组 DN 映射为全局角色 / Maps group DNs to global roles
+	RoleMappings []RoleMappingConfig `mapstructure:"role_mappings"`
+	// DefaultRole 未匹配任何映射时的角色，为空时新用户使用系统默认角色且不修改已有用户
+	DefaultRole string `mapstructure:"default_role"`
 }
 
 // DatabaseConfig 数据库配置（导出供其他包使用）
@@ -110,8 +326,22 @@ type DatabaseConfig struct {
 	MaxOpenConn     int    `mapstructure:"max_open_conn"`
 	ConnMaxLifetime int    `mapstructure:"conn_max_lifetime"`
 	LogLevel        string `mapstructure:"log_level"`
+	// MigrationMode is "auto" to apply pending schema migrations at startup, or "check" to refuse
+	// to start until they are applied with the migrate command.
+	// MigrationMode 为 "auto" 时启动时自动执行待执行的 schema 迁移；为 "check" 时若存在待执行迁移则拒绝启动，
+	// 需先通过 migrate 命令执行。
+	MigrationMode string `mapstructure:"migration_mode"`
 }
 
+const (
+	// MigrationModeAuto applies pending migrations at startup.
+	// MigrationModeAuto 启动时自动执行待执行的迁移。
+	MigrationModeAuto = "auto"
+	// MigrationModeCheck only verifies the schema version at startup.
+	// MigrationModeCheck 启动时仅校验 schema 版本。
+	MigrationModeCheck = "check"
+)
+
 // GRPCConfig gRPC 服务器配置
 // GRPCConfig holds configuration for the gRPC server
 type GRPCConfig struct {
@@ -154,6 +384,250 @@ type GRPCConfig struct {
 	// HeartbeatTimeout is the timeout for considering an Agent offline (seconds, default: 30)
 	// HeartbeatTimeout 是判断 Agent 离线的超时时间（秒，默认：30）
 	HeartbeatTimeout int `mapstructure:"heartbeat_timeout"`
+
+	// CommandQueueTTL is how long commands wait for a reconnecting Agent (seconds, default: 60)
+	// CommandQueueTTL 是命令等待 Agent 重连的最长时间（秒，默认：60）
+	CommandQueueTTL int `mapstructure:"command_queue_ttl"`
+
+	// CommandProgressTimeout is how long a running command may go without progress before it times out (seconds, default: 180, negative disables)
+	// CommandProgressTimeout 是执行中的命令无进度多久后判定超时（秒，默认：180，负数表示禁用）
+	CommandProgressTimeout int `mapstructure:"command_progress_timeout"`
+
+	// FileTransferChunkSizeKB is the chunk size for streaming package/plugin transfers (KB, 64-2048, default: 1024)
+	// FileTransferChunkSizeKB 是流式传输安装包/插件的数据块大小（KB，64-2048，默认：1024）
+	FileTransferChunkSizeKB int `mapstructure:"file_transfer_chunk_size_kb"`
+
+	// FileTransferCompression is the chunk compression for streaming transfers: zstd or none (default: zstd)
+	// FileTransferCompression 是流式传输的数据块压缩算法：zstd 或 none（默认：zstd）
+	FileTransferCompression string `mapstructure:"file_transfer_compression"`
+
+	// DrainTimeout is how long shutdown waits for in-flight Agent commands (seconds, default: 30)
+	// DrainTimeout 是关闭时等待 Agent 在途命令完成的时长（秒，默认：30）
+	DrainTimeout int `mapstructure:"drain_timeout"`
+
+	// ReconnectDelay is the minimum delay hinted to Agents before reconnecting after shutdown (seconds, default: 5)
+	// ReconnectDelay 是关闭后提示 Agent 重连前的最小延迟（秒，默认：5）
+	ReconnectDelay int `mapstructure:"reconnect_delay"`
+
+	// ReconnectJitter is the random window added to each Agent's reconnect delay (seconds, default: 30)
+	// ReconnectJitter 是叠加到每个 Agent 重连延迟上的随机窗口（秒，默认：30）
+	ReconnectJitter int `mapstructure:"reconnect_jitter"`
+
+	// RegistrationRateLimit is the number of Agent registrations allowed per IP per minute (default: 30, -1 disables)
+	// RegistrationRateLimit 是每个 IP 每分钟允许的 Agent 注册次数（默认：30，-1 表示不限流）
+	RegistrationRateLimit int `mapstructure:"registration_rate_limit"`
+
+	// RequireAgentApproval keeps newly registered Agents pending until an admin approves them (default: false)
+	// RequireAgentApproval 使新注册的 Agent 保持待审批状态，直到管理员审批通过（默认：false）
+	RequireAgentApproval bool `mapstructure:"require_agent_approval"`
+
+	// EnrollmentTokenTTL is how long a generated Agent install command stays usable (minutes, default: 60)
+	// EnrollmentTokenTTL 是生成的 Agent 安装命令的有效期（分钟，默认：60）
+	EnrollmentTokenTTL int `mapstructure:"enrollment_token_ttl"`
+
+	// AllowTokenlessRegistration lets unknown Agents register without an enrollment token (default: false).
+	// Tokenless Agents are also accepted when RequireAgentApproval is on, since an admin approves them.
+	// AllowTokenlessRegistration 允许未知 Agent 不带注册令牌注册（默认：false）。
+	// 开启 RequireAgentApproval 时也接受无令牌的 Agent，因为需由管理员审批。
+	AllowTokenlessRegistration bool `mapstructure:"allow_tokenless_registration"`
+}
+
+// SSHDeployConfig SSH 远程部署 Agent 配置
+// SSHDeployConfig configures Agent bootstrap over SSH from the Control Plane
+type SSHDeployConfig struct {
+	// Enabled 是否启用 SSH 部署 Agent 功能
+	// Enabled turns on the SSH deployer API
+	Enabled bool `mapstructure:"enabled"`
+
+	// CredentialSecret 早期版本加密 SSH 凭证的密钥，为空时使用 app.session_secret；仅用于迁移到 secrets.master_key
+	// CredentialSecret is the key earlier releases encrypted SSH credentials with, falling back to
+	// app.session_secret; it is only read to re-encrypt them with secrets.master_key
+	CredentialSecret string `mapstructure:"credential_secret"`
+
+	// TimeoutMinutes 单次部署超时时间（分钟），默认 15
+	// TimeoutMinutes is the timeout of one deployment in minutes (default: 15)
+	TimeoutMinutes int `mapstructure:"timeout_minutes"`
+}
+
+// SecretsConfig 敏感配置（存储凭证、Kerberos keytab 路径等）的静态加密配置
+// SecretsConfig configures at-rest encryption of credentials such as storage keys and keytab paths
+type SecretsConfig struct {
+	// MasterKey 派生 AES-256-GCM 数据密钥的主密钥，必填且不能与 app.session_secret 相同
+	// MasterKey derives the AES-256-GCM data key; required and must differ from app.session_secret
+	MasterKey string `mapstructure:"master_key"`
+}
+
+// RecycleBinConfig 回收站配置（已删除主机与集群的保留期与清理周期）
+// RecycleBinConfig configures how long deleted hosts and clusters are kept before being purged
+type RecycleBinConfig struct {
+	// RetentionHours 删除后保留的小时数，超过后由清理任务执行真正的拆除，默认 168（7 天）
+	// RetentionHours is how long a deleted resource can be restored before it is torn down
+	RetentionHours int `mapstructure:"retention_hours"`
+
+	// PurgeIntervalMinutes 清理任务的执行间隔（分钟），默认 30
+	// PurgeIntervalMinutes is the interval of the purge job
+	PurgeIntervalMinutes int `mapstructure:"purge_interval_minutes"`
+}
+
+// HAConfig 控制面高可用配置（多副本共享数据库，部署在负载均衡之后）
+// HAConfig configures running several Control Plane replicas on one database behind a load balancer
+type HAConfig struct {
+	// Enabled 是否启用多副本模式
+	// Enabled turns on Agent ownership sharing, command forwarding and leader election
+	Enabled bool `mapstructure:"enabled"`
+
+	// InstanceID 副本标识，为空时自动生成 hostname-随机串
+	// InstanceID identifies the replica, generated from the hostname when empty
+	InstanceID string `mapstructure:"instance_id"`
+
+	// AdvertiseAddr 其他副本访问本副本 HTTP API 的地址，例如 http://10.0.0.1:8000；跨越不受信任的网络时应使用 https
+	// AdvertiseAddr is the base URL peers use to reach this replica's HTTP API; use https across untrusted networks
+	AdvertiseAddr string `mapstructure:"advertise_addr"`
+
+	// SharedSecret 副本之间转发命令的认证与加密密钥，开启 HA 时必填且不能与 app.session_secret 相同
+	// SharedSecret authenticates and seals forwarded commands; required with HA and must differ from app.session_secret
+	SharedSecret string `mapstructure:"shared_secret"`
+
+	// LeaseTTLSeconds 副本存活与 leader 租约时长（秒），默认 15
+	// LeaseTTLSeconds is the liveness and leader lease duration in seconds (default: 15)
+	LeaseTTLSeconds int `mapstructure:"lease_ttl_seconds"`
+}
+
+// BackupConfig 集群备份配置（备份归档的存储位置）
+// BackupConfig configures where cluster backup archives are stored
+type BackupConfig struct {
+	// Storage 备份存储类型：local 或 s3（兼容 MinIO、阿里云 OSS 等 S3 协议存储），默认 local
+	// Storage is the archive backend: local or s3 (S3-compatible stores such as MinIO and Aliyun OSS)
+	Storage string `mapstructure:"storage"`
+
+	// LocalDir 本地备份目录，默认 ./data/backups
+	// LocalDir is the directory of local archives (default: ./data/backups)
+	LocalDir string `mapstructure:"local_dir"`
+
+	// S3 对象存储配置，storage=s3 时生效
+	// S3 configures the object store used when storage=s3
+	S3 BackupS3Config `mapstructure:"s3"`
+}
+
+const (
+	// BackupStorageLocal stores backup archives on the Control Plane's disk.
+	// BackupStorageLocal 将备份归档保存在控制面本地磁盘。
+	BackupStorageLocal = "local"
+	// BackupStorageS3 stores backup archives in an S3-compatible object store.
+	// BackupStorageS3 将备份归档保存在 S3 兼容对象存储中。
+	BackupStorageS3 = "s3"
+)
+
+// BackupS3Config S3 兼容对象存储配置
+// BackupS3Config configures an S3-compatible object store
+type BackupS3Config struct {
+	// Endpoint 对象存储地址，例如 https://oss-cn-hangzhou.aliyuncs.com 或 http://minio:9000
+	// Endpoint is the object store URL, the scheme selects TLS
+	Endpoint string `mapstructure:"endpoint"`
+
+	// Region 区域，可为空
+	// Region is the bucket region, optional
+	Region string `mapstructure:"region"`
+
+	// Bucket 存储桶名称
+	// Bucket is the bucket holding the archives
+	Bucket string `mapstructure:"bucket"`
+
+	// Prefix 对象键前缀，默认 seatunnelx-backups
+	// Prefix is prepended to every object key (default: seatunnelx-backups)
+	Prefix string `mapstructure:"prefix"`
+
+	// AccessKey 访问密钥 ID
+	// AccessKey is the access key ID
+	AccessKey string `mapstructure:"access_key"`
+
+	// SecretKey 访问密钥
+	// SecretKey is the secret access key
+	SecretKey string `mapstructure:"secret_key"`
+
+	// VirtualHostStyle 使用虚拟主机风格访问存储桶（阿里云 OSS 需要开启）
+	// VirtualHostStyle addresses the bucket as a subdomain, required by Aliyun OSS
+	VirtualHostStyle bool `mapstructure:"virtual_host_style"`
+}
+
+// InstallerConfig 安装流程配置
+// InstallerConfig configures the installation flow
+type InstallerConfig struct {
+	// Hooks 在安装步骤前后执行的自定义钩子
+	// Hooks run before or after installation steps
+	Hooks []InstallHookConfig `mapstructure:"hooks"`
+
+	// Concurrency 安装与安装包传输的并发上限，超出的请求排队等待
+	// Concurrency bounds concurrent installations and package transfers; excess requests wait in a queue
+	Concurrency InstallerConcurrencyConfig `mapstructure:"concurrency"`
+}
+
+// InstallerConcurrencyConfig 安装并发上限，0 表示不限制
+// InstallerConcurrencyConfig holds installation concurrency limits; 0 means unlimited
+type InstallerConcurrencyConfig struct {
+	// MaxInstallations 每个控制面副本同时运行的安装数量上限
+	// MaxInstallations bounds the installations running at once on each Control Plane replica
+	MaxInstallations int `mapstructure:"max_installations"`
+
+	// MaxPackageTransfers 每个控制面副本同时向 Agent 传输安装包的数量上限
+	// MaxPackageTransfers bounds the packages sent to Agents at once by each Control Plane replica
+	MaxPackageTransfers int `mapstructure:"max_package_transfers"`
+
+	// GlobalMaxInstallations 所有副本合计同时运行的安装数量上限（需启用 ha）
+	// GlobalMaxInstallations bounds the installations running at once across all replicas (requires ha)
+	GlobalMaxInstallations int `mapstructure:"global_max_installations"`
+
+	// GlobalMaxPackageTransfers 所有副本合计同时传输安装包的数量上限（需启用 ha）
+	// GlobalMaxPackageTransfers bounds the package transfers running at once across all replicas (requires ha)
+	GlobalMaxPackageTransfers int `mapstructure:"global_max_package_transfers"`
+}
+
+// InstallHookConfig 单个安装步骤钩子配置，script 与 webhook_url 二选一
+// InstallHookConfig configures one step hook; exactly one of script and webhook_url is set
+type InstallHookConfig struct {
+	// Name 钩子名称，需唯一
+	// Name identifies the hook and must be unique
+	Name string `mapstructure:"name"`
+
+	// Step 挂载的安装步骤，例如 configure_cluster
+	// Step is the installation step the hook is attached to, e.g. configure_cluster
+	Step string `mapstructure:"step"`
+
+	// Phase 执行时机：pre 或 post
+	// Phase is pre or post
+	Phase string `mapstructure:"phase"`
+
+	// Script 目标主机上已存在的可执行脚本绝对路径
+	// Script is the absolute path of an executable already present on the target host
+	Script string `mapstructure:"script"`
+
+	// WebhookURL 接收步骤上下文 POST 请求的地址
+	// WebhookURL receives a POST with the step context
+	WebhookURL string `mapstructure:"webhook_url"`
+
+	// TimeoutSeconds 超时时间（秒），默认 60
+	// TimeoutSeconds bounds the hook run (default: 60)
+	TimeoutSeconds int `mapstructure:"timeout_seconds"`
+
+	// FailOnError 钩子失败时使安装失败，默认仅记录
+	// FailOnError fails the installation when the hook fails; by default failures are only recorded
+	FailOnError bool `mapstructure:"fail_on_error"`
+}
+
+// AuditConfig 审计配置
+// AuditConfig configures audit and command logs
+type AuditConfig struct {
+	// Redaction 命令参数脱敏策略
+	// Redaction controls how command parameters are masked
+	Redaction AuditRedactionConfig `mapstructure:"redaction"`
+}
+
+// AuditRedactionConfig 命令参数脱敏配置
+// AuditRedactionConfig lists additional sensitive command parameter keys
+type AuditRedactionConfig struct {
+	// SensitiveKeys 需要屏蔽的参数键，不区分大小写，支持 * ? [] 通配符；内置的密码与密钥键始终屏蔽
+	// SensitiveKeys are case-insensitive key patterns with * ? [] wildcards; built-in password and key names are always masked
+	SensitiveKeys []string `mapstructure:"sensitive_keys"`
 }
 
 // StorageConfig 存储配置（本地文件存储目录）
@@ -181,6 +655,141 @@ type StorageConfig struct {
 	// CleanupIntervalHours 临时文件清理间隔（小时），默认 24
 	// CleanupIntervalHours is the interval for cleaning up temp files
 	CleanupIntervalHours int `mapstructure:"cleanup_interval_hours"`
+
+	// VerifyPluginSignatures 是否校验 Apache SeaTunnel 插件 jar 的 GPG 签名
+	// VerifyPluginSignatures enables GPG signature checks of Apache SeaTunnel plugin jars
+	VerifyPluginSignatures bool `mapstructure:"verify_plugin_signatures"`
+
+	// PluginKeysURL 用于签名校验的 KEYS 文件地址
+	// PluginKeysURL is the KEYS file used for plugin signature checks
+	PluginKeysURL string `mapstructure:"plugin_keys_url"`
+
+	// PluginIndexURL 离线插件索引的刷新地址
+	// PluginIndexURL is where the offline plugin index is refreshed from
+	PluginIndexURL string `mapstructure:"plugin_index_url"`
+
+	// SkipPackageVerification 是否跳过 SeaTunnel 安装包官方 SHA-512 校验（不推荐）
+	// SkipPackageVerification allows transferring packages without a verified official SHA-512 (not recommended)
+	SkipPackageVerification bool `mapstructure:"skip_package_verification"`
+
+	// ObjectStore 共享存储后端，多副本部署时安装包、插件与诊断包在此同步
+	// ObjectStore is the shared backend through which replicas exchange packages, plugins and diagnostic bundles
+	ObjectStore ObjectStoreConfig `mapstructure:"object_store"`
+}
+
+// ObjectStoreConfig 共享对象存储配置
+// ObjectStoreConfig configures the object store shared by all Control Plane replicas
+type ObjectStoreConfig struct {
+	// Type 存储类型：留空不启用，local（共享挂载目录）、s3、oss 或 minio
+	// Type is the backend: empty disables it, local (a shared mounted directory), s3, oss or minio
+	Type string `mapstructure:"type"`
+
+	// LocalDir type=local 时的共享目录，例如 NFS 挂载点
+	// LocalDir is the shared directory used when type=local, for example an NFS mount
+	LocalDir string `mapstructure:"local_dir"`
+
+	// Endpoint 对象存储地址，例如 https://s3.us-east-1.amazonaws.com、https://oss-cn-hangzhou.aliyuncs.com 或 http://minio:9000
+	// Endpoint is the object store URL, the scheme selects TLS
+	Endpoint string `mapstructure:"endpoint"`
+
+	// Region 区域；生成预签名地址时需要配置，否则会额外查询存储桶区域
+	// Region is the bucket region; set it when presigning so no bucket location lookup is needed
+	Region string `mapstructure:"region"`
+
+	// Bucket 存储桶名称
+	// Bucket is the bucket holding the objects
+	Bucket string `mapstructure:"bucket"`
+
+	// Prefix 对象键前缀，默认 seatunnelx
+	// Prefix is prepended to every object key (default: seatunnelx)
+	Prefix string `mapstructure:"prefix"`
+
+	// AccessKey 访问密钥 ID
+	// AccessKey is the access key ID
+	AccessKey string `mapstructure:"access_key"`
+
+	// SecretKey 访问密钥
+	// SecretKey is the secret access key
+	SecretKey string `mapstructure:"secret_key"`
+
+	// VirtualHostStyle type=s3 时使用虚拟主机风格访问存储桶（oss 始终使用，minio 始终使用路径风格）
+	// VirtualHostStyle addresses the bucket as a subdomain for type=s3 (always on for oss, always off for minio)
+	VirtualHostStyle bool `mapstructure:"virtual_host_style"`
+
+	// PresignAgentDownloads 让 Agent 通过预签名地址直接从对象存储下载安装包，而不经控制面中转
+	// PresignAgentDownloads lets Agents pull packages straight from the object store through presigned URLs
+	PresignAgentDownloads bool `mapstructure:"presign_agent_downloads"`
+
+	// PresignExpiryMinutes 预签名地址有效期（分钟），默认 30
+	// PresignExpiryMinutes is how long a presigned URL stays valid, in minutes (default: 30)
+	PresignExpiryMinutes int `mapstructure:"presign_expiry_minutes"`
+}
+
+const (
+	// ObjectStoreLocal shares a directory mounted on every replica.
+	// ObjectStoreLocal 使用各副本都挂载的共享目录。
+	ObjectStoreLocal = "local"
+	// ObjectStoreS3 uses AWS S3 or another S3-compatible service.
+	// ObjectStoreS3 使用 AWS S3 或其他 S3 兼容服务。
+	ObjectStoreS3 = "s3"
+	// ObjectStoreOSS uses Aliyun OSS through its S3-compatible API.
+	// ObjectStoreOSS 通过 S3 兼容接口使用阿里云 OSS。
+	ObjectStoreOSS = "oss"
+	// ObjectStoreMinIO uses a MinIO deployment.
+	// ObjectStoreMinIO 使用 MinIO。
+	ObjectStoreMinIO = "minio"
+)
+
+// DownloadConfig 外部下载配置（自定义镜像与代理）
+// DownloadConfig configures outbound downloads (custom mirrors and proxy)
+type DownloadConfig struct {
+	// Mirrors 自定义/私有镜像，如企业内部 Nexus、Artifactory
+	// Mirrors are custom/private mirrors such as internal Nexus or Artifactory
+	Mirrors []DownloadMirrorConfig `mapstructure:"mirrors"`
+
+	// Proxy 所有安装包和插件下载使用的 HTTP(S) 代理
+	// Proxy is the HTTP(S) proxy used by all package and plugin downloads
+	Proxy DownloadProxyConfig `mapstructure:"proxy"`
+}
+
+// DownloadMirrorConfig 自定义镜像配置
+// DownloadMirrorConfig describes a custom mirror
+type DownloadMirrorConfig struct {
+	// Name 镜像标识，用作安装和插件请求中的 mirror 值
+	// Name identifies the mirror and is used as the mirror value in install and plugin requests
+	Name string `mapstructure:"name"`
+
+	// PackageBaseURL SeaTunnel 安装包基础 URL（<base>/<version>/apache-seatunnel-<version>-bin.tar.gz）
+	// PackageBaseURL is the SeaTunnel package base URL (<base>/<version>/apache-seatunnel-<version>-bin.tar.gz)
+	PackageBaseURL string `mapstructure:"package_base_url"`
+
+	// MavenBaseURL 连接器 Maven 仓库基础 URL
+	// MavenBaseURL is the Maven repository base URL for connectors
+	MavenBaseURL string `mapstructure:"maven_base_url"`
+
+	// Username 可选的 Basic 认证用户名
+	// Username is the optional basic auth username
+	Username string `mapstructure:"username"`
+
+	// Password 可选的 Basic 认证密码
+	// Password is the optional basic auth password
+	Password string `mapstructure:"password"`
+}
+
+// DownloadProxyConfig 下载代理配置，留空时使用 HTTP_PROXY/HTTPS_PROXY 环境变量
+// DownloadProxyConfig configures the download proxy; falls back to HTTP_PROXY/HTTPS_PROXY when empty
+type DownloadProxyConfig struct {
+	// HTTPProxy 用于 http:// 请求的代理
+	// HTTPProxy is the proxy for http:// requests
+	HTTPProxy string `mapstructure:"http_proxy"`
+
+	// HTTPSProxy 用于 https:// 请求的代理
+	// HTTPSProxy is the proxy for https:// requests
+	HTTPSProxy string `mapstructure:"https_proxy"`
+
+	// NoProxy 逗号分隔的直连主机列表
+	// NoProxy is a comma-separated list of hosts to reach directly
+	NoProxy string `mapstructure:"no_proxy"`
 }
 
 // logConfig 日志配置
@@ -205,6 +814,15 @@ type TelemetryConfig struct {
 	// Endpoint is the OTLP collector endpoint (default: localhost:4317)
 	// Endpoint 是 OTLP 收集器端点（默认：localhost:4317）
 	Endpoint string `mapstructure:"endpoint"`
+
+	// AgentEndpoint is the collector address as reachable from Agent hosts, written into
+	// generated Agent configs; empty falls back to Endpoint
+	// AgentEndpoint 是 Agent 主机可访问的收集器地址，写入生成的 Agent 配置；为空时使用 Endpoint
+	AgentEndpoint string `mapstructure:"agent_endpoint"`
+
+	// AgentInsecure makes Agents connect to the collector without TLS
+	// AgentInsecure 表示 Agent 连接收集器时不使用 TLS
+	AgentInsecure bool `mapstructure:"agent_insecure"`
 }
 
 // ObservabilityConfig 可观测性配置（Prometheus/Grafana/Alertmanager 集成）
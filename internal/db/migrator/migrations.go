@@ -0,0 +1,229 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package migrator
+
+import (
+	"github.com/seatunnel/seatunnelX/internal/apps/host/sshdeploy"
+	"github.com/seatunnel/seatunnelX/internal/config"
+	"github.com/seatunnel/seatunnelX/internal/pkg/secrets"
+	"gorm.io/gorm"
+)
+
+// Migrations returns every schema migration in apply order.
+// Schema changes are shipped by appending a new migration; released migrations must never be edited.
+// Migrations work on the snapshot types of snapshot_0001.go and snapshots.go, never on live models.
+// Migrations 按执行顺序返回所有 schema 迁移。
+// schema 变更通过追加新迁移发布；已发布的迁移不得修改。
+// 迁移只使用 snapshot_0001.go 与 snapshots.go 中的快照类型，不引用业务模型。
+func Migrations() []Migration {
+	return []Migration{
+		{
+			ID:          "0001_initial_schema",
+			Description: "create all application tables / 创建所有应用表",
+			Up: func(tx *gorm.DB) error {
+				return tx.AutoMigrate(baselineModels()...)
+			},
+			Down: func(tx *gorm.DB) error {
+				models := baselineModels()
+				for i := len(models) - 1; i >= 0; i-- {
+					if err := tx.Migrator().DropTable(models[i]); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+		},
+		{
+			ID:          "0002_rebuild_upgrade_and_plugin_indexes",
+			Description: "recreate unique indexes whose column sets changed / 重建列组合已变化的唯一索引",
+			Up: func(tx *gorm.DB) error {
+				return rebuildIndexes(tx, []indexRef{
+					{&stupgradeUpgradeTaskStep0001{}, "idx_st_upgrade_task_step_code"},
+					{&pluginPluginDependencyConfig0001{}, "idx_plugin_dep"},
+					{&pluginPluginDependencyDisable0001{}, "idx_plugin_dep_disable"},
+				})
+			},
+			// The indexes keep the definition of the snapshot; there is nothing to restore.
+			// 索引沿用快照中的定义，无需恢复。
+			Down: func(tx *gorm.DB) error { return nil },
+		},
+		{
+			ID:          "0003_ha_registry",
+			Description: "create control plane replica, agent ownership and lease tables / 创建控制面副本、Agent 归属与租约表",
+			Up: func(tx *gorm.DB) error {
+				return tx.AutoMigrate(&haInstance0003{}, &haAgentOwnership0003{}, &haLease0003{})
+			},
+			Down: func(tx *gorm.DB) error {
+				return tx.Migrator().DropTable(&haLease0003{}, &haAgentOwnership0003{}, &haInstance0003{})
+			},
+		},
+		{
+			ID:          "0004_user_sessions",
+			Description: "create server-side login session table / 创建服务端登录会话表",
+			Up: func(tx *gorm.DB) error {
+				return tx.AutoMigrate(&authUserSession0004{})
+			},
+			Down: func(tx *gorm.DB) error {
+				return tx.Migrator().DropTable(&authUserSession0004{})
+			},
+		},
+		{
+			ID:          "0005_cluster_backups",
+			Description: "create cluster backup and backup policy tables / 创建集群备份与备份策略表",
+			Up: func(tx *gorm.DB) error {
+				return tx.AutoMigrate(&backupBackup0005{}, &backupBackupPolicy0005{})
+			},
+			Down: func(tx *gorm.DB) error {
+				return tx.Migrator().DropTable(&backupBackupPolicy0005{}, &backupBackup0005{})
+			},
+		},
+		{
+			ID:          "0006_custom_plugins",
+			Description: "create custom plugin table / 创建自定义插件表",
+			Up: func(tx *gorm.DB) error {
+				return tx.AutoMigrate(&pluginCustomPlugin0006{})
+			},
+			Down: func(tx *gorm.DB) error {
+				return tx.Migrator().DropTable(&pluginCustomPlugin0006{})
+			},
+		},
+		{
+			ID:          "0007_maven_metadata_cache",
+			Description: "create Maven metadata cache table / 创建 Maven 元数据缓存表",
+			Up: func(tx *gorm.DB) error {
+				return tx.AutoMigrate(&pluginMavenMetadataCache0007{})
+			},
+			Down: func(tx *gorm.DB) error {
+				return tx.Migrator().DropTable(&pluginMavenMetadataCache0007{})
+			},
+		},
+		{
+			ID:          "0008_host_maintenance",
+			Description: "add host maintenance mode columns / 添加主机维护模式字段",
+			Up: func(tx *gorm.DB) error {
+				return tx.AutoMigrate(&hostMaintenance0008{})
+			},
+			Down: func(tx *gorm.DB) error {
+				m := tx.Migrator()
+				for _, column := range []string{"MaintenanceStartedAt", "MaintenanceReason", "Maintenance"} {
+					if m.HasColumn(&hostMaintenance0008{}, column) {
+						if err := m.DropColumn(&hostMaintenance0008{}, column); err != nil {
+							return err
+						}
+					}
+				}
+				return nil
+			},
+		},
+		{
+			ID:          "0009_precheck_runs",
+			Description: "create precheck run history table / 创建预检查历史记录表",
+			Up: func(tx *gorm.DB) error {
+				return tx.AutoMigrate(&installerPrecheckRun0009{})
+			},
+			Down: func(tx *gorm.DB) error {
+				return tx.Migrator().DropTable(&installerPrecheckRun0009{})
+			},
+		},
+		{
+			ID:          "0010_installer_version_policy",
+			Description: "create installer version policy table / 创建安装版本策略表",
+			Up: func(tx *gorm.DB) error {
+				return tx.AutoMigrate(&installerVersionPolicy0010{})
+			},
+			Down: func(tx *gorm.DB) error {
+				return tx.Migrator().DropTable(&installerVersionPolicy0010{})
+			},
+		},
+		{
+			ID:          "0011_host_labels_as_text",
+			Description: "convert host labels stored as SQLite blobs to text / 将以 SQLite BLOB 存储的主机标签转换为文本",
+			Up: func(tx *gorm.DB) error {
+				// Only SQLite kept the JSON as a blob, which LIKE-based label selectors cannot match.
+				// 仅 SQLite 会将 JSON 存为 BLOB，基于 LIKE 的标签选择器无法匹配。
+				if tx.Dialector.Name() != "sqlite" {
+					return nil
+				}
+				return tx.Exec("UPDATE hosts SET labels = CAST(labels AS TEXT) WHERE typeof(labels) = 'blob'").Error
+			},
+			// Text labels are read back unchanged; there is nothing to restore.
+			// 文本标签可原样读取，无需恢复。
+			Down: func(tx *gorm.DB) error { return nil },
+		},
+		{
+			ID:          "0012_ssh_credentials_master_key",
+			Description: "re-encrypt SSH credentials with the secrets master key / 使用 secrets 主密钥重新加密 SSH 凭证",
+			Up: func(tx *gorm.DB) error {
+				// A missing box only matters when legacy credentials exist; MigrateLegacyCredentials reports it then.
+				// 仅当存在历史凭证时才需要 box；届时由 MigrateLegacyCredentials 报错。
+				box, _ := secrets.Default()
+				return sshdeploy.MigrateLegacyCredentials(tx, config.GetSSHCredentialSecret(), box)
+			},
+			// Sealed credentials cannot be converted back; releases before this one need them saved again.
+			// 已加密的凭证无法转换回旧格式；降级到更早的版本后需要重新保存凭证。
+			Down: func(tx *gorm.DB) error { return nil },
+		},
+		{
+			ID:          "0013_host_agent_secret",
+			Description: "add the enrolled Agent secret hash to hosts / 为主机添加已注册 Agent 的密钥哈希",
+			Up: func(tx *gorm.DB) error {
+				return tx.AutoMigrate(&hostAgentSecret0013{})
+			},
+			Down: func(tx *gorm.DB) error {
+				if m := tx.Migrator(); m.HasColumn(&hostAgentSecret0013{}, "AgentSecretHash") {
+					return m.DropColumn(&hostAgentSecret0013{}, "AgentSecretHash")
+				}
+				return nil
+			},
+		},
+		{
+			ID:          "0014_installer_task_states",
+			Description: "share installation and download status between replicas / 在副本之间共享安装与下载状态",
+			Up: func(tx *gorm.DB) error {
+				return tx.AutoMigrate(&installerTaskState0014{})
+			},
+			Down: func(tx *gorm.DB) error {
+				return tx.Migrator().DropTable(&installerTaskState0014{})
+			},
+		},
+	}
+}
+
+// indexRef names one index of a model.
+// indexRef 指向模型上的一个索引。
+type indexRef struct {
+	model interface{}
+	name  string
+}
+
+// rebuildIndexes drops the indexes when present and creates them again from the model definition.
+// rebuildIndexes 删除已存在的索引，并按模型定义重新创建。
+func rebuildIndexes(tx *gorm.DB, indexes []indexRef) error {
+	m := tx.Migrator()
+	for _, index := range indexes {
+		if m.HasIndex(index.model, index.name) {
+			if err := m.DropIndex(index.model, index.name); err != nil {
+				return err
+			}
+		}
+		if err := m.CreateIndex(index.model, index.name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
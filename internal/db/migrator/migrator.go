@@ -27,21 +27,12 @@ package migrator
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log"
 	"os"
 	"strings"
 
-	"github.com/seatunnel/seatunnelX/internal/apps/audit"
 	"github.com/seatunnel/seatunnelX/internal/apps/auth"
-	"github.com/seatunnel/seatunnelX/internal/apps/cluster"
-	appconfig "github.com/seatunnel/seatunnelX/internal/apps/config"
-	"github.com/seatunnel/seatunnelX/internal/apps/diagnostics"
-	"github.com/seatunnel/seatunnelX/internal/apps/host"
-	"github.com/seatunnel/seatunnelX/internal/apps/monitor"
-	monitoringapp "github.com/seatunnel/seatunnelX/internal/apps/monitoring"
-	"github.com/seatunnel/seatunnelX/internal/apps/plugin"
-	"github.com/seatunnel/seatunnelX/internal/apps/stupgrade"
-	syncapp "github.com/seatunnel/seatunnelX/internal/apps/sync"
 	"github.com/seatunnel/seatunnelX/internal/config"
 	"github.com/seatunnel/seatunnelX/internal/db"
 	"gorm.io/gorm"
@@ -59,86 +50,10 @@ func Migrate() {
 		return
 	}
 
-	// 执行数据库表迁移，包含用户表
-	// 注意：auth.User 是统一的用户表，同时支持密码登录和 OAuth 登录
-	// Execute database table migration, including user table
-	// Note: auth.User is the unified user table, supporting both password and OAuth login
-	if err := db.GetDB(context.Background()).AutoMigrate(
-		&auth.User{},                            // 统一用户表（支持密码认证和 OAuth 认证）/ Unified user table
-		&host.Host{},                            // 主机管理表 / Host management table
-		&cluster.Cluster{},                      // 集群表 / Cluster table
-		&cluster.ClusterNode{},                  // 集群节点表 / Cluster node table
-		&audit.CommandLog{},                     // 命令日志表 / Command log table
-		&audit.AuditLog{},                       // 审计日志表 / Audit log table
-		&plugin.InstalledPlugin{},               // 已安装插件表 / Installed plugin table
-		&plugin.PluginDependencyConfig{},        // 插件依赖配置表 / Plugin dependency config table
-		&plugin.PluginDependencyDisable{},       // 插件官方依赖禁用表 / Plugin official dependency disable table
-		&plugin.PluginCatalogEntry{},            // 插件目录表 / Plugin catalog table
-		&plugin.PluginDependencyProfile{},       // 插件官方依赖画像表 / Plugin official dependency profile table
-		&plugin.PluginDependencyProfileItem{},   // 插件官方依赖画像子项表 / Plugin official dependency profile item table
-		&appconfig.Config{},                     // 配置文件表 / Config file table
-		&appconfig.ConfigVersion{},              // 配置版本表 / Config version table
-		&monitor.MonitorConfig{},                // 监控配置表 / Monitor config table (Requirements: 5.2)
-		&monitor.ProcessEvent{},                 // 进程事件表 / Process event table (Requirements: 6.1)
-		&monitoringapp.AlertRule{},              // 监控告警规则表 / Monitoring alert rule table
-		&monitoringapp.AlertPolicy{},            // 统一告警策略表 / Unified alert policy table
-		&monitoringapp.AlertEventState{},        // 告警事件状态表 / Alert event state table
-		&monitoringapp.AlertState{},             // 统一告警状态表 / Unified alert state table
-		&monitoringapp.NotificationChannel{},    // 通知渠道表 / Notification channel table
-		&monitoringapp.NotificationRoute{},      // 通知路由表 / Notification route table
-		&monitoringapp.NotificationDelivery{},   // 通知投递记录表 / Notification delivery table
-		&monitoringapp.RemoteAlertRecord{},      // 远程告警记录表 / Remote alert record table
-		&diagnostics.SeatunnelErrorGroup{},      // 诊断错误组表 / Diagnostics error group table
-		&diagnostics.SeatunnelErrorEvent{},      // 诊断错误事件表 / Diagnostics error event table
-		&diagnostics.SeatunnelLogCursor{},       // 诊断日志游标表 / Diagnostics log cursor table
-		&diagnostics.ClusterInspectionReport{},  // 诊断巡检报告表 / Diagnostics inspection report table
-		&diagnostics.ClusterInspectionFinding{}, // 诊断巡检发现项表 / Diagnostics inspection finding table
-		&diagnostics.DiagnosticTask{},           // 诊断任务表 / Diagnostics task table
-		&diagnostics.DiagnosticTaskStep{},       // 诊断任务步骤表 / Diagnostics task step table
-		&diagnostics.DiagnosticNodeExecution{},  // 诊断任务节点执行表 / Diagnostics node execution table
-		&diagnostics.DiagnosticStepLog{},        // 诊断任务日志表 / Diagnostics task log table
-		&diagnostics.InspectionAutoPolicy{},     // 诊断自动巡检策略表 / Diagnostics auto-inspection policy table
-		&stupgrade.UpgradePlanRecord{},          // SeaTunnel 升级计划表 / SeaTunnel upgrade plan table
-		&stupgrade.UpgradeTask{},                // SeaTunnel 升级任务表 / SeaTunnel upgrade task table
-		&stupgrade.UpgradeTaskStep{},            // SeaTunnel 升级步骤表 / SeaTunnel upgrade step table
-		&stupgrade.UpgradeNodeExecution{},       // SeaTunnel 升级节点执行表 / SeaTunnel upgrade node execution table
-		&stupgrade.UpgradeStepLog{},             // SeaTunnel 升级日志表 / SeaTunnel upgrade log table
-		&syncapp.Task{},                         // 数据同步任务表 / Sync task table
-		&syncapp.TaskVersion{},                  // 数据同步任务版本表 / Sync task version table
-		&syncapp.JobInstance{},                  // 数据同步作业实例表 / Sync job instance table
-		&syncapp.GlobalVariable{},               // 数据同步全局变量表 / Sync global variable table
-		&syncapp.PreviewSession{},               // 数据同步预览会话表 / Sync preview session table
-		&syncapp.PreviewTable{},                 // 数据同步预览表分组表 / Sync preview table table
-		&syncapp.PreviewRow{},                   // 数据同步预览数据行表 / Sync preview row table
-	); err != nil {
-		log.Fatalf("[Database] auto migrate failed: %v\n", err)
-	}
-	log.Printf("[Database] auto migrate success\n")
-
-	upgradeMigrator := db.GetDB(context.Background()).Migrator()
-	if upgradeMigrator.HasIndex(&stupgrade.UpgradeTaskStep{}, "idx_st_upgrade_task_step_code") {
-		if err := upgradeMigrator.DropIndex(&stupgrade.UpgradeTaskStep{}, "idx_st_upgrade_task_step_code"); err != nil {
-			log.Printf("[Database] failed to recreate st upgrade task step index: %v\n", err)
-		}
-	}
-	if err := upgradeMigrator.CreateIndex(&stupgrade.UpgradeTaskStep{}, "idx_st_upgrade_task_step_code"); err != nil {
-		log.Printf("[Database] failed to create st upgrade task step index: %v\n", err)
-	}
-	if upgradeMigrator.HasIndex(&plugin.PluginDependencyConfig{}, "idx_plugin_dep") {
-		if err := upgradeMigrator.DropIndex(&plugin.PluginDependencyConfig{}, "idx_plugin_dep"); err != nil {
-			log.Printf("[Database] failed to recreate plugin dependency index: %v\n", err)
-		}
-	}
-	if err := upgradeMigrator.CreateIndex(&plugin.PluginDependencyConfig{}, "idx_plugin_dep"); err != nil {
-		log.Printf("[Database] failed to create plugin dependency index: %v\n", err)
-	}
-	if upgradeMigrator.HasIndex(&plugin.PluginDependencyDisable{}, "idx_plugin_dep_disable") {
-		if err := upgradeMigrator.DropIndex(&plugin.PluginDependencyDisable{}, "idx_plugin_dep_disable"); err != nil {
-			log.Printf("[Database] failed to recreate plugin dependency disable index: %v\n", err)
-		}
-	}
-	if err := upgradeMigrator.CreateIndex(&plugin.PluginDependencyDisable{}, "idx_plugin_dep_disable"); err != nil {
-		log.Printf("[Database] failed to create plugin dependency disable index: %v\n", err)
+	// 按版本执行 schema 迁移，或仅校验 schema 版本
+	// Apply versioned schema migrations, or only verify the schema version
+	if err := ensureSchema(context.Background(), config.GetMigrationMode()); err != nil {
+		log.Fatalf("[Database] %v\n", err)
 	}
 
 	// 初始化默认管理员用户
@@ -157,6 +72,44 @@ func Migrate() {
 	}
 }
 
+// NewDefaultRunner 基于全局数据库连接创建包含所有迁移的执行器
+// NewDefaultRunner creates a runner with all migrations on the global database connection
+func NewDefaultRunner() (*Runner, error) {
+	database := db.GetGlobalDB()
+	if database == nil {
+		return nil, errors.New("数据库连接未初始化 / database is not initialized")
+	}
+	return NewRunner(database, Migrations())
+}
+
+// ensureSchema 在 auto 模式下执行待执行迁移，在 check 模式下仅校验 schema 版本
+// ensureSchema applies pending migrations in auto mode and only verifies the schema version in check mode
+func ensureSchema(ctx context.Context, mode string) error {
+	runner, err := NewDefaultRunner()
+	if err != nil {
+		return err
+	}
+	if mode == config.MigrationModeCheck {
+		if err := runner.Check(ctx); err != nil {
+			return fmt.Errorf("schema version check failed, run `seatunnelx migrate up` first: %w", err)
+		}
+		version, _ := runner.Version(ctx)
+		log.Printf("[Database] schema version check passed: %s\n", version)
+		return nil
+	}
+
+	applied, err := runner.Up(ctx)
+	if err != nil {
+		return fmt.Errorf("schema migration failed: %w", err)
+	}
+	for _, id := range applied {
+		log.Printf("[Database] applied migration %s\n", id)
+	}
+	version, _ := runner.Version(ctx)
+	log.Printf("[Database] schema is up to date: %s\n", version)
+	return nil
+}
+
 // initDefaultAdminUser 初始化默认管理员用户
 // 仅在首次启动时（用户表为空）创建默认 admin 用户
 // Requirements: 2.1, 2.2
@@ -198,6 +151,7 @@ func initDefaultAdminUser() error {
 		Nickname: "系统管理员",
 		IsActive: true,
 		IsAdmin:  true,
+		Role:     string(auth.RoleAdmin),
 	}
 
 	// 设置密码（使用 bcrypt 哈希）
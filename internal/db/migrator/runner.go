@@ -0,0 +1,258 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package migrator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrPendingMigrations means the database schema is older than this binary.
+	// ErrPendingMigrations 表示数据库 schema 版本低于当前程序。
+	ErrPendingMigrations = errors.New("database schema has pending migrations")
+	// ErrUnknownMigrations means the database was migrated by a newer binary.
+	// ErrUnknownMigrations 表示数据库已被更新版本的程序迁移过。
+	ErrUnknownMigrations = errors.New("database schema contains migrations unknown to this binary")
+	// ErrIrreversibleMigration means a migration cannot be rolled back.
+	// ErrIrreversibleMigration 表示迁移不可回滚。
+	ErrIrreversibleMigration = errors.New("migration is irreversible")
+)
+
+// Migration is one versioned schema change. IDs sort lexically in apply order,
+// e.g. "0001_initial_schema".
+// Migration 表示一次带版本的 schema 变更。ID 按字典序即为执行顺序，例如 "0001_initial_schema"。
+type Migration struct {
+	ID          string
+	Description string
+	// Up applies the change inside a transaction.
+	// Up 在事务中执行变更。
+	Up func(tx *gorm.DB) error
+	// Down reverts the change; nil marks the migration irreversible.
+	// Down 回滚变更；为 nil 表示不可回滚。
+	Down func(tx *gorm.DB) error
+}
+
+// SchemaMigration records one applied migration.
+// SchemaMigration 记录一次已执行的迁移。
+type SchemaMigration struct {
+	ID          string    `gorm:"primaryKey;size:191"`
+	Description string    `gorm:"size:255"`
+	AppliedAt   time.Time `gorm:"not null"`
+}
+
+// TableName returns the schema version table name.
+// TableName 返回 schema 版本表名。
+func (SchemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+// MigrationStatus describes whether one known or unknown migration is applied.
+// MigrationStatus 描述一个已知或未知迁移的执行状态。
+type MigrationStatus struct {
+	ID          string
+	Description string
+	Applied     bool
+	AppliedAt   *time.Time
+	// Unknown is true for migrations recorded in the database but not compiled into this binary.
+	// Unknown 为 true 表示该迁移记录在数据库中，但不在当前程序内。
+	Unknown bool
+}
+
+// Runner applies and reverts migrations and tracks them in schema_migrations.
+// Runner 执行与回滚迁移，并在 schema_migrations 表中记录。
+type Runner struct {
+	db         *gorm.DB
+	migrations []Migration
+}
+
+// NewRunner creates a runner for the given migrations, which must have unique IDs in ascending order.
+// NewRunner 为给定迁移创建执行器，迁移 ID 必须唯一且升序。
+func NewRunner(db *gorm.DB, migrations []Migration) (*Runner, error) {
+	for i, m := range migrations {
+		if strings.TrimSpace(m.ID) == "" || m.Up == nil {
+			return nil, fmt.Errorf("migration #%d must have an ID and an Up function", i)
+		}
+		if i > 0 && migrations[i-1].ID >= m.ID {
+			return nil, fmt.Errorf("migration %s must sort after %s", m.ID, migrations[i-1].ID)
+		}
+	}
+	return &Runner{db: db, migrations: migrations}, nil
+}
+
+// applied returns the applied migrations keyed by ID, creating the version table when missing.
+// applied 返回以 ID 为键的已执行迁移，版本表不存在时自动创建。
+func (r *Runner) applied(ctx context.Context) (map[string]SchemaMigration, error) {
+	database := r.db.WithContext(ctx)
+	if err := database.AutoMigrate(&SchemaMigration{}); err != nil {
+		return nil, fmt.Errorf("create schema_migrations table: %w", err)
+	}
+	var rows []SchemaMigration
+	if err := database.Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("read schema_migrations: %w", err)
+	}
+	applied := make(map[string]SchemaMigration, len(rows))
+	for _, row := range rows {
+		applied[row.ID] = row
+	}
+	return applied, nil
+}
+
+// Status lists every known migration in order, followed by unknown applied ones.
+// Status 按顺序列出所有已知迁移，随后列出数据库中的未知迁移。
+func (r *Runner) Status(ctx context.Context) ([]MigrationStatus, error) {
+	applied, err := r.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+	statuses := make([]MigrationStatus, 0, len(r.migrations)+len(applied))
+	known := make(map[string]struct{}, len(r.migrations))
+	for _, m := range r.migrations {
+		known[m.ID] = struct{}{}
+		status := MigrationStatus{ID: m.ID, Description: m.Description}
+		if row, ok := applied[m.ID]; ok {
+			appliedAt := row.AppliedAt
+			status.Applied = true
+			status.AppliedAt = &appliedAt
+		}
+		statuses = append(statuses, status)
+	}
+	var unknown []MigrationStatus
+	for id, row := range applied {
+		if _, ok := known[id]; ok {
+			continue
+		}
+		appliedAt := row.AppliedAt
+		unknown = append(unknown, MigrationStatus{ID: id, Description: row.Description, Applied: true, AppliedAt: &appliedAt, Unknown: true})
+	}
+	sort.Slice(unknown, func(i, j int) bool { return unknown[i].ID < unknown[j].ID })
+	return append(statuses, unknown...), nil
+}
+
+// Version returns the ID of the latest applied migration, or "" for an empty database.
+// Version 返回最近一次已执行迁移的 ID，空数据库返回 ""。
+func (r *Runner) Version(ctx context.Context) (string, error) {
+	statuses, err := r.Status(ctx)
+	if err != nil {
+		return "", err
+	}
+	version := ""
+	for _, status := range statuses {
+		if status.Applied && status.ID > version {
+			version = status.ID
+		}
+	}
+	return version, nil
+}
+
+// Check reports ErrPendingMigrations or ErrUnknownMigrations when the schema does not match this binary.
+// Check 在 schema 与当前程序不一致时返回 ErrPendingMigrations 或 ErrUnknownMigrations。
+func (r *Runner) Check(ctx context.Context) error {
+	statuses, err := r.Status(ctx)
+	if err != nil {
+		return err
+	}
+	var pending, unknown []string
+	for _, status := range statuses {
+		switch {
+		case status.Unknown:
+			unknown = append(unknown, status.ID)
+		case !status.Applied:
+			pending = append(pending, status.ID)
+		}
+	}
+	if len(unknown) > 0 {
+		return fmt.Errorf("%w: %s", ErrUnknownMigrations, strings.Join(unknown, ", "))
+	}
+	if len(pending) > 0 {
+		return fmt.Errorf("%w: %s", ErrPendingMigrations, strings.Join(pending, ", "))
+	}
+	return nil
+}
+
+// Up applies all pending migrations in order and returns the IDs it applied.
+// A database migrated by a newer binary is refused.
+// Up 按顺序执行所有待执行迁移并返回已执行的 ID。数据库若已被更新版本的程序迁移则拒绝执行。
+func (r *Runner) Up(ctx context.Context) ([]string, error) {
+	if err := r.Check(ctx); err == nil || !errors.Is(err, ErrPendingMigrations) {
+		return nil, err
+	}
+	applied, err := r.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var done []string
+	for _, m := range r.migrations {
+		if _, ok := applied[m.ID]; ok {
+			continue
+		}
+		err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			if err := m.Up(tx); err != nil {
+				return err
+			}
+			return tx.Create(&SchemaMigration{ID: m.ID, Description: m.Description, AppliedAt: time.Now()}).Error
+		})
+		if err != nil {
+			return done, fmt.Errorf("apply migration %s: %w", m.ID, err)
+		}
+		done = append(done, m.ID)
+	}
+	return done, nil
+}
+
+// Down reverts the latest steps applied migrations, newest first, and returns the IDs it reverted.
+// Down 从最新开始回滚 steps 个已执行迁移，并返回已回滚的 ID。
+func (r *Runner) Down(ctx context.Context, steps int) ([]string, error) {
+	if steps <= 0 {
+		return nil, nil
+	}
+	if err := r.Check(ctx); err != nil && !errors.Is(err, ErrPendingMigrations) {
+		return nil, err
+	}
+	applied, err := r.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var done []string
+	for i := len(r.migrations) - 1; i >= 0 && len(done) < steps; i-- {
+		m := r.migrations[i]
+		if _, ok := applied[m.ID]; !ok {
+			continue
+		}
+		if m.Down == nil {
+			return done, fmt.Errorf("revert migration %s: %w", m.ID, ErrIrreversibleMigration)
+		}
+		err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			if err := m.Down(tx); err != nil {
+				return err
+			}
+			return tx.Delete(&SchemaMigration{ID: m.ID}).Error
+		})
+		if err != nil {
+			return done, fmt.Errorf("revert migration %s: %w", m.ID, err)
+		}
+		done = append(done, m.ID)
+	}
+	return done, nil
+}
@@ -0,0 +1,191 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package migrator
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type migrationTestWidget struct {
+	ID   uint
+	Name string
+}
+
+func openMigrationTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	database, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "migrate.db")), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	t.Cleanup(func() {
+		if sqlDB, err := database.DB(); err == nil {
+			_ = sqlDB.Close()
+		}
+	})
+	return database
+}
+
+func testMigrations() []Migration {
+	return []Migration{
+		{
+			ID: "0001_widgets",
+			Up: func(tx *gorm.DB) error { return tx.AutoMigrate(&migrationTestWidget{}) },
+			Down: func(tx *gorm.DB) error {
+				return tx.Migrator().DropTable(&migrationTestWidget{})
+			},
+		},
+		{
+			ID:   "0002_seed",
+			Up:   func(tx *gorm.DB) error { return tx.Create(&migrationTestWidget{Name: "seed"}).Error },
+			Down: func(tx *gorm.DB) error { return tx.Where("name = ?", "seed").Delete(&migrationTestWidget{}).Error },
+		},
+	}
+}
+
+func TestRunnerUpDownAndCheck(t *testing.T) {
+	ctx := context.Background()
+	database := openMigrationTestDB(t)
+	runner, err := NewRunner(database, testMigrations())
+	if err != nil {
+		t.Fatalf("new runner: %v", err)
+	}
+
+	if err := runner.Check(ctx); !errors.Is(err, ErrPendingMigrations) {
+		t.Fatalf("expected pending migrations, got %v", err)
+	}
+	applied, err := runner.Up(ctx)
+	if err != nil || len(applied) != 2 {
+		t.Fatalf("up applied %v, err %v", applied, err)
+	}
+	if err := runner.Check(ctx); err != nil {
+		t.Fatalf("expected schema to be current, got %v", err)
+	}
+	if applied, err := runner.Up(ctx); err != nil || len(applied) != 0 {
+		t.Fatalf("second up applied %v, err %v", applied, err)
+	}
+	if version, _ := runner.Version(ctx); version != "0002_seed" {
+		t.Fatalf("version = %q", version)
+	}
+
+	reverted, err := runner.Down(ctx, 1)
+	if err != nil || len(reverted) != 1 || reverted[0] != "0002_seed" {
+		t.Fatalf("down reverted %v, err %v", reverted, err)
+	}
+	var count int64
+	database.Model(&migrationTestWidget{}).Count(&count)
+	if count != 0 {
+		t.Fatalf("expected seed row to be removed, got %d rows", count)
+	}
+	if version, _ := runner.Version(ctx); version != "0001_widgets" {
+		t.Fatalf("version after down = %q", version)
+	}
+}
+
+func TestRunnerRefusesDatabaseFromNewerBinary(t *testing.T) {
+	ctx := context.Background()
+	database := openMigrationTestDB(t)
+	newer, _ := NewRunner(database, testMigrations())
+	if _, err := newer.Up(ctx); err != nil {
+		t.Fatalf("up: %v", err)
+	}
+
+	older, _ := NewRunner(database, testMigrations()[:1])
+	if err := older.Check(ctx); !errors.Is(err, ErrUnknownMigrations) {
+		t.Fatalf("expected unknown migrations, got %v", err)
+	}
+	if _, err := older.Up(ctx); !errors.Is(err, ErrUnknownMigrations) {
+		t.Fatalf("expected up to refuse, got %v", err)
+	}
+	statuses, err := older.Status(ctx)
+	if err != nil || len(statuses) != 2 || !statuses[1].Unknown {
+		t.Fatalf("statuses %+v, err %v", statuses, err)
+	}
+}
+
+func TestRunnerRollsBackFailedMigration(t *testing.T) {
+	ctx := context.Background()
+	database := openMigrationTestDB(t)
+	migrations := append(testMigrations(), Migration{
+		ID: "0003_broken",
+		Up: func(tx *gorm.DB) error {
+			if err := tx.Create(&migrationTestWidget{Name: "partial"}).Error; err != nil {
+				return err
+			}
+			return errors.New("boom")
+		},
+	})
+	runner, _ := NewRunner(database, migrations)
+
+	applied, err := runner.Up(ctx)
+	if err == nil || len(applied) != 2 {
+		t.Fatalf("expected failure after two migrations, applied %v, err %v", applied, err)
+	}
+	var count int64
+	database.Model(&migrationTestWidget{}).Where("name = ?", "partial").Count(&count)
+	if count != 0 {
+		t.Fatal("expected failed migration to be rolled back")
+	}
+	if err := runner.Check(ctx); !errors.Is(err, ErrPendingMigrations) {
+		t.Fatalf("expected failed migration to stay pending, got %v", err)
+	}
+	if _, err := runner.Down(ctx, 5); err != nil {
+		t.Fatalf("down: %v", err)
+	}
+}
+
+func TestRunnerRejectsIrreversibleAndUnorderedMigrations(t *testing.T) {
+	ctx := context.Background()
+	runner, _ := NewRunner(openMigrationTestDB(t), []Migration{{ID: "0001_once", Up: func(*gorm.DB) error { return nil }}})
+	if _, err := runner.Up(ctx); err != nil {
+		t.Fatalf("up: %v", err)
+	}
+	if _, err := runner.Down(ctx, 1); !errors.Is(err, ErrIrreversibleMigration) {
+		t.Fatalf("expected irreversible error, got %v", err)
+	}
+
+	unordered := []Migration{testMigrations()[1], testMigrations()[0]}
+	if _, err := NewRunner(nil, unordered); err == nil {
+		t.Fatal("expected unordered migrations to be rejected")
+	}
+}
+
+func TestMigrationsApplyAndRevertOnSQLite(t *testing.T) {
+	ctx := context.Background()
+	runner, err := NewRunner(openMigrationTestDB(t), Migrations())
+	if err != nil {
+		t.Fatalf("new runner: %v", err)
+	}
+	if _, err := runner.Up(ctx); err != nil {
+		t.Fatalf("up: %v", err)
+	}
+	if _, err := runner.Down(ctx, len(Migrations())); err != nil {
+		t.Fatalf("down: %v", err)
+	}
+	if _, err := runner.Up(ctx); err != nil {
+		t.Fatalf("up again: %v", err)
+	}
+}
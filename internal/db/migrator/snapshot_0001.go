@@ -0,0 +1,1254 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package migrator
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// The types below freeze the tables created by 0001_initial_schema; they must not follow later model changes.
+// 以下类型冻结了 0001_initial_schema 创建的表结构，不得随后续模型变更而修改。
+
+// baselineModels lists the tables of the initial schema as frozen snapshots.
+// baselineModels 以冻结快照的形式列出初始 schema 的表。
+// 注意：auth.User 是统一的用户表，同时支持密码登录和 OAuth 登录
+// Note: auth.User is the unified user table, supporting both password and OAuth login
+func baselineModels() []interface{} {
+	return []interface{}{
+		&authUser0001{},                            // 统一用户表（支持密码认证和 OAuth 认证）/ Unified user table
+		&authRoleBinding0001{},                     // 资源级角色绑定表 / Resource-scoped role binding table
+		&authProject0001{},                         // 项目（租户）表 / Project (tenant) table
+		&authProjectMember0001{},                   // 项目成员表 / Project member table
+		&hostHost0001{},                            // 主机管理表 / Host management table
+		&hostEnrollmentToken0001{},                 // 主机注册令牌表 / Host enrollment token table
+		&sshdeployCredential0001{},                 // 主机 SSH 凭证表 / Host SSH credential table
+		&sshdeployDeployTask0001{},                 // SSH 部署 Agent 任务表 / SSH agent deploy task table
+		&agentAgentApproval0001{},                  // Agent 审批表 / Agent approval table
+		&agentAgentDenyRule0001{},                  // Agent 拒绝规则表 / Agent deny rule table
+		&clusterCluster0001{},                      // 集群表 / Cluster table
+		&clusterClusterNode0001{},                  // 集群节点表 / Cluster node table
+		&clusterScaleTask0001{},                    // 集群扩缩容任务表 / Cluster scale task table
+		&auditCommandLog0001{},                     // 命令日志表 / Command log table
+		&auditAuditLog0001{},                       // 审计日志表 / Audit log table
+		&pluginInstalledPlugin0001{},               // 已安装插件表 / Installed plugin table
+		&pluginPluginDependencyConfig0001{},        // 插件依赖配置表 / Plugin dependency config table
+		&pluginPluginDependencyDisable0001{},       // 插件官方依赖禁用表 / Plugin official dependency disable table
+		&pluginPluginCatalogEntry0001{},            // 插件目录表 / Plugin catalog table
+		&pluginPluginDependencyProfile0001{},       // 插件官方依赖画像表 / Plugin official dependency profile table
+		&pluginPluginDependencyProfileItem0001{},   // 插件官方依赖画像子项表 / Plugin official dependency profile item table
+		&configConfig0001{},                        // 配置文件表 / Config file table
+		&configConfigVersion0001{},                 // 配置版本表 / Config version table
+		&configConfigDrift0001{},                   // 配置漂移检测表 / Config drift detection table
+		&monitorMonitorConfig0001{},                // 监控配置表 / Monitor config table (Requirements: 5.2)
+		&monitorProcessEvent0001{},                 // 进程事件表 / Process event table (Requirements: 6.1)
+		&monitoringAlertRule0001{},                 // 监控告警规则表 / Monitoring alert rule table
+		&monitoringAlertPolicy0001{},               // 统一告警策略表 / Unified alert policy table
+		&monitoringAlertEventState0001{},           // 告警事件状态表 / Alert event state table
+		&monitoringAlertState0001{},                // 统一告警状态表 / Unified alert state table
+		&monitoringNotificationChannel0001{},       // 通知渠道表 / Notification channel table
+		&monitoringNotificationRoute0001{},         // 通知路由表 / Notification route table
+		&monitoringNotificationDelivery0001{},      // 通知投递记录表 / Notification delivery table
+		&monitoringRemoteAlertRecord0001{},         // 远程告警记录表 / Remote alert record table
+		&diagnosticsSeatunnelErrorGroup0001{},      // 诊断错误组表 / Diagnostics error group table
+		&diagnosticsSeatunnelErrorEvent0001{},      // 诊断错误事件表 / Diagnostics error event table
+		&diagnosticsSeatunnelLogCursor0001{},       // 诊断日志游标表 / Diagnostics log cursor table
+		&diagnosticsClusterInspectionReport0001{},  // 诊断巡检报告表 / Diagnostics inspection report table
+		&diagnosticsClusterInspectionFinding0001{}, // 诊断巡检发现项表 / Diagnostics inspection finding table
+		&diagnosticsDiagnosticTask0001{},           // 诊断任务表 / Diagnostics task table
+		&diagnosticsDiagnosticTaskStep0001{},       // 诊断任务步骤表 / Diagnostics task step table
+		&diagnosticsDiagnosticNodeExecution0001{},  // 诊断任务节点执行表 / Diagnostics node execution table
+		&diagnosticsDiagnosticStepLog0001{},        // 诊断任务日志表 / Diagnostics task log table
+		&diagnosticsInspectionAutoPolicy0001{},     // 诊断自动巡检策略表 / Diagnostics auto-inspection policy table
+		&stupgradeUpgradePlanRecord0001{},          // SeaTunnel 升级计划表 / SeaTunnel upgrade plan table
+		&stupgradeUpgradeTask0001{},                // SeaTunnel 升级任务表 / SeaTunnel upgrade task table
+		&stupgradeUpgradeTaskStep0001{},            // SeaTunnel 升级步骤表 / SeaTunnel upgrade step table
+		&stupgradeUpgradeNodeExecution0001{},       // SeaTunnel 升级节点执行表 / SeaTunnel upgrade node execution table
+		&stupgradeUpgradeStepLog0001{},             // SeaTunnel 升级日志表 / SeaTunnel upgrade log table
+		&syncTask0001{},                            // 数据同步任务表 / Sync task table
+		&syncTaskVersion0001{},                     // 数据同步任务版本表 / Sync task version table
+		&syncJobInstance0001{},                     // 数据同步作业实例表 / Sync job instance table
+		&syncGlobalVariable0001{},                  // 数据同步全局变量表 / Sync global variable table
+		&syncPreviewSession0001{},                  // 数据同步预览会话表 / Sync preview session table
+		&syncPreviewTable0001{},                    // 数据同步预览表分组表 / Sync preview table table
+		&syncPreviewRow0001{},                      // 数据同步预览数据行表 / Sync preview row table
+		// 平台事件订阅表 / Platform event subscription table
+		&monitoringPlatformEventSubscription0001{},
+		// 心跳告警规则与告警表 / Heartbeat alert rule and alert tables
+		&monitoringHeartbeatAlertRule0001{},
+		&monitoringHeartbeatAlert0001{},
+		// 安装模板表 / Installation template table
+		&installerInstallationTemplate0001{},
+		// 集群指标快照表 / Cluster metrics snapshot table
+		&clustermetricsMetricsSnapshot0001{},
+		// 操作任务历史表 / Operation task history table
+		&taskTaskRecord0001{},
+	}
+}
+
+type authUser0001 struct {
+	ID           uint64    `gorm:"column:id;primaryKey;autoIncrement"`
+	Username     string    `gorm:"column:username;size:255;unique;not null"`
+	PasswordHash string    `gorm:"column:password_hash;size:255"`
+	Nickname     string    `gorm:"column:nickname;size:255"`
+	Email        string    `gorm:"column:email;size:255;index"`
+	Language     string    `gorm:"column:language;size:8;default:zh"`
+	AvatarURL    string    `gorm:"column:avatar_url;size:255"`
+	OAuthID      string    `gorm:"column:o_auth_id;size:255;index"`
+	IsActive     bool      `gorm:"column:is_active;default:true"`
+	IsAdmin      bool      `gorm:"column:is_admin;default:false"`
+	Role         string    `gorm:"column:role;size:32"`
+	LastLoginAt  time.Time `gorm:"column:last_login_at"`
+	CreatedAt    time.Time `gorm:"column:created_at"`
+	UpdatedAt    time.Time `gorm:"column:updated_at"`
+}
+
+func (authUser0001) TableName() string { return "auth_users" }
+
+type authRoleBinding0001 struct {
+	ID           uint64    `gorm:"column:id;primaryKey;autoIncrement"`
+	UserID       uint64    `gorm:"column:user_id;uniqueIndex:idx_role_binding_resource;not null"`
+	ResourceType string    `gorm:"column:resource_type;uniqueIndex:idx_role_binding_resource;size:32;not null"`
+	ResourceID   uint      `gorm:"column:resource_id;uniqueIndex:idx_role_binding_resource;not null"`
+	Role         string    `gorm:"column:role;size:32;not null"`
+	CreatedAt    time.Time `gorm:"column:created_at"`
+	UpdatedAt    time.Time `gorm:"column:updated_at"`
+}
+
+func (authRoleBinding0001) TableName() string { return "auth_role_bindings" }
+
+type authProject0001 struct {
+	ID          uint      `gorm:"column:id;primaryKey;autoIncrement"`
+	Name        string    `gorm:"column:name;size:100;uniqueIndex;not null"`
+	Description string    `gorm:"column:description;type:text"`
+	CreatedAt   time.Time `gorm:"column:created_at"`
+	UpdatedAt   time.Time `gorm:"column:updated_at"`
+}
+
+func (authProject0001) TableName() string { return "auth_projects" }
+
+type authProjectMember0001 struct {
+	ID        uint64    `gorm:"column:id;primaryKey;autoIncrement"`
+	ProjectID uint      `gorm:"column:project_id;uniqueIndex:idx_project_member;not null"`
+	UserID    uint64    `gorm:"column:user_id;uniqueIndex:idx_project_member;index;not null"`
+	CreatedAt time.Time `gorm:"column:created_at"`
+}
+
+func (authProjectMember0001) TableName() string { return "auth_project_members" }
+
+type hostHost0001 struct {
+	ID               uint           `gorm:"column:id;primaryKey;autoIncrement"`
+	Name             string         `gorm:"column:name;size:100;uniqueIndex;not null"`
+	HostType         string         `gorm:"column:host_type;size:20;not null;default:bare_metal;index"`
+	Description      string         `gorm:"column:description;type:text"`
+	Status           string         `gorm:"column:status;size:20;default:pending;index"`
+	Labels           string         `gorm:"column:labels;type:text"`
+	ProjectID        uint           `gorm:"column:project_id;default:0;index"`
+	CPUUsage         float64        `gorm:"column:cpu_usage;type:decimal(5,2)"`
+	MemoryUsage      float64        `gorm:"column:memory_usage;type:decimal(5,2)"`
+	DiskUsage        float64        `gorm:"column:disk_usage;type:decimal(5,2)"`
+	LastCheck        time.Time      `gorm:"column:last_check"`
+	IPAddress        string         `gorm:"column:ip_address;size:45"`
+	SSHPort          int            `gorm:"column:ssh_port;default:22"`
+	SSHUser          string         `gorm:"column:ssh_user;size:64"`
+	AgentID          string         `gorm:"column:agent_id;size:100;index"`
+	AgentStatus      string         `gorm:"column:agent_status;size:20;default:not_installed;index"`
+	AgentVersion     string         `gorm:"column:agent_version;size:20"`
+	OSType           string         `gorm:"column:os_type;size:20"`
+	Arch             string         `gorm:"column:arch;size:20"`
+	CPUCores         int            `gorm:"column:cpu_cores"`
+	TotalMemory      int64          `gorm:"column:total_memory"`
+	TotalDisk        int64          `gorm:"column:total_disk"`
+	LastHeartbeat    time.Time      `gorm:"column:last_heartbeat"`
+	DockerAPIURL     string         `gorm:"column:docker_api_url;size:255"`
+	DockerTLSEnabled bool           `gorm:"column:docker_tls_enabled;default:false"`
+	DockerCertPath   string         `gorm:"column:docker_cert_path;size:255"`
+	DockerVersion    string         `gorm:"column:docker_version;size:20"`
+	K8sAPIURL        string         `gorm:"column:k8s_api_url;size:255"`
+	K8sNamespace     string         `gorm:"column:k8s_namespace;size:100;default:default"`
+	K8sKubeconfig    string         `gorm:"column:k8s_kubeconfig;type:text"`
+	K8sToken         string         `gorm:"column:k8s_token;type:text"`
+	K8sVersion       string         `gorm:"column:k8s_version;size:20"`
+	CreatedAt        time.Time      `gorm:"column:created_at"`
+	UpdatedAt        time.Time      `gorm:"column:updated_at"`
+	CreatedBy        uint           `gorm:"column:created_by"`
+	DeletedAt        gorm.DeletedAt `gorm:"column:deleted_at;index"`
+}
+
+func (hostHost0001) TableName() string { return "hosts" }
+
+type hostEnrollmentToken0001 struct {
+	ID            uint      `gorm:"column:id;primaryKey;autoIncrement"`
+	HostID        uint      `gorm:"column:host_id;not null;index"`
+	TokenHash     string    `gorm:"column:token_hash;size:64;uniqueIndex;not null"`
+	ExpiresAt     time.Time `gorm:"column:expires_at;index"`
+	UsedAt        time.Time `gorm:"column:used_at"`
+	UsedByAgentID string    `gorm:"column:used_by_agent_id;size:100"`
+	CreatedAt     time.Time `gorm:"column:created_at"`
+}
+
+func (hostEnrollmentToken0001) TableName() string { return "host_enrollment_tokens" }
+
+type sshdeployCredential0001 struct {
+	ID                  uint      `gorm:"column:id;primaryKey"`
+	HostID              uint      `gorm:"column:host_id;uniqueIndex;not null"`
+	Username            string    `gorm:"column:username;size:64;not null"`
+	AuthType            string    `gorm:"column:auth_type;size:16;not null"`
+	EncryptedSecret     string    `gorm:"column:encrypted_secret;type:text;not null"`
+	EncryptedPassphrase string    `gorm:"column:encrypted_passphrase;type:text"`
+	HostKeyFingerprint  string    `gorm:"column:host_key_fingerprint;size:128"`
+	CreatedAt           time.Time `gorm:"column:created_at"`
+	UpdatedAt           time.Time `gorm:"column:updated_at"`
+}
+
+func (sshdeployCredential0001) TableName() string { return "host_ssh_credentials" }
+
+type sshdeployDeployTask0001 struct {
+	ID         uint      `gorm:"column:id;primaryKey"`
+	HostID     uint      `gorm:"column:host_id;index;not null"`
+	Status     string    `gorm:"column:status;size:16;index;not null"`
+	Step       string    `gorm:"column:step;size:32"`
+	Platform   string    `gorm:"column:platform;size:32"`
+	Log        string    `gorm:"column:log;type:text"`
+	Error      string    `gorm:"column:error;type:text"`
+	CreatedBy  string    `gorm:"column:created_by;size:64"`
+	StartedAt  time.Time `gorm:"column:started_at"`
+	FinishedAt time.Time `gorm:"column:finished_at"`
+	CreatedAt  time.Time `gorm:"column:created_at"`
+	UpdatedAt  time.Time `gorm:"column:updated_at"`
+}
+
+func (sshdeployDeployTask0001) TableName() string { return "host_ssh_deploy_tasks" }
+
+type agentAgentApproval0001 struct {
+	ID         uint      `gorm:"column:id;primaryKey;autoIncrement"`
+	AgentID    string    `gorm:"column:agent_id;size:100;not null;uniqueIndex"`
+	IPAddress  string    `gorm:"column:ip_address;size:50"`
+	Hostname   string    `gorm:"column:hostname;size:255"`
+	ApprovedBy uint      `gorm:"column:approved_by"`
+	CreatedAt  time.Time `gorm:"column:created_at"`
+}
+
+func (agentAgentApproval0001) TableName() string { return "agent_approvals" }
+
+type agentAgentDenyRule0001 struct {
+	ID        uint      `gorm:"column:id;primaryKey;autoIncrement"`
+	Type      string    `gorm:"column:type;size:20;not null;uniqueIndex:idx_agent_deny_rule"`
+	Value     string    `gorm:"column:value;size:255;not null;uniqueIndex:idx_agent_deny_rule"`
+	Reason    string    `gorm:"column:reason;size:500"`
+	CreatedBy uint      `gorm:"column:created_by"`
+	CreatedAt time.Time `gorm:"column:created_at"`
+}
+
+func (agentAgentDenyRule0001) TableName() string { return "agent_deny_rules" }
+
+type clusterCluster0001 struct {
+	ID              uint           `gorm:"column:id;primaryKey;autoIncrement"`
+	Name            string         `gorm:"column:name;size:100;uniqueIndex;not null"`
+	Description     string         `gorm:"column:description;type:text"`
+	DeploymentMode  string         `gorm:"column:deployment_mode;size:20;not null"`
+	Version         string         `gorm:"column:version;size:20"`
+	Status          string         `gorm:"column:status;size:20;default:created;index"`
+	Source          string         `gorm:"column:source;size:20;default:installed"`
+	InstallDir      string         `gorm:"column:install_dir;size:255"`
+	Config          string         `gorm:"column:config;type:json"`
+	NodeSelectors   string         `gorm:"column:node_selectors;type:text"`
+	ProjectID       uint           `gorm:"column:project_id;default:0;index"`
+	CreatedAt       time.Time      `gorm:"column:created_at"`
+	UpdatedAt       time.Time      `gorm:"column:updated_at"`
+	CreatedBy       uint           `gorm:"column:created_by"`
+	DeletedAt       gorm.DeletedAt `gorm:"column:deleted_at;index"`
+	PurgeInstallDir bool           `gorm:"column:purge_install_dir;default:false"`
+}
+
+func (clusterCluster0001) TableName() string { return "clusters" }
+
+type clusterClusterNode0001 struct {
+	ID            uint      `gorm:"column:id;primaryKey;autoIncrement"`
+	ClusterID     uint      `gorm:"column:cluster_id;index;not null"`
+	HostID        uint      `gorm:"column:host_id;index;not null"`
+	Role          string    `gorm:"column:role;size:20;not null"`
+	InstallDir    string    `gorm:"column:install_dir;size:255"`
+	HazelcastPort int       `gorm:"column:hazelcast_port"`
+	APIPort       int       `gorm:"column:api_port"`
+	WorkerPort    int       `gorm:"column:worker_port"`
+	Overrides     string    `gorm:"column:overrides;type:json"`
+	Status        string    `gorm:"column:status;size:20;default:pending"`
+	ProcessPID    int       `gorm:"column:process_pid"`
+	LastEventAt   time.Time `gorm:"column:last_event_at"`
+	CreatedAt     time.Time `gorm:"column:created_at"`
+	UpdatedAt     time.Time `gorm:"column:updated_at"`
+}
+
+func (clusterClusterNode0001) TableName() string { return "cluster_nodes" }
+
+type clusterScaleTask0001 struct {
+	ID         uint      `gorm:"column:id;primaryKey;autoIncrement"`
+	ClusterID  uint      `gorm:"column:cluster_id;index;not null"`
+	Status     string    `gorm:"column:status;size:20;index;not null"`
+	Step       string    `gorm:"column:step;size:50"`
+	Request    string    `gorm:"column:request;type:text"`
+	Log        string    `gorm:"column:log;type:text"`
+	Error      string    `gorm:"column:error;type:text"`
+	CreatedBy  string    `gorm:"column:created_by;size:100"`
+	StartedAt  time.Time `gorm:"column:started_at"`
+	FinishedAt time.Time `gorm:"column:finished_at"`
+	CreatedAt  time.Time `gorm:"column:created_at"`
+	UpdatedAt  time.Time `gorm:"column:updated_at"`
+}
+
+func (clusterScaleTask0001) TableName() string { return "cluster_scale_tasks" }
+
+type auditCommandLog0001 struct {
+	ID          uint      `gorm:"column:id;primaryKey;autoIncrement"`
+	CommandID   string    `gorm:"column:command_id;size:50;uniqueIndex;not null"`
+	AgentID     string    `gorm:"column:agent_id;size:100;not null;index"`
+	HostID      uint      `gorm:"column:host_id;index"`
+	CommandType string    `gorm:"column:command_type;size:30;not null"`
+	Parameters  string    `gorm:"column:parameters;type:json"`
+	Status      string    `gorm:"column:status;size:20;not null;index"`
+	Progress    int       `gorm:"column:progress;default:0"`
+	Output      string    `gorm:"column:output;type:longtext"`
+	Error       string    `gorm:"column:error;type:text"`
+	StartedAt   time.Time `gorm:"column:started_at"`
+	FinishedAt  time.Time `gorm:"column:finished_at"`
+	CreatedAt   time.Time `gorm:"column:created_at;index"`
+	CreatedBy   uint      `gorm:"column:created_by"`
+}
+
+func (auditCommandLog0001) TableName() string { return "command_logs" }
+
+type auditAuditLog0001 struct {
+	ID           uint      `gorm:"column:id;primaryKey;autoIncrement"`
+	UserID       uint      `gorm:"column:user_id;index"`
+	Username     string    `gorm:"column:username;size:100"`
+	Action       string    `gorm:"column:action;size:50;not null;index"`
+	ResourceType string    `gorm:"column:resource_type;size:50;not null;index:idx_resource"`
+	ResourceID   string    `gorm:"column:resource_id;size:100;index:idx_resource"`
+	ResourceName string    `gorm:"column:resource_name;size:200"`
+	Trigger      string    `gorm:"column:trigger;size:20;index"`
+	Details      string    `gorm:"column:details;type:json"`
+	IPAddress    string    `gorm:"column:ip_address;size:45"`
+	UserAgent    string    `gorm:"column:user_agent;size:500"`
+	RequestID    string    `gorm:"column:request_id;size:64;index"`
+	CreatedAt    time.Time `gorm:"column:created_at;index"`
+}
+
+func (auditAuditLog0001) TableName() string { return "audit_logs" }
+
+type pluginInstalledPlugin0001 struct {
+	ID           uint      `gorm:"column:id;primaryKey"`
+	ClusterID    uint      `gorm:"column:cluster_id;index;not null"`
+	PluginName   string    `gorm:"column:plugin_name;size:100;not null;index"`
+	ArtifactID   string    `gorm:"column:artifact_id;size:100"`
+	Category     string    `gorm:"column:category;size:20;not null"`
+	Version      string    `gorm:"column:version;size:20;not null"`
+	Status       string    `gorm:"column:status;size:20;not null;default:installed"`
+	InstallPath  string    `gorm:"column:install_path;size:255"`
+	InstalledAt  time.Time `gorm:"column:installed_at;not null"`
+	UpdatedAt    time.Time `gorm:"column:updated_at"`
+	InstalledBy  uint      `gorm:"column:installed_by"`
+	Dependencies string    `gorm:"column:dependencies;type:text"`
+}
+
+func (pluginInstalledPlugin0001) TableName() string { return "installed_plugins" }
+
+type pluginPluginDependencyConfig0001 struct {
+	ID               uint      `gorm:"column:id;primaryKey"`
+	PluginName       string    `gorm:"column:plugin_name;size:100;not null;index:idx_plugin_dep,unique"`
+	SeatunnelVersion string    `gorm:"column:seatunnel_version;size:50;not null;default:'';index:idx_plugin_dep,unique"`
+	GroupID          string    `gorm:"column:group_id;size:200;not null;index:idx_plugin_dep,unique"`
+	ArtifactID       string    `gorm:"column:artifact_id;size:200;not null;index:idx_plugin_dep,unique"`
+	Version          string    `gorm:"column:version;size:80;not null;index:idx_plugin_dep,unique"`
+	TargetDir        string    `gorm:"column:target_dir;size:120;not null;default:lib;index:idx_plugin_dep,unique"`
+	SourceType       string    `gorm:"column:source_type;size:20;not null;default:maven;index:idx_plugin_dep,unique"`
+	OriginalFileName string    `gorm:"column:original_file_name;size:255"`
+	StoredPath       string    `gorm:"column:stored_path;size:1024"`
+	FileSize         int64     `gorm:"column:file_size;not null;default:0"`
+	Checksum         string    `gorm:"column:checksum;size:128"`
+	ResolvedFrom     string    `gorm:"column:resolved_from;size:400;not null;default:'';index"`
+	CreatedAt        time.Time `gorm:"column:created_at"`
+	UpdatedAt        time.Time `gorm:"column:updated_at"`
+}
+
+func (pluginPluginDependencyConfig0001) TableName() string { return "plugin_dependency_configs" }
+
+type pluginPluginDependencyDisable0001 struct {
+	ID               uint      `gorm:"column:id;primaryKey"`
+	PluginName       string    `gorm:"column:plugin_name;size:100;not null;index:idx_plugin_dep_disable,unique"`
+	SeatunnelVersion string    `gorm:"column:seatunnel_version;size:50;not null;default:'';index:idx_plugin_dep_disable,unique"`
+	GroupID          string    `gorm:"column:group_id;size:200;not null;index:idx_plugin_dep_disable,unique"`
+	ArtifactID       string    `gorm:"column:artifact_id;size:200;not null;index:idx_plugin_dep_disable,unique"`
+	Version          string    `gorm:"column:version;size:80;not null;index:idx_plugin_dep_disable,unique"`
+	TargetDir        string    `gorm:"column:target_dir;size:120;not null;index:idx_plugin_dep_disable,unique"`
+	CreatedAt        time.Time `gorm:"column:created_at"`
+	UpdatedAt        time.Time `gorm:"column:updated_at"`
+}
+
+func (pluginPluginDependencyDisable0001) TableName() string { return "plugin_dependency_disables" }
+
+type pluginPluginCatalogEntry0001 struct {
+	ID               uint      `gorm:"column:id;primaryKey"`
+	SeatunnelVersion string    `gorm:"column:seatunnel_version;size:50;not null;index:idx_plugin_catalog_version_name,unique"`
+	PluginName       string    `gorm:"column:plugin_name;size:120;not null;index:idx_plugin_catalog_version_name,unique"`
+	DisplayName      string    `gorm:"column:display_name;size:200;not null"`
+	ArtifactID       string    `gorm:"column:artifact_id;size:200;not null"`
+	GroupID          string    `gorm:"column:group_id;size:200;not null"`
+	Category         string    `gorm:"column:category;size:50;not null"`
+	Description      string    `gorm:"column:description;type:text"`
+	DocURL           string    `gorm:"column:doc_url;size:500"`
+	Source           string    `gorm:"column:source;size:20;not null;default:remote"`
+	SourceMirror     string    `gorm:"column:source_mirror;size:30"`
+	RefreshedAt      time.Time `gorm:"column:refreshed_at"`
+	CreatedAt        time.Time `gorm:"column:created_at"`
+	UpdatedAt        time.Time `gorm:"column:updated_at"`
+}
+
+func (pluginPluginCatalogEntry0001) TableName() string { return "plugin_catalog_entries" }
+
+type pluginPluginDependencyProfile0001 struct {
+	ID                       uint      `gorm:"column:id;primaryKey"`
+	SeatunnelVersion         string    `gorm:"column:seatunnel_version;size:50;not null;index:idx_plugin_dep_profile_unique,unique"`
+	PluginName               string    `gorm:"column:plugin_name;size:120;not null;index:idx_plugin_dep_profile_unique,unique;index"`
+	ArtifactID               string    `gorm:"column:artifact_id;size:200;not null"`
+	ProfileKey               string    `gorm:"column:profile_key;size:120;not null;default:default;index:idx_plugin_dep_profile_unique,unique"`
+	ProfileName              string    `gorm:"column:profile_name;size:200"`
+	EngineScope              string    `gorm:"column:engine_scope;size:50;not null;default:zeta;index:idx_plugin_dep_profile_unique,unique"`
+	SourceKind               string    `gorm:"column:source_kind;size:30;not null;index:idx_plugin_dep_profile_unique,unique"`
+	BaselineVersionUsed      string    `gorm:"column:baseline_version_used;size:50"`
+	ResolutionMode           string    `gorm:"column:resolution_mode;size:20;not null;default:exact"`
+	TargetDir                string    `gorm:"column:target_dir;size:120;not null;default:lib"`
+	AppliesTo                string    `gorm:"column:applies_to;size:255;not null;default:*"`
+	IncludeVersions          string    `gorm:"column:include_versions;size:500"`
+	ExcludedVersions         string    `gorm:"column:excluded_versions;size:500"`
+	DocSlug                  string    `gorm:"column:doc_slug;size:255"`
+	DocSourceURL             string    `gorm:"column:doc_source_url;size:500"`
+	Confidence               string    `gorm:"column:confidence;size:20;not null;default:medium"`
+	IsDefault                bool      `gorm:"column:is_default;not null"`
+	NoAdditionalDependencies bool      `gorm:"column:no_additional_dependencies;not null;default:false"`
+	ContentHash              string    `gorm:"column:content_hash;size:128"`
+	CreatedAt                time.Time `gorm:"column:created_at"`
+	UpdatedAt                time.Time `gorm:"column:updated_at"`
+}
+
+func (pluginPluginDependencyProfile0001) TableName() string { return "plugin_dependency_profiles" }
+
+type pluginPluginDependencyProfileItem0001 struct {
+	ID         uint      `gorm:"column:id;primaryKey"`
+	ProfileID  uint      `gorm:"column:profile_id;not null;index:idx_plugin_dep_profile_item_unique,unique"`
+	GroupID    string    `gorm:"column:group_id;size:200;not null;index:idx_plugin_dep_profile_item_unique,unique"`
+	ArtifactID string    `gorm:"column:artifact_id;size:200;not null;index:idx_plugin_dep_profile_item_unique,unique"`
+	Version    string    `gorm:"column:version;size:80;not null;index:idx_plugin_dep_profile_item_unique,unique"`
+	TargetDir  string    `gorm:"column:target_dir;size:120;not null;index:idx_plugin_dep_profile_item_unique,unique"`
+	Required   bool      `gorm:"column:required;not null;default:true"`
+	SourceURL  string    `gorm:"column:source_url;size:500"`
+	Note       string    `gorm:"column:note;type:text"`
+	CreatedAt  time.Time `gorm:"column:created_at"`
+	UpdatedAt  time.Time `gorm:"column:updated_at"`
+}
+
+func (pluginPluginDependencyProfileItem0001) TableName() string {
+	return "plugin_dependency_profile_items"
+}
+
+type configConfig0001 struct {
+	ID         uint      `gorm:"column:id;primaryKey;autoIncrement"`
+	ClusterID  uint      `gorm:"column:cluster_id;index;not null"`
+	HostID     uint      `gorm:"column:host_id;index"`
+	ConfigType string    `gorm:"column:config_type;size:50;not null"`
+	FilePath   string    `gorm:"column:file_path;size:255"`
+	Content    string    `gorm:"column:content;type:text"`
+	Version    int       `gorm:"column:version;default:1"`
+	UpdatedAt  time.Time `gorm:"column:updated_at"`
+	UpdatedBy  uint      `gorm:"column:updated_by"`
+	CreatedAt  time.Time `gorm:"column:created_at"`
+}
+
+func (configConfig0001) TableName() string { return "configs" }
+
+type configConfigVersion0001 struct {
+	ID        uint      `gorm:"column:id;primaryKey;autoIncrement"`
+	ConfigID  uint      `gorm:"column:config_id;index;not null"`
+	Version   int       `gorm:"column:version;not null"`
+	Content   string    `gorm:"column:content;type:text"`
+	Comment   string    `gorm:"column:comment;size:255"`
+	CreatedBy uint      `gorm:"column:created_by"`
+	CreatedAt time.Time `gorm:"column:created_at"`
+}
+
+func (configConfigVersion0001) TableName() string { return "config_versions" }
+
+type configConfigDrift0001 struct {
+	ID            uint      `gorm:"column:id;primaryKey;autoIncrement"`
+	ClusterID     uint      `gorm:"column:cluster_id;uniqueIndex:idx_config_drift_node;not null"`
+	HostID        uint      `gorm:"column:host_id;uniqueIndex:idx_config_drift_node;not null"`
+	ConfigType    string    `gorm:"column:config_type;uniqueIndex:idx_config_drift_node;size:50;not null"`
+	ConfigID      uint      `gorm:"column:config_id"`
+	StoredVersion int       `gorm:"column:stored_version"`
+	Drifted       bool      `gorm:"column:drifted"`
+	Error         string    `gorm:"column:error;size:1024"`
+	DetectedAt    time.Time `gorm:"column:detected_at"`
+	CheckedAt     time.Time `gorm:"column:checked_at;not null"`
+}
+
+func (configConfigDrift0001) TableName() string { return "config_drifts" }
+
+type monitorMonitorConfig0001 struct {
+	ID              uint      `gorm:"column:id;primaryKey;autoIncrement"`
+	ClusterID       uint      `gorm:"column:cluster_id;uniqueIndex;not null"`
+	AutoMonitor     bool      `gorm:"column:auto_monitor;default:true"`
+	AutoRestart     bool      `gorm:"column:auto_restart;default:true"`
+	MonitorInterval int       `gorm:"column:monitor_interval;default:5"`
+	RestartDelay    int       `gorm:"column:restart_delay;default:10"`
+	MaxRestarts     int       `gorm:"column:max_restarts;default:3"`
+	TimeWindow      int       `gorm:"column:time_window;default:300"`
+	CooldownPeriod  int       `gorm:"column:cooldown_period;default:1800"`
+	ConfigVersion   int       `gorm:"column:config_version;default:1"`
+	LastSyncAt      time.Time `gorm:"column:last_sync_at"`
+	CreatedAt       time.Time `gorm:"column:created_at"`
+	UpdatedAt       time.Time `gorm:"column:updated_at"`
+}
+
+func (monitorMonitorConfig0001) TableName() string { return "monitor_configs" }
+
+type monitorProcessEvent0001 struct {
+	ID          uint      `gorm:"column:id;primaryKey;autoIncrement"`
+	ClusterID   uint      `gorm:"column:cluster_id;index"`
+	NodeID      uint      `gorm:"column:node_id;index"`
+	HostID      uint      `gorm:"column:host_id;index"`
+	EventType   string    `gorm:"column:event_type;size:30;index"`
+	PID         int       `gorm:"column:p_id"`
+	ProcessName string    `gorm:"column:process_name;size:100"`
+	InstallDir  string    `gorm:"column:install_dir;size:255"`
+	Role        string    `gorm:"column:role;size:20"`
+	Details     string    `gorm:"column:details;type:text"`
+	CreatedAt   time.Time `gorm:"column:created_at;index"`
+}
+
+func (monitorProcessEvent0001) TableName() string { return "process_events" }
+
+type monitoringAlertRule0001 struct {
+	ID            uint      `gorm:"column:id;primaryKey;autoIncrement"`
+	ClusterID     uint      `gorm:"column:cluster_id;not null;index;uniqueIndex:ux_monitoring_cluster_rule"`
+	RuleKey       string    `gorm:"column:rule_key;size:80;not null;uniqueIndex:ux_monitoring_cluster_rule"`
+	RuleName      string    `gorm:"column:rule_name;size:120;not null"`
+	Description   string    `gorm:"column:description;type:text"`
+	Severity      string    `gorm:"column:severity;size:20;default:warning"`
+	Enabled       bool      `gorm:"column:enabled;default:true"`
+	Threshold     int       `gorm:"column:threshold;default:1"`
+	WindowSeconds int       `gorm:"column:window_seconds;default:300"`
+	CreatedAt     time.Time `gorm:"column:created_at"`
+	UpdatedAt     time.Time `gorm:"column:updated_at"`
+}
+
+func (monitoringAlertRule0001) TableName() string { return "monitoring_alert_rules" }
+
+type monitoringAlertPolicy0001 struct {
+	ID                         uint      `gorm:"column:id;primaryKey;autoIncrement"`
+	Name                       string    `gorm:"column:name;size:160;not null;index"`
+	Description                string    `gorm:"column:description;type:text"`
+	PolicyType                 string    `gorm:"column:policy_type;size:40;not null;index"`
+	TemplateKey                string    `gorm:"column:template_key;size:120;index"`
+	LegacyRuleKey              string    `gorm:"column:legacy_rule_key;size:120;index"`
+	ClusterID                  string    `gorm:"column:cluster_id;size:64;index"`
+	Severity                   string    `gorm:"column:severity;size:20;default:warning"`
+	Enabled                    bool      `gorm:"column:enabled;default:true"`
+	CooldownMinutes            int       `gorm:"column:cooldown_minutes;default:0"`
+	SendRecovery               bool      `gorm:"column:send_recovery;default:true"`
+	PromQL                     string    `gorm:"column:prom_ql;type:text"`
+	ConditionsJSON             string    `gorm:"column:conditions_json;type:text"`
+	NotificationChannelIDsJSON string    `gorm:"column:notification_channel_ids_json;type:text"`
+	ReceiverUserIDsJSON        string    `gorm:"column:receiver_user_ids_json;type:text"`
+	MatchCount                 int       `gorm:"column:match_count;default:0"`
+	DeliveryCount              int       `gorm:"column:delivery_count;default:0"`
+	LastMatchedAt              time.Time `gorm:"column:last_matched_at"`
+	LastDeliveredAt            time.Time `gorm:"column:last_delivered_at"`
+	LastExecutionStatus        string    `gorm:"column:last_execution_status;size:20;default:idle"`
+	LastExecutionError         string    `gorm:"column:last_execution_error;type:text"`
+	CreatedAt                  time.Time `gorm:"column:created_at"`
+	UpdatedAt                  time.Time `gorm:"column:updated_at"`
+}
+
+func (monitoringAlertPolicy0001) TableName() string { return "monitoring_alert_policies" }
+
+type monitoringAlertEventState0001 struct {
+	ID             uint      `gorm:"column:id;primaryKey;autoIncrement"`
+	EventID        uint      `gorm:"column:event_id;not null;uniqueIndex"`
+	ClusterID      uint      `gorm:"column:cluster_id;not null;index"`
+	Status         string    `gorm:"column:status;size:20;not null;index"`
+	AcknowledgedBy string    `gorm:"column:acknowledged_by;size:100"`
+	AcknowledgedAt time.Time `gorm:"column:acknowledged_at"`
+	SilencedBy     string    `gorm:"column:silenced_by;size:100"`
+	SilencedUntil  time.Time `gorm:"column:silenced_until;index"`
+	Note           string    `gorm:"column:note;type:text"`
+	CreatedAt      time.Time `gorm:"column:created_at"`
+	UpdatedAt      time.Time `gorm:"column:updated_at"`
+}
+
+func (monitoringAlertEventState0001) TableName() string { return "monitoring_alert_event_states" }
+
+type monitoringAlertState0001 struct {
+	ID             uint      `gorm:"column:id;primaryKey;autoIncrement"`
+	SourceType     string    `gorm:"column:source_type;size:40;not null;index"`
+	SourceKey      string    `gorm:"column:source_key;size:255;not null;uniqueIndex"`
+	ClusterID      string    `gorm:"column:cluster_id;size:64;index"`
+	HandlingStatus string    `gorm:"column:handling_status;size:20;not null;index"`
+	AcknowledgedBy string    `gorm:"column:acknowledged_by;size:100"`
+	AcknowledgedAt time.Time `gorm:"column:acknowledged_at"`
+	SilencedBy     string    `gorm:"column:silenced_by;size:100"`
+	SilencedUntil  time.Time `gorm:"column:silenced_until;index"`
+	ClosedBy       string    `gorm:"column:closed_by;size:100"`
+	ClosedAt       time.Time `gorm:"column:closed_at;index"`
+	Note           string    `gorm:"column:note;type:text"`
+	CreatedAt      time.Time `gorm:"column:created_at"`
+	UpdatedAt      time.Time `gorm:"column:updated_at"`
+}
+
+func (monitoringAlertState0001) TableName() string { return "monitoring_alert_states" }
+
+type monitoringNotificationChannel0001 struct {
+	ID          uint      `gorm:"column:id;primaryKey;autoIncrement"`
+	Name        string    `gorm:"column:name;size:120;not null;index"`
+	Type        string    `gorm:"column:type;size:30;not null;index"`
+	Enabled     bool      `gorm:"column:enabled"`
+	Endpoint    string    `gorm:"column:endpoint;size:500"`
+	Secret      string    `gorm:"column:secret;size:500"`
+	ConfigJSON  string    `gorm:"column:config_json;type:text"`
+	Description string    `gorm:"column:description;type:text"`
+	CreatedAt   time.Time `gorm:"column:created_at"`
+	UpdatedAt   time.Time `gorm:"column:updated_at"`
+}
+
+func (monitoringNotificationChannel0001) TableName() string {
+	return "monitoring_notification_channels"
+}
+
+type monitoringNotificationRoute0001 struct {
+	ID                 uint      `gorm:"column:id;primaryKey;autoIncrement"`
+	Name               string    `gorm:"column:name;size:120;not null;index"`
+	Enabled            bool      `gorm:"column:enabled"`
+	SourceType         string    `gorm:"column:source_type;size:40;index"`
+	ClusterID          string    `gorm:"column:cluster_id;size:64;index"`
+	Severity           string    `gorm:"column:severity;size:20;index"`
+	RuleKey            string    `gorm:"column:rule_key;size:80;index"`
+	ChannelID          uint      `gorm:"column:channel_id;not null;index"`
+	SendResolved       bool      `gorm:"column:send_resolved"`
+	MuteIfAcknowledged bool      `gorm:"column:mute_if_acknowledged"`
+	MuteIfSilenced     bool      `gorm:"column:mute_if_silenced"`
+	CreatedAt          time.Time `gorm:"column:created_at"`
+	UpdatedAt          time.Time `gorm:"column:updated_at"`
+}
+
+func (monitoringNotificationRoute0001) TableName() string { return "monitoring_notification_routes" }
+
+type monitoringNotificationDelivery0001 struct {
+	ID                  uint      `gorm:"column:id;primaryKey;autoIncrement"`
+	AlertID             string    `gorm:"column:alert_id;size:255;index"`
+	SourceType          string    `gorm:"column:source_type;size:40;index"`
+	SourceKey           string    `gorm:"column:source_key;size:255;index;uniqueIndex:ux_monitoring_delivery_source_channel_event,priority:1"`
+	PolicyID            uint      `gorm:"column:policy_id;index"`
+	ClusterID           string    `gorm:"column:cluster_id;size:64;index"`
+	ClusterName         string    `gorm:"column:cluster_name;size:255;index"`
+	AlertName           string    `gorm:"column:alert_name;size:255;index"`
+	ChannelID           uint      `gorm:"column:channel_id;not null;index;uniqueIndex:ux_monitoring_delivery_source_channel_event,priority:2"`
+	ChannelName         string    `gorm:"column:channel_name;size:120"`
+	EventType           string    `gorm:"column:event_type;size:20;not null;index;uniqueIndex:ux_monitoring_delivery_source_channel_event,priority:3"`
+	Status              string    `gorm:"column:status;size:20;not null;index"`
+	AttemptCount        int       `gorm:"column:attempt_count;default:0"`
+	LastError           string    `gorm:"column:last_error;type:text"`
+	RequestPayload      string    `gorm:"column:request_payload;type:text"`
+	ResponseStatusCode  int       `gorm:"column:response_status_code"`
+	ResponseBodyExcerpt string    `gorm:"column:response_body_excerpt;type:text"`
+	SentAt              time.Time `gorm:"column:sent_at"`
+	CreatedAt           time.Time `gorm:"column:created_at"`
+	UpdatedAt           time.Time `gorm:"column:updated_at"`
+}
+
+func (monitoringNotificationDelivery0001) TableName() string {
+	return "monitoring_notification_deliveries"
+}
+
+type monitoringRemoteAlertRecord0001 struct {
+	ID              uint      `gorm:"column:id;primaryKey;autoIncrement"`
+	Fingerprint     string    `gorm:"column:fingerprint;size:255;not null;index;uniqueIndex:ux_monitoring_remote_alert"`
+	StartsAt        int64     `gorm:"column:starts_at;not null;index;uniqueIndex:ux_monitoring_remote_alert"`
+	Status          string    `gorm:"column:status;size:32;index"`
+	Receiver        string    `gorm:"column:receiver;size:200"`
+	AlertName       string    `gorm:"column:alert_name;size:255;index"`
+	Severity        string    `gorm:"column:severity;size:64;index"`
+	ClusterID       string    `gorm:"column:cluster_id;size:64;index"`
+	ClusterName     string    `gorm:"column:cluster_name;size:255;index"`
+	Env             string    `gorm:"column:env;size:64;index"`
+	GeneratorURL    string    `gorm:"column:generator_url;size:1024"`
+	Summary         string    `gorm:"column:summary;type:text"`
+	Description     string    `gorm:"column:description;type:text"`
+	LabelsJSON      string    `gorm:"column:labels_json;type:text"`
+	AnnotationsJSON string    `gorm:"column:annotations_json;type:text"`
+	EndsAt          int64     `gorm:"column:ends_at"`
+	ResolvedAt      time.Time `gorm:"column:resolved_at"`
+	LastReceivedAt  time.Time `gorm:"column:last_received_at;index"`
+	CreatedAt       time.Time `gorm:"column:created_at"`
+	UpdatedAt       time.Time `gorm:"column:updated_at"`
+}
+
+func (monitoringRemoteAlertRecord0001) TableName() string { return "monitoring_remote_alerts" }
+
+type diagnosticsSeatunnelErrorGroup0001 struct {
+	ID                 uint      `gorm:"column:id;primaryKey;autoIncrement"`
+	Fingerprint        string    `gorm:"column:fingerprint;size:64;uniqueIndex;not null"`
+	FingerprintVersion string    `gorm:"column:fingerprint_version;size:20;not null"`
+	Title              string    `gorm:"column:title;type:text"`
+	ExceptionClass     string    `gorm:"column:exception_class;size:255;index"`
+	NormalizedText     string    `gorm:"column:normalized_text;type:text"`
+	SampleMessage      string    `gorm:"column:sample_message;type:text"`
+	SampleEvidence     string    `gorm:"column:sample_evidence;type:text"`
+	OccurrenceCount    int64     `gorm:"column:occurrence_count;default:0"`
+	FirstSeenAt        time.Time `gorm:"column:first_seen_at;index"`
+	LastSeenAt         time.Time `gorm:"column:last_seen_at;index"`
+	LastClusterID      uint      `gorm:"column:last_cluster_id;index"`
+	LastNodeID         uint      `gorm:"column:last_node_id;index"`
+	LastHostID         uint      `gorm:"column:last_host_id;index"`
+	CreatedAt          time.Time `gorm:"column:created_at"`
+	UpdatedAt          time.Time `gorm:"column:updated_at"`
+}
+
+func (diagnosticsSeatunnelErrorGroup0001) TableName() string { return "diagnostics_error_groups" }
+
+type diagnosticsSeatunnelErrorEvent0001 struct {
+	ID             uint      `gorm:"column:id;primaryKey;autoIncrement"`
+	ErrorGroupID   uint      `gorm:"column:error_group_id;index;not null"`
+	Fingerprint    string    `gorm:"column:fingerprint;size:64;index;not null"`
+	ClusterID      uint      `gorm:"column:cluster_id;index"`
+	NodeID         uint      `gorm:"column:node_id;index"`
+	HostID         uint      `gorm:"column:host_id;index"`
+	AgentID        string    `gorm:"column:agent_id;size:100;index;not null"`
+	Role           string    `gorm:"column:role;size:20;index"`
+	InstallDir     string    `gorm:"column:install_dir;size:255"`
+	SourceFile     string    `gorm:"column:source_file;size:500;index"`
+	SourceKind     string    `gorm:"column:source_kind;size:20;index"`
+	JobID          string    `gorm:"column:job_id;size:100;index"`
+	OccurredAt     time.Time `gorm:"column:occurred_at;index"`
+	Message        string    `gorm:"column:message;type:text"`
+	ExceptionClass string    `gorm:"column:exception_class;size:255;index"`
+	NormalizedText string    `gorm:"column:normalized_text;type:text"`
+	Evidence       string    `gorm:"column:evidence;type:text"`
+	CursorStart    int64     `gorm:"column:cursor_start"`
+	CursorEnd      int64     `gorm:"column:cursor_end"`
+	CreatedAt      time.Time `gorm:"column:created_at;index"`
+}
+
+func (diagnosticsSeatunnelErrorEvent0001) TableName() string { return "diagnostics_error_events" }
+
+type diagnosticsSeatunnelLogCursor0001 struct {
+	ID             uint      `gorm:"column:id;primaryKey;autoIncrement"`
+	AgentID        string    `gorm:"column:agent_id;size:100;not null;uniqueIndex:idx_diag_log_cursor"`
+	HostID         uint      `gorm:"column:host_id;index;uniqueIndex:idx_diag_log_cursor"`
+	ClusterID      uint      `gorm:"column:cluster_id;index"`
+	NodeID         uint      `gorm:"column:node_id;index"`
+	InstallDir     string    `gorm:"column:install_dir;size:255;not null;uniqueIndex:idx_diag_log_cursor"`
+	Role           string    `gorm:"column:role;size:20;not null;uniqueIndex:idx_diag_log_cursor"`
+	SourceFile     string    `gorm:"column:source_file;size:500;not null;uniqueIndex:idx_diag_log_cursor"`
+	CursorOffset   int64     `gorm:"column:cursor_offset"`
+	LastOccurredAt time.Time `gorm:"column:last_occurred_at"`
+	CreatedAt      time.Time `gorm:"column:created_at"`
+	UpdatedAt      time.Time `gorm:"column:updated_at"`
+}
+
+func (diagnosticsSeatunnelLogCursor0001) TableName() string { return "diagnostics_log_cursors" }
+
+type diagnosticsClusterInspectionReport0001 struct {
+	ID                uint      `gorm:"column:id;primaryKey;autoIncrement"`
+	ClusterID         uint      `gorm:"column:cluster_id;index;not null"`
+	Status            string    `gorm:"column:status;size:20;index;not null"`
+	TriggerSource     string    `gorm:"column:trigger_source;size:32;index;not null"`
+	LookbackMinutes   int       `gorm:"column:lookback_minutes;default:30"`
+	ErrorThreshold    int       `gorm:"column:error_threshold;default:1"`
+	RequestedByUserID uint      `gorm:"column:requested_by_user_id;index"`
+	RequestedBy       string    `gorm:"column:requested_by;size:120;index"`
+	Summary           string    `gorm:"column:summary;type:text"`
+	ErrorMessage      string    `gorm:"column:error_message;type:text"`
+	FindingTotal      int       `gorm:"column:finding_total;default:0"`
+	CriticalCount     int       `gorm:"column:critical_count;default:0"`
+	WarningCount      int       `gorm:"column:warning_count;default:0"`
+	InfoCount         int       `gorm:"column:info_count;default:0"`
+	AutoTriggerReason string    `gorm:"column:auto_trigger_reason;size:200"`
+	StartedAt         time.Time `gorm:"column:started_at;index"`
+	FinishedAt        time.Time `gorm:"column:finished_at;index"`
+	CreatedAt         time.Time `gorm:"column:created_at;index"`
+	UpdatedAt         time.Time `gorm:"column:updated_at"`
+}
+
+func (diagnosticsClusterInspectionReport0001) TableName() string {
+	return "diagnostics_inspection_reports"
+}
+
+type diagnosticsClusterInspectionFinding0001 struct {
+	ID                  uint      `gorm:"column:id;primaryKey;autoIncrement"`
+	ReportID            uint      `gorm:"column:report_id;index;not null"`
+	ClusterID           uint      `gorm:"column:cluster_id;index;not null"`
+	Severity            string    `gorm:"column:severity;size:20;index;not null"`
+	Category            string    `gorm:"column:category;size:64;index;not null"`
+	CheckCode           string    `gorm:"column:check_code;size:100;index;not null"`
+	CheckName           string    `gorm:"column:check_name;size:200"`
+	Summary             string    `gorm:"column:summary;type:text"`
+	Recommendation      string    `gorm:"column:recommendation;type:text"`
+	EvidenceSummary     string    `gorm:"column:evidence_summary;type:text"`
+	RelatedNodeID       uint      `gorm:"column:related_node_id;index"`
+	RelatedHostID       uint      `gorm:"column:related_host_id;index"`
+	RelatedErrorGroupID uint      `gorm:"column:related_error_group_id;index"`
+	RelatedAlertID      string    `gorm:"column:related_alert_id;size:120;index"`
+	CreatedAt           time.Time `gorm:"column:created_at;index"`
+	UpdatedAt           time.Time `gorm:"column:updated_at"`
+}
+
+func (diagnosticsClusterInspectionFinding0001) TableName() string {
+	return "diagnostics_inspection_findings"
+}
+
+type diagnosticsDiagnosticTask0001 struct {
+	ID              uint      `gorm:"column:id;primaryKey;autoIncrement"`
+	ClusterID       uint      `gorm:"column:cluster_id;index;not null"`
+	TriggerSource   string    `gorm:"column:trigger_source;size:40;index;not null"`
+	SourceRef       string    `gorm:"column:source_ref;type:json;not null"`
+	Options         string    `gorm:"column:options;type:json;not null"`
+	LookbackMinutes int       `gorm:"column:lookback_minutes;default:0"`
+	Status          string    `gorm:"column:status;size:32;index;not null"`
+	CurrentStep     string    `gorm:"column:current_step;size:64;index"`
+	FailureStep     string    `gorm:"column:failure_step;size:64"`
+	FailureReason   string    `gorm:"column:failure_reason;type:text"`
+	SelectedNodes   string    `gorm:"column:selected_nodes;type:json;not null"`
+	Summary         string    `gorm:"column:summary;type:text"`
+	BundleDir       string    `gorm:"column:bundle_dir;size:500"`
+	ManifestPath    string    `gorm:"column:manifest_path;size:500"`
+	IndexPath       string    `gorm:"column:index_path;size:500"`
+	StartedAt       time.Time `gorm:"column:started_at"`
+	CompletedAt     time.Time `gorm:"column:completed_at"`
+	CreatedBy       uint      `gorm:"column:created_by"`
+	CreatedByName   string    `gorm:"column:created_by_name;size:120;index"`
+	CreatedAt       time.Time `gorm:"column:created_at;index"`
+	UpdatedAt       time.Time `gorm:"column:updated_at"`
+}
+
+func (diagnosticsDiagnosticTask0001) TableName() string { return "diagnostics_tasks" }
+
+type diagnosticsDiagnosticTaskStep0001 struct {
+	ID          uint      `gorm:"column:id;primaryKey;autoIncrement"`
+	TaskID      uint      `gorm:"column:task_id;index;not null;uniqueIndex:idx_diagnostics_task_step_code"`
+	Code        string    `gorm:"column:code;size:64;not null;uniqueIndex:idx_diagnostics_task_step_code"`
+	Sequence    int       `gorm:"column:sequence;not null;index"`
+	Title       string    `gorm:"column:title;size:120"`
+	Description string    `gorm:"column:description;type:text"`
+	Status      string    `gorm:"column:status;size:32;index;not null"`
+	Message     string    `gorm:"column:message;type:text"`
+	Error       string    `gorm:"column:error;type:text"`
+	StartedAt   time.Time `gorm:"column:started_at"`
+	CompletedAt time.Time `gorm:"column:completed_at"`
+	CreatedAt   time.Time `gorm:"column:created_at"`
+	UpdatedAt   time.Time `gorm:"column:updated_at"`
+}
+
+func (diagnosticsDiagnosticTaskStep0001) TableName() string { return "diagnostics_task_steps" }
+
+type diagnosticsDiagnosticNodeExecution0001 struct {
+	ID            uint      `gorm:"column:id;primaryKey;autoIncrement"`
+	TaskID        uint      `gorm:"column:task_id;index;not null;uniqueIndex:idx_diagnostics_task_node_scope"`
+	TaskStepID    uint      `gorm:"column:task_step_id;index"`
+	ClusterNodeID uint      `gorm:"column:cluster_node_id;index"`
+	NodeID        uint      `gorm:"column:node_id;index"`
+	HostID        uint      `gorm:"column:host_id;index;not null;uniqueIndex:idx_diagnostics_task_node_scope"`
+	HostName      string    `gorm:"column:host_name;size:100"`
+	HostIP        string    `gorm:"column:host_ip;size:64"`
+	Role          string    `gorm:"column:role;size:32;not null;uniqueIndex:idx_diagnostics_task_node_scope"`
+	AgentID       string    `gorm:"column:agent_id;size:120;index"`
+	InstallDir    string    `gorm:"column:install_dir;size:255"`
+	Status        string    `gorm:"column:status;size:32;index;not null"`
+	CurrentStep   string    `gorm:"column:current_step;size:64;index"`
+	Message       string    `gorm:"column:message;type:text"`
+	Error         string    `gorm:"column:error;type:text"`
+	StartedAt     time.Time `gorm:"column:started_at"`
+	CompletedAt   time.Time `gorm:"column:completed_at"`
+	CreatedAt     time.Time `gorm:"column:created_at"`
+	UpdatedAt     time.Time `gorm:"column:updated_at"`
+}
+
+func (diagnosticsDiagnosticNodeExecution0001) TableName() string { return "diagnostics_task_nodes" }
+
+type diagnosticsDiagnosticStepLog0001 struct {
+	ID              uint      `gorm:"column:id;primaryKey;autoIncrement"`
+	TaskID          uint      `gorm:"column:task_id;index;not null"`
+	TaskStepID      uint      `gorm:"column:task_step_id;index"`
+	NodeExecutionID uint      `gorm:"column:node_execution_id;index"`
+	StepCode        string    `gorm:"column:step_code;size:64;index"`
+	Level           string    `gorm:"column:level;size:10;index;not null"`
+	EventType       string    `gorm:"column:event_type;size:20;index;not null"`
+	Message         string    `gorm:"column:message;type:text"`
+	CommandSummary  string    `gorm:"column:command_summary;type:text"`
+	Metadata        string    `gorm:"column:metadata;type:json"`
+	CreatedAt       time.Time `gorm:"column:created_at;index"`
+}
+
+func (diagnosticsDiagnosticStepLog0001) TableName() string { return "diagnostics_task_logs" }
+
+type diagnosticsInspectionAutoPolicy0001 struct {
+	ID              uint      `gorm:"column:id;primaryKey;autoIncrement"`
+	ClusterID       uint      `gorm:"column:cluster_id;index;not null;default:0"`
+	Name            string    `gorm:"column:name;size:200;not null"`
+	Enabled         bool      `gorm:"column:enabled;not null;default:true"`
+	Conditions      string    `gorm:"column:conditions;type:json;not null"`
+	CooldownMinutes int       `gorm:"column:cooldown_minutes;not null;default:30"`
+	AutoCreateTask  bool      `gorm:"column:auto_create_task;not null;default:false"`
+	AutoStartTask   bool      `gorm:"column:auto_start_task;not null;default:true"`
+	TaskOptions     string    `gorm:"column:task_options;type:json;not null"`
+	CreatedAt       time.Time `gorm:"column:created_at;index"`
+	UpdatedAt       time.Time `gorm:"column:updated_at"`
+}
+
+func (diagnosticsInspectionAutoPolicy0001) TableName() string {
+	return "diagnostics_inspection_auto_policies"
+}
+
+type stupgradeUpgradePlanRecord0001 struct {
+	ID                 uint      `gorm:"column:id;primaryKey;autoIncrement"`
+	ClusterID          uint      `gorm:"column:cluster_id;index;not null"`
+	SourceVersion      string    `gorm:"column:source_version;size:50;not null"`
+	TargetVersion      string    `gorm:"column:target_version;size:50;not null;index"`
+	Status             string    `gorm:"column:status;size:20;not null;index"`
+	BlockingIssueCount int       `gorm:"column:blocking_issue_count;default:0"`
+	Snapshot           string    `gorm:"column:snapshot;type:json;not null"`
+	CreatedBy          uint      `gorm:"column:created_by"`
+	CreatedAt          time.Time `gorm:"column:created_at"`
+	UpdatedAt          time.Time `gorm:"column:updated_at"`
+}
+
+func (stupgradeUpgradePlanRecord0001) TableName() string { return "st_upgrade_plans" }
+
+type stupgradeUpgradeTask0001 struct {
+	ID             uint      `gorm:"column:id;primaryKey;autoIncrement"`
+	ClusterID      uint      `gorm:"column:cluster_id;index;not null"`
+	PlanID         uint      `gorm:"column:plan_id;index;not null"`
+	SourceVersion  string    `gorm:"column:source_version;size:50;not null"`
+	TargetVersion  string    `gorm:"column:target_version;size:50;not null;index"`
+	Status         string    `gorm:"column:status;size:32;not null;index"`
+	CurrentStep    string    `gorm:"column:current_step;size:64;index"`
+	FailureStep    string    `gorm:"column:failure_step;size:64"`
+	FailureReason  string    `gorm:"column:failure_reason;type:text"`
+	RollbackStatus string    `gorm:"column:rollback_status;size:32;index"`
+	RollbackReason string    `gorm:"column:rollback_reason;type:text"`
+	StartedAt      time.Time `gorm:"column:started_at"`
+	CompletedAt    time.Time `gorm:"column:completed_at"`
+	CreatedBy      uint      `gorm:"column:created_by"`
+	CreatedAt      time.Time `gorm:"column:created_at"`
+	UpdatedAt      time.Time `gorm:"column:updated_at"`
+}
+
+func (stupgradeUpgradeTask0001) TableName() string { return "st_upgrade_tasks" }
+
+type stupgradeUpgradeTaskStep0001 struct {
+	ID          uint      `gorm:"column:id;primaryKey;autoIncrement"`
+	TaskID      uint      `gorm:"column:task_id;index;not null;uniqueIndex:idx_st_upgrade_task_step_code"`
+	Code        string    `gorm:"column:code;size:64;not null;uniqueIndex:idx_st_upgrade_task_step_code"`
+	Sequence    int       `gorm:"column:sequence;not null;index"`
+	Status      string    `gorm:"column:status;size:32;not null;index"`
+	Message     string    `gorm:"column:message;type:text"`
+	Error       string    `gorm:"column:error;type:text"`
+	RetryCount  int       `gorm:"column:retry_count;default:0"`
+	StartedAt   time.Time `gorm:"column:started_at"`
+	CompletedAt time.Time `gorm:"column:completed_at"`
+	CreatedAt   time.Time `gorm:"column:created_at"`
+	UpdatedAt   time.Time `gorm:"column:updated_at"`
+}
+
+func (stupgradeUpgradeTaskStep0001) TableName() string { return "st_upgrade_task_steps" }
+
+type stupgradeUpgradeNodeExecution0001 struct {
+	ID               uint      `gorm:"column:id;primaryKey;autoIncrement"`
+	TaskID           uint      `gorm:"column:task_id;index;not null;uniqueIndex:idx_st_upgrade_task_node_scope"`
+	TaskStepID       uint      `gorm:"column:task_step_id;index"`
+	ClusterNodeID    uint      `gorm:"column:cluster_node_id;index"`
+	HostID           uint      `gorm:"column:host_id;index;not null;uniqueIndex:idx_st_upgrade_task_node_scope"`
+	HostName         string    `gorm:"column:host_name;size:100"`
+	HostIP           string    `gorm:"column:host_ip;size:64"`
+	Role             string    `gorm:"column:role;size:32;not null;uniqueIndex:idx_st_upgrade_task_node_scope"`
+	Status           string    `gorm:"column:status;size:32;not null;index"`
+	CurrentStep      string    `gorm:"column:current_step;size:64;index"`
+	SourceVersion    string    `gorm:"column:source_version;size:50;not null"`
+	TargetVersion    string    `gorm:"column:target_version;size:50;not null"`
+	SourceInstallDir string    `gorm:"column:source_install_dir;size:255"`
+	TargetInstallDir string    `gorm:"column:target_install_dir;size:255"`
+	Message          string    `gorm:"column:message;type:text"`
+	Error            string    `gorm:"column:error;type:text"`
+	StartedAt        time.Time `gorm:"column:started_at"`
+	CompletedAt      time.Time `gorm:"column:completed_at"`
+	CreatedAt        time.Time `gorm:"column:created_at"`
+	UpdatedAt        time.Time `gorm:"column:updated_at"`
+}
+
+func (stupgradeUpgradeNodeExecution0001) TableName() string { return "st_upgrade_task_nodes" }
+
+type stupgradeUpgradeStepLog0001 struct {
+	ID              uint      `gorm:"column:id;primaryKey;autoIncrement"`
+	TaskID          uint      `gorm:"column:task_id;index;not null"`
+	TaskStepID      uint      `gorm:"column:task_step_id;index"`
+	NodeExecutionID uint      `gorm:"column:node_execution_id;index"`
+	StepCode        string    `gorm:"column:step_code;size:64;index"`
+	Level           string    `gorm:"column:level;size:10;not null;index"`
+	EventType       string    `gorm:"column:event_type;size:20;not null;index"`
+	Message         string    `gorm:"column:message;type:text"`
+	CommandSummary  string    `gorm:"column:command_summary;type:text"`
+	ExitCode        int       `gorm:"column:exit_code"`
+	Metadata        string    `gorm:"column:metadata;type:json"`
+	CreatedAt       time.Time `gorm:"column:created_at;index"`
+}
+
+func (stupgradeUpgradeStepLog0001) TableName() string { return "st_upgrade_step_logs" }
+
+type syncTask0001 struct {
+	ID             uint      `gorm:"column:id;primaryKey;autoIncrement"`
+	ParentID       uint      `gorm:"column:parent_id;index"`
+	NodeType       string    `gorm:"column:node_type;size:20;not null;default:file;index"`
+	Name           string    `gorm:"column:name;size:120;not null;index"`
+	Description    string    `gorm:"column:description;type:text"`
+	ClusterID      uint      `gorm:"column:cluster_id;index"`
+	EngineVersion  string    `gorm:"column:engine_version;size:50"`
+	Mode           string    `gorm:"column:mode;size:20;default:streaming"`
+	Status         string    `gorm:"column:status;size:20;default:draft;index"`
+	ContentFormat  string    `gorm:"column:content_format;size:20;not null;default:hocon"`
+	Content        string    `gorm:"column:content;type:longtext"`
+	JobName        string    `gorm:"column:job_name;size:255"`
+	Definition     string    `gorm:"column:definition;type:json"`
+	SortOrder      int       `gorm:"column:sort_order;default:0;index"`
+	CurrentVersion int       `gorm:"column:current_version;default:0"`
+	CreatedBy      uint      `gorm:"column:created_by"`
+	CreatedAt      time.Time `gorm:"column:created_at"`
+	UpdatedAt      time.Time `gorm:"column:updated_at"`
+}
+
+func (syncTask0001) TableName() string { return "sync_tasks" }
+
+type syncTaskVersion0001 struct {
+	ID                    uint      `gorm:"column:id;primaryKey;autoIncrement"`
+	TaskID                uint      `gorm:"column:task_id;index;not null"`
+	Version               int       `gorm:"column:version;not null"`
+	NameSnapshot          string    `gorm:"column:name_snapshot;size:120"`
+	DescriptionSnapshot   string    `gorm:"column:description_snapshot;type:text"`
+	ClusterIDSnapshot     uint      `gorm:"column:cluster_id_snapshot"`
+	EngineVersionSnapshot string    `gorm:"column:engine_version_snapshot;size:50"`
+	ModeSnapshot          string    `gorm:"column:mode_snapshot;size:20"`
+	ContentFormatSnapshot string    `gorm:"column:content_format_snapshot;size:20"`
+	ContentSnapshot       string    `gorm:"column:content_snapshot;type:longtext"`
+	JobNameSnapshot       string    `gorm:"column:job_name_snapshot;size:255"`
+	DefinitionSnapshot    string    `gorm:"column:definition_snapshot;type:json"`
+	Comment               string    `gorm:"column:comment;size:255"`
+	CreatedBy             uint      `gorm:"column:created_by"`
+	CreatedAt             time.Time `gorm:"column:created_at"`
+}
+
+func (syncTaskVersion0001) TableName() string { return "sync_task_versions" }
+
+type syncJobInstance0001 struct {
+	ID                      uint      `gorm:"column:id;primaryKey;autoIncrement"`
+	TaskID                  uint      `gorm:"column:task_id;index;not null"`
+	TaskVersion             int       `gorm:"column:task_version;not null"`
+	RunType                 string    `gorm:"column:run_type;size:20;not null;index"`
+	PlatformJobID           string    `gorm:"column:platform_job_id;size:32;index"`
+	EngineJobID             string    `gorm:"column:engine_job_id;size:255;index"`
+	RecoveredFromInstanceID uint      `gorm:"column:recovered_from_instance_id;index"`
+	Status                  string    `gorm:"column:status;size:20;default:pending;index"`
+	SubmitSpec              string    `gorm:"column:submit_spec;type:json"`
+	ResultPreview           string    `gorm:"column:result_preview;type:json"`
+	ErrorMessage            string    `gorm:"column:error_message;type:text"`
+	StartedAt               time.Time `gorm:"column:started_at"`
+	FinishedAt              time.Time `gorm:"column:finished_at"`
+	CreatedBy               uint      `gorm:"column:created_by"`
+	CreatedAt               time.Time `gorm:"column:created_at"`
+	UpdatedAt               time.Time `gorm:"column:updated_at"`
+}
+
+func (syncJobInstance0001) TableName() string { return "sync_job_instances" }
+
+type syncGlobalVariable0001 struct {
+	ID          uint      `gorm:"column:id;primaryKey;autoIncrement"`
+	Key         string    `gorm:"column:key;size:120;not null;uniqueIndex"`
+	Value       string    `gorm:"column:value;type:text"`
+	Description string    `gorm:"column:description;type:text"`
+	CreatedBy   uint      `gorm:"column:created_by"`
+	CreatedAt   time.Time `gorm:"column:created_at"`
+	UpdatedAt   time.Time `gorm:"column:updated_at"`
+}
+
+func (syncGlobalVariable0001) TableName() string { return "sync_global_variables" }
+
+type syncPreviewSession0001 struct {
+	ID             uint      `gorm:"column:id;primaryKey;autoIncrement"`
+	JobInstanceID  uint      `gorm:"column:job_instance_id;uniqueIndex;not null"`
+	TaskID         uint      `gorm:"column:task_id;index;not null"`
+	PlatformJobID  string    `gorm:"column:platform_job_id;size:32;index"`
+	EngineJobID    string    `gorm:"column:engine_job_id;size:255;index"`
+	RowLimit       int       `gorm:"column:row_limit;not null;default:100"`
+	TimeoutMinutes int       `gorm:"column:timeout_minutes;not null;default:10"`
+	Status         string    `gorm:"column:status;size:32;not null;default:collecting;index"`
+	TotalRows      int       `gorm:"column:total_rows;not null;default:0"`
+	TableCount     int       `gorm:"column:table_count;not null;default:0"`
+	Truncated      bool      `gorm:"column:truncated;not null;default:false"`
+	LastError      string    `gorm:"column:last_error;type:text"`
+	StartedAt      time.Time `gorm:"column:started_at"`
+	FinishedAt     time.Time `gorm:"column:finished_at"`
+	CreatedAt      time.Time `gorm:"column:created_at"`
+	UpdatedAt      time.Time `gorm:"column:updated_at"`
+}
+
+func (syncPreviewSession0001) TableName() string { return "sync_preview_sessions" }
+
+type syncPreviewTable0001 struct {
+	ID          uint      `gorm:"column:id;primaryKey;autoIncrement"`
+	SessionID   uint      `gorm:"column:session_id;index;not null"`
+	TablePath   string    `gorm:"column:table_path;size:512;index;not null"`
+	DisplayName string    `gorm:"column:display_name;size:512"`
+	Columns     string    `gorm:"column:columns;type:json"`
+	RowCount    int       `gorm:"column:row_count;not null;default:0"`
+	CreatedAt   time.Time `gorm:"column:created_at"`
+	UpdatedAt   time.Time `gorm:"column:updated_at"`
+}
+
+func (syncPreviewTable0001) TableName() string { return "sync_preview_tables" }
+
+type syncPreviewRow0001 struct {
+	ID        uint      `gorm:"column:id;primaryKey;autoIncrement"`
+	SessionID uint      `gorm:"column:session_id;index;not null"`
+	TableID   uint      `gorm:"column:table_id;index;not null"`
+	RowIndex  int       `gorm:"column:row_index;index;not null"`
+	RowData   string    `gorm:"column:row_data;type:json"`
+	CreatedAt time.Time `gorm:"column:created_at"`
+}
+
+func (syncPreviewRow0001) TableName() string { return "sync_preview_rows" }
+
+type monitoringPlatformEventSubscription0001 struct {
+	ID          uint      `gorm:"column:id;primaryKey;autoIncrement"`
+	Name        string    `gorm:"column:name;size:120;not null;index"`
+	Enabled     bool      `gorm:"column:enabled"`
+	ChannelID   uint      `gorm:"column:channel_id;not null;index"`
+	EventTypes  string    `gorm:"column:event_types;size:500"`
+	ClusterID   string    `gorm:"column:cluster_id;size:64;index"`
+	Description string    `gorm:"column:description;type:text"`
+	CreatedAt   time.Time `gorm:"column:created_at"`
+	UpdatedAt   time.Time `gorm:"column:updated_at"`
+}
+
+func (monitoringPlatformEventSubscription0001) TableName() string {
+	return "monitoring_platform_event_subscriptions"
+}
+
+type monitoringHeartbeatAlertRule0001 struct {
+	ID              uint      `gorm:"column:id;primaryKey;autoIncrement"`
+	Name            string    `gorm:"column:name;size:160;not null;index"`
+	Enabled         bool      `gorm:"column:enabled"`
+	Metric          string    `gorm:"column:metric;size:30;not null;index"`
+	Operator        string    `gorm:"column:operator;size:4;not null"`
+	Threshold       float64   `gorm:"column:threshold"`
+	DurationSeconds int       `gorm:"column:duration_seconds;default:0"`
+	Severity        string    `gorm:"column:severity;size:20;default:warning"`
+	HostID          uint      `gorm:"column:host_id;index"`
+	SendRecovery    bool      `gorm:"column:send_recovery;default:true"`
+	Description     string    `gorm:"column:description;type:text"`
+	CreatedAt       time.Time `gorm:"column:created_at"`
+	UpdatedAt       time.Time `gorm:"column:updated_at"`
+}
+
+func (monitoringHeartbeatAlertRule0001) TableName() string { return "monitoring_heartbeat_alert_rules" }
+
+type monitoringHeartbeatAlert0001 struct {
+	ID              uint      `gorm:"column:id;primaryKey;autoIncrement"`
+	RuleID          uint      `gorm:"column:rule_id;not null;index"`
+	RuleName        string    `gorm:"column:rule_name;size:160"`
+	HostID          uint      `gorm:"column:host_id;not null;index"`
+	HostName        string    `gorm:"column:host_name;size:100"`
+	Metric          string    `gorm:"column:metric;size:30;not null"`
+	Operator        string    `gorm:"column:operator;size:4"`
+	Threshold       float64   `gorm:"column:threshold"`
+	Severity        string    `gorm:"column:severity;size:20"`
+	Status          string    `gorm:"column:status;size:20;not null;index"`
+	LastValue       float64   `gorm:"column:last_value"`
+	StartedAt       time.Time `gorm:"column:started_at"`
+	FiredAt         time.Time `gorm:"column:fired_at"`
+	ResolvedAt      time.Time `gorm:"column:resolved_at"`
+	LastEvaluatedAt time.Time `gorm:"column:last_evaluated_at"`
+	CreatedAt       time.Time `gorm:"column:created_at"`
+	UpdatedAt       time.Time `gorm:"column:updated_at"`
+}
+
+func (monitoringHeartbeatAlert0001) TableName() string { return "monitoring_heartbeat_alerts" }
+
+type installerInstallationTemplate0001 struct {
+	ID          uint      `gorm:"column:id;primaryKey;autoIncrement"`
+	Name        string    `gorm:"column:name;size:100;not null;uniqueIndex"`
+	Description string    `gorm:"column:description;size:500"`
+	Spec        string    `gorm:"column:spec;type:json;not null"`
+	CreatedBy   uint      `gorm:"column:created_by"`
+	CreatedAt   time.Time `gorm:"column:created_at"`
+	UpdatedAt   time.Time `gorm:"column:updated_at"`
+}
+
+func (installerInstallationTemplate0001) TableName() string { return "installation_templates" }
+
+type clustermetricsMetricsSnapshot0001 struct {
+	ID              uint      `gorm:"column:id;primaryKey;autoIncrement"`
+	ClusterID       uint      `gorm:"column:cluster_id;index:idx_cluster_metrics_cluster_time;not null"`
+	APIMode         string    `gorm:"column:api_mode;size:10"`
+	TotalSlot       int       `gorm:"column:total_slot"`
+	UnassignedSlot  int       `gorm:"column:unassigned_slot"`
+	UsedSlot        int       `gorm:"column:used_slot"`
+	SlotUtilization float64   `gorm:"column:slot_utilization"`
+	RunningJobs     int       `gorm:"column:running_jobs"`
+	WorkerCount     int       `gorm:"column:worker_count"`
+	Nodes           string    `gorm:"column:nodes;type:json"`
+	Error           string    `gorm:"column:error;size:1000"`
+	CollectedAt     time.Time `gorm:"column:collected_at;index:idx_cluster_metrics_cluster_time;not null"`
+}
+
+func (clustermetricsMetricsSnapshot0001) TableName() string { return "cluster_metrics_snapshots" }
+
+type taskTaskRecord0001 struct {
+	ID             string    `gorm:"column:id;primaryKey;size:36"`
+	Type           string    `gorm:"column:type;size:32;index;not null"`
+	Source         string    `gorm:"column:source;size:16;index"`
+	HostID         uint      `gorm:"column:host_id;index"`
+	AgentID        string    `gorm:"column:agent_id;size:100"`
+	ClusterID      uint      `gorm:"column:cluster_id;index"`
+	ParentID       string    `gorm:"column:parent_id;size:36"`
+	Status         string    `gorm:"column:status;size:16;index;not null"`
+	Progress       int       `gorm:"column:progress"`
+	Message        string    `gorm:"column:message;type:text"`
+	Error          string    `gorm:"column:error;type:text"`
+	CurrentStep    string    `gorm:"column:current_step;size:64"`
+	Steps          string    `gorm:"column:steps;type:text"`
+	Params         string    `gorm:"column:params;type:text"`
+	Result         string    `gorm:"column:result;type:text"`
+	TimeoutSeconds int       `gorm:"column:timeout_seconds"`
+	RetryCount     int       `gorm:"column:retry_count"`
+	MaxRetries     int       `gorm:"column:max_retries"`
+	Retryable      bool      `gorm:"column:retryable"`
+	CreatedBy      string    `gorm:"column:created_by;size:64"`
+	CreatedAt      time.Time `gorm:"column:created_at"`
+	StartedAt      time.Time `gorm:"column:started_at"`
+	CompletedAt    time.Time `gorm:"column:completed_at"`
+	UpdatedAt      time.Time `gorm:"column:updated_at"`
+}
+
+func (taskTaskRecord0001) TableName() string { return "operation_tasks" }
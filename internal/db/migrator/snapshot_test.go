@@ -0,0 +1,209 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package migrator
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/seatunnel/seatunnelX/internal/apps/agent"
+	"github.com/seatunnel/seatunnelX/internal/apps/audit"
+	"github.com/seatunnel/seatunnelX/internal/apps/auth"
+	"github.com/seatunnel/seatunnelX/internal/apps/backup"
+	"github.com/seatunnel/seatunnelX/internal/apps/cluster"
+	"github.com/seatunnel/seatunnelX/internal/apps/clustermetrics"
+	appconfig "github.com/seatunnel/seatunnelX/internal/apps/config"
+	"github.com/seatunnel/seatunnelX/internal/apps/diagnostics"
+	"github.com/seatunnel/seatunnelX/internal/apps/ha"
+	"github.com/seatunnel/seatunnelX/internal/apps/host"
+	"github.com/seatunnel/seatunnelX/internal/apps/host/sshdeploy"
+	"github.com/seatunnel/seatunnelX/internal/apps/installer"
+	"github.com/seatunnel/seatunnelX/internal/apps/monitor"
+	monitoringapp "github.com/seatunnel/seatunnelX/internal/apps/monitoring"
+	"github.com/seatunnel/seatunnelX/internal/apps/plugin"
+	"github.com/seatunnel/seatunnelX/internal/apps/stupgrade"
+	syncapp "github.com/seatunnel/seatunnelX/internal/apps/sync"
+	"github.com/seatunnel/seatunnelX/internal/apps/task"
+	"gorm.io/gorm"
+)
+
+// liveModels lists every current model whose table is owned by the migrations.
+func liveModels() []interface{} {
+	return []interface{}{
+		&auth.User{},
+		&auth.RoleBinding{},
+		&auth.Project{},
+		&auth.ProjectMember{},
+		&host.Host{},
+		&host.EnrollmentToken{},
+		&sshdeploy.Credential{},
+		&sshdeploy.DeployTask{},
+		&agent.AgentApproval{},
+		&agent.AgentDenyRule{},
+		&cluster.Cluster{},
+		&cluster.ClusterNode{},
+		&cluster.ScaleTask{},
+		&audit.CommandLog{},
+		&audit.AuditLog{},
+		&plugin.InstalledPlugin{},
+		&plugin.PluginDependencyConfig{},
+		&plugin.PluginDependencyDisable{},
+		&plugin.PluginCatalogEntry{},
+		&plugin.PluginDependencyProfile{},
+		&plugin.PluginDependencyProfileItem{},
+		&appconfig.Config{},
+		&appconfig.ConfigVersion{},
+		&appconfig.ConfigDrift{},
+		&monitor.MonitorConfig{},
+		&monitor.ProcessEvent{},
+		&monitoringapp.AlertRule{},
+		&monitoringapp.AlertPolicy{},
+		&monitoringapp.AlertEventState{},
+		&monitoringapp.AlertState{},
+		&monitoringapp.NotificationChannel{},
+		&monitoringapp.NotificationRoute{},
+		&monitoringapp.NotificationDelivery{},
+		&monitoringapp.RemoteAlertRecord{},
+		&diagnostics.SeatunnelErrorGroup{},
+		&diagnostics.SeatunnelErrorEvent{},
+		&diagnostics.SeatunnelLogCursor{},
+		&diagnostics.ClusterInspectionReport{},
+		&diagnostics.ClusterInspectionFinding{},
+		&diagnostics.DiagnosticTask{},
+		&diagnostics.DiagnosticTaskStep{},
+		&diagnostics.DiagnosticNodeExecution{},
+		&diagnostics.DiagnosticStepLog{},
+		&diagnostics.InspectionAutoPolicy{},
+		&stupgrade.UpgradePlanRecord{},
+		&stupgrade.UpgradeTask{},
+		&stupgrade.UpgradeTaskStep{},
+		&stupgrade.UpgradeNodeExecution{},
+		&stupgrade.UpgradeStepLog{},
+		&syncapp.Task{},
+		&syncapp.TaskVersion{},
+		&syncapp.JobInstance{},
+		&syncapp.GlobalVariable{},
+		&syncapp.PreviewSession{},
+		&syncapp.PreviewTable{},
+		&syncapp.PreviewRow{},
+		&monitoringapp.PlatformEventSubscription{},
+		&monitoringapp.HeartbeatAlertRule{},
+		&monitoringapp.HeartbeatAlert{},
+		&installer.InstallationTemplate{},
+		&clustermetrics.MetricsSnapshot{},
+		&task.TaskRecord{},
+		&ha.Instance{},
+		&ha.AgentOwnership{},
+		&ha.Lease{},
+		&auth.UserSession{},
+		&backup.Backup{},
+		&backup.BackupPolicy{},
+		&plugin.CustomPlugin{},
+		&plugin.MavenMetadataCache{},
+		&installer.PrecheckRun{},
+		&installer.VersionPolicy{},
+		&installer.TaskState{},
+	}
+}
+
+// sqliteSchema describes the columns and indexes of every application table.
+func sqliteSchema(t *testing.T, database *gorm.DB) map[string][]string {
+	t.Helper()
+	var tables []string
+	if err := database.Raw("SELECT name FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite_%' AND name <> ?", SchemaMigration{}.TableName()).Scan(&tables).Error; err != nil {
+		t.Fatalf("list tables: %v", err)
+	}
+	schema := make(map[string][]string, len(tables))
+	for _, table := range tables {
+		var columns []struct {
+			Name    string
+			Type    string
+			NotNull bool    `gorm:"column:notnull"`
+			Default *string `gorm:"column:dflt_value"`
+			PK      int     `gorm:"column:pk"`
+		}
+		if err := database.Raw("SELECT name, type, \"notnull\", dflt_value, pk FROM pragma_table_info(?)", table).Scan(&columns).Error; err != nil {
+			t.Fatalf("describe %s: %v", table, err)
+		}
+		var entries []string
+		for _, column := range columns {
+			entry := column.Name + " " + column.Type
+			if column.NotNull {
+				entry += " NOT NULL"
+			}
+			if column.Default != nil {
+				entry += " DEFAULT " + *column.Default
+			}
+			if column.PK > 0 {
+				entry += " PK"
+			}
+			entries = append(entries, entry)
+		}
+		var indexes []struct {
+			Name   string
+			Unique bool `gorm:"column:unique"`
+		}
+		if err := database.Raw("SELECT name, \"unique\" FROM pragma_index_list(?)", table).Scan(&indexes).Error; err != nil {
+			t.Fatalf("list indexes of %s: %v", table, err)
+		}
+		for _, index := range indexes {
+			var columns []string
+			if err := database.Raw("SELECT name FROM pragma_index_info(?) ORDER BY seqno", index.Name).Scan(&columns).Error; err != nil {
+				t.Fatalf("describe index %s: %v", index.Name, err)
+			}
+			entry := "index " + index.Name + " (" + strings.Join(columns, ",") + ")"
+			if index.Unique {
+				entry += " UNIQUE"
+			}
+			entries = append(entries, entry)
+		}
+		sort.Strings(entries)
+		schema[table] = entries
+	}
+	return schema
+}
+
+func TestMigrationsMatchLiveModels(t *testing.T) {
+	migrated := openMigrationTestDB(t)
+	runner, err := NewRunner(migrated, Migrations())
+	if err != nil {
+		t.Fatalf("new runner: %v", err)
+	}
+	if _, err := runner.Up(context.Background()); err != nil {
+		t.Fatalf("up: %v", err)
+	}
+	expected := openMigrationTestDB(t)
+	if err := expected.AutoMigrate(liveModels()...); err != nil {
+		t.Fatalf("auto migrate live models: %v", err)
+	}
+
+	got, want := sqliteSchema(t, migrated), sqliteSchema(t, expected)
+	for table, columns := range want {
+		if !reflect.DeepEqual(got[table], columns) {
+			t.Errorf("table %s drifted from its model; add a migration\nmigrated: %v\nmodel:    %v", table, got[table], columns)
+		}
+	}
+	for table := range got {
+		if _, ok := want[table]; !ok {
+			t.Errorf("table %s is created by the migrations but has no model", table)
+		}
+	}
+}
@@ -0,0 +1,212 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package migrator
+
+import "time"
+
+// Each migration that creates or alters tables declares the schema it applies here, so later model changes do not rewrite released migrations.
+// 每个创建或修改表的迁移都在此声明其应用的表结构，后续模型变更不会改写已发布的迁移。
+
+// Schema of 0003_ha_registry.
+// 0003_ha_registry 的表结构。
+
+type haInstance0003 struct {
+	ID            string    `gorm:"column:id;primaryKey;size:128"`
+	AdvertiseAddr string    `gorm:"column:advertise_addr;size:255"`
+	StartedAt     time.Time `gorm:"column:started_at"`
+	HeartbeatAt   time.Time `gorm:"column:heartbeat_at;index"`
+}
+
+func (haInstance0003) TableName() string { return "ha_instances" }
+
+type haAgentOwnership0003 struct {
+	AgentID    string    `gorm:"column:agent_id;primaryKey;size:128"`
+	InstanceID string    `gorm:"column:instance_id;size:128;index"`
+	ClaimedAt  time.Time `gorm:"column:claimed_at"`
+}
+
+func (haAgentOwnership0003) TableName() string { return "ha_agent_ownerships" }
+
+type haLease0003 struct {
+	Name      string    `gorm:"column:name;primaryKey;size:64"`
+	Holder    string    `gorm:"column:holder;size:128"`
+	ExpiresAt time.Time `gorm:"column:expires_at"`
+}
+
+func (haLease0003) TableName() string { return "ha_leases" }
+
+// Schema of 0004_user_sessions.
+// 0004_user_sessions 的表结构。
+
+type authUserSession0004 struct {
+	ID         string    `gorm:"column:id;primaryKey;size:64"`
+	UserID     uint64    `gorm:"column:user_id;index;not null"`
+	Username   string    `gorm:"column:username;size:50"`
+	IPAddress  string    `gorm:"column:ip_address;size:64"`
+	UserAgent  string    `gorm:"column:user_agent;size:512"`
+	CreatedAt  time.Time `gorm:"column:created_at"`
+	LastSeenAt time.Time `gorm:"column:last_seen_at"`
+	ExpiresAt  time.Time `gorm:"column:expires_at;index"`
+}
+
+func (authUserSession0004) TableName() string { return "user_sessions" }
+
+// Schema of 0005_cluster_backups.
+// 0005_cluster_backups 的表结构。
+
+type backupBackup0005 struct {
+	ID          uint      `gorm:"column:id;primaryKey;autoIncrement"`
+	ClusterID   uint      `gorm:"column:cluster_id;index;not null"`
+	ClusterName string    `gorm:"column:cluster_name;size:100"`
+	Trigger     string    `gorm:"column:trigger_type;size:20;not null"`
+	Status      string    `gorm:"column:status;size:20;not null;index"`
+	StorageType string    `gorm:"column:storage_type;size:20"`
+	Location    string    `gorm:"column:location;size:512"`
+	SizeBytes   int64     `gorm:"column:size_bytes"`
+	Checksum    string    `gorm:"column:checksum;size:64"`
+	NodeCount   int       `gorm:"column:node_count"`
+	FileCount   int       `gorm:"column:file_count"`
+	Error       string    `gorm:"column:error;type:text"`
+	CreatedBy   uint      `gorm:"column:created_by"`
+	CreatedAt   time.Time `gorm:"column:created_at;index"`
+	FinishedAt  time.Time `gorm:"column:finished_at"`
+}
+
+func (backupBackup0005) TableName() string { return "cluster_backups" }
+
+type backupBackupPolicy0005 struct {
+	ID        uint      `gorm:"column:id;primaryKey;autoIncrement"`
+	ClusterID uint      `gorm:"column:cluster_id;uniqueIndex;not null"`
+	Enabled   bool      `gorm:"column:enabled;not null;default:false"`
+	CronExpr  string    `gorm:"column:cron_expr;size:100"`
+	Timezone  string    `gorm:"column:timezone;size:64"`
+	Retention int       `gorm:"column:retention;not null;default:7"`
+	LastRunAt time.Time `gorm:"column:last_run_at"`
+	UpdatedBy uint      `gorm:"column:updated_by"`
+	CreatedAt time.Time `gorm:"column:created_at"`
+	UpdatedAt time.Time `gorm:"column:updated_at"`
+}
+
+func (backupBackupPolicy0005) TableName() string { return "cluster_backup_policies" }
+
+// Schema of 0006_custom_plugins.
+// 0006_custom_plugins 的表结构。
+
+type pluginCustomPlugin0006 struct {
+	ID               uint      `gorm:"column:id;primaryKey"`
+	Name             string    `gorm:"column:name;size:120;not null;index:idx_custom_plugin_version_name,unique"`
+	SeatunnelVersion string    `gorm:"column:seatunnel_version;size:50;not null;index:idx_custom_plugin_version_name,unique"`
+	DisplayName      string    `gorm:"column:display_name;size:200;not null"`
+	ArtifactID       string    `gorm:"column:artifact_id;size:200;not null"`
+	GroupID          string    `gorm:"column:group_id;size:200;not null"`
+	Category         string    `gorm:"column:category;size:50;not null"`
+	Description      string    `gorm:"column:description;type:text"`
+	DocURL           string    `gorm:"column:doc_url;size:500"`
+	OriginalFileName string    `gorm:"column:original_file_name;size:255"`
+	FileSize         int64     `gorm:"column:file_size;not null;default:0"`
+	SHA256           string    `gorm:"column:sha256;size:64"`
+	UploadedBy       string    `gorm:"column:uploaded_by;size:100"`
+	CreatedAt        time.Time `gorm:"column:created_at"`
+	UpdatedAt        time.Time `gorm:"column:updated_at"`
+}
+
+func (pluginCustomPlugin0006) TableName() string { return "custom_plugins" }
+
+// Schema of 0007_maven_metadata_cache.
+// 0007_maven_metadata_cache 的表结构。
+
+type pluginMavenMetadataCache0007 struct {
+	ID           uint      `gorm:"column:id;primaryKey"`
+	URL          string    `gorm:"column:url;size:512;not null;uniqueIndex"`
+	ETag         string    `gorm:"column:etag;size:255"`
+	LastModified string    `gorm:"column:last_modified;size:100"`
+	Entries      string    `gorm:"column:entries;type:text"`
+	CheckedAt    time.Time `gorm:"column:checked_at;not null"`
+	CreatedAt    time.Time `gorm:"column:created_at"`
+	UpdatedAt    time.Time `gorm:"column:updated_at"`
+}
+
+func (pluginMavenMetadataCache0007) TableName() string { return "maven_metadata_cache" }
+
+// Schema of 0008_host_maintenance: the maintenance columns added to hosts.
+// 0008_host_maintenance 的表结构：为 hosts 添加的维护模式字段。
+
+type hostMaintenance0008 struct {
+	ID                   uint       `gorm:"column:id;primaryKey;autoIncrement"`
+	Maintenance          bool       `gorm:"column:maintenance;default:false;index"`
+	MaintenanceReason    string     `gorm:"column:maintenance_reason;size:255"`
+	MaintenanceStartedAt *time.Time `gorm:"column:maintenance_started_at"`
+}
+
+func (hostMaintenance0008) TableName() string { return "hosts" }
+
+// Schema of 0009_precheck_runs.
+// 0009_precheck_runs 的表结构。
+
+type installerPrecheckRun0009 struct {
+	ID            uint      `gorm:"column:id;primaryKey;autoIncrement"`
+	HostID        uint      `gorm:"column:host_id;not null;index:idx_precheck_runs_host_id,priority:1"`
+	OverallStatus string    `gorm:"column:overall_status;size:20;not null;index"`
+	Summary       string    `gorm:"column:summary;type:text"`
+	InstallDir    string    `gorm:"column:install_dir;size:255"`
+	Version       string    `gorm:"column:version;size:50"`
+	Items         string    `gorm:"column:items;type:json"`
+	CreatedAt     time.Time `gorm:"column:created_at;index:idx_precheck_runs_host_id,priority:2"`
+}
+
+func (installerPrecheckRun0009) TableName() string { return "precheck_runs" }
+
+// Schema of 0010_installer_version_policy.
+// 0010_installer_version_policy 的表结构。
+
+type installerVersionPolicy0010 struct {
+	ID              uint      `gorm:"column:id;primaryKey"`
+	MinimumVersion  string    `gorm:"column:minimum_version;size:50"`
+	AllowedVersions string    `gorm:"column:allowed_versions;type:json"`
+	DeniedVersions  string    `gorm:"column:denied_versions;type:json"`
+	HideUnapproved  bool      `gorm:"column:hide_unapproved"`
+	UpdatedBy       string    `gorm:"column:updated_by;size:100"`
+	UpdatedAt       time.Time `gorm:"column:updated_at"`
+}
+
+func (installerVersionPolicy0010) TableName() string { return "installer_version_policies" }
+
+// Schema of 0013_host_agent_secret: the Agent secret hash added to hosts.
+// 0013_host_agent_secret 的表结构：为 hosts 添加的 Agent 密钥哈希字段。
+
+type hostAgentSecret0013 struct {
+	ID              uint   `gorm:"column:id;primaryKey;autoIncrement"`
+	AgentSecretHash string `gorm:"column:agent_secret_hash;size:64"`
+}
+
+func (hostAgentSecret0013) TableName() string { return "hosts" }
+
+// Schema of 0014_installer_task_states.
+// 0014_installer_task_states 的表结构。
+
+type installerTaskState0014 struct {
+	Kind            string    `gorm:"column:kind;primaryKey;size:20"`
+	Key             string    `gorm:"column:task_key;primaryKey;size:64"`
+	InstanceID      string    `gorm:"column:instance_id;size:128;index"`
+	Active          bool      `gorm:"column:active"`
+	Payload         string    `gorm:"column:payload;type:text"`
+	CancelRequested bool      `gorm:"column:cancel_requested"`
+	UpdatedAt       time.Time `gorm:"column:updated_at;index"`
+}
+
+func (installerTaskState0014) TableName() string { return "installer_task_states" }
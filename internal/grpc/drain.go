@@ -0,0 +1,134 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package grpc
+
+import (
+	"context"
+	"math/rand/v2"
+	"strconv"
+	"time"
+
+	pb "github.com/seatunnel/seatunnelX/internal/proto/agent"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Default values for graceful drain.
+// 优雅排空的默认值。
+const (
+	// DefaultDrainTimeout is how long Drain waits for in-flight commands to finish.
+	// DefaultDrainTimeout 是 Drain 等待在途命令完成的时长。
+	DefaultDrainTimeout = 30 * time.Second
+
+	// DefaultReconnectDelay is the minimum delay Agents wait before reconnecting after a drain.
+	// DefaultReconnectDelay 是排空后 Agent 重连前的最小等待时间。
+	DefaultReconnectDelay = 5 * time.Second
+
+	// DefaultReconnectJitter is the random window added to the reconnect delay of each Agent.
+	// DefaultReconnectJitter 是叠加到每个 Agent 重连延迟上的随机窗口。
+	DefaultReconnectJitter = 30 * time.Second
+)
+
+// MetadataReconnectAfter is the trailer key carrying the reconnect hint (milliseconds) when a
+// command stream is closed by a draining Control Plane.
+// MetadataReconnectAfter 是排空中的 Control Plane 关闭命令流时携带重连提示（毫秒）的 trailer 键。
+const MetadataReconnectAfter = "x-seatunnelx-reconnect-after-ms"
+
+// DrainConfig controls how the server drains before shutdown.
+// DrainConfig 控制服务器关闭前的排空方式。
+type DrainConfig struct {
+	// Timeout bounds the wait for in-flight commands.
+	// Timeout 限制等待在途命令的时长。
+	Timeout time.Duration
+
+	// ReconnectDelay is the minimum delay hinted to Agents.
+	// ReconnectDelay 是提示给 Agent 的最小重连延迟。
+	ReconnectDelay time.Duration
+
+	// ReconnectJitter spreads Agent reconnects over [ReconnectDelay, ReconnectDelay+ReconnectJitter).
+	// ReconnectJitter 将 Agent 重连分散在 [ReconnectDelay, ReconnectDelay+ReconnectJitter) 区间内。
+	ReconnectJitter time.Duration
+}
+
+// withDefaults fills unset fields with defaults.
+// withDefaults 为未设置的字段填充默认值。
+func (c DrainConfig) withDefaults() DrainConfig {
+	if c.Timeout <= 0 {
+		c.Timeout = DefaultDrainTimeout
+	}
+	if c.ReconnectDelay <= 0 {
+		c.ReconnectDelay = DefaultReconnectDelay
+	}
+	if c.ReconnectJitter < 0 {
+		c.ReconnectJitter = 0
+	}
+	return c
+}
+
+// reconnectAfter returns a jittered reconnect delay for one Agent.
+// reconnectAfter 返回单个 Agent 带抖动的重连延迟。
+func (c DrainConfig) reconnectAfter() time.Duration {
+	if c.ReconnectJitter <= 0 {
+		return c.ReconnectDelay
+	}
+	return c.ReconnectDelay + rand.N(c.ReconnectJitter)
+}
+
+// Drain prepares the server for shutdown without a reconnect storm: new commands are rejected,
+// in-flight commands get up to cfg.Timeout to finish, then every command stream is closed with a
+// jittered reconnect hint. Call Stop afterwards to close the listener.
+// Drain 为关闭做准备并避免重连风暴：拒绝新命令，在途命令最多有 cfg.Timeout 时间完成，
+// 随后以带抖动的重连提示关闭所有命令流。之后调用 Stop 关闭监听。
+func (s *Server) Drain(ctx context.Context, cfg DrainConfig) {
+	cfg = cfg.withDefaults()
+	s.logger.Info("Draining gRPC server / 正在排空 gRPC 服务器",
+		zap.Duration("timeout", cfg.Timeout),
+		zap.Duration("reconnect_delay", cfg.ReconnectDelay),
+		zap.Duration("reconnect_jitter", cfg.ReconnectJitter),
+	)
+
+	s.agentManager.BeginDrain()
+
+	waitCtx, cancel := context.WithTimeout(ctx, cfg.Timeout)
+	defer cancel()
+	if pending := s.agentManager.WaitInFlight(waitCtx); pending > 0 {
+		s.logger.Warn("Drain timeout reached with commands still running / 排空超时，仍有命令在运行",
+			zap.Int("pending", pending),
+		)
+	}
+
+	s.drainOnce.Do(func() {
+		s.drainConfig = cfg
+		close(s.drainCh)
+	})
+}
+
+// endDrainedStream closes a command stream with the reconnect hint in its trailer.
+// endDrainedStream 关闭命令流，并在 trailer 中携带重连提示。
+func (s *Server) endDrainedStream(stream grpc.BidiStreamingServer[pb.CommandResponse, pb.CommandRequest], agentID string) error {
+	after := s.drainConfig.reconnectAfter()
+	stream.SetTrailer(metadata.Pairs(MetadataReconnectAfter, strconv.FormatInt(after.Milliseconds(), 10)))
+	s.logger.Info("Closing CommandStream for drain / 因排空关闭命令流",
+		zap.String("agent_id", agentID),
+		zap.Duration("reconnect_after", after),
+	)
+	return status.Error(codes.Unavailable, "control plane is draining, reconnect later")
+}
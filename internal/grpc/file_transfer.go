@@ -0,0 +1,102 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package grpc
+
+import (
+	"errors"
+	"io"
+	"os"
+
+	"github.com/seatunnel/seatunnelX/internal/apps/agent"
+	"github.com/seatunnel/seatunnelX/internal/pkg/filestream"
+	pb "github.com/seatunnel/seatunnelX/internal/proto/agent"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// FetchFile streams a file registered for a transfer command to the Agent that received the command.
+// Chunks carry raw bytes, optionally zstd-compressed; the last chunk is always marked, even for empty files.
+// FetchFile 将为传输命令注册的文件流式发送给接收该命令的 Agent。
+// 数据块携带原始字节，可选 zstd 压缩；即使是空文件也总会发送标记为最后一块的数据块。
+func (s *Server) FetchFile(req *pb.FetchFileRequest, stream grpc.ServerStreamingServer[pb.FileChunk]) error {
+	if req.AgentId == "" || req.TransferId == "" {
+		return status.Error(codes.InvalidArgument, "agent_id and transfer_id are required")
+	}
+	transfer, err := s.agentManager.LookupFileTransfer(req.AgentId, req.TransferId)
+	if errors.Is(err, agent.ErrFileTransferNotFound) {
+		return status.Error(codes.NotFound, "file transfer not found")
+	}
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to look up file transfer: %v", err)
+	}
+	if req.Offset < 0 || req.Offset > transfer.Size {
+		return status.Errorf(codes.OutOfRange, "offset %d out of range [0, %d]", req.Offset, transfer.Size)
+	}
+
+	file, err := os.Open(transfer.Path)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to open file: %v", err)
+	}
+	defer file.Close()
+	if _, err := file.Seek(req.Offset, io.SeekStart); err != nil {
+		return status.Errorf(codes.Internal, "failed to seek file: %v", err)
+	}
+
+	compression := transfer.Compression
+	if compression == filestream.CompressionNone {
+		compression = ""
+	}
+	buf := make([]byte, transfer.ChunkSize)
+	offset := req.Offset
+	for {
+		n, readErr := io.ReadFull(file, buf)
+		if readErr != nil && readErr != io.EOF && readErr != io.ErrUnexpectedEOF {
+			return status.Errorf(codes.Internal, "failed to read file: %v", readErr)
+		}
+		isLast := offset+int64(n) >= transfer.Size
+		data, err := filestream.Compress(buf[:n], compression)
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to compress chunk: %v", err)
+		}
+		if err := stream.Send(&pb.FileChunk{
+			Data:        data,
+			Offset:      offset,
+			TotalSize:   transfer.Size,
+			Compression: compression,
+			IsLast:      isLast,
+		}); err != nil {
+			s.logger.Warn("FetchFile send failed",
+				zap.String("agent_id", req.AgentId),
+				zap.String("transfer_id", req.TransferId),
+				zap.Int64("offset", offset),
+				zap.Error(err),
+			)
+			return err
+		}
+		offset += int64(n)
+		transfer.ReportProgress(offset)
+		if isLast {
+			return nil
+		}
+		if n == 0 {
+			return status.Errorf(codes.DataLoss, "file shrank during transfer at offset %d", offset)
+		}
+	}
+}
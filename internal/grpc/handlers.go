@@ -21,6 +21,7 @@ import (
 	"context"
 	"crypto/sha256"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"strconv"
@@ -72,10 +73,48 @@ func (s *Server) Register(ctx context.Context, req *pb.RegisterRequest) (*pb.Reg
 		)
 	}
 
+	// Check the Agent secret, or the enrollment token issued with the install command
+	// 校验 Agent 密钥，或安装命令签发的注册令牌
+	var agentSecret string
+	if s.hostService != nil {
+		var err error
+		agentSecret, err = s.hostService.VerifyEnrollment(ctx, req.EnrollmentToken, req.AgentSecret, req.AgentId, req.DryRun)
+		if err != nil {
+			if code, ok := admissionErrorCode(err); ok {
+				s.logger.Warn("Agent enrollment rejected / Agent 注册令牌校验未通过",
+					zap.String("agent_id", req.AgentId),
+					zap.String("ip_address", req.IpAddress),
+					zap.Error(err),
+				)
+				return nil, status.Error(code, err.Error())
+			}
+			return &pb.RegisterResponse{
+				Success: false,
+				Message: "failed to verify enrollment: " + err.Error(),
+			}, nil
+		}
+	}
+
+	// A dry run only reports whether the registration would be admitted
+	// 试运行仅报告注册是否会被准入
+	if req.DryRun {
+		return s.dryRunRegister(ctx, req)
+	}
+
 	// Register Agent with manager
 	// 向管理器注册 Agent
 	conn, err := s.agentManager.RegisterAgent(ctx, req)
 	if err != nil {
+		// Admission failures use distinct codes so the Agent can back off instead of failing
+		// 准入失败使用独立的状态码，便于 Agent 退避重试而不是直接失败
+		if code, ok := admissionErrorCode(err); ok {
+			s.logger.Warn("Agent registration not admitted / Agent 注册未获准入",
+				zap.String("agent_id", req.AgentId),
+				zap.String("ip_address", req.IpAddress),
+				zap.Error(err),
+			)
+			return nil, status.Error(code, err.Error())
+		}
 		s.logger.Error("Failed to register Agent",
 			zap.String("agent_id", req.AgentId),
 			zap.Error(err),
@@ -126,9 +165,10 @@ func (s *Server) Register(ctx context.Context, req *pb.RegisterRequest) (*pb.Reg
 	)
 
 	response := &pb.RegisterResponse{
-		Success:    true,
-		Message:    "registration successful",
-		AssignedId: req.AgentId,
+		Success:     true,
+		Message:     "registration successful",
+		AssignedId:  req.AgentId,
+		AgentSecret: agentSecret,
 		Config: &pb.AgentConfig{
 			HeartbeatInterval: int32(s.config.HeartbeatInterval),
 			LogLevel:          int32(pb.LogLevel_INFO),
@@ -152,6 +192,44 @@ func (s *Server) Register(ctx context.Context, req *pb.RegisterRequest) (*pb.Reg
 	return response, nil
 }
 
+// dryRunRegister checks admission for a registration without touching Agent or host state.
+// dryRunRegister 检查注册的准入情况，不修改 Agent 与主机状态。
+func (s *Server) dryRunRegister(ctx context.Context, req *pb.RegisterRequest) (*pb.RegisterResponse, error) {
+	if err := s.agentManager.CheckRegistration(ctx, req); err != nil {
+		if code, ok := admissionErrorCode(err); ok {
+			return nil, status.Error(code, err.Error())
+		}
+		return &pb.RegisterResponse{
+			Success: false,
+			Message: "registration check failed: " + err.Error(),
+		}, nil
+	}
+	return &pb.RegisterResponse{
+		Success:    true,
+		Message:    "dry run: registration would be accepted",
+		AssignedId: req.AgentId,
+	}, nil
+}
+
+// admissionErrorCode maps registration admission errors to gRPC status codes.
+// admissionErrorCode 将注册准入错误映射为 gRPC 状态码。
+func admissionErrorCode(err error) (codes.Code, bool) {
+	switch {
+	case errors.Is(err, agent.ErrRegistrationRateLimited):
+		return codes.ResourceExhausted, true
+	case errors.Is(err, agent.ErrAgentDenied),
+		errors.Is(err, host.ErrEnrollmentTokenRequired),
+		errors.Is(err, host.ErrEnrollmentTokenInvalid),
+		errors.Is(err, host.ErrEnrollmentTokenExpired),
+		errors.Is(err, host.ErrEnrollmentTokenUsed),
+		errors.Is(err, host.ErrAgentSecretInvalid):
+		return codes.PermissionDenied, true
+	case errors.Is(err, agent.ErrAgentPendingApproval):
+		return codes.FailedPrecondition, true
+	}
+	return codes.OK, false
+}
+
 // GetDiagnosticsLogCursors returns all diagnostics log cursors for a given agent.
 // GetDiagnosticsLogCursors 返回某个 Agent 的所有诊断日志游标。
 func (s *Server) GetDiagnosticsLogCursors(ctx context.Context, req *pb.DiagnosticsCursorRequest) (*pb.DiagnosticsCursorResponse, error) {
@@ -214,6 +292,11 @@ func (s *Server) Heartbeat(ctx context.Context, req *pb.HeartbeatRequest) (*pb.H
 		return nil, status.Error(codes.Internal, "failed to process heartbeat")
 	}
 
+	// Record which Control Plane endpoint the Agent is using (multi-Control-Plane failover)
+	// 记录 Agent 当前使用的 Control Plane 端点（多 Control Plane 故障转移）
+	s.recordControlPlaneEndpoint(req)
+	s.recordHeartbeatRTT(ctx, req.AgentId)
+
 	// Update host heartbeat data if host service is available
 	// 如果主机服务可用，更新主机心跳数据
 	if s.hostService != nil && req.ResourceUsage != nil {
@@ -244,12 +327,46 @@ func (s *Server) Heartbeat(ctx context.Context, req *pb.HeartbeatRequest) (*pb.H
 		}
 	}
 
+	// Reconcile node status with the SeaTunnel installs the Agent found on its host.
+	// 用 Agent 在主机上发现的 SeaTunnel 安装清单对账节点状态。
+	if clusterNodeProvider != nil && req.SeatunnelInventory != nil {
+		conn, ok := s.agentManager.GetAgent(req.AgentId)
+		if ok && conn.HostID > 0 {
+			go s.reconcileInventory(context.Background(), req.AgentId, conn.HostID, req.SeatunnelInventory)
+		}
+	}
+
 	return &pb.HeartbeatResponse{
 		Success:    true,
 		ServerTime: time.Now().UnixMilli(),
 	}, nil
 }
 
+// recordControlPlaneEndpoint stores the endpoint status reported in a heartbeat.
+// Agents before the endpoint fields were added report nothing and are skipped.
+// recordControlPlaneEndpoint 保存心跳中上报的端点状态；未上报该字段的旧版 Agent 会被跳过。
+func (s *Server) recordControlPlaneEndpoint(req *pb.HeartbeatRequest) {
+	endpoint := req.GetControlPlaneEndpoint()
+	if endpoint == "" {
+		return
+	}
+	conn, ok := s.agentManager.GetAgent(req.GetAgentId())
+	if !ok {
+		return
+	}
+
+	failover := req.GetControlPlaneFailover()
+	_, wasFailover := conn.GetControlPlaneEndpoint()
+	conn.SetControlPlaneEndpoint(endpoint, failover)
+	if failover != wasFailover {
+		s.logger.Info("Agent Control Plane failover status changed",
+			zap.String("agent_id", req.GetAgentId()),
+			zap.String("endpoint", endpoint),
+			zap.Bool("failover", failover),
+		)
+	}
+}
+
 // CommandStream handles bidirectional streaming for command dispatch and result reporting.
 // CommandStream 处理用于命令分发和结果上报的双向流。
 // Requirements: 1.5, 8.6 - Implements bidirectional stream for command dispatching.
@@ -288,9 +405,11 @@ func (s *Server) CommandStream(stream grpc.BidiStreamingServer[pb.CommandRespons
 		return status.Error(codes.NotFound, "agent not registered, please register first")
 	}
 
-	// Set the stream for this Agent
-	// 为此 Agent 设置流
-	if err := s.agentManager.SetAgentStream(agentID, stream); err != nil {
+	// Set the stream for this Agent, counting the commands sent on it
+	// 为此 Agent 设置流，并统计其上发送的命令
+	tracked, generation := s.trackStream(agentID, peerAddr, stream)
+	s.recordStreamReceive(agentID)
+	if err := s.agentManager.SetAgentStream(agentID, tracked); err != nil {
 		s.logger.Error("Failed to set agent stream",
 			zap.String("agent_id", agentID),
 			zap.Error(err),
@@ -309,34 +428,77 @@ func (s *Server) CommandStream(stream grpc.BidiStreamingServer[pb.CommandRespons
 		s.handleCommandResponse(agentID, firstMsg)
 	}
 
+	// Receive in the background so a drain can end the stream while Recv is blocked
+	// 在后台接收，以便排空时可以在 Recv 阻塞期间结束命令流
+	recvCh := make(chan commandStreamRecv, 1)
+	go receiveCommandResponses(stream, recvCh)
+
 	// Main loop: receive command responses from Agent
 	// 主循环：从 Agent 接收命令响应
 	for {
-		resp, err := stream.Recv()
+		var resp *pb.CommandResponse
+		var err error
+		select {
+		case <-s.drainCh:
+			s.recordStreamEnd(agentID, generation, nil, true)
+			return s.endDrainedStream(stream, agentID)
+		case res := <-recvCh:
+			resp, err = res.resp, res.err
+		}
 		if err != nil {
 			if err == io.EOF {
+				s.recordStreamEnd(agentID, generation, nil, false)
 				s.logger.Info("CommandStream closed by Agent",
 					zap.String("agent_id", agentID),
 				)
 			} else {
+				s.recordStreamEnd(agentID, generation, err, false)
 				s.logger.Error("CommandStream receive error",
 					zap.String("agent_id", agentID),
 					zap.Error(err),
 				)
 			}
 
-			// Handle Agent disconnect
-			// 处理 Agent 断开连接
-			s.agentManager.HandleDisconnect(agentID)
+			// Handle Agent disconnect, unless the Agent already migrated to a newer stream
+			// 处理 Agent 断开连接，除非 Agent 已迁移到更新的命令流
+			if !s.agentManager.HandleStreamClosed(agentID, tracked) {
+				s.logger.Info("Superseded CommandStream drained / 已被替代的命令流已排空",
+					zap.String("agent_id", agentID),
+				)
+			}
 			return err
 		}
 
 		// Process command response
 		// 处理命令响应
+		s.recordStreamReceive(agentID)
 		s.handleCommandResponse(agentID, resp)
 	}
 }
 
+// commandStreamRecv is the result of one Recv on a command stream.
+// commandStreamRecv 是命令流单次 Recv 的结果。
+type commandStreamRecv struct {
+	resp *pb.CommandResponse
+	err  error
+}
+
+// receiveCommandResponses forwards command stream messages until Recv fails or the stream ends.
+// receiveCommandResponses 转发命令流消息，直到 Recv 失败或命令流结束。
+func receiveCommandResponses(stream grpc.BidiStreamingServer[pb.CommandResponse, pb.CommandRequest], out chan<- commandStreamRecv) {
+	for {
+		resp, err := stream.Recv()
+		select {
+		case out <- commandStreamRecv{resp: resp, err: err}:
+		case <-stream.Context().Done():
+			return
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
 // handleCommandResponse processes a command response from an Agent.
 // handleCommandResponse 处理来自 Agent 的命令响应。
 func (s *Server) handleCommandResponse(agentID string, resp *pb.CommandResponse) {
@@ -436,6 +598,8 @@ func (s *Server) updateCommandLog(resp *pb.CommandResponse) {
 		auditStatus = audit.CommandStatusFailed
 	case pb.CommandStatus_CANCELLED:
 		auditStatus = audit.CommandStatusCancelled
+	case pb.CommandStatus_TIMEOUT:
+		auditStatus = audit.CommandStatusTimeout
 	default:
 		auditStatus = audit.CommandStatusPending
 	}
@@ -443,12 +607,18 @@ func (s *Server) updateCommandLog(resp *pb.CommandResponse) {
 	// Update command log
 	// 更新命令日志
 	updates := map[string]interface{}{
-		"status":   auditStatus,
-		"progress": int(resp.Progress),
+		"status": auditStatus,
 	}
 
-	if resp.Output != "" {
-		updates["output"] = cmdLog.Output + resp.Output
+	// Output chunks only extend the console; they carry no progress of their own
+	// 输出片段只追加控制台内容，本身不携带进度
+	if resp.OutputChunk != "" {
+		updates["output"] = cmdLog.Output + resp.OutputChunk
+	} else {
+		updates["progress"] = int(resp.Progress)
+		if resp.Output != "" && !resp.OutputStreamed {
+			updates["output"] = appendOutputLine(cmdLog.Output, resp.Output)
+		}
 	}
 
 	if resp.Error != "" {
@@ -466,7 +636,8 @@ func (s *Server) updateCommandLog(resp *pb.CommandResponse) {
 	// 如果是终止状态，设置 finished_at
 	if auditStatus == audit.CommandStatusSuccess ||
 		auditStatus == audit.CommandStatusFailed ||
-		auditStatus == audit.CommandStatusCancelled {
+		auditStatus == audit.CommandStatusCancelled ||
+		auditStatus == audit.CommandStatusTimeout {
 		now := time.Now()
 		updates["finished_at"] = now
 	}
@@ -479,6 +650,19 @@ func (s *Server) updateCommandLog(resp *pb.CommandResponse) {
 	}
 }
 
+// appendOutputLine appends a progress message or final output to the command console,
+// starting it on a new line so consecutive messages do not run together.
+// appendOutputLine 将进度消息或最终输出追加到命令控制台，并另起一行，避免连续消息连在一起。
+func appendOutputLine(existing, output string) string {
+	if existing != "" && !strings.HasSuffix(existing, "\n") {
+		existing += "\n"
+	}
+	if !strings.HasSuffix(output, "\n") {
+		output += "\n"
+	}
+	return existing + output
+}
+
 // LogStream handles log streaming from Agents.
 // LogStream 处理来自 Agent 的日志流。
 // Requirements: 10.2, 10.3 - Receives Agent logs and stores to audit log.
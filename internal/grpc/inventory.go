@@ -0,0 +1,153 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package grpc
+
+import (
+	"context"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	pb "github.com/seatunnel/seatunnelX/internal/proto/agent"
+	"go.uber.org/zap"
+)
+
+// inventoryRole normalizes node and install roles so hybrid aliases compare equal.
+// inventoryRole 归一化节点与安装的角色，使混合模式的别名可以相互比较。
+func inventoryRole(role string) string {
+	role = strings.ToLower(strings.TrimSpace(role))
+	switch role {
+	case "", "master/worker", "master_worker":
+		return "hybrid"
+	}
+	return role
+}
+
+// inventoryKey identifies an install by its cleaned directory and normalized role.
+// inventoryKey 以清理后的目录与归一化角色标识一个安装。
+func inventoryKey(installDir, role string) string {
+	return filepath.Clean(strings.TrimSpace(installDir)) + "|" + inventoryRole(role)
+}
+
+// reconcileInventory reconciles cluster nodes on the host with the SeaTunnel installs reported in a heartbeat.
+// Nodes whose install is running are marked running with the reported PID; nodes that had a PID but no
+// longer show up are marked stopped. Installs that match no managed node are logged as unknown.
+// reconcileInventory 将主机上的集群节点与心跳上报的 SeaTunnel 安装清单对账。
+// 安装正在运行的节点标记为 running 并记录上报的 PID；曾有 PID 但已不在清单中的节点标记为 stopped；
+// 未匹配任何受管节点的安装记录为未知进程。
+func (s *Server) reconcileInventory(ctx context.Context, agentID string, hostID uint, inventory *pb.SeaTunnelInventory) {
+	if clusterNodeProvider == nil || inventory == nil {
+		return
+	}
+
+	nodes, err := clusterNodeProvider.GetNodesByHostID(ctx, hostID)
+	if err != nil {
+		s.logger.Warn("Failed to get nodes for inventory reconcile / 获取节点用于清单对账失败",
+			zap.Uint("host_id", hostID),
+			zap.Error(err),
+		)
+		return
+	}
+
+	installs := make(map[string]*pb.SeaTunnelInstall, len(inventory.GetInstalls()))
+	pids := make(map[int]struct{}, len(inventory.GetInstalls()))
+	for _, install := range inventory.GetInstalls() {
+		installs[inventoryKey(install.GetInstallDir(), install.GetRole())] = install
+		pids[int(install.GetPid())] = struct{}{}
+	}
+
+	matched := make(map[string]struct{}, len(installs))
+	clusterIDsSeen := make(map[uint]struct{})
+	for _, node := range nodes {
+		key := inventoryKey(node.InstallDir, node.Role)
+		install, found := installs[key]
+		if found {
+			matched[key] = struct{}{}
+			if int(install.GetPid()) == node.ProcessPID {
+				continue
+			}
+			s.updateNodeFromInventory(ctx, node, int(install.GetPid()), "running")
+			clusterIDsSeen[node.ClusterID] = struct{}{}
+			continue
+		}
+		if node.ProcessPID <= 0 {
+			continue
+		}
+		if _, alive := pids[node.ProcessPID]; alive {
+			continue
+		}
+		s.updateNodeFromInventory(ctx, node, 0, "stopped")
+		clusterIDsSeen[node.ClusterID] = struct{}{}
+	}
+
+	for cid := range clusterIDsSeen {
+		clusterNodeProvider.RefreshClusterStatusFromNodes(ctx, cid)
+	}
+
+	var unknown []string
+	for key, install := range installs {
+		if _, ok := matched[key]; ok {
+			continue
+		}
+		unknown = append(unknown, install.GetInstallDir()+"@"+inventoryRole(install.GetRole())+"#"+strconv.Itoa(int(install.GetPid())))
+	}
+	sort.Strings(unknown)
+	s.reportUnknownInstalls(agentID, hostID, unknown)
+}
+
+// updateNodeFromInventory writes the process status derived from the inventory to a node.
+// updateNodeFromInventory 将由清单推导出的进程状态写入节点。
+func (s *Server) updateNodeFromInventory(ctx context.Context, node *NodeWithMonitorConfig, pid int, processStatus string) {
+	if err := clusterNodeProvider.UpdateNodeProcessStatus(ctx, node.NodeID, pid, processStatus); err != nil {
+		s.logger.Warn("Failed to update node process status from inventory / 从清单更新节点进程状态失败",
+			zap.Uint("node_id", node.NodeID),
+			zap.Error(err),
+		)
+	}
+}
+
+// reportUnknownInstalls logs SeaTunnel installs that belong to no managed node, only when the set changes.
+// reportUnknownInstalls 记录不属于任何受管节点的 SeaTunnel 安装，仅在集合变化时输出。
+func (s *Server) reportUnknownInstalls(agentID string, hostID uint, unknown []string) {
+	key := strings.Join(unknown, ",")
+
+	s.inventoryMu.Lock()
+	if s.unknownInstalls == nil {
+		s.unknownInstalls = make(map[string]string)
+	}
+	previous, seen := s.unknownInstalls[agentID]
+	s.unknownInstalls[agentID] = key
+	s.inventoryMu.Unlock()
+
+	if (seen && previous == key) || (!seen && key == "") {
+		return
+	}
+	if key == "" {
+		s.logger.Info("Unknown SeaTunnel processes are gone / 未知的 SeaTunnel 进程已消失",
+			zap.String("agent_id", agentID),
+			zap.Uint("host_id", hostID),
+		)
+		return
+	}
+	s.logger.Warn("Unknown SeaTunnel processes detected on host / 主机上检测到未受管的 SeaTunnel 进程",
+		zap.String("agent_id", agentID),
+		zap.Uint("host_id", hostID),
+		zap.Strings("installs", unknown),
+	)
+}
@@ -0,0 +1,33 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package grpc
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/seatunnel/seatunnelX/internal/apps/response"
+)
+
+// GetRuntimeStats handles GET /api/v1/admin/grpc/runtime - reports live gRPC server and Agent stream state
+// GetRuntimeStats 处理 GET /api/v1/admin/grpc/runtime - 返回 gRPC 服务器与 Agent 命令流的实时状态
+// @Tags admin
+// @Produce json
+// @Success 200 {object} RuntimeStats
+// @Router /api/v1/admin/grpc/runtime [get]
+func (s *Server) GetRuntimeStats(c *gin.Context) {
+	response.OK(c, s.RuntimeStats())
+}
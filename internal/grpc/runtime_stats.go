@@ -0,0 +1,321 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package grpc
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/seatunnel/seatunnelX/internal/apps/agent"
+	pb "github.com/seatunnel/seatunnelX/internal/proto/agent"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// MetadataHeartbeatRTT is the heartbeat metadata key in which Agents report the round-trip time
+// (milliseconds) of their previous heartbeat ack.
+// MetadataHeartbeatRTT 是 Agent 在心跳中上报上一次心跳应答往返耗时（毫秒）的 metadata 键。
+const MetadataHeartbeatRTT = "x-seatunnelx-heartbeat-rtt-ms"
+
+// messageRateWindow is the number of seconds message rates are averaged over.
+// messageRateWindow 是计算消息速率的平均窗口（秒）。
+const messageRateWindow = 60
+
+// rateCounter counts events in one-second buckets over the last messageRateWindow seconds.
+// rateCounter 以一秒为桶统计最近 messageRateWindow 秒内的事件数。
+type rateCounter struct {
+	counts  [messageRateWindow]int64
+	seconds [messageRateWindow]int64
+}
+
+// add records one event at now.
+// add 记录一次发生在 now 的事件。
+func (r *rateCounter) add(now time.Time) {
+	sec := now.Unix()
+	i := sec % messageRateWindow
+	if r.seconds[i] != sec {
+		r.seconds[i] = sec
+		r.counts[i] = 0
+	}
+	r.counts[i]++
+}
+
+// perSecond returns the average rate over the window, or over since when it is more recent.
+// perSecond 返回窗口内的平均速率；若 since 更近，则按 since 以来的时长计算。
+func (r *rateCounter) perSecond(now, since time.Time) float64 {
+	sec := now.Unix()
+	var total int64
+	for i, bucket := range r.seconds {
+		if bucket > sec-messageRateWindow && bucket <= sec {
+			total += r.counts[i]
+		}
+	}
+	span := float64(messageRateWindow)
+	if elapsed := now.Sub(since).Seconds(); elapsed >= 1 && elapsed < span {
+		span = elapsed
+	}
+	return float64(total) / span
+}
+
+// agentStreamStats tracks the command stream of one Agent.
+// agentStreamStats 跟踪单个 Agent 的命令流。
+type agentStreamStats struct {
+	peer           string
+	generation     uint64
+	streamSince    time.Time
+	received       int64
+	sent           int64
+	receivedRate   rateCounter
+	sentRate       rateCounter
+	resets         int
+	lastReset      time.Time
+	lastResetError string
+	lastGoAway     time.Time
+	heartbeatRTT   time.Duration
+	rttReportedAt  time.Time
+}
+
+// countingCommandStream counts the commands sent on an Agent command stream.
+// countingCommandStream 统计通过 Agent 命令流发送的命令数。
+type countingCommandStream struct {
+	grpc.BidiStreamingServer[pb.CommandResponse, pb.CommandRequest]
+	onSend func()
+}
+
+// Send sends a command and counts it when it was written to the stream.
+// Send 发送命令，写入命令流成功后计数。
+func (c *countingCommandStream) Send(req *pb.CommandRequest) error {
+	if err := c.BidiStreamingServer.Send(req); err != nil {
+		return err
+	}
+	c.onSend()
+	return nil
+}
+
+// statsFor returns the stream stats of an Agent, creating them on first use. Callers hold statsMu.
+// statsFor 返回 Agent 的命令流统计，首次使用时创建。调用方须持有 statsMu。
+func (s *Server) statsFor(agentID string) *agentStreamStats {
+	if s.streamStats == nil {
+		s.streamStats = make(map[string]*agentStreamStats)
+	}
+	stats, ok := s.streamStats[agentID]
+	if !ok {
+		stats = &agentStreamStats{}
+		s.streamStats[agentID] = stats
+	}
+	return stats
+}
+
+// trackStream starts tracking a new command stream and returns the counting stream to register
+// with the Agent Manager together with its generation.
+// trackStream 开始跟踪新的命令流，返回需注册到 Agent Manager 的计数命令流及其代数。
+func (s *Server) trackStream(agentID, peerAddr string, stream grpc.BidiStreamingServer[pb.CommandResponse, pb.CommandRequest]) (grpc.BidiStreamingServer[pb.CommandResponse, pb.CommandRequest], uint64) {
+	s.statsMu.Lock()
+	stats := s.statsFor(agentID)
+	stats.generation++
+	stats.peer = peerAddr
+	stats.streamSince = time.Now()
+	generation := stats.generation
+	s.statsMu.Unlock()
+
+	return &countingCommandStream{
+		BidiStreamingServer: stream,
+		onSend: func() {
+			s.statsMu.Lock()
+			defer s.statsMu.Unlock()
+			stats := s.statsFor(agentID)
+			stats.sent++
+			stats.sentRate.add(time.Now())
+		},
+	}, generation
+}
+
+// recordStreamReceive counts one message received from an Agent command stream.
+// recordStreamReceive 统计从 Agent 命令流收到的一条消息。
+func (s *Server) recordStreamReceive(agentID string) {
+	s.statsMu.Lock()
+	defer s.statsMu.Unlock()
+	stats := s.statsFor(agentID)
+	stats.received++
+	stats.receivedRate.add(time.Now())
+}
+
+// recordStreamEnd records how a command stream ended. A nil err is a clean close by the Agent;
+// goAway marks streams closed by the Control Plane or by a GOAWAY from the transport.
+// recordStreamEnd 记录命令流的结束方式。err 为 nil 表示 Agent 正常关闭；
+// goAway 表示命令流由 Control Plane 或传输层 GOAWAY 关闭。
+func (s *Server) recordStreamEnd(agentID string, generation uint64, err error, goAway bool) {
+	now := time.Now()
+	s.statsMu.Lock()
+	defer s.statsMu.Unlock()
+	stats := s.statsFor(agentID)
+	if stats.generation == generation {
+		stats.streamSince = time.Time{}
+	}
+	if err != nil && strings.Contains(err.Error(), "GOAWAY") {
+		goAway = true
+	}
+	if goAway {
+		stats.lastGoAway = now
+	}
+	if err != nil {
+		stats.resets++
+		stats.lastReset = now
+		stats.lastResetError = err.Error()
+	}
+}
+
+// recordHeartbeatRTT stores the heartbeat round-trip time reported in heartbeat metadata.
+// recordHeartbeatRTT 保存心跳 metadata 中上报的心跳往返耗时。
+func (s *Server) recordHeartbeatRTT(ctx context.Context, agentID string) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return
+	}
+	values := md.Get(MetadataHeartbeatRTT)
+	if len(values) == 0 {
+		return
+	}
+	millis, err := strconv.ParseInt(values[0], 10, 64)
+	if err != nil || millis < 0 {
+		return
+	}
+
+	s.statsMu.Lock()
+	defer s.statsMu.Unlock()
+	stats := s.statsFor(agentID)
+	stats.heartbeatRTT = time.Duration(millis) * time.Millisecond
+	stats.rttReportedAt = time.Now()
+}
+
+// RuntimeStats is a point-in-time view of the gRPC server and its Agent command streams.
+// RuntimeStats 是 gRPC 服务器及其 Agent 命令流的即时视图。
+type RuntimeStats struct {
+	Running          bool                `json:"running"`
+	Port             int                 `json:"port"`
+	Draining         bool                `json:"draining"`
+	ConnectedAgents  int                 `json:"connected_agents"`
+	InFlightCommands int                 `json:"in_flight_commands"`
+	Agents           []AgentRuntimeStats `json:"agents"`
+	CollectedAt      time.Time           `json:"collected_at"`
+}
+
+// AgentRuntimeStats describes the connection and command stream of one Agent.
+// AgentRuntimeStats 描述单个 Agent 的连接与命令流。
+type AgentRuntimeStats struct {
+	AgentID              string     `json:"agent_id"`
+	HostID               uint       `json:"host_id"`
+	Hostname             string     `json:"hostname"`
+	IPAddress            string     `json:"ip_address"`
+	Status               string     `json:"status"`
+	Peer                 string     `json:"peer,omitempty"`
+	StreamConnected      bool       `json:"stream_connected"`
+	StreamStartedAt      *time.Time `json:"stream_started_at,omitempty"`
+	StreamAgeSeconds     float64    `json:"stream_age_seconds"`
+	MessagesReceived     int64      `json:"messages_received"`
+	MessagesSent         int64      `json:"messages_sent"`
+	ReceivedPerSecond    float64    `json:"received_per_second"`
+	SentPerSecond        float64    `json:"sent_per_second"`
+	PendingCommands      int        `json:"pending_commands"`
+	QueuedCommands       int        `json:"queued_commands"`
+	StreamResets         int        `json:"stream_resets"`
+	LastStreamReset      *time.Time `json:"last_stream_reset,omitempty"`
+	LastStreamError      string     `json:"last_stream_error,omitempty"`
+	LastGoAway           *time.Time `json:"last_goaway,omitempty"`
+	LastHeartbeat        time.Time  `json:"last_heartbeat"`
+	HeartbeatRTTMillis   *int64     `json:"heartbeat_rtt_ms,omitempty"`
+	HeartbeatRTTReported *time.Time `json:"heartbeat_rtt_reported_at,omitempty"`
+}
+
+// RuntimeStats collects live statistics about the gRPC server and every registered Agent.
+// RuntimeStats 收集 gRPC 服务器及所有已注册 Agent 的实时统计信息。
+func (s *Server) RuntimeStats() *RuntimeStats {
+	now := time.Now()
+	result := &RuntimeStats{
+		Running:     s.IsRunning(),
+		Port:        s.GetPort(),
+		Agents:      []AgentRuntimeStats{},
+		CollectedAt: now,
+	}
+	if s.agentManager == nil {
+		return result
+	}
+	result.Draining = s.agentManager.IsDraining()
+	pending := s.agentManager.InFlightCountByAgent()
+
+	s.statsMu.Lock()
+	defer s.statsMu.Unlock()
+	for _, conn := range s.agentManager.ListAgents() {
+		status := conn.GetStatus()
+		if status == agent.AgentStatusConnected {
+			result.ConnectedAgents++
+		}
+		item := AgentRuntimeStats{
+			AgentID:         conn.AgentID,
+			HostID:          conn.HostID,
+			Hostname:        conn.Hostname,
+			IPAddress:       conn.IPAddress,
+			Status:          string(status),
+			StreamConnected: conn.GetStream() != nil,
+			PendingCommands: pending[conn.AgentID],
+			QueuedCommands:  s.agentManager.QueuedCommandCount(conn.AgentID),
+			LastHeartbeat:   conn.GetLastHeartbeat(),
+		}
+		result.InFlightCommands += item.PendingCommands
+
+		if stats, ok := s.streamStats[conn.AgentID]; ok {
+			item.Peer = stats.peer
+			item.MessagesReceived = stats.received
+			item.MessagesSent = stats.sent
+			item.ReceivedPerSecond = stats.receivedRate.perSecond(now, stats.streamSince)
+			item.SentPerSecond = stats.sentRate.perSecond(now, stats.streamSince)
+			item.StreamResets = stats.resets
+			item.LastStreamError = stats.lastResetError
+			if !stats.streamSince.IsZero() {
+				item.StreamStartedAt = timePtr(stats.streamSince)
+				item.StreamAgeSeconds = now.Sub(stats.streamSince).Seconds()
+			}
+			if !stats.lastReset.IsZero() {
+				item.LastStreamReset = timePtr(stats.lastReset)
+			}
+			if !stats.lastGoAway.IsZero() {
+				item.LastGoAway = timePtr(stats.lastGoAway)
+			}
+			if !stats.rttReportedAt.IsZero() {
+				millis := stats.heartbeatRTT.Milliseconds()
+				item.HeartbeatRTTMillis = &millis
+				item.HeartbeatRTTReported = timePtr(stats.rttReportedAt)
+			}
+		}
+		result.Agents = append(result.Agents, item)
+	}
+
+	sort.Slice(result.Agents, func(i, j int) bool {
+		return result.Agents[i].AgentID < result.Agents[j].AgentID
+	})
+	return result
+}
+
+// timePtr returns a pointer to a copy of t.
+// timePtr 返回 t 副本的指针。
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
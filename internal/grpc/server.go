@@ -27,6 +27,7 @@ import (
 	"fmt"
 	"net"
 	"os"
+	"sync"
 	"time"
 
 	"github.com/seatunnel/seatunnelX/internal/apps/agent"
@@ -113,6 +114,10 @@ type ServerConfig struct {
 	// HeartbeatInterval is the heartbeat interval to send to Agents (seconds).
 	// HeartbeatInterval 是发送给 Agent 的心跳间隔（秒）。
 	HeartbeatInterval int
+
+	// RedactionPolicy masks sensitive command parameters in command logs; nil keeps the built-in keys.
+	// RedactionPolicy 屏蔽命令日志中的敏感命令参数；为 nil 时仅使用内置键。
+	RedactionPolicy *audit.RedactionPolicy
 }
 
 // Server represents the gRPC server for Agent communication.
@@ -152,6 +157,34 @@ type Server struct {
 	// listener is the network listener.
 	// listener 是网络监听器。
 	listener net.Listener
+
+	// drainCh is closed once in-flight commands are drained and command streams should end.
+	// drainCh 在在途命令排空、命令流应结束时关闭。
+	drainCh chan struct{}
+
+	// drainOnce guards closing drainCh.
+	// drainOnce 保证 drainCh 只关闭一次。
+	drainOnce sync.Once
+
+	// drainConfig is the drain configuration, set before drainCh is closed.
+	// drainConfig 是排空配置，在 drainCh 关闭前设置。
+	drainConfig DrainConfig
+
+	// inventoryMu protects unknownInstalls.
+	// inventoryMu 保护 unknownInstalls。
+	inventoryMu sync.Mutex
+
+	// unknownInstalls keeps the last reported set of unmanaged SeaTunnel installs per Agent.
+	// unknownInstalls 按 Agent 保存最近一次上报的未受管 SeaTunnel 安装集合。
+	unknownInstalls map[string]string
+
+	// statsMu protects streamStats.
+	// statsMu 保护 streamStats。
+	statsMu sync.Mutex
+
+	// streamStats tracks the command stream of each Agent for RuntimeStats.
+	// streamStats 为 RuntimeStats 跟踪各 Agent 的命令流。
+	streamStats map[string]*agentStreamStats
 }
 
 // NewServer creates a new gRPC server instance.
@@ -181,13 +214,27 @@ func NewServer(config *ServerConfig, agentManager *agent.Manager, hostService *h
 		logger, _ = zap.NewProduction()
 	}
 
-	return &Server{
+	s := &Server{
 		config:       config,
 		agentManager: agentManager,
 		hostService:  hostService,
 		auditRepo:    auditRepo,
 		logger:       logger,
+		drainCh:      make(chan struct{}),
+	}
+
+	// Every command log written for Agent commands goes through the same parameter redaction
+	// 所有 Agent 命令的命令日志都经过同一参数脱敏策略
+	if config.RedactionPolicy != nil {
+		audit.SetRedactionPolicy(config.RedactionPolicy)
+	}
+
+	// Record commands timed out by the Control Plane like Agent-reported results
+	// 像 Agent 上报的结果一样记录被 Control Plane 判定超时的命令
+	if agentManager != nil && auditRepo != nil {
+		agentManager.SetOnCommandTimeout(s.updateCommandLog)
 	}
+	return s
 }
 
 // Start starts the gRPC server.
@@ -18,20 +18,31 @@
 package grpc
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"net"
+	"os"
+	"path/filepath"
+	"strconv"
 	"testing"
 	"time"
 
+	"github.com/glebarez/sqlite"
 	"github.com/seatunnel/seatunnelX/internal/apps/agent"
+	"github.com/seatunnel/seatunnelX/internal/apps/audit"
+	"github.com/seatunnel/seatunnelX/internal/pkg/filestream"
 	pb "github.com/seatunnel/seatunnelX/internal/proto/agent"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 	"google.golang.org/grpc/test/bufconn"
+	"gorm.io/gorm"
 )
 
 const bufSize = 1024 * 1024
@@ -288,6 +299,23 @@ func TestRegisterRPC(t *testing.T) {
 		assert.False(t, resp.Success)
 		assert.Contains(t, resp.Message, "ip_address is required")
 	})
+
+	t.Run("dry run does not register agent", func(t *testing.T) {
+		req := &pb.RegisterRequest{
+			AgentId:   "test-agent-dry-run",
+			Hostname:  "test-host",
+			IpAddress: "192.168.1.102",
+			DryRun:    true,
+		}
+
+		resp, err := client.Register(ctx, req)
+		require.NoError(t, err)
+		assert.True(t, resp.Success)
+		assert.Equal(t, "test-agent-dry-run", resp.AssignedId)
+
+		_, ok := ts.agentManager.GetAgent("test-agent-dry-run")
+		assert.False(t, ok)
+	})
 }
 
 // TestHeartbeatRPC tests the Heartbeat RPC method.
@@ -357,6 +385,71 @@ func TestHeartbeatRPC(t *testing.T) {
 	})
 }
 
+// TestFetchFileRPC tests the FetchFile RPC method.
+// TestFetchFileRPC 测试 FetchFile RPC 方法。
+func TestFetchFileRPC(t *testing.T) {
+	ts := newTestServer(t)
+	defer ts.close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	conn, err := ts.dial(ctx)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	client := pb.NewAgentServiceClient(conn)
+
+	content := bytes.Repeat([]byte("seatunnel-package-"), 10000)
+	path := filepath.Join(t.TempDir(), "package.tar.gz")
+	require.NoError(t, os.WriteFile(path, content, 0644))
+
+	var progress []filestream.Snapshot
+	transfer, err := ts.agentManager.RegisterFileTransfer("fetch-test-agent", path, func(snapshot filestream.Snapshot) {
+		progress = append(progress, snapshot)
+	})
+	require.NoError(t, err)
+	transfer.ChunkSize = filestream.MinChunkSize
+	transfer.Compression = filestream.CompressionZstd
+
+	t.Run("streams compressed chunks", func(t *testing.T) {
+		stream, err := client.FetchFile(ctx, &pb.FetchFileRequest{AgentId: "fetch-test-agent", TransferId: transfer.ID})
+		require.NoError(t, err)
+
+		var received []byte
+		for {
+			chunk, err := stream.Recv()
+			require.NoError(t, err)
+			assert.Equal(t, int64(len(received)), chunk.Offset)
+			assert.Less(t, len(chunk.Data), filestream.MinChunkSize)
+			data, err := filestream.Decompress(chunk.Data, chunk.Compression)
+			require.NoError(t, err)
+			received = append(received, data...)
+			if chunk.IsLast {
+				break
+			}
+		}
+		assert.Equal(t, content, received)
+		require.NotEmpty(t, progress)
+		assert.Equal(t, 100, progress[len(progress)-1].Percent)
+	})
+
+	t.Run("other agents cannot fetch the file", func(t *testing.T) {
+		stream, err := client.FetchFile(ctx, &pb.FetchFileRequest{AgentId: "other-agent", TransferId: transfer.ID})
+		require.NoError(t, err)
+		_, err = stream.Recv()
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("unregistered transfer is not found", func(t *testing.T) {
+		ts.agentManager.UnregisterFileTransfer(transfer.ID)
+		stream, err := client.FetchFile(ctx, &pb.FetchFileRequest{AgentId: "fetch-test-agent", TransferId: transfer.ID})
+		require.NoError(t, err)
+		_, err = stream.Recv()
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+}
+
 // TestServerConfig tests server configuration.
 // TestServerConfig 测试服务器配置。
 func TestServerConfig(t *testing.T) {
@@ -409,3 +502,221 @@ func TestExtractAgentIDFromResponse(t *testing.T) {
 		assert.Empty(t, agentID)
 	})
 }
+
+// TestCommandStreamDrain tests that Drain rejects new commands and closes streams with a reconnect hint.
+// TestCommandStreamDrain 测试 Drain 拒绝新命令并以重连提示关闭命令流。
+func TestCommandStreamDrain(t *testing.T) {
+	ts := newTestServer(t)
+	defer ts.close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	conn, err := ts.dial(ctx)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	client := pb.NewAgentServiceClient(conn)
+	_, err = client.Register(ctx, &pb.RegisterRequest{
+		AgentId:      "drain-test-agent",
+		Hostname:     "test-host",
+		IpAddress:    "192.168.1.210",
+		AgentVersion: "1.0.0",
+	})
+	require.NoError(t, err)
+
+	stream, err := client.CommandStream(ctx)
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(&pb.CommandResponse{
+		CommandId: "AGENT_INIT",
+		Output:    "drain-test-agent",
+		Status:    pb.CommandStatus_SUCCESS,
+	}))
+	require.Eventually(t, func() bool {
+		agentConn, ok := ts.agentManager.GetAgent("drain-test-agent")
+		return ok && agentConn.GetStream() != nil
+	}, 2*time.Second, 10*time.Millisecond)
+
+	ts.server.Drain(ctx, DrainConfig{
+		Timeout:         time.Second,
+		ReconnectDelay:  2 * time.Second,
+		ReconnectJitter: time.Second,
+	})
+
+	_, err = ts.agentManager.SendCommandAsync(context.Background(), "drain-test-agent", pb.CommandType_STATUS, nil, time.Second)
+	assert.ErrorIs(t, err, agent.ErrManagerDraining)
+
+	_, err = stream.Recv()
+	require.Error(t, err)
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+
+	values := stream.Trailer().Get(MetadataReconnectAfter)
+	require.Len(t, values, 1)
+	after, err := strconv.ParseInt(values[0], 10, 64)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, after, int64(2000))
+	assert.Less(t, after, int64(3000))
+}
+
+// TestRuntimeStats tests the live stream, command and heartbeat statistics of Agents.
+// TestRuntimeStats 测试 Agent 的实时命令流、命令与心跳统计。
+func TestRuntimeStats(t *testing.T) {
+	ts := newTestServer(t)
+	defer ts.close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	conn, err := ts.dial(ctx)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	client := pb.NewAgentServiceClient(conn)
+	_, err = client.Register(ctx, &pb.RegisterRequest{
+		AgentId:      "stats-test-agent",
+		Hostname:     "test-host",
+		IpAddress:    "192.168.1.220",
+		AgentVersion: "1.0.0",
+	})
+	require.NoError(t, err)
+
+	heartbeatCtx := metadata.AppendToOutgoingContext(ctx, MetadataHeartbeatRTT, "42")
+	_, err = client.Heartbeat(heartbeatCtx, &pb.HeartbeatRequest{AgentId: "stats-test-agent", Timestamp: time.Now().UnixMilli()})
+	require.NoError(t, err)
+
+	stream, err := client.CommandStream(ctx)
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(&pb.CommandResponse{
+		CommandId: "AGENT_INIT",
+		Output:    "stats-test-agent",
+		Status:    pb.CommandStatus_SUCCESS,
+	}))
+	require.Eventually(t, func() bool {
+		agentConn, ok := ts.agentManager.GetAgent("stats-test-agent")
+		return ok && agentConn.GetStream() != nil
+	}, 2*time.Second, 10*time.Millisecond)
+
+	commandID, err := ts.agentManager.SendCommandAsync(context.Background(), "stats-test-agent", pb.CommandType_STATUS, nil, time.Minute)
+	require.NoError(t, err)
+	cmd, err := stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, commandID, cmd.CommandId)
+	require.NoError(t, stream.Send(&pb.CommandResponse{CommandId: commandID, Status: pb.CommandStatus_RUNNING, Progress: 10}))
+
+	var stats AgentRuntimeStats
+	require.Eventually(t, func() bool {
+		runtime := ts.server.RuntimeStats()
+		if len(runtime.Agents) != 1 {
+			return false
+		}
+		stats = runtime.Agents[0]
+		return stats.MessagesReceived == 2
+	}, 2*time.Second, 10*time.Millisecond)
+
+	assert.Equal(t, "stats-test-agent", stats.AgentID)
+	assert.True(t, stats.StreamConnected)
+	require.NotNil(t, stats.StreamStartedAt)
+	assert.Equal(t, int64(1), stats.MessagesSent)
+	assert.Greater(t, stats.ReceivedPerSecond, 0.0)
+	assert.Equal(t, 1, stats.PendingCommands)
+	require.NotNil(t, stats.HeartbeatRTTMillis)
+	assert.Equal(t, int64(42), *stats.HeartbeatRTTMillis)
+	assert.Zero(t, stats.StreamResets)
+
+	// A clean close by the Agent ends the stream without counting a reset
+	// Agent 正常关闭命令流时不计为重置
+	require.NoError(t, stream.CloseSend())
+	require.Eventually(t, func() bool {
+		return ts.server.RuntimeStats().Agents[0].StreamStartedAt == nil
+	}, 2*time.Second, 10*time.Millisecond)
+	assert.Zero(t, ts.server.RuntimeStats().Agents[0].StreamResets)
+}
+
+// TestUpdateCommandLogOutput tests how command responses extend the command log console.
+// TestUpdateCommandLogOutput 测试命令响应如何追加命令日志控制台内容。
+func TestUpdateCommandLogOutput(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "audit.db")), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&audit.CommandLog{}))
+	repo := audit.NewRepository(db)
+	ctx := context.Background()
+	require.NoError(t, repo.CreateCommandLog(ctx, &audit.CommandLog{CommandID: "cmd-stream", AgentID: "agent-1", CommandType: "exec_script", Status: audit.CommandStatusPending}))
+
+	server := NewServer(nil, agent.NewManager(nil), nil, repo, zap.NewNop())
+	server.updateCommandLog(&pb.CommandResponse{CommandId: "cmd-stream", Status: pb.CommandStatus_RUNNING, Progress: 10, Output: "Running script"})
+	server.updateCommandLog(&pb.CommandResponse{CommandId: "cmd-stream", Status: pb.CommandStatus_RUNNING, OutputChunk: "partial "})
+	server.updateCommandLog(&pb.CommandResponse{CommandId: "cmd-stream", Status: pb.CommandStatus_RUNNING, OutputChunk: "line\n"})
+
+	log, err := repo.GetCommandLogByCommandID(ctx, "cmd-stream")
+	require.NoError(t, err)
+	assert.Equal(t, "Running script\npartial line\n", log.Output)
+	assert.Equal(t, 10, log.Progress, "output chunks must not reset progress")
+	assert.Equal(t, audit.CommandStatusRunning, log.Status)
+
+	server.updateCommandLog(&pb.CommandResponse{CommandId: "cmd-stream", Status: pb.CommandStatus_SUCCESS, Progress: 100, Output: "partial line\n", OutputStreamed: true})
+	log, err = repo.GetCommandLogByCommandID(ctx, "cmd-stream")
+	require.NoError(t, err)
+	assert.Equal(t, "Running script\npartial line\n", log.Output, "streamed output must not be appended twice")
+	assert.Equal(t, audit.CommandStatusSuccess, log.Status)
+	assert.NotNil(t, log.FinishedAt)
+}
+
+// fakeClusterNodeProvider records node status updates made by heartbeat reconciliation.
+// fakeClusterNodeProvider 记录心跳对账产生的节点状态更新。
+type fakeClusterNodeProvider struct {
+	nodes     []*NodeWithMonitorConfig
+	updates   map[uint]string
+	refreshed []uint
+}
+
+func (f *fakeClusterNodeProvider) GetNodeByHostAndInstallDirAndRole(ctx context.Context, hostID uint, installDir, role string) (uint, uint, bool, error) {
+	return 0, 0, false, nil
+}
+
+func (f *fakeClusterNodeProvider) GetNodesByHostID(ctx context.Context, hostID uint) ([]*NodeWithMonitorConfig, error) {
+	return f.nodes, nil
+}
+
+func (f *fakeClusterNodeProvider) UpdateNodeProcessStatus(ctx context.Context, nodeID uint, pid int, status string) error {
+	f.updates[nodeID] = status + ":" + strconv.Itoa(pid)
+	return nil
+}
+
+func (f *fakeClusterNodeProvider) RefreshClusterStatusFromNodes(ctx context.Context, clusterID uint) {
+	f.refreshed = append(f.refreshed, clusterID)
+}
+
+func (f *fakeClusterNodeProvider) GetClusterNodeDisplayInfo(ctx context.Context, clusterID, nodeID uint) (string, string) {
+	return "", ""
+}
+
+// TestReconcileInventory tests that heartbeat inventories update node status and flag unmanaged installs.
+// TestReconcileInventory 测试心跳清单会更新节点状态并标记未受管的安装。
+func TestReconcileInventory(t *testing.T) {
+	provider := &fakeClusterNodeProvider{
+		nodes: []*NodeWithMonitorConfig{
+			{ClusterID: 1, NodeID: 10, InstallDir: "/opt/seatunnel/", Role: "master", ProcessPID: 0},
+			{ClusterID: 1, NodeID: 11, InstallDir: "/opt/seatunnel", Role: "worker", ProcessPID: 2002},
+			{ClusterID: 2, NodeID: 20, InstallDir: "/opt/st-hybrid", Role: "master/worker", ProcessPID: 3003},
+			{ClusterID: 2, NodeID: 21, InstallDir: "/opt/st-gone", Role: "worker", ProcessPID: 4004},
+		},
+		updates: make(map[uint]string),
+	}
+	previous := clusterNodeProvider
+	clusterNodeProvider = provider
+	defer func() { clusterNodeProvider = previous }()
+
+	server := NewServer(nil, agent.NewManager(nil), nil, nil, zap.NewNop())
+	server.reconcileInventory(context.Background(), "agent-1", 7, &pb.SeaTunnelInventory{
+		Installs: []*pb.SeaTunnelInstall{
+			{InstallDir: "/opt/seatunnel", Role: "master", Pid: 1001, Version: "2.3.12"},
+			{InstallDir: "/opt/seatunnel", Role: "worker", Pid: 2002, Version: "2.3.12"},
+			{InstallDir: "/opt/st-hybrid", Role: "hybrid", Pid: 3003, Version: "2.3.11"},
+			{InstallDir: "/tmp/seatunnel-dev", Role: "hybrid", Pid: 5005, Version: "2.3.12"},
+		},
+	})
+
+	assert.Equal(t, map[uint]string{10: "running:1001", 21: "stopped:0"}, provider.updates)
+	assert.ElementsMatch(t, []uint{1, 2}, provider.refreshed)
+	assert.Equal(t, "/tmp/seatunnel-dev@hybrid#5005", server.unknownInstalls["agent-1"])
+}
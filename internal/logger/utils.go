@@ -33,6 +33,7 @@ import (
 	"sync"
 
 	"github.com/seatunnel/seatunnelX/internal/config"
+	"github.com/seatunnel/seatunnelX/internal/pkg/requestid"
 	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
@@ -148,8 +149,12 @@ func getLogLevel() zapcore.Level {
 func getTraceIDFields(ctx context.Context) []zap.Field {
 	span := trace.SpanFromContext(ctx)
 	spanContext := span.SpanContext()
-	return []zap.Field{
+	fields := []zap.Field{
 		zap.String("traceID", spanContext.TraceID().String()),
 		zap.String("spanID", spanContext.SpanID().String()),
 	}
+	if id := requestid.FromContext(ctx); id != "" {
+		fields = append(fields, zap.String("requestID", id))
+	}
+	return fields
 }
@@ -0,0 +1,374 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package chunkupload stores resumable uploads on disk. A client initialises an upload with the
+// total size, appends chunks at explicit offsets (each optionally verified by its SHA-256) and
+// completes it once every byte arrived. Partial uploads survive disconnects and restarts, so the
+// client can ask for the current offset and continue from there.
+// Package chunkupload 在磁盘上保存可续传的上传。客户端先声明文件总大小完成初始化，再按明确的偏移量
+// 追加分片（每个分片可附带 SHA-256 校验），全部字节到达后完成上传。未完成的上传在断线和重启后依然保留，
+// 客户端可查询当前偏移量并从该位置继续。
+package chunkupload
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DefaultTTL is how long an untouched partial upload is kept before it is purged.
+// DefaultTTL 是未更新的未完成上传在被清理前保留的时长。
+const DefaultTTL = 24 * time.Hour
+
+const (
+	stateFileName = "state.json"
+	dataFileName  = "data.part"
+)
+
+var sha256Pattern = regexp.MustCompile(`^[0-9a-fA-F]{64}$`)
+
+var (
+	ErrInvalidUpload    = errors.New("invalid upload request / 上传请求不合法")
+	ErrUploadNotFound   = errors.New("upload not found or expired / 上传会话不存在或已过期")
+	ErrUploadBusy       = errors.New("upload is being written by another request / 上传会话正被其他请求写入")
+	ErrOffsetMismatch   = errors.New("chunk offset does not match the upload offset / 分片偏移量与已上传位置不一致")
+	ErrChecksumMismatch = errors.New("checksum mismatch / 校验和不匹配")
+	ErrUploadTooLarge   = errors.New("chunk exceeds the declared upload size / 分片超出声明的文件大小")
+	ErrUploadIncomplete = errors.New("upload is not complete / 上传尚未完成")
+)
+
+// Session is the persisted state of one upload.
+// Session 是单个上传持久化的状态。
+type Session struct {
+	ID        string            `json:"upload_id"`
+	FileName  string            `json:"file_name"`
+	TotalSize int64             `json:"total_size"`
+	Offset    int64             `json:"offset"`
+	SHA256    string            `json:"sha256,omitempty"`
+	Metadata  map[string]string `json:"metadata,omitempty"`
+	CreatedAt time.Time         `json:"created_at"`
+	UpdatedAt time.Time         `json:"updated_at"`
+}
+
+// InitRequest describes the file about to be uploaded. SHA256 is optional and, when set, is
+// verified against the whole file on completion.
+// InitRequest 描述即将上传的文件。SHA256 可选，设置后会在完成上传时校验整个文件。
+type InitRequest struct {
+	FileName  string
+	TotalSize int64
+	SHA256    string
+	Metadata  map[string]string
+}
+
+// Store keeps uploads under one directory, one sub-directory per upload.
+// Store 将上传保存在同一目录下，每个上传占用一个子目录。
+type Store struct {
+	dir string
+	ttl time.Duration
+
+	mu   sync.Mutex
+	busy map[string]bool
+}
+
+// NewStore creates a store rooted at dir; a non-positive ttl uses DefaultTTL.
+// NewStore 创建以 dir 为根目录的存储；ttl 非正数时使用 DefaultTTL。
+func NewStore(dir string, ttl time.Duration) *Store {
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+	return &Store{dir: dir, ttl: ttl, busy: make(map[string]bool)}
+}
+
+// Init starts a new upload and purges expired ones.
+// Init 开始一个新的上传，并清理已过期的上传。
+func (s *Store) Init(req InitRequest) (*Session, error) {
+	fileName := filepath.Base(strings.TrimSpace(req.FileName))
+	if fileName == "" || fileName == "." || fileName == string(filepath.Separator) {
+		return nil, fmt.Errorf("%w: file name is required", ErrInvalidUpload)
+	}
+	if req.TotalSize <= 0 {
+		return nil, fmt.Errorf("%w: total size must be positive", ErrInvalidUpload)
+	}
+	checksum := strings.ToLower(strings.TrimSpace(req.SHA256))
+	if checksum != "" && !sha256Pattern.MatchString(checksum) {
+		return nil, fmt.Errorf("%w: sha256 must be 64 hex characters", ErrInvalidUpload)
+	}
+	s.PurgeExpired()
+
+	now := time.Now()
+	session := &Session{
+		ID:        uuid.New().String(),
+		FileName:  fileName,
+		TotalSize: req.TotalSize,
+		SHA256:    checksum,
+		Metadata:  req.Metadata,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	uploadDir := filepath.Join(s.dir, session.ID)
+	if err := os.MkdirAll(uploadDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create upload directory: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(uploadDir, dataFileName), nil, 0644); err != nil {
+		_ = os.RemoveAll(uploadDir)
+		return nil, fmt.Errorf("failed to create upload file: %w", err)
+	}
+	if err := s.save(session); err != nil {
+		_ = os.RemoveAll(uploadDir)
+		return nil, err
+	}
+	return session, nil
+}
+
+// Get returns the current state of an upload, including the offset to resume from.
+// Get 返回上传的当前状态，包括续传的起始偏移量。
+func (s *Store) Get(id string) (*Session, error) {
+	return s.load(id)
+}
+
+// Append writes one chunk starting at offset, which must equal the upload offset. Bytes left by an
+// interrupted request beyond the recorded offset are discarded first, so retrying a chunk is safe.
+// On ErrOffsetMismatch the current session is returned so the client can resume from its offset.
+// Append 从 offset 处写入一个分片，offset 必须等于已上传的位置。写入前会先丢弃被中断的请求在记录位置之后
+// 留下的字节，因此重试分片是安全的。返回 ErrOffsetMismatch 时同时返回当前会话，便于客户端从其偏移量续传。
+func (s *Store) Append(id string, offset int64, checksum string, src io.Reader) (*Session, error) {
+	checksum = strings.TrimSpace(checksum)
+	if checksum != "" && !sha256Pattern.MatchString(checksum) {
+		return nil, fmt.Errorf("%w: chunk sha256 must be 64 hex characters", ErrInvalidUpload)
+	}
+	if !s.acquire(id) {
+		return nil, ErrUploadBusy
+	}
+	defer s.release(id)
+
+	session, err := s.load(id)
+	if err != nil {
+		return nil, err
+	}
+	if offset != session.Offset {
+		return session, ErrOffsetMismatch
+	}
+
+	file, err := os.OpenFile(s.dataPath(id), os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open upload file: %w", err)
+	}
+	defer file.Close()
+	rollback := func() { _ = file.Truncate(session.Offset) }
+	rollback()
+	if _, err := file.Seek(session.Offset, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("failed to seek upload file: %w", err)
+	}
+
+	remaining := session.TotalSize - session.Offset
+	hasher := sha256.New()
+	written, err := io.Copy(io.MultiWriter(file, hasher), io.LimitReader(src, remaining+1))
+	if err != nil {
+		rollback()
+		return nil, fmt.Errorf("failed to write chunk: %w", err)
+	}
+	if written == 0 {
+		return nil, fmt.Errorf("%w: chunk is empty", ErrInvalidUpload)
+	}
+	if written > remaining {
+		rollback()
+		return nil, ErrUploadTooLarge
+	}
+	if checksum != "" && !strings.EqualFold(hex.EncodeToString(hasher.Sum(nil)), checksum) {
+		rollback()
+		return nil, ErrChecksumMismatch
+	}
+	if err := file.Sync(); err != nil {
+		rollback()
+		return nil, fmt.Errorf("failed to flush chunk: %w", err)
+	}
+
+	session.Offset += written
+	session.UpdatedAt = time.Now()
+	if err := s.save(session); err != nil {
+		rollback()
+		return nil, err
+	}
+	return session, nil
+}
+
+// Complete verifies that every byte arrived (and the whole-file checksum when one was declared),
+// then hands the file to consume. The upload is removed once consume succeeds; on failure it is
+// kept so the client can retry or abort.
+// Complete 校验所有字节均已到达（若声明了整体校验和则一并校验），然后将文件交给 consume 处理。
+// consume 成功后删除该上传；失败时保留，便于客户端重试或放弃。
+func (s *Store) Complete(id string, consume func(session *Session, path string) error) (*Session, error) {
+	if !s.acquire(id) {
+		return nil, ErrUploadBusy
+	}
+	defer s.release(id)
+
+	session, err := s.load(id)
+	if err != nil {
+		return nil, err
+	}
+	if session.Offset != session.TotalSize {
+		return session, ErrUploadIncomplete
+	}
+	dataPath := s.dataPath(id)
+	if session.SHA256 != "" {
+		actual, err := fileSHA256(dataPath)
+		if err != nil {
+			return nil, err
+		}
+		if actual != session.SHA256 {
+			return session, ErrChecksumMismatch
+		}
+	}
+	if err := consume(session, dataPath); err != nil {
+		return session, err
+	}
+	_ = os.RemoveAll(filepath.Join(s.dir, id))
+	return session, nil
+}
+
+// Abort discards an upload.
+// Abort 丢弃一个上传。
+func (s *Store) Abort(id string) error {
+	if !s.acquire(id) {
+		return ErrUploadBusy
+	}
+	defer s.release(id)
+
+	if _, err := s.load(id); err != nil {
+		return err
+	}
+	return os.RemoveAll(filepath.Join(s.dir, id))
+}
+
+// PurgeExpired removes uploads that have not been touched for longer than the TTL.
+// PurgeExpired 删除超过 TTL 未更新的上传。
+func (s *Store) PurgeExpired() {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return
+	}
+	cutoff := time.Now().Add(-s.ttl)
+	for _, entry := range entries {
+		if !entry.IsDir() || !s.acquire(entry.Name()) {
+			continue
+		}
+		session, err := s.load(entry.Name())
+		if errors.Is(err, ErrUploadNotFound) || (err == nil && session.UpdatedAt.Before(cutoff)) {
+			_ = os.RemoveAll(filepath.Join(s.dir, entry.Name()))
+		}
+		s.release(entry.Name())
+	}
+}
+
+func (s *Store) acquire(id string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.busy[id] {
+		return false
+	}
+	s.busy[id] = true
+	return true
+}
+
+func (s *Store) release(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.busy, id)
+}
+
+func (s *Store) dataPath(id string) string {
+	return filepath.Join(s.dir, id, dataFileName)
+}
+
+func (s *Store) load(id string) (*Session, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, ErrUploadNotFound
+	}
+	content, err := os.ReadFile(filepath.Join(s.dir, id, stateFileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrUploadNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read upload state: %w", err)
+	}
+	var session Session
+	if err := json.Unmarshal(content, &session); err != nil {
+		return nil, ErrUploadNotFound
+	}
+	return &session, nil
+}
+
+// save writes the state through a temporary file so a crash never leaves it half written.
+// save 通过临时文件写入状态，确保崩溃时不会留下写了一半的状态文件。
+func (s *Store) save(session *Session) error {
+	content, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+	statePath := filepath.Join(s.dir, session.ID, stateFileName)
+	tmpPath := statePath + ".tmp"
+	if err := os.WriteFile(tmpPath, content, 0644); err != nil {
+		return fmt.Errorf("failed to write upload state: %w", err)
+	}
+	if err := os.Rename(tmpPath, statePath); err != nil {
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("failed to write upload state: %w", err)
+	}
+	return nil
+}
+
+func fileSHA256(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to open upload file: %w", err)
+	}
+	defer file.Close()
+	hasher := sha256.New()
+	if _, err := io.Copy(hasher, file); err != nil {
+		return "", fmt.Errorf("failed to hash upload file: %w", err)
+	}
+	return hex.EncodeToString(hasher.Sum(nil)), nil
+}
+
+// StatusCode maps the errors of this package to HTTP status codes; other errors map to 0.
+// StatusCode 将本包的错误映射为 HTTP 状态码，其他错误返回 0。
+func StatusCode(err error) int {
+	switch {
+	case errors.Is(err, ErrInvalidUpload), errors.Is(err, ErrChecksumMismatch):
+		return http.StatusBadRequest
+	case errors.Is(err, ErrUploadNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrUploadBusy), errors.Is(err, ErrOffsetMismatch), errors.Is(err, ErrUploadIncomplete):
+		return http.StatusConflict
+	case errors.Is(err, ErrUploadTooLarge):
+		return http.StatusRequestEntityTooLarge
+	}
+	return 0
+}
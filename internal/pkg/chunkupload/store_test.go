@@ -0,0 +1,133 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package chunkupload
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func checksumOf(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// failingReader returns some bytes and then fails, like a dropped connection.
+type failingReader struct {
+	data []byte
+}
+
+func (r *failingReader) Read(p []byte) (int, error) {
+	if len(r.data) == 0 {
+		return 0, io.ErrUnexpectedEOF
+	}
+	n := copy(p, r.data)
+	r.data = r.data[n:]
+	return n, nil
+}
+
+func TestStoreResumesAfterInterruptedChunk(t *testing.T) {
+	store := NewStore(t.TempDir(), time.Hour)
+	content := []byte("0123456789abcdefghij")
+	session, err := store.Init(InitRequest{FileName: "connector.jar", TotalSize: int64(len(content)), SHA256: checksumOf(content)})
+	if err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+
+	if session, err = store.Append(session.ID, 0, checksumOf(content[:8]), bytes.NewReader(content[:8])); err != nil || session.Offset != 8 {
+		t.Fatalf("first chunk: offset=%v err=%v", session, err)
+	}
+
+	// The connection drops halfway through the second chunk.
+	if _, err := store.Append(session.ID, 8, "", &failingReader{data: content[8:12]}); err == nil {
+		t.Fatal("expected the interrupted chunk to fail")
+	}
+	current, err := store.Get(session.ID)
+	if err != nil || current.Offset != 8 {
+		t.Fatalf("expected resume offset 8, got %+v err=%v", current, err)
+	}
+
+	// A stale offset is rejected and reports where to resume.
+	if current, err := store.Append(session.ID, 4, "", bytes.NewReader(content[4:8])); !errors.Is(err, ErrOffsetMismatch) || current.Offset != 8 {
+		t.Fatalf("expected offset mismatch at 8, got %+v err=%v", current, err)
+	}
+	// A corrupted chunk is rejected and leaves the offset untouched.
+	if _, err := store.Append(session.ID, 8, checksumOf(content[8:]), bytes.NewReader([]byte("XXXXXXXXXXXX"))); !errors.Is(err, ErrChecksumMismatch) {
+		t.Fatalf("expected checksum mismatch, got %v", err)
+	}
+	if _, err := store.Complete(session.ID, func(*Session, string) error { return nil }); !errors.Is(err, ErrUploadIncomplete) {
+		t.Fatalf("expected incomplete upload, got %v", err)
+	}
+
+	if session, err = store.Append(session.ID, 8, checksumOf(content[8:]), bytes.NewReader(content[8:])); err != nil || session.Offset != int64(len(content)) {
+		t.Fatalf("second chunk: %+v err=%v", session, err)
+	}
+
+	var received []byte
+	if _, err := store.Complete(session.ID, func(_ *Session, path string) error {
+		received, err = os.ReadFile(path)
+		return err
+	}); err != nil {
+		t.Fatalf("Complete failed: %v", err)
+	}
+	if !bytes.Equal(received, content) {
+		t.Fatalf("unexpected content %q", received)
+	}
+	if _, err := store.Get(session.ID); !errors.Is(err, ErrUploadNotFound) {
+		t.Fatalf("expected the completed upload to be removed, got %v", err)
+	}
+}
+
+func TestStoreRejectsOversizedChunk(t *testing.T) {
+	store := NewStore(t.TempDir(), time.Hour)
+	session, err := store.Init(InitRequest{FileName: "package.tar.gz", TotalSize: 4})
+	if err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+	if _, err := store.Append(session.ID, 0, "", bytes.NewReader([]byte("12345"))); !errors.Is(err, ErrUploadTooLarge) {
+		t.Fatalf("expected ErrUploadTooLarge, got %v", err)
+	}
+	if current, _ := store.Get(session.ID); current.Offset != 0 {
+		t.Fatalf("expected offset 0, got %d", current.Offset)
+	}
+}
+
+func TestStorePurgesExpiredUploads(t *testing.T) {
+	dir := t.TempDir()
+	store := NewStore(dir, time.Hour)
+	session, err := store.Init(InitRequest{FileName: "package.tar.gz", TotalSize: 4})
+	if err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+	session.UpdatedAt = time.Now().Add(-2 * time.Hour)
+	if err := store.save(session); err != nil {
+		t.Fatalf("save failed: %v", err)
+	}
+
+	store.PurgeExpired()
+	if _, err := os.Stat(filepath.Join(dir, session.ID)); !os.IsNotExist(err) {
+		t.Fatalf("expected the expired upload to be purged, got %v", err)
+	}
+}
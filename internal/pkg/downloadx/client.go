@@ -0,0 +1,101 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package downloadx builds HTTP clients for outbound package and plugin downloads,
+// applying the configured proxy and custom mirror credentials.
+// downloadx 包为安装包和插件下载构建 HTTP 客户端，应用配置的代理和自定义镜像凭据。
+package downloadx
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/seatunnel/seatunnelX/internal/config"
+	"golang.org/x/net/http/httpproxy"
+)
+
+// NewClient returns an HTTP client honoring download.proxy and custom mirror basic auth.
+// NewClient 返回遵循 download.proxy 和自定义镜像 Basic 认证的 HTTP 客户端。
+func NewClient(timeout time.Duration) *http.Client {
+	return NewClientWithConfig(config.GetDownloadConfig(), timeout)
+}
+
+// NewClientWithConfig returns an HTTP client for the given download configuration.
+// NewClientWithConfig 根据给定下载配置返回 HTTP 客户端。
+func NewClientWithConfig(cfg config.DownloadConfig, timeout time.Duration) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = proxyFunc(cfg.Proxy)
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: &authTransport{base: transport, mirrors: cfg.Mirrors},
+	}
+}
+
+// proxyFunc uses the configured proxy, falling back to the standard proxy environment variables.
+// proxyFunc 使用配置的代理，未配置时回退到标准代理环境变量。
+func proxyFunc(cfg config.DownloadProxyConfig) func(*http.Request) (*url.URL, error) {
+	if strings.TrimSpace(cfg.HTTPProxy) == "" && strings.TrimSpace(cfg.HTTPSProxy) == "" {
+		return http.ProxyFromEnvironment
+	}
+	proxyConfig := &httpproxy.Config{
+		HTTPProxy:  strings.TrimSpace(cfg.HTTPProxy),
+		HTTPSProxy: strings.TrimSpace(cfg.HTTPSProxy),
+		NoProxy:    strings.TrimSpace(cfg.NoProxy),
+	}
+	resolve := proxyConfig.ProxyFunc()
+	return func(req *http.Request) (*url.URL, error) {
+		return resolve(req.URL)
+	}
+}
+
+// authTransport adds basic auth to requests addressed to a custom mirror with credentials.
+// authTransport 为发往带凭据自定义镜像的请求添加 Basic 认证。
+type authTransport struct {
+	base    http.RoundTripper
+	mirrors []config.DownloadMirrorConfig
+}
+
+// RoundTrip implements http.RoundTripper.
+// RoundTrip 实现 http.RoundTripper。
+func (t *authTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Header.Get("Authorization") == "" {
+		if mirror, ok := matchMirror(t.mirrors, req.URL.String()); ok {
+			req = req.Clone(req.Context())
+			req.SetBasicAuth(mirror.Username, mirror.Password)
+		}
+	}
+	return t.base.RoundTrip(req)
+}
+
+// matchMirror finds the credentialed mirror whose package or Maven base URL prefixes target.
+// matchMirror 查找包或 Maven 基础 URL 为 target 前缀且带凭据的镜像。
+func matchMirror(mirrors []config.DownloadMirrorConfig, target string) (config.DownloadMirrorConfig, bool) {
+	for _, mirror := range mirrors {
+		if mirror.Username == "" && mirror.Password == "" {
+			continue
+		}
+		for _, base := range []string{mirror.PackageBaseURL, mirror.MavenBaseURL} {
+			base = strings.TrimSuffix(strings.TrimSpace(base), "/")
+			if base != "" && (target == base || strings.HasPrefix(target, base+"/")) {
+				return mirror, true
+			}
+		}
+	}
+	return config.DownloadMirrorConfig{}, false
+}
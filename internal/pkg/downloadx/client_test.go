@@ -0,0 +1,79 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package downloadx
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/seatunnel/seatunnelX/internal/config"
+)
+
+func TestNewClientWithConfig_AddsMirrorBasicAuth(t *testing.T) {
+	var gotUser, gotPassword string
+	var gotAuth bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotUser, gotPassword, gotAuth = r.BasicAuth()
+	}))
+	defer server.Close()
+
+	client := NewClientWithConfig(config.DownloadConfig{
+		Mirrors: []config.DownloadMirrorConfig{
+			{Name: "nexus", PackageBaseURL: server.URL + "/seatunnel/", Username: "deploy", Password: "secret"},
+		},
+	}, 5*time.Second)
+
+	resp, err := client.Get(server.URL + "/seatunnel/2.3.12/apache-seatunnel-2.3.12-bin.tar.gz")
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	resp.Body.Close()
+	if !gotAuth || gotUser != "deploy" || gotPassword != "secret" {
+		t.Fatalf("expected basic auth for mirror URL, got auth=%v user=%q", gotAuth, gotUser)
+	}
+
+	resp, err = client.Get(server.URL + "/seatunnel-other/file")
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	resp.Body.Close()
+	if gotAuth {
+		t.Fatalf("expected no basic auth outside the mirror base URL")
+	}
+}
+
+func TestProxyFunc_UsesConfiguredProxy(t *testing.T) {
+	proxy := proxyFunc(config.DownloadProxyConfig{
+		HTTPSProxy: "http://proxy.example.com:3128",
+		NoProxy:    "internal.example.com",
+	})
+
+	req := &http.Request{URL: &url.URL{Scheme: "https", Host: "archive.apache.org"}}
+	proxyURL, err := proxy(req)
+	if err != nil || proxyURL == nil || proxyURL.Host != "proxy.example.com:3128" {
+		t.Fatalf("expected configured https proxy, got %v err=%v", proxyURL, err)
+	}
+
+	req = &http.Request{URL: &url.URL{Scheme: "https", Host: "internal.example.com"}}
+	if proxyURL, err := proxy(req); err != nil || proxyURL != nil {
+		t.Fatalf("expected no_proxy host to bypass proxy, got %v err=%v", proxyURL, err)
+	}
+}
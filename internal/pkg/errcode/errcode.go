@@ -0,0 +1,300 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package errcode defines the error-code catalog shared by the Agent and the Control Plane.
+// errcode 包定义 Agent 与 Control Plane 共享的错误码目录。
+//
+// Codes are stable strings such as ST-AGENT-001 that travel in CommandResponse.error_code
+// and in the error_code field of HTTP error bodies, so clients can branch on them instead
+// of parsing messages.
+// 错误码是稳定的字符串（如 ST-AGENT-001），通过 CommandResponse.error_code 与 HTTP 错误响应
+// 的 error_code 字段传递，客户端可据此分支处理而无需解析错误消息。
+package errcode
+
+import (
+	"errors"
+	"net/http"
+)
+
+// Code is a catalog error code.
+// Code 是目录中的错误码。
+type Code string
+
+// General codes / 通用错误码
+const (
+	InvalidRequest Code = "ST-GEN-001"
+	Unauthorized   Code = "ST-GEN-002"
+	Forbidden      Code = "ST-GEN-003"
+	NotFound       Code = "ST-GEN-004"
+	Conflict       Code = "ST-GEN-005"
+	Internal       Code = "ST-GEN-006"
+	Unavailable    Code = "ST-GEN-007"
+	Timeout        Code = "ST-GEN-008"
+	RateLimited    Code = "ST-GEN-009"
+)
+
+// Agent and command dispatch codes / Agent 与命令下发错误码
+const (
+	AgentNotConnected     Code = "ST-AGENT-001"
+	AgentNotFound         Code = "ST-AGENT-002"
+	CommandTimeout        Code = "ST-AGENT-003"
+	CommandQueueExpired   Code = "ST-AGENT-004"
+	CommandCancelled      Code = "ST-AGENT-005"
+	ControlPlaneDraining  Code = "ST-AGENT-006"
+	AgentRejected         Code = "ST-AGENT-007"
+	CommandNotFound       Code = "ST-AGENT-008"
+	PendingAgentNotFound  Code = "ST-AGENT-009"
+	CommandStreamNotReady Code = "ST-AGENT-010"
+)
+
+// Agent-side execution codes / Agent 端执行错误码
+const (
+	CommandFailed      Code = "ST-EXEC-001"
+	UnsupportedCommand Code = "ST-EXEC-002"
+	InvalidParameters  Code = "ST-EXEC-003"
+)
+
+// Installation codes / 安装错误码
+const (
+	PackageNotFound          Code = "ST-INST-001"
+	InstallDirNotWritable    Code = "ST-INST-002"
+	InvalidDeploymentMode    Code = "ST-INST-003"
+	InvalidNodeRole          Code = "ST-INST-004"
+	InvalidMirrorSource      Code = "ST-INST-005"
+	ConfigGenerationFailed   Code = "ST-INST-006"
+	ClusterMembershipTimeout Code = "ST-INST-007"
+	DownloadFailed           Code = "ST-INST-010"
+	ExtractionFailed         Code = "ST-INST-011"
+	ChecksumMismatch         Code = "ST-INST-012"
+)
+
+// Precheck codes / 预检查错误码
+const (
+	PrecheckFailed Code = "ST-PRE-001"
+)
+
+// Cluster codes / 集群错误码
+const (
+	ClusterNotFound        Code = "ST-CLUSTER-001"
+	ClusterNameDuplicate   Code = "ST-CLUSTER-002"
+	ClusterNodeNotFound    Code = "ST-CLUSTER-003"
+	ClusterNodeExists      Code = "ST-CLUSTER-004"
+	NodeAgentNotInstalled  Code = "ST-CLUSTER-005"
+	ClusterHasRunningTask  Code = "ST-CLUSTER-006"
+	ClusterNotRunning      Code = "ST-CLUSTER-007"
+	ScaleInProgress        Code = "ST-CLUSTER-008"
+	CrossProjectNode       Code = "ST-CLUSTER-009"
+	NodeSelectorMismatch   Code = "ST-CLUSTER-010"
+	ClusterAlreadyAdopted  Code = "ST-CLUSTER-011"
+	ClusterAdoptionFailure Code = "ST-CLUSTER-012"
+)
+
+// Host codes / 主机错误码
+const (
+	HostNotFound      Code = "ST-HOST-001"
+	HostNameDuplicate Code = "ST-HOST-002"
+	HostIPDuplicate   Code = "ST-HOST-003"
+	HostIPInvalid     Code = "ST-HOST-004"
+	HostHasCluster    Code = "ST-HOST-005"
+	HostLabelInvalid  Code = "ST-HOST-006"
+	HostImportInvalid Code = "ST-HOST-007"
+)
+
+// Entry describes one catalog code.
+// Entry 描述目录中的一个错误码。
+type Entry struct {
+	Code       Code   `json:"code"`
+	Name       string `json:"name"`
+	HTTPStatus int    `json:"http_status"`
+	Message    string `json:"message"`
+}
+
+var catalog = []Entry{
+	{InvalidRequest, "invalid_request", http.StatusBadRequest, "Invalid request / 请求无效"},
+	{Unauthorized, "unauthorized", http.StatusUnauthorized, "Authentication required / 需要认证"},
+	{Forbidden, "forbidden", http.StatusForbidden, "Permission denied / 无权限"},
+	{NotFound, "not_found", http.StatusNotFound, "Resource not found / 资源不存在"},
+	{Conflict, "conflict", http.StatusConflict, "Resource conflict / 资源冲突"},
+	{Internal, "internal", http.StatusInternalServerError, "Internal error / 内部错误"},
+	{Unavailable, "unavailable", http.StatusServiceUnavailable, "Service unavailable / 服务不可用"},
+	{Timeout, "timeout", http.StatusGatewayTimeout, "Operation timed out / 操作超时"},
+	{RateLimited, "rate_limited", http.StatusTooManyRequests, "Too many requests / 请求过于频繁"},
+
+	{AgentNotConnected, "agent_not_connected", http.StatusServiceUnavailable, "Agent is not connected / Agent 未连接"},
+	{AgentNotFound, "agent_not_found", http.StatusNotFound, "Agent not found / Agent 不存在"},
+	{CommandTimeout, "command_timeout", http.StatusGatewayTimeout, "Command timed out / 命令执行超时"},
+	{CommandQueueExpired, "command_queue_expired", http.StatusServiceUnavailable, "Queued command expired before the agent reconnected / 排队命令在 Agent 重连前过期"},
+	{CommandCancelled, "command_cancelled", http.StatusConflict, "Command was cancelled / 命令已取消"},
+	{ControlPlaneDraining, "control_plane_draining", http.StatusServiceUnavailable, "Control Plane is draining / Control Plane 正在排空"},
+	{AgentRejected, "agent_rejected", http.StatusForbidden, "Agent registration rejected / Agent 注册被拒绝"},
+	{CommandNotFound, "command_not_found", http.StatusNotFound, "Command not found / 命令不存在"},
+	{PendingAgentNotFound, "pending_agent_not_found", http.StatusNotFound, "Pending agent not found / 待审批 Agent 不存在"},
+	{CommandStreamNotReady, "command_stream_not_ready", http.StatusServiceUnavailable, "Agent command stream not available / Agent 命令流不可用"},
+
+	{CommandFailed, "command_failed", http.StatusInternalServerError, "Command failed on the agent / Agent 执行命令失败"},
+	{UnsupportedCommand, "unsupported_command", http.StatusBadRequest, "Command type not supported by the agent / Agent 不支持该命令类型"},
+	{InvalidParameters, "invalid_parameters", http.StatusBadRequest, "Invalid command parameters / 命令参数无效"},
+
+	{PackageNotFound, "package_not_found", http.StatusNotFound, "Installation package not found / 安装包不存在"},
+	{InstallDirNotWritable, "install_dir_not_writable", http.StatusBadRequest, "Install directory is not writable / 安装目录不可写"},
+	{InvalidDeploymentMode, "invalid_deployment_mode", http.StatusBadRequest, "Invalid deployment mode / 部署模式无效"},
+	{InvalidNodeRole, "invalid_node_role", http.StatusBadRequest, "Invalid node role / 节点角色无效"},
+	{InvalidMirrorSource, "invalid_mirror_source", http.StatusBadRequest, "Invalid mirror source / 镜像源无效"},
+	{ConfigGenerationFailed, "config_generation_failed", http.StatusInternalServerError, "Failed to generate configuration / 生成配置失败"},
+	{ClusterMembershipTimeout, "cluster_membership_timeout", http.StatusGatewayTimeout, "Node did not join the cluster in time / 节点未按时加入集群"},
+	{DownloadFailed, "download_failed", http.StatusBadGateway, "Package download failed / 安装包下载失败"},
+	{ExtractionFailed, "extraction_failed", http.StatusInternalServerError, "Package extraction failed / 安装包解压失败"},
+	{ChecksumMismatch, "checksum_mismatch", http.StatusUnprocessableEntity, "Package checksum mismatch / 安装包校验和不匹配"},
+
+	{PrecheckFailed, "precheck_failed", http.StatusBadRequest, "Precheck failed / 预检查失败"},
+
+	{ClusterNotFound, "cluster_not_found", http.StatusNotFound, "Cluster not found / 集群不存在"},
+	{ClusterNameDuplicate, "cluster_name_duplicate", http.StatusConflict, "Cluster name already exists / 集群名称已存在"},
+	{ClusterNodeNotFound, "cluster_node_not_found", http.StatusNotFound, "Cluster node not found / 集群节点不存在"},
+	{ClusterNodeExists, "cluster_node_exists", http.StatusConflict, "Node already exists in the cluster / 节点已存在于集群中"},
+	{NodeAgentNotInstalled, "node_agent_not_installed", http.StatusBadRequest, "Host agent is not installed / 主机未安装 Agent"},
+	{ClusterHasRunningTask, "cluster_has_running_task", http.StatusConflict, "Cluster has a running task / 集群存在运行中的任务"},
+	{ClusterNotRunning, "cluster_not_running", http.StatusBadRequest, "Cluster is not running / 集群未运行"},
+	{ScaleInProgress, "scale_in_progress", http.StatusConflict, "A scale operation is already in progress / 扩缩容操作正在进行"},
+	{CrossProjectNode, "cross_project_node", http.StatusForbidden, "Host belongs to another project / 主机属于其他项目"},
+	{NodeSelectorMismatch, "node_selector_mismatch", http.StatusBadRequest, "Host does not match the node selector / 主机不匹配节点选择器"},
+	{ClusterAlreadyAdopted, "cluster_already_adopted", http.StatusConflict, "Cluster is already managed / 集群已被纳管"},
+	{ClusterAdoptionFailure, "cluster_adoption_failed", http.StatusBadRequest, "Cluster adoption failed / 集群纳管失败"},
+
+	{HostNotFound, "host_not_found", http.StatusNotFound, "Host not found / 主机不存在"},
+	{HostNameDuplicate, "host_name_duplicate", http.StatusConflict, "Host name already exists / 主机名已存在"},
+	{HostIPDuplicate, "host_ip_duplicate", http.StatusConflict, "Host IP already exists / 主机 IP 已存在"},
+	{HostIPInvalid, "host_ip_invalid", http.StatusBadRequest, "Invalid host IP address / 主机 IP 地址无效"},
+	{HostHasCluster, "host_has_cluster", http.StatusConflict, "Host is used by clusters / 主机被集群使用"},
+	{HostLabelInvalid, "host_label_invalid", http.StatusBadRequest, "Invalid host label or selector / 主机标签或选择器无效"},
+	{HostImportInvalid, "host_import_invalid", http.StatusBadRequest, "Invalid host import / 主机导入内容无效"},
+}
+
+var catalogIndex = func() map[Code]Entry {
+	index := make(map[Code]Entry, len(catalog))
+	for _, entry := range catalog {
+		index[entry.Code] = entry
+	}
+	return index
+}()
+
+// Catalog returns a copy of every registered code.
+// Catalog 返回所有已登记错误码的副本。
+func Catalog() []Entry {
+	out := make([]Entry, len(catalog))
+	copy(out, catalog)
+	return out
+}
+
+// Lookup returns the catalog entry for code.
+// Lookup 返回错误码对应的目录条目。
+func Lookup(code Code) (Entry, bool) {
+	entry, ok := catalogIndex[code]
+	return entry, ok
+}
+
+// Name returns the snake_case name of code, or "" when the code is unknown.
+// Name 返回错误码的 snake_case 名称，未知错误码返回空字符串。
+func (c Code) Name() string {
+	return catalogIndex[c].Name
+}
+
+// Error is an error tagged with a catalog code.
+// Error 是带有目录错误码的错误。
+type Error struct {
+	Code Code
+	Err  error
+}
+
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// New returns a sentinel error carrying code; errors.Is keeps working on the returned value.
+// New 返回携带错误码的哨兵错误；errors.Is 对返回值照常生效。
+func New(code Code, msg string) error {
+	return &Error{Code: code, Err: errors.New(msg)}
+}
+
+// Wrap tags err with code. A nil err stays nil.
+// Wrap 为 err 标记错误码，err 为 nil 时返回 nil。
+func Wrap(code Code, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &Error{Code: code, Err: err}
+}
+
+// Of returns the outermost code attached to err's chain.
+// Of 返回 err 链上最外层的错误码。
+func Of(err error) (Code, bool) {
+	var coded *Error
+	if errors.As(err, &coded) {
+		return coded.Code, true
+	}
+	return "", false
+}
+
+// OrDefault returns the code attached to err, or fallback when none is attached.
+// OrDefault 返回 err 携带的错误码，未携带时返回 fallback。
+func OrDefault(err error, fallback Code) Code {
+	if code, ok := Of(err); ok {
+		return code
+	}
+	return fallback
+}
+
+// ForHTTPStatus returns the general code for an HTTP status.
+// ForHTTPStatus 返回 HTTP 状态码对应的通用错误码。
+func ForHTTPStatus(status int) Code {
+	switch status {
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return InvalidRequest
+	case http.StatusUnauthorized:
+		return Unauthorized
+	case http.StatusForbidden:
+		return Forbidden
+	case http.StatusNotFound:
+		return NotFound
+	case http.StatusConflict:
+		return Conflict
+	case http.StatusTooManyRequests:
+		return RateLimited
+	case http.StatusServiceUnavailable, http.StatusBadGateway:
+		return Unavailable
+	case http.StatusGatewayTimeout, http.StatusRequestTimeout:
+		return Timeout
+	}
+	if status >= http.StatusInternalServerError {
+		return Internal
+	}
+	return InvalidRequest
+}
+
+// ContextKey is the request-context key under which HTTP handlers record the code of a failed request.
+// ContextKey 是 HTTP 处理器记录失败请求错误码所用的上下文键。
+const ContextKey = "errcode"
+
+// Attach records code on a request context (e.g. *gin.Context) for the error-code middleware.
+// Attach 在请求上下文（如 *gin.Context）上记录错误码，供错误码中间件使用。
+func Attach(c interface{ Set(key string, value any) }, code Code) {
+	c.Set(ContextKey, code)
+}
@@ -0,0 +1,83 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package errcode
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"testing"
+)
+
+func TestCatalogCodesAreUniqueAndWellFormed(t *testing.T) {
+	pattern := regexp.MustCompile(`^ST-[A-Z]+-\d{3}$`)
+	seenNames := map[string]Code{}
+	for _, entry := range Catalog() {
+		if !pattern.MatchString(string(entry.Code)) {
+			t.Errorf("malformed code %q", entry.Code)
+		}
+		if prev, ok := seenNames[entry.Name]; ok {
+			t.Errorf("name %q used by %s and %s", entry.Name, prev, entry.Code)
+		}
+		seenNames[entry.Name] = entry.Code
+		if entry.HTTPStatus < 400 || entry.Message == "" {
+			t.Errorf("%s: incomplete entry %+v", entry.Code, entry)
+		}
+	}
+	if len(catalogIndex) != len(catalog) {
+		t.Fatalf("duplicate codes in catalog: %d entries, %d unique", len(catalog), len(catalogIndex))
+	}
+	if ChecksumMismatch != "ST-INST-012" || ChecksumMismatch.Name() != "checksum_mismatch" {
+		t.Fatalf("checksum_mismatch = %s/%s", ChecksumMismatch, ChecksumMismatch.Name())
+	}
+}
+
+func TestOfFindsCodeThroughWrapping(t *testing.T) {
+	sentinel := New(AgentNotConnected, "agent: agent not connected")
+	err := fmt.Errorf("dispatch install: %w", sentinel)
+
+	if !errors.Is(err, sentinel) {
+		t.Fatal("errors.Is must match the coded sentinel")
+	}
+	if code, ok := Of(err); !ok || code != AgentNotConnected {
+		t.Fatalf("Of = %q, %v", code, ok)
+	}
+	if got := OrDefault(errors.New("plain"), CommandFailed); got != CommandFailed {
+		t.Fatalf("OrDefault = %q", got)
+	}
+	if Wrap(Internal, nil) != nil {
+		t.Fatal("Wrap(nil) must stay nil")
+	}
+}
+
+func TestForHTTPStatus(t *testing.T) {
+	cases := map[int]Code{
+		http.StatusBadRequest:          InvalidRequest,
+		http.StatusNotFound:            NotFound,
+		http.StatusConflict:            Conflict,
+		http.StatusServiceUnavailable:  Unavailable,
+		http.StatusInternalServerError: Internal,
+		http.StatusNotImplemented:      Internal,
+	}
+	for status, want := range cases {
+		if got := ForHTTPStatus(status); got != want {
+			t.Errorf("ForHTTPStatus(%d) = %s, want %s", status, got, want)
+		}
+	}
+}
@@ -0,0 +1,143 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package eventbus is the in-process publish/subscribe channel between modules.
+// Topic events (Agent online/offline, cluster and node status, running installations) are streamed
+// to live views; typed events (AgentRegistered, InstallCompleted, NodeCrashed...) let modules react
+// to each other without being wired together directly.
+// eventbus 包是模块之间的进程内发布/订阅通道。
+// 主题事件（Agent 上下线、集群与节点状态、运行中的安装）以流的形式推送给实时视图；
+// 类型化事件（AgentRegistered、InstallCompleted、NodeCrashed 等）使模块无需直接相互装配即可响应彼此。
+package eventbus
+
+import (
+	"reflect"
+	"sync"
+	"time"
+)
+
+// Topic identifies the kind of state change carried by an Event.
+// Topic 标识 Event 所携带的状态变化类型。
+type Topic string
+
+const (
+	// TopicAgentStatus is published when an Agent goes online, offline or disconnects.
+	// TopicAgentStatus 在 Agent 上线、离线或断开连接时发布。
+	TopicAgentStatus Topic = "agent_status"
+	// TopicClusterStatus is published when the status of a cluster changes.
+	// TopicClusterStatus 在集群状态变化时发布。
+	TopicClusterStatus Topic = "cluster_status"
+	// TopicNodeStatus is published when the status of a cluster node changes.
+	// TopicNodeStatus 在集群节点状态变化时发布。
+	TopicNodeStatus Topic = "node_status"
+	// TopicInstallations is published when the number of running installations changes.
+	// TopicInstallations 在运行中的安装数量变化时发布。
+	TopicInstallations Topic = "installations"
+)
+
+// subscriberBuffer bounds how far a slow subscriber may lag before events are dropped for it.
+// subscriberBuffer 限制慢订阅者可积压的事件数，超出后丢弃其事件。
+const subscriberBuffer = 64
+
+// Event is one state change.
+// Event 表示一次状态变化。
+type Event struct {
+	Topic     Topic     `json:"type"`
+	Timestamp time.Time `json:"timestamp"`
+	Data      any       `json:"data"`
+}
+
+// Bus fans topic events out to stream subscribers without blocking publishers,
+// and dispatches typed events (see events.go) to the handlers registered for their type.
+// Bus 将主题事件分发给流订阅者且不会阻塞发布方，
+// 并将类型化事件（见 events.go）分发给为该类型注册的处理器。
+type Bus struct {
+	mu          sync.RWMutex
+	subscribers map[chan Event]struct{}
+
+	handlersMu    sync.RWMutex
+	handlers      map[reflect.Type][]*typedHandler
+	nextHandlerID uint64
+}
+
+// New creates an empty Bus.
+// New 创建空的 Bus。
+func New() *Bus {
+	return &Bus{
+		subscribers: make(map[chan Event]struct{}),
+		handlers:    make(map[reflect.Type][]*typedHandler),
+	}
+}
+
+// defaultBus is the process-wide bus used by Publish and Subscribe.
+// defaultBus 是 Publish 与 Subscribe 使用的进程级 Bus。
+var defaultBus = New()
+
+// Default returns the process-wide bus.
+// Default 返回进程级 Bus。
+func Default() *Bus {
+	return defaultBus
+}
+
+// Publish publishes an event on the process-wide bus.
+// Publish 在进程级 Bus 上发布事件。
+func Publish(topic Topic, data any) {
+	defaultBus.Publish(topic, data)
+}
+
+// Subscribe subscribes to the process-wide bus.
+// Subscribe 订阅进程级 Bus。
+func Subscribe() (<-chan Event, func()) {
+	return defaultBus.Subscribe()
+}
+
+// Publish delivers an event to every subscriber; subscribers whose buffer is full miss it.
+// Publish 将事件投递给所有订阅者；缓冲区已满的订阅者会错过该事件。
+func (b *Bus) Publish(topic Topic, data any) {
+	if b == nil {
+		return
+	}
+	event := Event{Topic: topic, Timestamp: time.Now(), Data: data}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for ch := range b.subscribers {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
+
+// Subscribe returns a channel of subsequent events and a function that cancels the subscription.
+// Subscribe 返回后续事件的通道以及取消订阅的函数。
+func (b *Bus) Subscribe() (<-chan Event, func()) {
+	ch := make(chan Event, subscriberBuffer)
+
+	b.mu.Lock()
+	b.subscribers[ch] = struct{}{}
+	b.mu.Unlock()
+
+	return ch, func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		if _, ok := b.subscribers[ch]; ok {
+			delete(b.subscribers, ch)
+			close(ch)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package eventbus
+
+import (
+	"testing"
+)
+
+func TestBusDeliversToAllSubscribers(t *testing.T) {
+	bus := New()
+	first, cancelFirst := bus.Subscribe()
+	defer cancelFirst()
+	second, cancelSecond := bus.Subscribe()
+
+	bus.Publish(TopicAgentStatus, "online")
+
+	for _, ch := range []<-chan Event{first, second} {
+		event := <-ch
+		if event.Topic != TopicAgentStatus || event.Data != "online" || event.Timestamp.IsZero() {
+			t.Fatalf("unexpected event %+v", event)
+		}
+	}
+
+	cancelSecond()
+	cancelSecond()
+	if _, ok := <-second; ok {
+		t.Fatal("expected cancelled subscription to be closed")
+	}
+	bus.Publish(TopicClusterStatus, "running")
+	if event := <-first; event.Topic != TopicClusterStatus {
+		t.Fatalf("unexpected event %+v", event)
+	}
+}
+
+func TestBusDropsEventsForSlowSubscriber(t *testing.T) {
+	bus := New()
+	ch, cancel := bus.Subscribe()
+	defer cancel()
+
+	for i := 0; i < subscriberBuffer+10; i++ {
+		bus.Publish(TopicInstallations, i)
+	}
+	if len(ch) != subscriberBuffer {
+		t.Fatalf("expected %d buffered events, got %d", subscriberBuffer, len(ch))
+	}
+	if event := <-ch; event.Data != 0 {
+		t.Fatalf("expected oldest event first, got %+v", event)
+	}
+}
+
+func TestNilBusPublishIsNoop(t *testing.T) {
+	var bus *Bus
+	bus.Publish(TopicNodeStatus, nil)
+}
@@ -0,0 +1,155 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package eventbus
+
+import (
+	"context"
+	"log"
+	"reflect"
+	"runtime/debug"
+)
+
+// AgentRegistered is emitted after an Agent registered with the control plane.
+// AgentRegistered 在 Agent 向控制面注册后发出。
+type AgentRegistered struct {
+	AgentID   string
+	HostID    uint
+	IPAddress string
+	Hostname  string
+	Version   string
+}
+
+// AgentOffline is emitted when an Agent misses its heartbeats or its command stream disconnects.
+// AgentOffline 在 Agent 心跳超时或命令流断开时发出。
+type AgentOffline struct {
+	AgentID   string
+	HostID    uint
+	IPAddress string
+	Hostname  string
+	// Status is "offline" for a heartbeat timeout and "disconnected" for a closed stream.
+	// Status 心跳超时为 "offline"，流关闭为 "disconnected"。
+	Status string
+}
+
+// InstallCompleted is emitted when a SeaTunnel installation on a host ends, successfully or not.
+// InstallCompleted 在主机上的 SeaTunnel 安装结束（无论成功与否）时发出。
+type InstallCompleted struct {
+	InstallationID string
+	HostID         string
+	ClusterID      string
+	Version        string
+	CurrentStep    string
+	Success        bool
+	Error          string
+	Message        string
+}
+
+// NodeCrashed is emitted when the health checker finds a running node unhealthy and marks it as error.
+// NodeCrashed 在健康检查发现运行中的节点不健康并将其标记为 error 时发出。
+type NodeCrashed struct {
+	ClusterID   uint
+	ClusterName string
+	NodeID      uint
+	HostID      uint
+	Role        string
+	Failures    int
+	Error       string
+}
+
+// PluginInstalled is emitted when installing a plugin to a cluster ends, successfully or not.
+// PluginInstalled 在向集群安装插件结束（无论成功与否）时发出。
+type PluginInstalled struct {
+	ClusterID  uint
+	PluginName string
+	Version    string
+	Success    bool
+	Error      string
+}
+
+// typedHandler is one registered handler of a typed event.
+// typedHandler 是类型化事件的一个已注册处理器。
+type typedHandler struct {
+	id uint64
+	fn func(context.Context, any)
+}
+
+// Listen registers handler for events of type E on b and returns a function that removes it.
+// Handlers run synchronously in the emitting goroutine, in registration order.
+// Listen 在 b 上为类型 E 的事件注册处理器，并返回移除该处理器的函数。
+// 处理器在发出事件的 goroutine 中按注册顺序同步执行。
+func Listen[E any](b *Bus, handler func(context.Context, E)) func() {
+	eventType := reflect.TypeOf((*E)(nil)).Elem()
+
+	b.handlersMu.Lock()
+	b.nextHandlerID++
+	entry := &typedHandler{
+		id: b.nextHandlerID,
+		fn: func(ctx context.Context, event any) { handler(ctx, event.(E)) },
+	}
+	b.handlers[eventType] = append(b.handlers[eventType], entry)
+	b.handlersMu.Unlock()
+
+	return func() {
+		b.handlersMu.Lock()
+		defer b.handlersMu.Unlock()
+		current := b.handlers[eventType]
+		for i, h := range current {
+			if h.id == entry.id {
+				b.handlers[eventType] = append(current[:i:i], current[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// On registers handler for events of type E on the process-wide bus.
+// On 在进程级 Bus 上为类型 E 的事件注册处理器。
+func On[E any](handler func(context.Context, E)) func() {
+	return Listen(defaultBus, handler)
+}
+
+// Emit emits a typed event on the process-wide bus.
+// Emit 在进程级 Bus 上发出类型化事件。
+func Emit(ctx context.Context, event any) {
+	defaultBus.Emit(ctx, event)
+}
+
+// Emit delivers event to every handler registered for its type. A panicking handler is logged
+// and does not affect the emitter or the remaining handlers.
+// Emit 将事件投递给为其类型注册的所有处理器。处理器发生 panic 时仅记录日志，不影响发出方和其余处理器。
+func (b *Bus) Emit(ctx context.Context, event any) {
+	if b == nil || event == nil {
+		return
+	}
+	b.handlersMu.RLock()
+	handlers := b.handlers[reflect.TypeOf(event)]
+	b.handlersMu.RUnlock()
+
+	for _, h := range handlers {
+		runHandler(ctx, h, event)
+	}
+}
+
+func runHandler(ctx context.Context, h *typedHandler, event any) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("[EventBus] handler for %T panicked: %v\n%s", event, r, debug.Stack())
+		}
+	}()
+	h.fn(ctx, event)
+}
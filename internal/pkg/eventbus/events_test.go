@@ -0,0 +1,71 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package eventbus
+
+import (
+	"context"
+	"testing"
+)
+
+func TestListenDispatchesByEventType(t *testing.T) {
+	bus := New()
+	var got []string
+	Listen(bus, func(_ context.Context, event AgentRegistered) {
+		got = append(got, "first:"+event.AgentID)
+	})
+	remove := Listen(bus, func(_ context.Context, event AgentRegistered) {
+		got = append(got, "second:"+event.AgentID)
+	})
+	Listen(bus, func(_ context.Context, event AgentOffline) {
+		got = append(got, "offline:"+event.AgentID)
+	})
+
+	bus.Emit(context.Background(), AgentRegistered{AgentID: "a1"})
+	remove()
+	remove()
+	bus.Emit(context.Background(), AgentRegistered{AgentID: "a2"})
+
+	want := []string{"first:a1", "second:a1", "first:a2"}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+	}
+}
+
+func TestEmitRecoversFromPanickingHandler(t *testing.T) {
+	bus := New()
+	called := false
+	Listen(bus, func(context.Context, NodeCrashed) { panic("boom") })
+	Listen(bus, func(context.Context, NodeCrashed) { called = true })
+
+	bus.Emit(context.Background(), NodeCrashed{NodeID: 1})
+	if !called {
+		t.Fatal("expected handler after the panicking one to run")
+	}
+}
+
+func TestEmitWithoutHandlersIsNoop(t *testing.T) {
+	var nilBus *Bus
+	nilBus.Emit(context.Background(), InstallCompleted{})
+	New().Emit(context.Background(), nil)
+	New().Emit(context.Background(), PluginInstalled{PluginName: "jdbc"})
+}
@@ -0,0 +1,177 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package filestream holds what the Control Plane and Agents share for streaming file
+// transfer over the FetchFile RPC: command parameters, chunk compression and progress tracking.
+// filestream 包包含 Control Plane 与 Agent 通过 FetchFile RPC 流式传输文件时共用的内容：
+// 命令参数、数据块压缩和进度跟踪。
+package filestream
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// Command parameters that switch a transfer command to streaming mode.
+// 将传输命令切换为流式模式的命令参数。
+const (
+	ParamTransferMode = "transfer_mode"
+	ParamTransferID   = "transfer_id"
+
+	// ModeStream tells the Agent to pull the file through FetchFile instead of reading chunks from parameters.
+	// ModeStream 通知 Agent 通过 FetchFile 拉取文件，而不是从参数中读取数据块。
+	ModeStream = "stream"
+)
+
+// Supported chunk compressions.
+// 支持的数据块压缩算法。
+const (
+	CompressionNone = "none"
+	CompressionZstd = "zstd"
+)
+
+// Chunk size limits; the upper bound keeps compressed chunks below the default 4MB gRPC receive limit.
+// 数据块大小限制；上限保证压缩后的数据块低于 gRPC 默认 4MB 接收限制。
+const (
+	DefaultChunkSize = 1024 * 1024
+	MinChunkSize     = 64 * 1024
+	MaxChunkSize     = 2 * 1024 * 1024
+)
+
+// ErrUnsupported indicates the Agent does not understand streaming transfers and chunked commands must be used.
+// ErrUnsupported 表示 Agent 不支持流式传输，需要使用分块命令。
+var ErrUnsupported = errors.New("agent does not support streaming file transfer / Agent 不支持流式文件传输")
+
+var (
+	encoderOnce sync.Once
+	encoder     *zstd.Encoder
+	encoderErr  error
+	decoderOnce sync.Once
+	decoder     *zstd.Decoder
+	decoderErr  error
+)
+
+// NormalizeCompression maps an empty value to no compression and rejects unknown algorithms.
+// NormalizeCompression 将空值视为不压缩，并拒绝未知算法。
+func NormalizeCompression(compression string) (string, error) {
+	switch compression {
+	case "", CompressionNone:
+		return CompressionNone, nil
+	case CompressionZstd:
+		return CompressionZstd, nil
+	default:
+		return "", fmt.Errorf("unsupported compression: %s", compression)
+	}
+}
+
+// ClampChunkSize keeps a configured chunk size within the supported range.
+// ClampChunkSize 将配置的数据块大小限制在支持的范围内。
+func ClampChunkSize(size int) int {
+	switch {
+	case size <= 0:
+		return DefaultChunkSize
+	case size < MinChunkSize:
+		return MinChunkSize
+	case size > MaxChunkSize:
+		return MaxChunkSize
+	default:
+		return size
+	}
+}
+
+// Compress encodes a chunk with the given compression.
+// Compress 使用指定算法压缩数据块。
+func Compress(data []byte, compression string) ([]byte, error) {
+	switch compression {
+	case "", CompressionNone:
+		return data, nil
+	case CompressionZstd:
+		encoderOnce.Do(func() {
+			encoder, encoderErr = zstd.NewWriter(nil, zstd.WithEncoderLevel(zstd.SpeedFastest))
+		})
+		if encoderErr != nil {
+			return nil, encoderErr
+		}
+		return encoder.EncodeAll(data, make([]byte, 0, len(data))), nil
+	default:
+		return nil, fmt.Errorf("unsupported compression: %s", compression)
+	}
+}
+
+// Decompress decodes a chunk produced by Compress.
+// Decompress 解压由 Compress 生成的数据块。
+func Decompress(data []byte, compression string) ([]byte, error) {
+	switch compression {
+	case "", CompressionNone:
+		return data, nil
+	case CompressionZstd:
+		decoderOnce.Do(func() {
+			decoder, decoderErr = zstd.NewReader(nil, zstd.WithDecoderMaxMemory(MaxChunkSize*2))
+		})
+		if decoderErr != nil {
+			return nil, decoderErr
+		}
+		return decoder.DecodeAll(data, nil)
+	default:
+		return nil, fmt.Errorf("unsupported compression: %s", compression)
+	}
+}
+
+// Snapshot is the progress of a transfer at one point in time.
+// Snapshot 是传输在某一时刻的进度。
+type Snapshot struct {
+	SentBytes      int64 `json:"sent_bytes"`
+	TotalBytes     int64 `json:"total_bytes"`
+	Percent        int   `json:"percent"`
+	BytesPerSecond int64 `json:"bytes_per_second"`
+	ETASeconds     int64 `json:"eta_seconds"`
+}
+
+// Progress tracks throughput and ETA of a transfer.
+// Progress 跟踪传输的吞吐量和预计剩余时间。
+type Progress struct {
+	total   int64
+	startAt time.Time
+	now     func() time.Time
+}
+
+// NewProgress starts tracking a transfer of total bytes.
+// NewProgress 开始跟踪总计 total 字节的传输。
+func NewProgress(total int64) *Progress {
+	return &Progress{total: total, startAt: time.Now(), now: time.Now}
+}
+
+// Update returns the snapshot after sent bytes, using the average rate since the start.
+// Update 返回已发送 sent 字节后的快照，使用自开始以来的平均速率。
+func (p *Progress) Update(sent int64) Snapshot {
+	snapshot := Snapshot{SentBytes: sent, TotalBytes: p.total}
+	if p.total > 0 {
+		snapshot.Percent = int(sent * 100 / p.total)
+	}
+	elapsed := p.now().Sub(p.startAt).Seconds()
+	if elapsed > 0 {
+		snapshot.BytesPerSecond = int64(float64(sent) / elapsed)
+	}
+	if snapshot.BytesPerSecond > 0 && p.total > sent {
+		snapshot.ETASeconds = (p.total - sent + snapshot.BytesPerSecond - 1) / snapshot.BytesPerSecond
+	}
+	return snapshot
+}
@@ -0,0 +1,62 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package filestream
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestCompressRoundTrip(t *testing.T) {
+	data := bytes.Repeat([]byte("seatunnel "), 10000)
+	for _, compression := range []string{CompressionNone, CompressionZstd} {
+		encoded, err := Compress(data, compression)
+		if err != nil {
+			t.Fatalf("%s: Compress returned error: %v", compression, err)
+		}
+		if compression == CompressionZstd && len(encoded) >= len(data) {
+			t.Fatalf("expected zstd to shrink repetitive data, got %d bytes", len(encoded))
+		}
+		decoded, err := Decompress(encoded, compression)
+		if err != nil {
+			t.Fatalf("%s: Decompress returned error: %v", compression, err)
+		}
+		if !bytes.Equal(decoded, data) {
+			t.Fatalf("%s: round trip mismatch", compression)
+		}
+	}
+	if _, err := NormalizeCompression("gzip"); err == nil {
+		t.Fatalf("expected unknown compression to be rejected")
+	}
+}
+
+func TestProgressUpdate(t *testing.T) {
+	start := time.Unix(1000, 0)
+	current := start
+	progress := &Progress{total: 1000, startAt: start, now: func() time.Time { return current }}
+
+	current = start.Add(2 * time.Second)
+	snapshot := progress.Update(400)
+	if snapshot.Percent != 40 || snapshot.BytesPerSecond != 200 || snapshot.ETASeconds != 3 {
+		t.Fatalf("unexpected snapshot: %+v", snapshot)
+	}
+	if ClampChunkSize(0) != DefaultChunkSize || ClampChunkSize(1) != MinChunkSize || ClampChunkSize(1<<30) != MaxChunkSize {
+		t.Fatalf("unexpected chunk size clamping")
+	}
+}
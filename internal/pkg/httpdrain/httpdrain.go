@@ -0,0 +1,128 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package httpdrain tracks in-flight HTTP requests so that a graceful shutdown can wait for
+// uploads and downloads to finish while ending long-lived streams promptly.
+// Package httpdrain 跟踪在途 HTTP 请求，使优雅关闭能够等待上传与下载完成，同时及时结束长连接推送流。
+package httpdrain
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// pollInterval is how often Wait checks whether the tracked requests have finished.
+// pollInterval 是 Wait 检查被跟踪请求是否结束的间隔。
+const pollInterval = 50 * time.Millisecond
+
+// Tracker counts the requests served by the wrapped handler.
+// Tracker 统计被包装处理器正在处理的请求。
+type Tracker struct {
+	mu       sync.Mutex
+	requests int
+	streams  int
+
+	stopOnce sync.Once
+	stop     chan struct{}
+}
+
+// NewTracker creates an idle tracker.
+// NewTracker 创建一个空闲的跟踪器。
+func NewTracker() *Tracker {
+	return &Tracker{stop: make(chan struct{})}
+}
+
+// Wrap counts every request handled by next. Streams (WebSocket upgrades and server-sent events)
+// get a context that is cancelled as soon as BeginDrain is called; other requests such as package
+// uploads and downloads keep their context and run to completion.
+// Wrap 统计 next 处理的每个请求。推送流（WebSocket 升级与 SSE）的上下文会在调用 BeginDrain 时立即取消；
+// 其他请求（如安装包上传与下载）保留原上下文并运行至完成。
+func (t *Tracker) Wrap(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		stream := IsStream(r)
+		t.add(stream, 1)
+		defer t.add(stream, -1)
+
+		if stream {
+			ctx, cancel := context.WithCancel(r.Context())
+			defer cancel()
+			go func() {
+				select {
+				case <-t.stop:
+					cancel()
+				case <-ctx.Done():
+				}
+			}()
+			r = r.WithContext(ctx)
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// BeginDrain ends all current and future streams. It is safe to call more than once.
+// BeginDrain 结束当前及之后的所有推送流，可重复调用。
+func (t *Tracker) BeginDrain() {
+	t.stopOnce.Do(func() { close(t.stop) })
+}
+
+// Active returns the number of in-flight requests and how many of them are streams.
+// Active 返回在途请求数及其中推送流的数量。
+func (t *Tracker) Active() (requests, streams int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.requests, t.streams
+}
+
+// Wait blocks until no request is in flight or ctx is done. Unlike http.Server.Shutdown it also
+// waits for hijacked connections such as WebSockets.
+// Wait 阻塞直到没有在途请求或 ctx 结束。与 http.Server.Shutdown 不同，它也会等待被接管的连接（如 WebSocket）。
+func (t *Tracker) Wait(ctx context.Context) error {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		if requests, _ := t.Active(); requests == 0 {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (t *Tracker) add(stream bool, delta int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.requests += delta
+	if stream {
+		t.streams += delta
+	}
+}
+
+// IsStream reports whether r opens a long-lived stream: a WebSocket upgrade or an EventSource
+// request for server-sent events.
+// IsStream 判断 r 是否开启长连接推送流：WebSocket 升级或请求 SSE 的 EventSource。
+func IsStream(r *http.Request) bool {
+	if strings.EqualFold(r.Header.Get("Upgrade"), "websocket") {
+		return true
+	}
+	return strings.Contains(r.Header.Get("Accept"), "text/event-stream")
+}
@@ -0,0 +1,95 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package httpdrain
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestTrackerCancelsStreamsButLetsTransfersFinish(t *testing.T) {
+	tracker := NewTracker()
+	releaseUpload := make(chan struct{})
+	streamEnded := make(chan struct{})
+	handler := tracker.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if IsStream(r) {
+			<-r.Context().Done()
+			close(streamEnded)
+			return
+		}
+		<-releaseUpload
+		w.WriteHeader(http.StatusCreated)
+	}))
+
+	stream := httptest.NewRequest(http.MethodGet, "/api/v1/diagnostics/tasks/1/events", nil)
+	stream.Header.Set("Accept", "text/event-stream")
+	upload := httptest.NewRequest(http.MethodPost, "/api/v1/packages/upload", nil)
+	uploadDone := make(chan int, 1)
+	go handler.ServeHTTP(httptest.NewRecorder(), stream)
+	go func() {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, upload)
+		uploadDone <- recorder.Code
+	}()
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		if requests, streams := tracker.Active(); requests == 2 && streams == 1 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("requests were not tracked")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	tracker.BeginDrain()
+	select {
+	case <-streamEnded:
+	case <-time.After(time.Second):
+		t.Fatal("stream was not cancelled by BeginDrain")
+	}
+
+	waitCtx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if err := tracker.Wait(waitCtx); err == nil {
+		t.Fatal("Wait returned while the upload was still in flight")
+	}
+
+	close(releaseUpload)
+	if code := <-uploadDone; code != http.StatusCreated {
+		t.Fatalf("upload was interrupted, got status %d", code)
+	}
+	if err := tracker.Wait(context.Background()); err != nil {
+		t.Fatalf("Wait failed after all requests finished: %v", err)
+	}
+}
+
+func TestIsStream(t *testing.T) {
+	websocket := httptest.NewRequest(http.MethodGet, "/api/v1/dashboard/overview/ws", nil)
+	websocket.Header.Set("Upgrade", "WebSocket")
+	if !IsStream(websocket) {
+		t.Error("expected WebSocket upgrade to be a stream")
+	}
+	if IsStream(httptest.NewRequest(http.MethodGet, "/api/v1/packages/download", nil)) {
+		t.Error("expected plain download not to be a stream")
+	}
+}
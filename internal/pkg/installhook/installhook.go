@@ -0,0 +1,274 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package installhook defines the pre/post installation step hooks registered by an administrator
+// and the way their results travel from the Agent back to the Control Plane.
+// installhook 包定义管理员注册的安装步骤前置/后置钩子，以及钩子结果从 Agent 回传到 Control Plane 的方式。
+//
+// Hooks run on the Agent around each installation step. A script hook executes a script that
+// already exists on the target host, it is never shipped as shell source; a webhook hook POSTs
+// the step context as JSON.
+// 钩子由 Agent 在每个安装步骤前后执行。脚本钩子执行目标主机上已存在的脚本，不会下发 shell 源码；
+// Webhook 钩子以 JSON 形式 POST 步骤上下文。
+package installhook
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	// ParamHooks is the install command parameter carrying the JSON encoded hooks.
+	// ParamHooks 是携带 JSON 编码钩子的安装命令参数。
+	ParamHooks = "hooks"
+
+	// ParamSkipSteps is the install command parameter carrying the comma separated skipped steps.
+	// ParamSkipSteps 是携带以逗号分隔的跳过步骤的安装命令参数。
+	ParamSkipSteps = "skip_steps"
+
+	// ResultPrefix prefixes the progress message reporting one hook result.
+	// ResultPrefix 是上报单个钩子结果的进度消息前缀。
+	ResultPrefix = "Hook: "
+
+	// TrailerPrefix prefixes the line of the install output listing every hook result.
+	// TrailerPrefix 是安装输出中列出全部钩子结果的行前缀。
+	TrailerPrefix = "Hooks: "
+
+	// DefaultTimeout is used when a hook does not set a timeout.
+	// DefaultTimeout 是钩子未设置超时时使用的默认值。
+	DefaultTimeout = 60 * time.Second
+
+	// MaxTimeout bounds the timeout of a hook.
+	// MaxTimeout 限制钩子的最大超时时间。
+	MaxTimeout = 10 * time.Minute
+
+	// MaxOutputBytes bounds the output kept in a hook result.
+	// MaxOutputBytes 限制钩子结果中保留的输出大小。
+	MaxOutputBytes = 4 << 10
+)
+
+// Phase is when a hook runs relative to its step.
+// Phase 表示钩子相对于步骤的执行时机。
+type Phase string
+
+const (
+	// PhasePre runs before the step; a failing hook with FailOnError keeps the step from running.
+	// PhasePre 在步骤之前执行；设置 FailOnError 的钩子失败时步骤不会执行。
+	PhasePre Phase = "pre"
+	// PhasePost runs after the step succeeded.
+	// PhasePost 在步骤成功之后执行。
+	PhasePost Phase = "post"
+)
+
+// Type is the kind of action a hook performs.
+// Type 表示钩子执行的动作类型。
+type Type string
+
+const (
+	TypeScript  Type = "script"
+	TypeWebhook Type = "webhook"
+)
+
+// ErrInvalidHook indicates a hook definition is incomplete or unsafe.
+// ErrInvalidHook 表示钩子定义不完整或不安全。
+var ErrInvalidHook = errors.New("installhook: invalid hook")
+
+var scriptPathPattern = regexp.MustCompile(`^/[A-Za-z0-9._/@+-]*$`)
+
+// Hook is one administrator registered hook.
+// Hook 是管理员注册的一个钩子。
+type Hook struct {
+	Name  string `json:"name"`
+	Step  string `json:"step"`
+	Phase Phase  `json:"phase"`
+	// Script is the absolute path of an executable script on the target host.
+	// Script 是目标主机上可执行脚本的绝对路径。
+	Script string `json:"script,omitempty"`
+	// WebhookURL receives a POST with the step context.
+	// WebhookURL 接收携带步骤上下文的 POST 请求。
+	WebhookURL     string `json:"webhook_url,omitempty"`
+	TimeoutSeconds int    `json:"timeout_seconds,omitempty"`
+	// FailOnError fails the installation when the hook fails; otherwise the failure is only recorded.
+	// FailOnError 在钩子失败时使安装失败；否则仅记录失败。
+	FailOnError bool `json:"fail_on_error,omitempty"`
+}
+
+// Type returns whether the hook runs a script or calls a webhook.
+// Type 返回钩子是执行脚本还是调用 Webhook。
+func (h Hook) Type() Type {
+	if h.Script != "" {
+		return TypeScript
+	}
+	return TypeWebhook
+}
+
+// Timeout returns the effective timeout of the hook.
+// Timeout 返回钩子的实际超时时间。
+func (h Hook) Timeout() time.Duration {
+	if h.TimeoutSeconds <= 0 {
+		return DefaultTimeout
+	}
+	timeout := time.Duration(h.TimeoutSeconds) * time.Second
+	if timeout > MaxTimeout {
+		return MaxTimeout
+	}
+	return timeout
+}
+
+// Validate checks that the hook has a name, a step, a known phase and exactly one safe action.
+// Validate 校验钩子具有名称、步骤、合法的执行时机以及唯一且安全的动作。
+func (h Hook) Validate() error {
+	if strings.TrimSpace(h.Name) == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidHook)
+	}
+	if strings.TrimSpace(h.Step) == "" {
+		return fmt.Errorf("%w: %s: step is required", ErrInvalidHook, h.Name)
+	}
+	if h.Phase != PhasePre && h.Phase != PhasePost {
+		return fmt.Errorf("%w: %s: phase must be %q or %q", ErrInvalidHook, h.Name, PhasePre, PhasePost)
+	}
+	if (h.Script == "") == (h.WebhookURL == "") {
+		return fmt.Errorf("%w: %s: exactly one of script and webhook_url is required", ErrInvalidHook, h.Name)
+	}
+	if h.Script != "" && (!scriptPathPattern.MatchString(h.Script) || strings.Contains(h.Script, "..")) {
+		return fmt.Errorf("%w: %s: script must be an absolute path", ErrInvalidHook, h.Name)
+	}
+	if h.WebhookURL != "" {
+		u, err := url.Parse(h.WebhookURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("%w: %s: webhook_url must be an http(s) URL", ErrInvalidHook, h.Name)
+		}
+	}
+	if h.TimeoutSeconds < 0 {
+		return fmt.Errorf("%w: %s: timeout_seconds must not be negative", ErrInvalidHook, h.Name)
+	}
+	return nil
+}
+
+// Result is the outcome of one hook run.
+// Result 是一次钩子执行的结果。
+type Result struct {
+	Name       string    `json:"name"`
+	Step       string    `json:"step"`
+	Phase      Phase     `json:"phase"`
+	Type       Type      `json:"type"`
+	Success    bool      `json:"success"`
+	ExitCode   int       `json:"exit_code,omitempty"`
+	StatusCode int       `json:"status_code,omitempty"`
+	Output     string    `json:"output,omitempty"`
+	Error      string    `json:"error,omitempty"`
+	StartedAt  time.Time `json:"started_at"`
+	DurationMs int64     `json:"duration_ms"`
+}
+
+// Payload is the JSON body POSTed to webhook hooks.
+// Payload 是 POST 给 Webhook 钩子的 JSON 请求体。
+type Payload struct {
+	Hook       string `json:"hook"`
+	Step       string `json:"step"`
+	Phase      Phase  `json:"phase"`
+	HostID     string `json:"host_id,omitempty"`
+	ClusterID  string `json:"cluster_id,omitempty"`
+	Version    string `json:"version"`
+	InstallDir string `json:"install_dir"`
+	NodeRole   string `json:"node_role,omitempty"`
+}
+
+// TruncateOutput keeps the tail of the output within MaxOutputBytes.
+// TruncateOutput 保留输出末尾不超过 MaxOutputBytes 的部分。
+func TruncateOutput(output string) string {
+	output = strings.TrimSpace(output)
+	if len(output) <= MaxOutputBytes {
+		return output
+	}
+	return "..." + output[len(output)-MaxOutputBytes:]
+}
+
+// EncodeHooks encodes hooks for the ParamHooks command parameter.
+// EncodeHooks 将钩子编码为 ParamHooks 命令参数。
+func EncodeHooks(hooks []Hook) (string, error) {
+	data, err := json.Marshal(hooks)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// DecodeHooks decodes the ParamHooks command parameter; an empty value yields no hooks.
+// DecodeHooks 解码 ParamHooks 命令参数；空值表示没有钩子。
+func DecodeHooks(raw string) ([]Hook, error) {
+	if strings.TrimSpace(raw) == "" {
+		return nil, nil
+	}
+	var hooks []Hook
+	if err := json.Unmarshal([]byte(raw), &hooks); err != nil {
+		return nil, err
+	}
+	for _, hook := range hooks {
+		if err := hook.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	return hooks, nil
+}
+
+// FormatResult renders a hook result as a progress message.
+// FormatResult 将钩子结果渲染为进度消息。
+func FormatResult(result Result) string {
+	data, _ := json.Marshal(result)
+	return ResultPrefix + string(data)
+}
+
+// ParseResult extracts a hook result from a progress message, without its "[step] " prefix.
+// ParseResult 从去掉 "[step] " 前缀的进度消息中提取钩子结果。
+func ParseResult(message string) (Result, bool) {
+	var result Result
+	raw, ok := strings.CutPrefix(strings.TrimSpace(message), ResultPrefix)
+	if !ok || json.Unmarshal([]byte(raw), &result) != nil || result.Name == "" {
+		return Result{}, false
+	}
+	return result, true
+}
+
+// FormatTrailer renders every hook result as the last line of the install output.
+// FormatTrailer 将全部钩子结果渲染为安装输出的最后一行。
+func FormatTrailer(results []Result) string {
+	if len(results) == 0 {
+		return ""
+	}
+	data, _ := json.Marshal(results)
+	return "\n" + TrailerPrefix + string(data)
+}
+
+// ParseTrailer extracts the hook results from the install output, if any.
+// ParseTrailer 从安装输出中提取钩子结果（如有）。
+func ParseTrailer(output string) []Result {
+	idx := strings.LastIndex(output, "\n"+TrailerPrefix)
+	if idx == -1 {
+		return nil
+	}
+	var results []Result
+	if err := json.Unmarshal([]byte(strings.TrimSpace(output[idx+1+len(TrailerPrefix):])), &results); err != nil {
+		return nil
+	}
+	return results
+}
@@ -0,0 +1,98 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package installhook
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestValidateRejectsUnsafeHooks(t *testing.T) {
+	valid := Hook{Name: "ckpt", Step: "configure_checkpoint", Phase: PhasePre, Script: "/etc/seatunnelx/hooks/ckpt.sh"}
+	if err := valid.Validate(); err != nil {
+		t.Fatalf("expected valid hook, got %v", err)
+	}
+
+	cases := map[string]Hook{
+		"missing name":     {Step: "extract", Phase: PhasePre, Script: "/bin/true"},
+		"unknown phase":    {Name: "a", Step: "extract", Phase: "during", Script: "/bin/true"},
+		"no action":        {Name: "a", Step: "extract", Phase: PhasePost},
+		"both actions":     {Name: "a", Step: "extract", Phase: PhasePost, Script: "/bin/true", WebhookURL: "http://cmdb/hook"},
+		"shell source":     {Name: "a", Step: "extract", Phase: PhasePost, Script: "rm -rf /tmp; echo"},
+		"relative path":    {Name: "a", Step: "extract", Phase: PhasePost, Script: "hooks/a.sh"},
+		"path traversal":   {Name: "a", Step: "extract", Phase: PhasePost, Script: "/opt/../etc/a.sh"},
+		"non http url":     {Name: "a", Step: "extract", Phase: PhasePost, WebhookURL: "file:///etc/passwd"},
+		"negative timeout": {Name: "a", Step: "extract", Phase: PhasePost, Script: "/bin/true", TimeoutSeconds: -1},
+	}
+	for name, hook := range cases {
+		if err := hook.Validate(); !errors.Is(err, ErrInvalidHook) {
+			t.Errorf("%s: expected ErrInvalidHook, got %v", name, err)
+		}
+	}
+}
+
+func TestTimeoutDefaultsAndBounds(t *testing.T) {
+	if got := (Hook{}).Timeout(); got != DefaultTimeout {
+		t.Fatalf("default timeout = %v", got)
+	}
+	if got := (Hook{TimeoutSeconds: 5}).Timeout(); got != 5*time.Second {
+		t.Fatalf("timeout = %v", got)
+	}
+	if got := (Hook{TimeoutSeconds: 3600}).Timeout(); got != MaxTimeout {
+		t.Fatalf("bounded timeout = %v", got)
+	}
+}
+
+func TestResultRoundTrip(t *testing.T) {
+	result := Result{Name: "notify", Step: "install_plugins", Phase: PhasePost, Type: TypeWebhook, Success: true, StatusCode: 204}
+
+	parsed, ok := ParseResult(FormatResult(result))
+	if !ok || parsed.Name != "notify" || parsed.StatusCode != 204 {
+		t.Fatalf("ParseResult = %+v, %v", parsed, ok)
+	}
+	if _, ok := ParseResult("Downloading package... 50%"); ok {
+		t.Fatalf("expected plain progress message not to parse as a hook result")
+	}
+
+	output := "Installation completed / 安装完成" + FormatTrailer([]Result{result})
+	results := ParseTrailer(output)
+	if len(results) != 1 || results[0].Name != "notify" {
+		t.Fatalf("ParseTrailer = %+v", results)
+	}
+	if ParseTrailer("Installation completed / 安装完成") != nil {
+		t.Fatalf("expected no trailer results")
+	}
+}
+
+func TestDecodeHooksValidates(t *testing.T) {
+	raw, err := EncodeHooks([]Hook{{Name: "a", Step: "extract", Phase: PhasePost, Script: "/bin/true"}})
+	if err != nil {
+		t.Fatalf("EncodeHooks: %v", err)
+	}
+	hooks, err := DecodeHooks(raw)
+	if err != nil || len(hooks) != 1 {
+		t.Fatalf("DecodeHooks = %+v, %v", hooks, err)
+	}
+	if _, err := DecodeHooks(`[{"name":"a","step":"extract","phase":"post","script":"echo hi"}]`); !errors.Is(err, ErrInvalidHook) {
+		t.Fatalf("expected decoded hooks to be validated, got %v", err)
+	}
+	if hooks, err := DecodeHooks(""); err != nil || hooks != nil {
+		t.Fatalf("expected empty parameter to yield no hooks, got %+v, %v", hooks, err)
+	}
+}
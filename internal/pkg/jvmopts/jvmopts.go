@@ -0,0 +1,225 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package jvmopts renders the SeaTunnel jvm_options files (jvm_options, jvm_master_options,
+// jvm_worker_options) from heap size, garbage collector, direct memory and extra options.
+// jvmopts 包根据堆大小、垃圾回收器、直接内存和额外参数渲染 SeaTunnel 的 jvm_options 文件。
+//
+// Heap flags are rewritten in place, every other setting lives in a managed block that is
+// regenerated on each render; conflicting flags outside the block are commented out.
+// 堆参数原地改写，其余设置写入每次渲染都会重新生成的托管块；托管块外冲突的参数会被注释。
+package jvmopts
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const (
+	// BlockBegin and BlockEnd delimit the options managed by SeaTunnelX.
+	// BlockBegin 与 BlockEnd 界定由 SeaTunnelX 托管的参数。
+	BlockBegin = "# BEGIN SEATUNNELX MANAGED JVM OPTIONS"
+	BlockEnd   = "# END SEATUNNELX MANAGED JVM OPTIONS"
+
+	// MaxExtraOptions bounds the number of extra options.
+	// MaxExtraOptions 限制额外参数的数量。
+	MaxExtraOptions = 64
+)
+
+// ErrInvalidOptions indicates the JVM settings cannot be rendered.
+// ErrInvalidOptions 表示 JVM 设置无法渲染。
+var ErrInvalidOptions = errors.New("jvmopts: invalid JVM options")
+
+// GC selects the garbage collector.
+// GC 选择垃圾回收器。
+type GC string
+
+const (
+	GCG1         GC = "g1"
+	GCZ          GC = "zgc"
+	GCParallel   GC = "parallel"
+	GCShenandoah GC = "shenandoah"
+	GCSerial     GC = "serial"
+)
+
+var gcFlags = map[GC]string{
+	GCG1:         "-XX:+UseG1GC",
+	GCZ:          "-XX:+UseZGC",
+	GCParallel:   "-XX:+UseParallelGC",
+	GCShenandoah: "-XX:+UseShenandoahGC",
+	GCSerial:     "-XX:+UseSerialGC",
+}
+
+var (
+	heapPattern        = regexp.MustCompile(`^#?\s*-Xm([sx])\d+[kKmMgG]?\s*$`)
+	gcSelectionPattern = regexp.MustCompile(`^-XX:[+-]Use(G1|Z|Parallel|ParallelOld|ConcMarkSweep|Shenandoah|Serial|Epsilon)GC$`)
+	xOptionPattern     = regexp.MustCompile(`^(-X[a-z]+)\d`)
+)
+
+// Options are the JVM settings of one jvm_options file.
+// Options 是单个 jvm_options 文件的 JVM 设置。
+type Options struct {
+	// HeapSizeGB sets -Xms and -Xmx, 0 keeps the heap lines of the file.
+	// HeapSizeGB 设置 -Xms 与 -Xmx，为 0 时保留文件中的堆参数。
+	HeapSizeGB int `json:"heap_size_gb,omitempty"`
+	// GC selects the garbage collector, empty keeps the default of the file.
+	// GC 选择垃圾回收器，为空时保留文件中的默认值。
+	GC GC `json:"gc,omitempty"`
+	// MaxDirectMemoryMB sets -XX:MaxDirectMemorySize, 0 leaves it unset.
+	// MaxDirectMemoryMB 设置 -XX:MaxDirectMemorySize，为 0 时不设置。
+	MaxDirectMemoryMB int `json:"max_direct_memory_mb,omitempty"`
+	// ExtraOptions are appended verbatim, one option per line.
+	// ExtraOptions 原样追加，每行一个参数。
+	ExtraOptions []string `json:"extra_options,omitempty"`
+}
+
+// Validate checks the settings; heap, GC and direct memory must use their dedicated fields.
+// Validate 校验设置；堆、GC 与直接内存必须使用各自的专用字段。
+func (o Options) Validate() error {
+	if o.HeapSizeGB < 0 {
+		return fmt.Errorf("%w: heap size must not be negative", ErrInvalidOptions)
+	}
+	if o.MaxDirectMemoryMB < 0 {
+		return fmt.Errorf("%w: max direct memory must not be negative", ErrInvalidOptions)
+	}
+	if o.GC != "" {
+		if _, ok := gcFlags[o.GC]; !ok {
+			return fmt.Errorf("%w: unsupported gc %q", ErrInvalidOptions, o.GC)
+		}
+	}
+	if len(o.ExtraOptions) > MaxExtraOptions {
+		return fmt.Errorf("%w: at most %d extra options are allowed", ErrInvalidOptions, MaxExtraOptions)
+	}
+	for _, option := range o.ExtraOptions {
+		trimmed := strings.TrimSpace(option)
+		if !strings.HasPrefix(trimmed, "-") || strings.ContainsAny(trimmed, "\r\n") {
+			return fmt.Errorf("%w: extra option %q must be a single flag starting with '-'", ErrInvalidOptions, option)
+		}
+		switch key := flagKey(trimmed); {
+		case key == "-Xms" || key == "-Xmx":
+			return fmt.Errorf("%w: use the heap size instead of %q", ErrInvalidOptions, option)
+		case key == "-XX:MaxDirectMemorySize":
+			return fmt.Errorf("%w: use the max direct memory instead of %q", ErrInvalidOptions, option)
+		case gcSelectionPattern.MatchString(trimmed):
+			return fmt.Errorf("%w: use the gc selection instead of %q", ErrInvalidOptions, option)
+		}
+	}
+	return nil
+}
+
+// ManagedFlags returns the flags written into the managed block.
+// ManagedFlags 返回写入托管块的参数。
+func (o Options) ManagedFlags() []string {
+	flags := make([]string, 0, len(o.ExtraOptions)+2)
+	if flag, ok := gcFlags[o.GC]; ok {
+		flags = append(flags, flag)
+	}
+	if o.MaxDirectMemoryMB > 0 {
+		flags = append(flags, fmt.Sprintf("-XX:MaxDirectMemorySize=%dm", o.MaxDirectMemoryMB))
+	}
+	for _, option := range o.ExtraOptions {
+		if trimmed := strings.TrimSpace(option); trimmed != "" {
+			flags = append(flags, trimmed)
+		}
+	}
+	return flags
+}
+
+// Render applies the settings to the content of a jvm_options file.
+// Rendering is idempotent: rendering the output again with the same settings changes nothing.
+// Render 将设置应用到 jvm_options 文件内容；渲染是幂等的。
+func Render(content string, opts Options) string {
+	managed := opts.ManagedFlags()
+	conflicts := make(map[string]bool, len(managed))
+	for _, flag := range managed {
+		conflicts[flagKey(flag)] = true
+	}
+
+	lines := strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+	result := make([]string, 0, len(lines)+len(managed)+3)
+	inBlock := false
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case trimmed == BlockBegin:
+			inBlock = true
+			continue
+		case trimmed == BlockEnd:
+			inBlock = false
+			continue
+		case inBlock:
+			continue
+		}
+
+		if opts.HeapSizeGB > 0 {
+			if match := heapPattern.FindStringSubmatch(trimmed); match != nil {
+				result = append(result, fmt.Sprintf("-Xm%s%dg", match[1], opts.HeapSizeGB))
+				continue
+			}
+		}
+		if trimmed != "" && !strings.HasPrefix(trimmed, "#") &&
+			(conflicts[flagKey(trimmed)] || (opts.GC != "" && gcSelectionPattern.MatchString(trimmed))) {
+			result = append(result, "# "+trimmed)
+			continue
+		}
+		result = append(result, line)
+	}
+
+	for len(result) > 0 && strings.TrimSpace(result[len(result)-1]) == "" {
+		result = result[:len(result)-1]
+	}
+	if len(managed) > 0 {
+		if len(result) > 0 {
+			result = append(result, "")
+		}
+		result = append(result, BlockBegin)
+		result = append(result, managed...)
+		result = append(result, BlockEnd)
+	}
+	return strings.Join(result, "\n") + "\n"
+}
+
+// ActiveFlags returns the flags a JVM started from the file would receive, in file order.
+// ActiveFlags 按文件顺序返回基于该文件启动的 JVM 实际接收的参数。
+func ActiveFlags(content string) []string {
+	flags := make([]string, 0)
+	for _, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		flags = append(flags, trimmed)
+	}
+	return flags
+}
+
+// flagKey returns the part of a flag that identifies the setting regardless of its value.
+// flagKey 返回参数中标识设置项的部分（忽略取值）。
+func flagKey(flag string) string {
+	if strings.HasPrefix(flag, "-XX:+") || strings.HasPrefix(flag, "-XX:-") {
+		return "-XX:" + flag[len("-XX:+"):]
+	}
+	if index := strings.Index(flag, "="); index > 0 {
+		return flag[:index]
+	}
+	if match := xOptionPattern.FindStringSubmatch(flag); match != nil {
+		return match[1]
+	}
+	return flag
+}
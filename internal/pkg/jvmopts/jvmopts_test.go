@@ -0,0 +1,91 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package jvmopts
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+const legacyJVMOptions = `# JVM Heap
+# -Xms2g
+# -Xmx2g
+
+# JVM Dump
+-XX:+HeapDumpOnOutOfMemoryError
+-XX:HeapDumpPath=/tmp/seatunnel/dump/zeta-server
+-XX:MaxMetaspaceSize=2g
+-XX:+UseG1GC
+`
+
+func TestRender(t *testing.T) {
+	opts := Options{
+		HeapSizeGB:        4,
+		GC:                GCZ,
+		MaxDirectMemoryMB: 1024,
+		ExtraOptions:      []string{"-XX:MaxMetaspaceSize=1g", " -Dfile.encoding=UTF-8 "},
+	}
+	rendered := Render(legacyJVMOptions, opts)
+
+	want := []string{
+		"-Xms4g",
+		"-Xmx4g",
+		"-XX:+HeapDumpOnOutOfMemoryError",
+		"-XX:HeapDumpPath=/tmp/seatunnel/dump/zeta-server",
+		"-XX:+UseZGC",
+		"-XX:MaxDirectMemorySize=1024m",
+		"-XX:MaxMetaspaceSize=1g",
+		"-Dfile.encoding=UTF-8",
+	}
+	if got := ActiveFlags(rendered); !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected active flags:\n got %v\nwant %v\nfile:\n%s", got, want, rendered)
+	}
+	if again := Render(rendered, opts); again != rendered {
+		t.Fatalf("render is not idempotent:\n%s\n---\n%s", rendered, again)
+	}
+
+	// Dropping the managed settings removes the block but keeps the rewritten heap
+	// 去掉托管设置后移除托管块，但保留已改写的堆参数
+	reset := Render(rendered, Options{})
+	if got := ActiveFlags(reset); !reflect.DeepEqual(got, want[:4]) {
+		t.Fatalf("unexpected flags after reset: %v", got)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	valid := Options{HeapSizeGB: 2, GC: GCG1, ExtraOptions: []string{"-XX:+AlwaysPreTouch"}}
+	if err := valid.Validate(); err != nil {
+		t.Fatalf("expected valid options, got %v", err)
+	}
+
+	for name, opts := range map[string]Options{
+		"unknown gc":         {GC: "cms"},
+		"negative heap":      {HeapSizeGB: -1},
+		"not a flag":         {ExtraOptions: []string{"Xmx4g"}},
+		"multi line":         {ExtraOptions: []string{"-Da=1\n-Db=2"}},
+		"heap in extras":     {ExtraOptions: []string{"-Xmx8g"}},
+		"gc in extras":       {ExtraOptions: []string{"-XX:+UseParallelGC"}},
+		"direct in extras":   {ExtraOptions: []string{"-XX:MaxDirectMemorySize=1g"}},
+		"negative direct mb": {MaxDirectMemoryMB: -5},
+	} {
+		if err := opts.Validate(); !errors.Is(err, ErrInvalidOptions) {
+			t.Errorf("%s: expected ErrInvalidOptions, got %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,297 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package labelx validates host labels and evaluates label selectors.
+// labelx 包用于校验主机标签并计算标签选择器。
+//
+// Selector syntax (comma separated requirements, all must match):
+// 选择器语法（逗号分隔，所有条件都需满足）：
+//
+//	zone=az1, disk!=hdd, role in (etl, batch), env notin (dev), gpu, !maintenance
+package labelx
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const (
+	// MaxLabels is the maximum number of labels on one host.
+	// MaxLabels 是单个主机允许的最大标签数。
+	MaxLabels = 64
+	// MaxKeyLength and MaxValueLength bound label keys and values.
+	// MaxKeyLength 与 MaxValueLength 限制标签键与值的长度。
+	MaxKeyLength   = 63
+	MaxValueLength = 63
+)
+
+var (
+	keyPattern   = regexp.MustCompile(`^[A-Za-z0-9]([A-Za-z0-9._/-]*[A-Za-z0-9])?$`)
+	valuePattern = regexp.MustCompile(`^[A-Za-z0-9]([A-Za-z0-9._-]*[A-Za-z0-9])?$`)
+	setPattern   = regexp.MustCompile(`^(\S+)\s+(in|notin)\s*\((.*)\)$`)
+)
+
+// Operator is the comparison of a selector requirement.
+// Operator 表示选择器条件的比较方式。
+type Operator string
+
+const (
+	OpEquals       Operator = "="
+	OpNotEquals    Operator = "!="
+	OpIn           Operator = "in"
+	OpNotIn        Operator = "notin"
+	OpExists       Operator = "exists"
+	OpDoesNotExist Operator = "!"
+)
+
+// ValidateKey checks a label key.
+// ValidateKey 校验标签键。
+func ValidateKey(key string) error {
+	if len(key) == 0 || len(key) > MaxKeyLength || !keyPattern.MatchString(key) {
+		return fmt.Errorf("invalid label key %q: must be 1-%d alphanumeric characters, '-', '_', '.', or '/'", key, MaxKeyLength)
+	}
+	return nil
+}
+
+// ValidateValue checks a label value; empty values are allowed.
+// ValidateValue 校验标签值，允许为空。
+func ValidateValue(value string) error {
+	if value == "" {
+		return nil
+	}
+	if len(value) > MaxValueLength || !valuePattern.MatchString(value) {
+		return fmt.Errorf("invalid label value %q: must be at most %d alphanumeric characters, '-', '_', or '.'", value, MaxValueLength)
+	}
+	return nil
+}
+
+// Validate checks every key and value of a label set.
+// Validate 校验标签集合中的所有键和值。
+func Validate(labels map[string]string) error {
+	if len(labels) > MaxLabels {
+		return fmt.Errorf("too many labels: %d > %d", len(labels), MaxLabels)
+	}
+	for key, value := range labels {
+		if err := ValidateKey(key); err != nil {
+			return err
+		}
+		if err := ValidateValue(value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Requirement is one condition of a selector.
+// Requirement 表示选择器中的一个条件。
+type Requirement struct {
+	Key      string   `json:"key"`
+	Operator Operator `json:"operator"`
+	Values   []string `json:"values,omitempty"`
+}
+
+// Matches reports whether labels satisfy the requirement.
+// A missing key satisfies != and notin, like Kubernetes selectors.
+// Matches 判断标签是否满足条件。与 Kubernetes 选择器一致，缺失的键满足 != 与 notin。
+func (r Requirement) Matches(labels map[string]string) bool {
+	value, ok := labels[r.Key]
+	switch r.Operator {
+	case OpEquals:
+		return ok && value == r.Values[0]
+	case OpNotEquals:
+		return !ok || value != r.Values[0]
+	case OpIn:
+		return ok && contains(r.Values, value)
+	case OpNotIn:
+		return !ok || !contains(r.Values, value)
+	case OpExists:
+		return ok
+	case OpDoesNotExist:
+		return !ok
+	default:
+		return false
+	}
+}
+
+// String formats the requirement in selector syntax.
+// String 以选择器语法格式化条件。
+func (r Requirement) String() string {
+	switch r.Operator {
+	case OpEquals, OpNotEquals:
+		return r.Key + string(r.Operator) + r.Values[0]
+	case OpIn, OpNotIn:
+		return fmt.Sprintf("%s %s (%s)", r.Key, r.Operator, strings.Join(r.Values, ","))
+	case OpDoesNotExist:
+		return "!" + r.Key
+	default:
+		return r.Key
+	}
+}
+
+// Selector is a conjunction of requirements; an empty selector matches everything.
+// Selector 是多个条件的合取，空选择器匹配所有主机。
+type Selector []Requirement
+
+// Parse parses a selector expression. An empty expression yields an empty selector.
+// Parse 解析选择器表达式，空表达式返回空选择器。
+func Parse(expr string) (Selector, error) {
+	parts, err := splitRequirements(expr)
+	if err != nil {
+		return nil, err
+	}
+	selector := make(Selector, 0, len(parts))
+	for _, part := range parts {
+		requirement, err := parseRequirement(part)
+		if err != nil {
+			return nil, err
+		}
+		selector = append(selector, requirement)
+	}
+	return selector, nil
+}
+
+// Empty reports whether the selector has no requirement.
+// Empty 判断选择器是否为空。
+func (s Selector) Empty() bool {
+	return len(s) == 0
+}
+
+// Matches reports whether labels satisfy every requirement.
+// Matches 判断标签是否满足所有条件。
+func (s Selector) Matches(labels map[string]string) bool {
+	for _, requirement := range s {
+		if !requirement.Matches(labels) {
+			return false
+		}
+	}
+	return true
+}
+
+// Unmatched returns the requirements labels do not satisfy.
+// Unmatched 返回标签未满足的条件。
+func (s Selector) Unmatched(labels map[string]string) []Requirement {
+	var unmatched []Requirement
+	for _, requirement := range s {
+		if !requirement.Matches(labels) {
+			unmatched = append(unmatched, requirement)
+		}
+	}
+	return unmatched
+}
+
+// String formats the selector in canonical syntax.
+// String 以规范语法格式化选择器。
+func (s Selector) String() string {
+	parts := make([]string, len(s))
+	for i, requirement := range s {
+		parts[i] = requirement.String()
+	}
+	return strings.Join(parts, ",")
+}
+
+// splitRequirements splits on commas outside parentheses.
+// splitRequirements 按括号外的逗号拆分条件。
+func splitRequirements(expr string) ([]string, error) {
+	var parts []string
+	depth, start := 0, 0
+	for i, ch := range expr {
+		switch ch {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth < 0 {
+				return nil, fmt.Errorf("invalid label selector %q: unbalanced parentheses", expr)
+			}
+		case ',':
+			if depth == 0 {
+				parts = appendPart(parts, expr[start:i])
+				start = i + 1
+			}
+		}
+	}
+	if depth != 0 {
+		return nil, fmt.Errorf("invalid label selector %q: unbalanced parentheses", expr)
+	}
+	return appendPart(parts, expr[start:]), nil
+}
+
+func appendPart(parts []string, part string) []string {
+	if part = strings.TrimSpace(part); part != "" {
+		parts = append(parts, part)
+	}
+	return parts
+}
+
+func parseRequirement(part string) (Requirement, error) {
+	if match := setPattern.FindStringSubmatch(part); match != nil {
+		values := make([]string, 0)
+		for _, value := range strings.Split(match[3], ",") {
+			value = strings.TrimSpace(value)
+			if value == "" {
+				continue
+			}
+			if err := ValidateValue(value); err != nil {
+				return Requirement{}, err
+			}
+			values = append(values, value)
+		}
+		if len(values) == 0 {
+			return Requirement{}, fmt.Errorf("invalid label selector %q: %s requires at least one value", part, match[2])
+		}
+		sort.Strings(values)
+		return newRequirement(match[1], Operator(match[2]), values)
+	}
+
+	if strings.HasPrefix(part, "!") && !strings.Contains(part, "=") {
+		return newRequirement(strings.TrimSpace(part[1:]), OpDoesNotExist, nil)
+	}
+
+	for _, op := range []string{"!=", "==", "="} {
+		if key, value, ok := strings.Cut(part, op); ok {
+			operator := OpEquals
+			if op == "!=" {
+				operator = OpNotEquals
+			}
+			value = strings.TrimSpace(value)
+			if err := ValidateValue(value); err != nil {
+				return Requirement{}, err
+			}
+			return newRequirement(strings.TrimSpace(key), operator, []string{value})
+		}
+	}
+
+	return newRequirement(part, OpExists, nil)
+}
+
+func newRequirement(key string, operator Operator, values []string) (Requirement, error) {
+	if err := ValidateKey(key); err != nil {
+		return Requirement{}, err
+	}
+	return Requirement{Key: key, Operator: operator, Values: values}, nil
+}
+
+func contains(values []string, value string) bool {
+	for _, candidate := range values {
+		if candidate == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,80 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package labelx
+
+import "testing"
+
+func TestParseAndMatch(t *testing.T) {
+	selector, err := Parse("zone=az1, disk!=hdd, role in (etl, batch), env notin (dev), gpu, !maintenance")
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+	if got := selector.String(); got != "zone=az1,disk!=hdd,role in (batch,etl),env notin (dev),gpu,!maintenance" {
+		t.Fatalf("unexpected canonical selector %q", got)
+	}
+
+	cases := []struct {
+		name   string
+		labels map[string]string
+		want   bool
+	}{
+		{"all satisfied", map[string]string{"zone": "az1", "disk": "ssd", "role": "etl", "gpu": ""}, true},
+		{"missing key satisfies != and notin", map[string]string{"zone": "az1", "role": "batch", "gpu": "a100"}, true},
+		{"wrong zone", map[string]string{"zone": "az2", "role": "etl", "gpu": ""}, false},
+		{"excluded disk", map[string]string{"zone": "az1", "disk": "hdd", "role": "etl", "gpu": ""}, false},
+		{"role not in set", map[string]string{"zone": "az1", "role": "olap", "gpu": ""}, false},
+		{"missing required key", map[string]string{"zone": "az1", "role": "etl"}, false},
+		{"forbidden key present", map[string]string{"zone": "az1", "role": "etl", "gpu": "", "maintenance": "true"}, false},
+	}
+	for _, tc := range cases {
+		if got := selector.Matches(tc.labels); got != tc.want {
+			t.Errorf("%s: Matches = %v, want %v (unmatched %v)", tc.name, got, tc.want, selector.Unmatched(tc.labels))
+		}
+	}
+
+	empty, err := Parse("  ")
+	if err != nil || !empty.Empty() || !empty.Matches(nil) {
+		t.Fatalf("empty selector should match everything, got %v (%v)", empty, err)
+	}
+}
+
+func TestParseRejectsInvalidSelectors(t *testing.T) {
+	for _, expr := range []string{
+		"zone in ()",
+		"role in (etl",
+		"zone=a b",
+		"-bad=1",
+		"key=value)",
+	} {
+		if _, err := Parse(expr); err == nil {
+			t.Errorf("expected error for %q", expr)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	if err := Validate(map[string]string{"zone": "az1", "node.role/etl": "", "disk": "ssd"}); err != nil {
+		t.Fatalf("Validate returned error: %v", err)
+	}
+	if err := Validate(map[string]string{"bad key": "x"}); err == nil {
+		t.Fatal("expected error for key with space")
+	}
+	if err := Validate(map[string]string{"zone": "a=b"}); err == nil {
+		t.Fatal("expected error for value with '='")
+	}
+}
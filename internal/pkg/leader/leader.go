@@ -0,0 +1,50 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package leader exposes whether this Control Plane replica currently leads background jobs.
+// Without high availability every process is the leader; the HA node installs a lease-backed checker.
+// Package leader 暴露当前 Control Plane 副本是否负责执行后台任务。
+// 未启用高可用时每个进程都是 leader；HA 节点会安装基于租约的检查函数。
+package leader
+
+import "sync/atomic"
+
+// Checker reports whether this replica holds the leadership lease.
+// Checker 报告当前副本是否持有 leader 租约。
+type Checker func() bool
+
+var checker atomic.Pointer[Checker]
+
+// SetChecker installs the leadership checker; nil restores the single-instance default.
+// SetChecker 安装 leader 检查函数；传入 nil 恢复单实例默认行为。
+func SetChecker(fn Checker) {
+	if fn == nil {
+		checker.Store(nil)
+		return
+	}
+	checker.Store(&fn)
+}
+
+// IsLeader reports whether singleton background jobs (health checks, purgers, schedulers) should run here.
+// IsLeader 报告单例后台任务（健康检查、清理、调度等）是否应在本副本执行。
+func IsLeader() bool {
+	fn := checker.Load()
+	if fn == nil {
+		return true
+	}
+	return (*fn)()
+}
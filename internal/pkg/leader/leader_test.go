@@ -0,0 +1,43 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package leader
+
+import "testing"
+
+func TestIsLeaderDefaultsToTrueAndFollowsChecker(t *testing.T) {
+	t.Cleanup(func() { SetChecker(nil) })
+
+	if !IsLeader() {
+		t.Fatal("expected single instance to lead by default")
+	}
+
+	leading := false
+	SetChecker(func() bool { return leading })
+	if IsLeader() {
+		t.Fatal("expected follower while checker reports false")
+	}
+	leading = true
+	if !IsLeader() {
+		t.Fatal("expected leader once checker reports true")
+	}
+
+	SetChecker(nil)
+	if !IsLeader() {
+		t.Fatal("expected default leadership after reset")
+	}
+}
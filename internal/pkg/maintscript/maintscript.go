@@ -0,0 +1,280 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package maintscript defines the allow-list of maintenance scripts the Agent ships with and
+// the schema their arguments are validated against, shared by the Control Plane and the Agent.
+// maintscript 包定义 Agent 内置的运维脚本白名单及其参数校验 schema，由 Control Plane 与 Agent 共用。
+//
+// Only scripts listed here can run; arguments are passed as positional parameters in schema
+// order and are never interpolated into shell source.
+// 只有此处列出的脚本可以执行；参数按 schema 顺序作为位置参数传递，不会拼接进 shell 源码。
+package maintscript
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// ParamScript is the command parameter carrying the script name.
+	// ParamScript 是携带脚本名称的命令参数。
+	ParamScript = "script"
+
+	// ArgParamPrefix prefixes the command parameters carrying script arguments.
+	// ArgParamPrefix 是携带脚本参数的命令参数前缀。
+	ArgParamPrefix = "arg."
+
+	// MaxOutputBytes bounds the captured output of a script run.
+	// MaxOutputBytes 限制脚本执行捕获的输出大小。
+	MaxOutputBytes = 1 << 20
+
+	maxStringArgLen = 256
+)
+
+var (
+	// ErrUnknownScript indicates the script is not in the allow-list.
+	// ErrUnknownScript 表示脚本不在白名单中。
+	ErrUnknownScript = errors.New("maintscript: unknown script")
+
+	// ErrInvalidArgument indicates a script argument does not match its schema.
+	// ErrInvalidArgument 表示脚本参数不符合 schema。
+	ErrInvalidArgument = errors.New("maintscript: invalid argument")
+)
+
+var (
+	pathArgPattern   = regexp.MustCompile(`^/[A-Za-z0-9._/@+-]*$`)
+	stringArgPattern = regexp.MustCompile(`^[A-Za-z0-9._:@=,+-]*$`)
+)
+
+// ArgType is the type of a script argument.
+// ArgType 是脚本参数的类型。
+type ArgType string
+
+const (
+	// ArgString accepts a short token of letters, digits and ._:@=,+-
+	// ArgString 接受由字母、数字及 ._:@=,+- 组成的短字符串
+	ArgString ArgType = "string"
+	// ArgInt accepts an integer within [Min, Max]
+	// ArgInt 接受 [Min, Max] 范围内的整数
+	ArgInt ArgType = "int"
+	// ArgBool accepts true or false
+	// ArgBool 接受 true 或 false
+	ArgBool ArgType = "bool"
+	// ArgEnum accepts one of Enum
+	// ArgEnum 接受 Enum 中的一个取值
+	ArgEnum ArgType = "enum"
+	// ArgInstallDir is the SeaTunnel install directory of the target cluster node. The Control Plane
+	// resolves it from the registered node and never takes it from the caller; the value must be an
+	// absolute path other than "/" without ".." segments.
+	// ArgInstallDir 是目标集群节点的 SeaTunnel 安装目录，由 Control Plane 根据已注册的节点解析，
+	// 从不接受调用方传入；取值必须是非 "/" 且不含 ".." 段的绝对路径。
+	ArgInstallDir ArgType = "install_dir"
+)
+
+// ArgSpec describes one script argument.
+// ArgSpec 描述一个脚本参数。
+type ArgSpec struct {
+	Name        string   `json:"name"`
+	Type        ArgType  `json:"type"`
+	Description string   `json:"description"`
+	Required    bool     `json:"required"`
+	Default     string   `json:"default,omitempty"`
+	Enum        []string `json:"enum,omitempty"`
+	Min         int      `json:"min,omitempty"`
+	Max         int      `json:"max,omitempty"`
+}
+
+// Script is an allow-listed maintenance script.
+// Script 是白名单中的运维脚本。
+type Script struct {
+	Name        string        `json:"name"`
+	Description string        `json:"description"`
+	Args        []ArgSpec     `json:"args"`
+	Timeout     time.Duration `json:"-"`
+	// TimeoutSeconds mirrors Timeout for API responses / TimeoutSeconds 为 API 响应提供 Timeout 的秒数
+	TimeoutSeconds int `json:"timeout_seconds"`
+}
+
+var catalog = []Script{
+	{
+		Name:        "clear_temp_dirs",
+		Description: "Remove files older than N days from the SeaTunnel temp directories / 清理 SeaTunnel 临时目录中超过 N 天的文件",
+		Args: []ArgSpec{
+			{Name: "install_dir", Type: ArgInstallDir, Required: true, Description: "SeaTunnel install directory / SeaTunnel 安装目录"},
+			{Name: "older_than_days", Type: ArgInt, Default: "7", Min: 1, Max: 365, Description: "Minimum file age in days / 文件最小保留天数"},
+		},
+		Timeout: 5 * time.Minute,
+	},
+	{
+		Name:        "rotate_logs",
+		Description: "Compress oversized SeaTunnel logs and purge old archives / 压缩过大的 SeaTunnel 日志并清理旧归档",
+		Args: []ArgSpec{
+			{Name: "install_dir", Type: ArgInstallDir, Required: true, Description: "SeaTunnel install directory / SeaTunnel 安装目录"},
+			{Name: "max_size_mb", Type: ArgInt, Default: "100", Min: 1, Max: 10240, Description: "Rotate logs larger than this size in MB / 超过该大小（MB）的日志将被轮转"},
+			{Name: "keep_days", Type: ArgInt, Default: "7", Min: 1, Max: 365, Description: "Days to keep rotated archives / 归档保留天数"},
+		},
+		Timeout: 10 * time.Minute,
+	},
+	{
+		Name:        "thread_dump",
+		Description: "Print a thread dump of a running Java process / 打印运行中 Java 进程的线程转储",
+		Args: []ArgSpec{
+			{Name: "pid", Type: ArgInt, Required: true, Min: 1, Max: 1<<22 - 1, Description: "Java process ID / Java 进程 ID"},
+		},
+		Timeout: 2 * time.Minute,
+	},
+	{
+		Name:        "disk_usage",
+		Description: "Report disk usage of the SeaTunnel install directory and its filesystem / 报告 SeaTunnel 安装目录及其所在文件系统的磁盘使用情况",
+		Args: []ArgSpec{
+			{Name: "install_dir", Type: ArgInstallDir, Required: true, Description: "SeaTunnel install directory / SeaTunnel 安装目录"},
+		},
+		Timeout: 5 * time.Minute,
+	},
+}
+
+func init() {
+	for i := range catalog {
+		catalog[i].TimeoutSeconds = int(catalog[i].Timeout.Seconds())
+	}
+}
+
+// List returns the allow-listed scripts.
+// List 返回白名单中的脚本。
+func List() []Script {
+	return slices.Clone(catalog)
+}
+
+// Lookup returns the allow-listed script with the given name.
+// Lookup 返回指定名称的白名单脚本。
+func Lookup(name string) (Script, error) {
+	for _, s := range catalog {
+		if s.Name == name {
+			return s, nil
+		}
+	}
+	return Script{}, fmt.Errorf("%w: %q", ErrUnknownScript, name)
+}
+
+// InstallDirArg returns the name of the argument the Control Plane fills with the node install directory.
+// InstallDirArg 返回由 Control Plane 填入节点安装目录的参数名称。
+func (s Script) InstallDirArg() (string, bool) {
+	for _, spec := range s.Args {
+		if spec.Type == ArgInstallDir {
+			return spec.Name, true
+		}
+	}
+	return "", false
+}
+
+// Validate checks args against the script schema and returns the positional arguments in schema order,
+// with defaults filled in for omitted optional arguments.
+// Validate 按脚本 schema 校验参数，并按 schema 顺序返回位置参数，省略的可选参数使用默认值。
+func (s Script) Validate(args map[string]string) ([]string, error) {
+	for name := range args {
+		if !slices.ContainsFunc(s.Args, func(spec ArgSpec) bool { return spec.Name == name }) {
+			return nil, fmt.Errorf("%w: %s does not accept %q", ErrInvalidArgument, s.Name, name)
+		}
+	}
+
+	argv := make([]string, len(s.Args))
+	for i, spec := range s.Args {
+		value, ok := args[spec.Name]
+		if !ok || value == "" {
+			if spec.Required {
+				return nil, fmt.Errorf("%w: %s is required", ErrInvalidArgument, spec.Name)
+			}
+			value = spec.Default
+		}
+		normalized, err := spec.validate(value)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %v", ErrInvalidArgument, spec.Name, err)
+		}
+		argv[i] = normalized
+	}
+	return argv, nil
+}
+
+// validate checks a single value and returns its normalized form.
+// validate 校验单个取值并返回规范化后的形式。
+func (a ArgSpec) validate(value string) (string, error) {
+	switch a.Type {
+	case ArgInt:
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return "", fmt.Errorf("%q is not an integer", value)
+		}
+		if n < a.Min || (a.Max > 0 && n > a.Max) {
+			return "", fmt.Errorf("%d is out of range [%d, %d]", n, a.Min, a.Max)
+		}
+		return strconv.Itoa(n), nil
+	case ArgBool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return "", fmt.Errorf("%q is not a boolean", value)
+		}
+		return strconv.FormatBool(b), nil
+	case ArgEnum:
+		if !slices.Contains(a.Enum, value) {
+			return "", fmt.Errorf("%q is not one of %s", value, strings.Join(a.Enum, ", "))
+		}
+		return value, nil
+	case ArgInstallDir:
+		if !pathArgPattern.MatchString(value) || slices.Contains(strings.Split(value, "/"), "..") {
+			return "", fmt.Errorf("%q is not a plain absolute path", value)
+		}
+		if strings.Trim(value, "/.") == "" {
+			return "", fmt.Errorf("%q is not an install directory", value)
+		}
+		return value, nil
+	case ArgString:
+		if len(value) > maxStringArgLen || !stringArgPattern.MatchString(value) {
+			return "", fmt.Errorf("%q contains unsupported characters", value)
+		}
+		return value, nil
+	default:
+		return "", fmt.Errorf("unsupported argument type %q", a.Type)
+	}
+}
+
+// EncodeParams builds the EXEC_SCRIPT command parameters for a script run.
+// EncodeParams 构建执行脚本的 EXEC_SCRIPT 命令参数。
+func EncodeParams(script string, args map[string]string) map[string]string {
+	params := make(map[string]string, len(args)+1)
+	params[ParamScript] = script
+	for name, value := range args {
+		params[ArgParamPrefix+name] = value
+	}
+	return params
+}
+
+// DecodeParams extracts the script name and arguments from EXEC_SCRIPT command parameters.
+// DecodeParams 从 EXEC_SCRIPT 命令参数中提取脚本名称和参数。
+func DecodeParams(params map[string]string) (string, map[string]string) {
+	args := make(map[string]string)
+	for key, value := range params {
+		if name, ok := strings.CutPrefix(key, ArgParamPrefix); ok {
+			args[name] = value
+		}
+	}
+	return params[ParamScript], args
+}
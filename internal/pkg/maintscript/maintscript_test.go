@@ -0,0 +1,103 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package maintscript
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestValidateFillsDefaultsInSchemaOrder(t *testing.T) {
+	script, err := Lookup("rotate_logs")
+	if err != nil {
+		t.Fatalf("Lookup: %v", err)
+	}
+	argv, err := script.Validate(map[string]string{"keep_days": "03", "install_dir": "/opt/seatunnel"})
+	if err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+	if want := []string{"/opt/seatunnel", "100", "3"}; !reflect.DeepEqual(argv, want) {
+		t.Fatalf("argv = %v, want %v", argv, want)
+	}
+}
+
+func TestValidateRejectsInvalidArguments(t *testing.T) {
+	script, _ := Lookup("clear_temp_dirs")
+	cases := map[string]map[string]string{
+		"missing required": {"older_than_days": "3"},
+		"unknown argument": {"install_dir": "/opt/seatunnel", "cmd": "rm"},
+		"relative path":    {"install_dir": "opt/seatunnel"},
+		"parent segment":   {"install_dir": "/opt/../etc"},
+		"filesystem root":  {"install_dir": "/"},
+		"root with dots":   {"install_dir": "//./"},
+		"shell syntax":     {"install_dir": "/opt/seatunnel;reboot"},
+		"not an integer":   {"install_dir": "/opt/seatunnel", "older_than_days": "7d"},
+		"out of range":     {"install_dir": "/opt/seatunnel", "older_than_days": "0"},
+	}
+	for name, args := range cases {
+		if _, err := script.Validate(args); !errors.Is(err, ErrInvalidArgument) {
+			t.Errorf("%s: expected ErrInvalidArgument, got %v", name, err)
+		}
+	}
+}
+
+func TestArgTypes(t *testing.T) {
+	cases := []struct {
+		spec  ArgSpec
+		value string
+		want  string
+		ok    bool
+	}{
+		{ArgSpec{Type: ArgBool}, "1", "true", true},
+		{ArgSpec{Type: ArgBool}, "yes", "", false},
+		{ArgSpec{Type: ArgEnum, Enum: []string{"master", "worker"}}, "worker", "worker", true},
+		{ArgSpec{Type: ArgEnum, Enum: []string{"master", "worker"}}, "client", "", false},
+		{ArgSpec{Type: ArgString}, "zone=az1", "zone=az1", true},
+		{ArgSpec{Type: ArgString}, "$(id)", "", false},
+	}
+	for _, tc := range cases {
+		got, err := tc.spec.validate(tc.value)
+		if (err == nil) != tc.ok || got != tc.want {
+			t.Errorf("%s %q = %q, %v; want %q ok=%v", tc.spec.Type, tc.value, got, err, tc.want, tc.ok)
+		}
+	}
+}
+
+func TestInstallDirArg(t *testing.T) {
+	for _, script := range List() {
+		name, ok := script.InstallDirArg()
+		if want := script.Name != "thread_dump"; ok != want || (ok && name != "install_dir") {
+			t.Errorf("%s: InstallDirArg = %q, %v", script.Name, name, ok)
+		}
+	}
+}
+
+func TestLookupUnknownScript(t *testing.T) {
+	if _, err := Lookup("bash"); !errors.Is(err, ErrUnknownScript) {
+		t.Fatalf("expected ErrUnknownScript, got %v", err)
+	}
+}
+
+func TestParamsRoundTrip(t *testing.T) {
+	args := map[string]string{"pid": "42"}
+	name, decoded := DecodeParams(EncodeParams("thread_dump", args))
+	if name != "thread_dump" || !reflect.DeepEqual(decoded, args) {
+		t.Fatalf("DecodeParams = %q, %v", name, decoded)
+	}
+}
@@ -0,0 +1,187 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/seatunnel/seatunnelX/internal/config"
+)
+
+// LocalStore keeps objects in a directory, typically a network file system mounted on every replica.
+// LocalStore 将对象保存在目录中，通常是各副本都挂载的网络文件系统。
+type LocalStore struct {
+	dir string
+}
+
+// NewLocalStore creates a store rooted at dir.
+// NewLocalStore 创建以 dir 为根目录的存储。
+func NewLocalStore(dir string) *LocalStore {
+	return &LocalStore{dir: dir}
+}
+
+// Type implements Store.
+// Type 实现 Store 接口。
+func (s *LocalStore) Type() string {
+	return config.ObjectStoreLocal
+}
+
+// Put implements Store. The object is written to a temporary file first so readers never see a partial object.
+// Put 实现 Store 接口。先写入临时文件，读取方不会看到不完整的对象。
+func (s *LocalStore) Put(_ context.Context, key string, r io.Reader, _ int64) error {
+	target, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(target), ".objstore-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), target)
+}
+
+// Open implements Store.
+// Open 实现 Store 接口。
+func (s *LocalStore) Open(_ context.Context, key string) (io.ReadCloser, error) {
+	target, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(target)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	return file, err
+}
+
+// Stat implements Store.
+// Stat 实现 Store 接口。
+func (s *LocalStore) Stat(_ context.Context, key string) (ObjectInfo, error) {
+	target, err := s.path(key)
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+	info, err := os.Stat(target)
+	if errors.Is(err, os.ErrNotExist) || (err == nil && !info.Mode().IsRegular()) {
+		return ObjectInfo{}, ErrNotFound
+	}
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+	cleaned, _ := cleanKey(key)
+	return ObjectInfo{Key: cleaned, Size: info.Size(), ModTime: info.ModTime()}, nil
+}
+
+// Delete implements Store.
+// Delete 实现 Store 接口。
+func (s *LocalStore) Delete(_ context.Context, key string) error {
+	target, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(target); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// List implements Store.
+// List 实现 Store 接口。
+func (s *LocalStore) List(_ context.Context, prefix string) ([]ObjectInfo, error) {
+	prefix = strings.TrimPrefix(prefix, "/")
+	if strings.Contains(prefix, "..") {
+		return nil, fmt.Errorf("invalid object prefix %q", prefix)
+	}
+	// Only the deepest directory named by the prefix needs walking.
+	// 只需遍历 prefix 所指的最深目录。
+	walkRoot := s.dir
+	if dir := path.Dir(prefix); strings.HasSuffix(prefix, "/") {
+		walkRoot = filepath.Join(s.dir, filepath.FromSlash(prefix))
+	} else if dir != "." {
+		walkRoot = filepath.Join(s.dir, filepath.FromSlash(dir))
+	}
+
+	objects := make([]ObjectInfo, 0)
+	err := filepath.WalkDir(walkRoot, func(current string, entry fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			if errors.Is(walkErr, os.ErrNotExist) {
+				return nil
+			}
+			return walkErr
+		}
+		if !entry.Type().IsRegular() || strings.HasPrefix(entry.Name(), ".objstore-") {
+			return nil
+		}
+		rel, err := filepath.Rel(s.dir, current)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(rel)
+		if !strings.HasPrefix(key, prefix) {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		objects = append(objects, ObjectInfo{Key: key, Size: info.Size(), ModTime: info.ModTime()})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(objects, func(i, j int) bool { return objects[i].Key < objects[j].Key })
+	return objects, nil
+}
+
+// PresignGet implements Store. A shared directory has no URL agents could download from.
+// PresignGet 实现 Store 接口。共享目录没有可供 Agent 下载的地址。
+func (s *LocalStore) PresignGet(context.Context, string, time.Duration) (string, error) {
+	return "", ErrPresignUnsupported
+}
+
+// path maps a key to a file below the store directory, rejecting keys that escape it.
+// path 将 key 映射为存储目录下的文件路径，拒绝越出目录的 key。
+func (s *LocalStore) path(key string) (string, error) {
+	cleaned, err := cleanKey(key)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(s.dir, filepath.FromSlash(cleaned)), nil
+}
@@ -0,0 +1,158 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package objstore stores large Control Plane files (installation packages, plugin jars, diagnostic bundles)
+// in a backend shared by every replica: a local or network-mounted directory, or an S3-compatible object store
+// such as AWS S3, Aliyun OSS or MinIO.
+// Package objstore 将控制面的大文件（安装包、插件 jar、诊断包）保存在所有副本共享的后端中：
+// 本地或网络挂载目录，或 AWS S3、阿里云 OSS、MinIO 等 S3 兼容对象存储。
+package objstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/seatunnel/seatunnelX/internal/config"
+)
+
+var (
+	// ErrNotFound is returned when no object is stored under the key.
+	// ErrNotFound 表示 key 下不存在对象。
+	ErrNotFound = errors.New("objstore: object not found / 对象不存在")
+	// ErrPresignUnsupported is returned by backends that cannot hand out download URLs.
+	// ErrPresignUnsupported 表示后端不支持生成下载地址。
+	ErrPresignUnsupported = errors.New("objstore: presigned URLs are not supported by this backend / 当前存储后端不支持预签名地址")
+)
+
+// ObjectInfo describes a stored object.
+// ObjectInfo 描述一个已存储的对象。
+type ObjectInfo struct {
+	Key     string
+	Size    int64
+	ModTime time.Time
+}
+
+// Store keeps objects addressed by slash-separated keys.
+// Store 以斜杠分隔的 key 保存对象。
+type Store interface {
+	// Type returns the configured backend type.
+	// Type 返回配置的后端类型。
+	Type() string
+	// Put writes an object of the given size under key, replacing any previous object.
+	// Put 以 key 写入指定大小的对象，覆盖已有对象。
+	Put(ctx context.Context, key string, r io.Reader, size int64) error
+	// Open reads the object stored under key.
+	// Open 读取 key 对应的对象。
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	// Stat returns the object metadata, or ErrNotFound.
+	// Stat 返回对象元数据，不存在时返回 ErrNotFound。
+	Stat(ctx context.Context, key string) (ObjectInfo, error)
+	// Delete removes the object stored under key, a missing object is not an error.
+	// Delete 删除 key 对应的对象，对象不存在时不报错。
+	Delete(ctx context.Context, key string) error
+	// List returns every object whose key starts with prefix.
+	// List 返回 key 以 prefix 开头的所有对象。
+	List(ctx context.Context, prefix string) ([]ObjectInfo, error)
+	// PresignGet returns a URL that downloads the object without credentials until expiry, or ErrPresignUnsupported.
+	// PresignGet 返回在有效期内无需凭证即可下载对象的地址，不支持时返回 ErrPresignUnsupported。
+	PresignGet(ctx context.Context, key string, expiry time.Duration) (string, error)
+}
+
+// New creates the store selected by the configuration. It returns a nil store when object storage is disabled.
+// New 按配置创建存储，未启用对象存储时返回 nil。
+func New(cfg config.ObjectStoreConfig) (Store, error) {
+	switch strings.ToLower(strings.TrimSpace(cfg.Type)) {
+	case "":
+		return nil, nil
+	case config.ObjectStoreLocal:
+		return NewLocalStore(cfg.LocalDir), nil
+	case config.ObjectStoreS3, config.ObjectStoreOSS, config.ObjectStoreMinIO:
+		return NewS3Store(cfg)
+	default:
+		return nil, fmt.Errorf("unsupported object store type %q", cfg.Type)
+	}
+}
+
+// Sub returns a view of s whose keys are placed below prefix. A nil store stays nil.
+// Sub 返回将 key 置于 prefix 之下的 s 视图，nil 存储仍返回 nil。
+func Sub(s Store, prefix string) Store {
+	if s == nil {
+		return nil
+	}
+	prefix = strings.Trim(prefix, "/")
+	if prefix == "" {
+		return s
+	}
+	return &subStore{base: s, prefix: prefix + "/"}
+}
+
+type subStore struct {
+	base   Store
+	prefix string
+}
+
+func (s *subStore) Type() string {
+	return s.base.Type()
+}
+
+func (s *subStore) Put(ctx context.Context, key string, r io.Reader, size int64) error {
+	return s.base.Put(ctx, s.prefix+key, r, size)
+}
+
+func (s *subStore) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	return s.base.Open(ctx, s.prefix+key)
+}
+
+func (s *subStore) Stat(ctx context.Context, key string) (ObjectInfo, error) {
+	info, err := s.base.Stat(ctx, s.prefix+key)
+	info.Key = strings.TrimPrefix(info.Key, s.prefix)
+	return info, err
+}
+
+func (s *subStore) Delete(ctx context.Context, key string) error {
+	return s.base.Delete(ctx, s.prefix+key)
+}
+
+func (s *subStore) List(ctx context.Context, prefix string) ([]ObjectInfo, error) {
+	objects, err := s.base.List(ctx, s.prefix+prefix)
+	for i := range objects {
+		objects[i].Key = strings.TrimPrefix(objects[i].Key, s.prefix)
+	}
+	return objects, err
+}
+
+func (s *subStore) PresignGet(ctx context.Context, key string, expiry time.Duration) (string, error) {
+	return s.base.PresignGet(ctx, s.prefix+key, expiry)
+}
+
+// cleanKey normalizes a key and rejects keys that are empty or escape the store root.
+// cleanKey 规范化 key，拒绝空 key 或越出存储根目录的 key。
+func cleanKey(key string) (string, error) {
+	if strings.Contains(key, "..") || strings.Contains(key, "\\") {
+		return "", fmt.Errorf("invalid object key %q", key)
+	}
+	cleaned := strings.TrimPrefix(path.Clean("/"+key), "/")
+	if cleaned == "" {
+		return "", fmt.Errorf("invalid object key %q", key)
+	}
+	return cleaned, nil
+}
@@ -0,0 +1,166 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objstore
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/seatunnel/seatunnelX/internal/config"
+)
+
+func TestLocalStoreRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	store := Sub(NewLocalStore(t.TempDir()), "packages")
+
+	payload := []byte("apache-seatunnel")
+	if err := store.Put(ctx, "2.3.12/apache-seatunnel-2.3.12-bin.tar.gz", bytes.NewReader(payload), int64(len(payload))); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	info, err := store.Stat(ctx, "2.3.12/apache-seatunnel-2.3.12-bin.tar.gz")
+	if err != nil || info.Size != int64(len(payload)) || info.Key != "2.3.12/apache-seatunnel-2.3.12-bin.tar.gz" {
+		t.Fatalf("Stat = %+v, %v", info, err)
+	}
+	reader, err := store.Open(ctx, "2.3.12/apache-seatunnel-2.3.12-bin.tar.gz")
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	got, _ := io.ReadAll(reader)
+	reader.Close()
+	if !bytes.Equal(got, payload) {
+		t.Fatalf("Open returned %q", got)
+	}
+
+	objects, err := store.List(ctx, "2.3.12/")
+	if err != nil || len(objects) != 1 || objects[0].Key != "2.3.12/apache-seatunnel-2.3.12-bin.tar.gz" {
+		t.Fatalf("List = %+v, %v", objects, err)
+	}
+	if objects, err := store.List(ctx, "2.3.1/"); err != nil || len(objects) != 0 {
+		t.Fatalf("List of another version = %+v, %v", objects, err)
+	}
+
+	if err := store.Delete(ctx, "2.3.12/apache-seatunnel-2.3.12-bin.tar.gz"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := store.Stat(ctx, "2.3.12/apache-seatunnel-2.3.12-bin.tar.gz"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Stat after delete = %v, want ErrNotFound", err)
+	}
+	if _, err := store.Open(ctx, "2.3.12/apache-seatunnel-2.3.12-bin.tar.gz"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Open after delete = %v, want ErrNotFound", err)
+	}
+	if _, err := store.PresignGet(ctx, "any", time.Minute); !errors.Is(err, ErrPresignUnsupported) {
+		t.Fatalf("PresignGet = %v, want ErrPresignUnsupported", err)
+	}
+}
+
+func TestLocalStoreRejectsEscapingKeys(t *testing.T) {
+	store := NewLocalStore(t.TempDir())
+	for _, key := range []string{"", "/", "../secret", "a/../../b", `a\b`} {
+		if err := store.Put(context.Background(), key, strings.NewReader("x"), 1); err == nil {
+			t.Fatalf("Put(%q) succeeded, want error", key)
+		}
+	}
+}
+
+func TestUploadAndDownloadDir(t *testing.T) {
+	ctx := context.Background()
+	store := NewLocalStore(t.TempDir())
+	source := t.TempDir()
+	files := map[string]string{
+		"connectors/connector-jdbc-2.3.12.jar": "jdbc",
+		"lib/mysql-connector-j-8.0.33.jar":     "mysql",
+		"metadata/jdbc.json":                   "{}",
+	}
+	for name, content := range files {
+		target := filepath.Join(source, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(target, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := UploadDir(ctx, store, "plugins/2.3.12", source); err != nil {
+		t.Fatalf("UploadDir: %v", err)
+	}
+
+	dest := t.TempDir()
+	written, err := DownloadDir(ctx, store, "plugins/2.3.12", dest)
+	if err != nil || written != len(files) {
+		t.Fatalf("DownloadDir = %d, %v", written, err)
+	}
+	for name, content := range files {
+		got, err := os.ReadFile(filepath.Join(dest, filepath.FromSlash(name)))
+		if err != nil || string(got) != content {
+			t.Fatalf("%s = %q, %v", name, got, err)
+		}
+	}
+	if written, err := DownloadDir(ctx, store, "plugins/2.3.12", dest); err != nil || written != 0 {
+		t.Fatalf("second DownloadDir = %d, %v, want nothing rewritten", written, err)
+	}
+}
+
+func TestS3StorePresignGet(t *testing.T) {
+	tests := []struct {
+		storeType string
+		endpoint  string
+		wantHost  string
+		wantPath  string
+	}{
+		{storeType: config.ObjectStoreMinIO, endpoint: "http://minio:9000", wantHost: "minio:9000", wantPath: "/packages/seatunnelx/pkg.tar.gz"},
+		{storeType: config.ObjectStoreOSS, endpoint: "https://oss-cn-hangzhou.aliyuncs.com", wantHost: "packages.oss-cn-hangzhou.aliyuncs.com", wantPath: "/seatunnelx/pkg.tar.gz"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.storeType, func(t *testing.T) {
+			store, err := NewS3Store(config.ObjectStoreConfig{
+				Type:      tt.storeType,
+				Endpoint:  tt.endpoint,
+				Region:    "cn-hangzhou",
+				Bucket:    "packages",
+				Prefix:    "seatunnelx",
+				AccessKey: "ak",
+				SecretKey: "sk",
+			})
+			if err != nil {
+				t.Fatalf("NewS3Store: %v", err)
+			}
+			raw, err := store.PresignGet(context.Background(), "pkg.tar.gz", 10*time.Minute)
+			if err != nil {
+				t.Fatalf("PresignGet: %v", err)
+			}
+			signed, err := url.Parse(raw)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if signed.Host != tt.wantHost || signed.Path != tt.wantPath {
+				t.Fatalf("PresignGet = %s, want host %s path %s", raw, tt.wantHost, tt.wantPath)
+			}
+			if signed.Query().Get("X-Amz-Expires") != "600" || signed.Query().Get("X-Amz-Signature") == "" {
+				t.Fatalf("PresignGet = %s, want a signed URL valid for 600s", raw)
+			}
+		})
+	}
+}
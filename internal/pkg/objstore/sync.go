@@ -0,0 +1,135 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objstore
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// UploadFile copies the local file at localPath into the store under key.
+// UploadFile 将本地文件 localPath 复制到存储的 key 下。
+func UploadFile(ctx context.Context, s Store, key, localPath string) error {
+	file, err := os.Open(localPath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+	return s.Put(ctx, key, file, info.Size())
+}
+
+// DownloadFile copies the object stored under key to localPath. The file is written next to its
+// destination first and renamed, so an interrupted download never leaves a truncated file behind.
+// DownloadFile 将 key 下的对象复制到 localPath。先写入目标旁的临时文件再重命名，中断时不会留下不完整文件。
+func DownloadFile(ctx context.Context, s Store, key, localPath string) error {
+	reader, err := s.Open(ctx, key)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	if err := os.MkdirAll(filepath.Dir(localPath), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(localPath), ".objstore-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := io.Copy(tmp, reader); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), localPath)
+}
+
+// UploadDir copies every regular file below dir into the store under prefix, skipping files whose
+// stored copy has the same size and is not older than the local file.
+// UploadDir 将 dir 下的所有普通文件复制到存储的 prefix 下，已存在且大小相同、不早于本地文件的对象会被跳过。
+func UploadDir(ctx context.Context, s Store, prefix, dir string) error {
+	return filepath.WalkDir(dir, func(current string, entry fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			if errors.Is(walkErr, os.ErrNotExist) {
+				return nil
+			}
+			return walkErr
+		}
+		if !entry.Type().IsRegular() || strings.HasPrefix(entry.Name(), ".") {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, current)
+		if err != nil {
+			return err
+		}
+		key := path.Join(prefix, filepath.ToSlash(rel))
+		local, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		if stored, err := s.Stat(ctx, key); err == nil && stored.Size == local.Size() && !stored.ModTime.Before(local.ModTime()) {
+			return nil
+		}
+		return UploadFile(ctx, s, key, current)
+	})
+}
+
+// DownloadDir copies every object stored under prefix into dir, skipping files that already exist
+// locally with the same size. It returns the number of files written.
+// DownloadDir 将 prefix 下的所有对象复制到 dir，本地已存在且大小相同的文件会被跳过，返回写入的文件数。
+func DownloadDir(ctx context.Context, s Store, prefix, dir string) (int, error) {
+	// The prefix names a directory, so "2.3.1" must not match objects of "2.3.12".
+	// prefix 表示目录，"2.3.1" 不应匹配 "2.3.12" 下的对象。
+	if prefix != "" && !strings.HasSuffix(prefix, "/") {
+		prefix += "/"
+	}
+	objects, err := s.List(ctx, prefix)
+	if err != nil {
+		return 0, err
+	}
+	written := 0
+	for _, object := range objects {
+		rel := strings.TrimPrefix(object.Key, prefix)
+		if _, err := cleanKey(rel); err != nil {
+			return written, err
+		}
+		target := filepath.Join(dir, filepath.FromSlash(rel))
+		if info, err := os.Stat(target); err == nil && info.Size() == object.Size {
+			continue
+		}
+		if err := DownloadFile(ctx, s, object.Key, target); err != nil {
+			return written, err
+		}
+		written++
+	}
+	return written, nil
+}
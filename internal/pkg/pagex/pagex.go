@@ -0,0 +1,304 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package pagex is the common pagination, sorting and time-range layer of list endpoints.
+// pagex 包是列表接口通用的分页、排序和时间范围处理层。
+//
+// Query string (all optional):
+// 查询参数（均为可选）：
+//
+//	page=2&page_size=50&sort=-created_at,name&start_time=2026-01-01&end_time=2026-01-31T12:00:00Z
+//
+// current and size are accepted as legacy names of page and page_size; a leading "-" sorts descending.
+// current 和 size 作为 page 与 page_size 的旧名称仍被接受；字段前加 "-" 表示降序。
+package pagex
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// DefaultPageSize is the page size used when the endpoint does not set one.
+	// DefaultPageSize 是接口未指定时使用的每页数量。
+	DefaultPageSize = 20
+	// MaxPageSize is the largest page size accepted when the endpoint does not set one.
+	// MaxPageSize 是接口未指定时允许的最大每页数量。
+	MaxPageSize = 100
+)
+
+var (
+	// ErrInvalidPage is returned for a page below 1.
+	// ErrInvalidPage 在页码小于 1 时返回。
+	ErrInvalidPage = errors.New("page must be at least 1 / 页码必须大于等于 1")
+	// ErrInvalidPageSize is returned for a page size outside 1..MaxSize.
+	// ErrInvalidPageSize 在每页数量超出 1..MaxSize 时返回。
+	ErrInvalidPageSize = errors.New("invalid page_size / 无效的 page_size")
+	// ErrInvalidSort is returned for an unknown sort field.
+	// ErrInvalidSort 在排序字段未知时返回。
+	ErrInvalidSort = errors.New("invalid sort field / 无效的排序字段")
+	// ErrInvalidTime is returned for a time that is neither RFC3339 nor a date.
+	// ErrInvalidTime 在时间既不是 RFC3339 也不是日期格式时返回。
+	ErrInvalidTime = errors.New("invalid time, use RFC3339 or YYYY-MM-DD / 无效的时间，请使用 RFC3339 或 YYYY-MM-DD 格式")
+)
+
+// Query is the pagination and sorting part of a list request, embedded in the endpoint's query struct.
+// Query 是列表请求中的分页和排序部分，嵌入到各接口的查询结构体中。
+type Query struct {
+	Page     int    `json:"page" form:"page"`
+	PageSize int    `json:"page_size" form:"page_size"`
+	Current  int    `json:"current" form:"current"`
+	Size     int    `json:"size" form:"size"`
+	Sort     string `json:"sort" form:"sort"`
+}
+
+// Spec describes how an endpoint pages and which fields it can be sorted by.
+// Spec 描述接口的分页方式以及可排序的字段。
+type Spec struct {
+	// DefaultSize and MaxSize default to DefaultPageSize and MaxPageSize.
+	// DefaultSize 和 MaxSize 默认为 DefaultPageSize 和 MaxPageSize。
+	DefaultSize int
+	MaxSize     int
+	// Fields maps sortable API field names to database columns.
+	// Fields 将可排序的 API 字段名映射到数据库列。
+	Fields map[string]string
+	// DefaultSort is used when the request has no sort, e.g. "-created_at".
+	// DefaultSort 在请求未指定排序时使用，例如 "-created_at"。
+	DefaultSort string
+}
+
+// SortField is one resolved sort key.
+// SortField 是一个解析后的排序键。
+type SortField struct {
+	Field  string `json:"field"`
+	Column string `json:"-"`
+	Desc   bool   `json:"desc"`
+}
+
+// Params is a validated page and sort order.
+// Params 是校验后的分页和排序。
+type Params struct {
+	Page     int
+	PageSize int
+	Sort     []SortField
+}
+
+// Resolve validates the query against spec and fills in defaults.
+// Resolve 按 spec 校验查询并填充默认值。
+func (q Query) Resolve(spec Spec) (Params, error) {
+	defaultSize, maxSize := spec.DefaultSize, spec.MaxSize
+	if defaultSize <= 0 {
+		defaultSize = DefaultPageSize
+	}
+	if maxSize <= 0 {
+		maxSize = MaxPageSize
+	}
+
+	page := firstPositive(q.Page, q.Current, 1)
+	if q.Page < 0 || q.Current < 0 {
+		return Params{}, ErrInvalidPage
+	}
+	size := firstPositive(q.PageSize, q.Size, defaultSize)
+	if q.PageSize < 0 || q.Size < 0 || size > maxSize {
+		return Params{}, fmt.Errorf("%w: must be between 1 and %d", ErrInvalidPageSize, maxSize)
+	}
+
+	raw := strings.TrimSpace(q.Sort)
+	if raw == "" {
+		raw = spec.DefaultSort
+	}
+	sort, err := ParseSort(raw, spec.Fields)
+	if err != nil {
+		return Params{}, err
+	}
+	return Params{Page: page, PageSize: size, Sort: sort}, nil
+}
+
+// ParseSort parses a comma separated sort list such as "-created_at,name".
+// ParseSort 解析逗号分隔的排序列表，例如 "-created_at,name"。
+func ParseSort(raw string, fields map[string]string) ([]SortField, error) {
+	var sort []SortField
+	for _, part := range strings.Split(raw, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		desc := false
+		switch part[0] {
+		case '-':
+			desc, part = true, part[1:]
+		case '+':
+			part = part[1:]
+		}
+		column, ok := fields[part]
+		if !ok {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidSort, part)
+		}
+		sort = append(sort, SortField{Field: part, Column: column, Desc: desc})
+	}
+	return sort, nil
+}
+
+// Offset returns the number of rows skipped before the page.
+// Offset 返回当前页之前跳过的行数。
+func (p Params) Offset() int {
+	if p.Page <= 1 || p.PageSize <= 0 {
+		return 0
+	}
+	return (p.Page - 1) * p.PageSize
+}
+
+// Bounds returns the [start, end) slice bounds of the page within total items.
+// Bounds 返回当前页在 total 个元素中的 [start, end) 切片范围。
+func (p Params) Bounds(total int) (int, int) {
+	if p.PageSize <= 0 {
+		return 0, total
+	}
+	start := p.Offset()
+	if start > total {
+		start = total
+	}
+	end := start + p.PageSize
+	if end > total {
+		end = total
+	}
+	return start, end
+}
+
+// Order applies the sort fields to query, falling back to fallback (e.g. "created_at DESC").
+// Order 将排序字段应用到查询，未指定时使用 fallback（例如 "created_at DESC"）。
+func Order(query *gorm.DB, sort []SortField, fallback string) *gorm.DB {
+	if len(sort) == 0 {
+		if fallback == "" {
+			return query
+		}
+		return query.Order(fallback)
+	}
+	for _, field := range sort {
+		direction := "ASC"
+		if field.Desc {
+			direction = "DESC"
+		}
+		query = query.Order(field.Column + " " + direction)
+	}
+	return query
+}
+
+// Paginate applies offset and limit for page; a non-positive pageSize leaves the query unbounded.
+// Paginate 按页应用 offset 和 limit；pageSize 非正数时不限制。
+func Paginate(query *gorm.DB, page, pageSize int) *gorm.DB {
+	p := Params{Page: page, PageSize: pageSize}
+	if p.PageSize <= 0 {
+		return query
+	}
+	return query.Offset(p.Offset()).Limit(p.PageSize)
+}
+
+// TimeQuery is the time-range part of a list request; it filters on the record creation time.
+// TimeQuery 是列表请求中的时间范围部分，按记录创建时间过滤。
+type TimeQuery struct {
+	StartTime string `json:"start_time" form:"start_time"`
+	EndTime   string `json:"end_time" form:"end_time"`
+}
+
+// Range parses the query into a TimeRange.
+// Range 将查询解析为 TimeRange。
+func (q TimeQuery) Range() (TimeRange, error) {
+	return ParseTimeRange(q.StartTime, q.EndTime)
+}
+
+// TimeRange is an optional closed time interval.
+// TimeRange 是可选的闭区间时间范围。
+type TimeRange struct {
+	From *time.Time `json:"from,omitempty"`
+	To   *time.Time `json:"to,omitempty"`
+}
+
+// ParseTimeRange parses start and end as RFC3339 or YYYY-MM-DD; a date-only end covers that whole day.
+// ParseTimeRange 将 start 和 end 解析为 RFC3339 或 YYYY-MM-DD；仅有日期的 end 覆盖当天全天。
+func ParseTimeRange(start, end string) (TimeRange, error) {
+	var r TimeRange
+	if start = strings.TrimSpace(start); start != "" {
+		t, _, err := parseTime(start)
+		if err != nil {
+			return TimeRange{}, fmt.Errorf("start_time: %w", err)
+		}
+		r.From = &t
+	}
+	if end = strings.TrimSpace(end); end != "" {
+		t, dateOnly, err := parseTime(end)
+		if err != nil {
+			return TimeRange{}, fmt.Errorf("end_time: %w", err)
+		}
+		if dateOnly {
+			t = t.Add(24*time.Hour - time.Nanosecond)
+		}
+		r.To = &t
+	}
+	return r, nil
+}
+
+// Apply restricts column to the range.
+// Apply 将 column 限制在范围内。
+func (r TimeRange) Apply(query *gorm.DB, column string) *gorm.DB {
+	if r.From != nil {
+		query = query.Where(column+" >= ?", *r.From)
+	}
+	if r.To != nil {
+		query = query.Where(column+" <= ?", *r.To)
+	}
+	return query
+}
+
+// Contains reports whether t falls within the range.
+// Contains 判断 t 是否在范围内。
+func (r TimeRange) Contains(t time.Time) bool {
+	if r.From != nil && t.Before(*r.From) {
+		return false
+	}
+	if r.To != nil && t.After(*r.To) {
+		return false
+	}
+	return true
+}
+
+// parseTime parses RFC3339 or a plain date in UTC.
+// parseTime 解析 RFC3339 或 UTC 下的纯日期。
+func parseTime(value string) (time.Time, bool, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, false, nil
+	}
+	if t, err := time.Parse(time.DateOnly, value); err == nil {
+		return t, true, nil
+	}
+	return time.Time{}, false, ErrInvalidTime
+}
+
+// firstPositive returns the first positive value.
+// firstPositive 返回第一个正数值。
+func firstPositive(values ...int) int {
+	for _, v := range values {
+		if v > 0 {
+			return v
+		}
+	}
+	return 0
+}
@@ -0,0 +1,95 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pagex
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+var testSpec = Spec{
+	Fields:      map[string]string{"name": "name", "created_at": "created_at"},
+	DefaultSort: "-created_at",
+}
+
+func TestResolveDefaultsAndLegacyNames(t *testing.T) {
+	params, err := Query{}.Resolve(testSpec)
+	if err != nil {
+		t.Fatalf("Resolve returned error: %v", err)
+	}
+	if params.Page != 1 || params.PageSize != DefaultPageSize || len(params.Sort) != 1 || !params.Sort[0].Desc || params.Sort[0].Column != "created_at" {
+		t.Fatalf("unexpected defaults: %+v", params)
+	}
+
+	params, err = Query{Current: 3, Size: 10, Sort: "name,-created_at"}.Resolve(testSpec)
+	if err != nil {
+		t.Fatalf("Resolve returned error: %v", err)
+	}
+	if params.Page != 3 || params.PageSize != 10 || params.Offset() != 20 || len(params.Sort) != 2 || params.Sort[0].Desc {
+		t.Fatalf("unexpected legacy params: %+v", params)
+	}
+
+	// page and page_size take precedence over current and size / page 与 page_size 优先于 current 与 size
+	params, _ = Query{Page: 2, PageSize: 5, Current: 9, Size: 50}.Resolve(testSpec)
+	if params.Page != 2 || params.PageSize != 5 {
+		t.Fatalf("unexpected precedence: %+v", params)
+	}
+}
+
+func TestResolveRejectsInvalidInput(t *testing.T) {
+	cases := map[string]struct {
+		query Query
+		want  error
+	}{
+		"page size over max": {Query{PageSize: MaxPageSize + 1}, ErrInvalidPageSize},
+		"negative page":      {Query{Page: -1}, ErrInvalidPage},
+		"unknown sort":       {Query{Sort: "-password"}, ErrInvalidSort},
+	}
+	for name, tc := range cases {
+		if _, err := tc.query.Resolve(testSpec); !errors.Is(err, tc.want) {
+			t.Errorf("%s: expected %v, got %v", name, tc.want, err)
+		}
+	}
+}
+
+func TestBounds(t *testing.T) {
+	p := Params{Page: 3, PageSize: 4}
+	if start, end := p.Bounds(10); start != 8 || end != 10 {
+		t.Fatalf("Bounds(10) = %d, %d", start, end)
+	}
+	if start, end := p.Bounds(5); start != 5 || end != 5 {
+		t.Fatalf("Bounds(5) = %d, %d", start, end)
+	}
+}
+
+func TestParseTimeRange(t *testing.T) {
+	r, err := ParseTimeRange("2026-01-01T08:00:00Z", "2026-01-31")
+	if err != nil {
+		t.Fatalf("ParseTimeRange returned error: %v", err)
+	}
+	if !r.Contains(time.Date(2026, 1, 31, 23, 0, 0, 0, time.UTC)) {
+		t.Fatal("date-only end should cover the whole day")
+	}
+	if r.Contains(time.Date(2026, 1, 1, 7, 59, 0, 0, time.UTC)) {
+		t.Fatal("time before start should not be contained")
+	}
+	if _, err := ParseTimeRange("yesterday", ""); !errors.Is(err, ErrInvalidTime) {
+		t.Fatalf("expected ErrInvalidTime, got %v", err)
+	}
+}
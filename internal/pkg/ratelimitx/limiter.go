@@ -0,0 +1,91 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package ratelimitx provides in-memory keyed token bucket rate limiting.
+// ratelimitx 包提供基于内存、按键划分的令牌桶限流。
+package ratelimitx
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// Limiter allows limit requests per window for each key, refilling continuously.
+// Limiter 为每个键在每个窗口内允许 limit 次请求，令牌连续补充。
+type Limiter struct {
+	mu      sync.Mutex
+	limit   int
+	window  time.Duration
+	buckets map[string]*bucket
+	now     func() time.Time
+}
+
+// bucket is the rate limit state of one key.
+// bucket 是单个键的限流状态。
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// New creates a limiter; limit <= 0 or window <= 0 disables rate limiting.
+// New 创建限流器；limit <= 0 或 window <= 0 表示不限流。
+func New(limit int, window time.Duration) *Limiter {
+	return &Limiter{limit: limit, window: window, buckets: make(map[string]*bucket), now: time.Now}
+}
+
+// Enabled reports whether the limiter rejects anything at all.
+// Enabled 返回限流器是否生效。
+func (l *Limiter) Enabled() bool {
+	return l != nil && l.limit > 0 && l.window > 0
+}
+
+// Allow consumes one token for key. When the request is rejected it also returns how long to
+// wait until a token is available again.
+// Allow 为 key 消耗一个令牌。请求被拒绝时同时返回需要等待多久才会有可用令牌。
+func (l *Limiter) Allow(key string) (bool, time.Duration) {
+	if !l.Enabled() {
+		return true, 0
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	capacity := float64(l.limit)
+	rate := capacity / float64(l.window)
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: capacity, last: now}
+		l.buckets[key] = b
+	}
+	b.tokens = math.Min(capacity, b.tokens+float64(now.Sub(b.last))*rate)
+	b.last = now
+
+	// Buckets idle for a whole window are full again; drop them so the map stays bounded.
+	// 空闲超过一个窗口的桶已重新填满，删除以免映射无限增长。
+	for k, other := range l.buckets {
+		if k != key && now.Sub(other.last) > l.window {
+			delete(l.buckets, k)
+		}
+	}
+
+	if b.tokens < 1 {
+		return false, time.Duration(math.Ceil((1 - b.tokens) / rate))
+	}
+	b.tokens--
+	return true, 0
+}
@@ -0,0 +1,63 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ratelimitx
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLimiterAllowsBurstThenRefills(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	l := New(3, time.Minute)
+	l.now = func() time.Time { return now }
+
+	for i := 0; i < 3; i++ {
+		if ok, _ := l.Allow("10.0.0.1"); !ok {
+			t.Fatalf("request %d should be allowed", i+1)
+		}
+	}
+	ok, retryAfter := l.Allow("10.0.0.1")
+	if ok {
+		t.Fatal("fourth request should be rejected")
+	}
+	if retryAfter != 20*time.Second {
+		t.Fatalf("expected retry after 20s, got %s", retryAfter)
+	}
+	if ok, _ := l.Allow("10.0.0.2"); !ok {
+		t.Fatal("other keys must have their own bucket")
+	}
+
+	now = now.Add(20 * time.Second)
+	if ok, _ := l.Allow("10.0.0.1"); !ok {
+		t.Fatal("request should be allowed after one token refilled")
+	}
+}
+
+func TestLimiterDisabled(t *testing.T) {
+	for _, l := range []*Limiter{nil, New(0, time.Minute), New(-1, time.Minute)} {
+		if l.Enabled() {
+			t.Fatalf("expected limiter %+v to be disabled", l)
+		}
+		for i := 0; i < 100; i++ {
+			if ok, _ := l.Allow("key"); !ok {
+				t.Fatal("disabled limiter must allow everything")
+			}
+		}
+	}
+}
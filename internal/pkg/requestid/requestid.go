@@ -0,0 +1,70 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package requestid carries the HTTP request ID used to correlate responses, logs and audit records.
+// requestid 包传递用于关联响应、日志与审计记录的 HTTP 请求 ID。
+package requestid
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"regexp"
+)
+
+// Header is the HTTP header that carries the request ID in both directions.
+// Header 是在请求与响应中携带请求 ID 的 HTTP 头。
+const Header = "X-Request-ID"
+
+// validID bounds client supplied IDs so they are safe to log and store.
+// validID 限制客户端传入的 ID，确保可以安全写入日志与存储。
+var validID = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,64}$`)
+
+type contextKey struct{}
+
+// New generates a random request ID.
+// New 生成随机请求 ID。
+func New() string {
+	buf := make([]byte, 16)
+	_, _ = rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
+
+// Resolve returns incoming when it is a well-formed ID, otherwise a new one.
+// Resolve 在 incoming 格式合法时返回它，否则生成新的 ID。
+func Resolve(incoming string) string {
+	if validID.MatchString(incoming) {
+		return incoming
+	}
+	return New()
+}
+
+// WithContext returns a copy of ctx carrying id.
+// WithContext 返回携带 id 的 ctx 副本。
+func WithContext(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext returns the request ID carried by ctx, or "" when there is none.
+// FromContext 返回 ctx 携带的请求 ID，没有时返回空字符串。
+func FromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	id, _ := ctx.Value(contextKey{}).(string)
+	return id
+}
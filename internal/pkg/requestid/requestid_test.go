@@ -0,0 +1,46 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package requestid
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestResolve(t *testing.T) {
+	if got := Resolve("req-42.a:b_c"); got != "req-42.a:b_c" {
+		t.Fatalf("expected well-formed ID to be kept, got %q", got)
+	}
+	for _, incoming := range []string{"", "has space", "line\nbreak", strings.Repeat("x", 65)} {
+		got := Resolve(incoming)
+		if got == incoming || len(got) != 32 {
+			t.Fatalf("expected %q to be replaced by a generated ID, got %q", incoming, got)
+		}
+	}
+}
+
+func TestContextRoundTrip(t *testing.T) {
+	if got := FromContext(context.Background()); got != "" {
+		t.Fatalf("expected empty ID, got %q", got)
+	}
+	ctx := WithContext(context.Background(), "abc")
+	if got := FromContext(ctx); got != "abc" {
+		t.Fatalf("expected abc, got %q", got)
+	}
+}
@@ -0,0 +1,210 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package secrets
+
+import "strings"
+
+// sensitiveKeySuffixes match normalized keys (lower case, separators removed) holding credentials.
+// sensitiveKeySuffixes 匹配保存凭证的规范化键名（小写并去除分隔符）。
+var sensitiveKeySuffixes = []string{
+	"secretkey",
+	"accesskey",
+	"accesskeyid",
+	"accesskeysecret",
+	"keytabfilepath",
+	"password",
+}
+
+// IsSensitiveKey reports whether a parameter or config key holds a credential. Snake case,
+// dotted Hadoop and camel case keys are all recognized, e.g. storage_secret_key,
+// fs.s3a.access.key and kerberosKeytabFilePath.
+// IsSensitiveKey 判断参数或配置键是否保存凭证。可识别下划线、Hadoop 点分以及驼峰形式，
+// 例如 storage_secret_key、fs.s3a.access.key 与 kerberosKeytabFilePath。
+func IsSensitiveKey(key string) bool {
+	normalized := strings.Map(func(r rune) rune {
+		switch r {
+		case '_', '.', '-':
+			return -1
+		}
+		return r
+	}, strings.ToLower(key))
+	for _, suffix := range sensitiveKeySuffixes {
+		if strings.HasSuffix(normalized, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// Redact returns Mask for a non-empty value.
+// Redact 对非空值返回 Mask。
+func Redact(value string) string {
+	if value == "" {
+		return ""
+	}
+	return Mask
+}
+
+// RedactStringMap returns a copy of params with sensitive values masked.
+// RedactStringMap 返回屏蔽了敏感值的 params 副本。
+func RedactStringMap(params map[string]string) map[string]string {
+	if params == nil {
+		return nil
+	}
+	redacted := make(map[string]string, len(params))
+	for key, value := range params {
+		if IsSensitiveKey(key) {
+			value = Redact(value)
+		}
+		redacted[key] = value
+	}
+	return redacted
+}
+
+// RedactMap returns a deep copy of m with sensitive string values masked.
+// RedactMap 返回屏蔽了敏感字符串值的 m 深拷贝。
+func RedactMap(m map[string]interface{}) map[string]interface{} {
+	redacted, _ := transformMap(m, func(value string) (string, error) {
+		return Redact(value), nil
+	})
+	return redacted
+}
+
+// SealMap returns a deep copy of m with sensitive string values sealed.
+// SealMap 返回加密了敏感字符串值的 m 深拷贝。
+func SealMap(m map[string]interface{}) (map[string]interface{}, error) {
+	return transformMap(m, Seal)
+}
+
+// OpenMap returns a deep copy of m with sealed sensitive values decrypted.
+// OpenMap 返回解密了敏感加密值的 m 深拷贝。
+func OpenMap(m map[string]interface{}) (map[string]interface{}, error) {
+	return transformMap(m, Open)
+}
+
+// SealMap returns a deep copy of m with sensitive string values sealed by b.
+// SealMap 返回使用 b 加密了敏感字符串值的 m 深拷贝。
+func (b *Box) SealMap(m map[string]interface{}) (map[string]interface{}, error) {
+	return transformMap(m, b.Seal)
+}
+
+// OpenMap returns a deep copy of m with sensitive values sealed by b decrypted.
+// OpenMap 返回使用 b 解密了敏感加密值的 m 深拷贝。
+func (b *Box) OpenMap(m map[string]interface{}) (map[string]interface{}, error) {
+	return transformMap(m, b.Open)
+}
+
+// ClearMasked returns a deep copy of m where sensitive values still equal to Mask are emptied,
+// so a redacted copy never stores the mask as if it were the secret.
+// ClearMasked 返回 m 的深拷贝，其中仍等于 Mask 的敏感值被清空，避免把掩码当作密钥保存。
+func ClearMasked(m map[string]interface{}) map[string]interface{} {
+	cleared, _ := transformMap(m, func(value string) (string, error) {
+		return RestoreMaskedString(value, ""), nil
+	})
+	return cleared
+}
+
+func transformMap(m map[string]interface{}, fn func(string) (string, error)) (map[string]interface{}, error) {
+	if m == nil {
+		return nil, nil
+	}
+	out := make(map[string]interface{}, len(m))
+	for key, value := range m {
+		transformed, err := transformValue(IsSensitiveKey(key), value, fn)
+		if err != nil {
+			return nil, err
+		}
+		out[key] = transformed
+	}
+	return out, nil
+}
+
+func transformValue(sensitive bool, value interface{}, fn func(string) (string, error)) (interface{}, error) {
+	switch v := value.(type) {
+	case string:
+		if sensitive {
+			return fn(v)
+		}
+		return v, nil
+	case map[string]interface{}:
+		return transformMap(v, fn)
+	case map[string]string:
+		out := make(map[string]string, len(v))
+		for key, item := range v {
+			if IsSensitiveKey(key) {
+				transformed, err := fn(item)
+				if err != nil {
+					return nil, err
+				}
+				item = transformed
+			}
+			out[key] = item
+		}
+		return out, nil
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, item := range v {
+			transformed, err := transformValue(sensitive, item, fn)
+			if err != nil {
+				return nil, err
+			}
+			out[i] = transformed
+		}
+		return out, nil
+	default:
+		return value, nil
+	}
+}
+
+// RestoreMasked returns a deep copy of incoming where every sensitive value still equal to Mask is
+// replaced by the value at the same path in existing. Clients echo masked values back on update,
+// and this keeps them from overwriting the stored secret with the mask.
+// RestoreMasked 返回 incoming 的深拷贝，其中仍等于 Mask 的敏感值会被替换为 existing 中相同路径的值。
+// 客户端更新时会回传被屏蔽的值，此处避免用掩码覆盖已保存的密钥。
+func RestoreMasked(incoming, existing map[string]interface{}) map[string]interface{} {
+	if incoming == nil {
+		return nil
+	}
+	out := make(map[string]interface{}, len(incoming))
+	for key, value := range incoming {
+		switch v := value.(type) {
+		case string:
+			if v == Mask && IsSensitiveKey(key) {
+				if previous, ok := existing[key].(string); ok {
+					v = previous
+				}
+			}
+			out[key] = v
+		case map[string]interface{}:
+			previous, _ := existing[key].(map[string]interface{})
+			out[key] = RestoreMasked(v, previous)
+		default:
+			out[key] = value
+		}
+	}
+	return out
+}
+
+// RestoreMaskedString returns existing when incoming is still the mask.
+// RestoreMaskedString 在 incoming 仍为掩码时返回 existing。
+func RestoreMaskedString(incoming, existing string) string {
+	if incoming == Mask {
+		return existing
+	}
+	return incoming
+}
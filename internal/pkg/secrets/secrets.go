@@ -0,0 +1,204 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package secrets encrypts credential fields at rest and redacts them from logs, audit entries
+// and API responses.
+// secrets 包负责凭证字段的静态加密，并在日志、审计记录与 API 响应中屏蔽这些字段。
+//
+// Sealed values are stored as "enc:v1:" + base64(nonce || AES-256-GCM ciphertext). Values without
+// the prefix are treated as legacy plaintext, so existing rows keep working and are sealed on
+// their next write.
+// 加密值以 "enc:v1:" + base64(nonce || AES-256-GCM 密文) 存储。没有该前缀的值视为历史明文，
+// 已有数据仍可读取，并在下一次写入时被加密。
+package secrets
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+const (
+	// Mask replaces sensitive values in logs, audit entries and API responses.
+	// Mask 用于在日志、审计记录与 API 响应中替换敏感值。
+	Mask = "******"
+
+	sealedPrefix = "enc:v1:"
+)
+
+// ErrKeyUnavailable indicates a sealed value was read but no master key is configured.
+// ErrKeyUnavailable 表示读取到了加密值但未配置主密钥。
+var ErrKeyUnavailable = errors.New("secrets: master key is not configured")
+
+// KeyProvider supplies the 32-byte data key used for AES-256-GCM. A KMS integration implements
+// it by unwrapping a data key with the KMS instead of deriving one from a local master key.
+// KeyProvider 提供 AES-256-GCM 使用的 32 字节数据密钥。KMS 集成可通过 KMS 解封数据密钥来实现该接口，
+// 而不是从本地主密钥派生。
+type KeyProvider interface {
+	DataKey() ([]byte, error)
+}
+
+// masterKeyProvider derives the data key from a configured master key.
+// masterKeyProvider 从配置的主密钥派生数据密钥。
+type masterKeyProvider string
+
+// NewMasterKeyProvider returns a KeyProvider deriving the data key from masterKey.
+// NewMasterKeyProvider 返回一个从 masterKey 派生数据密钥的 KeyProvider。
+func NewMasterKeyProvider(masterKey string) KeyProvider {
+	return masterKeyProvider(masterKey)
+}
+
+// DataKey implements KeyProvider.
+// DataKey 实现 KeyProvider。
+func (k masterKeyProvider) DataKey() ([]byte, error) {
+	if k == "" {
+		return nil, ErrKeyUnavailable
+	}
+	key := sha256.Sum256([]byte("seatunnelx/secrets/" + string(k)))
+	return key[:], nil
+}
+
+// Box seals and opens secret values with AES-256-GCM.
+// Box 使用 AES-256-GCM 加密与解密敏感值。
+type Box struct {
+	aead cipher.AEAD
+}
+
+// NewBox creates a Box from the data key of provider.
+// NewBox 使用 provider 提供的数据密钥创建 Box。
+func NewBox(provider KeyProvider) (*Box, error) {
+	key, err := provider.DataKey()
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("secrets: invalid data key: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &Box{aead: aead}, nil
+}
+
+// Seal encrypts plaintext. Empty and already sealed values are returned unchanged.
+// Seal 加密明文。空值与已加密的值原样返回。
+func (b *Box) Seal(plaintext string) (string, error) {
+	if plaintext == "" || IsSealed(plaintext) {
+		return plaintext, nil
+	}
+	nonce := make([]byte, b.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed := b.aead.Seal(nonce, nonce, []byte(plaintext), nil)
+	return sealedPrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Open decrypts a sealed value. Values without the sealed prefix are legacy plaintext and are
+// returned unchanged.
+// Open 解密加密值。没有加密前缀的值为历史明文，原样返回。
+func (b *Box) Open(value string) (string, error) {
+	if !IsSealed(value) {
+		return value, nil
+	}
+	sealed, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(value, sealedPrefix))
+	if err != nil {
+		return "", fmt.Errorf("secrets: decode sealed value: %w", err)
+	}
+	nonceSize := b.aead.NonceSize()
+	if len(sealed) < nonceSize {
+		return "", errors.New("secrets: sealed value is too short")
+	}
+	plaintext, err := b.aead.Open(nil, sealed[:nonceSize], sealed[nonceSize:], nil)
+	if err != nil {
+		return "", fmt.Errorf("secrets: decrypt sealed value (was the master key changed?): %w", err)
+	}
+	return string(plaintext), nil
+}
+
+// IsSealed reports whether value was produced by Seal.
+// IsSealed 判断 value 是否为 Seal 生成的加密值。
+func IsSealed(value string) bool {
+	return strings.HasPrefix(value, sealedPrefix)
+}
+
+var (
+	defaultMu  sync.RWMutex
+	defaultBox *Box
+)
+
+// Init configures the process-wide Box used by Seal and Open.
+// Init 配置 Seal 与 Open 使用的进程级 Box。
+func Init(provider KeyProvider) error {
+	box, err := NewBox(provider)
+	if err != nil {
+		return err
+	}
+	defaultMu.Lock()
+	defaultBox = box
+	defaultMu.Unlock()
+	return nil
+}
+
+func currentBox() *Box {
+	defaultMu.RLock()
+	defer defaultMu.RUnlock()
+	return defaultBox
+}
+
+// Default returns the process-wide Box, or ErrKeyUnavailable before Init succeeds. Callers that
+// must never store plaintext use it instead of the package-level Seal.
+// Default 返回进程级 Box，Init 成功前返回 ErrKeyUnavailable。不允许明文存储的调用方应使用它，
+// 而不是包级 Seal。
+func Default() (*Box, error) {
+	box := currentBox()
+	if box == nil {
+		return nil, ErrKeyUnavailable
+	}
+	return box, nil
+}
+
+// Seal encrypts plaintext with the process-wide Box. Without Init, values are stored as plaintext.
+// Seal 使用进程级 Box 加密明文。未调用 Init 时按明文存储。
+func Seal(plaintext string) (string, error) {
+	box := currentBox()
+	if box == nil {
+		return plaintext, nil
+	}
+	return box.Seal(plaintext)
+}
+
+// Open decrypts value with the process-wide Box.
+// Open 使用进程级 Box 解密 value。
+func Open(value string) (string, error) {
+	if !IsSealed(value) {
+		return value, nil
+	}
+	box := currentBox()
+	if box == nil {
+		return "", ErrKeyUnavailable
+	}
+	return box.Open(value)
+}
@@ -0,0 +1,129 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package secrets
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestBoxSealOpen(t *testing.T) {
+	box, err := NewBox(NewMasterKeyProvider("master"))
+	if err != nil {
+		t.Fatalf("NewBox returned error: %v", err)
+	}
+	sealed, err := box.Seal("AKIAEXAMPLE")
+	if err != nil {
+		t.Fatalf("Seal returned error: %v", err)
+	}
+	if !IsSealed(sealed) || sealed == "AKIAEXAMPLE" {
+		t.Fatalf("expected sealed value, got %q", sealed)
+	}
+	if again, _ := box.Seal(sealed); again != sealed {
+		t.Fatalf("expected sealing to be idempotent")
+	}
+	opened, err := box.Open(sealed)
+	if err != nil || opened != "AKIAEXAMPLE" {
+		t.Fatalf("expected round trip, got %q, %v", opened, err)
+	}
+	if legacy, err := box.Open("plaintext"); err != nil || legacy != "plaintext" {
+		t.Fatalf("expected legacy plaintext to pass through, got %q, %v", legacy, err)
+	}
+
+	other, _ := NewBox(NewMasterKeyProvider("other"))
+	if _, err := other.Open(sealed); err == nil {
+		t.Fatalf("expected a different master key to fail")
+	}
+	if _, err := NewBox(NewMasterKeyProvider("")); !errors.Is(err, ErrKeyUnavailable) {
+		t.Fatalf("expected ErrKeyUnavailable for an empty master key, got %v", err)
+	}
+}
+
+func TestIsSensitiveKey(t *testing.T) {
+	for _, key := range []string{"storage_secret_key", "checkpoint_storage_access_key", "kerberos_keytab_file_path", "fs.s3a.access.key", "fs.oss.accessKeySecret", "kerberosKeytabFilePath"} {
+		if !IsSensitiveKey(key) {
+			t.Errorf("expected %s to be sensitive", key)
+		}
+	}
+	for _, key := range []string{"storage_bucket", "namespace", "kerberos_principal", "storage_endpoint"} {
+		if IsSensitiveKey(key) {
+			t.Errorf("expected %s not to be sensitive", key)
+		}
+	}
+}
+
+func TestSealRedactAndRestoreMaps(t *testing.T) {
+	if err := Init(NewMasterKeyProvider("master")); err != nil {
+		t.Fatalf("Init returned error: %v", err)
+	}
+	config := map[string]interface{}{
+		"checkpoint": map[string]interface{}{
+			"storage_type":       "S3",
+			"storage_secret_key": "secret",
+		},
+		"http_port": float64(8080),
+	}
+
+	sealed, err := SealMap(config)
+	if err != nil {
+		t.Fatalf("SealMap returned error: %v", err)
+	}
+	checkpoint := sealed["checkpoint"].(map[string]interface{})
+	if !IsSealed(checkpoint["storage_secret_key"].(string)) || checkpoint["storage_type"] != "S3" {
+		t.Fatalf("expected only the secret to be sealed, got %v", checkpoint)
+	}
+	if config["checkpoint"].(map[string]interface{})["storage_secret_key"] != "secret" {
+		t.Fatalf("expected SealMap not to modify its input")
+	}
+	opened, err := OpenMap(sealed)
+	if err != nil || opened["checkpoint"].(map[string]interface{})["storage_secret_key"] != "secret" {
+		t.Fatalf("expected OpenMap to restore the secret, got %v, %v", opened, err)
+	}
+
+	redacted := RedactMap(config)
+	if redacted["checkpoint"].(map[string]interface{})["storage_secret_key"] != Mask {
+		t.Fatalf("expected the secret to be masked, got %v", redacted)
+	}
+	restored := RestoreMasked(redacted, config)
+	if restored["checkpoint"].(map[string]interface{})["storage_secret_key"] != "secret" {
+		t.Fatalf("expected the masked secret to be restored, got %v", restored)
+	}
+	cleared := ClearMasked(redacted)
+	if cleared["checkpoint"].(map[string]interface{})["storage_secret_key"] != "" || cleared["http_port"] != float64(8080) {
+		t.Fatalf("expected only the masked secret to be cleared, got %v", cleared)
+	}
+}
+
+func TestBoxSealMapUsesItsOwnKey(t *testing.T) {
+	box, err := NewBox(NewMasterKeyProvider("export passphrase"))
+	if err != nil {
+		t.Fatalf("NewBox returned error: %v", err)
+	}
+	sealed, err := box.SealMap(map[string]interface{}{"password": "secret"})
+	if err != nil {
+		t.Fatalf("SealMap returned error: %v", err)
+	}
+	other, _ := NewBox(NewMasterKeyProvider("another passphrase"))
+	if _, err := other.OpenMap(sealed); err == nil {
+		t.Fatalf("expected a different key to fail opening the map")
+	}
+	opened, err := box.OpenMap(sealed)
+	if err != nil || opened["password"] != "secret" {
+		t.Fatalf("expected OpenMap to restore the secret, got %v, %v", opened, err)
+	}
+}
@@ -0,0 +1,72 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package tracex carries OpenTelemetry trace context across the Control Plane / Agent command boundary.
+// tracex 包在 Control Plane 与 Agent 的命令边界之间传递 OpenTelemetry 追踪上下文。
+package tracex
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// propagator encodes trace context as W3C traceparent/tracestate plus baggage, independent of the global one.
+// propagator 将追踪上下文编码为 W3C traceparent/tracestate 与 baggage，不依赖全局 propagator。
+var propagator = propagation.NewCompositeTextMapPropagator(
+	propagation.TraceContext{},
+	propagation.Baggage{},
+)
+
+// Inject returns the trace context of ctx as command metadata, or nil when ctx carries no sampled span.
+// Inject 将 ctx 的追踪上下文转换为命令元数据，ctx 不含有效 span 时返回 nil。
+func Inject(ctx context.Context) map[string]string {
+	if ctx == nil || !trace.SpanContextFromContext(ctx).IsValid() {
+		return nil
+	}
+	carrier := propagation.MapCarrier{}
+	propagator.Inject(ctx, carrier)
+	if len(carrier) == 0 {
+		return nil
+	}
+	return carrier
+}
+
+// Extract returns ctx with the remote parent span described by metadata, or ctx unchanged when there is none.
+// Extract 返回携带 metadata 所描述远端父 span 的 ctx，没有时原样返回 ctx。
+func Extract(ctx context.Context, metadata map[string]string) context.Context {
+	if len(metadata) == 0 {
+		return ctx
+	}
+	return propagator.Extract(ctx, propagation.MapCarrier(metadata))
+}
+
+// ContinueFrom returns ctx carrying the span context of parent, so work that outlives a request
+// (background goroutines, tasks) stays in the same trace without inheriting its cancellation.
+// ContinueFrom 返回携带 parent span 上下文的 ctx，使超出请求生命周期的工作（后台 goroutine、任务）
+// 保持在同一条追踪链路中，但不继承请求的取消。
+func ContinueFrom(ctx, parent context.Context) context.Context {
+	if parent == nil {
+		return ctx
+	}
+	sc := trace.SpanContextFromContext(parent)
+	if !sc.IsValid() {
+		return ctx
+	}
+	return trace.ContextWithSpanContext(ctx, sc)
+}
@@ -0,0 +1,84 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tracex
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+func testSpanContext(t *testing.T) trace.SpanContext {
+	t.Helper()
+	traceID, err := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	if err != nil {
+		t.Fatal(err)
+	}
+	spanID, err := trace.SpanIDFromHex("00f067aa0ba902b7")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+	})
+}
+
+func TestInjectExtractRoundTrip(t *testing.T) {
+	sc := testSpanContext(t)
+	ctx := trace.ContextWithSpanContext(context.Background(), sc)
+
+	md := Inject(ctx)
+	if got := md["traceparent"]; got != "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01" {
+		t.Fatalf("unexpected traceparent %q", got)
+	}
+
+	remote := trace.SpanContextFromContext(Extract(context.Background(), md))
+	if !remote.IsRemote() || remote.TraceID() != sc.TraceID() || remote.SpanID() != sc.SpanID() {
+		t.Fatalf("extracted span context mismatch: %+v", remote)
+	}
+}
+
+func TestInjectWithoutSpanReturnsNil(t *testing.T) {
+	if md := Inject(context.Background()); md != nil {
+		t.Fatalf("expected nil metadata, got %v", md)
+	}
+	ctx := context.Background()
+	if Extract(ctx, nil) != ctx {
+		t.Fatal("Extract without metadata should return ctx unchanged")
+	}
+}
+
+func TestContinueFromKeepsTraceButNotCancellation(t *testing.T) {
+	sc := testSpanContext(t)
+	parent, cancel := context.WithCancel(trace.ContextWithSpanContext(context.Background(), sc))
+	cancel()
+
+	ctx := ContinueFrom(context.Background(), parent)
+	if ctx.Err() != nil {
+		t.Fatalf("detached context should not be cancelled: %v", ctx.Err())
+	}
+	if got := trace.SpanContextFromContext(ctx); got.TraceID() != sc.TraceID() {
+		t.Fatalf("trace ID not carried over: %s", got.TraceID())
+	}
+	if ContinueFrom(context.Background(), context.Background()) != context.Background() {
+		t.Fatal("ContinueFrom without a span should return ctx unchanged")
+	}
+}
@@ -0,0 +1,82 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package worker tracks the background loops of the Control Plane so that readiness probes can
+// tell whether they are still running.
+// Package worker 跟踪 Control Plane 的后台循环，便于就绪探针判断它们是否仍在运行。
+package worker
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// State is the last known state of one background worker.
+// State 是单个后台任务最近的状态。
+type State struct {
+	Name      string     `json:"name"`
+	Running   bool       `json:"running"`
+	StartedAt time.Time  `json:"started_at"`
+	StoppedAt *time.Time `json:"stopped_at,omitempty"`
+}
+
+var (
+	mu      sync.Mutex
+	workers = make(map[string]*State)
+)
+
+// Start records that the named worker started and returns the function recording its exit;
+// call it as `defer worker.Start("name")()` at the top of the worker goroutine.
+// Start 记录指定后台任务已启动，并返回记录其退出的函数；
+// 在后台 goroutine 开头以 `defer worker.Start("name")()` 的方式调用。
+func Start(name string) func() {
+	mu.Lock()
+	workers[name] = &State{Name: name, Running: true, StartedAt: time.Now()}
+	mu.Unlock()
+
+	return func() {
+		mu.Lock()
+		defer mu.Unlock()
+		if state, ok := workers[name]; ok {
+			now := time.Now()
+			state.Running = false
+			state.StoppedAt = &now
+		}
+	}
+}
+
+// Snapshot returns the state of every worker that has started, sorted by name.
+// Snapshot 返回所有已启动后台任务的状态，按名称排序。
+func Snapshot() []State {
+	mu.Lock()
+	defer mu.Unlock()
+	states := make([]State, 0, len(workers))
+	for _, state := range workers {
+		states = append(states, *state)
+	}
+	sort.Slice(states, func(i, j int) bool { return states[i].Name < states[j].Name })
+	return states
+}
+
+// reset forgets every worker; used by tests.
+// reset 清空所有后台任务记录，供测试使用。
+func reset() {
+	mu.Lock()
+	defer mu.Unlock()
+	workers = make(map[string]*State)
+}
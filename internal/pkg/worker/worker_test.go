@@ -0,0 +1,46 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import "testing"
+
+func TestStartRecordsRunningAndStoppedWorkers(t *testing.T) {
+	t.Cleanup(reset)
+
+	stopPurger := Start("purger")
+	defer Start("scheduler")()
+
+	stopPurger()
+	states := Snapshot()
+	if len(states) != 2 {
+		t.Fatalf("expected 2 workers, got %+v", states)
+	}
+	if states[0].Name != "purger" || states[0].Running || states[0].StoppedAt == nil {
+		t.Fatalf("expected stopped purger, got %+v", states[0])
+	}
+	if states[1].Name != "scheduler" || !states[1].Running {
+		t.Fatalf("expected running scheduler, got %+v", states[1])
+	}
+
+	// A restarted worker is running again.
+	// 重新启动的后台任务恢复为运行状态。
+	defer Start("purger")()
+	if states := Snapshot(); !states[0].Running || states[0].StoppedAt != nil {
+		t.Fatalf("expected restarted purger to run, got %+v", states[0])
+	}
+}
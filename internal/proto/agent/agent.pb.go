@@ -44,6 +44,7 @@ const (
 	CommandType_COLLECT_LOGS CommandType = 30
 	CommandType_JVM_DUMP     CommandType = 31
 	CommandType_THREAD_DUMP  CommandType = 32
+	CommandType_DIAGNOSE     CommandType = 33 // 采集 JVM 线程栈、堆信息与类直方图（jcmd/jstack/jattach）
 	// 配置类
 	CommandType_UPDATE_CONFIG   CommandType = 40
 	CommandType_ROLLBACK_CONFIG CommandType = 41
@@ -61,6 +62,10 @@ const (
 	CommandType_MARK_MANUAL_STOP      CommandType = 72 // 标记手动停止
 	CommandType_CLEAR_MANUAL_STOP     CommandType = 73 // 清除手动停止标记
 	CommandType_REMOVE_INSTALL_DIR    CommandType = 74 // 强制删除：删除主机上的安装目录 (Control Plane -> Agent)
+	// 运维脚本
+	CommandType_EXEC_SCRIPT CommandType = 80 // 执行 Agent 内置的白名单运维脚本，参数按脚本 schema 校验
+	// 指令控制
+	CommandType_CANCEL CommandType = 90 // 取消执行中的指令，参数 target_command_id 为目标指令 ID
 )
 
 // Enum value maps for CommandType.
@@ -78,6 +83,7 @@ var (
 		30: "COLLECT_LOGS",
 		31: "JVM_DUMP",
 		32: "THREAD_DUMP",
+		33: "DIAGNOSE",
 		40: "UPDATE_CONFIG",
 		41: "ROLLBACK_CONFIG",
 		42: "PULL_CONFIG",
@@ -91,6 +97,8 @@ var (
 		72: "MARK_MANUAL_STOP",
 		73: "CLEAR_MANUAL_STOP",
 		74: "REMOVE_INSTALL_DIR",
+		80: "EXEC_SCRIPT",
+		90: "CANCEL",
 	}
 	CommandType_value = map[string]int32{
 		"COMMAND_TYPE_UNSPECIFIED": 0,
@@ -105,6 +113,7 @@ var (
 		"COLLECT_LOGS":             30,
 		"JVM_DUMP":                 31,
 		"THREAD_DUMP":              32,
+		"DIAGNOSE":                 33,
 		"UPDATE_CONFIG":            40,
 		"ROLLBACK_CONFIG":          41,
 		"PULL_CONFIG":              42,
@@ -118,6 +127,8 @@ var (
 		"MARK_MANUAL_STOP":         72,
 		"CLEAR_MANUAL_STOP":        73,
 		"REMOVE_INSTALL_DIR":       74,
+		"EXEC_SCRIPT":              80,
+		"CANCEL":                   90,
 	}
 )
 
@@ -158,6 +169,7 @@ const (
 	CommandStatus_SUCCESS                    CommandStatus = 3 // 执行成功
 	CommandStatus_FAILED                     CommandStatus = 4 // 执行失败
 	CommandStatus_CANCELLED                  CommandStatus = 5 // 已取消
+	CommandStatus_TIMEOUT                    CommandStatus = 6 // 执行超时（超过截止时间或长时间无进度心跳）
 )
 
 // Enum value maps for CommandStatus.
@@ -169,6 +181,7 @@ var (
 		3: "SUCCESS",
 		4: "FAILED",
 		5: "CANCELLED",
+		6: "TIMEOUT",
 	}
 	CommandStatus_value = map[string]int32{
 		"COMMAND_STATUS_UNSPECIFIED": 0,
@@ -177,6 +190,7 @@ var (
 		"SUCCESS":                    3,
 		"FAILED":                     4,
 		"CANCELLED":                  5,
+		"TIMEOUT":                    6,
 	}
 )
 
@@ -492,16 +506,19 @@ func (x *DiagnosticsCursorResponse) GetCursors() []*DiagnosticsCursor {
 
 // RegisterRequest - Agent 注册请求
 type RegisterRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`                // Agent 唯一标识
-	Hostname      string                 `protobuf:"bytes,2,opt,name=hostname,proto3" json:"hostname,omitempty"`                             // 主机名
-	IpAddress     string                 `protobuf:"bytes,3,opt,name=ip_address,json=ipAddress,proto3" json:"ip_address,omitempty"`          // IP 地址
-	OsType        string                 `protobuf:"bytes,4,opt,name=os_type,json=osType,proto3" json:"os_type,omitempty"`                   // 操作系统类型: linux, darwin, windows
-	Arch          string                 `protobuf:"bytes,5,opt,name=arch,proto3" json:"arch,omitempty"`                                     // CPU 架构: amd64, arm64
-	AgentVersion  string                 `protobuf:"bytes,6,opt,name=agent_version,json=agentVersion,proto3" json:"agent_version,omitempty"` // Agent 版本号
-	SystemInfo    *SystemInfo            `protobuf:"bytes,7,opt,name=system_info,json=systemInfo,proto3" json:"system_info,omitempty"`       // 系统信息
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	AgentId         string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`                         // Agent 唯一标识
+	Hostname        string                 `protobuf:"bytes,2,opt,name=hostname,proto3" json:"hostname,omitempty"`                                      // 主机名
+	IpAddress       string                 `protobuf:"bytes,3,opt,name=ip_address,json=ipAddress,proto3" json:"ip_address,omitempty"`                   // IP 地址
+	OsType          string                 `protobuf:"bytes,4,opt,name=os_type,json=osType,proto3" json:"os_type,omitempty"`                            // 操作系统类型: linux, darwin, windows
+	Arch            string                 `protobuf:"bytes,5,opt,name=arch,proto3" json:"arch,omitempty"`                                              // CPU 架构: amd64, arm64
+	AgentVersion    string                 `protobuf:"bytes,6,opt,name=agent_version,json=agentVersion,proto3" json:"agent_version,omitempty"`          // Agent 版本号
+	SystemInfo      *SystemInfo            `protobuf:"bytes,7,opt,name=system_info,json=systemInfo,proto3" json:"system_info,omitempty"`                // 系统信息
+	DryRun          bool                   `protobuf:"varint,8,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`                           // 仅校验准入，不登记连接（用于 doctor 自检）
+	EnrollmentToken string                 `protobuf:"bytes,9,opt,name=enrollment_token,json=enrollmentToken,proto3" json:"enrollment_token,omitempty"` // 安装命令签发的一次性注册令牌
+	AgentSecret     string                 `protobuf:"bytes,10,opt,name=agent_secret,json=agentSecret,proto3" json:"agent_secret,omitempty"`            // 注册令牌消费后签发的 Agent 密钥，后续注册必须携带
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *RegisterRequest) Reset() {
@@ -583,6 +600,27 @@ func (x *RegisterRequest) GetSystemInfo() *SystemInfo {
 	return nil
 }
 
+func (x *RegisterRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *RegisterRequest) GetEnrollmentToken() string {
+	if x != nil {
+		return x.EnrollmentToken
+	}
+	return ""
+}
+
+func (x *RegisterRequest) GetAgentSecret() string {
+	if x != nil {
+		return x.AgentSecret
+	}
+	return ""
+}
+
 // SystemInfo - 系统硬件信息
 type SystemInfo struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -655,10 +693,11 @@ func (x *SystemInfo) GetKernelVersion() string {
 // RegisterResponse - Agent 注册响应
 type RegisterResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`                        // 注册是否成功
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`                         // 响应消息
-	AssignedId    string                 `protobuf:"bytes,3,opt,name=assigned_id,json=assignedId,proto3" json:"assigned_id,omitempty"` // Control Plane 分配的 ID
-	Config        *AgentConfig           `protobuf:"bytes,4,opt,name=config,proto3" json:"config,omitempty"`                           // 下发的配置
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`                           // 注册是否成功
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`                            // 响应消息
+	AssignedId    string                 `protobuf:"bytes,3,opt,name=assigned_id,json=assignedId,proto3" json:"assigned_id,omitempty"`    // Control Plane 分配的 ID
+	Config        *AgentConfig           `protobuf:"bytes,4,opt,name=config,proto3" json:"config,omitempty"`                              // 下发的配置
+	AgentSecret   string                 `protobuf:"bytes,5,opt,name=agent_secret,json=agentSecret,proto3" json:"agent_secret,omitempty"` // 本次注册新签发的 Agent 密钥（仅返回一次）
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -721,6 +760,13 @@ func (x *RegisterResponse) GetConfig() *AgentConfig {
 	return nil
 }
 
+func (x *RegisterResponse) GetAgentSecret() string {
+	if x != nil {
+		return x.AgentSecret
+	}
+	return ""
+}
+
 // AgentConfig - Agent 配置信息
 type AgentConfig struct {
 	state             protoimpl.MessageState `protogen:"open.v1"`
@@ -784,13 +830,17 @@ func (x *AgentConfig) GetExtra() map[string]string {
 
 // HeartbeatRequest - 心跳请求
 type HeartbeatRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`                   // Agent 唯一标识
-	Timestamp     int64                  `protobuf:"varint,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`                             // 时间戳 (Unix 毫秒)
-	ResourceUsage *ResourceUsage         `protobuf:"bytes,3,opt,name=resource_usage,json=resourceUsage,proto3" json:"resource_usage,omitempty"` // 资源使用情况
-	Processes     []*ProcessStatus       `protobuf:"bytes,4,rep,name=processes,proto3" json:"processes,omitempty"`                              // 进程状态列表
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	AgentId              string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`                                           // Agent 唯一标识
+	Timestamp            int64                  `protobuf:"varint,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`                                                     // 时间戳 (Unix 毫秒)
+	ResourceUsage        *ResourceUsage         `protobuf:"bytes,3,opt,name=resource_usage,json=resourceUsage,proto3" json:"resource_usage,omitempty"`                         // 资源使用情况
+	Processes            []*ProcessStatus       `protobuf:"bytes,4,rep,name=processes,proto3" json:"processes,omitempty"`                                                      // 进程状态列表
+	PluginCache          *PluginCacheStats      `protobuf:"bytes,5,opt,name=plugin_cache,json=pluginCache,proto3" json:"plugin_cache,omitempty"`                               // 插件缓存统计
+	SeatunnelInventory   *SeaTunnelInventory    `protobuf:"bytes,6,opt,name=seatunnel_inventory,json=seatunnelInventory,proto3" json:"seatunnel_inventory,omitempty"`          // SeaTunnel 安装与进程清单（旧版 Agent 不上报）
+	ControlPlaneEndpoint string                 `protobuf:"bytes,7,opt,name=control_plane_endpoint,json=controlPlaneEndpoint,proto3" json:"control_plane_endpoint,omitempty"`  // 当前使用的 Control Plane 地址
+	ControlPlaneFailover bool                   `protobuf:"varint,8,opt,name=control_plane_failover,json=controlPlaneFailover,proto3" json:"control_plane_failover,omitempty"` // 是否正在使用故障转移地址
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
 }
 
 func (x *HeartbeatRequest) Reset() {
@@ -851,6 +901,34 @@ func (x *HeartbeatRequest) GetProcesses() []*ProcessStatus {
 	return nil
 }
 
+func (x *HeartbeatRequest) GetPluginCache() *PluginCacheStats {
+	if x != nil {
+		return x.PluginCache
+	}
+	return nil
+}
+
+func (x *HeartbeatRequest) GetSeatunnelInventory() *SeaTunnelInventory {
+	if x != nil {
+		return x.SeatunnelInventory
+	}
+	return nil
+}
+
+func (x *HeartbeatRequest) GetControlPlaneEndpoint() string {
+	if x != nil {
+		return x.ControlPlaneEndpoint
+	}
+	return ""
+}
+
+func (x *HeartbeatRequest) GetControlPlaneFailover() bool {
+	if x != nil {
+		return x.ControlPlaneFailover
+	}
+	return false
+}
+
 // ResourceUsage - 资源使用情况
 type ResourceUsage struct {
 	state           protoimpl.MessageState `protogen:"open.v1"`
@@ -928,6 +1006,229 @@ func (x *ResourceUsage) GetAvailableDisk() int64 {
 	return 0
 }
 
+// PluginCacheStats - 插件缓存统计
+type PluginCacheStats struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entries       int64                  `protobuf:"varint,1,opt,name=entries,proto3" json:"entries,omitempty"`                      // 缓存文件数
+	SizeBytes     int64                  `protobuf:"varint,2,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"` // 缓存总大小 (bytes)
+	MaxBytes      int64                  `protobuf:"varint,3,opt,name=max_bytes,json=maxBytes,proto3" json:"max_bytes,omitempty"`    // 缓存大小上限 (bytes, 0 表示不限制)
+	Hits          int64                  `protobuf:"varint,4,opt,name=hits,proto3" json:"hits,omitempty"`                            // 命中次数
+	Misses        int64                  `protobuf:"varint,5,opt,name=misses,proto3" json:"misses,omitempty"`                        // 未命中次数
+	Evictions     int64                  `protobuf:"varint,6,opt,name=evictions,proto3" json:"evictions,omitempty"`                  // 淘汰文件数
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PluginCacheStats) Reset() {
+	*x = PluginCacheStats{}
+	mi := &file_internal_proto_agent_agent_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PluginCacheStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PluginCacheStats) ProtoMessage() {}
+
+func (x *PluginCacheStats) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_agent_agent_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PluginCacheStats.ProtoReflect.Descriptor instead.
+func (*PluginCacheStats) Descriptor() ([]byte, []int) {
+	return file_internal_proto_agent_agent_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *PluginCacheStats) GetEntries() int64 {
+	if x != nil {
+		return x.Entries
+	}
+	return 0
+}
+
+func (x *PluginCacheStats) GetSizeBytes() int64 {
+	if x != nil {
+		return x.SizeBytes
+	}
+	return 0
+}
+
+func (x *PluginCacheStats) GetMaxBytes() int64 {
+	if x != nil {
+		return x.MaxBytes
+	}
+	return 0
+}
+
+func (x *PluginCacheStats) GetHits() int64 {
+	if x != nil {
+		return x.Hits
+	}
+	return 0
+}
+
+func (x *PluginCacheStats) GetMisses() int64 {
+	if x != nil {
+		return x.Misses
+	}
+	return 0
+}
+
+func (x *PluginCacheStats) GetEvictions() int64 {
+	if x != nil {
+		return x.Evictions
+	}
+	return 0
+}
+
+// SeaTunnelInventory - 节点上检测到的 SeaTunnel 安装与运行进程
+type SeaTunnelInventory struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ScannedAt     int64                  `protobuf:"varint,1,opt,name=scanned_at,json=scannedAt,proto3" json:"scanned_at,omitempty"` // 扫描时间 (Unix 毫秒)
+	Installs      []*SeaTunnelInstall    `protobuf:"bytes,2,rep,name=installs,proto3" json:"installs,omitempty"`                     // 检测到的安装，每个运行中的进程一项
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SeaTunnelInventory) Reset() {
+	*x = SeaTunnelInventory{}
+	mi := &file_internal_proto_agent_agent_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SeaTunnelInventory) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SeaTunnelInventory) ProtoMessage() {}
+
+func (x *SeaTunnelInventory) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_agent_agent_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SeaTunnelInventory.ProtoReflect.Descriptor instead.
+func (*SeaTunnelInventory) Descriptor() ([]byte, []int) {
+	return file_internal_proto_agent_agent_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SeaTunnelInventory) GetScannedAt() int64 {
+	if x != nil {
+		return x.ScannedAt
+	}
+	return 0
+}
+
+func (x *SeaTunnelInventory) GetInstalls() []*SeaTunnelInstall {
+	if x != nil {
+		return x.Installs
+	}
+	return nil
+}
+
+// SeaTunnelInstall - 运行中的 SeaTunnel 进程及其安装
+type SeaTunnelInstall struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	InstallDir    string                 `protobuf:"bytes,1,opt,name=install_dir,json=installDir,proto3" json:"install_dir,omitempty"`           // 安装目录
+	Version       string                 `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`                                   // SeaTunnel 版本
+	Role          string                 `protobuf:"bytes,3,opt,name=role,proto3" json:"role,omitempty"`                                         // 角色: master, worker, hybrid
+	Pid           int32                  `protobuf:"varint,4,opt,name=pid,proto3" json:"pid,omitempty"`                                          // 进程 ID
+	HazelcastPort int32                  `protobuf:"varint,5,opt,name=hazelcast_port,json=hazelcastPort,proto3" json:"hazelcast_port,omitempty"` // Hazelcast 端口
+	HttpPort      int32                  `protobuf:"varint,6,opt,name=http_port,json=httpPort,proto3" json:"http_port,omitempty"`                // HTTP API 端口
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SeaTunnelInstall) Reset() {
+	*x = SeaTunnelInstall{}
+	mi := &file_internal_proto_agent_agent_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SeaTunnelInstall) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SeaTunnelInstall) ProtoMessage() {}
+
+func (x *SeaTunnelInstall) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_agent_agent_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SeaTunnelInstall.ProtoReflect.Descriptor instead.
+func (*SeaTunnelInstall) Descriptor() ([]byte, []int) {
+	return file_internal_proto_agent_agent_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *SeaTunnelInstall) GetInstallDir() string {
+	if x != nil {
+		return x.InstallDir
+	}
+	return ""
+}
+
+func (x *SeaTunnelInstall) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *SeaTunnelInstall) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *SeaTunnelInstall) GetPid() int32 {
+	if x != nil {
+		return x.Pid
+	}
+	return 0
+}
+
+func (x *SeaTunnelInstall) GetHazelcastPort() int32 {
+	if x != nil {
+		return x.HazelcastPort
+	}
+	return 0
+}
+
+func (x *SeaTunnelInstall) GetHttpPort() int32 {
+	if x != nil {
+		return x.HttpPort
+	}
+	return 0
+}
+
 // ProcessStatus - 进程状态信息
 type ProcessStatus struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -943,7 +1244,7 @@ type ProcessStatus struct {
 
 func (x *ProcessStatus) Reset() {
 	*x = ProcessStatus{}
-	mi := &file_internal_proto_agent_agent_proto_msgTypes[9]
+	mi := &file_internal_proto_agent_agent_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -955,7 +1256,7 @@ func (x *ProcessStatus) String() string {
 func (*ProcessStatus) ProtoMessage() {}
 
 func (x *ProcessStatus) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_proto_agent_agent_proto_msgTypes[9]
+	mi := &file_internal_proto_agent_agent_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -968,7 +1269,7 @@ func (x *ProcessStatus) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ProcessStatus.ProtoReflect.Descriptor instead.
 func (*ProcessStatus) Descriptor() ([]byte, []int) {
-	return file_internal_proto_agent_agent_proto_rawDescGZIP(), []int{9}
+	return file_internal_proto_agent_agent_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *ProcessStatus) GetName() string {
@@ -1024,7 +1325,7 @@ type HeartbeatResponse struct {
 
 func (x *HeartbeatResponse) Reset() {
 	*x = HeartbeatResponse{}
-	mi := &file_internal_proto_agent_agent_proto_msgTypes[10]
+	mi := &file_internal_proto_agent_agent_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1036,7 +1337,7 @@ func (x *HeartbeatResponse) String() string {
 func (*HeartbeatResponse) ProtoMessage() {}
 
 func (x *HeartbeatResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_proto_agent_agent_proto_msgTypes[10]
+	mi := &file_internal_proto_agent_agent_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1049,7 +1350,7 @@ func (x *HeartbeatResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HeartbeatResponse.ProtoReflect.Descriptor instead.
 func (*HeartbeatResponse) Descriptor() ([]byte, []int) {
-	return file_internal_proto_agent_agent_proto_rawDescGZIP(), []int{10}
+	return file_internal_proto_agent_agent_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *HeartbeatResponse) GetSuccess() bool {
@@ -1073,13 +1374,14 @@ type CommandRequest struct {
 	Type          CommandType            `protobuf:"varint,2,opt,name=type,proto3,enum=seatunnel.agent.v1.CommandType" json:"type,omitempty"`                                                  // 指令类型
 	Parameters    map[string]string      `protobuf:"bytes,3,rep,name=parameters,proto3" json:"parameters,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // 指令参数
 	Timeout       int32                  `protobuf:"varint,4,opt,name=timeout,proto3" json:"timeout,omitempty"`                                                                                // 超时时间 (秒)
+	Metadata      map[string]string      `protobuf:"bytes,5,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`     // 元数据 (如 W3C traceparent/tracestate 追踪上下文)
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *CommandRequest) Reset() {
 	*x = CommandRequest{}
-	mi := &file_internal_proto_agent_agent_proto_msgTypes[11]
+	mi := &file_internal_proto_agent_agent_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1091,7 +1393,7 @@ func (x *CommandRequest) String() string {
 func (*CommandRequest) ProtoMessage() {}
 
 func (x *CommandRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_proto_agent_agent_proto_msgTypes[11]
+	mi := &file_internal_proto_agent_agent_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1104,7 +1406,7 @@ func (x *CommandRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CommandRequest.ProtoReflect.Descriptor instead.
 func (*CommandRequest) Descriptor() ([]byte, []int) {
-	return file_internal_proto_agent_agent_proto_rawDescGZIP(), []int{11}
+	return file_internal_proto_agent_agent_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *CommandRequest) GetCommandId() string {
@@ -1135,22 +1437,32 @@ func (x *CommandRequest) GetTimeout() int32 {
 	return 0
 }
 
+func (x *CommandRequest) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
 // CommandResponse - 指令执行结果 (Agent -> Control Plane)
 type CommandResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	CommandId     string                 `protobuf:"bytes,1,opt,name=command_id,json=commandId,proto3" json:"command_id,omitempty"`                 // 指令唯一标识
-	Status        CommandStatus          `protobuf:"varint,2,opt,name=status,proto3,enum=seatunnel.agent.v1.CommandStatus" json:"status,omitempty"` // 执行状态
-	Progress      int32                  `protobuf:"varint,3,opt,name=progress,proto3" json:"progress,omitempty"`                                   // 执行进度 (0-100)
-	Output        string                 `protobuf:"bytes,4,opt,name=output,proto3" json:"output,omitempty"`                                        // 标准输出
-	Error         string                 `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`                                          // 错误信息
-	Timestamp     int64                  `protobuf:"varint,6,opt,name=timestamp,proto3" json:"timestamp,omitempty"`                                 // 时间戳 (Unix 毫秒)
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	CommandId      string                 `protobuf:"bytes,1,opt,name=command_id,json=commandId,proto3" json:"command_id,omitempty"`                 // 指令唯一标识
+	Status         CommandStatus          `protobuf:"varint,2,opt,name=status,proto3,enum=seatunnel.agent.v1.CommandStatus" json:"status,omitempty"` // 执行状态
+	Progress       int32                  `protobuf:"varint,3,opt,name=progress,proto3" json:"progress,omitempty"`                                   // 执行进度 (0-100)
+	Output         string                 `protobuf:"bytes,4,opt,name=output,proto3" json:"output,omitempty"`                                        // 标准输出
+	Error          string                 `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`                                          // 错误信息
+	Timestamp      int64                  `protobuf:"varint,6,opt,name=timestamp,proto3" json:"timestamp,omitempty"`                                 // 时间戳 (Unix 毫秒)
+	ErrorCode      string                 `protobuf:"bytes,7,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`                 // 错误码 (如 ST-INST-012)，见 internal/pkg/errcode
+	OutputChunk    string                 `protobuf:"bytes,8,opt,name=output_chunk,json=outputChunk,proto3" json:"output_chunk,omitempty"`           // 增量输出片段 (stdout/stderr)，服务端追加到命令日志
+	OutputStreamed bool                   `protobuf:"varint,9,opt,name=output_streamed,json=outputStreamed,proto3" json:"output_streamed,omitempty"` // 终态响应的 output 已通过 output_chunk 流式上报
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *CommandResponse) Reset() {
 	*x = CommandResponse{}
-	mi := &file_internal_proto_agent_agent_proto_msgTypes[12]
+	mi := &file_internal_proto_agent_agent_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1162,7 +1474,7 @@ func (x *CommandResponse) String() string {
 func (*CommandResponse) ProtoMessage() {}
 
 func (x *CommandResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_proto_agent_agent_proto_msgTypes[12]
+	mi := &file_internal_proto_agent_agent_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1175,7 +1487,7 @@ func (x *CommandResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CommandResponse.ProtoReflect.Descriptor instead.
 func (*CommandResponse) Descriptor() ([]byte, []int) {
-	return file_internal_proto_agent_agent_proto_rawDescGZIP(), []int{12}
+	return file_internal_proto_agent_agent_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *CommandResponse) GetCommandId() string {
@@ -1220,6 +1532,27 @@ func (x *CommandResponse) GetTimestamp() int64 {
 	return 0
 }
 
+func (x *CommandResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+func (x *CommandResponse) GetOutputChunk() string {
+	if x != nil {
+		return x.OutputChunk
+	}
+	return ""
+}
+
+func (x *CommandResponse) GetOutputStreamed() bool {
+	if x != nil {
+		return x.OutputStreamed
+	}
+	return false
+}
+
 // LogEntry - 日志条目
 type LogEntry struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -1235,7 +1568,7 @@ type LogEntry struct {
 
 func (x *LogEntry) Reset() {
 	*x = LogEntry{}
-	mi := &file_internal_proto_agent_agent_proto_msgTypes[13]
+	mi := &file_internal_proto_agent_agent_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1247,7 +1580,7 @@ func (x *LogEntry) String() string {
 func (*LogEntry) ProtoMessage() {}
 
 func (x *LogEntry) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_proto_agent_agent_proto_msgTypes[13]
+	mi := &file_internal_proto_agent_agent_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1260,7 +1593,7 @@ func (x *LogEntry) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LogEntry.ProtoReflect.Descriptor instead.
 func (*LogEntry) Descriptor() ([]byte, []int) {
-	return file_internal_proto_agent_agent_proto_rawDescGZIP(), []int{13}
+	return file_internal_proto_agent_agent_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *LogEntry) GetAgentId() string {
@@ -1316,7 +1649,7 @@ type LogStreamResponse struct {
 
 func (x *LogStreamResponse) Reset() {
 	*x = LogStreamResponse{}
-	mi := &file_internal_proto_agent_agent_proto_msgTypes[14]
+	mi := &file_internal_proto_agent_agent_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1328,7 +1661,7 @@ func (x *LogStreamResponse) String() string {
 func (*LogStreamResponse) ProtoMessage() {}
 
 func (x *LogStreamResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_proto_agent_agent_proto_msgTypes[14]
+	mi := &file_internal_proto_agent_agent_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1341,7 +1674,7 @@ func (x *LogStreamResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LogStreamResponse.ProtoReflect.Descriptor instead.
 func (*LogStreamResponse) Descriptor() ([]byte, []int) {
-	return file_internal_proto_agent_agent_proto_rawDescGZIP(), []int{14}
+	return file_internal_proto_agent_agent_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *LogStreamResponse) GetSuccess() bool {
@@ -1376,7 +1709,7 @@ type TransferPluginRequest struct {
 
 func (x *TransferPluginRequest) Reset() {
 	*x = TransferPluginRequest{}
-	mi := &file_internal_proto_agent_agent_proto_msgTypes[15]
+	mi := &file_internal_proto_agent_agent_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1388,7 +1721,7 @@ func (x *TransferPluginRequest) String() string {
 func (*TransferPluginRequest) ProtoMessage() {}
 
 func (x *TransferPluginRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_proto_agent_agent_proto_msgTypes[15]
+	mi := &file_internal_proto_agent_agent_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1401,7 +1734,7 @@ func (x *TransferPluginRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TransferPluginRequest.ProtoReflect.Descriptor instead.
 func (*TransferPluginRequest) Descriptor() ([]byte, []int) {
-	return file_internal_proto_agent_agent_proto_rawDescGZIP(), []int{15}
+	return file_internal_proto_agent_agent_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *TransferPluginRequest) GetPluginName() string {
@@ -1479,7 +1812,7 @@ type TransferPluginResponse struct {
 
 func (x *TransferPluginResponse) Reset() {
 	*x = TransferPluginResponse{}
-	mi := &file_internal_proto_agent_agent_proto_msgTypes[16]
+	mi := &file_internal_proto_agent_agent_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1491,7 +1824,7 @@ func (x *TransferPluginResponse) String() string {
 func (*TransferPluginResponse) ProtoMessage() {}
 
 func (x *TransferPluginResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_proto_agent_agent_proto_msgTypes[16]
+	mi := &file_internal_proto_agent_agent_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1504,7 +1837,7 @@ func (x *TransferPluginResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TransferPluginResponse.ProtoReflect.Descriptor instead.
 func (*TransferPluginResponse) Descriptor() ([]byte, []int) {
-	return file_internal_proto_agent_agent_proto_rawDescGZIP(), []int{16}
+	return file_internal_proto_agent_agent_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *TransferPluginResponse) GetSuccess() bool {
@@ -1541,7 +1874,7 @@ type InstallPluginRequest struct {
 
 func (x *InstallPluginRequest) Reset() {
 	*x = InstallPluginRequest{}
-	mi := &file_internal_proto_agent_agent_proto_msgTypes[17]
+	mi := &file_internal_proto_agent_agent_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1553,7 +1886,7 @@ func (x *InstallPluginRequest) String() string {
 func (*InstallPluginRequest) ProtoMessage() {}
 
 func (x *InstallPluginRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_proto_agent_agent_proto_msgTypes[17]
+	mi := &file_internal_proto_agent_agent_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1566,7 +1899,7 @@ func (x *InstallPluginRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use InstallPluginRequest.ProtoReflect.Descriptor instead.
 func (*InstallPluginRequest) Descriptor() ([]byte, []int) {
-	return file_internal_proto_agent_agent_proto_rawDescGZIP(), []int{17}
+	return file_internal_proto_agent_agent_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *InstallPluginRequest) GetPluginName() string {
@@ -1611,7 +1944,7 @@ type InstallPluginResponse struct {
 
 func (x *InstallPluginResponse) Reset() {
 	*x = InstallPluginResponse{}
-	mi := &file_internal_proto_agent_agent_proto_msgTypes[18]
+	mi := &file_internal_proto_agent_agent_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1623,7 +1956,7 @@ func (x *InstallPluginResponse) String() string {
 func (*InstallPluginResponse) ProtoMessage() {}
 
 func (x *InstallPluginResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_proto_agent_agent_proto_msgTypes[18]
+	mi := &file_internal_proto_agent_agent_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1636,7 +1969,7 @@ func (x *InstallPluginResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use InstallPluginResponse.ProtoReflect.Descriptor instead.
 func (*InstallPluginResponse) Descriptor() ([]byte, []int) {
-	return file_internal_proto_agent_agent_proto_rawDescGZIP(), []int{18}
+	return file_internal_proto_agent_agent_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *InstallPluginResponse) GetSuccess() bool {
@@ -1687,7 +2020,7 @@ type UninstallPluginRequest struct {
 
 func (x *UninstallPluginRequest) Reset() {
 	*x = UninstallPluginRequest{}
-	mi := &file_internal_proto_agent_agent_proto_msgTypes[19]
+	mi := &file_internal_proto_agent_agent_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1699,7 +2032,7 @@ func (x *UninstallPluginRequest) String() string {
 func (*UninstallPluginRequest) ProtoMessage() {}
 
 func (x *UninstallPluginRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_proto_agent_agent_proto_msgTypes[19]
+	mi := &file_internal_proto_agent_agent_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1712,7 +2045,7 @@ func (x *UninstallPluginRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UninstallPluginRequest.ProtoReflect.Descriptor instead.
 func (*UninstallPluginRequest) Descriptor() ([]byte, []int) {
-	return file_internal_proto_agent_agent_proto_rawDescGZIP(), []int{19}
+	return file_internal_proto_agent_agent_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *UninstallPluginRequest) GetPluginName() string {
@@ -1755,7 +2088,7 @@ type UninstallPluginResponse struct {
 
 func (x *UninstallPluginResponse) Reset() {
 	*x = UninstallPluginResponse{}
-	mi := &file_internal_proto_agent_agent_proto_msgTypes[20]
+	mi := &file_internal_proto_agent_agent_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1767,7 +2100,7 @@ func (x *UninstallPluginResponse) String() string {
 func (*UninstallPluginResponse) ProtoMessage() {}
 
 func (x *UninstallPluginResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_proto_agent_agent_proto_msgTypes[20]
+	mi := &file_internal_proto_agent_agent_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1780,7 +2113,7 @@ func (x *UninstallPluginResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UninstallPluginResponse.ProtoReflect.Descriptor instead.
 func (*UninstallPluginResponse) Descriptor() ([]byte, []int) {
-	return file_internal_proto_agent_agent_proto_rawDescGZIP(), []int{20}
+	return file_internal_proto_agent_agent_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *UninstallPluginResponse) GetSuccess() bool {
@@ -1814,7 +2147,7 @@ type ListInstalledPluginsRequest struct {
 
 func (x *ListInstalledPluginsRequest) Reset() {
 	*x = ListInstalledPluginsRequest{}
-	mi := &file_internal_proto_agent_agent_proto_msgTypes[21]
+	mi := &file_internal_proto_agent_agent_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1826,7 +2159,7 @@ func (x *ListInstalledPluginsRequest) String() string {
 func (*ListInstalledPluginsRequest) ProtoMessage() {}
 
 func (x *ListInstalledPluginsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_proto_agent_agent_proto_msgTypes[21]
+	mi := &file_internal_proto_agent_agent_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1839,7 +2172,7 @@ func (x *ListInstalledPluginsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListInstalledPluginsRequest.ProtoReflect.Descriptor instead.
 func (*ListInstalledPluginsRequest) Descriptor() ([]byte, []int) {
-	return file_internal_proto_agent_agent_proto_rawDescGZIP(), []int{21}
+	return file_internal_proto_agent_agent_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *ListInstalledPluginsRequest) GetInstallPath() string {
@@ -1863,7 +2196,7 @@ type InstalledPluginInfo struct {
 
 func (x *InstalledPluginInfo) Reset() {
 	*x = InstalledPluginInfo{}
-	mi := &file_internal_proto_agent_agent_proto_msgTypes[22]
+	mi := &file_internal_proto_agent_agent_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1875,7 +2208,7 @@ func (x *InstalledPluginInfo) String() string {
 func (*InstalledPluginInfo) ProtoMessage() {}
 
 func (x *InstalledPluginInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_proto_agent_agent_proto_msgTypes[22]
+	mi := &file_internal_proto_agent_agent_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1888,7 +2221,7 @@ func (x *InstalledPluginInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use InstalledPluginInfo.ProtoReflect.Descriptor instead.
 func (*InstalledPluginInfo) Descriptor() ([]byte, []int) {
-	return file_internal_proto_agent_agent_proto_rawDescGZIP(), []int{22}
+	return file_internal_proto_agent_agent_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *InstalledPluginInfo) GetName() string {
@@ -1938,7 +2271,7 @@ type ListInstalledPluginsResponse struct {
 
 func (x *ListInstalledPluginsResponse) Reset() {
 	*x = ListInstalledPluginsResponse{}
-	mi := &file_internal_proto_agent_agent_proto_msgTypes[23]
+	mi := &file_internal_proto_agent_agent_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1950,7 +2283,7 @@ func (x *ListInstalledPluginsResponse) String() string {
 func (*ListInstalledPluginsResponse) ProtoMessage() {}
 
 func (x *ListInstalledPluginsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_proto_agent_agent_proto_msgTypes[23]
+	mi := &file_internal_proto_agent_agent_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1963,7 +2296,7 @@ func (x *ListInstalledPluginsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListInstalledPluginsResponse.ProtoReflect.Descriptor instead.
 func (*ListInstalledPluginsResponse) Descriptor() ([]byte, []int) {
-	return file_internal_proto_agent_agent_proto_rawDescGZIP(), []int{23}
+	return file_internal_proto_agent_agent_proto_rawDescGZIP(), []int{26}
 }
 
 func (x *ListInstalledPluginsResponse) GetSuccess() bool {
@@ -2003,7 +2336,7 @@ type TransferPackageRequest struct {
 
 func (x *TransferPackageRequest) Reset() {
 	*x = TransferPackageRequest{}
-	mi := &file_internal_proto_agent_agent_proto_msgTypes[24]
+	mi := &file_internal_proto_agent_agent_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2015,7 +2348,7 @@ func (x *TransferPackageRequest) String() string {
 func (*TransferPackageRequest) ProtoMessage() {}
 
 func (x *TransferPackageRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_proto_agent_agent_proto_msgTypes[24]
+	mi := &file_internal_proto_agent_agent_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2028,7 +2361,7 @@ func (x *TransferPackageRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TransferPackageRequest.ProtoReflect.Descriptor instead.
 func (*TransferPackageRequest) Descriptor() ([]byte, []int) {
-	return file_internal_proto_agent_agent_proto_rawDescGZIP(), []int{24}
+	return file_internal_proto_agent_agent_proto_rawDescGZIP(), []int{27}
 }
 
 func (x *TransferPackageRequest) GetVersion() string {
@@ -2093,7 +2426,7 @@ type TransferPackageResponse struct {
 
 func (x *TransferPackageResponse) Reset() {
 	*x = TransferPackageResponse{}
-	mi := &file_internal_proto_agent_agent_proto_msgTypes[25]
+	mi := &file_internal_proto_agent_agent_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2105,7 +2438,7 @@ func (x *TransferPackageResponse) String() string {
 func (*TransferPackageResponse) ProtoMessage() {}
 
 func (x *TransferPackageResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_proto_agent_agent_proto_msgTypes[25]
+	mi := &file_internal_proto_agent_agent_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2118,7 +2451,7 @@ func (x *TransferPackageResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TransferPackageResponse.ProtoReflect.Descriptor instead.
 func (*TransferPackageResponse) Descriptor() ([]byte, []int) {
-	return file_internal_proto_agent_agent_proto_rawDescGZIP(), []int{25}
+	return file_internal_proto_agent_agent_proto_rawDescGZIP(), []int{28}
 }
 
 func (x *TransferPackageResponse) GetSuccess() bool {
@@ -2149,6 +2482,144 @@ func (x *TransferPackageResponse) GetLocalPath() string {
 	return ""
 }
 
+// FetchFileRequest - 文件拉取请求
+type FetchFileRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`          // Agent 唯一标识
+	TransferId    string                 `protobuf:"bytes,2,opt,name=transfer_id,json=transferId,proto3" json:"transfer_id,omitempty"` // Control Plane 登记的传输 ID
+	Offset        int64                  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`                          // 起始偏移量 (原始字节)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FetchFileRequest) Reset() {
+	*x = FetchFileRequest{}
+	mi := &file_internal_proto_agent_agent_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FetchFileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FetchFileRequest) ProtoMessage() {}
+
+func (x *FetchFileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_agent_agent_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FetchFileRequest.ProtoReflect.Descriptor instead.
+func (*FetchFileRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_agent_agent_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *FetchFileRequest) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *FetchFileRequest) GetTransferId() string {
+	if x != nil {
+		return x.TransferId
+	}
+	return ""
+}
+
+func (x *FetchFileRequest) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+// FileChunk - 文件数据块
+type FileChunk struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`                             // 数据 (compression 非空时为压缩后的数据)
+	Offset        int64                  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`                        // 原始数据偏移量
+	TotalSize     int64                  `protobuf:"varint,3,opt,name=total_size,json=totalSize,proto3" json:"total_size,omitempty"` // 文件原始总大小
+	Compression   string                 `protobuf:"bytes,4,opt,name=compression,proto3" json:"compression,omitempty"`               // 压缩算法: 空或 zstd
+	IsLast        bool                   `protobuf:"varint,5,opt,name=is_last,json=isLast,proto3" json:"is_last,omitempty"`          // 是否最后一块
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FileChunk) Reset() {
+	*x = FileChunk{}
+	mi := &file_internal_proto_agent_agent_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileChunk) ProtoMessage() {}
+
+func (x *FileChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_agent_agent_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileChunk.ProtoReflect.Descriptor instead.
+func (*FileChunk) Descriptor() ([]byte, []int) {
+	return file_internal_proto_agent_agent_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *FileChunk) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *FileChunk) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *FileChunk) GetTotalSize() int64 {
+	if x != nil {
+		return x.TotalSize
+	}
+	return 0
+}
+
+func (x *FileChunk) GetCompression() string {
+	if x != nil {
+		return x.Compression
+	}
+	return ""
+}
+
+func (x *FileChunk) GetIsLast() bool {
+	if x != nil {
+		return x.IsLast
+	}
+	return false
+}
+
 // PullConfigRequest - 拉取配置文件请求
 type PullConfigRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -2160,7 +2631,7 @@ type PullConfigRequest struct {
 
 func (x *PullConfigRequest) Reset() {
 	*x = PullConfigRequest{}
-	mi := &file_internal_proto_agent_agent_proto_msgTypes[26]
+	mi := &file_internal_proto_agent_agent_proto_msgTypes[31]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2172,7 +2643,7 @@ func (x *PullConfigRequest) String() string {
 func (*PullConfigRequest) ProtoMessage() {}
 
 func (x *PullConfigRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_proto_agent_agent_proto_msgTypes[26]
+	mi := &file_internal_proto_agent_agent_proto_msgTypes[31]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2185,7 +2656,7 @@ func (x *PullConfigRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PullConfigRequest.ProtoReflect.Descriptor instead.
 func (*PullConfigRequest) Descriptor() ([]byte, []int) {
-	return file_internal_proto_agent_agent_proto_rawDescGZIP(), []int{26}
+	return file_internal_proto_agent_agent_proto_rawDescGZIP(), []int{31}
 }
 
 func (x *PullConfigRequest) GetInstallDir() string {
@@ -2216,7 +2687,7 @@ type PullConfigResponse struct {
 
 func (x *PullConfigResponse) Reset() {
 	*x = PullConfigResponse{}
-	mi := &file_internal_proto_agent_agent_proto_msgTypes[27]
+	mi := &file_internal_proto_agent_agent_proto_msgTypes[32]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2228,7 +2699,7 @@ func (x *PullConfigResponse) String() string {
 func (*PullConfigResponse) ProtoMessage() {}
 
 func (x *PullConfigResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_proto_agent_agent_proto_msgTypes[27]
+	mi := &file_internal_proto_agent_agent_proto_msgTypes[32]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2241,7 +2712,7 @@ func (x *PullConfigResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PullConfigResponse.ProtoReflect.Descriptor instead.
 func (*PullConfigResponse) Descriptor() ([]byte, []int) {
-	return file_internal_proto_agent_agent_proto_rawDescGZIP(), []int{27}
+	return file_internal_proto_agent_agent_proto_rawDescGZIP(), []int{32}
 }
 
 func (x *PullConfigResponse) GetSuccess() bool {
@@ -2292,7 +2763,7 @@ type UpdateConfigRequest struct {
 
 func (x *UpdateConfigRequest) Reset() {
 	*x = UpdateConfigRequest{}
-	mi := &file_internal_proto_agent_agent_proto_msgTypes[28]
+	mi := &file_internal_proto_agent_agent_proto_msgTypes[33]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2304,7 +2775,7 @@ func (x *UpdateConfigRequest) String() string {
 func (*UpdateConfigRequest) ProtoMessage() {}
 
 func (x *UpdateConfigRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_proto_agent_agent_proto_msgTypes[28]
+	mi := &file_internal_proto_agent_agent_proto_msgTypes[33]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2317,7 +2788,7 @@ func (x *UpdateConfigRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateConfigRequest.ProtoReflect.Descriptor instead.
 func (*UpdateConfigRequest) Descriptor() ([]byte, []int) {
-	return file_internal_proto_agent_agent_proto_rawDescGZIP(), []int{28}
+	return file_internal_proto_agent_agent_proto_rawDescGZIP(), []int{33}
 }
 
 func (x *UpdateConfigRequest) GetInstallDir() string {
@@ -2360,7 +2831,7 @@ type UpdateConfigResponse struct {
 
 func (x *UpdateConfigResponse) Reset() {
 	*x = UpdateConfigResponse{}
-	mi := &file_internal_proto_agent_agent_proto_msgTypes[29]
+	mi := &file_internal_proto_agent_agent_proto_msgTypes[34]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2372,7 +2843,7 @@ func (x *UpdateConfigResponse) String() string {
 func (*UpdateConfigResponse) ProtoMessage() {}
 
 func (x *UpdateConfigResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_proto_agent_agent_proto_msgTypes[29]
+	mi := &file_internal_proto_agent_agent_proto_msgTypes[34]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2385,7 +2856,7 @@ func (x *UpdateConfigResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateConfigResponse.ProtoReflect.Descriptor instead.
 func (*UpdateConfigResponse) Descriptor() ([]byte, []int) {
-	return file_internal_proto_agent_agent_proto_rawDescGZIP(), []int{29}
+	return file_internal_proto_agent_agent_proto_rawDescGZIP(), []int{34}
 }
 
 func (x *UpdateConfigResponse) GetSuccess() bool {
@@ -2420,7 +2891,7 @@ type DiscoverClustersRequest struct {
 
 func (x *DiscoverClustersRequest) Reset() {
 	*x = DiscoverClustersRequest{}
-	mi := &file_internal_proto_agent_agent_proto_msgTypes[30]
+	mi := &file_internal_proto_agent_agent_proto_msgTypes[35]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2432,7 +2903,7 @@ func (x *DiscoverClustersRequest) String() string {
 func (*DiscoverClustersRequest) ProtoMessage() {}
 
 func (x *DiscoverClustersRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_proto_agent_agent_proto_msgTypes[30]
+	mi := &file_internal_proto_agent_agent_proto_msgTypes[35]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2445,7 +2916,7 @@ func (x *DiscoverClustersRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DiscoverClustersRequest.ProtoReflect.Descriptor instead.
 func (*DiscoverClustersRequest) Descriptor() ([]byte, []int) {
-	return file_internal_proto_agent_agent_proto_rawDescGZIP(), []int{30}
+	return file_internal_proto_agent_agent_proto_rawDescGZIP(), []int{35}
 }
 
 func (x *DiscoverClustersRequest) GetAgentId() string {
@@ -2472,7 +2943,7 @@ type DiscoveredClusterInfo struct {
 
 func (x *DiscoveredClusterInfo) Reset() {
 	*x = DiscoveredClusterInfo{}
-	mi := &file_internal_proto_agent_agent_proto_msgTypes[31]
+	mi := &file_internal_proto_agent_agent_proto_msgTypes[36]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2484,7 +2955,7 @@ func (x *DiscoveredClusterInfo) String() string {
 func (*DiscoveredClusterInfo) ProtoMessage() {}
 
 func (x *DiscoveredClusterInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_proto_agent_agent_proto_msgTypes[31]
+	mi := &file_internal_proto_agent_agent_proto_msgTypes[36]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2497,7 +2968,7 @@ func (x *DiscoveredClusterInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DiscoveredClusterInfo.ProtoReflect.Descriptor instead.
 func (*DiscoveredClusterInfo) Descriptor() ([]byte, []int) {
-	return file_internal_proto_agent_agent_proto_rawDescGZIP(), []int{31}
+	return file_internal_proto_agent_agent_proto_rawDescGZIP(), []int{36}
 }
 
 func (x *DiscoveredClusterInfo) GetName() string {
@@ -2565,7 +3036,7 @@ type DiscoveredNodeInfo struct {
 
 func (x *DiscoveredNodeInfo) Reset() {
 	*x = DiscoveredNodeInfo{}
-	mi := &file_internal_proto_agent_agent_proto_msgTypes[32]
+	mi := &file_internal_proto_agent_agent_proto_msgTypes[37]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2577,7 +3048,7 @@ func (x *DiscoveredNodeInfo) String() string {
 func (*DiscoveredNodeInfo) ProtoMessage() {}
 
 func (x *DiscoveredNodeInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_proto_agent_agent_proto_msgTypes[32]
+	mi := &file_internal_proto_agent_agent_proto_msgTypes[37]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2590,7 +3061,7 @@ func (x *DiscoveredNodeInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DiscoveredNodeInfo.ProtoReflect.Descriptor instead.
 func (*DiscoveredNodeInfo) Descriptor() ([]byte, []int) {
-	return file_internal_proto_agent_agent_proto_rawDescGZIP(), []int{32}
+	return file_internal_proto_agent_agent_proto_rawDescGZIP(), []int{37}
 }
 
 func (x *DiscoveredNodeInfo) GetPid() int32 {
@@ -2648,7 +3119,7 @@ type DiscoverClustersResponse struct {
 
 func (x *DiscoverClustersResponse) Reset() {
 	*x = DiscoverClustersResponse{}
-	mi := &file_internal_proto_agent_agent_proto_msgTypes[33]
+	mi := &file_internal_proto_agent_agent_proto_msgTypes[38]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2660,7 +3131,7 @@ func (x *DiscoverClustersResponse) String() string {
 func (*DiscoverClustersResponse) ProtoMessage() {}
 
 func (x *DiscoverClustersResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_proto_agent_agent_proto_msgTypes[33]
+	mi := &file_internal_proto_agent_agent_proto_msgTypes[38]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2673,7 +3144,7 @@ func (x *DiscoverClustersResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DiscoverClustersResponse.ProtoReflect.Descriptor instead.
 func (*DiscoverClustersResponse) Descriptor() ([]byte, []int) {
-	return file_internal_proto_agent_agent_proto_rawDescGZIP(), []int{33}
+	return file_internal_proto_agent_agent_proto_rawDescGZIP(), []int{38}
 }
 
 func (x *DiscoverClustersResponse) GetSuccess() bool {
@@ -2715,7 +3186,7 @@ type ProcessEventReport struct {
 
 func (x *ProcessEventReport) Reset() {
 	*x = ProcessEventReport{}
-	mi := &file_internal_proto_agent_agent_proto_msgTypes[34]
+	mi := &file_internal_proto_agent_agent_proto_msgTypes[39]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2727,7 +3198,7 @@ func (x *ProcessEventReport) String() string {
 func (*ProcessEventReport) ProtoMessage() {}
 
 func (x *ProcessEventReport) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_proto_agent_agent_proto_msgTypes[34]
+	mi := &file_internal_proto_agent_agent_proto_msgTypes[39]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2740,7 +3211,7 @@ func (x *ProcessEventReport) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ProcessEventReport.ProtoReflect.Descriptor instead.
 func (*ProcessEventReport) Descriptor() ([]byte, []int) {
-	return file_internal_proto_agent_agent_proto_rawDescGZIP(), []int{34}
+	return file_internal_proto_agent_agent_proto_rawDescGZIP(), []int{39}
 }
 
 func (x *ProcessEventReport) GetAgentId() string {
@@ -2817,7 +3288,7 @@ type MonitorConfigUpdate struct {
 
 func (x *MonitorConfigUpdate) Reset() {
 	*x = MonitorConfigUpdate{}
-	mi := &file_internal_proto_agent_agent_proto_msgTypes[35]
+	mi := &file_internal_proto_agent_agent_proto_msgTypes[40]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2829,7 +3300,7 @@ func (x *MonitorConfigUpdate) String() string {
 func (*MonitorConfigUpdate) ProtoMessage() {}
 
 func (x *MonitorConfigUpdate) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_proto_agent_agent_proto_msgTypes[35]
+	mi := &file_internal_proto_agent_agent_proto_msgTypes[40]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2842,7 +3313,7 @@ func (x *MonitorConfigUpdate) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MonitorConfigUpdate.ProtoReflect.Descriptor instead.
 func (*MonitorConfigUpdate) Descriptor() ([]byte, []int) {
-	return file_internal_proto_agent_agent_proto_rawDescGZIP(), []int{35}
+	return file_internal_proto_agent_agent_proto_rawDescGZIP(), []int{40}
 }
 
 func (x *MonitorConfigUpdate) GetConfigVersion() int32 {
@@ -2917,7 +3388,7 @@ const file_internal_proto_agent_agent_proto_rawDesc = "" +
 	"\x06offset\x18\x04 \x01(\x03R\x06offset\x12(\n" +
 	"\x10last_occurred_at\x18\x05 \x01(\x03R\x0elastOccurredAt\"\\\n" +
 	"\x19DiagnosticsCursorResponse\x12?\n" +
-	"\acursors\x18\x01 \x03(\v2%.seatunnel.agent.v1.DiagnosticsCursorR\acursors\"\xfa\x01\n" +
+	"\acursors\x18\x01 \x03(\v2%.seatunnel.agent.v1.DiagnosticsCursorR\acursors\"\xe1\x02\n" +
 	"\x0fRegisterRequest\x12\x19\n" +
 	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12\x1a\n" +
 	"\bhostname\x18\x02 \x01(\tR\bhostname\x12\x1d\n" +
@@ -2927,20 +3398,25 @@ const file_internal_proto_agent_agent_proto_rawDesc = "" +
 	"\x04arch\x18\x05 \x01(\tR\x04arch\x12#\n" +
 	"\ragent_version\x18\x06 \x01(\tR\fagentVersion\x12?\n" +
 	"\vsystem_info\x18\a \x01(\v2\x1e.seatunnel.agent.v1.SystemInfoR\n" +
-	"systemInfo\"\x92\x01\n" +
+	"systemInfo\x12\x17\n" +
+	"\adry_run\x18\b \x01(\bR\x06dryRun\x12)\n" +
+	"\x10enrollment_token\x18\t \x01(\tR\x0fenrollmentToken\x12!\n" +
+	"\fagent_secret\x18\n" +
+	" \x01(\tR\vagentSecret\"\x92\x01\n" +
 	"\n" +
 	"SystemInfo\x12\x1b\n" +
 	"\tcpu_cores\x18\x01 \x01(\x05R\bcpuCores\x12!\n" +
 	"\ftotal_memory\x18\x02 \x01(\x03R\vtotalMemory\x12\x1d\n" +
 	"\n" +
 	"total_disk\x18\x03 \x01(\x03R\ttotalDisk\x12%\n" +
-	"\x0ekernel_version\x18\x04 \x01(\tR\rkernelVersion\"\xa0\x01\n" +
+	"\x0ekernel_version\x18\x04 \x01(\tR\rkernelVersion\"\xc3\x01\n" +
 	"\x10RegisterResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12\x1f\n" +
 	"\vassigned_id\x18\x03 \x01(\tR\n" +
 	"assignedId\x127\n" +
-	"\x06config\x18\x04 \x01(\v2\x1f.seatunnel.agent.v1.AgentConfigR\x06config\"\xd5\x01\n" +
+	"\x06config\x18\x04 \x01(\v2\x1f.seatunnel.agent.v1.AgentConfigR\x06config\x12!\n" +
+	"\fagent_secret\x18\x05 \x01(\tR\vagentSecret\"\xd5\x01\n" +
 	"\vAgentConfig\x12-\n" +
 	"\x12heartbeat_interval\x18\x01 \x01(\x05R\x11heartbeatInterval\x12\x1b\n" +
 	"\tlog_level\x18\x02 \x01(\x05R\blogLevel\x12@\n" +
@@ -2948,19 +3424,43 @@ const file_internal_proto_agent_agent_proto_rawDesc = "" +
 	"\n" +
 	"ExtraEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xd6\x01\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xe4\x03\n" +
 	"\x10HeartbeatRequest\x12\x19\n" +
 	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12\x1c\n" +
 	"\ttimestamp\x18\x02 \x01(\x03R\ttimestamp\x12H\n" +
 	"\x0eresource_usage\x18\x03 \x01(\v2!.seatunnel.agent.v1.ResourceUsageR\rresourceUsage\x12?\n" +
-	"\tprocesses\x18\x04 \x03(\v2!.seatunnel.agent.v1.ProcessStatusR\tprocesses\"\xc0\x01\n" +
+	"\tprocesses\x18\x04 \x03(\v2!.seatunnel.agent.v1.ProcessStatusR\tprocesses\x12G\n" +
+	"\fplugin_cache\x18\x05 \x01(\v2$.seatunnel.agent.v1.PluginCacheStatsR\vpluginCache\x12W\n" +
+	"\x13seatunnel_inventory\x18\x06 \x01(\v2&.seatunnel.agent.v1.SeaTunnelInventoryR\x12seatunnelInventory\x124\n" +
+	"\x16control_plane_endpoint\x18\a \x01(\tR\x14controlPlaneEndpoint\x124\n" +
+	"\x16control_plane_failover\x18\b \x01(\bR\x14controlPlaneFailover\"\xc0\x01\n" +
 	"\rResourceUsage\x12\x1b\n" +
 	"\tcpu_usage\x18\x01 \x01(\x01R\bcpuUsage\x12!\n" +
 	"\fmemory_usage\x18\x02 \x01(\x01R\vmemoryUsage\x12\x1d\n" +
 	"\n" +
 	"disk_usage\x18\x03 \x01(\x01R\tdiskUsage\x12)\n" +
 	"\x10available_memory\x18\x04 \x01(\x03R\x0favailableMemory\x12%\n" +
-	"\x0eavailable_disk\x18\x05 \x01(\x03R\ravailableDisk\"\xa5\x01\n" +
+	"\x0eavailable_disk\x18\x05 \x01(\x03R\ravailableDisk\"\xb2\x01\n" +
+	"\x10PluginCacheStats\x12\x18\n" +
+	"\aentries\x18\x01 \x01(\x03R\aentries\x12\x1d\n" +
+	"\n" +
+	"size_bytes\x18\x02 \x01(\x03R\tsizeBytes\x12\x1b\n" +
+	"\tmax_bytes\x18\x03 \x01(\x03R\bmaxBytes\x12\x12\n" +
+	"\x04hits\x18\x04 \x01(\x03R\x04hits\x12\x16\n" +
+	"\x06misses\x18\x05 \x01(\x03R\x06misses\x12\x1c\n" +
+	"\tevictions\x18\x06 \x01(\x03R\tevictions\"u\n" +
+	"\x12SeaTunnelInventory\x12\x1d\n" +
+	"\n" +
+	"scanned_at\x18\x01 \x01(\x03R\tscannedAt\x12@\n" +
+	"\binstalls\x18\x02 \x03(\v2$.seatunnel.agent.v1.SeaTunnelInstallR\binstalls\"\xb7\x01\n" +
+	"\x10SeaTunnelInstall\x12\x1f\n" +
+	"\vinstall_dir\x18\x01 \x01(\tR\n" +
+	"installDir\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\tR\aversion\x12\x12\n" +
+	"\x04role\x18\x03 \x01(\tR\x04role\x12\x10\n" +
+	"\x03pid\x18\x04 \x01(\x05R\x03pid\x12%\n" +
+	"\x0ehazelcast_port\x18\x05 \x01(\x05R\rhazelcastPort\x12\x1b\n" +
+	"\thttp_port\x18\x06 \x01(\x05R\bhttpPort\"\xa5\x01\n" +
 	"\rProcessStatus\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x10\n" +
 	"\x03pid\x18\x02 \x01(\x05R\x03pid\x12\x16\n" +
@@ -2971,7 +3471,7 @@ const file_internal_proto_agent_agent_proto_rawDesc = "" +
 	"\x11HeartbeatResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x1f\n" +
 	"\vserver_time\x18\x02 \x01(\x03R\n" +
-	"serverTime\"\x91\x02\n" +
+	"serverTime\"\x9c\x03\n" +
 	"\x0eCommandRequest\x12\x1d\n" +
 	"\n" +
 	"command_id\x18\x01 \x01(\tR\tcommandId\x123\n" +
@@ -2979,10 +3479,14 @@ const file_internal_proto_agent_agent_proto_rawDesc = "" +
 	"\n" +
 	"parameters\x18\x03 \x03(\v22.seatunnel.agent.v1.CommandRequest.ParametersEntryR\n" +
 	"parameters\x12\x18\n" +
-	"\atimeout\x18\x04 \x01(\x05R\atimeout\x1a=\n" +
+	"\atimeout\x18\x04 \x01(\x05R\atimeout\x12L\n" +
+	"\bmetadata\x18\x05 \x03(\v20.seatunnel.agent.v1.CommandRequest.MetadataEntryR\bmetadata\x1a=\n" +
 	"\x0fParametersEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xd3\x01\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xbe\x02\n" +
 	"\x0fCommandResponse\x12\x1d\n" +
 	"\n" +
 	"command_id\x18\x01 \x01(\tR\tcommandId\x129\n" +
@@ -2990,7 +3494,11 @@ const file_internal_proto_agent_agent_proto_rawDesc = "" +
 	"\bprogress\x18\x03 \x01(\x05R\bprogress\x12\x16\n" +
 	"\x06output\x18\x04 \x01(\tR\x06output\x12\x14\n" +
 	"\x05error\x18\x05 \x01(\tR\x05error\x12\x1c\n" +
-	"\ttimestamp\x18\x06 \x01(\x03R\ttimestamp\"\xad\x02\n" +
+	"\ttimestamp\x18\x06 \x01(\x03R\ttimestamp\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\a \x01(\tR\terrorCode\x12!\n" +
+	"\foutput_chunk\x18\b \x01(\tR\voutputChunk\x12'\n" +
+	"\x0foutput_streamed\x18\t \x01(\bR\x0eoutputStreamed\"\xad\x02\n" +
 	"\bLogEntry\x12\x19\n" +
 	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12\x1d\n" +
 	"\n" +
@@ -3069,7 +3577,19 @@ const file_internal_proto_agent_agent_proto_rawDesc = "" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12%\n" +
 	"\x0ereceived_bytes\x18\x03 \x01(\x03R\rreceivedBytes\x12\x1d\n" +
 	"\n" +
-	"local_path\x18\x04 \x01(\tR\tlocalPath\"U\n" +
+	"local_path\x18\x04 \x01(\tR\tlocalPath\"f\n" +
+	"\x10FetchFileRequest\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12\x1f\n" +
+	"\vtransfer_id\x18\x02 \x01(\tR\n" +
+	"transferId\x12\x16\n" +
+	"\x06offset\x18\x03 \x01(\x03R\x06offset\"\x91\x01\n" +
+	"\tFileChunk\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\x12\x16\n" +
+	"\x06offset\x18\x02 \x01(\x03R\x06offset\x12\x1d\n" +
+	"\n" +
+	"total_size\x18\x03 \x01(\x03R\ttotalSize\x12 \n" +
+	"\vcompression\x18\x04 \x01(\tR\vcompression\x12\x17\n" +
+	"\ais_last\x18\x05 \x01(\bR\x06isLast\"U\n" +
 	"\x11PullConfigRequest\x12\x1f\n" +
 	"\vinstall_dir\x18\x01 \x01(\tR\n" +
 	"installDir\x12\x1f\n" +
@@ -3143,7 +3663,7 @@ const file_internal_proto_agent_agent_proto_rawDesc = "" +
 	"\fmax_restarts\x18\x06 \x01(\x05R\vmaxRestarts\x12\x1f\n" +
 	"\vtime_window\x18\a \x01(\x05R\n" +
 	"timeWindow\x12'\n" +
-	"\x0fcooldown_period\x18\b \x01(\x05R\x0ecooldownPeriod*\xd8\x03\n" +
+	"\x0fcooldown_period\x18\b \x01(\x05R\x0ecooldownPeriod*\x83\x04\n" +
 	"\vCommandType\x12\x1c\n" +
 	"\x18COMMAND_TYPE_UNSPECIFIED\x10\x00\x12\f\n" +
 	"\bPRECHECK\x10\x01\x12\v\n" +
@@ -3158,7 +3678,8 @@ const file_internal_proto_agent_agent_proto_rawDesc = "" +
 	"\x06STATUS\x10\x17\x12\x10\n" +
 	"\fCOLLECT_LOGS\x10\x1e\x12\f\n" +
 	"\bJVM_DUMP\x10\x1f\x12\x0f\n" +
-	"\vTHREAD_DUMP\x10 \x12\x11\n" +
+	"\vTHREAD_DUMP\x10 \x12\f\n" +
+	"\bDIAGNOSE\x10!\x12\x11\n" +
 	"\rUPDATE_CONFIG\x10(\x12\x13\n" +
 	"\x0fROLLBACK_CONFIG\x10)\x12\x0f\n" +
 	"\vPULL_CONFIG\x10*\x12\x13\n" +
@@ -3171,7 +3692,10 @@ const file_internal_proto_agent_agent_proto_rawDesc = "" +
 	"\x15UPDATE_MONITOR_CONFIG\x10G\x12\x14\n" +
 	"\x10MARK_MANUAL_STOP\x10H\x12\x15\n" +
 	"\x11CLEAR_MANUAL_STOP\x10I\x12\x16\n" +
-	"\x12REMOVE_INSTALL_DIR\x10J*q\n" +
+	"\x12REMOVE_INSTALL_DIR\x10J\x12\x0f\n" +
+	"\vEXEC_SCRIPT\x10P\x12\n" +
+	"\n" +
+	"\x06CANCEL\x10Z*~\n" +
 	"\rCommandStatus\x12\x1e\n" +
 	"\x1aCOMMAND_STATUS_UNSPECIFIED\x10\x00\x12\v\n" +
 	"\aPENDING\x10\x01\x12\v\n" +
@@ -3179,7 +3703,8 @@ const file_internal_proto_agent_agent_proto_rawDesc = "" +
 	"\aSUCCESS\x10\x03\x12\n" +
 	"\n" +
 	"\x06FAILED\x10\x04\x12\r\n" +
-	"\tCANCELLED\x10\x05*O\n" +
+	"\tCANCELLED\x10\x05\x12\v\n" +
+	"\aTIMEOUT\x10\x06*O\n" +
 	"\bLogLevel\x12\x19\n" +
 	"\x15LOG_LEVEL_UNSPECIFIED\x10\x00\x12\t\n" +
 	"\x05DEBUG\x10\x01\x12\b\n" +
@@ -3192,13 +3717,14 @@ const file_internal_proto_agent_agent_proto_rawDesc = "" +
 	"\x0fPROCESS_STOPPED\x10\x02\x12\x13\n" +
 	"\x0fPROCESS_CRASHED\x10\x03\x12\x15\n" +
 	"\x11PROCESS_RESTARTED\x10\x04\x12\x1a\n" +
-	"\x16PROCESS_RESTART_FAILED\x10\x052\xea\x03\n" +
+	"\x16PROCESS_RESTART_FAILED\x10\x052\xbe\x04\n" +
 	"\fAgentService\x12U\n" +
 	"\bRegister\x12#.seatunnel.agent.v1.RegisterRequest\x1a$.seatunnel.agent.v1.RegisterResponse\x12X\n" +
 	"\tHeartbeat\x12$.seatunnel.agent.v1.HeartbeatRequest\x1a%.seatunnel.agent.v1.HeartbeatResponse\x12\\\n" +
 	"\rCommandStream\x12#.seatunnel.agent.v1.CommandResponse\x1a\".seatunnel.agent.v1.CommandRequest(\x010\x01\x12R\n" +
 	"\tLogStream\x12\x1c.seatunnel.agent.v1.LogEntry\x1a%.seatunnel.agent.v1.LogStreamResponse(\x01\x12w\n" +
-	"\x18GetDiagnosticsLogCursors\x12,.seatunnel.agent.v1.DiagnosticsCursorRequest\x1a-.seatunnel.agent.v1.DiagnosticsCursorResponseB6Z4github.com/seatunnel/seatunnelX/internal/proto/agentb\x06proto3"
+	"\x18GetDiagnosticsLogCursors\x12,.seatunnel.agent.v1.DiagnosticsCursorRequest\x1a-.seatunnel.agent.v1.DiagnosticsCursorResponse\x12R\n" +
+	"\tFetchFile\x12$.seatunnel.agent.v1.FetchFileRequest\x1a\x1d.seatunnel.agent.v1.FileChunk0\x01B6Z4github.com/seatunnel/seatunnelX/internal/proto/agentb\x06proto3"
 
 var (
 	file_internal_proto_agent_agent_proto_rawDescOnce sync.Once
@@ -3213,7 +3739,7 @@ func file_internal_proto_agent_agent_proto_rawDescGZIP() []byte {
 }
 
 var file_internal_proto_agent_agent_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
-var file_internal_proto_agent_agent_proto_msgTypes = make([]protoimpl.MessageInfo, 41)
+var file_internal_proto_agent_agent_proto_msgTypes = make([]protoimpl.MessageInfo, 47)
 var file_internal_proto_agent_agent_proto_goTypes = []any{
 	(CommandType)(0),                     // 0: seatunnel.agent.v1.CommandType
 	(CommandStatus)(0),                   // 1: seatunnel.agent.v1.CommandStatus
@@ -3228,72 +3754,84 @@ var file_internal_proto_agent_agent_proto_goTypes = []any{
 	(*AgentConfig)(nil),                  // 10: seatunnel.agent.v1.AgentConfig
 	(*HeartbeatRequest)(nil),             // 11: seatunnel.agent.v1.HeartbeatRequest
 	(*ResourceUsage)(nil),                // 12: seatunnel.agent.v1.ResourceUsage
-	(*ProcessStatus)(nil),                // 13: seatunnel.agent.v1.ProcessStatus
-	(*HeartbeatResponse)(nil),            // 14: seatunnel.agent.v1.HeartbeatResponse
-	(*CommandRequest)(nil),               // 15: seatunnel.agent.v1.CommandRequest
-	(*CommandResponse)(nil),              // 16: seatunnel.agent.v1.CommandResponse
-	(*LogEntry)(nil),                     // 17: seatunnel.agent.v1.LogEntry
-	(*LogStreamResponse)(nil),            // 18: seatunnel.agent.v1.LogStreamResponse
-	(*TransferPluginRequest)(nil),        // 19: seatunnel.agent.v1.TransferPluginRequest
-	(*TransferPluginResponse)(nil),       // 20: seatunnel.agent.v1.TransferPluginResponse
-	(*InstallPluginRequest)(nil),         // 21: seatunnel.agent.v1.InstallPluginRequest
-	(*InstallPluginResponse)(nil),        // 22: seatunnel.agent.v1.InstallPluginResponse
-	(*UninstallPluginRequest)(nil),       // 23: seatunnel.agent.v1.UninstallPluginRequest
-	(*UninstallPluginResponse)(nil),      // 24: seatunnel.agent.v1.UninstallPluginResponse
-	(*ListInstalledPluginsRequest)(nil),  // 25: seatunnel.agent.v1.ListInstalledPluginsRequest
-	(*InstalledPluginInfo)(nil),          // 26: seatunnel.agent.v1.InstalledPluginInfo
-	(*ListInstalledPluginsResponse)(nil), // 27: seatunnel.agent.v1.ListInstalledPluginsResponse
-	(*TransferPackageRequest)(nil),       // 28: seatunnel.agent.v1.TransferPackageRequest
-	(*TransferPackageResponse)(nil),      // 29: seatunnel.agent.v1.TransferPackageResponse
-	(*PullConfigRequest)(nil),            // 30: seatunnel.agent.v1.PullConfigRequest
-	(*PullConfigResponse)(nil),           // 31: seatunnel.agent.v1.PullConfigResponse
-	(*UpdateConfigRequest)(nil),          // 32: seatunnel.agent.v1.UpdateConfigRequest
-	(*UpdateConfigResponse)(nil),         // 33: seatunnel.agent.v1.UpdateConfigResponse
-	(*DiscoverClustersRequest)(nil),      // 34: seatunnel.agent.v1.DiscoverClustersRequest
-	(*DiscoveredClusterInfo)(nil),        // 35: seatunnel.agent.v1.DiscoveredClusterInfo
-	(*DiscoveredNodeInfo)(nil),           // 36: seatunnel.agent.v1.DiscoveredNodeInfo
-	(*DiscoverClustersResponse)(nil),     // 37: seatunnel.agent.v1.DiscoverClustersResponse
-	(*ProcessEventReport)(nil),           // 38: seatunnel.agent.v1.ProcessEventReport
-	(*MonitorConfigUpdate)(nil),          // 39: seatunnel.agent.v1.MonitorConfigUpdate
-	nil,                                  // 40: seatunnel.agent.v1.AgentConfig.ExtraEntry
-	nil,                                  // 41: seatunnel.agent.v1.CommandRequest.ParametersEntry
-	nil,                                  // 42: seatunnel.agent.v1.LogEntry.FieldsEntry
-	nil,                                  // 43: seatunnel.agent.v1.DiscoveredClusterInfo.ConfigEntry
-	nil,                                  // 44: seatunnel.agent.v1.ProcessEventReport.DetailsEntry
+	(*PluginCacheStats)(nil),             // 13: seatunnel.agent.v1.PluginCacheStats
+	(*SeaTunnelInventory)(nil),           // 14: seatunnel.agent.v1.SeaTunnelInventory
+	(*SeaTunnelInstall)(nil),             // 15: seatunnel.agent.v1.SeaTunnelInstall
+	(*ProcessStatus)(nil),                // 16: seatunnel.agent.v1.ProcessStatus
+	(*HeartbeatResponse)(nil),            // 17: seatunnel.agent.v1.HeartbeatResponse
+	(*CommandRequest)(nil),               // 18: seatunnel.agent.v1.CommandRequest
+	(*CommandResponse)(nil),              // 19: seatunnel.agent.v1.CommandResponse
+	(*LogEntry)(nil),                     // 20: seatunnel.agent.v1.LogEntry
+	(*LogStreamResponse)(nil),            // 21: seatunnel.agent.v1.LogStreamResponse
+	(*TransferPluginRequest)(nil),        // 22: seatunnel.agent.v1.TransferPluginRequest
+	(*TransferPluginResponse)(nil),       // 23: seatunnel.agent.v1.TransferPluginResponse
+	(*InstallPluginRequest)(nil),         // 24: seatunnel.agent.v1.InstallPluginRequest
+	(*InstallPluginResponse)(nil),        // 25: seatunnel.agent.v1.InstallPluginResponse
+	(*UninstallPluginRequest)(nil),       // 26: seatunnel.agent.v1.UninstallPluginRequest
+	(*UninstallPluginResponse)(nil),      // 27: seatunnel.agent.v1.UninstallPluginResponse
+	(*ListInstalledPluginsRequest)(nil),  // 28: seatunnel.agent.v1.ListInstalledPluginsRequest
+	(*InstalledPluginInfo)(nil),          // 29: seatunnel.agent.v1.InstalledPluginInfo
+	(*ListInstalledPluginsResponse)(nil), // 30: seatunnel.agent.v1.ListInstalledPluginsResponse
+	(*TransferPackageRequest)(nil),       // 31: seatunnel.agent.v1.TransferPackageRequest
+	(*TransferPackageResponse)(nil),      // 32: seatunnel.agent.v1.TransferPackageResponse
+	(*FetchFileRequest)(nil),             // 33: seatunnel.agent.v1.FetchFileRequest
+	(*FileChunk)(nil),                    // 34: seatunnel.agent.v1.FileChunk
+	(*PullConfigRequest)(nil),            // 35: seatunnel.agent.v1.PullConfigRequest
+	(*PullConfigResponse)(nil),           // 36: seatunnel.agent.v1.PullConfigResponse
+	(*UpdateConfigRequest)(nil),          // 37: seatunnel.agent.v1.UpdateConfigRequest
+	(*UpdateConfigResponse)(nil),         // 38: seatunnel.agent.v1.UpdateConfigResponse
+	(*DiscoverClustersRequest)(nil),      // 39: seatunnel.agent.v1.DiscoverClustersRequest
+	(*DiscoveredClusterInfo)(nil),        // 40: seatunnel.agent.v1.DiscoveredClusterInfo
+	(*DiscoveredNodeInfo)(nil),           // 41: seatunnel.agent.v1.DiscoveredNodeInfo
+	(*DiscoverClustersResponse)(nil),     // 42: seatunnel.agent.v1.DiscoverClustersResponse
+	(*ProcessEventReport)(nil),           // 43: seatunnel.agent.v1.ProcessEventReport
+	(*MonitorConfigUpdate)(nil),          // 44: seatunnel.agent.v1.MonitorConfigUpdate
+	nil,                                  // 45: seatunnel.agent.v1.AgentConfig.ExtraEntry
+	nil,                                  // 46: seatunnel.agent.v1.CommandRequest.ParametersEntry
+	nil,                                  // 47: seatunnel.agent.v1.CommandRequest.MetadataEntry
+	nil,                                  // 48: seatunnel.agent.v1.LogEntry.FieldsEntry
+	nil,                                  // 49: seatunnel.agent.v1.DiscoveredClusterInfo.ConfigEntry
+	nil,                                  // 50: seatunnel.agent.v1.ProcessEventReport.DetailsEntry
 }
 var file_internal_proto_agent_agent_proto_depIdxs = []int32{
 	5,  // 0: seatunnel.agent.v1.DiagnosticsCursorResponse.cursors:type_name -> seatunnel.agent.v1.DiagnosticsCursor
 	8,  // 1: seatunnel.agent.v1.RegisterRequest.system_info:type_name -> seatunnel.agent.v1.SystemInfo
 	10, // 2: seatunnel.agent.v1.RegisterResponse.config:type_name -> seatunnel.agent.v1.AgentConfig
-	40, // 3: seatunnel.agent.v1.AgentConfig.extra:type_name -> seatunnel.agent.v1.AgentConfig.ExtraEntry
+	45, // 3: seatunnel.agent.v1.AgentConfig.extra:type_name -> seatunnel.agent.v1.AgentConfig.ExtraEntry
 	12, // 4: seatunnel.agent.v1.HeartbeatRequest.resource_usage:type_name -> seatunnel.agent.v1.ResourceUsage
-	13, // 5: seatunnel.agent.v1.HeartbeatRequest.processes:type_name -> seatunnel.agent.v1.ProcessStatus
-	0,  // 6: seatunnel.agent.v1.CommandRequest.type:type_name -> seatunnel.agent.v1.CommandType
-	41, // 7: seatunnel.agent.v1.CommandRequest.parameters:type_name -> seatunnel.agent.v1.CommandRequest.ParametersEntry
-	1,  // 8: seatunnel.agent.v1.CommandResponse.status:type_name -> seatunnel.agent.v1.CommandStatus
-	2,  // 9: seatunnel.agent.v1.LogEntry.level:type_name -> seatunnel.agent.v1.LogLevel
-	42, // 10: seatunnel.agent.v1.LogEntry.fields:type_name -> seatunnel.agent.v1.LogEntry.FieldsEntry
-	26, // 11: seatunnel.agent.v1.ListInstalledPluginsResponse.plugins:type_name -> seatunnel.agent.v1.InstalledPluginInfo
-	36, // 12: seatunnel.agent.v1.DiscoveredClusterInfo.nodes:type_name -> seatunnel.agent.v1.DiscoveredNodeInfo
-	43, // 13: seatunnel.agent.v1.DiscoveredClusterInfo.config:type_name -> seatunnel.agent.v1.DiscoveredClusterInfo.ConfigEntry
-	35, // 14: seatunnel.agent.v1.DiscoverClustersResponse.clusters:type_name -> seatunnel.agent.v1.DiscoveredClusterInfo
-	3,  // 15: seatunnel.agent.v1.ProcessEventReport.event_type:type_name -> seatunnel.agent.v1.ProcessEventType
-	44, // 16: seatunnel.agent.v1.ProcessEventReport.details:type_name -> seatunnel.agent.v1.ProcessEventReport.DetailsEntry
-	7,  // 17: seatunnel.agent.v1.AgentService.Register:input_type -> seatunnel.agent.v1.RegisterRequest
-	11, // 18: seatunnel.agent.v1.AgentService.Heartbeat:input_type -> seatunnel.agent.v1.HeartbeatRequest
-	16, // 19: seatunnel.agent.v1.AgentService.CommandStream:input_type -> seatunnel.agent.v1.CommandResponse
-	17, // 20: seatunnel.agent.v1.AgentService.LogStream:input_type -> seatunnel.agent.v1.LogEntry
-	4,  // 21: seatunnel.agent.v1.AgentService.GetDiagnosticsLogCursors:input_type -> seatunnel.agent.v1.DiagnosticsCursorRequest
-	9,  // 22: seatunnel.agent.v1.AgentService.Register:output_type -> seatunnel.agent.v1.RegisterResponse
-	14, // 23: seatunnel.agent.v1.AgentService.Heartbeat:output_type -> seatunnel.agent.v1.HeartbeatResponse
-	15, // 24: seatunnel.agent.v1.AgentService.CommandStream:output_type -> seatunnel.agent.v1.CommandRequest
-	18, // 25: seatunnel.agent.v1.AgentService.LogStream:output_type -> seatunnel.agent.v1.LogStreamResponse
-	6,  // 26: seatunnel.agent.v1.AgentService.GetDiagnosticsLogCursors:output_type -> seatunnel.agent.v1.DiagnosticsCursorResponse
-	22, // [22:27] is the sub-list for method output_type
-	17, // [17:22] is the sub-list for method input_type
-	17, // [17:17] is the sub-list for extension type_name
-	17, // [17:17] is the sub-list for extension extendee
-	0,  // [0:17] is the sub-list for field type_name
+	16, // 5: seatunnel.agent.v1.HeartbeatRequest.processes:type_name -> seatunnel.agent.v1.ProcessStatus
+	13, // 6: seatunnel.agent.v1.HeartbeatRequest.plugin_cache:type_name -> seatunnel.agent.v1.PluginCacheStats
+	14, // 7: seatunnel.agent.v1.HeartbeatRequest.seatunnel_inventory:type_name -> seatunnel.agent.v1.SeaTunnelInventory
+	15, // 8: seatunnel.agent.v1.SeaTunnelInventory.installs:type_name -> seatunnel.agent.v1.SeaTunnelInstall
+	0,  // 9: seatunnel.agent.v1.CommandRequest.type:type_name -> seatunnel.agent.v1.CommandType
+	46, // 10: seatunnel.agent.v1.CommandRequest.parameters:type_name -> seatunnel.agent.v1.CommandRequest.ParametersEntry
+	47, // 11: seatunnel.agent.v1.CommandRequest.metadata:type_name -> seatunnel.agent.v1.CommandRequest.MetadataEntry
+	1,  // 12: seatunnel.agent.v1.CommandResponse.status:type_name -> seatunnel.agent.v1.CommandStatus
+	2,  // 13: seatunnel.agent.v1.LogEntry.level:type_name -> seatunnel.agent.v1.LogLevel
+	48, // 14: seatunnel.agent.v1.LogEntry.fields:type_name -> seatunnel.agent.v1.LogEntry.FieldsEntry
+	29, // 15: seatunnel.agent.v1.ListInstalledPluginsResponse.plugins:type_name -> seatunnel.agent.v1.InstalledPluginInfo
+	41, // 16: seatunnel.agent.v1.DiscoveredClusterInfo.nodes:type_name -> seatunnel.agent.v1.DiscoveredNodeInfo
+	49, // 17: seatunnel.agent.v1.DiscoveredClusterInfo.config:type_name -> seatunnel.agent.v1.DiscoveredClusterInfo.ConfigEntry
+	40, // 18: seatunnel.agent.v1.DiscoverClustersResponse.clusters:type_name -> seatunnel.agent.v1.DiscoveredClusterInfo
+	3,  // 19: seatunnel.agent.v1.ProcessEventReport.event_type:type_name -> seatunnel.agent.v1.ProcessEventType
+	50, // 20: seatunnel.agent.v1.ProcessEventReport.details:type_name -> seatunnel.agent.v1.ProcessEventReport.DetailsEntry
+	7,  // 21: seatunnel.agent.v1.AgentService.Register:input_type -> seatunnel.agent.v1.RegisterRequest
+	11, // 22: seatunnel.agent.v1.AgentService.Heartbeat:input_type -> seatunnel.agent.v1.HeartbeatRequest
+	19, // 23: seatunnel.agent.v1.AgentService.CommandStream:input_type -> seatunnel.agent.v1.CommandResponse
+	20, // 24: seatunnel.agent.v1.AgentService.LogStream:input_type -> seatunnel.agent.v1.LogEntry
+	4,  // 25: seatunnel.agent.v1.AgentService.GetDiagnosticsLogCursors:input_type -> seatunnel.agent.v1.DiagnosticsCursorRequest
+	33, // 26: seatunnel.agent.v1.AgentService.FetchFile:input_type -> seatunnel.agent.v1.FetchFileRequest
+	9,  // 27: seatunnel.agent.v1.AgentService.Register:output_type -> seatunnel.agent.v1.RegisterResponse
+	17, // 28: seatunnel.agent.v1.AgentService.Heartbeat:output_type -> seatunnel.agent.v1.HeartbeatResponse
+	18, // 29: seatunnel.agent.v1.AgentService.CommandStream:output_type -> seatunnel.agent.v1.CommandRequest
+	21, // 30: seatunnel.agent.v1.AgentService.LogStream:output_type -> seatunnel.agent.v1.LogStreamResponse
+	6,  // 31: seatunnel.agent.v1.AgentService.GetDiagnosticsLogCursors:output_type -> seatunnel.agent.v1.DiagnosticsCursorResponse
+	34, // 32: seatunnel.agent.v1.AgentService.FetchFile:output_type -> seatunnel.agent.v1.FileChunk
+	27, // [27:33] is the sub-list for method output_type
+	21, // [21:27] is the sub-list for method input_type
+	21, // [21:21] is the sub-list for extension type_name
+	21, // [21:21] is the sub-list for extension extendee
+	0,  // [0:21] is the sub-list for field type_name
 }
 
 func init() { file_internal_proto_agent_agent_proto_init() }
@@ -3307,7 +3845,7 @@ func file_internal_proto_agent_agent_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_internal_proto_agent_agent_proto_rawDesc), len(file_internal_proto_agent_agent_proto_rawDesc)),
 			NumEnums:      4,
-			NumMessages:   41,
+			NumMessages:   47,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -27,6 +27,7 @@ const (
 	AgentService_CommandStream_FullMethodName            = "/seatunnel.agent.v1.AgentService/CommandStream"
 	AgentService_LogStream_FullMethodName                = "/seatunnel.agent.v1.AgentService/LogStream"
 	AgentService_GetDiagnosticsLogCursors_FullMethodName = "/seatunnel.agent.v1.AgentService/GetDiagnosticsLogCursors"
+	AgentService_FetchFile_FullMethodName                = "/seatunnel.agent.v1.AgentService/FetchFile"
 )
 
 // AgentServiceClient is the client API for AgentService service.
@@ -46,6 +47,8 @@ type AgentServiceClient interface {
 	LogStream(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[LogEntry, LogStreamResponse], error)
 	// 诊断日志游标查询 - Agent 启动时从 Control Plane 拉取历史游标，避免重复采集尾部错误
 	GetDiagnosticsLogCursors(ctx context.Context, in *DiagnosticsCursorRequest, opts ...grpc.CallOption) (*DiagnosticsCursorResponse, error)
+	// 文件流 - Agent 按传输 ID 从 Control Plane 拉取原始文件字节（可选 zstd 压缩），替代 base64 分块指令
+	FetchFile(ctx context.Context, in *FetchFileRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[FileChunk], error)
 }
 
 type agentServiceClient struct {
@@ -112,6 +115,25 @@ func (c *agentServiceClient) GetDiagnosticsLogCursors(ctx context.Context, in *D
 	return out, nil
 }
 
+func (c *agentServiceClient) FetchFile(ctx context.Context, in *FetchFileRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[FileChunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &AgentService_ServiceDesc.Streams[2], AgentService_FetchFile_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[FetchFileRequest, FileChunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AgentService_FetchFileClient = grpc.ServerStreamingClient[FileChunk]
+
 // AgentServiceServer is the server API for AgentService service.
 // All implementations must embed UnimplementedAgentServiceServer
 // for forward compatibility.
@@ -129,6 +151,8 @@ type AgentServiceServer interface {
 	LogStream(grpc.ClientStreamingServer[LogEntry, LogStreamResponse]) error
 	// 诊断日志游标查询 - Agent 启动时从 Control Plane 拉取历史游标，避免重复采集尾部错误
 	GetDiagnosticsLogCursors(context.Context, *DiagnosticsCursorRequest) (*DiagnosticsCursorResponse, error)
+	// 文件流 - Agent 按传输 ID 从 Control Plane 拉取原始文件字节（可选 zstd 压缩），替代 base64 分块指令
+	FetchFile(*FetchFileRequest, grpc.ServerStreamingServer[FileChunk]) error
 	mustEmbedUnimplementedAgentServiceServer()
 }
 
@@ -154,6 +178,9 @@ func (UnimplementedAgentServiceServer) LogStream(grpc.ClientStreamingServer[LogE
 func (UnimplementedAgentServiceServer) GetDiagnosticsLogCursors(context.Context, *DiagnosticsCursorRequest) (*DiagnosticsCursorResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetDiagnosticsLogCursors not implemented")
 }
+func (UnimplementedAgentServiceServer) FetchFile(*FetchFileRequest, grpc.ServerStreamingServer[FileChunk]) error {
+	return status.Error(codes.Unimplemented, "method FetchFile not implemented")
+}
 func (UnimplementedAgentServiceServer) mustEmbedUnimplementedAgentServiceServer() {}
 func (UnimplementedAgentServiceServer) testEmbeddedByValue()                      {}
 
@@ -243,6 +270,17 @@ func _AgentService_GetDiagnosticsLogCursors_Handler(srv interface{}, ctx context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AgentService_FetchFile_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(FetchFileRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AgentServiceServer).FetchFile(m, &grpc.GenericServerStream[FetchFileRequest, FileChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AgentService_FetchFileServer = grpc.ServerStreamingServer[FileChunk]
+
 // AgentService_ServiceDesc is the grpc.ServiceDesc for AgentService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -275,6 +313,11 @@ var AgentService_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _AgentService_LogStream_Handler,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "FetchFile",
+			Handler:       _AgentService_FetchFile_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "internal/proto/agent/agent.proto",
 }
@@ -181,6 +181,7 @@ func genCommandStatus() gopter.Gen {
 		CommandStatus_SUCCESS,
 		CommandStatus_FAILED,
 		CommandStatus_CANCELLED,
+		CommandStatus_TIMEOUT,
 	)
 }
 
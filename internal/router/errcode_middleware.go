@@ -0,0 +1,116 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package router
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/seatunnel/seatunnelX/internal/apps/response"
+	"github.com/seatunnel/seatunnelX/internal/pkg/errcode"
+)
+
+// errorCodeMiddleware adds an error_code field to JSON error bodies that carry error_msg but no code.
+// The code is the one a handler attached with errcode.Attach, else the first coded error in c.Errors,
+// else the general code for the HTTP status.
+// errorCodeMiddleware 为携带 error_msg 但没有错误码的 JSON 错误响应补充 error_code 字段。
+// 错误码优先取处理器通过 errcode.Attach 记录的值，其次取 c.Errors 中带错误码的错误，最后按 HTTP 状态码取通用错误码。
+func errorCodeMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		writer := &errorCodeWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+
+		c.Next()
+
+		c.Writer = writer.ResponseWriter
+		if writer.buffering {
+			body := injectErrorCode(writer.body.Bytes(), requestErrorCode(c, writer.Status()))
+			_, _ = writer.ResponseWriter.Write(body)
+		}
+	}
+}
+
+// errorCodeWriter buffers JSON error bodies so the middleware can add the error code before they are sent.
+// errorCodeWriter 缓存 JSON 错误响应体，以便中间件在发送前补充错误码。
+type errorCodeWriter struct {
+	gin.ResponseWriter
+	body      bytes.Buffer
+	buffering bool
+}
+
+func (w *errorCodeWriter) Write(data []byte) (int, error) {
+	if w.shouldBuffer() {
+		return w.body.Write(data)
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *errorCodeWriter) WriteString(s string) (int, error) {
+	if w.shouldBuffer() {
+		return w.body.WriteString(s)
+	}
+	return w.ResponseWriter.WriteString(s)
+}
+
+func (w *errorCodeWriter) shouldBuffer() bool {
+	if w.buffering {
+		return true
+	}
+	if w.ResponseWriter.Written() || w.Status() < http.StatusBadRequest {
+		return false
+	}
+	if !strings.HasPrefix(w.Header().Get("Content-Type"), "application/json") {
+		return false
+	}
+	w.buffering = true
+	return true
+}
+
+// requestErrorCode resolves the error code of a failed request.
+// requestErrorCode 解析失败请求的错误码。
+func requestErrorCode(c *gin.Context, status int) errcode.Code {
+	return response.ErrorCode(c, status)
+}
+
+// injectErrorCode returns body with error_code set, or body unchanged when it is not an error object or already has a code.
+// injectErrorCode 返回设置了 error_code 的响应体；若不是错误对象或已有错误码则原样返回。
+func injectErrorCode(body []byte, code errcode.Code) []byte {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return body
+	}
+	if _, ok := fields["error_msg"]; !ok {
+		return body
+	}
+	if existing, ok := fields["error_code"]; ok && string(existing) != `""` && string(existing) != "null" {
+		return body
+	}
+	encoded, err := json.Marshal(code)
+	if err != nil {
+		return body
+	}
+	fields["error_code"] = encoded
+	out, err := json.Marshal(fields)
+	if err != nil {
+		return body
+	}
+	return out
+}
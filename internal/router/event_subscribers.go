@@ -0,0 +1,85 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package router
+
+import (
+	"context"
+
+	"github.com/seatunnel/seatunnelX/internal/apps/audit"
+	"github.com/seatunnel/seatunnelX/internal/pkg/eventbus"
+)
+
+// registerEventSubscribers subscribes the notification and audit modules to typed events emitted
+// by the agent, installer, cluster and plugin modules. It must run once per process because the
+// event bus is process-wide.
+// registerEventSubscribers 将通知与审计模块订阅到 Agent、安装器、集群和插件模块发出的类型化事件。
+// 事件总线是进程级的，因此每个进程只能调用一次。
+func registerEventSubscribers(publisher *platformEventPublisher, auditRepo *audit.Repository) {
+	// Notifications 通知
+	eventbus.On(publisher.onAgentOffline)
+	eventbus.On(publisher.onInstallCompleted)
+
+	// Audit 审计
+	eventbus.On(func(ctx context.Context, event eventbus.AgentRegistered) {
+		_ = auditRepo.CreateAuditLog(ctx, &audit.AuditLog{
+			Action:       "register",
+			ResourceType: "agent",
+			ResourceID:   event.AgentID,
+			ResourceName: event.Hostname,
+			Trigger:      "auto",
+			Details: audit.AuditDetails{
+				"host_id":    event.HostID,
+				"ip_address": event.IPAddress,
+				"version":    event.Version,
+			},
+		})
+	})
+	eventbus.On(func(ctx context.Context, event eventbus.NodeCrashed) {
+		_ = auditRepo.CreateAuditLog(ctx, &audit.AuditLog{
+			Action:       "crash",
+			ResourceType: "cluster_node",
+			ResourceID:   audit.UintID(event.NodeID),
+			ResourceName: event.ClusterName,
+			Trigger:      "auto",
+			Details: audit.AuditDetails{
+				"cluster_id": event.ClusterID,
+				"host_id":    event.HostID,
+				"role":       event.Role,
+				"failures":   event.Failures,
+				"last_error": event.Error,
+			},
+		})
+	})
+	eventbus.On(func(ctx context.Context, event eventbus.PluginInstalled) {
+		if event.Success {
+			return
+		}
+		_ = auditRepo.CreateAuditLog(ctx, &audit.AuditLog{
+			Action:       "install_failed",
+			ResourceType: "plugin",
+			ResourceID:   audit.UintID(event.ClusterID) + "/" + event.PluginName,
+			ResourceName: event.PluginName,
+			Trigger:      "auto",
+			Details: audit.AuditDetails{
+				"cluster_id": event.ClusterID,
+				"version":    event.Version,
+				"error":      event.Error,
+			},
+		})
+	})
+}
@@ -0,0 +1,74 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package router
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/glebarez/sqlite"
+	"github.com/seatunnel/seatunnelX/internal/apps/audit"
+	"github.com/seatunnel/seatunnelX/internal/pkg/eventbus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestRegisterEventSubscribersRecordsAuditLogs(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "audit.db")), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	if err := db.AutoMigrate(&audit.AuditLog{}); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	auditRepo := audit.NewRepository(db)
+	registerEventSubscribers(&platformEventPublisher{}, auditRepo)
+
+	ctx := context.Background()
+	eventbus.Emit(ctx, eventbus.AgentRegistered{AgentID: "agent-1", Hostname: "node-1", HostID: 3})
+	eventbus.Emit(ctx, eventbus.NodeCrashed{ClusterID: 1, ClusterName: "prod", NodeID: 7, Error: "probe failed"})
+	eventbus.Emit(ctx, eventbus.PluginInstalled{ClusterID: 1, PluginName: "jdbc", Success: true})
+	eventbus.Emit(ctx, eventbus.PluginInstalled{ClusterID: 1, PluginName: "kafka", Error: "transfer failed"})
+	eventbus.Emit(ctx, eventbus.InstallCompleted{HostID: "3", Error: "download failed"})
+	eventbus.Emit(ctx, eventbus.AgentOffline{AgentID: "agent-1", Status: "offline"})
+
+	logs, total, err := auditRepo.ListAuditLogs(ctx, &audit.AuditLogFilter{})
+	if err != nil {
+		t.Fatalf("list audit logs: %v", err)
+	}
+	if total != 3 {
+		t.Fatalf("expected 3 audit logs, got %d", total)
+	}
+	got := map[string]string{}
+	for _, log := range logs {
+		got[log.ResourceType+"/"+log.Action] = log.ResourceID
+	}
+	want := map[string]string{
+		"agent/register":        "agent-1",
+		"cluster_node/crash":    "7",
+		"plugin/install_failed": "1/kafka",
+	}
+	for key, resourceID := range want {
+		if got[key] != resourceID {
+			t.Fatalf("audit logs %v, want %s=%s", got, key, resourceID)
+		}
+	}
+}
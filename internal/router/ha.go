@@ -0,0 +1,82 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package router
+
+import (
+	"context"
+	"time"
+
+	"github.com/seatunnel/seatunnelX/internal/apps/agent"
+	"github.com/seatunnel/seatunnelX/internal/apps/cluster"
+	"github.com/seatunnel/seatunnelX/internal/apps/ha"
+	"github.com/seatunnel/seatunnelX/internal/apps/host"
+	"github.com/seatunnel/seatunnelX/internal/config"
+	"github.com/seatunnel/seatunnelX/internal/db"
+	"github.com/seatunnel/seatunnelX/internal/logger"
+	"github.com/seatunnel/seatunnelX/internal/pkg/eventbus"
+	"github.com/seatunnel/seatunnelX/internal/pkg/leader"
+)
+
+// initHANode joins this process to the Control Plane replica set: Agent ownership is shared through
+// the database, commands for Agents connected elsewhere are forwarded to their replica, and singleton
+// background jobs only run on the lease holder.
+// initHANode 将当前进程加入 Control Plane 副本集合：Agent 归属通过数据库共享，
+// 连接在其他副本上的 Agent 的命令会转发到对应副本，单例后台任务仅在租约持有者上运行。
+func initHANode(ctx context.Context, agentManager *agent.Manager) *ha.Node {
+	haConfig := config.GetHAConfig()
+	node := ha.NewNode(db.DB(ctx), ha.Config{
+		InstanceID:    haConfig.InstanceID,
+		AdvertiseAddr: haConfig.AdvertiseAddr,
+		APIPrefix:     config.Config.App.APIPrefix,
+		SharedSecret:  config.GetHASharedSecret(),
+		LeaseTTL:      time.Duration(haConfig.LeaseTTLSeconds) * time.Second,
+	})
+
+	hostService := host.NewService(host.NewRepository(db.DB(ctx)), cluster.NewRepository(db.DB(ctx)), &host.ServiceConfig{
+		HeartbeatTimeout: time.Duration(config.GetGRPCConfig().HeartbeatTimeout) * time.Second,
+	})
+	hostUpdater := &hostStatusUpdaterAdapter{hostService: hostService}
+	node.SetOnOrphanedAgents(func(ctx context.Context, agentIDs []string) {
+		for _, agentID := range agentIDs {
+			h, err := hostService.GetByAgentID(ctx, agentID)
+			if err != nil {
+				continue
+			}
+			if err := hostUpdater.MarkHostOffline(ctx, agentID); err != nil {
+				logger.WarnF(ctx, "[HA] mark host of orphaned agent %s offline failed: %v", agentID, err)
+				continue
+			}
+			eventbus.Emit(ctx, eventbus.AgentOffline{
+				AgentID:   agentID,
+				HostID:    h.ID,
+				IPAddress: h.IPAddress,
+				Hostname:  h.Name,
+				Status:    string(agent.AgentStatusOffline),
+			})
+		}
+	})
+
+	if agentManager != nil {
+		agentManager.SetOwnershipRegistry(node)
+		agentManager.SetCommandForwarder(node)
+	}
+	leader.SetChecker(node.IsLeader)
+	node.Start(ctx)
+	logger.InfoF(ctx, "[HA] control plane instance %s joined, leader=%t", node.InstanceID(), node.IsLeader())
+	return node
+}
@@ -0,0 +1,60 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package router
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/seatunnel/seatunnelX/internal/apps/auth"
+	"github.com/seatunnel/seatunnelX/internal/config"
+	"github.com/seatunnel/seatunnelX/internal/pkg/ratelimitx"
+)
+
+// rateLimitMiddleware limits one group of endpoints per client IP and, for logged-in sessions,
+// per user. Rejected requests get 429 with Retry-After in seconds.
+// rateLimitMiddleware 按客户端 IP 以及（已登录会话的）用户对一组接口限流。
+// 被拒绝的请求返回 429，并在 Retry-After 中给出秒数。
+func rateLimitMiddleware(rule config.RateLimitRule) gin.HandlerFunc {
+	perIP := ratelimitx.New(rule.PerIP, time.Minute)
+	perUser := ratelimitx.New(rule.PerUser, time.Minute)
+	if !perIP.Enabled() && !perUser.Enabled() {
+		return func(c *gin.Context) { c.Next() }
+	}
+
+	return func(c *gin.Context) {
+		ok, retryAfter := perIP.Allow(c.ClientIP())
+		if ok && perUser.Enabled() {
+			if userID := auth.GetUserIDFromContext(c); userID != 0 {
+				ok, retryAfter = perUser.Allow(strconv.FormatUint(userID, 10))
+			}
+		}
+		if !ok {
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{
+				"error_msg": "Too many requests, please retry later / 请求过于频繁，请稍后重试",
+				"data":      nil,
+			})
+			return
+		}
+		c.Next()
+	}
+}
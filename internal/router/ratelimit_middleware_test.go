@@ -0,0 +1,102 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/seatunnel/seatunnelX/internal/config"
+)
+
+func TestRateLimitMiddlewareReturnsRetryAfter(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.POST("/login", rateLimitMiddleware(config.RateLimitRule{PerIP: 2}), func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	send := func(ip string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/login", nil)
+		req.RemoteAddr = ip + ":40000"
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	for i := 0; i < 2; i++ {
+		if w := send("10.0.0.1"); w.Code != http.StatusOK {
+			t.Fatalf("request %d: expected 200, got %d", i+1, w.Code)
+		}
+	}
+	w := send("10.0.0.1")
+	if w.Code != http.StatusTooManyRequests || w.Header().Get("Retry-After") != "30" {
+		t.Fatalf("expected 429 with Retry-After 30, got %d %q", w.Code, w.Header().Get("Retry-After"))
+	}
+	if w := send("10.0.0.2"); w.Code != http.StatusOK {
+		t.Fatalf("expected other IPs to be unaffected, got %d", w.Code)
+	}
+}
+
+func TestRateLimitMiddlewareIgnoresForwardedForFromUntrustedPeers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	send := func(r *gin.Engine, forwardedFor string) int {
+		req := httptest.NewRequest(http.MethodPost, "/login", nil)
+		req.RemoteAddr = "10.0.0.1:40000"
+		req.Header.Set("X-Forwarded-For", forwardedFor)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	untrusted := gin.New()
+	if err := untrusted.SetTrustedProxies(nil); err != nil {
+		t.Fatal(err)
+	}
+	untrusted.POST("/login", rateLimitMiddleware(config.RateLimitRule{PerIP: 1}), func(c *gin.Context) { c.Status(http.StatusOK) })
+	if code := send(untrusted, "203.0.113.1"); code != http.StatusOK {
+		t.Fatalf("expected first request to pass, got %d", code)
+	}
+	if code := send(untrusted, "203.0.113.2"); code != http.StatusTooManyRequests {
+		t.Fatalf("expected a rotated X-Forwarded-For to share the peer's bucket, got %d", code)
+	}
+
+	proxied := gin.New()
+	if err := proxied.SetTrustedProxies([]string{"10.0.0.1"}); err != nil {
+		t.Fatal(err)
+	}
+	proxied.POST("/login", rateLimitMiddleware(config.RateLimitRule{PerIP: 1}), func(c *gin.Context) { c.Status(http.StatusOK) })
+	for _, client := range []string{"203.0.113.1", "203.0.113.2"} {
+		if code := send(proxied, client); code != http.StatusOK {
+			t.Fatalf("expected clients behind a trusted proxy to get their own bucket, got %d", code)
+		}
+	}
+}
+
+func TestRateLimitMiddlewareDisabled(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.POST("/install", rateLimitMiddleware(config.RateLimitRule{PerIP: -1, PerUser: -1}), func(c *gin.Context) { c.Status(http.StatusOK) })
+	for i := 0; i < 50; i++ {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/install", nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected disabled limiter to allow request %d, got %d", i+1, w.Code)
+		}
+	}
+}
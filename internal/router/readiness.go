@@ -0,0 +1,73 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package router
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/seatunnel/seatunnelX/internal/apps/health"
+	"github.com/seatunnel/seatunnelX/internal/db"
+	grpcServer "github.com/seatunnel/seatunnelX/internal/grpc"
+	"github.com/seatunnel/seatunnelX/internal/session"
+)
+
+const readinessSessionProbeKey = "seatunnelx:readiness-probe"
+
+// registerReadinessChecks registers the dependencies reported by /readyz: the database, the session
+// store and, when enabled, the gRPC listener Agents connect to. Background workers report themselves.
+// registerReadinessChecks 注册 /readyz 检查的依赖：数据库、会话存储，以及启用时 Agent 连接的 gRPC 监听端口。
+// 后台任务会自行上报状态。
+func registerReadinessChecks(grpcSrv *grpcServer.Server) {
+	health.RegisterCheck("database", func(ctx context.Context) error {
+		gormDB := db.GetGlobalDB()
+		if gormDB == nil {
+			return errors.New("database is not initialized")
+		}
+		sqlDB, err := gormDB.DB()
+		if err != nil {
+			return err
+		}
+		return sqlDB.PingContext(ctx)
+	})
+
+	health.RegisterCheck("session_store", func(ctx context.Context) error {
+		if session.Store == nil {
+			return errors.New("session store is not initialized")
+		}
+		if err := session.Store.Set(ctx, readinessSessionProbeKey, time.Now().Unix(), time.Minute); err != nil {
+			return err
+		}
+		_, err := session.Store.Get(ctx, readinessSessionProbeKey)
+		return err
+	})
+
+	if grpcSrv != nil {
+		health.RegisterCheck("grpc_server", func(ctx context.Context) error {
+			var dialer net.Dialer
+			conn, err := dialer.DialContext(ctx, "tcp", fmt.Sprintf("127.0.0.1:%d", grpcSrv.GetPort()))
+			if err != nil {
+				return fmt.Errorf("gRPC server is not listening: %w", err)
+			}
+			return conn.Close()
+		})
+	}
+}
@@ -0,0 +1,37 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package router
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/seatunnel/seatunnelX/internal/pkg/requestid"
+)
+
+// requestIDMiddleware assigns every request an ID (reusing a well-formed X-Request-ID from the
+// client), echoes it in the response header and stores it on the request context so responses,
+// logs and audit records can be correlated.
+// requestIDMiddleware 为每个请求分配 ID（复用客户端传入的合法 X-Request-ID），在响应头中回传，
+// 并保存到请求上下文，以便关联响应、日志与审计记录。
+func requestIDMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := requestid.Resolve(c.GetHeader(requestid.Header))
+		c.Header(requestid.Header, id)
+		c.Request = c.Request.WithContext(requestid.WithContext(c.Request.Context(), id))
+		c.Next()
+	}
+}
@@ -23,10 +23,14 @@ import (
 	"context"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
+	"net/http"
+	"os/signal"
 	"strconv"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/gin-contrib/sessions"
@@ -36,15 +40,20 @@ import (
 	"github.com/seatunnel/seatunnelX/internal/apps/agent"
 	"github.com/seatunnel/seatunnelX/internal/apps/audit"
 	"github.com/seatunnel/seatunnelX/internal/apps/auth"
+	"github.com/seatunnel/seatunnelX/internal/apps/backup"
 	"github.com/seatunnel/seatunnelX/internal/apps/cluster"
+	"github.com/seatunnel/seatunnelX/internal/apps/clustermetrics"
 	appconfig "github.com/seatunnel/seatunnelX/internal/apps/config"
 	"github.com/seatunnel/seatunnelX/internal/apps/dashboard"
 	"github.com/seatunnel/seatunnelX/internal/apps/deepwiki"
 	"github.com/seatunnel/seatunnelX/internal/apps/diagnostics"
 	"github.com/seatunnel/seatunnelX/internal/apps/discovery"
+	"github.com/seatunnel/seatunnelX/internal/apps/ha"
 	"github.com/seatunnel/seatunnelX/internal/apps/health"
 	"github.com/seatunnel/seatunnelX/internal/apps/host"
+	"github.com/seatunnel/seatunnelX/internal/apps/host/sshdeploy"
 	"github.com/seatunnel/seatunnelX/internal/apps/installer"
+	"github.com/seatunnel/seatunnelX/internal/apps/metadata"
 	"github.com/seatunnel/seatunnelX/internal/apps/monitor"
 	monitoringapp "github.com/seatunnel/seatunnelX/internal/apps/monitoring"
 	"github.com/seatunnel/seatunnelX/internal/apps/oauth"
@@ -57,6 +66,12 @@ import (
 	"github.com/seatunnel/seatunnelX/internal/db"
 	grpcServer "github.com/seatunnel/seatunnelX/internal/grpc"
 	"github.com/seatunnel/seatunnelX/internal/otel_trace"
+	"github.com/seatunnel/seatunnelX/internal/pkg/errcode"
+	"github.com/seatunnel/seatunnelX/internal/pkg/eventbus"
+	"github.com/seatunnel/seatunnelX/internal/pkg/filestream"
+	"github.com/seatunnel/seatunnelX/internal/pkg/httpdrain"
+	"github.com/seatunnel/seatunnelX/internal/pkg/objstore"
+	"github.com/seatunnel/seatunnelX/internal/pkg/tlsx"
 	pb "github.com/seatunnel/seatunnelX/internal/proto/agent"
 	"github.com/seatunnel/seatunnelX/internal/session"
 	swaggerFiles "github.com/swaggo/files"
@@ -92,26 +107,41 @@ func Serve() {
 	var agentManager *agent.Manager
 	if config.IsGRPCEnabled() {
 		grpcSrv, agentManager = initGRPCServer(ctx)
-		if grpcSrv != nil {
-			defer grpcSrv.Stop()
-		}
-		if agentManager != nil {
-			defer agentManager.Stop()
-		}
 	} else {
 		log.Println("[API] gRPC 服务器已禁用 / gRPC server is disabled")
 	}
 
+	// 控制面高可用（多副本共享 Agent 归属、命令转发与 leader 选举）
+	// Control Plane high availability (shared Agent ownership, command forwarding and leader election)
+	var haNode *ha.Node
+	if config.GetHAConfig().Enabled {
+		haNode = initHANode(ctx, agentManager)
+	}
+
 	// 初始化路由
 	// Initialize router
 	r := gin.New()
-	r.Use(gin.Recovery())
+	// Only configured reverse proxies may set the client IP used by rate limits, sessions and audit logs
+	// 仅配置的反向代理可以设置用于限流、会话与审计日志的客户端 IP
+	if err := r.SetTrustedProxies(config.Config.App.TrustedProxies); err != nil {
+		log.Fatalf("[API] 配置可信代理失败 / Failed to configure trusted proxies: %v\n", err)
+	}
+	r.Use(gin.Recovery(), requestIDMiddleware())
+	if cors := config.Config.App.CORS; len(cors.AllowedOrigins) > 0 {
+		r.Use(corsMiddleware(cors, config.Config.App.CSRF.HeaderName))
+	}
+
+	// 存活与就绪探针（注册在会话与日志中间件之前，避免探针请求刷屏日志）
+	// Liveness and readiness probes (registered before the session and logging middleware to keep probes out of the logs)
+	r.GET("/healthz", health.Healthz)
+	r.GET("/readyz", health.Readyz)
 
 	// 初始化会话存储（默认使用内存会话）
 	// Initialize session store (uses in-memory sessions by default)
 	if err := session.InitSessionStore(); err != nil {
 		log.Fatalf("[API] 初始化会话存储失败: %v\n", err)
 	}
+	registerReadinessChecks(grpcSrv)
 	r.Use(sessions.Sessions(config.Config.App.SessionCookieName, session.GinStore))
 
 	// 初始化 OAuth 提供商（GitHub、Google）
@@ -120,7 +150,28 @@ func Serve() {
 
 	// 补充中间件
 	// Add middleware
-	r.Use(otelgin.Middleware(config.Config.App.AppName), loggerMiddleware())
+	r.Use(otelgin.Middleware(config.Config.App.AppName), loggerMiddleware(), errorCodeMiddleware())
+	if tlsConfig := config.Config.App.TLS; tlsConfig.Enabled && tlsConfig.HSTSMaxAge > 0 {
+		r.Use(hstsMiddleware(tlsConfig))
+	}
+	if appConfig := config.Config.App; appConfig.CSRF.Enabled {
+		// 嵌入式 UI 代理（Grafana、SeaTunnel Web UI）自行发起写请求，无法携带 CSRF 请求头
+		// Embedded UI proxies (Grafana, SeaTunnel Web UI) issue their own writes without the CSRF header
+		apiV1Prefix := strings.TrimSuffix(appConfig.APIPrefix, "/") + "/v1"
+		r.Use(csrfMiddleware(appConfig.CSRF, appConfig.SessionCookieName, appConfig.SessionSecure || appConfig.TLS.Enabled,
+			apiV1Prefix+"/monitoring/proxy/grafana",
+			apiV1Prefix+"/clusters/:id/webui",
+			apiV1Prefix+"/clusters/:id/webui/*proxyPath",
+		))
+	}
+
+	// 敏感接口限流（按 IP 与用户）
+	// Rate limits of sensitive endpoints (per IP and per user)
+	rateLimits := config.Config.App.RateLimit
+	loginRateLimit := rateLimitMiddleware(rateLimits.Login)
+	uploadRateLimit := rateLimitMiddleware(rateLimits.PackageUpload)
+	installRateLimit := rateLimitMiddleware(rateLimits.Installation)
+	commandRateLimit := rateLimitMiddleware(rateLimits.Command)
 
 	apiGroup := r.Group(config.Config.App.APIPrefix)
 	{
@@ -129,6 +180,12 @@ func Serve() {
 			apiGroup.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 		}
 
+		// 副本间命令转发（共享密钥认证）
+		// Command forwarding between replicas (authenticated by the shared secret)
+		if haNode != nil && agentManager != nil {
+			apiGroup.POST(ha.ForwardPath, haNode.CommandHandler(agentManager))
+		}
+
 		// API V1
 		apiV1Router := apiGroup.Group("/v1")
 		{
@@ -136,15 +193,18 @@ func Serve() {
 			apiV1Router.GET("/health", health.Health)
 
 			// Auth（统一认证接口，支持密码登录和 OAuth 登录）
-			apiV1Router.POST("/auth/login", auth.Login)
+			apiV1Router.POST("/auth/login", loginRateLimit, auth.Login)
 			apiV1Router.POST("/auth/logout", auth.LoginRequired(), auth.Logout)
 			apiV1Router.GET("/auth/user-info", auth.LoginRequired(), auth.GetUserInfo)
 			apiV1Router.PUT("/auth/profile", auth.LoginRequired(), auth.UpdateProfile)
+			apiV1Router.GET("/auth/sessions", auth.LoginRequired(), auth.ListMySessions)
+			apiV1Router.POST("/auth/sessions/logout-others", auth.LoginRequired(), auth.LogoutOtherSessions)
+			apiV1Router.DELETE("/auth/sessions/:id", auth.LoginRequired(), auth.RevokeMySession)
 
 			// OAuth（备选登录方式：GitHub、Google）
 			apiV1Router.GET("/oauth/providers", oauth.GetEnabledProvidersHandler)
 			apiV1Router.GET("/oauth/login", oauth.GetLoginURL)
-			apiV1Router.POST("/oauth/callback", oauth.Callback)
+			apiV1Router.POST("/oauth/callback", loginRateLimit, oauth.Callback)
 
 			// Admin
 			adminRouter := apiV1Router.Group("/admin")
@@ -158,6 +218,51 @@ func Serve() {
 					userAdminRouter.GET("/:id", admin.GetUserHandler)
 					userAdminRouter.PUT("/:id", admin.UpdateUserHandler)
 					userAdminRouter.DELETE("/:id", admin.DeleteUserHandler)
+					userAdminRouter.GET("/:id/role-bindings", admin.ListRoleBindingsHandler)
+					userAdminRouter.PUT("/:id/role-bindings", admin.ReplaceRoleBindingsHandler)
+					userAdminRouter.DELETE("/:id/sessions", admin.RevokeUserSessionsHandler)
+				}
+
+				// Project 项目（租户）管理与资源归属转移
+				// Project (tenant) management and ownership transfer
+				projectAdminRouter := adminRouter.Group("/projects")
+				{
+					projectAdminRouter.GET("", admin.ListProjectsHandler)
+					projectAdminRouter.POST("", admin.CreateProjectHandler)
+					projectAdminRouter.DELETE("/:id", admin.DeleteProjectHandler)
+					projectAdminRouter.GET("/:id/members", admin.ListProjectMembersHandler)
+					projectAdminRouter.PUT("/:id/members", admin.ReplaceProjectMembersHandler)
+				}
+				adminRouter.POST("/ownership/transfer", admin.TransferOwnershipHandler)
+
+				// Session 活跃会话查看与强制下线
+				adminRouter.GET("/sessions", admin.ListSessionsHandler)
+				adminRouter.DELETE("/sessions/:id", admin.RevokeSessionHandler)
+				if haNode != nil {
+					adminRouter.GET("/ha/instances", haNode.ListInstances)
+				}
+
+				// gRPC 服务器运行时状态（Agent 命令流、待处理命令、心跳往返耗时）
+				// gRPC server runtime state (Agent command streams, pending commands, heartbeat round-trip time)
+				if grpcSrv != nil {
+					adminRouter.GET("/grpc/runtime", grpcSrv.GetRuntimeStats)
+				}
+
+				// Agent 准入控制（审批与拒绝列表）
+				// Agent admission control (approvals and deny-list)
+				if agentManager != nil {
+					admissionHandler := agent.NewAdmissionHandler(agentManager, audit.NewRepository(db.DB(context.Background())))
+					agentAdminRouter := adminRouter.Group("/agents")
+					agentAdminRouter.GET("/pending", admissionHandler.ListPendingAgents)
+					agentAdminRouter.POST("/pending/:agentId/approve", admissionHandler.ApproveAgent)
+					agentAdminRouter.GET("/deny-list", admissionHandler.ListDenyRules)
+					agentAdminRouter.POST("/deny-list", admissionHandler.AddDenyRule)
+					agentAdminRouter.DELETE("/deny-list/:id", admissionHandler.RemoveDenyRule)
+
+					// Agent 运行期配置热加载
+					// Agent runtime config hot-reload
+					configHandler := agent.NewConfigHandler(agentManager, audit.NewRepository(db.DB(context.Background())))
+					agentAdminRouter.PUT("/:agentId/config", configHandler.UpdateAgentConfig)
 				}
 			}
 
@@ -167,21 +272,54 @@ func Serve() {
 			hostRepo := host.NewRepository(db.DB(context.Background()))
 			clusterRepo := cluster.NewRepository(db.DB(context.Background()))
 			auditRepo := audit.NewRepository(db.DB(context.Background()))
+			recycleRetention := time.Duration(config.Config.RecycleBin.RetentionHours) * time.Hour
+			recyclePurgeInterval := time.Duration(config.Config.RecycleBin.PurgeIntervalMinutes) * time.Minute
 			hostService := host.NewService(hostRepo, clusterRepo, &host.ServiceConfig{
-				HeartbeatTimeout: time.Duration(config.Config.GRPC.HeartbeatTimeout) * time.Second,
-				ControlPlaneAddr: config.GetExternalURL(),
+				HeartbeatTimeout:     time.Duration(config.Config.GRPC.HeartbeatTimeout) * time.Second,
+				ControlPlaneAddr:     config.GetExternalURL(),
+				RecycleRetention:     recycleRetention,
+				RecyclePurgeInterval: recyclePurgeInterval,
+				EnrollmentTokenTTL:   time.Duration(config.Config.GRPC.EnrollmentTokenTTL) * time.Minute,
 			})
 			hostHandler := host.NewHandler(hostService, auditRepo)
 
+			// Host recycle bin: record an audit event for every host removed by the purge job
+			// 主机回收站：清理任务每删除一个主机记录一条审计事件
+			hostService.SetOnHostPurged(func(ctx context.Context, purged *host.Host, err error) {
+				details := audit.AuditDetails{"deleted_at": purged.DeletedAt.Time}
+				if err != nil {
+					details["error"] = err.Error()
+				}
+				_ = auditRepo.CreateAuditLog(ctx, &audit.AuditLog{
+					Action:       "purge",
+					ResourceType: "host",
+					ResourceID:   audit.UintID(purged.ID),
+					ResourceName: purged.Name,
+					Trigger:      "auto",
+					Details:      details,
+				})
+			})
+			hostService.StartRecyclePurger(ctx)
+
 			hostRouter := apiV1Router.Group("/hosts")
-			hostRouter.Use(auth.LoginRequired())
+			hostRouter.Use(auth.LoginRequired(), auth.RBAC(auth.ResourceGlobal, ""), auth.TenantGuard(auth.ResourceHost, "id"))
 			{
 				hostRouter.POST("", hostHandler.CreateHost)
+				hostRouter.POST("/import", hostHandler.ImportHosts)
+				hostRouter.GET("/labels", hostHandler.ListLabelValues)
 				hostRouter.GET("", hostHandler.ListHosts)
+				hostRouter.GET("/recycle-bin", hostHandler.ListRecycledHosts)
+				hostRouter.DELETE("/recycle-bin/:id", hostHandler.PurgeHost)
 				hostRouter.GET("/:id", hostHandler.GetHost)
 				hostRouter.PUT("/:id", hostHandler.UpdateHost)
 				hostRouter.DELETE("/:id", hostHandler.DeleteHost)
+				hostRouter.POST("/:id/restore", hostHandler.RestoreHost)
 				hostRouter.GET("/:id/install-command", hostHandler.GetInstallCommand)
+				hostRouter.GET("/:id/labels", hostHandler.GetLabels)
+				hostRouter.PUT("/:id/labels", hostHandler.ReplaceLabels)
+				hostRouter.PATCH("/:id/labels", hostHandler.PatchLabels)
+				hostRouter.DELETE("/:id/labels/:key", hostHandler.DeleteLabel)
+				hostRouter.PUT("/:id/maintenance", hostHandler.SetMaintenance)
 			}
 
 			// Dashboard Overview 仪表盘概览
@@ -198,13 +336,16 @@ func Serve() {
 				overviewRouter.GET("/clusters", overviewHandler.GetClusterSummaries)
 				overviewRouter.GET("/hosts", overviewHandler.GetHostSummaries)
 				overviewRouter.GET("/activities", overviewHandler.GetRecentActivities)
+				overviewRouter.GET("/ws", overviewHandler.StreamOverview)
 			}
 
 			// Cluster 集群管理
 			// Initialize cluster service and handler
 			// 初始化集群服务和处理器
 			clusterService := cluster.NewService(clusterRepo, hostService, &cluster.ServiceConfig{
-				HeartbeatTimeout: time.Duration(config.Config.GRPC.HeartbeatTimeout) * time.Second,
+				HeartbeatTimeout:     time.Duration(config.Config.GRPC.HeartbeatTimeout) * time.Second,
+				RecycleRetention:     recycleRetention,
+				RecyclePurgeInterval: recyclePurgeInterval,
 			})
 
 			// Inject agent command sender if agent manager is available
@@ -218,17 +359,67 @@ func Serve() {
 				log.Println("[API] Agent command sender injected into cluster service / Agent 命令发送器已注入集群服务")
 			}
 
+			// Node health checker: record an audit event when automatic recovery is exhausted
+			// 节点健康检查：自动恢复次数耗尽时记录审计事件
+			clusterService.SetOnRecoveryEscalation(func(ctx context.Context, escalation *cluster.RecoveryEscalation) {
+				_ = auditRepo.CreateAuditLog(ctx, &audit.AuditLog{
+					Action:       "recovery_escalation",
+					ResourceType: "cluster_node",
+					ResourceID:   audit.UintID(escalation.NodeID),
+					ResourceName: escalation.ClusterName,
+					Trigger:      "auto",
+					Details: audit.AuditDetails{
+						"cluster_id": escalation.ClusterID,
+						"host_id":    escalation.HostID,
+						"role":       escalation.Role,
+						"attempts":   escalation.Attempts,
+						"last_error": escalation.LastError,
+					},
+				})
+			})
+			clusterService.StartHealthChecker(ctx)
+
+			// Cluster recycle bin: record an audit event with the teardown result of every purged cluster
+			// 集群回收站：为每个被清理的集群记录包含拆除结果的审计事件
+			clusterService.SetOnClusterPurged(func(ctx context.Context, purged *cluster.Cluster, report *cluster.TeardownReport, err error) {
+				details := audit.AuditDetails{
+					"deleted_at":         purged.DeletedAt.Time,
+					"remove_install_dir": purged.PurgeInstallDir,
+				}
+				if report != nil {
+					details["nodes"] = len(report.Nodes)
+					details["failed_nodes"] = report.FailedNodes()
+					details["released_plugins"] = report.ReleasedPlugins
+				}
+				if err != nil {
+					details["error"] = err.Error()
+				}
+				_ = auditRepo.CreateAuditLog(ctx, &audit.AuditLog{
+					Action:       "purge",
+					ResourceType: "cluster",
+					ResourceID:   audit.UintID(purged.ID),
+					ResourceName: purged.Name,
+					Trigger:      "auto",
+					Details:      details,
+				})
+			})
+			clusterService.StartRecyclePurger(ctx)
+
 			clusterHandler := cluster.NewHandler(clusterService, auditRepo)
 
 			clusterRouter := apiV1Router.Group("/clusters")
-			clusterRouter.Use(auth.LoginRequired())
+			clusterRouter.Use(auth.LoginRequired(), auth.RBAC(auth.ResourceCluster, "id"), auth.TenantGuard(auth.ResourceCluster, "id"))
 			{
 				// Cluster CRUD 集群增删改查
 				clusterRouter.POST("", clusterHandler.CreateCluster)
+				clusterRouter.POST("/adopt", clusterHandler.AdoptCluster)
 				clusterRouter.GET("", clusterHandler.ListClusters)
+				clusterRouter.GET("/recycle-bin", clusterHandler.ListRecycledClusters)
+				clusterRouter.DELETE("/recycle-bin/:id", clusterHandler.PurgeCluster)
 				clusterRouter.GET("/:id", clusterHandler.GetCluster)
 				clusterRouter.PUT("/:id", clusterHandler.UpdateCluster)
 				clusterRouter.DELETE("/:id", clusterHandler.DeleteCluster)
+				clusterRouter.POST("/:id/restore", clusterHandler.RestoreCluster)
 
 				// Node management 节点管理
 				clusterRouter.POST("/:id/nodes", clusterHandler.AddNode)
@@ -237,18 +428,28 @@ func Serve() {
 				clusterRouter.PUT("/:id/nodes/:nodeId", clusterHandler.UpdateNode)
 				clusterRouter.DELETE("/:id/nodes/:nodeId", clusterHandler.RemoveNode)
 				clusterRouter.POST("/:id/nodes/precheck", clusterHandler.PrecheckNode)
+				clusterRouter.POST("/:id/precheck", clusterHandler.PrecheckCluster)
 
 				// Node operations 节点操作
 				clusterRouter.POST("/:id/nodes/:nodeId/start", clusterHandler.StartNode)
 				clusterRouter.POST("/:id/nodes/:nodeId/stop", clusterHandler.StopNode)
 				clusterRouter.POST("/:id/nodes/:nodeId/restart", clusterHandler.RestartNode)
 				clusterRouter.GET("/:id/nodes/:nodeId/logs", clusterHandler.GetNodeLogs)
+				clusterRouter.POST("/:id/nodes/:nodeId/diagnose", commandRateLimit, clusterHandler.DiagnoseNode)
+				clusterRouter.POST("/:id/nodes/:nodeId/decommission", clusterHandler.DecommissionNode)
 
 				// Cluster operations 集群操作
 				clusterRouter.POST("/:id/start", clusterHandler.StartCluster)
 				clusterRouter.POST("/:id/stop", clusterHandler.StopCluster)
 				clusterRouter.POST("/:id/restart", clusterHandler.RestartCluster)
 				clusterRouter.GET("/:id/status", clusterHandler.GetClusterStatus)
+				clusterRouter.GET("/:id/health", clusterHandler.GetClusterHealth)
+				clusterRouter.POST("/:id/scale", clusterHandler.StartScale)
+				clusterRouter.GET("/:id/scale/tasks", clusterHandler.ListScaleTasks)
+				clusterRouter.GET("/:id/scale/tasks/:taskId", clusterHandler.GetScaleTask)
+				clusterRouter.GET("/:id/jvm", clusterHandler.GetClusterJVM)
+				clusterRouter.POST("/:id/jvm/preview", clusterHandler.PreviewClusterJVM)
+				clusterRouter.PUT("/:id/jvm", clusterHandler.UpdateClusterJVM)
 				clusterRouter.GET("/:id/seatunnelx-java-proxy/status", clusterHandler.GetSeatunnelXJavaProxyStatus)
 				clusterRouter.GET("/:id/seatunnelx-java-proxy/logs", clusterHandler.PreviewSeatunnelXJavaProxyServiceLog)
 				clusterRouter.POST("/:id/seatunnelx-java-proxy/start", clusterHandler.StartSeatunnelXJavaProxy)
@@ -285,11 +486,21 @@ func Serve() {
 				log.Println("[API] Node provider and config sender injected into monitor service / 节点提供者和配置发送器已注入监控服务")
 			}
 
+			// Cluster overview metrics polled from the engine REST API
+			// 从引擎 REST API 轮询的集群概览指标
+			clusterMetricsService := clustermetrics.NewService(
+				clustermetrics.NewRepository(db.DB(context.Background())),
+				&clusterMetricsProviderAdapter{clusterService: clusterService},
+			)
+			clusterMetricsService.StartCollector(ctx)
+			clusterRouter.GET("/:id/metrics", clustermetrics.NewHandler(clusterMetricsService).GetClusterMetrics)
+
 			// Delete cluster 前：先向各 Agent 推送关闭监控配置（停止监控且不再重启进程），再清理 DB 中的监控配置与事件；cluster.Service 已向各节点 Agent 发送 stop 命令
 			clusterService.SetOnBeforeClusterDelete(func(ctx context.Context, clusterID uint) {
 				monitorService.PushDisableConfigForCluster(ctx, clusterID)
 				_ = monitorService.DeleteConfig(ctx, clusterID)
 				_ = monitorService.DeleteClusterEvents(ctx, clusterID)
+				_ = clusterMetricsService.DeleteClusterMetrics(ctx, clusterID)
 			})
 
 			monitorHandler := monitor.NewHandler(monitorService)
@@ -306,7 +517,7 @@ func Serve() {
 			if err := monitoringService.SyncManagedAlertingArtifacts(ctx); err != nil {
 				log.Printf("[Monitoring] sync managed alerting artifacts failed: %v", err)
 			}
-			monitorService.SetOnEventRecorded(monitoringService.DispatchAlertPolicyEvent)
+			monitorService.SetOnEventRecorded(dispatchProcessEvent(monitoringService))
 			monitoringService.StartNodeHealthEvaluator(ctx)
 			clusterHandler.SetOnOperationExecuted(func(ctx context.Context, event *cluster.OperationEvent) error {
 				if event == nil {
@@ -372,7 +583,15 @@ func Serve() {
 				})
 			})
 			monitoringHandler := monitoringapp.NewHandler(monitoringService)
+			// Object store shared by replicas for packages, plugins and diagnostic bundles
+			// 副本之间共享安装包、插件与诊断包的对象存储
+			objectStoreConfig := config.GetObjectStoreConfig()
+			sharedStore, sharedStoreErr := objstore.New(objectStoreConfig)
+			if sharedStoreErr != nil {
+				log.Printf("[API] Shared object store unavailable / 共享对象存储不可用: %v", sharedStoreErr)
+			}
 			diagnosticsService := diagnostics.NewService(clusterService, monitorService, monitoringService)
+			diagnosticsService.SetBundleStore(objstore.Sub(sharedStore, "diagnostics"))
 			diagnosticsService.SetHostReader(hostService)
 			diagnosticsService.SetAgentCommandSender(&agentCommandSenderAdapter{manager: agentManager})
 			diagnosticsService.StartAutoPolicyRuntime(ctx)
@@ -423,6 +642,15 @@ func Serve() {
 				monitoringRouter.POST("/notification-routes", monitoringHandler.CreateNotificationRoute)
 				monitoringRouter.PUT("/notification-routes/:id", monitoringHandler.UpdateNotificationRoute)
 				monitoringRouter.DELETE("/notification-routes/:id", monitoringHandler.DeleteNotificationRoute)
+				monitoringRouter.GET("/event-subscriptions", monitoringHandler.ListPlatformEventSubscriptions)
+				monitoringRouter.POST("/event-subscriptions", monitoringHandler.CreatePlatformEventSubscription)
+				monitoringRouter.PUT("/event-subscriptions/:id", monitoringHandler.UpdatePlatformEventSubscription)
+				monitoringRouter.DELETE("/event-subscriptions/:id", monitoringHandler.DeletePlatformEventSubscription)
+				monitoringRouter.GET("/heartbeat-rules", monitoringHandler.ListHeartbeatAlertRules)
+				monitoringRouter.POST("/heartbeat-rules", monitoringHandler.CreateHeartbeatAlertRule)
+				monitoringRouter.PUT("/heartbeat-rules/:id", monitoringHandler.UpdateHeartbeatAlertRule)
+				monitoringRouter.DELETE("/heartbeat-rules/:id", monitoringHandler.DeleteHeartbeatAlertRule)
+				monitoringRouter.GET("/heartbeat-alerts", monitoringHandler.ListHeartbeatAlerts)
 			}
 
 			diagnosticsRouter := apiV1Router.Group("/diagnostics")
@@ -491,6 +719,8 @@ func Serve() {
 				AgentBinaryDir:    "./lib/agent",
 				GRPCPort:          fmt.Sprintf("%d", config.GetGRPCPort()),
 				HeartbeatInterval: config.Config.GRPC.HeartbeatInterval,
+				TelemetryEndpoint: config.GetAgentTelemetryEndpoint(),
+				TelemetryInsecure: config.Config.Telemetry.AgentInsecure,
 			})
 
 			agentRouter := apiV1Router.Group("/agent")
@@ -516,6 +746,48 @@ func Serve() {
 				agentRouter.GET("/assets/seatunnelx-java-proxy.sh", agentHandler.DownloadSeatunnelXJavaProxyScript)
 			}
 
+			// SSH 远程部署 Agent API（可选，需在配置中启用 ssh_deploy.enabled）
+			// SSH Agent deployment API (optional, enabled by ssh_deploy.enabled)
+			var metadataCredentials metadata.CredentialStore
+			if config.Config.SSHDeploy.Enabled {
+				sshDeployRepo := sshdeploy.NewRepository(db.DB(context.Background()))
+				sshDeployService := sshdeploy.NewService(sshDeployRepo, hostRepo, &sshdeploy.ServiceConfig{
+					Timeout:       config.GetSSHDeployTimeout(),
+					InstallScript: agentHandler.InstallScript,
+					BinaryPath:    agentHandler.BinaryPath,
+					EnrollmentToken: func(ctx context.Context, hostID uint) (string, error) {
+						token, _, err := hostService.IssueEnrollmentToken(ctx, hostID)
+						return token, err
+					},
+				})
+				if err := sshDeployService.RecoverInterruptedTasks(context.Background()); err != nil {
+					log.Printf("[API] Failed to recover SSH deploy tasks: %v", err)
+				}
+				sshDeployHandler := sshdeploy.NewHandler(sshDeployService, auditRepo)
+				hostRouter.PUT("/:id/ssh-credential", sshDeployHandler.SaveCredential)
+				hostRouter.GET("/:id/ssh-credential", sshDeployHandler.GetCredential)
+				hostRouter.DELETE("/:id/ssh-credential", sshDeployHandler.DeleteCredential)
+				hostRouter.POST("/:id/ssh-deploy", sshDeployHandler.StartDeploy)
+				hostRouter.GET("/:id/ssh-deploy/tasks", sshDeployHandler.ListTasks)
+				hostRouter.GET("/:id/ssh-deploy/tasks/:taskId", sshDeployHandler.GetTask)
+				metadataCredentials = sshDeployService
+			}
+
+			// 控制面元数据导出/导入（仅管理员），用于在环境之间迁移主机、集群、模板与插件记录
+			// Control Plane metadata export/import (admin only) for moving hosts, clusters, templates and plugin records between environments
+			metadataHandler := metadata.NewHandler(metadata.NewService(db.DB(context.Background()), metadataCredentials), auditRepo)
+			metadataRouter := apiV1Router.Group("/admin/metadata")
+			metadataRouter.Use(auth.LoginRequired(), admin.LoginAdminRequired())
+			{
+				// POST /api/v1/admin/metadata/export - 导出元数据包
+				// POST /api/v1/admin/metadata/export - Export a metadata bundle
+				metadataRouter.POST("/export", metadataHandler.Export)
+
+				// POST /api/v1/admin/metadata/import - 导入元数据包
+				// POST /api/v1/admin/metadata/import - Import a metadata bundle
+				metadataRouter.POST("/import", metadataHandler.Import)
+			}
+
 			// SeaTunnelX 离线发布包分发 API（无需认证，供客户机器一键下载安装控制面）。
 			// SeaTunnelX offline release bundle distribution API (no authentication required for one-click control-plane install).
 			releaseBundleHandler := releasebundle.NewHandler(&releasebundle.HandlerConfig{
@@ -550,6 +822,31 @@ func Serve() {
 				// GET /api/v1/commands/:id - 获取命令日志详情
 				// GET /api/v1/commands/:id - Get command log details
 				commandRouter.GET("/:id", auditHandler.GetCommandLog)
+
+				// GET /api/v1/commands/:id/output - 获取命令输出（支持 tail/follow）
+				// GET /api/v1/commands/:id/output - Get command output with tail/follow support
+				commandRouter.GET("/:id/output", auditHandler.GetCommandOutput)
+
+				// POST /api/v1/commands/batch - 在多台主机上批量执行命令
+				// POST /api/v1/commands/batch - Run a command on many hosts
+				if agentManager != nil {
+					batchHandler := agent.NewBatchHandler(agentManager, hostService, auditRepo)
+					commandRouter.POST("/batch", commandRateLimit, auth.RBAC(auth.ResourceGlobal, ""), batchHandler.ExecuteBatch)
+				}
+			}
+
+			// Maintenance scripts 运维脚本（仅管理员）
+			// Maintenance scripts (admin only)
+			if agentManager != nil {
+				scriptHandler := agent.NewScriptHandler(agentManager, hostService, clusterRepo, auditRepo)
+
+				// GET /api/v1/admin/scripts - 获取白名单运维脚本列表
+				// GET /api/v1/admin/scripts - List allow-listed maintenance scripts
+				adminRouter.GET("/scripts", scriptHandler.ListScripts)
+
+				// POST /api/v1/admin/hosts/:id/exec-script - 在主机上执行运维脚本
+				// POST /api/v1/admin/hosts/:id/exec-script - Run a maintenance script on a host
+				adminRouter.POST("/hosts/:id/exec-script", commandRateLimit, scriptHandler.ExecScript)
 			}
 
 			// Audit logs 审计日志
@@ -572,11 +869,32 @@ func Serve() {
 			// so E2E / tests can preload packages into their isolated storage.
 			// 使用配置中的 packages_dir，而不是硬编码仓库路径，
 			// 这样 E2E / 测试才能在各自隔离目录中预热安装包。
+			// Register custom/private download mirrors before any download starts
+			// 在任何下载开始前注册自定义/私有下载镜像
+			downloadMirrors := config.GetDownloadConfig().Mirrors
+			installer.RegisterCustomMirrors(downloadMirrors)
+			plugin.RegisterCustomMirrors(downloadMirrors)
+			installer.RegisterInstallHooks(config.GetInstallerConfig().Hooks)
 			installerService := installer.NewService("", nil)
+			installerService.SetPackageStore(
+				objstore.Sub(sharedStore, "packages"),
+				time.Duration(objectStoreConfig.PresignExpiryMinutes)*time.Minute,
+				objectStoreConfig.PresignAgentDownloads,
+			)
 			// Set host provider for precheck operations
 			// 设置用于预检查操作的主机提供者
 			installerService.SetHostProvider(&hostProviderAdapter{hostService: hostService})
 			installerService.SetNodeJVMResolver(clusterService)
+			// Global installation limits take slots as leases shared by all replicas
+			// 全局安装上限以所有副本共享的租约形式获取名额
+			if haNode != nil {
+				installerService.SetSlotLeaser(haNode.Leases(), haNode.InstanceID())
+				// Installation and download status is shared so any replica can serve it
+				// 共享安装与下载状态，使任一副本都能返回
+				installerService.SetTaskStateStore(installer.NewTaskStateRepository(db.DB(ctx)), haNode.InstanceID())
+				installerService.StartTaskStateSync(ctx, installer.DefaultTaskStateSyncInterval)
+			}
+			overviewService.SetInstallationCounter(installerService.RunningInstallationCount)
 			// Inject agent manager if available
 			// 如果 Agent Manager 可用，注入
 			if agentManager != nil {
@@ -585,11 +903,20 @@ func Serve() {
 					hostService: hostService,
 				})
 			}
+			eventPublisher := &platformEventPublisher{
+				monitoringService: monitoringService,
+				clusterService:    clusterService,
+				hostService:       hostService,
+			}
+			registerEventSubscribers(eventPublisher, auditRepo)
+			installerService.SetTemplateRepository(installer.NewTemplateRepository(db.DB(context.Background())))
+			installerService.SetPrecheckRepository(installer.NewPrecheckRepository(db.DB(context.Background())))
+			installerService.SetVersionPolicyRepository(installer.NewVersionPolicyRepository(db.DB(context.Background())))
 			installerHandler := installer.NewHandler(installerService)
 
 			// Package management routes 安装包管理路由
 			packageRouter := apiV1Router.Group("/packages")
-			packageRouter.Use(auth.LoginRequired())
+			packageRouter.Use(auth.LoginRequired(), auth.RBAC(auth.ResourceGlobal, ""))
 			{
 				// GET /api/v1/packages - 获取可用安装包列表
 				// GET /api/v1/packages - List available packages
@@ -599,17 +926,29 @@ func Serve() {
 				// POST /api/v1/packages/versions/refresh - Refresh version list
 				packageRouter.POST("/versions/refresh", installerHandler.RefreshVersions)
 
+				// GET /api/v1/packages/mirrors - 获取镜像探测结果
+				// GET /api/v1/packages/mirrors - List mirror probe results
+				packageRouter.GET("/mirrors", installerHandler.ListMirrors)
+
 				// GET /api/v1/packages/:version - 获取安装包信息
 				// GET /api/v1/packages/:version - Get package info
 				packageRouter.GET("/:version", installerHandler.GetPackageInfo)
 
 				// POST /api/v1/packages/upload - 上传安装包
 				// POST /api/v1/packages/upload - Upload package
-				packageRouter.POST("/upload", installerHandler.UploadPackage)
+				packageRouter.POST("/upload", uploadRateLimit, installerHandler.UploadPackage)
 
 				// POST /api/v1/packages/upload/chunk - 分片上传安装包
 				// POST /api/v1/packages/upload/chunk - Upload package chunk
-				packageRouter.POST("/upload/chunk", installerHandler.UploadPackageChunk)
+				packageRouter.POST("/upload/chunk", uploadRateLimit, installerHandler.UploadPackageChunk)
+
+				// 可续传上传：初始化、查询偏移量、按偏移量追加分片、完成与放弃
+				// Resumable upload: init, query the offset, append chunks at offsets, complete and abort
+				packageRouter.POST("/uploads", uploadRateLimit, installerHandler.InitPackageUpload)
+				packageRouter.GET("/uploads/:uploadId", installerHandler.GetPackageUpload)
+				packageRouter.PATCH("/uploads/:uploadId", uploadRateLimit, installerHandler.AppendPackageUpload)
+				packageRouter.POST("/uploads/:uploadId/complete", installerHandler.CompletePackageUpload)
+				packageRouter.DELETE("/uploads/:uploadId", installerHandler.AbortPackageUpload)
 
 				// DELETE /api/v1/packages/:version - 删除本地安装包
 				// DELETE /api/v1/packages/:version - Delete local package
@@ -632,10 +971,82 @@ func Serve() {
 				packageRouter.POST("/download/:version/cancel", installerHandler.CancelDownload)
 			}
 
+			// Installation concurrency admin routes 安装并发管理路由（仅管理员）
+			installerAdminRouter := apiV1Router.Group("/admin/installer")
+			installerAdminRouter.Use(auth.LoginRequired(), admin.LoginAdminRequired())
+			{
+				// GET /api/v1/admin/installer/concurrency - 获取安装并发上限与队列
+				// GET /api/v1/admin/installer/concurrency - Get installation concurrency limits and queues
+				installerAdminRouter.GET("/concurrency", installerHandler.GetConcurrency)
+
+				// PUT /api/v1/admin/installer/concurrency - 运行时调整安装并发上限
+				// PUT /api/v1/admin/installer/concurrency - Adjust installation concurrency limits at runtime
+				installerAdminRouter.PUT("/concurrency", installerHandler.UpdateConcurrency)
+
+				// GET /api/v1/admin/installer/version-policy - 获取可安装版本策略
+				// GET /api/v1/admin/installer/version-policy - Get the installable version policy
+				installerAdminRouter.GET("/version-policy", installerHandler.GetVersionPolicy)
+
+				// PUT /api/v1/admin/installer/version-policy - 修改可安装版本策略
+				// PUT /api/v1/admin/installer/version-policy - Update the installable version policy
+				installerAdminRouter.PUT("/version-policy", installerHandler.UpdateVersionPolicy)
+			}
+
+			// Installation template routes 安装模板路由
+			installationTemplateRouter := apiV1Router.Group("/installation-templates")
+			installationTemplateRouter.Use(auth.LoginRequired(), auth.RBAC(auth.ResourceGlobal, ""))
+			{
+				// GET /api/v1/installation-templates - 获取安装模板列表
+				// GET /api/v1/installation-templates - List installation templates
+				installationTemplateRouter.GET("", installerHandler.ListInstallationTemplates)
+
+				// POST /api/v1/installation-templates - 创建安装模板
+				// POST /api/v1/installation-templates - Create installation template
+				installationTemplateRouter.POST("", installerHandler.CreateInstallationTemplate)
+
+				// GET /api/v1/installation-templates/:id - 获取安装模板
+				// GET /api/v1/installation-templates/:id - Get installation template
+				installationTemplateRouter.GET("/:id", installerHandler.GetInstallationTemplate)
+
+				// PUT /api/v1/installation-templates/:id - 更新安装模板
+				// PUT /api/v1/installation-templates/:id - Update installation template
+				installationTemplateRouter.PUT("/:id", installerHandler.UpdateInstallationTemplate)
+
+				// DELETE /api/v1/installation-templates/:id - 删除安装模板
+				// DELETE /api/v1/installation-templates/:id - Delete installation template
+				installationTemplateRouter.DELETE("/:id", installerHandler.DeleteInstallationTemplate)
+			}
+
 			// Task 任务管理
 			// Initialize task manager and handler
 			// 初始化任务管理器和处理器
 			taskManager := task.NewManager()
+			taskManager.SetRepository(task.NewRepository(db.DB(context.Background())))
+			if err := taskManager.RecoverInterrupted(context.Background()); err != nil {
+				log.Printf("[API] Failed to recover interrupted tasks: %v", err)
+			}
+			taskManager.StartCleanup(context.Background(), time.Hour, 24*time.Hour)
+
+			// Agent-backed runners for tasks created through the API
+			// 通过 API 创建的任务使用基于 Agent 的执行器
+			if agentManager != nil {
+				sender := &agentCommandSenderAdapter{manager: agentManager}
+				resolveAgent := (&hostInfoGetterAdapter{hostService: hostService}).GetHostAgentID
+				for taskType, commandType := range map[task.TaskType]string{
+					task.TaskTypePrecheck:    "full",
+					task.TaskTypeStart:       "start",
+					task.TaskTypeStop:        "stop",
+					task.TaskTypeRestart:     "restart",
+					task.TaskTypeCollectLogs: "get_logs",
+				} {
+					taskManager.RegisterRunner(taskType, task.NewAgentCommandRunner(sender, resolveAgent, commandType))
+				}
+			}
+
+			// Long-running installer and cluster operations are tracked as tasks
+			// 长时间运行的安装和集群操作作为任务跟踪
+			installerService.SetTaskManager(taskManager)
+			clusterService.SetTaskManager(taskManager)
 			taskHandler := task.NewHandler(taskManager)
 
 			// Task management routes 任务管理路由
@@ -675,6 +1086,7 @@ func Serve() {
 			syncService.SetExecutionTargetResolver(syncapp.NewDefaultExecutionTargetResolver(clusterRepo, hostRepo))
 			syncService.SetClusterLogProvider(clusterService)
 			syncService.SetClusterVersionProvider(clusterService)
+			syncService.SetMaintenanceChecker(clusterService)
 			syncService.SetConfigToolClient(syncapp.NewDefaultConfigToolClient())
 			syncService.SetConfigToolResolver(syncapp.NewDefaultConfigToolResolver(clusterService))
 			if agentManager != nil {
@@ -749,9 +1161,19 @@ func Serve() {
 			// 初始化插件仓库、服务和处理器
 			pluginRepo := plugin.NewRepository(db.DB(context.Background()))
 			pluginService := plugin.NewService(pluginRepo)
+			pluginService.SetPluginStore(objstore.Sub(sharedStore, "plugins"))
 			// Inject cluster service for version validation
 			// 注入集群服务用于版本校验
 			pluginService.SetClusterGetter(clusterService)
+			// Track cluster plugin installs as tasks
+			// 将集群插件安装作为任务跟踪
+			pluginService.SetTaskManager(taskManager)
+			// Release installed plugin records when a cluster is deleted
+			// 删除集群时释放已安装插件记录
+			clusterService.SetPluginRecordReleaser(pluginService.ReleaseClusterPlugins)
+			// Reject plugin operations on clusters with hosts in maintenance
+			// 拒绝对有主机处于维护模式的集群执行插件操作
+			pluginService.SetMaintenanceChecker(clusterService)
 
 			// Inject agent command sender for plugin installation to cluster nodes
 			// 注入 Agent 命令发送器用于将插件安装到集群节点
@@ -781,7 +1203,7 @@ func Serve() {
 
 			// Plugin marketplace routes 插件市场路由
 			pluginRouter := apiV1Router.Group("/plugins")
-			pluginRouter.Use(auth.LoginRequired())
+			pluginRouter.Use(auth.LoginRequired(), auth.RBAC(auth.ResourceGlobal, ""))
 			{
 				// GET /api/v1/plugins - 获取可用插件列表
 				// GET /api/v1/plugins - List available plugins
@@ -803,6 +1225,34 @@ func Serve() {
 				// POST /api/v1/plugins/download-all - Download all plugins
 				pluginRouter.POST("/download-all", pluginHandler.DownloadAllPlugins)
 
+				// GET /api/v1/plugins/index - 查看离线插件索引
+				// GET /api/v1/plugins/index - Get offline plugin index info
+				pluginRouter.GET("/index", pluginHandler.GetPluginIndex)
+
+				// POST /api/v1/plugins/index/refresh - 刷新离线插件索引
+				// POST /api/v1/plugins/index/refresh - Refresh offline plugin index
+				pluginRouter.POST("/index/refresh", pluginHandler.RefreshPluginIndex)
+
+				// GET /api/v1/plugins/custom - 获取自定义插件列表
+				// GET /api/v1/plugins/custom - List custom plugins
+				pluginRouter.GET("/custom", pluginHandler.ListCustomPlugins)
+
+				// POST /api/v1/plugins/custom - 上传自定义连接器 Jar
+				// POST /api/v1/plugins/custom - Upload a custom connector Jar
+				pluginRouter.POST("/custom", uploadRateLimit, pluginHandler.UploadCustomPlugin)
+
+				// 可续传上传自定义连接器 Jar：初始化、查询偏移量、按偏移量追加分片、完成与放弃
+				// Resumable custom connector Jar upload: init, query the offset, append chunks at offsets, complete and abort
+				pluginRouter.POST("/custom/uploads", uploadRateLimit, pluginHandler.InitCustomPluginUpload)
+				pluginRouter.GET("/custom/uploads/:uploadId", pluginHandler.GetCustomPluginUpload)
+				pluginRouter.PATCH("/custom/uploads/:uploadId", uploadRateLimit, pluginHandler.AppendCustomPluginUpload)
+				pluginRouter.POST("/custom/uploads/:uploadId/complete", pluginHandler.CompleteCustomPluginUpload)
+				pluginRouter.DELETE("/custom/uploads/:uploadId", pluginHandler.AbortCustomPluginUpload)
+
+				// DELETE /api/v1/plugins/custom/:id - 删除自定义插件
+				// DELETE /api/v1/plugins/custom/:id - Delete a custom plugin
+				pluginRouter.DELETE("/custom/:id", pluginHandler.DeleteCustomPlugin)
+
 				// GET /api/v1/plugins/:name - 获取插件详情
 				// GET /api/v1/plugins/:name - Get plugin info
 				pluginRouter.GET("/:name", pluginHandler.GetPluginInfo)
@@ -877,6 +1327,18 @@ func Serve() {
 			// GET /api/v1/clusters/:id/plugins/:name/progress - Get plugin installation progress
 			clusterRouter.GET("/:id/plugins/:name/progress", pluginHandler.GetInstallProgress)
 
+			// POST /api/v1/clusters/:id/plugins/operations - 批量安装/卸载插件
+			// POST /api/v1/clusters/:id/plugins/operations - Bulk install/uninstall plugins
+			clusterRouter.POST("/:id/plugins/operations", pluginHandler.StartPluginOperation)
+
+			// GET /api/v1/clusters/:id/plugins/operations/:opId - 获取批量操作进度
+			// GET /api/v1/clusters/:id/plugins/operations/:opId - Get bulk operation progress
+			clusterRouter.GET("/:id/plugins/operations/:opId", pluginHandler.GetPluginOperation)
+
+			// POST /api/v1/clusters/:id/plugins/operations/:opId/retry - 重试失败节点
+			// POST /api/v1/clusters/:id/plugins/operations/:opId/retry - Retry failed nodes
+			clusterRouter.POST("/:id/plugins/operations/:opId/retry", pluginHandler.RetryPluginOperation)
+
 			// Config 配置文件管理
 			// Initialize config repository, service and handler
 			// 初始化配置仓库、服务和处理器
@@ -885,6 +1347,7 @@ func Serve() {
 			configNodeInfoProvider := &configNodeInfoProviderAdapter{clusterService: clusterService}
 			configService := appconfig.NewService(configRepo, &configHostProviderAdapter{hostService: hostService}, configNodeInfoProvider, configAgentClient)
 			configService.SetPortMetadataUpdater(&configPortMetadataUpdaterAdapter{clusterRepo: clusterRepo})
+			configService.SetClusterRestarter(&configClusterRestarterAdapter{clusterService: clusterService})
 			configHandler := appconfig.NewHandler(configService)
 
 			// Inject config initializer into installer service for initializing configs after installation
@@ -892,6 +1355,31 @@ func Serve() {
 			installerService.SetConfigInitializer(configService)
 			log.Println("[API] Config initializer injected into installer service / 配置初始化器已注入安装服务")
 
+			// Inject installer and stored configs into cluster service for cluster scaling
+			// 将安装服务与已保存配置注入集群服务，用于集群扩缩容
+			clusterService.SetNodeInstaller(installerService)
+			clusterService.SetClusterConfigStore(configService)
+			if err := clusterService.RecoverInterruptedScaleTasks(context.Background()); err != nil {
+				log.Printf("[API] Failed to recover cluster scale tasks: %v", err)
+			}
+
+			// Detect config drift between stored configs and nodes
+			// 检测已保存配置与节点配置之间的漂移
+			clusterService.SetConfigDriftProvider(configService)
+			configService.StartDriftDetector(ctx)
+
+			// Cluster backup and restore of configs and metadata
+			// 集群配置与元数据的备份和恢复
+			backupStorage, backupStorageErr := backup.NewStorage(config.GetBackupConfig())
+			if backupStorageErr != nil {
+				log.Printf("[API] Backup storage unavailable / 备份存储不可用: %v", backupStorageErr)
+			}
+			backupService := backup.NewService(backup.NewRepository(db.DB(context.Background())), clusterRepo, pluginRepo, configService, backupStorage)
+			backupService.SetClusterRestarter(&configClusterRestarterAdapter{clusterService: clusterService})
+			backupService.SetMaintenanceChecker(clusterService)
+			backupService.StartScheduler(ctx)
+			backup.RegisterRoutes(clusterRouter, backup.NewHandler(backupService, auditRepo))
+
 			// Config management routes 配置管理路由
 			appconfig.RegisterRoutes(apiV1Router, configHandler)
 
@@ -902,6 +1390,7 @@ func Serve() {
 			stUpgradeService.SetHostProvider(hostService)
 			stUpgradeService.SetPackageProvider(installerService)
 			stUpgradeService.SetPluginProvider(pluginService)
+			stUpgradeService.SetConnectorBuildChecker(pluginService)
 			stUpgradeService.SetConfigProvider(configService)
 			stUpgradeService.SetClusterOperator(clusterService)
 			stUpgradeService.SetPackageTransferer(installerService)
@@ -911,10 +1400,11 @@ func Serve() {
 					hostService: hostService,
 				})
 			}
+			stUpgradeService.SetOnTaskFinished(eventPublisher.onUpgradeTaskFinished)
 			stUpgradeHandler := stupgrade.NewHandler(stUpgradeService)
 
 			stUpgradeRouter := apiV1Router.Group("/st-upgrade")
-			stUpgradeRouter.Use(auth.LoginRequired())
+			stUpgradeRouter.Use(auth.LoginRequired(), auth.RBAC(auth.ResourceGlobal, ""))
 			{
 				stUpgradeRouter.POST("/precheck", stUpgradeHandler.RunPrecheck)
 				stUpgradeRouter.POST("/plan", stUpgradeHandler.CreatePlan)
@@ -931,11 +1421,24 @@ func Serve() {
 			// POST /api/v1/hosts/:id/precheck - 运行预检查
 			// POST /api/v1/hosts/:id/precheck - Run precheck
 			hostRouter.POST("/:id/precheck", installerHandler.RunPrecheck)
-			apiV1Router.POST("/installer/runtime-storage/validate", auth.LoginRequired(), installerHandler.ValidateRuntimeStorage)
+
+			// GET /api/v1/hosts/:id/precheck/history - 获取预检查历史
+			// GET /api/v1/hosts/:id/precheck/history - List precheck history
+			hostRouter.GET("/:id/precheck/history", installerHandler.ListPrecheckHistory)
+			hostRouter.GET("/:id/precheck/history/:runId", installerHandler.GetPrecheckRun)
+
+			// GET /api/v1/hosts/:id/precheck/diff - 对比预检查记录
+			// GET /api/v1/hosts/:id/precheck/diff - Compare precheck runs
+			hostRouter.GET("/:id/precheck/diff", installerHandler.DiffPrecheckRuns)
+			apiV1Router.POST("/installer/runtime-storage/validate", auth.LoginRequired(), auth.RBAC(auth.ResourceGlobal, ""), installerHandler.ValidateRuntimeStorage)
+
+			// POST /api/v1/installer/resource-advice - 为新集群选定的主机推荐部署方案
+			// POST /api/v1/installer/resource-advice - Recommend a deployment for the hosts of a new cluster
+			apiV1Router.POST("/installer/resource-advice", auth.LoginRequired(), auth.RBAC(auth.ResourceGlobal, ""), installerHandler.AdviseResources)
 
 			// POST /api/v1/hosts/:id/install - 开始安装
 			// POST /api/v1/hosts/:id/install - Start installation
-			hostRouter.POST("/:id/install", installerHandler.StartInstallation)
+			hostRouter.POST("/:id/install", installRateLimit, installerHandler.StartInstallation)
 
 			// GET /api/v1/hosts/:id/install/status - 获取安装状态
 			// GET /api/v1/hosts/:id/install/status - Get installation status
@@ -943,7 +1446,7 @@ func Serve() {
 
 			// POST /api/v1/hosts/:id/install/retry - 重试失败步骤
 			// POST /api/v1/hosts/:id/install/retry - Retry failed step
-			hostRouter.POST("/:id/install/retry", installerHandler.RetryStep)
+			hostRouter.POST("/:id/install/retry", installRateLimit, installerHandler.RetryStep)
 
 			// POST /api/v1/hosts/:id/install/cancel - 取消安装
 			// POST /api/v1/hosts/:id/install/cancel - Cancel installation
@@ -977,10 +1480,82 @@ func Serve() {
 
 	// Serve HTTP API
 	// 启动 HTTP API 服务
-	log.Printf("[API] HTTP 服务器启动于 %s / HTTP server starting on %s\n", config.Config.App.Addr, config.Config.App.Addr)
-	if err := r.Run(config.Config.App.Addr); err != nil {
-		log.Fatalf("[API] serve api failed: %v\n", err)
+	requests := httpdrain.NewTracker()
+	httpSrv := &http.Server{Addr: config.Config.App.Addr, Handler: requests.Wrap(r.Handler())}
+	redirectSrv := startTLS(ctx, httpSrv)
+	serveErr := make(chan error, 1)
+	go func() {
+		if httpSrv.TLSConfig != nil {
+			log.Printf("[API] HTTPS 服务器启动于 %s / HTTPS server starting on %s\n", httpSrv.Addr, httpSrv.Addr)
+			serveErr <- httpSrv.ListenAndServeTLS("", "")
+			return
+		}
+		log.Printf("[API] HTTP 服务器启动于 %s / HTTP server starting on %s\n", httpSrv.Addr, httpSrv.Addr)
+		serveErr <- httpSrv.ListenAndServe()
+	}()
+
+	signalCtx, stopSignals := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
+	defer stopSignals()
+	select {
+	case err := <-serveErr:
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatalf("[API] serve api failed: %v\n", err)
+		}
+		return
+	case <-signalCtx.Done():
+	}
+	// 恢复默认信号处理：再次收到信号时立即退出，不再等待排空
+	// Restore default signal handling so a second signal exits immediately instead of waiting for the drain
+	stopSignals()
+
+	gracefulShutdown(ctx, shutdownTargets{
+		httpSrv:      httpSrv,
+		redirectSrv:  redirectSrv,
+		requests:     requests,
+		grpcSrv:      grpcSrv,
+		agentManager: agentManager,
+		haNode:       haNode,
+	})
+}
+
+// startTLS prepares httpSrv for HTTPS when app.tls is enabled: it loads the certificate, watches it
+// for rotation and starts the optional HTTP to HTTPS redirect listener, which is returned.
+// startTLS 在启用 app.tls 时为 httpSrv 准备 HTTPS：加载证书、监听证书轮换，
+// 并启动可选的 HTTP 到 HTTPS 重定向监听（作为返回值）。
+func startTLS(ctx context.Context, httpSrv *http.Server) *http.Server {
+	tlsConfig := config.Config.App.TLS
+	if !tlsConfig.Enabled {
+		return nil
+	}
+
+	reloader, err := tlsx.NewCertReloader(tlsConfig.CertFile, tlsConfig.KeyFile)
+	if err != nil {
+		log.Fatalf("[API] 加载 TLS 证书失败: %v / Failed to load TLS certificate: %v\n", err, err)
 	}
+	httpSrv.TLSConfig = reloader.TLSConfig()
+	reloader.Watch(ctx, time.Duration(tlsConfig.ReloadInterval)*time.Second, func(reloaded bool, err error) {
+		if err != nil {
+			log.Printf("[API] 重新加载 TLS 证书失败，继续使用旧证书: %v / Failed to reload TLS certificate, keeping the previous one: %v\n", err, err)
+			return
+		}
+		log.Println("[API] TLS 证书已重新加载 / TLS certificate reloaded")
+	})
+
+	if tlsConfig.RedirectAddr == "" {
+		return nil
+	}
+	redirectSrv := &http.Server{
+		Addr:              tlsConfig.RedirectAddr,
+		Handler:           httpsRedirectHandler(httpSrv.Addr),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		log.Printf("[API] HTTP 重定向服务器启动于 %s / HTTP redirect server starting on %s\n", redirectSrv.Addr, redirectSrv.Addr)
+		if err := redirectSrv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Printf("[API] HTTP 重定向服务器退出: %v / HTTP redirect server stopped: %v\n", err, err)
+		}
+	}()
+	return redirectSrv
 }
 
 // initGRPCServer initializes and starts the gRPC server for Agent communication.
@@ -1001,23 +1576,33 @@ func initGRPCServer(ctx context.Context) (*grpcServer.Server, *agent.Manager) {
 	// Initialize Agent Manager
 	// Requirements: 3.4 - Starts heartbeat timeout detection goroutine
 	agentManager := agent.NewManager(&agent.ManagerConfig{
-		HeartbeatInterval: time.Duration(grpcConfig.HeartbeatInterval) * time.Second,
-		HeartbeatTimeout:  time.Duration(grpcConfig.HeartbeatTimeout) * time.Second,
-		CheckInterval:     5 * time.Second,
+		HeartbeatInterval:       time.Duration(grpcConfig.HeartbeatInterval) * time.Second,
+		HeartbeatTimeout:        time.Duration(grpcConfig.HeartbeatTimeout) * time.Second,
+		CheckInterval:           5 * time.Second,
+		CommandQueueTTL:         time.Duration(grpcConfig.CommandQueueTTL) * time.Second,
+		CommandProgressTimeout:  time.Duration(grpcConfig.CommandProgressTimeout) * time.Second,
+		FileTransferChunkSize:   grpcConfig.FileTransferChunkSizeKB * 1024,
+		FileTransferCompression: grpcConfig.FileTransferCompression,
+		RegistrationRateLimit:   grpcConfig.RegistrationRateLimit,
+		RequireApproval:         grpcConfig.RequireAgentApproval,
 	})
+	agentManager.SetAdmissionStore(agent.NewAdmissionRepository(db.DB(ctx)))
 
 	// 初始化 Host Service 用于 Agent 状态更新
 	// Initialize Host Service for Agent status updates
 	hostRepo := host.NewRepository(db.DB(ctx))
 	clusterRepo := cluster.NewRepository(db.DB(ctx))
 	hostService := host.NewService(hostRepo, clusterRepo, &host.ServiceConfig{
-		HeartbeatTimeout: time.Duration(grpcConfig.HeartbeatTimeout) * time.Second,
-		ControlPlaneAddr: config.GetExternalURL(),
+		HeartbeatTimeout:       time.Duration(grpcConfig.HeartbeatTimeout) * time.Second,
+		ControlPlaneAddr:       config.GetExternalURL(),
+		EnrollmentTokenTTL:     time.Duration(grpcConfig.EnrollmentTokenTTL) * time.Minute,
+		RequireEnrollmentToken: !grpcConfig.AllowTokenlessRegistration && !grpcConfig.RequireAgentApproval,
 	})
 
 	// 设置 Host 状态更新器
 	// Set Host status updater
-	agentManager.SetHostUpdater(&hostStatusUpdaterAdapter{hostService: hostService})
+	hostUpdater := &hostStatusUpdaterAdapter{hostService: hostService}
+	agentManager.SetHostUpdater(hostUpdater)
 
 	// 初始化 Audit Repository 用于日志记录
 	// Initialize Audit Repository for logging
@@ -1035,6 +1620,11 @@ func initGRPCServer(ctx context.Context) (*grpcServer.Server, *agent.Manager) {
 		MaxSendMsgSize:    grpcConfig.MaxSendMsgSize * 1024 * 1024, // MB to bytes
 		HeartbeatInterval: grpcConfig.HeartbeatInterval,
 	}
+	if redactionPolicy, err := audit.NewRedactionPolicy(config.GetAuditConfig().Redaction.SensitiveKeys); err != nil {
+		log.Printf("[gRPC] 命令参数脱敏配置无效: %v / Invalid command parameter redaction config: %v\n", err, err)
+	} else {
+		serverConfig.RedactionPolicy = redactionPolicy
+	}
 
 	// 创建并启动 gRPC 服务器
 	// Create and start gRPC server
@@ -1052,7 +1642,8 @@ func initGRPCServer(ctx context.Context) (*grpcServer.Server, *agent.Manager) {
 	if err := monitoringService.SyncManagedAlertingArtifacts(ctx); err != nil {
 		log.Printf("[Monitoring] sync managed alerting artifacts failed: %v", err)
 	}
-	monitorService.SetOnEventRecorded(monitoringService.DispatchAlertPolicyEvent)
+	monitorService.SetOnEventRecorded(dispatchProcessEvent(monitoringService))
+	hostUpdater.monitoringService = monitoringService
 	grpcServer.SetClusterNodeProvider(&grpcClusterNodeProviderAdapter{
 		clusterService: clusterService,
 		monitorService: monitorService,
@@ -1085,7 +1676,8 @@ func initGRPCServer(ctx context.Context) (*grpcServer.Server, *agent.Manager) {
 // hostStatusUpdaterAdapter adapts host.Service to agent.HostStatusUpdater interface.
 // hostStatusUpdaterAdapter 将 host.Service 适配到 agent.HostStatusUpdater 接口。
 type hostStatusUpdaterAdapter struct {
-	hostService *host.Service
+	hostService       *host.Service
+	monitoringService *monitoringapp.Service
 }
 
 // UpdateAgentStatus updates the agent status for a host by IP address.
@@ -1111,8 +1703,30 @@ func (a *hostStatusUpdaterAdapter) UpdateAgentStatus(ctx context.Context, ipAddr
 
 // UpdateHeartbeat updates the heartbeat data for a host.
 // UpdateHeartbeat 更新主机的心跳数据。
+// Heartbeat metrics are then evaluated against heartbeat alert rules.
+// 随后使用心跳告警规则评估心跳指标。
 func (a *hostStatusUpdaterAdapter) UpdateHeartbeat(ctx context.Context, agentID string, cpuUsage, memoryUsage, diskUsage float64) error {
-	return a.hostService.UpdateHeartbeat(ctx, agentID, cpuUsage, memoryUsage, diskUsage)
+	if err := a.hostService.UpdateHeartbeat(ctx, agentID, cpuUsage, memoryUsage, diskUsage); err != nil {
+		return err
+	}
+	if a.monitoringService == nil {
+		return nil
+	}
+	h, err := a.hostService.GetByAgentID(ctx, agentID)
+	if err != nil || h == nil {
+		return nil
+	}
+	if err := a.monitoringService.EvaluateHeartbeatMetrics(ctx, &monitoringapp.HeartbeatSample{
+		HostID:      h.ID,
+		HostName:    h.Name,
+		CPUUsage:    cpuUsage,
+		MemoryUsage: memoryUsage,
+		DiskUsage:   diskUsage,
+		ObservedAt:  time.Now().UTC(),
+	}); err != nil {
+		log.Printf("[Monitoring] evaluate heartbeat alert rules failed: host=%d err=%v / 心跳告警规则评估失败", h.ID, err)
+	}
+	return nil
 }
 
 // MarkHostOffline marks a host as offline by agent ID.
@@ -1148,17 +1762,26 @@ func (a *agentCommandSenderAdapter) SendCommand(ctx context.Context, agentID str
 	switch commandType {
 	case "get_logs", "thread_dump":
 		timeout = 2 * time.Minute
+	case "diagnose":
+		timeout = 5 * time.Minute
 	case "jvm_dump":
 		timeout = 10 * time.Minute
-	case "pull_config":
+	case "pull_config", "discover_clusters":
 		timeout = 1 * time.Minute
 	}
+	// START/RESTART wait for the node to join the cluster when membership verification is requested
+	// 请求成员校验时，START/RESTART 需等待节点加入集群
+	if params["verify_membership"] == "true" {
+		timeout = 3 * time.Minute
+	}
 
 	// Send command with command-specific timeout
 	// 使用命令级超时发送命令
 	resp, err := a.manager.SendCommand(ctx, agentID, cmdType, params, timeout)
 	if err != nil {
-		return false, "", err
+		// Tag dispatch errors so HTTP handlers report e.g. agent_not_connected
+		// 为下发错误标记错误码，使 HTTP 处理器能返回如 agent_not_connected 的错误码
+		return false, "", errcode.Wrap(agent.ErrorCode(err), err)
 	}
 
 	// Convert response to (bool, string, error)
@@ -1176,7 +1799,7 @@ func (a *agentCommandSenderAdapter) SendCommand(ctx context.Context, agentID str
 // stringToCommandType 将命令类型字符串转换为 pb.CommandType。
 func (a *agentCommandSenderAdapter) stringToCommandType(cmdType string) pb.CommandType {
 	switch cmdType {
-	case "check_port", "check_directory", "check_http", "check_process", "check_java", "check_tcp", "check_path_ready", "stat_path", "cleanup_path", "seatunnelx_java_proxy_probe", "seatunnelx_java_proxy_stat", "seatunnelx_java_proxy_list", "seatunnelx_java_proxy_preview", "seatunnelx_java_proxy_inspect_checkpoint", "seatunnelx_java_proxy_inspect_checkpoint_source_state", "seatunnelx_java_proxy_inspect_imap_wal", "sync_local_run", "sync_local_status", "sync_local_stop", "sync_local_logs", "sync_job_logs", "full":
+	case "check_port", "suggest_ports", "check_directory", "check_http", "check_process", "check_java", "check_disk_space", "check_system", "check_tcp", "check_connectivity", "check_path_ready", "stat_path", "cleanup_path", "validate_checkpoint_storage", "seatunnelx_java_proxy_probe", "seatunnelx_java_proxy_stat", "seatunnelx_java_proxy_list", "seatunnelx_java_proxy_preview", "seatunnelx_java_proxy_inspect_checkpoint", "seatunnelx_java_proxy_inspect_checkpoint_source_state", "seatunnelx_java_proxy_inspect_imap_wal", "sync_local_run", "sync_local_status", "sync_local_stop", "sync_local_logs", "sync_job_logs", "full":
 		return pb.CommandType_PRECHECK
 	case "install":
 		return pb.CommandType_INSTALL
@@ -1198,10 +1821,14 @@ func (a *agentCommandSenderAdapter) stringToCommandType(cmdType string) pb.Comma
 		return pb.CommandType_THREAD_DUMP
 	case "jvm_dump":
 		return pb.CommandType_JVM_DUMP
+	case "diagnose":
+		return pb.CommandType_DIAGNOSE
 	case "pull_config":
 		return pb.CommandType_PULL_CONFIG
 	case "remove_install_dir":
 		return pb.CommandType_REMOVE_INSTALL_DIR
+	case "discover_clusters":
+		return pb.CommandType_DISCOVER_CLUSTERS
 	default:
 		return pb.CommandType_PRECHECK
 	}
@@ -1231,7 +1858,9 @@ func (a *pluginAgentCommandSenderAdapter) SendCommand(ctx context.Context, agent
 
 	resp, err := a.manager.SendCommand(ctx, agentID, cmdType, params, timeout)
 	if err != nil {
-		return false, "", err
+		// Tag dispatch errors so HTTP handlers report e.g. agent_not_connected
+		// 为下发错误标记错误码，使 HTTP 处理器能返回如 agent_not_connected 的错误码
+		return false, "", errcode.Wrap(agent.ErrorCode(err), err)
 	}
 
 	// For transfer_plugin command, RUNNING status means chunk received successfully
@@ -1251,6 +1880,21 @@ func (a *pluginAgentCommandSenderAdapter) SendCommand(ctx context.Context, agent
 	return success, message, nil
 }
 
+// StreamFileToAgent lets the Agent pull a plugin file through FetchFile instead of receiving chunks in commands.
+// StreamFileToAgent 让 Agent 通过 FetchFile 拉取插件文件，而不是在命令中接收数据块。
+func (a *pluginAgentCommandSenderAdapter) StreamFileToAgent(ctx context.Context, agentID string, commandType string, params map[string]string, localPath string) (bool, string, error) {
+	resp, err := a.manager.StreamFileToAgent(ctx, agentID, a.stringToCommandType(commandType), params, localPath, 5*time.Minute, nil)
+	if err != nil {
+		return false, "", err
+	}
+
+	message := resp.Output
+	if resp.Error != "" {
+		message = resp.Error
+	}
+	return resp.Status == pb.CommandStatus_SUCCESS, message, nil
+}
+
 // stringToCommandType converts a command type string to pb.CommandType for plugin operations.
 // stringToCommandType 将命令类型字符串转换为 pb.CommandType 用于插件操作。
 func (a *pluginAgentCommandSenderAdapter) stringToCommandType(cmdType string) pb.CommandType {
@@ -1263,6 +1907,8 @@ func (a *pluginAgentCommandSenderAdapter) stringToCommandType(cmdType string) pb
 		return pb.CommandType_UNINSTALL_PLUGIN
 	case "list_plugins":
 		return pb.CommandType_LIST_PLUGINS
+	case "has_plugin":
+		return pb.CommandType_PRECHECK
 	default:
 		return pb.CommandType_TRANSFER_PLUGIN
 	}
@@ -1331,6 +1977,11 @@ func (a *hostProviderAdapter) GetHostByID(ctx context.Context, hostID uint) (*in
 		AgentID:     h.AgentID,
 		AgentStatus: string(h.AgentStatus),
 		LastSeen:    h.LastHeartbeat,
+		Maintenance: h.Maintenance,
+		CPUCores:    h.CPUCores,
+		TotalMemory: h.TotalMemory,
+		TotalDisk:   h.TotalDisk,
+		DiskUsage:   h.DiskUsage,
 	}, nil
 }
 
@@ -1377,7 +2028,7 @@ func (a *installerAgentManagerAdapter) GetAgentByHostID(hostID uint) (agentID st
 func (a *installerAgentManagerAdapter) SendInstallCommand(ctx context.Context, agentID string, params map[string]string) (commandID string, err error) {
 	// Use async command to allow polling for status updates
 	// 使用异步命令以允许轮询状态更新
-	return a.manager.SendCommandAsync(agentID, pb.CommandType_INSTALL, params, 30*time.Minute)
+	return a.manager.SendCommandAsync(ctx, agentID, pb.CommandType_INSTALL, params, 30*time.Minute)
 }
 
 // GetCommandStatus returns the status of a command.
@@ -1386,6 +2037,12 @@ func (a *installerAgentManagerAdapter) GetCommandStatus(commandID string) (statu
 	return a.manager.GetCommandStatus(commandID)
 }
 
+// CancelCommand cancels a pending or running command.
+// CancelCommand 取消待执行或执行中的命令。
+func (a *installerAgentManagerAdapter) CancelCommand(ctx context.Context, commandID string) error {
+	return a.manager.CancelCommand(ctx, commandID)
+}
+
 // SendCommand sends a command to an agent and returns the result.
 // SendCommand 向 Agent 发送命令并返回结果。
 func (a *installerAgentManagerAdapter) SendCommand(ctx context.Context, agentID string, commandType string, params map[string]string) (success bool, output string, err error) {
@@ -1421,7 +2078,7 @@ func (a *installerAgentManagerAdapter) SendCommand(ctx context.Context, agentID
 // stringToCommandType 将命令类型字符串转换为 pb.CommandType。
 func (a *installerAgentManagerAdapter) stringToCommandType(cmdType string) pb.CommandType {
 	switch cmdType {
-	case "check_port", "check_directory", "check_http", "check_process", "check_java", "check_tcp", "check_path_ready", "stat_path", "cleanup_path", "seatunnelx_java_proxy_probe", "seatunnelx_java_proxy_stat", "seatunnelx_java_proxy_list", "seatunnelx_java_proxy_preview", "seatunnelx_java_proxy_inspect_checkpoint", "seatunnelx_java_proxy_inspect_checkpoint_source_state", "seatunnelx_java_proxy_inspect_imap_wal", "sync_local_run", "sync_local_status", "sync_local_stop", "full":
+	case "check_port", "suggest_ports", "check_directory", "check_http", "check_process", "check_java", "check_disk_space", "check_system", "check_tcp", "check_path_ready", "stat_path", "cleanup_path", "validate_checkpoint_storage", "seatunnelx_java_proxy_probe", "seatunnelx_java_proxy_stat", "seatunnelx_java_proxy_list", "seatunnelx_java_proxy_preview", "seatunnelx_java_proxy_inspect_checkpoint", "seatunnelx_java_proxy_inspect_checkpoint_source_state", "seatunnelx_java_proxy_inspect_imap_wal", "sync_local_run", "sync_local_status", "sync_local_stop", "check_package_cache", "has_plugin", "full":
 		return pb.CommandType_PRECHECK
 	case "install":
 		return pb.CommandType_INSTALL
@@ -1498,6 +2155,35 @@ func (a *installerAgentManagerAdapter) SendTransferPackageCommand(ctx context.Co
 	return success, receivedBytes, localPath, nil
 }
 
+// StreamPackageToAgent lets the Agent pull the whole package through FetchFile and returns its path on the Agent.
+// StreamPackageToAgent 让 Agent 通过 FetchFile 拉取整个安装包，并返回其在 Agent 上的路径。
+func (a *installerAgentManagerAdapter) StreamPackageToAgent(ctx context.Context, agentID, version, fileName, localPath, checksum string, totalSize int64, onProgress func(filestream.Snapshot)) (string, error) {
+	params := map[string]string{
+		"version":    version,
+		"file_name":  fileName,
+		"total_size": fmt.Sprintf("%d", totalSize),
+		"checksum":   checksum,
+	}
+
+	// The whole package is pulled by one command, so allow one hour
+	// 整个安装包由一个命令拉取，因此允许一小时
+	resp, err := a.manager.StreamFileToAgent(ctx, agentID, pb.CommandType_TRANSFER_PACKAGE, params, localPath, time.Hour, onProgress)
+	if err != nil {
+		return "", err
+	}
+	if resp.Status != pb.CommandStatus_SUCCESS {
+		return "", fmt.Errorf("%s", resp.Error)
+	}
+
+	var transferResp struct {
+		LocalPath string `json:"local_path"`
+	}
+	if err := json.Unmarshal([]byte(resp.Output), &transferResp); err != nil {
+		return "", fmt.Errorf("failed to parse transfer response: %w", err)
+	}
+	return transferResp.LocalPath, nil
+}
+
 // ==================== Config Service Adapters 配置服务适配器 ====================
 
 // configHostProviderAdapter adapts host.Service to appconfig.HostProvider interface.
@@ -1515,9 +2201,12 @@ func (a *configHostProviderAdapter) GetHostByID(ctx context.Context, hostID uint
 	}
 
 	return &appconfig.HostInfo{
-		ID:        h.ID,
-		Name:      h.Name,
-		IPAddress: h.IPAddress,
+		ID:          h.ID,
+		Name:        h.Name,
+		IPAddress:   h.IPAddress,
+		CPUCores:    h.CPUCores,
+		TotalMemory: h.TotalMemory,
+		Labels:      h.Labels,
 	}, nil
 }
 
@@ -1612,6 +2301,66 @@ func (a *configAgentClientAdapter) PushConfig(ctx context.Context, hostID uint,
 	return nil
 }
 
+// ApplyConfig applies a validated config file on a host via Agent (UPDATE_CONFIG with the apply_config action)
+// and returns the backup path of the previous file.
+// ApplyConfig 通过 Agent 在主机上应用经校验的配置文件（UPDATE_CONFIG + apply_config 动作），并返回原文件的备份路径。
+func (a *configAgentClientAdapter) ApplyConfig(ctx context.Context, hostID uint, installDir string, configType appconfig.ConfigType, content string) (string, error) {
+	h, err := a.hostService.Get(ctx, hostID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get host: %w", err)
+	}
+
+	if h.AgentID == "" {
+		return "", fmt.Errorf("host %d has no agent", hostID)
+	}
+
+	params := map[string]string{
+		"action":      "apply_config",
+		"install_dir": installDir,
+		"config_type": string(configType),
+		"content":     content,
+	}
+
+	resp, err := a.manager.SendCommand(ctx, h.AgentID, pb.CommandType_UPDATE_CONFIG, params, 30*time.Second)
+	if err != nil {
+		return "", fmt.Errorf("failed to send apply config command: %w", err)
+	}
+
+	if resp.Status != pb.CommandStatus_SUCCESS {
+		return "", fmt.Errorf("apply config failed: %s", resp.Error)
+	}
+
+	var result struct {
+		Success    bool   `json:"success"`
+		Message    string `json:"message"`
+		BackupPath string `json:"backup_path"`
+	}
+	if err := json.Unmarshal([]byte(resp.Output), &result); err != nil {
+		return "", fmt.Errorf("failed to parse apply config response: %w", err)
+	}
+	if !result.Success {
+		return "", fmt.Errorf("apply config failed: %s", result.Message)
+	}
+
+	return result.BackupPath, nil
+}
+
+// configClusterRestarterAdapter adapts cluster.Service to appconfig.ClusterRestarter interface.
+// configClusterRestarterAdapter 将 cluster.Service 适配到 appconfig.ClusterRestarter 接口。
+type configClusterRestarterAdapter struct {
+	clusterService *cluster.Service
+}
+
+// RollingRestartCluster rolling-restarts a cluster with default options.
+// RollingRestartCluster 使用默认参数滚动重启集群。
+func (a *configClusterRestarterAdapter) RollingRestartCluster(ctx context.Context, clusterID uint) (bool, string, error) {
+	result, err := a.clusterService.RollingRestart(ctx, clusterID, nil)
+	if err != nil {
+		return false, "", err
+	}
+	return result.Success, result.Message, nil
+}
+
 // configNodeInfoProviderAdapter adapts cluster.Service to appconfig.NodeInfoProvider interface.
 // configNodeInfoProviderAdapter 将 cluster.Service 适配到 appconfig.NodeInfoProvider 接口。
 type configNodeInfoProviderAdapter struct {
@@ -1956,6 +2705,55 @@ func (a *monitorClusterNodeProviderAdapter) GetNodesByClusterID(ctx context.Cont
 	return result, nil
 }
 
+// clusterMetricsProviderAdapter adapts cluster.Service to clustermetrics.ClusterProvider interface.
+// clusterMetricsProviderAdapter 将 cluster.Service 适配到 clustermetrics.ClusterProvider 接口。
+type clusterMetricsProviderAdapter struct {
+	clusterService *cluster.Service
+}
+
+// ListClusterIDs returns the IDs of all managed clusters.
+// ListClusterIDs 返回所有受管集群的 ID。
+func (a *clusterMetricsProviderAdapter) ListClusterIDs(ctx context.Context) ([]uint, error) {
+	clusters, _, err := a.clusterService.List(ctx, &cluster.ClusterFilter{Page: 1, PageSize: 1000})
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]uint, 0, len(clusters))
+	for _, item := range clusters {
+		if item != nil {
+			ids = append(ids, item.ID)
+		}
+	}
+	return ids, nil
+}
+
+// GetNodeTargets returns the nodes of a cluster with host address and ports.
+// GetNodeTargets 返回集群节点及其主机地址和端口。
+func (a *clusterMetricsProviderAdapter) GetNodeTargets(ctx context.Context, clusterID uint) ([]*clustermetrics.NodeTarget, error) {
+	if _, err := a.clusterService.Get(ctx, clusterID); err != nil {
+		return nil, err
+	}
+	nodes, err := a.clusterService.GetNodes(ctx, clusterID)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*clustermetrics.NodeTarget, 0, len(nodes))
+	for _, node := range nodes {
+		result = append(result, &clustermetrics.NodeTarget{
+			NodeID:        node.ID,
+			HostID:        node.HostID,
+			HostIP:        node.HostIP,
+			Role:          string(node.Role),
+			APIPort:       node.APIPort,
+			HazelcastPort: node.HazelcastPort,
+			WorkerPort:    node.WorkerPort,
+			Running:       node.Status == cluster.NodeStatusRunning,
+			Online:        node.IsOnline,
+		})
+	}
+	return result, nil
+}
+
 // monitorAgentConfigSenderAdapter adapts agent.Manager to monitor.AgentConfigSender interface.
 // monitorAgentConfigSenderAdapter 将 agent.Manager 适配到 monitor.AgentConfigSender 接口。
 type monitorAgentConfigSenderAdapter struct {
@@ -2075,3 +2873,115 @@ func normalizeAPIV1RoutePath(rawPath, fallback string) string {
 	}
 	return path
 }
+
+// dispatchProcessEvent fans one recorded process event out to alert policies and platform event subscriptions.
+// dispatchProcessEvent 将进程事件同时分发给告警策略与平台事件订阅。
+func dispatchProcessEvent(monitoringService *monitoringapp.Service) func(context.Context, *monitor.ProcessEvent) error {
+	return func(ctx context.Context, event *monitor.ProcessEvent) error {
+		_ = monitoringService.PublishProcessEvent(ctx, event)
+		return monitoringService.DispatchAlertPolicyEvent(ctx, event)
+	}
+}
+
+// platformEventPublisher converts module lifecycle hooks into monitoring platform events.
+// platformEventPublisher 将各模块生命周期回调转换为监控平台事件。
+type platformEventPublisher struct {
+	monitoringService *monitoringapp.Service
+	clusterService    *cluster.Service
+	hostService       *host.Service
+}
+
+func (p *platformEventPublisher) onAgentOffline(ctx context.Context, event eventbus.AgentOffline) {
+	hostName := event.Hostname
+	if p.hostService != nil && event.HostID > 0 {
+		if h, err := p.hostService.Get(ctx, event.HostID); err == nil && h != nil {
+			// Hosts in maintenance mode are expected to go offline / 维护模式下的主机预期会离线
+			if h.Maintenance {
+				return
+			}
+			hostName = h.Name
+		}
+	}
+	p.monitoringService.PublishPlatformEvent(&monitoringapp.PlatformEvent{
+		Type:     monitoringapp.PlatformEventAgentOffline,
+		HostID:   event.HostID,
+		HostName: hostName,
+		Title:    "Agent offline / Agent 离线",
+		Message:  fmt.Sprintf("Agent %s (%s) is %s / Agent %s（%s）状态为 %s", event.AgentID, event.IPAddress, event.Status, event.AgentID, event.IPAddress, event.Status),
+		Details: map[string]string{
+			"agent_id":   event.AgentID,
+			"ip_address": event.IPAddress,
+			"status":     event.Status,
+		},
+	})
+}
+
+func (p *platformEventPublisher) onInstallCompleted(ctx context.Context, event eventbus.InstallCompleted) {
+	if event.Success {
+		return
+	}
+	hostID, _ := strconv.ParseUint(event.HostID, 10, 32)
+	hostName := ""
+	if p.hostService != nil && hostID > 0 {
+		if h, err := p.hostService.Get(ctx, uint(hostID)); err == nil && h != nil {
+			hostName = h.Name
+		}
+	}
+	reason := strings.TrimSpace(event.Error)
+	if reason == "" {
+		reason = strings.TrimSpace(event.Message)
+	}
+	p.monitoringService.PublishPlatformEvent(&monitoringapp.PlatformEvent{
+		Type:        monitoringapp.PlatformEventInstallationFailed,
+		ClusterID:   event.ClusterID,
+		ClusterName: p.clusterName(ctx, event.ClusterID),
+		HostID:      uint(hostID),
+		HostName:    hostName,
+		Title:       "SeaTunnel installation failed / SeaTunnel 安装失败",
+		Message:     reason,
+		Details: map[string]string{
+			"installation_id": event.InstallationID,
+			"current_step":    event.CurrentStep,
+			"version":         event.Version,
+		},
+	})
+}
+
+func (p *platformEventPublisher) onUpgradeTaskFinished(ctx context.Context, task *stupgrade.UpgradeTask) {
+	if task == nil {
+		return
+	}
+	clusterID := strconv.FormatUint(uint64(task.ClusterID), 10)
+	event := &monitoringapp.PlatformEvent{
+		Type:        monitoringapp.PlatformEventUpgradeCompleted,
+		ClusterID:   clusterID,
+		ClusterName: p.clusterName(ctx, clusterID),
+		Title:       "SeaTunnel upgrade completed / SeaTunnel 升级完成",
+		Message:     fmt.Sprintf("Upgraded from %s to %s / 已从 %s 升级到 %s", task.SourceVersion, task.TargetVersion, task.SourceVersion, task.TargetVersion),
+		Details: map[string]string{
+			"task_id":        strconv.FormatUint(uint64(task.ID), 10),
+			"source_version": task.SourceVersion,
+			"target_version": task.TargetVersion,
+		},
+	}
+	if task.Status != stupgrade.ExecutionStatusSucceeded {
+		event.Type = monitoringapp.PlatformEventUpgradeFailed
+		event.Title = "SeaTunnel upgrade failed / SeaTunnel 升级失败"
+		event.Message = task.FailureReason
+		event.Details["failure_step"] = string(task.FailureStep)
+		event.Details["rollback_status"] = string(task.RollbackStatus)
+	}
+	p.monitoringService.PublishPlatformEvent(event)
+}
+
+func (p *platformEventPublisher) clusterName(ctx context.Context, clusterID string) string {
+	id, err := strconv.ParseUint(strings.TrimSpace(clusterID), 10, 32)
+	if err != nil || id == 0 || p.clusterService == nil {
+		return ""
+	}
+	clusterInfo, err := p.clusterService.Get(ctx, uint(id))
+	if err != nil || clusterInfo == nil {
+		return ""
+	}
+	return clusterInfo.Name
+}
@@ -0,0 +1,173 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package router
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/seatunnel/seatunnelX/internal/config"
+)
+
+// csrfTokenBytes is the amount of randomness in a CSRF token.
+// csrfTokenBytes 是 CSRF 令牌的随机字节数。
+const csrfTokenBytes = 32
+
+// corsMiddleware applies the configured cross-origin policy and answers preflight requests.
+// corsMiddleware 应用已配置的跨域策略并应答预检请求。
+func corsMiddleware(cors config.CORSConfig, exposeHeaders ...string) gin.HandlerFunc {
+	allowAny := false
+	allowed := make(map[string]struct{}, len(cors.AllowedOrigins))
+	for _, origin := range cors.AllowedOrigins {
+		origin = strings.TrimSuffix(strings.TrimSpace(origin), "/")
+		if origin == "*" {
+			allowAny = true
+			continue
+		}
+		allowed[strings.ToLower(origin)] = struct{}{}
+	}
+	methods := strings.Join(cors.AllowedMethods, ", ")
+	headers := strings.Join(cors.AllowedHeaders, ", ")
+	exposed := strings.Join(exposeHeaders, ", ")
+
+	return func(c *gin.Context) {
+		origin := c.GetHeader("Origin")
+		if origin == "" {
+			c.Next()
+			return
+		}
+		c.Writer.Header().Add("Vary", "Origin")
+		preflight := c.Request.Method == http.MethodOptions && c.GetHeader("Access-Control-Request-Method") != ""
+
+		_, ok := allowed[strings.ToLower(origin)]
+		if !ok && !allowAny {
+			if preflight {
+				c.AbortWithStatus(http.StatusForbidden)
+				return
+			}
+			c.Next()
+			return
+		}
+
+		if ok || cors.AllowCredentials {
+			c.Header("Access-Control-Allow-Origin", origin)
+		} else {
+			c.Header("Access-Control-Allow-Origin", "*")
+		}
+		if cors.AllowCredentials {
+			c.Header("Access-Control-Allow-Credentials", "true")
+		}
+		if exposed != "" {
+			c.Header("Access-Control-Expose-Headers", exposed)
+		}
+		if !preflight {
+			c.Next()
+			return
+		}
+
+		c.Header("Access-Control-Allow-Methods", methods)
+		c.Header("Access-Control-Allow-Headers", headers)
+		if cors.MaxAge > 0 {
+			c.Header("Access-Control-Max-Age", strconv.Itoa(cors.MaxAge))
+		}
+		c.AbortWithStatus(http.StatusNoContent)
+	}
+}
+
+// csrfMiddleware implements double-submit CSRF tokens: every response carries the token cookie
+// (and the same value in the token header for cross-origin clients), and state-changing requests
+// that carry the session cookie must echo it in the token header. Requests with an Authorization
+// header (token-authenticated API clients), without a session cookie, or matching an exempt path
+// (route pattern or path prefix) are not checked.
+// csrfMiddleware 实现双重提交 CSRF 令牌：每个响应都携带令牌 Cookie（跨域客户端可从同名响应头读取），
+// 携带会话 Cookie 的写请求必须在令牌请求头中回传该值。带 Authorization 请求头的请求（令牌认证的 API 客户端）、
+// 未携带会话 Cookie 的请求以及命中豁免路径（路由模式或路径前缀）的请求不做校验。
+func csrfMiddleware(csrf config.CSRFConfig, sessionCookieName string, secure bool, exemptPaths ...string) gin.HandlerFunc {
+	exempt := append(append([]string(nil), exemptPaths...), csrf.ExemptPaths...)
+
+	return func(c *gin.Context) {
+		token, _ := c.Cookie(csrf.CookieName)
+		issued := false
+		if !validCSRFToken(token) {
+			token = newCSRFToken()
+			issued = true
+			http.SetCookie(c.Writer, &http.Cookie{
+				Name:     csrf.CookieName,
+				Value:    token,
+				Path:     "/",
+				Secure:   secure,
+				SameSite: http.SameSiteLaxMode,
+			})
+		}
+		c.Header(csrf.HeaderName, token)
+
+		if !csrfChecked(c, sessionCookieName, exempt) {
+			c.Next()
+			return
+		}
+		header := c.GetHeader(csrf.HeaderName)
+		if issued || subtle.ConstantTimeCompare([]byte(header), []byte(token)) != 1 {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+				"error_msg": "CSRF token missing or invalid / CSRF 令牌缺失或无效",
+				"data":      nil,
+			})
+			return
+		}
+		c.Next()
+	}
+}
+
+// csrfChecked reports whether the request needs a valid CSRF token.
+// csrfChecked 判断请求是否需要有效的 CSRF 令牌。
+func csrfChecked(c *gin.Context, sessionCookieName string, exempt []string) bool {
+	switch c.Request.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace:
+		return false
+	}
+	if c.GetHeader("Authorization") != "" {
+		return false
+	}
+	if _, err := c.Cookie(sessionCookieName); err != nil {
+		return false
+	}
+	for _, path := range exempt {
+		if path != "" && (c.FullPath() == path || strings.HasPrefix(c.Request.URL.Path, path)) {
+			return false
+		}
+	}
+	return true
+}
+
+func newCSRFToken() string {
+	buf := make([]byte, csrfTokenBytes)
+	_, _ = rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
+
+func validCSRFToken(token string) bool {
+	if len(token) != csrfTokenBytes*2 {
+		return false
+	}
+	_, err := hex.DecodeString(token)
+	return err == nil
+}
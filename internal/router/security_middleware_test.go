@@ -0,0 +1,143 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/seatunnel/seatunnelX/internal/config"
+)
+
+func newSecurityTestEngine() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(corsMiddleware(config.CORSConfig{
+		AllowedOrigins:   []string{"https://ops.example.com"},
+		AllowedMethods:   []string{"GET", "POST"},
+		AllowedHeaders:   []string{"Content-Type", "X-CSRF-Token"},
+		AllowCredentials: true,
+		MaxAge:           600,
+	}, "X-CSRF-Token"))
+	r.Use(csrfMiddleware(config.CSRFConfig{CookieName: "csrf", HeaderName: "X-CSRF-Token", ExemptPaths: []string{"/hooks/"}},
+		"session", false, "/proxy/:id/*path"))
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	r.GET("/items", ok)
+	r.POST("/items", ok)
+	r.POST("/hooks/alert", ok)
+	r.POST("/proxy/:id/*path", ok)
+	return r
+}
+
+func TestCSRFMiddlewareDoubleSubmit(t *testing.T) {
+	r := newSecurityTestEngine()
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/items", nil))
+	cookies := w.Result().Cookies()
+	if w.Code != http.StatusOK || len(cookies) != 1 || cookies[0].Name != "csrf" || cookies[0].HttpOnly {
+		t.Fatalf("expected a readable csrf cookie on GET, got code=%d cookies=%v", w.Code, cookies)
+	}
+	token := cookies[0].Value
+	if w.Header().Get("X-CSRF-Token") != token {
+		t.Fatalf("expected token header to mirror cookie")
+	}
+
+	post := func(path, header string, withSession bool, extra map[string]string) int {
+		req := httptest.NewRequest(http.MethodPost, path, nil)
+		req.AddCookie(&http.Cookie{Name: "csrf", Value: token})
+		if withSession {
+			req.AddCookie(&http.Cookie{Name: "session", Value: "s"})
+		}
+		if header != "" {
+			req.Header.Set("X-CSRF-Token", header)
+		}
+		for k, v := range extra {
+			req.Header.Set(k, v)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	if code := post("/items", "", true, nil); code != http.StatusForbidden {
+		t.Fatalf("expected 403 without token header, got %d", code)
+	}
+	if code := post("/items", "deadbeef", true, nil); code != http.StatusForbidden {
+		t.Fatalf("expected 403 with wrong token, got %d", code)
+	}
+	if code := post("/items", token, true, nil); code != http.StatusOK {
+		t.Fatalf("expected 200 with matching token, got %d", code)
+	}
+	if code := post("/items", "", false, nil); code != http.StatusOK {
+		t.Fatalf("expected requests without session cookie to be exempt, got %d", code)
+	}
+	if code := post("/items", "", true, map[string]string{"Authorization": "Bearer abc"}); code != http.StatusOK {
+		t.Fatalf("expected token-authenticated requests to be exempt, got %d", code)
+	}
+	if code := post("/hooks/alert", "", true, nil); code != http.StatusOK {
+		t.Fatalf("expected exempt path prefix to skip the check, got %d", code)
+	}
+	if code := post("/proxy/7/api/ds/query", "", true, nil); code != http.StatusOK {
+		t.Fatalf("expected exempt route pattern to skip the check, got %d", code)
+	}
+}
+
+func TestCORSMiddleware(t *testing.T) {
+	r := newSecurityTestEngine()
+
+	req := httptest.NewRequest(http.MethodOptions, "/items", nil)
+	req.Header.Set("Origin", "https://ops.example.com")
+	req.Header.Set("Access-Control-Request-Method", "POST")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("expected 204 preflight, got %d", w.Code)
+	}
+	for header, want := range map[string]string{
+		"Access-Control-Allow-Origin":      "https://ops.example.com",
+		"Access-Control-Allow-Credentials": "true",
+		"Access-Control-Allow-Methods":     "GET, POST",
+		"Access-Control-Allow-Headers":     "Content-Type, X-CSRF-Token",
+		"Access-Control-Max-Age":           "600",
+		"Access-Control-Expose-Headers":    "X-CSRF-Token",
+	} {
+		if got := w.Header().Get(header); got != want {
+			t.Errorf("%s = %q, want %q", header, got, want)
+		}
+	}
+
+	req = httptest.NewRequest(http.MethodOptions, "/items", nil)
+	req.Header.Set("Origin", "https://evil.example.com")
+	req.Header.Set("Access-Control-Request-Method", "POST")
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusForbidden || w.Header().Get("Access-Control-Allow-Origin") != "" {
+		t.Fatalf("expected disallowed origin preflight to be rejected, got %d", w.Code)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/items", nil)
+	req.Header.Set("Origin", "https://evil.example.com")
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusOK || w.Header().Get("Access-Control-Allow-Origin") != "" {
+		t.Fatalf("expected simple request from disallowed origin to get no CORS headers")
+	}
+}
@@ -0,0 +1,101 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package router
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/seatunnel/seatunnelX/internal/apps/agent"
+	"github.com/seatunnel/seatunnelX/internal/apps/ha"
+	"github.com/seatunnel/seatunnelX/internal/apps/health"
+	"github.com/seatunnel/seatunnelX/internal/config"
+	grpcServer "github.com/seatunnel/seatunnelX/internal/grpc"
+	"github.com/seatunnel/seatunnelX/internal/pkg/httpdrain"
+)
+
+// shutdownTargets are the components stopped by gracefulShutdown; nil ones are skipped.
+// shutdownTargets 是 gracefulShutdown 需要停止的组件，为 nil 的组件会被跳过。
+type shutdownTargets struct {
+	httpSrv      *http.Server
+	redirectSrv  *http.Server
+	requests     *httpdrain.Tracker
+	grpcSrv      *grpcServer.Server
+	agentManager *agent.Manager
+	haNode       *ha.Node
+}
+
+// gracefulShutdown stops the Control Plane in dependency order:
+//  1. fail the readiness probe and end streams so load balancers stop routing work here;
+//  2. stop accepting HTTP connections and wait for in-flight requests such as package uploads and
+//     downloads, while gRPC and the Agent manager stay up because those requests may still reach Agents;
+//  3. drain in-flight Agent commands and hint Agents to reconnect with jitter;
+//  4. release HA ownership, then stop the Agent manager and finally the gRPC server.
+//
+// gracefulShutdown 按依赖顺序停止 Control Plane：
+//  1. 使就绪探针失败并结束推送流，让负载均衡器不再转发新请求；
+//  2. 停止接受 HTTP 连接并等待在途请求（如安装包上传与下载）完成，期间 gRPC 与 Agent Manager 保持运行，
+//     因为这些请求可能仍需访问 Agent；
+//  3. 排空 Agent 在途命令，并提示 Agent 带抖动地错峰重连；
+//  4. 释放高可用归属，然后停止 Agent Manager，最后停止 gRPC 服务器。
+func gracefulShutdown(ctx context.Context, targets shutdownTargets) {
+	log.Println("[API] 收到关闭信号，开始优雅关闭 / Shutdown signal received, shutting down gracefully")
+	health.SetShuttingDown()
+	targets.requests.BeginDrain()
+
+	httpCtx, cancel := context.WithTimeout(ctx, time.Duration(config.Config.App.ShutdownTimeout)*time.Second)
+	defer cancel()
+	if requests, streams := targets.requests.Active(); requests > 0 {
+		log.Printf("[API] 等待 %d 个在途请求完成（含 %d 个推送流） / Waiting for %d in-flight requests (%d streams)\n", requests, streams, requests, streams)
+	}
+	if targets.redirectSrv != nil {
+		_ = targets.redirectSrv.Shutdown(httpCtx)
+	}
+	err := targets.httpSrv.Shutdown(httpCtx)
+	if err == nil {
+		// Shutdown does not track hijacked connections, so wait for WebSocket handlers separately.
+		// Shutdown 不跟踪被接管的连接，因此单独等待 WebSocket 处理器退出。
+		err = targets.requests.Wait(httpCtx)
+	}
+	if err != nil {
+		requests, _ := targets.requests.Active()
+		log.Printf("[API] 等待在途请求超时，强制中断剩余 %d 个请求: %v / Timed out waiting for in-flight requests, aborting %d remaining: %v\n", requests, err, requests, err)
+		_ = targets.httpSrv.Close()
+	}
+
+	if targets.grpcSrv != nil {
+		grpcConfig := config.GetGRPCConfig()
+		targets.grpcSrv.Drain(ctx, grpcServer.DrainConfig{
+			Timeout:         time.Duration(grpcConfig.DrainTimeout) * time.Second,
+			ReconnectDelay:  time.Duration(grpcConfig.ReconnectDelay) * time.Second,
+			ReconnectJitter: time.Duration(grpcConfig.ReconnectJitter) * time.Second,
+		})
+	}
+	if targets.haNode != nil {
+		targets.haNode.Stop(ctx)
+	}
+	if targets.agentManager != nil {
+		targets.agentManager.Stop()
+	}
+	if targets.grpcSrv != nil {
+		targets.grpcSrv.Stop()
+	}
+	log.Println("[API] 优雅关闭完成 / Graceful shutdown completed")
+}
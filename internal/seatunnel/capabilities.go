@@ -53,6 +53,49 @@ const (
 	DefaultInstallerSlotAllocationStrategy = "RANDOM"
 )
 
+// Capability names one version-gated configuration step or installer option.
+// Capability 表示一个受版本控制的配置步骤或安装选项。
+type Capability string
+
+const (
+	CapabilityDynamicSlot             Capability = "dynamic_slot"
+	CapabilitySlotNum                 Capability = "slot_num"
+	CapabilityHistoryJobExpireMinutes Capability = "history_job_expire_minutes"
+	CapabilityScheduledDeletionEnable Capability = "scheduled_deletion_enable"
+	CapabilityJobScheduleStrategy     Capability = "job_schedule_strategy"
+	CapabilitySlotAllocationStrategy  Capability = "slot_allocation_strategy"
+	CapabilityHTTPService             Capability = "http_service"
+	CapabilityJobLogMode              Capability = "job_log_mode"
+)
+
+// capabilityMinVersions is the version compatibility matrix: the first SeaTunnel
+// release whose config files accept each capability.
+// capabilityMinVersions 是版本兼容矩阵：每项能力对应的首个支持该配置的 SeaTunnel 版本。
+var capabilityMinVersions = map[Capability]string{
+	CapabilityDynamicSlot:             "2.3.0",
+	CapabilitySlotNum:                 "2.3.0",
+	CapabilityHistoryJobExpireMinutes: "2.3.3",
+	CapabilityJobLogMode:              "2.3.8",
+	CapabilityScheduledDeletionEnable: "2.3.9",
+	CapabilityJobScheduleStrategy:     "2.3.9",
+	CapabilityHTTPService:             "2.3.9",
+	CapabilitySlotAllocationStrategy:  "2.3.10",
+}
+
+// MinVersionFor returns the first SeaTunnel version supporting the capability,
+// or an empty string for unknown capabilities.
+// MinVersionFor 返回支持该能力的首个 SeaTunnel 版本，未知能力返回空字符串。
+func MinVersionFor(capability Capability) string {
+	return capabilityMinVersions[capability]
+}
+
+// SupportsCapability reports whether the SeaTunnel version supports the capability.
+// SupportsCapability 判断 SeaTunnel 版本是否支持该能力。
+func SupportsCapability(version string, capability Capability) bool {
+	minVersion, ok := capabilityMinVersions[capability]
+	return ok && CompareVersions(version, minVersion) >= 0
+}
+
 // VersionCapabilities describes which advanced runtime settings are supported by
 // one SeaTunnel release from the install wizard perspective.
 // VersionCapabilities 描述安装向导视角下某个 SeaTunnel 版本支持的高级运行时配置能力。
@@ -73,43 +116,38 @@ type VersionCapabilities struct {
 	DefaultSlotAllocationStrategy   string `json:"default_slot_allocation_strategy"`
 	DefaultHTTPEnabled              bool   `json:"default_http_enabled"`
 	DefaultJobLogMode               string `json:"default_job_log_mode"`
+	// MinVersions lets the UI explain why an option is hidden for this version.
+	// MinVersions 供界面说明某选项在当前版本被隐藏的原因。
+	MinVersions map[Capability]string `json:"min_versions"`
 }
 
 // CapabilitiesForVersion returns the SeaTunnel runtime capability matrix used by
 // the installer and cluster deployment wizard.
 // CapabilitiesForVersion 返回安装器和集群部署向导使用的 SeaTunnel 运行时能力矩阵。
 func CapabilitiesForVersion(version string) VersionCapabilities {
-	capabilities := VersionCapabilities{
-		DefaultDynamicSlot:             DefaultInstallerDynamicSlot,
-		DefaultStaticSlotNum:           DefaultInstallerStaticSlotNum,
-		DefaultHistoryJobExpireMinutes: DefaultInstallerHistoryJobExpireMinutes,
-		DefaultScheduledDeletionEnable: DefaultInstallerScheduledDeletionEnable,
-		DefaultJobScheduleStrategy:     DefaultInstallerJobScheduleStrategy,
-		DefaultSlotAllocationStrategy:  DefaultInstallerSlotAllocationStrategy,
-		DefaultHTTPEnabled:             true,
-		DefaultJobLogMode:              "mixed",
-	}
-
-	if CompareVersions(version, "2.3.0") >= 0 {
-		capabilities.SupportsDynamicSlot = true
-		capabilities.SupportsSlotNum = true
+	minVersions := make(map[Capability]string, len(capabilityMinVersions))
+	for capability, minVersion := range capabilityMinVersions {
+		minVersions[capability] = minVersion
 	}
-	if CompareVersions(version, "2.3.3") >= 0 {
-		capabilities.SupportsHistoryJobExpireMinutes = true
+	return VersionCapabilities{
+		SupportsDynamicSlot:             SupportsCapability(version, CapabilityDynamicSlot),
+		SupportsSlotNum:                 SupportsCapability(version, CapabilitySlotNum),
+		SupportsHistoryJobExpireMinutes: SupportsCapability(version, CapabilityHistoryJobExpireMinutes),
+		SupportsScheduledDeletionEnable: SupportsCapability(version, CapabilityScheduledDeletionEnable),
+		SupportsJobScheduleStrategy:     SupportsCapability(version, CapabilityJobScheduleStrategy),
+		SupportsSlotAllocationStrategy:  SupportsCapability(version, CapabilitySlotAllocationStrategy),
+		SupportsHTTPService:             SupportsCapability(version, CapabilityHTTPService),
+		SupportsJobLogMode:              SupportsCapability(version, CapabilityJobLogMode),
+		DefaultDynamicSlot:              DefaultInstallerDynamicSlot,
+		DefaultStaticSlotNum:            DefaultInstallerStaticSlotNum,
+		DefaultHistoryJobExpireMinutes:  DefaultInstallerHistoryJobExpireMinutes,
+		DefaultScheduledDeletionEnable:  DefaultInstallerScheduledDeletionEnable,
+		DefaultJobScheduleStrategy:      DefaultInstallerJobScheduleStrategy,
+		DefaultSlotAllocationStrategy:   DefaultInstallerSlotAllocationStrategy,
+		DefaultHTTPEnabled:              true,
+		DefaultJobLogMode:               "mixed",
+		MinVersions:                     minVersions,
 	}
-	if CompareVersions(version, "2.3.9") >= 0 {
-		capabilities.SupportsScheduledDeletionEnable = true
-		capabilities.SupportsJobScheduleStrategy = true
-		capabilities.SupportsHTTPService = true
-	}
-	if CompareVersions(version, "2.3.10") >= 0 {
-		capabilities.SupportsSlotAllocationStrategy = true
-	}
-	if CompareVersions(version, "2.3.8") >= 0 {
-		capabilities.SupportsJobLogMode = true
-	}
-
-	return capabilities
 }
 
 // CompareVersions compares two SeaTunnel version strings.
@@ -86,6 +86,22 @@ func TestCapabilitiesForVersion(t *testing.T) {
 	})
 }
 
+func TestSupportsCapability(t *testing.T) {
+	if got := MinVersionFor(CapabilityHTTPService); got != "2.3.9" {
+		t.Fatalf("expected http service to start at 2.3.9, got %q", got)
+	}
+	if SupportsCapability("2.3.8", CapabilityHTTPService) || !SupportsCapability("2.3.9", CapabilityHTTPService) {
+		t.Fatalf("expected http service to be gated at 2.3.9")
+	}
+	if SupportsCapability("2.3.12", Capability("unknown")) {
+		t.Fatalf("expected unknown capability to be unsupported")
+	}
+	capabilities := CapabilitiesForVersion("2.3.8")
+	if capabilities.MinVersions[CapabilitySlotAllocationStrategy] != "2.3.10" {
+		t.Fatalf("expected min versions in capabilities, got %+v", capabilities.MinVersions)
+	}
+}
+
 func TestCompareVersions(t *testing.T) {
 	testCases := []struct {
 		name string
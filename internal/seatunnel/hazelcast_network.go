@@ -0,0 +1,193 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package seatunnel
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// HazelcastRESTEndpointGroups lists the REST endpoint groups Hazelcast accepts in hazelcast.yaml.
+// HazelcastRESTEndpointGroups 列出 hazelcast.yaml 中 Hazelcast 可接受的 REST 端点组。
+var HazelcastRESTEndpointGroups = []string{
+	"CLUSTER_READ",
+	"CLUSTER_WRITE",
+	"HEALTH_CHECK",
+	"HOT_RESTART",
+	"PERSISTENCE",
+	"WAN",
+	"DATA",
+	"CP",
+}
+
+// RequiredHazelcastRESTEndpointGroups are the groups SeaTunnelX needs to submit jobs and read metrics.
+// RequiredHazelcastRESTEndpointGroups 是 SeaTunnelX 提交作业与读取指标所需的端点组。
+var RequiredHazelcastRESTEndpointGroups = []string{"CLUSTER_WRITE", "DATA"}
+
+// NormalizeHazelcastRESTEndpointGroups upper-cases and deduplicates REST endpoint groups, rejecting
+// unknown groups and lists that drop a group SeaTunnelX depends on.
+// NormalizeHazelcastRESTEndpointGroups 将 REST 端点组转为大写并去重，拒绝未知的组以及缺少 SeaTunnelX 依赖组的列表。
+func NormalizeHazelcastRESTEndpointGroups(groups []string) ([]string, error) {
+	known := make(map[string]struct{}, len(HazelcastRESTEndpointGroups))
+	for _, group := range HazelcastRESTEndpointGroups {
+		known[group] = struct{}{}
+	}
+
+	normalized := make([]string, 0, len(groups))
+	seen := make(map[string]struct{}, len(groups))
+	for _, raw := range groups {
+		group := strings.ToUpper(strings.TrimSpace(raw))
+		if group == "" {
+			continue
+		}
+		if _, ok := known[group]; !ok {
+			return nil, fmt.Errorf("unknown hazelcast rest endpoint group %q", raw)
+		}
+		if _, dup := seen[group]; dup {
+			continue
+		}
+		seen[group] = struct{}{}
+		normalized = append(normalized, group)
+	}
+	if len(normalized) == 0 {
+		return nil, nil
+	}
+	for _, group := range RequiredHazelcastRESTEndpointGroups {
+		if _, ok := seen[group]; !ok {
+			return nil, fmt.Errorf("hazelcast rest endpoint group %s is required by SeaTunnelX", group)
+		}
+	}
+	return normalized, nil
+}
+
+// HazelcastInterfacePattern converts an IPv4 CIDR into the wildcard/range form Hazelcast uses for
+// network.interfaces (10.0.0.0/16 -> 10.0.*.*, 10.0.1.16/28 -> 10.0.1.16-31). Values that are already
+// Hazelcast patterns such as 10.0.1.* or 10.0.1.4-18 are validated and returned unchanged.
+// HazelcastInterfacePattern 将 IPv4 CIDR 转换为 Hazelcast network.interfaces 使用的通配/范围形式
+// （10.0.0.0/16 -> 10.0.*.*，10.0.1.16/28 -> 10.0.1.16-31）。已是 Hazelcast 形式的值（如 10.0.1.*、10.0.1.4-18）校验后原样返回。
+func HazelcastInterfacePattern(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "", fmt.Errorf("hazelcast interface must not be empty")
+	}
+	if !strings.Contains(value, "/") {
+		if err := validateHazelcastInterfacePattern(value); err != nil {
+			return "", err
+		}
+		return value, nil
+	}
+
+	_, network, err := net.ParseCIDR(value)
+	if err != nil {
+		return "", fmt.Errorf("invalid hazelcast interface CIDR %q: %v", value, err)
+	}
+	ip := network.IP.To4()
+	if ip == nil {
+		return "", fmt.Errorf("hazelcast interface CIDR %q must be IPv4", value)
+	}
+	ones, _ := network.Mask.Size()
+
+	parts := make([]string, 4)
+	for i := range parts {
+		fixed := ones - i*8
+		switch {
+		case fixed >= 8:
+			parts[i] = strconv.Itoa(int(ip[i]))
+		case fixed <= 0:
+			parts[i] = "*"
+		default:
+			low := int(ip[i])
+			high := low | (1<<(8-fixed) - 1)
+			parts[i] = strconv.Itoa(low) + "-" + strconv.Itoa(high)
+		}
+	}
+	return strings.Join(parts, "."), nil
+}
+
+// validateHazelcastInterfacePattern checks a dotted IPv4 pattern whose octets are numbers, ranges or "*".
+// validateHazelcastInterfacePattern 校验由数字、范围或 "*" 组成的点分 IPv4 模式。
+func validateHazelcastInterfacePattern(value string) error {
+	octets := strings.Split(value, ".")
+	if len(octets) != 4 {
+		return fmt.Errorf("invalid hazelcast interface %q: expected an IPv4 address, pattern or CIDR", value)
+	}
+	for _, octet := range octets {
+		if octet == "*" {
+			continue
+		}
+		low, high, isRange := strings.Cut(octet, "-")
+		if !isRange {
+			high = low
+		}
+		from, errFrom := strconv.Atoi(low)
+		to, errTo := strconv.Atoi(high)
+		if errFrom != nil || errTo != nil || from < 0 || to > 255 || from > to {
+			return fmt.Errorf("invalid hazelcast interface %q: bad octet %q", value, octet)
+		}
+	}
+	return nil
+}
+
+// ValidateHazelcastAddress checks a host or host:port used as a Hazelcast public or member address.
+// ValidateHazelcastAddress 校验作为 Hazelcast 公共地址或成员地址的 host 或 host:port。
+func ValidateHazelcastAddress(value string) error {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return fmt.Errorf("hazelcast address must not be empty")
+	}
+	if net.ParseIP(value) != nil {
+		return nil
+	}
+
+	host := value
+	if strings.Contains(value, ":") {
+		h, port, err := net.SplitHostPort(value)
+		if err != nil {
+			return fmt.Errorf("invalid hazelcast address %q: %v", value, err)
+		}
+		if n, err := strconv.Atoi(port); err != nil || n < 1 || n > 65535 {
+			return fmt.Errorf("invalid hazelcast address %q: bad port %q", value, port)
+		}
+		host = h
+	}
+	if net.ParseIP(host) != nil {
+		return nil
+	}
+	if host == "" || len(host) > 253 {
+		return fmt.Errorf("invalid hazelcast address %q: bad host", value)
+	}
+	for _, r := range host {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '.') {
+			return fmt.Errorf("invalid hazelcast address %q: bad host", value)
+		}
+	}
+	return nil
+}
+
+// HazelcastAddressHasPort reports whether a validated Hazelcast address carries an explicit port.
+// HazelcastAddressHasPort 判断已校验的 Hazelcast 地址是否带有显式端口。
+func HazelcastAddressHasPort(value string) bool {
+	value = strings.TrimSpace(value)
+	if net.ParseIP(value) != nil {
+		return false
+	}
+	_, _, err := net.SplitHostPort(value)
+	return err == nil
+}
@@ -0,0 +1,84 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package seatunnel
+
+import "testing"
+
+func TestHazelcastInterfacePattern(t *testing.T) {
+	cases := map[string]string{
+		"10.0.0.0/8":     "10.*.*.*",
+		"10.3.0.0/16":    "10.3.*.*",
+		"10.3.16.0/20":   "10.3.16-31.*",
+		"192.168.1.0/24": "192.168.1.*",
+		"192.168.1.5/28": "192.168.1.0-15",
+		"192.168.1.5/32": "192.168.1.5",
+		"10.3.10.4-18":   "10.3.10.4-18",
+		" 10.3.*.* ":     "10.3.*.*",
+	}
+	for input, want := range cases {
+		got, err := HazelcastInterfacePattern(input)
+		if err != nil {
+			t.Fatalf("HazelcastInterfacePattern(%q) returned error: %v", input, err)
+		}
+		if got != want {
+			t.Fatalf("HazelcastInterfacePattern(%q) = %q, want %q", input, got, want)
+		}
+	}
+
+	for _, input := range []string{"", "10.0.0.0/33", "fd00::/64", "10.0.1", "10.0.1.300", "10.0.1.9-3", "eth0"} {
+		if _, err := HazelcastInterfacePattern(input); err == nil {
+			t.Fatalf("expected error for interface %q", input)
+		}
+	}
+}
+
+func TestNormalizeHazelcastRESTEndpointGroups(t *testing.T) {
+	groups, err := NormalizeHazelcastRESTEndpointGroups([]string{" data", "cluster_write", "HEALTH_CHECK", "DATA"})
+	if err != nil {
+		t.Fatalf("expected valid groups, got error: %v", err)
+	}
+	if len(groups) != 3 || groups[0] != "DATA" || groups[1] != "CLUSTER_WRITE" || groups[2] != "HEALTH_CHECK" {
+		t.Fatalf("unexpected groups: %#v", groups)
+	}
+
+	if groups, err := NormalizeHazelcastRESTEndpointGroups([]string{" "}); err != nil || groups != nil {
+		t.Fatalf("expected empty list to keep defaults, got %#v, %v", groups, err)
+	}
+	if _, err := NormalizeHazelcastRESTEndpointGroups([]string{"CLUSTER_WRITE", "DATA", "ADMIN"}); err == nil {
+		t.Fatal("expected error for unknown group")
+	}
+	if _, err := NormalizeHazelcastRESTEndpointGroups([]string{"HEALTH_CHECK", "DATA"}); err == nil {
+		t.Fatal("expected error when CLUSTER_WRITE is dropped")
+	}
+}
+
+func TestValidateHazelcastAddress(t *testing.T) {
+	for _, input := range []string{"203.0.113.10", "203.0.113.10:5801", "st-master.example.com", "node-1:5802", "[2001:db8::1]:5801", "2001:db8::1"} {
+		if err := ValidateHazelcastAddress(input); err != nil {
+			t.Fatalf("expected %q to be valid, got %v", input, err)
+		}
+	}
+	for _, input := range []string{"", "host:0", "host:70000", "bad host", "host:port", "a_b"} {
+		if err := ValidateHazelcastAddress(input); err == nil {
+			t.Fatalf("expected %q to be rejected", input)
+		}
+	}
+	if HazelcastAddressHasPort("203.0.113.10") || !HazelcastAddressHasPort("203.0.113.10:5801") || HazelcastAddressHasPort("2001:db8::1") {
+		t.Fatal("unexpected HazelcastAddressHasPort result")
+	}
+}
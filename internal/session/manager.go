@@ -52,12 +52,13 @@ func initMemoryStore(appConfig config.AppConfig) error {
 	Store = NewMemoryStore()
 
 	ginStore := cookie.NewStore([]byte(appConfig.SessionSecret))
+	// 启用原生 HTTPS 时 Cookie 只能通过 HTTPS 发送
 	ginStore.Options(sessions.Options{
 		Path:     "/",
 		Domain:   appConfig.SessionDomain,
 		MaxAge:   appConfig.SessionAge,
 		HttpOnly: appConfig.SessionHttpOnly,
-		Secure:   appConfig.SessionSecure,
+		Secure:   appConfig.SessionSecure || appConfig.TLS.Enabled,
 	})
 
 	GinStore = ginStore
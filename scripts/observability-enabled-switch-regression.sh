@@ -65,6 +65,9 @@ auth:
   default_admin_password: "admin123"
   bcrypt_cost: 10
 
+secrets:
+  master_key: "regression-master-key"
+
 database:
   enabled: true
   type: "sqlite"
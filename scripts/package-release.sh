@@ -485,6 +485,10 @@ EOF
   done
 done
 
+# Offline plugin index, published next to the packages so control planes can refresh from the release.
+cp "$ROOT_DIR/internal/apps/plugin/seed/plugin-index.json" "$OUTPUT_DIR/plugin-index.json"
+echo "created: $OUTPUT_DIR/plugin-index.json"
+
 echo
 echo "all done."
 echo "output dir: $OUTPUT_DIR"